@@ -0,0 +1,161 @@
+// device-sim 模拟设备压测工具
+//
+// 启动多个模拟设备连接服务端的 WebSocket 接口，按设备协议循环发送预先录制的语音
+// （或以 -text 直接发送文本），统计每轮从说话结束到识别结果、首帧音频和回复结束的
+// 耗时百分位数以及错误率。报告以 JSON 写入 -out 指定的文件（默认标准输出），摘要打印到
+// 标准错误；指定 -max-error-rate 时错误率超出即以非零状态退出，便于在脚本中做容量评估。
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"xiaozhi-server-go/internal/devicesim"
+	"xiaozhi-server-go/internal/domain/protocol"
+	"xiaozhi-server-go/internal/utils"
+)
+
+// errThreshold 错误率超出阈值，报告已输出
+var errThreshold = errors.New("error rate exceeds threshold")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:]); err != nil {
+		if !errors.Is(err, errThreshold) {
+			_, _ = fmt.Fprintf(os.Stderr, "device-sim: %v\n", err)
+		}
+		stop()
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("device-sim", flag.ContinueOnError)
+	url := fs.String("url", envOr("XIAOZHI_WS_URL", "ws://127.0.0.1:8000/"), "服务端 WebSocket 地址")
+	devices := fs.Int("devices", 10, "模拟设备数")
+	turns := fs.Int("turns", 5, "每个设备的对话轮数")
+	audioFile := fs.String("audio", "", "每轮发送的语音文件（WAV 或 MP3），与 -text 二选一")
+	text := fs.String("text", "", "以文本代替语音发送，不经过 ASR")
+	sampleRate := fs.Int("sample-rate", 16000, "上行音频采样率")
+	frameDuration := fs.Int("frame-duration", 60, "上行音频帧时长（毫秒）")
+	realtime := fs.Bool("realtime", true, "按帧时长实时发送音频，false 时尽快发送")
+	rampUp := fs.Duration("ramp-up", 0, "在该时长内均匀启动全部设备")
+	think := fs.Duration("think", time.Second, "同一设备两轮之间的间隔")
+	turnTimeout := fs.Duration("turn-timeout", 30*time.Second, "单轮等待回复结束的超时")
+	connectTimeout := fs.Duration("connect-timeout", 10*time.Second, "建立连接和握手的超时")
+	prefix := fs.String("prefix", "sim-", "设备ID前缀")
+	out := fs.String("out", "-", "JSON 报告输出文件，- 表示标准输出")
+	maxErrorRate := fs.Float64("max-error-rate", -1, "错误率超过该值时以非零状态退出，负数表示不检查")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if (*audioFile == "") == (*text == "") {
+		return fmt.Errorf("需要且只能指定 -audio 或 -text 之一")
+	}
+
+	opts := devicesim.Options{
+		URL:            *url,
+		Devices:        *devices,
+		Turns:          *turns,
+		DeviceIDPrefix: *prefix,
+		RampUp:         *rampUp,
+		ThinkTime:      *think,
+		TurnTimeout:    *turnTimeout,
+		ConnectTimeout: *connectTimeout,
+		Realtime:       *realtime,
+		Text:           *text,
+		AudioParams: protocol.AudioParams{
+			Format:        protocol.CodecOpus,
+			SampleRate:    *sampleRate,
+			Channels:      1,
+			FrameDuration: *frameDuration,
+		},
+	}
+	if *audioFile != "" {
+		packets, duration, err := utils.AudioToOpusFrames(*audioFile, utils.OpusEncoderConfig{
+			SampleRate:    *sampleRate,
+			Channels:      1,
+			FrameDuration: *frameDuration,
+		})
+		if err != nil {
+			return fmt.Errorf("编码语音文件失败: %w", err)
+		}
+		opts.Audio = packets
+		_, _ = fmt.Fprintf(os.Stderr, "语音 %s：%.1f 秒，%d 帧\n", *audioFile, duration, len(packets))
+	}
+
+	_, _ = fmt.Fprintf(os.Stderr, "启动 %d 个模拟设备，每个 %d 轮，连接 %s\n", opts.Devices, opts.Turns, opts.URL)
+	report, err := devicesim.Run(ctx, opts)
+	if err != nil {
+		return err
+	}
+	printSummary(os.Stderr, report)
+	if err := writeReport(*out, report); err != nil {
+		return err
+	}
+	if *maxErrorRate >= 0 && report.ErrorRate > *maxErrorRate {
+		_, _ = fmt.Fprintf(os.Stderr, "错误率 %.2f%% 超过阈值 %.2f%%\n", report.ErrorRate*100, *maxErrorRate*100)
+		return errThreshold
+	}
+	return nil
+}
+
+// writeReport 将报告以 JSON 写入文件或标准输出
+func writeReport(path string, report *devicesim.Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if path == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("写入报告失败: %w", err)
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "报告已写入 %s\n", path)
+	return nil
+}
+
+// printSummary 打印报告摘要
+func printSummary(w io.Writer, r *devicesim.Report) {
+	_, _ = fmt.Fprintf(w, "\n用时 %.1f 秒，已连接设备 %d/%d（重连 %d 次），轮次 %d，成功 %d，失败 %d，错误率 %.2f%%，吞吐 %.2f 轮/秒\n",
+		float64(r.DurationMs)/1000, r.ConnectedDevices, r.Devices, r.Reconnects, r.Turns, r.Succeeded, r.Failed, r.ErrorRate*100, r.TurnsPerSecond)
+	_, _ = fmt.Fprintf(w, "%-12s %8s %8s %8s %8s %8s %8s\n", "阶段(ms)", "样本", "平均", "P50", "P95", "P99", "最大")
+	stages := []struct {
+		name  string
+		stats devicesim.LatencyStats
+	}{
+		{"connect", r.Latency.Connect},
+		{"asr", r.Latency.ASR},
+		{"first_audio", r.Latency.FirstAudio},
+		{"complete", r.Latency.Complete},
+	}
+	for _, stage := range stages {
+		s := stage.stats
+		_, _ = fmt.Fprintf(w, "%-12s %8d %8.0f %8d %8d %8d %8d\n", stage.name, s.Count, s.AvgMs, s.P50Ms, s.P95Ms, s.P99Ms, s.MaxMs)
+	}
+	for _, e := range r.Errors {
+		_, _ = fmt.Fprintf(w, "错误 %s × %d：%s\n", e.Kind, e.Count, e.Example)
+	}
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/plugin/providers/chatglm"
+	"xiaozhi-server-go/internal/plugin/providers/coze"
+	"xiaozhi-server-go/internal/plugin/providers/deepgram"
+	"xiaozhi-server-go/internal/plugin/providers/doubao"
+	"xiaozhi-server-go/internal/plugin/providers/edge"
+	"xiaozhi-server-go/internal/plugin/providers/gosherpa"
+	"xiaozhi-server-go/internal/plugin/providers/ollama"
+	"xiaozhi-server-go/internal/plugin/providers/openai"
+	"xiaozhi-server-go/internal/plugin/providers/stepfun"
+)
+
+// backend 能力的来源：进程内注册表或运行中的服务端
+type backend interface {
+	// describe 在提示信息中显示的来源说明
+	describe() string
+	// capabilities 返回按ID排序的能力定义，以及能力ID到供应商ID的映射
+	capabilities(ctx context.Context) ([]capability.Definition, map[string]string, error)
+	// run 执行一次能力调用，onChunk 在收到流式分片时调用（远程模式不逐片回调）
+	run(ctx context.Context, capabilityID string, config, inputs map[string]interface{}, onChunk func(map[string]interface{})) (*capability.RunResult, error)
+}
+
+// localBackend 在进程内注册内置供应商，与服务端启动时注册的一致
+type localBackend struct {
+	registry *capability.Registry
+}
+
+func newLocalBackend() *localBackend {
+	registry := capability.NewRegistry()
+	registry.Register("chatglm", chatglm.NewProvider())
+	registry.Register("coze", coze.NewProvider())
+	registry.Register("deepgram", deepgram.NewProvider())
+	registry.Register("doubao", doubao.NewProvider())
+	registry.Register("edge", edge.NewProvider())
+	registry.Register("gosherpa", gosherpa.NewProvider())
+	registry.Register("ollama", ollama.NewProvider())
+	registry.Register("openai", openai.NewProvider())
+	registry.Register("stepfun", stepfun.NewProvider())
+	return &localBackend{registry: registry}
+}
+
+func (b *localBackend) describe() string {
+	return "进程内注册表"
+}
+
+func (b *localBackend) capabilities(context.Context) ([]capability.Definition, map[string]string, error) {
+	defs := b.registry.ListCapabilities()
+	providers := make(map[string]string, len(defs))
+	for _, def := range defs {
+		if _, providerID, ok := b.registry.Lookup(def.ID); ok {
+			providers[def.ID] = providerID
+		}
+	}
+	sortDefinitions(defs)
+	return defs, providers, nil
+}
+
+func (b *localBackend) run(ctx context.Context, capabilityID string, config, inputs map[string]interface{}, onChunk func(map[string]interface{})) (*capability.RunResult, error) {
+	exec, err := b.registry.GetExecutor(capabilityID)
+	if err != nil {
+		return nil, err
+	}
+	return capability.Run(ctx, exec, config, inputs, onChunk)
+}
+
+// remoteBackend 通过 /api/v1/workflow/capabilities 在服务端执行
+type remoteBackend struct {
+	server string
+	token  string
+	http   *http.Client
+}
+
+func newRemoteBackend(server, token string, timeout time.Duration) *remoteBackend {
+	return &remoteBackend{
+		server: strings.TrimRight(server, "/"),
+		token:  token,
+		http:   &http.Client{Timeout: timeout + 10*time.Second},
+	}
+}
+
+func (b *remoteBackend) describe() string {
+	return b.server
+}
+
+func (b *remoteBackend) capabilities(ctx context.Context) ([]capability.Definition, map[string]string, error) {
+	var defs []capability.Definition
+	if err := b.call(ctx, http.MethodGet, "/workflow/capabilities", nil, &defs); err != nil {
+		return nil, nil, err
+	}
+	sortDefinitions(defs)
+	// 接口不返回供应商ID，按能力ID前缀推断，仅用于查找配置
+	providers := make(map[string]string, len(defs))
+	for _, def := range defs {
+		providers[def.ID], _, _ = strings.Cut(def.ID, "_")
+	}
+	return defs, providers, nil
+}
+
+func (b *remoteBackend) run(ctx context.Context, capabilityID string, config, inputs map[string]interface{}, _ func(map[string]interface{})) (*capability.RunResult, error) {
+	body := map[string]interface{}{"config": config, "inputs": inputs}
+	if deadline, ok := ctx.Deadline(); ok {
+		body["timeout"] = time.Until(deadline).Round(time.Second).String()
+	}
+	var result struct {
+		Outputs      map[string]interface{} `json:"outputs"`
+		Chunks       int                    `json:"chunks"`
+		FirstChunkMs int64                  `json:"first_chunk_ms"`
+		ElapsedMs    int64                  `json:"elapsed_ms"`
+	}
+	if err := b.call(ctx, http.MethodPost, "/workflow/capabilities/"+url.PathEscape(capabilityID)+"/execute", body, &result); err != nil {
+		return nil, err
+	}
+	return &capability.RunResult{
+		Outputs:    result.Outputs,
+		Chunks:     result.Chunks,
+		FirstChunk: time.Duration(result.FirstChunkMs) * time.Millisecond,
+		Elapsed:    time.Duration(result.ElapsedMs) * time.Millisecond,
+	}, nil
+}
+
+func (b *remoteBackend) call(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, b.server+"/api/v1"+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if b.token != "" {
+		req.Header.Set("AuthorToken", b.token)
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	}
+	resp, err := b.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		Data  json.RawMessage `json:"data"`
+		Error json.RawMessage `json:"error"`
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		var message string
+		var detail struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(envelope.Error, &message) != nil || message == "" {
+			_ = json.Unmarshal(envelope.Error, &detail)
+			message = detail.Message
+		}
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, message)
+	}
+	return json.Unmarshal(envelope.Data, out)
+}
+
+func sortDefinitions(defs []capability.Definition) {
+	sort.Slice(defs, func(i, j int) bool {
+		if defs[i].Type != defs[j].Type {
+			return defs[i].Type < defs[j].Type
+		}
+		return defs[i].ID < defs[j].ID
+	})
+}
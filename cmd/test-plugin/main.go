@@ -0,0 +1,68 @@
+// test-plugin 能力交互式测试工具
+//
+// 列出已注册的能力，按配置和输入 schema 逐项提示输入后执行，并显示输出和耗时。
+// 默认在进程内注册内置供应商直接调用；指定 -server 时改为调用运行中服务端的
+// /api/v1/workflow/capabilities 接口。
+//
+// API Key 等配置不写在代码中，按以下来源合并（后者覆盖前者）：schema 默认值、
+// -config 指定的 JSON 文件（键为供应商ID或能力ID）、环境变量 <供应商ID>_<配置项>
+// （如 OPENAI_API_KEY）、会话内的 set 命令。
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+func main() {
+	server := flag.String("server", os.Getenv("XIAOZHI_SERVER"), "服务端地址，为空时在进程内执行")
+	token := flag.String("token", os.Getenv("XIAOZHI_TOKEN"), "API Token，仅远程模式使用")
+	configPath := flag.String("config", "", "能力配置 JSON 文件，键为供应商ID或能力ID")
+	timeout := flag.Duration("timeout", 60*time.Second, "单次调用超时时间")
+	flag.Parse()
+
+	fileConfig, err := loadConfigFile(*configPath)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "test-plugin: %v\n", err)
+		os.Exit(1)
+	}
+
+	var b backend
+	if *server != "" {
+		b = newRemoteBackend(*server, *token, *timeout)
+	} else {
+		b = newLocalBackend()
+	}
+
+	r := &repl{
+		backend:    b,
+		fileConfig: fileConfig,
+		timeout:    *timeout,
+		prompt:     newPrompter(os.Stdin, os.Stdout),
+		out:        os.Stdout,
+	}
+	if err := r.loop(context.Background()); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "test-plugin: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// loadConfigFile 读取配置文件，格式为 {"openai": {"api_key": "..."}, "openai_llm": {"model": "..."}}
+func loadConfigFile(path string) (map[string]map[string]interface{}, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config map[string]map[string]interface{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("解析 %s 失败: %w", path, err)
+	}
+	return config, nil
+}
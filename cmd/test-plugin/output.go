@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// maxShownLength 超过该长度的字符串只显示开头，避免 base64 音频等刷屏
+const maxShownLength = 512
+
+// table 对齐列输出
+type table struct {
+	w *tabwriter.Writer
+}
+
+func newTable(out io.Writer, headers ...string) *table {
+	t := &table{w: tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)}
+	t.row(headers...)
+	return t
+}
+
+func (t *table) row(cells ...string) {
+	for i, cell := range cells {
+		cells[i] = strings.NewReplacer("\n", " ", "\t", " ").Replace(cell)
+	}
+	_, _ = fmt.Fprintln(t.w, strings.Join(cells, "\t"))
+}
+
+func (t *table) flush() {
+	_ = t.w.Flush()
+}
+
+// printOutputs 按键名排序输出，字符串原样显示，其它值格式化为 JSON
+func printOutputs(out io.Writer, outputs map[string]interface{}) {
+	keys := make([]string, 0, len(outputs))
+	for key := range outputs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(out, "  %s: %s\n", key, formatValue(outputs[key]))
+	}
+}
+
+func formatValue(value interface{}) string {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case []byte:
+		return fmt.Sprintf("<%d 字节>", len(v))
+	default:
+		data, err := json.MarshalIndent(v, "  ", "  ")
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		s = string(data)
+	}
+	if len(s) > maxShownLength {
+		return fmt.Sprintf("%s... (共 %d 字符)", s[:maxShownLength], len(s))
+	}
+	return s
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+// prompter 从输入逐行读取
+type prompter struct {
+	in  *bufio.Scanner
+	out io.Writer
+}
+
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	return &prompter{in: scanner, out: out}
+}
+
+// line 打印提示并读取一行，输入结束时返回 io.EOF
+func (p *prompter) line(prompt string) (string, error) {
+	_, _ = fmt.Fprint(p.out, prompt)
+	if !p.in.Scan() {
+		if err := p.in.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return strings.TrimSpace(p.in.Text()), nil
+}
+
+// orderedProperties 必填项在前，其余按名称排序
+func orderedProperties(schema capability.Schema) []string {
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if required[names[i]] != required[names[j]] {
+			return required[names[i]]
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// promptInputs 按输入 schema 逐项提示；空输入使用默认值，没有默认值的可选项跳过
+func (p *prompter) promptInputs(schema capability.Schema) (map[string]interface{}, error) {
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	inputs := make(map[string]interface{})
+	for _, name := range orderedProperties(schema) {
+		prop := schema.Properties[name]
+		if prop.Type == "channel" {
+			_, _ = fmt.Fprintf(p.out, "  跳过 %s：流式通道类型无法在交互模式中输入\n", name)
+			continue
+		}
+		for {
+			raw, err := p.line(propertyPrompt(name, prop, required[name]))
+			if err != nil {
+				return nil, err
+			}
+			if raw == "" {
+				if prop.Default != nil {
+					inputs[name] = prop.Default
+					break
+				}
+				if required[name] {
+					_, _ = fmt.Fprintln(p.out, "  必填项，请输入")
+					continue
+				}
+				break
+			}
+			value, err := parseValue(name, prop, raw)
+			if err != nil {
+				_, _ = fmt.Fprintf(p.out, "  %v\n", err)
+				continue
+			}
+			inputs[name] = value
+			break
+		}
+	}
+	return inputs, nil
+}
+
+func propertyPrompt(name string, prop capability.Property, required bool) string {
+	var b strings.Builder
+	b.WriteString("  ")
+	b.WriteString(name)
+	b.WriteString(" (")
+	b.WriteString(orDefault(prop.Type, "string"))
+	if required {
+		b.WriteString(", 必填")
+	}
+	b.WriteString(")")
+	if prop.Description != "" {
+		b.WriteString(" ")
+		b.WriteString(prop.Description)
+	}
+	if len(prop.Enum) > 0 {
+		options := make([]string, len(prop.Enum))
+		for i, option := range prop.Enum {
+			options[i] = fmt.Sprint(option)
+		}
+		b.WriteString(" 可选: ")
+		b.WriteString(strings.Join(options, "/"))
+	}
+	if prop.Default != nil {
+		fmt.Fprintf(&b, " [默认 %v]", prop.Default)
+	}
+	if hint := typeHint(name, prop); hint != "" {
+		b.WriteString(" ")
+		b.WriteString(hint)
+	}
+	b.WriteString(": ")
+	return b.String()
+}
+
+func typeHint(name string, prop capability.Property) string {
+	switch {
+	case name == "messages" && prop.Type == "array":
+		return "<JSON 数组，或直接输入文本作为 user 消息>"
+	case prop.Type == "array":
+		return "<JSON 数组，或以逗号分隔；@文件 读取文件并按 base64 编码>"
+	case prop.Type == "object":
+		return "<JSON 对象>"
+	}
+	return ""
+}
+
+// parseValue 按属性类型解析输入
+func parseValue(name string, prop capability.Property, raw string) (interface{}, error) {
+	var value interface{}
+	switch prop.Type {
+	case "number", "integer":
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("需要数字")
+		}
+		value = n
+	case "boolean":
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("需要 true 或 false")
+		}
+		value = v
+	case "object":
+		var object map[string]interface{}
+		if err := json.Unmarshal([]byte(raw), &object); err != nil {
+			return nil, fmt.Errorf("需要 JSON 对象: %v", err)
+		}
+		value = object
+	case "array":
+		var items []interface{}
+		switch {
+		case json.Unmarshal([]byte(raw), &items) == nil:
+		case name == "messages":
+			items = []interface{}{map[string]interface{}{"role": "user", "content": raw}}
+		default:
+			for _, part := range strings.Split(raw, ",") {
+				item, err := fileOrText(strings.TrimSpace(part))
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+			}
+		}
+		value = items
+	default:
+		v, err := fileOrText(raw)
+		if err != nil {
+			return nil, err
+		}
+		value = v
+	}
+
+	if len(prop.Enum) > 0 && !inEnum(value, prop.Enum) {
+		return nil, fmt.Errorf("必须是以下值之一: %v", prop.Enum)
+	}
+	return value, nil
+}
+
+// fileOrText @文件 读取文件并按 base64 编码，用于音频、图片等二进制输入
+func fileOrText(raw string) (string, error) {
+	path, ok := strings.CutPrefix(raw, "@")
+	if !ok {
+		return raw, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+func inEnum(value interface{}, enum []interface{}) bool {
+	for _, option := range enum {
+		if fmt.Sprint(option) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func orDefault(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+const replHelp = `命令:
+  list [类型]          列出能力，可按类型筛选（llm/asr/tts/tool/node）
+  use <序号|能力ID>    选择能力并显示其配置和输入
+  config               显示当前能力的配置（密钥已隐藏）
+  set <键> <值>        设置当前能力的配置项，值按 JSON 解析失败时视为字符串
+  unset <键>           删除当前能力的配置项
+  run                  按输入 schema 逐项输入并执行
+  again                使用上一次的输入再次执行
+  help                 显示帮助
+  quit                 退出`
+
+// repl 交互式能力测试会话
+type repl struct {
+	backend    backend
+	fileConfig map[string]map[string]interface{}
+	timeout    time.Duration
+	prompt     *prompter
+	out        io.Writer
+
+	defs       []capability.Definition
+	providers  map[string]string
+	current    *capability.Definition
+	overrides  map[string]map[string]interface{} // 能力ID -> 会话内 set 的配置
+	lastInputs map[string]interface{}
+}
+
+func (r *repl) loop(ctx context.Context) error {
+	defs, providers, err := r.backend.capabilities(ctx)
+	if err != nil {
+		return fmt.Errorf("获取能力列表失败: %w", err)
+	}
+	r.defs, r.providers = defs, providers
+	r.overrides = make(map[string]map[string]interface{})
+	_, _ = fmt.Fprintf(r.out, "已连接 %s，共 %d 个能力，输入 help 查看命令\n", r.backend.describe(), len(defs))
+
+	for {
+		prompt := "> "
+		if r.current != nil {
+			prompt = r.current.ID + "> "
+		}
+		line, err := r.prompt.line(prompt)
+		if errors.Is(err, io.EOF) {
+			_, _ = fmt.Fprintln(r.out)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch cmd, args := fields[0], fields[1:]; cmd {
+		case "list", "ls":
+			r.list(args)
+		case "use":
+			if len(args) != 1 {
+				_, _ = fmt.Fprintln(r.out, "用法: use <序号|能力ID>")
+				continue
+			}
+			r.use(args[0])
+		case "config":
+			r.showConfig()
+		case "set":
+			if len(args) < 2 {
+				_, _ = fmt.Fprintln(r.out, "用法: set <键> <值>")
+				continue
+			}
+			r.set(args[0], strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(line, cmd)), args[0])))
+		case "unset":
+			if len(args) != 1 {
+				_, _ = fmt.Fprintln(r.out, "用法: unset <键>")
+				continue
+			}
+			r.unset(args[0])
+		case "run":
+			if r.current == nil {
+				_, _ = fmt.Fprintln(r.out, "请先使用 use 选择能力")
+				continue
+			}
+			if !r.checkConfig() {
+				continue
+			}
+			inputs, err := r.prompt.promptInputs(r.current.InputSchema)
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			r.lastInputs = inputs
+			r.run(ctx, inputs)
+		case "again":
+			if r.current == nil || r.lastInputs == nil {
+				_, _ = fmt.Fprintln(r.out, "还没有执行过，请先使用 run")
+				continue
+			}
+			if r.checkConfig() {
+				r.run(ctx, r.lastInputs)
+			}
+		case "help", "?":
+			_, _ = fmt.Fprintln(r.out, replHelp)
+		case "quit", "exit", "q":
+			return nil
+		default:
+			_, _ = fmt.Fprintf(r.out, "未知命令 %s，输入 help 查看命令\n", cmd)
+		}
+	}
+}
+
+func (r *repl) list(args []string) {
+	t := newTable(r.out, "#", "ID", "TYPE", "PROVIDER", "NAME")
+	for i, def := range r.defs {
+		if len(args) > 0 && string(def.Type) != args[0] {
+			continue
+		}
+		t.row(strconv.Itoa(i+1), def.ID, string(def.Type), r.providers[def.ID], def.Name)
+	}
+	t.flush()
+}
+
+func (r *repl) use(ref string) {
+	var found *capability.Definition
+	if n, err := strconv.Atoi(ref); err == nil && n >= 1 && n <= len(r.defs) {
+		found = &r.defs[n-1]
+	} else {
+		for i := range r.defs {
+			if r.defs[i].ID == ref {
+				found = &r.defs[i]
+				break
+			}
+		}
+	}
+	if found == nil {
+		_, _ = fmt.Fprintf(r.out, "未找到能力 %s，使用 list 查看\n", ref)
+		return
+	}
+	r.current = found
+	r.lastInputs = nil
+
+	_, _ = fmt.Fprintf(r.out, "%s (%s) %s\n", found.ID, found.Type, found.Name)
+	if found.Description != "" {
+		_, _ = fmt.Fprintf(r.out, "  %s\n", found.Description)
+	}
+	r.showConfig()
+	if len(found.InputSchema.Properties) > 0 {
+		_, _ = fmt.Fprintln(r.out, "输入:")
+		for _, name := range orderedProperties(found.InputSchema) {
+			prop := found.InputSchema.Properties[name]
+			_, _ = fmt.Fprintf(r.out, "  %s (%s) %s\n", name, orDefault(prop.Type, "string"), prop.Description)
+		}
+	}
+}
+
+// config 合并能力配置：schema 默认值 < 配置文件中的供应商配置 < 配置文件中的能力配置 < 环境变量 < 会话内 set
+// 环境变量按 <供应商ID>_<键> 大写查找，如 OPENAI_API_KEY，仅用于未在文件中配置的项
+func (r *repl) config(def *capability.Definition) map[string]interface{} {
+	config := make(map[string]interface{})
+	for name, prop := range def.ConfigSchema.Properties {
+		if prop.Default != nil {
+			config[name] = prop.Default
+		}
+	}
+	providerID := r.providers[def.ID]
+	inFile := make(map[string]bool)
+	for _, key := range []string{providerID, def.ID} {
+		for name, value := range r.fileConfig[key] {
+			config[name] = value
+			inFile[name] = true
+		}
+	}
+	for name := range def.ConfigSchema.Properties {
+		if inFile[name] || providerID == "" {
+			continue
+		}
+		if value, ok := os.LookupEnv(strings.ToUpper(providerID + "_" + name)); ok {
+			config[name] = value
+		}
+	}
+	for name, value := range r.overrides[def.ID] {
+		config[name] = value
+	}
+	return config
+}
+
+func (r *repl) showConfig() {
+	if r.current == nil {
+		_, _ = fmt.Fprintln(r.out, "请先使用 use 选择能力")
+		return
+	}
+	config := r.config(r.current)
+	schema := r.current.ConfigSchema
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	_, _ = fmt.Fprintln(r.out, "配置:")
+	for _, name := range orderedProperties(schema) {
+		value, ok := config[name]
+		shown := fmt.Sprint(value)
+		switch {
+		case !ok && required[name]:
+			shown = "<未设置，必填>"
+		case !ok:
+			shown = "-"
+		case schema.Properties[name].Secret:
+			shown = maskSecret(shown)
+		}
+		_, _ = fmt.Fprintf(r.out, "  %s = %s\n", name, shown)
+	}
+	// 不在 schema 中的额外配置项也显示出来
+	for name, value := range config {
+		if _, known := schema.Properties[name]; !known {
+			_, _ = fmt.Fprintf(r.out, "  %s = %v (schema 未定义)\n", name, value)
+		}
+	}
+}
+
+func (r *repl) set(key, raw string) {
+	if r.current == nil {
+		_, _ = fmt.Fprintln(r.out, "请先使用 use 选择能力")
+		return
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		value = raw
+	}
+	if r.overrides[r.current.ID] == nil {
+		r.overrides[r.current.ID] = make(map[string]interface{})
+	}
+	r.overrides[r.current.ID][key] = value
+}
+
+func (r *repl) unset(key string) {
+	if r.current == nil {
+		_, _ = fmt.Fprintln(r.out, "请先使用 use 选择能力")
+		return
+	}
+	delete(r.overrides[r.current.ID], key)
+}
+
+// checkConfig 检查必填配置，缺失时提示设置方式
+func (r *repl) checkConfig() bool {
+	config := r.config(r.current)
+	for _, name := range r.current.ConfigSchema.Required {
+		if _, ok := config[name]; !ok {
+			_, _ = fmt.Fprintf(r.out, "缺少必填配置 %s，请使用 set %s <值>、配置文件或环境变量 %s 设置\n",
+				name, name, strings.ToUpper(r.providers[r.current.ID]+"_"+name))
+			return false
+		}
+	}
+	return true
+}
+
+func (r *repl) run(parent context.Context, inputs map[string]interface{}) {
+	config := r.config(r.current)
+
+	// 执行期间 Ctrl+C 只取消本次调用
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	streamed := false
+	result, err := r.backend.run(ctx, r.current.ID, config, inputs, func(chunk map[string]interface{}) {
+		for _, key := range []string{"content", "text"} {
+			if s, ok := chunk[key].(string); ok && s != "" {
+				_, _ = fmt.Fprint(r.out, s)
+				streamed = true
+			}
+		}
+	})
+	if streamed {
+		_, _ = fmt.Fprintln(r.out)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(r.out, "执行失败: %v\n", err)
+		return
+	}
+
+	_, _ = fmt.Fprintln(r.out, "输出:")
+	printOutputs(r.out, result.Outputs)
+	timing := fmt.Sprintf("耗时 %s", result.Elapsed.Round(time.Millisecond))
+	if result.Chunks > 0 {
+		timing += fmt.Sprintf("，首个分片 %s，共 %d 个分片", result.FirstChunk.Round(time.Millisecond), result.Chunks)
+	}
+	_, _ = fmt.Fprintln(r.out, timing)
+}
+
+func maskSecret(s string) string {
+	if len(s) <= 4 {
+		return strings.Repeat("*", len(s))
+	}
+	return s[:2] + strings.Repeat("*", len(s)-4) + s[len(s)-2:]
+}
@@ -0,0 +1,371 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strings"
+)
+
+// goRuntime 生成的 Go 客户端中与接口无关的部分
+const goRuntime = `
+// Client /api/v1 客户端
+type Client struct {
+	BaseURL    string       // 服务地址，如 http://localhost:8080
+	Token      string       // 访问令牌，非空时以 Bearer 方式发送
+	Header     http.Header  // 每个请求附加的请求头
+	HTTPClient *http.Client // 为空时使用 http.DefaultClient
+}
+
+// NewClient 创建客户端
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: baseURL}
+}
+
+// Error 接口返回的失败响应
+type Error struct {
+	Status  int             // HTTP状态码
+	Code    string          // 错误码，如 VALIDATION_FAILED
+	Message string          // 错误说明
+	Details json.RawMessage // 错误详情，如字段级校验错误
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("HTTP %d: %s", e.Status, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// envelope 统一响应结构
+type envelope struct {
+	Success bool            ` + "`json:\"success\"`" + `
+	Data    json.RawMessage ` + "`json:\"data\"`" + `
+	Message string          ` + "`json:\"message\"`" + `
+	Error   *struct {
+		Code    string          ` + "`json:\"code\"`" + `
+		Message string          ` + "`json:\"message\"`" + `
+		Details json.RawMessage ` + "`json:\"details\"`" + `
+	} ` + "`json:\"error\"`" + `
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	target := strings.TrimRight(c.BaseURL, "/") + basePath + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	for key, values := range c.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return &Error{Status: resp.StatusCode, Message: strings.TrimSpace(string(data))}
+	}
+	if resp.StatusCode >= http.StatusBadRequest || !env.Success {
+		apiErr := &Error{Status: resp.StatusCode, Message: env.Message}
+		if env.Error != nil {
+			apiErr.Code = env.Error.Code
+			apiErr.Message = env.Error.Message
+			apiErr.Details = env.Error.Details
+		}
+		return apiErr
+	}
+	if out == nil || len(env.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(env.Data, out)
+}
+`
+
+type goGenerator struct {
+	buf         bytes.Buffer
+	usesStrconv bool
+}
+
+// generateGo 生成 Go 客户端
+func generateGo(a *api, pkg, source string) ([]byte, error) {
+	g := &goGenerator{}
+	body := g.body(a)
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by xiaozhi-apigen from %s. DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&out, "// Package %s 是 /api/v1 的 Go 客户端，由 cmd/xiaozhi-apigen 根据 OpenAPI 规范生成\n", pkg)
+	fmt.Fprintf(&out, "package %s\n\n", pkg)
+	imports := []string{"bytes", "context", "encoding/json", "fmt", "io", "net/http", "net/url", "strings"}
+	if g.usesStrconv {
+		imports = append(imports, "strconv")
+	}
+	out.WriteString("import (\n")
+	for _, imp := range imports {
+		fmt.Fprintf(&out, "\t%q\n", imp)
+	}
+	out.WriteString(")\n\n")
+	fmt.Fprintf(&out, "const basePath = %s\n", quote(a.basePath))
+	out.WriteString(goRuntime)
+	out.Write(body)
+
+	code, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("格式化生成的 Go 代码失败: %w", err)
+	}
+	return code, nil
+}
+
+func (g *goGenerator) body(a *api) []byte {
+	for _, op := range a.operations {
+		g.operation(op)
+	}
+	for _, m := range a.models {
+		g.model(m)
+	}
+	return g.buf.Bytes()
+}
+
+func (g *goGenerator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *goGenerator) model(m *model) {
+	g.printf("\n")
+	if m.doc != "" {
+		g.buf.WriteString(commentLines(m.name+" "+m.doc, "// "))
+	}
+	switch m.kind {
+	case kindModel:
+		g.printf("type %s struct {\n", m.name)
+		for _, f := range m.fields {
+			g.buf.WriteString(commentLines(f.doc, "\t// "))
+			typ := goType(f.typ)
+			tag := f.jsonName
+			if !f.required {
+				tag += ",omitempty"
+				if isObject(f.typ) {
+					typ = "*" + typ
+				}
+			}
+			g.printf("\t%s %s `json:%q`\n", goName(splitWords(f.jsonName)), typ, tag)
+		}
+		g.printf("}\n")
+	case kindAny:
+		g.printf("type %s = json.RawMessage\n", m.name)
+	default:
+		g.printf("type %s %s\n", m.name, goType(&typeRef{kind: m.kind}))
+		if len(m.enum) > 0 {
+			g.printf("\nconst (\n")
+			for i, v := range m.enum {
+				g.printf("\t%s %s = %s\n", m.enumNames[i], m.name, quote(v))
+			}
+			g.printf(")\n")
+		}
+	}
+}
+
+func (g *goGenerator) operation(op *apiOperation) {
+	name := goName(op.words)
+	var args []string
+	args = append(args, "ctx context.Context")
+	for _, p := range op.pathParams {
+		args = append(args, goParamName(p.name)+" "+goType(p.typ))
+	}
+	paramsType := ""
+	if len(op.queryParams) > 0 {
+		paramsType = name + "Params"
+		args = append(args, "params *"+paramsType)
+	}
+	if op.body != nil {
+		typ := goType(op.body)
+		if isObject(op.body) {
+			typ = "*" + typ
+		}
+		args = append(args, "body "+typ)
+	}
+
+	resultType := ""
+	if op.result != nil {
+		resultType = goType(op.result)
+		if isObject(op.result) {
+			resultType = "*" + resultType
+		}
+	}
+
+	g.printf("\n")
+	if op.doc != "" {
+		g.buf.WriteString(commentLines(name+" "+op.doc, "// "))
+		g.printf("//\n")
+	}
+	g.printf("// %s %s\n", op.method, op.path)
+	if resultType == "" {
+		g.printf("func (c *Client) %s(%s) error {\n", name, strings.Join(args, ", "))
+	} else {
+		g.printf("func (c *Client) %s(%s) (%s, error) {\n", name, strings.Join(args, ", "), resultType)
+	}
+
+	g.printf("\tpath := %s\n", g.pathExpr(op))
+	query := "nil"
+	if paramsType != "" {
+		g.printf("\tvar query url.Values\n\tif params != nil {\n\t\tquery = params.values()\n\t}\n")
+		query = "query"
+	}
+	body := "nil"
+	if op.body != nil {
+		body = "body"
+	}
+	method := "http.Method" + upperFirst(strings.ToLower(op.method))
+
+	switch {
+	case resultType == "":
+		g.printf("\treturn c.do(ctx, %s, path, %s, %s, nil)\n", method, query, body)
+	case isObject(op.result):
+		g.printf("\tvar out %s\n", goType(op.result))
+		g.printf("\tif err := c.do(ctx, %s, path, %s, %s, &out); err != nil {\n\t\treturn nil, err\n\t}\n", method, query, body)
+		g.printf("\treturn &out, nil\n")
+	default:
+		g.printf("\tvar out %s\n", resultType)
+		g.printf("\terr := c.do(ctx, %s, path, %s, %s, &out)\n", method, query, body)
+		g.printf("\treturn out, err\n")
+	}
+	g.printf("}\n")
+
+	if paramsType != "" {
+		g.params(name, paramsType, op.queryParams)
+	}
+}
+
+// pathExpr 拼接路径的表达式，路径参数经过转义
+func (g *goGenerator) pathExpr(op *apiOperation) string {
+	path := op.path
+	var parts []string
+	for _, p := range op.pathParams {
+		placeholder := "{" + p.name + "}"
+		i := strings.Index(path, placeholder)
+		if i < 0 {
+			continue
+		}
+		if i > 0 {
+			parts = append(parts, quote(path[:i]))
+		}
+		value := goParamName(p.name)
+		if p.typ.kind != kindString {
+			value = "fmt.Sprint(" + value + ")"
+		}
+		parts = append(parts, "url.PathEscape("+value+")")
+		path = path[i+len(placeholder):]
+	}
+	if path != "" || len(parts) == 0 {
+		parts = append(parts, quote(path))
+	}
+	return strings.Join(parts, " + ")
+}
+
+func (g *goGenerator) params(opName, typeName string, params []*param) {
+	g.printf("\n// %s %s 的查询参数，零值不发送\n", typeName, opName)
+	g.printf("type %s struct {\n", typeName)
+	for _, p := range params {
+		g.buf.WriteString(commentLines(p.doc, "\t// "))
+		typ := goType(p.typ)
+		if p.typ.kind == kindBoolean {
+			typ = "*bool"
+		}
+		g.printf("\t%s %s\n", goName(splitWords(p.name)), typ)
+	}
+	g.printf("}\n\n")
+
+	g.printf("func (p *%s) values() url.Values {\n\tquery := url.Values{}\n", typeName)
+	for _, p := range params {
+		field := "p." + goName(splitWords(p.name))
+		switch p.typ.kind {
+		case kindString:
+			g.printf("\tif %s != \"\" {\n\t\tquery.Set(%q, %s)\n\t}\n", field, p.name, field)
+		case kindInteger:
+			g.usesStrconv = true
+			g.printf("\tif %s != 0 {\n\t\tquery.Set(%q, strconv.FormatInt(%s, 10))\n\t}\n", field, p.name, field)
+		case kindNumber:
+			g.usesStrconv = true
+			g.printf("\tif %s != 0 {\n\t\tquery.Set(%q, strconv.FormatFloat(%s, 'f', -1, 64))\n\t}\n", field, p.name, field)
+		case kindBoolean:
+			g.usesStrconv = true
+			g.printf("\tif %s != nil {\n\t\tquery.Set(%q, strconv.FormatBool(*%s))\n\t}\n", field, p.name, field)
+		default:
+			g.printf("\tfor _, v := range %s {\n\t\tquery.Add(%q, fmt.Sprint(v))\n\t}\n", field, p.name)
+		}
+	}
+	g.printf("\treturn query\n}\n")
+}
+
+func goType(t *typeRef) string {
+	switch t.kind {
+	case kindString:
+		return "string"
+	case kindInteger:
+		return "int64"
+	case kindNumber:
+		return "float64"
+	case kindBoolean:
+		return "bool"
+	case kindRaw:
+		return "json.RawMessage"
+	case kindModel:
+		return t.model.name
+	case kindArray:
+		return "[]" + goType(t.elem)
+	case kindMap:
+		return "map[string]" + goType(t.elem)
+	}
+	return "interface{}"
+}
+
+// isObject 类型是否为对象（Go 中以指针传递）
+func isObject(t *typeRef) bool {
+	return t != nil && t.kind == kindModel && t.model.kind == kindModel
+}
+
+// goParamName 路径参数的 Go 变量名
+func goParamName(name string) string {
+	words := splitWords(name)
+	if len(words) == 0 {
+		return "p"
+	}
+	ident := words[0] + strings.TrimPrefix(goName(words), goName(words[:1]))
+	if token.IsKeyword(ident) || ident == "ctx" || ident == "params" || ident == "body" || ident == "path" || ident == "query" {
+		ident += "Param"
+	}
+	return ident
+}
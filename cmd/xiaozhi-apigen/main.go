@@ -0,0 +1,68 @@
+// xiaozhi-apigen 根据 OpenAPI 规范生成 /api/v1 客户端
+//
+// 读取 swag 生成的 swagger.json，为 /v1 下的全部接口生成 Go 客户端（供 xiaozhi-cli 使用）
+// 和 TypeScript 客户端（供 web 前端使用）。接口返回的统一响应结构在客户端中拆开：
+// 方法直接返回 data 的类型，失败时返回带错误码的错误。
+//
+// 方法名取自接口的 @ID 注解，没有时由请求方法和路径生成，如
+// GET /v1/tenants/{id}/usage 生成 GetTenantsByIDUsage（TypeScript 为 getTenantsByIdUsage）。
+// 以 multipart 表单上传文件的接口不生成方法，调用方自行构造请求。
+//
+// 一般通过 make api-clients 调用，它会先执行 make swag 更新规范。
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	specPath := flag.String("spec", "internal/platform/docs/swagger.json", "swag 生成的 swagger.json")
+	goOut := flag.String("go", "gen/go/apiclient/client.gen.go", "Go 客户端输出文件，为空时不生成")
+	goPkg := flag.String("go-package", "apiclient", "Go 客户端包名")
+	tsOut := flag.String("ts", "web/src/services/apiClient.gen.ts", "TypeScript 客户端输出文件，为空时不生成")
+	flag.Parse()
+
+	if err := run(*specPath, *goOut, *goPkg, *tsOut); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "xiaozhi-apigen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, goOut, goPkg, tsOut string) error {
+	s, err := loadSpec(specPath)
+	if err != nil {
+		return err
+	}
+	api, err := buildAPI(s)
+	if err != nil {
+		return err
+	}
+
+	source := filepath.ToSlash(specPath)
+	if goOut != "" {
+		code, err := generateGo(api, goPkg, source)
+		if err != nil {
+			return err
+		}
+		if err := writeFile(goOut, code); err != nil {
+			return err
+		}
+	}
+	if tsOut != "" {
+		if err := writeFile(tsOut, generateTypeScript(api, source)); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("已生成 %d 个接口、%d 个类型\n", len(api.operations), len(api.models))
+	return nil
+}
+
+func writeFile(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o644)
+}
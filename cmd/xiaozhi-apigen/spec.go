@@ -0,0 +1,563 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// spec swagger 2.0 规范中生成客户端用到的部分
+type spec struct {
+	BasePath    string                           `json:"basePath"`
+	Paths       map[string]map[string]*operation `json:"paths"`
+	Definitions map[string]*schema               `json:"definitions"`
+}
+
+type operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Description string               `json:"description"`
+	Parameters  []*parameter         `json:"parameters"`
+	Responses   map[string]*response `json:"responses"`
+}
+
+type parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Type        string  `json:"type"`
+	Items       *schema `json:"items"`
+	Schema      *schema `json:"schema"`
+}
+
+type response struct {
+	Schema *schema `json:"schema"`
+}
+
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Description          string             `json:"description"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	Items                *schema            `json:"items"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	AllOf                []*schema          `json:"allOf"`
+	Enum                 []interface{}      `json:"enum"`
+	EnumVarNames         []string           `json:"x-enum-varnames"`
+}
+
+func loadSpec(path string) (*spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s spec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("解析 %s 失败: %w", path, err)
+	}
+	return &s, nil
+}
+
+// 类型种类
+const (
+	kindString  = "string"
+	kindInteger = "integer"
+	kindNumber  = "number"
+	kindBoolean = "boolean"
+	kindAny     = "any"
+	kindRaw     = "raw" // 多个成功响应的 data 类型不同，由调用方自行解析
+	kindModel   = "model"
+	kindArray   = "array"
+	kindMap     = "map"
+)
+
+// typeRef 与语言无关的类型
+type typeRef struct {
+	kind  string
+	model *model   // kindModel
+	elem  *typeRef // kindArray、kindMap 的元素类型
+}
+
+// model 规范 definitions 中的一个类型
+type model struct {
+	key       string // 定义名，如 v1.TenantInfo
+	name      string // 生成的类型名
+	doc       string
+	kind      string // kindModel 为对象，否则为基础类型（可带枚举值）
+	fields    []*field
+	enum      []string
+	enumNames []string
+}
+
+type field struct {
+	jsonName string
+	doc      string
+	typ      *typeRef
+	required bool
+}
+
+// apiOperation 一个接口
+type apiOperation struct {
+	words        []string // 方法名的单词
+	method       string   // GET、POST ...
+	path         string   // 相对 basePath 的路径，如 /v1/tenants/{id}
+	doc          string
+	pathParams   []*param
+	queryParams  []*param
+	body         *typeRef
+	bodyRequired bool
+	result       *typeRef // nil 表示响应没有 data
+}
+
+type param struct {
+	name     string
+	doc      string
+	typ      *typeRef
+	required bool
+}
+
+// api 生成客户端所需的全部信息
+type api struct {
+	basePath   string
+	operations []*apiOperation
+	models     []*model // 按类型名排序，只包含接口用到的类型
+}
+
+type builder struct {
+	spec   *spec
+	models map[string]*model
+}
+
+// buildAPI 收集 /v1 下的接口和它们用到的类型
+func buildAPI(s *spec) (*api, error) {
+	b := &builder{spec: s, models: make(map[string]*model)}
+	result := &api{basePath: strings.TrimRight(s.BasePath, "/")}
+
+	paths := make([]string, 0, len(s.Paths))
+	for p := range s.Paths {
+		if strings.HasPrefix(p, "/v1/") {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+	seen := make(map[string]string)
+	for _, p := range paths {
+		methods := make([]string, 0, len(s.Paths[p]))
+		for m := range s.Paths[p] {
+			methods = append(methods, m)
+		}
+		sort.Strings(methods)
+		for _, m := range methods {
+			if isUpload(s.Paths[p][m]) {
+				_, _ = fmt.Fprintf(os.Stderr, "xiaozhi-apigen: 跳过文件上传接口 %s %s\n", strings.ToUpper(m), p)
+				continue
+			}
+			op, err := b.operation(p, m, s.Paths[p][m])
+			if err != nil {
+				return nil, err
+			}
+			name := goName(op.words)
+			if other, ok := seen[name]; ok {
+				return nil, fmt.Errorf("%s %s 与 %s 的方法名都是 %s，请用 @ID 注解区分", strings.ToUpper(m), p, other, name)
+			}
+			seen[name] = strings.ToUpper(m) + " " + p
+			result.operations = append(result.operations, op)
+		}
+	}
+
+	result.models = b.nameModels()
+	return result, nil
+}
+
+// isUpload 接口是否以 multipart 表单上传文件，这类接口不生成客户端方法
+func isUpload(op *operation) bool {
+	for _, p := range op.Parameters {
+		if p.In == "formData" && p.Type == "file" {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *builder) operation(path, method string, op *operation) (*apiOperation, error) {
+	out := &apiOperation{
+		method: strings.ToUpper(method),
+		path:   path,
+		doc:    strings.TrimSpace(op.Summary),
+	}
+	if op.OperationID != "" {
+		out.words = splitWords(op.OperationID)
+	} else {
+		out.words = operationWords(method, path)
+	}
+	if op.Description != "" && op.Description != op.Summary {
+		if out.doc != "" {
+			out.doc += "\n"
+		}
+		out.doc += strings.TrimSpace(op.Description)
+	}
+
+	for _, p := range op.Parameters {
+		switch p.In {
+		case "path":
+			out.pathParams = append(out.pathParams, &param{name: p.Name, doc: p.Description, typ: b.paramType(p), required: true})
+		case "query":
+			out.queryParams = append(out.queryParams, &param{name: p.Name, doc: p.Description, typ: b.paramType(p), required: p.Required})
+		case "body":
+			out.body = b.typeOf(p.Schema)
+			out.bodyRequired = p.Required
+		case "formData":
+			return nil, fmt.Errorf("%s %s: 不支持 formData 参数", out.method, path)
+		}
+	}
+	// 路径参数按出现顺序排列
+	sort.SliceStable(out.pathParams, func(i, j int) bool {
+		return strings.Index(path, "{"+out.pathParams[i].name+"}") < strings.Index(path, "{"+out.pathParams[j].name+"}")
+	})
+
+	out.result = b.resultType(op.Responses)
+	return out, nil
+}
+
+func (b *builder) paramType(p *parameter) *typeRef {
+	if p.Type == "array" {
+		return &typeRef{kind: kindArray, elem: b.typeOf(p.Items)}
+	}
+	return b.typeOf(&schema{Type: p.Type})
+}
+
+// resultType 返回成功响应中 data 的类型，多个成功响应的类型不同时为 kindRaw
+func (b *builder) resultType(responses map[string]*response) *typeRef {
+	codes := make([]string, 0, len(responses))
+	for code := range responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+
+	var result *typeRef
+	for i, code := range codes {
+		r := responses[code]
+		if r == nil || r.Schema == nil {
+			continue
+		}
+		t, hasData := b.unwrapEnvelope(r.Schema)
+		if !hasData {
+			t = nil
+		}
+		if i == 0 {
+			result = t
+			continue
+		}
+		if typeKey(t) != typeKey(result) {
+			return &typeRef{kind: kindRaw}
+		}
+	}
+	return result
+}
+
+// unwrapEnvelope 拆开统一响应结构 {success, data, error, ...}，返回 data 的类型
+func (b *builder) unwrapEnvelope(s *schema) (*typeRef, bool) {
+	if b.isEnvelope(s) {
+		return nil, false
+	}
+	if len(s.AllOf) == 2 && b.isEnvelope(s.AllOf[0]) {
+		data, ok := s.AllOf[1].Properties["data"]
+		if !ok {
+			return nil, false
+		}
+		return b.typeOf(data), true
+	}
+	return b.typeOf(s), true
+}
+
+func (b *builder) isEnvelope(s *schema) bool {
+	if s == nil || s.Ref == "" {
+		return false
+	}
+	def := b.spec.Definitions[refKey(s.Ref)]
+	if def == nil {
+		return false
+	}
+	for _, name := range []string{"success", "data", "error"} {
+		if _, ok := def.Properties[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *builder) typeOf(s *schema) *typeRef {
+	if s == nil {
+		return &typeRef{kind: kindAny}
+	}
+	if s.Ref != "" {
+		return &typeRef{kind: kindModel, model: b.model(refKey(s.Ref))}
+	}
+	if len(s.AllOf) == 1 {
+		return b.typeOf(s.AllOf[0])
+	}
+	switch s.Type {
+	case kindString, kindInteger, kindNumber, kindBoolean:
+		return &typeRef{kind: s.Type}
+	case "array":
+		return &typeRef{kind: kindArray, elem: b.typeOf(s.Items)}
+	case "object":
+		if elem := b.additionalProperties(s); elem != nil {
+			return &typeRef{kind: kindMap, elem: elem}
+		}
+		return &typeRef{kind: kindMap, elem: &typeRef{kind: kindAny}}
+	}
+	return &typeRef{kind: kindAny}
+}
+
+func (b *builder) additionalProperties(s *schema) *typeRef {
+	raw := strings.TrimSpace(string(s.AdditionalProperties))
+	if !strings.HasPrefix(raw, "{") {
+		return nil
+	}
+	var elem schema
+	if err := json.Unmarshal([]byte(raw), &elem); err != nil {
+		return nil
+	}
+	return b.typeOf(&elem)
+}
+
+func (b *builder) model(key string) *model {
+	if m, ok := b.models[key]; ok {
+		return m
+	}
+	m := &model{key: key}
+	b.models[key] = m // 先登记再解析字段，允许类型递归引用
+
+	def := b.spec.Definitions[key]
+	if def == nil {
+		m.kind = kindAny
+		return m
+	}
+	m.doc = strings.TrimSpace(def.Description)
+	switch def.Type {
+	case kindString, kindInteger, kindNumber, kindBoolean:
+		m.kind = def.Type
+		if def.Type == kindString {
+			for i, v := range def.Enum {
+				m.enum = append(m.enum, fmt.Sprint(v))
+				if i < len(def.EnumVarNames) {
+					m.enumNames = append(m.enumNames, def.EnumVarNames[i])
+				}
+			}
+		}
+		return m
+	}
+
+	m.kind = kindModel
+	required := make(map[string]bool, len(def.Required))
+	for _, name := range def.Required {
+		required[name] = true
+	}
+	names := make([]string, 0, len(def.Properties))
+	for name := range def.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		prop := def.Properties[name]
+		m.fields = append(m.fields, &field{
+			jsonName: name,
+			doc:      strings.TrimSpace(prop.Description),
+			typ:      b.typeOf(prop),
+			required: required[name],
+		})
+	}
+	return m
+}
+
+// nameModels 为用到的类型命名：取定义名中的类型名，不同包中同名时加包名前缀
+func (b *builder) nameModels() []*model {
+	count := make(map[string]int)
+	for key := range b.models {
+		count[baseName(key)]++
+	}
+	models := make([]*model, 0, len(b.models))
+	for key, m := range b.models {
+		m.name = baseName(key)
+		if count[m.name] > 1 {
+			if pkg, _, ok := strings.Cut(key, "."); ok {
+				m.name = goName(splitWords(pkg)) + m.name
+			}
+		}
+		models = append(models, m)
+	}
+	sort.Slice(models, func(i, j int) bool { return models[i].name < models[j].name })
+	uniqueEnumNames(models)
+	return models
+}
+
+// uniqueEnumNames 枚举常量名缺失或重复时改用 类型名+值
+func uniqueEnumNames(models []*model) {
+	used := make(map[string]int)
+	for _, m := range models {
+		for _, name := range m.enumNames {
+			used[name]++
+		}
+	}
+	for _, m := range models {
+		if len(m.enum) == 0 {
+			continue
+		}
+		ok := len(m.enumNames) == len(m.enum)
+		for _, name := range m.enumNames {
+			if used[name] > 1 {
+				ok = false
+			}
+		}
+		if ok {
+			continue
+		}
+		m.enumNames = make([]string, len(m.enum))
+		for i, v := range m.enum {
+			m.enumNames[i] = m.name + goName(splitWords(v))
+		}
+	}
+}
+
+// typeKey 类型的唯一描述，用于比较两个类型是否相同
+func typeKey(t *typeRef) string {
+	switch {
+	case t == nil:
+		return ""
+	case t.kind == kindModel:
+		return t.model.key
+	case t.elem != nil:
+		return t.kind + "<" + typeKey(t.elem) + ">"
+	}
+	return t.kind
+}
+
+func refKey(ref string) string {
+	return strings.TrimPrefix(ref, "#/definitions/")
+}
+
+func baseName(key string) string {
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		return key[i+1:]
+	}
+	return key
+}
+
+// operationWords 由请求方法和路径生成方法名，路径参数写作 By<参数名>
+func operationWords(method, path string) []string {
+	words := []string{strings.ToLower(method)}
+	for _, segment := range strings.Split(strings.TrimPrefix(path, "/v1/"), "/") {
+		if segment == "" {
+			continue
+		}
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			words = append(words, "by")
+			words = append(words, splitWords(strings.Trim(segment, "{}"))...)
+			continue
+		}
+		words = append(words, splitWords(segment)...)
+	}
+	return words
+}
+
+// splitWords 按分隔符和大小写切分标识符，结果为小写单词
+func splitWords(s string) []string {
+	var words []string
+	var current []rune
+	runes := []rune(s)
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, strings.ToLower(string(current)))
+			current = current[:0]
+		}
+	}
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(current) > 0 {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return words
+}
+
+// initialisms Go 命名中保持全大写的缩写
+var initialisms = map[string]bool{
+	"api": true, "asr": true, "cpu": true, "csrf": true, "http": true, "id": true, "ip": true,
+	"json": true, "llm": true, "mac": true, "mcp": true, "ota": true, "sql": true, "tts": true,
+	"ttl": true, "ui": true, "url": true, "uuid": true, "vad": true,
+}
+
+// goName 导出的 Go 标识符
+func goName(words []string) string {
+	var sb strings.Builder
+	for _, w := range words {
+		if initialisms[w] {
+			sb.WriteString(strings.ToUpper(w))
+			continue
+		}
+		sb.WriteString(upperFirst(w))
+	}
+	name := sb.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+// camelName 首字母小写的驼峰标识符
+func camelName(words []string) string {
+	var sb strings.Builder
+	for i, w := range words {
+		if i == 0 {
+			sb.WriteString(w)
+			continue
+		}
+		sb.WriteString(upperFirst(w))
+	}
+	return sb.String()
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// commentLines 把说明拆成注释行
+func commentLines(doc, prefix string) string {
+	if doc == "" {
+		return ""
+	}
+	var sb strings.Builder
+	for _, line := range strings.Split(doc, "\n") {
+		sb.WriteString(prefix)
+		sb.WriteString(strings.TrimSpace(line))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func quote(s string) string {
+	return strconv.Quote(s)
+}
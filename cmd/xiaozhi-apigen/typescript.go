@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// tsRuntime 生成的 TypeScript 客户端中与接口无关的部分
+const tsRuntime = `import axios, { type AxiosInstance } from 'axios';
+
+// 接口返回的失败响应
+export class ApiError extends Error {
+  status: number;
+  code: string;
+  details: unknown;
+
+  constructor(status: number, code: string, message: string, details?: unknown) {
+    super(message);
+    this.name = 'ApiError';
+    this.status = status;
+    this.code = code;
+    this.details = details;
+  }
+}
+
+// 统一响应结构
+interface Envelope<T> {
+  success: boolean;
+  data?: T;
+  message?: string;
+  error?: { code: string; message: string; details?: unknown };
+}
+
+/**
+ * /api/v1 客户端，方法直接返回响应中的 data，失败时抛出 ApiError
+ */
+export class ApiClient {
+  private http: AxiosInstance;
+
+  constructor(baseURL = %s, http?: AxiosInstance) {
+    this.http = http ?? axios.create({ baseURL });
+  }
+
+  private async request<T>(
+    method: string,
+    url: string,
+    params?: object,
+    data?: unknown,
+  ): Promise<T> {
+    try {
+      const response = await this.http.request<Envelope<T>>({
+        method,
+        url,
+        params,
+        data,
+      });
+      const body = response.data;
+      if (!body.success) {
+        throw new ApiError(
+          response.status,
+          body.error?.code ?? '',
+          body.error?.message ?? body.message ?? '',
+          body.error?.details,
+        );
+      }
+      return body.data as T;
+    } catch (error) {
+      if (axios.isAxiosError(error) && error.response) {
+        const body = error.response.data as Envelope<unknown> | undefined;
+        throw new ApiError(
+          error.response.status,
+          body?.error?.code ?? '',
+          body?.error?.message ?? error.message,
+          body?.error?.details,
+        );
+      }
+      throw error;
+    }
+  }
+`
+
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// generateTypeScript 生成 TypeScript 客户端
+func generateTypeScript(a *api, source string) []byte {
+	var buf bytes.Buffer
+	printf := func(format string, args ...interface{}) {
+		fmt.Fprintf(&buf, format, args...)
+	}
+
+	printf("// Code generated by xiaozhi-apigen from %s. DO NOT EDIT.\n\n", source)
+	printf(tsRuntime, tsString(a.basePath))
+	for _, op := range a.operations {
+		tsOperation(&buf, op)
+	}
+	printf("}\n\nexport const apiClient = new ApiClient();\n")
+
+	for _, op := range a.operations {
+		if len(op.queryParams) == 0 {
+			continue
+		}
+		printf("\nexport interface %sParams {\n", goName(op.words))
+		for _, p := range op.queryParams {
+			buf.WriteString(tsComment(p.doc, "  "))
+			printf("  %s?: %s;\n", tsProperty(p.name), tsType(p.typ))
+		}
+		printf("}\n")
+	}
+
+	for _, m := range a.models {
+		printf("\n")
+		buf.WriteString(tsComment(m.doc, ""))
+		switch m.kind {
+		case kindModel:
+			printf("export interface %s {\n", m.name)
+			for _, f := range m.fields {
+				buf.WriteString(tsComment(f.doc, "  "))
+				optional := "?"
+				if f.required {
+					optional = ""
+				}
+				printf("  %s%s: %s;\n", tsProperty(f.jsonName), optional, tsType(f.typ))
+			}
+			printf("}\n")
+		case kindAny:
+			printf("export type %s = unknown;\n", m.name)
+		default:
+			if len(m.enum) > 0 {
+				values := make([]string, len(m.enum))
+				for i, v := range m.enum {
+					values[i] = tsString(v)
+				}
+				printf("export type %s = %s;\n", m.name, strings.Join(values, " | "))
+				continue
+			}
+			printf("export type %s = %s;\n", m.name, tsType(&typeRef{kind: m.kind}))
+		}
+	}
+	return buf.Bytes()
+}
+
+func tsOperation(buf *bytes.Buffer, op *apiOperation) {
+	var args []string
+	for _, p := range op.pathParams {
+		args = append(args, camelName(splitWords(p.name))+": "+tsType(p.typ))
+	}
+	if op.body != nil && op.bodyRequired {
+		args = append(args, "body: "+tsType(op.body))
+	}
+	params := "undefined"
+	if len(op.queryParams) > 0 {
+		args = append(args, "params?: "+goName(op.words)+"Params")
+		params = "params"
+	}
+	body := ""
+	if op.body != nil {
+		if !op.bodyRequired {
+			args = append(args, "body?: "+tsType(op.body))
+		}
+		body = ", body"
+	}
+	result := "void"
+	if op.result != nil {
+		result = tsType(op.result)
+	}
+
+	url := op.path
+	for _, p := range op.pathParams {
+		url = strings.Replace(url, "{"+p.name+"}", "${encodeURIComponent("+camelName(splitWords(p.name))+")}", 1)
+	}
+	if len(op.pathParams) > 0 {
+		url = "`" + url + "`"
+	} else {
+		url = tsString(url)
+	}
+	if params == "undefined" && body == "" {
+		params = ""
+	} else {
+		params = ", " + params
+	}
+
+	fmt.Fprintf(buf, "\n  /**\n")
+	if op.doc != "" {
+		buf.WriteString(commentLines(op.doc, "   * "))
+	}
+	fmt.Fprintf(buf, "   * %s %s\n   */\n", op.method, op.path)
+	fmt.Fprintf(buf, "  %s(%s): Promise<%s> {\n", camelName(op.words), strings.Join(args, ", "), result)
+	fmt.Fprintf(buf, "    return this.request<%s>(%s, %s%s%s);\n  }\n", result, tsString(op.method), url, params, body)
+}
+
+func tsType(t *typeRef) string {
+	switch t.kind {
+	case kindString:
+		return "string"
+	case kindInteger, kindNumber:
+		return "number"
+	case kindBoolean:
+		return "boolean"
+	case kindModel:
+		return t.model.name
+	case kindArray:
+		return tsType(t.elem) + "[]"
+	case kindMap:
+		return "Record<string, " + tsType(t.elem) + ">"
+	}
+	return "unknown"
+}
+
+func tsProperty(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return name
+	}
+	return tsString(name)
+}
+
+func tsString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+func tsComment(doc, indent string) string {
+	if doc == "" {
+		return ""
+	}
+	return indent + "/** " + strings.ReplaceAll(strings.TrimSpace(doc), "\n", " ") + " */\n"
+}
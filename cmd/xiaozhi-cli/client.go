@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"xiaozhi-server-go/gen/go/apiclient"
+)
+
+// client /api/v1 HTTP 客户端
+type client struct {
+	server string
+	token  string
+	http   *http.Client
+	api    *apiclient.Client // 根据 OpenAPI 规范生成的客户端，新命令优先使用
+}
+
+func newClient(server, token string) *client {
+	c := &client{
+		server: strings.TrimRight(server, "/"),
+		token:  token,
+		http:   &http.Client{Timeout: 2 * time.Minute},
+	}
+	c.api = apiclient.NewClient(c.server)
+	c.api.HTTPClient = c.http
+	c.api.Token = token
+	if token != "" {
+		c.api.Header = http.Header{"AuthorToken": []string{token}}
+	}
+	return c
+}
+
+// envelope 兼容两种响应格式：{success, data, error:{code,message}} 和 {data} / {error:"..."}
+type envelope struct {
+	Data    json.RawMessage `json:"data"`
+	Error   json.RawMessage `json:"error"`
+	Message string          `json:"message"`
+}
+
+// apiError 服务端返回的错误
+type apiError struct {
+	status  int
+	code    string
+	message string
+	details json.RawMessage // 错误附带的数据，如部分执行的配置清单计划
+}
+
+func (e *apiError) Error() string {
+	if e.code != "" {
+		return fmt.Sprintf("%s (HTTP %d, %s)", e.message, e.status, e.code)
+	}
+	return fmt.Sprintf("%s (HTTP %d)", e.message, e.status)
+}
+
+// fromAPIError 把生成客户端返回的错误转换为 apiError，其它错误原样返回
+func fromAPIError(err error) error {
+	var e *apiclient.Error
+	if !errors.As(err, &e) {
+		return err
+	}
+	message := e.Message
+	if message == "" {
+		message = http.StatusText(e.Status)
+	}
+	return &apiError{status: e.Status, code: e.Code, message: message, details: e.Details}
+}
+
+// get 发送 GET 请求，out 为 nil 时丢弃响应数据
+func (c *client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	return c.do(ctx, http.MethodGet, path, query, nil, out)
+}
+
+// post 发送 POST 请求，body 为 []byte 时原样发送，否则编码为 JSON
+func (c *client) post(ctx context.Context, path string, query url.Values, body, out interface{}) error {
+	return c.do(ctx, http.MethodPost, path, query, body, out)
+}
+
+func (c *client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case []byte:
+		reader = bytes.NewReader(b)
+	default:
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := c.newRequest(ctx, method, path, query, reader)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		if resp.StatusCode >= http.StatusBadRequest {
+			return &apiError{status: resp.StatusCode, message: strings.TrimSpace(string(data))}
+		}
+		return fmt.Errorf("无法解析响应: %w", err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest || len(env.Error) > 0 && string(env.Error) != "null" {
+		return decodeError(resp.StatusCode, env)
+	}
+	if out == nil || len(env.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(env.Data, out)
+}
+
+func decodeError(status int, env envelope) error {
+	e := &apiError{status: status, message: env.Message}
+	var text string
+	var detail struct {
+		Code    string          `json:"code"`
+		Message string          `json:"message"`
+		Details json.RawMessage `json:"details"`
+	}
+	if json.Unmarshal(env.Error, &text) == nil && text != "" {
+		e.message = text
+	} else if json.Unmarshal(env.Error, &detail) == nil && detail.Message != "" {
+		e.code, e.message, e.details = detail.Code, detail.Message, detail.Details
+	}
+	if e.message == "" {
+		e.message = http.StatusText(status)
+	}
+	return e
+}
+
+// stream 读取 Server-Sent Events，每个事件调用一次 handle，直到连接关闭或 ctx 取消
+func (c *client) stream(ctx context.Context, path string, query url.Values, handle func(event string, data []byte) error) error {
+	req, err := c.newRequest(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	// 跟随日志不设置整体超时
+	resp, err := (&http.Client{Transport: c.http.Transport}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		var env envelope
+		data, _ := io.ReadAll(resp.Body)
+		_ = json.Unmarshal(data, &env)
+		return decodeError(resp.StatusCode, env)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var event string
+	var data bytes.Buffer
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if data.Len() > 0 {
+				if err := handle(event, data.Bytes()); err != nil {
+					return err
+				}
+			}
+			event = ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// 心跳注释
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+func (c *client) newRequest(ctx context.Context, method, path string, query url.Values, body io.Reader) (*http.Request, error) {
+	target := c.server + "/api/v1" + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("AuthorToken", c.token)
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return req, nil
+}
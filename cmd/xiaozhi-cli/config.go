@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const configUsage = `用法:
+  xiaozhi-cli config export [-file <输出文件>]
+  xiaozhi-cli config import <配置文件|->
+  xiaozhi-cli config apply <清单文件|-> [-dry-run]
+
+导入时文件中未出现的字段保持服务端当前值，多数配置在重启服务后生效。
+apply 将供应商、提示词模板、设备分组和工作流调整为清单描述的状态，清单中出现的部分会删除多余的同类资源；
+-dry-run 只显示变更计划`
+
+// applyPlan 配置清单的变更计划
+type applyPlan struct {
+	Changes []struct {
+		Kind    string   `json:"kind"`
+		Name    string   `json:"name"`
+		Action  string   `json:"action"`
+		Fields  []string `json:"fields"`
+		Applied bool     `json:"applied"`
+		Error   string   `json:"error"`
+	} `json:"changes"`
+	Unchanged int  `json:"unchanged"`
+	DryRun    bool `json:"dry_run"`
+}
+
+// runConfig config 命令
+func runConfig(ctx context.Context, opts *globalOptions, args []string) error {
+	fs := opts.flagSet("config")
+	file := fs.String("file", "", "export: 写入文件而不是标准输出")
+	dryRun := fs.Bool("dry-run", false, "apply: 只显示变更计划，不做修改")
+	fs.Usage = func() { _, _ = fmt.Fprintln(opts.stderr, configUsage) }
+
+	positional, err := opts.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+	c, err := opts.client()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case positional[0] == "export" && len(positional) == 1:
+		var cfg json.RawMessage
+		if err := c.get(ctx, "/config/export", nil, &cfg); err != nil {
+			return err
+		}
+		if *file == "" {
+			return printJSON(opts.stdout, cfg)
+		}
+		out, err := os.OpenFile(*file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+		if err != nil {
+			return err
+		}
+		if err := printJSON(out, cfg); err != nil {
+			_ = out.Close()
+			return err
+		}
+		if err := out.Close(); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(opts.stderr, "配置已导出到 %s\n", *file)
+		return nil
+	case positional[0] == "import" && len(positional) == 2:
+		data, err := readInput(positional[1])
+		if err != nil {
+			return err
+		}
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(data, &object); err != nil {
+			return fmt.Errorf("%s 不是有效的 JSON 对象: %w", positional[1], err)
+		}
+		if err := c.post(ctx, "/config/import", nil, data, nil); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(opts.stdout, "配置导入成功，重启服务后生效")
+		return nil
+	case positional[0] == "apply" && len(positional) == 2:
+		data, err := readInput(positional[1])
+		if err != nil {
+			return err
+		}
+		query := url.Values{}
+		if *dryRun {
+			query.Set("dry_run", "true")
+		}
+		var raw json.RawMessage
+		err = c.post(ctx, "/config/apply", query, data, &raw)
+		// 部分变更执行后失败时，错误详情中带有计划
+		var apiErr *apiError
+		if errors.As(err, &apiErr) && len(apiErr.details) > 0 && string(apiErr.details) != "null" {
+			raw = apiErr.details
+		} else if err != nil {
+			return err
+		}
+		if printErr := printPlan(opts, raw); printErr != nil {
+			return printErr
+		}
+		return err
+	default:
+		fs.Usage()
+		return errUsage
+	}
+}
+
+func printPlan(opts *globalOptions, raw json.RawMessage) error {
+	if opts.json() {
+		return printJSON(opts.stdout, raw)
+	}
+	var plan applyPlan
+	if err := json.Unmarshal(raw, &plan); err != nil {
+		return err
+	}
+	if len(plan.Changes) == 0 {
+		_, _ = fmt.Fprintf(opts.stdout, "无需变更，%d 项资源已与清单一致\n", plan.Unchanged)
+		return nil
+	}
+
+	t := newTable(opts.stdout, "ACTION", "KIND", "NAME", "FIELDS", "STATUS")
+	for _, change := range plan.Changes {
+		status := "planned"
+		switch {
+		case change.Error != "":
+			status = "failed: " + change.Error
+		case change.Applied:
+			status = "applied"
+		case !plan.DryRun:
+			status = "skipped"
+		}
+		t.row(change.Action, change.Kind, change.Name, orDash(strings.Join(change.Fields, ",")), status)
+	}
+	if err := t.flush(); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(opts.stdout, "%d 项变更，%d 项无变化\n", len(plan.Changes), plan.Unchanged)
+	return nil
+}
+
+const providerUsage = `用法:
+  xiaozhi-cli provider test <能力ID> [-config <配置文件>] [-set key=value ...] [-timeout 15s]
+
+对能力执行一次最小调用验证配置（如 api_key、model），测试失败时以非零状态退出`
+
+// runProvider provider 命令
+func runProvider(ctx context.Context, opts *globalOptions, args []string) error {
+	fs := opts.flagSet("provider")
+	configFile := fs.String("config", "", "从 JSON 文件读取能力配置")
+	values := keyValues{}
+	fs.Var(values, "set", "配置项 key=value，覆盖 -config 中的同名项，可重复")
+	timeout := fs.String("timeout", "", "测试超时时间，如 15s，最长 1m")
+	fs.Usage = func() { _, _ = fmt.Fprintln(opts.stderr, providerUsage) }
+
+	positional, err := opts.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 || positional[0] != "test" {
+		fs.Usage()
+		return errUsage
+	}
+	c, err := opts.client()
+	if err != nil {
+		return err
+	}
+
+	config := map[string]interface{}{}
+	if *configFile != "" {
+		if config, err = readJSONObject(*configFile); err != nil {
+			return err
+		}
+	}
+	for k, v := range values {
+		config[k] = v
+	}
+
+	var result struct {
+		CapabilityID string `json:"capability_id"`
+		ProviderID   string `json:"provider_id"`
+		Type         string `json:"type"`
+		Success      bool   `json:"success"`
+		Message      string `json:"message"`
+		LatencyMs    int64  `json:"latency_ms"`
+	}
+	request := map[string]interface{}{
+		"capability_id": positional[1],
+		"config":        config,
+		"timeout":       *timeout,
+	}
+	if err := c.post(ctx, "/config/providers/test", nil, request, &result); err != nil {
+		return err
+	}
+	if opts.json() {
+		if err := printJSON(opts.stdout, result); err != nil {
+			return err
+		}
+	} else {
+		t := newTable(opts.stdout, "CAPABILITY", "PROVIDER", "TYPE", "SUCCESS", "LATENCY", "MESSAGE")
+		t.row(result.CapabilityID, result.ProviderID, result.Type, fmt.Sprint(result.Success), fmt.Sprintf("%dms", result.LatencyMs), result.Message)
+		if err := t.flush(); err != nil {
+			return err
+		}
+	}
+	if !result.Success {
+		return fmt.Errorf("能力 %s 配置测试失败", result.CapabilityID)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"xiaozhi-server-go/gen/go/apiclient"
+)
+
+const devicesUsage = `用法:
+  xiaozhi-cli devices register <设备ID> -name <名称> -type <类型> [-model <型号>] [-version <版本>] [-meta key=value ...]`
+
+// runDevices devices 命令
+func runDevices(ctx context.Context, opts *globalOptions, args []string) error {
+	fs := opts.flagSet("devices")
+	name := fs.String("name", "", "设备名称")
+	deviceType := fs.String("type", "", "设备类型")
+	model := fs.String("model", "", "设备型号")
+	version := fs.String("version", "", "固件版本")
+	metadata := keyValues{}
+	fs.Var(metadata, "meta", "元数据 key=value，可重复")
+	fs.Usage = func() { _, _ = fmt.Fprintln(opts.stderr, devicesUsage) }
+
+	positional, err := opts.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 || positional[0] != "register" {
+		fs.Usage()
+		return errUsage
+	}
+	if *name == "" || *deviceType == "" {
+		return fmt.Errorf("注册设备需要 -name 和 -type")
+	}
+	c, err := opts.client()
+	if err != nil {
+		return err
+	}
+
+	request := &apiclient.DeviceRegistrationRequest{
+		DeviceID:   positional[1],
+		DeviceName: *name,
+		DeviceType: *deviceType,
+		Model:      *model,
+		Version:    *version,
+	}
+	if len(metadata) > 0 {
+		request.Metadata = map[string]interface{}(metadata)
+	}
+	device, err := c.api.PostDevices(ctx, request)
+	if err != nil {
+		return fromAPIError(err)
+	}
+	if opts.json() {
+		return printJSON(opts.stdout, device)
+	}
+	t := newTable(opts.stdout, "ID", "DEVICE_ID", "NAME", "TYPE", "STATUS", "ACTIVATED")
+	t.row(fmt.Sprint(device.ID), device.DeviceID, device.DeviceName, device.DeviceType, orDash(device.Status), fmt.Sprint(device.IsActivated))
+	return t.flush()
+}
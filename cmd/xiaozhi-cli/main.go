@@ -0,0 +1,126 @@
+// xiaozhi-cli 小智服务端命令行管理工具
+//
+// 通过 /api/v1 HTTP 接口完成常用运维操作：插件列表与启停、插件日志、运行工作流、
+// 注册设备、测试供应商配置以及配置导入导出。多个服务端以 profile 区分，
+// 输出默认为表格，-o json 输出原始 JSON 便于脚本处理。
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+)
+
+// command 子命令
+type command struct {
+	usage string
+	run   func(ctx context.Context, opts *globalOptions, args []string) error
+}
+
+var commands = map[string]command{
+	"plugins":  {usage: "插件列表、详情、启停和日志", run: runPlugins},
+	"workflow": {usage: "运行工作流并查看执行结果", run: runWorkflow},
+	"devices":  {usage: "注册设备", run: runDevices},
+	"provider": {usage: "测试供应商配置", run: runProvider},
+	"config":   {usage: "导出和导入服务配置", run: runConfig},
+	"profile":  {usage: "管理服务端 profile", run: runProfile},
+}
+
+// errUsage 参数错误，已打印用法
+var errUsage = errors.New("usage")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	opts := &globalOptions{stdout: os.Stdout, stderr: os.Stderr}
+	if err := run(ctx, opts, os.Args[1:]); err != nil {
+		if !errors.Is(err, errUsage) {
+			_, _ = fmt.Fprintf(os.Stderr, "xiaozhi-cli: %v\n", err)
+		}
+		stop()
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, opts *globalOptions, args []string) error {
+	fs := opts.flagSet("xiaozhi-cli")
+	fs.Usage = func() { printUsage(opts.stderr) }
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return errUsage
+	}
+	if fs.NArg() == 0 {
+		printUsage(opts.stderr)
+		return errUsage
+	}
+
+	name := fs.Arg(0)
+	if name == "help" {
+		printUsage(opts.stdout)
+		return nil
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		_, _ = fmt.Fprintf(opts.stderr, "未知命令: %s\n\n", name)
+		printUsage(opts.stderr)
+		return errUsage
+	}
+	return cmd.run(ctx, opts, fs.Args()[1:])
+}
+
+func printUsage(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "用法: xiaozhi-cli [全局参数] <命令> <子命令> [参数]")
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "命令:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		_, _ = fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].usage)
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "全局参数（也可写在子命令之后）:")
+	_, _ = fmt.Fprintln(w, "  -profile string  使用的 profile，默认为当前 profile")
+	_, _ = fmt.Fprintln(w, "  -server string   服务端地址，覆盖 profile，如 http://127.0.0.1:8080")
+	_, _ = fmt.Fprintln(w, "  -token string    API Token，覆盖 profile")
+	_, _ = fmt.Fprintln(w, "  -o string        输出格式: table 或 json (默认 table)")
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "环境变量 XIAOZHI_SERVER、XIAOZHI_TOKEN、XIAOZHI_PROFILE 与对应参数等效，参数优先")
+}
+
+// keyValues 可重复的 key=value 参数，值按 JSON 解析失败时视为字符串
+type keyValues map[string]interface{}
+
+func (kv keyValues) String() string {
+	pairs := make([]string, 0, len(kv))
+	for k, v := range kv {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", k, v))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (kv keyValues) Set(value string) error {
+	key, raw, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("需要 key=value 格式: %s", value)
+	}
+	var v interface{}
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		v = raw
+	}
+	kv[key] = v
+	return nil
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pluginStatus 插件状态，对应 GET /api/v1/plugins 的列表项
+type pluginStatus struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Description  string `json:"description"`
+	Version      string `json:"version"`
+	Status       string `json:"status"`
+	Address      string `json:"address"`
+	Port         int    `json:"port"`
+	HealthStatus string `json:"health_status"`
+	Error        string `json:"error,omitempty"`
+	Capabilities []struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+		Name string `json:"name"`
+	} `json:"capabilities"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// pluginLogEntry 插件日志
+type pluginLogEntry struct {
+	Seq      uint64    `json:"seq"`
+	Time     time.Time `json:"time"`
+	PluginID string    `json:"plugin_id"`
+	Stream   string    `json:"stream"`
+	Level    string    `json:"level"`
+	Message  string    `json:"message"`
+}
+
+const pluginsUsage = `用法:
+  xiaozhi-cli plugins list [-type <类型>] [-status <状态>] [-search <关键字>]
+  xiaozhi-cli plugins get <插件ID>
+  xiaozhi-cli plugins start|stop|restart <插件ID>
+  xiaozhi-cli plugins logs <插件ID> [-n 100] [-level info] [-f]`
+
+// runPlugins plugins 命令
+func runPlugins(ctx context.Context, opts *globalOptions, args []string) error {
+	fs := opts.flagSet("plugins")
+	pluginType := fs.String("type", "", "list: 按插件类型筛选")
+	status := fs.String("status", "", "list: 按运行状态筛选")
+	search := fs.String("search", "", "list: 按名称或描述搜索")
+	tail := fs.Int("n", 100, "logs: 显示最近的日志条数")
+	level := fs.String("level", "", "logs: 最低日志级别 debug/info/warn/error")
+	follow := fs.Bool("f", false, "logs: 持续输出新日志")
+	fs.Usage = func() { _, _ = fmt.Fprintln(opts.stderr, pluginsUsage) }
+
+	positional, err := opts.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+	c, err := opts.client()
+	if err != nil {
+		return err
+	}
+
+	sub := positional[0]
+	if sub == "list" || sub == "ls" {
+		return listPlugins(ctx, opts, c, *pluginType, *status, *search)
+	}
+	if len(positional) != 2 {
+		fs.Usage()
+		return errUsage
+	}
+	id := positional[1]
+	switch sub {
+	case "get":
+		var plugin pluginStatus
+		if err := c.get(ctx, "/plugins/"+url.PathEscape(id), nil, &plugin); err != nil {
+			return err
+		}
+		return printPlugin(opts, &plugin)
+	case "start", "stop", "restart":
+		return controlPlugin(ctx, opts, c, id, sub)
+	case "logs":
+		return pluginLogs(ctx, opts, c, id, *tail, *level, *follow)
+	default:
+		fs.Usage()
+		return errUsage
+	}
+}
+
+func listPlugins(ctx context.Context, opts *globalOptions, c *client, pluginType, status, search string) error {
+	query := url.Values{"page": {"1"}, "page_size": {"100"}}
+	if pluginType != "" {
+		query.Set("type", pluginType)
+	}
+	if status != "" {
+		query.Set("status", status)
+	}
+	if search != "" {
+		query.Set("search", search)
+	}
+
+	var list struct {
+		Total   int            `json:"total"`
+		Plugins []pluginStatus `json:"plugins"`
+	}
+	if err := c.get(ctx, "/plugins/", query, &list); err != nil {
+		return err
+	}
+	sort.Slice(list.Plugins, func(i, j int) bool { return list.Plugins[i].ID < list.Plugins[j].ID })
+	if opts.json() {
+		return printJSON(opts.stdout, list.Plugins)
+	}
+
+	t := newTable(opts.stdout, "ID", "NAME", "TYPE", "STATUS", "HEALTH", "PORT", "CAPABILITIES")
+	for _, p := range list.Plugins {
+		port := "-"
+		if p.Port > 0 {
+			port = strconv.Itoa(p.Port)
+		}
+		t.row(p.ID, p.Name, p.Type, p.Status, orDash(p.HealthStatus), port, strconv.Itoa(len(p.Capabilities)))
+	}
+	if err := t.flush(); err != nil {
+		return err
+	}
+	if list.Total > len(list.Plugins) {
+		_, _ = fmt.Fprintf(opts.stderr, "仅显示前 %d 个，共 %d 个插件，请使用筛选条件缩小范围\n", len(list.Plugins), list.Total)
+	}
+	return nil
+}
+
+func printPlugin(opts *globalOptions, p *pluginStatus) error {
+	if opts.json() {
+		return printJSON(opts.stdout, p)
+	}
+	t := newTable(opts.stdout, "FIELD", "VALUE")
+	t.row("ID", p.ID)
+	t.row("名称", p.Name)
+	t.row("类型", p.Type)
+	t.row("版本", orDash(p.Version))
+	t.row("状态", p.Status)
+	t.row("健康", orDash(p.HealthStatus))
+	if p.Port > 0 {
+		t.row("地址", fmt.Sprintf("%s:%d", p.Address, p.Port))
+	}
+	t.row("错误", orDash(p.Error))
+	t.row("更新时间", formatTime(p.UpdatedAt))
+	for _, capItem := range p.Capabilities {
+		t.row("能力", fmt.Sprintf("%s (%s) %s", capItem.ID, capItem.Type, capItem.Name))
+	}
+	return t.flush()
+}
+
+func controlPlugin(ctx context.Context, opts *globalOptions, c *client, id, action string) error {
+	var result struct {
+		Success   bool   `json:"success"`
+		Message   string `json:"message"`
+		OldStatus string `json:"old_status"`
+		NewStatus string `json:"new_status"`
+	}
+	body := map[string]string{"action": action}
+	if err := c.post(ctx, "/plugins/"+url.PathEscape(id)+"/control", nil, body, &result); err != nil {
+		return err
+	}
+	if opts.json() {
+		return printJSON(opts.stdout, result)
+	}
+	_, _ = fmt.Fprintf(opts.stdout, "%s: %s -> %s %s\n", id, orDash(result.OldStatus), orDash(result.NewStatus), result.Message)
+	return nil
+}
+
+func pluginLogs(ctx context.Context, opts *globalOptions, c *client, id string, tail int, level string, follow bool) error {
+	query := url.Values{"tail": {strconv.Itoa(tail)}}
+	if level != "" {
+		query.Set("level", strings.ToLower(level))
+	}
+	path := "/plugins/" + url.PathEscape(id) + "/logs"
+
+	if !follow {
+		var result struct {
+			Entries []pluginLogEntry `json:"entries"`
+		}
+		if err := c.get(ctx, path, query, &result); err != nil {
+			return err
+		}
+		for _, entry := range result.Entries {
+			if err := printLogEntry(opts, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	query.Set("follow", "true")
+	return c.stream(ctx, path, query, func(event string, data []byte) error {
+		if event != "log" {
+			return nil
+		}
+		var entry pluginLogEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return fmt.Errorf("无法解析日志: %w", err)
+		}
+		return printLogEntry(opts, entry)
+	})
+}
+
+func printLogEntry(opts *globalOptions, entry pluginLogEntry) error {
+	if opts.json() {
+		// 每行一条，便于管道处理
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(opts.stdout, string(data))
+		return err
+	}
+	_, err := fmt.Fprintf(opts.stdout, "%s %-5s [%s] %s\n",
+		entry.Time.Local().Format("15:04:05.000"), strings.ToUpper(entry.Level), entry.Stream, entry.Message)
+	return err
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const defaultServer = "http://127.0.0.1:8080"
+
+// globalOptions 全局参数，所有子命令共享
+type globalOptions struct {
+	profile string
+	server  string
+	token   string
+	output  string
+
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// flagSet 创建注册了全局参数的 FlagSet，使全局参数既可写在命令前也可写在子命令后
+func (o *globalOptions) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(o.stderr)
+	fs.StringVar(&o.profile, "profile", o.profile, "使用的 profile")
+	fs.StringVar(&o.server, "server", o.server, "服务端地址")
+	fs.StringVar(&o.token, "token", o.token, "API Token")
+	fs.StringVar(&o.output, "o", o.output, "输出格式: table 或 json")
+	return fs
+}
+
+// parse 解析参数，允许参数和位置参数交错出现
+func (o *globalOptions) parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			// flag 包已打印错误和用法
+			return nil, errUsage
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+	switch o.output {
+	case "", "table", "json":
+	default:
+		return nil, fmt.Errorf("不支持的输出格式: %s", o.output)
+	}
+	return positional, nil
+}
+
+// json 是否输出 JSON
+func (o *globalOptions) json() bool {
+	return o.output == "json"
+}
+
+// client 按 参数 > 环境变量 > profile 的优先级确定服务端并创建客户端
+func (o *globalOptions) client() (*client, error) {
+	server := firstNonEmpty(o.server, os.Getenv("XIAOZHI_SERVER"))
+	token := firstNonEmpty(o.token, os.Getenv("XIAOZHI_TOKEN"))
+
+	if server == "" || token == "" {
+		store, err := loadProfiles()
+		if err != nil {
+			return nil, err
+		}
+		name := firstNonEmpty(o.profile, os.Getenv("XIAOZHI_PROFILE"), store.Current)
+		if name != "" {
+			p, ok := store.Profiles[name]
+			if !ok {
+				return nil, fmt.Errorf("profile 不存在: %s", name)
+			}
+			server = firstNonEmpty(server, p.Server)
+			token = firstNonEmpty(token, p.Token)
+		}
+	}
+	return newClient(firstNonEmpty(server, defaultServer), token), nil
+}
+
+// profile 单个服务端的连接信息
+type profile struct {
+	Server string `json:"server"`
+	Token  string `json:"token,omitempty"`
+}
+
+// profileStore profile 配置文件内容
+type profileStore struct {
+	Current  string              `json:"current,omitempty"`
+	Profiles map[string]*profile `json:"profiles"`
+}
+
+// profilePath 配置文件路径，可通过 XIAOZHI_CLI_CONFIG 指定
+func profilePath() (string, error) {
+	if path := os.Getenv("XIAOZHI_CLI_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("无法确定配置目录: %w", err)
+	}
+	return filepath.Join(dir, "xiaozhi", "cli.json"), nil
+}
+
+func loadProfiles() (*profileStore, error) {
+	store := &profileStore{Profiles: make(map[string]*profile)}
+	path, err := profilePath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("解析 %s 失败: %w", path, err)
+	}
+	if store.Profiles == nil {
+		store.Profiles = make(map[string]*profile)
+	}
+	return store, nil
+}
+
+// save 保存配置文件，文件包含 Token，仅当前用户可读
+func (s *profileStore) save() error {
+	path, err := profilePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// runProfile profile 命令
+func runProfile(_ context.Context, opts *globalOptions, args []string) error {
+	fs := opts.flagSet("profile")
+	use := fs.Bool("use", false, "add 时同时设为当前 profile")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(opts.stderr, `用法:
+  xiaozhi-cli profile list
+  xiaozhi-cli profile add <名称> -server <地址> [-token <Token>] [-use]
+  xiaozhi-cli profile use <名称>
+  xiaozhi-cli profile remove <名称>`)
+	}
+	positional, err := opts.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	store, err := loadProfiles()
+	if err != nil {
+		return err
+	}
+	switch sub := positional[0]; {
+	case sub == "list" || sub == "ls":
+		return printProfiles(opts, store)
+	case len(positional) != 2:
+		fs.Usage()
+		return errUsage
+	case sub == "add":
+		if opts.server == "" {
+			return fmt.Errorf("请使用 -server 指定服务端地址")
+		}
+		store.Profiles[positional[1]] = &profile{Server: strings.TrimRight(opts.server, "/"), Token: opts.token}
+		if *use || store.Current == "" {
+			store.Current = positional[1]
+		}
+	case sub == "use":
+		if _, ok := store.Profiles[positional[1]]; !ok {
+			return fmt.Errorf("profile 不存在: %s", positional[1])
+		}
+		store.Current = positional[1]
+	case sub == "remove" || sub == "rm":
+		if _, ok := store.Profiles[positional[1]]; !ok {
+			return fmt.Errorf("profile 不存在: %s", positional[1])
+		}
+		delete(store.Profiles, positional[1])
+		if store.Current == positional[1] {
+			store.Current = ""
+		}
+	default:
+		fs.Usage()
+		return errUsage
+	}
+	return store.save()
+}
+
+func printProfiles(opts *globalOptions, store *profileStore) error {
+	names := make([]string, 0, len(store.Profiles))
+	for name := range store.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if opts.json() {
+		// JSON 输出同样隐藏 Token
+		masked := profileStore{Current: store.Current, Profiles: make(map[string]*profile, len(names))}
+		for _, name := range names {
+			masked.Profiles[name] = &profile{Server: store.Profiles[name].Server, Token: maskToken(store.Profiles[name].Token)}
+		}
+		return printJSON(opts.stdout, masked)
+	}
+
+	t := newTable(opts.stdout, "CURRENT", "NAME", "SERVER", "TOKEN")
+	for _, name := range names {
+		current := ""
+		if name == store.Current {
+			current = "*"
+		}
+		t.row(current, name, store.Profiles[name].Server, maskToken(store.Profiles[name].Token))
+	}
+	return t.flush()
+}
+
+func maskToken(token string) string {
+	if len(token) <= 4 {
+		return strings.Repeat("*", len(token))
+	}
+	return token[:2] + strings.Repeat("*", len(token)-4) + token[len(token)-2:]
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"time"
+)
+
+// execution 工作流执行记录
+type execution struct {
+	ID          string                 `json:"id"`
+	WorkflowID  string                 `json:"workflow_id"`
+	Status      string                 `json:"status"`
+	StartTime   time.Time              `json:"start_time"`
+	EndTime     *time.Time             `json:"end_time,omitempty"`
+	NodeResults map[string]*nodeResult `json:"node_results"`
+	Outputs     map[string]interface{} `json:"outputs"`
+	Error       string                 `json:"error,omitempty"`
+
+	raw json.RawMessage // 服务端返回的完整记录，-o json 时原样输出
+}
+
+// nodeResult 节点执行结果，elapsed_time 为纳秒
+type nodeResult struct {
+	NodeID      string    `json:"node_id"`
+	Status      string    `json:"status"`
+	StartTime   time.Time `json:"start_time"`
+	Error       string    `json:"error,omitempty"`
+	ElapsedTime int64     `json:"elapsed_time"`
+	Attempts    int       `json:"attempts,omitempty"`
+}
+
+// fetch 发送请求并解析执行记录，保留原始响应
+func (e *execution) fetch(ctx context.Context, c *client, method, path string, body interface{}) error {
+	var raw json.RawMessage
+	if err := c.do(ctx, method, path, nil, body, &raw); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, e); err != nil {
+		return fmt.Errorf("无法解析执行记录: %w", err)
+	}
+	e.raw = raw
+	return nil
+}
+
+// finished 是否已结束
+func (e *execution) finished() bool {
+	switch e.Status {
+	case "completed", "failed", "cancelled":
+		return true
+	}
+	return false
+}
+
+const workflowUsage = `用法:
+  xiaozhi-cli workflow run [工作流ID] [-input key=value ...] [-inputs <文件>] [-file <工作流文件>] [-dry-run] [-no-wait] [-timeout 5m]
+  xiaozhi-cli workflow status <执行ID>
+
+run 未指定工作流ID时运行当前工作流；-file 运行未保存的工作流定义；
+默认等待执行结束，执行失败时以非零状态退出`
+
+// runWorkflow workflow 命令
+func runWorkflow(ctx context.Context, opts *globalOptions, args []string) error {
+	fs := opts.flagSet("workflow")
+	inputs := keyValues{}
+	fs.Var(inputs, "input", "输入参数 key=value，值按 JSON 解析失败时视为字符串，可重复")
+	inputsFile := fs.String("inputs", "", "从 JSON 文件读取输入参数")
+	workflowFile := fs.String("file", "", "运行 JSON 文件中的工作流定义而不是已保存的工作流")
+	dryRun := fs.Bool("dry-run", false, "只校验工作流，不实际执行")
+	noWait := fs.Bool("no-wait", false, "提交后立即返回，不等待执行结束")
+	timeout := fs.Duration("timeout", 5*time.Minute, "等待执行结束的最长时间")
+	fs.Usage = func() { _, _ = fmt.Fprintln(opts.stderr, workflowUsage) }
+
+	positional, err := opts.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+	c, err := opts.client()
+	if err != nil {
+		return err
+	}
+
+	switch positional[0] {
+	case "run":
+		if len(positional) > 2 {
+			fs.Usage()
+			return errUsage
+		}
+		request := map[string]interface{}{}
+		if *inputsFile != "" {
+			fileInputs, err := readJSONObject(*inputsFile)
+			if err != nil {
+				return err
+			}
+			for k, v := range fileInputs {
+				inputs[k] = v
+			}
+		}
+		request["inputs"] = map[string]interface{}(inputs)
+
+		var workflowID string
+		if len(positional) == 2 {
+			workflowID = positional[1]
+		}
+		if *workflowFile != "" {
+			definition, err := readJSONObject(*workflowFile)
+			if err != nil {
+				return err
+			}
+			request["workflow"] = definition
+			if id, _ := definition["id"].(string); workflowID == "" {
+				workflowID = id
+			}
+		}
+		if workflowID == "" {
+			var current struct {
+				ID string `json:"id"`
+			}
+			if err := c.get(ctx, "/workflow/current", nil, &current); err != nil {
+				return fmt.Errorf("获取当前工作流失败: %w", err)
+			}
+			workflowID = current.ID
+		}
+
+		if *dryRun {
+			return dryRunWorkflow(ctx, opts, c, workflowID, request)
+		}
+		return executeWorkflow(ctx, opts, c, workflowID, request, !*noWait, *timeout)
+	case "status":
+		if len(positional) != 2 {
+			fs.Usage()
+			return errUsage
+		}
+		var exec execution
+		if err := exec.fetch(ctx, c, http.MethodGet, "/executions/"+url.PathEscape(positional[1]), nil); err != nil {
+			return err
+		}
+		return printExecution(opts, &exec)
+	default:
+		fs.Usage()
+		return errUsage
+	}
+}
+
+func executeWorkflow(ctx context.Context, opts *globalOptions, c *client, workflowID string, request map[string]interface{}, wait bool, timeout time.Duration) error {
+	var exec execution
+	if err := exec.fetch(ctx, c, http.MethodPost, "/workflows/"+url.PathEscape(workflowID)+"/execute", request); err != nil {
+		return err
+	}
+	if !wait {
+		if opts.json() {
+			return printJSON(opts.stdout, exec.raw)
+		}
+		_, _ = fmt.Fprintf(opts.stdout, "已提交执行 %s，使用 xiaozhi-cli workflow status %s 查看结果\n", exec.ID, exec.ID)
+		return nil
+	}
+
+	if !opts.json() {
+		_, _ = fmt.Fprintf(opts.stderr, "执行 %s 已开始，等待结束...\n", exec.ID)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for !exec.finished() {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("等待执行 %s 结束超时，当前状态 %s", exec.ID, exec.Status)
+		case <-ticker.C:
+		}
+		if err := exec.fetch(ctx, c, http.MethodGet, "/executions/"+url.PathEscape(exec.ID), nil); err != nil {
+			return err
+		}
+	}
+
+	if err := printExecution(opts, &exec); err != nil {
+		return err
+	}
+	if exec.Status != "completed" {
+		return fmt.Errorf("执行 %s 状态为 %s", exec.ID, exec.Status)
+	}
+	return nil
+}
+
+func dryRunWorkflow(ctx context.Context, opts *globalOptions, c *client, workflowID string, request map[string]interface{}) error {
+	var report struct {
+		WorkflowID string   `json:"workflow_id"`
+		Valid      bool     `json:"valid"`
+		Order      []string `json:"order"`
+		Issues     []struct {
+			Severity string `json:"severity"`
+			NodeID   string `json:"node_id"`
+			EdgeID   string `json:"edge_id"`
+			Field    string `json:"field"`
+			Message  string `json:"message"`
+		} `json:"issues"`
+	}
+	query := url.Values{"dry_run": {"true"}}
+	if err := c.post(ctx, "/workflows/"+url.PathEscape(workflowID)+"/execute", query, request, &report); err != nil {
+		return err
+	}
+	if opts.json() {
+		if err := printJSON(opts.stdout, report); err != nil {
+			return err
+		}
+	} else {
+		result := "通过"
+		if !report.Valid {
+			result = "未通过"
+		}
+		_, _ = fmt.Fprintf(opts.stdout, "工作流 %s 校验%s，执行顺序: %v\n", report.WorkflowID, result, report.Order)
+		if len(report.Issues) > 0 {
+			t := newTable(opts.stdout, "SEVERITY", "NODE", "EDGE", "FIELD", "MESSAGE")
+			for _, issue := range report.Issues {
+				t.row(issue.Severity, orDash(issue.NodeID), orDash(issue.EdgeID), orDash(issue.Field), issue.Message)
+			}
+			if err := t.flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if !report.Valid {
+		return fmt.Errorf("工作流 %s 校验未通过", report.WorkflowID)
+	}
+	return nil
+}
+
+func printExecution(opts *globalOptions, exec *execution) error {
+	if opts.json() {
+		return printJSON(opts.stdout, exec.raw)
+	}
+	duration := "-"
+	if exec.EndTime != nil {
+		duration = exec.EndTime.Sub(exec.StartTime).Round(time.Millisecond).String()
+	}
+	_, _ = fmt.Fprintf(opts.stdout, "执行 %s  工作流 %s  状态 %s  耗时 %s\n", exec.ID, exec.WorkflowID, exec.Status, duration)
+	if exec.Error != "" {
+		_, _ = fmt.Fprintf(opts.stdout, "错误: %s\n", exec.Error)
+	}
+
+	results := make([]*nodeResult, 0, len(exec.NodeResults))
+	for _, result := range exec.NodeResults {
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].StartTime.Before(results[j].StartTime) })
+	t := newTable(opts.stdout, "NODE", "STATUS", "ELAPSED", "ATTEMPTS", "ERROR")
+	for _, result := range results {
+		attempts := "-"
+		if result.Attempts > 0 {
+			attempts = fmt.Sprint(result.Attempts)
+		}
+		t.row(result.NodeID, result.Status, time.Duration(result.ElapsedTime).Round(time.Millisecond).String(), attempts, orDash(result.Error))
+	}
+	if err := t.flush(); err != nil {
+		return err
+	}
+
+	if len(exec.Outputs) > 0 {
+		_, _ = fmt.Fprintln(opts.stdout, "输出:")
+		return printJSON(opts.stdout, exec.Outputs)
+	}
+	return nil
+}
+
+// readJSONObject 读取 JSON 对象文件，- 表示标准输入
+func readJSONObject(path string) (map[string]interface{}, error) {
+	data, err := readInput(path)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("%s 不是有效的 JSON 对象: %w", path, err)
+	}
+	return object, nil
+}
+
+// readInput 读取文件内容，- 表示标准输入
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"xiaozhi-server-go/internal/bootstrap"
+	"xiaozhi-server-go/internal/domain/backup"
+	"xiaozhi-server-go/internal/domain/script"
+)
+
+// subcommands 服务之外运行的子命令，不带子命令时启动服务
+var subcommands = map[string]func(ctx context.Context, args []string) error{
+	"backup":  runBackup,
+	"restore": runRestore,
+	"doctor":  runDoctor,
+
+	script.WorkerCommand: script.ServeWorker,
+}
+
+// runBackup 创建备份，缺省保存到 Backup.Dir
+func runBackup(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("xiaozhi-server backup", flag.ContinueOnError)
+	output := fs.String("o", "", "备份文件路径，缺省保存到配置的备份目录")
+	passphrase := fs.String("passphrase", "", "加密口令，缺省依次使用环境变量 "+backup.PassphraseEnv+" 和 Backup.Passphrase")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(fs.Output(), "用法: xiaozhi-server backup [-o 文件] [-passphrase 口令]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	service, err := bootstrap.NewBackupService()
+	if err != nil {
+		return err
+	}
+	service.UsePassphrase(*passphrase)
+
+	if *output == "" {
+		archive, err := service.Create(ctx, false)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("备份已保存到 %s/%s（%d 字节）\n", service.Dir(), archive.Name, archive.Size)
+		return nil
+	}
+
+	f, err := os.OpenFile(*output, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	manifest, err := service.WriteTo(ctx, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(*output)
+		return err
+	}
+	fmt.Printf("备份已保存到 %s，%d 个文件\n", *output, len(manifest.Entries))
+	return nil
+}
+
+// runRestore 校验备份并替换数据库和备份中的文件，需要先停止服务
+func runRestore(_ context.Context, args []string) error {
+	fs := flag.NewFlagSet("xiaozhi-server restore", flag.ContinueOnError)
+	passphrase := fs.String("passphrase", "", "加密口令，缺省使用环境变量 "+backup.PassphraseEnv)
+	verifyOnly := fs.Bool("verify", false, "只校验备份，不恢复")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(fs.Output(), "用法: xiaozhi-server restore [-verify] [-passphrase 口令] <备份文件>")
+		_, _ = fmt.Fprintln(fs.Output(), "恢复前请先停止服务，被替换的文件改名为 *.pre-restore-<时间> 保留")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("需要指定备份文件")
+	}
+	if *passphrase == "" {
+		*passphrase = os.Getenv(backup.PassphraseEnv)
+	}
+	if *passphrase == "" {
+		return fmt.Errorf("请通过 -passphrase 或环境变量 %s 提供口令", backup.PassphraseEnv)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if *verifyOnly {
+		result, err := backup.VerifyArchive(f, *passphrase)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("校验通过：%s 创建，%d 个文件，%d 字节\n",
+			result.Manifest.CreatedAt.Local().Format("2006-01-02 15:04:05"), result.Files, result.Bytes)
+		return nil
+	}
+
+	result, err := backup.Stage(f, *passphrase, backup.PendingDir)
+	if err != nil {
+		return err
+	}
+	if _, err := backup.ApplyPending(backup.PendingDir, time.Now()); err != nil {
+		return err
+	}
+	fmt.Printf("已从 %s 创建的备份恢复 %d 个文件\n", result.Manifest.CreatedAt.Local().Format("2006-01-02 15:04:05"), result.Files)
+	if result.Manifest.Database == "" {
+		fmt.Println("备份中不包含数据库快照，数据库未恢复")
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"xiaozhi-server-go/internal/bootstrap"
+)
+
+// runDoctor 以只检查模式执行初始化流程并输出报告，有失败项时返回错误
+func runDoctor(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("xiaozhi-server doctor", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "以 JSON 格式输出报告")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(fs.Output(), "用法: xiaozhi-server doctor [-json]")
+		_, _ = fmt.Fprintln(fs.Output(), "检查数据库、配置与密钥、所选提供者的连通性、端口和插件目录，不修改任何数据")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	report := bootstrap.Doctor(ctx)
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else if err := report.WriteText(os.Stdout); err != nil {
+		return err
+	}
+	if !report.Passed {
+		return fmt.Errorf("自检未通过")
+	}
+	return nil
+}
@@ -13,8 +13,11 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"time"
 
 	"xiaozhi-server-go/internal/bootstrap"
@@ -22,6 +25,19 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		if run, ok := subcommands[os.Args[1]]; ok {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			err := run(ctx, os.Args[2:])
+			stop()
+			if err != nil && !errors.Is(err, flag.ErrHelp) {
+				_, _ = fmt.Fprintf(os.Stderr, "xiaozhi-server %s: %v\n", os.Args[1], err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	fmt.Printf("[%s] [INFO] [引导] 开始启动 xiaozhi-server...\n", time.Now().Format("2006-01-02 15:04:05.000"))
 	if err := bootstrap.Run(context.Background()); err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "xiaozhi-server failed: %v\n", err)
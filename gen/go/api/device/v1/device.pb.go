@@ -0,0 +1,620 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: api/device/v1/device.proto
+
+package devicev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 设备 WebSocket 协议的二进制帧
+//
+// 设备在 hello 中声明 "framings": ["protobuf", "json"] 且服务端在 hello 回复中返回
+// "framing": "protobuf" 后，双方的每条消息都以 WebSocket 二进制帧发送，内容为一个 Frame。
+// hello 及其回复始终是 JSON 文本帧；协商后双方收到的 JSON 文本帧仍按原协议处理。
+// 会话ID已在握手时确定，帧中不再重复携带。
+type Frame struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Body:
+	//
+	//	*Frame_Audio
+	//	*Frame_Listen
+	//	*Frame_Abort
+	//	*Frame_Tts
+	//	*Frame_Stt
+	//	*Frame_Emotion
+	//	*Frame_Json
+	Body          isFrame_Body `protobuf_oneof:"body"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Frame) Reset() {
+	*x = Frame{}
+	mi := &file_api_device_v1_device_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Frame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Frame) ProtoMessage() {}
+
+func (x *Frame) ProtoReflect() protoreflect.Message {
+	mi := &file_api_device_v1_device_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Frame.ProtoReflect.Descriptor instead.
+func (*Frame) Descriptor() ([]byte, []int) {
+	return file_api_device_v1_device_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Frame) GetBody() isFrame_Body {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+func (x *Frame) GetAudio() *Audio {
+	if x != nil {
+		if x, ok := x.Body.(*Frame_Audio); ok {
+			return x.Audio
+		}
+	}
+	return nil
+}
+
+func (x *Frame) GetListen() *Listen {
+	if x != nil {
+		if x, ok := x.Body.(*Frame_Listen); ok {
+			return x.Listen
+		}
+	}
+	return nil
+}
+
+func (x *Frame) GetAbort() *Abort {
+	if x != nil {
+		if x, ok := x.Body.(*Frame_Abort); ok {
+			return x.Abort
+		}
+	}
+	return nil
+}
+
+func (x *Frame) GetTts() *Tts {
+	if x != nil {
+		if x, ok := x.Body.(*Frame_Tts); ok {
+			return x.Tts
+		}
+	}
+	return nil
+}
+
+func (x *Frame) GetStt() *Stt {
+	if x != nil {
+		if x, ok := x.Body.(*Frame_Stt); ok {
+			return x.Stt
+		}
+	}
+	return nil
+}
+
+func (x *Frame) GetEmotion() *Emotion {
+	if x != nil {
+		if x, ok := x.Body.(*Frame_Emotion); ok {
+			return x.Emotion
+		}
+	}
+	return nil
+}
+
+func (x *Frame) GetJson() []byte {
+	if x != nil {
+		if x, ok := x.Body.(*Frame_Json); ok {
+			return x.Json
+		}
+	}
+	return nil
+}
+
+type isFrame_Body interface {
+	isFrame_Body()
+}
+
+type Frame_Audio struct {
+	Audio *Audio `protobuf:"bytes,1,opt,name=audio,proto3,oneof"` // 双向：音频数据
+}
+
+type Frame_Listen struct {
+	Listen *Listen `protobuf:"bytes,2,opt,name=listen,proto3,oneof"` // 设备 -> 服务端：拾音状态
+}
+
+type Frame_Abort struct {
+	Abort *Abort `protobuf:"bytes,3,opt,name=abort,proto3,oneof"` // 设备 -> 服务端：打断当前回复
+}
+
+type Frame_Tts struct {
+	Tts *Tts `protobuf:"bytes,4,opt,name=tts,proto3,oneof"` // 服务端 -> 设备：语音合成状态
+}
+
+type Frame_Stt struct {
+	Stt *Stt `protobuf:"bytes,5,opt,name=stt,proto3,oneof"` // 服务端 -> 设备：语音识别结果
+}
+
+type Frame_Emotion struct {
+	Emotion *Emotion `protobuf:"bytes,6,opt,name=emotion,proto3,oneof"` // 服务端 -> 设备：回复的情绪
+}
+
+type Frame_Json struct {
+	Json []byte `protobuf:"bytes,15,opt,name=json,proto3,oneof"` // 其它消息，内容与对应的 JSON 文本帧相同
+}
+
+func (*Frame_Audio) isFrame_Body() {}
+
+func (*Frame_Listen) isFrame_Body() {}
+
+func (*Frame_Abort) isFrame_Body() {}
+
+func (*Frame_Tts) isFrame_Body() {}
+
+func (*Frame_Stt) isFrame_Body() {}
+
+func (*Frame_Emotion) isFrame_Body() {}
+
+func (*Frame_Json) isFrame_Body() {}
+
+// 音频数据，编码为握手时协商的格式
+type Audio struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	TimestampMs   uint32                 `protobuf:"varint,2,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"` // 可选，设备侧采集时间，用于回声消除对齐
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Audio) Reset() {
+	*x = Audio{}
+	mi := &file_api_device_v1_device_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Audio) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Audio) ProtoMessage() {}
+
+func (x *Audio) ProtoReflect() protoreflect.Message {
+	mi := &file_api_device_v1_device_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Audio.ProtoReflect.Descriptor instead.
+func (*Audio) Descriptor() ([]byte, []int) {
+	return file_api_device_v1_device_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Audio) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Audio) GetTimestampMs() uint32 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+// 对应 {"type": "listen", "state", "mode", "text"}
+type Listen struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"` // start、stop、detect
+	Mode          string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`   // auto、manual、realtime
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`   // state 为 detect 时的唤醒词
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Listen) Reset() {
+	*x = Listen{}
+	mi := &file_api_device_v1_device_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Listen) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Listen) ProtoMessage() {}
+
+func (x *Listen) ProtoReflect() protoreflect.Message {
+	mi := &file_api_device_v1_device_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Listen.ProtoReflect.Descriptor instead.
+func (*Listen) Descriptor() ([]byte, []int) {
+	return file_api_device_v1_device_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Listen) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Listen) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *Listen) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// 对应 {"type": "abort", "reason"}
+type Abort struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Abort) Reset() {
+	*x = Abort{}
+	mi := &file_api_device_v1_device_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Abort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Abort) ProtoMessage() {}
+
+func (x *Abort) ProtoReflect() protoreflect.Message {
+	mi := &file_api_device_v1_device_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Abort.ProtoReflect.Descriptor instead.
+func (*Abort) Descriptor() ([]byte, []int) {
+	return file_api_device_v1_device_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Abort) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// 对应 {"type": "tts", "state", "text", "index", "audio_codec"}
+type Tts struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"` // start、sentence_start、sentence_end、stop
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Index         int32                  `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	AudioCodec    string                 `protobuf:"bytes,4,opt,name=audio_codec,json=audioCodec,proto3" json:"audio_codec,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tts) Reset() {
+	*x = Tts{}
+	mi := &file_api_device_v1_device_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tts) ProtoMessage() {}
+
+func (x *Tts) ProtoReflect() protoreflect.Message {
+	mi := &file_api_device_v1_device_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tts.ProtoReflect.Descriptor instead.
+func (*Tts) Descriptor() ([]byte, []int) {
+	return file_api_device_v1_device_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Tts) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Tts) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Tts) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Tts) GetAudioCodec() string {
+	if x != nil {
+		return x.AudioCodec
+	}
+	return ""
+}
+
+// 对应 {"type": "stt", "text"}
+type Stt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Stt) Reset() {
+	*x = Stt{}
+	mi := &file_api_device_v1_device_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stt) ProtoMessage() {}
+
+func (x *Stt) ProtoReflect() protoreflect.Message {
+	mi := &file_api_device_v1_device_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stt.ProtoReflect.Descriptor instead.
+func (*Stt) Descriptor() ([]byte, []int) {
+	return file_api_device_v1_device_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Stt) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// 对应 {"type": "llm", "text", "emotion"}
+type Emotion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Emotion       string                 `protobuf:"bytes,1,opt,name=emotion,proto3" json:"emotion,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"` // 对应的表情符号
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Emotion) Reset() {
+	*x = Emotion{}
+	mi := &file_api_device_v1_device_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Emotion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Emotion) ProtoMessage() {}
+
+func (x *Emotion) ProtoReflect() protoreflect.Message {
+	mi := &file_api_device_v1_device_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Emotion.ProtoReflect.Descriptor instead.
+func (*Emotion) Descriptor() ([]byte, []int) {
+	return file_api_device_v1_device_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Emotion) GetEmotion() string {
+	if x != nil {
+		return x.Emotion
+	}
+	return ""
+}
+
+func (x *Emotion) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+var File_api_device_v1_device_proto protoreflect.FileDescriptor
+
+const file_api_device_v1_device_proto_rawDesc = "" +
+	"\n" +
+	"\x1aapi/device/v1/device.proto\x12\x11xiaozhi.device.v1\"\xce\x02\n" +
+	"\x05Frame\x120\n" +
+	"\x05audio\x18\x01 \x01(\v2\x18.xiaozhi.device.v1.AudioH\x00R\x05audio\x123\n" +
+	"\x06listen\x18\x02 \x01(\v2\x19.xiaozhi.device.v1.ListenH\x00R\x06listen\x120\n" +
+	"\x05abort\x18\x03 \x01(\v2\x18.xiaozhi.device.v1.AbortH\x00R\x05abort\x12*\n" +
+	"\x03tts\x18\x04 \x01(\v2\x16.xiaozhi.device.v1.TtsH\x00R\x03tts\x12*\n" +
+	"\x03stt\x18\x05 \x01(\v2\x16.xiaozhi.device.v1.SttH\x00R\x03stt\x126\n" +
+	"\aemotion\x18\x06 \x01(\v2\x1a.xiaozhi.device.v1.EmotionH\x00R\aemotion\x12\x14\n" +
+	"\x04json\x18\x0f \x01(\fH\x00R\x04jsonB\x06\n" +
+	"\x04body\">\n" +
+	"\x05Audio\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12!\n" +
+	"\ftimestamp_ms\x18\x02 \x01(\rR\vtimestampMs\"F\n" +
+	"\x06Listen\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\"\x1f\n" +
+	"\x05Abort\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\"f\n" +
+	"\x03Tts\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x14\n" +
+	"\x05index\x18\x03 \x01(\x05R\x05index\x12\x1f\n" +
+	"\vaudio_codec\x18\x04 \x01(\tR\n" +
+	"audioCodec\"\x19\n" +
+	"\x03Stt\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\"7\n" +
+	"\aEmotion\x12\x18\n" +
+	"\aemotion\x18\x01 \x01(\tR\aemotion\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04textB1Z/xiaozhi-server-go/gen/go/api/device/v1;devicev1b\x06proto3"
+
+var (
+	file_api_device_v1_device_proto_rawDescOnce sync.Once
+	file_api_device_v1_device_proto_rawDescData []byte
+)
+
+func file_api_device_v1_device_proto_rawDescGZIP() []byte {
+	file_api_device_v1_device_proto_rawDescOnce.Do(func() {
+		file_api_device_v1_device_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_device_v1_device_proto_rawDesc), len(file_api_device_v1_device_proto_rawDesc)))
+	})
+	return file_api_device_v1_device_proto_rawDescData
+}
+
+var file_api_device_v1_device_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_api_device_v1_device_proto_goTypes = []any{
+	(*Frame)(nil),   // 0: xiaozhi.device.v1.Frame
+	(*Audio)(nil),   // 1: xiaozhi.device.v1.Audio
+	(*Listen)(nil),  // 2: xiaozhi.device.v1.Listen
+	(*Abort)(nil),   // 3: xiaozhi.device.v1.Abort
+	(*Tts)(nil),     // 4: xiaozhi.device.v1.Tts
+	(*Stt)(nil),     // 5: xiaozhi.device.v1.Stt
+	(*Emotion)(nil), // 6: xiaozhi.device.v1.Emotion
+}
+var file_api_device_v1_device_proto_depIdxs = []int32{
+	1, // 0: xiaozhi.device.v1.Frame.audio:type_name -> xiaozhi.device.v1.Audio
+	2, // 1: xiaozhi.device.v1.Frame.listen:type_name -> xiaozhi.device.v1.Listen
+	3, // 2: xiaozhi.device.v1.Frame.abort:type_name -> xiaozhi.device.v1.Abort
+	4, // 3: xiaozhi.device.v1.Frame.tts:type_name -> xiaozhi.device.v1.Tts
+	5, // 4: xiaozhi.device.v1.Frame.stt:type_name -> xiaozhi.device.v1.Stt
+	6, // 5: xiaozhi.device.v1.Frame.emotion:type_name -> xiaozhi.device.v1.Emotion
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_api_device_v1_device_proto_init() }
+func file_api_device_v1_device_proto_init() {
+	if File_api_device_v1_device_proto != nil {
+		return
+	}
+	file_api_device_v1_device_proto_msgTypes[0].OneofWrappers = []any{
+		(*Frame_Audio)(nil),
+		(*Frame_Listen)(nil),
+		(*Frame_Abort)(nil),
+		(*Frame_Tts)(nil),
+		(*Frame_Stt)(nil),
+		(*Frame_Emotion)(nil),
+		(*Frame_Json)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_device_v1_device_proto_rawDesc), len(file_api_device_v1_device_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_device_v1_device_proto_goTypes,
+		DependencyIndexes: file_api_device_v1_device_proto_depIdxs,
+		MessageInfos:      file_api_device_v1_device_proto_msgTypes,
+	}.Build()
+	File_api_device_v1_device_proto = out.File
+	file_api_device_v1_device_proto_goTypes = nil
+	file_api_device_v1_device_proto_depIdxs = nil
+}
@@ -567,6 +567,250 @@ func (x *HealthCheckResponse) GetDetails() map[string]string {
 	return nil
 }
 
+// 指标采集请求
+type ScrapeMetricsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PluginId      string                 `protobuf:"bytes,1,opt,name=plugin_id,json=pluginId,proto3" json:"plugin_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScrapeMetricsRequest) Reset() {
+	*x = ScrapeMetricsRequest{}
+	mi := &file_api_proto_plugin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScrapeMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScrapeMetricsRequest) ProtoMessage() {}
+
+func (x *ScrapeMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_plugin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScrapeMetricsRequest.ProtoReflect.Descriptor instead.
+func (*ScrapeMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_plugin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ScrapeMetricsRequest) GetPluginId() string {
+	if x != nil {
+		return x.PluginId
+	}
+	return ""
+}
+
+// 直方图分桶，count 为不大于 upper_bound 的累计观测次数
+type HistogramBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpperBound    float64                `protobuf:"fixed64,1,opt,name=upper_bound,json=upperBound,proto3" json:"upper_bound,omitempty"`
+	Count         uint64                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistogramBucket) Reset() {
+	*x = HistogramBucket{}
+	mi := &file_api_proto_plugin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistogramBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistogramBucket) ProtoMessage() {}
+
+func (x *HistogramBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_plugin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistogramBucket.ProtoReflect.Descriptor instead.
+func (*HistogramBucket) Descriptor() ([]byte, []int) {
+	return file_api_proto_plugin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *HistogramBucket) GetUpperBound() float64 {
+	if x != nil {
+		return x.UpperBound
+	}
+	return 0
+}
+
+func (x *HistogramBucket) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// 单个指标序列
+type MetricSample struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // "counter", "histogram"
+	Help          string                 `protobuf:"bytes,3,opt,name=help,proto3" json:"help,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Value         float64                `protobuf:"fixed64,5,opt,name=value,proto3" json:"value,omitempty"`   // counter 的累计值
+	Buckets       []*HistogramBucket     `protobuf:"bytes,6,rep,name=buckets,proto3" json:"buckets,omitempty"` // histogram 的分桶，不含 +Inf
+	Count         uint64                 `protobuf:"varint,7,opt,name=count,proto3" json:"count,omitempty"`    // histogram 的观测次数
+	Sum           float64                `protobuf:"fixed64,8,opt,name=sum,proto3" json:"sum,omitempty"`       // histogram 的观测值之和
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetricSample) Reset() {
+	*x = MetricSample{}
+	mi := &file_api_proto_plugin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetricSample) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricSample) ProtoMessage() {}
+
+func (x *MetricSample) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_plugin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricSample.ProtoReflect.Descriptor instead.
+func (*MetricSample) Descriptor() ([]byte, []int) {
+	return file_api_proto_plugin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MetricSample) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MetricSample) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *MetricSample) GetHelp() string {
+	if x != nil {
+		return x.Help
+	}
+	return ""
+}
+
+func (x *MetricSample) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *MetricSample) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *MetricSample) GetBuckets() []*HistogramBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *MetricSample) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *MetricSample) GetSum() float64 {
+	if x != nil {
+		return x.Sum
+	}
+	return 0
+}
+
+// 指标采集响应
+type ScrapeMetricsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metrics       []*MetricSample        `protobuf:"bytes,1,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScrapeMetricsResponse) Reset() {
+	*x = ScrapeMetricsResponse{}
+	mi := &file_api_proto_plugin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScrapeMetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScrapeMetricsResponse) ProtoMessage() {}
+
+func (x *ScrapeMetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_plugin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScrapeMetricsResponse.ProtoReflect.Descriptor instead.
+func (*ScrapeMetricsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_plugin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ScrapeMetricsResponse) GetMetrics() []*MetricSample {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
 // 安装插件请求
 type InstallPluginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -580,7 +824,7 @@ type InstallPluginRequest struct {
 
 func (x *InstallPluginRequest) Reset() {
 	*x = InstallPluginRequest{}
-	mi := &file_api_proto_plugin_proto_msgTypes[8]
+	mi := &file_api_proto_plugin_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -592,7 +836,7 @@ func (x *InstallPluginRequest) String() string {
 func (*InstallPluginRequest) ProtoMessage() {}
 
 func (x *InstallPluginRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_plugin_proto_msgTypes[8]
+	mi := &file_api_proto_plugin_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -605,7 +849,7 @@ func (x *InstallPluginRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstallPluginRequest.ProtoReflect.Descriptor instead.
 func (*InstallPluginRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_plugin_proto_rawDescGZIP(), []int{8}
+	return file_api_proto_plugin_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *InstallPluginRequest) GetPluginId() string {
@@ -648,7 +892,7 @@ type InstallPluginResponse struct {
 
 func (x *InstallPluginResponse) Reset() {
 	*x = InstallPluginResponse{}
-	mi := &file_api_proto_plugin_proto_msgTypes[9]
+	mi := &file_api_proto_plugin_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -660,7 +904,7 @@ func (x *InstallPluginResponse) String() string {
 func (*InstallPluginResponse) ProtoMessage() {}
 
 func (x *InstallPluginResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_plugin_proto_msgTypes[9]
+	mi := &file_api_proto_plugin_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -673,7 +917,7 @@ func (x *InstallPluginResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstallPluginResponse.ProtoReflect.Descriptor instead.
 func (*InstallPluginResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_plugin_proto_rawDescGZIP(), []int{9}
+	return file_api_proto_plugin_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *InstallPluginResponse) GetSuccess() bool {
@@ -708,7 +952,7 @@ type UninstallPluginRequest struct {
 
 func (x *UninstallPluginRequest) Reset() {
 	*x = UninstallPluginRequest{}
-	mi := &file_api_proto_plugin_proto_msgTypes[10]
+	mi := &file_api_proto_plugin_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -720,7 +964,7 @@ func (x *UninstallPluginRequest) String() string {
 func (*UninstallPluginRequest) ProtoMessage() {}
 
 func (x *UninstallPluginRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_plugin_proto_msgTypes[10]
+	mi := &file_api_proto_plugin_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -733,7 +977,7 @@ func (x *UninstallPluginRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UninstallPluginRequest.ProtoReflect.Descriptor instead.
 func (*UninstallPluginRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_plugin_proto_rawDescGZIP(), []int{10}
+	return file_api_proto_plugin_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *UninstallPluginRequest) GetPluginId() string {
@@ -761,7 +1005,7 @@ type UninstallPluginResponse struct {
 
 func (x *UninstallPluginResponse) Reset() {
 	*x = UninstallPluginResponse{}
-	mi := &file_api_proto_plugin_proto_msgTypes[11]
+	mi := &file_api_proto_plugin_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -773,7 +1017,7 @@ func (x *UninstallPluginResponse) String() string {
 func (*UninstallPluginResponse) ProtoMessage() {}
 
 func (x *UninstallPluginResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_plugin_proto_msgTypes[11]
+	mi := &file_api_proto_plugin_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -786,7 +1030,7 @@ func (x *UninstallPluginResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UninstallPluginResponse.ProtoReflect.Descriptor instead.
 func (*UninstallPluginResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_plugin_proto_rawDescGZIP(), []int{11}
+	return file_api_proto_plugin_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *UninstallPluginResponse) GetSuccess() bool {
@@ -814,7 +1058,7 @@ type SetPluginStatusRequest struct {
 
 func (x *SetPluginStatusRequest) Reset() {
 	*x = SetPluginStatusRequest{}
-	mi := &file_api_proto_plugin_proto_msgTypes[12]
+	mi := &file_api_proto_plugin_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -826,7 +1070,7 @@ func (x *SetPluginStatusRequest) String() string {
 func (*SetPluginStatusRequest) ProtoMessage() {}
 
 func (x *SetPluginStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_plugin_proto_msgTypes[12]
+	mi := &file_api_proto_plugin_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -839,7 +1083,7 @@ func (x *SetPluginStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetPluginStatusRequest.ProtoReflect.Descriptor instead.
 func (*SetPluginStatusRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_plugin_proto_rawDescGZIP(), []int{12}
+	return file_api_proto_plugin_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *SetPluginStatusRequest) GetPluginId() string {
@@ -867,7 +1111,7 @@ type SetPluginStatusResponse struct {
 
 func (x *SetPluginStatusResponse) Reset() {
 	*x = SetPluginStatusResponse{}
-	mi := &file_api_proto_plugin_proto_msgTypes[13]
+	mi := &file_api_proto_plugin_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -879,7 +1123,7 @@ func (x *SetPluginStatusResponse) String() string {
 func (*SetPluginStatusResponse) ProtoMessage() {}
 
 func (x *SetPluginStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_plugin_proto_msgTypes[13]
+	mi := &file_api_proto_plugin_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -892,7 +1136,7 @@ func (x *SetPluginStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetPluginStatusResponse.ProtoReflect.Descriptor instead.
 func (*SetPluginStatusResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_plugin_proto_rawDescGZIP(), []int{13}
+	return file_api_proto_plugin_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *SetPluginStatusResponse) GetSuccess() bool {
@@ -920,7 +1164,7 @@ type ListPluginsRequest struct {
 
 func (x *ListPluginsRequest) Reset() {
 	*x = ListPluginsRequest{}
-	mi := &file_api_proto_plugin_proto_msgTypes[14]
+	mi := &file_api_proto_plugin_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -932,7 +1176,7 @@ func (x *ListPluginsRequest) String() string {
 func (*ListPluginsRequest) ProtoMessage() {}
 
 func (x *ListPluginsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_plugin_proto_msgTypes[14]
+	mi := &file_api_proto_plugin_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -945,7 +1189,7 @@ func (x *ListPluginsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPluginsRequest.ProtoReflect.Descriptor instead.
 func (*ListPluginsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_plugin_proto_rawDescGZIP(), []int{14}
+	return file_api_proto_plugin_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ListPluginsRequest) GetTypeFilter() string {
@@ -979,7 +1223,7 @@ type PluginStatus struct {
 
 func (x *PluginStatus) Reset() {
 	*x = PluginStatus{}
-	mi := &file_api_proto_plugin_proto_msgTypes[15]
+	mi := &file_api_proto_plugin_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -991,7 +1235,7 @@ func (x *PluginStatus) String() string {
 func (*PluginStatus) ProtoMessage() {}
 
 func (x *PluginStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_plugin_proto_msgTypes[15]
+	mi := &file_api_proto_plugin_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1004,7 +1248,7 @@ func (x *PluginStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PluginStatus.ProtoReflect.Descriptor instead.
 func (*PluginStatus) Descriptor() ([]byte, []int) {
-	return file_api_proto_plugin_proto_rawDescGZIP(), []int{15}
+	return file_api_proto_plugin_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *PluginStatus) GetPluginId() string {
@@ -1074,7 +1318,7 @@ type ListPluginsResponse struct {
 
 func (x *ListPluginsResponse) Reset() {
 	*x = ListPluginsResponse{}
-	mi := &file_api_proto_plugin_proto_msgTypes[16]
+	mi := &file_api_proto_plugin_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1086,7 +1330,7 @@ func (x *ListPluginsResponse) String() string {
 func (*ListPluginsResponse) ProtoMessage() {}
 
 func (x *ListPluginsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_plugin_proto_msgTypes[16]
+	mi := &file_api_proto_plugin_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1099,7 +1343,7 @@ func (x *ListPluginsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPluginsResponse.ProtoReflect.Descriptor instead.
 func (*ListPluginsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_plugin_proto_rawDescGZIP(), []int{16}
+	return file_api_proto_plugin_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *ListPluginsResponse) GetPlugins() []*PluginStatus {
@@ -1165,7 +1409,27 @@ const file_api_proto_plugin_proto_rawDesc = "" +
 	"\adetails\x18\x04 \x03(\v2(.plugin.HealthCheckResponse.DetailsEntryR\adetails\x1a:\n" +
 	"\fDetailsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x9f\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"3\n" +
+	"\x14ScrapeMetricsRequest\x12\x1b\n" +
+	"\tplugin_id\x18\x01 \x01(\tR\bpluginId\"H\n" +
+	"\x0fHistogramBucket\x12\x1f\n" +
+	"\vupper_bound\x18\x01 \x01(\x01R\n" +
+	"upperBound\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x04R\x05count\"\xb0\x02\n" +
+	"\fMetricSample\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04help\x18\x03 \x01(\tR\x04help\x128\n" +
+	"\x06labels\x18\x04 \x03(\v2 .plugin.MetricSample.LabelsEntryR\x06labels\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\x01R\x05value\x121\n" +
+	"\abuckets\x18\x06 \x03(\v2\x17.plugin.HistogramBucketR\abuckets\x12\x14\n" +
+	"\x05count\x18\a \x01(\x04R\x05count\x12\x10\n" +
+	"\x03sum\x18\b \x01(\x01R\x03sum\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"G\n" +
+	"\x15ScrapeMetricsResponse\x12.\n" +
+	"\ametrics\x18\x01 \x03(\v2\x14.plugin.MetricSampleR\ametrics\"\x9f\x01\n" +
 	"\x14InstallPluginRequest\x12\x1b\n" +
 	"\tplugin_id\x18\x01 \x01(\tR\bpluginId\x12\x1f\n" +
 	"\vplugin_type\x18\x02 \x01(\tR\n" +
@@ -1204,12 +1468,13 @@ const file_api_proto_plugin_proto_rawDesc = "" +
 	"\x13ListPluginsResponse\x12.\n" +
 	"\aplugins\x18\x01 \x03(\v2\x14.plugin.PluginStatusR\aplugins\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount2\xe1\x02\n" +
+	"totalCount2\xaf\x03\n" +
 	"\rPluginService\x12L\n" +
 	"\rGetPluginInfo\x12\x1c.plugin.GetPluginInfoRequest\x1a\x1d.plugin.GetPluginInfoResponse\x12X\n" +
 	"\x11ExecuteCapability\x12 .plugin.ExecuteCapabilityRequest\x1a!.plugin.ExecuteCapabilityResponse\x12`\n" +
 	"\x17ExecuteCapabilityStream\x12 .plugin.ExecuteCapabilityRequest\x1a!.plugin.ExecuteCapabilityResponse0\x01\x12F\n" +
-	"\vHealthCheck\x12\x1a.plugin.HealthCheckRequest\x1a\x1b.plugin.HealthCheckResponseB$Z\"xiaozhi-server-go/api/proto/pluginb\x06proto3"
+	"\vHealthCheck\x12\x1a.plugin.HealthCheckRequest\x1a\x1b.plugin.HealthCheckResponse\x12L\n" +
+	"\rScrapeMetrics\x12\x1c.plugin.ScrapeMetricsRequest\x1a\x1d.plugin.ScrapeMetricsResponseB$Z\"xiaozhi-server-go/api/proto/pluginb\x06proto3"
 
 var (
 	file_api_proto_plugin_proto_rawDescOnce sync.Once
@@ -1223,7 +1488,7 @@ func file_api_proto_plugin_proto_rawDescGZIP() []byte {
 	return file_api_proto_plugin_proto_rawDescData
 }
 
-var file_api_proto_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_api_proto_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_api_proto_plugin_proto_goTypes = []any{
 	(*GetPluginInfoRequest)(nil),      // 0: plugin.GetPluginInfoRequest
 	(*CapabilityDefinition)(nil),      // 1: plugin.CapabilityDefinition
@@ -1233,48 +1498,58 @@ var file_api_proto_plugin_proto_goTypes = []any{
 	(*ExecuteCapabilityResponse)(nil), // 5: plugin.ExecuteCapabilityResponse
 	(*HealthCheckRequest)(nil),        // 6: plugin.HealthCheckRequest
 	(*HealthCheckResponse)(nil),       // 7: plugin.HealthCheckResponse
-	(*InstallPluginRequest)(nil),      // 8: plugin.InstallPluginRequest
-	(*InstallPluginResponse)(nil),     // 9: plugin.InstallPluginResponse
-	(*UninstallPluginRequest)(nil),    // 10: plugin.UninstallPluginRequest
-	(*UninstallPluginResponse)(nil),   // 11: plugin.UninstallPluginResponse
-	(*SetPluginStatusRequest)(nil),    // 12: plugin.SetPluginStatusRequest
-	(*SetPluginStatusResponse)(nil),   // 13: plugin.SetPluginStatusResponse
-	(*ListPluginsRequest)(nil),        // 14: plugin.ListPluginsRequest
-	(*PluginStatus)(nil),              // 15: plugin.PluginStatus
-	(*ListPluginsResponse)(nil),       // 16: plugin.ListPluginsResponse
-	nil,                               // 17: plugin.HealthCheckResponse.DetailsEntry
-	(*structpb.Struct)(nil),           // 18: google.protobuf.Struct
-	(*timestamppb.Timestamp)(nil),     // 19: google.protobuf.Timestamp
+	(*ScrapeMetricsRequest)(nil),      // 8: plugin.ScrapeMetricsRequest
+	(*HistogramBucket)(nil),           // 9: plugin.HistogramBucket
+	(*MetricSample)(nil),              // 10: plugin.MetricSample
+	(*ScrapeMetricsResponse)(nil),     // 11: plugin.ScrapeMetricsResponse
+	(*InstallPluginRequest)(nil),      // 12: plugin.InstallPluginRequest
+	(*InstallPluginResponse)(nil),     // 13: plugin.InstallPluginResponse
+	(*UninstallPluginRequest)(nil),    // 14: plugin.UninstallPluginRequest
+	(*UninstallPluginResponse)(nil),   // 15: plugin.UninstallPluginResponse
+	(*SetPluginStatusRequest)(nil),    // 16: plugin.SetPluginStatusRequest
+	(*SetPluginStatusResponse)(nil),   // 17: plugin.SetPluginStatusResponse
+	(*ListPluginsRequest)(nil),        // 18: plugin.ListPluginsRequest
+	(*PluginStatus)(nil),              // 19: plugin.PluginStatus
+	(*ListPluginsResponse)(nil),       // 20: plugin.ListPluginsResponse
+	nil,                               // 21: plugin.HealthCheckResponse.DetailsEntry
+	nil,                               // 22: plugin.MetricSample.LabelsEntry
+	(*structpb.Struct)(nil),           // 23: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),     // 24: google.protobuf.Timestamp
 }
 var file_api_proto_plugin_proto_depIdxs = []int32{
-	18, // 0: plugin.CapabilityDefinition.config_schema:type_name -> google.protobuf.Struct
-	18, // 1: plugin.CapabilityDefinition.input_schema:type_name -> google.protobuf.Struct
-	18, // 2: plugin.CapabilityDefinition.output_schema:type_name -> google.protobuf.Struct
-	19, // 3: plugin.PluginInfo.updated_at:type_name -> google.protobuf.Timestamp
+	23, // 0: plugin.CapabilityDefinition.config_schema:type_name -> google.protobuf.Struct
+	23, // 1: plugin.CapabilityDefinition.input_schema:type_name -> google.protobuf.Struct
+	23, // 2: plugin.CapabilityDefinition.output_schema:type_name -> google.protobuf.Struct
+	24, // 3: plugin.PluginInfo.updated_at:type_name -> google.protobuf.Timestamp
 	2,  // 4: plugin.GetPluginInfoResponse.plugin_info:type_name -> plugin.PluginInfo
 	1,  // 5: plugin.GetPluginInfoResponse.capabilities:type_name -> plugin.CapabilityDefinition
-	18, // 6: plugin.ExecuteCapabilityRequest.config:type_name -> google.protobuf.Struct
-	18, // 7: plugin.ExecuteCapabilityRequest.inputs:type_name -> google.protobuf.Struct
-	18, // 8: plugin.ExecuteCapabilityResponse.outputs:type_name -> google.protobuf.Struct
-	19, // 9: plugin.ExecuteCapabilityResponse.timestamp:type_name -> google.protobuf.Timestamp
-	19, // 10: plugin.HealthCheckResponse.timestamp:type_name -> google.protobuf.Timestamp
-	17, // 11: plugin.HealthCheckResponse.details:type_name -> plugin.HealthCheckResponse.DetailsEntry
-	18, // 12: plugin.InstallPluginRequest.config:type_name -> google.protobuf.Struct
-	19, // 13: plugin.PluginStatus.last_updated:type_name -> google.protobuf.Timestamp
-	15, // 14: plugin.ListPluginsResponse.plugins:type_name -> plugin.PluginStatus
-	0,  // 15: plugin.PluginService.GetPluginInfo:input_type -> plugin.GetPluginInfoRequest
-	4,  // 16: plugin.PluginService.ExecuteCapability:input_type -> plugin.ExecuteCapabilityRequest
-	4,  // 17: plugin.PluginService.ExecuteCapabilityStream:input_type -> plugin.ExecuteCapabilityRequest
-	6,  // 18: plugin.PluginService.HealthCheck:input_type -> plugin.HealthCheckRequest
-	3,  // 19: plugin.PluginService.GetPluginInfo:output_type -> plugin.GetPluginInfoResponse
-	5,  // 20: plugin.PluginService.ExecuteCapability:output_type -> plugin.ExecuteCapabilityResponse
-	5,  // 21: plugin.PluginService.ExecuteCapabilityStream:output_type -> plugin.ExecuteCapabilityResponse
-	7,  // 22: plugin.PluginService.HealthCheck:output_type -> plugin.HealthCheckResponse
-	19, // [19:23] is the sub-list for method output_type
-	15, // [15:19] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	23, // 6: plugin.ExecuteCapabilityRequest.config:type_name -> google.protobuf.Struct
+	23, // 7: plugin.ExecuteCapabilityRequest.inputs:type_name -> google.protobuf.Struct
+	23, // 8: plugin.ExecuteCapabilityResponse.outputs:type_name -> google.protobuf.Struct
+	24, // 9: plugin.ExecuteCapabilityResponse.timestamp:type_name -> google.protobuf.Timestamp
+	24, // 10: plugin.HealthCheckResponse.timestamp:type_name -> google.protobuf.Timestamp
+	21, // 11: plugin.HealthCheckResponse.details:type_name -> plugin.HealthCheckResponse.DetailsEntry
+	22, // 12: plugin.MetricSample.labels:type_name -> plugin.MetricSample.LabelsEntry
+	9,  // 13: plugin.MetricSample.buckets:type_name -> plugin.HistogramBucket
+	10, // 14: plugin.ScrapeMetricsResponse.metrics:type_name -> plugin.MetricSample
+	23, // 15: plugin.InstallPluginRequest.config:type_name -> google.protobuf.Struct
+	24, // 16: plugin.PluginStatus.last_updated:type_name -> google.protobuf.Timestamp
+	19, // 17: plugin.ListPluginsResponse.plugins:type_name -> plugin.PluginStatus
+	0,  // 18: plugin.PluginService.GetPluginInfo:input_type -> plugin.GetPluginInfoRequest
+	4,  // 19: plugin.PluginService.ExecuteCapability:input_type -> plugin.ExecuteCapabilityRequest
+	4,  // 20: plugin.PluginService.ExecuteCapabilityStream:input_type -> plugin.ExecuteCapabilityRequest
+	6,  // 21: plugin.PluginService.HealthCheck:input_type -> plugin.HealthCheckRequest
+	8,  // 22: plugin.PluginService.ScrapeMetrics:input_type -> plugin.ScrapeMetricsRequest
+	3,  // 23: plugin.PluginService.GetPluginInfo:output_type -> plugin.GetPluginInfoResponse
+	5,  // 24: plugin.PluginService.ExecuteCapability:output_type -> plugin.ExecuteCapabilityResponse
+	5,  // 25: plugin.PluginService.ExecuteCapabilityStream:output_type -> plugin.ExecuteCapabilityResponse
+	7,  // 26: plugin.PluginService.HealthCheck:output_type -> plugin.HealthCheckResponse
+	11, // 27: plugin.PluginService.ScrapeMetrics:output_type -> plugin.ScrapeMetricsResponse
+	23, // [23:28] is the sub-list for method output_type
+	18, // [18:23] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_plugin_proto_init() }
@@ -1288,7 +1563,7 @@ func file_api_proto_plugin_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_plugin_proto_rawDesc), len(file_api_proto_plugin_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   18,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,535 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: api/proto/plugin_debug.proto
+
+package plugin
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 调试信息请求
+type GetDebugInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDebugInfoRequest) Reset() {
+	*x = GetDebugInfoRequest{}
+	mi := &file_api_proto_plugin_debug_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDebugInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDebugInfoRequest) ProtoMessage() {}
+
+func (x *GetDebugInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_plugin_debug_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDebugInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetDebugInfoRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_plugin_debug_proto_rawDescGZIP(), []int{0}
+}
+
+// 调试信息响应
+type GetDebugInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PluginInfo    *PluginInfo            `protobuf:"bytes,1,opt,name=plugin_info,json=pluginInfo,proto3" json:"plugin_info,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Pid           int32                  `protobuf:"varint,3,opt,name=pid,proto3" json:"pid,omitempty"`
+	GoVersion     string                 `protobuf:"bytes,4,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	UptimeSeconds int64                  `protobuf:"varint,6,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	Services      []string               `protobuf:"bytes,7,rep,name=services,proto3" json:"services,omitempty"`                 // 已注册的gRPC服务全名
+	LogLevel      string                 `protobuf:"bytes,8,opt,name=log_level,json=logLevel,proto3" json:"log_level,omitempty"` // debug/info/warn/error
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDebugInfoResponse) Reset() {
+	*x = GetDebugInfoResponse{}
+	mi := &file_api_proto_plugin_debug_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDebugInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDebugInfoResponse) ProtoMessage() {}
+
+func (x *GetDebugInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_plugin_debug_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDebugInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetDebugInfoResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_plugin_debug_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetDebugInfoResponse) GetPluginInfo() *PluginInfo {
+	if x != nil {
+		return x.PluginInfo
+	}
+	return nil
+}
+
+func (x *GetDebugInfoResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GetDebugInfoResponse) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *GetDebugInfoResponse) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+func (x *GetDebugInfoResponse) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *GetDebugInfoResponse) GetUptimeSeconds() int64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+func (x *GetDebugInfoResponse) GetServices() []string {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *GetDebugInfoResponse) GetLogLevel() string {
+	if x != nil {
+		return x.LogLevel
+	}
+	return ""
+}
+
+// 指标请求
+type DumpMetricsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DumpMetricsRequest) Reset() {
+	*x = DumpMetricsRequest{}
+	mi := &file_api_proto_plugin_debug_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DumpMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DumpMetricsRequest) ProtoMessage() {}
+
+func (x *DumpMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_plugin_debug_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DumpMetricsRequest.ProtoReflect.Descriptor instead.
+func (*DumpMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_plugin_debug_proto_rawDescGZIP(), []int{2}
+}
+
+// 单个gRPC方法的调用统计
+type MethodMetrics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Calls         int64                  `protobuf:"varint,2,opt,name=calls,proto3" json:"calls,omitempty"`
+	Errors        int64                  `protobuf:"varint,3,opt,name=errors,proto3" json:"errors,omitempty"`
+	TotalMs       float64                `protobuf:"fixed64,4,opt,name=total_ms,json=totalMs,proto3" json:"total_ms,omitempty"`
+	MaxMs         float64                `protobuf:"fixed64,5,opt,name=max_ms,json=maxMs,proto3" json:"max_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MethodMetrics) Reset() {
+	*x = MethodMetrics{}
+	mi := &file_api_proto_plugin_debug_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MethodMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MethodMetrics) ProtoMessage() {}
+
+func (x *MethodMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_plugin_debug_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MethodMetrics.ProtoReflect.Descriptor instead.
+func (*MethodMetrics) Descriptor() ([]byte, []int) {
+	return file_api_proto_plugin_debug_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MethodMetrics) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *MethodMetrics) GetCalls() int64 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *MethodMetrics) GetErrors() int64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *MethodMetrics) GetTotalMs() float64 {
+	if x != nil {
+		return x.TotalMs
+	}
+	return 0
+}
+
+func (x *MethodMetrics) GetMaxMs() float64 {
+	if x != nil {
+		return x.MaxMs
+	}
+	return 0
+}
+
+// 指标响应
+type DumpMetricsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Methods       []*MethodMetrics       `protobuf:"bytes,1,rep,name=methods,proto3" json:"methods,omitempty"`
+	Runtime       map[string]float64     `protobuf:"bytes,2,rep,name=runtime,proto3" json:"runtime,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"` // goroutines、heap_alloc_bytes、heap_sys_bytes、gc_count、gc_pause_total_ms
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DumpMetricsResponse) Reset() {
+	*x = DumpMetricsResponse{}
+	mi := &file_api_proto_plugin_debug_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DumpMetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DumpMetricsResponse) ProtoMessage() {}
+
+func (x *DumpMetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_plugin_debug_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DumpMetricsResponse.ProtoReflect.Descriptor instead.
+func (*DumpMetricsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_plugin_debug_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DumpMetricsResponse) GetMethods() []*MethodMetrics {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *DumpMetricsResponse) GetRuntime() map[string]float64 {
+	if x != nil {
+		return x.Runtime
+	}
+	return nil
+}
+
+// 修改日志级别请求
+type SetLogLevelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"` // debug/info/warn/error
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevelRequest) Reset() {
+	*x = SetLogLevelRequest{}
+	mi := &file_api_proto_plugin_debug_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelRequest) ProtoMessage() {}
+
+func (x *SetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_plugin_debug_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_plugin_debug_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SetLogLevelRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+// 修改日志级别响应
+type SetLogLevelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PreviousLevel string                 `protobuf:"bytes,1,opt,name=previous_level,json=previousLevel,proto3" json:"previous_level,omitempty"`
+	Level         string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevelResponse) Reset() {
+	*x = SetLogLevelResponse{}
+	mi := &file_api_proto_plugin_debug_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelResponse) ProtoMessage() {}
+
+func (x *SetLogLevelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_plugin_debug_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelResponse.ProtoReflect.Descriptor instead.
+func (*SetLogLevelResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_plugin_debug_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetLogLevelResponse) GetPreviousLevel() string {
+	if x != nil {
+		return x.PreviousLevel
+	}
+	return ""
+}
+
+func (x *SetLogLevelResponse) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+var File_api_proto_plugin_debug_proto protoreflect.FileDescriptor
+
+const file_api_proto_plugin_debug_proto_rawDesc = "" +
+	"\n" +
+	"\x1capi/proto/plugin_debug.proto\x12\x06plugin\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16api/proto/plugin.proto\"\x15\n" +
+	"\x13GetDebugInfoRequest\"\xb1\x02\n" +
+	"\x14GetDebugInfoResponse\x123\n" +
+	"\vplugin_info\x18\x01 \x01(\v2\x12.plugin.PluginInfoR\n" +
+	"pluginInfo\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x10\n" +
+	"\x03pid\x18\x03 \x01(\x05R\x03pid\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\x04 \x01(\tR\tgoVersion\x129\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12%\n" +
+	"\x0euptime_seconds\x18\x06 \x01(\x03R\ruptimeSeconds\x12\x1a\n" +
+	"\bservices\x18\a \x03(\tR\bservices\x12\x1b\n" +
+	"\tlog_level\x18\b \x01(\tR\blogLevel\"\x14\n" +
+	"\x12DumpMetricsRequest\"\x87\x01\n" +
+	"\rMethodMetrics\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12\x14\n" +
+	"\x05calls\x18\x02 \x01(\x03R\x05calls\x12\x16\n" +
+	"\x06errors\x18\x03 \x01(\x03R\x06errors\x12\x19\n" +
+	"\btotal_ms\x18\x04 \x01(\x01R\atotalMs\x12\x15\n" +
+	"\x06max_ms\x18\x05 \x01(\x01R\x05maxMs\"\xc6\x01\n" +
+	"\x13DumpMetricsResponse\x12/\n" +
+	"\amethods\x18\x01 \x03(\v2\x15.plugin.MethodMetricsR\amethods\x12B\n" +
+	"\aruntime\x18\x02 \x03(\v2(.plugin.DumpMetricsResponse.RuntimeEntryR\aruntime\x1a:\n" +
+	"\fRuntimeEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"*\n" +
+	"\x12SetLogLevelRequest\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\"R\n" +
+	"\x13SetLogLevelResponse\x12%\n" +
+	"\x0eprevious_level\x18\x01 \x01(\tR\rpreviousLevel\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\tR\x05level2\xea\x01\n" +
+	"\x12PluginDebugService\x12D\n" +
+	"\aGetInfo\x12\x1b.plugin.GetDebugInfoRequest\x1a\x1c.plugin.GetDebugInfoResponse\x12F\n" +
+	"\vDumpMetrics\x12\x1a.plugin.DumpMetricsRequest\x1a\x1b.plugin.DumpMetricsResponse\x12F\n" +
+	"\vSetLogLevel\x12\x1a.plugin.SetLogLevelRequest\x1a\x1b.plugin.SetLogLevelResponseB$Z\"xiaozhi-server-go/api/proto/pluginb\x06proto3"
+
+var (
+	file_api_proto_plugin_debug_proto_rawDescOnce sync.Once
+	file_api_proto_plugin_debug_proto_rawDescData []byte
+)
+
+func file_api_proto_plugin_debug_proto_rawDescGZIP() []byte {
+	file_api_proto_plugin_debug_proto_rawDescOnce.Do(func() {
+		file_api_proto_plugin_debug_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_plugin_debug_proto_rawDesc), len(file_api_proto_plugin_debug_proto_rawDesc)))
+	})
+	return file_api_proto_plugin_debug_proto_rawDescData
+}
+
+var file_api_proto_plugin_debug_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_api_proto_plugin_debug_proto_goTypes = []any{
+	(*GetDebugInfoRequest)(nil),   // 0: plugin.GetDebugInfoRequest
+	(*GetDebugInfoResponse)(nil),  // 1: plugin.GetDebugInfoResponse
+	(*DumpMetricsRequest)(nil),    // 2: plugin.DumpMetricsRequest
+	(*MethodMetrics)(nil),         // 3: plugin.MethodMetrics
+	(*DumpMetricsResponse)(nil),   // 4: plugin.DumpMetricsResponse
+	(*SetLogLevelRequest)(nil),    // 5: plugin.SetLogLevelRequest
+	(*SetLogLevelResponse)(nil),   // 6: plugin.SetLogLevelResponse
+	nil,                           // 7: plugin.DumpMetricsResponse.RuntimeEntry
+	(*PluginInfo)(nil),            // 8: plugin.PluginInfo
+	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+}
+var file_api_proto_plugin_debug_proto_depIdxs = []int32{
+	8, // 0: plugin.GetDebugInfoResponse.plugin_info:type_name -> plugin.PluginInfo
+	9, // 1: plugin.GetDebugInfoResponse.started_at:type_name -> google.protobuf.Timestamp
+	3, // 2: plugin.DumpMetricsResponse.methods:type_name -> plugin.MethodMetrics
+	7, // 3: plugin.DumpMetricsResponse.runtime:type_name -> plugin.DumpMetricsResponse.RuntimeEntry
+	0, // 4: plugin.PluginDebugService.GetInfo:input_type -> plugin.GetDebugInfoRequest
+	2, // 5: plugin.PluginDebugService.DumpMetrics:input_type -> plugin.DumpMetricsRequest
+	5, // 6: plugin.PluginDebugService.SetLogLevel:input_type -> plugin.SetLogLevelRequest
+	1, // 7: plugin.PluginDebugService.GetInfo:output_type -> plugin.GetDebugInfoResponse
+	4, // 8: plugin.PluginDebugService.DumpMetrics:output_type -> plugin.DumpMetricsResponse
+	6, // 9: plugin.PluginDebugService.SetLogLevel:output_type -> plugin.SetLogLevelResponse
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_plugin_debug_proto_init() }
+func file_api_proto_plugin_debug_proto_init() {
+	if File_api_proto_plugin_debug_proto != nil {
+		return
+	}
+	file_api_proto_plugin_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_plugin_debug_proto_rawDesc), len(file_api_proto_plugin_debug_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_proto_plugin_debug_proto_goTypes,
+		DependencyIndexes: file_api_proto_plugin_debug_proto_depIdxs,
+		MessageInfos:      file_api_proto_plugin_debug_proto_msgTypes,
+	}.Build()
+	File_api_proto_plugin_debug_proto = out.File
+	file_api_proto_plugin_debug_proto_goTypes = nil
+	file_api_proto_plugin_debug_proto_depIdxs = nil
+}
@@ -0,0 +1,205 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: api/proto/plugin_debug.proto
+
+package plugin
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PluginDebugService_GetInfo_FullMethodName     = "/plugin.PluginDebugService/GetInfo"
+	PluginDebugService_DumpMetrics_FullMethodName = "/plugin.PluginDebugService/DumpMetrics"
+	PluginDebugService_SetLogLevel_FullMethodName = "/plugin.PluginDebugService/SetLogLevel"
+)
+
+// PluginDebugServiceClient is the client API for PluginDebugService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// 插件调试服务，仅在启用插件调试（PluginGRPC.Debug）时与gRPC反射一起注册
+type PluginDebugServiceClient interface {
+	// 获取插件信息、进程信息和已注册的gRPC服务
+	GetInfo(ctx context.Context, in *GetDebugInfoRequest, opts ...grpc.CallOption) (*GetDebugInfoResponse, error)
+	// 导出gRPC调用统计和Go运行时指标
+	DumpMetrics(ctx context.Context, in *DumpMetricsRequest, opts ...grpc.CallOption) (*DumpMetricsResponse, error)
+	// 修改插件日志级别
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
+}
+
+type pluginDebugServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPluginDebugServiceClient(cc grpc.ClientConnInterface) PluginDebugServiceClient {
+	return &pluginDebugServiceClient{cc}
+}
+
+func (c *pluginDebugServiceClient) GetInfo(ctx context.Context, in *GetDebugInfoRequest, opts ...grpc.CallOption) (*GetDebugInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDebugInfoResponse)
+	err := c.cc.Invoke(ctx, PluginDebugService_GetInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginDebugServiceClient) DumpMetrics(ctx context.Context, in *DumpMetricsRequest, opts ...grpc.CallOption) (*DumpMetricsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DumpMetricsResponse)
+	err := c.cc.Invoke(ctx, PluginDebugService_DumpMetrics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginDebugServiceClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetLogLevelResponse)
+	err := c.cc.Invoke(ctx, PluginDebugService_SetLogLevel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PluginDebugServiceServer is the server API for PluginDebugService service.
+// All implementations should embed UnimplementedPluginDebugServiceServer
+// for forward compatibility.
+//
+// 插件调试服务，仅在启用插件调试（PluginGRPC.Debug）时与gRPC反射一起注册
+type PluginDebugServiceServer interface {
+	// 获取插件信息、进程信息和已注册的gRPC服务
+	GetInfo(context.Context, *GetDebugInfoRequest) (*GetDebugInfoResponse, error)
+	// 导出gRPC调用统计和Go运行时指标
+	DumpMetrics(context.Context, *DumpMetricsRequest) (*DumpMetricsResponse, error)
+	// 修改插件日志级别
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
+}
+
+// UnimplementedPluginDebugServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPluginDebugServiceServer struct{}
+
+func (UnimplementedPluginDebugServiceServer) GetInfo(context.Context, *GetDebugInfoRequest) (*GetDebugInfoResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetInfo not implemented")
+}
+func (UnimplementedPluginDebugServiceServer) DumpMetrics(context.Context, *DumpMetricsRequest) (*DumpMetricsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DumpMetrics not implemented")
+}
+func (UnimplementedPluginDebugServiceServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (UnimplementedPluginDebugServiceServer) testEmbeddedByValue() {}
+
+// UnsafePluginDebugServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PluginDebugServiceServer will
+// result in compilation errors.
+type UnsafePluginDebugServiceServer interface {
+	mustEmbedUnimplementedPluginDebugServiceServer()
+}
+
+func RegisterPluginDebugServiceServer(s grpc.ServiceRegistrar, srv PluginDebugServiceServer) {
+	// If the following call panics, it indicates UnimplementedPluginDebugServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PluginDebugService_ServiceDesc, srv)
+}
+
+func _PluginDebugService_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDebugInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginDebugServiceServer).GetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PluginDebugService_GetInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginDebugServiceServer).GetInfo(ctx, req.(*GetDebugInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginDebugService_DumpMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DumpMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginDebugServiceServer).DumpMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PluginDebugService_DumpMetrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginDebugServiceServer).DumpMetrics(ctx, req.(*DumpMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginDebugService_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginDebugServiceServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PluginDebugService_SetLogLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginDebugServiceServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PluginDebugService_ServiceDesc is the grpc.ServiceDesc for PluginDebugService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PluginDebugService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "plugin.PluginDebugService",
+	HandlerType: (*PluginDebugServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetInfo",
+			Handler:    _PluginDebugService_GetInfo_Handler,
+		},
+		{
+			MethodName: "DumpMetrics",
+			Handler:    _PluginDebugService_DumpMetrics_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _PluginDebugService_SetLogLevel_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/plugin_debug.proto",
+}
@@ -23,6 +23,7 @@ const (
 	PluginService_ExecuteCapability_FullMethodName       = "/plugin.PluginService/ExecuteCapability"
 	PluginService_ExecuteCapabilityStream_FullMethodName = "/plugin.PluginService/ExecuteCapabilityStream"
 	PluginService_HealthCheck_FullMethodName             = "/plugin.PluginService/HealthCheck"
+	PluginService_ScrapeMetrics_FullMethodName           = "/plugin.PluginService/ScrapeMetrics"
 )
 
 // PluginServiceClient is the client API for PluginService service.
@@ -39,6 +40,8 @@ type PluginServiceClient interface {
 	ExecuteCapabilityStream(ctx context.Context, in *ExecuteCapabilityRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExecuteCapabilityResponse], error)
 	// 健康检查
 	HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
+	// 采集插件指标，由核心定期调用并汇总到 Prometheus 指标中
+	ScrapeMetrics(ctx context.Context, in *ScrapeMetricsRequest, opts ...grpc.CallOption) (*ScrapeMetricsResponse, error)
 }
 
 type pluginServiceClient struct {
@@ -98,6 +101,16 @@ func (c *pluginServiceClient) HealthCheck(ctx context.Context, in *HealthCheckRe
 	return out, nil
 }
 
+func (c *pluginServiceClient) ScrapeMetrics(ctx context.Context, in *ScrapeMetricsRequest, opts ...grpc.CallOption) (*ScrapeMetricsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScrapeMetricsResponse)
+	err := c.cc.Invoke(ctx, PluginService_ScrapeMetrics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PluginServiceServer is the server API for PluginService service.
 // All implementations should embed UnimplementedPluginServiceServer
 // for forward compatibility.
@@ -112,6 +125,8 @@ type PluginServiceServer interface {
 	ExecuteCapabilityStream(*ExecuteCapabilityRequest, grpc.ServerStreamingServer[ExecuteCapabilityResponse]) error
 	// 健康检查
 	HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
+	// 采集插件指标，由核心定期调用并汇总到 Prometheus 指标中
+	ScrapeMetrics(context.Context, *ScrapeMetricsRequest) (*ScrapeMetricsResponse, error)
 }
 
 // UnimplementedPluginServiceServer should be embedded to have
@@ -133,6 +148,9 @@ func (UnimplementedPluginServiceServer) ExecuteCapabilityStream(*ExecuteCapabili
 func (UnimplementedPluginServiceServer) HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method HealthCheck not implemented")
 }
+func (UnimplementedPluginServiceServer) ScrapeMetrics(context.Context, *ScrapeMetricsRequest) (*ScrapeMetricsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ScrapeMetrics not implemented")
+}
 func (UnimplementedPluginServiceServer) testEmbeddedByValue() {}
 
 // UnsafePluginServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -218,6 +236,24 @@ func _PluginService_HealthCheck_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PluginService_ScrapeMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScrapeMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginServiceServer).ScrapeMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PluginService_ScrapeMetrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginServiceServer).ScrapeMetrics(ctx, req.(*ScrapeMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PluginService_ServiceDesc is the grpc.ServiceDesc for PluginService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -237,6 +273,10 @@ var PluginService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HealthCheck",
 			Handler:    _PluginService_HealthCheck_Handler,
 		},
+		{
+			MethodName: "ScrapeMetrics",
+			Handler:    _PluginService_ScrapeMetrics_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,1921 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: api/v1/management.proto
+
+package managementv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 设备信息
+type Device struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeviceId       string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	ClientId       string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Name           string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Version        string                 `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	BoardType      string                 `protobuf:"bytes,6,opt,name=board_type,json=boardType,proto3" json:"board_type,omitempty"`
+	ChipModelName  string                 `protobuf:"bytes,7,opt,name=chip_model_name,json=chipModelName,proto3" json:"chip_model_name,omitempty"`
+	AuthStatus     string                 `protobuf:"bytes,8,opt,name=auth_status,json=authStatus,proto3" json:"auth_status,omitempty"`
+	Online         bool                   `protobuf:"varint,9,opt,name=online,proto3" json:"online,omitempty"`
+	Ota            bool                   `protobuf:"varint,10,opt,name=ota,proto3" json:"ota,omitempty"`
+	LastIp         string                 `protobuf:"bytes,11,opt,name=last_ip,json=lastIp,proto3" json:"last_ip,omitempty"`
+	UserId         int64                  `protobuf:"varint,12,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 未绑定用户时为 0
+	RegisterTime   *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=register_time,json=registerTime,proto3" json:"register_time,omitempty"`
+	LastActiveTime *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=last_active_time,json=lastActiveTime,proto3" json:"last_active_time,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Device) Reset() {
+	*x = Device{}
+	mi := &file_api_v1_management_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Device) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Device) ProtoMessage() {}
+
+func (x *Device) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Device) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Device) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *Device) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *Device) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Device) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Device) GetBoardType() string {
+	if x != nil {
+		return x.BoardType
+	}
+	return ""
+}
+
+func (x *Device) GetChipModelName() string {
+	if x != nil {
+		return x.ChipModelName
+	}
+	return ""
+}
+
+func (x *Device) GetAuthStatus() string {
+	if x != nil {
+		return x.AuthStatus
+	}
+	return ""
+}
+
+func (x *Device) GetOnline() bool {
+	if x != nil {
+		return x.Online
+	}
+	return false
+}
+
+func (x *Device) GetOta() bool {
+	if x != nil {
+		return x.Ota
+	}
+	return false
+}
+
+func (x *Device) GetLastIp() string {
+	if x != nil {
+		return x.LastIp
+	}
+	return ""
+}
+
+func (x *Device) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Device) GetRegisterTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RegisterTime
+	}
+	return nil
+}
+
+func (x *Device) GetLastActiveTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActiveTime
+	}
+	return nil
+}
+
+// 设备列表请求
+type ListDevicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`                         // 从 1 开始，缺省为 1
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // 缺省为 20，最大 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesRequest) Reset() {
+	*x = ListDevicesRequest{}
+	mi := &file_api_v1_management_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesRequest) ProtoMessage() {}
+
+func (x *ListDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListDevicesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListDevicesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// 设备列表响应
+type ListDevicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Devices       []*Device              `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesResponse) Reset() {
+	*x = ListDevicesResponse{}
+	mi := &file_api_v1_management_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesResponse) ProtoMessage() {}
+
+func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListDevicesResponse) GetDevices() []*Device {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *ListDevicesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListDevicesResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListDevicesResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// 设备详情请求
+type GetDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeviceRequest) Reset() {
+	*x = GetDeviceRequest{}
+	mi := &file_api_v1_management_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeviceRequest) ProtoMessage() {}
+
+func (x *GetDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeviceRequest.ProtoReflect.Descriptor instead.
+func (*GetDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetDeviceRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+// 插件能力摘要
+type PluginCapability struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Enabled       bool                   `protobuf:"varint,5,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginCapability) Reset() {
+	*x = PluginCapability{}
+	mi := &file_api_v1_management_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginCapability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginCapability) ProtoMessage() {}
+
+func (x *PluginCapability) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginCapability.ProtoReflect.Descriptor instead.
+func (*PluginCapability) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PluginCapability) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PluginCapability) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PluginCapability) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PluginCapability) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PluginCapability) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+// 插件状态
+type Plugin struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type            string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Description     string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Version         string                 `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	Status          string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Address         string                 `protobuf:"bytes,7,opt,name=address,proto3" json:"address,omitempty"`
+	Port            int32                  `protobuf:"varint,8,opt,name=port,proto3" json:"port,omitempty"`
+	HealthStatus    string                 `protobuf:"bytes,9,opt,name=health_status,json=healthStatus,proto3" json:"health_status,omitempty"`
+	Error           string                 `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
+	Capabilities    []*PluginCapability    `protobuf:"bytes,11,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	LastHealthCheck *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=last_health_check,json=lastHealthCheck,proto3" json:"last_health_check,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Plugin) Reset() {
+	*x = Plugin{}
+	mi := &file_api_v1_management_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Plugin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Plugin) ProtoMessage() {}
+
+func (x *Plugin) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Plugin.ProtoReflect.Descriptor instead.
+func (*Plugin) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Plugin) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Plugin) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Plugin) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Plugin) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Plugin) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Plugin) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Plugin) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Plugin) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *Plugin) GetHealthStatus() string {
+	if x != nil {
+		return x.HealthStatus
+	}
+	return ""
+}
+
+func (x *Plugin) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Plugin) GetCapabilities() []*PluginCapability {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *Plugin) GetLastHealthCheck() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastHealthCheck
+	}
+	return nil
+}
+
+func (x *Plugin) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// 插件列表请求
+type ListPluginsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	HealthStatus  string                 `protobuf:"bytes,3,opt,name=health_status,json=healthStatus,proto3" json:"health_status,omitempty"`
+	Search        string                 `protobuf:"bytes,4,opt,name=search,proto3" json:"search,omitempty"`
+	Page          int32                  `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPluginsRequest) Reset() {
+	*x = ListPluginsRequest{}
+	mi := &file_api_v1_management_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPluginsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPluginsRequest) ProtoMessage() {}
+
+func (x *ListPluginsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPluginsRequest.ProtoReflect.Descriptor instead.
+func (*ListPluginsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListPluginsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ListPluginsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListPluginsRequest) GetHealthStatus() string {
+	if x != nil {
+		return x.HealthStatus
+	}
+	return ""
+}
+
+func (x *ListPluginsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListPluginsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPluginsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// 插件列表响应
+type ListPluginsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plugins       []*Plugin              `protobuf:"bytes,1,rep,name=plugins,proto3" json:"plugins,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,5,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPluginsResponse) Reset() {
+	*x = ListPluginsResponse{}
+	mi := &file_api_v1_management_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPluginsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPluginsResponse) ProtoMessage() {}
+
+func (x *ListPluginsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPluginsResponse.ProtoReflect.Descriptor instead.
+func (*ListPluginsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListPluginsResponse) GetPlugins() []*Plugin {
+	if x != nil {
+		return x.Plugins
+	}
+	return nil
+}
+
+func (x *ListPluginsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListPluginsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPluginsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPluginsResponse) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+// 插件详情请求
+type GetPluginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PluginId      string                 `protobuf:"bytes,1,opt,name=plugin_id,json=pluginId,proto3" json:"plugin_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPluginRequest) Reset() {
+	*x = GetPluginRequest{}
+	mi := &file_api_v1_management_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPluginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPluginRequest) ProtoMessage() {}
+
+func (x *GetPluginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPluginRequest.ProtoReflect.Descriptor instead.
+func (*GetPluginRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetPluginRequest) GetPluginId() string {
+	if x != nil {
+		return x.PluginId
+	}
+	return ""
+}
+
+// 插件控制请求
+type ControlPluginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PluginId      string                 `protobuf:"bytes,1,opt,name=plugin_id,json=pluginId,proto3" json:"plugin_id,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"` // start、stop、restart、reallocate_port
+	Config        *structpb.Struct       `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"` // 仅 start 使用，覆盖插件配置
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ControlPluginRequest) Reset() {
+	*x = ControlPluginRequest{}
+	mi := &file_api_v1_management_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ControlPluginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControlPluginRequest) ProtoMessage() {}
+
+func (x *ControlPluginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControlPluginRequest.ProtoReflect.Descriptor instead.
+func (*ControlPluginRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ControlPluginRequest) GetPluginId() string {
+	if x != nil {
+		return x.PluginId
+	}
+	return ""
+}
+
+func (x *ControlPluginRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ControlPluginRequest) GetConfig() *structpb.Struct {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+// 插件控制响应
+type ControlPluginResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OldStatus     string                 `protobuf:"bytes,1,opt,name=old_status,json=oldStatus,proto3" json:"old_status,omitempty"`
+	NewStatus     string                 `protobuf:"bytes,2,opt,name=new_status,json=newStatus,proto3" json:"new_status,omitempty"`
+	OldPort       int32                  `protobuf:"varint,3,opt,name=old_port,json=oldPort,proto3" json:"old_port,omitempty"`
+	NewPort       int32                  `protobuf:"varint,4,opt,name=new_port,json=newPort,proto3" json:"new_port,omitempty"`
+	ProcessTime   string                 `protobuf:"bytes,5,opt,name=process_time,json=processTime,proto3" json:"process_time,omitempty"`
+	Plugin        *Plugin                `protobuf:"bytes,6,opt,name=plugin,proto3" json:"plugin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ControlPluginResponse) Reset() {
+	*x = ControlPluginResponse{}
+	mi := &file_api_v1_management_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ControlPluginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControlPluginResponse) ProtoMessage() {}
+
+func (x *ControlPluginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControlPluginResponse.ProtoReflect.Descriptor instead.
+func (*ControlPluginResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ControlPluginResponse) GetOldStatus() string {
+	if x != nil {
+		return x.OldStatus
+	}
+	return ""
+}
+
+func (x *ControlPluginResponse) GetNewStatus() string {
+	if x != nil {
+		return x.NewStatus
+	}
+	return ""
+}
+
+func (x *ControlPluginResponse) GetOldPort() int32 {
+	if x != nil {
+		return x.OldPort
+	}
+	return 0
+}
+
+func (x *ControlPluginResponse) GetNewPort() int32 {
+	if x != nil {
+		return x.NewPort
+	}
+	return 0
+}
+
+func (x *ControlPluginResponse) GetProcessTime() string {
+	if x != nil {
+		return x.ProcessTime
+	}
+	return ""
+}
+
+func (x *ControlPluginResponse) GetPlugin() *Plugin {
+	if x != nil {
+		return x.Plugin
+	}
+	return nil
+}
+
+// 工作流；spec 为节点、连线和配置，结构与 HTTP 接口的工作流 JSON 相同
+type Workflow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Version       string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	Spec          *structpb.Struct       `protobuf:"bytes,5,opt,name=spec,proto3" json:"spec,omitempty"` // nodes、edges、config
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Workflow) Reset() {
+	*x = Workflow{}
+	mi := &file_api_v1_management_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Workflow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Workflow) ProtoMessage() {}
+
+func (x *Workflow) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Workflow.ProtoReflect.Descriptor instead.
+func (*Workflow) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Workflow) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Workflow) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Workflow) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Workflow) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Workflow) GetSpec() *structpb.Struct {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *Workflow) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Workflow) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// 获取当前工作流请求
+type GetCurrentWorkflowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentWorkflowRequest) Reset() {
+	*x = GetCurrentWorkflowRequest{}
+	mi := &file_api_v1_management_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentWorkflowRequest) ProtoMessage() {}
+
+func (x *GetCurrentWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*GetCurrentWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{12}
+}
+
+// 保存工作流请求
+type SaveWorkflowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workflow      *Workflow              `protobuf:"bytes,1,opt,name=workflow,proto3" json:"workflow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveWorkflowRequest) Reset() {
+	*x = SaveWorkflowRequest{}
+	mi := &file_api_v1_management_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveWorkflowRequest) ProtoMessage() {}
+
+func (x *SaveWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*SaveWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SaveWorkflowRequest) GetWorkflow() *Workflow {
+	if x != nil {
+		return x.Workflow
+	}
+	return nil
+}
+
+// 模板参数声明
+type TemplateParameter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Required      bool                   `protobuf:"varint,5,opt,name=required,proto3" json:"required,omitempty"`
+	DefaultValue  *structpb.Value        `protobuf:"bytes,6,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	Validation    *structpb.Struct       `protobuf:"bytes,7,opt,name=validation,proto3" json:"validation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TemplateParameter) Reset() {
+	*x = TemplateParameter{}
+	mi := &file_api_v1_management_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TemplateParameter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateParameter) ProtoMessage() {}
+
+func (x *TemplateParameter) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemplateParameter.ProtoReflect.Descriptor instead.
+func (*TemplateParameter) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TemplateParameter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TemplateParameter) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *TemplateParameter) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TemplateParameter) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TemplateParameter) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *TemplateParameter) GetDefaultValue() *structpb.Value {
+	if x != nil {
+		return x.DefaultValue
+	}
+	return nil
+}
+
+func (x *TemplateParameter) GetValidation() *structpb.Struct {
+	if x != nil {
+		return x.Validation
+	}
+	return nil
+}
+
+// 工作流模板
+type WorkflowTemplate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	Builtin       bool                   `protobuf:"varint,6,opt,name=builtin,proto3" json:"builtin,omitempty"`
+	Parameters    []*TemplateParameter   `protobuf:"bytes,7,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	Workflow      *Workflow              `protobuf:"bytes,8,opt,name=workflow,proto3" json:"workflow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowTemplate) Reset() {
+	*x = WorkflowTemplate{}
+	mi := &file_api_v1_management_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowTemplate) ProtoMessage() {}
+
+func (x *WorkflowTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowTemplate.ProtoReflect.Descriptor instead.
+func (*WorkflowTemplate) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WorkflowTemplate) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WorkflowTemplate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkflowTemplate) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *WorkflowTemplate) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *WorkflowTemplate) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *WorkflowTemplate) GetBuiltin() bool {
+	if x != nil {
+		return x.Builtin
+	}
+	return false
+}
+
+func (x *WorkflowTemplate) GetParameters() []*TemplateParameter {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *WorkflowTemplate) GetWorkflow() *Workflow {
+	if x != nil {
+		return x.Workflow
+	}
+	return nil
+}
+
+// 模板列表请求
+type ListWorkflowTemplatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkflowTemplatesRequest) Reset() {
+	*x = ListWorkflowTemplatesRequest{}
+	mi := &file_api_v1_management_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkflowTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkflowTemplatesRequest) ProtoMessage() {}
+
+func (x *ListWorkflowTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkflowTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkflowTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{16}
+}
+
+// 模板列表响应
+type ListWorkflowTemplatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Templates     []*WorkflowTemplate    `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkflowTemplatesResponse) Reset() {
+	*x = ListWorkflowTemplatesResponse{}
+	mi := &file_api_v1_management_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkflowTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkflowTemplatesResponse) ProtoMessage() {}
+
+func (x *ListWorkflowTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkflowTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkflowTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListWorkflowTemplatesResponse) GetTemplates() []*WorkflowTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+// 模板部署请求
+type DeployWorkflowTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId    string                 `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Parameters    *structpb.Struct       `protobuf:"bytes,2,opt,name=parameters,proto3" json:"parameters,omitempty"`
+	DryRun        bool                   `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeployWorkflowTemplateRequest) Reset() {
+	*x = DeployWorkflowTemplateRequest{}
+	mi := &file_api_v1_management_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeployWorkflowTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeployWorkflowTemplateRequest) ProtoMessage() {}
+
+func (x *DeployWorkflowTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeployWorkflowTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeployWorkflowTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeployWorkflowTemplateRequest) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *DeployWorkflowTemplateRequest) GetParameters() *structpb.Struct {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *DeployWorkflowTemplateRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// 工作流执行请求
+type ExecuteWorkflowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"` // 必须是当前工作流的ID
+	Inputs        *structpb.Struct       `protobuf:"bytes,2,opt,name=inputs,proto3" json:"inputs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteWorkflowRequest) Reset() {
+	*x = ExecuteWorkflowRequest{}
+	mi := &file_api_v1_management_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteWorkflowRequest) ProtoMessage() {}
+
+func (x *ExecuteWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*ExecuteWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ExecuteWorkflowRequest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *ExecuteWorkflowRequest) GetInputs() *structpb.Struct {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+// 执行记录
+type Execution struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WorkflowId    string                 `protobuf:"bytes,2,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"` // 未结束时为空
+	Inputs        *structpb.Struct       `protobuf:"bytes,7,opt,name=inputs,proto3" json:"inputs,omitempty"`
+	Outputs       *structpb.Struct       `protobuf:"bytes,8,opt,name=outputs,proto3" json:"outputs,omitempty"`
+	NodeResults   *structpb.Struct       `protobuf:"bytes,9,opt,name=node_results,json=nodeResults,proto3" json:"node_results,omitempty"` // 节点ID到节点结果
+	Logs          *structpb.ListValue    `protobuf:"bytes,10,opt,name=logs,proto3" json:"logs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Execution) Reset() {
+	*x = Execution{}
+	mi := &file_api_v1_management_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Execution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Execution) ProtoMessage() {}
+
+func (x *Execution) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Execution.ProtoReflect.Descriptor instead.
+func (*Execution) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Execution) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Execution) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *Execution) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Execution) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Execution) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *Execution) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *Execution) GetInputs() *structpb.Struct {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *Execution) GetOutputs() *structpb.Struct {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *Execution) GetNodeResults() *structpb.Struct {
+	if x != nil {
+		return x.NodeResults
+	}
+	return nil
+}
+
+func (x *Execution) GetLogs() *structpb.ListValue {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+// 执行记录请求
+type GetExecutionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExecutionRequest) Reset() {
+	*x = GetExecutionRequest{}
+	mi := &file_api_v1_management_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExecutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExecutionRequest) ProtoMessage() {}
+
+func (x *GetExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_management_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExecutionRequest.ProtoReflect.Descriptor instead.
+func (*GetExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_management_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetExecutionRequest) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+var File_api_v1_management_proto protoreflect.FileDescriptor
+
+const file_api_v1_management_proto_rawDesc = "" +
+	"\n" +
+	"\x17api/v1/management.proto\x12\x15xiaozhi.management.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xcb\x03\n" +
+	"\x06Device\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\tR\aversion\x12\x1d\n" +
+	"\n" +
+	"board_type\x18\x06 \x01(\tR\tboardType\x12&\n" +
+	"\x0fchip_model_name\x18\a \x01(\tR\rchipModelName\x12\x1f\n" +
+	"\vauth_status\x18\b \x01(\tR\n" +
+	"authStatus\x12\x16\n" +
+	"\x06online\x18\t \x01(\bR\x06online\x12\x10\n" +
+	"\x03ota\x18\n" +
+	" \x01(\bR\x03ota\x12\x17\n" +
+	"\alast_ip\x18\v \x01(\tR\x06lastIp\x12\x17\n" +
+	"\auser_id\x18\f \x01(\x03R\x06userId\x12?\n" +
+	"\rregister_time\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\fregisterTime\x12D\n" +
+	"\x10last_active_time\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\x0elastActiveTime\"E\n" +
+	"\x12ListDevicesRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"\x95\x01\n" +
+	"\x13ListDevicesResponse\x127\n" +
+	"\adevices\x18\x01 \x03(\v2\x1d.xiaozhi.management.v1.DeviceR\adevices\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"/\n" +
+	"\x10GetDeviceRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"\x86\x01\n" +
+	"\x10PluginCapability\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x18\n" +
+	"\aenabled\x18\x05 \x01(\bR\aenabled\"\xcd\x03\n" +
+	"\x06Plugin\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\tR\aversion\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x18\n" +
+	"\aaddress\x18\a \x01(\tR\aaddress\x12\x12\n" +
+	"\x04port\x18\b \x01(\x05R\x04port\x12#\n" +
+	"\rhealth_status\x18\t \x01(\tR\fhealthStatus\x12\x14\n" +
+	"\x05error\x18\n" +
+	" \x01(\tR\x05error\x12K\n" +
+	"\fcapabilities\x18\v \x03(\v2'.xiaozhi.management.v1.PluginCapabilityR\fcapabilities\x12F\n" +
+	"\x11last_health_check\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x0flastHealthCheck\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xae\x01\n" +
+	"\x12ListPluginsRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12#\n" +
+	"\rhealth_status\x18\x03 \x01(\tR\fhealthStatus\x12\x16\n" +
+	"\x06search\x18\x04 \x01(\tR\x06search\x12\x12\n" +
+	"\x04page\x18\x05 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\"\xb6\x01\n" +
+	"\x13ListPluginsResponse\x127\n" +
+	"\aplugins\x18\x01 \x03(\v2\x1d.xiaozhi.management.v1.PluginR\aplugins\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1f\n" +
+	"\vtotal_pages\x18\x05 \x01(\x05R\n" +
+	"totalPages\"/\n" +
+	"\x10GetPluginRequest\x12\x1b\n" +
+	"\tplugin_id\x18\x01 \x01(\tR\bpluginId\"|\n" +
+	"\x14ControlPluginRequest\x12\x1b\n" +
+	"\tplugin_id\x18\x01 \x01(\tR\bpluginId\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12/\n" +
+	"\x06config\x18\x03 \x01(\v2\x17.google.protobuf.StructR\x06config\"\xe5\x01\n" +
+	"\x15ControlPluginResponse\x12\x1d\n" +
+	"\n" +
+	"old_status\x18\x01 \x01(\tR\toldStatus\x12\x1d\n" +
+	"\n" +
+	"new_status\x18\x02 \x01(\tR\tnewStatus\x12\x19\n" +
+	"\bold_port\x18\x03 \x01(\x05R\aoldPort\x12\x19\n" +
+	"\bnew_port\x18\x04 \x01(\x05R\anewPort\x12!\n" +
+	"\fprocess_time\x18\x05 \x01(\tR\vprocessTime\x125\n" +
+	"\x06plugin\x18\x06 \x01(\v2\x1d.xiaozhi.management.v1.PluginR\x06plugin\"\x8d\x02\n" +
+	"\bWorkflow\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\x12+\n" +
+	"\x04spec\x18\x05 \x01(\v2\x17.google.protobuf.StructR\x04spec\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x1b\n" +
+	"\x19GetCurrentWorkflowRequest\"R\n" +
+	"\x13SaveWorkflowRequest\x12;\n" +
+	"\bworkflow\x18\x01 \x01(\v2\x1f.xiaozhi.management.v1.WorkflowR\bworkflow\"\x85\x02\n" +
+	"\x11TemplateParameter\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1a\n" +
+	"\brequired\x18\x05 \x01(\bR\brequired\x12;\n" +
+	"\rdefault_value\x18\x06 \x01(\v2\x16.google.protobuf.ValueR\fdefaultValue\x127\n" +
+	"\n" +
+	"validation\x18\a \x01(\v2\x17.google.protobuf.StructR\n" +
+	"validation\"\xa9\x02\n" +
+	"\x10WorkflowTemplate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x18\n" +
+	"\abuiltin\x18\x06 \x01(\bR\abuiltin\x12H\n" +
+	"\n" +
+	"parameters\x18\a \x03(\v2(.xiaozhi.management.v1.TemplateParameterR\n" +
+	"parameters\x12;\n" +
+	"\bworkflow\x18\b \x01(\v2\x1f.xiaozhi.management.v1.WorkflowR\bworkflow\"\x1e\n" +
+	"\x1cListWorkflowTemplatesRequest\"f\n" +
+	"\x1dListWorkflowTemplatesResponse\x12E\n" +
+	"\ttemplates\x18\x01 \x03(\v2'.xiaozhi.management.v1.WorkflowTemplateR\ttemplates\"\x92\x01\n" +
+	"\x1dDeployWorkflowTemplateRequest\x12\x1f\n" +
+	"\vtemplate_id\x18\x01 \x01(\tR\n" +
+	"templateId\x127\n" +
+	"\n" +
+	"parameters\x18\x02 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"parameters\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\"j\n" +
+	"\x16ExecuteWorkflowRequest\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\x12/\n" +
+	"\x06inputs\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x06inputs\"\xac\x03\n" +
+	"\tExecution\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vworkflow_id\x18\x02 \x01(\tR\n" +
+	"workflowId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"start_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12/\n" +
+	"\x06inputs\x18\a \x01(\v2\x17.google.protobuf.StructR\x06inputs\x121\n" +
+	"\aoutputs\x18\b \x01(\v2\x17.google.protobuf.StructR\aoutputs\x12:\n" +
+	"\fnode_results\x18\t \x01(\v2\x17.google.protobuf.StructR\vnodeResults\x12.\n" +
+	"\x04logs\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.ListValueR\x04logs\"8\n" +
+	"\x13GetExecutionRequest\x12!\n" +
+	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId2\xa9\f\n" +
+	"\x11ManagementService\x12}\n" +
+	"\vListDevices\x12).xiaozhi.management.v1.ListDevicesRequest\x1a*.xiaozhi.management.v1.ListDevicesResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/api/v1/devices\x12x\n" +
+	"\tGetDevice\x12'.xiaozhi.management.v1.GetDeviceRequest\x1a\x1d.xiaozhi.management.v1.Device\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/api/v1/devices/{device_id}\x12}\n" +
+	"\vListPlugins\x12).xiaozhi.management.v1.ListPluginsRequest\x1a*.xiaozhi.management.v1.ListPluginsResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/api/v1/plugins\x12x\n" +
+	"\tGetPlugin\x12'.xiaozhi.management.v1.GetPluginRequest\x1a\x1d.xiaozhi.management.v1.Plugin\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/api/v1/plugins/{plugin_id}\x12\x9a\x01\n" +
+	"\rControlPlugin\x12+.xiaozhi.management.v1.ControlPluginRequest\x1a,.xiaozhi.management.v1.ControlPluginResponse\".\x82\xd3\xe4\x93\x02(:\x01*\"#/api/v1/plugins/{plugin_id}/control\x12\x89\x01\n" +
+	"\x12GetCurrentWorkflow\x120.xiaozhi.management.v1.GetCurrentWorkflowRequest\x1a\x1f.xiaozhi.management.v1.Workflow\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/workflow/current\x12\x7f\n" +
+	"\fSaveWorkflow\x12*.xiaozhi.management.v1.SaveWorkflowRequest\x1a\x1f.xiaozhi.management.v1.Workflow\"\"\x82\xd3\xe4\x93\x02\x1c:\bworkflow\"\x10/api/v1/workflow\x12\xa6\x01\n" +
+	"\x15ListWorkflowTemplates\x123.xiaozhi.management.v1.ListWorkflowTemplatesRequest\x1a4.xiaozhi.management.v1.ListWorkflowTemplatesResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/api/v1/workflow/templates\x12\xab\x01\n" +
+	"\x16DeployWorkflowTemplate\x124.xiaozhi.management.v1.DeployWorkflowTemplateRequest\x1a\x1f.xiaozhi.management.v1.Workflow\":\x82\xd3\xe4\x93\x024:\x01*\"//api/v1/workflow/templates/{template_id}/deploy\x12\x96\x01\n" +
+	"\x0fExecuteWorkflow\x12-.xiaozhi.management.v1.ExecuteWorkflowRequest\x1a .xiaozhi.management.v1.Execution\"2\x82\xd3\xe4\x93\x02,:\x01*\"'/api/v1/workflows/{workflow_id}/execute\x12\x87\x01\n" +
+	"\fGetExecution\x12*.xiaozhi.management.v1.GetExecutionRequest\x1a .xiaozhi.management.v1.Execution\")\x82\xd3\xe4\x93\x02#\x12!/api/v1/executions/{execution_id}B.Z,xiaozhi-server-go/gen/go/api/v1;managementv1b\x06proto3"
+
+var (
+	file_api_v1_management_proto_rawDescOnce sync.Once
+	file_api_v1_management_proto_rawDescData []byte
+)
+
+func file_api_v1_management_proto_rawDescGZIP() []byte {
+	file_api_v1_management_proto_rawDescOnce.Do(func() {
+		file_api_v1_management_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_v1_management_proto_rawDesc), len(file_api_v1_management_proto_rawDesc)))
+	})
+	return file_api_v1_management_proto_rawDescData
+}
+
+var file_api_v1_management_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_api_v1_management_proto_goTypes = []any{
+	(*Device)(nil),                        // 0: xiaozhi.management.v1.Device
+	(*ListDevicesRequest)(nil),            // 1: xiaozhi.management.v1.ListDevicesRequest
+	(*ListDevicesResponse)(nil),           // 2: xiaozhi.management.v1.ListDevicesResponse
+	(*GetDeviceRequest)(nil),              // 3: xiaozhi.management.v1.GetDeviceRequest
+	(*PluginCapability)(nil),              // 4: xiaozhi.management.v1.PluginCapability
+	(*Plugin)(nil),                        // 5: xiaozhi.management.v1.Plugin
+	(*ListPluginsRequest)(nil),            // 6: xiaozhi.management.v1.ListPluginsRequest
+	(*ListPluginsResponse)(nil),           // 7: xiaozhi.management.v1.ListPluginsResponse
+	(*GetPluginRequest)(nil),              // 8: xiaozhi.management.v1.GetPluginRequest
+	(*ControlPluginRequest)(nil),          // 9: xiaozhi.management.v1.ControlPluginRequest
+	(*ControlPluginResponse)(nil),         // 10: xiaozhi.management.v1.ControlPluginResponse
+	(*Workflow)(nil),                      // 11: xiaozhi.management.v1.Workflow
+	(*GetCurrentWorkflowRequest)(nil),     // 12: xiaozhi.management.v1.GetCurrentWorkflowRequest
+	(*SaveWorkflowRequest)(nil),           // 13: xiaozhi.management.v1.SaveWorkflowRequest
+	(*TemplateParameter)(nil),             // 14: xiaozhi.management.v1.TemplateParameter
+	(*WorkflowTemplate)(nil),              // 15: xiaozhi.management.v1.WorkflowTemplate
+	(*ListWorkflowTemplatesRequest)(nil),  // 16: xiaozhi.management.v1.ListWorkflowTemplatesRequest
+	(*ListWorkflowTemplatesResponse)(nil), // 17: xiaozhi.management.v1.ListWorkflowTemplatesResponse
+	(*DeployWorkflowTemplateRequest)(nil), // 18: xiaozhi.management.v1.DeployWorkflowTemplateRequest
+	(*ExecuteWorkflowRequest)(nil),        // 19: xiaozhi.management.v1.ExecuteWorkflowRequest
+	(*Execution)(nil),                     // 20: xiaozhi.management.v1.Execution
+	(*GetExecutionRequest)(nil),           // 21: xiaozhi.management.v1.GetExecutionRequest
+	(*timestamppb.Timestamp)(nil),         // 22: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),               // 23: google.protobuf.Struct
+	(*structpb.Value)(nil),                // 24: google.protobuf.Value
+	(*structpb.ListValue)(nil),            // 25: google.protobuf.ListValue
+}
+var file_api_v1_management_proto_depIdxs = []int32{
+	22, // 0: xiaozhi.management.v1.Device.register_time:type_name -> google.protobuf.Timestamp
+	22, // 1: xiaozhi.management.v1.Device.last_active_time:type_name -> google.protobuf.Timestamp
+	0,  // 2: xiaozhi.management.v1.ListDevicesResponse.devices:type_name -> xiaozhi.management.v1.Device
+	4,  // 3: xiaozhi.management.v1.Plugin.capabilities:type_name -> xiaozhi.management.v1.PluginCapability
+	22, // 4: xiaozhi.management.v1.Plugin.last_health_check:type_name -> google.protobuf.Timestamp
+	22, // 5: xiaozhi.management.v1.Plugin.updated_at:type_name -> google.protobuf.Timestamp
+	5,  // 6: xiaozhi.management.v1.ListPluginsResponse.plugins:type_name -> xiaozhi.management.v1.Plugin
+	23, // 7: xiaozhi.management.v1.ControlPluginRequest.config:type_name -> google.protobuf.Struct
+	5,  // 8: xiaozhi.management.v1.ControlPluginResponse.plugin:type_name -> xiaozhi.management.v1.Plugin
+	23, // 9: xiaozhi.management.v1.Workflow.spec:type_name -> google.protobuf.Struct
+	22, // 10: xiaozhi.management.v1.Workflow.created_at:type_name -> google.protobuf.Timestamp
+	22, // 11: xiaozhi.management.v1.Workflow.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 12: xiaozhi.management.v1.SaveWorkflowRequest.workflow:type_name -> xiaozhi.management.v1.Workflow
+	24, // 13: xiaozhi.management.v1.TemplateParameter.default_value:type_name -> google.protobuf.Value
+	23, // 14: xiaozhi.management.v1.TemplateParameter.validation:type_name -> google.protobuf.Struct
+	14, // 15: xiaozhi.management.v1.WorkflowTemplate.parameters:type_name -> xiaozhi.management.v1.TemplateParameter
+	11, // 16: xiaozhi.management.v1.WorkflowTemplate.workflow:type_name -> xiaozhi.management.v1.Workflow
+	15, // 17: xiaozhi.management.v1.ListWorkflowTemplatesResponse.templates:type_name -> xiaozhi.management.v1.WorkflowTemplate
+	23, // 18: xiaozhi.management.v1.DeployWorkflowTemplateRequest.parameters:type_name -> google.protobuf.Struct
+	23, // 19: xiaozhi.management.v1.ExecuteWorkflowRequest.inputs:type_name -> google.protobuf.Struct
+	22, // 20: xiaozhi.management.v1.Execution.start_time:type_name -> google.protobuf.Timestamp
+	22, // 21: xiaozhi.management.v1.Execution.end_time:type_name -> google.protobuf.Timestamp
+	23, // 22: xiaozhi.management.v1.Execution.inputs:type_name -> google.protobuf.Struct
+	23, // 23: xiaozhi.management.v1.Execution.outputs:type_name -> google.protobuf.Struct
+	23, // 24: xiaozhi.management.v1.Execution.node_results:type_name -> google.protobuf.Struct
+	25, // 25: xiaozhi.management.v1.Execution.logs:type_name -> google.protobuf.ListValue
+	1,  // 26: xiaozhi.management.v1.ManagementService.ListDevices:input_type -> xiaozhi.management.v1.ListDevicesRequest
+	3,  // 27: xiaozhi.management.v1.ManagementService.GetDevice:input_type -> xiaozhi.management.v1.GetDeviceRequest
+	6,  // 28: xiaozhi.management.v1.ManagementService.ListPlugins:input_type -> xiaozhi.management.v1.ListPluginsRequest
+	8,  // 29: xiaozhi.management.v1.ManagementService.GetPlugin:input_type -> xiaozhi.management.v1.GetPluginRequest
+	9,  // 30: xiaozhi.management.v1.ManagementService.ControlPlugin:input_type -> xiaozhi.management.v1.ControlPluginRequest
+	12, // 31: xiaozhi.management.v1.ManagementService.GetCurrentWorkflow:input_type -> xiaozhi.management.v1.GetCurrentWorkflowRequest
+	13, // 32: xiaozhi.management.v1.ManagementService.SaveWorkflow:input_type -> xiaozhi.management.v1.SaveWorkflowRequest
+	16, // 33: xiaozhi.management.v1.ManagementService.ListWorkflowTemplates:input_type -> xiaozhi.management.v1.ListWorkflowTemplatesRequest
+	18, // 34: xiaozhi.management.v1.ManagementService.DeployWorkflowTemplate:input_type -> xiaozhi.management.v1.DeployWorkflowTemplateRequest
+	19, // 35: xiaozhi.management.v1.ManagementService.ExecuteWorkflow:input_type -> xiaozhi.management.v1.ExecuteWorkflowRequest
+	21, // 36: xiaozhi.management.v1.ManagementService.GetExecution:input_type -> xiaozhi.management.v1.GetExecutionRequest
+	2,  // 37: xiaozhi.management.v1.ManagementService.ListDevices:output_type -> xiaozhi.management.v1.ListDevicesResponse
+	0,  // 38: xiaozhi.management.v1.ManagementService.GetDevice:output_type -> xiaozhi.management.v1.Device
+	7,  // 39: xiaozhi.management.v1.ManagementService.ListPlugins:output_type -> xiaozhi.management.v1.ListPluginsResponse
+	5,  // 40: xiaozhi.management.v1.ManagementService.GetPlugin:output_type -> xiaozhi.management.v1.Plugin
+	10, // 41: xiaozhi.management.v1.ManagementService.ControlPlugin:output_type -> xiaozhi.management.v1.ControlPluginResponse
+	11, // 42: xiaozhi.management.v1.ManagementService.GetCurrentWorkflow:output_type -> xiaozhi.management.v1.Workflow
+	11, // 43: xiaozhi.management.v1.ManagementService.SaveWorkflow:output_type -> xiaozhi.management.v1.Workflow
+	17, // 44: xiaozhi.management.v1.ManagementService.ListWorkflowTemplates:output_type -> xiaozhi.management.v1.ListWorkflowTemplatesResponse
+	11, // 45: xiaozhi.management.v1.ManagementService.DeployWorkflowTemplate:output_type -> xiaozhi.management.v1.Workflow
+	20, // 46: xiaozhi.management.v1.ManagementService.ExecuteWorkflow:output_type -> xiaozhi.management.v1.Execution
+	20, // 47: xiaozhi.management.v1.ManagementService.GetExecution:output_type -> xiaozhi.management.v1.Execution
+	37, // [37:48] is the sub-list for method output_type
+	26, // [26:37] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_management_proto_init() }
+func file_api_v1_management_proto_init() {
+	if File_api_v1_management_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_management_proto_rawDesc), len(file_api_v1_management_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_v1_management_proto_goTypes,
+		DependencyIndexes: file_api_v1_management_proto_depIdxs,
+		MessageInfos:      file_api_v1_management_proto_msgTypes,
+	}.Build()
+	File_api_v1_management_proto = out.File
+	file_api_v1_management_proto_goTypes = nil
+	file_api_v1_management_proto_depIdxs = nil
+}
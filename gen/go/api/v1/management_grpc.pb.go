@@ -0,0 +1,527 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: api/v1/management.proto
+
+package managementv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ManagementService_ListDevices_FullMethodName            = "/xiaozhi.management.v1.ManagementService/ListDevices"
+	ManagementService_GetDevice_FullMethodName              = "/xiaozhi.management.v1.ManagementService/GetDevice"
+	ManagementService_ListPlugins_FullMethodName            = "/xiaozhi.management.v1.ManagementService/ListPlugins"
+	ManagementService_GetPlugin_FullMethodName              = "/xiaozhi.management.v1.ManagementService/GetPlugin"
+	ManagementService_ControlPlugin_FullMethodName          = "/xiaozhi.management.v1.ManagementService/ControlPlugin"
+	ManagementService_GetCurrentWorkflow_FullMethodName     = "/xiaozhi.management.v1.ManagementService/GetCurrentWorkflow"
+	ManagementService_SaveWorkflow_FullMethodName           = "/xiaozhi.management.v1.ManagementService/SaveWorkflow"
+	ManagementService_ListWorkflowTemplates_FullMethodName  = "/xiaozhi.management.v1.ManagementService/ListWorkflowTemplates"
+	ManagementService_DeployWorkflowTemplate_FullMethodName = "/xiaozhi.management.v1.ManagementService/DeployWorkflowTemplate"
+	ManagementService_ExecuteWorkflow_FullMethodName        = "/xiaozhi.management.v1.ManagementService/ExecuteWorkflow"
+	ManagementService_GetExecution_FullMethodName           = "/xiaozhi.management.v1.ManagementService/GetExecution"
+)
+
+// ManagementServiceClient is the client API for ManagementService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// 管理面服务：设备、插件、工作流和工作流执行
+// HTTP 注解与 Gin 路由 /api/v1/... 保持一致，可直接用于 grpc-gateway 生成
+type ManagementServiceClient interface {
+	// 获取设备列表
+	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
+	// 获取设备详情
+	GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*Device, error)
+	// 获取插件列表，支持分页和筛选
+	ListPlugins(ctx context.Context, in *ListPluginsRequest, opts ...grpc.CallOption) (*ListPluginsResponse, error)
+	// 获取插件详情
+	GetPlugin(ctx context.Context, in *GetPluginRequest, opts ...grpc.CallOption) (*Plugin, error)
+	// 控制插件：start、stop、restart、reallocate_port
+	ControlPlugin(ctx context.Context, in *ControlPluginRequest, opts ...grpc.CallOption) (*ControlPluginResponse, error)
+	// 获取当前工作流
+	GetCurrentWorkflow(ctx context.Context, in *GetCurrentWorkflowRequest, opts ...grpc.CallOption) (*Workflow, error)
+	// 保存工作流为当前工作流
+	SaveWorkflow(ctx context.Context, in *SaveWorkflowRequest, opts ...grpc.CallOption) (*Workflow, error)
+	// 获取工作流模板列表
+	ListWorkflowTemplates(ctx context.Context, in *ListWorkflowTemplatesRequest, opts ...grpc.CallOption) (*ListWorkflowTemplatesResponse, error)
+	// 以参数实例化模板并保存为当前工作流，dry_run 时只返回实例化结果
+	DeployWorkflowTemplate(ctx context.Context, in *DeployWorkflowTemplateRequest, opts ...grpc.CallOption) (*Workflow, error)
+	// 异步执行工作流，返回刚创建的执行记录
+	ExecuteWorkflow(ctx context.Context, in *ExecuteWorkflowRequest, opts ...grpc.CallOption) (*Execution, error)
+	// 获取执行记录，包含节点结果和日志
+	GetExecution(ctx context.Context, in *GetExecutionRequest, opts ...grpc.CallOption) (*Execution, error)
+}
+
+type managementServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewManagementServiceClient(cc grpc.ClientConnInterface) ManagementServiceClient {
+	return &managementServiceClient{cc}
+}
+
+func (c *managementServiceClient) ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDevicesResponse)
+	err := c.cc.Invoke(ctx, ManagementService_ListDevices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*Device, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Device)
+	err := c.cc.Invoke(ctx, ManagementService_GetDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) ListPlugins(ctx context.Context, in *ListPluginsRequest, opts ...grpc.CallOption) (*ListPluginsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPluginsResponse)
+	err := c.cc.Invoke(ctx, ManagementService_ListPlugins_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) GetPlugin(ctx context.Context, in *GetPluginRequest, opts ...grpc.CallOption) (*Plugin, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Plugin)
+	err := c.cc.Invoke(ctx, ManagementService_GetPlugin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) ControlPlugin(ctx context.Context, in *ControlPluginRequest, opts ...grpc.CallOption) (*ControlPluginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ControlPluginResponse)
+	err := c.cc.Invoke(ctx, ManagementService_ControlPlugin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) GetCurrentWorkflow(ctx context.Context, in *GetCurrentWorkflowRequest, opts ...grpc.CallOption) (*Workflow, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Workflow)
+	err := c.cc.Invoke(ctx, ManagementService_GetCurrentWorkflow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) SaveWorkflow(ctx context.Context, in *SaveWorkflowRequest, opts ...grpc.CallOption) (*Workflow, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Workflow)
+	err := c.cc.Invoke(ctx, ManagementService_SaveWorkflow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) ListWorkflowTemplates(ctx context.Context, in *ListWorkflowTemplatesRequest, opts ...grpc.CallOption) (*ListWorkflowTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWorkflowTemplatesResponse)
+	err := c.cc.Invoke(ctx, ManagementService_ListWorkflowTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) DeployWorkflowTemplate(ctx context.Context, in *DeployWorkflowTemplateRequest, opts ...grpc.CallOption) (*Workflow, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Workflow)
+	err := c.cc.Invoke(ctx, ManagementService_DeployWorkflowTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) ExecuteWorkflow(ctx context.Context, in *ExecuteWorkflowRequest, opts ...grpc.CallOption) (*Execution, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Execution)
+	err := c.cc.Invoke(ctx, ManagementService_ExecuteWorkflow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) GetExecution(ctx context.Context, in *GetExecutionRequest, opts ...grpc.CallOption) (*Execution, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Execution)
+	err := c.cc.Invoke(ctx, ManagementService_GetExecution_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ManagementServiceServer is the server API for ManagementService service.
+// All implementations should embed UnimplementedManagementServiceServer
+// for forward compatibility.
+//
+// 管理面服务：设备、插件、工作流和工作流执行
+// HTTP 注解与 Gin 路由 /api/v1/... 保持一致，可直接用于 grpc-gateway 生成
+type ManagementServiceServer interface {
+	// 获取设备列表
+	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
+	// 获取设备详情
+	GetDevice(context.Context, *GetDeviceRequest) (*Device, error)
+	// 获取插件列表，支持分页和筛选
+	ListPlugins(context.Context, *ListPluginsRequest) (*ListPluginsResponse, error)
+	// 获取插件详情
+	GetPlugin(context.Context, *GetPluginRequest) (*Plugin, error)
+	// 控制插件：start、stop、restart、reallocate_port
+	ControlPlugin(context.Context, *ControlPluginRequest) (*ControlPluginResponse, error)
+	// 获取当前工作流
+	GetCurrentWorkflow(context.Context, *GetCurrentWorkflowRequest) (*Workflow, error)
+	// 保存工作流为当前工作流
+	SaveWorkflow(context.Context, *SaveWorkflowRequest) (*Workflow, error)
+	// 获取工作流模板列表
+	ListWorkflowTemplates(context.Context, *ListWorkflowTemplatesRequest) (*ListWorkflowTemplatesResponse, error)
+	// 以参数实例化模板并保存为当前工作流，dry_run 时只返回实例化结果
+	DeployWorkflowTemplate(context.Context, *DeployWorkflowTemplateRequest) (*Workflow, error)
+	// 异步执行工作流，返回刚创建的执行记录
+	ExecuteWorkflow(context.Context, *ExecuteWorkflowRequest) (*Execution, error)
+	// 获取执行记录，包含节点结果和日志
+	GetExecution(context.Context, *GetExecutionRequest) (*Execution, error)
+}
+
+// UnimplementedManagementServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedManagementServiceServer struct{}
+
+func (UnimplementedManagementServiceServer) ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDevices not implemented")
+}
+func (UnimplementedManagementServiceServer) GetDevice(context.Context, *GetDeviceRequest) (*Device, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDevice not implemented")
+}
+func (UnimplementedManagementServiceServer) ListPlugins(context.Context, *ListPluginsRequest) (*ListPluginsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPlugins not implemented")
+}
+func (UnimplementedManagementServiceServer) GetPlugin(context.Context, *GetPluginRequest) (*Plugin, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPlugin not implemented")
+}
+func (UnimplementedManagementServiceServer) ControlPlugin(context.Context, *ControlPluginRequest) (*ControlPluginResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ControlPlugin not implemented")
+}
+func (UnimplementedManagementServiceServer) GetCurrentWorkflow(context.Context, *GetCurrentWorkflowRequest) (*Workflow, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCurrentWorkflow not implemented")
+}
+func (UnimplementedManagementServiceServer) SaveWorkflow(context.Context, *SaveWorkflowRequest) (*Workflow, error) {
+	return nil, status.Error(codes.Unimplemented, "method SaveWorkflow not implemented")
+}
+func (UnimplementedManagementServiceServer) ListWorkflowTemplates(context.Context, *ListWorkflowTemplatesRequest) (*ListWorkflowTemplatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListWorkflowTemplates not implemented")
+}
+func (UnimplementedManagementServiceServer) DeployWorkflowTemplate(context.Context, *DeployWorkflowTemplateRequest) (*Workflow, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeployWorkflowTemplate not implemented")
+}
+func (UnimplementedManagementServiceServer) ExecuteWorkflow(context.Context, *ExecuteWorkflowRequest) (*Execution, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExecuteWorkflow not implemented")
+}
+func (UnimplementedManagementServiceServer) GetExecution(context.Context, *GetExecutionRequest) (*Execution, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetExecution not implemented")
+}
+func (UnimplementedManagementServiceServer) testEmbeddedByValue() {}
+
+// UnsafeManagementServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ManagementServiceServer will
+// result in compilation errors.
+type UnsafeManagementServiceServer interface {
+	mustEmbedUnimplementedManagementServiceServer()
+}
+
+func RegisterManagementServiceServer(s grpc.ServiceRegistrar, srv ManagementServiceServer) {
+	// If the following call panics, it indicates UnimplementedManagementServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ManagementService_ServiceDesc, srv)
+}
+
+func _ManagementService_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_ListDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_GetDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).GetDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_GetDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).GetDevice(ctx, req.(*GetDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_ListPlugins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPluginsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).ListPlugins(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_ListPlugins_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).ListPlugins(ctx, req.(*ListPluginsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_GetPlugin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPluginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).GetPlugin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_GetPlugin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).GetPlugin(ctx, req.(*GetPluginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_ControlPlugin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ControlPluginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).ControlPlugin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_ControlPlugin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).ControlPlugin(ctx, req.(*ControlPluginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_GetCurrentWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCurrentWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).GetCurrentWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_GetCurrentWorkflow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).GetCurrentWorkflow(ctx, req.(*GetCurrentWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_SaveWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).SaveWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_SaveWorkflow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).SaveWorkflow(ctx, req.(*SaveWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_ListWorkflowTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkflowTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).ListWorkflowTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_ListWorkflowTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).ListWorkflowTemplates(ctx, req.(*ListWorkflowTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_DeployWorkflowTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeployWorkflowTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).DeployWorkflowTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_DeployWorkflowTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).DeployWorkflowTemplate(ctx, req.(*DeployWorkflowTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_ExecuteWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).ExecuteWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_ExecuteWorkflow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).ExecuteWorkflow(ctx, req.(*ExecuteWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_GetExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExecutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).GetExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_GetExecution_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).GetExecution(ctx, req.(*GetExecutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ManagementService_ServiceDesc is the grpc.ServiceDesc for ManagementService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ManagementService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "xiaozhi.management.v1.ManagementService",
+	HandlerType: (*ManagementServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDevices",
+			Handler:    _ManagementService_ListDevices_Handler,
+		},
+		{
+			MethodName: "GetDevice",
+			Handler:    _ManagementService_GetDevice_Handler,
+		},
+		{
+			MethodName: "ListPlugins",
+			Handler:    _ManagementService_ListPlugins_Handler,
+		},
+		{
+			MethodName: "GetPlugin",
+			Handler:    _ManagementService_GetPlugin_Handler,
+		},
+		{
+			MethodName: "ControlPlugin",
+			Handler:    _ManagementService_ControlPlugin_Handler,
+		},
+		{
+			MethodName: "GetCurrentWorkflow",
+			Handler:    _ManagementService_GetCurrentWorkflow_Handler,
+		},
+		{
+			MethodName: "SaveWorkflow",
+			Handler:    _ManagementService_SaveWorkflow_Handler,
+		},
+		{
+			MethodName: "ListWorkflowTemplates",
+			Handler:    _ManagementService_ListWorkflowTemplates_Handler,
+		},
+		{
+			MethodName: "DeployWorkflowTemplate",
+			Handler:    _ManagementService_DeployWorkflowTemplate_Handler,
+		},
+		{
+			MethodName: "ExecuteWorkflow",
+			Handler:    _ManagementService_ExecuteWorkflow_Handler,
+		},
+		{
+			MethodName: "GetExecution",
+			Handler:    _ManagementService_GetExecution_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v1/management.proto",
+}
@@ -0,0 +1,7225 @@
+// Code generated by xiaozhi-apigen from internal/platform/docs/swagger.json. DO NOT EDIT.
+
+// Package apiclient 是 /api/v1 的 Go 客户端，由 cmd/xiaozhi-apigen 根据 OpenAPI 规范生成
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const basePath = "/api"
+
+// Client /api/v1 客户端
+type Client struct {
+	BaseURL    string       // 服务地址，如 http://localhost:8080
+	Token      string       // 访问令牌，非空时以 Bearer 方式发送
+	Header     http.Header  // 每个请求附加的请求头
+	HTTPClient *http.Client // 为空时使用 http.DefaultClient
+}
+
+// NewClient 创建客户端
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: baseURL}
+}
+
+// Error 接口返回的失败响应
+type Error struct {
+	Status  int             // HTTP状态码
+	Code    string          // 错误码，如 VALIDATION_FAILED
+	Message string          // 错误说明
+	Details json.RawMessage // 错误详情，如字段级校验错误
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("HTTP %d: %s", e.Status, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// envelope 统一响应结构
+type envelope struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+	Message string          `json:"message"`
+	Error   *struct {
+		Code    string          `json:"code"`
+		Message string          `json:"message"`
+		Details json.RawMessage `json:"details"`
+	} `json:"error"`
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	target := strings.TrimRight(c.BaseURL, "/") + basePath + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	for key, values := range c.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return &Error{Status: resp.StatusCode, Message: strings.TrimSpace(string(data))}
+	}
+	if resp.StatusCode >= http.StatusBadRequest || !env.Success {
+		apiErr := &Error{Status: resp.StatusCode, Message: env.Message}
+		if env.Error != nil {
+			apiErr.Code = env.Error.Code
+			apiErr.Message = env.Error.Message
+			apiErr.Details = env.Error.Details
+		}
+		return apiErr
+	}
+	if out == nil || len(env.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(env.Data, out)
+}
+
+// GetAgents 获取智能体列表
+//
+// GET /v1/agents
+func (c *Client) GetAgents(ctx context.Context) ([]AgentInfo, error) {
+	path := "/v1/agents"
+	var out []AgentInfo
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// PostAgents 创建智能体
+// 创建有独立提示词、模型和工具白名单的智能体；配置 delegates 的智能体可以把子任务委托给其他智能体
+//
+// POST /v1/agents
+func (c *Client) PostAgents(ctx context.Context, body *AgentCreateRequest) (*AgentInfo, error) {
+	path := "/v1/agents"
+	var out AgentInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteAgentsByID 删除智能体
+// 被其他智能体委托的智能体不能删除
+//
+// DELETE /v1/agents/{id}
+func (c *Client) DeleteAgentsByID(ctx context.Context, id string) error {
+	path := "/v1/agents/" + url.PathEscape(id)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetAgentsByID 获取智能体详情
+//
+// GET /v1/agents/{id}
+func (c *Client) GetAgentsByID(ctx context.Context, id string) (*AgentInfo, error) {
+	path := "/v1/agents/" + url.PathEscape(id)
+	var out AgentInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutAgentsByID 更新智能体
+// 被其他智能体委托的智能体不能改名
+//
+// PUT /v1/agents/{id}
+func (c *Client) PutAgentsByID(ctx context.Context, id string, body *AgentUpdateRequest) (*AgentInfo, error) {
+	path := "/v1/agents/" + url.PathEscape(id)
+	var out AgentInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostAgentsByIDRun 运行智能体
+// 以给定输入运行智能体，返回回复以及按顺序记录的工具调用和委托
+//
+// POST /v1/agents/{id}/run
+func (c *Client) PostAgentsByIDRun(ctx context.Context, id string, body *AgentRunRequest) (*AgentRunResult, error) {
+	path := "/v1/agents/" + url.PathEscape(id) + "/run"
+	var out AgentRunResult
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAnalyticsRollups 按天或按周列出用量汇总
+// 返回后台定期预先汇总的对话数、平均延迟、Token和语音用量、热门意图、各提供者的出错比例和设备活跃情况，按周期起始日正序；不会在请求时扫描对话记录
+//
+// GET /v1/analytics/rollups
+func (c *Client) GetAnalyticsRollups(ctx context.Context, params *GetAnalyticsRollupsParams) ([]AnalyticsRollup, error) {
+	path := "/v1/analytics/rollups"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out []AnalyticsRollup
+	err := c.do(ctx, http.MethodGet, path, query, nil, &out)
+	return out, err
+}
+
+// GetAnalyticsRollupsParams GetAnalyticsRollups 的查询参数，零值不发送
+type GetAnalyticsRollupsParams struct {
+	// 汇总周期，默认 day
+	Period string
+	// 起始日期 YYYY-MM-DD，默认往前 7 天或 8 周
+	From string
+	// 结束日期 YYYY-MM-DD，默认今天
+	To string
+	// 只返回该设备的活跃情况
+	DeviceID string
+	// 意图和设备列表的条数，默认 20，最多 500
+	Limit int64
+}
+
+func (p *GetAnalyticsRollupsParams) values() url.Values {
+	query := url.Values{}
+	if p.Period != "" {
+		query.Set("period", p.Period)
+	}
+	if p.From != "" {
+		query.Set("from", p.From)
+	}
+	if p.To != "" {
+		query.Set("to", p.To)
+	}
+	if p.DeviceID != "" {
+		query.Set("device_id", p.DeviceID)
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return query
+}
+
+// GetAnalyticsSummary 查询范围内的用量合计
+// 把查询范围内的按天汇总合并为一个汇总，活跃设备数按设备去重
+//
+// GET /v1/analytics/summary
+func (c *Client) GetAnalyticsSummary(ctx context.Context, params *GetAnalyticsSummaryParams) (*AnalyticsRollup, error) {
+	path := "/v1/analytics/summary"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out AnalyticsRollup
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAnalyticsSummaryParams GetAnalyticsSummary 的查询参数，零值不发送
+type GetAnalyticsSummaryParams struct {
+	// 范围的对齐方式，默认 day
+	Period string
+	// 起始日期 YYYY-MM-DD，默认往前 7 天或 8 周
+	From string
+	// 结束日期 YYYY-MM-DD，默认今天
+	To string
+	// 只返回该设备的活跃情况
+	DeviceID string
+	// 意图和设备列表的条数，默认 20，最多 500
+	Limit int64
+}
+
+func (p *GetAnalyticsSummaryParams) values() url.Values {
+	query := url.Values{}
+	if p.Period != "" {
+		query.Set("period", p.Period)
+	}
+	if p.From != "" {
+		query.Set("from", p.From)
+	}
+	if p.To != "" {
+		query.Set("to", p.To)
+	}
+	if p.DeviceID != "" {
+		query.Set("device_id", p.DeviceID)
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return query
+}
+
+// GetApprovals List approval tasks
+//
+// GET /v1/approvals
+func (c *Client) GetApprovals(ctx context.Context, params *GetApprovalsParams) ([]ApprovalTask, error) {
+	path := "/v1/approvals"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out []ApprovalTask
+	err := c.do(ctx, http.MethodGet, path, query, nil, &out)
+	return out, err
+}
+
+// GetApprovalsParams GetApprovals 的查询参数，零值不发送
+type GetApprovalsParams struct {
+	// Filter by status
+	Status string
+}
+
+func (p *GetApprovalsParams) values() url.Values {
+	query := url.Values{}
+	if p.Status != "" {
+		query.Set("status", p.Status)
+	}
+	return query
+}
+
+// GetApprovalsByID Get approval task
+//
+// GET /v1/approvals/{id}
+func (c *Client) GetApprovalsByID(ctx context.Context, id string) (*ApprovalTask, error) {
+	path := "/v1/approvals/" + url.PathEscape(id)
+	var out ApprovalTask
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostApprovalsByIDDecision Decide approval task
+// Approves or rejects a pending task, which resumes or aborts the waiting execution. Deciding an already decided task returns 409 with the task in error.details
+//
+// POST /v1/approvals/{id}/decision
+func (c *Client) PostApprovalsByIDDecision(ctx context.Context, id string, body *ApprovalDecisionRequest) (*ApprovalTask, error) {
+	path := "/v1/approvals/" + url.PathEscape(id) + "/decision"
+	var out ApprovalTask
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBackups 获取备份列表
+// 列出备份目录中的备份文件，最新的在前，并返回自动备份设置
+//
+// GET /v1/backups
+func (c *Client) GetBackups(ctx context.Context) (*BackupListResponse, error) {
+	path := "/v1/backups"
+	var out BackupListResponse
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostBackups 创建备份
+// 对数据库做快照，连同 Backup.Paths 中的文件和目录打包并加密保存到备份目录。
+// 手动创建的备份不参与自动备份的保留数量清理；已有备份或恢复在进行时返回 409
+//
+// POST /v1/backups
+func (c *Client) PostBackups(ctx context.Context) (*BackupInfo, error) {
+	path := "/v1/backups"
+	var out BackupInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteBackupsByName 删除备份
+//
+// DELETE /v1/backups/{name}
+func (c *Client) DeleteBackupsByName(ctx context.Context, name string) error {
+	path := "/v1/backups/" + url.PathEscape(name)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetBackupsByName 下载备份
+// 下载加密的备份文件，可用 xiaozhi-server restore 在其他机器上恢复
+//
+// GET /v1/backups/{name}
+func (c *Client) GetBackupsByName(ctx context.Context, name string) (interface{}, error) {
+	path := "/v1/backups/" + url.PathEscape(name)
+	var out interface{}
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// PostBackupsByNameRestore 从备份恢复
+// 校验备份并解包到恢复暂存目录，服务下次启动时在打开数据库之前替换数据库和备份中的文件，
+// 被替换的文件改名为 *.pre-restore-<时间> 保留。校验不通过时不会暂存任何内容
+//
+// POST /v1/backups/{name}/restore
+func (c *Client) PostBackupsByNameRestore(ctx context.Context, name string, body *BackupPassphraseRequest) (*BackupRestoreResponse, error) {
+	path := "/v1/backups/" + url.PathEscape(name) + "/restore"
+	var out BackupRestoreResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostBackupsByNameVerify 校验备份
+// 解密备份并逐一核对清单中每个文件的大小和 SHA-256，不修改任何数据。口令错误或内容被篡改时返回 400
+//
+// POST /v1/backups/{name}/verify
+func (c *Client) PostBackupsByNameVerify(ctx context.Context, name string, body *BackupPassphraseRequest) (*BackupVerifyResponse, error) {
+	path := "/v1/backups/" + url.PathEscape(name) + "/verify"
+	var out BackupVerifyResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostChat 文本对话
+// 与语音设备使用相同的提示词、对话流水线和工具进行文本对话。关联的设备在线时进入设备当前的会话，共用对话历史；
+// 否则创建不连接设备的会话，返回的 session_id 用于继续对话。stream=true 或 Accept: text/event-stream 时以 SSE 推送，
+// 事件 segment 为回复分段，done 为完整回复（ChatResponse），error 为错误信息
+//
+// POST /v1/chat
+func (c *Client) PostChat(ctx context.Context, body *ChatRequest) (*ChatResponse, error) {
+	path := "/v1/chat"
+	var out ChatResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostConfigApply 应用配置清单
+// 将供应商、提示词模板、设备分组和当前工作流调整为清单描述的状态。清单中出现的部分（包括空列表）视为该类资源的完整期望状态，多余的同类资源会被删除；未出现的部分保持不变。dry_run=true 时只返回变更计划
+//
+// POST /v1/config/apply
+func (c *Client) PostConfigApply(ctx context.Context, params *PostConfigApplyParams, body *Manifest) (*Plan, error) {
+	path := "/v1/config/apply"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out Plan
+	if err := c.do(ctx, http.MethodPost, path, query, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostConfigApplyParams PostConfigApply 的查询参数，零值不发送
+type PostConfigApplyParams struct {
+	// 只计算变更计划，不做修改
+	DryRun *bool
+}
+
+func (p *PostConfigApplyParams) values() url.Values {
+	query := url.Values{}
+	if p.DryRun != nil {
+		query.Set("dry_run", strconv.FormatBool(*p.DryRun))
+	}
+	return query
+}
+
+// GetConfigExport 导出配置
+// 导出数据库中保存的完整服务配置，可直接用于导入
+//
+// GET /v1/config/export
+func (c *Client) GetConfigExport(ctx context.Context) error {
+	path := "/v1/config/export"
+	return c.do(ctx, http.MethodGet, path, nil, nil, nil)
+}
+
+// PostConfigImport 导入配置
+// 将导出的配置写回数据库；请求中未出现的字段保持当前值，未知字段视为错误。多数配置在重启服务后生效
+//
+// POST /v1/config/import
+func (c *Client) PostConfigImport(ctx context.Context, body map[string]interface{}) error {
+	path := "/v1/config/import"
+	return c.do(ctx, http.MethodPost, path, nil, body, nil)
+}
+
+// PostConfigProvidersConnectivity 测试提供者网络连通性
+// 按提供者的网络配置（HTTPClient.Providers 叠加请求中的 network）向 url 发送 HEAD 请求，经过配置的代理、端点改写和CA证书，返回实际请求的地址、代理、对端地址和TLS版本；收到任意HTTP响应即为成功
+//
+// POST /v1/config/providers/connectivity
+func (c *Client) PostConfigProvidersConnectivity(ctx context.Context, body *ProviderConnectivityRequest) (*CheckResult, error) {
+	path := "/v1/config/providers/connectivity"
+	var out CheckResult
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostConfigProvidersTest 测试供应商配置
+// 使用给定配置对能力执行一次最小调用（LLM 发送 ping，TTS 合成短文本，其它类型仅校验执行器可创建），测试失败时 success 为 false
+//
+// POST /v1/config/providers/test
+func (c *Client) PostConfigProvidersTest(ctx context.Context, body *ProviderTestRequest) (*ProviderTestResult, error) {
+	path := "/v1/config/providers/test"
+	var out ProviderTestResult
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetConversations 获取会话列表
+// 按设备、会话、时间范围过滤会话，支持按消息内容搜索
+//
+// GET /v1/conversations
+func (c *Client) GetConversations(ctx context.Context, params *GetConversationsParams) (*ConversationListResponse, error) {
+	path := "/v1/conversations"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out ConversationListResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetConversationsParams GetConversations 的查询参数，零值不发送
+type GetConversationsParams struct {
+	// 设备ID
+	DeviceID string
+	// 会话ID
+	SessionID string
+	// 消息内容关键字
+	Q string
+	// 开始时间 RFC3339
+	From string
+	// 结束时间 RFC3339
+	To string
+	// 页码
+	Page int64
+	// 每页数量
+	Limit int64
+}
+
+func (p *GetConversationsParams) values() url.Values {
+	query := url.Values{}
+	if p.DeviceID != "" {
+		query.Set("device_id", p.DeviceID)
+	}
+	if p.SessionID != "" {
+		query.Set("session_id", p.SessionID)
+	}
+	if p.Q != "" {
+		query.Set("q", p.Q)
+	}
+	if p.From != "" {
+		query.Set("from", p.From)
+	}
+	if p.To != "" {
+		query.Set("to", p.To)
+	}
+	if p.Page != 0 {
+		query.Set("page", strconv.FormatInt(p.Page, 10))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return query
+}
+
+// DeleteConversationsBySessionID 删除会话
+//
+// DELETE /v1/conversations/{session_id}
+func (c *Client) DeleteConversationsBySessionID(ctx context.Context, sessionID string) error {
+	path := "/v1/conversations/" + url.PathEscape(sessionID)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetConversationsBySessionID 获取会话详情
+// 获取会话内的全部消息，包括用户语句、助手回复、工具调用和耗时，以及各轮的分阶段耗时
+//
+// GET /v1/conversations/{session_id}
+func (c *Client) GetConversationsBySessionID(ctx context.Context, sessionID string) (*ConversationDetailResponse, error) {
+	path := "/v1/conversations/" + url.PathEscape(sessionID)
+	var out ConversationDetailResponse
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetConversationsBySessionIDExport 导出会话
+// 以 JSON 或 Markdown 格式下载会话记录
+//
+// GET /v1/conversations/{session_id}/export
+func (c *Client) GetConversationsBySessionIDExport(ctx context.Context, sessionID string, params *GetConversationsBySessionIDExportParams) (interface{}, error) {
+	path := "/v1/conversations/" + url.PathEscape(sessionID) + "/export"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out interface{}
+	err := c.do(ctx, http.MethodGet, path, query, nil, &out)
+	return out, err
+}
+
+// GetConversationsBySessionIDExportParams GetConversationsBySessionIDExport 的查询参数，零值不发送
+type GetConversationsBySessionIDExportParams struct {
+	// 导出格式 json/markdown
+	Format string
+}
+
+func (p *GetConversationsBySessionIDExportParams) values() url.Values {
+	query := url.Values{}
+	if p.Format != "" {
+		query.Set("format", p.Format)
+	}
+	return query
+}
+
+// PostConversationsBySessionIDMessagesByMessageIDFeedback 提交消息反馈
+// 对助手回复点赞/点踩或提交文字反馈，反馈会计入所在A/B实验的指标
+//
+// POST /v1/conversations/{session_id}/messages/{message_id}/feedback
+func (c *Client) PostConversationsBySessionIDMessagesByMessageIDFeedback(ctx context.Context, sessionID string, messageID string, body *FeedbackRequest) (*FeedbackInfo, error) {
+	path := "/v1/conversations/" + url.PathEscape(sessionID) + "/messages/" + url.PathEscape(messageID) + "/feedback"
+	var out FeedbackInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteDebugChaosRules 清空故障注入规则
+//
+// DELETE /v1/debug/chaos/rules
+func (c *Client) DeleteDebugChaosRules(ctx context.Context) (*ChaosClearResponse, error) {
+	path := "/v1/debug/chaos/rules"
+	var out ChaosClearResponse
+	if err := c.do(ctx, http.MethodDelete, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDebugChaosRules 获取故障注入规则
+// 返回仍在有效期内、未达到次数上限的规则及其已注入次数
+//
+// GET /v1/debug/chaos/rules
+func (c *Client) GetDebugChaosRules(ctx context.Context) (*ChaosRuleListResponse, error) {
+	path := "/v1/debug/chaos/rules"
+	var out ChaosRuleListResponse
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostDebugChaosRules 添加故障注入规则
+// 对匹配的能力调用、插件 gRPC 调用或提供者 HTTP 请求按概率注入延迟（delay）、错误（error）或中途中断（kill）；多条规则按添加顺序取第一条命中的规则，到期或达到次数上限后自动失效
+//
+// POST /v1/debug/chaos/rules
+func (c *Client) PostDebugChaosRules(ctx context.Context, body *ChaosRuleRequest) (*ChaosRuleInfo, error) {
+	path := "/v1/debug/chaos/rules"
+	var out ChaosRuleInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteDebugChaosRulesByID 删除故障注入规则
+//
+// DELETE /v1/debug/chaos/rules/{id}
+func (c *Client) DeleteDebugChaosRulesByID(ctx context.Context, id string) error {
+	path := "/v1/debug/chaos/rules/" + url.PathEscape(id)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetDebugProviderCalls 搜索提供者调用录制
+// 按提供者、能力、设备、调用结果、时间范围和文本搜索采样录制的能力调用，新记录在前；列表不含请求和响应
+//
+// GET /v1/debug/provider-calls
+func (c *Client) GetDebugProviderCalls(ctx context.Context, params *GetDebugProviderCallsParams) (*ProviderCallListResponse, error) {
+	path := "/v1/debug/provider-calls"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out ProviderCallListResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDebugProviderCallsParams GetDebugProviderCalls 的查询参数，零值不发送
+type GetDebugProviderCallsParams struct {
+	// 提供者ID
+	Provider string
+	// 能力ID
+	Capability string
+	// 设备ID
+	DeviceID string
+	// 是否成功
+	Success *bool
+	// 在请求、响应和错误信息中搜索的文本
+	Q string
+	// 开始时间 RFC3339
+	From string
+	// 结束时间 RFC3339
+	To string
+	// 页码
+	Page int64
+	// 每页数量
+	Limit int64
+}
+
+func (p *GetDebugProviderCallsParams) values() url.Values {
+	query := url.Values{}
+	if p.Provider != "" {
+		query.Set("provider", p.Provider)
+	}
+	if p.Capability != "" {
+		query.Set("capability", p.Capability)
+	}
+	if p.DeviceID != "" {
+		query.Set("device_id", p.DeviceID)
+	}
+	if p.Success != nil {
+		query.Set("success", strconv.FormatBool(*p.Success))
+	}
+	if p.Q != "" {
+		query.Set("q", p.Q)
+	}
+	if p.From != "" {
+		query.Set("from", p.From)
+	}
+	if p.To != "" {
+		query.Set("to", p.To)
+	}
+	if p.Page != 0 {
+		query.Set("page", strconv.FormatInt(p.Page, 10))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return query
+}
+
+// GetDebugProviderCallsByID 获取提供者调用录制详情
+// 返回录制的完整请求和响应，密钥类字段已替换为 ******，超出长度上限的部分已截断
+//
+// GET /v1/debug/provider-calls/{id}
+func (c *Client) GetDebugProviderCallsByID(ctx context.Context, id string) (*ProviderCallInfo, error) {
+	path := "/v1/debug/provider-calls/" + url.PathEscape(id)
+	var out ProviderCallInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDeviceDebugAudit 获取远程调试审计记录
+// 按设备、操作和时间范围过滤远程调试操作，包括失败和被拒绝的操作，新记录在前
+//
+// GET /v1/device-debug/audit
+func (c *Client) GetDeviceDebugAudit(ctx context.Context, params *GetDeviceDebugAuditParams) (*DeviceDebugAuditListResponse, error) {
+	path := "/v1/device-debug/audit"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out DeviceDebugAuditListResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDeviceDebugAuditParams GetDeviceDebugAudit 的查询参数，零值不发送
+type GetDeviceDebugAuditParams struct {
+	// 设备ID
+	DeviceID string
+	// 操作
+	Action string
+	// 开始时间 RFC3339
+	From string
+	// 结束时间 RFC3339
+	To string
+	// 页码
+	Page int64
+	// 每页数量
+	Limit int64
+}
+
+func (p *GetDeviceDebugAuditParams) values() url.Values {
+	query := url.Values{}
+	if p.DeviceID != "" {
+		query.Set("device_id", p.DeviceID)
+	}
+	if p.Action != "" {
+		query.Set("action", p.Action)
+	}
+	if p.From != "" {
+		query.Set("from", p.From)
+	}
+	if p.To != "" {
+		query.Set("to", p.To)
+	}
+	if p.Page != 0 {
+		query.Set("page", strconv.FormatInt(p.Page, 10))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return query
+}
+
+// GetDevices 获取设备列表
+// 获取设备列表，支持分页和过滤
+//
+// GET /v1/devices
+func (c *Client) GetDevices(ctx context.Context, params *GetDevicesParams) (*DeviceListResponse, error) {
+	path := "/v1/devices"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out DeviceListResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDevicesParams GetDevices 的查询参数，零值不发送
+type GetDevicesParams struct {
+	// 按状态过滤
+	Status string
+	// 按设备类型过滤
+	DeviceType string
+	// 搜索关键词
+	Search string
+	// 页码
+	Page int64
+	// 每页数量
+	Limit int64
+	// 排序字段
+	SortBy string
+	// 排序方向
+	SortOrder string
+	// 是否返回位置信息
+	Location *bool
+}
+
+func (p *GetDevicesParams) values() url.Values {
+	query := url.Values{}
+	if p.Status != "" {
+		query.Set("status", p.Status)
+	}
+	if p.DeviceType != "" {
+		query.Set("device_type", p.DeviceType)
+	}
+	if p.Search != "" {
+		query.Set("search", p.Search)
+	}
+	if p.Page != 0 {
+		query.Set("page", strconv.FormatInt(p.Page, 10))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	if p.SortBy != "" {
+		query.Set("sort_by", p.SortBy)
+	}
+	if p.SortOrder != "" {
+		query.Set("sort_order", p.SortOrder)
+	}
+	if p.Location != nil {
+		query.Set("location", strconv.FormatBool(*p.Location))
+	}
+	return query
+}
+
+// PostDevices 设备注册
+// 新设备注册到系统
+//
+// POST /v1/devices
+func (c *Client) PostDevices(ctx context.Context, body *DeviceRegistrationRequest) (*DeviceInfo, error) {
+	path := "/v1/devices"
+	var out DeviceInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostDevicesStatus 管理员激活/禁用设备
+// 管理员快速激活或禁用设备，通过设备MAC地址和激活状态
+//
+// POST /v1/devices/status
+func (c *Client) PostDevicesStatus(ctx context.Context, body *DeviceStatusRequest) (*DeviceStatusResponse, error) {
+	path := "/v1/devices/status"
+	var out DeviceStatusResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteDevicesByID 删除设备
+// 从系统中删除指定设备
+//
+// DELETE /v1/devices/{id}
+func (c *Client) DeleteDevicesByID(ctx context.Context, id string) error {
+	path := "/v1/devices/" + url.PathEscape(id)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetDevicesByID 获取设备详情
+// 根据ID获取设备的详细信息，设备正在播放或暂停中的媒体在 media 字段返回
+//
+// GET /v1/devices/{id}
+func (c *Client) GetDevicesByID(ctx context.Context, id string) (*DeviceInfo, error) {
+	path := "/v1/devices/" + url.PathEscape(id)
+	var out DeviceInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutDevicesByID 更新设备信息
+// 更新指定设备的信息
+//
+// PUT /v1/devices/{id}
+func (c *Client) PutDevicesByID(ctx context.Context, id string, body *DeviceUpdateRequest) (*DeviceInfo, error) {
+	path := "/v1/devices/" + url.PathEscape(id)
+	var out DeviceInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostDevicesByIDActivate 激活设备
+// 用 ACT_<设备ID> 形式的手动激活码激活已注册的设备，已被扫码配对（/v1/provisioning/claim）取代，可通过 Provisioning.LegacyActivationCode 关闭
+//
+// POST /v1/devices/{id}/activate
+func (c *Client) PostDevicesByIDActivate(ctx context.Context, id string, body *DeviceActivationRequest) (*DeviceActivationResponse, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/activate"
+	var out DeviceActivationResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteDevicesByIDData 删除设备的全部用户数据
+// 删除设备产生的录音、语音归档授权、对话记录和反馈，设备本身及绑定关系不受影响
+//
+// DELETE /v1/devices/{id}/data
+func (c *Client) DeleteDevicesByIDData(ctx context.Context, id string) (*DeviceDataErasureResponse, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/data"
+	var out DeviceDataErasureResponse
+	if err := c.do(ctx, http.MethodDelete, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDevicesByIDDebugCommands 查询设备诊断命令
+// 返回固件定义的诊断命令及其参数说明，格式由固件决定；操作记入审计日志
+//
+// GET /v1/devices/{id}/debug/commands
+func (c *Client) GetDevicesByIDDebugCommands(ctx context.Context, id string) (*DeviceDebugResult, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/debug/commands"
+	var out DeviceDebugResult
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostDevicesByIDDebugCommandsByCommand 执行设备诊断命令
+// 在设备上执行固件定义的诊断命令并返回结果；操作记入审计日志
+//
+// POST /v1/devices/{id}/debug/commands/{command}
+func (c *Client) PostDevicesByIDDebugCommandsByCommand(ctx context.Context, id string, command string, body *DeviceDebugRunRequest) (*DeviceDebugResult, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/debug/commands/" + url.PathEscape(command)
+	var out DeviceDebugResult
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutDevicesByIDDebugConfig 下发设备临时配置
+// 临时覆盖设备配置，到期或设备重启后由设备恢复原配置；操作记入审计日志
+//
+// PUT /v1/devices/{id}/debug/config
+func (c *Client) PutDevicesByIDDebugConfig(ctx context.Context, id string, body *DeviceDebugConfigRequest) (*DeviceDebugResult, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/debug/config"
+	var out DeviceDebugResult
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDevicesByIDDebugLogs 读取设备日志
+// 经设备连接读取设备最近的日志；设备需在线、在 hello 中声明 debug 特性并通过 remote_debug 功能开关允许，操作记入审计日志
+//
+// GET /v1/devices/{id}/debug/logs
+func (c *Client) GetDevicesByIDDebugLogs(ctx context.Context, id string, params *GetDevicesByIDDebugLogsParams) (*DeviceDebugResult, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/debug/logs"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out DeviceDebugResult
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDevicesByIDDebugLogsParams GetDevicesByIDDebugLogs 的查询参数，零值不发送
+type GetDevicesByIDDebugLogsParams struct {
+	// 读取的行数，缺省或超过上限时使用 DeviceDebug.MaxLogLines
+	Lines int64
+	// 最低日志级别
+	Level string
+}
+
+func (p *GetDevicesByIDDebugLogsParams) values() url.Values {
+	query := url.Values{}
+	if p.Lines != 0 {
+		query.Set("lines", strconv.FormatInt(p.Lines, 10))
+	}
+	if p.Level != "" {
+		query.Set("level", p.Level)
+	}
+	return query
+}
+
+// GetDevicesByIDKeys 获取设备密钥
+// 列出设备的全部密钥（只含公钥），新密钥在前；设备有未吊销的密钥时，连接 WebSocket 必须携带用其中之一签名的 JWT
+//
+// GET /v1/devices/{id}/keys
+func (c *Client) GetDevicesByIDKeys(ctx context.Context, id string) ([]DeviceKeyInfo, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/keys"
+	var out []DeviceKeyInfo
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// PostDevicesByIDKeys 签发设备密钥
+// 为设备签发新的 P-256 密钥，私钥只返回一次；设备此后改用 JWT 认证，原静态令牌失效。revoke_previous 为 true 时同时吊销其他密钥，否则旧密钥在吊销前仍可使用，便于设备切换
+//
+// POST /v1/devices/{id}/keys
+func (c *Client) PostDevicesByIDKeys(ctx context.Context, id string, body *DeviceKeyIssueRequest) (*IssuedDeviceKeyInfo, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/keys"
+	var out IssuedDeviceKeyInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteDevicesByIDKeysByKeyID 吊销设备密钥
+// 吊销后用该密钥签名的 JWT 在 WebSocket 握手时被拒绝；已建立的连接不受影响
+//
+// DELETE /v1/devices/{id}/keys/{key_id}
+func (c *Client) DeleteDevicesByIDKeysByKeyID(ctx context.Context, id string, keyID string) error {
+	path := "/v1/devices/" + url.PathEscape(id) + "/keys/" + url.PathEscape(keyID)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// DeleteDevicesByIDLanguages 恢复设备的默认允许语言
+// 删除设备单独设置的允许语言，之后使用 Language.Allowed，设备重新连接后生效
+//
+// DELETE /v1/devices/{id}/languages
+func (c *Client) DeleteDevicesByIDLanguages(ctx context.Context, id string) (*DeviceLanguagesInfo, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/languages"
+	var out DeviceLanguagesInfo
+	if err := c.do(ctx, http.MethodDelete, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDevicesByIDLanguages 获取设备允许的语言
+// 未单独设置的设备返回配置的默认值（Language.Allowed）
+//
+// GET /v1/devices/{id}/languages
+func (c *Client) GetDevicesByIDLanguages(ctx context.Context, id string) (*DeviceLanguagesInfo, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/languages"
+	var out DeviceLanguagesInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutDevicesByIDLanguages 设置设备允许的语言
+// 设备每句话在允许的语言中识别语言，第一个为主要语言（使用设备默认音色），其它语言使用 Language.Voices 配置的音色。设备重新连接后生效
+//
+// PUT /v1/devices/{id}/languages
+func (c *Client) PutDevicesByIDLanguages(ctx context.Context, id string, body *DeviceLanguagesRequest) (*DeviceLanguagesInfo, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/languages"
+	var out DeviceLanguagesInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDevicesByIDMedia 获取设备播放状态
+//
+// GET /v1/devices/{id}/media
+func (c *Client) GetDevicesByIDMedia(ctx context.Context, id string) (*MediaState, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/media"
+	var out MediaState
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutDevicesByIDMedia 点播或控制设备播放
+// play 检索后在设备上播放，其余操作控制设备当前的播放；设备需在线，播放和暂停以外的操作需要设备正在播放
+//
+// PUT /v1/devices/{id}/media
+func (c *Client) PutDevicesByIDMedia(ctx context.Context, id string, body *MediaControlRequest) (*MediaState, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/media"
+	var out MediaState
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDevicesByIDMembers 获取设备上的成员
+// 返回设备绑定的全部用户及其资料，所有者在前
+//
+// GET /v1/devices/{id}/members
+func (c *Client) GetDevicesByIDMembers(ctx context.Context, id string) ([]DeviceMemberInfo, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/members"
+	var out []DeviceMemberInfo
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// GetDevicesByIDRecordingConsent 获取设备语音归档授权
+// 未设置过授权的设备视为未授权，不会归档语音
+//
+// GET /v1/devices/{id}/recording-consent
+func (c *Client) GetDevicesByIDRecordingConsent(ctx context.Context, id string) (*RecordingConsentInfo, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/recording-consent"
+	var out RecordingConsentInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutDevicesByIDRecordingConsent 设置设备语音归档授权
+// 授权后该设备的用户语音会加密归档；撤销授权时可同时删除已归档的语音
+//
+// PUT /v1/devices/{id}/recording-consent
+func (c *Client) PutDevicesByIDRecordingConsent(ctx context.Context, id string, body *RecordingConsentRequest) (*RecordingConsentInfo, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/recording-consent"
+	var out RecordingConsentInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteDevicesByIDSpeechProfile 取消设备的播报文本方案分配
+// 取消后设备使用 default 方案，重新连接后生效
+//
+// DELETE /v1/devices/{id}/speech-profile
+func (c *Client) DeleteDevicesByIDSpeechProfile(ctx context.Context, id string) error {
+	path := "/v1/devices/" + url.PathEscape(id) + "/speech-profile"
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetDevicesByIDSpeechProfile 获取设备使用的播报文本方案
+// 未分配方案的设备使用 default 方案
+//
+// GET /v1/devices/{id}/speech-profile
+func (c *Client) GetDevicesByIDSpeechProfile(ctx context.Context, id string) (*SpeechTextAssignmentInfo, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/speech-profile"
+	var out SpeechTextAssignmentInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutDevicesByIDSpeechProfile 为设备分配播报文本方案
+// 设备重新连接后生效
+//
+// PUT /v1/devices/{id}/speech-profile
+func (c *Client) PutDevicesByIDSpeechProfile(ctx context.Context, id string, body *SpeechTextAssignRequest) (*SpeechTextAssignmentInfo, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/speech-profile"
+	var out SpeechTextAssignmentInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteDevicesByIDTranslation 退出设备翻译模式
+//
+// DELETE /v1/devices/{id}/translation
+func (c *Client) DeleteDevicesByIDTranslation(ctx context.Context, id string) (*TranslationSessionInfo, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/translation"
+	var out TranslationSessionInfo
+	if err := c.do(ctx, http.MethodDelete, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDevicesByIDTranslation 获取设备翻译模式状态
+//
+// GET /v1/devices/{id}/translation
+func (c *Client) GetDevicesByIDTranslation(ctx context.Context, id string) (*TranslationSessionInfo, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/translation"
+	var out TranslationSessionInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutDevicesByIDTranslation 开启或切换设备翻译模式
+// 设备需在线；之后设备的语音翻译成目标语言并用该语言的音色播报，断开连接时自动退出
+//
+// PUT /v1/devices/{id}/translation
+func (c *Client) PutDevicesByIDTranslation(ctx context.Context, id string, body *TranslationStartRequest) (*TranslationSessionInfo, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/translation"
+	var out TranslationSessionInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDevicesByIDUsage 查询设备的Token用量
+// 返回设备指定日期的Token用量、LLM调用次数、因超出预算被拒绝的次数以及适用的预算
+//
+// GET /v1/devices/{id}/usage
+func (c *Client) GetDevicesByIDUsage(ctx context.Context, id string, params *GetDevicesByIDUsageParams) (*DeviceTokenUsageInfo, error) {
+	path := "/v1/devices/" + url.PathEscape(id) + "/usage"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out DeviceTokenUsageInfo
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDevicesByIDUsageParams GetDevicesByIDUsage 的查询参数，零值不发送
+type GetDevicesByIDUsageParams struct {
+	// 日期 YYYY-MM-DD，默认今天
+	Day string
+}
+
+func (p *GetDevicesByIDUsageParams) values() url.Values {
+	query := url.Values{}
+	if p.Day != "" {
+		query.Set("day", p.Day)
+	}
+	return query
+}
+
+// GetEvaluationsCompare 对比评测报告
+// 对比同一测试集的 2-5 次评测，返回各自的汇总指标和逐用例结果
+//
+// GET /v1/evaluations/compare
+func (c *Client) GetEvaluationsCompare(ctx context.Context, params *GetEvaluationsCompareParams) (*EvalCompareResponse, error) {
+	path := "/v1/evaluations/compare"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out EvalCompareResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetEvaluationsCompareParams GetEvaluationsCompare 的查询参数，零值不发送
+type GetEvaluationsCompareParams struct {
+	// 逗号分隔的运行ID
+	RunIds string
+}
+
+func (p *GetEvaluationsCompareParams) values() url.Values {
+	query := url.Values{}
+	if p.RunIds != "" {
+		query.Set("run_ids", p.RunIds)
+	}
+	return query
+}
+
+// GetEvaluationsRuns 获取评测运行列表
+//
+// GET /v1/evaluations/runs
+func (c *Client) GetEvaluationsRuns(ctx context.Context, params *GetEvaluationsRunsParams) (*EvalRunListResponse, error) {
+	path := "/v1/evaluations/runs"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out EvalRunListResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetEvaluationsRunsParams GetEvaluationsRuns 的查询参数，零值不发送
+type GetEvaluationsRunsParams struct {
+	// 测试集ID
+	SuiteID string
+	// 页码
+	Page int64
+	// 每页数量
+	Limit int64
+}
+
+func (p *GetEvaluationsRunsParams) values() url.Values {
+	query := url.Values{}
+	if p.SuiteID != "" {
+		query.Set("suite_id", p.SuiteID)
+	}
+	if p.Page != 0 {
+		query.Set("page", strconv.FormatInt(p.Page, 10))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return query
+}
+
+// PostEvaluationsRuns 发起评测
+// 在后台对所选能力执行测试集，可通过运行ID查询进度和报告
+//
+// POST /v1/evaluations/runs
+func (c *Client) PostEvaluationsRuns(ctx context.Context, body *EvalRunRequest) (*EvalRunInfo, error) {
+	path := "/v1/evaluations/runs"
+	var out EvalRunInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetEvaluationsRunsByID 获取评测报告
+// 返回各能力的汇总指标和用例明细
+//
+// GET /v1/evaluations/runs/{id}
+func (c *Client) GetEvaluationsRunsByID(ctx context.Context, id string) (*EvalRunInfo, error) {
+	path := "/v1/evaluations/runs/" + url.PathEscape(id)
+	var out EvalRunInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetEvaluationsSuites 获取评测测试集列表
+//
+// GET /v1/evaluations/suites
+func (c *Client) GetEvaluationsSuites(ctx context.Context, params *GetEvaluationsSuitesParams) (*EvalSuiteListResponse, error) {
+	path := "/v1/evaluations/suites"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out EvalSuiteListResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetEvaluationsSuitesParams GetEvaluationsSuites 的查询参数，零值不发送
+type GetEvaluationsSuitesParams struct {
+	// 页码
+	Page int64
+	// 每页数量
+	Limit int64
+}
+
+func (p *GetEvaluationsSuitesParams) values() url.Values {
+	query := url.Values{}
+	if p.Page != 0 {
+		query.Set("page", strconv.FormatInt(p.Page, 10))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return query
+}
+
+// PostEvaluationsSuites 上传评测测试集
+// llm 用例需要 input，可选 expected；asr 用例需要 base64 音频和参考文本；tts 用例需要 input
+//
+// POST /v1/evaluations/suites
+func (c *Client) PostEvaluationsSuites(ctx context.Context, body *EvalSuiteCreateRequest) (*EvalSuiteInfo, error) {
+	path := "/v1/evaluations/suites"
+	var out EvalSuiteInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteEvaluationsSuitesByID 删除评测测试集
+// 已有的评测报告保留
+//
+// DELETE /v1/evaluations/suites/{id}
+func (c *Client) DeleteEvaluationsSuitesByID(ctx context.Context, id string) error {
+	path := "/v1/evaluations/suites/" + url.PathEscape(id)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetEvaluationsSuitesByID 获取评测测试集详情
+//
+// GET /v1/evaluations/suites/{id}
+func (c *Client) GetEvaluationsSuitesByID(ctx context.Context, id string) (*EvalSuiteInfo, error) {
+	path := "/v1/evaluations/suites/" + url.PathEscape(id)
+	var out EvalSuiteInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetExecutionsByID Get execution
+// Returns an execution with per-node results, timings and logs
+//
+// GET /v1/executions/{id}
+func (c *Client) GetExecutionsByID(ctx context.Context, id string) (*Execution, error) {
+	path := "/v1/executions/" + url.PathEscape(id)
+	var out Execution
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetExecutionsByIDNodesByNodeIDLogs Get node logs
+// Returns the engine and plugin logs recorded for one node of an execution
+//
+// GET /v1/executions/{id}/nodes/{nodeId}/logs
+func (c *Client) GetExecutionsByIDNodesByNodeIDLogs(ctx context.Context, id string, nodeID string) (*NodeLogsResponse, error) {
+	path := "/v1/executions/" + url.PathEscape(id) + "/nodes/" + url.PathEscape(nodeID) + "/logs"
+	var out NodeLogsResponse
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetExperiments 获取A/B实验列表
+//
+// GET /v1/experiments
+func (c *Client) GetExperiments(ctx context.Context, params *GetExperimentsParams) (*ExperimentListResponse, error) {
+	path := "/v1/experiments"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out ExperimentListResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetExperimentsParams GetExperiments 的查询参数，零值不发送
+type GetExperimentsParams struct {
+	// 状态
+	Status string
+	// 页码
+	Page int64
+	// 每页数量
+	Limit int64
+}
+
+func (p *GetExperimentsParams) values() url.Values {
+	query := url.Values{}
+	if p.Status != "" {
+		query.Set("status", p.Status)
+	}
+	if p.Page != 0 {
+		query.Set("page", strconv.FormatInt(p.Page, 10))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return query
+}
+
+// PostExperiments 创建A/B实验
+// 创建草稿状态的实验，每个分组可覆盖提示词模板和LLM
+//
+// POST /v1/experiments
+func (c *Client) PostExperiments(ctx context.Context, body *ExperimentCreateRequest) (*ExperimentInfo, error) {
+	path := "/v1/experiments"
+	var out ExperimentInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteExperimentsByID 删除A/B实验
+// 删除实验及其结果，运行中的实验需先停止
+//
+// DELETE /v1/experiments/{id}
+func (c *Client) DeleteExperimentsByID(ctx context.Context, id string) error {
+	path := "/v1/experiments/" + url.PathEscape(id)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetExperimentsByID 获取A/B实验详情
+//
+// GET /v1/experiments/{id}
+func (c *Client) GetExperimentsByID(ctx context.Context, id string) (*ExperimentInfo, error) {
+	path := "/v1/experiments/" + url.PathEscape(id)
+	var out ExperimentInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetExperimentsByIDResults 获取A/B实验结果
+// 按分组汇总延迟、token消耗、打断率和用户反馈
+//
+// GET /v1/experiments/{id}/results
+func (c *Client) GetExperimentsByIDResults(ctx context.Context, id string) (*ExperimentResultsResponse, error) {
+	path := "/v1/experiments/" + url.PathEscape(id) + "/results"
+	var out ExperimentResultsResponse
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostExperimentsByIDStart 开始A/B实验
+//
+// POST /v1/experiments/{id}/start
+func (c *Client) PostExperimentsByIDStart(ctx context.Context, id string) (*ExperimentInfo, error) {
+	path := "/v1/experiments/" + url.PathEscape(id) + "/start"
+	var out ExperimentInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostExperimentsByIDStop 停止A/B实验
+//
+// POST /v1/experiments/{id}/stop
+func (c *Client) PostExperimentsByIDStop(ctx context.Context, id string) (*ExperimentInfo, error) {
+	path := "/v1/experiments/" + url.PathEscape(id) + "/stop"
+	var out ExperimentInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetFeedback 获取反馈列表
+//
+// GET /v1/feedback
+func (c *Client) GetFeedback(ctx context.Context, params *GetFeedbackParams) (*FeedbackListResponse, error) {
+	path := "/v1/feedback"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out FeedbackListResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetFeedbackParams GetFeedback 的查询参数，零值不发送
+type GetFeedbackParams struct {
+	// 设备ID
+	DeviceID string
+	// 会话ID
+	SessionID string
+	// 评价
+	Rating string
+	// 来源
+	Source string
+	// 开始时间 RFC3339
+	From string
+	// 结束时间 RFC3339
+	To string
+	// 页码
+	Page int64
+	// 每页数量
+	Limit int64
+}
+
+func (p *GetFeedbackParams) values() url.Values {
+	query := url.Values{}
+	if p.DeviceID != "" {
+		query.Set("device_id", p.DeviceID)
+	}
+	if p.SessionID != "" {
+		query.Set("session_id", p.SessionID)
+	}
+	if p.Rating != "" {
+		query.Set("rating", p.Rating)
+	}
+	if p.Source != "" {
+		query.Set("source", p.Source)
+	}
+	if p.From != "" {
+		query.Set("from", p.From)
+	}
+	if p.To != "" {
+		query.Set("to", p.To)
+	}
+	if p.Page != 0 {
+		query.Set("page", strconv.FormatInt(p.Page, 10))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return query
+}
+
+// GetFeedbackStats 获取反馈质量统计
+// 汇总点赞/点踩、满意度、来源分布和每日趋势，默认统计最近30天
+//
+// GET /v1/feedback/stats
+func (c *Client) GetFeedbackStats(ctx context.Context, params *GetFeedbackStatsParams) (*FeedbackStatsResponse, error) {
+	path := "/v1/feedback/stats"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out FeedbackStatsResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetFeedbackStatsParams GetFeedbackStats 的查询参数，零值不发送
+type GetFeedbackStatsParams struct {
+	// 设备ID
+	DeviceID string
+	// 来源
+	Source string
+	// 开始时间 RFC3339
+	From string
+	// 结束时间 RFC3339
+	To string
+}
+
+func (p *GetFeedbackStatsParams) values() url.Values {
+	query := url.Values{}
+	if p.DeviceID != "" {
+		query.Set("device_id", p.DeviceID)
+	}
+	if p.Source != "" {
+		query.Set("source", p.Source)
+	}
+	if p.From != "" {
+		query.Set("from", p.From)
+	}
+	if p.To != "" {
+		query.Set("to", p.To)
+	}
+	return query
+}
+
+// GetFlags 获取功能开关列表
+// 列出数据库中的开关和未修改过的内置开关（streaming_tts、new_pipeline、binary_protocol、offline_mode）
+//
+// GET /v1/flags
+func (c *Client) GetFlags(ctx context.Context) (*FlagListResponse, error) {
+	path := "/v1/flags"
+	var out FlagListResponse
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteFlagsByKey 删除功能开关
+// 删除数据库中的开关；内置开关恢复默认值并返回，自定义开关删除后总是关闭
+//
+// DELETE /v1/flags/{key}
+func (c *Client) DeleteFlagsByKey(ctx context.Context, key string) (*FlagInfo, error) {
+	path := "/v1/flags/" + url.PathEscape(key)
+	var out FlagInfo
+	if err := c.do(ctx, http.MethodDelete, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetFlagsByKey 获取功能开关
+//
+// GET /v1/flags/{key}
+func (c *Client) GetFlagsByKey(ctx context.Context, key string) (*FlagInfo, error) {
+	path := "/v1/flags/" + url.PathEscape(key)
+	var out FlagInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutFlagsByKey 创建或更新功能开关
+// 规则按顺序匹配，第一条匹配的规则决定取值，没有匹配的规则时使用 enabled；每条规则需列出租户或设备（设备分组），两者都列出时需同时满足。修改立即在本实例生效，其它实例在 Flags.RefreshInterval 内生效
+//
+// PUT /v1/flags/{key}
+func (c *Client) PutFlagsByKey(ctx context.Context, key string, body *FlagSetRequest) (*FlagInfo, error) {
+	path := "/v1/flags/" + url.PathEscape(key)
+	var out FlagInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetFlagsByKeyEvaluate 查询功能开关对租户和设备的取值
+// 返回取值及其来源：命中的规则、开关的取值、内置默认值，或未定义（总是关闭）
+//
+// GET /v1/flags/{key}/evaluate
+func (c *Client) GetFlagsByKeyEvaluate(ctx context.Context, key string, params *GetFlagsByKeyEvaluateParams) (*FlagDecision, error) {
+	path := "/v1/flags/" + url.PathEscape(key) + "/evaluate"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out FlagDecision
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetFlagsByKeyEvaluateParams GetFlagsByKeyEvaluate 的查询参数，零值不发送
+type GetFlagsByKeyEvaluateParams struct {
+	// 租户ID，缺省为默认租户
+	TenantID string
+	// 设备ID
+	DeviceID string
+}
+
+func (p *GetFlagsByKeyEvaluateParams) values() url.Values {
+	query := url.Values{}
+	if p.TenantID != "" {
+		query.Set("tenant_id", p.TenantID)
+	}
+	if p.DeviceID != "" {
+		query.Set("device_id", p.DeviceID)
+	}
+	return query
+}
+
+// GetJobs 获取后台任务列表
+// 按类型、状态和去重键查询后台任务，最新的在前；status 为 dead 时即死信队列
+//
+// GET /v1/jobs
+func (c *Client) GetJobs(ctx context.Context, params *GetJobsParams) (*JobListResponse, error) {
+	path := "/v1/jobs"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out JobListResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetJobsParams GetJobs 的查询参数，零值不发送
+type GetJobsParams struct {
+	// 任务类型，如 knowledge.ingest
+	Type string
+	// 状态
+	Status string
+	// 去重键
+	Key string
+	// 页码
+	Page int64
+	// 每页数量
+	Limit int64
+}
+
+func (p *GetJobsParams) values() url.Values {
+	query := url.Values{}
+	if p.Type != "" {
+		query.Set("type", p.Type)
+	}
+	if p.Status != "" {
+		query.Set("status", p.Status)
+	}
+	if p.Key != "" {
+		query.Set("key", p.Key)
+	}
+	if p.Page != 0 {
+		query.Set("page", strconv.FormatInt(p.Page, 10))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return query
+}
+
+// GetJobsStats 获取后台任务统计
+// 返回各任务类型在数据库中各状态的任务数，以及本实例的工作协程数、执行中任务数和失败次数
+//
+// GET /v1/jobs/stats
+func (c *Client) GetJobsStats(ctx context.Context) (*JobStatsResponse, error) {
+	path := "/v1/jobs/stats"
+	var out JobStatsResponse
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetJobsByID 获取后台任务详情
+//
+// GET /v1/jobs/{id}
+func (c *Client) GetJobsByID(ctx context.Context, id string) (*JobInfo, error) {
+	path := "/v1/jobs/" + url.PathEscape(id)
+	var out JobInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostJobsByIDCancel 取消后台任务
+// 取消等待中或执行中的任务；执行中的任务在下次续约时中断
+//
+// POST /v1/jobs/{id}/cancel
+func (c *Client) PostJobsByIDCancel(ctx context.Context, id string) (*JobInfo, error) {
+	path := "/v1/jobs/" + url.PathEscape(id) + "/cancel"
+	var out JobInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostJobsByIDRetry 重新执行后台任务
+// 将死信或已取消的任务放回队列并清零执行次数；同一去重键已有未结束的任务时拒绝
+//
+// POST /v1/jobs/{id}/retry
+func (c *Client) PostJobsByIDRetry(ctx context.Context, id string) (*JobInfo, error) {
+	path := "/v1/jobs/" + url.PathEscape(id) + "/retry"
+	var out JobInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetKnowledge 获取知识库列表
+//
+// GET /v1/knowledge
+func (c *Client) GetKnowledge(ctx context.Context) ([]KnowledgeBaseInfo, error) {
+	path := "/v1/knowledge"
+	var out []KnowledgeBaseInfo
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// PostKnowledge 创建知识库
+//
+// POST /v1/knowledge
+func (c *Client) PostKnowledge(ctx context.Context, body *KnowledgeBaseCreateRequest) (*KnowledgeBaseInfo, error) {
+	path := "/v1/knowledge"
+	var out KnowledgeBaseInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetKnowledgeBindings 获取知识库档案绑定
+// 设备按配置的 knowledge.device_profiles 归入档案，未配置的设备使用 default 档案
+//
+// GET /v1/knowledge/bindings
+func (c *Client) GetKnowledgeBindings(ctx context.Context) ([]KnowledgeBindingInfo, error) {
+	path := "/v1/knowledge/bindings"
+	var out []KnowledgeBindingInfo
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// PutKnowledgeBindingsByProfile 设置知识库档案绑定
+// 设置档案下设备对话时检索的知识库，立即生效；knowledge_base_ids 为空时解除绑定
+//
+// PUT /v1/knowledge/bindings/{profile}
+func (c *Client) PutKnowledgeBindingsByProfile(ctx context.Context, profile string, body *KnowledgeBindingRequest) (*KnowledgeBindingInfo, error) {
+	path := "/v1/knowledge/bindings/" + url.PathEscape(profile)
+	var out KnowledgeBindingInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostKnowledgeSearch 检索知识库
+// 在指定知识库或设备所属档案绑定的知识库中检索，返回命中的分块和附加给 LLM 的参考资料，用于调试检索效果
+//
+// POST /v1/knowledge/search
+func (c *Client) PostKnowledgeSearch(ctx context.Context, body *KnowledgeSearchRequest) (*KnowledgeSearchResponse, error) {
+	path := "/v1/knowledge/search"
+	var out KnowledgeSearchResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteKnowledgeByID 删除知识库
+// 删除知识库及其全部文档和分块，进行中的导入被取消，档案绑定一并解除
+//
+// DELETE /v1/knowledge/{id}
+func (c *Client) DeleteKnowledgeByID(ctx context.Context, id string) error {
+	path := "/v1/knowledge/" + url.PathEscape(id)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetKnowledgeByID 获取知识库详情
+//
+// GET /v1/knowledge/{id}
+func (c *Client) GetKnowledgeByID(ctx context.Context, id string) (*KnowledgeBaseInfo, error) {
+	path := "/v1/knowledge/" + url.PathEscape(id)
+	var out KnowledgeBaseInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutKnowledgeByID 更新知识库
+//
+// PUT /v1/knowledge/{id}
+func (c *Client) PutKnowledgeByID(ctx context.Context, id string, body *KnowledgeBaseUpdateRequest) (*KnowledgeBaseInfo, error) {
+	path := "/v1/knowledge/" + url.PathEscape(id)
+	var out KnowledgeBaseInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetKnowledgeByIDDocuments 获取知识库文档列表
+// 返回文档及其导入状态和进度，新上传的在前
+//
+// GET /v1/knowledge/{id}/documents
+func (c *Client) GetKnowledgeByIDDocuments(ctx context.Context, id string) ([]KnowledgeDocumentInfo, error) {
+	path := "/v1/knowledge/" + url.PathEscape(id) + "/documents"
+	var out []KnowledgeDocumentInfo
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// PostKnowledgeByIDDocumentsURL 导入网页到知识库
+// 网页在导入任务中下载并提取正文，按响应的内容类型识别 PDF、Markdown 或文本
+//
+// POST /v1/knowledge/{id}/documents/url
+func (c *Client) PostKnowledgeByIDDocumentsURL(ctx context.Context, id string, body *KnowledgeURLRequest) (*KnowledgeDocumentInfo, error) {
+	path := "/v1/knowledge/" + url.PathEscape(id) + "/documents/url"
+	var out KnowledgeDocumentInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteKnowledgeByIDDocumentsByDocID 删除知识库文档
+//
+// DELETE /v1/knowledge/{id}/documents/{doc_id}
+func (c *Client) DeleteKnowledgeByIDDocumentsByDocID(ctx context.Context, id string, docID string) error {
+	path := "/v1/knowledge/" + url.PathEscape(id) + "/documents/" + url.PathEscape(docID)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetKnowledgeByIDDocumentsByDocID 获取知识库文档
+//
+// GET /v1/knowledge/{id}/documents/{doc_id}
+func (c *Client) GetKnowledgeByIDDocumentsByDocID(ctx context.Context, id string, docID string) (*KnowledgeDocumentInfo, error) {
+	path := "/v1/knowledge/" + url.PathEscape(id) + "/documents/" + url.PathEscape(docID)
+	var out KnowledgeDocumentInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostKnowledgeByIDDocumentsByDocIDReindex 重新导入知识库文档
+// 导入失败或更换向量化能力后重新导入，网页会重新下载；导入进行中时拒绝
+//
+// POST /v1/knowledge/{id}/documents/{doc_id}/reindex
+func (c *Client) PostKnowledgeByIDDocumentsByDocIDReindex(ctx context.Context, id string, docID string) (*KnowledgeDocumentInfo, error) {
+	path := "/v1/knowledge/" + url.PathEscape(id) + "/documents/" + url.PathEscape(docID) + "/reindex"
+	var out KnowledgeDocumentInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetLanguages 获取支持的语言
+// 返回支持自动识别的语言、各语言的播报音色和默认允许的语言
+//
+// GET /v1/languages
+func (c *Client) GetLanguages(ctx context.Context) (*LanguageListResponse, error) {
+	path := "/v1/languages"
+	var out LanguageListResponse
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetLogs 查询服务日志
+// 按最低级别、标签、时间范围和文本查询服务日志索引，新日志在前。
+// 日志在后台批量写入索引，最近约一秒内的日志可能还查不到；索引保留天数见 Log.Index.RetentionDays
+//
+// GET /v1/logs
+func (c *Client) GetLogs(ctx context.Context, params *GetLogsParams) (*LogListResponse, error) {
+	path := "/v1/logs"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out LogListResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetLogsParams GetLogs 的查询参数，零值不发送
+type GetLogsParams struct {
+	// 最低级别
+	Level string
+	// 日志标签
+	Tag string
+	// 在日志正文和属性中搜索的文本
+	Q string
+	// 开始时间 RFC3339
+	From string
+	// 结束时间 RFC3339
+	To string
+	// 页码
+	Page int64
+	// 每页数量
+	Limit int64
+}
+
+func (p *GetLogsParams) values() url.Values {
+	query := url.Values{}
+	if p.Level != "" {
+		query.Set("level", p.Level)
+	}
+	if p.Tag != "" {
+		query.Set("tag", p.Tag)
+	}
+	if p.Q != "" {
+		query.Set("q", p.Q)
+	}
+	if p.From != "" {
+		query.Set("from", p.From)
+	}
+	if p.To != "" {
+		query.Set("to", p.To)
+	}
+	if p.Page != 0 {
+		query.Set("page", strconv.FormatInt(p.Page, 10))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return query
+}
+
+// GetMediaSearch 检索曲目和电台
+// 按来源检索本地曲库、配置的网络电台和媒体能力，不指定来源时依次检索
+//
+// GET /v1/media/search
+func (c *Client) GetMediaSearch(ctx context.Context, params *GetMediaSearchParams) ([]MediaTrack, error) {
+	path := "/v1/media/search"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out []MediaTrack
+	err := c.do(ctx, http.MethodGet, path, query, nil, &out)
+	return out, err
+}
+
+// GetMediaSearchParams GetMediaSearch 的查询参数，零值不发送
+type GetMediaSearchParams struct {
+	// 检索内容，为空时随机返回曲库中的曲目
+	Q string
+	// 来源 library/radio/provider
+	Source string
+}
+
+func (p *GetMediaSearchParams) values() url.Values {
+	query := url.Values{}
+	if p.Q != "" {
+		query.Set("q", p.Q)
+	}
+	if p.Source != "" {
+		query.Set("source", p.Source)
+	}
+	return query
+}
+
+// GetMetricsLatency 获取对话耗时统计
+// 按阶段（采集、ASR、LLM首字、LLM完成、TTS首包、开始播放、端到端）汇总每轮对话耗时的平均值和 P50/P90/P95/P99，并给出每日端到端耗时趋势，默认统计最近30天
+//
+// GET /v1/metrics/latency
+func (c *Client) GetMetricsLatency(ctx context.Context, params *GetMetricsLatencyParams) (*LatencyStatsResponse, error) {
+	path := "/v1/metrics/latency"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out LatencyStatsResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMetricsLatencyParams GetMetricsLatency 的查询参数，零值不发送
+type GetMetricsLatencyParams struct {
+	// 设备ID
+	DeviceID string
+	// 开始时间 RFC3339
+	From string
+	// 结束时间 RFC3339
+	To string
+}
+
+func (p *GetMetricsLatencyParams) values() url.Values {
+	query := url.Values{}
+	if p.DeviceID != "" {
+		query.Set("device_id", p.DeviceID)
+	}
+	if p.From != "" {
+		query.Set("from", p.From)
+	}
+	if p.To != "" {
+		query.Set("to", p.To)
+	}
+	return query
+}
+
+// GetMonitorSessions 列出在线会话
+// 返回在线设备会话的对话状态、最近的对话片段和当前使用的模型与音色，最近连接的在前
+//
+// GET /v1/monitor/sessions
+func (c *Client) GetMonitorSessions(ctx context.Context, params *GetMonitorSessionsParams) ([]MonitorSession, error) {
+	path := "/v1/monitor/sessions"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out []MonitorSession
+	err := c.do(ctx, http.MethodGet, path, query, nil, &out)
+	return out, err
+}
+
+// GetMonitorSessionsParams GetMonitorSessions 的查询参数，零值不发送
+type GetMonitorSessionsParams struct {
+	// 租户ID
+	TenantID string
+	// 设备ID
+	DeviceID string
+	// 对话状态
+	State string
+	// 只返回已标记旁听的会话
+	Flagged *bool
+}
+
+func (p *GetMonitorSessionsParams) values() url.Values {
+	query := url.Values{}
+	if p.TenantID != "" {
+		query.Set("tenant_id", p.TenantID)
+	}
+	if p.DeviceID != "" {
+		query.Set("device_id", p.DeviceID)
+	}
+	if p.State != "" {
+		query.Set("state", p.State)
+	}
+	if p.Flagged != nil {
+		query.Set("flagged", strconv.FormatBool(*p.Flagged))
+	}
+	return query
+}
+
+// DeleteMonitorSessionsByID 终止在线会话
+// 断开会话的设备连接，正在旁听的事件流随之结束
+//
+// DELETE /v1/monitor/sessions/{id}
+func (c *Client) DeleteMonitorSessionsByID(ctx context.Context, id string) error {
+	path := "/v1/monitor/sessions/" + url.PathEscape(id)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetMonitorSessionsByID 获取在线会话状态
+//
+// GET /v1/monitor/sessions/{id}
+func (c *Client) GetMonitorSessionsByID(ctx context.Context, id string) (*MonitorSession, error) {
+	path := "/v1/monitor/sessions/" + url.PathEscape(id)
+	var out MonitorSession
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMonitorSessionsByIDEvents 旁听会话
+// 以SSE推送已标记旁听的会话的事件，事件名为 state（对话状态变化）、snippet（新的对话片段）和 closed（会话断开，之后事件流结束）；连接时先推送一次 state 作为当前状态
+//
+// GET /v1/monitor/sessions/{id}/events
+func (c *Client) GetMonitorSessionsByIDEvents(ctx context.Context, id string) (*MonitorEvent, error) {
+	path := "/v1/monitor/sessions/" + url.PathEscape(id) + "/events"
+	var out MonitorEvent
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteMonitorSessionsByIDFlag 取消会话旁听
+// 取消标记后正在旁听的事件流随之结束
+//
+// DELETE /v1/monitor/sessions/{id}/flag
+func (c *Client) DeleteMonitorSessionsByIDFlag(ctx context.Context, id string) (*MonitorSession, error) {
+	path := "/v1/monitor/sessions/" + url.PathEscape(id) + "/flag"
+	var out MonitorSession
+	if err := c.do(ctx, http.MethodDelete, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutMonitorSessionsByIDFlag 标记会话旁听
+// 标记后可通过事件流实时旁听会话的状态变化和对话内容；已标记时更新备注，会话断开后标记自动清除
+//
+// PUT /v1/monitor/sessions/{id}/flag
+func (c *Client) PutMonitorSessionsByIDFlag(ctx context.Context, id string, body *MonitorFlagRequest) (*MonitorSession, error) {
+	path := "/v1/monitor/sessions/" + url.PathEscape(id) + "/flag"
+	var out MonitorSession
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetNotificationsChannels 获取通知渠道列表
+//
+// GET /v1/notifications/channels
+func (c *Client) GetNotificationsChannels(ctx context.Context) ([]NotificationChannelInfo, error) {
+	path := "/v1/notifications/channels"
+	var out []NotificationChannelInfo
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// PostNotificationsChannels 创建通知渠道
+// 创建邮件、Webhook、钉钉、飞书或 Telegram 通知渠道，可订阅插件异常、供应商不可用和配额用尽告警
+//
+// POST /v1/notifications/channels
+func (c *Client) PostNotificationsChannels(ctx context.Context, body *NotificationChannelCreateRequest) (*NotificationChannelInfo, error) {
+	path := "/v1/notifications/channels"
+	var out NotificationChannelInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteNotificationsChannelsByID 删除通知渠道
+//
+// DELETE /v1/notifications/channels/{id}
+func (c *Client) DeleteNotificationsChannelsByID(ctx context.Context, id string) error {
+	path := "/v1/notifications/channels/" + url.PathEscape(id)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetNotificationsChannelsByID 获取通知渠道详情
+//
+// GET /v1/notifications/channels/{id}
+func (c *Client) GetNotificationsChannelsByID(ctx context.Context, id string) (*NotificationChannelInfo, error) {
+	path := "/v1/notifications/channels/" + url.PathEscape(id)
+	var out NotificationChannelInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutNotificationsChannelsByID 更新通知渠道
+//
+// PUT /v1/notifications/channels/{id}
+func (c *Client) PutNotificationsChannelsByID(ctx context.Context, id string, body *NotificationChannelUpdateRequest) (*NotificationChannelInfo, error) {
+	path := "/v1/notifications/channels/" + url.PathEscape(id)
+	var out NotificationChannelInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostNotificationsChannelsByIDTest 发送测试通知
+// 向渠道发送一条测试通知，停用的渠道也会发送
+//
+// POST /v1/notifications/channels/{id}/test
+func (c *Client) PostNotificationsChannelsByIDTest(ctx context.Context, id string) error {
+	path := "/v1/notifications/channels/" + url.PathEscape(id) + "/test"
+	return c.do(ctx, http.MethodPost, path, nil, nil, nil)
+}
+
+// GetObjects 获取对象列表
+// 按命名空间（tts、asr、ota、knowledge 等）和文件名查询对象，最近写入的在前
+//
+// GET /v1/objects
+func (c *Client) GetObjects(ctx context.Context, params *GetObjectsParams) (*ObjectListResponse, error) {
+	path := "/v1/objects"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out ObjectListResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetObjectsParams GetObjects 的查询参数，零值不发送
+type GetObjectsParams struct {
+	// 命名空间
+	Namespace string
+	// 文件名
+	Name string
+	// 页码
+	Page int64
+	// 每页数量
+	Limit int64
+}
+
+func (p *GetObjectsParams) values() url.Values {
+	query := url.Values{}
+	if p.Namespace != "" {
+		query.Set("namespace", p.Namespace)
+	}
+	if p.Name != "" {
+		query.Set("name", p.Name)
+	}
+	if p.Page != 0 {
+		query.Set("page", strconv.FormatInt(p.Page, 10))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return query
+}
+
+// DeleteObjectsByID 删除对象
+// 强制删除对象，不考虑引用数；仍被知识库文档引用的对象删除后，对应文档无法重新导入
+//
+// DELETE /v1/objects/{id}
+func (c *Client) DeleteObjectsByID(ctx context.Context, id string) error {
+	path := "/v1/objects/" + url.PathEscape(id)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetObjectsByID 获取对象元数据
+//
+// GET /v1/objects/{id}
+func (c *Client) GetObjectsByID(ctx context.Context, id string) (*ObjectInfo, error) {
+	path := "/v1/objects/" + url.PathEscape(id)
+	var out ObjectInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetObjectsByIDContent 下载对象
+// 通过 url 接口、TTS 输出或 OTA 响应中签发的地址下载对象内容，地址过期或签名不符时返回 403。
+// 内容按 SHA-256 寻址，ETag 即对象ID
+//
+// GET /v1/objects/{id}/content
+func (c *Client) GetObjectsByIDContent(ctx context.Context, id string, params *GetObjectsByIDContentParams) (interface{}, error) {
+	path := "/v1/objects/" + url.PathEscape(id) + "/content"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out interface{}
+	err := c.do(ctx, http.MethodGet, path, query, nil, &out)
+	return out, err
+}
+
+// GetObjectsByIDContentParams GetObjectsByIDContent 的查询参数，零值不发送
+type GetObjectsByIDContentParams struct {
+	// 过期时间（Unix 秒）
+	Expires int64
+	// 签名
+	Signature string
+}
+
+func (p *GetObjectsByIDContentParams) values() url.Values {
+	query := url.Values{}
+	if p.Expires != 0 {
+		query.Set("expires", strconv.FormatInt(p.Expires, 10))
+	}
+	if p.Signature != "" {
+		query.Set("signature", p.Signature)
+	}
+	return query
+}
+
+// GetObjectsByIDURL 签发对象下载地址
+// 使用 S3 后端时返回存储的预签名地址，否则返回本服务的签名下载地址
+//
+// GET /v1/objects/{id}/url
+func (c *Client) GetObjectsByIDURL(ctx context.Context, id string, params *GetObjectsByIDURLParams) (*ObjectURLResponse, error) {
+	path := "/v1/objects/" + url.PathEscape(id) + "/url"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out ObjectURLResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetObjectsByIDURLParams GetObjectsByIDURL 的查询参数，零值不发送
+type GetObjectsByIDURLParams struct {
+	// 有效秒数，缺省使用 ObjectStore.URLExpiry
+	ExpiresIn int64
+}
+
+func (p *GetObjectsByIDURLParams) values() url.Values {
+	query := url.Values{}
+	if p.ExpiresIn != 0 {
+		query.Set("expires_in", strconv.FormatInt(p.ExpiresIn, 10))
+	}
+	return query
+}
+
+// GetPlugins 获取插件列表
+// 获取所有插件的信息，支持分页、筛选和排序
+//
+// GET /v1/plugins
+func (c *Client) GetPlugins(ctx context.Context, params *GetPluginsParams) (*PluginListResponse, error) {
+	path := "/v1/plugins"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out PluginListResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPluginsParams GetPlugins 的查询参数，零值不发送
+type GetPluginsParams struct {
+	// 插件类型
+	Type string
+	// 插件状态
+	Status string
+	// 健康状态
+	HealthStatus string
+	// 页码
+	Page int64
+	// 每页大小
+	PageSize int64
+	// 排序字段
+	SortBy string
+	// 排序方向
+	SortOrder string
+	// 搜索关键词
+	Search string
+}
+
+func (p *GetPluginsParams) values() url.Values {
+	query := url.Values{}
+	if p.Type != "" {
+		query.Set("type", p.Type)
+	}
+	if p.Status != "" {
+		query.Set("status", p.Status)
+	}
+	if p.HealthStatus != "" {
+		query.Set("health_status", p.HealthStatus)
+	}
+	if p.Page != 0 {
+		query.Set("page", strconv.FormatInt(p.Page, 10))
+	}
+	if p.PageSize != 0 {
+		query.Set("page_size", strconv.FormatInt(p.PageSize, 10))
+	}
+	if p.SortBy != "" {
+		query.Set("sort_by", p.SortBy)
+	}
+	if p.SortOrder != "" {
+		query.Set("sort_order", p.SortOrder)
+	}
+	if p.Search != "" {
+		query.Set("search", p.Search)
+	}
+	return query
+}
+
+// GetPluginsCapabilities 获取所有插件能力
+// 获取所有插件的能力定义
+//
+// GET /v1/plugins/capabilities
+func (c *Client) GetPluginsCapabilities(ctx context.Context) error {
+	path := "/v1/plugins/capabilities"
+	return c.do(ctx, http.MethodGet, path, nil, nil, nil)
+}
+
+// GetPluginsCapabilitiesByType 按类型获取插件能力
+// 根据类型筛选插件能力
+//
+// GET /v1/plugins/capabilities/{type}
+func (c *Client) GetPluginsCapabilitiesByType(ctx context.Context, typeParam string) error {
+	path := "/v1/plugins/capabilities/" + url.PathEscape(typeParam)
+	return c.do(ctx, http.MethodGet, path, nil, nil, nil)
+}
+
+// GetPluginsConnections 获取提供者HTTP连接池统计
+// 获取各提供者的请求数、连接复用数、新建连接数、TLS握手次数和HTTP/2请求数
+//
+// GET /v1/plugins/connections
+func (c *Client) GetPluginsConnections(ctx context.Context) ([]ProviderStats, error) {
+	path := "/v1/plugins/connections"
+	var out []ProviderStats
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// GetPluginsPorts 获取端口统计信息
+// 获取端口使用情况统计、当前分配记录和分配历史（含重启后回收、孤儿端口等事件）
+//
+// GET /v1/plugins/ports
+func (c *Client) GetPluginsPorts(ctx context.Context, params *GetPluginsPortsParams) (*PortAllocationsResponse, error) {
+	path := "/v1/plugins/ports"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out PortAllocationsResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPluginsPortsParams GetPluginsPorts 的查询参数，零值不发送
+type GetPluginsPortsParams struct {
+	// 按插件ID筛选历史
+	PluginID string
+	// 按端口筛选历史
+	Port int64
+	// 历史条数
+	Limit int64
+}
+
+func (p *GetPluginsPortsParams) values() url.Values {
+	query := url.Values{}
+	if p.PluginID != "" {
+		query.Set("plugin_id", p.PluginID)
+	}
+	if p.Port != 0 {
+		query.Set("port", strconv.FormatInt(p.Port, 10))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return query
+}
+
+// GetPluginsQueue 获取能力执行队列统计
+// 获取各优先级（interactive、api、batch）的排队数、放行数、挤出数、拒绝数和等待时间
+//
+// GET /v1/plugins/queue
+func (c *Client) GetPluginsQueue(ctx context.Context) (*QueueStats, error) {
+	path := "/v1/plugins/queue"
+	var out QueueStats
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPluginsStats 获取插件统计信息
+// 获取插件的数量、状态分布、健康状态等统计信息
+//
+// GET /v1/plugins/stats
+func (c *Client) GetPluginsStats(ctx context.Context) (*PluginStats, error) {
+	path := "/v1/plugins/stats"
+	var out PluginStats
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPluginsByID 获取插件详情
+// 根据插件ID获取详细信息
+//
+// GET /v1/plugins/{id}
+func (c *Client) GetPluginsByID(ctx context.Context, id string) (*PluginStatus, error) {
+	path := "/v1/plugins/" + url.PathEscape(id)
+	var out PluginStatus
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostPluginsByIDControl 控制插件
+// 对插件进行启动、停止、重启、重新分配端口等操作
+//
+// POST /v1/plugins/{id}/control
+func (c *Client) PostPluginsByIDControl(ctx context.Context, id string, body *PluginControlRequest) (*PluginControlResponse, error) {
+	path := "/v1/plugins/" + url.PathEscape(id) + "/control"
+	var out PluginControlResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPluginsByIDDebug 获取插件调试信息
+// 通过插件调试服务获取插件信息、进程信息和已注册的gRPC服务，需启用 PluginGRPC.Debug
+//
+// GET /v1/plugins/{id}/debug
+func (c *Client) GetPluginsByIDDebug(ctx context.Context, id string) (*PluginDebugInfo, error) {
+	path := "/v1/plugins/" + url.PathEscape(id) + "/debug"
+	var out PluginDebugInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutPluginsByIDDebugLogLevel 修改插件日志级别
+// 通过插件调试服务修改插件日志级别，需启用 PluginGRPC.Debug；与核心在同一进程中运行的插件共用核心日志，修改对整个进程生效
+//
+// PUT /v1/plugins/{id}/debug/log-level
+func (c *Client) PutPluginsByIDDebugLogLevel(ctx context.Context, id string, body *PluginLogLevelRequest) (*PluginLogLevelResponse, error) {
+	path := "/v1/plugins/" + url.PathEscape(id) + "/debug/log-level"
+	var out PluginLogLevelResponse
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPluginsByIDDebugMetrics 获取插件调用统计
+// 通过插件调试服务获取插件各gRPC方法的调用次数、错误数和耗时，以及Go运行时指标，需启用 PluginGRPC.Debug
+//
+// GET /v1/plugins/{id}/debug/metrics
+func (c *Client) GetPluginsByIDDebugMetrics(ctx context.Context, id string) (*PluginDebugMetrics, error) {
+	path := "/v1/plugins/" + url.PathEscape(id) + "/debug/metrics"
+	var out PluginDebugMetrics
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostPluginsByIDHealth 检查插件健康状态
+// 手动触发插件健康检查
+//
+// POST /v1/plugins/{id}/health
+func (c *Client) PostPluginsByIDHealth(ctx context.Context, id string) error {
+	path := "/v1/plugins/" + url.PathEscape(id) + "/health"
+	return c.do(ctx, http.MethodPost, path, nil, nil, nil)
+}
+
+// GetPluginsByIDLogs 获取插件日志
+// 返回插件 stdout/stderr 的最近日志；follow=true 时以 SSE 持续推送新日志（事件名 log）
+//
+// GET /v1/plugins/{id}/logs
+func (c *Client) GetPluginsByIDLogs(ctx context.Context, id string, params *GetPluginsByIDLogsParams) (*PluginLogsResponse, error) {
+	path := "/v1/plugins/" + url.PathEscape(id) + "/logs"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out PluginLogsResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPluginsByIDLogsParams GetPluginsByIDLogs 的查询参数，零值不发送
+type GetPluginsByIDLogsParams struct {
+	// 返回最近的日志行数
+	Tail int64
+	// 最低日志级别
+	Level string
+	// 是否以SSE持续推送
+	Follow *bool
+}
+
+func (p *GetPluginsByIDLogsParams) values() url.Values {
+	query := url.Values{}
+	if p.Tail != 0 {
+		query.Set("tail", strconv.FormatInt(p.Tail, 10))
+	}
+	if p.Level != "" {
+		query.Set("level", p.Level)
+	}
+	if p.Follow != nil {
+		query.Set("follow", strconv.FormatBool(*p.Follow))
+	}
+	return query
+}
+
+// PostPluginsByIDReallocatePort 重新分配插件端口
+// 为插件分配新的端口
+//
+// POST /v1/plugins/{id}/reallocate-port
+func (c *Client) PostPluginsByIDReallocatePort(ctx context.Context, id string) error {
+	path := "/v1/plugins/" + url.PathEscape(id) + "/reallocate-port"
+	return c.do(ctx, http.MethodPost, path, nil, nil, nil)
+}
+
+// GetPresence 获取在家状态
+// 返回参与在家判断的全部成员及在家成员的称呼
+//
+// GET /v1/presence
+func (c *Client) GetPresence(ctx context.Context) (*PresenceOverview, error) {
+	path := "/v1/presence"
+	var out PresenceOverview
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostPresenceRouter 路由器推送在线设备
+// 路由器上报当前在线设备的MAC地址，成员的设备上线即判定到家，连续 AwayDelay 未上报才判定离家
+//
+// POST /v1/presence/router
+func (c *Client) PostPresenceRouter(ctx context.Context, body *PresenceRouterReport) (*PresenceRouterReportResponse, error) {
+	path := "/v1/presence/router"
+	var out PresenceRouterReportResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPromptAssignments 获取提示词模板分配列表
+//
+// GET /v1/prompt-assignments
+func (c *Client) GetPromptAssignments(ctx context.Context) ([]PromptAssignmentInfo, error) {
+	path := "/v1/prompt-assignments"
+	var out []PromptAssignmentInfo
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// DeletePromptAssignmentsByDeviceID 取消提示词模板分配
+//
+// DELETE /v1/prompt-assignments/{device_id}
+func (c *Client) DeletePromptAssignmentsByDeviceID(ctx context.Context, deviceID string) error {
+	path := "/v1/prompt-assignments/" + url.PathEscape(deviceID)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// PutPromptAssignmentsByDeviceID 为设备分配提示词模板
+// device_id 为 * 时设置全局默认模板
+//
+// PUT /v1/prompt-assignments/{device_id}
+func (c *Client) PutPromptAssignmentsByDeviceID(ctx context.Context, deviceID string, body *PromptAssignRequest) (*PromptAssignmentInfo, error) {
+	path := "/v1/prompt-assignments/" + url.PathEscape(deviceID)
+	var out PromptAssignmentInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPrompts 获取提示词模板列表
+// 返回各模板的当前生效版本
+//
+// GET /v1/prompts
+func (c *Client) GetPrompts(ctx context.Context, params *GetPromptsParams) (*PromptListResponse, error) {
+	path := "/v1/prompts"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out PromptListResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPromptsParams GetPrompts 的查询参数，零值不发送
+type GetPromptsParams struct {
+	// 名称关键字
+	Name string
+	// 页码
+	Page int64
+	// 每页数量
+	Limit int64
+}
+
+func (p *GetPromptsParams) values() url.Values {
+	query := url.Values{}
+	if p.Name != "" {
+		query.Set("name", p.Name)
+	}
+	if p.Page != 0 {
+		query.Set("page", strconv.FormatInt(p.Page, 10))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return query
+}
+
+// PostPrompts 创建提示词模板
+// 创建命名模板，支持 Go 模板语法和 Jinja 风格的 {{ name }} 变量
+//
+// POST /v1/prompts
+func (c *Client) PostPrompts(ctx context.Context, body *PromptCreateRequest) (*PromptInfo, error) {
+	path := "/v1/prompts"
+	var out PromptInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostPromptsRender 预览提示词模板
+// 渲染未保存的模板内容，用于编辑时预览
+//
+// POST /v1/prompts/render
+func (c *Client) PostPromptsRender(ctx context.Context, body *PromptRenderRequest) (*PromptRenderResponse, error) {
+	path := "/v1/prompts/render"
+	var out PromptRenderResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeletePromptsByName 删除提示词模板
+// 删除模板的全部版本及设备分配
+//
+// DELETE /v1/prompts/{name}
+func (c *Client) DeletePromptsByName(ctx context.Context, name string) error {
+	path := "/v1/prompts/" + url.PathEscape(name)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetPromptsByName 获取提示词模板
+//
+// GET /v1/prompts/{name}
+func (c *Client) GetPromptsByName(ctx context.Context, name string, params *GetPromptsByNameParams) (*PromptInfo, error) {
+	path := "/v1/prompts/" + url.PathEscape(name)
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out PromptInfo
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPromptsByNameParams GetPromptsByName 的查询参数，零值不发送
+type GetPromptsByNameParams struct {
+	// 版本号，默认为生效版本
+	Version int64
+}
+
+func (p *GetPromptsByNameParams) values() url.Values {
+	query := url.Values{}
+	if p.Version != 0 {
+		query.Set("version", strconv.FormatInt(p.Version, 10))
+	}
+	return query
+}
+
+// PutPromptsByName 更新提示词模板
+// 基于当前生效版本生成新版本，并设为生效版本
+//
+// PUT /v1/prompts/{name}
+func (c *Client) PutPromptsByName(ctx context.Context, name string, body *PromptUpdateRequest) (*PromptInfo, error) {
+	path := "/v1/prompts/" + url.PathEscape(name)
+	var out PromptInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostPromptsByNameRender 测试渲染提示词模板
+//
+// POST /v1/prompts/{name}/render
+func (c *Client) PostPromptsByNameRender(ctx context.Context, name string, body *PromptRenderRequest) (*PromptRenderResponse, error) {
+	path := "/v1/prompts/" + url.PathEscape(name) + "/render"
+	var out PromptRenderResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPromptsByNameVersions 获取提示词模板版本列表
+//
+// GET /v1/prompts/{name}/versions
+func (c *Client) GetPromptsByNameVersions(ctx context.Context, name string) ([]PromptInfo, error) {
+	path := "/v1/prompts/" + url.PathEscape(name) + "/versions"
+	var out []PromptInfo
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// PostPromptsByNameVersionsByVersionActivate 切换提示词模板生效版本
+// 将指定版本设为生效版本，可用于回滚
+//
+// POST /v1/prompts/{name}/versions/{version}/activate
+func (c *Client) PostPromptsByNameVersionsByVersionActivate(ctx context.Context, name string, version int64) (*PromptInfo, error) {
+	path := "/v1/prompts/" + url.PathEscape(name) + "/versions/" + url.PathEscape(fmt.Sprint(version)) + "/activate"
+	var out PromptInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostProvisioningClaim 扫码配对设备
+// 设备或配套 App 用二维码中的配对令牌换取设备凭证；设备未注册时以令牌签发者为所有者创建并激活，已注册的设备只能由原所有者重新配对。令牌只能使用一次
+//
+// POST /v1/provisioning/claim
+func (c *Client) PostProvisioningClaim(ctx context.Context, body *ProvisioningClaimRequest) (*ProvisioningClaimResponse, error) {
+	path := "/v1/provisioning/claim"
+	var out ProvisioningClaimResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetRecordings 获取录音列表
+// 按设备、会话、时间范围过滤归档的用户语音
+//
+// GET /v1/recordings
+func (c *Client) GetRecordings(ctx context.Context, params *GetRecordingsParams) (*RecordingListResponse, error) {
+	path := "/v1/recordings"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out RecordingListResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetRecordingsParams GetRecordings 的查询参数，零值不发送
+type GetRecordingsParams struct {
+	// 设备ID
+	DeviceID string
+	// 会话ID
+	SessionID string
+	// 开始时间 RFC3339
+	From string
+	// 结束时间 RFC3339
+	To string
+	// 页码
+	Page int64
+	// 每页数量
+	Limit int64
+}
+
+func (p *GetRecordingsParams) values() url.Values {
+	query := url.Values{}
+	if p.DeviceID != "" {
+		query.Set("device_id", p.DeviceID)
+	}
+	if p.SessionID != "" {
+		query.Set("session_id", p.SessionID)
+	}
+	if p.From != "" {
+		query.Set("from", p.From)
+	}
+	if p.To != "" {
+		query.Set("to", p.To)
+	}
+	if p.Page != 0 {
+		query.Set("page", strconv.FormatInt(p.Page, 10))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return query
+}
+
+// DeleteRecordingsByID 删除录音
+//
+// DELETE /v1/recordings/{id}
+func (c *Client) DeleteRecordingsByID(ctx context.Context, id string) error {
+	path := "/v1/recordings/" + url.PathEscape(id)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetRecordingsByID 获取录音详情
+//
+// GET /v1/recordings/{id}
+func (c *Client) GetRecordingsByID(ctx context.Context, id string) (*RecordingInfo, error) {
+	path := "/v1/recordings/" + url.PathEscape(id)
+	var out RecordingInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetRecordingsByIDAudio 下载录音
+// 返回解密后的 WAV 音频
+//
+// GET /v1/recordings/{id}/audio
+func (c *Client) GetRecordingsByIDAudio(ctx context.Context, id string) (interface{}, error) {
+	path := "/v1/recordings/" + url.PathEscape(id) + "/audio"
+	var out interface{}
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// GetReminders 获取提醒列表
+// 获取提醒列表，支持按设备、类型和状态过滤
+//
+// GET /v1/reminders
+func (c *Client) GetReminders(ctx context.Context, params *GetRemindersParams) (*ReminderListResponse, error) {
+	path := "/v1/reminders"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out ReminderListResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetRemindersParams GetReminders 的查询参数，零值不发送
+type GetRemindersParams struct {
+	// 设备ID
+	DeviceID string
+	// 类型 timer/alarm/reminder
+	Kind string
+	// 状态 pending/fired/cancelled/missed
+	Status string
+	// 页码
+	Page int64
+	// 每页数量
+	Limit int64
+}
+
+func (p *GetRemindersParams) values() url.Values {
+	query := url.Values{}
+	if p.DeviceID != "" {
+		query.Set("device_id", p.DeviceID)
+	}
+	if p.Kind != "" {
+		query.Set("kind", p.Kind)
+	}
+	if p.Status != "" {
+		query.Set("status", p.Status)
+	}
+	if p.Page != 0 {
+		query.Set("page", strconv.FormatInt(p.Page, 10))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return query
+}
+
+// PostReminders 创建提醒
+// 为设备创建计时器、闹钟或提醒，到期后向设备推送语音播报
+//
+// POST /v1/reminders
+func (c *Client) PostReminders(ctx context.Context, body *ReminderCreateRequest) (*ReminderInfo, error) {
+	path := "/v1/reminders"
+	var out ReminderInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteRemindersByID 删除提醒
+//
+// DELETE /v1/reminders/{id}
+func (c *Client) DeleteRemindersByID(ctx context.Context, id string) error {
+	path := "/v1/reminders/" + url.PathEscape(id)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetRemindersByID 获取提醒详情
+//
+// GET /v1/reminders/{id}
+func (c *Client) GetRemindersByID(ctx context.Context, id string) (*ReminderInfo, error) {
+	path := "/v1/reminders/" + url.PathEscape(id)
+	var out ReminderInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutRemindersByID 更新提醒
+// 修改待触发提醒的内容、触发时间或重复规则
+//
+// PUT /v1/reminders/{id}
+func (c *Client) PutRemindersByID(ctx context.Context, id string, body *ReminderUpdateRequest) (*ReminderInfo, error) {
+	path := "/v1/reminders/" + url.PathEscape(id)
+	var out ReminderInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostRemindersByIDCancel 取消提醒
+//
+// POST /v1/reminders/{id}/cancel
+func (c *Client) PostRemindersByIDCancel(ctx context.Context, id string) (*ReminderInfo, error) {
+	path := "/v1/reminders/" + url.PathEscape(id) + "/cancel"
+	var out ReminderInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetReplays 获取回放列表
+// 返回回放运行及汇总，不含逐轮明细
+//
+// GET /v1/replays
+func (c *Client) GetReplays(ctx context.Context, params *GetReplaysParams) (*ReplayRunListResponse, error) {
+	path := "/v1/replays"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out ReplayRunListResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetReplaysParams GetReplays 的查询参数，零值不发送
+type GetReplaysParams struct {
+	// 会话ID
+	SessionID string
+	// 页码
+	Page int64
+	// 每页数量
+	Limit int64
+}
+
+func (p *GetReplaysParams) values() url.Values {
+	query := url.Values{}
+	if p.SessionID != "" {
+		query.Set("session_id", p.SessionID)
+	}
+	if p.Page != 0 {
+		query.Set("page", strconv.FormatInt(p.Page, 10))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return query
+}
+
+// PostReplays 回放已记录的会话
+// 用当前或指定的 LLM 配置和提示词重新生成会话中每轮的回复，在后台执行；每轮的对话历史使用原回复，工具调用不重新执行。可通过回放ID查询逐轮的回复差异、耗时和成本对比
+//
+// POST /v1/replays
+func (c *Client) PostReplays(ctx context.Context, body *ReplayStartRequest) (*ReplayRunInfo, error) {
+	path := "/v1/replays"
+	var out ReplayRunInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetReplaysByID 获取回放对比结果
+// 返回汇总以及每轮的原回复、回放回复、逐词差异、首字和完成耗时、Token数和成本
+//
+// GET /v1/replays/{id}
+func (c *Client) GetReplaysByID(ctx context.Context, id string) (*ReplayRunInfo, error) {
+	path := "/v1/replays/" + url.PathEscape(id)
+	var out ReplayRunInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetRoutines 获取例程列表
+// 按创建时间列出例程，可按设备过滤
+//
+// GET /v1/routines
+func (c *Client) GetRoutines(ctx context.Context, params *GetRoutinesParams) ([]RoutineInfo, error) {
+	path := "/v1/routines"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out []RoutineInfo
+	err := c.do(ctx, http.MethodGet, path, query, nil, &out)
+	return out, err
+}
+
+// GetRoutinesParams GetRoutines 的查询参数，零值不发送
+type GetRoutinesParams struct {
+	// 设备ID
+	DeviceID string
+}
+
+func (p *GetRoutinesParams) values() url.Values {
+	query := url.Values{}
+	if p.DeviceID != "" {
+		query.Set("device_id", p.DeviceID)
+	}
+	return query
+}
+
+// PostRoutines 创建例程
+// 为设备创建例程，按 cron 计划定时触发或在设备上说出唤醒短语时触发，依次执行天气、新闻、日程、固定文本或工作流动作并合并播报
+//
+// POST /v1/routines
+func (c *Client) PostRoutines(ctx context.Context, body *RoutineCreateRequest) (*RoutineInfo, error) {
+	path := "/v1/routines"
+	var out RoutineInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteRoutinesByID 删除例程
+//
+// DELETE /v1/routines/{id}
+func (c *Client) DeleteRoutinesByID(ctx context.Context, id string) error {
+	path := "/v1/routines/" + url.PathEscape(id)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetRoutinesByID 获取例程详情
+//
+// GET /v1/routines/{id}
+func (c *Client) GetRoutinesByID(ctx context.Context, id string) (*RoutineInfo, error) {
+	path := "/v1/routines/" + url.PathEscape(id)
+	var out RoutineInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutRoutinesByID 更新例程
+// 修改例程的名称、计划、唤醒短语、动作或启用状态，修改计划后重新计算下次触发时间
+//
+// PUT /v1/routines/{id}
+func (c *Client) PutRoutinesByID(ctx context.Context, id string, body *RoutineUpdateRequest) (*RoutineInfo, error) {
+	path := "/v1/routines/" + url.PathEscape(id)
+	var out RoutineInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostRoutinesByIDRun 立即执行例程
+// 立即执行例程的全部动作并推送到设备播报，设备不在线时返回 409
+//
+// POST /v1/routines/{id}/run
+func (c *Client) PostRoutinesByIDRun(ctx context.Context, id string) (*RoutineRunResponse, error) {
+	path := "/v1/routines/" + url.PathEscape(id) + "/run"
+	var out RoutineRunResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetScripts 获取脚本列表
+//
+// GET /v1/scripts
+func (c *Client) GetScripts(ctx context.Context) ([]ScriptInfo, error) {
+	path := "/v1/scripts"
+	var out []ScriptInfo
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// PostScripts 创建脚本
+// 创建供工作流脚本节点使用的 Lua 脚本，保存时检查语法
+//
+// POST /v1/scripts
+func (c *Client) PostScripts(ctx context.Context, body *ScriptCreateRequest) (*ScriptInfo, error) {
+	path := "/v1/scripts"
+	var out ScriptInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteScriptsByID 删除脚本
+//
+// DELETE /v1/scripts/{id}
+func (c *Client) DeleteScriptsByID(ctx context.Context, id string) error {
+	path := "/v1/scripts/" + url.PathEscape(id)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetScriptsByID 获取脚本详情
+//
+// GET /v1/scripts/{id}
+func (c *Client) GetScriptsByID(ctx context.Context, id string) (*ScriptInfo, error) {
+	path := "/v1/scripts/" + url.PathEscape(id)
+	var out ScriptInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutScriptsByID 更新脚本
+// 修改源码时生成新版本，引用当前版本的脚本节点在下次执行时使用新源码
+//
+// PUT /v1/scripts/{id}
+func (c *Client) PutScriptsByID(ctx context.Context, id string, body *ScriptUpdateRequest) (*ScriptInfo, error) {
+	path := "/v1/scripts/" + url.PathEscape(id)
+	var out ScriptInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostScriptsByIDRollback 回滚脚本
+// 以历史版本的源码创建新版本
+//
+// POST /v1/scripts/{id}/rollback
+func (c *Client) PostScriptsByIDRollback(ctx context.Context, id string, body *ScriptRollbackRequest) (*ScriptInfo, error) {
+	path := "/v1/scripts/" + url.PathEscape(id) + "/rollback"
+	var out ScriptInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostScriptsByIDTest 试运行脚本
+// 以给定输入在沙箱中运行脚本，返回输出和日志；脚本错误或超时返回 400
+//
+// POST /v1/scripts/{id}/test
+func (c *Client) PostScriptsByIDTest(ctx context.Context, id string, body *ScriptTestRequest) (*ScriptTestResult, error) {
+	path := "/v1/scripts/" + url.PathEscape(id) + "/test"
+	var out ScriptTestResult
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetScriptsByIDVersions 获取脚本版本列表
+//
+// GET /v1/scripts/{id}/versions
+func (c *Client) GetScriptsByIDVersions(ctx context.Context, id string) ([]ScriptVersionInfo, error) {
+	path := "/v1/scripts/" + url.PathEscape(id) + "/versions"
+	var out []ScriptVersionInfo
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// GetScriptsByIDVersionsByVersion 获取脚本指定版本
+//
+// GET /v1/scripts/{id}/versions/{version}
+func (c *Client) GetScriptsByIDVersionsByVersion(ctx context.Context, id string, version int64) (*ScriptVersionInfo, error) {
+	path := "/v1/scripts/" + url.PathEscape(id) + "/versions/" + url.PathEscape(fmt.Sprint(version))
+	var out ScriptVersionInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSmarthomeAliases 列出智能家居设备别名
+// 返回当前租户的设备别名，包括通过接口设置的和从语音纠正中学到的
+//
+// GET /v1/smarthome/aliases
+func (c *Client) GetSmarthomeAliases(ctx context.Context) ([]SmartHomeAlias, error) {
+	path := "/v1/smarthome/aliases"
+	var out []SmartHomeAlias
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// PostSmarthomeAliases 设置智能家居设备别名
+// 把别名指向实体，别名已存在时改为指向新实体；比较别名时忽略空白、标点和“的”
+//
+// POST /v1/smarthome/aliases
+func (c *Client) PostSmarthomeAliases(ctx context.Context, body *SmartHomeAliasRequest) (*SmartHomeAlias, error) {
+	path := "/v1/smarthome/aliases"
+	var out SmartHomeAlias
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteSmarthomeAliasesByAlias 删除智能家居设备别名
+//
+// DELETE /v1/smarthome/aliases/{alias}
+func (c *Client) DeleteSmarthomeAliasesByAlias(ctx context.Context, alias string) error {
+	path := "/v1/smarthome/aliases/" + url.PathEscape(alias)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// PostSmarthomeDiscover 重新发现智能家居实体
+// 向所有桥接重新发现实体，单个桥接失败时保留它上次发现的实体
+//
+// POST /v1/smarthome/discover
+func (c *Client) PostSmarthomeDiscover(ctx context.Context) ([]SmartHomeEntity, error) {
+	path := "/v1/smarthome/discover"
+	var out []SmartHomeEntity
+	err := c.do(ctx, http.MethodPost, path, nil, nil, &out)
+	return out, err
+}
+
+// GetSmarthomeEntities 列出智能家居实体
+// 返回缓存的实体及其状态，可按房间和类型过滤
+//
+// GET /v1/smarthome/entities
+func (c *Client) GetSmarthomeEntities(ctx context.Context, params *GetSmarthomeEntitiesParams) ([]SmartHomeEntity, error) {
+	path := "/v1/smarthome/entities"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out []SmartHomeEntity
+	err := c.do(ctx, http.MethodGet, path, query, nil, &out)
+	return out, err
+}
+
+// GetSmarthomeEntitiesParams GetSmarthomeEntities 的查询参数，零值不发送
+type GetSmarthomeEntitiesParams struct {
+	// 房间
+	Room string
+	// 实体类型 light/switch/cover/climate/lock/fan/sensor
+	Type string
+	// 先向所有桥接重新发现实体
+	Refresh *bool
+}
+
+func (p *GetSmarthomeEntitiesParams) values() url.Values {
+	query := url.Values{}
+	if p.Room != "" {
+		query.Set("room", p.Room)
+	}
+	if p.Type != "" {
+		query.Set("type", p.Type)
+	}
+	if p.Refresh != nil {
+		query.Set("refresh", strconv.FormatBool(*p.Refresh))
+	}
+	return query
+}
+
+// GetSmarthomeEntitiesByID 获取智能家居实体
+// 缓存的状态过期时先向桥接刷新
+//
+// GET /v1/smarthome/entities/{id}
+func (c *Client) GetSmarthomeEntitiesByID(ctx context.Context, id string) (*SmartHomeEntity, error) {
+	path := "/v1/smarthome/entities/" + url.PathEscape(id)
+	var out SmartHomeEntity
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostSmarthomeEntitiesByIDControl 控制智能家居实体
+// 返回控制后预期的实体状态；窗帘的 turn_on/turn_off 按 open/close 处理
+//
+// POST /v1/smarthome/entities/{id}/control
+func (c *Client) PostSmarthomeEntitiesByIDControl(ctx context.Context, id string, body *SmartHomeControlRequest) (*SmartHomeEntity, error) {
+	path := "/v1/smarthome/entities/" + url.PathEscape(id) + "/control"
+	var out SmartHomeEntity
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSpeechProfiles 获取播报文本后处理方案列表
+// 列出全部方案和设备分配；数据库中没有 default 方案时列表包含内置方案
+//
+// GET /v1/speech-profiles
+func (c *Client) GetSpeechProfiles(ctx context.Context) (*SpeechTextProfileListResponse, error) {
+	path := "/v1/speech-profiles"
+	var out SpeechTextProfileListResponse
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostSpeechProfiles 创建播报文本后处理方案
+// 步骤按顺序执行：markdown 去掉 Markdown 标记，emoji 去掉表情，units 把日期、时间、范围、百分比、温度和计量单位转为读法，replace 按发音词典替换，regex 正则替换。同名方案会被覆盖，修改对之后建立的连接生效
+//
+// POST /v1/speech-profiles
+func (c *Client) PostSpeechProfiles(ctx context.Context, body *SpeechTextProfileRequest) (*SpeechTextProfileInfo, error) {
+	path := "/v1/speech-profiles"
+	var out SpeechTextProfileInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostSpeechProfilesPreview 试运行播报文本后处理
+// 用已保存的方案或未保存的步骤处理文本，返回送入 TTS 的文本；profile 和 stages 都为空时使用 default 方案
+//
+// POST /v1/speech-profiles/preview
+func (c *Client) PostSpeechProfilesPreview(ctx context.Context, body *SpeechTextPreviewRequest) (*SpeechTextPreviewResponse, error) {
+	path := "/v1/speech-profiles/preview"
+	var out SpeechTextPreviewResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteSpeechProfilesByName 删除播报文本后处理方案
+// 同时取消使用该方案的设备分配；删除 default 方案后恢复使用内置方案
+//
+// DELETE /v1/speech-profiles/{name}
+func (c *Client) DeleteSpeechProfilesByName(ctx context.Context, name string) error {
+	path := "/v1/speech-profiles/" + url.PathEscape(name)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetSpeechProfilesByName 获取播报文本后处理方案
+//
+// GET /v1/speech-profiles/{name}
+func (c *Client) GetSpeechProfilesByName(ctx context.Context, name string) (*SpeechTextProfileInfo, error) {
+	path := "/v1/speech-profiles/" + url.PathEscape(name)
+	var out SpeechTextProfileInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutSpeechProfilesByName 创建或覆盖播报文本后处理方案
+// 保存名为 default 的方案会替换内置方案，作为未分配方案的设备的默认方案
+//
+// PUT /v1/speech-profiles/{name}
+func (c *Client) PutSpeechProfilesByName(ctx context.Context, name string, body *SpeechTextProfileRequest) (*SpeechTextProfileInfo, error) {
+	path := "/v1/speech-profiles/" + url.PathEscape(name)
+	var out SpeechTextProfileInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSystemBootReport 获取启动耗时报告
+// 获取本次启动各初始化步骤的开始时间、耗时、超时设置和状态，以及决定启动总耗时的关键路径，用于排查启动缓慢；依赖关系满足的步骤并行执行，步骤累计耗时可能大于总耗时
+//
+// GET /v1/system/boot-report
+func (c *Client) GetSystemBootReport(ctx context.Context) (*BootReport, error) {
+	path := "/v1/system/boot-report"
+	var out BootReport
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSystemDatabase 获取数据库耗时与连接池统计
+// 获取各类操作（query/create/update/delete/row/raw）的次数、错误数、锁冲突次数和平均/最大耗时，以及写串行化排队和连接池状态，用于验证 WAL 与连接池调优效果
+//
+// GET /v1/system/database
+func (c *Client) GetSystemDatabase(ctx context.Context) (*DBStats, error) {
+	path := "/v1/system/database"
+	var out DBStats
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSystemDrain 获取排空进度
+//
+// GET /v1/system/drain
+func (c *Client) GetSystemDrain(ctx context.Context) (*DrainStatus, error) {
+	path := "/v1/system/drain"
+	var out DrainStatus
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostSystemDrain 进入排空模式
+// 停止接受新的WebSocket会话和工作流执行，等待活跃会话结束（最长至截止时间）后正常关停；重复调用返回当前进度
+//
+// POST /v1/system/drain
+func (c *Client) PostSystemDrain(ctx context.Context, body *DrainRequest) (*DrainStatus, error) {
+	path := "/v1/system/drain"
+	var out DrainStatus
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSystemIntents 获取意图路由统计
+// 获取进程启动以来各意图的分类命中、由处理器完成、回落到LLM和处理失败的次数及平均路由耗时，所有连接累计；intent 为 llm 的条目是未命中任何意图的语句
+//
+// GET /v1/system/intents
+func (c *Client) GetSystemIntents(ctx context.Context) ([]IntentRouteStats, error) {
+	path := "/v1/system/intents"
+	var out []IntentRouteStats
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// GetSystemMetrics 获取插件指标
+// 以 Prometheus 文本格式输出各插件通过 ScrapeMetrics 上报的指标，每个序列带 plugin_id 标签；另含各插件的 plugin_scrape_up 和 plugin_scrape_duration_seconds。数据为最近一次定期采集的结果，间隔见 PluginMetrics.Interval
+//
+// GET /v1/system/metrics
+func (c *Client) GetSystemMetrics(ctx context.Context) (string, error) {
+	path := "/v1/system/metrics"
+	var out string
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// GetSystemSelftest 执行启动自检
+// 按初始化依赖图执行只读检查：数据库连接与表结构、配置与密钥、所选提供者的连通性、日志与插件目录、外部 MCP 服务命令，返回逐项结果；运行中的服务不检查端口占用。与 xiaozhi-server doctor 的检查相同
+//
+// GET /v1/system/selftest
+func (c *Client) GetSystemSelftest(ctx context.Context) (*SelfTestReport, error) {
+	path := "/v1/system/selftest"
+	var out SelfTestReport
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTenant 获取当前租户
+// 返回请求所属租户的信息和今日用量；管理员未指定 X-Tenant-ID 时返回默认租户
+//
+// GET /v1/tenant
+func (c *Client) GetTenant(ctx context.Context) (*TenantUsageInfo, error) {
+	path := "/v1/tenant"
+	var out TenantUsageInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTenantProviders 获取租户供应商配置
+// 返回请求所属租户的 LLM 和 TTS 配置及选择，密钥脱敏为 ******；管理员未指定 X-Tenant-ID 时返回默认租户
+//
+// GET /v1/tenant/providers
+func (c *Client) GetTenantProviders(ctx context.Context) (*TenantProviders, error) {
+	path := "/v1/tenant/providers"
+	var out TenantProviders
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutTenantProviders 修改租户供应商配置
+// 整体替换请求所属租户的 LLM 和 TTS 配置。条目与实例配置同名时覆盖实例配置，选择为空时沿用实例的选择；
+// 密钥传 ****** 表示保留同名条目的原值。新连接的设备使用新配置，ASR 仍使用实例配置
+//
+// PUT /v1/tenant/providers
+func (c *Client) PutTenantProviders(ctx context.Context, body *TenantProviders) (*TenantProviders, error) {
+	path := "/v1/tenant/providers"
+	var out TenantProviders
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTenants 获取租户列表
+//
+// GET /v1/tenants
+func (c *Client) GetTenants(ctx context.Context) ([]TenantInfo, error) {
+	path := "/v1/tenants"
+	var out []TenantInfo
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// PostTenants 创建租户
+// 创建租户并签发访问令牌，令牌只在响应中返回一次。租户ID由小写字母、数字、下划线和连字符组成
+//
+// POST /v1/tenants
+func (c *Client) PostTenants(ctx context.Context, body *TenantCreateRequest) (*TenantTokenResponse, error) {
+	path := "/v1/tenants"
+	var out TenantTokenResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteTenantsByID 删除租户
+// 删除租户及其提示词模板和用量记录；仍有设备的租户不能删除，默认租户不能删除
+//
+// DELETE /v1/tenants/{id}
+func (c *Client) DeleteTenantsByID(ctx context.Context, id string) error {
+	path := "/v1/tenants/" + url.PathEscape(id)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetTenantsByID 获取租户
+//
+// GET /v1/tenants/{id}
+func (c *Client) GetTenantsByID(ctx context.Context, id string) (*TenantInfo, error) {
+	path := "/v1/tenants/" + url.PathEscape(id)
+	var out TenantInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutTenantsByID 修改租户
+// 修改名称、启用状态或配额；停用后租户令牌和设备连接都会被拒绝
+//
+// PUT /v1/tenants/{id}
+func (c *Client) PutTenantsByID(ctx context.Context, id string, body *TenantUpdateRequest) (*TenantInfo, error) {
+	path := "/v1/tenants/" + url.PathEscape(id)
+	var out TenantInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostTenantsByIDToken 重新签发租户令牌
+// 生成新的访问令牌，旧令牌立即失效；新令牌只在响应中返回一次
+//
+// POST /v1/tenants/{id}/token
+func (c *Client) PostTenantsByIDToken(ctx context.Context, id string) (*TenantTokenResponse, error) {
+	path := "/v1/tenants/" + url.PathEscape(id) + "/token"
+	var out TenantTokenResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTenantsByIDUsage 查询租户用量
+// 返回指定日期的能力调用次数、Token 数以及当前设备数
+//
+// GET /v1/tenants/{id}/usage
+func (c *Client) GetTenantsByIDUsage(ctx context.Context, id string, params *GetTenantsByIDUsageParams) (*TenantUsageInfo, error) {
+	path := "/v1/tenants/" + url.PathEscape(id) + "/usage"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out TenantUsageInfo
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTenantsByIDUsageParams GetTenantsByIDUsage 的查询参数，零值不发送
+type GetTenantsByIDUsageParams struct {
+	// 日期 YYYY-MM-DD，默认今天
+	Day string
+}
+
+func (p *GetTenantsByIDUsageParams) values() url.Values {
+	query := url.Values{}
+	if p.Day != "" {
+		query.Set("day", p.Day)
+	}
+	return query
+}
+
+// GetToolPolicyAudit 获取工具调用审计记录
+// 按设备、智能体、工具名称、检查结果和时间范围过滤设备会话和智能体发起的工具与能力调用，新记录在前
+//
+// GET /v1/tool-policy/audit
+func (c *Client) GetToolPolicyAudit(ctx context.Context, params *GetToolPolicyAuditParams) (*ToolPolicyAuditListResponse, error) {
+	path := "/v1/tool-policy/audit"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out ToolPolicyAuditListResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetToolPolicyAuditParams GetToolPolicyAudit 的查询参数，零值不发送
+type GetToolPolicyAuditParams struct {
+	// 设备ID
+	DeviceID string
+	// 智能体名称
+	Agent string
+	// 工具或能力名称
+	Name string
+	// 是否放行
+	Allowed *bool
+	// 开始时间 RFC3339
+	From string
+	// 结束时间 RFC3339
+	To string
+	// 页码
+	Page int64
+	// 每页数量
+	Limit int64
+}
+
+func (p *GetToolPolicyAuditParams) values() url.Values {
+	query := url.Values{}
+	if p.DeviceID != "" {
+		query.Set("device_id", p.DeviceID)
+	}
+	if p.Agent != "" {
+		query.Set("agent", p.Agent)
+	}
+	if p.Name != "" {
+		query.Set("name", p.Name)
+	}
+	if p.Allowed != nil {
+		query.Set("allowed", strconv.FormatBool(*p.Allowed))
+	}
+	if p.From != "" {
+		query.Set("from", p.From)
+	}
+	if p.To != "" {
+		query.Set("to", p.To)
+	}
+	if p.Page != 0 {
+		query.Set("page", strconv.FormatInt(p.Page, 10))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return query
+}
+
+// PostToolPolicyCheck 试算工具调用权限
+// 按当前策略检查设备会话或智能体能否以给定参数调用工具或能力，不执行调用也不记入审计
+//
+// POST /v1/tool-policy/check
+func (c *Client) PostToolPolicyCheck(ctx context.Context, body *ToolPolicyCheckRequest) (*ToolPolicyCheckResponse, error) {
+	path := "/v1/tool-policy/check"
+	var out ToolPolicyCheckResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTranslationLanguages 获取翻译模式支持的语言
+// 返回可作为源语言和目标语言的语言代码，以及各语言配置的TTS音色
+//
+// GET /v1/translation/languages
+func (c *Client) GetTranslationLanguages(ctx context.Context) ([]TranslationLanguage, error) {
+	path := "/v1/translation/languages"
+	var out []TranslationLanguage
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// GetUsageDevices 按Token用量列出设备
+// 返回指定日期有用量的设备，按 Token 数从多到少排序
+//
+// GET /v1/usage/devices
+func (c *Client) GetUsageDevices(ctx context.Context, params *GetUsageDevicesParams) ([]DeviceTokenUsageInfo, error) {
+	path := "/v1/usage/devices"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out []DeviceTokenUsageInfo
+	err := c.do(ctx, http.MethodGet, path, query, nil, &out)
+	return out, err
+}
+
+// GetUsageDevicesParams GetUsageDevices 的查询参数，零值不发送
+type GetUsageDevicesParams struct {
+	// 日期 YYYY-MM-DD，默认今天
+	Day string
+	// 返回的设备数，默认 100，最多 500
+	Limit int64
+}
+
+func (p *GetUsageDevicesParams) values() url.Values {
+	query := url.Values{}
+	if p.Day != "" {
+		query.Set("day", p.Day)
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return query
+}
+
+// GetUsersByIDDevices 获取用户绑定的设备
+//
+// GET /v1/users/{id}/devices
+func (c *Client) GetUsersByIDDevices(ctx context.Context, id int64) ([]DeviceBindingInfo, error) {
+	path := "/v1/users/" + url.PathEscape(fmt.Sprint(id)) + "/devices"
+	var out []DeviceBindingInfo
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// PostUsersByIDDevices 绑定设备
+// 将已注册的设备绑定给用户。每台设备最多一个所有者（owner），其余为家庭成员（member）；重复绑定时更新角色
+//
+// POST /v1/users/{id}/devices
+func (c *Client) PostUsersByIDDevices(ctx context.Context, id int64, body *BindDeviceRequest) (*DeviceBindingInfo, error) {
+	path := "/v1/users/" + url.PathEscape(fmt.Sprint(id)) + "/devices"
+	var out DeviceBindingInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteUsersByIDDevicesByDeviceID 解除设备绑定
+//
+// DELETE /v1/users/{id}/devices/{device_id}
+func (c *Client) DeleteUsersByIDDevicesByDeviceID(ctx context.Context, id int64, deviceID string) error {
+	path := "/v1/users/" + url.PathEscape(fmt.Sprint(id)) + "/devices/" + url.PathEscape(deviceID)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// DeleteUsersByIDMemories 清空长期记忆
+// 删除用户记忆命名空间中的全部记忆，不影响记忆提取授权
+//
+// DELETE /v1/users/{id}/memories
+func (c *Client) DeleteUsersByIDMemories(ctx context.Context, id int64) (*MemoryClearResponse, error) {
+	path := "/v1/users/" + url.PathEscape(fmt.Sprint(id)) + "/memories"
+	var out MemoryClearResponse
+	if err := c.do(ctx, http.MethodDelete, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUsersByIDMemories 获取用户的长期记忆
+// 列出用户记忆命名空间中的事实及其出处（设备、会话、对话记录和原话），最近更新的在前
+//
+// GET /v1/users/{id}/memories
+func (c *Client) GetUsersByIDMemories(ctx context.Context, id int64, params *GetUsersByIDMemoriesParams) (*MemoryFactListResponse, error) {
+	path := "/v1/users/" + url.PathEscape(fmt.Sprint(id)) + "/memories"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out MemoryFactListResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUsersByIDMemoriesParams GetUsersByIDMemories 的查询参数，零值不发送
+type GetUsersByIDMemoriesParams struct {
+	// 内容关键字
+	Q string
+	// 页码
+	Page int64
+	// 每页数量
+	Limit int64
+}
+
+func (p *GetUsersByIDMemoriesParams) values() url.Values {
+	query := url.Values{}
+	if p.Q != "" {
+		query.Set("q", p.Q)
+	}
+	if p.Page != 0 {
+		query.Set("page", strconv.FormatInt(p.Page, 10))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return query
+}
+
+// PostUsersByIDMemories 添加长期记忆
+// 手动为用户添加一条记忆，不需要记忆提取授权
+//
+// POST /v1/users/{id}/memories
+func (c *Client) PostUsersByIDMemories(ctx context.Context, id int64, body *MemoryFactRequest) (*MemoryFactInfo, error) {
+	path := "/v1/users/" + url.PathEscape(fmt.Sprint(id)) + "/memories"
+	var out MemoryFactInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteUsersByIDMemoriesByFactID 删除长期记忆
+//
+// DELETE /v1/users/{id}/memories/{fact_id}
+func (c *Client) DeleteUsersByIDMemoriesByFactID(ctx context.Context, id int64, factID string) error {
+	path := "/v1/users/" + url.PathEscape(fmt.Sprint(id)) + "/memories/" + url.PathEscape(factID)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// PutUsersByIDMemoriesByFactID 修改长期记忆
+// 修改后记忆的来源记为 manual，保留原始出处
+//
+// PUT /v1/users/{id}/memories/{fact_id}
+func (c *Client) PutUsersByIDMemoriesByFactID(ctx context.Context, id int64, factID string, body *MemoryFactRequest) (*MemoryFactInfo, error) {
+	path := "/v1/users/" + url.PathEscape(fmt.Sprint(id)) + "/memories/" + url.PathEscape(factID)
+	var out MemoryFactInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUsersByIDMemoryConsent 获取记忆提取授权
+//
+// GET /v1/users/{id}/memory-consent
+func (c *Client) GetUsersByIDMemoryConsent(ctx context.Context, id int64) (*MemoryConsentInfo, error) {
+	path := "/v1/users/" + url.PathEscape(fmt.Sprint(id)) + "/memory-consent"
+	var out MemoryConsentInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutUsersByIDMemoryConsent 设置记忆提取授权
+// 授权后从该用户的对话中提取长期记忆；撤销授权时可同时删除已有记忆
+//
+// PUT /v1/users/{id}/memory-consent
+func (c *Client) PutUsersByIDMemoryConsent(ctx context.Context, id int64, body *MemoryConsentRequest) (*MemoryConsentInfo, error) {
+	path := "/v1/users/" + url.PathEscape(fmt.Sprint(id)) + "/memory-consent"
+	var out MemoryConsentInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostUsersByIDPairingTokens 签发配对令牌
+// 为用户签发短时有效的一次性配对令牌，payload 用于生成二维码；设备或配套 App 扫码后调用 /v1/provisioning/claim 完成配对，设备以该用户为所有者注册
+//
+// POST /v1/users/{id}/pairing-tokens
+func (c *Client) PostUsersByIDPairingTokens(ctx context.Context, id int64) (*PairingTokenInfo, error) {
+	path := "/v1/users/" + url.PathEscape(fmt.Sprint(id)) + "/pairing-tokens"
+	var out PairingTokenInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUsersByIDPresence 获取成员的在家状态
+// 没有任何签到或路由器记录时状态为 unknown
+//
+// GET /v1/users/{id}/presence
+func (c *Client) GetUsersByIDPresence(ctx context.Context, id int64) (*PresenceStatus, error) {
+	path := "/v1/users/" + url.PathEscape(fmt.Sprint(id)) + "/presence"
+	var out PresenceStatus
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostUsersByIDPresenceCheckin 配套App签到
+// 上报到家（state=home）或离家（state=away），或上报位置按家的地理围栏判断；位置落在围栏边缘的定位精度范围内时保持原状态。状态变化时发布 presence:arrived 或 presence:left 事件
+//
+// POST /v1/users/{id}/presence/checkin
+func (c *Client) PostUsersByIDPresenceCheckin(ctx context.Context, id int64, body *PresenceCheckInRequest) (*PresenceStatus, error) {
+	path := "/v1/users/" + url.PathEscape(fmt.Sprint(id)) + "/presence/checkin"
+	var out PresenceStatus
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUsersByIDProfile 获取成员资料
+//
+// GET /v1/users/{id}/profile
+func (c *Client) GetUsersByIDProfile(ctx context.Context, id int64) (*MemberProfileInfo, error) {
+	path := "/v1/users/" + url.PathEscape(fmt.Sprint(id)) + "/profile"
+	var out MemberProfileInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutUsersByIDProfile 修改成员资料
+// 称呼用于按名字识别说话人；识别到该成员后使用其偏好音色和语言回复，个人记忆按命名空间隔离
+//
+// PUT /v1/users/{id}/profile
+func (c *Client) PutUsersByIDProfile(ctx context.Context, id int64, body *MemberProfileUpdateRequest) (*MemberProfileInfo, error) {
+	path := "/v1/users/" + url.PathEscape(fmt.Sprint(id)) + "/profile"
+	var out MemberProfileInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetWebhooks 获取推送端点列表
+//
+// GET /v1/webhooks
+func (c *Client) GetWebhooks(ctx context.Context) ([]WebhookInfo, error) {
+	path := "/v1/webhooks"
+	var out []WebhookInfo
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// PostWebhooks 创建推送端点
+// 登记接收地址并订阅设备离线、插件异常、工作流失败或配额用尽事件；推送为 POST JSON，请求头 X-Xiaozhi-Signature 为 t=<unix 秒>,v1=<hex(HMAC-SHA256(secret, "<t>.<body>"))>。未指定签名密钥时自动生成，只在本次响应中返回原值
+//
+// POST /v1/webhooks
+func (c *Client) PostWebhooks(ctx context.Context, body *WebhookCreateRequest) (*WebhookInfo, error) {
+	path := "/v1/webhooks"
+	var out WebhookInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteWebhooksByID 删除推送端点
+// 删除端点及其全部推送记录，尚未推送的事件不再推送
+//
+// DELETE /v1/webhooks/{id}
+func (c *Client) DeleteWebhooksByID(ctx context.Context, id string) error {
+	path := "/v1/webhooks/" + url.PathEscape(id)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// GetWebhooksByID 获取推送端点详情
+//
+// GET /v1/webhooks/{id}
+func (c *Client) GetWebhooksByID(ctx context.Context, id string) (*WebhookInfo, error) {
+	path := "/v1/webhooks/" + url.PathEscape(id)
+	var out WebhookInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutWebhooksByID 更新推送端点
+//
+// PUT /v1/webhooks/{id}
+func (c *Client) PutWebhooksByID(ctx context.Context, id string, body *WebhookUpdateRequest) (*WebhookInfo, error) {
+	path := "/v1/webhooks/" + url.PathEscape(id)
+	var out WebhookInfo
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetWebhooksByIDDeliveries 获取端点的推送记录
+// 按创建时间倒序分页返回推送记录，包含请求体、推送状态和各次尝试的响应
+//
+// GET /v1/webhooks/{id}/deliveries
+func (c *Client) GetWebhooksByIDDeliveries(ctx context.Context, id string, params *GetWebhooksByIDDeliveriesParams) (*WebhookDeliveryListResponse, error) {
+	path := "/v1/webhooks/" + url.PathEscape(id) + "/deliveries"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out WebhookDeliveryListResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetWebhooksByIDDeliveriesParams GetWebhooksByIDDeliveries 的查询参数，零值不发送
+type GetWebhooksByIDDeliveriesParams struct {
+	// 页码
+	Page int64
+	// 每页数量
+	Limit int64
+	// 事件类型
+	Event string
+	// 推送状态
+	Status string
+}
+
+func (p *GetWebhooksByIDDeliveriesParams) values() url.Values {
+	query := url.Values{}
+	if p.Page != 0 {
+		query.Set("page", strconv.FormatInt(p.Page, 10))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	if p.Event != "" {
+		query.Set("event", p.Event)
+	}
+	if p.Status != "" {
+		query.Set("status", p.Status)
+	}
+	return query
+}
+
+// GetWebhooksByIDDeliveriesByDeliveryID 获取推送记录详情
+//
+// GET /v1/webhooks/{id}/deliveries/{delivery_id}
+func (c *Client) GetWebhooksByIDDeliveriesByDeliveryID(ctx context.Context, id string, deliveryID string) (*WebhookDeliveryInfo, error) {
+	path := "/v1/webhooks/" + url.PathEscape(id) + "/deliveries/" + url.PathEscape(deliveryID)
+	var out WebhookDeliveryInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostWebhooksByIDDeliveriesByDeliveryIDRedeliver 重新推送
+// 把已成功或已失败的推送记录重新加入推送队列，请求体和事件ID不变，重试次数重新计算
+//
+// POST /v1/webhooks/{id}/deliveries/{delivery_id}/redeliver
+func (c *Client) PostWebhooksByIDDeliveriesByDeliveryIDRedeliver(ctx context.Context, id string, deliveryID string) (*WebhookDeliveryInfo, error) {
+	path := "/v1/webhooks/" + url.PathEscape(id) + "/deliveries/" + url.PathEscape(deliveryID) + "/redeliver"
+	var out WebhookDeliveryInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostWebhooksByIDTest 推送测试事件
+// 向端点同步推送一条 webhook.ping 事件并返回推送记录，不重试，停用的端点也会推送
+//
+// POST /v1/webhooks/{id}/test
+func (c *Client) PostWebhooksByIDTest(ctx context.Context, id string) (*WebhookDeliveryInfo, error) {
+	path := "/v1/webhooks/" + url.PathEscape(id) + "/test"
+	var out WebhookDeliveryInfo
+	if err := c.do(ctx, http.MethodPost, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostWorkflow Save workflow
+// Saves the workflow of the request's tenant; workflows whose node expressions do not compile are rejected
+//
+// POST /v1/workflow
+func (c *Client) PostWorkflow(ctx context.Context, body *Workflow) (*Workflow, error) {
+	path := "/v1/workflow"
+	var out Workflow
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetWorkflowCapabilities List capabilities
+// Lists every capability registered by builtin providers and plugins
+//
+// GET /v1/workflow/capabilities
+func (c *Client) GetWorkflowCapabilities(ctx context.Context) ([]Definition, error) {
+	path := "/v1/workflow/capabilities"
+	var out []Definition
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// PostWorkflowCapabilitiesByIDExecute Execute a capability
+// Runs one capability outside of any workflow; streaming output is merged into one result. On failure the error details carry the call traces with upstream request IDs
+//
+// POST /v1/workflow/capabilities/{id}/execute
+func (c *Client) PostWorkflowCapabilitiesByIDExecute(ctx context.Context, id string, body *ExecuteCapabilityRequest) (*ExecuteCapabilityResponse, error) {
+	path := "/v1/workflow/capabilities/" + url.PathEscape(id) + "/execute"
+	var out ExecuteCapabilityResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetWorkflowCurrent Get current workflow
+// Returns the workflow of the request's tenant
+//
+// GET /v1/workflow/current
+func (c *Client) GetWorkflowCurrent(ctx context.Context) (*Workflow, error) {
+	path := "/v1/workflow/current"
+	var out Workflow
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetWorkflowNodeTypes List node types
+// Lists builtin workflow node types and the custom node types registered by plugins
+//
+// GET /v1/workflow/node-types
+func (c *Client) GetWorkflowNodeTypes(ctx context.Context) ([]NodeTypeDefinition, error) {
+	path := "/v1/workflow/node-types"
+	var out []NodeTypeDefinition
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// GetWorkflowScheduler Get scheduler stats
+// Returns how many nodes are running and queued per concurrency class and execution
+//
+// GET /v1/workflow/scheduler
+func (c *Client) GetWorkflowScheduler(ctx context.Context) (*SchedulerStats, error) {
+	path := "/v1/workflow/scheduler"
+	var out SchedulerStats
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetWorkflowStartup Get startup workflow
+// Returns the service startup steps as a workflow together with per-step status and timing
+//
+// GET /v1/workflow/startup
+func (c *Client) GetWorkflowStartup(ctx context.Context) (*StartupWorkflowResponse, error) {
+	path := "/v1/workflow/startup"
+	var out StartupWorkflowResponse
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetWorkflowTemplates List workflow templates
+//
+// GET /v1/workflow/templates
+func (c *Client) GetWorkflowTemplates(ctx context.Context) ([]WorkflowTemplate, error) {
+	path := "/v1/workflow/templates"
+	var out []WorkflowTemplate
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	return out, err
+}
+
+// GetWorkflowTemplatesByID Get workflow template
+//
+// GET /v1/workflow/templates/{id}
+func (c *Client) GetWorkflowTemplatesByID(ctx context.Context, id string) (*WorkflowTemplate, error) {
+	path := "/v1/workflow/templates/" + url.PathEscape(id)
+	var out WorkflowTemplate
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostWorkflowTemplatesByIDDeploy Deploy workflow template
+// Instantiates a template and saves it as the current workflow; with dry_run=true the workflow is only returned
+//
+// POST /v1/workflow/templates/{id}/deploy
+func (c *Client) PostWorkflowTemplatesByIDDeploy(ctx context.Context, id string, params *PostWorkflowTemplatesByIDDeployParams, body *DeployTemplateRequest) (*Workflow, error) {
+	path := "/v1/workflow/templates/" + url.PathEscape(id) + "/deploy"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out Workflow
+	if err := c.do(ctx, http.MethodPost, path, query, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostWorkflowTemplatesByIDDeployParams PostWorkflowTemplatesByIDDeploy 的查询参数，零值不发送
+type PostWorkflowTemplatesByIDDeployParams struct {
+	// Return the instantiated workflow without saving it
+	DryRun *bool
+}
+
+func (p *PostWorkflowTemplatesByIDDeployParams) values() url.Values {
+	query := url.Values{}
+	if p.DryRun != nil {
+		query.Set("dry_run", strconv.FormatBool(*p.DryRun))
+	}
+	return query
+}
+
+// PostWorkflowsByIDExecute Execute workflow
+// Starts an execution of the stored (or supplied) workflow and returns it with 202; with dry_run=true returns a workflow.DryRunReport instead
+//
+// POST /v1/workflows/{id}/execute
+func (c *Client) PostWorkflowsByIDExecute(ctx context.Context, id string, params *PostWorkflowsByIDExecuteParams, body *ExecuteWorkflowRequest) (json.RawMessage, error) {
+	path := "/v1/workflows/" + url.PathEscape(id) + "/execute"
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var out json.RawMessage
+	err := c.do(ctx, http.MethodPost, path, query, body, &out)
+	return out, err
+}
+
+// PostWorkflowsByIDExecuteParams PostWorkflowsByIDExecute 的查询参数，零值不发送
+type PostWorkflowsByIDExecuteParams struct {
+	// Only resolve and validate the workflow
+	DryRun *bool
+}
+
+func (p *PostWorkflowsByIDExecuteParams) values() url.Values {
+	query := url.Values{}
+	if p.DryRun != nil {
+		query.Set("dry_run", strconv.FormatBool(*p.DryRun))
+	}
+	return query
+}
+
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+type AgentCreateRequest struct {
+	// 可以委托子任务的智能体名称
+	Delegates []string `json:"delegates,omitempty"`
+	// 用途说明，调度者据此选择委托对象
+	Description string `json:"description,omitempty"`
+	// LLM 配置名称，缺省为默认LLM
+	Model string `json:"model,omitempty"`
+	// 小写字母开头，只含小写字母、数字、_ 和 -
+	Name   string `json:"name"`
+	Prompt string `json:"prompt"`
+	// 允许调用的 MCP 工具
+	Tools []string `json:"tools,omitempty"`
+}
+
+type AgentInfo struct {
+	CreatedAt   string   `json:"created_at,omitempty"`
+	Delegates   []string `json:"delegates,omitempty"`
+	Description string   `json:"description,omitempty"`
+	ID          string   `json:"id,omitempty"`
+	Model       string   `json:"model,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	Prompt      string   `json:"prompt,omitempty"`
+	Tools       []string `json:"tools,omitempty"`
+	UpdatedAt   string   `json:"updated_at,omitempty"`
+}
+
+type AgentRunRequest struct {
+	Input string `json:"input"`
+}
+
+type AgentRunResult struct {
+	Agent string      `json:"agent,omitempty"`
+	Reply string      `json:"reply,omitempty"`
+	Steps []AgentStep `json:"steps,omitempty"`
+}
+
+type AgentStep struct {
+	Agent      string `json:"agent,omitempty"`
+	Arguments  string `json:"arguments,omitempty"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Result     string `json:"result,omitempty"`
+	// tool 或 delegate
+	Type string `json:"type,omitempty"`
+}
+
+type AgentUpdateRequest struct {
+	Delegates   []string `json:"delegates,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Model       string   `json:"model,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	Prompt      string   `json:"prompt,omitempty"`
+	Tools       []string `json:"tools,omitempty"`
+}
+
+type AnalyticsDevice struct {
+	Conversations int64  `json:"conversations,omitempty"`
+	DeviceID      string `json:"device_id,omitempty"`
+	LastActiveAt  string `json:"last_active_at,omitempty"`
+	SpeechMs      int64  `json:"speech_ms,omitempty"`
+	Tokens        int64  `json:"tokens,omitempty"`
+	TTSChars      int64  `json:"tts_chars,omitempty"`
+	Turns         int64  `json:"turns,omitempty"`
+}
+
+type AnalyticsIntent struct {
+	Count  int64 `json:"count,omitempty"`
+	Failed int64 `json:"failed,omitempty"`
+	// 由确定性处理器完成的次数
+	Handled int64  `json:"handled,omitempty"`
+	Intent  string `json:"intent,omitempty"`
+}
+
+type AnalyticsProvider struct {
+	AvgDurationMs float64 `json:"avg_duration_ms,omitempty"`
+	Calls         int64   `json:"calls,omitempty"`
+	Capability    string  `json:"capability,omitempty"`
+	ErrorRate     float64 `json:"error_rate,omitempty"`
+	Errors        int64   `json:"errors,omitempty"`
+	Provider      string  `json:"provider,omitempty"`
+}
+
+type AnalyticsRollup struct {
+	ComputedAt string `json:"computed_at,omitempty"`
+	// 按语句数从多到少
+	Devices []AnalyticsDevice `json:"devices,omitempty"`
+	// 按次数从多到少
+	Intents []AnalyticsIntent `json:"intents,omitempty"`
+	// day/week
+	Period string `json:"period,omitempty"`
+	// 按提供者和能力排列
+	Providers []AnalyticsProvider `json:"providers,omitempty"`
+	// 周期第一天 YYYY-MM-DD
+	Start string          `json:"start,omitempty"`
+	Usage *AnalyticsUsage `json:"usage,omitempty"`
+}
+
+type AnalyticsUsage struct {
+	ActiveDevices int64 `json:"active_devices,omitempty"`
+	// LLM首个输出的平均耗时
+	AvgFirstTokenMs float64 `json:"avg_first_token_ms,omitempty"`
+	// 说话结束到首帧音频下发的平均耗时
+	AvgLatencyMs  float64 `json:"avg_latency_ms,omitempty"`
+	Conversations int64   `json:"conversations,omitempty"`
+	LLMCalls      int64   `json:"llm_calls,omitempty"`
+	// 送入ASR的语音时长
+	SpeechMs int64 `json:"speech_ms,omitempty"`
+	Tokens   int64 `json:"tokens,omitempty"`
+	// 合成播报的字数
+	TTSChars int64 `json:"tts_chars,omitempty"`
+	// 用户语句数
+	Turns int64 `json:"turns,omitempty"`
+}
+
+type ApprovalDecisionRequest struct {
+	Approved bool `json:"approved"`
+	// falls back to the X-Approver header, then the client IP
+	Approver string `json:"approver,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+type ApprovalStatus string
+
+const (
+	ApprovalPending   ApprovalStatus = "pending"
+	ApprovalApproved  ApprovalStatus = "approved"
+	ApprovalRejected  ApprovalStatus = "rejected"
+	ApprovalExpired   ApprovalStatus = "expired"
+	ApprovalCancelled ApprovalStatus = "cancelled"
+)
+
+type ApprovalTask struct {
+	Approver  string `json:"approver,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	// 节点输入，供审批人查看
+	Data        map[string]interface{} `json:"data,omitempty"`
+	DecidedAt   string                 `json:"decided_at,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	ExecutionID string                 `json:"execution_id,omitempty"`
+	ExpiresAt   string                 `json:"expires_at,omitempty"`
+	ID          string                 `json:"id,omitempty"`
+	NodeID      string                 `json:"node_id,omitempty"`
+	OnTimeout   string                 `json:"on_timeout,omitempty"`
+	Status      ApprovalStatus         `json:"status,omitempty"`
+	Title       string                 `json:"title,omitempty"`
+	WorkflowID  string                 `json:"workflow_id,omitempty"`
+}
+
+type BackupInfo struct {
+	// 自动备份，超出保留数量时被清理
+	Auto      bool   `json:"auto,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Size      int64  `json:"size,omitempty"`
+}
+
+type BackupListResponse struct {
+	Backups []BackupInfo `json:"backups,omitempty"`
+	Dir     string       `json:"dir,omitempty"`
+	// 自动备份间隔，为空表示只手动备份
+	Interval string `json:"interval,omitempty"`
+	Keep     int64  `json:"keep,omitempty"`
+	// 备份的文件和目录
+	Paths []string `json:"paths,omitempty"`
+}
+
+type BackupPassphraseRequest struct {
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
+type BackupRestoreResponse struct {
+	Bytes int64 `json:"bytes,omitempty"`
+	// 备份创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 为空表示不含数据库快照
+	Database string `json:"database,omitempty"`
+	Driver   string `json:"driver,omitempty"`
+	Files    int64  `json:"files,omitempty"`
+	Name     string `json:"name,omitempty"`
+	// 恢复在下次启动时生效
+	RestartRequired bool     `json:"restart_required,omitempty"`
+	Roots           []string `json:"roots,omitempty"`
+}
+
+type BackupVerifyResponse struct {
+	Bytes int64 `json:"bytes,omitempty"`
+	// 备份创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 为空表示不含数据库快照
+	Database string   `json:"database,omitempty"`
+	Driver   string   `json:"driver,omitempty"`
+	Files    int64    `json:"files,omitempty"`
+	Name     string   `json:"name,omitempty"`
+	Roots    []string `json:"roots,omitempty"`
+}
+
+type BindDeviceRequest struct {
+	DeviceID string `json:"device_id"`
+	// 默认 member
+	Role string `json:"role,omitempty"`
+}
+
+type BootReport struct {
+	// 决定启动总耗时的步骤链
+	CriticalPath []string `json:"critical_path,omitempty"`
+	// 启动总耗时
+	DurationMs int64  `json:"duration_ms,omitempty"`
+	Error      string `json:"error,omitempty"`
+	// 启动未结束时为空
+	FinishedAt string `json:"finished_at,omitempty"`
+	StartedAt  string `json:"started_at,omitempty"`
+	// running, completed, failed
+	Status string `json:"status,omitempty"`
+	// 各步骤耗时之和，大于总耗时的部分来自并行执行
+	StepTotalMs int64 `json:"step_total_ms,omitempty"`
+	// 按开始时间排序
+	Steps []BootStepTiming `json:"steps,omitempty"`
+}
+
+type BootStepTiming struct {
+	DependsOn  []string `json:"depends_on,omitempty"`
+	DurationMs int64    `json:"duration_ms,omitempty"`
+	Error      string   `json:"error,omitempty"`
+	ID         string   `json:"id,omitempty"`
+	// 相对启动开始的时间
+	OffsetMs int64 `json:"offset_ms,omitempty"`
+	// pending, running, completed, failed, skipped
+	Status    string `json:"status,omitempty"`
+	TimeoutMs int64  `json:"timeout_ms,omitempty"`
+	Title     string `json:"title,omitempty"`
+}
+
+type BudgetLimits struct {
+	DailyTokens int64 `json:"daily_tokens,omitempty"`
+	// 预算名称，没有匹配的预算时为空
+	Profile       string `json:"profile,omitempty"`
+	SessionTokens int64  `json:"session_tokens,omitempty"`
+	TurnTokens    int64  `json:"turn_tokens,omitempty"`
+}
+
+type CapabilityDef struct {
+	ConfigSchema *CapabilitySchema `json:"config_schema,omitempty"`
+	Description  string            `json:"description,omitempty"`
+	Enabled      bool              `json:"enabled,omitempty"`
+	ID           string            `json:"id,omitempty"`
+	InputSchema  *CapabilitySchema `json:"input_schema,omitempty"`
+	Name         string            `json:"name,omitempty"`
+	OutputSchema *CapabilitySchema `json:"output_schema,omitempty"`
+	Type         string            `json:"type,omitempty"`
+}
+
+type CapabilityProperty struct {
+	Default     interface{} `json:"default,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Secret      bool        `json:"secret,omitempty"`
+	Type        string      `json:"type,omitempty"`
+}
+
+type CapabilitySchema struct {
+	Properties map[string]CapabilityProperty `json:"properties,omitempty"`
+	Required   []string                      `json:"required,omitempty"`
+	Type       string                        `json:"type,omitempty"`
+}
+
+type Change struct {
+	Action  Action      `json:"action,omitempty"`
+	After   interface{} `json:"after,omitempty"`
+	Applied bool        `json:"applied,omitempty"`
+	Before  interface{} `json:"before,omitempty"`
+	Error   string      `json:"error,omitempty"`
+	// 更新时值发生变化的字段
+	Fields []string `json:"fields,omitempty"`
+	Kind   string   `json:"kind,omitempty"`
+	Name   string   `json:"name,omitempty"`
+}
+
+type ChaosClearResponse struct {
+	Deleted int64 `json:"deleted,omitempty"`
+}
+
+type ChaosRuleInfo struct {
+	Action      string  `json:"action,omitempty"`
+	CreatedAt   string  `json:"created_at,omitempty"`
+	DelayMs     int64   `json:"delay_ms,omitempty"`
+	ExpiresAt   string  `json:"expires_at,omitempty"`
+	Hits        int64   `json:"hits,omitempty"`
+	ID          string  `json:"id,omitempty"`
+	Match       string  `json:"match,omitempty"`
+	MaxHits     int64   `json:"max_hits,omitempty"`
+	Message     string  `json:"message,omitempty"`
+	Method      string  `json:"method,omitempty"`
+	Probability float64 `json:"probability,omitempty"`
+	StatusCode  int64   `json:"status_code,omitempty"`
+	Target      string  `json:"target,omitempty"`
+}
+
+type ChaosRuleListResponse struct {
+	Rules []ChaosRuleInfo `json:"rules,omitempty"`
+}
+
+type ChaosRuleRequest struct {
+	Action  string `json:"action"`
+	DelayMs int64  `json:"delay_ms,omitempty"`
+	// 提供者或插件ID，支持 * 通配，为空时匹配全部
+	Match string `json:"match,omitempty"`
+	// 注入次数上限，0 表示不限
+	MaxHits int64  `json:"max_hits,omitempty"`
+	Message string `json:"message,omitempty"`
+	// 能力ID、RPC 方法名或请求主机名，支持 * 通配，为空时匹配全部
+	Method string `json:"method,omitempty"`
+	// 缺省为 1
+	Probability float64 `json:"probability,omitempty"`
+	// HTTP error 返回的状态码，缺省 503
+	StatusCode int64 `json:"status_code,omitempty"`
+	// capability 能力调用，grpc 插件 gRPC 调用，http 提供者 HTTP 请求
+	Target string `json:"target"`
+	// 有效期，缺省使用 Chaos.RuleTTL
+	TTLSeconds int64 `json:"ttl_seconds,omitempty"`
+}
+
+type ChatRequest struct {
+	// 关联的设备，在线时进入设备当前的会话
+	DeviceID string `json:"device_id,omitempty"`
+	// 继续之前的会话，为空时按设备生成或新建
+	SessionID string `json:"session_id,omitempty"`
+	// 以SSE逐段推送回复
+	Stream bool   `json:"stream,omitempty"`
+	Text   string `json:"text"`
+}
+
+type ChatResponse struct {
+	// 输入被内容审核拦截，reply 为拦截回复
+	Blocked   bool     `json:"blocked,omitempty"`
+	DeviceID  string   `json:"device_id,omitempty"`
+	Reply     string   `json:"reply,omitempty"`
+	Round     int64    `json:"round,omitempty"`
+	Segments  []string `json:"segments,omitempty"`
+	SessionID string   `json:"session_id,omitempty"`
+}
+
+type CheckResult struct {
+	// 按 Endpoints 改写后实际请求的地址
+	Endpoint  string `json:"endpoint,omitempty"`
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latency_ms,omitempty"`
+	Provider  string `json:"provider,omitempty"`
+	// 经过的代理（隐藏密码），为空表示直连
+	Proxy string `json:"proxy,omitempty"`
+	// 建立连接的对端地址，经过代理时为代理地址
+	RemoteAddr string `json:"remote_addr,omitempty"`
+	StatusCode int64  `json:"status_code,omitempty"`
+	// 收到响应即成功，不判断状态码
+	Success    bool   `json:"success,omitempty"`
+	TlsVersion string `json:"tls_version,omitempty"`
+	// 测试的原始地址
+	URL string `json:"url,omitempty"`
+}
+
+type ClassStats struct {
+	Admitted  int64   `json:"admitted,omitempty"`
+	AvgWaitMs float64 `json:"avg_wait_ms,omitempty"`
+	MaxWaitMs int64   `json:"max_wait_ms,omitempty"`
+	// 队列已满直接拒绝
+	Rejected int64 `json:"rejected,omitempty"`
+	// 排队中被更高优先级挤出
+	Shed int64 `json:"shed,omitempty"`
+	// 排队超时或调用方取消
+	TimedOut int64 `json:"timed_out,omitempty"`
+	Waiting  int64 `json:"waiting,omitempty"`
+}
+
+type ConversationDetailResponse struct {
+	Conversation *ConversationInfo     `json:"conversation,omitempty"`
+	Entries      []TranscriptEntryInfo `json:"entries,omitempty"`
+	// 各轮耗时
+	Latencies []TurnLatencyInfo `json:"latencies,omitempty"`
+}
+
+type ConversationInfo struct {
+	DeviceID  string `json:"device_id,omitempty"`
+	Entries   int64  `json:"entries,omitempty"`
+	LastAt    string `json:"last_at,omitempty"`
+	Rounds    int64  `json:"rounds,omitempty"`
+	SessionID string `json:"session_id,omitempty"`
+	StartedAt string `json:"started_at,omitempty"`
+}
+
+type ConversationListResponse struct {
+	Conversations []ConversationInfo `json:"conversations,omitempty"`
+	Pagination    *Pagination        `json:"pagination,omitempty"`
+}
+
+type DBOperationStats struct {
+	AvgMs  float64 `json:"avg_ms,omitempty"`
+	Count  int64   `json:"count,omitempty"`
+	Errors int64   `json:"errors,omitempty"`
+	// 因 database is locked / busy 失败的次数
+	Locked int64   `json:"locked,omitempty"`
+	MaxMs  float64 `json:"max_ms,omitempty"`
+	// query/create/update/delete/row/raw
+	Operation string `json:"operation,omitempty"`
+}
+
+type DBPoolStats struct {
+	Idle         int64 `json:"idle,omitempty"`
+	InUse        int64 `json:"in_use,omitempty"`
+	MaxOpenConns int64 `json:"max_open_conns,omitempty"`
+	Open         int64 `json:"open,omitempty"`
+	// 等待空闲连接的累计次数
+	WaitCount int64   `json:"wait_count,omitempty"`
+	WaitMs    float64 `json:"wait_ms,omitempty"`
+}
+
+type DBStats struct {
+	Driver      string             `json:"driver,omitempty"`
+	JournalMode string             `json:"journal_mode,omitempty"`
+	Operations  []DBOperationStats `json:"operations,omitempty"`
+	Pool        *DBPoolStats       `json:"pool,omitempty"`
+	Since       string             `json:"since,omitempty"`
+	WriteQueue  *DBWriteQueueStats `json:"write_queue,omitempty"`
+}
+
+type DBWriteQueueStats struct {
+	AvgWaitMs float64 `json:"avg_wait_ms,omitempty"`
+	Enabled   bool    `json:"enabled,omitempty"`
+	MaxWaitMs float64 `json:"max_wait_ms,omitempty"`
+	// 当前排队中的写操作
+	Waiting int64 `json:"waiting,omitempty"`
+	Writes  int64 `json:"writes,omitempty"`
+}
+
+type Definition struct {
+	// Static config (API keys, model selection)
+	ConfigSchema *Schema `json:"config_schema,omitempty"`
+	Description  string  `json:"description,omitempty"`
+	// Unique ID, e.g., "openai_chat"
+	ID string `json:"id,omitempty"`
+	// Runtime inputs (messages, audio bytes)
+	InputSchema *Schema `json:"input_schema,omitempty"`
+	// Human readable name
+	Name string `json:"name,omitempty"`
+	// Runtime outputs (text, audio bytes)
+	OutputSchema *Schema `json:"output_schema,omitempty"`
+	// llm, asr, etc.
+	Type Type `json:"type,omitempty"`
+	// 工作流编辑器的展示提示，主要用于 TypeNode
+	UI *UIHints `json:"ui,omitempty"`
+}
+
+type DeployTemplateRequest struct {
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+type DeviceActivationRequest struct {
+	ActivationCode string                 `json:"activation_code"`
+	Configuration  map[string]interface{} `json:"configuration,omitempty"`
+	DeviceName     string                 `json:"device_name,omitempty"`
+}
+
+type DeviceActivationResponse struct {
+	AccessToken string      `json:"access_token,omitempty"`
+	DeviceInfo  *DeviceInfo `json:"device_info,omitempty"`
+	DeviceToken string      `json:"device_token,omitempty"`
+	ExpiresIn   int64       `json:"expires_in,omitempty"`
+	Message     string      `json:"message,omitempty"`
+	Success     bool        `json:"success,omitempty"`
+}
+
+type DeviceBindingInfo struct {
+	CreatedAt string `json:"created_at,omitempty"`
+	DeviceID  string `json:"device_id,omitempty"`
+	Role      string `json:"role,omitempty"`
+	UserID    int64  `json:"user_id,omitempty"`
+}
+
+type DeviceDataErasureResponse struct {
+	// 按数据类别统计的删除条数，如 recordings、transcripts
+	Deleted  map[string]int64 `json:"deleted,omitempty"`
+	DeviceID string           `json:"device_id,omitempty"`
+}
+
+type DeviceDebugAuditInfo struct {
+	// logs/commands/run/config
+	Action     string `json:"action,omitempty"`
+	ClientIP   string `json:"client_ip,omitempty"`
+	Command    string `json:"command,omitempty"`
+	CreatedAt  string `json:"created_at,omitempty"`
+	DeviceID   string `json:"device_id,omitempty"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+	Error      string `json:"error,omitempty"`
+	ID         string `json:"id,omitempty"`
+	// 发给设备的参数 JSON
+	Params    string `json:"params,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	SessionID string `json:"session_id,omitempty"`
+	Success   bool   `json:"success,omitempty"`
+}
+
+type DeviceDebugAuditListResponse struct {
+	Pagination *Pagination            `json:"pagination,omitempty"`
+	Records    []DeviceDebugAuditInfo `json:"records,omitempty"`
+}
+
+type DeviceDebugConfigRequest struct {
+	// 覆盖的配置项，由固件定义
+	Overrides map[string]interface{} `json:"overrides"`
+	// 有效期，缺省或超过上限时使用 DeviceDebug.MaxOverrideTTL
+	TTLSeconds int64 `json:"ttl_seconds,omitempty"`
+}
+
+type DeviceDebugResult struct {
+	AuditID    string `json:"audit_id,omitempty"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+	// 设备返回的结果，原样透传
+	Result    interface{} `json:"result,omitempty"`
+	SessionID string      `json:"session_id,omitempty"`
+}
+
+type DeviceDebugRunRequest struct {
+	// 命令参数，由固件定义
+	Args map[string]interface{} `json:"args,omitempty"`
+}
+
+type DeviceGroup struct {
+	Devices []string `json:"devices,omitempty"`
+	Name    string   `json:"name,omitempty"`
+	Prompt  string   `json:"prompt,omitempty"`
+	// 0 表示跟随当前生效版本
+	PromptVersion int64 `json:"prompt_version,omitempty"`
+}
+
+type DeviceInfo struct {
+	Configuration map[string]interface{} `json:"configuration,omitempty"`
+	CreatedAt     string                 `json:"created_at,omitempty"`
+	DeviceID      string                 `json:"device_id,omitempty"`
+	DeviceName    string                 `json:"device_name,omitempty"`
+	DeviceType    string                 `json:"device_type,omitempty"`
+	Firmware      *FirmwareInfo          `json:"firmware,omitempty"`
+	ID            int64                  `json:"id,omitempty"`
+	IsActivated   bool                   `json:"is_activated,omitempty"`
+	IsActive      bool                   `json:"is_active,omitempty"`
+	LastSeen      string                 `json:"last_seen,omitempty"`
+	Location      *DeviceLocation        `json:"location,omitempty"`
+	// 设备正在播放或暂停中的媒体，仅设备详情返回
+	Media    *MediaState            `json:"media,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Model    string                 `json:"model,omitempty"`
+	// online, offline, error, unknown
+	Status    string `json:"status,omitempty"`
+	TenantID  string `json:"tenant_id,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+	Version   string `json:"version,omitempty"`
+}
+
+type DeviceKeyInfo struct {
+	CreatedAt string `json:"created_at,omitempty"`
+	DeviceID  string `json:"device_id,omitempty"`
+	ID        string `json:"id,omitempty"`
+	// 最近一次通过握手校验的时间
+	LastUsedAt string `json:"last_used_at,omitempty"`
+	PublicKey  string `json:"public_key,omitempty"`
+	Revoked    bool   `json:"revoked,omitempty"`
+	RevokedAt  string `json:"revoked_at,omitempty"`
+}
+
+type DeviceKeyIssueRequest struct {
+	// 同时吊销设备的其他密钥
+	RevokePrevious bool `json:"revoke_previous,omitempty"`
+}
+
+type DeviceLanguagesInfo struct {
+	// 为 false 时是配置的默认值
+	Custom   bool   `json:"custom,omitempty"`
+	DeviceID string `json:"device_id,omitempty"`
+	// 第一个为主要语言，使用设备默认音色
+	Languages []string `json:"languages,omitempty"`
+	// 使用默认值时为空
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+type DeviceLanguagesRequest struct {
+	// 语言代码或中文叫法，第一个为主要语言
+	Languages []string `json:"languages"`
+}
+
+type DeviceListResponse struct {
+	Devices    []DeviceInfo `json:"devices,omitempty"`
+	Pagination *Pagination  `json:"pagination,omitempty"`
+}
+
+type DeviceLocation struct {
+	Address   string  `json:"address,omitempty"`
+	Altitude  float64 `json:"altitude,omitempty"`
+	City      string  `json:"city,omitempty"`
+	Country   string  `json:"country,omitempty"`
+	Latitude  float64 `json:"latitude,omitempty"`
+	Longitude float64 `json:"longitude,omitempty"`
+	Province  string  `json:"province,omitempty"`
+}
+
+type DeviceMemberInfo struct {
+	CreatedAt string             `json:"created_at,omitempty"`
+	DeviceID  string             `json:"device_id,omitempty"`
+	Profile   *MemberProfileInfo `json:"profile,omitempty"`
+	Role      string             `json:"role,omitempty"`
+	UserID    int64              `json:"user_id,omitempty"`
+}
+
+type DeviceRegistrationRequest struct {
+	DeviceID   string                 `json:"device_id"`
+	DeviceName string                 `json:"device_name"`
+	DeviceType string                 `json:"device_type"`
+	Location   *DeviceLocation        `json:"location,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	Model      string                 `json:"model,omitempty"`
+	Version    string                 `json:"version,omitempty"`
+}
+
+type DeviceStatusRequest struct {
+	// 设备MAC地址
+	DeviceID string `json:"device_id"`
+	// 激活状态：true激活，false禁用
+	IsActive bool `json:"is_active"`
+}
+
+type DeviceStatusResponse struct {
+	DeviceInfo *DeviceInfo `json:"device_info,omitempty"`
+	Message    string      `json:"message,omitempty"`
+	Success    bool        `json:"success,omitempty"`
+}
+
+type DeviceTokenUsageInfo struct {
+	// LLM调用次数
+	Calls    int64  `json:"calls,omitempty"`
+	Day      string `json:"day,omitempty"`
+	DeviceID string `json:"device_id,omitempty"`
+	// 是否启用预算检查
+	Enabled bool          `json:"enabled,omitempty"`
+	Limits  *BudgetLimits `json:"limits,omitempty"`
+	// 因超出预算未调用LLM的次数
+	Rejected int64 `json:"rejected,omitempty"`
+	// 当天剩余的Token，未设置每日上限时为空
+	Remaining int64 `json:"remaining,omitempty"`
+	Tokens    int64 `json:"tokens,omitempty"`
+}
+
+type DeviceUpdateRequest struct {
+	Configuration map[string]interface{} `json:"configuration,omitempty"`
+	DeviceName    string                 `json:"device_name,omitempty"`
+	IsActive      bool                   `json:"is_active,omitempty"`
+	Location      *DeviceLocation        `json:"location,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	// 转移到其他租户，仅管理员可用
+	TenantID string `json:"tenant_id,omitempty"`
+}
+
+type DrainRequest struct {
+	// 排空原因，记录到日志
+	Reason string `json:"reason,omitempty"`
+	// 等待活跃会话结束的最长秒数，0 表示使用配置值
+	TimeoutSeconds int64 `json:"timeout_seconds,omitempty"`
+}
+
+type DrainStatus struct {
+	// 各类型活跃工作数：sessions、workflows
+	Active   map[string]int64 `json:"active,omitempty"`
+	Deadline string           `json:"deadline,omitempty"`
+	Reason   string           `json:"reason,omitempty"`
+	// 距截止时间的秒数
+	RemainingSeconds int64  `json:"remaining_seconds,omitempty"`
+	StartedAt        string `json:"started_at,omitempty"`
+	// serving, draining, drained
+	State    string `json:"state,omitempty"`
+	TimedOut bool   `json:"timed_out,omitempty"`
+}
+
+type DryRunIssue struct {
+	EdgeID   string `json:"edge_id,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Message  string `json:"message,omitempty"`
+	NodeID   string `json:"node_id,omitempty"`
+	Severity string `json:"severity,omitempty"`
+}
+
+type DryRunNode struct {
+	CapabilityID string `json:"capability_id,omitempty"`
+	// 合并全局配置和默认值后的配置，敏感字段已脱敏
+	Config     map[string]interface{} `json:"config,omitempty"`
+	Issues     []DryRunIssue          `json:"issues,omitempty"`
+	NodeID     string                 `json:"node_id,omitempty"`
+	ProviderID string                 `json:"provider_id,omitempty"`
+	Type       NodeType               `json:"type,omitempty"`
+}
+
+type DryRunReport struct {
+	// 全部问题，包括节点和边上的问题
+	Issues []DryRunIssue `json:"issues,omitempty"`
+	Nodes  []DryRunNode  `json:"nodes,omitempty"`
+	// 拓扑执行顺序
+	Order []string `json:"order,omitempty"`
+	// 没有 error 级别的问题
+	Valid      bool   `json:"valid,omitempty"`
+	WorkflowID string `json:"workflow_id,omitempty"`
+}
+
+type Duration int64
+
+type Edge struct {
+	From   string `json:"from,omitempty"`
+	ID     string `json:"id,omitempty"`
+	Label  string `json:"label,omitempty"`
+	To     string `json:"to,omitempty"`
+	Weight int64  `json:"weight,omitempty"`
+}
+
+type Entry struct {
+	Level    string `json:"level,omitempty"`
+	Message  string `json:"message,omitempty"`
+	PluginID string `json:"plugin_id,omitempty"`
+	Seq      int64  `json:"seq,omitempty"`
+	Stream   string `json:"stream,omitempty"`
+	Time     string `json:"time,omitempty"`
+}
+
+type EvalCase struct {
+	// ASR 音频，base64 编码
+	Audio string `json:"audio,omitempty"`
+	// 期望回答或参考文本
+	Expected string `json:"expected,omitempty"`
+	ID       string `json:"id,omitempty"`
+	// LLM 提示词或 TTS 文本
+	Input string `json:"input,omitempty"`
+}
+
+type EvalCaseComparison struct {
+	CaseID   string `json:"case_id,omitempty"`
+	Expected string `json:"expected,omitempty"`
+	Input    string `json:"input,omitempty"`
+	// key: 运行ID/能力ID
+	Results map[string]EvalCaseResult `json:"results,omitempty"`
+}
+
+type EvalCaseResult struct {
+	CaseID     string  `json:"case_id,omitempty"`
+	Error      string  `json:"error,omitempty"`
+	JudgeScore float64 `json:"judge_score,omitempty"`
+	LatencyMs  int64   `json:"latency_ms,omitempty"`
+	Output     string  `json:"output,omitempty"`
+	Similarity float64 `json:"similarity,omitempty"`
+	Wer        float64 `json:"wer,omitempty"`
+}
+
+type EvalCompareResponse struct {
+	Cases   []EvalCaseComparison `json:"cases,omitempty"`
+	Runs    []EvalRunInfo        `json:"runs,omitempty"`
+	SuiteID string               `json:"suite_id,omitempty"`
+}
+
+type EvalRunInfo struct {
+	CreatedAt  string             `json:"created_at,omitempty"`
+	Error      string             `json:"error,omitempty"`
+	FinishedAt string             `json:"finished_at,omitempty"`
+	ID         string             `json:"id,omitempty"`
+	Judge      *EvalTarget        `json:"judge,omitempty"`
+	Reports    []EvalTargetReport `json:"reports,omitempty"`
+	Status     string             `json:"status,omitempty"`
+	SuiteID    string             `json:"suite_id,omitempty"`
+	SuiteType  string             `json:"suite_type,omitempty"`
+	Targets    []EvalTarget       `json:"targets,omitempty"`
+}
+
+type EvalRunListResponse struct {
+	Pagination *Pagination   `json:"pagination,omitempty"`
+	Runs       []EvalRunInfo `json:"runs,omitempty"`
+}
+
+type EvalRunRequest struct {
+	// LLM 评审，仅 llm 测试集可用
+	Judge   *EvalTarget  `json:"judge,omitempty"`
+	SuiteID string       `json:"suite_id"`
+	Targets []EvalTarget `json:"targets"`
+}
+
+type EvalSuiteCreateRequest struct {
+	Cases        []EvalCase `json:"cases"`
+	Description  string     `json:"description,omitempty"`
+	Name         string     `json:"name"`
+	SystemPrompt string     `json:"system_prompt,omitempty"`
+	Type         string     `json:"type"`
+}
+
+type EvalSuiteInfo struct {
+	CaseCount    int64      `json:"case_count,omitempty"`
+	Cases        []EvalCase `json:"cases,omitempty"`
+	CreatedAt    string     `json:"created_at,omitempty"`
+	Description  string     `json:"description,omitempty"`
+	ID           string     `json:"id,omitempty"`
+	Name         string     `json:"name,omitempty"`
+	SystemPrompt string     `json:"system_prompt,omitempty"`
+	Type         string     `json:"type,omitempty"`
+}
+
+type EvalSuiteListResponse struct {
+	Pagination *Pagination     `json:"pagination,omitempty"`
+	Suites     []EvalSuiteInfo `json:"suites,omitempty"`
+}
+
+type EvalTarget struct {
+	CapabilityID string                 `json:"capability_id"`
+	Config       map[string]interface{} `json:"config,omitempty"`
+}
+
+type EvalTargetReport struct {
+	AvgJudgeScore float64          `json:"avg_judge_score,omitempty"`
+	AvgLatencyMs  float64          `json:"avg_latency_ms,omitempty"`
+	AvgSimilarity float64          `json:"avg_similarity,omitempty"`
+	AvgWer        float64          `json:"avg_wer,omitempty"`
+	CapabilityID  string           `json:"capability_id,omitempty"`
+	Cases         []EvalCaseResult `json:"cases,omitempty"`
+	P95LatencyMs  int64            `json:"p95_latency_ms,omitempty"`
+	Succeeded     int64            `json:"succeeded,omitempty"`
+	Total         int64            `json:"total,omitempty"`
+}
+
+type ExecuteCapabilityRequest struct {
+	Config map[string]interface{} `json:"config,omitempty"`
+	Inputs map[string]interface{} `json:"inputs,omitempty"`
+	// repair attempts, default 2, at most 5
+	MaxRepairs int64 `json:"max_repairs,omitempty"`
+	// ResponseFormat requests JSON output from an LLM capability: {"type": "json_object"} or
+	// {"type": "json_schema", "name": "...", "schema": {...}}; invalid output is sent back to the LLM for repair
+	ResponseFormat map[string]interface{} `json:"response_format,omitempty"`
+	// e.g. 30s, at most 5m
+	Timeout string `json:"timeout,omitempty"`
+}
+
+type ExecuteCapabilityResponse struct {
+	// generations including repairs when response_format is set
+	Attempts     int64  `json:"attempts,omitempty"`
+	CapabilityID string `json:"capability_id,omitempty"`
+	// streamed chunks, 0 for non-streaming calls
+	Chunks    int64 `json:"chunks,omitempty"`
+	ElapsedMs int64 `json:"elapsed_ms,omitempty"`
+	// time to the first chunk
+	FirstChunkMs int64 `json:"first_chunk_ms,omitempty"`
+	// parsed output when response_format is set
+	JSON    interface{}            `json:"json,omitempty"`
+	Outputs map[string]interface{} `json:"outputs,omitempty"`
+	// provider and upstream request IDs of every call made
+	Traces []Trace `json:"traces,omitempty"`
+}
+
+type ExecuteWorkflowRequest struct {
+	Inputs map[string]interface{} `json:"inputs,omitempty"`
+	// unsaved workflow to execute or validate instead of the stored one
+	Workflow *Workflow `json:"workflow,omitempty"`
+}
+
+type Execution struct {
+	// 执行上下文
+	Context map[string]interface{} `json:"context,omitempty"`
+	EndTime string                 `json:"end_time,omitempty"`
+	// 执行错误
+	Error string `json:"error,omitempty"`
+	ID    string `json:"id,omitempty"`
+	// 输入参数
+	Inputs map[string]interface{} `json:"inputs,omitempty"`
+	// 执行日志
+	Logs []ExecutionLog `json:"logs,omitempty"`
+	// 节点执行结果
+	NodeResults map[string]NodeResult `json:"node_results,omitempty"`
+	// 输出结果
+	Outputs map[string]interface{} `json:"outputs,omitempty"`
+	// 发起执行的请求关联ID
+	RequestID string          `json:"request_id,omitempty"`
+	StartTime string          `json:"start_time,omitempty"`
+	Status    ExecutionStatus `json:"status,omitempty"`
+	// 发起执行的租户
+	TenantID   string `json:"tenant_id,omitempty"`
+	WorkflowID string `json:"workflow_id,omitempty"`
+}
+
+type ExecutionLog struct {
+	Details map[string]interface{} `json:"details,omitempty"`
+	// info, warn, error
+	Level     string `json:"level,omitempty"`
+	Message   string `json:"message,omitempty"`
+	NodeID    string `json:"node_id,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+}
+
+type ExecutionStatus string
+
+const (
+	ExecutionStatusPending   ExecutionStatus = "pending"
+	ExecutionStatusRunning   ExecutionStatus = "running"
+	ExecutionStatusPaused    ExecutionStatus = "paused"
+	ExecutionStatusCompleted ExecutionStatus = "completed"
+	ExecutionStatusFailed    ExecutionStatus = "failed"
+	ExecutionStatusCancelled ExecutionStatus = "cancelled"
+)
+
+type ExperimentCreateRequest struct {
+	Cohort         []string            `json:"cohort,omitempty"`
+	Description    string              `json:"description,omitempty"`
+	Name           string              `json:"name"`
+	TrafficPercent int64               `json:"traffic_percent,omitempty"`
+	Variants       []ExperimentVariant `json:"variants"`
+}
+
+type ExperimentInfo struct {
+	Cohort         []string            `json:"cohort,omitempty"`
+	CreatedAt      string              `json:"created_at,omitempty"`
+	Description    string              `json:"description,omitempty"`
+	ID             string              `json:"id,omitempty"`
+	Name           string              `json:"name,omitempty"`
+	StartedAt      string              `json:"started_at,omitempty"`
+	Status         string              `json:"status,omitempty"`
+	StoppedAt      string              `json:"stopped_at,omitempty"`
+	TrafficPercent int64               `json:"traffic_percent,omitempty"`
+	Variants       []ExperimentVariant `json:"variants,omitempty"`
+}
+
+type ExperimentListResponse struct {
+	Experiments []ExperimentInfo `json:"experiments,omitempty"`
+	Pagination  *Pagination      `json:"pagination,omitempty"`
+}
+
+type ExperimentResultsResponse struct {
+	ExperimentID string                    `json:"experiment_id,omitempty"`
+	Name         string                    `json:"name,omitempty"`
+	Status       string                    `json:"status,omitempty"`
+	Variants     []ExperimentVariantResult `json:"variants,omitempty"`
+}
+
+type ExperimentVariant struct {
+	CostPer1kToken float64 `json:"cost_per_1k_token,omitempty"`
+	LLM            string  `json:"llm,omitempty"`
+	Name           string  `json:"name"`
+	PromptTemplate string  `json:"prompt_template,omitempty"`
+	PromptVersion  int64   `json:"prompt_version,omitempty"`
+	Weight         int64   `json:"weight,omitempty"`
+}
+
+type ExperimentVariantResult struct {
+	AvgLatencyMs     float64 `json:"avg_latency_ms,omitempty"`
+	AvgTokens        float64 `json:"avg_tokens,omitempty"`
+	CompletionTokens int64   `json:"completion_tokens,omitempty"`
+	EstimatedCost    float64 `json:"estimated_cost,omitempty"`
+	InterruptionRate float64 `json:"interruption_rate,omitempty"`
+	PromptTokens     int64   `json:"prompt_tokens,omitempty"`
+	Samples          int64   `json:"samples,omitempty"`
+	SatisfactionRate float64 `json:"satisfaction_rate,omitempty"`
+	ThumbsDown       int64   `json:"thumbs_down,omitempty"`
+	ThumbsUp         int64   `json:"thumbs_up,omitempty"`
+	Variant          string  `json:"variant,omitempty"`
+}
+
+type FeedbackDailyInfo struct {
+	Date       string `json:"date,omitempty"`
+	ThumbsDown int64  `json:"thumbs_down,omitempty"`
+	ThumbsUp   int64  `json:"thumbs_up,omitempty"`
+}
+
+type FeedbackInfo struct {
+	Comment   string `json:"comment,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	DeviceID  string `json:"device_id,omitempty"`
+	EntryID   string `json:"entry_id,omitempty"`
+	ID        string `json:"id,omitempty"`
+	Rating    string `json:"rating,omitempty"`
+	Round     int64  `json:"round,omitempty"`
+	SessionID string `json:"session_id,omitempty"`
+	Source    string `json:"source,omitempty"`
+}
+
+type FeedbackListResponse struct {
+	Feedback   []FeedbackInfo `json:"feedback,omitempty"`
+	Pagination *Pagination    `json:"pagination,omitempty"`
+}
+
+type FeedbackRequest struct {
+	Comment string `json:"comment,omitempty"`
+	// up/down，可为空仅提交文字
+	Rating string `json:"rating,omitempty"`
+}
+
+type FeedbackStatsResponse struct {
+	BySource         map[string]int64    `json:"by_source,omitempty"`
+	Daily            []FeedbackDailyInfo `json:"daily,omitempty"`
+	From             string              `json:"from,omitempty"`
+	SatisfactionRate float64             `json:"satisfaction_rate,omitempty"`
+	ThumbsDown       int64               `json:"thumbs_down,omitempty"`
+	ThumbsUp         int64               `json:"thumbs_up,omitempty"`
+	To               string              `json:"to,omitempty"`
+	Total            int64               `json:"total,omitempty"`
+	WithComment      int64               `json:"with_comment,omitempty"`
+}
+
+type FirmwareInfo struct {
+	Checksum      string `json:"checksum,omitempty"`
+	Description   string `json:"description,omitempty"`
+	DownloadCount int64  `json:"download_count,omitempty"`
+	ReleaseDate   string `json:"release_date,omitempty"`
+	Size          int64  `json:"size,omitempty"`
+	URL           string `json:"url,omitempty"`
+	Version       string `json:"version,omitempty"`
+}
+
+type FlagDecision struct {
+	DeviceID string `json:"device_id,omitempty"`
+	Enabled  bool   `json:"enabled,omitempty"`
+	Key      string `json:"key,omitempty"`
+	// 命中的规则名称，未命名时为 "#序号"
+	Rule string `json:"rule,omitempty"`
+	// rule / flag / default / unknown
+	Source   string `json:"source,omitempty"`
+	TenantID string `json:"tenant_id,omitempty"`
+}
+
+type FlagInfo struct {
+	// 内置开关，删除后恢复默认值
+	BuiltIn     bool       `json:"built_in,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Enabled     bool       `json:"enabled,omitempty"`
+	Key         string     `json:"key,omitempty"`
+	Rules       []FlagRule `json:"rules,omitempty"`
+	// 为 false 时是内置开关的默认值
+	Stored bool `json:"stored,omitempty"`
+	// 未修改过的内置开关为空
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+type FlagListResponse struct {
+	Flags []FlagInfo `json:"flags,omitempty"`
+}
+
+type FlagRule struct {
+	// 设备ID，空表示租户下的全部设备
+	Devices []string `json:"devices,omitempty"`
+	Enabled bool     `json:"enabled,omitempty"`
+	// 设备分组名称
+	Name string `json:"name,omitempty"`
+	// 租户ID，空表示任意租户
+	Tenants []string `json:"tenants,omitempty"`
+}
+
+type FlagSetRequest struct {
+	Description string `json:"description,omitempty"`
+	// 没有匹配的规则时的取值
+	Enabled bool `json:"enabled,omitempty"`
+	// 按顺序匹配，第一条匹配的规则决定取值
+	Rules []FlagRule `json:"rules,omitempty"`
+}
+
+type InputSchema struct {
+	Default     interface{} `json:"default,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Name        string      `json:"name,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	// string, number, boolean, object, array
+	Type       string      `json:"type,omitempty"`
+	Validation *Validation `json:"validation,omitempty"`
+}
+
+type IntentRouteStats struct {
+	AverageLatencyMs float64 `json:"average_latency_ms,omitempty"`
+	Errors           int64   `json:"errors,omitempty"`
+	// 回落到LLM的次数
+	FallbackToLLM int64 `json:"fallback_to_llm,omitempty"`
+	// 由确定性处理器完成的次数
+	Handled int64  `json:"handled,omitempty"`
+	Intent  string `json:"intent,omitempty"`
+	// 分类命中次数
+	Matched int64 `json:"matched,omitempty"`
+}
+
+type IssuedDeviceKeyInfo struct {
+	Algorithm string `json:"algorithm,omitempty"`
+	KeyID     string `json:"key_id,omitempty"`
+	// PEM 编码的 P-256 私钥
+	PrivateKey string `json:"private_key,omitempty"`
+	PublicKey  string `json:"public_key,omitempty"`
+}
+
+type JobInfo struct {
+	Attempts    int64       `json:"attempts,omitempty"`
+	CreatedAt   string      `json:"created_at,omitempty"`
+	FinishedAt  string      `json:"finished_at,omitempty"`
+	ID          string      `json:"id,omitempty"`
+	Key         string      `json:"key,omitempty"`
+	LastError   string      `json:"last_error,omitempty"`
+	LeaseUntil  string      `json:"lease_until,omitempty"`
+	MaxAttempts int64       `json:"max_attempts,omitempty"`
+	Payload     interface{} `json:"payload,omitempty"`
+	// 最早可执行时间，等待重试时为退避后的时间
+	RunAt     string `json:"run_at,omitempty"`
+	StartedAt string `json:"started_at,omitempty"`
+	// pending、running、succeeded、dead（死信）或 canceled
+	Status    string `json:"status,omitempty"`
+	Type      string `json:"type,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+type JobListResponse struct {
+	Jobs       []JobInfo   `json:"jobs,omitempty"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+type JobStatsResponse struct {
+	Types []JobTypeStats `json:"types,omitempty"`
+}
+
+type JobTypeStats struct {
+	// 各状态的任务数
+	Counts map[string]int64 `json:"counts,omitempty"`
+	// 本实例启动以来失败的执行次数
+	Failures int64 `json:"failures,omitempty"`
+	// 本实例执行中的任务数
+	Running int64  `json:"running,omitempty"`
+	Type    string `json:"type,omitempty"`
+	// 本实例的工作协程数
+	Workers int64 `json:"workers,omitempty"`
+}
+
+type KnowledgeBaseCreateRequest struct {
+	Description string `json:"description,omitempty"`
+	Name        string `json:"name"`
+}
+
+type KnowledgeBaseInfo struct {
+	CreatedAt   string `json:"created_at,omitempty"`
+	Description string `json:"description,omitempty"`
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	UpdatedAt   string `json:"updated_at,omitempty"`
+}
+
+type KnowledgeBaseUpdateRequest struct {
+	Description string `json:"description,omitempty"`
+	Name        string `json:"name,omitempty"`
+}
+
+type KnowledgeBindingInfo struct {
+	KnowledgeBaseIds []string `json:"knowledge_base_ids,omitempty"`
+	Profile          string   `json:"profile,omitempty"`
+}
+
+type KnowledgeBindingRequest struct {
+	// 为空时解除绑定
+	KnowledgeBaseIds []string `json:"knowledge_base_ids,omitempty"`
+}
+
+type KnowledgeDocumentInfo struct {
+	Chunks    int64  `json:"chunks,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	// 生成向量使用的能力ID
+	Embedding string `json:"embedding,omitempty"`
+	Error     string `json:"error,omitempty"`
+	// 导入任务的工作流执行ID
+	ExecutionID string `json:"execution_id,omitempty"`
+	// pdf/markdown/text/html
+	Format          string `json:"format,omitempty"`
+	ID              string `json:"id,omitempty"`
+	KnowledgeBaseID string `json:"knowledge_base_id,omitempty"`
+	Name            string `json:"name,omitempty"`
+	// 上传文件在对象存储中的ID，可通过 /api/v1/objects/:id/url 获取下载地址
+	ObjectID string `json:"object_id,omitempty"`
+	// 0-100
+	Progress int64 `json:"progress,omitempty"`
+	Size     int64 `json:"size,omitempty"`
+	// 文件名或网页地址
+	Source string `json:"source,omitempty"`
+	// file/url
+	SourceType string `json:"source_type,omitempty"`
+	// pending/extracting/chunking/embedding/ready/failed
+	Status    string `json:"status,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+type KnowledgeHit struct {
+	ChunkIndex      int64   `json:"chunk_index,omitempty"`
+	Content         string  `json:"content,omitempty"`
+	DocumentID      string  `json:"document_id,omitempty"`
+	DocumentName    string  `json:"document_name,omitempty"`
+	KnowledgeBaseID string  `json:"knowledge_base_id,omitempty"`
+	Score           float64 `json:"score,omitempty"`
+}
+
+type KnowledgeSearchRequest struct {
+	// 检索设备所属档案绑定的知识库
+	DeviceID         string   `json:"device_id,omitempty"`
+	KnowledgeBaseIds []string `json:"knowledge_base_ids,omitempty"`
+	Query            string   `json:"query"`
+	TopK             int64    `json:"top_k,omitempty"`
+}
+
+type KnowledgeSearchResponse struct {
+	// 附加给 LLM 的带出处编号的参考资料
+	Context string         `json:"context,omitempty"`
+	Hits    []KnowledgeHit `json:"hits,omitempty"`
+}
+
+type KnowledgeURLRequest struct {
+	// 缺省使用网页地址
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url"`
+}
+
+type LanguageInfo struct {
+	Code string `json:"code,omitempty"`
+	Name string `json:"name,omitempty"`
+	// 该语言的播报音色，未配置时使用设备默认音色
+	Voice string `json:"voice,omitempty"`
+}
+
+type LanguageListResponse struct {
+	// 未单独设置的设备允许的语言
+	Allowed []string `json:"allowed,omitempty"`
+	// 是否启用语言自动识别
+	Enabled bool `json:"enabled,omitempty"`
+	// 支持的语言
+	Languages []LanguageInfo `json:"languages,omitempty"`
+}
+
+type LatencyDailyInfo struct {
+	Date       string `json:"date,omitempty"`
+	P50TotalMs int64  `json:"p50_total_ms,omitempty"`
+	P95TotalMs int64  `json:"p95_total_ms,omitempty"`
+	Turns      int64  `json:"turns,omitempty"`
+}
+
+type LatencyStatsResponse struct {
+	Daily  []LatencyDailyInfo `json:"daily,omitempty"`
+	From   string             `json:"from,omitempty"`
+	Stages []StageLatencyInfo `json:"stages,omitempty"`
+	To     string             `json:"to,omitempty"`
+	Turns  int64              `json:"turns,omitempty"`
+}
+
+type LogEntryInfo struct {
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+	Level   string                 `json:"level,omitempty"`
+	Message string                 `json:"message,omitempty"`
+	Tag     string                 `json:"tag,omitempty"`
+	Time    string                 `json:"time,omitempty"`
+}
+
+type LogListResponse struct {
+	// 启动以来因队列已满或写入失败未进入索引的日志条数
+	Dropped    int64          `json:"dropped,omitempty"`
+	Entries    []LogEntryInfo `json:"entries,omitempty"`
+	Pagination *Pagination    `json:"pagination,omitempty"`
+}
+
+type Manifest struct {
+	// DeviceGroups 设备分组，组内设备分配同一个提示词模板
+	DeviceGroups []DeviceGroup `json:"device_groups,omitempty"`
+	// Prompts 提示词模板，与当前生效版本比较，修改时生成新版本
+	Prompts []PromptSpec `json:"prompts,omitempty"`
+	// Providers 按类型（llm/tts/asr/vllm）列出供应商配置，键为供应商ID；只对出现的类型做增删
+	Providers map[string]map[string][]int64 `json:"providers,omitempty"`
+	// Workflow 当前工作流
+	Workflow *Workflow `json:"workflow,omitempty"`
+}
+
+type MediaControlRequest struct {
+	Action string `json:"action"`
+	// action 为 play 时点播的内容，为空时随机播放
+	Query string `json:"query,omitempty"`
+	// action 为 play 时的检索来源
+	Source string `json:"source,omitempty"`
+	// action 为 volume 时设置的音量
+	Volume int64 `json:"volume,omitempty"`
+}
+
+type MediaState struct {
+	DeviceID string `json:"device_id,omitempty"`
+	// 最近一次播放失败的原因
+	Error string `json:"error,omitempty"`
+	// 当前曲目在播放列表中的位置
+	Index      int64 `json:"index,omitempty"`
+	PositionMs int64 `json:"position_ms,omitempty"`
+	// 播放列表长度
+	QueueSize int64 `json:"queue_size,omitempty"`
+	// idle/loading/playing/paused
+	Status    string      `json:"status,omitempty"`
+	Track     *MediaTrack `json:"track,omitempty"`
+	UpdatedAt string      `json:"updated_at,omitempty"`
+	// 0-100
+	Volume int64 `json:"volume,omitempty"`
+}
+
+type MediaTrack struct {
+	Artist string `json:"artist,omitempty"`
+	Live   bool   `json:"live,omitempty"`
+	// library/radio/provider
+	Source string `json:"source,omitempty"`
+	Title  string `json:"title,omitempty"`
+	// 电台和媒体能力返回的音频流地址，本地曲库不返回
+	URL string `json:"url,omitempty"`
+}
+
+type MemberProfileInfo struct {
+	Language        string `json:"language,omitempty"`
+	MemoryNamespace string `json:"memory_namespace,omitempty"`
+	Name            string `json:"name,omitempty"`
+	UpdatedAt       string `json:"updated_at,omitempty"`
+	UserID          int64  `json:"user_id,omitempty"`
+	Voice           string `json:"voice,omitempty"`
+}
+
+type MemberProfileUpdateRequest struct {
+	Language string `json:"language,omitempty"`
+	// 置空时恢复默认的 user-<id>
+	MemoryNamespace string `json:"memory_namespace,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Voice           string `json:"voice,omitempty"`
+}
+
+type MemoryClearResponse struct {
+	Deleted int64 `json:"deleted,omitempty"`
+}
+
+type MemoryConsentInfo struct {
+	// 本次删除的记忆条数
+	Deleted   int64  `json:"deleted,omitempty"`
+	Granted   bool   `json:"granted,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+	UserID    int64  `json:"user_id,omitempty"`
+}
+
+type MemoryConsentRequest struct {
+	// 撤销授权时同时删除已有记忆
+	DeleteExisting bool `json:"delete_existing,omitempty"`
+	Granted        bool `json:"granted"`
+}
+
+type MemoryFactInfo struct {
+	Content   string `json:"content,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	// 提取自该设备的对话
+	DeviceID string `json:"device_id,omitempty"`
+	// 对应对话记录中的用户消息
+	EntryID string `json:"entry_id,omitempty"`
+	// 提取依据的用户原话
+	Evidence  string `json:"evidence,omitempty"`
+	ID        string `json:"id,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	// 提取自该会话
+	SessionID string `json:"session_id,omitempty"`
+	// extracted（从对话中提取）/manual（手动添加或修改）
+	Source    string `json:"source,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+type MemoryFactListResponse struct {
+	Facts      []MemoryFactInfo `json:"facts,omitempty"`
+	Pagination *Pagination      `json:"pagination,omitempty"`
+}
+
+type MemoryFactRequest struct {
+	// 最多 500 字
+	Content string `json:"content"`
+}
+
+type MonitorEvent struct {
+	At        string `json:"at,omitempty"`
+	Round     int64  `json:"round,omitempty"`
+	SessionID string `json:"session_id,omitempty"`
+	// type 为 snippet 时的对话片段
+	Snippet *MonitorSnippet `json:"snippet,omitempty"`
+	// type 为 state 时的新状态
+	State string `json:"state,omitempty"`
+	// state/snippet/closed
+	Type string `json:"type,omitempty"`
+}
+
+type MonitorFlag struct {
+	FlaggedAt string `json:"flagged_at,omitempty"`
+	Note      string `json:"note,omitempty"`
+}
+
+type MonitorFlagRequest struct {
+	// 标记原因
+	Note string `json:"note,omitempty"`
+}
+
+type MonitorSession struct {
+	ConnectedAt string       `json:"connected_at,omitempty"`
+	DeviceID    string       `json:"device_id,omitempty"`
+	Flag        *MonitorFlag `json:"flag,omitempty"`
+	// 提供者/模型
+	LLM string `json:"llm,omitempty"`
+	// 最近的对话片段，按时间顺序
+	Recent    []MonitorSnippet `json:"recent,omitempty"`
+	Round     int64            `json:"round,omitempty"`
+	SessionID string           `json:"session_id,omitempty"`
+	// idle/listening/thinking/speaking
+	State    string `json:"state,omitempty"`
+	TenantID string `json:"tenant_id,omitempty"`
+	// TTS 配置名
+	TTS string `json:"tts,omitempty"`
+	// 当前播报使用的音色
+	Voice string `json:"voice,omitempty"`
+}
+
+type MonitorSnippet struct {
+	At string `json:"at,omitempty"`
+	// 超过 200 字的部分截断
+	Content string `json:"content,omitempty"`
+	// user/assistant
+	Role string `json:"role,omitempty"`
+}
+
+type Node struct {
+	// 节点配置
+	Config      map[string]interface{} `json:"config,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	// 错误信息
+	Error string `json:"error,omitempty"`
+	ID    string `json:"id,omitempty"`
+	// 输入Schema
+	Inputs []InputSchema `json:"inputs,omitempty"`
+	// 调用的方法
+	Method string `json:"method,omitempty"`
+	Name   string `json:"name,omitempty"`
+	// 输出Schema
+	Outputs []OutputSchema `json:"outputs,omitempty"`
+	// 关联的插件ID
+	Plugin string `json:"plugin,omitempty"`
+	// 画布位置
+	Position *Position `json:"position,omitempty"`
+	// 节点状态
+	Status NodeStatus `json:"status,omitempty"`
+	Type   NodeType   `json:"type,omitempty"`
+}
+
+type NodeLog struct {
+	Attempt int64  `json:"attempt,omitempty"`
+	Level   string `json:"level,omitempty"`
+	Message string `json:"message,omitempty"`
+	// engine 或 plugin
+	Source string `json:"source,omitempty"`
+	// 插件输出流：stdout、stderr
+	Stream    string `json:"stream,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+}
+
+type NodeLogsResponse struct {
+	Attempts int64       `json:"attempts,omitempty"`
+	Logs     []NodeLog   `json:"logs,omitempty"`
+	NodeID   string      `json:"node_id,omitempty"`
+	Status   NodeStatus  `json:"status,omitempty"`
+	Timing   *NodeTiming `json:"timing,omitempty"`
+}
+
+type NodeResult struct {
+	// 能力调用次数，含重试
+	Attempts    int64                  `json:"attempts,omitempty"`
+	ElapsedTime Duration               `json:"elapsed_time,omitempty"`
+	EndTime     string                 `json:"end_time,omitempty"`
+	Error       string                 `json:"error,omitempty"`
+	Inputs      map[string]interface{} `json:"inputs,omitempty"`
+	// 节点日志，包括插件输出和重试记录
+	Logs      []NodeLog              `json:"logs,omitempty"`
+	NodeID    string                 `json:"node_id,omitempty"`
+	Outputs   map[string]interface{} `json:"outputs,omitempty"`
+	StartTime string                 `json:"start_time,omitempty"`
+	Status    NodeStatus             `json:"status,omitempty"`
+	Timing    *NodeTiming            `json:"timing,omitempty"`
+	// 能力调用的追踪信息，含提供者返回的请求ID
+	Traces []Trace `json:"traces,omitempty"`
+}
+
+type NodeStatus string
+
+const (
+	NodeStatusPending   NodeStatus = "pending"
+	NodeStatusRunning   NodeStatus = "running"
+	NodeStatusCompleted NodeStatus = "completed"
+	NodeStatusFailed    NodeStatus = "failed"
+	NodeStatusSkipped   NodeStatus = "skipped"
+)
+
+type NodeTiming struct {
+	// 能力调用，含重试
+	Execution Duration `json:"execution,omitempty"`
+	// 解析输入
+	Inputs Duration `json:"inputs,omitempty"`
+	// 等待执行槽
+	Queue Duration `json:"queue,omitempty"`
+	// 重试前的等待，包含在 Execution 中
+	RetryWait Duration `json:"retry_wait,omitempty"`
+	// 输出校验
+	Validation Duration `json:"validation,omitempty"`
+}
+
+type NodeType string
+
+const (
+	NodeTypeStart     NodeType = "start"
+	NodeTypeEnd       NodeType = "end"
+	NodeTypeTask      NodeType = "task"
+	NodeTypeCondition NodeType = "condition"
+	NodeTypeParallel  NodeType = "parallel"
+	NodeTypeMerge     NodeType = "merge"
+	NodeTypeApproval  NodeType = "approval"
+	NodeTypeTransform NodeType = "transform"
+	NodeTypeHTTP      NodeType = "http"
+)
+
+type NodeTypeDefinition struct {
+	Builtin      bool    `json:"builtin,omitempty"`
+	ConfigSchema *Schema `json:"config_schema,omitempty"`
+	Description  string  `json:"description,omitempty"`
+	InputSchema  *Schema `json:"input_schema,omitempty"`
+	Name         string  `json:"name,omitempty"`
+	OutputSchema *Schema `json:"output_schema,omitempty"`
+	// 注册来源：capability 表示从能力注册表同步
+	Source string   `json:"source,omitempty"`
+	Type   NodeType `json:"type,omitempty"`
+	UI     *UIHints `json:"ui,omitempty"`
+}
+
+type NotificationChannelCreateRequest struct {
+	Config  map[string]interface{} `json:"config"`
+	Enabled bool                   `json:"enabled,omitempty"`
+	// plugin_crashed, provider_outage, budget_exceeded 或 *
+	Events []string `json:"events,omitempty"`
+	Name   string   `json:"name"`
+	Type   string   `json:"type"`
+}
+
+type NotificationChannelInfo struct {
+	Config    map[string]interface{} `json:"config,omitempty"`
+	CreatedAt string                 `json:"created_at,omitempty"`
+	Enabled   bool                   `json:"enabled,omitempty"`
+	Events    []string               `json:"events,omitempty"`
+	ID        string                 `json:"id,omitempty"`
+	Name      string                 `json:"name,omitempty"`
+	Type      string                 `json:"type,omitempty"`
+	UpdatedAt string                 `json:"updated_at,omitempty"`
+}
+
+type NotificationChannelUpdateRequest struct {
+	// 敏感字段传 ****** 表示保留原值
+	Config  map[string]interface{} `json:"config,omitempty"`
+	Enabled bool                   `json:"enabled,omitempty"`
+	Events  []string               `json:"events,omitempty"`
+	Name    string                 `json:"name,omitempty"`
+}
+
+type ObjectInfo struct {
+	ContentType string `json:"content_type,omitempty"`
+	CreatedAt   string `json:"created_at,omitempty"`
+	// 为空表示由引用方释放
+	ExpiresAt string `json:"expires_at,omitempty"`
+	// 内容的 SHA-256
+	ID        string `json:"id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	// 长期引用数
+	Refs      int64  `json:"refs,omitempty"`
+	Size      int64  `json:"size,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+type ObjectListResponse struct {
+	Objects    []ObjectInfo `json:"objects,omitempty"`
+	Pagination *Pagination  `json:"pagination,omitempty"`
+}
+
+type ObjectURLResponse struct {
+	ExpiresAt string `json:"expires_at,omitempty"`
+	ID        string `json:"id,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+type OutputSchema struct {
+	Description string `json:"description,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Type        string `json:"type,omitempty"`
+}
+
+type Pagination struct {
+	HasNext    bool  `json:"has_next,omitempty"`
+	HasPrev    bool  `json:"has_prev,omitempty"`
+	Limit      int64 `json:"limit,omitempty"`
+	Page       int64 `json:"page,omitempty"`
+	Total      int64 `json:"total,omitempty"`
+	TotalPages int64 `json:"total_pages,omitempty"`
+}
+
+type PairingTokenInfo struct {
+	ExpiresAt string `json:"expires_at,omitempty"`
+	// 二维码内容
+	Payload string `json:"payload,omitempty"`
+	Token   string `json:"token,omitempty"`
+}
+
+type Plan struct {
+	Changes []Change `json:"changes,omitempty"`
+	DryRun  bool     `json:"dry_run,omitempty"`
+	// 已与清单一致的资源数
+	Unchanged int64 `json:"unchanged,omitempty"`
+}
+
+type PluginControlRequest struct {
+	Action string                 `json:"action"`
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+type PluginControlResponse struct {
+	Message     string `json:"message,omitempty"`
+	NewPort     int64  `json:"new_port,omitempty"`
+	NewStatus   string `json:"new_status,omitempty"`
+	OldPort     int64  `json:"old_port,omitempty"`
+	OldStatus   string `json:"old_status,omitempty"`
+	ProcessTime string `json:"process_time,omitempty"`
+	Success     bool   `json:"success,omitempty"`
+}
+
+type PluginDebugInfo struct {
+	Address   string `json:"address,omitempty"`
+	GoVersion string `json:"go_version,omitempty"`
+	LogLevel  string `json:"log_level,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Pid       int64  `json:"pid,omitempty"`
+	PluginID  string `json:"plugin_id,omitempty"`
+	// 已注册的gRPC服务全名
+	Services      []string `json:"services,omitempty"`
+	StartedAt     string   `json:"started_at,omitempty"`
+	UptimeSeconds int64    `json:"uptime_seconds,omitempty"`
+	Version       string   `json:"version,omitempty"`
+}
+
+type PluginDebugMetrics struct {
+	Methods  []PluginMethodMetrics `json:"methods,omitempty"`
+	PluginID string                `json:"plugin_id,omitempty"`
+	// goroutines、heap_alloc_bytes、heap_sys_bytes、gc_count、gc_pause_total_ms
+	Runtime map[string]float64 `json:"runtime,omitempty"`
+}
+
+type PluginListResponse struct {
+	Page       int64          `json:"page,omitempty"`
+	PageSize   int64          `json:"page_size,omitempty"`
+	Plugins    []PluginStatus `json:"plugins,omitempty"`
+	Total      int64          `json:"total,omitempty"`
+	TotalPages int64          `json:"total_pages,omitempty"`
+}
+
+type PluginLogLevelRequest struct {
+	Level string `json:"level"`
+}
+
+type PluginLogLevelResponse struct {
+	Level         string `json:"level,omitempty"`
+	PluginID      string `json:"plugin_id,omitempty"`
+	PreviousLevel string `json:"previous_level,omitempty"`
+}
+
+type PluginLogsResponse struct {
+	Entries  []Entry `json:"entries,omitempty"`
+	PluginID string  `json:"plugin_id,omitempty"`
+	// 缓冲区中的日志行数
+	Total int64 `json:"total,omitempty"`
+}
+
+type PluginMethodMetrics struct {
+	Calls   int64   `json:"calls,omitempty"`
+	Errors  int64   `json:"errors,omitempty"`
+	MaxMs   float64 `json:"max_ms,omitempty"`
+	Method  string  `json:"method,omitempty"`
+	TotalMs float64 `json:"total_ms,omitempty"`
+}
+
+type PluginStats struct {
+	ByCapability map[string]int64 `json:"by_capability,omitempty"`
+	ByType       map[string]int64 `json:"by_type,omitempty"`
+	Disabled     int64            `json:"disabled,omitempty"`
+	Enabled      int64            `json:"enabled,omitempty"`
+	Error        int64            `json:"error,omitempty"`
+	Healthy      int64            `json:"healthy,omitempty"`
+	LastUpdated  string           `json:"last_updated,omitempty"`
+	Running      int64            `json:"running,omitempty"`
+	Stopped      int64            `json:"stopped,omitempty"`
+	Total        int64            `json:"total,omitempty"`
+	Unknown      int64            `json:"unknown,omitempty"`
+}
+
+type PluginStatus struct {
+	Address         string          `json:"address,omitempty"`
+	Capabilities    []CapabilityDef `json:"capabilities,omitempty"`
+	CreatedAt       string          `json:"created_at,omitempty"`
+	Description     string          `json:"description,omitempty"`
+	HealthStatus    string          `json:"health_status,omitempty"`
+	ID              string          `json:"id,omitempty"`
+	LastHealthCheck string          `json:"last_health_check,omitempty"`
+	Name            string          `json:"name,omitempty"`
+	Port            int64           `json:"port,omitempty"`
+	Status          string          `json:"status,omitempty"`
+	Type            string          `json:"type,omitempty"`
+	UpdatedAt       string          `json:"updated_at,omitempty"`
+	Version         string          `json:"version,omitempty"`
+}
+
+type PortAllocation struct {
+	Address  string `json:"address,omitempty"`
+	PluginID string `json:"plugin_id,omitempty"`
+	Port     int64  `json:"port,omitempty"`
+	// "allocated", "released", "reserved", "orphaned"
+	Status    string `json:"status,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+}
+
+type PortAllocationsResponse struct {
+	Allocations []PortAllocation `json:"allocations,omitempty"`
+	History     []PortEvent      `json:"history,omitempty"`
+	Stats       *PortStats       `json:"stats,omitempty"`
+}
+
+type PortEvent struct {
+	PluginID  string `json:"plugin_id,omitempty"`
+	Port      int64  `json:"port,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+}
+
+type PortStats struct {
+	AllocatedPorts int64   `json:"allocated_ports,omitempty"`
+	AvailablePorts int64   `json:"available_ports,omitempty"`
+	OrphanedPorts  int64   `json:"orphaned_ports,omitempty"`
+	ReservedPorts  int64   `json:"reserved_ports,omitempty"`
+	TotalPorts     int64   `json:"total_ports,omitempty"`
+	UnixSockets    int64   `json:"unix_sockets,omitempty"`
+	UsagePercent   float64 `json:"usage_percent,omitempty"`
+}
+
+type Position struct {
+	X float64 `json:"x,omitempty"`
+	Y float64 `json:"y,omitempty"`
+}
+
+type PresenceCheckInRequest struct {
+	// 定位精度（米）
+	Accuracy  float64 `json:"accuracy,omitempty"`
+	Latitude  float64 `json:"latitude,omitempty"`
+	Longitude float64 `json:"longitude,omitempty"`
+	State     string  `json:"state,omitempty"`
+}
+
+type PresenceOverview struct {
+	AnyoneHome bool `json:"anyone_home,omitempty"`
+	// 在家成员的称呼
+	Home    []string         `json:"home,omitempty"`
+	Members []PresenceStatus `json:"members,omitempty"`
+}
+
+type PresenceRouterReport struct {
+	Macs []string `json:"macs"`
+}
+
+type PresenceRouterReportResponse struct {
+	// 其中属于成员的设备数
+	Matched int64 `json:"matched,omitempty"`
+}
+
+type PresenceStatus struct {
+	Name string `json:"name,omitempty"`
+	// 进入当前状态的时间
+	Since string `json:"since,omitempty"`
+	// 进入当前状态的来源：app / geofence / router
+	Source string `json:"source,omitempty"`
+	// home / away / unknown
+	State     string `json:"state,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+	UserID    int64  `json:"user_id,omitempty"`
+}
+
+type PromptAssignRequest struct {
+	TemplateName string `json:"template_name"`
+	// 0 表示跟随生效版本
+	Version int64 `json:"version,omitempty"`
+}
+
+type PromptAssignmentInfo struct {
+	DeviceID     string `json:"device_id,omitempty"`
+	TemplateName string `json:"template_name,omitempty"`
+	UpdatedAt    string `json:"updated_at,omitempty"`
+	Version      int64  `json:"version,omitempty"`
+}
+
+type PromptCreateRequest struct {
+	Content     string            `json:"content"`
+	Description string            `json:"description,omitempty"`
+	Name        string            `json:"name"`
+	Variables   map[string]string `json:"variables,omitempty"`
+}
+
+type PromptInfo struct {
+	Active      bool              `json:"active,omitempty"`
+	Content     string            `json:"content,omitempty"`
+	CreatedAt   string            `json:"created_at,omitempty"`
+	Description string            `json:"description,omitempty"`
+	ID          string            `json:"id,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Variables   map[string]string `json:"variables,omitempty"`
+	Version     int64             `json:"version,omitempty"`
+}
+
+type PromptListResponse struct {
+	Pagination *Pagination  `json:"pagination,omitempty"`
+	Prompts    []PromptInfo `json:"prompts,omitempty"`
+}
+
+type PromptRenderRequest struct {
+	Content string `json:"content,omitempty"`
+	// Content 的变量默认值
+	Variables map[string]string `json:"variables,omitempty"`
+	// 本次渲染传入的变量
+	Vars    map[string]string `json:"vars,omitempty"`
+	Version int64             `json:"version,omitempty"`
+}
+
+type PromptRenderResponse struct {
+	Name     string `json:"name,omitempty"`
+	Rendered string `json:"rendered,omitempty"`
+	Version  int64  `json:"version,omitempty"`
+}
+
+type PromptSpec struct {
+	Content     string            `json:"content,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Variables   map[string]string `json:"variables,omitempty"`
+}
+
+type PromptUpdateRequest struct {
+	Content     string            `json:"content,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Variables   map[string]string `json:"variables,omitempty"`
+}
+
+type Property struct {
+	Default     interface{}   `json:"default,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Enum        []interface{} `json:"enum,omitempty"`
+	// For arrays
+	Items *Schema `json:"items,omitempty"`
+	// For sensitive config like API keys
+	Secret bool   `json:"secret,omitempty"`
+	Type   string `json:"type,omitempty"`
+}
+
+type ProviderCallInfo struct {
+	CapabilityID   string `json:"capability_id,omitempty"`
+	CapabilityType string `json:"capability_type,omitempty"`
+	Chunks         int64  `json:"chunks,omitempty"`
+	CreatedAt      string `json:"created_at,omitempty"`
+	DeviceID       string `json:"device_id,omitempty"`
+	DurationMs     int64  `json:"duration_ms,omitempty"`
+	Error          string `json:"error,omitempty"`
+	ID             string `json:"id,omitempty"`
+	ProviderID     string `json:"provider_id,omitempty"`
+	// JSON，包含 config 和 inputs，已脱敏
+	Request string `json:"request,omitempty"`
+	// JSON，流式调用为分块数组，已脱敏
+	Response  string `json:"response,omitempty"`
+	SessionID string `json:"session_id,omitempty"`
+	Stream    bool   `json:"stream,omitempty"`
+	Success   bool   `json:"success,omitempty"`
+}
+
+type ProviderCallListResponse struct {
+	Calls      []ProviderCallSummary `json:"calls,omitempty"`
+	Pagination *Pagination           `json:"pagination,omitempty"`
+}
+
+type ProviderCallSummary struct {
+	CapabilityID   string `json:"capability_id,omitempty"`
+	CapabilityType string `json:"capability_type,omitempty"`
+	Chunks         int64  `json:"chunks,omitempty"`
+	CreatedAt      string `json:"created_at,omitempty"`
+	DeviceID       string `json:"device_id,omitempty"`
+	DurationMs     int64  `json:"duration_ms,omitempty"`
+	Error          string `json:"error,omitempty"`
+	ID             string `json:"id,omitempty"`
+	ProviderID     string `json:"provider_id,omitempty"`
+	SessionID      string `json:"session_id,omitempty"`
+	Stream         bool   `json:"stream,omitempty"`
+	Success        bool   `json:"success,omitempty"`
+}
+
+type ProviderConnectivityRequest struct {
+	// 与能力配置中的 network 格式相同，叠加在提供者的网络配置之上
+	Network map[string]interface{} `json:"network,omitempty"`
+	// 连接池中的提供者名称，与 HTTPClient.Providers 的键相同，如 openai
+	Provider string `json:"provider"`
+	// 如 10s，最长 1m
+	Timeout string `json:"timeout,omitempty"`
+	// 测试地址，如提供者的 base_url
+	URL string `json:"url"`
+}
+
+type ProviderStats struct {
+	AvgTlsHandshakeMs float64 `json:"avg_tls_handshake_ms,omitempty"`
+	// 走 HTTP/2 的请求数
+	Http2Requests int64 `json:"http2_requests,omitempty"`
+	// 新建连接的请求数
+	NewConns int64  `json:"new_conns,omitempty"`
+	Provider string `json:"provider,omitempty"`
+	Requests int64  `json:"requests,omitempty"`
+	// 复用率 0~1
+	ReuseRate float64 `json:"reuse_rate,omitempty"`
+	// 复用已有连接的请求数
+	ReusedConns int64 `json:"reused_conns,omitempty"`
+	// TLS 握手次数
+	TlsHandshakes int64 `json:"tls_handshakes,omitempty"`
+}
+
+type ProviderTestRequest struct {
+	CapabilityID string `json:"capability_id"`
+	// 待测试的能力配置，如 api_key、model
+	Config map[string]interface{} `json:"config,omitempty"`
+	// 如 10s，最长 1m
+	Timeout string `json:"timeout,omitempty"`
+}
+
+type ProviderTestResult struct {
+	CapabilityID string `json:"capability_id,omitempty"`
+	LatencyMs    int64  `json:"latency_ms,omitempty"`
+	Message      string `json:"message,omitempty"`
+	ProviderID   string `json:"provider_id,omitempty"`
+	Success      bool   `json:"success,omitempty"`
+	Type         string `json:"type,omitempty"`
+}
+
+type ProvisioningClaimRequest struct {
+	// 为空时使用 client_<设备ID>
+	ClientID   string `json:"client_id,omitempty"`
+	DeviceID   string `json:"device_id"`
+	DeviceName string `json:"device_name,omitempty"`
+	DeviceType string `json:"device_type,omitempty"`
+	Model      string `json:"model,omitempty"`
+	Token      string `json:"token"`
+	Version    string `json:"version,omitempty"`
+}
+
+type ProvisioningClaimResponse struct {
+	ClientID string `json:"client_id,omitempty"`
+	DeviceID string `json:"device_id,omitempty"`
+	// 连接 WebSocket 时以 Authorization: Bearer 携带
+	DeviceToken string               `json:"device_token,omitempty"`
+	Key         *IssuedDeviceKeyInfo `json:"key,omitempty"`
+	TenantID    string               `json:"tenant_id,omitempty"`
+	// 设备所有者
+	UserID    int64  `json:"user_id,omitempty"`
+	Websocket string `json:"websocket,omitempty"`
+}
+
+type QueueStats struct {
+	Active        int64                 `json:"active,omitempty"`
+	Classes       map[string]ClassStats `json:"classes,omitempty"`
+	MaxConcurrent int64                 `json:"max_concurrent,omitempty"`
+}
+
+type RecordingConsentInfo struct {
+	// 本次删除的录音条数
+	Deleted   int64  `json:"deleted,omitempty"`
+	DeviceID  string `json:"device_id,omitempty"`
+	Granted   bool   `json:"granted,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+type RecordingConsentRequest struct {
+	// 撤销授权时同时删除已归档的语音
+	DeleteExisting bool `json:"delete_existing,omitempty"`
+	Granted        bool `json:"granted"`
+}
+
+type RecordingInfo struct {
+	Channels   int64  `json:"channels,omitempty"`
+	CreatedAt  string `json:"created_at,omitempty"`
+	DeviceID   string `json:"device_id,omitempty"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+	// 对应的对话记录消息ID
+	EntryID    string `json:"entry_id,omitempty"`
+	Format     string `json:"format,omitempty"`
+	ID         string `json:"id,omitempty"`
+	Round      int64  `json:"round,omitempty"`
+	SampleRate int64  `json:"sample_rate,omitempty"`
+	SessionID  string `json:"session_id,omitempty"`
+	Size       int64  `json:"size,omitempty"`
+}
+
+type RecordingListResponse struct {
+	Pagination *Pagination     `json:"pagination,omitempty"`
+	Recordings []RecordingInfo `json:"recordings,omitempty"`
+}
+
+type ReminderCreateRequest struct {
+	DelaySeconds int64  `json:"delay_seconds,omitempty"`
+	DeviceID     string `json:"device_id"`
+	FireAt       string `json:"fire_at,omitempty"`
+	Kind         string `json:"kind,omitempty"`
+	Message      string `json:"message,omitempty"`
+	Recurrence   string `json:"recurrence,omitempty"`
+}
+
+type ReminderInfo struct {
+	CreatedAt  string `json:"created_at,omitempty"`
+	DeviceID   string `json:"device_id,omitempty"`
+	FireAt     string `json:"fire_at,omitempty"`
+	FiredAt    string `json:"fired_at,omitempty"`
+	ID         string `json:"id,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Message    string `json:"message,omitempty"`
+	Recurrence string `json:"recurrence,omitempty"`
+	Status     string `json:"status,omitempty"`
+	UpdatedAt  string `json:"updated_at,omitempty"`
+}
+
+type ReminderListResponse struct {
+	Pagination *Pagination    `json:"pagination,omitempty"`
+	Reminders  []ReminderInfo `json:"reminders,omitempty"`
+}
+
+type ReminderUpdateRequest struct {
+	FireAt  string `json:"fire_at,omitempty"`
+	Message string `json:"message,omitempty"`
+	// 传空字符串表示取消重复
+	Recurrence string `json:"recurrence,omitempty"`
+}
+
+type ReplayDiffSegment struct {
+	Op   string `json:"op,omitempty"`
+	Text string `json:"text,omitempty"`
+}
+
+type ReplayOptions struct {
+	// 回放模型的每千Token成本
+	CostPer1kToken float64 `json:"cost_per_1k_token,omitempty"`
+	MaxTokens      int64   `json:"max_tokens,omitempty"`
+	// LLM 配置名称，为空时使用当前选择的 LLM
+	Model string `json:"model,omitempty"`
+	// 覆盖配置中的模型名称
+	ModelName string `json:"model_name,omitempty"`
+	// 原对话模型的每千Token成本
+	OriginalCostPer1kToken float64 `json:"original_cost_per_1k_token,omitempty"`
+	// 提示词模板名称，为空时按设备当前分配解析
+	PromptTemplate string `json:"prompt_template,omitempty"`
+	PromptVersion  int64  `json:"prompt_version,omitempty"`
+	// 直接指定系统提示词，优先于模板
+	SystemPrompt string  `json:"system_prompt,omitempty"`
+	Temperature  float64 `json:"temperature,omitempty"`
+}
+
+type ReplayRunInfo struct {
+	CreatedAt    string         `json:"created_at,omitempty"`
+	DeviceID     string         `json:"device_id,omitempty"`
+	Error        string         `json:"error,omitempty"`
+	FinishedAt   string         `json:"finished_at,omitempty"`
+	ID           string         `json:"id,omitempty"`
+	ModelName    string         `json:"model_name,omitempty"`
+	Options      *ReplayOptions `json:"options,omitempty"`
+	SessionID    string         `json:"session_id,omitempty"`
+	Status       string         `json:"status,omitempty"`
+	Summary      *ReplaySummary `json:"summary,omitempty"`
+	SystemPrompt string         `json:"system_prompt,omitempty"`
+	Turns        []ReplayTurn   `json:"turns,omitempty"`
+}
+
+type ReplayRunListResponse struct {
+	Pagination *Pagination     `json:"pagination,omitempty"`
+	Runs       []ReplayRunInfo `json:"runs,omitempty"`
+}
+
+type ReplaySide struct {
+	CompleteMs      int64   `json:"complete_ms,omitempty"`
+	Cost            float64 `json:"cost,omitempty"`
+	FirstTokenMs    int64   `json:"first_token_ms,omitempty"`
+	Response        string  `json:"response,omitempty"`
+	Tokens          int64   `json:"tokens,omitempty"`
+	TokensEstimated bool    `json:"tokens_estimated,omitempty"`
+}
+
+type ReplaySideSummary struct {
+	AvgCompleteMs   float64 `json:"avg_complete_ms,omitempty"`
+	AvgFirstTokenMs float64 `json:"avg_first_token_ms,omitempty"`
+	Cost            float64 `json:"cost,omitempty"`
+	Tokens          int64   `json:"tokens,omitempty"`
+}
+
+type ReplayStartRequest struct {
+	// 回放模型的每千Token成本
+	CostPer1kToken float64 `json:"cost_per_1k_token,omitempty"`
+	MaxTokens      int64   `json:"max_tokens,omitempty"`
+	// LLM 配置名称，为空时使用当前选择的 LLM
+	Model string `json:"model,omitempty"`
+	// 覆盖配置中的模型名称
+	ModelName string `json:"model_name,omitempty"`
+	// 原对话模型的每千Token成本
+	OriginalCostPer1kToken float64 `json:"original_cost_per_1k_token,omitempty"`
+	// 提示词模板名称，为空时按设备当前分配解析
+	PromptTemplate string `json:"prompt_template,omitempty"`
+	PromptVersion  int64  `json:"prompt_version,omitempty"`
+	SessionID      string `json:"session_id"`
+	// 直接指定系统提示词，优先于模板
+	SystemPrompt string  `json:"system_prompt,omitempty"`
+	Temperature  float64 `json:"temperature,omitempty"`
+}
+
+type ReplaySummary struct {
+	AvgSimilarity float64            `json:"avg_similarity,omitempty"`
+	Original      *ReplaySideSummary `json:"original,omitempty"`
+	Replay        *ReplaySideSummary `json:"replay,omitempty"`
+	Skipped       int64              `json:"skipped,omitempty"`
+	Succeeded     int64              `json:"succeeded,omitempty"`
+	Turns         int64              `json:"turns,omitempty"`
+}
+
+type ReplayTurn struct {
+	Diff       []ReplayDiffSegment `json:"diff,omitempty"`
+	Error      string              `json:"error,omitempty"`
+	Input      string              `json:"input,omitempty"`
+	Original   *ReplaySide         `json:"original,omitempty"`
+	Redacted   bool                `json:"redacted,omitempty"`
+	Replay     *ReplaySide         `json:"replay,omitempty"`
+	Round      int64               `json:"round,omitempty"`
+	Similarity float64             `json:"similarity,omitempty"`
+}
+
+type RoutineAction struct {
+	// weather 为地点，news 为新闻分类，say 为播报文本，workflow 为工作流ID
+	Param string `json:"param,omitempty"`
+	Type  string `json:"type"`
+}
+
+type RoutineCreateRequest struct {
+	Actions  []RoutineAction `json:"actions"`
+	DeviceID string          `json:"device_id"`
+	// 默认启用
+	Enabled bool   `json:"enabled,omitempty"`
+	Name    string `json:"name"`
+	// 唤醒短语
+	Phrase string `json:"phrase,omitempty"`
+	// 五段式 cron 表达式（分 时 日 月 周），如 "0 7 * * 1-5"
+	Schedule string `json:"schedule,omitempty"`
+}
+
+type RoutineInfo struct {
+	Actions   []RoutineAction `json:"actions,omitempty"`
+	CreatedAt string          `json:"created_at,omitempty"`
+	// 口语描述，如“每天7点整播报天气和新闻”
+	Description string `json:"description,omitempty"`
+	DeviceID    string `json:"device_id,omitempty"`
+	Enabled     bool   `json:"enabled,omitempty"`
+	ID          string `json:"id,omitempty"`
+	LastRunAt   string `json:"last_run_at,omitempty"`
+	// ok / partial / failed / missed
+	LastStatus string `json:"last_status,omitempty"`
+	Name       string `json:"name,omitempty"`
+	NextRunAt  string `json:"next_run_at,omitempty"`
+	Phrase     string `json:"phrase,omitempty"`
+	Schedule   string `json:"schedule,omitempty"`
+	UpdatedAt  string `json:"updated_at,omitempty"`
+}
+
+type RoutineRunResponse struct {
+	// 推送给设备的播报文本
+	Reply string `json:"reply,omitempty"`
+}
+
+type RoutineUpdateRequest struct {
+	Actions []RoutineAction `json:"actions,omitempty"`
+	Enabled bool            `json:"enabled,omitempty"`
+	Name    string          `json:"name,omitempty"`
+	// 传空字符串表示取消唤醒短语
+	Phrase string `json:"phrase,omitempty"`
+	// 传空字符串表示取消定时触发
+	Schedule string `json:"schedule,omitempty"`
+}
+
+type SchedulerStats struct {
+	// 各类别运行中的节点数
+	Classes map[string]int64 `json:"classes,omitempty"`
+	// 各执行运行中的节点数
+	Executions map[string]int64 `json:"executions,omitempty"`
+	MaxWorkers int64            `json:"max_workers,omitempty"`
+	Queued     int64            `json:"queued,omitempty"`
+	Running    int64            `json:"running,omitempty"`
+}
+
+type Schema struct {
+	Properties map[string]Property `json:"properties,omitempty"`
+	Required   []string            `json:"required,omitempty"`
+	// object, string, number, array, boolean
+	Type string `json:"type,omitempty"`
+}
+
+type ScriptCreateRequest struct {
+	// 版本说明
+	Comment     string `json:"comment,omitempty"`
+	Description string `json:"description,omitempty"`
+	Name        string `json:"name"`
+	// Lua 源码
+	Source string `json:"source"`
+}
+
+type ScriptInfo struct {
+	CreatedAt   string `json:"created_at,omitempty"`
+	Description string `json:"description,omitempty"`
+	ID          string `json:"id,omitempty"`
+	Language    string `json:"language,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Source      string `json:"source,omitempty"`
+	UpdatedAt   string `json:"updated_at,omitempty"`
+	Version     int64  `json:"version,omitempty"`
+}
+
+type ScriptRollbackRequest struct {
+	Version int64 `json:"version"`
+}
+
+type ScriptTestRequest struct {
+	Inputs map[string]interface{} `json:"inputs,omitempty"`
+	// 如 5s，最长 1m
+	Timeout string `json:"timeout,omitempty"`
+	// 缺省为当前版本
+	Version int64 `json:"version,omitempty"`
+}
+
+type ScriptTestResult struct {
+	Logs    []string               `json:"logs,omitempty"`
+	Outputs map[string]interface{} `json:"outputs,omitempty"`
+}
+
+type ScriptUpdateRequest struct {
+	Comment     string `json:"comment,omitempty"`
+	Description string `json:"description,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Source      string `json:"source,omitempty"`
+}
+
+type ScriptVersionInfo struct {
+	Comment   string `json:"comment,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	ScriptID  string `json:"script_id,omitempty"`
+	Source    string `json:"source,omitempty"`
+	Version   int64  `json:"version,omitempty"`
+}
+
+type SelfTestReport struct {
+	DurationMs int64 `json:"duration_ms,omitempty"`
+	// 没有失败项
+	Passed    bool             `json:"passed,omitempty"`
+	Results   []SelfTestResult `json:"results,omitempty"`
+	StartedAt string           `json:"started_at,omitempty"`
+}
+
+type SelfTestResult struct {
+	Detail     string `json:"detail,omitempty"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+	Name       string `json:"name,omitempty"`
+	// pass, warn, fail, skip
+	Status string `json:"status,omitempty"`
+	// 所属初始化步骤ID，如 storage:init-database
+	Step string `json:"step,omitempty"`
+}
+
+type SmartHomeAlias struct {
+	Alias    string `json:"alias,omitempty"`
+	EntityID string `json:"entity_id,omitempty"`
+	// 实体当前的播报名称，实体已不存在时为空
+	EntityName string `json:"entity_name,omitempty"`
+	// 语音指令通过该别名匹配到实体的次数
+	Hits int64 `json:"hits,omitempty"`
+	// manual/learned（从语音纠正中学到）
+	Source    string `json:"source,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+type SmartHomeAliasRequest struct {
+	Alias    string `json:"alias"`
+	EntityID string `json:"entity_id"`
+}
+
+type SmartHomeControlRequest struct {
+	Action string `json:"action"`
+	// set_brightness/set_position 为 0-100 的百分比，set_temperature 为摄氏度
+	Value float64 `json:"value,omitempty"`
+}
+
+type SmartHomeEntity struct {
+	Aliases   []string `json:"aliases,omitempty"`
+	Available bool     `json:"available,omitempty"`
+	// 桥接名称
+	Bridge string `json:"bridge,omitempty"`
+	// on_off/brightness/open_close/position/temperature/lock
+	Features []string `json:"features,omitempty"`
+	// "<桥接名称>:<设备ID>"
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	Room string `json:"room,omitempty"`
+	// on/brightness/position/temperature/current_temperature/locked 及传感器读数
+	State map[string]interface{} `json:"state,omitempty"`
+	// light/switch/cover/climate/lock/fan/sensor
+	Type string `json:"type,omitempty"`
+	// 最近一次从桥接读取状态的时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+type SpeechTextAssignRequest struct {
+	Profile string `json:"profile"`
+}
+
+type SpeechTextAssignmentInfo struct {
+	DeviceID string `json:"device_id,omitempty"`
+	Profile  string `json:"profile,omitempty"`
+	// 未分配、使用 default 方案时为空
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+type SpeechTextPreviewRequest struct {
+	// 使用已保存的方案，与 stages 二选一
+	Profile string `json:"profile,omitempty"`
+	// 使用未保存的步骤
+	Stages []SpeechTextStage `json:"stages,omitempty"`
+	Text   string            `json:"text"`
+}
+
+type SpeechTextPreviewResponse struct {
+	// 原始文本
+	Source string `json:"source,omitempty"`
+	// 处理后送入 TTS 的文本
+	Text string `json:"text,omitempty"`
+}
+
+type SpeechTextProfileInfo struct {
+	// 数据库中没有 default 方案时返回的内置方案
+	BuiltIn     bool              `json:"built_in,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Stages      []SpeechTextStage `json:"stages,omitempty"`
+	// 内置方案为空
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+type SpeechTextProfileListResponse struct {
+	Assignments []SpeechTextAssignmentInfo `json:"assignments,omitempty"`
+	Profiles    []SpeechTextProfileInfo    `json:"profiles,omitempty"`
+}
+
+type SpeechTextProfileRequest struct {
+	Description string `json:"description,omitempty"`
+	// 创建时必填，更新时以路径为准
+	Name string `json:"name,omitempty"`
+	// 按顺序执行
+	Stages []SpeechTextStage `json:"stages,omitempty"`
+}
+
+type SpeechTextStage struct {
+	// units 使用：zh 或 en，为空时按文本是否包含汉字判断
+	Language string `json:"language,omitempty"`
+	// regex 使用：Go 正则表达式
+	Pattern string `json:"pattern,omitempty"`
+	// regex 使用：替换文本，用 ${1} 引用分组
+	Replacement string `json:"replacement,omitempty"`
+	Type        string `json:"type"`
+	// replace 使用：原词到读法的映射，长词优先
+	Words map[string]string `json:"words,omitempty"`
+}
+
+type StageLatencyInfo struct {
+	AvgMs int64 `json:"avg_ms,omitempty"`
+	Count int64 `json:"count,omitempty"`
+	MaxMs int64 `json:"max_ms,omitempty"`
+	P50Ms int64 `json:"p50_ms,omitempty"`
+	P90Ms int64 `json:"p90_ms,omitempty"`
+	P95Ms int64 `json:"p95_ms,omitempty"`
+	P99Ms int64 `json:"p99_ms,omitempty"`
+	// capture/asr/llm_first_token/llm_complete/tts_first_byte/playback_start/total
+	Stage string `json:"stage,omitempty"`
+}
+
+type StartupWorkflowResponse struct {
+	Execution *Execution `json:"execution,omitempty"`
+	Workflow  *Workflow  `json:"workflow,omitempty"`
+}
+
+type TemplateParameter struct {
+	Default     interface{} `json:"default,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Label       string      `json:"label,omitempty"`
+	Name        string      `json:"name,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	// string, number, integer, boolean, array, object
+	Type       string      `json:"type,omitempty"`
+	Validation *Validation `json:"validation,omitempty"`
+}
+
+type TenantCreateRequest struct {
+	ID    string       `json:"id"`
+	Name  string       `json:"name,omitempty"`
+	Quota *TenantQuota `json:"quota,omitempty"`
+}
+
+type TenantInfo struct {
+	CreatedAt string       `json:"created_at,omitempty"`
+	Enabled   bool         `json:"enabled,omitempty"`
+	HasToken  bool         `json:"has_token,omitempty"`
+	ID        string       `json:"id,omitempty"`
+	Name      string       `json:"name,omitempty"`
+	Quota     *TenantQuota `json:"quota,omitempty"`
+	UpdatedAt string       `json:"updated_at,omitempty"`
+}
+
+type TenantLLMConfig struct {
+	// 读取时脱敏为 ******，保存时传 ****** 表示保留原值
+	APIKey      string                 `json:"api_key,omitempty"`
+	BaseURL     string                 `json:"base_url,omitempty"`
+	Extra       map[string]interface{} `json:"extra,omitempty"`
+	MaxTokens   int64                  `json:"max_tokens,omitempty"`
+	ModelName   string                 `json:"model_name,omitempty"`
+	Temperature float64                `json:"temperature,omitempty"`
+	TopP        float64                `json:"top_p,omitempty"`
+	Type        string                 `json:"type,omitempty"`
+}
+
+type TenantProviders struct {
+	LLM         map[string]TenantLLMConfig `json:"llm,omitempty"`
+	SelectedLLM string                     `json:"selected_llm,omitempty"`
+	SelectedTTS string                     `json:"selected_tts,omitempty"`
+	TTS         map[string]TenantTTSConfig `json:"tts,omitempty"`
+}
+
+type TenantQuota struct {
+	DailyRequests int64 `json:"daily_requests,omitempty"`
+	DailyTokens   int64 `json:"daily_tokens,omitempty"`
+	MaxDevices    int64 `json:"max_devices,omitempty"`
+}
+
+type TenantTTSConfig struct {
+	AppID   string                 `json:"app_id,omitempty"`
+	Cluster string                 `json:"cluster,omitempty"`
+	Extra   map[string]interface{} `json:"extra,omitempty"`
+	Format  string                 `json:"format,omitempty"`
+	// 读取时脱敏为 ******，保存时传 ****** 表示保留原值
+	Token string `json:"token,omitempty"`
+	Type  string `json:"type,omitempty"`
+	Voice string `json:"voice,omitempty"`
+}
+
+type TenantTokenResponse struct {
+	Tenant *TenantInfo `json:"tenant,omitempty"`
+	Token  string      `json:"token,omitempty"`
+}
+
+type TenantUpdateRequest struct {
+	Enabled bool         `json:"enabled,omitempty"`
+	Name    string       `json:"name,omitempty"`
+	Quota   *TenantQuota `json:"quota,omitempty"`
+}
+
+type TenantUsageInfo struct {
+	Day      string       `json:"day,omitempty"`
+	Devices  int64        `json:"devices,omitempty"`
+	Quota    *TenantQuota `json:"quota,omitempty"`
+	Requests int64        `json:"requests,omitempty"`
+	TenantID string       `json:"tenant_id,omitempty"`
+	Tokens   int64        `json:"tokens,omitempty"`
+}
+
+type ToolPolicyAuditInfo struct {
+	Agent   string `json:"agent,omitempty"`
+	Allowed bool   `json:"allowed,omitempty"`
+	// JSON
+	Arguments string `json:"arguments,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	DeviceID  string `json:"device_id,omitempty"`
+	ID        string `json:"id,omitempty"`
+	// tool/capability
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Profile   string `json:"profile,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	Rule      string `json:"rule,omitempty"`
+	SessionID string `json:"session_id,omitempty"`
+}
+
+type ToolPolicyAuditListResponse struct {
+	Pagination *Pagination           `json:"pagination,omitempty"`
+	Records    []ToolPolicyAuditInfo `json:"records,omitempty"`
+}
+
+type ToolPolicyCheckRequest struct {
+	Agent     string                 `json:"agent,omitempty"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	DeviceID  string                 `json:"device_id,omitempty"`
+	// tool/capability，缺省为 tool
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name"`
+	SessionID string `json:"session_id,omitempty"`
+}
+
+type ToolPolicyCheckResponse struct {
+	Allowed bool `json:"allowed,omitempty"`
+	// 参与检查的策略，多个以逗号分隔
+	Profile string `json:"profile,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	// 做出决定的规则，为空表示使用策略的默认动作
+	Rule string `json:"rule,omitempty"`
+}
+
+type Trace struct {
+	Capability string `json:"capability,omitempty"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Provider   string `json:"provider,omitempty"`
+	// 核心的关联ID
+	RequestID string `json:"request_id,omitempty"`
+	StartedAt string `json:"started_at,omitempty"`
+	// 提供者返回的请求ID，如 OpenAI 的 x-request-id
+	UpstreamRequestIds []string `json:"upstream_request_ids,omitempty"`
+}
+
+type TranscriptEntryInfo struct {
+	Content    string `json:"content,omitempty"`
+	CreatedAt  string `json:"created_at,omitempty"`
+	ID         string `json:"id,omitempty"`
+	LatencyMs  int64  `json:"latency_ms,omitempty"`
+	Redacted   bool   `json:"redacted,omitempty"`
+	Role       string `json:"role,omitempty"`
+	Round      int64  `json:"round,omitempty"`
+	ToolArgs   string `json:"tool_args,omitempty"`
+	ToolCallID string `json:"tool_call_id,omitempty"`
+	ToolName   string `json:"tool_name,omitempty"`
+}
+
+type TranslationLanguage struct {
+	Code string `json:"code,omitempty"`
+	Name string `json:"name,omitempty"`
+	// 该语言配置的TTS音色
+	Voice string `json:"voice,omitempty"`
+}
+
+type TranslationSessionInfo struct {
+	Active    bool   `json:"active,omitempty"`
+	DeviceID  string `json:"device_id,omitempty"`
+	Source    string `json:"source,omitempty"`
+	StartedAt string `json:"started_at,omitempty"`
+	Target    string `json:"target,omitempty"`
+}
+
+type TranslationStartRequest struct {
+	// 源语言代码，auto 表示自动识别
+	Source string `json:"source,omitempty"`
+	// 目标语言代码
+	Target string `json:"target,omitempty"`
+}
+
+type TurnLatencyInfo struct {
+	// 说话结束到识别结果
+	ASRMs int64 `json:"asr_ms,omitempty"`
+	// 开始说话到说话结束
+	CaptureMs int64  `json:"capture_ms,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	// 识别结果到LLM输出结束
+	LLMCompleteMs int64 `json:"llm_complete_ms,omitempty"`
+	// 识别结果到LLM首个输出
+	LLMFirstTokenMs int64 `json:"llm_first_token_ms,omitempty"`
+	// 识别结果到首帧音频下发
+	PlaybackStartMs int64 `json:"playback_start_ms,omitempty"`
+	Round           int64 `json:"round,omitempty"`
+	// 说话结束到首帧音频下发
+	TotalMs int64 `json:"total_ms,omitempty"`
+	// 首句送入TTS到首句音频合成完成
+	TTSFirstByteMs int64 `json:"tts_first_byte_ms,omitempty"`
+}
+
+type Type string
+
+const (
+	TypeLLM         Type = "llm"
+	TypeASR         Type = "asr"
+	TypeTTS         Type = "tts"
+	TypeTool        Type = "tool"
+	TypeNode        Type = "node"
+	TypeTranslation Type = "translation"
+	TypeEmbedding   Type = "embedding"
+	TypeSearch      Type = "search"
+	TypeMedia       Type = "media"
+	TypeSmartHome   Type = "smart_home"
+)
+
+type UIHints struct {
+	// 节点面板中的分组
+	Category string `json:"category,omitempty"`
+	Color    string `json:"color,omitempty"`
+	Icon     string `json:"icon,omitempty"`
+	// 配置字段 -> 编辑控件（textarea、select、code 等）
+	Widgets map[string]string `json:"widgets,omitempty"`
+}
+
+type Validation struct {
+	Enum      []string `json:"enum,omitempty"`
+	Max       float64  `json:"max,omitempty"`
+	MaxLength int64    `json:"max_length,omitempty"`
+	Min       float64  `json:"min,omitempty"`
+	MinLength int64    `json:"min_length,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+}
+
+type WebhookAttempt struct {
+	At         string `json:"at,omitempty"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+	Error      string `json:"error,omitempty"`
+	// 截取前 1KB
+	ResponseBody string `json:"response_body,omitempty"`
+	// 未收到响应时为 0
+	StatusCode int64 `json:"status_code,omitempty"`
+}
+
+type WebhookCreateRequest struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// device.offline, plugin.crashed, workflow.failed, budget.exceeded 或 *
+	Events []string `json:"events"`
+	Name   string   `json:"name"`
+	// 签名密钥，为空时自动生成
+	Secret string `json:"secret,omitempty"`
+	URL    string `json:"url"`
+}
+
+type WebhookDeliveryInfo struct {
+	Attempts    int64  `json:"attempts,omitempty"`
+	CreatedAt   string `json:"created_at,omitempty"`
+	DeliveredAt string `json:"delivered_at,omitempty"`
+	EndpointID  string `json:"endpoint_id,omitempty"`
+	Error       string `json:"error,omitempty"`
+	Event       string `json:"event,omitempty"`
+	// 同一事件发往各端点及重试时相同
+	EventID       string           `json:"event_id,omitempty"`
+	History       []WebhookAttempt `json:"history,omitempty"`
+	ID            string           `json:"id,omitempty"`
+	NextAttemptAt string           `json:"next_attempt_at,omitempty"`
+	// 请求体 JSON
+	Payload    string `json:"payload,omitempty"`
+	Status     string `json:"status,omitempty"`
+	StatusCode int64  `json:"status_code,omitempty"`
+	UpdatedAt  string `json:"updated_at,omitempty"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDeliveryInfo `json:"deliveries,omitempty"`
+	Pagination *Pagination           `json:"pagination,omitempty"`
+}
+
+type WebhookInfo struct {
+	CreatedAt string   `json:"created_at,omitempty"`
+	Enabled   bool     `json:"enabled,omitempty"`
+	Events    []string `json:"events,omitempty"`
+	ID        string   `json:"id,omitempty"`
+	Name      string   `json:"name,omitempty"`
+	Secret    string   `json:"secret,omitempty"`
+	UpdatedAt string   `json:"updated_at,omitempty"`
+	URL       string   `json:"url,omitempty"`
+}
+
+type WebhookUpdateRequest struct {
+	Enabled bool     `json:"enabled,omitempty"`
+	Events  []string `json:"events,omitempty"`
+	Name    string   `json:"name,omitempty"`
+	// 传 ****** 表示保留原值
+	Secret string `json:"secret,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+type Workflow struct {
+	Config      *WorkflowConfig `json:"config,omitempty"`
+	CreatedAt   string          `json:"created_at,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Edges       []Edge          `json:"edges,omitempty"`
+	ID          string          `json:"id,omitempty"`
+	Name        string          `json:"name,omitempty"`
+	Nodes       []Node          `json:"nodes,omitempty"`
+	UpdatedAt   string          `json:"updated_at,omitempty"`
+	Version     string          `json:"version,omitempty"`
+}
+
+type WorkflowConfig struct {
+	// 启用日志
+	EnableLog bool `json:"enable_log,omitempty"`
+	// 最大重试次数
+	MaxRetries int64 `json:"max_retries,omitempty"`
+	// 并行执行限制
+	ParallelLimit int64 `json:"parallel_limit,omitempty"`
+	// 执行超时时间
+	Timeout Duration `json:"timeout,omitempty"`
+	// 全局变量
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type WorkflowTemplate struct {
+	Builtin     bool                `json:"builtin,omitempty"`
+	Category    string              `json:"category,omitempty"`
+	Description string              `json:"description,omitempty"`
+	ID          string              `json:"id,omitempty"`
+	Name        string              `json:"name,omitempty"`
+	Parameters  []TemplateParameter `json:"parameters,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Workflow    *Workflow           `json:"workflow,omitempty"`
+}
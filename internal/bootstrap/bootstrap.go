@@ -3,12 +3,14 @@ package bootstrap
 import (
 	"context"
 	"crypto/md5"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
@@ -20,8 +22,12 @@ import (
 	llminfra "xiaozhi-server-go/internal/domain/llm/infrastructure"
 	llmrepo "xiaozhi-server-go/internal/domain/llm/repository"
 	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/plugin/grpc/certs"
 	"xiaozhi-server-go/internal/plugin/grpc/discovery"
 	"xiaozhi-server-go/internal/plugin/grpc/lifecycle"
+	pluginserver "xiaozhi-server-go/internal/plugin/grpc/server"
+	pluginlogs "xiaozhi-server-go/internal/plugin/logs"
+	pluginmetrics "xiaozhi-server-go/internal/plugin/metrics"
 	"xiaozhi-server-go/internal/plugin/providers/chatglm"
 	"xiaozhi-server-go/internal/plugin/providers/coze"
 	"xiaozhi-server-go/internal/plugin/providers/deepgram"
@@ -31,29 +37,81 @@ import (
 	"xiaozhi-server-go/internal/plugin/providers/ollama"
 		"xiaozhi-server-go/internal/plugin/providers/openai"
 	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/plugin/providers/skills"
+	smarthomeprovider "xiaozhi-server-go/internal/plugin/providers/smarthome"
 	"xiaozhi-server-go/internal/plugin/providers/stepfun"
+	"xiaozhi-server-go/internal/plugin/providers/websearch"
+	_ "xiaozhi-server-go/internal/plugin/providers/whispercpp" // 注册离线模式使用的 whisper.cpp ASR
 	llmadapters "xiaozhi-server-go/internal/core/adapters"
 	configmanager "xiaozhi-server-go/internal/domain/config/manager"
 	"xiaozhi-server-go/internal/domain/config/types"
 	"xiaozhi-server-go/internal/domain/device/service"
 	"xiaozhi-server-go/internal/domain/device/repository"
 		"xiaozhi-server-go/internal/domain/eventbus"
+	"xiaozhi-server-go/internal/domain/evaluation"
+	"xiaozhi-server-go/internal/domain/experiment"
+	"xiaozhi-server-go/internal/domain/flags"
+	"xiaozhi-server-go/internal/domain/analytics"
+	"xiaozhi-server-go/internal/domain/budget"
+	"xiaozhi-server-go/internal/domain/notification"
+	"xiaozhi-server-go/internal/domain/pipeline"
+	"xiaozhi-server-go/internal/domain/agent"
+	"xiaozhi-server-go/internal/domain/script"
+	"xiaozhi-server-go/internal/domain/speechtext"
+	"xiaozhi-server-go/internal/domain/language"
+	skillsservice "xiaozhi-server-go/internal/domain/skills"
+	"xiaozhi-server-go/internal/domain/devicedebug"
+	"xiaozhi-server-go/internal/domain/media"
+	"xiaozhi-server-go/internal/domain/monitor"
+	"xiaozhi-server-go/internal/domain/presence"
+	"xiaozhi-server-go/internal/domain/offline"
+	"xiaozhi-server-go/internal/domain/routine"
+	"xiaozhi-server-go/internal/domain/smarthome"
+	websearchservice "xiaozhi-server-go/internal/domain/websearch"
+	"xiaozhi-server-go/internal/domain/prompt"
+	"xiaozhi-server-go/internal/domain/provisioning"
+	"xiaozhi-server-go/internal/domain/member"
+	"xiaozhi-server-go/internal/domain/intent"
+	"xiaozhi-server-go/internal/domain/knowledge"
+	"xiaozhi-server-go/internal/domain/job"
+	"xiaozhi-server-go/internal/domain/objectstore"
+	"xiaozhi-server-go/internal/domain/backup"
+	"xiaozhi-server-go/internal/domain/memory"
+	"xiaozhi-server-go/internal/domain/reminder"
+	"xiaozhi-server-go/internal/domain/tenant"
+	"xiaozhi-server-go/internal/domain/recording"
+	"xiaozhi-server-go/internal/domain/transcript"
+	"xiaozhi-server-go/internal/domain/translation"
+	"xiaozhi-server-go/internal/domain/webhook"
+	"xiaozhi-server-go/internal/domain/providercall"
+	"xiaozhi-server-go/internal/domain/replay"
+	"xiaozhi-server-go/internal/domain/toolpolicy"
+	"xiaozhi-server-go/internal/platform/chaos"
+	"xiaozhi-server-go/internal/platform/drain"
 	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/httpclient"
 	platformlogging "xiaozhi-server-go/internal/platform/logging"
 	platformobservability "xiaozhi-server-go/internal/platform/observability"
 	platformstorage "xiaozhi-server-go/internal/platform/storage"
+	"xiaozhi-server-go/internal/platform/selftest"
+	"xiaozhi-server-go/internal/platform/redaction"
 	platformconfig "xiaozhi-server-go/internal/platform/config"
+	graphqltransport "xiaozhi-server-go/internal/transport/graphql"
+	grpctransport "xiaozhi-server-go/internal/transport/grpc"
 	httptransport "xiaozhi-server-go/internal/transport/http"
+	httpmiddleware "xiaozhi-server-go/internal/transport/http/middleware"
 	httpvision "xiaozhi-server-go/internal/transport/http/vision"
 	httpwebapi "xiaozhi-server-go/internal/transport/http/webapi"
 	httpota "xiaozhi-server-go/internal/transport/http/ota"
 	devicev1 "xiaozhi-server-go/internal/transport/http/v1"
 	"xiaozhi-server-go/internal/plugin/ports"
 	"xiaozhi-server-go/internal/plugin/status"
+	"xiaozhi-server-go/internal/core"
 	"xiaozhi-server-go/internal/core/transport"
 	"xiaozhi-server-go/internal/contracts/adapters"
 	"xiaozhi-server-go/internal/contracts/config/integration"
 	"xiaozhi-server-go/internal/utils"
+	"xiaozhi-server-go/internal/workflow"
 
 	"github.com/gin-gonic/gin"
 	"github.com/swaggo/swag"
@@ -88,7 +146,9 @@ type initStep struct {
 	Title     string
 	DependsOn []string
 	Kind      platformerrors.Kind
+	Optional  bool // 可通过 Startup.Skip 配置跳过，依赖它的步骤随之跳过
 	Execute   stepFn
+	Check     checkFn // 自检时代替 Execute 执行的只读检查，为空时自检不记录该步骤
 }
 
 type appState struct {
@@ -109,14 +169,20 @@ type appState struct {
 	// 新增：动态端口和状态管理器
 	portManager           *ports.PortManager         // 动态端口管理器
 	pluginStatusManager   *status.PluginStatusManager // 插件状态管理器
+	redactor              *redaction.Redactor         // 敏感信息脱敏器，未启用时为nil
+	logEntries            platformlogging.EntryStore  // 日志索引，未启用时为nil
+	startup               *workflow.StartupRecord     // 启动流程记录，为nil时不记录
 }
 
 // Run 启动整个服务生命周期，负责加载配置、初始化依赖和优雅关停。
 func Run(ctx context.Context) error {
-	state := &appState{}
-
 	steps := InitGraph()
-	if err := executeInitSteps(ctx, steps, state); err != nil {
+	state := &appState{startup: workflow.NewStartupRecord(startupSteps(steps))}
+	workflow.SetStartupRecord(state.startup)
+
+	err := executeInitSteps(ctx, steps, state)
+	state.startup.Finish(err)
+	if err != nil {
 		return err
 	}
 
@@ -140,7 +206,7 @@ func Run(ctx context.Context) error {
 		)
 	}
 
-	logBootstrapGraph(steps, logger)
+	logBootstrapGraph(steps, state.startup, logger)
 
 	if shutdown := state.observabilityShutdown; shutdown != nil {
 		defer func() {
@@ -152,12 +218,15 @@ func Run(ctx context.Context) error {
 		}()
 	}
 
-	rootCtx, cancel := context.WithCancel(ctx)
+	rootCtx, cancelRoot := context.WithCancelCause(ctx)
+	cancel := func() { cancelRoot(context.Canceled) }
 	defer cancel()
 
 	signalCtx, stop := signal.NotifyContext(rootCtx, syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	startDrainController(config, logger, rootCtx, cancelRoot)
+
 	group, groupCtx := errgroup.WithContext(rootCtx)
 
 	if err := startServices(state, group, groupCtx); err != nil {
@@ -165,7 +234,9 @@ func Run(ctx context.Context) error {
 		return err
 	}
 
-	if err := waitForShutdown(signalCtx, cancel, logger, group); err != nil {
+	err = waitForShutdown(signalCtx, cancel, logger, group)
+	httpclient.Default().CloseIdleConnections()
+	if err != nil {
 		return err
 	}
 
@@ -174,7 +245,33 @@ func Run(ctx context.Context) error {
 	return nil
 }
 
-func logBootstrapGraph(steps []initStep, logger *platformlogging.Logger) {
+// startDrainController 创建排空控制器：收到 SIGUSR1 或排空接口请求后拒绝新会话和工作流，
+// 活跃工作结束或到达截止时间后走正常的关停流程
+func startDrainController(
+	config *platformconfig.Config,
+	logger *platformlogging.Logger,
+	ctx context.Context,
+	cancel context.CancelCauseFunc,
+) {
+	controller := drain.NewController(logger)
+	controller.OnDrained(func() {
+		cancel(drain.ErrDraining)
+	})
+	drain.SetDefault(controller)
+
+	usr1 := make(chan os.Signal, 1)
+	signal.Notify(usr1, syscall.SIGUSR1)
+	go func() {
+		defer signal.Stop(usr1)
+		select {
+		case <-usr1:
+			controller.Start(config.Server.DrainTimeout, "SIGUSR1")
+		case <-ctx.Done():
+		}
+	}()
+}
+
+func logBootstrapGraph(steps []initStep, record *workflow.StartupRecord, logger *platformlogging.Logger) {
 	if logger == nil {
 		return
 	}
@@ -197,13 +294,38 @@ func logBootstrapGraph(steps []initStep, logger *platformlogging.Logger) {
 	}
 
 	for _, step := range steps {
-		if name, ok := stepNames[step.ID]; ok {
-			logger.InfoTag("引导", name)
+		name, ok := stepNames[step.ID]
+		if !ok {
+			continue
+		}
+		if record != nil {
+			if elapsed, done := record.Duration(step.ID); done {
+				logger.InfoTag("引导", "%s (%v)", name, elapsed.Round(time.Millisecond))
+				continue
+			}
 		}
+		logger.InfoTag("引导", name)
+	}
+	if record != nil {
+		report := record.Report()
+		logger.InfoTag("引导", "初始化耗时 %v（步骤累计 %v），关键路径: %s",
+			report.Duration.Round(time.Millisecond), report.StepTotal.Round(time.Millisecond),
+			strings.Join(report.CriticalPath, " -> "))
 	}
 	logger.InfoTag("引导", "启动服务")
 }
 
+// defaultInitStepTimeout 配置加载之前的步骤和未配置 Startup.StepTimeout 时的单步超时
+const defaultInitStepTimeout = 2 * time.Minute
+
+// initResult 步骤执行结果
+type initResult struct {
+	step initStep
+	err  error
+}
+
+// executeInitSteps 按依赖关系执行初始化步骤：依赖已完成的步骤并行执行，每个步骤有独立的超时；
+// 任一步骤失败时取消其余步骤，等待它们退出后返回第一个错误
 func executeInitSteps(ctx context.Context, steps []initStep, state *appState) error {
 	if state == nil {
 		return platformerrors.New(
@@ -213,39 +335,226 @@ func executeInitSteps(ctx context.Context, steps []initStep, state *appState) er
 		)
 	}
 
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	completed := make(map[string]struct{}, len(steps))
-	for _, step := range steps {
-		for _, dep := range step.DependsOn {
-			if _, ok := completed[dep]; !ok {
-				return platformerrors.New(
+	skipped := make(map[string]struct{})
+	remaining := append([]initStep(nil), steps...)
+	results := make(chan initResult)
+	running := 0
+	var firstErr error
+
+	// 步骤运行时会写入 state，只在没有步骤运行时读取配置和日志，避免并发读写
+	var config *platformconfig.Config
+	var logger *platformlogging.Logger
+
+	for {
+		if running == 0 {
+			config, logger = state.config, state.logger
+		}
+
+		for firstErr == nil && len(remaining) > 0 {
+			if config != nil && config.Startup.MaxParallel > 0 && running >= config.Startup.MaxParallel {
+				break
+			}
+			// 配置加载后按 Startup.Order 调整剩余步骤的顺序，依赖关系始终优先
+			if config != nil {
+				orderInitSteps(remaining, config.Startup.Order)
+			}
+
+			index := -1
+			for i, step := range remaining {
+				if initDependenciesDone(step, completed, skipped) {
+					index = i
+					break
+				}
+			}
+			if index < 0 {
+				break
+			}
+			step := remaining[index]
+			remaining = append(remaining[:index], remaining[index+1:]...)
+
+			if reason, skip := initSkipReason(step, config, skipped); skip {
+				if !step.Optional {
+					firstErr = platformerrors.New(
+						platformerrors.KindBootstrap,
+						step.ID,
+						fmt.Sprintf("required step cannot be skipped (%s)", reason),
+					)
+					break
+				}
+				skipped[step.ID] = struct{}{}
+				if state.startup != nil {
+					state.startup.StepSkipped(step.ID, reason)
+				}
+				if logger != nil {
+					logger.InfoTag("引导", "跳过启动步骤 %s: %s", step.ID, reason)
+				}
+				continue
+			}
+
+			if step.Execute == nil {
+				firstErr = platformerrors.New(
 					platformerrors.KindBootstrap,
 					step.ID,
-					fmt.Sprintf("dependency %s not satisfied", dep),
+					"missing execute function",
 				)
+				break
 			}
+			timeout := initStepTimeout(step, config)
+			if state.startup != nil {
+				state.startup.StepStarted(step.ID, timeout)
+			}
+			running++
+			go func(step initStep) {
+				results <- initResult{step: step, err: runInitStep(ctx, step, state, timeout)}
+			}(step)
 		}
-		if step.Execute == nil {
-			return platformerrors.New(
-				platformerrors.KindBootstrap,
-				step.ID,
-				"missing execute function",
-			)
+
+		if running == 0 {
+			if firstErr != nil || len(remaining) == 0 {
+				return firstErr
+			}
+			step := remaining[0]
+			for _, dep := range step.DependsOn {
+				if _, ok := completed[dep]; !ok {
+					return platformerrors.New(
+						platformerrors.KindBootstrap,
+						step.ID,
+						fmt.Sprintf("dependency %s not satisfied", dep),
+					)
+				}
+			}
+			return platformerrors.New(platformerrors.KindBootstrap, step.ID, "no runnable init step")
 		}
-		if err := step.Execute(ctx, state); err != nil {
+
+		result := <-results
+		running--
+		step := result.step
+		if err := result.err; err != nil {
+			if state.startup != nil {
+				state.startup.StepFailed(step.ID, err)
+			}
+			if firstErr != nil {
+				continue
+			}
+			cancel()
 			var typed *platformerrors.Error
 			if errors.As(err, &typed) {
-				return err
+				firstErr = err
+				continue
 			}
 
 			kind := step.Kind
 			if kind == "" {
 				kind = platformerrors.KindBootstrap
 			}
-			return platformerrors.Wrap(kind, step.ID, "bootstrap step failed", err)
+			firstErr = platformerrors.Wrap(kind, step.ID, "bootstrap step failed", err)
+			continue
+		}
+		if state.startup != nil {
+			state.startup.StepCompleted(step.ID)
 		}
 		completed[step.ID] = struct{}{}
 	}
-	return nil
+}
+
+// runInitStep 在超时时间内执行步骤；超时或被取消时不再等待步骤返回，启动随之失败
+func runInitStep(ctx context.Context, step initStep, state *appState, timeout time.Duration) error {
+	stepCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- step.Execute(stepCtx, state)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-stepCtx.Done():
+		if ctx.Err() != nil {
+			return fmt.Errorf("cancelled because another init step failed: %w", ctx.Err())
+		}
+		return fmt.Errorf("timed out after %v", timeout)
+	}
+}
+
+// initStepTimeout 返回步骤的超时时间：Startup.Timeouts 中的单独设置优先，其次 Startup.StepTimeout
+func initStepTimeout(step initStep, config *platformconfig.Config) time.Duration {
+	if config != nil {
+		if timeout := config.Startup.Timeouts[step.ID]; timeout > 0 {
+			return timeout
+		}
+		if config.Startup.StepTimeout > 0 {
+			return config.Startup.StepTimeout
+		}
+	}
+	return defaultInitStepTimeout
+}
+
+// initDependenciesDone 步骤的依赖是否都已完成或被跳过
+func initDependenciesDone(step initStep, completed, skipped map[string]struct{}) bool {
+	for _, dep := range step.DependsOn {
+		_, done := completed[dep]
+		_, skip := skipped[dep]
+		if !done && !skip {
+			return false
+		}
+	}
+	return true
+}
+
+// initSkipReason 判断步骤是否需要跳过：配置中列出，或依赖的步骤已被跳过
+func initSkipReason(step initStep, config *platformconfig.Config, skipped map[string]struct{}) (string, bool) {
+	for _, dep := range step.DependsOn {
+		if _, ok := skipped[dep]; ok {
+			return fmt.Sprintf("dependency %s skipped", dep), true
+		}
+	}
+	if config == nil {
+		return "", false
+	}
+	for _, id := range config.Startup.Skip {
+		if id == step.ID {
+			return "skipped by configuration", true
+		}
+	}
+	return "", false
+}
+
+// orderInitSteps 按配置的顺序稳定排序：列出的步骤按列出顺序靠前，其余保持原顺序
+func orderInitSteps(steps []initStep, order []string) {
+	if len(order) == 0 {
+		return
+	}
+	rank := make(map[string]int, len(order))
+	for i, id := range order {
+		rank[id] = i
+	}
+	sort.SliceStable(steps, func(i, j int) bool {
+		ri, okI := rank[steps[i].ID]
+		rj, okJ := rank[steps[j].ID]
+		if okI && okJ {
+			return ri < rj
+		}
+		return okI && !okJ
+	})
+}
+
+// startupSteps 将初始化步骤转换为启动工作流的步骤描述
+func startupSteps(steps []initStep) []workflow.StartupStep {
+	result := make([]workflow.StartupStep, len(steps))
+	for i, step := range steps {
+		result[i] = workflow.StartupStep{
+			ID:        step.ID,
+			Title:     step.Title,
+			DependsOn: step.DependsOn,
+			Optional:  step.Optional,
+		}
+	}
+	return result
 }
 
 func InitGraph() []initStep {
@@ -257,10 +566,19 @@ func InitGraph() []initStep {
 			Execute: initStorageStep,
 		},
 		{
-			ID:      "storage:init-database",
-			Title:   "Initialise database",
+			ID:      "storage:apply-restore",
+			Title:   "Apply pending backup restore",
 			Kind:    platformerrors.KindStorage,
-			Execute: initDatabaseStep,
+			Execute: applyPendingRestoreStep,
+			Check:   checkPendingRestore,
+		},
+		{
+			ID:        "storage:init-database",
+			Title:     "Initialise database",
+			DependsOn: []string{"storage:apply-restore"},
+			Kind:      platformerrors.KindStorage,
+			Execute:   initDatabaseStep,
+			Check:     checkDatabase,
 		},
 		{
 			ID:        "config:load-default",
@@ -268,6 +586,7 @@ func InitGraph() []initStep {
 			DependsOn: []string{"storage:init-config-store", "storage:init-database"},
 			Kind:      platformerrors.KindConfig,
 			Execute:   loadDefaultConfigStep,
+			Check:     checkConfig,
 		},
 		{
 			ID:        "logging:init-provider",
@@ -275,20 +594,24 @@ func InitGraph() []initStep {
 			DependsOn: []string{"config:load-default"},
 			Kind:      platformerrors.KindBootstrap,
 			Execute:   initLoggingStep,
+			Check:     checkLogging,
 		},
 		{
 			ID:        "llm:init-manager",
 			Title:     "Initialise LLM manager",
-			DependsOn: []string{"config:load-default"},
+			DependsOn: []string{"logging:init-provider"},
 			Kind:      platformerrors.KindBootstrap,
 			Execute:   initLLMManagerStep,
+			Check:     checkProviders,
 		},
 		{
 			ID:        "plugin:init-port-manager",
 			Title:     "Initialise plugin port manager",
 			DependsOn: []string{"logging:init-provider"},
 			Kind:      platformerrors.KindBootstrap,
+			Optional:  true,
 			Execute:   initPluginPortManagerStep,
+			Check:     checkPorts,
 		},
 		{
 			ID:        "mcp:init-manager",
@@ -296,19 +619,23 @@ func InitGraph() []initStep {
 			DependsOn: []string{"logging:init-provider"},
 			Kind:      platformerrors.KindBootstrap,
 			Execute:   initMCPManagerStep,
+			Check:     checkMCPServers,
 		},
 	{
 			ID:        "plugin:init-status-manager",
 			Title:     "Initialise plugin status manager",
 			DependsOn: []string{"plugin:init-port-manager", "llm:init-manager"},
 			Kind:      platformerrors.KindBootstrap,
+			Optional:  true,
 			Execute:   initPluginStatusManagerStep,
+			Check:     checkPluginCerts,
 		},
 		{
 			ID:        "observability:setup-hooks",
 			Title:     "Setup observability hooks",
 			DependsOn: []string{"logging:init-provider"},
 			Kind:      platformerrors.KindBootstrap,
+			Optional:  true,
 			Execute:   setupObservabilityStep,
 		},
 		{
@@ -316,6 +643,7 @@ func InitGraph() []initStep {
 			Title:     "Initialise config integrator",
 			DependsOn: []string{"logging:init-provider"},
 			Kind:      platformerrors.KindBootstrap,
+			Optional:  true,
 			Execute:   initConfigIntegratorStep,
 		},
 		}
@@ -330,6 +658,9 @@ func initLLMManagerStep(_ context.Context, state *appState) error {
 		)
 	}
 
+	// 提供者共享的HTTP连接池，需在创建任何提供者客户端之前设置
+	httpclient.SetDefault(httpclient.NewPool(state.config.HTTPClient))
+
 	// Initialize Capability Registry
 	registry := capability.NewRegistry()
 
@@ -342,11 +673,29 @@ func initLLMManagerStep(_ context.Context, state *appState) error {
 	registry.Register("gosherpa", gosherpa.NewProvider())
 	registry.Register("ollama", ollama.NewProvider())
 	registry.Register("openai", openai.NewProvider())
+	registry.Register("skills", skills.NewProvider())
+	registry.Register("smarthome", smarthomeprovider.NewProvider())
 	registry.Register("stepfun", stepfun.NewProvider())
+	registry.Register("websearch", websearch.NewProvider())
 
 	// Register Legacy Adapters
 	llmadapters.RegisterLegacyAdapters()
 
+	if scheduling := state.config.Scheduling; scheduling.Enabled {
+		registry.SetLimiter(capability.NewPriorityQueue(capability.PriorityQueueConfig{
+			MaxConcurrent: scheduling.MaxConcurrent,
+			QueueDepths: map[capability.Priority]int{
+				capability.PriorityInteractive: scheduling.InteractiveQueueDepth,
+				capability.PriorityAPI:         scheduling.APIQueueDepth,
+				capability.PriorityBatch:       scheduling.BatchQueueDepth,
+			},
+			MaxQueued: scheduling.MaxQueued,
+			MaxWait:   scheduling.MaxWait,
+		}))
+	}
+
+	registry.SetValidationPolicy(newValidationPolicy(state.config.Validation, state.logger))
+
 	state.registry = registry
 
 	// Plugin API Registry is no longer needed in gRPC architecture
@@ -358,17 +707,29 @@ func initLLMManagerStep(_ context.Context, state *appState) error {
 		pluginLogger = platformlogging.DefaultLogger
 	}
 
+	// 插件日志同时写入各自的日志缓冲区，供Web界面查看
+	pluginLogs := newPluginLogManager(state.config)
+	loggerFor := func(pluginID string) *platformlogging.Logger {
+		if pluginLogger == nil {
+			return nil
+		}
+		return pluginLogger.Tee(pluginLogs.Writer(pluginID, pluginlogs.StreamStdout))
+	}
+
 	// Register plugins directly with capability registry for gRPC architecture
 	plugins := map[string]capability.Provider{
-		"chatglm": chatglm.NewProviderWithLogger(pluginLogger),
-		"coze":     coze.NewProviderWithLogger(pluginLogger),
-		"deepgram": deepgram.NewProviderWithLogger(pluginLogger),
-		"doubao":   doubao.NewProviderWithLogger(pluginLogger),
-		"edge":     edge.NewProviderWithLogger(pluginLogger),
-		"gosherpa": gosherpa.NewProviderWithLogger(pluginLogger),
-		"ollama":   ollama.NewProviderWithLogger(pluginLogger),
-		"openai":   openai.NewProviderWithLogger(pluginLogger),
-		"stepfun":  stepfun.NewProviderWithLogger(pluginLogger),
+		"chatglm": chatglm.NewProviderWithLogger(loggerFor("chatglm")),
+		"coze":     coze.NewProviderWithLogger(loggerFor("coze")),
+		"deepgram": deepgram.NewProviderWithLogger(loggerFor("deepgram")),
+		"doubao":   doubao.NewProviderWithLogger(loggerFor("doubao")),
+		"edge":     edge.NewProviderWithLogger(loggerFor("edge")),
+		"gosherpa": gosherpa.NewProviderWithLogger(loggerFor("gosherpa")),
+		"ollama":   ollama.NewProviderWithLogger(loggerFor("ollama")),
+		"openai":   openai.NewProviderWithLogger(loggerFor("openai")),
+		"skills":   skills.NewProviderWithLogger(loggerFor("skills")),
+		"smarthome": smarthomeprovider.NewProviderWithLogger(loggerFor("smarthome")),
+		"stepfun":  stepfun.NewProviderWithLogger(loggerFor("stepfun")),
+		"websearch": websearch.NewProviderWithLogger(loggerFor("websearch")),
 	}
 
 	// Register plugins with capability registry
@@ -386,6 +747,7 @@ func initLLMManagerStep(_ context.Context, state *appState) error {
 
 	// Initialize Plugin Lifecycle Manager
 	pluginLifecycle := lifecycle.NewLifecycleManager(registry, pluginDiscovery, state.logger)
+	pluginLifecycle.SetLogManager(pluginLogs)
 	state.pluginLifecycle = pluginLifecycle
 
 	if state.logger != nil {
@@ -397,6 +759,11 @@ func initLLMManagerStep(_ context.Context, state *appState) error {
 		return platformerrors.Wrap(platformerrors.KindBootstrap, "plugin:auto-discover", "failed to auto-discover plugins", err)
 	}
 
+	// 插件以 node 类型能力声明的自定义工作流节点注册到节点注册表
+	if count := workflow.DefaultNodeRegistry().SyncCapabilities(registry); count > 0 && state.logger != nil {
+		state.logger.InfoTag("引导", "已注册 %d 个插件工作流节点", count)
+	}
+
 	// Start plugin health check loop
 	go pluginDiscovery.StartHealthCheckLoop(context.Background(), 30*time.Second)
 
@@ -420,6 +787,18 @@ func initStorageStep(_ context.Context, _ *appState) error {
 	return nil
 }
 
+// applyPendingRestoreStep 应用通过接口暂存的备份恢复，必须在打开数据库之前执行
+func applyPendingRestoreStep(_ context.Context, _ *appState) error {
+	manifest, err := backup.ApplyPending(backup.PendingDir, time.Now())
+	if err != nil {
+		return platformerrors.Wrap(platformerrors.KindStorage, "storage:apply-restore", "failed to apply pending restore", err)
+	}
+	if manifest != nil {
+		fmt.Printf("已从 %s 创建的备份恢复数据，原有文件已改名为 *.pre-restore-*\n", manifest.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+	}
+	return nil
+}
+
 func initDatabaseStep(ctx context.Context, state *appState) error {
 	// 注意：此时 logger 可能还没有初始化，所以不能使用
 
@@ -526,11 +905,37 @@ func initLoggingStep(_ context.Context, state *appState) error {
 		)
 	}
 
-	logProvider, err := platformlogging.New(platformlogging.Config{
+	redactor, err := redaction.NewFromConfig(state.config.Redaction)
+	if err != nil {
+		return platformerrors.Wrap(platformerrors.KindConfig, "logging:init-redaction", "invalid redaction config", err)
+	}
+	state.redactor = redactor
+
+	logConfig := platformlogging.Config{
 		Level:    state.config.Log.Level,
 		Dir:      state.config.Log.Dir,
 		Filename: state.config.Log.File,
-	})
+		Rotate: platformlogging.RotateOptions{
+			MaxSizeMB:     state.config.Log.MaxSizeMB,
+			MaxBackups:    state.config.Log.MaxBackups,
+			RetentionDays: state.config.Log.RetentionDays,
+			Compress:      state.config.Log.Compress,
+		},
+	}
+	if redactor != nil && state.config.Redaction.RedactLogs {
+		logConfig.Redactor = redactor
+	}
+	// 日志索引写入数据库，数据库在日志之前已初始化
+	if indexCfg := state.config.Log.Index; indexCfg.Enabled && platformstorage.GetDB() != nil {
+		state.logEntries = platformstorage.NewLogRepository(platformstorage.GetDB())
+		logConfig.Index = &platformlogging.IndexOptions{
+			Store:         state.logEntries,
+			Level:         platformlogging.ParseLevel(indexCfg.Level),
+			RetentionDays: indexCfg.RetentionDays,
+			BufferSize:    indexCfg.BufferSize,
+		}
+	}
+	logProvider, err := platformlogging.New(logConfig)
 	if err != nil {
 		return platformerrors.Wrap(platformerrors.KindBootstrap, "logging:init-provider", "failed to initialize logging provider", err)
 	}
@@ -680,7 +1085,7 @@ func startTransportServer(
 	registry *capability.Registry,
 	g *errgroup.Group,
 	groupCtx context.Context,
-) (adapters.TransportManager, error) {
+) (adapters.TransportManager, *transport.TextChat, error) {
 	// 创建传输适配器
 	transportAdapter := adapters.NewTransportAdapter(config, logger, deviceRepo, registry)
 
@@ -694,7 +1099,7 @@ func startTransportServer(
 
 	// 启动传输服务器
 	if err := transportAdapter.StartTransportServer(groupCtx, domainMCPManager); err != nil {
-		return nil, platformerrors.Wrap(
+		return nil, nil, platformerrors.Wrap(
 			platformerrors.KindTransport,
 			"transport:start-server",
 			"failed to start transport server",
@@ -725,8 +1130,16 @@ func startTransportServer(
 		return nil
 	})
 
+	// 释放空闲的文本对话会话
+	textChat := transportAdapter.GetTextChat()
+	if textChat != nil {
+		g.Go(func() error {
+			return textChat.Run(groupCtx)
+		})
+	}
+
 	logger.InfoTag("传输", "传输服务已成功启动（适配器模式）")
-	return transportManager, nil
+	return transportManager, textChat, nil
 }
 
 func startHTTPServer(
@@ -740,6 +1153,7 @@ func startHTTPServer(
 	pluginStatusManager *status.PluginStatusManager,
 	pluginLifecycle *lifecycle.LifecycleManager,
 	pluginDiscovery *discovery.DiscoveryService,
+	services *domainServices,
 	g *errgroup.Group,
 	groupCtx context.Context,
 ) (*http.Server, error) {
@@ -751,14 +1165,22 @@ func startHTTPServer(
 		return nil, platformerrors.Wrap(platformerrors.KindTransport, "webapi:new-service", "failed to create webapi service", err)
 	}
 
+	var pluginLogs *pluginlogs.Manager
+	if pluginLifecycle != nil {
+		pluginLogs = pluginLifecycle.Logs()
+	}
+
 	// 构建HTTP路由器，传入认证中间件和新的管理器
 	httpRouter, err := httptransport.Build(httptransport.Options{
 		Config:               config,
 		Logger:               logger,
 		AuthMiddleware:       webapiService.AuthMiddleware(),
+		TenantMiddleware:     httpmiddleware.TenantMiddleware(services.tenant, config.Server.Token),
 		Registry:             registry,
 		PortManager:          portManager,
 		PluginStatusManager:  pluginStatusManager,
+		PluginLogs:           pluginLogs,
+		WorkflowExecutor:     services.workflowExecutor,
 	})
 	if err != nil {
 		return nil, err
@@ -806,155 +1228,1589 @@ func startHTTPServer(
 		logger.ErrorTag("OTA", "OTA 服务初始化失败: %v", err)
 		return nil, platformerrors.Wrap(platformerrors.KindTransport, "ota:new-service", "failed to create ota service", err)
 	}
+	if services.objects != nil {
+		otaService.UseObjects(services.objects)
+	}
 
 	// 初始化V1设备服务
-	deviceServiceV1, err := devicev1.NewDeviceServiceV1(config, logger, transportManager)
+	deviceServiceV1, err := devicev1.NewDeviceServiceV1(config, logger, transportManager, services.tenant)
 	if err != nil {
 		logger.ErrorTag("API", "V1设备服务初始化失败: %v", err)
 		return nil, platformerrors.Wrap(platformerrors.KindTransport, "device-v1:new-service", "failed to create device v1 service", err)
 	}
 
-	// 注册服务路由
-	visionService.Register(groupCtx, apiGroup)
-	webapiService.Register(groupCtx, apiGroup)
-	otaService.Register(groupCtx, apiGroup)
-
-	// 如果有认证中间件，注册需要认证的接口到V1Secure
-	if httpRouter.V1Secure != nil {
-		deviceServiceV1.Register(httpRouter.V1Secure)     // 设备管理需要认证
-	} else {
-		// 没有认证中间件时，注册到普通V1路由
-		deviceServiceV1.Register(httpRouter.V1)
+	// 初始化V1租户服务
+	tenantServiceV1, err := devicev1.NewTenantServiceV1(config, logger, services.tenant)
+	if err != nil {
+		logger.ErrorTag("API", "V1租户服务初始化失败: %v", err)
+		return nil, platformerrors.Wrap(platformerrors.KindTransport, "tenant-v1:new-service", "failed to create tenant v1 service", err)
 	}
 
-	// 注意: 旧的systemServiceV1已被移除，现在使用新的动态插件管理系统
-	// 新API路径: /api/v1/plugins/
-
-	// 自动分配可用端口
-	port, err := utils.GetAvailablePort(config.Web.Port)
+	// 初始化V1 Token预算服务
+	budgetServiceV1, err := devicev1.NewBudgetServiceV1(logger, services.budget)
 	if err != nil {
-		logger.ErrorTag("HTTP", "自动分配端口失败: %v", err)
-		port = config.Web.Port // 如果自动分配失败，使用配置的端口
-	} else {
-		logger.InfoTag("HTTP", "自动分配端口: %d", port)
+		logger.ErrorTag("API", "V1 Token预算服务初始化失败: %v", err)
+		return nil, platformerrors.Wrap(platformerrors.KindTransport, "budget-v1:new-service", "failed to create budget v1 service", err)
 	}
 
-	httpServer := &http.Server{
-		Addr:    ":" + strconv.Itoa(port),
-		Handler: router,
+	// 初始化V1播报文本后处理服务
+	speechTextServiceV1, err := devicev1.NewSpeechTextServiceV1(logger, services.speechText)
+	if err != nil {
+		logger.ErrorTag("API", "V1播报文本后处理服务初始化失败: %v", err)
+		return nil, platformerrors.Wrap(platformerrors.KindTransport, "speech-text-v1:new-service", "failed to create speech text v1 service", err)
 	}
 
-	router.GET("/openapi.json", func(c *gin.Context) {
-		doc, err := swag.ReadDoc()
+	// 初始化V1远程设备调试服务（未启用远程调试时不注册）
+	var deviceDebugServiceV1 *devicev1.DeviceDebugServiceV1
+	if services.deviceDebug != nil {
+		deviceDebugServiceV1, err = devicev1.NewDeviceDebugServiceV1(logger, services.deviceDebug)
 		if err != nil {
-			logger.ErrorTag("HTTP", "生成 OpenAPI 文档失败: %v", err)
-			c.JSON(http.StatusInternalServerError, httptransport.APIResponse{
-				Success: false,
-				Data:    gin.H{"error": err.Error()},
-				Message: "failed to generate openapi spec",
-				Code:    http.StatusInternalServerError,
-			})
-			return
+			logger.ErrorTag("API", "V1远程设备调试服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "device-debug-v1:new-service", "failed to create device debug v1 service", err)
 		}
-		c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(doc))
-	})
-
-	router.GET("/docs", func(c *gin.Context) {
-		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(scalarHTML))
-	})
-
-	g.Go(func() error {
-		logger.InfoTag("HTTP", "Gin 服务已启动，访问地址 http://localhost:%d", port)
-		logger.InfoTag("HTTP", "OTA 服务入口: http://localhost:%d/api/ota/", port)
-		logger.InfoTag("HTTP", "在线文档入口: http://localhost:%d/docs", port)
+	}
 
-		go func() {
-			<-groupCtx.Done()
-			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-			defer cancel()
+	// 初始化V1扫码配对服务（未启用扫码配对时不注册）
+	var provisioningServiceV1 *devicev1.ProvisioningServiceV1
+	if services.provisioning != nil {
+		provisioningServiceV1, err = devicev1.NewProvisioningServiceV1(logger, services.provisioning)
+		if err != nil {
+			logger.ErrorTag("API", "V1扫码配对服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "provisioning-v1:new-service", "failed to create provisioning v1 service", err)
+		}
+	}
 
-			if err := httpServer.Shutdown(shutdownCtx); err != nil {
-				logger.ErrorTag("HTTP", "HTTP 服务关闭失败: %v", err)
-			} else {
-				logger.InfoTag("HTTP", "HTTP 服务已优雅关闭")
-			}
-		}()
+	// 初始化V1在线会话监控服务
+	monitorServiceV1, err := devicev1.NewMonitorServiceV1(logger, services.monitor)
+	if err != nil {
+		logger.ErrorTag("API", "V1在线会话监控服务初始化失败: %v", err)
+		return nil, platformerrors.Wrap(platformerrors.KindTransport, "monitor-v1:new-service", "failed to create monitor v1 service", err)
+	}
 
-		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			logger.ErrorTag("HTTP", "HTTP 服务启动失败: %v", err)
-			return err
+	// 初始化V1用量统计服务（未启用用量统计时不注册）
+	var analyticsServiceV1 *devicev1.AnalyticsServiceV1
+	if services.analytics != nil {
+		analyticsServiceV1, err = devicev1.NewAnalyticsServiceV1(logger, services.analytics)
+		if err != nil {
+			logger.ErrorTag("API", "V1用量统计服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "analytics-v1:new-service", "failed to create analytics v1 service", err)
 		}
-		return nil
-	})
+	}
 
-	return httpServer, nil
-}
+	// 初始化V1事件推送服务（未启用事件推送时不注册）
+	var webhookServiceV1 *devicev1.WebhookServiceV1
+	if services.webhook != nil {
+		webhookServiceV1, err = devicev1.NewWebhookServiceV1(logger, services.webhook)
+		if err != nil {
+			logger.ErrorTag("API", "V1事件推送服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "webhook-v1:new-service", "failed to create webhook v1 service", err)
+		}
+	}
 
-func waitForShutdown(
-	ctx context.Context,
-	cancel context.CancelFunc,
-	logger *logging.Logger,
-	g *errgroup.Group,
-) error {
-	<-ctx.Done()
-	logger.InfoTag("引导", "收到系统信号 %v，正在进行资源清理", context.Cause(ctx))
+	// 初始化V1语言服务
+	languageServiceV1, err := devicev1.NewLanguageServiceV1(logger, services.language)
+	if err != nil {
+		logger.ErrorTag("API", "V1语言服务初始化失败: %v", err)
+		return nil, platformerrors.Wrap(platformerrors.KindTransport, "language-v1:new-service", "failed to create language v1 service", err)
+	}
 
-	cancel()
+	// 初始化V1提醒服务
+	reminderServiceV1, err := devicev1.NewReminderServiceV1(logger, services.reminder)
+	if err != nil {
+		logger.ErrorTag("API", "V1提醒服务初始化失败: %v", err)
+		return nil, platformerrors.Wrap(platformerrors.KindTransport, "reminder-v1:new-service", "failed to create reminder v1 service", err)
+	}
 
-	done := make(chan error, 1)
-	go func() {
-		done <- g.Wait()
-	}()
+	// 初始化V1对话记录服务（未启用对话记录时不注册）
+	var conversationServiceV1 *devicev1.ConversationServiceV1
+	if services.transcript != nil {
+		conversationServiceV1, err = devicev1.NewConversationServiceV1(logger, services.transcript)
+		if err != nil {
+			logger.ErrorTag("API", "V1对话记录服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "conversation-v1:new-service", "failed to create conversation v1 service", err)
+		}
+	}
 
-	select {
-	case err := <-done:
+	// 初始化V1语音归档服务（未启用语音归档时不注册）
+	var recordingServiceV1 *devicev1.RecordingServiceV1
+	if services.recording != nil {
+		recordingServiceV1, err = devicev1.NewRecordingServiceV1(logger, services.recording)
 		if err != nil {
-			logger.ErrorTag("引导", "服务关闭过程中出现错误: %v", err)
-			return err
+			logger.ErrorTag("API", "V1语音归档服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "recording-v1:new-service", "failed to create recording v1 service", err)
 		}
-		logger.InfoTag("引导", "所有服务已成功关闭")
-	case <-time.After(15 * time.Second):
-		timeoutErr := errors.New("服务关闭超时")
-		logger.ErrorTag("引导", "服务关闭超时，已强制退出")
-		return timeoutErr
 	}
-	return nil
-}
 
-func startServices(
-	state *appState,
-	g *errgroup.Group,
-	groupCtx context.Context,
-) error {
-	// 初始化设备仓库
-	db := platformstorage.GetDB()
-	deviceRepo := platformstorage.NewDeviceRepository(db)
+	// 初始化V1用户数据删除服务，只删除已启用服务中的数据
+	erasers := map[string]devicev1.DeviceDataEraser{}
+	if services.recording != nil {
+		erasers["recordings"] = services.recording
+	}
+	if services.transcript != nil {
+		erasers["transcripts"] = services.transcript
+	}
+	if services.toolPolicy != nil {
+		erasers["tool_policy_audits"] = services.toolPolicy
+	}
+	if services.providerCall != nil {
+		erasers["provider_calls"] = services.providerCall
+	}
+	if services.deviceDebug != nil {
+		erasers["device_debug_audits"] = services.deviceDebug
+	}
+	if services.provisioning != nil {
+		erasers["device_credentials"] = services.provisioning
+	}
+	if services.memory != nil {
+		erasers["memory_facts"] = services.memory
+	}
+	privacyServiceV1, err := devicev1.NewPrivacyServiceV1(logger, erasers)
+	if err != nil {
+		logger.ErrorTag("API", "V1用户数据删除服务初始化失败: %v", err)
+		return nil, platformerrors.Wrap(platformerrors.KindTransport, "privacy-v1:new-service", "failed to create privacy v1 service", err)
+	}
 
-	transportManager, err := startTransportServer(state.config, state.logger, state.domainMCPManager, deviceRepo, state.registry, g, groupCtx)
+	// 初始化V1提示词模板服务
+	promptServiceV1, err := devicev1.NewPromptServiceV1(logger, services.prompt)
 	if err != nil {
-		return fmt.Errorf("启动 Transport 服务失败: %w", err)
+		logger.ErrorTag("API", "V1提示词模板服务初始化失败: %v", err)
+		return nil, platformerrors.Wrap(platformerrors.KindTransport, "prompt-v1:new-service", "failed to create prompt v1 service", err)
 	}
 
-	if _, err := startHTTPServer(state.config, state.logger, state.configRepo, transportManager, deviceRepo, state.registry, state.portManager, state.pluginStatusManager, state.pluginLifecycle, state.pluginDiscovery, g, groupCtx); err != nil {
-		return fmt.Errorf("启动 Http 服务失败: %w", err)
+	// 初始化V1家庭成员服务
+	memberServiceV1, err := devicev1.NewMemberServiceV1(logger, services.member)
+	if err != nil {
+		logger.ErrorTag("API", "V1家庭成员服务初始化失败: %v", err)
+		return nil, platformerrors.Wrap(platformerrors.KindTransport, "member-v1:new-service", "failed to create member v1 service", err)
 	}
 
-	return nil
-}
+	// 初始化V1实验服务
+	experimentServiceV1, err := devicev1.NewExperimentServiceV1(logger, services.experiment)
+	if err != nil {
+		logger.ErrorTag("API", "V1实验服务初始化失败: %v", err)
+		return nil, platformerrors.Wrap(platformerrors.KindTransport, "experiment-v1:new-service", "failed to create experiment v1 service", err)
+	}
 
-// loadConfigAndLogger 加载配置和日志记录器（用于测试）
-func loadConfigAndLogger() (*platformconfig.Config, *logging.Logger, error) {
-	state := &appState{}
+	// 初始化V1功能开关服务
+	flagServiceV1, err := devicev1.NewFlagServiceV1(logger, services.flags)
+	if err != nil {
+		logger.ErrorTag("API", "V1功能开关服务初始化失败: %v", err)
+		return nil, platformerrors.Wrap(platformerrors.KindTransport, "flag-v1:new-service", "failed to create flag v1 service", err)
+	}
 
-	// 执行必要的初始化步骤
-	steps := []initStep{
-		{
-			ID:      "storage:init-config-store",
-			Title:   "Initialise configuration store",
-			Kind:    platformerrors.KindStorage,
-			Execute: initStorageStep,
-		},
-		{
+	// 初始化V1评测服务
+	evaluationServiceV1, err := devicev1.NewEvaluationServiceV1(logger, services.evaluation)
+	if err != nil {
+		logger.ErrorTag("API", "V1评测服务初始化失败: %v", err)
+		return nil, platformerrors.Wrap(platformerrors.KindTransport, "evaluation-v1:new-service", "failed to create evaluation v1 service", err)
+	}
+
+	// 初始化V1通知渠道服务
+	notificationServiceV1, err := devicev1.NewNotificationServiceV1(logger, services.notification)
+	if err != nil {
+		logger.ErrorTag("API", "V1通知渠道服务初始化失败: %v", err)
+		return nil, platformerrors.Wrap(platformerrors.KindTransport, "notification-v1:new-service", "failed to create notification v1 service", err)
+	}
+
+	// 初始化V1脚本服务
+	scriptServiceV1, err := devicev1.NewScriptServiceV1(logger, services.script)
+	if err != nil {
+		logger.ErrorTag("API", "V1脚本服务初始化失败: %v", err)
+		return nil, platformerrors.Wrap(platformerrors.KindTransport, "script-v1:new-service", "failed to create script v1 service", err)
+	}
+
+	// 初始化V1智能体服务
+	agentServiceV1, err := devicev1.NewAgentServiceV1(logger, services.agent)
+	if err != nil {
+		logger.ErrorTag("API", "V1智能体服务初始化失败: %v", err)
+		return nil, platformerrors.Wrap(platformerrors.KindTransport, "agent-v1:new-service", "failed to create agent v1 service", err)
+	}
+
+	// 初始化V1长期记忆服务（未启用长期记忆时不注册）
+	var memoryServiceV1 *devicev1.MemoryServiceV1
+	if services.memory != nil {
+		memoryServiceV1, err = devicev1.NewMemoryServiceV1(logger, services.memory)
+		if err != nil {
+			logger.ErrorTag("API", "V1长期记忆服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "memory-v1:new-service", "failed to create memory v1 service", err)
+		}
+	}
+
+	// 初始化V1工具调用权限服务（未启用工具调用权限时不注册）
+	var toolPolicyServiceV1 *devicev1.ToolPolicyServiceV1
+	if services.toolPolicy != nil {
+		toolPolicyServiceV1, err = devicev1.NewToolPolicyServiceV1(logger, services.toolPolicy)
+		if err != nil {
+			logger.ErrorTag("API", "V1工具调用权限服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "toolpolicy-v1:new-service", "failed to create tool policy v1 service", err)
+		}
+	}
+
+	// 初始化V1提供者调用录制服务（未启用调用录制时不注册）
+	var providerCallServiceV1 *devicev1.ProviderCallServiceV1
+	if services.providerCall != nil {
+		providerCallServiceV1, err = devicev1.NewProviderCallServiceV1(logger, services.providerCall)
+		if err != nil {
+			logger.ErrorTag("API", "V1提供者调用录制服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "providercall-v1:new-service", "failed to create provider call v1 service", err)
+		}
+	}
+
+	// 初始化V1对话回放服务（未启用对话记录时不注册）
+	var replayServiceV1 *devicev1.ReplayServiceV1
+	if services.replay != nil {
+		replayServiceV1, err = devicev1.NewReplayServiceV1(logger, services.replay)
+		if err != nil {
+			logger.ErrorTag("API", "V1对话回放服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "replay-v1:new-service", "failed to create replay v1 service", err)
+		}
+	}
+
+	// 初始化V1故障注入服务（未启用故障注入时不注册）
+	var chaosServiceV1 *devicev1.ChaosServiceV1
+	if services.faults != nil {
+		chaosServiceV1, err = devicev1.NewChaosServiceV1(logger, services.faults)
+		if err != nil {
+			logger.ErrorTag("API", "V1故障注入服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "chaos-v1:new-service", "failed to create chaos v1 service", err)
+		}
+	}
+
+	// 初始化V1对象存储服务（未启用对象存储时不注册）
+	var objectServiceV1 *devicev1.ObjectServiceV1
+	if services.objects != nil {
+		objectServiceV1, err = devicev1.NewObjectServiceV1(logger, services.objects)
+		if err != nil {
+			logger.ErrorTag("API", "V1对象存储服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "object-v1:new-service", "failed to create object v1 service", err)
+		}
+	}
+
+	// 初始化V1备份服务（未启用备份时不注册）
+	var backupServiceV1 *devicev1.BackupServiceV1
+	if services.backups != nil {
+		backupServiceV1, err = devicev1.NewBackupServiceV1(logger, services.backups)
+		if err != nil {
+			logger.ErrorTag("API", "V1备份服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "backup-v1:new-service", "failed to create backup v1 service", err)
+		}
+	}
+
+	// 初始化V1日志查询服务（未启用日志索引时不注册）
+	var logServiceV1 *devicev1.LogServiceV1
+	if services.logs != nil {
+		logServiceV1, err = devicev1.NewLogServiceV1(logger, services.logs)
+		if err != nil {
+			logger.ErrorTag("API", "V1日志查询服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "log-v1:new-service", "failed to create log v1 service", err)
+		}
+	}
+
+	// 初始化V1后台任务服务（未启用任务队列时不注册）
+	var jobServiceV1 *devicev1.JobServiceV1
+	if services.jobs != nil {
+		jobServiceV1, err = devicev1.NewJobServiceV1(logger, services.jobs)
+		if err != nil {
+			logger.ErrorTag("API", "V1后台任务服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "job-v1:new-service", "failed to create job v1 service", err)
+		}
+	}
+
+	// 初始化V1知识库服务（未启用知识库时不注册）
+	var knowledgeServiceV1 *devicev1.KnowledgeServiceV1
+	if services.knowledge != nil {
+		knowledgeServiceV1, err = devicev1.NewKnowledgeServiceV1(logger, services.knowledge)
+		if err != nil {
+			logger.ErrorTag("API", "V1知识库服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "knowledge-v1:new-service", "failed to create knowledge v1 service", err)
+		}
+	}
+
+	// 初始化V1翻译模式服务（未启用翻译模式时不注册）
+	var translationServiceV1 *devicev1.TranslationServiceV1
+	if services.translation != nil {
+		translationServiceV1, err = devicev1.NewTranslationServiceV1(logger, services.translation)
+		if err != nil {
+			logger.ErrorTag("API", "V1翻译模式服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "translation-v1:new-service", "failed to create translation v1 service", err)
+		}
+	}
+
+	// 初始化V1媒体播放服务（未启用媒体播放时不注册）
+	var mediaServiceV1 *devicev1.MediaServiceV1
+	if services.media != nil {
+		mediaServiceV1, err = devicev1.NewMediaServiceV1(logger, services.media)
+		if err != nil {
+			logger.ErrorTag("API", "V1媒体播放服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "media-v1:new-service", "failed to create media v1 service", err)
+		}
+	}
+
+	// 初始化V1智能家居服务（未启用智能家居时不注册）
+	var smartHomeServiceV1 *devicev1.SmartHomeServiceV1
+	if services.smartHome != nil {
+		smartHomeServiceV1, err = devicev1.NewSmartHomeServiceV1(logger, services.smartHome)
+		if err != nil {
+			logger.ErrorTag("API", "V1智能家居服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "smarthome-v1:new-service", "failed to create smart home v1 service", err)
+		}
+	}
+
+	// 初始化V1在家状态服务（未启用在家状态时不注册）
+	var presenceServiceV1 *devicev1.PresenceServiceV1
+	if services.presence != nil {
+		presenceServiceV1, err = devicev1.NewPresenceServiceV1(logger, services.presence)
+		if err != nil {
+			logger.ErrorTag("API", "V1在家状态服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "presence-v1:new-service", "failed to create presence v1 service", err)
+		}
+	}
+
+	// 初始化V1例程服务（未启用例程时不注册）
+	var routineServiceV1 *devicev1.RoutineServiceV1
+	if services.routine != nil {
+		routineServiceV1, err = devicev1.NewRoutineServiceV1(logger, services.routine)
+		if err != nil {
+			logger.ErrorTag("API", "V1例程服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "routine-v1:new-service", "failed to create routine v1 service", err)
+		}
+	}
+
+	// 初始化V1文本对话服务（未启用文本对话时不注册）
+	var chatServiceV1 *devicev1.ChatServiceV1
+	if services.textChat != nil {
+		chatServiceV1, err = devicev1.NewChatServiceV1(logger, services.textChat)
+		if err != nil {
+			logger.ErrorTag("API", "V1文本对话服务初始化失败: %v", err)
+			return nil, platformerrors.Wrap(platformerrors.KindTransport, "chat-v1:new-service", "failed to create chat v1 service", err)
+		}
+	}
+
+	// 初始化V1配置服务（导入导出、清单应用和供应商配置测试）
+	configServiceV1, err := devicev1.NewConfigServiceV1(logger, configRepo, registry, services.prompt)
+	if err != nil {
+		logger.ErrorTag("API", "V1配置服务初始化失败: %v", err)
+		return nil, platformerrors.Wrap(platformerrors.KindTransport, "config-v1:new-service", "failed to create config v1 service", err)
+	}
+
+	// 初始化V1 GraphQL查询服务（控制台只读聚合视图）
+	graphqlServiceV1, err := graphqltransport.NewService(logger, graphqltransport.Sources{
+		Devices:  deviceRepo,
+		Plugins:  pluginStatusManager,
+		Executor: services.workflowExecutor,
+		DB:       db,
+	})
+	if err != nil {
+		logger.ErrorTag("API", "V1 GraphQL服务初始化失败: %v", err)
+		return nil, platformerrors.Wrap(platformerrors.KindTransport, "graphql-v1:new-service", "failed to create graphql v1 service", err)
+	}
+
+	// 初始化V1系统运维服务（排空模式）
+	systemServiceV1, err := devicev1.NewSystemServiceV1(logger, drain.Default(), config.Server.DrainTimeout, services.selfTest)
+	if err != nil {
+		logger.ErrorTag("API", "V1系统运维服务初始化失败: %v", err)
+		return nil, platformerrors.Wrap(platformerrors.KindTransport, "system-v1:new-service", "failed to create system v1 service", err)
+	}
+	if services.metrics != nil {
+		systemServiceV1.SetPluginMetrics(services.metrics)
+	}
+
+	// 注册服务路由
+	visionService.Register(groupCtx, apiGroup)
+	webapiService.Register(groupCtx, apiGroup)
+	otaService.Register(groupCtx, apiGroup)
+	if objectServiceV1 != nil {
+		// 签名下载地址会发给设备和第三方，凭签名访问
+		objectServiceV1.RegisterPublic(httpRouter.V1Public)
+	}
+	if provisioningServiceV1 != nil {
+		// 设备和配套 App 凭一次性配对令牌访问
+		provisioningServiceV1.RegisterPublic(httpRouter.V1Public)
+	}
+
+	// 有认证中间件时注册到V1Secure，否则注册到普通V1路由
+	v1Group := httpRouter.V1Secure
+	if v1Group == nil {
+		v1Group = httpRouter.V1
+	}
+	// 设备、租户、提示词、配对和文本对话按请求的租户隔离数据，租户令牌可以访问；
+	// 其余接口的存储没有租户维度或属于实例级操作，携带租户令牌的请求无权访问
+	adminGroup := v1Group.Group("", httpmiddleware.RequireAdmin())
+	deviceServiceV1.Register(v1Group) // 设备管理需要认证
+	tenantServiceV1.Register(v1Group)
+	promptServiceV1.Register(v1Group)
+	if provisioningServiceV1 != nil {
+		provisioningServiceV1.Register(v1Group)
+	}
+	if chatServiceV1 != nil {
+		chatServiceV1.Register(v1Group)
+	}
+	budgetServiceV1.Register(adminGroup)
+	languageServiceV1.Register(adminGroup)
+	reminderServiceV1.Register(adminGroup)
+	memberServiceV1.Register(adminGroup)
+	experimentServiceV1.Register(adminGroup)
+	evaluationServiceV1.Register(adminGroup)
+	if replayServiceV1 != nil {
+		replayServiceV1.Register(adminGroup)
+	}
+	notificationServiceV1.Register(adminGroup)
+	scriptServiceV1.Register(adminGroup)
+	agentServiceV1.Register(adminGroup)
+	privacyServiceV1.Register(adminGroup)
+	configServiceV1.Register(adminGroup)
+	graphqlServiceV1.Register(adminGroup)
+	systemServiceV1.Register(adminGroup)
+	flagServiceV1.Register(adminGroup)
+	if conversationServiceV1 != nil {
+		conversationServiceV1.Register(adminGroup)
+	}
+	if recordingServiceV1 != nil {
+		recordingServiceV1.Register(adminGroup)
+	}
+	if translationServiceV1 != nil {
+		translationServiceV1.Register(adminGroup)
+	}
+	if mediaServiceV1 != nil {
+		mediaServiceV1.Register(adminGroup)
+	}
+	if smartHomeServiceV1 != nil {
+		smartHomeServiceV1.Register(adminGroup)
+	}
+	if presenceServiceV1 != nil {
+		presenceServiceV1.Register(adminGroup)
+	}
+	if routineServiceV1 != nil {
+		routineServiceV1.Register(adminGroup)
+	}
+	if memoryServiceV1 != nil {
+		memoryServiceV1.Register(adminGroup)
+	}
+	if toolPolicyServiceV1 != nil {
+		toolPolicyServiceV1.Register(adminGroup)
+	}
+	if providerCallServiceV1 != nil {
+		providerCallServiceV1.Register(adminGroup)
+	}
+	if chaosServiceV1 != nil {
+		chaosServiceV1.Register(adminGroup)
+	}
+	if knowledgeServiceV1 != nil {
+		knowledgeServiceV1.Register(adminGroup)
+	}
+	if jobServiceV1 != nil {
+		jobServiceV1.Register(adminGroup)
+	}
+	if objectServiceV1 != nil {
+		objectServiceV1.Register(adminGroup)
+	}
+	if backupServiceV1 != nil {
+		backupServiceV1.Register(adminGroup)
+	}
+	if logServiceV1 != nil {
+		logServiceV1.Register(adminGroup)
+	}
+	speechTextServiceV1.Register(adminGroup)
+	monitorServiceV1.Register(adminGroup)
+	if deviceDebugServiceV1 != nil {
+		deviceDebugServiceV1.Register(adminGroup)
+	}
+	if analyticsServiceV1 != nil {
+		analyticsServiceV1.Register(adminGroup)
+	}
+	if webhookServiceV1 != nil {
+		webhookServiceV1.Register(adminGroup)
+	}
+
+	// 注意: 旧的systemServiceV1已被移除，插件管理使用新的动态插件管理系统
+	// 新API路径: /api/v1/plugins/，系统运维接口仅保留 /api/v1/system/drain
+
+	// 自动分配可用端口
+	port, err := utils.GetAvailablePort(config.Web.Port)
+	if err != nil {
+		logger.ErrorTag("HTTP", "自动分配端口失败: %v", err)
+		port = config.Web.Port // 如果自动分配失败，使用配置的端口
+	} else {
+		logger.InfoTag("HTTP", "自动分配端口: %d", port)
+	}
+
+	httpServer := &http.Server{
+		Addr:    ":" + strconv.Itoa(port),
+		Handler: router,
+	}
+
+	router.GET("/openapi.json", func(c *gin.Context) {
+		doc, err := swag.ReadDoc()
+		if err != nil {
+			logger.ErrorTag("HTTP", "生成 OpenAPI 文档失败: %v", err)
+			httptransport.RespondError(c, "INTERNAL_SERVER_ERROR", "failed to generate openapi spec", err.Error())
+			return
+		}
+		c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(doc))
+	})
+
+	router.GET("/docs", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(scalarHTML))
+	})
+
+	g.Go(func() error {
+		logger.InfoTag("HTTP", "Gin 服务已启动，访问地址 http://localhost:%d", port)
+		logger.InfoTag("HTTP", "OTA 服务入口: http://localhost:%d/api/ota/", port)
+		logger.InfoTag("HTTP", "在线文档入口: http://localhost:%d/docs", port)
+
+		go func() {
+			<-groupCtx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			if err := httpServer.Shutdown(shutdownCtx); err != nil {
+				logger.ErrorTag("HTTP", "HTTP 服务关闭失败: %v", err)
+			} else {
+				logger.InfoTag("HTTP", "HTTP 服务已优雅关闭")
+			}
+		}()
+
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.ErrorTag("HTTP", "HTTP 服务启动失败: %v", err)
+			return err
+		}
+		return nil
+	})
+
+	return httpServer, nil
+}
+
+func waitForShutdown(
+	ctx context.Context,
+	cancel context.CancelFunc,
+	logger *logging.Logger,
+	g *errgroup.Group,
+) error {
+	<-ctx.Done()
+	logger.InfoTag("引导", "收到系统信号 %v，正在进行资源清理", context.Cause(ctx))
+
+	cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- g.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			logger.ErrorTag("引导", "服务关闭过程中出现错误: %v", err)
+			return err
+		}
+		logger.InfoTag("引导", "所有服务已成功关闭")
+	case <-time.After(15 * time.Second):
+		timeoutErr := errors.New("服务关闭超时")
+		logger.ErrorTag("引导", "服务关闭超时，已强制退出")
+		return timeoutErr
+	}
+	return nil
+}
+
+func startServices(
+	state *appState,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) error {
+	// 初始化设备仓库
+	db := platformstorage.GetDB()
+	deviceRepo := platformstorage.NewDeviceRepository(db)
+
+	// 租户服务需在接受设备连接之前就绪，连接建立时据此拒绝已停用租户的设备
+	tenantService := startTenantService(state.logger, state.registry)
+	// 功能开关在连接建立和每轮对话时判断，需在接受设备连接之前就绪
+	flagService := startFlagService(state.config, state.logger, g, groupCtx)
+	// 工具调用权限需在接受设备连接之前就绪
+	toolPolicyService := startToolPolicyService(state.config, state.logger, state.registry, g, groupCtx)
+	// Token预算在连接建立时读取，需在接受设备连接之前就绪
+	budgetService := startBudgetService(state.config, state.logger)
+	// 播报文本后处理方案在连接建立时加载，需在接受设备连接之前就绪
+	speechTextService := startSpeechTextService(state.logger)
+	// 设备允许的语言在连接建立时加载，需在接受设备连接之前就绪
+	languageService := startLanguageService(state.config, state.logger)
+	// 经扫码配对的设备在握手时校验凭证，需在接受设备连接之前就绪
+	memberService := startMemberService(state.logger)
+	provisioningService := startProvisioningService(state.config, state.logger, deviceRepo, memberService, tenantService, g, groupCtx)
+
+	transportManager, textChat, err := startTransportServer(state.config, state.logger, state.domainMCPManager, deviceRepo, state.registry, g, groupCtx)
+	if err != nil {
+		return fmt.Errorf("启动 Transport 服务失败: %w", err)
+	}
+
+	services := &domainServices{
+		tenant:       tenantService,
+		flags:        flagService,
+		reminder:     startReminderScheduler(state.logger, g, groupCtx),
+		transcript:   startTranscriptRecorder(state.config, state.logger, state.redactor, g, groupCtx),
+		recording:    startRecordingArchive(state.config, state.logger, g, groupCtx),
+		prompt:       startPromptService(state.logger),
+		member:       memberService,
+		provisioning: provisioningService,
+		experiment:   startExperimentService(state.logger),
+		evaluation:   startEvaluationService(state.logger, state.registry, g, groupCtx),
+		notification: startNotificationService(state.logger),
+		script:       startScriptService(state.logger),
+		agent:        startAgentService(state.config, state.logger),
+		translation:  startTranslationService(state.config, state.logger, state.registry),
+		media:        startMediaService(state.config, state.logger, state.registry),
+		textChat:     textChat,
+		toolPolicy:   toolPolicyService,
+		budget:       budgetService,
+		speechText:   speechTextService,
+		language:     languageService,
+		monitor:      startMonitorService(state.logger),
+		analytics:    startAnalyticsService(state.config, state.logger, g, groupCtx),
+		webhook:      startWebhookService(state.config, state.logger, g, groupCtx),
+
+		workflowExecutor: newWorkflowExecutor(state.config, state.registry, state.logger),
+	}
+	services.memory = startMemoryService(state.config, state.logger, services.member, g, groupCtx)
+	services.smartHome = startSmartHomeService(state.config, state.logger, state.registry, g, groupCtx)
+	services.presence = startPresenceService(state.config, state.logger, services.member, g, groupCtx)
+	services.jobs = newJobQueue(state.config, state.logger)
+	services.objects = startObjectStore(state.config, state.logger, g, groupCtx)
+	services.backups = startBackupService(state.config, state.logger, g, groupCtx)
+	services.logs = state.logEntries
+	services.selfTest = newLiveSelfTest(state)
+	services.knowledge = startKnowledgeService(state.config, state.logger, state.registry, services.workflowExecutor, services.jobs, services.objects)
+	startWebSearchService(state.config, state.logger, state.registry)
+	startIntentEmbedding(state.config, state.logger, state.registry)
+	startSkillsService(state.config, state.logger, state.registry)
+	services.routine = startRoutineService(state.config, state.logger, services.workflowExecutor, g, groupCtx)
+	startOfflineMonitor(state.config, state.logger, g, groupCtx)
+	services.providerCall = startProviderCallRecorder(state.config, state.logger, state.registry, state.redactor, g, groupCtx)
+	services.replay = startReplayService(state.config, state.logger, services.transcript, g, groupCtx)
+	services.faults = startChaosInjector(state.config, state.logger, state.registry)
+	services.deviceDebug = startDeviceDebugService(state.config, state.logger, g, groupCtx)
+	services.metrics = startPluginMetrics(state.config, state.logger, state.registry, g, groupCtx)
+	// 各领域在上面注册完任务类型后再启动工作协程
+	startJobQueue(services.jobs, g, groupCtx)
+	startConversationPipeline(state.config, state.logger, services.workflowExecutor)
+	if services.transcript != nil {
+		// 用户反馈同时计入所在A/B实验的指标
+		services.transcript.SetFeedbackObserver(services.experiment)
+	}
+
+	if _, err := startHTTPServer(state.config, state.logger, state.configRepo, transportManager, deviceRepo, state.registry, state.portManager, state.pluginStatusManager, state.pluginLifecycle, state.pluginDiscovery, services, g, groupCtx); err != nil {
+		return fmt.Errorf("启动 Http 服务失败: %w", err)
+	}
+
+	if err := startAdminGRPCServer(state.config, state.logger, deviceRepo, state.pluginStatusManager, services.workflowExecutor, g, groupCtx); err != nil {
+		return fmt.Errorf("启动管理面 gRPC 服务失败: %w", err)
+	}
+
+	return nil
+}
+
+// domainServices 由 startServices 创建、供HTTP层使用的领域服务
+type domainServices struct {
+	tenant       *tenant.Service
+	flags        *flags.Service
+	budget       *budget.Service
+	speechText   *speechtext.Service
+	language     *language.Service
+	monitor      *monitor.Service
+	analytics    *analytics.Service // 未启用用量统计时为 nil
+	webhook      *webhook.Service   // 未启用事件推送时为 nil
+	reminder     *reminder.Service
+	transcript   *transcript.Service // 未启用对话记录时为 nil
+	recording    *recording.Service  // 未启用语音归档时为 nil
+	prompt       *prompt.Service
+	member       *member.Service
+	memory       *memory.Service    // 未启用长期记忆时为 nil
+	knowledge    *knowledge.Service // 未启用知识库时为 nil
+	experiment   *experiment.Service
+	evaluation   *evaluation.Service
+	notification *notification.Service
+	script       *script.Service
+	agent        *agent.Service
+	translation  *translation.Service  // 未启用翻译模式时为 nil
+	media        *media.Service        // 未启用媒体播放时为 nil
+	smartHome    *smarthome.Service    // 未启用智能家居时为 nil
+	presence     *presence.Service     // 未启用在家状态时为 nil
+	routine      *routine.Service      // 未启用例程时为 nil
+	textChat     *transport.TextChat   // 未启用文本对话时为 nil
+	toolPolicy   *toolpolicy.Service   // 未启用工具调用权限时为 nil
+	providerCall *providercall.Service // 未启用提供者调用录制时为 nil
+	replay       *replay.Service       // 未启用对话记录时为 nil
+	faults       *chaos.Injector       // 未启用故障注入时为 nil
+	deviceDebug  *devicedebug.Service  // 未启用远程调试时为 nil
+	provisioning *provisioning.Service // 未启用扫码配对时为 nil
+	metrics      *pluginmetrics.Aggregator // 未启用插件指标汇总时为 nil
+	jobs         *job.Service          // 未启用任务队列时为 nil
+	objects      *objectstore.Service  // 未启用对象存储时为 nil
+	backups      *backup.Service       // 未启用备份时为 nil
+	logs         logging.EntryStore    // 未启用日志索引时为 nil
+	selfTest     func(context.Context) *selftest.Report // 在运行中的服务上执行只读自检
+
+	// workflowExecutor 由工作流HTTP接口和管理面gRPC服务共享，两边都能查询对方发起的执行
+	workflowExecutor workflow.WorkflowExecutor
+}
+
+// newWorkflowExecutor 创建共享的工作流执行器
+func newWorkflowExecutor(config *platformconfig.Config, registry *capability.Registry, logger *logging.Logger) workflow.WorkflowExecutor {
+	dagEngine := workflow.NewDAGEngine(logger)
+	return workflow.NewWorkflowExecutor(config, registry, dagEngine, workflow.NewDataFlowEngine(dagEngine, logger), logger)
+}
+
+// startAdminGRPCServer 按配置启动管理面gRPC服务，未启用时直接返回
+func startAdminGRPCServer(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	deviceRepo repository.DeviceRepository,
+	pluginStatusManager *status.PluginStatusManager,
+	executor workflow.WorkflowExecutor,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) error {
+	if !config.AdminGRPC.Enabled {
+		return nil
+	}
+
+	service := grpctransport.NewManagementServer(deviceRepo, pluginStatusManager, executor, logger)
+	server, err := grpctransport.NewServer(config.AdminGRPC.Address, config.Server.Token, config.AdminGRPC.Reflection, service, logger)
+	if err != nil {
+		return platformerrors.Wrap(platformerrors.KindTransport, "admin-grpc:new-server", "failed to create management gRPC server", err)
+	}
+
+	g.Go(func() error {
+		go func() {
+			<-groupCtx.Done()
+			server.Stop(10 * time.Second)
+		}()
+
+		if err := server.Start(); err != nil {
+			logger.ErrorTag("gRPC", "管理面gRPC服务运行失败: %v", err)
+			return err
+		}
+		return nil
+	})
+	return nil
+}
+
+// startReminderScheduler 创建提醒服务并启动到期调度循环
+func startReminderScheduler(
+	logger *logging.Logger,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) *reminder.Service {
+	reminderRepo := platformstorage.NewReminderRepository(platformstorage.GetDB())
+	reminderService := reminder.NewService(reminderRepo, core.NewDeviceNotifier(), logger)
+	reminder.SetDefault(reminderService)
+
+	g.Go(func() error {
+		return reminderService.Run(groupCtx)
+	})
+	return reminderService
+}
+
+// startTranscriptRecorder 创建对话记录服务并启动写入和清理循环
+func startTranscriptRecorder(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	redactor *redaction.Redactor,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) *transcript.Service {
+	if !config.Transcript.Enabled {
+		logger.InfoTag("对话记录", "对话记录未启用")
+		return nil
+	}
+	transcriptRepo := platformstorage.NewTranscriptRepository(platformstorage.GetDB())
+	transcriptService := transcript.NewService(transcriptRepo, config.Transcript.RetentionDays, logger)
+	if redactor != nil && config.Redaction.RedactTranscripts {
+		transcriptService.SetRedactor(redactor)
+	}
+	transcript.SetDefault(transcriptService)
+
+	g.Go(func() error {
+		return transcriptService.Run(groupCtx)
+	})
+	return transcriptService
+}
+
+// startRecordingArchive 创建语音归档服务并启动写入和清理循环，密钥无效时不启用
+func startRecordingArchive(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) *recording.Service {
+	if !config.Recording.Enabled {
+		logger.InfoTag("语音归档", "语音归档未启用")
+		return nil
+	}
+	key, err := base64.StdEncoding.DecodeString(config.Recording.EncryptionKey)
+	if err != nil || len(key) != 32 {
+		logger.ErrorTag("语音归档", "加密密钥必须是 base64 编码的 32 字节，语音归档未启用")
+		return nil
+	}
+	blobs, err := platformstorage.NewEncryptedFileStore(config.Recording.Dir, key)
+	if err != nil {
+		logger.ErrorTag("语音归档", "初始化录音存储失败，语音归档未启用: %v", err)
+		return nil
+	}
+	recordingRepo := platformstorage.NewRecordingRepository(platformstorage.GetDB())
+	recordingService := recording.NewService(recordingRepo, blobs, config.Recording.RetentionDays, logger)
+	recording.SetDefault(recordingService)
+
+	g.Go(func() error {
+		return recordingService.Run(groupCtx)
+	})
+	return recordingService
+}
+
+// startTenantService 创建租户服务，并在能力执行路径上按租户计量用量、实施每日配额
+func startTenantService(logger *logging.Logger, registry *capability.Registry) *tenant.Service {
+	tenantService := tenant.NewService(platformstorage.NewTenantRepository(platformstorage.GetDB()), logger)
+	if err := tenantService.EnsureDefault(context.Background()); err != nil {
+		logger.ErrorTag("租户", "创建默认租户失败: %v", err)
+	}
+	tenant.SetDefault(tenantService)
+	if registry != nil {
+		// 排在优先级队列之后，排队被拒绝的调用不计入租户用量
+		registry.SetLimiter(capability.ChainLimiters(registry.Limiter(), tenantService.Limiter()))
+	}
+	return tenantService
+}
+
+// startPromptService 创建提示词模板服务，连接建立时据此解析设备的系统提示词
+func startPromptService(logger *logging.Logger) *prompt.Service {
+	promptRepo := platformstorage.NewPromptRepository(platformstorage.GetDB())
+	promptService := prompt.NewService(promptRepo, logger)
+	prompt.SetDefault(promptService)
+	return promptService
+}
+
+// startMemberService 创建家庭成员服务，连接建立时据此识别说话人并个性化回复
+func startMemberService(logger *logging.Logger) *member.Service {
+	memberRepo := platformstorage.NewMemberRepository(platformstorage.GetDB())
+	memberService := member.NewService(memberRepo, logger)
+	member.SetDefault(memberService)
+	return memberService
+}
+
+// startFlagService 创建功能开关服务并加载数据库中的开关，按间隔重新加载以同步其它实例的修改
+func startFlagService(config *platformconfig.Config, logger *logging.Logger, g *errgroup.Group, groupCtx context.Context) *flags.Service {
+	flagService := flags.NewService(platformstorage.NewFlagRepository(platformstorage.GetDB()), logger)
+	if err := flagService.Load(groupCtx); err != nil {
+		logger.WarnTag("功能开关", "加载功能开关失败，使用内置默认值: %v", err)
+	}
+	flags.SetDefault(flagService)
+	g.Go(func() error {
+		return flagService.Run(groupCtx, config.Flags.RefreshInterval)
+	})
+	return flagService
+}
+
+// startBudgetService 创建Token预算服务，未启用预算时连接不检查也不记录用量，查询接口仍可用
+func startBudgetService(config *platformconfig.Config, logger *logging.Logger) *budget.Service {
+	budgetService := budget.NewService(config.Budget, platformstorage.NewBudgetRepository(platformstorage.GetDB()), logger)
+	budget.SetDefault(budgetService)
+	return budgetService
+}
+
+// startSpeechTextService 创建播报文本后处理服务，连接建立时据此加载设备使用的方案
+func startSpeechTextService(logger *logging.Logger) *speechtext.Service {
+	speechTextService := speechtext.NewService(platformstorage.NewSpeechTextRepository(platformstorage.GetDB()), logger)
+	speechtext.SetDefault(speechTextService)
+	return speechTextService
+}
+
+// startMonitorService 创建在线会话监控服务，管理后台据此查看、旁听和终止设备会话
+func startMonitorService(logger *logging.Logger) *monitor.Service {
+	monitorService := monitor.NewService(core.NewDeviceSessionSource(), logger)
+	monitor.SetDefault(monitorService)
+	return monitorService
+}
+
+// startLanguageService 创建语言自动识别服务，未启用时连接不识别语言，设备允许语言的接口仍可用
+func startLanguageService(config *platformconfig.Config, logger *logging.Logger) *language.Service {
+	languageService := language.NewService(config.Language, platformstorage.NewLanguageRepository(platformstorage.GetDB()), logger)
+	language.SetDefault(languageService)
+	return languageService
+}
+
+// startExperimentService 创建A/B实验服务，连接建立时据此为设备分组
+func startExperimentService(logger *logging.Logger) *experiment.Service {
+	experimentRepo := platformstorage.NewExperimentRepository(platformstorage.GetDB())
+	experimentService := experiment.NewService(experimentRepo, logger)
+	experiment.SetDefault(experimentService)
+	return experimentService
+}
+
+// startEvaluationService 创建评测服务，通过能力注册表调用被测提供者
+func startEvaluationService(
+	logger *logging.Logger,
+	registry *capability.Registry,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) *evaluation.Service {
+	evaluationRepo := platformstorage.NewEvaluationRepository(platformstorage.GetDB())
+	evaluationService := evaluation.NewService(evaluationRepo, registry, logger)
+
+	g.Go(func() error {
+		return evaluationService.Run(groupCtx)
+	})
+	return evaluationService
+}
+
+// startNotificationService 创建通知服务，订阅系统告警事件并注册工作流通知节点
+func startNotificationService(logger *logging.Logger) *notification.Service {
+	notificationRepo := platformstorage.NewNotificationRepository(platformstorage.GetDB())
+	notificationService := notification.NewService(notificationRepo, httpclient.Default().Client("notification"), logger)
+	notification.SetDefault(notificationService)
+
+	if _, err := notification.SubscribeAlerts(notificationService); err != nil {
+		logger.WarnTag("通知", "订阅告警事件失败: %v", err)
+	}
+	if err := notification.RegisterWorkflowNode(workflow.DefaultNodeRegistry(), notificationService); err != nil {
+		logger.WarnTag("通知", "注册工作流通知节点失败: %v", err)
+	}
+	return notificationService
+}
+
+// startConversationPipeline 注册对话流水线的内置节点并加载配置的流水线，节点执行复用共享的工作流执行器
+func startConversationPipeline(config *platformconfig.Config, logger *logging.Logger, executor workflow.WorkflowExecutor) {
+	if err := pipeline.RegisterWorkflowNodes(workflow.DefaultNodeRegistry()); err != nil {
+		logger.WarnTag("流水线", "注册对话流水线节点失败: %v", err)
+	}
+	if len(config.Pipeline.Profiles) == 0 {
+		return
+	}
+	pipelineService := pipeline.NewService(config.Pipeline, executor, logger)
+	pipeline.SetDefault(pipelineService)
+	logger.InfoTag("流水线", "已加载对话流水线: %v", pipelineService.Profiles())
+}
+
+// startTranslationService 创建翻译服务，设备可通过语音指令或接口进入翻译模式
+func startTranslationService(config *platformconfig.Config, logger *logging.Logger, registry *capability.Registry) *translation.Service {
+	if !config.Translation.Enabled {
+		logger.InfoTag("翻译", "翻译模式未启用")
+		return nil
+	}
+	translationService := translation.NewService(config.Translation, registry, core.IsDeviceOnline, logger)
+	translation.SetDefault(translationService)
+	if config.Translation.Provider == "" {
+		logger.InfoTag("翻译", "未配置翻译能力，翻译模式使用设备当前的LLM")
+	}
+	return translationService
+}
+
+// startScriptService 创建脚本服务并注册工作流脚本节点
+func startScriptService(logger *logging.Logger) *script.Service {
+	scriptRepo := platformstorage.NewScriptRepository(platformstorage.GetDB())
+	scriptService := script.NewService(scriptRepo, logger)
+	script.SetDefault(scriptService)
+
+	if err := script.RegisterWorkflowNode(workflow.DefaultNodeRegistry(), scriptService); err != nil {
+		logger.WarnTag("脚本", "注册工作流脚本节点失败: %v", err)
+	}
+	return scriptService
+}
+
+// startAgentService 创建智能体服务并注册工作流智能体节点，智能体使用全局MCP管理器的工具
+func startAgentService(config *platformconfig.Config, logger *logging.Logger) *agent.Service {
+	agentRepo := platformstorage.NewAgentRepository(platformstorage.GetDB())
+	agentService := agent.NewService(agentRepo, config, domainmcp.GetGlobalMCPManager(), logger)
+	agent.SetDefault(agentService)
+
+	if err := agent.RegisterWorkflowNode(workflow.DefaultNodeRegistry(), agentService); err != nil {
+		logger.WarnTag("智能体", "注册工作流智能体节点失败: %v", err)
+	}
+	return agentService
+}
+
+// startMemoryService 创建长期记忆服务并启动记忆提取循环
+func startMemoryService(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	members *member.Service,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) *memory.Service {
+	if !config.Memory.Enabled {
+		logger.InfoTag("记忆", "长期记忆未启用")
+		return nil
+	}
+	memoryRepo := platformstorage.NewMemoryRepository(platformstorage.GetDB())
+	memoryService := memory.NewService(memoryRepo, members, config, logger)
+	memory.SetDefault(memoryService)
+
+	g.Go(func() error {
+		return memoryService.Run(groupCtx)
+	})
+	return memoryService
+}
+
+// startKnowledgeService 创建知识库服务并注册导入和检索节点
+// 启用任务队列时文档导入作为后台任务执行，上次运行时中断的导入重新入队；否则标记为失败
+// 启用对象存储时上传的文件保存到对象存储
+func startKnowledgeService(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	registry *capability.Registry,
+	executor workflow.WorkflowExecutor,
+	jobs *job.Service,
+	objects *objectstore.Service,
+) *knowledge.Service {
+	if !config.Knowledge.Enabled {
+		logger.InfoTag("知识库", "知识库未启用")
+		return nil
+	}
+	knowledgeRepo := platformstorage.NewKnowledgeRepository(platformstorage.GetDB())
+	knowledgeService := knowledge.NewService(config.Knowledge, knowledgeRepo, registry, executor, httpclient.Default().Client("knowledge"), logger)
+	if err := knowledge.RegisterWorkflowNodes(workflow.DefaultNodeRegistry(), knowledgeService); err != nil {
+		logger.WarnTag("知识库", "注册知识库节点失败: %v", err)
+	}
+	if objects != nil {
+		knowledgeService.UseObjects(objects)
+	}
+	if jobs != nil {
+		if err := knowledgeService.UseJobs(jobs); err != nil {
+			logger.WarnTag("知识库", "注册导入任务失败，导入不经过任务队列: %v", err)
+		}
+	}
+	if err := knowledgeService.Start(context.Background()); err != nil {
+		logger.ErrorTag("知识库", "加载知识库失败，未启用: %v", err)
+		return nil
+	}
+	knowledge.SetDefault(knowledgeService)
+	logger.InfoTag("知识库", "知识库已启用，向量化能力: %s", knowledgeService.Embedding())
+	return knowledgeService
+}
+
+// startWebSearchService 创建联网搜索服务，供 LLM 的 web_search 工具调用
+func startWebSearchService(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	registry *capability.Registry,
+) *websearchservice.Service {
+	if !config.WebSearch.Enabled {
+		logger.InfoTag("联网搜索", "联网搜索未启用")
+		return nil
+	}
+	service := websearchservice.NewService(config.WebSearch, registry, logger)
+	if _, _, ok := registry.Lookup(service.Capability()); !ok {
+		logger.ErrorTag("联网搜索", "搜索能力 %s 不存在，未启用", service.Capability())
+		return nil
+	}
+	websearchservice.SetDefault(service)
+	logger.InfoTag("联网搜索", "联网搜索已启用，搜索能力: %s", service.Capability())
+	return service
+}
+
+// startIntentEmbedding 配置了 Intent.Embedding 时为意图路由启用向量分类器
+func startIntentEmbedding(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	registry *capability.Registry,
+) {
+	cfg := config.Intent
+	if !cfg.Enabled || cfg.Embedding == "" {
+		return
+	}
+	if cfg.Embedding != knowledge.HashEmbedding {
+		if _, _, ok := registry.Lookup(cfg.Embedding); !ok {
+			logger.ErrorTag("意图", "向量化能力 %s 不存在，未启用向量分类器", cfg.Embedding)
+			return
+		}
+	}
+	intent.SetEmbedder(knowledge.NewEmbedder(registry, cfg.Embedding, cfg.EmbeddingConfig), cfg.Rules)
+	logger.InfoTag("意图", "向量分类器已启用，向量化能力: %s", cfg.Embedding)
+}
+
+// startSkillsService 创建天气、新闻和日程技能服务，供意图路由和 LLM 工具调用
+func startSkillsService(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	registry *capability.Registry,
+) *skillsservice.Service {
+	if !config.Skills.Enabled {
+		logger.InfoTag("技能", "内置技能未启用")
+		return nil
+	}
+	service := skillsservice.NewService(config.Skills, registry, logger)
+	for _, id := range service.Capabilities() {
+		if _, _, ok := registry.Lookup(id); !ok {
+			logger.WarnTag("技能", "技能使用的能力 %s 不存在", id)
+		}
+	}
+	skillsservice.SetDefault(service)
+	logger.InfoTag("技能", "内置技能已启用，新闻源: %v，日历: %v", service.HasNews(), service.HasCalendar())
+	return service
+}
+
+// startMediaService 创建媒体播放服务，设备可通过语音、LLM 工具或接口点播音乐和电台
+func startMediaService(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	registry *capability.Registry,
+) *media.Service {
+	if !config.Media.Enabled {
+		logger.InfoTag("媒体", "媒体播放未启用")
+		return nil
+	}
+	mediaConfig := config.Media
+	if mediaConfig.LibraryDir == "" {
+		mediaConfig.LibraryDir = config.GetMusicDir()
+	}
+	if mediaConfig.Provider != "" {
+		if _, _, ok := registry.Lookup(mediaConfig.Provider); !ok {
+			logger.WarnTag("媒体", "媒体能力 %s 不存在，仅使用曲库和电台", mediaConfig.Provider)
+			mediaConfig.Provider = ""
+		}
+	}
+	service := media.NewService(mediaConfig, registry, core.NewDeviceMediaController(), logger)
+	media.SetDefault(service)
+	logger.InfoTag("媒体", "媒体播放已启用，曲库目录: %s，电台: %d 个", mediaConfig.LibraryDir, len(mediaConfig.Radios))
+	return service
+}
+
+// startSmartHomeService 创建智能家居服务并启动实体发现循环，能力不存在的桥接会被跳过
+func startSmartHomeService(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	registry *capability.Registry,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) *smarthome.Service {
+	if !config.SmartHome.Enabled {
+		logger.InfoTag("智能家居", "智能家居未启用")
+		return nil
+	}
+	smartHomeConfig := config.SmartHome
+	bridges := make([]platformconfig.SmartHomeBridge, 0, len(smartHomeConfig.Bridges))
+	for _, bridge := range smartHomeConfig.Bridges {
+		if _, _, ok := registry.Lookup(bridge.Capability); !ok || bridge.Name == "" {
+			logger.WarnTag("智能家居", "桥接 %q 的能力 %s 不存在或缺少名称，已跳过", bridge.Name, bridge.Capability)
+			continue
+		}
+		bridges = append(bridges, bridge)
+	}
+	smartHomeConfig.Bridges = bridges
+
+	service := smarthome.NewService(smartHomeConfig, registry, logger)
+	service.UseAliases(platformstorage.NewSmartHomeAliasRepository(platformstorage.GetDB()))
+	smarthome.SetDefault(service)
+	g.Go(func() error {
+		return service.Run(groupCtx)
+	})
+	logger.InfoTag("智能家居", "智能家居已启用，桥接: %d 个", len(bridges))
+	return service
+}
+
+// startPresenceService 创建在家状态服务并启动路由器轮询和离家检查，工作流条件节点可使用在家条件
+func startPresenceService(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	members *member.Service,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) *presence.Service {
+	if !config.Presence.Enabled {
+		logger.InfoTag("在家状态", "在家状态未启用")
+		return nil
+	}
+	presenceRepo := platformstorage.NewPresenceRepository(platformstorage.GetDB())
+	service := presence.NewService(config.Presence, presenceRepo, members, logger)
+	presence.SetDefault(service)
+	workflow.SetDefaultPresenceChecker(service)
+	g.Go(func() error {
+		return service.Run(groupCtx)
+	})
+	logger.InfoTag("在家状态", "在家状态已启用，成员: %d 个，路由器轮询: %t", len(config.Presence.People), config.Presence.Router.URL != "")
+	return service
+}
+
+// startRoutineService 创建例程服务并启动定时触发循环，需在内置技能之后启动
+func startRoutineService(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	executor workflow.WorkflowExecutor,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) *routine.Service {
+	if !config.Routines.Enabled {
+		logger.InfoTag("例程", "例程未启用")
+		return nil
+	}
+	// 未启用内置技能时天气、新闻和日程动作执行失败，其余动作不受影响
+	var skills routine.Skills
+	if svc := skillsservice.Default(); svc != nil {
+		skills = svc
+	}
+	routineRepo := platformstorage.NewRoutineRepository(platformstorage.GetDB())
+	service := routine.NewService(config.Routines, routineRepo, skills, executor, core.NewDeviceNotifier(), logger)
+	if err := service.Load(groupCtx); err != nil {
+		logger.WarnTag("例程", "加载例程失败: %v", err)
+	}
+	routine.SetDefault(service)
+	g.Go(func() error {
+		return service.Run(groupCtx)
+	})
+	logger.InfoTag("例程", "例程已启用，已加载 %d 个例程", len(service.List(groupCtx, "")))
+	return service
+}
+
+// startOfflineMonitor 创建离线模式监控并启动云端服务探测，断网时连接切换到本地的 ASR、LLM 和 TTS
+func startOfflineMonitor(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) *offline.Service {
+	if !config.Offline.Enabled {
+		logger.InfoTag("离线模式", "离线模式未启用")
+		return nil
+	}
+	service := offline.NewService(config, nil, core.NewOfflineAnnouncer(), logger)
+	offline.SetDefault(service)
+	g.Go(func() error {
+		return service.Run(groupCtx)
+	})
+	logger.InfoTag("离线模式", "离线模式已启用，本地服务: ASR=%s, LLM=%s, TTS=%s", config.Offline.ASR, config.Offline.LLM, config.Offline.TTS)
+	return service
+}
+
+// startProviderCallRecorder 创建提供者调用录制服务并挂到能力注册表，按采样率录制能力调用
+func startProviderCallRecorder(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	registry *capability.Registry,
+	redactor *redaction.Redactor,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) *providercall.Service {
+	if !config.ProviderCalls.Enabled {
+		logger.InfoTag("调用录制", "提供者调用录制未启用")
+		return nil
+	}
+	repo := platformstorage.NewProviderCallRepository(platformstorage.GetDB())
+	service := providercall.NewService(config.ProviderCalls, repo, logger)
+	if redactor != nil {
+		service.SetRedactor(redactor)
+	}
+	registry.SetRecorder(service)
+
+	g.Go(func() error {
+		return service.Run(groupCtx)
+	})
+	logger.InfoTag("调用录制", "提供者调用录制已启用，默认采样率 %.2f，保留 %d 小时", config.ProviderCalls.SampleRate, config.ProviderCalls.RetentionHours)
+	return service
+}
+
+// startObjectStore 创建对象存储并启动到期对象清理，后端配置无效时不启用
+func startObjectStore(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) *objectstore.Service {
+	if !config.ObjectStore.Enabled {
+		logger.InfoTag("对象存储", "对象存储未启用")
+		return nil
+	}
+	var backend objectstore.Backend
+	var err error
+	switch config.ObjectStore.Backend {
+	case "", "local":
+		backend, err = platformstorage.NewLocalObjectBackend(config.ObjectStore.Dir)
+	case "s3":
+		backend, err = platformstorage.NewS3ObjectBackend(config.ObjectStore.S3, httpclient.Default().Client("objectstore"))
+	default:
+		err = fmt.Errorf("未知的后端 %s，可选 local、s3", config.ObjectStore.Backend)
+	}
+	if err != nil {
+		logger.ErrorTag("对象存储", "创建对象存储后端失败，未启用: %v", err)
+		return nil
+	}
+
+	repo := platformstorage.NewObjectRepository(platformstorage.GetDB())
+	service, err := objectstore.NewService(config.ObjectStore, repo, backend, logger)
+	if err != nil {
+		logger.ErrorTag("对象存储", "创建对象存储失败，未启用: %v", err)
+		return nil
+	}
+	objectstore.SetDefault(service)
+
+	g.Go(func() error {
+		return service.Run(groupCtx)
+	})
+	return service
+}
+
+// startBackupService 创建备份服务并启动自动备份
+func startBackupService(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) *backup.Service {
+	if !config.Backup.Enabled {
+		logger.InfoTag("备份", "备份未启用")
+		return nil
+	}
+	service, err := newBackupService(config, logger)
+	if err != nil {
+		logger.ErrorTag("备份", "创建备份服务失败，未启用: %v", err)
+		return nil
+	}
+	g.Go(func() error {
+		return service.Run(groupCtx)
+	})
+	return service
+}
+
+// newBackupService 按 db.json 中的数据库配置创建备份服务，只有 SQLite 支持快照
+func newBackupService(config *platformconfig.Config, logger *logging.Logger) (*backup.Service, error) {
+	db := backup.Database{Driver: "sqlite", Path: "./data/xiaozhi.db"}
+	configManager := platformstorage.NewDatabaseConfigManager()
+	if configManager.Exists() {
+		dbConfig, err := configManager.LoadConfig()
+		if err != nil {
+			return nil, err
+		}
+		db.Driver = dbConfig.Database.Type
+		db.Path = dbConfig.Database.Path
+	}
+	if db.Driver == "sqlite" {
+		db.Snapshot = func(ctx context.Context, dest string) error {
+			return platformstorage.SnapshotSQLite(ctx, platformstorage.GetDB(), dest)
+		}
+	} else {
+		db.Path = ""
+	}
+	return backup.NewService(config.Backup, db, logger)
+}
+
+// NewBackupService 连接数据库、加载配置并创建备份服务，供 xiaozhi-server backup 命令在服务之外使用
+func NewBackupService() (*backup.Service, error) {
+	config, logger, err := loadConfigAndLogger()
+	if err != nil {
+		return nil, err
+	}
+	return newBackupService(config, logger)
+}
+
+// newJobQueue 创建持久化的后台任务队列，各领域在 startJobQueue 之前注册任务类型
+func newJobQueue(config *platformconfig.Config, logger *logging.Logger) *job.Service {
+	if !config.Jobs.Enabled {
+		logger.InfoTag("任务队列", "后台任务队列未启用")
+		return nil
+	}
+	repo := platformstorage.NewJobRepository(platformstorage.GetDB())
+	service := job.NewService(config.Jobs, repo, logger)
+	job.SetDefault(service)
+	return service
+}
+
+// startJobQueue 启动任务队列的工作协程
+func startJobQueue(service *job.Service, g *errgroup.Group, groupCtx context.Context) {
+	if service == nil {
+		return
+	}
+	g.Go(func() error {
+		return service.Run(groupCtx)
+	})
+}
+
+// startChaosInjector 调试模式下创建故障注入器，挂到能力注册表并作为插件 gRPC 连接和提供者 HTTP 客户端的全局注入器
+func startChaosInjector(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	registry *capability.Registry,
+) *chaos.Injector {
+	if !config.Chaos.Enabled {
+		return nil
+	}
+	if config.Log.Level != "debug" {
+		logger.WarnTag("故障注入", "故障注入仅在 Log.Level 为 debug 时可用，已忽略 Chaos.Enabled")
+		return nil
+	}
+	injector := chaos.NewInjector(config.Chaos.RuleTTL, logger)
+	registry.SetFaultInjector(injector)
+	chaos.SetDefault(injector)
+	logger.WarnTag("故障注入", "故障注入已启用，管理员可通过 /api/v1/debug/chaos/rules 添加规则，请勿在生产环境开启")
+	return injector
+}
+
+// startPluginMetrics 定期采集各插件的指标，汇总后由系统运维接口以 Prometheus 文本格式输出
+func startPluginMetrics(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	registry *capability.Registry,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) *pluginmetrics.Aggregator {
+	if !config.PluginMetrics.Enabled || registry == nil {
+		logger.InfoTag("插件指标", "插件指标汇总未启用")
+		return nil
+	}
+	aggregator := pluginmetrics.NewAggregator(registry, pluginmetrics.Options{
+		Interval: config.PluginMetrics.Interval,
+		Timeout:  config.PluginMetrics.Timeout,
+	}, logger)
+	g.Go(func() error {
+		return aggregator.Run(groupCtx)
+	})
+	return aggregator
+}
+
+// startReplayService 创建对话回放服务，依赖对话记录读取原会话
+func startReplayService(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	transcripts *transcript.Service,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) *replay.Service {
+	if transcripts == nil {
+		logger.InfoTag("对话回放", "对话记录未启用，对话回放不可用")
+		return nil
+	}
+	repo := platformstorage.NewReplayRepository(platformstorage.GetDB())
+	service := replay.NewService(config, transcripts, repo, logger)
+
+	g.Go(func() error {
+		return service.Run(groupCtx)
+	})
+	return service
+}
+
+// startToolPolicyService 创建工具调用权限服务并启动审计写入和清理循环，策略配置无效时不启用
+func startToolPolicyService(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	registry *capability.Registry,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) *toolpolicy.Service {
+	if !config.ToolPolicy.Enabled {
+		logger.InfoTag("工具策略", "工具调用权限未启用")
+		return nil
+	}
+	repo := platformstorage.NewToolPolicyRepository(platformstorage.GetDB())
+	service, err := toolpolicy.NewService(config.ToolPolicy, repo, logger)
+	if err != nil {
+		logger.ErrorTag("工具策略", "工具调用权限配置无效，未启用: %v", err)
+		return nil
+	}
+	toolpolicy.SetDefault(service)
+	registry.SetAuthorizer(service)
+
+	g.Go(func() error {
+		return service.Run(groupCtx)
+	})
+	return service
+}
+
+// startDeviceDebugService 创建远程设备调试服务并启动审计记录清理循环
+func startDeviceDebugService(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) *devicedebug.Service {
+	if !config.DeviceDebug.Enabled {
+		logger.InfoTag("远程调试", "远程设备调试未启用")
+		return nil
+	}
+	repo := platformstorage.NewDeviceDebugRepository(platformstorage.GetDB())
+	service := devicedebug.NewService(config.DeviceDebug, core.NewDeviceDebugChannel(), repo, logger)
+	g.Go(func() error {
+		return service.Run(groupCtx)
+	})
+	return service
+}
+
+// startAnalyticsService 创建用量统计服务，订阅意图路由事件并启动后台汇总循环
+func startAnalyticsService(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) *analytics.Service {
+	if !config.Analytics.Enabled {
+		logger.InfoTag("用量统计", "用量统计未启用")
+		return nil
+	}
+	service := analytics.NewService(config.Analytics, platformstorage.NewAnalyticsRepository(platformstorage.GetDB()), logger)
+	if err := service.Subscribe(); err != nil {
+		logger.WarnTag("用量统计", "订阅意图路由事件失败，热门意图将为空: %v", err)
+	}
+	g.Go(func() error {
+		return service.Run(groupCtx)
+	})
+	return service
+}
+
+// startWebhookService 创建事件推送服务，订阅系统事件并启动推送循环
+func startWebhookService(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) *webhook.Service {
+	if !config.Webhooks.Enabled {
+		logger.InfoTag("事件推送", "事件推送未启用")
+		return nil
+	}
+	repo := platformstorage.NewWebhookRepository(platformstorage.GetDB())
+	service := webhook.NewService(config.Webhooks, repo, httpclient.Default().Client("webhook"), logger)
+	online := func(deviceID string) bool {
+		_, ok := core.FindActiveHandler(deviceID)
+		return ok
+	}
+	if _, err := webhook.Subscribe(service, online); err != nil {
+		logger.WarnTag("事件推送", "订阅系统事件失败，将不会推送事件: %v", err)
+	}
+	g.Go(func() error {
+		return service.Run(groupCtx)
+	})
+	return service
+}
+
+// startProvisioningService 创建扫码配对服务并启动过期令牌清理循环
+func startProvisioningService(
+	config *platformconfig.Config,
+	logger *logging.Logger,
+	deviceRepo repository.DeviceRepository,
+	members *member.Service,
+	tenants *tenant.Service,
+	g *errgroup.Group,
+	groupCtx context.Context,
+) *provisioning.Service {
+	if !config.Provisioning.Enabled {
+		logger.InfoTag("配对", "扫码配对未启用")
+		return nil
+	}
+	repo := platformstorage.NewProvisioningRepository(platformstorage.GetDB())
+	service := provisioning.NewService(config.Provisioning, config.Web.Websocket, repo, deviceRepo, members, tenants, logger)
+	provisioning.SetDefault(service)
+	g.Go(func() error {
+		return service.Run(groupCtx)
+	})
+	return service
+}
+
+// loadConfigAndLogger 加载配置和日志记录器，用于测试和命令行子命令
+func loadConfigAndLogger() (*platformconfig.Config, *logging.Logger, error) {
+	state := &appState{}
+
+	// 执行必要的初始化步骤
+	steps := []initStep{
+		{
+			ID:      "storage:init-config-store",
+			Title:   "Initialise configuration store",
+			Kind:    platformerrors.KindStorage,
+			Execute: initStorageStep,
+		},
+		{
 			ID:      "storage:init-database",
 			Title:   "Initialise database",
 			Kind:    platformerrors.KindStorage,
@@ -993,12 +2849,7 @@ func setupStaticFiles(router *gin.Engine, config *platformconfig.Config) {
 		path := c.Request.URL.Path
 		// 如果是API请求，返回404
 		if strings.HasPrefix(path, "/api") {
-			c.JSON(http.StatusNotFound, httptransport.APIResponse{
-				Success: false,
-				Data:    gin.H{},
-				Message: "API Not found",
-				Code:    http.StatusNotFound,
-			})
+			httptransport.RespondError(c, "RESOURCE_NOT_FOUND", "API Not found")
 			return
 		}
 
@@ -1017,23 +2868,77 @@ func setupStaticFiles(router *gin.Engine, config *platformconfig.Config) {
 	})
 }
 
+// newPluginLogManager 按配置创建插件日志采集管理器
+func newPluginLogManager(config *platformconfig.Config) *pluginlogs.Manager {
+	opts := pluginlogs.Options{}
+	if config != nil {
+		opts = pluginlogs.Options{
+			BufferLines: config.PluginLogs.BufferLines,
+			Dir:         config.PluginLogs.Dir,
+			MaxFileSize: int64(config.PluginLogs.MaxFileSizeMB) * 1024 * 1024,
+			MaxBackups:  config.PluginLogs.MaxBackups,
+		}
+	}
+	return pluginlogs.NewManager(opts)
+}
+
+// newValidationPolicy 根据配置创建能力 schema 校验策略，宽松模式的校验失败写入警告日志
+func newValidationPolicy(cfg platformconfig.CapabilityValidationConfig, logger *platformlogging.Logger) *capability.ValidationPolicy {
+	policy := &capability.ValidationPolicy{
+		Default:      capability.ValidationMode(cfg.Mode),
+		Capabilities: make(map[string]capability.ValidationMode, len(cfg.Capabilities)),
+	}
+	for capabilityID, mode := range cfg.Capabilities {
+		policy.Capabilities[capabilityID] = capability.ValidationMode(mode)
+	}
+	if logger != nil {
+		policy.Report = func(err *capability.SchemaError) {
+			logger.WarnTag("能力校验", "%v", err)
+		}
+	}
+	return policy
+}
+
 // startGRPCPlugins 启动支持gRPC的插件服务器，使用动态端口分配
 func startGRPCPlugins(plugins map[string]capability.Provider, portManager *ports.PortManager, logger *platformlogging.Logger) error {
 	for pluginID, provider := range plugins {
 		// 检查插件是否支持gRPC
 		if grpcProvider, ok := provider.(capability.GRPCProvider); ok {
-			// 使用动态端口分配
-			port, err := portManager.AllocatePortWithRetry(pluginID, 3, 1*time.Second)
-			if err != nil {
-				if logger != nil {
-					logger.ErrorTag("gRPC", "插件端口分配失败，跳过gRPC启动",
-						"plugin_id", pluginID,
-						"error", err.Error())
+			// 启用Unix域套接字时监听套接字文件，否则使用动态端口分配
+			var address string
+			release := func() {}
+			if portManager.UnixSocketsEnabled() {
+				path, err := portManager.AllocateSocket(pluginID)
+				if err != nil {
+					if logger != nil {
+						logger.ErrorTag("gRPC", "插件 %s 套接字分配失败，跳过gRPC启动: %v", pluginID, err)
+					}
+					continue
+				}
+				address = ports.UnixAddress(path)
+				release = func() { portManager.ReleaseSocket(pluginID) }
+			} else {
+				port, err := portManager.AllocatePortWithRetry(pluginID, 3, 1*time.Second)
+				if err != nil {
+					if logger != nil {
+						logger.ErrorTag("gRPC", "插件端口分配失败，跳过gRPC启动",
+							"plugin_id", pluginID,
+							"error", err.Error())
+					}
+					continue
+				}
+				address = fmt.Sprintf("0.0.0.0:%d", port)
+				release = func() { portManager.ReleasePort(port) }
+			}
+
+			// 启用 mTLS 时每次启动为插件签发新证书
+			if authority := certs.Default(); authority != nil {
+				if _, err := authority.Issue(pluginID, address); err != nil {
+					release()
+					return fmt.Errorf("failed to issue certificate for plugin %s: %w", pluginID, err)
 				}
-				continue
 			}
 
-			address := fmt.Sprintf("0.0.0.0:%d", port)
 			if logger != nil {
 				logger.InfoTag("gRPC", "启动插件gRPC服务器",
 					"plugin_id", pluginID,
@@ -1048,8 +2953,11 @@ func startGRPCPlugins(plugins map[string]capability.Provider, portManager *ports
 						"address", address,
 						"error", err.Error())
 				}
-				// 释放已分配的端口
-				portManager.ReleasePort(port)
+				// 释放已分配的端口或套接字
+				release()
+				if authority := certs.Default(); authority != nil {
+					authority.Revoke(pluginID)
+				}
 				return fmt.Errorf("failed to start gRPC server for plugin %s: %w", pluginID, err)
 			}
 
@@ -1065,7 +2973,7 @@ func startGRPCPlugins(plugins map[string]capability.Provider, portManager *ports
 }
 
 // initPluginPortManagerStep 初始化插件端口管理器
-func initPluginPortManagerStep(_ context.Context, state *appState) error {
+func initPluginPortManagerStep(ctx context.Context, state *appState) error {
 	if state == nil || state.logger == nil {
 		return platformerrors.New(
 			platformerrors.KindBootstrap,
@@ -1078,6 +2986,25 @@ func initPluginPortManagerStep(_ context.Context, state *appState) error {
 	portManager := ports.NewDefaultPortManager(state.logger)
 	state.portManager = portManager
 
+	// 单机部署可改用Unix域套接字，避免占用和暴露TCP端口
+	if state.config != nil && state.config.PluginGRPC.Transport == "unix" {
+		if err := portManager.EnableUnixSockets(state.config.PluginGRPC.SocketDir); err != nil {
+			return platformerrors.Wrap(platformerrors.KindBootstrap, "plugin:init-port-manager", "failed to enable plugin unix sockets", err)
+		}
+		state.logger.InfoTag("引导", "插件gRPC使用Unix域套接字，目录: %s", state.config.PluginGRPC.SocketDir)
+	}
+
+	// 持久化端口分配，重启后回收上次运行遗留的端口，避免与仍在占用端口的残留进程冲突
+	if db := platformstorage.GetDB(); db != nil {
+		portManager.SetStore(platformstorage.NewPortAllocationRepository(db))
+		result, err := portManager.Restore(ctx)
+		if err != nil {
+			state.logger.WarnTag("引导", "恢复插件端口分配记录失败: %v", err)
+		} else if result.Reclaimed > 0 || result.Orphaned > 0 {
+			state.logger.InfoTag("引导", "已恢复插件端口分配记录，回收 %d 个，仍被占用 %d 个", result.Reclaimed, result.Orphaned)
+		}
+	}
+
 	if state.logger != nil {
 		state.logger.InfoTag("引导", "插件端口管理器初始化完成")
 	}
@@ -1107,6 +3034,29 @@ func initPluginStatusManagerStep(_ context.Context, state *appState) error {
 		state.logger.InfoTag("引导", "插件状态管理器初始化完成")
 	}
 
+	// 生命周期管理器通过端口管理器获取插件套接字地址
+	if state.pluginLifecycle != nil {
+		state.pluginLifecycle.SetPortManager(state.portManager)
+	}
+
+	// 核心与插件之间的 mTLS，CA 和证书在每次启动时重新生成
+	if state.config != nil && state.config.PluginGRPC.MTLS {
+		authority, err := certs.NewAuthority(state.config.PluginGRPC.CertDir)
+		if err != nil {
+			return platformerrors.Wrap(platformerrors.KindBootstrap, "plugin:init-certs", "failed to create plugin certificate authority", err)
+		}
+		certs.SetDefault(authority)
+		state.logger.InfoTag("引导", "插件gRPC已启用mTLS")
+	} else {
+		state.logger.WarnTag("引导", "插件gRPC未启用mTLS，仅适用于开发环境")
+	}
+
+	// 插件调试服务需在插件gRPC服务器启动前开启
+	if state.config != nil && state.config.PluginGRPC.Debug {
+		pluginserver.SetDebug(true)
+		state.logger.WarnTag("引导", "插件gRPC已启用反射和调试服务，生产环境请关闭")
+	}
+
 	// 启动gRPC服务器
 	allProviders := state.registry.GetAllProviders()
 	plugins := make(map[string]capability.Provider)
@@ -1122,6 +3072,9 @@ func initPluginStatusManagerStep(_ context.Context, state *appState) error {
 	// 启动健康检查任务
 	go pluginStatusManager.StartHealthCheck(context.Background(), 30*time.Second)
 
+	// 启动端口记录清理任务，定期回收已空闲的孤儿端口
+	go state.portManager.StartCleanupTask(context.Background(), time.Minute)
+
 	return nil
 }
 
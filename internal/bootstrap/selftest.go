@@ -0,0 +1,500 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/backup"
+	configmanager "xiaozhi-server-go/internal/domain/config/manager"
+	domainmcp "xiaozhi-server-go/internal/domain/mcp"
+	"xiaozhi-server-go/internal/domain/offline"
+	platformconfig "xiaozhi-server-go/internal/platform/config"
+	platformlogging "xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/redaction"
+	"xiaozhi-server-go/internal/platform/selftest"
+	platformstorage "xiaozhi-server-go/internal/platform/storage"
+)
+
+// selfTestProbeTimeout 单个提供者连通性探测的超时时间
+const selfTestProbeTimeout = 5 * time.Second
+
+// checkFn 初始化步骤的只读检查，通过 selfTest 记录结果；返回错误等同于记录一项失败
+type checkFn func(context.Context, *selfTest) error
+
+// selfTest 按初始化依赖图执行只读检查，不修改数据库、不启动任何服务
+type selfTest struct {
+	live   bool // 在运行中的服务上自检：沿用已初始化的依赖，跳过只在启动前有意义的端口检查
+	report *selftest.Report
+
+	// 检查过程中取得的依赖，后续步骤的检查使用
+	db     *gorm.DB
+	config *platformconfig.Config
+	logger *platformlogging.Logger
+
+	step   string
+	failed bool // 当前步骤是否有失败项
+}
+
+// Doctor 以只检查模式执行初始化依赖图，供 xiaozhi-server doctor 在服务之外运行
+func Doctor(ctx context.Context) *selftest.Report {
+	t := &selfTest{logger: platformlogging.NewDiscard()}
+	defer t.closeDB()
+	return t.run(ctx, InitGraph())
+}
+
+// newLiveSelfTest 返回在运行中的服务上自检的函数，供系统运维接口调用
+func newLiveSelfTest(state *appState) func(context.Context) *selftest.Report {
+	return func(ctx context.Context) *selftest.Report {
+		t := &selfTest{
+			live:   true,
+			db:     platformstorage.GetDB(),
+			config: state.config,
+			logger: state.logger,
+		}
+		return t.run(ctx, InitGraph())
+	}
+}
+
+// run 按依赖顺序执行各步骤的检查；检查失败或被配置跳过的步骤，其后继步骤记为跳过
+func (t *selfTest) run(ctx context.Context, steps []initStep) *selftest.Report {
+	t.report = selftest.NewReport()
+	blocked := make(map[string]string, len(steps))
+	for _, step := range steps {
+		t.step = step.ID
+		if reason := t.blockedReason(step, blocked); reason != "" {
+			blocked[step.ID] = reason
+			if step.Check != nil {
+				t.add(step.Title, selftest.StatusSkip, reason, 0)
+			}
+			continue
+		}
+		if step.Optional && t.config != nil && slices.Contains(t.config.Startup.Skip, step.ID) {
+			blocked[step.ID] = fmt.Sprintf("依赖的步骤 %s 已在配置中跳过", step.ID)
+			if step.Check != nil {
+				t.add(step.Title, selftest.StatusSkip, "已在 Startup.Skip 中跳过", 0)
+			}
+			continue
+		}
+		if step.Check == nil {
+			continue
+		}
+
+		t.failed = false
+		if err := step.Check(ctx, t); err != nil {
+			t.fail(step.Title, err)
+		}
+		if t.failed {
+			blocked[step.ID] = fmt.Sprintf("依赖的步骤 %s 检查未通过", step.ID)
+		}
+	}
+	t.report.Finish()
+	return t.report
+}
+
+func (t *selfTest) blockedReason(step initStep, blocked map[string]string) string {
+	for _, dep := range step.DependsOn {
+		if reason, ok := blocked[dep]; ok {
+			return reason
+		}
+	}
+	return ""
+}
+
+// add 记录一项结果，失败项使依赖当前步骤的后续检查跳过
+func (t *selfTest) add(name string, status selftest.Status, detail string, elapsed time.Duration) {
+	if status == selftest.StatusFail {
+		t.failed = true
+	}
+	t.record(name, status, detail, elapsed)
+}
+
+// record 只记录结果；服务仍能启动的失败（如提供者不可达）用它记录，不影响后续检查
+func (t *selfTest) record(name string, status selftest.Status, detail string, elapsed time.Duration) {
+	t.report.Add(selftest.Result{
+		Step:       t.step,
+		Name:       name,
+		Status:     status,
+		Detail:     detail,
+		DurationMs: elapsed.Milliseconds(),
+	})
+}
+
+func (t *selfTest) pass(name, detail string) {
+	t.add(name, selftest.StatusPass, detail, 0)
+}
+
+func (t *selfTest) warn(name, detail string) {
+	t.add(name, selftest.StatusWarn, detail, 0)
+}
+
+func (t *selfTest) fail(name string, err error) {
+	t.add(name, selftest.StatusFail, err.Error(), 0)
+}
+
+func (t *selfTest) closeDB() {
+	if t.live || t.db == nil {
+		return
+	}
+	if sqlDB, err := t.db.DB(); err == nil {
+		_ = sqlDB.Close()
+	}
+}
+
+// checkPendingRestore 检查是否有待应用的备份恢复，自检不会应用它
+func checkPendingRestore(_ context.Context, t *selfTest) error {
+	if _, err := os.Stat(backup.PendingDir); err == nil {
+		t.warn("待应用的恢复", fmt.Sprintf("%s 中有暂存的备份，下次启动时将替换数据库和数据文件", backup.PendingDir))
+		return nil
+	}
+	t.pass("待应用的恢复", "无")
+	return nil
+}
+
+// checkDatabase 检查数据库连接和表结构，只打开连接不迁移
+func checkDatabase(ctx context.Context, t *selfTest) error {
+	if !t.live {
+		manager := platformstorage.NewDatabaseConfigManager()
+		if !manager.Exists() {
+			t.warn("数据库配置", fmt.Sprintf("未找到 %s，首次启动时将创建默认 SQLite 数据库", manager.GetConfigPath()))
+			return nil
+		}
+		dbConfig, err := manager.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("读取数据库配置失败: %w", err)
+		}
+		if !dbConfig.Initialized {
+			t.warn("数据库配置", "数据库尚未初始化，启动时将建表并创建管理员账号")
+			return nil
+		}
+
+		started := time.Now()
+		db, err := platformstorage.OpenDatabase(dbConfig.Database)
+		if err != nil {
+			t.add("数据库连接", selftest.StatusFail, err.Error(), time.Since(started))
+			return nil
+		}
+		t.db = db
+		t.add("数据库连接", selftest.StatusPass, describeDatabase(dbConfig.Database), time.Since(started))
+	} else {
+		if t.db == nil {
+			return fmt.Errorf("数据库未初始化")
+		}
+		sqlDB, err := t.db.DB()
+		if err != nil {
+			return err
+		}
+		started := time.Now()
+		if err := sqlDB.PingContext(ctx); err != nil {
+			t.add("数据库连接", selftest.StatusFail, err.Error(), time.Since(started))
+			return nil
+		}
+		t.add("数据库连接", selftest.StatusPass, t.db.Dialector.Name(), time.Since(started))
+	}
+
+	problems, err := platformstorage.SchemaProblems(t.db)
+	switch {
+	case err != nil:
+		t.fail("表结构", err)
+	case len(problems) > 0:
+		t.warn("表结构", fmt.Sprintf("%s，启动时将自动迁移补齐", summarize(problems, 3)))
+	default:
+		t.pass("表结构", "完整")
+	}
+	return nil
+}
+
+func describeDatabase(conn platformstorage.DatabaseConnection) string {
+	if conn.Type == "sqlite" {
+		return "sqlite " + conn.Path
+	}
+	return fmt.Sprintf("%s %s:%d/%s", conn.Type, conn.Host, conn.Port, conn.Database)
+}
+
+// checkConfig 从数据库读取配置，未初始化时按默认配置继续检查；随后检查各项密钥能否使用
+func checkConfig(_ context.Context, t *selfTest) error {
+	if !t.live {
+		if t.db == nil {
+			t.config = platformconfig.DefaultConfig()
+			t.warn("加载配置", "数据库不可用，按默认配置检查")
+		} else {
+			repo := configmanager.NewDatabaseRepository(t.db)
+			initialized, err := repo.IsInitialized()
+			if err != nil {
+				return err
+			}
+			if !initialized {
+				t.config = platformconfig.DefaultConfig()
+				t.warn("加载配置", "数据库中没有配置，启动时将写入默认配置")
+			} else {
+				config, err := repo.LoadConfig()
+				if err != nil {
+					return err
+				}
+				t.config = config
+				t.pass("加载配置", "database:config")
+			}
+		}
+	} else {
+		t.pass("加载配置", "database:config")
+	}
+	checkSecrets(t, t.config)
+	return nil
+}
+
+// checkSecrets 检查语音归档密钥、备份口令和工作流密钥能否解析
+func checkSecrets(t *selfTest, config *platformconfig.Config) {
+	if config.Recording.Enabled {
+		key, err := base64.StdEncoding.DecodeString(config.Recording.EncryptionKey)
+		if err != nil || len(key) != 32 {
+			t.warn("语音归档密钥", "必须是 base64 编码的 32 字节，语音归档不会启用")
+		} else {
+			t.pass("语音归档密钥", "AES-256")
+		}
+	}
+	if config.Backup.Enabled {
+		if os.Getenv(backup.PassphraseEnv) == "" && config.Backup.Passphrase == "" {
+			t.warn("备份口令", fmt.Sprintf("未设置 %s 或 Backup.Passphrase，无法创建备份", backup.PassphraseEnv))
+		} else {
+			t.pass("备份口令", "已配置")
+		}
+	}
+	var empty []string
+	for name, value := range config.Workflow.Secrets {
+		if value == "" && os.Getenv("XIAOZHI_SECRET_"+strings.ToUpper(name)) == "" {
+			empty = append(empty, name)
+		}
+	}
+	if len(empty) > 0 {
+		slices.Sort(empty)
+		t.warn("工作流密钥", fmt.Sprintf("%s 为空", summarize(empty, 5)))
+	} else if len(config.Workflow.Secrets) > 0 {
+		t.pass("工作流密钥", fmt.Sprintf("%d 个", len(config.Workflow.Secrets)))
+	}
+}
+
+// checkLogging 检查脱敏规则和日志目录
+func checkLogging(_ context.Context, t *selfTest) error {
+	if _, err := redaction.NewFromConfig(t.config.Redaction); err != nil {
+		t.fail("脱敏规则", err)
+	} else {
+		t.pass("脱敏规则", "有效")
+	}
+	t.checkWritableDir("日志目录", t.config.Log.Dir)
+	return nil
+}
+
+// checkProviders 检查所选 ASR、LLM、TTS、VLLLM 的配置和凭据，并探测云端服务地址是否可达
+func checkProviders(ctx context.Context, t *selfTest) error {
+	config := t.config
+	selected := []struct {
+		kind  offline.Kind
+		label string
+		name  string
+	}{
+		{offline.KindASR, "ASR", config.Selected.ASR},
+		{offline.KindLLM, "LLM", config.Selected.LLM},
+		{offline.KindTTS, "TTS", config.Selected.TTS},
+	}
+	for _, s := range selected {
+		name := fmt.Sprintf("%s %s", s.label, s.name)
+		if s.name == "" {
+			t.add(s.label, selftest.StatusSkip, "未选择", 0)
+			continue
+		}
+		if !hasProviderConfig(config, s.kind, s.name) {
+			t.fail(name, fmt.Errorf("配置 %s 不存在", s.name))
+			continue
+		}
+		t.probe(ctx, name, offline.ResolveEndpoint(config, s.kind, s.name))
+		if llm := config.LLM[s.name]; s.kind == offline.KindLLM && llm.Type != "ollama" && placeholderSecret(llm.APIKey) {
+			t.warn(name+" 凭据", "未配置 API Key")
+		}
+	}
+
+	if name := config.Selected.VLLLM; name != "" {
+		vlllm, ok := config.VLLLM[name]
+		switch {
+		case !ok:
+			t.fail("VLLLM "+name, fmt.Errorf("配置 %s 不存在", name))
+		case placeholderSecret(vlllm.APIKey):
+			t.warn("VLLLM "+name, "未配置 API Key")
+		default:
+			t.pass("VLLLM "+name, "已配置")
+		}
+	}
+	return nil
+}
+
+func hasProviderConfig(config *platformconfig.Config, kind offline.Kind, name string) bool {
+	var ok bool
+	switch kind {
+	case offline.KindASR:
+		_, ok = config.ASR[name]
+	case offline.KindLLM:
+		_, ok = config.LLM[name]
+	case offline.KindTTS:
+		_, ok = config.TTS[name]
+	}
+	return ok
+}
+
+// placeholderSecret 密钥为空或仍是默认配置中的占位值
+func placeholderSecret(value string) bool {
+	return value == "" || strings.HasPrefix(value, "your_") || strings.HasPrefix(value, "your-")
+}
+
+// probe 以 TCP 连接探测服务地址，地址无法确定（如本地模型）时不探测
+func (t *selfTest) probe(ctx context.Context, name, endpoint string) {
+	if endpoint == "" {
+		t.pass(name, "无需探测（本地服务或未配置地址）")
+		return
+	}
+	probeCtx, cancel := context.WithTimeout(ctx, selfTestProbeTimeout)
+	defer cancel()
+	started := time.Now()
+	if err := (offline.DialProber{}).Probe(probeCtx, endpoint); err != nil {
+		t.record(name, selftest.StatusFail, fmt.Sprintf("%s 不可达: %v", endpoint, err), time.Since(started))
+		return
+	}
+	t.add(name, selftest.StatusPass, endpoint+" 可达", time.Since(started))
+}
+
+// checkPorts 检查服务端口是否被占用、插件套接字目录是否可写；运行中的服务不检查端口
+func checkPorts(_ context.Context, t *selfTest) error {
+	config := t.config
+	if config.PluginGRPC.Transport == "unix" {
+		t.checkWritableDir("插件套接字目录", config.PluginGRPC.SocketDir)
+	}
+	if t.live {
+		t.add("服务端口", selftest.StatusSkip, "服务运行中，端口由本进程占用", 0)
+		return nil
+	}
+
+	// HTTP 和 WebSocket 端口被占用时启动会自动改用其他端口
+	t.checkPort("HTTP 端口", fmt.Sprintf(":%d", config.Web.Port), false)
+	if config.Transport.WebSocket.Enabled {
+		t.checkPort("WebSocket 端口", fmt.Sprintf(":%d", config.Transport.WebSocket.Port), false)
+	}
+	if config.AdminGRPC.Enabled {
+		t.checkPort("管理面 gRPC 地址", config.AdminGRPC.Address, true)
+	}
+	return nil
+}
+
+func (t *selfTest) checkPort(name, address string, required bool) {
+	listener, err := net.Listen("tcp", address)
+	if err == nil {
+		_ = listener.Close()
+		t.pass(name, address+" 可用")
+		return
+	}
+	if required {
+		t.fail(name, fmt.Errorf("%s 无法监听: %w", address, err))
+		return
+	}
+	t.warn(name, fmt.Sprintf("%s 已被占用，启动时将自动改用其他端口", address))
+}
+
+// checkPluginCerts 启用 mTLS 并指定证书目录时检查目录可写
+func checkPluginCerts(_ context.Context, t *selfTest) error {
+	config := t.config
+	if !config.PluginGRPC.MTLS {
+		t.warn("插件 mTLS", "未启用，仅适用于开发环境")
+		return nil
+	}
+	if config.PluginGRPC.CertDir == "" {
+		t.pass("插件 mTLS", "证书只保存在内存中")
+		return nil
+	}
+	t.checkWritableDir("插件证书目录", config.PluginGRPC.CertDir)
+	return nil
+}
+
+// checkMCPServers 检查外部 MCP 服务的启动命令能否找到
+func checkMCPServers(_ context.Context, t *selfTest) error {
+	servers, err := domainmcp.NewConfigLoader(t.logger).LoadConfig()
+	if err != nil {
+		return err
+	}
+	if len(servers) == 0 {
+		t.pass("外部 MCP 服务", "未配置")
+		return nil
+	}
+	names := make([]string, 0, len(servers))
+	for name := range servers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		server := servers[name]
+		label := "MCP " + name
+		switch {
+		case !server.Enabled:
+			t.add(label, selftest.StatusSkip, "未启用", 0)
+		case server.Command != "":
+			path, err := exec.LookPath(server.Command)
+			if err != nil {
+				t.fail(label, fmt.Errorf("找不到命令 %s", server.Command))
+				continue
+			}
+			t.pass(label, path)
+		case server.URL != "":
+			t.pass(label, server.URL)
+		default:
+			t.fail(label, fmt.Errorf("没有配置 command 或 url"))
+		}
+	}
+	return nil
+}
+
+// checkWritableDir 目录不存在时检查能否创建，存在时写入一个临时文件验证权限
+func (t *selfTest) checkWritableDir(name, dir string) {
+	if dir == "" {
+		t.pass(name, "未配置")
+		return
+	}
+	target := dir
+	info, err := os.Stat(target)
+	for os.IsNotExist(err) && target != filepath.Dir(target) {
+		target = filepath.Dir(target)
+		info, err = os.Stat(target)
+	}
+	if err != nil {
+		t.fail(name, err)
+		return
+	}
+	if !info.IsDir() {
+		t.fail(name, fmt.Errorf("%s 不是目录", target))
+		return
+	}
+	f, err := os.CreateTemp(target, ".selftest-*")
+	if err != nil {
+		t.fail(name, fmt.Errorf("%s 不可写: %w", target, err))
+		return
+	}
+	_ = f.Close()
+	_ = os.Remove(f.Name())
+	if target != dir {
+		t.pass(name, fmt.Sprintf("%s 不存在，启动时可在 %s 下创建", dir, target))
+		return
+	}
+	t.pass(name, dir+" 可写")
+}
+
+// summarize 列出前 limit 项，其余只给出数量
+func summarize(items []string, limit int) string {
+	if len(items) <= limit {
+		return strings.Join(items, "、")
+	}
+	return fmt.Sprintf("%s 等 %d 项", strings.Join(items[:limit], "、"), len(items))
+}
@@ -23,6 +23,7 @@ type TransportAdapter struct {
 
 	// WebSocket 服务器组件
 	wsTransport  *websockettransport.WebSocketTransport
+	textChat     *transport.TextChat // 未启用文本对话时为 nil
 	providerManager *providers.Manager
 	taskMgr      *task.TaskManager
 }
@@ -56,12 +57,17 @@ func NewTransportAdapter(cfg *config.Config, logger *logging.Logger, deviceRepo
 		adapter.taskMgr = taskMgr
 
 		// 创建WebSocket传输
-		adapter.wsTransport = websockettransport.NewWebSocketTransport(cfg, logger)
+		adapter.wsTransport = websockettransport.NewWebSocketTransport(cfg, logger, deviceRepo)
 
 		// 设置连接处理器工厂
 		connFactory := transport.NewDefaultConnectionHandlerFactory(cfg, providerManager, taskMgr, logger, deviceRepo, registry)
 		adapter.wsTransport.SetConnectionHandler(connFactory)
 
+		// 文本对话的无设备会话与设备连接使用同一个工厂，共享资源池和设备校验
+		if cfg.TextChat.Enabled {
+			adapter.textChat = transport.NewTextChat(cfg.TextChat, connFactory, logger)
+		}
+
 		if logger != nil {
 			logger.InfoTag("传输适配器", "WebSocket传输已初始化，已设置连接处理器工厂和池管理器")
 		}
@@ -79,6 +85,11 @@ func (ta *TransportAdapter) GetWebSocketTransport() *websockettransport.WebSocke
 	return ta.wsTransport
 }
 
+// GetTextChat 获取文本对话入口，WebSocket或文本对话未启用时返回 nil
+func (ta *TransportAdapter) GetTextChat() *transport.TextChat {
+	return ta.textChat
+}
+
 // StartTransportServer 启动传输服务器
 func (ta *TransportAdapter) StartTransportServer(ctx context.Context, domainMCPManager interface{}) error {
 	if ta.logger != nil {
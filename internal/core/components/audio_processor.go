@@ -1,15 +1,26 @@
 package components
 
 import (
+	"sync"
+
+	"xiaozhi-server-go/internal/domain/audio/preprocess"
 	"xiaozhi-server-go/internal/platform/logging"
 	"xiaozhi-server-go/internal/utils"
 )
 
+// Defaults for uplink Opus when the device does not declare audio_params in hello
+const (
+	defaultInputSampleRate = 16000
+	defaultInputChannels   = 1
+)
+
 // AudioProcessor handles audio data processing
 type AudioProcessor struct {
-	logger      *logging.Logger
-	format      string
-	opusDecoder *utils.OpusDecoder
+	mu           sync.Mutex // guards format/opusDecoder: hello updates them while audio is being decoded
+	logger       *logging.Logger
+	format       string
+	opusDecoder  *utils.OpusDecoder
+	preprocessor *preprocess.Preprocessor // optional noise suppression / echo cancellation on decoded PCM
 }
 
 // NewAudioProcessor creates a new AudioProcessor
@@ -21,8 +32,8 @@ func NewAudioProcessor(logger *logging.Logger, format string) *AudioProcessor {
 
 	if format == "opus" {
 		decoder, err := utils.NewOpusDecoder(&utils.OpusDecoderConfig{
-			SampleRate:  16000,
-			MaxChannels: 1,
+			SampleRate:  defaultInputSampleRate,
+			MaxChannels: defaultInputChannels,
 		})
 		if err != nil {
 			logger.Error("Failed to initialize Opus decoder: %v", err)
@@ -36,28 +47,55 @@ func NewAudioProcessor(logger *logging.Logger, format string) *AudioProcessor {
 
 // ProcessAudio processes incoming audio data (e.g., decoding)
 func (ap *AudioProcessor) ProcessAudio(data []byte) ([]byte, error) {
-	if ap.format == "pcm" {
-		return data, nil
-	} else if ap.format == "opus" {
-		if ap.opusDecoder != nil {
-			decodedData, err := ap.opusDecoder.Decode(data)
-			if err != nil {
-				ap.logger.Error("Failed to decode Opus audio: %v", err)
-				// Return original data on failure as fallback, or error?
-				// Original logic returned original data to queue
-				return data, nil
-			}
-			ap.logger.Debug("Opus decoded: %d bytes -> %d bytes", len(data), len(decodedData))
-			return decodedData, nil
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+
+	pcm := data
+	if ap.format == "opus" {
+		if ap.opusDecoder == nil {
+			// No decoder, return raw
+			return data, nil
 		}
-		// No decoder, return raw
+		decodedData, err := ap.opusDecoder.Decode(data)
+		if err != nil {
+			ap.logger.Error("Failed to decode Opus audio: %v", err)
+			// Return original data on failure as fallback, or error?
+			// Original logic returned original data to queue
+			return data, nil
+		}
+		ap.logger.Debug("Opus decoded: %d bytes -> %d bytes", len(data), len(decodedData))
+		pcm = decodedData
+	} else if ap.format != "pcm" {
 		return data, nil
 	}
-	return data, nil
+
+	if ap.preprocessor != nil {
+		pcm = ap.preprocessor.Process(pcm)
+	}
+	return pcm, nil
+}
+
+// SetPreprocessor sets the preprocessing stage applied to decoded PCM before it reaches VAD/ASR; nil disables it
+func (ap *AudioProcessor) SetPreprocessor(p *preprocess.Preprocessor) {
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+	ap.preprocessor = p
 }
 
-// UpdateFormat updates the audio format and re-initializes decoder if needed
+// UpdateFormat updates the audio format and re-initializes decoder if needed.
+// A zero sampleRate or channels falls back to 16kHz mono.
 func (ap *AudioProcessor) UpdateFormat(format string, sampleRate int, channels int) {
+	if sampleRate <= 0 {
+		sampleRate = defaultInputSampleRate
+	}
+	if channels <= 0 {
+		channels = defaultInputChannels
+	}
+
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+
+	ap.closeDecoder()
 	ap.format = format
 	if format == "opus" {
 		decoder, err := utils.NewOpusDecoder(&utils.OpusDecoderConfig{
@@ -70,7 +108,22 @@ func (ap *AudioProcessor) UpdateFormat(format string, sampleRate int, channels i
 		} else {
 			ap.opusDecoder = decoder
 		}
-	} else {
-		ap.opusDecoder = nil
 	}
 }
+
+// Close releases the Opus decoder
+func (ap *AudioProcessor) Close() {
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+	ap.closeDecoder()
+}
+
+func (ap *AudioProcessor) closeDecoder() {
+	if ap.opusDecoder == nil {
+		return
+	}
+	if err := ap.opusDecoder.Close(); err != nil {
+		ap.logger.Error("Failed to close Opus decoder: %v", err)
+	}
+	ap.opusDecoder = nil
+}
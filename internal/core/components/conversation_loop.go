@@ -17,6 +17,7 @@ import (
 	domaintts "xiaozhi-server-go/internal/domain/tts"
 	domainttsinter "xiaozhi-server-go/internal/domain/tts/inter"
 	providers "xiaozhi-server-go/internal/domain/providers/types"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
 	internallogging "xiaozhi-server-go/internal/platform/logging"
 	"xiaozhi-server-go/internal/platform/config"
 	internalutils "xiaozhi-server-go/internal/utils"
@@ -297,7 +298,7 @@ func (c *ConversationLoop) GenResponseByLLM(ctx context.Context, messages []prov
 	defer func() {
 		if r := recover(); r != nil {
 			c.logger.Error(fmt.Sprintf("GenResponseByLLM发生panic: %v", r))
-			errorMsg := "抱歉，处理您的请求时发生了错误"
+			errorMsg := platformerrors.UserErrorFor(platformerrors.CategoryInternal).Message
 			c.SpeakAndPlay(errorMsg, 1, round)
 		}
 	}()
@@ -368,7 +369,7 @@ func (c *ConversationLoop) GenResponseByLLM(ctx context.Context, messages []prov
 
 	// 处理回复
 	var responseMessage []string
-	processedChars := 0
+	segments := internalutils.NewSentenceStream(c.config.Dialogue.FirstSegmentMinChars, c.config.Dialogue.MaxSegmentChars)
 	textIndex := 0
 
 	atomic.StoreInt32(&c.serverVoiceStop, 0)
@@ -381,12 +382,19 @@ func (c *ConversationLoop) GenResponseByLLM(ctx context.Context, messages []prov
 	contentArguments := ""
 
 	for response := range responses {
+		if ctx.Err() != nil {
+			go func() {
+				for range responses {
+				}
+			}()
+			return nil
+		}
 		content := response.Content
 		toolCall := response.ToolCalls
 
 		if response.Error != nil {
 			c.logger.Error(fmt.Sprintf("LLM响应错误: %s", response.Error.Error()))
-			errorMsg := "抱歉，服务暂时不可用，请稍后再试"
+			errorMsg := platformerrors.Describe(platformerrors.WithCategory(response.Error, platformerrors.CategoryProviderDown)).Message
 			c.SpeakAndPlay(errorMsg, 1, round)
 			return fmt.Errorf("LLM响应错误: %s", response.Error)
 		}
@@ -418,42 +426,19 @@ func (c *ConversationLoop) GenResponseByLLM(ctx context.Context, messages []prov
 
 		// 累积回复内容
 		responseMessage = append(responseMessage, content)
-		
-		// 实时处理文本用于TTS
-		fullText := strings.Join(responseMessage, "")
-		if len(fullText) > processedChars {
-			newText := fullText[processedChars:]
-			
-			// 简单的分句逻辑，实际可能需要更复杂的处理
-			if strings.ContainsAny(newText, "，。！？；：,.!?;:") {
-				// 找到最后一个标点符号
-				lastPunct := -1
-				for i, r := range newText {
-					if strings.ContainsRune("，。！？；：,.!?;:", r) {
-						lastPunct = i
-					}
-				}
-				
-				if lastPunct != -1 {
-					sentence := newText[:lastPunct+1]
-					processedChars += len(sentence)
-					textIndex++
-					
-					// 异步发送TTS
-					go c.SpeakAndPlay(sentence, textIndex, round)
-				}
-			}
+
+		// 完整的分段按顺序送入TTS队列，边生成边合成
+		for _, sentence := range segments.Write(content) {
+			textIndex++
+			c.SpeakAndPlay(sentence, textIndex, round)
 		}
 	}
 
 	// 处理剩余文本
 	fullText := strings.Join(responseMessage, "")
-	if len(fullText) > processedChars {
-		remainingText := fullText[processedChars:]
-		if strings.TrimSpace(remainingText) != "" {
-			textIndex++
-			go c.SpeakAndPlay(remainingText, textIndex, round)
-		}
+	if remainingText := segments.Flush(); remainingText != "" {
+		textIndex++
+		c.SpeakAndPlay(remainingText, textIndex, round)
 	}
 
 	// 处理工具调用
@@ -27,6 +27,7 @@ type MCPDispatcher struct {
 	config          ConfigProvider
 	audioSender     AudioSender
 	agentID         uint
+	reminders       ReminderScheduler
 	
 	// State management
 	closeAfterChat *bool // Pointer to allow modification
@@ -57,6 +58,11 @@ type AudioSender interface {
 	AddTTSPending(delta int32)
 }
 
+// ReminderScheduler 根据工具参数创建提醒，返回给用户的确认语
+type ReminderScheduler interface {
+	ScheduleReminder(args map[string]interface{}) (string, error)
+}
+
 type LLMGenerator interface {
 	GenResponseByLLM(ctx context.Context, dialogue []interface{}, round int)
 }
@@ -95,9 +101,15 @@ func (d *MCPDispatcher) initHandlers() {
 		"mcp_handler_change_role":  d.handleChangeRole,
 		"mcp_handler_play_music":   d.handlePlayMusic,
 		"mcp_handler_switch_agent": d.handleSwitchAgent,
+		"mcp_handler_set_reminder": d.handleSetReminder,
 	}
 }
 
+// SetReminderScheduler sets the scheduler used by the set_reminder tool
+func (d *MCPDispatcher) SetReminderScheduler(s ReminderScheduler) {
+	d.reminders = s
+}
+
 // Dispatch handles the MCP result call
 func (d *MCPDispatcher) Dispatch(result llm.ActionResponse) string {
 	errResult := "调用工具失败"
@@ -273,3 +285,22 @@ func (d *MCPDispatcher) handleTakePhoto(args interface{}) {
 
 	_ = d.speaker.SystemSpeak(resp.Data.Result)
 }
+
+func (d *MCPDispatcher) handleSetReminder(args interface{}) {
+	params, ok := args.(map[string]interface{})
+	if !ok {
+		d.logger.Error("mcp_handler_set_reminder: args is not a map")
+		return
+	}
+	if d.reminders == nil {
+		_ = d.speaker.SystemSpeak("提醒功能暂不可用")
+		return
+	}
+	reply, err := d.reminders.ScheduleReminder(params)
+	if err != nil {
+		d.logger.Error("mcp_handler_set_reminder: %v", err)
+		_ = d.speaker.SystemSpeak("设置提醒失败了，请换个说法再试一次")
+		return
+	}
+	_ = d.speaker.SystemSpeak(reply)
+}
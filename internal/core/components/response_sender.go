@@ -3,6 +3,7 @@ package components
 import (
 	"encoding/json"
 	"fmt"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
 	"xiaozhi-server-go/internal/platform/logging"
 	"xiaozhi-server-go/internal/utils"
 )
@@ -28,14 +29,20 @@ func NewResponseSender(conn MessageWriter, logger *logging.Logger, sessionID str
 	}
 }
 
-// SendHello sends the initial hello message
-func (s *ResponseSender) SendHello(version int, transport string, audioParams map[string]interface{}) error {
+// SendHello sends the initial hello message with the negotiated protocol version, features and framing
+func (s *ResponseSender) SendHello(version int, transport string, audioParams map[string]interface{}, features map[string]bool, framing string) error {
 	hello := make(map[string]interface{})
 	hello["type"] = "hello"
 	hello["version"] = version
 	hello["transport"] = transport
 	hello["session_id"] = s.sessionID
 	hello["audio_params"] = audioParams
+	if features != nil {
+		hello["features"] = features
+	}
+	if framing != "" {
+		hello["framing"] = framing
+	}
 
 	data, err := json.Marshal(hello)
 	if err != nil {
@@ -45,6 +52,54 @@ func (s *ResponseSender) SendHello(version int, transport string, audioParams ma
 	return s.conn.WriteMessage(1, data)
 }
 
+// SendHelloError rejects the handshake with an error code and what the server supports.
+// Errors with a user-facing category also carry the category and a displayable code.
+func (s *ResponseSender) SendHelloError(code, message string, user platformerrors.UserError, supported interface{}) error {
+	helloErr := map[string]string{
+		"code":    code,
+		"message": message,
+	}
+	if user.Category != platformerrors.CategoryInternal {
+		helloErr["category"] = string(user.Category)
+		helloErr["display_code"] = user.Code
+		helloErr["action"] = string(user.Action)
+	}
+	hello := map[string]interface{}{
+		"type":       "hello",
+		"status":     "error",
+		"session_id": s.sessionID,
+		"error":      helloErr,
+		"supported":  supported,
+	}
+
+	data, err := json.Marshal(hello)
+	if err != nil {
+		return fmt.Errorf("failed to marshal hello error: %v", err)
+	}
+
+	return s.conn.WriteMessage(1, data)
+}
+
+// SendError sends a categorized error so the firmware can show the code and decide whether to retry
+func (s *ResponseSender) SendError(user platformerrors.UserError) error {
+	errorMsg := map[string]interface{}{
+		"type":       "error",
+		"session_id": s.sessionID,
+		"category":   user.Category,
+		"code":       user.Code,
+		"message":    user.Message,
+		"action":     user.Action,
+		"retryable":  user.Retryable,
+	}
+
+	data, err := json.Marshal(errorMsg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal error message: %v", err)
+	}
+
+	return s.conn.WriteMessage(1, data)
+}
+
 // SendTTSState sends TTS state updates (start, stop, etc.)
 func (s *ResponseSender) SendTTSState(state string, text string, textIndex int) error {
 	stateMsg := map[string]interface{}{
@@ -103,6 +158,37 @@ func (s *ResponseSender) SendEmotion(emotion string) error {
 	return s.conn.WriteMessage(1, jsonData)
 }
 
+// SendDebugRequest sends a remote debug request; the device replies with a debug message carrying the same id
+func (s *ResponseSender) SendDebugRequest(id string, action string, params map[string]interface{}) error {
+	debugMsg := map[string]interface{}{
+		"type":       "debug",
+		"session_id": s.sessionID,
+		"id":         id,
+		"action":     action,
+		"params":     params,
+	}
+
+	data, err := json.Marshal(debugMsg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal debug request: %v", err)
+	}
+
+	return s.conn.WriteMessage(1, data)
+}
+
+// SendPong answers a heartbeat ping from the device
+func (s *ResponseSender) SendPong() error {
+	data, err := json.Marshal(map[string]interface{}{
+		"type":       "pong",
+		"session_id": s.sessionID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal pong: %v", err)
+	}
+
+	return s.conn.WriteMessage(1, data)
+}
+
 // SendAudioFrame sends a single audio frame
 func (s *ResponseSender) SendAudioFrame(data []byte) error {
 	return s.conn.WriteMessage(2, data)
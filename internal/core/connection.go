@@ -27,9 +27,24 @@ import (
 	domaintts "xiaozhi-server-go/internal/domain/tts"
 	domainttsinter "xiaozhi-server-go/internal/domain/tts/inter"
 	"xiaozhi-server-go/internal/platform/config"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/requestid"
 	"xiaozhi-server-go/internal/platform/storage"
+	"xiaozhi-server-go/internal/domain/budget"
 	"xiaozhi-server-go/internal/domain/chat"
+	"xiaozhi-server-go/internal/domain/experiment"
+	"xiaozhi-server-go/internal/domain/flags"
+	"xiaozhi-server-go/internal/domain/member"
+	"xiaozhi-server-go/internal/domain/intent"
+	"xiaozhi-server-go/internal/domain/moderation"
+	"xiaozhi-server-go/internal/domain/presence"
+	"xiaozhi-server-go/internal/domain/protocol"
+	"xiaozhi-server-go/internal/domain/speechtext"
+	"xiaozhi-server-go/internal/domain/audio/preprocess"
+	domainprompt "xiaozhi-server-go/internal/domain/prompt"
 	domainproviders "xiaozhi-server-go/internal/domain/providers"
+	"xiaozhi-server-go/internal/domain/tenant"
+	"xiaozhi-server-go/internal/domain/toolpolicy"
 	providers "xiaozhi-server-go/internal/domain/providers/types"
 	"xiaozhi-server-go/internal/domain/providers/llm"
 	"xiaozhi-server-go/internal/domain/providers/tts"
@@ -70,8 +85,10 @@ type ConnectionHandler struct {
 	_                components.AudioSender
 	
 	config           *config.Config
+	tenantProviders  tenant.Providers // 设备所属租户的供应商配置，已合并到 config
 	logger           *internallogging.Logger // TODO: 待logger.go迁移后更新
 	conn             Connection
+	framedConn       *framedConnection // 与conn相同，用于切换帧格式和解码protobuf帧
 	closeOnce        sync.Once
 	taskMgr          *task.TaskManager
 	safeCallbackFunc func(func(*ConnectionHandler)) func()
@@ -87,6 +104,7 @@ type ConnectionHandler struct {
 	ttsManager    *domaintts.Manager
 	configService *service.ConfigService // 配置服务
 	registry      *capability.Registry   // 插件注册表
+	offline       offlineState           // 离线模式下替换的 ASR、LLM、TTS
 
 	initialVoice    string // 初始语音名称
 	ttsProviderName string // 默认TTS提供者名称
@@ -109,7 +127,9 @@ type ConnectionHandler struct {
 	serverAudioSampleRate    int
 	serverAudioChannels      int
 	serverAudioFrameDuration int
+	serverAudioBitrate       int // opus 码率，0 为自动
 
+	protocolSession  *protocol.Session // 握手协商结果，设备未发送hello时为nil
 	clientListenMode string
 	isDeviceVerified bool
 	closeAfterChat   bool
@@ -125,7 +145,8 @@ type ConnectionHandler struct {
 	ttsPending      int32 // 当前待播放的TTS段数量
 	llmGenerating   int32 // 1表示LLM正在生成回复
 
-	opusDecoder *internalutils.OpusDecoder // Opus解码器
+	opusDecoder       *internalutils.OpusDecoder               // Opus解码器
+	audioPreprocessor atomic.Pointer[preprocess.Preprocessor] // 上行音频预处理（降噪/回声消除），未开启时为nil
 
 	// 对话相关
 	dialogueManager      *chat.DialogueManager
@@ -139,7 +160,16 @@ type ConnectionHandler struct {
 	clientTextQueue  chan string
 
 	// ASR结果队列 - 用于避免重复识别导致的并发处理
-	asrResultQueue chan string
+	asrResultQueue chan asrResult
+	textChatQueue  chan *textChatTurn // 经HTTP提交的文本对话，与语音轮次在同一协程中串行处理
+	notifyQueue    chan *notification // 服务端主动播报，同样在语音轮次的协程中执行
+	textChat       *textChatTurn      // 进行中的文本对话轮次，由 roundMu 保护
+	utterance      utteranceBuffer    // 送入ASR的语音，开启语音归档且设备已授权时才缓存
+	turnLatency    turnLatencyTracker // 语音起止和当前轮次各阶段的时间点
+	turnTraces     turnTraceCollector // 当前轮次能力调用的追踪信息
+	recent         recentSnippets     // 最近的对话片段，供会话监控展示
+	connectedAt    time.Time          // 设备连接建立时间
+	debugCalls     debugCalls         // 等待设备回复的远程调试请求
 
 	// TTS任务队列
 	ttsQueue chan struct {
@@ -164,6 +194,22 @@ type ConnectionHandler struct {
 	mcpManager       *domainmcp.Manager
 
 	mcpResultHandlers map[string]func(interface{}) // MCP处理器映射
+	intentRouter      *intent.Router                // 意图路由器，为nil时全部交给LLM
+	dialogueState     *chat.StateMachine            // 对话状态机（listening/thinking/speaking）
+	moderation        *moderation.Pipeline          // 内容审核，为nil时不审核
+	budget            *budget.Session               // Token预算计量，为nil时不限制
+	speechText        *speechtext.Pipeline          // 播报文本后处理，为nil时原样播报
+	language          *languageState                // 语言自动识别，为nil时不识别
+	experiment        *experiment.Assignment        // 所在的A/B实验分组，为nil时未参与实验
+	speaker           *member.Member                // 当前识别到的家庭成员，为nil时未识别
+	basePrompt        string                        // 追加说话人信息之前的系统提示词
+	blockedRound      int32                         // 输出被拦截的轮次，该轮后续分段不再播放
+	translationRound  int32                         // 播报译文的轮次，该轮分段使用目标语言的音色
+	media             *mediaPlayer                  // 媒体播放器，经下行音频通道播放音乐和电台
+	roundMu           sync.Mutex
+	roundCancel       context.CancelFunc // 取消当前轮次的LLM生成，用于打断
+	speechCtx         context.Context    // 进行中的语音合成，服务端停止说话时取消
+	speechCancel      context.CancelFunc
 	ctx               context.Context
 
 	// Components
@@ -189,7 +235,9 @@ func NewConnectionHandler(
 		stopChan:         make(chan struct{}),
 		clientAudioQueue: make(chan []byte, 100),
 		clientTextQueue:  make(chan string, 100),
-		asrResultQueue:   make(chan string, 10), // ASR结果队列，缓冲大小为10
+		asrResultQueue:   make(chan asrResult, 10), // ASR结果队列，缓冲大小为10
+		textChatQueue:    make(chan *textChatTurn, 8),
+		notifyQueue:      make(chan *notification, 8),
 		ttsQueue: make(chan struct {
 			text      string
 			round     int // 轮次
@@ -206,7 +254,9 @@ func NewConnectionHandler(
 		tts_last_text_index:  -1,
 		tts_last_audio_index: -1,
 
-		talkRound: 0,
+		talkRound:        0,
+		blockedRound:     -1,
+		translationRound: -1,
 
 		serverAudioFormat:        "opus", // 默认使用Opus格式
 		audioProcessor:           components.NewAudioProcessor(logger, "opus"),
@@ -218,6 +268,8 @@ func NewConnectionHandler(
 
 		headers: make(map[string]string),
 	}
+	// 设备未在 hello 中协商前，下行音频使用配置的默认参数
+	handler.setServerAudio(handler.outputAudioDefaults())
 	
 	// Initialize MCP Dispatcher
 	// Note: dialogueManager is initialized later in InitWithAgent, so we might need to update dispatcher then.
@@ -271,6 +323,13 @@ func NewConnectionHandler(
 			handler.sessionID = "device-" + strings.Replace(handler.deviceID, ":", "_", -1)
 		}
 	}
+	handler.dialogueState = chat.NewStateMachine(handler.sessionID, handler.deviceID)
+	handler.media = newMediaPlayer(handler)
+	// 本连接发起的工具和能力调用按设备的工具权限策略检查
+	if handler.ctx == nil {
+		handler.ctx = context.Background()
+	}
+	handler.ctx = toolpolicy.WithSubject(handler.ctx, toolpolicy.Subject{DeviceID: handler.deviceID, SessionID: handler.sessionID})
 
 	// 正确设置providers
 	if providerSet != nil {
@@ -281,6 +340,12 @@ func NewConnectionHandler(
 		handler.mcpManager = providerSet.MCP
 	}
 	handler.checkDeviceInfo()
+	// 设备所属租户的 LLM 和 TTS 配置覆盖实例配置
+	if svc := tenant.Default(); svc != nil {
+		handler.tenantProviders = svc.ProvidersFor(handler.ctx)
+		config = handler.tenantProviders.Apply(config)
+		handler.config = config
+	}
 
 	// 初始化配置服务
 	configRepo := manager.NewDatabaseRepository(nil)
@@ -289,6 +354,9 @@ func NewConnectionHandler(
 	prompt := handler.InitWithAgent()
 	handler.checkTTSProvider(config) // 检查TTS提供者
 	handler.checkLLMProvider(config) // 检查LLM提供者是否匹配
+	prompt = handler.applyExperiment(config, prompt)
+	handler.basePrompt = prompt
+	handler.identifySpeaker("")
 
 	// 初始化新架构的 LLM 和 TTS Manager
 	handler.initManagers(config)
@@ -301,7 +369,7 @@ func NewConnectionHandler(
 
 	// 初始化对话管理器
 	handler.dialogueManager = chat.NewDialogueManager(handler.logger, nil)
-	handler.dialogueManager.SetSystemMessage(prompt)
+	handler.dialogueManager.SetSystemMessage(handler.speakerPrompt(prompt))
 	handler.functionRegister = domainllminfra.NewFunctionRegistry()
 	
 	// Re-initialize MCP Dispatcher with initialized dependencies
@@ -316,18 +384,52 @@ func NewConnectionHandler(
 		&handler.closeAfterChat,
 	)
 	
+	handler.mcpDispatcher.SetReminderScheduler(handler)
 	handler.initMCPResultHandlers()
+	handler.initIntentRouter()
+	handler.initModeration()
+	handler.initBudget()
+	handler.initSpeechText()
+	handler.initLanguage()
 
 	return handler
 }
 
+// tenantContext 返回携带设备所属租户和连接关联ID、但不随连接关闭而取消的上下文
+func (h *ConnectionHandler) tenantContext() context.Context {
+	return requestid.WithID(tenant.WithID(context.Background(), tenant.IDFrom(h.ctx)), requestid.FromContext(h.ctx))
+}
+
+// flagEnabled 判断功能开关对本连接的租户和设备是否开启
+func (h *ConnectionHandler) flagEnabled(key string) bool {
+	return flags.Enabled(flags.WithDevice(h.tenantContext(), h.deviceID), key)
+}
+
 func (h *ConnectionHandler) InitWithAgent() string {
-	// Database functionality removed - return default prompt
+	// 优先使用提示词模板服务中分配给设备的模板，未分配时使用配置中的默认提示词
+	if svc := domainprompt.Default(); svc != nil {
+		if prompt, ok := svc.ResolveForDevice(h.tenantContext(), h.deviceID, h.promptVariables()); ok {
+			return prompt
+		}
+	}
 	prompt := h.config.System.DefaultPrompt
-	h.LogDebug("Database functionality removed - using default agent configuration")
+	h.LogDebug("未分配提示词模板 - 使用默认提示词")
 	return prompt
 }
 
+// promptVariables 渲染提示词模板时传入的连接变量，home_members 为当前在家成员的称呼
+func (h *ConnectionHandler) promptVariables() map[string]string {
+	vars := map[string]string{
+		"device_id":    h.deviceID,
+		"session_id":   h.sessionID,
+		"home_members": "",
+	}
+	if svc := presence.Default(); svc != nil {
+		vars["home_members"] = strings.Join(svc.HomeNames(), "、")
+	}
+	return vars
+}
+
 func (h *ConnectionHandler) checkTTSProvider(config *config.Config) {
 	h.ttsProviderName = "default" // 默认TTS提供者名称
 	h.voiceName = "default"
@@ -355,29 +457,11 @@ func (h *ConnectionHandler) checkLLMProvider(config *config.Config) {
 	// 判断handler.providers.llm 类型是否和用户选择的LLM相同
 	if getter, ok := h.providers.llm.(llmConfigGetter); ok {
 		currentLLMName := getter.Config().Name
-		if currentLLMName != llmName {
+		_, tenantLLM := h.tenantProviders.LLM[llmName]
+		if currentLLMName != llmName || tenantLLM {
 			// 根据用户选择的LLM类型设置LLM提供者
-			if cfg, ok := config.LLM[llmName]; !ok {
-				h.LogError(fmt.Sprintf("用户选择的LLM类型 %s 不存在", llmName))
-			} else {
-				llmCfg := &llm.Config{
-					Name:        llmName,
-					Type:        cfg.Type,
-					ModelName:   cfg.ModelName,
-					BaseURL:     cfg.BaseURL,
-					APIKey:      cfg.APIKey,
-					Temperature: cfg.Temperature,
-					MaxTokens:   cfg.MaxTokens,
-					TopP:        cfg.TopP,
-					Extra:       cfg.Extra,
-				}
-				newllm, err := llm.Create(cfg.Type, llmCfg)
-				if err != nil {
-					h.LogError(fmt.Sprintf("创建LLM提供者失败: %v", err))
-				} else {
-					h.providers.llm = newllm
-					h.LogInfo(fmt.Sprintf("已切换到用户选择的LLM提供者: %s", llmName))
-				}
+			if h.switchLLMProvider(config, llmName) {
+				h.LogInfo(fmt.Sprintf("已切换到用户选择的LLM提供者: %s", llmName))
 			}
 		} else {
 			h.LogDebug(fmt.Sprintf("使用用户选择的LLM类型: %s", llmName))
@@ -385,6 +469,33 @@ func (h *ConnectionHandler) checkLLMProvider(config *config.Config) {
 	}
 }
 
+// switchLLMProvider 按配置名称创建LLM提供者并替换当前提供者
+func (h *ConnectionHandler) switchLLMProvider(config *config.Config, llmName string) bool {
+	cfg, ok := config.LLM[llmName]
+	if !ok {
+		h.LogError(fmt.Sprintf("LLM类型 %s 不存在", llmName))
+		return false
+	}
+	llmCfg := &llm.Config{
+		Name:        llmName,
+		Type:        cfg.Type,
+		ModelName:   cfg.ModelName,
+		BaseURL:     cfg.BaseURL,
+		APIKey:      cfg.APIKey,
+		Temperature: cfg.Temperature,
+		MaxTokens:   cfg.MaxTokens,
+		TopP:        cfg.TopP,
+		Extra:       cfg.Extra,
+	}
+	newllm, err := llm.Create(cfg.Type, llmCfg)
+	if err != nil {
+		h.LogError(fmt.Sprintf("创建LLM提供者失败: %v", err))
+		return false
+	}
+	h.providers.llm = newllm
+	return true
+}
+
 func (h *ConnectionHandler) checkDeviceInfo() {
 	h.agentID = 0 // 清空AgentID
 	h.userID = "" // 清空用户ID
@@ -536,8 +647,15 @@ func (h *ConnectionHandler) LogWarn(msg string) {
 func (h *ConnectionHandler) Handle(conn Connection) {
 	defer conn.Close()
 
-	h.conn = conn
-	h.responseSender = components.NewResponseSender(conn, h.logger, h.sessionID)
+	h.framedConn = newFramedConnection(conn)
+	h.conn = h.framedConn
+	h.responseSender = components.NewResponseSender(h.conn, h.logger, h.sessionID)
+
+	h.connectedAt = time.Now()
+	registerActiveHandler(h)
+	defer unregisterActiveHandler(h)
+	defer h.notifyMonitorClosed()
+	h.setDialogueState(chat.StateListening, "connected")
 
 	// Initialize ConversationLoop
 	h.conversationLoop = components.NewConversationLoop(
@@ -581,7 +699,7 @@ func (h *ConnectionHandler) Handle(conn Connection) {
 			"client_id":  h.clientId,
 			"token":      h.config.Server.Token,
 		}
-		if err := h.mcpManager.BindConnection(conn, h.functionRegister, params); err != nil {
+		if err := h.mcpManager.BindConnection(h.conn, h.functionRegister, params); err != nil {
 			h.LogError(fmt.Sprintf("[MCP] bind connection failed: %v", err))
 			return
 		}
@@ -618,7 +736,8 @@ func (h *ConnectionHandler) processASRResultQueueCoroutine() {
 		case <-h.stopChan:
 			h.LogDebug("[协程] [ASR队列] 收到停止信号，退出协程")
 			return
-		case asrText := <-h.asrResultQueue:
+		case res := <-h.asrResultQueue:
+			asrText := res.text
 			h.LogInfo(fmt.Sprintf("[协程] [ASR队列] 处理ASR结果: %s", internalutils.SanitizeForLog(asrText)))
 
 			// 检查是否是重复的唤醒词处理
@@ -630,11 +749,21 @@ func (h *ConnectionHandler) processASRResultQueueCoroutine() {
 				}
 			}
 
-			if err := h.handleChatMessage(context.Background(), asrText); err != nil {
+			ctx := h.beginRound()
+			h.utterance.setPending(res.audio)
+			h.turnLatency.setPending(res.speech)
+			err := h.handleChatMessage(ctx, asrText)
+			h.utterance.setPending(nil)
+			h.endRound()
+			if err != nil {
 				h.LogError(fmt.Sprintf("[协程] [ASR队列] 处理ASR结果失败: %v", err))
 			} else {
 				h.LogDebug(fmt.Sprintf("[协程] [ASR队列] ASR结果处理完成: %s", internalutils.SanitizeForLog(asrText)))
 			}
+		case turn := <-h.textChatQueue:
+			h.runTextChat(turn)
+		case n := <-h.notifyQueue:
+			h.runNotification(n)
 		}
 	}
 }
@@ -650,7 +779,7 @@ func (h *ConnectionHandler) processClientTextMessagesCoroutine() {
 			h.LogDebug("[协程] [文本队列] 收到停止信号，退出协程")
 			return
 		case text := <-h.clientTextQueue:
-			if err := h.processClientTextMessage(context.Background(), text); err != nil {
+			if err := h.processClientTextMessage(h.tenantContext(), text); err != nil {
 				h.LogError(fmt.Sprintf("[协程] [文本队列] 处理文本消息失败: %v", err))
 			}
 		}
@@ -669,11 +798,14 @@ func (h *ConnectionHandler) processClientAudioMessagesCoroutine() {
 			return
 		case data := <-h.clientAudioQueue:
 			// 如果已设置为在播放服务端语音时暂停ASR，则跳过发送到ASR
-			if atomic.LoadInt32(&h.asrPause) == 1 {
+			if atomic.LoadInt32(&h.asrPause) == 1 && !h.bargeInEnabled() {
 				h.LogDebug("[协程] [音频队列] 当前处于ASR暂停状态，跳过发送客户端音频到ASR")
 				continue
 			}
 
+			h.bufferUtteranceAudio(data)
+			h.turnLatency.observeAudio(data)
+
 			// 将音频数据发送给ASR提供者
 			if h.providers.asr != nil {
 				if err := h.providers.asr.AddAudio(data); err != nil {
@@ -713,9 +845,16 @@ func (h *ConnectionHandler) OnAsrResult(result string, isFinalResult bool) bool
 			return false
 		}
 		h.LogInfo(fmt.Sprintf("[ASR] [识别结果 %s/%s]", h.clientListenMode, internalutils.SanitizeForLog(result)))
+		if h.bargeInEnabled() && h.dialogueState.IsBusy() {
+			if !h.shouldBargeIn(result) {
+				h.LogDebug(fmt.Sprintf("[ASR] [打断] 未达到打断条件，忽略: %s", internalutils.SanitizeForLog(result)))
+				return false
+			}
+			h.bargeIn(result)
+		}
 		// 将ASR结果放入队列，避免并发处理
 		select {
-		case h.asrResultQueue <- result:
+		case h.asrResultQueue <- asrResult{text: result, audio: h.utterance.take(), speech: h.turnLatency.takeSpeech()}:
 			h.LogDebug(fmt.Sprintf("[ASR] [队列] 已将结果放入队列: %s", internalutils.SanitizeForLog(result)))
 		default:
 			h.LogWarn(fmt.Sprintf("[ASR] [队列] 队列已满，丢弃结果: %s", internalutils.SanitizeForLog(result)))
@@ -726,7 +865,7 @@ func (h *ConnectionHandler) OnAsrResult(result string, isFinalResult bool) bool
 		if isFinalResult {
 			// 将ASR结果放入队列，避免并发处理
 			select {
-			case h.asrResultQueue <- h.client_asr_text:
+			case h.asrResultQueue <- asrResult{text: h.client_asr_text, audio: h.utterance.take(), speech: h.turnLatency.takeSpeech()}:
 				h.LogDebug(fmt.Sprintf("[ASR] [队列] 已将手动结果放入队列: %s", internalutils.SanitizeForLog(h.client_asr_text)))
 			default:
 				h.LogWarn(fmt.Sprintf("[ASR] [队列] 队列已满，丢弃手动结果: %s", internalutils.SanitizeForLog(h.client_asr_text)))
@@ -738,12 +877,16 @@ func (h *ConnectionHandler) OnAsrResult(result string, isFinalResult bool) bool
 		if result == "" {
 			return false
 		}
-		h.stopServerSpeak()
+		if h.dialogueState != nil && h.dialogueState.IsBusy() {
+			h.bargeIn(result)
+		} else {
+			h.stopServerSpeak()
+		}
 		h.providers.asr.Reset() // 重置ASR状态，准备下一次识别
 		h.LogInfo(fmt.Sprintf("[ASR] [识别结果 %s/%s]", h.clientListenMode, internalutils.SanitizeForLog(result)))
 		// 将ASR结果放入队列，避免并发处理
 		select {
-		case h.asrResultQueue <- result:
+		case h.asrResultQueue <- asrResult{text: result, audio: h.utterance.take(), speech: h.turnLatency.takeSpeech()}:
 			h.LogDebug(fmt.Sprintf("[ASR] [队列] 已将实时结果放入队列: %s", internalutils.SanitizeForLog(result)))
 		default:
 			h.LogWarn(fmt.Sprintf("[ASR] [队列] 队列已满，丢弃实时结果: %s", internalutils.SanitizeForLog(result)))
@@ -829,6 +972,9 @@ clearedAudioQueue:
 	h.roundStartTime = time.Now()
 	currentRound := h.talkRound
 	h.LogInfo(fmt.Sprintf("[对话] [轮次 %d] 开始新的对话轮次", currentRound))
+	h.setDialogueState(chat.StateThinking, "user_speech")
+	h.beginTurnLatency(currentRound)
+	h.detectUtteranceLanguage(text)
 
 	// 普通文本消息处理流程
 	// 立即发送 stt 消息
@@ -852,16 +998,49 @@ clearedAudioQueue:
 		return fmt.Errorf("发送情绪消息失败: %v", err)
 	}
 
+	// 开启、切换、退出翻译模式的语音指令
+	if h.handleTranslationCommand(text, currentRound) {
+		return nil
+	}
+	// 翻译模式下只做输入审核，译文用目标语言播报，不进入对话
+	if h.inTranslation() {
+		return h.translateTurn(ctx, text, currentRound)
+	}
+
+	// 对话流水线：输入审核、意图路由以及部署配置的其他节点，拦截时直接播报拦截回复
+	result := h.runPipeline(ctx, text)
+	if result.Blocked {
+		h.tts_last_text_index = 1
+		if err := h.SpeakAndPlay(h.blockReply(), 1, currentRound); err != nil {
+			h.LogError(fmt.Sprintf("[审核] 播放拦截回复失败: %v", err))
+		}
+		return nil
+	}
+	text = result.Text
+
 	// 添加用户消息到对话历史
-	h.dialogueManager.Put(chat.Message{
+	h.putMessage(chat.Message{
 		Role:    "user",
 		Content: text,
 	})
 
-	return h.genResponseByLLM(ctx, h.dialogueManager.GetLLMDialogue(), currentRound)
+	// 确定性意图（时间、计时器、设备控制等）或流水线给出的回复直接播报，不调用LLM
+	if result.Reply != "" {
+		h.speakDirectReply(result.Reply, currentRound)
+		return nil
+	}
+
+	return h.genResponseByLLM(ctx, withTurnContext(h.dialogueManager.GetLLMDialogueWithMemory(h.memoryPrompt(ctx, text)), h.knowledgeContext(ctx, text, result.Context)), currentRound)
 }
 
 func (h *ConnectionHandler) genResponseByLLM(ctx context.Context, messages []providers.Message, round int) error {
+	// 超出Token预算时播报提示，不调用LLM
+	promptEstimate := estimatePromptTokens(messages)
+	if reply, ok := h.checkBudget(ctx, round, promptEstimate); !ok {
+		h.speakDirectReply(reply, round)
+		return nil
+	}
+
 	atomic.StoreInt32(&h.llmGenerating, 1)
 	// h.LogInfo(fmt.Sprintf("[DEBUG] genResponseByLLM start, set llmGenerating=1, round=%d", round))
 	defer func() {
@@ -873,7 +1052,7 @@ func (h *ConnectionHandler) genResponseByLLM(ctx context.Context, messages []pro
 		// 此时如果SendAudioMessage已经执行完毕（pending=0），它可能因为当时llmGenerating=1而没有清除状态
 		// 所以这里需要补救
 		pending := atomic.LoadInt32(&h.ttsPending)
-		if pending == 0 {
+		if pending == 0 && h.textChatTurn(round) == nil {
 			h.LogInfo("[LLM] 生成结束且无待播放音频，清除讲话状态")
 			h.sendTTSMessage("stop", "", h.tts_last_text_index)
 			// 恢复ASR接收移至 clearSpeakStatus 中处理
@@ -887,13 +1066,19 @@ func (h *ConnectionHandler) genResponseByLLM(ctx context.Context, messages []pro
 
 		if r := recover(); r != nil {
 			h.LogError(fmt.Sprintf("genResponseByLLM发生panic: %v", r))
-			errorMsg := "抱歉，处理您的请求时发生了错误"
-			h.tts_last_text_index = 1 // 重置文本索引
-			h.SpeakAndPlay(errorMsg, 1, round)
+			h.reportError(fmt.Errorf("genResponseByLLM panic: %v", r), round)
 		}
 	}()
 
 	llmStartTime := time.Now()
+	var firstSegmentLatency time.Duration
+	var usage *domainllminter.Usage
+	defer func() {
+		if firstSegmentLatency == 0 {
+			firstSegmentLatency = time.Since(llmStartTime)
+		}
+		h.recordExperimentOutcome(round, firstSegmentLatency, usage, ctx.Err() != nil)
+	}()
 	//h.logger.Info("开始生成LLM回复, round:%d ", round)
 	for _, msg := range messages {
 		_ = msg
@@ -959,18 +1144,21 @@ func (h *ConnectionHandler) genResponseByLLM(ctx context.Context, messages []pro
 	}
 	h.LogDebug(fmt.Sprintf("[调试] 转换完成，共 %d 个工具", len(interTools)))
 
-	responses, err := h.llmManager.Response(ctx, h.sessionID, interMessages, interTools)
+	// 设备实时对话，与能力调用共用优先级队列并优先于API和批处理任务
+	llmCtx := capability.WithPriority(h.withTurnTraces(ctx, round), capability.PriorityInteractive)
+	responses, err := h.llmManager.Response(llmCtx, h.sessionID, interMessages, interTools)
 	if err != nil {
 		// 发布LLM错误事件
 		if publisher := llm.GetEventPublisher(h.providers.llm); publisher != nil {
 			publisher.PublishLLMError(err, round)
 		}
-		return fmt.Errorf("LLM生成回复失败: %v", err)
+		h.reportError(platformerrors.WithCategory(err, platformerrors.CategoryProviderDown), round)
+		return fmt.Errorf("LLM生成回复失败: %w", err)
 	}
 
 	// 处理回复
 	var responseMessage []string
-	processedChars := 0
+	segments := h.newSentenceStream()
 	textIndex := 0
 
 	atomic.StoreInt32(&h.serverVoiceStop, 0)
@@ -981,18 +1169,34 @@ func (h *ConnectionHandler) genResponseByLLM(ctx context.Context, messages []pro
 	functionID := ""
 	functionArguments := ""
 	contentArguments := ""
+	defer func() {
+		h.recordBudget(round, promptEstimate, usage, contentArguments+functionArguments)
+	}()
 
 	for response := range responses {
+		if ctx.Err() != nil {
+			// 本轮已被用户打断，丢弃剩余输出并让生产者尽快退出
+			h.LogInfo(fmt.Sprintf("[对话] [轮次 %d] 已被打断，停止处理LLM输出", round))
+			go func() {
+				for range responses {
+				}
+			}()
+			return nil
+		}
 		content := response.Content
 		toolCall := response.ToolCalls
+		if response.Usage != nil {
+			usage = response.Usage
+		}
 
 		if response.Error != nil {
 			h.LogError(fmt.Sprintf("LLM响应错误: %s", response.Error.Error()))
-			errorMsg := "抱歉，服务暂时不可用，请稍后再试"
-			h.tts_last_text_index = 1 // 重置文本索引
-			h.SpeakAndPlay(errorMsg, 1, round)
+			h.reportError(platformerrors.WithCategory(response.Error, platformerrors.CategoryProviderDown), round)
 			return fmt.Errorf("LLM响应错误: %s", response.Error)
 		}
+		if content != "" || len(toolCall) > 0 {
+			h.turnLatency.mark(round, markLLMFirstToken, false)
+		}
 
 		if content != "" {
 			// 累加content_arguments
@@ -1019,10 +1223,9 @@ func (h *ConnectionHandler) genResponseByLLM(ctx context.Context, messages []pro
 		if content != "" {
 			if strings.Contains(content, "服务响应异常") {
 				h.LogError(fmt.Sprintf("检测到LLM服务异常: %s", content))
-				errorMsg := "抱歉，LLM服务暂时不可用，请稍后再试"
-				h.tts_last_text_index = 1 // 重置文本索引
-				h.SpeakAndPlay(errorMsg, 1, round)
-				return fmt.Errorf("LLM服务异常")
+				err := platformerrors.WithCategory(errors.New("LLM服务异常"), platformerrors.CategoryProviderDown)
+				h.reportError(err, round)
+				return err
 			}
 
 			if toolCallFlag {
@@ -1030,24 +1233,17 @@ func (h *ConnectionHandler) genResponseByLLM(ctx context.Context, messages []pro
 			}
 
 			responseMessage = append(responseMessage, content)
-			// 处理分段
-			fullText := internalutils.JoinStrings(responseMessage)
-			if len(fullText) <= processedChars {
-				h.logger.Warn("文本处理异常: fullText长度=%d, processedChars=%d", len(fullText), processedChars)
-				continue
-			}
-			currentText := fullText[processedChars:]
-
-			// 按标点符号分割
-			if segment, charsCnt := internalutils.SplitAtLastPunctuation(currentText); charsCnt > 0 {
+			// 已完整的分段立即送入TTS，不等待整段回复生成完毕
+			for _, segment := range segments.Write(content) {
 				textIndex++
-				segment = strings.TrimSpace(segment)
 				h.tts_last_text_index = textIndex
+				if textIndex == 1 {
+					firstSegmentLatency = time.Since(llmStartTime)
+				}
 				err := h.SpeakAndPlay(segment, textIndex, round)
 				if err != nil {
 					h.LogError(fmt.Sprintf("播放LLM回复分段失败: %v", err))
 				}
-				processedChars += charsCnt
 
 				// 发布LLM响应事件
 				if publisher := llm.GetEventPublisher(h.providers.llm); publisher != nil {
@@ -1085,6 +1281,7 @@ func (h *ConnectionHandler) genResponseByLLM(ctx context.Context, messages []pro
 		if !bHasError {
 			// 清空responseMessage
 			responseMessage = []string{}
+			segments.Reset()
 			arguments := make(map[string]interface{})
 			if err := json.Unmarshal([]byte(functionArguments), &arguments); err != nil {
 				h.LogError(fmt.Sprintf("函数调用参数解析失败: %v", err))
@@ -1096,28 +1293,36 @@ func (h *ConnectionHandler) genResponseByLLM(ctx context.Context, messages []pro
 			}
 			h.LogInfo(fmt.Sprintf("函数调用: %v", arguments))
 			if h.mcpManager.IsMCPTool(functionName) {
-				// 处理MCP函数调用
-				result, err := h.mcpManager.ExecuteTool(ctx, functionName, arguments)
-				if err != nil {
-					h.LogError(fmt.Sprintf("MCP函数调用失败: %v", err))
-					if result == nil {
-						result = "MCP工具调用失败"
-					}
-				}
-				// 判断result 是否是domainllm.ActionResponse类型
-				if actionResult, ok := result.(domainllm.ActionResponse); ok {
-					h.handleFunctionResult(actionResult, functionCallData, textIndex)
+				// 处理MCP函数调用，被工具权限策略拒绝时把原因交给LLM
+				if err := toolpolicy.Authorize(ctx, toolpolicy.KindTool, functionName, arguments); err != nil {
+					h.sendErrorMessage(err)
+					h.handleFunctionResult(domainllm.ActionResponse{
+						Action: domainllm.ActionTypeReqLLM,
+						Result: "没有权限执行该操作: " + err.Error(),
+					}, functionCallData, textIndex)
 				} else {
-					resultStr := fmt.Sprintf("%v", result)
-					if len(resultStr) > 20 {
-						resultStr = resultStr[:20] + "..."
+					result, err := h.mcpManager.ExecuteTool(ctx, functionName, arguments)
+					if err != nil {
+						h.LogError(fmt.Sprintf("MCP函数调用失败: %v", err))
+						if result == nil {
+							result = "MCP工具调用失败"
+						}
 					}
-					h.LogInfo(fmt.Sprintf("MCP函数调用结果: %s", resultStr))
-					actionResult := domainllm.ActionResponse{
-						Action: domainllm.ActionTypeReqLLM, // 动作类型
-						Result: result,                     // 动作产生的结果
+					// 判断result 是否是domainllm.ActionResponse类型
+					if actionResult, ok := result.(domainllm.ActionResponse); ok {
+						h.handleFunctionResult(actionResult, functionCallData, textIndex)
+					} else {
+						resultStr := fmt.Sprintf("%v", result)
+						if len(resultStr) > 20 {
+							resultStr = resultStr[:20] + "..."
+						}
+						h.LogInfo(fmt.Sprintf("MCP函数调用结果: %s", resultStr))
+						actionResult := domainllm.ActionResponse{
+							Action: domainllm.ActionTypeReqLLM, // 动作类型
+							Result: result,                     // 动作产生的结果
+						}
+						h.handleFunctionResult(actionResult, functionCallData, textIndex)
 					}
-					h.handleFunctionResult(actionResult, functionCallData, textIndex)
 				}
 
 			} else {
@@ -1127,17 +1332,15 @@ func (h *ConnectionHandler) genResponseByLLM(ctx context.Context, messages []pro
 		}
 	}
 
+	h.turnLatency.mark(round, markLLMComplete, true)
+
 	// 处理剩余文本
-	fullResponse := internalutils.JoinStrings(responseMessage)
-	if len(fullResponse) > processedChars {
-		remainingText := fullResponse[processedChars:]
-		if remainingText != "" {
-			textIndex++
-			h.tts_last_text_index = textIndex
-			h.SpeakAndPlay(remainingText, textIndex, round)
-		}
+	if remainingText := segments.Flush(); remainingText != "" {
+		textIndex++
+		h.tts_last_text_index = textIndex
+		h.SpeakAndPlay(remainingText, textIndex, round)
 	} else {
-		h.logger.Debug("无剩余文本需要处理: fullResponse长度=%d, processedChars=%d", len(fullResponse), processedChars)
+		h.logger.Debug("无剩余文本需要处理: 已输出分段数=%d", textIndex)
 	}
 
 	// 分析回复并发送相应的情绪
@@ -1147,7 +1350,7 @@ func (h *ConnectionHandler) genResponseByLLM(ctx context.Context, messages []pro
 
 	// 添加助手回复到对话历史
 	if !toolCallFlag {
-		h.dialogueManager.Put(chat.Message{
+		h.putMessage(chat.Message{
 			Role:    "assistant",
 			Content: cleanContent, // 使用清理后的内容
 		})
@@ -1178,6 +1381,7 @@ func (h *ConnectionHandler) handleWakeUpMessage(ctx context.Context, text string
 	// 增加对话轮次
 	h.talkRound++
 	h.roundStartTime = time.Now()
+	h.beginTurnLatency(h.talkRound)
 	currentRound := h.talkRound
 	h.LogInfo(fmt.Sprintf("[对话] [轮次 %d] 唤醒响应", currentRound))
 
@@ -1201,7 +1405,7 @@ func (h *ConnectionHandler) handleWakeUpMessage(ctx context.Context, text string
 	}
 
 	// 添加用户消息到对话历史
-	h.dialogueManager.Put(chat.Message{
+	h.putMessage(chat.Message{
 		Role:    "user",
 		Content: text,
 	})
@@ -1231,7 +1435,7 @@ func (h *ConnectionHandler) handleWakeUpMessage(ctx context.Context, text string
 	}
 
 	// 添加助手回复到对话历史
-	h.dialogueManager.Put(chat.Message{
+	h.putMessage(chat.Message{
 		Role:    "assistant",
 		Content: internalutils.RemoveAllEmoji(responseText), // 移除表情符号
 	})
@@ -1297,7 +1501,7 @@ func (h *ConnectionHandler) addToolCallMessage(toolResultText string, functionCa
 	h.LogInfo(fmt.Sprintf("函数调用ID: %s", functionID))
 
 	// 添加 assistant 消息，包含 tool_calls
-	h.dialogueManager.Put(chat.Message{
+	h.putMessage(chat.Message{
 		Role: "assistant",
 		ToolCalls: []domainllminter.ToolCall{{
 			ID: functionID,
@@ -1314,7 +1518,7 @@ func (h *ConnectionHandler) addToolCallMessage(toolResultText string, functionCa
 	if toolCallID == "" {
 		toolCallID = uuid.New().String()
 	}
-	h.dialogueManager.Put(chat.Message{
+	h.putMessage(chat.Message{
 		Role:       "tool",
 		ToolCallID: toolCallID,
 		Content:    toolResultText,
@@ -1344,7 +1548,7 @@ func (h *ConnectionHandler) handleFunctionResult(result domainllm.ActionResponse
 		text, ok := result.Result.(string)
 		if ok && len(text) > 0 {
 			h.addToolCallMessage(text, functionCallData)
-			h.genResponseByLLM(context.Background(), h.dialogueManager.GetLLMDialogue(), h.talkRound)
+			h.genResponseByLLM(h.tenantContext(), h.dialogueManager.GetLLMDialogue(), h.talkRound)
 
 		} else {
 			h.LogError(fmt.Sprintf("函数调用结果解析失败: %v", result.Result))
@@ -1395,6 +1599,7 @@ func (h *ConnectionHandler) processTTSQueueCoroutine() {
 func (h *ConnectionHandler) stopServerSpeak() {
 	h.LogInfo("[服务端] [语音] 停止说话")
 	atomic.StoreInt32(&h.serverVoiceStop, 1)
+	h.cancelSpeech()
 	h.cleanTTSAndAudioQueue(false)
 }
 
@@ -1455,6 +1660,12 @@ func (h *ConnectionHandler) processTTSTask(text string, textIndex int, round int
 		hasAudio = true
 		return
 	}
+	// 打断后仍在队列中的分段不再合成
+	if atomic.LoadInt32(&h.serverVoiceStop) == 1 || round < h.talkRound {
+		h.logger.DebugTag("TTS", "跳过已停止或过期轮次的分段，轮次=%d，索引=%d", round, textIndex)
+		return
+	}
+	speechCtx := h.speechContext()
 
 	ttsStartTime := time.Now()
 	// 过滤表情
@@ -1471,6 +1682,7 @@ func (h *ConnectionHandler) processTTSTask(text string, textIndex int, round int
 		h.logger.DebugTag("TTS", "收到空文本，索引=%d", textIndex)
 		return
 	}
+	h.turnLatency.mark(round, markTTSSubmit, false)
 
 	text = cleanText
 	logText := internalutils.SanitizeForLog(text)
@@ -1482,6 +1694,9 @@ func (h *ConnectionHandler) processTTSTask(text string, textIndex int, round int
 	if h.registry != nil {
 		// 获取配置的TTS提供者
 		ttsProviderName := h.config.Selected.TTS
+		if name := h.offlineTTSName(); name != "" {
+			ttsProviderName = name
+		}
 		if ttsProviderName == "" {
 			ttsProviderName = "builtin_tts"
 		}
@@ -1500,6 +1715,15 @@ func (h *ConnectionHandler) processTTSTask(text string, textIndex int, round int
 					config["api_key"] = ttsConfig.Token // Map Token to api_key
 					config["cluster"] = ttsConfig.Cluster
 					config["voice"] = ttsConfig.Voice
+					if voice := h.speakerVoice(); voice != "" {
+						config["voice"] = voice
+					}
+					if voice := h.languageVoice(); voice != "" {
+						config["voice"] = voice
+					}
+					if voice := h.translationVoice(round); voice != "" {
+						config["voice"] = voice
+					}
 					// 如果需要更多字段，可能需要扩展TTSConfig或使用Extra字段
 				}
 			}
@@ -1513,11 +1737,16 @@ func (h *ConnectionHandler) processTTSTask(text string, textIndex int, round int
 				"text": text,
 			}
 
-			outputs, execErr := executor.Execute(context.Background(), config, inputs)
+			// 设备实时播报，优先于API和批处理任务调度
+			ttsCtx := capability.WithPriority(h.withTurnTraces(speechCtx, round), capability.PriorityInteractive)
+			outputs, execErr := executor.Execute(ttsCtx, config, inputs)
 			if execErr == nil {
 				if path, ok := outputs["file_path"].(string); ok {
 					generatedFile = path
 				}
+			} else if speechCtx.Err() != nil {
+				h.LogInfo(fmt.Sprintf("processTTSTask 合成已取消: %s", logText))
+				return
 			} else {
 				h.LogError(fmt.Sprintf("插件TTS执行失败(%s): %v，回退到旧版管理器", ttsProviderName, execErr))
 			}
@@ -1535,6 +1764,7 @@ func (h *ConnectionHandler) processTTSTask(text string, textIndex int, round int
 
 	filepath = generatedFile
 	hasAudio = true
+	h.turnLatency.mark(round, markTTSFirstByte, false)
 	h.logger.DebugTag("TTS", "转换成功 text=%s index=%d 文件=%s", logText, textIndex, filepath)
 
 	// 发布TTS完成事件
@@ -1569,8 +1799,15 @@ func (h *ConnectionHandler) SpeakAndPlay(text string, textIndex int, round int)
 	//     publisher.PublishTTSSpeak(text, textIndex, round)
 	// }
 
+	// 文本对话轮次的回复直接交给调用方，不合成语音
+	if turn := h.textChatTurn(round); turn != nil {
+		turn.emit(h.moderateOutput(text, round))
+		return nil
+	}
+
 	// 暂停将客户端音频发送到ASR（避免TTS播放期间触发ASR导致服务端sequence冲突）
 	atomic.StoreInt32(&h.asrPause, 1)
+	h.setDialogueState(chat.StateSpeaking, "tts")
 
 	defer func() {
 		// 在函数返回时不立即恢复；实际恢复在音频发送完成的地方处理（sendAudioMessage defer）
@@ -1588,9 +1825,11 @@ func (h *ConnectionHandler) SpeakAndPlay(text string, textIndex int, round int)
 		}
 	}()
 
+	// 输出审核在TTS之前执行，被拦截的分段替换为拦截回复或置空
+	text = h.moderateOutput(text, round)
+
 	originText := text // 保存原始文本用于日志
-	text = internalutils.RemoveAllEmoji(text)
-	text = internalutils.RemoveMarkdownSyntax(text) // 移除Markdown语法
+	text = h.speechText.Process(text) // 去掉Markdown和表情，数字、单位等转为读法
 	if text == "" {
 		// 如果清理后的文本为空，可能是纯表情符号或纯Markdown，跳过语音合成
 		h.logger.Debug("SpeakAndPlay 跳过空文本分段，原始文本: %s, 索引: %d", internalutils.SanitizeForLog(originText), textIndex)
@@ -1613,6 +1852,7 @@ func (h *ConnectionHandler) SpeakAndPlay(text string, textIndex int, round int)
 
 func (h *ConnectionHandler) clearSpeakStatus() {
 	h.LogInfo("[服务端] [讲话状态] 已清除")
+	h.finishTurnLatency()
 	h.tts_last_text_index = -1
 	h.tts_last_audio_index = -1
 	atomic.StoreInt32(&h.ttsPending, 0)
@@ -1628,6 +1868,7 @@ func (h *ConnectionHandler) clearSpeakStatus() {
 	// 恢复ASR接收，避免打断后无法重新启动ASR
 	atomic.StoreInt32(&h.asrPause, 0)
 	h.closeAfterChat = false
+	h.setDialogueState(chat.StateListening, "speak_finished")
 }
 
 func (h *ConnectionHandler) closeOpusDecoder() {
@@ -1637,6 +1878,9 @@ func (h *ConnectionHandler) closeOpusDecoder() {
 		}
 		h.opusDecoder = nil
 	}
+	if h.audioProcessor != nil {
+		h.audioProcessor.Close()
+	}
 }
 
 func (h *ConnectionHandler) cleanTTSAndAudioQueue(bClose bool) error {
@@ -1679,6 +1923,7 @@ func (h *ConnectionHandler) Close() {
 		close(h.stopChan)
 
 		h.closeOpusDecoder()
+		h.restoreOnlineASR()
 		if h.providers.tts != nil {
 			h.providers.tts.SetVoice(h.initialVoice) // 恢复初始语音
 		}
@@ -1694,6 +1939,12 @@ func (h *ConnectionHandler) Close() {
 		h.cleanTTSAndAudioQueue(true)
 		// 确保解除ASR暂停标志，避免遗留状态
 		atomic.StoreInt32(&h.asrPause, 0)
+		h.cancelRound()
+		h.cancelSpeech()
+		h.finishTurnLatency()
+		h.endTranslation()
+		h.endMedia()
+		h.setDialogueState(chat.StateIdle, "closed")
 	})
 }
 
@@ -1722,7 +1973,7 @@ func (h *ConnectionHandler) genResponseByVLLM(ctx context.Context, messages []pr
 
 	// 处理VLLLM流式回复
 	var responseMessage []string
-	processedChars := 0
+	segments := h.newSentenceStream()
 	textIndex := 0
 
 	atomic.StoreInt32(&h.serverVoiceStop, 0)
@@ -1731,24 +1982,25 @@ func (h *ConnectionHandler) genResponseByVLLM(ctx context.Context, messages []pr
 		if response == "" {
 			continue
 		}
+		if ctx.Err() != nil {
+			h.LogInfo(fmt.Sprintf("[对话] [轮次 %d] 已被打断，停止处理VLLLM输出", round))
+			go func() {
+				for range responses {
+				}
+			}()
+			return nil
+		}
 
 		responseMessage = append(responseMessage, response)
-		// 处理分段
-		fullText := internalutils.JoinStrings(responseMessage)
-		currentText := fullText[processedChars:]
-
-		// 按标点符号分割
-		if segment, chars := internalutils.SplitAtLastPunctuation(currentText); chars > 0 {
+		for _, segment := range segments.Write(response) {
 			textIndex++
 			h.tts_last_text_index = textIndex
 			h.SpeakAndPlay(segment, textIndex, round)
-			processedChars += chars
 		}
 	}
 
 	// 处理剩余文本
-	remainingText := internalutils.JoinStrings(responseMessage)[processedChars:]
-	if remainingText != "" {
+	if remainingText := segments.Flush(); remainingText != "" {
 		textIndex++
 		h.tts_last_text_index = textIndex
 		h.SpeakAndPlay(remainingText, textIndex, round)
@@ -1760,7 +2012,7 @@ func (h *ConnectionHandler) genResponseByVLLM(ctx context.Context, messages []pr
 	cleanContent := internalutils.RemoveAllEmoji(content)
 
 	// 添加VLLLM回复到对话历史
-	h.dialogueManager.Put(chat.Message{
+	h.putMessage(chat.Message{
 		Role:    "assistant",
 		Content: cleanContent, // 使用清理后的内容
 	})
@@ -1781,16 +2033,10 @@ func (h *ConnectionHandler) initManagers(config *config.Config) {
 	// 初始化 LLM Manager
 	if llmName != "" {
 		if llmCfg, ok := config.LLM[llmName]; ok {
-			llmConfig := domainllminter.LLMConfig{
-				Provider:    llmCfg.Type,
-				Model:       llmCfg.ModelName,
-				APIKey:      llmCfg.APIKey,
-				BaseURL:     llmCfg.BaseURL,
-				Temperature: float32(llmCfg.Temperature),
-				MaxTokens:   llmCfg.MaxTokens,
-				Timeout:     60, // 默认超时时间
+			h.llmManager = domainllm.NewManager(llmManagerConfig(llmCfg))
+			if h.registry != nil {
+				h.llmManager.SetLimiter(h.registry.Limiter())
 			}
-			h.llmManager = domainllm.NewManager(llmConfig)
 			if h.userID != "" {
 				h.LogDebug(fmt.Sprintf("使用用户 %s 的LLM提供者: %s (%s)", h.userID, llmName, llmCfg.Type))
 			} else {
@@ -1804,17 +2050,7 @@ func (h *ConnectionHandler) initManagers(config *config.Config) {
 	// 初始化 TTS Manager
 	if ttsName != "" {
 		if ttsCfg, ok := config.TTS[ttsName]; ok {
-			ttsConfig := domainttsinter.TTSConfig{
-				Provider:   ttsCfg.Type,
-				Voice:      ttsCfg.Voice,
-				Speed:      1.0,   // 默认语速
-				Pitch:      1.0,   // 默认音调
-				Volume:     1.0,   // 默认音量
-				SampleRate: 24000, // 默认采样率
-				Format:     ttsCfg.Format,
-				Language:   "zh-CN", // 默认语言
-			}
-			h.ttsManager = domaintts.NewManager(ttsConfig, config)
+			h.ttsManager = domaintts.NewManager(ttsManagerConfig(ttsName, ttsCfg), config)
 			if h.userID != "" {
 				h.LogDebug(fmt.Sprintf("使用用户 %s 的TTS提供者: %s (%s)", h.userID, ttsName, ttsCfg.Type))
 			} else {
@@ -1835,6 +2071,34 @@ func (h *ConnectionHandler) initManagers(config *config.Config) {
 	}
 }
 
+// llmManagerConfig 由全局 LLM 配置生成 LLM Manager 配置
+func llmManagerConfig(llmCfg config.LLMConfig) domainllminter.LLMConfig {
+	return domainllminter.LLMConfig{
+		Provider:    llmCfg.Type,
+		Model:       llmCfg.ModelName,
+		APIKey:      llmCfg.APIKey,
+		BaseURL:     llmCfg.BaseURL,
+		Temperature: float32(llmCfg.Temperature),
+		MaxTokens:   llmCfg.MaxTokens,
+		Timeout:     60, // 默认超时时间
+	}
+}
+
+// ttsManagerConfig 由全局 TTS 配置生成 TTS Manager 配置
+func ttsManagerConfig(ttsName string, ttsCfg config.TTSConfig) domainttsinter.TTSConfig {
+	return domainttsinter.TTSConfig{
+		Name:       ttsName,
+		Provider:   ttsCfg.Type,
+		Voice:      ttsCfg.Voice,
+		Speed:      1.0,   // 默认语速
+		Pitch:      1.0,   // 默认音调
+		Volume:     1.0,   // 默认音量
+		SampleRate: 24000, // 默认采样率
+		Format:     ttsCfg.Format,
+		Language:   "zh-CN", // 默认语言
+	}
+}
+
 // GetDeviceID 获取设备ID
 func (h *ConnectionHandler) GetDeviceID() string {
 	return h.deviceID
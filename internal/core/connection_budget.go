@@ -0,0 +1,59 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"xiaozhi-server-go/internal/domain/budget"
+	domainllminter "xiaozhi-server-go/internal/domain/llm/inter"
+	providers "xiaozhi-server-go/internal/domain/providers/types"
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+// initBudget 初始化本次连接的Token预算计量，预算服务未启用时不检查
+func (h *ConnectionHandler) initBudget() {
+	svc := budget.Default()
+	if svc == nil || !svc.Enabled() {
+		return
+	}
+	h.budget = svc.NewSession(h.deviceID, h.sessionID)
+}
+
+// estimatePromptTokens 估算本次调用提示词的Token数
+func estimatePromptTokens(messages []providers.Message) int64 {
+	var tokens int64
+	for _, msg := range messages {
+		tokens += capability.EstimateTokens(msg.Content)
+		for _, tc := range msg.ToolCalls {
+			tokens += capability.EstimateTokens(tc.Function.Name + tc.Function.Arguments)
+		}
+	}
+	return tokens
+}
+
+// checkBudget 在调用LLM之前检查预算，超出时返回需要播报的提示
+func (h *ConnectionHandler) checkBudget(ctx context.Context, round int, estimate int64) (string, bool) {
+	if h.budget == nil {
+		return "", true
+	}
+	err := h.budget.Check(ctx, round, estimate)
+	var exceeded *budget.ExceededError
+	if errors.As(err, &exceeded) {
+		h.LogWarn(fmt.Sprintf("[预算] [轮次 %d] 超出%s Token预算 (%d/%d)，不调用LLM", round, exceeded.Scope, exceeded.Used, exceeded.Limit))
+		return exceeded.Reply, false
+	}
+	return "", true
+}
+
+// recordBudget 记录一次LLM调用的用量，提供者未返回用量时按提示词和回复文本估算
+func (h *ConnectionHandler) recordBudget(round int, promptEstimate int64, usage *domainllminter.Usage, completion string) {
+	if h.budget == nil {
+		return
+	}
+	tokens := promptEstimate + capability.EstimateTokens(completion)
+	if usage != nil && usage.TotalTokens > 0 {
+		tokens = int64(usage.TotalTokens)
+	}
+	h.budget.Record(round, tokens)
+}
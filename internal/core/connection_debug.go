@@ -0,0 +1,123 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/domain/devicedebug"
+	"xiaozhi-server-go/internal/domain/flags"
+	"xiaozhi-server-go/internal/domain/protocol"
+)
+
+// debugReply 设备对远程调试请求的回复
+type debugReply struct {
+	result json.RawMessage
+	err    error
+}
+
+// debugCalls 等待设备回复的远程调试请求，按请求ID索引
+type debugCalls struct {
+	mu      sync.Mutex
+	pending map[string]chan debugReply
+}
+
+func (c *debugCalls) add(id string) chan debugReply {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.pending == nil {
+		c.pending = make(map[string]chan debugReply)
+	}
+	ch := make(chan debugReply, 1)
+	c.pending[id] = ch
+	return ch
+}
+
+func (c *debugCalls) remove(id string) {
+	c.mu.Lock()
+	delete(c.pending, id)
+	c.mu.Unlock()
+}
+
+// resolve 把回复交给等待中的请求，请求已超时或不存在时返回 false
+func (c *debugCalls) resolve(id string, reply debugReply) bool {
+	c.mu.Lock()
+	ch, ok := c.pending[id]
+	delete(c.pending, id)
+	c.mu.Unlock()
+	if ok {
+		ch <- reply
+	}
+	return ok
+}
+
+// DeviceDebugChannel 经设备在线连接收发远程调试消息，实现 devicedebug.Channel
+type DeviceDebugChannel struct{}
+
+// NewDeviceDebugChannel 创建远程调试通道
+func NewDeviceDebugChannel() *DeviceDebugChannel {
+	return &DeviceDebugChannel{}
+}
+
+// Call 向设备发送调试请求并等待回复；设备需在线、声明了 debug 特性并通过 remote_debug 开关允许
+func (c *DeviceDebugChannel) Call(ctx context.Context, deviceID string, action devicedebug.Action, params map[string]interface{}) (string, json.RawMessage, error) {
+	h, ok := FindActiveHandler(deviceID)
+	if !ok {
+		return "", nil, devicedebug.ErrDeviceOffline
+	}
+	if !h.flagEnabled(flags.RemoteDebug) {
+		return h.sessionID, nil, devicedebug.ErrNotPermitted
+	}
+	if !h.protocolSession.HasFeature(protocol.FeatureDebug) {
+		return h.sessionID, nil, devicedebug.ErrUnsupported
+	}
+	result, err := h.callDebug(ctx, action, params)
+	return h.sessionID, result, err
+}
+
+// callDebug 发送调试请求并等待设备回复，连接关闭时返回 ErrDeviceOffline
+func (h *ConnectionHandler) callDebug(ctx context.Context, action devicedebug.Action, params map[string]interface{}) (json.RawMessage, error) {
+	id := uuid.New().String()
+	replies := h.debugCalls.add(id)
+	defer h.debugCalls.remove(id)
+
+	if err := h.responseSender.SendDebugRequest(id, string(action), params); err != nil {
+		return nil, fmt.Errorf("发送调试请求失败: %w", err)
+	}
+	h.LogInfo(fmt.Sprintf("[远程调试] 已发送 %s 请求 %s", action, id))
+
+	select {
+	case reply := <-replies:
+		return reply.result, reply.err
+	case <-h.stopChan:
+		return nil, devicedebug.ErrDeviceOffline
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// handleDebugMessage 处理设备对调试请求的回复
+// 设备以 {"type": "debug", "id": 请求ID, "result": ...} 返回结果，失败时以 "error" 返回原因
+func (h *ConnectionHandler) handleDebugMessage(msgMap map[string]interface{}) error {
+	id, _ := msgMap["id"].(string)
+	if id == "" {
+		return fmt.Errorf("debug消息缺少id")
+	}
+	var reply debugReply
+	if message, _ := msgMap["error"].(string); message != "" {
+		reply.err = &devicedebug.DeviceError{Message: message}
+	} else if result, ok := msgMap["result"]; ok {
+		data, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("debug消息的result无效: %v", err)
+		}
+		reply.result = data
+	}
+	if !h.debugCalls.resolve(id, reply) {
+		h.LogWarn(fmt.Sprintf("[远程调试] 忽略已超时或未知的回复 %s", id))
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"xiaozhi-server-go/internal/domain/chat"
+	"xiaozhi-server-go/internal/domain/flags"
+	internalutils "xiaozhi-server-go/internal/utils"
+)
+
+// setDialogueState 迁移对话状态，状态机未初始化时忽略；会话被旁听时发布状态变化
+func (h *ConnectionHandler) setDialogueState(to chat.DialogueState, reason string) {
+	if h.dialogueState == nil {
+		return
+	}
+	if h.dialogueState.Transition(to, h.talkRound, reason) {
+		h.publishMonitorState(to)
+	}
+}
+
+// bargeInEnabled 是否允许用户打断服务端回复
+func (h *ConnectionHandler) bargeInEnabled() bool {
+	return h.dialogueState != nil && h.config != nil && h.config.Dialogue.BargeInEnabled
+}
+
+// shouldBargeIn 识别结果是否足以触发打断，过滤回声和零星噪声
+func (h *ConnectionHandler) shouldBargeIn(text string) bool {
+	cleaned := internalutils.RemoveAllPunctuation(text)
+	minChars := h.config.Dialogue.BargeInMinChars
+	if minChars <= 0 {
+		minChars = 1
+	}
+	return utf8.RuneCountInString(cleaned) >= minChars
+}
+
+// beginRound 为新一轮对话创建可取消的上下文
+func (h *ConnectionHandler) beginRound() context.Context {
+	parent := h.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parent)
+
+	h.roundMu.Lock()
+	h.roundCancel = cancel
+	h.roundMu.Unlock()
+	return ctx
+}
+
+// endRound 结束当前轮次并释放上下文
+func (h *ConnectionHandler) endRound() {
+	h.cancelRound()
+}
+
+// cancelRound 取消当前轮次中尚未完成的LLM生成
+func (h *ConnectionHandler) cancelRound() {
+	h.roundMu.Lock()
+	cancel := h.roundCancel
+	h.roundCancel = nil
+	h.roundMu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// speechContext 返回语音合成使用的上下文，服务端停止说话（打断、中止）时取消，之后的合成使用新的上下文
+func (h *ConnectionHandler) speechContext() context.Context {
+	h.roundMu.Lock()
+	defer h.roundMu.Unlock()
+	if h.speechCtx == nil {
+		h.speechCtx, h.speechCancel = context.WithCancel(h.tenantContext())
+	}
+	return h.speechCtx
+}
+
+// cancelSpeech 取消进行中的语音合成
+func (h *ConnectionHandler) cancelSpeech() {
+	h.roundMu.Lock()
+	cancel := h.speechCancel
+	h.speechCtx, h.speechCancel = nil, nil
+	h.roundMu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// newSentenceStream 按对话配置创建回复分段器，LLM输出的完整分段边生成边送入TTS；
+// 设备关闭 streaming_tts 开关时首段也按整句切分
+func (h *ConnectionHandler) newSentenceStream() *internalutils.SentenceStream {
+	firstMinChars := h.config.Dialogue.FirstSegmentMinChars
+	if !h.flagEnabled(flags.StreamingTTS) {
+		firstMinChars = 0
+	}
+	return internalutils.NewSentenceStream(firstMinChars, h.config.Dialogue.MaxSegmentChars)
+}
+
+// bargeIn 用户在服务端思考或说话时开口：停止播放、取消LLM生成并通知客户端
+func (h *ConnectionHandler) bargeIn(text string) {
+	h.LogInfo(fmt.Sprintf("[对话] [打断] 轮次 %d 状态 %s，用户插话: %s",
+		h.talkRound, h.dialogueState.State(), internalutils.SanitizeForLog(text)))
+
+	h.stopServerSpeak()
+	h.cancelRound()
+	if err := h.sendTTSMessage("stop", "", 0); err != nil {
+		h.LogWarn(fmt.Sprintf("[对话] [打断] 发送TTS停止消息失败: %v", err))
+	}
+	h.dialogueState.BargeIn(h.talkRound, text)
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/experiment"
+	domainllminter "xiaozhi-server-go/internal/domain/llm/inter"
+	domainprompt "xiaozhi-server-go/internal/domain/prompt"
+	"xiaozhi-server-go/internal/platform/config"
+)
+
+// applyExperiment 为设备分配A/B实验分组，并按分组覆盖LLM提供者和系统提示词
+// 返回最终使用的系统提示词，未参与实验时原样返回
+func (h *ConnectionHandler) applyExperiment(config *config.Config, prompt string) string {
+	svc := experiment.Default()
+	if svc == nil || h.deviceID == "" {
+		return prompt
+	}
+	assignment, ok := svc.Assign(context.Background(), h.deviceID)
+	if !ok {
+		return prompt
+	}
+	h.experiment = assignment
+	variant := assignment.Variant
+	h.LogInfo(fmt.Sprintf("[实验] 设备进入实验 %s 分组 %s", assignment.ExperimentID, variant.Name))
+
+	if variant.LLM != "" && h.switchLLMProvider(config, variant.LLM) {
+		h.LogInfo(fmt.Sprintf("[实验] 使用实验分组的LLM提供者: %s", variant.LLM))
+	}
+
+	if variant.PromptTemplate != "" {
+		if promptSvc := domainprompt.Default(); promptSvc != nil {
+			rendered, _, err := promptSvc.RenderTemplate(context.Background(), variant.PromptTemplate, variant.PromptVersion, h.promptVariables())
+			if err != nil {
+				h.LogWarn(fmt.Sprintf("[实验] 渲染分组提示词模板 %s 失败，使用原提示词: %v", variant.PromptTemplate, err))
+			} else {
+				prompt = rendered
+			}
+		}
+	}
+	return prompt
+}
+
+// recordExperimentOutcome 记录一轮LLM回复的实验结果，异步写入避免阻塞对话
+func (h *ConnectionHandler) recordExperimentOutcome(round int, latency time.Duration, usage *domainllminter.Usage, interrupted bool) {
+	svc := experiment.Default()
+	if svc == nil || h.experiment == nil {
+		return
+	}
+	outcome := &experiment.Outcome{
+		ExperimentID: h.experiment.ExperimentID,
+		Variant:      h.experiment.Variant.Name,
+		DeviceID:     h.deviceID,
+		SessionID:    h.sessionID,
+		Round:        round,
+		LatencyMs:    latency.Milliseconds(),
+		Interrupted:  interrupted,
+	}
+	if usage != nil {
+		outcome.PromptTokens = usage.PromptTokens
+		outcome.CompletionTokens = usage.CompletionTokens
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := svc.RecordOutcome(ctx, outcome); err != nil {
+			h.LogWarn(fmt.Sprintf("[实验] 记录实验结果失败: %v", err))
+		}
+	}()
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/transcript"
+)
+
+// RecordVoiceFeedback 实现 intent.FeedbackRecorder
+// 反馈语句本身开启了新的轮次，因此关联到上一轮回复
+func (h *ConnectionHandler) RecordVoiceFeedback(ctx context.Context, positive bool, text string) error {
+	rating := transcript.RatingDown
+	if positive {
+		rating = transcript.RatingUp
+	}
+	return h.submitFeedback(ctx, h.talkRound-1, rating, text, transcript.FeedbackSourceVoice)
+}
+
+// handleFeedbackMessage 处理设备上报的反馈消息
+// 格式: {"type":"feedback","rating":"up|down","text":"...","round":3}，round 缺省为最近一轮
+func (h *ConnectionHandler) handleFeedbackMessage(msgMap map[string]interface{}) error {
+	ratingText, _ := msgMap["rating"].(string)
+	rating, ok := transcript.ParseRating(ratingText)
+	if !ok {
+		return fmt.Errorf("feedback消息的rating无效: %s", ratingText)
+	}
+	comment, _ := msgMap["text"].(string)
+	round := h.talkRound
+	if r, ok := msgMap["round"].(float64); ok && r > 0 {
+		round = int(r)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return h.submitFeedback(ctx, round, rating, comment, transcript.FeedbackSourceDevice)
+}
+
+// submitFeedback 将反馈写入对话记录服务，对话记录未启用时忽略
+func (h *ConnectionHandler) submitFeedback(ctx context.Context, round int, rating transcript.Rating, comment string, source transcript.FeedbackSource) error {
+	svc := transcript.Default()
+	if svc == nil {
+		h.LogDebug("[反馈] 对话记录未启用，忽略反馈")
+		return nil
+	}
+	if round <= 0 {
+		return fmt.Errorf("当前会话还没有可反馈的回复")
+	}
+	if _, err := svc.SubmitFeedback(ctx, transcript.FeedbackRequest{
+		SessionID: h.sessionID,
+		DeviceID:  h.deviceID,
+		Round:     round,
+		Rating:    rating,
+		Comment:   comment,
+		Source:    source,
+	}); err != nil {
+		return err
+	}
+	h.LogInfo(fmt.Sprintf("[反馈] [轮次 %d] 收到%s反馈: %s", round, source, rating))
+	return nil
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"sync/atomic"
+
+	"xiaozhi-server-go/internal/domain/protocol"
+)
+
+// controlFrameWriter 连接支持发送不会被丢弃的二进制控制帧（ws.Connection）
+type controlFrameWriter interface {
+	WriteControlFrame(data []byte) error
+}
+
+// framedConnection 协商 protobuf 帧格式后对收发的消息编解码，协商前与原连接行为一致
+type framedConnection struct {
+	Connection
+	protobuf atomic.Bool
+}
+
+func newFramedConnection(conn Connection) *framedConnection {
+	return &framedConnection{Connection: conn}
+}
+
+// setFraming 切换帧格式，应在发送 hello 回复之后调用
+func (c *framedConnection) setFraming(framing string) {
+	c.protobuf.Store(framing == protocol.FramingProtobuf)
+}
+
+// WriteMessage 发送文本或音频消息，protobuf 模式下编码为二进制帧
+func (c *framedConnection) WriteMessage(messageType int, data []byte) error {
+	if !c.protobuf.Load() || (messageType != protocol.MessageText && messageType != protocol.MessageBinary) {
+		return c.Connection.WriteMessage(messageType, data)
+	}
+	frame, err := protocol.EncodeFrame(messageType, data)
+	if err != nil {
+		return err
+	}
+	// 控制消息编码后也是二进制帧，不能按音频的丢弃策略处理
+	if writer, ok := c.Connection.(controlFrameWriter); ok && messageType == protocol.MessageText {
+		return writer.WriteControlFrame(frame)
+	}
+	return c.Connection.WriteMessage(protocol.MessageBinary, frame)
+}
+
+// decode protobuf 模式下把收到的二进制帧还原为文本或音频消息，其它情况原样返回
+func (c *framedConnection) decode(messageType int, data []byte, sessionID string) (int, []byte, error) {
+	if messageType != protocol.MessageBinary || !c.protobuf.Load() {
+		return messageType, data, nil
+	}
+	return protocol.DecodeFrame(data, sessionID)
+}
@@ -4,14 +4,28 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	domainimage "xiaozhi-server-go/internal/domain/image"
 	"xiaozhi-server-go/internal/domain/chat"
+	"xiaozhi-server-go/internal/domain/flags"
+	"xiaozhi-server-go/internal/domain/protocol"
 	providers "xiaozhi-server-go/internal/domain/providers/types"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/plugin/capability"
+
+	"github.com/gorilla/websocket"
 )
 
 // handleMessage 处理接收到的消息
 func (h *ConnectionHandler) handleMessage(messageType int, message []byte) error {
+	if h.framedConn != nil {
+		var err error
+		messageType, message, err = h.framedConn.decode(messageType, message, h.sessionID)
+		if err != nil {
+			return err
+		}
+	}
 	switch messageType {
 	case 1: // 文本消息
 		h.clientTextQueue <- string(message)
@@ -59,7 +73,9 @@ func (h *ConnectionHandler) processClientTextMessage(ctx context.Context, text s
 
 	switch msgType {
 	case "hello":
-		return h.handleHelloMessage(msgMap)
+		return h.handleHelloMessage([]byte(text), msgMap)
+	case "ping": // 心跳
+		return h.responseSender.SendPong()
 	case "abort":
 		return h.clientAbortChat()
 	case "listen":
@@ -67,13 +83,18 @@ func (h *ConnectionHandler) processClientTextMessage(ctx context.Context, text s
 	case "iot":
 		return h.handleIotMessage(msgMap)
 	case "chat":
-		return h.conversationLoop.HandleChatMessage(ctx, text)
+		h.applyOfflineMode()
+		return h.conversationLoop.HandleChatMessage(capability.WithPriority(ctx, capability.PriorityInteractive), text)
 	case "vision":
 		return h.handleVisionMessage(msgMap)
 	case "image":
 		return h.handleImageMessage(ctx, msgMap)
 	case "mcp":
 		return h.mcpManager.HandleXiaoZhiMCPMessage(msgMap)
+	case "feedback":
+		return h.handleFeedbackMessage(msgMap)
+	case "debug":
+		return h.handleDebugMessage(msgMap)
 	default:
 		h.logger.Warn(
 			"=== 未知消息类型 ===: unknown_type=%s full_message=%v",
@@ -95,39 +116,91 @@ func (h *ConnectionHandler) handleVisionMessage(msgMap map[string]interface{}) e
 }
 
 // handleHelloMessage 处理欢迎消息
-// 客户端会上传语音格式和采样率等信息
-func (h *ConnectionHandler) handleHelloMessage(msgMap map[string]interface{}) error {
+// 客户端上传协议版本、音频编码、音频参数和支持的特性，协商失败时回复错误码并断开连接
+func (h *ConnectionHandler) handleHelloMessage(data []byte, msgMap map[string]interface{}) error {
 	h.logger.InfoTag("客户端", " 收到欢迎消息")
-	// 获取客户端编码格式
-	if audioParams, ok := msgMap["audio_params"].(map[string]interface{}); ok {
-		if format, ok := audioParams["format"].(string); ok {
-			h.clientAudioFormat = format
-			if format == "pcm" {
-				// 客户端使用PCM格式，服务端也使用PCM格式
-				h.serverAudioFormat = "pcm"
-			}
-		}
-		if sampleRate, ok := audioParams["sample_rate"].(float64); ok {
-			h.clientAudioSampleRate = int(sampleRate)
-		}
-		if channels, ok := audioParams["channels"].(float64); ok {
-			h.clientAudioChannels = int(channels)
-		}
-		if frameDuration, ok := audioParams["frame_duration"].(float64); ok {
-			h.clientAudioFrameDuration = int(frameDuration)
-		}
-		h.LogInfo(fmt.Sprintf("[客户端] [音频参数 %s/%d/%d/%d]",
-			h.clientAudioFormat, h.clientAudioSampleRate, h.clientAudioChannels, h.clientAudioFrameDuration))
+	fallbackVersion, _ := strconv.Atoi(h.headers["Protocol-Version"])
+	hello, perr := protocol.ParseHello(data, fallbackVersion)
+	if perr == nil {
+		h.protocolSession, perr = protocol.Negotiate(hello, h.outputAudioDefaults())
+	}
+	if perr != nil {
+		h.rejectHello(perr)
+		return perr
+	}
+
+	session := h.protocolSession
+	if session.Framing == protocol.FramingProtobuf && !h.flagEnabled(flags.BinaryProtocol) {
+		session.Framing = protocol.FramingJSON
+	}
+	h.clientAudioFormat = session.Codec
+	h.setServerAudio(session.Output)
+	if session.AudioParams.SampleRate > 0 {
+		h.clientAudioSampleRate = session.AudioParams.SampleRate
+	}
+	if session.AudioParams.Channels > 0 {
+		h.clientAudioChannels = session.AudioParams.Channels
+	}
+	if session.AudioParams.FrameDuration > 0 {
+		h.clientAudioFrameDuration = session.AudioParams.FrameDuration
+	}
+	h.LogInfo(fmt.Sprintf("[客户端] [协议 v%d/%s] [音频参数 %s/%d/%d/%d] [下行 %s/%d/%d/%dbps] [特性 %v]",
+		session.Version, session.Framing, h.clientAudioFormat, h.clientAudioSampleRate, h.clientAudioChannels, h.clientAudioFrameDuration,
+		h.serverAudioFormat, h.serverAudioSampleRate, h.serverAudioFrameDuration, h.serverAudioBitrate, session.Features))
+
+	if hint, ok := speakerHint(msgMap); ok {
+		h.identifySpeaker(hint)
 	}
 	h.sendHelloMessage()
-	
+	// hello 回复始终为 JSON，之后的消息使用协商的帧格式
+	h.framedConn.setFraming(session.Framing)
+
 	// Update AudioProcessor
 	h.audioProcessor.UpdateFormat(h.clientAudioFormat, h.clientAudioSampleRate, h.clientAudioChannels)
 	h.LogInfo("[AudioProcessor] Updated format")
+	h.initAudioPreprocess()
+	h.announceOfflineMode()
 
 	return nil
 }
 
+// outputAudioDefaults 配置的下行音频参数，未配置的字段使用 24kHz、60ms 帧
+func (h *ConnectionHandler) outputAudioDefaults() protocol.AudioParams {
+	params := protocol.AudioParams{Format: protocol.CodecOpus, SampleRate: 24000, Channels: 1, FrameDuration: 60}
+	if h.config == nil {
+		return params
+	}
+	output := h.config.Audio.Output
+	if output.SampleRate > 0 {
+		params.SampleRate = output.SampleRate
+	}
+	if output.FrameDuration > 0 {
+		params.FrameDuration = output.FrameDuration
+	}
+	params.Bitrate = output.Bitrate
+	return params
+}
+
+// setServerAudio 设置下行音频参数
+func (h *ConnectionHandler) setServerAudio(params protocol.AudioParams) {
+	h.serverAudioFormat = params.Format
+	h.serverAudioSampleRate = params.SampleRate
+	h.serverAudioChannels = 1
+	h.serverAudioFrameDuration = params.FrameDuration
+	h.serverAudioBitrate = params.Bitrate
+}
+
+// rejectHello 回复握手错误并关闭连接，设备可根据错误码提示升级固件或调整配置
+func (h *ConnectionHandler) rejectHello(perr *protocol.Error) {
+	h.logger.WarnTag("客户端", "握手失败，断开连接: device=%s code=%s %s", h.deviceID, perr.Code, perr.Message)
+	if err := h.responseSender.SendHelloError(perr.Code, perr.Message, platformerrors.Describe(perr), protocol.ServerSupported()); err != nil {
+		h.LogError(fmt.Sprintf("发送握手错误失败: %v", err))
+	}
+	closeFrame := websocket.FormatCloseMessage(websocket.CloseProtocolError, perr.Code)
+	_ = h.conn.WriteMessage(websocket.CloseMessage, closeFrame)
+	_ = h.conn.Close()
+}
+
 // handleListenMessage 处理语音相关消息
 func (h *ConnectionHandler) handleListenMessage(msgMap map[string]interface{}) error {
 
@@ -144,14 +217,22 @@ func (h *ConnectionHandler) handleListenMessage(msgMap map[string]interface{}) e
 		h.providers.asr.SetListener(h)
 	}
 
+	// 支持声纹识别的设备在消息中携带说话人的用户ID或称呼
+	if hint, ok := speakerHint(msgMap); ok {
+		h.identifySpeaker(hint)
+	}
+
 	switch state {
 	case "start":
+		h.applyOfflineMode()
 		h.closeAfterChat = false
 		if h.client_asr_text != "" && h.clientListenMode == "manual" {
 			h.clientAbortChat()
 		}
 		h.client_asr_text = ""
+		h.utterance.take() // 丢弃上一段未识别出结果的语音
 	case "stop":
+		h.turnLatency.markSpeechStop()
 		h.providers.asr.SendLastAudio([]byte{}) // 发送空数据标记结束
 		h.LogInfo("客户端停止语音识别")
 	case "detect":
@@ -160,7 +241,7 @@ func (h *ConnectionHandler) handleListenMessage(msgMap map[string]interface{}) e
 		if hasText && text != "" {
 			// 只有文本，使用普通LLM处理
 			h.LogInfo(fmt.Sprintf("[检测] [纯文本消息 %s] 使用LLM处理", text))
-			return h.handleChatMessage(context.Background(), text)
+			return h.handleChatMessage(h.tenantContext(), text)
 		} else {
 			// 既没有图片也没有文本
 			h.logger.Warn("detect消息既没有text也没有image参数")
@@ -255,7 +336,7 @@ func (h *ConnectionHandler) handleImageMessage(ctx context.Context, msgMap map[s
 
 	// 添加用户消息到对话历史（包含图片信息的描述）
 	userMessage := fmt.Sprintf("%s [用户发送了一张%s格式的图片]", text, imageData.Format)
-	h.dialogueManager.Put(chat.Message{
+	h.putMessage(chat.Message{
 		Role:    "user",
 		Content: userMessage,
 	})
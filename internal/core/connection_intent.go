@@ -0,0 +1,168 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/chat"
+	"xiaozhi-server-go/internal/domain/intent"
+	"xiaozhi-server-go/internal/domain/media"
+	"xiaozhi-server-go/internal/domain/reminder"
+	"xiaozhi-server-go/internal/domain/routine"
+	"xiaozhi-server-go/internal/domain/skills"
+	"xiaozhi-server-go/internal/domain/smarthome"
+	"xiaozhi-server-go/internal/domain/toolpolicy"
+	internalutils "xiaozhi-server-go/internal/utils"
+)
+
+// initIntentRouter 初始化意图路由器，失败时仅记录日志并全部交给LLM
+func (h *ConnectionHandler) initIntentRouter() {
+	var tools intent.ToolExecutor
+	if h.mcpManager != nil {
+		tools = policyTools{h.mcpManager}
+	}
+	router, err := intent.NewRouterFromConfig(h.config.Intent, h.logger, h, tools, h)
+	if err != nil {
+		h.LogError(fmt.Sprintf("[意图] 初始化意图路由器失败: %v", err))
+		return
+	}
+	if router != nil {
+		// 天气、新闻和日程查询由内置技能直接回答
+		if svc := skills.Default(); svc != nil {
+			for _, handler := range svc.IntentHandlers() {
+				router.RegisterHandler(handler)
+			}
+		}
+		// 点播音乐、电台和播放控制由媒体服务直接处理
+		if svc := media.Default(); svc != nil {
+			router.RegisterHandler(svc.IntentHandler())
+		}
+		// 开关灯、调温度等智能家居指令由智能家居服务直接控制，
+		// 控制后“不是这个，是另一个灯”这类纠正优先于规则识别，避免被当作对回复的反馈
+		if svc := smarthome.Default(); svc != nil {
+			router.PrependClassifier(svc.CorrectionClassifier(h.sessionID))
+			router.RegisterHandler(svc.IntentHandler())
+		}
+		// 设备上例程的唤醒短语优先于规则识别，命中后直接执行例程
+		if svc := routine.Default(); svc != nil && h.deviceID != "" {
+			router.PrependClassifier(svc.PhraseClassifier(h.deviceID))
+			router.RegisterHandler(svc.IntentHandler())
+		}
+	}
+	h.intentRouter = router
+}
+
+// policyTools 在意图处理器直接调用工具前按工具权限策略检查
+type policyTools struct {
+	intent.ToolExecutor
+}
+
+func (t policyTools) ExecuteTool(ctx context.Context, name string, args map[string]any) (any, error) {
+	if err := toolpolicy.Authorize(ctx, toolpolicy.KindTool, name, args); err != nil {
+		return nil, err
+	}
+	return t.ToolExecutor.ExecuteTool(ctx, name, args)
+}
+
+// RouteIntent 实现 pipeline.Turn，由确定性处理器处理，handled 为 true 表示无需调用LLM
+func (h *ConnectionHandler) RouteIntent(ctx context.Context, text string) (string, bool) {
+	if h.intentRouter == nil {
+		return "", false
+	}
+
+	resp := h.intentRouter.Route(ctx, &intent.Request{
+		Text:      text,
+		DeviceID:  h.deviceID,
+		SessionID: h.sessionID,
+	})
+	if resp == nil {
+		return "", false
+	}
+	return resp.Reply, true
+}
+
+// speakDirectReply 播报不经过LLM的回复（意图、流水线节点给出的回复）并记入对话历史
+func (h *ConnectionHandler) speakDirectReply(reply string, round int) {
+	h.putMessage(chat.Message{
+		Role:    "assistant",
+		Content: internalutils.RemoveAllEmoji(reply),
+	})
+
+	h.tts_last_text_index = 1
+	if err := h.SpeakAndPlay(reply, 1, round); err != nil {
+		h.LogError(fmt.Sprintf("[意图] 播放回复失败: %v", err))
+	}
+}
+
+// ScheduleTimer 实现 intent.TimerScheduler
+// 提醒服务可用时持久化为计时器，否则退化为仅在本连接存活期间有效的内存计时器
+func (h *ConnectionHandler) ScheduleTimer(ctx context.Context, deviceID string, delay time.Duration, content string) error {
+	h.LogInfo(fmt.Sprintf("[意图] [计时器] %s 后提醒: %s", delay, content))
+	if svc := reminder.Default(); svc != nil && deviceID != "" {
+		_, err := svc.Create(ctx, reminder.CreateRequest{
+			DeviceID: deviceID,
+			Kind:     reminder.KindTimer,
+			Message:  content,
+			Delay:    delay,
+		})
+		return err
+	}
+
+	time.AfterFunc(delay, func() {
+		text := "时间到了"
+		if content != "" {
+			text = "时间到了，记得" + content
+		}
+		if err := h.PushNotification(context.Background(), text); err != nil {
+			h.LogError(fmt.Sprintf("[意图] [计时器] 播报提醒失败: %v", err))
+		}
+	})
+	return nil
+}
+
+// notifyEnqueueTimeout 播报队列已满时等待入队的最长时间
+const notifyEnqueueTimeout = 5 * time.Second
+
+// notification 服务端主动播报的一段文本
+type notification struct {
+	text string
+}
+
+// PushNotification 由服务端主动向设备播报一段文本（提醒、通知等）
+// 计时器和提醒服务在其他协程调用，播报排入语音轮次的协程串行执行；入队即返回，
+// 不等待设备播报完成，队列已满时最多等待 notifyEnqueueTimeout 或 ctx 结束
+func (h *ConnectionHandler) PushNotification(ctx context.Context, text string) error {
+	if h.conn == nil || h.conn.IsClosed() {
+		return fmt.Errorf("连接已关闭")
+	}
+	ctx, cancel := context.WithTimeout(ctx, notifyEnqueueTimeout)
+	defer cancel()
+	select {
+	case h.notifyQueue <- &notification{text: text}:
+		return nil
+	case <-h.stopChan:
+		return fmt.Errorf("连接已关闭")
+	case <-ctx.Done():
+		return fmt.Errorf("播报队列已满: %w", ctx.Err())
+	}
+}
+
+// runNotification 在语音轮次的协程中播报通知，调用方不等待结果，失败时在此记录
+func (h *ConnectionHandler) runNotification(n *notification) {
+	if h.conn == nil || h.conn.IsClosed() {
+		h.LogWarn("[通知] 连接已关闭，丢弃播报")
+		return
+	}
+	atomic.StoreInt32(&h.serverVoiceStop, 0)
+	h.talkRound++
+	h.roundStartTime = time.Now()
+	if err := h.sendTTSMessage("start", "", 0); err != nil {
+		h.LogError(fmt.Sprintf("[通知] 发送TTS开始状态失败: %v", err))
+		return
+	}
+	if err := h.SystemSpeak(n.text); err != nil {
+		h.LogError(fmt.Sprintf("[通知] 播报失败: %v", err))
+	}
+}
@@ -0,0 +1,33 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/knowledge"
+)
+
+// knowledgeTimeout 每轮检索知识库的时间上限，超时后不附加参考资料，避免拖慢回复
+const knowledgeTimeout = 3 * time.Second
+
+// knowledgeContext 在设备所属档案绑定的知识库中检索本轮语句，把命中的分块附加到流水线给出的参考资料之后
+func (h *ConnectionHandler) knowledgeContext(ctx context.Context, text, turnContext string) string {
+	svc := knowledge.Default()
+	if svc == nil {
+		return turnContext
+	}
+	searchCtx, cancel := context.WithTimeout(ctx, knowledgeTimeout)
+	defer cancel()
+	hits, err := svc.SearchForDevice(searchCtx, h.deviceID, text)
+	if err != nil {
+		h.LogWarn("检索知识库失败: " + err.Error())
+		return turnContext
+	}
+	if len(hits) == 0 {
+		return turnContext
+	}
+	if turnContext == "" {
+		return knowledge.FormatContext(hits)
+	}
+	return turnContext + "\n\n" + knowledge.FormatContext(hits)
+}
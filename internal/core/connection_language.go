@@ -0,0 +1,87 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/language"
+)
+
+// languageState 本次连接的语言自动识别状态
+type languageState struct {
+	allowed []string // 设备允许的语言，第一个为主要语言
+
+	mu      sync.Mutex
+	current string // 最近一句的语言，作为播报音色和下一句的 ASR 语言提示
+}
+
+// initLanguage 加载设备允许的语言，语言服务未启用时不识别
+func (h *ConnectionHandler) initLanguage() {
+	svc := language.Default()
+	if svc == nil || !svc.Enabled() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(h.tenantContext(), 3*time.Second)
+	defer cancel()
+	allowed := svc.AllowedFor(ctx, h.deviceID)
+	h.language = &languageState{allowed: allowed, current: allowed[0]}
+	h.applyASRLanguage(allowed[0])
+}
+
+// detectUtteranceLanguage 识别用户这句话的语言，语言变化时更新 ASR 语言提示
+func (h *ConnectionHandler) detectUtteranceLanguage(text string) {
+	state := h.language
+	if state == nil {
+		return
+	}
+	state.mu.Lock()
+	previous := state.current
+	current := language.Choose(text, state.allowed, previous)
+	state.current = current
+	state.mu.Unlock()
+
+	if current != previous {
+		h.LogInfo(fmt.Sprintf("[语言] 切换到 %s（上一句 %s）", current, previous))
+		h.applyASRLanguage(current)
+	}
+}
+
+// applyASRLanguage 把当前语言和允许的语言交给 ASR，由提供者决定如何使用
+func (h *ConnectionHandler) applyASRLanguage(current string) {
+	if h.providers.asr == nil || h.language == nil {
+		return
+	}
+	preferences := map[string]interface{}{
+		"language":  current,
+		"languages": append([]string(nil), h.language.allowed...),
+	}
+	if err := h.providers.asr.SetUserPreferences(preferences); err != nil {
+		h.LogWarn(fmt.Sprintf("[语言] 设置ASR语言提示失败: %v", err))
+	}
+}
+
+// currentLanguage 最近一句的语言，未启用语言识别时返回空
+func (h *ConnectionHandler) currentLanguage() string {
+	state := h.language
+	if state == nil {
+		return ""
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	return state.current
+}
+
+// languageVoice 当前语言的播报音色；主要语言使用设备默认音色，返回空
+func (h *ConnectionHandler) languageVoice() string {
+	current := h.currentLanguage()
+	if current == "" || current == h.language.allowed[0] {
+		return ""
+	}
+	svc := language.Default()
+	if svc == nil {
+		return ""
+	}
+	return svc.Voice(current)
+}
@@ -0,0 +1,210 @@
+package core
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/transcript"
+	"xiaozhi-server-go/internal/platform/observability"
+)
+
+// voiceRMSThreshold 16 位 PCM 均方根幅度超过该值（约 -40dBFS）视为有人声，用于估计说话的起止时间
+const voiceRMSThreshold = 330
+
+// turnMark 轮次内记录的时间点
+type turnMark int
+
+const (
+	markLLMFirstToken turnMark = iota // LLM 首个输出
+	markLLMComplete                   // LLM 输出结束，含工具调用后的再次生成
+	markTTSSubmit                     // 首句送入 TTS
+	markTTSFirstByte                  // 首句音频合成完成
+	markPlayback                      // 首帧音频下发
+	turnMarkCount
+)
+
+// speechTiming 一段用户语音的时间点
+type speechTiming struct {
+	start      time.Time // 首个有声帧到达
+	end        time.Time // 说话结束：手动模式为 listen stop，其他模式为最后一个有声帧
+	recognized time.Time // 识别结果到达
+}
+
+// turnLatencyTracker 采集正在识别的语音和当前轮次各阶段的时间点
+type turnLatencyTracker struct {
+	mu sync.Mutex
+
+	// 正在识别的语音
+	speechStart time.Time
+	lastVoice   time.Time
+	speechStop  time.Time
+	pending     speechTiming // 正在处理的识别结果对应的语音
+
+	// 当前轮次
+	active bool
+	round  int
+	speech speechTiming
+	start  time.Time // 轮次开始（开始处理识别结果或文本）
+	marks  [turnMarkCount]time.Time
+}
+
+// turnSnapshot 已结束轮次的时间点
+type turnSnapshot struct {
+	round  int
+	speech speechTiming
+	start  time.Time
+	marks  [turnMarkCount]time.Time
+}
+
+// observeAudio 根据送入ASR的PCM估计说话的起止时间
+func (t *turnLatencyTracker) observeAudio(pcm []byte) {
+	if len(pcm) < 2 || pcmRMS(pcm) < voiceRMSThreshold {
+		return
+	}
+	now := time.Now()
+	t.mu.Lock()
+	if t.speechStart.IsZero() {
+		t.speechStart = now
+	}
+	t.lastVoice = now
+	t.mu.Unlock()
+}
+
+// markSpeechStop 客户端明确结束拾音
+func (t *turnLatencyTracker) markSpeechStop() {
+	t.mu.Lock()
+	t.speechStop = time.Now()
+	t.mu.Unlock()
+}
+
+// takeSpeech 识别出结果时取出这段语音的时间点，并开始记录下一段
+func (t *turnLatencyTracker) takeSpeech() speechTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	speech := speechTiming{start: t.speechStart, end: t.speechStop, recognized: time.Now()}
+	if speech.end.IsZero() {
+		speech.end = t.lastVoice
+	}
+	t.speechStart, t.lastVoice, t.speechStop = time.Time{}, time.Time{}, time.Time{}
+	return speech
+}
+
+func (t *turnLatencyTracker) setPending(speech speechTiming) {
+	t.mu.Lock()
+	t.pending = speech
+	t.mu.Unlock()
+}
+
+// begin 开始记录新一轮，返回尚未结束的上一轮（如被打断）
+func (t *turnLatencyTracker) begin(round int, start time.Time) *turnSnapshot {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	prev := t.finishLocked()
+	t.active, t.round, t.start = true, round, start
+	t.speech, t.pending = t.pending, speechTiming{}
+	t.marks = [turnMarkCount]time.Time{}
+	return prev
+}
+
+// mark 记录当前轮次的时间点；last 为 false 时只记录第一次
+func (t *turnLatencyTracker) mark(round int, m turnMark, last bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.active || t.round != round || (!last && !t.marks[m].IsZero()) {
+		return
+	}
+	t.marks[m] = time.Now()
+}
+
+// finish 结束当前轮次，没有进行中的轮次时返回 nil
+func (t *turnLatencyTracker) finish() *turnSnapshot {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.finishLocked()
+}
+
+func (t *turnLatencyTracker) finishLocked() *turnSnapshot {
+	if !t.active {
+		return nil
+	}
+	t.active = false
+	return &turnSnapshot{round: t.round, speech: t.speech, start: t.start, marks: t.marks}
+}
+
+// latency 计算各阶段耗时，缺少时间点的阶段为 0
+func (s *turnSnapshot) latency() transcript.TurnLatency {
+	origin := s.speech.end
+	if origin.IsZero() {
+		origin = s.start // 文本输入没有语音阶段
+	}
+	return transcript.TurnLatency{
+		Round:           s.round,
+		CaptureMs:       elapsedMs(s.speech.start, s.speech.end),
+		ASRMs:           elapsedMs(s.speech.end, s.speech.recognized),
+		LLMFirstTokenMs: elapsedMs(s.start, s.marks[markLLMFirstToken]),
+		LLMCompleteMs:   elapsedMs(s.start, s.marks[markLLMComplete]),
+		TTSFirstByteMs:  elapsedMs(s.marks[markTTSSubmit], s.marks[markTTSFirstByte]),
+		PlaybackStartMs: elapsedMs(s.start, s.marks[markPlayback]),
+		TotalMs:         elapsedMs(origin, s.marks[markPlayback]),
+	}
+}
+
+// beginTurnLatency 开始记录新一轮的耗时
+func (h *ConnectionHandler) beginTurnLatency(round int) {
+	if prev := h.turnLatency.begin(round, h.roundStartTime); prev != nil {
+		h.recordTurnLatency(prev)
+	}
+}
+
+// finishTurnLatency 本轮回复播放完毕或连接关闭时记录耗时
+func (h *ConnectionHandler) finishTurnLatency() {
+	if snapshot := h.turnLatency.finish(); snapshot != nil {
+		h.recordTurnLatency(snapshot)
+	}
+}
+
+// recordTurnLatency 上报各阶段耗时指标，并与对话记录一起保存
+func (h *ConnectionHandler) recordTurnLatency(snapshot *turnSnapshot) {
+	latency := snapshot.latency()
+	latency.SessionID = h.sessionID
+	latency.DeviceID = h.deviceID
+
+	stages := latency.Stages()
+	if len(stages) == 0 {
+		return
+	}
+	for stage, ms := range stages {
+		observability.RecordMetric(context.Background(), "turn_latency_ms", float64(ms), map[string]string{
+			"stage": string(stage),
+		})
+	}
+	h.LogDebug(fmt.Sprintf("[延迟] [轮次 %d] 采集=%dms ASR=%dms LLM首字=%dms LLM完成=%dms TTS首包=%dms 开始播放=%dms 总计=%dms",
+		latency.Round, latency.CaptureMs, latency.ASRMs, latency.LLMFirstTokenMs, latency.LLMCompleteMs,
+		latency.TTSFirstByteMs, latency.PlaybackStartMs, latency.TotalMs))
+
+	if svc := transcript.Default(); svc != nil {
+		svc.RecordLatency(&latency)
+	}
+}
+
+func elapsedMs(from, to time.Time) int64 {
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		return 0
+	}
+	return to.Sub(from).Milliseconds()
+}
+
+// pcmRMS 16 位小端序 PCM 的均方根幅度
+func pcmRMS(pcm []byte) float64 {
+	n := len(pcm) / 2
+	var sum float64
+	for i := 0; i < n; i++ {
+		v := float64(int16(binary.LittleEndian.Uint16(pcm[i*2:])))
+		sum += v * v
+	}
+	return math.Sqrt(sum / float64(n))
+}
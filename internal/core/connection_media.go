@@ -0,0 +1,626 @@
+package core
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/media"
+	"xiaozhi-server-go/internal/platform/httpclient"
+	internalutils "xiaozhi-server-go/internal/utils"
+)
+
+// mediaPreBufferFrames 开始播放时不等待直接发送的帧数
+const mediaPreBufferFrames = 3
+
+// mediaReportInterval 播放进度上报到设备影子的间隔
+const mediaReportInterval = time.Second
+
+// mediaPlayer 连接上的媒体播放器，经下行音频通道流式播放曲目和电台
+// 等当前回复播报完成后才开始发送；用户开始新一轮对话或打断时自动暂停，说“继续播放”恢复
+type mediaPlayer struct {
+	h *ConnectionHandler
+
+	mu          sync.Mutex
+	queue       []media.Track
+	index       int
+	volume      int
+	status      media.Status
+	interrupted bool // 因对话打断而暂停，调整音量时自动继续
+	position    time.Duration
+	lastErr     string
+	reportedAt  time.Time
+	stop        chan struct{} // 关闭时结束当前的播放协程
+	resume      chan struct{}
+}
+
+func newMediaPlayer(h *ConnectionHandler) *mediaPlayer {
+	return &mediaPlayer{h: h, status: media.StatusIdle, resume: make(chan struct{}, 1)}
+}
+
+// Play 替换播放列表并从第一首开始播放
+func (p *mediaPlayer) Play(tracks []media.Track, volume int) {
+	p.mu.Lock()
+	p.queue = tracks
+	p.volume = volume
+	p.lastErr = ""
+	p.startLocked(0)
+	p.mu.Unlock()
+	p.report()
+}
+
+// Pause 暂停播放，已暂停时不做处理
+func (p *mediaPlayer) Pause() error {
+	p.mu.Lock()
+	switch p.status {
+	case media.StatusIdle:
+		p.mu.Unlock()
+		return media.ErrNotPlaying
+	case media.StatusPaused:
+		p.interrupted = false
+		p.mu.Unlock()
+		return nil
+	}
+	p.status = media.StatusPaused
+	p.interrupted = false
+	p.mu.Unlock()
+	p.report()
+	return nil
+}
+
+// Resume 继续播放，等当前回复播报完成后恢复发送
+func (p *mediaPlayer) Resume() error {
+	p.mu.Lock()
+	if p.status == media.StatusIdle {
+		p.mu.Unlock()
+		return media.ErrNotPlaying
+	}
+	p.resumeLocked()
+	p.mu.Unlock()
+	p.report()
+	return nil
+}
+
+// Stop 停止播放并清空播放列表
+func (p *mediaPlayer) Stop() error {
+	p.mu.Lock()
+	if p.status == media.StatusIdle {
+		p.mu.Unlock()
+		return media.ErrNotPlaying
+	}
+	streaming := p.status == media.StatusPlaying
+	p.resetLocked()
+	p.mu.Unlock()
+	p.report()
+	if streaming {
+		p.h.sendTTSMessage("stop", "", 0)
+	}
+	return nil
+}
+
+// Next 播放列表的下一首，已是最后一首时停止播放
+func (p *mediaPlayer) Next() error {
+	p.mu.Lock()
+	if p.status == media.StatusIdle {
+		p.mu.Unlock()
+		return media.ErrNotPlaying
+	}
+	if p.index+1 >= len(p.queue) {
+		p.mu.Unlock()
+		return p.Stop()
+	}
+	p.startLocked(p.index + 1)
+	p.mu.Unlock()
+	p.report()
+	return nil
+}
+
+// SetVolume 调整音量，从下一帧开始生效；因对话打断而暂停时继续播放
+func (p *mediaPlayer) SetVolume(volume int) error {
+	p.mu.Lock()
+	if p.status == media.StatusIdle {
+		p.mu.Unlock()
+		return media.ErrNotPlaying
+	}
+	p.volume = volume
+	if p.status == media.StatusPaused && p.interrupted {
+		p.resumeLocked()
+	}
+	p.mu.Unlock()
+	p.report()
+	return nil
+}
+
+// close 连接关闭时结束播放
+func (p *mediaPlayer) close() {
+	p.mu.Lock()
+	p.resetLocked()
+	p.mu.Unlock()
+}
+
+// startLocked 结束当前的播放协程并从播放列表的第 index 首开始播放
+func (p *mediaPlayer) startLocked(index int) {
+	p.stopLocked()
+	p.index = index
+	p.position = 0
+	p.status = media.StatusLoading
+	p.interrupted = false
+	select {
+	case <-p.resume:
+	default:
+	}
+	p.stop = make(chan struct{})
+	go p.run(p.stop)
+}
+
+func (p *mediaPlayer) resumeLocked() {
+	if p.status != media.StatusPaused {
+		return
+	}
+	p.status = media.StatusLoading
+	p.interrupted = false
+	select {
+	case p.resume <- struct{}{}:
+	default:
+	}
+}
+
+func (p *mediaPlayer) resetLocked() {
+	p.stopLocked()
+	p.queue = nil
+	p.index = 0
+	p.position = 0
+	p.status = media.StatusIdle
+	p.interrupted = false
+}
+
+func (p *mediaPlayer) stopLocked() {
+	if p.stop != nil {
+		close(p.stop)
+		p.stop = nil
+	}
+}
+
+// run 依次播放列表中的曲目，stop 关闭后退出
+func (p *mediaPlayer) run(stop chan struct{}) {
+	for {
+		p.mu.Lock()
+		if p.stop != stop || p.index >= len(p.queue) {
+			p.mu.Unlock()
+			return
+		}
+		track := p.queue[p.index]
+		p.mu.Unlock()
+
+		err := p.playTrack(track, stop)
+		if p.stopped(stop) {
+			return
+		}
+		if err != nil {
+			p.h.LogError(fmt.Sprintf("[媒体] 播放《%s》失败: %v", track.Title, err))
+		}
+		if !p.advance(stop, err) {
+			return
+		}
+	}
+}
+
+// advance 当前曲目结束后切换到下一首，播放列表放完时返回 false
+func (p *mediaPlayer) advance(stop chan struct{}, err error) bool {
+	p.mu.Lock()
+	if p.stop != stop {
+		p.mu.Unlock()
+		return false
+	}
+	if err != nil {
+		p.lastErr = err.Error()
+	}
+	if p.index+1 < len(p.queue) {
+		p.index++
+		p.position = 0
+		p.status = media.StatusLoading
+		p.mu.Unlock()
+		p.report()
+		return true
+	}
+	streaming := p.status == media.StatusPlaying
+	p.resetLocked()
+	p.mu.Unlock()
+	p.report()
+	if streaming {
+		p.h.sendTTSMessage("stop", "", 0)
+	}
+	return false
+}
+
+// playTrack 解码并分时发送一首曲目，正常放完返回 nil
+func (p *mediaPlayer) playTrack(track media.Track, stop chan struct{}) error {
+	h := p.h
+	frameDuration := time.Duration(h.serverAudioFrameDuration) * time.Millisecond
+	pcm := make([]byte, internalutils.PCMFrameBytes(h.serverAudioSampleRate, 1, h.serverAudioFrameDuration))
+
+	var encoder *internalutils.OpusEncoder
+	if h.serverAudioFormat == "opus" {
+		encoderConfig := h.opusEncoderConfig()
+		encoderConfig.Channels = 1
+		var err error
+		if encoder, err = internalutils.NewOpusEncoder(encoderConfig); err != nil {
+			return err
+		}
+		defer encoder.Close()
+	}
+
+	var src io.ReadCloser
+	defer func() {
+		if src != nil {
+			src.Close()
+		}
+	}()
+
+	var (
+		streaming bool
+		round     int
+		startTime time.Time
+		sent      int
+	)
+	for {
+		if p.stopped(stop) {
+			return nil
+		}
+
+		if p.currentStatus() == media.StatusPaused {
+			if streaming {
+				h.sendTTSMessage("stop", "", 0)
+				streaming = false
+			}
+			// 直播流暂停期间不再读取，服务器会断开连接，继续时重新连接
+			if track.Live && src != nil {
+				src.Close()
+				src = nil
+			}
+			if !p.waitResume(stop) {
+				return nil
+			}
+			continue
+		}
+
+		if !streaming {
+			if !p.waitIdle(stop) {
+				return nil
+			}
+			if p.currentStatus() == media.StatusPaused {
+				continue
+			}
+			if src == nil {
+				var err error
+				if src, err = p.open(track, stop); err != nil {
+					return err
+				}
+			}
+			atomic.StoreInt32(&h.serverVoiceStop, 0)
+			round = h.talkRound
+			if err := h.sendTTSMessage("start", "", 0); err != nil {
+				return fmt.Errorf("发送TTS开始状态失败: %v", err)
+			}
+			h.sendTTSMessage("sentence_start", track.Title, 0)
+			p.setPlaying()
+			h.LogInfo(fmt.Sprintf("[媒体] 开始播放《%s》", track.Title))
+			streaming, startTime, sent = true, time.Now(), 0
+		}
+
+		n, err := io.ReadFull(src, pcm)
+		if n == 0 {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("读取音频失败: %v", err)
+		}
+		if n < len(pcm) {
+			clear(pcm[n:])
+		}
+		applyGain(pcm, p.currentVolume())
+
+		frame := pcm
+		if encoder != nil {
+			packets, err := encoder.Encode(pcm)
+			if err != nil {
+				return err
+			}
+			if len(packets) == 0 {
+				continue
+			}
+			frame = packets[0]
+		}
+
+		// 预缓冲后按帧时长匀速发送
+		if sent >= mediaPreBufferFrames {
+			delay := time.Until(startTime.Add(time.Duration(sent-mediaPreBufferFrames) * frameDuration))
+			if delay > 0 {
+				select {
+				case <-time.After(delay):
+				case <-stop:
+					return nil
+				case <-h.stopChan:
+					return nil
+				}
+			}
+		}
+
+		// 用户开始新一轮对话或打断播放
+		if atomic.LoadInt32(&h.serverVoiceStop) == 1 || round != h.talkRound {
+			h.LogInfo(fmt.Sprintf("[媒体] 对话打断，暂停播放《%s》", track.Title))
+			streaming = false
+			p.interrupt()
+			continue
+		}
+
+		if err := h.responseSender.SendAudioFrame(frame); err != nil {
+			return fmt.Errorf("发送音频帧失败: %v", err)
+		}
+		sent++
+		p.advancePosition(frameDuration)
+	}
+}
+
+// open 打开曲目的音频源，输出下行采样率的单声道PCM；网络音频流在播放停止或连接关闭时断开
+func (p *mediaPlayer) open(track media.Track, stop chan struct{}) (io.ReadCloser, error) {
+	sampleRate := p.h.serverAudioSampleRate
+	if !strings.HasPrefix(track.URL, "http://") && !strings.HasPrefix(track.URL, "https://") {
+		return internalutils.OpenAudioPCMStream(track.URL, sampleRate)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-stop:
+		case <-p.h.stopChan:
+		case <-ctx.Done():
+		}
+		cancel()
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, track.URL, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp, err := httpclient.Default().Client("media").Do(req)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("打开音频流失败: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("打开音频流失败: HTTP %d", resp.StatusCode)
+	}
+	stream, err := internalutils.NewMP3PCMStream(resp.Body, sampleRate)
+	if err != nil {
+		resp.Body.Close()
+		cancel()
+		return nil, err
+	}
+	return &mediaStream{Reader: stream, close: func() error {
+		cancel()
+		return resp.Body.Close()
+	}}, nil
+}
+
+// waitIdle 等待当前回复播报完成，stop 关闭或连接关闭时返回 false
+func (p *mediaPlayer) waitIdle(stop chan struct{}) bool {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for p.h.dialogueState.IsBusy() || atomic.LoadInt32(&p.h.ttsPending) > 0 {
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return false
+		case <-p.h.stopChan:
+			return false
+		}
+	}
+	return true
+}
+
+// waitResume 等待继续播放，stop 关闭或连接关闭时返回 false
+func (p *mediaPlayer) waitResume(stop chan struct{}) bool {
+	select {
+	case <-p.resume:
+		return true
+	case <-stop:
+		return false
+	case <-p.h.stopChan:
+		return false
+	}
+}
+
+func (p *mediaPlayer) stopped(stop chan struct{}) bool {
+	select {
+	case <-stop:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *mediaPlayer) currentStatus() media.Status {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.status
+}
+
+func (p *mediaPlayer) currentVolume() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.volume
+}
+
+func (p *mediaPlayer) setPlaying() {
+	p.mu.Lock()
+	if p.status == media.StatusLoading {
+		p.status = media.StatusPlaying
+	}
+	p.mu.Unlock()
+	p.report()
+}
+
+func (p *mediaPlayer) interrupt() {
+	p.mu.Lock()
+	if p.status == media.StatusPlaying {
+		p.status = media.StatusPaused
+		p.interrupted = true
+	}
+	p.mu.Unlock()
+	p.report()
+}
+
+// advancePosition 累加播放进度，每隔 mediaReportInterval 上报一次
+func (p *mediaPlayer) advancePosition(d time.Duration) {
+	p.mu.Lock()
+	p.position += d
+	due := time.Since(p.reportedAt) >= mediaReportInterval
+	p.mu.Unlock()
+	if due {
+		p.report()
+	}
+}
+
+// report 将播放状态上报到媒体服务，作为设备影子的一部分对外展示
+func (p *mediaPlayer) report() {
+	svc := media.Default()
+	if svc == nil || p.h.deviceID == "" {
+		return
+	}
+	svc.UpdateState(p.snapshot())
+}
+
+func (p *mediaPlayer) snapshot() media.State {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.reportedAt = time.Now()
+	state := media.State{
+		DeviceID:  p.h.deviceID,
+		Status:    p.status,
+		Index:     p.index,
+		QueueSize: len(p.queue),
+		Volume:    p.volume,
+		Position:  p.position,
+		Error:     p.lastErr,
+	}
+	if p.status != media.StatusIdle && p.index < len(p.queue) {
+		track := p.queue[p.index]
+		state.Track = &track
+	}
+	return state
+}
+
+// mediaStream 网络音频流，关闭时同时取消请求
+type mediaStream struct {
+	io.Reader
+	close func() error
+}
+
+func (s *mediaStream) Close() error {
+	return s.close()
+}
+
+// applyGain 按音量（0-100）缩放16位PCM，音量按平方曲线换算为增益，听感上更均匀
+func applyGain(pcm []byte, volume int) {
+	if volume >= 100 {
+		return
+	}
+	gain := float64(volume*volume) / 10000
+	for i := 0; i+1 < len(pcm); i += 2 {
+		sample := int16(binary.LittleEndian.Uint16(pcm[i:]))
+		binary.LittleEndian.PutUint16(pcm[i:], uint16(int16(float64(sample)*gain)))
+	}
+}
+
+// endMedia 连接关闭时停止播放；同一设备已建立新连接时保留新连接上报的状态
+func (h *ConnectionHandler) endMedia() {
+	if h.media == nil {
+		return
+	}
+	h.media.close()
+	if current, ok := FindActiveHandler(h.deviceID); ok && current != h {
+		return
+	}
+	h.media.report()
+}
+
+// DeviceMediaController 通过设备在线连接的播放器控制播放，实现 media.Controller
+type DeviceMediaController struct{}
+
+// NewDeviceMediaController 创建设备播放控制器
+func NewDeviceMediaController() *DeviceMediaController {
+	return &DeviceMediaController{}
+}
+
+func (c *DeviceMediaController) player(deviceID string) (*mediaPlayer, error) {
+	h, ok := FindActiveHandler(deviceID)
+	if !ok || h.media == nil {
+		return nil, media.ErrDeviceOffline
+	}
+	return h.media, nil
+}
+
+// Play 在设备上播放曲目列表
+func (c *DeviceMediaController) Play(deviceID string, tracks []media.Track, volume int) error {
+	p, err := c.player(deviceID)
+	if err != nil {
+		return err
+	}
+	p.Play(tracks, volume)
+	return nil
+}
+
+// Pause 暂停设备的播放
+func (c *DeviceMediaController) Pause(deviceID string) error {
+	p, err := c.player(deviceID)
+	if err != nil {
+		return err
+	}
+	return p.Pause()
+}
+
+// Resume 继续设备的播放
+func (c *DeviceMediaController) Resume(deviceID string) error {
+	p, err := c.player(deviceID)
+	if err != nil {
+		return err
+	}
+	return p.Resume()
+}
+
+// Stop 停止设备的播放
+func (c *DeviceMediaController) Stop(deviceID string) error {
+	p, err := c.player(deviceID)
+	if err != nil {
+		return err
+	}
+	return p.Stop()
+}
+
+// Next 切换到设备播放列表的下一首
+func (c *DeviceMediaController) Next(deviceID string) error {
+	p, err := c.player(deviceID)
+	if err != nil {
+		return err
+	}
+	return p.Next()
+}
+
+// SetVolume 调整设备的播放音量
+func (c *DeviceMediaController) SetVolume(deviceID string, volume int) error {
+	p, err := c.player(deviceID)
+	if err != nil {
+		return err
+	}
+	return p.SetVolume(volume)
+}
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"xiaozhi-server-go/internal/domain/member"
+)
+
+// identifySpeaker 按识别结果切换当前说话的家庭成员，并应用其偏好音色和语言
+// hint 为设备上报的用户ID或成员称呼，为空时按设备的默认成员识别
+func (h *ConnectionHandler) identifySpeaker(hint string) {
+	svc := member.Default()
+	if svc == nil || h.deviceID == "" {
+		return
+	}
+	m, ok := svc.Identify(h.tenantContext(), h.deviceID, hint)
+	if !ok {
+		if hint != "" {
+			h.LogWarn(fmt.Sprintf("[成员] 设备 %s 上没有成员 %s", h.deviceID, hint))
+		}
+		return
+	}
+	if h.speaker != nil && h.speaker.UserID == m.UserID {
+		return
+	}
+	h.speaker = m
+	h.LogInfo(fmt.Sprintf("[成员] 当前说话人: 用户 %d %s", m.UserID, m.Profile.Name))
+
+	if m.Profile.Voice != "" && h.providers.tts != nil {
+		if err, _ := h.providers.tts.SetVoice(m.Profile.Voice); err != nil {
+			h.LogWarn(fmt.Sprintf("[成员] 切换到成员偏好音色 %s 失败: %v", m.Profile.Voice, err))
+		}
+	}
+	if h.dialogueManager != nil {
+		h.dialogueManager.SetSystemMessage(h.speakerPrompt(h.basePrompt))
+	}
+}
+
+// speakerPrompt 在系统提示词后追加当前说话人的信息，未识别说话人时原样返回
+func (h *ConnectionHandler) speakerPrompt(prompt string) string {
+	if h.speaker == nil {
+		return prompt
+	}
+	var b strings.Builder
+	b.WriteString(prompt)
+	if h.speaker.Profile.Name != "" {
+		b.WriteString("\n\n当前和你对话的家庭成员是")
+		b.WriteString(h.speaker.Profile.Name)
+		b.WriteString("，请按这位成员的身份称呼和回应。")
+	}
+	if h.speaker.Profile.Language != "" {
+		b.WriteString("\n请使用语言 ")
+		b.WriteString(h.speaker.Profile.Language)
+		b.WriteString(" 回复。")
+	}
+	return b.String()
+}
+
+// speakerVoice 返回当前说话人的偏好音色，没有时返回空
+func (h *ConnectionHandler) speakerVoice() string {
+	if h.speaker == nil {
+		return ""
+	}
+	return h.speaker.Profile.Voice
+}
+
+// speakerHint 从设备消息中读取说话人标识，支持字符串和数字
+func speakerHint(msgMap map[string]interface{}) (string, bool) {
+	switch v := msgMap["speaker"].(type) {
+	case string:
+		return v, v != ""
+	case float64:
+		return fmt.Sprintf("%d", int64(v)), true
+	}
+	return "", false
+}
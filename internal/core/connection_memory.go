@@ -0,0 +1,32 @@
+package core
+
+import (
+	"context"
+
+	"xiaozhi-server-go/internal/domain/memory"
+)
+
+// observeMemory 把当前说话人的语句交给长期记忆服务提取事实，未识别说话人或未启用长期记忆时忽略
+func (h *ConnectionHandler) observeMemory(text, entryID string) {
+	svc := memory.Default()
+	if svc == nil || h.speaker == nil {
+		return
+	}
+	svc.Observe(memory.Observation{
+		UserID:    h.speaker.UserID,
+		Namespace: h.speaker.Profile.MemoryNamespace,
+		DeviceID:  h.deviceID,
+		SessionID: h.sessionID,
+		EntryID:   entryID,
+		Text:      text,
+	})
+}
+
+// memoryPrompt 返回当前说话人与本轮语句相关的长期记忆，作为本轮的附加系统消息
+func (h *ConnectionHandler) memoryPrompt(ctx context.Context, text string) string {
+	svc := memory.Default()
+	if svc == nil || h.speaker == nil {
+		return ""
+	}
+	return svc.Prompt(ctx, h.speaker.Profile.MemoryNamespace, text)
+}
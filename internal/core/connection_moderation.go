@@ -0,0 +1,52 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"xiaozhi-server-go/internal/domain/moderation"
+)
+
+// initModeration 初始化内容审核流水线，配置错误时仅记录日志并关闭审核
+func (h *ConnectionHandler) initModeration() {
+	pipeline, err := moderation.NewPipelineFromConfig(h.config.Moderation, h.logger)
+	if err != nil {
+		h.LogError(fmt.Sprintf("[审核] 初始化审核流水线失败: %v", err))
+		return
+	}
+	h.moderation = pipeline
+}
+
+// moderateInput 审核用户输入，返回处理后的文本以及是否被拦截
+func (h *ConnectionHandler) moderateInput(ctx context.Context, text string) (string, bool) {
+	if h.moderation == nil {
+		return text, false
+	}
+	result := h.moderation.Moderate(ctx, moderation.StageInput, h.sessionID, h.deviceID, text)
+	switch result.Action {
+	case moderation.ActionBlock:
+		h.LogWarn("[审核] 用户输入被拦截")
+		return text, true
+	case moderation.ActionRedact:
+		h.LogInfo("[审核] 用户输入已脱敏")
+	}
+	return result.Text, false
+}
+
+// moderateOutput 审核即将合成语音的文本
+// 同一轮次内首个被拦截的分段替换为拦截回复，其后的分段全部丢弃
+func (h *ConnectionHandler) moderateOutput(text string, round int) string {
+	if h.moderation == nil || text == "" {
+		return text
+	}
+	if atomic.LoadInt32(&h.blockedRound) == int32(round) {
+		return ""
+	}
+	result := h.moderation.Moderate(context.Background(), moderation.StageOutput, h.sessionID, h.deviceID, text)
+	if result.Action == moderation.ActionBlock {
+		h.LogWarn(fmt.Sprintf("[审核] 轮次 %d 的回复被拦截", round))
+		atomic.StoreInt32(&h.blockedRound, int32(round))
+	}
+	return result.Text
+}
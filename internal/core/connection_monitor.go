@@ -0,0 +1,166 @@
+package core
+
+import (
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/chat"
+	"xiaozhi-server-go/internal/domain/monitor"
+	"xiaozhi-server-go/internal/domain/tenant"
+)
+
+// recentSnippetLimit 每个会话保留的最近对话片段数
+const recentSnippetLimit = 6
+
+// recentSnippets 最近的对话片段，超出上限时丢弃最早的
+type recentSnippets struct {
+	mu       sync.Mutex
+	snippets []monitor.Snippet
+}
+
+func (r *recentSnippets) add(snippet monitor.Snippet) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.snippets = append(r.snippets, snippet)
+	if len(r.snippets) > recentSnippetLimit {
+		r.snippets = append([]monitor.Snippet(nil), r.snippets[len(r.snippets)-recentSnippetLimit:]...)
+	}
+}
+
+func (r *recentSnippets) list() []monitor.Snippet {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]monitor.Snippet(nil), r.snippets...)
+}
+
+// DeviceSessionSource 以在线设备连接作为会话监控的数据源，实现 monitor.Source
+type DeviceSessionSource struct{}
+
+// NewDeviceSessionSource 创建在线会话数据源
+func NewDeviceSessionSource() *DeviceSessionSource {
+	return &DeviceSessionSource{}
+}
+
+// Sessions 返回全部在线设备连接的实时状态
+func (s *DeviceSessionSource) Sessions() []monitor.Session {
+	activeHandlers.RLock()
+	handlers := make([]*ConnectionHandler, 0, len(activeHandlers.byDevice))
+	for _, h := range activeHandlers.byDevice {
+		handlers = append(handlers, h)
+	}
+	activeHandlers.RUnlock()
+
+	sessions := make([]monitor.Session, 0, len(handlers))
+	for _, h := range handlers {
+		sessions = append(sessions, h.monitorSession())
+	}
+	return sessions
+}
+
+// Terminate 关闭会话对应的设备连接
+func (s *DeviceSessionSource) Terminate(sessionID string) error {
+	activeHandlers.RLock()
+	var target *ConnectionHandler
+	for _, h := range activeHandlers.byDevice {
+		if h.sessionID == sessionID {
+			target = h
+			break
+		}
+	}
+	activeHandlers.RUnlock()
+	if target == nil {
+		return monitor.ErrNotFound
+	}
+	target.LogInfo("[监控] 会话被管理员终止")
+	target.Close()
+	return nil
+}
+
+// monitorSession 连接当前的会话状态
+func (h *ConnectionHandler) monitorSession() monitor.Session {
+	session := monitor.Session{
+		SessionID:   h.sessionID,
+		DeviceID:    h.deviceID,
+		TenantID:    tenant.IDFrom(h.ctx),
+		ConnectedAt: h.connectedAt,
+		Recent:      h.recent.list(),
+	}
+	if h.dialogueState != nil {
+		session.State = string(h.dialogueState.State())
+		session.Round = h.dialogueState.Round()
+	}
+	if h.llmManager != nil {
+		cfg := h.llmManager.GetConfig()
+		session.LLM = cfg.Provider
+		if cfg.Model != "" {
+			session.LLM += "/" + cfg.Model
+		}
+	}
+	session.TTS, session.Voice = h.monitorTTS(session.Round)
+	return session
+}
+
+// monitorTTS 当前使用的 TTS 配置名和音色，与播报时的选择顺序一致
+func (h *ConnectionHandler) monitorTTS(round int) (string, string) {
+	if h.config == nil {
+		return "", ""
+	}
+	name := h.config.Selected.TTS
+	if offline := h.offlineTTSName(); offline != "" {
+		name = offline
+	}
+	var voice string
+	if cfg, ok := h.config.TTS[name]; ok {
+		voice = cfg.Voice
+	}
+	for _, override := range []string{h.speakerVoice(), h.languageVoice(), h.translationVoice(round)} {
+		if override != "" {
+			voice = override
+		}
+	}
+	return name, voice
+}
+
+// recordSnippet 记录用户和助手的对话片段，会话被旁听时同时发布
+func (h *ConnectionHandler) recordSnippet(msg chat.Message) {
+	if (msg.Role != "user" && msg.Role != "assistant") || msg.Content == "" || len(msg.ToolCalls) > 0 {
+		return
+	}
+	content := []rune(msg.Content)
+	if len(content) > monitor.SnippetMaxRunes {
+		content = append(content[:monitor.SnippetMaxRunes], '…')
+	}
+	snippet := monitor.Snippet{Role: msg.Role, Content: string(content), At: time.Now()}
+	h.recent.add(snippet)
+
+	if svc := monitor.Default(); svc != nil && svc.Listening(h.sessionID) {
+		svc.Publish(monitor.Event{
+			Type:      monitor.EventSnippet,
+			SessionID: h.sessionID,
+			Round:     h.talkRound,
+			Snippet:   &snippet,
+			At:        snippet.At,
+		})
+	}
+}
+
+// publishMonitorState 会话被旁听时发布对话状态变化
+func (h *ConnectionHandler) publishMonitorState(state chat.DialogueState) {
+	svc := monitor.Default()
+	if svc == nil || !svc.Listening(h.sessionID) {
+		return
+	}
+	svc.Publish(monitor.Event{
+		Type:      monitor.EventState,
+		SessionID: h.sessionID,
+		State:     string(state),
+		Round:     h.talkRound,
+	})
+}
+
+// notifyMonitorClosed 连接关闭时结束会话的旁听
+func (h *ConnectionHandler) notifyMonitorClosed() {
+	if svc := monitor.Default(); svc != nil {
+		svc.SessionClosed(h.sessionID)
+	}
+}
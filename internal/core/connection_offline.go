@@ -0,0 +1,228 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+
+	"xiaozhi-server-go/internal/domain/flags"
+	domainllminter "xiaozhi-server-go/internal/domain/llm/inter"
+	"xiaozhi-server-go/internal/domain/offline"
+	"xiaozhi-server-go/internal/domain/providers/asr"
+	providers "xiaozhi-server-go/internal/domain/providers/types"
+	domainttsinter "xiaozhi-server-go/internal/domain/tts/inter"
+)
+
+// offlineState 连接在离线模式下的切换状态
+type offlineState struct {
+	mu         sync.Mutex
+	generation uint64                    // 已应用的离线监控状态版本
+	disabled   bool                      // 已应用的 offline_mode 开关状态，关闭时保持使用云端服务
+	onlineASR  providers.ASRProvider     // 切换前的云端 ASR，切回时恢复，未切换时为 nil
+	onlineLLM  *domainllminter.LLMConfig // 切换前的 LLM 配置，未切换时为 nil
+	onlineTTS  *domainttsinter.TTSConfig // 切换前的 TTS 配置，未切换时为 nil
+	tts        string                    // 离线时插件 TTS 使用的配置名称
+}
+
+// OfflineAnnouncer 向所有在线设备播报离线模式的切换，实现 offline.Announcer
+type OfflineAnnouncer struct{}
+
+// NewOfflineAnnouncer 创建离线模式播报器
+func NewOfflineAnnouncer() *OfflineAnnouncer {
+	return &OfflineAnnouncer{}
+}
+
+// Announce 先按最新状态切换各连接的服务，再用切换后的 TTS 播报
+func (a *OfflineAnnouncer) Announce(ctx context.Context, text string) int {
+	activeHandlers.RLock()
+	handlers := make([]*ConnectionHandler, 0, len(activeHandlers.byDevice))
+	for _, h := range activeHandlers.byDevice {
+		handlers = append(handlers, h)
+	}
+	activeHandlers.RUnlock()
+
+	count := 0
+	for _, h := range handlers {
+		h.applyOfflineMode()
+		if !h.flagEnabled(flags.OfflineMode) {
+			continue
+		}
+		if err := h.PushNotification(context.Background(), text); err != nil {
+			h.LogWarn(fmt.Sprintf("[离线模式] 播报失败: %v", err))
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+// announceOfflineMode 设备在离线期间连接时提示当前处于离线模式
+func (h *ConnectionHandler) announceOfflineMode() {
+	monitor := offline.Default()
+	if monitor == nil || !monitor.Active() || monitor.Announcement() == "" || !h.flagEnabled(flags.OfflineMode) {
+		return
+	}
+	h.applyOfflineMode()
+	if err := h.PushNotification(context.Background(), monitor.Announcement()); err != nil {
+		h.LogWarn(fmt.Sprintf("[离线模式] 播报失败: %v", err))
+	}
+}
+
+// applyOfflineMode 按离线监控的当前状态切换本连接的 ASR、LLM 和 TTS，
+// 在每轮对话开始前调用，状态和 offline_mode 开关都未变化时直接返回
+func (h *ConnectionHandler) applyOfflineMode() {
+	monitor := offline.Default()
+	if monitor == nil {
+		return
+	}
+	disabled := !h.flagEnabled(flags.OfflineMode)
+	h.offline.mu.Lock()
+	defer h.offline.mu.Unlock()
+	generation := monitor.Generation()
+	if generation == h.offline.generation && disabled == h.offline.disabled {
+		return
+	}
+	h.offline.generation = generation
+	h.offline.disabled = disabled
+	if disabled {
+		// 开关关闭时恢复已切换的云端服务
+		h.switchOfflineASR("")
+		h.switchOfflineLLM("")
+		h.switchOfflineTTS("")
+		return
+	}
+	h.switchOfflineASR(monitor.Select(offline.KindASR))
+	h.switchOfflineLLM(monitor.Select(offline.KindLLM))
+	h.switchOfflineTTS(monitor.Select(offline.KindTTS))
+}
+
+// offlineTTSName 离线时插件 TTS 使用的配置名称，未切换时返回空字符串
+func (h *ConnectionHandler) offlineTTSName() string {
+	h.offline.mu.Lock()
+	defer h.offline.mu.Unlock()
+	return h.offline.tts
+}
+
+// restoreOnlineASR 连接关闭时释放本地 ASR，恢复池中的云端 ASR 以便归还
+func (h *ConnectionHandler) restoreOnlineASR() {
+	h.offline.mu.Lock()
+	defer h.offline.mu.Unlock()
+	h.switchOfflineASR("")
+}
+
+// switchOfflineASR name 为空时切回云端 ASR，否则创建本地 ASR 替换当前 ASR
+func (h *ConnectionHandler) switchOfflineASR(name string) {
+	if name == "" {
+		if h.offline.onlineASR == nil {
+			return
+		}
+		local := h.providers.asr
+		h.providers.asr = h.offline.onlineASR
+		h.offline.onlineASR = nil
+		h.providers.asr.SetListener(h)
+		if err := local.Cleanup(); err != nil {
+			h.LogError(fmt.Sprintf("[离线模式] 释放本地ASR失败: %v", err))
+		}
+		h.LogInfo("[离线模式] ASR 已切回云端服务")
+		return
+	}
+	// 已切换，或没有云端 ASR 可替换
+	if h.offline.onlineASR != nil || h.providers.asr == nil {
+		return
+	}
+	local, err := h.createASR(name)
+	if err != nil {
+		h.LogError(fmt.Sprintf("[离线模式] 创建本地ASR %s 失败: %v", name, err))
+		return
+	}
+	if err := h.providers.asr.Reset(); err != nil {
+		h.LogWarn(fmt.Sprintf("[离线模式] 重置云端ASR失败: %v", err))
+	}
+	local.SetListener(h)
+	h.offline.onlineASR = h.providers.asr
+	h.providers.asr = local
+	if current := h.currentLanguage(); current != "" {
+		h.applyASRLanguage(current)
+	}
+	h.LogInfo(fmt.Sprintf("[离线模式] ASR 已切换到本地服务 %s", name))
+}
+
+// createASR 按配置名称创建不经过资源池的 ASR 提供者
+func (h *ConnectionHandler) createASR(name string) (providers.ASRProvider, error) {
+	data, ok := h.config.ASR[name].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("ASR 配置不存在: %s", name)
+	}
+	providerType, _ := data["type"].(string)
+	if providerType == "" {
+		providerType = name
+	}
+	provider, err := asr.Create(providerType, &asr.Config{
+		Name: name,
+		Type: providerType,
+		Data: maps.Clone(data),
+	}, h.config.Audio.DeleteAudio, h.logger)
+	if err != nil {
+		return nil, err
+	}
+	coreProvider, ok := provider.(providers.ASRProvider)
+	if !ok {
+		return nil, fmt.Errorf("asr provider %s does not implement types.ASRProvider", name)
+	}
+	return coreProvider, nil
+}
+
+// switchOfflineLLM name 为空时恢复原 LLM 配置，否则切换到本地 LLM 配置
+func (h *ConnectionHandler) switchOfflineLLM(name string) {
+	if h.llmManager == nil {
+		return
+	}
+	if name == "" {
+		if h.offline.onlineLLM != nil {
+			h.llmManager.UpdateConfig(*h.offline.onlineLLM)
+			h.offline.onlineLLM = nil
+			h.LogInfo("[离线模式] LLM 已切回云端服务")
+		}
+		return
+	}
+	llmCfg, ok := h.config.LLM[name]
+	if !ok {
+		h.LogError(fmt.Sprintf("[离线模式] LLM 配置不存在: %s", name))
+		return
+	}
+	if h.offline.onlineLLM == nil {
+		online := h.llmManager.GetConfig()
+		h.offline.onlineLLM = &online
+	}
+	h.llmManager.UpdateConfig(llmManagerConfig(llmCfg))
+	h.LogInfo(fmt.Sprintf("[离线模式] LLM 已切换到本地服务 %s", name))
+}
+
+// switchOfflineTTS name 为空时恢复原 TTS 配置，否则切换到本地 TTS 配置
+func (h *ConnectionHandler) switchOfflineTTS(name string) {
+	h.offline.tts = name
+	if h.ttsManager == nil {
+		return
+	}
+	if name == "" {
+		if h.offline.onlineTTS != nil {
+			h.ttsManager.UpdateConfig(*h.offline.onlineTTS)
+			h.offline.onlineTTS = nil
+			h.LogInfo("[离线模式] TTS 已切回云端服务")
+		}
+		return
+	}
+	ttsCfg, ok := h.config.TTS[name]
+	if !ok {
+		h.offline.tts = ""
+		h.LogError(fmt.Sprintf("[离线模式] TTS 配置不存在: %s", name))
+		return
+	}
+	if h.offline.onlineTTS == nil {
+		online := h.ttsManager.GetConfig()
+		h.offline.onlineTTS = &online
+	}
+	h.ttsManager.UpdateConfig(ttsManagerConfig(name, ttsCfg))
+	h.LogInfo(fmt.Sprintf("[离线模式] TTS 已切换到本地服务 %s", name))
+}
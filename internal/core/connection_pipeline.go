@@ -0,0 +1,70 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"xiaozhi-server-go/internal/domain/flags"
+	"xiaozhi-server-go/internal/domain/pipeline"
+	providers "xiaozhi-server-go/internal/domain/providers/types"
+)
+
+// ModerateInput 实现 pipeline.Turn
+func (h *ConnectionHandler) ModerateInput(ctx context.Context, text string) (string, bool) {
+	return h.moderateInput(ctx, text)
+}
+
+// runPipeline 执行设备所属的对话流水线
+// 未配置流水线或设备关闭 new_pipeline 开关时按内置流程处理：输入审核 -> 意图路由；流水线执行失败时只做输入审核，
+// 避免已执行过的意图（如设置计时器）被重复执行
+func (h *ConnectionHandler) runPipeline(ctx context.Context, text string) *pipeline.Result {
+	if svc := pipeline.Default(); svc != nil && h.flagEnabled(flags.NewPipeline) {
+		if _, ok := svc.ProfileFor(h.deviceID); ok {
+			result, err := svc.Run(ctx, pipeline.Input{
+				Text:      text,
+				DeviceID:  h.deviceID,
+				SessionID: h.sessionID,
+				Turn:      h,
+			})
+			if err == nil {
+				h.LogDebug(fmt.Sprintf("[流水线] %s 执行完成，直接回复=%t，拦截=%t", result.Profile, result.Reply != "", result.Blocked))
+				return result
+			}
+			h.LogError(fmt.Sprintf("[流水线] %v，仅做输入审核后交给LLM", err))
+			result = &pipeline.Result{}
+			result.Text, result.Blocked = h.moderateInput(ctx, text)
+			return result
+		}
+	}
+
+	result := &pipeline.Result{}
+	result.Text, result.Blocked = h.moderateInput(ctx, text)
+	if !result.Blocked {
+		result.Reply, _ = h.RouteIntent(ctx, result.Text)
+	}
+	return result
+}
+
+// blockReply 输入被拦截时的回复，未启用审核（由流水线节点拦截）时使用配置中的回复
+func (h *ConnectionHandler) blockReply() string {
+	if h.moderation != nil {
+		return h.moderation.BlockReply()
+	}
+	return h.config.Moderation.BlockReply
+}
+
+// withTurnContext 将流水线给出的参考资料附加到最后一条用户消息，不修改对话历史
+func withTurnContext(messages []providers.Message, turnContext string) []providers.Message {
+	if turnContext == "" {
+		return messages
+	}
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role != "user" {
+			continue
+		}
+		augmented := append([]providers.Message(nil), messages...)
+		augmented[i].Content = fmt.Sprintf("%s\n\n参考资料：\n%s", messages[i].Content, turnContext)
+		return augmented
+	}
+	return messages
+}
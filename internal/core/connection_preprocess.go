@@ -0,0 +1,60 @@
+package core
+
+import (
+	"fmt"
+
+	"xiaozhi-server-go/internal/domain/audio/preprocess"
+	internalutils "xiaozhi-server-go/internal/utils"
+)
+
+// initAudioPreprocess 按设备所属的预处理策略创建上行音频预处理器，应在握手确定音频参数后调用
+func (h *ConnectionHandler) initAudioPreprocess() {
+	var preprocessor *preprocess.Preprocessor
+	defer func() {
+		h.audioPreprocessor.Store(preprocessor)
+		h.audioProcessor.SetPreprocessor(preprocessor)
+	}()
+	if h.config == nil {
+		return
+	}
+
+	name, profile := preprocess.ProfileFor(h.config.Audio.Preprocess, h.deviceID)
+	if !profile.NoiseSuppression && !profile.EchoCancellation {
+		return
+	}
+	if h.clientAudioChannels > 1 {
+		h.LogInfo(fmt.Sprintf("[预处理] 策略 %s 仅支持单声道，当前 %d 声道，跳过预处理", name, h.clientAudioChannels))
+		return
+	}
+	sampleRate := h.clientAudioSampleRate
+	if sampleRate <= 0 {
+		sampleRate = 16000
+	}
+	preprocessor = preprocess.New(profile, sampleRate)
+	h.LogInfo(fmt.Sprintf("[预处理] 策略 %s: 降噪=%v 回声消除=%v 采样率=%d", name, profile.NoiseSuppression, profile.EchoCancellation, sampleRate))
+}
+
+// echoReferenceFrames 把待发送的 TTS 音频转换为与下行音频帧一一对应的参考信号，未开启回声消除时返回 nil
+func (h *ConnectionHandler) echoReferenceFrames(filepath string) [][]byte {
+	preprocessor := h.audioPreprocessor.Load()
+	if !preprocessor.EchoCancellation() {
+		return nil
+	}
+	pcm, _, err := internalutils.AudioFileToPCM(filepath, preprocessor.SampleRate())
+	if err != nil {
+		h.LogError(fmt.Sprintf("[预处理] 生成回声参考信号失败: %v", err))
+		return nil
+	}
+	frameBytes := internalutils.PCMFrameBytes(preprocessor.SampleRate(), 1, h.serverAudioFrameDuration)
+	return internalutils.SplitPCMFrames(pcm, frameBytes, true)
+}
+
+// writeEchoReference 记录刚发送的第 index 帧对应的参考信号
+func (h *ConnectionHandler) writeEchoReference(reference [][]byte, index int) {
+	if index >= len(reference) {
+		return
+	}
+	if preprocessor := h.audioPreprocessor.Load(); preprocessor != nil {
+		preprocessor.WriteReference(reference[index])
+	}
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"context"
+	"sync"
+
+	"xiaozhi-server-go/internal/domain/recording"
+)
+
+// defaultMaxUtteranceSeconds 未配置时单段语音最多缓存的时长
+const defaultMaxUtteranceSeconds = 30
+
+// asrResult 识别结果及其对应的语音
+type asrResult struct {
+	text   string
+	audio  []byte // 未开启语音归档或设备未授权时为空
+	speech speechTiming
+}
+
+// utteranceBuffer 缓存送入ASR的语音，识别出结果后随结果交给对话处理，与用户消息一起归档
+type utteranceBuffer struct {
+	mu      sync.Mutex
+	pcm     []byte // 正在识别的语音
+	pending []byte // 正在处理的识别结果对应的语音
+}
+
+// append 追加语音，超过 maxBytes 时丢弃最早的部分
+func (b *utteranceBuffer) append(data []byte, maxBytes int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pcm = append(b.pcm, data...)
+	if excess := len(b.pcm) - maxBytes; excess > 0 {
+		excess += excess % 2 // 保持16位样本对齐
+		b.pcm = append(b.pcm[:0], b.pcm[excess:]...)
+	}
+}
+
+// take 取出并清空正在识别的语音
+func (b *utteranceBuffer) take() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	pcm := b.pcm
+	b.pcm = nil
+	return pcm
+}
+
+func (b *utteranceBuffer) setPending(pcm []byte) {
+	b.mu.Lock()
+	b.pending = pcm
+	b.mu.Unlock()
+}
+
+func (b *utteranceBuffer) takePending() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	pcm := b.pending
+	b.pending = nil
+	return pcm
+}
+
+// bufferUtteranceAudio 开启语音归档且设备已授权时缓存送入ASR的PCM
+func (h *ConnectionHandler) bufferUtteranceAudio(data []byte) {
+	svc := recording.Default()
+	if svc == nil || !svc.Consented(context.Background(), h.deviceID) {
+		return
+	}
+	seconds := h.config.Recording.MaxUtteranceSeconds
+	if seconds <= 0 {
+		seconds = defaultMaxUtteranceSeconds
+	}
+	h.utterance.append(data, seconds*h.clientAudioSampleRate*h.uplinkChannels()*2)
+}
+
+// archiveUtterance 归档当前用户消息对应的语音，entryID 为对话记录中的消息ID
+func (h *ConnectionHandler) archiveUtterance(entryID string) {
+	pcm := h.utterance.takePending()
+	svc := recording.Default()
+	if svc == nil || len(pcm) == 0 {
+		return
+	}
+	svc.Archive(recording.Recording{
+		SessionID:  h.sessionID,
+		DeviceID:   h.deviceID,
+		Round:      h.talkRound,
+		EntryID:    entryID,
+		SampleRate: h.clientAudioSampleRate,
+		Channels:   h.uplinkChannels(),
+	}, pcm)
+}
+
+func (h *ConnectionHandler) uplinkChannels() int {
+	if h.clientAudioChannels <= 0 {
+		return 1
+	}
+	return h.clientAudioChannels
+}
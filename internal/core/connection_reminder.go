@@ -0,0 +1,111 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/reminder"
+)
+
+// ScheduleReminder 实现 components.ReminderScheduler，由 set_reminder 工具调用
+func (h *ConnectionHandler) ScheduleReminder(args map[string]interface{}) (string, error) {
+	svc := reminder.Default()
+	if svc == nil {
+		return "", fmt.Errorf("提醒服务未初始化")
+	}
+	if h.deviceID == "" {
+		return "", fmt.Errorf("缺少设备ID，无法设置提醒")
+	}
+
+	req := reminder.CreateRequest{
+		DeviceID: h.deviceID,
+		Kind:     reminder.Kind(stringArg(args, "kind")),
+		Message:  stringArg(args, "message"),
+	}
+	req.Recurrence = reminder.Recurrence(stringArg(args, "recurrence"))
+
+	switch v := args["delay_seconds"].(type) {
+	case float64:
+		req.Delay = time.Duration(v * float64(time.Second))
+	case int:
+		req.Delay = time.Duration(v) * time.Second
+	}
+	if req.Delay <= 0 {
+		at := stringArg(args, "time")
+		if at == "" {
+			return "", fmt.Errorf("缺少触发时间")
+		}
+		fireAt, err := reminder.ParseFireTime(at, time.Now())
+		if err != nil {
+			return "", fmt.Errorf("无法解析触发时间 %q: %v", at, err)
+		}
+		req.FireAt = fireAt
+	}
+
+	r, err := svc.Create(context.Background(), req)
+	if err != nil {
+		return "", err
+	}
+	h.LogInfo(fmt.Sprintf("[提醒] 已为设备 %s 创建 %s %s", h.deviceID, r.Kind, r.ID))
+	return confirmReminder(r, time.Now()), nil
+}
+
+func stringArg(args map[string]interface{}, key string) string {
+	if v, ok := args[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// confirmReminder 生成创建成功后的口语化确认
+func confirmReminder(r *reminder.Reminder, now time.Time) string {
+	var when string
+	if d := r.FireAt.Sub(now); r.Kind == reminder.KindTimer && d < 24*time.Hour {
+		when = humanizeDuration(d) + "后"
+	} else {
+		day := "今天"
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		switch int(r.FireAt.Sub(today).Hours() / 24) {
+		case 0:
+		case 1:
+			day = "明天"
+		default:
+			day = fmt.Sprintf("%d月%d日", int(r.FireAt.Month()), r.FireAt.Day())
+		}
+		when = fmt.Sprintf("%s%d点%02d分", day, r.FireAt.Hour(), r.FireAt.Minute())
+	}
+
+	switch r.Kind {
+	case reminder.KindAlarm:
+		return "好的，闹钟定在" + when
+	case reminder.KindReminder:
+		if r.Message != "" {
+			return "好的，" + when + "提醒你" + r.Message
+		}
+		return "好的，" + when + "提醒你"
+	default:
+		return "好的，计时器设好了，" + when + "叫你"
+	}
+}
+
+func humanizeDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	s := int(d.Seconds()) % 60
+	out := ""
+	if h > 0 {
+		out += fmt.Sprintf("%d小时", h)
+	}
+	if m > 0 {
+		out += fmt.Sprintf("%d分钟", m)
+	}
+	if s > 0 && h == 0 {
+		out += fmt.Sprintf("%d秒", s)
+	}
+	if out == "" {
+		out = "马上"
+	}
+	return out
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"sync/atomic"
 	"time"
+	"xiaozhi-server-go/internal/domain/protocol"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
 	internalutils "xiaozhi-server-go/internal/utils"
 )
 
@@ -18,7 +20,14 @@ func (h *ConnectionHandler) sendHelloMessage() error {
 		"channels":       h.serverAudioChannels,
 		"frame_duration": h.serverAudioFrameDuration,
 	}
-	return h.responseSender.SendHello(1, "websocket", audioParams)
+	if h.serverAudioFormat == protocol.CodecOpus && h.serverAudioBitrate > 0 {
+		audioParams["bitrate"] = h.serverAudioBitrate
+	}
+	version, features, framing := protocol.MinVersion, map[string]bool(nil), ""
+	if h.protocolSession != nil {
+		version, features, framing = h.protocolSession.Version, h.protocolSession.Features, h.protocolSession.Framing
+	}
+	return h.responseSender.SendHello(version, protocol.TransportWebSocket, audioParams, features, framing)
 }
 
 func (h *ConnectionHandler) sendTTSMessage(state string, text string, textIndex int) error {
@@ -43,6 +52,24 @@ func (h *ConnectionHandler) sendEmotionMessage(emotion string) error {
 	return h.responseSender.SendEmotion(emotion)
 }
 
+// sendErrorMessage 向协商了 errors 特性的设备下发结构化错误，未协商时不发送
+func (h *ConnectionHandler) sendErrorMessage(err error) {
+	if h.responseSender == nil || !h.protocolSession.HasFeature(protocol.FeatureErrors) {
+		return
+	}
+	if sendErr := h.responseSender.SendError(platformerrors.Describe(err)); sendErr != nil {
+		h.LogError(fmt.Sprintf("发送错误消息失败: %v", sendErr))
+	}
+}
+
+// reportError 记录本轮的能力调用追踪信息，下发结构化错误并播报该类别的提示语，作为本轮的第一句回复
+func (h *ConnectionHandler) reportError(err error, round int) {
+	h.logTurnTraces(round)
+	h.sendErrorMessage(err)
+	h.tts_last_text_index = 1 // 重置文本索引
+	h.SpeakAndPlay(platformerrors.Describe(err).Message, 1, round)
+}
+
 // SendAudioMessage sends an audio message
 func (h *ConnectionHandler) SendAudioMessage(filepath string, text string, textIndex int, round int) {
 	logText := internalutils.SanitizeForLog(text)
@@ -108,16 +135,16 @@ func (h *ConnectionHandler) SendAudioMessage(filepath string, text string, textI
 	var duration float64
 	var err error
 
-	// 使用TTS提供者的方法将音频转为Opus格式
+	// 按协商的下行参数重采样并切分为帧，opus 格式再逐帧编码
 	if h.serverAudioFormat == "pcm" {
 		h.LogInfo("服务端音频格式为PCM，直接发送")
-		audioData, duration, err = internalutils.AudioToPCMData(filepath)
+		audioData, duration, err = internalutils.AudioToPCMFrames(filepath, h.serverAudioSampleRate, h.serverAudioFrameDuration)
 		if err != nil {
 			h.LogError(fmt.Sprintf("音频转PCM失败: %v", err))
 			return
 		}
 	} else if h.serverAudioFormat == "opus" {
-		audioData, duration, err = internalutils.AudioToOpusData(filepath)
+		audioData, duration, err = internalutils.AudioToOpusFrames(filepath, h.opusEncoderConfig())
 		if err != nil {
 			h.LogError(fmt.Sprintf("音频转Opus失败: %v", err))
 			return
@@ -138,7 +165,7 @@ func (h *ConnectionHandler) SendAudioMessage(filepath string, text string, textI
 	h.logger.Debug("TTS发送(%s): \"%s\" (索引:%d/%d，时长:%f，帧数:%d)", h.serverAudioFormat, logText, textIndex, h.tts_last_text_index, duration, len(audioData))
 
 	// 分时发送音频数据
-	if err := h.sendAudioFrames(audioData, text, round); err != nil {
+	if err := h.sendAudioFrames(audioData, h.echoReferenceFrames(filepath), text, round); err != nil {
 		h.LogError(fmt.Sprintf("分时发送音频数据失败: %v", err))
 		return
 	}
@@ -150,8 +177,24 @@ func (h *ConnectionHandler) SendAudioMessage(filepath string, text string, textI
 	}
 }
 
+// opusEncoderConfig 按协商的下行参数和配置生成 TTS 音频的编码器配置
+func (h *ConnectionHandler) opusEncoderConfig() internalutils.OpusEncoderConfig {
+	encoderConfig := internalutils.OpusEncoderConfig{
+		SampleRate:    h.serverAudioSampleRate,
+		Channels:      h.serverAudioChannels,
+		FrameDuration: h.serverAudioFrameDuration,
+		Bitrate:       h.serverAudioBitrate,
+	}
+	if h.config != nil {
+		encoderConfig.Complexity = h.config.Audio.Output.Complexity
+		encoderConfig.InbandFEC = h.config.Audio.Output.InbandFEC
+	}
+	return encoderConfig
+}
+
 // sendAudioFrames 分时发送音频帧，避免撑爆客户端缓冲区
-func (h *ConnectionHandler) sendAudioFrames(audioData [][]byte, text string, round int) error {
+// reference 为与音频帧一一对应的回声消除参考信号，未开启回声消除时为 nil
+func (h *ConnectionHandler) sendAudioFrames(audioData [][]byte, reference [][]byte, text string, round int) error {
 	if len(audioData) == 0 {
 		return nil
 	}
@@ -178,6 +221,10 @@ func (h *ConnectionHandler) sendAudioFrames(audioData [][]byte, text string, rou
 		if err := h.responseSender.SendAudioFrame(audioData[i]); err != nil {
 			return fmt.Errorf("发送预缓冲音频帧失败: %v", err)
 		}
+		if i == 0 {
+			h.turnLatency.mark(round, markPlayback, false)
+		}
+		h.writeEchoReference(reference, i)
 		playPosition += h.serverAudioFrameDuration
 	}
 
@@ -227,6 +274,7 @@ func (h *ConnectionHandler) sendAudioFrames(audioData [][]byte, text string, rou
 		if err := h.responseSender.SendAudioFrame(chunk); err != nil {
 			return fmt.Errorf("发送音频帧失败: %v", err)
 		}
+		h.writeEchoReference(reference, i+preBufferFrames)
 
 		playPosition += h.serverAudioFrameDuration
 	}
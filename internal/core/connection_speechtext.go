@@ -0,0 +1,20 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/speechtext"
+)
+
+// initSpeechText 加载设备使用的播报文本后处理方案，后处理服务未初始化时使用内置方案
+func (h *ConnectionHandler) initSpeechText() {
+	svc := speechtext.Default()
+	if svc == nil {
+		h.speechText = speechtext.DefaultPipeline()
+		return
+	}
+	ctx, cancel := context.WithTimeout(h.tenantContext(), 3*time.Second)
+	defer cancel()
+	h.speechText = svc.PipelineFor(ctx, h.deviceID)
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+// turnTraceCollector 按轮次收集能力调用的追踪信息，随助手回复保存到对话记录，轮次失败时写入日志
+type turnTraceCollector struct {
+	mu     sync.Mutex
+	round  int
+	traces *capability.Traces
+}
+
+// forRound 返回轮次的收集器，新的轮次开始时替换；已经过去的轮次返回 nil，不再收集
+func (c *turnTraceCollector) forRound(round int) *capability.Traces {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if round < c.round {
+		return nil
+	}
+	if round > c.round || c.traces == nil {
+		c.round = round
+		c.traces = capability.NewTraces()
+	}
+	return c.traces
+}
+
+// list 返回轮次已完成的能力调用，不是当前轮次时返回 nil
+func (c *turnTraceCollector) list(round int) []capability.Trace {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if round != c.round {
+		return nil
+	}
+	return c.traces.List()
+}
+
+// withTurnTraces 返回收集该轮能力调用追踪信息的上下文
+func (h *ConnectionHandler) withTurnTraces(ctx context.Context, round int) context.Context {
+	return capability.WithTraces(ctx, h.turnTraces.forRound(round))
+}
+
+// logTurnTraces 轮次失败时记录已发生的能力调用，便于按提供者的请求ID查找提供者侧日志
+func (h *ConnectionHandler) logTurnTraces(round int) {
+	traces := h.turnTraces.list(round)
+	if len(traces) == 0 {
+		return
+	}
+	parts := make([]string, len(traces))
+	for i, trace := range traces {
+		part := fmt.Sprintf("%s/%s %dms", trace.Capability, trace.Provider, trace.DurationMs)
+		if len(trace.UpstreamRequestIDs) > 0 {
+			part += " upstream=" + strings.Join(trace.UpstreamRequestIDs, ",")
+		}
+		if trace.Error != "" {
+			part += " error=" + trace.Error
+		}
+		parts[i] = part
+	}
+	h.LogError(fmt.Sprintf("[追踪] [轮次 %d] 能力调用: %s", round, strings.Join(parts, "; ")))
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/domain/chat"
+	"xiaozhi-server-go/internal/domain/transcript"
+)
+
+// putMessage 写入对话历史，并同步记录到对话记录服务和会话监控；用户消息同时归档对应的语音并提交长期记忆提取
+func (h *ConnectionHandler) putMessage(msg chat.Message) {
+	h.dialogueManager.Put(msg)
+	h.recordSnippet(msg)
+	entryID := h.recordTranscript(msg)
+	if msg.Role == "user" {
+		h.archiveUtterance(entryID)
+		h.observeMemory(msg.Content, entryID)
+	}
+}
+
+// recordTranscript 将消息转换为对话记录，对话记录未启用时忽略；返回用户消息的记录ID
+func (h *ConnectionHandler) recordTranscript(msg chat.Message) string {
+	svc := transcript.Default()
+	if svc == nil {
+		return ""
+	}
+
+	var latency int64
+	if !h.roundStartTime.IsZero() {
+		latency = time.Since(h.roundStartTime).Milliseconds()
+	}
+	base := transcript.Entry{
+		SessionID: h.sessionID,
+		DeviceID:  h.deviceID,
+		Round:     h.talkRound,
+		LatencyMs: latency,
+	}
+
+	switch {
+	case len(msg.ToolCalls) > 0:
+		for _, call := range msg.ToolCalls {
+			entry := base
+			entry.Role = transcript.RoleTool
+			entry.ToolName = call.Function.Name
+			entry.ToolCallID = call.ID
+			entry.ToolArgs = call.Function.Arguments
+			svc.Record(&entry)
+		}
+	case msg.Role == "tool":
+		entry := base
+		entry.Role = transcript.RoleTool
+		entry.ToolCallID = msg.ToolCallID
+		entry.Content = msg.Content
+		svc.Record(&entry)
+	case msg.Role == "user" || msg.Role == "assistant":
+		if msg.Content == "" {
+			return ""
+		}
+		entry := base
+		entry.ID = uuid.New().String() // 预先分配ID，供语音归档关联
+		entry.Role = transcript.Role(msg.Role)
+		entry.Content = msg.Content
+		if entry.Role == transcript.RoleAssistant {
+			entry.Traces = h.turnTraces.list(h.talkRound)
+		}
+		svc.Record(&entry)
+		if entry.Role == transcript.RoleUser {
+			return entry.ID
+		}
+	}
+	return ""
+}
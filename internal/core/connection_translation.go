@@ -0,0 +1,203 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	domainllminter "xiaozhi-server-go/internal/domain/llm/inter"
+	"xiaozhi-server-go/internal/domain/translation"
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+// handleTranslationCommand 处理开启、切换和退出翻译模式的语音指令，不是指令或未启用翻译模式时返回 false
+func (h *ConnectionHandler) handleTranslationCommand(text string, round int) bool {
+	svc := translation.Default()
+	if svc == nil {
+		return false
+	}
+	cmd, ok := translation.ParseCommand(text)
+	if !ok {
+		return false
+	}
+
+	var reply string
+	switch cmd.Kind {
+	case translation.CommandStop:
+		if svc.Stop(h.deviceID) {
+			reply = "已退出翻译模式"
+		} else {
+			reply = "当前没有开启翻译模式"
+		}
+	case translation.CommandStart:
+		// 只说“翻译成日语”时保留当前会话的源语言
+		if current, ok := svc.Session(h.deviceID); ok && cmd.Source == "" {
+			cmd.Source = current.Source
+		}
+		session, err := svc.Start(h.deviceID, cmd.Source, cmd.Target)
+		if err != nil {
+			h.LogError(fmt.Sprintf("[翻译] 开启翻译模式失败: %v", err))
+			reply = "抱歉，暂不支持这种翻译"
+		} else {
+			reply = fmt.Sprintf("已开启翻译模式，%s翻译成%s", translation.LanguageName(session.Source), translation.LanguageName(session.Target))
+		}
+	}
+
+	h.LogInfo(fmt.Sprintf("[翻译] 语音指令: %s", reply))
+	h.tts_last_text_index = 1
+	if err := h.SpeakAndPlay(reply, 1, round); err != nil {
+		h.LogError(fmt.Sprintf("[翻译] 播放指令回复失败: %v", err))
+	}
+	return true
+}
+
+// inTranslation 设备当前是否处于翻译模式
+func (h *ConnectionHandler) inTranslation() bool {
+	svc := translation.Default()
+	if svc == nil {
+		return false
+	}
+	_, ok := svc.Session(h.deviceID)
+	return ok
+}
+
+// translationVoice 译文分段使用的目标语言音色，不是译文或目标语言未配置音色时返回空
+func (h *ConnectionHandler) translationVoice(round int) string {
+	if atomic.LoadInt32(&h.translationRound) != int32(round) {
+		return ""
+	}
+	svc := translation.Default()
+	if svc == nil {
+		return ""
+	}
+	session, ok := svc.Session(h.deviceID)
+	if !ok {
+		return ""
+	}
+	return svc.Voice(session.Target)
+}
+
+// endTranslation 连接关闭时结束翻译模式；同一设备已建立新连接时保留会话
+func (h *ConnectionHandler) endTranslation() {
+	svc := translation.Default()
+	if svc == nil || h.deviceID == "" {
+		return
+	}
+	if current, ok := FindActiveHandler(h.deviceID); ok && current != h {
+		return
+	}
+	svc.Stop(h.deviceID)
+}
+
+// translateTurn 翻译模式下的一轮：审核输入后翻译，用目标语言的音色播报，不记入对话历史
+func (h *ConnectionHandler) translateTurn(ctx context.Context, text string, round int) error {
+	svc := translation.Default()
+	session, ok := svc.Session(h.deviceID)
+	if !ok {
+		return nil
+	}
+
+	text, blocked := h.moderateInput(ctx, text)
+	if blocked {
+		h.tts_last_text_index = 1
+		if err := h.SpeakAndPlay(h.blockReply(), 1, round); err != nil {
+			h.LogError(fmt.Sprintf("[审核] 播放拦截回复失败: %v", err))
+		}
+		return nil
+	}
+
+	atomic.StoreInt32(&h.translationRound, int32(round))
+	atomic.StoreInt32(&h.serverVoiceStop, 0)
+	if !svc.HasProvider() {
+		return h.translateByLLM(ctx, text, session, round)
+	}
+
+	translated, err := svc.Translate(capability.WithPriority(ctx, capability.PriorityInteractive), text, session.Source, session.Target)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		h.LogError(fmt.Sprintf("[翻译] %v", err))
+		atomic.StoreInt32(&h.translationRound, -1)
+		h.tts_last_text_index = 1
+		h.SpeakAndPlay("抱歉，翻译服务暂时不可用", 1, round)
+		return err
+	}
+
+	segments := h.newSentenceStream()
+	textIndex := 0
+	for _, segment := range append(segments.Write(translated), segments.Flush()) {
+		if segment == "" {
+			continue
+		}
+		textIndex++
+		h.tts_last_text_index = textIndex
+		if err := h.SpeakAndPlay(segment, textIndex, round); err != nil {
+			h.LogError(fmt.Sprintf("[翻译] 播放译文失败: %v", err))
+		}
+	}
+	return nil
+}
+
+// translateByLLM 未配置翻译能力时使用设备当前的LLM翻译，译文按分段流式送入TTS
+func (h *ConnectionHandler) translateByLLM(ctx context.Context, text string, session translation.Session, round int) error {
+	atomic.StoreInt32(&h.llmGenerating, 1)
+	defer func() {
+		atomic.StoreInt32(&h.llmGenerating, 0)
+		// 与 genResponseByLLM 相同：音频已全部发送完时由这里清除讲话状态
+		if atomic.LoadInt32(&h.ttsPending) == 0 {
+			h.sendTTSMessage("stop", "", h.tts_last_text_index)
+			if h.closeAfterChat {
+				h.Close()
+			} else {
+				h.clearSpeakStatus()
+			}
+		}
+	}()
+
+	messages := []domainllminter.Message{
+		{Role: "system", Content: translation.Prompt(session.Source, session.Target)},
+		{Role: "user", Content: text},
+	}
+	responses, err := h.llmManager.Response(capability.WithPriority(ctx, capability.PriorityInteractive), h.sessionID, messages, nil)
+	if err != nil {
+		return fmt.Errorf("LLM翻译失败: %v", err)
+	}
+
+	segments := h.newSentenceStream()
+	textIndex := 0
+	speak := func(segment string) {
+		textIndex++
+		h.tts_last_text_index = textIndex
+		if err := h.SpeakAndPlay(segment, textIndex, round); err != nil {
+			h.LogError(fmt.Sprintf("[翻译] 播放译文失败: %v", err))
+		}
+	}
+	for response := range responses {
+		if ctx.Err() != nil {
+			go func() {
+				for range responses {
+				}
+			}()
+			return nil
+		}
+		if response.Error != nil {
+			h.LogError(fmt.Sprintf("[翻译] LLM响应错误: %v", response.Error))
+			atomic.StoreInt32(&h.translationRound, -1)
+			h.tts_last_text_index = textIndex + 1
+			h.SpeakAndPlay("抱歉，翻译服务暂时不可用", textIndex+1, round)
+			return fmt.Errorf("LLM响应错误: %s", response.Error)
+		}
+		if response.Content == "" {
+			continue
+		}
+		h.turnLatency.mark(round, markLLMFirstToken, false)
+		for _, segment := range segments.Write(response.Content) {
+			speak(segment)
+		}
+	}
+	if rest := segments.Flush(); rest != "" {
+		speak(rest)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/eventbus"
+)
+
+// activeHandlers 按设备ID索引的在线连接，用于服务端主动推送（提醒等）
+var activeHandlers = struct {
+	sync.RWMutex
+	byDevice map[string]*ConnectionHandler
+}{byDevice: make(map[string]*ConnectionHandler)}
+
+func registerActiveHandler(h *ConnectionHandler) {
+	// 文本对话会话没有设备连接，不能接收推送
+	if h.deviceID == "" || h.transportType == TransportTypeText {
+		return
+	}
+	activeHandlers.Lock()
+	activeHandlers.byDevice[h.deviceID] = h
+	activeHandlers.Unlock()
+}
+
+func unregisterActiveHandler(h *ConnectionHandler) {
+	if h.deviceID == "" || h.transportType == TransportTypeText {
+		return
+	}
+	activeHandlers.Lock()
+	// 同一设备可能已经建立了新连接，只移除自身
+	current, ok := activeHandlers.byDevice[h.deviceID]
+	offline := ok && current == h
+	if offline {
+		delete(activeHandlers.byDevice, h.deviceID)
+	}
+	activeHandlers.Unlock()
+
+	if offline {
+		eventbus.Publish(eventbus.EventDeviceOffline, eventbus.DeviceOfflineEventData{
+			DeviceID:    h.deviceID,
+			SessionID:   h.sessionID,
+			ConnectedAt: h.connectedAt,
+			Timestamp:   time.Now(),
+		})
+	}
+}
+
+// FindActiveHandler 查找设备当前的在线连接
+func FindActiveHandler(deviceID string) (*ConnectionHandler, bool) {
+	activeHandlers.RLock()
+	defer activeHandlers.RUnlock()
+	h, ok := activeHandlers.byDevice[deviceID]
+	return h, ok
+}
+
+// DeviceNotifier 通过设备在线连接播报通知，实现 reminder.Notifier
+type DeviceNotifier struct{}
+
+// NewDeviceNotifier 创建设备通知器
+func NewDeviceNotifier() *DeviceNotifier {
+	return &DeviceNotifier{}
+}
+
+// NotifyDevice 将文本排入设备连接的播报队列，入队即视为送达，设备不在线或队列已满时返回错误
+func (n *DeviceNotifier) NotifyDevice(ctx context.Context, deviceID string, text string) error {
+	h, ok := FindActiveHandler(deviceID)
+	if !ok {
+		return fmt.Errorf("设备 %s 不在线", deviceID)
+	}
+	return h.PushNotification(ctx, text)
+}
+
+// IsDeviceOnline 设备当前是否有在线连接
+func IsDeviceOnline(deviceID string) bool {
+	_, ok := FindActiveHandler(deviceID)
+	return ok
+}
@@ -0,0 +1,143 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/chat"
+	internalutils "xiaozhi-server-go/internal/utils"
+)
+
+// TransportTypeText 没有设备连接的文本对话会话的传输类型（Transport-Type 请求头）
+const TransportTypeText = "text"
+
+// TextChatResult 一轮文本对话的结果
+type TextChatResult struct {
+	SessionID string
+	DeviceID  string
+	Round     int
+	Reply     string   // 完整回复
+	Segments  []string // 按生成顺序的回复分段
+	Blocked   bool     // 输入被审核拦截
+}
+
+// textChatTurn 经HTTP提交的一轮文本对话
+type textChatTurn struct {
+	ctx       context.Context
+	text      string
+	onSegment func(string)
+	done      chan struct{}
+
+	mu     sync.Mutex
+	result TextChatResult
+	err    error
+}
+
+// emit 记录一个回复分段并回调调用方
+func (t *textChatTurn) emit(segment string) {
+	if segment == "" {
+		return
+	}
+	t.mu.Lock()
+	t.result.Segments = append(t.result.Segments, segment)
+	t.mu.Unlock()
+	if t.onSegment != nil {
+		t.onSegment(segment)
+	}
+}
+
+// ChatText 在当前连接上执行一轮文本对话
+//
+// 与语音对话共用对话流水线、对话历史、提示词和工具，回复不合成语音，而是按分段回调 onSegment
+// （可能为 nil）并在结束时返回。轮次排在语音轮次之后串行执行，ctx 取消时中止本轮生成。
+func (h *ConnectionHandler) ChatText(ctx context.Context, text string, onSegment func(string)) (*TextChatResult, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("聊天消息为空")
+	}
+	turn := &textChatTurn{ctx: ctx, text: text, onSegment: onSegment, done: make(chan struct{})}
+
+	select {
+	case h.textChatQueue <- turn:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-h.stopChan:
+		return nil, fmt.Errorf("会话已关闭")
+	}
+
+	select {
+	case <-turn.done:
+	case <-h.stopChan:
+		return nil, fmt.Errorf("会话已关闭")
+	}
+	if turn.err != nil {
+		return nil, turn.err
+	}
+	result := turn.result
+	result.Reply = strings.Join(result.Segments, "")
+	return &result, nil
+}
+
+// textChatTurn 返回轮次对应的文本对话，不是文本对话轮次时返回 nil
+func (h *ConnectionHandler) textChatTurn(round int) *textChatTurn {
+	h.roundMu.Lock()
+	defer h.roundMu.Unlock()
+	if h.textChat == nil || h.textChat.result.Round != round {
+		return nil
+	}
+	return h.textChat
+}
+
+// runTextChat 在语音轮次的协程中执行一轮文本对话
+func (h *ConnectionHandler) runTextChat(turn *textChatTurn) {
+	defer close(turn.done)
+	if turn.ctx.Err() != nil {
+		turn.err = turn.ctx.Err()
+		return
+	}
+
+	ctx := h.beginRound()
+	// 调用方断开时取消本轮生成
+	stop := context.AfterFunc(turn.ctx, h.cancelRound)
+	defer func() {
+		stop()
+		h.endRound()
+		h.roundMu.Lock()
+		h.textChat = nil
+		h.roundMu.Unlock()
+	}()
+
+	h.talkRound++
+	h.roundStartTime = time.Now()
+	round := h.talkRound
+	turn.result.SessionID = h.sessionID
+	turn.result.DeviceID = h.deviceID
+	turn.result.Round = round
+	h.roundMu.Lock()
+	h.textChat = turn
+	h.roundMu.Unlock()
+	h.LogInfo(fmt.Sprintf("[文本对话] [轮次 %d] %s", round, internalutils.SanitizeForLog(turn.text)))
+
+	result := h.runPipeline(ctx, turn.text)
+	if result.Blocked {
+		turn.result.Blocked = true
+		turn.emit(h.blockReply())
+		return
+	}
+
+	h.putMessage(chat.Message{
+		Role:    "user",
+		Content: result.Text,
+	})
+
+	if result.Reply != "" {
+		h.speakDirectReply(result.Reply, round)
+		return
+	}
+	if err := h.genResponseByLLM(ctx, withTurnContext(h.dialogueManager.GetLLMDialogueWithMemory(h.memoryPrompt(ctx, result.Text)), h.knowledgeContext(ctx, result.Text, result.Context)), round); err != nil {
+		turn.err = err
+	}
+}
@@ -15,6 +15,8 @@ import (
 	"xiaozhi-server-go/internal/domain/task"
 	"xiaozhi-server-go/internal/domain/device/repository"
 	"xiaozhi-server-go/internal/domain/device/aggregate"
+	"xiaozhi-server-go/internal/domain/tenant"
+	"xiaozhi-server-go/internal/platform/requestid"
 )
 
 // ConnectionContextAdapter 连接上下文适配器，完全兼容现有的ConnectionContext逻辑
@@ -42,7 +44,12 @@ func NewConnectionContextAdapter(
 	req *http.Request,
 ) *ConnectionContextAdapter {
 	clientID := conn.GetID()
-	connCtx, connCancel := context.WithCancelCause(context.Background())
+	// 连接上下文携带设备所属租户和关联ID，能力调用据此计量用量、解析提示词模板，并把关联ID传给插件
+	requestID := requestid.FromContext(req.Context())
+	connCtx, connCancel := context.WithCancelCause(requestid.WithID(tenant.WithID(context.Background(), tenant.IDFrom(req.Context())), requestID))
+	if requestID != "" {
+		logger = logger.With("request_id", requestID)
+	}
 
 	// 创建ConnectionHandler
 	// 创建ConnectionHandler，直接使用internal utils Logger
@@ -222,6 +229,11 @@ func (f *DefaultConnectionHandlerFactory) CreateHandler(
 					f.logger.WarnTag("连接", "设备 %s 已被禁用，拒绝连接", deviceID)
 					return nil
 				}
+				if svc := tenant.Default(); svc != nil && !svc.Active(req.Context(), device.TenantID) {
+					f.logger.WarnTag("连接", "设备 %s 所属租户 %s 已停用，拒绝连接", deviceID, device.TenantID)
+					return nil
+				}
+				req = req.WithContext(tenant.WithID(req.Context(), device.TenantID))
 			}
 		}
 	}
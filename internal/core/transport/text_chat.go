@@ -0,0 +1,217 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+
+	"xiaozhi-server-go/internal/core"
+	"xiaozhi-server-go/internal/domain/tenant"
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+var (
+	// ErrTextSessionLimit 无设备会话数已达上限
+	ErrTextSessionLimit = errors.New("text chat session limit reached")
+	// ErrTextSessionRejected 无法为请求创建会话（设备已禁用、租户已停用或资源池耗尽）
+	ErrTextSessionRejected = errors.New("text chat session rejected")
+)
+
+// TextChatRequest 文本对话请求
+type TextChatRequest struct {
+	DeviceID  string // 关联的设备，设备在线时进入设备当前的会话
+	SessionID string // 继续已有的无设备会话，为空时按设备ID生成或新建
+	Text      string
+}
+
+// textSession 不连接设备的文本对话会话，由连接处理器工厂创建，与设备连接走相同的初始化流程
+type textSession struct {
+	id         string
+	tenantID   string
+	handler    ConnectionHandler
+	core       *core.ConnectionHandler
+	lastActive atomic.Int64
+}
+
+// TextChat 文本对话入口，供没有麦克风的设备、配套应用和网页使用
+type TextChat struct {
+	factory ConnectionHandlerFactory
+	config  config.TextChatConfig
+	logger  *logging.Logger
+
+	mu       sync.Mutex
+	sessions map[string]*textSession
+}
+
+// NewTextChat 创建文本对话入口
+func NewTextChat(cfg config.TextChatConfig, factory ConnectionHandlerFactory, logger *logging.Logger) *TextChat {
+	return &TextChat{
+		factory:  factory,
+		config:   cfg,
+		logger:   logger,
+		sessions: make(map[string]*textSession),
+	}
+}
+
+// Chat 执行一轮文本对话，回复分段依次回调 onSegment（可能为 nil）
+// 关联的设备在线时与设备共用会话状态；否则使用无设备会话，同一设备或会话ID的多次请求共用对话历史
+func (t *TextChat) Chat(ctx context.Context, req TextChatRequest, onSegment func(string)) (*core.TextChatResult, error) {
+	if req.DeviceID != "" {
+		if h, ok := core.FindActiveHandler(req.DeviceID); ok {
+			return h.ChatText(ctx, req.Text, onSegment)
+		}
+	}
+
+	session, err := t.session(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	session.lastActive.Store(time.Now().UnixNano())
+	defer session.lastActive.Store(time.Now().UnixNano())
+	return session.core.ChatText(ctx, req.Text, onSegment)
+}
+
+// session 查找或创建无设备会话
+func (t *TextChat) session(ctx context.Context, req TextChatRequest) (*textSession, error) {
+	tenantID := tenant.IDFrom(ctx)
+	id := req.SessionID
+	if id == "" && req.DeviceID != "" {
+		// 与设备连接的默认会话ID一致
+		id = "device-" + strings.Replace(req.DeviceID, ":", "_", -1)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if id != "" {
+		if session, ok := t.sessions[id]; ok {
+			if session.tenantID != tenantID {
+				return nil, fmt.Errorf("%w: session %s belongs to another tenant", ErrTextSessionRejected, id)
+			}
+			return session, nil
+		}
+	} else {
+		id = "text-" + uuid.New().String()
+	}
+	if t.config.MaxSessions > 0 && len(t.sessions) >= t.config.MaxSessions {
+		return nil, ErrTextSessionLimit
+	}
+
+	httpReq, err := http.NewRequestWithContext(tenant.WithID(context.Background(), tenantID), http.MethodPost, "/api/v1/chat", nil)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Session-Id", id)
+	httpReq.Header.Set("Client-Id", id)
+	httpReq.Header.Set("Transport-Type", core.TransportTypeText)
+	if req.DeviceID != "" {
+		httpReq.Header.Set("Device-Id", req.DeviceID)
+	}
+
+	handler := t.factory.CreateHandler(newTextConnection(id), httpReq)
+	if handler == nil {
+		return nil, ErrTextSessionRejected
+	}
+	adapter, ok := handler.(*ConnectionContextAdapter)
+	if !ok || adapter.GetConnectionHandler() == nil {
+		handler.Close()
+		return nil, ErrTextSessionRejected
+	}
+	session := &textSession{id: id, tenantID: tenantID, handler: handler, core: adapter.GetConnectionHandler()}
+	session.lastActive.Store(time.Now().UnixNano())
+	t.sessions[id] = session
+	go handler.Handle()
+
+	t.logger.InfoTag("文本对话", "创建无设备会话 %s，设备=%s", id, req.DeviceID)
+	return session, nil
+}
+
+// Run 定期释放空闲的无设备会话，ctx 结束时释放全部会话
+func (t *TextChat) Run(ctx context.Context) error {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			t.closeIdle(0)
+			return nil
+		case <-ticker.C:
+			t.closeIdle(t.config.IdleTimeout)
+		}
+	}
+}
+
+// closeIdle 释放空闲超过 idle 的会话，idle 为 0 时释放全部
+func (t *TextChat) closeIdle(idle time.Duration) {
+	var expired []*textSession
+	t.mu.Lock()
+	for id, session := range t.sessions {
+		if idle > 0 && time.Since(time.Unix(0, session.lastActive.Load())) < idle {
+			continue
+		}
+		delete(t.sessions, id)
+		expired = append(expired, session)
+	}
+	t.mu.Unlock()
+
+	for _, session := range expired {
+		session.handler.Close()
+		t.logger.InfoTag("文本对话", "释放无设备会话 %s", session.id)
+	}
+}
+
+// textConnection 无设备会话的连接：不接收消息，发往设备的消息直接丢弃
+type textConnection struct {
+	id        string
+	createdAt time.Time
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newTextConnection(id string) *textConnection {
+	return &textConnection{id: id, createdAt: time.Now(), closed: make(chan struct{})}
+}
+
+func (c *textConnection) WriteMessage(messageType int, data []byte) error {
+	if c.IsClosed() {
+		return fmt.Errorf("connection closed")
+	}
+	return nil
+}
+
+func (c *textConnection) ReadMessage(stopChan <-chan struct{}) (int, []byte, error) {
+	select {
+	case <-stopChan:
+		return 0, nil, fmt.Errorf("connection closed by stop signal")
+	case <-c.closed:
+		return 0, nil, fmt.Errorf("connection closed")
+	}
+}
+
+func (c *textConnection) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return nil
+}
+
+func (c *textConnection) GetID() string                      { return c.id }
+func (c *textConnection) GetType() string                    { return core.TransportTypeText }
+func (c *textConnection) GetLastActiveTime() time.Time       { return c.createdAt }
+func (c *textConnection) IsStale(timeout time.Duration) bool { return false }
+func (c *textConnection) GetWebSocketConn() *websocket.Conn  { return nil }
+
+func (c *textConnection) IsClosed() bool {
+	select {
+	case <-c.closed:
+		return true
+	default:
+		return false
+	}
+}
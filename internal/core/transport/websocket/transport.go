@@ -5,7 +5,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
+	"xiaozhi-server-go/internal/domain/device/repository"
+	"xiaozhi-server-go/internal/domain/eventbus"
+	"xiaozhi-server-go/internal/domain/provisioning"
 	"xiaozhi-server-go/internal/platform/config"
 	"xiaozhi-server-go/internal/transport/ws"
 	"xiaozhi-server-go/internal/core/transport"
@@ -22,7 +26,8 @@ type WebSocketTransport struct {
 }
 
 // NewWebSocketTransport creates a websocket transport backed by the refactored internal package.
-func NewWebSocketTransport(cfg *config.Config, logger *logging.Logger) *WebSocketTransport {
+// deviceRepo resolves device auth statuses for the message ACL and may be nil.
+func NewWebSocketTransport(cfg *config.Config, logger *logging.Logger, deviceRepo repository.DeviceRepository) *WebSocketTransport {
 	if logger == nil {
 		logger = logging.DefaultLogger
 	}
@@ -38,7 +43,27 @@ func NewWebSocketTransport(cfg *config.Config, logger *logging.Logger) *WebSocke
 
 	// 直接使用internal utils Logger
 	hub := ws.NewHub(logger)
-	router := ws.NewRouter(hub, logger, ws.RouterOptions{})
+	sendBuffer := cfg.Transport.WebSocket.SendBuffer
+	opts := ws.RouterOptions{
+		SendBuffer: ws.SendBufferConfig{
+			Capacity:            sendBuffer.Capacity,
+			AudioPolicy:         ws.SendPolicy(sendBuffer.AudioPolicy),
+			WriteTimeout:        sendBuffer.WriteTimeout,
+			SlowConsumerTimeout: sendBuffer.SlowConsumerTimeout,
+		},
+		Authenticate: authenticateDevice,
+	}
+	if acl := cfg.Transport.WebSocket.MessageACL; acl.Enabled && deviceRepo != nil {
+		opts.MessageACL = ws.NewMessageACL(acl.PendingMessages)
+		opts.AuthStatus = deviceAuthStatus(deviceRepo, logger)
+		// 管理员修改认证状态后立即作用于设备已建立的连接
+		if err := eventbus.Subscribe(eventbus.EventDeviceAuthChanged, func(data eventbus.DeviceAuthEventData) {
+			hub.ApplyAuthStatus(data.DeviceID, data.Status)
+		}); err != nil {
+			logger.ErrorTag("WebSocket", "订阅设备认证状态事件失败: %v", err)
+		}
+	}
+	router := ws.NewRouter(hub, logger, opts)
 	addr := fmt.Sprintf("%s:%d", cfg.Server.IP, port)
 	server := ws.NewServer(
 		ws.ServerConfig{
@@ -71,6 +96,36 @@ func NewWebSocketTransport(cfg *config.Config, logger *logging.Logger) *WebSocke
 	return transport
 }
 
+// authenticateDevice checks the static token or signed JWT a QR-paired device sends as "Authorization: Bearer"; it is a no-op when provisioning is disabled.
+func authenticateDevice(req *http.Request, deviceID string) error {
+	service := provisioning.Default()
+	if service == nil {
+		return nil
+	}
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	return service.Authenticate(req.Context(), deviceID, strings.TrimSpace(token))
+}
+
+// deviceAuthStatus looks up the auth status of a registered device; unknown devices are left unrestricted
+// and lookup failures fall back to pending so a storage outage never grants full message access.
+func deviceAuthStatus(deviceRepo repository.DeviceRepository, logger *logging.Logger) func(req *http.Request, deviceID string) string {
+	return func(req *http.Request, deviceID string) string {
+		// 未携带设备ID或未注册的设备按待认证处理，只能发送配置允许的消息
+		if deviceID == "" {
+			return ws.AuthStatusPending
+		}
+		device, err := deviceRepo.FindByDeviceID(req.Context(), deviceID)
+		if err != nil {
+			logger.ErrorTag("WebSocket", "查询设备 %s 认证状态失败，按待认证处理: %v", deviceID, err)
+			return ws.AuthStatusPending
+		}
+		if device == nil || device.AuthStatus == "" {
+			return ws.AuthStatusPending
+		}
+		return string(device.AuthStatus)
+	}
+}
+
 // Start launches the websocket server.
 func (t *WebSocketTransport) Start(ctx context.Context) error {
 	return t.server.Start(ctx)
@@ -91,6 +146,11 @@ func (t *WebSocketTransport) GetActiveConnectionCount() (int, int) {
 	return t.server.Counts()
 }
 
+// SendBufferStats reports per-session outbound queue depth and drop counters.
+func (t *WebSocketTransport) SendBufferStats() map[string]ws.SendBufferStats {
+	return t.hub.SendBufferStats()
+}
+
 // GetType returns the transport identifier.
 func (t *WebSocketTransport) GetType() string {
 	return "websocket"
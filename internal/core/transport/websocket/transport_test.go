@@ -0,0 +1,54 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"xiaozhi-server-go/internal/domain/device/aggregate"
+	"xiaozhi-server-go/internal/domain/device/repository"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/ws"
+)
+
+// stubDeviceRepo 只实现认证状态查询用到的 FindByDeviceID
+type stubDeviceRepo struct {
+	repository.DeviceRepository
+	device *aggregate.Device
+	err    error
+}
+
+func (r *stubDeviceRepo) FindByDeviceID(ctx context.Context, deviceID string) (*aggregate.Device, error) {
+	return r.device, r.err
+}
+
+func TestDeviceAuthStatus(t *testing.T) {
+	logger, err := logging.New(logging.Config{Level: "error", Dir: t.TempDir(), Filename: "test.log"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		deviceID string
+		repo     *stubDeviceRepo
+		want     string
+	}{
+		{"no device id", "", &stubDeviceRepo{}, ws.AuthStatusPending},
+		{"unregistered", "aa:bb", &stubDeviceRepo{}, ws.AuthStatusPending},
+		{"no status", "aa:bb", &stubDeviceRepo{device: &aggregate.Device{}}, ws.AuthStatusPending},
+		{"approved", "aa:bb", &stubDeviceRepo{device: &aggregate.Device{AuthStatus: aggregate.DeviceStatusApproved}}, ws.AuthStatusApproved},
+		{"pending", "aa:bb", &stubDeviceRepo{device: &aggregate.Device{AuthStatus: aggregate.DeviceStatusPending}}, ws.AuthStatusPending},
+		{"rejected", "aa:bb", &stubDeviceRepo{device: &aggregate.Device{AuthStatus: aggregate.DeviceStatusRejected}}, ws.AuthStatusRejected},
+		{"lookup failure", "aa:bb", &stubDeviceRepo{err: errors.New("database is down")}, ws.AuthStatusPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/xiaozhi/v1/", nil)
+			if got := deviceAuthStatus(tt.repo, logger)(req, tt.deviceID); got != tt.want {
+				t.Fatalf("deviceAuthStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
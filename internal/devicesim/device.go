@@ -0,0 +1,203 @@
+package devicesim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+
+	"xiaozhi-server-go/internal/domain/protocol"
+)
+
+// simError 带分类的错误
+type simError struct {
+	kind string
+	err  error
+}
+
+func (e *simError) Error() string { return e.kind + ": " + e.err.Error() }
+func (e *simError) Unwrap() error { return e.err }
+
+func fail(kind string, format string, args ...interface{}) error {
+	return &simError{kind: kind, err: fmt.Errorf(format, args...)}
+}
+
+// errorKind 返回错误的分类
+func errorKind(err error) string {
+	var se *simError
+	if errors.As(err, &se) {
+		return se.kind
+	}
+	return ErrorClosed
+}
+
+// serverMessage 服务端文本消息中用到的字段
+type serverMessage struct {
+	Type   string `json:"type"`
+	State  string `json:"state"`
+	Status string `json:"status"`
+	Error  *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// device 单个模拟设备，对话按轮次串行进行
+type device struct {
+	id   string
+	opts *Options
+	conn *websocket.Conn
+	stop func() bool // 取消 ctx 结束时关闭连接的回调
+}
+
+// connect 建立连接并完成 hello 握手，返回耗时
+func (d *device) connect(ctx context.Context) (time.Duration, error) {
+	startedAt := time.Now()
+	header := http.Header{}
+	header.Set("Device-Id", d.id)
+	header.Set("Client-Id", uuid.New().String())
+	header.Set("Protocol-Version", strconv.Itoa(protocol.MaxVersion))
+
+	dialer := websocket.Dialer{HandshakeTimeout: d.opts.ConnectTimeout}
+	dialCtx, cancel := context.WithTimeout(ctx, d.opts.ConnectTimeout)
+	defer cancel()
+	conn, _, err := dialer.DialContext(dialCtx, d.opts.URL, header)
+	if err != nil {
+		return 0, fail(ErrorConnect, "%v", err)
+	}
+	d.conn = conn
+	d.stop = context.AfterFunc(ctx, func() { _ = conn.Close() })
+
+	hello := map[string]interface{}{
+		"type":         "hello",
+		"version":      protocol.MaxVersion,
+		"transport":    protocol.TransportWebSocket,
+		"audio_params": d.opts.AudioParams,
+	}
+	if err := d.sendJSON(hello); err != nil {
+		return 0, fail(ErrorHandshake, "发送 hello 失败: %v", err)
+	}
+
+	_ = conn.SetReadDeadline(startedAt.Add(d.opts.ConnectTimeout))
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			return 0, fail(ErrorHandshake, "等待 hello 回复失败: %v", err)
+		}
+		if messageType != websocket.TextMessage {
+			continue
+		}
+		var msg serverMessage
+		if json.Unmarshal(data, &msg) != nil || msg.Type != "hello" {
+			continue
+		}
+		if msg.Status == "error" && msg.Error != nil {
+			return 0, fail(ErrorHandshake, "握手被拒绝 %s: %s", msg.Error.Code, msg.Error.Message)
+		}
+		return time.Since(startedAt), nil
+	}
+}
+
+// turn 进行一轮对话：发送语音或文本，等待回复结束
+func (d *device) turn(ctx context.Context) (turnResult, error) {
+	var result turnResult
+	if d.opts.Text != "" {
+		if err := d.sendJSON(map[string]interface{}{"type": "listen", "state": "detect", "text": d.opts.Text}); err != nil {
+			return result, fail(ErrorSend, "发送文本失败: %v", err)
+		}
+	} else if err := d.sendAudio(ctx); err != nil {
+		return result, err
+	}
+
+	stoppedAt := time.Now()
+	_ = d.conn.SetReadDeadline(stoppedAt.Add(d.opts.TurnTimeout))
+	for {
+		messageType, data, err := d.conn.ReadMessage()
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				return result, fail(ErrorTimeout, "%s 内未收到回复结束", d.opts.TurnTimeout)
+			}
+			return result, fail(ErrorClosed, "%v", err)
+		}
+		elapsed := time.Since(stoppedAt)
+		if messageType == websocket.BinaryMessage {
+			if result.firstAudio == 0 {
+				result.firstAudio = elapsed
+			}
+			continue
+		}
+
+		var msg serverMessage
+		if json.Unmarshal(data, &msg) != nil {
+			continue
+		}
+		switch {
+		case msg.Type == "stt" && result.asr == 0:
+			result.asr = elapsed
+		case msg.Type == "tts" && msg.State == "stop":
+			if result.firstAudio == 0 {
+				return result, fail(ErrorNoAudio, "回复结束但未收到音频")
+			}
+			result.complete = elapsed
+			return result, nil
+		}
+	}
+}
+
+// sendAudio 以手动拾音模式发送一轮语音
+func (d *device) sendAudio(ctx context.Context) error {
+	if err := d.sendJSON(map[string]interface{}{"type": "listen", "state": "start", "mode": "manual"}); err != nil {
+		return fail(ErrorSend, "发送 listen start 失败: %v", err)
+	}
+
+	var ticker *time.Ticker
+	if d.opts.Realtime {
+		ticker = time.NewTicker(time.Duration(d.opts.AudioParams.FrameDuration) * time.Millisecond)
+		defer ticker.Stop()
+	}
+	for _, packet := range d.opts.Audio {
+		if ticker != nil {
+			select {
+			case <-ctx.Done():
+				return fail(ErrorClosed, "%v", ctx.Err())
+			case <-ticker.C:
+			}
+		}
+		if err := d.conn.WriteMessage(websocket.BinaryMessage, packet); err != nil {
+			return fail(ErrorSend, "发送音频失败: %v", err)
+		}
+	}
+
+	if err := d.sendJSON(map[string]interface{}{"type": "listen", "state": "stop"}); err != nil {
+		return fail(ErrorSend, "发送 listen stop 失败: %v", err)
+	}
+	return nil
+}
+
+func (d *device) sendJSON(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return d.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// close 关闭连接
+func (d *device) close() {
+	if d.stop != nil {
+		d.stop()
+		d.stop = nil
+	}
+	if d.conn != nil {
+		_ = d.conn.Close()
+		d.conn = nil
+	}
+}
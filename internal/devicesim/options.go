@@ -0,0 +1,85 @@
+// Package devicesim 模拟设备压测
+//
+// 按设备协议启动多个 WebSocket 模拟设备：握手后按轮次发送预先编码的语音（手动拾音模式，
+// 发送完毕后 listen stop），或以 listen detect 直接发送文本，记录从说话结束到识别结果、
+// 首帧音频和回复结束的耗时。运行结束后汇总成功率、错误分类和各阶段耗时百分位数，
+// 报告可序列化为 JSON，用于自动化容量评估。
+package devicesim
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/protocol"
+)
+
+// Options 压测参数
+type Options struct {
+	URL            string        // WebSocket 地址，如 ws://127.0.0.1:8000/
+	Devices        int           // 模拟设备数
+	Turns          int           // 每个设备的对话轮数
+	DeviceIDPrefix string        // 设备ID前缀，设备ID为前缀加序号
+	RampUp         time.Duration // 在该时长内均匀启动全部设备，0 表示同时启动
+	ThinkTime      time.Duration // 同一设备两轮之间的间隔
+	TurnTimeout    time.Duration // 单轮从发送结束到回复结束的超时
+	ConnectTimeout time.Duration // 建立连接和握手的超时
+
+	Audio       [][]byte             // 每轮发送的 opus 数据包
+	AudioParams protocol.AudioParams // 上行音频参数，在 hello 中声明
+	Realtime    bool                 // 按帧时长实时发送音频，为 false 时尽快发送
+	Text        string               // 非空时以文本代替音频，不经过 ASR
+}
+
+// 缺省参数
+const (
+	defaultTurnTimeout    = 30 * time.Second
+	defaultConnectTimeout = 10 * time.Second
+	defaultDeviceIDPrefix = "sim-"
+)
+
+// normalize 校验参数并填充缺省值
+func (o *Options) normalize() error {
+	if !strings.HasPrefix(o.URL, "ws://") && !strings.HasPrefix(o.URL, "wss://") {
+		return fmt.Errorf("WebSocket 地址必须以 ws:// 或 wss:// 开头: %q", o.URL)
+	}
+	if o.Devices <= 0 {
+		return fmt.Errorf("设备数必须大于 0")
+	}
+	if o.Turns <= 0 {
+		return fmt.Errorf("对话轮数必须大于 0")
+	}
+	if o.Text == "" && len(o.Audio) == 0 {
+		return fmt.Errorf("需要提供音频或文本")
+	}
+	if o.DeviceIDPrefix == "" {
+		o.DeviceIDPrefix = defaultDeviceIDPrefix
+	}
+	if o.TurnTimeout <= 0 {
+		o.TurnTimeout = defaultTurnTimeout
+	}
+	if o.ConnectTimeout <= 0 {
+		o.ConnectTimeout = defaultConnectTimeout
+	}
+	if o.AudioParams.Format == "" {
+		o.AudioParams.Format = protocol.CodecOpus
+	}
+	if o.AudioParams.SampleRate == 0 {
+		o.AudioParams.SampleRate = 16000
+	}
+	if o.AudioParams.Channels == 0 {
+		o.AudioParams.Channels = 1
+	}
+	if o.AudioParams.FrameDuration == 0 {
+		o.AudioParams.FrameDuration = 60
+	}
+	return nil
+}
+
+// mode 发送方式，写入报告
+func (o *Options) mode() string {
+	if o.Text != "" {
+		return "text"
+	}
+	return "audio"
+}
@@ -0,0 +1,194 @@
+package devicesim
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// 错误分类
+const (
+	ErrorConnect   = "connect"   // 建立 WebSocket 连接失败
+	ErrorHandshake = "handshake" // hello 握手失败或被拒绝
+	ErrorSend      = "send"      // 发送消息或音频失败
+	ErrorTimeout   = "timeout"   // 超时未收到回复结束
+	ErrorClosed    = "closed"    // 对话过程中连接被关闭
+	ErrorNoAudio   = "no_audio"  // 回复结束但没有收到音频
+)
+
+// Report 压测报告
+type Report struct {
+	URL              string        `json:"url"`
+	Mode             string        `json:"mode"` // audio 或 text
+	Devices          int           `json:"devices"`
+	TurnsPerDevice   int           `json:"turns_per_device"`
+	StartedAt        time.Time     `json:"started_at"`
+	DurationMs       int64         `json:"duration_ms"`
+	ConnectedDevices int           `json:"connected_devices"` // 至少成功连接过一次的设备数
+	Reconnects       int           `json:"reconnects"`        // 某轮失败后重新建立的连接数
+	Turns            int           `json:"turns"`             // 已执行的轮数，连接失败时其后未执行的轮次计为失败
+	Succeeded        int           `json:"succeeded"`
+	Failed           int           `json:"failed"`
+	ErrorRate        float64       `json:"error_rate"`
+	TurnsPerSecond   float64       `json:"turns_per_second"` // 成功轮次的吞吐
+	Latency          LatencyReport `json:"latency"`
+	Errors           []ErrorCount  `json:"errors,omitempty"`
+}
+
+// LatencyReport 各阶段耗时，均从说话结束（发送 listen stop 或文本）开始计时，连接耗时除外
+type LatencyReport struct {
+	Connect    LatencyStats `json:"connect"`     // 建立连接到收到 hello 回复
+	ASR        LatencyStats `json:"asr"`         // 到收到识别结果，文本模式为空
+	FirstAudio LatencyStats `json:"first_audio"` // 到收到首帧音频（端到端）
+	Complete   LatencyStats `json:"complete"`    // 到收到 tts stop
+}
+
+// LatencyStats 耗时统计（毫秒）
+type LatencyStats struct {
+	Count int     `json:"count"`
+	AvgMs float64 `json:"avg_ms"`
+	MinMs int64   `json:"min_ms"`
+	P50Ms int64   `json:"p50_ms"`
+	P90Ms int64   `json:"p90_ms"`
+	P95Ms int64   `json:"p95_ms"`
+	P99Ms int64   `json:"p99_ms"`
+	MaxMs int64   `json:"max_ms"`
+}
+
+// ErrorCount 某类错误的次数及一条示例
+type ErrorCount struct {
+	Kind    string `json:"kind"`
+	Count   int    `json:"count"`
+	Example string `json:"example"`
+}
+
+// turnResult 单轮结果
+type turnResult struct {
+	asr        time.Duration // 未收到识别结果时为 0
+	firstAudio time.Duration
+	complete   time.Duration
+}
+
+// collector 并发收集各设备的结果
+type collector struct {
+	mu         sync.Mutex
+	connected  int
+	reconnects int
+	succeeded  int
+	failed     int
+	connect    []int64
+	asr        []int64
+	firstAudio []int64
+	complete   []int64
+	errors     map[string]*ErrorCount
+}
+
+func newCollector() *collector {
+	return &collector{errors: make(map[string]*ErrorCount)}
+}
+
+// addConnect 记录连接耗时，reconnect 表示设备此前已连接过
+func (c *collector) addConnect(d time.Duration, reconnect bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if reconnect {
+		c.reconnects++
+	} else {
+		c.connected++
+	}
+	c.connect = append(c.connect, d.Milliseconds())
+}
+
+func (c *collector) addTurn(r turnResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.succeeded++
+	if r.asr > 0 {
+		c.asr = append(c.asr, r.asr.Milliseconds())
+	}
+	c.firstAudio = append(c.firstAudio, r.firstAudio.Milliseconds())
+	c.complete = append(c.complete, r.complete.Milliseconds())
+}
+
+// addFailure 记录失败，turns 为因此失败的轮数
+func (c *collector) addFailure(kind string, err error, turns int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failed += turns
+	stat, ok := c.errors[kind]
+	if !ok {
+		stat = &ErrorCount{Kind: kind, Example: err.Error()}
+		c.errors[kind] = stat
+	}
+	stat.Count += turns
+}
+
+// report 生成报告
+func (c *collector) report(opts *Options, startedAt time.Time, elapsed time.Duration) *Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	report := &Report{
+		URL:              opts.URL,
+		Mode:             opts.mode(),
+		Devices:          opts.Devices,
+		TurnsPerDevice:   opts.Turns,
+		StartedAt:        startedAt,
+		DurationMs:       elapsed.Milliseconds(),
+		ConnectedDevices: c.connected,
+		Reconnects:       c.reconnects,
+		Turns:            c.succeeded + c.failed,
+		Succeeded:        c.succeeded,
+		Failed:           c.failed,
+		Latency: LatencyReport{
+			Connect:    summarize(c.connect),
+			ASR:        summarize(c.asr),
+			FirstAudio: summarize(c.firstAudio),
+			Complete:   summarize(c.complete),
+		},
+	}
+	if report.Turns > 0 {
+		report.ErrorRate = float64(report.Failed) / float64(report.Turns)
+	}
+	if elapsed > 0 {
+		report.TurnsPerSecond = float64(c.succeeded) / elapsed.Seconds()
+	}
+	for _, stat := range c.errors {
+		report.Errors = append(report.Errors, *stat)
+	}
+	sort.Slice(report.Errors, func(i, j int) bool {
+		if report.Errors[i].Count != report.Errors[j].Count {
+			return report.Errors[i].Count > report.Errors[j].Count
+		}
+		return report.Errors[i].Kind < report.Errors[j].Kind
+	})
+	return report
+}
+
+// summarize 计算耗时统计，没有样本时各项为 0
+func summarize(values []int64) LatencyStats {
+	if len(values) == 0 {
+		return LatencyStats{}
+	}
+	sorted := append([]int64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var sum int64
+	for _, v := range sorted {
+		sum += v
+	}
+	return LatencyStats{
+		Count: len(sorted),
+		AvgMs: float64(sum) / float64(len(sorted)),
+		MinMs: sorted[0],
+		P50Ms: percentile(sorted, 0.50),
+		P90Ms: percentile(sorted, 0.90),
+		P95Ms: percentile(sorted, 0.95),
+		P99Ms: percentile(sorted, 0.99),
+		MaxMs: sorted[len(sorted)-1],
+	}
+}
+
+// percentile 返回已排序样本的百分位数
+func percentile(sorted []int64, p float64) int64 {
+	return sorted[int(float64(len(sorted)-1)*p)]
+}
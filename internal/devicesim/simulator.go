@@ -0,0 +1,87 @@
+package devicesim
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Run 按参数启动模拟设备并等待全部完成，ctx 取消时中断进行中的对话并返回已收集的结果
+func Run(ctx context.Context, opts Options) (*Report, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+
+	results := newCollector()
+	startedAt := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Devices; i++ {
+		delay := time.Duration(0)
+		if opts.Devices > 1 {
+			delay = opts.RampUp * time.Duration(i) / time.Duration(opts.Devices)
+		}
+		d := &device{id: fmt.Sprintf("%s%04d", opts.DeviceIDPrefix, i+1), opts: &opts}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !sleep(ctx, delay) {
+				return
+			}
+			runDevice(ctx, d, results)
+		}()
+	}
+	wg.Wait()
+
+	return results.report(&opts, startedAt, time.Since(startedAt)), nil
+}
+
+// runDevice 依次进行设备的全部轮次；某轮失败后重新连接再继续，连接失败时其余轮次计为失败
+func runDevice(ctx context.Context, d *device, results *collector) {
+	defer d.close()
+	connected := false
+	for turn := 0; turn < d.opts.Turns; turn++ {
+		if turn > 0 && !sleep(ctx, d.opts.ThinkTime) {
+			return
+		}
+		if d.conn == nil {
+			elapsed, err := d.connect(ctx)
+			if err != nil {
+				d.close()
+				if ctx.Err() == nil {
+					results.addFailure(errorKind(err), err, d.opts.Turns-turn)
+				}
+				return
+			}
+			results.addConnect(elapsed, connected)
+			connected = true
+		}
+
+		result, err := d.turn(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			results.addFailure(errorKind(err), err, 1)
+			d.close()
+			continue
+		}
+		results.addTurn(result)
+	}
+}
+
+// sleep 等待指定时长，ctx 先结束时返回 false
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
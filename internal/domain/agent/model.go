@@ -0,0 +1,47 @@
+// Package agent 多智能体编排
+//
+// 每个智能体有自己的提示词、模型和工具白名单。配置了可委托智能体的智能体作为调度者，
+// 通过 delegate_to_agent 工具把子任务交给其他智能体，再汇总结果回复。
+// 智能体可以通过接口直接运行，也可以作为工作流节点和设备对话中的 MCP 工具调用。
+package agent
+
+import "time"
+
+// Agent 智能体定义
+type Agent struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"` // 唯一名称，委托和调用时使用
+	Description string    `json:"description"`
+	Prompt      string    `json:"prompt"`    // 系统提示词
+	Model       string    `json:"model"`     // LLM 配置名称，为空时使用默认LLM
+	Tools       []string  `json:"tools"`     // 允许调用的 MCP 工具
+	Delegates   []string  `json:"delegates"` // 可以委托子任务的智能体名称
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// StepType 运行步骤类型
+type StepType string
+
+const (
+	StepTool     StepType = "tool"     // 调用 MCP 工具
+	StepDelegate StepType = "delegate" // 委托给其他智能体
+)
+
+// Step 一次运行中的一个工具调用或委托，按发生顺序记录，被委托智能体的步骤紧随委托步骤之后
+type Step struct {
+	Agent     string        `json:"agent"` // 发起调用的智能体
+	Type      StepType      `json:"type"`
+	Name      string        `json:"name"` // 工具名称或被委托的智能体名称
+	Arguments string        `json:"arguments"`
+	Result    string        `json:"result"`
+	Error     string        `json:"error,omitempty"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// RunResult 一次运行的结果
+type RunResult struct {
+	Agent string `json:"agent"`
+	Reply string `json:"reply"`
+	Steps []Step `json:"steps"`
+}
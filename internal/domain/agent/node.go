@@ -0,0 +1,71 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/workflow"
+)
+
+// NodeType 工作流智能体节点类型
+const NodeType workflow.NodeType = "agent"
+
+// RegisterWorkflowNode 将智能体节点注册到工作流节点注册表
+// 节点配置 agent 为智能体名称，task 为固定的任务说明；节点输入 text 附在任务说明之后交给智能体
+func RegisterWorkflowNode(nodes *workflow.NodeRegistry, service *Service) error {
+	return nodes.Register(workflow.NodeTypeDefinition{
+		Type:        NodeType,
+		Name:        "智能体",
+		Description: "运行已定义的智能体，智能体按需调用工具或委托其他智能体后给出回复",
+		ConfigSchema: capability.Schema{
+			Type: "object",
+			Properties: map[string]capability.Property{
+				"agent": {Type: "string", Description: "智能体名称"},
+				"task":  {Type: "string", Description: "任务说明，输入文本附在其后"},
+			},
+			Required: []string{"agent"},
+		},
+		InputSchema: capability.Schema{
+			Type: "object",
+			Properties: map[string]capability.Property{
+				"text": {Type: "string", Description: "交给智能体的文本"},
+			},
+		},
+		OutputSchema: capability.Schema{
+			Type: "object",
+			Properties: map[string]capability.Property{
+				"text":  {Type: "string", Description: "智能体的回复"},
+				"steps": {Type: "integer", Description: "工具调用和委托次数"},
+			},
+		},
+		UI: &capability.UIHints{Category: "ai", Icon: "robot"},
+	}, workflow.NodeExecutorFunc(func(ctx context.Context, node *workflow.Node, inputs map[string]interface{}) (map[string]interface{}, error) {
+		return executeNode(ctx, service, node, inputs)
+	}))
+}
+
+func executeNode(ctx context.Context, service *Service, node *workflow.Node, inputs map[string]interface{}) (map[string]interface{}, error) {
+	name, _ := node.Config["agent"].(string)
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("agent node %s requires config.agent", node.ID)
+	}
+
+	var parts []string
+	if task, _ := node.Config["task"].(string); strings.TrimSpace(task) != "" {
+		parts = append(parts, strings.TrimSpace(task))
+	}
+	if text, _ := inputs["text"].(string); strings.TrimSpace(text) != "" {
+		parts = append(parts, strings.TrimSpace(text))
+	}
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("agent node %s has neither config.task nor input text", node.ID)
+	}
+
+	result, err := service.Run(ctx, name, strings.Join(parts, "\n\n"))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"text": result.Reply, "steps": len(result.Steps)}, nil
+}
@@ -0,0 +1,24 @@
+package agent
+
+import "context"
+
+// Repository 智能体仓库接口
+type Repository interface {
+	// Save 新建智能体
+	Save(ctx context.Context, a *Agent) error
+
+	// Update 更新智能体
+	Update(ctx context.Context, a *Agent) error
+
+	// FindByID 根据ID查找智能体，不存在时返回 nil
+	FindByID(ctx context.Context, id string) (*Agent, error)
+
+	// FindByName 根据名称查找智能体，不存在时返回 nil
+	FindByName(ctx context.Context, name string) (*Agent, error)
+
+	// List 查询全部智能体
+	List(ctx context.Context) ([]*Agent, error)
+
+	// Delete 删除智能体
+	Delete(ctx context.Context, id string) error
+}
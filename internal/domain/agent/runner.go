@@ -0,0 +1,349 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sashabaranov/go-openai"
+
+	domainllm "xiaozhi-server-go/internal/domain/llm"
+	"xiaozhi-server-go/internal/domain/llm/inter"
+	"xiaozhi-server-go/internal/domain/toolpolicy"
+	"xiaozhi-server-go/internal/platform/errors"
+	internalutils "xiaozhi-server-go/internal/utils"
+)
+
+const (
+	// DelegateTool 调度者委托子任务的工具名称
+	DelegateTool = "delegate_to_agent"
+
+	// maxSteps 单个智能体一次运行最多的 LLM 调用次数
+	maxSteps = 8
+	// maxDelegationDepth 委托的最大层数，达到后不再提供委托工具
+	maxDelegationDepth = 3
+	// runTimeout 一次运行（含全部委托）的时间上限
+	runTimeout = 2 * time.Minute
+)
+
+// Tools 智能体可调用的 MCP 工具，由全局 MCP 管理器提供
+type Tools interface {
+	GetAvailableTools() []openai.Tool
+	ExecuteTool(ctx context.Context, name string, args map[string]any) (any, error)
+}
+
+// Run 运行智能体，返回最终回复和按顺序记录的工具调用与委托
+func (s *Service) Run(ctx context.Context, name, input string) (*RunResult, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, errors.New(errors.KindDomain, "agent.run", "input is required")
+	}
+	a, err := s.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, runTimeout)
+	defer cancel()
+
+	result := &RunResult{Agent: a.Name, Steps: []Step{}}
+	reply, err := s.run(ctx, a, input, 0, result)
+	if err != nil {
+		return nil, err
+	}
+	result.Reply = reply
+	s.logger.InfoTag("智能体", "智能体 %s 运行完成，工具调用和委托 %d 次", a.Name, len(result.Steps))
+	return result, nil
+}
+
+// run 执行智能体的对话循环：LLM 请求工具时执行工具并把结果交回 LLM，直到 LLM 直接回复
+func (s *Service) run(ctx context.Context, a *Agent, input string, depth int, trace *RunResult) (string, error) {
+	manager, err := s.newLLM(a)
+	if err != nil {
+		return "", err
+	}
+	tools := s.availableTools(ctx, a, depth)
+	// 智能体调用工具时同时按设备策略和智能体策略检查
+	ctx = toolpolicy.WithAgent(ctx, a.Name)
+
+	messages := []inter.Message{
+		{Role: "system", Content: a.Prompt},
+		{Role: "user", Content: input},
+	}
+	sessionID := "agent-" + a.Name + "-" + uuid.New().String()
+	for step := 0; step < maxSteps; step++ {
+		content, calls, err := complete(ctx, manager, sessionID, messages, tools)
+		if err != nil {
+			return "", fmt.Errorf("智能体 %s 调用LLM失败: %w", a.Name, err)
+		}
+		if len(calls) == 0 {
+			return strings.TrimSpace(content), nil
+		}
+
+		messages = append(messages, inter.Message{Role: "assistant", Content: content, ToolCalls: calls})
+		for _, call := range calls {
+			messages = append(messages, inter.Message{
+				Role:       "tool",
+				ToolCallID: call.ID,
+				Content:    s.callTool(ctx, a, call, depth, trace),
+			})
+		}
+	}
+	return "", errors.New(errors.KindDomain, "agent.run", fmt.Sprintf("agent %s did not finish within %d steps", a.Name, maxSteps))
+}
+
+// newLLM 按智能体配置的模型创建 LLM，未配置时使用默认LLM
+func (s *Service) newLLM(a *Agent) (*domainllm.Manager, error) {
+	if s.cfg == nil {
+		return nil, fmt.Errorf("智能体 %s 没有可用的LLM配置", a.Name)
+	}
+	name := a.Model
+	if name == "" {
+		name = s.cfg.Selected.LLM
+	}
+	llmCfg, ok := s.cfg.LLM[name]
+	if !ok {
+		return nil, errors.New(errors.KindDomain, "agent.run", fmt.Sprintf("agent %s uses unknown model %s", a.Name, name))
+	}
+	return domainllm.NewManager(inter.LLMConfig{
+		Provider:    llmCfg.Type,
+		Model:       llmCfg.ModelName,
+		APIKey:      llmCfg.APIKey,
+		BaseURL:     llmCfg.BaseURL,
+		Temperature: float32(llmCfg.Temperature),
+		MaxTokens:   llmCfg.MaxTokens,
+		Timeout:     60,
+	}), nil
+}
+
+// availableTools 返回白名单中的 MCP 工具，有可委托智能体且未达到委托层数上限时加上委托工具
+func (s *Service) availableTools(ctx context.Context, a *Agent, depth int) []inter.Tool {
+	var tools []inter.Tool
+	if len(a.Tools) > 0 && s.tools != nil {
+		found := make(map[string]bool, len(a.Tools))
+		for _, tool := range s.tools.GetAvailableTools() {
+			if tool.Function == nil || !contains(a.Tools, tool.Function.Name) {
+				continue
+			}
+			found[tool.Function.Name] = true
+			tools = append(tools, inter.Tool{
+				Type: "function",
+				Function: inter.ToolFunction{
+					Name:        tool.Function.Name,
+					Description: tool.Function.Description,
+					Parameters:  tool.Function.Parameters,
+				},
+			})
+		}
+		for _, name := range a.Tools {
+			if !found[name] {
+				s.logger.WarnTag("智能体", "智能体 %s 的工具 %s 当前不可用", a.Name, name)
+			}
+		}
+	}
+
+	if len(a.Delegates) > 0 && depth < maxDelegationDepth {
+		if tool, ok := s.delegateTool(ctx, a); ok {
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+// delegateTool 生成委托工具，描述中列出可委托智能体的用途
+func (s *Service) delegateTool(ctx context.Context, a *Agent) (inter.Tool, bool) {
+	names := make([]string, 0, len(a.Delegates))
+	var desc strings.Builder
+	desc.WriteString("把子任务交给更擅长的智能体完成并取回结果。可委托的智能体：")
+	for _, name := range a.Delegates {
+		delegate, err := s.repo.FindByName(ctx, name)
+		if err != nil || delegate == nil {
+			continue
+		}
+		names = append(names, delegate.Name)
+		fmt.Fprintf(&desc, "\n- %s：%s", delegate.Name, delegate.Description)
+	}
+	if len(names) == 0 {
+		return inter.Tool{}, false
+	}
+	return inter.Tool{
+		Type: "function",
+		Function: inter.ToolFunction{
+			Name:        DelegateTool,
+			Description: desc.String(),
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"agent": map[string]interface{}{
+						"type":        "string",
+						"enum":        names,
+						"description": "接收子任务的智能体名称",
+					},
+					"task": map[string]interface{}{
+						"type":        "string",
+						"description": "子任务内容，需包含完成任务所需的全部信息",
+					},
+				},
+				"required": []string{"agent", "task"},
+			},
+		},
+	}, true
+}
+
+// callTool 执行一次工具调用或委托并记录步骤，返回交给 LLM 的结果文本
+func (s *Service) callTool(ctx context.Context, a *Agent, call inter.ToolCall, depth int, trace *RunResult) string {
+	start := time.Now()
+	step := Step{Agent: a.Name, Type: StepTool, Name: call.Function.Name, Arguments: call.Function.Arguments}
+
+	args := map[string]interface{}{}
+	var (
+		output string
+		err    error
+	)
+	if call.Function.Arguments != "" {
+		err = json.Unmarshal([]byte(call.Function.Arguments), &args)
+	}
+	switch {
+	case err != nil:
+		err = fmt.Errorf("参数不是有效的JSON: %v", err)
+	case call.Function.Name == DelegateTool:
+		step.Type = StepDelegate
+		step.Name, _ = args["agent"].(string)
+		task, _ := args["task"].(string)
+		// 先占位，被委托智能体的步骤排在委托步骤之后
+		index := len(trace.Steps)
+		trace.Steps = append(trace.Steps, step)
+		output, err = s.delegate(ctx, a, step.Name, task, depth, trace)
+		step.Result = output
+		if err != nil {
+			step.Error = err.Error()
+		}
+		step.Duration = time.Since(start)
+		trace.Steps[index] = step
+		if err != nil {
+			return "委托失败: " + err.Error()
+		}
+		return output
+	case !contains(a.Tools, call.Function.Name) || s.tools == nil:
+		err = fmt.Errorf("工具 %s 不在智能体 %s 的白名单中", call.Function.Name, a.Name)
+	default:
+		if err = toolpolicy.Authorize(ctx, toolpolicy.KindTool, call.Function.Name, args); err != nil {
+			break
+		}
+		var result any
+		result, err = s.tools.ExecuteTool(ctx, call.Function.Name, args)
+		output = resultText(result)
+	}
+
+	step.Result = output
+	if err != nil {
+		step.Error = err.Error()
+		output = "工具调用失败: " + err.Error()
+	}
+	step.Duration = time.Since(start)
+	trace.Steps = append(trace.Steps, step)
+	return output
+}
+
+// delegate 运行被委托的智能体
+func (s *Service) delegate(ctx context.Context, from *Agent, name, task string, depth int, trace *RunResult) (string, error) {
+	if !contains(from.Delegates, name) {
+		return "", fmt.Errorf("智能体 %s 不能委托给 %s", from.Name, name)
+	}
+	if strings.TrimSpace(task) == "" {
+		return "", fmt.Errorf("子任务为空")
+	}
+	delegate, err := s.GetByName(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	s.logger.InfoTag("智能体", "智能体 %s 委托 %s: %s", from.Name, name, internalutils.SanitizeForLog(task))
+	return s.run(ctx, delegate, task, depth+1, trace)
+}
+
+// complete 请求一次 LLM，汇总流式返回的文本和工具调用
+func complete(ctx context.Context, manager *domainllm.Manager, sessionID string, messages []inter.Message, tools []inter.Tool) (string, []inter.ToolCall, error) {
+	responses, err := manager.Response(ctx, sessionID, messages, tools)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var content strings.Builder
+	var calls []inter.ToolCall
+	for response := range responses {
+		if ctx.Err() != nil || response.Error != nil {
+			go func() {
+				for range responses {
+				}
+			}()
+			if response.Error != nil {
+				return "", nil, response.Error
+			}
+			return "", nil, ctx.Err()
+		}
+		content.WriteString(response.Content)
+		// 同一个工具调用的参数分多块返回，ID 变化时表示新的工具调用
+		for _, tc := range response.ToolCalls {
+			if len(calls) == 0 || (tc.ID != "" && tc.ID != calls[len(calls)-1].ID) {
+				calls = append(calls, inter.ToolCall{ID: tc.ID, Type: "function"})
+			}
+			last := &calls[len(calls)-1]
+			if tc.Function.Name != "" {
+				last.Function.Name = tc.Function.Name
+			}
+			last.Function.Arguments += tc.Function.Arguments
+		}
+	}
+	if ctx.Err() != nil {
+		return "", nil, ctx.Err()
+	}
+
+	text := content.String()
+	// 部分模型以 <tool_call>{...}</tool_call> 文本的形式返回工具调用
+	if len(calls) == 0 && strings.HasPrefix(strings.TrimSpace(text), "<tool_call>") {
+		if parsed := internalutils.Extract_json_from_string(text); parsed != nil {
+			name, _ := parsed["name"].(string)
+			arguments, _ := json.Marshal(parsed["arguments"])
+			calls = append(calls, inter.ToolCall{Type: "function", Function: inter.ToolCallFunction{Name: name, Arguments: string(arguments)}})
+			text = ""
+		}
+	}
+	for i := range calls {
+		if calls[i].ID == "" {
+			calls[i].ID = uuid.New().String()
+		}
+	}
+	return text, calls, nil
+}
+
+// resultText 把工具结果转换为交给 LLM 的文本
+func resultText(result any) string {
+	switch v := result.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case domainllm.ActionResponse:
+		if v.Response != nil {
+			return resultText(v.Response)
+		}
+		return resultText(v.Result)
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Sprintf("%v", result)
+	}
+	return string(data)
+}
+
+func contains(items []string, name string) bool {
+	for _, item := range items {
+		if item == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,260 @@
+package agent
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+// ErrNotFound 智能体不存在
+var ErrNotFound = stderrors.New("agent not found")
+
+// namePattern 智能体名称：小写字母开头，只含小写字母、数字、下划线和连字符
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,63}$`)
+
+// CreateRequest 创建智能体请求
+type CreateRequest struct {
+	Name        string
+	Description string
+	Prompt      string
+	Model       string
+	Tools       []string
+	Delegates   []string
+}
+
+// UpdateRequest 更新智能体请求，nil 字段表示不修改
+type UpdateRequest struct {
+	Name        *string
+	Description *string
+	Prompt      *string
+	Model       *string
+	Tools       *[]string
+	Delegates   *[]string
+}
+
+// Service 智能体服务，管理智能体定义并运行智能体
+type Service struct {
+	repo   Repository
+	cfg    *config.Config
+	tools  Tools
+	logger *logging.Logger
+	now    func() time.Time
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局智能体服务，供工作流节点和 MCP 工具使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局智能体服务，未初始化时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建智能体服务，tools 为智能体可调用的 MCP 工具，为 nil 时智能体不能调用工具
+func NewService(repo Repository, cfg *config.Config, tools Tools, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Service{repo: repo, cfg: cfg, tools: tools, logger: logger, now: time.Now}
+}
+
+// Create 创建智能体
+func (s *Service) Create(ctx context.Context, req CreateRequest) (*Agent, error) {
+	now := s.now()
+	a := &Agent{
+		ID:          uuid.New().String(),
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Prompt:      req.Prompt,
+		Model:       strings.TrimSpace(req.Model),
+		Tools:       normalizeNames(req.Tools),
+		Delegates:   normalizeNames(req.Delegates),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.validate(ctx, a, "agent.create"); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, a); err != nil {
+		return nil, err
+	}
+	s.logger.InfoTag("智能体", "已创建智能体 %s(%s)", a.Name, a.ID)
+	return a, nil
+}
+
+// Get 获取智能体
+func (s *Service) Get(ctx context.Context, id string) (*Agent, error) {
+	a, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil {
+		return nil, errors.Wrap(errors.KindDomain, "agent.get", "agent not found", ErrNotFound)
+	}
+	return a, nil
+}
+
+// GetByName 按名称获取智能体
+func (s *Service) GetByName(ctx context.Context, name string) (*Agent, error) {
+	a, err := s.repo.FindByName(ctx, strings.TrimSpace(name))
+	if err != nil {
+		return nil, err
+	}
+	if a == nil {
+		return nil, errors.Wrap(errors.KindDomain, "agent.get", fmt.Sprintf("agent %s not found", name), ErrNotFound)
+	}
+	return a, nil
+}
+
+// List 获取全部智能体
+func (s *Service) List(ctx context.Context) ([]*Agent, error) {
+	return s.repo.List(ctx)
+}
+
+// Update 更新智能体；被其他智能体委托时不能改名
+func (s *Service) Update(ctx context.Context, id string, req UpdateRequest) (*Agent, error) {
+	a, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	oldName := a.Name
+	if req.Name != nil {
+		a.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		a.Description = *req.Description
+	}
+	if req.Prompt != nil {
+		a.Prompt = *req.Prompt
+	}
+	if req.Model != nil {
+		a.Model = strings.TrimSpace(*req.Model)
+	}
+	if req.Tools != nil {
+		a.Tools = normalizeNames(*req.Tools)
+	}
+	if req.Delegates != nil {
+		a.Delegates = normalizeNames(*req.Delegates)
+	}
+	a.UpdatedAt = s.now()
+
+	if a.Name != oldName {
+		if err := s.checkUnreferenced(ctx, oldName, "agent.update"); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.validate(ctx, a, "agent.update"); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Delete 删除智能体，被其他智能体委托时拒绝删除
+func (s *Service) Delete(ctx context.Context, id string) error {
+	a, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.checkUnreferenced(ctx, a.Name, "agent.delete"); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.logger.InfoTag("智能体", "已删除智能体 %s(%s)", a.Name, a.ID)
+	return nil
+}
+
+// validate 校验名称、模型和委托关系
+func (s *Service) validate(ctx context.Context, a *Agent, op string) error {
+	if !namePattern.MatchString(a.Name) {
+		return errors.New(errors.KindDomain, op, "name must start with a lowercase letter and contain only lowercase letters, digits, '_' or '-' (max 64)")
+	}
+	if strings.TrimSpace(a.Prompt) == "" {
+		return errors.New(errors.KindDomain, op, "prompt is required")
+	}
+	if a.Model != "" && s.cfg != nil {
+		if _, ok := s.cfg.LLM[a.Model]; !ok {
+			return errors.New(errors.KindDomain, op, "unknown model: "+a.Model)
+		}
+	}
+
+	existing, err := s.repo.FindByName(ctx, a.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != a.ID {
+		return errors.New(errors.KindDomain, op, fmt.Sprintf("agent %s already exists", a.Name))
+	}
+
+	for _, name := range a.Delegates {
+		if name == a.Name {
+			return errors.New(errors.KindDomain, op, "agent cannot delegate to itself")
+		}
+		delegate, err := s.repo.FindByName(ctx, name)
+		if err != nil {
+			return err
+		}
+		if delegate == nil {
+			return errors.New(errors.KindDomain, op, "unknown delegate agent: "+name)
+		}
+	}
+	return nil
+}
+
+// checkUnreferenced 检查没有其他智能体委托给 name
+func (s *Service) checkUnreferenced(ctx context.Context, name, op string) error {
+	agents, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, other := range agents {
+		if other.Name == name {
+			continue
+		}
+		for _, delegate := range other.Delegates {
+			if delegate == name {
+				return errors.New(errors.KindDomain, op, fmt.Sprintf("agent %s is a delegate of %s", name, other.Name))
+			}
+		}
+	}
+	return nil
+}
+
+// normalizeNames 去除空白、空项和重复项
+func normalizeNames(names []string) []string {
+	result := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	return result
+}
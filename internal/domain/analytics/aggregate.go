@@ -0,0 +1,153 @@
+package analytics
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"xiaozhi-server-go/internal/domain/budget"
+	"xiaozhi-server-go/internal/domain/transcript"
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+// roundKey 会话内的一轮对话
+type roundKey struct {
+	session string
+	round   int
+}
+
+// dayBuilder 累加一天的数据来源，生成按天汇总
+type dayBuilder struct {
+	rollup   *Rollup
+	sessions map[string]struct{}
+	devices  map[string]*DeviceStat
+	sessDev  map[[2]string]struct{}          // 设备ID和会话ID，用于按设备统计会话数
+	traces   map[roundKey][]capability.Trace // 每轮的能力调用追踪信息
+}
+
+func newDayBuilder(start string) *dayBuilder {
+	return &dayBuilder{
+		rollup:   &Rollup{Period: PeriodDay, Start: start},
+		sessions: make(map[string]struct{}),
+		devices:  make(map[string]*DeviceStat),
+		sessDev:  make(map[[2]string]struct{}),
+		traces:   make(map[roundKey][]capability.Trace),
+	}
+}
+
+func (b *dayBuilder) device(id string) *DeviceStat {
+	stat, ok := b.devices[id]
+	if !ok {
+		stat = &DeviceStat{DeviceID: id}
+		b.devices[id] = stat
+	}
+	return stat
+}
+
+// addEntries 累加对话记录：会话数、用户语句数、播报字数和能力调用
+func (b *dayBuilder) addEntries(entries []*transcript.Entry) {
+	u := &b.rollup.Usage
+	for _, e := range entries {
+		if _, ok := b.sessions[e.SessionID]; !ok {
+			b.sessions[e.SessionID] = struct{}{}
+			u.Conversations++
+		}
+		var dev *DeviceStat
+		if e.DeviceID != "" {
+			dev = b.device(e.DeviceID)
+			key := [2]string{e.DeviceID, e.SessionID}
+			if _, ok := b.sessDev[key]; !ok {
+				b.sessDev[key] = struct{}{}
+				dev.Conversations++
+			}
+			if dev.LastActiveAt == nil || e.CreatedAt.After(*dev.LastActiveAt) {
+				at := e.CreatedAt
+				dev.LastActiveAt = &at
+			}
+		}
+
+		switch e.Role {
+		case transcript.RoleUser:
+			u.Turns++
+			if dev != nil {
+				dev.Turns++
+			}
+		case transcript.RoleAssistant:
+			chars := int64(utf8.RuneCountInString(e.Content))
+			u.TTSChars += chars
+			if dev != nil {
+				dev.TTSChars += chars
+			}
+			// 助手消息携带的是本轮到该条回复为止的全部追踪信息，同一轮只取最完整的一份
+			if len(e.Traces) > 0 {
+				key := roundKey{session: e.SessionID, round: e.Round}
+				if len(e.Traces) >= len(b.traces[key]) {
+					b.traces[key] = e.Traces
+				}
+			}
+		}
+	}
+}
+
+// addLatencies 累加轮次耗时，语音时长按开始说话到说话结束的时长计算
+func (b *dayBuilder) addLatencies(items []*transcript.TurnLatency) {
+	u := &b.rollup.Usage
+	for _, t := range items {
+		if t.TotalMs > 0 {
+			u.LatencyTurns++
+			u.LatencySumMs += t.TotalMs
+		}
+		if t.LLMFirstTokenMs > 0 {
+			u.FirstTokenTurns++
+			u.FirstTokenSumMs += t.LLMFirstTokenMs
+		}
+		u.SpeechMs += t.CaptureMs
+		if t.DeviceID != "" && t.CaptureMs > 0 {
+			b.device(t.DeviceID).SpeechMs += t.CaptureMs
+		}
+	}
+}
+
+// addTokenUsage 累加设备当天的Token用量
+func (b *dayBuilder) addTokenUsage(items []*budget.Usage) {
+	u := &b.rollup.Usage
+	for _, usage := range items {
+		u.Tokens += usage.Tokens
+		u.LLMCalls += usage.Calls
+		if usage.Tokens > 0 {
+			b.device(usage.DeviceID).Tokens += usage.Tokens
+		}
+	}
+}
+
+// build 生成按天汇总
+func (b *dayBuilder) build(intents []*IntentStat, computedAt time.Time) *Rollup {
+	providers := make(map[[2]string]*ProviderStat)
+	for _, traces := range b.traces {
+		for _, t := range traces {
+			key := [2]string{t.Provider, t.Capability}
+			stat, ok := providers[key]
+			if !ok {
+				stat = &ProviderStat{Provider: t.Provider, Capability: t.Capability}
+				providers[key] = stat
+			}
+			stat.Calls++
+			stat.DurationSumMs += t.DurationMs
+			if t.Error != "" {
+				stat.Errors++
+			}
+		}
+	}
+
+	r := b.rollup
+	r.Intents = intents
+	for _, stat := range providers {
+		r.Providers = append(r.Providers, stat)
+	}
+	for _, stat := range b.devices {
+		r.Devices = append(r.Devices, stat)
+	}
+	r.Usage.ActiveDevices = int64(len(b.devices))
+	r.ComputedAt = computedAt
+	r.sort()
+	return r
+}
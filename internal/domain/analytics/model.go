@@ -0,0 +1,205 @@
+package analytics
+
+import (
+	"sort"
+	"time"
+)
+
+// 汇总周期
+const (
+	PeriodDay  = "day"
+	PeriodWeek = "week" // 自然周，从周一开始
+)
+
+const dayLayout = "2006-01-02"
+
+// Usage 一个周期的整体用量
+type Usage struct {
+	Conversations   int64 `json:"conversations"` // 有对话记录的会话数
+	Turns           int64 `json:"turns"`         // 用户语句数
+	ActiveDevices   int64 `json:"active_devices"`
+	LatencyTurns    int64 `json:"latency_turns"` // 测量了响应延迟的轮次数
+	LatencySumMs    int64 `json:"latency_sum_ms"`
+	FirstTokenTurns int64 `json:"first_token_turns"` // 测量了LLM首个输出耗时的轮次数
+	FirstTokenSumMs int64 `json:"first_token_sum_ms"`
+	Tokens          int64 `json:"tokens"`
+	LLMCalls        int64 `json:"llm_calls"`
+	SpeechMs        int64 `json:"speech_ms"` // 送入ASR的语音时长
+	TTSChars        int64 `json:"tts_chars"` // 合成播报的字数
+}
+
+// AvgLatencyMs 平均响应延迟（说话结束到首帧音频下发）
+func (u *Usage) AvgLatencyMs() float64 {
+	return average(u.LatencySumMs, u.LatencyTurns)
+}
+
+// AvgFirstTokenMs LLM首个输出的平均耗时
+func (u *Usage) AvgFirstTokenMs() float64 {
+	return average(u.FirstTokenSumMs, u.FirstTokenTurns)
+}
+
+// IntentStat 意图路由次数
+type IntentStat struct {
+	Intent  string `json:"intent"`
+	Count   int64  `json:"count"`
+	Handled int64  `json:"handled"` // 由确定性处理器完成的次数，其余回落到LLM
+	Failed  int64  `json:"failed"`
+}
+
+// ProviderStat 提供者的能力调用次数和出错次数，来自助手消息的能力调用追踪信息
+type ProviderStat struct {
+	Provider      string `json:"provider"`
+	Capability    string `json:"capability"`
+	Calls         int64  `json:"calls"`
+	Errors        int64  `json:"errors"`
+	DurationSumMs int64  `json:"duration_sum_ms"`
+}
+
+// ErrorRate 出错比例
+func (p *ProviderStat) ErrorRate() float64 {
+	if p.Calls == 0 {
+		return 0
+	}
+	return float64(p.Errors) / float64(p.Calls)
+}
+
+// AvgDurationMs 平均调用耗时
+func (p *ProviderStat) AvgDurationMs() float64 {
+	return average(p.DurationSumMs, p.Calls)
+}
+
+// DeviceStat 设备的活跃情况
+type DeviceStat struct {
+	DeviceID      string     `json:"device_id"`
+	Conversations int64      `json:"conversations"`
+	Turns         int64      `json:"turns"`
+	Tokens        int64      `json:"tokens"`
+	SpeechMs      int64      `json:"speech_ms"`
+	TTSChars      int64      `json:"tts_chars"`
+	LastActiveAt  *time.Time `json:"last_active_at,omitempty"` // 最后一条对话记录的时间
+}
+
+// Rollup 一个周期的汇总
+type Rollup struct {
+	Period     string          `json:"period"`
+	Start      string          `json:"start"` // 周期第一天 YYYY-MM-DD
+	Usage      Usage           `json:"usage"`
+	Intents    []*IntentStat   `json:"intents"`
+	Providers  []*ProviderStat `json:"providers"`
+	Devices    []*DeviceStat   `json:"devices"`
+	ComputedAt time.Time       `json:"computed_at"`
+}
+
+// Query 汇总查询条件，From 和 To 为周期起始日，To 缺省为今天所在的周期
+type Query struct {
+	Period   string
+	From     string
+	To       string
+	DeviceID string
+	Limit    int
+}
+
+// merge 把多个按天汇总合并为一个周期的汇总；活跃设备数按设备去重
+func merge(period, start string, rollups []*Rollup) *Rollup {
+	out := &Rollup{Period: period, Start: start}
+	intents := make(map[string]*IntentStat)
+	providers := make(map[[2]string]*ProviderStat)
+	devices := make(map[string]*DeviceStat)
+	for _, r := range rollups {
+		u := r.Usage
+		out.Usage.Conversations += u.Conversations
+		out.Usage.Turns += u.Turns
+		out.Usage.LatencyTurns += u.LatencyTurns
+		out.Usage.LatencySumMs += u.LatencySumMs
+		out.Usage.FirstTokenTurns += u.FirstTokenTurns
+		out.Usage.FirstTokenSumMs += u.FirstTokenSumMs
+		out.Usage.Tokens += u.Tokens
+		out.Usage.LLMCalls += u.LLMCalls
+		out.Usage.SpeechMs += u.SpeechMs
+		out.Usage.TTSChars += u.TTSChars
+		for _, stat := range r.Intents {
+			acc, ok := intents[stat.Intent]
+			if !ok {
+				acc = &IntentStat{Intent: stat.Intent}
+				intents[stat.Intent] = acc
+			}
+			acc.Count += stat.Count
+			acc.Handled += stat.Handled
+			acc.Failed += stat.Failed
+		}
+		for _, stat := range r.Providers {
+			key := [2]string{stat.Provider, stat.Capability}
+			acc, ok := providers[key]
+			if !ok {
+				acc = &ProviderStat{Provider: stat.Provider, Capability: stat.Capability}
+				providers[key] = acc
+			}
+			acc.Calls += stat.Calls
+			acc.Errors += stat.Errors
+			acc.DurationSumMs += stat.DurationSumMs
+		}
+		for _, stat := range r.Devices {
+			acc, ok := devices[stat.DeviceID]
+			if !ok {
+				acc = &DeviceStat{DeviceID: stat.DeviceID}
+				devices[stat.DeviceID] = acc
+			}
+			acc.Conversations += stat.Conversations
+			acc.Turns += stat.Turns
+			acc.Tokens += stat.Tokens
+			acc.SpeechMs += stat.SpeechMs
+			acc.TTSChars += stat.TTSChars
+			if stat.LastActiveAt != nil && (acc.LastActiveAt == nil || stat.LastActiveAt.After(*acc.LastActiveAt)) {
+				at := *stat.LastActiveAt
+				acc.LastActiveAt = &at
+			}
+		}
+	}
+	for _, stat := range intents {
+		out.Intents = append(out.Intents, stat)
+	}
+	for _, stat := range providers {
+		out.Providers = append(out.Providers, stat)
+	}
+	for _, stat := range devices {
+		out.Devices = append(out.Devices, stat)
+	}
+	out.Usage.ActiveDevices = int64(len(devices))
+	out.sort()
+	return out
+}
+
+// sort 意图按次数、设备按语句数从多到少排列，提供者按名称排列
+func (r *Rollup) sort() {
+	sort.Slice(r.Intents, func(i, j int) bool {
+		if r.Intents[i].Count != r.Intents[j].Count {
+			return r.Intents[i].Count > r.Intents[j].Count
+		}
+		return r.Intents[i].Intent < r.Intents[j].Intent
+	})
+	sort.Slice(r.Providers, func(i, j int) bool {
+		if r.Providers[i].Provider != r.Providers[j].Provider {
+			return r.Providers[i].Provider < r.Providers[j].Provider
+		}
+		return r.Providers[i].Capability < r.Providers[j].Capability
+	})
+	sort.Slice(r.Devices, func(i, j int) bool {
+		if r.Devices[i].Turns != r.Devices[j].Turns {
+			return r.Devices[i].Turns > r.Devices[j].Turns
+		}
+		return r.Devices[i].DeviceID < r.Devices[j].DeviceID
+	})
+}
+
+// weekStart 返回所在自然周的周一
+func weekStart(day time.Time) time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+func average(sum, count int64) float64 {
+	if count == 0 {
+		return 0
+	}
+	return float64(sum) / float64(count)
+}
@@ -0,0 +1,36 @@
+package analytics
+
+import (
+	"context"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/budget"
+	"xiaozhi-server-go/internal/domain/transcript"
+)
+
+// Repository 用量统计仓库接口，读取对话记录、轮次耗时和Token用量作为汇总的数据来源
+type Repository interface {
+	// ScanEntries 按时间正序分批读取 [from, to) 内的对话记录
+	ScanEntries(ctx context.Context, from, to time.Time, fn func([]*transcript.Entry) error) error
+
+	// ListTurnLatencies 查询 [from, to) 内的轮次耗时
+	ListTurnLatencies(ctx context.Context, from, to time.Time) ([]*transcript.TurnLatency, error)
+
+	// ListTokenUsage 查询某一天各设备的Token用量
+	ListTokenUsage(ctx context.Context, day string) ([]*budget.Usage, error)
+
+	// AddIntentCounts 累加某一天的意图路由次数
+	AddIntentCounts(ctx context.Context, day string, stats []*IntentStat) error
+
+	// ListIntentCounts 查询某一天的意图路由次数
+	ListIntentCounts(ctx context.Context, day string) ([]*IntentStat, error)
+
+	// SaveRollup 保存汇总，覆盖同一周期和起始日的旧结果
+	SaveRollup(ctx context.Context, r *Rollup) error
+
+	// ListRollups 查询起始日在 [from, to] 内的汇总，按起始日正序
+	ListRollups(ctx context.Context, period, from, to string) ([]*Rollup, error)
+
+	// DeleteBefore 删除起始日早于 day 的汇总和意图路由次数，返回删除的汇总数
+	DeleteBefore(ctx context.Context, day string) (int64, error)
+}
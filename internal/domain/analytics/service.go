@@ -0,0 +1,357 @@
+package analytics
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/eventbus"
+	"xiaozhi-server-go/internal/domain/transcript"
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+const (
+	// intentFlushInterval 意图路由次数在内存中累加，按该间隔写入数据库
+	intentFlushInterval = 30 * time.Second
+
+	defaultLimit = 20
+	maxLimit     = 500
+	maxDays      = 400 // 单次查询最多覆盖的天数
+)
+
+// Service 用量统计服务，后台定期把对话记录等数据汇总为按天和按周的统计，查询只读取汇总结果
+type Service struct {
+	cfg    config.AnalyticsConfig
+	repo   Repository
+	logger *logging.Logger
+	now    func() time.Time
+
+	mu      sync.Mutex
+	intents map[string]map[string]*IntentStat // 日期到尚未写入的意图路由次数
+}
+
+// NewService 创建用量统计服务
+func NewService(cfg config.AnalyticsConfig, repo Repository, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = 15 * time.Minute
+	}
+	if cfg.BackfillDays <= 0 {
+		cfg.BackfillDays = 1
+	}
+	return &Service{
+		cfg:     cfg,
+		repo:    repo,
+		logger:  logger,
+		now:     time.Now,
+		intents: make(map[string]map[string]*IntentStat),
+	}
+}
+
+// Subscribe 订阅意图路由事件，累加每天的意图路由次数
+func (s *Service) Subscribe() error {
+	return eventbus.Subscribe(eventbus.EventIntentRouted, s.handleIntent)
+}
+
+// handleIntent 在路由所在的协程中调用，只做内存累加
+func (s *Service) handleIntent(data eventbus.IntentEventData) {
+	at := data.Timestamp
+	if at.IsZero() {
+		at = s.now()
+	}
+	day := at.In(time.Local).Format(dayLayout)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts, ok := s.intents[day]
+	if !ok {
+		counts = make(map[string]*IntentStat)
+		s.intents[day] = counts
+	}
+	stat, ok := counts[data.Intent]
+	if !ok {
+		stat = &IntentStat{Intent: data.Intent}
+		counts[data.Intent] = stat
+	}
+	stat.Count++
+	if data.Handled {
+		stat.Handled++
+	}
+	if data.Failed {
+		stat.Failed++
+	}
+}
+
+// Run 启动时重新汇总最近 BackfillDays 天，之后定期重新汇总今天和昨天，直到 ctx 取消
+func (s *Service) Run(ctx context.Context) error {
+	s.logger.InfoTag("用量统计", "用量统计服务已启动，每 %s 汇总一次", s.cfg.Interval)
+	flushTicker := time.NewTicker(intentFlushInterval)
+	defer flushTicker.Stop()
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	s.refresh(ctx, s.cfg.BackfillDays)
+	s.purge(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.flushIntents(context.Background())
+			s.logger.InfoTag("用量统计", "用量统计服务已停止")
+			return nil
+		case <-flushTicker.C:
+			s.flushIntents(ctx)
+		case <-ticker.C:
+			s.refresh(ctx, 2)
+			s.purge(ctx)
+		}
+	}
+}
+
+// flushIntents 把内存中累加的意图路由次数写入数据库，失败的留待下次写入
+func (s *Service) flushIntents(ctx context.Context) {
+	s.mu.Lock()
+	pending := s.intents
+	s.intents = make(map[string]map[string]*IntentStat)
+	s.mu.Unlock()
+
+	for day, counts := range pending {
+		stats := make([]*IntentStat, 0, len(counts))
+		for _, stat := range counts {
+			stats = append(stats, stat)
+		}
+		if err := s.repo.AddIntentCounts(ctx, day, stats); err != nil {
+			s.logger.WarnTag("用量统计", "写入 %s 的意图路由次数失败: %v", day, err)
+			s.requeue(day, stats)
+		}
+	}
+}
+
+func (s *Service) requeue(day string, stats []*IntentStat) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts, ok := s.intents[day]
+	if !ok {
+		counts = make(map[string]*IntentStat)
+		s.intents[day] = counts
+	}
+	for _, stat := range stats {
+		acc, ok := counts[stat.Intent]
+		if !ok {
+			acc = &IntentStat{Intent: stat.Intent}
+			counts[stat.Intent] = acc
+		}
+		acc.Count += stat.Count
+		acc.Handled += stat.Handled
+		acc.Failed += stat.Failed
+	}
+}
+
+// refresh 重新汇总最近 days 天的按天统计及其所在周的按周统计
+func (s *Service) refresh(ctx context.Context, days int) {
+	s.flushIntents(ctx)
+
+	today := startOfDay(s.now())
+	weeks := make(map[string]time.Time)
+	for i := days - 1; i >= 0; i-- {
+		day := today.AddDate(0, 0, -i)
+		if err := s.computeDay(ctx, day); err != nil {
+			s.logger.ErrorTag("用量统计", "汇总 %s 的用量失败: %v", day.Format(dayLayout), err)
+			continue
+		}
+		week := weekStart(day)
+		weeks[week.Format(dayLayout)] = week
+	}
+	for _, week := range weeks {
+		if err := s.computeWeek(ctx, week); err != nil {
+			s.logger.ErrorTag("用量统计", "汇总 %s 所在周的用量失败: %v", week.Format(dayLayout), err)
+		}
+	}
+}
+
+// computeDay 从对话记录、轮次耗时、Token用量和意图路由次数汇总一天的统计
+func (s *Service) computeDay(ctx context.Context, day time.Time) error {
+	start := day.Format(dayLayout)
+	from, to := day, day.AddDate(0, 0, 1)
+	b := newDayBuilder(start)
+
+	err := s.repo.ScanEntries(ctx, from, to, func(entries []*transcript.Entry) error {
+		b.addEntries(entries)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	latencies, err := s.repo.ListTurnLatencies(ctx, from, to)
+	if err != nil {
+		return err
+	}
+	b.addLatencies(latencies)
+	usage, err := s.repo.ListTokenUsage(ctx, start)
+	if err != nil {
+		return err
+	}
+	b.addTokenUsage(usage)
+	intents, err := s.repo.ListIntentCounts(ctx, start)
+	if err != nil {
+		return err
+	}
+	return s.repo.SaveRollup(ctx, b.build(intents, s.now()))
+}
+
+// computeWeek 合并一周内的按天统计
+func (s *Service) computeWeek(ctx context.Context, week time.Time) error {
+	start := week.Format(dayLayout)
+	daily, err := s.repo.ListRollups(ctx, PeriodDay, start, week.AddDate(0, 0, 6).Format(dayLayout))
+	if err != nil {
+		return err
+	}
+	rollup := merge(PeriodWeek, start, daily)
+	rollup.ComputedAt = s.now()
+	return s.repo.SaveRollup(ctx, rollup)
+}
+
+// purge 删除超过保留天数的汇总
+func (s *Service) purge(ctx context.Context) {
+	if s.cfg.RetentionDays <= 0 {
+		return
+	}
+	// 按周汇总的起始日最多早于所含日期6天，多保留一周避免删掉仍在保留期内的周
+	before := startOfDay(s.now()).AddDate(0, 0, -s.cfg.RetentionDays-7).Format(dayLayout)
+	removed, err := s.repo.DeleteBefore(ctx, before)
+	if err != nil {
+		s.logger.ErrorTag("用量统计", "清理过期汇总失败: %v", err)
+		return
+	}
+	if removed > 0 {
+		s.logger.InfoTag("用量统计", "已清理 %d 条过期汇总", removed)
+	}
+}
+
+// Rollups 查询各周期的汇总，按起始日正序；意图和设备按 Limit 截取，指定设备时只返回该设备的活跃情况
+func (s *Service) Rollups(ctx context.Context, q Query) ([]*Rollup, error) {
+	q, err := s.normalize(q)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.repo.ListRollups(ctx, q.Period, q.From, q.To)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range items {
+		trim(r, q)
+	}
+	return items, nil
+}
+
+// Summary 把查询范围内的按天汇总合并为一个汇总
+func (s *Service) Summary(ctx context.Context, q Query) (*Rollup, error) {
+	q, err := s.normalize(q)
+	if err != nil {
+		return nil, err
+	}
+	// 周期只决定范围的对齐方式，合并始终基于按天汇总，活跃设备数才能按设备去重
+	to := q.To
+	if q.Period == PeriodWeek {
+		end, _ := time.ParseInLocation(dayLayout, q.To, time.Local)
+		to = end.AddDate(0, 0, 6).Format(dayLayout)
+	}
+	daily, err := s.repo.ListRollups(ctx, PeriodDay, q.From, to)
+	if err != nil {
+		return nil, err
+	}
+	summary := merge(q.Period, q.From, daily)
+	for _, r := range daily {
+		if r.ComputedAt.After(summary.ComputedAt) {
+			summary.ComputedAt = r.ComputedAt
+		}
+	}
+	trim(summary, q)
+	return summary, nil
+}
+
+// normalize 校验周期和日期并补全缺省值：To 缺省为当前周期，From 缺省为往前 7 天或 8 周
+func (s *Service) normalize(q Query) (Query, error) {
+	q.Period = strings.TrimSpace(q.Period)
+	if q.Period == "" {
+		q.Period = PeriodDay
+	}
+	if q.Period != PeriodDay && q.Period != PeriodWeek {
+		return q, errors.New(errors.KindDomain, "analytics.query", "period must be day or week")
+	}
+	align := func(t time.Time) time.Time {
+		if q.Period == PeriodWeek {
+			return weekStart(t)
+		}
+		return t
+	}
+
+	to := startOfDay(s.now())
+	if q.To != "" {
+		t, err := time.ParseInLocation(dayLayout, q.To, time.Local)
+		if err != nil {
+			return q, errors.New(errors.KindDomain, "analytics.query", "to must be formatted as YYYY-MM-DD")
+		}
+		to = t
+	}
+	to = align(to)
+
+	var from time.Time
+	if q.From != "" {
+		t, err := time.ParseInLocation(dayLayout, q.From, time.Local)
+		if err != nil {
+			return q, errors.New(errors.KindDomain, "analytics.query", "from must be formatted as YYYY-MM-DD")
+		}
+		from = align(t)
+	} else if q.Period == PeriodWeek {
+		from = to.AddDate(0, 0, -7*7)
+	} else {
+		from = to.AddDate(0, 0, -6)
+	}
+	if from.After(to) {
+		return q, errors.New(errors.KindDomain, "analytics.query", "from must not be after to")
+	}
+	if to.Sub(from) > maxDays*24*time.Hour {
+		return q, errors.New(errors.KindDomain, "analytics.query", "range must not exceed 400 days")
+	}
+	q.From, q.To = from.Format(dayLayout), to.Format(dayLayout)
+
+	if q.Limit <= 0 {
+		q.Limit = defaultLimit
+	}
+	if q.Limit > maxLimit {
+		q.Limit = maxLimit
+	}
+	q.DeviceID = strings.TrimSpace(q.DeviceID)
+	return q, nil
+}
+
+// trim 按查询条件截取意图和设备列表
+func trim(r *Rollup, q Query) {
+	if q.DeviceID != "" {
+		var devices []*DeviceStat
+		for _, d := range r.Devices {
+			if d.DeviceID == q.DeviceID {
+				devices = append(devices, d)
+			}
+		}
+		r.Devices = devices
+	}
+	if len(r.Devices) > q.Limit {
+		r.Devices = r.Devices[:q.Limit]
+	}
+	if len(r.Intents) > q.Limit {
+		r.Intents = r.Intents[:q.Limit]
+	}
+}
+
+// startOfDay 返回服务器本地时区当天零点
+func startOfDay(t time.Time) time.Time {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,150 @@
+package preprocess
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	echoStepSize      = 0.3  // NLMS 步长
+	echoRegularizer   = 1e-6 // 防止参考信号能量过小时除零
+	echoSilenceEnergy = 1e-7 // 参考信号窗口能量低于该值视为没有回声，直接透传
+	geigelThreshold   = 0.6  // 采集幅度超过参考最大幅度的该比例时判定为双讲，暂停自适应
+	referenceKeep     = 2 * time.Second
+)
+
+// EchoReference 下发给设备的音频，按预计的播放时间排列，作为回声消除的参考信号
+//
+// 样本用绝对序号定位：序号 0 对应创建时刻，之后每个样本占 1/sampleRate 秒。
+// 写入时按“发送时刻 + 延迟”推算播放位置，连续发送的帧首尾相接，间隔处补零。
+type EchoReference struct {
+	mu         sync.Mutex
+	sampleRate int
+	delay      time.Duration
+	origin     time.Time
+	base       int64 // samples[0] 的绝对序号
+	samples    []float64
+	maxLen     int
+}
+
+// NewEchoReference 创建参考信号缓冲，delay 为发送到设备采集到回声的延迟
+func NewEchoReference(sampleRate int, delay time.Duration) *EchoReference {
+	return &EchoReference{
+		sampleRate: sampleRate,
+		delay:      delay,
+		origin:     time.Now(),
+		maxLen:     int(int64(sampleRate) * int64(referenceKeep) / int64(time.Second)),
+	}
+}
+
+// position 时刻 t 对应的绝对序号
+func (r *EchoReference) position(t time.Time) int64 {
+	return int64(t.Sub(r.origin)) * int64(r.sampleRate) / int64(time.Second)
+}
+
+// Write 记录刚发送的一段单声道样本
+func (r *EchoReference) Write(samples []float64) {
+	if len(samples) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	playAt := r.position(time.Now().Add(r.delay))
+	end := r.base + int64(len(r.samples))
+	switch {
+	case len(r.samples) == 0 || playAt-end > int64(r.maxLen):
+		r.base, r.samples = playAt, r.samples[:0]
+	case playAt > end:
+		r.samples = append(r.samples, make([]float64, playAt-end)...)
+	}
+	r.samples = append(r.samples, samples...)
+
+	if excess := len(r.samples) - r.maxLen; excess > 0 {
+		r.samples = append(r.samples[:0], r.samples[excess:]...)
+		r.base += int64(excess)
+	}
+}
+
+// Read 读取从绝对序号 from 开始的 n 个样本，没有记录的位置为 0
+func (r *EchoReference) Read(from int64, n int) []float64 {
+	out := make([]float64, n)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	start := from - r.base
+	for i := range out {
+		if idx := start + int64(i); idx >= 0 && idx < int64(len(r.samples)) {
+			out[i] = r.samples[idx]
+		}
+	}
+	return out
+}
+
+// EchoCanceller 时域 NLMS 自适应滤波回声消除，使用 Geigel 算法检测双讲
+type EchoCanceller struct {
+	taps    int
+	weights []float64
+	history []float64 // 参考信号历史，长度 2*taps，history[pos:pos+taps] 为最近的 taps 个样本（新样本在前）
+	pos     int
+	energy  float64
+}
+
+// NewEchoCanceller 创建回声消除器，tailMs 为能消除的回声尾长
+func NewEchoCanceller(sampleRate, tailMs int) *EchoCanceller {
+	taps := sampleRate * tailMs / 1000
+	if taps < 1 {
+		taps = 1
+	}
+	return &EchoCanceller{
+		taps:    taps,
+		weights: make([]float64, taps),
+		history: make([]float64, 2*taps),
+		pos:     taps,
+	}
+}
+
+// Process 以 ref 为参考信号原地消除 mic 中的回声，两者长度相同且已对齐
+func (e *EchoCanceller) Process(mic, ref []float64) {
+	for i := range mic {
+		// 滑出窗口的最旧样本
+		oldest := e.history[e.pos+e.taps-1]
+		e.pos--
+		if e.pos < 0 {
+			copy(e.history[e.taps+1:], e.history[:e.taps-1])
+			e.pos = e.taps
+		}
+		e.history[e.pos] = ref[i]
+		e.energy += ref[i]*ref[i] - oldest*oldest
+		if e.pos == e.taps {
+			// 每轮回绕时重新计算能量，避免累加误差
+			e.energy = 0
+			for _, v := range e.history[e.pos : e.pos+e.taps] {
+				e.energy += v * v
+			}
+		}
+		if e.energy < echoSilenceEnergy {
+			e.energy = math.Max(e.energy, 0)
+			continue
+		}
+
+		x := e.history[e.pos : e.pos+e.taps]
+		estimate, peak := 0.0, 0.0
+		for j, w := range e.weights {
+			estimate += w * x[j]
+			if a := math.Abs(x[j]); a > peak {
+				peak = a
+			}
+		}
+		residual := mic[i] - estimate
+
+		if math.Abs(mic[i]) < geigelThreshold*peak {
+			step := echoStepSize * residual / (e.energy + echoRegularizer)
+			for j := range e.weights {
+				e.weights[j] += step * x[j]
+			}
+		}
+		mic[i] = residual
+	}
+}
@@ -0,0 +1,126 @@
+package preprocess
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+const (
+	noiseInitFrames   = 20    // 开始的若干帧直接用于估计噪声底
+	noiseUpdateRatio  = 3.0   // 平滑功率低于噪声估计的该倍数时视为无语音，更新噪声估计
+	noiseSmooth       = 0.95  // 噪声估计的平滑系数
+	noiseRiseRate     = 1.005 // 有语音时噪声估计每帧上升的比例，避免背景噪声变大后一直被当作语音
+	powerSmooth       = 0.7   // 判断是否有语音时使用的功率平滑系数
+	noiseOverSubtract = 2.0   // 过减因子，抑制残留噪声
+	gainReleaseSmooth = 0.7   // 增益下降时的平滑系数，减少“音乐噪声”
+)
+
+// NoiseSuppressor 基于短时傅里叶变换的频谱减法降噪
+//
+// 每个频点在判定为无语音时更新噪声功率估计，按信噪比计算增益并限制最大衰减，
+// 分析和合成使用平方根汉宁窗、50% 重叠，输出比输入滞后半帧。
+type NoiseSuppressor struct {
+	size   int
+	hop    int
+	floor  float64 // 最小增益
+	window []float64
+	input  []float64 // 待分析的样本，长度为 size
+	filled int
+	output []float64 // 重叠相加缓冲，长度为 size
+	noise  []float64 // 各频点的噪声功率估计
+	power  []float64 // 各频点平滑后的功率
+	gain   []float64 // 各频点平滑后的增益
+	spec   []complex128
+	frames int
+}
+
+// NewNoiseSuppressor 创建降噪器，帧长约 16ms，maxAttenuationDB 为噪声频点的最大衰减
+func NewNoiseSuppressor(sampleRate int, maxAttenuationDB int) *NoiseSuppressor {
+	size := 1
+	for size < sampleRate*16/1000 {
+		size <<= 1
+	}
+	bins := size/2 + 1
+	window := make([]float64, size)
+	for i := range window {
+		window[i] = math.Sqrt(0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(size)))
+	}
+	gain := make([]float64, bins)
+	for i := range gain {
+		gain[i] = 1
+	}
+	return &NoiseSuppressor{
+		size:   size,
+		hop:    size / 2,
+		floor:  math.Pow(10, -float64(maxAttenuationDB)/20),
+		window: window,
+		input:  make([]float64, size),
+		output: make([]float64, size),
+		noise:  make([]float64, bins),
+		power:  make([]float64, bins),
+		gain:   gain,
+		spec:   make([]complex128, size),
+	}
+}
+
+// Process 处理一段样本，返回已完成处理的样本，长度可能与输入不同
+func (n *NoiseSuppressor) Process(samples []float64) []float64 {
+	out := make([]float64, 0, len(samples)+n.hop)
+	for _, s := range samples {
+		n.input[n.filled] = s
+		n.filled++
+		if n.filled < n.size {
+			continue
+		}
+		out = n.processFrame(out)
+		copy(n.input, n.input[n.hop:])
+		n.filled = n.size - n.hop
+	}
+	return out
+}
+
+func (n *NoiseSuppressor) processFrame(out []float64) []float64 {
+	for i, s := range n.input {
+		n.spec[i] = complex(s*n.window[i], 0)
+	}
+	fft(n.spec, false)
+
+	for k := range n.noise {
+		power := real(n.spec[k])*real(n.spec[k]) + imag(n.spec[k])*imag(n.spec[k])
+		n.power[k] = powerSmooth*n.power[k] + (1-powerSmooth)*power
+		switch {
+		case n.frames < noiseInitFrames:
+			n.noise[k] += (power - n.noise[k]) / float64(n.frames+1)
+		case n.power[k] < noiseUpdateRatio*n.noise[k]:
+			n.noise[k] = noiseSmooth*n.noise[k] + (1-noiseSmooth)*power
+		default:
+			n.noise[k] *= noiseRiseRate
+		}
+
+		g := n.floor
+		if power > 0 {
+			g = math.Max(1-noiseOverSubtract*n.noise[k]/power, n.floor)
+		}
+		if g < n.gain[k] {
+			g = gainReleaseSmooth*n.gain[k] + (1-gainReleaseSmooth)*g
+		}
+		n.gain[k] = g
+
+		n.spec[k] *= complex(g, 0)
+		if k > 0 && k < n.size-k {
+			n.spec[n.size-k] = cmplx.Conj(n.spec[k])
+		}
+	}
+	n.frames++
+
+	fft(n.spec, true)
+	for i := range n.output {
+		n.output[i] += real(n.spec[i]) * n.window[i]
+	}
+	out = append(out, n.output[:n.hop]...)
+	copy(n.output, n.output[n.hop:])
+	for i := n.size - n.hop; i < n.size; i++ {
+		n.output[i] = 0
+	}
+	return out
+}
@@ -0,0 +1,138 @@
+// Package preprocess 上行音频预处理
+//
+// 在送入 VAD/ASR 之前对解码后的 16 位单声道 PCM 做回声消除和降噪：回声消除以服务端下发的
+// TTS 音频为参考信号，适合设备本身没有硬件 AEC、播放时仍允许打断的场景；降噪使用频谱减法，
+// 改善嘈杂房间里的识别效果。两者都可按设备所属的预处理策略单独开启。
+package preprocess
+
+import (
+	"encoding/binary"
+	"math"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/platform/config"
+)
+
+const (
+	defaultProfile       = "default"
+	defaultSuppressionDB = 18
+	defaultEchoTailMs    = 64
+	defaultEchoDelayMs   = 150
+	resyncTolerance      = 200 * time.Millisecond // 采集时间线与参考信号偏差超过该值时重新对齐
+)
+
+// ProfileFor 返回设备使用的策略名称和策略，未配置或策略不存在时使用 default
+func ProfileFor(cfg config.AudioPreprocessConfig, deviceID string) (string, config.AudioPreprocessProfile) {
+	if name, ok := cfg.DeviceProfiles[deviceID]; ok {
+		if profile, exists := cfg.Profiles[name]; exists {
+			return name, profile
+		}
+	}
+	return defaultProfile, cfg.Profiles[defaultProfile]
+}
+
+// Preprocessor 单个连接的预处理器
+type Preprocessor struct {
+	mu         sync.Mutex
+	sampleRate int
+	noise      *NoiseSuppressor
+	echo       *EchoCanceller
+	reference  *EchoReference
+	cursor     int64 // 下一个采集样本对应的参考信号绝对序号
+	synced     bool
+}
+
+// New 按策略创建预处理器，策略没有开启任何处理时返回 nil
+func New(profile config.AudioPreprocessProfile, sampleRate int) *Preprocessor {
+	if !profile.NoiseSuppression && !profile.EchoCancellation {
+		return nil
+	}
+
+	p := &Preprocessor{sampleRate: sampleRate}
+	if profile.NoiseSuppression {
+		suppression := profile.SuppressionDB
+		if suppression <= 0 {
+			suppression = defaultSuppressionDB
+		}
+		p.noise = NewNoiseSuppressor(sampleRate, suppression)
+	}
+	if profile.EchoCancellation {
+		tail, delay := profile.EchoTailMs, profile.EchoDelayMs
+		if tail <= 0 {
+			tail = defaultEchoTailMs
+		}
+		if delay <= 0 {
+			delay = defaultEchoDelayMs
+		}
+		p.echo = NewEchoCanceller(sampleRate, tail)
+		p.reference = NewEchoReference(sampleRate, time.Duration(delay)*time.Millisecond)
+	}
+	return p
+}
+
+// SampleRate 处理的采样率，参考信号需要先重采样到该采样率
+func (p *Preprocessor) SampleRate() int {
+	return p.sampleRate
+}
+
+// EchoCancellation 是否开启了回声消除，未开启时无需提供参考信号
+func (p *Preprocessor) EchoCancellation() bool {
+	return p != nil && p.echo != nil
+}
+
+// WriteReference 记录刚发送给设备的一段 16 位单声道 PCM，采样率须与 SampleRate 一致
+func (p *Preprocessor) WriteReference(pcm []byte) {
+	if !p.EchoCancellation() {
+		return
+	}
+	p.reference.Write(toFloat(pcm))
+}
+
+// Process 处理一段采集到的 16 位单声道 PCM；开启降噪时输出有约 8ms 延迟，长度可能与输入不同
+func (p *Preprocessor) Process(pcm []byte) []byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	samples := toFloat(pcm)
+	if p.echo != nil {
+		p.echo.Process(samples, p.reference.Read(p.alignCursor(len(samples)), len(samples)))
+	}
+	if p.noise != nil {
+		samples = p.noise.Process(samples)
+	}
+	return toPCM(samples)
+}
+
+// alignCursor 返回本段采集样本对应的参考信号起始序号
+//
+// 采集数据按实时节奏到达，刚收到的最后一个样本约在当前时刻采集。之后沿连续的序号读取，
+// 避免网络抖动导致参考信号前后跳动；偏差过大（如设备暂停上传后恢复）时重新对齐。
+func (p *Preprocessor) alignCursor(n int) int64 {
+	expected := p.reference.position(time.Now()) - int64(n)
+	tolerance := int64(resyncTolerance) * int64(p.sampleRate) / int64(time.Second)
+	if !p.synced || p.cursor-expected > tolerance || expected-p.cursor > tolerance {
+		p.cursor, p.synced = expected, true
+	}
+	from := p.cursor
+	p.cursor += int64(n)
+	return from
+}
+
+func toFloat(pcm []byte) []float64 {
+	samples := make([]float64, len(pcm)/2)
+	for i := range samples {
+		samples[i] = float64(int16(binary.LittleEndian.Uint16(pcm[i*2:]))) / 32768
+	}
+	return samples
+}
+
+func toPCM(samples []float64) []byte {
+	pcm := make([]byte, len(samples)*2)
+	for i, s := range samples {
+		v := math.Round(s * 32768)
+		v = math.Max(math.Min(v, math.MaxInt16), math.MinInt16)
+		binary.LittleEndian.PutUint16(pcm[i*2:], uint16(int16(v)))
+	}
+	return pcm
+}
@@ -0,0 +1,231 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// maxManifestSize 清单大小上限，防止构造的归档耗尽内存
+const maxManifestSize = 64 << 20
+
+// archiveWriter 依次写入文件，最后写入清单
+type archiveWriter struct {
+	enc      *encryptWriter
+	gz       *gzip.Writer
+	tw       *tar.Writer
+	manifest *Manifest
+}
+
+func newArchiveWriter(w io.Writer, passphrase string, manifest *Manifest) (*archiveWriter, error) {
+	enc, err := newEncryptWriter(w, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	gz := gzip.NewWriter(enc)
+	return &archiveWriter{enc: enc, gz: gz, tw: tar.NewWriter(gz), manifest: manifest}, nil
+}
+
+// addFile 写入单个文件并记录摘要，写入过程中文件被截短时返回错误
+func (a *archiveWriter) addFile(name, src string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     info.Size(),
+		Mode:     int64(info.Mode().Perm()),
+		ModTime:  info.ModTime(),
+	}
+	if err := a.tw.WriteHeader(header); err != nil {
+		return err
+	}
+	hash := sha256.New()
+	n, err := io.Copy(io.MultiWriter(a.tw, hash), io.LimitReader(f, info.Size()))
+	if err != nil {
+		return err
+	}
+	if n != info.Size() {
+		return fmt.Errorf("%s 在备份过程中被修改", src)
+	}
+	a.manifest.Entries = append(a.manifest.Entries, Entry{Path: name, Size: n, SHA256: hex.EncodeToString(hash.Sum(nil))})
+	return nil
+}
+
+// addRoot 写入文件或目录，skip 中的路径及其子路径不写入，只备份普通文件
+func (a *archiveWriter) addRoot(root string, skip map[string]bool) error {
+	return filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if skip[filepath.Clean(p)] {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		return a.addFile(path.Join(filesDir, filepath.ToSlash(p)), p)
+	})
+}
+
+// Close 写入清单并结束加密流
+func (a *archiveWriter) Close() error {
+	data, err := json.MarshalIndent(a.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     manifestName,
+		Size:     int64(len(data)),
+		Mode:     0o600,
+		ModTime:  a.manifest.CreatedAt,
+	}
+	if err := a.tw.WriteHeader(header); err != nil {
+		return err
+	}
+	if _, err := a.tw.Write(data); err != nil {
+		return err
+	}
+	if err := a.tw.Close(); err != nil {
+		return err
+	}
+	if err := a.gz.Close(); err != nil {
+		return err
+	}
+	return a.enc.Close()
+}
+
+// extract 解密并解包到 dest，dest 为空时只校验；返回前核对清单中每个文件的大小和摘要
+func extract(r io.Reader, passphrase, dest string) (*Result, error) {
+	dec, err := newDecryptReader(r, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	gz, err := gzip.NewReader(dec)
+	if err != nil {
+		return nil, integrityError(err)
+	}
+	defer gz.Close()
+
+	var manifest *Manifest
+	found := make(map[string]Entry)
+	result := &Result{}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, integrityError(err)
+		}
+		if header.Typeflag != tar.TypeReg || !safeEntry(header.Name) {
+			return nil, fmt.Errorf("%w: 非法条目 %s", ErrIntegrity, header.Name)
+		}
+		if _, ok := found[header.Name]; ok || (header.Name == manifestName && manifest != nil) {
+			return nil, fmt.Errorf("%w: 重复条目 %s", ErrIntegrity, header.Name)
+		}
+
+		if header.Name == manifestName {
+			if header.Size > maxManifestSize {
+				return nil, fmt.Errorf("%w: 清单过大", ErrIntegrity)
+			}
+			manifest = &Manifest{}
+			if err := json.NewDecoder(io.LimitReader(tr, maxManifestSize)).Decode(manifest); err != nil {
+				return nil, fmt.Errorf("%w: 清单无法解析: %v", ErrIntegrity, err)
+			}
+			continue
+		}
+
+		entry, err := extractEntry(tr, header, dest)
+		if err != nil {
+			return nil, err
+		}
+		found[header.Name] = entry
+		result.Files++
+		result.Bytes += entry.Size
+	}
+
+	if manifest == nil {
+		return nil, fmt.Errorf("%w: 缺少清单", ErrIntegrity)
+	}
+	if manifest.Version > FormatVersion {
+		return nil, fmt.Errorf("%w: 不支持的备份格式版本 %d", ErrIntegrity, manifest.Version)
+	}
+	if len(manifest.Entries) != len(found) {
+		return nil, fmt.Errorf("%w: 清单记录 %d 个文件，归档中有 %d 个", ErrIntegrity, len(manifest.Entries), len(found))
+	}
+	for _, want := range manifest.Entries {
+		got, ok := found[want.Path]
+		if !ok {
+			return nil, fmt.Errorf("%w: 缺少文件 %s", ErrIntegrity, want.Path)
+		}
+		if got.Size != want.Size || got.SHA256 != want.SHA256 {
+			return nil, fmt.Errorf("%w: 文件 %s 摘要不符", ErrIntegrity, want.Path)
+		}
+	}
+	result.Manifest = manifest
+	return result, nil
+}
+
+// extractEntry 写出单个文件并计算摘要
+func extractEntry(tr *tar.Reader, header *tar.Header, dest string) (Entry, error) {
+	out := io.Discard
+	var file *os.File
+	if dest != "" {
+		target := filepath.Join(dest, filepath.FromSlash(header.Name))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return Entry{}, err
+		}
+		mode := os.FileMode(header.Mode).Perm() | 0o600
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+		if err != nil {
+			return Entry{}, err
+		}
+		file = f
+		out = f
+	}
+	hash := sha256.New()
+	n, err := io.Copy(io.MultiWriter(out, hash), tr)
+	if file != nil {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			_ = os.Chtimes(file.Name(), time.Now(), header.ModTime)
+		}
+	}
+	if err != nil {
+		return Entry{}, integrityError(err)
+	}
+	return Entry{Path: header.Name, Size: n, SHA256: hex.EncodeToString(hash.Sum(nil))}, nil
+}
+
+// integrityError 解密失败原样返回，其他读取错误归为完整性错误
+func integrityError(err error) error {
+	if stderrors.Is(err, ErrDecrypt) {
+		return err
+	}
+	return fmt.Errorf("%w: %v", ErrIntegrity, err)
+}
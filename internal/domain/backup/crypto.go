@@ -0,0 +1,227 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// 加密格式：
+//
+//	头部   magic "XZB1" | PBKDF2 迭代次数 uint32 | 盐 16 字节 | nonce 前缀 4 字节
+//	数据块 长度 uint32（最高位标记最后一块）| AES-256-GCM 密文
+//
+// 每块的 nonce 为前缀加 8 字节序号，附加数据为头部加最后一块标记，
+// 调换、删除块或截断文件都会导致认证失败。
+const (
+	magic           = "XZB1"
+	saltSize        = 16
+	prefixSize      = 4
+	headerSize      = len(magic) + 4 + saltSize + prefixSize
+	chunkSize       = 64 << 10
+	finalFlag       = 1 << 31
+	kdfIterations   = 600000
+	maxKDFIteration = 10000000
+)
+
+// deriveKey 由口令派生 AES-256 密钥
+func deriveKey(passphrase string, salt []byte, iterations int) ([]byte, error) {
+	return pbkdf2.Key(sha256.New, passphrase, salt, iterations, 32)
+}
+
+// encryptWriter 分块加密写入
+type encryptWriter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	header  []byte
+	prefix  []byte
+	counter uint64
+	buf     []byte
+	closed  bool
+}
+
+// newEncryptWriter 写入头部并返回加密写入器，Close 时写入最后一块，不关闭底层写入器
+func newEncryptWriter(w io.Writer, passphrase string) (*encryptWriter, error) {
+	header := make([]byte, headerSize)
+	copy(header, magic)
+	binary.BigEndian.PutUint32(header[len(magic):], kdfIterations)
+	if _, err := io.ReadFull(rand.Reader, header[len(magic)+4:]); err != nil {
+		return nil, fmt.Errorf("生成盐失败: %w", err)
+	}
+	salt := header[len(magic)+4 : len(magic)+4+saltSize]
+	aead, err := newAEAD(passphrase, salt, kdfIterations)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+	return &encryptWriter{
+		w:      w,
+		aead:   aead,
+		header: header,
+		prefix: header[headerSize-prefixSize:],
+		buf:    make([]byte, 0, chunkSize),
+	}, nil
+}
+
+func (e *encryptWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		// 缓冲区满时留到有后续数据才写出，保证最后一块由 Close 写入
+		if len(e.buf) == chunkSize {
+			if err := e.flush(false); err != nil {
+				return written, err
+			}
+		}
+		n := copy(e.buf[len(e.buf):chunkSize], p)
+		e.buf = e.buf[:len(e.buf)+n]
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+// Close 写入最后一块
+func (e *encryptWriter) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	return e.flush(true)
+}
+
+func (e *encryptWriter) flush(final bool) error {
+	sealed := e.aead.Seal(nil, e.nonce(), e.buf, chunkAAD(e.header, final))
+	length := uint32(len(sealed))
+	if final {
+		length |= finalFlag
+	}
+	var lenBuf [4]byte
+	binary.BigEndian.PutUint32(lenBuf[:], length)
+	if _, err := e.w.Write(lenBuf[:]); err != nil {
+		return err
+	}
+	if _, err := e.w.Write(sealed); err != nil {
+		return err
+	}
+	e.counter++
+	e.buf = e.buf[:0]
+	return nil
+}
+
+func (e *encryptWriter) nonce() []byte {
+	nonce := make([]byte, 12)
+	copy(nonce, e.prefix)
+	binary.BigEndian.PutUint64(nonce[prefixSize:], e.counter)
+	return nonce
+}
+
+// decryptReader 分块解密读取，读到最后一块之前遇到文件结束返回 ErrDecrypt
+type decryptReader struct {
+	r       io.Reader
+	aead    cipher.AEAD
+	header  []byte
+	prefix  []byte
+	counter uint64
+	buf     []byte
+	done    bool
+}
+
+// newDecryptReader 读取头部并派生密钥
+func newDecryptReader(r io.Reader, passphrase string) (*decryptReader, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("%w: 未提供口令", ErrDecrypt)
+	}
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("%w: 文件过短", ErrDecrypt)
+	}
+	if !bytes.Equal(header[:len(magic)], []byte(magic)) {
+		return nil, fmt.Errorf("%w: 不是备份文件", ErrDecrypt)
+	}
+	iterations := binary.BigEndian.Uint32(header[len(magic):])
+	if iterations == 0 || iterations > maxKDFIteration {
+		return nil, fmt.Errorf("%w: 迭代次数无效", ErrDecrypt)
+	}
+	salt := header[len(magic)+4 : len(magic)+4+saltSize]
+	aead, err := newAEAD(passphrase, salt, int(iterations))
+	if err != nil {
+		return nil, err
+	}
+	return &decryptReader{
+		r:      r,
+		aead:   aead,
+		header: header,
+		prefix: header[headerSize-prefixSize:],
+	}, nil
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.buf) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+		if err := d.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, d.buf)
+	d.buf = d.buf[n:]
+	return n, nil
+}
+
+func (d *decryptReader) next() error {
+	var lenBuf [4]byte
+	if _, err := io.ReadFull(d.r, lenBuf[:]); err != nil {
+		return fmt.Errorf("%w: 文件被截断", ErrDecrypt)
+	}
+	length := binary.BigEndian.Uint32(lenBuf[:])
+	final := length&finalFlag != 0
+	length &^= finalFlag
+	if int(length) > chunkSize+d.aead.Overhead() {
+		return fmt.Errorf("%w: 数据块长度无效", ErrDecrypt)
+	}
+	sealed := make([]byte, length)
+	if _, err := io.ReadFull(d.r, sealed); err != nil {
+		return fmt.Errorf("%w: 文件被截断", ErrDecrypt)
+	}
+	nonce := make([]byte, 12)
+	copy(nonce, d.prefix)
+	binary.BigEndian.PutUint64(nonce[prefixSize:], d.counter)
+	plain, err := d.aead.Open(sealed[:0], nonce, sealed, chunkAAD(d.header, final))
+	if err != nil {
+		return ErrDecrypt
+	}
+	d.counter++
+	d.buf = plain
+	d.done = final
+	return nil
+}
+
+func newAEAD(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := deriveKey(passphrase, salt, iterations)
+	if err != nil {
+		return nil, fmt.Errorf("派生密钥失败: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func chunkAAD(header []byte, final bool) []byte {
+	aad := make([]byte, len(header)+1)
+	copy(aad, header)
+	if final {
+		aad[len(header)] = 1
+	}
+	return aad
+}
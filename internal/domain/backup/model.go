@@ -0,0 +1,102 @@
+// Package backup 全量备份与恢复
+//
+// 备份文件是加密的 tar.gz：SQLite 数据库以快照方式写入 database/ 下，配置的文件和目录写入 files/ 下，
+// 最后写入记录各文件 SHA-256 的 MANIFEST.json。恢复时先解密解包到暂存目录并逐一校验，
+// 全部一致后才替换现有数据，被替换的文件改名为 <原路径>.pre-restore-<时间> 保留。
+package backup
+
+import (
+	stderrors "errors"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// FormatVersion 清单格式版本
+	FormatVersion = 1
+	// Extension 备份文件扩展名
+	Extension = ".xzb"
+	// PendingDir 通过接口发起的恢复在此暂存，下次启动时在打开数据库之前应用
+	PendingDir = "data/restore-pending"
+	// PassphraseEnv 加密口令的环境变量，优先于配置
+	PassphraseEnv = "XIAOZHI_BACKUP_PASSPHRASE"
+
+	manifestName = "MANIFEST.json"
+	readyName    = "READY" // 暂存目录校验完成的标记，没有该标记的暂存目录视为未完成
+	databaseDir  = "database"
+	filesDir     = "files"
+	autoPrefix   = "xiaozhi-auto-"
+)
+
+var (
+	// ErrNotFound 备份文件不存在
+	ErrNotFound = stderrors.New("backup not found")
+	// ErrBusy 已有备份或恢复在进行
+	ErrBusy = stderrors.New("another backup or restore is in progress")
+	// ErrDecrypt 口令错误或文件被篡改
+	ErrDecrypt = stderrors.New("wrong passphrase or corrupted backup")
+	// ErrIntegrity 内容与清单不一致
+	ErrIntegrity = stderrors.New("backup integrity check failed")
+
+	namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*\.xzb$`)
+)
+
+// Manifest 备份清单
+type Manifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Driver    string    `json:"driver"`             // 数据库类型
+	Database  string    `json:"database,omitempty"` // SQLite 数据库文件路径，为空表示未包含数据库
+	Roots     []string  `json:"roots"`              // 已备份的文件和目录，恢复时整体替换
+	Entries   []Entry   `json:"entries"`
+}
+
+// Entry 清单中的单个文件
+type Entry struct {
+	Path   string `json:"path"` // 归档内路径
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Archive 备份文件信息
+type Archive struct {
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`
+	Auto      bool      `json:"auto"` // 是否为自动备份，只有自动备份按保留数量清理
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Result 校验或恢复的结果
+type Result struct {
+	Manifest *Manifest `json:"manifest"`
+	Files    int       `json:"files"`
+	Bytes    int64     `json:"bytes"`
+}
+
+// ValidName 校验备份文件名，防止路径穿越
+func ValidName(name string) bool {
+	return namePattern.MatchString(name) && !strings.Contains(name, "..")
+}
+
+// cleanRoot 规范化需要备份的路径，只接受工作目录内的相对路径
+func cleanRoot(p string) (string, bool) {
+	p = path.Clean(strings.ReplaceAll(p, "\\", "/"))
+	if p == "." || path.IsAbs(p) || p == ".." || strings.HasPrefix(p, "../") {
+		return "", false
+	}
+	return p, true
+}
+
+// safeEntry 校验归档内路径，只允许 database/ 和 files/ 下的相对路径
+func safeEntry(name string) bool {
+	if name == manifestName {
+		return true
+	}
+	clean := path.Clean(name)
+	if clean != name || path.IsAbs(clean) || strings.HasPrefix(clean, "../") || strings.Contains(clean, "/../") {
+		return false
+	}
+	return strings.HasPrefix(clean, databaseDir+"/") || strings.HasPrefix(clean, filesDir+"/")
+}
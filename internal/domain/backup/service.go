@@ -0,0 +1,576 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+// nameTimeFormat 备份文件名中的时间格式
+const nameTimeFormat = "20060102-150405"
+
+// Database 需要快照的数据库
+type Database struct {
+	Driver   string                                       // sqlite、mysql、postgresql
+	Path     string                                       // SQLite 数据库文件路径
+	Snapshot func(ctx context.Context, dest string) error // 写出一致的数据库快照，为 nil 表示该数据库不支持快照
+}
+
+// Service 备份服务
+type Service struct {
+	cfg        config.BackupConfig
+	passphrase string
+	db         Database
+	logger     *logging.Logger
+	now        func() time.Time
+
+	// mu 同一时刻只允许一个备份、导入或恢复
+	mu sync.Mutex
+}
+
+// NewService 创建备份服务，口令优先取环境变量 XIAOZHI_BACKUP_PASSPHRASE
+func NewService(cfg config.BackupConfig, db Database, logger *logging.Logger) (*Service, error) {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	if cfg.Dir == "" {
+		cfg.Dir = "data/backups"
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o700); err != nil {
+		return nil, fmt.Errorf("创建备份目录失败: %w", err)
+	}
+	passphrase := os.Getenv(PassphraseEnv)
+	if passphrase == "" {
+		passphrase = cfg.Passphrase
+	}
+	return &Service{
+		cfg:        cfg,
+		passphrase: passphrase,
+		db:         db,
+		logger:     logger,
+		now:        time.Now,
+	}, nil
+}
+
+// UsePassphrase 使用指定口令，供命令行参数覆盖配置
+func (s *Service) UsePassphrase(passphrase string) {
+	if passphrase != "" {
+		s.passphrase = passphrase
+	}
+}
+
+// Dir 备份目录
+func (s *Service) Dir() string {
+	return s.cfg.Dir
+}
+
+// Config 备份配置，口令已清除
+func (s *Service) Config() config.BackupConfig {
+	cfg := s.cfg
+	cfg.Passphrase = ""
+	return cfg
+}
+
+// Create 在备份目录中创建备份，auto 为 true 时作为自动备份参与保留数量清理
+func (s *Service) Create(ctx context.Context, auto bool) (*Archive, error) {
+	if !s.mu.TryLock() {
+		return nil, errors.Wrap(errors.KindDomain, "backup.create", "backup is busy", ErrBusy)
+	}
+	defer s.mu.Unlock()
+
+	prefix := "xiaozhi-"
+	if auto {
+		prefix = autoPrefix
+	}
+	name := s.uniqueName(prefix)
+	tmp := filepath.Join(s.cfg.Dir, "."+name+".tmp")
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "backup.create", "failed to create backup file", err)
+	}
+	defer os.Remove(tmp)
+
+	start := s.now()
+	manifest, err := s.write(ctx, f)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "backup.create", "failed to write backup", err)
+	}
+	target := filepath.Join(s.cfg.Dir, name)
+	if err := os.Rename(tmp, target); err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "backup.create", "failed to save backup", err)
+	}
+
+	archive, err := s.stat(name)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.InfoTag("备份", "已创建备份 %s，%d 个文件，%d 字节，耗时 %s",
+		name, len(manifest.Entries), archive.Size, s.now().Sub(start).Round(time.Millisecond))
+	return archive, nil
+}
+
+// WriteTo 把备份写入 w，用于命令行输出到指定文件
+func (s *Service) WriteTo(ctx context.Context, w io.Writer) (*Manifest, error) {
+	if !s.mu.TryLock() {
+		return nil, errors.Wrap(errors.KindDomain, "backup.write", "backup is busy", ErrBusy)
+	}
+	defer s.mu.Unlock()
+	return s.write(ctx, w)
+}
+
+// write 写出数据库快照、配置的文件和清单
+func (s *Service) write(ctx context.Context, w io.Writer) (*Manifest, error) {
+	if s.passphrase == "" {
+		return nil, errors.New(errors.KindDomain, "backup.write", "backup passphrase is not configured, set Backup.Passphrase or "+PassphraseEnv)
+	}
+	manifest := &Manifest{
+		Version:   FormatVersion,
+		CreatedAt: s.now().UTC(),
+		Driver:    s.db.Driver,
+	}
+	aw, err := newArchiveWriter(w, s.passphrase, manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.db.Snapshot != nil {
+		snapshot := filepath.Join(s.cfg.Dir, fmt.Sprintf(".snapshot-%d.db", s.now().UnixNano()))
+		defer os.Remove(snapshot)
+		if err := s.db.Snapshot(ctx, snapshot); err != nil {
+			return nil, fmt.Errorf("数据库快照失败: %w", err)
+		}
+		if err := aw.addFile(databaseDir+"/"+filepath.Base(s.db.Path), snapshot); err != nil {
+			return nil, err
+		}
+		manifest.Database = s.db.Path
+	} else {
+		s.logger.WarnTag("备份", "数据库类型 %s 不支持快照，备份中不包含数据库，请使用数据库自带的备份工具", s.db.Driver)
+	}
+
+	skip := s.skipPaths()
+	for _, p := range s.cfg.Paths {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		root, ok := cleanRoot(p)
+		if !ok {
+			s.logger.WarnTag("备份", "跳过 %s：只能备份工作目录内的相对路径", p)
+			continue
+		}
+		if containsAny(root, skip) {
+			s.logger.WarnTag("备份", "跳过 %s：不能包含备份目录或恢复暂存目录，请列出其中的子目录", p)
+			continue
+		}
+		if _, err := os.Lstat(root); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		if err := aw.addRoot(root, skip); err != nil {
+			return nil, fmt.Errorf("备份 %s 失败: %w", root, err)
+		}
+		manifest.Roots = append(manifest.Roots, root)
+	}
+
+	if err := aw.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// skipPaths 不能写入备份的路径：备份目录、恢复暂存目录和数据库文件本身（以快照代替）
+func (s *Service) skipPaths() map[string]bool {
+	skip := map[string]bool{
+		filepath.Clean(s.cfg.Dir):  true,
+		filepath.Clean(PendingDir): true,
+	}
+	if s.db.Path != "" {
+		db := filepath.Clean(s.db.Path)
+		for _, suffix := range []string{"", "-wal", "-shm", "-journal"} {
+			skip[db+suffix] = true
+		}
+	}
+	return skip
+}
+
+// List 列出备份，最新的在前
+func (s *Service) List() ([]*Archive, error) {
+	entries, err := os.ReadDir(s.cfg.Dir)
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "backup.list", "failed to read backup dir", err)
+	}
+	archives := make([]*Archive, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !ValidName(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		archives = append(archives, toArchive(info))
+	}
+	sort.Slice(archives, func(i, j int) bool {
+		return archives[i].CreatedAt.After(archives[j].CreatedAt)
+	})
+	return archives, nil
+}
+
+// Open 打开备份文件用于下载
+func (s *Service) Open(name string) (*os.File, *Archive, error) {
+	archive, err := s.stat(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(filepath.Join(s.cfg.Dir, name))
+	if err != nil {
+		return nil, nil, errors.Wrap(errors.KindStorage, "backup.open", "failed to open backup", err)
+	}
+	return f, archive, nil
+}
+
+// Delete 删除备份
+func (s *Service) Delete(name string) error {
+	if _, err := s.stat(name); err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(s.cfg.Dir, name)); err != nil {
+		return errors.Wrap(errors.KindStorage, "backup.delete", "failed to delete backup", err)
+	}
+	s.logger.InfoTag("备份", "已删除备份 %s", name)
+	return nil
+}
+
+// Verify 解密并校验备份中每个文件的摘要，不写出任何文件；passphrase 为空时使用配置的口令
+func (s *Service) Verify(ctx context.Context, name, passphrase string) (*Result, error) {
+	f, _, err := s.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	result, err := VerifyArchive(contextReader(ctx, f), s.resolve(passphrase))
+	if err != nil {
+		return nil, verifyError("backup.verify", err)
+	}
+	return result, nil
+}
+
+// Import 导入外部备份文件，校验通过后才保存到备份目录
+func (s *Service) Import(ctx context.Context, r io.Reader, passphrase string) (*Archive, *Result, error) {
+	if !s.mu.TryLock() {
+		return nil, nil, errors.Wrap(errors.KindDomain, "backup.import", "backup is busy", ErrBusy)
+	}
+	defer s.mu.Unlock()
+
+	name := s.uniqueName("xiaozhi-import-")
+	tmp := filepath.Join(s.cfg.Dir, "."+name+".tmp")
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_RDWR|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, nil, errors.Wrap(errors.KindStorage, "backup.import", "failed to create backup file", err)
+	}
+	defer os.Remove(tmp)
+	defer f.Close()
+
+	if _, err := io.Copy(f, contextReader(ctx, r)); err != nil {
+		return nil, nil, errors.Wrap(errors.KindStorage, "backup.import", "failed to save upload", err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, nil, errors.Wrap(errors.KindStorage, "backup.import", "failed to rewind upload", err)
+	}
+	result, err := VerifyArchive(f, s.resolve(passphrase))
+	if err != nil {
+		return nil, nil, verifyError("backup.import", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, nil, errors.Wrap(errors.KindStorage, "backup.import", "failed to save upload", err)
+	}
+	if err := os.Rename(tmp, filepath.Join(s.cfg.Dir, name)); err != nil {
+		return nil, nil, errors.Wrap(errors.KindStorage, "backup.import", "failed to save backup", err)
+	}
+	archive, err := s.stat(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.logger.InfoTag("备份", "已导入备份 %s，%d 个文件", name, result.Files)
+	return archive, result, nil
+}
+
+// StageRestore 校验备份并解包到恢复暂存目录，服务下次启动时在打开数据库之前替换现有数据
+func (s *Service) StageRestore(ctx context.Context, name, passphrase string) (*Result, error) {
+	if !s.mu.TryLock() {
+		return nil, errors.Wrap(errors.KindDomain, "backup.restore", "backup is busy", ErrBusy)
+	}
+	defer s.mu.Unlock()
+
+	f, _, err := s.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	result, err := Stage(contextReader(ctx, f), s.resolve(passphrase), PendingDir)
+	if err != nil {
+		return nil, verifyError("backup.restore", err)
+	}
+	s.logger.InfoTag("备份", "备份 %s 已校验并暂存到 %s，重启服务后生效", name, PendingDir)
+	return result, nil
+}
+
+// Run 按间隔创建自动备份，并只保留最近 Keep 个自动备份
+func (s *Service) Run(ctx context.Context) error {
+	if s.cfg.Interval <= 0 {
+		s.logger.InfoTag("备份", "未配置自动备份间隔，只支持手动备份")
+		return nil
+	}
+	if s.passphrase == "" {
+		s.logger.WarnTag("备份", "未配置备份口令（Backup.Passphrase 或 %s），自动备份未启用", PassphraseEnv)
+		return nil
+	}
+	s.logger.InfoTag("备份", "自动备份已启用，间隔 %s，保留 %d 个", s.cfg.Interval, s.cfg.Keep)
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := s.Create(ctx, true); err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				s.logger.ErrorTag("备份", "自动备份失败: %v", err)
+				continue
+			}
+			s.prune()
+		}
+	}
+}
+
+// prune 删除超出保留数量的自动备份
+func (s *Service) prune() {
+	if s.cfg.Keep <= 0 {
+		return
+	}
+	archives, err := s.List()
+	if err != nil {
+		s.logger.WarnTag("备份", "清理自动备份失败: %v", err)
+		return
+	}
+	kept := 0
+	for _, archive := range archives {
+		if !archive.Auto {
+			continue
+		}
+		kept++
+		if kept <= s.cfg.Keep {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.cfg.Dir, archive.Name)); err != nil {
+			s.logger.WarnTag("备份", "删除过期的自动备份 %s 失败: %v", archive.Name, err)
+		}
+	}
+}
+
+func (s *Service) resolve(passphrase string) string {
+	if passphrase != "" {
+		return passphrase
+	}
+	return s.passphrase
+}
+
+func (s *Service) stat(name string) (*Archive, error) {
+	if !ValidName(name) {
+		return nil, errors.Wrap(errors.KindDomain, "backup.stat", "backup not found", ErrNotFound)
+	}
+	info, err := os.Stat(filepath.Join(s.cfg.Dir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Wrap(errors.KindDomain, "backup.stat", "backup not found", ErrNotFound)
+		}
+		return nil, errors.Wrap(errors.KindStorage, "backup.stat", "failed to stat backup", err)
+	}
+	return toArchive(info), nil
+}
+
+// uniqueName 生成备份文件名，同一秒内重复时追加序号
+func (s *Service) uniqueName(prefix string) string {
+	base := prefix + s.now().Format(nameTimeFormat)
+	name := base + Extension
+	for i := 2; ; i++ {
+		if _, err := os.Stat(filepath.Join(s.cfg.Dir, name)); os.IsNotExist(err) {
+			return name
+		}
+		name = fmt.Sprintf("%s-%d%s", base, i, Extension)
+	}
+}
+
+// VerifyArchive 解密并校验备份内容，不写出任何文件
+func VerifyArchive(r io.Reader, passphrase string) (*Result, error) {
+	return extract(r, passphrase, "")
+}
+
+// Stage 解密、解包并校验备份到 dir，全部通过后写入完成标记；失败时清除 dir
+func Stage(r io.Reader, passphrase, dir string) (*Result, error) {
+	if passphrase == "" {
+		return nil, errors.New(errors.KindDomain, "backup.stage", "backup passphrase is required")
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	result, err := extract(r, passphrase, dir)
+	if err == nil {
+		var data []byte
+		data, err = json.Marshal(result.Manifest)
+		if err == nil {
+			err = os.WriteFile(filepath.Join(dir, manifestName), data, 0o600)
+		}
+	}
+	if err == nil {
+		err = os.WriteFile(filepath.Join(dir, readyName), nil, 0o600)
+	}
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	return result, nil
+}
+
+// ApplyPending 应用暂存目录中已校验的恢复，必须在打开数据库之前调用
+// 没有暂存内容时返回 nil；未完成校验的暂存目录直接清除
+func ApplyPending(dir string, now time.Time) (*Manifest, error) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, nil
+	}
+	if _, err := os.Stat(filepath.Join(dir, readyName)); err != nil {
+		return nil, os.RemoveAll(dir)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, manifestName))
+	if err != nil {
+		return nil, fmt.Errorf("读取恢复清单失败: %w", err)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("解析恢复清单失败: %w", err)
+	}
+
+	suffix := ".pre-restore-" + now.Format(nameTimeFormat)
+	for _, p := range manifest.Roots {
+		root, ok := cleanRoot(p)
+		if !ok {
+			return nil, fmt.Errorf("恢复清单包含非法路径 %s", p)
+		}
+		src := filepath.Join(dir, filesDir, filepath.FromSlash(root))
+		if _, err := os.Stat(src); os.IsNotExist(err) {
+			continue // 备份时为空目录
+		}
+		if err := replace(src, filepath.FromSlash(root), suffix); err != nil {
+			return nil, err
+		}
+	}
+	if manifest.Database != "" {
+		src := filepath.Join(dir, databaseDir, filepath.Base(manifest.Database))
+		// WAL 和共享内存文件属于旧数据库，一并移开
+		for _, extra := range []string{"-wal", "-shm", "-journal"} {
+			if err := moveAside(manifest.Database+extra, suffix); err != nil {
+				return nil, err
+			}
+		}
+		if err := replace(src, manifest.Database, suffix); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return &manifest, fmt.Errorf("恢复已完成，清除暂存目录失败: %w", err)
+	}
+	return &manifest, nil
+}
+
+// replace 把现有文件或目录改名保留，再把暂存内容移到原位置
+func replace(src, target, suffix string) error {
+	if err := moveAside(target, suffix); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	if err := os.Rename(src, target); err != nil {
+		return fmt.Errorf("恢复 %s 失败: %w", target, err)
+	}
+	return nil
+}
+
+func moveAside(target, suffix string) error {
+	if _, err := os.Lstat(target); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err := os.Rename(target, target+suffix); err != nil {
+		return fmt.Errorf("保留现有的 %s 失败: %w", target, err)
+	}
+	return nil
+}
+
+// containsAny root 是否为 paths 中某个路径本身或其上级目录
+func containsAny(root string, paths map[string]bool) bool {
+	root = filepath.Clean(filepath.FromSlash(root))
+	for p := range paths {
+		if p == root || strings.HasPrefix(p, root+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+func toArchive(info os.FileInfo) *Archive {
+	return &Archive{
+		Name:      info.Name(),
+		Size:      info.Size(),
+		Auto:      strings.HasPrefix(info.Name(), autoPrefix),
+		CreatedAt: info.ModTime(),
+	}
+}
+
+// verifyError 口令错误和校验失败归为领域错误，其余为存储错误
+func verifyError(op string, err error) error {
+	if stderrors.Is(err, ErrDecrypt) || stderrors.Is(err, ErrIntegrity) {
+		return errors.Wrap(errors.KindDomain, op, "backup verification failed", err)
+	}
+	return errors.Wrap(errors.KindStorage, op, "failed to read backup", err)
+}
+
+// contextReader 读取前检查 ctx，取消后停止校验或导入
+func contextReader(ctx context.Context, r io.Reader) io.Reader {
+	return readerFunc(func(p []byte) (int, error) {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		return r.Read(p)
+	})
+}
+
+type readerFunc func(p []byte) (int, error)
+
+func (f readerFunc) Read(p []byte) (int, error) { return f(p) }
@@ -0,0 +1,71 @@
+package budget
+
+import (
+	stderrors "errors"
+	"fmt"
+)
+
+// ErrExceeded 超出Token预算，可通过 errors.Is 判断
+var ErrExceeded = stderrors.New("token budget exceeded")
+
+// Scope 预算的计量范围
+type Scope string
+
+const (
+	ScopeTurn    Scope = "turn"    // 一轮对话，包括工具调用后的再次生成
+	ScopeSession Scope = "session" // 一次连接会话
+	ScopeDaily   Scope = "daily"   // 设备当天
+)
+
+// 超出预算时的内置播报
+const (
+	defaultTurnReply    = "这个问题有点复杂，超出了我一次能回答的范围，换个简单点的问法吧"
+	defaultSessionReply = "这次我们聊得有点久了，先休息一下，待会儿再来找我吧"
+	defaultDailyReply   = "我今天的对话额度已经用完了，明天再来找我聊天吧"
+)
+
+// Limits 设备适用的Token上限，0 表示不限制
+type Limits struct {
+	Profile       string `json:"profile"` // 预算名称，没有匹配的预算时为空
+	TurnTokens    int64  `json:"turn_tokens"`
+	SessionTokens int64  `json:"session_tokens"`
+	DailyTokens   int64  `json:"daily_tokens"`
+}
+
+// limit 返回范围对应的上限
+func (l Limits) limit(scope Scope) int64 {
+	switch scope {
+	case ScopeTurn:
+		return l.TurnTokens
+	case ScopeSession:
+		return l.SessionTokens
+	default:
+		return l.DailyTokens
+	}
+}
+
+// Usage 设备某一天的Token用量
+type Usage struct {
+	DeviceID string `json:"device_id"`
+	Day      string `json:"day"` // YYYY-MM-DD
+	Tokens   int64  `json:"tokens"`
+	Calls    int64  `json:"calls"`    // LLM调用次数
+	Rejected int64  `json:"rejected"` // 因超出预算未调用LLM的次数
+}
+
+// ExceededError 超出预算错误
+type ExceededError struct {
+	Scope Scope
+	Limit int64
+	Used  int64  // 已用量加上本次调用的估算
+	Reply string // 播报给设备的提示
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("%s token budget exceeded: %d/%d", e.Scope, e.Used, e.Limit)
+}
+
+// Is 使 errors.Is(err, ErrExceeded) 成立
+func (e *ExceededError) Is(target error) bool {
+	return target == ErrExceeded
+}
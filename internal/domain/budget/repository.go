@@ -0,0 +1,17 @@
+package budget
+
+import (
+	"context"
+)
+
+// Repository 设备Token用量仓库接口
+type Repository interface {
+	// AddUsage 累加设备当天的用量
+	AddUsage(ctx context.Context, deviceID, day string, tokens, calls, rejected int64) error
+
+	// GetUsage 查询设备某一天的用量，没有记录时返回零值
+	GetUsage(ctx context.Context, deviceID, day string) (*Usage, error)
+
+	// ListUsage 列出某一天有用量的设备，按 Token 数从多到少排序
+	ListUsage(ctx context.Context, day string, limit int) ([]*Usage, error)
+}
@@ -0,0 +1,288 @@
+package budget
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/eventbus"
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+const (
+	dayLayout      = "2006-01-02"
+	defaultProfile = "default"
+)
+
+// dailyUsage 设备当天用量的内存计数
+type dailyUsage struct {
+	day    string
+	tokens int64
+}
+
+// Service Token预算服务，按设备使用的预算检查每轮、每次会话和每天的用量
+type Service struct {
+	cfg    config.BudgetConfig
+	repo   Repository
+	logger *logging.Logger
+	now    func() time.Time
+
+	usageMu sync.Mutex
+	usage   map[string]*dailyUsage // 设备ID到当天用量
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局预算服务，供连接处理器使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局预算服务，未初始化时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建预算服务
+func NewService(cfg config.BudgetConfig, repo Repository, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Service{
+		cfg:    cfg,
+		repo:   repo,
+		logger: logger,
+		now:    time.Now,
+		usage:  make(map[string]*dailyUsage),
+	}
+}
+
+// Enabled 是否启用预算检查
+func (s *Service) Enabled() bool {
+	return s.cfg.Enabled
+}
+
+// Limits 返回设备适用的上限，设备未指定预算时使用 default，没有匹配的预算时不限制
+func (s *Service) Limits(deviceID string) Limits {
+	name := s.cfg.DeviceProfiles[deviceID]
+	if name == "" {
+		name = defaultProfile
+	}
+	profile, ok := s.cfg.Profiles[name]
+	if !ok {
+		return Limits{}
+	}
+	return Limits{
+		Profile:       name,
+		TurnTokens:    profile.TurnTokens,
+		SessionTokens: profile.SessionTokens,
+		DailyTokens:   profile.DailyTokens,
+	}
+}
+
+// Usage 查询设备某一天的用量，day 为空时查询当天
+func (s *Service) Usage(ctx context.Context, deviceID, day string) (*Usage, error) {
+	day, err := s.normalizeDay(day)
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.repo.GetUsage(ctx, deviceID, day)
+	if err != nil {
+		return nil, err
+	}
+	u.DeviceID, u.Day = deviceID, day
+	return u, nil
+}
+
+// ListUsage 列出某一天用量最多的设备，day 为空时查询当天
+func (s *Service) ListUsage(ctx context.Context, day string, limit int) ([]*Usage, error) {
+	day, err := s.normalizeDay(day)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	return s.repo.ListUsage(ctx, day, limit)
+}
+
+func (s *Service) normalizeDay(day string) (string, error) {
+	day = strings.TrimSpace(day)
+	if day == "" {
+		return s.now().Format(dayLayout), nil
+	}
+	if _, err := time.Parse(dayLayout, day); err != nil {
+		return "", errors.New(errors.KindDomain, "budget.usage", "day must be formatted as YYYY-MM-DD")
+	}
+	return day, nil
+}
+
+// NewSession 创建一次连接会话的预算计量
+func (s *Service) NewSession(deviceID, sessionID string) *Session {
+	return &Session{
+		service:   s,
+		deviceID:  deviceID,
+		sessionID: sessionID,
+		limits:    s.Limits(deviceID),
+		round:     -1,
+	}
+}
+
+// reply 返回超出范围上限时的播报
+func (s *Service) reply(profile string, scope Scope) string {
+	p := s.cfg.Profiles[profile]
+	switch scope {
+	case ScopeTurn:
+		if p.TurnReply != "" {
+			return p.TurnReply
+		}
+		return defaultTurnReply
+	case ScopeSession:
+		if p.SessionReply != "" {
+			return p.SessionReply
+		}
+		return defaultSessionReply
+	default:
+		if p.DailyReply != "" {
+			return p.DailyReply
+		}
+		return defaultDailyReply
+	}
+}
+
+// dailyTokens 返回设备当天已用的Token，跨天或首次使用时从数据库恢复
+func (s *Service) dailyTokens(ctx context.Context, deviceID, day string) (int64, error) {
+	s.usageMu.Lock()
+	defer s.usageMu.Unlock()
+	u, err := s.dailyUsage(ctx, deviceID, day)
+	if err != nil {
+		return 0, err
+	}
+	return u.tokens, nil
+}
+
+// dailyUsage 返回设备当天的计数；调用方需持有 usageMu
+func (s *Service) dailyUsage(ctx context.Context, deviceID, day string) (*dailyUsage, error) {
+	if u, ok := s.usage[deviceID]; ok && u.day == day {
+		return u, nil
+	}
+	stored, err := s.repo.GetUsage(ctx, deviceID, day)
+	if err != nil {
+		return nil, err
+	}
+	u := &dailyUsage{day: day, tokens: stored.Tokens}
+	s.usage[deviceID] = u
+	return u, nil
+}
+
+// record 累加设备当天的用量并写入数据库
+func (s *Service) record(deviceID, day string, tokens, calls, rejected int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s.usageMu.Lock()
+	if u, err := s.dailyUsage(ctx, deviceID, day); err == nil {
+		u.tokens += tokens
+	}
+	s.usageMu.Unlock()
+
+	if err := s.repo.AddUsage(ctx, deviceID, day, tokens, calls, rejected); err != nil {
+		s.logger.WarnTag("预算", "记录设备 %s 的Token用量失败: %v", deviceID, err)
+	}
+}
+
+// Session 一次连接会话的预算计量，可并发使用
+type Session struct {
+	service   *Service
+	deviceID  string
+	sessionID string
+	limits    Limits
+
+	mu      sync.Mutex
+	round   int   // 当前轮次
+	turn    int64 // 当前轮次已用
+	session int64 // 本次会话已用
+}
+
+// Limits 返回会话适用的上限
+func (s *Session) Limits() Limits {
+	return s.limits
+}
+
+// Check 在调用LLM之前检查预算，estimate 为本次调用提示词的估算；
+// 超出时返回 *ExceededError（可用 errors.Is(err, ErrExceeded) 判断），记录拒绝次数并发布 EventBudgetExceeded 事件
+func (s *Session) Check(ctx context.Context, round int, estimate int64) error {
+	svc := s.service
+	now := svc.now()
+	day := now.Format(dayLayout)
+
+	var daily int64
+	if s.limits.DailyTokens > 0 {
+		var err error
+		if daily, err = svc.dailyTokens(ctx, s.deviceID, day); err != nil {
+			// 加载失败时不限制当天用量，避免影响对话
+			svc.logger.WarnTag("预算", "加载设备 %s 的Token用量失败: %v", s.deviceID, err)
+		}
+	}
+
+	s.mu.Lock()
+	turn := s.turn
+	if round != s.round {
+		turn = 0
+	}
+	used := map[Scope]int64{ScopeDaily: daily, ScopeSession: s.session, ScopeTurn: turn}
+	s.mu.Unlock()
+
+	for _, scope := range []Scope{ScopeDaily, ScopeSession, ScopeTurn} {
+		limit := s.limits.limit(scope)
+		if limit <= 0 || used[scope]+estimate <= limit {
+			continue
+		}
+		exceeded := &ExceededError{
+			Scope: scope,
+			Limit: limit,
+			Used:  used[scope] + estimate,
+			Reply: svc.reply(s.limits.Profile, scope),
+		}
+		svc.logger.InfoTag("预算", "设备 %s 超出%s Token预算: %d/%d", s.deviceID, scope, exceeded.Used, limit)
+		go svc.record(s.deviceID, day, 0, 0, 1)
+		eventbus.Publish(eventbus.EventBudgetExceeded, eventbus.BudgetEventData{
+			DeviceID:  s.deviceID,
+			SessionID: s.sessionID,
+			Scope:     string(scope),
+			Limit:     limit,
+			Used:      exceeded.Used,
+			Timestamp: now,
+		})
+		return exceeded
+	}
+	return nil
+}
+
+// Record 记录一次LLM调用的用量，round 为调用所在的轮次
+func (s *Session) Record(round int, tokens int64) {
+	if tokens < 0 {
+		tokens = 0
+	}
+	s.mu.Lock()
+	switch {
+	case round > s.round:
+		s.round, s.turn = round, tokens
+	case round == s.round:
+		s.turn += tokens
+	}
+	s.session += tokens
+	s.mu.Unlock()
+
+	s.service.record(s.deviceID, s.service.now().Format(dayLayout), tokens, 1, 0)
+}
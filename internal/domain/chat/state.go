@@ -0,0 +1,123 @@
+package chat
+
+import (
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/eventbus"
+)
+
+// DialogueState 对话状态
+type DialogueState string
+
+const (
+	StateIdle      DialogueState = "idle"      // 连接建立前或已关闭
+	StateListening DialogueState = "listening" // 等待/接收用户语音
+	StateThinking  DialogueState = "thinking"  // 已收到用户语句，等待LLM/意图处理
+	StateSpeaking  DialogueState = "speaking"  // 正在播放服务端语音
+)
+
+// validTransitions 允许的状态迁移
+var validTransitions = map[DialogueState][]DialogueState{
+	StateIdle:      {StateListening, StateThinking, StateSpeaking},
+	StateListening: {StateThinking, StateSpeaking, StateIdle},
+	StateThinking:  {StateSpeaking, StateListening, StateThinking, StateIdle},
+	StateSpeaking:  {StateListening, StateThinking, StateSpeaking, StateIdle},
+}
+
+// StateMachine 多轮对话状态机，状态变化通过事件总线发布给UI
+type StateMachine struct {
+	mu        sync.RWMutex
+	sessionID string
+	deviceID  string
+	state     DialogueState
+	round     int
+	changedAt time.Time
+}
+
+// NewStateMachine 创建对话状态机，初始状态为 idle
+func NewStateMachine(sessionID, deviceID string) *StateMachine {
+	return &StateMachine{
+		sessionID: sessionID,
+		deviceID:  deviceID,
+		state:     StateIdle,
+		changedAt: time.Now(),
+	}
+}
+
+// State 当前状态
+func (m *StateMachine) State() DialogueState {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.state
+}
+
+// Round 最近一次状态迁移所在的轮次
+func (m *StateMachine) Round() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.round
+}
+
+// IsBusy 服务端是否正在思考或说话，此时新的用户语音视为打断
+func (m *StateMachine) IsBusy() bool {
+	s := m.State()
+	return s == StateThinking || s == StateSpeaking
+}
+
+// Transition 迁移到新状态，非法迁移或状态未变化时返回 false
+func (m *StateMachine) Transition(to DialogueState, round int, reason string) bool {
+	m.mu.Lock()
+	from := m.state
+	if from == to && to != StateThinking {
+		m.mu.Unlock()
+		return false
+	}
+	allowed := false
+	for _, s := range validTransitions[from] {
+		if s == to {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		m.mu.Unlock()
+		return false
+	}
+	m.state = to
+	m.round = round
+	now := time.Now()
+	duration := now.Sub(m.changedAt)
+	m.changedAt = now
+	m.mu.Unlock()
+
+	eventbus.Publish(eventbus.EventDialogueStateChanged, eventbus.DialogueStateEventData{
+		SessionID:  m.sessionID,
+		DeviceID:   m.deviceID,
+		From:       string(from),
+		To:         string(to),
+		Round:      round,
+		Reason:     reason,
+		DurationMs: duration.Milliseconds(),
+		Timestamp:  now,
+	})
+	return true
+}
+
+// BargeIn 发布打断事件并迁移到 thinking 状态
+func (m *StateMachine) BargeIn(round int, text string) {
+	m.mu.RLock()
+	from := m.state
+	m.mu.RUnlock()
+
+	eventbus.Publish(eventbus.EventDialogueBargeIn, eventbus.DialogueStateEventData{
+		SessionID: m.sessionID,
+		DeviceID:  m.deviceID,
+		From:      string(from),
+		To:        string(StateThinking),
+		Round:     round,
+		Reason:    text,
+		Timestamp: time.Now(),
+	})
+	m.Transition(StateThinking, round, "barge-in")
+}
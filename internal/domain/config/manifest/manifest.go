@@ -0,0 +1,212 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"xiaozhi-server-go/internal/domain/prompt"
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/workflow"
+)
+
+// Manifest 声明式配置清单
+// 未出现的部分保持现状；出现的部分（包括空列表）视为该类资源的完整期望状态，清单中没有的同类资源会被删除
+type Manifest struct {
+	// Providers 按类型（llm/tts/asr/vllm）列出供应商配置，键为供应商ID；只对出现的类型做增删
+	Providers map[string]map[string]json.RawMessage `json:"providers,omitempty"`
+	// Prompts 提示词模板，与当前生效版本比较，修改时生成新版本
+	Prompts []PromptSpec `json:"prompts,omitempty"`
+	// DeviceGroups 设备分组，组内设备分配同一个提示词模板
+	DeviceGroups []DeviceGroup `json:"device_groups,omitempty"`
+	// Workflow 当前工作流
+	Workflow *workflow.Workflow `json:"workflow,omitempty"`
+}
+
+// PromptSpec 提示词模板的期望状态
+type PromptSpec struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Content     string            `json:"content"`
+	Variables   map[string]string `json:"variables,omitempty"`
+}
+
+// DeviceGroup 设备分组，设备ID可以为 "*" 表示全局默认模板
+// 分组本身不单独存储，应用时展开为各设备的提示词模板分配
+type DeviceGroup struct {
+	Name          string   `json:"name"`
+	Devices       []string `json:"devices"`
+	Prompt        string   `json:"prompt"`
+	PromptVersion int      `json:"prompt_version,omitempty"` // 0 表示跟随当前生效版本
+}
+
+// providerSections 供应商类型到配置字段的映射
+var providerSections = map[string]func(cfg *config.Config) interface{}{
+	"llm":  func(cfg *config.Config) interface{} { return &cfg.LLM },
+	"tts":  func(cfg *config.Config) interface{} { return &cfg.TTS },
+	"asr":  func(cfg *config.Config) interface{} { return &cfg.ASR },
+	"vllm": func(cfg *config.Config) interface{} { return &cfg.VLLLM },
+}
+
+// validate 校验清单自身的一致性，不访问存储
+func (m *Manifest) validate() error {
+	for kind, providers := range m.Providers {
+		section, ok := providerSections[kind]
+		if !ok {
+			return invalid("不支持的供应商类型: %s", kind)
+		}
+		elem := reflect.TypeOf(section(&config.Config{})).Elem().Elem()
+		for id, raw := range providers {
+			if strings.TrimSpace(id) == "" {
+				return invalid("%s 供应商ID不能为空", kind)
+			}
+			if _, err := decodeProvider(elem, raw); err != nil {
+				return invalid("供应商 %s/%s 配置无效: %v", kind, id, err)
+			}
+		}
+	}
+
+	names := make(map[string]bool, len(m.Prompts))
+	for _, p := range m.Prompts {
+		if names[p.Name] {
+			return invalid("提示词模板 %s 重复", p.Name)
+		}
+		names[p.Name] = true
+		if strings.TrimSpace(p.Content) == "" {
+			return invalid("提示词模板 %s 内容不能为空", p.Name)
+		}
+		if _, err := prompt.Parse(p.Name, p.Content); err != nil {
+			return invalid("提示词模板 %s 语法错误: %v", p.Name, err)
+		}
+	}
+
+	groups := make(map[string]bool, len(m.DeviceGroups))
+	devices := make(map[string]string)
+	for _, g := range m.DeviceGroups {
+		if strings.TrimSpace(g.Name) == "" {
+			return invalid("设备分组名称不能为空")
+		}
+		if groups[g.Name] {
+			return invalid("设备分组 %s 重复", g.Name)
+		}
+		groups[g.Name] = true
+		if g.Prompt == "" {
+			return invalid("设备分组 %s 未指定提示词模板", g.Name)
+		}
+		if m.Prompts != nil && !names[g.Prompt] {
+			return invalid("设备分组 %s 引用的提示词模板 %s 不在清单中", g.Name, g.Prompt)
+		}
+		for _, deviceID := range g.Devices {
+			if strings.TrimSpace(deviceID) == "" {
+				return invalid("设备分组 %s 包含空的设备ID", g.Name)
+			}
+			if other, ok := devices[deviceID]; ok {
+				return invalid("设备 %s 同时属于分组 %s 和 %s", deviceID, other, g.Name)
+			}
+			devices[deviceID] = g.Name
+		}
+	}
+
+	if m.Workflow != nil {
+		if err := workflow.ValidateNodeExpressions(m.Workflow); err != nil {
+			return invalid("工作流无效: %v", err)
+		}
+	}
+	return nil
+}
+
+// decodeProvider 按配置字段的类型严格解码，拼错的字段名视为错误；返回规范化后的值
+func decodeProvider(elem reflect.Type, raw json.RawMessage) (interface{}, error) {
+	value := reflect.New(elem)
+	decoder := json.NewDecoder(strings.NewReader(string(raw)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(value.Interface()); err != nil {
+		return nil, err
+	}
+	return normalize(value.Elem().Interface())
+}
+
+func invalid(format string, args ...interface{}) error {
+	return errors.New(errors.KindDomain, "manifest.validate", fmt.Sprintf(format, args...))
+}
+
+// normalize 经过一次 JSON 编解码，使结构体和 map 可以按值比较
+func normalize(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// changedFields 返回两个对象中值不同的顶层字段
+func changedFields(before, after interface{}) []string {
+	b, _ := before.(map[string]interface{})
+	a, _ := after.(map[string]interface{})
+	keys := make(map[string]bool, len(a)+len(b))
+	for k := range b {
+		keys[k] = true
+	}
+	for k := range a {
+		keys[k] = true
+	}
+	var fields []string
+	for k := range keys {
+		if !reflect.DeepEqual(b[k], a[k]) {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// pick 只保留发生变化的字段，避免更新计划中出现大段未变化的内容
+func pick(state interface{}, fields []string) interface{} {
+	m, ok := state.(map[string]interface{})
+	if !ok {
+		return state
+	}
+	picked := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		if v, ok := m[f]; ok {
+			picked[f] = v
+		}
+	}
+	return picked
+}
+
+// maskSecrets 隐藏 API Key、Token、密码等字段的值，用于返回计划
+func maskSecrets(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(value))
+		for k, item := range value {
+			if s, ok := item.(string); ok && s != "" && isSecretField(k) {
+				masked[k] = "******"
+				continue
+			}
+			masked[k] = maskSecrets(item)
+		}
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, len(value))
+		for i, item := range value {
+			masked[i] = maskSecrets(item)
+		}
+		return masked
+	}
+	return v
+}
+
+func isSecretField(name string) bool {
+	n := strings.ToLower(strings.ReplaceAll(name, "_", ""))
+	return strings.HasSuffix(n, "key") || strings.HasSuffix(n, "token") ||
+		strings.Contains(n, "secret") || strings.Contains(n, "password")
+}
@@ -0,0 +1,468 @@
+package manifest
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/config/types"
+	"xiaozhi-server-go/internal/domain/prompt"
+	"xiaozhi-server-go/internal/domain/tenant"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/workflow"
+)
+
+// Action 变更类型
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// 计划中的资源类型
+const (
+	KindProvider         = "provider"
+	KindPrompt           = "prompt"
+	KindDeviceAssignment = "device_assignment"
+	KindWorkflow         = "workflow"
+)
+
+// Change 单项资源变更，Before/After 中的密钥已隐藏
+type Change struct {
+	Kind    string      `json:"kind"`
+	Name    string      `json:"name"`
+	Action  Action      `json:"action"`
+	Fields  []string    `json:"fields,omitempty"` // 更新时值发生变化的字段
+	Before  interface{} `json:"before,omitempty"`
+	After   interface{} `json:"after,omitempty"`
+	Applied bool        `json:"applied"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// Plan 清单与当前状态的差异
+type Plan struct {
+	Changes   []*Change `json:"changes"`
+	Unchanged int       `json:"unchanged"` // 已与清单一致的资源数
+	DryRun    bool      `json:"dry_run"`
+
+	steps []step
+}
+
+// step 一次写操作，可能对应多项变更（供应商配置整体保存一次）
+type step struct {
+	changes []*Change
+	run     func(ctx context.Context) error
+}
+
+func (p *Plan) add(run func(ctx context.Context) error, changes ...*Change) {
+	p.Changes = append(p.Changes, changes...)
+	p.steps = append(p.steps, step{changes: changes, run: run})
+}
+
+// Reconciler 将供应商、提示词模板、设备分组和工作流调整为清单描述的状态
+type Reconciler struct {
+	repo    types.Repository
+	prompts *prompt.Service
+	logger  *logging.Logger
+	mu      sync.Mutex // 同一时间只执行一次 apply
+}
+
+// NewReconciler 创建清单同步器，prompts 为 nil 时清单中不能包含提示词和设备分组
+func NewReconciler(repo types.Repository, prompts *prompt.Service, logger *logging.Logger) *Reconciler {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Reconciler{
+		repo:    repo,
+		prompts: prompts,
+		logger:  logger,
+	}
+}
+
+// Plan 校验清单并计算需要执行的变更，不做任何修改
+func (r *Reconciler) Plan(ctx context.Context, m *Manifest) (*Plan, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	if r.prompts == nil && (m.Prompts != nil || m.DeviceGroups != nil) {
+		return nil, invalid("提示词模板服务不可用，清单不能包含 prompts 和 device_groups")
+	}
+
+	plan := &Plan{Changes: []*Change{}, DryRun: true}
+	if err := r.planProviders(plan, m); err != nil {
+		return nil, err
+	}
+	promptDeletes, err := r.planPrompts(ctx, plan, m)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.planWorkflow(ctx, plan, m); err != nil {
+		return nil, err
+	}
+	if err := r.planDeviceGroups(ctx, plan, m); err != nil {
+		return nil, err
+	}
+	// 模板最后删除，此时引用它的设备分配已经调整完毕
+	for _, s := range promptDeletes {
+		plan.add(s.run, s.changes...)
+	}
+	return plan, nil
+}
+
+// Apply 计算变更并依次执行，遇到错误时停止；返回的计划中标明了已执行的变更和失败原因
+func (r *Reconciler) Apply(ctx context.Context, m *Manifest) (*Plan, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	plan, err := r.Plan(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	plan.DryRun = false
+	for _, s := range plan.steps {
+		if err := s.run(ctx); err != nil {
+			for _, c := range s.changes {
+				c.Error = err.Error()
+			}
+			r.logger.ErrorTag("配置", "应用配置清单失败，%s %s: %v", s.changes[0].Kind, s.changes[0].Name, err)
+			return plan, err
+		}
+		for _, c := range s.changes {
+			c.Applied = true
+		}
+	}
+	if len(plan.Changes) > 0 {
+		r.logger.InfoTag("配置", "配置清单已应用，共 %d 项变更", len(plan.Changes))
+	}
+	return plan, nil
+}
+
+// planProviders 供应商配置在内存中修改后整体保存一次
+func (r *Reconciler) planProviders(plan *Plan, m *Manifest) error {
+	if len(m.Providers) == 0 {
+		return nil
+	}
+	cfg, err := r.repo.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	kinds := make([]string, 0, len(m.Providers))
+	for kind := range m.Providers {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	var changes []*Change
+	for _, kind := range kinds {
+		section := providerSections[kind](cfg)
+		liveValue, err := normalize(reflect.ValueOf(section).Elem().Interface())
+		if err != nil {
+			return err
+		}
+		live, _ := liveValue.(map[string]interface{})
+
+		elem := reflect.TypeOf(section).Elem().Elem()
+		desired := make(map[string]interface{}, len(m.Providers[kind]))
+		for id, raw := range m.Providers[kind] {
+			if desired[id], err = decodeProvider(elem, raw); err != nil {
+				return err
+			}
+		}
+
+		before := len(changes)
+		for _, id := range sortedKeys(live, desired) {
+			current, exists := live[id]
+			target, wanted := desired[id]
+			c := &Change{Kind: KindProvider, Name: kind + "/" + id}
+			switch {
+			case !exists:
+				c.Action, c.After = ActionCreate, maskSecrets(target)
+			case !wanted:
+				c.Action, c.Before = ActionDelete, maskSecrets(current)
+			case !reflect.DeepEqual(current, target):
+				c.Action, c.Fields = ActionUpdate, changedFields(current, target)
+				c.Before, c.After = maskSecrets(pick(current, c.Fields)), maskSecrets(pick(target, c.Fields))
+			default:
+				plan.Unchanged++
+				continue
+			}
+			changes = append(changes, c)
+		}
+		if len(changes) == before {
+			continue
+		}
+
+		// 用期望状态替换该类型的全部供应商
+		data, err := json.Marshal(desired)
+		if err != nil {
+			return err
+		}
+		replaced := reflect.New(reflect.TypeOf(section).Elem())
+		if err := json.Unmarshal(data, replaced.Interface()); err != nil {
+			return err
+		}
+		reflect.ValueOf(section).Elem().Set(replaced.Elem())
+	}
+
+	if len(changes) > 0 {
+		plan.add(func(context.Context) error { return r.repo.SaveConfig(cfg) }, changes...)
+	}
+	return nil
+}
+
+// planPrompts 创建和修改直接加入计划，删除作为返回值延后执行
+func (r *Reconciler) planPrompts(ctx context.Context, plan *Plan, m *Manifest) ([]step, error) {
+	if m.Prompts == nil {
+		return nil, nil
+	}
+	live, err := r.activePrompts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(m.Prompts))
+	for _, spec := range m.Prompts {
+		spec := spec
+		wanted[spec.Name] = true
+		target := promptState(spec.Description, spec.Content, spec.Variables)
+
+		current, exists := live[spec.Name]
+		if !exists {
+			plan.add(func(ctx context.Context) error {
+				_, err := r.prompts.Create(ctx, prompt.CreateRequest{
+					Name:        spec.Name,
+					Description: spec.Description,
+					Content:     spec.Content,
+					Variables:   spec.Variables,
+				})
+				return err
+			}, &Change{Kind: KindPrompt, Name: spec.Name, Action: ActionCreate, After: target})
+			continue
+		}
+
+		state := promptState(current.Description, current.Content, current.Variables)
+		fields := changedFields(state, target)
+		if len(fields) == 0 {
+			plan.Unchanged++
+			continue
+		}
+		plan.add(func(ctx context.Context) error {
+			variables := spec.Variables
+			if variables == nil {
+				// nil 表示沿用上一版本，清单中未写变量时应清空
+				variables = map[string]string{}
+			}
+			_, err := r.prompts.Update(ctx, spec.Name, prompt.UpdateRequest{
+				Description: &spec.Description,
+				Content:     &spec.Content,
+				Variables:   variables,
+			})
+			return err
+		}, &Change{Kind: KindPrompt, Name: spec.Name, Action: ActionUpdate, Fields: fields,
+			Before: pick(state, fields), After: pick(target, fields)})
+	}
+
+	var deletes []step
+	names := make([]string, 0, len(live))
+	for name := range live {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if wanted[name] {
+			continue
+		}
+		name := name
+		t := live[name]
+		deletes = append(deletes, step{
+			changes: []*Change{{Kind: KindPrompt, Name: name, Action: ActionDelete,
+				Before: promptState(t.Description, t.Content, t.Variables)}},
+			run: func(ctx context.Context) error { return r.prompts.Delete(ctx, name) },
+		})
+	}
+	return deletes, nil
+}
+
+// activePrompts 分页读取全部模板的当前生效版本
+func (r *Reconciler) activePrompts(ctx context.Context) (map[string]*prompt.Template, error) {
+	live := make(map[string]*prompt.Template)
+	for page := 1; ; page++ {
+		templates, total, err := r.prompts.List(ctx, prompt.Filter{Page: page, PageSize: 100})
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range templates {
+			live[t.Name] = t
+		}
+		if len(templates) == 0 || int64(len(live)) >= total {
+			return live, nil
+		}
+	}
+}
+
+func promptState(description, content string, variables map[string]string) map[string]interface{} {
+	if variables == nil {
+		variables = map[string]string{}
+	}
+	return map[string]interface{}{
+		"description": description,
+		"content":     content,
+		"variables":   variables,
+	}
+}
+
+// planDeviceGroups 设备分组展开为逐个设备的模板分配，不属于任何分组的分配会被删除
+func (r *Reconciler) planDeviceGroups(ctx context.Context, plan *Plan, m *Manifest) error {
+	if m.DeviceGroups == nil {
+		return nil
+	}
+
+	type assignment struct {
+		group   string
+		prompt  string
+		version int
+	}
+	desired := make(map[string]assignment)
+	for _, g := range m.DeviceGroups {
+		// 清单中没有 prompts 时引用的模板必须已存在；指定版本时该版本必须已存在
+		if m.Prompts == nil || g.PromptVersion > 0 {
+			if _, err := r.prompts.Get(ctx, g.Prompt, g.PromptVersion); err != nil {
+				return invalid("设备分组 %s 引用的提示词模板 %s 版本 %d 不存在", g.Name, g.Prompt, g.PromptVersion)
+			}
+		}
+		for _, deviceID := range g.Devices {
+			desired[deviceID] = assignment{group: g.Name, prompt: g.Prompt, version: g.PromptVersion}
+		}
+	}
+
+	assignments, err := r.prompts.ListAssignments(ctx)
+	if err != nil {
+		return err
+	}
+	live := make(map[string]*prompt.Assignment, len(assignments))
+	for _, a := range assignments {
+		live[a.DeviceID] = a
+	}
+
+	deviceIDs := make([]string, 0, len(desired)+len(live))
+	for id := range desired {
+		deviceIDs = append(deviceIDs, id)
+	}
+	for id := range live {
+		if _, ok := desired[id]; !ok {
+			deviceIDs = append(deviceIDs, id)
+		}
+	}
+	sort.Strings(deviceIDs)
+
+	for _, deviceID := range deviceIDs {
+		deviceID := deviceID
+		current, exists := live[deviceID]
+		target, wanted := desired[deviceID]
+		c := &Change{Kind: KindDeviceAssignment, Name: deviceID}
+		if wanted {
+			c.After = map[string]interface{}{"group": target.group, "prompt": target.prompt, "prompt_version": target.version}
+		}
+		if exists {
+			c.Before = map[string]interface{}{"prompt": current.TemplateName, "prompt_version": current.Version}
+		}
+
+		switch {
+		case !exists:
+			c.Action = ActionCreate
+		case !wanted:
+			c.Action = ActionDelete
+			plan.add(func(ctx context.Context) error { return r.prompts.Unassign(ctx, deviceID) }, c)
+			continue
+		case current.TemplateName != target.prompt || current.Version != target.version:
+			c.Action = ActionUpdate
+			c.Fields = changedFields(c.Before, map[string]interface{}{"prompt": target.prompt, "prompt_version": target.version})
+		default:
+			plan.Unchanged++
+			continue
+		}
+		plan.add(func(ctx context.Context) error {
+			_, err := r.prompts.Assign(ctx, deviceID, target.prompt, target.version)
+			return err
+		}, c)
+	}
+	return nil
+}
+
+// planWorkflow 比较当前工作流，忽略创建和修改时间
+func (r *Reconciler) planWorkflow(ctx context.Context, plan *Plan, m *Manifest) error {
+	if m.Workflow == nil {
+		return nil
+	}
+	tenantID := tenant.IDFrom(ctx)
+	current, err := workflow.LoadTenantWorkflow(tenantID)
+	if err != nil {
+		return err
+	}
+	before, err := workflowState(current)
+	if err != nil {
+		return err
+	}
+	after, err := workflowState(m.Workflow)
+	if err != nil {
+		return err
+	}
+	fields := changedFields(before, after)
+	if len(fields) == 0 {
+		plan.Unchanged++
+		return nil
+	}
+
+	wf := *m.Workflow
+	plan.add(func(context.Context) error {
+		if wf.CreatedAt.IsZero() {
+			wf.CreatedAt = current.CreatedAt
+		}
+		wf.UpdatedAt = time.Now()
+		return workflow.SaveTenantWorkflow(tenantID, &wf)
+	}, &Change{Kind: KindWorkflow, Name: firstNonEmpty(wf.ID, wf.Name), Action: ActionUpdate, Fields: fields,
+		Before: maskSecrets(pick(before, fields)), After: maskSecrets(pick(after, fields))})
+	return nil
+}
+
+func workflowState(wf *workflow.Workflow) (interface{}, error) {
+	state, err := normalize(wf)
+	if err != nil {
+		return nil, err
+	}
+	if fields, ok := state.(map[string]interface{}); ok {
+		delete(fields, "created_at")
+		delete(fields, "updated_at")
+	}
+	return state, nil
+}
+
+func sortedKeys(maps ...map[string]interface{}) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range maps {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
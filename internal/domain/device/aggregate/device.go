@@ -20,6 +20,7 @@ type Device struct {
 	ID               int          `json:"id"`
 	UserID           *int         `json:"userId"`
 	AgentID          *int         `json:"agentId"`
+	TenantID         string       `json:"tenantId"`         // 所属租户
 	Name             string       `json:"name"`
 	DeviceID         string       `json:"deviceId"`         // 设备唯一标识
 	ClientID         string       `json:"clientId"`         // 客户端唯一标识
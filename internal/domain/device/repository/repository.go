@@ -28,6 +28,9 @@ type DeviceRepository interface {
 
 	// ListByUserID 根据用户ID列出设备
 	ListByUserID(ctx context.Context, userID int) ([]*aggregate.Device, error)
+
+	// FindAll 列出全部设备
+	FindAll(ctx context.Context) ([]*aggregate.Device, error)
 }
 
 // VerificationCodeRepository 验证码仓库接口
@@ -0,0 +1,96 @@
+package devicedebug
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"time"
+)
+
+// Action 调试操作
+type Action string
+
+const (
+	ActionLogs     Action = "logs"     // 读取设备最近的日志
+	ActionCommands Action = "commands" // 查询固件定义的诊断命令
+	ActionRun      Action = "run"      // 执行诊断命令
+	ActionConfig   Action = "config"   // 下发临时配置覆盖，到期后设备恢复原配置
+)
+
+var (
+	// ErrDeviceOffline 设备不在线
+	ErrDeviceOffline = stderrors.New("device offline")
+	// ErrNotPermitted 设备未通过 remote_debug 功能开关允许远程调试
+	ErrNotPermitted = stderrors.New("remote debug not permitted for device")
+	// ErrUnsupported 设备在 hello 中没有声明 debug 特性
+	ErrUnsupported = stderrors.New("device does not support remote debug")
+	// ErrTimeout 设备没有在超时前回复
+	ErrTimeout = stderrors.New("device debug request timed out")
+)
+
+// DeviceError 设备执行调试操作失败，Message 为设备返回的原因
+type DeviceError struct {
+	Message string
+}
+
+func (e *DeviceError) Error() string {
+	return "device debug failed: " + e.Message
+}
+
+// Request 一次调试操作
+type Request struct {
+	Action    Action
+	Lines     int                    // ActionLogs 读取的行数，<=0 时使用 MaxLogLines
+	Level     string                 // ActionLogs 的最低日志级别，为空时不过滤
+	Command   string                 // ActionRun 的命令名称，取自 ActionCommands 的结果
+	Args      map[string]interface{} // ActionRun 的命令参数
+	Overrides map[string]interface{} // ActionConfig 覆盖的配置项
+	TTL       time.Duration          // ActionConfig 的有效期，<=0 或超过 MaxOverrideTTL 时使用 MaxOverrideTTL
+}
+
+// Origin 发起调试操作的管理端请求，记入审计日志
+type Origin struct {
+	RequestID string
+	ClientIP  string
+}
+
+// Result 调试操作的结果
+type Result struct {
+	AuditID    string
+	SessionID  string
+	Data       json.RawMessage // 设备返回的结果，原样透传
+	DurationMs int64
+}
+
+// AuditRecord 一次调试操作的审计记录
+type AuditRecord struct {
+	ID         string    `json:"id"`
+	DeviceID   string    `json:"device_id"`
+	SessionID  string    `json:"session_id"`
+	Action     Action    `json:"action"`
+	Command    string    `json:"command"`
+	Params     string    `json:"params"` // 发给设备的参数 JSON
+	Success    bool      `json:"success"`
+	Error      string    `json:"error"`
+	DurationMs int64     `json:"duration_ms"`
+	RequestID  string    `json:"request_id"`
+	ClientIP   string    `json:"client_ip"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// AuditFilter 审计记录查询条件
+type AuditFilter struct {
+	DeviceID string
+	Action   Action
+	From     time.Time
+	To       time.Time
+	Page     int
+	PageSize int
+}
+
+// Channel 设备连接上的调试通道，由 core 实现
+type Channel interface {
+	// Call 向设备发送调试请求并等待回复，返回设备当前的会话ID和设备返回的结果；
+	// 设备不在线、未允许或不支持时返回对应的错误，设备执行失败时返回 *DeviceError
+	Call(ctx context.Context, deviceID string, action Action, params map[string]interface{}) (string, json.RawMessage, error)
+}
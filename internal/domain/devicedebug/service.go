@@ -0,0 +1,215 @@
+// Package devicedebug 远程设备调试，经设备的 WebSocket 连接读取日志、执行诊断命令和下发临时配置
+package devicedebug
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+const (
+	purgeInterval = time.Hour
+	// auditTimeout 写入审计记录的超时，调用方取消请求时审计记录仍会写入
+	auditTimeout = 5 * time.Second
+	// maxAuditParams 审计记录中参数 JSON 的长度上限
+	maxAuditParams = 4096
+)
+
+// logLevels 设备日志支持的最低级别
+var logLevels = map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
+
+// Service 远程设备调试服务，每次操作无论成功与否都记入审计日志
+type Service struct {
+	cfg       config.DeviceDebugConfig
+	channel   Channel
+	repo      Repository
+	logger    *logging.Logger
+	retention time.Duration // <=0 表示永久保留
+	now       func() time.Time
+}
+
+// NewService 创建远程设备调试服务，未配置的参数使用默认值
+func NewService(cfg config.DeviceDebugConfig, channel Channel, repo Repository, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.MaxLogLines <= 0 {
+		cfg.MaxLogLines = 500
+	}
+	if cfg.MaxOverrideTTL <= 0 {
+		cfg.MaxOverrideTTL = time.Hour
+	}
+	return &Service{
+		cfg:       cfg,
+		channel:   channel,
+		repo:      repo,
+		logger:    logger,
+		retention: time.Duration(cfg.AuditRetentionDays) * 24 * time.Hour,
+		now:       time.Now,
+	}
+}
+
+// Execute 在设备上执行调试操作并记入审计日志，参数无效时返回 KindDomain 错误且不发给设备
+func (s *Service) Execute(ctx context.Context, deviceID string, req Request, origin Origin) (*Result, error) {
+	params, err := s.params(req)
+	if err != nil {
+		return nil, err
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+	start := s.now()
+	sessionID, data, err := s.channel.Call(callCtx, deviceID, req.Action, params)
+	if err != nil && stderrors.Is(callCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		err = ErrTimeout
+	}
+
+	record := &AuditRecord{
+		ID:         uuid.New().String(),
+		DeviceID:   deviceID,
+		SessionID:  sessionID,
+		Action:     req.Action,
+		Command:    req.Command,
+		Params:     auditParams(params),
+		Success:    err == nil,
+		DurationMs: s.now().Sub(start).Milliseconds(),
+		RequestID:  origin.RequestID,
+		ClientIP:   origin.ClientIP,
+		CreatedAt:  start,
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	s.audit(ctx, record)
+
+	if err != nil {
+		s.logger.WarnTag("远程调试", "设备 %s 执行 %s 失败: %v", deviceID, actionLabel(req), err)
+		return nil, err
+	}
+	s.logger.InfoTag("远程调试", "设备 %s 已执行 %s，耗时 %dms", deviceID, actionLabel(req), record.DurationMs)
+	return &Result{
+		AuditID:    record.ID,
+		SessionID:  sessionID,
+		Data:       data,
+		DurationMs: record.DurationMs,
+	}, nil
+}
+
+// params 校验操作并生成发给设备的参数
+func (s *Service) params(req Request) (map[string]interface{}, error) {
+	switch req.Action {
+	case ActionLogs:
+		lines := req.Lines
+		if lines <= 0 || lines > s.cfg.MaxLogLines {
+			lines = s.cfg.MaxLogLines
+		}
+		params := map[string]interface{}{"lines": lines}
+		if req.Level != "" {
+			level := strings.ToLower(req.Level)
+			if !logLevels[level] {
+				return nil, errors.New(errors.KindDomain, "devicedebug.execute", "invalid log level: "+req.Level)
+			}
+			params["level"] = level
+		}
+		return params, nil
+	case ActionCommands:
+		return map[string]interface{}{}, nil
+	case ActionRun:
+		if req.Command == "" {
+			return nil, errors.New(errors.KindDomain, "devicedebug.execute", "command is required")
+		}
+		args := req.Args
+		if args == nil {
+			args = map[string]interface{}{}
+		}
+		return map[string]interface{}{"command": req.Command, "args": args}, nil
+	case ActionConfig:
+		if len(req.Overrides) == 0 {
+			return nil, errors.New(errors.KindDomain, "devicedebug.execute", "overrides are required")
+		}
+		ttl := req.TTL
+		if ttl <= 0 || ttl > s.cfg.MaxOverrideTTL {
+			ttl = s.cfg.MaxOverrideTTL
+		}
+		return map[string]interface{}{"overrides": req.Overrides, "ttl_seconds": int(ttl.Seconds())}, nil
+	default:
+		return nil, errors.New(errors.KindDomain, "devicedebug.execute", "invalid action: "+string(req.Action))
+	}
+}
+
+// audit 同步写入审计记录，写入失败时只记录日志，不影响操作结果
+func (s *Service) audit(ctx context.Context, record *AuditRecord) {
+	auditCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), auditTimeout)
+	defer cancel()
+	if err := s.repo.Save(auditCtx, record); err != nil {
+		s.logger.ErrorTag("远程调试", "写入设备 %s 的审计记录失败: %v", record.DeviceID, err)
+	}
+}
+
+func auditParams(params map[string]interface{}) string {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return ""
+	}
+	if len(data) > maxAuditParams {
+		data = data[:maxAuditParams]
+	}
+	return string(data)
+}
+
+func actionLabel(req Request) string {
+	if req.Action == ActionRun {
+		return fmt.Sprintf("%s %s", req.Action, req.Command)
+	}
+	return string(req.Action)
+}
+
+// ListAudit 分页查询审计记录
+func (s *Service) ListAudit(ctx context.Context, filter AuditFilter) ([]*AuditRecord, int64, error) {
+	return s.repo.List(ctx, filter)
+}
+
+// EraseDeviceData 删除设备的全部调试审计记录
+func (s *Service) EraseDeviceData(ctx context.Context, deviceID string) (int64, error) {
+	return s.repo.DeleteByDevice(ctx, deviceID)
+}
+
+// Run 按保留策略定期清理审计记录，直到 ctx 结束
+func (s *Service) Run(ctx context.Context) error {
+	if s.retention <= 0 {
+		return nil
+	}
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+	for {
+		s.purge(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) purge(ctx context.Context) {
+	removed, err := s.repo.DeleteBefore(ctx, s.now().Add(-s.retention))
+	if err != nil {
+		s.logger.ErrorTag("远程调试", "清理过期审计记录失败: %v", err)
+		return
+	}
+	if removed > 0 {
+		s.logger.InfoTag("远程调试", "已清理 %d 条过期审计记录", removed)
+	}
+}
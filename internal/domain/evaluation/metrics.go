@@ -0,0 +1,129 @@
+package evaluation
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"xiaozhi-server-go/internal/utils"
+)
+
+// tokenize 归一化文本并切分：中日韩字符按字切分，其他按空白切分
+// 因此对中文计算的是字错误率(CER)，对英文计算的是词错误率(WER)
+func tokenize(text string) []string {
+	text = strings.ToLower(utils.RemoveAllPunctuation(text))
+	var tokens []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range text {
+		switch {
+		case unicode.IsSpace(r):
+			flush()
+		case unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r):
+			flush()
+			tokens = append(tokens, string(r))
+		default:
+			word.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+// WordErrorRate 计算识别结果相对参考文本的错误率（替换+删除+插入）/参考长度
+func WordErrorRate(reference, hypothesis string) float64 {
+	ref := tokenize(reference)
+	hyp := tokenize(hypothesis)
+	if len(ref) == 0 {
+		if len(hyp) == 0 {
+			return 0
+		}
+		return 1
+	}
+
+	prev := make([]int, len(hyp)+1)
+	curr := make([]int, len(hyp)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ref); i++ {
+		curr[0] = i
+		for j := 1; j <= len(hyp); j++ {
+			cost := 1
+			if ref[i-1] == hyp[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return float64(prev[len(hyp)]) / float64(len(ref))
+}
+
+// Similarity 基于字符二元组的 Dice 系数，取值 0-1
+func Similarity(expected, actual string) float64 {
+	a := bigrams(tokenize(expected))
+	b := bigrams(tokenize(actual))
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	counts := make(map[string]int, len(a))
+	for _, g := range a {
+		counts[g]++
+	}
+	overlap := 0
+	for _, g := range b {
+		if counts[g] > 0 {
+			counts[g]--
+			overlap++
+		}
+	}
+	return 2 * float64(overlap) / float64(len(a)+len(b))
+}
+
+func bigrams(tokens []string) []string {
+	if len(tokens) == 1 {
+		return tokens
+	}
+	grams := make([]string, 0, len(tokens))
+	for i := 0; i+1 < len(tokens); i++ {
+		grams = append(grams, tokens[i]+" "+tokens[i+1])
+	}
+	return grams
+}
+
+var judgeScorePattern = regexp.MustCompile(`\d+(\.\d+)?`)
+
+// parseJudgeScore 从评审模型的回复中提取 0-10 分并归一化到 0-1
+func parseJudgeScore(reply string) (float64, bool) {
+	m := judgeScorePattern.FindString(reply)
+	if m == "" {
+		return 0, false
+	}
+	score, err := strconv.ParseFloat(m, 64)
+	if err != nil || score < 0 || score > 10 {
+		return 0, false
+	}
+	return score / 10, true
+}
+
+// percentile 返回延迟的百分位数
+func percentile(values []int64, p float64) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]int64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	idx := int(float64(len(sorted)-1) * p)
+	return sorted[idx]
+}
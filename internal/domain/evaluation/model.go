@@ -0,0 +1,114 @@
+package evaluation
+
+import (
+	"time"
+)
+
+// SuiteType 测试集类型，对应被测能力的类型
+type SuiteType string
+
+const (
+	SuiteLLM SuiteType = "llm" // 对话：输入提示词，对比期望回答
+	SuiteASR SuiteType = "asr" // 语音识别：输入音频，对比参考文本
+	SuiteTTS SuiteType = "tts" // 语音合成：输入文本，统计成功率和延迟
+)
+
+// IsValidSuiteType 校验测试集类型
+func IsValidSuiteType(t SuiteType) bool {
+	return t == SuiteLLM || t == SuiteASR || t == SuiteTTS
+}
+
+// RunStatus 评测运行状态
+type RunStatus string
+
+const (
+	RunPending   RunStatus = "pending"
+	RunRunning   RunStatus = "running"
+	RunCompleted RunStatus = "completed"
+	RunFailed    RunStatus = "failed"
+)
+
+// Case 测试用例
+type Case struct {
+	ID       string `json:"id"`
+	Input    string `json:"input,omitempty"`    // LLM 的提示词或 TTS 的文本
+	Audio    string `json:"audio,omitempty"`    // ASR 的音频，base64 编码
+	Expected string `json:"expected,omitempty"` // 期望回答或参考文本
+}
+
+// Suite 测试集
+type Suite struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Type         SuiteType `json:"type"`
+	Description  string    `json:"description,omitempty"`
+	SystemPrompt string    `json:"system_prompt,omitempty"` // 仅 LLM 测试集使用
+	Cases        []Case    `json:"cases"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Target 被测能力及其配置
+type Target struct {
+	CapabilityID string                 `json:"capability_id"`
+	Config       map[string]interface{} `json:"config,omitempty"`
+}
+
+// CaseResult 单个用例在单个能力上的结果
+type CaseResult struct {
+	CaseID     string   `json:"case_id"`
+	Output     string   `json:"output"`
+	Similarity float64  `json:"similarity,omitempty"`  // LLM：与期望回答的相似度 0-1
+	JudgeScore *float64 `json:"judge_score,omitempty"` // LLM：评审模型打分 0-1，未评审或评审失败时为空
+	WER        float64  `json:"wer,omitempty"`         // ASR：字/词错误率
+	LatencyMs  int64    `json:"latency_ms"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// TargetReport 单个能力的汇总报告
+type TargetReport struct {
+	CapabilityID  string       `json:"capability_id"`
+	Total         int          `json:"total"`
+	Succeeded     int          `json:"succeeded"`
+	AvgSimilarity float64      `json:"avg_similarity,omitempty"`
+	AvgJudgeScore float64      `json:"avg_judge_score,omitempty"`
+	AvgWER        float64      `json:"avg_wer,omitempty"`
+	AvgLatencyMs  float64      `json:"avg_latency_ms"`
+	P95LatencyMs  int64        `json:"p95_latency_ms"`
+	Cases         []CaseResult `json:"cases,omitempty"`
+}
+
+// Run 一次评测运行
+type Run struct {
+	ID         string         `json:"id"`
+	SuiteID    string         `json:"suite_id"`
+	SuiteType  SuiteType      `json:"suite_type"`
+	Targets    []Target       `json:"targets"`
+	Judge      *Target        `json:"judge,omitempty"` // LLM 评审，可选
+	Status     RunStatus      `json:"status"`
+	Error      string         `json:"error,omitempty"`
+	Reports    []TargetReport `json:"reports,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	FinishedAt *time.Time     `json:"finished_at,omitempty"`
+}
+
+// RunFilter 运行查询条件
+type RunFilter struct {
+	SuiteID  string
+	Page     int
+	PageSize int
+}
+
+// CaseComparison 同一用例在各次运行、各能力上的结果
+type CaseComparison struct {
+	CaseID   string                `json:"case_id"`
+	Input    string                `json:"input,omitempty"`
+	Expected string                `json:"expected,omitempty"`
+	Results  map[string]CaseResult `json:"results"` // key: 运行ID/能力ID
+}
+
+// Comparison 多次运行的对比
+type Comparison struct {
+	SuiteID string           `json:"suite_id"`
+	Runs    []*Run           `json:"runs"` // 仅包含汇总，不含用例明细
+	Cases   []CaseComparison `json:"cases"`
+}
@@ -0,0 +1,18 @@
+package evaluation
+
+import "context"
+
+// Repository 评测存储接口
+type Repository interface {
+	SaveSuite(ctx context.Context, s *Suite) error
+	FindSuite(ctx context.Context, id string) (*Suite, error)
+	ListSuites(ctx context.Context, page, pageSize int) ([]*Suite, int64, error)
+	DeleteSuite(ctx context.Context, id string) error
+
+	SaveRun(ctx context.Context, r *Run) error
+	UpdateRun(ctx context.Context, r *Run) error
+	FindRun(ctx context.Context, id string) (*Run, error)
+	ListRuns(ctx context.Context, filter RunFilter) ([]*Run, int64, error)
+	// FailUnfinished 将未完成的运行标记为失败，用于服务重启后清理
+	FailUnfinished(ctx context.Context, reason string) (int64, error)
+}
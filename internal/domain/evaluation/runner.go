@@ -0,0 +1,232 @@
+package evaluation
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+// audioChunkSize 向流式 ASR 推送音频时的分片大小
+const audioChunkSize = 3200
+
+const judgePrompt = `你是评测助手。请根据期望回答判断模型回答的正确性和完整性，给出 0 到 10 的整数分数，只输出分数。
+
+问题：%s
+
+期望回答：%s
+
+模型回答：%s`
+
+// Registry 能力注册表，由 capability.Registry 实现
+type Registry interface {
+	GetExecutor(capabilityID string) (capability.Executor, error)
+	ListCapabilities() []capability.Definition
+}
+
+// invoke 调用能力，流式执行器会合并全部输出
+func invoke(ctx context.Context, exec capability.Executor, config, inputs map[string]interface{}) ([]map[string]interface{}, error) {
+	if config == nil {
+		config = map[string]interface{}{}
+	}
+	if stream, ok := exec.(capability.StreamExecutor); ok {
+		ch, err := stream.ExecuteStream(ctx, config, inputs)
+		if err != nil {
+			return nil, err
+		}
+		var outputs []map[string]interface{}
+		for {
+			select {
+			case <-ctx.Done():
+				return outputs, ctx.Err()
+			case out, ok := <-ch:
+				if !ok {
+					return outputs, nil
+				}
+				if errText, ok := out["error"].(string); ok && errText != "" {
+					return outputs, fmt.Errorf("%s", errText)
+				}
+				outputs = append(outputs, out)
+			}
+		}
+	}
+	out, err := exec.Execute(ctx, config, inputs)
+	if err != nil {
+		return nil, err
+	}
+	return []map[string]interface{}{out}, nil
+}
+
+// chatText 调用对话能力并拼接回复文本
+func chatText(ctx context.Context, exec capability.Executor, config map[string]interface{}, system, prompt string) (string, error) {
+	messages := make([]interface{}, 0, 2)
+	if system != "" {
+		messages = append(messages, map[string]interface{}{"role": "system", "content": system})
+	}
+	messages = append(messages, map[string]interface{}{"role": "user", "content": prompt})
+
+	outputs, err := invoke(ctx, exec, config, map[string]interface{}{
+		"messages": messages,
+		"prompt":   prompt,
+	})
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, out := range outputs {
+		if s, ok := out["content"].(string); ok {
+			b.WriteString(s)
+		} else if s, ok := out["text"].(string); ok {
+			b.WriteString(s)
+		}
+	}
+	return b.String(), nil
+}
+
+// transcribe 调用识别能力，同时提供整段音频和音频流两种输入
+func transcribe(ctx context.Context, exec capability.Executor, config map[string]interface{}, audio []byte) (string, error) {
+	audioStream := make(chan []byte, len(audio)/audioChunkSize+1)
+	for start := 0; start < len(audio); start += audioChunkSize {
+		end := min(start+audioChunkSize, len(audio))
+		audioStream <- audio[start:end]
+	}
+	close(audioStream)
+
+	outputs, err := invoke(ctx, exec, config, map[string]interface{}{
+		"audio_data":   audio,
+		"audio_stream": (<-chan []byte)(audioStream),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// 优先使用最终结果，流式识别的中间结果会被后续结果覆盖
+	var text string
+	var finals []string
+	for _, out := range outputs {
+		s, _ := out["text"].(string)
+		if s == "" {
+			continue
+		}
+		if final, ok := out["is_final"].(bool); ok && final {
+			finals = append(finals, s)
+		}
+		text = s
+	}
+	if len(finals) > 0 {
+		return strings.Join(finals, ""), nil
+	}
+	return text, nil
+}
+
+// synthesize 调用合成能力，返回音频描述（文件路径或字节数）
+func synthesize(ctx context.Context, exec capability.Executor, config map[string]interface{}, text string) (string, error) {
+	outputs, err := invoke(ctx, exec, config, map[string]interface{}{"text": text})
+	if err != nil {
+		return "", err
+	}
+	size := 0
+	for _, out := range outputs {
+		for _, key := range []string{"file_path", "audio_file"} {
+			if path, ok := out[key].(string); ok && path != "" {
+				return path, nil
+			}
+		}
+		if data, ok := out["audio_data"].([]byte); ok {
+			size += len(data)
+		}
+		if data, ok := out["audio"].([]byte); ok {
+			size += len(data)
+		}
+	}
+	if size == 0 {
+		return "", fmt.Errorf("合成结果中没有音频")
+	}
+	return fmt.Sprintf("%d bytes", size), nil
+}
+
+// runCase 在单个能力上执行单个用例
+func runCase(ctx context.Context, suite *Suite, exec capability.Executor, target Target, judge capability.Executor, judgeCfg map[string]interface{}, c Case) CaseResult {
+	result := CaseResult{CaseID: c.ID}
+	start := time.Now()
+
+	var err error
+	switch suite.Type {
+	case SuiteLLM:
+		result.Output, err = chatText(ctx, exec, target.Config, suite.SystemPrompt, c.Input)
+	case SuiteASR:
+		var audio []byte
+		audio, err = base64.StdEncoding.DecodeString(c.Audio)
+		if err == nil {
+			result.Output, err = transcribe(ctx, exec, target.Config, audio)
+		}
+	case SuiteTTS:
+		result.Output, err = synthesize(ctx, exec, target.Config, c.Input)
+	}
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	switch suite.Type {
+	case SuiteLLM:
+		if c.Expected != "" {
+			result.Similarity = Similarity(c.Expected, result.Output)
+			if judge != nil {
+				reply, err := chatText(ctx, judge, judgeCfg, "", fmt.Sprintf(judgePrompt, c.Input, c.Expected, result.Output))
+				if err == nil {
+					if score, ok := parseJudgeScore(reply); ok {
+						result.JudgeScore = &score
+					}
+				}
+			}
+		}
+	case SuiteASR:
+		result.WER = WordErrorRate(c.Expected, result.Output)
+	}
+	return result
+}
+
+// summarize 汇总单个能力的用例结果
+func summarize(capabilityID string, results []CaseResult) TargetReport {
+	report := TargetReport{
+		CapabilityID: capabilityID,
+		Total:        len(results),
+		Cases:        results,
+	}
+	var latencies []int64
+	var similarity, wer, judge float64
+	judged := 0
+	for _, r := range results {
+		if r.Error != "" {
+			continue
+		}
+		report.Succeeded++
+		latencies = append(latencies, r.LatencyMs)
+		similarity += r.Similarity
+		wer += r.WER
+		if r.JudgeScore != nil {
+			judge += *r.JudgeScore
+			judged++
+		}
+	}
+	if report.Succeeded > 0 {
+		n := float64(report.Succeeded)
+		report.AvgSimilarity = similarity / n
+		report.AvgWER = wer / n
+		var total int64
+		for _, l := range latencies {
+			total += l
+		}
+		report.AvgLatencyMs = float64(total) / n
+		report.P95LatencyMs = percentile(latencies, 0.95)
+	}
+	if judged > 0 {
+		report.AvgJudgeScore = judge / float64(judged)
+	}
+	return report
+}
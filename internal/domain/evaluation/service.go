@@ -0,0 +1,421 @@
+package evaluation
+
+import (
+	"context"
+	"encoding/base64"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/observability"
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+const (
+	caseTimeout       = 2 * time.Minute
+	maxConcurrentRuns = 2
+	maxCompareRuns    = 5
+)
+
+var (
+	// ErrSuiteNotFound 测试集不存在
+	ErrSuiteNotFound = stderrors.New("evaluation suite not found")
+	// ErrRunNotFound 评测运行不存在
+	ErrRunNotFound = stderrors.New("evaluation run not found")
+)
+
+// CreateSuiteRequest 创建测试集请求
+type CreateSuiteRequest struct {
+	Name         string
+	Type         SuiteType
+	Description  string
+	SystemPrompt string
+	Cases        []Case
+}
+
+// StartRunRequest 发起评测请求
+type StartRunRequest struct {
+	SuiteID string
+	Targets []Target
+	Judge   *Target
+}
+
+// Service 评测服务，在后台依次执行用例并保存报告
+type Service struct {
+	repo     Repository
+	registry Registry
+	logger   *logging.Logger
+	now      func() time.Time
+
+	baseCtx context.Context
+	cancel  context.CancelFunc
+	slots   chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewService 创建评测服务
+func NewService(repo Repository, registry Registry, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Service{
+		repo:     repo,
+		registry: registry,
+		logger:   logger,
+		now:      time.Now,
+		baseCtx:  ctx,
+		cancel:   cancel,
+		slots:    make(chan struct{}, maxConcurrentRuns),
+	}
+}
+
+// Run 清理上次未完成的运行，并在 ctx 结束时取消进行中的评测
+func (s *Service) Run(ctx context.Context) error {
+	if n, err := s.repo.FailUnfinished(ctx, "服务重启，评测中断"); err != nil {
+		s.logger.WarnTag("评测", "清理未完成的评测失败: %v", err)
+	} else if n > 0 {
+		s.logger.InfoTag("评测", "已将 %d 个未完成的评测标记为失败", n)
+	}
+
+	<-ctx.Done()
+	s.cancel()
+	s.wg.Wait()
+	s.logger.InfoTag("评测", "评测服务已停止")
+	return nil
+}
+
+// CreateSuite 创建测试集
+func (s *Service) CreateSuite(ctx context.Context, req CreateSuiteRequest) (*Suite, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, errors.New(errors.KindDomain, "evaluation.create_suite", "name is required")
+	}
+	if !IsValidSuiteType(req.Type) {
+		return nil, errors.New(errors.KindDomain, "evaluation.create_suite", "unsupported suite type: "+string(req.Type))
+	}
+	if len(req.Cases) == 0 {
+		return nil, errors.New(errors.KindDomain, "evaluation.create_suite", "at least one case is required")
+	}
+
+	cases := make([]Case, len(req.Cases))
+	seen := make(map[string]bool, len(req.Cases))
+	for i, c := range req.Cases {
+		if c.ID == "" {
+			c.ID = fmt.Sprintf("case-%d", i+1)
+		}
+		if seen[c.ID] {
+			return nil, errors.New(errors.KindDomain, "evaluation.create_suite", "duplicate case id: "+c.ID)
+		}
+		seen[c.ID] = true
+		if err := validateCase(req.Type, c); err != nil {
+			return nil, err
+		}
+		cases[i] = c
+	}
+
+	suite := &Suite{
+		ID:           uuid.New().String(),
+		Name:         req.Name,
+		Type:         req.Type,
+		Description:  req.Description,
+		SystemPrompt: req.SystemPrompt,
+		Cases:        cases,
+		CreatedAt:    s.now(),
+	}
+	if err := s.repo.SaveSuite(ctx, suite); err != nil {
+		return nil, err
+	}
+	s.logger.InfoTag("评测", "已创建测试集 %s (%s, %d 个用例)", suite.Name, suite.Type, len(cases))
+	return suite, nil
+}
+
+func validateCase(t SuiteType, c Case) error {
+	switch t {
+	case SuiteLLM, SuiteTTS:
+		if strings.TrimSpace(c.Input) == "" {
+			return errors.New(errors.KindDomain, "evaluation.validate_case", "case "+c.ID+": input is required")
+		}
+	case SuiteASR:
+		if c.Audio == "" || c.Expected == "" {
+			return errors.New(errors.KindDomain, "evaluation.validate_case", "case "+c.ID+": audio and expected are required")
+		}
+		if _, err := base64.StdEncoding.DecodeString(c.Audio); err != nil {
+			return errors.New(errors.KindDomain, "evaluation.validate_case", "case "+c.ID+": audio must be base64 encoded")
+		}
+	}
+	return nil
+}
+
+// GetSuite 获取测试集
+func (s *Service) GetSuite(ctx context.Context, id string) (*Suite, error) {
+	suite, err := s.repo.FindSuite(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if suite == nil {
+		return nil, errors.Wrap(errors.KindDomain, "evaluation.get_suite", "evaluation suite not found", ErrSuiteNotFound)
+	}
+	return suite, nil
+}
+
+// ListSuites 分页查询测试集
+func (s *Service) ListSuites(ctx context.Context, page, pageSize int) ([]*Suite, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	return s.repo.ListSuites(ctx, page, pageSize)
+}
+
+// DeleteSuite 删除测试集，已有的运行报告保留
+func (s *Service) DeleteSuite(ctx context.Context, id string) error {
+	if _, err := s.GetSuite(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteSuite(ctx, id)
+}
+
+// StartRun 校验被测能力并在后台开始评测
+func (s *Service) StartRun(ctx context.Context, req StartRunRequest) (*Run, error) {
+	suite, err := s.GetSuite(ctx, req.SuiteID)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Targets) == 0 {
+		return nil, errors.New(errors.KindDomain, "evaluation.start_run", "at least one target is required")
+	}
+	for _, t := range req.Targets {
+		if err := s.checkCapability(t.CapabilityID, capability.Type(suite.Type)); err != nil {
+			return nil, err
+		}
+	}
+	if req.Judge != nil {
+		if suite.Type != SuiteLLM {
+			return nil, errors.New(errors.KindDomain, "evaluation.start_run", "judge is only supported for llm suites")
+		}
+		if err := s.checkCapability(req.Judge.CapabilityID, capability.TypeLLM); err != nil {
+			return nil, err
+		}
+	}
+
+	run := &Run{
+		ID:        uuid.New().String(),
+		SuiteID:   suite.ID,
+		SuiteType: suite.Type,
+		Targets:   req.Targets,
+		Judge:     req.Judge,
+		Status:    RunPending,
+		CreatedAt: s.now(),
+	}
+	if err := s.repo.SaveRun(ctx, run); err != nil {
+		return nil, err
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.execute(suite, run)
+	}()
+	return run, nil
+}
+
+// checkCapability 校验能力存在且类型与测试集一致
+func (s *Service) checkCapability(id string, want capability.Type) error {
+	for _, def := range s.registry.ListCapabilities() {
+		if def.ID != id {
+			continue
+		}
+		if def.Type != want {
+			return errors.New(errors.KindDomain, "evaluation.check_capability",
+				fmt.Sprintf("capability %s is %s, expected %s", id, def.Type, want))
+		}
+		return nil
+	}
+	return errors.New(errors.KindDomain, "evaluation.check_capability", "capability not found: "+id)
+}
+
+// execute 执行评测，各被测能力并行、用例串行
+func (s *Service) execute(suite *Suite, run *Run) {
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	case <-s.baseCtx.Done():
+		s.finish(run, s.baseCtx.Err())
+		return
+	}
+
+	run.Status = RunRunning
+	if err := s.repo.UpdateRun(s.baseCtx, run); err != nil {
+		s.logger.WarnTag("评测", "更新评测状态失败: %v", err)
+	}
+	s.logger.InfoTag("评测", "开始评测 %s，测试集 %s，%d 个能力", run.ID, suite.Name, len(run.Targets))
+
+	var judge capability.Executor
+	var judgeCfg map[string]interface{}
+	if run.Judge != nil {
+		exec, err := s.registry.GetExecutor(run.Judge.CapabilityID)
+		if err != nil {
+			s.finish(run, fmt.Errorf("创建评审执行器失败: %w", err))
+			return
+		}
+		judge, judgeCfg = exec, run.Judge.Config
+	}
+
+	reports := make([]TargetReport, len(run.Targets))
+	var wg sync.WaitGroup
+	for i, target := range run.Targets {
+		wg.Add(1)
+		go func(i int, target Target) {
+			defer wg.Done()
+			reports[i] = s.evaluateTarget(suite, target, judge, judgeCfg)
+		}(i, target)
+	}
+	wg.Wait()
+
+	run.Reports = reports
+	s.finish(run, s.baseCtx.Err())
+}
+
+// evaluateTarget 在单个能力上执行全部用例
+func (s *Service) evaluateTarget(suite *Suite, target Target, judge capability.Executor, judgeCfg map[string]interface{}) TargetReport {
+	results := make([]CaseResult, 0, len(suite.Cases))
+	exec, err := s.registry.GetExecutor(target.CapabilityID)
+	if err != nil {
+		for _, c := range suite.Cases {
+			results = append(results, CaseResult{CaseID: c.ID, Error: err.Error()})
+		}
+		return summarize(target.CapabilityID, results)
+	}
+
+	for _, c := range suite.Cases {
+		if s.baseCtx.Err() != nil {
+			break
+		}
+		ctx, cancel := context.WithTimeout(capability.WithPriority(s.baseCtx, capability.PriorityBatch), caseTimeout)
+		result := runCase(ctx, suite, exec, target, judge, judgeCfg, c)
+		cancel()
+		results = append(results, result)
+
+		observability.RecordMetric(s.baseCtx, "evaluation.case_latency_ms", float64(result.LatencyMs), map[string]string{
+			"capability": target.CapabilityID,
+			"suite_type": string(suite.Type),
+		})
+	}
+	return summarize(target.CapabilityID, results)
+}
+
+// finish 保存评测结果
+func (s *Service) finish(run *Run, err error) {
+	now := s.now()
+	run.FinishedAt = &now
+	run.Status = RunCompleted
+	if err != nil {
+		run.Status = RunFailed
+		run.Error = err.Error()
+	}
+	// 服务停止时 baseCtx 已取消，使用独立的 ctx 保存最终状态
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.repo.UpdateRun(ctx, run); err != nil {
+		s.logger.ErrorTag("评测", "保存评测结果失败: %v", err)
+		return
+	}
+	s.logger.InfoTag("评测", "评测 %s 结束，状态 %s", run.ID, run.Status)
+}
+
+// GetRun 获取评测运行及完整报告
+func (s *Service) GetRun(ctx context.Context, id string) (*Run, error) {
+	run, err := s.repo.FindRun(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, errors.Wrap(errors.KindDomain, "evaluation.get_run", "evaluation run not found", ErrRunNotFound)
+	}
+	return run, nil
+}
+
+// ListRuns 分页查询评测运行，报告中不含用例明细
+func (s *Service) ListRuns(ctx context.Context, filter RunFilter) ([]*Run, int64, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = 20
+	}
+	runs, total, err := s.repo.ListRuns(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, run := range runs {
+		stripCases(run)
+	}
+	return runs, total, nil
+}
+
+// Compare 对比同一测试集的多次运行
+func (s *Service) Compare(ctx context.Context, runIDs []string) (*Comparison, error) {
+	if len(runIDs) < 2 || len(runIDs) > maxCompareRuns {
+		return nil, errors.New(errors.KindDomain, "evaluation.compare", fmt.Sprintf("between 2 and %d runs are required", maxCompareRuns))
+	}
+
+	runs := make([]*Run, 0, len(runIDs))
+	for _, id := range runIDs {
+		run, err := s.GetRun(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if len(runs) > 0 && run.SuiteID != runs[0].SuiteID {
+			return nil, errors.New(errors.KindDomain, "evaluation.compare", "runs must belong to the same suite")
+		}
+		runs = append(runs, run)
+	}
+
+	comparison := &Comparison{SuiteID: runs[0].SuiteID, Runs: runs}
+	suite, err := s.repo.FindSuite(ctx, comparison.SuiteID)
+	if err != nil {
+		return nil, err
+	}
+
+	byCase := make(map[string]*CaseComparison)
+	var order []string
+	if suite != nil {
+		for _, c := range suite.Cases {
+			byCase[c.ID] = &CaseComparison{CaseID: c.ID, Input: c.Input, Expected: c.Expected, Results: map[string]CaseResult{}}
+			order = append(order, c.ID)
+		}
+	}
+	for _, run := range runs {
+		for _, report := range run.Reports {
+			for _, r := range report.Cases {
+				cc, ok := byCase[r.CaseID]
+				if !ok {
+					// 测试集已删除，仅按用例ID对比
+					cc = &CaseComparison{CaseID: r.CaseID, Results: map[string]CaseResult{}}
+					byCase[r.CaseID] = cc
+					order = append(order, r.CaseID)
+				}
+				cc.Results[run.ID+"/"+report.CapabilityID] = r
+			}
+		}
+		stripCases(run)
+	}
+	for _, id := range order {
+		comparison.Cases = append(comparison.Cases, *byCase[id])
+	}
+	return comparison, nil
+}
+
+func stripCases(run *Run) {
+	for i := range run.Reports {
+		run.Reports[i].Cases = nil
+	}
+}
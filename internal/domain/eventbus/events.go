@@ -1,5 +1,7 @@
 package eventbus
 
+import "time"
+
 // 事件类型定义
 const (
 	// ASR相关事件
@@ -24,6 +26,13 @@ const (
 	EventChatStarted   = "chat:started"
 	EventChatCompleted = "chat:completed"
 
+	// 对话状态相关事件
+	EventDialogueStateChanged = "dialogue:state_changed"
+	EventDialogueBargeIn      = "dialogue:barge_in"
+
+	// 内容审核相关事件
+	EventModerationFlagged = "moderation:flagged"
+
 	// 连接相关事件
 	EventConnectionHello    = "connection:hello"
 	EventConnectionClosed   = "connection:closed"
@@ -32,6 +41,37 @@ const (
 	// 系统事件
 	EventSystemError   = "system:error"
 	EventSystemInfo    = "system:info"
+
+	// 告警事件，数据为 AlertEventData
+	EventAlertPluginCrashed  = "alert:plugin_crashed"
+	EventAlertProviderOutage = "alert:provider_outage"
+	EventAlertBudgetExceeded = "alert:budget_exceeded"
+
+	// 在家状态事件，数据为 PresenceEventData
+	EventPresenceArrived = "presence:arrived"
+	EventPresenceLeft    = "presence:left"
+
+	// 离线模式事件，数据为 OfflineEventData
+	EventOfflineEntered = "offline:entered"
+	EventOfflineExited  = "offline:exited"
+
+	// 功能开关事件，数据为 FlagEventData
+	EventFlagChanged = "flag:changed"
+
+	// Token预算事件，数据为 BudgetEventData
+	EventBudgetExceeded = "budget:exceeded"
+
+	// 设备认证状态事件，数据为 DeviceAuthEventData
+	EventDeviceAuthChanged = "device:auth_changed"
+
+	// 意图路由事件，每轮语句一次，数据为 IntentEventData
+	EventIntentRouted = "intent:routed"
+
+	// 设备离线事件，数据为 DeviceOfflineEventData
+	EventDeviceOffline = "device:offline"
+
+	// 工作流执行失败事件，数据为 WorkflowEventData
+	EventWorkflowFailed = "workflow:failed"
 )
 
 // 事件数据结构
@@ -67,6 +107,30 @@ type ChatEventData struct {
 	Round     int    `json:"round"`
 }
 
+// DialogueStateEventData 对话状态迁移事件
+type DialogueStateEventData struct {
+	SessionID  string    `json:"session_id"`
+	DeviceID   string    `json:"device_id,omitempty"`
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	Round      int       `json:"round"`
+	Reason     string    `json:"reason,omitempty"`
+	DurationMs int64     `json:"duration_ms"` // 在上一个状态停留的时长
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// ModerationEventData 内容审核命中事件，不包含原文，避免敏感内容进入日志
+type ModerationEventData struct {
+	SessionID  string    `json:"session_id"`
+	DeviceID   string    `json:"device_id,omitempty"`
+	Stage      string    `json:"stage"`  // input / output
+	Action     string    `json:"action"` // warn / redact / block
+	Profile    string    `json:"profile"`
+	Checks     []string  `json:"checks"`
+	Categories []string  `json:"categories"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
 type ConnectionEventData struct {
 	SessionID string                 `json:"session_id"`
 	UserID    string                 `json:"user_id,omitempty"`
@@ -77,4 +141,82 @@ type SystemEventData struct {
 	Level   string `json:"level"` // error, warn, info
 	Message string `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
-}
\ No newline at end of file
+}
+
+// AlertEventData 告警事件数据
+type AlertEventData struct {
+	Target    string                 `json:"target"` // 插件ID、供应商名称或配额目标
+	Message   string                 `json:"message"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+}
+
+// PresenceEventData 成员到家或离家事件
+type PresenceEventData struct {
+	UserID    uint      `json:"user_id"`
+	Name      string    `json:"name,omitempty"`
+	Source    string    `json:"source"`     // app / geofence / router
+	HomeCount int       `json:"home_count"` // 事件发生后在家的人数，为 0 表示家中无人
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// OfflineEventData 进入或退出离线模式事件
+type OfflineEventData struct {
+	Degraded  []string  `json:"degraded"`           // 已切换到本地服务的类型（asr / llm / tts），退出离线模式时为空
+	Failures  []string  `json:"failures,omitempty"` // 探测失败的云端服务及原因
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// FlagEventData 功能开关被修改、删除或从数据库加载到其它实例的修改
+type FlagEventData struct {
+	Key       string    `json:"key"`
+	Enabled   bool      `json:"enabled"` // 没有匹配规则时的取值，删除后为内置默认值
+	Rules     int       `json:"rules"`   // 规则数
+	Deleted   bool      `json:"deleted"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// BudgetEventData 设备的对话因超出Token预算未调用LLM
+type BudgetEventData struct {
+	DeviceID  string    `json:"device_id"`
+	SessionID string    `json:"session_id"`
+	Scope     string    `json:"scope"` // turn、session 或 daily
+	Limit     int64     `json:"limit"`
+	Used      int64     `json:"used"` // 已用量加上本次调用的估算
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// DeviceAuthEventData 设备认证状态被管理员修改或经扫码配对变为已认证
+type DeviceAuthEventData struct {
+	DeviceID  string    `json:"device_id"`
+	Status    string    `json:"status"` // pending / approved / rejected
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// IntentEventData 一轮语句的意图路由结果，未识别出意图时 Intent 为 llm
+type IntentEventData struct {
+	DeviceID  string    `json:"device_id,omitempty"`
+	SessionID string    `json:"session_id,omitempty"`
+	Intent    string    `json:"intent"`
+	Handled   bool      `json:"handled"` // 由确定性处理器完成，否则回落到LLM
+	Failed    bool      `json:"failed"`  // 处理器出错
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// DeviceOfflineEventData 设备的在线连接断开，同一设备已建立新连接时不发布
+type DeviceOfflineEventData struct {
+	DeviceID    string    `json:"device_id"`
+	SessionID   string    `json:"session_id"`
+	ConnectedAt time.Time `json:"connected_at"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// WorkflowEventData 工作流执行结束，Run 同步执行的内联工作流不发布
+type WorkflowEventData struct {
+	ExecutionID string    `json:"execution_id"`
+	WorkflowID  string    `json:"workflow_id"`
+	TenantID    string    `json:"tenant_id,omitempty"`
+	RequestID   string    `json:"request_id,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
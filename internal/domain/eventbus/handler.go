@@ -29,6 +29,10 @@ func (h *DefaultEventHandler) Handle(eventType string, data interface{}) {
 		h.handleTTSSpeak(data.(TTSEventData))
 	case EventASRError, EventLLMError, EventTTSError:
 		h.handleError(data.(SystemEventData))
+	case EventDialogueStateChanged, EventDialogueBargeIn:
+		h.handleDialogueState(eventType, data.(DialogueStateEventData))
+	case EventModerationFlagged:
+		h.handleModeration(data.(ModerationEventData))
 	default:
 		logging.DefaultLogger.InfoTag("事件处理器", "未处理的事件类型: %s", eventType)
 	}
@@ -66,6 +70,21 @@ func (h *DefaultEventHandler) handleError(data SystemEventData) {
 	logging.DefaultLogger.InfoTag("事件处理器", "系统错误: 级别=%s, 消息=%s", data.Level, data.Message)
 }
 
+// handleDialogueState 处理对话状态事件
+func (h *DefaultEventHandler) handleDialogueState(eventType string, data DialogueStateEventData) {
+	if eventType == EventDialogueBargeIn {
+		logging.DefaultLogger.InfoTag("事件处理器", "[轮次 %d] 用户打断: %s -> %s, 文本=%s", data.Round, data.From, data.To, internalutils.SanitizeForLog(data.Reason))
+		return
+	}
+	logging.DefaultLogger.DebugTag("事件处理器", "[轮次 %d] 对话状态: %s -> %s (%s)", data.Round, data.From, data.To, data.Reason)
+}
+
+// handleModeration 记录内容审核事件
+func (h *DefaultEventHandler) handleModeration(data ModerationEventData) {
+	logging.DefaultLogger.WarnTag("内容审核", "会话 %s 设备 %s 的%s命中审核: 动作=%s 策略=%s 检查=%v 类别=%v",
+		data.SessionID, data.DeviceID, data.Stage, data.Action, data.Profile, data.Checks, data.Categories)
+}
+
 // SetupEventHandlers 设置事件处理器
 func SetupEventHandlers() {
 	handler := NewDefaultEventHandler()
@@ -106,5 +125,22 @@ func SetupEventHandlers() {
 			handler.Handle(EventTTSError, args[0])
 		}
 	})
-}
 
+	Subscribe(EventDialogueStateChanged, func(args ...interface{}) {
+		if len(args) > 0 {
+			handler.Handle(EventDialogueStateChanged, args[0])
+		}
+	})
+
+	Subscribe(EventDialogueBargeIn, func(args ...interface{}) {
+		if len(args) > 0 {
+			handler.Handle(EventDialogueBargeIn, args[0])
+		}
+	})
+
+	Subscribe(EventModerationFlagged, func(args ...interface{}) {
+		if len(args) > 0 {
+			handler.Handle(EventModerationFlagged, args[0])
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package experiment
+
+import (
+	"hash/fnv"
+	"time"
+)
+
+// Status 实验状态
+type Status string
+
+const (
+	StatusDraft   Status = "draft"
+	StatusRunning Status = "running"
+	StatusStopped Status = "stopped"
+)
+
+// Variant 实验分组，覆盖提示词模板和/或LLM提供者
+type Variant struct {
+	Name           string  `json:"name"`
+	Weight         int     `json:"weight"`                      // 分流权重
+	PromptTemplate string  `json:"prompt_template,omitempty"`   // 提示词模板名称，空表示不覆盖
+	PromptVersion  int     `json:"prompt_version,omitempty"`    // 0 表示跟随生效版本
+	LLM            string  `json:"llm,omitempty"`               // 配置中的LLM名称，空表示不覆盖
+	CostPer1KToken float64 `json:"cost_per_1k_token,omitempty"` // 每千token成本，用于估算费用
+}
+
+// Experiment A/B 实验
+type Experiment struct {
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	Description    string     `json:"description,omitempty"`
+	Status         Status     `json:"status"`
+	TrafficPercent int        `json:"traffic_percent"`  // 进入实验的设备比例，0-100
+	Cohort         []string   `json:"cohort,omitempty"` // 限定设备ID，空表示全部设备
+	Variants       []Variant  `json:"variants"`
+	CreatedAt      time.Time  `json:"created_at"`
+	StartedAt      *time.Time `json:"started_at,omitempty"`
+	StoppedAt      *time.Time `json:"stopped_at,omitempty"`
+}
+
+// Outcome 一轮对话的实验结果
+type Outcome struct {
+	ID               string    `json:"id"`
+	ExperimentID     string    `json:"experiment_id"`
+	Variant          string    `json:"variant"`
+	DeviceID         string    `json:"device_id"`
+	SessionID        string    `json:"session_id"`
+	Round            int       `json:"round"`
+	LatencyMs        int64     `json:"latency_ms"` // 首段回复耗时
+	PromptTokens     int       `json:"prompt_tokens"`
+	CompletionTokens int       `json:"completion_tokens"`
+	Interrupted      bool      `json:"interrupted"` // 回复被用户打断
+	Feedback         int       `json:"feedback"`    // 1 赞，-1 踩，0 无反馈
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// VariantStats 仓库聚合出的分组统计
+type VariantStats struct {
+	Variant          string
+	Samples          int64
+	AvgLatencyMs     float64
+	PromptTokens     int64
+	CompletionTokens int64
+	Interrupted      int64
+	ThumbsUp         int64
+	ThumbsDown       int64
+}
+
+// VariantResult 分组结果
+type VariantResult struct {
+	Variant          string  `json:"variant"`
+	Samples          int64   `json:"samples"`
+	AvgLatencyMs     float64 `json:"avg_latency_ms"`
+	PromptTokens     int64   `json:"prompt_tokens"`
+	CompletionTokens int64   `json:"completion_tokens"`
+	AvgTokens        float64 `json:"avg_tokens"`
+	EstimatedCost    float64 `json:"estimated_cost"`
+	InterruptionRate float64 `json:"interruption_rate"`
+	ThumbsUp         int64   `json:"thumbs_up"`
+	ThumbsDown       int64   `json:"thumbs_down"`
+	SatisfactionRate float64 `json:"satisfaction_rate"` // 赞 / (赞 + 踩)
+}
+
+// Results 实验结果
+type Results struct {
+	ExperimentID string          `json:"experiment_id"`
+	Name         string          `json:"name"`
+	Status       Status          `json:"status"`
+	Variants     []VariantResult `json:"variants"`
+}
+
+// Filter 实验查询条件
+type Filter struct {
+	Status   Status
+	Page     int
+	PageSize int
+}
+
+// Assignment 设备分到的实验分组
+type Assignment struct {
+	ExperimentID string
+	Variant      Variant
+}
+
+// InCohort 判断设备是否在实验目标人群中
+func (e *Experiment) InCohort(deviceID string) bool {
+	if len(e.Cohort) > 0 {
+		found := false
+		for _, id := range e.Cohort {
+			if id == deviceID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return bucket(e.ID, deviceID, "traffic", 100) < uint32(e.TrafficPercent)
+}
+
+// Pick 按权重为设备选择分组，同一设备在同一实验中的分组保持稳定
+func (e *Experiment) Pick(deviceID string) (Variant, bool) {
+	total := 0
+	for _, v := range e.Variants {
+		total += v.Weight
+	}
+	if total <= 0 {
+		return Variant{}, false
+	}
+	n := int(bucket(e.ID, deviceID, "variant", uint32(total)))
+	for _, v := range e.Variants {
+		if n < v.Weight {
+			return v, true
+		}
+		n -= v.Weight
+	}
+	return Variant{}, false
+}
+
+// bucket 对实验ID和设备ID做稳定哈希，返回 [0, n) 内的值
+func bucket(experimentID, deviceID, salt string, n uint32) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(experimentID + ":" + salt + ":" + deviceID))
+	return h.Sum32() % n
+}
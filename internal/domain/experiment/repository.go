@@ -0,0 +1,18 @@
+package experiment
+
+import "context"
+
+// Repository 实验存储接口
+type Repository interface {
+	Save(ctx context.Context, e *Experiment) error
+	Update(ctx context.Context, e *Experiment) error
+	FindByID(ctx context.Context, id string) (*Experiment, error)
+	List(ctx context.Context, filter Filter) ([]*Experiment, int64, error)
+	ListRunning(ctx context.Context) ([]*Experiment, error)
+	Delete(ctx context.Context, id string) error
+
+	SaveOutcome(ctx context.Context, o *Outcome) error
+	// SetFeedback 更新会话某轮对话的反馈，返回受影响的记录数
+	SetFeedback(ctx context.Context, sessionID string, round int, feedback int) (int64, error)
+	Aggregate(ctx context.Context, experimentID string) ([]VariantStats, error)
+}
@@ -0,0 +1,298 @@
+package experiment
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/observability"
+)
+
+// ErrNotFound 实验不存在
+var ErrNotFound = stderrors.New("experiment not found")
+
+// CreateRequest 创建实验请求
+type CreateRequest struct {
+	Name           string
+	Description    string
+	TrafficPercent int // 0 表示全部流量
+	Cohort         []string
+	Variants       []Variant
+}
+
+// Service 实验服务
+type Service struct {
+	repo   Repository
+	logger *logging.Logger
+	now    func() time.Time
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局实验服务，供连接处理器使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局实验服务，未初始化时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建实验服务
+func NewService(repo Repository, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Service{
+		repo:   repo,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Create 创建草稿状态的实验
+func (s *Service) Create(ctx context.Context, req CreateRequest) (*Experiment, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, errors.New(errors.KindDomain, "experiment.create", "name is required")
+	}
+	if req.TrafficPercent < 0 || req.TrafficPercent > 100 {
+		return nil, errors.New(errors.KindDomain, "experiment.create", "traffic_percent must be between 0 and 100")
+	}
+	if err := validateVariants(req.Variants); err != nil {
+		return nil, err
+	}
+
+	traffic := req.TrafficPercent
+	if traffic == 0 {
+		traffic = 100
+	}
+	e := &Experiment{
+		ID:             uuid.New().String(),
+		Name:           req.Name,
+		Description:    req.Description,
+		Status:         StatusDraft,
+		TrafficPercent: traffic,
+		Cohort:         req.Cohort,
+		Variants:       req.Variants,
+		CreatedAt:      s.now(),
+	}
+	if err := s.repo.Save(ctx, e); err != nil {
+		return nil, err
+	}
+	s.logger.InfoTag("实验", "已创建实验 %s (%s)", e.Name, e.ID)
+	return e, nil
+}
+
+func validateVariants(variants []Variant) error {
+	if len(variants) < 2 {
+		return errors.New(errors.KindDomain, "experiment.validate", "at least two variants are required")
+	}
+	seen := make(map[string]bool, len(variants))
+	total := 0
+	for _, v := range variants {
+		if strings.TrimSpace(v.Name) == "" {
+			return errors.New(errors.KindDomain, "experiment.validate", "variant name is required")
+		}
+		if seen[v.Name] {
+			return errors.New(errors.KindDomain, "experiment.validate", "duplicate variant name: "+v.Name)
+		}
+		seen[v.Name] = true
+		if v.Weight < 0 {
+			return errors.New(errors.KindDomain, "experiment.validate", "variant weight must not be negative")
+		}
+		total += v.Weight
+	}
+	if total == 0 {
+		return errors.New(errors.KindDomain, "experiment.validate", "total variant weight must be positive")
+	}
+	return nil
+}
+
+// Get 获取实验
+func (s *Service) Get(ctx context.Context, id string) (*Experiment, error) {
+	e, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if e == nil {
+		return nil, errors.Wrap(errors.KindDomain, "experiment.get", "experiment not found", ErrNotFound)
+	}
+	return e, nil
+}
+
+// List 分页查询实验
+func (s *Service) List(ctx context.Context, filter Filter) ([]*Experiment, int64, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = 20
+	}
+	return s.repo.List(ctx, filter)
+}
+
+// Start 开始分流，只有草稿状态的实验可以开始
+func (s *Service) Start(ctx context.Context, id string) (*Experiment, error) {
+	e, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if e.Status != StatusDraft {
+		return nil, errors.New(errors.KindDomain, "experiment.start", "only draft experiments can be started")
+	}
+	now := s.now()
+	e.Status = StatusRunning
+	e.StartedAt = &now
+	if err := s.repo.Update(ctx, e); err != nil {
+		return nil, err
+	}
+	s.logger.InfoTag("实验", "实验 %s 开始分流", e.Name)
+	return e, nil
+}
+
+// Stop 停止分流，已记录的结果保留
+func (s *Service) Stop(ctx context.Context, id string) (*Experiment, error) {
+	e, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if e.Status != StatusRunning {
+		return nil, errors.New(errors.KindDomain, "experiment.stop", "only running experiments can be stopped")
+	}
+	now := s.now()
+	e.Status = StatusStopped
+	e.StoppedAt = &now
+	if err := s.repo.Update(ctx, e); err != nil {
+		return nil, err
+	}
+	s.logger.InfoTag("实验", "实验 %s 已停止", e.Name)
+	return e, nil
+}
+
+// Delete 删除实验及其结果，运行中的实验需先停止
+func (s *Service) Delete(ctx context.Context, id string) error {
+	e, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if e.Status == StatusRunning {
+		return errors.New(errors.KindDomain, "experiment.delete", "stop the experiment before deleting it")
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// Assign 为设备选择实验分组，设备不在任何运行中实验时返回 ok=false
+func (s *Service) Assign(ctx context.Context, deviceID string) (*Assignment, bool) {
+	running, err := s.repo.ListRunning(ctx)
+	if err != nil {
+		s.logger.WarnTag("实验", "查询运行中实验失败: %v", err)
+		return nil, false
+	}
+	for _, e := range running {
+		if !e.InCohort(deviceID) {
+			continue
+		}
+		if v, ok := e.Pick(deviceID); ok {
+			s.logger.DebugTag("实验", "设备 %s 进入实验 %s 分组 %s", deviceID, e.Name, v.Name)
+			return &Assignment{ExperimentID: e.ID, Variant: v}, true
+		}
+	}
+	return nil, false
+}
+
+// RecordOutcome 记录一轮对话的实验结果
+func (s *Service) RecordOutcome(ctx context.Context, o *Outcome) error {
+	if o.ID == "" {
+		o.ID = uuid.New().String()
+	}
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = s.now()
+	}
+	if err := s.repo.SaveOutcome(ctx, o); err != nil {
+		return err
+	}
+
+	labels := map[string]string{"experiment": o.ExperimentID, "variant": o.Variant}
+	observability.RecordMetric(ctx, "experiment.latency_ms", float64(o.LatencyMs), labels)
+	observability.RecordMetric(ctx, "experiment.tokens", float64(o.PromptTokens+o.CompletionTokens), labels)
+	if o.Interrupted {
+		observability.RecordMetric(ctx, "experiment.interrupted", 1, labels)
+	}
+	return nil
+}
+
+// RecordFeedback 将用户反馈关联到会话某轮对话的实验结果
+// 会话未参与实验时返回 false
+func (s *Service) RecordFeedback(ctx context.Context, sessionID string, round int, feedback int) (bool, error) {
+	switch {
+	case feedback > 0:
+		feedback = 1
+	case feedback < 0:
+		feedback = -1
+	}
+	n, err := s.repo.SetFeedback(ctx, sessionID, round, feedback)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Results 汇总实验各分组的结果
+func (s *Service) Results(ctx context.Context, id string) (*Results, error) {
+	e, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.repo.Aggregate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	byVariant := make(map[string]VariantStats, len(stats))
+	for _, st := range stats {
+		byVariant[st.Variant] = st
+	}
+
+	results := &Results{
+		ExperimentID: e.ID,
+		Name:         e.Name,
+		Status:       e.Status,
+		Variants:     make([]VariantResult, 0, len(e.Variants)),
+	}
+	for _, v := range e.Variants {
+		st := byVariant[v.Name]
+		r := VariantResult{
+			Variant:          v.Name,
+			Samples:          st.Samples,
+			AvgLatencyMs:     st.AvgLatencyMs,
+			PromptTokens:     st.PromptTokens,
+			CompletionTokens: st.CompletionTokens,
+			ThumbsUp:         st.ThumbsUp,
+			ThumbsDown:       st.ThumbsDown,
+		}
+		tokens := st.PromptTokens + st.CompletionTokens
+		r.EstimatedCost = float64(tokens) / 1000 * v.CostPer1KToken
+		if st.Samples > 0 {
+			r.AvgTokens = float64(tokens) / float64(st.Samples)
+			r.InterruptionRate = float64(st.Interrupted) / float64(st.Samples)
+		}
+		if rated := st.ThumbsUp + st.ThumbsDown; rated > 0 {
+			r.SatisfactionRate = float64(st.ThumbsUp) / float64(rated)
+		}
+		results.Variants = append(results.Variants, r)
+	}
+	return results, nil
+}
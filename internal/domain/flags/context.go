@@ -0,0 +1,18 @@
+package flags
+
+import (
+	"context"
+)
+
+type deviceKey struct{}
+
+// WithDevice 返回携带设备ID的上下文，开关规则据此匹配设备分组；租户取自 tenant.WithID
+func WithDevice(ctx context.Context, deviceID string) context.Context {
+	return context.WithValue(ctx, deviceKey{}, deviceID)
+}
+
+// DeviceFrom 返回上下文中的设备ID，未设置时返回空字符串
+func DeviceFrom(ctx context.Context) string {
+	id, _ := ctx.Value(deviceKey{}).(string)
+	return id
+}
@@ -0,0 +1,126 @@
+package flags
+
+import (
+	"slices"
+	"strconv"
+	"time"
+)
+
+// 内置开关，用于按租户或设备分组启用有风险的功能
+const (
+	StreamingTTS   = "streaming_tts"   // 回复首段达到 Dialogue.FirstSegmentMinChars 后提前送入TTS
+	NewPipeline    = "new_pipeline"    // 按配置的对话流水线处理识别结果
+	BinaryProtocol = "binary_protocol" // 允许设备协商 protobuf 二进制帧
+	OfflineMode    = "offline_mode"    // 云端服务不可用时切换到本地服务
+	RemoteDebug    = "remote_debug"    // 允许管理员经设备连接远程调试
+)
+
+// Definition 内置开关的说明和默认值，数据库中没有该开关时使用默认值
+type Definition struct {
+	Key         string
+	Description string
+	Default     bool
+}
+
+// definitions 内置开关，默认值与引入开关之前的行为一致
+var definitions = []Definition{
+	{Key: StreamingTTS, Description: "回复首段达到 Dialogue.FirstSegmentMinChars 字后遇到停顿即送入TTS，关闭时首段也按整句切分", Default: true},
+	{Key: NewPipeline, Description: "按 Pipeline.Profiles 配置的对话流水线处理识别结果，关闭时使用内置的审核和意图路由", Default: true},
+	{Key: BinaryProtocol, Description: "允许设备在 hello 中协商 protobuf 二进制帧，关闭时始终使用 JSON", Default: true},
+	{Key: OfflineMode, Description: "云端服务不可用时把设备切换到本地 ASR、LLM 和 TTS，关闭时设备保持使用云端服务", Default: true},
+	{Key: RemoteDebug, Description: "允许管理员经设备连接读取日志、执行诊断命令和下发临时配置，默认关闭，按租户或设备开启", Default: false},
+}
+
+// Definitions 返回内置开关
+func Definitions() []Definition {
+	return slices.Clone(definitions)
+}
+
+// lookupDefinition 查找内置开关
+func lookupDefinition(key string) (Definition, bool) {
+	for _, d := range definitions {
+		if d.Key == key {
+			return d, true
+		}
+	}
+	return Definition{}, false
+}
+
+// Flag 功能开关
+type Flag struct {
+	Key         string    `json:"key"`
+	Description string    `json:"description,omitempty"`
+	Enabled     bool      `json:"enabled"`         // 没有匹配的规则时的取值
+	Rules       []Rule    `json:"rules,omitempty"` // 按顺序匹配，第一条匹配的规则决定取值
+	BuiltIn     bool      `json:"built_in"`        // 内置开关，删除后恢复默认值
+	Stored      bool      `json:"stored"`          // 数据库中有记录，为 false 时是内置开关的默认值
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Rule 为一组租户或设备覆盖开关取值，租户和设备都列出时需同时满足
+type Rule struct {
+	Name    string   `json:"name,omitempty"`    // 设备分组名称，仅用于展示
+	Tenants []string `json:"tenants,omitempty"` // 租户ID，空表示任意租户
+	Devices []string `json:"devices,omitempty"` // 设备ID，空表示租户下的全部设备
+	Enabled bool     `json:"enabled"`
+}
+
+// Matches 判断规则是否适用于租户下的设备
+func (r Rule) Matches(tenantID, deviceID string) bool {
+	if len(r.Tenants) > 0 && !slices.Contains(r.Tenants, tenantID) {
+		return false
+	}
+	if len(r.Devices) > 0 && (deviceID == "" || !slices.Contains(r.Devices, deviceID)) {
+		return false
+	}
+	return true
+}
+
+// label 规则名称，未命名时为 "#序号"（从1开始）
+func (r Rule) label(index int) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return "#" + strconv.Itoa(index+1)
+}
+
+// Decision 开关对某个租户和设备的取值及其来源
+type Decision struct {
+	Key     string `json:"key"`
+	Enabled bool   `json:"enabled"`
+	Rule    string `json:"rule,omitempty"` // 命中的规则名称，未命名时为 "#序号"（从1开始）
+	Source  string `json:"source"`         // rule / flag / default / unknown
+}
+
+// Decision 来源
+const (
+	SourceRule    = "rule"    // 命中规则
+	SourceFlag    = "flag"    // 开关的取值
+	SourceDefault = "default" // 内置开关的默认值
+	SourceUnknown = "unknown" // 未定义的开关，总是关闭
+)
+
+// Evaluate 按规则计算开关对租户下设备的取值
+func (f *Flag) Evaluate(tenantID, deviceID string) Decision {
+	for i, rule := range f.Rules {
+		if rule.Matches(tenantID, deviceID) {
+			return Decision{Key: f.Key, Enabled: rule.Enabled, Rule: rule.label(i), Source: SourceRule}
+		}
+	}
+	source := SourceFlag
+	if !f.Stored {
+		source = SourceDefault
+	}
+	return Decision{Key: f.Key, Enabled: f.Enabled, Source: source}
+}
+
+// clone 复制开关，缓存中的开关不直接交给调用方
+func (f *Flag) clone() *Flag {
+	copied := *f
+	copied.Rules = slices.Clone(f.Rules)
+	for i, rule := range copied.Rules {
+		copied.Rules[i].Tenants = slices.Clone(rule.Tenants)
+		copied.Rules[i].Devices = slices.Clone(rule.Devices)
+	}
+	return &copied
+}
@@ -0,0 +1,17 @@
+package flags
+
+import (
+	"context"
+)
+
+// Repository 功能开关仓库接口
+type Repository interface {
+	// List 列出数据库中的全部开关，按键排序
+	List(ctx context.Context) ([]*Flag, error)
+
+	// Save 创建或更新开关
+	Save(ctx context.Context, f *Flag) error
+
+	// Delete 删除开关，不存在时不报错
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,298 @@
+package flags
+
+import (
+	"context"
+	stderrors "errors"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/eventbus"
+	"xiaozhi-server-go/internal/domain/tenant"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+// ErrNotFound 开关不存在
+var ErrNotFound = stderrors.New("feature flag not found")
+
+// keyPattern 开关键只能包含小写字母、数字和 _ . -
+var keyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,63}$`)
+
+// SetRequest 创建或更新开关请求
+type SetRequest struct {
+	Description string // 为空时内置开关使用内置说明
+	Enabled     bool
+	Rules       []Rule
+}
+
+// Service 功能开关服务，开关保存在数据库中，判断时只读取内存缓存
+type Service struct {
+	repo   Repository
+	logger *logging.Logger
+	now    func() time.Time
+
+	mu     sync.RWMutex
+	stored map[string]*Flag // 数据库中的开关，只整体替换不修改，读取后可在锁外使用
+	loaded bool             // 已从数据库加载过，首次加载不发布变化事件
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局开关服务，供 Enabled 使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局开关服务，未初始化时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// Enabled 判断开关对上下文中的租户和设备是否开启，开关服务未初始化时使用内置默认值
+func Enabled(ctx context.Context, key string) bool {
+	if s := Default(); s != nil {
+		return s.Enabled(ctx, key)
+	}
+	d, _ := lookupDefinition(key)
+	return d.Default
+}
+
+// NewService 创建开关服务，需调用 Load 加载数据库中的开关
+func NewService(repo Repository, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Service{
+		repo:   repo,
+		logger: logger,
+		now:    time.Now,
+		stored: make(map[string]*Flag),
+	}
+}
+
+// Load 从数据库重新加载开关，与缓存不同的开关发布 EventFlagChanged 事件
+func (s *Service) Load(ctx context.Context) error {
+	items, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	next := make(map[string]*Flag, len(items))
+	for _, f := range items {
+		f.Stored = true
+		_, f.BuiltIn = lookupDefinition(f.Key)
+		next[f.Key] = f
+	}
+
+	s.mu.Lock()
+	prev, loaded := s.stored, s.loaded
+	s.stored, s.loaded = next, true
+	s.mu.Unlock()
+
+	if !loaded {
+		return nil
+	}
+	for key, f := range next {
+		if old, ok := prev[key]; !ok || !old.UpdatedAt.Equal(f.UpdatedAt) {
+			s.notify(key, false)
+		}
+	}
+	for key := range prev {
+		if _, ok := next[key]; !ok {
+			s.notify(key, true)
+		}
+	}
+	return nil
+}
+
+// Run 按间隔从数据库重新加载开关，使其它实例的修改生效；interval<=0 时直接返回
+func (s *Service) Run(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return nil
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.Load(ctx); err != nil && ctx.Err() == nil {
+				s.logger.WarnTag("功能开关", "重新加载功能开关失败: %v", err)
+			}
+		}
+	}
+}
+
+// List 列出全部开关，包括未修改过的内置开关，按键排序
+func (s *Service) List() []*Flag {
+	s.mu.RLock()
+	items := make([]*Flag, 0, len(s.stored)+len(definitions))
+	for _, f := range s.stored {
+		items = append(items, f.clone())
+	}
+	s.mu.RUnlock()
+
+	for _, d := range definitions {
+		if !containsKey(items, d.Key) {
+			items = append(items, builtIn(d))
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Key < items[j].Key })
+	return items
+}
+
+// Get 获取开关，未修改过的内置开关返回默认值
+func (s *Service) Get(key string) (*Flag, error) {
+	f, ok := s.lookup(key)
+	if !ok {
+		return nil, errors.Wrap(errors.KindDomain, "flags.get", "feature flag not found", ErrNotFound)
+	}
+	return f.clone(), nil
+}
+
+// Set 创建或更新开关，立即在本实例生效
+func (s *Service) Set(ctx context.Context, key string, req SetRequest) (*Flag, error) {
+	if !keyPattern.MatchString(key) {
+		return nil, errors.New(errors.KindDomain, "flags.set", "key must be 1-64 lowercase letters, digits, '_', '.' or '-'")
+	}
+	rules, err := normalizeRules(req.Rules)
+	if err != nil {
+		return nil, err
+	}
+	definition, isBuiltIn := lookupDefinition(key)
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		description = definition.Description
+	}
+
+	f := &Flag{
+		Key:         key,
+		Description: description,
+		Enabled:     req.Enabled,
+		Rules:       rules,
+		BuiltIn:     isBuiltIn,
+		Stored:      true,
+		UpdatedAt:   s.now().Truncate(time.Millisecond), // 与数据库的时间精度一致，重新加载时不会误判为变化
+	}
+	if err := s.repo.Save(ctx, f); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.stored[key] = f
+	s.mu.Unlock()
+
+	s.logger.InfoTag("功能开关", "已更新功能开关 %s: 默认 %t，规则 %d 条", key, f.Enabled, len(f.Rules))
+	s.notify(key, false)
+	return f.clone(), nil
+}
+
+// Delete 删除数据库中的开关，内置开关恢复默认值，返回删除后的开关（自定义开关为 nil）
+func (s *Service) Delete(ctx context.Context, key string) (*Flag, error) {
+	s.mu.RLock()
+	_, ok := s.stored[key]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, errors.Wrap(errors.KindDomain, "flags.delete", "feature flag not found", ErrNotFound)
+	}
+	if err := s.repo.Delete(ctx, key); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	delete(s.stored, key)
+	s.mu.Unlock()
+
+	s.logger.InfoTag("功能开关", "已删除功能开关 %s", key)
+	s.notify(key, true)
+	if definition, ok := lookupDefinition(key); ok {
+		return builtIn(definition), nil
+	}
+	return nil, nil
+}
+
+// Evaluate 计算开关对上下文中的租户（tenant.WithID）和设备（WithDevice）的取值
+func (s *Service) Evaluate(ctx context.Context, key string) Decision {
+	f, ok := s.lookup(key)
+	if !ok {
+		return Decision{Key: key, Source: SourceUnknown}
+	}
+	return f.Evaluate(tenant.IDFrom(ctx), DeviceFrom(ctx))
+}
+
+// Enabled 判断开关对上下文中的租户和设备是否开启，未定义的开关总是关闭
+func (s *Service) Enabled(ctx context.Context, key string) bool {
+	return s.Evaluate(ctx, key).Enabled
+}
+
+// lookup 返回缓存中的开关或内置开关的默认值，返回值不可修改
+func (s *Service) lookup(key string) (*Flag, bool) {
+	s.mu.RLock()
+	f, ok := s.stored[key]
+	s.mu.RUnlock()
+	if ok {
+		return f, true
+	}
+	if definition, ok := lookupDefinition(key); ok {
+		return builtIn(definition), true
+	}
+	return nil, false
+}
+
+// notify 发布开关变化事件，数据为变化后的取值
+func (s *Service) notify(key string, deleted bool) {
+	data := eventbus.FlagEventData{Key: key, Deleted: deleted, Timestamp: s.now()}
+	if f, ok := s.lookup(key); ok {
+		data.Enabled = f.Enabled
+		data.Rules = len(f.Rules)
+	}
+	eventbus.Publish(eventbus.EventFlagChanged, data)
+}
+
+// normalizeRules 去除空白的租户和设备ID，每条规则至少需要限定租户或设备
+func normalizeRules(rules []Rule) ([]Rule, error) {
+	normalized := make([]Rule, 0, len(rules))
+	for i, rule := range rules {
+		rule.Name = strings.TrimSpace(rule.Name)
+		rule.Tenants = compactIDs(rule.Tenants)
+		rule.Devices = compactIDs(rule.Devices)
+		if len(rule.Tenants) == 0 && len(rule.Devices) == 0 {
+			return nil, errors.New(errors.KindDomain, "flags.validate",
+				"rule "+rule.label(i)+" must list tenants or devices")
+		}
+		normalized = append(normalized, rule)
+	}
+	return normalized, nil
+}
+
+func compactIDs(ids []string) []string {
+	var out []string
+	for _, id := range ids {
+		if id = strings.TrimSpace(id); id != "" {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func containsKey(items []*Flag, key string) bool {
+	for _, f := range items {
+		if f.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// builtIn 内置开关的默认值
+func builtIn(d Definition) *Flag {
+	return &Flag{Key: d.Key, Description: d.Description, Enabled: d.Default, BuiltIn: true}
+}
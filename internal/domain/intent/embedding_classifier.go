@@ -0,0 +1,140 @@
+package intent
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// Embedder 文本向量化接口，由具体的向量模型提供者实现
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+type embeddedExample struct {
+	rule   *Rule
+	vector []float32
+}
+
+// EmbeddingClassifier 基于示例语句向量相似度的意图分类器
+type EmbeddingClassifier struct {
+	embedder Embedder
+	rules    []*Rule
+
+	mu       sync.RWMutex
+	examples []embeddedExample
+	loaded   bool
+}
+
+// NewEmbeddingClassifier 创建向量分类器，示例语句在首次分类时懒加载
+func NewEmbeddingClassifier(embedder Embedder, rules []*Rule) *EmbeddingClassifier {
+	return &EmbeddingClassifier{
+		embedder: embedder,
+		rules:    rules,
+	}
+}
+
+// Name 分类器名称
+func (c *EmbeddingClassifier) Name() string {
+	return "embedding"
+}
+
+func (c *EmbeddingClassifier) load(ctx context.Context) error {
+	c.mu.RLock()
+	loaded := c.loaded
+	c.mu.RUnlock()
+	if loaded {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.loaded {
+		return nil
+	}
+
+	var texts []string
+	var owners []*Rule
+	for _, r := range c.rules {
+		for _, ex := range r.Examples {
+			texts = append(texts, ex)
+			owners = append(owners, r)
+		}
+	}
+	if len(texts) > 0 {
+		vectors, err := c.embedder.Embed(ctx, texts)
+		if err != nil {
+			return fmt.Errorf("示例语句向量化失败: %v", err)
+		}
+		if len(vectors) != len(texts) {
+			return fmt.Errorf("向量数量不匹配: 期望 %d, 实际 %d", len(texts), len(vectors))
+		}
+		for i, v := range vectors {
+			c.examples = append(c.examples, embeddedExample{rule: owners[i], vector: v})
+		}
+	}
+	c.loaded = true
+	return nil
+}
+
+// Classify 返回与输入最相似示例所属的意图
+func (c *EmbeddingClassifier) Classify(ctx context.Context, text string) (*Result, error) {
+	if c.embedder == nil || text == "" {
+		return nil, nil
+	}
+	if err := c.load(ctx); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.examples) == 0 {
+		return nil, nil
+	}
+
+	vectors, err := c.embedder.Embed(ctx, []string{text})
+	if err != nil {
+		return nil, fmt.Errorf("语句向量化失败: %v", err)
+	}
+	if len(vectors) == 0 {
+		return nil, nil
+	}
+
+	var best *embeddedExample
+	bestScore := -1.0
+	for i := range c.examples {
+		score := cosineSimilarity(vectors[0], c.examples[i].vector)
+		if score > bestScore {
+			bestScore = score
+			best = &c.examples[i]
+		}
+	}
+	if best == nil {
+		return nil, nil
+	}
+
+	return &Result{
+		Intent:     best.rule.Intent,
+		Confidence: bestScore,
+		Slots:      map[string]string{},
+		Classifier: c.Name(),
+		Rule:       best.rule,
+	}, nil
+}
+
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
@@ -0,0 +1,258 @@
+package intent
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var weekdayNames = []string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"}
+
+// TimeHandler 时间/日期查询处理器
+type TimeHandler struct {
+	now func() time.Time
+}
+
+// NewTimeHandler 创建时间查询处理器
+func NewTimeHandler() *TimeHandler {
+	return &TimeHandler{now: time.Now}
+}
+
+// Intent 处理的意图
+func (h *TimeHandler) Intent() string {
+	return IntentTime
+}
+
+// Handle 根据问题返回当前时间或日期
+func (h *TimeHandler) Handle(ctx context.Context, req *Request, result *Result) (*Response, error) {
+	now := h.now()
+	text := req.Text
+	var reply string
+	switch {
+	case strings.Contains(text, "星期") || strings.Contains(text, "周几"):
+		reply = fmt.Sprintf("今天是%s", weekdayNames[now.Weekday()])
+	case strings.Contains(text, "几号") || strings.Contains(text, "日期"):
+		reply = fmt.Sprintf("今天是%d年%d月%d日，%s", now.Year(), int(now.Month()), now.Day(), weekdayNames[now.Weekday()])
+	default:
+		reply = fmt.Sprintf("现在是%d点%02d分", now.Hour(), now.Minute())
+	}
+	return &Response{Reply: reply, Handled: true}, nil
+}
+
+// TimerScheduler 计时器调度接口，到期后由实现方负责提醒用户
+type TimerScheduler interface {
+	ScheduleTimer(ctx context.Context, deviceID string, delay time.Duration, content string) error
+}
+
+// TimerHandler 计时器/提醒处理器
+type TimerHandler struct {
+	scheduler TimerScheduler
+}
+
+// NewTimerHandler 创建计时器处理器
+func NewTimerHandler(scheduler TimerScheduler) *TimerHandler {
+	return &TimerHandler{scheduler: scheduler}
+}
+
+// Intent 处理的意图
+func (h *TimerHandler) Intent() string {
+	return IntentTimer
+}
+
+// Handle 解析时长并调度计时器
+func (h *TimerHandler) Handle(ctx context.Context, req *Request, result *Result) (*Response, error) {
+	if h.scheduler == nil {
+		return &Response{Handled: false}, nil
+	}
+	delay, ok := ParseDuration(result.Slots["amount"], result.Slots["unit"])
+	if !ok || delay <= 0 {
+		return &Response{Handled: false}, nil
+	}
+	content := strings.TrimSpace(result.Slots["content"])
+	if err := h.scheduler.ScheduleTimer(ctx, req.DeviceID, delay, content); err != nil {
+		return nil, err
+	}
+
+	spoken := result.Slots["amount"] + result.Slots["unit"]
+	if content != "" {
+		return &Response{Reply: fmt.Sprintf("好的，%s后提醒你%s", spoken, content), Handled: true}, nil
+	}
+	return &Response{Reply: fmt.Sprintf("好的，已设置%s的计时器", spoken), Handled: true}, nil
+}
+
+// ToolExecutor 工具执行接口，MCP管理器满足该接口
+type ToolExecutor interface {
+	IsMCPTool(name string) bool
+	ExecuteTool(ctx context.Context, name string, args map[string]any) (any, error)
+}
+
+// DeviceControlHandler 设备控制处理器，将规则映射到设备侧工具调用
+type DeviceControlHandler struct {
+	executor ToolExecutor
+}
+
+// NewDeviceControlHandler 创建设备控制处理器
+func NewDeviceControlHandler(executor ToolExecutor) *DeviceControlHandler {
+	return &DeviceControlHandler{executor: executor}
+}
+
+// Intent 处理的意图
+func (h *DeviceControlHandler) Intent() string {
+	return IntentDeviceControl
+}
+
+// Handle 按规则填充参数并执行工具，工具不可用时回落到LLM
+func (h *DeviceControlHandler) Handle(ctx context.Context, req *Request, result *Result) (*Response, error) {
+	if h.executor == nil || result.Rule == nil || result.Rule.Tool == "" {
+		return &Response{Handled: false}, nil
+	}
+	if !h.executor.IsMCPTool(result.Rule.Tool) {
+		return &Response{Handled: false}, nil
+	}
+
+	args := make(map[string]any, len(result.Rule.Args))
+	for k, v := range result.Rule.Args {
+		if s, ok := v.(string); ok {
+			filled := fillSlots(s, result.Slots)
+			if n, err := strconv.Atoi(filled); err == nil {
+				args[k] = n
+			} else {
+				args[k] = filled
+			}
+			continue
+		}
+		args[k] = v
+	}
+
+	if _, err := h.executor.ExecuteTool(ctx, result.Rule.Tool, args); err != nil {
+		return nil, fmt.Errorf("执行工具 %s 失败: %v", result.Rule.Tool, err)
+	}
+
+	reply := fillSlots(result.Rule.Reply, result.Slots)
+	if reply == "" {
+		reply = "好的"
+	}
+	return &Response{Reply: reply, Handled: true}, nil
+}
+
+// FeedbackRecorder 语音反馈记录接口，由连接处理器实现并关联到上一轮回复
+type FeedbackRecorder interface {
+	RecordVoiceFeedback(ctx context.Context, positive bool, text string) error
+}
+
+// FeedbackHandler 语音反馈处理器，如“你说错了”“回答得很好”
+// 规则 Args 中 rating 为 up 时记为好评，其余记为差评
+type FeedbackHandler struct {
+	recorder FeedbackRecorder
+}
+
+// NewFeedbackHandler 创建语音反馈处理器
+func NewFeedbackHandler(recorder FeedbackRecorder) *FeedbackHandler {
+	return &FeedbackHandler{recorder: recorder}
+}
+
+// Intent 处理的意图
+func (h *FeedbackHandler) Intent() string {
+	return IntentFeedback
+}
+
+// Handle 记录反馈并给出简短回应
+func (h *FeedbackHandler) Handle(ctx context.Context, req *Request, result *Result) (*Response, error) {
+	if h.recorder == nil {
+		return &Response{Handled: false}, nil
+	}
+	positive := false
+	if result.Rule != nil {
+		if rating, ok := result.Rule.Args["rating"].(string); ok && rating == "up" {
+			positive = true
+		}
+	}
+	if err := h.recorder.RecordVoiceFeedback(ctx, positive, req.Text); err != nil {
+		return nil, fmt.Errorf("记录语音反馈失败: %v", err)
+	}
+
+	var reply string
+	if result.Rule != nil {
+		reply = fillSlots(result.Rule.Reply, result.Slots)
+	}
+	if reply == "" {
+		reply = "抱歉，我记下了，会继续改进"
+		if positive {
+			reply = "谢谢你的鼓励"
+		}
+	}
+	return &Response{Reply: reply, Handled: true}, nil
+}
+
+func fillSlots(tpl string, slots map[string]string) string {
+	for k, v := range slots {
+		tpl = strings.ReplaceAll(tpl, "{"+k+"}", v)
+	}
+	return tpl
+}
+
+var chineseDigits = map[rune]int{
+	'零': 0, '一': 1, '二': 2, '两': 2, '三': 3, '四': 4,
+	'五': 5, '六': 6, '七': 7, '八': 8, '九': 9,
+}
+
+// ParseChineseNumber 解析阿拉伯数字或简单中文数字（支持到百位）
+func ParseChineseNumber(s string) (int, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, false
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, true
+	}
+
+	total, current := 0, 0
+	for _, r := range s {
+		switch r {
+		case '十':
+			if current == 0 {
+				current = 1
+			}
+			total += current * 10
+			current = 0
+		case '百':
+			if current == 0 {
+				current = 1
+			}
+			total += current * 100
+			current = 0
+		default:
+			d, ok := chineseDigits[r]
+			if !ok {
+				return 0, false
+			}
+			current = d
+		}
+	}
+	return total + current, true
+}
+
+// ParseDuration 将数量和单位解析为时长，"半" 表示0.5个单位
+func ParseDuration(amount, unit string) (time.Duration, bool) {
+	var base time.Duration
+	switch {
+	case strings.HasPrefix(unit, "秒"):
+		base = time.Second
+	case strings.HasPrefix(unit, "分"):
+		base = time.Minute
+	case strings.HasPrefix(unit, "小时"), strings.HasPrefix(unit, "钟头"):
+		base = time.Hour
+	default:
+		return 0, false
+	}
+	if amount == "半" {
+		return base / 2, true
+	}
+	n, ok := ParseChineseNumber(amount)
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(n) * base, true
+}
@@ -0,0 +1,232 @@
+package intent
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/eventbus"
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/observability"
+)
+
+const defaultThreshold = 0.6
+
+// Stats 单个意图的路由统计
+type Stats struct {
+	Intent         string        `json:"intent"`
+	Matched        int64         `json:"matched"`         // 分类命中次数
+	Handled        int64         `json:"handled"`         // 由确定性处理器完成的次数
+	FallbackToLLM  int64         `json:"fallback_to_llm"` // 回落到LLM的次数
+	Errors         int64         `json:"errors"`
+	TotalLatency   time.Duration `json:"-"`
+	AverageLatency float64       `json:"average_latency_ms"`
+}
+
+// statsTable 按意图累计的路由统计
+type statsTable struct {
+	mu    sync.Mutex
+	items map[string]*Stats
+}
+
+// routeStats 所有连接的路由器共用的统计，路由器随连接创建和销毁，统计在进程内持续累计
+var routeStats = &statsTable{items: make(map[string]*Stats)}
+
+// RouteStats 返回进程启动以来每个意图的路由统计，按意图名称排序
+func RouteStats() []Stats {
+	return routeStats.snapshot()
+}
+
+var (
+	embeddingMu         sync.RWMutex
+	embeddingClassifier *EmbeddingClassifier
+)
+
+// SetEmbedder 设置向量分类器使用的向量化实现，示例语句只在首次分类时向量化一次，供所有连接共用；
+// embedder 为 nil 时不使用向量分类器
+func SetEmbedder(embedder Embedder, rules []config.IntentRule) {
+	var c *EmbeddingClassifier
+	if embedder != nil {
+		c = NewEmbeddingClassifier(embedder, toRules(rules))
+	}
+	embeddingMu.Lock()
+	embeddingClassifier = c
+	embeddingMu.Unlock()
+}
+
+func defaultEmbeddingClassifier() *EmbeddingClassifier {
+	embeddingMu.RLock()
+	defer embeddingMu.RUnlock()
+	return embeddingClassifier
+}
+
+// Router 意图路由器，在LLM之前对语句进行分类并分发给确定性处理器
+type Router struct {
+	logger      *logging.Logger
+	threshold   float64
+	classifiers []Classifier
+	handlers    map[string]Handler
+	stats       *statsTable
+}
+
+// NewRouter 创建意图路由器
+func NewRouter(logger *logging.Logger, threshold float64) *Router {
+	if threshold <= 0 {
+		threshold = defaultThreshold
+	}
+	return &Router{
+		logger:    logger,
+		threshold: threshold,
+		handlers:  make(map[string]Handler),
+		stats:     routeStats,
+	}
+}
+
+// NewRouterFromConfig 根据配置创建路由器并注册规则分类器和内置处理器，
+// 配置了 Embedding 时在规则分类器之后尝试向量分类器；未启用时返回 nil
+func NewRouterFromConfig(cfg config.IntentConfig, logger *logging.Logger, timers TimerScheduler, tools ToolExecutor, feedback FeedbackRecorder) (*Router, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	ruleClassifier, err := NewRuleClassifier(cfg.Rules)
+	if err != nil {
+		return nil, err
+	}
+	router := NewRouter(logger, cfg.Threshold)
+	router.AddClassifier(ruleClassifier)
+	if c := defaultEmbeddingClassifier(); c != nil && cfg.Embedding != "" {
+		router.AddClassifier(c)
+	}
+	router.RegisterHandler(NewTimeHandler())
+	router.RegisterHandler(NewTimerHandler(timers))
+	router.RegisterHandler(NewDeviceControlHandler(tools))
+	router.RegisterHandler(NewFeedbackHandler(feedback))
+	return router, nil
+}
+
+// AddClassifier 追加分类器，按添加顺序依次尝试
+func (r *Router) AddClassifier(c Classifier) {
+	r.classifiers = append(r.classifiers, c)
+}
+
+// PrependClassifier 将分类器插到最前面，优先于规则分类器尝试
+func (r *Router) PrependClassifier(c Classifier) {
+	r.classifiers = append([]Classifier{c}, r.classifiers...)
+}
+
+// RegisterHandler 注册意图处理器，同名意图会被覆盖
+func (r *Router) RegisterHandler(h Handler) {
+	r.handlers[h.Intent()] = h
+}
+
+// Classify 依次调用分类器，返回第一个达到阈值的结果
+func (r *Router) Classify(ctx context.Context, text string) *Result {
+	for _, c := range r.classifiers {
+		result, err := c.Classify(ctx, text)
+		if err != nil {
+			if r.logger != nil {
+				r.logger.WarnTag("意图", "分类器 %s 执行失败: %v", c.Name(), err)
+			}
+			continue
+		}
+		if result != nil && result.Confidence >= r.threshold {
+			return result
+		}
+	}
+	return nil
+}
+
+// Route 对语句进行分类和处理
+// 返回 nil 表示应交给LLM处理
+func (r *Router) Route(ctx context.Context, req *Request) *Response {
+	start := time.Now()
+	result := r.Classify(ctx, req.Text)
+	if result == nil {
+		r.record(ctx, req, IntentLLM, "llm", time.Since(start), false)
+		return nil
+	}
+
+	handler, ok := r.handlers[result.Intent]
+	if !ok {
+		r.record(ctx, req, result.Intent, "llm", time.Since(start), false)
+		return nil
+	}
+
+	resp, err := handler.Handle(ctx, req, result)
+	if err != nil {
+		if r.logger != nil {
+			r.logger.ErrorTag("意图", "意图 %s 处理失败，回落到LLM: %v", result.Intent, err)
+		}
+		r.record(ctx, req, result.Intent, "llm", time.Since(start), true)
+		return nil
+	}
+	if resp == nil || !resp.Handled || resp.Reply == "" {
+		r.record(ctx, req, result.Intent, "llm", time.Since(start), false)
+		return nil
+	}
+
+	if r.logger != nil {
+		r.logger.InfoTag("意图", "命中意图 %s (分类器=%s, 置信度=%.2f)", result.Intent, result.Classifier, result.Confidence)
+	}
+	r.record(ctx, req, result.Intent, "handler", time.Since(start), false)
+	return resp
+}
+
+func (r *Router) record(ctx context.Context, req *Request, intentName, target string, latency time.Duration, failed bool) {
+	r.stats.mu.Lock()
+	s, ok := r.stats.items[intentName]
+	if !ok {
+		s = &Stats{Intent: intentName}
+		r.stats.items[intentName] = s
+	}
+	if intentName != IntentLLM {
+		s.Matched++
+	}
+	if target == "handler" {
+		s.Handled++
+	} else {
+		s.FallbackToLLM++
+	}
+	if failed {
+		s.Errors++
+	}
+	s.TotalLatency += latency
+	r.stats.mu.Unlock()
+
+	labels := map[string]string{"intent": intentName, "target": target}
+	observability.RecordMetric(ctx, "intent_routed_total", 1, labels)
+	observability.RecordMetric(ctx, "intent_route_latency_ms", float64(latency.Microseconds())/1000, labels)
+	if failed {
+		observability.RecordMetric(ctx, "intent_handler_errors_total", 1, labels)
+	}
+	eventbus.Publish(eventbus.EventIntentRouted, eventbus.IntentEventData{
+		DeviceID:  req.DeviceID,
+		SessionID: req.SessionID,
+		Intent:    intentName,
+		Handled:   target == "handler",
+		Failed:    failed,
+		Timestamp: time.Now(),
+	})
+}
+
+// Stats 返回每个意图的路由统计快照，与 RouteStats 相同
+func (r *Router) Stats() []Stats {
+	return r.stats.snapshot()
+}
+
+func (t *statsTable) snapshot() []Stats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]Stats, 0, len(t.items))
+	for _, s := range t.items {
+		cp := *s
+		if total := cp.Handled + cp.FallbackToLLM; total > 0 {
+			cp.AverageLatency = float64(cp.TotalLatency.Microseconds()) / 1000 / float64(total)
+		}
+		out = append(out, cp)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Intent < out[j].Intent })
+	return out
+}
@@ -0,0 +1,127 @@
+package intent
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/utils"
+)
+
+const (
+	patternConfidence = 1.0
+	keywordConfidence = 0.8
+)
+
+type compiledRule struct {
+	rule     *Rule
+	patterns []*regexp.Regexp
+}
+
+// RuleClassifier 基于关键词和正则的意图分类器
+type RuleClassifier struct {
+	rules []compiledRule
+}
+
+// NewRuleClassifier 根据配置创建规则分类器
+func NewRuleClassifier(rules []config.IntentRule) (*RuleClassifier, error) {
+	c := &RuleClassifier{}
+	for _, r := range rules {
+		if r.Intent == "" {
+			return nil, fmt.Errorf("意图规则缺少 intent 字段")
+		}
+		cr := compiledRule{rule: toRule(r)}
+		for _, p := range r.Patterns {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return nil, fmt.Errorf("意图 %s 的正则 %q 无效: %v", r.Intent, p, err)
+			}
+			cr.patterns = append(cr.patterns, re)
+		}
+		c.rules = append(c.rules, cr)
+	}
+	return c, nil
+}
+
+func toRule(r config.IntentRule) *Rule {
+	return &Rule{
+		Intent:   r.Intent,
+		Keywords: r.Keywords,
+		Examples: r.Examples,
+		Tool:     r.Tool,
+		Args:     r.Args,
+		Reply:    r.Reply,
+	}
+}
+
+// toRules 转换配置中的意图规则，缺少 intent 字段的规则由 NewRuleClassifier 报错，这里跳过
+func toRules(rules []config.IntentRule) []*Rule {
+	out := make([]*Rule, 0, len(rules))
+	for _, r := range rules {
+		if r.Intent != "" {
+			out = append(out, toRule(r))
+		}
+	}
+	return out
+}
+
+// Name 分类器名称
+func (c *RuleClassifier) Name() string {
+	return "rule"
+}
+
+// Classify 按配置顺序匹配规则，正则优先于关键词
+func (c *RuleClassifier) Classify(ctx context.Context, text string) (*Result, error) {
+	cleaned := utils.RemoveAllPunctuation(strings.TrimSpace(text))
+	if cleaned == "" {
+		return nil, nil
+	}
+
+	for _, cr := range c.rules {
+		for _, re := range cr.patterns {
+			match := re.FindStringSubmatch(cleaned)
+			if match == nil {
+				continue
+			}
+			slots := make(map[string]string)
+			for i, name := range re.SubexpNames() {
+				if name != "" && i < len(match) {
+					slots[name] = match[i]
+				}
+			}
+			return &Result{
+				Intent:     cr.rule.Intent,
+				Confidence: patternConfidence,
+				Slots:      slots,
+				Classifier: c.Name(),
+				Rule:       cr.rule,
+			}, nil
+		}
+	}
+
+	for _, cr := range c.rules {
+		for _, kw := range cr.rule.Keywords {
+			if kw != "" && strings.Contains(cleaned, kw) {
+				return &Result{
+					Intent:     cr.rule.Intent,
+					Confidence: keywordConfidence,
+					Slots:      map[string]string{},
+					Classifier: c.Name(),
+					Rule:       cr.rule,
+				}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Rules 返回规则列表，供其他分类器复用示例语句
+func (c *RuleClassifier) Rules() []*Rule {
+	rules := make([]*Rule, 0, len(c.rules))
+	for _, cr := range c.rules {
+		rules = append(rules, cr.rule)
+	}
+	return rules
+}
@@ -0,0 +1,65 @@
+package intent
+
+import (
+	"context"
+)
+
+// 内置意图名称
+const (
+	IntentLLM           = "llm"            // 交由LLM处理
+	IntentTime          = "time"           // 查询时间/日期
+	IntentTimer         = "timer"          // 计时器/提醒
+	IntentDeviceControl = "device_control" // 设备控制
+	IntentFeedback      = "feedback"       // 对上一轮回复的语音反馈
+	IntentWeather       = "weather"        // 查询天气
+	IntentNews          = "news"           // 播报新闻
+	IntentCalendar      = "calendar"       // 查询日程
+	IntentMedia         = "media"          // 播放音乐/电台及播放控制
+	IntentSmartHome     = "smart_home"     // 控制智能家居设备
+	IntentRoutine       = "routine"        // 用唤醒短语触发例程
+)
+
+// Result 意图分类结果
+type Result struct {
+	Intent     string            `json:"intent"`
+	Confidence float64           `json:"confidence"`
+	Slots      map[string]string `json:"slots,omitempty"`
+	Classifier string            `json:"classifier"`
+	Rule       *Rule             `json:"-"`
+}
+
+// Request 路由请求
+type Request struct {
+	Text      string
+	DeviceID  string
+	SessionID string
+}
+
+// Response 确定性处理器的回复
+type Response struct {
+	Reply   string // 需要播报给用户的文本
+	Handled bool   // false 表示处理器放弃，回落到LLM
+}
+
+// Classifier 意图分类器
+type Classifier interface {
+	Name() string
+	// Classify 返回 nil 表示未识别出任何意图
+	Classify(ctx context.Context, text string) (*Result, error)
+}
+
+// Handler 确定性意图处理器
+type Handler interface {
+	Intent() string
+	Handle(ctx context.Context, req *Request, result *Result) (*Response, error)
+}
+
+// Rule 编译后的意图规则
+type Rule struct {
+	Intent   string
+	Keywords []string
+	Examples []string
+	Tool     string
+	Args     map[string]interface{}
+	Reply    string
+}
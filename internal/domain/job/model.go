@@ -0,0 +1,94 @@
+// Package job 持久化的后台任务队列
+//
+// 任务写入数据库后由各类型的工作协程池领取执行，失败按指数退避重试，
+// 超过重试次数或返回 Permanent 错误的任务进入死信（dead）等待人工处理。
+// 执行中的任务持有租约并定期续约，进程退出后租约过期的任务会被重新领取。
+package job
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"time"
+)
+
+// ErrNotFound 任务不存在
+var ErrNotFound = stderrors.New("job not found")
+
+// Status 任务状态
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // 等待执行，包括等待重试
+	StatusRunning   Status = "running"   // 执行中
+	StatusSucceeded Status = "succeeded" // 执行成功
+	StatusDead      Status = "dead"      // 重试次数用尽或不可重试的失败，进入死信
+	StatusCanceled  Status = "canceled"  // 已取消
+)
+
+// Done 任务是否已结束
+func (s Status) Done() bool {
+	return s == StatusSucceeded || s == StatusDead || s == StatusCanceled
+}
+
+// Job 后台任务
+type Job struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	Key         string          `json:"key,omitempty"` // 去重键，同类型同键只保留一个未结束的任务
+	Payload     json.RawMessage `json:"payload"`
+	Status      Status          `json:"status"`
+	Attempts    int             `json:"attempts"` // 已开始执行的次数
+	MaxAttempts int             `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"` // 最早可执行时间，重试时为退避后的时间
+	LeaseUntil  *time.Time      `json:"lease_until,omitempty"`
+	LastError   string          `json:"last_error,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	StartedAt   *time.Time      `json:"started_at,omitempty"`
+	FinishedAt  *time.Time      `json:"finished_at,omitempty"`
+}
+
+// Decode 将任务参数解码到 v
+func (j *Job) Decode(v interface{}) error {
+	return json.Unmarshal(j.Payload, v)
+}
+
+// Filter 任务查询条件
+type Filter struct {
+	Type     string
+	Status   Status
+	Key      string
+	Page     int
+	PageSize int
+}
+
+// TypeStats 单个任务类型的统计
+type TypeStats struct {
+	Type     string           `json:"type"`
+	Workers  int              `json:"workers"`  // 本实例的工作协程数，未在本实例注册时为 0
+	Running  int              `json:"running"`  // 本实例执行中的任务数
+	Counts   map[Status]int64 `json:"counts"`   // 数据库中各状态的任务数
+	Failures int64            `json:"failures"` // 本实例启动以来失败的执行次数，包括之后重试成功的
+}
+
+// permanentError 不可重试的错误
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent 包装不可重试的错误，任务直接进入死信而不再重试
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent 判断是否为不可重试的错误
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return stderrors.As(err, &p)
+}
@@ -0,0 +1,28 @@
+package job
+
+import (
+	"context"
+	"time"
+)
+
+// Repository 任务存储接口
+type Repository interface {
+	// Save 保存新任务
+	Save(ctx context.Context, j *Job) error
+	// Update 更新任务
+	Update(ctx context.Context, j *Job) error
+	// Find 根据ID查找任务，不存在时返回 nil
+	Find(ctx context.Context, id string) (*Job, error)
+	// FindActiveByKey 查找同类型同去重键的未结束任务，不存在时返回 nil
+	FindActiveByKey(ctx context.Context, jobType, key string) (*Job, error)
+	// List 分页查询任务
+	List(ctx context.Context, filter Filter) ([]*Job, int64, error)
+	// Claim 领取一个到期的待执行任务或租约已过期的执行中任务，置为执行中并设置租约；没有可领取的任务时返回 nil
+	Claim(ctx context.Context, jobType string, now, leaseUntil time.Time) (*Job, error)
+	// Renew 为第 attempt 次执行中的任务续约，任务已被取消或被其他工作协程重新领取时返回 false
+	Renew(ctx context.Context, id string, attempt int, leaseUntil time.Time) (bool, error)
+	// CountByStatus 按类型和状态统计任务数
+	CountByStatus(ctx context.Context) (map[string]map[Status]int64, error)
+	// DeleteFinished 删除指定时间之前结束的任务，死信不删除
+	DeleteFinished(ctx context.Context, before time.Time) (int64, error)
+}
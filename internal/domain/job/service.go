@@ -0,0 +1,560 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/drain"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/observability"
+)
+
+// cleanupInterval 清理过期任务的间隔
+const cleanupInterval = time.Hour
+
+// finishTimeout 执行结束后写回任务状态的超时，服务关停时 ctx 已取消，不能复用
+const finishTimeout = 10 * time.Second
+
+// Handler 任务处理函数；返回错误时按退避重试，返回 Permanent 包装的错误时直接进入死信
+type Handler func(ctx context.Context, j *Job) error
+
+// HandlerOptions 任务类型的执行选项，零值使用配置中的默认值
+type HandlerOptions struct {
+	Workers     int           // 工作协程数
+	MaxAttempts int           // 最大执行次数（含首次）
+	Timeout     time.Duration // 单次执行上限，<=0 表示不限
+}
+
+// EnqueueOptions 提交任务的选项
+type EnqueueOptions struct {
+	Key         string        // 去重键，已有同类型同键的未结束任务时直接返回该任务
+	Delay       time.Duration // 延迟执行
+	MaxAttempts int           // 覆盖任务类型的最大执行次数
+}
+
+// handlerEntry 已注册的任务类型
+type handlerEntry struct {
+	handler  Handler
+	opts     HandlerOptions
+	wake     chan struct{}
+	running  atomic.Int64
+	failures atomic.Int64
+}
+
+// Service 后台任务队列
+type Service struct {
+	cfg    config.JobsConfig
+	repo   Repository
+	logger *logging.Logger
+	now    func() time.Time
+
+	mu       sync.RWMutex
+	handlers map[string]*handlerEntry
+	started  bool
+
+	runningMu sync.Mutex
+	cancels   map[string]context.CancelFunc // 本实例执行中的任务
+
+	randMu sync.Mutex
+	rand   *rand.Rand
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局任务队列，供不便注入依赖的模块提交任务
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局任务队列，未启用时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建任务队列
+func NewService(cfg config.JobsConfig, repo Repository, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = 2
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 5
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = 10 * time.Second
+	}
+	if cfg.MaxBackoff < cfg.RetryBackoff {
+		cfg.MaxBackoff = cfg.RetryBackoff
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 2 * time.Second
+	}
+	if cfg.LeaseDuration <= 0 {
+		cfg.LeaseDuration = time.Minute
+	}
+	return &Service{
+		cfg:      cfg,
+		repo:     repo,
+		logger:   logger,
+		now:      time.Now,
+		handlers: make(map[string]*handlerEntry),
+		cancels:  make(map[string]context.CancelFunc),
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Register 注册任务类型的处理函数，需在 Run 之前调用
+func (s *Service) Register(jobType string, handler Handler, opts HandlerOptions) error {
+	if jobType == "" || handler == nil {
+		return fmt.Errorf("job type and handler are required")
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = s.cfg.Workers
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = s.cfg.MaxAttempts
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return fmt.Errorf("job queue already started, cannot register %s", jobType)
+	}
+	if _, exists := s.handlers[jobType]; exists {
+		return fmt.Errorf("job type %s already registered", jobType)
+	}
+	s.handlers[jobType] = &handlerEntry{handler: handler, opts: opts, wake: make(chan struct{}, 1)}
+	return nil
+}
+
+func (s *Service) entry(jobType string) (*handlerEntry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	e, ok := s.handlers[jobType]
+	return e, ok
+}
+
+// Enqueue 提交任务，payload 按 JSON 保存
+func (s *Service) Enqueue(ctx context.Context, jobType string, payload interface{}, opts EnqueueOptions) (*Job, error) {
+	e, ok := s.entry(jobType)
+	if !ok {
+		return nil, errors.New(errors.KindDomain, "job.enqueue", "unknown job type: "+jobType)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(errors.KindDomain, "job.enqueue", "failed to encode job payload", err)
+	}
+	if opts.Key != "" {
+		existing, err := s.repo.FindActiveByKey(ctx, jobType, opts.Key)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return existing, nil
+		}
+	}
+
+	maxAttempts := opts.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = e.opts.MaxAttempts
+	}
+	now := s.now()
+	j := &Job{
+		ID:          uuid.New().String(),
+		Type:        jobType,
+		Key:         opts.Key,
+		Payload:     data,
+		Status:      StatusPending,
+		MaxAttempts: maxAttempts,
+		RunAt:       now.Add(opts.Delay),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.repo.Save(ctx, j); err != nil {
+		return nil, err
+	}
+	s.logger.DebugTag("任务队列", "已提交任务 %s(%s)", j.ID, jobType)
+	if opts.Delay <= 0 {
+		wake(e)
+	}
+	return j, nil
+}
+
+// wake 唤醒空闲的工作协程
+func wake(e *handlerEntry) {
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run 启动各任务类型的工作协程和过期任务清理，阻塞至 ctx 结束；执行中的任务会被中断并放回队列
+func (s *Service) Run(ctx context.Context) error {
+	s.mu.Lock()
+	s.started = true
+	handlers := make(map[string]*handlerEntry, len(s.handlers))
+	for jobType, e := range s.handlers {
+		handlers[jobType] = e
+	}
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for jobType, e := range handlers {
+		for i := 0; i < e.opts.Workers; i++ {
+			wg.Add(1)
+			go func(jobType string, e *handlerEntry) {
+				defer wg.Done()
+				s.work(ctx, jobType, e)
+			}(jobType, e)
+		}
+	}
+	s.logger.InfoTag("任务队列", "任务队列已启动，%d 种任务类型", len(handlers))
+
+	if s.cfg.RetentionHours > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.cleanupLoop(ctx)
+		}()
+	}
+
+	<-ctx.Done()
+	wg.Wait()
+	s.logger.InfoTag("任务队列", "任务队列已停止")
+	return nil
+}
+
+// work 工作协程：领取并执行任务，没有任务时等待唤醒或轮询间隔；排空模式下不再领取新任务
+func (s *Service) work(ctx context.Context, jobType string, e *handlerEntry) {
+	idle := func() bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-e.wake:
+		case <-time.After(s.cfg.PollInterval):
+		}
+		return true
+	}
+
+	for ctx.Err() == nil {
+		done, err := drain.Default().Begin(drain.KindJobs)
+		if err != nil {
+			if !idle() {
+				return
+			}
+			continue
+		}
+
+		now := s.now()
+		j, err := s.repo.Claim(ctx, jobType, now, now.Add(s.cfg.LeaseDuration))
+		if err != nil || j == nil {
+			done()
+			if err != nil && ctx.Err() == nil {
+				s.logger.WarnTag("任务队列", "领取 %s 任务失败: %v", jobType, err)
+			}
+			if !idle() {
+				return
+			}
+			continue
+		}
+
+		// 执行完后不等待，队列里可能还有积压
+		s.execute(ctx, e, j)
+		done()
+	}
+}
+
+// execute 执行任务并写回结果
+func (s *Service) execute(ctx context.Context, e *handlerEntry, j *Job) {
+	var jobCtx context.Context
+	var cancel context.CancelFunc
+	if e.opts.Timeout > 0 {
+		jobCtx, cancel = context.WithTimeout(ctx, e.opts.Timeout)
+	} else {
+		jobCtx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	s.runningMu.Lock()
+	s.cancels[j.ID] = cancel
+	s.runningMu.Unlock()
+	defer func() {
+		s.runningMu.Lock()
+		delete(s.cancels, j.ID)
+		s.runningMu.Unlock()
+	}()
+
+	// 续约，任务在别处被取消时中断执行
+	heartbeatDone := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(s.cfg.LeaseDuration / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-heartbeatDone:
+				return
+			case <-jobCtx.Done():
+				return
+			case <-ticker.C:
+				ok, err := s.repo.Renew(jobCtx, j.ID, j.Attempts, s.now().Add(s.cfg.LeaseDuration))
+				if err != nil {
+					s.logger.WarnTag("任务队列", "任务 %s 续约失败: %v", j.ID, err)
+					continue
+				}
+				if !ok {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	e.running.Add(1)
+	started := time.Now()
+	err := s.invoke(jobCtx, e.handler, j)
+	elapsed := time.Since(started)
+	e.running.Add(-1)
+	close(heartbeatDone)
+
+	finishCtx, finishCancel := context.WithTimeout(context.Background(), finishTimeout)
+	defer finishCancel()
+	current, findErr := s.repo.Find(finishCtx, j.ID)
+	if findErr != nil {
+		s.logger.ErrorTag("任务队列", "查询任务 %s 失败: %v", j.ID, findErr)
+		return
+	}
+	// 执行期间被取消或被其他工作协程接管（租约过期）时不覆盖状态
+	if current == nil || current.Status != StatusRunning || current.Attempts != j.Attempts {
+		s.logger.InfoTag("任务队列", "任务 %s(%s) 已在执行期间被取消", j.ID, j.Type)
+		return
+	}
+
+	now := s.now()
+	j.LeaseUntil = nil
+	j.UpdatedAt = now
+	result := "succeeded"
+	switch {
+	case err == nil:
+		j.Status = StatusSucceeded
+		j.LastError = ""
+		j.FinishedAt = &now
+		s.logger.DebugTag("任务队列", "任务 %s(%s) 执行成功，耗时 %s", j.ID, j.Type, elapsed)
+	case ctx.Err() != nil:
+		// 服务关停导致的中断不计入执行次数，放回队列等待下次启动
+		j.Status = StatusPending
+		j.Attempts--
+		j.RunAt = now
+		result = "interrupted"
+		s.logger.InfoTag("任务队列", "服务关停，任务 %s(%s) 已放回队列", j.ID, j.Type)
+	case IsPermanent(err) || j.Attempts >= j.MaxAttempts:
+		e.failures.Add(1)
+		j.Status = StatusDead
+		j.LastError = err.Error()
+		j.FinishedAt = &now
+		result = "dead"
+		s.logger.ErrorTag("任务队列", "任务 %s(%s) 第 %d 次执行失败，已进入死信: %v", j.ID, j.Type, j.Attempts, err)
+	default:
+		e.failures.Add(1)
+		j.Status = StatusPending
+		j.LastError = err.Error()
+		j.RunAt = now.Add(s.backoff(j.Attempts))
+		result = "retry"
+		s.logger.WarnTag("任务队列", "任务 %s(%s) 第 %d 次执行失败，%s 后重试: %v", j.ID, j.Type, j.Attempts, j.RunAt.Sub(now).Round(time.Second), err)
+	}
+	if err := s.repo.Update(finishCtx, j); err != nil {
+		s.logger.ErrorTag("任务队列", "更新任务 %s 失败: %v", j.ID, err)
+	}
+	observability.RecordMetric(finishCtx, "jobs.duration_ms", float64(elapsed.Milliseconds()), map[string]string{
+		"type":   j.Type,
+		"result": result,
+	})
+}
+
+// invoke 调用处理函数，panic 视为执行失败
+func (s *Service) invoke(ctx context.Context, handler Handler, j *Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job handler panic: %v", r)
+		}
+	}()
+	return handler(ctx, j)
+}
+
+// backoff 第 attempt 次失败后的重试等待时间：指数增长并加入 ±20% 抖动，避免同时失败的任务同时重试
+func (s *Service) backoff(attempt int) time.Duration {
+	d := s.cfg.RetryBackoff
+	for i := 1; i < attempt && d < s.cfg.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > s.cfg.MaxBackoff {
+		d = s.cfg.MaxBackoff
+	}
+	s.randMu.Lock()
+	jitter := 0.8 + 0.4*s.rand.Float64()
+	s.randMu.Unlock()
+	return time.Duration(float64(d) * jitter)
+}
+
+// cleanupLoop 定期删除超过保留时间的已结束任务
+func (s *Service) cleanupLoop(ctx context.Context) {
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+	for {
+		n, err := s.repo.DeleteFinished(ctx, s.now().Add(-time.Duration(s.cfg.RetentionHours)*time.Hour))
+		if err != nil && ctx.Err() == nil {
+			s.logger.WarnTag("任务队列", "清理过期任务失败: %v", err)
+		} else if n > 0 {
+			s.logger.InfoTag("任务队列", "已清理 %d 个过期任务", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Get 获取任务
+func (s *Service) Get(ctx context.Context, id string) (*Job, error) {
+	j, err := s.repo.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if j == nil {
+		return nil, errors.Wrap(errors.KindDomain, "job.get", "job not found", ErrNotFound)
+	}
+	return j, nil
+}
+
+// List 分页查询任务
+func (s *Service) List(ctx context.Context, filter Filter) ([]*Job, int64, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = 20
+	}
+	return s.repo.List(ctx, filter)
+}
+
+// Retry 将死信或已取消的任务放回队列，执行次数清零
+func (s *Service) Retry(ctx context.Context, id string) (*Job, error) {
+	j, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if j.Status != StatusDead && j.Status != StatusCanceled {
+		return nil, errors.New(errors.KindDomain, "job.retry", "only dead or canceled jobs can be retried")
+	}
+	e, ok := s.entry(j.Type)
+	if !ok {
+		return nil, errors.New(errors.KindDomain, "job.retry", "unknown job type: "+j.Type)
+	}
+	if j.Key != "" {
+		existing, err := s.repo.FindActiveByKey(ctx, j.Type, j.Key)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, errors.New(errors.KindDomain, "job.retry", "another job with the same key is active: "+existing.ID)
+		}
+	}
+
+	now := s.now()
+	j.Status = StatusPending
+	j.Attempts = 0
+	j.RunAt = now
+	j.UpdatedAt = now
+	j.FinishedAt = nil
+	if err := s.repo.Update(ctx, j); err != nil {
+		return nil, err
+	}
+	s.logger.InfoTag("任务队列", "任务 %s(%s) 已重新放回队列", j.ID, j.Type)
+	wake(e)
+	return j, nil
+}
+
+// Cancel 取消未结束的任务，执行中的任务在本实例上立即中断，在其他实例上于下次续约时中断
+func (s *Service) Cancel(ctx context.Context, id string) (*Job, error) {
+	j, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if j.Status.Done() {
+		return nil, errors.New(errors.KindDomain, "job.cancel", "job already finished")
+	}
+
+	now := s.now()
+	j.Status = StatusCanceled
+	j.LeaseUntil = nil
+	j.UpdatedAt = now
+	j.FinishedAt = &now
+	if err := s.repo.Update(ctx, j); err != nil {
+		return nil, err
+	}
+
+	s.runningMu.Lock()
+	cancel, running := s.cancels[id]
+	s.runningMu.Unlock()
+	if running {
+		cancel()
+	}
+	s.logger.InfoTag("任务队列", "任务 %s(%s) 已取消", j.ID, j.Type)
+	return j, nil
+}
+
+// Stats 返回各任务类型的统计，包括本实例未注册但数据库中存在的类型
+func (s *Service) Stats(ctx context.Context) ([]TypeStats, error) {
+	counts, err := s.repo.CountByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	types := make(map[string]TypeStats, len(s.handlers)+len(counts))
+	for jobType, e := range s.handlers {
+		types[jobType] = TypeStats{
+			Type:     jobType,
+			Workers:  e.opts.Workers,
+			Running:  int(e.running.Load()),
+			Failures: e.failures.Load(),
+		}
+	}
+	s.mu.RUnlock()
+
+	for jobType, byStatus := range counts {
+		stats := types[jobType]
+		stats.Type = jobType
+		stats.Counts = byStatus
+		types[jobType] = stats
+	}
+
+	result := make([]TypeStats, 0, len(types))
+	for _, stats := range types {
+		if stats.Counts == nil {
+			stats.Counts = map[Status]int64{}
+		}
+		result = append(result, stats)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Type < result[j].Type })
+	return result, nil
+}
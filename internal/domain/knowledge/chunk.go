@@ -0,0 +1,101 @@
+package knowledge
+
+import (
+	"strings"
+)
+
+// sentenceEnds 按这些字符切分过长的段落
+const sentenceEnds = "。！？；!?;\n"
+
+// splitChunks 按段落和句子把文本切分为不超过 size 个字的分块，相邻分块重叠 overlap 个字
+func splitChunks(text string, size, overlap int) []string {
+	if size <= 0 {
+		size = 500
+	}
+	if overlap < 0 || overlap > size/2 {
+		overlap = size / 10
+	}
+
+	var units []string
+	for _, para := range strings.Split(text, "\n\n") {
+		para = strings.TrimSpace(para)
+		if para == "" {
+			continue
+		}
+		if runeLen(para) <= size {
+			units = append(units, para)
+			continue
+		}
+		for _, sentence := range splitSentences(para) {
+			units = append(units, hardSplit(sentence, size)...)
+		}
+	}
+
+	var chunks []string
+	var current []rune
+	flush := func() {
+		if s := strings.TrimSpace(string(current)); s != "" {
+			chunks = append(chunks, s)
+		}
+	}
+	for _, unit := range units {
+		r := []rune(unit)
+		if len(current) > 0 && len(current)+1+len(r) > size {
+			flush()
+			tail := current
+			if len(tail) > overlap {
+				tail = tail[len(tail)-overlap:]
+			}
+			current = append([]rune(nil), tail...)
+			if len(current)+1+len(r) > size {
+				current = current[:0]
+			}
+		}
+		if len(current) > 0 {
+			current = append(current, '\n')
+		}
+		current = append(current, r...)
+	}
+	flush()
+	return chunks
+}
+
+// splitSentences 在句末标点之后切分，标点保留在句子末尾
+func splitSentences(s string) []string {
+	var sentences []string
+	start := 0
+	for i, r := range s {
+		if strings.ContainsRune(sentenceEnds, r) {
+			end := i + len(string(r))
+			if part := strings.TrimSpace(s[start:end]); part != "" {
+				sentences = append(sentences, part)
+			}
+			start = end
+		}
+	}
+	if part := strings.TrimSpace(s[start:]); part != "" {
+		sentences = append(sentences, part)
+	}
+	return sentences
+}
+
+// hardSplit 把超过 size 个字的句子按字数切开
+func hardSplit(s string, size int) []string {
+	r := []rune(s)
+	if len(r) <= size {
+		return []string{s}
+	}
+	var parts []string
+	for len(r) > size {
+		parts = append(parts, string(r[:size]))
+		r = r[size:]
+	}
+	if len(r) > 0 {
+		parts = append(parts, string(r))
+	}
+	return parts
+}
+
+func runeLen(s string) int {
+	return len([]rune(s))
+}
@@ -0,0 +1,191 @@
+package knowledge
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+// HashEmbedding 未配置向量化能力时使用的内置向量
+const HashEmbedding = "builtin:hash"
+
+// hashDimensions 内置向量的维数
+const hashDimensions = 4096
+
+// Embedder 文本向量化接口，与意图路由的向量分类器使用同样的签名
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// NewEmbedder 返回调用 embedding 类型能力 id 的向量化实现，id 为 HashEmbedding 时使用内置的哈希向量
+func NewEmbedder(registry *capability.Registry, id string, config map[string]interface{}) Embedder {
+	if id == HashEmbedding || registry == nil {
+		return hashEmbedder{}
+	}
+	return &capabilityEmbedder{registry: registry, id: id, config: config}
+}
+
+// capabilityEmbedder 调用 embedding 类型的能力
+type capabilityEmbedder struct {
+	registry *capability.Registry
+	id       string
+	config   map[string]interface{}
+}
+
+func (e *capabilityEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	exec, err := e.registry.GetExecutor(e.id)
+	if err != nil {
+		return nil, fmt.Errorf("获取向量化能力 %s 失败: %w", e.id, err)
+	}
+	inputs := make([]interface{}, len(texts))
+	for i, t := range texts {
+		inputs[i] = t
+	}
+	outputs, err := exec.Execute(ctx, e.config, map[string]interface{}{"texts": inputs})
+	if err != nil {
+		return nil, fmt.Errorf("向量化能力 %s 执行失败: %w", e.id, err)
+	}
+	vectors, err := parseVectors(outputs["vectors"])
+	if err != nil {
+		return nil, fmt.Errorf("向量化能力 %s 的输出无效: %w", e.id, err)
+	}
+	if len(vectors) != len(texts) {
+		return nil, fmt.Errorf("向量数量不匹配: 期望 %d, 实际 %d", len(texts), len(vectors))
+	}
+	return vectors, nil
+}
+
+// parseVectors 解析能力输出的向量，兼容进程内的 [][]float32 和经 gRPC 转换后的 []interface{}
+func parseVectors(value interface{}) ([][]float32, error) {
+	switch v := value.(type) {
+	case [][]float32:
+		return v, nil
+	case [][]float64:
+		out := make([][]float32, len(v))
+		for i, vec := range v {
+			out[i] = make([]float32, len(vec))
+			for j, x := range vec {
+				out[i][j] = float32(x)
+			}
+		}
+		return out, nil
+	case []interface{}:
+		out := make([][]float32, len(v))
+		for i, item := range v {
+			vec, ok := item.([]interface{})
+			if !ok {
+				if f32, ok := item.([]float32); ok {
+					out[i] = f32
+					continue
+				}
+				return nil, fmt.Errorf("vectors[%d] is not an array", i)
+			}
+			out[i] = make([]float32, len(vec))
+			for j, x := range vec {
+				f, ok := x.(float64)
+				if !ok {
+					return nil, fmt.Errorf("vectors[%d][%d] is not a number", i, j)
+				}
+				out[i][j] = float32(f)
+			}
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("vectors is missing")
+	}
+}
+
+// scorer 由向量化方式自行定义相似度，未实现时使用余弦相似度
+type scorer interface {
+	Score(query, chunk []float32) float64
+}
+
+// hashEmbedder 把单字、相邻两字和英文单词计数到固定维数的向量，不依赖外部模型，
+// 适合小规模、以字面匹配为主的检索
+type hashEmbedder struct{}
+
+func (hashEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = hashVector(text)
+	}
+	return vectors, nil
+}
+
+// Score 查询的字词在分块中出现的比例；分块越长余弦相似度越低，按覆盖率计分不受分块长度影响
+func (hashEmbedder) Score(query, chunk []float32) float64 {
+	if len(query) != len(chunk) {
+		return 0
+	}
+	var total, covered float64
+	for i, q := range query {
+		if q <= 0 {
+			continue
+		}
+		total += float64(q)
+		covered += math.Min(float64(q), float64(chunk[i]))
+	}
+	if total == 0 {
+		return 0
+	}
+	return covered / total
+}
+
+func hashVector(text string) []float32 {
+	vec := make([]float32, hashDimensions)
+	add := func(feature string, weight float32) {
+		h := fnv.New32a()
+		h.Write([]byte(feature))
+		vec[h.Sum32()%hashDimensions] += weight
+	}
+
+	var prev rune
+	var word strings.Builder
+	flushWord := func() {
+		if word.Len() > 1 {
+			add("w:"+word.String(), 1)
+		}
+		word.Reset()
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.Is(unicode.Han, r) || unicode.In(r, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			flushWord()
+			add(string(r), 0.25)
+			if prev != 0 {
+				add(string([]rune{prev, r}), 1)
+			}
+			prev = r
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(r)
+			prev = 0
+		default:
+			flushWord()
+			prev = 0
+		}
+	}
+	flushWord()
+	return vec
+}
+
+// cosine 余弦相似度，维数不同（换过向量化能力）时返回 0
+func cosine(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
@@ -0,0 +1,195 @@
+package knowledge
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"path"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// DetectFormat 按文件扩展名或内容类型判断文档格式
+func DetectFormat(name, contentType string) (Format, bool) {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		switch mediaType {
+		case "application/pdf":
+			return FormatPDF, true
+		case "text/markdown", "text/x-markdown":
+			return FormatMarkdown, true
+		case "text/html", "application/xhtml+xml":
+			return FormatHTML, true
+		case "text/plain":
+			// 上传的 .md 文件常以 text/plain 提交，再看扩展名
+			if format, ok := formatByExt(name); ok {
+				return format, true
+			}
+			return FormatText, true
+		}
+	}
+	return formatByExt(name)
+}
+
+func formatByExt(name string) (Format, bool) {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".pdf":
+		return FormatPDF, true
+	case ".md", ".markdown":
+		return FormatMarkdown, true
+	case ".txt", ".text":
+		return FormatText, true
+	case ".html", ".htm":
+		return FormatHTML, true
+	}
+	return "", false
+}
+
+// ExtractText 从文档内容中提取纯文本
+func ExtractText(format Format, data []byte) (string, error) {
+	var text string
+	switch format {
+	case FormatPDF:
+		extracted, err := extractPDF(data)
+		if err != nil {
+			return "", err
+		}
+		text = extracted
+	case FormatHTML:
+		extracted, err := extractHTML(data)
+		if err != nil {
+			return "", err
+		}
+		text = extracted
+	case FormatMarkdown:
+		text = cleanMarkdown(string(bytes.ToValidUTF8(data, nil)))
+	case FormatText:
+		text = string(bytes.ToValidUTF8(data, nil))
+	default:
+		return "", fmt.Errorf("不支持的文档格式: %s", format)
+	}
+	text = normalizeText(text)
+	if text == "" {
+		if format == FormatPDF {
+			return "", fmt.Errorf("未能从 PDF 中提取到文本，可能是扫描件或使用了不支持的编码")
+		}
+		return "", fmt.Errorf("文档中没有文本内容")
+	}
+	return text, nil
+}
+
+// htmlSkipped 不提取文本的网页元素
+var htmlSkipped = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true, "svg": true,
+	"nav": true, "header": true, "footer": true, "aside": true, "form": true, "iframe": true,
+}
+
+// htmlBlocks 前后换行的块级元素
+var htmlBlocks = map[string]bool{
+	"p": true, "div": true, "section": true, "article": true, "main": true, "br": true, "li": true,
+	"ul": true, "ol": true, "table": true, "tr": true, "h1": true, "h2": true, "h3": true,
+	"h4": true, "h5": true, "h6": true, "pre": true, "blockquote": true, "title": true, "dd": true, "dt": true,
+}
+
+// extractHTML 提取网页正文，跳过脚本、样式和导航等元素；页面有 <main> 或 <article> 时只取其中的内容
+func extractHTML(data []byte) (string, error) {
+	doc, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("解析网页失败: %w", err)
+	}
+	root := doc
+	if main := findElement(doc, "main"); main != nil {
+		root = main
+	} else if article := findElement(doc, "article"); article != nil {
+		root = article
+	}
+
+	var b strings.Builder
+	if root != doc {
+		if title := findElement(doc, "title"); title != nil {
+			writeHTMLText(&b, title)
+			b.WriteString("\n\n")
+		}
+	}
+	writeHTMLText(&b, root)
+	return b.String(), nil
+}
+
+func findElement(n *html.Node, tag string) *html.Node {
+	if n.Type == html.ElementNode && n.Data == tag {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findElement(c, tag); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func writeHTMLText(b *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		b.WriteString(n.Data)
+		return
+	case html.ElementNode:
+		if htmlSkipped[n.Data] {
+			return
+		}
+		if htmlBlocks[n.Data] {
+			b.WriteString("\n")
+			defer b.WriteString("\n")
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		writeHTMLText(b, c)
+	}
+}
+
+var (
+	mdImage     = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdLink      = regexp.MustCompile(`\[([^\]]+)\]\([^)]*\)`)
+	mdHeading   = regexp.MustCompile(`(?m)^\s{0,3}#{1,6}\s+`)
+	mdEmphasis  = regexp.MustCompile(`(\*\*|__|~~)(\S(?:.*?\S)?)(\*\*|__|~~)`)
+	mdFence     = regexp.MustCompile("(?m)^\\s*(```|~~~).*$")
+	mdRule      = regexp.MustCompile(`(?m)^\s*([-*_]\s*){3,}$`)
+	mdTableRule = regexp.MustCompile(`(?m)^\s*\|?\s*:?-{3,}:?\s*(\|\s*:?-{3,}:?\s*)*\|?\s*$`)
+	mdHTMLTag   = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+)
+
+// cleanMarkdown 去掉 Markdown 标记，保留文字、链接文本和代码内容
+func cleanMarkdown(s string) string {
+	s = mdFence.ReplaceAllString(s, "")
+	s = mdTableRule.ReplaceAllString(s, "")
+	s = mdRule.ReplaceAllString(s, "")
+	s = mdImage.ReplaceAllString(s, "$1")
+	s = mdLink.ReplaceAllString(s, "$1")
+	s = mdHeading.ReplaceAllString(s, "")
+	s = mdEmphasis.ReplaceAllString(s, "$2")
+	s = mdHTMLTag.ReplaceAllString(s, "")
+	return s
+}
+
+var (
+	spaceRun   = regexp.MustCompile(`[ \t\f\v\x{00a0}\x{3000}]+`)
+	blankLines = regexp.MustCompile(`\n{3,}`)
+)
+
+// normalizeText 统一换行、合并多余的空白和空行
+func normalizeText(s string) string {
+	if !utf8.ValidString(s) {
+		s = strings.ToValidUTF8(s, "")
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	s = spaceRun.ReplaceAllString(s, " ")
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	s = strings.Join(lines, "\n")
+	s = blankLines.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,499 @@
+package knowledge
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/job"
+	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/workflow"
+)
+
+// 导入流水线的节点类型
+const (
+	NodeExtract  workflow.NodeType = "knowledge.extract"  // 提取文本
+	NodeChunk    workflow.NodeType = "knowledge.chunk"    // 切分分块
+	NodeEmbed    workflow.NodeType = "knowledge.embed"    // 生成向量
+	NodeRetrieve workflow.NodeType = "knowledge.retrieve" // 检索参考资料
+)
+
+// InputDocumentID 导入工作流的输入：要导入的文档ID
+const InputDocumentID = "document_id"
+
+// JobIngest 文档导入的后台任务类型
+const JobIngest = "knowledge.ingest"
+
+// ingestAttempts 导入任务的最大执行次数，网页下载或向量化能力的临时故障可以通过重试恢复
+const ingestAttempts = 3
+
+// ingestTimeout 单个文档导入的执行上限，大文档的向量化可能需要较长时间
+const ingestTimeout = 30 * time.Minute
+
+// embedBatch 每次调用向量化能力的分块数
+const embedBatch = 16
+
+// watchInterval 检查导入执行状态的间隔
+const watchInterval = time.Second
+
+// IngestWorkflow 文档导入工作流：提取文本 -> 切分分块 -> 生成向量
+func IngestWorkflow() *workflow.Workflow {
+	inputs := []workflow.InputSchema{{Name: InputDocumentID, Type: "string", Required: true}}
+	return &workflow.Workflow{
+		ID:          "knowledge-ingest",
+		Name:        "知识库文档导入",
+		Description: "提取文本 -> 切分分块 -> 生成向量",
+		Version:     "1.0.0",
+		Config:      workflow.WorkflowConfig{Timeout: ingestTimeout, ParallelLimit: 1},
+		Nodes: []workflow.Node{
+			{ID: "start", Name: "开始", Type: workflow.NodeTypeStart, Inputs: inputs, Position: workflow.Position{X: 0, Y: 100}},
+			{ID: "extract", Name: "提取文本", Type: NodeExtract, Inputs: inputs, Position: workflow.Position{X: 200, Y: 100}},
+			{ID: "chunk", Name: "切分分块", Type: NodeChunk, Inputs: inputs, Position: workflow.Position{X: 400, Y: 100}},
+			{ID: "embed", Name: "生成向量", Type: NodeEmbed, Inputs: inputs, Position: workflow.Position{X: 600, Y: 100}},
+			{ID: "end", Name: "结束", Type: workflow.NodeTypeEnd, Position: workflow.Position{X: 800, Y: 100}},
+		},
+		Edges: []workflow.Edge{
+			{ID: "e1", From: "start", To: "extract"},
+			{ID: "e2", From: "extract", To: "chunk"},
+			{ID: "e3", From: "chunk", To: "embed"},
+			{ID: "e4", From: "embed", To: "end"},
+		},
+	}
+}
+
+// documentInputSchema 导入节点的输入
+var documentInputSchema = capability.Schema{
+	Type: "object",
+	Properties: map[string]capability.Property{
+		InputDocumentID: {Type: "string", Description: "要导入的文档ID"},
+	},
+	Required: []string{InputDocumentID},
+}
+
+// RegisterWorkflowNodes 将文档导入的各个步骤和知识库检索注册为工作流节点
+func RegisterWorkflowNodes(nodes *workflow.NodeRegistry, s *Service) error {
+	steps := []struct {
+		nodeType    workflow.NodeType
+		name        string
+		description string
+		output      capability.Property
+		execute     func(ctx context.Context, doc *Document) (map[string]interface{}, error)
+	}{
+		{NodeExtract, "提取文本", "读取上传的文件或下载网页，提取纯文本", capability.Property{Type: "integer", Description: "提取出的字数"}, s.extract},
+		{NodeChunk, "切分分块", "按段落和句子把提取出的文本切分为分块", capability.Property{Type: "integer", Description: "分块数"}, s.chunk},
+		{NodeEmbed, "生成向量", "调用向量化能力为分块生成向量，完成后文档可被检索", capability.Property{Type: "integer", Description: "生成向量的分块数"}, s.embed},
+	}
+	for _, step := range steps {
+		execute := step.execute
+		if err := nodes.Register(workflow.NodeTypeDefinition{
+			Type:        step.nodeType,
+			Name:        step.name,
+			Description: step.description,
+			InputSchema: documentInputSchema,
+			OutputSchema: capability.Schema{
+				Type:       "object",
+				Properties: map[string]capability.Property{InputDocumentID: documentInputSchema.Properties[InputDocumentID], "count": step.output},
+			},
+			UI: &capability.UIHints{Category: "knowledge", Icon: "book"},
+		}, workflow.NodeExecutorFunc(func(ctx context.Context, node *workflow.Node, inputs map[string]interface{}) (map[string]interface{}, error) {
+			id, _ := inputs[InputDocumentID].(string)
+			doc, err := s.repo.FindDocument(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if doc == nil {
+				return nil, fmt.Errorf("文档 %s 不存在或已删除", id)
+			}
+			outputs, err := execute(ctx, doc)
+			if err != nil {
+				s.fail(doc.ID, err)
+				return nil, err
+			}
+			outputs[InputDocumentID] = doc.ID
+			return outputs, nil
+		})); err != nil {
+			return err
+		}
+	}
+
+	return nodes.Register(workflow.NodeTypeDefinition{
+		Type:        NodeRetrieve,
+		Name:        "知识库检索",
+		Description: "在指定知识库中检索与文本相关的参考资料，输出带出处编号的 context",
+		ConfigSchema: capability.Schema{
+			Type: "object",
+			Properties: map[string]capability.Property{
+				"knowledge_base_ids": {Type: "array", Description: "检索的知识库ID"},
+				"top_k":              {Type: "integer", Description: "返回的分块数，缺省使用配置值"},
+			},
+			Required: []string{"knowledge_base_ids"},
+		},
+		InputSchema: capability.Schema{
+			Type:       "object",
+			Properties: map[string]capability.Property{"text": {Type: "string", Description: "检索的文本"}},
+			Required:   []string{"text"},
+		},
+		OutputSchema: capability.Schema{
+			Type: "object",
+			Properties: map[string]capability.Property{
+				"context": {Type: "string", Description: "带出处编号的参考资料，没有命中时为空"},
+				"hits":    {Type: "integer", Description: "命中的分块数"},
+			},
+		},
+		UI: &capability.UIHints{Category: "knowledge", Icon: "search"},
+	}, workflow.NodeExecutorFunc(func(ctx context.Context, node *workflow.Node, inputs map[string]interface{}) (map[string]interface{}, error) {
+		text, _ := inputs["text"].(string)
+		var baseIDs []string
+		switch ids := node.Config["knowledge_base_ids"].(type) {
+		case []string:
+			baseIDs = ids
+		case []interface{}:
+			for _, id := range ids {
+				if v, ok := id.(string); ok {
+					baseIDs = append(baseIDs, v)
+				}
+			}
+		}
+		topK := 0
+		switch v := node.Config["top_k"].(type) {
+		case float64:
+			topK = int(v)
+		case int:
+			topK = v
+		}
+		hits, err := s.Search(ctx, baseIDs, text, topK)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"context": FormatContext(hits), "hits": len(hits)}, nil
+	}))
+}
+
+// ingestPayload 导入任务的参数
+type ingestPayload struct {
+	DocumentID string `json:"document_id"`
+}
+
+// UseJobs 改为通过后台任务队列执行导入：服务重启后继续未完成的导入，失败时按退避重试；需在任务队列启动前调用
+func (s *Service) UseJobs(jobs *job.Service) error {
+	if err := jobs.Register(JobIngest, s.runIngestJob, job.HandlerOptions{MaxAttempts: ingestAttempts, Timeout: ingestTimeout}); err != nil {
+		return err
+	}
+	s.jobs = jobs
+	return nil
+}
+
+// startIngestion 提交导入任务；未使用任务队列时直接提交导入工作流，并在后台跟踪执行结果
+func (s *Service) startIngestion(ctx context.Context, doc *Document) {
+	if s.jobs != nil {
+		j, err := s.jobs.Enqueue(ctx, JobIngest, ingestPayload{DocumentID: doc.ID}, job.EnqueueOptions{Key: doc.ID})
+		if err != nil {
+			s.submitFailed(ctx, doc, err)
+			return
+		}
+		s.logger.InfoTag("知识库", "文档 %s(%s) 的导入任务已提交，任务ID: %s", doc.Name, doc.ID, j.ID)
+		return
+	}
+
+	// 导入在请求返回之后继续执行，不能使用请求的 ctx
+	execution, err := s.executor.Execute(context.Background(), IngestWorkflow(), map[string]interface{}{InputDocumentID: doc.ID})
+	if err != nil {
+		s.submitFailed(ctx, doc, err)
+		return
+	}
+	// 导入节点可能已经开始更新进度，只写执行ID以免覆盖
+	doc.ExecutionID = execution.ID
+	if err := s.repo.SetExecutionID(ctx, doc.ID, execution.ID); err != nil {
+		s.logger.ErrorTag("知识库", "更新文档 %s 失败: %v", doc.ID, err)
+	}
+	s.logger.InfoTag("知识库", "文档 %s(%s) 的导入任务已提交，执行ID: %s", doc.Name, doc.ID, execution.ID)
+	go s.watch(doc.ID, execution.ID)
+}
+
+// submitFailed 提交导入任务失败时把文档标记为失败
+func (s *Service) submitFailed(ctx context.Context, doc *Document, cause error) {
+	s.logger.ErrorTag("知识库", "提交文档 %s 的导入任务失败: %v", doc.ID, cause)
+	doc.Status = StatusFailed
+	doc.Error = "提交导入任务失败: " + cause.Error()
+	doc.UpdatedAt = s.now()
+	if err := s.repo.UpdateDocument(ctx, doc); err != nil {
+		s.logger.ErrorTag("知识库", "更新文档 %s 失败: %v", doc.ID, err)
+	}
+}
+
+// runIngestJob 执行导入任务：提交导入工作流并等待结束，导入失败时返回错误由任务队列重试
+func (s *Service) runIngestJob(ctx context.Context, j *job.Job) error {
+	var payload ingestPayload
+	if err := j.Decode(&payload); err != nil {
+		return job.Permanent(fmt.Errorf("invalid ingest payload: %w", err))
+	}
+	doc, err := s.repo.FindDocument(ctx, payload.DocumentID)
+	if err != nil {
+		return err
+	}
+	if doc == nil || doc.Status == StatusReady {
+		// 文档已删除或已导入完成
+		return nil
+	}
+	if doc.Status != StatusPending {
+		// 上次执行失败或因服务重启中断，从头开始
+		doc.Status = StatusPending
+		doc.Progress = 0
+		doc.Error = ""
+		doc.UpdatedAt = s.now()
+		if err := s.repo.UpdateDocument(ctx, doc); err != nil {
+			return err
+		}
+	}
+
+	execution, err := s.executor.Execute(ctx, IngestWorkflow(), map[string]interface{}{InputDocumentID: doc.ID})
+	if err != nil {
+		return err
+	}
+	if err := s.repo.SetExecutionID(ctx, doc.ID, execution.ID); err != nil {
+		s.logger.ErrorTag("知识库", "更新文档 %s 失败: %v", doc.ID, err)
+	}
+	s.logger.InfoTag("知识库", "开始导入文档 %s(%s)，第 %d 次执行，执行ID: %s", doc.Name, doc.ID, j.Attempts, execution.ID)
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := s.executor.Cancel(execution.ID); err != nil {
+				s.logger.DebugTag("知识库", "取消文档 %s 的导入: %v", doc.ID, err)
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		current, ok := s.executor.GetExecution(execution.ID)
+		if ok {
+			switch current.Status {
+			case workflow.ExecutionStatusPending, workflow.ExecutionStatusRunning, workflow.ExecutionStatusPaused:
+				continue
+			}
+		}
+
+		latest, err := s.repo.FindDocument(ctx, doc.ID)
+		if err != nil {
+			return err
+		}
+		// 文档已删除或已开始新的导入时结束本任务
+		if latest == nil || latest.ExecutionID != execution.ID || latest.Status == StatusReady {
+			return nil
+		}
+		reason := "导入任务已结束"
+		if latest.Error != "" {
+			reason = latest.Error
+		} else if ok && current.Error != "" {
+			reason = current.Error
+		}
+		if !latest.Status.Done() {
+			s.fail(doc.ID, fmt.Errorf("%s", reason))
+		}
+		return fmt.Errorf("%s", reason)
+	}
+}
+
+// watch 等待导入执行结束；执行被取消或超时等节点之外的原因结束时，把文档标记为失败
+func (s *Service) watch(documentID, executionID string) {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		execution, ok := s.executor.GetExecution(executionID)
+		if ok {
+			switch execution.Status {
+			case workflow.ExecutionStatusPending, workflow.ExecutionStatusRunning, workflow.ExecutionStatusPaused:
+				continue
+			}
+		}
+
+		doc, err := s.repo.FindDocument(context.Background(), documentID)
+		if err != nil || doc == nil || doc.ExecutionID != executionID || doc.Status.Done() {
+			return
+		}
+		reason := "导入任务已结束"
+		if ok && execution.Error != "" {
+			reason = execution.Error
+		}
+		s.fail(documentID, fmt.Errorf("%s", reason))
+		return
+	}
+}
+
+// cancelIngestion 取消文档进行中的导入
+func (s *Service) cancelIngestion(doc *Document) {
+	if doc.Status.Done() || doc.ExecutionID == "" {
+		return
+	}
+	if err := s.executor.Cancel(doc.ExecutionID); err != nil {
+		s.logger.DebugTag("知识库", "取消文档 %s 的导入: %v", doc.ID, err)
+	}
+}
+
+// progress 更新文档的导入状态和进度
+func (s *Service) progress(ctx context.Context, doc *Document, status Status, progress int) error {
+	doc.Status = status
+	doc.Progress = progress
+	doc.UpdatedAt = s.now()
+	return s.repo.UpdateDocument(ctx, doc)
+}
+
+// fail 把文档标记为导入失败
+func (s *Service) fail(documentID string, cause error) {
+	ctx := context.Background()
+	doc, err := s.repo.FindDocument(ctx, documentID)
+	if err != nil || doc == nil || doc.Status.Done() {
+		return
+	}
+	doc.Status = StatusFailed
+	doc.Error = cause.Error()
+	doc.UpdatedAt = s.now()
+	if err := s.repo.UpdateDocument(ctx, doc); err != nil {
+		s.logger.ErrorTag("知识库", "更新文档 %s 失败: %v", doc.ID, err)
+		return
+	}
+	s.logger.WarnTag("知识库", "文档 %s(%s) 导入失败: %v", doc.Name, doc.ID, cause)
+}
+
+func (s *Service) extract(ctx context.Context, doc *Document) (map[string]interface{}, error) {
+	if err := s.progress(ctx, doc, StatusExtracting, 5); err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	switch doc.SourceType {
+	case SourceURL:
+		body, format, err := s.fetch(ctx, doc.Source)
+		if err != nil {
+			return nil, err
+		}
+		data = body
+		doc.Format = format
+		doc.Size = int64(len(body))
+	default:
+		body, err := s.readOriginal(ctx, doc)
+		if err != nil {
+			return nil, fmt.Errorf("读取文件失败: %w", err)
+		}
+		data = body
+	}
+
+	text, err := ExtractText(doc.Format, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(s.baseDir(doc.BaseID), 0o755); err != nil {
+		return nil, fmt.Errorf("创建目录失败: %w", err)
+	}
+	if err := os.WriteFile(s.textPath(doc), []byte(text), 0o644); err != nil {
+		return nil, fmt.Errorf("保存提取的文本失败: %w", err)
+	}
+	if err := s.progress(ctx, doc, StatusExtracting, 20); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"count": runeLen(text)}, nil
+}
+
+// fetch 下载网页，超过文档大小上限时报错；未知类型按网页处理
+func (s *Service) fetch(ctx context.Context, rawURL string) ([]byte, Format, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("创建请求失败: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("下载网页失败: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("下载网页失败: HTTP %d", resp.StatusCode)
+	}
+
+	limit := s.MaxDocumentSize()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("读取网页失败: %w", err)
+	}
+	if int64(len(body)) > limit {
+		return nil, "", fmt.Errorf("网页超过 %d MB", s.cfg.MaxDocumentMB)
+	}
+	format, ok := DetectFormat(resp.Request.URL.Path, resp.Header.Get("Content-Type"))
+	if !ok {
+		format = FormatHTML
+	}
+	return body, format, nil
+}
+
+func (s *Service) chunk(ctx context.Context, doc *Document) (map[string]interface{}, error) {
+	if err := s.progress(ctx, doc, StatusChunking, 25); err != nil {
+		return nil, err
+	}
+	text, err := os.ReadFile(s.textPath(doc))
+	if err != nil {
+		return nil, fmt.Errorf("读取提取的文本失败: %w", err)
+	}
+	parts := splitChunks(string(text), s.cfg.ChunkSize, s.cfg.ChunkOverlap)
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("文档中没有文本内容")
+	}
+	chunks := make([]*Chunk, len(parts))
+	for i, content := range parts {
+		chunks[i] = &Chunk{ID: fmt.Sprintf("%s-%d", doc.ID, i), BaseID: doc.BaseID, DocumentID: doc.ID, Index: i, Content: content}
+	}
+	if err := s.repo.ReplaceChunks(ctx, doc.ID, chunks); err != nil {
+		return nil, err
+	}
+	doc.Chunks = len(chunks)
+	if err := s.progress(ctx, doc, StatusChunking, 30); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"count": len(chunks)}, nil
+}
+
+func (s *Service) embed(ctx context.Context, doc *Document) (map[string]interface{}, error) {
+	if err := s.progress(ctx, doc, StatusEmbedding, 30); err != nil {
+		return nil, err
+	}
+	chunks, err := s.repo.ListChunks(ctx, doc.ID)
+	if err != nil {
+		return nil, err
+	}
+	for start := 0; start < len(chunks); start += embedBatch {
+		end := start + embedBatch
+		if end > len(chunks) {
+			end = len(chunks)
+		}
+		batch := chunks[start:end]
+		texts := make([]string, len(batch))
+		for i, c := range batch {
+			texts[i] = c.Content
+		}
+		vectors, err := s.embedder.Embed(ctx, texts)
+		if err != nil {
+			return nil, err
+		}
+		for i, c := range batch {
+			c.Vector = vectors[i]
+		}
+		if err := s.repo.SaveVectors(ctx, batch); err != nil {
+			return nil, err
+		}
+		if err := s.progress(ctx, doc, StatusEmbedding, 30+69*end/len(chunks)); err != nil {
+			return nil, err
+		}
+	}
+
+	doc.Embedding = s.embedding
+	doc.Error = ""
+	if err := s.progress(ctx, doc, StatusReady, 100); err != nil {
+		return nil, err
+	}
+	s.invalidate(doc.BaseID)
+	s.logger.InfoTag("知识库", "文档 %s(%s) 导入完成，共 %d 个分块", doc.Name, doc.ID, len(chunks))
+	return map[string]interface{}{"count": len(chunks)}, nil
+}
@@ -0,0 +1,92 @@
+// Package knowledge 知识库
+//
+// 上传的 PDF、Markdown、文本文件或网页地址作为文档加入知识库，由工作流任务依次提取文本、
+// 分块并向量化后写入向量存储，导入进度记录在文档上。设备按所属的知识库档案检索绑定的知识库，
+// 命中的分块连同出处作为参考资料附加给 LLM。
+package knowledge
+
+import "time"
+
+// Status 文档导入状态
+type Status string
+
+const (
+	StatusPending    Status = "pending"    // 已提交，等待导入任务开始
+	StatusExtracting Status = "extracting" // 提取文本
+	StatusChunking   Status = "chunking"   // 分块
+	StatusEmbedding  Status = "embedding"  // 向量化
+	StatusReady      Status = "ready"      // 可检索
+	StatusFailed     Status = "failed"     // 导入失败
+)
+
+// Done 导入是否已结束
+func (s Status) Done() bool {
+	return s == StatusReady || s == StatusFailed
+}
+
+// SourceType 文档来源
+type SourceType string
+
+const (
+	SourceFile SourceType = "file" // 上传的文件
+	SourceURL  SourceType = "url"  // 网页地址
+)
+
+// Format 文档格式
+type Format string
+
+const (
+	FormatPDF      Format = "pdf"
+	FormatMarkdown Format = "markdown"
+	FormatText     Format = "text"
+	FormatHTML     Format = "html"
+)
+
+// Base 知识库
+type Base struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Document 知识库中的文档
+type Document struct {
+	ID          string     `json:"id"`
+	BaseID      string     `json:"base_id"`
+	Name        string     `json:"name"`
+	SourceType  SourceType `json:"source_type"`
+	Source      string     `json:"source"` // 上传时的文件名或网页地址
+	Format      Format     `json:"format"` // 网页在提取时按响应类型确定
+	Size        int64      `json:"size"`
+	Status      Status     `json:"status"`
+	Progress    int        `json:"progress"` // 0-100
+	Chunks      int        `json:"chunks"`
+	Embedding   string     `json:"embedding"`           // 生成向量使用的能力ID
+	ExecutionID string     `json:"execution_id"`        // 导入任务的工作流执行ID
+	ObjectID    string     `json:"object_id,omitempty"` // 上传文件在对象存储中的ID，未启用对象存储时为空
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// Chunk 文档分块及其向量
+type Chunk struct {
+	ID         string
+	BaseID     string
+	DocumentID string
+	Index      int // 在文档中的顺序
+	Content    string
+	Vector     []float32 // 未向量化时为空
+}
+
+// Hit 检索命中的分块
+type Hit struct {
+	BaseID       string  `json:"base_id"`
+	DocumentID   string  `json:"document_id"`
+	DocumentName string  `json:"document_name"`
+	ChunkIndex   int     `json:"chunk_index"`
+	Content      string  `json:"content"`
+	Score        float64 `json:"score"`
+}
@@ -0,0 +1,918 @@
+package knowledge
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// PDF 文本提取：解析间接对象（含对象流），解压 FlateDecode 流，按页面顺序解释内容流中的文本操作符，
+// 字体带 ToUnicode 映射时据此解码。不支持加密文档，扫描件（图片）中的文字无法提取。
+
+var (
+	pdfObjPattern     = regexp.MustCompile(`(\d+)\s+\d+\s+obj\b`)
+	pdfRefPattern     = regexp.MustCompile(`^(\d+)\s+\d+\s+R$`)
+	pdfRefPrefix      = regexp.MustCompile(`^\d+\s+\d+\s+R\b`)
+	pdfHexPattern     = regexp.MustCompile(`<[0-9A-Fa-f\s]*>|\[|\]`)
+	pdfEncryptPattern = regexp.MustCompile(`/Encrypt\s`)
+)
+
+// pdfMaxFormDepth 表单 XObject 的最大嵌套层数
+const pdfMaxFormDepth = 5
+
+type pdfObject struct {
+	body   string // 对象本身（字典、数组等）的文本，带流时为流之前的字典
+	stream []byte // 解码后的流数据，没有流或使用了不支持的过滤器时为 nil
+}
+
+type pdfFont struct {
+	cmap    map[uint32]string
+	codeLen int // 每个字符编码的字节数
+}
+
+type pdfReader struct {
+	objects map[int]*pdfObject
+	fonts   map[int]*pdfFont
+	out     strings.Builder
+}
+
+// extractPDF 按页面顺序提取 PDF 中的文本
+func extractPDF(data []byte) (string, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, "\x00\t\r\n "), []byte("%PDF")) {
+		return "", fmt.Errorf("不是有效的 PDF 文件")
+	}
+	r := &pdfReader{objects: make(map[int]*pdfObject), fonts: make(map[int]*pdfFont)}
+	r.parseObjects(data)
+	for _, obj := range r.objects {
+		if pdfEncryptPattern.MatchString(obj.body) {
+			return "", fmt.Errorf("不支持加密的 PDF 文件")
+		}
+	}
+	if pdfEncryptPattern.Match(trailerOf(data)) {
+		return "", fmt.Errorf("不支持加密的 PDF 文件")
+	}
+
+	for _, page := range r.pages() {
+		r.renderPage(page)
+		r.newline()
+	}
+	return strings.TrimSpace(r.out.String()), nil
+}
+
+// trailerOf 返回最后一个 trailer 字典附近的内容，用于检测加密
+func trailerOf(data []byte) []byte {
+	idx := bytes.LastIndex(data, []byte("trailer"))
+	if idx < 0 {
+		return nil
+	}
+	return data[idx:]
+}
+
+// parseObjects 顺序扫描间接对象，后出现的同号对象（增量更新）覆盖先出现的
+func (r *pdfReader) parseObjects(data []byte) {
+	var objStreams []*pdfObject
+	pos := 0
+	for pos < len(data) {
+		loc := pdfObjPattern.FindSubmatchIndex(data[pos:])
+		if loc == nil {
+			break
+		}
+		num, _ := strconv.Atoi(string(data[pos+loc[2] : pos+loc[3]]))
+		start := pos + loc[1]
+
+		obj := &pdfObject{}
+		end := bytes.Index(data[start:], []byte("endobj"))
+		if end < 0 {
+			end = len(data) - start
+		}
+		body := data[start : start+end]
+		if idx := streamStart(body); idx >= 0 {
+			dict := body[:bytes.LastIndex(body[:idx], []byte("stream"))]
+			streamEnd := bytes.Index(data[start+idx:], []byte("endstream"))
+			if streamEnd < 0 {
+				break
+			}
+			raw := data[start+idx : start+idx+streamEnd]
+			obj.body = string(dict)
+			obj.stream = decodeStream(obj.body, raw)
+			// 流数据中可能出现 endobj 字样，以 endstream 之后的 endobj 为准
+			after := start + idx + streamEnd
+			if e := bytes.Index(data[after:], []byte("endobj")); e >= 0 {
+				end = after - start + e
+			} else {
+				end = len(data) - start
+			}
+		} else {
+			obj.body = string(body)
+		}
+		r.objects[num] = obj
+		if obj.stream != nil && nameValue(dictEntry(obj.body, "Type")) == "ObjStm" {
+			objStreams = append(objStreams, obj)
+		}
+		pos = start + end + len("endobj")
+	}
+
+	for _, stm := range objStreams {
+		r.expandObjectStream(stm)
+	}
+}
+
+// streamStart 返回流数据在对象内的起始位置，没有流时返回 -1
+func streamStart(body []byte) int {
+	idx := bytes.Index(body, []byte(">>"))
+	if idx < 0 {
+		return -1
+	}
+	for {
+		s := bytes.Index(body[idx:], []byte("stream"))
+		if s < 0 {
+			return -1
+		}
+		p := idx + s + len("stream")
+		// 排除 endstream 等，stream 关键字后紧跟换行
+		if idx+s >= 3 && string(body[idx+s-3:idx+s]) == "end" {
+			idx = p
+			continue
+		}
+		if p < len(body) && body[p] == '\r' {
+			p++
+		}
+		if p < len(body) && body[p] == '\n' {
+			p++
+		}
+		return p
+	}
+}
+
+// decodeStream 按 Filter 解码流数据，仅支持不带过滤器和 FlateDecode
+func decodeStream(dict string, raw []byte) []byte {
+	filter := dictEntry(dict, "Filter")
+	if filter == "" {
+		return raw
+	}
+	names := strings.Fields(strings.NewReplacer("[", " ", "]", " ", "/", " /").Replace(filter))
+	for _, name := range names {
+		if name != "/FlateDecode" && name != "/Fl" {
+			return nil
+		}
+	}
+	data := raw
+	for range names {
+		zr, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil
+		}
+		// 部分生成器写出的流缺少校验和，已读出的内容仍然可用
+		decoded, err := io.ReadAll(zr)
+		if len(decoded) == 0 && err != nil {
+			return nil
+		}
+		data = decoded
+	}
+	return data
+}
+
+// expandObjectStream 展开对象流中的对象，已单独定义的对象不覆盖
+func (r *pdfReader) expandObjectStream(stm *pdfObject) {
+	n, _ := strconv.Atoi(dictEntry(stm.body, "N"))
+	first, _ := strconv.Atoi(dictEntry(stm.body, "First"))
+	if n <= 0 || first <= 0 || first > len(stm.stream) {
+		return
+	}
+	header := strings.Fields(string(stm.stream[:first]))
+	type entry struct{ num, offset int }
+	var entries []entry
+	for i := 0; i+1 < len(header) && len(entries) < n; i += 2 {
+		num, err1 := strconv.Atoi(header[i])
+		offset, err2 := strconv.Atoi(header[i+1])
+		if err1 != nil || err2 != nil {
+			return
+		}
+		entries = append(entries, entry{num, first + offset})
+	}
+	for i, e := range entries {
+		end := len(stm.stream)
+		if i+1 < len(entries) {
+			end = entries[i+1].offset
+		}
+		if e.offset > end || end > len(stm.stream) {
+			continue
+		}
+		if _, exists := r.objects[e.num]; !exists {
+			r.objects[e.num] = &pdfObject{body: string(stm.stream[e.offset:end])}
+		}
+	}
+}
+
+// pdfPage 页面字典及其（可能继承自上级的）资源字典
+type pdfPage struct {
+	body      string
+	resources string
+}
+
+// pages 按页面树顺序返回页面，找不到文档目录时按对象号顺序
+func (r *pdfReader) pages() []pdfPage {
+	for _, obj := range r.objects {
+		if nameValue(dictEntry(obj.body, "Type")) != "Catalog" {
+			continue
+		}
+		var pages []pdfPage
+		r.walkPages(dictEntry(obj.body, "Pages"), "", &pages, 0)
+		if len(pages) > 0 {
+			return pages
+		}
+	}
+
+	nums := make([]int, 0, len(r.objects))
+	for num, obj := range r.objects {
+		if nameValue(dictEntry(obj.body, "Type")) == "Page" {
+			nums = append(nums, num)
+		}
+	}
+	sort.Ints(nums)
+	pages := make([]pdfPage, 0, len(nums))
+	for _, num := range nums {
+		body := r.objects[num].body
+		pages = append(pages, pdfPage{body: body, resources: r.resolve(dictEntry(body, "Resources"))})
+	}
+	return pages
+}
+
+func (r *pdfReader) walkPages(ref, inherited string, pages *[]pdfPage, depth int) {
+	if depth > 32 {
+		return
+	}
+	node := r.resolve(ref)
+	if node == "" {
+		return
+	}
+	resources := inherited
+	if res := dictEntry(node, "Resources"); res != "" {
+		resources = r.resolve(res)
+	}
+	if nameValue(dictEntry(node, "Type")) == "Page" {
+		*pages = append(*pages, pdfPage{body: node, resources: resources})
+		return
+	}
+	for _, kid := range arrayItems(r.resolve(dictEntry(node, "Kids"))) {
+		r.walkPages(kid, resources, pages, depth+1)
+	}
+}
+
+// renderPage 解释页面内容流
+func (r *pdfReader) renderPage(page pdfPage) {
+	contents := dictEntry(page.body, "Contents")
+	var data []byte
+	if num, ok := refNumber(contents); ok {
+		if obj := r.objects[num]; obj != nil {
+			if obj.stream != nil {
+				data = obj.stream
+			} else {
+				contents = obj.body
+			}
+		}
+	}
+	if data == nil {
+		for _, item := range arrayItems(contents) {
+			if num, ok := refNumber(item); ok && r.objects[num] != nil {
+				data = append(data, r.objects[num].stream...)
+				data = append(data, '\n')
+			}
+		}
+	}
+	r.render(data, page.resources, 0)
+}
+
+// render 解释内容流中的文本操作符，Do 引用的表单 XObject 递归解释
+func (r *pdfReader) render(data []byte, resources string, depth int) {
+	fonts := parseDict(r.resolve(dictEntry(resources, "Font")))
+	xobjects := parseDict(r.resolve(dictEntry(resources, "XObject")))
+
+	var font *pdfFont
+	var operands []pdfToken
+	lastY, hasY := 0.0, false
+	lex := &pdfLexer{data: data}
+	for {
+		tok, ok := lex.next()
+		if !ok {
+			return
+		}
+		if tok.kind != pdfOperator {
+			operands = append(operands, tok)
+			continue
+		}
+		switch tok.text {
+		case "Tf":
+			if len(operands) >= 2 && operands[len(operands)-2].kind == pdfName {
+				font = r.font(fonts[operands[len(operands)-2].text])
+			}
+		case "Tj":
+			if len(operands) > 0 {
+				r.write(font, operands[len(operands)-1])
+			}
+		case "'", "\"":
+			r.newline()
+			if len(operands) > 0 {
+				r.write(font, operands[len(operands)-1])
+			}
+		case "TJ":
+			for _, item := range arrayOperand(operands) {
+				if item.kind == pdfNumber {
+					// 较大的负字距通常是词间空格
+					if v, _ := strconv.ParseFloat(item.text, 64); v < -250 {
+						r.space()
+					}
+					continue
+				}
+				r.write(font, item)
+			}
+		case "Td", "TD":
+			if len(operands) >= 2 {
+				if ty, _ := strconv.ParseFloat(operands[len(operands)-1].text, 64); ty != 0 {
+					r.newline()
+				}
+			}
+		case "T*":
+			r.newline()
+		case "Tm":
+			if len(operands) >= 6 {
+				y, _ := strconv.ParseFloat(operands[len(operands)-1].text, 64)
+				if hasY && y != lastY {
+					r.newline()
+				}
+				lastY, hasY = y, true
+			}
+		case "Do":
+			if len(operands) > 0 && depth < pdfMaxFormDepth {
+				if num, ok := refNumber(xobjects[operands[len(operands)-1].text]); ok {
+					if obj := r.objects[num]; obj != nil && obj.stream != nil && nameValue(dictEntry(obj.body, "Subtype")) == "Form" {
+						formResources := resources
+						if res := dictEntry(obj.body, "Resources"); res != "" {
+							formResources = r.resolve(res)
+						}
+						r.render(obj.stream, formResources, depth+1)
+					}
+				}
+			}
+		}
+		operands = operands[:0]
+	}
+}
+
+// font 加载字体的 ToUnicode 映射，按对象号缓存
+func (r *pdfReader) font(ref string) *pdfFont {
+	num, ok := refNumber(ref)
+	if !ok {
+		return nil
+	}
+	if f, cached := r.fonts[num]; cached {
+		return f
+	}
+	f := &pdfFont{codeLen: 1}
+	if obj := r.objects[num]; obj != nil {
+		if nameValue(dictEntry(obj.body, "Subtype")) == "Type0" {
+			f.codeLen = 2
+		}
+		if cmapNum, ok := refNumber(dictEntry(obj.body, "ToUnicode")); ok {
+			if cmapObj := r.objects[cmapNum]; cmapObj != nil && cmapObj.stream != nil {
+				f.cmap, f.codeLen = parseToUnicode(string(cmapObj.stream), f.codeLen)
+			}
+		}
+	}
+	r.fonts[num] = f
+	return f
+}
+
+// write 按字体解码字符串并输出
+func (r *pdfReader) write(font *pdfFont, tok pdfToken) {
+	if tok.kind != pdfString {
+		return
+	}
+	raw := []byte(tok.text)
+	if font == nil || font.cmap == nil {
+		if font != nil && font.codeLen == 2 {
+			return // 复合字体没有 ToUnicode 映射时无法解码
+		}
+		for _, b := range raw {
+			if b >= 0x20 || b == '\t' {
+				r.out.WriteRune(rune(b))
+			}
+		}
+		return
+	}
+	for i := 0; i+font.codeLen <= len(raw); i += font.codeLen {
+		var code uint32
+		for _, b := range raw[i : i+font.codeLen] {
+			code = code<<8 | uint32(b)
+		}
+		if s, ok := font.cmap[code]; ok {
+			r.out.WriteString(s)
+		} else if font.codeLen == 1 && code >= 0x20 {
+			r.out.WriteRune(rune(code))
+		}
+	}
+}
+
+func (r *pdfReader) newline() {
+	s := r.out.String()
+	if s != "" && !strings.HasSuffix(s, "\n") {
+		r.out.WriteByte('\n')
+	}
+}
+
+func (r *pdfReader) space() {
+	last, size := utf8.DecodeLastRuneInString(r.out.String())
+	if size > 0 && !unicode.IsSpace(last) && !unicode.Is(unicode.Han, last) {
+		r.out.WriteByte(' ')
+	}
+}
+
+// resolve 解析间接引用，返回被引用对象的文本；不是引用时原样返回
+func (r *pdfReader) resolve(value string) string {
+	if num, ok := refNumber(value); ok {
+		if obj := r.objects[num]; obj != nil {
+			return obj.body
+		}
+		return ""
+	}
+	return value
+}
+
+// parseToUnicode 解析 ToUnicode CMap 中的 bfchar 和 bfrange 映射
+func parseToUnicode(cmap string, codeLen int) (map[uint32]string, int) {
+	if section := between(cmap, "begincodespacerange", "endcodespacerange"); section != "" {
+		if hexes := pdfHexPattern.FindAllString(section, 1); len(hexes) == 1 {
+			if n := len(hexBytes(hexes[0])); n > 0 {
+				codeLen = n
+			}
+		}
+	}
+
+	mapping := make(map[uint32]string)
+	for _, section := range sections(cmap, "beginbfchar", "endbfchar") {
+		tokens := pdfHexPattern.FindAllString(section, -1)
+		for i := 0; i+1 < len(tokens); i += 2 {
+			mapping[hexCode(tokens[i])] = utf16String(hexBytes(tokens[i+1]))
+		}
+	}
+	for _, section := range sections(cmap, "beginbfrange", "endbfrange") {
+		tokens := pdfHexPattern.FindAllString(section, -1)
+		for i := 0; i+2 < len(tokens); {
+			lo, hi := hexCode(tokens[i]), hexCode(tokens[i+1])
+			if hi < lo || hi-lo > 0xFFFF {
+				break
+			}
+			if tokens[i+2] == "[" {
+				j := i + 3
+				for code := lo; j < len(tokens) && tokens[j] != "]"; code, j = code+1, j+1 {
+					mapping[code] = utf16String(hexBytes(tokens[j]))
+				}
+				i = j + 1
+				continue
+			}
+			dst := hexBytes(tokens[i+2])
+			for code := lo; code <= hi; code++ {
+				mapping[code] = utf16String(dst)
+				// 目标编码的末字节随源编码递增
+				if len(dst) > 0 {
+					dst = append([]byte(nil), dst...)
+					dst[len(dst)-1]++
+				}
+			}
+			i += 3
+		}
+	}
+	return mapping, codeLen
+}
+
+func between(s, begin, end string) string {
+	start := strings.Index(s, begin)
+	if start < 0 {
+		return ""
+	}
+	start += len(begin)
+	stop := strings.Index(s[start:], end)
+	if stop < 0 {
+		return ""
+	}
+	return s[start : start+stop]
+}
+
+func sections(s, begin, end string) []string {
+	var result []string
+	for {
+		section := between(s, begin, end)
+		if section == "" {
+			return result
+		}
+		result = append(result, section)
+		s = s[strings.Index(s, begin)+len(begin)+len(section)+len(end):]
+	}
+}
+
+func hexBytes(token string) []byte {
+	hex := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '<' || r == '>' {
+			return -1
+		}
+		return r
+	}, token)
+	if len(hex)%2 == 1 {
+		hex += "0"
+	}
+	out := make([]byte, 0, len(hex)/2)
+	for i := 0; i+1 < len(hex); i += 2 {
+		v, err := strconv.ParseUint(hex[i:i+2], 16, 8)
+		if err != nil {
+			return nil
+		}
+		out = append(out, byte(v))
+	}
+	return out
+}
+
+func hexCode(token string) uint32 {
+	var code uint32
+	for _, b := range hexBytes(token) {
+		code = code<<8 | uint32(b)
+	}
+	return code
+}
+
+func utf16String(b []byte) string {
+	if len(b) == 1 {
+		return string(rune(b[0]))
+	}
+	units := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
+
+// refNumber 解析 "12 0 R" 形式的间接引用
+func refNumber(value string) (int, bool) {
+	m := pdfRefPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return 0, false
+	}
+	num, err := strconv.Atoi(m[1])
+	return num, err == nil
+}
+
+// nameValue 去掉名称对象的前导 /
+func nameValue(value string) string {
+	return strings.TrimPrefix(strings.TrimSpace(value), "/")
+}
+
+// dictEntry 返回字典顶层键对应的值的原始文本
+func dictEntry(dict, key string) string {
+	return parseDict(dict)["/"+key]
+}
+
+// parseDict 解析字典顶层的键值对，值保留原始文本
+func parseDict(dict string) map[string]string {
+	result := make(map[string]string)
+	s := strings.TrimSpace(dict)
+	if !strings.HasPrefix(s, "<<") {
+		return result
+	}
+	i := 2
+	for {
+		i = skipSpace(s, i)
+		if i >= len(s) || strings.HasPrefix(s[i:], ">>") || s[i] != '/' {
+			return result
+		}
+		keyEnd := scanName(s, i)
+		key := s[i:keyEnd]
+		valueStart := skipSpace(s, keyEnd)
+		valueEnd := scanValue(s, valueStart)
+		if valueEnd <= valueStart {
+			return result
+		}
+		result[key] = strings.TrimSpace(s[valueStart:valueEnd])
+		i = valueEnd
+	}
+}
+
+// arrayItems 拆分数组的元素，间接引用作为一个元素
+func arrayItems(array string) []string {
+	s := strings.TrimSpace(array)
+	if !strings.HasPrefix(s, "[") {
+		if s != "" {
+			return []string{s}
+		}
+		return nil
+	}
+	var items []string
+	i := 1
+	for {
+		i = skipSpace(s, i)
+		if i >= len(s) || s[i] == ']' {
+			return items
+		}
+		end := scanValue(s, i)
+		if end <= i {
+			return items
+		}
+		items = append(items, strings.TrimSpace(s[i:end]))
+		i = end
+	}
+}
+
+func skipSpace(s string, i int) int {
+	for i < len(s) {
+		switch s[i] {
+		case ' ', '\t', '\r', '\n', '\f', 0:
+			i++
+		case '%':
+			for i < len(s) && s[i] != '\n' && s[i] != '\r' {
+				i++
+			}
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+func isDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/% \t\r\n\f\x00", c) >= 0
+}
+
+func scanName(s string, i int) int {
+	i++ // 跳过 /
+	for i < len(s) && !isDelimiter(s[i]) {
+		i++
+	}
+	return i
+}
+
+// scanValue 返回从 i 开始的一个值的结束位置，"12 0 R" 作为一个值
+func scanValue(s string, i int) int {
+	if i >= len(s) {
+		return i
+	}
+	switch {
+	case strings.HasPrefix(s[i:], "<<"):
+		return scanBalanced(s, i, "<<", ">>")
+	case s[i] == '[':
+		return scanBalanced(s, i, "[", "]")
+	case s[i] == '(':
+		return scanLiteral(s, i)
+	case s[i] == '<':
+		if end := strings.IndexByte(s[i:], '>'); end >= 0 {
+			return i + end + 1
+		}
+		return len(s)
+	case s[i] == '/':
+		return scanName(s, i)
+	}
+	end := i
+	for end < len(s) && !isDelimiter(s[end]) {
+		end++
+	}
+	if end == i {
+		return i + 1
+	}
+	// 引用：数字 数字 R
+	if m := pdfRefPrefix.FindStringIndex(s[i:]); m != nil {
+		return i + m[1]
+	}
+	return end
+}
+
+func scanBalanced(s string, i int, open, close string) int {
+	depth := 0
+	for i < len(s) {
+		switch {
+		case s[i] == '(':
+			i = scanLiteral(s, i)
+			continue
+		case strings.HasPrefix(s[i:], open):
+			depth++
+			i += len(open)
+			continue
+		case strings.HasPrefix(s[i:], close):
+			depth--
+			i += len(close)
+			if depth == 0 {
+				return i
+			}
+			continue
+		case open == "<<" && s[i] == '[':
+			i = scanBalanced(s, i, "[", "]")
+			continue
+		case open == "[" && strings.HasPrefix(s[i:], "<<"):
+			i = scanBalanced(s, i, "<<", ">>")
+			continue
+		}
+		i++
+	}
+	return len(s)
+}
+
+func scanLiteral(s string, i int) int {
+	depth := 0
+	for i < len(s) {
+		switch s[i] {
+		case '\\':
+			i += 2
+			continue
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+		i++
+	}
+	return len(s)
+}
+
+type pdfTokenKind int
+
+const (
+	pdfNumber pdfTokenKind = iota
+	pdfName
+	pdfString
+	pdfArrayStart
+	pdfArrayEnd
+	pdfOperator
+	pdfOther
+)
+
+type pdfToken struct {
+	kind pdfTokenKind
+	text string // 字符串为解码后的字节，名称不含 /
+}
+
+// arrayOperand 取出操作数栈中最后一个数组的元素
+func arrayOperand(operands []pdfToken) []pdfToken {
+	start := -1
+	for i := len(operands) - 1; i >= 0; i-- {
+		if operands[i].kind == pdfArrayStart {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return nil
+	}
+	var items []pdfToken
+	for _, tok := range operands[start+1:] {
+		if tok.kind == pdfArrayEnd {
+			break
+		}
+		items = append(items, tok)
+	}
+	return items
+}
+
+// pdfLexer 内容流词法分析
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+func (l *pdfLexer) next() (pdfToken, bool) {
+	s := l.data
+	for l.pos < len(s) {
+		c := s[l.pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f' || c == 0:
+			l.pos++
+		case c == '%':
+			for l.pos < len(s) && s[l.pos] != '\n' && s[l.pos] != '\r' {
+				l.pos++
+			}
+		case c == '(':
+			return pdfToken{kind: pdfString, text: l.literal()}, true
+		case c == '<' && l.pos+1 < len(s) && s[l.pos+1] == '<':
+			l.pos += 2
+			return pdfToken{kind: pdfOther, text: "<<"}, true
+		case c == '>' && l.pos+1 < len(s) && s[l.pos+1] == '>':
+			l.pos += 2
+			return pdfToken{kind: pdfOther, text: ">>"}, true
+		case c == '<':
+			end := bytes.IndexByte(s[l.pos:], '>')
+			if end < 0 {
+				end = len(s) - l.pos - 1
+			}
+			tok := pdfToken{kind: pdfString, text: string(hexBytes(string(s[l.pos : l.pos+end+1])))}
+			l.pos += end + 1
+			return tok, true
+		case c == '[':
+			l.pos++
+			return pdfToken{kind: pdfArrayStart}, true
+		case c == ']':
+			l.pos++
+			return pdfToken{kind: pdfArrayEnd}, true
+		case c == '/':
+			end := scanName(string(s[l.pos:min(len(s), l.pos+256)]), 0)
+			tok := pdfToken{kind: pdfName, text: string(s[l.pos+1 : l.pos+end])}
+			l.pos += end
+			return tok, true
+		case c == '{' || c == '}' || c == ')' || c == '>':
+			l.pos++
+		default:
+			start := l.pos
+			for l.pos < len(s) && !isDelimiter(s[l.pos]) {
+				l.pos++
+			}
+			word := string(s[start:l.pos])
+			if _, err := strconv.ParseFloat(word, 64); err == nil {
+				return pdfToken{kind: pdfNumber, text: word}, true
+			}
+			if word == "ID" {
+				l.skipInlineImage()
+				continue
+			}
+			return pdfToken{kind: pdfOperator, text: word}, true
+		}
+	}
+	return pdfToken{}, false
+}
+
+// skipInlineImage 跳过内联图片数据，直到 EI
+func (l *pdfLexer) skipInlineImage() {
+	for l.pos+2 < len(l.data) {
+		if l.data[l.pos] == 'E' && l.data[l.pos+1] == 'I' && isDelimiter(l.data[l.pos-1]) &&
+			(l.pos+2 == len(l.data) || isDelimiter(l.data[l.pos+2])) {
+			l.pos += 2
+			return
+		}
+		l.pos++
+	}
+	l.pos = len(l.data)
+}
+
+// literal 解析 (...) 字符串，处理转义和嵌套括号
+func (l *pdfLexer) literal() string {
+	s := l.data
+	l.pos++ // 跳过 (
+	depth := 1
+	var out []byte
+	for l.pos < len(s) {
+		c := s[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+			out = append(out, c)
+		case ')':
+			depth--
+			if depth == 0 {
+				return string(out)
+			}
+			out = append(out, c)
+		case '\\':
+			if l.pos >= len(s) {
+				return string(out)
+			}
+			e := s[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case 'b':
+				out = append(out, '\b')
+			case 'f':
+				out = append(out, '\f')
+			case '\r':
+				if l.pos < len(s) && s[l.pos] == '\n' {
+					l.pos++
+				}
+			case '\n':
+			default:
+				if e >= '0' && e <= '7' {
+					v := int(e - '0')
+					for n := 0; n < 2 && l.pos < len(s) && s[l.pos] >= '0' && s[l.pos] <= '7'; n++ {
+						v = v*8 + int(s[l.pos]-'0')
+						l.pos++
+					}
+					out = append(out, byte(v))
+				} else {
+					out = append(out, e)
+				}
+			}
+		default:
+			out = append(out, c)
+		}
+	}
+	return string(out)
+}
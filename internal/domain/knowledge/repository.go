@@ -0,0 +1,63 @@
+package knowledge
+
+import "context"
+
+// Repository 知识库仓库接口
+type Repository interface {
+	// SaveBase 新建知识库
+	SaveBase(ctx context.Context, b *Base) error
+
+	// UpdateBase 更新知识库
+	UpdateBase(ctx context.Context, b *Base) error
+
+	// FindBase 根据ID查找知识库，不存在时返回 nil
+	FindBase(ctx context.Context, id string) (*Base, error)
+
+	// FindBaseByName 根据名称查找知识库，不存在时返回 nil
+	FindBaseByName(ctx context.Context, name string) (*Base, error)
+
+	// ListBases 查询全部知识库
+	ListBases(ctx context.Context) ([]*Base, error)
+
+	// DeleteBase 删除知识库及其文档、分块和档案绑定
+	DeleteBase(ctx context.Context, id string) error
+
+	// SaveDocument 新建文档
+	SaveDocument(ctx context.Context, d *Document) error
+
+	// UpdateDocument 更新文档的导入状态和进度，不修改执行ID；文档已删除时不做任何事
+	UpdateDocument(ctx context.Context, d *Document) error
+
+	// SetExecutionID 记录文档当前导入任务的执行ID
+	SetExecutionID(ctx context.Context, id, executionID string) error
+
+	// FindDocument 根据ID查找文档，不存在时返回 nil
+	FindDocument(ctx context.Context, id string) (*Document, error)
+
+	// ListDocuments 查询知识库中的文档，最新的在前
+	ListDocuments(ctx context.Context, baseID string) ([]*Document, error)
+
+	// DeleteDocument 删除文档及其分块
+	DeleteDocument(ctx context.Context, id string) error
+
+	// FailUnfinished 将导入未结束的文档标记为失败，返回影响的条数
+	FailUnfinished(ctx context.Context, reason string) (int64, error)
+
+	// ReplaceChunks 用新的分块替换文档原有的分块
+	ReplaceChunks(ctx context.Context, documentID string, chunks []*Chunk) error
+
+	// ListChunks 查询文档的分块，按顺序排列
+	ListChunks(ctx context.Context, documentID string) ([]*Chunk, error)
+
+	// ListBaseChunks 查询知识库中已导入完成的文档的分块
+	ListBaseChunks(ctx context.Context, baseID string) ([]*Chunk, error)
+
+	// SaveVectors 保存分块的向量
+	SaveVectors(ctx context.Context, chunks []*Chunk) error
+
+	// ListBindings 查询全部档案绑定，档案名称到知识库ID
+	ListBindings(ctx context.Context) (map[string][]string, error)
+
+	// SetBindings 设置档案绑定的知识库，为空时解除该档案的全部绑定
+	SetBindings(ctx context.Context, profile string, baseIDs []string) error
+}
@@ -0,0 +1,651 @@
+package knowledge
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/domain/job"
+	"xiaozhi-server-go/internal/domain/objectstore"
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/workflow"
+)
+
+var (
+	// ErrBaseNotFound 知识库不存在
+	ErrBaseNotFound = stderrors.New("knowledge base not found")
+	// ErrDocumentNotFound 文档不存在
+	ErrDocumentNotFound = stderrors.New("knowledge document not found")
+)
+
+// defaultProfile 未单独配置的设备使用的知识库档案
+const defaultProfile = "default"
+
+// maxTopK 单次检索返回的分块上限
+const maxTopK = 20
+
+// indexedChunk 检索索引中的分块
+type indexedChunk struct {
+	chunk    *Chunk
+	document string // 文档名称，作为出处
+}
+
+// Service 知识库服务，管理知识库和文档、执行导入任务并检索参考资料
+type Service struct {
+	repo      Repository
+	cfg       config.KnowledgeConfig
+	embedder  Embedder
+	embedding string // 向量化能力ID，未配置时为 HashEmbedding
+	executor  workflow.WorkflowExecutor
+	jobs      *job.Service         // 未使用任务队列时为 nil
+	objects   *objectstore.Service // 未启用对象存储时为 nil，上传文件保存在 Dir 下
+	client    *http.Client
+	logger    *logging.Logger
+	now       func() time.Time
+
+	mu       sync.RWMutex
+	index    map[string][]indexedChunk // 按知识库缓存的检索索引，文档变化时失效
+	bindings map[string][]string       // 档案名称到知识库ID
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局知识库服务，供设备对话和工作流节点使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局知识库服务，未启用时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建知识库服务；cfg.Embedding 为空时使用内置的哈希向量，导入任务由 executor 执行
+func NewService(cfg config.KnowledgeConfig, repo Repository, registry *capability.Registry, executor workflow.WorkflowExecutor, client *http.Client, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	s := &Service{
+		repo:      repo,
+		cfg:       cfg,
+		embedder:  hashEmbedder{},
+		embedding: HashEmbedding,
+		executor:  executor,
+		client:    client,
+		logger:    logger,
+		now:       time.Now,
+		index:     make(map[string][]indexedChunk),
+		bindings:  make(map[string][]string),
+	}
+	if cfg.Embedding != "" && registry != nil {
+		s.embedder = &capabilityEmbedder{registry: registry, id: cfg.Embedding, config: cfg.EmbeddingConfig}
+		s.embedding = cfg.Embedding
+	}
+	return s
+}
+
+// Start 处理上次运行时中断的导入并加载档案绑定；使用任务队列时重新提交导入任务，否则标记为失败
+func (s *Service) Start(ctx context.Context) error {
+	if s.jobs != nil {
+		if err := s.resumeUnfinished(ctx); err != nil {
+			return err
+		}
+	} else if n, err := s.repo.FailUnfinished(ctx, "服务重启，导入中断，请重新导入"); err != nil {
+		return err
+	} else if n > 0 {
+		s.logger.WarnTag("知识库", "%d 个文档的导入因服务重启中断，已标记为失败", n)
+	}
+	bindings, err := s.repo.ListBindings(ctx)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.bindings = bindings
+	s.mu.Unlock()
+	return nil
+}
+
+// resumeUnfinished 为导入未结束的文档提交导入任务，已有未结束任务的文档不会重复提交
+func (s *Service) resumeUnfinished(ctx context.Context) error {
+	bases, err := s.repo.ListBases(ctx)
+	if err != nil {
+		return err
+	}
+	resumed := 0
+	for _, b := range bases {
+		docs, err := s.repo.ListDocuments(ctx, b.ID)
+		if err != nil {
+			return err
+		}
+		for _, d := range docs {
+			if d.Status.Done() {
+				continue
+			}
+			if _, err := s.jobs.Enqueue(ctx, JobIngest, ingestPayload{DocumentID: d.ID}, job.EnqueueOptions{Key: d.ID}); err != nil {
+				return err
+			}
+			resumed++
+		}
+	}
+	if resumed > 0 {
+		s.logger.InfoTag("知识库", "%d 个文档的导入因服务重启中断，将由任务队列继续", resumed)
+	}
+	return nil
+}
+
+// Embedding 返回生成向量使用的能力ID
+func (s *Service) Embedding() string {
+	return s.embedding
+}
+
+// CreateBase 创建知识库
+func (s *Service) CreateBase(ctx context.Context, name, description string) (*Base, error) {
+	name = strings.TrimSpace(name)
+	if err := s.checkName(ctx, name, "", "knowledge.create"); err != nil {
+		return nil, err
+	}
+	now := s.now()
+	b := &Base{ID: uuid.New().String(), Name: name, Description: description, CreatedAt: now, UpdatedAt: now}
+	if err := s.repo.SaveBase(ctx, b); err != nil {
+		return nil, err
+	}
+	s.logger.InfoTag("知识库", "已创建知识库 %s(%s)", b.Name, b.ID)
+	return b, nil
+}
+
+// GetBase 获取知识库
+func (s *Service) GetBase(ctx context.Context, id string) (*Base, error) {
+	b, err := s.repo.FindBase(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, errors.Wrap(errors.KindDomain, "knowledge.get", "knowledge base not found", ErrBaseNotFound)
+	}
+	return b, nil
+}
+
+// ListBases 获取全部知识库
+func (s *Service) ListBases(ctx context.Context) ([]*Base, error) {
+	return s.repo.ListBases(ctx)
+}
+
+// UpdateBase 修改知识库名称和说明，nil 表示不修改
+func (s *Service) UpdateBase(ctx context.Context, id string, name, description *string) (*Base, error) {
+	b, err := s.GetBase(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if name != nil {
+		newName := strings.TrimSpace(*name)
+		if err := s.checkName(ctx, newName, b.ID, "knowledge.update"); err != nil {
+			return nil, err
+		}
+		b.Name = newName
+	}
+	if description != nil {
+		b.Description = *description
+	}
+	b.UpdatedAt = s.now()
+	if err := s.repo.UpdateBase(ctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// DeleteBase 删除知识库及其文档，取消进行中的导入并解除档案绑定
+func (s *Service) DeleteBase(ctx context.Context, id string) error {
+	b, err := s.GetBase(ctx, id)
+	if err != nil {
+		return err
+	}
+	docs, err := s.repo.ListDocuments(ctx, id)
+	if err != nil {
+		return err
+	}
+	for _, d := range docs {
+		s.cancelIngestion(d)
+	}
+	if err := s.repo.DeleteBase(ctx, id); err != nil {
+		return err
+	}
+	for _, d := range docs {
+		s.removeOriginal(ctx, d)
+	}
+	if err := os.RemoveAll(s.baseDir(id)); err != nil {
+		s.logger.WarnTag("知识库", "删除知识库 %s 的文件失败: %v", id, err)
+	}
+
+	s.mu.Lock()
+	delete(s.index, id)
+	for profile, ids := range s.bindings {
+		s.bindings[profile] = removeID(ids, id)
+		if len(s.bindings[profile]) == 0 {
+			delete(s.bindings, profile)
+		}
+	}
+	s.mu.Unlock()
+	s.logger.InfoTag("知识库", "已删除知识库 %s(%s)，共 %d 个文档", b.Name, b.ID, len(docs))
+	return nil
+}
+
+func (s *Service) checkName(ctx context.Context, name, selfID, op string) error {
+	if name == "" || len([]rune(name)) > 64 {
+		return errors.New(errors.KindDomain, op, "name is required and must be at most 64 characters")
+	}
+	existing, err := s.repo.FindBaseByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != selfID {
+		return errors.New(errors.KindDomain, op, fmt.Sprintf("knowledge base %s already exists", name))
+	}
+	return nil
+}
+
+// AddFile 保存上传的文件并提交导入任务，name 为空时使用文件名
+func (s *Service) AddFile(ctx context.Context, baseID, filename, name, contentType string, data []byte) (*Document, error) {
+	if _, err := s.GetBase(ctx, baseID); err != nil {
+		return nil, err
+	}
+	filename = path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	format, ok := DetectFormat(filename, contentType)
+	if !ok {
+		return nil, errors.New(errors.KindDomain, "knowledge.add_file", "unsupported file type, expected PDF, Markdown, text or HTML: "+filename)
+	}
+	if len(data) == 0 {
+		return nil, errors.New(errors.KindDomain, "knowledge.add_file", "file is empty")
+	}
+	if int64(len(data)) > s.MaxDocumentSize() {
+		return nil, errors.New(errors.KindDomain, "knowledge.add_file", fmt.Sprintf("file exceeds %d MB", s.cfg.MaxDocumentMB))
+	}
+
+	if name = strings.TrimSpace(name); name == "" {
+		name = filename
+	}
+	doc := s.newDocument(baseID, name, SourceFile, filename)
+	doc.Format = format
+	doc.Size = int64(len(data))
+	if err := s.saveOriginal(ctx, doc, contentType, data); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveDocument(ctx, doc); err != nil {
+		s.removeOriginal(ctx, doc)
+		return nil, err
+	}
+	s.startIngestion(ctx, doc)
+	return doc, nil
+}
+
+// AddURL 提交网页导入任务，网页内容在导入时下载
+func (s *Service) AddURL(ctx context.Context, baseID, rawURL, name string) (*Document, error) {
+	if _, err := s.GetBase(ctx, baseID); err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New(errors.KindDomain, "knowledge.add_url", "url must be an absolute http(s) URL")
+	}
+	if strings.TrimSpace(name) == "" {
+		name = u.Host + u.Path
+	}
+	doc := s.newDocument(baseID, strings.TrimSpace(name), SourceURL, u.String())
+	if err := s.repo.SaveDocument(ctx, doc); err != nil {
+		return nil, err
+	}
+	s.startIngestion(ctx, doc)
+	return doc, nil
+}
+
+func (s *Service) newDocument(baseID, name string, sourceType SourceType, source string) *Document {
+	now := s.now()
+	return &Document{
+		ID:         uuid.New().String(),
+		BaseID:     baseID,
+		Name:       name,
+		SourceType: sourceType,
+		Source:     source,
+		Status:     StatusPending,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// ListDocuments 获取知识库中的文档及其导入进度
+func (s *Service) ListDocuments(ctx context.Context, baseID string) ([]*Document, error) {
+	if _, err := s.GetBase(ctx, baseID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListDocuments(ctx, baseID)
+}
+
+// GetDocument 获取文档及其导入进度
+func (s *Service) GetDocument(ctx context.Context, baseID, id string) (*Document, error) {
+	d, err := s.repo.FindDocument(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil || d.BaseID != baseID {
+		return nil, errors.Wrap(errors.KindDomain, "knowledge.get_document", "document not found", ErrDocumentNotFound)
+	}
+	return d, nil
+}
+
+// DeleteDocument 删除文档及其分块，进行中的导入一并取消
+func (s *Service) DeleteDocument(ctx context.Context, baseID, id string) error {
+	d, err := s.GetDocument(ctx, baseID, id)
+	if err != nil {
+		return err
+	}
+	s.cancelIngestion(d)
+	if err := s.repo.DeleteDocument(ctx, id); err != nil {
+		return err
+	}
+	s.removeOriginal(ctx, d)
+	os.Remove(s.textPath(d))
+	s.invalidate(baseID)
+	s.logger.InfoTag("知识库", "已删除文档 %s(%s)", d.Name, d.ID)
+	return nil
+}
+
+// Reingest 重新导入文档，用于导入失败或更换向量化能力之后；导入进行中时拒绝
+func (s *Service) Reingest(ctx context.Context, baseID, id string) (*Document, error) {
+	d, err := s.GetDocument(ctx, baseID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !d.Status.Done() {
+		return nil, errors.New(errors.KindDomain, "knowledge.reingest", "document is still being ingested")
+	}
+	d.Status = StatusPending
+	d.Progress = 0
+	d.Error = ""
+	d.UpdatedAt = s.now()
+	if err := s.repo.UpdateDocument(ctx, d); err != nil {
+		return nil, err
+	}
+	s.invalidate(baseID)
+	s.startIngestion(ctx, d)
+	return d, nil
+}
+
+// Search 在指定知识库中检索与 query 最相关的分块，topK <= 0 时使用配置值
+func (s *Service) Search(ctx context.Context, baseIDs []string, query string, topK int) ([]Hit, error) {
+	query = strings.TrimSpace(query)
+	if query == "" || len(baseIDs) == 0 {
+		return nil, nil
+	}
+	if topK <= 0 {
+		topK = s.cfg.TopK
+	}
+	if topK > maxTopK {
+		topK = maxTopK
+	}
+
+	vectors, err := s.embedder.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) == 0 {
+		return nil, nil
+	}
+
+	score := cosine
+	if sc, ok := s.embedder.(scorer); ok {
+		score = sc.Score
+	}
+
+	var hits []Hit
+	for _, baseID := range baseIDs {
+		chunks, err := s.baseIndex(ctx, baseID)
+		if err != nil {
+			return nil, err
+		}
+		for _, ic := range chunks {
+			similarity := score(vectors[0], ic.chunk.Vector)
+			if similarity < s.cfg.MinScore {
+				continue
+			}
+			hits = append(hits, Hit{
+				BaseID:       baseID,
+				DocumentID:   ic.chunk.DocumentID,
+				DocumentName: ic.document,
+				ChunkIndex:   ic.chunk.Index,
+				Content:      ic.chunk.Content,
+				Score:        similarity,
+			})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score })
+	if len(hits) > topK {
+		hits = hits[:topK]
+	}
+	return hits, nil
+}
+
+// SearchForDevice 在设备所属档案绑定的知识库中检索，档案没有绑定知识库时返回空
+func (s *Service) SearchForDevice(ctx context.Context, deviceID, query string) ([]Hit, error) {
+	baseIDs := s.BaseIDsFor(deviceID)
+	if len(baseIDs) == 0 {
+		return nil, nil
+	}
+	return s.Search(ctx, baseIDs, query, 0)
+}
+
+// ProfileFor 返回设备所属的知识库档案
+func (s *Service) ProfileFor(deviceID string) string {
+	if profile, ok := s.cfg.DeviceProfiles[deviceID]; ok && profile != "" {
+		return profile
+	}
+	return defaultProfile
+}
+
+// BaseIDsFor 返回设备所属档案绑定的知识库
+func (s *Service) BaseIDsFor(deviceID string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]string(nil), s.bindings[s.ProfileFor(deviceID)]...)
+}
+
+// Bindings 返回全部档案绑定
+func (s *Service) Bindings() map[string][]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result := make(map[string][]string, len(s.bindings))
+	for profile, ids := range s.bindings {
+		result[profile] = append([]string(nil), ids...)
+	}
+	return result
+}
+
+// SetBindings 设置档案绑定的知识库，为空时解除绑定
+func (s *Service) SetBindings(ctx context.Context, profile string, baseIDs []string) ([]string, error) {
+	profile = strings.TrimSpace(profile)
+	if profile == "" {
+		return nil, errors.New(errors.KindDomain, "knowledge.bind", "profile is required")
+	}
+	var ids []string
+	seen := make(map[string]bool)
+	for _, id := range baseIDs {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		if _, err := s.GetBase(ctx, id); err != nil {
+			return nil, err
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if err := s.repo.SetBindings(ctx, profile, ids); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if len(ids) == 0 {
+		delete(s.bindings, profile)
+	} else {
+		s.bindings[profile] = ids
+	}
+	s.mu.Unlock()
+	s.logger.InfoTag("知识库", "档案 %s 绑定的知识库: %v", profile, ids)
+	return ids, nil
+}
+
+// FormatContext 把检索结果整理为带出处编号的参考资料
+func FormatContext(hits []Hit) string {
+	if len(hits) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for i, hit := range hits {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		fmt.Fprintf(&b, "[%d]《%s》\n%s", i+1, hit.DocumentName, hit.Content)
+	}
+	return b.String()
+}
+
+// baseIndex 返回知识库的检索索引，首次使用时从存储加载
+func (s *Service) baseIndex(ctx context.Context, baseID string) ([]indexedChunk, error) {
+	s.mu.RLock()
+	chunks, ok := s.index[baseID]
+	s.mu.RUnlock()
+	if ok {
+		return chunks, nil
+	}
+
+	docs, err := s.repo.ListDocuments(ctx, baseID)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(docs))
+	for _, d := range docs {
+		names[d.ID] = d.Name
+	}
+	stored, err := s.repo.ListBaseChunks(ctx, baseID)
+	if err != nil {
+		return nil, err
+	}
+	chunks = make([]indexedChunk, 0, len(stored))
+	for _, c := range stored {
+		if len(c.Vector) > 0 {
+			chunks = append(chunks, indexedChunk{chunk: c, document: names[c.DocumentID]})
+		}
+	}
+
+	s.mu.Lock()
+	s.index[baseID] = chunks
+	s.mu.Unlock()
+	return chunks, nil
+}
+
+func (s *Service) invalidate(baseID string) {
+	s.mu.Lock()
+	delete(s.index, baseID)
+	s.mu.Unlock()
+}
+
+// MaxDocumentSize 返回单个文档的大小上限（字节）
+func (s *Service) MaxDocumentSize() int64 {
+	mb := s.cfg.MaxDocumentMB
+	if mb <= 0 {
+		mb = 20
+	}
+	return int64(mb) << 20
+}
+
+func (s *Service) baseDir(baseID string) string {
+	return filepath.Join(s.cfg.Dir, baseID)
+}
+
+// originalPath 上传文件的保存路径，保留扩展名便于排查
+func (s *Service) originalPath(d *Document) string {
+	return filepath.Join(s.baseDir(d.BaseID), d.ID+strings.ToLower(path.Ext(d.Source)))
+}
+
+// UseObjects 改为把上传的文件保存到对象存储，已保存在 Dir 下的文件仍从原路径读取
+func (s *Service) UseObjects(objects *objectstore.Service) {
+	s.objects = objects
+}
+
+// saveOriginal 保存上传的原始文件
+func (s *Service) saveOriginal(ctx context.Context, doc *Document, contentType string, data []byte) error {
+	if s.objects != nil {
+		obj, err := s.objects.PutBytes(ctx, objectstore.NamespaceKnowledge, data, objectstore.PutOptions{Name: doc.Source, ContentType: contentType})
+		if err != nil {
+			return err
+		}
+		doc.ObjectID = obj.ID
+		return nil
+	}
+	if err := os.MkdirAll(s.baseDir(doc.BaseID), 0o755); err != nil {
+		return errors.Wrap(errors.KindStorage, "knowledge.add_file", "failed to create directory", err)
+	}
+	if err := os.WriteFile(s.originalPath(doc), data, 0o644); err != nil {
+		return errors.Wrap(errors.KindStorage, "knowledge.add_file", "failed to save file", err)
+	}
+	return nil
+}
+
+// readOriginal 读取上传的原始文件
+func (s *Service) readOriginal(ctx context.Context, doc *Document) ([]byte, error) {
+	if doc.ObjectID != "" {
+		if s.objects == nil {
+			return nil, fmt.Errorf("文件保存在对象存储中，但对象存储未启用")
+		}
+		return s.objects.Read(ctx, doc.ObjectID)
+	}
+	return os.ReadFile(s.originalPath(doc))
+}
+
+// removeOriginal 删除上传的原始文件，对象存储中的文件释放引用
+func (s *Service) removeOriginal(ctx context.Context, doc *Document) {
+	if doc.ObjectID == "" {
+		os.Remove(s.originalPath(doc))
+		return
+	}
+	if s.objects == nil {
+		return
+	}
+	if err := s.objects.Release(ctx, doc.ObjectID); err != nil {
+		s.logger.WarnTag("知识库", "释放文档 %s 的文件失败: %v", doc.ID, err)
+	}
+}
+
+// textPath 提取出的文本的保存路径，供分块步骤读取
+func (s *Service) textPath(d *Document) string {
+	return filepath.Join(s.baseDir(d.BaseID), d.ID+".txt.extracted")
+}
+
+func removeID(ids []string, id string) []string {
+	result := ids[:0:0]
+	for _, v := range ids {
+		if v != id {
+			result = append(result, v)
+		}
+	}
+	return result
+}
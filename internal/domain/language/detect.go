@@ -0,0 +1,134 @@
+// Package language 语言自动识别
+//
+// 按每句识别结果的文字判断语言，在设备允许的语言中选择本句的语言，用于切换播报音色和 ASR 语言提示。
+// 识别只看文字：汉字、假名、谚文、西里尔字母直接判断，拉丁字母再按常用词和特殊字母区分英、法、德、西语。
+package language
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	minConfidence = 0.6 // 识别结果的占比低于该值时视为无法判断，沿用上一句的语言
+	minScore      = 2   // 少于 2 个汉字（或 8 个拉丁字母）时视为无法判断，如“嗯”“OK”
+)
+
+// 拉丁字母语言的常用词，按词命中计分
+var latinWords = map[string]map[string]bool{
+	"en": wordSet("the and is are you what to of i it my please how can play turn on off this that with for do"),
+	"fr": wordSet("le la les et est je tu vous une des du que pas c'est bonjour merci pour avec quoi comment"),
+	"de": wordSet("der die das und ist ich du nicht ein eine bitte wie was mit für auf den dem guten danke"),
+	"es": wordSet("el los las y es yo tú que por favor una cómo qué hola gracias con para del está"),
+}
+
+// 拉丁字母语言的特殊字母
+var latinLetters = map[string]string{
+	"fr": "çàèêëîïôœùûÿ",
+	"de": "äöüß",
+	"es": "ñ¿¡áíóú",
+}
+
+func wordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
+
+// Detect 判断文本的语言，返回语言代码和主要文字的占比；文字太少无法判断时返回空
+// 汉字按字计、拉丁字母按每 4 个字母计一次，使“帮我打开YouTube”判断为中文
+func Detect(text string) (string, float64) {
+	var han, kana, hangul, cyrillic, latin float64
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+	latin /= 4
+
+	scores := map[string]float64{"ko": hangul, "ru": cyrillic, "latin": latin}
+	if kana > 0 {
+		scores["ja"] = kana + han
+	} else {
+		scores["zh"] = han
+	}
+	var best string
+	var top, total float64
+	for code, score := range scores {
+		total += score
+		if score > top || (score == top && code < best) {
+			best, top = code, score
+		}
+	}
+	if top < minScore {
+		return "", 0
+	}
+	if best == "latin" {
+		best = detectLatin(text)
+	}
+	return best, top / total
+}
+
+// detectLatin 区分拉丁字母语言，没有线索时判断为英语
+func detectLatin(text string) string {
+	lower := strings.ToLower(text)
+	scores := make(map[string]int)
+	for code, letters := range latinLetters {
+		for _, r := range letters {
+			scores[code] += strings.Count(lower, string(r))
+		}
+	}
+	words := strings.FieldsFunc(lower, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	for _, w := range words {
+		for code, set := range latinWords {
+			if set[w] {
+				scores[code] += 2
+			}
+		}
+	}
+	best, top := "en", scores["en"]
+	for _, code := range []string{"fr", "de", "es"} {
+		if scores[code] > top {
+			best, top = code, scores[code]
+		}
+	}
+	return best
+}
+
+// Choose 在允许的语言中选择本句的语言：识别结果可信且在允许范围内时使用识别结果，
+// 否则沿用上一句的语言，上一句的语言也不在允许范围内时使用第一个允许的语言
+func Choose(text string, allowed []string, previous string) string {
+	detected, confidence := Detect(text)
+	if detected != "" && confidence >= minConfidence && contains(allowed, detected) {
+		return detected
+	}
+	if contains(allowed, previous) {
+		return previous
+	}
+	if len(allowed) > 0 {
+		return allowed[0]
+	}
+	return detected
+}
+
+func contains(list []string, code string) bool {
+	for _, c := range list {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
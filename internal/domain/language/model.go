@@ -0,0 +1,10 @@
+package language
+
+import "time"
+
+// DeviceLanguages 设备单独设置的允许语言
+type DeviceLanguages struct {
+	DeviceID  string    `json:"device_id"`
+	Languages []string  `json:"languages"` // 第一个为设备的主要语言
+	UpdatedAt time.Time `json:"updated_at"`
+}
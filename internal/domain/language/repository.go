@@ -0,0 +1,17 @@
+package language
+
+import (
+	"context"
+)
+
+// Repository 设备允许语言仓库接口
+type Repository interface {
+	// Find 查找设备的允许语言，未设置时返回 nil
+	Find(ctx context.Context, deviceID string) (*DeviceLanguages, error)
+
+	// Save 保存或覆盖设备的允许语言
+	Save(ctx context.Context, d *DeviceLanguages) error
+
+	// Delete 删除设备的允许语言，之后使用配置的默认值
+	Delete(ctx context.Context, deviceID string) error
+}
@@ -0,0 +1,152 @@
+package language
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/translation"
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+// maxLanguages 单台设备允许语言的上限
+const maxLanguages = 8
+
+// fallbackLanguage 配置的默认允许语言无效时使用
+const fallbackLanguage = "zh"
+
+// Service 语言自动识别服务
+type Service struct {
+	cfg     config.LanguageConfig
+	allowed []string // 规范化后的默认允许语言
+	repo    Repository
+	logger  *logging.Logger
+	now     func() time.Time
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局语言服务，供连接处理器使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局语言服务，未初始化时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建语言服务，配置中无法识别的语言代码记录日志后忽略
+func NewService(cfg config.LanguageConfig, repo Repository, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	allowed, err := normalize(cfg.Allowed)
+	if err != nil || len(allowed) == 0 {
+		if err != nil {
+			logger.WarnTag("语言", "Language.Allowed 无效，使用 %s: %v", fallbackLanguage, err)
+		}
+		allowed = []string{fallbackLanguage}
+	}
+	return &Service{
+		cfg:     cfg,
+		allowed: allowed,
+		repo:    repo,
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+// Enabled 是否启用语言自动识别
+func (s *Service) Enabled() bool {
+	return s.cfg.Enabled
+}
+
+// DefaultAllowed 未单独设置的设备使用的允许语言
+func (s *Service) DefaultAllowed() []string {
+	return append([]string(nil), s.allowed...)
+}
+
+// Voice 返回语言的播报音色，未配置时返回空
+func (s *Service) Voice(code string) string {
+	return s.cfg.Voices[code]
+}
+
+// Get 查询设备的允许语言，custom 表示设备单独设置过
+func (s *Service) Get(ctx context.Context, deviceID string) (langs []string, custom bool, err error) {
+	d, err := s.repo.Find(ctx, deviceID)
+	if err != nil {
+		return nil, false, err
+	}
+	if d == nil || len(d.Languages) == 0 {
+		return s.DefaultAllowed(), false, nil
+	}
+	return d.Languages, true, nil
+}
+
+// AllowedFor 返回设备的允许语言，查询失败时记录日志并使用默认值，供连接建立时使用
+func (s *Service) AllowedFor(ctx context.Context, deviceID string) []string {
+	langs, _, err := s.Get(ctx, deviceID)
+	if err != nil {
+		s.logger.WarnTag("语言", "查询设备 %s 的允许语言失败，使用默认值: %v", deviceID, err)
+		return s.DefaultAllowed()
+	}
+	return langs
+}
+
+// Set 设置设备的允许语言，languages 可以是语言代码或中文叫法，第一个为主要语言
+func (s *Service) Set(ctx context.Context, deviceID string, languages []string) (*DeviceLanguages, error) {
+	if strings.TrimSpace(deviceID) == "" {
+		return nil, errors.New(errors.KindDomain, "language.set", "device_id is required")
+	}
+	codes, err := normalize(languages)
+	if err != nil {
+		return nil, err
+	}
+	if len(codes) == 0 {
+		return nil, errors.New(errors.KindDomain, "language.set", "at least one language is required")
+	}
+	if len(codes) > maxLanguages {
+		return nil, errors.New(errors.KindDomain, "language.set", "too many languages")
+	}
+	d := &DeviceLanguages{
+		DeviceID:  deviceID,
+		Languages: codes,
+		UpdatedAt: s.now(),
+	}
+	if err := s.repo.Save(ctx, d); err != nil {
+		return nil, err
+	}
+	s.logger.InfoTag("语言", "设备 %s 的允许语言已设置为 %s", deviceID, strings.Join(codes, ","))
+	return d, nil
+}
+
+// Reset 删除设备单独设置的允许语言，之后使用默认值
+func (s *Service) Reset(ctx context.Context, deviceID string) error {
+	return s.repo.Delete(ctx, deviceID)
+}
+
+// normalize 把语言代码或中文叫法转为支持的语言代码并去重，保持顺序
+func normalize(languages []string) ([]string, error) {
+	codes := make([]string, 0, len(languages))
+	for _, name := range languages {
+		lang, ok := translation.Lookup(name)
+		if !ok {
+			return nil, errors.New(errors.KindDomain, "language.normalize", "unsupported language: "+name)
+		}
+		if !contains(codes, lang.Code) {
+			codes = append(codes, lang.Code)
+		}
+	}
+	return codes, nil
+}
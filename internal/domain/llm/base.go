@@ -7,6 +7,7 @@ import (
 
 	"xiaozhi-server-go/internal/domain/llm/inter"
 	"xiaozhi-server-go/internal/domain/providers/llm"
+	"xiaozhi-server-go/internal/plugin/capability"
 )
 
 // Manager LLM管理器 - 基于 Eino 框架
@@ -15,6 +16,7 @@ type Manager struct {
 	llm    interface{} // Eino LLM component
 	config inter.LLMConfig
 	provider inter.LLMProvider // 实际的LLM提供商
+	limiter  capability.Limiter // 调用前申请的限制器，为 nil 时直接调用
 }
 
 // NewManager 创建LLM管理器
@@ -31,6 +33,14 @@ func (m *Manager) SetLLM(llm interface{}) {
 	m.llm = llm
 }
 
+// SetLimiter 设置调用前申请的限制器（能力注册表的优先级队列和配额），
+// 排队优先级取自调用上下文，见 capability.WithPriority
+func (m *Manager) SetLimiter(limiter capability.Limiter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.limiter = limiter
+}
+
 // GetLLM 获取 Eino LLM
 func (m *Manager) GetLLM() interface{} {
 	m.mu.RLock()
@@ -40,24 +50,46 @@ func (m *Manager) GetLLM() interface{} {
 
 // Response 生成回复
 func (m *Manager) Response(ctx context.Context, sessionID string, messages []inter.Message, tools []inter.Tool) (<-chan inter.ResponseChunk, error) {
-	// 创建LLM配置
+	// 创建LLM配置，配置可能在离线模式切换时被更新，先取快照
+	config := m.GetConfig()
 	llmConfig := &llm.Config{
-		Type:        m.config.Provider,
-		ModelName:   m.config.Model,
-		BaseURL:     m.config.BaseURL,
-		APIKey:      m.config.APIKey,
-		Temperature: float64(m.config.Temperature),
-		MaxTokens:   m.config.MaxTokens,
+		Type:        config.Provider,
+		ModelName:   config.Model,
+		BaseURL:     config.BaseURL,
+		APIKey:      config.APIKey,
+		Temperature: float64(config.Temperature),
+		MaxTokens:   config.MaxTokens,
+	}
+
+	// 上下文中有追踪收集器时记录本次调用及提供者返回的请求ID
+	ctx, finish := capability.StartTrace(ctx, "llm", config.Provider)
+
+	// 与能力执行共用限制器，排队等待或超出配额时不调用提供商
+	release := func(capability.Usage) {}
+	m.mu.RLock()
+	limiter := m.limiter
+	m.mu.RUnlock()
+	if limiter != nil {
+		r, err := limiter.Acquire(ctx, "llm:"+config.Provider, capability.Definition{ID: config.Provider, Type: capability.TypeLLM})
+		if err != nil {
+			finish(err)
+			return nil, fmt.Errorf("LLM request rejected: %w", err)
+		}
+		release = r
 	}
 
 	// 创建LLM提供商
-	provider, err := llm.Create(m.config.Provider, llmConfig)
+	provider, err := llm.Create(config.Provider, llmConfig)
 	if err != nil {
+		release(capability.Usage{})
+		finish(err)
 		return nil, fmt.Errorf("failed to create LLM provider: %w", err)
 	}
 
 	// 初始化提供商
 	if err := provider.Initialize(); err != nil {
+		release(capability.Usage{})
+		finish(err)
 		return nil, fmt.Errorf("failed to initialize LLM provider: %w", err)
 	}
 
@@ -104,6 +136,8 @@ func (m *Manager) Response(ctx context.Context, sessionID string, messages []int
 	// 调用提供商的ResponseWithFunctions方法
 	responseChan, err := provider.ResponseWithFunctions(ctx, sessionID, coreMessages, coreTools)
 	if err != nil {
+		release(capability.Usage{})
+		finish(err)
 		return nil, fmt.Errorf("failed to get LLM response: %w", err)
 	}
 
@@ -114,8 +148,18 @@ func (m *Manager) Response(ctx context.Context, sessionID string, messages []int
 	go func() {
 		defer close(outChan)
 		defer provider.Cleanup()
+		var streamErr error
+		var tokens int64
+		defer func() { finish(streamErr) }()
+		defer func() { release(capability.Usage{Tokens: tokens}) }()
 
 		for response := range responseChan {
+			if response.Error != nil && streamErr == nil {
+				streamErr = response.Error
+			}
+			if response.Usage != nil && response.Usage.TotalTokens > 0 {
+				tokens = int64(response.Usage.TotalTokens)
+			}
 			chunk := inter.ResponseChunk{
 				Content: response.Content,
 				IsDone:  response.IsDone,
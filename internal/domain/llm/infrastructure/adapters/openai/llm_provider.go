@@ -1,6 +1,7 @@
 package openai
 
 import (
+	"xiaozhi-server-go/internal/platform/httpclient"
 	"xiaozhi-server-go/internal/platform/logging"
 	"context"
 	"fmt"
@@ -160,6 +161,7 @@ func (p *OpenAILLMProvider) Initialize() error {
 	if p.baseURL != "" {
 		clientConfig.BaseURL = p.baseURL
 	}
+	clientConfig.HTTPClient = httpclient.Default().Client("openai")
 
 	p.client = openai.NewClientWithConfig(clientConfig)
 	p.isInitialized = true
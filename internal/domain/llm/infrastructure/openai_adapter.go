@@ -9,6 +9,7 @@ import (
 	"xiaozhi-server-go/internal/domain/llm/aggregate"
 	"xiaozhi-server-go/internal/domain/llm/repository"
 	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/httpclient"
 )
 
 type openaiAdapter struct {
@@ -20,6 +21,7 @@ func NewOpenAIAdapter(apiKey, baseURL string) repository.LLMRepository {
 	if baseURL != "" {
 		config.BaseURL = baseURL
 	}
+	config.HTTPClient = httpclient.Default().Client("openai")
 
 	return &openaiAdapter{
 		client: openai.NewClientWithConfig(config),
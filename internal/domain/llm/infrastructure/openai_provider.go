@@ -11,6 +11,7 @@ import (
 	"xiaozhi-server-go/internal/domain/llm/repository"
 	"xiaozhi-server-go/internal/platform/config"
 	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/httpclient"
 )
 
 type OpenAIProvider struct {
@@ -24,6 +25,7 @@ func NewOpenAIProvider(id string, cfg config.LLMConfig) provider.Provider {
 	if cfg.BaseURL != "" {
 		openaiConfig.BaseURL = cfg.BaseURL
 	}
+	openaiConfig.HTTPClient = httpclient.Default().Client("openai")
 
 	return &OpenAIProvider{
 		id:     id,
@@ -2,12 +2,22 @@ package mcp
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
+	"xiaozhi-server-go/internal/domain/agent"
 	"xiaozhi-server-go/internal/domain/llm"
+	"xiaozhi-server-go/internal/domain/media"
+	"xiaozhi-server-go/internal/domain/routine"
+	"xiaozhi-server-go/internal/domain/skills"
+	"xiaozhi-server-go/internal/domain/smarthome"
+	"xiaozhi-server-go/internal/domain/toolpolicy"
+	"xiaozhi-server-go/internal/domain/websearch"
 	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
 
 	"github.com/sashabaranov/go-openai"
 )
@@ -67,9 +77,53 @@ func (c *LocalClient) RegisterTools() {
 		} else if localFunc.Name == "play_music" && localFunc.Enabled {
 			c.AddToolPlayMusic()
 			c.logger.InfoTag("MCP", "音乐播放工具已注册")
+		} else if localFunc.Name == "reminder" && localFunc.Enabled {
+			c.AddToolReminder()
+			c.logger.InfoTag("MCP", "提醒工具已注册")
 		} else if localFunc.Name == "switch_agent" && localFunc.Enabled {
 			c.AddToolSwitchAgent()
 			c.logger.InfoTag("MCP", "智能体切换工具已注册")
+		} else if localFunc.Name == "call_agent" && localFunc.Enabled {
+			c.AddToolCallAgent()
+			c.logger.InfoTag("MCP", "智能体委托工具已注册")
+		} else if localFunc.Name == "web_search" && localFunc.Enabled {
+			// 联网搜索需要配置搜索后端，未启用时不向LLM暴露该工具
+			if c.cfg.WebSearch.Enabled {
+				c.AddToolWebSearch()
+				c.logger.InfoTag("MCP", "联网搜索工具已注册")
+			}
+		} else if localFunc.Name == "weather" && localFunc.Enabled {
+			if c.cfg.Skills.Enabled {
+				c.AddToolWeather()
+				c.logger.InfoTag("MCP", "天气工具已注册")
+			}
+		} else if localFunc.Name == "news" && localFunc.Enabled {
+			// 没有配置新闻源或日历时不向LLM暴露对应工具
+			if c.cfg.Skills.Enabled && len(c.cfg.Skills.News.Feeds) > 0 {
+				c.AddToolNews()
+				c.logger.InfoTag("MCP", "新闻工具已注册")
+			}
+		} else if localFunc.Name == "calendar" && localFunc.Enabled {
+			calendar := c.cfg.Skills.Calendar
+			if c.cfg.Skills.Enabled && (len(calendar.Config) > 0 || len(calendar.DeviceConfigs) > 0) {
+				c.AddToolCalendar()
+				c.logger.InfoTag("MCP", "日程工具已注册")
+			}
+		} else if localFunc.Name == "media_control" && localFunc.Enabled {
+			if c.cfg.Media.Enabled {
+				c.AddToolMediaControl()
+				c.logger.InfoTag("MCP", "播放控制工具已注册")
+			}
+		} else if localFunc.Name == "smart_home" && localFunc.Enabled {
+			if c.cfg.SmartHome.Enabled {
+				c.AddToolSmartHome()
+				c.logger.InfoTag("MCP", "智能家居工具已注册")
+			}
+		} else if localFunc.Name == "routines" && localFunc.Enabled {
+			if c.cfg.Routines.Enabled {
+				c.AddToolRoutines()
+				c.logger.InfoTag("MCP", "例程工具已注册")
+			}
 		} else {
 			if localFunc.Enabled {
 				c.logger.WarnTag("MCP", "未知功能名称: %s", localFunc.Name)
@@ -348,6 +402,15 @@ func (c *LocalClient) AddToolPlayMusic() error {
 		InputSchema,
 		func(ctx context.Context, args map[string]any) (interface{}, error) {
 			song_name := args["song_name"].(string)
+			// 启用媒体播放时经设备的音频通道流式播放，支持曲库、电台和播放列表
+			if svc := media.Default(); svc != nil {
+				subject, _ := toolpolicy.SubjectFrom(ctx)
+				track, err := svc.Play(ctx, subject.DeviceID, song_name, "")
+				if err != nil {
+					return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: c.mediaError("play_music", err)}, nil
+				}
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: media.PlayReply(track)}, nil
+			}
 			res := llm.ActionResponse{
 				Action: llm.ActionTypeCallHandler, // 动作类型
 				Result: llm.ActionResponseCall{
@@ -404,4 +467,647 @@ func (c *LocalClient) AddToolSwitchAgent() error {
 		})
 
 	return nil
-}
\ No newline at end of file
+}
+
+func (c *LocalClient) AddToolReminder() error {
+	InputSchema := ToolInputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"kind": map[string]any{
+				"type":        "string",
+				"enum":        []string{"timer", "alarm", "reminder"},
+				"description": "类型：timer 倒计时，alarm 闹钟，reminder 提醒事项",
+			},
+			"delay_seconds": map[string]any{
+				"type":        "number",
+				"description": "多少秒后触发，用于'10分钟后'这类相对时间",
+			},
+			"time": map[string]any{
+				"type":        "string",
+				"description": "触发时刻，格式 HH:MM 或 YYYY-MM-DD HH:MM，用于'明天早上7点'这类绝对时间",
+			},
+			"message": map[string]any{
+				"type":        "string",
+				"description": "到时需要提醒的内容，没有则为空",
+			},
+			"recurrence": map[string]any{
+				"type":        "string",
+				"enum":        []string{"", "daily", "weekdays", "weekly"},
+				"description": "重复规则：每天 daily、工作日 weekdays、每周 weekly，不重复为空",
+			},
+		},
+		Required: []string{"kind"},
+	}
+
+	c.AddTool("set_reminder",
+		"当用户要求设置计时器、闹钟或提醒时调用，如'10分钟后提醒我关火'、'明天早上7点叫我起床'，必须提供 delay_seconds 或 time 其中之一",
+		InputSchema,
+		func(ctx context.Context, args map[string]any) (interface{}, error) {
+			if _, hasDelay := args["delay_seconds"]; !hasDelay {
+				if _, hasTime := args["time"]; !hasTime {
+					return llm.ActionResponse{
+						Action: llm.ActionTypeReqLLM,
+						Result: "设置提醒需要提供相对时间 delay_seconds 或具体时刻 time",
+					}, nil
+				}
+			}
+			res := llm.ActionResponse{
+				Action: llm.ActionTypeCallHandler,
+				Result: llm.ActionResponseCall{
+					FuncName: "mcp_handler_set_reminder",
+					Args:     args,
+				},
+			}
+			return res, nil
+		})
+
+	return nil
+}
+
+func (c *LocalClient) AddToolCallAgent() error {
+	InputSchema := ToolInputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"agent": map[string]any{
+				"type":        "string",
+				"description": "智能体名称",
+			},
+			"task": map[string]any{
+				"type":        "string",
+				"description": "交给智能体的任务，需包含完成任务所需的全部信息",
+			},
+		},
+		Required: []string{"agent", "task"},
+	}
+
+	c.AddTool("call_agent",
+		"把需要专门处理的任务交给已定义的智能体完成并取回结果，智能体名称不确定时可以先不带 agent 调用获取可用的智能体列表",
+		InputSchema,
+		func(ctx context.Context, args map[string]any) (interface{}, error) {
+			svc := agent.Default()
+			if svc == nil {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "没有可用的智能体"}, nil
+			}
+			name, _ := args["agent"].(string)
+			task, _ := args["task"].(string)
+			if name == "" {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: c.describeAgents(ctx, svc)}, nil
+			}
+
+			result, err := svc.Run(ctx, name, task)
+			if err != nil {
+				c.logger.WarnTag("MCP", "call_agent: 运行智能体 %s 失败: %v", name, err)
+				text := fmt.Sprintf("智能体 %s 执行失败: %v", name, err)
+				if stderrors.Is(err, agent.ErrNotFound) {
+					text = fmt.Sprintf("智能体 %s 不存在。%s", name, c.describeAgents(ctx, svc))
+				}
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: text}, nil
+			}
+			return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: result.Reply}, nil
+		})
+
+	return nil
+}
+
+// describeAgents 列出可用的智能体供LLM选择
+func (c *LocalClient) describeAgents(ctx context.Context, svc *agent.Service) string {
+	agents, err := svc.List(ctx)
+	if err != nil || len(agents) == 0 {
+		return "没有可用的智能体"
+	}
+	var b strings.Builder
+	b.WriteString("可用的智能体：")
+	for _, a := range agents {
+		fmt.Fprintf(&b, "\n- %s：%s", a.Name, a.Description)
+	}
+	return b.String()
+}
+
+func (c *LocalClient) AddToolWebSearch() error {
+	InputSchema := ToolInputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"query": map[string]any{
+				"type":        "string",
+				"description": "搜索关键词，应简洁并包含时间、地点等限定信息",
+			},
+		},
+		Required: []string{"query"},
+	}
+
+	c.AddTool("web_search",
+		"联网搜索实时信息，用于回答新闻、天气、赛事、价格等需要最新资料或自身知识无法确定的问题",
+		InputSchema,
+		func(ctx context.Context, args map[string]any) (interface{}, error) {
+			svc := websearch.Default()
+			if svc == nil {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "联网搜索未启用，请告诉用户暂时无法联网查询"}, nil
+			}
+			query, _ := args["query"].(string)
+			hits, err := svc.Search(ctx, query)
+			if err != nil {
+				c.logger.WarnTag("MCP", "web_search: 搜索 %q 失败: %v", query, err)
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "联网搜索失败，请告诉用户暂时无法查询并稍后再试"}, nil
+			}
+			return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: websearch.FormatResults(query, hits)}, nil
+		})
+
+	return nil
+}
+
+func (c *LocalClient) AddToolWeather() error {
+	InputSchema := ToolInputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"location": map[string]any{
+				"type":        "string",
+				"description": "城市或地区名称，用户没有说地点时留空，使用设备所在位置",
+			},
+			"day": map[string]any{
+				"type":        "integer",
+				"description": "相对今天的天数，今天为 0，明天为 1，最多 6",
+			},
+		},
+	}
+
+	c.AddTool("get_weather",
+		"查询天气预报，包括天气状况、气温和降水概率",
+		InputSchema,
+		func(ctx context.Context, args map[string]any) (interface{}, error) {
+			svc := skills.Default()
+			if svc == nil {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "天气查询未启用"}, nil
+			}
+			location, _ := args["location"].(string)
+			day := 0
+			if d, ok := args["day"].(float64); ok {
+				day = int(d)
+			}
+			subject, _ := toolpolicy.SubjectFrom(ctx)
+			reply, err := svc.Weather(ctx, subject.DeviceID, location, day)
+			if err != nil {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: c.skillError("get_weather", err)}, nil
+			}
+			return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: reply}, nil
+		})
+
+	return nil
+}
+
+func (c *LocalClient) AddToolNews() error {
+	InputSchema := ToolInputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"category": map[string]any{
+				"type":        "string",
+				"description": "新闻分类，如科技、体育，用户没有指定时留空",
+			},
+		},
+	}
+
+	c.AddTool("get_news",
+		"获取最新的新闻标题",
+		InputSchema,
+		func(ctx context.Context, args map[string]any) (interface{}, error) {
+			svc := skills.Default()
+			if svc == nil {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "新闻播报未启用"}, nil
+			}
+			category, _ := args["category"].(string)
+			reply, err := svc.News(ctx, svc.NewsCategory(category))
+			if err != nil {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: c.skillError("get_news", err)}, nil
+			}
+			return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: reply}, nil
+		})
+
+	return nil
+}
+
+func (c *LocalClient) AddToolCalendar() error {
+	InputSchema := ToolInputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"day": map[string]any{
+				"type":        "integer",
+				"description": "相对今天的天数，今天为 0，明天为 1",
+			},
+		},
+	}
+
+	c.AddTool("get_calendar",
+		"查询用户日历中某一天的日程安排",
+		InputSchema,
+		func(ctx context.Context, args map[string]any) (interface{}, error) {
+			svc := skills.Default()
+			if svc == nil {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "日程查询未启用"}, nil
+			}
+			day := 0
+			if d, ok := args["day"].(float64); ok {
+				day = int(d)
+			}
+			subject, _ := toolpolicy.SubjectFrom(ctx)
+			reply, err := svc.Agenda(ctx, subject.DeviceID, day)
+			if err != nil {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: c.skillError("get_calendar", err)}, nil
+			}
+			return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: reply}, nil
+		})
+
+	return nil
+}
+
+func (c *LocalClient) AddToolMediaControl() error {
+	InputSchema := ToolInputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"action": map[string]any{
+				"type":        "string",
+				"enum":        []string{"pause", "resume", "stop", "next", "volume_up", "volume_down", "volume"},
+				"description": "暂停、继续、停止、下一首、调大音量、调小音量，或设置为 volume 指定的音量",
+			},
+			"volume": map[string]any{
+				"type":        "integer",
+				"description": "播放音量 0-100，仅 action 为 volume 时使用",
+			},
+		},
+		Required: []string{"action"},
+	}
+
+	c.AddTool("media_control",
+		"控制设备上正在播放的音乐或电台",
+		InputSchema,
+		func(ctx context.Context, args map[string]any) (interface{}, error) {
+			svc := media.Default()
+			if svc == nil {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "媒体播放未启用"}, nil
+			}
+			action, _ := args["action"].(string)
+			subject, _ := toolpolicy.SubjectFrom(ctx)
+
+			if action == "volume" {
+				volume, _ := args["volume"].(float64)
+				state, err := svc.SetVolume(subject.DeviceID, int(volume))
+				if err != nil {
+					return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: c.mediaError("media_control", err)}, nil
+				}
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: fmt.Sprintf("音量已调到%d", state.Volume)}, nil
+			}
+			state, err := svc.Control(subject.DeviceID, media.Action(action))
+			if err != nil {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: c.mediaError("media_control", err)}, nil
+			}
+			return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: media.ControlReply(media.Action(action), state)}, nil
+		})
+
+	return nil
+}
+
+func (c *LocalClient) AddToolSmartHome() error {
+	c.AddTool("smart_home_list",
+		"列出可控制的智能家居设备及其当前状态",
+		ToolInputSchema{
+			Type: "object",
+			Properties: map[string]any{
+				"room": map[string]any{
+					"type":        "string",
+					"description": "只列出该房间的设备，用户没有指定时留空",
+				},
+			},
+		},
+		func(ctx context.Context, args map[string]any) (interface{}, error) {
+			svc := smarthome.Default()
+			if svc == nil {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "智能家居未启用"}, nil
+			}
+			room, _ := args["room"].(string)
+			var lines []string
+			for _, entity := range svc.Entities() {
+				if room != "" && !strings.Contains(entity.Room, room) {
+					continue
+				}
+				lines = append(lines, smarthome.Describe(entity))
+			}
+			if len(lines) == 0 {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "没有找到智能家居设备"}, nil
+			}
+			return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: strings.Join(lines, "\n")}, nil
+		})
+
+	c.AddTool(smarthome.ToolName,
+		"控制智能家居设备：开关灯和插座、调亮度、开合窗帘、调空调温度、锁门",
+		ToolInputSchema{
+			Type: "object",
+			Properties: map[string]any{
+				"entity": map[string]any{
+					"type":        "string",
+					"description": "设备名称（可带房间，如“客厅灯”），也可以是 smart_home_list 返回的设备；控制多个设备时以“所有”开头，如“所有灯”",
+				},
+				"action": map[string]any{
+					"type":        "string",
+					"enum":        []string{"turn_on", "turn_off", "toggle", "set_brightness", "set_position", "set_temperature", "open", "close", "lock", "unlock"},
+					"description": "打开、关闭、切换、设置亮度、设置窗帘开合度、设置温度、拉开、合上、上锁、解锁",
+				},
+				"value": map[string]any{
+					"type":        "number",
+					"description": "亮度或开合度为 0-100 的百分比，温度为摄氏度",
+				},
+			},
+			Required: []string{"entity", "action"},
+		},
+		func(ctx context.Context, args map[string]any) (interface{}, error) {
+			svc := smarthome.Default()
+			if svc == nil {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "智能家居未启用"}, nil
+			}
+			target, _ := args["entity"].(string)
+			action := smarthome.Action(fmt.Sprint(args["action"]))
+			value, _ := args["value"].(float64)
+			if !action.Valid() {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "不支持的操作: " + string(action)}, nil
+			}
+
+			matches, all := svc.ResolveTarget(ctx, target)
+			if len(matches) == 0 {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "没有找到这个设备，可以先调用 smart_home_list 查看设备列表"}, nil
+			}
+			if len(matches) > 1 && !all {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: smarthome.AmbiguousReply(matches)}, nil
+			}
+			var results []string
+			for _, entity := range matches {
+				controlled, err := svc.Control(ctx, entity.ID, action, value)
+				if err != nil {
+					if !stderrors.Is(err, smarthome.ErrUnsupported) && !stderrors.Is(err, smarthome.ErrUnavailable) {
+						c.logger.WarnTag("MCP", "%s: 控制 %s 失败: %v", smarthome.ToolName, entity.ID, err)
+					}
+					results = append(results, smarthome.ErrorReply(entity, err))
+					continue
+				}
+				results = append(results, "已完成，"+smarthome.Describe(controlled))
+				if len(matches) == 1 {
+					subject, _ := toolpolicy.SubjectFrom(ctx)
+					svc.RecordControl(subject.SessionID, target, matches, controlled, action, value)
+				}
+			}
+			return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: strings.Join(results, "\n")}, nil
+		})
+
+	c.AddTool("smart_home_alias",
+		"记住或忘记用户对智能家居设备的叫法。用户说“以后说台灯就是卧室的落地灯”，或纠正设备（如“不是这个，是另一个灯”）并改为控制正确的设备后调用 remember，把用户原来的叫法记到正确的设备上",
+		ToolInputSchema{
+			Type: "object",
+			Properties: map[string]any{
+				"operation": map[string]any{
+					"type":        "string",
+					"enum":        []string{"remember", "forget", "list"},
+					"description": "记住别名、忘记别名、列出已记住的别名",
+				},
+				"alias": map[string]any{
+					"type":        "string",
+					"description": "用户对设备的叫法，如“台灯”，list 时留空",
+				},
+				"entity": map[string]any{
+					"type":        "string",
+					"description": "别名指向的设备，使用 smart_home_list 返回的设备名称，只在 remember 时需要",
+				},
+			},
+			Required: []string{"operation"},
+		},
+		func(ctx context.Context, args map[string]any) (interface{}, error) {
+			svc := smarthome.Default()
+			if svc == nil {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "智能家居未启用"}, nil
+			}
+			alias, _ := args["alias"].(string)
+			switch args["operation"] {
+			case "list":
+				aliases, err := svc.Aliases(ctx)
+				if err != nil {
+					return nil, err
+				}
+				if len(aliases) == 0 {
+					return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "还没有记住任何设备别名"}, nil
+				}
+				names := make(map[string]string)
+				for _, entity := range svc.Entities() {
+					names[entity.ID] = entity.DisplayName()
+				}
+				lines := make([]string, 0, len(aliases))
+				for _, a := range aliases {
+					lines = append(lines, fmt.Sprintf("“%s”：%s", a.Alias, names[a.EntityID]))
+				}
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: strings.Join(lines, "\n")}, nil
+			case "forget":
+				if err := svc.DeleteAlias(ctx, alias); err != nil {
+					if stderrors.Is(err, smarthome.ErrAliasNotFound) {
+						return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "没有记过这个叫法"}, nil
+					}
+					return nil, err
+				}
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: fmt.Sprintf("已忘记“%s”", alias)}, nil
+			case "remember":
+				target, _ := args["entity"].(string)
+				matches, _ := smarthome.Resolve(svc.Entities(), target)
+				if len(matches) == 0 {
+					return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "没有找到这个设备，可以先调用 smart_home_list 查看设备列表"}, nil
+				}
+				if len(matches) > 1 {
+					return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: smarthome.AmbiguousReply(matches)}, nil
+				}
+				if _, err := svc.SetAlias(ctx, alias, matches[0].ID, smarthome.AliasManual); err != nil {
+					return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "记住别名失败: " + err.Error()}, nil
+				}
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: fmt.Sprintf("已记住，以后说“%s”就是%s", alias, matches[0].DisplayName())}, nil
+			default:
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "不支持的操作"}, nil
+			}
+		})
+
+	return nil
+}
+
+func (c *LocalClient) AddToolRoutines() error {
+	c.AddTool("create_routine",
+		"当用户要求创建例程时调用，如'每天早上7点播报天气、日程和新闻'、'我说早安的时候播报天气'，time 和 phrase 至少提供一个",
+		ToolInputSchema{
+			Type: "object",
+			Properties: map[string]any{
+				"name": map[string]any{
+					"type":        "string",
+					"description": "例程名称，如'早间播报'",
+				},
+				"time": map[string]any{
+					"type":        "string",
+					"description": "定时触发的时刻，格式 HH:MM，只用唤醒短语触发时留空",
+				},
+				"repeat": map[string]any{
+					"type":        "string",
+					"enum":        []string{"daily", "weekdays", "weekends", "weekly"},
+					"description": "重复方式：每天 daily、工作日 weekdays、周末 weekends、每周 weekly",
+				},
+				"weekday": map[string]any{
+					"type":        "integer",
+					"description": "repeat 为 weekly 时的星期，0 为周日，1-6 为周一到周六",
+				},
+				"phrase": map[string]any{
+					"type":        "string",
+					"description": "唤醒短语，用户说出这句话时执行例程，如'早安'",
+				},
+				"actions": map[string]any{
+					"type":        "array",
+					"description": "按顺序执行的动作",
+					"items": map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"type": map[string]any{
+								"type":        "string",
+								"enum":        []string{"weather", "news", "calendar", "say"},
+								"description": "天气 weather、新闻 news、今天的日程 calendar、播报一句话 say",
+							},
+							"param": map[string]any{
+								"type":        "string",
+								"description": "weather 为城市（留空使用设备所在城市），news 为新闻分类，say 为要播报的话",
+							},
+						},
+						"required": []string{"type"},
+					},
+				},
+			},
+			Required: []string{"name", "actions"},
+		},
+		func(ctx context.Context, args map[string]any) (interface{}, error) {
+			svc := routine.Default()
+			if svc == nil {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "例程未启用"}, nil
+			}
+			subject, _ := toolpolicy.SubjectFrom(ctx)
+			req := routine.CreateRequest{DeviceID: subject.DeviceID}
+			req.Name, _ = args["name"].(string)
+			req.Phrase, _ = args["phrase"].(string)
+			if clock, _ := args["time"].(string); clock != "" {
+				repeat, _ := args["repeat"].(string)
+				weekday, _ := args["weekday"].(float64)
+				schedule, err := routine.ScheduleFor(clock, routine.Repeat(repeat), int(weekday))
+				if err != nil {
+					return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "时间格式不正确: " + err.Error()}, nil
+				}
+				req.Schedule = schedule
+			}
+			items, _ := args["actions"].([]interface{})
+			for _, item := range items {
+				m, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				actionType, _ := m["type"].(string)
+				param, _ := m["param"].(string)
+				req.Actions = append(req.Actions, routine.Action{Type: routine.ActionType(actionType), Param: param})
+			}
+
+			r, err := svc.Create(ctx, req)
+			if err != nil {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: c.routineError("create_routine", err)}, nil
+			}
+			return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: fmt.Sprintf("已创建例程“%s”：%s", r.Name, r.Describe())}, nil
+		})
+
+	c.AddTool("list_routines",
+		"列出本设备上的例程",
+		ToolInputSchema{Type: "object", Properties: map[string]any{}},
+		func(ctx context.Context, args map[string]any) (interface{}, error) {
+			svc := routine.Default()
+			if svc == nil {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "例程未启用"}, nil
+			}
+			subject, _ := toolpolicy.SubjectFrom(ctx)
+			var lines []string
+			for _, r := range svc.List(ctx, subject.DeviceID) {
+				line := fmt.Sprintf("%s：%s", r.Name, r.Describe())
+				if !r.Enabled {
+					line += "（已停用）"
+				}
+				lines = append(lines, line)
+			}
+			if len(lines) == 0 {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "还没有创建任何例程"}, nil
+			}
+			return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: strings.Join(lines, "\n")}, nil
+		})
+
+	c.AddTool("delete_routine",
+		"删除本设备上的例程",
+		ToolInputSchema{
+			Type: "object",
+			Properties: map[string]any{
+				"name": map[string]any{
+					"type":        "string",
+					"description": "例程名称，可以先调用 list_routines 查看",
+				},
+			},
+			Required: []string{"name"},
+		},
+		func(ctx context.Context, args map[string]any) (interface{}, error) {
+			svc := routine.Default()
+			if svc == nil {
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "例程未启用"}, nil
+			}
+			subject, _ := toolpolicy.SubjectFrom(ctx)
+			name, _ := args["name"].(string)
+			for _, r := range svc.List(ctx, subject.DeviceID) {
+				if r.Name != strings.TrimSpace(name) {
+					continue
+				}
+				if err := svc.Delete(ctx, r.ID); err != nil {
+					return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: c.routineError("delete_routine", err)}, nil
+				}
+				return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: fmt.Sprintf("已删除例程“%s”", r.Name)}, nil
+			}
+			return llm.ActionResponse{Action: llm.ActionTypeReqLLM, Result: "没有找到这个例程，可以先调用 list_routines 查看"}, nil
+		})
+
+	return nil
+}
+
+// routineError 把例程操作失败的原因转换为交给LLM的说明
+func (c *LocalClient) routineError(tool string, err error) string {
+	switch {
+	case stderrors.Is(err, routine.ErrLimitReached):
+		return "例程数量已达上限，请让用户先删除不用的例程"
+	case stderrors.Is(err, routine.ErrPhraseTaken):
+		return "这个唤醒短语已被其他例程使用，请让用户换一个"
+	case errors.IsKind(err, errors.KindDomain):
+		return "例程内容不完整或不正确: " + err.Error()
+	}
+	c.logger.WarnTag("MCP", "%s: 操作例程失败: %v", tool, err)
+	return "操作失败，请告诉用户稍后再试"
+}
+
+// mediaError 把播放失败的原因转换为交给LLM的说明
+func (c *LocalClient) mediaError(tool string, err error) string {
+	switch {
+	case stderrors.Is(err, media.ErrNotFound):
+		return "没有找到用户想听的内容，请让用户换个说法"
+	case stderrors.Is(err, media.ErrNotPlaying):
+		return "当前没有在播放音乐"
+	case stderrors.Is(err, media.ErrDeviceOffline):
+		return "设备不在线，无法播放"
+	}
+	c.logger.WarnTag("MCP", "%s: 播放失败: %v", tool, err)
+	return "播放失败，请告诉用户稍后再试"
+}
+
+// skillError 把技能查询失败的原因转换为交给LLM的说明
+func (c *LocalClient) skillError(tool string, err error) string {
+	switch {
+	case stderrors.Is(err, skills.ErrNoLocation):
+		return "不知道用户所在的城市，请询问用户想查询哪里的天气"
+	case stderrors.Is(err, skills.ErrPlaceNotFound):
+		return "没有找到这个地点，请让用户换个说法"
+	case stderrors.Is(err, skills.ErrNotConfigured):
+		return "该功能还没有配置"
+	}
+	c.logger.WarnTag("MCP", "%s: 查询失败: %v", tool, err)
+	return "查询失败，请告诉用户暂时无法获取并稍后再试"
+}
@@ -0,0 +1,50 @@
+package media
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Command 解析出的播放语音指令
+type Command struct {
+	Action Action
+	Query  string // 点播的内容，仅 ActionPlay 使用，为空时随机播放
+}
+
+var (
+	playPattern = regexp.MustCompile(`^(?:请|帮我|给我)?(?:播放|放一首|放首|放一下|放点|来一首|来首|来点|我想听|我要听)(.*)$`)
+
+	// 控制指令按顺序匹配，“继续播放”“停止播放”需要在点播之前识别
+	controlWords = []struct {
+		action Action
+		words  []string
+	}{
+		{ActionNext, []string{"下一首", "换一首", "切歌", "换首歌"}},
+		{ActionStop, []string{"停止播放", "别放了", "不要放了", "关掉音乐", "关闭音乐", "不听了", "关掉电台", "关闭电台"}},
+		{ActionResume, []string{"继续播放", "接着放", "接着播放", "继续放"}},
+		{ActionVolumeUp, []string{"大声点", "大声一点", "声音大一点", "音量大一点", "调大音量"}},
+		{ActionVolumeDown, []string{"小声点", "小声一点", "声音小一点", "音量小一点", "调小音量"}},
+	}
+)
+
+// ParseCommand 解析播放语音指令，不是指令时 ok 为 false
+func ParseCommand(text string) (Command, bool) {
+	text = normalize(text)
+	if text == "" {
+		return Command{}, false
+	}
+	for _, control := range controlWords {
+		for _, word := range control.words {
+			if strings.Contains(text, word) {
+				return Command{Action: control.action}, true
+			}
+		}
+	}
+	if strings.HasPrefix(text, "暂停") || strings.HasSuffix(text, "暂停") || strings.HasSuffix(text, "暂停一下") {
+		return Command{Action: ActionPause}, true
+	}
+	if m := playPattern.FindStringSubmatch(text); m != nil {
+		return Command{Action: ActionPlay, Query: m[1]}, true
+	}
+	return Command{}, false
+}
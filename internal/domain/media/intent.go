@@ -0,0 +1,81 @@
+package media
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	"xiaozhi-server-go/internal/domain/intent"
+)
+
+// IntentHandler 返回播放和播放控制的确定性意图处理器
+// 没有找到曲目，或设备没有在播放时收到控制指令，处理器放弃并交给LLM处理
+func (s *Service) IntentHandler() intent.Handler {
+	return &mediaHandler{s}
+}
+
+type mediaHandler struct{ s *Service }
+
+func (h *mediaHandler) Intent() string {
+	return intent.IntentMedia
+}
+
+func (h *mediaHandler) Handle(ctx context.Context, req *intent.Request, result *intent.Result) (*intent.Response, error) {
+	cmd, ok := ParseCommand(req.Text)
+	if !ok || req.DeviceID == "" {
+		return &intent.Response{Handled: false}, nil
+	}
+
+	if cmd.Action == ActionPlay {
+		track, err := h.s.Play(ctx, req.DeviceID, cmd.Query, "")
+		if stderrors.Is(err, ErrNotFound) {
+			return &intent.Response{Handled: false}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &intent.Response{Reply: PlayReply(track), Handled: true}, nil
+	}
+
+	state, err := h.s.Control(req.DeviceID, cmd.Action)
+	if stderrors.Is(err, ErrNotPlaying) {
+		return &intent.Response{Handled: false}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &intent.Response{Reply: ControlReply(cmd.Action, state), Handled: true}, nil
+}
+
+// PlayReply 开始播放时的播报
+func PlayReply(track Track) string {
+	switch {
+	case track.Live:
+		return fmt.Sprintf("好的，为你打开%s", track.Title)
+	case track.Artist != "":
+		return fmt.Sprintf("好的，为你播放%s的《%s》", track.Artist, track.Title)
+	default:
+		return fmt.Sprintf("好的，为你播放《%s》", track.Title)
+	}
+}
+
+// ControlReply 播放控制后的播报
+func ControlReply(action Action, state State) string {
+	switch action {
+	case ActionPause:
+		return "已暂停"
+	case ActionResume:
+		return "好的，继续播放"
+	case ActionStop:
+		return "好的，已停止播放"
+	case ActionNext:
+		if state.Track != nil {
+			return fmt.Sprintf("好的，下一首《%s》", state.Track.Title)
+		}
+		return "播放列表已经放完了"
+	case ActionVolumeUp, ActionVolumeDown:
+		return fmt.Sprintf("音量已调到%d", state.Volume)
+	default:
+		return "好的"
+	}
+}
@@ -0,0 +1,85 @@
+package media
+
+import (
+	stderrors "errors"
+	"time"
+)
+
+// 曲目来源
+const (
+	SourceLibrary  = "library"  // 本地曲库
+	SourceRadio    = "radio"    // 配置的网络电台
+	SourceProvider = "provider" // 媒体能力检索的结果
+)
+
+var (
+	// ErrDeviceOffline 设备不在线，无法播放
+	ErrDeviceOffline = stderrors.New("device offline")
+	// ErrNotFound 没有找到匹配的曲目或电台
+	ErrNotFound = stderrors.New("media not found")
+	// ErrNotPlaying 设备当前没有播放或暂停中的音频
+	ErrNotPlaying = stderrors.New("nothing is playing")
+	// ErrUnknownSource 检索时指定了不支持的来源
+	ErrUnknownSource = stderrors.New("unknown media source")
+)
+
+// Track 可播放的曲目或电台
+type Track struct {
+	Title  string
+	Artist string
+	Source string // library/radio/provider
+	URL    string // 本地文件路径或 http(s) 音频流地址（MP3）
+	Live   bool   // 电台等没有结尾的直播流，暂停时断开，继续时重新连接
+}
+
+// Status 设备播放状态
+type Status string
+
+const (
+	StatusIdle    Status = "idle"    // 没有播放
+	StatusLoading Status = "loading" // 等待当前回复播报完成或正在打开音频源
+	StatusPlaying Status = "playing"
+	StatusPaused  Status = "paused"
+)
+
+// Action 播放控制指令
+type Action string
+
+const (
+	ActionPlay       Action = "play"
+	ActionPause      Action = "pause"
+	ActionResume     Action = "resume"
+	ActionStop       Action = "stop"
+	ActionNext       Action = "next"
+	ActionVolumeUp   Action = "volume_up"
+	ActionVolumeDown Action = "volume_down"
+)
+
+// State 设备的播放状态，由连接层的播放器上报，作为设备影子的一部分对外展示
+type State struct {
+	DeviceID  string
+	Status    Status
+	Track     *Track // 当前曲目，空闲时为nil
+	Index     int    // 当前曲目在播放列表中的位置，从0开始
+	QueueSize int    // 播放列表长度
+	Volume    int    // 0-100
+	Position  time.Duration
+	Error     string // 最近一次打开或解码音频失败的原因
+	UpdatedAt time.Time
+}
+
+// Active 是否正在播放或暂停中，空闲时语音控制指令交给LLM处理
+func (s State) Active() bool {
+	return s.Status != StatusIdle && s.Status != ""
+}
+
+// Controller 设备端播放器，由连接层实现
+// 设备不在线时返回 ErrDeviceOffline，没有可控制的播放时返回 ErrNotPlaying
+type Controller interface {
+	Play(deviceID string, tracks []Track, volume int) error
+	Pause(deviceID string) error
+	Resume(deviceID string) error
+	Stop(deviceID string) error
+	Next(deviceID string) error
+	SetVolume(deviceID string, volume int) error
+}
@@ -0,0 +1,243 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/plugin/sdk"
+)
+
+// radioWords 泛指电台的说法，没有说具体电台时播放第一个配置的电台
+var radioWords = []string{"电台", "广播", "收音机", "fm"}
+
+// randomQueries 没有指定曲目的点播，随机播放曲库
+var randomQueries = map[string]bool{
+	"": true, "随机": true, "random": true, "音乐": true, "歌": true, "歌曲": true,
+	"首歌": true, "一首歌": true, "点音乐": true, "点歌": true, "随便": true, "随便一首": true,
+}
+
+// querySuffixes 点播时常见的多余说法，如“周杰伦的歌”
+var querySuffixes = []string{"的歌曲", "的音乐", "的曲子", "的歌", "这首歌", "吧"}
+
+// Search 按来源检索可播放的曲目，source 为空时依次检索电台、本地曲库和媒体能力
+func (s *Service) Search(ctx context.Context, query, source string) ([]Track, error) {
+	query = strings.TrimSpace(query)
+	switch source {
+	case SourceRadio:
+		return s.searchRadio(query, true), nil
+	case SourceLibrary:
+		return s.searchLibrary(query)
+	case SourceProvider:
+		return s.searchProvider(ctx, query)
+	case "":
+	default:
+		return nil, errors.Wrap(errors.KindDomain, "media.search", "unknown source: "+source, ErrUnknownSource)
+	}
+
+	if tracks := s.searchRadio(query, false); len(tracks) > 0 {
+		return tracks, nil
+	}
+	tracks, err := s.searchLibrary(query)
+	if err != nil {
+		s.logger.WarnTag("媒体", "检索本地曲库失败: %v", err)
+	}
+	if len(tracks) > 0 || !s.hasProvider() || randomQueries[cleanQuery(query)] {
+		return tracks, nil
+	}
+	return s.searchProvider(ctx, query)
+}
+
+// searchRadio 按名称或关键词匹配电台
+// explicit 为 true 时指定了只检索电台，查询为空返回全部电台，名称包含查询也算匹配
+func (s *Service) searchRadio(query string, explicit bool) []Track {
+	key := normalize(query)
+	var tracks []Track
+	for _, radio := range s.cfg.Radios {
+		if radio.URL == "" {
+			continue
+		}
+		matched := (explicit && key == "") || containsName(key, radio.Name, explicit)
+		for _, keyword := range radio.Keywords {
+			matched = matched || containsName(key, keyword, explicit)
+		}
+		if matched {
+			tracks = append(tracks, radioTrack(radio.Name, radio.URL))
+		}
+	}
+	if len(tracks) > 0 || len(s.cfg.Radios) == 0 {
+		return tracks
+	}
+	for _, word := range radioWords {
+		if strings.Contains(key, word) {
+			return []Track{radioTrack(s.cfg.Radios[0].Name, s.cfg.Radios[0].URL)}
+		}
+	}
+	return nil
+}
+
+// searchLibrary 在本地曲库中按文件名匹配，文件名为“歌手 - 歌名”时分别识别歌手和歌名
+func (s *Service) searchLibrary(query string) ([]Track, error) {
+	if s.cfg.LibraryDir == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(s.cfg.LibraryDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("读取曲库目录失败: %w", err)
+	}
+
+	var tracks []Track
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".mp3" && ext != ".wav") {
+			continue
+		}
+		track := Track{
+			Title:  strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())),
+			Source: SourceLibrary,
+			URL:    filepath.Join(s.cfg.LibraryDir, entry.Name()),
+		}
+		if artist, title, ok := strings.Cut(track.Title, " - "); ok {
+			track.Artist, track.Title = strings.TrimSpace(artist), strings.TrimSpace(title)
+		}
+		tracks = append(tracks, track)
+	}
+
+	key := cleanQuery(query)
+	if randomQueries[key] {
+		rand.Shuffle(len(tracks), func(i, j int) { tracks[i], tracks[j] = tracks[j], tracks[i] })
+		return s.limit(tracks), nil
+	}
+
+	type scored struct {
+		track Track
+		score float64
+	}
+	var matches []scored
+	for _, track := range tracks {
+		if score := matchScore(key, track); score > 0 {
+			matches = append(matches, scored{track, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	result := make([]Track, 0, len(matches))
+	for _, m := range matches {
+		result = append(result, m.track)
+	}
+	return s.limit(result), nil
+}
+
+// searchProvider 调用配置的媒体能力检索
+func (s *Service) searchProvider(ctx context.Context, query string) ([]Track, error) {
+	if !s.hasProvider() {
+		return nil, nil
+	}
+	exec, err := s.registry.GetExecutor(s.cfg.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("获取媒体能力 %s 失败: %w", s.cfg.Provider, err)
+	}
+	outputs, err := exec.Execute(ctx, s.cfg.ProviderConfig, map[string]interface{}{
+		"query": query,
+		"count": s.cfg.MaxResults,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("媒体能力 %s 执行失败: %w", s.cfg.Provider, err)
+	}
+
+	var tracks []Track
+	for _, item := range sdk.Args(outputs).Objects("tracks") {
+		track := Track{
+			Title:  item.String("title", ""),
+			Artist: item.String("artist", ""),
+			Source: SourceProvider,
+			URL:    item.String("url", ""),
+			Live:   item.Bool("live", false),
+		}
+		if track.URL == "" {
+			continue
+		}
+		if track.Title == "" {
+			track.Title = query
+		}
+		tracks = append(tracks, track)
+	}
+	return s.limit(tracks), nil
+}
+
+func (s *Service) hasProvider() bool {
+	return s.cfg.Provider != "" && s.registry != nil
+}
+
+func (s *Service) limit(tracks []Track) []Track {
+	if len(tracks) > s.cfg.MaxResults {
+		return tracks[:s.cfg.MaxResults]
+	}
+	return tracks
+}
+
+func radioTrack(name, url string) Track {
+	return Track{Title: name, Source: SourceRadio, URL: url, Live: true}
+}
+
+// matchScore 查询与曲目的匹配程度，0 表示不匹配
+// 歌名包含查询（“晴天”匹配“晴天（Live）”）或查询包含歌名（“周杰伦的晴天”）都算匹配，歌手匹配的得分较低
+func matchScore(key string, track Track) float64 {
+	if key == "" {
+		return 0
+	}
+	title := normalize(track.Title)
+	artist := normalize(track.Artist)
+	score := 0.0
+	switch {
+	case title == "":
+	case title == key:
+		score = 3
+	case strings.Contains(title, key):
+		score = 2 + float64(len(key))/float64(len(title))
+	case strings.Contains(key, title):
+		score = 1 + float64(len(title))/float64(len(key))
+	}
+	if artist != "" && (strings.Contains(key, artist) || strings.Contains(artist, key)) {
+		score += 0.5
+	}
+	return score
+}
+
+// containsName 查询中是否提到了名称，partial 为 true 时查询是名称的一部分也算
+func containsName(key, name string, partial bool) bool {
+	name = normalize(name)
+	if name == "" || key == "" {
+		return false
+	}
+	return strings.Contains(key, name) || (partial && strings.Contains(name, key))
+}
+
+// cleanQuery 去掉“的歌”等说法后规范化
+func cleanQuery(query string) string {
+	query = normalize(query)
+	for _, suffix := range querySuffixes {
+		if trimmed := strings.TrimSuffix(query, suffix); trimmed != query {
+			return trimmed
+		}
+	}
+	return query
+}
+
+// normalize 转为小写并去掉标点和空白
+func normalize(text string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsPunct(r) || unicode.IsSpace(r) || unicode.IsSymbol(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, text)
+}
@@ -0,0 +1,198 @@
+package media
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+// Service 媒体播放服务，检索曲库、电台和媒体能力，并通过连接层的播放器控制设备播放
+// 播放状态只保存在内存中，随设备断开连接清除
+type Service struct {
+	cfg        config.MediaConfig
+	registry   *capability.Registry
+	controller Controller
+	logger     *logging.Logger
+	now        func() time.Time
+
+	mu      sync.RWMutex
+	states  map[string]State
+	volumes map[string]int // 设备最近一次设置的音量，空闲时也保留
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局媒体服务，供意图路由、LLM 工具和连接层使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局媒体服务，未启用时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建媒体服务，registry 为 nil 时不使用媒体能力
+func NewService(cfg config.MediaConfig, registry *capability.Registry, controller Controller, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	if cfg.MaxResults <= 0 {
+		cfg.MaxResults = 10
+	}
+	if cfg.DefaultVolume <= 0 || cfg.DefaultVolume > 100 {
+		cfg.DefaultVolume = 80
+	}
+	if cfg.VolumeStep <= 0 {
+		cfg.VolumeStep = 15
+	}
+	return &Service{
+		cfg:        cfg,
+		registry:   registry,
+		controller: controller,
+		logger:     logger,
+		now:        time.Now,
+		states:     make(map[string]State),
+		volumes:    make(map[string]int),
+	}
+}
+
+// Play 检索并在设备上播放，返回第一首曲目；检索到的其余曲目作为播放列表依次播放
+func (s *Service) Play(ctx context.Context, deviceID, query, source string) (Track, error) {
+	tracks, err := s.Search(ctx, query, source)
+	if err != nil {
+		return Track{}, err
+	}
+	if len(tracks) == 0 {
+		return Track{}, errors.Wrap(errors.KindDomain, "media.play", fmt.Sprintf("no media matches %q", query), ErrNotFound)
+	}
+	if err := s.controller.Play(deviceID, tracks, s.volume(deviceID)); err != nil {
+		return Track{}, s.wrap("media.play", deviceID, err)
+	}
+	s.logger.InfoTag("媒体", "设备 %s 开始播放《%s》，播放列表 %d 首", deviceID, tracks[0].Title, len(tracks))
+	return tracks[0], nil
+}
+
+// Control 暂停、继续、停止、切歌或调整音量，设备没有在播放时返回 ErrNotPlaying
+func (s *Service) Control(deviceID string, action Action) (State, error) {
+	state := s.State(deviceID)
+	if !state.Active() {
+		return state, errors.Wrap(errors.KindDomain, "media.control", fmt.Sprintf("device %s is not playing", deviceID), ErrNotPlaying)
+	}
+
+	var err error
+	switch action {
+	case ActionPause:
+		err = s.controller.Pause(deviceID)
+	case ActionResume:
+		err = s.controller.Resume(deviceID)
+	case ActionStop:
+		err = s.controller.Stop(deviceID)
+	case ActionNext:
+		err = s.controller.Next(deviceID)
+	case ActionVolumeUp:
+		return s.SetVolume(deviceID, state.Volume+s.cfg.VolumeStep)
+	case ActionVolumeDown:
+		return s.SetVolume(deviceID, state.Volume-s.cfg.VolumeStep)
+	default:
+		return state, errors.New(errors.KindDomain, "media.control", "unsupported action: "+string(action))
+	}
+	if err != nil {
+		return state, s.wrap("media.control", deviceID, err)
+	}
+	s.logger.InfoTag("媒体", "设备 %s 播放控制：%s", deviceID, action)
+	return s.State(deviceID), nil
+}
+
+// SetVolume 设置设备的播放音量（0-100），空闲时保存到下次播放
+func (s *Service) SetVolume(deviceID string, volume int) (State, error) {
+	volume = clampVolume(volume)
+	s.mu.Lock()
+	s.volumes[deviceID] = volume
+	state, active := s.states[deviceID]
+	s.mu.Unlock()
+
+	if active {
+		if err := s.controller.SetVolume(deviceID, volume); err != nil && !stderrors.Is(err, ErrNotPlaying) {
+			return state, s.wrap("media.volume", deviceID, err)
+		}
+	}
+	return s.State(deviceID), nil
+}
+
+// VolumeStep 返回“大声点”“小声点”每次调整的音量
+func (s *Service) VolumeStep() int {
+	return s.cfg.VolumeStep
+}
+
+// State 返回设备的播放状态，没有播放时 Status 为 idle
+func (s *Service) State(deviceID string) State {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if state, ok := s.states[deviceID]; ok {
+		return state
+	}
+	return State{DeviceID: deviceID, Status: StatusIdle, Volume: s.volumeLocked(deviceID)}
+}
+
+// UpdateState 保存播放器上报的状态，状态为 idle 时清除
+func (s *Service) UpdateState(state State) {
+	state.UpdatedAt = s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.volumes[state.DeviceID] = clampVolume(state.Volume)
+	if !state.Active() {
+		delete(s.states, state.DeviceID)
+		return
+	}
+	s.states[state.DeviceID] = state
+}
+
+func (s *Service) volume(deviceID string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.volumeLocked(deviceID)
+}
+
+func (s *Service) volumeLocked(deviceID string) int {
+	if volume, ok := s.volumes[deviceID]; ok {
+		return volume
+	}
+	return s.cfg.DefaultVolume
+}
+
+// wrap 将播放器返回的哨兵错误包装为领域错误
+func (s *Service) wrap(op, deviceID string, err error) error {
+	switch {
+	case stderrors.Is(err, ErrDeviceOffline):
+		return errors.Wrap(errors.KindDomain, op, fmt.Sprintf("device %s is offline", deviceID), ErrDeviceOffline)
+	case stderrors.Is(err, ErrNotPlaying):
+		return errors.Wrap(errors.KindDomain, op, fmt.Sprintf("device %s is not playing", deviceID), ErrNotPlaying)
+	default:
+		return err
+	}
+}
+
+func clampVolume(volume int) int {
+	if volume < 0 {
+		return 0
+	}
+	if volume > 100 {
+		return 100
+	}
+	return volume
+}
@@ -0,0 +1,39 @@
+package member
+
+import (
+	"time"
+)
+
+// Role 用户在设备上的角色
+type Role string
+
+const (
+	// RoleOwner 设备所有者，每台设备最多一个
+	RoleOwner Role = "owner"
+	// RoleMember 家庭成员，可以使用设备并拥有个人资料
+	RoleMember Role = "member"
+)
+
+// Binding 用户与设备的绑定关系
+type Binding struct {
+	UserID    uint      `json:"user_id"`
+	DeviceID  string    `json:"device_id"`
+	Role      Role      `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Profile 成员个人资料，用于识别说话人后个性化回复
+type Profile struct {
+	UserID          uint      `json:"user_id"`
+	Name            string    `json:"name"`             // 称呼，也用于按名字识别说话人
+	Voice           string    `json:"voice"`            // 偏好音色，为空时使用设备默认音色
+	Language        string    `json:"language"`         // 偏好语言，如 zh-CN、en-US
+	MemoryNamespace string    `json:"memory_namespace"` // 个人记忆命名空间，不同成员的记忆互不可见
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// Member 设备上的一位成员
+type Member struct {
+	Binding
+	Profile Profile `json:"profile"`
+}
@@ -0,0 +1,32 @@
+package member
+
+import (
+	"context"
+)
+
+// Repository 成员仓库接口
+type Repository interface {
+	// UserExists 判断用户是否存在
+	UserExists(ctx context.Context, userID uint) (bool, error)
+
+	// DeviceTenant 返回设备所属的租户，设备未注册时 ok 为 false
+	DeviceTenant(ctx context.Context, deviceID string) (tenantID string, ok bool, err error)
+
+	// Bind 创建或更新绑定；绑定为所有者时同步设备的所属用户
+	Bind(ctx context.Context, b *Binding) error
+
+	// Unbind 删除绑定；解除所有者时清空设备的所属用户
+	Unbind(ctx context.Context, userID uint, deviceID string) error
+
+	// ListByUser 列出用户绑定的设备
+	ListByUser(ctx context.Context, userID uint) ([]*Binding, error)
+
+	// ListByDevice 列出设备的全部绑定，所有者在前
+	ListByDevice(ctx context.Context, deviceID string) ([]*Binding, error)
+
+	// FindProfile 查找成员资料，不存在时返回 nil
+	FindProfile(ctx context.Context, userID uint) (*Profile, error)
+
+	// SaveProfile 创建或更新成员资料
+	SaveProfile(ctx context.Context, p *Profile) error
+}
@@ -0,0 +1,305 @@
+package member
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"xiaozhi-server-go/internal/domain/tenant"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+var (
+	// ErrUserNotFound 用户不存在
+	ErrUserNotFound = stderrors.New("user not found")
+	// ErrDeviceNotFound 设备不存在或不属于当前租户
+	ErrDeviceNotFound = stderrors.New("device not found")
+	// ErrNotBound 用户未绑定该设备
+	ErrNotBound = stderrors.New("device not bound to user")
+)
+
+var (
+	languagePattern  = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+	namespacePattern = regexp.MustCompile(`^[A-Za-z0-9_.:-]{1,128}$`)
+)
+
+// ProfileUpdate 修改成员资料请求，nil 字段保持不变
+type ProfileUpdate struct {
+	Name            *string
+	Voice           *string
+	Language        *string
+	MemoryNamespace *string
+}
+
+// Service 成员服务，管理用户与设备的绑定和成员资料
+type Service struct {
+	repo   Repository
+	logger *logging.Logger
+	now    func() time.Time
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局成员服务，供连接处理器使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局成员服务，未初始化时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建成员服务
+func NewService(repo Repository, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Service{
+		repo:   repo,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// BindDevice 将设备绑定给用户，role 为空时作为家庭成员绑定
+// 每台设备只能有一个所有者，更换所有者需先解除原所有者的绑定
+func (s *Service) BindDevice(ctx context.Context, userID uint, deviceID string, role Role) (*Binding, error) {
+	if role == "" {
+		role = RoleMember
+	}
+	if role != RoleOwner && role != RoleMember {
+		return nil, errors.New(errors.KindDomain, "member.bind", "invalid role: "+string(role))
+	}
+	if err := s.checkUser(ctx, "member.bind", userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkDevice(ctx, "member.bind", deviceID); err != nil {
+		return nil, err
+	}
+
+	bindings, err := s.repo.ListByDevice(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	createdAt := s.now()
+	for _, b := range bindings {
+		if b.Role == RoleOwner && role == RoleOwner && b.UserID != userID {
+			return nil, errors.New(errors.KindDomain, "member.bind", fmt.Sprintf("device %s already has an owner", deviceID))
+		}
+		if b.UserID == userID {
+			createdAt = b.CreatedAt
+		}
+	}
+
+	binding := &Binding{UserID: userID, DeviceID: deviceID, Role: role, CreatedAt: createdAt}
+	if err := s.repo.Bind(ctx, binding); err != nil {
+		return nil, err
+	}
+	s.logger.InfoTag("成员", "用户 %d 以 %s 身份绑定设备 %s", userID, role, deviceID)
+	return binding, nil
+}
+
+// UnbindDevice 解除用户与设备的绑定
+func (s *Service) UnbindDevice(ctx context.Context, userID uint, deviceID string) error {
+	if err := s.checkDevice(ctx, "member.unbind", deviceID); err != nil {
+		return err
+	}
+	bindings, err := s.repo.ListByDevice(ctx, deviceID)
+	if err != nil {
+		return err
+	}
+	for _, b := range bindings {
+		if b.UserID == userID {
+			if err := s.repo.Unbind(ctx, userID, deviceID); err != nil {
+				return err
+			}
+			s.logger.InfoTag("成员", "用户 %d 已解除绑定设备 %s", userID, deviceID)
+			return nil
+		}
+	}
+	return errors.Wrap(errors.KindDomain, "member.unbind", fmt.Sprintf("device %s is not bound to user %d", deviceID, userID), ErrNotBound)
+}
+
+// ListDevices 列出用户绑定的设备；租户请求只返回本租户的设备
+func (s *Service) ListDevices(ctx context.Context, userID uint) ([]*Binding, error) {
+	if err := s.checkUser(ctx, "member.list_devices", userID); err != nil {
+		return nil, err
+	}
+	bindings, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if _, scoped := tenant.FromContext(ctx); !scoped {
+		return bindings, nil
+	}
+	visible := make([]*Binding, 0, len(bindings))
+	for _, b := range bindings {
+		if s.checkDevice(ctx, "member.list_devices", b.DeviceID) == nil {
+			visible = append(visible, b)
+		}
+	}
+	return visible, nil
+}
+
+// Members 列出设备上的全部成员及其资料，所有者在前
+func (s *Service) Members(ctx context.Context, deviceID string) ([]*Member, error) {
+	if err := s.checkDevice(ctx, "member.members", deviceID); err != nil {
+		return nil, err
+	}
+	bindings, err := s.repo.ListByDevice(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]*Member, 0, len(bindings))
+	for _, b := range bindings {
+		profile, err := s.profile(ctx, b.UserID)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, &Member{Binding: *b, Profile: *profile})
+	}
+	return members, nil
+}
+
+// Identify 按识别结果确定设备当前的说话人
+// hint 可以是用户ID或成员称呼；为空时设备只有一位成员则是该成员，否则是所有者
+func (s *Service) Identify(ctx context.Context, deviceID, hint string) (*Member, bool) {
+	members, err := s.Members(ctx, deviceID)
+	if err != nil {
+		if !stderrors.Is(err, ErrDeviceNotFound) {
+			s.logger.WarnTag("成员", "加载设备 %s 的成员失败: %v", deviceID, err)
+		}
+		return nil, false
+	}
+	if len(members) == 0 {
+		return nil, false
+	}
+
+	hint = strings.TrimSpace(hint)
+	if hint == "" {
+		if len(members) == 1 || members[0].Role == RoleOwner {
+			return members[0], true
+		}
+		return nil, false
+	}
+	if id, err := strconv.ParseUint(hint, 10, 64); err == nil {
+		for _, m := range members {
+			if uint64(m.UserID) == id {
+				return m, true
+			}
+		}
+	}
+	for _, m := range members {
+		if m.Profile.Name != "" && strings.EqualFold(m.Profile.Name, hint) {
+			return m, true
+		}
+	}
+	return nil, false
+}
+
+// GetProfile 返回成员资料，未设置过时返回默认值
+func (s *Service) GetProfile(ctx context.Context, userID uint) (*Profile, error) {
+	if err := s.checkUser(ctx, "member.get_profile", userID); err != nil {
+		return nil, err
+	}
+	return s.profile(ctx, userID)
+}
+
+// UpdateProfile 修改成员资料
+func (s *Service) UpdateProfile(ctx context.Context, userID uint, req ProfileUpdate) (*Profile, error) {
+	profile, err := s.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if utf8.RuneCountInString(name) > 64 {
+			return nil, errors.New(errors.KindDomain, "member.update_profile", "name must be at most 64 characters")
+		}
+		profile.Name = name
+	}
+	if req.Voice != nil {
+		profile.Voice = strings.TrimSpace(*req.Voice)
+	}
+	if req.Language != nil {
+		language := strings.TrimSpace(*req.Language)
+		if language != "" && !languagePattern.MatchString(language) {
+			return nil, errors.New(errors.KindDomain, "member.update_profile", "invalid language: "+language)
+		}
+		profile.Language = language
+	}
+	if req.MemoryNamespace != nil {
+		namespace := strings.TrimSpace(*req.MemoryNamespace)
+		if namespace == "" {
+			namespace = defaultNamespace(userID)
+		}
+		if !namespacePattern.MatchString(namespace) {
+			return nil, errors.New(errors.KindDomain, "member.update_profile", "invalid memory namespace: "+namespace)
+		}
+		profile.MemoryNamespace = namespace
+	}
+	profile.UpdatedAt = s.now()
+	if err := s.repo.SaveProfile(ctx, profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+func (s *Service) profile(ctx context.Context, userID uint) (*Profile, error) {
+	profile, err := s.repo.FindProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		profile = &Profile{UserID: userID}
+	}
+	if profile.MemoryNamespace == "" {
+		profile.MemoryNamespace = defaultNamespace(userID)
+	}
+	return profile, nil
+}
+
+func (s *Service) checkUser(ctx context.Context, op string, userID uint) error {
+	ok, err := s.repo.UserExists(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.Wrap(errors.KindDomain, op, fmt.Sprintf("user %d not found", userID), ErrUserNotFound)
+	}
+	return nil
+}
+
+// checkDevice 设备未注册或不属于当前租户时返回 ErrDeviceNotFound
+func (s *Service) checkDevice(ctx context.Context, op, deviceID string) error {
+	owner, ok, err := s.repo.DeviceTenant(ctx, deviceID)
+	if err != nil {
+		return err
+	}
+	if id, scoped := tenant.FromContext(ctx); ok && scoped && owner != id {
+		ok = false
+	}
+	if !ok {
+		return errors.Wrap(errors.KindDomain, op, "device not found: "+deviceID, ErrDeviceNotFound)
+	}
+	return nil
+}
+
+func defaultNamespace(userID uint) string {
+	return fmt.Sprintf("user-%d", userID)
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	domainllm "xiaozhi-server-go/internal/domain/llm"
+	"xiaozhi-server-go/internal/domain/llm/inter"
+	"xiaozhi-server-go/internal/platform/config"
+)
+
+const extractPrompt = `你负责维护用户的长期记忆。从用户的这句话中找出值得长期记住的、关于用户本人的事实，例如姓名和称呼、家庭成员、职业、爱好、饮食禁忌、作息、对回答方式的偏好。
+忽略一次性的请求、提问、闲聊，以及与用户本人无关的内容；不要推测用户没有明确说出的信息。
+每条事实用第三人称写成一句简短的陈述，如“用户叫李雷”“用户喜欢简洁的回答”。新事实更正或补充已有记忆时，在 replaces 中给出被替换的已有记忆序号。
+
+已有记忆：
+%s
+
+用户的话：%s
+
+只输出 JSON，不要输出其他内容：{"facts": [{"content": "事实", "replaces": 0}]}
+没有值得记住的事实时输出 {"facts": []}`
+
+// extracted LLM 提取出的一条事实
+type extracted struct {
+	Content  string `json:"content"`
+	Replaces int    `json:"replaces,omitempty"` // 被更正的已有记忆序号，从 1 开始，0 表示新事实
+}
+
+// extractor 从用户语句中提取事实
+type extractor interface {
+	extract(ctx context.Context, existing []*Fact, text string) ([]extracted, error)
+}
+
+// llmExtractor 使用配置的 LLM 提取事实
+type llmExtractor struct {
+	cfg *config.Config
+	llm string
+}
+
+func (e *llmExtractor) extract(ctx context.Context, existing []*Fact, text string) ([]extracted, error) {
+	name := e.llm
+	if name == "" {
+		name = e.cfg.Selected.LLM
+	}
+	llmCfg, ok := e.cfg.LLM[name]
+	if !ok {
+		return nil, fmt.Errorf("未找到LLM配置 %s", name)
+	}
+	manager := domainllm.NewManager(inter.LLMConfig{
+		Provider:    llmCfg.Type,
+		Model:       llmCfg.ModelName,
+		APIKey:      llmCfg.APIKey,
+		BaseURL:     llmCfg.BaseURL,
+		Temperature: 0,
+		MaxTokens:   llmCfg.MaxTokens,
+		Timeout:     30,
+	})
+
+	var known strings.Builder
+	if len(existing) == 0 {
+		known.WriteString("（无）")
+	}
+	for i, f := range existing {
+		fmt.Fprintf(&known, "%d. %s\n", i+1, f.Content)
+	}
+	messages := []inter.Message{{Role: "user", Content: fmt.Sprintf(extractPrompt, strings.TrimSpace(known.String()), text)}}
+
+	responses, err := manager.Response(ctx, "memory-"+uuid.New().String(), messages, nil)
+	if err != nil {
+		return nil, err
+	}
+	var content strings.Builder
+	for response := range responses {
+		if response.Error != nil {
+			go func() {
+				for range responses {
+				}
+			}()
+			return nil, response.Error
+		}
+		content.WriteString(response.Content)
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return parseExtracted(content.String())
+}
+
+// parseExtracted 解析 LLM 输出中的 JSON，兼容包在代码块或说明文字中的输出
+func parseExtracted(output string) ([]extracted, error) {
+	start := strings.Index(output, "{")
+	end := strings.LastIndex(output, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("LLM输出中没有JSON: %s", output)
+	}
+	var result struct {
+		Facts []extracted `json:"facts"`
+	}
+	if err := json.Unmarshal([]byte(output[start:end+1]), &result); err != nil {
+		return nil, fmt.Errorf("解析LLM输出失败: %w", err)
+	}
+	return result.Facts, nil
+}
@@ -0,0 +1,56 @@
+// Package memory 用户长期记忆
+//
+// 用户授权后，LLM 从其对话中提取值得长期记住的事实（称呼、偏好、家庭情况等），
+// 连同来源设备、会话和原话一起按成员的记忆命名空间保存；之后的对话把相关事实注入提示词。
+// 用户可以查看、修改和删除自己的记忆。
+package memory
+
+import "time"
+
+// Source 记忆的来源
+type Source string
+
+const (
+	SourceExtracted Source = "extracted" // 从对话中提取
+	SourceManual    Source = "manual"    // 通过接口添加或修改
+)
+
+// Fact 一条关于用户的长期记忆
+type Fact struct {
+	ID        string    `json:"id"`
+	Namespace string    `json:"namespace"`
+	UserID    uint      `json:"user_id"`
+	Content   string    `json:"content"`
+	Source    Source    `json:"source"`
+	DeviceID  string    `json:"device_id"`  // 提取自该设备的对话
+	SessionID string    `json:"session_id"` // 提取自该会话
+	EntryID   string    `json:"entry_id"`   // 对应对话记录中的用户消息
+	Evidence  string    `json:"evidence"`   // 提取依据的用户原话
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Consent 用户对从对话中提取记忆的授权，默认未授权
+type Consent struct {
+	UserID    uint      `json:"user_id"`
+	Granted   bool      `json:"granted"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Observation 一句待提取记忆的用户语句
+type Observation struct {
+	UserID    uint
+	Namespace string
+	DeviceID  string
+	SessionID string
+	EntryID   string
+	Text      string
+}
+
+// Filter 记忆查询条件
+type Filter struct {
+	Namespace string
+	Query     string // 内容关键字
+	Page      int
+	PageSize  int
+}
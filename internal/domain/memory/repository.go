@@ -0,0 +1,39 @@
+package memory
+
+import "context"
+
+// Repository 长期记忆仓库接口
+type Repository interface {
+	// SaveFact 新建记忆
+	SaveFact(ctx context.Context, f *Fact) error
+
+	// UpdateFact 更新记忆
+	UpdateFact(ctx context.Context, f *Fact) error
+
+	// FindFact 根据ID查找记忆，不存在时返回 nil
+	FindFact(ctx context.Context, id string) (*Fact, error)
+
+	// ListFacts 按条件分页查询记忆，最近更新的在前
+	ListFacts(ctx context.Context, filter Filter) ([]*Fact, int64, error)
+
+	// AllFacts 查询命名空间的全部记忆，最近更新的在前
+	AllFacts(ctx context.Context, namespace string) ([]*Fact, error)
+
+	// DeleteFact 删除记忆
+	DeleteFact(ctx context.Context, id string) error
+
+	// DeleteNamespace 删除命名空间的全部记忆
+	DeleteNamespace(ctx context.Context, namespace string) (int64, error)
+
+	// DeleteDevice 删除提取自设备对话的全部记忆
+	DeleteDevice(ctx context.Context, deviceID string) (int64, error)
+
+	// TrimNamespace 只保留命名空间中最近更新的 keep 条记忆
+	TrimNamespace(ctx context.Context, namespace string, keep int) (int64, error)
+
+	// GetConsent 获取用户授权，未设置时返回 nil
+	GetConsent(ctx context.Context, userID uint) (*Consent, error)
+
+	// SaveConsent 保存用户授权
+	SaveConsent(ctx context.Context, c *Consent) error
+}
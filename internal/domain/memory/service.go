@@ -0,0 +1,456 @@
+package memory
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/domain/member"
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/observability"
+	internalutils "xiaozhi-server-go/internal/utils"
+)
+
+const (
+	queueSize      = 256
+	extractTimeout = 30 * time.Second
+	// maxContentChars 单条记忆的最大字数
+	maxContentChars = 500
+)
+
+// ErrNotFound 记忆不存在或不属于该用户
+var ErrNotFound = stderrors.New("memory fact not found")
+
+// Profiles 成员资料来源，用于确定用户的记忆命名空间并校验用户存在
+type Profiles interface {
+	GetProfile(ctx context.Context, userID uint) (*member.Profile, error)
+}
+
+// Service 长期记忆服务
+//
+// 只从已授权用户的语句中提取记忆；提取异步进行，提取前再次确认授权，
+// 避免撤销授权后仍有排队中的语句被处理。
+type Service struct {
+	repo        Repository
+	profiles    Profiles
+	extractor   extractor
+	logger      *logging.Logger
+	minChars    int
+	maxFacts    int
+	injectLimit int
+	now         func() time.Time
+
+	queue chan Observation
+
+	consentMu sync.RWMutex
+	consent   map[uint]bool // 用户授权缓存
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局长期记忆服务，供连接处理器使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局长期记忆服务，未启用时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建长期记忆服务，使用 cfg.Memory.LLM 指定的 LLM 提取记忆
+func NewService(repo Repository, profiles Profiles, cfg *config.Config, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Service{
+		repo:        repo,
+		profiles:    profiles,
+		extractor:   &llmExtractor{cfg: cfg, llm: cfg.Memory.LLM},
+		logger:      logger,
+		minChars:    cfg.Memory.MinChars,
+		maxFacts:    cfg.Memory.MaxFacts,
+		injectLimit: cfg.Memory.InjectLimit,
+		now:         time.Now,
+		queue:       make(chan Observation, queueSize),
+		consent:     make(map[uint]bool),
+	}
+}
+
+// Consented 用户是否已授权从对话中提取记忆，查询失败时按未授权处理
+func (s *Service) Consented(ctx context.Context, userID uint) bool {
+	if userID == 0 {
+		return false
+	}
+	s.consentMu.RLock()
+	granted, ok := s.consent[userID]
+	s.consentMu.RUnlock()
+	if ok {
+		return granted
+	}
+
+	c, err := s.repo.GetConsent(ctx, userID)
+	if err != nil {
+		s.logger.WarnTag("记忆", "查询用户 %d 的授权失败: %v", userID, err)
+		return false
+	}
+	granted = c != nil && c.Granted
+	s.consentMu.Lock()
+	s.consent[userID] = granted
+	s.consentMu.Unlock()
+	return granted
+}
+
+// GetConsent 获取用户授权，未设置时返回未授权
+func (s *Service) GetConsent(ctx context.Context, userID uint) (*Consent, error) {
+	if _, err := s.profiles.GetProfile(ctx, userID); err != nil {
+		return nil, err
+	}
+	c, err := s.repo.GetConsent(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		c = &Consent{UserID: userID}
+	}
+	return c, nil
+}
+
+// SetConsent 设置用户授权；撤销授权且 deleteExisting 为 true 时同时删除已有记忆，返回删除条数
+func (s *Service) SetConsent(ctx context.Context, userID uint, granted, deleteExisting bool) (*Consent, int64, error) {
+	profile, err := s.profiles.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	c := &Consent{UserID: userID, Granted: granted, UpdatedAt: s.now()}
+	if err := s.repo.SaveConsent(ctx, c); err != nil {
+		return nil, 0, err
+	}
+	s.consentMu.Lock()
+	s.consent[userID] = granted
+	s.consentMu.Unlock()
+	s.logger.InfoTag("记忆", "用户 %d 记忆提取授权: %t", userID, granted)
+
+	if granted || !deleteExisting {
+		return c, 0, nil
+	}
+	removed, err := s.repo.DeleteNamespace(ctx, profile.MemoryNamespace)
+	return c, removed, err
+}
+
+// List 分页查询用户的记忆
+func (s *Service) List(ctx context.Context, userID uint, query string, page, pageSize int) ([]*Fact, int64, error) {
+	profile, err := s.profiles.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.repo.ListFacts(ctx, Filter{
+		Namespace: profile.MemoryNamespace,
+		Query:     strings.TrimSpace(query),
+		Page:      page,
+		PageSize:  pageSize,
+	})
+}
+
+// Add 手动为用户添加一条记忆，不需要授权
+func (s *Service) Add(ctx context.Context, userID uint, content string) (*Fact, error) {
+	content, err := validateContent("memory.add", content)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := s.profiles.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	f := &Fact{
+		ID:        uuid.New().String(),
+		Namespace: profile.MemoryNamespace,
+		UserID:    userID,
+		Content:   content,
+		Source:    SourceManual,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.repo.SaveFact(ctx, f); err != nil {
+		return nil, err
+	}
+	s.trim(ctx, f.Namespace)
+	return f, nil
+}
+
+// Update 修改用户的记忆，修改后来源记为手动，保留原始出处
+func (s *Service) Update(ctx context.Context, userID uint, id, content string) (*Fact, error) {
+	content, err := validateContent("memory.update", content)
+	if err != nil {
+		return nil, err
+	}
+	f, err := s.find(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	f.Content = content
+	f.Source = SourceManual
+	f.UpdatedAt = s.now()
+	if err := s.repo.UpdateFact(ctx, f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Delete 删除用户的一条记忆
+func (s *Service) Delete(ctx context.Context, userID uint, id string) error {
+	if _, err := s.find(ctx, userID, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteFact(ctx, id)
+}
+
+// Clear 删除用户的全部记忆，返回删除条数
+func (s *Service) Clear(ctx context.Context, userID uint) (int64, error) {
+	profile, err := s.profiles.GetProfile(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return s.repo.DeleteNamespace(ctx, profile.MemoryNamespace)
+}
+
+// EraseDeviceData 删除提取自设备对话的全部记忆
+func (s *Service) EraseDeviceData(ctx context.Context, deviceID string) (int64, error) {
+	return s.repo.DeleteDevice(ctx, deviceID)
+}
+
+// find 查找属于用户记忆命名空间的记忆
+func (s *Service) find(ctx context.Context, userID uint, id string) (*Fact, error) {
+	profile, err := s.profiles.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	f, err := s.repo.FindFact(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if f == nil || f.Namespace != profile.MemoryNamespace {
+		return nil, errors.Wrap(errors.KindDomain, "memory.find", "memory fact not found: "+id, ErrNotFound)
+	}
+	return f, nil
+}
+
+// Prompt 返回与本轮用户语句最相关的记忆组成的提示词，没有记忆时返回空
+func (s *Service) Prompt(ctx context.Context, namespace, text string) string {
+	if namespace == "" || s.injectLimit <= 0 {
+		return ""
+	}
+	facts, err := s.repo.AllFacts(ctx, namespace)
+	if err != nil {
+		s.logger.WarnTag("记忆", "读取命名空间 %s 的记忆失败: %v", namespace, err)
+		return ""
+	}
+	facts = rank(facts, text, s.injectLimit)
+	if len(facts) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("以下是你记得的关于当前用户的信息，回答时自然地参考，不要逐条复述：")
+	for _, f := range facts {
+		b.WriteString("\n- ")
+		b.WriteString(f.Content)
+	}
+	return b.String()
+}
+
+// Observe 提交一句用户语句用于提取记忆，不阻塞调用方；用户未授权、语句过短或队列满时忽略
+func (s *Service) Observe(obs Observation) {
+	obs.Text = strings.TrimSpace(obs.Text)
+	if obs.UserID == 0 || obs.Namespace == "" || utf8.RuneCountInString(obs.Text) < s.minChars {
+		return
+	}
+	if !s.Consented(context.Background(), obs.UserID) {
+		return
+	}
+	select {
+	case s.queue <- obs:
+	default:
+		s.logger.WarnTag("记忆", "提取队列已满，忽略用户 %d 的语句", obs.UserID)
+	}
+}
+
+// Run 启动记忆提取循环，直到 ctx 结束
+func (s *Service) Run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case obs := <-s.queue:
+			s.process(ctx, obs)
+		}
+	}
+}
+
+// process 从一句用户语句中提取记忆并合并到已有记忆
+func (s *Service) process(ctx context.Context, obs Observation) {
+	if !s.Consented(ctx, obs.UserID) {
+		return
+	}
+	existing, err := s.repo.AllFacts(ctx, obs.Namespace)
+	if err != nil {
+		s.logger.ErrorTag("记忆", "读取命名空间 %s 的记忆失败: %v", obs.Namespace, err)
+		return
+	}
+
+	extractCtx, cancel := context.WithTimeout(ctx, extractTimeout)
+	items, err := s.extractor.extract(extractCtx, existing, obs.Text)
+	cancel()
+	if err != nil {
+		s.logger.WarnTag("记忆", "提取用户 %d 的记忆失败: %v", obs.UserID, err)
+		return
+	}
+
+	known := make(map[string]bool, len(existing))
+	for _, f := range existing {
+		known[normalize(f.Content)] = true
+	}
+	var added, updated int
+	for _, item := range items {
+		content := strings.TrimSpace(item.Content)
+		key := normalize(content)
+		if content == "" || utf8.RuneCountInString(content) > maxContentChars || known[key] {
+			continue
+		}
+		known[key] = true
+
+		now := s.now()
+		if item.Replaces > 0 && item.Replaces <= len(existing) {
+			f := existing[item.Replaces-1]
+			f.Content = content
+			f.Source = SourceExtracted
+			f.UserID = obs.UserID
+			f.DeviceID, f.SessionID, f.EntryID, f.Evidence = obs.DeviceID, obs.SessionID, obs.EntryID, obs.Text
+			f.UpdatedAt = now
+			if err := s.repo.UpdateFact(ctx, f); err != nil {
+				s.logger.ErrorTag("记忆", "更新记忆失败: %v", err)
+				continue
+			}
+			updated++
+			continue
+		}
+
+		f := &Fact{
+			ID:        uuid.New().String(),
+			Namespace: obs.Namespace,
+			UserID:    obs.UserID,
+			Content:   content,
+			Source:    SourceExtracted,
+			DeviceID:  obs.DeviceID,
+			SessionID: obs.SessionID,
+			EntryID:   obs.EntryID,
+			Evidence:  obs.Text,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := s.repo.SaveFact(ctx, f); err != nil {
+			s.logger.ErrorTag("记忆", "保存记忆失败: %v", err)
+			continue
+		}
+		added++
+	}
+	if added == 0 && updated == 0 {
+		return
+	}
+
+	observability.RecordMetric(ctx, "memory_facts_extracted_total", float64(added+updated), nil)
+	s.logger.InfoTag("记忆", "从用户 %d 的语句中新增 %d 条、更新 %d 条记忆: %s", obs.UserID, added, updated, internalutils.SanitizeForLog(obs.Text))
+	if added > 0 {
+		s.trim(ctx, obs.Namespace)
+	}
+}
+
+// trim 按上限删除命名空间中最久未更新的记忆
+func (s *Service) trim(ctx context.Context, namespace string) {
+	if s.maxFacts <= 0 {
+		return
+	}
+	removed, err := s.repo.TrimNamespace(ctx, namespace, s.maxFacts)
+	if err != nil {
+		s.logger.ErrorTag("记忆", "清理命名空间 %s 的记忆失败: %v", namespace, err)
+		return
+	}
+	if removed > 0 {
+		s.logger.InfoTag("记忆", "命名空间 %s 超出上限，已删除 %d 条最久未更新的记忆", namespace, removed)
+	}
+}
+
+func validateContent(op, content string) (string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", errors.New(errors.KindDomain, op, "content is required")
+	}
+	if utf8.RuneCountInString(content) > maxContentChars {
+		return "", errors.New(errors.KindDomain, op, fmt.Sprintf("content must be at most %d characters", maxContentChars))
+	}
+	return content, nil
+}
+
+// rank 按与用户语句共有的字词对记忆排序，相关度相同时保持最近更新的在前，返回前 limit 条
+func rank(facts []*Fact, text string, limit int) []*Fact {
+	query := bigrams(text)
+	scores := make(map[*Fact]int, len(facts))
+	for _, f := range facts {
+		for gram := range bigrams(f.Content) {
+			if query[gram] {
+				scores[f]++
+			}
+		}
+	}
+	ranked := append([]*Fact(nil), facts...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[ranked[i]] > scores[ranked[j]]
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
+
+// bigrams 返回文本中相邻两个字（忽略标点和空白）组成的集合，中英文都适用
+func bigrams(text string) map[string]bool {
+	var runes []rune
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			runes = append(runes, r)
+		}
+	}
+	grams := make(map[string]bool, len(runes))
+	for i := 0; i+1 < len(runes); i++ {
+		grams[string(runes[i:i+2])] = true
+	}
+	return grams
+}
+
+// normalize 用于判断两条记忆是否重复
+func normalize(content string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, content)
+}
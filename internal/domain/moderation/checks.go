@@ -0,0 +1,215 @@
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+const checkTimeout = 3 * time.Second
+
+// KeywordCheck 关键词检查，忽略大小写
+type KeywordCheck struct {
+	name     string
+	category string
+	keywords []string
+}
+
+// NewKeywordCheck 创建关键词检查
+func NewKeywordCheck(name, category string, keywords []string) *KeywordCheck {
+	cleaned := make([]string, 0, len(keywords))
+	for _, kw := range keywords {
+		if kw = strings.TrimSpace(kw); kw != "" {
+			cleaned = append(cleaned, kw)
+		}
+	}
+	return &KeywordCheck{name: name, category: category, keywords: cleaned}
+}
+
+// Name 检查名称
+func (c *KeywordCheck) Name() string { return c.name }
+
+// Check 执行关键词检查
+func (c *KeywordCheck) Check(_ context.Context, text string) ([]Finding, error) {
+	lower := strings.ToLower(text)
+	var matches []string
+	for _, kw := range c.keywords {
+		idx := strings.Index(lower, strings.ToLower(kw))
+		if idx < 0 {
+			continue
+		}
+		// 保留原文大小写，便于 redact 替换
+		matches = append(matches, text[idx:idx+len(kw)])
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	return []Finding{{Check: c.name, Category: c.category, Score: 1, Matches: matches}}, nil
+}
+
+// RegexCheck 正则表达式检查
+type RegexCheck struct {
+	name     string
+	category string
+	patterns []*regexp.Regexp
+}
+
+// NewRegexCheck 创建正则检查
+func NewRegexCheck(name, category string, patterns []string) (*RegexCheck, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid moderation pattern %q: %w", p, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return &RegexCheck{name: name, category: category, patterns: compiled}, nil
+}
+
+// Name 检查名称
+func (c *RegexCheck) Name() string { return c.name }
+
+// Check 执行正则检查
+func (c *RegexCheck) Check(_ context.Context, text string) ([]Finding, error) {
+	var matches []string
+	for _, re := range c.patterns {
+		matches = append(matches, re.FindAllString(text, -1)...)
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	return []Finding{{Check: c.name, Category: c.category, Score: 1, Matches: matches}}, nil
+}
+
+// ProviderCheck 调用 OpenAI 兼容的审核接口
+type ProviderCheck struct {
+	name      string
+	client    *openai.Client
+	model     string
+	threshold float64
+}
+
+// NewProviderCheck 创建审核接口检查
+func NewProviderCheck(name, apiKey, baseURL, model string, threshold float64) *ProviderCheck {
+	cfg := openai.DefaultConfig(apiKey)
+	if baseURL != "" {
+		cfg.BaseURL = baseURL
+	}
+	return &ProviderCheck{
+		name:      name,
+		client:    openai.NewClientWithConfig(cfg),
+		model:     model,
+		threshold: threshold,
+	}
+}
+
+// Name 检查名称
+func (c *ProviderCheck) Name() string { return c.name }
+
+// Check 调用审核接口，按类别分数与阈值比较
+func (c *ProviderCheck) Check(ctx context.Context, text string) ([]Finding, error) {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	resp, err := c.client.Moderations(ctx, openai.ModerationRequest{Input: text, Model: c.model})
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, result := range resp.Results {
+		scores, err := toScoreMap(result.CategoryScores)
+		if err != nil {
+			return nil, err
+		}
+		for category, score := range scores {
+			if (c.threshold > 0 && score >= c.threshold) || (c.threshold <= 0 && result.Flagged && score >= 0.5) {
+				findings = append(findings, Finding{Check: c.name, Category: category, Score: score})
+			}
+		}
+	}
+	return findings, nil
+}
+
+// ClassifierCheck 调用本地分类服务
+// 请求: POST {"text": "..."}，响应: {"scores": {"类别": 分数}}
+type ClassifierCheck struct {
+	name      string
+	endpoint  string
+	threshold float64
+	client    *http.Client
+}
+
+// NewClassifierCheck 创建本地分类检查
+func NewClassifierCheck(name, endpoint string, threshold float64) *ClassifierCheck {
+	if threshold <= 0 {
+		threshold = 0.5
+	}
+	return &ClassifierCheck{
+		name:      name,
+		endpoint:  endpoint,
+		threshold: threshold,
+		client:    &http.Client{Timeout: checkTimeout},
+	}
+}
+
+// Name 检查名称
+func (c *ClassifierCheck) Name() string { return c.name }
+
+// Check 调用分类服务
+func (c *ClassifierCheck) Check(ctx context.Context, text string) ([]Finding, error) {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("classifier returned status %d", resp.StatusCode)
+	}
+
+	var out struct {
+		Scores map[string]float64 `json:"scores"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decode classifier response: %w", err)
+	}
+
+	var findings []Finding
+	for category, score := range out.Scores {
+		if score >= c.threshold {
+			findings = append(findings, Finding{Check: c.name, Category: category, Score: score})
+		}
+	}
+	return findings, nil
+}
+
+// toScoreMap 将审核接口的分数结构转换为 类别->分数
+func toScoreMap(scores openai.ResultCategoryScores) (map[string]float64, error) {
+	data, err := json.Marshal(scores)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]float64)
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
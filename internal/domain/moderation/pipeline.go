@@ -0,0 +1,234 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/eventbus"
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/observability"
+)
+
+const (
+	defaultProfile  = "default"
+	redactMask      = "***"
+	defaultBlockMsg = "抱歉，这个问题我不能回答"
+)
+
+// Profile 审核策略，决定各类别命中后的动作
+type Profile struct {
+	DefaultAction Action
+	Actions       map[string]Action
+}
+
+// actionFor 返回类别对应的动作
+func (p Profile) actionFor(category string) Action {
+	if action, ok := p.Actions[category]; ok {
+		return action
+	}
+	if p.DefaultAction == "" {
+		return ActionWarn
+	}
+	return p.DefaultAction
+}
+
+type stagedCheck struct {
+	check  Check
+	stages map[Stage]bool // 为空表示所有阶段
+}
+
+// Pipeline 审核流水线，依次执行各项检查并按设备策略合并结果
+type Pipeline struct {
+	logger         *logging.Logger
+	checks         []stagedCheck
+	profiles       map[string]Profile
+	deviceProfiles map[string]string
+	blockReply     string
+}
+
+// NewPipeline 创建空的审核流水线
+func NewPipeline(logger *logging.Logger, blockReply string) *Pipeline {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	if blockReply == "" {
+		blockReply = defaultBlockMsg
+	}
+	return &Pipeline{
+		logger:         logger,
+		profiles:       map[string]Profile{defaultProfile: {DefaultAction: ActionWarn}},
+		deviceProfiles: make(map[string]string),
+		blockReply:     blockReply,
+	}
+}
+
+// NewPipelineFromConfig 根据配置创建审核流水线，未启用时返回 nil
+func NewPipelineFromConfig(cfg config.ModerationConfig, logger *logging.Logger) (*Pipeline, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	p := NewPipeline(logger, cfg.BlockReply)
+	for _, c := range cfg.Checks {
+		check, err := buildCheck(c)
+		if err != nil {
+			return nil, err
+		}
+		stages := make([]Stage, 0, len(c.Stages))
+		for _, s := range c.Stages {
+			stages = append(stages, Stage(s))
+		}
+		p.AddCheck(check, stages...)
+	}
+	for name, profile := range cfg.Profiles {
+		actions := make(map[string]Action, len(profile.Actions))
+		for category, action := range profile.Actions {
+			actions[category] = ParseAction(action)
+		}
+		p.SetProfile(name, Profile{DefaultAction: ParseAction(profile.DefaultAction), Actions: actions})
+	}
+	for deviceID, profile := range cfg.DeviceProfiles {
+		p.deviceProfiles[deviceID] = profile
+	}
+	return p, nil
+}
+
+func buildCheck(c config.ModerationCheck) (Check, error) {
+	name := c.Name
+	if name == "" {
+		name = c.Type
+	}
+	category := c.Category
+	if category == "" {
+		category = name
+	}
+	switch c.Type {
+	case "keyword":
+		return NewKeywordCheck(name, category, c.Keywords), nil
+	case "regex":
+		return NewRegexCheck(name, category, c.Patterns)
+	case "provider":
+		return NewProviderCheck(name, c.APIKey, c.BaseURL, c.Model, c.Threshold), nil
+	case "classifier":
+		if c.Endpoint == "" {
+			return nil, fmt.Errorf("moderation check %s: classifier endpoint is required", name)
+		}
+		return NewClassifierCheck(name, c.Endpoint, c.Threshold), nil
+	default:
+		return nil, fmt.Errorf("moderation check %s: unknown type %q", name, c.Type)
+	}
+}
+
+// AddCheck 添加检查，stages 为空表示输入和输出都检查
+func (p *Pipeline) AddCheck(check Check, stages ...Stage) {
+	sc := stagedCheck{check: check}
+	if len(stages) > 0 {
+		sc.stages = make(map[Stage]bool, len(stages))
+		for _, s := range stages {
+			sc.stages[s] = true
+		}
+	}
+	p.checks = append(p.checks, sc)
+}
+
+// SetProfile 设置审核策略
+func (p *Pipeline) SetProfile(name string, profile Profile) {
+	p.profiles[name] = profile
+}
+
+// BlockReply 拦截时的回复
+func (p *Pipeline) BlockReply() string {
+	return p.blockReply
+}
+
+// ProfileFor 返回设备使用的策略名称
+func (p *Pipeline) ProfileFor(deviceID string) string {
+	if name, ok := p.deviceProfiles[deviceID]; ok {
+		if _, exists := p.profiles[name]; exists {
+			return name
+		}
+	}
+	return defaultProfile
+}
+
+// Moderate 审核文本，返回最终动作和处理后的文本
+// 检查出错时记录日志并放行，避免审核服务故障影响对话
+func (p *Pipeline) Moderate(ctx context.Context, stage Stage, sessionID, deviceID, text string) *Result {
+	result := &Result{Action: ActionAllow, Text: text}
+	if p == nil || strings.TrimSpace(text) == "" {
+		return result
+	}
+
+	start := time.Now()
+	profileName := p.ProfileFor(deviceID)
+	profile := p.profiles[profileName]
+
+	var redactions []string
+	for _, sc := range p.checks {
+		if sc.stages != nil && !sc.stages[stage] {
+			continue
+		}
+		findings, err := sc.check.Check(ctx, text)
+		if err != nil {
+			p.logger.WarnTag("审核", "检查 %s 执行失败: %v", sc.check.Name(), err)
+			observability.RecordMetric(ctx, "moderation_check_errors_total", 1, map[string]string{"check": sc.check.Name()})
+			continue
+		}
+		for _, f := range findings {
+			action := profile.actionFor(f.Category)
+			if action == ActionAllow {
+				continue
+			}
+			result.Findings = append(result.Findings, f)
+			if action.severity() > result.Action.severity() {
+				result.Action = action
+			}
+			if action == ActionRedact {
+				redactions = append(redactions, f.Matches...)
+			}
+		}
+	}
+
+	switch result.Action {
+	case ActionBlock:
+		result.Text = p.blockReply
+	case ActionRedact:
+		for _, m := range redactions {
+			if m != "" {
+				result.Text = strings.ReplaceAll(result.Text, m, redactMask)
+			}
+		}
+	}
+
+	if result.Flagged() {
+		p.publish(stage, sessionID, deviceID, profileName, result)
+	}
+	observability.RecordMetric(ctx, "moderation_latency_ms", float64(time.Since(start).Milliseconds()), map[string]string{"stage": string(stage)})
+	return result
+}
+
+func (p *Pipeline) publish(stage Stage, sessionID, deviceID, profile string, result *Result) {
+	checks := make([]string, 0, len(result.Findings))
+	categories := make([]string, 0, len(result.Findings))
+	for _, f := range result.Findings {
+		checks = append(checks, f.Check)
+		categories = append(categories, f.Category)
+	}
+	observability.RecordMetric(context.Background(), "moderation_flagged_total", 1, map[string]string{
+		"stage":  string(stage),
+		"action": string(result.Action),
+	})
+	eventbus.Publish(eventbus.EventModerationFlagged, eventbus.ModerationEventData{
+		SessionID:  sessionID,
+		DeviceID:   deviceID,
+		Stage:      string(stage),
+		Action:     string(result.Action),
+		Profile:    profile,
+		Checks:     checks,
+		Categories: categories,
+		Timestamp:  time.Now(),
+	})
+}
@@ -0,0 +1,74 @@
+package moderation
+
+import (
+	"context"
+)
+
+// Action 审核命中后的处理动作
+type Action string
+
+const (
+	ActionAllow  Action = "allow"
+	ActionWarn   Action = "warn"   // 放行，仅记录审核事件
+	ActionRedact Action = "redact" // 将命中片段替换为掩码后放行
+	ActionBlock  Action = "block"  // 拦截，改为播报拦截回复
+)
+
+// severity 动作优先级，多个检查命中时取最严格的动作
+func (a Action) severity() int {
+	switch a {
+	case ActionBlock:
+		return 3
+	case ActionRedact:
+		return 2
+	case ActionWarn:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ParseAction 解析动作，无法识别时返回 ActionWarn
+func ParseAction(value string) Action {
+	switch Action(value) {
+	case ActionAllow, ActionWarn, ActionRedact, ActionBlock:
+		return Action(value)
+	default:
+		return ActionWarn
+	}
+}
+
+// Stage 审核阶段
+type Stage string
+
+const (
+	StageInput  Stage = "input"  // 用户输入（ASR结果或文本消息）
+	StageOutput Stage = "output" // LLM输出，TTS之前
+)
+
+// Finding 单个检查的命中结果
+type Finding struct {
+	Check    string   `json:"check"`
+	Category string   `json:"category"`
+	Score    float64  `json:"score,omitempty"`
+	Matches  []string `json:"matches,omitempty"` // 命中的原文片段，redact 时替换
+}
+
+// Result 审核结果
+type Result struct {
+	Action   Action
+	Text     string // 处理后的文本，redact 时为掩码后的文本
+	Findings []Finding
+}
+
+// Flagged 是否有检查命中
+func (r *Result) Flagged() bool {
+	return r != nil && len(r.Findings) > 0
+}
+
+// Check 审核检查
+type Check interface {
+	Name() string
+	// Check 返回命中结果，未命中时返回 nil
+	Check(ctx context.Context, text string) ([]Finding, error)
+}
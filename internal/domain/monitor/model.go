@@ -0,0 +1,79 @@
+package monitor
+
+import (
+	stderrors "errors"
+	"time"
+)
+
+var (
+	// ErrNotFound 会话不存在或已断开
+	ErrNotFound = stderrors.New("session not found")
+	// ErrNotFlagged 会话未标记旁听，不能订阅实时事件
+	ErrNotFlagged = stderrors.New("session not flagged for listen-in")
+)
+
+// Snippet 会话中的一条对话片段
+type Snippet struct {
+	Role    string // user/assistant
+	Content string // 超过 SnippetMaxRunes 的部分截断
+	At      time.Time
+}
+
+// SnippetMaxRunes 对话片段保留的最大字符数
+const SnippetMaxRunes = 200
+
+// Session 在线设备会话的实时状态
+type Session struct {
+	SessionID   string
+	DeviceID    string
+	TenantID    string
+	State       string // idle/listening/thinking/speaking
+	Round       int
+	LLM         string // 当前使用的 LLM 提供者和模型，离线模式下为本地模型
+	TTS         string // 当前使用的 TTS 配置名
+	Voice       string // 当前播报使用的音色
+	ConnectedAt time.Time
+	Recent      []Snippet // 最近的对话片段，按时间顺序
+	Flag        *Flag     // 旁听标记，未标记时为 nil
+}
+
+// Flag 管理员对会话的旁听标记
+type Flag struct {
+	Note      string
+	FlaggedAt time.Time
+}
+
+// EventType 旁听事件类型
+type EventType string
+
+const (
+	EventState   EventType = "state"   // 对话状态变化
+	EventSnippet EventType = "snippet" // 新的对话片段
+	EventClosed  EventType = "closed"  // 会话断开或被终止，之后不再有事件
+)
+
+// Event 旁听会话的实时事件
+type Event struct {
+	Type      EventType
+	SessionID string
+	State     string   // EventState 的新状态
+	Round     int      // EventState 的轮次
+	Snippet   *Snippet // EventSnippet 的对话片段
+	At        time.Time
+}
+
+// Filter 会话查询条件
+type Filter struct {
+	TenantID string
+	DeviceID string
+	State    string
+	Flagged  bool // 只返回已标记旁听的会话
+}
+
+// Source 连接层的在线会话，由 core 实现
+type Source interface {
+	// Sessions 返回全部在线设备会话，不含旁听标记
+	Sessions() []Session
+	// Terminate 断开会话的设备连接，会话不存在时返回 ErrNotFound
+	Terminate(sessionID string) error
+}
@@ -0,0 +1,230 @@
+// Package monitor 在线会话监控，供管理后台的实时视图列出在线设备会话、旁听和终止会话
+package monitor
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+// subscriberBuffer 旁听订阅的缓冲事件数，订阅方读取过慢时丢弃新事件
+const subscriberBuffer = 64
+
+// Service 在线会话监控服务
+// 会话状态由连接层实时提供；旁听标记和订阅只保存在内存中，随会话断开清除
+type Service struct {
+	source Source
+	logger *logging.Logger
+	now    func() time.Time
+
+	mu          sync.RWMutex
+	flags       map[string]Flag
+	subscribers map[string]map[chan Event]struct{}
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局监控服务，供连接层发布旁听事件
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局监控服务，未启用时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建监控服务
+func NewService(source Source, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Service{
+		source:      source,
+		logger:      logger,
+		now:         time.Now,
+		flags:       make(map[string]Flag),
+		subscribers: make(map[string]map[chan Event]struct{}),
+	}
+}
+
+// List 按条件返回在线会话，最近连接的在前
+func (s *Service) List(filter Filter) []Session {
+	sessions := s.source.Sessions()
+	result := make([]Session, 0, len(sessions))
+	s.mu.RLock()
+	for _, session := range sessions {
+		s.attachFlag(&session)
+		if !filter.matches(session) {
+			continue
+		}
+		result = append(result, session)
+	}
+	s.mu.RUnlock()
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ConnectedAt.After(result[j].ConnectedAt)
+	})
+	return result
+}
+
+// Get 返回在线会话，会话不存在时返回 ErrNotFound
+func (s *Service) Get(sessionID string) (Session, error) {
+	for _, session := range s.source.Sessions() {
+		if session.SessionID == sessionID {
+			s.mu.RLock()
+			s.attachFlag(&session)
+			s.mu.RUnlock()
+			return session, nil
+		}
+	}
+	return Session{}, ErrNotFound
+}
+
+// attachFlag 补充旁听标记，调用方持有读锁
+func (s *Service) attachFlag(session *Session) {
+	if flag, ok := s.flags[session.SessionID]; ok {
+		session.Flag = &flag
+	}
+}
+
+func (f Filter) matches(session Session) bool {
+	switch {
+	case f.TenantID != "" && session.TenantID != f.TenantID:
+		return false
+	case f.DeviceID != "" && session.DeviceID != f.DeviceID:
+		return false
+	case f.State != "" && session.State != f.State:
+		return false
+	case f.Flagged && session.Flag == nil:
+		return false
+	}
+	return true
+}
+
+// Flag 标记会话旁听，之后可订阅该会话的实时事件；已标记时更新备注
+func (s *Service) Flag(sessionID, note string) (Session, error) {
+	session, err := s.Get(sessionID)
+	if err != nil {
+		return Session{}, err
+	}
+	s.mu.Lock()
+	flag, ok := s.flags[sessionID]
+	if !ok {
+		flag.FlaggedAt = s.now()
+	}
+	flag.Note = note
+	s.flags[sessionID] = flag
+	s.mu.Unlock()
+
+	session.Flag = &flag
+	s.logger.InfoTag("监控", "会话 %s（设备 %s）已标记旁听", sessionID, session.DeviceID)
+	return session, nil
+}
+
+// Unflag 取消旁听标记，正在旁听的订阅随之结束
+func (s *Service) Unflag(sessionID string) (Session, error) {
+	session, err := s.Get(sessionID)
+	if err != nil {
+		return Session{}, err
+	}
+	s.mu.Lock()
+	delete(s.flags, sessionID)
+	s.closeSubscribers(sessionID)
+	s.mu.Unlock()
+
+	session.Flag = nil
+	s.logger.InfoTag("监控", "会话 %s（设备 %s）已取消旁听", sessionID, session.DeviceID)
+	return session, nil
+}
+
+// Terminate 断开会话的设备连接，旁听标记和订阅在连接层通知会话关闭时清除
+func (s *Service) Terminate(sessionID string) error {
+	if err := s.source.Terminate(sessionID); err != nil {
+		return err
+	}
+	s.logger.InfoTag("监控", "会话 %s 已被管理员终止", sessionID)
+	return nil
+}
+
+// Listening 会话是否已标记旁听，连接层据此决定是否发布事件
+func (s *Service) Listening(sessionID string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.flags[sessionID]
+	return ok
+}
+
+// Subscribe 订阅已标记旁听的会话的实时事件，返回的函数取消订阅
+func (s *Service) Subscribe(sessionID string) (<-chan Event, func(), error) {
+	if _, err := s.Get(sessionID); err != nil {
+		return nil, nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.flags[sessionID]; !ok {
+		return nil, nil, ErrNotFlagged
+	}
+	ch := make(chan Event, subscriberBuffer)
+	if s.subscribers[sessionID] == nil {
+		s.subscribers[sessionID] = make(map[chan Event]struct{})
+	}
+	s.subscribers[sessionID][ch] = struct{}{}
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if subs, ok := s.subscribers[sessionID]; ok {
+				if _, ok := subs[ch]; ok {
+					delete(subs, ch)
+					close(ch)
+				}
+				if len(subs) == 0 {
+					delete(s.subscribers, sessionID)
+				}
+			}
+		})
+	}, nil
+}
+
+// Publish 把事件发给会话的旁听订阅，没有订阅时忽略；订阅方缓冲已满时丢弃
+func (s *Service) Publish(e Event) {
+	if e.At.IsZero() {
+		e.At = s.now()
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for ch := range s.subscribers[e.SessionID] {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+// SessionClosed 会话断开时由连接层调用，通知旁听订阅并清除旁听标记
+func (s *Service) SessionClosed(sessionID string) {
+	s.Publish(Event{Type: EventClosed, SessionID: sessionID})
+	s.mu.Lock()
+	delete(s.flags, sessionID)
+	s.closeSubscribers(sessionID)
+	s.mu.Unlock()
+}
+
+// closeSubscribers 关闭会话的全部订阅，调用方持有写锁
+func (s *Service) closeSubscribers(sessionID string) {
+	for ch := range s.subscribers[sessionID] {
+		close(ch)
+	}
+	delete(s.subscribers, sessionID)
+}
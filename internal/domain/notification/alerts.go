@@ -0,0 +1,69 @@
+package notification
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/eventbus"
+)
+
+// alertCooldown 同一事件和目标的告警在该时间内只发送一次，避免状态抖动时刷屏
+const alertCooldown = 10 * time.Minute
+
+// alertTopics 事件总线告警主题与通知事件、级别、标题的对应关系
+var alertTopics = []struct {
+	topic string
+	event string
+	level Level
+	title string
+}{
+	{eventbus.EventAlertPluginCrashed, EventPluginCrashed, LevelCritical, "插件异常"},
+	{eventbus.EventAlertProviderOutage, EventProviderOutage, LevelCritical, "供应商不可用"},
+	{eventbus.EventAlertBudgetExceeded, EventBudgetExceeded, LevelWarning, "配额已用尽"},
+}
+
+// Alerter 订阅事件总线上的告警事件并转发到订阅了对应事件的渠道
+type Alerter struct {
+	service *Service
+
+	mu       sync.Mutex
+	lastSent map[string]time.Time
+}
+
+// SubscribeAlerts 订阅告警事件，返回的 Alerter 在进程生命周期内有效
+func SubscribeAlerts(service *Service) (*Alerter, error) {
+	a := &Alerter{service: service, lastSent: make(map[string]time.Time)}
+	for _, t := range alertTopics {
+		t := t
+		handler := func(data eventbus.AlertEventData) {
+			a.handle(t.event, t.level, t.title, data)
+		}
+		if err := eventbus.Subscribe(t.topic, handler); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// handle 在事件发布方的协程中调用，发送放到新协程中进行
+func (a *Alerter) handle(event string, level Level, title string, data eventbus.AlertEventData) {
+	key := event + "|" + data.Target
+	now := time.Now()
+	a.mu.Lock()
+	if last, ok := a.lastSent[key]; ok && now.Sub(last) < alertCooldown {
+		a.mu.Unlock()
+		return
+	}
+	a.lastSent[key] = now
+	a.mu.Unlock()
+
+	msg := Message{
+		Title: title + ": " + data.Target,
+		Body:  data.Message,
+		Level: level,
+		Event: event,
+		Data:  data.Data,
+	}
+	go a.service.Dispatch(context.Background(), msg)
+}
@@ -0,0 +1,129 @@
+package notification
+
+import (
+	"strings"
+	"time"
+)
+
+// ChannelType 通知渠道类型
+type ChannelType string
+
+const (
+	ChannelEmail    ChannelType = "email"    // SMTP 邮件
+	ChannelWebhook  ChannelType = "webhook"  // 通用 Webhook，POST JSON
+	ChannelDingTalk ChannelType = "dingtalk" // 钉钉群机器人
+	ChannelFeishu   ChannelType = "feishu"   // 飞书群机器人
+	ChannelTelegram ChannelType = "telegram" // Telegram Bot
+)
+
+// 告警事件，渠道通过 Events 订阅；工作流通知节点直接指定渠道，不经过订阅
+const (
+	EventPluginCrashed  = "plugin_crashed"  // 插件异常退出或健康检查失败
+	EventProviderOutage = "provider_outage" // 供应商健康探测判定不可用
+	EventBudgetExceeded = "budget_exceeded" // 每日配额用尽
+	EventAll            = "*"               // 订阅全部告警事件
+)
+
+// Level 通知级别
+type Level string
+
+const (
+	LevelInfo     Level = "info"
+	LevelWarning  Level = "warning"
+	LevelCritical Level = "critical"
+)
+
+// Channel 通知渠道
+// Config 按渠道类型填写：
+//   - email: host, port, username, password, from, to（逗号分隔或数组）
+//   - webhook: url, method, headers
+//   - dingtalk / feishu: url（机器人 Webhook 地址）, secret（加签密钥，可选）
+//   - telegram: bot_token, chat_id, api_base（可选，默认官方地址）
+type Channel struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	Type      ChannelType            `json:"type"`
+	Config    map[string]interface{} `json:"config"`
+	Events    []string               `json:"events"` // 订阅的告警事件
+	Enabled   bool                   `json:"enabled"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+// Subscribes 渠道是否订阅了告警事件
+func (c *Channel) Subscribes(event string) bool {
+	for _, e := range c.Events {
+		if e == EventAll || e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Message 通知内容
+type Message struct {
+	Title string                 `json:"title"`
+	Body  string                 `json:"body"`
+	Level Level                  `json:"level"`
+	Event string                 `json:"event,omitempty"` // 告警事件，工作流通知为空
+	Data  map[string]interface{} `json:"data,omitempty"`
+}
+
+// Text 标题和正文合并后的纯文本
+func (m Message) Text() string {
+	if m.Title == "" {
+		return m.Body
+	}
+	if m.Body == "" {
+		return m.Title
+	}
+	return m.Title + "\n" + m.Body
+}
+
+// IsValidChannelType 检查渠道类型是否合法
+func IsValidChannelType(t ChannelType) bool {
+	switch t {
+	case ChannelEmail, ChannelWebhook, ChannelDingTalk, ChannelFeishu, ChannelTelegram:
+		return true
+	}
+	return false
+}
+
+// IsValidEvent 检查告警事件是否合法
+func IsValidEvent(event string) bool {
+	switch event {
+	case EventPluginCrashed, EventProviderOutage, EventBudgetExceeded, EventAll:
+		return true
+	}
+	return false
+}
+
+// requiredConfig 各渠道类型的必填配置项
+var requiredConfig = map[ChannelType][]string{
+	ChannelEmail:    {"host", "from", "to"},
+	ChannelWebhook:  {"url"},
+	ChannelDingTalk: {"url"},
+	ChannelFeishu:   {"url"},
+	ChannelTelegram: {"bot_token", "chat_id"},
+}
+
+// secretConfigKeys 返回给接口调用方时需要脱敏的配置项
+var secretConfigKeys = map[string]bool{
+	"password":  true,
+	"secret":    true,
+	"bot_token": true,
+}
+
+// MaskedConfig 返回脱敏后的渠道配置
+func (c *Channel) MaskedConfig() map[string]interface{} {
+	masked := make(map[string]interface{}, len(c.Config))
+	for key, value := range c.Config {
+		if secretConfigKeys[strings.ToLower(key)] {
+			if s, ok := value.(string); ok && s != "" {
+				value = "******"
+			}
+		}
+		masked[key] = value
+	}
+	return masked
+}
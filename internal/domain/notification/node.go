@@ -0,0 +1,113 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/workflow"
+)
+
+// NodeType 工作流通知节点类型
+const NodeType workflow.NodeType = "notification"
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.]+)\s*\}\}`)
+
+// RegisterWorkflowNode 将通知节点注册到工作流节点注册表
+// 节点配置 channels 指定渠道ID，title 和 body 中的 {{name}} 替换为同名输入；
+// 输入中的 title、body 优先于配置
+func RegisterWorkflowNode(nodes *workflow.NodeRegistry, service *Service) error {
+	return nodes.Register(workflow.NodeTypeDefinition{
+		Type:        NodeType,
+		Name:        "通知",
+		Description: "通过邮件、Webhook、钉钉、飞书或 Telegram 发送通知",
+		ConfigSchema: capability.Schema{
+			Type: "object",
+			Properties: map[string]capability.Property{
+				"channels": {Type: "array", Description: "通知渠道ID", Items: &capability.Schema{Type: "string"}},
+				"title":    {Type: "string", Description: "标题，支持 {{输入名}} 占位符"},
+				"body":     {Type: "string", Description: "正文，支持 {{输入名}} 占位符"},
+				"level":    {Type: "string", Description: "通知级别", Enum: []interface{}{"info", "warning", "critical"}, Default: "info"},
+			},
+			Required: []string{"channels"},
+		},
+		InputSchema: capability.Schema{
+			Type: "object",
+			Properties: map[string]capability.Property{
+				"title": {Type: "string", Description: "覆盖配置中的标题"},
+				"body":  {Type: "string", Description: "覆盖配置中的正文"},
+			},
+		},
+		OutputSchema: capability.Schema{
+			Type: "object",
+			Properties: map[string]capability.Property{
+				"sent":   {Type: "integer", Description: "发送成功的渠道数"},
+				"failed": {Type: "array", Description: "发送失败的渠道及原因", Items: &capability.Schema{Type: "object"}},
+			},
+		},
+		UI: &capability.UIHints{Category: "integration", Icon: "bell"},
+	}, workflow.NodeExecutorFunc(func(ctx context.Context, node *workflow.Node, inputs map[string]interface{}) (map[string]interface{}, error) {
+		return executeNode(ctx, service, node, inputs)
+	}))
+}
+
+func executeNode(ctx context.Context, service *Service, node *workflow.Node, inputs map[string]interface{}) (map[string]interface{}, error) {
+	channels := configList(node.Config, "channels")
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("notification node %s has no channels", node.ID)
+	}
+
+	title := configString(node.Config, "title")
+	if v, ok := inputs["title"].(string); ok && v != "" {
+		title = v
+	}
+	body := configString(node.Config, "body")
+	if v, ok := inputs["body"].(string); ok && v != "" {
+		body = v
+	}
+	level := Level(configString(node.Config, "level"))
+	if level == "" {
+		level = LevelInfo
+	}
+	msg := Message{
+		Title: renderTemplate(title, inputs),
+		Body:  renderTemplate(body, inputs),
+		Level: level,
+		Data:  map[string]interface{}{"node_id": node.ID},
+	}
+
+	sent := 0
+	failed := make([]interface{}, 0)
+	for _, id := range channels {
+		if err := service.Send(ctx, id, msg); err != nil {
+			failed = append(failed, map[string]interface{}{"channel": id, "error": err.Error()})
+			continue
+		}
+		sent++
+	}
+	if sent == 0 {
+		return nil, fmt.Errorf("notification failed on all %d channels: %v", len(channels), failed)
+	}
+	return map[string]interface{}{"sent": sent, "failed": failed}, nil
+}
+
+// renderTemplate 将 {{name}} 替换为输入值，支持 a.b 形式访问嵌套对象，缺失的输入替换为空字符串
+func renderTemplate(text string, inputs map[string]interface{}) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+		path := strings.Split(placeholderPattern.FindStringSubmatch(match)[1], ".")
+		var value interface{} = inputs
+		for _, key := range path {
+			m, ok := value.(map[string]interface{})
+			if !ok {
+				return ""
+			}
+			value = m[key]
+		}
+		if value == nil {
+			return ""
+		}
+		return fmt.Sprint(value)
+	})
+}
@@ -0,0 +1,21 @@
+package notification
+
+import "context"
+
+// Repository 通知渠道仓库接口
+type Repository interface {
+	// Save 新建渠道
+	Save(ctx context.Context, c *Channel) error
+
+	// Update 更新渠道
+	Update(ctx context.Context, c *Channel) error
+
+	// FindByID 根据ID查找渠道，不存在时返回 nil
+	FindByID(ctx context.Context, id string) (*Channel, error)
+
+	// List 查询全部渠道
+	List(ctx context.Context) ([]*Channel, error)
+
+	// Delete 删除渠道
+	Delete(ctx context.Context, id string) error
+}
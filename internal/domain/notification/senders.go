@@ -0,0 +1,317 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sender 通知渠道的发送实现
+type Sender interface {
+	Send(ctx context.Context, channel *Channel, msg Message) error
+}
+
+// SenderFunc 函数形式的发送实现
+type SenderFunc func(ctx context.Context, channel *Channel, msg Message) error
+
+// Send 实现 Sender
+func (f SenderFunc) Send(ctx context.Context, channel *Channel, msg Message) error {
+	return f(ctx, channel, msg)
+}
+
+// defaultSenders 返回内置渠道的发送实现
+func defaultSenders(client *http.Client) map[ChannelType]Sender {
+	return map[ChannelType]Sender{
+		ChannelEmail:    SenderFunc(sendEmail),
+		ChannelWebhook:  &webhookSender{client: client},
+		ChannelDingTalk: &dingTalkSender{client: client},
+		ChannelFeishu:   &feishuSender{client: client},
+		ChannelTelegram: &telegramSender{client: client},
+	}
+}
+
+func configString(config map[string]interface{}, key string) string {
+	switch v := config[key].(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	default:
+		return ""
+	}
+}
+
+// configList 读取逗号分隔字符串或数组形式的配置
+func configList(config map[string]interface{}, key string) []string {
+	var items []string
+	switch v := config[key].(type) {
+	case string:
+		items = strings.Split(v, ",")
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				items = append(items, s)
+			}
+		}
+	case []string:
+		items = v
+	}
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// postJSON 发送 JSON 请求，非 2xx 响应返回错误
+func postJSON(ctx context.Context, client *http.Client, method, target string, headers map[string]string, payload interface{}) ([]byte, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return respBody, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return respBody, nil
+}
+
+// webhookSender 通用 Webhook，发送完整的消息 JSON
+type webhookSender struct {
+	client *http.Client
+}
+
+func (s *webhookSender) Send(ctx context.Context, channel *Channel, msg Message) error {
+	method := strings.ToUpper(configString(channel.Config, "method"))
+	if method == "" {
+		method = http.MethodPost
+	}
+	headers := make(map[string]string)
+	if raw, ok := channel.Config["headers"].(map[string]interface{}); ok {
+		for key, value := range raw {
+			if s, ok := value.(string); ok {
+				headers[key] = s
+			}
+		}
+	}
+	payload := map[string]interface{}{
+		"title":     msg.Title,
+		"body":      msg.Body,
+		"level":     msg.Level,
+		"event":     msg.Event,
+		"data":      msg.Data,
+		"channel":   channel.Name,
+		"timestamp": time.Now().Unix(),
+	}
+	_, err := postJSON(ctx, s.client, method, configString(channel.Config, "url"), headers, payload)
+	return err
+}
+
+// dingTalkSender 钉钉群机器人，配置 secret 时按加签方式在地址上附加 timestamp 和 sign
+type dingTalkSender struct {
+	client *http.Client
+}
+
+func (s *dingTalkSender) Send(ctx context.Context, channel *Channel, msg Message) error {
+	target := configString(channel.Config, "url")
+	if secret := configString(channel.Config, "secret"); secret != "" {
+		timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(timestamp + "\n" + secret))
+		sign := url.QueryEscape(base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+		separator := "?"
+		if strings.Contains(target, "?") {
+			separator = "&"
+		}
+		target += separator + "timestamp=" + timestamp + "&sign=" + sign
+	}
+
+	title := msg.Title
+	if title == "" {
+		title = "通知"
+	}
+	payload := map[string]interface{}{
+		"msgtype": "markdown",
+		"markdown": map[string]string{
+			"title": title,
+			"text":  "### " + title + "\n\n" + msg.Body,
+		},
+	}
+	respBody, err := postJSON(ctx, s.client, http.MethodPost, target, nil, payload)
+	if err != nil {
+		return err
+	}
+	return checkBotResponse(respBody, "errcode", "errmsg")
+}
+
+// feishuSender 飞书群机器人，配置 secret 时在消息体中附加 timestamp 和 sign
+type feishuSender struct {
+	client *http.Client
+}
+
+func (s *feishuSender) Send(ctx context.Context, channel *Channel, msg Message) error {
+	payload := map[string]interface{}{
+		"msg_type": "text",
+		"content":  map[string]string{"text": msg.Text()},
+	}
+	if secret := configString(channel.Config, "secret"); secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		mac := hmac.New(sha256.New, []byte(timestamp+"\n"+secret))
+		payload["timestamp"] = timestamp
+		payload["sign"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	}
+	respBody, err := postJSON(ctx, s.client, http.MethodPost, configString(channel.Config, "url"), nil, payload)
+	if err != nil {
+		return err
+	}
+	return checkBotResponse(respBody, "code", "msg")
+}
+
+// checkBotResponse 机器人接口以 HTTP 200 返回业务错误码，非 0 时返回错误
+func checkBotResponse(body []byte, codeKey, msgKey string) error {
+	var resp map[string]interface{}
+	if len(body) == 0 || json.Unmarshal(body, &resp) != nil {
+		return nil
+	}
+	code, _ := resp[codeKey].(float64)
+	if code == 0 {
+		return nil
+	}
+	return fmt.Errorf("bot api error %v: %v", code, resp[msgKey])
+}
+
+// telegramSender Telegram Bot sendMessage
+type telegramSender struct {
+	client *http.Client
+}
+
+func (s *telegramSender) Send(ctx context.Context, channel *Channel, msg Message) error {
+	base := configString(channel.Config, "api_base")
+	if base == "" {
+		base = "https://api.telegram.org"
+	}
+	target := strings.TrimRight(base, "/") + "/bot" + configString(channel.Config, "bot_token") + "/sendMessage"
+	payload := map[string]interface{}{
+		"chat_id": configString(channel.Config, "chat_id"),
+		"text":    msg.Text(),
+	}
+	_, err := postJSON(ctx, s.client, http.MethodPost, target, nil, payload)
+	if err != nil {
+		// 错误信息中的地址包含 bot token，不原样返回
+		return fmt.Errorf("telegram sendMessage failed: %s", strings.ReplaceAll(err.Error(), configString(channel.Config, "bot_token"), "******"))
+	}
+	return nil
+}
+
+// sendEmail 通过 SMTP 发送纯文本邮件，465 端口使用隐式 TLS，其他端口在服务器支持时使用 STARTTLS
+func sendEmail(ctx context.Context, channel *Channel, msg Message) error {
+	host := configString(channel.Config, "host")
+	port := configString(channel.Config, "port")
+	if port == "" {
+		port = "587"
+	}
+	from := configString(channel.Config, "from")
+	to := configList(channel.Config, "to")
+	if len(to) == 0 {
+		return fmt.Errorf("no recipients configured")
+	}
+
+	dialer := &net.Dialer{Timeout: 15 * time.Second}
+	address := net.JoinHostPort(host, port)
+	var conn net.Conn
+	var err error
+	if port == "465" {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, &tls.Config{ServerName: host})
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if port != "465" {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+				return err
+			}
+		}
+	}
+	if username := configString(channel.Config, "username"); username != "" {
+		if err := client.Auth(smtp.PlainAuth("", username, configString(channel.Config, "password"), host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := client.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	subject := "=?UTF-8?B?" + base64.StdEncoding.EncodeToString([]byte(msg.Title)) + "?="
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\nTo: %s\r\nSubject: %s\r\n", from, strings.Join(to, ", "), subject)
+	buf.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\nContent-Transfer-Encoding: base64\r\n\r\n")
+	encoded := base64.StdEncoding.EncodeToString([]byte(msg.Body))
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
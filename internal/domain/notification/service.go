@@ -0,0 +1,268 @@
+package notification
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+// sendTimeout 单个渠道发送的超时时间
+const sendTimeout = 15 * time.Second
+
+// ErrNotFound 通知渠道不存在
+var ErrNotFound = stderrors.New("notification channel not found")
+
+// CreateRequest 创建渠道请求
+type CreateRequest struct {
+	Name    string
+	Type    ChannelType
+	Config  map[string]interface{}
+	Events  []string
+	Enabled bool
+}
+
+// UpdateRequest 更新渠道请求，nil 字段表示不修改
+type UpdateRequest struct {
+	Name    *string
+	Config  map[string]interface{} // 脱敏占位值（******）保留原值
+	Events  []string
+	Enabled *bool
+}
+
+// Service 通知服务，管理渠道配置并向渠道发送通知
+type Service struct {
+	repo   Repository
+	logger *logging.Logger
+	now    func() time.Time
+
+	mu      sync.RWMutex
+	senders map[ChannelType]Sender
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局通知服务，供工作流节点和告警使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局通知服务，未初始化时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建通知服务，client 为 nil 时使用默认 HTTP 客户端
+func NewService(repo Repository, client *http.Client, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	if client == nil {
+		client = &http.Client{Timeout: sendTimeout}
+	}
+	return &Service{
+		repo:    repo,
+		logger:  logger,
+		now:     time.Now,
+		senders: defaultSenders(client),
+	}
+}
+
+// RegisterSender 注册或替换渠道类型的发送实现
+func (s *Service) RegisterSender(t ChannelType, sender Sender) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.senders[t] = sender
+}
+
+// Create 创建渠道
+func (s *Service) Create(ctx context.Context, req CreateRequest) (*Channel, error) {
+	now := s.now()
+	c := &Channel{
+		ID:        uuid.New().String(),
+		Name:      strings.TrimSpace(req.Name),
+		Type:      req.Type,
+		Config:    req.Config,
+		Events:    req.Events,
+		Enabled:   req.Enabled,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := validateChannel(c, "notification.create"); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, c); err != nil {
+		return nil, err
+	}
+	s.logger.InfoTag("通知", "已创建%s渠道 %s(%s)", c.Type, c.Name, c.ID)
+	return c, nil
+}
+
+// Get 获取渠道
+func (s *Service) Get(ctx context.Context, id string) (*Channel, error) {
+	c, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, errors.Wrap(errors.KindDomain, "notification.get", "notification channel not found", ErrNotFound)
+	}
+	return c, nil
+}
+
+// List 获取全部渠道
+func (s *Service) List(ctx context.Context) ([]*Channel, error) {
+	return s.repo.List(ctx)
+}
+
+// Update 更新渠道
+func (s *Service) Update(ctx context.Context, id string, req UpdateRequest) (*Channel, error) {
+	c, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		c.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Config != nil {
+		merged := make(map[string]interface{}, len(req.Config))
+		for key, value := range req.Config {
+			if value == "******" {
+				value = c.Config[key]
+			}
+			merged[key] = value
+		}
+		c.Config = merged
+	}
+	if req.Events != nil {
+		c.Events = req.Events
+	}
+	if req.Enabled != nil {
+		c.Enabled = *req.Enabled
+	}
+	c.UpdatedAt = s.now()
+
+	if err := validateChannel(c, "notification.update"); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Delete 删除渠道
+func (s *Service) Delete(ctx context.Context, id string) error {
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// Send 向指定渠道发送通知，停用的渠道返回错误
+func (s *Service) Send(ctx context.Context, id string, msg Message) error {
+	c, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !c.Enabled {
+		return errors.New(errors.KindDomain, "notification.send", "notification channel is disabled: "+c.Name)
+	}
+	return s.send(ctx, c, msg)
+}
+
+// Test 向渠道发送一条测试通知，停用的渠道也会发送
+func (s *Service) Test(ctx context.Context, id string) error {
+	c, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.send(ctx, c, Message{
+		Title: "测试通知",
+		Body:  fmt.Sprintf("这是一条来自 xiaozhi-server 的测试通知，渠道：%s", c.Name),
+		Level: LevelInfo,
+	})
+}
+
+// Dispatch 向订阅了 msg.Event 的全部启用渠道发送告警，返回发送成功的渠道数
+func (s *Service) Dispatch(ctx context.Context, msg Message) int {
+	channels, err := s.repo.List(ctx)
+	if err != nil {
+		s.logger.WarnTag("通知", "读取通知渠道失败: %v", err)
+		return 0
+	}
+
+	delivered := 0
+	for _, c := range channels {
+		if !c.Enabled || !c.Subscribes(msg.Event) {
+			continue
+		}
+		if err := s.send(ctx, c, msg); err != nil {
+			s.logger.WarnTag("通知", "告警 %s 发送到渠道 %s 失败: %v", msg.Event, c.Name, err)
+			continue
+		}
+		delivered++
+	}
+	return delivered
+}
+
+func (s *Service) send(ctx context.Context, c *Channel, msg Message) error {
+	s.mu.RLock()
+	sender, ok := s.senders[c.Type]
+	s.mu.RUnlock()
+	if !ok {
+		return errors.New(errors.KindDomain, "notification.send", "unsupported channel type: "+string(c.Type))
+	}
+	if msg.Level == "" {
+		msg.Level = LevelInfo
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	if err := sender.Send(ctx, c, msg); err != nil {
+		return errors.Wrap(errors.KindTransport, "notification.send", "failed to send notification via "+c.Name, err)
+	}
+	return nil
+}
+
+// validateChannel 校验渠道名称、类型、必填配置和订阅事件
+func validateChannel(c *Channel, op string) error {
+	if c.Name == "" {
+		return errors.New(errors.KindDomain, op, "name is required")
+	}
+	if !IsValidChannelType(c.Type) {
+		return errors.New(errors.KindDomain, op, "invalid channel type: "+string(c.Type))
+	}
+	if c.Config == nil {
+		c.Config = make(map[string]interface{})
+	}
+	for _, key := range requiredConfig[c.Type] {
+		if configString(c.Config, key) == "" && len(configList(c.Config, key)) == 0 {
+			return errors.New(errors.KindDomain, op, fmt.Sprintf("%s channel requires config %s", c.Type, key))
+		}
+	}
+	if c.Events == nil {
+		c.Events = []string{}
+	}
+	for _, event := range c.Events {
+		if !IsValidEvent(event) {
+			return errors.New(errors.KindDomain, op, "invalid event: "+event)
+		}
+	}
+	return nil
+}
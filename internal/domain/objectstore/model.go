@@ -0,0 +1,82 @@
+// Package objectstore 大文件对象存储
+//
+// 音频、固件和上传的文档按内容的 SHA-256 寻址存放在本地磁盘或 S3 兼容存储中，
+// 相同内容只保存一份。接口和工作流只传递对象ID和带签名的下载地址，
+// 不再把文件内容以 base64 放进 JSON 响应和数据库。
+//
+// 对象有两种生命周期：写入时指定保留时长的临时对象（如 TTS 输出）到期后由清理循环删除；
+// 长期对象（如知识库文档）按引用计数管理，引用方释放后删除。
+package objectstore
+
+import (
+	stderrors "errors"
+	"regexp"
+	"time"
+)
+
+// ErrNotFound 对象不存在
+var ErrNotFound = stderrors.New("object not found")
+
+// 各模块使用的命名空间
+const (
+	NamespaceTTS       = "tts"       // TTS 合成的音频
+	NamespaceASR       = "asr"       // 上传待识别的音频
+	NamespaceOTA       = "ota"       // OTA 固件，名称为 <版本号>.bin
+	NamespaceKnowledge = "knowledge" // 知识库上传的原始文档
+)
+
+// namespacePattern 命名空间只允许小写字母、数字、下划线和连字符
+var namespacePattern = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+// idPattern 对象ID为 SHA-256 的十六进制
+var idPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// Object 对象元数据
+type Object struct {
+	ID          string     `json:"id"`        // 内容的 SHA-256 十六进制
+	Namespace   string     `json:"namespace"` // 首次写入的命名空间
+	Name        string     `json:"name"`      // 最近一次写入时的文件名
+	ContentType string     `json:"content_type"`
+	Size        int64      `json:"size"`
+	Refs        int        `json:"refs"`                 // 长期引用数，为 0 且已过期时删除
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"` // 为空表示不会因到期删除
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// Key 对象在后端中的存放键，按ID前两位分目录
+func (o *Object) Key() string {
+	return objectKey(o.ID)
+}
+
+// expired 对象是否已无引用且过期
+func (o *Object) expired(now time.Time) bool {
+	if o.Refs > 0 {
+		return false
+	}
+	return o.ExpiresAt == nil || !o.ExpiresAt.After(now)
+}
+
+func objectKey(id string) string {
+	return id[:2] + "/" + id
+}
+
+// ValidID 是否为合法的对象ID
+func ValidID(id string) bool {
+	return idPattern.MatchString(id)
+}
+
+// Filter 对象查询条件
+type Filter struct {
+	Namespace string
+	Name      string // 按文件名精确匹配
+	Page      int
+	PageSize  int
+}
+
+// PutOptions 写入选项
+type PutOptions struct {
+	Name        string        // 文件名，用于下载时的文件名和 OTA 固件版本
+	ContentType string        // 为空时按内容检测
+	TTL         time.Duration // 保留时长，为 0 时使用命名空间的默认值；都为 0 时作为长期对象增加一次引用
+}
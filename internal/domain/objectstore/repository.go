@@ -0,0 +1,49 @@
+package objectstore
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Repository 对象元数据仓库接口
+type Repository interface {
+	// Save 保存新对象
+	Save(ctx context.Context, o *Object) error
+
+	// Update 更新对象
+	Update(ctx context.Context, o *Object) error
+
+	// Find 根据ID查找对象，不存在时返回 nil
+	Find(ctx context.Context, id string) (*Object, error)
+
+	// List 按条件分页查询对象，按更新时间倒序
+	List(ctx context.Context, filter Filter) ([]*Object, int64, error)
+
+	// ListExpired 查询无引用且在指定时间之前到期的对象，最多 limit 条
+	ListExpired(ctx context.Context, before time.Time, limit int) ([]*Object, error)
+
+	// Delete 删除对象元数据
+	Delete(ctx context.Context, id string) error
+}
+
+// Backend 对象内容存储后端
+type Backend interface {
+	// Name 后端名称，如 local、s3
+	Name() string
+
+	// Put 写入内容，size 为内容长度
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+
+	// Open 读取内容，不存在时返回包装了 ErrNotFound 的错误
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete 删除内容，不存在时不报错
+	Delete(ctx context.Context, key string) error
+}
+
+// Presigner 可直接签发下载地址的后端，下载不经过本服务转发
+type Presigner interface {
+	// PresignGet 签发有效期为 expires 的下载地址，filename 非空时作为下载文件名
+	PresignGet(key string, expires time.Duration, filename string) (string, error)
+}
@@ -0,0 +1,406 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/observability"
+)
+
+const (
+	cleanupBatchSize = 100
+	// downloadPath 本服务转发下载的路由，对象ID之后为 /content
+	downloadPath = "/api/v1/objects/"
+)
+
+// Service 对象存储服务
+type Service struct {
+	cfg        config.ObjectStoreConfig
+	repo       Repository
+	backend    Backend
+	signingKey []byte
+	logger     *logging.Logger
+	now        func() time.Time
+
+	// mu 串行化同一进程内的元数据读改写，避免相同内容并发写入时重复登记
+	mu sync.Mutex
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局对象存储，供能力执行器使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局对象存储，未启用时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建对象存储服务，未配置签名密钥时随机生成
+func NewService(cfg config.ObjectStoreConfig, repo Repository, backend Backend, logger *logging.Logger) (*Service, error) {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	if cfg.URLExpiry <= 0 {
+		cfg.URLExpiry = time.Hour
+	}
+	if cfg.MaxObjectMB <= 0 {
+		cfg.MaxObjectMB = 100
+	}
+	if cfg.CleanupInterval <= 0 {
+		cfg.CleanupInterval = 10 * time.Minute
+	}
+
+	key := []byte(cfg.SigningKey)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("生成签名密钥失败: %w", err)
+		}
+		logger.WarnTag("对象存储", "未配置 ObjectStore.SigningKey，已随机生成，重启后已签发的下载地址失效")
+	}
+
+	return &Service{
+		cfg:        cfg,
+		repo:       repo,
+		backend:    backend,
+		signingKey: key,
+		logger:     logger,
+		now:        time.Now,
+	}, nil
+}
+
+// Backend 返回后端名称
+func (s *Service) Backend() string {
+	return s.backend.Name()
+}
+
+// MaxObjectSize 返回单个对象的大小上限（字节）
+func (s *Service) MaxObjectSize() int64 {
+	return int64(s.cfg.MaxObjectMB) << 20
+}
+
+// Put 写入对象，内容先落到临时文件计算摘要，相同内容已存在时只更新保留时长或引用数
+func (s *Service) Put(ctx context.Context, namespace string, r io.Reader, opts PutOptions) (*Object, error) {
+	if !namespacePattern.MatchString(namespace) {
+		return nil, errors.New(errors.KindDomain, "objectstore.put", "invalid namespace: "+namespace)
+	}
+
+	tmp, err := os.CreateTemp("", "xiaozhi-object-*")
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "objectstore.put", "failed to create temp file", err)
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+
+	hasher := sha256.New()
+	limit := s.MaxObjectSize()
+	size, err := io.Copy(io.MultiWriter(tmp, hasher), io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "objectstore.put", "failed to buffer object", err)
+	}
+	if size == 0 {
+		return nil, errors.New(errors.KindDomain, "objectstore.put", "object is empty")
+	}
+	if size > limit {
+		return nil, errors.New(errors.KindDomain, "objectstore.put", fmt.Sprintf("object exceeds %d MB", s.cfg.MaxObjectMB))
+	}
+	id := hex.EncodeToString(hasher.Sum(nil))
+
+	contentType := opts.ContentType
+	if contentType == "" {
+		head := make([]byte, 512)
+		n, _ := tmp.ReadAt(head, 0)
+		contentType = http.DetectContentType(head[:n])
+	}
+	ttl := opts.TTL
+	if ttl <= 0 {
+		ttl = s.cfg.TTL[namespace]
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	obj, err := s.repo.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return nil, errors.Wrap(errors.KindStorage, "objectstore.put", "failed to rewind temp file", err)
+		}
+		if err := s.backend.Put(ctx, objectKey(id), tmp, size, contentType); err != nil {
+			return nil, err
+		}
+		obj = &Object{
+			ID:          id,
+			Namespace:   namespace,
+			Name:        opts.Name,
+			ContentType: contentType,
+			Size:        size,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		applyLifecycle(obj, ttl, now)
+		if err := s.repo.Save(ctx, obj); err != nil {
+			if delErr := s.backend.Delete(ctx, obj.Key()); delErr != nil {
+				s.logger.WarnTag("对象存储", "清理未登记的对象 %s 失败: %v", id, delErr)
+			}
+			return nil, err
+		}
+		observability.RecordMetric(ctx, "objectstore.put_bytes", float64(size), map[string]string{"namespace": namespace})
+		s.logger.DebugTag("对象存储", "已写入对象 %s(%s)，%d 字节", id, namespace, size)
+		return obj, nil
+	}
+
+	if opts.Name != "" {
+		obj.Name = opts.Name
+	}
+	applyLifecycle(obj, ttl, now)
+	obj.UpdatedAt = now
+	if err := s.repo.Update(ctx, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// PutBytes 写入内存中的内容
+func (s *Service) PutBytes(ctx context.Context, namespace string, data []byte, opts PutOptions) (*Object, error) {
+	return s.Put(ctx, namespace, bytes.NewReader(data), opts)
+}
+
+// applyLifecycle 临时写入把到期时间延后到 now+ttl，长期写入增加一次引用
+func applyLifecycle(obj *Object, ttl time.Duration, now time.Time) {
+	if ttl <= 0 {
+		obj.Refs++
+		return
+	}
+	expiresAt := now.Add(ttl)
+	if obj.ExpiresAt == nil || obj.ExpiresAt.Before(expiresAt) {
+		obj.ExpiresAt = &expiresAt
+	}
+}
+
+// Get 获取对象元数据
+func (s *Service) Get(ctx context.Context, id string) (*Object, error) {
+	if !ValidID(id) {
+		return nil, errors.Wrap(errors.KindDomain, "objectstore.get", "object not found", ErrNotFound)
+	}
+	obj, err := s.repo.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil || obj.expired(s.now()) {
+		return nil, errors.Wrap(errors.KindDomain, "objectstore.get", "object not found", ErrNotFound)
+	}
+	return obj, nil
+}
+
+// Open 打开对象内容，调用方负责关闭
+func (s *Service) Open(ctx context.Context, id string) (*Object, io.ReadCloser, error) {
+	obj, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	rc, err := s.backend.Open(ctx, obj.Key())
+	if err != nil {
+		return nil, nil, err
+	}
+	return obj, rc, nil
+}
+
+// Read 读取对象的全部内容，用于内容较小或需要整体处理的场景
+func (s *Service) Read(ctx context.Context, id string) ([]byte, error) {
+	_, rc, err := s.Open(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "objectstore.read", "failed to read object", err)
+	}
+	return data, nil
+}
+
+// List 分页查询对象
+func (s *Service) List(ctx context.Context, filter Filter) ([]*Object, int64, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = 20
+	}
+	return s.repo.List(ctx, filter)
+}
+
+// Release 释放一次长期引用，无引用且未设置保留时长或已到期时立即删除
+func (s *Service) Release(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, err := s.repo.Find(ctx, id)
+	if err != nil || obj == nil {
+		return err
+	}
+	if obj.Refs > 0 {
+		obj.Refs--
+	}
+	if obj.expired(s.now()) {
+		return s.remove(ctx, obj)
+	}
+	obj.UpdatedAt = s.now()
+	return s.repo.Update(ctx, obj)
+}
+
+// Delete 强制删除对象，不考虑引用数
+func (s *Service) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, err := s.repo.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+	if obj == nil {
+		return errors.Wrap(errors.KindDomain, "objectstore.delete", "object not found", ErrNotFound)
+	}
+	if err := s.remove(ctx, obj); err != nil {
+		return err
+	}
+	s.logger.InfoTag("对象存储", "已删除对象 %s(%s)", obj.ID, obj.Namespace)
+	return nil
+}
+
+// remove 先删除内容再删除元数据；内容删除失败时保留元数据，便于之后重试
+func (s *Service) remove(ctx context.Context, obj *Object) error {
+	if err := s.backend.Delete(ctx, obj.Key()); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, obj.ID)
+}
+
+// URL 签发下载地址，expires<=0 时使用默认有效期；后端支持预签名时直接返回后端地址
+func (s *Service) URL(obj *Object, expires time.Duration) (string, time.Time, error) {
+	if expires <= 0 {
+		expires = s.cfg.URLExpiry
+	}
+	expiresAt := s.now().Add(expires)
+	if presigner, ok := s.backend.(Presigner); ok {
+		u, err := presigner.PresignGet(obj.Key(), expires, obj.Name)
+		return u, expiresAt, err
+	}
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	query.Set("signature", s.sign(obj.ID, expiresAt.Unix()))
+	base := strings.TrimRight(s.cfg.PublicURL, "/")
+	return base + downloadPath + obj.ID + "/content?" + query.Encode(), expiresAt, nil
+}
+
+// Verify 校验本服务签发的下载地址
+func (s *Service) Verify(id, expires, signature string) error {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return errors.New(errors.KindDomain, "objectstore.verify", "invalid expires")
+	}
+	if s.now().Unix() > unix {
+		return errors.New(errors.KindDomain, "objectstore.verify", "download url has expired")
+	}
+	expected, err := hex.DecodeString(s.sign(id, unix))
+	if err != nil {
+		return err
+	}
+	actual, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, actual) {
+		return errors.New(errors.KindDomain, "objectstore.verify", "invalid signature")
+	}
+	return nil
+}
+
+func (s *Service) sign(id string, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(id + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Run 启动到期对象的清理循环，直到 ctx 结束
+func (s *Service) Run(ctx context.Context) error {
+	s.logger.InfoTag("对象存储", "对象存储已启动，后端 %s，清理间隔 %s", s.backend.Name(), s.cfg.CleanupInterval)
+	ticker := time.NewTicker(s.cfg.CleanupInterval)
+	defer ticker.Stop()
+
+	s.cleanup(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.cleanup(ctx)
+		}
+	}
+}
+
+// cleanup 删除无引用且已到期的对象
+func (s *Service) cleanup(ctx context.Context) {
+	var removed int
+	for ctx.Err() == nil {
+		items, err := s.repo.ListExpired(ctx, s.now(), cleanupBatchSize)
+		if err != nil {
+			s.logger.ErrorTag("对象存储", "查询到期对象失败: %v", err)
+			return
+		}
+		failed := 0
+		for _, obj := range items {
+			s.mu.Lock()
+			// 查询之后可能有新的写入延长了保留时长
+			current, err := s.repo.Find(ctx, obj.ID)
+			if err == nil && current != nil && current.expired(s.now()) {
+				err = s.remove(ctx, current)
+				if err == nil {
+					removed++
+				}
+			}
+			s.mu.Unlock()
+			if err != nil {
+				failed++
+				s.logger.WarnTag("对象存储", "删除到期对象 %s 失败: %v", obj.ID, err)
+			}
+		}
+		// 删除失败的对象仍会被查出，避免在同一轮里反复重试
+		if len(items) < cleanupBatchSize || failed > 0 {
+			break
+		}
+	}
+	if removed > 0 {
+		s.logger.InfoTag("对象存储", "已清理 %d 个到期对象", removed)
+	}
+}
@@ -0,0 +1,121 @@
+package offline
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"strings"
+
+	"xiaozhi-server-go/internal/platform/config"
+)
+
+// defaultEndpoints 配置中没有服务地址的云端服务按类型使用的默认地址
+var defaultEndpoints = map[Kind]map[string]string{
+	KindASR: {
+		"doubao":   "openspeech.bytedance.com:443",
+		"deepgram": "api.deepgram.com:443",
+		"stepfun":  "api.stepfun.com:443",
+	},
+	KindLLM: {
+		"openai": "api.openai.com:443",
+		"doubao": "ark.cn-beijing.volces.com:443",
+		"coze":   "api.coze.cn:443",
+	},
+	KindTTS: {
+		"doubao":   "openspeech.bytedance.com:443",
+		"edge":     "speech.platform.bing.com:443",
+		"deepgram": "api.deepgram.com:443",
+	},
+}
+
+// Prober 探测服务地址（host:port）是否可达，返回 nil 表示可达
+type Prober interface {
+	Probe(ctx context.Context, endpoint string) error
+}
+
+// DialProber 以 TCP 连接探测，域名解析失败或连接超时都视为不可达
+type DialProber struct{}
+
+// Probe 建立一次 TCP 连接后立即关闭
+func (DialProber) Probe(ctx context.Context, endpoint string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", endpoint)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// ResolveEndpoint 取配置名称对应服务的地址（host:port），无法确定时返回空字符串
+func ResolveEndpoint(cfg *config.Config, kind Kind, name string) string {
+	switch kind {
+	case KindASR:
+		data, ok := cfg.ASR[name].(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		for _, key := range []string{"addr", "url", "base_url"} {
+			if raw, ok := data[key].(string); ok {
+				if endpoint := hostPort(raw); endpoint != "" {
+					return endpoint
+				}
+			}
+		}
+		providerType, _ := data["type"].(string)
+		return defaultEndpoints[KindASR][providerType]
+	case KindLLM:
+		c, ok := cfg.LLM[name]
+		if !ok {
+			return ""
+		}
+		if endpoint := hostPort(c.BaseURL); endpoint != "" {
+			return endpoint
+		}
+		return defaultEndpoints[KindLLM][c.Type]
+	case KindTTS:
+		c, ok := cfg.TTS[name]
+		if !ok {
+			return ""
+		}
+		// 豆包的 Cluster 是集群名而非地址，只有带协议的才当作地址
+		if endpoint := hostPort(c.Cluster); endpoint != "" {
+			return endpoint
+		}
+		return defaultEndpoints[KindTTS][c.Type]
+	}
+	return ""
+}
+
+// hostPort 从 URL 中取出 host:port，未写端口时按协议补全，不是 URL 时返回空字符串
+func hostPort(raw string) string {
+	if !strings.Contains(raw, "://") {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "https", "wss":
+			port = "443"
+		default:
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// isLocal 地址是否为本机或局域网，这类服务不依赖外网，无需监控
+func isLocal(endpoint string) bool {
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && (ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast())
+}
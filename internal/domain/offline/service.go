@@ -0,0 +1,324 @@
+package offline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/eventbus"
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+// Kind 可切换到本地服务的类型
+type Kind string
+
+const (
+	KindASR Kind = "asr"
+	KindLLM Kind = "llm"
+	KindTTS Kind = "tts"
+)
+
+func (k Kind) label() string {
+	return strings.ToUpper(string(k))
+}
+
+// Announcer 向所有在线设备播报，返回播报成功的设备数
+type Announcer interface {
+	Announce(ctx context.Context, text string) int
+}
+
+// target 一个被监控的云端服务及其本地替代
+type target struct {
+	kind     Kind
+	name     string // 云端服务配置名称
+	endpoint string
+	fallback string // 本地服务配置名称
+	local    string // 本地服务地址，进入离线模式时检查，为空表示无法确定
+
+	successes int
+	failures  int
+	degraded  bool
+	lastError string
+}
+
+// Service 离线模式监控
+//
+// 按全局选择的 ASR、LLM、TTS 探测云端服务，某类服务连续失败 FailureThreshold 次后
+// 切换到配置的本地服务，连续成功 SuccessThreshold 次后切回。只要有一类服务已切换即处于离线模式，
+// 进入和退出离线模式时发布事件并向在线设备播报。连接在每轮对话开始前按 Generation 判断是否需要重新选择服务。
+type Service struct {
+	cfg       config.OfflineConfig
+	prober    Prober
+	announcer Announcer
+	logger    *logging.Logger
+	now       func() time.Time
+
+	mu         sync.RWMutex
+	targets    []*target
+	generation uint64
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局离线模式监控，供连接处理器使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局离线模式监控，未启用时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建离线模式监控，prober 为 nil 时使用 TCP 连接探测，announcer 为 nil 时不播报
+func NewService(cfg *config.Config, prober Prober, announcer Announcer, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	if prober == nil {
+		prober = DialProber{}
+	}
+	offlineCfg := cfg.Offline
+	if offlineCfg.Interval <= 0 {
+		offlineCfg.Interval = 30 * time.Second
+	}
+	if offlineCfg.Timeout <= 0 {
+		offlineCfg.Timeout = 5 * time.Second
+	}
+	if offlineCfg.FailureThreshold <= 0 {
+		offlineCfg.FailureThreshold = 2
+	}
+	if offlineCfg.SuccessThreshold <= 0 {
+		offlineCfg.SuccessThreshold = 2
+	}
+	s := &Service{
+		cfg:       offlineCfg,
+		prober:    prober,
+		announcer: announcer,
+		logger:    logger,
+		now:       time.Now,
+	}
+	selected := map[Kind]string{KindASR: cfg.Selected.ASR, KindLLM: cfg.Selected.LLM, KindTTS: cfg.Selected.TTS}
+	fallbacks := map[Kind]string{KindASR: offlineCfg.ASR, KindLLM: offlineCfg.LLM, KindTTS: offlineCfg.TTS}
+	for _, kind := range []Kind{KindASR, KindLLM, KindTTS} {
+		if t := s.newTarget(cfg, kind, selected[kind], fallbacks[kind]); t != nil {
+			s.targets = append(s.targets, t)
+		}
+	}
+	return s
+}
+
+// newTarget 检查云端服务和本地替代，不需要或无法监控时返回 nil
+func (s *Service) newTarget(cfg *config.Config, kind Kind, name, fallback string) *target {
+	if name == "" || fallback == "" || name == fallback {
+		return nil
+	}
+	if !hasConfig(cfg, kind, fallback) {
+		s.logger.WarnTag("离线模式", "本地%s配置 %s 不存在，%s 不会切换", kind.label(), fallback, name)
+		return nil
+	}
+	endpoint := ResolveEndpoint(cfg, kind, name)
+	if endpoint == "" {
+		s.logger.WarnTag("离线模式", "无法确定%s服务 %s 的地址，不做探测", kind.label(), name)
+		return nil
+	}
+	if isLocal(endpoint) {
+		return nil
+	}
+	return &target{
+		kind:     kind,
+		name:     name,
+		endpoint: endpoint,
+		fallback: fallback,
+		local:    ResolveEndpoint(cfg, kind, fallback),
+	}
+}
+
+func hasConfig(cfg *config.Config, kind Kind, name string) bool {
+	var ok bool
+	switch kind {
+	case KindASR:
+		_, ok = cfg.ASR[name]
+	case KindLLM:
+		_, ok = cfg.LLM[name]
+	case KindTTS:
+		_, ok = cfg.TTS[name]
+	}
+	return ok
+}
+
+// Run 周期性探测云端服务，直到 ctx 取消
+func (s *Service) Run(ctx context.Context) error {
+	if len(s.targets) == 0 {
+		s.logger.InfoTag("离线模式", "所选服务均为本地服务或未配置本地替代，无需监控")
+		return nil
+	}
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	s.Check(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.Check(ctx)
+		}
+	}
+}
+
+// Check 探测一次全部云端服务并按结果切换
+func (s *Service) Check(ctx context.Context) {
+	// 同一地址（如豆包的 ASR 和 TTS）只探测一次
+	results := make(map[string]error)
+	for _, t := range s.targets {
+		if _, done := results[t.endpoint]; done {
+			continue
+		}
+		results[t.endpoint] = s.probe(ctx, t.endpoint)
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	s.mu.Lock()
+	wasActive := s.activeLocked()
+	changed := false
+	var switched []*target
+	for _, t := range s.targets {
+		err := results[t.endpoint]
+		if err != nil {
+			t.failures++
+			t.successes = 0
+			t.lastError = err.Error()
+			if !t.degraded && t.failures >= s.cfg.FailureThreshold {
+				t.degraded = true
+				changed = true
+				switched = append(switched, t)
+				s.logger.WarnTag("离线模式", "%s服务 %s 不可用（%s），切换到本地服务 %s", t.kind.label(), t.name, t.lastError, t.fallback)
+			}
+			continue
+		}
+		t.successes++
+		t.failures = 0
+		t.lastError = ""
+		if t.degraded && t.successes >= s.cfg.SuccessThreshold {
+			t.degraded = false
+			changed = true
+			s.logger.InfoTag("离线模式", "%s服务 %s 已恢复，切回云端服务", t.kind.label(), t.name)
+		}
+	}
+	if changed {
+		s.generation++
+	}
+	active := s.activeLocked()
+	degraded, failures := s.summaryLocked()
+	s.mu.Unlock()
+
+	for _, t := range switched {
+		s.checkLocal(ctx, t)
+	}
+	switch {
+	case active && !wasActive:
+		eventbus.Publish(eventbus.EventOfflineEntered, eventbus.OfflineEventData{
+			Degraded:  degraded,
+			Failures:  failures,
+			Timestamp: s.now(),
+		})
+		s.announce(ctx, s.cfg.Announcement)
+	case !active && wasActive:
+		eventbus.Publish(eventbus.EventOfflineExited, eventbus.OfflineEventData{
+			Degraded:  degraded,
+			Timestamp: s.now(),
+		})
+		s.announce(ctx, s.cfg.RecoveredAnnouncement)
+	}
+}
+
+// checkLocal 切换后检查本地服务是否可达，不可达时只记录日志，仍然切换
+func (s *Service) checkLocal(ctx context.Context, t *target) {
+	if t.local == "" {
+		return
+	}
+	if err := s.probe(ctx, t.local); err != nil {
+		s.logger.ErrorTag("离线模式", "本地%s服务 %s（%s）也不可达: %v", t.kind.label(), t.fallback, t.local, err)
+	}
+}
+
+func (s *Service) probe(ctx context.Context, endpoint string) error {
+	probeCtx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+	return s.prober.Probe(probeCtx, endpoint)
+}
+
+func (s *Service) announce(ctx context.Context, text string) {
+	if s.announcer == nil || text == "" {
+		return
+	}
+	count := s.announcer.Announce(ctx, text)
+	s.logger.InfoTag("离线模式", "已向 %d 台在线设备播报: %s", count, text)
+}
+
+// Active 是否处于离线模式
+func (s *Service) Active() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.activeLocked()
+}
+
+// Select 返回该类服务当前应使用的本地服务配置名称，未切换时返回空字符串
+func (s *Service) Select(kind Kind) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, t := range s.targets {
+		if t.kind == kind && t.degraded {
+			return t.fallback
+		}
+	}
+	return ""
+}
+
+// Generation 切换状态的版本号，每次有服务切换或切回时递增
+func (s *Service) Generation() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.generation
+}
+
+// Announcement 进入离线模式时的播报文本
+func (s *Service) Announcement() string {
+	return s.cfg.Announcement
+}
+
+func (s *Service) activeLocked() bool {
+	for _, t := range s.targets {
+		if t.degraded {
+			return true
+		}
+	}
+	return false
+}
+
+// summaryLocked 已切换的服务类型和探测失败的服务
+func (s *Service) summaryLocked() (degraded []string, failures []string) {
+	degraded = []string{}
+	for _, t := range s.targets {
+		if t.degraded {
+			degraded = append(degraded, string(t.kind))
+		}
+		if t.lastError != "" {
+			failures = append(failures, fmt.Sprintf("%s %s: %s", t.kind, t.name, t.lastError))
+		}
+	}
+	return degraded, failures
+}
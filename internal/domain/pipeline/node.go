@@ -0,0 +1,89 @@
+package pipeline
+
+import (
+	"context"
+
+	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/workflow"
+)
+
+// textInputSchema 内置节点的输入：上游节点处理后的 text，缺省为原始的 user_text
+var textInputSchema = capability.Schema{
+	Type: "object",
+	Properties: map[string]capability.Property{
+		OutputText:    {Type: "string", Description: "上游处理后的用户文本"},
+		InputUserText: {Type: "string", Description: "识别出的原始用户文本"},
+		OutputBlocked: {Type: "boolean", Description: "上游已拦截时直接跳过"},
+	},
+}
+
+// RegisterWorkflowNodes 将输入审核和意图路由注册为工作流节点
+// 节点在对话流水线之外执行（没有当前轮次）时原样传递文本
+func RegisterWorkflowNodes(nodes *workflow.NodeRegistry) error {
+	if err := nodes.Register(workflow.NodeTypeDefinition{
+		Type:        NodeModeration,
+		Name:        "输入审核",
+		Description: "按设备所属租户的审核策略检查用户输入，拦截时输出 blocked",
+		InputSchema: textInputSchema,
+		OutputSchema: capability.Schema{
+			Type: "object",
+			Properties: map[string]capability.Property{
+				OutputText:    {Type: "string", Description: "审核后的文本，命中脱敏规则时已替换"},
+				OutputBlocked: {Type: "boolean", Description: "输入被拦截"},
+			},
+		},
+		UI: &capability.UIHints{Category: "conversation", Icon: "shield"},
+	}, workflow.NodeExecutorFunc(executeModeration)); err != nil {
+		return err
+	}
+
+	return nodes.Register(workflow.NodeTypeDefinition{
+		Type:        NodeIntent,
+		Name:        "意图路由",
+		Description: "时间、计时器、设备控制等确定性意图直接回复，命中时不再调用 LLM",
+		InputSchema: textInputSchema,
+		OutputSchema: capability.Schema{
+			Type: "object",
+			Properties: map[string]capability.Property{
+				OutputText:  {Type: "string", Description: "用户文本，原样传递"},
+				OutputReply: {Type: "string", Description: "命中意图时的回复"},
+			},
+		},
+		UI: &capability.UIHints{Category: "conversation", Icon: "route"},
+	}, workflow.NodeExecutorFunc(executeIntent))
+}
+
+func executeModeration(ctx context.Context, node *workflow.Node, inputs map[string]interface{}) (map[string]interface{}, error) {
+	text := inputText(inputs)
+	if blocked, _ := inputs[OutputBlocked].(bool); blocked {
+		return map[string]interface{}{OutputText: text, OutputBlocked: true}, nil
+	}
+	turn := TurnFrom(ctx)
+	if turn == nil {
+		return map[string]interface{}{OutputText: text, OutputBlocked: false}, nil
+	}
+	text, blocked := turn.ModerateInput(ctx, text)
+	return map[string]interface{}{OutputText: text, OutputBlocked: blocked}, nil
+}
+
+func executeIntent(ctx context.Context, node *workflow.Node, inputs map[string]interface{}) (map[string]interface{}, error) {
+	text := inputText(inputs)
+	outputs := map[string]interface{}{OutputText: text}
+	if blocked, _ := inputs[OutputBlocked].(bool); blocked {
+		return outputs, nil
+	}
+	if turn := TurnFrom(ctx); turn != nil {
+		if reply, handled := turn.RouteIntent(ctx, text); handled {
+			outputs[OutputReply] = reply
+		}
+	}
+	return outputs, nil
+}
+
+func inputText(inputs map[string]interface{}) string {
+	if text, _ := inputs[OutputText].(string); text != "" {
+		return text
+	}
+	text, _ := inputs[InputUserText].(string)
+	return text
+}
@@ -0,0 +1,116 @@
+// Package pipeline 对话流水线
+//
+// 识别出的用户文本在送入 LLM 之前，按设备所属的流水线执行一个工作流图。图中可以使用任意
+// 工作流节点（转换、HTTP、脚本、插件节点等），内置的输入审核和意图路由也以节点形式提供，
+// 部署时插入检索增强、翻译等步骤无需修改核心代码。
+//
+// 节点之间通过约定的输出名传递结果：text 替换用户文本，context 作为本轮参考资料附加给 LLM，
+// reply 直接作为回复播报且不再调用 LLM，blocked 为 true 时拦截本轮输入。
+package pipeline
+
+import (
+	"context"
+	"time"
+
+	"xiaozhi-server-go/internal/workflow"
+)
+
+// 内置节点类型
+const (
+	NodeModeration workflow.NodeType = "conversation.moderation" // 输入审核
+	NodeIntent     workflow.NodeType = "conversation.intent"     // 确定性意图路由
+)
+
+// 流水线的执行输入
+const (
+	InputUserText  = "user_text" // 识别出的原始用户文本
+	InputDeviceID  = "device_id"
+	InputSessionID = "session_id"
+)
+
+// 节点的约定输出
+const (
+	OutputText    = "text"    // 替换用户文本
+	OutputContext = "context" // 本轮附加给 LLM 的参考资料
+	OutputReply   = "reply"   // 直接回复，不再调用 LLM
+	OutputBlocked = "blocked" // 拦截本轮输入
+)
+
+// defaultTimeout 工作流未设置超时时单轮流水线的执行上限
+const defaultTimeout = 10 * time.Second
+
+// Turn 当前对话轮次，由连接实现，供内置节点调用连接上的审核和意图路由
+type Turn interface {
+	// ModerateInput 审核用户输入，返回处理后的文本，blocked 为 true 表示拦截
+	ModerateInput(ctx context.Context, text string) (string, bool)
+	// RouteIntent 由确定性处理器处理，handled 为 true 时返回回复
+	RouteIntent(ctx context.Context, text string) (string, bool)
+}
+
+type turnKey struct{}
+
+// WithTurn 将当前轮次放入上下文
+func WithTurn(ctx context.Context, turn Turn) context.Context {
+	return context.WithValue(ctx, turnKey{}, turn)
+}
+
+// TurnFrom 取出上下文中的当前轮次，不在对话中执行时返回 nil
+func TurnFrom(ctx context.Context) Turn {
+	turn, _ := ctx.Value(turnKey{}).(Turn)
+	return turn
+}
+
+// Input 一轮对话的流水线输入
+type Input struct {
+	Text      string
+	DeviceID  string
+	SessionID string
+	Turn      Turn
+}
+
+// Result 流水线执行结果
+type Result struct {
+	Profile string // 使用的流水线名称
+	Text    string // 送入 LLM 的用户文本
+	Context string // 附加给 LLM 的参考资料
+	Reply   string // 非空时直接播报，不调用 LLM
+	Blocked bool   // 输入被拦截
+}
+
+// DefaultWorkflow 内置流水线：输入审核 -> 意图路由，与未配置流水线时的处理一致
+func DefaultWorkflow() *workflow.Workflow {
+	textInputs := []workflow.InputSchema{
+		{Name: OutputText, Type: "string"},
+		{Name: InputUserText, Type: "string"},
+	}
+	return &workflow.Workflow{
+		ID:          "conversation-pipeline",
+		Name:        "对话流水线",
+		Description: "输入审核 -> 意图路由",
+		Version:     "1.0.0",
+		Config:      workflow.WorkflowConfig{Timeout: defaultTimeout, ParallelLimit: 1},
+		Nodes: []workflow.Node{
+			{
+				ID: "start", Name: "开始", Type: workflow.NodeTypeStart,
+				Inputs:   []workflow.InputSchema{{Name: InputUserText, Type: "string", Required: true}},
+				Position: workflow.Position{X: 0, Y: 100},
+			},
+			{
+				ID: "moderation", Name: "输入审核", Type: NodeModeration,
+				Inputs:   textInputs,
+				Position: workflow.Position{X: 200, Y: 100},
+			},
+			{
+				ID: "intent", Name: "意图路由", Type: NodeIntent,
+				Inputs:   append(textInputs, workflow.InputSchema{Name: OutputBlocked, Type: "boolean"}),
+				Position: workflow.Position{X: 400, Y: 100},
+			},
+			{ID: "end", Name: "结束", Type: workflow.NodeTypeEnd, Position: workflow.Position{X: 600, Y: 100}},
+		},
+		Edges: []workflow.Edge{
+			{ID: "e1", From: "start", To: "moderation"},
+			{ID: "e2", From: "moderation", To: "intent"},
+			{ID: "e3", From: "intent", To: "end"},
+		},
+	}
+}
@@ -0,0 +1,167 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/workflow"
+)
+
+// defaultProfile 未单独配置的设备使用的流水线
+const defaultProfile = "default"
+
+// compiled 已校验的流水线及其节点执行顺序
+type compiled struct {
+	workflow *workflow.Workflow
+	order    []string
+}
+
+// Service 按设备选择并执行对话流水线
+type Service struct {
+	executor workflow.WorkflowExecutor
+	profiles map[string]*compiled
+	devices  map[string]string
+	logger   *logging.Logger
+}
+
+var (
+	defaultService   *Service
+	defaultServiceMu sync.RWMutex
+)
+
+// SetDefault 设置全局对话流水线服务
+func SetDefault(s *Service) {
+	defaultServiceMu.Lock()
+	defer defaultServiceMu.Unlock()
+	defaultService = s
+}
+
+// Default 返回全局对话流水线服务，未配置时为 nil
+func Default() *Service {
+	defaultServiceMu.RLock()
+	defer defaultServiceMu.RUnlock()
+	return defaultService
+}
+
+// NewService 加载并校验配置中的流水线，加载失败的流水线跳过并记录日志，使用它的设备退回内置流程
+func NewService(cfg config.PipelineConfig, executor workflow.WorkflowExecutor, logger *logging.Logger) *Service {
+	s := &Service{
+		executor: executor,
+		profiles: make(map[string]*compiled),
+		devices:  cfg.DeviceProfiles,
+		logger:   logger,
+	}
+	dag := workflow.NewDAGEngine(logger)
+	for name, profile := range cfg.Profiles {
+		wf, err := loadWorkflow(profile)
+		if err == nil {
+			err = dag.ValidateWorkflow(wf)
+		}
+		var order []string
+		if err == nil {
+			order, err = dag.TopologicalSort(wf.Nodes, wf.Edges)
+		}
+		if err != nil {
+			logger.ErrorTag("流水线", "加载对话流水线 %s 失败，使用该流水线的设备退回内置流程: %v", name, err)
+			continue
+		}
+		s.profiles[name] = &compiled{workflow: wf, order: order}
+	}
+	return s
+}
+
+func loadWorkflow(profile config.PipelineProfile) (*workflow.Workflow, error) {
+	if strings.TrimSpace(profile.Workflow) == "" {
+		return DefaultWorkflow(), nil
+	}
+	data, err := os.ReadFile(profile.Workflow)
+	if err != nil {
+		return nil, err
+	}
+	var wf workflow.Workflow
+	if err := json.Unmarshal(data, &wf); err != nil {
+		return nil, fmt.Errorf("解析 %s 失败: %w", profile.Workflow, err)
+	}
+	if wf.Config.Timeout <= 0 {
+		wf.Config.Timeout = defaultTimeout
+	}
+	return &wf, nil
+}
+
+// Profiles 返回已加载的流水线名称
+func (s *Service) Profiles() []string {
+	names := make([]string, 0, len(s.profiles))
+	for name := range s.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ProfileFor 返回设备使用的流水线名称，设备没有可用的流水线时 ok 为 false
+func (s *Service) ProfileFor(deviceID string) (string, bool) {
+	if name, exists := s.devices[deviceID]; exists {
+		if _, ok := s.profiles[name]; ok {
+			return name, true
+		}
+	}
+	if _, ok := s.profiles[defaultProfile]; ok {
+		return defaultProfile, true
+	}
+	return "", false
+}
+
+// Run 为一轮对话执行设备所属的流水线
+func (s *Service) Run(ctx context.Context, in Input) (*Result, error) {
+	name, ok := s.ProfileFor(in.DeviceID)
+	if !ok {
+		return nil, fmt.Errorf("设备 %s 没有可用的对话流水线", in.DeviceID)
+	}
+	profile := s.profiles[name]
+
+	execution, err := s.executor.Run(WithTurn(ctx, in.Turn), profile.workflow, map[string]interface{}{
+		InputUserText:  in.Text,
+		InputDeviceID:  in.DeviceID,
+		InputSessionID: in.SessionID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("对话流水线 %s 执行失败: %w", name, err)
+	}
+	result := collect(profile.order, execution, in.Text)
+	result.Profile = name
+	return result, nil
+}
+
+// collect 按执行顺序汇总各节点的约定输出：text 以最后一个为准，context 依次拼接，
+// reply 以第一个为准，任一节点拦截即拦截
+func collect(order []string, execution *workflow.Execution, text string) *Result {
+	result := &Result{Text: text}
+	var contexts []string
+	for _, nodeID := range order {
+		node, ok := execution.NodeResults[nodeID]
+		if !ok || node.Status != workflow.NodeStatusCompleted {
+			continue
+		}
+		if v, _ := node.Outputs[OutputText].(string); strings.TrimSpace(v) != "" {
+			result.Text = v
+		}
+		if v, _ := node.Outputs[OutputContext].(string); strings.TrimSpace(v) != "" {
+			contexts = append(contexts, strings.TrimSpace(v))
+		}
+		if v, _ := node.Outputs[OutputReply].(string); result.Reply == "" && strings.TrimSpace(v) != "" {
+			result.Reply = v
+		}
+		if v, _ := node.Outputs[OutputBlocked].(bool); v {
+			result.Blocked = true
+		}
+	}
+	result.Context = strings.Join(contexts, "\n\n")
+	return result
+}
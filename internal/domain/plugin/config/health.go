@@ -0,0 +1,462 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/eventbus"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+// HealthCheckRecord 健康探测记录
+type HealthCheckRecord struct {
+	ID               int          `json:"id" gorm:"primaryKey"`
+	ProviderConfigID int          `json:"providerConfigId" gorm:"not null;index"`
+	Success          bool         `json:"success" gorm:"index"`
+	LatencyMs        int64        `json:"latencyMs"`
+	Error            string       `json:"error,omitempty" gorm:"type:text"`
+	Status           HealthStatus `json:"status" gorm:"type:varchar(50)"` // 本次探测后的健康状态
+	CreatedAt        time.Time    `json:"createdAt" gorm:"autoCreateTime;index"`
+}
+
+// TableName 指定表名
+func (HealthCheckRecord) TableName() string {
+	return "plugin_provider_health_checks"
+}
+
+// HealthCheckFilter 健康探测记录过滤器
+type HealthCheckFilter struct {
+	Success   *bool     `json:"success"`
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+	Page      int       `json:"page"`
+	PageSize  int       `json:"pageSize"`
+}
+
+// HealthCheckList 健康探测记录列表
+type HealthCheckList struct {
+	Total        int64               `json:"total"`
+	Page         int                 `json:"page"`
+	PageSize     int                 `json:"pageSize"`
+	TotalPages   int64               `json:"totalPages"`
+	SuccessRate  float64             `json:"successRate"`  // 过滤范围内的成功率
+	AvgLatencyMs float64             `json:"avgLatencyMs"` // 过滤范围内成功探测的平均延迟
+	Records      []HealthCheckRecord `json:"records"`
+}
+
+// HealthProbe 对单个供应商配置执行一次探测，返回 nil 表示健康
+type HealthProbe interface {
+	Probe(ctx context.Context, providerConfig *ProviderConfig, config map[string]interface{}) error
+}
+
+// HealthAlerter 健康状态变化通知
+type HealthAlerter interface {
+	HealthChanged(ctx context.Context, providerConfig *ProviderConfig, from, to HealthStatus, lastError string)
+}
+
+// HealthProberConfig 健康探测配置
+type HealthProberConfig struct {
+	Interval         time.Duration // 探测周期
+	Timeout          time.Duration // 单次探测超时
+	FailureThreshold int           // 连续失败多少次判定为不健康
+	SuccessThreshold int           // 连续成功多少次恢复为健康
+	Retention        time.Duration // 探测记录保留时长
+}
+
+// DefaultHealthProberConfig 默认健康探测配置
+func DefaultHealthProberConfig() HealthProberConfig {
+	return HealthProberConfig{
+		Interval:         time.Minute,
+		Timeout:          15 * time.Second,
+		FailureThreshold: 3,
+		SuccessThreshold: 2,
+		Retention:        7 * 24 * time.Hour,
+	}
+}
+
+// healthStreak 连续探测结果计数
+type healthStreak struct {
+	successes int
+	failures  int
+}
+
+// HealthProber 后台健康探测器，周期性探测启用的供应商配置并按滞后规则更新健康状态
+type HealthProber struct {
+	db        *gorm.DB
+	logger    *logging.Logger
+	encryptor *ConfigEncryptor
+	probe     HealthProbe
+	alerter   HealthAlerter
+	cfg       HealthProberConfig
+
+	mu      sync.Mutex
+	streaks map[int]*healthStreak
+}
+
+// NewHealthProber 创建健康探测器，probe 为 nil 时通过能力注册表探测，alerter 为 nil 时仅记录日志
+func NewHealthProber(
+	db *gorm.DB,
+	logger *logging.Logger,
+	encryptor *ConfigEncryptor,
+	registry *capability.Registry,
+	probe HealthProbe,
+	alerter HealthAlerter,
+	cfg HealthProberConfig,
+) *HealthProber {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	defaults := DefaultHealthProberConfig()
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaults.Interval
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaults.Timeout
+	}
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaults.FailureThreshold
+	}
+	if cfg.SuccessThreshold <= 0 {
+		cfg.SuccessThreshold = defaults.SuccessThreshold
+	}
+	if cfg.Retention <= 0 {
+		cfg.Retention = defaults.Retention
+	}
+	if probe == nil {
+		probe = &capabilityProbe{registry: registry}
+	}
+	if alerter == nil {
+		alerter = &logAlerter{logger: logger}
+	}
+	return &HealthProber{
+		db:        db,
+		logger:    logger,
+		encryptor: encryptor,
+		probe:     probe,
+		alerter:   alerter,
+		cfg:       cfg,
+		streaks:   make(map[int]*healthStreak),
+	}
+}
+
+// Run 周期性探测，直到 ctx 取消
+func (p *HealthProber) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+
+	p.probeAll(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			p.probeAll(ctx)
+			p.prune(ctx)
+		}
+	}
+}
+
+// ProbeNow 立即探测指定供应商配置
+func (p *HealthProber) ProbeNow(ctx context.Context, providerConfigID int) (*HealthCheckRecord, error) {
+	var providerConfig ProviderConfig
+	if err := p.db.WithContext(ctx).Preload("Capabilities").First(&providerConfig, providerConfigID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New(errors.KindDomain, "plugin_health.probe", "provider config not found")
+		}
+		return nil, errors.Wrap(errors.KindStorage, "plugin_health.probe", "failed to get provider config", err)
+	}
+	return p.probeOne(ctx, &providerConfig)
+}
+
+// probeAll 探测全部启用的供应商配置
+func (p *HealthProber) probeAll(ctx context.Context) {
+	var configs []ProviderConfig
+	if err := p.db.WithContext(ctx).Preload("Capabilities").Where("enabled = ?", true).Find(&configs).Error; err != nil {
+		p.logger.WarnTag("健康检查", "查询供应商配置失败: %v", err)
+		return
+	}
+	for i := range configs {
+		if ctx.Err() != nil {
+			return
+		}
+		if _, err := p.probeOne(ctx, &configs[i]); err != nil {
+			p.logger.WarnTag("健康检查", "记录供应商 %s 探测结果失败: %v", configs[i].ProviderName, err)
+		}
+	}
+}
+
+// probeOne 探测单个配置，记录结果并更新健康状态
+func (p *HealthProber) probeOne(ctx context.Context, providerConfig *ProviderConfig) (*HealthCheckRecord, error) {
+	start := time.Now()
+	probeErr := p.runProbe(ctx, providerConfig)
+	record := &HealthCheckRecord{
+		ProviderConfigID: providerConfig.ID,
+		Success:          probeErr == nil,
+		LatencyMs:        time.Since(start).Milliseconds(),
+		CreatedAt:        time.Now(),
+	}
+	if probeErr != nil {
+		record.Error = probeErr.Error()
+	}
+
+	previous := providerConfig.HealthStatus
+	record.Status = p.nextStatus(providerConfig.ID, previous, record.Success)
+
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(record).Error; err != nil {
+			return err
+		}
+		return tx.Model(&ProviderConfig{}).Where("id = ?", providerConfig.ID).Updates(map[string]interface{}{
+			"health_status":     record.Status,
+			"last_health_check": record.CreatedAt,
+		}).Error
+	})
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "plugin_health.record", "failed to record health check", err)
+	}
+
+	providerConfig.HealthStatus = record.Status
+	providerConfig.LastHealthCheck = &record.CreatedAt
+	if previous != record.Status {
+		p.alerter.HealthChanged(ctx, providerConfig, previous, record.Status, record.Error)
+		if record.Status == HealthStatusUnhealthy {
+			eventbus.Publish(eventbus.EventAlertProviderOutage, eventbus.AlertEventData{
+				Target:  providerConfig.ProviderName,
+				Message: record.Error,
+				Data: map[string]interface{}{
+					"provider_config_id": providerConfig.ID,
+					"provider_type":      string(providerConfig.ProviderType),
+					"previous_status":    string(previous),
+				},
+				Timestamp: record.CreatedAt,
+			})
+		}
+	}
+	return record, nil
+}
+
+// runProbe 解密配置并在超时内执行探测
+func (p *HealthProber) runProbe(ctx context.Context, providerConfig *ProviderConfig) error {
+	config := make(map[string]interface{})
+	if p.encryptor != nil && providerConfig.ConfigData != "" {
+		plaintext, err := p.encryptor.Decrypt(providerConfig.ConfigData)
+		if err != nil {
+			return fmt.Errorf("decrypt config: %w", err)
+		}
+		if err := json.Unmarshal([]byte(plaintext), &config); err != nil {
+			return fmt.Errorf("decode config: %w", err)
+		}
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, p.cfg.Timeout)
+	defer cancel()
+	return p.probe.Probe(probeCtx, providerConfig, config)
+}
+
+// nextStatus 按滞后规则计算新状态：未知状态下首次结果即生效，
+// 之后需连续失败/成功达到阈值才切换，避免偶发错误导致状态抖动
+func (p *HealthProber) nextStatus(id int, current HealthStatus, success bool) HealthStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	streak, ok := p.streaks[id]
+	if !ok {
+		streak = &healthStreak{}
+		p.streaks[id] = streak
+	}
+	if success {
+		streak.successes++
+		streak.failures = 0
+	} else {
+		streak.failures++
+		streak.successes = 0
+	}
+
+	switch {
+	case current == "" || current == HealthStatusUnknown:
+		if success {
+			return HealthStatusHealthy
+		}
+		return HealthStatusUnhealthy
+	case current == HealthStatusHealthy && streak.failures >= p.cfg.FailureThreshold:
+		return HealthStatusUnhealthy
+	case current == HealthStatusUnhealthy && streak.successes >= p.cfg.SuccessThreshold:
+		return HealthStatusHealthy
+	default:
+		return current
+	}
+}
+
+// prune 清理过期的探测记录
+func (p *HealthProber) prune(ctx context.Context) {
+	cutoff := time.Now().Add(-p.cfg.Retention)
+	if err := p.db.WithContext(ctx).Where("created_at < ?", cutoff).Delete(&HealthCheckRecord{}).Error; err != nil {
+		p.logger.WarnTag("健康检查", "清理过期探测记录失败: %v", err)
+	}
+}
+
+// GetHealthHistory 分页获取供应商配置的健康探测记录
+func (s *pluginConfigServiceImpl) GetHealthHistory(ctx context.Context, providerConfigID int, filter *HealthCheckFilter) (*HealthCheckList, error) {
+	if _, err := s.GetProviderConfig(ctx, providerConfigID); err != nil {
+		return nil, err
+	}
+	if filter == nil {
+		filter = &HealthCheckFilter{}
+	}
+
+	query := s.db.WithContext(ctx).Model(&HealthCheckRecord{}).Where("provider_config_id = ?", providerConfigID)
+	if !filter.StartDate.IsZero() {
+		query = query.Where("created_at >= ?", filter.StartDate)
+	}
+	if !filter.EndDate.IsZero() {
+		query = query.Where("created_at <= ?", filter.EndDate)
+	}
+
+	var summary struct {
+		Total      int64
+		Successes  int64
+		AvgLatency *float64
+	}
+	if err := query.Session(&gorm.Session{}).
+		Select("COUNT(*) AS total, SUM(CASE WHEN success THEN 1 ELSE 0 END) AS successes, AVG(CASE WHEN success THEN latency_ms END) AS avg_latency").
+		Scan(&summary).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "plugin_health.history", "failed to summarize health checks", err)
+	}
+
+	if filter.Success != nil {
+		query = query.Where("success = ?", *filter.Success)
+	}
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "plugin_health.history", "failed to count health checks", err)
+	}
+
+	page, pageSize := normalizePage(filter.Page, filter.PageSize)
+	records := make([]HealthCheckRecord, 0)
+	if err := query.Order("created_at DESC, id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&records).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "plugin_health.history", "failed to list health checks", err)
+	}
+
+	list := &HealthCheckList{
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+		Records:    records,
+	}
+	if summary.Total > 0 {
+		list.SuccessRate = float64(summary.Successes) / float64(summary.Total)
+	}
+	if summary.AvgLatency != nil {
+		list.AvgLatencyMs = *summary.AvgLatency
+	}
+	return list, nil
+}
+
+// capabilityProbe 通过能力注册表执行轻量调用的默认探测实现
+type capabilityProbe struct {
+	registry *capability.Registry
+}
+
+// Probe 对配置的每个启用能力执行一次最小调用，ASR/工具类能力仅校验执行器可创建
+func (c *capabilityProbe) Probe(ctx context.Context, providerConfig *ProviderConfig, config map[string]interface{}) error {
+	if c.registry == nil {
+		return fmt.Errorf("capability registry not available")
+	}
+	enabled := providerConfig.GetEnabledCapabilities()
+	if len(enabled) == 0 {
+		return fmt.Errorf("no enabled capability")
+	}
+
+	for _, capItem := range enabled {
+		if err := ProbeCapability(ctx, c.registry, capItem.CapabilityID, capItem.CapabilityType, config); err != nil {
+			return fmt.Errorf("%s: %w", capItem.CapabilityID, err)
+		}
+	}
+	return nil
+}
+
+// ProbeCapability 使用给定配置对单个能力执行一次最小调用，ASR/工具类能力仅校验执行器可创建
+func ProbeCapability(ctx context.Context, registry *capability.Registry, capabilityID string, capabilityType CapabilityType, config map[string]interface{}) error {
+	exec, err := registry.GetExecutor(capabilityID)
+	if err != nil {
+		return err
+	}
+
+	var inputs map[string]interface{}
+	switch capabilityType {
+	case CapabilityTypeLLM:
+		inputs = map[string]interface{}{
+			"messages": []interface{}{
+				map[string]interface{}{"role": "user", "content": "ping"},
+			},
+		}
+	case CapabilityTypeTTS:
+		inputs = map[string]interface{}{"text": "测试"}
+	case CapabilityTypeTranslation:
+		inputs = map[string]interface{}{"text": "测试", "source": "zh", "target": "en"}
+	case CapabilityTypeEmbedding:
+		inputs = map[string]interface{}{"texts": []interface{}{"测试"}}
+	case CapabilityTypeSearch, CapabilityTypeMedia:
+		inputs = map[string]interface{}{"query": "测试", "count": 1}
+	case CapabilityTypeSmartHome:
+		inputs = map[string]interface{}{"operation": "discover"}
+	default:
+		return nil
+	}
+	return probeExecute(capability.WithPriority(ctx, capability.PriorityBatch), exec, config, inputs)
+}
+
+// probeExecute 优先使用流式接口（部分LLM执行器仅支持流式），读到首个分片即视为成功
+func probeExecute(ctx context.Context, exec capability.Executor, config, inputs map[string]interface{}) error {
+	stream, ok := exec.(capability.StreamExecutor)
+	if !ok {
+		_, err := exec.Execute(ctx, config, inputs)
+		return err
+	}
+
+	ch, err := stream.ExecuteStream(ctx, config, inputs)
+	if err != nil {
+		return err
+	}
+	// 无论结果如何都排空通道，避免生产者阻塞
+	defer func() {
+		go func() {
+			for range ch {
+			}
+		}()
+	}()
+	select {
+	case chunk, ok := <-ch:
+		if !ok {
+			return fmt.Errorf("empty response")
+		}
+		if errMsg, _ := chunk["error"].(string); errMsg != "" {
+			return fmt.Errorf("%s", errMsg)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// logAlerter 默认告警实现，仅记录日志
+type logAlerter struct {
+	logger *logging.Logger
+}
+
+// HealthChanged 记录健康状态变化
+func (a *logAlerter) HealthChanged(ctx context.Context, providerConfig *ProviderConfig, from, to HealthStatus, lastError string) {
+	if to == HealthStatusUnhealthy {
+		a.logger.ErrorTag("健康检查", "供应商 %s(%d) 状态 %s -> %s: %s", providerConfig.ProviderName, providerConfig.ID, from, to, lastError)
+		return
+	}
+	a.logger.InfoTag("健康检查", "供应商 %s(%d) 状态 %s -> %s", providerConfig.ProviderName, providerConfig.ID, from, to)
+}
@@ -25,10 +25,15 @@ const (
 type CapabilityType string
 
 const (
-	CapabilityTypeLLM  CapabilityType = "llm"  // 大语言模型能力
-	CapabilityTypeASR  CapabilityType = "asr"  // 语音识别能力
-	CapabilityTypeTTS  CapabilityType = "tts"  // 文字转语音能力
-	CapabilityTypeTool CapabilityType = "tool" // 工具能力
+	CapabilityTypeLLM         CapabilityType = "llm"         // 大语言模型能力
+	CapabilityTypeASR         CapabilityType = "asr"         // 语音识别能力
+	CapabilityTypeTTS         CapabilityType = "tts"         // 文字转语音能力
+	CapabilityTypeTool        CapabilityType = "tool"        // 工具能力
+	CapabilityTypeTranslation CapabilityType = "translation" // 文本翻译能力
+	CapabilityTypeEmbedding   CapabilityType = "embedding"   // 文本向量化能力
+	CapabilityTypeSearch      CapabilityType = "search"      // 网页搜索能力
+	CapabilityTypeMedia       CapabilityType = "media"       // 媒体检索能力
+	CapabilityTypeSmartHome   CapabilityType = "smart_home"  // 智能家居桥接能力
 )
 
 // HealthStatus 健康状态
@@ -50,6 +55,8 @@ const (
 	OperationEnable  HistoryOperation = "enable"  // 启用
 	OperationDisable HistoryOperation = "disable" // 禁用
 	OperationTest    HistoryOperation = "test"    // 测试
+	OperationSnapshot HistoryOperation = "snapshot" // 创建快照
+	OperationRestore  HistoryOperation = "restore"  // 恢复快照
 )
 
 // ProviderConfig 供应商配置聚合根
@@ -115,6 +122,16 @@ type ConfigHistory struct {
 	UserAgent       string           `json:"userAgent" gorm:"type:text"`
 	IPAddress       string           `json:"ipAddress" gorm:"type:varchar(45)"`
 	CreatedAt       time.Time        `json:"createdAt" gorm:"autoCreateTime;index"`
+
+	// Changes 由 OldData 与 NewData 比对得出，查询历史时填充
+	Changes []FieldChange `json:"changes,omitempty" gorm:"-"`
+}
+
+// FieldChange 单个字段的变更
+type FieldChange struct {
+	Field    string      `json:"field"`
+	OldValue interface{} `json:"oldValue,omitempty"`
+	NewValue interface{} `json:"newValue,omitempty"`
 }
 
 // TableName 指定表名
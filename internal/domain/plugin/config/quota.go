@@ -0,0 +1,384 @@
+package config
+
+import (
+	"context"
+	stderrors "errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"xiaozhi-server-go/internal/domain/eventbus"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+// QuotaScope 配额作用范围
+type QuotaScope string
+
+const (
+	QuotaScopeProvider   QuotaScope = "provider"   // 按提供者类型限制
+	QuotaScopeCapability QuotaScope = "capability" // 按能力ID限制
+)
+
+// defaultQuotaRefresh 限制器重新加载策略的周期
+const defaultQuotaRefresh = 30 * time.Second
+
+// QuotaPolicy 配额策略，各项上限为 0 表示不限制
+type QuotaPolicy struct {
+	ID                int        `json:"id" gorm:"primaryKey"`
+	Scope             QuotaScope `json:"scope" gorm:"type:varchar(20);not null;uniqueIndex:idx_quota_scope_target"`
+	Target            string     `json:"target" gorm:"type:varchar(255);not null;uniqueIndex:idx_quota_scope_target"`
+	MaxConcurrency    int        `json:"maxConcurrency" gorm:"default:0"`
+	DailyRequests     int64      `json:"dailyRequests" gorm:"default:0"`
+	DailyTokens       int64      `json:"dailyTokens" gorm:"default:0"`
+	DailyAudioMinutes float64    `json:"dailyAudioMinutes" gorm:"default:0"`
+	Enabled           bool       `json:"enabled" gorm:"default:true"`
+	CreatedAt         time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time  `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// TableName 指定表名
+func (QuotaPolicy) TableName() string {
+	return "plugin_quota_policies"
+}
+
+// QuotaUsage 每日用量
+type QuotaUsage struct {
+	ID           int        `json:"-" gorm:"primaryKey"`
+	Scope        QuotaScope `json:"scope" gorm:"type:varchar(20);not null;uniqueIndex:idx_quota_usage_key"`
+	Target       string     `json:"target" gorm:"type:varchar(255);not null;uniqueIndex:idx_quota_usage_key"`
+	Day          string     `json:"day" gorm:"type:varchar(10);not null;uniqueIndex:idx_quota_usage_key"` // YYYY-MM-DD
+	Requests     int64      `json:"requests"`
+	Tokens       int64      `json:"tokens"`
+	AudioSeconds float64    `json:"audioSeconds"`
+	InFlight     int        `json:"inFlight,omitempty" gorm:"-"` // 仅限制器内存中有效
+
+	alerted bool // 今日已发布配额用尽告警
+}
+
+// TableName 指定表名
+func (QuotaUsage) TableName() string {
+	return "plugin_quota_usage"
+}
+
+// QuotaPolicyRequest 设置配额策略请求
+type QuotaPolicyRequest struct {
+	Scope             QuotaScope `json:"scope"`
+	Target            string     `json:"target"`
+	MaxConcurrency    int        `json:"maxConcurrency"`
+	DailyRequests     int64      `json:"dailyRequests"`
+	DailyTokens       int64      `json:"dailyTokens"`
+	DailyAudioMinutes float64    `json:"dailyAudioMinutes"`
+	Enabled           *bool      `json:"enabled"`
+}
+
+// SetQuotaPolicy 创建或更新配额策略
+func (s *pluginConfigServiceImpl) SetQuotaPolicy(ctx context.Context, req *QuotaPolicyRequest) (*QuotaPolicy, error) {
+	if req.Scope != QuotaScopeProvider && req.Scope != QuotaScopeCapability {
+		return nil, errors.New(errors.KindDomain, "plugin_quota.set", "scope must be provider or capability")
+	}
+	if strings.TrimSpace(req.Target) == "" {
+		return nil, errors.New(errors.KindDomain, "plugin_quota.set", "target cannot be empty")
+	}
+	if req.MaxConcurrency < 0 || req.DailyRequests < 0 || req.DailyTokens < 0 || req.DailyAudioMinutes < 0 {
+		return nil, errors.New(errors.KindDomain, "plugin_quota.set", "limits cannot be negative")
+	}
+
+	var policy QuotaPolicy
+	err := s.db.WithContext(ctx).Where("scope = ? AND target = ?", req.Scope, req.Target).First(&policy).Error
+	if err != nil && !stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.Wrap(errors.KindStorage, "plugin_quota.set", "failed to get quota policy", err)
+	}
+	if err != nil {
+		policy = QuotaPolicy{Scope: req.Scope, Target: req.Target, Enabled: true}
+	}
+	policy.MaxConcurrency = req.MaxConcurrency
+	policy.DailyRequests = req.DailyRequests
+	policy.DailyTokens = req.DailyTokens
+	policy.DailyAudioMinutes = req.DailyAudioMinutes
+	if req.Enabled != nil {
+		policy.Enabled = *req.Enabled
+	}
+
+	if err := s.db.WithContext(ctx).Save(&policy).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "plugin_quota.set", "failed to save quota policy", err)
+	}
+	s.logger.Info("Plugin quota policy saved: %s/%s", policy.Scope, policy.Target)
+	return &policy, nil
+}
+
+// GetQuotaPolicies 获取全部配额策略
+func (s *pluginConfigServiceImpl) GetQuotaPolicies(ctx context.Context) ([]QuotaPolicy, error) {
+	policies := make([]QuotaPolicy, 0)
+	if err := s.db.WithContext(ctx).Order("scope ASC, target ASC").Find(&policies).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "plugin_quota.list", "failed to list quota policies", err)
+	}
+	return policies, nil
+}
+
+// DeleteQuotaPolicy 删除配额策略
+func (s *pluginConfigServiceImpl) DeleteQuotaPolicy(ctx context.Context, id int) error {
+	result := s.db.WithContext(ctx).Delete(&QuotaPolicy{}, id)
+	if result.Error != nil {
+		return errors.Wrap(errors.KindStorage, "plugin_quota.delete", "failed to delete quota policy", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(errors.KindDomain, "plugin_quota.delete", "quota policy not found")
+	}
+	return nil
+}
+
+// GetQuotaUsage 获取指定日期（YYYY-MM-DD，空表示今天）的用量
+func (s *pluginConfigServiceImpl) GetQuotaUsage(ctx context.Context, day string) ([]QuotaUsage, error) {
+	return QueryQuotaUsage(ctx, s.db, day, nil)
+}
+
+// QueryQuotaUsage 查询指定日期（YYYY-MM-DD，空表示今天）的用量，targets 不为空时只返回这些目标的记录
+func QueryQuotaUsage(ctx context.Context, db *gorm.DB, day string, targets []string) ([]QuotaUsage, error) {
+	if day == "" {
+		day = time.Now().Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", day); err != nil {
+		return nil, errors.New(errors.KindDomain, "plugin_quota.usage", "day must be in YYYY-MM-DD format")
+	}
+	query := db.WithContext(ctx).Where("day = ?", day)
+	if len(targets) > 0 {
+		query = query.Where("target IN ?", targets)
+	}
+	usage := make([]QuotaUsage, 0)
+	if err := query.Order("scope ASC, target ASC").Find(&usage).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "plugin_quota.usage", "failed to list quota usage", err)
+	}
+	return usage, nil
+}
+
+// QuotaLimiter 基于配额策略的 capability.Limiter 实现
+// 并发计数保存在内存中；每日用量在内存中累计并逐次写回数据库，重启后从数据库恢复
+type QuotaLimiter struct {
+	db      *gorm.DB
+	logger  *logging.Logger
+	refresh time.Duration
+	now     func() time.Time
+
+	mu            sync.Mutex
+	loadedAt      time.Time
+	policies      map[string]QuotaPolicy
+	providerTypes map[int]ProviderType
+	day           string
+	usage         map[string]*QuotaUsage
+}
+
+// NewQuotaLimiter 创建配额限制器，refresh 不大于 0 时使用默认周期
+func NewQuotaLimiter(db *gorm.DB, logger *logging.Logger, refresh time.Duration) *QuotaLimiter {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	if refresh <= 0 {
+		refresh = defaultQuotaRefresh
+	}
+	return &QuotaLimiter{
+		db:            db,
+		logger:        logger,
+		refresh:       refresh,
+		now:           time.Now,
+		policies:      make(map[string]QuotaPolicy),
+		providerTypes: make(map[int]ProviderType),
+		usage:         make(map[string]*QuotaUsage),
+	}
+}
+
+// Reload 立即重新加载策略和今日用量
+func (l *QuotaLimiter) Reload(ctx context.Context) error {
+	var policies []QuotaPolicy
+	if err := l.db.WithContext(ctx).Where("enabled = ?", true).Find(&policies).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "plugin_quota.reload", "failed to load quota policies", err)
+	}
+	var configs []ProviderConfig
+	if err := l.db.WithContext(ctx).Select("id", "provider_type").Find(&configs).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "plugin_quota.reload", "failed to load provider configs", err)
+	}
+	day := l.now().Format("2006-01-02")
+	var rows []QuotaUsage
+	if err := l.db.WithContext(ctx).Where("day = ?", day).Find(&rows).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "plugin_quota.reload", "failed to load quota usage", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.policies = make(map[string]QuotaPolicy, len(policies))
+	for _, p := range policies {
+		l.policies[quotaKey(p.Scope, p.Target)] = p
+	}
+	l.providerTypes = make(map[int]ProviderType, len(configs))
+	for _, c := range configs {
+		l.providerTypes[c.ID] = c.ProviderType
+	}
+
+	// 并发计数以内存为准，用量取数据库与内存中的较大值，避免覆盖尚未写回的增量
+	l.rollDay(day)
+	for _, row := range rows {
+		u := l.usageFor(row.Scope, row.Target)
+		u.Requests = max(u.Requests, row.Requests)
+		u.Tokens = max(u.Tokens, row.Tokens)
+		u.AudioSeconds = max(u.AudioSeconds, row.AudioSeconds)
+	}
+	l.loadedAt = l.now()
+	return nil
+}
+
+// Acquire 实现 capability.Limiter
+func (l *QuotaLimiter) Acquire(ctx context.Context, providerID string, def capability.Definition) (func(capability.Usage), error) {
+	// 先占用本轮刷新，避免并发调用或加载失败时反复访问数据库
+	l.mu.Lock()
+	stale := l.now().Sub(l.loadedAt) >= l.refresh
+	if stale {
+		l.loadedAt = l.now()
+	}
+	l.mu.Unlock()
+	if stale {
+		if err := l.Reload(ctx); err != nil {
+			// 策略加载失败时沿用旧策略，不阻断调用
+			l.logger.WarnTag("配额", "加载配额策略失败: %v", err)
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.rollDay(now.Format("2006-01-02"))
+
+	matched := make([]QuotaPolicy, 0, 2)
+	if p, ok := l.policies[quotaKey(QuotaScopeProvider, l.providerTarget(providerID))]; ok {
+		matched = append(matched, p)
+	}
+	if p, ok := l.policies[quotaKey(QuotaScopeCapability, def.ID)]; ok {
+		matched = append(matched, p)
+	}
+	if len(matched) == 0 {
+		return func(capability.Usage) {}, nil
+	}
+
+	untilTomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location()).Sub(now)
+	for _, p := range matched {
+		u := l.usageFor(p.Scope, p.Target)
+		exceeded := &capability.QuotaExceededError{Scope: string(p.Scope), Target: p.Target, RetryAfter: untilTomorrow}
+		switch {
+		case p.MaxConcurrency > 0 && u.InFlight >= p.MaxConcurrency:
+			exceeded.Kind, exceeded.Limit, exceeded.RetryAfter = capability.QuotaConcurrency, float64(p.MaxConcurrency), time.Second
+		case p.DailyRequests > 0 && u.Requests >= p.DailyRequests:
+			exceeded.Kind, exceeded.Limit = capability.QuotaRequests, float64(p.DailyRequests)
+		case p.DailyTokens > 0 && u.Tokens >= p.DailyTokens:
+			exceeded.Kind, exceeded.Limit = capability.QuotaTokens, float64(p.DailyTokens)
+		case p.DailyAudioMinutes > 0 && u.AudioSeconds >= p.DailyAudioMinutes*60:
+			exceeded.Kind, exceeded.Limit = capability.QuotaAudioMinutes, p.DailyAudioMinutes
+		default:
+			continue
+		}
+		if exceeded.Kind != capability.QuotaConcurrency && !u.alerted {
+			u.alerted = true
+			eventbus.Publish(eventbus.EventAlertBudgetExceeded, eventbus.AlertEventData{
+				Target:  string(p.Scope) + ":" + p.Target,
+				Message: exceeded.Error(),
+				Data: map[string]interface{}{
+					"scope": string(p.Scope),
+					"kind":  string(exceeded.Kind),
+					"limit": exceeded.Limit,
+					"day":   l.day,
+				},
+				Timestamp: now,
+			})
+		}
+		return nil, exceeded
+	}
+
+	for _, p := range matched {
+		u := l.usageFor(p.Scope, p.Target)
+		u.InFlight++
+		u.Requests++
+	}
+
+	var once sync.Once
+	return func(usage capability.Usage) {
+		once.Do(func() { l.release(matched, usage) })
+	}, nil
+}
+
+// release 结算一次调用并写回数据库
+func (l *QuotaLimiter) release(matched []QuotaPolicy, usage capability.Usage) {
+	l.mu.Lock()
+	day := l.day
+	for _, p := range matched {
+		u := l.usageFor(p.Scope, p.Target)
+		if u.InFlight > 0 {
+			u.InFlight--
+		}
+		u.Tokens += usage.Tokens
+		u.AudioSeconds += usage.AudioSeconds
+	}
+	l.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for _, p := range matched {
+		row := &QuotaUsage{Scope: p.Scope, Target: p.Target, Day: day, Requests: 1, Tokens: usage.Tokens, AudioSeconds: usage.AudioSeconds}
+		err := l.db.WithContext(ctx).Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "scope"}, {Name: "target"}, {Name: "day"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"requests":      gorm.Expr("plugin_quota_usage.requests + ?", row.Requests),
+				"tokens":        gorm.Expr("plugin_quota_usage.tokens + ?", row.Tokens),
+				"audio_seconds": gorm.Expr("plugin_quota_usage.audio_seconds + ?", row.AudioSeconds),
+			}),
+		}).Create(row).Error
+		if err != nil {
+			l.logger.WarnTag("配额", "写回 %s/%s 用量失败: %v", p.Scope, p.Target, err)
+		}
+	}
+}
+
+// rollDay 跨天时清零用量，仅保留进行中的并发计数，调用方需持有锁
+func (l *QuotaLimiter) rollDay(day string) {
+	if l.day == day {
+		return
+	}
+	previous := l.usage
+	l.day = day
+	l.usage = make(map[string]*QuotaUsage, len(previous))
+	for _, u := range previous {
+		if u.InFlight > 0 {
+			l.usageFor(u.Scope, u.Target).InFlight = u.InFlight
+		}
+	}
+}
+
+// usageFor 返回今日用量计数，调用方需持有锁
+func (l *QuotaLimiter) usageFor(scope QuotaScope, target string) *QuotaUsage {
+	key := quotaKey(scope, target)
+	u, ok := l.usage[key]
+	if !ok {
+		u = &QuotaUsage{Scope: scope, Target: target, Day: l.day}
+		l.usage[key] = u
+	}
+	return u
+}
+
+// providerTarget 将注册表中的提供者ID映射为提供者类型，数据库配置注册为 config:<id>
+func (l *QuotaLimiter) providerTarget(providerID string) string {
+	if idStr, ok := strings.CutPrefix(providerID, "config:"); ok {
+		if id, err := strconv.Atoi(idStr); err == nil {
+			if t, ok := l.providerTypes[id]; ok {
+				return string(t)
+			}
+		}
+	}
+	return providerID
+}
+
+func quotaKey(scope QuotaScope, target string) string {
+	return string(scope) + "|" + target
+}
@@ -0,0 +1,191 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+// defaultReconcileInterval 默认轮询周期
+const defaultReconcileInterval = 10 * time.Second
+
+// ChangeNotifier 配置变更通知，供服务在增删改后触发立即同步
+type ChangeNotifier interface {
+	Trigger()
+}
+
+// reconcileState 已同步的配置状态
+type reconcileState struct {
+	fingerprint string
+	registered  bool
+}
+
+// ProviderReconciler 将数据库中的供应商配置同步到能力注册表，
+// 启用的配置以绑定凭据的提供者注册，禁用或删除后注销，无需重启
+type ProviderReconciler struct {
+	db        *gorm.DB
+	logger    *logging.Logger
+	encryptor *ConfigEncryptor
+	registry  *capability.Registry
+	interval  time.Duration
+	trigger   chan struct{}
+
+	mu      sync.Mutex
+	applied map[int]reconcileState
+}
+
+// NewProviderReconciler 创建供应商配置同步器，interval 不大于 0 时使用默认周期
+func NewProviderReconciler(
+	db *gorm.DB,
+	logger *logging.Logger,
+	encryptor *ConfigEncryptor,
+	registry *capability.Registry,
+	interval time.Duration,
+) *ProviderReconciler {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	if interval <= 0 {
+		interval = defaultReconcileInterval
+	}
+	return &ProviderReconciler{
+		db:        db,
+		logger:    logger,
+		encryptor: encryptor,
+		registry:  registry,
+		interval:  interval,
+		trigger:   make(chan struct{}, 1),
+		applied:   make(map[int]reconcileState),
+	}
+}
+
+// Run 周期性同步，收到 Trigger 时立即同步，直到 ctx 取消
+func (r *ProviderReconciler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Reconcile(ctx); err != nil {
+			r.logger.WarnTag("插件配置", "同步供应商配置失败: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-r.trigger:
+		}
+	}
+}
+
+// Trigger 请求尽快同步一次，不阻塞
+func (r *ProviderReconciler) Trigger() {
+	select {
+	case r.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Reconcile 执行一次同步
+func (r *ProviderReconciler) Reconcile(ctx context.Context) error {
+	var configs []ProviderConfig
+	if err := r.db.WithContext(ctx).Preload("Capabilities").Find(&configs).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "plugin_config.reconcile", "failed to load provider configs", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := make(map[int]bool, len(configs))
+	for i := range configs {
+		pc := &configs[i]
+		if !pc.Enabled {
+			continue
+		}
+		seen[pc.ID] = true
+
+		fingerprint := reconcileFingerprint(pc)
+		if state, ok := r.applied[pc.ID]; ok && state.fingerprint == fingerprint {
+			continue
+		}
+
+		err := r.register(pc)
+		r.applied[pc.ID] = reconcileState{fingerprint: fingerprint, registered: err == nil}
+		if err != nil {
+			r.logger.WarnTag("插件配置", "注册供应商 %s(%d) 失败: %v", pc.ProviderName, pc.ID, err)
+			continue
+		}
+		r.logger.InfoTag("插件配置", "已注册供应商 %s(%d)", pc.ProviderName, pc.ID)
+	}
+
+	for id, state := range r.applied {
+		if seen[id] {
+			continue
+		}
+		if state.registered {
+			r.registry.Unregister(registeredProviderID(id))
+			r.logger.InfoTag("插件配置", "已注销供应商配置 %d", id)
+		}
+		delete(r.applied, id)
+	}
+	return nil
+}
+
+// register 以绑定配置的提供者注册（或替换）能力
+func (r *ProviderReconciler) register(pc *ProviderConfig) error {
+	base, ok := r.registry.GetProvider(string(pc.ProviderType))
+	if !ok {
+		return fmt.Errorf("no built-in provider for type %s", pc.ProviderType)
+	}
+
+	config := make(map[string]interface{})
+	if pc.ConfigData != "" {
+		plaintext, err := r.encryptor.Decrypt(pc.ConfigData)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal([]byte(plaintext), &config); err != nil {
+			return fmt.Errorf("decode config: %w", err)
+		}
+	}
+
+	disabled := make([]string, 0)
+	for _, c := range pc.Capabilities {
+		if !c.Enabled {
+			disabled = append(disabled, c.CapabilityID)
+		}
+	}
+
+	providerID := registeredProviderID(pc.ID)
+	// 先注销再注册，避免凭据或能力变更后残留旧映射
+	r.registry.Unregister(providerID)
+	r.registry.Register(providerID, capability.NewConfiguredProvider(base, config, disabled))
+	return nil
+}
+
+// registeredProviderID 供应商配置在注册表中的提供者ID
+func registeredProviderID(id int) string {
+	return fmt.Sprintf("config:%d", id)
+}
+
+// reconcileFingerprint 计算影响注册结果的字段指纹；
+// ConfigData 每次加密使用随机 nonce，内容变更必然导致密文变化
+func reconcileFingerprint(pc *ProviderConfig) string {
+	disabled := make([]string, 0)
+	for _, c := range pc.Capabilities {
+		if !c.Enabled {
+			disabled = append(disabled, c.CapabilityID)
+		}
+	}
+	sort.Strings(disabled)
+	return string(pc.ProviderType) + "|" + pc.ConfigData + "|" + strings.Join(disabled, ",")
+}
@@ -33,6 +33,15 @@ type PluginConfigService interface {
 	// 历史管理
 	GetConfigHistory(ctx context.Context, providerConfigID int, filter *HistoryFilter) (*HistoryList, error)
 
+	// 配额管理
+	SetQuotaPolicy(ctx context.Context, req *QuotaPolicyRequest) (*QuotaPolicy, error)
+	GetQuotaPolicies(ctx context.Context) ([]QuotaPolicy, error)
+	DeleteQuotaPolicy(ctx context.Context, id int) error
+	GetQuotaUsage(ctx context.Context, day string) ([]QuotaUsage, error)
+
+	// 健康探测历史
+	GetHealthHistory(ctx context.Context, providerConfigID int, filter *HealthCheckFilter) (*HealthCheckList, error)
+
 	// 统计和可用性
 	GetAvailableProviders(ctx context.Context) ([]AvailableProvider, error)
 	GetPluginStats(ctx context.Context) (*PluginStats, error)
@@ -40,6 +49,7 @@ type PluginConfigService interface {
 	// 系统集成
 	GetEnabledCapabilities(ctx context.Context, capabilityType CapabilityType) ([]Capability, error)
 	GetCapabilityExecutor(ctx context.Context, capabilityID string, config map[string]interface{}) (capability.Executor, error)
+	SetChangeNotifier(notifier ChangeNotifier)
 }
 
 // CreateProviderConfigRequest 创建供应商配置请求
@@ -89,6 +99,8 @@ type CreateSnapshotRequest struct {
 	SnapshotName string `json:"snapshotName"`
 	Description string `json:"description"`
 	CreatedBy   string `json:"createdBy"`
+	UserAgent   string `json:"userAgent"`
+	IPAddress   string `json:"ipAddress"`
 }
 
 // ProviderConfigFilter 供应商配置过滤器
@@ -196,6 +208,7 @@ type pluginConfigServiceImpl struct {
 	encryptor    *ConfigEncryptor
 	validator    *ConfigValidator
 	registry     *capability.Registry
+	notifier     ChangeNotifier
 }
 
 // NewPluginConfigService 创建插件配置服务
@@ -267,8 +280,10 @@ func (s *pluginConfigServiceImpl) CreateProviderConfig(ctx context.Context, req
 	}
 
 	// 记录历史
-	s.recordHistory(ctx, providerConfig.ID, OperationCreate, "", string(configJSON), "Created new provider config", []string{}, req.CreatedBy, req.UserAgent, req.IPAddress)
+	newData, _ := json.Marshal(providerConfig)
+	s.recordHistory(ctx, providerConfig.ID, OperationCreate, "", string(newData), "Created new provider config", []string{}, req.CreatedBy, req.UserAgent, req.IPAddress)
 
+	s.notifyChange()
 	s.logger.Info("Plugin provider config created", "id", providerConfig.ID, "type", req.ProviderType, "name", req.ProviderName)
 	return providerConfig, nil
 }
@@ -389,6 +404,7 @@ func (s *pluginConfigServiceImpl) UpdateProviderConfig(ctx context.Context, id i
 	newData, _ := json.Marshal(providerConfig)
 	s.recordHistory(ctx, id, OperationUpdate, string(oldData), string(newData), fmt.Sprintf("Updated fields: %v", changes), changes, req.UpdatedBy, req.UserAgent, req.IPAddress)
 
+	s.notifyChange()
 	s.logger.Info("Plugin provider config updated", "id", id, "changes", changes)
 	return providerConfig, nil
 }
@@ -409,6 +425,7 @@ func (s *pluginConfigServiceImpl) DeleteProviderConfig(ctx context.Context, id i
 		return errors.Wrap(errors.KindDomain, "plugin_config.delete", "failed to delete provider config", err)
 	}
 
+	s.notifyChange()
 	s.logger.Info("Plugin provider config deleted", "id", id, "type", providerConfig.ProviderType, "name", providerConfig.ProviderName)
 	return nil
 }
@@ -517,8 +534,19 @@ func (s *pluginConfigServiceImpl) createCapabilitiesForProvider(ctx context.Cont
 
 // recordHistory 记录配置变更历史
 func (s *pluginConfigServiceImpl) recordHistory(ctx context.Context, providerConfigID int, operation HistoryOperation, oldData, newData, changeSummary string, changedFields []string, createdBy, userAgent, ipAddress string) {
-	history, _ := NewConfigHistory(providerConfigID, operation, oldData, newData, changeSummary, "", createdBy, userAgent, ipAddress)
-	s.db.Create(history)
+	fields := ""
+	if len(changedFields) > 0 {
+		data, _ := json.Marshal(changedFields)
+		fields = string(data)
+	}
+	history, err := NewConfigHistory(providerConfigID, operation, oldData, newData, changeSummary, fields, createdBy, userAgent, ipAddress)
+	if err != nil {
+		s.logger.Error("Failed to build config history for provider config %d: %v", providerConfigID, err)
+		return
+	}
+	if err := s.db.WithContext(ctx).Create(history).Error; err != nil {
+		s.logger.Error("Failed to record config history for provider config %d: %v", providerConfigID, err)
+	}
 }
 
 // GetAvailableProviders 获取可用供应商列表
@@ -676,23 +704,14 @@ func (s *pluginConfigServiceImpl) GetCapabilityExecutor(ctx context.Context, cap
 	return nil, errors.New(errors.KindDomain, "plugin_config.get_executor", "executor integration not implemented")
 }
 
-// 实现其他必需的方法...
-func (s *pluginConfigServiceImpl) CreateConfigSnapshot(ctx context.Context, providerConfigID int, req *CreateSnapshotRequest) (*ConfigSnapshot, error) {
-	// TODO: 实现快照创建
-	return nil, errors.New(errors.KindDomain, "plugin_config.create_snapshot", "not implemented")
-}
-
-func (s *pluginConfigServiceImpl) GetConfigSnapshots(ctx context.Context, providerConfigID int, filter *SnapshotFilter) (*SnapshotList, error) {
-	// TODO: 实现快照列表获取
-	return nil, errors.New(errors.KindDomain, "plugin_config.get_snapshots", "not implemented")
+// SetChangeNotifier 设置配置变更通知，通常为 ProviderReconciler
+func (s *pluginConfigServiceImpl) SetChangeNotifier(notifier ChangeNotifier) {
+	s.notifier = notifier
 }
 
-func (s *pluginConfigServiceImpl) RestoreConfigSnapshot(ctx context.Context, providerConfigID, snapshotID int) error {
-	// TODO: 实现快照恢复
-	return errors.New(errors.KindDomain, "plugin_config.restore_snapshot", "not implemented")
+// notifyChange 通知配置已变更
+func (s *pluginConfigServiceImpl) notifyChange() {
+	if s.notifier != nil {
+		s.notifier.Trigger()
+	}
 }
-
-func (s *pluginConfigServiceImpl) GetConfigHistory(ctx context.Context, providerConfigID int, filter *HistoryFilter) (*HistoryList, error) {
-	// TODO: 实现历史记录获取
-	return nil, errors.New(errors.KindDomain, "plugin_config.get_history", "not implemented")
-}
\ No newline at end of file
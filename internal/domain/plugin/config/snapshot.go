@@ -0,0 +1,310 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// snapshotPayload 快照内容，整体加密后写入 SnapshotData
+type snapshotPayload struct {
+	DisplayName string                 `json:"displayName"`
+	Description string                 `json:"description"`
+	Config      map[string]interface{} `json:"config"`
+	Enabled     bool                   `json:"enabled"`
+	Priority    int                    `json:"priority"`
+}
+
+// CreateConfigSnapshot 为供应商配置的当前状态创建快照
+func (s *pluginConfigServiceImpl) CreateConfigSnapshot(ctx context.Context, providerConfigID int, req *CreateSnapshotRequest) (*ConfigSnapshot, error) {
+	if req == nil {
+		req = &CreateSnapshotRequest{}
+	}
+	providerConfig, err := s.GetProviderConfig(ctx, providerConfigID)
+	if err != nil {
+		return nil, err
+	}
+
+	configData, err := s.decryptConfig(providerConfig.ConfigData)
+	if err != nil {
+		return nil, errors.Wrap(errors.KindDomain, "plugin_config.create_snapshot", "failed to decrypt current config", err)
+	}
+	payload, _ := json.Marshal(snapshotPayload{
+		DisplayName: providerConfig.DisplayName,
+		Description: providerConfig.Description,
+		Config:      configData,
+		Enabled:     providerConfig.Enabled,
+		Priority:    providerConfig.Priority,
+	})
+	encrypted, err := s.encryptor.Encrypt(string(payload))
+	if err != nil {
+		return nil, errors.Wrap(errors.KindDomain, "plugin_config.create_snapshot", "failed to encrypt snapshot", err)
+	}
+
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&ConfigSnapshot{}).Where("provider_config_id = ?", providerConfigID).Count(&count).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "plugin_config.create_snapshot", "failed to count snapshots", err)
+	}
+	version := req.Version
+	if version == "" {
+		version = fmt.Sprintf("v%d", count+1)
+	}
+	name := req.SnapshotName
+	if name == "" {
+		name = fmt.Sprintf("%s-%s", providerConfig.ProviderName, time.Now().Format("20060102150405"))
+	}
+
+	snapshot, err := NewConfigSnapshot(providerConfigID, version, name, req.Description, encrypted, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	// 新快照与当前配置一致，成为激活快照
+	snapshot.SetActive(true)
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&ConfigSnapshot{}).Where("provider_config_id = ?", providerConfigID).Update("is_active", false).Error; err != nil {
+			return err
+		}
+		return tx.Create(snapshot).Error
+	})
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "plugin_config.create_snapshot", "failed to save snapshot", err)
+	}
+
+	s.recordHistory(ctx, providerConfigID, OperationSnapshot, "", "", fmt.Sprintf("Created snapshot %s (%s)", snapshot.SnapshotName, snapshot.Version), []string{}, req.CreatedBy, req.UserAgent, req.IPAddress)
+
+	s.logger.Info("Plugin provider config snapshot created: config=%d snapshot=%d version=%s", providerConfigID, snapshot.ID, snapshot.Version)
+	snapshot.SnapshotData = ""
+	return snapshot, nil
+}
+
+// GetConfigSnapshots 分页获取供应商配置的快照列表，不返回加密的快照内容
+func (s *pluginConfigServiceImpl) GetConfigSnapshots(ctx context.Context, providerConfigID int, filter *SnapshotFilter) (*SnapshotList, error) {
+	if _, err := s.GetProviderConfig(ctx, providerConfigID); err != nil {
+		return nil, err
+	}
+	if filter == nil {
+		filter = &SnapshotFilter{}
+	}
+
+	query := s.db.WithContext(ctx).Model(&ConfigSnapshot{}).Where("provider_config_id = ?", providerConfigID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "plugin_config.get_snapshots", "failed to count snapshots", err)
+	}
+
+	page, pageSize := normalizePage(filter.Page, filter.PageSize)
+	snapshots := make([]ConfigSnapshot, 0)
+	if err := query.Omit("snapshot_data").Order("created_at DESC, id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&snapshots).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "plugin_config.get_snapshots", "failed to list snapshots", err)
+	}
+
+	return &SnapshotList{
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+		Snapshots:  snapshots,
+	}, nil
+}
+
+// RestoreConfigSnapshot 将供应商配置恢复为指定快照的内容
+func (s *pluginConfigServiceImpl) RestoreConfigSnapshot(ctx context.Context, providerConfigID, snapshotID int) error {
+	providerConfig, err := s.GetProviderConfig(ctx, providerConfigID)
+	if err != nil {
+		return err
+	}
+
+	var snapshot ConfigSnapshot
+	if err := s.db.WithContext(ctx).Where("id = ? AND provider_config_id = ?", snapshotID, providerConfigID).First(&snapshot).Error; err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New(errors.KindDomain, "plugin_config.restore_snapshot", "config snapshot not found")
+		}
+		return errors.Wrap(errors.KindStorage, "plugin_config.restore_snapshot", "failed to get snapshot", err)
+	}
+
+	plaintext, err := s.encryptor.Decrypt(snapshot.SnapshotData)
+	if err != nil {
+		return errors.Wrap(errors.KindDomain, "plugin_config.restore_snapshot", "failed to decrypt snapshot", err)
+	}
+	var payload snapshotPayload
+	if err := json.Unmarshal([]byte(plaintext), &payload); err != nil {
+		return errors.Wrap(errors.KindDomain, "plugin_config.restore_snapshot", "invalid snapshot data", err)
+	}
+
+	// 模式可能在快照之后发生变化，恢复前重新验证
+	configSchema := s.validator.GetConfigSchema(providerConfig.ProviderType)
+	if err := s.validator.ValidateConfig(payload.Config, configSchema); err != nil {
+		return err
+	}
+	configJSON, _ := json.Marshal(payload.Config)
+	encryptedConfig, err := s.encryptor.Encrypt(string(configJSON))
+	if err != nil {
+		return errors.Wrap(errors.KindDomain, "plugin_config.restore_snapshot", "failed to encrypt config", err)
+	}
+
+	oldData, _ := json.Marshal(providerConfig)
+	providerConfig.DisplayName = payload.DisplayName
+	providerConfig.Description = payload.Description
+	providerConfig.Enabled = payload.Enabled
+	providerConfig.Priority = payload.Priority
+	providerConfig.ConfigData = encryptedConfig
+	providerConfig.UpdatedAt = time.Now()
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Capabilities", "Snapshots", "History").Save(providerConfig).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&ConfigSnapshot{}).Where("provider_config_id = ?", providerConfigID).Update("is_active", false).Error; err != nil {
+			return err
+		}
+		return tx.Model(&ConfigSnapshot{}).Where("id = ?", snapshot.ID).Update("is_active", true).Error
+	})
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "plugin_config.restore_snapshot", "failed to restore snapshot", err)
+	}
+
+	newData, _ := json.Marshal(providerConfig)
+	changes := diffFields(string(oldData), string(newData))
+	fields := make([]string, 0, len(changes)+1)
+	for _, change := range changes {
+		fields = append(fields, change.Field)
+	}
+	// 配置数据加密存储，无法逐项比对，统一记为 config_data
+	fields = append(fields, "config_data")
+	s.recordHistory(ctx, providerConfigID, OperationRestore, string(oldData), string(newData), fmt.Sprintf("Restored snapshot %s (%s)", snapshot.SnapshotName, snapshot.Version), fields, "", "", "")
+
+	s.notifyChange()
+	s.logger.Info("Plugin provider config snapshot restored: config=%d snapshot=%d version=%s", providerConfigID, snapshotID, snapshot.Version)
+	return nil
+}
+
+// GetConfigHistory 分页获取供应商配置的变更历史，并计算每条记录的字段差异
+func (s *pluginConfigServiceImpl) GetConfigHistory(ctx context.Context, providerConfigID int, filter *HistoryFilter) (*HistoryList, error) {
+	if filter == nil {
+		filter = &HistoryFilter{}
+	}
+	if !filter.StartDate.IsZero() && !filter.EndDate.IsZero() && filter.EndDate.Before(filter.StartDate) {
+		return nil, errors.New(errors.KindDomain, "plugin_config.get_history", "end date must not be before start date")
+	}
+
+	// 删除记录保留在历史中，因此不要求配置仍然存在
+	query := s.db.WithContext(ctx).Model(&ConfigHistory{}).Where("provider_config_id = ?", providerConfigID)
+	if filter.Operation != "" {
+		query = query.Where("operation = ?", filter.Operation)
+	}
+	if !filter.StartDate.IsZero() {
+		query = query.Where("created_at >= ?", filter.StartDate)
+	}
+	if !filter.EndDate.IsZero() {
+		query = query.Where("created_at <= ?", filter.EndDate)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "plugin_config.get_history", "failed to count history", err)
+	}
+
+	page, pageSize := normalizePage(filter.Page, filter.PageSize)
+	history := make([]ConfigHistory, 0)
+	if err := query.Order("created_at DESC, id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&history).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "plugin_config.get_history", "failed to list history", err)
+	}
+	for i := range history {
+		history[i].Changes = diffFields(history[i].OldData, history[i].NewData)
+	}
+
+	return &HistoryList{
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+		History:    history,
+	}, nil
+}
+
+// decryptConfig 解密供应商配置数据
+func (s *pluginConfigServiceImpl) decryptConfig(encrypted string) (map[string]interface{}, error) {
+	configData := make(map[string]interface{})
+	if encrypted == "" {
+		return configData, nil
+	}
+	plaintext, err := s.encryptor.Decrypt(encrypted)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(plaintext), &configData); err != nil {
+		return nil, err
+	}
+	return configData, nil
+}
+
+// normalizePage 规范化分页参数
+func normalizePage(page, pageSize int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+	return page, pageSize
+}
+
+// historyIgnoredFields 比对时忽略的字段：标识、时间戳及关联数据
+var historyIgnoredFields = map[string]bool{
+	"id":              true,
+	"createdAt":       true,
+	"updatedAt":       true,
+	"lastHealthCheck": true,
+	"capabilities":    true,
+	"snapshots":       true,
+}
+
+// diffFields 比对两份 JSON 对象的顶层字段，任一侧无法解析时返回 nil
+func diffFields(oldData, newData string) []FieldChange {
+	oldValues := make(map[string]interface{})
+	newValues := make(map[string]interface{})
+	if oldData != "" && json.Unmarshal([]byte(oldData), &oldValues) != nil {
+		return nil
+	}
+	if newData != "" && json.Unmarshal([]byte(newData), &newValues) != nil {
+		return nil
+	}
+	if oldData == "" && newData == "" {
+		return nil
+	}
+
+	keys := make(map[string]struct{}, len(oldValues)+len(newValues))
+	for k := range oldValues {
+		keys[k] = struct{}{}
+	}
+	for k := range newValues {
+		keys[k] = struct{}{}
+	}
+
+	changes := make([]FieldChange, 0)
+	for k := range keys {
+		if historyIgnoredFields[k] {
+			continue
+		}
+		oldValue, newValue := oldValues[k], newValues[k]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: k, OldValue: oldValue, NewValue: newValue})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
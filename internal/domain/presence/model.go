@@ -0,0 +1,61 @@
+package presence
+
+import (
+	stderrors "errors"
+	"time"
+)
+
+// State 在家状态
+type State string
+
+const (
+	StateHome    State = "home"
+	StateAway    State = "away"
+	StateUnknown State = "unknown" // 还没有任何签到或路由器记录
+)
+
+// Source 状态来源
+type Source string
+
+const (
+	SourceApp      Source = "app"      // 配套App上报到家或离家
+	SourceGeofence Source = "geofence" // 配套App上报位置，按地理围栏判断
+	SourceRouter   Source = "router"   // 路由器在线设备
+)
+
+// 条件主体，其余取值按成员名称或用户ID匹配
+const (
+	SubjectAnyone   = "anyone"   // 至少一人处于该状态
+	SubjectEveryone = "everyone" // 所有人都处于该状态
+)
+
+var (
+	// ErrUnknownPerson 条件中的成员不在参与在家判断的成员中
+	ErrUnknownPerson = stderrors.New("unknown person")
+	// ErrNoHome 未配置家的位置，无法按位置判断
+	ErrNoHome = stderrors.New("home location not configured")
+)
+
+// Status 成员的在家状态
+type Status struct {
+	UserID    uint      `json:"user_id"`
+	Name      string    `json:"name"`
+	State     State     `json:"state"`
+	Source    Source    `json:"source,omitempty"`
+	Since     time.Time `json:"since"`      // 进入当前状态的时间
+	UpdatedAt time.Time `json:"updated_at"` // 最近一次签到或路由器见到设备的时间
+}
+
+// CheckIn 配套App的签到，State 和位置二选一
+type CheckIn struct {
+	State     State    // home 或 away
+	Latitude  *float64 // 位置签到，按家的地理围栏判断
+	Longitude *float64
+	Accuracy  float64 // 定位精度（米），位于围栏边缘精度范围内时保持原状态
+}
+
+// Condition 在家条件，供工作流条件节点使用
+type Condition struct {
+	Subject string // anyone、everyone、成员名称或用户ID
+	State   State  // home 或 away
+}
@@ -0,0 +1,12 @@
+package presence
+
+import "context"
+
+// Repository 在家状态仓库接口，保存每位成员的最新状态，重启后据此恢复，避免误发到家和离家事件
+type Repository interface {
+	// ListStatuses 查询全部成员的状态
+	ListStatuses(ctx context.Context) ([]*Status, error)
+
+	// SaveStatus 保存成员状态
+	SaveStatus(ctx context.Context, s *Status) error
+}
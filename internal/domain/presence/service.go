@@ -0,0 +1,436 @@
+package presence
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/eventbus"
+	"xiaozhi-server-go/internal/domain/member"
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/httpclient"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+const (
+	// maxRouterResponse 路由器在线设备页面的最大读取字节数
+	maxRouterResponse = 1 << 20
+	// earthRadius 地球平均半径（米）
+	earthRadius = 6371000
+)
+
+var macPattern = regexp.MustCompile(`(?i)\b[0-9a-f]{2}(?:[:-][0-9a-f]{2}){5}\b`)
+
+// Profiles 成员资料来源，用于校验用户存在并取得称呼
+type Profiles interface {
+	GetProfile(ctx context.Context, userID uint) (*member.Profile, error)
+}
+
+// Service 在家状态服务
+//
+// 配套App的签到立即生效；路由器按设备上线和下线的变化判断，设备上线即到家，
+// 连续 AwayDelay 未见到才判定离家。状态变化时发布到家和离家事件。
+type Service struct {
+	cfg      config.PresenceConfig
+	repo     Repository
+	profiles Profiles
+	logger   *logging.Logger
+	client   *http.Client
+	now      func() time.Time
+	owners   map[string]uint // 规范化的MAC地址 -> 用户ID
+
+	mu       sync.RWMutex
+	statuses map[uint]*Status
+	lanSeen  map[uint]time.Time // 路由器最近一次见到成员设备的时间，只包含当前在线的成员
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局在家状态服务，供连接处理器使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局在家状态服务，未启用时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建在家状态服务
+func NewService(cfg config.PresenceConfig, repo Repository, profiles Profiles, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	if cfg.Home.Radius <= 0 {
+		cfg.Home.Radius = 100
+	}
+	if cfg.AwayDelay <= 0 {
+		cfg.AwayDelay = 10 * time.Minute
+	}
+	if cfg.Router.Interval <= 0 {
+		cfg.Router.Interval = time.Minute
+	}
+	if cfg.Router.Timeout <= 0 {
+		cfg.Router.Timeout = 10 * time.Second
+	}
+	owners := make(map[string]uint)
+	for _, person := range cfg.People {
+		for _, mac := range person.MACs {
+			if mac = normalizeMAC(mac); mac != "" {
+				owners[mac] = person.UserID
+			}
+		}
+	}
+	return &Service{
+		cfg:      cfg,
+		repo:     repo,
+		profiles: profiles,
+		logger:   logger,
+		client:   httpclient.Default().Client("presence_router"),
+		now:      time.Now,
+		owners:   owners,
+		statuses: make(map[uint]*Status),
+		lanSeen:  make(map[uint]time.Time),
+	}
+}
+
+// Run 恢复保存的状态，之后按间隔轮询路由器并检查离家，直到 ctx 结束
+func (s *Service) Run(ctx context.Context) error {
+	if err := s.load(ctx); err != nil {
+		s.logger.WarnTag("在家状态", "恢复成员状态失败: %v", err)
+	}
+	ticker := time.NewTicker(s.cfg.Router.Interval)
+	defer ticker.Stop()
+	for {
+		if s.cfg.Router.URL != "" {
+			if err := s.pollRouter(ctx); err != nil && ctx.Err() == nil {
+				s.logger.WarnTag("在家状态", "轮询路由器失败: %v", err)
+			}
+		}
+		s.checkAway(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// load 恢复保存的状态，配置中的成员没有记录时为未知状态
+func (s *Service) load(ctx context.Context) error {
+	saved, err := s.repo.ListStatuses(ctx)
+	if err != nil {
+		return err
+	}
+	statuses := make(map[uint]*Status, len(saved)+len(s.cfg.People))
+	for _, status := range saved {
+		statuses[status.UserID] = status
+	}
+	for _, person := range s.cfg.People {
+		if _, ok := statuses[person.UserID]; !ok {
+			statuses[person.UserID] = &Status{UserID: person.UserID, State: StateUnknown}
+		}
+	}
+	for id, status := range statuses {
+		status.Name = s.name(ctx, id)
+	}
+
+	s.mu.Lock()
+	s.statuses = statuses
+	s.mu.Unlock()
+	return nil
+}
+
+// Statuses 返回全部成员的状态，按用户ID排序
+func (s *Service) Statuses() []Status {
+	s.mu.RLock()
+	out := make([]Status, 0, len(s.statuses))
+	for _, status := range s.statuses {
+		out = append(out, *status)
+	}
+	s.mu.RUnlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].UserID < out[j].UserID })
+	return out
+}
+
+// Status 返回成员的状态，没有记录时为未知状态
+func (s *Service) Status(ctx context.Context, userID uint) (Status, error) {
+	if _, err := s.profiles.GetProfile(ctx, userID); err != nil {
+		return Status{}, err
+	}
+	s.mu.RLock()
+	status, ok := s.statuses[userID]
+	s.mu.RUnlock()
+	if !ok {
+		return Status{UserID: userID, Name: s.name(ctx, userID), State: StateUnknown}, nil
+	}
+	return *status, nil
+}
+
+// HomeNames 返回在家成员的称呼
+func (s *Service) HomeNames() []string {
+	var names []string
+	for _, status := range s.Statuses() {
+		if status.State == StateHome {
+			names = append(names, status.Name)
+		}
+	}
+	return names
+}
+
+// CheckIn 处理配套App的签到；位置签到落在围栏边缘的定位精度范围内时保持原状态
+func (s *Service) CheckIn(ctx context.Context, userID uint, in CheckIn) (Status, error) {
+	if _, err := s.profiles.GetProfile(ctx, userID); err != nil {
+		return Status{}, err
+	}
+	if in.Latitude == nil && in.Longitude == nil {
+		if in.State != StateHome && in.State != StateAway {
+			return Status{}, errors.New(errors.KindDomain, "presence.checkin", "state must be home or away")
+		}
+		return s.transition(ctx, userID, in.State, SourceApp)
+	}
+
+	if in.Latitude == nil || in.Longitude == nil {
+		return Status{}, errors.New(errors.KindDomain, "presence.checkin", "latitude and longitude are required together")
+	}
+	if !s.hasHome() {
+		return Status{}, errors.Wrap(errors.KindDomain, "presence.checkin", "home location not configured", ErrNoHome)
+	}
+	distance := s.distance(*in.Latitude, *in.Longitude)
+	switch {
+	case distance <= s.cfg.Home.Radius:
+		return s.transition(ctx, userID, StateHome, SourceGeofence)
+	case distance > s.cfg.Home.Radius+math.Max(in.Accuracy, 0):
+		return s.transition(ctx, userID, StateAway, SourceGeofence)
+	default:
+		return s.Status(ctx, userID)
+	}
+}
+
+// RouterReport 处理路由器上报的在线设备MAC地址，返回其中属于成员的设备数
+// 成员的设备从离线变为在线时判定到家，离家由 checkAway 在超过 AwayDelay 后判定
+func (s *Service) RouterReport(ctx context.Context, macs []string) int {
+	now := s.now()
+	seen := make(map[uint]bool)
+	matched := 0
+	for _, mac := range macs {
+		if userID, ok := s.owners[normalizeMAC(mac)]; ok {
+			seen[userID] = true
+			matched++
+		}
+	}
+
+	var arrived []uint
+	s.mu.Lock()
+	for userID := range seen {
+		if _, online := s.lanSeen[userID]; !online {
+			arrived = append(arrived, userID)
+		}
+		s.lanSeen[userID] = now
+		if status, ok := s.statuses[userID]; ok && status.State == StateHome {
+			status.UpdatedAt = now
+		}
+	}
+	s.mu.Unlock()
+
+	for _, userID := range arrived {
+		if _, err := s.transition(ctx, userID, StateHome, SourceRouter); err != nil {
+			s.logger.WarnTag("在家状态", "更新用户 %d 的状态失败: %v", userID, err)
+		}
+	}
+	return matched
+}
+
+// checkAway 路由器连续 AwayDelay 未见到成员设备时判定离家
+func (s *Service) checkAway(ctx context.Context) {
+	now := s.now()
+	var left []uint
+	s.mu.Lock()
+	for userID, seen := range s.lanSeen {
+		if now.Sub(seen) >= s.cfg.AwayDelay {
+			delete(s.lanSeen, userID)
+			left = append(left, userID)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, userID := range left {
+		if _, err := s.transition(ctx, userID, StateAway, SourceRouter); err != nil {
+			s.logger.WarnTag("在家状态", "更新用户 %d 的状态失败: %v", userID, err)
+		}
+	}
+}
+
+// pollRouter 读取路由器在线设备页面，响应中出现的MAC地址都视为在线
+func (s *Service) pollRouter(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Router.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.Router.URL, nil)
+	if err != nil {
+		return err
+	}
+	if s.cfg.Router.Username != "" {
+		req.SetBasicAuth(s.cfg.Router.Username, s.cfg.Router.Password)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("router returned status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRouterResponse))
+	if err != nil {
+		return err
+	}
+	s.RouterReport(ctx, macPattern.FindAllString(string(body), -1))
+	return nil
+}
+
+// transition 更新成员状态，状态变化时保存并发布到家或离家事件
+func (s *Service) transition(ctx context.Context, userID uint, state State, source Source) (Status, error) {
+	name := s.name(ctx, userID)
+	now := s.now()
+
+	s.mu.Lock()
+	status, ok := s.statuses[userID]
+	if !ok {
+		status = &Status{UserID: userID, State: StateUnknown}
+		s.statuses[userID] = status
+	}
+	previous := status.State
+	status.Name = name
+	status.UpdatedAt = now
+	if previous != state {
+		status.State = state
+		status.Source = source
+		status.Since = now
+	}
+	snapshot := *status
+	homeCount := 0
+	for _, st := range s.statuses {
+		if st.State == StateHome {
+			homeCount++
+		}
+	}
+	s.mu.Unlock()
+
+	if err := s.repo.SaveStatus(ctx, &snapshot); err != nil {
+		return snapshot, err
+	}
+	if previous == state {
+		return snapshot, nil
+	}
+
+	s.logger.InfoTag("在家状态", "%s（用户 %d）%s，来源 %s", name, userID, stateText(state), source)
+	event := eventbus.PresenceEventData{
+		UserID:    userID,
+		Name:      name,
+		Source:    string(source),
+		HomeCount: homeCount,
+		Timestamp: now,
+	}
+	switch {
+	case state == StateHome:
+		eventbus.Publish(eventbus.EventPresenceArrived, event)
+	case previous == StateHome:
+		eventbus.Publish(eventbus.EventPresenceLeft, event)
+	}
+	return snapshot, nil
+}
+
+// Evaluate 判断在家条件是否成立，状态未知的成员既不算在家也不算离家
+func (s *Service) Evaluate(cond Condition) (bool, error) {
+	if cond.State != StateHome && cond.State != StateAway {
+		return false, errors.New(errors.KindDomain, "presence.evaluate", "state must be home or away")
+	}
+	statuses := s.Statuses()
+	subject := strings.TrimSpace(cond.Subject)
+	switch subject {
+	case "", SubjectAnyone:
+		for _, status := range statuses {
+			if status.State == cond.State {
+				return true, nil
+			}
+		}
+		return false, nil
+	case SubjectEveryone:
+		for _, status := range statuses {
+			if status.State != cond.State {
+				return false, nil
+			}
+		}
+		return len(statuses) > 0, nil
+	}
+
+	for _, status := range statuses {
+		if status.Name == subject || strconv.FormatUint(uint64(status.UserID), 10) == subject {
+			return status.State == cond.State, nil
+		}
+	}
+	return false, errors.Wrap(errors.KindDomain, "presence.evaluate", "unknown person: "+subject, ErrUnknownPerson)
+}
+
+// EvaluatePresence 供工作流条件节点使用，见 Evaluate
+func (s *Service) EvaluatePresence(ctx context.Context, subject, state string) (bool, error) {
+	return s.Evaluate(Condition{Subject: subject, State: State(state)})
+}
+
+// name 返回成员称呼，资料未设置称呼时使用用户ID
+func (s *Service) name(ctx context.Context, userID uint) string {
+	if profile, err := s.profiles.GetProfile(ctx, userID); err == nil && profile.Name != "" {
+		return profile.Name
+	}
+	return fmt.Sprintf("用户%d", userID)
+}
+
+func (s *Service) hasHome() bool {
+	return s.cfg.Home.Latitude != 0 || s.cfg.Home.Longitude != 0
+}
+
+// distance 计算位置到家的球面距离（米）
+func (s *Service) distance(lat, lon float64) float64 {
+	rad := math.Pi / 180
+	dLat := (lat - s.cfg.Home.Latitude) * rad
+	dLon := (lon - s.cfg.Home.Longitude) * rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(s.cfg.Home.Latitude*rad)*math.Cos(lat*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// normalizeMAC 统一为小写冒号分隔的形式，不是MAC地址时返回空字符串
+func normalizeMAC(mac string) string {
+	mac = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(mac), "-", ":"))
+	if !macPattern.MatchString(mac) || len(mac) != 17 {
+		return ""
+	}
+	return mac
+}
+
+func stateText(state State) string {
+	switch state {
+	case StateHome:
+		return "到家"
+	case StateAway:
+		return "离家"
+	default:
+		return "状态未知"
+	}
+}
@@ -0,0 +1,36 @@
+package prompt
+
+import (
+	"time"
+)
+
+// DefaultScope 未单独分配模板的设备使用的分配键
+const DefaultScope = "*"
+
+// Template 提示词模板的一个版本
+// 同名模板的每次修改都会生成新版本，Active 标记当前生效的版本
+type Template struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Version     int               `json:"version"`
+	Description string            `json:"description,omitempty"`
+	Content     string            `json:"content"`
+	Variables   map[string]string `json:"variables,omitempty"` // 变量默认值
+	Active      bool              `json:"active"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+// Assignment 模板分配，DeviceID 为 DefaultScope 时作为全局默认
+type Assignment struct {
+	DeviceID     string    `json:"device_id"`
+	TemplateName string    `json:"template_name"`
+	Version      int       `json:"version"` // 0 表示跟随当前生效版本
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Filter 模板查询条件
+type Filter struct {
+	Name     string // 名称模糊匹配
+	Page     int
+	PageSize int
+}
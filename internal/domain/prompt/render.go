@@ -0,0 +1,88 @@
+package prompt
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// jinjaVar 匹配 Jinja 风格的 {{ name }}，转换为 Go 模板的 {{ .name }}
+var jinjaVar = regexp.MustCompile(`\{\{(-?\s*)([A-Za-z_][A-Za-z0-9_]*)(\s*-?)\}\}`)
+
+// goKeywords 不能当作变量名转换的模板关键字
+var goKeywords = map[string]bool{
+	"end": true, "else": true, "break": true, "continue": true,
+	"nil": true, "true": true, "false": true,
+}
+
+var weekdayNames = []string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"}
+
+// normalize 将 Jinja 风格的变量写法转换为 Go 模板写法，Go 模板语法保持不变
+func normalize(content string) string {
+	return jinjaVar.ReplaceAllStringFunc(content, func(m string) string {
+		parts := jinjaVar.FindStringSubmatch(m)
+		if goKeywords[parts[2]] {
+			return m
+		}
+		return "{{" + parts[1] + "." + parts[2] + parts[3] + "}}"
+	})
+}
+
+// Parse 校验模板语法
+func Parse(name, content string) (*template.Template, error) {
+	tmpl, err := template.New(name).
+		Option("missingkey=error").
+		Funcs(template.FuncMap{
+			"upper":   strings.ToUpper,
+			"lower":   strings.ToLower,
+			"trim":    strings.TrimSpace,
+			"default": defaultValue,
+		}).
+		Parse(normalize(content))
+	if err != nil {
+		return nil, fmt.Errorf("parse prompt template %s: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// Render 渲染模板，变量优先级：调用方传入 > 模板默认值 > 内置变量
+func Render(t *Template, vars map[string]string, now time.Time) (string, error) {
+	tmpl, err := Parse(t.Name, t.Content)
+	if err != nil {
+		return "", err
+	}
+
+	data := BuiltinVariables(now)
+	for k, v := range t.Variables {
+		data[k] = v
+	}
+	for k, v := range vars {
+		data[k] = v
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("render prompt template %s: %w", t.Name, err)
+	}
+	return b.String(), nil
+}
+
+// BuiltinVariables 所有模板都可使用的内置变量
+func BuiltinVariables(now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"date":     now.Format("2006-01-02"),
+		"time":     now.Format("15:04"),
+		"weekday":  weekdayNames[now.Weekday()],
+		"datetime": now.Format("2006-01-02 15:04:05"),
+	}
+}
+
+// defaultValue 变量为空字符串时使用默认值，用法：{{ default "小智" .name }}
+func defaultValue(def string, value interface{}) string {
+	if s, ok := value.(string); ok && s != "" {
+		return s
+	}
+	return def
+}
@@ -0,0 +1,41 @@
+package prompt
+
+import (
+	"context"
+)
+
+// Repository 提示词模板仓库接口
+type Repository interface {
+	// Save 保存模板版本
+	Save(ctx context.Context, t *Template) error
+
+	// FindVersion 查找指定版本，version 为 0 时返回当前生效版本；不存在时返回 nil
+	FindVersion(ctx context.Context, name string, version int) (*Template, error)
+
+	// LatestVersion 返回模板的最大版本号，模板不存在时返回 0
+	LatestVersion(ctx context.Context, name string) (int, error)
+
+	// ListVersions 列出模板的全部版本，按版本倒序
+	ListVersions(ctx context.Context, name string) ([]*Template, error)
+
+	// ListActive 分页查询各模板的当前生效版本
+	ListActive(ctx context.Context, filter Filter) ([]*Template, int64, error)
+
+	// SetActive 将指定版本设为生效版本
+	SetActive(ctx context.Context, name string, version int) error
+
+	// Delete 删除模板的全部版本及分配
+	Delete(ctx context.Context, name string) error
+
+	// SaveAssignment 保存或覆盖设备的模板分配
+	SaveAssignment(ctx context.Context, a *Assignment) error
+
+	// FindAssignment 查找设备的模板分配，不存在时返回 nil
+	FindAssignment(ctx context.Context, deviceID string) (*Assignment, error)
+
+	// ListAssignments 列出全部分配
+	ListAssignments(ctx context.Context) ([]*Assignment, error)
+
+	// DeleteAssignment 删除设备的模板分配
+	DeleteAssignment(ctx context.Context, deviceID string) error
+}
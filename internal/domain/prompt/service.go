@@ -0,0 +1,291 @@
+package prompt
+
+import (
+	"context"
+	stderrors "errors"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+// ErrNotFound 模板不存在
+var ErrNotFound = stderrors.New("prompt template not found")
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
+// CreateRequest 创建模板请求
+type CreateRequest struct {
+	Name        string
+	Description string
+	Content     string
+	Variables   map[string]string
+}
+
+// UpdateRequest 修改模板请求，会生成新版本并设为生效版本
+type UpdateRequest struct {
+	Description *string
+	Content     *string
+	Variables   map[string]string // nil 表示沿用上一版本
+}
+
+// Service 提示词模板服务
+type Service struct {
+	repo   Repository
+	logger *logging.Logger
+	now    func() time.Time
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局模板服务，供连接处理器使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局模板服务，未初始化时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建模板服务
+func NewService(repo Repository, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Service{
+		repo:   repo,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Create 创建模板，生成版本 1
+func (s *Service) Create(ctx context.Context, req CreateRequest) (*Template, error) {
+	if !namePattern.MatchString(req.Name) {
+		return nil, errors.New(errors.KindDomain, "prompt.create", "invalid template name: "+req.Name)
+	}
+	if strings.TrimSpace(req.Content) == "" {
+		return nil, errors.New(errors.KindDomain, "prompt.create", "content is required")
+	}
+	if _, err := Parse(req.Name, req.Content); err != nil {
+		return nil, errors.Wrap(errors.KindDomain, "prompt.create", err.Error(), err)
+	}
+
+	latest, err := s.repo.LatestVersion(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if latest > 0 {
+		return nil, errors.New(errors.KindDomain, "prompt.create", "template already exists: "+req.Name)
+	}
+
+	t := &Template{
+		ID:          uuid.New().String(),
+		Name:        req.Name,
+		Version:     1,
+		Description: req.Description,
+		Content:     req.Content,
+		Variables:   req.Variables,
+		Active:      true,
+		CreatedAt:   s.now(),
+	}
+	if err := s.repo.Save(ctx, t); err != nil {
+		return nil, err
+	}
+	s.logger.InfoTag("提示词", "已创建模板 %s", t.Name)
+	return t, nil
+}
+
+// Update 基于当前生效版本生成新版本
+func (s *Service) Update(ctx context.Context, name string, req UpdateRequest) (*Template, error) {
+	current, err := s.Get(ctx, name, 0)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := s.repo.LatestVersion(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	next := &Template{
+		ID:          uuid.New().String(),
+		Name:        name,
+		Version:     latest + 1,
+		Description: current.Description,
+		Content:     current.Content,
+		Variables:   current.Variables,
+		Active:      false,
+		CreatedAt:   s.now(),
+	}
+	if req.Description != nil {
+		next.Description = *req.Description
+	}
+	if req.Content != nil {
+		if strings.TrimSpace(*req.Content) == "" {
+			return nil, errors.New(errors.KindDomain, "prompt.update", "content is required")
+		}
+		next.Content = *req.Content
+	}
+	if req.Variables != nil {
+		next.Variables = req.Variables
+	}
+	if _, err := Parse(name, next.Content); err != nil {
+		return nil, errors.Wrap(errors.KindDomain, "prompt.update", err.Error(), err)
+	}
+
+	if err := s.repo.Save(ctx, next); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetActive(ctx, name, next.Version); err != nil {
+		return nil, err
+	}
+	next.Active = true
+	s.logger.InfoTag("提示词", "模板 %s 已更新到版本 %d", name, next.Version)
+	return next, nil
+}
+
+// Get 获取模板，version 为 0 时返回当前生效版本
+func (s *Service) Get(ctx context.Context, name string, version int) (*Template, error) {
+	t, err := s.repo.FindVersion(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, errors.Wrap(errors.KindDomain, "prompt.get", "prompt template not found", ErrNotFound)
+	}
+	return t, nil
+}
+
+// List 分页查询模板的当前生效版本
+func (s *Service) List(ctx context.Context, filter Filter) ([]*Template, int64, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = 20
+	}
+	return s.repo.ListActive(ctx, filter)
+}
+
+// ListVersions 列出模板的全部版本
+func (s *Service) ListVersions(ctx context.Context, name string) ([]*Template, error) {
+	versions, err := s.repo.ListVersions(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, errors.Wrap(errors.KindDomain, "prompt.list_versions", "prompt template not found", ErrNotFound)
+	}
+	return versions, nil
+}
+
+// Activate 将指定版本设为生效版本，可用于回滚
+func (s *Service) Activate(ctx context.Context, name string, version int) (*Template, error) {
+	t, err := s.Get(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetActive(ctx, name, version); err != nil {
+		return nil, err
+	}
+	t.Active = true
+	s.logger.InfoTag("提示词", "模板 %s 已切换到版本 %d", name, version)
+	return t, nil
+}
+
+// Delete 删除模板的全部版本
+func (s *Service) Delete(ctx context.Context, name string) error {
+	if _, err := s.Get(ctx, name, 0); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, name)
+}
+
+// Assign 为设备分配模板，deviceID 为 DefaultScope 时设置全局默认
+func (s *Service) Assign(ctx context.Context, deviceID, name string, version int) (*Assignment, error) {
+	if strings.TrimSpace(deviceID) == "" {
+		return nil, errors.New(errors.KindDomain, "prompt.assign", "device_id is required")
+	}
+	if _, err := s.Get(ctx, name, version); err != nil {
+		return nil, err
+	}
+	a := &Assignment{
+		DeviceID:     deviceID,
+		TemplateName: name,
+		Version:      version,
+		UpdatedAt:    s.now(),
+	}
+	if err := s.repo.SaveAssignment(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Unassign 取消设备的模板分配
+func (s *Service) Unassign(ctx context.Context, deviceID string) error {
+	return s.repo.DeleteAssignment(ctx, deviceID)
+}
+
+// ListAssignments 列出全部分配
+func (s *Service) ListAssignments(ctx context.Context) ([]*Assignment, error) {
+	return s.repo.ListAssignments(ctx)
+}
+
+// RenderContent 渲染未保存的模板内容，供测试渲染使用
+func (s *Service) RenderContent(content string, variables, vars map[string]string) (string, error) {
+	out, err := Render(&Template{Name: "preview", Content: content, Variables: variables}, vars, s.now())
+	if err != nil {
+		return "", errors.Wrap(errors.KindDomain, "prompt.render", err.Error(), err)
+	}
+	return out, nil
+}
+
+// RenderTemplate 渲染已保存的模板版本
+func (s *Service) RenderTemplate(ctx context.Context, name string, version int, vars map[string]string) (string, *Template, error) {
+	t, err := s.Get(ctx, name, version)
+	if err != nil {
+		return "", nil, err
+	}
+	out, err := Render(t, vars, s.now())
+	if err != nil {
+		return "", nil, errors.Wrap(errors.KindDomain, "prompt.render", err.Error(), err)
+	}
+	return out, t, nil
+}
+
+// ResolveForDevice 按设备分配、全局默认的顺序解析系统提示词
+// 未分配模板时返回 ok=false，调用方应使用配置中的默认提示词
+func (s *Service) ResolveForDevice(ctx context.Context, deviceID string, vars map[string]string) (string, bool) {
+	a, err := s.repo.FindAssignment(ctx, deviceID)
+	if err == nil && a == nil {
+		a, err = s.repo.FindAssignment(ctx, DefaultScope)
+	}
+	if err != nil {
+		s.logger.WarnTag("提示词", "查询设备 %s 的模板分配失败: %v", deviceID, err)
+		return "", false
+	}
+	if a == nil {
+		return "", false
+	}
+
+	out, t, err := s.RenderTemplate(ctx, a.TemplateName, a.Version, vars)
+	if err != nil {
+		s.logger.WarnTag("提示词", "渲染模板 %s 失败，使用默认提示词: %v", a.TemplateName, err)
+		return "", false
+	}
+	s.logger.DebugTag("提示词", "设备 %s 使用模板 %s 版本 %d", deviceID, t.Name, t.Version)
+	return out, true
+}
@@ -0,0 +1,162 @@
+package protocol
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	devicev1 "xiaozhi-server-go/gen/go/api/device/v1"
+)
+
+// 消息帧格式，定义见 api/device/v1/device.proto
+const (
+	FramingJSON     = "json"     // 控制消息为 JSON 文本帧，音频为裸二进制帧
+	FramingProtobuf = "protobuf" // 所有消息都是 protobuf 编码的二进制帧
+)
+
+// WebSocket 消息类型，与 gorilla/websocket 的取值一致
+const (
+	MessageText   = 1
+	MessageBinary = 2
+)
+
+var supportedFramings = []string{FramingProtobuf, FramingJSON}
+
+// 可编码为类型化帧的消息及其允许的字段；含其它字段的消息以 json 帧原样发送，保证转换无损
+var typedFields = map[string]map[string]bool{
+	"listen": {"type": true, "session_id": true, "state": true, "mode": true, "text": true},
+	"abort":  {"type": true, "session_id": true, "reason": true},
+	"tts":    {"type": true, "session_id": true, "state": true, "text": true, "index": true, "audio_codec": true},
+	"stt":    {"type": true, "session_id": true, "text": true},
+	"llm":    {"type": true, "session_id": true, "text": true, "emotion": true},
+}
+
+// negotiateFraming 按设备的优先级选择帧格式，设备未声明或都不支持时使用 JSON
+func negotiateFraming(framings []string) string {
+	for _, framing := range framings {
+		for _, supported := range supportedFramings {
+			if framing == supported {
+				return supported
+			}
+		}
+	}
+	return FramingJSON
+}
+
+// typedMessage 类型化帧对应的 JSON 字段
+type typedMessage struct {
+	Type       string `json:"type"`
+	State      string `json:"state,omitempty"`
+	Mode       string `json:"mode,omitempty"`
+	Text       string `json:"text,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	Index      *int32 `json:"index,omitempty"`
+	AudioCodec string `json:"audio_codec,omitempty"`
+	Emotion    string `json:"emotion,omitempty"`
+}
+
+// EncodeFrame 把 JSON 文本消息或音频数据编码为 protobuf 帧
+func EncodeFrame(messageType int, data []byte) ([]byte, error) {
+	frame := &devicev1.Frame{}
+	switch messageType {
+	case MessageBinary:
+		frame.Body = &devicev1.Frame_Audio{Audio: &devicev1.Audio{Data: data}}
+	case MessageText:
+		frame = encodeText(data)
+	default:
+		return nil, fmt.Errorf("不支持编码消息类型 %d", messageType)
+	}
+	return proto.Marshal(frame)
+}
+
+func encodeText(data []byte) *devicev1.Frame {
+	raw := &devicev1.Frame{Body: &devicev1.Frame_Json{Json: data}}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return raw
+	}
+	var msg typedMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return raw
+	}
+	allowed := typedFields[msg.Type]
+	if allowed == nil {
+		return raw
+	}
+	for name := range fields {
+		if !allowed[name] {
+			return raw
+		}
+	}
+
+	switch msg.Type {
+	case "listen":
+		return &devicev1.Frame{Body: &devicev1.Frame_Listen{Listen: &devicev1.Listen{State: msg.State, Mode: msg.Mode, Text: msg.Text}}}
+	case "abort":
+		return &devicev1.Frame{Body: &devicev1.Frame_Abort{Abort: &devicev1.Abort{Reason: msg.Reason}}}
+	case "tts":
+		tts := &devicev1.Tts{State: msg.State, Text: msg.Text, AudioCodec: msg.AudioCodec}
+		if msg.Index != nil {
+			tts.Index = *msg.Index
+		}
+		return &devicev1.Frame{Body: &devicev1.Frame_Tts{Tts: tts}}
+	case "stt":
+		return &devicev1.Frame{Body: &devicev1.Frame_Stt{Stt: &devicev1.Stt{Text: msg.Text}}}
+	case "llm":
+		return &devicev1.Frame{Body: &devicev1.Frame_Emotion{Emotion: &devicev1.Emotion{Emotion: msg.Emotion, Text: msg.Text}}}
+	}
+	return raw
+}
+
+// DecodeFrame 把 protobuf 帧还原为 JSON 文本消息或音频数据，sessionID 非空时写入还原的 JSON 消息
+func DecodeFrame(data []byte, sessionID string) (int, []byte, error) {
+	var frame devicev1.Frame
+	if err := proto.Unmarshal(data, &frame); err != nil {
+		return 0, nil, fmt.Errorf("解析 protobuf 帧失败: %w", err)
+	}
+
+	var msg map[string]interface{}
+	switch body := frame.Body.(type) {
+	case *devicev1.Frame_Audio:
+		return MessageBinary, body.Audio.GetData(), nil
+	case *devicev1.Frame_Json:
+		return MessageText, body.Json, nil
+	case *devicev1.Frame_Listen:
+		msg = map[string]interface{}{"type": "listen"}
+		setString(msg, "state", body.Listen.GetState())
+		setString(msg, "mode", body.Listen.GetMode())
+		setString(msg, "text", body.Listen.GetText())
+	case *devicev1.Frame_Abort:
+		msg = map[string]interface{}{"type": "abort"}
+		setString(msg, "reason", body.Abort.GetReason())
+	case *devicev1.Frame_Tts:
+		msg = map[string]interface{}{"type": "tts", "index": body.Tts.GetIndex()}
+		setString(msg, "state", body.Tts.GetState())
+		setString(msg, "text", body.Tts.GetText())
+		setString(msg, "audio_codec", body.Tts.GetAudioCodec())
+	case *devicev1.Frame_Stt:
+		msg = map[string]interface{}{"type": "stt", "text": body.Stt.GetText()}
+	case *devicev1.Frame_Emotion:
+		msg = map[string]interface{}{"type": "llm"}
+		setString(msg, "emotion", body.Emotion.GetEmotion())
+		setString(msg, "text", body.Emotion.GetText())
+	default:
+		return 0, nil, fmt.Errorf("protobuf 帧没有内容")
+	}
+	setString(msg, "session_id", sessionID)
+
+	text, err := json.Marshal(msg)
+	if err != nil {
+		return 0, nil, err
+	}
+	return MessageText, text, nil
+}
+
+// setString 只写入非空字符串，与 JSON 协议中省略字段的语义一致
+func setString(msg map[string]interface{}, key, value string) {
+	if value != "" {
+		msg[key] = value
+	}
+}
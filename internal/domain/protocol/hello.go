@@ -0,0 +1,336 @@
+// Package protocol 设备 WebSocket 协议的握手与能力协商
+//
+// 设备连接后发送 hello 消息，声明协议版本、支持的音频编码和特性；服务端据此协商出双方都支持的
+// 工作方式并在 hello 回复中返回。无法兼容时回复带错误码的 hello 并关闭连接，设备可根据错误码
+// 提示升级固件或调整配置，而不是在后续交互中出现难以排查的异常。
+package protocol
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+)
+
+// 服务端支持的协议版本范围
+const (
+	MinVersion = 1
+	MaxVersion = 1
+)
+
+// TransportWebSocket 当前唯一支持的传输方式
+const TransportWebSocket = "websocket"
+
+// 音频编码
+const (
+	CodecOpus = "opus"
+	CodecPCM  = "pcm"
+)
+
+// 服务端支持的可选特性，hello 中声明的其它特性不会出现在协商结果中
+const (
+	FeatureMCP      = "mcp"      // 设备端 MCP 工具
+	FeatureIoT      = "iot"      // IoT 设备描述与状态
+	FeatureImage    = "image"    // 上传图片进行识别
+	FeatureFeedback = "feedback" // 对回复进行评价
+	FeatureErrors   = "errors"   // 接收带类别和错误码的结构化错误消息
+	FeatureDebug    = "debug"    // 远程调试通道：读取日志、执行诊断命令、临时配置
+)
+
+// 握手失败的错误码
+const (
+	ErrCodeHelloInvalid         = "HELLO_INVALID"                // hello 消息格式错误
+	ErrCodeVersionUnsupported   = "PROTOCOL_VERSION_UNSUPPORTED" // 协议版本不在服务端支持的范围内
+	ErrCodeTransportUnsupported = "TRANSPORT_UNSUPPORTED"        // 传输方式不受支持
+	ErrCodeCodecUnsupported     = "AUDIO_CODEC_UNSUPPORTED"      // 没有双方都支持的音频编码
+	ErrCodeAudioParamsInvalid   = "AUDIO_PARAMS_INVALID"         // 采样率、声道数等音频参数无效
+	ErrCodeFeatureUnsupported   = "FEATURE_UNSUPPORTED"          // 设备要求的特性服务端不支持
+)
+
+var (
+	supportedCodecs   = []string{CodecOpus, CodecPCM}
+	supportedFeatures = map[string]bool{FeatureMCP: true, FeatureIoT: true, FeatureImage: true, FeatureFeedback: true, FeatureErrors: true, FeatureDebug: true}
+	opusSampleRates   = map[int]bool{8000: true, 12000: true, 16000: true, 24000: true, 48000: true}
+	frameDurations    = map[int]bool{10: true, 20: true, 40: true, 60: true, 80: true, 100: true, 120: true}
+)
+
+// 下行 opus 码率范围（bps）
+const (
+	MinOpusBitrate = 6000
+	MaxOpusBitrate = 510000
+)
+
+// AudioParams 音频参数
+type AudioParams struct {
+	Format        string `json:"format,omitempty"`
+	SampleRate    int    `json:"sample_rate,omitempty"`
+	Channels      int    `json:"channels,omitempty"`
+	FrameDuration int    `json:"frame_duration,omitempty"` // 帧时长，毫秒
+	Bitrate       int    `json:"bitrate,omitempty"`        // opus 码率，bps，仅用于下行
+}
+
+// Hello 设备发送的握手消息
+type Hello struct {
+	Version           int             `json:"version"`                       // 设备使用的最高协议版本，缺省视为 1
+	MinVersion        int             `json:"min_version,omitempty"`         // 设备能接受的最低协议版本，缺省与 Version 相同
+	Transport         string          `json:"transport,omitempty"`           // 缺省为 websocket
+	AudioParams       *AudioParams    `json:"audio_params,omitempty"`        // 上行音频参数
+	OutputAudioParams *AudioParams    `json:"output_audio_params,omitempty"` // 期望的下行（TTS）音频参数，未声明的字段使用服务端配置
+	Codecs            []string        `json:"codecs,omitempty"`              // 支持的音频编码，按优先级排列，缺省取 audio_params.format
+	Features          map[string]bool `json:"features,omitempty"`            // 设备支持的特性
+	RequiredFeatures  []string        `json:"required_features,omitempty"`   // 服务端必须支持的特性，缺一不可
+	Framings          []string        `json:"framings,omitempty"`            // 支持的帧格式，按优先级排列，缺省为 json
+}
+
+// ParseHello 解析 hello 消息，fallbackVersion 为 hello 中没有版本时使用的版本（如 Protocol-Version 请求头）
+func ParseHello(data []byte, fallbackVersion int) (Hello, *Error) {
+	var hello Hello
+	if err := json.Unmarshal(data, &hello); err != nil {
+		return hello, newError(ErrCodeHelloInvalid, fmt.Sprintf("hello 消息格式错误: %v", err))
+	}
+	if hello.Version == 0 {
+		hello.Version = fallbackVersion
+	}
+	return hello, nil
+}
+
+// Session 协商结果，连接期间保持不变
+type Session struct {
+	Version     int
+	Codec       string          // 上行音频编码
+	AudioParams AudioParams     // 上行音频参数，未声明的字段为零值
+	Output      AudioParams     // 下行音频参数，各字段均已确定
+	Features    map[string]bool // 双方都支持的特性
+	Framing     string          // 握手之后的帧格式
+}
+
+// HasFeature 是否协商了某个特性
+func (s *Session) HasFeature(name string) bool {
+	return s != nil && s.Features[name]
+}
+
+// Supported 服务端支持的协议能力，握手失败时返回给设备
+type Supported struct {
+	MinVersion int      `json:"min_version"`
+	MaxVersion int      `json:"max_version"`
+	Transports []string `json:"transports"`
+	Codecs     []string `json:"codecs"`
+	Features   []string `json:"features"`
+	Framings   []string `json:"framings"`
+}
+
+// ServerSupported 返回服务端支持的协议能力
+func ServerSupported() Supported {
+	features := make([]string, 0, len(supportedFeatures))
+	for name := range supportedFeatures {
+		features = append(features, name)
+	}
+	sort.Strings(features)
+	return Supported{
+		MinVersion: MinVersion,
+		MaxVersion: MaxVersion,
+		Transports: []string{TransportWebSocket},
+		Codecs:     append([]string(nil), supportedCodecs...),
+		Features:   features,
+		Framings:   append([]string(nil), supportedFramings...),
+	}
+}
+
+// Error 握手失败
+type Error struct {
+	Code    string
+	Message string
+}
+
+func newError(code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+// UserCategory 音频编码和参数错误归为 audio_format，其它握手错误不对应用户类别
+func (e *Error) UserCategory() platformerrors.Category {
+	switch e.Code {
+	case ErrCodeCodecUnsupported, ErrCodeAudioParamsInvalid:
+		return platformerrors.CategoryAudioFormat
+	}
+	return ""
+}
+
+// Negotiate 协商协议版本、音频编码和特性，不兼容时返回带错误码的 Error
+// output 为服务端配置的下行音频参数，设备未在 output_audio_params 中声明的字段取该值
+func Negotiate(hello Hello, output AudioParams) (*Session, *Error) {
+	version, err := negotiateVersion(hello)
+	if err != nil {
+		return nil, err
+	}
+
+	if hello.Transport != "" && !strings.EqualFold(hello.Transport, TransportWebSocket) {
+		return nil, newError(ErrCodeTransportUnsupported,
+			fmt.Sprintf("不支持传输方式 %s，请使用 %s", hello.Transport, TransportWebSocket))
+	}
+
+	var params AudioParams
+	if hello.AudioParams != nil {
+		params = *hello.AudioParams
+	}
+	codec, err := negotiateCodec(hello.Codecs, params.Format)
+	if err != nil {
+		return nil, err
+	}
+	params.Format = codec
+	if err := validateAudioParams(params); err != nil {
+		return nil, err
+	}
+
+	output, err = negotiateOutput(hello.OutputAudioParams, codec, output)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range hello.RequiredFeatures {
+		if !supportedFeatures[name] {
+			return nil, newError(ErrCodeFeatureUnsupported, fmt.Sprintf("服务端不支持特性 %s", name))
+		}
+	}
+	features := make(map[string]bool)
+	for name, enabled := range hello.Features {
+		if enabled && supportedFeatures[name] {
+			features[name] = true
+		}
+	}
+	for _, name := range hello.RequiredFeatures {
+		features[name] = true
+	}
+
+	return &Session{
+		Version:     version,
+		Codec:       codec,
+		AudioParams: params,
+		Output:      output,
+		Features:    features,
+		Framing:     negotiateFraming(hello.Framings),
+	}, nil
+}
+
+// negotiateVersion 取设备最高版本与服务端最高版本中较小者，低于任一方的最低版本时失败
+func negotiateVersion(hello Hello) (int, *Error) {
+	clientMax := hello.Version
+	if clientMax <= 0 {
+		clientMax = MinVersion
+	}
+	clientMin := hello.MinVersion
+	if clientMin <= 0 || clientMin > clientMax {
+		clientMin = clientMax
+	}
+
+	version := clientMax
+	if version > MaxVersion {
+		version = MaxVersion
+	}
+	switch {
+	case clientMax < MinVersion:
+		return 0, newError(ErrCodeVersionUnsupported,
+			fmt.Sprintf("协议版本 %d 过旧，服务端支持 %d-%d，请升级设备固件", clientMax, MinVersion, MaxVersion))
+	case version < clientMin:
+		return 0, newError(ErrCodeVersionUnsupported,
+			fmt.Sprintf("设备要求协议版本不低于 %d，服务端最高支持 %d，请升级服务端", clientMin, MaxVersion))
+	}
+	return version, nil
+}
+
+// negotiateCodec 按设备的优先级选择第一个服务端支持的编码，设备未声明时使用 opus
+func negotiateCodec(codecs []string, format string) (string, *Error) {
+	if len(codecs) == 0 && format != "" {
+		codecs = []string{format}
+	}
+	if len(codecs) == 0 {
+		return CodecOpus, nil
+	}
+	for _, codec := range codecs {
+		for _, supported := range supportedCodecs {
+			if strings.EqualFold(codec, supported) {
+				return supported, nil
+			}
+		}
+	}
+	return "", newError(ErrCodeCodecUnsupported,
+		fmt.Sprintf("不支持音频编码 %s，服务端支持 %s", strings.Join(codecs, ","), strings.Join(supportedCodecs, ",")))
+}
+
+func validateAudioParams(params AudioParams) *Error {
+	if params.SampleRate < 0 || params.Channels < 0 || params.FrameDuration < 0 {
+		return newError(ErrCodeAudioParamsInvalid, "音频参数不能为负数")
+	}
+	if params.Channels > 2 {
+		return newError(ErrCodeAudioParamsInvalid, fmt.Sprintf("不支持 %d 声道音频，最多 2 声道", params.Channels))
+	}
+	if params.SampleRate == 0 {
+		return nil
+	}
+	switch params.Format {
+	case CodecOpus:
+		if !opusSampleRates[params.SampleRate] {
+			return newError(ErrCodeAudioParamsInvalid,
+				fmt.Sprintf("opus 不支持采样率 %d，可选 8000、12000、16000、24000、48000", params.SampleRate))
+		}
+	case CodecPCM:
+		if params.SampleRate < 8000 || params.SampleRate > 48000 {
+			return newError(ErrCodeAudioParamsInvalid,
+				fmt.Sprintf("pcm 采样率 %d 超出范围 8000-48000", params.SampleRate))
+		}
+	}
+	return nil
+}
+
+// negotiateOutput 确定下行音频参数：格式缺省与上行编码一致，其余字段缺省取服务端配置；
+// 设备显式声明的参数不合法时失败，而不是悄悄改用其它参数导致设备播放异常
+func negotiateOutput(requested *AudioParams, uplinkCodec string, defaults AudioParams) (AudioParams, *Error) {
+	output := defaults
+	output.Format = uplinkCodec
+	output.Channels = 1
+	if requested != nil {
+		if requested.Format != "" {
+			codec, err := negotiateCodec([]string{requested.Format}, "")
+			if err != nil {
+				return output, err
+			}
+			output.Format = codec
+		}
+		if requested.Channels > 1 {
+			return output, newError(ErrCodeAudioParamsInvalid, "下行音频仅支持单声道")
+		}
+		if requested.SampleRate != 0 {
+			output.SampleRate = requested.SampleRate
+		}
+		if requested.FrameDuration != 0 {
+			output.FrameDuration = requested.FrameDuration
+		}
+		if requested.Bitrate != 0 {
+			output.Bitrate = requested.Bitrate
+		}
+	}
+	if output.Format == CodecPCM {
+		output.Bitrate = 0
+	}
+
+	if err := validateAudioParams(output); err != nil {
+		return output, err
+	}
+	if output.SampleRate == 0 {
+		return output, newError(ErrCodeAudioParamsInvalid, "下行音频缺少采样率")
+	}
+	if !frameDurations[output.FrameDuration] {
+		return output, newError(ErrCodeAudioParamsInvalid,
+			fmt.Sprintf("不支持下行帧时长 %dms，可选 10、20、40、60、80、100、120", output.FrameDuration))
+	}
+	if output.Bitrate != 0 && (output.Bitrate < MinOpusBitrate || output.Bitrate > MaxOpusBitrate) {
+		return output, newError(ErrCodeAudioParamsInvalid,
+			fmt.Sprintf("opus 码率 %d 超出范围 %d-%d", output.Bitrate, MinOpusBitrate, MaxOpusBitrate))
+	}
+	return output, nil
+}
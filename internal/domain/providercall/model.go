@@ -0,0 +1,40 @@
+package providercall
+
+import (
+	stderrors "errors"
+	"time"
+)
+
+// ErrNotFound 调用录制不存在
+var ErrNotFound = stderrors.New("provider call not found")
+
+// Call 一次被录制的提供者调用，请求和响应均已脱敏
+type Call struct {
+	ID             string    `json:"id"`
+	ProviderID     string    `json:"provider_id"`
+	CapabilityID   string    `json:"capability_id"`
+	CapabilityType string    `json:"capability_type"`
+	DeviceID       string    `json:"device_id"`
+	SessionID      string    `json:"session_id"`
+	Request        string    `json:"request"`  // JSON，包含 config 和 inputs
+	Response       string    `json:"response"` // JSON，非流式调用为输出，流式调用为分块数组
+	Stream         bool      `json:"stream"`
+	Chunks         int       `json:"chunks"`
+	Success        bool      `json:"success"`
+	Error          string    `json:"error"`
+	DurationMs     int64     `json:"duration_ms"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Filter 调用录制查询条件
+type Filter struct {
+	ProviderID   string
+	CapabilityID string
+	DeviceID     string
+	Success      *bool  // nil 表示不按结果过滤
+	Query        string // 在请求、响应和错误信息中搜索的文本
+	From         time.Time
+	To           time.Time
+	Page         int
+	PageSize     int
+}
@@ -0,0 +1,24 @@
+package providercall
+
+import (
+	"context"
+	"time"
+)
+
+// Repository 调用录制仓库接口
+type Repository interface {
+	// SaveBatch 批量写入调用录制
+	SaveBatch(ctx context.Context, calls []*Call) error
+
+	// List 按条件分页查询调用录制，新记录在前
+	List(ctx context.Context, filter Filter) ([]*Call, int64, error)
+
+	// FindByID 根据ID查找调用录制，不存在时返回 nil
+	FindByID(ctx context.Context, id string) (*Call, error)
+
+	// DeleteBefore 删除早于指定时间的调用录制
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+
+	// DeleteByDevice 删除设备的全部调用录制
+	DeleteByDevice(ctx context.Context, deviceID string) (int64, error)
+}
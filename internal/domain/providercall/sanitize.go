@@ -0,0 +1,133 @@
+package providercall
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+const (
+	masked = "******"
+	// maxStringLen 单个字符串值的长度上限，base64 音频等大字段只保留开头
+	maxStringLen = 2048
+)
+
+// credentialPatterns 出现在字符串值中的凭证，如错误信息里回显的请求头
+var credentialPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/=-]+`),
+	regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{16,}`),
+}
+
+// secretConfigKeys 能力配置 schema 中标记为密钥的字段
+func secretConfigKeys(def capability.Definition) map[string]bool {
+	keys := make(map[string]bool)
+	for name, prop := range def.ConfigSchema.Properties {
+		if prop.Secret {
+			keys[name] = true
+		}
+	}
+	return keys
+}
+
+// isSecretKey 按字段名判断是否为密钥，如 api_key、access_token、password
+func isSecretKey(name string) bool {
+	n := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+	return strings.HasSuffix(n, "key") || strings.HasSuffix(n, "token") ||
+		strings.Contains(n, "secret") || strings.Contains(n, "password") ||
+		strings.Contains(n, "authorization") || strings.Contains(n, "credential")
+}
+
+// sanitizeMap 递归脱敏：密钥字段替换为 ******，二进制数据只记录长度，过长的字符串截断
+func sanitizeMap(m map[string]interface{}, secrets map[string]bool) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if (secrets[k] || isSecretKey(k)) && !isEmpty(v) {
+			out[k] = masked
+			continue
+		}
+		out[k] = sanitizeValue(v)
+	}
+	return out
+}
+
+func sanitizeValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case nil, bool, int, int32, int64, uint, uint32, uint64, float32, float64, json.Number:
+		return val
+	case string:
+		return scrubString(val)
+	case []byte:
+		return fmt.Sprintf("<%d bytes>", len(val))
+	case map[string]interface{}:
+		return sanitizeMap(val, nil)
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = sanitizeValue(item)
+		}
+		return out
+	case []map[string]interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = sanitizeMap(item, nil)
+		}
+		return out
+	}
+	// 其他类型（结构体、类型化切片）先转为通用 JSON 结构再脱敏，无法序列化的只记录类型
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("<%T>", v)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return fmt.Sprintf("<%T>", v)
+	}
+	return sanitizeValue(generic)
+}
+
+// scrubString 替换字符串中的凭证并截断过长内容
+func scrubString(s string) string {
+	for _, p := range credentialPatterns {
+		s = p.ReplaceAllString(s, masked)
+	}
+	return truncate(s, maxStringLen)
+}
+
+// truncate 按字节上限截断，不拆分多字节字符，并注明截掉的长度
+func truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s...(truncated %d bytes)", s[:cut], len(s)-cut)
+}
+
+func isEmpty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	s, ok := v.(string)
+	return ok && s == ""
+}
+
+// marshal 序列化为 JSON，不转义 <>& 以便阅读
+func marshal(v interface{}) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprintf("<unserializable: %v>", err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
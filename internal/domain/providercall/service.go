@@ -0,0 +1,240 @@
+package providercall
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/domain/toolpolicy"
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/observability"
+	"xiaozhi-server-go/internal/platform/redaction"
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+const (
+	queueSize      = 256
+	flushBatchSize = 20
+	flushInterval  = time.Second
+	purgeInterval  = time.Hour
+	// defaultMaxPayload 请求和响应 JSON 的默认长度上限
+	defaultMaxPayload = 16384
+)
+
+// Service 提供者调用录制服务，实现 capability.Recorder
+//
+// 按提供者采样能力调用，脱敏后异步批量写入，并按保留时长清理过期录制。
+type Service struct {
+	cfg        config.ProviderCallsConfig
+	repo       Repository
+	logger     *logging.Logger
+	redactor   *redaction.Redactor // 为 nil 时不做个人信息脱敏
+	retention  time.Duration       // <=0 表示永久保留
+	maxPayload int
+	now        func() time.Time
+
+	randMu sync.Mutex
+	rand   *rand.Rand
+
+	queue chan *Call
+}
+
+// NewService 根据配置创建提供者调用录制服务
+func NewService(cfg config.ProviderCallsConfig, repo Repository, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	maxPayload := cfg.MaxPayloadBytes
+	if maxPayload <= 0 {
+		maxPayload = defaultMaxPayload
+	}
+	return &Service{
+		cfg:        cfg,
+		repo:       repo,
+		logger:     logger,
+		retention:  time.Duration(cfg.RetentionHours) * time.Hour,
+		maxPayload: maxPayload,
+		now:        time.Now,
+		rand:       rand.New(rand.NewSource(time.Now().UnixNano())),
+		queue:      make(chan *Call, queueSize),
+	}
+}
+
+// SetRedactor 设置个人信息脱敏器，录制的请求和响应写入前先脱敏
+func (s *Service) SetRedactor(redactor *redaction.Redactor) {
+	s.redactor = redactor
+}
+
+// SampleRate 提供者的采样率，未单独配置时使用默认采样率
+func (s *Service) SampleRate(providerID string) float64 {
+	if rate, ok := s.cfg.Providers[providerID]; ok {
+		return rate
+	}
+	return s.cfg.SampleRate
+}
+
+// Sample 实现 capability.Recorder，按提供者采样率决定是否录制
+func (s *Service) Sample(providerID string, def capability.Definition) bool {
+	rate := s.SampleRate(providerID)
+	if rate <= 0 {
+		return false
+	}
+	if rate >= 1 {
+		return true
+	}
+	s.randMu.Lock()
+	defer s.randMu.Unlock()
+	return s.rand.Float64() < rate
+}
+
+// Record 实现 capability.Recorder，脱敏后放入写入队列，不阻塞调用方；队列满时丢弃并记录日志
+func (s *Service) Record(ctx context.Context, rc capability.RecordedCall) {
+	call := &Call{
+		ID:             uuid.New().String(),
+		ProviderID:     rc.ProviderID,
+		CapabilityID:   rc.Definition.ID,
+		CapabilityType: string(rc.Definition.Type),
+		Stream:         rc.Chunks != nil,
+		Chunks:         len(rc.Chunks),
+		Success:        rc.Err == nil,
+		DurationMs:     rc.Duration.Milliseconds(),
+		CreatedAt:      rc.StartedAt,
+	}
+	if call.CreatedAt.IsZero() {
+		call.CreatedAt = s.now()
+	}
+	if subject, ok := toolpolicy.SubjectFrom(ctx); ok {
+		call.DeviceID = subject.DeviceID
+		call.SessionID = subject.SessionID
+	}
+	if rc.Err != nil {
+		call.Error = s.redact(scrubString(rc.Err.Error()))
+	}
+
+	secrets := secretConfigKeys(rc.Definition)
+	call.Request = s.payload(map[string]interface{}{
+		"config": sanitizeMap(rc.Config, secrets),
+		"inputs": sanitizeMap(rc.Inputs, nil),
+	})
+	if call.Stream {
+		chunks := make([]interface{}, len(rc.Chunks))
+		for i, chunk := range rc.Chunks {
+			chunks[i] = sanitizeMap(chunk, nil)
+		}
+		call.Response = s.payload(chunks)
+	} else if rc.Outputs != nil {
+		call.Response = s.payload(sanitizeMap(rc.Outputs, nil))
+	}
+
+	observability.RecordMetric(ctx, "provider_calls_recorded_total", 1, map[string]string{"provider": rc.ProviderID})
+	select {
+	case s.queue <- call:
+	default:
+		s.logger.WarnTag("调用录制", "录制队列已满，丢弃 %s 的调用录制", rc.Definition.ID)
+	}
+}
+
+// payload 序列化、脱敏并按长度上限截断
+func (s *Service) payload(v interface{}) string {
+	return truncate(s.redact(marshal(v)), s.maxPayload)
+}
+
+func (s *Service) redact(text string) string {
+	if s.redactor == nil {
+		return text
+	}
+	out, _ := s.redactor.Redact(text)
+	return out
+}
+
+// List 分页查询调用录制
+func (s *Service) List(ctx context.Context, filter Filter) ([]*Call, int64, error) {
+	return s.repo.List(ctx, filter)
+}
+
+// Get 获取调用录制的完整请求和响应
+func (s *Service) Get(ctx context.Context, id string) (*Call, error) {
+	call, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if call == nil {
+		return nil, errors.Wrap(errors.KindDomain, "providercall.get", "provider call not found", ErrNotFound)
+	}
+	return call, nil
+}
+
+// EraseDeviceData 删除设备的全部调用录制
+func (s *Service) EraseDeviceData(ctx context.Context, deviceID string) (int64, error) {
+	return s.repo.DeleteByDevice(ctx, deviceID)
+}
+
+// Run 启动录制写入和清理循环，直到 ctx 结束；退出前写入剩余录制
+func (s *Service) Run(ctx context.Context) error {
+	flushTicker := time.NewTicker(flushInterval)
+	defer flushTicker.Stop()
+	purgeTicker := time.NewTicker(purgeInterval)
+	defer purgeTicker.Stop()
+
+	s.purge(ctx)
+
+	batch := make([]*Call, 0, flushBatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+		drain:
+			for {
+				select {
+				case call := <-s.queue:
+					batch = append(batch, call)
+				default:
+					break drain
+				}
+			}
+			s.flush(context.Background(), batch)
+			return nil
+		case call := <-s.queue:
+			batch = append(batch, call)
+			if len(batch) >= flushBatchSize {
+				s.flush(ctx, batch)
+				batch = batch[:0]
+			}
+		case <-flushTicker.C:
+			if len(batch) > 0 {
+				s.flush(ctx, batch)
+				batch = batch[:0]
+			}
+		case <-purgeTicker.C:
+			s.purge(ctx)
+		}
+	}
+}
+
+func (s *Service) flush(ctx context.Context, batch []*Call) {
+	if len(batch) == 0 {
+		return
+	}
+	if err := s.repo.SaveBatch(ctx, batch); err != nil {
+		s.logger.ErrorTag("调用录制", "写入 %d 条调用录制失败: %v", len(batch), err)
+	}
+}
+
+// purge 按保留时长删除过期录制
+func (s *Service) purge(ctx context.Context) {
+	if s.retention <= 0 {
+		return
+	}
+	removed, err := s.repo.DeleteBefore(ctx, s.now().Add(-s.retention))
+	if err != nil {
+		s.logger.ErrorTag("调用录制", "清理过期调用录制失败: %v", err)
+		return
+	}
+	if removed > 0 {
+		s.logger.InfoTag("调用录制", "已清理 %d 条过期调用录制", removed)
+	}
+}
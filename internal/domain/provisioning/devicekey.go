@@ -0,0 +1,242 @@
+package provisioning
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/domain/tenant"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// clockSkew 校验 JWT 时间时允许的设备时钟偏差
+const clockSkew = time.Minute
+
+// jwtHeader 设备 JWT 的头部
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// jwtClaims 设备 JWT 的声明，sub 为设备ID
+type jwtClaims struct {
+	Sub string `json:"sub"`
+	Iat int64  `json:"iat"`
+	Exp int64  `json:"exp"`
+}
+
+// IssueKey 为设备签发新密钥，设备此后改用 JWT 认证，原静态令牌失效；
+// revokePrevious 为 true 时同时吊销设备的其他密钥，否则旧密钥在吊销前仍然有效，便于设备平滑切换
+func (s *Service) IssueKey(ctx context.Context, deviceID string, revokePrevious bool) (*IssuedKey, error) {
+	if err := s.checkDevice(ctx, "provisioning.issue_key", deviceID); err != nil {
+		return nil, err
+	}
+	issued, err := s.newKey(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.DeleteCredential(ctx, deviceID); err != nil {
+		return nil, err
+	}
+	if revokePrevious {
+		if err := s.revokeKeys(ctx, deviceID, issued.KeyID); err != nil {
+			return nil, err
+		}
+	}
+	s.logger.InfoTag("配对", "已为设备 %s 签发密钥 %s", deviceID, issued.KeyID)
+	return issued, nil
+}
+
+// ListKeys 列出设备的全部密钥，新密钥在前
+func (s *Service) ListKeys(ctx context.Context, deviceID string) ([]*DeviceKey, error) {
+	if err := s.checkDevice(ctx, "provisioning.list_keys", deviceID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListKeys(ctx, deviceID)
+}
+
+// RevokeKey 吊销设备密钥，之后用该密钥签名的 JWT 在握手时被拒绝
+func (s *Service) RevokeKey(ctx context.Context, deviceID, keyID string) error {
+	if err := s.checkDevice(ctx, "provisioning.revoke_key", deviceID); err != nil {
+		return err
+	}
+	revoked, err := s.repo.RevokeKey(ctx, deviceID, keyID, s.now())
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return errors.Wrap(errors.KindDomain, "provisioning.revoke_key", "device key not found: "+keyID, ErrKeyNotFound)
+	}
+	s.logger.InfoTag("配对", "已吊销设备 %s 的密钥 %s", deviceID, keyID)
+	return nil
+}
+
+// newKey 生成 P-256 密钥对，保存公钥并返回私钥
+func (s *Service) newKey(ctx context.Context, deviceID string) (*IssuedKey, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(errors.KindDomain, "provisioning.issue_key", "failed to generate device key", err)
+	}
+	privateDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, errors.Wrap(errors.KindDomain, "provisioning.issue_key", "failed to encode device key", err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(errors.KindDomain, "provisioning.issue_key", "failed to encode device key", err)
+	}
+
+	record := &DeviceKey{
+		ID:        uuid.New().String(),
+		DeviceID:  deviceID,
+		PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})),
+		CreatedAt: s.now(),
+	}
+	if err := s.repo.SaveKey(ctx, record); err != nil {
+		return nil, err
+	}
+	return &IssuedKey{
+		KeyID:      record.ID,
+		Algorithm:  KeyAlgorithm,
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateDER})),
+		PublicKey:  record.PublicKey,
+	}, nil
+}
+
+// revokeKeys 吊销设备除 keep 以外的全部有效密钥
+func (s *Service) revokeKeys(ctx context.Context, deviceID, keep string) error {
+	keys, err := s.repo.ListKeys(ctx, deviceID)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	for _, key := range keys {
+		if key.ID == keep || key.Revoked() {
+			continue
+		}
+		if _, err := s.repo.RevokeKey(ctx, deviceID, key.ID, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyJWT 校验设备用私钥签名的 JWT：alg 为 ES256，kid 为设备未吊销的密钥，sub 为设备ID，
+// iat 和 exp 在允许的时钟偏差内有效，且有效期不超过 JWTMaxAge
+func (s *Service) verifyJWT(ctx context.Context, deviceID, token string, keys []*DeviceKey) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("%w: malformed jwt", ErrBadCredential)
+	}
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return fmt.Errorf("%w: invalid jwt header", ErrBadCredential)
+	}
+	if header.Alg != KeyAlgorithm {
+		return fmt.Errorf("%w: unsupported alg %q", ErrBadCredential, header.Alg)
+	}
+	var key *DeviceKey
+	for _, k := range keys {
+		if k.ID == header.Kid {
+			key = k
+			break
+		}
+	}
+	if key == nil {
+		return fmt.Errorf("%w: unknown kid %q", ErrBadCredential, header.Kid)
+	}
+	if key.Revoked() {
+		return fmt.Errorf("%w: key %s revoked", ErrBadCredential, key.ID)
+	}
+
+	publicKey, err := parsePublicKey(key.PublicKey)
+	if err != nil {
+		return err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || len(signature) != 64 {
+		return fmt.Errorf("%w: invalid jwt signature", ErrBadCredential)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r := new(big.Int).SetBytes(signature[:32])
+	sig := new(big.Int).SetBytes(signature[32:])
+	if !ecdsa.Verify(publicKey, digest[:], r, sig) {
+		return fmt.Errorf("%w: jwt signature mismatch", ErrBadCredential)
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return fmt.Errorf("%w: invalid jwt claims", ErrBadCredential)
+	}
+	if claims.Sub != deviceID {
+		return fmt.Errorf("%w: jwt issued for %q", ErrBadCredential, claims.Sub)
+	}
+	now := s.now()
+	issuedAt, expiresAt := time.Unix(claims.Iat, 0), time.Unix(claims.Exp, 0)
+	switch {
+	case claims.Iat == 0 || claims.Exp == 0:
+		return fmt.Errorf("%w: jwt missing iat or exp", ErrBadCredential)
+	case issuedAt.After(now.Add(clockSkew)):
+		return fmt.Errorf("%w: jwt issued in the future", ErrBadCredential)
+	case !expiresAt.After(now.Add(-clockSkew)):
+		return fmt.Errorf("%w: jwt expired", ErrBadCredential)
+	case expiresAt.Sub(issuedAt) > s.cfg.JWTMaxAge:
+		return fmt.Errorf("%w: jwt lifetime exceeds %s", ErrBadCredential, s.cfg.JWTMaxAge)
+	}
+
+	if err := s.repo.TouchKey(ctx, key.ID, now); err != nil {
+		s.logger.WarnTag("配对", "记录设备 %s 密钥 %s 的使用时间失败: %v", deviceID, key.ID, err)
+	}
+	return nil
+}
+
+// checkDevice 检查设备存在且属于请求所在的租户
+func (s *Service) checkDevice(ctx context.Context, op, deviceID string) error {
+	device, err := s.devices.FindByDeviceID(ctx, deviceID)
+	if err != nil {
+		return err
+	}
+	if id, scoped := tenant.FromContext(ctx); device != nil && scoped && tenantOf(device) != id {
+		device = nil
+	}
+	if device == nil {
+		return errors.Wrap(errors.KindDomain, op, "device not found: "+deviceID, ErrDeviceNotFound)
+	}
+	return nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func parsePublicKey(data string) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, fmt.Errorf("invalid public key PEM")
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse public key: %w", err)
+	}
+	publicKey, ok := parsed.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key is not ECDSA")
+	}
+	return publicKey, nil
+}
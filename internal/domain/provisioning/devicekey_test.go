@@ -0,0 +1,157 @@
+package provisioning
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+// stubRepo 只实现设备认证用到的方法
+type stubRepo struct {
+	Repository
+	keys []*DeviceKey
+}
+
+func (r *stubRepo) ListKeys(ctx context.Context, deviceID string) ([]*DeviceKey, error) {
+	return r.keys, nil
+}
+
+func (r *stubRepo) FindCredential(ctx context.Context, deviceID string) (*Credential, error) {
+	return nil, nil
+}
+
+func (r *stubRepo) TouchKey(ctx context.Context, keyID string, at time.Time) error {
+	return nil
+}
+
+func newTestKey(t *testing.T, id string, revoked bool) (*ecdsa.PrivateKey, *DeviceKey) {
+	t.Helper()
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := &DeviceKey{
+		ID:        id,
+		DeviceID:  "aa:bb",
+		PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+	}
+	if revoked {
+		at := time.Now()
+		key.RevokedAt = &at
+	}
+	return private, key
+}
+
+func signJWT(t *testing.T, private *ecdsa.PrivateKey, header jwtHeader, claims jwtClaims) string {
+	t.Helper()
+	encode := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signing := encode(header) + "." + encode(claims)
+	digest := sha256.Sum256([]byte(signing))
+	r, s, err := ecdsa.Sign(rand.Reader, private, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return signing + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestAuthenticateJWT(t *testing.T) {
+	logger, err := logging.New(logging.Config{Level: "error", Dir: t.TempDir(), Filename: "test.log"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1_800_000_000, 0)
+	active, activeKey := newTestKey(t, "key-active", false)
+	revoked, revokedKey := newTestKey(t, "key-revoked", true)
+	other, _ := newTestKey(t, "key-other", false)
+
+	valid := jwtClaims{Sub: "aa:bb", Iat: now.Unix(), Exp: now.Add(5 * time.Minute).Unix()}
+	es256 := func(kid string) jwtHeader { return jwtHeader{Alg: KeyAlgorithm, Kid: kid} }
+	with := func(change func(*jwtClaims)) jwtClaims {
+		c := valid
+		change(&c)
+		return c
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		ok    bool
+	}{
+		{"valid", signJWT(t, active, es256("key-active"), valid), true},
+		{"within clock skew after exp", signJWT(t, active, es256("key-active"), with(func(c *jwtClaims) {
+			c.Iat, c.Exp = now.Add(-5*time.Minute).Unix(), now.Add(-30*time.Second).Unix()
+		})), true},
+		{"expired", signJWT(t, active, es256("key-active"), with(func(c *jwtClaims) {
+			c.Iat, c.Exp = now.Add(-10*time.Minute).Unix(), now.Add(-2*time.Minute).Unix()
+		})), false},
+		{"issued in the future", signJWT(t, active, es256("key-active"), with(func(c *jwtClaims) {
+			c.Iat, c.Exp = now.Add(5*time.Minute).Unix(), now.Add(8*time.Minute).Unix()
+		})), false},
+		{"lifetime too long", signJWT(t, active, es256("key-active"), with(func(c *jwtClaims) {
+			c.Exp = now.Add(time.Hour).Unix()
+		})), false},
+		{"missing exp", signJWT(t, active, es256("key-active"), with(func(c *jwtClaims) { c.Exp = 0 })), false},
+		{"other device", signJWT(t, active, es256("key-active"), with(func(c *jwtClaims) { c.Sub = "cc:dd" })), false},
+		{"unknown kid", signJWT(t, active, es256("key-missing"), valid), false},
+		{"wrong kid for signing key", signJWT(t, revoked, es256("key-active"), valid), false},
+		{"revoked kid", signJWT(t, revoked, es256("key-revoked"), valid), false},
+		{"foreign key", signJWT(t, other, es256("key-active"), valid), false},
+		{"alg none", signJWT(t, active, jwtHeader{Alg: "none", Kid: "key-active"}, valid), false},
+		{"malformed", "not-a-jwt", false},
+		{"static token", "dev_0123456789", false},
+	}
+
+	svc := NewService(config.ProvisioningConfig{RequireCredential: true, DeviceAuth: AuthJWT, JWTMaxAge: 10 * time.Minute},
+		"", &stubRepo{keys: []*DeviceKey{activeKey, revokedKey}}, nil, nil, nil, logger)
+	svc.now = func() time.Time { return now }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.Authenticate(context.Background(), "aa:bb", tt.token)
+			if tt.ok && err != nil {
+				t.Fatalf("Authenticate() error = %v, want nil", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrBadCredential) {
+				t.Fatalf("Authenticate() error = %v, want ErrBadCredential", err)
+			}
+		})
+	}
+}
+
+func TestAuthenticateAllKeysRevoked(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	private, key := newTestKey(t, "key-revoked", true)
+	svc := NewService(config.ProvisioningConfig{RequireCredential: true, DeviceAuth: AuthJWT},
+		"", &stubRepo{keys: []*DeviceKey{key}}, nil, nil, nil, logging.DefaultLogger)
+	svc.now = func() time.Time { return now }
+
+	token := signJWT(t, private, jwtHeader{Alg: KeyAlgorithm, Kid: key.ID},
+		jwtClaims{Sub: "aa:bb", Iat: now.Unix(), Exp: now.Add(time.Minute).Unix()})
+	if err := svc.Authenticate(context.Background(), "aa:bb", token); !errors.Is(err, ErrBadCredential) {
+		t.Fatalf("Authenticate() error = %v, want ErrBadCredential", err)
+	}
+}
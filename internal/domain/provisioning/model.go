@@ -0,0 +1,102 @@
+package provisioning
+
+import (
+	stderrors "errors"
+	"time"
+)
+
+var (
+	// ErrUserNotFound 签发令牌的用户不存在
+	ErrUserNotFound = stderrors.New("user not found")
+	// ErrTokenInvalid 配对令牌不存在、已过期或已被使用
+	ErrTokenInvalid = stderrors.New("pairing token invalid or expired")
+	// ErrDeviceClaimed 设备已归属其他用户或租户
+	ErrDeviceClaimed = stderrors.New("device already claimed")
+	// ErrBadCredential 设备凭证不匹配
+	ErrBadCredential = stderrors.New("invalid device credential")
+	// ErrDeviceNotFound 设备不存在或不属于当前租户
+	ErrDeviceNotFound = stderrors.New("device not found")
+	// ErrKeyNotFound 设备密钥不存在
+	ErrKeyNotFound = stderrors.New("device key not found")
+)
+
+// 配对时签发的设备凭证类型
+const (
+	AuthToken = "token" // 静态令牌，握手时原样携带
+	AuthJWT   = "jwt"   // 设备密钥，握手时携带用私钥签名的短期 JWT
+)
+
+// KeyAlgorithm 设备密钥的签名算法
+const KeyAlgorithm = "ES256"
+
+// PairingToken 配对令牌，只保存令牌的哈希
+type PairingToken struct {
+	ID        string     `json:"id"`
+	TokenHash string     `json:"-"`
+	UserID    uint       `json:"user_id"`   // 签发令牌的用户，配对后成为设备所有者
+	TenantID  string     `json:"tenant_id"` // 设备归属的租户
+	ExpiresAt time.Time  `json:"expires_at"`
+	ClaimedAt *time.Time `json:"claimed_at,omitempty"`
+	DeviceID  string     `json:"device_id,omitempty"` // 用令牌配对的设备
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Pairing 新签发的配对令牌，令牌明文只在签发时返回一次
+type Pairing struct {
+	Token     string    `json:"token"`
+	Payload   string    `json:"payload"` // 二维码内容
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Claim 设备或配套 App 用令牌换取凭证的请求
+type Claim struct {
+	Token     string
+	DeviceID  string
+	ClientID  string // 为空时使用 client_<设备ID>
+	Name      string
+	BoardType string
+	ChipModel string
+	Version   string
+}
+
+// Credential 设备凭证，只保存凭证的哈希
+type Credential struct {
+	DeviceID   string    `json:"device_id"`
+	SecretHash string    `json:"-"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// DeviceKey 设备密钥，服务端只保存公钥
+type DeviceKey struct {
+	ID         string     `json:"id"` // 即 JWT 头部的 kid
+	DeviceID   string     `json:"device_id"`
+	PublicKey  string     `json:"public_key"` // PEM 编码的 P-256 公钥
+	CreatedAt  time.Time  `json:"created_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// Revoked 判断密钥是否已吊销
+func (k *DeviceKey) Revoked() bool {
+	return k.RevokedAt != nil
+}
+
+// IssuedKey 新签发的设备密钥，私钥只在签发时返回一次
+type IssuedKey struct {
+	KeyID      string `json:"key_id"`
+	Algorithm  string `json:"algorithm"`
+	PrivateKey string `json:"private_key"` // PEM 编码的 P-256 私钥
+	PublicKey  string `json:"public_key"`
+}
+
+// Provisioned 配对结果，设备凭证明文只在配对时返回一次
+// DeviceAuth 为 token 时返回 DeviceToken，为 jwt 时返回 Key
+type Provisioned struct {
+	DeviceID    string     `json:"device_id"`
+	ClientID    string     `json:"client_id"`
+	UserID      uint       `json:"user_id"`
+	TenantID    string     `json:"tenant_id"`
+	DeviceToken string     `json:"device_token,omitempty"`
+	Key         *IssuedKey `json:"key,omitempty"`
+	Websocket   string     `json:"websocket"`
+}
@@ -0,0 +1,48 @@
+package provisioning
+
+import (
+	"context"
+	"time"
+)
+
+// Repository 配对令牌和设备凭证仓库接口
+type Repository interface {
+	// UserExists 判断用户是否存在
+	UserExists(ctx context.Context, userID uint) (bool, error)
+
+	// SaveToken 保存新签发的配对令牌
+	SaveToken(ctx context.Context, token *PairingToken) error
+
+	// FindToken 按令牌哈希查找配对令牌，不存在时返回 nil
+	FindToken(ctx context.Context, tokenHash string) (*PairingToken, error)
+
+	// ClaimToken 将未使用且未过期的令牌标记为已被设备使用，令牌已被使用或已过期时返回 false
+	ClaimToken(ctx context.Context, id, deviceID string, at time.Time) (bool, error)
+
+	// DeleteExpired 删除早于指定时间过期的令牌
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+
+	// SaveCredential 创建或替换设备凭证
+	SaveCredential(ctx context.Context, credential *Credential) error
+
+	// FindCredential 查找设备凭证，设备没有凭证时返回 nil
+	FindCredential(ctx context.Context, deviceID string) (*Credential, error)
+
+	// DeleteCredential 删除设备的静态令牌
+	DeleteCredential(ctx context.Context, deviceID string) error
+
+	// SaveKey 保存设备密钥
+	SaveKey(ctx context.Context, key *DeviceKey) error
+
+	// ListKeys 列出设备的全部密钥，新密钥在前
+	ListKeys(ctx context.Context, deviceID string) ([]*DeviceKey, error)
+
+	// RevokeKey 吊销设备的密钥，密钥不存在或已吊销时返回 false
+	RevokeKey(ctx context.Context, deviceID, keyID string, at time.Time) (bool, error)
+
+	// TouchKey 记录密钥最近一次通过校验的时间
+	TouchKey(ctx context.Context, keyID string, at time.Time) error
+
+	// DeleteByDevice 删除设备的凭证、密钥和配对令牌
+	DeleteByDevice(ctx context.Context, deviceID string) (int64, error)
+}
@@ -0,0 +1,357 @@
+// Package provisioning 扫码配对，用短时有效的一次性令牌替代手动激活码，为设备创建记录并签发凭证
+package provisioning
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/domain/device/aggregate"
+	devicerepo "xiaozhi-server-go/internal/domain/device/repository"
+	"xiaozhi-server-go/internal/domain/eventbus"
+	"xiaozhi-server-go/internal/domain/member"
+	"xiaozhi-server-go/internal/domain/tenant"
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+const (
+	purgeInterval = time.Hour
+	// payloadScheme 二维码内容的前缀，设备固件和配套 App 据此识别配对二维码
+	payloadScheme = "xiaozhi://pair"
+	tokenPrefix   = "pair_"
+	secretPrefix  = "dev_"
+)
+
+// Service 扫码配对服务
+type Service struct {
+	cfg       config.ProvisioningConfig
+	websocket string
+	repo      Repository
+	devices   devicerepo.DeviceRepository
+	members   *member.Service
+	tenants   *tenant.Service // 为 nil 时不检查租户的设备配额
+	logger    *logging.Logger
+	now       func() time.Time
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局扫码配对服务，供 WebSocket 握手校验设备凭证
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局扫码配对服务，未启用时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建扫码配对服务，websocket 为配对成功后返回给设备的连接地址
+func NewService(
+	cfg config.ProvisioningConfig,
+	websocket string,
+	repo Repository,
+	devices devicerepo.DeviceRepository,
+	members *member.Service,
+	tenants *tenant.Service,
+	logger *logging.Logger,
+) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	if cfg.TokenTTL <= 0 {
+		cfg.TokenTTL = 10 * time.Minute
+	}
+	if cfg.DeviceAuth != AuthJWT {
+		cfg.DeviceAuth = AuthToken
+	}
+	if cfg.JWTMaxAge <= 0 {
+		cfg.JWTMaxAge = 10 * time.Minute
+	}
+	cfg.PublicURL = strings.TrimRight(cfg.PublicURL, "/")
+	return &Service{
+		cfg:       cfg,
+		websocket: websocket,
+		repo:      repo,
+		devices:   devices,
+		members:   members,
+		tenants:   tenants,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// Issue 为用户签发配对令牌，令牌归属请求所在的租户
+func (s *Service) Issue(ctx context.Context, userID uint) (*Pairing, error) {
+	exists, err := s.repo.UserExists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.Wrap(errors.KindDomain, "provisioning.issue", fmt.Sprintf("user not found: %d", userID), ErrUserNotFound)
+	}
+
+	token, hash, err := newSecret(tokenPrefix)
+	if err != nil {
+		return nil, errors.Wrap(errors.KindDomain, "provisioning.issue", "failed to generate pairing token", err)
+	}
+	now := s.now()
+	record := &PairingToken{
+		ID:        uuid.New().String(),
+		TokenHash: hash,
+		UserID:    userID,
+		TenantID:  tenant.IDFrom(ctx),
+		ExpiresAt: now.Add(s.cfg.TokenTTL),
+		CreatedAt: now,
+	}
+	if err := s.repo.SaveToken(ctx, record); err != nil {
+		return nil, err
+	}
+	s.logger.InfoTag("配对", "已为用户 %d 签发配对令牌，%s 后过期", userID, s.cfg.TokenTTL)
+	return &Pairing{
+		Token:     token,
+		Payload:   s.payload(token),
+		ExpiresAt: record.ExpiresAt,
+	}, nil
+}
+
+// payload 生成二维码内容，配置了 PublicURL 时附带服务地址
+func (s *Service) payload(token string) string {
+	values := url.Values{}
+	values.Set("token", token)
+	if s.cfg.PublicURL != "" {
+		values.Set("server", s.cfg.PublicURL)
+	}
+	return payloadScheme + "?" + values.Encode()
+}
+
+// Claim 用配对令牌换取设备凭证，DeviceAuth 为 jwt 时签发设备密钥，否则签发静态令牌
+// 设备未注册时以令牌签发者为所有者创建并激活设备记录；已注册的设备只能由原所有者重新配对，重新配对会替换原凭证并吊销原密钥。
+// 令牌在创建设备前作废，之后的步骤失败时需重新签发令牌
+func (s *Service) Claim(ctx context.Context, claim Claim) (*Provisioned, error) {
+	claim.Token = strings.TrimSpace(claim.Token)
+	claim.DeviceID = strings.TrimSpace(claim.DeviceID)
+	if claim.Token == "" || claim.DeviceID == "" {
+		return nil, errors.New(errors.KindDomain, "provisioning.claim", "token and device_id are required")
+	}
+
+	now := s.now()
+	record, err := s.repo.FindToken(ctx, hashSecret(claim.Token))
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || record.ClaimedAt != nil || !now.Before(record.ExpiresAt) {
+		return nil, errors.Wrap(errors.KindDomain, "provisioning.claim", "pairing token invalid or expired", ErrTokenInvalid)
+	}
+	ctx = tenant.WithID(ctx, record.TenantID)
+
+	device, err := s.devices.FindByDeviceID(ctx, claim.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+	if device != nil {
+		if tenantOf(device) != record.TenantID || (device.UserID != nil && uint(*device.UserID) != record.UserID) {
+			return nil, errors.Wrap(errors.KindDomain, "provisioning.claim", "device already claimed: "+claim.DeviceID, ErrDeviceClaimed)
+		}
+	} else if s.tenants != nil {
+		if err := s.tenants.CheckDeviceQuota(ctx, record.TenantID); err != nil {
+			return nil, err
+		}
+	}
+
+	claimed, err := s.repo.ClaimToken(ctx, record.ID, claim.DeviceID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, errors.Wrap(errors.KindDomain, "provisioning.claim", "pairing token invalid or expired", ErrTokenInvalid)
+	}
+
+	owner := int(record.UserID)
+	if device == nil {
+		device = &aggregate.Device{
+			UserID:         &owner,
+			TenantID:       record.TenantID,
+			DeviceID:       claim.DeviceID,
+			ClientID:       claim.ClientID,
+			Name:           claim.Name,
+			BoardType:      claim.BoardType,
+			ChipModelName:  claim.ChipModel,
+			Version:        claim.Version,
+			OTA:            true,
+			Language:       "zh-CN",
+			AuthStatus:     aggregate.DeviceStatusApproved,
+			RegisterTime:   now,
+			LastActiveTime: now,
+		}
+		if device.ClientID == "" {
+			device.ClientID = "client_" + claim.DeviceID
+		}
+		if device.Name == "" {
+			device.Name = claim.DeviceID
+		}
+		if err := s.devices.Save(ctx, device); err != nil {
+			return nil, err
+		}
+	} else {
+		device.UserID = &owner
+		device.AuthStatus = aggregate.DeviceStatusApproved
+		device.AuthCode = ""
+		if claim.Name != "" {
+			device.Name = claim.Name
+		}
+		if claim.Version != "" {
+			device.Version = claim.Version
+		}
+		if err := s.devices.Update(ctx, device); err != nil {
+			return nil, err
+		}
+		// 待认证设备可能已建立连接，通知传输层解除消息限制
+		eventbus.Publish(eventbus.EventDeviceAuthChanged, eventbus.DeviceAuthEventData{
+			DeviceID:  device.DeviceID,
+			Status:    string(device.AuthStatus),
+			Timestamp: now,
+		})
+	}
+
+	if _, err := s.members.BindDevice(ctx, record.UserID, claim.DeviceID, member.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	result := &Provisioned{
+		DeviceID:  device.DeviceID,
+		ClientID:  device.ClientID,
+		UserID:    record.UserID,
+		TenantID:  record.TenantID,
+		Websocket: s.websocket,
+	}
+	if s.cfg.DeviceAuth == AuthJWT {
+		if result.Key, err = s.newKey(ctx, claim.DeviceID); err != nil {
+			return nil, err
+		}
+		if err := s.revokeKeys(ctx, claim.DeviceID, result.Key.KeyID); err != nil {
+			return nil, err
+		}
+		if err := s.repo.DeleteCredential(ctx, claim.DeviceID); err != nil {
+			return nil, err
+		}
+	} else {
+		secret, hash, err := newSecret(secretPrefix)
+		if err != nil {
+			return nil, errors.Wrap(errors.KindDomain, "provisioning.claim", "failed to generate device credential", err)
+		}
+		if err := s.repo.SaveCredential(ctx, &Credential{DeviceID: claim.DeviceID, SecretHash: hash, CreatedAt: now}); err != nil {
+			return nil, err
+		}
+		if err := s.revokeKeys(ctx, claim.DeviceID, ""); err != nil {
+			return nil, err
+		}
+		result.DeviceToken = secret
+	}
+
+	s.logger.InfoTag("配对", "设备 %s 已通过扫码配对，所有者为用户 %d，凭证类型 %s", claim.DeviceID, record.UserID, s.cfg.DeviceAuth)
+	return result, nil
+}
+
+// Authenticate 校验设备连接时携带的凭证
+// 设备有未吊销的密钥时要求用密钥签名的 JWT，否则有静态令牌时要求令牌；密钥全部被吊销且没有令牌的设备需重新签发密钥或重新配对。
+// 没有任何凭证的设备（未经扫码配对）和未开启 RequireCredential 时直接放行
+func (s *Service) Authenticate(ctx context.Context, deviceID, secret string) error {
+	if !s.cfg.RequireCredential || deviceID == "" {
+		return nil
+	}
+	keys, err := s.repo.ListKeys(ctx, deviceID)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if !key.Revoked() {
+			return s.verifyJWT(ctx, deviceID, secret, keys)
+		}
+	}
+
+	credential, err := s.repo.FindCredential(ctx, deviceID)
+	if err != nil {
+		return err
+	}
+	if credential == nil {
+		if len(keys) > 0 {
+			return fmt.Errorf("%w: all device keys revoked", ErrBadCredential)
+		}
+		return nil
+	}
+	if subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(credential.SecretHash)) != 1 {
+		return ErrBadCredential
+	}
+	return nil
+}
+
+// EraseDeviceData 删除设备的凭证、密钥和配对令牌
+func (s *Service) EraseDeviceData(ctx context.Context, deviceID string) (int64, error) {
+	return s.repo.DeleteByDevice(ctx, deviceID)
+}
+
+// Run 定期清理过期的配对令牌，直到 ctx 结束
+func (s *Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+	for {
+		s.purge(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) purge(ctx context.Context) {
+	removed, err := s.repo.DeleteExpired(ctx, s.now())
+	if err != nil {
+		s.logger.ErrorTag("配对", "清理过期配对令牌失败: %v", err)
+		return
+	}
+	if removed > 0 {
+		s.logger.InfoTag("配对", "已清理 %d 个过期配对令牌", removed)
+	}
+}
+
+func tenantOf(device *aggregate.Device) string {
+	if device.TenantID == "" {
+		return tenant.DefaultID
+	}
+	return device.TenantID
+}
+
+// newSecret 生成随机令牌及其哈希
+func newSecret(prefix string) (secret, hash string, err error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	secret = prefix + hex.EncodeToString(buf)
+	return secret, hashSecret(secret), nil
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,41 @@
+package recording
+
+import (
+	"time"
+)
+
+// FormatWAV 归档音频格式，16 位 PCM WAV
+const FormatWAV = "wav"
+
+// Recording 一段归档的用户语音
+type Recording struct {
+	ID         string    `json:"id"`
+	SessionID  string    `json:"session_id"`
+	DeviceID   string    `json:"device_id"`
+	Round      int       `json:"round"`
+	EntryID    string    `json:"entry_id,omitempty"` // 对应的对话记录消息ID，未启用对话记录时为空
+	Format     string    `json:"format"`
+	SampleRate int       `json:"sample_rate"`
+	Channels   int       `json:"channels"`
+	DurationMs int64     `json:"duration_ms"`
+	Size       int64     `json:"size"` // 未加密的音频字节数
+	BlobKey    string    `json:"-"`    // 加密音频在存储中的键
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Consent 设备的语音归档授权，默认未授权
+type Consent struct {
+	DeviceID  string    `json:"device_id"`
+	Granted   bool      `json:"granted"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Filter 录音查询条件
+type Filter struct {
+	DeviceID  string
+	SessionID string
+	From      time.Time
+	To        time.Time
+	Page      int
+	PageSize  int
+}
@@ -0,0 +1,48 @@
+package recording
+
+import (
+	"context"
+	"time"
+)
+
+// Repository 录音元数据和授权仓库接口
+type Repository interface {
+	// Save 保存录音元数据
+	Save(ctx context.Context, r *Recording) error
+
+	// Find 根据ID查找录音，不存在时返回 nil
+	Find(ctx context.Context, id string) (*Recording, error)
+
+	// List 按条件分页查询录音，按时间倒序
+	List(ctx context.Context, filter Filter) ([]*Recording, int64, error)
+
+	// ListBefore 查询指定时间之前的录音，最多 limit 条
+	ListBefore(ctx context.Context, before time.Time, limit int) ([]*Recording, error)
+
+	// ListByDevice 查询设备的全部录音
+	ListByDevice(ctx context.Context, deviceID string) ([]*Recording, error)
+
+	// Delete 删除录音元数据
+	Delete(ctx context.Context, ids []string) error
+
+	// GetConsent 获取设备授权，未设置时返回 nil
+	GetConsent(ctx context.Context, deviceID string) (*Consent, error)
+
+	// SaveConsent 保存设备授权
+	SaveConsent(ctx context.Context, c *Consent) error
+
+	// DeleteConsent 删除设备授权
+	DeleteConsent(ctx context.Context, deviceID string) error
+}
+
+// BlobStore 音频内容存储，实现负责加密
+type BlobStore interface {
+	// Put 写入音频
+	Put(ctx context.Context, key string, data []byte) error
+
+	// Get 读取并解密音频
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Delete 删除音频，不存在时不报错
+	Delete(ctx context.Context, key string) error
+}
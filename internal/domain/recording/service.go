@@ -0,0 +1,338 @@
+package recording
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/observability"
+)
+
+const (
+	queueSize      = 32 // 每段语音可达数百KB，队列不宜过长
+	purgeInterval  = time.Hour
+	purgeBatchSize = 100
+)
+
+// ErrNotFound 录音不存在
+var ErrNotFound = stderrors.New("recording not found")
+
+// archiveTask 待写入的录音
+type archiveTask struct {
+	recording *Recording
+	pcm       []byte
+}
+
+// Service 用户语音归档服务
+//
+// 只归档已授权设备的语音；写入异步进行，写入前再次确认授权，
+// 避免撤销授权或删除数据后仍有排队中的语音落盘。
+type Service struct {
+	repo      Repository
+	blobs     BlobStore
+	logger    *logging.Logger
+	retention time.Duration // <=0 表示永久保留
+	now       func() time.Time
+
+	queue chan archiveTask
+
+	consentMu sync.RWMutex
+	consent   map[string]bool // 设备授权缓存
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局语音归档服务，供连接处理器使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局语音归档服务，未启用时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建语音归档服务，retentionDays<=0 表示不自动清理
+func NewService(repo Repository, blobs BlobStore, retentionDays int, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Service{
+		repo:      repo,
+		blobs:     blobs,
+		logger:    logger,
+		retention: time.Duration(retentionDays) * 24 * time.Hour,
+		now:       time.Now,
+		queue:     make(chan archiveTask, queueSize),
+		consent:   make(map[string]bool),
+	}
+}
+
+// Consented 设备是否已授权归档语音，查询失败时按未授权处理
+func (s *Service) Consented(ctx context.Context, deviceID string) bool {
+	if deviceID == "" {
+		return false
+	}
+	s.consentMu.RLock()
+	granted, ok := s.consent[deviceID]
+	s.consentMu.RUnlock()
+	if ok {
+		return granted
+	}
+
+	c, err := s.repo.GetConsent(ctx, deviceID)
+	if err != nil {
+		s.logger.WarnTag("语音归档", "查询设备 %s 的授权失败: %v", deviceID, err)
+		return false
+	}
+	granted = c != nil && c.Granted
+	s.consentMu.Lock()
+	s.consent[deviceID] = granted
+	s.consentMu.Unlock()
+	return granted
+}
+
+// GetConsent 获取设备授权，未设置时返回未授权
+func (s *Service) GetConsent(ctx context.Context, deviceID string) (*Consent, error) {
+	c, err := s.repo.GetConsent(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		c = &Consent{DeviceID: deviceID}
+	}
+	return c, nil
+}
+
+// SetConsent 设置设备授权；撤销授权且 deleteExisting 为 true 时同时删除已归档的语音，返回删除条数
+func (s *Service) SetConsent(ctx context.Context, deviceID string, granted, deleteExisting bool) (*Consent, int64, error) {
+	if deviceID == "" {
+		return nil, 0, errors.New(errors.KindDomain, "recording.consent", "device_id is required")
+	}
+	c := &Consent{DeviceID: deviceID, Granted: granted, UpdatedAt: s.now()}
+	if err := s.repo.SaveConsent(ctx, c); err != nil {
+		return nil, 0, err
+	}
+	s.setConsentCache(deviceID, granted)
+	s.logger.InfoTag("语音归档", "设备 %s 语音归档授权: %t", deviceID, granted)
+
+	if granted || !deleteExisting {
+		return c, 0, nil
+	}
+	removed, err := s.deleteDevice(ctx, deviceID)
+	return c, removed, err
+}
+
+// Archive 归档一段 16 位 PCM 语音，不阻塞调用方；设备未授权或队列满时丢弃
+func (s *Service) Archive(rec Recording, pcm []byte) {
+	if rec.SessionID == "" || rec.SampleRate <= 0 || len(pcm) == 0 {
+		return
+	}
+	if !s.Consented(context.Background(), rec.DeviceID) {
+		return
+	}
+	if rec.Channels <= 0 {
+		rec.Channels = 1
+	}
+	rec.ID = uuid.New().String()
+	rec.Format = FormatWAV
+	rec.DurationMs = int64(len(pcm)) * 1000 / int64(rec.SampleRate*rec.Channels*2)
+	if rec.CreatedAt.IsZero() {
+		rec.CreatedAt = s.now()
+	}
+
+	select {
+	case s.queue <- archiveTask{recording: &rec, pcm: pcm}:
+	default:
+		s.logger.WarnTag("语音归档", "写入队列已满，丢弃会话 %s 的语音", rec.SessionID)
+		observability.RecordMetric(context.Background(), "recording_dropped_total", 1, nil)
+	}
+}
+
+// List 分页查询录音
+func (s *Service) List(ctx context.Context, filter Filter) ([]*Recording, int64, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = 20
+	}
+	return s.repo.List(ctx, filter)
+}
+
+// Get 获取录音元数据
+func (s *Service) Get(ctx context.Context, id string) (*Recording, error) {
+	rec, err := s.repo.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rec == nil {
+		return nil, errors.Wrap(errors.KindDomain, "recording.get", "recording not found", ErrNotFound)
+	}
+	return rec, nil
+}
+
+// Audio 获取解密后的录音内容
+func (s *Service) Audio(ctx context.Context, id string) (*Recording, []byte, error) {
+	rec, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := s.blobs.Get(ctx, rec.BlobKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rec, data, nil
+}
+
+// Delete 删除录音
+func (s *Service) Delete(ctx context.Context, id string) error {
+	rec, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	_, err = s.remove(ctx, []*Recording{rec})
+	return err
+}
+
+// EraseDeviceData 删除设备的全部录音和授权记录，返回删除的录音条数
+func (s *Service) EraseDeviceData(ctx context.Context, deviceID string) (int64, error) {
+	removed, err := s.deleteDevice(ctx, deviceID)
+	if err != nil {
+		return removed, err
+	}
+	if err := s.repo.DeleteConsent(ctx, deviceID); err != nil {
+		return removed, err
+	}
+	s.setConsentCache(deviceID, false)
+	return removed, nil
+}
+
+// Run 启动写入和清理循环，直到 ctx 结束；退出前写入剩余语音
+func (s *Service) Run(ctx context.Context) error {
+	s.logger.InfoTag("语音归档", "语音归档服务已启动，保留 %s", s.retention)
+	purgeTicker := time.NewTicker(purgeInterval)
+	defer purgeTicker.Stop()
+
+	s.purge(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case task := <-s.queue:
+					s.write(context.Background(), task)
+				default:
+					s.logger.InfoTag("语音归档", "语音归档服务已停止")
+					return nil
+				}
+			}
+		case task := <-s.queue:
+			s.write(ctx, task)
+		case <-purgeTicker.C:
+			s.purge(ctx)
+		}
+	}
+}
+
+func (s *Service) write(ctx context.Context, task archiveTask) {
+	rec := task.recording
+	if !s.Consented(ctx, rec.DeviceID) {
+		return
+	}
+
+	data := EncodeWAV(task.pcm, rec.SampleRate, rec.Channels)
+	rec.Size = int64(len(data))
+	rec.BlobKey = rec.CreatedAt.Format("2006-01-02") + "/" + rec.ID + "." + rec.Format
+	if err := s.blobs.Put(ctx, rec.BlobKey, data); err != nil {
+		s.logger.ErrorTag("语音归档", "写入会话 %s 的语音失败: %v", rec.SessionID, err)
+		return
+	}
+	if err := s.repo.Save(ctx, rec); err != nil {
+		s.logger.ErrorTag("语音归档", "保存会话 %s 的录音记录失败: %v", rec.SessionID, err)
+		if err := s.blobs.Delete(ctx, rec.BlobKey); err != nil {
+			s.logger.WarnTag("语音归档", "清理未登记的语音文件失败: %v", err)
+		}
+		return
+	}
+	observability.RecordMetric(ctx, "recording_archived_total", 1, nil)
+}
+
+// purge 按保留策略删除过期录音
+func (s *Service) purge(ctx context.Context) {
+	if s.retention <= 0 {
+		return
+	}
+	before := s.now().Add(-s.retention)
+	var total int64
+	for {
+		items, err := s.repo.ListBefore(ctx, before, purgeBatchSize)
+		if err != nil {
+			s.logger.ErrorTag("语音归档", "查询过期录音失败: %v", err)
+			break
+		}
+		removed, err := s.remove(ctx, items)
+		total += removed
+		if err != nil {
+			s.logger.ErrorTag("语音归档", "清理过期录音失败: %v", err)
+			break
+		}
+		if len(items) < purgeBatchSize {
+			break
+		}
+	}
+	if total > 0 {
+		s.logger.InfoTag("语音归档", "已清理 %d 条过期录音", total)
+	}
+}
+
+func (s *Service) deleteDevice(ctx context.Context, deviceID string) (int64, error) {
+	items, err := s.repo.ListByDevice(ctx, deviceID)
+	if err != nil {
+		return 0, err
+	}
+	return s.remove(ctx, items)
+}
+
+// remove 先删除音频再删除元数据；音频删除失败的录音保留元数据，便于之后重试
+func (s *Service) remove(ctx context.Context, items []*Recording) (int64, error) {
+	if len(items) == 0 {
+		return 0, nil
+	}
+	ids := make([]string, 0, len(items))
+	var firstErr error
+	for _, rec := range items {
+		if err := s.blobs.Delete(ctx, rec.BlobKey); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		ids = append(ids, rec.ID)
+	}
+	if len(ids) > 0 {
+		if err := s.repo.Delete(ctx, ids); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(ids)), firstErr
+}
+
+func (s *Service) setConsentCache(deviceID string, granted bool) {
+	s.consentMu.Lock()
+	s.consent[deviceID] = granted
+	s.consentMu.Unlock()
+}
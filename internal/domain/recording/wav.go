@@ -0,0 +1,28 @@
+package recording
+
+import (
+	"encoding/binary"
+)
+
+// EncodeWAV 为 16 位小端序 PCM 加上 WAV 头
+func EncodeWAV(pcm []byte, sampleRate, channels int) []byte {
+	const headerSize = 44
+	out := make([]byte, headerSize+len(pcm))
+	blockAlign := channels * 2
+
+	copy(out[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(out[4:8], uint32(headerSize-8+len(pcm)))
+	copy(out[8:12], "WAVE")
+	copy(out[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(out[16:20], 16)
+	binary.LittleEndian.PutUint16(out[20:22], 1) // PCM
+	binary.LittleEndian.PutUint16(out[22:24], uint16(channels))
+	binary.LittleEndian.PutUint32(out[24:28], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(out[28:32], uint32(sampleRate*blockAlign))
+	binary.LittleEndian.PutUint16(out[32:34], uint16(blockAlign))
+	binary.LittleEndian.PutUint16(out[34:36], 16)
+	copy(out[36:40], "data")
+	binary.LittleEndian.PutUint32(out[40:44], uint32(len(pcm)))
+	copy(out[headerSize:], pcm)
+	return out
+}
@@ -0,0 +1,139 @@
+package reminder
+
+import (
+	"time"
+)
+
+// Kind 提醒类型
+type Kind string
+
+const (
+	KindTimer    Kind = "timer"    // 倒计时
+	KindAlarm    Kind = "alarm"    // 闹钟（指定时刻）
+	KindReminder Kind = "reminder" // 带内容的提醒事项
+)
+
+// Status 提醒状态
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusFired     Status = "fired"
+	StatusCancelled Status = "cancelled"
+	StatusMissed    Status = "missed" // 设备长时间离线，未能送达
+)
+
+// Recurrence 重复规则
+type Recurrence string
+
+const (
+	RecurrenceNone     Recurrence = ""
+	RecurrenceDaily    Recurrence = "daily"
+	RecurrenceWeekdays Recurrence = "weekdays"
+	RecurrenceWeekly   Recurrence = "weekly"
+)
+
+// Reminder 提醒聚合
+type Reminder struct {
+	ID         string     `json:"id"`
+	DeviceID   string     `json:"device_id"`
+	Kind       Kind       `json:"kind"`
+	Message    string     `json:"message"`
+	FireAt     time.Time  `json:"fire_at"`
+	Recurrence Recurrence `json:"recurrence,omitempty"`
+	Status     Status     `json:"status"`
+	FiredAt    *time.Time `json:"fired_at,omitempty"`
+	Attempts   int        `json:"attempts"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// IsValidKind 检查提醒类型是否合法
+func IsValidKind(k Kind) bool {
+	switch k {
+	case KindTimer, KindAlarm, KindReminder:
+		return true
+	}
+	return false
+}
+
+// IsValidRecurrence 检查重复规则是否合法
+func IsValidRecurrence(r Recurrence) bool {
+	switch r {
+	case RecurrenceNone, RecurrenceDaily, RecurrenceWeekdays, RecurrenceWeekly:
+		return true
+	}
+	return false
+}
+
+// NextFireAt 计算重复提醒的下一次触发时间，非重复提醒返回零值
+func (r *Reminder) NextFireAt(after time.Time) time.Time {
+	next := r.FireAt
+	switch r.Recurrence {
+	case RecurrenceDaily:
+		for !next.After(after) {
+			next = next.AddDate(0, 0, 1)
+		}
+	case RecurrenceWeekly:
+		for !next.After(after) {
+			next = next.AddDate(0, 0, 7)
+		}
+	case RecurrenceWeekdays:
+		for !next.After(after) || next.Weekday() == time.Saturday || next.Weekday() == time.Sunday {
+			next = next.AddDate(0, 0, 1)
+		}
+	default:
+		return time.Time{}
+	}
+	return next
+}
+
+// Announcement 到期时播报的文本
+func (r *Reminder) Announcement() string {
+	switch r.Kind {
+	case KindAlarm:
+		if r.Message != "" {
+			return "闹钟响了，" + r.Message
+		}
+		return "闹钟响了，该起来啦"
+	case KindReminder:
+		if r.Message != "" {
+			return "提醒一下，" + r.Message
+		}
+		return "你设置的提醒时间到了"
+	default:
+		if r.Message != "" {
+			return "时间到了，记得" + r.Message
+		}
+		return "时间到了"
+	}
+}
+
+// Filter 查询条件
+type Filter struct {
+	DeviceID string
+	Kind     Kind
+	Status   Status
+	Page     int
+	PageSize int
+}
+
+// ParseFireTime 解析 "15:04"、"2006-01-02 15:04" 或 RFC3339 格式的触发时间
+// 仅给出时刻且已过去时，顺延到第二天
+func ParseFireTime(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02 15:04", value, now.Location()); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("15:04", value, now.Location())
+	if err != nil {
+		return time.Time{}, err
+	}
+	fireAt := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+	if !fireAt.After(now) {
+		fireAt = fireAt.AddDate(0, 0, 1)
+	}
+	return fireAt, nil
+}
@@ -0,0 +1,27 @@
+package reminder
+
+import (
+	"context"
+	"time"
+)
+
+// Repository 提醒仓库接口
+type Repository interface {
+	// Save 新建提醒
+	Save(ctx context.Context, r *Reminder) error
+
+	// Update 更新提醒
+	Update(ctx context.Context, r *Reminder) error
+
+	// FindByID 根据ID查找提醒，不存在时返回 nil
+	FindByID(ctx context.Context, id string) (*Reminder, error)
+
+	// List 按条件分页查询
+	List(ctx context.Context, filter Filter) ([]*Reminder, int64, error)
+
+	// ListDue 查询在指定时间之前到期且仍待触发的提醒
+	ListDue(ctx context.Context, before time.Time, limit int) ([]*Reminder, error)
+
+	// Delete 删除提醒
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,325 @@
+package reminder
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/observability"
+)
+
+const (
+	defaultPollInterval = time.Second
+	dueBatchSize        = 100
+	// 设备离线时，超过该时长仍未送达的提醒标记为 missed
+	missedAfter = 10 * time.Minute
+	retryDelay  = 15 * time.Second
+)
+
+// ErrNotFound 提醒不存在
+var ErrNotFound = stderrors.New("reminder not found")
+
+// Notifier 将提醒推送到设备，设备不在线时返回错误
+type Notifier interface {
+	NotifyDevice(ctx context.Context, deviceID string, text string) error
+}
+
+// CreateRequest 创建提醒请求
+type CreateRequest struct {
+	DeviceID   string
+	Kind       Kind
+	Message    string
+	Delay      time.Duration // 与 FireAt 二选一，优先使用 Delay
+	FireAt     time.Time
+	Recurrence Recurrence
+}
+
+// UpdateRequest 更新提醒请求，nil 字段表示不修改
+type UpdateRequest struct {
+	Message    *string
+	FireAt     *time.Time
+	Recurrence *Recurrence
+}
+
+// Service 提醒服务，负责提醒的增删改查和到期调度
+type Service struct {
+	repo     Repository
+	notifier Notifier
+	logger   *logging.Logger
+	interval time.Duration
+	now      func() time.Time
+
+	wake chan struct{}
+
+	retryMu sync.Mutex
+	retryAt map[string]time.Time // 推送失败的提醒在此时间前不再重试
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局提醒服务，供连接处理器等无法直接注入的组件使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局提醒服务，未初始化时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建提醒服务
+func NewService(repo Repository, notifier Notifier, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Service{
+		repo:     repo,
+		notifier: notifier,
+		logger:   logger,
+		interval: defaultPollInterval,
+		now:      time.Now,
+		wake:     make(chan struct{}, 1),
+		retryAt:  make(map[string]time.Time),
+	}
+}
+
+// Create 创建提醒
+func (s *Service) Create(ctx context.Context, req CreateRequest) (*Reminder, error) {
+	if strings.TrimSpace(req.DeviceID) == "" {
+		return nil, errors.New(errors.KindDomain, "reminder.create", "device_id is required")
+	}
+	if req.Kind == "" {
+		req.Kind = KindTimer
+	}
+	if !IsValidKind(req.Kind) {
+		return nil, errors.New(errors.KindDomain, "reminder.create", "invalid reminder kind: "+string(req.Kind))
+	}
+	if !IsValidRecurrence(req.Recurrence) {
+		return nil, errors.New(errors.KindDomain, "reminder.create", "invalid recurrence: "+string(req.Recurrence))
+	}
+
+	now := s.now()
+	fireAt := req.FireAt
+	if req.Delay > 0 {
+		fireAt = now.Add(req.Delay)
+	}
+	if fireAt.IsZero() || !fireAt.After(now) {
+		return nil, errors.New(errors.KindDomain, "reminder.create", "fire time must be in the future")
+	}
+
+	r := &Reminder{
+		ID:         uuid.New().String(),
+		DeviceID:   req.DeviceID,
+		Kind:       req.Kind,
+		Message:    strings.TrimSpace(req.Message),
+		FireAt:     fireAt,
+		Recurrence: req.Recurrence,
+		Status:     StatusPending,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.repo.Save(ctx, r); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoTag("提醒", "已创建%s %s，设备 %s，触发时间 %s", r.Kind, r.ID, r.DeviceID, r.FireAt.Format("2006-01-02 15:04:05"))
+	observability.RecordMetric(ctx, "reminder_created_total", 1, map[string]string{"kind": string(r.Kind)})
+	s.kick()
+	return r, nil
+}
+
+// Get 获取提醒
+func (s *Service) Get(ctx context.Context, id string) (*Reminder, error) {
+	r, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, errors.Wrap(errors.KindDomain, "reminder.get", "reminder not found", ErrNotFound)
+	}
+	return r, nil
+}
+
+// List 分页查询提醒
+func (s *Service) List(ctx context.Context, filter Filter) ([]*Reminder, int64, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = 20
+	}
+	return s.repo.List(ctx, filter)
+}
+
+// Update 修改待触发提醒的内容、时间或重复规则
+func (s *Service) Update(ctx context.Context, id string, req UpdateRequest) (*Reminder, error) {
+	r, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if r.Status != StatusPending {
+		return nil, errors.New(errors.KindDomain, "reminder.update", "only pending reminders can be updated")
+	}
+
+	if req.Message != nil {
+		r.Message = strings.TrimSpace(*req.Message)
+	}
+	if req.Recurrence != nil {
+		if !IsValidRecurrence(*req.Recurrence) {
+			return nil, errors.New(errors.KindDomain, "reminder.update", "invalid recurrence: "+string(*req.Recurrence))
+		}
+		r.Recurrence = *req.Recurrence
+	}
+	if req.FireAt != nil {
+		if !req.FireAt.After(s.now()) {
+			return nil, errors.New(errors.KindDomain, "reminder.update", "fire time must be in the future")
+		}
+		r.FireAt = *req.FireAt
+	}
+	r.UpdatedAt = s.now()
+
+	if err := s.repo.Update(ctx, r); err != nil {
+		return nil, err
+	}
+	s.kick()
+	return r, nil
+}
+
+// Cancel 取消提醒
+func (s *Service) Cancel(ctx context.Context, id string) (*Reminder, error) {
+	r, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if r.Status != StatusPending {
+		return r, nil
+	}
+	r.Status = StatusCancelled
+	r.UpdatedAt = s.now()
+	if err := s.repo.Update(ctx, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Delete 删除提醒
+func (s *Service) Delete(ctx context.Context, id string) error {
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// Run 启动调度循环，直到 ctx 结束
+func (s *Service) Run(ctx context.Context) error {
+	s.logger.InfoTag("提醒", "提醒调度器已启动")
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.fireDue(ctx)
+		select {
+		case <-ctx.Done():
+			s.logger.InfoTag("提醒", "提醒调度器已停止")
+			return nil
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+func (s *Service) kick() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Service) fireDue(ctx context.Context) {
+	now := s.now()
+	due, err := s.repo.ListDue(ctx, now, dueBatchSize)
+	if err != nil {
+		s.logger.ErrorTag("提醒", "查询到期提醒失败: %v", err)
+		return
+	}
+
+	for _, r := range due {
+		s.retryMu.Lock()
+		next, waiting := s.retryAt[r.ID]
+		s.retryMu.Unlock()
+		if waiting && now.Before(next) {
+			continue
+		}
+		s.fire(ctx, r, now)
+	}
+}
+
+func (s *Service) fire(ctx context.Context, r *Reminder, now time.Time) {
+	labels := map[string]string{"kind": string(r.Kind)}
+
+	var notifyErr error
+	if s.notifier == nil {
+		notifyErr = stderrors.New("notifier not configured")
+	} else {
+		notifyErr = s.notifier.NotifyDevice(ctx, r.DeviceID, r.Announcement())
+	}
+
+	r.Attempts++
+	r.UpdatedAt = now
+	if notifyErr != nil {
+		// 设备不在线时保留待触发状态，等设备重新连接后再推送
+		if now.Sub(r.FireAt) < missedAfter {
+			s.retryMu.Lock()
+			_, retried := s.retryAt[r.ID]
+			s.retryAt[r.ID] = now.Add(retryDelay)
+			s.retryMu.Unlock()
+			if !retried {
+				s.logger.WarnTag("提醒", "提醒 %s 推送到设备 %s 失败，稍后重试: %v", r.ID, r.DeviceID, notifyErr)
+			}
+			return
+		}
+		s.logger.WarnTag("提醒", "提醒 %s 超时未送达，标记为 missed", r.ID)
+		observability.RecordMetric(ctx, "reminder_missed_total", 1, labels)
+		s.advance(r, now, StatusMissed)
+	} else {
+		s.logger.InfoTag("提醒", "提醒 %s 已推送到设备 %s", r.ID, r.DeviceID)
+		observability.RecordMetric(ctx, "reminder_fired_total", 1, labels)
+		s.advance(r, now, StatusFired)
+	}
+
+	s.retryMu.Lock()
+	delete(s.retryAt, r.ID)
+	s.retryMu.Unlock()
+
+	if err := s.repo.Update(ctx, r); err != nil {
+		s.logger.ErrorTag("提醒", "更新提醒 %s 失败: %v", r.ID, err)
+	}
+}
+
+// advance 非重复提醒进入终态，重复提醒滚动到下一次触发时间
+func (s *Service) advance(r *Reminder, now time.Time, final Status) {
+	if final == StatusFired {
+		firedAt := now
+		r.FiredAt = &firedAt
+	}
+	if next := r.NextFireAt(now); !next.IsZero() {
+		r.FireAt = next
+		r.Status = StatusPending
+		r.Attempts = 0
+		return
+	}
+	r.Status = final
+}
@@ -0,0 +1,109 @@
+package replay
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxDiffTokens 参与逐词比较的最大片段数，超出时整体标记为删除和插入
+const maxDiffTokens = 2000
+
+// Diff 逐词比较原回复和回放回复，中日韩字符按字比较，其他按单词、空白和标点切分
+func Diff(original, replayed string) []DiffSegment {
+	a := splitTokens(original)
+	b := splitTokens(replayed)
+	if len(a) > maxDiffTokens || len(b) > maxDiffTokens {
+		return compact([]DiffSegment{{Op: DiffDelete, Text: original}, {Op: DiffInsert, Text: replayed}})
+	}
+
+	// lcs[i][j] 为 a[i:] 与 b[j:] 的最长公共子序列长度
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var segments []DiffSegment
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			segments = append(segments, DiffSegment{Op: DiffEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			segments = append(segments, DiffSegment{Op: DiffDelete, Text: a[i]})
+			i++
+		default:
+			segments = append(segments, DiffSegment{Op: DiffInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		segments = append(segments, DiffSegment{Op: DiffDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		segments = append(segments, DiffSegment{Op: DiffInsert, Text: b[j]})
+	}
+	return compact(segments)
+}
+
+// compact 合并相邻的同类片段并去掉空片段
+func compact(segments []DiffSegment) []DiffSegment {
+	out := make([]DiffSegment, 0, len(segments))
+	for _, s := range segments {
+		if s.Text == "" {
+			continue
+		}
+		if n := len(out); n > 0 && out[n-1].Op == s.Op {
+			out[n-1].Text += s.Text
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// splitTokens 切分文本并保留全部字符，拼接结果与原文相同
+func splitTokens(text string) []string {
+	var tokens []string
+	var word strings.Builder
+	wordKind := 0 // 1 单词，2 空白
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+		wordKind = 0
+	}
+	for _, r := range text {
+		kind := 0
+		switch {
+		case unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r):
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			kind = 1
+		case unicode.IsSpace(r):
+			kind = 2
+		}
+		if kind == 0 {
+			flush()
+			tokens = append(tokens, string(r))
+			continue
+		}
+		if kind != wordKind {
+			flush()
+			wordKind = kind
+		}
+		word.WriteRune(r)
+	}
+	flush()
+	return tokens
+}
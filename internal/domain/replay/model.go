@@ -0,0 +1,111 @@
+package replay
+
+import (
+	stderrors "errors"
+	"time"
+)
+
+// ErrNotFound 回放运行不存在
+var ErrNotFound = stderrors.New("replay run not found")
+
+// RunStatus 回放运行状态
+type RunStatus string
+
+const (
+	RunPending   RunStatus = "pending"
+	RunRunning   RunStatus = "running"
+	RunCompleted RunStatus = "completed"
+	RunFailed    RunStatus = "failed"
+)
+
+// Options 回放使用的配置，未指定的项使用当前配置
+type Options struct {
+	Model          string   `json:"model,omitempty"`           // LLM 配置名称，为空时使用 Selected.LLM
+	ModelName      string   `json:"model_name,omitempty"`      // 覆盖配置中的模型名称，用于试用新模型
+	Temperature    *float64 `json:"temperature,omitempty"`     // 覆盖配置中的温度
+	MaxTokens      int      `json:"max_tokens,omitempty"`      // 覆盖配置中的最大输出Token数
+	SystemPrompt   string   `json:"system_prompt,omitempty"`   // 直接指定系统提示词，优先于模板
+	PromptTemplate string   `json:"prompt_template,omitempty"` // 使用的提示词模板，为空时按设备当前分配解析
+	PromptVersion  int      `json:"prompt_version,omitempty"`  // 模板版本，0 表示当前生效版本
+
+	OriginalCostPer1KToken float64 `json:"original_cost_per_1k_token,omitempty"` // 原对话模型的每千Token成本
+	CostPer1KToken         float64 `json:"cost_per_1k_token,omitempty"`          // 回放模型的每千Token成本
+}
+
+// DiffOp 差异片段的类型
+type DiffOp string
+
+const (
+	DiffEqual  DiffOp = "equal"
+	DiffDelete DiffOp = "delete" // 仅出现在原回复中
+	DiffInsert DiffOp = "insert" // 仅出现在回放回复中
+)
+
+// DiffSegment 回复差异中的一段文本
+type DiffSegment struct {
+	Op   DiffOp `json:"op"`
+	Text string `json:"text"`
+}
+
+// Side 一轮对话在原对话或回放中的结果
+type Side struct {
+	Response        string  `json:"response"`
+	FirstTokenMs    int64   `json:"first_token_ms"` // 原对话取自对话耗时记录，未记录时为 0
+	CompleteMs      int64   `json:"complete_ms"`
+	Tokens          int64   `json:"tokens"`
+	TokensEstimated bool    `json:"tokens_estimated"` // Token数按文本长度估算，提供者未返回用量
+	Cost            float64 `json:"cost"`
+}
+
+// Turn 一轮对话的回放结果
+type Turn struct {
+	Round      int           `json:"round"`
+	Input      string        `json:"input"`
+	Redacted   bool          `json:"redacted"` // 原记录已脱敏，回放使用的是脱敏后的文本
+	Original   Side          `json:"original"`
+	Replay     Side          `json:"replay"`
+	Similarity float64       `json:"similarity"` // 两次回复的相似度 0-1
+	Diff       []DiffSegment `json:"diff"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// SideSummary 原对话或回放的汇总
+type SideSummary struct {
+	AvgFirstTokenMs float64 `json:"avg_first_token_ms"`
+	AvgCompleteMs   float64 `json:"avg_complete_ms"`
+	Tokens          int64   `json:"tokens"`
+	Cost            float64 `json:"cost"`
+}
+
+// Summary 回放汇总
+type Summary struct {
+	Turns         int         `json:"turns"`
+	Succeeded     int         `json:"succeeded"`
+	Skipped       int         `json:"skipped"` // 超出回放轮数上限而未回放的轮次
+	AvgSimilarity float64     `json:"avg_similarity"`
+	Original      SideSummary `json:"original"`
+	Replay        SideSummary `json:"replay"`
+}
+
+// Run 一次回放运行
+type Run struct {
+	ID           string     `json:"id"`
+	SessionID    string     `json:"session_id"`
+	DeviceID     string     `json:"device_id"`
+	Options      Options    `json:"options"`
+	ModelName    string     `json:"model_name"`    // 回放实际使用的模型
+	SystemPrompt string     `json:"system_prompt"` // 回放实际使用的系统提示词
+	Status       RunStatus  `json:"status"`
+	Error        string     `json:"error,omitempty"`
+	Summary      *Summary   `json:"summary,omitempty"`
+	Turns        []Turn     `json:"turns,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+}
+
+// RunFilter 回放运行查询条件
+type RunFilter struct {
+	SessionID string
+	Page      int
+	PageSize  int
+}
@@ -0,0 +1,15 @@
+package replay
+
+import "context"
+
+// Repository 回放运行存储接口
+type Repository interface {
+	SaveRun(ctx context.Context, r *Run) error
+	UpdateRun(ctx context.Context, r *Run) error
+	// FindRun 根据ID查找回放运行，不存在时返回 nil
+	FindRun(ctx context.Context, id string) (*Run, error)
+	// ListRuns 分页查询回放运行，不含各轮明细
+	ListRuns(ctx context.Context, filter RunFilter) ([]*Run, int64, error)
+	// FailUnfinished 将未完成的运行标记为失败，用于服务重启后清理
+	FailUnfinished(ctx context.Context, reason string) (int64, error)
+}
@@ -0,0 +1,445 @@
+package replay
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/domain/evaluation"
+	domainllm "xiaozhi-server-go/internal/domain/llm"
+	"xiaozhi-server-go/internal/domain/llm/inter"
+	"xiaozhi-server-go/internal/domain/prompt"
+	"xiaozhi-server-go/internal/domain/transcript"
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/observability"
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+const (
+	turnTimeout       = 2 * time.Minute
+	maxConcurrentRuns = 2
+	// maxTurns 单次回放的最大轮数，超出的轮次不回放
+	maxTurns = 50
+)
+
+// Transcripts 对话记录来源，由 transcript.Service 实现
+type Transcripts interface {
+	GetConversation(ctx context.Context, sessionID string) (*transcript.Conversation, []*transcript.Entry, error)
+	TurnLatencies(ctx context.Context, sessionID string) ([]*transcript.TurnLatency, error)
+}
+
+// Service 对话回放服务
+//
+// 取出已记录会话中每轮的用户输入，用当前（或指定的）LLM 配置和提示词重新生成回复，
+// 与原回复逐轮对比内容、耗时和成本。每轮的对话历史使用原回复，保证两边的上下文一致；
+// 工具调用不会重新执行。
+type Service struct {
+	cfg         *config.Config
+	transcripts Transcripts
+	repo        Repository
+	logger      *logging.Logger
+	now         func() time.Time
+
+	baseCtx context.Context
+	cancel  context.CancelFunc
+	slots   chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewService 创建对话回放服务
+func NewService(cfg *config.Config, transcripts Transcripts, repo Repository, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Service{
+		cfg:         cfg,
+		transcripts: transcripts,
+		repo:        repo,
+		logger:      logger,
+		now:         time.Now,
+		baseCtx:     ctx,
+		cancel:      cancel,
+		slots:       make(chan struct{}, maxConcurrentRuns),
+	}
+}
+
+// Run 清理上次未完成的回放，并在 ctx 结束时取消进行中的回放
+func (s *Service) Run(ctx context.Context) error {
+	if n, err := s.repo.FailUnfinished(ctx, "服务重启，回放中断"); err != nil {
+		s.logger.WarnTag("回放", "清理未完成的回放失败: %v", err)
+	} else if n > 0 {
+		s.logger.InfoTag("回放", "已将 %d 个未完成的回放标记为失败", n)
+	}
+
+	<-ctx.Done()
+	s.cancel()
+	s.wg.Wait()
+	s.logger.InfoTag("回放", "回放服务已停止")
+	return nil
+}
+
+// round 原对话中的一轮
+type round struct {
+	number   int
+	input    string
+	response string
+	redacted bool
+	latency  *transcript.TurnLatency
+}
+
+// Start 校验配置并在后台回放会话
+func (s *Service) Start(ctx context.Context, sessionID string, opts Options) (*Run, error) {
+	conv, entries, err := s.transcripts.GetConversation(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	llmCfg, err := s.llmConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.OriginalCostPer1KToken < 0 || opts.CostPer1KToken < 0 {
+		return nil, errors.New(errors.KindDomain, "replay.start", "cost per 1k token must not be negative")
+	}
+	systemPrompt, err := s.systemPrompt(ctx, conv, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rounds := buildRounds(entries)
+	if len(rounds) == 0 {
+		return nil, errors.New(errors.KindDomain, "replay.start", "conversation has no user input to replay")
+	}
+	latencies, err := s.transcripts.TurnLatencies(ctx, sessionID)
+	if err != nil {
+		s.logger.WarnTag("回放", "查询会话 %s 的对话耗时失败，原对话耗时记为 0: %v", sessionID, err)
+	}
+	byRound := make(map[int]*transcript.TurnLatency, len(latencies))
+	for _, l := range latencies {
+		byRound[l.Round] = l
+	}
+	for i := range rounds {
+		rounds[i].latency = byRound[rounds[i].number]
+	}
+
+	run := &Run{
+		ID:           uuid.New().String(),
+		SessionID:    sessionID,
+		DeviceID:     conv.DeviceID,
+		Options:      opts,
+		ModelName:    llmCfg.Model,
+		SystemPrompt: systemPrompt,
+		Status:       RunPending,
+		CreatedAt:    s.now(),
+	}
+	if err := s.repo.SaveRun(ctx, run); err != nil {
+		return nil, err
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.execute(run, llmCfg, rounds)
+	}()
+	return run, nil
+}
+
+// llmConfig 按回放选项生成 LLM 配置
+func (s *Service) llmConfig(opts Options) (inter.LLMConfig, error) {
+	name := opts.Model
+	if name == "" {
+		name = s.cfg.Selected.LLM
+	}
+	llmCfg, ok := s.cfg.LLM[name]
+	if !ok {
+		return inter.LLMConfig{}, errors.New(errors.KindDomain, "replay.start", "unknown model: "+name)
+	}
+	result := inter.LLMConfig{
+		Provider:    llmCfg.Type,
+		Model:       llmCfg.ModelName,
+		APIKey:      llmCfg.APIKey,
+		BaseURL:     llmCfg.BaseURL,
+		Temperature: float32(llmCfg.Temperature),
+		MaxTokens:   llmCfg.MaxTokens,
+		Timeout:     int(turnTimeout / time.Second),
+	}
+	if opts.ModelName != "" {
+		result.Model = opts.ModelName
+	}
+	if opts.Temperature != nil {
+		result.Temperature = float32(*opts.Temperature)
+	}
+	if opts.MaxTokens > 0 {
+		result.MaxTokens = opts.MaxTokens
+	}
+	return result, nil
+}
+
+// systemPrompt 按指定提示词、指定模板、设备当前分配、配置默认提示词的顺序确定系统提示词
+func (s *Service) systemPrompt(ctx context.Context, conv *transcript.Conversation, opts Options) (string, error) {
+	if opts.SystemPrompt != "" {
+		return opts.SystemPrompt, nil
+	}
+	vars := map[string]string{"device_id": conv.DeviceID, "session_id": conv.SessionID}
+	svc := prompt.Default()
+	if opts.PromptTemplate != "" {
+		if svc == nil {
+			return "", errors.New(errors.KindDomain, "replay.start", "prompt templates are not available")
+		}
+		out, _, err := svc.RenderTemplate(ctx, opts.PromptTemplate, opts.PromptVersion, vars)
+		return out, err
+	}
+	if svc != nil {
+		if out, ok := svc.ResolveForDevice(ctx, conv.DeviceID, vars); ok {
+			return out, nil
+		}
+	}
+	return s.cfg.System.DefaultPrompt, nil
+}
+
+// buildRounds 按轮次合并用户输入和助手回复，工具消息不参与回放
+func buildRounds(entries []*transcript.Entry) []round {
+	var rounds []round
+	index := make(map[int]int)
+	for _, e := range entries {
+		if e.Role != transcript.RoleUser && e.Role != transcript.RoleAssistant {
+			continue
+		}
+		i, ok := index[e.Round]
+		if !ok {
+			if e.Role != transcript.RoleUser {
+				continue
+			}
+			rounds = append(rounds, round{number: e.Round})
+			i = len(rounds) - 1
+			index[e.Round] = i
+		}
+		r := &rounds[i]
+		r.redacted = r.redacted || e.Redacted
+		if e.Role == transcript.RoleUser {
+			r.input = joinText(r.input, e.Content)
+		} else {
+			r.response = joinText(r.response, e.Content)
+		}
+	}
+	return rounds
+}
+
+func joinText(a, b string) string {
+	if a == "" {
+		return b
+	}
+	if b == "" {
+		return a
+	}
+	return a + "\n" + b
+}
+
+// execute 依次回放各轮并保存结果
+func (s *Service) execute(run *Run, llmCfg inter.LLMConfig, rounds []round) {
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	case <-s.baseCtx.Done():
+		s.finish(run, s.baseCtx.Err())
+		return
+	}
+
+	run.Status = RunRunning
+	if err := s.repo.UpdateRun(s.baseCtx, run); err != nil {
+		s.logger.WarnTag("回放", "更新回放状态失败: %v", err)
+	}
+	s.logger.InfoTag("回放", "开始回放会话 %s，%d 轮，模型 %s", run.SessionID, len(rounds), llmCfg.Model)
+
+	skipped := 0
+	if len(rounds) > maxTurns {
+		skipped = len(rounds) - maxTurns
+		rounds = rounds[:maxTurns]
+	}
+	manager := domainllm.NewManager(llmCfg)
+	defer manager.Close()
+
+	history := []inter.Message{{Role: "system", Content: run.SystemPrompt}}
+	turns := make([]Turn, 0, len(rounds))
+	for _, r := range rounds {
+		if s.baseCtx.Err() != nil {
+			break
+		}
+		messages := append(slices.Clip(history), inter.Message{Role: "user", Content: r.input})
+		turns = append(turns, s.replayTurn(manager, run, messages, r))
+		// 后续轮次的历史使用原回复，与原对话的上下文保持一致
+		history = append(messages, inter.Message{Role: "assistant", Content: r.response})
+	}
+
+	run.Turns = turns
+	run.Summary = summarize(turns, skipped)
+	s.finish(run, s.baseCtx.Err())
+}
+
+// replayTurn 回放一轮对话并与原回复对比
+func (s *Service) replayTurn(manager *domainllm.Manager, run *Run, messages []inter.Message, r round) Turn {
+	turn := Turn{
+		Round:    r.number,
+		Input:    r.input,
+		Redacted: r.redacted,
+		Original: Side{Response: r.response},
+	}
+	promptTokens := estimatePromptTokens(messages)
+	turn.Original.Tokens = promptTokens + capability.EstimateTokens(r.response)
+	turn.Original.TokensEstimated = true
+	turn.Original.Cost = cost(turn.Original.Tokens, run.Options.OriginalCostPer1KToken)
+	if r.latency != nil {
+		turn.Original.FirstTokenMs = r.latency.LLMFirstTokenMs
+		turn.Original.CompleteMs = r.latency.LLMCompleteMs
+	}
+
+	ctx, cancel := context.WithTimeout(s.baseCtx, turnTimeout)
+	defer cancel()
+	start := time.Now()
+	responses, err := manager.Response(ctx, "replay-"+run.ID, messages, nil)
+	var content strings.Builder
+	var usage *inter.Usage
+	if err == nil {
+		for chunk := range responses {
+			if chunk.Error != nil {
+				err = chunk.Error
+				continue
+			}
+			if chunk.Content != "" && content.Len() == 0 {
+				turn.Replay.FirstTokenMs = time.Since(start).Milliseconds()
+			}
+			content.WriteString(chunk.Content)
+			if chunk.Usage != nil {
+				usage = chunk.Usage
+			}
+		}
+		if err == nil {
+			err = ctx.Err()
+		}
+	}
+	turn.Replay.CompleteMs = time.Since(start).Milliseconds()
+	turn.Replay.Response = strings.TrimSpace(content.String())
+	if usage != nil && usage.TotalTokens > 0 {
+		turn.Replay.Tokens = int64(usage.TotalTokens)
+	} else {
+		turn.Replay.Tokens = promptTokens + capability.EstimateTokens(turn.Replay.Response)
+		turn.Replay.TokensEstimated = true
+	}
+	turn.Replay.Cost = cost(turn.Replay.Tokens, run.Options.CostPer1KToken)
+	if err != nil {
+		turn.Error = err.Error()
+		s.logger.WarnTag("回放", "会话 %s 第 %d 轮回放失败: %v", run.SessionID, r.number, err)
+	}
+	turn.Similarity = evaluation.Similarity(turn.Original.Response, turn.Replay.Response)
+	turn.Diff = Diff(turn.Original.Response, turn.Replay.Response)
+
+	observability.RecordMetric(s.baseCtx, "replay.turn_latency_ms", float64(turn.Replay.CompleteMs), map[string]string{
+		"model": run.ModelName,
+	})
+	return turn
+}
+
+func estimatePromptTokens(messages []inter.Message) int64 {
+	var tokens int64
+	for _, m := range messages {
+		tokens += capability.EstimateTokens(m.Content)
+	}
+	return tokens
+}
+
+func cost(tokens int64, per1K float64) float64 {
+	return float64(tokens) / 1000 * per1K
+}
+
+// summarize 汇总各轮结果，原对话的耗时只统计有记录的轮次
+func summarize(turns []Turn, skipped int) *Summary {
+	summary := &Summary{Turns: len(turns), Skipped: skipped}
+	var similarity float64
+	var origFirst, origComplete, replayFirst, replayComplete []int64
+	for _, t := range turns {
+		summary.Original.Tokens += t.Original.Tokens
+		summary.Original.Cost += t.Original.Cost
+		if t.Original.FirstTokenMs > 0 {
+			origFirst = append(origFirst, t.Original.FirstTokenMs)
+		}
+		if t.Original.CompleteMs > 0 {
+			origComplete = append(origComplete, t.Original.CompleteMs)
+		}
+		if t.Error != "" {
+			continue
+		}
+		summary.Succeeded++
+		similarity += t.Similarity
+		summary.Replay.Tokens += t.Replay.Tokens
+		summary.Replay.Cost += t.Replay.Cost
+		replayFirst = append(replayFirst, t.Replay.FirstTokenMs)
+		replayComplete = append(replayComplete, t.Replay.CompleteMs)
+	}
+	if summary.Succeeded > 0 {
+		summary.AvgSimilarity = similarity / float64(summary.Succeeded)
+	}
+	summary.Original.AvgFirstTokenMs = average(origFirst)
+	summary.Original.AvgCompleteMs = average(origComplete)
+	summary.Replay.AvgFirstTokenMs = average(replayFirst)
+	summary.Replay.AvgCompleteMs = average(replayComplete)
+	return summary
+}
+
+func average(values []int64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum int64
+	for _, v := range values {
+		sum += v
+	}
+	return float64(sum) / float64(len(values))
+}
+
+// finish 保存回放结果
+func (s *Service) finish(run *Run, err error) {
+	now := s.now()
+	run.FinishedAt = &now
+	run.Status = RunCompleted
+	if err != nil {
+		run.Status = RunFailed
+		run.Error = err.Error()
+	}
+	// 服务停止时 baseCtx 已取消，使用独立的 ctx 保存最终状态
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.repo.UpdateRun(ctx, run); err != nil {
+		s.logger.ErrorTag("回放", "保存回放结果失败: %v", err)
+		return
+	}
+	s.logger.InfoTag("回放", "回放 %s 结束，状态 %s", run.ID, run.Status)
+}
+
+// Get 获取回放运行及各轮结果
+func (s *Service) Get(ctx context.Context, id string) (*Run, error) {
+	run, err := s.repo.FindRun(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, errors.Wrap(errors.KindDomain, "replay.get", "replay run not found", ErrNotFound)
+	}
+	return run, nil
+}
+
+// List 分页查询回放运行
+func (s *Service) List(ctx context.Context, filter RunFilter) ([]*Run, int64, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = 20
+	}
+	return s.repo.ListRuns(ctx, filter)
+}
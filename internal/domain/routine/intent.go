@@ -0,0 +1,61 @@
+package routine
+
+import (
+	"context"
+
+	"xiaozhi-server-go/internal/domain/intent"
+)
+
+// slotRoutineID 唤醒短语分类结果中的例程ID
+const slotRoutineID = "routine_id"
+
+// PhraseClassifier 返回按设备唤醒短语识别例程的分类器，需插在规则分类器之前，
+// 避免“早间播报”这类短语被新闻等规则抢先命中
+func (s *Service) PhraseClassifier(deviceID string) intent.Classifier {
+	return &phraseClassifier{s: s, deviceID: deviceID}
+}
+
+type phraseClassifier struct {
+	s        *Service
+	deviceID string
+}
+
+func (c *phraseClassifier) Name() string {
+	return "routine"
+}
+
+func (c *phraseClassifier) Classify(ctx context.Context, text string) (*intent.Result, error) {
+	r := c.s.MatchPhrase(c.deviceID, text)
+	if r == nil {
+		return nil, nil
+	}
+	return &intent.Result{
+		Intent:     intent.IntentRoutine,
+		Confidence: 1,
+		Slots:      map[string]string{slotRoutineID: r.ID},
+		Classifier: c.Name(),
+	}, nil
+}
+
+// IntentHandler 返回执行例程的确定性意图处理器，合并后的播报作为本轮回复
+func (s *Service) IntentHandler() intent.Handler {
+	return &routineHandler{s}
+}
+
+type routineHandler struct{ s *Service }
+
+func (h *routineHandler) Intent() string {
+	return intent.IntentRoutine
+}
+
+func (h *routineHandler) Handle(ctx context.Context, req *intent.Request, result *intent.Result) (*intent.Response, error) {
+	id := result.Slots[slotRoutineID]
+	if id == "" {
+		return &intent.Response{Handled: false}, nil
+	}
+	reply, err := h.s.Trigger(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &intent.Response{Reply: reply, Handled: true}, nil
+}
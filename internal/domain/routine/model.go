@@ -0,0 +1,144 @@
+package routine
+
+import (
+	stderrors "errors"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ActionType 例程动作类型
+type ActionType string
+
+const (
+	ActionWeather  ActionType = "weather"  // 天气播报，Param 为地点，为空时使用设备所在城市
+	ActionNews     ActionType = "news"     // 新闻播报，Param 为新闻分类，为空时播报头条
+	ActionCalendar ActionType = "calendar" // 今天的日程
+	ActionSay      ActionType = "say"      // 播报固定文本，Param 为文本
+	ActionWorkflow ActionType = "workflow" // 执行租户当前的工作流，Param 为工作流ID，播报其 reply 输出
+)
+
+// Status 最近一次执行结果
+type Status string
+
+const (
+	StatusOK      Status = "ok"
+	StatusPartial Status = "partial" // 部分动作失败
+	StatusFailed  Status = "failed"  // 全部动作失败
+	StatusMissed  Status = "missed"  // 定时触发时设备不在线，未能播报
+)
+
+var (
+	// ErrNotFound 例程不存在
+	ErrNotFound = stderrors.New("routine not found")
+	// ErrLimitReached 设备的例程数已达上限
+	ErrLimitReached = stderrors.New("routine limit reached")
+	// ErrPhraseTaken 设备上已有例程使用相同的唤醒短语
+	ErrPhraseTaken = stderrors.New("wake phrase already in use")
+	// ErrDeviceOffline 设备不在线，执行结果无法推送
+	ErrDeviceOffline = stderrors.New("device offline")
+)
+
+// Action 例程中的一个动作
+type Action struct {
+	Type  ActionType `json:"type"`
+	Param string     `json:"param,omitempty"`
+}
+
+// Routine 例程，由定时计划或唤醒短语触发，依次执行动作并合并播报
+type Routine struct {
+	ID         string     `json:"id"`
+	DeviceID   string     `json:"device_id"`
+	TenantID   string     `json:"tenant_id"` // 创建时所在的租户，工作流动作按该租户加载工作流
+	Name       string     `json:"name"`
+	Schedule   string     `json:"schedule,omitempty"` // 五段式 cron 表达式（分 时 日 月 周），为空时只能用唤醒短语触发
+	Phrase     string     `json:"phrase,omitempty"`   // 唤醒短语，如“早安”
+	Actions    []Action   `json:"actions"`
+	Enabled    bool       `json:"enabled"`
+	NextRunAt  *time.Time `json:"next_run_at,omitempty"` // 下次定时触发时间，不持久化，加载时按计划重新计算
+	LastRunAt  *time.Time `json:"last_run_at,omitempty"`
+	LastStatus Status     `json:"last_status,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// IsValidActionType 检查动作类型是否合法
+func IsValidActionType(t ActionType) bool {
+	switch t {
+	case ActionWeather, ActionNews, ActionCalendar, ActionSay, ActionWorkflow:
+		return true
+	}
+	return false
+}
+
+// validateActions 检查动作列表，say 和 workflow 动作必须带参数
+func validateActions(actions []Action) string {
+	if len(actions) == 0 {
+		return "at least one action is required"
+	}
+	for _, a := range actions {
+		if !IsValidActionType(a.Type) {
+			return "invalid action type: " + string(a.Type)
+		}
+		if (a.Type == ActionSay || a.Type == ActionWorkflow) && strings.TrimSpace(a.Param) == "" {
+			return string(a.Type) + " action requires param"
+		}
+	}
+	return ""
+}
+
+// NormalizePhrase 去掉空白和标点，用于唤醒短语的比较
+func NormalizePhrase(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Describe 例程的口语描述，如“每天7点整播报天气、日程和新闻”
+func (r *Routine) Describe() string {
+	var when string
+	if r.Schedule != "" {
+		if schedule, err := ParseSchedule(r.Schedule); err == nil {
+			when = schedule.Describe()
+		}
+	}
+	if r.Phrase != "" {
+		if when != "" {
+			when += "或"
+		}
+		when += "说“" + r.Phrase + "”时"
+	}
+	return when + describeActions(r.Actions)
+}
+
+func describeActions(actions []Action) string {
+	names := make([]string, 0, len(actions))
+	for _, a := range actions {
+		var name string
+		switch a.Type {
+		case ActionWeather:
+			name = "天气"
+		case ActionNews:
+			name = "新闻"
+		case ActionCalendar:
+			name = "日程"
+		case ActionSay:
+			name = "“" + a.Param + "”"
+		case ActionWorkflow:
+			name = "工作流"
+		}
+		names = append(names, name)
+	}
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return "播报" + names[0]
+	}
+	return "播报" + strings.Join(names[:len(names)-1], "、") + "和" + names[len(names)-1]
+}
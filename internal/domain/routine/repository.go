@@ -0,0 +1,17 @@
+package routine
+
+import (
+	"context"
+)
+
+// Repository 例程仓库接口
+type Repository interface {
+	// List 查询全部例程
+	List(ctx context.Context) ([]*Routine, error)
+
+	// Save 新建或更新例程
+	Save(ctx context.Context, r *Routine) error
+
+	// Delete 删除例程
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,206 @@
+package routine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule 解析后的五段式 cron 表达式：分 时 日 月 周
+// 每段支持 *、数字、范围 a-b、步长 */n 和 a-b/n 以及逗号分隔的列表，周的 0 和 7 都表示周日；
+// 日和周同时受限时满足其一即可，与标准 cron 一致
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+// Repeat 语音创建例程时的重复方式
+type Repeat string
+
+const (
+	RepeatDaily    Repeat = "daily"
+	RepeatWeekdays Repeat = "weekdays"
+	RepeatWeekends Repeat = "weekends"
+	RepeatWeekly   Repeat = "weekly"
+)
+
+// maxSearch 查找下次触发时间的上限，避免永远不会触发的表达式（如 2 月 30 日）死循环
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+var weekdayNames = []string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"}
+
+// ParseSchedule 解析 cron 表达式
+func ParseSchedule(expr string) (*Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression must have 5 fields, got %d", len(fields))
+	}
+	s := &Schedule{}
+	var err error
+	if s.minute, err = parseField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("minute: %w", err)
+	}
+	if s.hour, err = parseField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("hour: %w", err)
+	}
+	if s.dom, err = parseField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("day of month: %w", err)
+	}
+	if s.month, err = parseField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("month: %w", err)
+	}
+	if s.dow, err = parseField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("day of week: %w", err)
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domAny = fields[2] == "*"
+	s.dowAny = fields[4] == "*"
+	return s, nil
+}
+
+func parseField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part)
+			}
+			rangePart, step = part[:i], n
+		}
+		lo, hi := min, max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("invalid value %q", part)
+				}
+			} else if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("value %q out of range %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// Next 返回 after 之后（不含）的下一次触发时间，找不到时返回零值
+func (s *Schedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxSearch)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// Describe 常见计划的口语描述，如“每天7点整”“工作日6点30分”，其余返回“按计划”
+func (s *Schedule) Describe() string {
+	hour, okHour := single(s.hour)
+	minute, okMinute := single(s.minute)
+	if !okHour || !okMinute || !s.domAny || s.month != fullBits(1, 12) {
+		return "按计划"
+	}
+	clock := fmt.Sprintf("%d点%02d分", hour, minute)
+	if minute == 0 {
+		clock = fmt.Sprintf("%d点整", hour)
+	}
+	dow := s.dow &^ (1 << 7)
+	switch dow {
+	case fullBits(0, 6):
+		return "每天" + clock
+	case fullBits(1, 5):
+		return "工作日" + clock
+	case 1<<0 | 1<<6:
+		return "周末" + clock
+	}
+	if day, ok := single(dow); ok {
+		return "每" + weekdayNames[day] + clock
+	}
+	return "按计划"
+}
+
+// ScheduleFor 由时刻（HH:MM）和重复方式生成 cron 表达式，weekly 时 weekday 为 0-6（0 为周日）
+func ScheduleFor(clock string, repeat Repeat, weekday int) (string, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(clock))
+	if err != nil {
+		return "", fmt.Errorf("invalid time %q, expected HH:MM", clock)
+	}
+	var dow string
+	switch repeat {
+	case RepeatDaily, "":
+		dow = "*"
+	case RepeatWeekdays:
+		dow = "1-5"
+	case RepeatWeekends:
+		dow = "0,6"
+	case RepeatWeekly:
+		if weekday < 0 || weekday > 6 {
+			return "", fmt.Errorf("invalid weekday %d", weekday)
+		}
+		dow = strconv.Itoa(weekday)
+	default:
+		return "", fmt.Errorf("invalid repeat %q", repeat)
+	}
+	return fmt.Sprintf("%d %d * * %s", t.Minute(), t.Hour(), dow), nil
+}
+
+// single 位集合中只有一个值时返回该值
+func single(bits uint64) (int, bool) {
+	if bits == 0 || bits&(bits-1) != 0 {
+		return 0, false
+	}
+	for i := 0; i < 64; i++ {
+		if bits == 1<<uint(i) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func fullBits(lo, hi int) uint64 {
+	var bits uint64
+	for v := lo; v <= hi; v++ {
+		bits |= 1 << uint(v)
+	}
+	return bits
+}
@@ -0,0 +1,529 @@
+package routine
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/domain/tenant"
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/observability"
+	"xiaozhi-server-go/internal/workflow"
+)
+
+const (
+	pollInterval = 15 * time.Second
+	// 唤醒短语前后允许多出的字数，如“小智早安”“早安啊”都能触发“早安”
+	phraseSlack = 2
+	// outputReply 工作流动作播报的节点输出，与对话流水线的约定一致
+	outputReply = "reply"
+)
+
+// Notifier 将例程的播报推送到设备，设备不在线时返回错误
+type Notifier interface {
+	NotifyDevice(ctx context.Context, deviceID string, text string) error
+}
+
+// Skills 天气、新闻和日程技能，由内置技能服务实现
+type Skills interface {
+	Weather(ctx context.Context, deviceID, place string, day int) (string, error)
+	News(ctx context.Context, category string) (string, error)
+	Agenda(ctx context.Context, deviceID string, day int) (string, error)
+}
+
+// CreateRequest 创建例程请求，Schedule 和 Phrase 至少提供一个
+type CreateRequest struct {
+	DeviceID string
+	Name     string
+	Schedule string
+	Phrase   string
+	Actions  []Action
+	Enabled  *bool // 为 nil 时默认启用
+}
+
+// UpdateRequest 更新例程请求，nil 字段表示不修改
+type UpdateRequest struct {
+	Name     *string
+	Schedule *string
+	Phrase   *string
+	Actions  []Action
+	Enabled  *bool
+}
+
+// Service 例程服务，负责例程的增删改查、定时触发和唤醒短语匹配
+type Service struct {
+	cfg      config.RoutinesConfig
+	repo     Repository
+	skills   Skills
+	executor workflow.WorkflowExecutor
+	notifier Notifier
+	logger   *logging.Logger
+	now      func() time.Time
+
+	mu       sync.RWMutex
+	routines map[string]*Routine
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局例程服务，供连接处理器和本地工具使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局例程服务，未启用时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建例程服务，skills 和 executor 为 nil 时对应的动作执行失败
+func NewService(cfg config.RoutinesConfig, repo Repository, skills Skills, executor workflow.WorkflowExecutor, notifier Notifier, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Service{
+		cfg:      cfg,
+		repo:     repo,
+		skills:   skills,
+		executor: executor,
+		notifier: notifier,
+		logger:   logger,
+		now:      time.Now,
+		routines: make(map[string]*Routine),
+	}
+}
+
+// Load 加载已保存的例程并计算下次触发时间，停机期间错过的触发不再补发
+func (s *Service) Load(ctx context.Context) error {
+	saved, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range saved {
+		s.plan(r, now)
+		s.routines[r.ID] = r
+	}
+	return nil
+}
+
+// Create 创建例程
+func (s *Service) Create(ctx context.Context, req CreateRequest) (*Routine, error) {
+	if strings.TrimSpace(req.DeviceID) == "" {
+		return nil, errors.New(errors.KindDomain, "routine.create", "device_id is required")
+	}
+	now := s.now()
+	r := &Routine{
+		ID:        uuid.New().String(),
+		DeviceID:  req.DeviceID,
+		TenantID:  tenant.IDFrom(ctx),
+		Name:      strings.TrimSpace(req.Name),
+		Schedule:  strings.TrimSpace(req.Schedule),
+		Phrase:    strings.TrimSpace(req.Phrase),
+		Actions:   req.Actions,
+		Enabled:   req.Enabled == nil || *req.Enabled,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if r.Name == "" {
+		r.Name = r.Phrase
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := 0
+	for _, existing := range s.routines {
+		if existing.DeviceID == r.DeviceID {
+			count++
+		}
+	}
+	if s.cfg.MaxPerDevice > 0 && count >= s.cfg.MaxPerDevice {
+		return nil, errors.Wrap(errors.KindDomain, "routine.create", fmt.Sprintf("device already has %d routines", count), ErrLimitReached)
+	}
+	if err := s.validate("routine.create", r); err != nil {
+		return nil, err
+	}
+	s.plan(r, now)
+	if err := s.repo.Save(ctx, r); err != nil {
+		return nil, err
+	}
+	s.routines[r.ID] = r
+
+	s.logger.InfoTag("例程", "已创建例程 %s（%s），设备 %s：%s", r.ID, r.Name, r.DeviceID, r.Describe())
+	observability.RecordMetric(ctx, "routine_created_total", 1, nil)
+	return clone(r), nil
+}
+
+// Get 获取例程
+func (s *Service) Get(ctx context.Context, id string) (*Routine, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	r, ok := s.routines[id]
+	if !ok {
+		return nil, errors.Wrap(errors.KindDomain, "routine.get", "routine not found", ErrNotFound)
+	}
+	return clone(r), nil
+}
+
+// List 按创建时间列出例程，deviceID 为空时列出全部
+func (s *Service) List(ctx context.Context, deviceID string) []*Routine {
+	s.mu.RLock()
+	items := make([]*Routine, 0, len(s.routines))
+	for _, r := range s.routines {
+		if deviceID == "" || r.DeviceID == deviceID {
+			items = append(items, clone(r))
+		}
+	}
+	s.mu.RUnlock()
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].CreatedAt.Before(items[j].CreatedAt)
+	})
+	return items
+}
+
+// Update 修改例程
+func (s *Service) Update(ctx context.Context, id string, req UpdateRequest) (*Routine, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, ok := s.routines[id]
+	if !ok {
+		return nil, errors.Wrap(errors.KindDomain, "routine.update", "routine not found", ErrNotFound)
+	}
+	r := clone(current)
+	if req.Name != nil {
+		r.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Schedule != nil {
+		r.Schedule = strings.TrimSpace(*req.Schedule)
+	}
+	if req.Phrase != nil {
+		r.Phrase = strings.TrimSpace(*req.Phrase)
+	}
+	if req.Actions != nil {
+		r.Actions = req.Actions
+	}
+	if req.Enabled != nil {
+		r.Enabled = *req.Enabled
+	}
+	if err := s.validate("routine.update", r); err != nil {
+		return nil, err
+	}
+	r.UpdatedAt = s.now()
+	s.plan(r, r.UpdatedAt)
+	if err := s.repo.Save(ctx, r); err != nil {
+		return nil, err
+	}
+	s.routines[id] = r
+	return clone(r), nil
+}
+
+// Delete 删除例程
+func (s *Service) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.routines[id]; !ok {
+		return errors.Wrap(errors.KindDomain, "routine.delete", "routine not found", ErrNotFound)
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	delete(s.routines, id)
+	return nil
+}
+
+// validate 检查例程内容，调用方需持有写锁
+func (s *Service) validate(op string, r *Routine) error {
+	if r.Name == "" {
+		return errors.New(errors.KindDomain, op, "name is required")
+	}
+	if r.Schedule == "" && r.Phrase == "" {
+		return errors.New(errors.KindDomain, op, "schedule or phrase is required")
+	}
+	if r.Schedule != "" {
+		if _, err := ParseSchedule(r.Schedule); err != nil {
+			return errors.New(errors.KindDomain, op, "invalid schedule: "+err.Error())
+		}
+	}
+	if msg := validateActions(r.Actions); msg != "" {
+		return errors.New(errors.KindDomain, op, msg)
+	}
+	if phrase := NormalizePhrase(r.Phrase); r.Phrase != "" {
+		if phrase == "" {
+			return errors.New(errors.KindDomain, op, "invalid phrase")
+		}
+		for _, existing := range s.routines {
+			if existing.ID != r.ID && existing.DeviceID == r.DeviceID && NormalizePhrase(existing.Phrase) == phrase {
+				return errors.Wrap(errors.KindDomain, op, "phrase is used by routine "+existing.Name, ErrPhraseTaken)
+			}
+		}
+	}
+	return nil
+}
+
+// plan 计算下次定时触发时间，停用或没有定时计划的例程没有下次触发时间
+func (s *Service) plan(r *Routine, now time.Time) {
+	r.NextRunAt = nil
+	if !r.Enabled || r.Schedule == "" {
+		return
+	}
+	schedule, err := ParseSchedule(r.Schedule)
+	if err != nil {
+		s.logger.WarnTag("例程", "例程 %s 的定时计划无效: %v", r.ID, err)
+		return
+	}
+	if next := schedule.Next(now); !next.IsZero() {
+		r.NextRunAt = &next
+	}
+}
+
+// MatchPhrase 返回设备上唤醒短语与语句匹配的已启用例程，没有时返回 nil
+// 语句与短语相同，或包含短语且只多出少量字时视为匹配，多个匹配时取最长的短语
+func (s *Service) MatchPhrase(deviceID, text string) *Routine {
+	normalized := NormalizePhrase(text)
+	if normalized == "" {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var best *Routine
+	bestLen := 0
+	for _, r := range s.routines {
+		if r.DeviceID != deviceID || !r.Enabled || r.Phrase == "" {
+			continue
+		}
+		phrase := NormalizePhrase(r.Phrase)
+		if phrase == "" || !strings.Contains(normalized, phrase) {
+			continue
+		}
+		length := utf8.RuneCountInString(phrase)
+		if utf8.RuneCountInString(normalized)-length > phraseSlack {
+			continue
+		}
+		if length > bestLen {
+			best, bestLen = r, length
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return clone(best)
+}
+
+// Trigger 执行例程并返回合并后的播报文本，由调用方负责播报（如语音唤醒时作为本轮回复）
+func (s *Service) Trigger(ctx context.Context, id string) (string, error) {
+	r, err := s.Get(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	reply, status := s.execute(ctx, r)
+	s.record(ctx, r.ID, status)
+	return reply, nil
+}
+
+// RunNow 立即执行例程并推送到设备，设备不在线时返回 ErrDeviceOffline 和未能播报的文本
+func (s *Service) RunNow(ctx context.Context, id string) (string, error) {
+	r, err := s.Get(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return s.fire(ctx, r)
+}
+
+// Run 启动定时触发循环，直到 ctx 结束
+func (s *Service) Run(ctx context.Context) error {
+	s.logger.InfoTag("例程", "例程调度器已启动")
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.InfoTag("例程", "例程调度器已停止")
+			return nil
+		case <-ticker.C:
+			s.fireDue(ctx)
+		}
+	}
+}
+
+// fireDue 触发到期的例程，先滚动下次触发时间，执行较慢时不会重复触发
+func (s *Service) fireDue(ctx context.Context) {
+	now := s.now()
+	var due []*Routine
+	s.mu.Lock()
+	for _, r := range s.routines {
+		if r.NextRunAt != nil && !now.Before(*r.NextRunAt) {
+			due = append(due, clone(r))
+			s.plan(r, now)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, r := range due {
+		go func(r *Routine) {
+			if _, err := s.fire(ctx, r); err != nil && !stderrors.Is(err, ErrDeviceOffline) {
+				s.logger.ErrorTag("例程", "例程 %s 执行失败: %v", r.ID, err)
+			}
+		}(r)
+	}
+}
+
+// fire 执行例程并推送到设备
+func (s *Service) fire(ctx context.Context, r *Routine) (string, error) {
+	reply, status := s.execute(ctx, r)
+
+	var notifyErr error
+	if s.notifier == nil {
+		notifyErr = stderrors.New("notifier not configured")
+	} else {
+		notifyErr = s.notifier.NotifyDevice(ctx, r.DeviceID, reply)
+	}
+	if notifyErr != nil {
+		s.logger.WarnTag("例程", "例程 %s 推送到设备 %s 失败: %v", r.ID, r.DeviceID, notifyErr)
+		s.record(ctx, r.ID, StatusMissed)
+		return reply, errors.Wrap(errors.KindDomain, "routine.run", notifyErr.Error(), ErrDeviceOffline)
+	}
+	s.logger.InfoTag("例程", "例程 %s 已推送到设备 %s", r.ID, r.DeviceID)
+	s.record(ctx, r.ID, status)
+	return reply, nil
+}
+
+// execute 依次执行例程的动作并合并播报文本，失败的动作跳过
+func (s *Service) execute(ctx context.Context, r *Routine) (string, Status) {
+	ctx = tenant.WithID(ctx, r.TenantID)
+	var parts []string
+	failed := 0
+	for _, action := range r.Actions {
+		text, err := s.runAction(ctx, r, action)
+		if err != nil {
+			failed++
+			s.logger.WarnTag("例程", "例程 %s 的动作 %s 执行失败: %v", r.ID, action.Type, err)
+			continue
+		}
+		if text = strings.TrimSpace(text); text != "" {
+			parts = append(parts, text)
+		}
+	}
+
+	status := StatusOK
+	switch {
+	case failed == len(r.Actions):
+		status = StatusFailed
+	case failed > 0:
+		status = StatusPartial
+	}
+	observability.RecordMetric(ctx, "routine_runs_total", 1, map[string]string{"status": string(status)})
+	if len(parts) == 0 {
+		return fmt.Sprintf("抱歉，%s暂时没能完成", r.Name), status
+	}
+	return strings.Join(parts, "\n"), status
+}
+
+func (s *Service) runAction(ctx context.Context, r *Routine, action Action) (string, error) {
+	if s.cfg.ActionTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.cfg.ActionTimeout)
+		defer cancel()
+	}
+	switch action.Type {
+	case ActionSay:
+		return action.Param, nil
+	case ActionWorkflow:
+		return s.runWorkflow(ctx, r, action.Param)
+	}
+	if s.skills == nil {
+		return "", fmt.Errorf("skills are not enabled")
+	}
+	switch action.Type {
+	case ActionWeather:
+		return s.skills.Weather(ctx, r.DeviceID, action.Param, 0)
+	case ActionNews:
+		return s.skills.News(ctx, action.Param)
+	case ActionCalendar:
+		return s.skills.Agenda(ctx, r.DeviceID, 0)
+	}
+	return "", fmt.Errorf("unsupported action type %s", action.Type)
+}
+
+// runWorkflow 同步执行租户当前的工作流，按执行顺序取第一个 reply 输出
+func (s *Service) runWorkflow(ctx context.Context, r *Routine, workflowID string) (string, error) {
+	if s.executor == nil {
+		return "", fmt.Errorf("workflow executor is not available")
+	}
+	wf, err := workflow.LoadTenantWorkflow(r.TenantID)
+	if err != nil {
+		return "", err
+	}
+	if wf.ID != workflowID {
+		return "", fmt.Errorf("workflow %s is not the current workflow of tenant %s", workflowID, r.TenantID)
+	}
+	order, err := workflow.NewDAGEngine(s.logger).TopologicalSort(wf.Nodes, wf.Edges)
+	if err != nil {
+		return "", err
+	}
+	execution, err := s.executor.Run(ctx, wf, map[string]interface{}{
+		"device_id":  r.DeviceID,
+		"routine_id": r.ID,
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, nodeID := range order {
+		node, ok := execution.NodeResults[nodeID]
+		if !ok || node.Status != workflow.NodeStatusCompleted {
+			continue
+		}
+		if v, _ := node.Outputs[outputReply].(string); strings.TrimSpace(v) != "" {
+			return v, nil
+		}
+	}
+	v, _ := execution.Outputs[outputReply].(string)
+	return v, nil
+}
+
+// record 保存最近一次执行时间和结果，例程已被删除时忽略
+func (s *Service) record(ctx context.Context, id string, status Status) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.routines[id]
+	if !ok {
+		return
+	}
+	now := s.now()
+	r.LastRunAt = &now
+	r.LastStatus = status
+	if err := s.repo.Save(ctx, r); err != nil {
+		s.logger.ErrorTag("例程", "保存例程 %s 的执行结果失败: %v", id, err)
+	}
+}
+
+func clone(r *Routine) *Routine {
+	c := *r
+	c.Actions = append([]Action(nil), r.Actions...)
+	if r.NextRunAt != nil {
+		next := *r.NextRunAt
+		c.NextRunAt = &next
+	}
+	if r.LastRunAt != nil {
+		last := *r.LastRunAt
+		c.LastRunAt = &last
+	}
+	return &c
+}
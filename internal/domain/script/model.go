@@ -0,0 +1,30 @@
+package script
+
+import "time"
+
+// Language 脚本语言
+type Language string
+
+// LanguageLua 目前只支持 Lua 5.1
+const LanguageLua Language = "lua"
+
+// Script 脚本，每次修改源码生成一个新版本，Version 为当前版本号
+type Script struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Language    Language  `json:"language"`
+	Version     int       `json:"version"`
+	Source      string    `json:"source"` // 当前版本的源码
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Version 脚本的一个历史版本，版本号从 1 开始递增
+type Version struct {
+	ScriptID  string    `json:"script_id"`
+	Version   int       `json:"version"`
+	Source    string    `json:"source"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,111 @@
+package script
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/workflow"
+)
+
+// NodeType 工作流脚本节点类型
+const NodeType workflow.NodeType = "script"
+
+// RegisterWorkflowNode 将脚本节点注册到工作流节点注册表
+// 节点配置 script_id 引用已保存的脚本，version 固定版本（缺省为执行时的当前版本），
+// 或者用 source 直接内联源码；timeout 为运行时间上限
+func RegisterWorkflowNode(nodes *workflow.NodeRegistry, service *Service) error {
+	return nodes.Register(workflow.NodeTypeDefinition{
+		Type:        NodeType,
+		Name:        "脚本",
+		Description: "在沙箱中运行 Lua 脚本，脚本只能访问节点输入和受限的 API",
+		ConfigSchema: capability.Schema{
+			Type: "object",
+			Properties: map[string]capability.Property{
+				"script_id": {Type: "string", Description: "已保存脚本的ID"},
+				"version":   {Type: "integer", Description: "固定使用的脚本版本，缺省为当前版本"},
+				"source":    {Type: "string", Description: "内联 Lua 源码，未设置 script_id 时使用"},
+				"timeout":   {Type: "string", Description: "运行时间上限，如 5s，最长 1m", Default: "5s"},
+			},
+		},
+		OutputSchema: capability.Schema{
+			Type: "object",
+		},
+		UI: &capability.UIHints{Category: "logic", Icon: "code"},
+	}, workflow.NodeExecutorFunc(func(ctx context.Context, node *workflow.Node, inputs map[string]interface{}) (map[string]interface{}, error) {
+		return executeNode(ctx, service, node, inputs)
+	}))
+}
+
+func executeNode(ctx context.Context, service *Service, node *workflow.Node, inputs map[string]interface{}) (map[string]interface{}, error) {
+	timeout, err := configTimeout(node.Config["timeout"])
+	if err != nil {
+		return nil, err
+	}
+
+	name := "node:" + node.ID
+	source, _ := node.Config["source"].(string)
+	if id, _ := node.Config["script_id"].(string); strings.TrimSpace(id) != "" {
+		version, err := configInt(node.Config["version"])
+		if err != nil {
+			return nil, err
+		}
+		var resolved int
+		source, resolved, err = service.Source(ctx, id, version)
+		if err != nil {
+			return nil, err
+		}
+		name = fmt.Sprintf("%s@v%d", id, resolved)
+	}
+	if strings.TrimSpace(source) == "" {
+		return nil, fmt.Errorf("script node %s requires config.script_id or config.source", node.ID)
+	}
+
+	result, err := Run(ctx, name, source, inputs, map[string]interface{}{"id": node.ID, "name": node.Name}, Limits{Timeout: timeout})
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range result.Logs {
+		service.logger.InfoTag("脚本", "[%s] %s", node.ID, line)
+	}
+	return result.Outputs, nil
+}
+
+func configTimeout(value interface{}) (time.Duration, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case string:
+		if v == "" {
+			return 0, nil
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("invalid timeout %q: %v", v, err)
+		}
+		return d, nil
+	case float64:
+		return time.Duration(v * float64(time.Second)), nil
+	case int:
+		return time.Duration(v) * time.Second, nil
+	default:
+		return 0, fmt.Errorf("invalid timeout type %T", value)
+	}
+}
+
+func configInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return int(v), nil
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	default:
+		return 0, fmt.Errorf("invalid version type %T", value)
+	}
+}
@@ -0,0 +1,27 @@
+package script
+
+import "context"
+
+// Repository 脚本仓库接口
+type Repository interface {
+	// Save 新建脚本及其第一个版本
+	Save(ctx context.Context, s *Script, v *Version) error
+
+	// Update 更新脚本；v 不为 nil 时同时写入新版本
+	Update(ctx context.Context, s *Script, v *Version) error
+
+	// FindByID 根据ID查找脚本，不存在时返回 nil
+	FindByID(ctx context.Context, id string) (*Script, error)
+
+	// List 查询全部脚本
+	List(ctx context.Context) ([]*Script, error)
+
+	// FindVersion 查找脚本的指定版本，不存在时返回 nil
+	FindVersion(ctx context.Context, id string, version int) (*Version, error)
+
+	// ListVersions 查询脚本的全部版本，新版本在前
+	ListVersions(ctx context.Context, id string) ([]*Version, error)
+
+	// Delete 删除脚本及其全部版本
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,365 @@
+package script
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
+)
+
+// Limits 脚本运行限制
+type Limits struct {
+	Timeout        time.Duration // 运行时间上限，超时中止脚本
+	CallStackSize  int           // 调用栈深度上限
+	RegistrySize   int           // 值栈容量上限（槽位数）
+	MaxStringLen   int           // string.rep 等函数可生成的字符串长度上限
+	MaxOutputBytes int           // 输出编码为 JSON 后的大小上限
+	MaxLogLines    int           // 单次运行可记录的日志行数
+	MaxMemory      int64         // 脚本进程可新增的堆内存上限（字节）
+}
+
+// DefaultLimits 默认运行限制
+var DefaultLimits = Limits{
+	Timeout:        5 * time.Second,
+	CallStackSize:  200,
+	RegistrySize:   64 * 1024,
+	MaxStringLen:   1 << 20,
+	MaxOutputBytes: 1 << 20,
+	MaxLogLines:    100,
+	MaxMemory:      64 << 20,
+}
+
+// MaxTimeout 节点配置可设置的最长运行时间
+const MaxTimeout = time.Minute
+
+// maxConvertDepth Go 与 Lua 值互相转换时的嵌套深度上限，防止循环引用
+const maxConvertDepth = 32
+
+// removedBaseFuncs 沙箱中移除的基础库函数：文件和代码加载、模块系统、环境访问和调试函数
+var removedBaseFuncs = []string{
+	"dofile", "loadfile", "load", "loadstring", "require", "module",
+	"collectgarbage", "getfenv", "setfenv", "print", "_printregs", "newproxy",
+}
+
+// RunResult 脚本运行结果
+type RunResult struct {
+	Outputs map[string]interface{} `json:"outputs"`
+	Logs    []string               `json:"logs"`
+}
+
+// Compile 检查脚本语法
+func Compile(name, source string) error {
+	_, err := compile(name, source)
+	return err
+}
+
+func compile(name, source string) (*lua.FunctionProto, error) {
+	chunk, err := parse.Parse(strings.NewReader(source), name)
+	if err != nil {
+		return nil, fmt.Errorf("syntax error: %w", err)
+	}
+	proto, err := lua.Compile(chunk, name)
+	if err != nil {
+		return nil, fmt.Errorf("compile error: %w", err)
+	}
+	return proto, nil
+}
+
+// runSandbox 在当前进程的沙箱中运行脚本，只在脚本进程（ServeWorker）中调用
+// 脚本只能访问全局变量 inputs（节点输入）、node（节点信息）以及白名单 API：
+// log(...) 记录日志，json.encode / json.decode，now() 返回 Unix 时间（秒）；
+// 可用的标准库只有 base（已移除加载和环境相关函数）、string、table、math。
+// 脚本返回的 table 作为输出，返回其他值时作为 result 输出
+func runSandbox(ctx context.Context, name, source string, inputs, node map[string]interface{}, limits Limits) (*RunResult, error) {
+	proto, err := compile(name, source)
+	if err != nil {
+		return nil, err
+	}
+
+	L := lua.NewState(lua.Options{
+		SkipOpenLibs:        true,
+		CallStackSize:       limits.CallStackSize,
+		RegistrySize:        1024,
+		RegistryMaxSize:     limits.RegistrySize,
+		MinimizeStackMemory: true,
+	})
+	defer L.Close()
+
+	runCtx, cancel := context.WithTimeout(ctx, limits.Timeout)
+	defer cancel()
+	L.SetContext(runCtx)
+
+	result := &RunResult{Logs: []string{}}
+	if err := openSandbox(L, limits, result); err != nil {
+		return nil, err
+	}
+	inputsValue, err := toLua(L, inputs, 0)
+	if err != nil {
+		return nil, fmt.Errorf("inputs: %w", err)
+	}
+	nodeValue, err := toLua(L, node, 0)
+	if err != nil {
+		return nil, fmt.Errorf("node: %w", err)
+	}
+	L.SetGlobal("inputs", inputsValue)
+	L.SetGlobal("node", nodeValue)
+
+	L.Push(L.NewFunctionFromProto(proto))
+	if err := L.PCall(0, 1, nil); err != nil {
+		if runCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+			return nil, fmt.Errorf("script exceeded time limit %s", limits.Timeout)
+		}
+		return nil, fmt.Errorf("script error: %w", err)
+	}
+
+	ret := L.Get(-1)
+	L.Pop(1)
+	value, err := fromLua(ret, 0)
+	if err != nil {
+		return nil, fmt.Errorf("return value: %w", err)
+	}
+	if outputs, ok := value.(map[string]interface{}); ok {
+		result.Outputs = outputs
+	} else if value == nil {
+		result.Outputs = map[string]interface{}{}
+	} else {
+		result.Outputs = map[string]interface{}{"result": value}
+	}
+
+	data, err := json.Marshal(result.Outputs)
+	if err != nil {
+		return nil, fmt.Errorf("return value: %w", err)
+	}
+	if len(data) > limits.MaxOutputBytes {
+		return nil, fmt.Errorf("script output exceeds %d bytes", limits.MaxOutputBytes)
+	}
+	return result, nil
+}
+
+func (l Limits) withDefaults() Limits {
+	if l.Timeout <= 0 {
+		l.Timeout = DefaultLimits.Timeout
+	}
+	if l.Timeout > MaxTimeout {
+		l.Timeout = MaxTimeout
+	}
+	if l.CallStackSize <= 0 {
+		l.CallStackSize = DefaultLimits.CallStackSize
+	}
+	if l.RegistrySize <= 0 {
+		l.RegistrySize = DefaultLimits.RegistrySize
+	}
+	if l.MaxStringLen <= 0 {
+		l.MaxStringLen = DefaultLimits.MaxStringLen
+	}
+	if l.MaxOutputBytes <= 0 {
+		l.MaxOutputBytes = DefaultLimits.MaxOutputBytes
+	}
+	if l.MaxLogLines <= 0 {
+		l.MaxLogLines = DefaultLimits.MaxLogLines
+	}
+	if l.MaxMemory <= 0 {
+		l.MaxMemory = DefaultLimits.MaxMemory
+	}
+	return l
+}
+
+// openSandbox 打开白名单标准库并注册脚本 API
+func openSandbox(L *lua.LState, limits Limits, result *RunResult) error {
+	for _, lib := range []struct {
+		name string
+		open lua.LGFunction
+	}{
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.StringLibName, lua.OpenString},
+		{lua.MathLibName, lua.OpenMath},
+	} {
+		if err := L.CallByParam(lua.P{Fn: L.NewFunction(lib.open), NRet: 0, Protect: true}, lua.LString(lib.name)); err != nil {
+			return fmt.Errorf("failed to open %s library: %w", lib.name, err)
+		}
+	}
+	for _, name := range removedBaseFuncs {
+		L.SetGlobal(name, lua.LNil)
+	}
+	// math.random 依赖全局随机源，保留；string.rep 限制结果长度
+	if str, ok := L.GetGlobal(lua.StringLibName).(*lua.LTable); ok {
+		str.RawSetString("rep", L.NewFunction(func(L *lua.LState) int {
+			s := L.CheckString(1)
+			n := L.CheckInt(2)
+			if n > 0 && len(s) > 0 && n > limits.MaxStringLen/len(s) {
+				L.RaiseError("string.rep result exceeds %d bytes", limits.MaxStringLen)
+			}
+			if n <= 0 {
+				L.Push(lua.LString(""))
+				return 1
+			}
+			L.Push(lua.LString(strings.Repeat(s, n)))
+			return 1
+		}))
+	}
+
+	L.SetGlobal("log", L.NewFunction(func(L *lua.LState) int {
+		if len(result.Logs) >= limits.MaxLogLines {
+			return 0
+		}
+		parts := make([]string, 0, L.GetTop())
+		for i := 1; i <= L.GetTop(); i++ {
+			parts = append(parts, L.ToStringMeta(L.Get(i)).String())
+		}
+		result.Logs = append(result.Logs, strings.Join(parts, " "))
+		return 0
+	}))
+	L.SetGlobal("now", L.NewFunction(func(L *lua.LState) int {
+		L.Push(lua.LNumber(float64(time.Now().UnixNano()) / 1e9))
+		return 1
+	}))
+
+	jsonLib := L.NewTable()
+	jsonLib.RawSetString("encode", L.NewFunction(func(L *lua.LState) int {
+		value, err := fromLua(L.CheckAny(1), 0)
+		if err != nil {
+			L.RaiseError("json.encode: %v", err)
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			L.RaiseError("json.encode: %v", err)
+		}
+		if len(data) > limits.MaxStringLen {
+			L.RaiseError("json.encode result exceeds %d bytes", limits.MaxStringLen)
+		}
+		L.Push(lua.LString(data))
+		return 1
+	}))
+	jsonLib.RawSetString("decode", L.NewFunction(func(L *lua.LState) int {
+		var value interface{}
+		if err := json.Unmarshal([]byte(L.CheckString(1)), &value); err != nil {
+			L.RaiseError("json.decode: %v", err)
+		}
+		converted, err := toLua(L, value, 0)
+		if err != nil {
+			L.RaiseError("json.decode: %v", err)
+		}
+		L.Push(converted)
+		return 1
+	}))
+	L.SetGlobal("json", jsonLib)
+	return nil
+}
+
+// toLua 将 Go 值转换为 Lua 值，数组转换为从 1 开始的 table
+func toLua(L *lua.LState, value interface{}, depth int) (lua.LValue, error) {
+	if depth > maxConvertDepth {
+		return nil, fmt.Errorf("value nested deeper than %d levels", maxConvertDepth)
+	}
+	switch v := value.(type) {
+	case nil:
+		return lua.LNil, nil
+	case bool:
+		return lua.LBool(v), nil
+	case string:
+		return lua.LString(v), nil
+	case float64:
+		return lua.LNumber(v), nil
+	case float32:
+		return lua.LNumber(v), nil
+	case int:
+		return lua.LNumber(v), nil
+	case int32:
+		return lua.LNumber(v), nil
+	case int64:
+		return lua.LNumber(v), nil
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return lua.LNumber(f), nil
+	case []interface{}:
+		tbl := L.CreateTable(len(v), 0)
+		for _, item := range v {
+			converted, err := toLua(L, item, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			tbl.Append(converted)
+		}
+		return tbl, nil
+	case map[string]interface{}:
+		tbl := L.CreateTable(0, len(v))
+		for key, item := range v {
+			converted, err := toLua(L, item, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			tbl.RawSetString(key, converted)
+		}
+		return tbl, nil
+	}
+
+	// 其他类型按 JSON 编码结果转换
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported value %T", value)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, fmt.Errorf("unsupported value %T", value)
+	}
+	return toLua(L, decoded, depth)
+}
+
+// fromLua 将 Lua 值转换为 Go 值：连续整数键从 1 开始的 table 转换为数组，其他 table 转换为对象
+func fromLua(value lua.LValue, depth int) (interface{}, error) {
+	if depth > maxConvertDepth {
+		return nil, fmt.Errorf("value nested deeper than %d levels", maxConvertDepth)
+	}
+	switch v := value.(type) {
+	case *lua.LNilType:
+		return nil, nil
+	case lua.LBool:
+		return bool(v), nil
+	case lua.LNumber:
+		return float64(v), nil
+	case lua.LString:
+		return string(v), nil
+	case *lua.LTable:
+		n := v.Len()
+		count := 0
+		v.ForEach(func(lua.LValue, lua.LValue) { count++ })
+		if n > 0 && n == count {
+			items := make([]interface{}, 0, n)
+			for i := 1; i <= n; i++ {
+				item, err := fromLua(v.RawGetInt(i), depth+1)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+			}
+			return items, nil
+		}
+
+		result := make(map[string]interface{}, count)
+		var convErr error
+		v.ForEach(func(key, item lua.LValue) {
+			if convErr != nil {
+				return
+			}
+			converted, err := fromLua(item, depth+1)
+			if err != nil {
+				convErr = err
+				return
+			}
+			result[key.String()] = converted
+		})
+		if convErr != nil {
+			return nil, convErr
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("cannot convert %s to a value", value.Type().String())
+}
@@ -0,0 +1,124 @@
+package script
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMain 测试进程以 WorkerCommand 启动时充当脚本进程
+func TestMain(m *testing.M) {
+	if len(os.Args) > 1 && os.Args[1] == WorkerCommand {
+		if err := ServeWorker(context.Background(), os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestRunLimits(t *testing.T) {
+	limits := Limits{Timeout: 10 * time.Second, MaxMemory: 32 << 20, MaxOutputBytes: 1024}
+	tests := []struct {
+		name    string
+		source  string
+		limits  Limits
+		wantErr string
+	}{
+		{
+			name:   "within limits",
+			source: `local s = string.rep("x", 1048576) return { n = #s, first = inputs.items[1] }`,
+			limits: limits,
+		},
+		{
+			name:    "string.rep over max length",
+			source:  `return string.rep("x", 1048577)`,
+			limits:  limits,
+			wantErr: "string.rep result exceeds",
+		},
+		{
+			name:    "string.rep overflowing length",
+			source:  `return string.rep("xx", 4611686018427387904)`,
+			limits:  limits,
+			wantErr: "string.rep result exceeds",
+		},
+		{
+			name:    "concat doubling",
+			source:  `local s = string.rep("x", 1048576) for i = 1, 9 do s = s .. s end return #s`,
+			limits:  limits,
+			wantErr: "memory limit",
+		},
+		{
+			name:    "string.format",
+			source:  `local s = string.rep("x", 1048576) local parts = {} for i = 1, 200 do parts[i] = string.format("%s%s%s%s", s, s, s, s) end return #parts`,
+			limits:  limits,
+			wantErr: "memory limit",
+		},
+		{
+			name:    "table.concat",
+			source:  `local s = string.rep("x", 1048576) local t = {} for i = 1, 1024 do t[i] = s end return #table.concat(t)`,
+			limits:  limits,
+			wantErr: "memory limit",
+		},
+		{
+			name:    "table growth",
+			source:  `local t = {} for i = 1, 1e8 do t[i] = { i } end return #t`,
+			limits:  limits,
+			wantErr: "memory limit",
+		},
+		{
+			name:    "timeout",
+			source:  `while true do end`,
+			limits:  Limits{Timeout: 200 * time.Millisecond},
+			wantErr: "time limit",
+		},
+		{
+			name:    "call stack",
+			source:  `local function f() return 1 + f() end return f()`,
+			limits:  limits,
+			wantErr: "stack overflow",
+		},
+		{
+			name:    "output size",
+			source:  `return { s = string.rep("x", 2048) }`,
+			limits:  limits,
+			wantErr: "output exceeds",
+		},
+		{
+			name:    "removed function",
+			source:  `return loadstring("return 1")()`,
+			limits:  limits,
+			wantErr: "script error",
+		},
+		{
+			name:    "syntax error",
+			source:  `return (`,
+			limits:  limits,
+			wantErr: "syntax error",
+		},
+	}
+
+	inputs := map[string]interface{}{"items": []interface{}{"a", "b"}}
+	node := map[string]interface{}{"id": "n1", "name": "script"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Run(context.Background(), tt.name, tt.source, inputs, node, tt.limits)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Run() error = %v", err)
+				}
+				if result.Outputs["n"] != float64(1<<20) || result.Outputs["first"] != "a" {
+					t.Fatalf("Run() outputs = %v", result.Outputs)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Run() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package script
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+// maxSourceLen 脚本源码长度上限
+const maxSourceLen = 256 * 1024
+
+// ErrNotFound 脚本或脚本版本不存在
+var ErrNotFound = stderrors.New("script not found")
+
+// CreateRequest 创建脚本请求
+type CreateRequest struct {
+	Name        string
+	Description string
+	Source      string
+	Comment     string // 第一个版本的说明
+}
+
+// UpdateRequest 更新脚本请求，nil 字段表示不修改；修改源码时生成新版本
+type UpdateRequest struct {
+	Name        *string
+	Description *string
+	Source      *string
+	Comment     string // 新版本的说明
+}
+
+// TestRequest 试运行请求
+type TestRequest struct {
+	Version int                    // 0 表示当前版本
+	Inputs  map[string]interface{} // 作为脚本的 inputs
+	Timeout time.Duration          // 0 表示默认时间上限
+}
+
+// Service 脚本服务，管理脚本及其版本并在沙箱中运行脚本
+type Service struct {
+	repo   Repository
+	logger *logging.Logger
+	now    func() time.Time
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局脚本服务，供工作流脚本节点使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局脚本服务，未初始化时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建脚本服务
+func NewService(repo Repository, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Service{repo: repo, logger: logger, now: time.Now}
+}
+
+// Create 创建脚本，源码语法错误时返回错误
+func (s *Service) Create(ctx context.Context, req CreateRequest) (*Script, error) {
+	now := s.now()
+	sc := &Script{
+		ID:          uuid.New().String(),
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Language:    LanguageLua,
+		Version:     1,
+		Source:      req.Source,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := validateScript(sc, "script.create"); err != nil {
+		return nil, err
+	}
+	v := &Version{ScriptID: sc.ID, Version: 1, Source: sc.Source, Comment: req.Comment, CreatedAt: now}
+	if err := s.repo.Save(ctx, sc, v); err != nil {
+		return nil, err
+	}
+	s.logger.InfoTag("脚本", "已创建脚本 %s(%s)", sc.Name, sc.ID)
+	return sc, nil
+}
+
+// Get 获取脚本
+func (s *Service) Get(ctx context.Context, id string) (*Script, error) {
+	sc, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sc == nil {
+		return nil, errors.Wrap(errors.KindDomain, "script.get", "script not found", ErrNotFound)
+	}
+	return sc, nil
+}
+
+// List 获取全部脚本
+func (s *Service) List(ctx context.Context) ([]*Script, error) {
+	return s.repo.List(ctx)
+}
+
+// Update 更新脚本，源码变化时版本号加一
+func (s *Service) Update(ctx context.Context, id string, req UpdateRequest) (*Script, error) {
+	sc, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		sc.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		sc.Description = *req.Description
+	}
+	var v *Version
+	if req.Source != nil && *req.Source != sc.Source {
+		sc.Source = *req.Source
+		sc.Version++
+		v = &Version{ScriptID: sc.ID, Version: sc.Version, Source: sc.Source, Comment: req.Comment}
+	}
+	sc.UpdatedAt = s.now()
+	if v != nil {
+		v.CreatedAt = sc.UpdatedAt
+	}
+
+	if err := validateScript(sc, "script.update"); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, sc, v); err != nil {
+		return nil, err
+	}
+	if v != nil {
+		s.logger.InfoTag("脚本", "脚本 %s(%s) 已更新到版本 %d", sc.Name, sc.ID, sc.Version)
+	}
+	return sc, nil
+}
+
+// Delete 删除脚本及其全部版本
+func (s *Service) Delete(ctx context.Context, id string) error {
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// Versions 获取脚本的全部版本，新版本在前
+func (s *Service) Versions(ctx context.Context, id string) ([]*Version, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.ListVersions(ctx, id)
+}
+
+// Version 获取脚本的指定版本
+func (s *Service) Version(ctx context.Context, id string, version int) (*Version, error) {
+	v, err := s.repo.FindVersion(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, errors.Wrap(errors.KindDomain, "script.version", fmt.Sprintf("script %s has no version %d", id, version), ErrNotFound)
+	}
+	return v, nil
+}
+
+// Rollback 以指定历史版本的源码创建新版本，历史版本本身保持不变
+func (s *Service) Rollback(ctx context.Context, id string, version int) (*Script, error) {
+	v, err := s.Version(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+	return s.Update(ctx, id, UpdateRequest{
+		Source:  &v.Source,
+		Comment: fmt.Sprintf("回滚到版本 %d", version),
+	})
+}
+
+// Source 返回脚本指定版本的源码，version 为 0 时返回当前版本
+func (s *Service) Source(ctx context.Context, id string, version int) (string, int, error) {
+	if version > 0 {
+		v, err := s.Version(ctx, id, version)
+		if err != nil {
+			return "", 0, err
+		}
+		return v.Source, v.Version, nil
+	}
+	sc, err := s.Get(ctx, id)
+	if err != nil {
+		return "", 0, err
+	}
+	return sc.Source, sc.Version, nil
+}
+
+// Test 在沙箱中试运行脚本
+func (s *Service) Test(ctx context.Context, id string, req TestRequest) (*RunResult, error) {
+	source, version, err := s.Source(ctx, id, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	inputs := req.Inputs
+	if inputs == nil {
+		inputs = map[string]interface{}{}
+	}
+	node := map[string]interface{}{"id": "test", "name": "test"}
+	result, err := Run(ctx, fmt.Sprintf("%s@v%d", id, version), source, inputs, node, Limits{Timeout: req.Timeout})
+	if err != nil {
+		return nil, errors.Wrap(errors.KindDomain, "script.test", "script run failed", err)
+	}
+	return result, nil
+}
+
+// validateScript 校验脚本名称、源码长度和语法
+func validateScript(sc *Script, op string) error {
+	if sc.Name == "" {
+		return errors.New(errors.KindDomain, op, "name is required")
+	}
+	if strings.TrimSpace(sc.Source) == "" {
+		return errors.New(errors.KindDomain, op, "source is required")
+	}
+	if len(sc.Source) > maxSourceLen {
+		return errors.New(errors.KindDomain, op, fmt.Sprintf("source exceeds %d bytes", maxSourceLen))
+	}
+	if err := Compile(sc.Name, sc.Source); err != nil {
+		return errors.Wrap(errors.KindDomain, op, "invalid script", err)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package script
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"runtime/debug"
+	"runtime/metrics"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WorkerCommand 运行脚本的子命令，服务可执行文件以该参数启动脚本进程
+const WorkerCommand = "script-worker"
+
+const (
+	// workerStartGrace 脚本进程启动和退出所需的额外时间
+	workerStartGrace = 5 * time.Second
+	// memoryCheckInterval 脚本进程检查堆内存的间隔
+	memoryCheckInterval = 2 * time.Millisecond
+	// heapObjectsMetric 堆上对象占用的字节数，包括尚未回收的对象
+	heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+)
+
+// workerRequest 通过标准输入传给脚本进程的运行请求
+type workerRequest struct {
+	Name   string                 `json:"name"`
+	Source string                 `json:"source"`
+	Inputs map[string]interface{} `json:"inputs"`
+	Node   map[string]interface{} `json:"node"`
+	Limits Limits                 `json:"limits"`
+}
+
+// workerResponse 脚本进程通过标准输出返回的运行结果
+type workerResponse struct {
+	Result *RunResult `json:"result,omitempty"`
+	Error  string     `json:"error,omitempty"`
+}
+
+// Run 在独立的脚本进程中运行脚本，脚本的说明见 runSandbox。
+// gopher-lua 无法限制单条指令的内存分配（字符串拼接、string.format、table.concat 和 table 增长），
+// 因此每次运行启动一个限制了内存的子进程，超出 MaxMemory 时结束该进程而不影响服务
+func Run(ctx context.Context, name, source string, inputs, node map[string]interface{}, limits Limits) (*RunResult, error) {
+	limits = limits.withDefaults()
+	if _, err := compile(name, source); err != nil {
+		return nil, err
+	}
+	request, err := json.Marshal(workerRequest{Name: name, Source: source, Inputs: inputs, Node: node, Limits: limits})
+	if err != nil {
+		return nil, fmt.Errorf("inputs: %w", err)
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate script worker: %w", err)
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, limits.Timeout+workerStartGrace)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(runCtx, executable, WorkerCommand)
+	// 脚本单线程运行，限制线程数以免线程栈占用地址空间上限；崩溃时只保留错误信息
+	cmd.Env = append(os.Environ(), "GOMAXPROCS=2", "GOTRACEBACK=none")
+	cmd.Stdin = bytes.NewReader(request)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if runCtx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("script exceeded time limit %s", limits.Timeout)
+	}
+
+	var response workerResponse
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		// 超出地址空间上限时运行时直接退出，没有输出结果
+		if strings.Contains(stderr.String(), "out of memory") {
+			return nil, memoryError(limits)
+		}
+		if runErr == nil {
+			runErr = err
+		}
+		return nil, fmt.Errorf("script worker failed: %v: %s", runErr, lastLine(stderr.String()))
+	}
+	if response.Error != "" {
+		return nil, errors.New(response.Error)
+	}
+	if response.Result == nil {
+		return nil, fmt.Errorf("script worker returned no result")
+	}
+	return response.Result, nil
+}
+
+// ServeWorker 脚本进程入口：从标准输入读取运行请求，把结果写到标准输出。
+// 堆内存超出 MaxMemory 时返回错误并立即退出；地址空间上限兜底单次超大分配
+func ServeWorker(ctx context.Context, args []string) error {
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return err
+	}
+	var request workerRequest
+	if err := json.Unmarshal(data, &request); err != nil {
+		return fmt.Errorf("invalid script request: %w", err)
+	}
+	limits := request.Limits.withDefaults()
+
+	var once sync.Once
+	respond := func(response workerResponse) {
+		once.Do(func() {
+			_ = json.NewEncoder(os.Stdout).Encode(response)
+		})
+	}
+
+	base := heapObjects()
+	debug.SetMemoryLimit(int64(base) + limits.MaxMemory)
+	if err := limitAddressSpace(limits.MaxMemory); err != nil {
+		return fmt.Errorf("failed to limit script memory: %w", err)
+	}
+	go func() {
+		for range time.Tick(memoryCheckInterval) {
+			if int64(heapObjects()-base) <= limits.MaxMemory {
+				continue
+			}
+			// 超出时先回收垃圾，仍然超出才判定为超限
+			runtime.GC()
+			if int64(heapObjects()-base) > limits.MaxMemory {
+				respond(workerResponse{Error: memoryError(limits).Error()})
+				os.Exit(0)
+			}
+		}
+	}()
+
+	result, err := runSandbox(ctx, request.Name, request.Source, request.Inputs, request.Node, limits)
+	if err != nil {
+		respond(workerResponse{Error: err.Error()})
+	} else {
+		respond(workerResponse{Result: result})
+	}
+	return nil
+}
+
+func memoryError(limits Limits) error {
+	return fmt.Errorf("script exceeded memory limit %d bytes", limits.MaxMemory)
+}
+
+// heapObjects 返回堆上对象占用的字节数
+func heapObjects() uint64 {
+	sample := []metrics.Sample{{Name: heapObjectsMetric}}
+	metrics.Read(sample)
+	return sample[0].Value.Uint64()
+}
+
+func lastLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
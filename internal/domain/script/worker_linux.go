@@ -0,0 +1,45 @@
+package script
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// addressSpaceSlack 地址空间上限在内存预算之外预留的空间，用于运行时元数据和线程栈
+const addressSpaceSlack = 256 << 20
+
+// limitAddressSpace 以当前虚拟内存加上两倍预算设置地址空间上限。
+// 堆检查按间隔进行，无法拦住单次超大分配（如 table.concat 把同一字符串重复上千次），
+// 超出上限时分配失败，运行时以 out of memory 退出
+func limitAddressSpace(budget int64) error {
+	data, err := os.ReadFile("/proc/self/status")
+	if err != nil {
+		return err
+	}
+	var current uint64
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "VmSize:") {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(line, "VmSize:"))
+		if len(fields) > 0 {
+			kb, err := strconv.ParseUint(fields[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			current = kb << 10
+		}
+		break
+	}
+	if current == 0 {
+		return nil
+	}
+	limit := current + uint64(2*budget) + addressSpaceSlack
+	return syscall.Setrlimit(syscall.RLIMIT_AS, &syscall.Rlimit{Cur: limit, Max: limit})
+}
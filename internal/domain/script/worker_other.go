@@ -0,0 +1,8 @@
+//go:build !linux
+
+package script
+
+// limitAddressSpace 非 Linux 平台不设置地址空间上限，只依靠堆内存检查
+func limitAddressSpace(budget int64) error {
+	return nil
+}
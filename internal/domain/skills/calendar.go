@@ -0,0 +1,84 @@
+package skills
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// calendarConfig 返回设备使用的日历配置
+func (s *Service) calendarConfig(deviceID string) map[string]interface{} {
+	if cfg, ok := s.cfg.Calendar.DeviceConfigs[deviceID]; ok {
+		return cfg
+	}
+	return s.cfg.Calendar.Config
+}
+
+// Agenda 查询设备对应日历某一天的日程并返回播报文本，day 为相对今天的天数
+func (s *Service) Agenda(ctx context.Context, deviceID string, day int) (string, error) {
+	cfg := s.calendarConfig(deviceID)
+	if url, _ := cfg["url"].(string); url == "" {
+		return "", ErrNotConfigured
+	}
+
+	now := s.now()
+	start := time.Date(now.Year(), now.Month(), now.Day()+day, 0, 0, 0, 0, now.Location())
+	end := start.AddDate(0, 0, 1)
+	out, err := s.execute(ctx, s.cfg.Calendar.Capability, cfg, map[string]interface{}{
+		"start": start.Format(time.RFC3339),
+		"end":   end.Format(time.RFC3339),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	events := out.Objects("events")
+	if len(events) == 0 {
+		return dayName(day) + "没有日程安排", nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s有%d个安排：", dayName(day), len(events))
+	for i, ev := range events {
+		if i > 0 {
+			b.WriteString("；")
+		}
+		at := "全天"
+		if !ev.Bool("all_day", false) {
+			t, err := time.Parse(time.RFC3339, ev.String("start", ""))
+			if err == nil {
+				at = spokenTime(t.In(now.Location()))
+			}
+		}
+		b.WriteString(at + "，" + ev.String("summary", "未命名日程"))
+		if loc := ev.String("location", ""); loc != "" {
+			b.WriteString("，在" + loc)
+		}
+	}
+	return b.String(), nil
+}
+
+// spokenTime 把时刻转换为口语说法，如“下午2点30分”
+func spokenTime(t time.Time) string {
+	hour := t.Hour()
+	var period string
+	switch {
+	case hour < 6:
+		period = "凌晨"
+	case hour < 12:
+		period = "上午"
+	case hour < 13:
+		period = "中午"
+	case hour < 18:
+		period = "下午"
+	default:
+		period = "晚上"
+	}
+	if hour > 12 {
+		hour -= 12
+	}
+	if t.Minute() == 0 {
+		return fmt.Sprintf("%s%d点", period, hour)
+	}
+	return fmt.Sprintf("%s%d点%d分", period, hour, t.Minute())
+}
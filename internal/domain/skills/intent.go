@@ -0,0 +1,110 @@
+package skills
+
+import (
+	"context"
+	stderrors "errors"
+	"regexp"
+	"strings"
+
+	"xiaozhi-server-go/internal/domain/intent"
+)
+
+var (
+	// dayWords 相对日期说法到天数
+	dayWords = map[string]int{"今天": 0, "今日": 0, "明天": 1, "明日": 1, "后天": 2}
+
+	// fillerWords 提取地点前去掉的口头语，剩下紧挨“天气”之前的词视为地点
+	fillerWords = []string{
+		"我想知道", "想知道", "你知道", "告诉我", "帮我", "给我", "会不会", "要不要", "需不需要", "需要", "查一下", "查询", "查查", "看一下", "看看", "说说", "请问", "一下",
+		"现在", "这边", "那边", "外面", "我们这里", "我们这", "我这里", "我这", "这里", "本地", "当地",
+		"今天", "今日", "明天", "明日", "后天",
+	}
+
+	placePattern = regexp.MustCompile(`(\p{Han}{2,8}?)会?的?(天气|气温|下雨|下雪|带伞)`)
+)
+
+// IntentHandlers 返回天气、新闻和日程的确定性意图处理器
+// 技能缺少配置或无法识别用户说的地点时处理器放弃，交给LLM处理
+func (s *Service) IntentHandlers() []intent.Handler {
+	return []intent.Handler{
+		&weatherHandler{s},
+		&newsHandler{s},
+		&calendarHandler{s},
+	}
+}
+
+type weatherHandler struct{ s *Service }
+
+func (h *weatherHandler) Intent() string {
+	return intent.IntentWeather
+}
+
+func (h *weatherHandler) Handle(ctx context.Context, req *intent.Request, result *intent.Result) (*intent.Response, error) {
+	reply, err := h.s.Weather(ctx, req.DeviceID, ExtractPlace(req.Text), ParseDay(req.Text))
+	switch {
+	case stderrors.Is(err, ErrNoLocation):
+		return &intent.Response{Reply: "我还不知道你在哪个城市，可以问我“北京明天天气怎么样”", Handled: true}, nil
+	case stderrors.Is(err, ErrPlaceNotFound):
+		return &intent.Response{Handled: false}, nil
+	case err != nil:
+		return nil, err
+	}
+	return &intent.Response{Reply: reply, Handled: true}, nil
+}
+
+type newsHandler struct{ s *Service }
+
+func (h *newsHandler) Intent() string {
+	return intent.IntentNews
+}
+
+func (h *newsHandler) Handle(ctx context.Context, req *intent.Request, result *intent.Result) (*intent.Response, error) {
+	reply, err := h.s.News(ctx, h.s.NewsCategory(req.Text))
+	if stderrors.Is(err, ErrNotConfigured) {
+		return &intent.Response{Handled: false}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &intent.Response{Reply: reply, Handled: true}, nil
+}
+
+type calendarHandler struct{ s *Service }
+
+func (h *calendarHandler) Intent() string {
+	return intent.IntentCalendar
+}
+
+func (h *calendarHandler) Handle(ctx context.Context, req *intent.Request, result *intent.Result) (*intent.Response, error) {
+	reply, err := h.s.Agenda(ctx, req.DeviceID, ParseDay(req.Text))
+	if stderrors.Is(err, ErrNotConfigured) {
+		return &intent.Response{Handled: false}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &intent.Response{Reply: reply, Handled: true}, nil
+}
+
+// ParseDay 从文本中识别相对日期，没有提到时为今天
+func ParseDay(text string) int {
+	best, day := -1, 0
+	for word, d := range dayWords {
+		if idx := strings.Index(text, word); idx >= 0 && (best < 0 || idx < best) {
+			best, day = idx, d
+		}
+	}
+	return day
+}
+
+// ExtractPlace 提取“北京明天天气怎么样”中的地点，没有说地点时返回空
+func ExtractPlace(text string) string {
+	for _, w := range fillerWords {
+		text = strings.ReplaceAll(text, w, "")
+	}
+	match := placePattern.FindStringSubmatch(text)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
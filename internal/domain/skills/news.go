@@ -0,0 +1,62 @@
+package skills
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// defaultFeed 未指定分类时使用的新闻源
+const defaultFeed = "default"
+
+// NewsCategory 返回文本中提到的新闻分类，没有提到时返回空
+func (s *Service) NewsCategory(text string) string {
+	for category := range s.cfg.News.Feeds {
+		if category != defaultFeed && strings.Contains(text, category) {
+			return category
+		}
+	}
+	return ""
+}
+
+// News 读取新闻源并返回播报文本；category 为空或未配置时使用 default 新闻源
+func (s *Service) News(ctx context.Context, category string) (string, error) {
+	feedURL, ok := s.cfg.News.Feeds[category]
+	if !ok {
+		feedURL, ok = s.cfg.News.Feeds[defaultFeed]
+	}
+	if !ok && len(s.cfg.News.Feeds) == 1 {
+		for _, u := range s.cfg.News.Feeds {
+			feedURL, ok = u, true
+		}
+	}
+	if !ok {
+		return "", ErrNotConfigured
+	}
+
+	out, err := s.execute(ctx, s.cfg.News.Capability, nil, map[string]interface{}{
+		"url":   feedURL,
+		"count": s.cfg.News.MaxItems,
+	})
+	if err != nil {
+		return "", err
+	}
+	items := out.Objects("items")
+	if len(items) == 0 {
+		return "暂时没有获取到新闻", nil
+	}
+
+	var b strings.Builder
+	if source := out.String("source", ""); source != "" {
+		fmt.Fprintf(&b, "以下是%s的最新新闻：", source)
+	} else {
+		b.WriteString("以下是最新新闻：")
+	}
+	for i, item := range items {
+		if i > 0 {
+			b.WriteString("；")
+		}
+		fmt.Fprintf(&b, "%d、%s", i+1, item.String("title", ""))
+	}
+	return b.String(), nil
+}
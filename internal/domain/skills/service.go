@@ -0,0 +1,114 @@
+package skills
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/plugin/sdk"
+)
+
+var (
+	// ErrNoLocation 用户没有说地点，设备和全局也都没有配置位置
+	ErrNoLocation = stderrors.New("location not configured")
+	// ErrPlaceNotFound 用户说的地点无法识别
+	ErrPlaceNotFound = stderrors.New("place not found")
+	// ErrNotConfigured 技能缺少必要配置（新闻源、日历地址）
+	ErrNotConfigured = stderrors.New("skill not configured")
+)
+
+// Service 内置技能服务，通过能力注册表查询天气、新闻和日程并整理为可直接播报的回复
+type Service struct {
+	registry *capability.Registry
+	cfg      config.SkillsConfig
+	logger   *logging.Logger
+	now      func() time.Time
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局技能服务，供意图路由和 LLM 工具调用使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局技能服务，未启用时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建技能服务，未配置的能力ID使用内置实现
+func NewService(cfg config.SkillsConfig, registry *capability.Registry, logger *logging.Logger) *Service {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 8 * time.Second
+	}
+	if cfg.Weather.Capability == "" {
+		cfg.Weather.Capability = "weather_openmeteo"
+	}
+	if cfg.News.Capability == "" {
+		cfg.News.Capability = "news_rss"
+	}
+	if cfg.News.MaxItems <= 0 {
+		cfg.News.MaxItems = 5
+	}
+	if cfg.Calendar.Capability == "" {
+		cfg.Calendar.Capability = "calendar_caldav"
+	}
+	return &Service{registry: registry, cfg: cfg, logger: logger, now: time.Now}
+}
+
+// Capabilities 返回技能使用的能力ID
+func (s *Service) Capabilities() []string {
+	return []string{s.cfg.Weather.Capability, s.cfg.News.Capability, s.cfg.Calendar.Capability}
+}
+
+// HasNews 是否配置了新闻源
+func (s *Service) HasNews() bool {
+	return len(s.cfg.News.Feeds) > 0
+}
+
+// HasCalendar 是否配置了日历
+func (s *Service) HasCalendar() bool {
+	return len(s.cfg.Calendar.Config) > 0 || len(s.cfg.Calendar.DeviceConfigs) > 0
+}
+
+// execute 在超时内调用能力
+func (s *Service) execute(ctx context.Context, id string, cfg map[string]interface{}, inputs map[string]interface{}) (sdk.Args, error) {
+	exec, err := s.registry.GetExecutor(id)
+	if err != nil {
+		return nil, fmt.Errorf("获取能力 %s 失败: %w", id, err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+	outputs, err := exec.Execute(ctx, cfg, inputs)
+	if err != nil {
+		return nil, fmt.Errorf("能力 %s 执行失败: %w", id, err)
+	}
+	return sdk.Args(outputs), nil
+}
+
+// dayName 相对今天的日期说法
+func dayName(day int) string {
+	switch day {
+	case 0:
+		return "今天"
+	case 1:
+		return "明天"
+	case 2:
+		return "后天"
+	default:
+		return fmt.Sprintf("%d天后", day)
+	}
+}
@@ -0,0 +1,113 @@
+package skills
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/plugin/sdk"
+)
+
+// maxWeatherDay 最远可查询的天数（0 为今天）
+const maxWeatherDay = 6
+
+// weatherCodes WMO 天气代码的中文描述
+var weatherCodes = map[int]string{
+	0: "晴", 1: "晴间多云", 2: "多云", 3: "阴",
+	45: "有雾", 48: "有雾凇",
+	51: "有毛毛雨", 53: "有毛毛雨", 55: "有较强的毛毛雨", 56: "有冻毛毛雨", 57: "有冻毛毛雨",
+	61: "有小雨", 63: "有中雨", 65: "有大雨", 66: "有冻雨", 67: "有冻雨",
+	71: "有小雪", 73: "有中雪", 75: "有大雪", 77: "有米雪",
+	80: "有阵雨", 81: "有阵雨", 82: "有强阵雨", 85: "有阵雪", 86: "有强阵雪",
+	95: "有雷阵雨", 96: "有雷阵雨伴有冰雹", 99: "有强雷阵雨伴有冰雹",
+}
+
+// LocationFor 返回设备所在位置，设备未配置时使用默认位置
+func (s *Service) LocationFor(deviceID string) config.SkillLocation {
+	if loc, ok := s.cfg.Weather.DeviceLocations[deviceID]; ok {
+		return loc
+	}
+	return s.cfg.Weather.DefaultLocation
+}
+
+// Weather 查询天气并返回播报文本；place 为空时使用设备所在位置，day 为相对今天的天数
+func (s *Service) Weather(ctx context.Context, deviceID, place string, day int) (string, error) {
+	if day < 0 || day > maxWeatherDay {
+		return "", fmt.Errorf("只能查询%d天内的天气", maxWeatherDay+1)
+	}
+	inputs := map[string]interface{}{"days": day + 1}
+	name := place
+	if place != "" {
+		inputs["location"] = place
+	} else {
+		loc := s.LocationFor(deviceID)
+		switch {
+		case loc.Latitude != 0 || loc.Longitude != 0:
+			inputs["latitude"] = loc.Latitude
+			inputs["longitude"] = loc.Longitude
+		case loc.Name != "":
+			inputs["location"] = loc.Name
+		default:
+			return "", ErrNoLocation
+		}
+		name = loc.Name
+	}
+
+	out, err := s.execute(ctx, s.cfg.Weather.Capability, s.cfg.Weather.Config, inputs)
+	if err != nil {
+		var pluginErr *sdk.Error
+		if place != "" && stderrors.As(err, &pluginErr) && pluginErr.Field == "location" {
+			return "", ErrPlaceNotFound
+		}
+		return "", err
+	}
+	if name == "" {
+		name = out.String("location", "当地")
+	}
+
+	daily := out.Objects("daily")
+	if day >= len(daily) {
+		return "", fmt.Errorf("天气能力没有返回%s的预报", dayName(day))
+	}
+	return formatWeather(name, day, out.Object("current"), daily[day]), nil
+}
+
+// formatWeather 生成天气播报，今天附带实时温度，降水概率较高时提醒带伞
+func formatWeather(name string, day int, current, forecast sdk.Args) string {
+	var b strings.Builder
+	b.WriteString(name + dayName(day))
+	if day == 0 && current != nil {
+		fmt.Fprintf(&b, "%s，现在%d度，体感%d度，全天%d到%d度",
+			describeWeather(current.Int("weather_code", -1)),
+			degrees(current.Float("temperature", 0)),
+			degrees(current.Float("apparent_temperature", 0)),
+			degrees(forecast.Float("temperature_min", 0)),
+			degrees(forecast.Float("temperature_max", 0)))
+	} else {
+		fmt.Fprintf(&b, "%s，%d到%d度",
+			describeWeather(forecast.Int("weather_code", -1)),
+			degrees(forecast.Float("temperature_min", 0)),
+			degrees(forecast.Float("temperature_max", 0)))
+	}
+	if p, ok, _ := forecast.LookupFloat("precipitation_probability"); ok {
+		fmt.Fprintf(&b, "，降水概率百分之%d", int(math.Round(p)))
+		if p >= 50 {
+			b.WriteString("，出门记得带伞")
+		}
+	}
+	return b.String()
+}
+
+func describeWeather(code int) string {
+	if desc, ok := weatherCodes[code]; ok {
+		return desc
+	}
+	return "天气未知"
+}
+
+func degrees(v float64) int {
+	return int(math.Round(v))
+}
@@ -0,0 +1,165 @@
+package smarthome
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+	"time"
+
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// 别名来源
+const (
+	AliasManual  = "manual"  // 通过接口或 LLM 工具设置
+	AliasLearned = "learned" // 从用户的纠正中学到
+)
+
+// maxAliasLength 别名的最大字数
+const maxAliasLength = 32
+
+// ErrAliasNotFound 别名不存在
+var ErrAliasNotFound = stderrors.New("alias not found")
+
+// Alias 租户的设备别名，把用户对设备的叫法映射到实体
+// 同一租户内一个别名只对应一个实体，一个实体可以有多个别名
+type Alias struct {
+	Key       string    `json:"-"` // 规范化后的别名，忽略空白、标点和“的”
+	Alias     string    `json:"alias"`
+	EntityID  string    `json:"entity_id"`
+	Source    string    `json:"source"` // manual/learned
+	Hits      int       `json:"hits"`   // 语音指令通过该别名匹配到实体的次数
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AliasRepository 设备别名仓库，按上下文中的租户隔离
+type AliasRepository interface {
+	// List 列出租户的全部别名
+	List(ctx context.Context) ([]*Alias, error)
+	// Find 按规范化后的别名查找，不存在时返回 nil
+	Find(ctx context.Context, key string) (*Alias, error)
+	// Save 创建或覆盖别名
+	Save(ctx context.Context, alias *Alias) error
+	// Delete 删除别名
+	Delete(ctx context.Context, key string) error
+	// Hit 累加别名的匹配次数
+	Hit(ctx context.Context, key string) error
+}
+
+// UseAliases 启用租户设备别名，未调用时只使用配置中的别名
+func (s *Service) UseAliases(repo AliasRepository) {
+	s.aliases = repo
+}
+
+// Aliases 列出租户的设备别名，未启用别名时返回空列表
+func (s *Service) Aliases(ctx context.Context) ([]*Alias, error) {
+	if s.aliases == nil {
+		return []*Alias{}, nil
+	}
+	return s.aliases.List(ctx)
+}
+
+// SetAlias 把别名指向实体，别名已存在时改为指向新实体
+func (s *Service) SetAlias(ctx context.Context, alias, entityID, source string) (*Alias, error) {
+	if s.aliases == nil {
+		return nil, errors.New(errors.KindDomain, "smarthome.set_alias", "device aliases are not enabled")
+	}
+	alias = strings.TrimSpace(alias)
+	key := normalizeName(alias)
+	if key == "" || len([]rune(alias)) > maxAliasLength {
+		return nil, errors.New(errors.KindDomain, "smarthome.set_alias", "alias must be 1-32 characters")
+	}
+	if _, all := splitAll(key); all {
+		return nil, errors.New(errors.KindDomain, "smarthome.set_alias", "alias must not start with 所有 or 全部")
+	}
+	s.mu.RLock()
+	_, ok := s.entities[entityID]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, errors.Wrap(errors.KindDomain, "smarthome.set_alias", "unknown entity "+entityID, ErrNotFound)
+	}
+	if source != AliasLearned {
+		source = AliasManual
+	}
+
+	a := &Alias{Key: key, Alias: alias, EntityID: entityID, Source: source, UpdatedAt: s.now()}
+	if existing, err := s.aliases.Find(ctx, key); err != nil {
+		return nil, err
+	} else if existing != nil && existing.EntityID == entityID {
+		a.Hits = existing.Hits
+	}
+	if err := s.aliases.Save(ctx, a); err != nil {
+		return nil, err
+	}
+	s.logger.InfoTag("智能家居", "别名“%s”已指向实体 %s（%s）", alias, entityID, source)
+	return a, nil
+}
+
+// DeleteAlias 删除别名
+func (s *Service) DeleteAlias(ctx context.Context, alias string) error {
+	if s.aliases == nil {
+		return errors.Wrap(errors.KindDomain, "smarthome.delete_alias", "alias not found", ErrAliasNotFound)
+	}
+	key := normalizeName(alias)
+	existing, err := s.aliases.Find(ctx, key)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.Wrap(errors.KindDomain, "smarthome.delete_alias", "alias not found", ErrAliasNotFound)
+	}
+	return s.aliases.Delete(ctx, key)
+}
+
+// ResolveTarget 按租户别名和实体名称匹配说法
+// 租户别名完全匹配时直接返回对应实体，否则把租户别名并入实体别名后按 Resolve 匹配
+func (s *Service) ResolveTarget(ctx context.Context, target string) ([]Entity, bool) {
+	return s.resolve(ctx, target, true)
+}
+
+// resolve 匹配说法，hit 为 true 时累加命中别名的匹配次数
+func (s *Service) resolve(ctx context.Context, target string, hit bool) ([]Entity, bool) {
+	entities := s.Entities()
+	if s.aliases == nil {
+		return Resolve(entities, target)
+	}
+	aliases, err := s.aliases.List(ctx)
+	if err != nil {
+		s.logger.WarnTag("智能家居", "加载设备别名失败，只使用配置中的别名: %v", err)
+		return Resolve(entities, target)
+	}
+
+	key, all := splitAll(normalizeName(target))
+	byEntity := make(map[string][]string, len(aliases))
+	for _, a := range aliases {
+		if a.Key == key && !all {
+			for _, entity := range entities {
+				if entity.ID == a.EntityID {
+					if hit {
+						if err := s.aliases.Hit(ctx, a.Key); err != nil {
+							s.logger.WarnTag("智能家居", "更新别名“%s”的匹配次数失败: %v", a.Alias, err)
+						}
+					}
+					return []Entity{entity}, false
+				}
+			}
+		}
+		byEntity[a.EntityID] = append(byEntity[a.EntityID], a.Alias)
+	}
+	for i := range entities {
+		if extra := byEntity[entities[i].ID]; len(extra) > 0 {
+			entities[i].Aliases = append(append([]string{}, entities[i].Aliases...), extra...)
+		}
+	}
+	return Resolve(entities, target)
+}
+
+// splitAll 去掉规范化说法开头的“所有”“全部”
+func splitAll(target string) (string, bool) {
+	for _, word := range allWords {
+		if rest, ok := strings.CutPrefix(target, word); ok {
+			return rest, true
+		}
+	}
+	return target, false
+}
@@ -0,0 +1,280 @@
+package smarthome
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Command 解析出的智能家居语音指令
+type Command struct {
+	Action    Action // 设定数值时为空，按实体类型确定为亮度、开合度或温度
+	Attribute string // 设定数值时指明的属性：brightness/position/temperature，未指明时为空
+	Target    string // 指令中的设备说法，如“客厅灯”
+	Value     float64
+}
+
+var (
+	commandPrefixes = []string{"请", "帮我", "麻烦", "给我"}
+	commandSuffixes = []string{"好吗", "吧", "啊", "呀", "了", "一下"}
+
+	// 动词按长度排列，较长的说法优先匹配
+	commandVerbs = []struct {
+		word   string
+		action Action
+	}{
+		{"开一下", ActionTurnOn}, {"打开", ActionTurnOn}, {"开启", ActionTurnOn},
+		{"关一下", ActionTurnOff}, {"关闭", ActionTurnOff}, {"关掉", ActionTurnOff}, {"关上", ActionTurnOff},
+		{"拉开", ActionOpen}, {"合上", ActionClose}, {"拉上", ActionClose},
+		{"锁上", ActionLock}, {"锁好", ActionLock}, {"解锁", ActionUnlock},
+		{"开", ActionTurnOn}, {"关", ActionTurnOff},
+	}
+
+	setPattern = regexp.MustCompile(`^把?(.+?)的?(亮度|温度|开合度|位置)?(?:调到|调成|调至|设为|设置为|设置成)([0-9]+(?:\.[0-9]+)?)(?:度|%|％|摄氏度)?$`)
+
+	setAttributes = map[string]string{
+		"亮度":  "brightness",
+		"温度":  "temperature",
+		"开合度": "position",
+		"位置":  "position",
+	}
+
+	// typeWords 设备类型的通称，“关灯”“打开空调”按类型匹配实体
+	typeWords = map[string]string{
+		"灯":   TypeLight,
+		"灯光":  TypeLight,
+		"电灯":  TypeLight,
+		"开关":  TypeSwitch,
+		"插座":  TypeSwitch,
+		"窗帘":  TypeCover,
+		"空调":  TypeClimate,
+		"暖气":  TypeClimate,
+		"地暖":  TypeClimate,
+		"温控器": TypeClimate,
+		"风扇":  TypeFan,
+		"电扇":  TypeFan,
+		"门锁":  TypeLock,
+		"锁":   TypeLock,
+		"门":   TypeLock,
+	}
+
+	allWords = []string{"所有的", "所有", "全部的", "全部"}
+)
+
+// ParseCommand 解析开关、开合、锁和设定数值的语音指令，不是指令时 ok 为 false
+func ParseCommand(text string) (Command, bool) {
+	text = normalizeText(text)
+	for _, prefix := range commandPrefixes {
+		text = strings.TrimPrefix(text, prefix)
+	}
+	for _, suffix := range commandSuffixes {
+		text = strings.TrimSuffix(text, suffix)
+	}
+	if text == "" {
+		return Command{}, false
+	}
+
+	if m := setPattern.FindStringSubmatch(text); m != nil {
+		value, err := strconv.ParseFloat(m[3], 64)
+		if err != nil {
+			return Command{}, false
+		}
+		return Command{Attribute: setAttributes[m[2]], Target: m[1], Value: value}, true
+	}
+
+	// 动词在前：“打开客厅灯”
+	for _, verb := range commandVerbs {
+		if target, ok := strings.CutPrefix(text, verb.word); ok {
+			target = strings.TrimPrefix(target, "一下")
+			if target != "" {
+				return Command{Action: verb.action, Target: target}, true
+			}
+		}
+	}
+	// 动词在后：“把客厅灯打开”
+	for _, verb := range commandVerbs {
+		if target, ok := strings.CutSuffix(text, verb.word); ok {
+			target = strings.TrimPrefix(target, "把")
+			if target != "" {
+				return Command{Action: verb.action, Target: target}, true
+			}
+		}
+	}
+	return Command{}, false
+}
+
+// ActionFor 确定指令对实体执行的动作，设定数值时按指明的属性或实体类型选择
+func (c Command) ActionFor(entity Entity) Action {
+	if c.Action != "" {
+		return c.Action
+	}
+	switch c.Attribute {
+	case "brightness":
+		return ActionSetBrightness
+	case "temperature":
+		return ActionSetTemperature
+	case "position":
+		return ActionSetPosition
+	}
+	switch entity.Type {
+	case TypeCover:
+		return ActionSetPosition
+	case TypeClimate:
+		return ActionSetTemperature
+	default:
+		return ActionSetBrightness
+	}
+}
+
+// Resolve 按实体ID或说法匹配实体，all 表示说法中包含“所有”“全部”
+// 依次尝试名称或别名完全匹配、房间加名称或类型匹配、名称包含说法，最后容忍语音识别的错字模糊匹配
+func Resolve(entities []Entity, target string) (matches []Entity, all bool) {
+	for _, entity := range entities {
+		if entity.ID == strings.TrimSpace(target) {
+			return []Entity{entity}, false
+		}
+	}
+	target, all = splitAll(normalizeName(target))
+	if target == "" {
+		return nil, all
+	}
+
+	for _, entity := range entities {
+		for _, name := range entityNames(entity) {
+			if name == target {
+				matches = append(matches, entity)
+				break
+			}
+		}
+	}
+	if len(matches) > 0 {
+		return matches, all
+	}
+
+	for _, entity := range entities {
+		rest := target
+		if room := normalizeName(entity.Room); room != "" {
+			rest = strings.TrimPrefix(target, room)
+		}
+		if rest == "" {
+			continue
+		}
+		// “卧室灯”只匹配卧室的灯，“灯”匹配所有房间的灯
+		if t, ok := typeWords[rest]; ok && t == entity.Type {
+			matches = append(matches, entity)
+			continue
+		}
+		if rest != target {
+			for _, name := range entityNames(entity) {
+				if name == rest {
+					matches = append(matches, entity)
+					break
+				}
+			}
+		}
+	}
+	if len(matches) > 0 {
+		return matches, all
+	}
+
+	if len([]rune(target)) >= 2 {
+		for _, entity := range entities {
+			if strings.Contains(normalizeName(entity.DisplayName()), target) {
+				matches = append(matches, entity)
+			}
+		}
+	}
+	if len(matches) > 0 {
+		return matches, all
+	}
+	return fuzzyMatch(entities, target), all
+}
+
+// fuzzyMatch 返回名称或别名与说法编辑距离最小的实体
+// 3 到 5 个字容忍 1 个错字，更长的说法容忍 2 个，如“客厅等”匹配“客厅灯”
+func fuzzyMatch(entities []Entity, target string) []Entity {
+	runes := []rune(target)
+	limit := 0
+	switch {
+	case len(runes) >= 6:
+		limit = 2
+	case len(runes) >= 3:
+		limit = 1
+	}
+	if limit == 0 {
+		return nil
+	}
+	best := limit + 1
+	var matches []Entity
+	for _, entity := range entities {
+		distance := limit + 1
+		for _, name := range entityNames(entity) {
+			if d := editDistance(runes, []rune(name)); d < distance {
+				distance = d
+			}
+		}
+		switch {
+		case distance < best:
+			best, matches = distance, []Entity{entity}
+		case distance == best && best <= limit:
+			matches = append(matches, entity)
+		}
+	}
+	return matches
+}
+
+// editDistance 按字计算的编辑距离
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func entityNames(entity Entity) []string {
+	names := []string{normalizeName(entity.Name), normalizeName(entity.DisplayName())}
+	room := normalizeName(entity.Room)
+	for _, alias := range entity.Aliases {
+		alias = normalizeName(alias)
+		names = append(names, alias)
+		if room != "" {
+			names = append(names, room+alias)
+		}
+	}
+	return names
+}
+
+// normalizeText 去除空白和标点并转为小写
+func normalizeText(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsSpace(r) || (unicode.IsPunct(r) && r != '.' && r != '%') {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// normalizeName 名称比较时忽略空白、标点和“的”
+func normalizeName(name string) string {
+	return strings.ReplaceAll(normalizeText(name), "的", "")
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
@@ -0,0 +1,254 @@
+package smarthome
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/intent"
+	"xiaozhi-server-go/internal/domain/toolpolicy"
+)
+
+// correctionWindow 控制之后多长时间内的否定视为对设备的纠正
+const correctionWindow = 2 * time.Minute
+
+// slotCorrection 纠正分类结果中纠正后的说法，说“另一个”时为空
+const slotCorrection = "correction"
+
+var (
+	// 否定说法按长度排列，较长的说法优先匹配
+	correctionPrefixes = []string{
+		"不是这个", "不是这盏", "不是这台", "不是那个", "搞错了", "不对", "错了", "不是",
+		"notthatone", "notthisone", "wrongone", "nope", "no",
+	}
+	correctionLeads = []string{"我说的是", "我要的是", "我是说", "应该是", "是", "要", "imeant", "imean", "the"}
+	otherWords      = []string{"另外一个", "另外那个", "另一个", "另一盏", "另一台", "other"}
+)
+
+// recentControl 会话中最近一次控制，用于理解“不是这个，是另一个灯”
+type recentControl struct {
+	target     string   // 原说法
+	candidates []string // 说法匹配到的实体
+	controlled []string // 实际控制的实体
+	action     Action
+	value      float64
+	at         time.Time
+}
+
+// RecordControl 记录会话中按说法控制了哪个实体，之后的纠正据此改为控制另一个实体并学习别名
+// 同时控制多个实体（“所有灯”）时不记录
+func (s *Service) RecordControl(sessionID, target string, candidates []Entity, controlled Entity, action Action, value float64) {
+	if sessionID == "" {
+		return
+	}
+	rc := &recentControl{
+		target:     strings.TrimSpace(target),
+		candidates: make([]string, 0, len(candidates)),
+		controlled: []string{controlled.ID},
+		action:     action,
+		value:      value,
+		at:         s.now(),
+	}
+	for _, entity := range candidates {
+		rc.candidates = append(rc.candidates, entity.ID)
+	}
+	s.recentMu.Lock()
+	defer s.recentMu.Unlock()
+	for id, old := range s.recent {
+		if rc.at.Sub(old.at) > correctionWindow {
+			delete(s.recent, id)
+		}
+	}
+	s.recent[sessionID] = rc
+}
+
+func (s *Service) lastControl(sessionID string) *recentControl {
+	s.recentMu.Lock()
+	defer s.recentMu.Unlock()
+	rc, ok := s.recent[sessionID]
+	if !ok || s.now().Sub(rc.at) > correctionWindow {
+		return nil
+	}
+	return rc
+}
+
+// ParseCorrection 解析“不是这个，是另一个灯”“no, the other lamp”这类纠正，
+// target 为纠正后的说法，说“另一个”或只有否定时为空
+func ParseCorrection(text string) (target string, ok bool) {
+	text = normalizeText(text)
+	for _, prefix := range correctionPrefixes {
+		if rest, found := strings.CutPrefix(text, prefix); found {
+			text, ok = rest, true
+			break
+		}
+	}
+	if !ok {
+		return "", false
+	}
+	for _, suffix := range commandSuffixes {
+		text = strings.TrimSuffix(text, suffix)
+	}
+	for _, lead := range correctionLeads {
+		text = strings.TrimPrefix(text, lead)
+	}
+	for _, word := range otherWords {
+		if strings.Contains(text, word) {
+			return "", true
+		}
+	}
+	return text, true
+}
+
+// alternatives 纠正后应控制的实体
+// 说“另一个”时依次取上次说法匹配到的其他实体、与上次控制的实体同类的其他实体
+func (s *Service) alternatives(ctx context.Context, rc *recentControl, target string) []Entity {
+	controlled := make(map[string]bool, len(rc.controlled))
+	for _, id := range rc.controlled {
+		controlled[id] = true
+	}
+	exclude := func(entities []Entity) []Entity {
+		var out []Entity
+		for _, entity := range entities {
+			if !controlled[entity.ID] {
+				out = append(out, entity)
+			}
+		}
+		return out
+	}
+
+	if target != "" {
+		matches, all := s.resolve(ctx, target, false)
+		if all {
+			return nil
+		}
+		return exclude(matches)
+	}
+
+	entities := s.Entities()
+	candidates := make(map[string]bool, len(rc.candidates))
+	for _, id := range rc.candidates {
+		candidates[id] = true
+	}
+	var others []Entity
+	types := make(map[string]bool)
+	for _, entity := range entities {
+		if candidates[entity.ID] {
+			others = append(others, entity)
+		}
+		if controlled[entity.ID] {
+			types[entity.Type] = true
+		}
+	}
+	if others = exclude(others); len(others) > 0 {
+		return others
+	}
+	for _, entity := range exclude(entities) {
+		if types[entity.Type] {
+			others = append(others, entity)
+		}
+	}
+	return others
+}
+
+// CorrectionClassifier 返回识别设备纠正的分类器，需插在规则分类器之前，
+// 避免“不对”被当作对回复的反馈；只有会话最近控制过设备且能找到要改控的实体时才命中
+func (s *Service) CorrectionClassifier(sessionID string) intent.Classifier {
+	return &correctionClassifier{s: s, sessionID: sessionID}
+}
+
+type correctionClassifier struct {
+	s         *Service
+	sessionID string
+}
+
+func (c *correctionClassifier) Name() string {
+	return "smart_home_correction"
+}
+
+func (c *correctionClassifier) Classify(ctx context.Context, text string) (*intent.Result, error) {
+	rc := c.s.lastControl(c.sessionID)
+	if rc == nil {
+		return nil, nil
+	}
+	target, ok := ParseCorrection(text)
+	if !ok || len(c.s.alternatives(ctx, rc, target)) == 0 {
+		return nil, nil
+	}
+	return &intent.Result{
+		Intent:     intent.IntentSmartHome,
+		Confidence: 1,
+		Slots:      map[string]string{slotCorrection: target},
+		Classifier: c.Name(),
+	}, nil
+}
+
+// handleCorrection 撤销上次的开关类控制，对纠正后的实体执行同样的动作，并把上次的说法学习为该实体的别名
+func (h *smartHomeHandler) handleCorrection(ctx context.Context, req *intent.Request, target string) (*intent.Response, error) {
+	rc := h.s.lastControl(req.SessionID)
+	if rc == nil {
+		return &intent.Response{Handled: false}, nil
+	}
+	matches := h.s.alternatives(ctx, rc, target)
+	if len(matches) == 0 {
+		return &intent.Response{Handled: false}, nil
+	}
+	if len(matches) > 1 {
+		return &intent.Response{Reply: AmbiguousReply(matches), Handled: true}, nil
+	}
+	entity := matches[0]
+
+	action := resolveAction(entity, rc.action)
+	args := map[string]interface{}{"entity_id": entity.ID, "action": string(action)}
+	if action.NeedsValue() {
+		args["value"] = rc.value
+	}
+	if err := toolpolicy.Authorize(ctx, toolpolicy.KindTool, ToolName, args); err != nil {
+		return &intent.Response{Reply: fmt.Sprintf("抱歉，没有权限控制%s", entity.DisplayName()), Handled: true}, nil
+	}
+	controlled, err := h.s.Control(ctx, entity.ID, action, rc.value)
+	if err != nil {
+		return &intent.Response{Reply: ErrorReply(entity, err), Handled: true}, nil
+	}
+	for _, id := range rc.controlled {
+		h.s.undo(ctx, id, rc.action)
+	}
+	h.s.RecordControl(req.SessionID, rc.target, nil, controlled, rc.action, rc.value)
+
+	reply := ControlReply([]Entity{controlled}, action, rc.value)
+	if learnable(rc.target) {
+		if _, err := h.s.SetAlias(ctx, rc.target, entity.ID, AliasLearned); err != nil {
+			h.s.logger.WarnTag("智能家居", "学习别名“%s”失败: %v", rc.target, err)
+		} else {
+			reply += fmt.Sprintf("，以后说“%s”就是%s", rc.target, controlled.DisplayName())
+		}
+	}
+	return &intent.Response{Reply: reply, Handled: true}, nil
+}
+
+// undo 撤销误控制的开关和开合，设定数值和门锁不撤销
+func (s *Service) undo(ctx context.Context, id string, action Action) {
+	inverse := map[Action]Action{
+		ActionTurnOn:  ActionTurnOff,
+		ActionTurnOff: ActionTurnOn,
+		ActionToggle:  ActionToggle,
+		ActionOpen:    ActionClose,
+		ActionClose:   ActionOpen,
+	}[action]
+	if inverse == "" {
+		return
+	}
+	if _, err := s.Control(ctx, id, inverse, 0); err != nil {
+		s.logger.WarnTag("智能家居", "撤销对实体 %s 的控制失败: %v", id, err)
+	}
+}
+
+// learnable 只有具体的叫法才学习为别名，“灯”“所有灯”这类通称不学习
+func learnable(target string) bool {
+	key, all := splitAll(normalizeName(target))
+	if all || key == "" {
+		return false
+	}
+	_, generic := typeWords[key]
+	return !generic
+}
@@ -0,0 +1,205 @@
+package smarthome
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"xiaozhi-server-go/internal/domain/intent"
+	"xiaozhi-server-go/internal/domain/toolpolicy"
+)
+
+// ToolName 控制智能家居的本地工具名，意图处理器按同名工具检查工具调用权限
+const ToolName = "smart_home_control"
+
+// IntentHandler 返回智能家居控制的确定性意图处理器
+// 没有匹配到实体时处理器放弃并交给LLM处理；匹配到多个实体且没有说“所有”时询问要控制哪一个
+func (s *Service) IntentHandler() intent.Handler {
+	return &smartHomeHandler{s}
+}
+
+type smartHomeHandler struct{ s *Service }
+
+func (h *smartHomeHandler) Intent() string {
+	return intent.IntentSmartHome
+}
+
+func (h *smartHomeHandler) Handle(ctx context.Context, req *intent.Request, result *intent.Result) (*intent.Response, error) {
+	if target, ok := result.Slots[slotCorrection]; ok {
+		return h.handleCorrection(ctx, req, target)
+	}
+	cmd, ok := ParseCommand(req.Text)
+	if !ok {
+		return &intent.Response{Handled: false}, nil
+	}
+	matches, all := h.s.ResolveTarget(ctx, cmd.Target)
+	if len(matches) == 0 {
+		return &intent.Response{Handled: false}, nil
+	}
+	// 同时匹配到多个实体时只保留支持该指令的实体，如“打开客厅”不包含客厅的传感器
+	if supported := supporting(matches, cmd); len(supported) > 0 {
+		matches = supported
+	}
+	if len(matches) > 1 && !all {
+		return &intent.Response{Reply: AmbiguousReply(matches), Handled: true}, nil
+	}
+
+	var done, failed []Entity
+	var action Action
+	for _, entity := range matches {
+		action = resolveAction(entity, cmd.ActionFor(entity))
+		args := map[string]interface{}{"entity_id": entity.ID, "action": string(action)}
+		if action.NeedsValue() {
+			args["value"] = cmd.Value
+		}
+		if err := toolpolicy.Authorize(ctx, toolpolicy.KindTool, ToolName, args); err != nil {
+			return &intent.Response{Reply: fmt.Sprintf("抱歉，没有权限控制%s", entity.DisplayName()), Handled: true}, nil
+		}
+		controlled, err := h.s.Control(ctx, entity.ID, action, cmd.Value)
+		if err != nil {
+			if len(matches) == 1 {
+				return &intent.Response{Reply: ErrorReply(entity, err), Handled: true}, nil
+			}
+			failed = append(failed, entity)
+			continue
+		}
+		done = append(done, controlled)
+	}
+	if len(matches) == 1 && len(done) == 1 {
+		h.s.RecordControl(req.SessionID, cmd.Target, matches, done[0], cmd.ActionFor(matches[0]), cmd.Value)
+	}
+
+	reply := ""
+	if len(done) > 0 {
+		reply = ControlReply(done, action, cmd.Value)
+	}
+	if len(failed) > 0 {
+		if reply != "" {
+			reply += "，"
+		}
+		reply += fmt.Sprintf("%s没有控制成功", joinNames(failed))
+	}
+	return &intent.Response{Reply: reply, Handled: true}, nil
+}
+
+// ControlReply 控制成功后的播报
+func ControlReply(entities []Entity, action Action, value float64) string {
+	names := joinNames(entities)
+	number := strconv.FormatFloat(value, 'f', -1, 64)
+	switch action {
+	case ActionTurnOn:
+		return fmt.Sprintf("好的，已打开%s", names)
+	case ActionTurnOff:
+		return fmt.Sprintf("好的，已关闭%s", names)
+	case ActionToggle:
+		return fmt.Sprintf("好的，已切换%s", names)
+	case ActionOpen:
+		return fmt.Sprintf("好的，已拉开%s", names)
+	case ActionClose:
+		return fmt.Sprintf("好的，已合上%s", names)
+	case ActionLock:
+		return fmt.Sprintf("好的，%s已锁上", names)
+	case ActionUnlock:
+		return fmt.Sprintf("好的，%s已解锁", names)
+	case ActionSetBrightness:
+		return fmt.Sprintf("好的，%s亮度已调到百分之%s", names, number)
+	case ActionSetPosition:
+		return fmt.Sprintf("好的，%s已调到百分之%s", names, number)
+	case ActionSetTemperature:
+		return fmt.Sprintf("好的，%s已调到%s度", names, number)
+	default:
+		return "好的"
+	}
+}
+
+// ErrorReply 控制失败时的播报
+func ErrorReply(entity Entity, err error) string {
+	name := entity.DisplayName()
+	switch {
+	case stderrors.Is(err, ErrUnsupported):
+		return fmt.Sprintf("%s不支持这个操作", name)
+	case stderrors.Is(err, ErrUnavailable):
+		return fmt.Sprintf("%s现在离线，暂时无法控制", name)
+	default:
+		return fmt.Sprintf("控制%s失败了，请稍后再试", name)
+	}
+}
+
+// AmbiguousReply 匹配到多个实体时的追问
+func AmbiguousReply(entities []Entity) string {
+	return fmt.Sprintf("找到了%d个设备：%s，要控制哪一个？", len(entities), joinNames(entities))
+}
+
+func supporting(entities []Entity, cmd Command) []Entity {
+	var out []Entity
+	for _, entity := range entities {
+		if entity.Supports(resolveAction(entity, cmd.ActionFor(entity))) {
+			out = append(out, entity)
+		}
+	}
+	return out
+}
+
+func joinNames(entities []Entity) string {
+	names := make([]string, 0, len(entities))
+	for _, entity := range entities {
+		names = append(names, entity.DisplayName())
+	}
+	return strings.Join(names, "、")
+}
+
+// typeNames 实体类型的中文名称
+var typeNames = map[string]string{
+	TypeLight:   "灯",
+	TypeSwitch:  "开关",
+	TypeCover:   "窗帘",
+	TypeClimate: "温控",
+	TypeLock:    "门锁",
+	TypeFan:     "风扇",
+	TypeSensor:  "传感器",
+}
+
+// Describe 描述实体的类型和当前状态，供LLM和播报使用，如“客厅灯（灯）：开，亮度60%”
+func Describe(entity Entity) string {
+	var parts []string
+	if !entity.Available {
+		parts = append(parts, "离线")
+	}
+	state := entity.State
+	if on, ok := state["on"].(bool); ok {
+		parts = append(parts, map[bool]string{true: "开", false: "关"}[on])
+	}
+	if open, ok := state["open"].(bool); ok {
+		parts = append(parts, map[bool]string{true: "已打开", false: "已关闭"}[open])
+	}
+	if locked, ok := state["locked"].(bool); ok {
+		parts = append(parts, map[bool]string{true: "已上锁", false: "未上锁"}[locked])
+	}
+	number := func(key, format string) {
+		if v, ok := state[key].(float64); ok {
+			parts = append(parts, fmt.Sprintf(format, strconv.FormatFloat(v, 'f', -1, 64)))
+		}
+	}
+	number("brightness", "亮度%s%%")
+	number("position", "开合度%s%%")
+	if entity.Type == TypeSensor {
+		number("temperature", "温度%s度")
+		number("humidity", "湿度%s%%")
+		number("battery", "电量%s%%")
+	} else {
+		number("temperature", "设定温度%s度")
+		number("current_temperature", "室温%s度")
+	}
+
+	typeName := typeNames[entity.Type]
+	if typeName == "" {
+		typeName = entity.Type
+	}
+	desc := fmt.Sprintf("%s（%s）", entity.DisplayName(), typeName)
+	if len(parts) > 0 {
+		desc += "：" + strings.Join(parts, "，")
+	}
+	return desc
+}
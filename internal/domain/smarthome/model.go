@@ -0,0 +1,126 @@
+package smarthome
+
+import (
+	stderrors "errors"
+	"time"
+)
+
+// 实体类型，与 smart_home 能力返回的类型一致
+const (
+	TypeLight   = "light"
+	TypeSwitch  = "switch"
+	TypeCover   = "cover"
+	TypeClimate = "climate"
+	TypeLock    = "lock"
+	TypeFan     = "fan"
+	TypeSensor  = "sensor"
+)
+
+// Action 控制动作
+type Action string
+
+const (
+	ActionTurnOn         Action = "turn_on"
+	ActionTurnOff        Action = "turn_off"
+	ActionToggle         Action = "toggle"
+	ActionSetBrightness  Action = "set_brightness"  // 亮度 0-100
+	ActionSetPosition    Action = "set_position"    // 窗帘开合度 0-100，100 为全开
+	ActionSetTemperature Action = "set_temperature" // 目标温度（摄氏度）
+	ActionOpen           Action = "open"
+	ActionClose          Action = "close"
+	ActionLock           Action = "lock"
+	ActionUnlock         Action = "unlock"
+)
+
+// actionFeatures 动作需要实体具备的功能
+var actionFeatures = map[Action]string{
+	ActionTurnOn:         "on_off",
+	ActionTurnOff:        "on_off",
+	ActionToggle:         "on_off",
+	ActionSetBrightness:  "brightness",
+	ActionSetPosition:    "position",
+	ActionSetTemperature: "temperature",
+	ActionOpen:           "open_close",
+	ActionClose:          "open_close",
+	ActionLock:           "lock",
+	ActionUnlock:         "lock",
+}
+
+// NeedsValue 动作是否需要设定值
+func (a Action) NeedsValue() bool {
+	return a == ActionSetBrightness || a == ActionSetPosition || a == ActionSetTemperature
+}
+
+// Valid 是否为支持的动作
+func (a Action) Valid() bool {
+	_, ok := actionFeatures[a]
+	return ok
+}
+
+var (
+	// ErrNotFound 实体不存在
+	ErrNotFound = stderrors.New("entity not found")
+	// ErrUnsupported 实体不支持该动作
+	ErrUnsupported = stderrors.New("action not supported by entity")
+	// ErrUnavailable 实体离线
+	ErrUnavailable = stderrors.New("entity unavailable")
+	// ErrBridge 调用桥接失败
+	ErrBridge = stderrors.New("smart home bridge failed")
+)
+
+// Entity 桥接设备映射出的实体，ID 为 "<桥接名称>:<设备ID>"
+type Entity struct {
+	ID        string
+	Bridge    string
+	DeviceID  string // 桥接内的设备ID
+	Name      string
+	Type      string
+	Room      string
+	Aliases   []string
+	Features  []string
+	State     map[string]interface{} // on/brightness/position/temperature/current_temperature/locked 及传感器读数
+	Available bool
+	UpdatedAt time.Time // 最近一次从桥接读取状态的时间
+}
+
+// Supports 实体是否支持该动作
+func (e Entity) Supports(action Action) bool {
+	feature, ok := actionFeatures[action]
+	if !ok {
+		return false
+	}
+	for _, f := range e.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveAction 窗帘的打开和关闭对应拉开和合上
+func resolveAction(entity Entity, action Action) Action {
+	if entity.Type == TypeCover {
+		switch action {
+		case ActionTurnOn:
+			return ActionOpen
+		case ActionTurnOff:
+			return ActionClose
+		}
+	}
+	return action
+}
+
+// DisplayName 播报用的名称，名称中不含房间时加上房间
+func (e Entity) DisplayName() string {
+	if e.Room == "" || containsFold(e.Name, e.Room) {
+		return e.Name
+	}
+	if isASCIILetter(e.Room[len(e.Room)-1]) && e.Name != "" && isASCIILetter(e.Name[0]) {
+		return e.Room + " " + e.Name
+	}
+	return e.Room + e.Name
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,308 @@
+package smarthome
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/plugin/sdk"
+)
+
+// Service 智能家居服务，通过 smart_home 能力发现各桥接上的设备并统一为实体
+// 实体和状态缓存在内存中，定期重新发现，状态过期后查询时向桥接刷新
+type Service struct {
+	cfg       config.SmartHomeConfig
+	registry  *capability.Registry
+	logger    *logging.Logger
+	now       func() time.Time
+	overrides map[string]config.SmartHomeEntity
+	aliases   AliasRepository // 未启用租户别名时为 nil
+
+	mu       sync.RWMutex
+	entities map[string]*Entity
+
+	recentMu sync.Mutex
+	recent   map[string]*recentControl // 按会话记录最近一次控制，用于纠正
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局智能家居服务，供意图路由和 LLM 工具使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局智能家居服务，未启用时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建智能家居服务
+func NewService(cfg config.SmartHomeConfig, registry *capability.Registry, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = 10 * time.Minute
+	}
+	if cfg.StateTTL <= 0 {
+		cfg.StateTTL = 30 * time.Second
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	overrides := make(map[string]config.SmartHomeEntity, len(cfg.Entities))
+	for _, e := range cfg.Entities {
+		overrides[e.ID] = e
+	}
+	return &Service{
+		cfg:       cfg,
+		registry:  registry,
+		logger:    logger,
+		now:       time.Now,
+		overrides: overrides,
+		entities:  make(map[string]*Entity),
+		recent:    make(map[string]*recentControl),
+	}
+}
+
+// Bridges 返回配置的桥接
+func (s *Service) Bridges() []config.SmartHomeBridge {
+	return s.cfg.Bridges
+}
+
+// Run 启动时发现实体，之后按间隔重新发现，直到 ctx 结束
+func (s *Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.cfg.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.Discover(ctx); err != nil && ctx.Err() == nil {
+			s.logger.WarnTag("智能家居", "发现实体失败: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Discover 向所有桥接重新发现实体，单个桥接失败时保留它上次发现的实体
+// 所有桥接都失败时返回错误
+func (s *Service) Discover(ctx context.Context) ([]Entity, error) {
+	var failures []string
+	for _, bridge := range s.cfg.Bridges {
+		outputs, err := s.execute(ctx, bridge, map[string]interface{}{"operation": "discover"})
+		if err != nil {
+			s.logger.WarnTag("智能家居", "桥接 %s 发现实体失败: %v", bridge.Name, err)
+			failures = append(failures, bridge.Name)
+			continue
+		}
+		entities := s.parseEntities(bridge.Name, outputs.Objects("entities"))
+
+		s.mu.Lock()
+		for id, entity := range s.entities {
+			if entity.Bridge == bridge.Name {
+				delete(s.entities, id)
+			}
+		}
+		for i := range entities {
+			s.entities[entities[i].ID] = &entities[i]
+		}
+		s.mu.Unlock()
+		s.logger.DebugTag("智能家居", "桥接 %s 发现 %d 个实体", bridge.Name, len(entities))
+	}
+	if len(failures) > 0 && len(failures) == len(s.cfg.Bridges) {
+		return nil, errors.Wrap(errors.KindDomain, "smarthome.discover", "all bridges failed: "+strings.Join(failures, ", "), ErrBridge)
+	}
+	return s.Entities(), nil
+}
+
+// Entities 返回缓存的实体，按房间和名称排序
+func (s *Service) Entities() []Entity {
+	s.mu.RLock()
+	out := make([]Entity, 0, len(s.entities))
+	for _, entity := range s.entities {
+		out = append(out, entity.clone())
+	}
+	s.mu.RUnlock()
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Room != out[j].Room {
+			return out[i].Room < out[j].Room
+		}
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+// Entity 返回实体，缓存的状态过期时先向桥接刷新，刷新失败时返回缓存的状态
+func (s *Service) Entity(ctx context.Context, id string) (Entity, error) {
+	s.mu.RLock()
+	cached, ok := s.entities[id]
+	var entity Entity
+	if ok {
+		entity = cached.clone()
+	}
+	s.mu.RUnlock()
+	if !ok {
+		return Entity{}, errors.Wrap(errors.KindDomain, "smarthome.entity", "unknown entity "+id, ErrNotFound)
+	}
+	if s.now().Sub(entity.UpdatedAt) < s.cfg.StateTTL {
+		return entity, nil
+	}
+
+	bridge, _ := s.bridge(entity.Bridge)
+	outputs, err := s.execute(ctx, bridge, map[string]interface{}{
+		"operation":  "state",
+		"entity_ids": []interface{}{entity.DeviceID},
+	})
+	if err != nil {
+		s.logger.WarnTag("智能家居", "刷新实体 %s 的状态失败: %v", id, err)
+		return entity, nil
+	}
+	for _, refreshed := range s.parseEntities(entity.Bridge, outputs.Objects("entities")) {
+		if refreshed.ID == id {
+			s.store(refreshed)
+			return refreshed, nil
+		}
+	}
+	return entity, nil
+}
+
+// Control 控制实体，窗帘的打开和关闭转换为拉开和合上；返回控制后预期的实体状态
+func (s *Service) Control(ctx context.Context, id string, action Action, value float64) (Entity, error) {
+	s.mu.RLock()
+	cached, ok := s.entities[id]
+	var entity Entity
+	if ok {
+		entity = cached.clone()
+	}
+	s.mu.RUnlock()
+	if !ok {
+		return Entity{}, errors.Wrap(errors.KindDomain, "smarthome.control", "unknown entity "+id, ErrNotFound)
+	}
+
+	action = resolveAction(entity, action)
+	if !action.Valid() {
+		return entity, errors.New(errors.KindDomain, "smarthome.control", "unsupported action: "+string(action))
+	}
+	if !entity.Supports(action) {
+		return entity, errors.Wrap(errors.KindDomain, "smarthome.control", fmt.Sprintf("%s does not support %s", entity.Name, action), ErrUnsupported)
+	}
+	if !entity.Available {
+		return entity, errors.Wrap(errors.KindDomain, "smarthome.control", entity.Name+" is offline", ErrUnavailable)
+	}
+
+	bridge, _ := s.bridge(entity.Bridge)
+	inputs := map[string]interface{}{
+		"operation": "control",
+		"entity_id": entity.DeviceID,
+		"action":    string(action),
+	}
+	if action.NeedsValue() {
+		inputs["value"] = value
+	}
+	outputs, err := s.execute(ctx, bridge, inputs)
+	if err != nil {
+		return entity, errors.Wrap(errors.KindDomain, "smarthome.control", fmt.Sprintf("control %s: %v", entity.Name, err), ErrBridge)
+	}
+	if controlled := s.parseEntities(entity.Bridge, []sdk.Args{outputs.Object("entity")}); len(controlled) == 1 && controlled[0].ID == id {
+		entity = controlled[0]
+		s.store(entity)
+	}
+	s.logger.InfoTag("智能家居", "控制实体 %s（%s）：%s %v", entity.Name, id, action, value)
+	return entity, nil
+}
+
+func (s *Service) store(entity Entity) {
+	s.mu.Lock()
+	s.entities[entity.ID] = &entity
+	s.mu.Unlock()
+}
+
+func (s *Service) bridge(name string) (config.SmartHomeBridge, bool) {
+	for _, bridge := range s.cfg.Bridges {
+		if bridge.Name == name {
+			return bridge, true
+		}
+	}
+	return config.SmartHomeBridge{Name: name}, false
+}
+
+func (s *Service) execute(ctx context.Context, bridge config.SmartHomeBridge, inputs map[string]interface{}) (sdk.Args, error) {
+	if bridge.Capability == "" {
+		return nil, fmt.Errorf("桥接 %s 未配置能力", bridge.Name)
+	}
+	exec, err := s.registry.GetExecutor(bridge.Capability)
+	if err != nil {
+		return nil, fmt.Errorf("获取能力 %s 失败: %w", bridge.Capability, err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+	outputs, err := exec.Execute(ctx, bridge.Config, inputs)
+	if err != nil {
+		return nil, fmt.Errorf("能力 %s 执行失败: %w", bridge.Capability, err)
+	}
+	return sdk.Args(outputs), nil
+}
+
+// parseEntities 转换能力返回的实体，并应用配置中的名称、房间和别名
+func (s *Service) parseEntities(bridge string, items []sdk.Args) []Entity {
+	now := s.now()
+	entities := make([]Entity, 0, len(items))
+	for _, item := range items {
+		deviceID := item.String("id", "")
+		if deviceID == "" {
+			continue
+		}
+		entity := Entity{
+			ID:        bridge + ":" + deviceID,
+			Bridge:    bridge,
+			DeviceID:  deviceID,
+			Name:      item.String("name", deviceID),
+			Type:      item.String("type", ""),
+			Room:      item.String("room", ""),
+			Features:  item.Strings("features", nil),
+			State:     map[string]interface{}(item.Object("state")),
+			Available: item.Bool("available", true),
+			UpdatedAt: now,
+		}
+		if override, ok := s.overrides[entity.ID]; ok {
+			if override.Name != "" {
+				entity.Name = override.Name
+			}
+			if override.Room != "" {
+				entity.Room = override.Room
+			}
+			entity.Aliases = override.Aliases
+		}
+		entities = append(entities, entity)
+	}
+	return entities
+}
+
+func (e *Entity) clone() Entity {
+	out := *e
+	out.State = make(map[string]interface{}, len(e.State))
+	for k, v := range e.State {
+		out.State[k] = v
+	}
+	return out
+}
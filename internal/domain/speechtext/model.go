@@ -0,0 +1,60 @@
+// Package speechtext 播报文本后处理
+//
+// LLM 回复送入 TTS 之前按设备分配的方案依次处理：去掉 Markdown 和表情、把数字和单位转为读法、
+// 按发音词典和正则替换。方案保存在数据库中，未分配方案的设备使用 default 方案。
+package speechtext
+
+import (
+	"time"
+)
+
+// DefaultProfile 未分配方案的设备使用的方案名称，数据库中没有时使用内置方案
+const DefaultProfile = "default"
+
+// 处理步骤类型
+const (
+	StageMarkdown = "markdown" // 去掉 Markdown 标记，保留文字和链接文本
+	StageEmoji    = "emoji"    // 去掉表情符号
+	StageUnits    = "units"    // 数字、百分比、温度和计量单位转为读法
+	StageReplace  = "replace"  // 按发音词典替换词语，长词优先
+	StageRegex    = "regex"    // 正则替换
+)
+
+// Stage 处理步骤
+type Stage struct {
+	Type        string            `json:"type"`
+	Language    string            `json:"language,omitempty"`    // units 使用：zh 或 en，为空时按文本是否包含汉字判断
+	Words       map[string]string `json:"words,omitempty"`       // replace 使用：原词到读法的映射
+	Pattern     string            `json:"pattern,omitempty"`     // regex 使用：Go 正则表达式
+	Replacement string            `json:"replacement,omitempty"` // regex 使用：替换文本，用 ${1} 引用分组
+}
+
+// Profile 后处理方案，步骤按顺序执行
+type Profile struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Stages      []Stage   `json:"stages"`
+	BuiltIn     bool      `json:"built_in"` // 数据库中没有 default 方案时返回的内置方案
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Assignment 设备使用的方案
+type Assignment struct {
+	DeviceID  string    `json:"device_id"`
+	Profile   string    `json:"profile"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// builtInProfile 内置的 default 方案
+func builtInProfile() *Profile {
+	return &Profile{
+		Name:        DefaultProfile,
+		Description: "去掉 Markdown 和表情，数字和单位按文本语言转为读法",
+		Stages: []Stage{
+			{Type: StageMarkdown},
+			{Type: StageEmoji},
+			{Type: StageUnits},
+		},
+		BuiltIn: true,
+	}
+}
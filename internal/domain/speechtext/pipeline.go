@@ -0,0 +1,140 @@
+package speechtext
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"xiaozhi-server-go/internal/utils"
+)
+
+// Pipeline 编译后的处理步骤，可并发使用
+type Pipeline struct {
+	steps []func(string) string
+}
+
+// Compile 校验并编译处理步骤
+func Compile(stages []Stage) (*Pipeline, error) {
+	p := &Pipeline{steps: make([]func(string) string, 0, len(stages))}
+	for i, stage := range stages {
+		step, err := compileStage(stage)
+		if err != nil {
+			return nil, fmt.Errorf("stages[%d]: %w", i, err)
+		}
+		p.steps = append(p.steps, step)
+	}
+	return p, nil
+}
+
+// DefaultPipeline 内置 default 方案的处理步骤
+func DefaultPipeline() *Pipeline {
+	p, _ := Compile(builtInProfile().Stages)
+	return p
+}
+
+// Process 依次执行处理步骤，p 为 nil 时原样返回
+func (p *Pipeline) Process(text string) string {
+	if p == nil {
+		return text
+	}
+	for _, step := range p.steps {
+		if text == "" {
+			break
+		}
+		text = step(text)
+	}
+	return text
+}
+
+func compileStage(stage Stage) (func(string) string, error) {
+	switch stage.Type {
+	case StageMarkdown:
+		return stripMarkdown, nil
+	case StageEmoji:
+		return utils.RemoveAllEmoji, nil
+	case StageUnits:
+		language := strings.ToLower(stage.Language)
+		if language != "" && language != languageZh && language != languageEn {
+			return nil, fmt.Errorf("language must be zh, en or empty")
+		}
+		return func(text string) string { return verbalize(text, language) }, nil
+	case StageReplace:
+		if len(stage.Words) == 0 {
+			return nil, fmt.Errorf("words is required for replace")
+		}
+		return replaceWords(stage.Words), nil
+	case StageRegex:
+		if stage.Pattern == "" {
+			return nil, fmt.Errorf("pattern is required for regex")
+		}
+		re, err := regexp.Compile(stage.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+		return func(text string) string { return re.ReplaceAllString(text, stage.Replacement) }, nil
+	default:
+		return nil, fmt.Errorf("unknown stage type %q", stage.Type)
+	}
+}
+
+// replaceWords 按发音词典替换，长词优先，已替换的文本不会再次匹配
+func replaceWords(words map[string]string) func(string) string {
+	keys := make([]string, 0, len(words))
+	for k := range words {
+		if k != "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	pairs := make([]string, 0, len(keys)*2)
+	for _, k := range keys {
+		pairs = append(pairs, k, words[k])
+	}
+	replacer := strings.NewReplacer(pairs...)
+	return replacer.Replace
+}
+
+var (
+	mdImage     = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdLink      = regexp.MustCompile(`\[([^\]]+)\]\([^)]*\)`)
+	mdHeading   = regexp.MustCompile(`(?m)^\s{0,3}#{1,6}\s+`)
+	mdQuote     = regexp.MustCompile(`(?m)^\s*>\s?`)
+	mdBullet    = regexp.MustCompile(`(?m)^\s*[-*+]\s+`)
+	mdEmphasis  = regexp.MustCompile(`(\*\*|__|~~)(\S(?:.*?\S)?)(\*\*|__|~~)`)
+	mdItalic    = regexp.MustCompile(`\*(\S(?:[^*]*?\S)?)\*`)
+	mdCode      = regexp.MustCompile("`+")
+	mdRule      = regexp.MustCompile(`(?m)^\s*([-*_]\s*){3,}$`)
+	mdTableRule = regexp.MustCompile(`(?m)^\s*\|?\s*:?-{3,}:?\s*(\|\s*:?-{3,}:?\s*)*\|?\s*$`)
+	mdTableCell = regexp.MustCompile(`\s*\|\s*`)
+)
+
+// stripMarkdown 去掉 Markdown 标记，保留文字和链接文本，表格单元格之间用逗号隔开
+func stripMarkdown(text string) string {
+	text = mdTableRule.ReplaceAllString(text, "")
+	text = mdRule.ReplaceAllString(text, "")
+	text = mdImage.ReplaceAllString(text, "$1")
+	text = mdLink.ReplaceAllString(text, "$1")
+	text = mdHeading.ReplaceAllString(text, "")
+	text = mdQuote.ReplaceAllString(text, "")
+	text = mdBullet.ReplaceAllString(text, "")
+	text = mdEmphasis.ReplaceAllString(text, "$2")
+	text = mdItalic.ReplaceAllString(text, "$1")
+	text = mdCode.ReplaceAllString(text, "")
+	if strings.Contains(text, "|") {
+		lines := strings.Split(text, "\n")
+		for i, line := range lines {
+			trimmed := strings.Trim(strings.TrimSpace(line), "|")
+			if trimmed != strings.TrimSpace(line) || strings.Count(line, "|") >= 2 {
+				lines[i] = mdTableCell.ReplaceAllString(strings.TrimSpace(trimmed), "，")
+			}
+		}
+		text = strings.Join(lines, "\n")
+	}
+	return text
+}
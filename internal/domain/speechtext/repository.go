@@ -0,0 +1,32 @@
+package speechtext
+
+import (
+	"context"
+)
+
+// Repository 后处理方案仓库接口
+type Repository interface {
+	// Save 创建或更新方案
+	Save(ctx context.Context, p *Profile) error
+
+	// Find 查找方案，不存在时返回 nil
+	Find(ctx context.Context, name string) (*Profile, error)
+
+	// List 列出全部方案，按名称排序
+	List(ctx context.Context) ([]*Profile, error)
+
+	// Delete 删除方案及其设备分配
+	Delete(ctx context.Context, name string) error
+
+	// SaveAssignment 保存或覆盖设备的方案分配
+	SaveAssignment(ctx context.Context, a *Assignment) error
+
+	// FindAssignment 查找设备的方案分配，不存在时返回 nil
+	FindAssignment(ctx context.Context, deviceID string) (*Assignment, error)
+
+	// ListAssignments 列出全部分配
+	ListAssignments(ctx context.Context) ([]*Assignment, error)
+
+	// DeleteAssignment 删除设备的方案分配
+	DeleteAssignment(ctx context.Context, deviceID string) error
+}
@@ -0,0 +1,210 @@
+package speechtext
+
+import (
+	"context"
+	stderrors "errors"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+// ErrNotFound 方案不存在
+var ErrNotFound = stderrors.New("speech text profile not found")
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
+// maxStages 单个方案的步骤上限
+const maxStages = 32
+
+// Service 播报文本后处理服务
+type Service struct {
+	repo   Repository
+	logger *logging.Logger
+	now    func() time.Time
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局后处理服务，供连接处理器使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局后处理服务，未初始化时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建后处理服务
+func NewService(repo Repository, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Service{
+		repo:   repo,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Get 查询方案，数据库中没有 default 方案时返回内置方案
+func (s *Service) Get(ctx context.Context, name string) (*Profile, error) {
+	p, err := s.repo.Find(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		if name == DefaultProfile {
+			return builtInProfile(), nil
+		}
+		return nil, errors.Wrap(errors.KindDomain, "speechtext.get", "speech text profile not found", ErrNotFound)
+	}
+	return p, nil
+}
+
+// List 列出全部方案，数据库中没有 default 方案时包含内置方案
+func (s *Service) List(ctx context.Context) ([]*Profile, error) {
+	profiles, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range profiles {
+		if p.Name == DefaultProfile {
+			return profiles, nil
+		}
+	}
+	return append([]*Profile{builtInProfile()}, profiles...), nil
+}
+
+// Save 创建或覆盖方案，保存前校验全部步骤
+func (s *Service) Save(ctx context.Context, p *Profile) (*Profile, error) {
+	if !namePattern.MatchString(p.Name) {
+		return nil, errors.New(errors.KindDomain, "speechtext.save", "invalid profile name: "+p.Name)
+	}
+	if len(p.Stages) > maxStages {
+		return nil, errors.New(errors.KindDomain, "speechtext.save", "too many stages")
+	}
+	if _, err := Compile(p.Stages); err != nil {
+		return nil, errors.Wrap(errors.KindDomain, "speechtext.save", err.Error(), err)
+	}
+	saved := &Profile{
+		Name:        p.Name,
+		Description: strings.TrimSpace(p.Description),
+		Stages:      p.Stages,
+		UpdatedAt:   s.now(),
+	}
+	if saved.Stages == nil {
+		saved.Stages = []Stage{}
+	}
+	if err := s.repo.Save(ctx, saved); err != nil {
+		return nil, err
+	}
+	s.logger.InfoTag("播报文本", "方案 %s 已保存，共 %d 个步骤", saved.Name, len(saved.Stages))
+	return saved, nil
+}
+
+// Delete 删除方案及其设备分配，删除 default 方案后恢复使用内置方案
+func (s *Service) Delete(ctx context.Context, name string) error {
+	p, err := s.repo.Find(ctx, name)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		return errors.Wrap(errors.KindDomain, "speechtext.delete", "speech text profile not found", ErrNotFound)
+	}
+	return s.repo.Delete(ctx, name)
+}
+
+// Assign 为设备分配方案
+func (s *Service) Assign(ctx context.Context, deviceID, name string) (*Assignment, error) {
+	if strings.TrimSpace(deviceID) == "" {
+		return nil, errors.New(errors.KindDomain, "speechtext.assign", "device_id is required")
+	}
+	if _, err := s.Get(ctx, name); err != nil {
+		return nil, err
+	}
+	a := &Assignment{
+		DeviceID:  deviceID,
+		Profile:   name,
+		UpdatedAt: s.now(),
+	}
+	if err := s.repo.SaveAssignment(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Assignment 查询设备的方案分配，未分配时返回 default
+func (s *Service) Assignment(ctx context.Context, deviceID string) (*Assignment, error) {
+	a, err := s.repo.FindAssignment(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil {
+		return &Assignment{DeviceID: deviceID, Profile: DefaultProfile}, nil
+	}
+	return a, nil
+}
+
+// Unassign 取消设备的方案分配
+func (s *Service) Unassign(ctx context.Context, deviceID string) error {
+	return s.repo.DeleteAssignment(ctx, deviceID)
+}
+
+// ListAssignments 列出全部分配
+func (s *Service) ListAssignments(ctx context.Context) ([]*Assignment, error) {
+	return s.repo.ListAssignments(ctx)
+}
+
+// Preview 用未保存的步骤处理文本，供调试方案使用
+func (s *Service) Preview(stages []Stage, text string) (string, error) {
+	p, err := Compile(stages)
+	if err != nil {
+		return "", errors.Wrap(errors.KindDomain, "speechtext.preview", err.Error(), err)
+	}
+	return p.Process(text), nil
+}
+
+// PipelineFor 按设备分配、default 方案、内置方案的顺序解析设备的处理步骤
+// 查询或编译失败时记录日志并使用内置方案，不影响播报
+func (s *Service) PipelineFor(ctx context.Context, deviceID string) *Pipeline {
+	name := DefaultProfile
+	if deviceID != "" {
+		a, err := s.repo.FindAssignment(ctx, deviceID)
+		if err != nil {
+			s.logger.WarnTag("播报文本", "查询设备 %s 的方案分配失败: %v", deviceID, err)
+		} else if a != nil {
+			name = a.Profile
+		}
+	}
+
+	p, err := s.Get(ctx, name)
+	if err != nil && name != DefaultProfile {
+		s.logger.WarnTag("播报文本", "设备 %s 的方案 %s 不可用，使用 default 方案: %v", deviceID, name, err)
+		name = DefaultProfile
+		p, err = s.Get(ctx, name)
+	}
+	if err != nil {
+		s.logger.WarnTag("播报文本", "加载方案 %s 失败，使用内置方案: %v", name, err)
+		return DefaultPipeline()
+	}
+
+	pipeline, err := Compile(p.Stages)
+	if err != nil {
+		s.logger.WarnTag("播报文本", "编译方案 %s 失败，使用内置方案: %v", name, err)
+		return DefaultPipeline()
+	}
+	s.logger.DebugTag("播报文本", "设备 %s 使用方案 %s", deviceID, name)
+	return pipeline
+}
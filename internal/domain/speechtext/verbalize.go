@@ -0,0 +1,325 @@
+package speechtext
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// 数字和单位的读法，zh 和 en 之外的语言不处理
+const (
+	languageZh = "zh"
+	languageEn = "en"
+)
+
+// unit 计量单位的读法
+type unit struct {
+	zh         string
+	enSingular string
+	enPlural   string
+}
+
+// units 数字后的单位，只有紧跟在数字后（可隔一个空格）且后面不是字母时才转换
+var units = map[string]unit{
+	"km/h": {"公里每小时", "kilometer per hour", "kilometers per hour"},
+	"m/s":  {"米每秒", "meter per second", "meters per second"},
+	"mph":  {"英里每小时", "mile per hour", "miles per hour"},
+	"kWh":  {"千瓦时", "kilowatt hour", "kilowatt hours"},
+	"°C":   {"摄氏度", "degree Celsius", "degrees Celsius"},
+	"℃":    {"摄氏度", "degree Celsius", "degrees Celsius"},
+	"°F":   {"华氏度", "degree Fahrenheit", "degrees Fahrenheit"},
+	"℉":    {"华氏度", "degree Fahrenheit", "degrees Fahrenheit"},
+	"°":    {"度", "degree", "degrees"},
+	"km":   {"公里", "kilometer", "kilometers"},
+	"cm":   {"厘米", "centimeter", "centimeters"},
+	"mm":   {"毫米", "millimeter", "millimeters"},
+	"m":    {"米", "meter", "meters"},
+	"kg":   {"千克", "kilogram", "kilograms"},
+	"mg":   {"毫克", "milligram", "milligrams"},
+	"g":    {"克", "gram", "grams"},
+	"ml":   {"毫升", "milliliter", "milliliters"},
+	"mL":   {"毫升", "milliliter", "milliliters"},
+	"L":    {"升", "liter", "liters"},
+	"kW":   {"千瓦", "kilowatt", "kilowatts"},
+	"W":    {"瓦", "watt", "watts"},
+	"h":    {"小时", "hour", "hours"},
+	"min":  {"分钟", "minute", "minutes"},
+	"s":    {"秒", "second", "seconds"},
+}
+
+var (
+	reThousands = regexp.MustCompile(`\d{1,3}(?:,\d{3})+(?:\.\d+)?`)
+	reDate      = regexp.MustCompile(`\b(\d{4})-(\d{1,2})-(\d{1,2})\b`)
+	reTime      = regexp.MustCompile(`\b(\d{1,2}):(\d{2})\b`)
+	reDigitRun  = regexp.MustCompile(`\d+(?:\s?[-~～]\s?\d+)+`)
+	reUnit      = regexp.MustCompile(`(\d+(?:\.\d+)?) ?(km/h|m/s|°C|°F|°|℃|℉|%|[A-Za-z]+)`)
+	reNegative  = regexp.MustCompile(`(^|[^\w.])-(\d)`)
+	reNumber    = regexp.MustCompile(`\d+(?:\.\d+)?`)
+	reYearZh    = regexp.MustCompile(`(\d{4})年`)
+)
+
+var monthsEn = []string{"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December"}
+
+// detectLanguage 文本包含汉字时按中文读，否则按英文读
+func detectLanguage(text string) string {
+	for _, r := range text {
+		if unicode.Is(unicode.Han, r) {
+			return languageZh
+		}
+	}
+	return languageEn
+}
+
+// verbalize 把日期、时间、范围、百分比、单位和数字转为 language 的读法
+func verbalize(text, language string) string {
+	if language == "" {
+		language = detectLanguage(text)
+	}
+	if language != languageZh && language != languageEn {
+		return text
+	}
+	if !strings.ContainsAny(text, "0123456789") {
+		return text
+	}
+	zh := language == languageZh
+
+	text = reThousands.ReplaceAllStringFunc(text, func(s string) string {
+		return strings.ReplaceAll(s, ",", "")
+	})
+	text = reDate.ReplaceAllStringFunc(text, func(s string) string {
+		m := reDate.FindStringSubmatch(s)
+		month, _ := strconv.Atoi(m[2])
+		day, _ := strconv.Atoi(m[3])
+		if month < 1 || month > 12 || day < 1 || day > 31 {
+			return s
+		}
+		if zh {
+			return m[1] + "年" + strconv.Itoa(month) + "月" + strconv.Itoa(day) + "日"
+		}
+		return monthsEn[month-1] + " " + strconv.Itoa(day) + ", " + m[1]
+	})
+	if zh {
+		text = reTime.ReplaceAllStringFunc(text, func(s string) string {
+			m := reTime.FindStringSubmatch(s)
+			hour, _ := strconv.Atoi(m[1])
+			minute, _ := strconv.Atoi(m[2])
+			if hour > 24 || minute > 59 {
+				return s
+			}
+			if minute == 0 {
+				return strconv.Itoa(hour) + "点"
+			}
+			return strconv.Itoa(hour) + "点" + strconv.Itoa(minute) + "分"
+		})
+	}
+	text = reDigitRun.ReplaceAllStringFunc(text, func(s string) string {
+		parts := strings.FieldsFunc(s, func(r rune) bool { return r == '-' || r == '~' || r == '～' || r == ' ' })
+		if len(parts) == 2 {
+			if zh {
+				return parts[0] + "到" + parts[1]
+			}
+			return parts[0] + " to " + parts[1]
+		}
+		// 电话号码等多段数字逐位读
+		for i, part := range parts {
+			parts[i] = readDigits(part, zh)
+		}
+		if zh {
+			return strings.Join(parts, "，")
+		}
+		return strings.Join(parts, ", ")
+	})
+	text = reUnit.ReplaceAllStringFunc(text, func(s string) string {
+		m := reUnit.FindStringSubmatch(s)
+		number, symbol := m[1], m[2]
+		if symbol == "%" {
+			if zh {
+				return "百分之" + number
+			}
+			return number + " percent"
+		}
+		u, ok := units[symbol]
+		if !ok {
+			return s
+		}
+		if zh {
+			return number + u.zh
+		}
+		if number == "1" {
+			return number + " " + u.enSingular
+		}
+		return number + " " + u.enPlural
+	})
+	if zh {
+		text = reYearZh.ReplaceAllStringFunc(text, func(s string) string {
+			return readDigits(strings.TrimSuffix(s, "年"), true) + "年"
+		})
+		text = reNegative.ReplaceAllString(text, "${1}负$2")
+	} else {
+		text = reNegative.ReplaceAllString(text, "${1}minus $2")
+	}
+	return reNumber.ReplaceAllStringFunc(text, func(s string) string {
+		if zh {
+			return readNumberZh(s)
+		}
+		return readNumberEn(s)
+	})
+}
+
+var digitsZh = []string{"零", "一", "二", "三", "四", "五", "六", "七", "八", "九"}
+var digitsEn = []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
+
+// readDigits 逐位读数字
+func readDigits(s string, zh bool) string {
+	words := make([]string, 0, len(s))
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			continue
+		}
+		if zh {
+			words = append(words, digitsZh[r-'0'])
+		} else {
+			words = append(words, digitsEn[r-'0'])
+		}
+	}
+	if zh {
+		return strings.Join(words, "")
+	}
+	return strings.Join(words, " ")
+}
+
+// readNumberZh 按中文读数，整数部分以 0 开头或超过 12 位时逐位读（编号、电话号码）
+func readNumberZh(s string) string {
+	integer, fraction, _ := strings.Cut(s, ".")
+	var out string
+	if (len(integer) > 1 && integer[0] == '0') || len(integer) > 12 {
+		out = readDigits(integer, true)
+	} else {
+		n, _ := strconv.ParseInt(integer, 10, 64)
+		out = integerZh(n)
+	}
+	if fraction != "" {
+		out += "点" + readDigits(fraction, true)
+	}
+	return out
+}
+
+// integerZh 读 0 到 9999亿 之间的整数
+func integerZh(n int64) string {
+	if n == 0 {
+		return digitsZh[0]
+	}
+	groups := []string{"", "万", "亿"}
+	var parts []string
+	needZero := false
+	for i := 0; n > 0 && i < len(groups); i++ {
+		group := n % 10000
+		n /= 10000
+		if group == 0 {
+			needZero = len(parts) > 0
+			continue
+		}
+		s := groupZh(group) + groups[i]
+		if needZero || (group < 1000 && n > 0) {
+			s = "零" + s
+		}
+		parts = append([]string{s}, parts...)
+		needZero = false
+	}
+	out := strings.TrimPrefix(strings.Join(parts, ""), "零")
+	// 十到十九读作“十X”而不是“一十X”
+	if strings.HasPrefix(out, "一十") {
+		out = strings.TrimPrefix(out, "一")
+	}
+	return out
+}
+
+// groupZh 读 1 到 9999
+func groupZh(n int64) string {
+	places := []string{"千", "百", "十", ""}
+	divisors := []int64{1000, 100, 10, 1}
+	var b strings.Builder
+	zero := false
+	for i, d := range divisors {
+		digit := n / d % 10
+		if digit == 0 {
+			zero = b.Len() > 0
+			continue
+		}
+		if zero {
+			b.WriteString("零")
+			zero = false
+		}
+		b.WriteString(digitsZh[digit] + places[i])
+	}
+	return b.String()
+}
+
+// readNumberEn 按英文读数，1000 到 2099 之间的四位整数（通常是年份）保留原样，TTS 会按年份读
+func readNumberEn(s string) string {
+	integer, fraction, _ := strings.Cut(s, ".")
+	if fraction == "" && len(integer) == 4 && integer >= "1000" && integer <= "2099" {
+		return s
+	}
+	var out string
+	if (len(integer) > 1 && integer[0] == '0') || len(integer) > 12 {
+		out = readDigits(integer, false)
+	} else {
+		n, _ := strconv.ParseInt(integer, 10, 64)
+		out = integerEn(n)
+	}
+	if fraction != "" {
+		out += " point " + readDigits(fraction, false)
+	}
+	return out
+}
+
+var (
+	onesEn = []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	tensEn = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+)
+
+// integerEn 读 0 到 999 billion 之间的整数
+func integerEn(n int64) string {
+	if n == 0 {
+		return onesEn[0]
+	}
+	scales := []string{"", "thousand", "million", "billion"}
+	var parts []string
+	for i := 0; n > 0 && i < len(scales); i++ {
+		group := n % 1000
+		n /= 1000
+		if group == 0 {
+			continue
+		}
+		s := hundredsEn(group)
+		if scales[i] != "" {
+			s += " " + scales[i]
+		}
+		parts = append([]string{s}, parts...)
+	}
+	return strings.Join(parts, " ")
+}
+
+// hundredsEn 读 1 到 999
+func hundredsEn(n int64) string {
+	var words []string
+	if n >= 100 {
+		words = append(words, onesEn[n/100], "hundred")
+		n %= 100
+	}
+	switch {
+	case n >= 20:
+		word := tensEn[n/10]
+		if n%10 != 0 {
+			word += "-" + onesEn[n%10]
+		}
+		words = append(words, word)
+	case n > 0:
+		words = append(words, onesEn[n])
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,26 @@
+package tenant
+
+import (
+	"context"
+)
+
+type contextKey struct{}
+
+// WithID 返回携带租户ID的上下文，仓库和限制器据此隔离数据与计量用量
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext 返回上下文中的租户ID；管理员请求未指定租户时 ok 为 false
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKey{}).(string)
+	return id, ok && id != ""
+}
+
+// IDFrom 返回上下文中的租户ID，未指定时返回默认租户
+func IDFrom(ctx context.Context) string {
+	if id, ok := FromContext(ctx); ok {
+		return id
+	}
+	return DefaultID
+}
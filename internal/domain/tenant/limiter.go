@@ -0,0 +1,96 @@
+package tenant
+
+import (
+	"context"
+	"time"
+
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+const dayLayout = "2006-01-02"
+
+// dailyUsage 租户当天用量的内存计数
+type dailyUsage struct {
+	day      string
+	requests int64
+	tokens   int64
+	inFlight int64
+}
+
+// Limiter 返回按上下文中的租户计量并实施每日配额的 capability.Limiter
+// 未指定租户的调用计入默认租户
+func (s *Service) Limiter() capability.Limiter {
+	return tenantLimiter{service: s}
+}
+
+type tenantLimiter struct {
+	service *Service
+}
+
+func (l tenantLimiter) Acquire(ctx context.Context, _ string, _ capability.Definition) (func(capability.Usage), error) {
+	s := l.service
+	id := IDFrom(ctx)
+	t, err := s.Get(ctx, id)
+	if err != nil {
+		// 租户不存在或加载失败时不计量，避免影响调用
+		return func(capability.Usage) {}, nil
+	}
+	if !t.Enabled {
+		return nil, errors.Wrap(errors.KindDomain, "tenant.limiter", "tenant disabled: "+id, ErrDisabled)
+	}
+
+	now := s.now()
+	day := now.Format(dayLayout)
+	s.usageMu.Lock()
+	u, err := s.dailyUsage(ctx, id, day)
+	if err != nil {
+		s.usageMu.Unlock()
+		s.logger.WarnTag("租户", "加载租户 %s 的用量失败: %v", id, err)
+		return func(capability.Usage) {}, nil
+	}
+	retryAfter := nextDay(now).Sub(now)
+	if t.Quota.DailyRequests > 0 && u.requests+u.inFlight >= t.Quota.DailyRequests {
+		s.usageMu.Unlock()
+		return nil, &capability.QuotaExceededError{Scope: "tenant", Target: id, Kind: capability.QuotaRequests, Limit: float64(t.Quota.DailyRequests), RetryAfter: retryAfter}
+	}
+	if t.Quota.DailyTokens > 0 && u.tokens >= t.Quota.DailyTokens {
+		s.usageMu.Unlock()
+		return nil, &capability.QuotaExceededError{Scope: "tenant", Target: id, Kind: capability.QuotaTokens, Limit: float64(t.Quota.DailyTokens), RetryAfter: retryAfter}
+	}
+	u.inFlight++
+	s.usageMu.Unlock()
+
+	return func(usage capability.Usage) {
+		s.usageMu.Lock()
+		u.inFlight--
+		u.requests++
+		u.tokens += usage.Tokens
+		s.usageMu.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := s.repo.AddUsage(ctx, id, day, 1, usage.Tokens); err != nil {
+			s.logger.WarnTag("租户", "记录租户 %s 的用量失败: %v", id, err)
+		}
+	}, nil
+}
+
+// dailyUsage 返回租户当天的计数，跨天或首次使用时从数据库恢复；调用方需持有 usageMu
+func (s *Service) dailyUsage(ctx context.Context, id, day string) (*dailyUsage, error) {
+	if u, ok := s.usage[id]; ok && u.day == day {
+		return u, nil
+	}
+	stored, err := s.repo.GetUsage(ctx, id, day)
+	if err != nil {
+		return nil, err
+	}
+	u := &dailyUsage{day: day, requests: stored.Requests, tokens: stored.Tokens}
+	s.usage[id] = u
+	return u, nil
+}
+
+func nextDay(now time.Time) time.Time {
+	y, m, d := now.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, now.Location())
+}
@@ -0,0 +1,36 @@
+package tenant
+
+import (
+	"time"
+)
+
+// DefaultID 默认租户，升级前的数据和未携带租户令牌的请求都归属于它
+const DefaultID = "default"
+
+// Tenant 租户，一个家庭或组织，拥有独立的设备、供应商配置、提示词模板、工作流和用量
+type Tenant struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Enabled   bool      `json:"enabled"`
+	Quota     Quota     `json:"quota"`
+	Providers Providers `json:"-"` // 含供应商密钥，接口返回时脱敏
+	TokenHash string    `json:"-"` // 访问令牌的 SHA-256，令牌明文只在签发时返回一次
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Quota 租户资源配额，各项为 0 表示不限制
+type Quota struct {
+	MaxDevices    int   `json:"max_devices"`
+	DailyRequests int64 `json:"daily_requests"`
+	DailyTokens   int64 `json:"daily_tokens"`
+}
+
+// Usage 租户某一天的用量
+type Usage struct {
+	TenantID string `json:"tenant_id"`
+	Day      string `json:"day"` // YYYY-MM-DD
+	Requests int64  `json:"requests"`
+	Tokens   int64  `json:"tokens"`
+	Devices  int64  `json:"devices"` // 当前设备数，查询时填充
+}
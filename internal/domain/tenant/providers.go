@@ -0,0 +1,143 @@
+package tenant
+
+import (
+	"context"
+	"maps"
+	"strings"
+
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// maskedSecret 返回给接口调用方的脱敏密钥，保存时传入该值表示保留原值
+const maskedSecret = "******"
+
+// Providers 租户的供应商配置。LLM 和 TTS 中的条目与实例配置同名时覆盖实例配置，
+// SelectedLLM、SelectedTTS 为空时沿用实例的选择。ASR 由资源池在各租户间共享，仍使用实例配置
+type Providers struct {
+	SelectedLLM string                      `json:"selected_llm,omitempty"`
+	SelectedTTS string                      `json:"selected_tts,omitempty"`
+	LLM         map[string]config.LLMConfig `json:"llm,omitempty"`
+	TTS         map[string]config.TTSConfig `json:"tts,omitempty"`
+}
+
+// Empty 租户是否没有任何供应商配置
+func (p Providers) Empty() bool {
+	return p.SelectedLLM == "" && p.SelectedTTS == "" && len(p.LLM) == 0 && len(p.TTS) == 0
+}
+
+// Apply 返回合并了租户供应商配置的实例配置副本，没有租户配置时直接返回 base
+func (p Providers) Apply(base *config.Config) *config.Config {
+	if base == nil || p.Empty() {
+		return base
+	}
+	merged := *base
+	merged.LLM = make(map[string]config.LLMConfig, len(base.LLM)+len(p.LLM))
+	maps.Copy(merged.LLM, base.LLM)
+	maps.Copy(merged.LLM, p.LLM)
+	merged.TTS = make(map[string]config.TTSConfig, len(base.TTS)+len(p.TTS))
+	maps.Copy(merged.TTS, base.TTS)
+	maps.Copy(merged.TTS, p.TTS)
+	if p.SelectedLLM != "" {
+		merged.Selected.LLM = p.SelectedLLM
+	}
+	if p.SelectedTTS != "" {
+		merged.Selected.TTS = p.SelectedTTS
+	}
+	return &merged
+}
+
+// Validate 检查每个条目都指定了类型，选用的配置名称在合并后的配置中存在
+func (p Providers) Validate(base *config.Config) error {
+	for name, c := range p.LLM {
+		if strings.TrimSpace(name) == "" || c.Type == "" {
+			return errors.New(errors.KindDomain, "tenant.providers", "llm config "+name+" requires a name and type")
+		}
+	}
+	for name, c := range p.TTS {
+		if strings.TrimSpace(name) == "" || c.Type == "" {
+			return errors.New(errors.KindDomain, "tenant.providers", "tts config "+name+" requires a name and type")
+		}
+	}
+	merged := p.Apply(base)
+	if merged == nil {
+		return nil
+	}
+	if _, ok := merged.LLM[merged.Selected.LLM]; p.SelectedLLM != "" && !ok {
+		return errors.New(errors.KindDomain, "tenant.providers", "selected llm config not found: "+p.SelectedLLM)
+	}
+	if _, ok := merged.TTS[merged.Selected.TTS]; p.SelectedTTS != "" && !ok {
+		return errors.New(errors.KindDomain, "tenant.providers", "selected tts config not found: "+p.SelectedTTS)
+	}
+	return nil
+}
+
+// Masked 返回密钥脱敏后的副本
+func (p Providers) Masked() Providers {
+	masked := p
+	masked.LLM = make(map[string]config.LLMConfig, len(p.LLM))
+	for name, c := range p.LLM {
+		if c.APIKey != "" {
+			c.APIKey = maskedSecret
+		}
+		masked.LLM[name] = c
+	}
+	masked.TTS = make(map[string]config.TTSConfig, len(p.TTS))
+	for name, c := range p.TTS {
+		if c.Token != "" {
+			c.Token = maskedSecret
+		}
+		masked.TTS[name] = c
+	}
+	return masked
+}
+
+// keepSecrets 密钥为脱敏占位值时沿用 current 中同名条目的原值
+func (p Providers) keepSecrets(current Providers) Providers {
+	kept := p
+	kept.LLM = make(map[string]config.LLMConfig, len(p.LLM))
+	for name, c := range p.LLM {
+		if c.APIKey == maskedSecret {
+			c.APIKey = current.LLM[name].APIKey
+		}
+		kept.LLM[name] = c
+	}
+	kept.TTS = make(map[string]config.TTSConfig, len(p.TTS))
+	for name, c := range p.TTS {
+		if c.Token == maskedSecret {
+			c.Token = current.TTS[name].Token
+		}
+		kept.TTS[name] = c
+	}
+	return kept
+}
+
+// SetProviders 替换租户的供应商配置，base 为实例配置，用于检查选用的配置名称
+func (s *Service) SetProviders(ctx context.Context, id string, p Providers, base *config.Config) (*Tenant, error) {
+	current, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	p = p.keepSecrets(current.Providers)
+	if err := p.Validate(base); err != nil {
+		return nil, err
+	}
+	t := *current
+	t.Providers = p
+	t.UpdatedAt = s.now()
+	if err := s.repo.Save(ctx, &t); err != nil {
+		return nil, err
+	}
+	s.put(&t)
+	s.logger.InfoTag("租户", "租户 %s 的供应商配置已更新", id)
+	return &t, nil
+}
+
+// ProvidersFor 返回上下文中租户的供应商配置，租户不存在或加载失败时返回空配置
+func (s *Service) ProvidersFor(ctx context.Context) Providers {
+	t, err := s.Get(ctx, IDFrom(ctx))
+	if err != nil {
+		return Providers{}
+	}
+	return t.Providers
+}
@@ -0,0 +1,186 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+// memoryRepo 只保存租户的内存仓库
+type memoryRepo struct {
+	Repository
+	tenants map[string]*Tenant
+}
+
+func (r *memoryRepo) Save(ctx context.Context, t *Tenant) error {
+	copied := *t
+	r.tenants[t.ID] = &copied
+	return nil
+}
+
+func (r *memoryRepo) List(ctx context.Context) ([]*Tenant, error) {
+	items := make([]*Tenant, 0, len(r.tenants))
+	for _, t := range r.tenants {
+		items = append(items, t)
+	}
+	return items, nil
+}
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+	logger, err := logging.New(logging.Config{Level: "error", Dir: t.TempDir(), Filename: "test.log"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewService(&memoryRepo{tenants: map[string]*Tenant{}}, logger)
+	if err := s.EnsureDefault(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func baseConfig() *config.Config {
+	return &config.Config{
+		Selected: config.SelectedConfig{LLM: "shared", TTS: "edge", ASR: "doubao"},
+		LLM: map[string]config.LLMConfig{
+			"shared": {Type: "openai", ModelName: "gpt-4o-mini", APIKey: "instance-key"},
+		},
+		TTS: map[string]config.TTSConfig{
+			"edge": {Type: "edge", Voice: "zh-CN-XiaoxiaoNeural"},
+		},
+	}
+}
+
+func TestProvidersApply(t *testing.T) {
+	base := baseConfig()
+	if got := (Providers{}).Apply(base); got != base {
+		t.Fatalf("empty providers should return the instance config")
+	}
+
+	p := Providers{
+		SelectedLLM: "acme",
+		LLM: map[string]config.LLMConfig{
+			"acme":   {Type: "openai", APIKey: "acme-key"},
+			"shared": {Type: "ollama", ModelName: "qwen"},
+		},
+	}
+	merged := p.Apply(base)
+	if merged.Selected.LLM != "acme" || merged.Selected.TTS != "edge" || merged.Selected.ASR != "doubao" {
+		t.Fatalf("selected = %+v", merged.Selected)
+	}
+	if merged.LLM["acme"].APIKey != "acme-key" || merged.LLM["shared"].Type != "ollama" {
+		t.Fatalf("llm = %+v", merged.LLM)
+	}
+	if merged.TTS["edge"].Voice != "zh-CN-XiaoxiaoNeural" {
+		t.Fatalf("tts = %+v", merged.TTS)
+	}
+	// 实例配置不受影响
+	if base.Selected.LLM != "shared" || base.LLM["shared"].Type != "openai" || len(base.LLM) != 1 {
+		t.Fatalf("instance config modified: %+v", base)
+	}
+}
+
+func TestProvidersValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		p     Providers
+		valid bool
+	}{
+		{"empty", Providers{}, true},
+		{"select instance llm", Providers{SelectedLLM: "shared"}, true},
+		{"select own llm", Providers{SelectedLLM: "acme", LLM: map[string]config.LLMConfig{"acme": {Type: "openai"}}}, true},
+		{"select own tts", Providers{SelectedTTS: "acme", TTS: map[string]config.TTSConfig{"acme": {Type: "doubao"}}}, true},
+		{"unknown llm", Providers{SelectedLLM: "missing"}, false},
+		{"unknown tts", Providers{SelectedTTS: "missing"}, false},
+		{"llm without type", Providers{LLM: map[string]config.LLMConfig{"acme": {}}}, false},
+		{"tts without type", Providers{TTS: map[string]config.TTSConfig{"acme": {}}}, false},
+		{"blank name", Providers{LLM: map[string]config.LLMConfig{" ": {Type: "openai"}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.p.Validate(baseConfig())
+			if (err == nil) != tt.valid {
+				t.Fatalf("Validate() = %v, valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestSetProvidersKeepsMaskedSecrets(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	if _, _, err := s.Create(ctx, CreateRequest{ID: "acme"}); err != nil {
+		t.Fatal(err)
+	}
+
+	first := Providers{
+		SelectedLLM: "acme",
+		LLM:         map[string]config.LLMConfig{"acme": {Type: "openai", APIKey: "acme-key"}},
+		TTS:         map[string]config.TTSConfig{"voice": {Type: "doubao", Token: "tts-token"}},
+	}
+	if _, err := s.SetProviders(ctx, "acme", first, baseConfig()); err != nil {
+		t.Fatal(err)
+	}
+
+	// 读取到的脱敏配置原样写回时保留密钥
+	current, err := s.Get(ctx, "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	masked := current.Providers.Masked()
+	if masked.LLM["acme"].APIKey != maskedSecret || masked.TTS["voice"].Token != maskedSecret {
+		t.Fatalf("masked = %+v", masked)
+	}
+	masked.LLM["acme"] = config.LLMConfig{Type: "openai", ModelName: "gpt-4o", APIKey: maskedSecret}
+	updated, err := s.SetProviders(ctx, "acme", masked, baseConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := updated.Providers.LLM["acme"]; got.APIKey != "acme-key" || got.ModelName != "gpt-4o" {
+		t.Fatalf("llm = %+v", got)
+	}
+	if got := updated.Providers.TTS["voice"]; got.Token != "tts-token" {
+		t.Fatalf("tts = %+v", got)
+	}
+
+	// 新条目不能借用占位值
+	masked.LLM["other"] = config.LLMConfig{Type: "openai", APIKey: maskedSecret}
+	updated, err = s.SetProviders(ctx, "acme", masked, baseConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := updated.Providers.LLM["other"].APIKey; got != "" {
+		t.Fatalf("new entry api key = %q", got)
+	}
+
+	if _, err := s.SetProviders(ctx, "acme", Providers{SelectedLLM: "missing"}, baseConfig()); err == nil {
+		t.Fatalf("expected error for unknown selection")
+	}
+
+	// 只有设备所属租户的配置生效
+	if got := s.ProvidersFor(WithID(ctx, "acme")); got.SelectedLLM != "acme" {
+		t.Fatalf("acme providers = %+v", got)
+	}
+	if got := s.ProvidersFor(ctx); !got.Empty() {
+		t.Fatalf("default providers = %+v", got)
+	}
+	if got := s.ProvidersFor(WithID(ctx, "nobody")); !got.Empty() {
+		t.Fatalf("unknown tenant providers = %+v", got)
+	}
+}
+
+func TestMultiTenant(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	if s.MultiTenant(ctx) {
+		t.Fatalf("only the default tenant exists")
+	}
+	if _, _, err := s.Create(ctx, CreateRequest{ID: "acme"}); err != nil {
+		t.Fatal(err)
+	}
+	if !s.MultiTenant(ctx) {
+		t.Fatalf("acme exists")
+	}
+}
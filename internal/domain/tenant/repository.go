@@ -0,0 +1,26 @@
+package tenant
+
+import (
+	"context"
+)
+
+// Repository 租户仓库接口
+type Repository interface {
+	// Save 创建或更新租户
+	Save(ctx context.Context, t *Tenant) error
+
+	// List 列出全部租户，按ID排序
+	List(ctx context.Context) ([]*Tenant, error)
+
+	// Delete 删除租户及其用量记录
+	Delete(ctx context.Context, id string) error
+
+	// AddUsage 累加租户某一天的用量
+	AddUsage(ctx context.Context, id, day string, requests, tokens int64) error
+
+	// GetUsage 查询租户某一天的用量，没有记录时返回零值
+	GetUsage(ctx context.Context, id, day string) (*Usage, error)
+
+	// CountDevices 统计租户的设备数
+	CountDevices(ctx context.Context, id string) (int64, error)
+}
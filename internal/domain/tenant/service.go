@@ -0,0 +1,426 @@
+package tenant
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+var (
+	// ErrNotFound 租户不存在
+	ErrNotFound = stderrors.New("tenant not found")
+	// ErrInvalidToken 租户令牌无效
+	ErrInvalidToken = stderrors.New("invalid tenant token")
+	// ErrDisabled 租户已停用
+	ErrDisabled = stderrors.New("tenant disabled")
+	// ErrDeviceQuota 租户设备数已达上限
+	ErrDeviceQuota = stderrors.New("tenant device quota exceeded")
+)
+
+var idPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// tokenPrefix 租户令牌前缀，便于在日志和配置中识别
+const tokenPrefix = "xzt_"
+
+// CreateRequest 创建租户请求
+type CreateRequest struct {
+	ID    string
+	Name  string
+	Quota Quota
+}
+
+// UpdateRequest 修改租户请求，nil 字段保持不变
+type UpdateRequest struct {
+	Name    *string
+	Enabled *bool
+	Quota   *Quota
+}
+
+// Service 租户服务
+// 租户数量通常很少，全部缓存在内存中，认证和限流不访问数据库
+type Service struct {
+	repo   Repository
+	logger *logging.Logger
+	now    func() time.Time
+
+	mu      sync.RWMutex
+	loaded  bool
+	tenants map[string]*Tenant
+	byToken map[string]string // 令牌哈希 -> 租户ID
+
+	usageMu sync.Mutex
+	usage   map[string]*dailyUsage
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局租户服务，供连接处理器使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局租户服务，未初始化时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建租户服务
+func NewService(repo Repository, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Service{
+		repo:    repo,
+		logger:  logger,
+		now:     time.Now,
+		tenants: make(map[string]*Tenant),
+		byToken: make(map[string]string),
+		usage:   make(map[string]*dailyUsage),
+	}
+}
+
+// EnsureDefault 默认租户不存在时创建，升级前的数据都归属于它
+func (s *Service) EnsureDefault(ctx context.Context) error {
+	if err := s.load(ctx); err != nil {
+		return err
+	}
+	s.mu.RLock()
+	_, ok := s.tenants[DefaultID]
+	s.mu.RUnlock()
+	if ok {
+		return nil
+	}
+	now := s.now()
+	t := &Tenant{ID: DefaultID, Name: "默认租户", Enabled: true, CreatedAt: now, UpdatedAt: now}
+	if err := s.repo.Save(ctx, t); err != nil {
+		return err
+	}
+	s.put(t)
+	return nil
+}
+
+// Create 创建租户并签发访问令牌，令牌明文只在此时返回
+func (s *Service) Create(ctx context.Context, req CreateRequest) (*Tenant, string, error) {
+	if !idPattern.MatchString(req.ID) {
+		return nil, "", errors.New(errors.KindDomain, "tenant.create", "invalid tenant id: "+req.ID)
+	}
+	if err := validateQuota(req.Quota); err != nil {
+		return nil, "", err
+	}
+	if err := s.load(ctx); err != nil {
+		return nil, "", err
+	}
+	if _, err := s.Get(ctx, req.ID); err == nil {
+		return nil, "", errors.New(errors.KindDomain, "tenant.create", "tenant already exists: "+req.ID)
+	}
+
+	token, hash, err := newToken()
+	if err != nil {
+		return nil, "", errors.Wrap(errors.KindDomain, "tenant.create", "failed to generate token", err)
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = req.ID
+	}
+	now := s.now()
+	t := &Tenant{
+		ID:        req.ID,
+		Name:      name,
+		Enabled:   true,
+		Quota:     req.Quota,
+		TokenHash: hash,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.repo.Save(ctx, t); err != nil {
+		return nil, "", err
+	}
+	s.put(t)
+	s.logger.InfoTag("租户", "已创建租户 %s", t.ID)
+	return t, token, nil
+}
+
+// Update 修改租户名称、启用状态或配额
+func (s *Service) Update(ctx context.Context, id string, req UpdateRequest) (*Tenant, error) {
+	current, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	t := *current
+	if req.Name != nil && strings.TrimSpace(*req.Name) != "" {
+		t.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Enabled != nil {
+		if !*req.Enabled && id == DefaultID {
+			return nil, errors.New(errors.KindDomain, "tenant.update", "default tenant cannot be disabled")
+		}
+		t.Enabled = *req.Enabled
+	}
+	if req.Quota != nil {
+		if err := validateQuota(*req.Quota); err != nil {
+			return nil, err
+		}
+		t.Quota = *req.Quota
+	}
+	t.UpdatedAt = s.now()
+	if err := s.repo.Save(ctx, &t); err != nil {
+		return nil, err
+	}
+	s.put(&t)
+	return &t, nil
+}
+
+// RotateToken 重新签发访问令牌，旧令牌立即失效
+func (s *Service) RotateToken(ctx context.Context, id string) (string, error) {
+	current, err := s.Get(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	token, hash, err := newToken()
+	if err != nil {
+		return "", errors.Wrap(errors.KindDomain, "tenant.rotate_token", "failed to generate token", err)
+	}
+	t := *current
+	t.TokenHash = hash
+	t.UpdatedAt = s.now()
+	if err := s.repo.Save(ctx, &t); err != nil {
+		return "", err
+	}
+	s.put(&t)
+	s.logger.InfoTag("租户", "租户 %s 的访问令牌已轮换", id)
+	return token, nil
+}
+
+// Get 获取租户
+func (s *Service) Get(ctx context.Context, id string) (*Tenant, error) {
+	if err := s.load(ctx); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	t, ok := s.tenants[id]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, errors.Wrap(errors.KindDomain, "tenant.get", "tenant not found: "+id, ErrNotFound)
+	}
+	copied := *t
+	return &copied, nil
+}
+
+// List 列出全部租户
+func (s *Service) List(ctx context.Context) ([]*Tenant, error) {
+	if err := s.load(ctx); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	items := make([]*Tenant, 0, len(s.tenants))
+	for _, t := range s.tenants {
+		copied := *t
+		items = append(items, &copied)
+	}
+	s.mu.RUnlock()
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	return items, nil
+}
+
+// Delete 删除租户，仍有设备的租户不能删除
+func (s *Service) Delete(ctx context.Context, id string) error {
+	if id == DefaultID {
+		return errors.New(errors.KindDomain, "tenant.delete", "default tenant cannot be deleted")
+	}
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+	devices, err := s.repo.CountDevices(ctx, id)
+	if err != nil {
+		return err
+	}
+	if devices > 0 {
+		return errors.New(errors.KindDomain, "tenant.delete", "tenant still owns devices, move or delete them first")
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	if t, ok := s.tenants[id]; ok {
+		delete(s.byToken, t.TokenHash)
+		delete(s.tenants, id)
+	}
+	s.mu.Unlock()
+	s.usageMu.Lock()
+	delete(s.usage, id)
+	s.usageMu.Unlock()
+	s.logger.InfoTag("租户", "已删除租户 %s", id)
+	return nil
+}
+
+// Authenticate 按访问令牌识别租户
+func (s *Service) Authenticate(ctx context.Context, token string) (*Tenant, error) {
+	if !strings.HasPrefix(token, tokenPrefix) {
+		return nil, ErrInvalidToken
+	}
+	if err := s.load(ctx); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	id, ok := s.byToken[hashToken(token)]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	t, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !t.Enabled {
+		return nil, ErrDisabled
+	}
+	return t, nil
+}
+
+// MultiTenant 判断是否已创建默认租户以外的租户；加载失败时按多租户处理，以拒绝未认证的请求
+func (s *Service) MultiTenant(ctx context.Context) bool {
+	if err := s.load(ctx); err != nil {
+		return true
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for id := range s.tenants {
+		if id != DefaultID {
+			return true
+		}
+	}
+	return false
+}
+
+// Active 判断租户是否存在且已启用；加载失败时放行，避免数据库故障导致设备无法连接
+func (s *Service) Active(ctx context.Context, id string) bool {
+	t, err := s.Get(ctx, id)
+	if stderrors.Is(err, ErrNotFound) {
+		return false
+	}
+	return err != nil || t.Enabled
+}
+
+// CheckDeviceQuota 检查租户是否还能添加设备
+func (s *Service) CheckDeviceQuota(ctx context.Context, id string) error {
+	t, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !t.Enabled {
+		return errors.Wrap(errors.KindDomain, "tenant.device_quota", "tenant disabled: "+id, ErrDisabled)
+	}
+	if t.Quota.MaxDevices <= 0 {
+		return nil
+	}
+	devices, err := s.repo.CountDevices(ctx, id)
+	if err != nil {
+		return err
+	}
+	if devices >= int64(t.Quota.MaxDevices) {
+		return errors.Wrap(errors.KindDomain, "tenant.device_quota", "device limit reached for tenant "+id, ErrDeviceQuota)
+	}
+	return nil
+}
+
+// Usage 查询租户某一天（YYYY-MM-DD，空表示今天）的用量和当前设备数
+func (s *Service) Usage(ctx context.Context, id, day string) (*Usage, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	if day == "" {
+		day = s.now().Format(dayLayout)
+	} else if _, err := time.Parse(dayLayout, day); err != nil {
+		return nil, errors.New(errors.KindDomain, "tenant.usage", "day must be in YYYY-MM-DD format")
+	}
+
+	usage, err := s.repo.GetUsage(ctx, id, day)
+	if err != nil {
+		return nil, err
+	}
+	if usage.Devices, err = s.repo.CountDevices(ctx, id); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// load 首次使用时加载全部租户
+func (s *Service) load(ctx context.Context) error {
+	s.mu.RLock()
+	loaded := s.loaded
+	s.mu.RUnlock()
+	if loaded {
+		return nil
+	}
+
+	tenants, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.loaded {
+		return nil
+	}
+	for _, t := range tenants {
+		s.tenants[t.ID] = t
+		if t.TokenHash != "" {
+			s.byToken[t.TokenHash] = t.ID
+		}
+	}
+	s.loaded = true
+	return nil
+}
+
+// put 更新缓存
+func (s *Service) put(t *Tenant) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if old, ok := s.tenants[t.ID]; ok {
+		delete(s.byToken, old.TokenHash)
+	}
+	s.tenants[t.ID] = t
+	if t.TokenHash != "" {
+		s.byToken[t.TokenHash] = t.ID
+	}
+}
+
+func validateQuota(q Quota) error {
+	if q.MaxDevices < 0 || q.DailyRequests < 0 || q.DailyTokens < 0 {
+		return errors.New(errors.KindDomain, "tenant.quota", "limits cannot be negative")
+	}
+	return nil
+}
+
+// newToken 生成访问令牌及其哈希
+func newToken() (token, hash string, err error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token = tokenPrefix + hex.EncodeToString(buf)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,47 @@
+package toolpolicy
+
+import "time"
+
+// Kind 受策略约束的调用类型
+type Kind string
+
+const (
+	KindTool       Kind = "tool"       // MCP 工具
+	KindCapability Kind = "capability" // 插件能力
+)
+
+// Decision 一次权限检查的结果
+type Decision struct {
+	Allowed bool
+	Profile string // 参与检查的策略，如 device:default、agent:researcher，多个以逗号分隔
+	Rule    string // 做出决定的规则，多个以逗号分隔，为空表示使用策略的默认动作
+	Reason  string
+}
+
+// AuditRecord 一次工具或能力调用的审计记录
+type AuditRecord struct {
+	ID        string    `json:"id"`
+	DeviceID  string    `json:"device_id"`
+	SessionID string    `json:"session_id"`
+	Agent     string    `json:"agent"`
+	Kind      Kind      `json:"kind"`
+	Name      string    `json:"name"`
+	Arguments string    `json:"arguments"` // JSON
+	Allowed   bool      `json:"allowed"`
+	Profile   string    `json:"profile"`
+	Rule      string    `json:"rule"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuditFilter 审计记录查询条件
+type AuditFilter struct {
+	DeviceID string
+	Agent    string
+	Name     string
+	Allowed  *bool // nil 表示不按结果过滤
+	From     time.Time
+	To       time.Time
+	Page     int
+	PageSize int
+}
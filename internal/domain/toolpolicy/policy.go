@@ -0,0 +1,307 @@
+// Package toolpolicy 工具调用权限策略
+//
+// 设备对话、智能体和工作流发起的 MCP 工具和能力调用在分发前按调用方所属的策略检查，
+// 规则可以限制工具名称和参数取值（如设备控制只允许操作设备所在的房间），每次检查的结果记入审计日志。
+package toolpolicy
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"xiaozhi-server-go/internal/platform/config"
+)
+
+const defaultProfile = "default"
+
+// Subject 发起调用的设备会话和智能体
+type Subject struct {
+	DeviceID  string
+	SessionID string
+	Agent     string // 正在运行的智能体，设备对话中直接调用时为空
+}
+
+// fromConnection 调用来自设备连接或文本对话会话，此时按设备策略检查
+func (s Subject) fromConnection() bool {
+	return s.DeviceID != "" || s.SessionID != ""
+}
+
+type subjectKey struct{}
+
+// WithSubject 在上下文中标记调用方
+func WithSubject(ctx context.Context, s Subject) context.Context {
+	return context.WithValue(ctx, subjectKey{}, s)
+}
+
+// WithAgent 在上下文中标记正在运行的智能体，保留原有的设备会话
+func WithAgent(ctx context.Context, agent string) context.Context {
+	s, _ := SubjectFrom(ctx)
+	s.Agent = agent
+	return WithSubject(ctx, s)
+}
+
+// SubjectFrom 读取上下文中的调用方，未标记时（管理接口、批处理任务）返回 false
+func SubjectFrom(ctx context.Context) (Subject, bool) {
+	s, ok := ctx.Value(subjectKey{}).(Subject)
+	return s, ok
+}
+
+type rule struct {
+	name  string
+	kinds map[Kind]bool // 为空表示全部类型
+	match []string
+	allow bool
+	args  map[string][]string
+}
+
+type profile struct {
+	defaultAllow bool
+	rules        []rule
+}
+
+// Policy 编译后的策略集合
+type Policy struct {
+	profiles       map[string]profile
+	deviceProfiles map[string]string
+	agentProfiles  map[string]string
+	attributes     map[string]map[string]string
+}
+
+// NewPolicy 根据配置编译策略，动作或通配符无效时返回错误
+func NewPolicy(cfg config.ToolPolicyConfig) (*Policy, error) {
+	p := &Policy{
+		profiles:       make(map[string]profile, len(cfg.Profiles)),
+		deviceProfiles: cfg.DeviceProfiles,
+		agentProfiles:  cfg.AgentProfiles,
+		attributes:     cfg.DeviceAttributes,
+	}
+	for name, pc := range cfg.Profiles {
+		defaultAllow, err := parseAction(pc.DefaultAction, true)
+		if err != nil {
+			return nil, fmt.Errorf("tool policy %s: %w", name, err)
+		}
+		compiled := profile{defaultAllow: defaultAllow}
+		for i, rc := range pc.Rules {
+			r, err := compileRule(rc)
+			if err != nil {
+				return nil, fmt.Errorf("tool policy %s rule %d: %w", name, i+1, err)
+			}
+			if r.name == "" {
+				r.name = fmt.Sprintf("#%d", i+1)
+			}
+			compiled.rules = append(compiled.rules, r)
+		}
+		p.profiles[name] = compiled
+	}
+	for deviceID, name := range cfg.DeviceProfiles {
+		if _, ok := p.profiles[name]; !ok {
+			return nil, fmt.Errorf("device %s uses unknown tool policy %s", deviceID, name)
+		}
+	}
+	for agent, name := range cfg.AgentProfiles {
+		if _, ok := p.profiles[name]; !ok {
+			return nil, fmt.Errorf("agent %s uses unknown tool policy %s", agent, name)
+		}
+	}
+	return p, nil
+}
+
+func compileRule(rc config.ToolPolicyRule) (rule, error) {
+	allow, err := parseAction(rc.Action, false)
+	if err != nil {
+		return rule{}, err
+	}
+	if len(rc.Match) == 0 {
+		return rule{}, fmt.Errorf("match is required")
+	}
+	for _, pattern := range rc.Match {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return rule{}, fmt.Errorf("invalid pattern %q", pattern)
+		}
+	}
+	r := rule{name: rc.Name, match: rc.Match, allow: allow, args: rc.Args}
+	switch Kind(rc.Kind) {
+	case "":
+	case KindTool, KindCapability:
+		r.kinds = map[Kind]bool{Kind(rc.Kind): true}
+	default:
+		return rule{}, fmt.Errorf("unknown kind %q", rc.Kind)
+	}
+	return r, nil
+}
+
+func parseAction(action string, empty bool) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(action)) {
+	case "":
+		return empty, nil
+	case "allow":
+		return true, nil
+	case "deny":
+		return false, nil
+	default:
+		return false, fmt.Errorf("unknown action %q", action)
+	}
+}
+
+// Evaluate 检查调用方能否以给定参数调用工具或能力
+// 设备会话按设备策略检查，运行中的智能体另按智能体策略检查，全部允许时才放行
+func (p *Policy) Evaluate(subject Subject, kind Kind, name string, args map[string]interface{}) Decision {
+	var checked, rules []string
+	if subject.fromConnection() {
+		profileName, ok := p.deviceProfiles[subject.DeviceID]
+		if !ok {
+			profileName = defaultProfile
+		}
+		if prof, exists := p.profiles[profileName]; exists {
+			label := "device:" + profileName
+			checked = append(checked, label)
+			d := p.evaluateProfile(prof, label, subject, kind, name, args)
+			if !d.Allowed {
+				return d
+			}
+			if d.Rule != "" {
+				rules = append(rules, d.Rule)
+			}
+		}
+	}
+	if subject.Agent != "" {
+		if profileName, ok := p.agentProfiles[subject.Agent]; ok {
+			label := "agent:" + profileName
+			checked = append(checked, label)
+			d := p.evaluateProfile(p.profiles[profileName], label, subject, kind, name, args)
+			if !d.Allowed {
+				return d
+			}
+			if d.Rule != "" {
+				rules = append(rules, d.Rule)
+			}
+		}
+	}
+	return Decision{Allowed: true, Profile: strings.Join(checked, ","), Rule: strings.Join(rules, ",")}
+}
+
+func (p *Policy) evaluateProfile(prof profile, label string, subject Subject, kind Kind, name string, args map[string]interface{}) Decision {
+	for _, r := range prof.rules {
+		if !r.matches(kind, name) {
+			continue
+		}
+		d := Decision{Allowed: r.allow, Profile: label, Rule: r.name}
+		if !r.allow {
+			d.Reason = fmt.Sprintf("策略 %s 的规则 %s 禁止调用 %s", label, r.name, name)
+			return d
+		}
+		if reason := p.checkArgs(r, subject, args); reason != "" {
+			d.Allowed = false
+			d.Reason = fmt.Sprintf("策略 %s 的规则 %s 不允许该参数：%s", label, r.name, reason)
+		}
+		return d
+	}
+	d := Decision{Allowed: prof.defaultAllow, Profile: label}
+	if !d.Allowed {
+		d.Reason = fmt.Sprintf("策略 %s 未允许调用 %s", label, name)
+	}
+	return d
+}
+
+func (r rule) matches(kind Kind, name string) bool {
+	if r.kinds != nil && !r.kinds[kind] {
+		return false
+	}
+	for _, pattern := range r.match {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// checkArgs 检查参数约束，不满足时返回原因
+func (p *Policy) checkArgs(r rule, subject Subject, args map[string]interface{}) string {
+	keys := make([]string, 0, len(r.args))
+	for key := range r.args {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, ok := args[key]
+		if !ok || value == nil {
+			return fmt.Sprintf("缺少参数 %s", key)
+		}
+		actual := argString(value)
+		allowed := false
+		for _, pattern := range r.args[key] {
+			expanded := p.expand(pattern, subject)
+			if expanded == "" {
+				continue
+			}
+			if ok, _ := path.Match(expanded, actual); ok {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Sprintf("%s=%s", key, actual)
+		}
+	}
+	return ""
+}
+
+// expand 替换约束中的 ${device_id}、${agent} 和 ${device.属性}，引用的值不存在时返回空
+func (p *Policy) expand(pattern string, subject Subject) string {
+	missing := false
+	result := expandVars(pattern, func(name string) string {
+		var value string
+		switch {
+		case name == "device_id":
+			value = subject.DeviceID
+		case name == "agent":
+			value = subject.Agent
+		case strings.HasPrefix(name, "device."):
+			value = p.attributes[subject.DeviceID][strings.TrimPrefix(name, "device.")]
+		}
+		if value == "" {
+			missing = true
+		}
+		return value
+	})
+	if missing {
+		return ""
+	}
+	return result
+}
+
+// expandVars 只替换 ${name} 形式的引用，保留其他 $ 字符
+func expandVars(s string, mapping func(string) string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		end := strings.Index(s[start:], "}")
+		if end < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		b.WriteString(s[:start])
+		b.WriteString(mapping(s[start+2 : start+end]))
+		s = s[start+end+1:]
+	}
+}
+
+// argString 把参数值转换为用于匹配的字符串，整数形式的数字不带小数
+func argString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,21 @@
+package toolpolicy
+
+import (
+	"context"
+	"time"
+)
+
+// Repository 审计记录仓库接口
+type Repository interface {
+	// SaveBatch 批量写入审计记录
+	SaveBatch(ctx context.Context, records []*AuditRecord) error
+
+	// List 按条件分页查询审计记录，新记录在前
+	List(ctx context.Context, filter AuditFilter) ([]*AuditRecord, int64, error)
+
+	// DeleteBefore 删除早于指定时间的审计记录
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+
+	// DeleteByDevice 删除设备的全部审计记录
+	DeleteByDevice(ctx context.Context, deviceID string) (int64, error)
+}
@@ -0,0 +1,249 @@
+package toolpolicy
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/platform/config"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/observability"
+)
+
+const (
+	queueSize      = 1024
+	flushBatchSize = 50
+	flushInterval  = time.Second
+	purgeInterval  = time.Hour
+	// maxAuditArgs 审计记录中参数 JSON 的长度上限
+	maxAuditArgs = 2048
+)
+
+// ErrDenied 调用被策略拒绝，可通过 errors.Is 判断
+var ErrDenied = stderrors.New("call denied by tool policy")
+
+// DeniedError 调用被拒绝的详细原因
+type DeniedError struct {
+	Kind    Kind
+	Name    string
+	Profile string
+	Rule    string
+	Reason  string
+}
+
+func (e *DeniedError) Error() string {
+	return fmt.Sprintf("%s %s denied: %s", e.Kind, e.Name, e.Reason)
+}
+
+// Is 使 errors.Is(err, ErrDenied) 成立
+func (e *DeniedError) Is(target error) bool {
+	return target == ErrDenied
+}
+
+// UserCategory 设备侧提示为无权限执行
+func (e *DeniedError) UserCategory() platformerrors.Category {
+	return platformerrors.CategoryUnauthorizedTool
+}
+
+// Service 工具调用权限服务，在分发前检查调用并异步写入审计日志
+type Service struct {
+	policy    *Policy
+	repo      Repository
+	logger    *logging.Logger
+	retention time.Duration // <=0 表示永久保留
+	now       func() time.Time
+
+	queue chan *AuditRecord
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局工具调用权限服务，供连接处理器和智能体使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局工具调用权限服务，未启用时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// Authorize 使用全局服务检查调用，未启用权限策略时放行
+func Authorize(ctx context.Context, kind Kind, name string, args map[string]interface{}) error {
+	s := Default()
+	if s == nil {
+		return nil
+	}
+	return s.Authorize(ctx, kind, name, args)
+}
+
+// NewService 根据配置创建工具调用权限服务，策略配置无效时返回错误
+func NewService(cfg config.ToolPolicyConfig, repo Repository, logger *logging.Logger) (*Service, error) {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	policy, err := NewPolicy(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		policy:    policy,
+		repo:      repo,
+		logger:    logger,
+		retention: time.Duration(cfg.AuditRetentionDays) * 24 * time.Hour,
+		now:       time.Now,
+		queue:     make(chan *AuditRecord, queueSize),
+	}, nil
+}
+
+// Authorize 按上下文中的调用方检查调用并记入审计日志，被拒绝时返回 *DeniedError
+// 上下文中没有调用方（管理接口、批处理任务）时不检查
+func (s *Service) Authorize(ctx context.Context, kind Kind, name string, args map[string]interface{}) error {
+	subject, ok := SubjectFrom(ctx)
+	if !ok {
+		return nil
+	}
+	d := s.policy.Evaluate(subject, kind, name, args)
+	s.audit(subject, kind, name, args, d)
+
+	decision := "allow"
+	if !d.Allowed {
+		decision = "deny"
+	}
+	observability.RecordMetric(ctx, "tool_policy_decisions_total", 1, map[string]string{"kind": string(kind), "decision": decision})
+	if d.Allowed {
+		return nil
+	}
+	s.logger.WarnTag("工具策略", "拒绝设备 %s 智能体 %s 调用 %s: %s", subject.DeviceID, subject.Agent, name, d.Reason)
+	return &DeniedError{Kind: kind, Name: name, Profile: d.Profile, Rule: d.Rule, Reason: d.Reason}
+}
+
+// AuthorizeCapability 实现 capability.Authorizer
+func (s *Service) AuthorizeCapability(ctx context.Context, capabilityID string, inputs map[string]interface{}) error {
+	return s.Authorize(ctx, KindCapability, capabilityID, inputs)
+}
+
+// Evaluate 试算调用方能否调用，不记入审计日志
+func (s *Service) Evaluate(subject Subject, kind Kind, name string, args map[string]interface{}) Decision {
+	return s.policy.Evaluate(subject, kind, name, args)
+}
+
+// ListAudit 分页查询审计记录
+func (s *Service) ListAudit(ctx context.Context, filter AuditFilter) ([]*AuditRecord, int64, error) {
+	return s.repo.List(ctx, filter)
+}
+
+// EraseDeviceData 删除设备的全部审计记录
+func (s *Service) EraseDeviceData(ctx context.Context, deviceID string) (int64, error) {
+	return s.repo.DeleteByDevice(ctx, deviceID)
+}
+
+// audit 记录一次检查，不阻塞调用方；队列满时丢弃并记录日志
+func (s *Service) audit(subject Subject, kind Kind, name string, args map[string]interface{}, d Decision) {
+	arguments := ""
+	if len(args) > 0 {
+		if data, err := json.Marshal(args); err == nil {
+			arguments = string(data)
+		}
+		if len(arguments) > maxAuditArgs {
+			arguments = arguments[:maxAuditArgs]
+		}
+	}
+	record := &AuditRecord{
+		ID:        uuid.New().String(),
+		DeviceID:  subject.DeviceID,
+		SessionID: subject.SessionID,
+		Agent:     subject.Agent,
+		Kind:      kind,
+		Name:      name,
+		Arguments: arguments,
+		Allowed:   d.Allowed,
+		Profile:   d.Profile,
+		Rule:      d.Rule,
+		Reason:    d.Reason,
+		CreatedAt: s.now(),
+	}
+	select {
+	case s.queue <- record:
+	default:
+		s.logger.WarnTag("工具策略", "审计队列已满，丢弃 %s 的审计记录", name)
+	}
+}
+
+// Run 启动审计写入和清理循环，直到 ctx 结束；退出前写入剩余记录
+func (s *Service) Run(ctx context.Context) error {
+	flushTicker := time.NewTicker(flushInterval)
+	defer flushTicker.Stop()
+	purgeTicker := time.NewTicker(purgeInterval)
+	defer purgeTicker.Stop()
+
+	s.purge(ctx)
+
+	batch := make([]*AuditRecord, 0, flushBatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+		drain:
+			for {
+				select {
+				case record := <-s.queue:
+					batch = append(batch, record)
+				default:
+					break drain
+				}
+			}
+			s.flush(context.Background(), batch)
+			return nil
+		case record := <-s.queue:
+			batch = append(batch, record)
+			if len(batch) >= flushBatchSize {
+				s.flush(ctx, batch)
+				batch = batch[:0]
+			}
+		case <-flushTicker.C:
+			if len(batch) > 0 {
+				s.flush(ctx, batch)
+				batch = batch[:0]
+			}
+		case <-purgeTicker.C:
+			s.purge(ctx)
+		}
+	}
+}
+
+func (s *Service) flush(ctx context.Context, batch []*AuditRecord) {
+	if len(batch) == 0 {
+		return
+	}
+	if err := s.repo.SaveBatch(ctx, batch); err != nil {
+		s.logger.ErrorTag("工具策略", "写入 %d 条审计记录失败: %v", len(batch), err)
+	}
+}
+
+// purge 按保留策略删除过期审计记录
+func (s *Service) purge(ctx context.Context) {
+	if s.retention <= 0 {
+		return
+	}
+	removed, err := s.repo.DeleteBefore(ctx, s.now().Add(-s.retention))
+	if err != nil {
+		s.logger.ErrorTag("工具策略", "清理过期审计记录失败: %v", err)
+		return
+	}
+	if removed > 0 {
+		s.logger.InfoTag("工具策略", "已清理 %d 条过期审计记录", removed)
+	}
+}
@@ -0,0 +1,167 @@
+package transcript
+
+import (
+	"sort"
+	"time"
+)
+
+// LatencyStage 对话轮次的耗时阶段
+type LatencyStage string
+
+const (
+	StageCapture       LatencyStage = "capture"         // 开始说话 → VAD 判定说话结束
+	StageASR           LatencyStage = "asr"             // 说话结束 → 识别结果
+	StageLLMFirstToken LatencyStage = "llm_first_token" // 识别结果 → LLM 首个输出
+	StageLLMComplete   LatencyStage = "llm_complete"    // 识别结果 → LLM 输出结束
+	StageTTSFirstByte  LatencyStage = "tts_first_byte"  // 首句送入 TTS → 首句音频合成完成
+	StagePlayback      LatencyStage = "playback_start"  // 识别结果 → 首帧音频下发
+	StageTotal         LatencyStage = "total"           // 说话结束 → 首帧音频下发，即用户感受到的响应延迟
+)
+
+// LatencyStages 全部阶段，按对话流程排列
+var LatencyStages = []LatencyStage{
+	StageCapture, StageASR, StageLLMFirstToken, StageLLMComplete, StageTTSFirstByte, StagePlayback, StageTotal,
+}
+
+// TurnLatency 一轮对话各阶段的耗时（毫秒），本轮未经过的阶段为 0
+type TurnLatency struct {
+	ID              string    `json:"id"`
+	SessionID       string    `json:"session_id"`
+	DeviceID        string    `json:"device_id"`
+	Round           int       `json:"round"`
+	CaptureMs       int64     `json:"capture_ms"`
+	ASRMs           int64     `json:"asr_ms"`
+	LLMFirstTokenMs int64     `json:"llm_first_token_ms"`
+	LLMCompleteMs   int64     `json:"llm_complete_ms"`
+	TTSFirstByteMs  int64     `json:"tts_first_byte_ms"`
+	PlaybackStartMs int64     `json:"playback_start_ms"`
+	TotalMs         int64     `json:"total_ms"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// Stages 按阶段返回已测量的耗时
+func (t *TurnLatency) Stages() map[LatencyStage]int64 {
+	stages := make(map[LatencyStage]int64, len(LatencyStages))
+	for stage, ms := range map[LatencyStage]int64{
+		StageCapture:       t.CaptureMs,
+		StageASR:           t.ASRMs,
+		StageLLMFirstToken: t.LLMFirstTokenMs,
+		StageLLMComplete:   t.LLMCompleteMs,
+		StageTTSFirstByte:  t.TTSFirstByteMs,
+		StagePlayback:      t.PlaybackStartMs,
+		StageTotal:         t.TotalMs,
+	} {
+		if ms > 0 {
+			stages[stage] = ms
+		}
+	}
+	return stages
+}
+
+// LatencyFilter 耗时统计条件
+type LatencyFilter struct {
+	DeviceID string
+	From     time.Time
+	To       time.Time
+}
+
+// StageLatency 单个阶段的耗时分布
+type StageLatency struct {
+	Stage LatencyStage `json:"stage"`
+	Count int64        `json:"count"`
+	AvgMs int64        `json:"avg_ms"`
+	P50Ms int64        `json:"p50_ms"`
+	P90Ms int64        `json:"p90_ms"`
+	P95Ms int64        `json:"p95_ms"`
+	P99Ms int64        `json:"p99_ms"`
+	MaxMs int64        `json:"max_ms"`
+}
+
+// DailyLatency 按天汇总的端到端耗时
+type DailyLatency struct {
+	Date       string `json:"date"`
+	Turns      int64  `json:"turns"`
+	P50TotalMs int64  `json:"p50_total_ms"`
+	P95TotalMs int64  `json:"p95_total_ms"`
+}
+
+// LatencyStats 对话耗时统计
+type LatencyStats struct {
+	From   time.Time      `json:"from"`
+	To     time.Time      `json:"to"`
+	Turns  int64          `json:"turns"`
+	Stages []StageLatency `json:"stages"`
+	Daily  []DailyLatency `json:"daily"`
+}
+
+// summarizeLatency 汇总各阶段的百分位耗时，没有样本的阶段不出现在结果中
+func summarizeLatency(items []*TurnLatency, from, to time.Time) *LatencyStats {
+	stats := &LatencyStats{
+		From:   from,
+		To:     to,
+		Turns:  int64(len(items)),
+		Stages: []StageLatency{},
+		Daily:  []DailyLatency{},
+	}
+
+	samples := make(map[LatencyStage][]int64, len(LatencyStages))
+	daily := make(map[string][]int64)
+	turns := make(map[string]int64)
+	var days []string
+	for _, t := range items {
+		for stage, ms := range t.Stages() {
+			samples[stage] = append(samples[stage], ms)
+		}
+		date := t.CreatedAt.Format("2006-01-02")
+		if _, ok := turns[date]; !ok {
+			days = append(days, date)
+		}
+		turns[date]++
+		if t.TotalMs > 0 {
+			daily[date] = append(daily[date], t.TotalMs)
+		}
+	}
+
+	for _, stage := range LatencyStages {
+		values := samples[stage]
+		if len(values) == 0 {
+			continue
+		}
+		sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+		var sum int64
+		for _, v := range values {
+			sum += v
+		}
+		stats.Stages = append(stats.Stages, StageLatency{
+			Stage: stage,
+			Count: int64(len(values)),
+			AvgMs: sum / int64(len(values)),
+			P50Ms: percentile(values, 0.50),
+			P90Ms: percentile(values, 0.90),
+			P95Ms: percentile(values, 0.95),
+			P99Ms: percentile(values, 0.99),
+			MaxMs: values[len(values)-1],
+		})
+	}
+
+	sort.Strings(days)
+	for _, date := range days {
+		values := daily[date]
+		sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+		stats.Daily = append(stats.Daily, DailyLatency{
+			Date:       date,
+			Turns:      turns[date],
+			P50TotalMs: percentile(values, 0.50),
+			P95TotalMs: percentile(values, 0.95),
+		})
+	}
+	return stats
+}
+
+// percentile 返回已排序样本的百分位数，没有样本时返回 0
+func percentile(sorted []int64, p float64) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[int(float64(len(sorted)-1)*p)]
+}
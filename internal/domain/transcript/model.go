@@ -0,0 +1,155 @@
+package transcript
+
+import (
+	"time"
+
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+// Role 记录的发言角色
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+	RoleTool      Role = "tool" // 工具调用或工具结果
+)
+
+// Entry 对话记录中的一条消息
+type Entry struct {
+	ID         string    `json:"id"`
+	SessionID  string    `json:"session_id"`
+	DeviceID   string    `json:"device_id"`
+	Round      int       `json:"round"`
+	Role       Role      `json:"role"`
+	Content    string    `json:"content"`
+	ToolName   string    `json:"tool_name,omitempty"`
+	ToolCallID string    `json:"tool_call_id,omitempty"`
+	ToolArgs   string    `json:"tool_args,omitempty"`
+	LatencyMs  int64     `json:"latency_ms"` // 距本轮开始的耗时
+	Redacted   bool      `json:"redacted"`   // 内容或工具参数中的敏感信息已被替换
+	CreatedAt  time.Time `json:"created_at"`
+
+	Traces []capability.Trace `json:"traces,omitempty"` // 本轮到该条回复为止的能力调用追踪信息，仅助手消息
+}
+
+// Conversation 会话摘要
+type Conversation struct {
+	SessionID string    `json:"session_id"`
+	DeviceID  string    `json:"device_id"`
+	Entries   int64     `json:"entries"`
+	Rounds    int       `json:"rounds"`
+	StartedAt time.Time `json:"started_at"`
+	LastAt    time.Time `json:"last_at"`
+}
+
+// Filter 会话查询条件
+type Filter struct {
+	DeviceID  string
+	SessionID string
+	Query     string // 在消息内容中搜索
+	From      time.Time
+	To        time.Time
+	Page      int
+	PageSize  int
+}
+
+// Format 导出格式
+type Format string
+
+const (
+	FormatJSON     Format = "json"
+	FormatMarkdown Format = "markdown"
+)
+
+// IsValidFormat 校验导出格式
+func IsValidFormat(f Format) bool {
+	return f == FormatJSON || f == FormatMarkdown
+}
+
+// Rating 用户对回复的评价
+type Rating int
+
+const (
+	RatingDown Rating = -1
+	RatingNone Rating = 0 // 仅文字反馈
+	RatingUp   Rating = 1
+)
+
+// ParseRating 解析评价，支持 up/down 及空字符串
+func ParseRating(s string) (Rating, bool) {
+	switch s {
+	case "up", "thumbs_up":
+		return RatingUp, true
+	case "down", "thumbs_down":
+		return RatingDown, true
+	case "", "none":
+		return RatingNone, true
+	}
+	return RatingNone, false
+}
+
+// String 返回评价的文本形式
+func (r Rating) String() string {
+	switch {
+	case r > 0:
+		return "up"
+	case r < 0:
+		return "down"
+	}
+	return "none"
+}
+
+// FeedbackSource 反馈来源
+type FeedbackSource string
+
+const (
+	FeedbackSourceAPI    FeedbackSource = "api"    // 管理端或App通过HTTP提交
+	FeedbackSourceDevice FeedbackSource = "device" // 设备按键等通过 feedback 消息提交
+	FeedbackSourceVoice  FeedbackSource = "voice"  // 用户语音反馈，如“你说错了”
+)
+
+// Feedback 用户对某条回复或某轮对话的反馈
+type Feedback struct {
+	ID        string         `json:"id"`
+	SessionID string         `json:"session_id"`
+	EntryID   string         `json:"entry_id,omitempty"` // 为空时针对整轮对话
+	DeviceID  string         `json:"device_id"`
+	Round     int            `json:"round"`
+	Rating    Rating         `json:"rating"`
+	Comment   string         `json:"comment,omitempty"`
+	Source    FeedbackSource `json:"source"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// FeedbackFilter 反馈查询条件
+type FeedbackFilter struct {
+	DeviceID  string
+	SessionID string
+	Rating    Rating // RatingNone 表示不过滤
+	Source    FeedbackSource
+	From      time.Time
+	To        time.Time
+	Page      int
+	PageSize  int
+}
+
+// DailyFeedback 按天汇总的反馈
+type DailyFeedback struct {
+	Date       string `json:"date"`
+	ThumbsUp   int64  `json:"thumbs_up"`
+	ThumbsDown int64  `json:"thumbs_down"`
+}
+
+// FeedbackStats 反馈质量统计
+type FeedbackStats struct {
+	From             time.Time                `json:"from"`
+	To               time.Time                `json:"to"`
+	Total            int64                    `json:"total"`
+	ThumbsUp         int64                    `json:"thumbs_up"`
+	ThumbsDown       int64                    `json:"thumbs_down"`
+	WithComment      int64                    `json:"with_comment"`
+	SatisfactionRate float64                  `json:"satisfaction_rate"` // 赞 / (赞 + 踩)
+	BySource         map[FeedbackSource]int64 `json:"by_source"`
+	Daily            []DailyFeedback          `json:"daily"`
+}
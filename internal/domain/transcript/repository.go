@@ -0,0 +1,48 @@
+package transcript
+
+import (
+	"context"
+	"time"
+)
+
+// Repository 对话记录仓库接口
+type Repository interface {
+	// SaveBatch 批量保存消息
+	SaveBatch(ctx context.Context, entries []*Entry) error
+
+	// ListConversations 按条件分页查询会话摘要，按最后活跃时间倒序
+	ListConversations(ctx context.Context, filter Filter) ([]*Conversation, int64, error)
+
+	// ListEntries 查询会话内的全部消息，按时间正序
+	ListEntries(ctx context.Context, sessionID string) ([]*Entry, error)
+
+	// DeleteSession 删除会话的全部消息、反馈和耗时记录
+	DeleteSession(ctx context.Context, sessionID string) error
+
+	// DeleteDevice 删除设备的全部消息、反馈和耗时记录，返回删除的消息条数
+	DeleteDevice(ctx context.Context, deviceID string) (int64, error)
+
+	// DeleteBefore 删除指定时间之前的消息、反馈和耗时记录，返回删除的消息条数
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+
+	// FindEntry 根据ID查找消息，不存在时返回 nil
+	FindEntry(ctx context.Context, id string) (*Entry, error)
+
+	// SaveFeedback 保存反馈
+	SaveFeedback(ctx context.Context, f *Feedback) error
+
+	// ListFeedback 按条件分页查询反馈，按时间倒序
+	ListFeedback(ctx context.Context, filter FeedbackFilter) ([]*Feedback, int64, error)
+
+	// ListAllFeedback 查询满足条件的全部反馈，忽略分页，用于统计
+	ListAllFeedback(ctx context.Context, filter FeedbackFilter) ([]*Feedback, error)
+
+	// SaveTurnLatency 保存一轮对话的耗时
+	SaveTurnLatency(ctx context.Context, t *TurnLatency) error
+
+	// ListTurnLatencies 查询会话内各轮的耗时，按轮次正序
+	ListTurnLatencies(ctx context.Context, sessionID string) ([]*TurnLatency, error)
+
+	// ListAllTurnLatencies 查询满足条件的全部耗时记录，用于统计
+	ListAllTurnLatencies(ctx context.Context, filter LatencyFilter) ([]*TurnLatency, error)
+}
@@ -0,0 +1,508 @@
+package transcript
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/observability"
+)
+
+const (
+	queueSize      = 1024
+	flushBatchSize = 50
+	flushInterval  = time.Second
+	purgeInterval  = time.Hour
+)
+
+// ErrNotFound 会话不存在
+var ErrNotFound = stderrors.New("conversation not found")
+
+// ErrMessageNotFound 消息不存在
+var ErrMessageNotFound = stderrors.New("message not found")
+
+// defaultStatsWindow 未指定统计时间范围时的默认窗口
+const defaultStatsWindow = 30 * 24 * time.Hour
+
+// FeedbackObserver 反馈写入后的通知接口，用于将反馈计入实验指标
+type FeedbackObserver interface {
+	RecordFeedback(ctx context.Context, sessionID string, round int, feedback int) (bool, error)
+}
+
+// FeedbackRequest 提交反馈请求，EntryID 为空时按 Round 关联整轮对话
+type FeedbackRequest struct {
+	SessionID string
+	EntryID   string
+	DeviceID  string
+	Round     int
+	Rating    Rating
+	Comment   string
+	Source    FeedbackSource
+}
+
+// Redactor 落库前对消息脱敏
+type Redactor interface {
+	RedactAll(ctx context.Context, text string) (string, bool, error)
+}
+
+// Service 对话记录服务，异步落库并按保留策略清理
+type Service struct {
+	repo      Repository
+	logger    *logging.Logger
+	retention time.Duration // <=0 表示永久保留
+	now       func() time.Time
+	redactor  Redactor
+	observer  FeedbackObserver
+
+	queue     chan *Entry
+	latencies chan *TurnLatency
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局对话记录服务，供连接处理器使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局对话记录服务，未启用时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建对话记录服务，retentionDays<=0 表示不自动清理
+func NewService(repo Repository, retentionDays int, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Service{
+		repo:      repo,
+		logger:    logger,
+		retention: time.Duration(retentionDays) * 24 * time.Hour,
+		now:       time.Now,
+		queue:     make(chan *Entry, queueSize),
+		latencies: make(chan *TurnLatency, queueSize),
+	}
+}
+
+// SetRedactor 设置脱敏器，需在 Run 之前调用
+func (s *Service) SetRedactor(r Redactor) {
+	s.redactor = r
+}
+
+// SetFeedbackObserver 设置反馈观察者
+func (s *Service) SetFeedbackObserver(o FeedbackObserver) {
+	s.observer = o
+}
+
+// Record 记录一条消息，不阻塞调用方；队列满时丢弃并记录日志
+func (s *Service) Record(entry *Entry) {
+	if entry == nil || entry.SessionID == "" {
+		return
+	}
+	if entry.ID == "" {
+		entry.ID = uuid.New().String()
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = s.now()
+	}
+	select {
+	case s.queue <- entry:
+	default:
+		s.logger.WarnTag("对话记录", "写入队列已满，丢弃会话 %s 的消息", entry.SessionID)
+		observability.RecordMetric(context.Background(), "transcript_dropped_total", 1, nil)
+	}
+}
+
+// RecordLatency 记录一轮对话的耗时，不阻塞调用方；队列满时丢弃
+func (s *Service) RecordLatency(t *TurnLatency) {
+	if t == nil || t.SessionID == "" {
+		return
+	}
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = s.now()
+	}
+	select {
+	case s.latencies <- t:
+	default:
+		s.logger.WarnTag("对话记录", "耗时队列已满，丢弃会话 %s 第 %d 轮的耗时", t.SessionID, t.Round)
+	}
+}
+
+// TurnLatencies 查询会话内各轮的耗时
+func (s *Service) TurnLatencies(ctx context.Context, sessionID string) ([]*TurnLatency, error) {
+	return s.repo.ListTurnLatencies(ctx, sessionID)
+}
+
+// LatencyStats 汇总各阶段耗时的百分位分布，未指定时间范围时统计最近30天
+func (s *Service) LatencyStats(ctx context.Context, filter LatencyFilter) (*LatencyStats, error) {
+	if filter.To.IsZero() {
+		filter.To = s.now()
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.Add(-defaultStatsWindow)
+	}
+	items, err := s.repo.ListAllTurnLatencies(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeLatency(items, filter.From, filter.To), nil
+}
+
+// ListConversations 分页查询会话
+func (s *Service) ListConversations(ctx context.Context, filter Filter) ([]*Conversation, int64, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = 20
+	}
+	filter.Query = strings.TrimSpace(filter.Query)
+	return s.repo.ListConversations(ctx, filter)
+}
+
+// GetConversation 获取会话摘要和全部消息
+func (s *Service) GetConversation(ctx context.Context, sessionID string) (*Conversation, []*Entry, error) {
+	entries, err := s.repo.ListEntries(ctx, sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil, errors.Wrap(errors.KindDomain, "transcript.get", "conversation not found", ErrNotFound)
+	}
+	return summarize(sessionID, entries), entries, nil
+}
+
+// DeleteConversation 删除会话记录
+func (s *Service) DeleteConversation(ctx context.Context, sessionID string) error {
+	if _, _, err := s.GetConversation(ctx, sessionID); err != nil {
+		return err
+	}
+	return s.repo.DeleteSession(ctx, sessionID)
+}
+
+// EraseDeviceData 删除设备的全部对话记录和反馈，返回删除的消息条数
+func (s *Service) EraseDeviceData(ctx context.Context, deviceID string) (int64, error) {
+	if deviceID == "" {
+		return 0, errors.New(errors.KindDomain, "transcript.erase", "device_id is required")
+	}
+	return s.repo.DeleteDevice(ctx, deviceID)
+}
+
+// Export 导出会话，返回内容和 Content-Type
+func (s *Service) Export(ctx context.Context, sessionID string, format Format) ([]byte, string, error) {
+	if format == "" {
+		format = FormatJSON
+	}
+	if !IsValidFormat(format) {
+		return nil, "", errors.New(errors.KindDomain, "transcript.export", "unsupported export format: "+string(format))
+	}
+
+	conv, entries, err := s.GetConversation(ctx, sessionID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if format == FormatMarkdown {
+		return []byte(renderMarkdown(conv, entries)), "text/markdown; charset=utf-8", nil
+	}
+
+	data, err := json.MarshalIndent(struct {
+		Conversation *Conversation `json:"conversation"`
+		Entries      []*Entry      `json:"entries"`
+	}{conv, entries}, "", "  ")
+	if err != nil {
+		return nil, "", errors.Wrap(errors.KindDomain, "transcript.export", "failed to encode conversation", err)
+	}
+	return data, "application/json; charset=utf-8", nil
+}
+
+// SubmitFeedback 保存用户反馈，并通知观察者计入实验指标
+func (s *Service) SubmitFeedback(ctx context.Context, req FeedbackRequest) (*Feedback, error) {
+	if req.SessionID == "" {
+		return nil, errors.New(errors.KindDomain, "transcript.feedback", "session_id is required")
+	}
+	if req.Rating == RatingNone && strings.TrimSpace(req.Comment) == "" {
+		return nil, errors.New(errors.KindDomain, "transcript.feedback", "rating or comment is required")
+	}
+	if req.Source == "" {
+		req.Source = FeedbackSourceAPI
+	}
+
+	f := &Feedback{
+		ID:        uuid.New().String(),
+		SessionID: req.SessionID,
+		EntryID:   req.EntryID,
+		DeviceID:  req.DeviceID,
+		Round:     req.Round,
+		Rating:    req.Rating,
+		Comment:   strings.TrimSpace(req.Comment),
+		Source:    req.Source,
+		CreatedAt: s.now(),
+	}
+	if req.EntryID != "" {
+		entry, err := s.repo.FindEntry(ctx, req.EntryID)
+		if err != nil {
+			return nil, err
+		}
+		if entry == nil || entry.SessionID != req.SessionID {
+			return nil, errors.Wrap(errors.KindDomain, "transcript.feedback", "message not found", ErrMessageNotFound)
+		}
+		f.DeviceID = entry.DeviceID
+		f.Round = entry.Round
+	}
+	if s.redactor != nil && f.Comment != "" {
+		if out, changed, err := s.redactor.RedactAll(ctx, f.Comment); err != nil {
+			s.logger.WarnTag("对话记录", "反馈脱敏检测失败: %v", err)
+		} else if changed {
+			f.Comment = out
+		}
+	}
+
+	if err := s.repo.SaveFeedback(ctx, f); err != nil {
+		return nil, err
+	}
+	observability.RecordMetric(ctx, "transcript_feedback_total", 1, map[string]string{
+		"rating": f.Rating.String(),
+		"source": string(f.Source),
+	})
+
+	if s.observer != nil && f.Rating != RatingNone {
+		if _, err := s.observer.RecordFeedback(ctx, f.SessionID, f.Round, int(f.Rating)); err != nil {
+			s.logger.WarnTag("对话记录", "反馈计入实验指标失败: %v", err)
+		}
+	}
+	return f, nil
+}
+
+// ListFeedback 分页查询反馈
+func (s *Service) ListFeedback(ctx context.Context, filter FeedbackFilter) ([]*Feedback, int64, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = 20
+	}
+	return s.repo.ListFeedback(ctx, filter)
+}
+
+// FeedbackStats 汇总反馈质量，未指定时间范围时统计最近30天
+func (s *Service) FeedbackStats(ctx context.Context, filter FeedbackFilter) (*FeedbackStats, error) {
+	if filter.To.IsZero() {
+		filter.To = s.now()
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.Add(-defaultStatsWindow)
+	}
+	items, err := s.repo.ListAllFeedback(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &FeedbackStats{
+		From:     filter.From,
+		To:       filter.To,
+		BySource: make(map[FeedbackSource]int64),
+		Daily:    []DailyFeedback{},
+	}
+	daily := make(map[string]*DailyFeedback)
+	var days []string
+	for _, f := range items {
+		stats.Total++
+		stats.BySource[f.Source]++
+		if f.Comment != "" {
+			stats.WithComment++
+		}
+		date := f.CreatedAt.Format("2006-01-02")
+		d, ok := daily[date]
+		if !ok {
+			d = &DailyFeedback{Date: date}
+			daily[date] = d
+			days = append(days, date)
+		}
+		switch {
+		case f.Rating > 0:
+			stats.ThumbsUp++
+			d.ThumbsUp++
+		case f.Rating < 0:
+			stats.ThumbsDown++
+			d.ThumbsDown++
+		}
+	}
+	sort.Strings(days)
+	for _, date := range days {
+		stats.Daily = append(stats.Daily, *daily[date])
+	}
+	if rated := stats.ThumbsUp + stats.ThumbsDown; rated > 0 {
+		stats.SatisfactionRate = float64(stats.ThumbsUp) / float64(rated)
+	}
+	return stats, nil
+}
+
+// Run 启动写入和清理循环，直到 ctx 结束；退出前写入剩余消息
+func (s *Service) Run(ctx context.Context) error {
+	s.logger.InfoTag("对话记录", "对话记录服务已启动，保留 %s", s.retention)
+	flushTicker := time.NewTicker(flushInterval)
+	defer flushTicker.Stop()
+	purgeTicker := time.NewTicker(purgeInterval)
+	defer purgeTicker.Stop()
+
+	s.purge(ctx)
+
+	batch := make([]*Entry, 0, flushBatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+		drain:
+			for {
+				select {
+				case entry := <-s.queue:
+					batch = append(batch, entry)
+				case t := <-s.latencies:
+					s.saveLatency(context.Background(), t)
+				default:
+					break drain
+				}
+			}
+			s.flush(context.Background(), batch)
+			s.logger.InfoTag("对话记录", "对话记录服务已停止")
+			return nil
+		case entry := <-s.queue:
+			batch = append(batch, entry)
+			if len(batch) >= flushBatchSize {
+				s.flush(ctx, batch)
+				batch = batch[:0]
+			}
+		case t := <-s.latencies:
+			s.saveLatency(ctx, t)
+		case <-flushTicker.C:
+			if len(batch) > 0 {
+				s.flush(ctx, batch)
+				batch = batch[:0]
+			}
+		case <-purgeTicker.C:
+			s.purge(ctx)
+		}
+	}
+}
+
+func (s *Service) flush(ctx context.Context, batch []*Entry) {
+	if len(batch) == 0 {
+		return
+	}
+	for _, entry := range batch {
+		s.redact(ctx, entry)
+	}
+	if err := s.repo.SaveBatch(ctx, batch); err != nil {
+		s.logger.ErrorTag("对话记录", "写入 %d 条对话记录失败: %v", len(batch), err)
+		return
+	}
+	observability.RecordMetric(ctx, "transcript_entries_total", float64(len(batch)), nil)
+}
+
+func (s *Service) saveLatency(ctx context.Context, t *TurnLatency) {
+	if err := s.repo.SaveTurnLatency(ctx, t); err != nil {
+		s.logger.ErrorTag("对话记录", "写入会话 %s 第 %d 轮的耗时失败: %v", t.SessionID, t.Round, err)
+	}
+}
+
+// redact 在写入磁盘前脱敏内容和工具参数
+// 慢速检测器失败时仍写入快速检测器处理后的文本，保证敏感信息不会以明文落盘
+func (s *Service) redact(ctx context.Context, entry *Entry) {
+	if s.redactor == nil {
+		return
+	}
+	for _, field := range []*string{&entry.Content, &entry.ToolArgs} {
+		out, changed, err := s.redactor.RedactAll(ctx, *field)
+		if err != nil {
+			s.logger.WarnTag("对话记录", "脱敏检测失败: %v", err)
+		}
+		if changed {
+			*field = out
+			entry.Redacted = true
+		}
+	}
+}
+
+// purge 按保留策略删除过期记录
+func (s *Service) purge(ctx context.Context) {
+	if s.retention <= 0 {
+		return
+	}
+	removed, err := s.repo.DeleteBefore(ctx, s.now().Add(-s.retention))
+	if err != nil {
+		s.logger.ErrorTag("对话记录", "清理过期对话记录失败: %v", err)
+		return
+	}
+	if removed > 0 {
+		s.logger.InfoTag("对话记录", "已清理 %d 条过期对话记录", removed)
+	}
+}
+
+func summarize(sessionID string, entries []*Entry) *Conversation {
+	conv := &Conversation{
+		SessionID: sessionID,
+		Entries:   int64(len(entries)),
+		StartedAt: entries[0].CreatedAt,
+		LastAt:    entries[len(entries)-1].CreatedAt,
+	}
+	for _, e := range entries {
+		if conv.DeviceID == "" {
+			conv.DeviceID = e.DeviceID
+		}
+		if e.Round > conv.Rounds {
+			conv.Rounds = e.Round
+		}
+	}
+	return conv
+}
+
+func renderMarkdown(conv *Conversation, entries []*Entry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# 对话记录 %s\n\n", conv.SessionID)
+	fmt.Fprintf(&b, "- 设备: %s\n", conv.DeviceID)
+	fmt.Fprintf(&b, "- 开始: %s\n", conv.StartedAt.Format(time.RFC3339))
+	fmt.Fprintf(&b, "- 结束: %s\n", conv.LastAt.Format(time.RFC3339))
+	fmt.Fprintf(&b, "- 消息数: %d\n", conv.Entries)
+
+	round := -1
+	for _, e := range entries {
+		if e.Round != round {
+			round = e.Round
+			fmt.Fprintf(&b, "\n## 第 %d 轮\n\n", round)
+		}
+		ts := e.CreatedAt.Format("15:04:05")
+		switch e.Role {
+		case RoleUser:
+			fmt.Fprintf(&b, "**用户** (%s): %s\n\n", ts, e.Content)
+		case RoleAssistant:
+			fmt.Fprintf(&b, "**助手** (%s, +%dms): %s\n\n", ts, e.LatencyMs, e.Content)
+		case RoleTool:
+			if e.ToolName != "" {
+				fmt.Fprintf(&b, "> 工具调用 `%s` (%s, +%dms)\n>\n> ```json\n> %s\n> ```\n\n", e.ToolName, ts, e.LatencyMs, e.ToolArgs)
+			} else {
+				fmt.Fprintf(&b, "> 工具结果 (%s, +%dms): %s\n\n", ts, e.LatencyMs, e.Content)
+			}
+		}
+	}
+	return b.String()
+}
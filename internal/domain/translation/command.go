@@ -0,0 +1,80 @@
+package translation
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// CommandKind 翻译语音指令类型
+type CommandKind int
+
+const (
+	CommandStart CommandKind = iota + 1 // 开启翻译模式或切换语言
+	CommandStop                         // 退出翻译模式
+)
+
+// Command 解析出的翻译语音指令，Source/Target 为空时使用配置的默认语言
+type Command struct {
+	Kind   CommandKind
+	Source string
+	Target string
+}
+
+var (
+	stopPattern  = regexp.MustCompile(`^(?:请|帮我)?(?:退出|关闭|停止|结束|取消)(?:同声|实时)?翻译(?:模式)?(?:吧)?$`)
+	startPattern = regexp.MustCompile(`^(?:请|帮我)?(?:开启|打开|进入|启动|开始)(?:同声|实时)?翻译(?:模式)?(?:吧)?$`)
+	// 如“把中文翻译成英文”“开启翻译模式，翻译成日语”
+	directionPattern = regexp.MustCompile(`^(?:请|帮我)?(?:(?:开启|打开|进入|启动|开始)(?:同声|实时)?翻译(?:模式)?)?(?:把|从)?(\p{Han}{0,5}?)(?:翻译成|翻译为|翻成|译成|译为)(\p{Han}{1,5}?)(?:模式)?(?:吧)?$`)
+	// 如“中译英”“英译中模式”
+	shortPattern = regexp.MustCompile(`^(\p{Han})译(\p{Han})(?:模式)?$`)
+)
+
+// ParseCommand 解析翻译语音指令，不是指令时 ok 为 false
+// 语言必须是支持的语言，“把这句话翻译成英文”之类的普通请求不视为指令
+func ParseCommand(text string) (Command, bool) {
+	text = normalize(text)
+	if text == "" {
+		return Command{}, false
+	}
+	if stopPattern.MatchString(text) {
+		return Command{Kind: CommandStop}, true
+	}
+	if startPattern.MatchString(text) {
+		return Command{Kind: CommandStart}, true
+	}
+
+	var source, target string
+	if m := shortPattern.FindStringSubmatch(text); m != nil {
+		source, target = m[1], m[2]
+	} else if m := directionPattern.FindStringSubmatch(text); m != nil {
+		source, target = m[1], m[2]
+	} else {
+		return Command{}, false
+	}
+
+	cmd := Command{Kind: CommandStart}
+	lang, ok := Lookup(target)
+	if !ok {
+		return Command{}, false
+	}
+	cmd.Target = lang.Code
+	if source != "" {
+		lang, ok := Lookup(source)
+		if !ok {
+			return Command{}, false
+		}
+		cmd.Source = lang.Code
+	}
+	return cmd, true
+}
+
+// normalize 去掉标点和空白
+func normalize(text string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsPunct(r) || unicode.IsSpace(r) || unicode.IsSymbol(r) {
+			return -1
+		}
+		return r
+	}, text)
+}
@@ -0,0 +1,73 @@
+// Package translation 实时翻译模式
+//
+// 翻译模式按设备会话开启：设备的语音识别后不进入对话，而是翻译成目标语言并用该语言的音色播报。
+// 翻译由配置的 translation 类型能力完成，未配置时由连接使用设备当前的LLM按翻译提示词完成。
+package translation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AutoDetect 源语言自动识别
+const AutoDetect = "auto"
+
+// Language 支持的语言
+type Language struct {
+	Code    string   `json:"code"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"-"` // 语音指令中的叫法
+}
+
+var languages = []Language{
+	{Code: "zh", Name: "中文", Aliases: []string{"中文", "汉语", "普通话", "国语", "中"}},
+	{Code: "en", Name: "英语", Aliases: []string{"英语", "英文", "英"}},
+	{Code: "ja", Name: "日语", Aliases: []string{"日语", "日文", "日"}},
+	{Code: "ko", Name: "韩语", Aliases: []string{"韩语", "韩文", "朝鲜语", "韩"}},
+	{Code: "fr", Name: "法语", Aliases: []string{"法语", "法文", "法"}},
+	{Code: "de", Name: "德语", Aliases: []string{"德语", "德文", "德"}},
+	{Code: "es", Name: "西班牙语", Aliases: []string{"西班牙语", "西班牙文", "西语", "西"}},
+	{Code: "ru", Name: "俄语", Aliases: []string{"俄语", "俄文", "俄"}},
+}
+
+// Languages 返回支持的语言
+func Languages() []Language {
+	return append([]Language(nil), languages...)
+}
+
+// Lookup 按语言代码（不区分大小写）或中文叫法查找语言
+func Lookup(name string) (Language, bool) {
+	name = strings.TrimSpace(name)
+	for _, lang := range languages {
+		if strings.EqualFold(lang.Code, name) {
+			return lang, true
+		}
+		for _, alias := range lang.Aliases {
+			if alias == name {
+				return lang, true
+			}
+		}
+	}
+	return Language{}, false
+}
+
+// LanguageName 返回语言的中文名称，自动识别和未知代码原样返回
+func LanguageName(code string) string {
+	if code == AutoDetect {
+		return "自动识别"
+	}
+	if lang, ok := Lookup(code); ok {
+		return lang.Name
+	}
+	return code
+}
+
+// Prompt 使用LLM翻译时的系统提示词
+func Prompt(source, target string) string {
+	direction := "翻译成" + LanguageName(target)
+	if source != "" && source != AutoDetect {
+		direction = "从" + LanguageName(source) + direction
+	}
+	return fmt.Sprintf("你是同声传译。把用户说的话%s，只输出译文，不要解释、不要回答问题、不要添加任何其他内容。"+
+		"如果用户说的已经是%s，原样输出。", direction, LanguageName(target))
+}
@@ -0,0 +1,176 @@
+package translation
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+var (
+	// ErrDeviceOffline 设备不在线，无法开启翻译模式
+	ErrDeviceOffline = stderrors.New("device offline")
+	// ErrNoProvider 未配置翻译能力，由调用方使用LLM翻译
+	ErrNoProvider = stderrors.New("translation provider not configured")
+)
+
+// Session 设备当前的翻译会话
+type Session struct {
+	DeviceID  string
+	Source    string // 源语言代码，auto 表示自动识别
+	Target    string // 目标语言代码
+	StartedAt time.Time
+}
+
+// Service 管理设备的翻译会话并调用翻译能力
+// 翻译会话只保存在内存中，随设备断开连接结束
+type Service struct {
+	cfg      config.TranslationConfig
+	registry *capability.Registry
+	online   func(deviceID string) bool
+	logger   *logging.Logger
+	now      func() time.Time
+
+	mu       sync.RWMutex
+	sessions map[string]Session
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局翻译服务，供连接处理器使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局翻译服务，未启用翻译模式时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建翻译服务，online 用于接口开启翻译模式时检查设备是否在线，为 nil 时不检查
+func NewService(cfg config.TranslationConfig, registry *capability.Registry, online func(deviceID string) bool, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Service{
+		cfg:      cfg,
+		registry: registry,
+		online:   online,
+		logger:   logger,
+		now:      time.Now,
+		sessions: make(map[string]Session),
+	}
+}
+
+// Start 为设备开启翻译模式，已开启时切换语言；语言为空时使用配置的默认语言
+func (s *Service) Start(deviceID, source, target string) (Session, error) {
+	if source == "" {
+		source = s.cfg.DefaultSource
+	}
+	if target == "" {
+		target = s.cfg.DefaultTarget
+	}
+	source, target, err := normalizePair(source, target)
+	if err != nil {
+		return Session{}, err
+	}
+	if s.online != nil && !s.online(deviceID) {
+		return Session{}, errors.Wrap(errors.KindDomain, "translation.start", fmt.Sprintf("device %s is offline", deviceID), ErrDeviceOffline)
+	}
+
+	session := Session{DeviceID: deviceID, Source: source, Target: target, StartedAt: s.now()}
+	s.mu.Lock()
+	s.sessions[deviceID] = session
+	s.mu.Unlock()
+	s.logger.InfoTag("翻译", "设备 %s 开启翻译模式：%s -> %s", deviceID, source, target)
+	return session, nil
+}
+
+// Stop 结束设备的翻译模式，原本未开启时返回 false
+func (s *Service) Stop(deviceID string) bool {
+	s.mu.Lock()
+	_, ok := s.sessions[deviceID]
+	delete(s.sessions, deviceID)
+	s.mu.Unlock()
+	if ok {
+		s.logger.InfoTag("翻译", "设备 %s 退出翻译模式", deviceID)
+	}
+	return ok
+}
+
+// Session 返回设备当前的翻译会话
+func (s *Service) Session(deviceID string) (Session, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	session, ok := s.sessions[deviceID]
+	return session, ok
+}
+
+// Voice 返回目标语言配置的TTS音色，未配置时返回空
+func (s *Service) Voice(lang string) string {
+	return s.cfg.Voices[lang]
+}
+
+// HasProvider 是否配置了翻译能力
+func (s *Service) HasProvider() bool {
+	return s.cfg.Provider != "" && s.registry != nil
+}
+
+// Translate 调用配置的翻译能力，未配置时返回 ErrNoProvider
+func (s *Service) Translate(ctx context.Context, text, source, target string) (string, error) {
+	if !s.HasProvider() {
+		return "", ErrNoProvider
+	}
+	exec, err := s.registry.GetExecutor(s.cfg.Provider)
+	if err != nil {
+		return "", fmt.Errorf("获取翻译能力 %s 失败: %w", s.cfg.Provider, err)
+	}
+	outputs, err := exec.Execute(ctx, s.cfg.ProviderConfig, map[string]interface{}{
+		"text":   text,
+		"source": source,
+		"target": target,
+	})
+	if err != nil {
+		return "", fmt.Errorf("翻译能力 %s 执行失败: %w", s.cfg.Provider, err)
+	}
+	translated, _ := outputs["text"].(string)
+	if strings.TrimSpace(translated) == "" {
+		return "", fmt.Errorf("翻译能力 %s 未返回译文", s.cfg.Provider)
+	}
+	return translated, nil
+}
+
+// normalizePair 校验并规范化语言代码
+func normalizePair(source, target string) (string, string, error) {
+	lang, ok := Lookup(target)
+	if !ok {
+		return "", "", errors.New(errors.KindDomain, "translation.start", "unsupported target language: "+target)
+	}
+	target = lang.Code
+
+	if source != AutoDetect {
+		lang, ok := Lookup(source)
+		if !ok {
+			return "", "", errors.New(errors.KindDomain, "translation.start", "unsupported source language: "+source)
+		}
+		source = lang.Code
+	}
+	if source == target {
+		return "", "", errors.New(errors.KindDomain, "translation.start", "source and target languages must differ")
+	}
+	return source, target, nil
+}
@@ -2,6 +2,7 @@ package tts
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"xiaozhi-server-go/internal/domain/tts/inter"
 	"xiaozhi-server-go/internal/domain/providers/tts"
@@ -41,7 +42,7 @@ func (m *Manager) GetTTS() interface{} {
 
 // ToTTS 将文本转换为语音
 func (m *Manager) ToTTS(text string) (string, error) {
-	return m.ToTTSWithConfig(text, m.config, m.globalConfig)
+	return m.ToTTSWithConfig(text, m.GetConfig(), m.globalConfig)
 }
 
 // ToTTSWithConfig 使用指定配置转换文本
@@ -68,6 +69,16 @@ func (m *Manager) ToTTSWithConfig(text string, config inter.TTSConfig, globalCon
 		}
 	case "edge":
 		// Edge TTS 不需要额外配置
+	case "gosherpa":
+		// GoSherpa TTS 为本地服务，需要服务地址
+		sherpaCfg, ok := lookupTTSConfig(globalConfig, config)
+		if !ok {
+			return "", fmt.Errorf("GoSherpaTTS configuration not found")
+		}
+		ttsConfig.Cluster = sherpaCfg.Cluster
+		if sherpaCfg.OutputDir != "" {
+			ttsConfig.OutputDir = sherpaCfg.OutputDir
+		}
 	}
 
 	// 创建TTS提供商
@@ -100,6 +111,28 @@ func (m *Manager) ToTTSWithConfig(text string, config inter.TTSConfig, globalCon
 	return filePath, nil
 }
 
+// lookupTTSConfig 按配置名称查找全局TTS配置，未指定名称时按提供者类型取名称最小的一个
+func lookupTTSConfig(globalConfig *config.Config, cfg inter.TTSConfig) (config.TTSConfig, bool) {
+	if globalConfig == nil {
+		return config.TTSConfig{}, false
+	}
+	if cfg.Name != "" {
+		c, ok := globalConfig.TTS[cfg.Name]
+		return c, ok
+	}
+	names := make([]string, 0, len(globalConfig.TTS))
+	for name, c := range globalConfig.TTS {
+		if c.Type == cfg.Provider {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return config.TTSConfig{}, false
+	}
+	sort.Strings(names)
+	return globalConfig.TTS[names[0]], true
+}
+
 // Close 关闭TTS资源
 func (m *Manager) Close() error {
 	m.mu.Lock()
@@ -17,6 +17,7 @@ type TTSProvider interface {
 
 // TTSConfig TTS配置
 type TTSConfig struct {
+	Name            string        `json:"name,omitempty"`     // 全局配置中的 TTS 配置名称，用于读取服务地址等额外字段
 	Provider        string        `json:"provider"`         // 提供者类型 (doubao, edge, etc.)
 	Voice           string        `json:"voice"`            // 语音名称
 	Speed           float32       `json:"speed"`            // 语速 (0.5-2.0)
@@ -0,0 +1,152 @@
+package webhook
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/eventbus"
+)
+
+// budgetCooldown 同一设备同一预算范围的超限事件在该时间内只推送一次，超限后每轮对话都会触发
+const budgetCooldown = 10 * time.Minute
+
+// Subscriber 订阅事件总线上的系统事件并转为推送
+type Subscriber struct {
+	service *Service
+	grace   time.Duration
+	online  func(deviceID string) bool
+
+	mu         sync.Mutex
+	offline    map[string]*time.Timer // 设备ID到宽限期内等待推送的离线事件
+	lastBudget map[string]time.Time
+}
+
+// Subscribe 订阅系统事件，online 用于在离线宽限期结束时判断设备是否已重新连接，为 nil 时断开即推送
+func Subscribe(service *Service, online func(deviceID string) bool) (*Subscriber, error) {
+	sub := &Subscriber{
+		service:    service,
+		grace:      service.cfg.OfflineGrace,
+		online:     online,
+		offline:    make(map[string]*time.Timer),
+		lastBudget: make(map[string]time.Time),
+	}
+	if online == nil {
+		sub.grace = 0
+	}
+	if err := eventbus.Subscribe(eventbus.EventDeviceOffline, sub.handleDeviceOffline); err != nil {
+		return nil, err
+	}
+	if err := eventbus.Subscribe(eventbus.EventAlertPluginCrashed, sub.handlePluginCrashed); err != nil {
+		return nil, err
+	}
+	if err := eventbus.Subscribe(eventbus.EventWorkflowFailed, sub.handleWorkflowFailed); err != nil {
+		return nil, err
+	}
+	if err := eventbus.Subscribe(eventbus.EventBudgetExceeded, sub.handleDeviceBudget); err != nil {
+		return nil, err
+	}
+	if err := eventbus.Subscribe(eventbus.EventAlertBudgetExceeded, sub.handleQuota); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// handleDeviceOffline 宽限期内重新连接的设备不推送，同一设备再次断开时重新计时
+func (sub *Subscriber) handleDeviceOffline(data eventbus.DeviceOfflineEventData) {
+	payload := map[string]interface{}{
+		"device_id":    data.DeviceID,
+		"session_id":   data.SessionID,
+		"connected_at": data.ConnectedAt,
+		"offline_at":   data.Timestamp,
+	}
+	if sub.grace <= 0 {
+		go sub.publish(EventDeviceOffline, payload)
+		return
+	}
+
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	if t, ok := sub.offline[data.DeviceID]; ok {
+		t.Stop()
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(sub.grace, func() {
+		sub.mu.Lock()
+		current := sub.offline[data.DeviceID] == timer
+		if current {
+			delete(sub.offline, data.DeviceID)
+		}
+		sub.mu.Unlock()
+		if current && !sub.online(data.DeviceID) {
+			sub.publish(EventDeviceOffline, payload)
+		}
+	})
+	sub.offline[data.DeviceID] = timer
+}
+
+func (sub *Subscriber) handlePluginCrashed(data eventbus.AlertEventData) {
+	payload := map[string]interface{}{
+		"plugin_id":  data.Target,
+		"reason":     data.Message,
+		"crashed_at": data.Timestamp,
+	}
+	for key, value := range data.Data {
+		payload[key] = value
+	}
+	go sub.publish(EventPluginCrashed, payload)
+}
+
+func (sub *Subscriber) handleWorkflowFailed(data eventbus.WorkflowEventData) {
+	go sub.publish(EventWorkflowFailed, map[string]interface{}{
+		"execution_id": data.ExecutionID,
+		"workflow_id":  data.WorkflowID,
+		"tenant_id":    data.TenantID,
+		"request_id":   data.RequestID,
+		"error":        data.Error,
+		"failed_at":    data.Timestamp,
+	})
+}
+
+// handleDeviceBudget 设备Token预算超限，source 为 device
+func (sub *Subscriber) handleDeviceBudget(data eventbus.BudgetEventData) {
+	key := data.DeviceID + "|" + data.Scope
+	now := time.Now()
+	sub.mu.Lock()
+	if last, ok := sub.lastBudget[key]; ok && now.Sub(last) < budgetCooldown {
+		sub.mu.Unlock()
+		return
+	}
+	sub.lastBudget[key] = now
+	sub.mu.Unlock()
+
+	go sub.publish(EventBudgetExceeded, map[string]interface{}{
+		"source":      "device",
+		"device_id":   data.DeviceID,
+		"session_id":  data.SessionID,
+		"scope":       data.Scope,
+		"limit":       data.Limit,
+		"used":        data.Used,
+		"exceeded_at": data.Timestamp,
+	})
+}
+
+// handleQuota 租户或插件的每日配额用尽，每个配额每天只发布一次，source 为 quota
+func (sub *Subscriber) handleQuota(data eventbus.AlertEventData) {
+	payload := map[string]interface{}{
+		"source":      "quota",
+		"target":      data.Target,
+		"message":     data.Message,
+		"exceeded_at": data.Timestamp,
+	}
+	for key, value := range data.Data {
+		payload[key] = value
+	}
+	go sub.publish(EventBudgetExceeded, payload)
+}
+
+func (sub *Subscriber) publish(event string, data map[string]interface{}) {
+	if _, err := sub.service.Publish(context.Background(), event, data); err != nil {
+		sub.service.logger.WarnTag("事件推送", "生成事件 %s 的推送记录失败: %v", event, err)
+	}
+}
@@ -0,0 +1,143 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// 可订阅的系统事件
+const (
+	EventDeviceOffline  = "device.offline"  // 设备断开连接且未在宽限时间内重新连接
+	EventPluginCrashed  = "plugin.crashed"  // 插件异常退出或健康检查失败
+	EventWorkflowFailed = "workflow.failed" // 工作流执行失败
+	EventBudgetExceeded = "budget.exceeded" // 设备Token预算或租户配额用尽
+	EventAll            = "*"               // 订阅全部事件
+
+	// EventPing 测试推送，只发往被测试的端点，不能订阅
+	EventPing = "webhook.ping"
+)
+
+// 推送请求头，接收方用 SignatureHeader 校验请求来源，用 EventIDHeader 对重试去重
+const (
+	SignatureHeader = "X-Xiaozhi-Signature" // t=<unix 秒>,v1=<hex(HMAC-SHA256(secret, "<t>.<body>"))>
+	EventHeader     = "X-Xiaozhi-Event"
+	EventIDHeader   = "X-Xiaozhi-Event-Id"
+	DeliveryHeader  = "X-Xiaozhi-Delivery"
+)
+
+// maskedSecret 返回给接口调用方的脱敏密钥，更新时传入该值表示不修改
+const maskedSecret = "******"
+
+// Endpoint 接收推送的端点
+type Endpoint struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret"` // 签名密钥
+	Events    []string  `json:"events"` // 订阅的事件
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Subscribes 端点是否订阅了事件
+func (e *Endpoint) Subscribes(event string) bool {
+	for _, s := range e.Events {
+		if s == EventAll || s == event {
+			return true
+		}
+	}
+	return false
+}
+
+// MaskedSecret 返回脱敏后的签名密钥
+func (e *Endpoint) MaskedSecret() string {
+	if e.Secret == "" {
+		return ""
+	}
+	return maskedSecret
+}
+
+// IsValidEvent 检查订阅的事件是否合法
+func IsValidEvent(event string) bool {
+	switch event {
+	case EventDeviceOffline, EventPluginCrashed, EventWorkflowFailed, EventBudgetExceeded, EventAll:
+		return true
+	}
+	return false
+}
+
+// Event 推送的请求体，同一事件发往各端点时 ID 相同
+type Event struct {
+	ID        string                 `json:"id"`
+	Type      string                 `json:"type"`
+	Timestamp time.Time              `json:"timestamp"`
+	Data      map[string]interface{} `json:"data"`
+}
+
+// DeliveryStatus 推送状态
+type DeliveryStatus string
+
+const (
+	StatusPending   DeliveryStatus = "pending"   // 等待首次推送或重试
+	StatusSucceeded DeliveryStatus = "succeeded" // 端点返回 2xx
+	StatusFailed    DeliveryStatus = "failed"    // 重试次数用尽或端点已停用
+)
+
+// IsValidStatus 检查推送状态是否合法
+func IsValidStatus(status DeliveryStatus) bool {
+	switch status {
+	case StatusPending, StatusSucceeded, StatusFailed:
+		return true
+	}
+	return false
+}
+
+// Attempt 一次推送尝试
+type Attempt struct {
+	At           time.Time `json:"at"`
+	StatusCode   int       `json:"status_code,omitempty"` // 未收到响应时为 0
+	DurationMs   int64     `json:"duration_ms"`
+	Error        string    `json:"error,omitempty"`
+	ResponseBody string    `json:"response_body,omitempty"` // 截取前 1KB
+}
+
+// Delivery 一个事件发往一个端点的推送记录
+type Delivery struct {
+	ID            string         `json:"id"`
+	EndpointID    string         `json:"endpoint_id"`
+	EventID       string         `json:"event_id"`
+	Event         string         `json:"event"`
+	Payload       string         `json:"payload"` // 请求体 JSON，重试时原样发送
+	Status        DeliveryStatus `json:"status"`
+	Attempts      int            `json:"attempts"` // 本轮已推送次数，重新推送时清零
+	NextAttemptAt *time.Time     `json:"next_attempt_at,omitempty"`
+	StatusCode    int            `json:"status_code,omitempty"` // 最近一次响应的状态码
+	Error         string         `json:"error,omitempty"`       // 最近一次失败的原因
+	History       []Attempt      `json:"history"`               // 各次尝试，最多保留 maxHistory 条
+	DeliveredAt   *time.Time     `json:"delivered_at,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// DeliveryFilter 推送记录查询条件
+type DeliveryFilter struct {
+	EndpointID string
+	Event      string
+	Status     DeliveryStatus
+	Page       int
+	PageSize   int
+}
+
+// Sign 计算签名请求头的值，接收方按同样的方式计算并用常量时间比较 v1
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,42 @@
+package webhook
+
+import (
+	"context"
+	"time"
+)
+
+// Repository 推送端点和推送记录仓库接口
+type Repository interface {
+	// SaveEndpoint 新建端点
+	SaveEndpoint(ctx context.Context, e *Endpoint) error
+
+	// UpdateEndpoint 更新端点
+	UpdateEndpoint(ctx context.Context, e *Endpoint) error
+
+	// FindEndpoint 根据ID查找端点，不存在时返回 nil
+	FindEndpoint(ctx context.Context, id string) (*Endpoint, error)
+
+	// ListEndpoints 查询全部端点
+	ListEndpoints(ctx context.Context) ([]*Endpoint, error)
+
+	// DeleteEndpoint 删除端点及其推送记录
+	DeleteEndpoint(ctx context.Context, id string) error
+
+	// SaveDeliveries 批量新建推送记录
+	SaveDeliveries(ctx context.Context, items []*Delivery) error
+
+	// UpdateDelivery 更新推送记录
+	UpdateDelivery(ctx context.Context, d *Delivery) error
+
+	// FindDelivery 根据ID查找推送记录，不存在时返回 nil
+	FindDelivery(ctx context.Context, id string) (*Delivery, error)
+
+	// ListDeliveries 按条件分页查询推送记录，按创建时间倒序
+	ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]*Delivery, int64, error)
+
+	// ListDue 查询在指定时间之前到期且仍待推送的记录
+	ListDue(ctx context.Context, before time.Time, limit int) ([]*Delivery, error)
+
+	// DeleteFinishedBefore 删除在指定时间之前结束的推送记录，返回删除条数
+	DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,526 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	mathrand "math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/observability"
+)
+
+const (
+	pollInterval    = 5 * time.Second
+	dueBatchSize    = 100
+	deliveryWorkers = 8 // 同一批到期记录的并发推送数
+	purgeInterval   = time.Hour
+	maxHistory      = 20   // 每条推送记录保留的尝试次数
+	maxResponseBody = 1024 // 记录的响应体长度
+)
+
+// ErrNotFound 推送端点不存在
+var ErrNotFound = stderrors.New("webhook endpoint not found")
+
+// ErrDeliveryNotFound 推送记录不存在
+var ErrDeliveryNotFound = stderrors.New("webhook delivery not found")
+
+// CreateRequest 创建端点请求
+type CreateRequest struct {
+	Name    string
+	URL     string
+	Secret  string // 为空时自动生成
+	Events  []string
+	Enabled bool
+}
+
+// UpdateRequest 更新端点请求，nil 字段表示不修改
+type UpdateRequest struct {
+	Name    *string
+	URL     *string
+	Secret  *string // 脱敏占位值（******）保留原值
+	Events  []string
+	Enabled *bool
+}
+
+// Service 事件推送服务，管理端点并把订阅的系统事件签名后推送到端点，失败按指数退避重试
+type Service struct {
+	cfg    config.WebhooksConfig
+	repo   Repository
+	client *http.Client
+	logger *logging.Logger
+	now    func() time.Time
+
+	wake chan struct{}
+
+	randMu sync.Mutex
+	rand   *mathrand.Rand
+}
+
+// NewService 创建事件推送服务，client 为 nil 时使用默认 HTTP 客户端
+func NewService(cfg config.WebhooksConfig, repo Repository, client *http.Client, logger *logging.Logger) *Service {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = 30 * time.Second
+	}
+	if cfg.MaxBackoff < cfg.RetryBackoff {
+		cfg.MaxBackoff = cfg.RetryBackoff
+	}
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &Service{
+		cfg:    cfg,
+		repo:   repo,
+		client: client,
+		logger: logger,
+		now:    time.Now,
+		wake:   make(chan struct{}, 1),
+		rand:   mathrand.New(mathrand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Create 创建端点，未指定签名密钥时自动生成
+func (s *Service) Create(ctx context.Context, req CreateRequest) (*Endpoint, error) {
+	now := s.now()
+	e := &Endpoint{
+		ID:        uuid.New().String(),
+		Name:      strings.TrimSpace(req.Name),
+		URL:       strings.TrimSpace(req.URL),
+		Secret:    strings.TrimSpace(req.Secret),
+		Events:    req.Events,
+		Enabled:   req.Enabled,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if e.Secret == "" {
+		secret, err := newSecret()
+		if err != nil {
+			return nil, errors.Wrap(errors.KindDomain, "webhook.create", "failed to generate secret", err)
+		}
+		e.Secret = secret
+	}
+	if err := validateEndpoint(e, "webhook.create"); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveEndpoint(ctx, e); err != nil {
+		return nil, err
+	}
+	s.logger.InfoTag("事件推送", "已创建推送端点 %s(%s)", e.Name, e.ID)
+	return e, nil
+}
+
+// Get 获取端点
+func (s *Service) Get(ctx context.Context, id string) (*Endpoint, error) {
+	e, err := s.repo.FindEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if e == nil {
+		return nil, errors.Wrap(errors.KindDomain, "webhook.get", "webhook endpoint not found", ErrNotFound)
+	}
+	return e, nil
+}
+
+// List 获取全部端点
+func (s *Service) List(ctx context.Context) ([]*Endpoint, error) {
+	return s.repo.ListEndpoints(ctx)
+}
+
+// Update 更新端点
+func (s *Service) Update(ctx context.Context, id string, req UpdateRequest) (*Endpoint, error) {
+	e, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		e.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.URL != nil {
+		e.URL = strings.TrimSpace(*req.URL)
+	}
+	if req.Secret != nil && *req.Secret != maskedSecret {
+		e.Secret = strings.TrimSpace(*req.Secret)
+	}
+	if req.Events != nil {
+		e.Events = req.Events
+	}
+	if req.Enabled != nil {
+		e.Enabled = *req.Enabled
+	}
+	e.UpdatedAt = s.now()
+
+	if err := validateEndpoint(e, "webhook.update"); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateEndpoint(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Delete 删除端点及其推送记录
+func (s *Service) Delete(ctx context.Context, id string) error {
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteEndpoint(ctx, id)
+}
+
+// Test 向端点同步推送一条 webhook.ping 事件，不重试，停用的端点也会推送
+func (s *Service) Test(ctx context.Context, id string) (*Delivery, error) {
+	e, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	event := s.newEvent(EventPing, map[string]interface{}{
+		"endpoint_id": e.ID,
+		"message":     fmt.Sprintf("这是一条来自 xiaozhi-server 的测试推送，端点：%s", e.Name),
+	})
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, errors.Wrap(errors.KindDomain, "webhook.test", "failed to encode event", err)
+	}
+	d := s.newDelivery(e, event, string(payload))
+	d.NextAttemptAt = nil // 同步推送，不交给推送循环领取
+	if err := s.repo.SaveDeliveries(ctx, []*Delivery{d}); err != nil {
+		return nil, err
+	}
+	s.attempt(ctx, e, d, 1)
+	return d, nil
+}
+
+// Publish 为订阅了 eventType 的全部启用端点生成推送记录并唤醒推送循环，返回生成的记录数
+func (s *Service) Publish(ctx context.Context, eventType string, data map[string]interface{}) (int, error) {
+	endpoints, err := s.repo.ListEndpoints(ctx)
+	if err != nil {
+		return 0, err
+	}
+	event := s.newEvent(eventType, data)
+	var payload []byte
+	var items []*Delivery
+	for _, e := range endpoints {
+		if !e.Enabled || !e.Subscribes(eventType) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(event); err != nil {
+				return 0, errors.Wrap(errors.KindDomain, "webhook.publish", "failed to encode event", err)
+			}
+		}
+		items = append(items, s.newDelivery(e, event, string(payload)))
+	}
+	if len(items) == 0 {
+		return 0, nil
+	}
+	if err := s.repo.SaveDeliveries(ctx, items); err != nil {
+		return 0, err
+	}
+	s.kick()
+	return len(items), nil
+}
+
+// ListDeliveries 分页查询端点的推送记录
+func (s *Service) ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]*Delivery, int64, error) {
+	if _, err := s.Get(ctx, filter.EndpointID); err != nil {
+		return nil, 0, err
+	}
+	if filter.Status != "" && !IsValidStatus(filter.Status) {
+		return nil, 0, errors.New(errors.KindDomain, "webhook.list_deliveries", "invalid delivery status: "+string(filter.Status))
+	}
+	return s.repo.ListDeliveries(ctx, filter)
+}
+
+// GetDelivery 获取端点的一条推送记录
+func (s *Service) GetDelivery(ctx context.Context, endpointID, id string) (*Delivery, error) {
+	d, err := s.repo.FindDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil || d.EndpointID != endpointID {
+		return nil, errors.Wrap(errors.KindDomain, "webhook.get_delivery", "webhook delivery not found", ErrDeliveryNotFound)
+	}
+	return d, nil
+}
+
+// Redeliver 把已结束的推送记录重新加入推送队列，请求体和事件ID不变，重试次数重新计算
+func (s *Service) Redeliver(ctx context.Context, endpointID, id string) (*Delivery, error) {
+	d, err := s.GetDelivery(ctx, endpointID, id)
+	if err != nil {
+		return nil, err
+	}
+	if d.Status == StatusPending {
+		return nil, errors.New(errors.KindDomain, "webhook.redeliver", "delivery is already pending")
+	}
+	now := s.now()
+	d.Status = StatusPending
+	d.Attempts = 0
+	d.NextAttemptAt = &now
+	d.UpdatedAt = now
+	if err := s.repo.UpdateDelivery(ctx, d); err != nil {
+		return nil, err
+	}
+	s.kick()
+	return d, nil
+}
+
+// Run 启动推送循环，直到 ctx 结束
+func (s *Service) Run(ctx context.Context) error {
+	s.logger.InfoTag("事件推送", "事件推送服务已启动")
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	purgeTicker := time.NewTicker(purgeInterval)
+	defer purgeTicker.Stop()
+
+	s.purge(ctx)
+	for {
+		s.deliverDue(ctx)
+		select {
+		case <-ctx.Done():
+			s.logger.InfoTag("事件推送", "事件推送服务已停止")
+			return nil
+		case <-ticker.C:
+		case <-s.wake:
+		case <-purgeTicker.C:
+			s.purge(ctx)
+		}
+	}
+}
+
+func (s *Service) kick() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// deliverDue 推送到期的记录，等本批全部结束后返回，避免下一轮重复领取
+func (s *Service) deliverDue(ctx context.Context) {
+	due, err := s.repo.ListDue(ctx, s.now(), dueBatchSize)
+	if err != nil {
+		s.logger.ErrorTag("事件推送", "查询待推送记录失败: %v", err)
+		return
+	}
+	if len(due) == 0 {
+		return
+	}
+	endpoints, err := s.repo.ListEndpoints(ctx)
+	if err != nil {
+		s.logger.ErrorTag("事件推送", "读取推送端点失败: %v", err)
+		return
+	}
+	byID := make(map[string]*Endpoint, len(endpoints))
+	for _, e := range endpoints {
+		byID[e.ID] = e
+	}
+
+	sem := make(chan struct{}, deliveryWorkers)
+	var wg sync.WaitGroup
+	for _, d := range due {
+		e, ok := byID[d.EndpointID]
+		if !ok || !e.Enabled {
+			s.abandon(ctx, d, "endpoint is disabled or deleted")
+			continue
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(e *Endpoint, d *Delivery) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			s.attempt(ctx, e, d, s.cfg.MaxAttempts)
+		}(e, d)
+	}
+	wg.Wait()
+}
+
+// attempt 推送一次并更新记录：成功或次数达到 maxAttempts 时结束，否则安排下一次重试
+func (s *Service) attempt(ctx context.Context, e *Endpoint, d *Delivery, maxAttempts int) {
+	started := s.now()
+	code, body, sendErr := s.send(ctx, e, d, started)
+	now := s.now()
+
+	a := Attempt{At: started, StatusCode: code, DurationMs: now.Sub(started).Milliseconds(), ResponseBody: body}
+	if sendErr != nil {
+		a.Error = sendErr.Error()
+	}
+	d.History = append(d.History, a)
+	if len(d.History) > maxHistory {
+		d.History = d.History[len(d.History)-maxHistory:]
+	}
+	d.Attempts++
+	d.StatusCode = code
+	d.Error = a.Error
+	d.UpdatedAt = now
+
+	labels := map[string]string{"event": d.Event}
+	switch {
+	case sendErr == nil:
+		d.Status = StatusSucceeded
+		d.NextAttemptAt = nil
+		d.DeliveredAt = &now
+		observability.RecordMetric(ctx, "webhook_delivered_total", 1, labels)
+	case d.Attempts >= maxAttempts:
+		d.Status = StatusFailed
+		d.NextAttemptAt = nil
+		s.logger.WarnTag("事件推送", "事件 %s 推送到端点 %s 失败，已推送 %d 次: %v", d.Event, e.Name, d.Attempts, sendErr)
+		observability.RecordMetric(ctx, "webhook_failed_total", 1, labels)
+	default:
+		next := now.Add(s.backoff(d.Attempts))
+		d.NextAttemptAt = &next
+		s.logger.DebugTag("事件推送", "事件 %s 推送到端点 %s 失败，%s 后重试: %v", d.Event, e.Name, next.Sub(now).Round(time.Second), sendErr)
+	}
+
+	if err := s.repo.UpdateDelivery(context.Background(), d); err != nil {
+		s.logger.ErrorTag("事件推送", "更新推送记录 %s 失败: %v", d.ID, err)
+	}
+}
+
+// abandon 端点已停用或删除时结束推送记录，重新启用后可手动重新推送
+func (s *Service) abandon(ctx context.Context, d *Delivery, reason string) {
+	d.Status = StatusFailed
+	d.NextAttemptAt = nil
+	d.Error = reason
+	d.UpdatedAt = s.now()
+	if err := s.repo.UpdateDelivery(ctx, d); err != nil {
+		s.logger.ErrorTag("事件推送", "更新推送记录 %s 失败: %v", d.ID, err)
+	}
+}
+
+// send 签名并发送请求体，返回响应状态码和截取的响应体，非 2xx 视为失败
+func (s *Service) send(ctx context.Context, e *Endpoint, d *Delivery, at time.Time) (int, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	body := []byte(d.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "xiaozhi-server-webhook")
+	req.Header.Set(EventHeader, d.Event)
+	req.Header.Set(EventIDHeader, d.EventID)
+	req.Header.Set(DeliveryHeader, d.ID)
+	req.Header.Set(SignatureHeader, Sign(e.Secret, at, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, string(snippet), fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, string(snippet), nil
+}
+
+// backoff 第 attempt 次失败后的重试等待时间：指数增长并加入 ±20% 抖动，避免同时失败的推送同时重试
+func (s *Service) backoff(attempt int) time.Duration {
+	d := s.cfg.RetryBackoff
+	for i := 1; i < attempt && d < s.cfg.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > s.cfg.MaxBackoff {
+		d = s.cfg.MaxBackoff
+	}
+	s.randMu.Lock()
+	jitter := 0.8 + 0.4*s.rand.Float64()
+	s.randMu.Unlock()
+	return time.Duration(float64(d) * jitter)
+}
+
+// purge 删除超过保留天数的已结束推送记录
+func (s *Service) purge(ctx context.Context) {
+	if s.cfg.RetentionDays <= 0 {
+		return
+	}
+	before := s.now().AddDate(0, 0, -s.cfg.RetentionDays)
+	removed, err := s.repo.DeleteFinishedBefore(ctx, before)
+	if err != nil {
+		s.logger.ErrorTag("事件推送", "清理过期推送记录失败: %v", err)
+		return
+	}
+	if removed > 0 {
+		s.logger.InfoTag("事件推送", "已清理 %d 条过期推送记录", removed)
+	}
+}
+
+func (s *Service) newEvent(eventType string, data map[string]interface{}) *Event {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	return &Event{ID: uuid.New().String(), Type: eventType, Timestamp: s.now(), Data: data}
+}
+
+func (s *Service) newDelivery(e *Endpoint, event *Event, payload string) *Delivery {
+	now := s.now()
+	return &Delivery{
+		ID:            uuid.New().String(),
+		EndpointID:    e.ID,
+		EventID:       event.ID,
+		Event:         event.Type,
+		Payload:       payload,
+		Status:        StatusPending,
+		NextAttemptAt: &now,
+		History:       []Attempt{},
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// validateEndpoint 校验端点名称、地址和订阅事件
+func validateEndpoint(e *Endpoint, op string) error {
+	if e.Name == "" {
+		return errors.New(errors.KindDomain, op, "name is required")
+	}
+	u, err := url.Parse(e.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New(errors.KindDomain, op, "url must be an absolute http or https URL")
+	}
+	if e.Secret == "" {
+		return errors.New(errors.KindDomain, op, "secret must not be empty")
+	}
+	if len(e.Events) == 0 {
+		return errors.New(errors.KindDomain, op, "at least one event is required")
+	}
+	for _, event := range e.Events {
+		if !IsValidEvent(event) {
+			return errors.New(errors.KindDomain, op, "invalid event: "+event)
+		}
+	}
+	return nil
+}
+
+// newSecret 生成随机签名密钥
+func newSecret() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
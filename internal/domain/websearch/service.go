@@ -0,0 +1,162 @@
+package websearch
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+// minSnippetChars 总字数预算剩余不足该值时不再附加后续结果
+const minSnippetChars = 40
+
+// Hit 经过预算裁剪的搜索结果
+type Hit struct {
+	Index   int // 引用编号，从 1 开始
+	Title   string
+	URL     string
+	Site    string // 来源站点，用于口头说明出处
+	Snippet string
+}
+
+// Service 联网搜索服务，调用 search 类型的能力并按预算裁剪结果
+type Service struct {
+	registry *capability.Registry
+	cfg      config.WebSearchConfig
+	logger   *logging.Logger
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultService *Service
+)
+
+// SetDefault 设置全局联网搜索服务，供 LLM 工具调用使用
+func SetDefault(s *Service) {
+	defaultMu.Lock()
+	defaultService = s
+	defaultMu.Unlock()
+}
+
+// Default 返回全局联网搜索服务，未启用时返回 nil
+func Default() *Service {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultService
+}
+
+// NewService 创建联网搜索服务，未配置的预算项使用默认值
+func NewService(cfg config.WebSearchConfig, registry *capability.Registry, logger *logging.Logger) *Service {
+	if cfg.Capability == "" {
+		cfg.Capability = "web_search"
+	}
+	if cfg.MaxResults <= 0 {
+		cfg.MaxResults = 5
+	}
+	if cfg.MaxSnippetChars <= 0 {
+		cfg.MaxSnippetChars = 200
+	}
+	if cfg.MaxTotalChars <= 0 {
+		cfg.MaxTotalChars = 1500
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 8 * time.Second
+	}
+	return &Service{registry: registry, cfg: cfg, logger: logger}
+}
+
+// Capability 返回使用的搜索能力ID
+func (s *Service) Capability() string {
+	return s.cfg.Capability
+}
+
+// Search 执行一次搜索，结果按 URL 去重，摘要截取查询词附近的句子，并受结果数和总字数预算限制
+func (s *Service) Search(ctx context.Context, query string) ([]Hit, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("搜索内容为空")
+	}
+	exec, err := s.registry.GetExecutor(s.cfg.Capability)
+	if err != nil {
+		return nil, fmt.Errorf("获取搜索能力 %s 失败: %w", s.cfg.Capability, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+	outputs, err := exec.Execute(ctx, s.cfg.Config, map[string]interface{}{
+		"query": query,
+		"count": s.cfg.MaxResults,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("搜索能力 %s 执行失败: %w", s.cfg.Capability, err)
+	}
+
+	raw, _ := outputs["results"].([]interface{})
+	hits := make([]Hit, 0, s.cfg.MaxResults)
+	seen := make(map[string]bool, len(raw))
+	remaining := s.cfg.MaxTotalChars
+	for _, item := range raw {
+		if len(hits) >= s.cfg.MaxResults || remaining < minSnippetChars {
+			break
+		}
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		link, _ := m["url"].(string)
+		if link == "" || seen[link] {
+			continue
+		}
+		seen[link] = true
+
+		title, _ := m["title"].(string)
+		snippet, _ := m["snippet"].(string)
+		limit := s.cfg.MaxSnippetChars
+		if limit > remaining {
+			limit = remaining
+		}
+		text := Snippet(snippet, query, limit)
+		remaining -= len([]rune(text))
+
+		hits = append(hits, Hit{
+			Index:   len(hits) + 1,
+			Title:   cleanText(title),
+			URL:     link,
+			Site:    siteName(link),
+			Snippet: text,
+		})
+	}
+	if s.logger != nil {
+		s.logger.DebugTag("联网搜索", "query=%q 返回 %d 条, 使用 %d 条", query, len(raw), len(hits))
+	}
+	return hits, nil
+}
+
+// FormatResults 将搜索结果整理为带引用编号的参考资料，并要求 LLM 在回答中注明出处
+func FormatResults(query string, hits []Hit) string {
+	if len(hits) == 0 {
+		return fmt.Sprintf("联网搜索「%s」没有找到相关结果，请如实告诉用户", query)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "联网搜索「%s」的结果：\n", query)
+	for _, h := range hits {
+		fmt.Fprintf(&b, "[%d] %s（%s）\n%s\n", h.Index, h.Title, h.Site, h.Snippet)
+	}
+	b.WriteString("请根据以上结果简洁地回答，引用的内容用 [编号] 标注，并在回答末尾说明信息来自哪些网站；结果不足以回答时如实说明")
+	return b.String()
+}
+
+// siteName 返回结果地址的站点名，去掉 www. 前缀
+func siteName(link string) string {
+	u, err := url.Parse(link)
+	if err != nil || u.Hostname() == "" {
+		return link
+	}
+	return strings.TrimPrefix(u.Hostname(), "www.")
+}
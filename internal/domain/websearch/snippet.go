@@ -0,0 +1,121 @@
+package websearch
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	// 搜索引擎用来高亮关键词的行内标签直接去掉，其余标签替换为空格
+	inlineTagPattern = regexp.MustCompile(`(?i)</?(b|i|em|strong|span|mark)\b[^>]*>`)
+	tagPattern       = regexp.MustCompile(`<[^>]*>`)
+)
+
+// sentenceEnds 摘要截断时优先停在这些字符之后
+const sentenceEnds = "。！？；.!?;"
+
+// cleanText 去掉 HTML 标签和实体并合并空白
+func cleanText(s string) string {
+	s = inlineTagPattern.ReplaceAllString(s, "")
+	s = tagPattern.ReplaceAllString(s, " ")
+	s = html.UnescapeString(s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// Snippet 从结果摘要中截取不超过 maxChars 字的片段
+// 片段从第一个命中查询词的句子开始，尽量在句末截断，超长时以省略号结尾
+func Snippet(text, query string, maxChars int) string {
+	runes := []rune(cleanText(text))
+	if maxChars <= 0 || len(runes) <= maxChars {
+		return string(runes)
+	}
+
+	start := 0
+	if pos := firstMatch(runes, queryTerms(query)); pos > 0 {
+		// 回退到所在句子的开头，但至少保留命中位置之后一半的预算
+		start = pos
+		for i := pos - 1; i >= 0 && pos-i < maxChars/2; i-- {
+			if strings.ContainsRune(sentenceEnds, runes[i]) {
+				break
+			}
+			start = i
+		}
+		if len(runes)-start < maxChars {
+			start = len(runes) - maxChars
+		}
+	}
+
+	window := runes[start : start+maxChars]
+	end := len(window)
+	if start+maxChars < len(runes) {
+		for i := len(window) - 1; i >= len(window)/2; i-- {
+			if strings.ContainsRune(sentenceEnds, window[i]) {
+				end = i + 1
+				break
+			}
+		}
+	}
+	out := strings.TrimSpace(string(window[:end]))
+	if start > 0 {
+		out = "…" + out
+	}
+	if end == len(window) && start+maxChars < len(runes) {
+		out += "…"
+	}
+	return out
+}
+
+// queryTerms 拆分查询词；中文没有空格分词，按相邻两字切分
+func queryTerms(query string) []string {
+	var terms []string
+	for _, field := range strings.Fields(strings.ToLower(query)) {
+		runes := []rune(field)
+		if !containsHan(runes) {
+			if len(runes) >= 2 {
+				terms = append(terms, field)
+			}
+			continue
+		}
+		if len(runes) == 1 {
+			terms = append(terms, field)
+			continue
+		}
+		for i := 0; i+1 < len(runes); i++ {
+			terms = append(terms, string(runes[i:i+2]))
+		}
+	}
+	return terms
+}
+
+// firstMatch 返回任一查询词最早出现的位置（按字符计），未命中时返回 -1
+func firstMatch(runes []rune, terms []string) int {
+	lower := []rune(strings.ToLower(string(runes)))
+	if len(lower) != len(runes) {
+		// 大小写转换改变了长度时无法对应位置，退化为从头截取
+		return -1
+	}
+	text := string(lower)
+	best := -1
+	for _, term := range terms {
+		idx := strings.Index(text, term)
+		if idx < 0 {
+			continue
+		}
+		pos := len([]rune(text[:idx]))
+		if best < 0 || pos < best {
+			best = pos
+		}
+	}
+	return best
+}
+
+func containsHan(runes []rune) bool {
+	for _, r := range runes {
+		if unicode.Is(unicode.Han, r) {
+			return true
+		}
+	}
+	return false
+}
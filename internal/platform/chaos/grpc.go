@@ -0,0 +1,98 @@
+package chaos
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DialOptions 插件 gRPC 连接的故障注入拦截器，每次调用时读取全局注入器，未启用时直接放行
+func DialOptions(pluginID string) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(unaryInterceptor(pluginID)),
+		grpc.WithChainStreamInterceptor(streamInterceptor(pluginID)),
+	}
+}
+
+// methodName 取完整方法名中的 RPC 名称，如 /plugin.PluginService/HealthCheck 取 HealthCheck
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
+
+func unaryInterceptor(pluginID string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		injector := Default()
+		if injector == nil {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		rule, ok := injector.decide(TargetGRPC, pluginID, methodName(method))
+		if !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		if err := wait(ctx, rule.Delay); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		switch rule.Action {
+		case ActionError:
+			return status.Error(codes.Unavailable, rule.message())
+		case ActionKill:
+			// 插件处理完成后连接断开，调用方收不到响应
+			_ = invoker(ctx, method, req, reply, cc, opts...)
+			return status.Error(codes.Unavailable, rule.message())
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func streamInterceptor(pluginID string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		injector := Default()
+		if injector == nil {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+		rule, ok := injector.decide(TargetGRPC, pluginID, methodName(method))
+		if !ok {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+		if err := wait(ctx, rule.Delay); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+		switch rule.Action {
+		case ActionError:
+			return nil, status.Error(codes.Unavailable, rule.message())
+		case ActionKill:
+			streamCtx, cancel := context.WithCancel(ctx)
+			stream, err := streamer(streamCtx, desc, cc, method, opts...)
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			return &killedStream{ClientStream: stream, cancel: cancel, err: status.Error(codes.Unavailable, rule.message())}, nil
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// killedStream 收到首条消息后中断的流
+type killedStream struct {
+	grpc.ClientStream
+	cancel   context.CancelFunc
+	err      error
+	received bool
+}
+
+func (s *killedStream) RecvMsg(m interface{}) error {
+	if s.received {
+		s.cancel()
+		return s.err
+	}
+	if err := s.ClientStream.RecvMsg(m); err != nil {
+		s.cancel()
+		return err
+	}
+	s.received = true
+	return nil
+}
@@ -0,0 +1,82 @@
+package chaos
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Transport 提供者 HTTP 请求的故障注入，每次请求时读取全局注入器，未启用时直接转发
+func Transport(provider string, base http.RoundTripper) http.RoundTripper {
+	return &faultTransport{base: base, provider: provider}
+}
+
+type faultTransport struct {
+	base     http.RoundTripper
+	provider string
+}
+
+func (t *faultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	injector := Default()
+	if injector == nil {
+		return t.base.RoundTrip(req)
+	}
+	rule, ok := injector.decide(TargetHTTP, t.provider, req.URL.Hostname())
+	if !ok {
+		return t.base.RoundTrip(req)
+	}
+	if err := wait(req.Context(), rule.Delay); err != nil {
+		return nil, err
+	}
+
+	switch rule.Action {
+	case ActionError:
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		message := rule.message()
+		return &http.Response{
+			Status:        http.StatusText(rule.StatusCode),
+			StatusCode:    rule.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": []string{"text/plain; charset=utf-8"}},
+			Body:          io.NopCloser(strings.NewReader(message)),
+			ContentLength: int64(len(message)),
+			Request:       req,
+		}, nil
+	case ActionKill:
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = &killedBody{body: resp.Body, err: fmt.Errorf("%s: %w", rule.message(), io.ErrUnexpectedEOF)}
+		return resp, nil
+	}
+	return t.base.RoundTrip(req)
+}
+
+// killedBody 读到第一段数据后连接断开的响应体
+type killedBody struct {
+	body io.ReadCloser
+	err  error
+	read bool
+}
+
+func (b *killedBody) Read(p []byte) (int, error) {
+	if b.read {
+		return 0, b.err
+	}
+	n, err := b.body.Read(p)
+	if n > 0 {
+		b.read = true
+		return n, nil
+	}
+	return n, err
+}
+
+func (b *killedBody) Close() error {
+	return b.body.Close()
+}
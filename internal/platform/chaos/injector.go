@@ -0,0 +1,186 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+// ErrNotFound 规则不存在
+var ErrNotFound = errors.New("chaos rule not found")
+
+// defaultTTL 未指定有效期的规则默认保留时长，避免忘记删除的规则长期影响服务
+const defaultTTL = 10 * time.Minute
+
+// Injector 故障注入器，实现 capability.FaultInjector
+type Injector struct {
+	logger *logging.Logger
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu    sync.Mutex
+	rules []*Rule
+	rand  *rand.Rand
+}
+
+// NewInjector 创建故障注入器，ttl 为规则的默认有效期，<=0 时为 10 分钟
+func NewInjector(ttl time.Duration, logger *logging.Logger) *Injector {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+	return &Injector{
+		logger: logger,
+		ttl:    ttl,
+		now:    time.Now,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+var defaultInjector atomic.Pointer[Injector]
+
+// SetDefault 设置全局注入器，插件 gRPC 连接和提供者 HTTP 客户端在每次调用时读取；传 nil 关闭注入
+func SetDefault(injector *Injector) {
+	defaultInjector.Store(injector)
+}
+
+// Default 返回全局注入器，未启用时为 nil
+func Default() *Injector {
+	return defaultInjector.Load()
+}
+
+// AddRule 校验并添加规则，ttl<=0 时使用默认有效期
+func (i *Injector) AddRule(rule Rule, ttl time.Duration) (Rule, error) {
+	if err := rule.validate(); err != nil {
+		return Rule{}, err
+	}
+	if ttl <= 0 {
+		ttl = i.ttl
+	}
+	rule.ID = uuid.New().String()
+	rule.Hits = 0
+	rule.CreatedAt = i.now()
+	rule.ExpiresAt = rule.CreatedAt.Add(ttl)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.rules = append(i.rules, &rule)
+	i.logger.WarnTag("故障注入", "已添加规则 %s：%s %s/%s %s，概率 %.2f，有效期至 %s",
+		rule.ID, rule.Target, rule.Match, rule.Method, rule.Action, rule.Probability, rule.ExpiresAt.Format(time.RFC3339))
+	return rule, nil
+}
+
+// Rules 返回仍然有效的规则，按创建时间排序
+func (i *Injector) Rules() []Rule {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.pruneLocked()
+	rules := make([]Rule, len(i.rules))
+	for idx, rule := range i.rules {
+		rules[idx] = *rule
+	}
+	sort.Slice(rules, func(a, b int) bool { return rules[a].CreatedAt.Before(rules[b].CreatedAt) })
+	return rules
+}
+
+// RemoveRule 删除规则
+func (i *Injector) RemoveRule(id string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for idx, rule := range i.rules {
+		if rule.ID == id {
+			i.rules = append(i.rules[:idx], i.rules[idx+1:]...)
+			i.logger.InfoTag("故障注入", "已删除规则 %s", id)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+// Clear 删除全部规则，返回删除数量
+func (i *Injector) Clear() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	removed := len(i.rules)
+	i.rules = nil
+	if removed > 0 {
+		i.logger.InfoTag("故障注入", "已清空 %d 条规则", removed)
+	}
+	return removed
+}
+
+// decide 按规则顺序取第一条匹配且按概率命中的规则，命中后计数
+func (i *Injector) decide(target Target, subject, method string) (Rule, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.pruneLocked()
+	for _, rule := range i.rules {
+		if !rule.matches(target, subject, method) {
+			continue
+		}
+		if rule.Probability < 1 && i.rand.Float64() >= rule.Probability {
+			continue
+		}
+		rule.Hits++
+		hit := *rule
+		if rule.MaxHits > 0 && rule.Hits >= rule.MaxHits {
+			i.pruneLocked()
+		}
+		i.logger.DebugTag("故障注入", "规则 %s 命中 %s %s/%s：%s", hit.ID, target, subject, method, hit.Action)
+		return hit, true
+	}
+	return Rule{}, false
+}
+
+// pruneLocked 移除过期和达到次数上限的规则，调用方需持有锁
+func (i *Injector) pruneLocked() {
+	now := i.now()
+	kept := i.rules[:0]
+	for _, rule := range i.rules {
+		if now.After(rule.ExpiresAt) || (rule.MaxHits > 0 && rule.Hits >= rule.MaxHits) {
+			continue
+		}
+		kept = append(kept, rule)
+	}
+	clear(i.rules[len(kept):])
+	i.rules = kept
+}
+
+// Fault 实现 capability.FaultInjector
+func (i *Injector) Fault(ctx context.Context, providerID string, def capability.Definition) (capability.Fault, bool) {
+	rule, ok := i.decide(TargetCapability, providerID, def.ID)
+	if !ok {
+		return capability.Fault{}, false
+	}
+	fault := capability.Fault{Delay: rule.Delay, Kill: rule.Action == ActionKill}
+	if rule.Action != ActionDelay {
+		fault.Err = errors.New(rule.message())
+	}
+	return fault, true
+}
+
+// wait 等待规则的延迟，ctx 先结束时返回其错误
+func wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,110 @@
+// Package chaos 故障注入
+//
+// 仅用于调试环境验证熔断、降级链和插件重启等容错机制：按规则对能力调用、插件 gRPC 调用和
+// 提供者 HTTP 请求随机注入延迟、错误或中断。规则只保存在内存中，到期或达到次数上限后自动失效，
+// 服务重启后全部清空。
+package chaos
+
+import (
+	"fmt"
+	"path"
+	"time"
+)
+
+// Target 注入目标
+type Target string
+
+const (
+	TargetCapability Target = "capability" // 能力调用，Match 为提供者ID，Method 为能力ID
+	TargetGRPC       Target = "grpc"       // 插件 gRPC 调用，Match 为插件ID，Method 为 RPC 方法名
+	TargetHTTP       Target = "http"       // 提供者 HTTP 请求，Match 为提供者名称，Method 为请求主机名
+)
+
+// Action 故障类型
+type Action string
+
+const (
+	ActionDelay Action = "delay" // 延迟后正常调用
+	ActionError Action = "error" // 不调用，直接返回错误
+	ActionKill  Action = "kill"  // 调用中途中断：流式响应在首个分块后截断，非流式调用丢弃结果并返回错误
+)
+
+// Rule 故障注入规则
+type Rule struct {
+	ID          string        `json:"id"`
+	Target      Target        `json:"target"`
+	Match       string        `json:"match"`       // 提供者或插件ID，支持 * 通配，为空时匹配全部
+	Method      string        `json:"method"`      // 能力ID、RPC 方法名或主机名，支持 * 通配，为空时匹配全部
+	Action      Action        `json:"action"`      // delay、error、kill
+	Probability float64       `json:"probability"` // 命中后注入的概率，0~1
+	Delay       time.Duration `json:"delay"`       // delay 的时长，error 和 kill 也可附加延迟
+	StatusCode  int           `json:"status_code"` // HTTP error 返回的状态码，缺省 503
+	Message     string        `json:"message"`     // 注入错误的信息
+	MaxHits     int           `json:"max_hits"`    // 注入次数上限，0 表示不限
+	Hits        int           `json:"hits"`        // 已注入次数
+	CreatedAt   time.Time     `json:"created_at"`
+	ExpiresAt   time.Time     `json:"expires_at"`
+}
+
+// matches 规则是否适用于本次调用
+func (r *Rule) matches(target Target, subject, method string) bool {
+	if r.Target != target {
+		return false
+	}
+	return glob(r.Match, subject) && glob(r.Method, method)
+}
+
+// message 注入错误的信息
+func (r *Rule) message() string {
+	if r.Message != "" {
+		return r.Message
+	}
+	return fmt.Sprintf("chaos: injected %s fault (rule %s)", r.Action, r.ID)
+}
+
+func glob(pattern, value string) bool {
+	if pattern == "" || pattern == "*" {
+		return true
+	}
+	ok, _ := path.Match(pattern, value)
+	return ok
+}
+
+// validate 校验规则并填充缺省值
+func (r *Rule) validate() error {
+	switch r.Target {
+	case TargetCapability, TargetGRPC, TargetHTTP:
+	default:
+		return fmt.Errorf("不支持的注入目标: %q", r.Target)
+	}
+	switch r.Action {
+	case ActionDelay:
+		if r.Delay <= 0 {
+			return fmt.Errorf("delay 规则需要指定延迟")
+		}
+	case ActionError, ActionKill:
+	default:
+		return fmt.Errorf("不支持的故障类型: %q", r.Action)
+	}
+	for _, pattern := range []string{r.Match, r.Method} {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("通配模式 %q 无效: %v", pattern, err)
+		}
+	}
+	if r.Probability < 0 || r.Probability > 1 {
+		return fmt.Errorf("概率必须在 0~1 之间")
+	}
+	if r.Probability == 0 {
+		r.Probability = 1
+	}
+	if r.Delay < 0 || r.MaxHits < 0 {
+		return fmt.Errorf("延迟和次数上限不能为负数")
+	}
+	if r.StatusCode == 0 {
+		r.StatusCode = 503
+	}
+	if r.StatusCode < 400 || r.StatusCode > 599 {
+		return fmt.Errorf("HTTP 状态码必须是 4xx 或 5xx")
+	}
+	return nil
+}
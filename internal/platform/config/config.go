@@ -14,6 +14,47 @@ type Config struct {
 	Pool          PoolConfig
 	McpPool       McpPoolConfig
 	QuickReply    QuickReplyConfig
+	Intent        IntentConfig
+	Dialogue      DialogueConfig
+	Pipeline      PipelineConfig
+	Translation   TranslationConfig
+	Language      LanguageConfig
+	TextChat      TextChatConfig
+	Transcript    TranscriptConfig
+	Recording     RecordingConfig
+	Startup       StartupConfig
+	Flags         FlagsConfig
+	Scheduling    SchedulingConfig
+	Workflow      WorkflowConfig
+	Validation    CapabilityValidationConfig
+	HTTPClient    HTTPClientConfig
+	PluginGRPC    PluginGRPCConfig
+	AdminGRPC     AdminGRPCConfig
+	PluginLogs    PluginLogsConfig
+	PluginMetrics PluginMetricsConfig
+	Moderation    ModerationConfig
+	Budget        BudgetConfig
+	ToolPolicy    ToolPolicyConfig
+	Memory        MemoryConfig
+	Knowledge     KnowledgeConfig
+	WebSearch     WebSearchConfig
+	Skills        SkillsConfig
+	Media         MediaConfig
+	SmartHome     SmartHomeConfig
+	Presence      PresenceConfig
+	Routines      RoutinesConfig
+	Offline       OfflineConfig
+	ProviderCalls ProviderCallsConfig
+	Chaos         ChaosConfig
+	DeviceDebug   DeviceDebugConfig
+	Provisioning  ProvisioningConfig
+	Analytics     AnalyticsConfig
+	Webhooks      WebhooksConfig
+	Jobs          JobsConfig
+	ObjectStore   ObjectStoreConfig
+	Backup        BackupConfig
+	Redaction     RedactionConfig
+	HTTPSecurity  HTTPSecurityConfig
 	LocalMCPFun   []LocalMCPFun
 	Selected      SelectedConfig
 	ASR           map[string]interface{}
@@ -39,6 +80,8 @@ type ServerConfig struct {
 	Token  string
 	Auth   AuthConfig
 	Device DeviceRegistrationConfig
+	// DrainTimeout 排空模式下等待活跃会话和工作流结束的最长时间
+	DrainTimeout time.Duration
 }
 
 type AuthConfig struct {
@@ -66,9 +109,22 @@ type DeviceRegistrationConfig struct {
 }
 
 type LogConfig struct {
-	Level string
-	Dir   string
-	File  string
+	Level         string
+	Dir           string
+	File          string
+	MaxSizeMB     int            // 单个日志文件大小上限，超过后轮转；0 表示只按天轮转
+	MaxBackups    int            // 保留的轮转文件数，0 表示不限
+	RetentionDays int            // 轮转文件保留天数，0 表示 7 天
+	Compress      bool           // 轮转后的文件用 gzip 压缩
+	Index         LogIndexConfig // 可查询的日志索引
+}
+
+// LogIndexConfig 日志索引配置，开启后日志同时写入数据库，可通过 /api/v1/logs 按级别、标签、时间和关键字查询
+type LogIndexConfig struct {
+	Enabled       bool
+	Level         string // 写入索引的最低级别，低于 Log.Level 时按 Log.Level
+	RetentionDays int    // 索引保留天数，0 表示永久保留
+	BufferSize    int    // 待写入队列长度，写满时丢弃新日志
 }
 
 type WebConfig struct {
@@ -149,8 +205,26 @@ type TransportConfig struct {
 }
 
 type WebSocketConfig struct {
-	Enabled bool
-	Port    int
+	Enabled    bool
+	Port       int
+	SendBuffer SendBufferConfig
+	MessageACL MessageACLConfig
+}
+
+// MessageACLConfig 按设备认证状态限制设备经 WebSocket 发送的消息，管理员修改认证状态后对已建立的连接立即生效
+// 已认证和未注册的设备不受限制；待认证设备只能发送 PendingMessages 中的文本消息，音频等二进制帧被丢弃；已拒绝的设备握手返回 403
+type MessageACLConfig struct {
+	Enabled         bool
+	PendingMessages []string // 待认证设备允许发送的文本消息类型
+}
+
+// SendBufferConfig 每个WebSocket连接的发送缓冲区配置
+// 文本消息不会丢弃，缓冲区满时阻塞发送方；音频帧按 AudioPolicy 处理
+type SendBufferConfig struct {
+	Capacity            int           // 最大排队帧数
+	AudioPolicy         string        // 缓冲区满时的音频帧策略：block、drop_oldest、drop_newest、downsample（半满后隔帧丢弃）
+	WriteTimeout        time.Duration // 单次写入及发送方阻塞等待的超时
+	SlowConsumerTimeout time.Duration // 缓冲区持续饱和超过该时长则断开连接
 }
 
 type MQTTUDPConfig struct {
@@ -189,6 +263,32 @@ type Role struct {
 type AudioConfig struct {
 	DeleteAudio  bool
 	SaveTTSAudio bool
+	Output       AudioOutputConfig
+	Preprocess   AudioPreprocessConfig
+}
+
+// AudioOutputConfig 下发给设备的 TTS 音频默认参数，设备可在 hello 的 output_audio_params 中另行协商
+type AudioOutputConfig struct {
+	SampleRate    int  // 采样率，opus 仅支持 8000/12000/16000/24000/48000，默认 24000
+	FrameDuration int  // 帧时长（毫秒），默认 60
+	Bitrate       int  // opus 码率（bps），0 表示由编码器自动选择
+	Complexity    int  // opus 编码复杂度 1-10，0 表示使用编码器默认值
+	InbandFEC     bool // 是否开启 opus 带内前向纠错，弱网下可减少丢包带来的卡顿
+}
+
+// AudioPreprocessConfig 上行音频预处理配置，在 VAD/ASR 之前对解码后的 PCM 做降噪和回声消除
+type AudioPreprocessConfig struct {
+	Profiles       map[string]AudioPreprocessProfile // 预处理策略，key为策略名称
+	DeviceProfiles map[string]string                 // 设备ID到策略名称的映射，未配置的设备使用 default
+}
+
+// AudioPreprocessProfile 预处理策略
+type AudioPreprocessProfile struct {
+	NoiseSuppression bool // 降噪（频谱减法）
+	SuppressionDB    int  // 降噪最大衰减（dB），默认 18
+	EchoCancellation bool // 以下发的 TTS 音频为参考信号消除回声
+	EchoTailMs       int  // 回声尾长（毫秒），默认 64，越长越耗 CPU
+	EchoDelayMs      int  // 服务端发送音频到设备采集到回声的延迟（毫秒），默认 150
 }
 
 // PoolConfig 连接池配置
@@ -204,6 +304,613 @@ type QuickReplyConfig struct {
 	Words   []string
 }
 
+// IntentConfig 意图路由配置
+// 在调用LLM之前对用户语句做意图分类，命中确定性意图时直接由处理器回复
+type IntentConfig struct {
+	Enabled         bool
+	Threshold       float64                // 最低置信度，低于该值时回落到LLM
+	Embedding       string                 // embedding 类型的能力ID，builtin:hash 为内置哈希向量；设置后规则未命中的语句按与示例语句的相似度分类，为空时不启用
+	EmbeddingConfig map[string]interface{} // 传给向量化能力的配置
+	Rules           []IntentRule
+}
+
+// IntentRule 意图规则
+type IntentRule struct {
+	Intent   string                 // 意图名称，如 time、timer、device_control
+	Keywords []string               // 关键词，包含即命中
+	Patterns []string               // 正则表达式，支持命名分组提取槽位
+	Examples []string               // 示例语句，供向量分类器使用
+	Tool     string                 // 设备控制意图对应的工具名称
+	Args     map[string]interface{} // 工具参数，字符串值支持 {slot} 占位符
+	Reply    string                 // 执行成功后的回复，支持 {slot} 占位符
+}
+
+// DialogueConfig 多轮对话状态机配置
+type DialogueConfig struct {
+	BargeInEnabled       bool // 服务端思考或说话时，用户开口即打断当前回复
+	BargeInMinChars      int  // 触发打断所需的最少识别字数，过滤回声和噪声
+	FirstSegmentMinChars int  // 回复首段达到该字数后遇到逗号等停顿即送入TTS，<=0 时首段也按整句切分
+	MaxSegmentChars      int  // 单个合成分段的最大字数，超出时强制切分，<=0 时不限制
+}
+
+// PipelineConfig 对话流水线配置
+//
+// 识别出的用户文本在送入 LLM 之前按设备所属的流水线（工作流图）处理，可在其中插入审核、
+// 检索增强、翻译、意图路由等节点；未配置流水线的设备使用内置流程（输入审核 -> 意图路由）
+type PipelineConfig struct {
+	Profiles       map[string]PipelineProfile // 流水线，key为流水线名称
+	DeviceProfiles map[string]string          // 设备ID到流水线名称的映射，未配置的设备使用 default
+}
+
+// PipelineProfile 对话流水线
+type PipelineProfile struct {
+	Workflow string // 工作流定义文件（JSON，格式与工作流接口相同），为空时使用内置流水线
+}
+
+// TranslationConfig 实时翻译模式配置
+//
+// 翻译模式下设备的语音识别后直接翻译并用目标语言的音色播报，不进入对话；
+// 通过语音指令（如“把中文翻译成英文”“退出翻译模式”）或接口按会话开启和关闭
+type TranslationConfig struct {
+	Enabled        bool
+	Provider       string                 // translation 类型的能力ID，为空时使用设备当前的LLM翻译
+	ProviderConfig map[string]interface{} // 传给翻译能力的配置
+	DefaultSource  string                 // 语音指令未指定源语言时使用，auto 表示自动识别
+	DefaultTarget  string                 // 语音指令未指定目标语言时使用
+	Voices         map[string]string      // 语言代码到TTS音色的映射，未配置的语言使用设备默认音色
+}
+
+// LanguageConfig 语言自动识别配置
+//
+// 启用后按每句识别结果的文字判断语言，在设备允许的语言中选择本轮播报的音色，并把允许的语言和
+// 当前语言作为 ASR 的语言提示；设备的允许语言可通过接口单独设置，未设置时使用 Allowed
+type LanguageConfig struct {
+	Enabled bool
+	Allowed []string          // 默认允许的语言代码，第一个为设备的主要语言
+	Voices  map[string]string // 语言代码到TTS音色的映射，主要语言和未配置的语言使用设备默认音色
+}
+
+// TextChatConfig 文本对话接口配置
+//
+// 设备在线时文本对话进入设备当前的会话；设备不在线或没有关联设备时，服务端创建不连接设备的会话，
+// 与语音设备使用相同的提示词、工具和对话流水线，空闲超时后释放
+type TextChatConfig struct {
+	Enabled     bool
+	IdleTimeout time.Duration // 无设备会话的空闲超时
+	MaxSessions int           // 同时保留的无设备会话上限，每个会话占用一组提供者资源
+}
+
+// TranscriptConfig 对话记录配置
+type TranscriptConfig struct {
+	Enabled       bool
+	RetentionDays int // 保留天数，<=0 表示永久保留
+}
+
+// RecordingConfig 用户语音归档配置
+//
+// 仅归档设备授权（consent）后的用户语音，音频使用 AES-256-GCM 加密后落盘，
+// 元数据通过会话ID和消息ID与对话记录关联
+type RecordingConfig struct {
+	Enabled             bool
+	Dir                 string // 加密音频的存放目录
+	EncryptionKey       string // base64 编码的 32 字节 AES-256 密钥，为空时不启用归档
+	RetentionDays       int    // 保留天数，<=0 表示永久保留
+	MaxUtteranceSeconds int    // 单段语音最多保留的时长，超出时丢弃最早的音频
+}
+
+// StartupConfig 服务启动流程配置，步骤ID见启动工作流（GET /api/v1/workflow/startup）
+// 依赖关系满足的步骤并行执行，各步骤耗时见 GET /api/v1/system/boot-report
+type StartupConfig struct {
+	Order       []string                 // 依赖已满足的步骤中优先启动的，按列出顺序；只影响配置加载之后的步骤
+	Skip        []string                 // 跳过的可选步骤，依赖它们的步骤随之跳过
+	MaxParallel int                      // 同时执行的步骤数上限，<=0 表示不限制，1 表示逐个执行
+	StepTimeout time.Duration            // 单个步骤的超时时间，<=0 时为 2 分钟；配置加载之前的步骤总是使用 2 分钟
+	Timeouts    map[string]time.Duration // 按步骤ID单独设置的超时时间
+}
+
+// FlagsConfig 功能开关配置，开关本身保存在数据库中，通过 /api/v1/flags 管理
+type FlagsConfig struct {
+	RefreshInterval time.Duration // 从数据库重新加载开关的间隔，多实例部署时其它实例的修改在该间隔内生效；<=0 表示只在本实例修改时更新
+}
+
+// SchedulingConfig 能力执行调度配置
+// 能力执行和设备对话的 LLM 调用在并发已满时按优先级排队：设备交互 > API 调用 > 批处理（工作流、评测），排队总数达到上限时挤出最低优先级的请求
+type SchedulingConfig struct {
+	Enabled               bool
+	MaxConcurrent         int           // 同时执行的能力调用上限
+	InteractiveQueueDepth int           // 设备交互请求的最大排队数
+	APIQueueDepth         int           // API 请求的最大排队数
+	BatchQueueDepth       int           // 批处理请求的最大排队数
+	MaxQueued             int           // 合计排队上限，<=0 表示各优先级之和
+	MaxWait               time.Duration // 最长排队时间，超时视为过载
+}
+
+// WorkflowConfig 工作流引擎配置
+type WorkflowConfig struct {
+	MaxWorkers         int               // 全部工作流执行共享的节点并发上限
+	DefaultParallelism int               // 工作流未设置 parallel_limit 时单个执行的节点并发上限
+	ConcurrencyClasses map[string]int    // 并发类别及其上限，如 {"llm": 4, "tts": 8}
+	CapabilityClasses  map[string]string // 能力ID或能力类型到并发类别的映射，未映射的能力归入 default 类别
+	Secrets            map[string]string // HTTP 节点请求头可通过 {{secret.NAME}} 引用的密钥，未配置的名称读取环境变量 XIAOZHI_SECRET_<NAME>
+	HTTPAllowedHosts   []string          // HTTP 节点允许访问的内网主机名、IP 或 CIDR，其余回环、链路本地和私有地址一律拒绝
+}
+
+// CapabilityValidationConfig 能力输入输出 schema 校验配置
+// 模式：off 不校验，lenient 校验失败只记录日志，strict 校验失败拒绝调用
+type CapabilityValidationConfig struct {
+	Mode         string            // 默认模式
+	Capabilities map[string]string // 按能力ID覆盖模式
+}
+
+// HTTPClientConfig 提供者HTTP客户端连接池配置
+type HTTPClientConfig struct {
+	Default   HTTPClientOptions
+	Providers map[string]HTTPClientOptions // 按提供者覆盖，key为提供者类型（openai、doubao等），零值字段沿用 Default
+}
+
+// HTTPClientOptions HTTP客户端连接参数
+type HTTPClientOptions struct {
+	Proxy                 string            // 代理地址，支持 http、https、socks5、socks5h，为空时使用 HTTP_PROXY/HTTPS_PROXY 环境变量
+	CAFile                string            // 额外信任的CA证书（PEM），与系统证书一起使用，用于自签名证书的代理或私有部署
+	Endpoints             map[string]string // 按主机名改写请求地址，如 api.openai.com -> https://eu.api.example.com，"*" 匹配全部主机，用于按区域路由
+	Timeout               time.Duration     // 整体请求超时，流式响应的提供者应保持为 0
+	DialTimeout           time.Duration
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration     // 等待响应头（首包）的超时
+	IdleConnTimeout       time.Duration     // 空闲连接保活时间
+	MaxIdleConnsPerHost   int
+	DisableHTTP2          bool
+}
+
+// PluginGRPCConfig 插件gRPC服务配置
+type PluginGRPCConfig struct {
+	Transport string // 监听方式：tcp（默认，使用动态端口）或 unix（单机部署使用Unix域套接字）
+	SocketDir string // Unix域套接字文件目录
+	MTLS      bool   // 核心与插件之间启用双向TLS，开发模式可关闭
+	CertDir   string // 插件证书输出目录，供独立进程的插件加载；为空时证书只保存在内存中
+	Debug     bool   // 启用gRPC反射和插件调试服务（GetInfo、DumpMetrics、SetLogLevel），便于 grpcurl 和管理后台查看运行中的插件
+}
+
+// AdminGRPCConfig 管理面gRPC服务配置
+// 提供与 /api/v1 HTTP接口对应的设备、插件、工作流和执行管理，调用方使用 Server.Token 认证
+type AdminGRPCConfig struct {
+	Enabled    bool
+	Address    string // 监听地址，如 127.0.0.1:9090
+	Reflection bool   // 启用服务反射，便于 grpcurl 等工具调试
+}
+
+// PluginLogsConfig 插件日志采集配置
+type PluginLogsConfig struct {
+	BufferLines   int    // 每个插件在内存中保留的日志行数
+	Dir           string // 日志文件目录，为空时只保留在内存中
+	MaxFileSizeMB int    // 单个日志文件大小上限，超过后轮转
+	MaxBackups    int    // 保留的轮转文件数
+}
+
+// PluginMetricsConfig 插件指标汇总配置
+// 核心定期调用各插件的 ScrapeMetrics，加上 plugin_id 标签后由 GET /api/v1/system/metrics 以 Prometheus 文本格式输出
+type PluginMetricsConfig struct {
+	Enabled  bool
+	Interval time.Duration // 采集间隔
+	Timeout  time.Duration // 单个插件的采集超时
+}
+
+// ModerationConfig 内容审核配置
+// 对用户输入和LLM输出（TTS之前）执行审核检查，按设备所属的审核策略决定处理动作
+type ModerationConfig struct {
+	Enabled        bool
+	BlockReply     string                       // 拦截时播报的回复
+	Checks         []ModerationCheck            // 审核检查项
+	Profiles       map[string]ModerationProfile // 审核策略，key为策略名称
+	DeviceProfiles map[string]string            // 设备ID到策略名称的映射，未配置的设备使用 default
+}
+
+// ModerationCheck 审核检查项
+type ModerationCheck struct {
+	Name      string
+	Type      string   // keyword、regex、provider、classifier
+	Category  string   // 命中时归入的类别，provider/classifier 使用返回的类别
+	Stages    []string // input、output，为空表示两者都检查
+	Keywords  []string // keyword 类型使用
+	Patterns  []string // regex 类型使用
+	APIKey    string   // provider 类型使用，OpenAI 兼容的审核接口
+	BaseURL   string
+	Model     string
+	Endpoint  string  // classifier 类型使用，本地分类服务地址
+	Threshold float64 // provider/classifier 的分数阈值
+}
+
+// ModerationProfile 审核策略
+type ModerationProfile struct {
+	DefaultAction string            // 未单独配置的类别使用的动作：block、redact、warn、allow
+	Actions       map[string]string // 类别到动作的映射
+}
+
+// BudgetConfig 对话Token预算
+// 每次调用LLM之前检查本轮、本次会话和设备当天的Token用量，超出上限时播报对应的提示而不调用LLM；
+// 设备使用 DeviceProfiles 指定的预算（未配置时使用 default），上限为 0 表示不限制
+type BudgetConfig struct {
+	Enabled        bool
+	Profiles       map[string]BudgetProfile // 预算，key为预算名称
+	DeviceProfiles map[string]string        // 设备ID到预算名称的映射，未配置的设备使用 default
+}
+
+// BudgetProfile Token预算，用量包括提示词和回复，提供者未返回用量时按文本估算
+type BudgetProfile struct {
+	TurnTokens    int64  // 每轮对话的上限，包括工具调用后的再次生成
+	SessionTokens int64  // 每次连接会话的上限
+	DailyTokens   int64  // 每台设备每天的上限，按服务器本地日期计算
+	TurnReply     string // 超出每轮上限时的播报，为空时使用内置提示
+	SessionReply  string // 超出会话上限时的播报
+	DailyReply    string // 超出每日上限时的播报
+}
+
+// ToolPolicyConfig 工具调用权限策略
+//
+// 设备对话和智能体调用 MCP 工具、工作流代设备调用能力时，按设备和智能体所属的策略检查是否允许，
+// 每次检查的结果都记入审计日志。设备使用 DeviceProfiles 指定的策略（未配置时使用 default），
+// 智能体使用 AgentProfiles 指定的策略（未配置时不受智能体策略限制），两者都允许时才放行
+type ToolPolicyConfig struct {
+	Enabled            bool
+	Profiles           map[string]ToolPolicyProfile // 策略，key为策略名称
+	DeviceProfiles     map[string]string            // 设备ID到策略名称的映射
+	AgentProfiles      map[string]string            // 智能体名称到策略名称的映射
+	DeviceAttributes   map[string]map[string]string // 设备属性（如 room），参数约束中以 ${device.属性} 引用
+	AuditRetentionDays int                          // 审计日志保留天数，<=0 表示永久保留
+}
+
+// ToolPolicyProfile 工具调用策略，规则按顺序匹配，第一条匹配的规则生效
+type ToolPolicyProfile struct {
+	DefaultAction string // 没有规则匹配时的动作：allow（默认）、deny
+	Rules         []ToolPolicyRule
+}
+
+// ToolPolicyRule 工具调用规则
+type ToolPolicyRule struct {
+	Name   string              // 规则名称，记入审计日志
+	Kind   string              // tool（MCP 工具）、capability（能力），为空表示两者
+	Match  []string            // 工具名称或能力ID，支持 * 通配，如 mcp_home_*
+	Action string              // allow、deny
+	Args   map[string][]string // allow 规则的参数约束：参数值需匹配其中之一，支持 * 通配和 ${device_id}、${agent}、${device.属性}；参数缺失视为不满足
+}
+
+// MemoryConfig 长期记忆配置
+// 已授权用户的语句由 LLM 提取出值得长期记住的事实，按成员的记忆命名空间保存，之后的对话中注入提示词
+type MemoryConfig struct {
+	Enabled     bool
+	LLM         string // 提取记忆使用的 LLM 配置名称，为空时使用 Selected.LLM
+	MinChars    int    // 短于该字数的用户语句不提取
+	MaxFacts    int    // 每个命名空间最多保留的记忆条数，超出时删除最久未更新的
+	InjectLimit int    // 每轮注入提示词的记忆条数
+}
+
+// KnowledgeConfig 知识库配置
+// 上传的文档经工作流任务提取文本、分块并向量化，设备按所属档案绑定的知识库检索参考资料后回答
+type KnowledgeConfig struct {
+	Enabled         bool
+	Dir             string                 // 上传文档和提取文本的保存目录
+	MaxDocumentMB   int                    // 单个文档（含网页）的大小上限
+	Embedding       string                 // embedding 类型的能力ID，为空时使用内置的字符 n-gram 哈希向量
+	EmbeddingConfig map[string]interface{} // 传给向量化能力的配置
+	ChunkSize       int                    // 分块的最大字数
+	ChunkOverlap    int                    // 相邻分块重叠的字数
+	TopK            int                    // 每轮检索的分块数
+	MinScore        float64                // 相似度低于该值的分块不作为参考资料
+	DeviceProfiles  map[string]string      // 设备ID到知识库档案的映射，未配置的设备使用 default
+}
+
+// WebSearchConfig 联网搜索配置
+// 搜索经能力注册表调用，每次调用的结果数、摘要字数和耗时受预算限制，避免搜索结果挤占上下文
+type WebSearchConfig struct {
+	Enabled         bool
+	Capability      string                 // search 类型的能力ID，默认内置的 web_search
+	Config          map[string]interface{} // 传给搜索能力的配置，如 backend、base_url、api_key
+	MaxResults      int                    // 每次调用最多使用的结果数
+	MaxSnippetChars int                    // 单条摘要的最大字数
+	MaxTotalChars   int                    // 每次调用附加给 LLM 的摘要总字数
+	Timeout         time.Duration          // 单次搜索的超时
+}
+
+// SkillsConfig 内置技能配置
+// 天气、新闻和日程查询由意图路由直接回答而不调用LLM，同时作为本地工具供LLM调用
+type SkillsConfig struct {
+	Enabled  bool
+	Timeout  time.Duration // 单次查询的超时
+	Weather  WeatherSkillConfig
+	News     NewsSkillConfig
+	Calendar CalendarSkillConfig
+}
+
+// SkillLocation 天气查询使用的位置，配置了经纬度时不再按名称查询
+type SkillLocation struct {
+	Name      string
+	Latitude  float64
+	Longitude float64
+}
+
+// WeatherSkillConfig 天气技能配置
+type WeatherSkillConfig struct {
+	Capability      string                   // 天气能力ID，默认内置的 weather_openmeteo
+	Config          map[string]interface{}   // 传给天气能力的配置
+	DefaultLocation SkillLocation            // 用户没有说地点、设备也未配置位置时使用
+	DeviceLocations map[string]SkillLocation // 设备ID到所在位置的映射
+}
+
+// NewsSkillConfig 新闻技能配置
+type NewsSkillConfig struct {
+	Capability string            // 新闻能力ID，默认内置的 news_rss
+	Feeds      map[string]string // 分类名称（如“科技”）到 RSS/Atom 地址，未说分类时使用 default
+	MaxItems   int               // 每次播报的新闻条数
+}
+
+// CalendarSkillConfig 日程技能配置
+type CalendarSkillConfig struct {
+	Capability    string                            // 日历能力ID，默认内置的 calendar_caldav
+	Config        map[string]interface{}            // 传给日历能力的配置，如 url、username、password
+	DeviceConfigs map[string]map[string]interface{} // 按设备ID覆盖的日历配置，用于不同用户的日历
+}
+
+// MediaConfig 媒体播放配置
+// 音乐和电台经设备的下行音频通道流式播放，播放、暂停、音量等语音指令由意图路由处理
+type MediaConfig struct {
+	Enabled        bool
+	LibraryDir     string                 // 本地曲库目录（MP3/WAV），为空时使用 System.MusicDir
+	Radios         []RadioStation         // 网络电台
+	Provider       string                 // media 类型的能力ID，用于检索曲库和电台之外的音频，为空时不使用
+	ProviderConfig map[string]interface{} // 传给媒体能力的配置
+	MaxResults     int                    // 每次检索加入播放列表的曲目数
+	DefaultVolume  int                    // 播放音量（0-100），对解码后的音频做增益
+	VolumeStep     int                    // “大声点”“小声点”每次调整的音量
+}
+
+// RadioStation 网络电台，URL 为 MP3 格式的音频流
+type RadioStation struct {
+	Name     string
+	URL      string
+	Keywords []string // 除名称外可用于点播的说法，如“交通台”
+}
+
+// SmartHomeConfig 智能家居配置
+// 没有 Home Assistant 时直接通过 Zigbee2MQTT、涂鸦云或 Matter Server 控制设备，设备统一映射为实体，
+// 开关、亮度、窗帘、温控等语音指令由意图路由处理，同时作为本地工具供LLM调用
+type SmartHomeConfig struct {
+	Enabled         bool
+	Bridges         []SmartHomeBridge
+	RefreshInterval time.Duration     // 重新发现实体的间隔
+	StateTTL        time.Duration     // 实体状态缓存的有效期，过期后查询时向桥接刷新
+	Timeout         time.Duration     // 单次调用桥接的超时
+	Entities        []SmartHomeEntity // 覆盖桥接上报的名称和房间，并设置别名
+}
+
+// SmartHomeBridge 智能家居桥接，由 smart_home 类型的能力实现
+type SmartHomeBridge struct {
+	Name       string                 // 桥接名称，作为实体ID的前缀，如 "z2m"
+	Capability string                 // 能力ID，如 smarthome_zigbee2mqtt、smarthome_tuya、smarthome_matter
+	Config     map[string]interface{} // 传给能力的配置
+}
+
+// SmartHomeEntity 实体的本地设置，ID 为 "<桥接名称>:<设备ID>"
+type SmartHomeEntity struct {
+	ID      string
+	Name    string
+	Room    string
+	Aliases []string // 除名称外可用于语音控制的说法，如“大灯”
+}
+
+// PresenceConfig 在家状态配置
+// 成员通过配套App签到（上报进出事件或位置），或由路由器按手机等设备的MAC地址判断是否在家
+type PresenceConfig struct {
+	Enabled   bool
+	Home      PresenceHomeConfig
+	AwayDelay time.Duration // 路由器连续多久未见到成员的设备后判定离家，避免手机休眠断开WiFi误判
+	Router    PresenceRouterConfig
+	People    []PresencePerson
+}
+
+// PresenceHomeConfig 家的地理围栏，App上报位置时按此判断进出
+type PresenceHomeConfig struct {
+	Latitude  float64
+	Longitude float64
+	Radius    float64 // 围栏半径（米）
+}
+
+// PresenceRouterConfig 路由器在线设备轮询，URL 为空时只接收路由器推送
+// 响应中出现的所有MAC地址都视为在线，可直接使用 OpenWrt 等路由器的在线设备或 ARP 表页面
+type PresenceRouterConfig struct {
+	URL      string
+	Username string // HTTP Basic 认证
+	Password string
+	Interval time.Duration
+	Timeout  time.Duration
+}
+
+// PresencePerson 参与在家判断的成员
+type PresencePerson struct {
+	UserID uint
+	MACs   []string // 成员手机等随身设备的MAC地址，路由器据此判断
+}
+
+// RoutinesConfig 例程配置
+// 例程按设备保存，由定时计划或唤醒短语触发，依次执行天气、日程、新闻播报等动作并合并成一段播报
+type RoutinesConfig struct {
+	Enabled       bool
+	MaxPerDevice  int           // 每台设备最多可创建的例程数
+	ActionTimeout time.Duration // 单个动作的执行上限
+}
+
+// OfflineConfig 离线模式配置
+// 周期性探测当前选择的云端 ASR、LLM、TTS 服务，连续失败达到阈值后切换到本地的替代服务，
+// 恢复后自动切回，切换时向在线设备播报提示
+type OfflineConfig struct {
+	Enabled               bool
+	Interval              time.Duration // 探测周期
+	Timeout               time.Duration // 单次探测超时
+	FailureThreshold      int           // 连续失败多少次切换到本地服务
+	SuccessThreshold      int           // 连续成功多少次切回云端服务
+	ASR                   string        // 离线时使用的 ASR 配置名称，为空时不切换 ASR
+	LLM                   string        // 离线时使用的 LLM 配置名称，为空时不切换 LLM
+	TTS                   string        // 离线时使用的 TTS 配置名称，为空时不切换 TTS
+	Announcement          string        // 进入离线模式时的播报，设备在离线期间连接时也会播报
+	RecoveredAnnouncement string        // 恢复在线时的播报
+}
+
+// ProviderCallsConfig 提供者调用录制配置
+// 按采样率录制能力调用的完整请求和响应，密钥类字段脱敏后保存，用于排查提供者问题
+type ProviderCallsConfig struct {
+	Enabled         bool
+	SampleRate      float64            // 默认采样率，0~1
+	Providers       map[string]float64 // 按提供者ID覆盖采样率，为 0 时不录制该提供者
+	RetentionHours  int                // 录制保留小时数，<=0 表示永久保留
+	MaxPayloadBytes int                // 请求和响应 JSON 各自的长度上限，超出部分截断
+}
+
+// ChaosConfig 故障注入配置
+// 仅在 Log.Level 为 debug 时生效，用于验证熔断、降级和插件重启等容错机制，不应在生产环境开启
+type ChaosConfig struct {
+	Enabled bool
+	RuleTTL time.Duration // 未指定有效期的规则默认保留时长
+}
+
+// DeviceDebugConfig 远程设备调试配置
+// 管理员经设备的 WebSocket 连接读取日志、执行固件定义的诊断命令和下发临时配置，每次操作都记入审计日志；
+// 设备还需在 hello 中声明 debug 特性，并通过 remote_debug 功能开关允许
+type DeviceDebugConfig struct {
+	Enabled            bool
+	Timeout            time.Duration // 等待设备回复的超时
+	MaxLogLines        int           // 单次读取的最大日志行数
+	MaxOverrideTTL     time.Duration // 临时配置的最长有效期，到期后由设备恢复原配置
+	AuditRetentionDays int           // 审计日志保留天数，<=0 表示永久保留
+}
+
+// ProvisioningConfig 扫码配对配置
+// 服务端为用户签发短时有效的一次性配对令牌并生成二维码内容，设备或配套 App 用令牌换取设备凭证，设备记录以该用户为所有者创建
+type ProvisioningConfig struct {
+	Enabled              bool
+	TokenTTL             time.Duration // 配对令牌的有效期
+	PublicURL            string        // 写入二维码的服务地址，如 https://example.com，为空时二维码只包含令牌
+	RequireCredential    bool          // 已签发凭证的设备连接 WebSocket 时必须携带凭证
+	LegacyActivationCode bool          // 是否仍接受 ACT_<设备ID> 形式的手动激活码
+	DeviceAuth           string        // 配对时签发的凭证：token（静态令牌，默认）或 jwt（设备密钥，设备用私钥签名短期 JWT）
+	JWTMaxAge            time.Duration // 设备签名的 JWT 最长有效期，exp 与 iat 之差超过该值时拒绝
+}
+
+// AnalyticsConfig 用量统计配置
+// 后台定期把对话记录、轮次耗时、Token 用量和意图路由结果汇总为按天和按周的统计，仪表盘直接读取汇总结果
+type AnalyticsConfig struct {
+	Enabled       bool
+	Interval      time.Duration // 重新汇总今天和昨天的间隔
+	BackfillDays  int           // 启动时重新汇总的天数
+	RetentionDays int           // 汇总结果保留天数，<=0 表示永久保留
+}
+
+// WebhooksConfig 事件推送配置
+// 管理员登记接收地址并按事件类型订阅，设备离线、插件异常、工作流失败、配额用尽等事件签名后推送，失败按指数退避重试
+type WebhooksConfig struct {
+	Enabled       bool
+	Timeout       time.Duration // 单次推送的超时时间
+	MaxAttempts   int           // 最大推送次数（含首次）
+	RetryBackoff  time.Duration // 首次重试的等待时间，之后每次翻倍
+	MaxBackoff    time.Duration // 重试等待时间上限
+	OfflineGrace  time.Duration // 设备断开后在该时长内重新连接则不推送离线事件，<=0 表示断开即推送
+	RetentionDays int           // 推送记录保留天数，<=0 表示永久保留
+}
+
+// JobsConfig 后台任务队列配置
+// 任务持久化到数据库，失败按指数退避重试，重试次数用尽后进入死信；关闭后各模块退回为进程内直接执行
+type JobsConfig struct {
+	Enabled        bool
+	Workers        int           // 每种任务类型的默认工作协程数，任务类型可单独指定
+	MaxAttempts    int           // 默认最大执行次数（含首次）
+	RetryBackoff   time.Duration // 首次重试的等待时间，之后每次翻倍
+	MaxBackoff     time.Duration // 重试等待时间上限
+	PollInterval   time.Duration // 空闲时检查新任务的间隔，本实例提交的任务会立即唤醒工作协程
+	LeaseDuration  time.Duration // 执行租约时长，进程退出后租约过期的任务由其他工作协程重新领取
+	RetentionHours int           // 成功和已取消任务的保留小时数，死信不自动删除；<=0 表示永久保留
+}
+
+// ObjectStoreConfig 对象存储配置
+// 音频、固件和上传的文档按内容寻址存放，接口只返回对象ID和带签名的下载地址；关闭后各模块沿用原来的本地文件和内联数据
+type ObjectStoreConfig struct {
+	Enabled         bool
+	Backend         string                   // local 或 s3
+	Dir             string                   // local 后端的存放目录
+	S3              S3Config                 // s3 后端的连接配置
+	SigningKey      string                   // 下载地址的签名密钥，为空时每次启动随机生成，重启后已签发的地址失效
+	PublicURL       string                   // 下载地址的前缀，如 https://example.com，为空时返回以 /api 开头的相对地址
+	URLExpiry       time.Duration            // 下载地址的默认有效期
+	MaxObjectMB     int                      // 单个对象的大小上限
+	TTL             map[string]time.Duration // 各命名空间新对象的保留时长，未配置的命名空间作为长期对象由引用方释放
+	CleanupInterval time.Duration            // 清理到期对象的间隔
+}
+
+// S3Config S3 兼容存储配置，适用于 AWS S3、MinIO 等支持 SigV4 签名的服务
+type S3Config struct {
+	Endpoint  string // 如 https://s3.us-east-1.amazonaws.com 或 http://minio:9000
+	Region    string
+	Bucket    string
+	Prefix    string // 对象键前缀，多个实例共用存储桶时区分
+	AccessKey string
+	SecretKey string
+	PathStyle bool // 使用 endpoint/bucket/key 形式的地址，MinIO 通常需要开启
+}
+
+// BackupConfig 备份配置
+// 备份包含数据库快照、上传的文件、插件配置和证书密钥，打包后用口令加密为单个文件
+type BackupConfig struct {
+	Enabled    bool
+	Dir        string        // 备份文件的存放目录
+	Passphrase string        // 加密口令，环境变量 XIAOZHI_BACKUP_PASSPHRASE 优先；均为空时不能创建备份
+	Interval   time.Duration // 自动备份的间隔，<=0 表示只手动备份
+	Keep       int           // 保留的自动备份数量，<=0 表示全部保留
+	Paths      []string      // 需要备份的文件和目录，不存在的跳过；SQLite 数据库文件总是以快照方式备份
+}
+
+// RedactionConfig 敏感信息脱敏配置
+// 正则检测器同时用于日志和对话记录，NER检测器开销较大，仅用于对话记录
+type RedactionConfig struct {
+	Enabled           bool
+	RedactLogs        bool
+	RedactTranscripts bool
+	Detectors         []RedactionDetector
+}
+
+// RedactionDetector 脱敏检测器
+type RedactionDetector struct {
+	Name     string
+	Type     string   // regex、ner
+	Pattern  string   // regex 类型使用
+	Label    string   // 替换后的占位标签，如 PHONE
+	Endpoint string   // ner 类型使用，实体识别服务地址
+	Entities []string // ner 类型需要脱敏的实体类型，如 PERSON、ADDRESS，为空表示全部
+}
+
+// HTTPSecurityConfig HTTP 安全中间件配置，各项可按部署环境分别开关，零值保持原有行为
+type HTTPSecurityConfig struct {
+	HSTS         string        // auto（默认，仅 release 模式发送）、on、off；只应在 HTTPS 部署中开启
+	HSTSMaxAge   time.Duration // 默认一年
+	FrameOptions string        // X-Frame-Options，默认 DENY，off 表示不发送
+	CSP          string        // 前端页面的 Content-Security-Policy，为空时使用内置策略，off 表示不发送
+	CORS         CORSConfig
+	CSRF         CSRFConfig
+}
+
+// CORSConfig 跨域访问配置
+type CORSConfig struct {
+	AllowedOrigins   []string      // 允许的来源，如 https://admin.example.com；为空时允许任意来源
+	AllowCredentials bool          // 允许携带 Cookie，只对列出的来源生效
+	MaxAge           time.Duration // 浏览器缓存预检结果的时间
+}
+
+// CSRFConfig 基于 Cookie 的 Web 会话的 CSRF 防护配置
+// 采用双重提交：服务端下发令牌 Cookie，前端在写请求的请求头中回传；令牌只校验携带 Cookie 且没有 API 令牌的请求，
+// 没有 API 令牌的写请求还要校验 Origin/Referer
+type CSRFConfig struct {
+	Enabled    bool
+	CookieName string // 默认 XSRF-TOKEN，与 axios 的默认设置一致
+	HeaderName string // 默认 X-XSRF-TOKEN
+	Secure     bool   // 令牌 Cookie 只通过 HTTPS 发送
+}
+
 // LocalMCPFun 本地MCP函数配置
 type LocalMCPFun struct {
 	Name        string
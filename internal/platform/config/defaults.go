@@ -24,11 +24,22 @@ func DefaultConfig() *Config {
 				RequireActivationCode: false, // 默认不需要激活码
 				DefaultAdminUserID:    1,     // 默认管理员用户ID
 			},
+			DrainTimeout: 5 * time.Minute,
 		},
 		Log: LogConfig{
-			Level: "INFO",
-			Dir:   "data/logs",
-			File:  "server.log",
+			Level:         "INFO",
+			Dir:           "data/logs",
+			File:          "server.log",
+			MaxSizeMB:     100,
+			MaxBackups:    30,
+			RetentionDays: 7,
+			Compress:      true,
+			Index: LogIndexConfig{
+				Enabled:       true,
+				Level:         "INFO",
+				RetentionDays: 7,
+				BufferSize:    4096,
+			},
 		},
 		Web: WebConfig{
 			Enabled:   true,
@@ -40,6 +51,16 @@ func DefaultConfig() *Config {
 			WebSocket: WebSocketConfig{
 				Enabled: true,
 				Port:    8000,
+				SendBuffer: SendBufferConfig{
+					Capacity:            256,
+					AudioPolicy:         "drop_oldest",
+					WriteTimeout:        10 * time.Second,
+					SlowConsumerTimeout: 15 * time.Second,
+				},
+				MessageACL: MessageACLConfig{
+					Enabled:         false,
+					PendingMessages: []string{"hello", "ping", "mcp"},
+				},
 			},
 			MQTTUDP: MQTTUDPConfig{
 				Enabled: true,
@@ -88,6 +109,22 @@ func DefaultConfig() *Config {
 		Audio: AudioConfig{
 			DeleteAudio:  false,
 			SaveTTSAudio: false,
+			Output: AudioOutputConfig{
+				SampleRate:    24000,
+				FrameDuration: 60,
+			},
+			Preprocess: AudioPreprocessConfig{
+				Profiles: map[string]AudioPreprocessProfile{
+					"default": {},
+					"noisy_room": {
+						NoiseSuppression: true,
+						SuppressionDB:    18,
+						EchoCancellation: true,
+						EchoTailMs:       64,
+						EchoDelayMs:      150,
+					},
+				},
+			},
 		},
 		Pool:    PoolConfig{},
 		McpPool: McpPoolConfig{},
@@ -102,12 +139,399 @@ func DefaultConfig() *Config {
 				"请讲",
 			},
 		},
+		Dialogue: DialogueConfig{
+			BargeInEnabled:       true,
+			BargeInMinChars:      2,
+			FirstSegmentMinChars: 4,
+			MaxSegmentChars:      60,
+		},
+		Translation: TranslationConfig{
+			Enabled:       true,
+			DefaultSource: "auto",
+			DefaultTarget: "en",
+		},
+		Language: LanguageConfig{
+			Enabled: false,
+			Allowed: []string{"zh", "en"},
+		},
+		TextChat: TextChatConfig{
+			Enabled:     true,
+			IdleTimeout: 10 * time.Minute,
+			MaxSessions: 20,
+		},
+		Budget: BudgetConfig{
+			Enabled: false,
+			Profiles: map[string]BudgetProfile{
+				"default": {
+					TurnTokens: 16000,
+				},
+				"kids": {
+					TurnTokens:    4000,
+					SessionTokens: 20000,
+					DailyTokens:   50000,
+				},
+			},
+		},
+		ToolPolicy: ToolPolicyConfig{
+			Enabled:            true,
+			AuditRetentionDays: 90,
+		},
+		Memory: MemoryConfig{
+			Enabled:     true,
+			MinChars:    4,
+			MaxFacts:    200,
+			InjectLimit: 8,
+		},
+		Knowledge: KnowledgeConfig{
+			Enabled:       true,
+			Dir:           "data/knowledge",
+			MaxDocumentMB: 20,
+			ChunkSize:     500,
+			ChunkOverlap:  50,
+			TopK:          4,
+			MinScore:      0.3,
+		},
+		WebSearch: WebSearchConfig{
+			Enabled:    false,
+			Capability: "web_search",
+			Config: map[string]interface{}{
+				"backend":  "searxng",
+				"base_url": "http://127.0.0.1:8888",
+				"language": "zh-CN",
+			},
+			MaxResults:      5,
+			MaxSnippetChars: 200,
+			MaxTotalChars:   1500,
+			Timeout:         8 * time.Second,
+		},
+		Skills: SkillsConfig{
+			Enabled: true,
+			Timeout: 8 * time.Second,
+			Weather: WeatherSkillConfig{
+				Capability: "weather_openmeteo",
+			},
+			News: NewsSkillConfig{
+				Capability: "news_rss",
+				MaxItems:   5,
+			},
+			Calendar: CalendarSkillConfig{
+				Capability: "calendar_caldav",
+			},
+		},
+		Media: MediaConfig{
+			Enabled:       true,
+			MaxResults:    10,
+			DefaultVolume: 80,
+			VolumeStep:    15,
+		},
+		SmartHome: SmartHomeConfig{
+			Enabled:         false,
+			RefreshInterval: 10 * time.Minute,
+			StateTTL:        30 * time.Second,
+			Timeout:         10 * time.Second,
+		},
+		Presence: PresenceConfig{
+			Enabled:   false,
+			Home:      PresenceHomeConfig{Radius: 100},
+			AwayDelay: 10 * time.Minute,
+			Router: PresenceRouterConfig{
+				Interval: time.Minute,
+				Timeout:  10 * time.Second,
+			},
+		},
+		Routines: RoutinesConfig{
+			Enabled:       true,
+			MaxPerDevice:  20,
+			ActionTimeout: 30 * time.Second,
+		},
+		Offline: OfflineConfig{
+			Enabled:               false,
+			Interval:              30 * time.Second,
+			Timeout:               5 * time.Second,
+			FailureThreshold:      2,
+			SuccessThreshold:      2,
+			ASR:                   "WhisperASR",
+			LLM:                   "OllamaLLM",
+			TTS:                   "GoSherpaTTS",
+			Announcement:          "网络连接中断，已切换到离线模式，部分功能暂时不可用",
+			RecoveredAnnouncement: "网络已恢复，已切换回在线模式",
+		},
+		ProviderCalls: ProviderCallsConfig{
+			Enabled:         false,
+			SampleRate:      0.1,
+			Providers:       map[string]float64{},
+			RetentionHours:  72,
+			MaxPayloadBytes: 16384,
+		},
+		Chaos: ChaosConfig{
+			Enabled: false,
+			RuleTTL: 10 * time.Minute,
+		},
+		DeviceDebug: DeviceDebugConfig{
+			Enabled:            true,
+			Timeout:            10 * time.Second,
+			MaxLogLines:        500,
+			MaxOverrideTTL:     time.Hour,
+			AuditRetentionDays: 180,
+		},
+		Provisioning: ProvisioningConfig{
+			Enabled:              true,
+			TokenTTL:             10 * time.Minute,
+			RequireCredential:    true,
+			LegacyActivationCode: true,
+			DeviceAuth:           "token",
+			JWTMaxAge:            10 * time.Minute,
+		},
+		Analytics: AnalyticsConfig{
+			Enabled:       true,
+			Interval:      15 * time.Minute,
+			BackfillDays:  7,
+			RetentionDays: 400,
+		},
+		Webhooks: WebhooksConfig{
+			Enabled:       true,
+			Timeout:       10 * time.Second,
+			MaxAttempts:   8,
+			RetryBackoff:  30 * time.Second,
+			MaxBackoff:    time.Hour,
+			OfflineGrace:  time.Minute,
+			RetentionDays: 30,
+		},
+		Jobs: JobsConfig{
+			Enabled:        true,
+			Workers:        2,
+			MaxAttempts:    5,
+			RetryBackoff:   10 * time.Second,
+			MaxBackoff:     10 * time.Minute,
+			PollInterval:   2 * time.Second,
+			LeaseDuration:  time.Minute,
+			RetentionHours: 168,
+		},
+		ObjectStore: ObjectStoreConfig{
+			Enabled: true,
+			Backend: "local",
+			Dir:     "data/objects",
+			S3: S3Config{
+				Region: "us-east-1",
+			},
+			URLExpiry:   time.Hour,
+			MaxObjectMB: 100,
+			TTL: map[string]time.Duration{
+				"tts": 24 * time.Hour,
+				"asr": 72 * time.Hour,
+			},
+			CleanupInterval: 10 * time.Minute,
+		},
+		Backup: BackupConfig{
+			Enabled:  true,
+			Dir:      "data/backups",
+			Interval: 24 * time.Hour,
+			Keep:     7,
+			Paths: []string{
+				"data/db.json",
+				"data/objects",
+				"data/knowledge",
+				"data/recordings",
+				"data/ota_bin",
+				"data/uploads",
+				"data/workflow.json",
+				"data/tenants",
+				"data/plugin-certs",
+				"config",
+			},
+		},
+		Transcript: TranscriptConfig{
+			Enabled:       true,
+			RetentionDays: 30,
+		},
+		Recording: RecordingConfig{
+			Enabled:             false,
+			Dir:                 "data/recordings",
+			RetentionDays:       30,
+			MaxUtteranceSeconds: 30,
+		},
+		Startup: StartupConfig{
+			StepTimeout: 2 * time.Minute,
+		},
+		Flags: FlagsConfig{
+			RefreshInterval: 30 * time.Second,
+		},
+		Scheduling: SchedulingConfig{
+			Enabled:               true,
+			MaxConcurrent:         32,
+			InteractiveQueueDepth: 64,
+			APIQueueDepth:         64,
+			BatchQueueDepth:       256,
+			MaxQueued:             256,
+			MaxWait:               30 * time.Second,
+		},
+		Workflow: WorkflowConfig{
+			MaxWorkers:         16,
+			DefaultParallelism: 5,
+		},
+		Validation: CapabilityValidationConfig{
+			Mode: "lenient",
+		},
+		HTTPClient: HTTPClientConfig{
+			Default: HTTPClientOptions{
+				DialTimeout:           10 * time.Second,
+				TLSHandshakeTimeout:   10 * time.Second,
+				ResponseHeaderTimeout: 60 * time.Second,
+				IdleConnTimeout:       90 * time.Second,
+				MaxIdleConnsPerHost:   16,
+			},
+		},
+		PluginGRPC: PluginGRPCConfig{
+			Transport: "tcp",
+			SocketDir: "./data/plugin-sockets",
+			MTLS:      true,
+			CertDir:   "./data/plugin-certs",
+		},
+		AdminGRPC: AdminGRPCConfig{
+			Enabled: false,
+			Address: "127.0.0.1:9090",
+		},
+		PluginLogs: PluginLogsConfig{
+			BufferLines:   5000,
+			Dir:           "./logs/plugins",
+			MaxFileSizeMB: 10,
+			MaxBackups:    3,
+		},
+		PluginMetrics: PluginMetricsConfig{
+			Enabled:  true,
+			Interval: 15 * time.Second,
+			Timeout:  5 * time.Second,
+		},
+		Moderation: ModerationConfig{
+			Enabled:    true,
+			BlockReply: "抱歉，这个问题我不能回答，我们换个话题吧",
+			Checks: []ModerationCheck{
+				{
+					Name:     "secrets",
+					Type:     "regex",
+					Category: "secret",
+					Patterns: []string{
+						`sk-[A-Za-z0-9]{20,}`,
+						`AKIA[0-9A-Z]{16}`,
+					},
+				},
+			},
+			Profiles: map[string]ModerationProfile{
+				"default": {
+					DefaultAction: "warn",
+					Actions: map[string]string{
+						"secret": "redact",
+					},
+				},
+				"kids": {
+					DefaultAction: "block",
+					Actions: map[string]string{
+						"secret": "redact",
+					},
+				},
+			},
+		},
+		Redaction: RedactionConfig{
+			Enabled:           true,
+			RedactLogs:        true,
+			RedactTranscripts: true,
+			Detectors: []RedactionDetector{
+				{Name: "api_key", Type: "regex", Label: "API_KEY", Pattern: `\b(sk-[A-Za-z0-9_-]{20,}|AKIA[0-9A-Z]{16}|[Bb]earer\s+[A-Za-z0-9._-]{20,})`},
+				{Name: "email", Type: "regex", Label: "EMAIL", Pattern: `[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`},
+				{Name: "id_card", Type: "regex", Label: "ID_CARD", Pattern: `\b[1-9]\d{5}(19|20)\d{2}(0[1-9]|1[0-2])(0[1-9]|[12]\d|3[01])\d{3}[\dXx]\b`},
+				{Name: "phone", Type: "regex", Label: "PHONE", Pattern: `(\+?86[- ]?)?1[3-9]\d{9}\b`},
+			},
+		},
+		HTTPSecurity: HTTPSecurityConfig{
+			HSTS:         "auto",
+			HSTSMaxAge:   365 * 24 * time.Hour,
+			FrameOptions: "DENY",
+			CSRF: CSRFConfig{
+				Enabled:    true,
+				CookieName: "XSRF-TOKEN",
+				HeaderName: "X-XSRF-TOKEN",
+			},
+		},
+		Intent: IntentConfig{
+			Enabled:   true,
+			Threshold: 0.6,
+			Rules: []IntentRule{
+				{
+					Intent:   "time",
+					Keywords: []string{"几点了", "现在几点", "现在时间", "今天几号", "今天星期几", "今天周几", "今天是几号"},
+				},
+				{
+					Intent:   "timer",
+					Patterns: []string{
+						`(?P<amount>[0-9零一二两三四五六七八九十百半]+)个?(?P<unit>秒钟?|分钟|小时|钟头)以?后(提醒我|叫我)(?P<content>.*)`,
+						`(定|设)一?个(?P<amount>[0-9零一二两三四五六七八九十百半]+)个?(?P<unit>秒钟?|分钟|小时|钟头)的?(计时器|倒计时|闹钟)`,
+					},
+				},
+				{
+					Intent:   "weather",
+					Keywords: []string{"天气", "气温", "下雨吗", "下雪吗", "带伞"},
+				},
+				{
+					Intent:   "news",
+					Keywords: []string{"新闻", "头条"},
+				},
+				{
+					Intent:   "calendar",
+					Keywords: []string{"日程", "有什么安排", "有哪些安排", "行程"},
+				},
+				{
+					Intent:   "media",
+					Patterns: []string{
+						`^(请|帮我)?(播放|放一首|放首|放一下|来一首|来首|我想听|我要听)(?P<query>.*)$`,
+						`^(请|帮我)?(关掉|关闭)(音乐|电台)(吧)?$`,
+					},
+					Keywords: []string{"暂停", "继续播放", "接着放", "停止播放", "别放了", "关掉音乐", "下一首", "换一首", "大声点", "大声一点", "小声点", "小声一点"},
+				},
+				{
+					Intent:   "device_control",
+					Patterns: []string{`(音量|声音)(调到|设置为|设为)(?P<volume>[0-9]+)`},
+					Tool:     "self.audio_speaker.set_volume",
+					Args:     map[string]interface{}{"volume": "{volume}"},
+					Reply:    "好的，音量已调到{volume}",
+				},
+				{
+					Intent:   "feedback",
+					Patterns: []string{`^(你)?(说|回答|答|讲)(错了|得不对|的不对)(吧|啊|呀)?$`, `^(不对|错了)(不对)?$`},
+					Args:     map[string]interface{}{"rating": "down"},
+					Reply:    "抱歉，我记下了，会继续改进",
+				},
+				{
+					Intent:   "feedback",
+					Patterns: []string{`^(你)?(说|回答|答|讲)得(真|很|太)?(好|对|棒)(了)?(啊|呀)?$`},
+					Args:     map[string]interface{}{"rating": "up"},
+					Reply:    "谢谢你的鼓励",
+				},
+				{
+					Intent: "smart_home",
+					Patterns: []string{
+						`^(请|帮我)?(把)?(?P<target>.+?)(打开|开启|关闭|关掉|关上|拉开|合上|拉上|锁上|解锁)(一下)?(吧|了)?$`,
+						`^(请|帮我)?(打开|开启|开一下|关闭|关掉|关上|关一下|拉开|合上|拉上|锁上|解锁)(一下)?(?P<target>.+)$`,
+						`^(请|帮我)?(开|关)(?P<target>.{1,5})$`,
+						`^(请|帮我)?(把)?(?P<target>.+?)(调到|调成|调至|设为|设置为|设置成)(?P<value>[0-9]+)(度|%)?$`,
+					},
+				},
+			},
+		},
 		LocalMCPFun: []LocalMCPFun{
 			{Name: "time", Description: "获取当前时间", Enabled: true},
 			{Name: "exit", Description: "退出程序", Enabled: true},
 			{Name: "change_role", Description: "切换角色", Enabled: true},
 			{Name: "play_music", Description: "播放音乐", Enabled: true},
 			{Name: "change_voice", Description: "切换声音", Enabled: true},
+			{Name: "reminder", Description: "设置计时器、闹钟和提醒", Enabled: true},
+			{Name: "call_agent", Description: "把任务交给已定义的智能体", Enabled: true},
+			{Name: "web_search", Description: "联网搜索", Enabled: true},
+			{Name: "weather", Description: "查询天气", Enabled: true},
+			{Name: "news", Description: "播报新闻", Enabled: true},
+			{Name: "calendar", Description: "查询日程", Enabled: true},
+			{Name: "media_control", Description: "控制音乐播放", Enabled: true},
+			{Name: "smart_home", Description: "控制智能家居设备", Enabled: true},
+			{Name: "routines", Description: "创建和管理例程", Enabled: true},
 		},
 		Selected: SelectedConfig{
 			ASR:   "DoubaoASR",
@@ -127,6 +551,12 @@ func DefaultConfig() *Config {
 				"type": "gosherpa",
 				"addr": "ws://127.0.0.1:8848/asr",
 			},
+			"WhisperASR": map[string]interface{}{
+				"type":       "whispercpp",
+				"addr":       "http://127.0.0.1:8080/inference",
+				"language":   "zh",
+				"silence_ms": 800,
+			},
 			"DeepgramSST": map[string]interface{}{
 				"type":     "deepgram",
 				"addr":     "wss://api.deepgram.com/v1/listen",
@@ -76,70 +76,15 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/devices": {
+        "/v1/agents": {
             "get": {
-                "description": "获取设备列表，支持分页和过滤",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Devices"
-                ],
-                "summary": "获取设备列表",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "按状态过滤",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "按设备类型过滤",
-                        "name": "device_type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "搜索关键词",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "每页数量",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "default": "created_at",
-                        "description": "排序字段",
-                        "name": "sort_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "default": "desc",
-                        "description": "排序方向",
-                        "name": "sort_order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "是否返回位置信息",
-                        "name": "location",
-                        "in": "query"
-                    }
+                    "Agents"
                 ],
+                "summary": "获取智能体列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -152,7 +97,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.DeviceListResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.AgentInfo"
+                                            }
                                         }
                                     }
                                 }
@@ -162,7 +110,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "新设备注册到系统",
+                "description": "创建有独立提示词、模型和工具白名单的智能体；配置 delegates 的智能体可以把子任务委托给其他智能体",
                 "consumes": [
                     "application/json"
                 ],
@@ -170,17 +118,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Devices"
+                    "Agents"
                 ],
-                "summary": "设备注册",
+                "summary": "创建智能体",
                 "parameters": [
                     {
-                        "description": "设备注册信息",
+                        "description": "智能体信息",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.DeviceRegistrationRequest"
+                            "$ref": "#/definitions/v1.AgentCreateRequest"
                         }
                     }
                 ],
@@ -196,59 +144,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.DeviceInfo"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/httptransport.APIResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/v1/devices/status": {
-            "post": {
-                "description": "管理员快速激活或禁用设备，通过设备MAC地址和激活状态",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Devices"
-                ],
-                "summary": "管理员激活/禁用设备",
-                "parameters": [
-                    {
-                        "description": "设备状态管理请求",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.DeviceStatusRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/httptransport.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/v1.DeviceStatusResponse"
+                                            "$ref": "#/definitions/v1.AgentInfo"
                                         }
                                     }
                                 }
@@ -260,30 +156,23 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/httptransport.APIResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/httptransport.APIResponse"
-                        }
                     }
                 }
             }
         },
-        "/v1/devices/{id}": {
+        "/v1/agents/{id}": {
             "get": {
-                "description": "根据ID获取设备的详细信息",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Devices"
+                    "Agents"
                 ],
-                "summary": "获取设备详情",
+                "summary": "获取智能体详情",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "设备ID",
+                        "description": "智能体ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -301,7 +190,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.DeviceInfo"
+                                            "$ref": "#/definitions/v1.AgentInfo"
                                         }
                                     }
                                 }
@@ -317,7 +206,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "更新指定设备的信息",
+                "description": "被其他智能体委托的智能体不能改名",
                 "consumes": [
                     "application/json"
                 ],
@@ -325,24 +214,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Devices"
+                    "Agents"
                 ],
-                "summary": "更新设备信息",
+                "summary": "更新智能体",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "设备ID",
+                        "description": "智能体ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "设备更新信息",
+                        "description": "更新内容",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.DeviceUpdateRequest"
+                            "$ref": "#/definitions/v1.AgentUpdateRequest"
                         }
                     }
                 ],
@@ -358,7 +247,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.DeviceInfo"
+                                            "$ref": "#/definitions/v1.AgentInfo"
                                         }
                                     }
                                 }
@@ -380,18 +269,18 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "从系统中删除指定设备",
+                "description": "被其他智能体委托的智能体不能删除",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Devices"
+                    "Agents"
                 ],
-                "summary": "删除设备",
+                "summary": "删除智能体",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "设备ID",
+                        "description": "智能体ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -404,6 +293,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/httptransport.APIResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -413,9 +308,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/devices/{id}/activate": {
+        "/v1/agents/{id}/run": {
             "post": {
-                "description": "激活已注册的设备",
+                "description": "以给定输入运行智能体，返回回复以及按顺序记录的工具调用和委托",
                 "consumes": [
                     "application/json"
                 ],
@@ -423,24 +318,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Devices"
+                    "Agents"
                 ],
-                "summary": "激活设备",
+                "summary": "运行智能体",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "设备ID",
+                        "description": "智能体ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "设备激活信息",
+                        "description": "输入",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.DeviceActivationRequest"
+                            "$ref": "#/definitions/v1.AgentRunRequest"
                         }
                     }
                 ],
@@ -456,7 +351,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.DeviceActivationResponse"
+                                            "$ref": "#/definitions/v1.AgentRunResult"
                                         }
                                     }
                                 }
@@ -478,90 +373,126 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/plugins": {
+        "/v1/analytics/rollups": {
             "get": {
-                "description": "获取所有插件的信息，支持分页、筛选和排序",
+                "description": "返回后台定期预先汇总的对话数、平均延迟、Token和语音用量、热门意图、各提供者的出错比例和设备活跃情况，按周期起始日正序；不会在请求时扫描对话记录",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "plugins"
+                    "Analytics"
                 ],
-                "summary": "获取插件列表",
+                "summary": "按天或按周列出用量汇总",
                 "parameters": [
                     {
                         "enum": [
-                            "LLM",
-                            "TTS",
-                            "ASR",
-                            "Tool"
+                            "day",
+                            "week"
                         ],
                         "type": "string",
-                        "description": "插件类型",
-                        "name": "type",
+                        "description": "汇总周期，默认 day",
+                        "name": "period",
                         "in": "query"
                     },
                     {
-                        "enum": [
-                            "installed",
-                            "enabled",
-                            "disabled",
-                            "running",
-                            "stopped",
-                            "error"
-                        ],
                         "type": "string",
-                        "description": "插件状态",
-                        "name": "status",
+                        "description": "起始日期 YYYY-MM-DD，默认往前 7 天或 8 周",
+                        "name": "from",
                         "in": "query"
                     },
                     {
-                        "enum": [
-                            "healthy",
-                            "unhealthy",
-                            "unknown"
-                        ],
                         "type": "string",
-                        "description": "健康状态",
-                        "name": "health_status",
+                        "description": "结束日期 YYYY-MM-DD，默认今天",
+                        "name": "to",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "页码",
-                        "name": "page",
+                        "type": "string",
+                        "description": "只返回该设备的活跃情况",
+                        "name": "device_id",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "default": 20,
-                        "description": "每页大小",
-                        "name": "page_size",
+                        "description": "意图和设备列表的条数，默认 20，最多 500",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.AnalyticsRollup"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/analytics/summary": {
+            "get": {
+                "description": "把查询范围内的按天汇总合并为一个汇总，活跃设备数按设备去重",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "查询范围内的用量合计",
+                "parameters": [
+                    {
+                        "enum": [
+                            "day",
+                            "week"
+                        ],
+                        "type": "string",
+                        "description": "范围的对齐方式，默认 day",
+                        "name": "period",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "default": "updated_at",
-                        "description": "排序字段",
-                        "name": "sort_by",
+                        "description": "起始日期 YYYY-MM-DD，默认往前 7 天或 8 周",
+                        "name": "from",
                         "in": "query"
                     },
                     {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
                         "type": "string",
-                        "default": "desc",
-                        "description": "排序方向",
-                        "name": "sort_order",
+                        "description": "结束日期 YYYY-MM-DD，默认今天",
+                        "name": "to",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "搜索关键词",
-                        "name": "search",
+                        "description": "只返回该设备的活跃情况",
+                        "name": "device_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "意图和设备列表的条数，默认 20，最多 500",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
@@ -571,57 +502,91 @@ const docTemplate = `{
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/v1.APIResponse"
+                                    "$ref": "#/definitions/httptransport.APIResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.PluginListResponse"
+                                            "$ref": "#/definitions/v1.AnalyticsRollup"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
                     }
                 }
             }
         },
-        "/v1/plugins/capabilities": {
+        "/v1/approvals": {
             "get": {
-                "description": "获取所有插件的能力定义",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "plugins"
+                    "Approvals"
+                ],
+                "summary": "List approval tasks",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected",
+                            "expired",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    }
                 ],
-                "summary": "获取所有插件能力",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/workflow.ApprovalTask"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/v1/plugins/capabilities/{type}": {
+        "/v1/approvals/{id}": {
             "get": {
-                "description": "根据类型筛选插件能力",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "plugins"
+                    "Approvals"
                 ],
-                "summary": "按类型获取插件能力",
+                "summary": "Get approval task",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "能力类型",
-                        "name": "type",
+                        "description": "Approval ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -630,164 +595,228 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/workflow.ApprovalTask"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/plugins/ports": {
-            "get": {
-                "description": "获取端口使用情况统计",
+        "/v1/approvals/{id}/decision": {
+            "post": {
+                "description": "Approves or rejects a pending task, which resumes or aborts the waiting execution. Deciding an already decided task returns 409 with the task in error.details",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "plugins"
+                    "Approvals"
+                ],
+                "summary": "Decide approval task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Approval ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Decision",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.ApprovalDecisionRequest"
+                        }
+                    }
                 ],
-                "summary": "获取端口统计信息",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/v1.APIResponse"
+                                    "$ref": "#/definitions/httptransport.APIResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.PortStats"
+                                            "$ref": "#/definitions/workflow.ApprovalTask"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
                     }
                 }
             }
         },
-        "/v1/plugins/stats": {
+        "/v1/backups": {
             "get": {
-                "description": "获取插件的数量、状态分布、健康状态等统计信息",
+                "description": "列出备份目录中的备份文件，最新的在前，并返回自动备份设置",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "plugins"
+                    "Backups"
                 ],
-                "summary": "获取插件统计信息",
+                "summary": "获取备份列表",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/v1.APIResponse"
+                                    "$ref": "#/definitions/httptransport.APIResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.PluginStats"
+                                            "$ref": "#/definitions/v1.BackupListResponse"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/v1/plugins/{id}": {
-            "get": {
-                "description": "根据插件ID获取详细信息",
+            },
+            "post": {
+                "description": "对数据库做快照，连同 Backup.Paths 中的文件和目录打包并加密保存到备份目录。\n手动创建的备份不参与自动备份的保留数量清理；已有备份或恢复在进行时返回 409",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "plugins"
-                ],
-                "summary": "获取插件详情",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "插件ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Backups"
                 ],
+                "summary": "创建备份",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/v1.APIResponse"
+                                    "$ref": "#/definitions/httptransport.APIResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.PluginStatus"
+                                            "$ref": "#/definitions/v1.BackupInfo"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/v1.APIResponse"
+                            "$ref": "#/definitions/httptransport.APIResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/plugins/{id}/control": {
+        "/v1/backups/import": {
             "post": {
-                "description": "对插件进行启动、停止、重启、重新分配端口等操作",
+                "description": "以 multipart 的 file 字段上传其他实例或之前下载的备份文件，解密并校验全部文件摘要通过后保存到备份目录。\npassphrase 缺省使用本实例配置的口令",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "plugins"
+                    "Backups"
                 ],
-                "summary": "控制插件",
+                "summary": "导入备份",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "插件ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "file",
+                        "description": "备份文件",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
                     },
                     {
-                        "description": "控制请求",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.PluginControlRequest"
-                        }
+                        "type": "string",
+                        "description": "备份口令",
+                        "name": "passphrase",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/v1.APIResponse"
+                                    "$ref": "#/definitions/httptransport.APIResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.PluginControlResponse"
+                                            "$ref": "#/definitions/v1.BackupInfo"
                                         }
                                     }
                                 }
@@ -797,33 +826,39 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/v1.APIResponse"
+                            "$ref": "#/definitions/httptransport.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/v1.APIResponse"
+                            "$ref": "#/definitions/httptransport.APIResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/plugins/{id}/health": {
-            "post": {
-                "description": "手动触发插件健康检查",
+        "/v1/backups/{name}": {
+            "get": {
+                "description": "下载加密的备份文件，可用 xiaozhi-server restore 在其他机器上恢复",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "plugins"
+                    "Backups"
                 ],
-                "summary": "检查插件健康状态",
+                "summary": "下载备份",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "插件ID",
-                        "name": "id",
+                        "description": "备份文件名",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     }
@@ -832,33 +867,36 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.APIResponse"
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/v1.APIResponse"
+                            "$ref": "#/definitions/httptransport.APIResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/plugins/{id}/reallocate-port": {
-            "post": {
-                "description": "为插件分配新的端口",
+            },
+            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "plugins"
+                    "Backups"
                 ],
-                "summary": "重新分配插件端口",
+                "summary": "删除备份",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "插件ID",
-                        "name": "id",
+                        "description": "备份文件名",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     }
@@ -867,755 +905,19985 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.APIResponse"
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/v1.APIResponse"
+                            "$ref": "#/definitions/httptransport.APIResponse"
                         }
                     }
                 }
             }
         },
-        "/vision": {
-            "get": {
-                "description": "获取Vision服务的运行状态和可用模型信息",
-                "produces": [
-                    "text/plain"
-                ],
-                "tags": [
-                    "Vision"
-                ],
-                "summary": "检查Vision服务状态",
-                "responses": {
-                    "200": {
-                        "description": "服务状态信息",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            },
+        "/v1/backups/{name}/restore": {
             "post": {
-                "description": "上传图片并进行视觉分析，支持问题询问",
+                "description": "校验备份并解包到恢复暂存目录，服务下次启动时在打开数据库之前替换数据库和备份中的文件，\n被替换的文件改名为 *.pre-restore-\u003c时间\u003e 保留。校验不通过时不会暂存任何内容",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Vision"
+                    "Backups"
                 ],
-                "summary": "图片视觉分析",
+                "summary": "从备份恢复",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer token",
-                        "name": "Authorization",
-                        "in": "header",
+                        "description": "备份文件名",
+                        "name": "name",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "设备ID",
-                        "name": "Device-Id",
-                        "in": "header",
-                        "required": true
+                        "description": "口令，缺省使用配置的口令",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/v1.BackupPassphraseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.BackupRestoreResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/backups/{name}/verify": {
+            "post": {
+                "description": "解密备份并逐一核对清单中每个文件的大小和 SHA-256，不修改任何数据。口令错误或内容被篡改时返回 400",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backups"
+                ],
+                "summary": "校验备份",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "客户端ID",
-                        "name": "Client-Id",
-                        "in": "header",
+                        "description": "备份文件名",
+                        "name": "name",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "图片文件",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "description": "口令，缺省使用配置的口令",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/v1.BackupPassphraseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.BackupVerifyResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/chat": {
+            "post": {
+                "description": "与语音设备使用相同的提示词、对话流水线和工具进行文本对话。关联的设备在线时进入设备当前的会话，共用对话历史；\n否则创建不连接设备的会话，返回的 session_id 用于继续对话。stream=true 或 Accept: text/event-stream 时以 SSE 推送，\n事件 segment 为回复分段，done 为完整回复（ChatResponse），error 为错误信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Chat"
+                ],
+                "summary": "文本对话",
+                "parameters": [
                     {
-                        "type": "string",
-                        "description": "分析问题",
-                        "name": "question",
-                        "in": "formData",
-                        "required": true
+                        "description": "对话内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.ChatRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/vision.VisionAnalysisData"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ChatResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/httptransport.APIResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/config/apply": {
+            "post": {
+                "description": "将供应商、提示词模板、设备分组和当前工作流调整为清单描述的状态。清单中出现的部分（包括空列表）视为该类资源的完整期望状态，多余的同类资源会被删除；未出现的部分保持不变。dry_run=true 时只返回变更计划",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Config"
+                ],
+                "summary": "应用配置清单",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "只计算变更计划，不做修改",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "配置清单",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/manifest.Manifest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/manifest.Plan"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/config/export": {
+            "get": {
+                "description": "导出数据库中保存的完整服务配置，可直接用于导入",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Config"
+                ],
+                "summary": "导出配置",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/config/import": {
+            "post": {
+                "description": "将导出的配置写回数据库；请求中未出现的字段保持当前值，未知字段视为错误。多数配置在重启服务后生效",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Config"
+                ],
+                "summary": "导入配置",
+                "parameters": [
+                    {
+                        "description": "导出的配置",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
                             "type": "object"
                         }
                     }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/config/providers/connectivity": {
+            "post": {
+                "description": "按提供者的网络配置（HTTPClient.Providers 叠加请求中的 network）向 url 发送 HEAD 请求，经过配置的代理、端点改写和CA证书，返回实际请求的地址、代理、对端地址和TLS版本；收到任意HTTP响应即为成功",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Config"
+                ],
+                "summary": "测试提供者网络连通性",
+                "parameters": [
+                    {
+                        "description": "测试参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.ProviderConnectivityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/httpclient.CheckResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/config/providers/test": {
+            "post": {
+                "description": "使用给定配置对能力执行一次最小调用（LLM 发送 ping，TTS 合成短文本，其它类型仅校验执行器可创建），测试失败时 success 为 false",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Config"
+                ],
+                "summary": "测试供应商配置",
+                "parameters": [
+                    {
+                        "description": "测试参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.ProviderTestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ProviderTestResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations": {
+            "get": {
+                "description": "按设备、会话、时间范围过滤会话，支持按消息内容搜索",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations"
+                ],
+                "summary": "获取会话列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "device_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "session_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "消息内容关键字",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始时间 RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束时间 RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ConversationListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations/{session_id}": {
+            "get": {
+                "description": "获取会话内的全部消息，包括用户语句、助手回复、工具调用和耗时，以及各轮的分阶段耗时",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations"
+                ],
+                "summary": "获取会话详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "session_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ConversationDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations"
+                ],
+                "summary": "删除会话",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "session_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations/{session_id}/export": {
+            "get": {
+                "description": "以 JSON 或 Markdown 格式下载会话记录",
+                "produces": [
+                    "application/json",
+                    "text/markdown"
+                ],
+                "tags": [
+                    "Conversations"
+                ],
+                "summary": "导出会话",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "session_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "json",
+                        "description": "导出格式 json/markdown",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations/{session_id}/messages/{message_id}/feedback": {
+            "post": {
+                "description": "对助手回复点赞/点踩或提交文字反馈，反馈会计入所在A/B实验的指标",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations"
+                ],
+                "summary": "提交消息反馈",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "session_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "消息ID",
+                        "name": "message_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "反馈内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.FeedbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.FeedbackInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/debug/chaos/rules": {
+            "get": {
+                "description": "返回仍在有效期内、未达到次数上限的规则及其已注入次数",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Debug"
+                ],
+                "summary": "获取故障注入规则",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ChaosRuleListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "对匹配的能力调用、插件 gRPC 调用或提供者 HTTP 请求按概率注入延迟（delay）、错误（error）或中途中断（kill）；多条规则按添加顺序取第一条命中的规则，到期或达到次数上限后自动失效",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Debug"
+                ],
+                "summary": "添加故障注入规则",
+                "parameters": [
+                    {
+                        "description": "规则",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.ChaosRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ChaosRuleInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Debug"
+                ],
+                "summary": "清空故障注入规则",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ChaosClearResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/debug/chaos/rules/{id}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Debug"
+                ],
+                "summary": "删除故障注入规则",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "规则ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/debug/provider-calls": {
+            "get": {
+                "description": "按提供者、能力、设备、调用结果、时间范围和文本搜索采样录制的能力调用，新记录在前；列表不含请求和响应",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Debug"
+                ],
+                "summary": "搜索提供者调用录制",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "提供者ID",
+                        "name": "provider",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "能力ID",
+                        "name": "capability",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "device_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否成功",
+                        "name": "success",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "在请求、响应和错误信息中搜索的文本",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始时间 RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束时间 RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ProviderCallListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/debug/provider-calls/{id}": {
+            "get": {
+                "description": "返回录制的完整请求和响应，密钥类字段已替换为 ******，超出长度上限的部分已截断",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Debug"
+                ],
+                "summary": "获取提供者调用录制详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "录制ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ProviderCallInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/device-debug/audit": {
+            "get": {
+                "description": "按设备、操作和时间范围过滤远程调试操作，包括失败和被拒绝的操作，新记录在前",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DeviceDebug"
+                ],
+                "summary": "获取远程调试审计记录",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "device_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "logs",
+                            "commands",
+                            "run",
+                            "config"
+                        ],
+                        "type": "string",
+                        "description": "操作",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始时间 RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束时间 RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.DeviceDebugAuditListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/devices": {
+            "get": {
+                "description": "获取设备列表，支持分页和过滤",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "获取设备列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "按状态过滤",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "按设备类型过滤",
+                        "name": "device_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "搜索关键词",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "排序字段",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "desc",
+                        "description": "排序方向",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否返回位置信息",
+                        "name": "location",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.DeviceListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "新设备注册到系统",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "设备注册",
+                "parameters": [
+                    {
+                        "description": "设备注册信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.DeviceRegistrationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.DeviceInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/devices/status": {
+            "post": {
+                "description": "管理员快速激活或禁用设备，通过设备MAC地址和激活状态",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "管理员激活/禁用设备",
+                "parameters": [
+                    {
+                        "description": "设备状态管理请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.DeviceStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.DeviceStatusResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/devices/{id}": {
+            "get": {
+                "description": "根据ID获取设备的详细信息，设备正在播放或暂停中的媒体在 media 字段返回",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "获取设备详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.DeviceInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "更新指定设备的信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "更新设备信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "设备更新信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.DeviceUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.DeviceInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "从系统中删除指定设备",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "删除设备",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/devices/{id}/activate": {
+            "post": {
+                "description": "用 ACT_\u003c设备ID\u003e 形式的手动激活码激活已注册的设备，已被扫码配对（/v1/provisioning/claim）取代，可通过 Provisioning.LegacyActivationCode 关闭",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "激活设备",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "设备激活信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.DeviceActivationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.DeviceActivationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/devices/{id}/data": {
+            "delete": {
+                "description": "删除设备产生的录音、语音归档授权、对话记录和反馈，设备本身及绑定关系不受影响",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Privacy"
+                ],
+                "summary": "删除设备的全部用户数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.DeviceDataErasureResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/devices/{id}/debug/commands": {
+            "get": {
+                "description": "返回固件定义的诊断命令及其参数说明，格式由固件决定；操作记入审计日志",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DeviceDebug"
+                ],
+                "summary": "查询设备诊断命令",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.DeviceDebugResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/devices/{id}/debug/commands/{command}": {
+            "post": {
+                "description": "在设备上执行固件定义的诊断命令并返回结果；操作记入审计日志",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DeviceDebug"
+                ],
+                "summary": "执行设备诊断命令",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命令名称",
+                        "name": "command",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "命令参数",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/v1.DeviceDebugRunRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.DeviceDebugResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/devices/{id}/debug/config": {
+            "put": {
+                "description": "临时覆盖设备配置，到期或设备重启后由设备恢复原配置；操作记入审计日志",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DeviceDebug"
+                ],
+                "summary": "下发设备临时配置",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "覆盖的配置项和有效期",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.DeviceDebugConfigRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.DeviceDebugResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/devices/{id}/debug/logs": {
+            "get": {
+                "description": "经设备连接读取设备最近的日志；设备需在线、在 hello 中声明 debug 特性并通过 remote_debug 功能开关允许，操作记入审计日志",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DeviceDebug"
+                ],
+                "summary": "读取设备日志",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "读取的行数，缺省或超过上限时使用 DeviceDebug.MaxLogLines",
+                        "name": "lines",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "debug",
+                            "info",
+                            "warn",
+                            "error"
+                        ],
+                        "type": "string",
+                        "description": "最低日志级别",
+                        "name": "level",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.DeviceDebugResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/devices/{id}/keys": {
+            "get": {
+                "description": "列出设备的全部密钥（只含公钥），新密钥在前；设备有未吊销的密钥时，连接 WebSocket 必须携带用其中之一签名的 JWT",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Provisioning"
+                ],
+                "summary": "获取设备密钥",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.DeviceKeyInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "为设备签发新的 P-256 密钥，私钥只返回一次；设备此后改用 JWT 认证，原静态令牌失效。revoke_previous 为 true 时同时吊销其他密钥，否则旧密钥在吊销前仍可使用，便于设备切换",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Provisioning"
+                ],
+                "summary": "签发设备密钥",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "是否吊销旧密钥",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/v1.DeviceKeyIssueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.IssuedDeviceKeyInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/devices/{id}/keys/{key_id}": {
+            "delete": {
+                "description": "吊销后用该密钥签名的 JWT 在 WebSocket 握手时被拒绝；已建立的连接不受影响",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Provisioning"
+                ],
+                "summary": "吊销设备密钥",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "密钥ID",
+                        "name": "key_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/devices/{id}/languages": {
+            "get": {
+                "description": "未单独设置的设备返回配置的默认值（Language.Allowed）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Languages"
+                ],
+                "summary": "获取设备允许的语言",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.DeviceLanguagesInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "设备每句话在允许的语言中识别语言，第一个为主要语言（使用设备默认音色），其它语言使用 Language.Voices 配置的音色。设备重新连接后生效",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Languages"
+                ],
+                "summary": "设置设备允许的语言",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "允许的语言",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.DeviceLanguagesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.DeviceLanguagesInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除设备单独设置的允许语言，之后使用 Language.Allowed，设备重新连接后生效",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Languages"
+                ],
+                "summary": "恢复设备的默认允许语言",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.DeviceLanguagesInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/devices/{id}/media": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "获取设备播放状态",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.MediaState"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "play 检索后在设备上播放，其余操作控制设备当前的播放；设备需在线，播放和暂停以外的操作需要设备正在播放",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "点播或控制设备播放",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "播放操作",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.MediaControlRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.MediaState"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/devices/{id}/members": {
+            "get": {
+                "description": "返回设备绑定的全部用户及其资料，所有者在前",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Members"
+                ],
+                "summary": "获取设备上的成员",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.DeviceMemberInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/devices/{id}/recording-consent": {
+            "get": {
+                "description": "未设置过授权的设备视为未授权，不会归档语音",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recordings"
+                ],
+                "summary": "获取设备语音归档授权",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.RecordingConsentInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "授权后该设备的用户语音会加密归档；撤销授权时可同时删除已归档的语音",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recordings"
+                ],
+                "summary": "设置设备语音归档授权",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "授权设置",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.RecordingConsentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.RecordingConsentInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/devices/{id}/speech-profile": {
+            "get": {
+                "description": "未分配方案的设备使用 default 方案",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SpeechText"
+                ],
+                "summary": "获取设备使用的播报文本方案",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.SpeechTextAssignmentInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "设备重新连接后生效",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SpeechText"
+                ],
+                "summary": "为设备分配播报文本方案",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "分配请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.SpeechTextAssignRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.SpeechTextAssignmentInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "取消后设备使用 default 方案，重新连接后生效",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SpeechText"
+                ],
+                "summary": "取消设备的播报文本方案分配",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/devices/{id}/translation": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Translation"
+                ],
+                "summary": "获取设备翻译模式状态",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.TranslationSessionInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "设备需在线；之后设备的语音翻译成目标语言并用该语言的音色播报，断开连接时自动退出",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Translation"
+                ],
+                "summary": "开启或切换设备翻译模式",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "翻译语言",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.TranslationStartRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.TranslationSessionInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Translation"
+                ],
+                "summary": "退出设备翻译模式",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.TranslationSessionInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/devices/{id}/usage": {
+            "get": {
+                "description": "返回设备指定日期的Token用量、LLM调用次数、因超出预算被拒绝的次数以及适用的预算",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Budgets"
+                ],
+                "summary": "查询设备的Token用量",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "日期 YYYY-MM-DD，默认今天",
+                        "name": "day",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.DeviceTokenUsageInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/evaluations/compare": {
+            "get": {
+                "description": "对比同一测试集的 2-5 次评测，返回各自的汇总指标和逐用例结果",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluations"
+                ],
+                "summary": "对比评测报告",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "逗号分隔的运行ID",
+                        "name": "run_ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.EvalCompareResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/evaluations/runs": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluations"
+                ],
+                "summary": "获取评测运行列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "测试集ID",
+                        "name": "suite_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.EvalRunListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "在后台对所选能力执行测试集，可通过运行ID查询进度和报告",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluations"
+                ],
+                "summary": "发起评测",
+                "parameters": [
+                    {
+                        "description": "评测参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.EvalRunRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.EvalRunInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/evaluations/runs/{id}": {
+            "get": {
+                "description": "返回各能力的汇总指标和用例明细",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluations"
+                ],
+                "summary": "获取评测报告",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "运行ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.EvalRunInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/evaluations/suites": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluations"
+                ],
+                "summary": "获取评测测试集列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.EvalSuiteListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "llm 用例需要 input，可选 expected；asr 用例需要 base64 音频和参考文本；tts 用例需要 input",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluations"
+                ],
+                "summary": "上传评测测试集",
+                "parameters": [
+                    {
+                        "description": "测试集",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.EvalSuiteCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.EvalSuiteInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/evaluations/suites/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluations"
+                ],
+                "summary": "获取评测测试集详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "测试集ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.EvalSuiteInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "已有的评测报告保留",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluations"
+                ],
+                "summary": "删除评测测试集",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "测试集ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/executions/{id}": {
+            "get": {
+                "description": "Returns an execution with per-node results, timings and logs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workflow"
+                ],
+                "summary": "Get execution",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Execution ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/workflow.Execution"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/executions/{id}/nodes/{nodeId}/logs": {
+            "get": {
+                "description": "Returns the engine and plugin logs recorded for one node of an execution",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workflow"
+                ],
+                "summary": "Get node logs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Execution ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Node ID",
+                        "name": "nodeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NodeLogsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/experiments": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Experiments"
+                ],
+                "summary": "获取A/B实验列表",
+                "parameters": [
+                    {
+                        "enum": [
+                            "draft",
+                            "running",
+                            "stopped"
+                        ],
+                        "type": "string",
+                        "description": "状态",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ExperimentListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "创建草稿状态的实验，每个分组可覆盖提示词模板和LLM",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Experiments"
+                ],
+                "summary": "创建A/B实验",
+                "parameters": [
+                    {
+                        "description": "实验信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.ExperimentCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ExperimentInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/experiments/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Experiments"
+                ],
+                "summary": "获取A/B实验详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "实验ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ExperimentInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除实验及其结果，运行中的实验需先停止",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Experiments"
+                ],
+                "summary": "删除A/B实验",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "实验ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/experiments/{id}/results": {
+            "get": {
+                "description": "按分组汇总延迟、token消耗、打断率和用户反馈",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Experiments"
+                ],
+                "summary": "获取A/B实验结果",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "实验ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ExperimentResultsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/experiments/{id}/start": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Experiments"
+                ],
+                "summary": "开始A/B实验",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "实验ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ExperimentInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/experiments/{id}/stop": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Experiments"
+                ],
+                "summary": "停止A/B实验",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "实验ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ExperimentInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/feedback": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations"
+                ],
+                "summary": "获取反馈列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "device_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "session_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "up",
+                            "down"
+                        ],
+                        "type": "string",
+                        "description": "评价",
+                        "name": "rating",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "api",
+                            "device",
+                            "voice"
+                        ],
+                        "type": "string",
+                        "description": "来源",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始时间 RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束时间 RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.FeedbackListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/feedback/stats": {
+            "get": {
+                "description": "汇总点赞/点踩、满意度、来源分布和每日趋势，默认统计最近30天",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations"
+                ],
+                "summary": "获取反馈质量统计",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "device_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "api",
+                            "device",
+                            "voice"
+                        ],
+                        "type": "string",
+                        "description": "来源",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始时间 RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束时间 RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.FeedbackStatsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/flags": {
+            "get": {
+                "description": "列出数据库中的开关和未修改过的内置开关（streaming_tts、new_pipeline、binary_protocol、offline_mode）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Flags"
+                ],
+                "summary": "获取功能开关列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.FlagListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/flags/{key}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Flags"
+                ],
+                "summary": "获取功能开关",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "开关键",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.FlagInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "规则按顺序匹配，第一条匹配的规则决定取值，没有匹配的规则时使用 enabled；每条规则需列出租户或设备（设备分组），两者都列出时需同时满足。修改立即在本实例生效，其它实例在 Flags.RefreshInterval 内生效",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Flags"
+                ],
+                "summary": "创建或更新功能开关",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "开关键",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "开关设置",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.FlagSetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.FlagInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除数据库中的开关；内置开关恢复默认值并返回，自定义开关删除后总是关闭",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Flags"
+                ],
+                "summary": "删除功能开关",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "开关键",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.FlagInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/flags/{key}/evaluate": {
+            "get": {
+                "description": "返回取值及其来源：命中的规则、开关的取值、内置默认值，或未定义（总是关闭）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Flags"
+                ],
+                "summary": "查询功能开关对租户和设备的取值",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "开关键",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "租户ID，缺省为默认租户",
+                        "name": "tenant_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "device_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.FlagDecision"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/jobs": {
+            "get": {
+                "description": "按类型、状态和去重键查询后台任务，最新的在前；status 为 dead 时即死信队列",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "获取后台任务列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "任务类型，如 knowledge.ingest",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "running",
+                            "succeeded",
+                            "dead",
+                            "canceled"
+                        ],
+                        "type": "string",
+                        "description": "状态",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "去重键",
+                        "name": "key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.JobListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/jobs/stats": {
+            "get": {
+                "description": "返回各任务类型在数据库中各状态的任务数，以及本实例的工作协程数、执行中任务数和失败次数",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "获取后台任务统计",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.JobStatsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/jobs/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "获取后台任务详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "任务ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.JobInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/jobs/{id}/cancel": {
+            "post": {
+                "description": "取消等待中或执行中的任务；执行中的任务在下次续约时中断",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "取消后台任务",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "任务ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.JobInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/jobs/{id}/retry": {
+            "post": {
+                "description": "将死信或已取消的任务放回队列并清零执行次数；同一去重键已有未结束的任务时拒绝",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "重新执行后台任务",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "任务ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.JobInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/knowledge": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Knowledge"
+                ],
+                "summary": "获取知识库列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.KnowledgeBaseInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Knowledge"
+                ],
+                "summary": "创建知识库",
+                "parameters": [
+                    {
+                        "description": "知识库信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.KnowledgeBaseCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.KnowledgeBaseInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/knowledge/bindings": {
+            "get": {
+                "description": "设备按配置的 knowledge.device_profiles 归入档案，未配置的设备使用 default 档案",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Knowledge"
+                ],
+                "summary": "获取知识库档案绑定",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.KnowledgeBindingInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/knowledge/bindings/{profile}": {
+            "put": {
+                "description": "设置档案下设备对话时检索的知识库，立即生效；knowledge_base_ids 为空时解除绑定",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Knowledge"
+                ],
+                "summary": "设置知识库档案绑定",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "档案名称",
+                        "name": "profile",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "绑定的知识库",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.KnowledgeBindingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.KnowledgeBindingInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/knowledge/search": {
+            "post": {
+                "description": "在指定知识库或设备所属档案绑定的知识库中检索，返回命中的分块和附加给 LLM 的参考资料，用于调试检索效果",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Knowledge"
+                ],
+                "summary": "检索知识库",
+                "parameters": [
+                    {
+                        "description": "检索内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.KnowledgeSearchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.KnowledgeSearchResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/knowledge/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Knowledge"
+                ],
+                "summary": "获取知识库详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "知识库ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.KnowledgeBaseInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Knowledge"
+                ],
+                "summary": "更新知识库",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "知识库ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.KnowledgeBaseUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.KnowledgeBaseInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除知识库及其全部文档和分块，进行中的导入被取消，档案绑定一并解除",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Knowledge"
+                ],
+                "summary": "删除知识库",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "知识库ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/knowledge/{id}/documents": {
+            "get": {
+                "description": "返回文档及其导入状态和进度，新上传的在前",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Knowledge"
+                ],
+                "summary": "获取知识库文档列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "知识库ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.KnowledgeDocumentInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "以 multipart 的 file 字段上传 PDF、Markdown、文本或 HTML 文件，文档在后台由工作流任务依次提取文本、分块并生成向量，\n可通过文档详情查询导入进度",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Knowledge"
+                ],
+                "summary": "上传知识库文档",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "知识库ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "文档文件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "文档名称，缺省使用文件名",
+                        "name": "name",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.KnowledgeDocumentInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/knowledge/{id}/documents/url": {
+            "post": {
+                "description": "网页在导入任务中下载并提取正文，按响应的内容类型识别 PDF、Markdown 或文本",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Knowledge"
+                ],
+                "summary": "导入网页到知识库",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "知识库ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "网页地址",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.KnowledgeURLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.KnowledgeDocumentInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/knowledge/{id}/documents/{doc_id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Knowledge"
+                ],
+                "summary": "获取知识库文档",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "知识库ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "文档ID",
+                        "name": "doc_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.KnowledgeDocumentInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Knowledge"
+                ],
+                "summary": "删除知识库文档",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "知识库ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "文档ID",
+                        "name": "doc_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/knowledge/{id}/documents/{doc_id}/reindex": {
+            "post": {
+                "description": "导入失败或更换向量化能力后重新导入，网页会重新下载；导入进行中时拒绝",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Knowledge"
+                ],
+                "summary": "重新导入知识库文档",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "知识库ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "文档ID",
+                        "name": "doc_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.KnowledgeDocumentInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/languages": {
+            "get": {
+                "description": "返回支持自动识别的语言、各语言的播报音色和默认允许的语言",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Languages"
+                ],
+                "summary": "获取支持的语言",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.LanguageListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/logs": {
+            "get": {
+                "description": "按最低级别、标签、时间范围和文本查询服务日志索引，新日志在前。\n日志在后台批量写入索引，最近约一秒内的日志可能还查不到；索引保留天数见 Log.Index.RetentionDays",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Logs"
+                ],
+                "summary": "查询服务日志",
+                "parameters": [
+                    {
+                        "enum": [
+                            "debug",
+                            "info",
+                            "warn",
+                            "error"
+                        ],
+                        "type": "string",
+                        "description": "最低级别",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "日志标签",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "在日志正文和属性中搜索的文本",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始时间 RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束时间 RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.LogListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/media/search": {
+            "get": {
+                "description": "按来源检索本地曲库、配置的网络电台和媒体能力，不指定来源时依次检索",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "检索曲目和电台",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "检索内容，为空时随机返回曲库中的曲目",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "来源 library/radio/provider",
+                        "name": "source",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.MediaTrack"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/metrics/latency": {
+            "get": {
+                "description": "按阶段（采集、ASR、LLM首字、LLM完成、TTS首包、开始播放、端到端）汇总每轮对话耗时的平均值和 P50/P90/P95/P99，并给出每日端到端耗时趋势，默认统计最近30天",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations"
+                ],
+                "summary": "获取对话耗时统计",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "device_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始时间 RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束时间 RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.LatencyStatsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/monitor/sessions": {
+            "get": {
+                "description": "返回在线设备会话的对话状态、最近的对话片段和当前使用的模型与音色，最近连接的在前",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Monitor"
+                ],
+                "summary": "列出在线会话",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "租户ID",
+                        "name": "tenant_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "device_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "idle",
+                            "listening",
+                            "thinking",
+                            "speaking"
+                        ],
+                        "type": "string",
+                        "description": "对话状态",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "只返回已标记旁听的会话",
+                        "name": "flagged",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.MonitorSession"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/monitor/sessions/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Monitor"
+                ],
+                "summary": "获取在线会话状态",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.MonitorSession"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "断开会话的设备连接，正在旁听的事件流随之结束",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Monitor"
+                ],
+                "summary": "终止在线会话",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/monitor/sessions/{id}/events": {
+            "get": {
+                "description": "以SSE推送已标记旁听的会话的事件，事件名为 state（对话状态变化）、snippet（新的对话片段）和 closed（会话断开，之后事件流结束）；连接时先推送一次 state 作为当前状态",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Monitor"
+                ],
+                "summary": "旁听会话",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.MonitorEvent"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/monitor/sessions/{id}/flag": {
+            "put": {
+                "description": "标记后可通过事件流实时旁听会话的状态变化和对话内容；已标记时更新备注，会话断开后标记自动清除",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Monitor"
+                ],
+                "summary": "标记会话旁听",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "标记备注",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/v1.MonitorFlagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.MonitorSession"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "取消标记后正在旁听的事件流随之结束",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Monitor"
+                ],
+                "summary": "取消会话旁听",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.MonitorSession"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/notifications/channels": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "获取通知渠道列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.NotificationChannelInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "创建邮件、Webhook、钉钉、飞书或 Telegram 通知渠道，可订阅插件异常、供应商不可用和配额用尽告警",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "创建通知渠道",
+                "parameters": [
+                    {
+                        "description": "渠道信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.NotificationChannelCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NotificationChannelInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/notifications/channels/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "获取通知渠道详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "渠道ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NotificationChannelInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "更新通知渠道",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "渠道ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.NotificationChannelUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NotificationChannelInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "删除通知渠道",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "渠道ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/notifications/channels/{id}/test": {
+            "post": {
+                "description": "向渠道发送一条测试通知，停用的渠道也会发送",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "发送测试通知",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "渠道ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/objects": {
+            "get": {
+                "description": "按命名空间（tts、asr、ota、knowledge 等）和文件名查询对象，最近写入的在前",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Objects"
+                ],
+                "summary": "获取对象列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "文件名",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ObjectListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "以 multipart 的 file 字段上传文件。OTA 固件上传到 ota 命名空间并以 \u003c版本号\u003e.bin 命名，设备检查更新时即可获取；\n待识别的音频上传到 asr 命名空间后，把返回的对象ID作为 core.asr 的 audio_object 输入。\nttl_seconds 缺省时使用命名空间的默认保留时长，命名空间未配置保留时长时作为长期对象保存",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Objects"
+                ],
+                "summary": "上传对象",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "文件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "namespace",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "文件名，缺省使用上传的文件名",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "保留秒数",
+                        "name": "ttl_seconds",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ObjectUploadResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/objects/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Objects"
+                ],
+                "summary": "获取对象元数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "对象ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ObjectInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "强制删除对象，不考虑引用数；仍被知识库文档引用的对象删除后，对应文档无法重新导入",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Objects"
+                ],
+                "summary": "删除对象",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "对象ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/objects/{id}/content": {
+            "get": {
+                "description": "通过 url 接口、TTS 输出或 OTA 响应中签发的地址下载对象内容，地址过期或签名不符时返回 403。\n内容按 SHA-256 寻址，ETag 即对象ID",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Objects"
+                ],
+                "summary": "下载对象",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "对象ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "过期时间（Unix 秒）",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "签名",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/objects/{id}/url": {
+            "get": {
+                "description": "使用 S3 后端时返回存储的预签名地址，否则返回本服务的签名下载地址",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Objects"
+                ],
+                "summary": "签发对象下载地址",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "对象ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "有效秒数，缺省使用 ObjectStore.URLExpiry",
+                        "name": "expires_in",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ObjectURLResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/plugins": {
+            "get": {
+                "description": "获取所有插件的信息，支持分页、筛选和排序",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plugins"
+                ],
+                "summary": "获取插件列表",
+                "parameters": [
+                    {
+                        "enum": [
+                            "LLM",
+                            "TTS",
+                            "ASR",
+                            "Tool"
+                        ],
+                        "type": "string",
+                        "description": "插件类型",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "installed",
+                            "enabled",
+                            "disabled",
+                            "running",
+                            "stopped",
+                            "error"
+                        ],
+                        "type": "string",
+                        "description": "插件状态",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "healthy",
+                            "unhealthy",
+                            "unknown"
+                        ],
+                        "type": "string",
+                        "description": "健康状态",
+                        "name": "health_status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页大小",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "updated_at",
+                        "description": "排序字段",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "排序方向",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "搜索关键词",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PluginListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/plugins/capabilities": {
+            "get": {
+                "description": "获取所有插件的能力定义",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plugins"
+                ],
+                "summary": "获取所有插件能力",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/plugins/capabilities/{type}": {
+            "get": {
+                "description": "根据类型筛选插件能力",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plugins"
+                ],
+                "summary": "按类型获取插件能力",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "能力类型",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/plugins/connections": {
+            "get": {
+                "description": "获取各提供者的请求数、连接复用数、新建连接数、TLS握手次数和HTTP/2请求数",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plugins"
+                ],
+                "summary": "获取提供者HTTP连接池统计",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/httpclient.ProviderStats"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/plugins/ports": {
+            "get": {
+                "description": "获取端口使用情况统计、当前分配记录和分配历史（含重启后回收、孤儿端口等事件）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plugins"
+                ],
+                "summary": "获取端口统计信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "按插件ID筛选历史",
+                        "name": "plugin_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "按端口筛选历史",
+                        "name": "port",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "历史条数",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PortAllocationsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/plugins/queue": {
+            "get": {
+                "description": "获取各优先级（interactive、api、batch）的排队数、放行数、挤出数、拒绝数和等待时间",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plugins"
+                ],
+                "summary": "获取能力执行队列统计",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/capability.QueueStats"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/plugins/stats": {
+            "get": {
+                "description": "获取插件的数量、状态分布、健康状态等统计信息",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plugins"
+                ],
+                "summary": "获取插件统计信息",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PluginStats"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/plugins/{id}": {
+            "get": {
+                "description": "根据插件ID获取详细信息",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plugins"
+                ],
+                "summary": "获取插件详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "插件ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PluginStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/plugins/{id}/control": {
+            "post": {
+                "description": "对插件进行启动、停止、重启、重新分配端口等操作",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plugins"
+                ],
+                "summary": "控制插件",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "插件ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "控制请求",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.PluginControlRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PluginControlResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/plugins/{id}/debug": {
+            "get": {
+                "description": "通过插件调试服务获取插件信息、进程信息和已注册的gRPC服务，需启用 PluginGRPC.Debug",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plugins"
+                ],
+                "summary": "获取插件调试信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "插件ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PluginDebugInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/plugins/{id}/debug/log-level": {
+            "put": {
+                "description": "通过插件调试服务修改插件日志级别，需启用 PluginGRPC.Debug；与核心在同一进程中运行的插件共用核心日志，修改对整个进程生效",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plugins"
+                ],
+                "summary": "修改插件日志级别",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "插件ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "日志级别",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.PluginLogLevelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PluginLogLevelResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/plugins/{id}/debug/metrics": {
+            "get": {
+                "description": "通过插件调试服务获取插件各gRPC方法的调用次数、错误数和耗时，以及Go运行时指标，需启用 PluginGRPC.Debug",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plugins"
+                ],
+                "summary": "获取插件调用统计",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "插件ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PluginDebugMetrics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/plugins/{id}/health": {
+            "post": {
+                "description": "手动触发插件健康检查",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plugins"
+                ],
+                "summary": "检查插件健康状态",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "插件ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/plugins/{id}/logs": {
+            "get": {
+                "description": "返回插件 stdout/stderr 的最近日志；follow=true 时以 SSE 持续推送新日志（事件名 log）",
+                "produces": [
+                    "application/json",
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "plugins"
+                ],
+                "summary": "获取插件日志",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "插件ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 500,
+                        "description": "返回最近的日志行数",
+                        "name": "tail",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "debug",
+                            "info",
+                            "warn",
+                            "error"
+                        ],
+                        "type": "string",
+                        "description": "最低日志级别",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否以SSE持续推送",
+                        "name": "follow",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PluginLogsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/plugins/{id}/reallocate-port": {
+            "post": {
+                "description": "为插件分配新的端口",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plugins"
+                ],
+                "summary": "重新分配插件端口",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "插件ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/presence": {
+            "get": {
+                "description": "返回参与在家判断的全部成员及在家成员的称呼",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Presence"
+                ],
+                "summary": "获取在家状态",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PresenceOverview"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/presence/router": {
+            "post": {
+                "description": "路由器上报当前在线设备的MAC地址，成员的设备上线即判定到家，连续 AwayDelay 未上报才判定离家",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Presence"
+                ],
+                "summary": "路由器推送在线设备",
+                "parameters": [
+                    {
+                        "description": "在线设备",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.PresenceRouterReport"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PresenceRouterReportResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/prompt-assignments": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prompts"
+                ],
+                "summary": "获取提示词模板分配列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.PromptAssignmentInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/prompt-assignments/{device_id}": {
+            "put": {
+                "description": "device_id 为 * 时设置全局默认模板",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prompts"
+                ],
+                "summary": "为设备分配提示词模板",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "device_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "分配信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.PromptAssignRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PromptAssignmentInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prompts"
+                ],
+                "summary": "取消提示词模板分配",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "device_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/prompts": {
+            "get": {
+                "description": "返回各模板的当前生效版本",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prompts"
+                ],
+                "summary": "获取提示词模板列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "名称关键字",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PromptListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "创建命名模板，支持 Go 模板语法和 Jinja 风格的 {{ name }} 变量",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prompts"
+                ],
+                "summary": "创建提示词模板",
+                "parameters": [
+                    {
+                        "description": "模板信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.PromptCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PromptInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/prompts/render": {
+            "post": {
+                "description": "渲染未保存的模板内容，用于编辑时预览",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prompts"
+                ],
+                "summary": "预览提示词模板",
+                "parameters": [
+                    {
+                        "description": "渲染参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.PromptRenderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PromptRenderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/prompts/{name}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prompts"
+                ],
+                "summary": "获取提示词模板",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "模板名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "版本号，默认为生效版本",
+                        "name": "version",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PromptInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "基于当前生效版本生成新版本，并设为生效版本",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prompts"
+                ],
+                "summary": "更新提示词模板",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "模板名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.PromptUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PromptInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除模板的全部版本及设备分配",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prompts"
+                ],
+                "summary": "删除提示词模板",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "模板名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/prompts/{name}/render": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prompts"
+                ],
+                "summary": "测试渲染提示词模板",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "模板名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "渲染参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.PromptRenderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PromptRenderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/prompts/{name}/versions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prompts"
+                ],
+                "summary": "获取提示词模板版本列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "模板名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.PromptInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/prompts/{name}/versions/{version}/activate": {
+            "post": {
+                "description": "将指定版本设为生效版本，可用于回滚",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prompts"
+                ],
+                "summary": "切换提示词模板生效版本",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "模板名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "版本号",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PromptInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/provisioning/claim": {
+            "post": {
+                "description": "设备或配套 App 用二维码中的配对令牌换取设备凭证；设备未注册时以令牌签发者为所有者创建并激活，已注册的设备只能由原所有者重新配对。令牌只能使用一次",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Provisioning"
+                ],
+                "summary": "扫码配对设备",
+                "parameters": [
+                    {
+                        "description": "配对令牌和设备信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.ProvisioningClaimRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ProvisioningClaimResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/recordings": {
+            "get": {
+                "description": "按设备、会话、时间范围过滤归档的用户语音",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recordings"
+                ],
+                "summary": "获取录音列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "device_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "session_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始时间 RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束时间 RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.RecordingListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/recordings/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recordings"
+                ],
+                "summary": "获取录音详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "录音ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.RecordingInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recordings"
+                ],
+                "summary": "删除录音",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "录音ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/recordings/{id}/audio": {
+            "get": {
+                "description": "返回解密后的 WAV 音频",
+                "produces": [
+                    "audio/wav"
+                ],
+                "tags": [
+                    "Recordings"
+                ],
+                "summary": "下载录音",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "录音ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reminders": {
+            "get": {
+                "description": "获取提醒列表，支持按设备、类型和状态过滤",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reminders"
+                ],
+                "summary": "获取提醒列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "device_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "类型 timer/alarm/reminder",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "状态 pending/fired/cancelled/missed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ReminderListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "为设备创建计时器、闹钟或提醒，到期后向设备推送语音播报",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reminders"
+                ],
+                "summary": "创建提醒",
+                "parameters": [
+                    {
+                        "description": "提醒信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.ReminderCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ReminderInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reminders/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reminders"
+                ],
+                "summary": "获取提醒详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "提醒ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ReminderInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "修改待触发提醒的内容、触发时间或重复规则",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reminders"
+                ],
+                "summary": "更新提醒",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "提醒ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.ReminderUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ReminderInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reminders"
+                ],
+                "summary": "删除提醒",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "提醒ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reminders/{id}/cancel": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reminders"
+                ],
+                "summary": "取消提醒",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "提醒ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ReminderInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/replays": {
+            "get": {
+                "description": "返回回放运行及汇总，不含逐轮明细",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Replays"
+                ],
+                "summary": "获取回放列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "session_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ReplayRunListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "用当前或指定的 LLM 配置和提示词重新生成会话中每轮的回复，在后台执行；每轮的对话历史使用原回复，工具调用不重新执行。可通过回放ID查询逐轮的回复差异、耗时和成本对比",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Replays"
+                ],
+                "summary": "回放已记录的会话",
+                "parameters": [
+                    {
+                        "description": "回放参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.ReplayStartRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ReplayRunInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/replays/{id}": {
+            "get": {
+                "description": "返回汇总以及每轮的原回复、回放回复、逐词差异、首字和完成耗时、Token数和成本",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Replays"
+                ],
+                "summary": "获取回放对比结果",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "回放ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ReplayRunInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/routines": {
+            "get": {
+                "description": "按创建时间列出例程，可按设备过滤",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Routines"
+                ],
+                "summary": "获取例程列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "device_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.RoutineInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "为设备创建例程，按 cron 计划定时触发或在设备上说出唤醒短语时触发，依次执行天气、新闻、日程、固定文本或工作流动作并合并播报",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Routines"
+                ],
+                "summary": "创建例程",
+                "parameters": [
+                    {
+                        "description": "例程信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.RoutineCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.RoutineInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/routines/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Routines"
+                ],
+                "summary": "获取例程详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "例程ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.RoutineInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "修改例程的名称、计划、唤醒短语、动作或启用状态，修改计划后重新计算下次触发时间",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Routines"
+                ],
+                "summary": "更新例程",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "例程ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.RoutineUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.RoutineInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Routines"
+                ],
+                "summary": "删除例程",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "例程ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/routines/{id}/run": {
+            "post": {
+                "description": "立即执行例程的全部动作并推送到设备播报，设备不在线时返回 409",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Routines"
+                ],
+                "summary": "立即执行例程",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "例程ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.RoutineRunResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/scripts": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scripts"
+                ],
+                "summary": "获取脚本列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.ScriptInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "创建供工作流脚本节点使用的 Lua 脚本，保存时检查语法",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scripts"
+                ],
+                "summary": "创建脚本",
+                "parameters": [
+                    {
+                        "description": "脚本信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.ScriptCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ScriptInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/scripts/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scripts"
+                ],
+                "summary": "获取脚本详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "脚本ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ScriptInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "修改源码时生成新版本，引用当前版本的脚本节点在下次执行时使用新源码",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scripts"
+                ],
+                "summary": "更新脚本",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "脚本ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.ScriptUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ScriptInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scripts"
+                ],
+                "summary": "删除脚本",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "脚本ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/scripts/{id}/rollback": {
+            "post": {
+                "description": "以历史版本的源码创建新版本",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scripts"
+                ],
+                "summary": "回滚脚本",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "脚本ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "目标版本",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.ScriptRollbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ScriptInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/scripts/{id}/test": {
+            "post": {
+                "description": "以给定输入在沙箱中运行脚本，返回输出和日志；脚本错误或超时返回 400",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scripts"
+                ],
+                "summary": "试运行脚本",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "脚本ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "试运行参数",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/v1.ScriptTestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ScriptTestResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/scripts/{id}/versions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scripts"
+                ],
+                "summary": "获取脚本版本列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "脚本ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.ScriptVersionInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/scripts/{id}/versions/{version}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scripts"
+                ],
+                "summary": "获取脚本指定版本",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "脚本ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "版本号",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ScriptVersionInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/smarthome/aliases": {
+            "get": {
+                "description": "返回当前租户的设备别名，包括通过接口设置的和从语音纠正中学到的",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SmartHome"
+                ],
+                "summary": "列出智能家居设备别名",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.SmartHomeAlias"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "把别名指向实体，别名已存在时改为指向新实体；比较别名时忽略空白、标点和“的”",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SmartHome"
+                ],
+                "summary": "设置智能家居设备别名",
+                "parameters": [
+                    {
+                        "description": "别名和实体ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.SmartHomeAliasRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.SmartHomeAlias"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/smarthome/aliases/{alias}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SmartHome"
+                ],
+                "summary": "删除智能家居设备别名",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "别名",
+                        "name": "alias",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/smarthome/discover": {
+            "post": {
+                "description": "向所有桥接重新发现实体，单个桥接失败时保留它上次发现的实体",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SmartHome"
+                ],
+                "summary": "重新发现智能家居实体",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.SmartHomeEntity"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/smarthome/entities": {
+            "get": {
+                "description": "返回缓存的实体及其状态，可按房间和类型过滤",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SmartHome"
+                ],
+                "summary": "列出智能家居实体",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "房间",
+                        "name": "room",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "实体类型 light/switch/cover/climate/lock/fan/sensor",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "先向所有桥接重新发现实体",
+                        "name": "refresh",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.SmartHomeEntity"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/smarthome/entities/{id}": {
+            "get": {
+                "description": "缓存的状态过期时先向桥接刷新",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SmartHome"
+                ],
+                "summary": "获取智能家居实体",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "实体ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.SmartHomeEntity"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/smarthome/entities/{id}/control": {
+            "post": {
+                "description": "返回控制后预期的实体状态；窗帘的 turn_on/turn_off 按 open/close 处理",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SmartHome"
+                ],
+                "summary": "控制智能家居实体",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "实体ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "控制动作",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.SmartHomeControlRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.SmartHomeEntity"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/speech-profiles": {
+            "get": {
+                "description": "列出全部方案和设备分配；数据库中没有 default 方案时列表包含内置方案",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SpeechText"
+                ],
+                "summary": "获取播报文本后处理方案列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.SpeechTextProfileListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "步骤按顺序执行：markdown 去掉 Markdown 标记，emoji 去掉表情，units 把日期、时间、范围、百分比、温度和计量单位转为读法，replace 按发音词典替换，regex 正则替换。同名方案会被覆盖，修改对之后建立的连接生效",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SpeechText"
+                ],
+                "summary": "创建播报文本后处理方案",
+                "parameters": [
+                    {
+                        "description": "方案",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.SpeechTextProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.SpeechTextProfileInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/speech-profiles/preview": {
+            "post": {
+                "description": "用已保存的方案或未保存的步骤处理文本，返回送入 TTS 的文本；profile 和 stages 都为空时使用 default 方案",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SpeechText"
+                ],
+                "summary": "试运行播报文本后处理",
+                "parameters": [
+                    {
+                        "description": "试运行请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.SpeechTextPreviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.SpeechTextPreviewResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/speech-profiles/{name}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SpeechText"
+                ],
+                "summary": "获取播报文本后处理方案",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "方案名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.SpeechTextProfileInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "保存名为 default 的方案会替换内置方案，作为未分配方案的设备的默认方案",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SpeechText"
+                ],
+                "summary": "创建或覆盖播报文本后处理方案",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "方案名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "方案",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.SpeechTextProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.SpeechTextProfileInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "同时取消使用该方案的设备分配；删除 default 方案后恢复使用内置方案",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SpeechText"
+                ],
+                "summary": "删除播报文本后处理方案",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "方案名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/system/boot-report": {
+            "get": {
+                "description": "获取本次启动各初始化步骤的开始时间、耗时、超时设置和状态，以及决定启动总耗时的关键路径，用于排查启动缓慢；依赖关系满足的步骤并行执行，步骤累计耗时可能大于总耗时",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "获取启动耗时报告",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.BootReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/system/database": {
+            "get": {
+                "description": "获取各类操作（query/create/update/delete/row/raw）的次数、错误数、锁冲突次数和平均/最大耗时，以及写串行化排队和连接池状态，用于验证 WAL 与连接池调优效果",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "获取数据库耗时与连接池统计",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/storage.DBStats"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/system/drain": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "获取排空进度",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.DrainStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "停止接受新的WebSocket会话和工作流执行，等待活跃会话结束（最长至截止时间）后正常关停；重复调用返回当前进度",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "进入排空模式",
+                "parameters": [
+                    {
+                        "description": "排空参数",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/v1.DrainRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.DrainStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/system/intents": {
+            "get": {
+                "description": "获取进程启动以来各意图的分类命中、由处理器完成、回落到LLM和处理失败的次数及平均路由耗时，所有连接累计；intent 为 llm 的条目是未命中任何意图的语句",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "获取意图路由统计",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.IntentRouteStats"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/system/metrics": {
+            "get": {
+                "description": "以 Prometheus 文本格式输出各插件通过 ScrapeMetrics 上报的指标，每个序列带 plugin_id 标签；另含各插件的 plugin_scrape_up 和 plugin_scrape_duration_seconds。数据为最近一次定期采集的结果，间隔见 PluginMetrics.Interval",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "获取插件指标",
+                "responses": {
+                    "200": {
+                        "description": "Prometheus 文本格式指标",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/system/selftest": {
+            "get": {
+                "description": "按初始化依赖图执行只读检查：数据库连接与表结构、配置与密钥、所选提供者的连通性、日志与插件目录、外部 MCP 服务命令，返回逐项结果；运行中的服务不检查端口占用。与 xiaozhi-server doctor 的检查相同",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "执行启动自检",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.SelfTestReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tenant": {
+            "get": {
+                "description": "返回请求所属租户的信息和今日用量；管理员未指定 X-Tenant-ID 时返回默认租户",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "获取当前租户",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.TenantUsageInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tenant/providers": {
+            "get": {
+                "description": "返回请求所属租户的 LLM 和 TTS 配置及选择，密钥脱敏为 ******；管理员未指定 X-Tenant-ID 时返回默认租户",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "获取租户供应商配置",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.TenantProviders"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "整体替换请求所属租户的 LLM 和 TTS 配置。条目与实例配置同名时覆盖实例配置，选择为空时沿用实例的选择；\n密钥传 ****** 表示保留同名条目的原值。新连接的设备使用新配置，ASR 仍使用实例配置",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "修改租户供应商配置",
+                "parameters": [
+                    {
+                        "description": "供应商配置",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.TenantProviders"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.TenantProviders"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tenants": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "获取租户列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.TenantInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "创建租户并签发访问令牌，令牌只在响应中返回一次。租户ID由小写字母、数字、下划线和连字符组成",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "创建租户",
+                "parameters": [
+                    {
+                        "description": "租户信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.TenantCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.TenantTokenResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tenants/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "获取租户",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "租户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.TenantInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "修改名称、启用状态或配额；停用后租户令牌和设备连接都会被拒绝",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "修改租户",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "租户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "修改内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.TenantUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.TenantInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除租户及其提示词模板和用量记录；仍有设备的租户不能删除，默认租户不能删除",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "删除租户",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "租户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tenants/{id}/token": {
+            "post": {
+                "description": "生成新的访问令牌，旧令牌立即失效；新令牌只在响应中返回一次",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "重新签发租户令牌",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "租户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.TenantTokenResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tenants/{id}/usage": {
+            "get": {
+                "description": "返回指定日期的能力调用次数、Token 数以及当前设备数",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "查询租户用量",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "租户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "日期 YYYY-MM-DD，默认今天",
+                        "name": "day",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.TenantUsageInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tool-policy/audit": {
+            "get": {
+                "description": "按设备、智能体、工具名称、检查结果和时间范围过滤设备会话和智能体发起的工具与能力调用，新记录在前",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ToolPolicy"
+                ],
+                "summary": "获取工具调用审计记录",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "device_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "智能体名称",
+                        "name": "agent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "工具或能力名称",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否放行",
+                        "name": "allowed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始时间 RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束时间 RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ToolPolicyAuditListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tool-policy/check": {
+            "post": {
+                "description": "按当前策略检查设备会话或智能体能否以给定参数调用工具或能力，不执行调用也不记入审计",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ToolPolicy"
+                ],
+                "summary": "试算工具调用权限",
+                "parameters": [
+                    {
+                        "description": "调用方和调用内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.ToolPolicyCheckRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ToolPolicyCheckResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/translation/languages": {
+            "get": {
+                "description": "返回可作为源语言和目标语言的语言代码，以及各语言配置的TTS音色",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Translation"
+                ],
+                "summary": "获取翻译模式支持的语言",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.TranslationLanguage"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/usage/devices": {
+            "get": {
+                "description": "返回指定日期有用量的设备，按 Token 数从多到少排序",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Budgets"
+                ],
+                "summary": "按Token用量列出设备",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "日期 YYYY-MM-DD，默认今天",
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "返回的设备数，默认 100，最多 500",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.DeviceTokenUsageInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/{id}/devices": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Members"
+                ],
+                "summary": "获取用户绑定的设备",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.DeviceBindingInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "将已注册的设备绑定给用户。每台设备最多一个所有者（owner），其余为家庭成员（member）；重复绑定时更新角色",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Members"
+                ],
+                "summary": "绑定设备",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "绑定信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.BindDeviceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.DeviceBindingInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/{id}/devices/{device_id}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Members"
+                ],
+                "summary": "解除设备绑定",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "device_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/{id}/memories": {
+            "get": {
+                "description": "列出用户记忆命名空间中的事实及其出处（设备、会话、对话记录和原话），最近更新的在前",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Memory"
+                ],
+                "summary": "获取用户的长期记忆",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "内容关键字",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.MemoryFactListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "手动为用户添加一条记忆，不需要记忆提取授权",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Memory"
+                ],
+                "summary": "添加长期记忆",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "记忆内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.MemoryFactRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.MemoryFactInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除用户记忆命名空间中的全部记忆，不影响记忆提取授权",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Memory"
+                ],
+                "summary": "清空长期记忆",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.MemoryClearResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/{id}/memories/{fact_id}": {
+            "put": {
+                "description": "修改后记忆的来源记为 manual，保留原始出处",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Memory"
+                ],
+                "summary": "修改长期记忆",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "记忆ID",
+                        "name": "fact_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "记忆内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.MemoryFactRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.MemoryFactInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Memory"
+                ],
+                "summary": "删除长期记忆",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "记忆ID",
+                        "name": "fact_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/{id}/memory-consent": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Memory"
+                ],
+                "summary": "获取记忆提取授权",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.MemoryConsentInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "授权后从该用户的对话中提取长期记忆；撤销授权时可同时删除已有记忆",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Memory"
+                ],
+                "summary": "设置记忆提取授权",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "授权设置",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.MemoryConsentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.MemoryConsentInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/{id}/pairing-tokens": {
+            "post": {
+                "description": "为用户签发短时有效的一次性配对令牌，payload 用于生成二维码；设备或配套 App 扫码后调用 /v1/provisioning/claim 完成配对，设备以该用户为所有者注册",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Provisioning"
+                ],
+                "summary": "签发配对令牌",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PairingTokenInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/{id}/presence": {
+            "get": {
+                "description": "没有任何签到或路由器记录时状态为 unknown",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Presence"
+                ],
+                "summary": "获取成员的在家状态",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PresenceStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/{id}/presence/checkin": {
+            "post": {
+                "description": "上报到家（state=home）或离家（state=away），或上报位置按家的地理围栏判断；位置落在围栏边缘的定位精度范围内时保持原状态。状态变化时发布 presence:arrived 或 presence:left 事件",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Presence"
+                ],
+                "summary": "配套App签到",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "签到内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.PresenceCheckInRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PresenceStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/{id}/profile": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Members"
+                ],
+                "summary": "获取成员资料",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.MemberProfileInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "称呼用于按名字识别说话人；识别到该成员后使用其偏好音色和语言回复，个人记忆按命名空间隔离",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Members"
+                ],
+                "summary": "修改成员资料",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "修改内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.MemberProfileUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.MemberProfileInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "获取推送端点列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.WebhookInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "登记接收地址并订阅设备离线、插件异常、工作流失败或配额用尽事件；推送为 POST JSON，请求头 X-Xiaozhi-Signature 为 t=\u003cunix 秒\u003e,v1=\u003chex(HMAC-SHA256(secret, \"\u003ct\u003e.\u003cbody\u003e\"))\u003e。未指定签名密钥时自动生成，只在本次响应中返回原值",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "创建推送端点",
+                "parameters": [
+                    {
+                        "description": "端点信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.WebhookCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.WebhookInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "获取推送端点详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "端点ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.WebhookInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "更新推送端点",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "端点ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.WebhookUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.WebhookInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除端点及其全部推送记录，尚未推送的事件不再推送",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "删除推送端点",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "端点ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "按创建时间倒序分页返回推送记录，包含请求体、推送状态和各次尝试的响应",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "获取端点的推送记录",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "端点ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "事件类型",
+                        "name": "event",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "succeeded",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "推送状态",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.WebhookDeliveryListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{id}/deliveries/{delivery_id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "获取推送记录详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "端点ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "推送记录ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.WebhookDeliveryInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{id}/deliveries/{delivery_id}/redeliver": {
+            "post": {
+                "description": "把已成功或已失败的推送记录重新加入推送队列，请求体和事件ID不变，重试次数重新计算",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "重新推送",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "端点ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "推送记录ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.WebhookDeliveryInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{id}/test": {
+            "post": {
+                "description": "向端点同步推送一条 webhook.ping 事件并返回推送记录，不重试，停用的端点也会推送",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "推送测试事件",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "端点ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.WebhookDeliveryInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/workflow": {
+            "post": {
+                "description": "Saves the workflow of the request's tenant; workflows whose node expressions do not compile are rejected",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workflow"
+                ],
+                "summary": "Save workflow",
+                "parameters": [
+                    {
+                        "description": "Workflow",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/workflow.Workflow"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/workflow.Workflow"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/workflow/capabilities": {
+            "get": {
+                "description": "Lists every capability registered by builtin providers and plugins",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workflow"
+                ],
+                "summary": "List capabilities",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/capability.Definition"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/workflow/capabilities/{id}/execute": {
+            "post": {
+                "description": "Runs one capability outside of any workflow; streaming output is merged into one result. On failure the error details carry the call traces with upstream request IDs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workflow"
+                ],
+                "summary": "Execute a capability",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Capability ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Config, inputs and timeout",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/v1.ExecuteCapabilityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ExecuteCapabilityResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/workflow/current": {
+            "get": {
+                "description": "Returns the workflow of the request's tenant",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workflow"
+                ],
+                "summary": "Get current workflow",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/workflow.Workflow"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/workflow/node-types": {
+            "get": {
+                "description": "Lists builtin workflow node types and the custom node types registered by plugins",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workflow"
+                ],
+                "summary": "List node types",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/workflow.NodeTypeDefinition"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/workflow/scheduler": {
+            "get": {
+                "description": "Returns how many nodes are running and queued per concurrency class and execution",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workflow"
+                ],
+                "summary": "Get scheduler stats",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/workflow.SchedulerStats"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/workflow/startup": {
+            "get": {
+                "description": "Returns the service startup steps as a workflow together with per-step status and timing",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workflow"
+                ],
+                "summary": "Get startup workflow",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.StartupWorkflowResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/workflow/templates": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workflow"
+                ],
+                "summary": "List workflow templates",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/workflow.WorkflowTemplate"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/workflow/templates/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workflow"
+                ],
+                "summary": "Get workflow template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/workflow.WorkflowTemplate"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/workflow/templates/{id}/deploy": {
+            "post": {
+                "description": "Instantiates a template and saves it as the current workflow; with dry_run=true the workflow is only returned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workflow"
+                ],
+                "summary": "Deploy workflow template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the instantiated workflow without saving it",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Template parameters",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/v1.DeployTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/workflow.Workflow"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/workflows/{id}/execute": {
+            "post": {
+                "description": "Starts an execution of the stored (or supplied) workflow and returns it with 202; with dry_run=true returns a workflow.DryRunReport instead",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workflow"
+                ],
+                "summary": "Execute workflow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workflow ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only resolve and validate the workflow",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Inputs and optional unsaved workflow",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/v1.ExecuteWorkflowRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/workflow.DryRunReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httptransport.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/workflow.Execution"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httptransport.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vision": {
+            "get": {
+                "description": "获取Vision服务的运行状态和可用模型信息",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Vision"
+                ],
+                "summary": "检查Vision服务状态",
+                "responses": {
+                    "200": {
+                        "description": "服务状态信息",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "上传图片并进行视觉分析，支持问题询问",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vision"
+                ],
+                "summary": "图片视觉分析",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "设备ID",
+                        "name": "Device-Id",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "客户端ID",
+                        "name": "Client-Id",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "图片文件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "分析问题",
+                        "name": "question",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/vision.VisionAnalysisData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "capability.ClassStats": {
+            "type": "object",
+            "properties": {
+                "admitted": {
+                    "type": "integer"
+                },
+                "avg_wait_ms": {
+                    "type": "number"
+                },
+                "max_wait_ms": {
+                    "type": "integer"
+                },
+                "rejected": {
+                    "description": "队列已满直接拒绝",
+                    "type": "integer"
+                },
+                "shed": {
+                    "description": "排队中被更高优先级挤出",
+                    "type": "integer"
+                },
+                "timed_out": {
+                    "description": "排队超时或调用方取消",
+                    "type": "integer"
+                },
+                "waiting": {
+                    "type": "integer"
+                }
+            }
+        },
+        "capability.Definition": {
+            "type": "object",
+            "properties": {
+                "config_schema": {
+                    "description": "Static config (API keys, model selection)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/capability.Schema"
+                        }
+                    ]
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Unique ID, e.g., \"openai_chat\"",
+                    "type": "string"
+                },
+                "input_schema": {
+                    "description": "Runtime inputs (messages, audio bytes)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/capability.Schema"
+                        }
+                    ]
+                },
+                "name": {
+                    "description": "Human readable name",
+                    "type": "string"
+                },
+                "output_schema": {
+                    "description": "Runtime outputs (text, audio bytes)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/capability.Schema"
+                        }
+                    ]
+                },
+                "type": {
+                    "description": "llm, asr, etc.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/capability.Type"
+                        }
+                    ]
+                },
+                "ui": {
+                    "description": "工作流编辑器的展示提示，主要用于 TypeNode",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/capability.UIHints"
+                        }
+                    ]
+                }
+            }
+        },
+        "capability.Property": {
+            "type": "object",
+            "properties": {
+                "default": {},
+                "description": {
+                    "type": "string"
+                },
+                "enum": {
+                    "type": "array",
+                    "items": {}
+                },
+                "items": {
+                    "description": "For arrays",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/capability.Schema"
+                        }
+                    ]
+                },
+                "secret": {
+                    "description": "For sensitive config like API keys",
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "capability.QueueStats": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "integer"
+                },
+                "classes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/capability.ClassStats"
+                    }
+                },
+                "max_concurrent": {
+                    "type": "integer"
+                }
+            }
+        },
+        "capability.Schema": {
+            "type": "object",
+            "properties": {
+                "properties": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/capability.Property"
+                    }
+                },
+                "required": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "description": "object, string, number, array, boolean",
+                    "type": "string"
+                }
+            }
+        },
+        "capability.Trace": {
+            "type": "object",
+            "properties": {
+                "capability": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "description": "核心的关联ID",
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "upstream_request_ids": {
+                    "description": "提供者返回的请求ID，如 OpenAI 的 x-request-id",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "capability.Type": {
+            "type": "string",
+            "enum": [
+                "llm",
+                "asr",
+                "tts",
+                "tool",
+                "node",
+                "translation",
+                "embedding",
+                "search",
+                "media",
+                "smart_home"
+            ],
+            "x-enum-comments": {
+                "TypeEmbedding": "文本向量化，输入 texts，输出 vectors",
+                "TypeMedia": "媒体检索，输入 query/count，输出 tracks（title/artist/url/live）",
+                "TypeNode": "自定义工作流节点，能力ID即节点类型",
+                "TypeSearch": "网页搜索，输入 query/count，输出 results",
+                "TypeSmartHome": "智能家居桥接，输入 operation（discover/state/control），输出 entities",
+                "TypeTranslation": "文本翻译，输入 text/source/target，输出 text"
+            },
+            "x-enum-varnames": [
+                "TypeLLM",
+                "TypeASR",
+                "TypeTTS",
+                "TypeTool",
+                "TypeNode",
+                "TypeTranslation",
+                "TypeEmbedding",
+                "TypeSearch",
+                "TypeMedia",
+                "TypeSmartHome"
+            ]
+        },
+        "capability.UIHints": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "节点面板中的分组",
+                    "type": "string"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "widgets": {
+                    "description": "配置字段 -\u003e 编辑控件（textarea、select、code 等）",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "httpclient.CheckResult": {
+            "type": "object",
+            "properties": {
+                "endpoint": {
+                    "description": "按 Endpoints 改写后实际请求的地址",
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "type": "integer"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "proxy": {
+                    "description": "经过的代理（隐藏密码），为空表示直连",
+                    "type": "string"
+                },
+                "remote_addr": {
+                    "description": "建立连接的对端地址，经过代理时为代理地址",
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "success": {
+                    "description": "收到响应即成功，不判断状态码",
+                    "type": "boolean"
+                },
+                "tls_version": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "测试的原始地址",
+                    "type": "string"
+                }
+            }
+        },
+        "httpclient.ProviderStats": {
+            "type": "object",
+            "properties": {
+                "avg_tls_handshake_ms": {
+                    "type": "number"
+                },
+                "http2_requests": {
+                    "description": "走 HTTP/2 的请求数",
+                    "type": "integer"
+                },
+                "new_conns": {
+                    "description": "新建连接的请求数",
+                    "type": "integer"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "requests": {
+                    "type": "integer"
+                },
+                "reuse_rate": {
+                    "description": "复用率 0~1",
+                    "type": "number"
+                },
+                "reused_conns": {
+                    "description": "复用已有连接的请求数",
+                    "type": "integer"
+                },
+                "tls_handshakes": {
+                    "description": "TLS 握手次数",
+                    "type": "integer"
+                }
+            }
+        },
+        "httptransport.APIResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "$ref": "#/definitions/middleware.APIError"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "logs.Entry": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "plugin_id": {
+                    "type": "string"
+                },
+                "seq": {
+                    "type": "integer"
+                },
+                "stream": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "manifest.Action": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "ActionCreate",
+                "ActionUpdate",
+                "ActionDelete"
+            ]
+        },
+        "manifest.Change": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/manifest.Action"
+                },
+                "after": {},
+                "applied": {
+                    "type": "boolean"
+                },
+                "before": {},
+                "error": {
+                    "type": "string"
+                },
+                "fields": {
+                    "description": "更新时值发生变化的字段",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "manifest.DeviceGroup": {
+            "type": "object",
+            "properties": {
+                "devices": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prompt": {
+                    "type": "string"
+                },
+                "prompt_version": {
+                    "description": "0 表示跟随当前生效版本",
+                    "type": "integer"
+                }
+            }
+        },
+        "manifest.Manifest": {
+            "type": "object",
+            "properties": {
+                "device_groups": {
+                    "description": "DeviceGroups 设备分组，组内设备分配同一个提示词模板",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/manifest.DeviceGroup"
+                    }
+                },
+                "prompts": {
+                    "description": "Prompts 提示词模板，与当前生效版本比较，修改时生成新版本",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/manifest.PromptSpec"
+                    }
+                },
+                "providers": {
+                    "description": "Providers 按类型（llm/tts/asr/vllm）列出供应商配置，键为供应商ID；只对出现的类型做增删",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "object",
+                        "additionalProperties": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    }
+                },
+                "workflow": {
+                    "description": "Workflow 当前工作流",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/workflow.Workflow"
+                        }
+                    ]
+                }
+            }
+        },
+        "manifest.Plan": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/manifest.Change"
+                    }
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "unchanged": {
+                    "description": "已与清单一致的资源数",
+                    "type": "integer"
+                }
+            }
+        },
+        "manifest.PromptSpec": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "middleware.APIError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "机器可读的错误码，取值见 utils/error_codes.go，决定响应的HTTP状态码",
+                    "type": "string",
+                    "example": "VALIDATION_FAILED"
+                },
+                "details": {
+                    "description": "校验失败时为 []FieldError"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "ota.Activation": {
+            "type": "object",
+            "properties": {
+                "challenge": {
+                    "description": "用于设备认证挑战",
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "543091"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Anime AI Chat 543091"
+                }
+            }
+        },
+        "ota.FirmwareInfo": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "string",
+                    "example": "/ota_bin/1.2.4.bin"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.2.4"
+                }
+            }
+        },
+        "ota.MQTTInfo": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string",
+                    "example": "CGID_test@@@mac@@@client"
+                },
+                "endpoint": {
+                    "type": "string",
+                    "example": "mqtt://broker:1883"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "randomPwd"
+                },
+                "publish_topic": {
+                    "type": "string",
+                    "example": "device-server"
+                },
+                "subscribe_topic": {
+                    "type": "string",
+                    "example": "null"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "base64string"
+                }
+            }
+        },
+        "ota.OTAResponse": {
+            "type": "object",
+            "properties": {
+                "activation": {
+                    "$ref": "#/definitions/ota.Activation"
+                },
+                "firmware": {
+                    "$ref": "#/definitions/ota.FirmwareInfo"
+                },
+                "mqtt": {
+                    "$ref": "#/definitions/ota.MQTTInfo"
+                },
+                "server_time": {
+                    "$ref": "#/definitions/ota.ServerTimeInfo"
+                },
+                "websocket": {
+                    "$ref": "#/definitions/ota.WebSocketInfo"
+                }
+            }
+        },
+        "ota.ServerTimeInfo": {
+            "type": "object",
+            "properties": {
+                "timestamp": {
+                    "type": "integer",
+                    "example": 1720065289451
+                },
+                "timezone_offset": {
+                    "type": "integer",
+                    "example": 480
+                }
+            }
+        },
+        "ota.WebSocketInfo": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "string",
+                    "example": "wss://your-server/ws"
+                }
+            }
+        },
+        "ports.PortAllocation": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "plugin_id": {
+                    "type": "string"
+                },
+                "port": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "\"allocated\", \"released\", \"reserved\", \"orphaned\"",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "ports.PortEvent": {
+            "type": "object",
+            "properties": {
+                "plugin_id": {
+                    "type": "string"
+                },
+                "port": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "ports.PortStats": {
+            "type": "object",
+            "properties": {
+                "allocated_ports": {
+                    "type": "integer"
+                },
+                "available_ports": {
+                    "type": "integer"
+                },
+                "orphaned_ports": {
+                    "type": "integer"
+                },
+                "reserved_ports": {
+                    "type": "integer"
+                },
+                "total_ports": {
+                    "type": "integer"
+                },
+                "unix_sockets": {
+                    "type": "integer"
+                },
+                "usage_percent": {
+                    "type": "number"
+                }
+            }
+        },
+        "storage.DBOperationStats": {
+            "type": "object",
+            "properties": {
+                "avg_ms": {
+                    "type": "number"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "integer"
+                },
+                "locked": {
+                    "description": "因 database is locked / busy 失败的次数",
+                    "type": "integer"
+                },
+                "max_ms": {
+                    "type": "number"
+                },
+                "operation": {
+                    "description": "query/create/update/delete/row/raw",
+                    "type": "string"
+                }
+            }
+        },
+        "storage.DBPoolStats": {
+            "type": "object",
+            "properties": {
+                "idle": {
+                    "type": "integer"
+                },
+                "in_use": {
+                    "type": "integer"
+                },
+                "max_open_conns": {
+                    "type": "integer"
+                },
+                "open": {
+                    "type": "integer"
+                },
+                "wait_count": {
+                    "description": "等待空闲连接的累计次数",
+                    "type": "integer"
+                },
+                "wait_ms": {
+                    "type": "number"
+                }
+            }
+        },
+        "storage.DBStats": {
+            "type": "object",
+            "properties": {
+                "driver": {
+                    "type": "string"
+                },
+                "journal_mode": {
+                    "type": "string"
+                },
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.DBOperationStats"
+                    }
+                },
+                "pool": {
+                    "$ref": "#/definitions/storage.DBPoolStats"
+                },
+                "since": {
+                    "type": "string"
+                },
+                "write_queue": {
+                    "$ref": "#/definitions/storage.DBWriteQueueStats"
+                }
+            }
+        },
+        "storage.DBWriteQueueStats": {
+            "type": "object",
+            "properties": {
+                "avg_wait_ms": {
+                    "type": "number"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "max_wait_ms": {
+                    "type": "number"
+                },
+                "waiting": {
+                    "description": "当前排队中的写操作",
+                    "type": "integer"
+                },
+                "writes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "time.Duration": {
+            "type": "integer",
+            "enum": [
+                1,
+                1000,
+                1000000,
+                1000000000,
+                60000000000,
+                3600000000000,
+                1,
+                1000,
+                1000000,
+                1000000000,
+                60000000000,
+                3600000000000
+            ],
+            "x-enum-varnames": [
+                "Nanosecond",
+                "Microsecond",
+                "Millisecond",
+                "Second",
+                "Minute",
+                "Hour",
+                "Nanosecond",
+                "Microsecond",
+                "Millisecond",
+                "Second",
+                "Minute",
+                "Hour"
+            ]
+        },
+        "v1.AgentCreateRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "prompt"
+            ],
+            "properties": {
+                "delegates": {
+                    "description": "可以委托子任务的智能体名称",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "description": "用途说明，调度者据此选择委托对象",
+                    "type": "string"
+                },
+                "model": {
+                    "description": "LLM 配置名称，缺省为默认LLM",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "小写字母开头，只含小写字母、数字、_ 和 -",
+                    "type": "string"
+                },
+                "prompt": {
+                    "type": "string"
+                },
+                "tools": {
+                    "description": "允许调用的 MCP 工具",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v1.AgentInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "delegates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prompt": {
+                    "type": "string"
+                },
+                "tools": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.AgentRunRequest": {
+            "type": "object",
+            "required": [
+                "input"
+            ],
+            "properties": {
+                "input": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.AgentRunResult": {
+            "type": "object",
+            "properties": {
+                "agent": {
+                    "type": "string"
+                },
+                "reply": {
+                    "type": "string"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.AgentStep"
+                    }
+                }
+            }
+        },
+        "v1.AgentStep": {
+            "type": "object",
+            "properties": {
+                "agent": {
+                    "type": "string"
+                },
+                "arguments": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "tool 或 delegate",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.AgentUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "delegates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prompt": {
+                    "type": "string"
+                },
+                "tools": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v1.AnalyticsDevice": {
+            "type": "object",
+            "properties": {
+                "conversations": {
+                    "type": "integer"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "last_active_at": {
+                    "type": "string"
+                },
+                "speech_ms": {
+                    "type": "integer"
+                },
+                "tokens": {
+                    "type": "integer"
+                },
+                "tts_chars": {
+                    "type": "integer"
+                },
+                "turns": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.AnalyticsIntent": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "handled": {
+                    "description": "由确定性处理器完成的次数",
+                    "type": "integer"
+                },
+                "intent": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.AnalyticsProvider": {
+            "type": "object",
+            "properties": {
+                "avg_duration_ms": {
+                    "type": "number"
+                },
+                "calls": {
+                    "type": "integer"
+                },
+                "capability": {
+                    "type": "string"
+                },
+                "error_rate": {
+                    "type": "number"
+                },
+                "errors": {
+                    "type": "integer"
+                },
+                "provider": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.AnalyticsRollup": {
+            "type": "object",
+            "properties": {
+                "computed_at": {
+                    "type": "string"
+                },
+                "devices": {
+                    "description": "按语句数从多到少",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.AnalyticsDevice"
+                    }
+                },
+                "intents": {
+                    "description": "按次数从多到少",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.AnalyticsIntent"
+                    }
+                },
+                "period": {
+                    "description": "day/week",
+                    "type": "string"
+                },
+                "providers": {
+                    "description": "按提供者和能力排列",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.AnalyticsProvider"
+                    }
+                },
+                "start": {
+                    "description": "周期第一天 YYYY-MM-DD",
+                    "type": "string"
+                },
+                "usage": {
+                    "$ref": "#/definitions/v1.AnalyticsUsage"
+                }
+            }
+        },
+        "v1.AnalyticsUsage": {
+            "type": "object",
+            "properties": {
+                "active_devices": {
+                    "type": "integer"
+                },
+                "avg_first_token_ms": {
+                    "description": "LLM首个输出的平均耗时",
+                    "type": "number"
+                },
+                "avg_latency_ms": {
+                    "description": "说话结束到首帧音频下发的平均耗时",
+                    "type": "number"
+                },
+                "conversations": {
+                    "type": "integer"
+                },
+                "llm_calls": {
+                    "type": "integer"
+                },
+                "speech_ms": {
+                    "description": "送入ASR的语音时长",
+                    "type": "integer"
+                },
+                "tokens": {
+                    "type": "integer"
+                },
+                "tts_chars": {
+                    "description": "合成播报的字数",
+                    "type": "integer"
+                },
+                "turns": {
+                    "description": "用户语句数",
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.ApprovalDecisionRequest": {
+            "type": "object",
+            "required": [
+                "approved"
+            ],
+            "properties": {
+                "approved": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "approver": {
+                    "description": "falls back to the X-Approver header, then the client IP",
+                    "type": "string",
+                    "example": "alice"
+                },
+                "comment": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.BackupInfo": {
+            "type": "object",
+            "properties": {
+                "auto": {
+                    "description": "自动备份，超出保留数量时被清理",
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.BackupListResponse": {
+            "type": "object",
+            "properties": {
+                "backups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.BackupInfo"
+                    }
+                },
+                "dir": {
+                    "type": "string"
+                },
+                "interval": {
+                    "description": "自动备份间隔，为空表示只手动备份",
+                    "type": "string"
+                },
+                "keep": {
+                    "type": "integer"
+                },
+                "paths": {
+                    "description": "备份的文件和目录",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v1.BackupPassphraseRequest": {
+            "type": "object",
+            "properties": {
+                "passphrase": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.BackupRestoreResponse": {
+            "type": "object",
+            "properties": {
+                "bytes": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "备份创建时间",
+                    "type": "string"
+                },
+                "database": {
+                    "description": "为空表示不含数据库快照",
+                    "type": "string"
+                },
+                "driver": {
+                    "type": "string"
+                },
+                "files": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "restart_required": {
+                    "description": "恢复在下次启动时生效",
+                    "type": "boolean"
+                },
+                "roots": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v1.BackupVerifyResponse": {
+            "type": "object",
+            "properties": {
+                "bytes": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "备份创建时间",
+                    "type": "string"
+                },
+                "database": {
+                    "description": "为空表示不含数据库快照",
+                    "type": "string"
+                },
+                "driver": {
+                    "type": "string"
+                },
+                "files": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "roots": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v1.BindDeviceRequest": {
+            "type": "object",
+            "required": [
+                "device_id"
+            ],
+            "properties": {
+                "device_id": {
+                    "type": "string",
+                    "example": "AA:BB:CC:DD:EE:FF"
+                },
+                "role": {
+                    "description": "默认 member",
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "member"
+                    ],
+                    "example": "owner"
+                }
+            }
+        },
+        "v1.BootReport": {
+            "type": "object",
+            "properties": {
+                "critical_path": {
+                    "description": "决定启动总耗时的步骤链",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "duration_ms": {
+                    "description": "启动总耗时",
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "description": "启动未结束时为空",
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "running, completed, failed",
+                    "type": "string"
+                },
+                "step_total_ms": {
+                    "description": "各步骤耗时之和，大于总耗时的部分来自并行执行",
+                    "type": "integer"
+                },
+                "steps": {
+                    "description": "按开始时间排序",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.BootStepTiming"
+                    }
+                }
+            }
+        },
+        "v1.BootStepTiming": {
+            "type": "object",
+            "properties": {
+                "depends_on": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "offset_ms": {
+                    "description": "相对启动开始的时间",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "pending, running, completed, failed, skipped",
+                    "type": "string"
+                },
+                "timeout_ms": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.BudgetLimits": {
+            "type": "object",
+            "properties": {
+                "daily_tokens": {
+                    "type": "integer"
+                },
+                "profile": {
+                    "description": "预算名称，没有匹配的预算时为空",
+                    "type": "string"
+                },
+                "session_tokens": {
+                    "type": "integer"
+                },
+                "turn_tokens": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.CapabilityDef": {
+            "type": "object",
+            "properties": {
+                "config_schema": {
+                    "$ref": "#/definitions/v1.CapabilitySchema"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "input_schema": {
+                    "$ref": "#/definitions/v1.CapabilitySchema"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "output_schema": {
+                    "$ref": "#/definitions/v1.CapabilitySchema"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.CapabilityProperty": {
+            "type": "object",
+            "properties": {
+                "default": {},
+                "description": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.CapabilitySchema": {
+            "type": "object",
+            "properties": {
+                "properties": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/v1.CapabilityProperty"
+                    }
+                },
+                "required": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ChaosClearResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.ChaosRuleInfo": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delay_ms": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "match": {
+                    "type": "string"
+                },
+                "max_hits": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "probability": {
+                    "type": "number"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ChaosRuleListResponse": {
+            "type": "object",
+            "properties": {
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ChaosRuleInfo"
+                    }
+                }
+            }
+        },
+        "v1.ChaosRuleRequest": {
+            "type": "object",
+            "required": [
+                "action",
+                "target"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "delay",
+                        "error",
+                        "kill"
+                    ]
+                },
+                "delay_ms": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "match": {
+                    "description": "提供者或插件ID，支持 * 通配，为空时匹配全部",
+                    "type": "string"
+                },
+                "max_hits": {
+                    "description": "注入次数上限，0 表示不限",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "message": {
+                    "type": "string"
+                },
+                "method": {
+                    "description": "能力ID、RPC 方法名或请求主机名，支持 * 通配，为空时匹配全部",
+                    "type": "string"
+                },
+                "probability": {
+                    "description": "缺省为 1",
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                },
+                "status_code": {
+                    "description": "HTTP error 返回的状态码，缺省 503",
+                    "type": "integer"
+                },
+                "target": {
+                    "description": "capability 能力调用，grpc 插件 gRPC 调用，http 提供者 HTTP 请求",
+                    "type": "string",
+                    "enum": [
+                        "capability",
+                        "grpc",
+                        "http"
+                    ]
+                },
+                "ttl_seconds": {
+                    "description": "有效期，缺省使用 Chaos.RuleTTL",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "v1.ChatRequest": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "device_id": {
+                    "description": "关联的设备，在线时进入设备当前的会话",
+                    "type": "string"
+                },
+                "session_id": {
+                    "description": "继续之前的会话，为空时按设备生成或新建",
+                    "type": "string"
+                },
+                "stream": {
+                    "description": "以SSE逐段推送回复",
+                    "type": "boolean"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ChatResponse": {
+            "type": "object",
+            "properties": {
+                "blocked": {
+                    "description": "输入被内容审核拦截，reply 为拦截回复",
+                    "type": "boolean"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "reply": {
+                    "type": "string"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "session_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ConversationDetailResponse": {
+            "type": "object",
+            "properties": {
+                "conversation": {
+                    "$ref": "#/definitions/v1.ConversationInfo"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.TranscriptEntryInfo"
+                    }
+                },
+                "latencies": {
+                    "description": "各轮耗时",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.TurnLatencyInfo"
+                    }
+                }
+            }
+        },
+        "v1.ConversationInfo": {
+            "type": "object",
+            "properties": {
+                "device_id": {
+                    "type": "string"
+                },
+                "entries": {
+                    "type": "integer"
+                },
+                "last_at": {
+                    "type": "string"
+                },
+                "rounds": {
+                    "type": "integer"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ConversationListResponse": {
+            "type": "object",
+            "properties": {
+                "conversations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ConversationInfo"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/v1.Pagination"
+                }
+            }
+        },
+        "v1.DeployTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "parameters": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "v1.DeviceActivationRequest": {
+            "type": "object",
+            "required": [
+                "activation_code"
+            ],
+            "properties": {
+                "activation_code": {
+                    "type": "string"
+                },
+                "configuration": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "device_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.DeviceActivationResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "device_info": {
+                    "$ref": "#/definitions/v1.DeviceInfo"
+                },
+                "device_token": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "v1.DeviceBindingInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.DeviceDataErasureResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "description": "按数据类别统计的删除条数，如 recordings、transcripts",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "device_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.DeviceDebugAuditInfo": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "logs/commands/run/config",
+                    "type": "string"
+                },
+                "client_ip": {
+                    "type": "string"
+                },
+                "command": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "params": {
+                    "description": "发给设备的参数 JSON",
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "v1.DeviceDebugAuditListResponse": {
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/v1.Pagination"
+                },
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.DeviceDebugAuditInfo"
+                    }
+                }
+            }
+        },
+        "v1.DeviceDebugConfigRequest": {
+            "type": "object",
+            "required": [
+                "overrides"
+            ],
+            "properties": {
+                "overrides": {
+                    "description": "覆盖的配置项，由固件定义",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "ttl_seconds": {
+                    "description": "有效期，缺省或超过上限时使用 DeviceDebug.MaxOverrideTTL",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "v1.DeviceDebugResult": {
+            "type": "object",
+            "properties": {
+                "audit_id": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "result": {
+                    "description": "设备返回的结果，原样透传"
+                },
+                "session_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.DeviceDebugRunRequest": {
+            "type": "object",
+            "properties": {
+                "args": {
+                    "description": "命令参数，由固件定义",
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "v1.DeviceInfo": {
+            "type": "object",
+            "properties": {
+                "configuration": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "device_name": {
+                    "type": "string"
+                },
+                "device_type": {
+                    "type": "string"
+                },
+                "firmware": {
+                    "$ref": "#/definitions/v1.FirmwareInfo"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_activated": {
+                    "type": "boolean"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "last_seen": {
+                    "type": "string"
+                },
+                "location": {
+                    "$ref": "#/definitions/v1.DeviceLocation"
+                },
+                "media": {
+                    "description": "设备正在播放或暂停中的媒体，仅设备详情返回",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.MediaState"
+                        }
+                    ]
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "model": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "online, offline, error, unknown",
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.DeviceKeyInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "description": "最近一次通过握手校验的时间",
+                    "type": "string"
+                },
+                "public_key": {
+                    "type": "string"
+                },
+                "revoked": {
+                    "type": "boolean"
+                },
+                "revoked_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.DeviceKeyIssueRequest": {
+            "type": "object",
+            "properties": {
+                "revoke_previous": {
+                    "description": "同时吊销设备的其他密钥",
+                    "type": "boolean"
+                }
+            }
+        },
+        "v1.DeviceLanguagesInfo": {
+            "type": "object",
+            "properties": {
+                "custom": {
+                    "description": "为 false 时是配置的默认值",
+                    "type": "boolean"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "languages": {
+                    "description": "第一个为主要语言，使用设备默认音色",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "description": "使用默认值时为空",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.DeviceLanguagesRequest": {
+            "type": "object",
+            "required": [
+                "languages"
+            ],
+            "properties": {
+                "languages": {
+                    "description": "语言代码或中文叫法，第一个为主要语言",
+                    "type": "array",
+                    "maxItems": 8,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v1.DeviceListResponse": {
+            "type": "object",
+            "properties": {
+                "devices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.DeviceInfo"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/v1.Pagination"
+                }
+            }
+        },
+        "v1.DeviceLocation": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "altitude": {
+                    "type": "number"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "province": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.DeviceMemberInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "profile": {
+                    "$ref": "#/definitions/v1.MemberProfileInfo"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.DeviceRegistrationRequest": {
+            "type": "object",
+            "required": [
+                "device_id",
+                "device_name",
+                "device_type"
+            ],
+            "properties": {
+                "device_id": {
+                    "type": "string",
+                    "example": "AA:BB:CC:DD:EE:FF"
+                },
+                "device_name": {
+                    "type": "string",
+                    "example": "客厅小智"
+                },
+                "device_type": {
+                    "type": "string",
+                    "example": "esp32"
+                },
+                "location": {
+                    "$ref": "#/definitions/v1.DeviceLocation"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "model": {
+                    "type": "string",
+                    "example": "esp32-s3"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.6.0"
+                }
+            }
+        },
+        "v1.DeviceStatusRequest": {
+            "type": "object",
+            "required": [
+                "device_id",
+                "is_active"
+            ],
+            "properties": {
+                "device_id": {
+                    "description": "设备MAC地址",
+                    "type": "string"
+                },
+                "is_active": {
+                    "description": "激活状态：true激活，false禁用",
+                    "type": "boolean"
+                }
+            }
+        },
+        "v1.DeviceStatusResponse": {
+            "type": "object",
+            "properties": {
+                "device_info": {
+                    "$ref": "#/definitions/v1.DeviceInfo"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "v1.DeviceTokenUsageInfo": {
+            "type": "object",
+            "properties": {
+                "calls": {
+                    "description": "LLM调用次数",
+                    "type": "integer"
+                },
+                "day": {
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "是否启用预算检查",
+                    "type": "boolean"
+                },
+                "limits": {
+                    "$ref": "#/definitions/v1.BudgetLimits"
+                },
+                "rejected": {
+                    "description": "因超出预算未调用LLM的次数",
+                    "type": "integer"
+                },
+                "remaining": {
+                    "description": "当天剩余的Token，未设置每日上限时为空",
+                    "type": "integer"
+                },
+                "tokens": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.DeviceUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "configuration": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "device_name": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "location": {
+                    "$ref": "#/definitions/v1.DeviceLocation"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "tenant_id": {
+                    "description": "转移到其他租户，仅管理员可用",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.DrainRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "排空原因，记录到日志",
+                    "type": "string"
+                },
+                "timeout_seconds": {
+                    "description": "等待活跃会话结束的最长秒数，0 表示使用配置值",
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.DrainStatus": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "各类型活跃工作数：sessions、workflows",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "deadline": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "remaining_seconds": {
+                    "description": "距截止时间的秒数",
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "serving, draining, drained",
+                    "type": "string"
+                },
+                "timed_out": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "v1.EvalCase": {
+            "type": "object",
+            "properties": {
+                "audio": {
+                    "description": "ASR 音频，base64 编码",
+                    "type": "string"
+                },
+                "expected": {
+                    "description": "期望回答或参考文本",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "input": {
+                    "description": "LLM 提示词或 TTS 文本",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.EvalCaseComparison": {
+            "type": "object",
+            "properties": {
+                "case_id": {
+                    "type": "string"
+                },
+                "expected": {
+                    "type": "string"
+                },
+                "input": {
+                    "type": "string"
+                },
+                "results": {
+                    "description": "key: 运行ID/能力ID",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/v1.EvalCaseResult"
+                    }
+                }
+            }
+        },
+        "v1.EvalCaseResult": {
+            "type": "object",
+            "properties": {
+                "case_id": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "judge_score": {
+                    "type": "number"
+                },
+                "latency_ms": {
+                    "type": "integer"
+                },
+                "output": {
+                    "type": "string"
+                },
+                "similarity": {
+                    "type": "number"
+                },
+                "wer": {
+                    "type": "number"
+                }
+            }
+        },
+        "v1.EvalCompareResponse": {
+            "type": "object",
+            "properties": {
+                "cases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.EvalCaseComparison"
+                    }
+                },
+                "runs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.EvalRunInfo"
+                    }
+                },
+                "suite_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.EvalRunInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "judge": {
+                    "$ref": "#/definitions/v1.EvalTarget"
+                },
+                "reports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.EvalTargetReport"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "suite_id": {
+                    "type": "string"
+                },
+                "suite_type": {
+                    "type": "string"
+                },
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.EvalTarget"
+                    }
+                }
+            }
+        },
+        "v1.EvalRunListResponse": {
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/v1.Pagination"
+                },
+                "runs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.EvalRunInfo"
+                    }
+                }
+            }
+        },
+        "v1.EvalRunRequest": {
+            "type": "object",
+            "required": [
+                "suite_id",
+                "targets"
+            ],
+            "properties": {
+                "judge": {
+                    "description": "LLM 评审，仅 llm 测试集可用",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.EvalTarget"
+                        }
+                    ]
+                },
+                "suite_id": {
+                    "type": "string"
+                },
+                "targets": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/v1.EvalTarget"
+                    }
+                }
+            }
+        },
+        "v1.EvalSuiteCreateRequest": {
+            "type": "object",
+            "required": [
+                "cases",
+                "name",
+                "type"
+            ],
+            "properties": {
+                "cases": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/v1.EvalCase"
+                    }
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 1024
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "system_prompt": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "llm",
+                        "asr",
+                        "tts"
+                    ]
+                }
+            }
+        },
+        "v1.EvalSuiteInfo": {
+            "type": "object",
+            "properties": {
+                "case_count": {
+                    "type": "integer"
+                },
+                "cases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.EvalCase"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "system_prompt": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.EvalSuiteListResponse": {
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/v1.Pagination"
+                },
+                "suites": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.EvalSuiteInfo"
+                    }
+                }
+            }
+        },
+        "v1.EvalTarget": {
+            "type": "object",
+            "required": [
+                "capability_id"
+            ],
+            "properties": {
+                "capability_id": {
+                    "type": "string"
+                },
+                "config": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "v1.EvalTargetReport": {
+            "type": "object",
+            "properties": {
+                "avg_judge_score": {
+                    "type": "number"
+                },
+                "avg_latency_ms": {
+                    "type": "number"
+                },
+                "avg_similarity": {
+                    "type": "number"
+                },
+                "avg_wer": {
+                    "type": "number"
+                },
+                "capability_id": {
+                    "type": "string"
+                },
+                "cases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.EvalCaseResult"
+                    }
+                },
+                "p95_latency_ms": {
+                    "type": "integer"
+                },
+                "succeeded": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.ExecuteCapabilityRequest": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "inputs": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "max_repairs": {
+                    "description": "repair attempts, default 2, at most 5",
+                    "type": "integer"
+                },
+                "response_format": {
+                    "description": "ResponseFormat requests JSON output from an LLM capability: {\"type\": \"json_object\"} or\n{\"type\": \"json_schema\", \"name\": \"...\", \"schema\": {...}}; invalid output is sent back to the LLM for repair",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "timeout": {
+                    "description": "e.g. 30s, at most 5m",
+                    "type": "string",
+                    "example": "30s"
+                }
+            }
+        },
+        "v1.ExecuteCapabilityResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "generations including repairs when response_format is set",
+                    "type": "integer"
+                },
+                "capability_id": {
+                    "type": "string",
+                    "example": "openai_llm"
+                },
+                "chunks": {
+                    "description": "streamed chunks, 0 for non-streaming calls",
+                    "type": "integer"
+                },
+                "elapsed_ms": {
+                    "type": "integer"
+                },
+                "first_chunk_ms": {
+                    "description": "time to the first chunk",
+                    "type": "integer"
+                },
+                "json": {
+                    "description": "parsed output when response_format is set"
+                },
+                "outputs": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "traces": {
+                    "description": "provider and upstream request IDs of every call made",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/capability.Trace"
+                    }
+                }
+            }
+        },
+        "v1.ExecuteWorkflowRequest": {
+            "type": "object",
+            "properties": {
+                "inputs": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "workflow": {
+                    "description": "unsaved workflow to execute or validate instead of the stored one",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/workflow.Workflow"
+                        }
+                    ]
+                }
+            }
+        },
+        "v1.ExperimentCreateRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "variants"
+            ],
+            "properties": {
+                "cohort": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 1024
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "traffic_percent": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "variants": {
+                    "type": "array",
+                    "minItems": 2,
+                    "items": {
+                        "$ref": "#/definitions/v1.ExperimentVariant"
+                    }
+                }
+            }
+        },
+        "v1.ExperimentInfo": {
+            "type": "object",
+            "properties": {
+                "cohort": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "stopped_at": {
+                    "type": "string"
+                },
+                "traffic_percent": {
+                    "type": "integer"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ExperimentVariant"
+                    }
+                }
+            }
+        },
+        "v1.ExperimentListResponse": {
+            "type": "object",
+            "properties": {
+                "experiments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ExperimentInfo"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/v1.Pagination"
+                }
+            }
+        },
+        "v1.ExperimentResultsResponse": {
+            "type": "object",
+            "properties": {
+                "experiment_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ExperimentVariantResult"
+                    }
+                }
+            }
+        },
+        "v1.ExperimentVariant": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "cost_per_1k_token": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "llm": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "prompt_template": {
+                    "type": "string"
+                },
+                "prompt_version": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "weight": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "v1.ExperimentVariantResult": {
+            "type": "object",
+            "properties": {
+                "avg_latency_ms": {
+                    "type": "number"
+                },
+                "avg_tokens": {
+                    "type": "number"
+                },
+                "completion_tokens": {
+                    "type": "integer"
+                },
+                "estimated_cost": {
+                    "type": "number"
+                },
+                "interruption_rate": {
+                    "type": "number"
+                },
+                "prompt_tokens": {
+                    "type": "integer"
+                },
+                "samples": {
+                    "type": "integer"
+                },
+                "satisfaction_rate": {
+                    "type": "number"
+                },
+                "thumbs_down": {
+                    "type": "integer"
+                },
+                "thumbs_up": {
+                    "type": "integer"
+                },
+                "variant": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.FeedbackDailyInfo": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "thumbs_down": {
+                    "type": "integer"
+                },
+                "thumbs_up": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.FeedbackInfo": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "entry_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "string"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.FeedbackListResponse": {
+            "type": "object",
+            "properties": {
+                "feedback": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.FeedbackInfo"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/v1.Pagination"
+                }
+            }
+        },
+        "v1.FeedbackRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "rating": {
+                    "description": "up/down，可为空仅提交文字",
+                    "type": "string",
+                    "enum": [
+                        "up",
+                        "down"
+                    ]
+                }
+            }
+        },
+        "v1.FeedbackStatsResponse": {
+            "type": "object",
+            "properties": {
+                "by_source": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "daily": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.FeedbackDailyInfo"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "satisfaction_rate": {
+                    "type": "number"
+                },
+                "thumbs_down": {
+                    "type": "integer"
+                },
+                "thumbs_up": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "with_comment": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.FirmwareInfo": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "download_count": {
+                    "type": "integer"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.FlagDecision": {
+            "type": "object",
+            "properties": {
+                "device_id": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "rule": {
+                    "description": "命中的规则名称，未命名时为 \"#序号\"",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "rule / flag / default / unknown",
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.FlagInfo": {
+            "type": "object",
+            "properties": {
+                "built_in": {
+                    "description": "内置开关，删除后恢复默认值",
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.FlagRule"
+                    }
+                },
+                "stored": {
+                    "description": "为 false 时是内置开关的默认值",
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "description": "未修改过的内置开关为空",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.FlagListResponse": {
+            "type": "object",
+            "properties": {
+                "flags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.FlagInfo"
+                    }
+                }
+            }
+        },
+        "v1.FlagRule": {
+            "type": "object",
+            "properties": {
+                "devices": {
+                    "description": "设备ID，空表示租户下的全部设备",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "设备分组名称",
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "tenants": {
+                    "description": "租户ID，空表示任意租户",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v1.FlagSetRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 1024
+                },
+                "enabled": {
+                    "description": "没有匹配的规则时的取值",
+                    "type": "boolean"
+                },
+                "rules": {
+                    "description": "按顺序匹配，第一条匹配的规则决定取值",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.FlagRule"
+                    }
+                }
+            }
+        },
+        "v1.IntentRouteStats": {
+            "type": "object",
+            "properties": {
+                "average_latency_ms": {
+                    "type": "number"
+                },
+                "errors": {
+                    "type": "integer"
+                },
+                "fallback_to_llm": {
+                    "description": "回落到LLM的次数",
+                    "type": "integer"
+                },
+                "handled": {
+                    "description": "由确定性处理器完成的次数",
+                    "type": "integer"
+                },
+                "intent": {
+                    "type": "string"
+                },
+                "matched": {
+                    "description": "分类命中次数",
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.IssuedDeviceKeyInfo": {
+            "type": "object",
+            "properties": {
+                "algorithm": {
+                    "type": "string",
+                    "example": "ES256"
+                },
+                "key_id": {
+                    "type": "string"
+                },
+                "private_key": {
+                    "description": "PEM 编码的 P-256 私钥",
+                    "type": "string"
+                },
+                "public_key": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.JobInfo": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "lease_until": {
+                    "type": "string"
+                },
+                "max_attempts": {
+                    "type": "integer"
+                },
+                "payload": {},
+                "run_at": {
+                    "description": "最早可执行时间，等待重试时为退避后的时间",
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "pending、running、succeeded、dead（死信）或 canceled",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.JobListResponse": {
+            "type": "object",
+            "properties": {
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.JobInfo"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/v1.Pagination"
+                }
+            }
+        },
+        "v1.JobStatsResponse": {
+            "type": "object",
+            "properties": {
+                "types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.JobTypeStats"
+                    }
+                }
+            }
+        },
+        "v1.JobTypeStats": {
+            "type": "object",
+            "properties": {
+                "counts": {
+                    "description": "各状态的任务数",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "failures": {
+                    "description": "本实例启动以来失败的执行次数",
+                    "type": "integer"
+                },
+                "running": {
+                    "description": "本实例执行中的任务数",
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "workers": {
+                    "description": "本实例的工作协程数",
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.KnowledgeBaseCreateRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.KnowledgeBaseInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.KnowledgeBaseUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.KnowledgeBindingInfo": {
+            "type": "object",
+            "properties": {
+                "knowledge_base_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "profile": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.KnowledgeBindingRequest": {
+            "type": "object",
+            "properties": {
+                "knowledge_base_ids": {
+                    "description": "为空时解除绑定",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v1.KnowledgeDocumentInfo": {
+            "type": "object",
+            "properties": {
+                "chunks": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "embedding": {
+                    "description": "生成向量使用的能力ID",
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "execution_id": {
+                    "description": "导入任务的工作流执行ID",
+                    "type": "string"
+                },
+                "format": {
+                    "description": "pdf/markdown/text/html",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "knowledge_base_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "object_id": {
+                    "description": "上传文件在对象存储中的ID，可通过 /api/v1/objects/:id/url 获取下载地址",
+                    "type": "string"
+                },
+                "progress": {
+                    "description": "0-100",
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "文件名或网页地址",
+                    "type": "string"
+                },
+                "source_type": {
+                    "description": "file/url",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "pending/extracting/chunking/embedding/ready/failed",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.KnowledgeHit": {
+            "type": "object",
+            "properties": {
+                "chunk_index": {
+                    "type": "integer"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "document_id": {
+                    "type": "string"
+                },
+                "document_name": {
+                    "type": "string"
+                },
+                "knowledge_base_id": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "v1.KnowledgeSearchRequest": {
+            "type": "object",
+            "required": [
+                "query"
+            ],
+            "properties": {
+                "device_id": {
+                    "description": "检索设备所属档案绑定的知识库",
+                    "type": "string"
+                },
+                "knowledge_base_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "query": {
+                    "type": "string"
+                },
+                "top_k": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.KnowledgeSearchResponse": {
+            "type": "object",
+            "properties": {
+                "context": {
+                    "description": "附加给 LLM 的带出处编号的参考资料",
+                    "type": "string"
+                },
+                "hits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.KnowledgeHit"
+                    }
+                }
+            }
+        },
+        "v1.KnowledgeURLRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "name": {
+                    "description": "缺省使用网页地址",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.LanguageInfo": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "voice": {
+                    "description": "该语言的播报音色，未配置时使用设备默认音色",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.LanguageListResponse": {
+            "type": "object",
+            "properties": {
+                "allowed": {
+                    "description": "未单独设置的设备允许的语言",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "enabled": {
+                    "description": "是否启用语言自动识别",
+                    "type": "boolean"
+                },
+                "languages": {
+                    "description": "支持的语言",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.LanguageInfo"
+                    }
+                }
+            }
+        },
+        "v1.LatencyDailyInfo": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "p50_total_ms": {
+                    "type": "integer"
+                },
+                "p95_total_ms": {
+                    "type": "integer"
+                },
+                "turns": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.LatencyStatsResponse": {
+            "type": "object",
+            "properties": {
+                "daily": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.LatencyDailyInfo"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "stages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.StageLatencyInfo"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "turns": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.LogEntryInfo": {
+            "type": "object",
+            "properties": {
+                "attrs": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "level": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "tag": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.LogListResponse": {
+            "type": "object",
+            "properties": {
+                "dropped": {
+                    "description": "启动以来因队列已满或写入失败未进入索引的日志条数",
+                    "type": "integer"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.LogEntryInfo"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/v1.Pagination"
+                }
+            }
+        },
+        "v1.MediaControlRequest": {
+            "type": "object",
+            "required": [
+                "action"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "play",
+                        "pause",
+                        "resume",
+                        "stop",
+                        "next",
+                        "volume"
+                    ]
+                },
+                "query": {
+                    "description": "action 为 play 时点播的内容，为空时随机播放",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "action 为 play 时的检索来源",
+                    "type": "string",
+                    "enum": [
+                        "library",
+                        "radio",
+                        "provider"
+                    ]
+                },
+                "volume": {
+                    "description": "action 为 volume 时设置的音量",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                }
+            }
+        },
+        "v1.MediaState": {
+            "type": "object",
+            "properties": {
+                "device_id": {
+                    "type": "string"
+                },
+                "error": {
+                    "description": "最近一次播放失败的原因",
+                    "type": "string"
+                },
+                "index": {
+                    "description": "当前曲目在播放列表中的位置",
+                    "type": "integer"
+                },
+                "position_ms": {
+                    "type": "integer"
+                },
+                "queue_size": {
+                    "description": "播放列表长度",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "idle/loading/playing/paused",
+                    "type": "string"
+                },
+                "track": {
+                    "$ref": "#/definitions/v1.MediaTrack"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "volume": {
+                    "description": "0-100",
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.MediaTrack": {
+            "type": "object",
+            "properties": {
+                "artist": {
+                    "type": "string"
+                },
+                "live": {
+                    "type": "boolean"
+                },
+                "source": {
+                    "description": "library/radio/provider",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "电台和媒体能力返回的音频流地址，本地曲库不返回",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.MemberProfileInfo": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "type": "string"
+                },
+                "memory_namespace": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "voice": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.MemberProfileUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "type": "string",
+                    "example": "zh-CN"
+                },
+                "memory_namespace": {
+                    "description": "置空时恢复默认的 user-\u003cid\u003e",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "小明"
+                },
+                "voice": {
+                    "type": "string",
+                    "example": "zh-CN-XiaoxiaoNeural"
+                }
+            }
+        },
+        "v1.MemoryClearResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.MemoryConsentInfo": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "description": "本次删除的记忆条数",
+                    "type": "integer"
+                },
+                "granted": {
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.MemoryConsentRequest": {
+            "type": "object",
+            "required": [
+                "granted"
+            ],
+            "properties": {
+                "delete_existing": {
+                    "description": "撤销授权时同时删除已有记忆",
+                    "type": "boolean"
+                },
+                "granted": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "v1.MemoryFactInfo": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "device_id": {
+                    "description": "提取自该设备的对话",
+                    "type": "string"
+                },
+                "entry_id": {
+                    "description": "对应对话记录中的用户消息",
+                    "type": "string"
+                },
+                "evidence": {
+                    "description": "提取依据的用户原话",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "description": "提取自该会话",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "extracted（从对话中提取）/manual（手动添加或修改）",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.MemoryFactListResponse": {
+            "type": "object",
+            "properties": {
+                "facts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.MemoryFactInfo"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/v1.Pagination"
+                }
+            }
+        },
+        "v1.MemoryFactRequest": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "description": "最多 500 字",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.MonitorEvent": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "snippet": {
+                    "description": "type 为 snippet 时的对话片段",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.MonitorSnippet"
+                        }
+                    ]
+                },
+                "state": {
+                    "description": "type 为 state 时的新状态",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "state/snippet/closed",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.MonitorFlag": {
+            "type": "object",
+            "properties": {
+                "flagged_at": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.MonitorFlagRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "description": "标记原因",
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "v1.MonitorSession": {
+            "type": "object",
+            "properties": {
+                "connected_at": {
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "flag": {
+                    "$ref": "#/definitions/v1.MonitorFlag"
+                },
+                "llm": {
+                    "description": "提供者/模型",
+                    "type": "string"
+                },
+                "recent": {
+                    "description": "最近的对话片段，按时间顺序",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.MonitorSnippet"
+                    }
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "idle/listening/thinking/speaking",
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "tts": {
+                    "description": "TTS 配置名",
+                    "type": "string"
+                },
+                "voice": {
+                    "description": "当前播报使用的音色",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.MonitorSnippet": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "content": {
+                    "description": "超过 200 字的部分截断",
+                    "type": "string"
+                },
+                "role": {
+                    "description": "user/assistant",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.NodeLogsResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "logs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workflow.NodeLog"
+                    }
+                },
+                "node_id": {
+                    "type": "string",
+                    "example": "llm_1"
+                },
+                "status": {
+                    "$ref": "#/definitions/workflow.NodeStatus"
+                },
+                "timing": {
+                    "$ref": "#/definitions/workflow.NodeTiming"
+                }
+            }
+        },
+        "v1.NotificationChannelCreateRequest": {
+            "type": "object",
+            "required": [
+                "config",
+                "name",
+                "type"
+            ],
+            "properties": {
+                "config": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "description": "plugin_crashed, provider_outage, budget_exceeded 或 *",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "email",
+                        "webhook",
+                        "dingtalk",
+                        "feishu",
+                        "telegram"
+                    ]
+                }
+            }
+        },
+        "v1.NotificationChannelInfo": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.NotificationChannelUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "description": "敏感字段传 ****** 表示保留原值",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ObjectInfo": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "为空表示由引用方释放",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "内容的 SHA-256",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "refs": {
+                    "description": "长期引用数",
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ObjectListResponse": {
+            "type": "object",
+            "properties": {
+                "objects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ObjectInfo"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/v1.Pagination"
+                }
+            }
+        },
+        "v1.ObjectURLResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ObjectUploadResponse": {
+            "type": "object",
+            "properties": {
+                "object": {
+                    "$ref": "#/definitions/v1.ObjectInfo"
+                },
+                "url": {
+                    "description": "默认有效期的签名下载地址",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.Pagination": {
+            "type": "object",
+            "properties": {
+                "has_next": {
+                    "type": "boolean"
+                },
+                "has_prev": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.PairingTokenInfo": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "二维码内容",
+                    "type": "string",
+                    "example": "xiaozhi://pair?server=https%3A%2F%2Fexample.com\u0026token=pair_..."
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.PluginControlRequest": {
+            "type": "object",
+            "required": [
+                "action"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "start",
+                        "stop",
+                        "restart",
+                        "reallocate_port"
+                    ]
+                },
+                "config": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "v1.PluginControlResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "new_port": {
+                    "type": "integer"
+                },
+                "new_status": {
+                    "type": "string"
+                },
+                "old_port": {
+                    "type": "integer"
+                },
+                "old_status": {
+                    "type": "string"
+                },
+                "process_time": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "v1.PluginDebugInfo": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "go_version": {
+                    "type": "string"
+                },
+                "log_level": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pid": {
+                    "type": "integer"
+                },
+                "plugin_id": {
+                    "type": "string"
+                },
+                "services": {
+                    "description": "已注册的gRPC服务全名",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "uptime_seconds": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.PluginDebugMetrics": {
+            "type": "object",
+            "properties": {
+                "methods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.PluginMethodMetrics"
+                    }
+                },
+                "plugin_id": {
+                    "type": "string"
+                },
+                "runtime": {
+                    "description": "goroutines、heap_alloc_bytes、heap_sys_bytes、gc_count、gc_pause_total_ms",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "v1.PluginListResponse": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "plugins": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.PluginStatus"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.PluginLogLevelRequest": {
+            "type": "object",
+            "required": [
+                "level"
+            ],
+            "properties": {
+                "level": {
+                    "type": "string",
+                    "enum": [
+                        "debug",
+                        "info",
+                        "warn",
+                        "error"
+                    ]
+                }
+            }
+        },
+        "v1.PluginLogLevelResponse": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string"
+                },
+                "plugin_id": {
+                    "type": "string"
+                },
+                "previous_level": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.PluginLogsResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/logs.Entry"
+                    }
+                },
+                "plugin_id": {
+                    "type": "string"
+                },
+                "total": {
+                    "description": "缓冲区中的日志行数",
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.PluginMethodMetrics": {
+            "type": "object",
+            "properties": {
+                "calls": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "integer"
+                },
+                "max_ms": {
+                    "type": "number"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "total_ms": {
+                    "type": "number"
+                }
+            }
+        },
+        "v1.PluginStats": {
+            "type": "object",
+            "properties": {
+                "by_capability": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "by_type": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "disabled": {
+                    "type": "integer"
+                },
+                "enabled": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "integer"
+                },
+                "healthy": {
+                    "type": "integer"
+                },
+                "last_updated": {
+                    "type": "string"
+                },
+                "running": {
+                    "type": "integer"
+                },
+                "stopped": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unknown": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.PluginStatus": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "capabilities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.CapabilityDef"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "health_status": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_health_check": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "port": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.PortAllocationsResponse": {
+            "type": "object",
+            "properties": {
+                "allocations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ports.PortAllocation"
+                    }
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ports.PortEvent"
+                    }
+                },
+                "stats": {
+                    "$ref": "#/definitions/ports.PortStats"
+                }
+            }
+        },
+        "v1.PresenceCheckInRequest": {
+            "type": "object",
+            "properties": {
+                "accuracy": {
+                    "description": "定位精度（米）",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "home",
+                        "away"
+                    ]
+                }
+            }
+        },
+        "v1.PresenceOverview": {
+            "type": "object",
+            "properties": {
+                "anyone_home": {
+                    "type": "boolean"
+                },
+                "home": {
+                    "description": "在家成员的称呼",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.PresenceStatus"
+                    }
+                }
+            }
+        },
+        "v1.PresenceRouterReport": {
+            "type": "object",
+            "required": [
+                "macs"
+            ],
+            "properties": {
+                "macs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v1.PresenceRouterReportResponse": {
+            "type": "object",
+            "properties": {
+                "matched": {
+                    "description": "其中属于成员的设备数",
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.PresenceStatus": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "since": {
+                    "description": "进入当前状态的时间",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "进入当前状态的来源：app / geofence / router",
+                    "type": "string"
+                },
+                "state": {
+                    "description": "home / away / unknown",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.PromptAssignRequest": {
+            "type": "object",
+            "required": [
+                "template_name"
+            ],
+            "properties": {
+                "template_name": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "0 表示跟随生效版本",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "v1.PromptAssignmentInfo": {
+            "type": "object",
+            "properties": {
+                "device_id": {
+                    "type": "string"
+                },
+                "template_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.PromptCreateRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "name"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "你是孩子的学习伙伴，回答要简短易懂"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 512
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "kids-tutor"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v1.PromptInfo": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.PromptListResponse": {
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/v1.Pagination"
+                },
+                "prompts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.PromptInfo"
+                    }
+                }
+            }
+        },
+        "v1.PromptRenderRequest": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "variables": {
+                    "description": "Content 的变量默认值",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "vars": {
+                    "description": "本次渲染传入的变量",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.PromptRenderResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "rendered": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.PromptUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v1.ProviderCallInfo": {
+            "type": "object",
+            "properties": {
+                "capability_id": {
+                    "type": "string"
+                },
+                "capability_type": {
+                    "type": "string"
+                },
+                "chunks": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "provider_id": {
+                    "type": "string"
+                },
+                "request": {
+                    "description": "JSON，包含 config 和 inputs，已脱敏",
+                    "type": "string"
+                },
+                "response": {
+                    "description": "JSON，流式调用为分块数组，已脱敏",
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "stream": {
+                    "type": "boolean"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "v1.ProviderCallListResponse": {
+            "type": "object",
+            "properties": {
+                "calls": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ProviderCallSummary"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/v1.Pagination"
+                }
+            }
+        },
+        "v1.ProviderCallSummary": {
+            "type": "object",
+            "properties": {
+                "capability_id": {
+                    "type": "string"
+                },
+                "capability_type": {
+                    "type": "string"
+                },
+                "chunks": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "provider_id": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "stream": {
+                    "type": "boolean"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "v1.ProviderConnectivityRequest": {
+            "type": "object",
+            "required": [
+                "provider",
+                "url"
+            ],
+            "properties": {
+                "network": {
+                    "description": "与能力配置中的 network 格式相同，叠加在提供者的网络配置之上",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "provider": {
+                    "description": "连接池中的提供者名称，与 HTTPClient.Providers 的键相同，如 openai",
+                    "type": "string"
+                },
+                "timeout": {
+                    "description": "如 10s，最长 1m",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "测试地址，如提供者的 base_url",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ProviderTestRequest": {
+            "type": "object",
+            "required": [
+                "capability_id"
+            ],
+            "properties": {
+                "capability_id": {
+                    "type": "string"
+                },
+                "config": {
+                    "description": "待测试的能力配置，如 api_key、model",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "timeout": {
+                    "description": "如 10s，最长 1m",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ProviderTestResult": {
+            "type": "object",
+            "properties": {
+                "capability_id": {
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "provider_id": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ProvisioningClaimRequest": {
+            "type": "object",
+            "required": [
+                "device_id",
+                "token"
+            ],
+            "properties": {
+                "client_id": {
+                    "description": "为空时使用 client_\u003c设备ID\u003e",
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string",
+                    "example": "AA:BB:CC:DD:EE:FF"
+                },
+                "device_name": {
+                    "type": "string",
+                    "example": "客厅小智"
+                },
+                "device_type": {
+                    "type": "string",
+                    "example": "esp32-s3"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.0.0"
+                }
+            }
+        },
+        "v1.ProvisioningClaimResponse": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "device_token": {
+                    "description": "连接 WebSocket 时以 Authorization: Bearer 携带",
+                    "type": "string"
+                },
+                "key": {
+                    "$ref": "#/definitions/v1.IssuedDeviceKeyInfo"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "设备所有者",
+                    "type": "integer"
+                },
+                "websocket": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.RecordingConsentInfo": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "description": "本次删除的录音条数",
+                    "type": "integer"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "granted": {
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.RecordingConsentRequest": {
+            "type": "object",
+            "required": [
+                "granted"
+            ],
+            "properties": {
+                "delete_existing": {
+                    "description": "撤销授权时同时删除已归档的语音",
+                    "type": "boolean"
+                },
+                "granted": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "v1.RecordingInfo": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "entry_id": {
+                    "description": "对应的对话记录消息ID",
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "sample_rate": {
+                    "type": "integer"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.RecordingListResponse": {
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/v1.Pagination"
+                },
+                "recordings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.RecordingInfo"
+                    }
+                }
+            }
+        },
+        "v1.ReminderCreateRequest": {
+            "type": "object",
+            "required": [
+                "device_id"
+            ],
+            "properties": {
+                "delay_seconds": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "fire_at": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "timer",
+                        "alarm",
+                        "reminder"
+                    ]
+                },
+                "message": {
+                    "type": "string"
+                },
+                "recurrence": {
+                    "type": "string",
+                    "enum": [
+                        "daily",
+                        "weekdays",
+                        "weekly"
+                    ]
+                }
+            }
+        },
+        "v1.ReminderInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "fire_at": {
+                    "type": "string"
+                },
+                "fired_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "recurrence": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ReminderListResponse": {
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/v1.Pagination"
+                },
+                "reminders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ReminderInfo"
+                    }
+                }
+            }
+        },
+        "v1.ReminderUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "fire_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "recurrence": {
+                    "description": "传空字符串表示取消重复",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ReplayDiffSegment": {
+            "type": "object",
+            "properties": {
+                "op": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ReplayOptions": {
+            "type": "object",
+            "properties": {
+                "cost_per_1k_token": {
+                    "description": "回放模型的每千Token成本",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "max_tokens": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "model": {
+                    "description": "LLM 配置名称，为空时使用当前选择的 LLM",
+                    "type": "string"
+                },
+                "model_name": {
+                    "description": "覆盖配置中的模型名称",
+                    "type": "string"
+                },
+                "original_cost_per_1k_token": {
+                    "description": "原对话模型的每千Token成本",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "prompt_template": {
+                    "description": "提示词模板名称，为空时按设备当前分配解析",
+                    "type": "string"
+                },
+                "prompt_version": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "system_prompt": {
+                    "description": "直接指定系统提示词，优先于模板",
+                    "type": "string"
+                },
+                "temperature": {
+                    "type": "number",
+                    "maximum": 2,
+                    "minimum": 0
+                }
+            }
+        },
+        "v1.ReplayRunInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "model_name": {
+                    "type": "string"
+                },
+                "options": {
+                    "$ref": "#/definitions/v1.ReplayOptions"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "summary": {
+                    "$ref": "#/definitions/v1.ReplaySummary"
+                },
+                "system_prompt": {
+                    "type": "string"
+                },
+                "turns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ReplayTurn"
+                    }
+                }
+            }
+        },
+        "v1.ReplayRunListResponse": {
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/v1.Pagination"
+                },
+                "runs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ReplayRunInfo"
+                    }
+                }
+            }
+        },
+        "v1.ReplaySide": {
+            "type": "object",
+            "properties": {
+                "complete_ms": {
+                    "type": "integer"
+                },
+                "cost": {
+                    "type": "number"
+                },
+                "first_token_ms": {
+                    "type": "integer"
+                },
+                "response": {
+                    "type": "string"
+                },
+                "tokens": {
+                    "type": "integer"
+                },
+                "tokens_estimated": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "v1.ReplaySideSummary": {
+            "type": "object",
+            "properties": {
+                "avg_complete_ms": {
+                    "type": "number"
+                },
+                "avg_first_token_ms": {
+                    "type": "number"
+                },
+                "cost": {
+                    "type": "number"
+                },
+                "tokens": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.ReplayStartRequest": {
+            "type": "object",
+            "required": [
+                "session_id"
+            ],
+            "properties": {
+                "cost_per_1k_token": {
+                    "description": "回放模型的每千Token成本",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "max_tokens": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "model": {
+                    "description": "LLM 配置名称，为空时使用当前选择的 LLM",
+                    "type": "string"
+                },
+                "model_name": {
+                    "description": "覆盖配置中的模型名称",
+                    "type": "string"
+                },
+                "original_cost_per_1k_token": {
+                    "description": "原对话模型的每千Token成本",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "prompt_template": {
+                    "description": "提示词模板名称，为空时按设备当前分配解析",
+                    "type": "string"
+                },
+                "prompt_version": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "system_prompt": {
+                    "description": "直接指定系统提示词，优先于模板",
+                    "type": "string"
+                },
+                "temperature": {
+                    "type": "number",
+                    "maximum": 2,
+                    "minimum": 0
+                }
+            }
+        },
+        "v1.ReplaySummary": {
+            "type": "object",
+            "properties": {
+                "avg_similarity": {
+                    "type": "number"
+                },
+                "original": {
+                    "$ref": "#/definitions/v1.ReplaySideSummary"
+                },
+                "replay": {
+                    "$ref": "#/definitions/v1.ReplaySideSummary"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "succeeded": {
+                    "type": "integer"
+                },
+                "turns": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.ReplayTurn": {
+            "type": "object",
+            "properties": {
+                "diff": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ReplayDiffSegment"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "input": {
+                    "type": "string"
+                },
+                "original": {
+                    "$ref": "#/definitions/v1.ReplaySide"
+                },
+                "redacted": {
+                    "type": "boolean"
+                },
+                "replay": {
+                    "$ref": "#/definitions/v1.ReplaySide"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "similarity": {
+                    "type": "number"
+                }
+            }
+        },
+        "v1.RoutineAction": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "param": {
+                    "description": "weather 为地点，news 为新闻分类，say 为播报文本，workflow 为工作流ID",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "weather",
+                        "news",
+                        "calendar",
+                        "say",
+                        "workflow"
+                    ]
+                }
+            }
+        },
+        "v1.RoutineCreateRequest": {
+            "type": "object",
+            "required": [
+                "actions",
+                "device_id",
+                "name"
+            ],
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/v1.RoutineAction"
+                    }
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "默认启用",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phrase": {
+                    "description": "唤醒短语",
+                    "type": "string"
+                },
+                "schedule": {
+                    "description": "五段式 cron 表达式（分 时 日 月 周），如 \"0 7 * * 1-5\"",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.RoutineInfo": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.RoutineAction"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "description": "口语描述，如“每天7点整播报天气和新闻”",
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_run_at": {
+                    "type": "string"
+                },
+                "last_status": {
+                    "description": "ok / partial / failed / missed",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "next_run_at": {
+                    "type": "string"
+                },
+                "phrase": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.RoutineRunResponse": {
+            "type": "object",
+            "properties": {
+                "reply": {
+                    "description": "推送给设备的播报文本",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.RoutineUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/v1.RoutineAction"
+                    }
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phrase": {
+                    "description": "传空字符串表示取消唤醒短语",
+                    "type": "string"
+                },
+                "schedule": {
+                    "description": "传空字符串表示取消定时触发",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ScriptCreateRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "source"
+            ],
+            "properties": {
+                "comment": {
+                    "description": "版本说明",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Lua 源码",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ScriptInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.ScriptRollbackRequest": {
+            "type": "object",
+            "required": [
+                "version"
+            ],
+            "properties": {
+                "version": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "v1.ScriptTestRequest": {
+            "type": "object",
+            "properties": {
+                "inputs": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "timeout": {
+                    "description": "如 5s，最长 1m",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "缺省为当前版本",
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.ScriptTestResult": {
+            "type": "object",
+            "properties": {
+                "logs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "outputs": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "v1.ScriptUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ScriptVersionInfo": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "script_id": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.SelfTestReport": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "passed": {
+                    "description": "没有失败项",
+                    "type": "boolean"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.SelfTestResult"
+                    }
+                },
+                "started_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.SelfTestResult": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "pass, warn, fail, skip",
+                    "type": "string"
+                },
+                "step": {
+                    "description": "所属初始化步骤ID，如 storage:init-database",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.SmartHomeAlias": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_name": {
+                    "description": "实体当前的播报名称，实体已不存在时为空",
+                    "type": "string"
+                },
+                "hits": {
+                    "description": "语音指令通过该别名匹配到实体的次数",
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "manual/learned（从语音纠正中学到）",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.SmartHomeAliasRequest": {
+            "type": "object",
+            "required": [
+                "alias",
+                "entity_id"
+            ],
+            "properties": {
+                "alias": {
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "entity_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.SmartHomeControlRequest": {
+            "type": "object",
+            "required": [
+                "action"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "turn_on",
+                        "turn_off",
+                        "toggle",
+                        "set_brightness",
+                        "set_position",
+                        "set_temperature",
+                        "open",
+                        "close",
+                        "lock",
+                        "unlock"
+                    ]
+                },
+                "value": {
+                    "description": "set_brightness/set_position 为 0-100 的百分比，set_temperature 为摄氏度",
+                    "type": "number"
+                }
+            }
+        },
+        "v1.SmartHomeEntity": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "available": {
+                    "type": "boolean"
+                },
+                "bridge": {
+                    "description": "桥接名称",
+                    "type": "string"
+                },
+                "features": {
+                    "description": "on_off/brightness/open_close/position/temperature/lock",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "description": "\"\u003c桥接名称\u003e:\u003c设备ID\u003e\"",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "room": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "on/brightness/position/temperature/current_temperature/locked 及传感器读数",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "type": {
+                    "description": "light/switch/cover/climate/lock/fan/sensor",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "最近一次从桥接读取状态的时间",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.SpeechTextAssignRequest": {
+            "type": "object",
+            "required": [
+                "profile"
+            ],
+            "properties": {
+                "profile": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.SpeechTextAssignmentInfo": {
+            "type": "object",
+            "properties": {
+                "device_id": {
+                    "type": "string"
+                },
+                "profile": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "未分配、使用 default 方案时为空",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.SpeechTextPreviewRequest": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "profile": {
+                    "description": "使用已保存的方案，与 stages 二选一",
+                    "type": "string"
+                },
+                "stages": {
+                    "description": "使用未保存的步骤",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.SpeechTextStage"
+                    }
+                },
+                "text": {
+                    "type": "string",
+                    "maxLength": 4096
+                }
+            }
+        },
+        "v1.SpeechTextPreviewResponse": {
+            "type": "object",
+            "properties": {
+                "source": {
+                    "description": "原始文本",
+                    "type": "string"
+                },
+                "text": {
+                    "description": "处理后送入 TTS 的文本",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.SpeechTextProfileInfo": {
+            "type": "object",
+            "properties": {
+                "built_in": {
+                    "description": "数据库中没有 default 方案时返回的内置方案",
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "stages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.SpeechTextStage"
+                    }
+                },
+                "updated_at": {
+                    "description": "内置方案为空",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.SpeechTextProfileListResponse": {
+            "type": "object",
+            "properties": {
+                "assignments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.SpeechTextAssignmentInfo"
+                    }
+                },
+                "profiles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.SpeechTextProfileInfo"
+                    }
+                }
+            }
+        },
+        "v1.SpeechTextProfileRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 1024
+                },
+                "name": {
+                    "description": "创建时必填，更新时以路径为准",
+                    "type": "string"
+                },
+                "stages": {
+                    "description": "按顺序执行",
+                    "type": "array",
+                    "maxItems": 32,
+                    "items": {
+                        "$ref": "#/definitions/v1.SpeechTextStage"
+                    }
+                }
+            }
+        },
+        "v1.SpeechTextStage": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "language": {
+                    "description": "units 使用：zh 或 en，为空时按文本是否包含汉字判断",
+                    "type": "string"
+                },
+                "pattern": {
+                    "description": "regex 使用：Go 正则表达式",
+                    "type": "string"
+                },
+                "replacement": {
+                    "description": "regex 使用：替换文本，用 ${1} 引用分组",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "markdown",
+                        "emoji",
+                        "units",
+                        "replace",
+                        "regex"
+                    ]
+                },
+                "words": {
+                    "description": "replace 使用：原词到读法的映射，长词优先",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v1.StageLatencyInfo": {
+            "type": "object",
+            "properties": {
+                "avg_ms": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "max_ms": {
+                    "type": "integer"
+                },
+                "p50_ms": {
+                    "type": "integer"
+                },
+                "p90_ms": {
+                    "type": "integer"
+                },
+                "p95_ms": {
+                    "type": "integer"
+                },
+                "p99_ms": {
+                    "type": "integer"
+                },
+                "stage": {
+                    "description": "capture/asr/llm_first_token/llm_complete/tts_first_byte/playback_start/total",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.StartupWorkflowResponse": {
+            "type": "object",
+            "properties": {
+                "execution": {
+                    "$ref": "#/definitions/workflow.Execution"
+                },
+                "workflow": {
+                    "$ref": "#/definitions/workflow.Workflow"
+                }
+            }
+        },
+        "v1.TenantCreateRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "acme"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Acme 公司"
+                },
+                "quota": {
+                    "$ref": "#/definitions/v1.TenantQuota"
+                }
+            }
+        },
+        "v1.TenantInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "has_token": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quota": {
+                    "$ref": "#/definitions/v1.TenantQuota"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.TenantLLMConfig": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "description": "读取时脱敏为 ******，保存时传 ****** 表示保留原值",
+                    "type": "string"
+                },
+                "base_url": {
+                    "type": "string"
+                },
+                "extra": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "max_tokens": {
+                    "type": "integer"
+                },
+                "model_name": {
+                    "type": "string",
+                    "example": "gpt-4o-mini"
+                },
+                "temperature": {
+                    "type": "number"
+                },
+                "top_p": {
+                    "type": "number"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "openai"
+                }
+            }
+        },
+        "v1.TenantProviders": {
+            "type": "object",
+            "properties": {
+                "llm": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/v1.TenantLLMConfig"
+                    }
+                },
+                "selected_llm": {
+                    "type": "string",
+                    "example": "acme-llm"
+                },
+                "selected_tts": {
+                    "type": "string"
+                },
+                "tts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/v1.TenantTTSConfig"
+                    }
+                }
+            }
+        },
+        "v1.TenantQuota": {
+            "type": "object",
+            "properties": {
+                "daily_requests": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10000
+                },
+                "daily_tokens": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2000000
+                },
+                "max_devices": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 100
+                }
+            }
+        },
+        "v1.TenantTTSConfig": {
+            "type": "object",
+            "properties": {
+                "app_id": {
+                    "type": "string"
+                },
+                "cluster": {
+                    "type": "string"
+                },
+                "extra": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "format": {
+                    "type": "string",
+                    "example": "mp3"
+                },
+                "token": {
+                    "description": "读取时脱敏为 ******，保存时传 ****** 表示保留原值",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "doubao"
+                },
+                "voice": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.TenantTokenResponse": {
+            "type": "object",
+            "properties": {
+                "tenant": {
+                    "$ref": "#/definitions/v1.TenantInfo"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.TenantUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quota": {
+                    "$ref": "#/definitions/v1.TenantQuota"
+                }
+            }
+        },
+        "v1.TenantUsageInfo": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "string"
+                },
+                "devices": {
+                    "type": "integer"
+                },
+                "quota": {
+                    "$ref": "#/definitions/v1.TenantQuota"
+                },
+                "requests": {
+                    "type": "integer"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "tokens": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.ToolPolicyAuditInfo": {
+            "type": "object",
+            "properties": {
+                "agent": {
+                    "type": "string"
+                },
+                "allowed": {
+                    "type": "boolean"
+                },
+                "arguments": {
+                    "description": "JSON",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "tool/capability",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "profile": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
                 }
             }
-        }
-    },
-    "definitions": {
-        "httptransport.APIResponse": {
+        },
+        "v1.ToolPolicyAuditListResponse": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer"
-                },
-                "data": {},
-                "message": {
-                    "type": "string"
+                "pagination": {
+                    "$ref": "#/definitions/v1.Pagination"
                 },
-                "success": {
-                    "type": "boolean"
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ToolPolicyAuditInfo"
+                    }
                 }
             }
         },
-        "ota.Activation": {
+        "v1.ToolPolicyCheckRequest": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "challenge": {
-                    "description": "用于设备认证挑战",
+                "agent": {
                     "type": "string"
                 },
-                "code": {
-                    "type": "string",
-                    "example": "543091"
+                "arguments": {
+                    "type": "object",
+                    "additionalProperties": true
                 },
-                "message": {
-                    "type": "string",
-                    "example": "Anime AI Chat 543091"
+                "device_id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "tool/capability，缺省为 tool",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
                 }
             }
         },
-        "ota.FirmwareInfo": {
+        "v1.ToolPolicyCheckResponse": {
             "type": "object",
             "properties": {
-                "url": {
-                    "type": "string",
-                    "example": "/ota_bin/1.2.4.bin"
+                "allowed": {
+                    "type": "boolean"
                 },
-                "version": {
-                    "type": "string",
-                    "example": "1.2.4"
+                "profile": {
+                    "description": "参与检查的策略，多个以逗号分隔",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "rule": {
+                    "description": "做出决定的规则，为空表示使用策略的默认动作",
+                    "type": "string"
                 }
             }
         },
-        "ota.MQTTInfo": {
+        "v1.TranscriptEntryInfo": {
             "type": "object",
             "properties": {
-                "client_id": {
-                    "type": "string",
-                    "example": "CGID_test@@@mac@@@client"
+                "content": {
+                    "type": "string"
                 },
-                "endpoint": {
-                    "type": "string",
-                    "example": "mqtt://broker:1883"
+                "created_at": {
+                    "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "example": "randomPwd"
+                "id": {
+                    "type": "string"
                 },
-                "publish_topic": {
-                    "type": "string",
-                    "example": "device-server"
+                "latency_ms": {
+                    "type": "integer"
                 },
-                "subscribe_topic": {
-                    "type": "string",
-                    "example": "null"
+                "redacted": {
+                    "type": "boolean"
                 },
-                "username": {
-                    "type": "string",
-                    "example": "base64string"
+                "role": {
+                    "type": "string"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "tool_args": {
+                    "type": "string"
+                },
+                "tool_call_id": {
+                    "type": "string"
+                },
+                "tool_name": {
+                    "type": "string"
                 }
             }
         },
-        "ota.OTAResponse": {
+        "v1.TranslationLanguage": {
             "type": "object",
             "properties": {
-                "activation": {
-                    "$ref": "#/definitions/ota.Activation"
-                },
-                "firmware": {
-                    "$ref": "#/definitions/ota.FirmwareInfo"
-                },
-                "mqtt": {
-                    "$ref": "#/definitions/ota.MQTTInfo"
+                "code": {
+                    "type": "string"
                 },
-                "server_time": {
-                    "$ref": "#/definitions/ota.ServerTimeInfo"
+                "name": {
+                    "type": "string"
                 },
-                "websocket": {
-                    "$ref": "#/definitions/ota.WebSocketInfo"
+                "voice": {
+                    "description": "该语言配置的TTS音色",
+                    "type": "string"
                 }
             }
         },
-        "ota.ServerTimeInfo": {
+        "v1.TranslationSessionInfo": {
             "type": "object",
             "properties": {
-                "timestamp": {
-                    "type": "integer",
-                    "example": 1720065289451
+                "active": {
+                    "type": "boolean"
                 },
-                "timezone_offset": {
-                    "type": "integer",
-                    "example": 480
+                "device_id": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
                 }
             }
         },
-        "ota.WebSocketInfo": {
+        "v1.TranslationStartRequest": {
             "type": "object",
             "properties": {
-                "url": {
-                    "type": "string",
-                    "example": "wss://your-server/ws"
+                "source": {
+                    "description": "源语言代码，auto 表示自动识别",
+                    "type": "string"
+                },
+                "target": {
+                    "description": "目标语言代码",
+                    "type": "string"
                 }
             }
         },
-        "v1.APIError": {
+        "v1.TurnLatencyInfo": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "string"
+                "asr_ms": {
+                    "description": "说话结束到识别结果",
+                    "type": "integer"
                 },
-                "message": {
+                "capture_ms": {
+                    "description": "开始说话到说话结束",
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
+                },
+                "llm_complete_ms": {
+                    "description": "识别结果到LLM输出结束",
+                    "type": "integer"
+                },
+                "llm_first_token_ms": {
+                    "description": "识别结果到LLM首个输出",
+                    "type": "integer"
+                },
+                "playback_start_ms": {
+                    "description": "识别结果到首帧音频下发",
+                    "type": "integer"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "total_ms": {
+                    "description": "说话结束到首帧音频下发",
+                    "type": "integer"
+                },
+                "tts_first_byte_ms": {
+                    "description": "首句送入TTS到首句音频合成完成",
+                    "type": "integer"
                 }
             }
         },
-        "v1.APIResponse": {
+        "v1.WebhookAttempt": {
             "type": "object",
             "properties": {
-                "data": {},
-                "error": {
-                    "$ref": "#/definitions/v1.APIError"
+                "at": {
+                    "type": "string"
                 },
-                "message": {
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
                     "type": "string"
                 },
-                "request_id": {
+                "response_body": {
+                    "description": "截取前 1KB",
                     "type": "string"
                 },
-                "success": {
+                "status_code": {
+                    "description": "未收到响应时为 0",
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.WebhookCreateRequest": {
+            "type": "object",
+            "required": [
+                "events",
+                "name",
+                "url"
+            ],
+            "properties": {
+                "enabled": {
                     "type": "boolean"
                 },
-                "timestamp": {
-                    "type": "integer"
+                "events": {
+                    "description": "device.offline, plugin.crashed, workflow.failed, budget.exceeded 或 *",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "version": {
+                "name": {
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "签名密钥，为空时自动生成",
+                    "type": "string"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "v1.CapabilityDef": {
+        "v1.WebhookDeliveryInfo": {
             "type": "object",
             "properties": {
-                "config_schema": {
-                    "$ref": "#/definitions/v1.CapabilitySchema"
+                "attempts": {
+                    "type": "integer"
                 },
-                "description": {
+                "created_at": {
                     "type": "string"
                 },
-                "enabled": {
-                    "type": "boolean"
+                "delivered_at": {
+                    "type": "string"
+                },
+                "endpoint_id": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "description": "同一事件发往各端点及重试时相同",
+                    "type": "string"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.WebhookAttempt"
+                    }
                 },
                 "id": {
                     "type": "string"
                 },
-                "input_schema": {
-                    "$ref": "#/definitions/v1.CapabilitySchema"
+                "next_attempt_at": {
+                    "type": "string"
                 },
-                "name": {
+                "payload": {
+                    "description": "请求体 JSON",
                     "type": "string"
                 },
-                "output_schema": {
-                    "$ref": "#/definitions/v1.CapabilitySchema"
+                "status": {
+                    "type": "string"
                 },
-                "type": {
+                "status_code": {
+                    "type": "integer"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "v1.CapabilityProperty": {
+        "v1.WebhookDeliveryListResponse": {
             "type": "object",
             "properties": {
-                "default": {},
-                "description": {
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.WebhookDeliveryInfo"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/v1.Pagination"
+                }
+            }
+        },
+        "v1.WebhookInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
                     "type": "string"
                 },
                 "secret": {
-                    "type": "boolean"
+                    "type": "string"
                 },
-                "type": {
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "v1.CapabilitySchema": {
+        "v1.WebhookUpdateRequest": {
             "type": "object",
             "properties": {
-                "properties": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/v1.CapabilityProperty"
-                    }
+                "enabled": {
+                    "type": "boolean"
                 },
-                "required": {
+                "events": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "type": {
+                "name": {
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "传 ****** 表示保留原值",
+                    "type": "string"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "v1.DeviceActivationRequest": {
+        "vision.VisionAnalysisData": {
             "type": "object",
-            "required": [
-                "activation_code"
-            ],
             "properties": {
-                "activation_code": {
+                "error": {
+                    "description": "错误信息（失败时）",
                     "type": "string"
                 },
-                "configuration": {
-                    "type": "object",
-                    "additionalProperties": true
-                },
-                "device_name": {
+                "result": {
+                    "description": "分析结果（成功时）",
                     "type": "string"
                 }
             }
         },
-        "v1.DeviceActivationResponse": {
+        "workflow.ApprovalStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "approved",
+                "rejected",
+                "expired",
+                "cancelled"
+            ],
+            "x-enum-comments": {
+                "ApprovalApproved": "已批准",
+                "ApprovalCancelled": "执行取消或超时，审批作废",
+                "ApprovalExpired": "超时未审批",
+                "ApprovalPending": "等待审批",
+                "ApprovalRejected": "已拒绝"
+            },
+            "x-enum-varnames": [
+                "ApprovalPending",
+                "ApprovalApproved",
+                "ApprovalRejected",
+                "ApprovalExpired",
+                "ApprovalCancelled"
+            ]
+        },
+        "workflow.ApprovalTask": {
             "type": "object",
             "properties": {
-                "access_token": {
+                "approver": {
                     "type": "string"
                 },
-                "device_info": {
-                    "$ref": "#/definitions/v1.DeviceInfo"
-                },
-                "device_token": {
+                "comment": {
                     "type": "string"
                 },
-                "expires_in": {
-                    "type": "integer"
-                },
-                "message": {
+                "created_at": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "v1.DeviceInfo": {
-            "type": "object",
-            "properties": {
-                "configuration": {
+                "data": {
+                    "description": "节点输入，供审批人查看",
                     "type": "object",
                     "additionalProperties": true
                 },
-                "created_at": {
+                "decided_at": {
                     "type": "string"
                 },
-                "device_id": {
+                "description": {
                     "type": "string"
                 },
-                "device_name": {
+                "execution_id": {
                     "type": "string"
                 },
-                "device_type": {
+                "expires_at": {
                     "type": "string"
                 },
-                "firmware": {
-                    "$ref": "#/definitions/v1.FirmwareInfo"
-                },
                 "id": {
-                    "type": "integer"
-                },
-                "is_activated": {
-                    "type": "boolean"
+                    "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "node_id": {
+                    "type": "string"
                 },
-                "last_seen": {
+                "on_timeout": {
                     "type": "string"
                 },
-                "location": {
-                    "$ref": "#/definitions/v1.DeviceLocation"
+                "status": {
+                    "$ref": "#/definitions/workflow.ApprovalStatus"
                 },
-                "metadata": {
-                    "type": "object",
-                    "additionalProperties": true
+                "title": {
+                    "type": "string"
                 },
-                "model": {
+                "workflow_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "workflow.DryRunIssue": {
+            "type": "object",
+            "properties": {
+                "edge_id": {
                     "type": "string"
                 },
-                "status": {
-                    "description": "online, offline, error, unknown",
+                "field": {
                     "type": "string"
                 },
-                "updated_at": {
+                "message": {
                     "type": "string"
                 },
-                "version": {
+                "node_id": {
+                    "type": "string"
+                },
+                "severity": {
                     "type": "string"
                 }
             }
         },
-        "v1.DeviceListResponse": {
+        "workflow.DryRunNode": {
             "type": "object",
             "properties": {
-                "devices": {
+                "capability_id": {
+                    "type": "string"
+                },
+                "config": {
+                    "description": "合并全局配置和默认值后的配置，敏感字段已脱敏",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "issues": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/v1.DeviceInfo"
+                        "$ref": "#/definitions/workflow.DryRunIssue"
                     }
                 },
-                "pagination": {
-                    "$ref": "#/definitions/v1.Pagination"
+                "node_id": {
+                    "type": "string"
+                },
+                "provider_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/workflow.NodeType"
                 }
             }
         },
-        "v1.DeviceLocation": {
+        "workflow.DryRunReport": {
             "type": "object",
             "properties": {
-                "address": {
-                    "type": "string"
+                "issues": {
+                    "description": "全部问题，包括节点和边上的问题",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workflow.DryRunIssue"
+                    }
                 },
-                "altitude": {
-                    "type": "number"
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workflow.DryRunNode"
+                    }
                 },
-                "city": {
-                    "type": "string"
+                "order": {
+                    "description": "拓扑执行顺序",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "country": {
+                "valid": {
+                    "description": "没有 error 级别的问题",
+                    "type": "boolean"
+                },
+                "workflow_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "workflow.Edge": {
+            "type": "object",
+            "properties": {
+                "from": {
                     "type": "string"
                 },
-                "latitude": {
-                    "type": "number"
+                "id": {
+                    "type": "string"
                 },
-                "longitude": {
-                    "type": "number"
+                "label": {
+                    "type": "string"
                 },
-                "province": {
+                "to": {
                     "type": "string"
+                },
+                "weight": {
+                    "type": "integer"
                 }
             }
         },
-        "v1.DeviceRegistrationRequest": {
+        "workflow.Execution": {
             "type": "object",
-            "required": [
-                "device_id",
-                "device_name",
-                "device_type"
-            ],
             "properties": {
-                "device_id": {
+                "context": {
+                    "description": "执行上下文",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "end_time": {
                     "type": "string"
                 },
-                "device_name": {
+                "error": {
+                    "description": "执行错误",
                     "type": "string"
                 },
-                "device_type": {
+                "id": {
                     "type": "string"
                 },
-                "location": {
-                    "$ref": "#/definitions/v1.DeviceLocation"
+                "inputs": {
+                    "description": "输入参数",
+                    "type": "object",
+                    "additionalProperties": true
                 },
-                "metadata": {
+                "logs": {
+                    "description": "执行日志",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workflow.ExecutionLog"
+                    }
+                },
+                "node_results": {
+                    "description": "节点执行结果",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/workflow.NodeResult"
+                    }
+                },
+                "outputs": {
+                    "description": "输出结果",
                     "type": "object",
                     "additionalProperties": true
                 },
-                "model": {
+                "request_id": {
+                    "description": "发起执行的请求关联ID",
                     "type": "string"
                 },
-                "version": {
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/workflow.ExecutionStatus"
+                },
+                "tenant_id": {
+                    "description": "发起执行的租户",
+                    "type": "string"
+                },
+                "workflow_id": {
                     "type": "string"
                 }
             }
         },
-        "v1.DeviceStatusRequest": {
+        "workflow.ExecutionLog": {
             "type": "object",
-            "required": [
-                "device_id",
-                "is_active"
-            ],
             "properties": {
-                "device_id": {
-                    "description": "设备MAC地址",
+                "details": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "level": {
+                    "description": "info, warn, error",
                     "type": "string"
                 },
-                "is_active": {
-                    "description": "激活状态：true激活，false禁用",
-                    "type": "boolean"
+                "message": {
+                    "type": "string"
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
                 }
             }
         },
-        "v1.DeviceStatusResponse": {
+        "workflow.ExecutionStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "paused",
+                "completed",
+                "failed",
+                "cancelled"
+            ],
+            "x-enum-comments": {
+                "ExecutionStatusCancelled": "已取消",
+                "ExecutionStatusCompleted": "已完成",
+                "ExecutionStatusFailed": "执行失败",
+                "ExecutionStatusPaused": "已暂停",
+                "ExecutionStatusPending": "待执行",
+                "ExecutionStatusRunning": "执行中"
+            },
+            "x-enum-varnames": [
+                "ExecutionStatusPending",
+                "ExecutionStatusRunning",
+                "ExecutionStatusPaused",
+                "ExecutionStatusCompleted",
+                "ExecutionStatusFailed",
+                "ExecutionStatusCancelled"
+            ]
+        },
+        "workflow.InputSchema": {
             "type": "object",
             "properties": {
-                "device_info": {
-                    "$ref": "#/definitions/v1.DeviceInfo"
+                "default": {},
+                "description": {
+                    "type": "string"
                 },
-                "message": {
+                "name": {
                     "type": "string"
                 },
-                "success": {
+                "required": {
                     "type": "boolean"
+                },
+                "type": {
+                    "description": "string, number, boolean, object, array",
+                    "type": "string"
+                },
+                "validation": {
+                    "$ref": "#/definitions/workflow.Validation"
                 }
             }
         },
-        "v1.DeviceUpdateRequest": {
+        "workflow.Node": {
             "type": "object",
             "properties": {
-                "configuration": {
+                "config": {
+                    "description": "节点配置",
                     "type": "object",
                     "additionalProperties": true
                 },
-                "device_name": {
+                "description": {
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "error": {
+                    "description": "错误信息",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "inputs": {
+                    "description": "输入Schema",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workflow.InputSchema"
+                    }
+                },
+                "method": {
+                    "description": "调用的方法",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "outputs": {
+                    "description": "输出Schema",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workflow.OutputSchema"
+                    }
+                },
+                "plugin": {
+                    "description": "关联的插件ID",
+                    "type": "string"
+                },
+                "position": {
+                    "description": "画布位置",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/workflow.Position"
+                        }
+                    ]
                 },
-                "location": {
-                    "$ref": "#/definitions/v1.DeviceLocation"
+                "status": {
+                    "description": "节点状态",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/workflow.NodeStatus"
+                        }
+                    ]
                 },
-                "metadata": {
-                    "type": "object",
-                    "additionalProperties": true
+                "type": {
+                    "$ref": "#/definitions/workflow.NodeType"
                 }
             }
         },
-        "v1.FirmwareInfo": {
+        "workflow.NodeLog": {
             "type": "object",
             "properties": {
-                "checksum": {
-                    "type": "string"
+                "attempt": {
+                    "type": "integer"
                 },
-                "description": {
+                "level": {
                     "type": "string"
                 },
-                "download_count": {
-                    "type": "integer"
-                },
-                "release_date": {
+                "message": {
                     "type": "string"
                 },
-                "size": {
-                    "type": "integer"
+                "source": {
+                    "description": "engine 或 plugin",
+                    "type": "string"
                 },
-                "url": {
+                "stream": {
+                    "description": "插件输出流：stdout、stderr",
                     "type": "string"
                 },
-                "version": {
+                "timestamp": {
                     "type": "string"
                 }
             }
         },
-        "v1.Pagination": {
+        "workflow.NodeResult": {
             "type": "object",
             "properties": {
-                "has_next": {
-                    "type": "boolean"
+                "attempts": {
+                    "description": "能力调用次数，含重试",
+                    "type": "integer"
                 },
-                "has_prev": {
-                    "type": "boolean"
+                "elapsed_time": {
+                    "$ref": "#/definitions/time.Duration"
                 },
-                "limit": {
-                    "type": "integer"
+                "end_time": {
+                    "type": "string"
                 },
-                "page": {
-                    "type": "integer"
+                "error": {
+                    "type": "string"
                 },
-                "total": {
-                    "type": "integer"
+                "inputs": {
+                    "type": "object",
+                    "additionalProperties": true
                 },
-                "total_pages": {
-                    "type": "integer"
+                "logs": {
+                    "description": "节点日志，包括插件输出和重试记录",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workflow.NodeLog"
+                    }
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "outputs": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/workflow.NodeStatus"
+                },
+                "timing": {
+                    "$ref": "#/definitions/workflow.NodeTiming"
+                },
+                "traces": {
+                    "description": "能力调用的追踪信息，含提供者返回的请求ID",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/capability.Trace"
+                    }
                 }
             }
         },
-        "v1.PluginControlRequest": {
-            "type": "object",
-            "required": [
-                "action"
+        "workflow.NodeStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "completed",
+                "failed",
+                "skipped"
             ],
+            "x-enum-comments": {
+                "NodeStatusCompleted": "已完成",
+                "NodeStatusFailed": "执行失败",
+                "NodeStatusPending": "待执行",
+                "NodeStatusRunning": "执行中",
+                "NodeStatusSkipped": "已跳过"
+            },
+            "x-enum-varnames": [
+                "NodeStatusPending",
+                "NodeStatusRunning",
+                "NodeStatusCompleted",
+                "NodeStatusFailed",
+                "NodeStatusSkipped"
+            ]
+        },
+        "workflow.NodeTiming": {
+            "type": "object",
             "properties": {
-                "action": {
-                    "type": "string",
-                    "enum": [
-                        "start",
-                        "stop",
-                        "restart",
-                        "reallocate_port"
+                "execution": {
+                    "description": "能力调用，含重试",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/time.Duration"
+                        }
                     ]
                 },
-                "config": {
-                    "type": "object",
-                    "additionalProperties": true
+                "inputs": {
+                    "description": "解析输入",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/time.Duration"
+                        }
+                    ]
+                },
+                "queue": {
+                    "description": "等待执行槽",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/time.Duration"
+                        }
+                    ]
+                },
+                "retry_wait": {
+                    "description": "重试前的等待，包含在 Execution 中",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/time.Duration"
+                        }
+                    ]
+                },
+                "validation": {
+                    "description": "输出校验",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/time.Duration"
+                        }
+                    ]
                 }
             }
         },
-        "v1.PluginControlResponse": {
+        "workflow.NodeType": {
+            "type": "string",
+            "enum": [
+                "start",
+                "end",
+                "task",
+                "condition",
+                "parallel",
+                "merge",
+                "approval",
+                "transform",
+                "http"
+            ],
+            "x-enum-comments": {
+                "NodeTypeApproval": "人工审批节点",
+                "NodeTypeCondition": "条件节点",
+                "NodeTypeEnd": "结束节点",
+                "NodeTypeHTTP": "HTTP 请求节点",
+                "NodeTypeMerge": "合并节点",
+                "NodeTypeParallel": "并行节点",
+                "NodeTypeStart": "开始节点",
+                "NodeTypeTask": "任务节点",
+                "NodeTypeTransform": "数据转换节点"
+            },
+            "x-enum-varnames": [
+                "NodeTypeStart",
+                "NodeTypeEnd",
+                "NodeTypeTask",
+                "NodeTypeCondition",
+                "NodeTypeParallel",
+                "NodeTypeMerge",
+                "NodeTypeApproval",
+                "NodeTypeTransform",
+                "NodeTypeHTTP"
+            ]
+        },
+        "workflow.NodeTypeDefinition": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string"
+                "builtin": {
+                    "type": "boolean"
                 },
-                "new_port": {
-                    "type": "integer"
+                "config_schema": {
+                    "$ref": "#/definitions/capability.Schema"
                 },
-                "new_status": {
+                "description": {
                     "type": "string"
                 },
-                "old_port": {
-                    "type": "integer"
+                "input_schema": {
+                    "$ref": "#/definitions/capability.Schema"
                 },
-                "old_status": {
+                "name": {
                     "type": "string"
                 },
-                "process_time": {
+                "output_schema": {
+                    "$ref": "#/definitions/capability.Schema"
+                },
+                "source": {
+                    "description": "注册来源：capability 表示从能力注册表同步",
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "type": {
+                    "$ref": "#/definitions/workflow.NodeType"
+                },
+                "ui": {
+                    "$ref": "#/definitions/capability.UIHints"
                 }
             }
         },
-        "v1.PluginListResponse": {
+        "workflow.OutputSchema": {
             "type": "object",
             "properties": {
-                "page": {
-                    "type": "integer"
-                },
-                "page_size": {
-                    "type": "integer"
+                "description": {
+                    "type": "string"
                 },
-                "plugins": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/v1.PluginStatus"
-                    }
+                "name": {
+                    "type": "string"
                 },
-                "total": {
-                    "type": "integer"
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "workflow.Position": {
+            "type": "object",
+            "properties": {
+                "x": {
+                    "type": "number"
                 },
-                "total_pages": {
-                    "type": "integer"
+                "y": {
+                    "type": "number"
                 }
             }
         },
-        "v1.PluginStats": {
+        "workflow.SchedulerStats": {
             "type": "object",
             "properties": {
-                "by_capability": {
+                "classes": {
+                    "description": "各类别运行中的节点数",
                     "type": "object",
                     "additionalProperties": {
                         "type": "integer"
                     }
                 },
-                "by_type": {
+                "executions": {
+                    "description": "各执行运行中的节点数",
                     "type": "object",
                     "additionalProperties": {
                         "type": "integer"
                     }
                 },
-                "disabled": {
-                    "type": "integer"
-                },
-                "enabled": {
+                "max_workers": {
                     "type": "integer"
                 },
-                "error": {
+                "queued": {
                     "type": "integer"
                 },
-                "healthy": {
+                "running": {
                     "type": "integer"
+                }
+            }
+        },
+        "workflow.TemplateParameter": {
+            "type": "object",
+            "properties": {
+                "default": {},
+                "description": {
+                    "type": "string"
                 },
-                "last_updated": {
+                "label": {
                     "type": "string"
                 },
-                "running": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 },
-                "stopped": {
-                    "type": "integer"
+                "required": {
+                    "type": "boolean"
                 },
-                "total": {
-                    "type": "integer"
+                "type": {
+                    "description": "string, number, integer, boolean, array, object",
+                    "type": "string"
                 },
-                "unknown": {
-                    "type": "integer"
+                "validation": {
+                    "$ref": "#/definitions/workflow.Validation"
                 }
             }
         },
-        "v1.PluginStatus": {
+        "workflow.Validation": {
             "type": "object",
             "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "capabilities": {
+                "enum": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/v1.CapabilityDef"
+                        "type": "string"
                     }
                 },
-                "created_at": {
-                    "type": "string"
+                "max": {
+                    "type": "number"
                 },
-                "description": {
-                    "type": "string"
+                "max_length": {
+                    "type": "integer"
                 },
-                "health_status": {
-                    "type": "string"
+                "min": {
+                    "type": "number"
                 },
-                "id": {
+                "min_length": {
+                    "type": "integer"
+                },
+                "pattern": {
                     "type": "string"
+                }
+            }
+        },
+        "workflow.Workflow": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "$ref": "#/definitions/workflow.WorkflowConfig"
                 },
-                "last_health_check": {
+                "created_at": {
                     "type": "string"
                 },
-                "name": {
+                "description": {
                     "type": "string"
                 },
-                "port": {
-                    "type": "integer"
+                "edges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workflow.Edge"
+                    }
                 },
-                "status": {
+                "id": {
                     "type": "string"
                 },
-                "type": {
+                "name": {
                     "type": "string"
                 },
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workflow.Node"
+                    }
+                },
                 "updated_at": {
                     "type": "string"
                 },
@@ -1624,36 +20892,68 @@ const docTemplate = `{
                 }
             }
         },
-        "v1.PortStats": {
+        "workflow.WorkflowConfig": {
             "type": "object",
             "properties": {
-                "allocated_ports": {
-                    "type": "integer"
+                "enable_log": {
+                    "description": "启用日志",
+                    "type": "boolean"
                 },
-                "available_ports": {
+                "max_retries": {
+                    "description": "最大重试次数",
                     "type": "integer"
                 },
-                "reserved_ports": {
+                "parallel_limit": {
+                    "description": "并行执行限制",
                     "type": "integer"
                 },
-                "total_ports": {
-                    "type": "integer"
+                "timeout": {
+                    "description": "执行超时时间",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/time.Duration"
+                        }
+                    ]
                 },
-                "usage_percent": {
-                    "type": "number"
+                "variables": {
+                    "description": "全局变量",
+                    "type": "object",
+                    "additionalProperties": true
                 }
             }
         },
-        "vision.VisionAnalysisData": {
+        "workflow.WorkflowTemplate": {
             "type": "object",
             "properties": {
-                "error": {
-                    "description": "错误信息（失败时）",
+                "builtin": {
+                    "type": "boolean"
+                },
+                "category": {
                     "type": "string"
                 },
-                "result": {
-                    "description": "分析结果（成功时）",
+                "description": {
                     "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parameters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workflow.TemplateParameter"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "workflow": {
+                    "$ref": "#/definitions/workflow.Workflow"
                 }
             }
         }
@@ -0,0 +1,239 @@
+package drain
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+// ErrDraining 服务处于排空模式，拒绝新的会话或任务
+var ErrDraining = errors.New("server is draining")
+
+// 排空状态
+const (
+	StateServing  = "serving"  // 正常服务
+	StateDraining = "draining" // 排空中，等待活跃工作结束
+	StateDrained  = "drained"  // 排空完成或已到截止时间，进入关停流程
+)
+
+// 工作类型
+const (
+	KindSessions  = "sessions"  // 设备会话
+	KindWorkflows = "workflows" // 工作流执行
+	KindJobs      = "jobs"      // 后台任务
+)
+
+const (
+	defaultPollInterval = time.Second     // 排空进度检查间隔
+	defaultTimeout      = 5 * time.Minute // 未指定截止时间时的默认等待时间
+)
+
+// Status 排空进度
+type Status struct {
+	State     string         `json:"state"`
+	Reason    string         `json:"reason,omitempty"`
+	StartedAt *time.Time     `json:"started_at,omitempty"`
+	Deadline  *time.Time     `json:"deadline,omitempty"`
+	Active    map[string]int `json:"active"`    // 各类型的活跃工作数
+	TimedOut  bool           `json:"timed_out"` // 是否因到达截止时间而结束
+}
+
+// Controller 排空控制器
+// 进入排空模式后拒绝新的会话和工作流，等待活跃工作结束或到达截止时间后调用关停回调
+type Controller struct {
+	logger       *logging.Logger
+	pollInterval time.Duration
+	draining     atomic.Bool
+
+	mu        sync.Mutex
+	active    map[string]int
+	state     string
+	reason    string
+	startedAt time.Time
+	deadline  time.Time
+	timedOut  bool
+	onDrained func()
+	changed   chan struct{}
+}
+
+// NewController 创建排空控制器
+func NewController(logger *logging.Logger) *Controller {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Controller{
+		logger:       logger,
+		pollInterval: defaultPollInterval,
+		active:       make(map[string]int),
+		state:        StateServing,
+		changed:      make(chan struct{}, 1),
+	}
+}
+
+var (
+	defaultController   *Controller
+	defaultControllerMu sync.RWMutex
+)
+
+// SetDefault 设置全局排空控制器
+func SetDefault(c *Controller) {
+	defaultControllerMu.Lock()
+	defer defaultControllerMu.Unlock()
+	defaultController = c
+}
+
+// Default 返回全局排空控制器，未设置时创建一个仅记录状态的控制器
+func Default() *Controller {
+	defaultControllerMu.RLock()
+	c := defaultController
+	defaultControllerMu.RUnlock()
+	if c != nil {
+		return c
+	}
+
+	defaultControllerMu.Lock()
+	defer defaultControllerMu.Unlock()
+	if defaultController == nil {
+		defaultController = NewController(nil)
+	}
+	return defaultController
+}
+
+// OnDrained 设置排空结束后的回调，通常用于触发正常关停流程
+func (c *Controller) OnDrained(fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onDrained = fn
+}
+
+// Draining 是否已进入排空模式
+func (c *Controller) Draining() bool {
+	return c.draining.Load()
+}
+
+// Begin 登记一项活跃工作，排空模式下返回 ErrDraining；
+// 工作结束后必须调用返回的 done
+func (c *Controller) Begin(kind string) (done func(), err error) {
+	c.mu.Lock()
+	if c.draining.Load() {
+		c.mu.Unlock()
+		return nil, ErrDraining
+	}
+	c.active[kind]++
+	c.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.mu.Lock()
+			c.active[kind]--
+			c.mu.Unlock()
+			c.notify()
+		})
+	}, nil
+}
+
+// Start 进入排空模式，timeout 不大于 0 时使用默认等待时间；重复调用返回当前进度
+func (c *Controller) Start(timeout time.Duration, reason string) Status {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	c.mu.Lock()
+	if c.state != StateServing {
+		c.mu.Unlock()
+		return c.Status()
+	}
+	now := time.Now()
+	c.draining.Store(true)
+	c.state = StateDraining
+	c.reason = reason
+	c.startedAt = now
+	c.deadline = now.Add(timeout)
+	c.mu.Unlock()
+
+	c.logger.InfoTag("排空", "进入排空模式（%s），最长等待 %s", reason, timeout)
+	go c.wait()
+	return c.Status()
+}
+
+// wait 等待活跃工作结束或到达截止时间
+func (c *Controller) wait() {
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		c.mu.Lock()
+		remaining := c.totalActiveLocked()
+		deadline := c.deadline
+		c.mu.Unlock()
+
+		if remaining == 0 {
+			c.finish(false)
+			return
+		}
+		if !time.Now().Before(deadline) {
+			c.logger.WarnTag("排空", "已到截止时间，仍有 %d 项活跃工作，继续关停", remaining)
+			c.finish(true)
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-c.changed:
+		}
+	}
+}
+
+func (c *Controller) finish(timedOut bool) {
+	c.mu.Lock()
+	c.state = StateDrained
+	c.timedOut = timedOut
+	onDrained := c.onDrained
+	elapsed := time.Since(c.startedAt)
+	c.mu.Unlock()
+
+	c.logger.InfoTag("排空", "排空结束，耗时 %s", elapsed.Round(time.Millisecond))
+	if onDrained != nil {
+		onDrained()
+	}
+}
+
+// Status 返回排空进度
+func (c *Controller) Status() Status {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	status := Status{
+		State:    c.state,
+		Reason:   c.reason,
+		Active:   make(map[string]int, len(c.active)),
+		TimedOut: c.timedOut,
+	}
+	for kind, n := range c.active {
+		status.Active[kind] = n
+	}
+	if !c.startedAt.IsZero() {
+		startedAt, deadline := c.startedAt, c.deadline
+		status.StartedAt = &startedAt
+		status.Deadline = &deadline
+	}
+	return status
+}
+
+func (c *Controller) totalActiveLocked() int {
+	total := 0
+	for _, n := range c.active {
+		total += n
+	}
+	return total
+}
+
+func (c *Controller) notify() {
+	select {
+	case c.changed <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,135 @@
+package errors
+
+import (
+	"context"
+	"errors"
+)
+
+// Category 面向设备用户的错误类别，设备固件按类别决定重试、提示设置等处理方式
+type Category string
+
+const (
+	CategoryProviderDown     Category = "provider_down"     // 模型或语音服务不可用
+	CategoryQuotaExceeded    Category = "quota_exceeded"    // 调用配额或并发超限
+	CategoryUnauthorizedTool Category = "unauthorized_tool" // 工具调用被权限策略拒绝
+	CategoryAudioFormat      Category = "audio_format"      // 音频编码或参数不受支持
+	CategoryInternal         Category = "internal"          // 其它服务端错误
+)
+
+// Action 建议设备采取的处理方式
+type Action string
+
+const (
+	ActionRetry Action = "retry" // 稍后重试
+	ActionWait  Action = "wait"  // 等待配额恢复后再试，短时间内重试无效
+	ActionSetup Action = "setup" // 提示用户检查设备设置或权限
+)
+
+// UserError 可下发给设备的错误描述
+type UserError struct {
+	Category  Category `json:"category"`
+	Code      string   `json:"code"`    // 设备屏幕可显示的错误码
+	Message   string   `json:"message"` // 可直接播报的提示语
+	Action    Action   `json:"action"`
+	Retryable bool     `json:"retryable"`
+}
+
+var userErrors = map[Category]UserError{
+	CategoryProviderDown: {
+		Code:      "E101",
+		Message:   "抱歉，服务暂时不可用，请稍后再试",
+		Action:    ActionRetry,
+		Retryable: true,
+	},
+	CategoryQuotaExceeded: {
+		Code:    "E201",
+		Message: "抱歉，今天的使用额度已经用完了，请明天再试",
+		Action:  ActionWait,
+	},
+	CategoryUnauthorizedTool: {
+		Code:    "E301",
+		Message: "抱歉，我没有权限执行这个操作",
+		Action:  ActionSetup,
+	},
+	CategoryAudioFormat: {
+		Code:    "E401",
+		Message: "抱歉，设备的音频格式不受支持，请检查设备设置",
+		Action:  ActionSetup,
+	},
+	CategoryInternal: {
+		Code:      "E500",
+		Message:   "抱歉，处理您的请求时发生了错误",
+		Action:    ActionRetry,
+		Retryable: true,
+	},
+}
+
+// Categorized 能给出自身用户类别的错误，返回空字符串表示不属于任何类别
+type Categorized interface {
+	UserCategory() Category
+}
+
+type categorizedError struct {
+	category Category
+	err      error
+}
+
+func (e *categorizedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *categorizedError) Unwrap() error {
+	return e.err
+}
+
+func (e *categorizedError) UserCategory() Category {
+	return e.category
+}
+
+// WithCategory 为错误指定用户类别；错误链上已有更具体的类别时保持不变
+func WithCategory(err error, category Category) error {
+	if err == nil || CategoryOf(err) != CategoryInternal {
+		return err
+	}
+	return &categorizedError{category: category, err: err}
+}
+
+// CategoryOf 返回错误链上最先出现的用户类别，无法识别时为 CategoryInternal
+func CategoryOf(err error) Category {
+	var categorized Categorized
+	if errors.As(err, &categorized) {
+		if category := categorized.UserCategory(); category != "" {
+			return category
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return CategoryProviderDown
+	}
+	return CategoryInternal
+}
+
+// UserErrorFor 返回类别对应的提示语和错误码，未知类别按 CategoryInternal 处理
+func UserErrorFor(category Category) UserError {
+	user, ok := userErrors[category]
+	if !ok {
+		category = CategoryInternal
+		user = userErrors[category]
+	}
+	user.Category = category
+	return user
+}
+
+// Describe 把错误转换为可下发给设备的描述；错误实现 Retryable() bool 时以其为准
+func Describe(err error) UserError {
+	user := UserErrorFor(CategoryOf(err))
+	var retryable interface{ Retryable() bool }
+	if errors.As(err, &retryable) {
+		user.Retryable = retryable.Retryable()
+		if user.Retryable && user.Category == CategoryQuotaExceeded {
+			// 并发超限，短暂等待即可恢复
+			user.Message = "抱歉，现在请求的人有点多，请稍后再试"
+			user.Action = ActionRetry
+		}
+	}
+	return user
+}
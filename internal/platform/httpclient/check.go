@@ -0,0 +1,97 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"time"
+
+	"xiaozhi-server-go/internal/platform/config"
+)
+
+// CheckResult 连通性测试结果
+type CheckResult struct {
+	Provider   string `json:"provider"`
+	URL        string `json:"url"`                   // 测试的原始地址
+	Endpoint   string `json:"endpoint"`              // 按 Endpoints 改写后实际请求的地址
+	Proxy      string `json:"proxy,omitempty"`       // 经过的代理（隐藏密码），为空表示直连
+	RemoteAddr string `json:"remote_addr,omitempty"` // 建立连接的对端地址，经过代理时为代理地址
+	TLSVersion string `json:"tls_version,omitempty"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Success    bool   `json:"success"` // 收到响应即成功，不判断状态码
+	Error      string `json:"error,omitempty"`
+	LatencyMs  int64  `json:"latency_ms"`
+}
+
+// Check 按提供者的网络参数（叠加 network，见 ParseNetwork）向 target 发送 HEAD 请求，
+// 验证代理、端点改写和CA证书是否可用；使用独立的连接，不影响连接池和连接统计
+func (p *Pool) Check(ctx context.Context, provider, target string, network config.HTTPClientOptions) CheckResult {
+	result := CheckResult{Provider: provider, URL: target}
+	fail := func(err error) CheckResult {
+		result.Error = err.Error()
+		return result
+	}
+
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fail(fmt.Errorf("url must be an absolute http(s) URL"))
+	}
+
+	opts := mergeOptions(p.options(provider), network)
+
+	transport, err := newTransport(opts)
+	if err != nil {
+		return fail(err)
+	}
+	defer transport.CloseIdleConnections()
+	endpoints, err := parseEndpoints(opts.Endpoints)
+	if err != nil {
+		return fail(err)
+	}
+	rewriter := &endpointTransport{base: transport, endpoints: endpoints}
+	resolved := rewriter.resolve(u)
+	result.Endpoint = resolved.String()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, resolved.String(), nil)
+	if err != nil {
+		return fail(err)
+	}
+	proxyURL, err := transport.Proxy(req)
+	if err != nil {
+		return fail(err)
+	}
+	if proxyURL != nil {
+		result.Proxy = proxyURL.Redacted()
+	}
+
+	req = req.WithContext(httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			result.RemoteAddr = info.Conn.RemoteAddr().String()
+		},
+	}))
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   opts.Timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		return fail(err)
+	}
+	resp.Body.Close()
+
+	result.Success = true
+	result.StatusCode = resp.StatusCode
+	if resp.TLS != nil {
+		result.TLSVersion = tls.VersionName(resp.TLS.Version)
+	}
+	return result
+}
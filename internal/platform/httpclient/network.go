@@ -0,0 +1,218 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"xiaozhi-server-go/internal/platform/config"
+)
+
+// NetworkKey 能力配置中网络参数的键，值的格式见 ParseNetwork
+const NetworkKey = "network"
+
+// ParseNetwork 解析能力配置中的网络参数 {"proxy": "socks5://...", "ca_file": "...", "endpoints": {"api.openai.com": "https://..."}}，
+// 只返回 Proxy、CAFile 和 Endpoints，v 为 nil 时返回零值；代理、证书和端点在创建客户端时校验
+func ParseNetwork(v interface{}) (config.HTTPClientOptions, error) {
+	var opts config.HTTPClientOptions
+	if v == nil {
+		return opts, nil
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return opts, fmt.Errorf("%s must be an object", NetworkKey)
+	}
+	var err error
+	if opts.Proxy, err = stringField(m, "proxy"); err != nil {
+		return opts, err
+	}
+	if opts.CAFile, err = stringField(m, "ca_file"); err != nil {
+		return opts, err
+	}
+	switch endpoints := m["endpoints"].(type) {
+	case nil:
+	case map[string]interface{}:
+		opts.Endpoints = make(map[string]string, len(endpoints))
+		for host, target := range endpoints {
+			s, ok := target.(string)
+			if !ok {
+				return opts, fmt.Errorf("%s.endpoints.%s must be a string", NetworkKey, host)
+			}
+			opts.Endpoints[host] = s
+		}
+	case map[string]string:
+		opts.Endpoints = endpoints
+	default:
+		return opts, fmt.Errorf("%s.endpoints must be an object", NetworkKey)
+	}
+	return opts, nil
+}
+
+func stringField(m map[string]interface{}, key string) (string, error) {
+	switch v := m[key].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return strings.TrimSpace(v), nil
+	default:
+		return "", fmt.Errorf("%s.%s must be a string", NetworkKey, key)
+	}
+}
+
+// networkKey 区分不同网络参数的客户端，没有网络参数时为空
+func networkKey(opts config.HTTPClientOptions) string {
+	if opts.Proxy == "" && opts.CAFile == "" && len(opts.Endpoints) == 0 {
+		return ""
+	}
+	hosts := make([]string, 0, len(opts.Endpoints))
+	for host := range opts.Endpoints {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	var b strings.Builder
+	b.WriteString("#" + opts.Proxy + "#" + opts.CAFile)
+	for _, host := range hosts {
+		b.WriteString("#" + host + "=" + opts.Endpoints[host])
+	}
+	return b.String()
+}
+
+// parseProxy 解析代理地址，为空时返回 nil
+func parseProxy(proxy string) (*url.URL, error) {
+	if proxy == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy %q: %w", proxy, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("invalid proxy %q: scheme must be http, https, socks5 or socks5h", proxy)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy %q: missing host", proxy)
+	}
+	return u, nil
+}
+
+// loadRootCAs 系统证书加上 caFile 中的证书，caFile 为空时返回 nil（使用系统证书）
+func loadRootCAs(caFile string) (*x509.CertPool, error) {
+	if caFile == "" {
+		return nil, nil
+	}
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificates found in CA file %s", caFile)
+	}
+	return pool, nil
+}
+
+// parseEndpoints 解析端点改写，目标只能是 scheme://host[:port]
+func parseEndpoints(endpoints map[string]string) (map[string]*url.URL, error) {
+	if len(endpoints) == 0 {
+		return nil, nil
+	}
+	parsed := make(map[string]*url.URL, len(endpoints))
+	for host, target := range endpoints {
+		u, err := url.Parse(strings.TrimSpace(target))
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint for %s: %w", host, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || strings.Trim(u.Path, "/") != "" {
+			return nil, fmt.Errorf("invalid endpoint for %s: %q must be http(s)://host[:port] without path", host, target)
+		}
+		parsed[strings.ToLower(strings.TrimSpace(host))] = u
+	}
+	return parsed, nil
+}
+
+// endpointTransport 按主机名把请求改写到配置的端点，路径和查询参数保持不变
+type endpointTransport struct {
+	base      http.RoundTripper
+	endpoints map[string]*url.URL
+}
+
+// resolve 返回改写后的地址，没有匹配的端点时返回原地址
+func (t *endpointTransport) resolve(u *url.URL) *url.URL {
+	target, ok := t.endpoints[strings.ToLower(u.Hostname())]
+	if !ok {
+		if target, ok = t.endpoints["*"]; !ok {
+			return u
+		}
+	}
+	resolved := *u
+	resolved.Scheme = target.Scheme
+	resolved.Host = target.Host
+	return &resolved
+}
+
+func (t *endpointTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resolved := t.resolve(req.URL)
+	if resolved == req.URL {
+		return t.base.RoundTrip(req)
+	}
+	// RoundTrip 不能修改调用方的请求
+	req = req.Clone(req.Context())
+	req.URL = resolved
+	req.Host = ""
+	return t.base.RoundTrip(req)
+}
+
+// CloseIdleConnections 透传给底层 Transport
+func (t *endpointTransport) CloseIdleConnections() {
+	if c, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
+
+// failingTransport 网络参数无效时使每个请求失败，而不是绕过代理直连
+type failingTransport struct {
+	err error
+}
+
+func (t failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, t.err
+}
+
+// newRoundTripper 按参数创建带端点改写的 Transport，参数无效时返回 failingTransport
+func newRoundTripper(provider string, opts config.HTTPClientOptions) http.RoundTripper {
+	t, err := newTransport(opts)
+	if err != nil {
+		return failingTransport{err: fmt.Errorf("http client for %s: %w", provider, err)}
+	}
+	endpoints, err := parseEndpoints(opts.Endpoints)
+	if err != nil {
+		return failingTransport{err: fmt.Errorf("http client for %s: %w", provider, err)}
+	}
+	if len(endpoints) == 0 {
+		return t
+	}
+	return &endpointTransport{base: t, endpoints: endpoints}
+}
+
+// tlsConfig 使用自定义CA时的 TLS 配置，没有自定义CA时返回 nil
+func tlsConfig(caFile string) (*tls.Config, error) {
+	roots, err := loadRootCAs(caFile)
+	if err != nil || roots == nil {
+		return nil, err
+	}
+	return &tls.Config{RootCAs: roots}, nil
+}
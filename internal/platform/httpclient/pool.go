@@ -0,0 +1,310 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"xiaozhi-server-go/internal/platform/chaos"
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/observability"
+	"xiaozhi-server-go/internal/platform/requestid"
+)
+
+// DefaultOptions 默认连接参数；整体超时为 0，避免截断流式响应，由响应头超时兜底
+func DefaultOptions() config.HTTPClientOptions {
+	return config.HTTPClientOptions{
+		DialTimeout:           10 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 60 * time.Second,
+		IdleConnTimeout:       90 * time.Second,
+		MaxIdleConnsPerHost:   16,
+	}
+}
+
+// ProviderStats 单个提供者的连接复用统计
+type ProviderStats struct {
+	Provider          string  `json:"provider"`
+	Requests          int64   `json:"requests"`
+	ReusedConns       int64   `json:"reused_conns"`   // 复用已有连接的请求数
+	NewConns          int64   `json:"new_conns"`      // 新建连接的请求数
+	TLSHandshakes     int64   `json:"tls_handshakes"` // TLS 握手次数
+	HTTP2Requests     int64   `json:"http2_requests"` // 走 HTTP/2 的请求数
+	ReuseRate         float64 `json:"reuse_rate"`     // 复用率 0~1
+	AvgTLSHandshakeMs float64 `json:"avg_tls_handshake_ms"`
+}
+
+// counters 连接统计计数
+type counters struct {
+	requests      atomic.Int64
+	reused        atomic.Int64
+	created       atomic.Int64
+	tlsHandshakes atomic.Int64
+	tlsNanos      atomic.Int64
+	http2         atomic.Int64
+}
+
+// Pool 提供者共享的HTTP客户端池
+// 每个提供者持有一个长期复用的 Transport，保持 keep-alive 连接，避免每次调用重新握手
+type Pool struct {
+	cfg config.HTTPClientConfig
+
+	mu      sync.Mutex
+	clients map[string]*http.Client
+	stats   map[string]*counters
+}
+
+// NewPool 创建客户端池
+func NewPool(cfg config.HTTPClientConfig) *Pool {
+	return &Pool{
+		cfg:     cfg,
+		clients: make(map[string]*http.Client),
+		stats:   make(map[string]*counters),
+	}
+}
+
+var (
+	defaultPool   *Pool
+	defaultPoolMu sync.RWMutex
+)
+
+// SetDefault 设置全局客户端池
+func SetDefault(p *Pool) {
+	defaultPoolMu.Lock()
+	defer defaultPoolMu.Unlock()
+	defaultPool = p
+}
+
+// Default 返回全局客户端池，未设置时使用默认参数创建
+func Default() *Pool {
+	defaultPoolMu.RLock()
+	p := defaultPool
+	defaultPoolMu.RUnlock()
+	if p != nil {
+		return p
+	}
+
+	defaultPoolMu.Lock()
+	defer defaultPoolMu.Unlock()
+	if defaultPool == nil {
+		defaultPool = NewPool(config.HTTPClientConfig{})
+	}
+	return defaultPool
+}
+
+// Client 返回提供者的共享客户端，同一提供者多次调用返回同一实例
+func (p *Pool) Client(provider string) *http.Client {
+	return p.ClientFor(provider, nil)
+}
+
+// ClientFor 返回叠加能力配置中 network 参数（见 ParseNetwork）后的客户端，没有 network 时与 Client 相同；
+// 网络参数相同的调用共享同一客户端，连接统计都计入该提供者
+func (p *Pool) ClientFor(provider string, capabilityConfig map[string]interface{}) *http.Client {
+	network, err := ParseNetwork(capabilityConfig[NetworkKey])
+	if err != nil {
+		return &http.Client{Transport: failingTransport{err: fmt.Errorf("http client for %s: %w", provider, err)}}
+	}
+	key := provider + networkKey(network)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if c, ok := p.clients[key]; ok {
+		return c
+	}
+
+	opts := mergeOptions(p.options(provider), network)
+	stats, ok := p.stats[provider]
+	if !ok {
+		stats = &counters{}
+		p.stats[provider] = stats
+	}
+	c := &http.Client{
+		Timeout:   opts.Timeout,
+		Transport: chaos.Transport(provider, &tracingTransport{base: newRoundTripper(provider, opts), provider: provider, stats: stats}),
+	}
+	p.clients[key] = c
+	return c
+}
+
+// options 合并默认参数与提供者覆盖参数，零值字段沿用上一级
+func (p *Pool) options(provider string) config.HTTPClientOptions {
+	opts := mergeOptions(DefaultOptions(), p.cfg.Default)
+	if override, ok := p.cfg.Providers[provider]; ok {
+		opts = mergeOptions(opts, override)
+	}
+	return opts
+}
+
+func mergeOptions(base, override config.HTTPClientOptions) config.HTTPClientOptions {
+	if override.Proxy != "" {
+		base.Proxy = override.Proxy
+	}
+	if override.CAFile != "" {
+		base.CAFile = override.CAFile
+	}
+	if len(override.Endpoints) > 0 {
+		base.Endpoints = override.Endpoints
+	}
+	if override.Timeout > 0 {
+		base.Timeout = override.Timeout
+	}
+	if override.DialTimeout > 0 {
+		base.DialTimeout = override.DialTimeout
+	}
+	if override.TLSHandshakeTimeout > 0 {
+		base.TLSHandshakeTimeout = override.TLSHandshakeTimeout
+	}
+	if override.ResponseHeaderTimeout > 0 {
+		base.ResponseHeaderTimeout = override.ResponseHeaderTimeout
+	}
+	if override.IdleConnTimeout > 0 {
+		base.IdleConnTimeout = override.IdleConnTimeout
+	}
+	if override.MaxIdleConnsPerHost > 0 {
+		base.MaxIdleConnsPerHost = override.MaxIdleConnsPerHost
+	}
+	if override.DisableHTTP2 {
+		base.DisableHTTP2 = true
+	}
+	return base
+}
+
+// newTransport 按参数创建 Transport，代理地址或CA证书无效时返回错误
+func newTransport(opts config.HTTPClientOptions) (*http.Transport, error) {
+	proxy := http.ProxyFromEnvironment
+	proxyURL, err := parseProxy(opts.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	if proxyURL != nil {
+		proxy = http.ProxyURL(proxyURL)
+	}
+	tlsClientConfig, err := tlsConfig(opts.CAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   opts.DialTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+	t := &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           dialer.DialContext,
+		TLSClientConfig:       tlsClientConfig,
+		ForceAttemptHTTP2:     !opts.DisableHTTP2,
+		MaxIdleConns:          opts.MaxIdleConnsPerHost * 8,
+		MaxIdleConnsPerHost:   opts.MaxIdleConnsPerHost,
+		IdleConnTimeout:       opts.IdleConnTimeout,
+		TLSHandshakeTimeout:   opts.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: opts.ResponseHeaderTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+	if opts.DisableHTTP2 {
+		// 非空的 TLSNextProto 会关闭自动协商 HTTP/2
+		t.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+	return t, nil
+}
+
+// Stats 返回各提供者的连接复用统计，按提供者名称排序
+func (p *Pool) Stats() []ProviderStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	result := make([]ProviderStats, 0, len(p.stats))
+	for provider, c := range p.stats {
+		s := ProviderStats{
+			Provider:      provider,
+			Requests:      c.requests.Load(),
+			ReusedConns:   c.reused.Load(),
+			NewConns:      c.created.Load(),
+			TLSHandshakes: c.tlsHandshakes.Load(),
+			HTTP2Requests: c.http2.Load(),
+		}
+		if total := s.ReusedConns + s.NewConns; total > 0 {
+			s.ReuseRate = float64(s.ReusedConns) / float64(total)
+		}
+		if s.TLSHandshakes > 0 {
+			s.AvgTLSHandshakeMs = float64(c.tlsNanos.Load()) / float64(s.TLSHandshakes) / float64(time.Millisecond)
+		}
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Provider < result[j].Provider })
+	return result
+}
+
+// CloseIdleConnections 关闭所有空闲连接，用于服务关停
+func (p *Pool) CloseIdleConnections() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, c := range p.clients {
+		c.CloseIdleConnections()
+	}
+}
+
+// tracingTransport 通过 httptrace 统计连接复用和 TLS 握手，并记录提供者返回的请求ID
+type tracingTransport struct {
+	base     http.RoundTripper
+	provider string
+	stats    *counters
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.stats.requests.Add(1)
+
+	var tlsStart time.Time
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				t.stats.reused.Add(1)
+			} else {
+				t.stats.created.Add(1)
+			}
+			reused := 0.0
+			if info.Reused {
+				reused = 1
+			}
+			observability.RecordMetric(context.Background(), "provider_http.conn_reused", reused, map[string]string{
+				"provider": t.provider,
+			})
+		},
+		TLSHandshakeStart: func() {
+			tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.stats.tlsHandshakes.Add(1)
+			if !tlsStart.IsZero() {
+				t.stats.tlsNanos.Add(int64(time.Since(tlsStart)))
+			}
+		},
+	}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+
+	resp, err := t.base.RoundTrip(req)
+	if err == nil && resp.ProtoMajor == 2 {
+		t.stats.http2.Add(1)
+	}
+	if err == nil {
+		// 记录提供者返回的请求ID，随能力调用的追踪信息返回给调用方
+		for _, header := range requestid.UpstreamHeaders {
+			requestid.AddUpstream(req.Context(), resp.Header.Get(header))
+		}
+	}
+	return resp, err
+}
+
+// CloseIdleConnections 透传给底层 Transport，使 http.Client.CloseIdleConnections 生效
+func (t *tracingTransport) CloseIdleConnections() {
+	if c, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
@@ -0,0 +1,261 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	indexBatchSize     = 200
+	indexFlushInterval = time.Second
+	indexPruneInterval = time.Hour
+	indexDefaultBuffer = 4096
+)
+
+// Entry 写入索引的日志
+type Entry struct {
+	Time    time.Time              `json:"time"`
+	Level   string                 `json:"level"` // DEBUG、INFO、WARN、ERROR
+	Tag     string                 `json:"tag,omitempty"`
+	Message string                 `json:"message"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+}
+
+// Query 日志查询条件
+type Query struct {
+	MinLevel slog.Level // 最低级别
+	Tag      string
+	From     time.Time
+	To       time.Time
+	Text     string // 在正文和属性中查找
+	Page     int
+	PageSize int
+}
+
+// EntryStore 日志索引存储
+type EntryStore interface {
+	SaveEntries(ctx context.Context, entries []Entry) error
+	QueryEntries(ctx context.Context, query Query) ([]Entry, int64, error)
+	DeleteEntriesBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// IndexOptions 日志索引配置
+type IndexOptions struct {
+	Store         EntryStore
+	Level         slog.Level // 写入索引的最低级别
+	RetentionDays int        // 保留天数，<=0 表示永久保留
+	BufferSize    int        // 待写入队列长度，写满时丢弃新日志而不阻塞调用方
+}
+
+// ParseLevel 解析日志级别名称，无法识别时返回 INFO
+func ParseLevel(name string) slog.Level {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// indexer 在后台批量写入日志索引
+type indexer struct {
+	opts    IndexOptions
+	ch      chan Entry
+	dropped atomic.Uint64
+	done    chan struct{}
+	once    sync.Once
+	lastErr time.Time
+}
+
+func newIndexer(opts IndexOptions) *indexer {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = indexDefaultBuffer
+	}
+	ix := &indexer{
+		opts: opts,
+		ch:   make(chan Entry, opts.BufferSize),
+		done: make(chan struct{}),
+	}
+	go ix.run()
+	return ix
+}
+
+// enqueue 不阻塞地加入队列，队列满时计入丢弃数
+func (ix *indexer) enqueue(entry Entry) {
+	defer func() {
+		// 关闭之后仍有日志写入时忽略
+		_ = recover()
+	}()
+	select {
+	case ix.ch <- entry:
+	default:
+		ix.dropped.Add(1)
+	}
+}
+
+func (ix *indexer) run() {
+	defer close(ix.done)
+	flush := time.NewTicker(indexFlushInterval)
+	defer flush.Stop()
+	prune := time.NewTicker(indexPruneInterval)
+	defer prune.Stop()
+
+	ix.prune()
+	batch := make([]Entry, 0, indexBatchSize)
+	for {
+		select {
+		case entry, ok := <-ix.ch:
+			if !ok {
+				ix.save(batch)
+				return
+			}
+			batch = append(batch, entry)
+			if len(batch) >= indexBatchSize {
+				ix.save(batch)
+				batch = batch[:0]
+			}
+		case <-flush.C:
+			ix.save(batch)
+			batch = batch[:0]
+		case <-prune.C:
+			ix.prune()
+		}
+	}
+}
+
+func (ix *indexer) save(batch []Entry) {
+	if len(batch) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := ix.opts.Store.SaveEntries(ctx, batch); err != nil {
+		ix.dropped.Add(uint64(len(batch)))
+		// 写索引失败不能再写日志，否则会循环；只输出到 stderr 并限制频率
+		if time.Since(ix.lastErr) > time.Minute {
+			ix.lastErr = time.Now()
+			fmt.Fprintf(os.Stderr, "Failed to write log index: %v\n", err)
+		}
+	}
+}
+
+func (ix *indexer) prune() {
+	if ix.opts.RetentionDays <= 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	before := time.Now().AddDate(0, 0, -ix.opts.RetentionDays)
+	if _, err := ix.opts.Store.DeleteEntriesBefore(ctx, before); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to prune log index: %v\n", err)
+	}
+}
+
+// close 停止接收并写入剩余日志
+func (ix *indexer) close() {
+	ix.once.Do(func() {
+		close(ix.ch)
+		<-ix.done
+	})
+}
+
+// indexHandler 把日志记录转换为 Entry 交给 indexer
+type indexHandler struct {
+	ix     *indexer
+	level  slog.Level
+	attrs  []slog.Attr
+	groups []string
+}
+
+func (h *indexHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *indexHandler) Handle(_ context.Context, r slog.Record) error {
+	tag, message := splitTag(r.Message)
+	entry := Entry{
+		Time:    r.Time,
+		Level:   r.Level.String(),
+		Tag:     tag,
+		Message: message,
+	}
+	if len(h.attrs) > 0 || r.NumAttrs() > 0 {
+		entry.Attrs = make(map[string]interface{}, len(h.attrs)+r.NumAttrs())
+		for _, attr := range h.attrs {
+			addAttr(entry.Attrs, "", attr)
+		}
+		prefix := strings.Join(h.groups, ".")
+		r.Attrs(func(attr slog.Attr) bool {
+			addAttr(entry.Attrs, prefix, attr)
+			return true
+		})
+	}
+	h.ix.enqueue(entry)
+	return nil
+}
+
+func (h *indexHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	prefix := strings.Join(h.groups, ".")
+	clone.attrs = append([]slog.Attr(nil), h.attrs...)
+	for _, attr := range attrs {
+		if prefix != "" {
+			attr.Key = prefix + "." + attr.Key
+		}
+		clone.attrs = append(clone.attrs, attr)
+	}
+	return &clone
+}
+
+func (h *indexHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.groups = append(append([]string(nil), h.groups...), name)
+	return &clone
+}
+
+// splitTag 拆出 formatLog 添加的 [标签] 前缀
+func splitTag(message string) (string, string) {
+	if !strings.HasPrefix(message, "[") {
+		return "", message
+	}
+	end := strings.IndexByte(message, ']')
+	if end <= 1 || end > 64 {
+		return "", message
+	}
+	return message[1:end], strings.TrimSpace(message[end+1:])
+}
+
+func addAttr(dst map[string]interface{}, prefix string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	key := attr.Key
+	if prefix != "" {
+		key = prefix + "." + key
+	}
+	if attr.Value.Kind() == slog.KindGroup {
+		for _, sub := range attr.Value.Group() {
+			addAttr(dst, key, sub)
+		}
+		return
+	}
+	switch v := attr.Value.Any().(type) {
+	case error:
+		dst[key] = v.Error()
+	case fmt.Stringer:
+		dst[key] = v.String()
+	case json.Marshaler:
+		dst[key] = v
+	default:
+		dst[key] = attr.Value.Any()
+	}
+}
@@ -1,7 +1,9 @@
 package logging
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"sort"
@@ -10,9 +12,12 @@ import (
 
 // Config captures logging configuration options.
 type Config struct {
-	Level    string `yaml:"log_level" json:"log_level"`
-	Dir      string `yaml:"log_dir" json:"log_dir"`
-	Filename string `yaml:"log_file" json:"log_file"`
+	Level    string        `yaml:"log_level" json:"log_level"`
+	Dir      string        `yaml:"log_dir" json:"log_dir"`
+	Filename string        `yaml:"log_file" json:"log_file"`
+	Redactor Redactor      `yaml:"-" json:"-"` // 可选，设置后日志写出前先脱敏
+	Rotate   RotateOptions `yaml:"-" json:"-"` // 日志文件轮转和保留
+	Index    *IndexOptions `yaml:"-" json:"-"` // 可选，设置后日志同时写入可查询的索引
 }
 
 var DefaultLogger *Logger
@@ -21,50 +26,65 @@ var DefaultLogger *Logger
 type Logger struct {
 	logger *slog.Logger
 	writer *RotatableFileWriter
+	index  *indexer
+	level  *slog.LevelVar // nil for loggers not created by New
 }
 
 // New creates a new Logger instance.
 func New(cfg Config) (*Logger, error) {
 	// 1. Create RotatableFileWriter
-	writer, err := NewRotatableFileWriter(cfg.Dir, cfg.Filename)
+	writer, err := NewRotatableFileWriter(cfg.Dir, cfg.Filename, cfg.Rotate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create log writer: %w", err)
 	}
 
-	// 2. Determine Log Level
-	var level slog.Level
-	switch strings.ToLower(cfg.Level) {
-	case "debug":
-		level = slog.LevelDebug
-	case "info":
-		level = slog.LevelInfo
-	case "warn":
-		level = slog.LevelWarn
-	case "error":
-		level = slog.LevelError
-	default:
-		level = slog.LevelInfo
-	}
+	// 2. Determine Log Level, adjustable at runtime via SetLevel
+	level := ParseLevel(cfg.Level)
+	levelVar := new(slog.LevelVar)
+	levelVar.Set(level)
 
 	// 3. Create Handlers
 	jsonHandler := slog.NewJSONHandler(writer, &slog.HandlerOptions{
-		Level: level,
+		Level: levelVar,
 	})
 
 	textHandler := NewCustomTextHandler(os.Stdout, &slog.HandlerOptions{
-		Level: level,
+		Level: levelVar,
 	})
 
-	multiHandler := NewMultiHandler(jsonHandler, textHandler)
+	handlers := []slog.Handler{jsonHandler, textHandler}
+	var index *indexer
+	if cfg.Index != nil && cfg.Index.Store != nil {
+		opts := *cfg.Index
+		// 索引只收录日志本身会输出的级别
+		if opts.Level < level {
+			opts.Level = level
+		}
+		index = newIndexer(opts)
+		handlers = append(handlers, &indexHandler{ix: index, level: opts.Level})
+	}
 
-	logger := slog.New(multiHandler)
+	var handler slog.Handler = NewMultiHandler(handlers...)
+	if cfg.Redactor != nil {
+		handler = NewRedactingHandler(handler, cfg.Redactor)
+	}
+
+	logger := slog.New(handler)
 
 	return &Logger{
 		logger: logger,
 		writer: writer,
+		index:  index,
+		level:  levelVar,
 	}, nil
 }
 
+// NewDiscard returns a logger that drops everything, for callers that report
+// results themselves and must keep stdout and the log file clean.
+func NewDiscard() *Logger {
+	return &Logger{logger: slog.New(slog.DiscardHandler)}
+}
+
 // Legacy returns the logger itself for backward compatibility.
 // Deprecated: Use the Logger methods directly.
 func (l *Logger) Legacy() *Logger {
@@ -76,8 +96,94 @@ func (l *Logger) Slog() *slog.Logger {
 	return l.logger
 }
 
-// Close closes the underlying log writer.
+// Tee returns a logger that additionally writes JSON lines to w, using the same
+// level and redaction as l. The underlying log file is still owned by l.
+func (l *Logger) Tee(w io.Writer) *Logger {
+	base := l.logger.Handler()
+	var redactor Redactor
+	if rh, ok := base.(*RedactingHandler); ok {
+		base, redactor = rh.next, rh.redactor
+	}
+
+	var level slog.Leveler = slog.LevelError
+	if l.level != nil {
+		level = l.level
+	} else {
+		for _, candidate := range []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn} {
+			if base.Enabled(context.Background(), candidate) {
+				level = candidate
+				break
+			}
+		}
+	}
+
+	var handler slog.Handler = NewMultiHandler(base, slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}))
+	if redactor != nil {
+		handler = NewRedactingHandler(handler, redactor)
+	}
+	return &Logger{logger: slog.New(handler), writer: l.writer, index: l.index, level: l.level}
+}
+
+// With returns a logger that adds the given key-value pairs to every line,
+// e.g. logger.With("request_id", id) for everything logged on behalf of one request.
+func (l *Logger) With(args ...any) *Logger {
+	return &Logger{logger: l.logger.With(args...), writer: l.writer, index: l.index, level: l.level}
+}
+
+// Level returns the current minimum level: "debug", "info", "warn" or "error".
+func (l *Logger) Level() string {
+	level := slog.LevelError
+	if l.level != nil {
+		level = l.level.Level()
+	} else {
+		for _, candidate := range []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn} {
+			if l.logger.Enabled(context.Background(), candidate) {
+				level = candidate
+				break
+			}
+		}
+	}
+	switch {
+	case level <= slog.LevelDebug:
+		return "debug"
+	case level <= slog.LevelInfo:
+		return "info"
+	case level <= slog.LevelWarn:
+		return "warn"
+	default:
+		return "error"
+	}
+}
+
+// SetLevel changes the minimum level of the log file and console output at
+// runtime; loggers derived with With and Tee follow the change. The log index
+// keeps the level it was created with. It returns false for loggers not
+// created by New.
+func (l *Logger) SetLevel(name string) bool {
+	if l.level == nil {
+		return false
+	}
+	l.level.Set(ParseLevel(name))
+	return true
+}
+
+// IndexDropped returns how many entries were dropped from the log index because
+// the queue was full or the store failed.
+func (l *Logger) IndexDropped() uint64 {
+	if l.index == nil {
+		return 0
+	}
+	return l.index.dropped.Load()
+}
+
+// Close flushes the log index and closes the underlying log writer.
 func (l *Logger) Close() error {
+	if l.index != nil {
+		l.index.close()
+	}
+	if l.writer == nil {
+		return nil
+	}
 	return l.writer.Close()
 }
 
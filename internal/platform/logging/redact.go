@@ -0,0 +1,89 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Redactor 日志脱敏接口，返回脱敏后的文本以及是否发生替换
+type Redactor interface {
+	Redact(text string) (string, bool)
+}
+
+// RedactingHandler 在日志写出前对消息和字符串属性脱敏，发生替换时追加 redacted=true
+type RedactingHandler struct {
+	next     slog.Handler
+	redactor Redactor
+}
+
+// NewRedactingHandler 创建脱敏处理器
+func NewRedactingHandler(next slog.Handler, redactor Redactor) *RedactingHandler {
+	return &RedactingHandler{next: next, redactor: redactor}
+}
+
+func (h *RedactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *RedactingHandler) Handle(ctx context.Context, r slog.Record) error {
+	msg, redacted := h.redactor.Redact(r.Message)
+
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		a, changed := h.redactAttr(a)
+		redacted = redacted || changed
+		attrs = append(attrs, a)
+		return true
+	})
+	if !redacted {
+		return h.next.Handle(ctx, r)
+	}
+
+	out := slog.NewRecord(r.Time, r.Level, msg, r.PC)
+	out.AddAttrs(attrs...)
+	out.AddAttrs(slog.Bool("redacted", true))
+	return h.next.Handle(ctx, out)
+}
+
+func (h *RedactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	cleaned := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		cleaned[i], _ = h.redactAttr(a)
+	}
+	return &RedactingHandler{next: h.next.WithAttrs(cleaned), redactor: h.redactor}
+}
+
+func (h *RedactingHandler) WithGroup(name string) slog.Handler {
+	return &RedactingHandler{next: h.next.WithGroup(name), redactor: h.redactor}
+}
+
+// redactAttr 对字符串、error 及分组属性脱敏
+func (h *RedactingHandler) redactAttr(a slog.Attr) (slog.Attr, bool) {
+	v := a.Value.Resolve()
+	switch v.Kind() {
+	case slog.KindString:
+		if s, changed := h.redactor.Redact(v.String()); changed {
+			return slog.String(a.Key, s), true
+		}
+	case slog.KindGroup:
+		group := v.Group()
+		cleaned := make([]any, len(group))
+		changed := false
+		for i, ga := range group {
+			var c bool
+			ga, c = h.redactAttr(ga)
+			changed = changed || c
+			cleaned[i] = ga
+		}
+		if changed {
+			return slog.Group(a.Key, cleaned...), true
+		}
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			if s, changed := h.redactor.Redact(err.Error()); changed {
+				return slog.String(a.Key, s), true
+			}
+		}
+	}
+	return a, false
+}
@@ -1,9 +1,13 @@
 package logging
 
 import (
+	"compress/gzip"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -13,25 +17,42 @@ const (
 	LogRetentionDays = 7
 )
 
-// RotatableFileWriter 支持按日期轮转的文件写入器
+// RotateOptions 日志文件轮转配置，零值表示只按天轮转并保留 LogRetentionDays 天
+type RotateOptions struct {
+	MaxSizeMB     int  // 单个文件大小上限，超过后当天再轮转一次；<=0 表示只按天轮转
+	MaxBackups    int  // 保留的轮转文件数，<=0 表示不限
+	RetentionDays int  // 轮转文件保留天数，<=0 时使用 LogRetentionDays
+	Compress      bool // 轮转后的文件用 gzip 压缩
+}
+
+// RotatableFileWriter 支持按日期和大小轮转的文件写入器
+//
+// 轮转后的文件命名为 <文件名>-<日期>.<扩展名>，同一天内按大小再次轮转时追加序号：
+// <文件名>-<日期>.1.<扩展名>；开启压缩时再加 .gz 后缀。
 type RotatableFileWriter struct {
 	dir         string
 	filename    string
+	opts        RotateOptions
 	file        *os.File
+	size        int64
 	currentDate string
 	mu          sync.Mutex
 	stopCh      chan struct{}
 	wg          sync.WaitGroup
 }
 
-func NewRotatableFileWriter(dir, filename string) (*RotatableFileWriter, error) {
+func NewRotatableFileWriter(dir, filename string, opts RotateOptions) (*RotatableFileWriter, error) {
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return nil, fmt.Errorf("创建日志目录失败: %v", err)
 	}
+	if opts.RetentionDays <= 0 {
+		opts.RetentionDays = LogRetentionDays
+	}
 
 	w := &RotatableFileWriter{
 		dir:      dir,
 		filename: filename,
+		opts:     opts,
 		stopCh:   make(chan struct{}),
 	}
 
@@ -49,7 +70,15 @@ func (w *RotatableFileWriter) Write(p []byte) (n int, err error) {
 	if w.file == nil {
 		return 0, os.ErrClosed
 	}
-	return w.file.Write(p)
+	if max := int64(w.opts.MaxSizeMB) << 20; max > 0 && w.size > 0 && w.size+int64(len(p)) > max {
+		w.rotateLocked(w.currentDate)
+		if w.file == nil {
+			return 0, os.ErrClosed
+		}
+	}
+	n, err = w.file.Write(p)
+	w.size += int64(n)
+	return n, err
 }
 
 func (w *RotatableFileWriter) Close() error {
@@ -73,6 +102,10 @@ func (w *RotatableFileWriter) openFile() error {
 	if err != nil {
 		return fmt.Errorf("打开日志文件失败: %v", err)
 	}
+	info, err := file.Stat()
+	if err == nil {
+		w.size = info.Size()
+	}
 
 	w.file = file
 	w.currentDate = time.Now().Format("2006-01-02")
@@ -100,34 +133,34 @@ func (w *RotatableFileWriter) startRotationChecker() {
 func (w *RotatableFileWriter) checkAndRotate() {
 	today := time.Now().Format("2006-01-02")
 	w.mu.Lock()
-	shouldRotate := today != w.currentDate
-	w.mu.Unlock()
+	defer w.mu.Unlock()
 
-	if shouldRotate {
-		w.rotate(today)
-		w.cleanOldLogs()
+	if today != w.currentDate {
+		w.rotateLocked(today)
 	}
 }
 
-func (w *RotatableFileWriter) rotate(newDate string) {
-	w.mu.Lock()
-	defer w.mu.Unlock()
-
+// rotateLocked 归档当前文件并打开新文件，压缩和清理在后台进行；调用方持有 w.mu
+func (w *RotatableFileWriter) rotateLocked(newDate string) {
 	// 关闭当前文件
 	if w.file != nil {
 		w.file.Close()
 	}
 
-	// 重命名旧文件
-	logPath := filepath.Join(w.dir, w.filename)
-	baseName := strings.TrimSuffix(w.filename, filepath.Ext(w.filename))
-	ext := filepath.Ext(w.filename)
 	// 使用旧日期归档
-	archiveName := fmt.Sprintf("%s-%s%s", baseName, w.currentDate, ext)
-	archivePath := filepath.Join(w.dir, archiveName)
-
+	logPath := filepath.Join(w.dir, w.filename)
+	archivePath := w.archivePath(w.currentDate)
 	if _, err := os.Stat(logPath); err == nil {
-		os.Rename(logPath, archivePath)
+		if err := os.Rename(logPath, archivePath); err == nil {
+			w.wg.Add(1)
+			go func() {
+				defer w.wg.Done()
+				if w.opts.Compress {
+					compressFile(archivePath)
+				}
+				w.cleanOldLogs()
+			}()
+		}
 	}
 
 	// 打开新文件
@@ -140,11 +173,69 @@ func (w *RotatableFileWriter) rotate(newDate string) {
 	}
 
 	w.file = file
+	w.size = 0
 	w.currentDate = newDate
 }
 
+// archivePath 返回指定日期下一个未占用的归档文件名
+func (w *RotatableFileWriter) archivePath(date string) string {
+	baseName := strings.TrimSuffix(w.filename, filepath.Ext(w.filename))
+	ext := filepath.Ext(w.filename)
+	name := fmt.Sprintf("%s-%s%s", baseName, date, ext)
+	for seq := 1; w.archiveExists(name); seq++ {
+		name = fmt.Sprintf("%s-%s.%d%s", baseName, date, seq, ext)
+	}
+	return filepath.Join(w.dir, name)
+}
+
+func (w *RotatableFileWriter) archiveExists(name string) bool {
+	for _, candidate := range []string{name, name + ".gz"} {
+		if _, err := os.Stat(filepath.Join(w.dir, candidate)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// compressFile 压缩归档文件，成功后删除原文件
+func compressFile(path string) {
+	src, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to compress log file: %v\n", err)
+		return
+	}
+	gz := gzip.NewWriter(dst)
+	_, err = io.Copy(gz, src)
+	if closeErr := gz.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to compress log file: %v\n", err)
+		os.Remove(path + ".gz")
+		return
+	}
+	src.Close()
+	os.Remove(path)
+}
+
+// archivedLog 已轮转的日志文件
+type archivedLog struct {
+	name string
+	date time.Time
+	seq  int
+}
+
+// cleanOldLogs 删除超过保留天数的归档，并只保留最近 MaxBackups 个
 func (w *RotatableFileWriter) cleanOldLogs() {
-	cutoffDate := time.Now().AddDate(0, 0, -LogRetentionDays)
+	cutoffDate := time.Now().AddDate(0, 0, -w.opts.RetentionDays)
 	entries, err := os.ReadDir(w.dir)
 	if err != nil {
 		return
@@ -153,18 +244,46 @@ func (w *RotatableFileWriter) cleanOldLogs() {
 	baseName := strings.TrimSuffix(w.filename, filepath.Ext(w.filename))
 	ext := filepath.Ext(w.filename)
 
+	var archives []archivedLog
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
 		}
 		name := entry.Name()
-		// 匹配 pattern: baseName-YYYY-MM-DD.ext
-		if strings.HasPrefix(name, baseName+"-") && strings.HasSuffix(name, ext) {
-			datePart := strings.TrimSuffix(strings.TrimPrefix(name, baseName+"-"), ext)
-			fileDate, err := time.Parse("2006-01-02", datePart)
-			if err == nil && fileDate.Before(cutoffDate) {
-				os.Remove(filepath.Join(w.dir, name))
+		// 匹配 pattern: baseName-YYYY-MM-DD[.N].ext[.gz]
+		trimmed := strings.TrimSuffix(name, ".gz")
+		if !strings.HasPrefix(trimmed, baseName+"-") || !strings.HasSuffix(trimmed, ext) {
+			continue
+		}
+		datePart := strings.TrimSuffix(strings.TrimPrefix(trimmed, baseName+"-"), ext)
+		seq := 0
+		if dot := strings.IndexByte(datePart, '.'); dot >= 0 {
+			if seq, err = strconv.Atoi(datePart[dot+1:]); err != nil {
+				continue
 			}
+			datePart = datePart[:dot]
+		}
+		fileDate, err := time.Parse("2006-01-02", datePart)
+		if err != nil {
+			continue
+		}
+		if fileDate.Before(cutoffDate) {
+			os.Remove(filepath.Join(w.dir, name))
+			continue
+		}
+		archives = append(archives, archivedLog{name: name, date: fileDate, seq: seq})
+	}
+
+	if w.opts.MaxBackups <= 0 || len(archives) <= w.opts.MaxBackups {
+		return
+	}
+	sort.Slice(archives, func(i, j int) bool {
+		if !archives[i].date.Equal(archives[j].date) {
+			return archives[i].date.After(archives[j].date)
 		}
+		return archives[i].seq > archives[j].seq
+	})
+	for _, archive := range archives[w.opts.MaxBackups:] {
+		os.Remove(filepath.Join(w.dir, archive.name))
 	}
 }
@@ -0,0 +1,86 @@
+package redaction
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const nerTimeout = 3 * time.Second
+
+// NERDetector 调用外部实体识别服务检测人名、地址等无法用正则覆盖的敏感信息
+// 请求: POST {"text": "..."}
+// 响应: {"entities": [{"start": 0, "end": 6, "label": "PERSON"}]}，偏移为字节偏移
+type NERDetector struct {
+	name     string
+	endpoint string
+	entities map[string]bool
+	client   *http.Client
+}
+
+// NewNERDetector 创建实体识别检测器，entities 为空表示所有实体类型都脱敏
+func NewNERDetector(name, endpoint string, entities []string) *NERDetector {
+	var allowed map[string]bool
+	if len(entities) > 0 {
+		allowed = make(map[string]bool, len(entities))
+		for _, e := range entities {
+			allowed[strings.ToUpper(e)] = true
+		}
+	}
+	return &NERDetector{
+		name:     name,
+		endpoint: endpoint,
+		entities: allowed,
+		client:   &http.Client{Timeout: nerTimeout},
+	}
+}
+
+// Name 检测器名称
+func (d *NERDetector) Name() string { return d.name }
+
+// Detect 调用实体识别服务
+func (d *NERDetector) Detect(ctx context.Context, text string) ([]Span, error) {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ner service returned status %d", resp.StatusCode)
+	}
+
+	var out struct {
+		Entities []struct {
+			Start int    `json:"start"`
+			End   int    `json:"end"`
+			Label string `json:"label"`
+		} `json:"entities"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decode ner response: %w", err)
+	}
+
+	spans := make([]Span, 0, len(out.Entities))
+	for _, e := range out.Entities {
+		label := strings.ToUpper(e.Label)
+		if d.entities != nil && !d.entities[label] {
+			continue
+		}
+		spans = append(spans, Span{Start: e.Start, End: e.End, Label: label})
+	}
+	return spans, nil
+}
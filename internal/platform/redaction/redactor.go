@@ -0,0 +1,173 @@
+package redaction
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"xiaozhi-server-go/internal/platform/config"
+)
+
+// Span 检测到的敏感片段，Start/End 为字节偏移
+type Span struct {
+	Start int
+	End   int
+	Label string
+}
+
+// Detector 敏感信息检测器
+type Detector interface {
+	Name() string
+	Detect(ctx context.Context, text string) ([]Span, error)
+}
+
+// RegexDetector 基于正则表达式的检测器
+type RegexDetector struct {
+	name  string
+	label string
+	re    *regexp.Regexp
+}
+
+// NewRegexDetector 创建正则检测器
+func NewRegexDetector(name, label, pattern string) (*RegexDetector, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redaction pattern for %s: %w", name, err)
+	}
+	return &RegexDetector{name: name, label: label, re: re}, nil
+}
+
+// Name 检测器名称
+func (d *RegexDetector) Name() string { return d.name }
+
+// Detect 查找所有匹配片段
+func (d *RegexDetector) Detect(_ context.Context, text string) ([]Span, error) {
+	locs := d.re.FindAllStringIndex(text, -1)
+	spans := make([]Span, 0, len(locs))
+	for _, loc := range locs {
+		spans = append(spans, Span{Start: loc[0], End: loc[1], Label: d.label})
+	}
+	return spans, nil
+}
+
+// Redactor 组合多个检测器执行脱敏
+// fast 检测器（正则）可在日志热路径上同步执行，slow 检测器（NER）仅在 RedactAll 中执行
+type Redactor struct {
+	fast []Detector
+	slow []Detector
+}
+
+// New 创建脱敏器
+func New() *Redactor {
+	return &Redactor{}
+}
+
+// NewFromConfig 根据配置创建脱敏器，未启用时返回 nil
+func NewFromConfig(cfg config.RedactionConfig) (*Redactor, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	r := New()
+	for _, d := range cfg.Detectors {
+		label := d.Label
+		if label == "" {
+			label = strings.ToUpper(d.Name)
+		}
+		switch d.Type {
+		case "regex", "":
+			detector, err := NewRegexDetector(d.Name, label, d.Pattern)
+			if err != nil {
+				return nil, err
+			}
+			r.AddFast(detector)
+		case "ner":
+			if d.Endpoint == "" {
+				return nil, fmt.Errorf("redaction detector %s: ner endpoint is required", d.Name)
+			}
+			r.AddSlow(NewNERDetector(d.Name, d.Endpoint, d.Entities))
+		default:
+			return nil, fmt.Errorf("redaction detector %s: unknown type %q", d.Name, d.Type)
+		}
+	}
+	return r, nil
+}
+
+// AddFast 添加可同步执行的检测器
+func (r *Redactor) AddFast(d Detector) {
+	r.fast = append(r.fast, d)
+}
+
+// AddSlow 添加开销较大的检测器
+func (r *Redactor) AddSlow(d Detector) {
+	r.slow = append(r.slow, d)
+}
+
+// Redact 仅使用快速检测器脱敏，返回脱敏后的文本以及是否发生替换
+func (r *Redactor) Redact(text string) (string, bool) {
+	if r == nil || text == "" {
+		return text, false
+	}
+	out, changed, _ := apply(context.Background(), text, r.fast)
+	return out, changed
+}
+
+// RedactAll 使用全部检测器脱敏；慢速检测器失败时仍返回快速检测器的结果和错误
+func (r *Redactor) RedactAll(ctx context.Context, text string) (string, bool, error) {
+	if r == nil || text == "" {
+		return text, false, nil
+	}
+	detectors := make([]Detector, 0, len(r.fast)+len(r.slow))
+	detectors = append(detectors, r.fast...)
+	detectors = append(detectors, r.slow...)
+	return apply(ctx, text, detectors)
+}
+
+// apply 汇总各检测器的片段，合并重叠区间后替换为 [LABEL]
+func apply(ctx context.Context, text string, detectors []Detector) (string, bool, error) {
+	var spans []Span
+	var firstErr error
+	for _, d := range detectors {
+		found, err := d.Detect(ctx, text)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("detector %s: %w", d.Name(), err)
+			}
+			continue
+		}
+		for _, s := range found {
+			if s.Start >= 0 && s.End <= len(text) && s.Start < s.End {
+				spans = append(spans, s)
+			}
+		}
+	}
+	if len(spans) == 0 {
+		return text, false, firstErr
+	}
+
+	sort.Slice(spans, func(i, j int) bool {
+		if spans[i].Start == spans[j].Start {
+			return spans[i].End > spans[j].End
+		}
+		return spans[i].Start < spans[j].Start
+	})
+
+	var b strings.Builder
+	b.Grow(len(text))
+	pos := 0
+	for _, s := range spans {
+		if s.Start < pos {
+			// 与上一个片段重叠，扩展已替换的区间
+			if s.End > pos {
+				pos = s.End
+			}
+			continue
+		}
+		b.WriteString(text[pos:s.Start])
+		b.WriteString("[" + s.Label + "]")
+		pos = s.End
+	}
+	b.WriteString(text[pos:])
+	return b.String(), true, firstErr
+}
@@ -0,0 +1,80 @@
+package requestid
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// DialOptions 客户端拦截器，把上下文中的关联ID写入每次调用的 metadata
+func DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(unaryClientInterceptor),
+		grpc.WithChainStreamInterceptor(streamClientInterceptor),
+	}
+}
+
+func outgoing(ctx context.Context) context.Context {
+	id := FromContext(ctx)
+	if id == "" {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(MetadataKey)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, id)
+}
+
+func unaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(outgoing(ctx), method, req, reply, cc, opts...)
+}
+
+func streamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(outgoing(ctx), desc, cc, method, opts...)
+}
+
+// incoming 读取 metadata 中的关联ID并放入上下文
+func incoming(ctx context.Context, generate bool) context.Context {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(MetadataKey); len(values) > 0 && Valid(values[0]) {
+			id = values[0]
+		}
+	}
+	if id == "" {
+		if !generate {
+			return ctx
+		}
+		id = New()
+	}
+	if generate {
+		_ = grpc.SetHeader(ctx, metadata.Pairs(MetadataKey, id))
+	}
+	return WithID(ctx, id)
+}
+
+// UnaryServerInterceptor 把 metadata 中的关联ID放入处理函数的上下文。
+// generate 为 true 时缺少或不合规的ID会重新生成，并通过响应头 x-request-id 返回给调用方
+func UnaryServerInterceptor(generate bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(incoming(ctx, generate), req)
+	}
+}
+
+// StreamServerInterceptor 流式调用的 UnaryServerInterceptor
+func StreamServerInterceptor(generate bool) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: incoming(ss.Context(), generate)})
+	}
+}
+
+// serverStream 替换流的上下文
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
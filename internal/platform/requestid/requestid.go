@@ -0,0 +1,70 @@
+// Package requestid 为每个 HTTP 请求和 WebSocket 连接生成关联ID，并沿上下文、
+// gRPC metadata 传递到插件调用和工作流执行，日志和响应中用同一个ID串联整条调用链
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+const (
+	// Header 请求和响应中携带关联ID的HTTP头
+	Header = "X-Request-ID"
+	// MetadataKey gRPC metadata 中的关联ID键
+	MetadataKey = "x-request-id"
+
+	maxLength = 128
+)
+
+type contextKey struct{}
+
+// New 生成新的关联ID
+func New() string {
+	bytes := make([]byte, 8)
+	_, _ = rand.Read(bytes)
+	return "req_" + hex.EncodeToString(bytes)
+}
+
+// Valid 判断调用方传入的关联ID是否可以沿用：非空、不超过 128 个字符，且只含字母、数字和 -_.:
+// 不合规的ID不写入日志和响应，避免日志注入
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-' || c == '_' || c == '.' || c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Ensure 沿用合规的ID，否则生成新ID
+func Ensure(id string) string {
+	if Valid(id) {
+		return id
+	}
+	return New()
+}
+
+// WithID 返回携带关联ID的上下文，id 为空时原样返回
+func WithID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext 返回上下文中的关联ID，没有时返回空字符串
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
@@ -0,0 +1,75 @@
+package requestid
+
+import (
+	"context"
+	"sync"
+)
+
+// UpstreamHeaders 提供者响应中携带其请求ID的HTTP头，如 OpenAI 的 x-request-id、火山引擎的 X-Tt-Logid
+var UpstreamHeaders = []string{
+	"X-Request-Id",
+	"Request-Id",
+	"X-Tt-Logid",
+	"Apim-Request-Id",
+	"X-Amzn-Requestid",
+	"X-Acs-Request-Id",
+}
+
+type upstreamKey struct{}
+
+// Upstream 收集一次调用中提供者返回的请求ID，用于与提供者侧的日志对照
+type Upstream struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+// WithUpstream 返回收集提供者请求ID的上下文，上下文中已有收集器时两者都会收到
+func WithUpstream(ctx context.Context) (context.Context, *Upstream) {
+	u := &Upstream{}
+	return context.WithValue(ctx, upstreamKey{}, &upstreamNode{upstream: u, parent: upstreamFrom(ctx)}), u
+}
+
+// upstreamNode 嵌套的收集器链，内层收集到的ID同时记入外层
+type upstreamNode struct {
+	upstream *Upstream
+	parent   *upstreamNode
+}
+
+func upstreamFrom(ctx context.Context) *upstreamNode {
+	if ctx == nil {
+		return nil
+	}
+	node, _ := ctx.Value(upstreamKey{}).(*upstreamNode)
+	return node
+}
+
+// AddUpstream 记录提供者返回的请求ID，上下文中没有收集器或ID不合规时忽略
+func AddUpstream(ctx context.Context, id string) {
+	if !Valid(id) {
+		return
+	}
+	for node := upstreamFrom(ctx); node != nil; node = node.parent {
+		node.upstream.add(id)
+	}
+}
+
+func (u *Upstream) add(id string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	for _, existing := range u.ids {
+		if existing == id {
+			return
+		}
+	}
+	u.ids = append(u.ids, id)
+}
+
+// IDs 返回按收到顺序排列的请求ID
+func (u *Upstream) IDs() []string {
+	if u == nil {
+		return nil
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return append([]string(nil), u.ids...)
+}
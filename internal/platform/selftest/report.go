@@ -0,0 +1,93 @@
+package selftest
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Status 单项检查结果
+type Status string
+
+const (
+	StatusPass Status = "pass" // 检查通过
+	StatusWarn Status = "warn" // 可以启动，但功能可能受影响
+	StatusFail Status = "fail" // 服务无法正常启动
+	StatusSkip Status = "skip" // 依赖的检查未通过或当前模式下不适用
+)
+
+// Result 一项检查的结果
+type Result struct {
+	Step       string `json:"step"` // 所属初始化步骤ID
+	Name       string `json:"name"`
+	Status     Status `json:"status"`
+	Detail     string `json:"detail,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// Report 自检报告
+type Report struct {
+	StartedAt  time.Time `json:"started_at"`
+	DurationMs int64     `json:"duration_ms"`
+	Passed     bool      `json:"passed"` // 没有失败项
+	Results    []Result  `json:"results"`
+}
+
+// NewReport 创建空报告，完成后调用 Finish
+func NewReport() *Report {
+	return &Report{StartedAt: time.Now(), Passed: true}
+}
+
+// Add 追加一项结果，失败项使整个报告不通过
+func (r *Report) Add(result Result) {
+	if result.Status == StatusFail {
+		r.Passed = false
+	}
+	r.Results = append(r.Results, result)
+}
+
+// Finish 记录总耗时
+func (r *Report) Finish() {
+	r.DurationMs = time.Since(r.StartedAt).Milliseconds()
+}
+
+// Count 返回指定状态的结果数
+func (r *Report) Count(status Status) int {
+	count := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// WriteText 以便于阅读的文本格式输出报告，每项一行，最后是汇总
+func (r *Report) WriteText(w io.Writer) error {
+	var b strings.Builder
+	step := ""
+	for _, result := range r.Results {
+		if result.Step != step {
+			step = result.Step
+			fmt.Fprintf(&b, "%s\n", step)
+		}
+		fmt.Fprintf(&b, "  [%s] %s", strings.ToUpper(string(result.Status)), result.Name)
+		if result.Detail != "" {
+			fmt.Fprintf(&b, ": %s", result.Detail)
+		}
+		if result.DurationMs > 0 {
+			fmt.Fprintf(&b, " (%dms)", result.DurationMs)
+		}
+		b.WriteString("\n")
+	}
+
+	verdict := "通过"
+	if !r.Passed {
+		verdict = "未通过"
+	}
+	fmt.Fprintf(&b, "\n自检%s：%d 项通过，%d 项警告，%d 项失败，%d 项跳过，耗时 %dms\n",
+		verdict, r.Count(StatusPass), r.Count(StatusWarn), r.Count(StatusFail), r.Count(StatusSkip), r.DurationMs)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
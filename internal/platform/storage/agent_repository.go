@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/agent"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// AgentDefinition 编排智能体存储模型，与设备使用的 Agent 角色配置无关
+type AgentDefinition struct {
+	ID          string `gorm:"type:varchar(64);primaryKey"`
+	Name        string `gorm:"type:varchar(64);uniqueIndex;not null"`
+	Description string `gorm:"type:text"`
+	Prompt      string `gorm:"type:text"`
+	Model       string `gorm:"type:varchar(128)"`
+	Tools       string `gorm:"type:text"` // JSON 数组
+	Delegates   string `gorm:"type:text"` // JSON 数组
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TableName 指定表名
+func (AgentDefinition) TableName() string {
+	return "agent_definitions"
+}
+
+// agentRepository 智能体仓库实现
+type agentRepository struct {
+	db *gorm.DB
+}
+
+// NewAgentRepository 创建智能体仓库实例
+func NewAgentRepository(db *gorm.DB) agent.Repository {
+	return &agentRepository{
+		db: db,
+	}
+}
+
+// Save 新建智能体
+func (r *agentRepository) Save(ctx context.Context, a *agent.Agent) error {
+	model, err := r.toModel(a)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "agent.save", "failed to save agent", err)
+	}
+	return nil
+}
+
+// Update 更新智能体
+func (r *agentRepository) Update(ctx context.Context, a *agent.Agent) error {
+	model, err := r.toModel(a)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "agent.update", "failed to update agent", err)
+	}
+	return nil
+}
+
+// FindByID 根据ID查找智能体
+func (r *agentRepository) FindByID(ctx context.Context, id string) (*agent.Agent, error) {
+	return r.findOne(ctx, "id = ?", id)
+}
+
+// FindByName 根据名称查找智能体
+func (r *agentRepository) FindByName(ctx context.Context, name string) (*agent.Agent, error) {
+	return r.findOne(ctx, "name = ?", name)
+}
+
+func (r *agentRepository) findOne(ctx context.Context, query string, arg interface{}) (*agent.Agent, error) {
+	var model AgentDefinition
+	if err := r.db.WithContext(ctx).Where(query, arg).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "agent.find", "failed to find agent", err)
+	}
+	return r.fromModel(&model), nil
+}
+
+// List 查询全部智能体
+func (r *agentRepository) List(ctx context.Context) ([]*agent.Agent, error) {
+	var models []AgentDefinition
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "agent.list", "failed to list agents", err)
+	}
+
+	items := make([]*agent.Agent, len(models))
+	for i := range models {
+		items[i] = r.fromModel(&models[i])
+	}
+	return items, nil
+}
+
+// Delete 删除智能体
+func (r *agentRepository) Delete(ctx context.Context, id string) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&AgentDefinition{}).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "agent.delete", "failed to delete agent", err)
+	}
+	return nil
+}
+
+// toModel 将领域对象转换为存储模型
+func (r *agentRepository) toModel(a *agent.Agent) (*AgentDefinition, error) {
+	tools, err := json.Marshal(a.Tools)
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "agent.encode", "failed to encode agent tools", err)
+	}
+	delegates, err := json.Marshal(a.Delegates)
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "agent.encode", "failed to encode agent delegates", err)
+	}
+	return &AgentDefinition{
+		ID:          a.ID,
+		Name:        a.Name,
+		Description: a.Description,
+		Prompt:      a.Prompt,
+		Model:       a.Model,
+		Tools:       string(tools),
+		Delegates:   string(delegates),
+		CreatedAt:   a.CreatedAt,
+		UpdatedAt:   a.UpdatedAt,
+	}, nil
+}
+
+// fromModel 将存储模型转换为领域对象
+func (r *agentRepository) fromModel(model *AgentDefinition) *agent.Agent {
+	a := &agent.Agent{
+		ID:          model.ID,
+		Name:        model.Name,
+		Description: model.Description,
+		Prompt:      model.Prompt,
+		Model:       model.Model,
+		Tools:       []string{},
+		Delegates:   []string{},
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+	}
+	if model.Tools != "" {
+		json.Unmarshal([]byte(model.Tools), &a.Tools)
+	}
+	if model.Delegates != "" {
+		json.Unmarshal([]byte(model.Delegates), &a.Delegates)
+	}
+	return a
+}
@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"xiaozhi-server-go/internal/domain/analytics"
+	"xiaozhi-server-go/internal/domain/budget"
+	"xiaozhi-server-go/internal/domain/transcript"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// AnalyticsRollup 用量汇总存储模型
+type AnalyticsRollup struct {
+	Period     string `gorm:"type:varchar(16);primaryKey"`
+	Start      string `gorm:"column:start_day;type:varchar(10);primaryKey;index"` // 周期第一天 YYYY-MM-DD
+	Data       string `gorm:"type:text"`                                          // analytics.Rollup 的 JSON
+	ComputedAt time.Time
+}
+
+// TableName 指定表名
+func (AnalyticsRollup) TableName() string {
+	return "analytics_rollups"
+}
+
+// AnalyticsIntentCount 每日意图路由次数存储模型
+type AnalyticsIntentCount struct {
+	Day     string `gorm:"type:varchar(10);primaryKey;index"` // YYYY-MM-DD
+	Intent  string `gorm:"type:varchar(128);primaryKey"`
+	Count   int64
+	Handled int64
+	Failed  int64
+}
+
+// TableName 指定表名
+func (AnalyticsIntentCount) TableName() string {
+	return "analytics_intent_counts"
+}
+
+// analyticsScanBatch 汇总时每批读取的对话记录条数
+const analyticsScanBatch = 500
+
+// analyticsRepository 用量统计仓库实现
+type analyticsRepository struct {
+	db          *gorm.DB
+	transcripts *transcriptRepository
+}
+
+// NewAnalyticsRepository 创建用量统计仓库实例
+func NewAnalyticsRepository(db *gorm.DB) analytics.Repository {
+	return &analyticsRepository{
+		db:          db,
+		transcripts: &transcriptRepository{db: db},
+	}
+}
+
+// ScanEntries 按时间正序分批读取对话记录
+func (r *analyticsRepository) ScanEntries(ctx context.Context, from, to time.Time, fn func([]*transcript.Entry) error) error {
+	var models []TranscriptEntry
+	err := r.db.WithContext(ctx).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Order("created_at ASC").
+		FindInBatches(&models, analyticsScanBatch, func(tx *gorm.DB, batch int) error {
+			entries := make([]*transcript.Entry, len(models))
+			for i := range models {
+				entries[i] = r.transcripts.fromModel(&models[i])
+			}
+			return fn(entries)
+		}).Error
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "analytics.scan_entries", "failed to scan transcript entries", err)
+	}
+	return nil
+}
+
+// ListTurnLatencies 查询时间范围内的轮次耗时
+func (r *analyticsRepository) ListTurnLatencies(ctx context.Context, from, to time.Time) ([]*transcript.TurnLatency, error) {
+	var models []TranscriptTurnLatency
+	err := r.db.WithContext(ctx).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Find(&models).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "analytics.list_latencies", "failed to list turn latencies", err)
+	}
+	return r.transcripts.fromLatencyModels(models), nil
+}
+
+// ListTokenUsage 查询某一天各设备的Token用量
+func (r *analyticsRepository) ListTokenUsage(ctx context.Context, day string) ([]*budget.Usage, error) {
+	var models []DeviceTokenUsage
+	if err := r.db.WithContext(ctx).Where("day = ?", day).Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "analytics.list_token_usage", "failed to list device token usage", err)
+	}
+	items := make([]*budget.Usage, 0, len(models))
+	for _, m := range models {
+		items = append(items, &budget.Usage{DeviceID: m.DeviceID, Day: m.Day, Tokens: m.Tokens, Calls: m.Calls, Rejected: m.Rejected})
+	}
+	return items, nil
+}
+
+// AddIntentCounts 累加某一天的意图路由次数
+func (r *analyticsRepository) AddIntentCounts(ctx context.Context, day string, stats []*analytics.IntentStat) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, stat := range stats {
+			row := &AnalyticsIntentCount{Day: day, Intent: stat.Intent, Count: stat.Count, Handled: stat.Handled, Failed: stat.Failed}
+			err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "day"}, {Name: "intent"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"count":   gorm.Expr("analytics_intent_counts.count + ?", stat.Count),
+					"handled": gorm.Expr("analytics_intent_counts.handled + ?", stat.Handled),
+					"failed":  gorm.Expr("analytics_intent_counts.failed + ?", stat.Failed),
+				}),
+			}).Create(row).Error
+			if err != nil {
+				return errors.Wrap(errors.KindStorage, "analytics.add_intent_counts", "failed to record intent counts", err)
+			}
+		}
+		return nil
+	})
+}
+
+// ListIntentCounts 查询某一天的意图路由次数
+func (r *analyticsRepository) ListIntentCounts(ctx context.Context, day string) ([]*analytics.IntentStat, error) {
+	var models []AnalyticsIntentCount
+	if err := r.db.WithContext(ctx).Where("day = ?", day).Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "analytics.list_intent_counts", "failed to list intent counts", err)
+	}
+	items := make([]*analytics.IntentStat, 0, len(models))
+	for _, m := range models {
+		items = append(items, &analytics.IntentStat{Intent: m.Intent, Count: m.Count, Handled: m.Handled, Failed: m.Failed})
+	}
+	return items, nil
+}
+
+// SaveRollup 保存汇总，覆盖同一周期和起始日的旧结果
+func (r *analyticsRepository) SaveRollup(ctx context.Context, rollup *analytics.Rollup) error {
+	data, err := json.Marshal(rollup)
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "analytics.save_rollup", "failed to encode rollup", err)
+	}
+	row := &AnalyticsRollup{Period: rollup.Period, Start: rollup.Start, Data: string(data), ComputedAt: rollup.ComputedAt}
+	err = r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "period"}, {Name: "start_day"}},
+		DoUpdates: clause.AssignmentColumns([]string{"data", "computed_at"}),
+	}).Create(row).Error
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "analytics.save_rollup", "failed to save rollup", err)
+	}
+	return nil
+}
+
+// ListRollups 查询起始日在范围内的汇总，按起始日正序
+func (r *analyticsRepository) ListRollups(ctx context.Context, period, from, to string) ([]*analytics.Rollup, error) {
+	var models []AnalyticsRollup
+	err := r.db.WithContext(ctx).
+		Where("period = ? AND start_day >= ? AND start_day <= ?", period, from, to).
+		Order("start_day ASC").Find(&models).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "analytics.list_rollups", "failed to list rollups", err)
+	}
+	items := make([]*analytics.Rollup, 0, len(models))
+	for _, m := range models {
+		var rollup analytics.Rollup
+		if err := json.Unmarshal([]byte(m.Data), &rollup); err != nil {
+			return nil, errors.Wrap(errors.KindStorage, "analytics.list_rollups", "failed to decode rollup", err)
+		}
+		items = append(items, &rollup)
+	}
+	return items, nil
+}
+
+// DeleteBefore 删除起始日早于 day 的汇总和意图路由次数
+func (r *analyticsRepository) DeleteBefore(ctx context.Context, day string) (int64, error) {
+	var removed int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("start_day < ?", day).Delete(&AnalyticsRollup{})
+		if result.Error != nil {
+			return result.Error
+		}
+		removed = result.RowsAffected
+		return tx.Where("day < ?", day).Delete(&AnalyticsIntentCount{}).Error
+	})
+	if err != nil {
+		return 0, errors.Wrap(errors.KindStorage, "analytics.delete_before", "failed to delete expired rollups", err)
+	}
+	return removed, nil
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"xiaozhi-server-go/internal/domain/budget"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// DeviceTokenUsage 设备每日Token用量存储模型
+type DeviceTokenUsage struct {
+	DeviceID string `gorm:"type:varchar(128);primaryKey"`
+	Day      string `gorm:"type:varchar(10);primaryKey;index"` // YYYY-MM-DD
+	Tokens   int64
+	Calls    int64
+	Rejected int64
+}
+
+// TableName 指定表名
+func (DeviceTokenUsage) TableName() string {
+	return "device_token_usage"
+}
+
+// budgetRepository 设备Token用量仓库实现
+type budgetRepository struct {
+	db *gorm.DB
+}
+
+// NewBudgetRepository 创建设备Token用量仓库实例
+func NewBudgetRepository(db *gorm.DB) budget.Repository {
+	return &budgetRepository{
+		db: db,
+	}
+}
+
+// AddUsage 累加设备每日用量
+func (r *budgetRepository) AddUsage(ctx context.Context, deviceID, day string, tokens, calls, rejected int64) error {
+	row := &DeviceTokenUsage{DeviceID: deviceID, Day: day, Tokens: tokens, Calls: calls, Rejected: rejected}
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "device_id"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"tokens":   gorm.Expr("device_token_usage.tokens + ?", tokens),
+			"calls":    gorm.Expr("device_token_usage.calls + ?", calls),
+			"rejected": gorm.Expr("device_token_usage.rejected + ?", rejected),
+		}),
+	}).Create(row).Error
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "budget.add_usage", "failed to record device token usage", err)
+	}
+	return nil
+}
+
+// GetUsage 查询设备每日用量
+func (r *budgetRepository) GetUsage(ctx context.Context, deviceID, day string) (*budget.Usage, error) {
+	var model DeviceTokenUsage
+	err := r.db.WithContext(ctx).Where("device_id = ? AND day = ?", deviceID, day).First(&model).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, errors.Wrap(errors.KindStorage, "budget.get_usage", "failed to get device token usage", err)
+	}
+	return &budget.Usage{DeviceID: deviceID, Day: day, Tokens: model.Tokens, Calls: model.Calls, Rejected: model.Rejected}, nil
+}
+
+// ListUsage 列出某一天的设备用量，按 Token 数从多到少排序
+func (r *budgetRepository) ListUsage(ctx context.Context, day string, limit int) ([]*budget.Usage, error) {
+	var models []DeviceTokenUsage
+	err := r.db.WithContext(ctx).Where("day = ?", day).
+		Order("tokens DESC").Order("device_id ASC").Limit(limit).Find(&models).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "budget.list_usage", "failed to list device token usage", err)
+	}
+	items := make([]*budget.Usage, 0, len(models))
+	for _, m := range models {
+		items = append(items, &budget.Usage{DeviceID: m.DeviceID, Day: m.Day, Tokens: m.Tokens, Calls: m.Calls, Rejected: m.Rejected})
+	}
+	return items, nil
+}
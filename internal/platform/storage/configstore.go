@@ -14,7 +14,6 @@ import (
 	"gorm.io/datatypes"
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
-	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
@@ -41,12 +40,32 @@ func InitDatabaseWithConfig(config DatabaseConnection) error {
 // ConnectDatabaseWithConfig connects to an existing database using the provided configuration
 // This function only connects to an existing database without reinitializing tables
 func ConnectDatabaseWithConfig(config DatabaseConnection) error {
+	gormDB, err := OpenDatabase(config)
+	if err != nil {
+		return err
+	}
+
+	// Set global database instance only after successful validation
+	SetDB(gormDB)
+
+	// Auto-migrate tables to ensure schema is up to date
+	// This is safe as AutoMigrate only adds missing tables/columns and doesn't delete data
+	if err := migrateSchema(gormDB); err != nil {
+		return fmt.Errorf("failed to migrate database schema: %w", err)
+	}
+
+	// fmt.Printf("数据库已成功连接\n")
+	return nil
+}
+
+// OpenDatabase 打开已有数据库并验证连接可用，不设置全局实例也不迁移表结构
+func OpenDatabase(config DatabaseConnection) (*gorm.DB, error) {
 	dbPath := config.Path
 
 	// For SQLite, ensure the database file exists
 	if config.Type == "sqlite" {
 		if _, err := os.Stat(dbPath); os.IsNotExist(err) {
-			return fmt.Errorf("database file does not exist: %s", dbPath)
+			return nil, fmt.Errorf("database file does not exist: %s", dbPath)
 		}
 	}
 
@@ -55,9 +74,7 @@ func ConnectDatabaseWithConfig(config DatabaseConnection) error {
 
 	switch config.Type {
 	case "sqlite":
-		gormDB, err = gorm.Open(sqlite.Open(dbPath), &gorm.Config{
-			Logger: logger.Default.LogMode(logger.Silent),
-		})
+		gormDB, err = openSQLite(dbPath, config.SQLite, nil)
 	case "mysql":
 		dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=%s&parseTime=True&loc=Local",
 			config.Username, config.Password, config.Host, config.Port, config.Database, config.Charset)
@@ -71,46 +88,38 @@ func ConnectDatabaseWithConfig(config DatabaseConnection) error {
 			Logger: logger.Default.LogMode(logger.Silent),
 		})
 	default:
-		return fmt.Errorf("unsupported database type: %s", config.Type)
+		return nil, fmt.Errorf("unsupported database type: %s", config.Type)
 	}
 
 	if err != nil {
-		return fmt.Errorf("failed to connect to database: %w", err)
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	// SQLite 在 openSQLite 中已挂载统计，其他数据库只统计耗时不串行化写入
+	if config.Type != "sqlite" {
+		if err := instrumentDB(gormDB, config.Type, "", false); err != nil {
+			return nil, fmt.Errorf("failed to instrument database: %w", err)
+		}
 	}
 
 	// Test the connection
 	sqlDB, err := gormDB.DB()
 	if err != nil {
-		return fmt.Errorf("failed to get underlying database: %w", err)
+		return nil, fmt.Errorf("failed to get underlying database: %w", err)
 	}
 
 	if err := sqlDB.Ping(); err != nil {
-		return fmt.Errorf("failed to ping database: %w", err)
+		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
 	// Set connection pool parameters for long-running connections
-	sqlDB.SetMaxOpenConns(25)
-	sqlDB.SetMaxIdleConns(25)  // 保持所有连接都处于空闲状态以复用
-	sqlDB.SetConnMaxLifetime(0)  // 连接永不自动过期，SQLite专用
-	sqlDB.SetConnMaxIdleTime(0)  // 空闲连接永不自动关闭，SQLite专用
+	applyConnectionPool(sqlDB, config.ConnectionPool)
 
 	// Verify the database connection is fully operational by running a test query
 	var testResult int64
 	if err := gormDB.Raw("SELECT 1").Count(&testResult).Error; err != nil {
-		return fmt.Errorf("database connection test query failed: %w", err)
-	}
-
-	// Set global database instance only after successful validation
-	SetDB(gormDB)
-
-	// Auto-migrate tables to ensure schema is up to date
-	// This is safe as AutoMigrate only adds missing tables/columns and doesn't delete data
-	if err := gormDB.AutoMigrate(&AuthClient{}, &DomainEvent{}, &ConfigRecord{}, &ConfigSnapshot{}, &ModelSelection{}, &User{}, &Device{}, &Agent{}, &AgentDialog{}, &VerificationCode{}, &Workflow{}, &Plugin{}, &Provider{}); err != nil {
-		return fmt.Errorf("failed to migrate database schema: %w", err)
+		return nil, fmt.Errorf("database connection test query failed: %w", err)
 	}
-
-	// fmt.Printf("数据库已成功连接\n")
-	return nil
+	return gormDB, nil
 }
 
 // InitDatabase checks database initialization status without creating it automatically.
@@ -152,9 +161,7 @@ func silentInitDatabase() error {
 
 	// Database file exists, try to open and initialize it
 	var err error
-	db, err = gorm.Open(sqlite.Open(dbPath), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
-	})
+	db, err = openSQLite(dbPath, SQLiteOptions{}, nil)
 	if err != nil {
 		return fmt.Errorf("failed to open existing database: %w", err)
 	}
@@ -170,10 +177,7 @@ func silentInitDatabase() error {
 	}
 
 	// Set connection pool parameters for SQLite (never expire connections)
-	sqlDB.SetMaxOpenConns(25)
-	sqlDB.SetMaxIdleConns(25)
-	sqlDB.SetConnMaxLifetime(0)  // SQLite专用：连接永不自动过期
-	sqlDB.SetConnMaxIdleTime(0)  // SQLite专用：空闲连接永不自动关闭
+	applyConnectionPool(sqlDB, ConnectionPool{})
 
 	// Verify the database connection is fully operational by running a test query
 	var testResult int64
@@ -182,7 +186,7 @@ func silentInitDatabase() error {
 	}
 
 	// Auto-migrate tables for existing database
-	if err := db.AutoMigrate(&AuthClient{}, &DomainEvent{}, &ConfigRecord{}, &ConfigSnapshot{}, &ModelSelection{}, &User{}, &Device{}, &Agent{}, &AgentDialog{}, &VerificationCode{}, &Workflow{}, &Plugin{}, &Provider{}); err != nil {
+	if err := migrateSchema(db); err != nil {
 		return fmt.Errorf("failed to migrate existing database: %w", err)
 	}
 
@@ -223,9 +227,7 @@ func actualInitDatabase() error {
 
 	// Database file exists, try to open and initialize it
 	var err error
-	db, err = gorm.Open(sqlite.Open(dbPath), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
-	})
+	db, err = openSQLite(dbPath, SQLiteOptions{}, nil)
 	if err != nil {
 		return fmt.Errorf("failed to open existing database: %w", err)
 	}
@@ -241,10 +243,7 @@ func actualInitDatabase() error {
 	}
 
 	// Set connection pool parameters for SQLite (never expire connections)
-	sqlDB.SetMaxOpenConns(25)
-	sqlDB.SetMaxIdleConns(25)
-	sqlDB.SetConnMaxLifetime(0)  // SQLite专用：连接永不自动过期
-	sqlDB.SetConnMaxIdleTime(0)  // SQLite专用：空闲连接永不自动关闭
+	applyConnectionPool(sqlDB, ConnectionPool{})
 
 	// Verify the database connection is fully operational by running a test query
 	var testResult int64
@@ -253,7 +252,7 @@ func actualInitDatabase() error {
 	}
 
 	// Auto-migrate tables for existing database
-	if err := db.AutoMigrate(&AuthClient{}, &DomainEvent{}, &ConfigRecord{}, &ConfigSnapshot{}, &ModelSelection{}, &User{}, &Device{}, &Agent{}, &AgentDialog{}, &VerificationCode{}, &Workflow{}, &Plugin{}, &Provider{}); err != nil {
+	if err := migrateSchema(db); err != nil {
 		return fmt.Errorf("failed to migrate existing database: %w", err)
 	}
 
@@ -329,6 +328,39 @@ func SetDB(database *gorm.DB) {
 	db = database
 }
 
+// schemaModels 返回需要自动迁移的全部 GORM 模型
+func schemaModels() []interface{} {
+	return []interface{}{
+		&AuthClient{}, &DomainEvent{}, &ConfigRecord{}, &ConfigSnapshot{}, &ModelSelection{},
+		&User{}, &Device{}, &Agent{}, &AgentDialog{}, &VerificationCode{},
+		&Workflow{}, &Plugin{}, &Provider{},
+		&Reminder{}, &TranscriptEntry{}, &TranscriptFeedback{}, &TranscriptTurnLatency{}, &PromptTemplate{}, &PromptAssignment{}, &Experiment{}, &ExperimentOutcome{}, &EvaluationSuite{}, &EvaluationRun{}, &ReplayRun{},
+		&NotificationChannel{}, &WebhookEndpoint{}, &WebhookDelivery{}, &Script{}, &ScriptVersion{}, &AgentDefinition{}, &ToolPolicyAudit{}, &DeviceDebugAudit{}, &MemoryFact{}, &MemoryConsent{}, &PresenceStatus{}, &Routine{}, &ProviderCall{}, &BackgroundJob{}, &StoredObject{},
+		&KnowledgeBase{}, &KnowledgeDocument{}, &KnowledgeChunk{}, &KnowledgeBinding{},
+		&PluginPortAllocation{}, &PluginPortEvent{},
+		&Tenant{}, &TenantUsage{}, &DeviceTokenUsage{}, &AnalyticsRollup{}, &AnalyticsIntentCount{},
+		&SpeechTextProfile{}, &SpeechTextAssignment{}, &DeviceLanguage{}, &SmartHomeAlias{},
+		&UserDevice{}, &MemberProfile{}, &PairingToken{}, &DeviceCredential{}, &DeviceKey{},
+		&AudioRecording{}, &AudioRecordingConsent{},
+		&LogEntry{},
+		&FeatureFlag{},
+	}
+}
+
+// migrateSchema 自动迁移全部模型，并清理已被替换的旧索引
+func migrateSchema(db *gorm.DB) error {
+	if err := db.AutoMigrate(schemaModels()...); err != nil {
+		return err
+	}
+	// 提示词模板的唯一索引加入了租户ID，旧索引会阻止不同租户使用同名模板
+	if db.Migrator().HasIndex(&PromptTemplate{}, "idx_prompt_name_version") {
+		if err := db.Migrator().DropIndex(&PromptTemplate{}, "idx_prompt_name_version"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // AuthClient represents the authentication client model for GORM
 type AuthClient struct {
 	ID        uint           `gorm:"primaryKey"`
@@ -392,6 +424,7 @@ type Device struct {
 	ID               uint           `gorm:"primaryKey"`
 	AgentID          *uint          `gorm:"index"`
 	UserID           *uint          `gorm:"index"`
+	TenantID         string         `gorm:"type:varchar(64);index;default:'default'"`
 	Name             string         `gorm:"not null"`
 	DeviceID         string         `gorm:"type:varchar(255);uniqueIndex;not null"`
 	ClientID         string         `gorm:"type:varchar(255);uniqueIndex;not null"`
@@ -507,9 +540,7 @@ func initDatabaseWithConnection(config DatabaseConnection) error {
 			}
 		}
 
-		db, err = gorm.Open(sqlite.Open(config.Path), &gorm.Config{
-			Logger: logger.Default.LogMode(logger.Silent),
-		})
+		db, err = openSQLite(config.Path, config.SQLite, nil)
 		if err != nil {
 			return fmt.Errorf("failed to connect to sqlite database: %w", err)
 		}
@@ -535,19 +566,7 @@ func initDatabaseWithConnection(config DatabaseConnection) error {
 	}
 
 	// Use enhanced connection pool settings for better stability
-	maxOpenConns := config.ConnectionPool.MaxOpenConns
-	if maxOpenConns == 0 {
-		maxOpenConns = 25
-	}
-	maxIdleConns := config.ConnectionPool.MaxIdleConns
-	if maxIdleConns == 0 {
-		maxIdleConns = 25
-	}
-
-	sqlDB.SetMaxOpenConns(maxOpenConns)
-	sqlDB.SetMaxIdleConns(maxIdleConns)
-	sqlDB.SetConnMaxLifetime(0)  // SQLite专用：连接永不自动过期
-	sqlDB.SetConnMaxIdleTime(0)  // SQLite专用：空闲连接永不自动关闭
+	applyConnectionPool(sqlDB, config.ConnectionPool)
 
 	// Test the database connection before proceeding with migrations
 	if err := sqlDB.Ping(); err != nil {
@@ -561,7 +580,7 @@ func initDatabaseWithConnection(config DatabaseConnection) error {
 	}
 
 	// Auto-migrate tables
-	if err := db.AutoMigrate(&AuthClient{}, &DomainEvent{}, &ConfigRecord{}, &ConfigSnapshot{}, &ModelSelection{}, &User{}, &Device{}, &Agent{}, &AgentDialog{}, &VerificationCode{}, &Workflow{}, &Plugin{}, &Provider{}); err != nil {
+	if err := migrateSchema(db); err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
@@ -32,6 +32,7 @@ type DatabaseConnection struct {
 	SSLMode        string           `json:"ssl_mode,omitempty"` // SSL 模式 (PostgreSQL)
 	Charset        string           `json:"charset,omitempty"` // 字符集 (MySQL)
 	ConnectionPool ConnectionPool   `json:"connection_pool"`   // 连接池配置
+	SQLite         SQLiteOptions    `json:"sqlite,omitempty"`  // SQLite 调优配置
 }
 
 // ConnectionPool 连接池配置
@@ -39,6 +40,7 @@ type ConnectionPool struct {
 	MaxOpenConns    int           `json:"max_open_conns"`    // 最大打开连接数
 	MaxIdleConns    int           `json:"max_idle_conns"`    // 最大空闲连接数
 	ConnMaxLifetime time.Duration `json:"conn_max_lifetime"` // 连接最大生存时间
+	ConnMaxIdleTime time.Duration `json:"conn_max_idle_time,omitempty"` // 空闲连接最大保留时间，0 表示不关闭
 }
 
 // AdminConfig 管理员配置
@@ -197,6 +199,9 @@ func (m *DatabaseConfigManager) validateConfigInternal(config *DatabaseConfig) e
 		if !filepath.IsAbs(config.Database.Path) && !strings.HasPrefix(config.Database.Path, "./") {
 			config.Database.Path = "./" + config.Database.Path
 		}
+		if err := config.Database.SQLite.validate(); err != nil {
+			return err
+		}
 
 	case "mysql", "postgresql":
 		if config.Database.Host == "" {
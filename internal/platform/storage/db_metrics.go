@@ -0,0 +1,293 @@
+package storage
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/platform/observability"
+)
+
+const (
+	startedAtKey   = "storage:started_at"
+	writeLockedKey = "storage:write_locked"
+)
+
+// DBOperationStats 单类数据库操作的耗时统计
+type DBOperationStats struct {
+	Operation string  `json:"operation"` // query/create/update/delete/row/raw
+	Count     int64   `json:"count"`
+	Errors    int64   `json:"errors"`
+	Locked    int64   `json:"locked"` // 因 database is locked / busy 失败的次数
+	AvgMs     float64 `json:"avg_ms"`
+	MaxMs     float64 `json:"max_ms"`
+}
+
+// DBWriteQueueStats 写串行化的排队统计
+type DBWriteQueueStats struct {
+	Enabled   bool    `json:"enabled"`
+	Waiting   int64   `json:"waiting"` // 当前排队中的写操作
+	Writes    int64   `json:"writes"`
+	AvgWaitMs float64 `json:"avg_wait_ms"`
+	MaxWaitMs float64 `json:"max_wait_ms"`
+}
+
+// DBPoolStats 连接池状态
+type DBPoolStats struct {
+	MaxOpenConns int     `json:"max_open_conns"`
+	Open         int     `json:"open"`
+	InUse        int     `json:"in_use"`
+	Idle         int     `json:"idle"`
+	WaitCount    int64   `json:"wait_count"` // 等待空闲连接的累计次数
+	WaitMs       float64 `json:"wait_ms"`
+}
+
+// DBStats 数据库耗时、写排队和连接池统计
+type DBStats struct {
+	Driver      string             `json:"driver"`
+	JournalMode string             `json:"journal_mode,omitempty"`
+	Since       time.Time          `json:"since"`
+	Pool        DBPoolStats        `json:"pool"`
+	WriteQueue  DBWriteQueueStats  `json:"write_queue"`
+	Operations  []DBOperationStats `json:"operations"`
+}
+
+// operationCounters 单类操作的计数
+type operationCounters struct {
+	count  atomic.Int64
+	errors atomic.Int64
+	locked atomic.Int64
+	nanos  atomic.Int64
+	max    atomic.Int64
+}
+
+// dbInstrumentation 挂载在 gorm 回调上的写串行化与耗时统计
+// SQLite 同一时刻只允许一个写者，进程内先排队可以避免大量连接同时抢锁、超过 busy_timeout 后报错
+type dbInstrumentation struct {
+	driver      string
+	journalMode string
+	serialize   bool
+	since       time.Time
+	db          *gorm.DB
+
+	writeMu    sync.Mutex
+	waiting    atomic.Int64
+	writes     atomic.Int64
+	waitNanos  atomic.Int64
+	maxWait    atomic.Int64
+	operations map[string]*operationCounters
+}
+
+// activeInstrumentation 当前全局数据库的统计，供 Stats 读取
+var activeInstrumentation atomic.Pointer[dbInstrumentation]
+
+// instrumentDB 注册耗时统计回调，serialize 为 true 时串行化事务外的写操作
+func instrumentDB(db *gorm.DB, driver, journalMode string, serialize bool) error {
+	inst := &dbInstrumentation{
+		driver:      driver,
+		journalMode: journalMode,
+		serialize:   serialize,
+		since:       time.Now(),
+		db:          db,
+		operations:  make(map[string]*operationCounters),
+	}
+	for _, operation := range []string{"query", "create", "update", "delete", "row", "raw"} {
+		inst.operations[operation] = &operationCounters{}
+	}
+
+	callbacks := db.Callback()
+	errs := []error{
+		callbacks.Query().Before("*").Register("storage:timing_start", inst.start),
+		callbacks.Query().After("*").Register("storage:timing_end", inst.finisher("query")),
+		callbacks.Create().Before("*").Register("storage:timing_start", inst.start),
+		callbacks.Create().After("*").Register("storage:timing_end", inst.finisher("create")),
+		callbacks.Update().Before("*").Register("storage:timing_start", inst.start),
+		callbacks.Update().After("*").Register("storage:timing_end", inst.finisher("update")),
+		callbacks.Delete().Before("*").Register("storage:timing_start", inst.start),
+		callbacks.Delete().After("*").Register("storage:timing_end", inst.finisher("delete")),
+		callbacks.Row().Before("*").Register("storage:timing_start", inst.start),
+		callbacks.Row().After("*").Register("storage:timing_end", inst.finisher("row")),
+		callbacks.Raw().Before("*").Register("storage:timing_start", inst.start),
+		callbacks.Raw().After("*").Register("storage:timing_end", inst.finisher("raw")),
+	}
+	if serialize {
+		// 写锁包住 gorm 默认事务的开始到提交，原生 Exec 只包住执行本身
+		errs = append(errs,
+			callbacks.Create().Before("gorm:begin_transaction").Register("storage:write_lock", inst.lockWrite),
+			callbacks.Create().After("gorm:commit_or_rollback_transaction").Register("storage:write_unlock", inst.unlockWrite),
+			callbacks.Update().Before("gorm:begin_transaction").Register("storage:write_lock", inst.lockWrite),
+			callbacks.Update().After("gorm:commit_or_rollback_transaction").Register("storage:write_unlock", inst.unlockWrite),
+			callbacks.Delete().Before("gorm:begin_transaction").Register("storage:write_lock", inst.lockWrite),
+			callbacks.Delete().After("gorm:commit_or_rollback_transaction").Register("storage:write_unlock", inst.unlockWrite),
+			callbacks.Raw().Before("gorm:raw").Register("storage:write_lock", inst.lockWrite),
+			callbacks.Raw().After("gorm:raw").Register("storage:write_unlock", inst.unlockWrite),
+		)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	activeInstrumentation.Store(inst)
+	return nil
+}
+
+func (i *dbInstrumentation) start(tx *gorm.DB) {
+	tx.InstanceSet(startedAtKey, time.Now())
+}
+
+// finisher 返回记录指定操作耗时的回调
+func (i *dbInstrumentation) finisher(operation string) func(*gorm.DB) {
+	return func(tx *gorm.DB) { i.finish(tx, operation) }
+}
+
+func (i *dbInstrumentation) finish(tx *gorm.DB, operation string) {
+	value, ok := tx.InstanceGet(startedAtKey)
+	if !ok {
+		return
+	}
+	started, ok := value.(time.Time)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(started)
+
+	counters := i.operations[operation]
+	counters.count.Add(1)
+	counters.nanos.Add(int64(elapsed))
+	storeMax(&counters.max, int64(elapsed))
+
+	failed := tx.Error != nil && !errors.Is(tx.Error, gorm.ErrRecordNotFound)
+	if failed {
+		counters.errors.Add(1)
+		if isLockedError(tx.Error) {
+			counters.locked.Add(1)
+		}
+	}
+
+	observability.RecordMetric(tx.Statement.Context, "storage.db.latency_ms", float64(elapsed)/float64(time.Millisecond), map[string]string{
+		"operation": operation,
+		"table":     tx.Statement.Table,
+		"error":     boolLabel(failed),
+	})
+}
+
+// lockWrite 事务外的写操作排队获取进程内写锁；已处于事务中的语句不再加锁，避免与事务自身互相等待
+func (i *dbInstrumentation) lockWrite(tx *gorm.DB) {
+	if tx.Statement.DryRun || tx.Error != nil {
+		return
+	}
+	if _, inTx := tx.Statement.ConnPool.(gorm.TxCommitter); inTx {
+		return
+	}
+
+	i.waiting.Add(1)
+	started := time.Now()
+	i.writeMu.Lock()
+	waited := time.Since(started)
+	i.waiting.Add(-1)
+
+	i.writes.Add(1)
+	i.waitNanos.Add(int64(waited))
+	storeMax(&i.maxWait, int64(waited))
+	tx.InstanceSet(writeLockedKey, true)
+
+	if waited >= time.Millisecond {
+		observability.RecordMetric(tx.Statement.Context, "storage.db.write_wait_ms", float64(waited)/float64(time.Millisecond), map[string]string{
+			"table": tx.Statement.Table,
+		})
+	}
+}
+
+func (i *dbInstrumentation) unlockWrite(tx *gorm.DB) {
+	if locked, _ := tx.InstanceGet(writeLockedKey); locked == true {
+		tx.InstanceSet(writeLockedKey, false)
+		i.writeMu.Unlock()
+	}
+}
+
+// stats 汇总统计
+func (i *dbInstrumentation) stats() DBStats {
+	stats := DBStats{
+		Driver:      i.driver,
+		JournalMode: i.journalMode,
+		Since:       i.since,
+		WriteQueue: DBWriteQueueStats{
+			Enabled:   i.serialize,
+			Waiting:   i.waiting.Load(),
+			Writes:    i.writes.Load(),
+			MaxWaitMs: nanosToMs(i.maxWait.Load()),
+		},
+		Operations: make([]DBOperationStats, 0, len(i.operations)),
+	}
+	if stats.WriteQueue.Writes > 0 {
+		stats.WriteQueue.AvgWaitMs = nanosToMs(i.waitNanos.Load()) / float64(stats.WriteQueue.Writes)
+	}
+
+	for operation, c := range i.operations {
+		op := DBOperationStats{
+			Operation: operation,
+			Count:     c.count.Load(),
+			Errors:    c.errors.Load(),
+			Locked:    c.locked.Load(),
+			MaxMs:     nanosToMs(c.max.Load()),
+		}
+		if op.Count > 0 {
+			op.AvgMs = nanosToMs(c.nanos.Load()) / float64(op.Count)
+		}
+		stats.Operations = append(stats.Operations, op)
+	}
+	sort.Slice(stats.Operations, func(a, b int) bool { return stats.Operations[a].Operation < stats.Operations[b].Operation })
+
+	if sqlDB, err := i.db.DB(); err == nil {
+		pool := sqlDB.Stats()
+		stats.Pool = DBPoolStats{
+			MaxOpenConns: pool.MaxOpenConnections,
+			Open:         pool.OpenConnections,
+			InUse:        pool.InUse,
+			Idle:         pool.Idle,
+			WaitCount:    pool.WaitCount,
+			WaitMs:       nanosToMs(int64(pool.WaitDuration)),
+		}
+	}
+	return stats
+}
+
+// Stats 返回全局数据库的耗时、写排队和连接池统计，数据库未初始化时返回 false
+func Stats() (DBStats, bool) {
+	inst := activeInstrumentation.Load()
+	if inst == nil {
+		return DBStats{}, false
+	}
+	return inst.stats(), true
+}
+
+// isLockedError 判断是否为 SQLite 锁冲突错误
+func isLockedError(err error) bool {
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "database is locked") || strings.Contains(message, "database table is locked") || strings.Contains(message, "sqlite_busy")
+}
+
+func storeMax(target *atomic.Int64, value int64) {
+	for {
+		current := target.Load()
+		if value <= current || target.CompareAndSwap(current, value) {
+			return
+		}
+	}
+}
+
+func nanosToMs(nanos int64) float64 {
+	return float64(nanos) / float64(time.Millisecond)
+}
+
+func boolLabel(value bool) string {
+	if value {
+		return "true"
+	}
+	return "false"
+}
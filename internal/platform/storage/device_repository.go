@@ -7,6 +7,7 @@ import (
 
 	"xiaozhi-server-go/internal/domain/device/aggregate"
 	"xiaozhi-server-go/internal/domain/device/repository"
+	"xiaozhi-server-go/internal/domain/tenant"
 	"xiaozhi-server-go/internal/platform/errors"
 )
 
@@ -124,6 +125,7 @@ func (r *deviceRepository) UpdateDeviceStatus(ctx context.Context, deviceID stri
 func (r *deviceRepository) toModel(device *aggregate.Device) *Device {
 	model := &Device{
 		ID:               uint(device.ID),
+		TenantID:         device.TenantID,
 		Name:             device.Name,
 		DeviceID:         device.DeviceID,
 		ClientID:         device.ClientID,
@@ -160,6 +162,10 @@ func (r *deviceRepository) toModel(device *aggregate.Device) *Device {
 		model.AgentID = &agentID
 	}
 
+	if model.TenantID == "" {
+		model.TenantID = tenant.DefaultID
+	}
+
 	return model
 }
 
@@ -167,6 +173,7 @@ func (r *deviceRepository) toModel(device *aggregate.Device) *Device {
 func (r *deviceRepository) fromModel(model *Device) *aggregate.Device {
 	device := &aggregate.Device{
 		ID:               int(model.ID),
+		TenantID:         model.TenantID,
 		Name:             model.Name,
 		DeviceID:         model.DeviceID,
 		ClientID:         model.ClientID,
@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/devicedebug"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// DeviceDebugAudit 远程调试审计存储模型
+type DeviceDebugAudit struct {
+	ID         string    `gorm:"type:varchar(64);primaryKey"`
+	DeviceID   string    `gorm:"type:varchar(255);index"`
+	SessionID  string    `gorm:"type:varchar(255)"`
+	Action     string    `gorm:"type:varchar(32);index"`
+	Command    string    `gorm:"type:varchar(255)"`
+	Params     string    `gorm:"type:text"`
+	Success    bool      `gorm:"default:false"`
+	Error      string    `gorm:"type:text"`
+	DurationMs int64     `gorm:"default:0"`
+	RequestID  string    `gorm:"type:varchar(64)"`
+	ClientIP   string    `gorm:"type:varchar(64)"`
+	CreatedAt  time.Time `gorm:"index"`
+}
+
+// TableName 指定表名
+func (DeviceDebugAudit) TableName() string {
+	return "device_debug_audits"
+}
+
+// deviceDebugRepository 远程调试审计仓库实现
+type deviceDebugRepository struct {
+	db *gorm.DB
+}
+
+// NewDeviceDebugRepository 创建远程调试审计仓库实例
+func NewDeviceDebugRepository(db *gorm.DB) devicedebug.Repository {
+	return &deviceDebugRepository{
+		db: db,
+	}
+}
+
+// Save 保存审计记录
+func (r *deviceDebugRepository) Save(ctx context.Context, record *devicedebug.AuditRecord) error {
+	model := DeviceDebugAudit{
+		ID:         record.ID,
+		DeviceID:   record.DeviceID,
+		SessionID:  record.SessionID,
+		Action:     string(record.Action),
+		Command:    record.Command,
+		Params:     record.Params,
+		Success:    record.Success,
+		Error:      record.Error,
+		DurationMs: record.DurationMs,
+		RequestID:  record.RequestID,
+		ClientIP:   record.ClientIP,
+		CreatedAt:  record.CreatedAt,
+	}
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "devicedebug.save", "failed to save device debug audit", err)
+	}
+	return nil
+}
+
+// List 按条件分页查询审计记录
+func (r *deviceDebugRepository) List(ctx context.Context, filter devicedebug.AuditFilter) ([]*devicedebug.AuditRecord, int64, error) {
+	query := r.db.WithContext(ctx).Model(&DeviceDebugAudit{})
+	if filter.DeviceID != "" {
+		query = query.Where("device_id = ?", filter.DeviceID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", string(filter.Action))
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at <= ?", filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "devicedebug.list", "failed to count device debug audits", err)
+	}
+
+	var models []DeviceDebugAudit
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(filter.PageSize).Find(&models).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "devicedebug.list", "failed to list device debug audits", err)
+	}
+
+	items := make([]*devicedebug.AuditRecord, len(models))
+	for i, m := range models {
+		items[i] = &devicedebug.AuditRecord{
+			ID:         m.ID,
+			DeviceID:   m.DeviceID,
+			SessionID:  m.SessionID,
+			Action:     devicedebug.Action(m.Action),
+			Command:    m.Command,
+			Params:     m.Params,
+			Success:    m.Success,
+			Error:      m.Error,
+			DurationMs: m.DurationMs,
+			RequestID:  m.RequestID,
+			ClientIP:   m.ClientIP,
+			CreatedAt:  m.CreatedAt,
+		}
+	}
+	return items, total, nil
+}
+
+// DeleteBefore 删除指定时间之前的审计记录
+func (r *deviceDebugRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("created_at < ?", before).Delete(&DeviceDebugAudit{})
+	if result.Error != nil {
+		return 0, errors.Wrap(errors.KindStorage, "devicedebug.delete_before", "failed to purge device debug audits", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// DeleteByDevice 删除设备的全部审计记录
+func (r *deviceDebugRepository) DeleteByDevice(ctx context.Context, deviceID string) (int64, error) {
+	result := r.db.WithContext(ctx).Where("device_id = ?", deviceID).Delete(&DeviceDebugAudit{})
+	if result.Error != nil {
+		return 0, errors.Wrap(errors.KindStorage, "devicedebug.delete_device", "failed to delete device debug audits", result.Error)
+	}
+	return result.RowsAffected, nil
+}
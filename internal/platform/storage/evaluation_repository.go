@@ -0,0 +1,275 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/evaluation"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// EvaluationSuite 评测测试集存储模型
+type EvaluationSuite struct {
+	ID           string `gorm:"type:varchar(64);primaryKey"`
+	Name         string `gorm:"type:varchar(255);not null"`
+	Type         string `gorm:"type:varchar(16);index;not null"`
+	Description  string `gorm:"type:varchar(1024)"`
+	SystemPrompt string `gorm:"type:text"`
+	Cases        string // JSON，ASR 用例包含 base64 音频，不指定类型以便 MySQL 使用 longtext
+	CaseCount    int
+	CreatedAt    time.Time
+}
+
+// TableName 指定表名
+func (EvaluationSuite) TableName() string {
+	return "evaluation_suites"
+}
+
+// EvaluationRun 评测运行存储模型
+type EvaluationRun struct {
+	ID         string    `gorm:"type:varchar(64);primaryKey"`
+	SuiteID    string    `gorm:"type:varchar(64);index;not null"`
+	SuiteType  string    `gorm:"type:varchar(16)"`
+	Targets    string    `gorm:"type:text"` // JSON
+	Judge      string    `gorm:"type:text"` // JSON
+	Status     string    `gorm:"type:varchar(16);index;not null"`
+	Error      string    `gorm:"type:text"`
+	Reports    string    // JSON，不指定类型以便 MySQL 使用 longtext
+	CreatedAt  time.Time `gorm:"index"`
+	FinishedAt *time.Time
+}
+
+// TableName 指定表名
+func (EvaluationRun) TableName() string {
+	return "evaluation_runs"
+}
+
+// evaluationRepository 评测仓库实现
+type evaluationRepository struct {
+	db *gorm.DB
+}
+
+// NewEvaluationRepository 创建评测仓库实例
+func NewEvaluationRepository(db *gorm.DB) evaluation.Repository {
+	return &evaluationRepository{
+		db: db,
+	}
+}
+
+// SaveSuite 保存测试集
+func (r *evaluationRepository) SaveSuite(ctx context.Context, s *evaluation.Suite) error {
+	cases, err := json.Marshal(s.Cases)
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "evaluation.save_suite", "failed to encode cases", err)
+	}
+	model := &EvaluationSuite{
+		ID:           s.ID,
+		Name:         s.Name,
+		Type:         string(s.Type),
+		Description:  s.Description,
+		SystemPrompt: s.SystemPrompt,
+		Cases:        string(cases),
+		CaseCount:    len(s.Cases),
+		CreatedAt:    s.CreatedAt,
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "evaluation.save_suite", "failed to save evaluation suite", err)
+	}
+	return nil
+}
+
+// FindSuite 根据ID查找测试集
+func (r *evaluationRepository) FindSuite(ctx context.Context, id string) (*evaluation.Suite, error) {
+	var model EvaluationSuite
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "evaluation.find_suite", "failed to find evaluation suite", err)
+	}
+	suite := r.fromSuiteModel(&model)
+	if err := json.Unmarshal([]byte(model.Cases), &suite.Cases); err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "evaluation.find_suite", "failed to decode cases", err)
+	}
+	return suite, nil
+}
+
+// ListSuites 分页查询测试集，不加载用例内容
+func (r *evaluationRepository) ListSuites(ctx context.Context, page, pageSize int) ([]*evaluation.Suite, int64, error) {
+	query := r.db.WithContext(ctx).Model(&EvaluationSuite{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "evaluation.list_suites", "failed to count evaluation suites", err)
+	}
+
+	var models []EvaluationSuite
+	if err := query.
+		Omit("cases").
+		Order("created_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&models).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "evaluation.list_suites", "failed to list evaluation suites", err)
+	}
+
+	items := make([]*evaluation.Suite, len(models))
+	for i := range models {
+		items[i] = r.fromSuiteModel(&models[i])
+		items[i].Cases = make([]evaluation.Case, models[i].CaseCount)
+	}
+	return items, total, nil
+}
+
+// DeleteSuite 删除测试集
+func (r *evaluationRepository) DeleteSuite(ctx context.Context, id string) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&EvaluationSuite{}).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "evaluation.delete_suite", "failed to delete evaluation suite", err)
+	}
+	return nil
+}
+
+// SaveRun 保存评测运行
+func (r *evaluationRepository) SaveRun(ctx context.Context, run *evaluation.Run) error {
+	model, err := r.toRunModel(run)
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "evaluation.save_run", "failed to encode evaluation run", err)
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "evaluation.save_run", "failed to save evaluation run", err)
+	}
+	return nil
+}
+
+// UpdateRun 更新评测运行
+func (r *evaluationRepository) UpdateRun(ctx context.Context, run *evaluation.Run) error {
+	model, err := r.toRunModel(run)
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "evaluation.update_run", "failed to encode evaluation run", err)
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "evaluation.update_run", "failed to update evaluation run", err)
+	}
+	return nil
+}
+
+// FindRun 根据ID查找评测运行
+func (r *evaluationRepository) FindRun(ctx context.Context, id string) (*evaluation.Run, error) {
+	var model EvaluationRun
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "evaluation.find_run", "failed to find evaluation run", err)
+	}
+	return r.fromRunModel(&model), nil
+}
+
+// ListRuns 分页查询评测运行
+func (r *evaluationRepository) ListRuns(ctx context.Context, filter evaluation.RunFilter) ([]*evaluation.Run, int64, error) {
+	query := r.db.WithContext(ctx).Model(&EvaluationRun{})
+	if filter.SuiteID != "" {
+		query = query.Where("suite_id = ?", filter.SuiteID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "evaluation.list_runs", "failed to count evaluation runs", err)
+	}
+
+	var models []EvaluationRun
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(filter.PageSize).Find(&models).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "evaluation.list_runs", "failed to list evaluation runs", err)
+	}
+
+	items := make([]*evaluation.Run, len(models))
+	for i := range models {
+		items[i] = r.fromRunModel(&models[i])
+	}
+	return items, total, nil
+}
+
+// FailUnfinished 将未完成的运行标记为失败
+func (r *evaluationRepository) FailUnfinished(ctx context.Context, reason string) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&EvaluationRun{}).
+		Where("status IN ?", []string{string(evaluation.RunPending), string(evaluation.RunRunning)}).
+		Updates(map[string]interface{}{
+			"status":      string(evaluation.RunFailed),
+			"error":       reason,
+			"finished_at": time.Now(),
+		})
+	if result.Error != nil {
+		return 0, errors.Wrap(errors.KindStorage, "evaluation.fail_unfinished", "failed to mark unfinished runs", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *evaluationRepository) fromSuiteModel(m *EvaluationSuite) *evaluation.Suite {
+	return &evaluation.Suite{
+		ID:           m.ID,
+		Name:         m.Name,
+		Type:         evaluation.SuiteType(m.Type),
+		Description:  m.Description,
+		SystemPrompt: m.SystemPrompt,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+// toRunModel 将领域对象转换为存储模型
+func (r *evaluationRepository) toRunModel(run *evaluation.Run) (*EvaluationRun, error) {
+	targets, err := json.Marshal(run.Targets)
+	if err != nil {
+		return nil, err
+	}
+	var judge []byte
+	if run.Judge != nil {
+		if judge, err = json.Marshal(run.Judge); err != nil {
+			return nil, err
+		}
+	}
+	var reports []byte
+	if len(run.Reports) > 0 {
+		if reports, err = json.Marshal(run.Reports); err != nil {
+			return nil, err
+		}
+	}
+	return &EvaluationRun{
+		ID:         run.ID,
+		SuiteID:    run.SuiteID,
+		SuiteType:  string(run.SuiteType),
+		Targets:    string(targets),
+		Judge:      string(judge),
+		Status:     string(run.Status),
+		Error:      run.Error,
+		Reports:    string(reports),
+		CreatedAt:  run.CreatedAt,
+		FinishedAt: run.FinishedAt,
+	}, nil
+}
+
+// fromRunModel 将存储模型转换为领域对象
+func (r *evaluationRepository) fromRunModel(m *EvaluationRun) *evaluation.Run {
+	run := &evaluation.Run{
+		ID:         m.ID,
+		SuiteID:    m.SuiteID,
+		SuiteType:  evaluation.SuiteType(m.SuiteType),
+		Status:     evaluation.RunStatus(m.Status),
+		Error:      m.Error,
+		CreatedAt:  m.CreatedAt,
+		FinishedAt: m.FinishedAt,
+	}
+	if m.Targets != "" {
+		_ = json.Unmarshal([]byte(m.Targets), &run.Targets)
+	}
+	if m.Judge != "" {
+		_ = json.Unmarshal([]byte(m.Judge), &run.Judge)
+	}
+	if m.Reports != "" {
+		_ = json.Unmarshal([]byte(m.Reports), &run.Reports)
+	}
+	return run
+}
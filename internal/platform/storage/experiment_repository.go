@@ -0,0 +1,257 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/experiment"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// Experiment 实验存储模型
+type Experiment struct {
+	ID             string `gorm:"type:varchar(64);primaryKey"`
+	Name           string `gorm:"type:varchar(255);not null"`
+	Description    string `gorm:"type:varchar(1024)"`
+	Status         string `gorm:"type:varchar(32);index;not null"`
+	TrafficPercent int    `gorm:"default:100"`
+	Cohort         string `gorm:"type:text"` // JSON
+	Variants       string `gorm:"type:text"` // JSON
+	CreatedAt      time.Time
+	StartedAt      *time.Time
+	StoppedAt      *time.Time
+}
+
+// TableName 指定表名
+func (Experiment) TableName() string {
+	return "experiments"
+}
+
+// ExperimentOutcome 实验结果存储模型
+type ExperimentOutcome struct {
+	ID               string `gorm:"type:varchar(64);primaryKey"`
+	ExperimentID     string `gorm:"type:varchar(64);index;not null"`
+	Variant          string `gorm:"type:varchar(255);not null"`
+	DeviceID         string `gorm:"type:varchar(255);index"`
+	SessionID        string `gorm:"type:varchar(255);index:idx_outcome_session_round"`
+	Round            int    `gorm:"index:idx_outcome_session_round"`
+	LatencyMs        int64
+	PromptTokens     int
+	CompletionTokens int
+	Interrupted      bool `gorm:"default:false"`
+	Feedback         int  `gorm:"default:0"`
+	CreatedAt        time.Time
+}
+
+// TableName 指定表名
+func (ExperimentOutcome) TableName() string {
+	return "experiment_outcomes"
+}
+
+// experimentRepository 实验仓库实现
+type experimentRepository struct {
+	db *gorm.DB
+}
+
+// NewExperimentRepository 创建实验仓库实例
+func NewExperimentRepository(db *gorm.DB) experiment.Repository {
+	return &experimentRepository{
+		db: db,
+	}
+}
+
+// Save 保存实验
+func (r *experimentRepository) Save(ctx context.Context, e *experiment.Experiment) error {
+	model, err := r.toModel(e)
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "experiment.save", "failed to encode experiment", err)
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "experiment.save", "failed to save experiment", err)
+	}
+	return nil
+}
+
+// Update 更新实验
+func (r *experimentRepository) Update(ctx context.Context, e *experiment.Experiment) error {
+	model, err := r.toModel(e)
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "experiment.update", "failed to encode experiment", err)
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "experiment.update", "failed to update experiment", err)
+	}
+	return nil
+}
+
+// FindByID 根据ID查找实验
+func (r *experimentRepository) FindByID(ctx context.Context, id string) (*experiment.Experiment, error) {
+	var model Experiment
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "experiment.find_by_id", "failed to find experiment", err)
+	}
+	return r.fromModel(&model), nil
+}
+
+// List 分页查询实验
+func (r *experimentRepository) List(ctx context.Context, filter experiment.Filter) ([]*experiment.Experiment, int64, error) {
+	query := r.db.WithContext(ctx).Model(&Experiment{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "experiment.list", "failed to count experiments", err)
+	}
+
+	var models []Experiment
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(filter.PageSize).Find(&models).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "experiment.list", "failed to list experiments", err)
+	}
+	return r.fromModels(models), total, nil
+}
+
+// ListRunning 列出运行中的实验，按开始时间排序
+func (r *experimentRepository) ListRunning(ctx context.Context) ([]*experiment.Experiment, error) {
+	var models []Experiment
+	if err := r.db.WithContext(ctx).
+		Where("status = ?", string(experiment.StatusRunning)).
+		Order("started_at ASC").
+		Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "experiment.list_running", "failed to list running experiments", err)
+	}
+	return r.fromModels(models), nil
+}
+
+// Delete 删除实验及其结果
+func (r *experimentRepository) Delete(ctx context.Context, id string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("experiment_id = ?", id).Delete(&ExperimentOutcome{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&Experiment{}).Error
+	})
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "experiment.delete", "failed to delete experiment", err)
+	}
+	return nil
+}
+
+// SaveOutcome 保存一轮对话的实验结果
+func (r *experimentRepository) SaveOutcome(ctx context.Context, o *experiment.Outcome) error {
+	model := &ExperimentOutcome{
+		ID:               o.ID,
+		ExperimentID:     o.ExperimentID,
+		Variant:          o.Variant,
+		DeviceID:         o.DeviceID,
+		SessionID:        o.SessionID,
+		Round:            o.Round,
+		LatencyMs:        o.LatencyMs,
+		PromptTokens:     o.PromptTokens,
+		CompletionTokens: o.CompletionTokens,
+		Interrupted:      o.Interrupted,
+		Feedback:         o.Feedback,
+		CreatedAt:        o.CreatedAt,
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "experiment.save_outcome", "failed to save experiment outcome", err)
+	}
+	return nil
+}
+
+// SetFeedback 更新会话某轮对话的反馈
+func (r *experimentRepository) SetFeedback(ctx context.Context, sessionID string, round int, feedback int) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&ExperimentOutcome{}).
+		Where("session_id = ? AND round = ?", sessionID, round).
+		Update("feedback", feedback)
+	if result.Error != nil {
+		return 0, errors.Wrap(errors.KindStorage, "experiment.set_feedback", "failed to update experiment feedback", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// Aggregate 按分组汇总实验结果
+func (r *experimentRepository) Aggregate(ctx context.Context, experimentID string) ([]experiment.VariantStats, error) {
+	var rows []experiment.VariantStats
+	err := r.db.WithContext(ctx).Model(&ExperimentOutcome{}).
+		Select(`variant,
+			COUNT(*) AS samples,
+			AVG(latency_ms) AS avg_latency_ms,
+			SUM(prompt_tokens) AS prompt_tokens,
+			SUM(completion_tokens) AS completion_tokens,
+			SUM(CASE WHEN interrupted THEN 1 ELSE 0 END) AS interrupted,
+			SUM(CASE WHEN feedback > 0 THEN 1 ELSE 0 END) AS thumbs_up,
+			SUM(CASE WHEN feedback < 0 THEN 1 ELSE 0 END) AS thumbs_down`).
+		Where("experiment_id = ?", experimentID).
+		Group("variant").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "experiment.aggregate", "failed to aggregate experiment outcomes", err)
+	}
+	return rows, nil
+}
+
+// toModel 将领域对象转换为存储模型
+func (r *experimentRepository) toModel(e *experiment.Experiment) (*Experiment, error) {
+	variants, err := json.Marshal(e.Variants)
+	if err != nil {
+		return nil, err
+	}
+	var cohort string
+	if len(e.Cohort) > 0 {
+		data, err := json.Marshal(e.Cohort)
+		if err != nil {
+			return nil, err
+		}
+		cohort = string(data)
+	}
+	return &Experiment{
+		ID:             e.ID,
+		Name:           e.Name,
+		Description:    e.Description,
+		Status:         string(e.Status),
+		TrafficPercent: e.TrafficPercent,
+		Cohort:         cohort,
+		Variants:       string(variants),
+		CreatedAt:      e.CreatedAt,
+		StartedAt:      e.StartedAt,
+		StoppedAt:      e.StoppedAt,
+	}, nil
+}
+
+// fromModel 将存储模型转换为领域对象
+func (r *experimentRepository) fromModel(m *Experiment) *experiment.Experiment {
+	e := &experiment.Experiment{
+		ID:             m.ID,
+		Name:           m.Name,
+		Description:    m.Description,
+		Status:         experiment.Status(m.Status),
+		TrafficPercent: m.TrafficPercent,
+		CreatedAt:      m.CreatedAt,
+		StartedAt:      m.StartedAt,
+		StoppedAt:      m.StoppedAt,
+	}
+	if m.Cohort != "" {
+		_ = json.Unmarshal([]byte(m.Cohort), &e.Cohort)
+	}
+	if m.Variants != "" {
+		_ = json.Unmarshal([]byte(m.Variants), &e.Variants)
+	}
+	return e
+}
+
+func (r *experimentRepository) fromModels(models []Experiment) []*experiment.Experiment {
+	items := make([]*experiment.Experiment, len(models))
+	for i := range models {
+		items[i] = r.fromModel(&models[i])
+	}
+	return items
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/flags"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// FeatureFlag 功能开关存储模型
+type FeatureFlag struct {
+	Key         string    `gorm:"column:flag_key;type:varchar(64);primaryKey"` // key 是 MySQL 保留字
+	Description string    `gorm:"type:varchar(1024)"`
+	Enabled     bool      `gorm:"default:false"`
+	Rules       string    `gorm:"type:text"`            // JSON
+	UpdatedAt   time.Time `gorm:"autoUpdateTime:false"` // 由服务设置，重新加载时据此判断开关是否变化
+}
+
+// TableName 指定表名
+func (FeatureFlag) TableName() string {
+	return "feature_flags"
+}
+
+// flagRepository 功能开关仓库实现
+type flagRepository struct {
+	db *gorm.DB
+}
+
+// NewFlagRepository 创建功能开关仓库实例
+func NewFlagRepository(db *gorm.DB) flags.Repository {
+	return &flagRepository{
+		db: db,
+	}
+}
+
+// List 列出全部开关
+func (r *flagRepository) List(ctx context.Context) ([]*flags.Flag, error) {
+	var models []FeatureFlag
+	if err := r.db.WithContext(ctx).Order("flag_key ASC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "flags.list", "failed to list feature flags", err)
+	}
+	items := make([]*flags.Flag, len(models))
+	for i, m := range models {
+		items[i] = &flags.Flag{
+			Key:         m.Key,
+			Description: m.Description,
+			Enabled:     m.Enabled,
+			UpdatedAt:   m.UpdatedAt,
+		}
+		if m.Rules != "" {
+			_ = json.Unmarshal([]byte(m.Rules), &items[i].Rules)
+		}
+	}
+	return items, nil
+}
+
+// Save 创建或更新开关
+func (r *flagRepository) Save(ctx context.Context, f *flags.Flag) error {
+	model := &FeatureFlag{
+		Key:         f.Key,
+		Description: f.Description,
+		Enabled:     f.Enabled,
+		UpdatedAt:   f.UpdatedAt,
+	}
+	if len(f.Rules) > 0 {
+		data, err := json.Marshal(f.Rules)
+		if err != nil {
+			return errors.Wrap(errors.KindStorage, "flags.save", "failed to encode feature flag rules", err)
+		}
+		model.Rules = string(data)
+	}
+	// Save 对零值 bool 也会写入，保证关闭状态能落库
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "flags.save", "failed to save feature flag", err)
+	}
+	return nil
+}
+
+// Delete 删除开关
+func (r *flagRepository) Delete(ctx context.Context, key string) error {
+	if err := r.db.WithContext(ctx).Where("flag_key = ?", key).Delete(&FeatureFlag{}).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "flags.delete", "failed to delete feature flag", err)
+	}
+	return nil
+}
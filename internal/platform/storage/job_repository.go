@@ -0,0 +1,253 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/job"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// claimAttempts 领取任务时与其他工作协程冲突的重试次数
+const claimAttempts = 3
+
+// BackgroundJob 后台任务存储模型
+type BackgroundJob struct {
+	ID          string    `gorm:"type:varchar(64);primaryKey"`
+	Type        string    `gorm:"type:varchar(64);index:idx_job_claim,priority:1;not null"`
+	Key         string    `gorm:"column:job_key;type:varchar(255);index"`
+	Payload     string    `gorm:"type:text"` // JSON
+	Status      string    `gorm:"type:varchar(16);index:idx_job_claim,priority:2;not null"`
+	Attempts    int       `gorm:"default:0"`
+	MaxAttempts int       `gorm:"default:0"`
+	RunAt       time.Time `gorm:"index:idx_job_claim,priority:3"`
+	LeaseUntil  *time.Time
+	LastError   string    `gorm:"type:text"`
+	CreatedAt   time.Time `gorm:"index"`
+	UpdatedAt   time.Time
+	StartedAt   *time.Time
+	FinishedAt  *time.Time `gorm:"index"`
+}
+
+// TableName 指定表名
+func (BackgroundJob) TableName() string {
+	return "background_jobs"
+}
+
+// jobRepository 后台任务仓库实现
+type jobRepository struct {
+	db *gorm.DB
+}
+
+// NewJobRepository 创建后台任务仓库实例
+func NewJobRepository(db *gorm.DB) job.Repository {
+	return &jobRepository{
+		db: db,
+	}
+}
+
+// Save 保存新任务
+func (r *jobRepository) Save(ctx context.Context, j *job.Job) error {
+	if err := r.db.WithContext(ctx).Create(r.toModel(j)).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "job.save", "failed to save job", err)
+	}
+	return nil
+}
+
+// Update 更新任务
+func (r *jobRepository) Update(ctx context.Context, j *job.Job) error {
+	if err := r.db.WithContext(ctx).Save(r.toModel(j)).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "job.update", "failed to update job", err)
+	}
+	return nil
+}
+
+// Find 根据ID查找任务
+func (r *jobRepository) Find(ctx context.Context, id string) (*job.Job, error) {
+	var model BackgroundJob
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "job.find", "failed to find job", err)
+	}
+	return r.fromModel(&model), nil
+}
+
+// FindActiveByKey 查找同类型同去重键的未结束任务
+func (r *jobRepository) FindActiveByKey(ctx context.Context, jobType, key string) (*job.Job, error) {
+	var model BackgroundJob
+	err := r.db.WithContext(ctx).
+		Where("type = ? AND job_key = ? AND status IN ?", jobType, key, []string{string(job.StatusPending), string(job.StatusRunning)}).
+		Order("created_at").
+		First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "job.find_active", "failed to find active job", err)
+	}
+	return r.fromModel(&model), nil
+}
+
+// List 分页查询任务，按创建时间倒序
+func (r *jobRepository) List(ctx context.Context, filter job.Filter) ([]*job.Job, int64, error) {
+	query := r.db.WithContext(ctx).Model(&BackgroundJob{})
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.Key != "" {
+		query = query.Where("job_key = ?", filter.Key)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "job.list", "failed to count jobs", err)
+	}
+
+	var models []BackgroundJob
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(filter.PageSize).Find(&models).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "job.list", "failed to list jobs", err)
+	}
+
+	items := make([]*job.Job, len(models))
+	for i := range models {
+		items[i] = r.fromModel(&models[i])
+	}
+	return items, total, nil
+}
+
+// Claim 领取最早到期的任务
+// 先查出候选任务，再以状态和执行次数为条件更新，更新不到说明已被其他工作协程领取，换下一个候选
+func (r *jobRepository) Claim(ctx context.Context, jobType string, now, leaseUntil time.Time) (*job.Job, error) {
+	for i := 0; i < claimAttempts; i++ {
+		var model BackgroundJob
+		err := r.db.WithContext(ctx).
+			Where("type = ? AND ((status = ? AND run_at <= ?) OR (status = ? AND lease_until < ?))",
+				jobType, string(job.StatusPending), now, string(job.StatusRunning), now).
+			Order("run_at").
+			First(&model).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, nil
+			}
+			return nil, errors.Wrap(errors.KindStorage, "job.claim", "failed to find claimable job", err)
+		}
+
+		result := r.db.WithContext(ctx).Model(&BackgroundJob{}).
+			Where("id = ? AND status = ? AND attempts = ?", model.ID, model.Status, model.Attempts).
+			Updates(map[string]interface{}{
+				"status":      string(job.StatusRunning),
+				"attempts":    model.Attempts + 1,
+				"lease_until": leaseUntil,
+				"started_at":  now,
+				"updated_at":  now,
+			})
+		if result.Error != nil {
+			return nil, errors.Wrap(errors.KindStorage, "job.claim", "failed to claim job", result.Error)
+		}
+		if result.RowsAffected == 1 {
+			model.Status = string(job.StatusRunning)
+			model.Attempts++
+			model.LeaseUntil = &leaseUntil
+			model.StartedAt = &now
+			model.UpdatedAt = now
+			return r.fromModel(&model), nil
+		}
+	}
+	return nil, nil
+}
+
+// Renew 为执行中的任务续约
+func (r *jobRepository) Renew(ctx context.Context, id string, attempt int, leaseUntil time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&BackgroundJob{}).
+		Where("id = ? AND status = ? AND attempts = ?", id, string(job.StatusRunning), attempt).
+		Updates(map[string]interface{}{
+			"lease_until": leaseUntil,
+			"updated_at":  time.Now(),
+		})
+	if result.Error != nil {
+		return false, errors.Wrap(errors.KindStorage, "job.renew", "failed to renew job lease", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// CountByStatus 按类型和状态统计任务数
+func (r *jobRepository) CountByStatus(ctx context.Context) (map[string]map[job.Status]int64, error) {
+	var rows []struct {
+		Type   string
+		Status string
+		Count  int64
+	}
+	if err := r.db.WithContext(ctx).Model(&BackgroundJob{}).
+		Select("type, status, COUNT(*) AS count").
+		Group("type, status").
+		Scan(&rows).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "job.count", "failed to count jobs", err)
+	}
+	counts := make(map[string]map[job.Status]int64)
+	for _, row := range rows {
+		if counts[row.Type] == nil {
+			counts[row.Type] = make(map[job.Status]int64)
+		}
+		counts[row.Type][job.Status(row.Status)] = row.Count
+	}
+	return counts, nil
+}
+
+// DeleteFinished 删除指定时间之前成功或取消的任务
+func (r *jobRepository) DeleteFinished(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("status IN ? AND finished_at < ?", []string{string(job.StatusSucceeded), string(job.StatusCanceled)}, before).
+		Delete(&BackgroundJob{})
+	if result.Error != nil {
+		return 0, errors.Wrap(errors.KindStorage, "job.delete_finished", "failed to delete finished jobs", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// toModel 将领域对象转换为存储模型
+func (r *jobRepository) toModel(j *job.Job) *BackgroundJob {
+	return &BackgroundJob{
+		ID:          j.ID,
+		Type:        j.Type,
+		Key:         j.Key,
+		Payload:     string(j.Payload),
+		Status:      string(j.Status),
+		Attempts:    j.Attempts,
+		MaxAttempts: j.MaxAttempts,
+		RunAt:       j.RunAt,
+		LeaseUntil:  j.LeaseUntil,
+		LastError:   j.LastError,
+		CreatedAt:   j.CreatedAt,
+		UpdatedAt:   j.UpdatedAt,
+		StartedAt:   j.StartedAt,
+		FinishedAt:  j.FinishedAt,
+	}
+}
+
+// fromModel 将存储模型转换为领域对象
+func (r *jobRepository) fromModel(m *BackgroundJob) *job.Job {
+	return &job.Job{
+		ID:          m.ID,
+		Type:        m.Type,
+		Key:         m.Key,
+		Payload:     []byte(m.Payload),
+		Status:      job.Status(m.Status),
+		Attempts:    m.Attempts,
+		MaxAttempts: m.MaxAttempts,
+		RunAt:       m.RunAt,
+		LeaseUntil:  m.LeaseUntil,
+		LastError:   m.LastError,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		StartedAt:   m.StartedAt,
+		FinishedAt:  m.FinishedAt,
+	}
+}
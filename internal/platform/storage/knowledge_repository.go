@@ -0,0 +1,416 @@
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/knowledge"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// KnowledgeBase 知识库存储模型
+type KnowledgeBase struct {
+	ID          string `gorm:"type:varchar(64);primaryKey"`
+	Name        string `gorm:"type:varchar(64);uniqueIndex;not null"`
+	Description string `gorm:"type:text"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TableName 指定表名
+func (KnowledgeBase) TableName() string {
+	return "knowledge_bases"
+}
+
+// KnowledgeDocument 知识库文档存储模型
+type KnowledgeDocument struct {
+	ID          string `gorm:"type:varchar(64);primaryKey"`
+	BaseID      string `gorm:"type:varchar(64);index;not null"`
+	Name        string `gorm:"type:varchar(255)"`
+	SourceType  string `gorm:"type:varchar(16)"`
+	Source      string `gorm:"type:text"`
+	Format      string `gorm:"type:varchar(16)"`
+	Size        int64
+	Status      string `gorm:"type:varchar(16);index"`
+	Progress    int
+	Chunks      int
+	Embedding   string    `gorm:"type:varchar(128)"`
+	ExecutionID string    `gorm:"type:varchar(64)"`
+	ObjectID    string    `gorm:"type:varchar(64)"`
+	Error       string    `gorm:"type:text"`
+	CreatedAt   time.Time `gorm:"index"`
+	UpdatedAt   time.Time
+}
+
+// TableName 指定表名
+func (KnowledgeDocument) TableName() string {
+	return "knowledge_documents"
+}
+
+// KnowledgeChunk 文档分块存储模型，向量按小端 float32 序列存储
+type KnowledgeChunk struct {
+	ID         string `gorm:"type:varchar(80);primaryKey"`
+	BaseID     string `gorm:"type:varchar(64);index;not null"`
+	DocumentID string `gorm:"type:varchar(64);index;not null"`
+	Index      int    `gorm:"column:chunk_index"`
+	Content    string `gorm:"type:text"`
+	Vector     []byte
+}
+
+// TableName 指定表名
+func (KnowledgeChunk) TableName() string {
+	return "knowledge_chunks"
+}
+
+// KnowledgeBinding 知识库档案绑定存储模型
+type KnowledgeBinding struct {
+	Profile         string `gorm:"type:varchar(64);primaryKey"`
+	KnowledgeBaseID string `gorm:"type:varchar(64);primaryKey"`
+	Position        int
+}
+
+// TableName 指定表名
+func (KnowledgeBinding) TableName() string {
+	return "knowledge_bindings"
+}
+
+// knowledgeRepository 知识库仓库实现
+type knowledgeRepository struct {
+	db *gorm.DB
+}
+
+// NewKnowledgeRepository 创建知识库仓库实例
+func NewKnowledgeRepository(db *gorm.DB) knowledge.Repository {
+	return &knowledgeRepository{
+		db: db,
+	}
+}
+
+// SaveBase 新建知识库
+func (r *knowledgeRepository) SaveBase(ctx context.Context, b *knowledge.Base) error {
+	if err := r.db.WithContext(ctx).Create(baseToModel(b)).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "knowledge.save_base", "failed to save knowledge base", err)
+	}
+	return nil
+}
+
+// UpdateBase 更新知识库
+func (r *knowledgeRepository) UpdateBase(ctx context.Context, b *knowledge.Base) error {
+	if err := r.db.WithContext(ctx).Save(baseToModel(b)).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "knowledge.update_base", "failed to update knowledge base", err)
+	}
+	return nil
+}
+
+// FindBase 根据ID查找知识库
+func (r *knowledgeRepository) FindBase(ctx context.Context, id string) (*knowledge.Base, error) {
+	return r.findBase(ctx, "id = ?", id)
+}
+
+// FindBaseByName 根据名称查找知识库
+func (r *knowledgeRepository) FindBaseByName(ctx context.Context, name string) (*knowledge.Base, error) {
+	return r.findBase(ctx, "name = ?", name)
+}
+
+func (r *knowledgeRepository) findBase(ctx context.Context, query string, arg interface{}) (*knowledge.Base, error) {
+	var model KnowledgeBase
+	if err := r.db.WithContext(ctx).Where(query, arg).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "knowledge.find_base", "failed to find knowledge base", err)
+	}
+	return baseFromModel(&model), nil
+}
+
+// ListBases 查询全部知识库
+func (r *knowledgeRepository) ListBases(ctx context.Context) ([]*knowledge.Base, error) {
+	var models []KnowledgeBase
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "knowledge.list_bases", "failed to list knowledge bases", err)
+	}
+	items := make([]*knowledge.Base, len(models))
+	for i := range models {
+		items[i] = baseFromModel(&models[i])
+	}
+	return items, nil
+}
+
+// DeleteBase 删除知识库及其文档、分块和档案绑定
+func (r *knowledgeRepository) DeleteBase(ctx context.Context, id string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("base_id = ?", id).Delete(&KnowledgeChunk{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("base_id = ?", id).Delete(&KnowledgeDocument{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("knowledge_base_id = ?", id).Delete(&KnowledgeBinding{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&KnowledgeBase{}).Error
+	})
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "knowledge.delete_base", "failed to delete knowledge base", err)
+	}
+	return nil
+}
+
+// SaveDocument 新建文档
+func (r *knowledgeRepository) SaveDocument(ctx context.Context, d *knowledge.Document) error {
+	if err := r.db.WithContext(ctx).Create(documentToModel(d)).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "knowledge.save_document", "failed to save knowledge document", err)
+	}
+	return nil
+}
+
+// UpdateDocument 更新文档的导入状态和进度，只更新已存在的记录
+func (r *knowledgeRepository) UpdateDocument(ctx context.Context, d *knowledge.Document) error {
+	err := r.db.WithContext(ctx).Model(&KnowledgeDocument{}).Where("id = ?", d.ID).Updates(map[string]interface{}{
+		"format":     string(d.Format),
+		"size":       d.Size,
+		"status":     string(d.Status),
+		"progress":   d.Progress,
+		"chunks":     d.Chunks,
+		"embedding":  d.Embedding,
+		"error":      d.Error,
+		"updated_at": d.UpdatedAt,
+	}).Error
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "knowledge.update_document", "failed to update knowledge document", err)
+	}
+	return nil
+}
+
+// SetExecutionID 记录文档当前导入任务的执行ID
+func (r *knowledgeRepository) SetExecutionID(ctx context.Context, id, executionID string) error {
+	if err := r.db.WithContext(ctx).Model(&KnowledgeDocument{}).Where("id = ?", id).Update("execution_id", executionID).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "knowledge.set_execution", "failed to update knowledge document", err)
+	}
+	return nil
+}
+
+// FindDocument 根据ID查找文档
+func (r *knowledgeRepository) FindDocument(ctx context.Context, id string) (*knowledge.Document, error) {
+	var model KnowledgeDocument
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "knowledge.find_document", "failed to find knowledge document", err)
+	}
+	return documentFromModel(&model), nil
+}
+
+// ListDocuments 查询知识库中的文档
+func (r *knowledgeRepository) ListDocuments(ctx context.Context, baseID string) ([]*knowledge.Document, error) {
+	var models []KnowledgeDocument
+	if err := r.db.WithContext(ctx).Where("base_id = ?", baseID).Order("created_at DESC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "knowledge.list_documents", "failed to list knowledge documents", err)
+	}
+	items := make([]*knowledge.Document, len(models))
+	for i := range models {
+		items[i] = documentFromModel(&models[i])
+	}
+	return items, nil
+}
+
+// DeleteDocument 删除文档及其分块
+func (r *knowledgeRepository) DeleteDocument(ctx context.Context, id string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("document_id = ?", id).Delete(&KnowledgeChunk{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&KnowledgeDocument{}).Error
+	})
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "knowledge.delete_document", "failed to delete knowledge document", err)
+	}
+	return nil
+}
+
+// FailUnfinished 将导入未结束的文档标记为失败
+func (r *knowledgeRepository) FailUnfinished(ctx context.Context, reason string) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&KnowledgeDocument{}).
+		Where("status NOT IN ?", []string{string(knowledge.StatusReady), string(knowledge.StatusFailed)}).
+		Updates(map[string]interface{}{"status": string(knowledge.StatusFailed), "error": reason, "updated_at": time.Now()})
+	if result.Error != nil {
+		return 0, errors.Wrap(errors.KindStorage, "knowledge.fail_unfinished", "failed to update unfinished knowledge documents", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// ReplaceChunks 用新的分块替换文档原有的分块
+func (r *knowledgeRepository) ReplaceChunks(ctx context.Context, documentID string, chunks []*knowledge.Chunk) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("document_id = ?", documentID).Delete(&KnowledgeChunk{}).Error; err != nil {
+			return err
+		}
+		if len(chunks) == 0 {
+			return nil
+		}
+		models := make([]KnowledgeChunk, len(chunks))
+		for i, c := range chunks {
+			models[i] = KnowledgeChunk{ID: c.ID, BaseID: c.BaseID, DocumentID: c.DocumentID, Index: c.Index, Content: c.Content, Vector: encodeVector(c.Vector)}
+		}
+		return tx.CreateInBatches(models, 100).Error
+	})
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "knowledge.replace_chunks", "failed to replace knowledge chunks", err)
+	}
+	return nil
+}
+
+// ListChunks 查询文档的分块
+func (r *knowledgeRepository) ListChunks(ctx context.Context, documentID string) ([]*knowledge.Chunk, error) {
+	var models []KnowledgeChunk
+	if err := r.db.WithContext(ctx).Where("document_id = ?", documentID).Order("chunk_index ASC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "knowledge.list_chunks", "failed to list knowledge chunks", err)
+	}
+	return chunksFromModels(models), nil
+}
+
+// ListBaseChunks 查询知识库中已导入完成的文档的分块
+func (r *knowledgeRepository) ListBaseChunks(ctx context.Context, baseID string) ([]*knowledge.Chunk, error) {
+	ready := r.db.Model(&KnowledgeDocument{}).Select("id").Where("base_id = ? AND status = ?", baseID, string(knowledge.StatusReady))
+	var models []KnowledgeChunk
+	if err := r.db.WithContext(ctx).Where("document_id IN (?)", ready).Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "knowledge.list_base_chunks", "failed to list knowledge chunks", err)
+	}
+	return chunksFromModels(models), nil
+}
+
+// SaveVectors 保存分块的向量
+func (r *knowledgeRepository) SaveVectors(ctx context.Context, chunks []*knowledge.Chunk) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, c := range chunks {
+			if err := tx.Model(&KnowledgeChunk{}).Where("id = ?", c.ID).Update("vector", encodeVector(c.Vector)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "knowledge.save_vectors", "failed to save knowledge vectors", err)
+	}
+	return nil
+}
+
+// ListBindings 查询全部档案绑定
+func (r *knowledgeRepository) ListBindings(ctx context.Context) (map[string][]string, error) {
+	var models []KnowledgeBinding
+	if err := r.db.WithContext(ctx).Order("profile ASC, position ASC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "knowledge.list_bindings", "failed to list knowledge bindings", err)
+	}
+	bindings := make(map[string][]string)
+	for _, m := range models {
+		bindings[m.Profile] = append(bindings[m.Profile], m.KnowledgeBaseID)
+	}
+	return bindings, nil
+}
+
+// SetBindings 设置档案绑定的知识库
+func (r *knowledgeRepository) SetBindings(ctx context.Context, profile string, baseIDs []string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("profile = ?", profile).Delete(&KnowledgeBinding{}).Error; err != nil {
+			return err
+		}
+		if len(baseIDs) == 0 {
+			return nil
+		}
+		models := make([]KnowledgeBinding, len(baseIDs))
+		for i, id := range baseIDs {
+			models[i] = KnowledgeBinding{Profile: profile, KnowledgeBaseID: id, Position: i}
+		}
+		return tx.Create(&models).Error
+	})
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "knowledge.set_bindings", "failed to set knowledge bindings", err)
+	}
+	return nil
+}
+
+func baseToModel(b *knowledge.Base) *KnowledgeBase {
+	return &KnowledgeBase{ID: b.ID, Name: b.Name, Description: b.Description, CreatedAt: b.CreatedAt, UpdatedAt: b.UpdatedAt}
+}
+
+func baseFromModel(m *KnowledgeBase) *knowledge.Base {
+	return &knowledge.Base{ID: m.ID, Name: m.Name, Description: m.Description, CreatedAt: m.CreatedAt, UpdatedAt: m.UpdatedAt}
+}
+
+func documentToModel(d *knowledge.Document) *KnowledgeDocument {
+	return &KnowledgeDocument{
+		ID:          d.ID,
+		BaseID:      d.BaseID,
+		Name:        d.Name,
+		SourceType:  string(d.SourceType),
+		Source:      d.Source,
+		Format:      string(d.Format),
+		Size:        d.Size,
+		Status:      string(d.Status),
+		Progress:    d.Progress,
+		Chunks:      d.Chunks,
+		Embedding:   d.Embedding,
+		ExecutionID: d.ExecutionID,
+		ObjectID:    d.ObjectID,
+		Error:       d.Error,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+	}
+}
+
+func documentFromModel(m *KnowledgeDocument) *knowledge.Document {
+	return &knowledge.Document{
+		ID:          m.ID,
+		BaseID:      m.BaseID,
+		Name:        m.Name,
+		SourceType:  knowledge.SourceType(m.SourceType),
+		Source:      m.Source,
+		Format:      knowledge.Format(m.Format),
+		Size:        m.Size,
+		Status:      knowledge.Status(m.Status),
+		Progress:    m.Progress,
+		Chunks:      m.Chunks,
+		Embedding:   m.Embedding,
+		ExecutionID: m.ExecutionID,
+		ObjectID:    m.ObjectID,
+		Error:       m.Error,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+func chunksFromModels(models []KnowledgeChunk) []*knowledge.Chunk {
+	items := make([]*knowledge.Chunk, len(models))
+	for i, m := range models {
+		items[i] = &knowledge.Chunk{ID: m.ID, BaseID: m.BaseID, DocumentID: m.DocumentID, Index: m.Index, Content: m.Content, Vector: decodeVector(m.Vector)}
+	}
+	return items
+}
+
+// encodeVector 把向量编码为小端 float32 序列
+func encodeVector(v []float32) []byte {
+	if len(v) == 0 {
+		return nil
+	}
+	buf := make([]byte, 4*len(v))
+	for i, x := range v {
+		binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(x))
+	}
+	return buf
+}
+
+func decodeVector(buf []byte) []float32 {
+	if len(buf) < 4 {
+		return nil
+	}
+	v := make([]float32, len(buf)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[i*4:]))
+	}
+	return v
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/language"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// DeviceLanguage 设备允许语言存储模型
+type DeviceLanguage struct {
+	DeviceID  string    `gorm:"type:varchar(255);primaryKey"`
+	Languages string    `gorm:"type:text"` // JSON
+	UpdatedAt time.Time `gorm:"autoUpdateTime:false"`
+}
+
+// TableName 指定表名
+func (DeviceLanguage) TableName() string {
+	return "device_languages"
+}
+
+// languageRepository 设备允许语言仓库实现
+type languageRepository struct {
+	db *gorm.DB
+}
+
+// NewLanguageRepository 创建设备允许语言仓库实例
+func NewLanguageRepository(db *gorm.DB) language.Repository {
+	return &languageRepository{
+		db: db,
+	}
+}
+
+// Find 查找设备的允许语言
+func (r *languageRepository) Find(ctx context.Context, deviceID string) (*language.DeviceLanguages, error) {
+	var model DeviceLanguage
+	if err := r.db.WithContext(ctx).Where("device_id = ?", deviceID).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "language.find", "failed to find device languages", err)
+	}
+	d := &language.DeviceLanguages{DeviceID: model.DeviceID, UpdatedAt: model.UpdatedAt}
+	if model.Languages != "" {
+		_ = json.Unmarshal([]byte(model.Languages), &d.Languages)
+	}
+	return d, nil
+}
+
+// Save 保存或覆盖设备的允许语言
+func (r *languageRepository) Save(ctx context.Context, d *language.DeviceLanguages) error {
+	data, err := json.Marshal(d.Languages)
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "language.save", "failed to encode device languages", err)
+	}
+	model := &DeviceLanguage{
+		DeviceID:  d.DeviceID,
+		Languages: string(data),
+		UpdatedAt: d.UpdatedAt,
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "language.save", "failed to save device languages", err)
+	}
+	return nil
+}
+
+// Delete 删除设备的允许语言
+func (r *languageRepository) Delete(ctx context.Context, deviceID string) error {
+	if err := r.db.WithContext(ctx).Where("device_id = ?", deviceID).Delete(&DeviceLanguage{}).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "language.delete", "failed to delete device languages", err)
+	}
+	return nil
+}
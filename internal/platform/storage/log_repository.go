@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+// LogEntry 日志索引存储模型
+type LogEntry struct {
+	ID       uint      `gorm:"primaryKey"`
+	Time     time.Time `gorm:"index"`
+	Level    string    `gorm:"type:varchar(8)"`
+	Severity int       `gorm:"index"` // slog.Level 数值，用于按最低级别过滤
+	Tag      string    `gorm:"type:varchar(64);index"`
+	Message  string    `gorm:"type:text"`
+	Attrs    string    `gorm:"type:text"`
+}
+
+// TableName 指定表名
+func (LogEntry) TableName() string {
+	return "log_entries"
+}
+
+// logRepository 日志索引仓库实现
+type logRepository struct {
+	db *gorm.DB
+}
+
+// NewLogRepository 创建日志索引仓库实例
+func NewLogRepository(db *gorm.DB) logging.EntryStore {
+	return &logRepository{
+		db: db,
+	}
+}
+
+// SaveEntries 批量保存日志
+func (r *logRepository) SaveEntries(ctx context.Context, entries []logging.Entry) error {
+	models := make([]LogEntry, len(entries))
+	for i, entry := range entries {
+		models[i] = LogEntry{
+			Time:     entry.Time,
+			Level:    entry.Level,
+			Severity: int(logging.ParseLevel(entry.Level)),
+			Tag:      entry.Tag,
+			Message:  entry.Message,
+		}
+		if len(entry.Attrs) > 0 {
+			if data, err := json.Marshal(entry.Attrs); err == nil {
+				models[i].Attrs = string(data)
+			}
+		}
+	}
+	if err := r.db.WithContext(ctx).CreateInBatches(models, 100).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "log.save_entries", "failed to save log entries", err)
+	}
+	return nil
+}
+
+// QueryEntries 按条件分页查询日志，最新的在前
+func (r *logRepository) QueryEntries(ctx context.Context, filter logging.Query) ([]logging.Entry, int64, error) {
+	query := r.db.WithContext(ctx).Model(&LogEntry{}).Where("severity >= ?", int(filter.MinLevel))
+	if filter.Tag != "" {
+		query = query.Where("tag = ?", filter.Tag)
+	}
+	if filter.Text != "" {
+		like := "%" + filter.Text + "%"
+		query = query.Where("message LIKE ? OR attrs LIKE ?", like, like)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("time >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("time <= ?", filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "log.query_entries", "failed to count log entries", err)
+	}
+
+	var models []LogEntry
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := query.Order("time DESC, id DESC").Offset(offset).Limit(filter.PageSize).Find(&models).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "log.query_entries", "failed to query log entries", err)
+	}
+
+	entries := make([]logging.Entry, len(models))
+	for i := range models {
+		entries[i] = r.fromModel(&models[i])
+	}
+	return entries, total, nil
+}
+
+// DeleteEntriesBefore 删除指定时间之前的日志
+func (r *logRepository) DeleteEntriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("time < ?", before).Delete(&LogEntry{})
+	if result.Error != nil {
+		return 0, errors.Wrap(errors.KindStorage, "log.delete_before", "failed to purge log entries", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *logRepository) fromModel(m *LogEntry) logging.Entry {
+	entry := logging.Entry{
+		Time:    m.Time,
+		Level:   m.Level,
+		Tag:     m.Tag,
+		Message: m.Message,
+	}
+	if m.Attrs != "" {
+		_ = json.Unmarshal([]byte(m.Attrs), &entry.Attrs)
+	}
+	return entry
+}
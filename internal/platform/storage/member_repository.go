@@ -0,0 +1,180 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"xiaozhi-server-go/internal/domain/member"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// UserDevice 用户与设备的绑定存储模型
+type UserDevice struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;uniqueIndex:idx_user_device"`
+	DeviceID  string `gorm:"type:varchar(255);not null;uniqueIndex:idx_user_device;index"`
+	Role      string `gorm:"type:varchar(16);not null"`
+	CreatedAt time.Time
+}
+
+// TableName 指定表名
+func (UserDevice) TableName() string {
+	return "user_devices"
+}
+
+// MemberProfile 成员资料存储模型
+type MemberProfile struct {
+	UserID          uint   `gorm:"primaryKey;autoIncrement:false"`
+	Name            string `gorm:"type:varchar(64)"`
+	Voice           string `gorm:"type:varchar(128)"`
+	Language        string `gorm:"type:varchar(32)"`
+	MemoryNamespace string `gorm:"type:varchar(128)"`
+	UpdatedAt       time.Time
+}
+
+// TableName 指定表名
+func (MemberProfile) TableName() string {
+	return "member_profiles"
+}
+
+// memberRepository 成员仓库实现
+type memberRepository struct {
+	db *gorm.DB
+}
+
+// NewMemberRepository 创建成员仓库实例
+func NewMemberRepository(db *gorm.DB) member.Repository {
+	return &memberRepository{
+		db: db,
+	}
+}
+
+// UserExists 判断用户是否存在
+func (r *memberRepository) UserExists(ctx context.Context, userID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&User{}).Where("id = ?", userID).Count(&count).Error; err != nil {
+		return false, errors.Wrap(errors.KindStorage, "member.user_exists", "failed to query user", err)
+	}
+	return count > 0, nil
+}
+
+// DeviceTenant 返回设备所属的租户
+func (r *memberRepository) DeviceTenant(ctx context.Context, deviceID string) (string, bool, error) {
+	var tenants []string
+	if err := r.db.WithContext(ctx).Model(&Device{}).Where("device_id = ?", deviceID).Limit(1).Pluck("tenant_id", &tenants).Error; err != nil {
+		return "", false, errors.Wrap(errors.KindStorage, "member.device_tenant", "failed to query device", err)
+	}
+	if len(tenants) == 0 {
+		return "", false, nil
+	}
+	return tenants[0], true, nil
+}
+
+// Bind 创建或更新绑定，所有者同步写入设备的 user_id
+func (r *memberRepository) Bind(ctx context.Context, b *member.Binding) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		row := &UserDevice{UserID: b.UserID, DeviceID: b.DeviceID, Role: string(b.Role), CreatedAt: b.CreatedAt}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "device_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"role"}),
+		}).Create(row).Error; err != nil {
+			return err
+		}
+		devices := tx.Model(&Device{}).Where("device_id = ?", b.DeviceID)
+		if b.Role == member.RoleOwner {
+			return devices.Update("user_id", b.UserID).Error
+		}
+		// 所有者降为成员时设备不再有所有者
+		return devices.Where("user_id = ?", b.UserID).Update("user_id", nil).Error
+	})
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "member.bind", "failed to bind device", err)
+	}
+	return nil
+}
+
+// Unbind 删除绑定，解除所有者时清空设备的 user_id
+func (r *memberRepository) Unbind(ctx context.Context, userID uint, deviceID string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND device_id = ?", userID, deviceID).Delete(&UserDevice{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&Device{}).Where("device_id = ? AND user_id = ?", deviceID, userID).Update("user_id", nil).Error
+	})
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "member.unbind", "failed to unbind device", err)
+	}
+	return nil
+}
+
+// ListByUser 列出用户绑定的设备
+func (r *memberRepository) ListByUser(ctx context.Context, userID uint) ([]*member.Binding, error) {
+	var models []UserDevice
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at ASC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "member.list_by_user", "failed to list user devices", err)
+	}
+	return toBindings(models), nil
+}
+
+// ListByDevice 列出设备的全部绑定，所有者在前
+func (r *memberRepository) ListByDevice(ctx context.Context, deviceID string) ([]*member.Binding, error) {
+	var models []UserDevice
+	err := r.db.WithContext(ctx).Where("device_id = ?", deviceID).
+		Order(clause.Expr{SQL: "CASE WHEN role = ? THEN 0 ELSE 1 END, created_at ASC", Vars: []interface{}{string(member.RoleOwner)}}).
+		Find(&models).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "member.list_by_device", "failed to list device members", err)
+	}
+	return toBindings(models), nil
+}
+
+// FindProfile 查找成员资料
+func (r *memberRepository) FindProfile(ctx context.Context, userID uint) (*member.Profile, error) {
+	var model MemberProfile
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "member.find_profile", "failed to find member profile", err)
+	}
+	return &member.Profile{
+		UserID:          model.UserID,
+		Name:            model.Name,
+		Voice:           model.Voice,
+		Language:        model.Language,
+		MemoryNamespace: model.MemoryNamespace,
+		UpdatedAt:       model.UpdatedAt,
+	}, nil
+}
+
+// SaveProfile 创建或更新成员资料
+func (r *memberRepository) SaveProfile(ctx context.Context, p *member.Profile) error {
+	model := &MemberProfile{
+		UserID:          p.UserID,
+		Name:            p.Name,
+		Voice:           p.Voice,
+		Language:        p.Language,
+		MemoryNamespace: p.MemoryNamespace,
+		UpdatedAt:       p.UpdatedAt,
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "member.save_profile", "failed to save member profile", err)
+	}
+	return nil
+}
+
+func toBindings(models []UserDevice) []*member.Binding {
+	bindings := make([]*member.Binding, len(models))
+	for i, m := range models {
+		bindings[i] = &member.Binding{
+			UserID:    m.UserID,
+			DeviceID:  m.DeviceID,
+			Role:      member.Role(m.Role),
+			CreatedAt: m.CreatedAt,
+		}
+	}
+	return bindings
+}
@@ -0,0 +1,222 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/memory"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// MemoryFact 长期记忆存储模型
+type MemoryFact struct {
+	ID        string `gorm:"type:varchar(64);primaryKey"`
+	Namespace string `gorm:"type:varchar(128);index;not null"`
+	UserID    uint   `gorm:"index"`
+	Content   string `gorm:"type:text"`
+	Source    string `gorm:"type:varchar(16)"`
+	DeviceID  string `gorm:"type:varchar(255);index"`
+	SessionID string `gorm:"type:varchar(255)"`
+	EntryID   string `gorm:"type:varchar(64)"`
+	Evidence  string `gorm:"type:text"`
+	CreatedAt time.Time
+	UpdatedAt time.Time `gorm:"index"`
+}
+
+// TableName 指定表名
+func (MemoryFact) TableName() string {
+	return "memory_facts"
+}
+
+// MemoryConsent 用户记忆提取授权存储模型
+type MemoryConsent struct {
+	UserID    uint `gorm:"primaryKey;autoIncrement:false"`
+	Granted   bool `gorm:"default:false"`
+	UpdatedAt time.Time
+}
+
+// TableName 指定表名
+func (MemoryConsent) TableName() string {
+	return "memory_consents"
+}
+
+// memoryRepository 长期记忆仓库实现
+type memoryRepository struct {
+	db *gorm.DB
+}
+
+// NewMemoryRepository 创建长期记忆仓库实例
+func NewMemoryRepository(db *gorm.DB) memory.Repository {
+	return &memoryRepository{
+		db: db,
+	}
+}
+
+// SaveFact 新建记忆
+func (r *memoryRepository) SaveFact(ctx context.Context, f *memory.Fact) error {
+	if err := r.db.WithContext(ctx).Create(r.toModel(f)).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "memory.save", "failed to save memory fact", err)
+	}
+	return nil
+}
+
+// UpdateFact 更新记忆
+func (r *memoryRepository) UpdateFact(ctx context.Context, f *memory.Fact) error {
+	if err := r.db.WithContext(ctx).Save(r.toModel(f)).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "memory.update", "failed to update memory fact", err)
+	}
+	return nil
+}
+
+// FindFact 根据ID查找记忆
+func (r *memoryRepository) FindFact(ctx context.Context, id string) (*memory.Fact, error) {
+	var model MemoryFact
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "memory.find", "failed to find memory fact", err)
+	}
+	return r.fromModel(&model), nil
+}
+
+// ListFacts 按条件分页查询记忆
+func (r *memoryRepository) ListFacts(ctx context.Context, filter memory.Filter) ([]*memory.Fact, int64, error) {
+	query := r.db.WithContext(ctx).Model(&MemoryFact{}).Where("namespace = ?", filter.Namespace)
+	if filter.Query != "" {
+		query = query.Where("content LIKE ?", "%"+filter.Query+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "memory.list", "failed to count memory facts", err)
+	}
+
+	var models []MemoryFact
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := query.Order("updated_at DESC").Offset(offset).Limit(filter.PageSize).Find(&models).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "memory.list", "failed to list memory facts", err)
+	}
+	return r.fromModels(models), total, nil
+}
+
+// AllFacts 查询命名空间的全部记忆
+func (r *memoryRepository) AllFacts(ctx context.Context, namespace string) ([]*memory.Fact, error) {
+	var models []MemoryFact
+	if err := r.db.WithContext(ctx).Where("namespace = ?", namespace).Order("updated_at DESC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "memory.list_all", "failed to list memory facts", err)
+	}
+	return r.fromModels(models), nil
+}
+
+// DeleteFact 删除记忆
+func (r *memoryRepository) DeleteFact(ctx context.Context, id string) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&MemoryFact{}).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "memory.delete", "failed to delete memory fact", err)
+	}
+	return nil
+}
+
+// DeleteNamespace 删除命名空间的全部记忆
+func (r *memoryRepository) DeleteNamespace(ctx context.Context, namespace string) (int64, error) {
+	result := r.db.WithContext(ctx).Where("namespace = ?", namespace).Delete(&MemoryFact{})
+	if result.Error != nil {
+		return 0, errors.Wrap(errors.KindStorage, "memory.delete_namespace", "failed to delete memory facts", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// DeleteDevice 删除提取自设备对话的全部记忆
+func (r *memoryRepository) DeleteDevice(ctx context.Context, deviceID string) (int64, error) {
+	result := r.db.WithContext(ctx).Where("device_id = ?", deviceID).Delete(&MemoryFact{})
+	if result.Error != nil {
+		return 0, errors.Wrap(errors.KindStorage, "memory.delete_device", "failed to delete device memory facts", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// TrimNamespace 只保留命名空间中最近更新的 keep 条记忆
+func (r *memoryRepository) TrimNamespace(ctx context.Context, namespace string, keep int) (int64, error) {
+	var ids []string
+	if err := r.db.WithContext(ctx).Model(&MemoryFact{}).
+		Where("namespace = ?", namespace).
+		Order("updated_at DESC").
+		Offset(keep).
+		Limit(-1).
+		Pluck("id", &ids).Error; err != nil {
+		return 0, errors.Wrap(errors.KindStorage, "memory.trim", "failed to list stale memory facts", err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&MemoryFact{})
+	if result.Error != nil {
+		return 0, errors.Wrap(errors.KindStorage, "memory.trim", "failed to delete stale memory facts", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// GetConsent 获取用户授权
+func (r *memoryRepository) GetConsent(ctx context.Context, userID uint) (*memory.Consent, error) {
+	var model MemoryConsent
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "memory.get_consent", "failed to get memory consent", err)
+	}
+	return &memory.Consent{UserID: model.UserID, Granted: model.Granted, UpdatedAt: model.UpdatedAt}, nil
+}
+
+// SaveConsent 保存用户授权
+func (r *memoryRepository) SaveConsent(ctx context.Context, c *memory.Consent) error {
+	model := &MemoryConsent{UserID: c.UserID, Granted: c.Granted, UpdatedAt: c.UpdatedAt}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "memory.save_consent", "failed to save memory consent", err)
+	}
+	return nil
+}
+
+func (r *memoryRepository) fromModels(models []MemoryFact) []*memory.Fact {
+	items := make([]*memory.Fact, len(models))
+	for i := range models {
+		items[i] = r.fromModel(&models[i])
+	}
+	return items
+}
+
+// toModel 将领域对象转换为存储模型
+func (r *memoryRepository) toModel(f *memory.Fact) *MemoryFact {
+	return &MemoryFact{
+		ID:        f.ID,
+		Namespace: f.Namespace,
+		UserID:    f.UserID,
+		Content:   f.Content,
+		Source:    string(f.Source),
+		DeviceID:  f.DeviceID,
+		SessionID: f.SessionID,
+		EntryID:   f.EntryID,
+		Evidence:  f.Evidence,
+		CreatedAt: f.CreatedAt,
+		UpdatedAt: f.UpdatedAt,
+	}
+}
+
+// fromModel 将存储模型转换为领域对象
+func (r *memoryRepository) fromModel(m *MemoryFact) *memory.Fact {
+	return &memory.Fact{
+		ID:        m.ID,
+		Namespace: m.Namespace,
+		UserID:    m.UserID,
+		Content:   m.Content,
+		Source:    memory.Source(m.Source),
+		DeviceID:  m.DeviceID,
+		SessionID: m.SessionID,
+		EntryID:   m.EntryID,
+		Evidence:  m.Evidence,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// Migration005PluginHealthChecks 插件健康探测记录表迁移
+type Migration005PluginHealthChecks struct{}
+
+func (m *Migration005PluginHealthChecks) Version() string {
+	return "005_plugin_health_checks"
+}
+
+func (m *Migration005PluginHealthChecks) Description() string {
+	return "Create plugin provider health check history table"
+}
+
+func (m *Migration005PluginHealthChecks) Up(db *gorm.DB) error {
+	// 创建健康探测记录表
+	if err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS plugin_provider_health_checks (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			provider_config_id INTEGER NOT NULL,           -- 外键关联供应商配置
+			success BOOLEAN NOT NULL DEFAULT FALSE,        -- 本次探测是否成功
+			latency_ms INTEGER DEFAULT 0,                  -- 探测耗时（毫秒）
+			error TEXT,                                    -- 失败原因
+			status VARCHAR(50),                            -- 探测后的健康状态
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY(provider_config_id) REFERENCES plugin_provider_configs(id) ON DELETE CASCADE
+		)
+	`).Error; err != nil {
+		return err
+	}
+
+	// 创建索引
+	if err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_plugin_health_checks_config_id ON plugin_provider_health_checks(provider_config_id)`).Error; err != nil {
+		return err
+	}
+	if err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_plugin_health_checks_success ON plugin_provider_health_checks(success)`).Error; err != nil {
+		return err
+	}
+	if err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_plugin_health_checks_created_at ON plugin_provider_health_checks(created_at)`).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Migration005PluginHealthChecks) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS plugin_provider_health_checks`).Error; err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// Migration006PluginQuotas 插件配额策略与用量表迁移
+type Migration006PluginQuotas struct{}
+
+func (m *Migration006PluginQuotas) Version() string {
+	return "006_plugin_quotas"
+}
+
+func (m *Migration006PluginQuotas) Description() string {
+	return "Create plugin quota policy and daily usage tables"
+}
+
+func (m *Migration006PluginQuotas) Up(db *gorm.DB) error {
+	// 创建配额策略表
+	if err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS plugin_quota_policies (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			scope VARCHAR(20) NOT NULL,                    -- 作用范围：provider, capability
+			target VARCHAR(255) NOT NULL,                  -- 提供者类型或能力ID
+			max_concurrency INTEGER DEFAULT 0,             -- 并发上限，0 表示不限制
+			daily_requests INTEGER DEFAULT 0,              -- 每日请求上限
+			daily_tokens INTEGER DEFAULT 0,                -- 每日Token上限
+			daily_audio_minutes REAL DEFAULT 0,            -- 每日音频分钟上限
+			enabled BOOLEAN DEFAULT TRUE,                  -- 是否启用
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE(scope, target)
+		)
+	`).Error; err != nil {
+		return err
+	}
+
+	// 创建每日用量表
+	if err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS plugin_quota_usage (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			scope VARCHAR(20) NOT NULL,                    -- 作用范围
+			target VARCHAR(255) NOT NULL,                  -- 提供者类型或能力ID
+			day VARCHAR(10) NOT NULL,                      -- 日期（YYYY-MM-DD）
+			requests INTEGER DEFAULT 0,                    -- 请求数
+			tokens INTEGER DEFAULT 0,                      -- Token数
+			audio_seconds REAL DEFAULT 0,                  -- 音频秒数
+			UNIQUE(scope, target, day)
+		)
+	`).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Migration006PluginQuotas) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS plugin_quota_usage`).Error; err != nil {
+		return err
+	}
+	if err := db.Exec(`DROP TABLE IF EXISTS plugin_quota_policies`).Error; err != nil {
+		return err
+	}
+	return nil
+}
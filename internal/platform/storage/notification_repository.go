@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/notification"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// NotificationChannel 通知渠道存储模型
+type NotificationChannel struct {
+	ID        string `gorm:"type:varchar(64);primaryKey"`
+	Name      string `gorm:"type:varchar(255);not null"`
+	Type      string `gorm:"type:varchar(32);index;not null"`
+	Config    string `gorm:"type:text"` // JSON
+	Events    string `gorm:"type:text"` // JSON 数组
+	Enabled   bool   `gorm:"default:true"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName 指定表名
+func (NotificationChannel) TableName() string {
+	return "notification_channels"
+}
+
+// notificationRepository 通知渠道仓库实现
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository 创建通知渠道仓库实例
+func NewNotificationRepository(db *gorm.DB) notification.Repository {
+	return &notificationRepository{
+		db: db,
+	}
+}
+
+// Save 保存渠道
+func (r *notificationRepository) Save(ctx context.Context, c *notification.Channel) error {
+	model, err := r.toModel(c)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "notification.save", "failed to save notification channel", err)
+	}
+	return nil
+}
+
+// Update 更新渠道
+func (r *notificationRepository) Update(ctx context.Context, c *notification.Channel) error {
+	model, err := r.toModel(c)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "notification.update", "failed to update notification channel", err)
+	}
+	return nil
+}
+
+// FindByID 根据ID查找渠道
+func (r *notificationRepository) FindByID(ctx context.Context, id string) (*notification.Channel, error) {
+	var model NotificationChannel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "notification.find_by_id", "failed to find notification channel", err)
+	}
+	return r.fromModel(&model), nil
+}
+
+// List 查询全部渠道
+func (r *notificationRepository) List(ctx context.Context) ([]*notification.Channel, error) {
+	var models []NotificationChannel
+	if err := r.db.WithContext(ctx).Order("created_at ASC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "notification.list", "failed to list notification channels", err)
+	}
+
+	items := make([]*notification.Channel, len(models))
+	for i := range models {
+		items[i] = r.fromModel(&models[i])
+	}
+	return items, nil
+}
+
+// Delete 删除渠道
+func (r *notificationRepository) Delete(ctx context.Context, id string) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&NotificationChannel{}).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "notification.delete", "failed to delete notification channel", err)
+	}
+	return nil
+}
+
+// toModel 将领域对象转换为存储模型
+func (r *notificationRepository) toModel(c *notification.Channel) (*NotificationChannel, error) {
+	config, err := json.Marshal(c.Config)
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "notification.encode", "failed to encode channel config", err)
+	}
+	events, err := json.Marshal(c.Events)
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "notification.encode", "failed to encode channel events", err)
+	}
+	return &NotificationChannel{
+		ID:        c.ID,
+		Name:      c.Name,
+		Type:      string(c.Type),
+		Config:    string(config),
+		Events:    string(events),
+		Enabled:   c.Enabled,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}, nil
+}
+
+// fromModel 将存储模型转换为领域对象
+func (r *notificationRepository) fromModel(model *NotificationChannel) *notification.Channel {
+	c := &notification.Channel{
+		ID:        model.ID,
+		Name:      model.Name,
+		Type:      notification.ChannelType(model.Type),
+		Config:    map[string]interface{}{},
+		Events:    []string{},
+		Enabled:   model.Enabled,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}
+	if model.Config != "" {
+		json.Unmarshal([]byte(model.Config), &c.Config)
+	}
+	if model.Events != "" {
+		json.Unmarshal([]byte(model.Events), &c.Events)
+	}
+	return c
+}
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"xiaozhi-server-go/internal/domain/objectstore"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// localObjectBackend 本地磁盘对象存储
+type localObjectBackend struct {
+	dir string
+}
+
+// NewLocalObjectBackend 创建本地磁盘对象存储
+func NewLocalObjectBackend(dir string) (objectstore.Backend, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("创建对象存储目录失败: %w", err)
+	}
+	return &localObjectBackend{dir: dir}, nil
+}
+
+// Name 后端名称
+func (b *localObjectBackend) Name() string {
+	return "local"
+}
+
+// Put 写入内容，先写临时文件再重命名，避免留下不完整的文件
+func (b *localObjectBackend) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	path, err := b.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return errors.Wrap(errors.KindStorage, "object.local_put", "failed to create directory", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "object.local_put", "failed to create file", err)
+	}
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return errors.Wrap(errors.KindStorage, "object.local_put", "failed to write object", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrap(errors.KindStorage, "object.local_put", "failed to write object", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrap(errors.KindStorage, "object.local_put", "failed to write object", err)
+	}
+	return nil
+}
+
+// Open 打开内容
+func (b *localObjectBackend) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := b.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Wrap(errors.KindStorage, "object.local_open", "object content missing", objectstore.ErrNotFound)
+		}
+		return nil, errors.Wrap(errors.KindStorage, "object.local_open", "failed to open object", err)
+	}
+	return f, nil
+}
+
+// Delete 删除内容，不存在时不报错
+func (b *localObjectBackend) Delete(ctx context.Context, key string) error {
+	path, err := b.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(errors.KindStorage, "object.local_delete", "failed to delete object", err)
+	}
+	return nil
+}
+
+// path 将键转换为目录内的文件路径，拒绝越出目录的键
+func (b *localObjectBackend) path(key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", errors.New(errors.KindStorage, "object.local_path", "invalid object key: "+key)
+	}
+	return filepath.Join(b.dir, clean), nil
+}
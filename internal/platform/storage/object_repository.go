@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/objectstore"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// StoredObject 对象存储元数据模型
+type StoredObject struct {
+	ID          string `gorm:"type:varchar(64);primaryKey"`
+	Namespace   string `gorm:"type:varchar(32);index;not null"`
+	Name        string `gorm:"type:varchar(255);index"`
+	ContentType string `gorm:"type:varchar(128)"`
+	Size        int64
+	Refs        int        `gorm:"default:0"`
+	ExpiresAt   *time.Time `gorm:"index"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time `gorm:"index"`
+}
+
+// TableName 指定表名
+func (StoredObject) TableName() string {
+	return "stored_objects"
+}
+
+// objectRepository 对象元数据仓库实现
+type objectRepository struct {
+	db *gorm.DB
+}
+
+// NewObjectRepository 创建对象元数据仓库实例
+func NewObjectRepository(db *gorm.DB) objectstore.Repository {
+	return &objectRepository{
+		db: db,
+	}
+}
+
+// Save 保存新对象
+func (r *objectRepository) Save(ctx context.Context, o *objectstore.Object) error {
+	if err := r.db.WithContext(ctx).Create(r.toModel(o)).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "object.save", "failed to save object", err)
+	}
+	return nil
+}
+
+// Update 更新对象
+func (r *objectRepository) Update(ctx context.Context, o *objectstore.Object) error {
+	if err := r.db.WithContext(ctx).Save(r.toModel(o)).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "object.update", "failed to update object", err)
+	}
+	return nil
+}
+
+// Find 根据ID查找对象
+func (r *objectRepository) Find(ctx context.Context, id string) (*objectstore.Object, error) {
+	var model StoredObject
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "object.find", "failed to find object", err)
+	}
+	return r.fromModel(&model), nil
+}
+
+// List 分页查询对象，按更新时间倒序
+func (r *objectRepository) List(ctx context.Context, filter objectstore.Filter) ([]*objectstore.Object, int64, error) {
+	query := r.db.WithContext(ctx).Model(&StoredObject{})
+	if filter.Namespace != "" {
+		query = query.Where("namespace = ?", filter.Namespace)
+	}
+	if filter.Name != "" {
+		query = query.Where("name = ?", filter.Name)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "object.list", "failed to count objects", err)
+	}
+
+	var models []StoredObject
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := query.Order("updated_at DESC").Offset(offset).Limit(filter.PageSize).Find(&models).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "object.list", "failed to list objects", err)
+	}
+
+	items := make([]*objectstore.Object, len(models))
+	for i := range models {
+		items[i] = r.fromModel(&models[i])
+	}
+	return items, total, nil
+}
+
+// ListExpired 查询无引用且在指定时间之前到期的对象；无引用也无到期时间的是释放时未能删除的长期对象
+func (r *objectRepository) ListExpired(ctx context.Context, before time.Time, limit int) ([]*objectstore.Object, error) {
+	var models []StoredObject
+	if err := r.db.WithContext(ctx).
+		Where("refs <= 0 AND (expires_at IS NULL OR expires_at <= ?)", before).
+		Order("expires_at").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "object.list_expired", "failed to list expired objects", err)
+	}
+	items := make([]*objectstore.Object, len(models))
+	for i := range models {
+		items[i] = r.fromModel(&models[i])
+	}
+	return items, nil
+}
+
+// Delete 删除对象元数据
+func (r *objectRepository) Delete(ctx context.Context, id string) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&StoredObject{}).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "object.delete", "failed to delete object", err)
+	}
+	return nil
+}
+
+// toModel 将领域对象转换为存储模型
+func (r *objectRepository) toModel(o *objectstore.Object) *StoredObject {
+	return &StoredObject{
+		ID:          o.ID,
+		Namespace:   o.Namespace,
+		Name:        o.Name,
+		ContentType: o.ContentType,
+		Size:        o.Size,
+		Refs:        o.Refs,
+		ExpiresAt:   o.ExpiresAt,
+		CreatedAt:   o.CreatedAt,
+		UpdatedAt:   o.UpdatedAt,
+	}
+}
+
+// fromModel 将存储模型转换为领域对象
+func (r *objectRepository) fromModel(m *StoredObject) *objectstore.Object {
+	return &objectstore.Object{
+		ID:          m.ID,
+		Namespace:   m.Namespace,
+		Name:        m.Name,
+		ContentType: m.ContentType,
+		Size:        m.Size,
+		Refs:        m.Refs,
+		ExpiresAt:   m.ExpiresAt,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
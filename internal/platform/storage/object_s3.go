@@ -0,0 +1,264 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/objectstore"
+	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3MaxPresign      = 7 * 24 * time.Hour // SigV4 预签名的有效期上限
+)
+
+// s3ObjectBackend S3 兼容对象存储，请求按 SigV4 签名
+//
+// 写入时不对请求体计算摘要（UNSIGNED-PAYLOAD），内容已由上层按 SHA-256 寻址校验过。
+type s3ObjectBackend struct {
+	cfg      config.S3Config
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewS3ObjectBackend 创建 S3 兼容对象存储
+func NewS3ObjectBackend(cfg config.S3Config, client *http.Client) (objectstore.Backend, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, fmt.Errorf("S3 后端需要配置 Endpoint 和 Bucket")
+	}
+	if cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, fmt.Errorf("S3 后端需要配置 AccessKey 和 SecretKey")
+	}
+	endpoint, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("S3 Endpoint 无效: %s", cfg.Endpoint)
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &s3ObjectBackend{cfg: cfg, endpoint: endpoint, client: client, now: time.Now}, nil
+}
+
+// Name 后端名称
+func (b *s3ObjectBackend) Name() string {
+	return "s3"
+}
+
+// Put 上传内容
+func (b *s3ObjectBackend) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, b.objectURL(key).String(), r)
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "object.s3_put", "failed to build request", err)
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := b.do(req)
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "object.s3_put", "failed to upload object", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return errors.New(errors.KindStorage, "object.s3_put", "upload failed: "+s3ErrorMessage(resp))
+	}
+	return nil
+}
+
+// Open 下载内容
+func (b *s3ObjectBackend) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "object.s3_open", "failed to build request", err)
+	}
+	resp, err := b.do(req)
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "object.s3_open", "failed to download object", err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		resp.Body.Close()
+		return nil, errors.Wrap(errors.KindStorage, "object.s3_open", "object content missing", objectstore.ErrNotFound)
+	case resp.StatusCode/100 != 2:
+		defer resp.Body.Close()
+		return nil, errors.New(errors.KindStorage, "object.s3_open", "download failed: "+s3ErrorMessage(resp))
+	}
+	return resp.Body, nil
+}
+
+// Delete 删除内容，S3 对不存在的键同样返回成功
+func (b *s3ObjectBackend) Delete(ctx context.Context, key string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, b.objectURL(key).String(), nil)
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "object.s3_delete", "failed to build request", err)
+	}
+	resp, err := b.do(req)
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "object.s3_delete", "failed to delete object", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusNotFound {
+		return errors.New(errors.KindStorage, "object.s3_delete", "delete failed: "+s3ErrorMessage(resp))
+	}
+	return nil
+}
+
+// PresignGet 签发下载地址，客户端直接从存储下载
+func (b *s3ObjectBackend) PresignGet(key string, expires time.Duration, filename string) (string, error) {
+	if expires > s3MaxPresign {
+		expires = s3MaxPresign
+	}
+	now := b.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	scope := b.scope(now)
+
+	u := b.objectURL(key)
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", s3Algorithm)
+	query.Set("X-Amz-Credential", b.cfg.AccessKey+"/"+scope)
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expires.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+	if filename != "" {
+		query.Set("response-content-disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	}
+
+	canonicalQuery := s3CanonicalQuery(query)
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		u.EscapedPath(),
+		canonicalQuery,
+		"host:" + u.Host + "\n",
+		"host",
+		s3UnsignedPayload,
+	}, "\n")
+	signature := b.signature(now, amzDate, scope, canonicalRequest)
+
+	u.RawQuery = canonicalQuery + "&X-Amz-Signature=" + signature
+	return u.String(), nil
+}
+
+// objectURL 拼接对象地址，路径中的各段按 SigV4 的规则编码
+func (b *s3ObjectBackend) objectURL(key string) *url.URL {
+	u := *b.endpoint
+	key = strings.TrimPrefix(b.cfg.Prefix+"/"+key, "/")
+	if b.cfg.PathStyle {
+		u.Path = u.Path + "/" + b.cfg.Bucket + "/" + key
+	} else {
+		u.Host = b.cfg.Bucket + "." + u.Host
+		u.Path = u.Path + "/" + key
+	}
+	u.RawPath = s3EncodePath(u.Path)
+	return &u
+}
+
+// do 签名并发送请求
+func (b *s3ObjectBackend) do(req *http.Request) (*http.Response, error) {
+	now := b.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	scope := b.scope(now)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + s3UnsignedPayload + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+	signature := b.signature(now, amzDate, scope, canonicalRequest)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, b.cfg.AccessKey, scope, signedHeaders, signature))
+	return b.client.Do(req)
+}
+
+func (b *s3ObjectBackend) scope(now time.Time) string {
+	return now.Format("20060102") + "/" + b.cfg.Region + "/s3/aws4_request"
+}
+
+// signature 按 SigV4 派生签名密钥并对规范请求签名
+func (b *s3ObjectBackend) signature(now time.Time, amzDate, scope, canonicalRequest string) string {
+	hashed := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := s3Algorithm + "\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hashed[:])
+
+	key := hmacSHA256([]byte("AWS4"+b.cfg.SecretKey), now.Format("20060102"))
+	key = hmacSHA256(key, b.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3CanonicalQuery 按键排序并按 RFC 3986 编码查询参数
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		values := append([]string(nil), query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, s3Escape(k, true)+"="+s3Escape(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3EncodePath 编码路径，保留分隔符
+func s3EncodePath(path string) string {
+	return s3Escape(path, false)
+}
+
+// s3Escape 除 A-Z a-z 0-9 - _ . ~ 外全部百分号编码，encodeSlash 为 false 时保留 /
+func s3Escape(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// s3ErrorMessage 提取错误响应的状态和正文摘要
+func s3ErrorMessage(resp *http.Response) string {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return strings.TrimSpace(resp.Status + " " + string(body))
+}
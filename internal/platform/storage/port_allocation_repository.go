@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/plugin/ports"
+)
+
+// PluginPortAllocation 插件端口分配状态存储模型，每个端口一条记录
+type PluginPortAllocation struct {
+	Port      int       `gorm:"primaryKey;autoIncrement:false"`
+	PluginID  string    `gorm:"type:varchar(255);index"`
+	Address   string    `gorm:"type:varchar(64)"`
+	Status    string    `gorm:"type:varchar(32);index;not null"`
+	UpdatedAt time.Time `gorm:"index"`
+}
+
+// TableName 指定表名
+func (PluginPortAllocation) TableName() string {
+	return "plugin_port_allocations"
+}
+
+// PluginPortEvent 插件端口分配历史存储模型
+type PluginPortEvent struct {
+	ID        uint      `gorm:"primaryKey"`
+	Port      int       `gorm:"index;not null"`
+	PluginID  string    `gorm:"type:varchar(255);index"`
+	Status    string    `gorm:"type:varchar(32);not null"`
+	Reason    string    `gorm:"type:varchar(255)"`
+	CreatedAt time.Time `gorm:"index"`
+}
+
+// TableName 指定表名
+func (PluginPortEvent) TableName() string {
+	return "plugin_port_events"
+}
+
+// portAllocationRepository 端口分配仓库实现
+type portAllocationRepository struct {
+	db *gorm.DB
+}
+
+// NewPortAllocationRepository 创建端口分配仓库实例
+func NewPortAllocationRepository(db *gorm.DB) ports.Store {
+	return &portAllocationRepository{
+		db: db,
+	}
+}
+
+// LoadActive 加载仍处于占用状态的端口记录
+func (r *portAllocationRepository) LoadActive(ctx context.Context) ([]ports.PortAllocation, error) {
+	var models []PluginPortAllocation
+	if err := r.db.WithContext(ctx).
+		Where("status IN ?", []string{
+			string(ports.StatusAllocated),
+			string(ports.StatusReserved),
+			string(ports.StatusOrphaned),
+		}).
+		Order("port ASC").
+		Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "port_allocation.load_active", "failed to load port allocations", err)
+	}
+
+	items := make([]ports.PortAllocation, len(models))
+	for i, m := range models {
+		items[i] = ports.PortAllocation{
+			Port:      m.Port,
+			PluginID:  m.PluginID,
+			Address:   m.Address,
+			Timestamp: m.UpdatedAt,
+			Status:    m.Status,
+		}
+	}
+	return items, nil
+}
+
+// Save 保存端口当前分配状态
+func (r *portAllocationRepository) Save(ctx context.Context, allocation ports.PortAllocation) error {
+	model := &PluginPortAllocation{
+		Port:      allocation.Port,
+		PluginID:  allocation.PluginID,
+		Address:   allocation.Address,
+		Status:    allocation.Status,
+		UpdatedAt: allocation.Timestamp,
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "port"}},
+		DoUpdates: clause.AssignmentColumns([]string{"plugin_id", "address", "status", "updated_at"}),
+	}).Create(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "port_allocation.save", "failed to save port allocation", err)
+	}
+	return nil
+}
+
+// AppendEvent 追加分配历史事件
+func (r *portAllocationRepository) AppendEvent(ctx context.Context, event ports.PortEvent) error {
+	model := &PluginPortEvent{
+		Port:      event.Port,
+		PluginID:  event.PluginID,
+		Status:    event.Status,
+		Reason:    event.Reason,
+		CreatedAt: event.Timestamp,
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "port_allocation.append_event", "failed to append port event", err)
+	}
+	return nil
+}
+
+// ListEvents 按时间倒序查询分配历史
+func (r *portAllocationRepository) ListEvents(ctx context.Context, filter ports.PortEventFilter) ([]ports.PortEvent, error) {
+	query := r.db.WithContext(ctx).Model(&PluginPortEvent{})
+	if filter.PluginID != "" {
+		query = query.Where("plugin_id = ?", filter.PluginID)
+	}
+	if filter.Port > 0 {
+		query = query.Where("port = ?", filter.Port)
+	}
+
+	var models []PluginPortEvent
+	if err := query.Order("id DESC").Limit(filter.Limit).Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "port_allocation.list_events", "failed to list port events", err)
+	}
+
+	items := make([]ports.PortEvent, len(models))
+	for i, m := range models {
+		items[i] = ports.PortEvent{
+			Port:      m.Port,
+			PluginID:  m.PluginID,
+			Status:    m.Status,
+			Reason:    m.Reason,
+			Timestamp: m.CreatedAt,
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/presence"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// PresenceStatus 成员在家状态存储模型
+type PresenceStatus struct {
+	UserID    uint   `gorm:"primaryKey;autoIncrement:false"`
+	State     string `gorm:"type:varchar(16);not null"`
+	Source    string `gorm:"type:varchar(16)"`
+	Since     time.Time
+	UpdatedAt time.Time
+}
+
+// TableName 指定表名
+func (PresenceStatus) TableName() string {
+	return "presence_statuses"
+}
+
+// presenceRepository 在家状态仓库实现
+type presenceRepository struct {
+	db *gorm.DB
+}
+
+// NewPresenceRepository 创建在家状态仓库实例
+func NewPresenceRepository(db *gorm.DB) presence.Repository {
+	return &presenceRepository{
+		db: db,
+	}
+}
+
+// ListStatuses 查询全部成员的状态
+func (r *presenceRepository) ListStatuses(ctx context.Context) ([]*presence.Status, error) {
+	var models []PresenceStatus
+	if err := r.db.WithContext(ctx).Order("user_id").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "presence.list", "failed to list presence statuses", err)
+	}
+	statuses := make([]*presence.Status, 0, len(models))
+	for _, m := range models {
+		statuses = append(statuses, &presence.Status{
+			UserID:    m.UserID,
+			State:     presence.State(m.State),
+			Source:    presence.Source(m.Source),
+			Since:     m.Since,
+			UpdatedAt: m.UpdatedAt,
+		})
+	}
+	return statuses, nil
+}
+
+// SaveStatus 保存成员状态
+func (r *presenceRepository) SaveStatus(ctx context.Context, s *presence.Status) error {
+	model := &PresenceStatus{
+		UserID:    s.UserID,
+		State:     string(s.State),
+		Source:    string(s.Source),
+		Since:     s.Since,
+		UpdatedAt: s.UpdatedAt,
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "presence.save", "failed to save presence status", err)
+	}
+	return nil
+}
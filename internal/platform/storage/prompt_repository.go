@@ -0,0 +1,310 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"xiaozhi-server-go/internal/domain/prompt"
+	"xiaozhi-server-go/internal/domain/tenant"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// PromptTemplate 提示词模板版本存储模型
+type PromptTemplate struct {
+	ID          string `gorm:"type:varchar(64);primaryKey"`
+	TenantID    string `gorm:"type:varchar(64);uniqueIndex:idx_prompt_tenant_name_version;default:'default';not null"`
+	Name        string `gorm:"type:varchar(64);uniqueIndex:idx_prompt_tenant_name_version;not null"`
+	Version     int    `gorm:"uniqueIndex:idx_prompt_tenant_name_version;not null"`
+	Description string `gorm:"type:varchar(512)"`
+	Content     string `gorm:"type:text;not null"`
+	Variables   string `gorm:"type:text"` // JSON
+	Active      bool   `gorm:"index;default:false"`
+	CreatedAt   time.Time
+}
+
+// TableName 指定表名
+func (PromptTemplate) TableName() string {
+	return "prompt_templates"
+}
+
+// PromptAssignment 设备模板分配存储模型
+// 设备ID全局唯一，可直接作为主键；各租户的全局默认分配以 "*:<租户ID>" 存储，见 assignmentKey
+type PromptAssignment struct {
+	DeviceID     string `gorm:"type:varchar(255);primaryKey"`
+	TenantID     string `gorm:"type:varchar(64);index;default:'default';not null"`
+	TemplateName string `gorm:"type:varchar(64);index;not null"`
+	Version      int    `gorm:"default:0"`
+	UpdatedAt    time.Time
+}
+
+// TableName 指定表名
+func (PromptAssignment) TableName() string {
+	return "prompt_assignments"
+}
+
+// promptRepository 提示词模板仓库实现
+type promptRepository struct {
+	db *gorm.DB
+}
+
+// NewPromptRepository 创建提示词模板仓库实例
+func NewPromptRepository(db *gorm.DB) prompt.Repository {
+	return &promptRepository{
+		db: db,
+	}
+}
+
+// Save 保存模板版本
+func (r *promptRepository) Save(ctx context.Context, t *prompt.Template) error {
+	model, err := r.toModel(ctx, t)
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "prompt.save", "failed to encode template variables", err)
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "prompt.save", "failed to save prompt template", err)
+	}
+	return nil
+}
+
+// FindVersion 查找指定版本，version 为 0 时返回生效版本
+func (r *promptRepository) FindVersion(ctx context.Context, name string, version int) (*prompt.Template, error) {
+	query := r.scoped(ctx).Where("name = ?", name)
+	if version > 0 {
+		query = query.Where("version = ?", version)
+	} else {
+		query = query.Where("active = ?", true)
+	}
+
+	var model PromptTemplate
+	if err := query.First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "prompt.find_version", "failed to find prompt template", err)
+	}
+	return r.fromModel(&model), nil
+}
+
+// LatestVersion 返回最大版本号
+func (r *promptRepository) LatestVersion(ctx context.Context, name string) (int, error) {
+	var latest *int
+	if err := r.scoped(ctx).Model(&PromptTemplate{}).
+		Where("name = ?", name).
+		Select("MAX(version)").
+		Scan(&latest).Error; err != nil {
+		return 0, errors.Wrap(errors.KindStorage, "prompt.latest_version", "failed to query latest version", err)
+	}
+	if latest == nil {
+		return 0, nil
+	}
+	return *latest, nil
+}
+
+// ListVersions 列出全部版本
+func (r *promptRepository) ListVersions(ctx context.Context, name string) ([]*prompt.Template, error) {
+	var models []PromptTemplate
+	if err := r.scoped(ctx).Where("name = ?", name).Order("version DESC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "prompt.list_versions", "failed to list prompt versions", err)
+	}
+	items := make([]*prompt.Template, len(models))
+	for i := range models {
+		items[i] = r.fromModel(&models[i])
+	}
+	return items, nil
+}
+
+// ListActive 分页查询生效版本
+func (r *promptRepository) ListActive(ctx context.Context, filter prompt.Filter) ([]*prompt.Template, int64, error) {
+	query := r.scoped(ctx).Model(&PromptTemplate{}).Where("active = ?", true)
+	if filter.Name != "" {
+		query = query.Where("name LIKE ?", "%"+filter.Name+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "prompt.list", "failed to count prompt templates", err)
+	}
+
+	var models []PromptTemplate
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := query.Order("name ASC").Offset(offset).Limit(filter.PageSize).Find(&models).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "prompt.list", "failed to list prompt templates", err)
+	}
+
+	items := make([]*prompt.Template, len(models))
+	for i := range models {
+		items[i] = r.fromModel(&models[i])
+	}
+	return items, total, nil
+}
+
+// SetActive 切换生效版本
+func (r *promptRepository) SetActive(ctx context.Context, name string, version int) error {
+	tenantID := tenant.IDFrom(ctx)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&PromptTemplate{}).Where("tenant_id = ? AND name = ?", tenantID, name).Update("active", false).Error; err != nil {
+			return err
+		}
+		return tx.Model(&PromptTemplate{}).Where("tenant_id = ? AND name = ? AND version = ?", tenantID, name, version).Update("active", true).Error
+	})
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "prompt.set_active", "failed to activate prompt version", err)
+	}
+	return nil
+}
+
+// Delete 删除模板的全部版本及分配
+func (r *promptRepository) Delete(ctx context.Context, name string) error {
+	tenantID := tenant.IDFrom(ctx)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tenant_id = ? AND template_name = ?", tenantID, name).Delete(&PromptAssignment{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("tenant_id = ? AND name = ?", tenantID, name).Delete(&PromptTemplate{}).Error
+	})
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "prompt.delete", "failed to delete prompt template", err)
+	}
+	return nil
+}
+
+// SaveAssignment 保存或覆盖分配，设备须属于当前租户；未注册的设备视为属于默认租户
+func (r *promptRepository) SaveAssignment(ctx context.Context, a *prompt.Assignment) error {
+	tenantID := tenant.IDFrom(ctx)
+	model := &PromptAssignment{
+		DeviceID:     assignmentKey(tenantID, a.DeviceID),
+		TenantID:     tenantID,
+		TemplateName: a.TemplateName,
+		Version:      a.Version,
+		UpdatedAt:    a.UpdatedAt,
+	}
+	foreign := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if a.DeviceID != prompt.DefaultScope {
+			var owners []string
+			if err := tx.Model(&Device{}).Where("device_id = ?", a.DeviceID).Pluck("tenant_id", &owners).Error; err != nil {
+				return err
+			}
+			owner := tenant.DefaultID
+			if len(owners) > 0 && owners[0] != "" {
+				owner = owners[0]
+			}
+			if owner != tenantID {
+				foreign = true
+				return nil
+			}
+		}
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(model).Error
+	})
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "prompt.save_assignment", "failed to save prompt assignment", err)
+	}
+	if foreign {
+		return errors.New(errors.KindDomain, "prompt.save_assignment", "device does not belong to tenant: "+a.DeviceID)
+	}
+	return nil
+}
+
+// FindAssignment 查找设备分配
+func (r *promptRepository) FindAssignment(ctx context.Context, deviceID string) (*prompt.Assignment, error) {
+	var model PromptAssignment
+	if err := r.scoped(ctx).Where("device_id = ?", assignmentKey(tenant.IDFrom(ctx), deviceID)).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "prompt.find_assignment", "failed to find prompt assignment", err)
+	}
+	return r.fromAssignment(&model), nil
+}
+
+// ListAssignments 列出全部分配
+func (r *promptRepository) ListAssignments(ctx context.Context) ([]*prompt.Assignment, error) {
+	var models []PromptAssignment
+	if err := r.scoped(ctx).Order("device_id ASC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "prompt.list_assignments", "failed to list prompt assignments", err)
+	}
+	items := make([]*prompt.Assignment, len(models))
+	for i := range models {
+		items[i] = r.fromAssignment(&models[i])
+	}
+	return items, nil
+}
+
+// DeleteAssignment 删除设备分配
+func (r *promptRepository) DeleteAssignment(ctx context.Context, deviceID string) error {
+	if err := r.scoped(ctx).Where("device_id = ?", assignmentKey(tenant.IDFrom(ctx), deviceID)).Delete(&PromptAssignment{}).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "prompt.delete_assignment", "failed to delete prompt assignment", err)
+	}
+	return nil
+}
+
+// scoped 返回限定在上下文租户内的查询
+func (r *promptRepository) scoped(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Where("tenant_id = ?", tenant.IDFrom(ctx))
+}
+
+// assignmentKey 返回分配记录的主键；默认租户的全局默认仍使用 "*"，兼容升级前的数据
+func assignmentKey(tenantID, deviceID string) string {
+	if deviceID == prompt.DefaultScope && tenantID != tenant.DefaultID {
+		return prompt.DefaultScope + ":" + tenantID
+	}
+	return deviceID
+}
+
+// toModel 将领域对象转换为存储模型
+func (r *promptRepository) toModel(ctx context.Context, t *prompt.Template) (*PromptTemplate, error) {
+	var variables string
+	if len(t.Variables) > 0 {
+		data, err := json.Marshal(t.Variables)
+		if err != nil {
+			return nil, err
+		}
+		variables = string(data)
+	}
+	return &PromptTemplate{
+		ID:          t.ID,
+		TenantID:    tenant.IDFrom(ctx),
+		Name:        t.Name,
+		Version:     t.Version,
+		Description: t.Description,
+		Content:     t.Content,
+		Variables:   variables,
+		Active:      t.Active,
+		CreatedAt:   t.CreatedAt,
+	}, nil
+}
+
+// fromModel 将存储模型转换为领域对象
+func (r *promptRepository) fromModel(m *PromptTemplate) *prompt.Template {
+	t := &prompt.Template{
+		ID:          m.ID,
+		Name:        m.Name,
+		Version:     m.Version,
+		Description: m.Description,
+		Content:     m.Content,
+		Active:      m.Active,
+		CreatedAt:   m.CreatedAt,
+	}
+	if m.Variables != "" {
+		_ = json.Unmarshal([]byte(m.Variables), &t.Variables)
+	}
+	return t
+}
+
+func (r *promptRepository) fromAssignment(m *PromptAssignment) *prompt.Assignment {
+	deviceID := m.DeviceID
+	if strings.HasPrefix(deviceID, prompt.DefaultScope+":") {
+		deviceID = prompt.DefaultScope
+	}
+	return &prompt.Assignment{
+		DeviceID:     deviceID,
+		TemplateName: m.TemplateName,
+		Version:      m.Version,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/providercall"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// ProviderCall 提供者调用录制存储模型
+type ProviderCall struct {
+	ID             string    `gorm:"type:varchar(64);primaryKey"`
+	ProviderID     string    `gorm:"type:varchar(255);index"`
+	CapabilityID   string    `gorm:"type:varchar(255);index"`
+	CapabilityType string    `gorm:"type:varchar(32)"`
+	DeviceID       string    `gorm:"type:varchar(255);index"`
+	SessionID      string    `gorm:"type:varchar(255)"`
+	Request        string    `gorm:"type:text"`
+	Response       string    `gorm:"type:text"`
+	Stream         bool      `gorm:"default:false"`
+	Chunks         int       `gorm:"default:0"`
+	Success        bool      `gorm:"default:false"`
+	Error          string    `gorm:"type:text"`
+	DurationMs     int64     `gorm:"default:0"`
+	CreatedAt      time.Time `gorm:"index"`
+}
+
+// TableName 指定表名
+func (ProviderCall) TableName() string {
+	return "provider_calls"
+}
+
+// providerCallRepository 提供者调用录制仓库实现
+type providerCallRepository struct {
+	db *gorm.DB
+}
+
+// NewProviderCallRepository 创建提供者调用录制仓库实例
+func NewProviderCallRepository(db *gorm.DB) providercall.Repository {
+	return &providerCallRepository{
+		db: db,
+	}
+}
+
+// SaveBatch 批量保存调用录制
+func (r *providerCallRepository) SaveBatch(ctx context.Context, calls []*providercall.Call) error {
+	models := make([]ProviderCall, len(calls))
+	for i, call := range calls {
+		models[i] = ProviderCall{
+			ID:             call.ID,
+			ProviderID:     call.ProviderID,
+			CapabilityID:   call.CapabilityID,
+			CapabilityType: call.CapabilityType,
+			DeviceID:       call.DeviceID,
+			SessionID:      call.SessionID,
+			Request:        call.Request,
+			Response:       call.Response,
+			Stream:         call.Stream,
+			Chunks:         call.Chunks,
+			Success:        call.Success,
+			Error:          call.Error,
+			DurationMs:     call.DurationMs,
+			CreatedAt:      call.CreatedAt,
+		}
+	}
+	if err := r.db.WithContext(ctx).CreateInBatches(models, 50).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "providercall.save_batch", "failed to save provider calls", err)
+	}
+	return nil
+}
+
+// List 按条件分页查询调用录制
+func (r *providerCallRepository) List(ctx context.Context, filter providercall.Filter) ([]*providercall.Call, int64, error) {
+	query := r.db.WithContext(ctx).Model(&ProviderCall{})
+	if filter.ProviderID != "" {
+		query = query.Where("provider_id = ?", filter.ProviderID)
+	}
+	if filter.CapabilityID != "" {
+		query = query.Where("capability_id = ?", filter.CapabilityID)
+	}
+	if filter.DeviceID != "" {
+		query = query.Where("device_id = ?", filter.DeviceID)
+	}
+	if filter.Success != nil {
+		query = query.Where("success = ?", *filter.Success)
+	}
+	if filter.Query != "" {
+		like := "%" + filter.Query + "%"
+		query = query.Where("request LIKE ? OR response LIKE ? OR error LIKE ?", like, like, like)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at <= ?", filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "providercall.list", "failed to count provider calls", err)
+	}
+
+	var models []ProviderCall
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(filter.PageSize).Find(&models).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "providercall.list", "failed to list provider calls", err)
+	}
+
+	items := make([]*providercall.Call, len(models))
+	for i := range models {
+		items[i] = r.fromModel(&models[i])
+	}
+	return items, total, nil
+}
+
+// FindByID 根据ID查找调用录制
+func (r *providerCallRepository) FindByID(ctx context.Context, id string) (*providercall.Call, error) {
+	var model ProviderCall
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "providercall.find_by_id", "failed to find provider call", err)
+	}
+	return r.fromModel(&model), nil
+}
+
+// DeleteBefore 删除指定时间之前的调用录制
+func (r *providerCallRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("created_at < ?", before).Delete(&ProviderCall{})
+	if result.Error != nil {
+		return 0, errors.Wrap(errors.KindStorage, "providercall.delete_before", "failed to purge provider calls", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// DeleteByDevice 删除设备的全部调用录制
+func (r *providerCallRepository) DeleteByDevice(ctx context.Context, deviceID string) (int64, error) {
+	result := r.db.WithContext(ctx).Where("device_id = ?", deviceID).Delete(&ProviderCall{})
+	if result.Error != nil {
+		return 0, errors.Wrap(errors.KindStorage, "providercall.delete_device", "failed to delete device provider calls", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *providerCallRepository) fromModel(m *ProviderCall) *providercall.Call {
+	return &providercall.Call{
+		ID:             m.ID,
+		ProviderID:     m.ProviderID,
+		CapabilityID:   m.CapabilityID,
+		CapabilityType: m.CapabilityType,
+		DeviceID:       m.DeviceID,
+		SessionID:      m.SessionID,
+		Request:        m.Request,
+		Response:       m.Response,
+		Stream:         m.Stream,
+		Chunks:         m.Chunks,
+		Success:        m.Success,
+		Error:          m.Error,
+		DurationMs:     m.DurationMs,
+		CreatedAt:      m.CreatedAt,
+	}
+}
@@ -0,0 +1,251 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"xiaozhi-server-go/internal/domain/provisioning"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// PairingToken 配对令牌存储模型
+type PairingToken struct {
+	ID        string    `gorm:"type:varchar(64);primaryKey"`
+	TokenHash string    `gorm:"type:varchar(64);uniqueIndex;not null"`
+	UserID    uint      `gorm:"not null;index"`
+	TenantID  string    `gorm:"type:varchar(64)"`
+	ExpiresAt time.Time `gorm:"index"`
+	DeviceID  string    `gorm:"type:varchar(255);index"`
+	ClaimedAt *time.Time
+	CreatedAt time.Time
+}
+
+// TableName 指定表名
+func (PairingToken) TableName() string {
+	return "pairing_tokens"
+}
+
+// DeviceCredential 设备凭证存储模型
+type DeviceCredential struct {
+	DeviceID   string `gorm:"type:varchar(255);primaryKey"`
+	SecretHash string `gorm:"type:varchar(64);not null"`
+	CreatedAt  time.Time
+}
+
+// TableName 指定表名
+func (DeviceCredential) TableName() string {
+	return "device_credentials"
+}
+
+// DeviceKey 设备密钥存储模型
+type DeviceKey struct {
+	ID         string `gorm:"type:varchar(64);primaryKey"`
+	DeviceID   string `gorm:"type:varchar(255);index;not null"`
+	PublicKey  string `gorm:"type:text;not null"`
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+	LastUsedAt *time.Time
+}
+
+// TableName 指定表名
+func (DeviceKey) TableName() string {
+	return "device_keys"
+}
+
+// provisioningRepository 扫码配对仓库实现
+type provisioningRepository struct {
+	db *gorm.DB
+}
+
+// NewProvisioningRepository 创建扫码配对仓库实例
+func NewProvisioningRepository(db *gorm.DB) provisioning.Repository {
+	return &provisioningRepository{
+		db: db,
+	}
+}
+
+// UserExists 判断用户是否存在
+func (r *provisioningRepository) UserExists(ctx context.Context, userID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&User{}).Where("id = ?", userID).Count(&count).Error; err != nil {
+		return false, errors.Wrap(errors.KindStorage, "provisioning.user_exists", "failed to query user", err)
+	}
+	return count > 0, nil
+}
+
+// SaveToken 保存配对令牌
+func (r *provisioningRepository) SaveToken(ctx context.Context, token *provisioning.PairingToken) error {
+	model := PairingToken{
+		ID:        token.ID,
+		TokenHash: token.TokenHash,
+		UserID:    token.UserID,
+		TenantID:  token.TenantID,
+		ExpiresAt: token.ExpiresAt,
+		ClaimedAt: token.ClaimedAt,
+		DeviceID:  token.DeviceID,
+		CreatedAt: token.CreatedAt,
+	}
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "provisioning.save_token", "failed to save pairing token", err)
+	}
+	return nil
+}
+
+// FindToken 按令牌哈希查找配对令牌
+func (r *provisioningRepository) FindToken(ctx context.Context, tokenHash string) (*provisioning.PairingToken, error) {
+	var model PairingToken
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "provisioning.find_token", "failed to find pairing token", err)
+	}
+	return &provisioning.PairingToken{
+		ID:        model.ID,
+		TokenHash: model.TokenHash,
+		UserID:    model.UserID,
+		TenantID:  model.TenantID,
+		ExpiresAt: model.ExpiresAt,
+		ClaimedAt: model.ClaimedAt,
+		DeviceID:  model.DeviceID,
+		CreatedAt: model.CreatedAt,
+	}, nil
+}
+
+// ClaimToken 以条件更新标记令牌已使用，并发配对时只有一个请求成功
+func (r *provisioningRepository) ClaimToken(ctx context.Context, id, deviceID string, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&PairingToken{}).
+		Where("id = ? AND claimed_at IS NULL AND expires_at > ?", id, at).
+		Updates(map[string]interface{}{"claimed_at": at, "device_id": deviceID})
+	if result.Error != nil {
+		return false, errors.Wrap(errors.KindStorage, "provisioning.claim_token", "failed to claim pairing token", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// DeleteExpired 删除早于指定时间过期的令牌
+func (r *provisioningRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at < ?", before).Delete(&PairingToken{})
+	if result.Error != nil {
+		return 0, errors.Wrap(errors.KindStorage, "provisioning.delete_expired", "failed to purge pairing tokens", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// SaveCredential 创建或替换设备凭证
+func (r *provisioningRepository) SaveCredential(ctx context.Context, credential *provisioning.Credential) error {
+	model := DeviceCredential{
+		DeviceID:   credential.DeviceID,
+		SecretHash: credential.SecretHash,
+		CreatedAt:  credential.CreatedAt,
+	}
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "device_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"secret_hash", "created_at"}),
+	}).Create(&model).Error
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "provisioning.save_credential", "failed to save device credential", err)
+	}
+	return nil
+}
+
+// FindCredential 查找设备凭证
+func (r *provisioningRepository) FindCredential(ctx context.Context, deviceID string) (*provisioning.Credential, error) {
+	var model DeviceCredential
+	if err := r.db.WithContext(ctx).Where("device_id = ?", deviceID).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "provisioning.find_credential", "failed to find device credential", err)
+	}
+	return &provisioning.Credential{
+		DeviceID:   model.DeviceID,
+		SecretHash: model.SecretHash,
+		CreatedAt:  model.CreatedAt,
+	}, nil
+}
+
+// DeleteCredential 删除设备的静态令牌
+func (r *provisioningRepository) DeleteCredential(ctx context.Context, deviceID string) error {
+	if err := r.db.WithContext(ctx).Where("device_id = ?", deviceID).Delete(&DeviceCredential{}).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "provisioning.delete_credential", "failed to delete device credential", err)
+	}
+	return nil
+}
+
+// SaveKey 保存设备密钥
+func (r *provisioningRepository) SaveKey(ctx context.Context, key *provisioning.DeviceKey) error {
+	model := DeviceKey{
+		ID:         key.ID,
+		DeviceID:   key.DeviceID,
+		PublicKey:  key.PublicKey,
+		CreatedAt:  key.CreatedAt,
+		RevokedAt:  key.RevokedAt,
+		LastUsedAt: key.LastUsedAt,
+	}
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "provisioning.save_key", "failed to save device key", err)
+	}
+	return nil
+}
+
+// ListKeys 列出设备的全部密钥，新密钥在前
+func (r *provisioningRepository) ListKeys(ctx context.Context, deviceID string) ([]*provisioning.DeviceKey, error) {
+	var models []DeviceKey
+	if err := r.db.WithContext(ctx).Where("device_id = ?", deviceID).Order("created_at DESC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "provisioning.list_keys", "failed to list device keys", err)
+	}
+	keys := make([]*provisioning.DeviceKey, len(models))
+	for i, m := range models {
+		keys[i] = &provisioning.DeviceKey{
+			ID:         m.ID,
+			DeviceID:   m.DeviceID,
+			PublicKey:  m.PublicKey,
+			CreatedAt:  m.CreatedAt,
+			RevokedAt:  m.RevokedAt,
+			LastUsedAt: m.LastUsedAt,
+		}
+	}
+	return keys, nil
+}
+
+// RevokeKey 吊销设备的密钥，已吊销的密钥保持原吊销时间
+func (r *provisioningRepository) RevokeKey(ctx context.Context, deviceID, keyID string, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&DeviceKey{}).
+		Where("id = ? AND device_id = ? AND revoked_at IS NULL", keyID, deviceID).
+		Update("revoked_at", at)
+	if result.Error != nil {
+		return false, errors.Wrap(errors.KindStorage, "provisioning.revoke_key", "failed to revoke device key", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// TouchKey 记录密钥最近一次通过校验的时间
+func (r *provisioningRepository) TouchKey(ctx context.Context, keyID string, at time.Time) error {
+	if err := r.db.WithContext(ctx).Model(&DeviceKey{}).Where("id = ?", keyID).Update("last_used_at", at).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "provisioning.touch_key", "failed to update device key", err)
+	}
+	return nil
+}
+
+// DeleteByDevice 删除设备的凭证、密钥和配对令牌
+func (r *provisioningRepository) DeleteByDevice(ctx context.Context, deviceID string) (int64, error) {
+	var removed int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&DeviceCredential{}, &DeviceKey{}, &PairingToken{}} {
+			result := tx.Where("device_id = ?", deviceID).Delete(model)
+			if result.Error != nil {
+				return result.Error
+			}
+			removed += result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(errors.KindStorage, "provisioning.delete_device", "failed to delete device credentials", err)
+	}
+	return removed, nil
+}
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"xiaozhi-server-go/internal/domain/recording"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// encryptedBlobMagic 加密文件头，便于将来更换加密格式
+const encryptedBlobMagic = "XZR1"
+
+// encryptedFileStore 本地文件音频存储，使用 AES-256-GCM 加密
+//
+// 文件格式为 magic + nonce + 密文，键作为附加认证数据，文件被挪到其他键下时无法解密。
+type encryptedFileStore struct {
+	dir  string
+	aead cipher.AEAD
+}
+
+// NewEncryptedFileStore 创建加密文件存储，key 必须为 32 字节
+func NewEncryptedFileStore(dir string, key []byte) (recording.BlobStore, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("AES-256 密钥必须为 32 字节，当前为 %d 字节", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("创建录音目录失败: %w", err)
+	}
+	return &encryptedFileStore{dir: dir, aead: aead}, nil
+}
+
+// Put 加密并写入音频，先写临时文件再重命名，避免留下不完整的文件
+func (s *encryptedFileStore) Put(ctx context.Context, key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return errors.Wrap(errors.KindStorage, "recording.blob_put", "failed to generate nonce", err)
+	}
+	out := make([]byte, 0, len(encryptedBlobMagic)+len(nonce)+len(data)+s.aead.Overhead())
+	out = append(out, encryptedBlobMagic...)
+	out = append(out, nonce...)
+	out = s.aead.Seal(out, nonce, data, []byte(key))
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return errors.Wrap(errors.KindStorage, "recording.blob_put", "failed to create directory", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, out, 0o600); err != nil {
+		return errors.Wrap(errors.KindStorage, "recording.blob_put", "failed to write recording", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return errors.Wrap(errors.KindStorage, "recording.blob_put", "failed to write recording", err)
+	}
+	return nil
+}
+
+// Get 读取并解密音频
+func (s *encryptedFileStore) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "recording.blob_get", "failed to read recording", err)
+	}
+	header := len(encryptedBlobMagic) + s.aead.NonceSize()
+	if len(data) < header || string(data[:len(encryptedBlobMagic)]) != encryptedBlobMagic {
+		return nil, errors.New(errors.KindStorage, "recording.blob_get", "invalid recording file")
+	}
+	plain, err := s.aead.Open(nil, data[len(encryptedBlobMagic):header], data[header:], []byte(key))
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "recording.blob_get", "failed to decrypt recording", err)
+	}
+	return plain, nil
+}
+
+// Delete 删除音频，不存在时不报错
+func (s *encryptedFileStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(errors.KindStorage, "recording.blob_delete", "failed to delete recording", err)
+	}
+	return nil
+}
+
+// path 将键转换为目录内的文件路径，拒绝越出目录的键
+func (s *encryptedFileStore) path(key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", errors.New(errors.KindStorage, "recording.blob_path", "invalid recording key: "+key)
+	}
+	return filepath.Join(s.dir, clean) + ".enc", nil
+}
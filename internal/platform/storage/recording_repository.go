@@ -0,0 +1,215 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/recording"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// AudioRecording 归档录音存储模型，音频内容加密后存放在 BlobKey 指向的文件中
+type AudioRecording struct {
+	ID         string    `gorm:"type:varchar(64);primaryKey"`
+	SessionID  string    `gorm:"type:varchar(255);index;not null"`
+	DeviceID   string    `gorm:"type:varchar(255);index"`
+	Round      int       `gorm:"default:0"`
+	EntryID    string    `gorm:"type:varchar(64);index"`
+	Format     string    `gorm:"type:varchar(16)"`
+	SampleRate int       `gorm:"default:0"`
+	Channels   int       `gorm:"default:1"`
+	DurationMs int64     `gorm:"default:0"`
+	Size       int64     `gorm:"default:0"`
+	BlobKey    string    `gorm:"type:varchar(255);not null"`
+	CreatedAt  time.Time `gorm:"index"`
+}
+
+// TableName 指定表名
+func (AudioRecording) TableName() string {
+	return "audio_recordings"
+}
+
+// AudioRecordingConsent 设备语音归档授权存储模型
+type AudioRecordingConsent struct {
+	DeviceID  string `gorm:"type:varchar(255);primaryKey"`
+	Granted   bool   `gorm:"default:false"`
+	UpdatedAt time.Time
+}
+
+// TableName 指定表名
+func (AudioRecordingConsent) TableName() string {
+	return "audio_recording_consents"
+}
+
+// recordingRepository 录音仓库实现
+type recordingRepository struct {
+	db *gorm.DB
+}
+
+// NewRecordingRepository 创建录音仓库实例
+func NewRecordingRepository(db *gorm.DB) recording.Repository {
+	return &recordingRepository{
+		db: db,
+	}
+}
+
+// Save 保存录音元数据
+func (r *recordingRepository) Save(ctx context.Context, rec *recording.Recording) error {
+	model := &AudioRecording{
+		ID:         rec.ID,
+		SessionID:  rec.SessionID,
+		DeviceID:   rec.DeviceID,
+		Round:      rec.Round,
+		EntryID:    rec.EntryID,
+		Format:     rec.Format,
+		SampleRate: rec.SampleRate,
+		Channels:   rec.Channels,
+		DurationMs: rec.DurationMs,
+		Size:       rec.Size,
+		BlobKey:    rec.BlobKey,
+		CreatedAt:  rec.CreatedAt,
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "recording.save", "failed to save recording", err)
+	}
+	return nil
+}
+
+// Find 根据ID查找录音
+func (r *recordingRepository) Find(ctx context.Context, id string) (*recording.Recording, error) {
+	var model AudioRecording
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "recording.find", "failed to find recording", err)
+	}
+	return r.fromModel(&model), nil
+}
+
+// List 按条件分页查询录音
+func (r *recordingRepository) List(ctx context.Context, filter recording.Filter) ([]*recording.Recording, int64, error) {
+	query := r.db.WithContext(ctx).Model(&AudioRecording{})
+	if filter.DeviceID != "" {
+		query = query.Where("device_id = ?", filter.DeviceID)
+	}
+	if filter.SessionID != "" {
+		query = query.Where("session_id = ?", filter.SessionID)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at <= ?", filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "recording.list", "failed to count recordings", err)
+	}
+
+	var models []AudioRecording
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(filter.PageSize).Find(&models).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "recording.list", "failed to list recordings", err)
+	}
+	return r.fromModels(models), total, nil
+}
+
+// ListBefore 查询指定时间之前的录音
+func (r *recordingRepository) ListBefore(ctx context.Context, before time.Time, limit int) ([]*recording.Recording, error) {
+	var models []AudioRecording
+	if err := r.db.WithContext(ctx).
+		Where("created_at < ?", before).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "recording.list_before", "failed to list expired recordings", err)
+	}
+	return r.fromModels(models), nil
+}
+
+// ListByDevice 查询设备的全部录音
+func (r *recordingRepository) ListByDevice(ctx context.Context, deviceID string) ([]*recording.Recording, error) {
+	var models []AudioRecording
+	if err := r.db.WithContext(ctx).Where("device_id = ?", deviceID).Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "recording.list_by_device", "failed to list device recordings", err)
+	}
+	return r.fromModels(models), nil
+}
+
+// Delete 删除录音元数据
+func (r *recordingRepository) Delete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&AudioRecording{}).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "recording.delete", "failed to delete recordings", err)
+	}
+	return nil
+}
+
+// GetConsent 获取设备授权
+func (r *recordingRepository) GetConsent(ctx context.Context, deviceID string) (*recording.Consent, error) {
+	var model AudioRecordingConsent
+	if err := r.db.WithContext(ctx).Where("device_id = ?", deviceID).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "recording.get_consent", "failed to get recording consent", err)
+	}
+	return &recording.Consent{
+		DeviceID:  model.DeviceID,
+		Granted:   model.Granted,
+		UpdatedAt: model.UpdatedAt,
+	}, nil
+}
+
+// SaveConsent 保存设备授权
+func (r *recordingRepository) SaveConsent(ctx context.Context, c *recording.Consent) error {
+	model := &AudioRecordingConsent{
+		DeviceID:  c.DeviceID,
+		Granted:   c.Granted,
+		UpdatedAt: c.UpdatedAt,
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "recording.save_consent", "failed to save recording consent", err)
+	}
+	return nil
+}
+
+// DeleteConsent 删除设备授权
+func (r *recordingRepository) DeleteConsent(ctx context.Context, deviceID string) error {
+	if err := r.db.WithContext(ctx).Where("device_id = ?", deviceID).Delete(&AudioRecordingConsent{}).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "recording.delete_consent", "failed to delete recording consent", err)
+	}
+	return nil
+}
+
+func (r *recordingRepository) fromModels(models []AudioRecording) []*recording.Recording {
+	items := make([]*recording.Recording, len(models))
+	for i := range models {
+		items[i] = r.fromModel(&models[i])
+	}
+	return items
+}
+
+// fromModel 将存储模型转换为领域对象
+func (r *recordingRepository) fromModel(m *AudioRecording) *recording.Recording {
+	return &recording.Recording{
+		ID:         m.ID,
+		SessionID:  m.SessionID,
+		DeviceID:   m.DeviceID,
+		Round:      m.Round,
+		EntryID:    m.EntryID,
+		Format:     m.Format,
+		SampleRate: m.SampleRate,
+		Channels:   m.Channels,
+		DurationMs: m.DurationMs,
+		Size:       m.Size,
+		BlobKey:    m.BlobKey,
+		CreatedAt:  m.CreatedAt,
+	}
+}
@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/reminder"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// Reminder 提醒存储模型
+type Reminder struct {
+	ID         string    `gorm:"type:varchar(64);primaryKey"`
+	DeviceID   string    `gorm:"type:varchar(255);index;not null"`
+	Kind       string    `gorm:"type:varchar(32);not null"`
+	Message    string    `gorm:"type:text"`
+	FireAt     time.Time `gorm:"index;not null"`
+	Recurrence string    `gorm:"type:varchar(32)"`
+	Status     string    `gorm:"type:varchar(32);index;not null"`
+	FiredAt    *time.Time
+	Attempts   int `gorm:"default:0"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// TableName 指定表名
+func (Reminder) TableName() string {
+	return "reminders"
+}
+
+// reminderRepository 提醒仓库实现
+type reminderRepository struct {
+	db *gorm.DB
+}
+
+// NewReminderRepository 创建提醒仓库实例
+func NewReminderRepository(db *gorm.DB) reminder.Repository {
+	return &reminderRepository{
+		db: db,
+	}
+}
+
+// Save 保存提醒
+func (r *reminderRepository) Save(ctx context.Context, item *reminder.Reminder) error {
+	if err := r.db.WithContext(ctx).Create(r.toModel(item)).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "reminder.save", "failed to save reminder", err)
+	}
+	return nil
+}
+
+// Update 更新提醒
+func (r *reminderRepository) Update(ctx context.Context, item *reminder.Reminder) error {
+	if err := r.db.WithContext(ctx).Save(r.toModel(item)).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "reminder.update", "failed to update reminder", err)
+	}
+	return nil
+}
+
+// FindByID 根据ID查找提醒
+func (r *reminderRepository) FindByID(ctx context.Context, id string) (*reminder.Reminder, error) {
+	var model Reminder
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "reminder.find_by_id", "failed to find reminder", err)
+	}
+	return r.fromModel(&model), nil
+}
+
+// List 分页查询提醒
+func (r *reminderRepository) List(ctx context.Context, filter reminder.Filter) ([]*reminder.Reminder, int64, error) {
+	query := r.db.WithContext(ctx).Model(&Reminder{})
+	if filter.DeviceID != "" {
+		query = query.Where("device_id = ?", filter.DeviceID)
+	}
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", string(filter.Kind))
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "reminder.list", "failed to count reminders", err)
+	}
+
+	var models []Reminder
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := query.Order("fire_at ASC").Offset(offset).Limit(filter.PageSize).Find(&models).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "reminder.list", "failed to list reminders", err)
+	}
+
+	items := make([]*reminder.Reminder, len(models))
+	for i := range models {
+		items[i] = r.fromModel(&models[i])
+	}
+	return items, total, nil
+}
+
+// ListDue 查询到期的待触发提醒
+func (r *reminderRepository) ListDue(ctx context.Context, before time.Time, limit int) ([]*reminder.Reminder, error) {
+	var models []Reminder
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND fire_at <= ?", string(reminder.StatusPending), before).
+		Order("fire_at ASC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "reminder.list_due", "failed to list due reminders", err)
+	}
+
+	items := make([]*reminder.Reminder, len(models))
+	for i := range models {
+		items[i] = r.fromModel(&models[i])
+	}
+	return items, nil
+}
+
+// Delete 删除提醒
+func (r *reminderRepository) Delete(ctx context.Context, id string) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&Reminder{}).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "reminder.delete", "failed to delete reminder", err)
+	}
+	return nil
+}
+
+// toModel 将领域对象转换为存储模型
+func (r *reminderRepository) toModel(item *reminder.Reminder) *Reminder {
+	return &Reminder{
+		ID:         item.ID,
+		DeviceID:   item.DeviceID,
+		Kind:       string(item.Kind),
+		Message:    item.Message,
+		FireAt:     item.FireAt,
+		Recurrence: string(item.Recurrence),
+		Status:     string(item.Status),
+		FiredAt:    item.FiredAt,
+		Attempts:   item.Attempts,
+		CreatedAt:  item.CreatedAt,
+		UpdatedAt:  item.UpdatedAt,
+	}
+}
+
+// fromModel 将存储模型转换为领域对象
+func (r *reminderRepository) fromModel(model *Reminder) *reminder.Reminder {
+	return &reminder.Reminder{
+		ID:         model.ID,
+		DeviceID:   model.DeviceID,
+		Kind:       reminder.Kind(model.Kind),
+		Message:    model.Message,
+		FireAt:     model.FireAt,
+		Recurrence: reminder.Recurrence(model.Recurrence),
+		Status:     reminder.Status(model.Status),
+		FiredAt:    model.FiredAt,
+		Attempts:   model.Attempts,
+		CreatedAt:  model.CreatedAt,
+		UpdatedAt:  model.UpdatedAt,
+	}
+}
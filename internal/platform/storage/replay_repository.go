@@ -0,0 +1,180 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/replay"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// ReplayRun 对话回放运行存储模型
+type ReplayRun struct {
+	ID           string    `gorm:"type:varchar(64);primaryKey"`
+	SessionID    string    `gorm:"type:varchar(255);index;not null"`
+	DeviceID     string    `gorm:"type:varchar(255);index"`
+	Options      string    `gorm:"type:text"` // JSON
+	ModelName    string    `gorm:"type:varchar(255)"`
+	SystemPrompt string    `gorm:"type:text"`
+	Status       string    `gorm:"type:varchar(16);index;not null"`
+	Error        string    `gorm:"type:text"`
+	Summary      string    `gorm:"type:text"` // JSON
+	Turns        string    // JSON，不指定类型以便 MySQL 使用 longtext
+	CreatedAt    time.Time `gorm:"index"`
+	FinishedAt   *time.Time
+}
+
+// TableName 指定表名
+func (ReplayRun) TableName() string {
+	return "replay_runs"
+}
+
+// replayRepository 对话回放仓库实现
+type replayRepository struct {
+	db *gorm.DB
+}
+
+// NewReplayRepository 创建对话回放仓库实例
+func NewReplayRepository(db *gorm.DB) replay.Repository {
+	return &replayRepository{
+		db: db,
+	}
+}
+
+// SaveRun 保存回放运行
+func (r *replayRepository) SaveRun(ctx context.Context, run *replay.Run) error {
+	model, err := r.toModel(run)
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "replay.save_run", "failed to encode replay run", err)
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "replay.save_run", "failed to save replay run", err)
+	}
+	return nil
+}
+
+// UpdateRun 更新回放运行
+func (r *replayRepository) UpdateRun(ctx context.Context, run *replay.Run) error {
+	model, err := r.toModel(run)
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "replay.update_run", "failed to encode replay run", err)
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "replay.update_run", "failed to update replay run", err)
+	}
+	return nil
+}
+
+// FindRun 根据ID查找回放运行
+func (r *replayRepository) FindRun(ctx context.Context, id string) (*replay.Run, error) {
+	var model ReplayRun
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "replay.find_run", "failed to find replay run", err)
+	}
+	return r.fromModel(&model), nil
+}
+
+// ListRuns 分页查询回放运行，不加载各轮明细
+func (r *replayRepository) ListRuns(ctx context.Context, filter replay.RunFilter) ([]*replay.Run, int64, error) {
+	query := r.db.WithContext(ctx).Model(&ReplayRun{})
+	if filter.SessionID != "" {
+		query = query.Where("session_id = ?", filter.SessionID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "replay.list_runs", "failed to count replay runs", err)
+	}
+
+	var models []ReplayRun
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := query.Omit("turns").Order("created_at DESC").Offset(offset).Limit(filter.PageSize).Find(&models).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "replay.list_runs", "failed to list replay runs", err)
+	}
+
+	items := make([]*replay.Run, len(models))
+	for i := range models {
+		items[i] = r.fromModel(&models[i])
+	}
+	return items, total, nil
+}
+
+// FailUnfinished 将未完成的运行标记为失败
+func (r *replayRepository) FailUnfinished(ctx context.Context, reason string) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&ReplayRun{}).
+		Where("status IN ?", []string{string(replay.RunPending), string(replay.RunRunning)}).
+		Updates(map[string]interface{}{
+			"status":      string(replay.RunFailed),
+			"error":       reason,
+			"finished_at": time.Now(),
+		})
+	if result.Error != nil {
+		return 0, errors.Wrap(errors.KindStorage, "replay.fail_unfinished", "failed to mark unfinished replay runs", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// toModel 将领域对象转换为存储模型
+func (r *replayRepository) toModel(run *replay.Run) (*ReplayRun, error) {
+	options, err := json.Marshal(run.Options)
+	if err != nil {
+		return nil, err
+	}
+	var summary []byte
+	if run.Summary != nil {
+		if summary, err = json.Marshal(run.Summary); err != nil {
+			return nil, err
+		}
+	}
+	var turns []byte
+	if len(run.Turns) > 0 {
+		if turns, err = json.Marshal(run.Turns); err != nil {
+			return nil, err
+		}
+	}
+	return &ReplayRun{
+		ID:           run.ID,
+		SessionID:    run.SessionID,
+		DeviceID:     run.DeviceID,
+		Options:      string(options),
+		ModelName:    run.ModelName,
+		SystemPrompt: run.SystemPrompt,
+		Status:       string(run.Status),
+		Error:        run.Error,
+		Summary:      string(summary),
+		Turns:        string(turns),
+		CreatedAt:    run.CreatedAt,
+		FinishedAt:   run.FinishedAt,
+	}, nil
+}
+
+// fromModel 将存储模型转换为领域对象
+func (r *replayRepository) fromModel(m *ReplayRun) *replay.Run {
+	run := &replay.Run{
+		ID:           m.ID,
+		SessionID:    m.SessionID,
+		DeviceID:     m.DeviceID,
+		ModelName:    m.ModelName,
+		SystemPrompt: m.SystemPrompt,
+		Status:       replay.RunStatus(m.Status),
+		Error:        m.Error,
+		CreatedAt:    m.CreatedAt,
+		FinishedAt:   m.FinishedAt,
+	}
+	if m.Options != "" {
+		_ = json.Unmarshal([]byte(m.Options), &run.Options)
+	}
+	if m.Summary != "" {
+		_ = json.Unmarshal([]byte(m.Summary), &run.Summary)
+	}
+	if m.Turns != "" {
+		_ = json.Unmarshal([]byte(m.Turns), &run.Turns)
+	}
+	return run
+}
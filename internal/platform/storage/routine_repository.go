@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/routine"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// Routine 例程存储模型
+type Routine struct {
+	ID         string `gorm:"type:varchar(64);primaryKey"`
+	DeviceID   string `gorm:"type:varchar(128);index;not null"`
+	TenantID   string `gorm:"type:varchar(64)"`
+	Name       string `gorm:"type:varchar(128);not null"`
+	Schedule   string `gorm:"type:varchar(128)"`
+	Phrase     string `gorm:"type:varchar(128)"`
+	Actions    string `gorm:"type:text"` // JSON 数组
+	Enabled    bool
+	LastRunAt  *time.Time
+	LastStatus string `gorm:"type:varchar(16)"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// TableName 指定表名
+func (Routine) TableName() string {
+	return "routines"
+}
+
+// routineRepository 例程仓库实现
+type routineRepository struct {
+	db *gorm.DB
+}
+
+// NewRoutineRepository 创建例程仓库实例
+func NewRoutineRepository(db *gorm.DB) routine.Repository {
+	return &routineRepository{
+		db: db,
+	}
+}
+
+// List 查询全部例程
+func (r *routineRepository) List(ctx context.Context) ([]*routine.Routine, error) {
+	var models []Routine
+	if err := r.db.WithContext(ctx).Order("created_at").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "routine.list", "failed to list routines", err)
+	}
+	items := make([]*routine.Routine, 0, len(models))
+	for i := range models {
+		items = append(items, r.fromModel(&models[i]))
+	}
+	return items, nil
+}
+
+// Save 新建或更新例程
+func (r *routineRepository) Save(ctx context.Context, item *routine.Routine) error {
+	actions, err := json.Marshal(item.Actions)
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "routine.encode", "failed to encode routine actions", err)
+	}
+	model := &Routine{
+		ID:         item.ID,
+		DeviceID:   item.DeviceID,
+		TenantID:   item.TenantID,
+		Name:       item.Name,
+		Schedule:   item.Schedule,
+		Phrase:     item.Phrase,
+		Actions:    string(actions),
+		Enabled:    item.Enabled,
+		LastRunAt:  item.LastRunAt,
+		LastStatus: string(item.LastStatus),
+		CreatedAt:  item.CreatedAt,
+		UpdatedAt:  item.UpdatedAt,
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "routine.save", "failed to save routine", err)
+	}
+	return nil
+}
+
+// Delete 删除例程
+func (r *routineRepository) Delete(ctx context.Context, id string) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&Routine{}).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "routine.delete", "failed to delete routine", err)
+	}
+	return nil
+}
+
+// fromModel 将存储模型转换为领域对象
+func (r *routineRepository) fromModel(model *Routine) *routine.Routine {
+	item := &routine.Routine{
+		ID:         model.ID,
+		DeviceID:   model.DeviceID,
+		TenantID:   model.TenantID,
+		Name:       model.Name,
+		Schedule:   model.Schedule,
+		Phrase:     model.Phrase,
+		Actions:    []routine.Action{},
+		Enabled:    model.Enabled,
+		LastRunAt:  model.LastRunAt,
+		LastStatus: routine.Status(model.LastStatus),
+		CreatedAt:  model.CreatedAt,
+		UpdatedAt:  model.UpdatedAt,
+	}
+	if model.Actions != "" {
+		json.Unmarshal([]byte(model.Actions), &item.Actions)
+	}
+	return item
+}
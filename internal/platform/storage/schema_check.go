@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// SchemaProblems 检查数据库是否包含全部模型的表和列，返回缺失项说明，不做任何修改；
+// 缺失的表和列会在服务启动时自动迁移补齐
+func SchemaProblems(db *gorm.DB) ([]string, error) {
+	migrator := db.Migrator()
+	var problems []string
+	for _, model := range schemaModels() {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("failed to parse model %T: %w", model, err)
+		}
+		table := stmt.Schema.Table
+		if !migrator.HasTable(model) {
+			problems = append(problems, fmt.Sprintf("缺少表 %s", table))
+			continue
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			if !migrator.HasColumn(model, field.DBName) {
+				problems = append(problems, fmt.Sprintf("表 %s 缺少列 %s", table, field.DBName))
+			}
+		}
+	}
+	return problems, nil
+}
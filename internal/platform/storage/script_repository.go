@@ -0,0 +1,199 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/script"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// Script 脚本存储模型，Source 为当前版本的源码
+type Script struct {
+	ID          string `gorm:"type:varchar(64);primaryKey"`
+	Name        string `gorm:"type:varchar(255);not null"`
+	Description string `gorm:"type:text"`
+	Language    string `gorm:"type:varchar(32);not null"`
+	Version     int    `gorm:"not null"`
+	Source      string `gorm:"type:text"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TableName 指定表名
+func (Script) TableName() string {
+	return "scripts"
+}
+
+// ScriptVersion 脚本版本存储模型
+type ScriptVersion struct {
+	ScriptID  string `gorm:"type:varchar(64);primaryKey"`
+	Version   int    `gorm:"primaryKey;autoIncrement:false"`
+	Source    string `gorm:"type:text"`
+	Comment   string `gorm:"type:varchar(512)"`
+	CreatedAt time.Time
+}
+
+// TableName 指定表名
+func (ScriptVersion) TableName() string {
+	return "script_versions"
+}
+
+// scriptRepository 脚本仓库实现
+type scriptRepository struct {
+	db *gorm.DB
+}
+
+// NewScriptRepository 创建脚本仓库实例
+func NewScriptRepository(db *gorm.DB) script.Repository {
+	return &scriptRepository{
+		db: db,
+	}
+}
+
+// Save 新建脚本及其第一个版本
+func (r *scriptRepository) Save(ctx context.Context, s *script.Script, v *script.Version) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(r.toModel(s)).Error; err != nil {
+			return err
+		}
+		return tx.Create(r.toVersionModel(v)).Error
+	})
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "script.save", "failed to save script", err)
+	}
+	return nil
+}
+
+// Update 更新脚本，v 不为 nil 时同时写入新版本
+func (r *scriptRepository) Update(ctx context.Context, s *script.Script, v *script.Version) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(r.toModel(s)).Error; err != nil {
+			return err
+		}
+		if v == nil {
+			return nil
+		}
+		return tx.Create(r.toVersionModel(v)).Error
+	})
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "script.update", "failed to update script", err)
+	}
+	return nil
+}
+
+// FindByID 根据ID查找脚本
+func (r *scriptRepository) FindByID(ctx context.Context, id string) (*script.Script, error) {
+	var model Script
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "script.find_by_id", "failed to find script", err)
+	}
+	return r.fromModel(&model), nil
+}
+
+// List 查询全部脚本
+func (r *scriptRepository) List(ctx context.Context) ([]*script.Script, error) {
+	var models []Script
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "script.list", "failed to list scripts", err)
+	}
+
+	items := make([]*script.Script, len(models))
+	for i := range models {
+		items[i] = r.fromModel(&models[i])
+	}
+	return items, nil
+}
+
+// FindVersion 查找脚本的指定版本
+func (r *scriptRepository) FindVersion(ctx context.Context, id string, version int) (*script.Version, error) {
+	var model ScriptVersion
+	if err := r.db.WithContext(ctx).Where("script_id = ? AND version = ?", id, version).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "script.find_version", "failed to find script version", err)
+	}
+	return r.fromVersionModel(&model), nil
+}
+
+// ListVersions 查询脚本的全部版本，新版本在前
+func (r *scriptRepository) ListVersions(ctx context.Context, id string) ([]*script.Version, error) {
+	var models []ScriptVersion
+	if err := r.db.WithContext(ctx).Where("script_id = ?", id).Order("version DESC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "script.list_versions", "failed to list script versions", err)
+	}
+
+	items := make([]*script.Version, len(models))
+	for i := range models {
+		items[i] = r.fromVersionModel(&models[i])
+	}
+	return items, nil
+}
+
+// Delete 删除脚本及其全部版本
+func (r *scriptRepository) Delete(ctx context.Context, id string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("script_id = ?", id).Delete(&ScriptVersion{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&Script{}).Error
+	})
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "script.delete", "failed to delete script", err)
+	}
+	return nil
+}
+
+// toModel 将领域对象转换为存储模型
+func (r *scriptRepository) toModel(s *script.Script) *Script {
+	return &Script{
+		ID:          s.ID,
+		Name:        s.Name,
+		Description: s.Description,
+		Language:    string(s.Language),
+		Version:     s.Version,
+		Source:      s.Source,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+// fromModel 将存储模型转换为领域对象
+func (r *scriptRepository) fromModel(model *Script) *script.Script {
+	return &script.Script{
+		ID:          model.ID,
+		Name:        model.Name,
+		Description: model.Description,
+		Language:    script.Language(model.Language),
+		Version:     model.Version,
+		Source:      model.Source,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+	}
+}
+
+func (r *scriptRepository) toVersionModel(v *script.Version) *ScriptVersion {
+	return &ScriptVersion{
+		ScriptID:  v.ScriptID,
+		Version:   v.Version,
+		Source:    v.Source,
+		Comment:   v.Comment,
+		CreatedAt: v.CreatedAt,
+	}
+}
+
+func (r *scriptRepository) fromVersionModel(model *ScriptVersion) *script.Version {
+	return &script.Version{
+		ScriptID:  model.ScriptID,
+		Version:   model.Version,
+		Source:    model.Source,
+		Comment:   model.Comment,
+		CreatedAt: model.CreatedAt,
+	}
+}
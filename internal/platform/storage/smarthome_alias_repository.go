@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/smarthome"
+	"xiaozhi-server-go/internal/domain/tenant"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// SmartHomeAlias 智能家居设备别名存储模型
+type SmartHomeAlias struct {
+	TenantID  string    `gorm:"type:varchar(64);primaryKey"`
+	AliasKey  string    `gorm:"type:varchar(128);primaryKey"` // 规范化后的别名
+	Alias     string    `gorm:"type:varchar(128);not null"`
+	EntityID  string    `gorm:"type:varchar(255);index;not null"`
+	Source    string    `gorm:"type:varchar(16);not null"`
+	Hits      int       `gorm:"default:0;not null"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime:false"`
+}
+
+// TableName 指定表名
+func (SmartHomeAlias) TableName() string {
+	return "smart_home_aliases"
+}
+
+// smartHomeAliasRepository 设备别名仓库实现
+type smartHomeAliasRepository struct {
+	db *gorm.DB
+}
+
+// NewSmartHomeAliasRepository 创建设备别名仓库实例
+func NewSmartHomeAliasRepository(db *gorm.DB) smarthome.AliasRepository {
+	return &smartHomeAliasRepository{
+		db: db,
+	}
+}
+
+// List 列出租户的全部别名
+func (r *smartHomeAliasRepository) List(ctx context.Context) ([]*smarthome.Alias, error) {
+	var models []SmartHomeAlias
+	if err := r.scoped(ctx).Order("alias_key ASC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "smarthome.list_aliases", "failed to list smart home aliases", err)
+	}
+	items := make([]*smarthome.Alias, len(models))
+	for i := range models {
+		items[i] = r.fromModel(&models[i])
+	}
+	return items, nil
+}
+
+// Find 按规范化后的别名查找
+func (r *smartHomeAliasRepository) Find(ctx context.Context, key string) (*smarthome.Alias, error) {
+	var model SmartHomeAlias
+	if err := r.scoped(ctx).Where("alias_key = ?", key).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "smarthome.find_alias", "failed to find smart home alias", err)
+	}
+	return r.fromModel(&model), nil
+}
+
+// Save 创建或覆盖别名
+func (r *smartHomeAliasRepository) Save(ctx context.Context, a *smarthome.Alias) error {
+	model := &SmartHomeAlias{
+		TenantID:  tenant.IDFrom(ctx),
+		AliasKey:  a.Key,
+		Alias:     a.Alias,
+		EntityID:  a.EntityID,
+		Source:    a.Source,
+		Hits:      a.Hits,
+		UpdatedAt: a.UpdatedAt,
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "smarthome.save_alias", "failed to save smart home alias", err)
+	}
+	return nil
+}
+
+// Delete 删除别名
+func (r *smartHomeAliasRepository) Delete(ctx context.Context, key string) error {
+	if err := r.scoped(ctx).Where("alias_key = ?", key).Delete(&SmartHomeAlias{}).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "smarthome.delete_alias", "failed to delete smart home alias", err)
+	}
+	return nil
+}
+
+// Hit 累加别名的匹配次数
+func (r *smartHomeAliasRepository) Hit(ctx context.Context, key string) error {
+	err := r.scoped(ctx).Model(&SmartHomeAlias{}).Where("alias_key = ?", key).
+		UpdateColumn("hits", gorm.Expr("hits + 1")).Error
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "smarthome.hit_alias", "failed to update smart home alias hits", err)
+	}
+	return nil
+}
+
+// scoped 返回限定在上下文租户内的查询
+func (r *smartHomeAliasRepository) scoped(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Where("tenant_id = ?", tenant.IDFrom(ctx))
+}
+
+func (r *smartHomeAliasRepository) fromModel(m *SmartHomeAlias) *smarthome.Alias {
+	return &smarthome.Alias{
+		Key:       m.AliasKey,
+		Alias:     m.Alias,
+		EntityID:  m.EntityID,
+		Source:    m.Source,
+		Hits:      m.Hits,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/speechtext"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// SpeechTextProfile 播报文本后处理方案存储模型
+type SpeechTextProfile struct {
+	Name        string    `gorm:"type:varchar(64);primaryKey"`
+	Description string    `gorm:"type:varchar(1024)"`
+	Stages      string    `gorm:"type:text"` // JSON
+	UpdatedAt   time.Time `gorm:"autoUpdateTime:false"`
+}
+
+// TableName 指定表名
+func (SpeechTextProfile) TableName() string {
+	return "speech_text_profiles"
+}
+
+// SpeechTextAssignment 设备方案分配存储模型
+type SpeechTextAssignment struct {
+	DeviceID  string    `gorm:"type:varchar(255);primaryKey"`
+	Profile   string    `gorm:"type:varchar(64);index"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime:false"`
+}
+
+// TableName 指定表名
+func (SpeechTextAssignment) TableName() string {
+	return "speech_text_assignments"
+}
+
+// speechTextRepository 播报文本后处理方案仓库实现
+type speechTextRepository struct {
+	db *gorm.DB
+}
+
+// NewSpeechTextRepository 创建播报文本后处理方案仓库实例
+func NewSpeechTextRepository(db *gorm.DB) speechtext.Repository {
+	return &speechTextRepository{
+		db: db,
+	}
+}
+
+// Save 创建或更新方案
+func (r *speechTextRepository) Save(ctx context.Context, p *speechtext.Profile) error {
+	data, err := json.Marshal(p.Stages)
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "speechtext.save", "failed to encode speech text stages", err)
+	}
+	model := &SpeechTextProfile{
+		Name:        p.Name,
+		Description: p.Description,
+		Stages:      string(data),
+		UpdatedAt:   p.UpdatedAt,
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "speechtext.save", "failed to save speech text profile", err)
+	}
+	return nil
+}
+
+// Find 查找方案
+func (r *speechTextRepository) Find(ctx context.Context, name string) (*speechtext.Profile, error) {
+	var model SpeechTextProfile
+	if err := r.db.WithContext(ctx).Where("name = ?", name).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "speechtext.find", "failed to find speech text profile", err)
+	}
+	return r.fromProfile(&model), nil
+}
+
+// List 列出全部方案
+func (r *speechTextRepository) List(ctx context.Context) ([]*speechtext.Profile, error) {
+	var models []SpeechTextProfile
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "speechtext.list", "failed to list speech text profiles", err)
+	}
+	items := make([]*speechtext.Profile, len(models))
+	for i := range models {
+		items[i] = r.fromProfile(&models[i])
+	}
+	return items, nil
+}
+
+// Delete 删除方案及其设备分配
+func (r *speechTextRepository) Delete(ctx context.Context, name string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("profile = ?", name).Delete(&SpeechTextAssignment{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("name = ?", name).Delete(&SpeechTextProfile{}).Error
+	})
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "speechtext.delete", "failed to delete speech text profile", err)
+	}
+	return nil
+}
+
+// SaveAssignment 保存或覆盖设备的方案分配
+func (r *speechTextRepository) SaveAssignment(ctx context.Context, a *speechtext.Assignment) error {
+	model := &SpeechTextAssignment{
+		DeviceID:  a.DeviceID,
+		Profile:   a.Profile,
+		UpdatedAt: a.UpdatedAt,
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "speechtext.save_assignment", "failed to save speech text assignment", err)
+	}
+	return nil
+}
+
+// FindAssignment 查找设备的方案分配
+func (r *speechTextRepository) FindAssignment(ctx context.Context, deviceID string) (*speechtext.Assignment, error) {
+	var model SpeechTextAssignment
+	if err := r.db.WithContext(ctx).Where("device_id = ?", deviceID).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "speechtext.find_assignment", "failed to find speech text assignment", err)
+	}
+	return &speechtext.Assignment{DeviceID: model.DeviceID, Profile: model.Profile, UpdatedAt: model.UpdatedAt}, nil
+}
+
+// ListAssignments 列出全部分配
+func (r *speechTextRepository) ListAssignments(ctx context.Context) ([]*speechtext.Assignment, error) {
+	var models []SpeechTextAssignment
+	if err := r.db.WithContext(ctx).Order("device_id ASC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "speechtext.list_assignments", "failed to list speech text assignments", err)
+	}
+	items := make([]*speechtext.Assignment, len(models))
+	for i, m := range models {
+		items[i] = &speechtext.Assignment{DeviceID: m.DeviceID, Profile: m.Profile, UpdatedAt: m.UpdatedAt}
+	}
+	return items, nil
+}
+
+// DeleteAssignment 删除设备的方案分配
+func (r *speechTextRepository) DeleteAssignment(ctx context.Context, deviceID string) error {
+	if err := r.db.WithContext(ctx).Where("device_id = ?", deviceID).Delete(&SpeechTextAssignment{}).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "speechtext.delete_assignment", "failed to delete speech text assignment", err)
+	}
+	return nil
+}
+
+func (r *speechTextRepository) fromProfile(m *SpeechTextProfile) *speechtext.Profile {
+	p := &speechtext.Profile{
+		Name:        m.Name,
+		Description: m.Description,
+		Stages:      []speechtext.Stage{},
+		UpdatedAt:   m.UpdatedAt,
+	}
+	if m.Stages != "" {
+		_ = json.Unmarshal([]byte(m.Stages), &p.Stages)
+	}
+	return p
+}
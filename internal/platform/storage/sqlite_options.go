@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// SQLite 调优参数的默认值
+const (
+	defaultSQLiteJournalMode   = "WAL"
+	defaultSQLiteSynchronous   = "NORMAL"
+	defaultSQLiteBusyTimeoutMs = 5000
+	defaultMaxOpenConns        = 25
+	defaultMaxIdleConns        = 25
+)
+
+// SQLiteOptions SQLite 调优配置，零值字段使用默认值
+type SQLiteOptions struct {
+	JournalMode     string `json:"journal_mode,omitempty"`     // 日志模式，默认 WAL，读写互不阻塞
+	Synchronous     string `json:"synchronous,omitempty"`      // 同步级别，默认 NORMAL（WAL 下足够安全）
+	BusyTimeoutMs   int    `json:"busy_timeout_ms,omitempty"`  // 遇到锁时的等待时长，默认 5000 毫秒
+	SerializeWrites *bool  `json:"serialize_writes,omitempty"` // 进程内串行化写操作，默认开启
+}
+
+// withDefaults 填充默认值
+func (o SQLiteOptions) withDefaults() SQLiteOptions {
+	if o.JournalMode == "" {
+		o.JournalMode = defaultSQLiteJournalMode
+	}
+	if o.Synchronous == "" {
+		o.Synchronous = defaultSQLiteSynchronous
+	}
+	if o.BusyTimeoutMs <= 0 {
+		o.BusyTimeoutMs = defaultSQLiteBusyTimeoutMs
+	}
+	if o.SerializeWrites == nil {
+		enabled := true
+		o.SerializeWrites = &enabled
+	}
+	return o
+}
+
+// validate 校验日志模式和同步级别
+func (o SQLiteOptions) validate() error {
+	switch strings.ToUpper(o.JournalMode) {
+	case "", "WAL", "DELETE", "TRUNCATE", "PERSIST", "MEMORY", "OFF":
+	default:
+		return fmt.Errorf("unsupported sqlite journal_mode: %s", o.JournalMode)
+	}
+	switch strings.ToUpper(o.Synchronous) {
+	case "", "OFF", "NORMAL", "FULL", "EXTRA":
+	default:
+		return fmt.Errorf("unsupported sqlite synchronous: %s", o.Synchronous)
+	}
+	if o.BusyTimeoutMs < 0 {
+		return fmt.Errorf("sqlite busy_timeout_ms must not be negative")
+	}
+	return nil
+}
+
+// sqliteDSN 把调优参数拼入连接串，驱动会对连接池中的每个连接执行对应的 PRAGMA
+// busy_timeout 是连接级设置，只在打开后执行一次 PRAGMA 会漏掉池里的其他连接
+func sqliteDSN(path string, opts SQLiteOptions) string {
+	opts = opts.withDefaults()
+	params := url.Values{}
+	params.Set("_journal_mode", strings.ToUpper(opts.JournalMode))
+	params.Set("_synchronous", strings.ToUpper(opts.Synchronous))
+	params.Set("_busy_timeout", fmt.Sprintf("%d", opts.BusyTimeoutMs))
+	// 事务开始即获取写锁，避免读事务升级为写事务时直接返回 database is locked（busy_timeout 对此无效）
+	params.Set("_txlock", "immediate")
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return path + separator + params.Encode()
+}
+
+// openSQLite 按调优参数打开 SQLite 数据库，并挂载写串行化和耗时统计
+func openSQLite(path string, opts SQLiteOptions, gormConfig *gorm.Config) (*gorm.DB, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	opts = opts.withDefaults()
+	if gormConfig == nil {
+		gormConfig = &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)}
+	}
+
+	gormDB, err := gorm.Open(sqlite.Open(sqliteDSN(path, opts)), gormConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err := instrumentDB(gormDB, "sqlite", strings.ToUpper(opts.JournalMode), *opts.SerializeWrites); err != nil {
+		return nil, fmt.Errorf("failed to instrument database: %w", err)
+	}
+	return gormDB, nil
+}
+
+// applyConnectionPool 应用连接池配置，未配置的上限沿用 25，生存时间为 0 表示连接不过期
+func applyConnectionPool(sqlDB *sql.DB, pool ConnectionPool) {
+	maxOpenConns := pool.MaxOpenConns
+	if maxOpenConns <= 0 {
+		maxOpenConns = defaultMaxOpenConns
+	}
+	maxIdleConns := pool.MaxIdleConns
+	if maxIdleConns <= 0 {
+		maxIdleConns = defaultMaxIdleConns
+	}
+	if maxIdleConns > maxOpenConns {
+		maxIdleConns = maxOpenConns
+	}
+
+	sqlDB.SetMaxOpenConns(maxOpenConns)
+	sqlDB.SetMaxIdleConns(maxIdleConns)
+	sqlDB.SetConnMaxLifetime(nonNegative(pool.ConnMaxLifetime))
+	sqlDB.SetConnMaxIdleTime(nonNegative(pool.ConnMaxIdleTime))
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// SnapshotSQLite 用 VACUUM INTO 把数据库写成一致的快照文件，期间其他连接仍可读写
+// dest 不能已存在，快照不含 WAL，可直接作为独立的数据库文件打开
+func SnapshotSQLite(ctx context.Context, db *gorm.DB, dest string) error {
+	if db == nil {
+		return fmt.Errorf("database is not initialized")
+	}
+	if name := db.Dialector.Name(); name != "sqlite" {
+		return fmt.Errorf("snapshot is not supported for %s", name)
+	}
+	if err := db.WithContext(ctx).Exec("VACUUM INTO ?", dest).Error; err != nil {
+		return fmt.Errorf("failed to snapshot database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"xiaozhi-server-go/internal/domain/tenant"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// Tenant 租户存储模型
+type Tenant struct {
+	ID            string `gorm:"type:varchar(64);primaryKey"`
+	Name          string `gorm:"type:varchar(255);not null"`
+	Enabled       bool   `gorm:"default:true"`
+	TokenHash     string `gorm:"type:varchar(64);index"`
+	MaxDevices    int    `gorm:"default:0"`
+	DailyRequests int64  `gorm:"default:0"`
+	DailyTokens   int64  `gorm:"default:0"`
+	Providers     string `gorm:"type:text"` // JSON 对象，租户的供应商配置
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// TableName 指定表名
+func (Tenant) TableName() string {
+	return "tenants"
+}
+
+// TenantUsage 租户每日用量存储模型
+type TenantUsage struct {
+	TenantID string `gorm:"type:varchar(64);primaryKey"`
+	Day      string `gorm:"type:varchar(10);primaryKey"` // YYYY-MM-DD
+	Requests int64
+	Tokens   int64
+}
+
+// TableName 指定表名
+func (TenantUsage) TableName() string {
+	return "tenant_usage"
+}
+
+// tenantRepository 租户仓库实现
+type tenantRepository struct {
+	db *gorm.DB
+}
+
+// NewTenantRepository 创建租户仓库实例
+func NewTenantRepository(db *gorm.DB) tenant.Repository {
+	return &tenantRepository{
+		db: db,
+	}
+}
+
+// Save 创建或更新租户
+func (r *tenantRepository) Save(ctx context.Context, t *tenant.Tenant) error {
+	var providers string
+	if !t.Providers.Empty() {
+		data, err := json.Marshal(t.Providers)
+		if err != nil {
+			return errors.Wrap(errors.KindStorage, "tenant.encode", "failed to encode tenant providers", err)
+		}
+		providers = string(data)
+	}
+	model := &Tenant{
+		ID:            t.ID,
+		Name:          t.Name,
+		Enabled:       t.Enabled,
+		TokenHash:     t.TokenHash,
+		MaxDevices:    t.Quota.MaxDevices,
+		DailyRequests: t.Quota.DailyRequests,
+		DailyTokens:   t.Quota.DailyTokens,
+		Providers:     providers,
+		CreatedAt:     t.CreatedAt,
+		UpdatedAt:     t.UpdatedAt,
+	}
+	// Save 对零值 bool 也会写入，保证停用状态能落库
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "tenant.save", "failed to save tenant", err)
+	}
+	return nil
+}
+
+// List 列出全部租户
+func (r *tenantRepository) List(ctx context.Context) ([]*tenant.Tenant, error) {
+	var models []Tenant
+	if err := r.db.WithContext(ctx).Order("id ASC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "tenant.list", "failed to list tenants", err)
+	}
+	items := make([]*tenant.Tenant, len(models))
+	for i, m := range models {
+		items[i] = &tenant.Tenant{
+			ID:        m.ID,
+			Name:      m.Name,
+			Enabled:   m.Enabled,
+			TokenHash: m.TokenHash,
+			Quota: tenant.Quota{
+				MaxDevices:    m.MaxDevices,
+				DailyRequests: m.DailyRequests,
+				DailyTokens:   m.DailyTokens,
+			},
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		}
+		if m.Providers != "" {
+			json.Unmarshal([]byte(m.Providers), &items[i].Providers)
+		}
+	}
+	return items, nil
+}
+
+// Delete 删除租户及其用量、提示词模板和分配
+func (r *tenantRepository) Delete(ctx context.Context, id string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tenant_id = ?", id).Delete(&PromptAssignment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("tenant_id = ?", id).Delete(&PromptTemplate{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("tenant_id = ?", id).Delete(&TenantUsage{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&Tenant{}).Error
+	})
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "tenant.delete", "failed to delete tenant", err)
+	}
+	return nil
+}
+
+// AddUsage 累加每日用量
+func (r *tenantRepository) AddUsage(ctx context.Context, id, day string, requests, tokens int64) error {
+	row := &TenantUsage{TenantID: id, Day: day, Requests: requests, Tokens: tokens}
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "tenant_id"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"requests": gorm.Expr("tenant_usage.requests + ?", requests),
+			"tokens":   gorm.Expr("tenant_usage.tokens + ?", tokens),
+		}),
+	}).Create(row).Error
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "tenant.add_usage", "failed to record tenant usage", err)
+	}
+	return nil
+}
+
+// GetUsage 查询每日用量
+func (r *tenantRepository) GetUsage(ctx context.Context, id, day string) (*tenant.Usage, error) {
+	var model TenantUsage
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND day = ?", id, day).First(&model).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, errors.Wrap(errors.KindStorage, "tenant.get_usage", "failed to get tenant usage", err)
+	}
+	return &tenant.Usage{TenantID: id, Day: day, Requests: model.Requests, Tokens: model.Tokens}, nil
+}
+
+// CountDevices 统计租户的设备数
+func (r *tenantRepository) CountDevices(ctx context.Context, id string) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&Device{}).Where("tenant_id = ?", id).Count(&count).Error; err != nil {
+		return 0, errors.Wrap(errors.KindStorage, "tenant.count_devices", "failed to count tenant devices", err)
+	}
+	return count, nil
+}
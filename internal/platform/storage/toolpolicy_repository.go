@@ -0,0 +1,140 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/toolpolicy"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// ToolPolicyAudit 工具调用审计存储模型
+type ToolPolicyAudit struct {
+	ID        string    `gorm:"type:varchar(64);primaryKey"`
+	DeviceID  string    `gorm:"type:varchar(255);index"`
+	SessionID string    `gorm:"type:varchar(255)"`
+	Agent     string    `gorm:"type:varchar(64);index"`
+	Kind      string    `gorm:"type:varchar(32)"`
+	Name      string    `gorm:"type:varchar(255);index"`
+	Arguments string    `gorm:"type:text"`
+	Allowed   bool      `gorm:"default:false"`
+	Profile   string    `gorm:"type:varchar(255)"`
+	Rule      string    `gorm:"type:varchar(255)"`
+	Reason    string    `gorm:"type:text"`
+	CreatedAt time.Time `gorm:"index"`
+}
+
+// TableName 指定表名
+func (ToolPolicyAudit) TableName() string {
+	return "tool_policy_audits"
+}
+
+// toolPolicyRepository 工具调用审计仓库实现
+type toolPolicyRepository struct {
+	db *gorm.DB
+}
+
+// NewToolPolicyRepository 创建工具调用审计仓库实例
+func NewToolPolicyRepository(db *gorm.DB) toolpolicy.Repository {
+	return &toolPolicyRepository{
+		db: db,
+	}
+}
+
+// SaveBatch 批量保存审计记录
+func (r *toolPolicyRepository) SaveBatch(ctx context.Context, records []*toolpolicy.AuditRecord) error {
+	models := make([]ToolPolicyAudit, len(records))
+	for i, rec := range records {
+		models[i] = ToolPolicyAudit{
+			ID:        rec.ID,
+			DeviceID:  rec.DeviceID,
+			SessionID: rec.SessionID,
+			Agent:     rec.Agent,
+			Kind:      string(rec.Kind),
+			Name:      rec.Name,
+			Arguments: rec.Arguments,
+			Allowed:   rec.Allowed,
+			Profile:   rec.Profile,
+			Rule:      rec.Rule,
+			Reason:    rec.Reason,
+			CreatedAt: rec.CreatedAt,
+		}
+	}
+	if err := r.db.WithContext(ctx).CreateInBatches(models, 100).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "toolpolicy.save_batch", "failed to save tool policy audits", err)
+	}
+	return nil
+}
+
+// List 按条件分页查询审计记录
+func (r *toolPolicyRepository) List(ctx context.Context, filter toolpolicy.AuditFilter) ([]*toolpolicy.AuditRecord, int64, error) {
+	query := r.db.WithContext(ctx).Model(&ToolPolicyAudit{})
+	if filter.DeviceID != "" {
+		query = query.Where("device_id = ?", filter.DeviceID)
+	}
+	if filter.Agent != "" {
+		query = query.Where("agent = ?", filter.Agent)
+	}
+	if filter.Name != "" {
+		query = query.Where("name = ?", filter.Name)
+	}
+	if filter.Allowed != nil {
+		query = query.Where("allowed = ?", *filter.Allowed)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at <= ?", filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "toolpolicy.list", "failed to count tool policy audits", err)
+	}
+
+	var models []ToolPolicyAudit
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(filter.PageSize).Find(&models).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "toolpolicy.list", "failed to list tool policy audits", err)
+	}
+
+	items := make([]*toolpolicy.AuditRecord, len(models))
+	for i, m := range models {
+		items[i] = &toolpolicy.AuditRecord{
+			ID:        m.ID,
+			DeviceID:  m.DeviceID,
+			SessionID: m.SessionID,
+			Agent:     m.Agent,
+			Kind:      toolpolicy.Kind(m.Kind),
+			Name:      m.Name,
+			Arguments: m.Arguments,
+			Allowed:   m.Allowed,
+			Profile:   m.Profile,
+			Rule:      m.Rule,
+			Reason:    m.Reason,
+			CreatedAt: m.CreatedAt,
+		}
+	}
+	return items, total, nil
+}
+
+// DeleteBefore 删除指定时间之前的审计记录
+func (r *toolPolicyRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("created_at < ?", before).Delete(&ToolPolicyAudit{})
+	if result.Error != nil {
+		return 0, errors.Wrap(errors.KindStorage, "toolpolicy.delete_before", "failed to purge tool policy audits", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// DeleteByDevice 删除设备的全部审计记录
+func (r *toolPolicyRepository) DeleteByDevice(ctx context.Context, deviceID string) (int64, error) {
+	result := r.db.WithContext(ctx).Where("device_id = ?", deviceID).Delete(&ToolPolicyAudit{})
+	if result.Error != nil {
+		return 0, errors.Wrap(errors.KindStorage, "toolpolicy.delete_device", "failed to delete device tool policy audits", result.Error)
+	}
+	return result.RowsAffected, nil
+}
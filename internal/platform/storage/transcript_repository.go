@@ -0,0 +1,461 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/transcript"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// TranscriptEntry 对话记录存储模型
+type TranscriptEntry struct {
+	ID         string    `gorm:"type:varchar(64);primaryKey"`
+	SessionID  string    `gorm:"type:varchar(255);index;not null"`
+	DeviceID   string    `gorm:"type:varchar(255);index"`
+	Round      int       `gorm:"default:0"`
+	Role       string    `gorm:"type:varchar(32);not null"`
+	Content    string    `gorm:"type:text"`
+	ToolName   string    `gorm:"type:varchar(255)"`
+	ToolCallID string    `gorm:"type:varchar(255)"`
+	ToolArgs   string    `gorm:"type:text"`
+	LatencyMs  int64     `gorm:"default:0"`
+	Redacted   bool      `gorm:"default:false"`
+	Traces     string    `gorm:"type:text"` // JSON 数组
+	CreatedAt  time.Time `gorm:"index"`
+}
+
+// TableName 指定表名
+func (TranscriptEntry) TableName() string {
+	return "conversation_transcripts"
+}
+
+// TranscriptFeedback 用户反馈存储模型
+type TranscriptFeedback struct {
+	ID        string    `gorm:"type:varchar(64);primaryKey"`
+	SessionID string    `gorm:"type:varchar(255);index;not null"`
+	EntryID   string    `gorm:"type:varchar(64);index"`
+	DeviceID  string    `gorm:"type:varchar(255);index"`
+	Round     int       `gorm:"default:0"`
+	Rating    int       `gorm:"default:0"`
+	Comment   string    `gorm:"type:text"`
+	Source    string    `gorm:"type:varchar(32)"`
+	CreatedAt time.Time `gorm:"index"`
+}
+
+// TableName 指定表名
+func (TranscriptFeedback) TableName() string {
+	return "conversation_feedback"
+}
+
+// TranscriptTurnLatency 对话轮次耗时存储模型
+type TranscriptTurnLatency struct {
+	ID              string    `gorm:"type:varchar(64);primaryKey"`
+	SessionID       string    `gorm:"type:varchar(255);index;not null"`
+	DeviceID        string    `gorm:"type:varchar(255);index"`
+	Round           int       `gorm:"default:0"`
+	CaptureMs       int64     `gorm:"default:0"`
+	ASRMs           int64     `gorm:"column:asr_ms;default:0"`
+	LLMFirstTokenMs int64     `gorm:"column:llm_first_token_ms;default:0"`
+	LLMCompleteMs   int64     `gorm:"column:llm_complete_ms;default:0"`
+	TTSFirstByteMs  int64     `gorm:"column:tts_first_byte_ms;default:0"`
+	PlaybackStartMs int64     `gorm:"default:0"`
+	TotalMs         int64     `gorm:"default:0"`
+	CreatedAt       time.Time `gorm:"index"`
+}
+
+// TableName 指定表名
+func (TranscriptTurnLatency) TableName() string {
+	return "conversation_turn_latencies"
+}
+
+// transcriptRepository 对话记录仓库实现
+type transcriptRepository struct {
+	db *gorm.DB
+}
+
+// NewTranscriptRepository 创建对话记录仓库实例
+func NewTranscriptRepository(db *gorm.DB) transcript.Repository {
+	return &transcriptRepository{
+		db: db,
+	}
+}
+
+// SaveBatch 批量保存消息
+func (r *transcriptRepository) SaveBatch(ctx context.Context, entries []*transcript.Entry) error {
+	models := make([]TranscriptEntry, len(entries))
+	for i, e := range entries {
+		models[i] = *r.toModel(e)
+	}
+	if err := r.db.WithContext(ctx).CreateInBatches(models, 100).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "transcript.save_batch", "failed to save transcript entries", err)
+	}
+	return nil
+}
+
+// conversationRow 会话聚合查询结果
+type conversationRow struct {
+	SessionID string
+	DeviceID  string
+	Entries   int64
+	Rounds    int
+	StartedAt string
+	LastAt    string
+}
+
+// ListConversations 按条件分页查询会话摘要
+func (r *transcriptRepository) ListConversations(ctx context.Context, filter transcript.Filter) ([]*transcript.Conversation, int64, error) {
+	query := r.db.WithContext(ctx).Model(&TranscriptEntry{})
+	if filter.DeviceID != "" {
+		query = query.Where("device_id = ?", filter.DeviceID)
+	}
+	if filter.SessionID != "" {
+		query = query.Where("session_id = ?", filter.SessionID)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at <= ?", filter.To)
+	}
+	if filter.Query != "" {
+		matched := r.db.Model(&TranscriptEntry{}).Select("session_id").Where("content LIKE ?", "%"+filter.Query+"%")
+		query = query.Where("session_id IN (?)", matched)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Distinct("session_id").Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "transcript.list", "failed to count conversations", err)
+	}
+
+	var rows []conversationRow
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := query.
+		Select("session_id, MAX(device_id) AS device_id, COUNT(*) AS entries, MAX(round) AS rounds, MIN(created_at) AS started_at, MAX(created_at) AS last_at").
+		Group("session_id").
+		Order("last_at DESC").
+		Offset(offset).
+		Limit(filter.PageSize).
+		Scan(&rows).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "transcript.list", "failed to list conversations", err)
+	}
+
+	items := make([]*transcript.Conversation, len(rows))
+	for i, row := range rows {
+		items[i] = &transcript.Conversation{
+			SessionID: row.SessionID,
+			DeviceID:  row.DeviceID,
+			Entries:   row.Entries,
+			Rounds:    row.Rounds,
+			StartedAt: parseAggregateTime(row.StartedAt),
+			LastAt:    parseAggregateTime(row.LastAt),
+		}
+	}
+	return items, total, nil
+}
+
+// ListEntries 查询会话内的全部消息
+func (r *transcriptRepository) ListEntries(ctx context.Context, sessionID string) ([]*transcript.Entry, error) {
+	var models []TranscriptEntry
+	if err := r.db.WithContext(ctx).
+		Where("session_id = ?", sessionID).
+		Order("created_at ASC").
+		Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "transcript.list_entries", "failed to list transcript entries", err)
+	}
+
+	items := make([]*transcript.Entry, len(models))
+	for i := range models {
+		items[i] = r.fromModel(&models[i])
+	}
+	return items, nil
+}
+
+// DeleteSession 删除会话的全部消息、反馈和耗时记录
+func (r *transcriptRepository) DeleteSession(ctx context.Context, sessionID string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("session_id = ?", sessionID).Delete(&TranscriptFeedback{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("session_id = ?", sessionID).Delete(&TranscriptTurnLatency{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("session_id = ?", sessionID).Delete(&TranscriptEntry{}).Error
+	})
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "transcript.delete_session", "failed to delete conversation", err)
+	}
+	return nil
+}
+
+// DeleteDevice 删除设备的全部消息、反馈和耗时记录
+func (r *transcriptRepository) DeleteDevice(ctx context.Context, deviceID string) (int64, error) {
+	var removed int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("device_id = ?", deviceID).Delete(&TranscriptFeedback{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("device_id = ?", deviceID).Delete(&TranscriptTurnLatency{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("device_id = ?", deviceID).Delete(&TranscriptEntry{})
+		removed = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, errors.Wrap(errors.KindStorage, "transcript.delete_device", "failed to delete device transcripts", err)
+	}
+	return removed, nil
+}
+
+// DeleteBefore 删除指定时间之前的消息、反馈和耗时记录
+func (r *transcriptRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	if err := r.db.WithContext(ctx).Where("created_at < ?", before).Delete(&TranscriptFeedback{}).Error; err != nil {
+		return 0, errors.Wrap(errors.KindStorage, "transcript.delete_before", "failed to purge feedback", err)
+	}
+	if err := r.db.WithContext(ctx).Where("created_at < ?", before).Delete(&TranscriptTurnLatency{}).Error; err != nil {
+		return 0, errors.Wrap(errors.KindStorage, "transcript.delete_before", "failed to purge turn latencies", err)
+	}
+	result := r.db.WithContext(ctx).Where("created_at < ?", before).Delete(&TranscriptEntry{})
+	if result.Error != nil {
+		return 0, errors.Wrap(errors.KindStorage, "transcript.delete_before", "failed to purge transcripts", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// FindEntry 根据ID查找消息
+func (r *transcriptRepository) FindEntry(ctx context.Context, id string) (*transcript.Entry, error) {
+	var model TranscriptEntry
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "transcript.find_entry", "failed to find transcript entry", err)
+	}
+	return r.fromModel(&model), nil
+}
+
+// SaveFeedback 保存反馈
+func (r *transcriptRepository) SaveFeedback(ctx context.Context, f *transcript.Feedback) error {
+	model := &TranscriptFeedback{
+		ID:        f.ID,
+		SessionID: f.SessionID,
+		EntryID:   f.EntryID,
+		DeviceID:  f.DeviceID,
+		Round:     f.Round,
+		Rating:    int(f.Rating),
+		Comment:   f.Comment,
+		Source:    string(f.Source),
+		CreatedAt: f.CreatedAt,
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "transcript.save_feedback", "failed to save feedback", err)
+	}
+	return nil
+}
+
+// ListFeedback 按条件分页查询反馈
+func (r *transcriptRepository) ListFeedback(ctx context.Context, filter transcript.FeedbackFilter) ([]*transcript.Feedback, int64, error) {
+	query := r.feedbackQuery(ctx, filter)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "transcript.list_feedback", "failed to count feedback", err)
+	}
+
+	var models []TranscriptFeedback
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(filter.PageSize).Find(&models).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "transcript.list_feedback", "failed to list feedback", err)
+	}
+	return r.fromFeedbackModels(models), total, nil
+}
+
+// ListAllFeedback 查询满足条件的全部反馈
+func (r *transcriptRepository) ListAllFeedback(ctx context.Context, filter transcript.FeedbackFilter) ([]*transcript.Feedback, error) {
+	var models []TranscriptFeedback
+	if err := r.feedbackQuery(ctx, filter).Order("created_at ASC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "transcript.list_all_feedback", "failed to list feedback", err)
+	}
+	return r.fromFeedbackModels(models), nil
+}
+
+// SaveTurnLatency 保存一轮对话的耗时
+func (r *transcriptRepository) SaveTurnLatency(ctx context.Context, t *transcript.TurnLatency) error {
+	model := &TranscriptTurnLatency{
+		ID:              t.ID,
+		SessionID:       t.SessionID,
+		DeviceID:        t.DeviceID,
+		Round:           t.Round,
+		CaptureMs:       t.CaptureMs,
+		ASRMs:           t.ASRMs,
+		LLMFirstTokenMs: t.LLMFirstTokenMs,
+		LLMCompleteMs:   t.LLMCompleteMs,
+		TTSFirstByteMs:  t.TTSFirstByteMs,
+		PlaybackStartMs: t.PlaybackStartMs,
+		TotalMs:         t.TotalMs,
+		CreatedAt:       t.CreatedAt,
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "transcript.save_latency", "failed to save turn latency", err)
+	}
+	return nil
+}
+
+// ListTurnLatencies 查询会话内各轮的耗时
+func (r *transcriptRepository) ListTurnLatencies(ctx context.Context, sessionID string) ([]*transcript.TurnLatency, error) {
+	var models []TranscriptTurnLatency
+	if err := r.db.WithContext(ctx).
+		Where("session_id = ?", sessionID).
+		Order("round ASC, created_at ASC").
+		Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "transcript.list_latencies", "failed to list turn latencies", err)
+	}
+	return r.fromLatencyModels(models), nil
+}
+
+// ListAllTurnLatencies 查询满足条件的全部耗时记录
+func (r *transcriptRepository) ListAllTurnLatencies(ctx context.Context, filter transcript.LatencyFilter) ([]*transcript.TurnLatency, error) {
+	query := r.db.WithContext(ctx).Model(&TranscriptTurnLatency{})
+	if filter.DeviceID != "" {
+		query = query.Where("device_id = ?", filter.DeviceID)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at <= ?", filter.To)
+	}
+	var models []TranscriptTurnLatency
+	if err := query.Order("created_at ASC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "transcript.list_all_latencies", "failed to list turn latencies", err)
+	}
+	return r.fromLatencyModels(models), nil
+}
+
+func (r *transcriptRepository) fromLatencyModels(models []TranscriptTurnLatency) []*transcript.TurnLatency {
+	items := make([]*transcript.TurnLatency, len(models))
+	for i, m := range models {
+		items[i] = &transcript.TurnLatency{
+			ID:              m.ID,
+			SessionID:       m.SessionID,
+			DeviceID:        m.DeviceID,
+			Round:           m.Round,
+			CaptureMs:       m.CaptureMs,
+			ASRMs:           m.ASRMs,
+			LLMFirstTokenMs: m.LLMFirstTokenMs,
+			LLMCompleteMs:   m.LLMCompleteMs,
+			TTSFirstByteMs:  m.TTSFirstByteMs,
+			PlaybackStartMs: m.PlaybackStartMs,
+			TotalMs:         m.TotalMs,
+			CreatedAt:       m.CreatedAt,
+		}
+	}
+	return items
+}
+
+func (r *transcriptRepository) feedbackQuery(ctx context.Context, filter transcript.FeedbackFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&TranscriptFeedback{})
+	if filter.DeviceID != "" {
+		query = query.Where("device_id = ?", filter.DeviceID)
+	}
+	if filter.SessionID != "" {
+		query = query.Where("session_id = ?", filter.SessionID)
+	}
+	if filter.Rating != transcript.RatingNone {
+		query = query.Where("rating = ?", int(filter.Rating))
+	}
+	if filter.Source != "" {
+		query = query.Where("source = ?", string(filter.Source))
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at <= ?", filter.To)
+	}
+	return query
+}
+
+func (r *transcriptRepository) fromFeedbackModels(models []TranscriptFeedback) []*transcript.Feedback {
+	items := make([]*transcript.Feedback, len(models))
+	for i, m := range models {
+		items[i] = &transcript.Feedback{
+			ID:        m.ID,
+			SessionID: m.SessionID,
+			EntryID:   m.EntryID,
+			DeviceID:  m.DeviceID,
+			Round:     m.Round,
+			Rating:    transcript.Rating(m.Rating),
+			Comment:   m.Comment,
+			Source:    transcript.FeedbackSource(m.Source),
+			CreatedAt: m.CreatedAt,
+		}
+	}
+	return items
+}
+
+// toModel 将领域对象转换为存储模型
+func (r *transcriptRepository) toModel(e *transcript.Entry) *TranscriptEntry {
+	var traces string
+	if len(e.Traces) > 0 {
+		data, _ := json.Marshal(e.Traces)
+		traces = string(data)
+	}
+	return &TranscriptEntry{
+		ID:         e.ID,
+		SessionID:  e.SessionID,
+		DeviceID:   e.DeviceID,
+		Round:      e.Round,
+		Role:       string(e.Role),
+		Content:    e.Content,
+		ToolName:   e.ToolName,
+		ToolCallID: e.ToolCallID,
+		ToolArgs:   e.ToolArgs,
+		LatencyMs:  e.LatencyMs,
+		Redacted:   e.Redacted,
+		Traces:     traces,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+// fromModel 将存储模型转换为领域对象
+func (r *transcriptRepository) fromModel(m *TranscriptEntry) *transcript.Entry {
+	e := &transcript.Entry{
+		ID:         m.ID,
+		SessionID:  m.SessionID,
+		DeviceID:   m.DeviceID,
+		Round:      m.Round,
+		Role:       transcript.Role(m.Role),
+		Content:    m.Content,
+		ToolName:   m.ToolName,
+		ToolCallID: m.ToolCallID,
+		ToolArgs:   m.ToolArgs,
+		LatencyMs:  m.LatencyMs,
+		Redacted:   m.Redacted,
+		CreatedAt:  m.CreatedAt,
+	}
+	if m.Traces != "" {
+		json.Unmarshal([]byte(m.Traces), &e.Traces)
+	}
+	return e
+}
+
+// parseAggregateTime 解析聚合函数返回的时间，不同数据库驱动返回的格式不一致
+func parseAggregateTime(value string) time.Time {
+	layouts := []string{
+		time.RFC3339Nano,
+		"2006-01-02 15:04:05.999999999-07:00",
+		"2006-01-02 15:04:05.999999999",
+		"2006-01-02 15:04:05",
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,309 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/webhook"
+	"xiaozhi-server-go/internal/platform/errors"
+)
+
+// WebhookEndpoint 推送端点存储模型
+type WebhookEndpoint struct {
+	ID        string `gorm:"type:varchar(64);primaryKey"`
+	Name      string `gorm:"type:varchar(255);not null"`
+	URL       string `gorm:"type:varchar(1024);not null"`
+	Secret    string `gorm:"type:varchar(255)"`
+	Events    string `gorm:"type:text"` // JSON 数组
+	Enabled   bool   `gorm:"default:true"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName 指定表名
+func (WebhookEndpoint) TableName() string {
+	return "webhook_endpoints"
+}
+
+// WebhookDelivery 推送记录存储模型
+type WebhookDelivery struct {
+	ID            string     `gorm:"type:varchar(64);primaryKey"`
+	EndpointID    string     `gorm:"type:varchar(64);index;not null"`
+	EventID       string     `gorm:"type:varchar(64);index"`
+	Event         string     `gorm:"type:varchar(64);index"`
+	Payload       string     `gorm:"type:text"`
+	Status        string     `gorm:"type:varchar(16);index:idx_webhook_deliveries_due,priority:1"`
+	Attempts      int        `gorm:"default:0"`
+	NextAttemptAt *time.Time `gorm:"index:idx_webhook_deliveries_due,priority:2"`
+	StatusCode    int
+	Error         string `gorm:"type:text"`
+	History       string `gorm:"type:text"` // JSON 数组
+	DeliveredAt   *time.Time
+	CreatedAt     time.Time `gorm:"index"`
+	UpdatedAt     time.Time
+}
+
+// TableName 指定表名
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// webhookRepository 推送端点和推送记录仓库实现
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository 创建推送仓库实例
+func NewWebhookRepository(db *gorm.DB) webhook.Repository {
+	return &webhookRepository{
+		db: db,
+	}
+}
+
+// SaveEndpoint 保存端点
+func (r *webhookRepository) SaveEndpoint(ctx context.Context, e *webhook.Endpoint) error {
+	model, err := r.toEndpointModel(e)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "webhook.save_endpoint", "failed to save webhook endpoint", err)
+	}
+	return nil
+}
+
+// UpdateEndpoint 更新端点
+func (r *webhookRepository) UpdateEndpoint(ctx context.Context, e *webhook.Endpoint) error {
+	model, err := r.toEndpointModel(e)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "webhook.update_endpoint", "failed to update webhook endpoint", err)
+	}
+	return nil
+}
+
+// FindEndpoint 根据ID查找端点
+func (r *webhookRepository) FindEndpoint(ctx context.Context, id string) (*webhook.Endpoint, error) {
+	var model WebhookEndpoint
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "webhook.find_endpoint", "failed to find webhook endpoint", err)
+	}
+	return r.fromEndpointModel(&model), nil
+}
+
+// ListEndpoints 查询全部端点
+func (r *webhookRepository) ListEndpoints(ctx context.Context) ([]*webhook.Endpoint, error) {
+	var models []WebhookEndpoint
+	if err := r.db.WithContext(ctx).Order("created_at ASC").Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "webhook.list_endpoints", "failed to list webhook endpoints", err)
+	}
+
+	items := make([]*webhook.Endpoint, len(models))
+	for i := range models {
+		items[i] = r.fromEndpointModel(&models[i])
+	}
+	return items, nil
+}
+
+// DeleteEndpoint 删除端点及其推送记录
+func (r *webhookRepository) DeleteEndpoint(ctx context.Context, id string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("endpoint_id = ?", id).Delete(&WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&WebhookEndpoint{}).Error
+	})
+	if err != nil {
+		return errors.Wrap(errors.KindStorage, "webhook.delete_endpoint", "failed to delete webhook endpoint", err)
+	}
+	return nil
+}
+
+// SaveDeliveries 批量保存推送记录
+func (r *webhookRepository) SaveDeliveries(ctx context.Context, items []*webhook.Delivery) error {
+	models := make([]*WebhookDelivery, 0, len(items))
+	for _, d := range items {
+		model, err := r.toDeliveryModel(d)
+		if err != nil {
+			return err
+		}
+		models = append(models, model)
+	}
+	if err := r.db.WithContext(ctx).Create(&models).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "webhook.save_deliveries", "failed to save webhook deliveries", err)
+	}
+	return nil
+}
+
+// UpdateDelivery 更新推送记录
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, d *webhook.Delivery) error {
+	model, err := r.toDeliveryModel(d)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return errors.Wrap(errors.KindStorage, "webhook.update_delivery", "failed to update webhook delivery", err)
+	}
+	return nil
+}
+
+// FindDelivery 根据ID查找推送记录
+func (r *webhookRepository) FindDelivery(ctx context.Context, id string) (*webhook.Delivery, error) {
+	var model WebhookDelivery
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(errors.KindStorage, "webhook.find_delivery", "failed to find webhook delivery", err)
+	}
+	return r.fromDeliveryModel(&model), nil
+}
+
+// ListDeliveries 按条件分页查询推送记录
+func (r *webhookRepository) ListDeliveries(ctx context.Context, filter webhook.DeliveryFilter) ([]*webhook.Delivery, int64, error) {
+	query := r.db.WithContext(ctx).Model(&WebhookDelivery{}).Where("endpoint_id = ?", filter.EndpointID)
+	if filter.Event != "" {
+		query = query.Where("event = ?", filter.Event)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "webhook.list_deliveries", "failed to count webhook deliveries", err)
+	}
+
+	var models []WebhookDelivery
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(filter.PageSize).Find(&models).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.KindStorage, "webhook.list_deliveries", "failed to list webhook deliveries", err)
+	}
+
+	items := make([]*webhook.Delivery, len(models))
+	for i := range models {
+		items[i] = r.fromDeliveryModel(&models[i])
+	}
+	return items, total, nil
+}
+
+// ListDue 查询到期的待推送记录
+func (r *webhookRepository) ListDue(ctx context.Context, before time.Time, limit int) ([]*webhook.Delivery, error) {
+	var models []WebhookDelivery
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", string(webhook.StatusPending), before).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "webhook.list_due", "failed to list due webhook deliveries", err)
+	}
+
+	items := make([]*webhook.Delivery, len(models))
+	for i := range models {
+		items[i] = r.fromDeliveryModel(&models[i])
+	}
+	return items, nil
+}
+
+// DeleteFinishedBefore 删除在指定时间之前结束的推送记录
+func (r *webhookRepository) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("status <> ? AND updated_at < ?", string(webhook.StatusPending), before).
+		Delete(&WebhookDelivery{})
+	if result.Error != nil {
+		return 0, errors.Wrap(errors.KindStorage, "webhook.delete_finished", "failed to delete expired webhook deliveries", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// toEndpointModel 将领域对象转换为存储模型
+func (r *webhookRepository) toEndpointModel(e *webhook.Endpoint) (*WebhookEndpoint, error) {
+	events, err := json.Marshal(e.Events)
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "webhook.encode", "failed to encode endpoint events", err)
+	}
+	return &WebhookEndpoint{
+		ID:        e.ID,
+		Name:      e.Name,
+		URL:       e.URL,
+		Secret:    e.Secret,
+		Events:    string(events),
+		Enabled:   e.Enabled,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}, nil
+}
+
+// fromEndpointModel 将存储模型转换为领域对象
+func (r *webhookRepository) fromEndpointModel(model *WebhookEndpoint) *webhook.Endpoint {
+	e := &webhook.Endpoint{
+		ID:        model.ID,
+		Name:      model.Name,
+		URL:       model.URL,
+		Secret:    model.Secret,
+		Events:    []string{},
+		Enabled:   model.Enabled,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}
+	if model.Events != "" {
+		json.Unmarshal([]byte(model.Events), &e.Events)
+	}
+	return e
+}
+
+// toDeliveryModel 将领域对象转换为存储模型
+func (r *webhookRepository) toDeliveryModel(d *webhook.Delivery) (*WebhookDelivery, error) {
+	history, err := json.Marshal(d.History)
+	if err != nil {
+		return nil, errors.Wrap(errors.KindStorage, "webhook.encode", "failed to encode delivery history", err)
+	}
+	return &WebhookDelivery{
+		ID:            d.ID,
+		EndpointID:    d.EndpointID,
+		EventID:       d.EventID,
+		Event:         d.Event,
+		Payload:       d.Payload,
+		Status:        string(d.Status),
+		Attempts:      d.Attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		StatusCode:    d.StatusCode,
+		Error:         d.Error,
+		History:       string(history),
+		DeliveredAt:   d.DeliveredAt,
+		CreatedAt:     d.CreatedAt,
+		UpdatedAt:     d.UpdatedAt,
+	}, nil
+}
+
+// fromDeliveryModel 将存储模型转换为领域对象
+func (r *webhookRepository) fromDeliveryModel(model *WebhookDelivery) *webhook.Delivery {
+	d := &webhook.Delivery{
+		ID:            model.ID,
+		EndpointID:    model.EndpointID,
+		EventID:       model.EventID,
+		Event:         model.Event,
+		Payload:       model.Payload,
+		Status:        webhook.DeliveryStatus(model.Status),
+		Attempts:      model.Attempts,
+		NextAttemptAt: model.NextAttemptAt,
+		StatusCode:    model.StatusCode,
+		Error:         model.Error,
+		History:       []webhook.Attempt{},
+		DeliveredAt:   model.DeliveredAt,
+		CreatedAt:     model.CreatedAt,
+		UpdatedAt:     model.UpdatedAt,
+	}
+	if model.History != "" {
+		json.Unmarshal([]byte(model.History), &d.History)
+	}
+	return d
+}
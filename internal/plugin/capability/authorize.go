@@ -0,0 +1,43 @@
+package capability
+
+import "context"
+
+// Authorizer 按上下文中的调用方检查能力调用权限，拒绝时返回错误
+type Authorizer interface {
+	AuthorizeCapability(ctx context.Context, capabilityID string, inputs map[string]interface{}) error
+}
+
+// authorizedExecutor 执行前检查调用权限
+type authorizedExecutor struct {
+	base       Executor
+	authorizer Authorizer
+	def        Definition
+}
+
+// newAuthorizedExecutor 包装执行器，流式执行器保持流式能力
+func newAuthorizedExecutor(base Executor, authorizer Authorizer, def Definition) Executor {
+	authorized := &authorizedExecutor{base: base, authorizer: authorizer, def: def}
+	if stream, ok := base.(StreamExecutor); ok {
+		return &authorizedStreamExecutor{authorizedExecutor: authorized, stream: stream}
+	}
+	return authorized
+}
+
+func (e *authorizedExecutor) Execute(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (map[string]interface{}, error) {
+	if err := e.authorizer.AuthorizeCapability(ctx, e.def.ID, inputs); err != nil {
+		return nil, err
+	}
+	return e.base.Execute(ctx, config, inputs)
+}
+
+type authorizedStreamExecutor struct {
+	*authorizedExecutor
+	stream StreamExecutor
+}
+
+func (e *authorizedStreamExecutor) ExecuteStream(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (<-chan map[string]interface{}, error) {
+	if err := e.authorizer.AuthorizeCapability(ctx, e.def.ID, inputs); err != nil {
+		return nil, err
+	}
+	return e.stream.ExecuteStream(ctx, config, inputs)
+}
@@ -0,0 +1,93 @@
+package capability
+
+import (
+	"context"
+	"fmt"
+)
+
+// ConfiguredProvider 为基础提供者绑定一份静态配置（如数据库中保存的凭据），
+// 执行时以绑定配置为默认值，调用方传入的配置项优先
+type ConfiguredProvider struct {
+	base     Provider
+	config   map[string]interface{}
+	disabled map[string]bool
+}
+
+// NewConfiguredProvider 创建绑定配置的提供者，disabled 中的能力不对外暴露
+func NewConfiguredProvider(base Provider, config map[string]interface{}, disabled []string) *ConfiguredProvider {
+	p := &ConfiguredProvider{
+		base:     base,
+		config:   make(map[string]interface{}, len(config)),
+		disabled: make(map[string]bool, len(disabled)),
+	}
+	for k, v := range config {
+		p.config[k] = v
+	}
+	for _, id := range disabled {
+		p.disabled[id] = true
+	}
+	return p
+}
+
+// GetCapabilities 返回基础提供者中未被禁用的能力
+func (p *ConfiguredProvider) GetCapabilities() []Definition {
+	defs := p.base.GetCapabilities()
+	caps := make([]Definition, 0, len(defs))
+	for _, def := range defs {
+		if !p.disabled[def.ID] {
+			caps = append(caps, def)
+		}
+	}
+	return caps
+}
+
+// CreateExecutor 创建注入绑定配置的执行器
+func (p *ConfiguredProvider) CreateExecutor(capabilityID string) (Executor, error) {
+	if p.disabled[capabilityID] {
+		return nil, fmt.Errorf("capability disabled: %s", capabilityID)
+	}
+	exec, err := p.base.CreateExecutor(capabilityID)
+	if err != nil {
+		return nil, err
+	}
+	configured := &configuredExecutor{base: exec, config: p.config}
+	if stream, ok := exec.(StreamExecutor); ok {
+		return &configuredStreamExecutor{configuredExecutor: configured, stream: stream}, nil
+	}
+	return configured, nil
+}
+
+// configuredExecutor 合并绑定配置后调用基础执行器
+type configuredExecutor struct {
+	base   Executor
+	config map[string]interface{}
+}
+
+func (e *configuredExecutor) Execute(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (map[string]interface{}, error) {
+	return e.base.Execute(ctx, e.merge(config), inputs)
+}
+
+// merge 以绑定配置为底，覆盖调用方传入的非空配置项
+func (e *configuredExecutor) merge(config map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(e.config)+len(config))
+	for k, v := range e.config {
+		merged[k] = v
+	}
+	for k, v := range config {
+		if s, ok := v.(string); ok && s == "" {
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// configuredStreamExecutor 保留基础执行器的流式能力
+type configuredStreamExecutor struct {
+	*configuredExecutor
+	stream StreamExecutor
+}
+
+func (e *configuredStreamExecutor) ExecuteStream(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (<-chan map[string]interface{}, error) {
+	return e.stream.ExecuteStream(ctx, e.merge(config), inputs)
+}
@@ -0,0 +1,110 @@
+package capability
+
+import (
+	"context"
+	"time"
+)
+
+// Fault 一次注入的故障
+type Fault struct {
+	Delay time.Duration // 调用前的延迟
+	Err   error         // 非 nil 时延迟后直接返回该错误，不调用提供者
+	Kill  bool          // 模拟提供者崩溃：流式调用在首个分块后中断，非流式调用执行后丢弃结果并返回 Err
+}
+
+// FaultInjector 故障注入器，用于验证熔断、降级和重启等容错机制
+type FaultInjector interface {
+	// Fault 决定本次调用是否注入故障
+	Fault(ctx context.Context, providerID string, def Definition) (Fault, bool)
+}
+
+// faultExecutor 按注入器的决定延迟、失败或中断调用
+type faultExecutor struct {
+	base       Executor
+	injector   FaultInjector
+	providerID string
+	def        Definition
+}
+
+// newFaultExecutor 包装执行器，流式执行器保持流式能力
+func newFaultExecutor(base Executor, injector FaultInjector, providerID string, def Definition) Executor {
+	faulty := &faultExecutor{base: base, injector: injector, providerID: providerID, def: def}
+	if stream, ok := base.(StreamExecutor); ok {
+		return &faultStreamExecutor{faultExecutor: faulty, stream: stream}
+	}
+	return faulty
+}
+
+// before 执行延迟并返回需要直接返回的错误
+func (e *faultExecutor) before(ctx context.Context) (Fault, bool, error) {
+	fault, ok := e.injector.Fault(ctx, e.providerID, e.def)
+	if !ok {
+		return fault, false, nil
+	}
+	if fault.Delay > 0 {
+		timer := time.NewTimer(fault.Delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return fault, true, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if fault.Err != nil && !fault.Kill {
+		return fault, true, fault.Err
+	}
+	return fault, true, nil
+}
+
+func (e *faultExecutor) Execute(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (map[string]interface{}, error) {
+	fault, injected, err := e.before(ctx)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := e.base.Execute(ctx, config, inputs)
+	if injected && fault.Kill {
+		// 提供者在返回结果前崩溃，调用方收不到已完成的结果
+		return nil, fault.Err
+	}
+	return outputs, err
+}
+
+// faultStreamExecutor 流式调用的故障注入
+type faultStreamExecutor struct {
+	*faultExecutor
+	stream StreamExecutor
+}
+
+func (e *faultStreamExecutor) ExecuteStream(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (<-chan map[string]interface{}, error) {
+	fault, injected, err := e.before(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !injected || !fault.Kill {
+		return e.stream.ExecuteStream(ctx, config, inputs)
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	ch, err := e.stream.ExecuteStream(streamCtx, config, inputs)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	out := make(chan map[string]interface{}, 1)
+	go func() {
+		defer close(out)
+		defer cancel()
+		first, ok := <-ch
+		if ok {
+			select {
+			case out <- first:
+			case <-ctx.Done():
+			}
+		}
+		// 首个分块之后流被截断，排空提供者剩余输出避免其阻塞
+		cancel()
+		for range ch {
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,352 @@
+package capability
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOverloaded 执行队列已满或请求被更高优先级的请求挤出
+var ErrOverloaded = errors.New("capability execution queue overloaded")
+
+// Priority 请求优先级，数值越大越优先
+type Priority int
+
+const (
+	PriorityBatch       Priority = iota // 批处理任务（工作流、评测）
+	PriorityAPI                         // HTTP API 调用
+	PriorityInteractive                 // 设备实时交互
+)
+
+// priorities 按从高到低排列
+var priorities = []Priority{PriorityInteractive, PriorityAPI, PriorityBatch}
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityInteractive:
+		return "interactive"
+	case PriorityBatch:
+		return "batch"
+	default:
+		return "api"
+	}
+}
+
+type priorityKey struct{}
+
+// WithPriority 在上下文中标记请求优先级
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFromContext 读取上下文中的优先级，未标记时视为 API 调用
+func PriorityFromContext(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return p
+	}
+	return PriorityAPI
+}
+
+// PriorityQueueConfig 优先级队列配置
+type PriorityQueueConfig struct {
+	MaxConcurrent int              // 同时执行的请求上限
+	QueueDepths   map[Priority]int // 各优先级的最大排队数
+	MaxQueued     int              // 全部优先级合计的排队上限，达到后挤出低优先级请求；0 表示各优先级之和
+	MaxWait       time.Duration    // 最长排队时间，0 表示只受 ctx 限制
+}
+
+// ClassStats 单个优先级的队列统计
+type ClassStats struct {
+	Waiting   int     `json:"waiting"`
+	Admitted  int64   `json:"admitted"`
+	Shed      int64   `json:"shed"`      // 排队中被更高优先级挤出
+	Rejected  int64   `json:"rejected"`  // 队列已满直接拒绝
+	TimedOut  int64   `json:"timed_out"` // 排队超时或调用方取消
+	AvgWaitMs float64 `json:"avg_wait_ms"`
+	MaxWaitMs int64   `json:"max_wait_ms"`
+}
+
+// QueueStats 队列统计
+type QueueStats struct {
+	Active        int                   `json:"active"`
+	MaxConcurrent int                   `json:"max_concurrent"`
+	Classes       map[string]ClassStats `json:"classes"`
+}
+
+// waiter 排队中的请求
+type waiter struct {
+	priority Priority
+	enqueued time.Time
+	ready    chan error // 放行时写入 nil，被挤出时写入 ErrOverloaded
+}
+
+// classCounters 单个优先级的累计计数
+type classCounters struct {
+	admitted  int64
+	shed      int64
+	rejected  int64
+	timedOut  int64
+	waitTotal time.Duration
+	waitMax   time.Duration
+}
+
+// PriorityQueue 执行前的优先级队列，实现 Limiter
+// 有空闲槽位时直接放行；否则按优先级排队，高优先级队列满时挤出最低优先级的最新排队请求
+type PriorityQueue struct {
+	cfg PriorityQueueConfig
+
+	mu       sync.Mutex
+	active   int
+	queues   map[Priority]*list.List
+	counters map[Priority]*classCounters
+}
+
+// NewPriorityQueue 创建优先级队列
+func NewPriorityQueue(cfg PriorityQueueConfig) *PriorityQueue {
+	if cfg.MaxConcurrent <= 0 {
+		cfg.MaxConcurrent = 1
+	}
+	q := &PriorityQueue{
+		cfg:      cfg,
+		queues:   make(map[Priority]*list.List, len(priorities)),
+		counters: make(map[Priority]*classCounters, len(priorities)),
+	}
+	for _, p := range priorities {
+		q.queues[p] = list.New()
+		q.counters[p] = &classCounters{}
+	}
+	return q
+}
+
+// Acquire 实现 Limiter，按上下文中的优先级排队等待执行槽位
+func (q *PriorityQueue) Acquire(ctx context.Context, providerID string, def Definition) (func(Usage), error) {
+	p := PriorityFromContext(ctx)
+	if _, ok := q.queues[p]; !ok {
+		p = PriorityAPI
+	}
+
+	q.mu.Lock()
+	if q.active < q.cfg.MaxConcurrent && !q.hasWaitingAtOrAbove(p) {
+		q.active++
+		q.counters[p].admitted++
+		q.mu.Unlock()
+		return q.releaseFunc(), nil
+	}
+
+	if depth := q.cfg.QueueDepths[p]; q.queues[p].Len() >= depth {
+		q.counters[p].rejected++
+		q.mu.Unlock()
+		return nil, ErrOverloaded
+	}
+	if q.totalWaiting() >= q.maxQueued() && !q.shedBelow(p) {
+		q.counters[p].rejected++
+		q.mu.Unlock()
+		return nil, ErrOverloaded
+	}
+
+	w := &waiter{priority: p, enqueued: time.Now(), ready: make(chan error, 1)}
+	elem := q.queues[p].PushBack(w)
+	q.mu.Unlock()
+
+	var timeout <-chan time.Time
+	if q.cfg.MaxWait > 0 {
+		timer := time.NewTimer(q.cfg.MaxWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case err := <-w.ready:
+		if err != nil {
+			return nil, err
+		}
+		return q.releaseFunc(), nil
+	case <-ctx.Done():
+		return nil, q.abandon(w, elem, ctx.Err())
+	case <-timeout:
+		return nil, q.abandon(w, elem, ErrOverloaded)
+	}
+}
+
+// abandon 放弃排队；若此时恰好已被放行，则归还槽位
+func (q *PriorityQueue) abandon(w *waiter, elem *list.Element, reason error) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	select {
+	case err := <-w.ready:
+		if err == nil {
+			q.active--
+			q.dispatch()
+		}
+		return reason
+	default:
+	}
+	q.queues[w.priority].Remove(elem)
+	q.counters[w.priority].timedOut++
+	return reason
+}
+
+// releaseFunc 返回只执行一次的槽位释放函数
+func (q *PriorityQueue) releaseFunc() func(Usage) {
+	var once sync.Once
+	return func(Usage) {
+		once.Do(func() {
+			q.mu.Lock()
+			q.active--
+			q.dispatch()
+			q.mu.Unlock()
+		})
+	}
+}
+
+// dispatch 将空闲槽位分配给最高优先级的最早排队请求，调用方需持有锁
+func (q *PriorityQueue) dispatch() {
+	for q.active < q.cfg.MaxConcurrent {
+		w := q.popHighest()
+		if w == nil {
+			return
+		}
+		q.active++
+		c := q.counters[w.priority]
+		c.admitted++
+		wait := time.Since(w.enqueued)
+		c.waitTotal += wait
+		if wait > c.waitMax {
+			c.waitMax = wait
+		}
+		w.ready <- nil
+	}
+}
+
+func (q *PriorityQueue) popHighest() *waiter {
+	for _, p := range priorities {
+		if front := q.queues[p].Front(); front != nil {
+			q.queues[p].Remove(front)
+			return front.Value.(*waiter)
+		}
+	}
+	return nil
+}
+
+// shedBelow 挤出一个低于 p 的最低优先级中最新排队的请求，调用方需持有锁
+func (q *PriorityQueue) shedBelow(p Priority) bool {
+	for i := len(priorities) - 1; i >= 0; i-- {
+		lower := priorities[i]
+		if lower >= p {
+			return false
+		}
+		if back := q.queues[lower].Back(); back != nil {
+			q.queues[lower].Remove(back)
+			q.counters[lower].shed++
+			back.Value.(*waiter).ready <- ErrOverloaded
+			return true
+		}
+	}
+	return false
+}
+
+func (q *PriorityQueue) hasWaitingAtOrAbove(p Priority) bool {
+	for _, other := range priorities {
+		if other >= p && q.queues[other].Len() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *PriorityQueue) totalWaiting() int {
+	n := 0
+	for _, l := range q.queues {
+		n += l.Len()
+	}
+	return n
+}
+
+func (q *PriorityQueue) maxQueued() int {
+	if q.cfg.MaxQueued > 0 {
+		return q.cfg.MaxQueued
+	}
+	n := 0
+	for _, p := range priorities {
+		n += q.cfg.QueueDepths[p]
+	}
+	return n
+}
+
+// Stats 返回队列统计
+func (q *PriorityQueue) Stats() QueueStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	stats := QueueStats{
+		Active:        q.active,
+		MaxConcurrent: q.cfg.MaxConcurrent,
+		Classes:       make(map[string]ClassStats, len(priorities)),
+	}
+	for _, p := range priorities {
+		c := q.counters[p]
+		cs := ClassStats{
+			Waiting:   q.queues[p].Len(),
+			Admitted:  c.admitted,
+			Shed:      c.shed,
+			Rejected:  c.rejected,
+			TimedOut:  c.timedOut,
+			MaxWaitMs: c.waitMax.Milliseconds(),
+		}
+		if c.admitted > 0 {
+			cs.AvgWaitMs = float64(c.waitTotal.Milliseconds()) / float64(c.admitted)
+		}
+		stats.Classes[p.String()] = cs
+	}
+	return stats
+}
+
+// queueStatser 可提供优先级队列统计的限制器
+type queueStatser interface {
+	queueStats() (QueueStats, bool)
+}
+
+func (q *PriorityQueue) queueStats() (QueueStats, bool) {
+	return q.Stats(), true
+}
+
+// ChainLimiters 依次申请多个限制器，任一失败时释放已获得的许可
+func ChainLimiters(limiters ...Limiter) Limiter {
+	return chainLimiter(limiters)
+}
+
+type chainLimiter []Limiter
+
+func (c chainLimiter) Acquire(ctx context.Context, providerID string, def Definition) (func(Usage), error) {
+	releases := make([]func(Usage), 0, len(c))
+	for _, l := range c {
+		if l == nil {
+			continue
+		}
+		release, err := l.Acquire(ctx, providerID, def)
+		if err != nil {
+			for i := len(releases) - 1; i >= 0; i-- {
+				releases[i](Usage{})
+			}
+			return nil, err
+		}
+		releases = append(releases, release)
+	}
+	return func(u Usage) {
+		for i := len(releases) - 1; i >= 0; i-- {
+			releases[i](u)
+		}
+	}, nil
+}
+
+func (c chainLimiter) queueStats() (QueueStats, bool) {
+	for _, l := range c {
+		if s, ok := l.(queueStatser); ok {
+			return s.queueStats()
+		}
+	}
+	return QueueStats{}, false
+}
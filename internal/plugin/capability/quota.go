@@ -0,0 +1,219 @@
+package capability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"unicode"
+
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+)
+
+// ErrQuotaExceeded 配额或并发超限，可通过 errors.Is 判断
+var ErrQuotaExceeded = errors.New("capability quota exceeded")
+
+// QuotaKind 配额类型
+type QuotaKind string
+
+const (
+	QuotaConcurrency  QuotaKind = "concurrency"   // 并发上限
+	QuotaRequests     QuotaKind = "requests"      // 每日请求数
+	QuotaTokens       QuotaKind = "tokens"        // 每日Token数
+	QuotaAudioMinutes QuotaKind = "audio_minutes" // 每日音频分钟数
+)
+
+// QuotaExceededError 配额超限错误
+type QuotaExceededError struct {
+	Scope      string        // provider 或 capability
+	Target     string        // 提供者类型或能力ID
+	Kind       QuotaKind     // 超限的配额类型
+	Limit      float64       // 配置的上限
+	RetryAfter time.Duration // 建议的重试等待时间
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s quota exceeded for %s %s (limit %g)", e.Kind, e.Scope, e.Target, e.Limit)
+}
+
+// Is 使 errors.Is(err, ErrQuotaExceeded) 成立
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// Retryable 并发超限可短暂等待后重试，日配额超限需切换提供者或等到次日
+func (e *QuotaExceededError) Retryable() bool {
+	return e.Kind == QuotaConcurrency
+}
+
+// UserCategory 设备侧提示为额度用尽
+func (e *QuotaExceededError) UserCategory() platformerrors.Category {
+	return platformerrors.CategoryQuotaExceeded
+}
+
+// Usage 单次调用的资源消耗
+type Usage struct {
+	Tokens       int64
+	AudioSeconds float64
+}
+
+// Limiter 在执行路径上实施并发与配额限制
+// Acquire 成功后必须调用 release 并传入实际消耗
+type Limiter interface {
+	Acquire(ctx context.Context, providerID string, def Definition) (release func(Usage), err error)
+}
+
+// limitedExecutor 受限执行器
+type limitedExecutor struct {
+	base       Executor
+	limiter    Limiter
+	providerID string
+	def        Definition
+}
+
+// newLimitedExecutor 包装执行器，流式执行器保持流式能力
+func newLimitedExecutor(base Executor, limiter Limiter, providerID string, def Definition) Executor {
+	limited := &limitedExecutor{base: base, limiter: limiter, providerID: providerID, def: def}
+	if stream, ok := base.(StreamExecutor); ok {
+		return &limitedStreamExecutor{limitedExecutor: limited, stream: stream}
+	}
+	return limited
+}
+
+func (e *limitedExecutor) Execute(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (map[string]interface{}, error) {
+	release, err := e.limiter.Acquire(ctx, e.providerID, e.def)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := e.base.Execute(ctx, config, inputs)
+	release(measureUsage(e.def.Type, inputs, outputs, outputText(outputs)))
+	return outputs, err
+}
+
+// limitedStreamExecutor 受限流式执行器，在流结束时结算消耗
+type limitedStreamExecutor struct {
+	*limitedExecutor
+	stream StreamExecutor
+}
+
+func (e *limitedStreamExecutor) ExecuteStream(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (<-chan map[string]interface{}, error) {
+	release, err := e.limiter.Acquire(ctx, e.providerID, e.def)
+	if err != nil {
+		return nil, err
+	}
+	ch, err := e.stream.ExecuteStream(ctx, config, inputs)
+	if err != nil {
+		release(Usage{})
+		return nil, err
+	}
+
+	out := make(chan map[string]interface{})
+	go func() {
+		defer close(out)
+		var text []rune
+		var last map[string]interface{}
+		defer func() {
+			release(measureUsage(e.def.Type, inputs, last, string(text)))
+		}()
+		for chunk := range ch {
+			text = append(text, []rune(outputText(chunk))...)
+			last = chunk
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				// 调用方已放弃读取，继续排空上游以便结算
+				for chunk := range ch {
+					text = append(text, []rune(outputText(chunk))...)
+				}
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// measureUsage 估算消耗：优先使用输出中的 usage/duration 字段，否则按文本或音频长度估算
+func measureUsage(capType Type, inputs, outputs map[string]interface{}, outText string) Usage {
+	var usage Usage
+	switch capType {
+	case TypeLLM:
+		if u, ok := outputs["usage"].(map[string]interface{}); ok {
+			if total, ok := toFloat(u["total_tokens"]); ok {
+				usage.Tokens = int64(total)
+				return usage
+			}
+		}
+		var prompt []rune
+		if msgs, ok := inputs["messages"].([]interface{}); ok {
+			for _, m := range msgs {
+				if msg, ok := m.(map[string]interface{}); ok {
+					content, _ := msg["content"].(string)
+					prompt = append(prompt, []rune(content)...)
+				}
+			}
+		}
+		usage.Tokens = EstimateTokens(string(prompt)) + EstimateTokens(outText)
+	case TypeASR:
+		if d, ok := toFloat(outputs["duration"]); ok {
+			usage.AudioSeconds = d
+		} else if audio, ok := inputs["audio_data"].([]byte); ok {
+			// 按 16kHz 16bit 单声道 PCM 估算
+			usage.AudioSeconds = float64(len(audio)) / 32000
+		}
+	case TypeTTS:
+		if d, ok := toFloat(outputs["duration"]); ok {
+			usage.AudioSeconds = d
+		} else if text, ok := inputs["text"].(string); ok {
+			// 中文语速约每秒 4 字
+			usage.AudioSeconds = float64(len([]rune(text))) / 4
+		}
+	case TypeTranslation:
+		text, _ := inputs["text"].(string)
+		usage.Tokens = EstimateTokens(text) + EstimateTokens(outText)
+	case TypeEmbedding:
+		if texts, ok := inputs["texts"].([]interface{}); ok {
+			for _, t := range texts {
+				s, _ := t.(string)
+				usage.Tokens += EstimateTokens(s)
+			}
+		}
+	}
+	return usage
+}
+
+// EstimateTokens 粗略估算Token数：CJK字符按 1 个计，其余字符按 4 个计 1 个
+func EstimateTokens(text string) int64 {
+	var cjk, other int64
+	for _, r := range text {
+		if unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r) {
+			cjk++
+		} else if !unicode.IsSpace(r) {
+			other++
+		}
+	}
+	return cjk + (other+3)/4
+}
+
+// outputText 提取输出中的文本内容
+func outputText(outputs map[string]interface{}) string {
+	if s, ok := outputs["content"].(string); ok {
+		return s
+	}
+	s, _ := outputs["text"].(string)
+	return s
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
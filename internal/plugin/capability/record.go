@@ -0,0 +1,115 @@
+package capability
+
+import (
+	"context"
+	"time"
+)
+
+// Recorder 按采样录制能力调用的完整请求和响应，用于排查提供者问题
+type Recorder interface {
+	// Sample 决定本次调用是否录制
+	Sample(providerID string, def Definition) bool
+	// Record 保存一次已完成的调用，不应阻塞调用方
+	Record(ctx context.Context, call RecordedCall)
+}
+
+// RecordedCall 一次被录制的能力调用
+type RecordedCall struct {
+	ProviderID string
+	Definition Definition
+	Config     map[string]interface{}
+	Inputs     map[string]interface{}
+	Outputs    map[string]interface{}   // 非流式调用的输出
+	Chunks     []map[string]interface{} // 流式调用按顺序收到的分块
+	Err        error
+	StartedAt  time.Time
+	Duration   time.Duration
+}
+
+// recordedExecutor 录制被采样的调用
+type recordedExecutor struct {
+	base       Executor
+	recorder   Recorder
+	providerID string
+	def        Definition
+}
+
+// newRecordedExecutor 包装执行器，流式执行器保持流式能力
+func newRecordedExecutor(base Executor, recorder Recorder, providerID string, def Definition) Executor {
+	recorded := &recordedExecutor{base: base, recorder: recorder, providerID: providerID, def: def}
+	if stream, ok := base.(StreamExecutor); ok {
+		return &recordedStreamExecutor{recordedExecutor: recorded, stream: stream}
+	}
+	return recorded
+}
+
+func (e *recordedExecutor) Execute(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (map[string]interface{}, error) {
+	if !e.recorder.Sample(e.providerID, e.def) {
+		return e.base.Execute(ctx, config, inputs)
+	}
+	start := time.Now()
+	outputs, err := e.base.Execute(ctx, config, inputs)
+	e.recorder.Record(ctx, RecordedCall{
+		ProviderID: e.providerID,
+		Definition: e.def,
+		Config:     config,
+		Inputs:     inputs,
+		Outputs:    outputs,
+		Err:        err,
+		StartedAt:  start,
+		Duration:   time.Since(start),
+	})
+	return outputs, err
+}
+
+// recordedStreamExecutor 录制流式调用，在流结束时保存全部分块
+type recordedStreamExecutor struct {
+	*recordedExecutor
+	stream StreamExecutor
+}
+
+func (e *recordedStreamExecutor) ExecuteStream(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (<-chan map[string]interface{}, error) {
+	if !e.recorder.Sample(e.providerID, e.def) {
+		return e.stream.ExecuteStream(ctx, config, inputs)
+	}
+	start := time.Now()
+	call := RecordedCall{
+		ProviderID: e.providerID,
+		Definition: e.def,
+		Config:     config,
+		Inputs:     inputs,
+		StartedAt:  start,
+	}
+	ch, err := e.stream.ExecuteStream(ctx, config, inputs)
+	if err != nil {
+		call.Err = err
+		call.Duration = time.Since(start)
+		e.recorder.Record(ctx, call)
+		return nil, err
+	}
+
+	out := make(chan map[string]interface{})
+	go func() {
+		defer close(out)
+		defer func() {
+			call.Duration = time.Since(start)
+			if call.Err == nil && ctx.Err() != nil {
+				call.Err = ctx.Err()
+			}
+			e.recorder.Record(ctx, call)
+		}()
+		for chunk := range ch {
+			call.Chunks = append(call.Chunks, chunk)
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				// 调用方已放弃读取，继续排空上游以便完整录制
+				for chunk := range ch {
+					call.Chunks = append(call.Chunks, chunk)
+				}
+				return
+			}
+		}
+	}()
+	return out, nil
+}
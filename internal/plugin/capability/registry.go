@@ -2,6 +2,7 @@ package capability
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 )
 
@@ -9,6 +10,11 @@ type Registry struct {
 	providers       map[string]Provider
 	capabilities    map[string]Definition
 	capToProvider   map[string]string // capabilityID -> providerID
+	limiter         Limiter
+	validation      *ValidationPolicy
+	authorizer      Authorizer
+	recorder        Recorder
+	faults          FaultInjector
 	mu              sync.RWMutex
 }
 
@@ -42,13 +48,148 @@ func (r *Registry) GetExecutor(capabilityID string) (Executor, error) {
 
 	r.mu.RLock()
 	provider, ok := r.providers[providerID]
+	def := r.capabilities[capabilityID]
+	limiter := r.limiter
+	validation := r.validation
+	authorizer := r.authorizer
+	recorder := r.recorder
+	faults := r.faults
 	r.mu.RUnlock()
 
 	if !ok {
 		return nil, fmt.Errorf("provider not found for capability: %s", capabilityID)
 	}
 
-	return provider.CreateExecutor(capabilityID)
+	exec, err := provider.CreateExecutor(capabilityID)
+	if err != nil {
+		return nil, err
+	}
+	// 录制在最内层，只记录实际到达提供者的调用
+	if recorder != nil {
+		exec = newRecordedExecutor(exec, recorder, providerID, def)
+	}
+	// 故障注入在限流之内，注入的延迟与真实的慢调用一样占用并发
+	if faults != nil {
+		exec = newFaultExecutor(exec, faults, providerID, def)
+	}
+	// 追踪在限流之内，记录的耗时不含排队等待
+	exec = newTracedExecutor(exec, providerID, def)
+	if limiter != nil {
+		exec = newLimitedExecutor(exec, limiter, providerID, def)
+	}
+	// 校验在限流之外，格式错误的调用不占用并发和配额
+	if validation != nil {
+		exec = newValidatedExecutor(exec, validation, def)
+	}
+	// 权限检查在最外层，被拒绝的调用不做校验也不占用配额
+	if authorizer != nil {
+		exec = newAuthorizedExecutor(exec, authorizer, def)
+	}
+	return exec, nil
+}
+
+// SetAuthorizer 设置调用权限检查，之后获取的执行器在执行前按调用方检查权限
+func (r *Registry) SetAuthorizer(authorizer Authorizer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.authorizer = authorizer
+}
+
+// SetValidationPolicy 设置输入输出 schema 校验策略，之后获取的执行器均按策略校验
+func (r *Registry) SetValidationPolicy(policy *ValidationPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.validation = policy
+}
+
+// SetLimiter 设置并发与配额限制器，之后获取的执行器均受其约束
+func (r *Registry) SetLimiter(limiter Limiter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.limiter = limiter
+}
+
+// SetRecorder 设置调用录制器，之后获取的执行器按采样录制请求和响应
+func (r *Registry) SetRecorder(recorder Recorder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.recorder = recorder
+}
+
+// SetFaultInjector 设置故障注入器，之后获取的执行器按注入规则延迟、失败或中断
+func (r *Registry) SetFaultInjector(injector FaultInjector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.faults = injector
+}
+
+// Limiter 返回当前的限制器，未设置时返回 nil
+func (r *Registry) Limiter() Limiter {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.limiter
+}
+
+// QueueStats 返回执行优先级队列的统计，未启用优先级队列时 ok 为 false
+func (r *Registry) QueueStats() (stats QueueStats, ok bool) {
+	r.mu.RLock()
+	limiter := r.limiter
+	r.mu.RUnlock()
+
+	if s, isStats := limiter.(queueStatser); isStats {
+		return s.queueStats()
+	}
+	return QueueStats{}, false
+}
+
+// Unregister 注销提供者；其能力若仍由其他提供者提供则改指向该提供者，否则一并移除
+func (r *Registry) Unregister(providerID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.providers[providerID]; !ok {
+		return
+	}
+	delete(r.providers, providerID)
+
+	// 按ID排序选择替代提供者，保证结果确定
+	others := make([]string, 0, len(r.providers))
+	for id := range r.providers {
+		others = append(others, id)
+	}
+	sort.Strings(others)
+
+	for capID, owner := range r.capToProvider {
+		if owner != providerID {
+			continue
+		}
+		delete(r.capToProvider, capID)
+		delete(r.capabilities, capID)
+		for _, id := range others {
+			for _, def := range r.providers[id].GetCapabilities() {
+				if def.ID == capID {
+					r.capabilities[capID] = def
+					r.capToProvider[capID] = id
+					break
+				}
+			}
+			if _, ok := r.capToProvider[capID]; ok {
+				break
+			}
+		}
+	}
+}
+
+// Lookup 获取能力定义及其提供者ID
+func (r *Registry) Lookup(capabilityID string) (Definition, string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	providerID, ok := r.capToProvider[capabilityID]
+	if !ok {
+		return Definition{}, "", false
+	}
+	return r.capabilities[capabilityID], providerID, true
 }
 
 // GetProvider 获取指定ID的提供者
@@ -0,0 +1,78 @@
+package capability
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RunResult 单次能力调用的结果和耗时
+type RunResult struct {
+	Outputs    map[string]interface{}
+	Chunks     int           // 流式分片数，非流式调用为 0
+	FirstChunk time.Duration // 收到首个分片的耗时，非流式调用为 0
+	Elapsed    time.Duration
+	Traces     []Trace // 本次调用的追踪信息，含提供者返回的请求ID
+}
+
+// Run 执行一次能力调用，供调试工具和接口使用
+// 流式执行器的每个分片依次传给 onChunk（可为 nil），并合并为最终输出：content 和 text 按顺序拼接，其它值以最后一个分片为准；
+// 分片中带 error 字段时视为调用失败
+func Run(ctx context.Context, exec Executor, config, inputs map[string]interface{}, onChunk func(map[string]interface{})) (*RunResult, error) {
+	start := time.Now()
+	traces := NewTraces()
+	ctx = WithTraces(ctx, traces)
+	stream, ok := exec.(StreamExecutor)
+	if !ok {
+		outputs, err := exec.Execute(ctx, config, inputs)
+		if err != nil {
+			return nil, err
+		}
+		return &RunResult{Outputs: outputs, Elapsed: time.Since(start), Traces: traces.List()}, nil
+	}
+
+	ch, err := stream.ExecuteStream(ctx, config, inputs)
+	if err != nil {
+		return nil, err
+	}
+	// 提前返回时排空通道，避免生产者阻塞
+	defer func() {
+		go func() {
+			for range ch {
+			}
+		}()
+	}()
+
+	result := &RunResult{Outputs: make(map[string]interface{})}
+	for {
+		select {
+		case chunk, open := <-ch:
+			if !open {
+				result.Elapsed = time.Since(start)
+				result.Traces = traces.List()
+				return result, nil
+			}
+			if result.Chunks == 0 {
+				result.FirstChunk = time.Since(start)
+			}
+			result.Chunks++
+			if errMsg, _ := chunk["error"].(string); errMsg != "" {
+				return nil, fmt.Errorf("%s", errMsg)
+			}
+			if onChunk != nil {
+				onChunk(chunk)
+			}
+			for key, value := range chunk {
+				if key == "content" || key == "text" {
+					if s, ok := value.(string); ok {
+						prev, _ := result.Outputs[key].(string)
+						value = prev + s
+					}
+				}
+				result.Outputs[key] = value
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
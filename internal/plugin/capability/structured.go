@@ -0,0 +1,437 @@
+package capability
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ResponseFormatKey LLM 能力输入中结构化输出参数的键，值的格式见 ParseResponseFormat
+const ResponseFormatKey = "response_format"
+
+// ErrInvalidStructuredOutput 修复重试后 LLM 输出仍不是符合要求的 JSON，可通过 errors.Is 判断
+var ErrInvalidStructuredOutput = errors.New("invalid structured output")
+
+// 结构化输出类型
+const (
+	ResponseFormatText       = "text"        // 不限制输出
+	ResponseFormatJSONObject = "json_object" // 输出任意 JSON 对象
+	ResponseFormatJSONSchema = "json_schema" // 输出符合 Schema 的 JSON
+)
+
+// responseFormatName OpenAI 对 json_schema 名称的要求
+var responseFormatName = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// ResponseFormat LLM 的结构化输出要求
+type ResponseFormat struct {
+	Type   string                 `json:"type"`
+	Name   string                 `json:"name,omitempty"`   // json_schema 的名称，默认 response
+	Schema map[string]interface{} `json:"schema,omitempty"` // JSON Schema，json_schema 时必填
+	Strict bool                   `json:"strict,omitempty"` // 要求提供者严格按 Schema 约束生成（OpenAI strict 模式对 Schema 有额外要求）
+}
+
+// JSON 是否要求输出 JSON
+func (f *ResponseFormat) JSON() bool {
+	return f != nil && (f.Type == ResponseFormatJSONObject || f.Type == ResponseFormatJSONSchema)
+}
+
+// ParseResponseFormat 解析能力输入中的 {"type": "json_schema", "name": "...", "schema": {...}, "strict": false}，
+// type 也可以是 json_object 或 text；v 为 nil 或 type 为 text 时返回 nil
+func ParseResponseFormat(v interface{}) (*ResponseFormat, error) {
+	var f ResponseFormat
+	switch raw := v.(type) {
+	case nil:
+		return nil, nil
+	case *ResponseFormat:
+		if raw == nil {
+			return nil, nil
+		}
+		f = *raw
+	case map[string]interface{}:
+		data, err := json.Marshal(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ResponseFormatKey, err)
+		}
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("%s: %w", ResponseFormatKey, err)
+		}
+	default:
+		return nil, fmt.Errorf("%s must be an object", ResponseFormatKey)
+	}
+
+	switch f.Type {
+	case "", ResponseFormatText:
+		return nil, nil
+	case ResponseFormatJSONObject:
+	case ResponseFormatJSONSchema:
+		if len(f.Schema) == 0 {
+			return nil, fmt.Errorf("%s.schema is required for json_schema", ResponseFormatKey)
+		}
+		if f.Name == "" {
+			f.Name = "response"
+		}
+		if !responseFormatName.MatchString(f.Name) {
+			return nil, fmt.Errorf("%s.name must be 1-64 letters, digits, '_' or '-'", ResponseFormatKey)
+		}
+	default:
+		return nil, fmt.Errorf("%s.type must be text, json_object or json_schema", ResponseFormatKey)
+	}
+	return &f, nil
+}
+
+// Instruction 追加到系统提示词的输出要求，未原生支持结构化输出的提供者依靠它生成 JSON
+func (f *ResponseFormat) Instruction() string {
+	if !f.JSON() {
+		return ""
+	}
+	if f.Type == ResponseFormatJSONObject {
+		return "只输出一个 JSON 对象，不要使用代码块，也不要输出其它内容。"
+	}
+	schema, _ := json.Marshal(f.Schema)
+	return "只输出一个符合以下 JSON Schema 的 JSON 值，不要使用代码块，也不要输出其它内容。\nJSON Schema：" + string(schema)
+}
+
+// Validate 解析并校验 LLM 输出，兼容包在代码块或说明文字中的 JSON
+func (f *ResponseFormat) Validate(output string) (interface{}, []Violation) {
+	text := extractJSON(output)
+	if text == "" {
+		return nil, []Violation{{Field: "$", Message: "output contains no JSON"}}
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		return nil, []Violation{{Field: "$", Message: "invalid JSON: " + err.Error()}}
+	}
+	switch f.Type {
+	case ResponseFormatJSONObject:
+		if _, ok := value.(map[string]interface{}); !ok {
+			return nil, []Violation{{Field: "$", Message: "expected a JSON object"}}
+		}
+	case ResponseFormatJSONSchema:
+		if violations := ValidateJSONSchema(f.Schema, value); len(violations) > 0 {
+			return nil, violations
+		}
+	}
+	return value, nil
+}
+
+// extractJSON 取出输出中的 JSON：去掉代码块标记，截取第一个 { 或 [ 到最后一个 } 或 ]
+func extractJSON(output string) string {
+	text := strings.TrimSpace(output)
+	if strings.HasPrefix(text, "```") {
+		text = strings.TrimPrefix(text, "```json")
+		text = strings.TrimPrefix(text, "```")
+		text = strings.TrimSuffix(strings.TrimSpace(text), "```")
+		text = strings.TrimSpace(text)
+	}
+	if json.Valid([]byte(text)) {
+		return text
+	}
+	start := strings.IndexAny(text, "{[")
+	end := strings.LastIndexAny(text, "}]")
+	if start < 0 || end < start {
+		return ""
+	}
+	return text[start : end+1]
+}
+
+// ValidateJSONSchema 按 JSON Schema 校验 JSON 值（encoding/json 解码的结果），返回全部字段错误
+// 支持 type（含类型数组）、properties、required、additionalProperties、items、enum、const、
+// minimum、maximum、minLength、maxLength、minItems、maxItems 和 anyOf，其它关键字忽略
+func ValidateJSONSchema(schema map[string]interface{}, value interface{}) []Violation {
+	return validateJSON("$", schema, value)
+}
+
+func validateJSON(path string, schema map[string]interface{}, value interface{}) []Violation {
+	if len(schema) == 0 {
+		return nil
+	}
+	if options, ok := schema["anyOf"].([]interface{}); ok && len(options) > 0 {
+		matched := false
+		for _, option := range options {
+			if s, ok := option.(map[string]interface{}); ok && len(validateJSON(path, s, value)) == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return []Violation{{Field: path, Message: "does not match any of anyOf"}}
+		}
+	}
+	if typ, ok := schema["type"]; ok && !matchesJSONType(typ, value) {
+		return []Violation{{Field: path, Message: fmt.Sprintf("expected %v, got %s", typ, jsonTypeName(value))}}
+	}
+	if expected, ok := schema["const"]; ok && !jsonEqual(expected, value) {
+		return []Violation{{Field: path, Message: fmt.Sprintf("must be %v", expected)}}
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && !jsonInEnum(enum, value) {
+		return []Violation{{Field: path, Message: fmt.Sprintf("must be one of %v", enum)}}
+	}
+
+	var violations []Violation
+	switch v := value.(type) {
+	case map[string]interface{}:
+		violations = append(violations, validateJSONObject(path, schema, v)...)
+	case []interface{}:
+		if n, ok := schemaNumber(schema, "minItems"); ok && float64(len(v)) < n {
+			violations = append(violations, Violation{Field: path, Message: fmt.Sprintf("must have at least %v items", n)})
+		}
+		if n, ok := schemaNumber(schema, "maxItems"); ok && float64(len(v)) > n {
+			violations = append(violations, Violation{Field: path, Message: fmt.Sprintf("must have at most %v items", n)})
+		}
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				violations = append(violations, validateJSON(fmt.Sprintf("%s[%d]", path, i), items, item)...)
+			}
+		}
+	case string:
+		length := float64(len([]rune(v)))
+		if n, ok := schemaNumber(schema, "minLength"); ok && length < n {
+			violations = append(violations, Violation{Field: path, Message: fmt.Sprintf("must be at least %v characters", n)})
+		}
+		if n, ok := schemaNumber(schema, "maxLength"); ok && length > n {
+			violations = append(violations, Violation{Field: path, Message: fmt.Sprintf("must be at most %v characters", n)})
+		}
+	case float64:
+		if n, ok := schemaNumber(schema, "minimum"); ok && v < n {
+			violations = append(violations, Violation{Field: path, Message: fmt.Sprintf("must be >= %v", n)})
+		}
+		if n, ok := schemaNumber(schema, "maximum"); ok && v > n {
+			violations = append(violations, Violation{Field: path, Message: fmt.Sprintf("must be <= %v", n)})
+		}
+	}
+	return violations
+}
+
+func validateJSONObject(path string, schema map[string]interface{}, value map[string]interface{}) []Violation {
+	var violations []Violation
+	properties, _ := schema["properties"].(map[string]interface{})
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, name := range required {
+			key, _ := name.(string)
+			if _, ok := value[key]; key != "" && !ok {
+				violations = append(violations, Violation{Field: path + "." + key, Message: "is required"})
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(value))
+	for key := range value {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if prop, ok := properties[key].(map[string]interface{}); ok {
+			violations = append(violations, validateJSON(path+"."+key, prop, value[key])...)
+			continue
+		}
+		if _, declared := properties[key]; declared {
+			continue
+		}
+		switch extra := schema["additionalProperties"].(type) {
+		case bool:
+			if !extra {
+				violations = append(violations, Violation{Field: path + "." + key, Message: "is not allowed"})
+			}
+		case map[string]interface{}:
+			violations = append(violations, validateJSON(path+"."+key, extra, value[key])...)
+		}
+	}
+	return violations
+}
+
+// matchesJSONType typ 为类型名称或类型名称数组
+func matchesJSONType(typ interface{}, value interface{}) bool {
+	switch t := typ.(type) {
+	case string:
+		return matchesJSONTypeName(t, value)
+	case []interface{}:
+		for _, name := range t {
+			if s, ok := name.(string); ok && matchesJSONTypeName(s, value) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+func matchesJSONTypeName(name string, value interface{}) bool {
+	switch name {
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return jsonTypeName(value) == name
+	}
+}
+
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func schemaNumber(schema map[string]interface{}, key string) (float64, bool) {
+	return numberValue(schema[key])
+}
+
+func jsonInEnum(enum []interface{}, value interface{}) bool {
+	for _, candidate := range enum {
+		if jsonEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonEqual 比较 JSON 值，数值按大小比较（Schema 中的数值可能不是 float64）
+func jsonEqual(a, b interface{}) bool {
+	if x, ok := numberValue(a); ok {
+		y, ok := numberValue(b)
+		return ok && x == y
+	}
+	left, errA := json.Marshal(a)
+	right, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(left) == string(right)
+}
+
+// StructuredResult 结构化输出调用的结果
+type StructuredResult struct {
+	*RunResult
+	Value    interface{} // 解析后的 JSON 值
+	Content  string      // 最后一次生成的原始输出
+	Attempts int         // 生成次数，包括修复重试
+}
+
+// StructuredOutputError 修复重试后输出仍不符合要求
+type StructuredOutputError struct {
+	Attempts   int
+	Content    string // 最后一次生成的原始输出
+	Violations []Violation
+}
+
+func (e *StructuredOutputError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = v.Field + ": " + v.Message
+	}
+	return fmt.Sprintf("structured output invalid after %d attempts: %s", e.Attempts, strings.Join(parts, "; "))
+}
+
+// Is 使 errors.Is(err, ErrInvalidStructuredOutput) 成立
+func (e *StructuredOutputError) Is(target error) bool {
+	return target == ErrInvalidStructuredOutput
+}
+
+// RunStructured 调用 LLM 能力并要求输出符合 format 的 JSON
+// 输出要求追加到系统提示词，同时通过 response_format 输入交给提供者按各自的方式约束生成；
+// 输出解析或校验失败时把错误交给 LLM 修复，最多重试 maxRepairs 次，仍失败时返回 *StructuredOutputError
+func RunStructured(ctx context.Context, exec Executor, config, inputs map[string]interface{}, format *ResponseFormat, maxRepairs int) (*StructuredResult, error) {
+	if !format.JSON() {
+		return nil, fmt.Errorf("%s must be json_object or json_schema", ResponseFormatKey)
+	}
+	messages, err := structuredMessages(inputs["messages"], format.Instruction())
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptInputs := make(map[string]interface{}, len(inputs)+1)
+		for k, v := range inputs {
+			attemptInputs[k] = v
+		}
+		attemptInputs["messages"] = messages
+		attemptInputs[ResponseFormatKey] = formatInput(format)
+
+		run, err := Run(ctx, exec, config, attemptInputs, nil)
+		if err != nil {
+			return nil, err
+		}
+		content, _ := run.Outputs["content"].(string)
+		value, violations := format.Validate(content)
+		if len(violations) == 0 {
+			return &StructuredResult{RunResult: run, Value: value, Content: content, Attempts: attempt}, nil
+		}
+		if attempt > maxRepairs {
+			return nil, &StructuredOutputError{Attempts: attempt, Content: content, Violations: violations}
+		}
+		messages = append(messages,
+			map[string]interface{}{"role": "assistant", "content": content},
+			map[string]interface{}{"role": "user", "content": repairPrompt(violations)},
+		)
+	}
+}
+
+// structuredMessages 复制消息并把输出要求追加到系统提示词，没有系统提示词时插入一条
+func structuredMessages(raw interface{}, instruction string) ([]interface{}, error) {
+	list, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("messages must be an array")
+	}
+	messages := make([]interface{}, 0, len(list)+1)
+	appended := false
+	for _, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			messages = append(messages, item)
+			continue
+		}
+		if role, _ := m["role"].(string); role == "system" && !appended {
+			copied := make(map[string]interface{}, len(m))
+			for k, v := range m {
+				copied[k] = v
+			}
+			content, _ := m["content"].(string)
+			copied["content"] = strings.TrimSpace(content + "\n\n" + instruction)
+			m, appended = copied, true
+		}
+		messages = append(messages, m)
+	}
+	if !appended {
+		messages = append([]interface{}{map[string]interface{}{"role": "system", "content": instruction}}, messages...)
+	}
+	return messages, nil
+}
+
+// formatInput 转换为能力输入，经 gRPC 传递时只能使用基本类型
+func formatInput(f *ResponseFormat) map[string]interface{} {
+	input := map[string]interface{}{"type": f.Type}
+	if f.Type == ResponseFormatJSONSchema {
+		input["name"] = f.Name
+		input["schema"] = f.Schema
+		input["strict"] = f.Strict
+	}
+	return input
+}
+
+func repairPrompt(violations []Violation) string {
+	var b strings.Builder
+	b.WriteString("上面的输出不符合要求：\n")
+	for _, v := range violations {
+		fmt.Fprintf(&b, "- %s: %s\n", v.Field, v.Message)
+	}
+	b.WriteString("请修正后重新输出，只输出 JSON，不要包含其它内容。")
+	return b.String()
+}
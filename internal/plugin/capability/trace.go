@@ -0,0 +1,170 @@
+package capability
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/platform/requestid"
+)
+
+// Trace 一次能力调用的追踪信息，随执行结果返回并与对话记录、工作流执行记录一起保存，
+// 调用失败时可据此到提供者侧查找对应的日志
+type Trace struct {
+	Capability         string    `json:"capability"`
+	Provider           string    `json:"provider"`
+	RequestID          string    `json:"request_id,omitempty"`           // 核心的关联ID
+	UpstreamRequestIDs []string  `json:"upstream_request_ids,omitempty"` // 提供者返回的请求ID，如 OpenAI 的 x-request-id
+	StartedAt          time.Time `json:"started_at"`
+	DurationMs         int64     `json:"duration_ms"`
+	Error              string    `json:"error,omitempty"`
+}
+
+// Traces 收集上下文中发生的能力调用追踪信息，可并发使用
+type Traces struct {
+	mu     sync.Mutex
+	traces []Trace
+}
+
+// NewTraces 创建追踪信息收集器
+func NewTraces() *Traces {
+	return &Traces{}
+}
+
+type tracesKey struct{}
+
+// tracesNode 嵌套的收集器链，内层收集到的追踪信息同时记入外层
+type tracesNode struct {
+	traces *Traces
+	parent *tracesNode
+}
+
+// WithTraces 返回收集能力调用追踪信息的上下文，上下文中已有收集器时两者都会收到
+func WithTraces(ctx context.Context, traces *Traces) context.Context {
+	if traces == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, tracesKey{}, &tracesNode{traces: traces, parent: tracesFrom(ctx)})
+}
+
+func tracesFrom(ctx context.Context) *tracesNode {
+	node, _ := ctx.Value(tracesKey{}).(*tracesNode)
+	return node
+}
+
+func (t *Traces) add(trace Trace) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.traces = append(t.traces, trace)
+}
+
+// List 返回按完成顺序排列的追踪信息
+func (t *Traces) List() []Trace {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Trace(nil), t.traces...)
+}
+
+// tracedExecutor 上下文中有收集器时记录调用的追踪信息
+type tracedExecutor struct {
+	base       Executor
+	providerID string
+	def        Definition
+}
+
+// newTracedExecutor 包装执行器，流式执行器保持流式能力
+func newTracedExecutor(base Executor, providerID string, def Definition) Executor {
+	traced := &tracedExecutor{base: base, providerID: providerID, def: def}
+	if stream, ok := base.(StreamExecutor); ok {
+		return &tracedStreamExecutor{tracedExecutor: traced, stream: stream}
+	}
+	return traced
+}
+
+// StartTrace 开始记录一次能力调用，调用结束时以调用结果调用返回的 finish；
+// 返回的上下文收集提供者的请求ID，应传给实际发起请求的代码。上下文中没有收集器时不记录。
+// 经 Registry 获取的执行器已自动记录，不经执行器直接调用提供者时（如核心的 LLM 管理器）使用
+func StartTrace(ctx context.Context, capabilityID, providerID string) (context.Context, func(error)) {
+	if tracesFrom(ctx) == nil {
+		return ctx, func(error) {}
+	}
+	trace := Trace{
+		Capability: capabilityID,
+		Provider:   providerID,
+		RequestID:  requestid.FromContext(ctx),
+		StartedAt:  time.Now(),
+	}
+	callCtx, upstream := requestid.WithUpstream(ctx)
+	var once sync.Once
+	return callCtx, func(err error) {
+		once.Do(func() {
+			trace.DurationMs = time.Since(trace.StartedAt).Milliseconds()
+			trace.UpstreamRequestIDs = upstream.IDs()
+			if err != nil {
+				trace.Error = err.Error()
+			}
+			for node := tracesFrom(ctx); node != nil; node = node.parent {
+				node.traces.add(trace)
+			}
+		})
+	}
+}
+
+func (e *tracedExecutor) Execute(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (map[string]interface{}, error) {
+	if tracesFrom(ctx) == nil {
+		return e.base.Execute(ctx, config, inputs)
+	}
+	callCtx, finish := StartTrace(ctx, e.def.ID, e.providerID)
+	outputs, err := e.base.Execute(callCtx, config, inputs)
+	finish(err)
+	return outputs, err
+}
+
+// tracedStreamExecutor 流式调用在流结束、关闭输出通道前记录追踪信息，
+// 调用方读完通道后即可从收集器取到本次调用
+type tracedStreamExecutor struct {
+	*tracedExecutor
+	stream StreamExecutor
+}
+
+func (e *tracedStreamExecutor) ExecuteStream(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (<-chan map[string]interface{}, error) {
+	if tracesFrom(ctx) == nil {
+		return e.stream.ExecuteStream(ctx, config, inputs)
+	}
+	callCtx, finish := StartTrace(ctx, e.def.ID, e.providerID)
+	ch, err := e.stream.ExecuteStream(callCtx, config, inputs)
+	if err != nil {
+		finish(err)
+		return nil, err
+	}
+
+	out := make(chan map[string]interface{})
+	go func() {
+		defer close(out)
+		var streamErr error
+		defer func() {
+			if streamErr == nil && ctx.Err() != nil {
+				streamErr = ctx.Err()
+			}
+			finish(streamErr)
+		}()
+		for chunk := range ch {
+			if msg, _ := chunk["error"].(string); msg != "" && streamErr == nil {
+				streamErr = errors.New(msg)
+			}
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				// 调用方已放弃读取，排空上游避免提供者阻塞
+				for range ch {
+				}
+				return
+			}
+		}
+	}()
+	return out, nil
+}
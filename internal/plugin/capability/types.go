@@ -8,10 +8,16 @@ import (
 type Type string
 
 const (
-	TypeLLM  Type = "llm"
-	TypeASR  Type = "asr"
-	TypeTTS  Type = "tts"
-	TypeTool Type = "tool"
+	TypeLLM         Type = "llm"
+	TypeASR         Type = "asr"
+	TypeTTS         Type = "tts"
+	TypeTool        Type = "tool"
+	TypeNode        Type = "node"        // 自定义工作流节点，能力ID即节点类型
+	TypeTranslation Type = "translation" // 文本翻译，输入 text/source/target，输出 text
+	TypeEmbedding   Type = "embedding"   // 文本向量化，输入 texts，输出 vectors
+	TypeSearch      Type = "search"      // 网页搜索，输入 query/count，输出 results
+	TypeMedia       Type = "media"       // 媒体检索，输入 query/count，输出 tracks（title/artist/url/live）
+	TypeSmartHome   Type = "smart_home"  // 智能家居桥接，输入 operation（discover/state/control），输出 entities
 )
 
 // Schema describes the data structure for config, inputs, or outputs
@@ -41,6 +47,16 @@ type Definition struct {
 	ConfigSchema Schema `json:"config_schema"` // Static config (API keys, model selection)
 	InputSchema  Schema `json:"input_schema"`  // Runtime inputs (messages, audio bytes)
 	OutputSchema Schema `json:"output_schema"` // Runtime outputs (text, audio bytes)
+
+	UI *UIHints `json:"ui,omitempty"` // 工作流编辑器的展示提示，主要用于 TypeNode
+}
+
+// UIHints 工作流编辑器中节点的展示提示
+type UIHints struct {
+	Category string            `json:"category,omitempty"` // 节点面板中的分组
+	Icon     string            `json:"icon,omitempty"`
+	Color    string            `json:"color,omitempty"`
+	Widgets  map[string]string `json:"widgets,omitempty"` // 配置字段 -> 编辑控件（textarea、select、code 等）
 }
 
 // Executor is the interface that must be implemented to run the capability
@@ -0,0 +1,305 @@
+package capability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrSchemaViolation 输入或输出不符合能力声明的 schema，可通过 errors.Is 判断
+var ErrSchemaViolation = errors.New("capability schema violation")
+
+// ValidationMode schema 校验模式
+type ValidationMode string
+
+const (
+	ValidationOff     ValidationMode = "off"     // 不校验
+	ValidationLenient ValidationMode = "lenient" // 校验失败只记录，调用照常执行
+	ValidationStrict  ValidationMode = "strict"  // 校验失败拒绝调用
+)
+
+// 校验方向
+const (
+	DirectionInput  = "input"
+	DirectionOutput = "output"
+)
+
+// Violation 单个字段的校验错误
+type Violation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// SchemaError 能力输入或输出的校验错误
+type SchemaError struct {
+	CapabilityID string
+	Direction    string // input 或 output
+	Violations   []Violation
+}
+
+func (e *SchemaError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = v.Field + ": " + v.Message
+	}
+	return fmt.Sprintf("%s %s does not match schema: %s", e.CapabilityID, e.Direction, strings.Join(parts, "; "))
+}
+
+// Is 使 errors.Is(err, ErrSchemaViolation) 成立
+func (e *SchemaError) Is(target error) bool {
+	return target == ErrSchemaViolation
+}
+
+// ValidateSchema 按 schema 校验参数，返回全部字段错误
+// 只做校验不做类型转换：number 接受任意数值类型，integer 要求数值无小数部分
+func ValidateSchema(schema Schema, values map[string]interface{}) []Violation {
+	return validateObject("", schema, values, true)
+}
+
+// validateObject 校验对象；checkRequired 为 false 时跳过必填检查，用于流式输出的增量片段
+func validateObject(path string, schema Schema, values map[string]interface{}, checkRequired bool) []Violation {
+	var violations []Violation
+	if checkRequired {
+		for _, name := range schema.Required {
+			if v, ok := values[name]; !ok || v == nil {
+				violations = append(violations, Violation{Field: joinField(path, name), Message: "is required"})
+			}
+		}
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, ok := values[name]
+		if !ok || value == nil {
+			continue
+		}
+		prop := schema.Properties[name]
+		violations = append(violations, validateValue(joinField(path, name), prop.Type, prop.Items, prop.Enum, value, checkRequired)...)
+	}
+	return violations
+}
+
+func validateValue(field, typ string, items *Schema, enum []interface{}, value interface{}, checkRequired bool) []Violation {
+	if msg := checkType(typ, value); msg != "" {
+		return []Violation{{Field: field, Message: msg}}
+	}
+
+	var violations []Violation
+	if items != nil {
+		rv := reflect.ValueOf(value)
+		switch {
+		case rv.Kind() == reflect.Slice && typ == "array" && rv.Type().Elem().Kind() != reflect.Uint8:
+			for i := 0; i < rv.Len(); i++ {
+				item := rv.Index(i).Interface()
+				itemField := fmt.Sprintf("%s[%d]", field, i)
+				if m, ok := item.(map[string]interface{}); ok && len(items.Properties) > 0 {
+					violations = append(violations, validateObject(itemField, *items, m, checkRequired)...)
+				} else if item != nil {
+					violations = append(violations, validateValue(itemField, items.Type, nil, nil, item, checkRequired)...)
+				}
+			}
+		case typ == "object":
+			if m, ok := value.(map[string]interface{}); ok && len(items.Properties) > 0 {
+				violations = append(violations, validateObject(field, *items, m, checkRequired)...)
+			}
+		}
+	}
+
+	if len(enum) > 0 && !matchesEnum(enum, value) {
+		violations = append(violations, Violation{Field: field, Message: fmt.Sprintf("must be one of %v", enum)})
+	}
+	return violations
+}
+
+// checkType 检查值是否符合声明类型，符合时返回空字符串
+func checkType(typ string, value interface{}) string {
+	ok := true
+	switch typ {
+	case "string":
+		_, ok = value.(string)
+	case "number":
+		f, isNumber := numberValue(value)
+		ok = isNumber && !math.IsNaN(f) && !math.IsInf(f, 0)
+	case "integer":
+		f, isNumber := numberValue(value)
+		ok = isNumber && f == math.Trunc(f)
+	case "boolean":
+		_, ok = value.(bool)
+	case "array":
+		kind := reflect.TypeOf(value).Kind()
+		// []byte 在 JSON 中编码为字符串，音频等二进制输入按数组声明时也接受
+		ok = kind == reflect.Slice || kind == reflect.Array
+	case "object":
+		kind := reflect.TypeOf(value).Kind()
+		ok = kind == reflect.Map || kind == reflect.Struct || (kind == reflect.Ptr && reflect.TypeOf(value).Elem().Kind() == reflect.Struct)
+	}
+	if ok {
+		return ""
+	}
+	return fmt.Sprintf("expected %s, got %T", typ, value)
+}
+
+func numberValue(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+func matchesEnum(enum []interface{}, value interface{}) bool {
+	for _, candidate := range enum {
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+		a, okA := numberValue(candidate)
+		b, okB := numberValue(value)
+		if okA && okB && a == b {
+			return true
+		}
+	}
+	return false
+}
+
+func joinField(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// ValidationPolicy 能力 schema 校验策略
+type ValidationPolicy struct {
+	Default      ValidationMode            // 未单独配置的能力使用的模式
+	Capabilities map[string]ValidationMode // 按能力ID覆盖
+
+	// Report 宽松模式下校验失败时调用，用于记录日志；严格模式的错误直接返回给调用方
+	Report func(err *SchemaError)
+}
+
+// Mode 返回能力的校验模式
+func (p *ValidationPolicy) Mode(capabilityID string) ValidationMode {
+	if p == nil {
+		return ValidationOff
+	}
+	if mode, ok := p.Capabilities[capabilityID]; ok && mode != "" {
+		return mode
+	}
+	if p.Default == "" {
+		return ValidationOff
+	}
+	return p.Default
+}
+
+// check 校验并按模式处理结果，严格模式下返回错误
+func (p *ValidationPolicy) check(mode ValidationMode, def Definition, direction string, schema Schema, values map[string]interface{}, partial bool) error {
+	if len(schema.Properties) == 0 && len(schema.Required) == 0 {
+		return nil
+	}
+	violations := validateObject("", schema, values, !partial)
+	if len(violations) == 0 {
+		return nil
+	}
+	err := &SchemaError{CapabilityID: def.ID, Direction: direction, Violations: violations}
+	if mode == ValidationStrict {
+		return err
+	}
+	if p.Report != nil {
+		p.Report(err)
+	}
+	return nil
+}
+
+// validatedExecutor 在执行前后校验输入和输出
+type validatedExecutor struct {
+	base   Executor
+	policy *ValidationPolicy
+	mode   ValidationMode
+	def    Definition
+}
+
+// newValidatedExecutor 包装执行器，流式执行器保持流式能力
+func newValidatedExecutor(base Executor, policy *ValidationPolicy, def Definition) Executor {
+	mode := policy.Mode(def.ID)
+	if mode == ValidationOff {
+		return base
+	}
+	validated := &validatedExecutor{base: base, policy: policy, mode: mode, def: def}
+	if stream, ok := base.(StreamExecutor); ok {
+		return &validatedStreamExecutor{validatedExecutor: validated, stream: stream}
+	}
+	return validated
+}
+
+func (e *validatedExecutor) Execute(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (map[string]interface{}, error) {
+	if err := e.policy.check(e.mode, e.def, DirectionInput, e.def.InputSchema, inputs, false); err != nil {
+		return nil, err
+	}
+	outputs, err := e.base.Execute(ctx, config, inputs)
+	if err != nil {
+		return outputs, err
+	}
+	if err := e.policy.check(e.mode, e.def, DirectionOutput, e.def.OutputSchema, outputs, false); err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+// validatedStreamExecutor 校验流式执行器，输出片段是增量的，只检查类型不检查必填
+type validatedStreamExecutor struct {
+	*validatedExecutor
+	stream StreamExecutor
+}
+
+func (e *validatedStreamExecutor) ExecuteStream(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (<-chan map[string]interface{}, error) {
+	if err := e.policy.check(e.mode, e.def, DirectionInput, e.def.InputSchema, inputs, false); err != nil {
+		return nil, err
+	}
+	ch, err := e.stream.ExecuteStream(ctx, config, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan map[string]interface{})
+	go func() {
+		defer close(out)
+		for chunk := range ch {
+			if _, isError := chunk["error"]; !isError {
+				if err := e.policy.check(e.mode, e.def, DirectionOutput, e.def.OutputSchema, chunk, true); err != nil {
+					// 严格模式下以错误片段结束输出，并排空上游
+					chunk = map[string]interface{}{"error": err.Error()}
+					select {
+					case out <- chunk:
+					case <-ctx.Done():
+					}
+					for range ch {
+					}
+					return
+				}
+			}
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				for range ch {
+				}
+				return
+			}
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,241 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	caValidity   = 30 * 24 * time.Hour // 每次启动重新生成，有效期只需覆盖一次运行
+	leafValidity = 30 * 24 * time.Hour
+	clockSkew    = 5 * time.Minute
+	coreName     = "xiaozhi-core"
+)
+
+// Bundle 插件证书文件，供独立进程的插件加载
+type Bundle struct {
+	PluginID string
+	CertPEM  []byte
+	KeyPEM   []byte
+	CAPEM    []byte
+}
+
+// Authority 核心内置的证书颁发机构
+// 核心启动时生成自签名CA和核心客户端证书，为每个插件签发服务端证书；
+// 插件只接受由该CA签发的核心客户端证书，核心只信任该CA签发、名称与插件ID一致的服务端证书
+type Authority struct {
+	caCert  *x509.Certificate
+	caKey   *ecdsa.PrivateKey
+	caPEM   []byte
+	caPool  *x509.CertPool
+	client  tls.Certificate
+	certDir string
+
+	mu        sync.RWMutex
+	servers   map[string]*tls.Certificate // plugin_id -> 服务端证书
+	addresses map[string]string           // 监听地址 -> plugin_id
+}
+
+// NewAuthority 生成新的CA和核心客户端证书；certDir 非空时签发的证书同时写入该目录
+func NewAuthority(certDir string) (*Authority, error) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate CA key: %w", err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          randomSerial(),
+		Subject:               pkix.Name{CommonName: "xiaozhi plugin CA"},
+		NotBefore:             now.Add(-clockSkew),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, fmt.Errorf("create CA certificate: %w", err)
+	}
+	caCert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("parse CA certificate: %w", err)
+	}
+
+	a := &Authority{
+		caCert:    caCert,
+		caKey:     caKey,
+		caPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		caPool:    x509.NewCertPool(),
+		certDir:   certDir,
+		servers:   make(map[string]*tls.Certificate),
+		addresses: make(map[string]string),
+	}
+	a.caPool.AddCert(caCert)
+
+	client, _, err := a.issue(coreName, x509.ExtKeyUsageClientAuth)
+	if err != nil {
+		return nil, err
+	}
+	a.client = *client
+
+	if certDir != "" {
+		if err := os.MkdirAll(certDir, 0o700); err != nil {
+			return nil, fmt.Errorf("create cert dir %s: %w", certDir, err)
+		}
+		if err := os.WriteFile(filepath.Join(certDir, "ca.pem"), a.caPEM, 0o644); err != nil {
+			return nil, fmt.Errorf("write CA certificate: %w", err)
+		}
+	}
+	return a, nil
+}
+
+// Issue 为插件签发服务端证书，并记录插件的监听地址；重复调用会替换旧证书
+func (a *Authority) Issue(pluginID, address string) (*Bundle, error) {
+	cert, bundle, err := a.issue(pluginID, x509.ExtKeyUsageServerAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.certDir != "" {
+		dir := filepath.Join(a.certDir, pluginID)
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, fmt.Errorf("create cert dir %s: %w", dir, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "cert.pem"), bundle.CertPEM, 0o600); err != nil {
+			return nil, fmt.Errorf("write certificate for %s: %w", pluginID, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "key.pem"), bundle.KeyPEM, 0o600); err != nil {
+			return nil, fmt.Errorf("write key for %s: %w", pluginID, err)
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for addr, id := range a.addresses {
+		if id == pluginID {
+			delete(a.addresses, addr)
+		}
+	}
+	a.servers[pluginID] = cert
+	if address != "" {
+		a.addresses[address] = pluginID
+	}
+	return bundle, nil
+}
+
+// Revoke 移除插件的服务端证书，插件停止后调用
+func (a *Authority) Revoke(pluginID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.servers, pluginID)
+	for addr, id := range a.addresses {
+		if id == pluginID {
+			delete(a.addresses, addr)
+		}
+	}
+}
+
+// ServerTLS 返回监听地址对应插件的服务端TLS配置，要求并校验核心客户端证书
+func (a *Authority) ServerTLS(address string) (*tls.Config, error) {
+	a.mu.RLock()
+	pluginID, ok := a.addresses[address]
+	cert := a.servers[pluginID]
+	a.mu.RUnlock()
+	if !ok || cert == nil {
+		return nil, fmt.Errorf("no certificate issued for %s", address)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{*cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    a.caPool,
+		MinVersion:   tls.VersionTLS13,
+		VerifyPeerCertificate: func(_ [][]byte, chains [][]*x509.Certificate) error {
+			if len(chains) == 0 || chains[0][0].Subject.CommonName != coreName {
+				return fmt.Errorf("client certificate is not issued to %s", coreName)
+			}
+			return nil
+		},
+	}, nil
+}
+
+// ClientTLS 返回连接插件时使用的TLS配置，校验服务端证书名称与插件ID一致
+func (a *Authority) ClientTLS(pluginID string) *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{a.client},
+		RootCAs:      a.caPool,
+		ServerName:   pluginID,
+		MinVersion:   tls.VersionTLS13,
+	}
+}
+
+// CAPEM 返回CA证书
+func (a *Authority) CAPEM() []byte {
+	return a.caPEM
+}
+
+// issue 签发叶子证书，证书名称写入 CN 和 DNS SAN
+func (a *Authority) issue(name string, usage x509.ExtKeyUsage) (*tls.Certificate, *Bundle, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generate key for %s: %w", name, err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: randomSerial(),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    now.Add(-clockSkew),
+		NotAfter:     now.Add(leafValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	if usage == x509.ExtKeyUsageServerAuth {
+		template.DNSNames = append(template.DNSNames, "localhost")
+		template.IPAddresses = []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, a.caCert, &key.PublicKey, a.caKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("sign certificate for %s: %w", name, err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal key for %s: %w", name, err)
+	}
+
+	bundle := &Bundle{
+		PluginID: name,
+		CertPEM:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		KeyPEM:   pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		CAPEM:    a.caPEM,
+	}
+	cert, err := tls.X509KeyPair(bundle.CertPEM, bundle.KeyPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load certificate for %s: %w", name, err)
+	}
+	return &cert, bundle, nil
+}
+
+func randomSerial() *big.Int {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 126))
+	if err != nil {
+		return big.NewInt(time.Now().UnixNano())
+	}
+	return serial
+}
@@ -0,0 +1,51 @@
+package certs
+
+import (
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var (
+	defaultAuthority   *Authority
+	defaultAuthorityMu sync.RWMutex
+)
+
+// SetDefault 设置全局证书颁发机构，传入 nil 表示关闭 mTLS（开发模式）
+func SetDefault(a *Authority) {
+	defaultAuthorityMu.Lock()
+	defer defaultAuthorityMu.Unlock()
+	defaultAuthority = a
+}
+
+// Default 返回全局证书颁发机构，未启用 mTLS 时返回 nil
+func Default() *Authority {
+	defaultAuthorityMu.RLock()
+	defer defaultAuthorityMu.RUnlock()
+	return defaultAuthority
+}
+
+// ServerOptions 返回插件gRPC服务端的传输凭证选项；
+// 启用 mTLS 但未给该地址签发证书时返回错误，避免服务以明文方式监听
+func ServerOptions(address string) ([]grpc.ServerOption, error) {
+	a := Default()
+	if a == nil {
+		return nil, nil
+	}
+	cfg, err := a.ServerTLS(address)
+	if err != nil {
+		return nil, err
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(cfg))}, nil
+}
+
+// DialOption 返回连接插件时使用的传输凭证，未启用 mTLS 时使用明文连接
+func DialOption(pluginID string) grpc.DialOption {
+	a := Default()
+	if a == nil {
+		return grpc.WithTransportCredentials(insecure.NewCredentials())
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(a.ClientTLS(pluginID)))
+}
@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"xiaozhi-server-go/internal/platform/chaos"
 	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/requestid"
+	"xiaozhi-server-go/internal/plugin/grpc/certs"
 	pluginpb "xiaozhi-server-go/gen/go/api/proto"
 )
 
@@ -60,11 +62,7 @@ func (p *ClientPool) AddConnection(pluginID string, address string, info *Plugin
 	}
 
 	// 创建gRPC连接
-	conn, err := grpc.Dial(address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithBlock(),
-		grpc.WithTimeout(5*time.Second),
-	)
+	conn, err := grpc.Dial(address, dialOptions(pluginID)...)
 	if err != nil {
 		return fmt.Errorf("failed to connect to plugin %s at %s: %w", pluginID, address, err)
 	}
@@ -95,6 +93,26 @@ func (p *ClientPool) AddConnection(pluginID string, address string, info *Plugin
 	return nil
 }
 
+// dialOptions 插件连接参数：启用 mTLS 时校验插件证书，调试时可注入故障
+func dialOptions(pluginID string) []grpc.DialOption {
+	opts := []grpc.DialOption{
+		certs.DialOption(pluginID),
+		grpc.WithBlock(),
+		grpc.WithTimeout(5 * time.Second),
+	}
+	opts = append(opts, requestid.DialOptions()...)
+	return append(opts, chaos.DialOptions(pluginID)...)
+}
+
+// Dial 建立到插件的独立连接，供管理接口调用插件调试服务等，调用方负责关闭连接
+func Dial(pluginID, address string) (*grpc.ClientConn, error) {
+	conn, err := grpc.Dial(address, dialOptions(pluginID)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to plugin %s at %s: %w", pluginID, address, err)
+	}
+	return conn, nil
+}
+
 // RemoveConnection 移除插件连接
 func (p *ClientPool) RemoveConnection(pluginID string) error {
 	p.mu.Lock()
@@ -272,11 +290,7 @@ func (p *ClientPool) ReconnectConnection(pluginID string) error {
 	}
 
 	// 创建新连接
-	newConn, err := grpc.Dial(conn.info.Address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithBlock(),
-		grpc.WithTimeout(5*time.Second),
-	)
+	newConn, err := grpc.Dial(conn.info.Address, dialOptions(pluginID)...)
 	if err != nil {
 		conn.info.Status = "error"
 		return fmt.Errorf("failed to reconnect to plugin %s: %w", pluginID, err)
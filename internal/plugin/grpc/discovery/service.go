@@ -7,9 +7,18 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 	pluginpb "xiaozhi-server-go/gen/go/api/proto"
+	"xiaozhi-server-go/internal/platform/chaos"
 	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/requestid"
+	"xiaozhi-server-go/internal/plugin/grpc/certs"
+	"xiaozhi-server-go/internal/plugin/ports"
+)
+
+// 插件连接方式
+const (
+	TransportTCP  = "tcp"
+	TransportUnix = "unix"
 )
 
 // PluginInfo 插件信息
@@ -21,6 +30,7 @@ type PluginInfo struct {
 	Version      string
 	Status       string
 	Address      string
+	Transport    string // 协商后的连接方式：tcp 或 unix
 	Capabilities []string
 	LastSeen     time.Time
 }
@@ -44,17 +54,45 @@ func NewDiscoveryService(logger *logging.Logger) *DiscoveryService {
 
 // RegisterPlugin 注册插件
 func (ds *DiscoveryService) RegisterPlugin(ctx context.Context, pluginID, address string) error {
+	return ds.RegisterPluginEndpoints(ctx, pluginID, address)
+}
+
+// RegisterPluginEndpoints 按优先顺序尝试插件的多个地址，使用第一个能通过信息查询和健康检查的地址注册；
+// 通常将Unix域套接字地址放在TCP地址之前，插件未监听套接字时回退到TCP
+func (ds *DiscoveryService) RegisterPluginEndpoints(ctx context.Context, pluginID string, addresses ...string) error {
+	if len(addresses) == 0 {
+		return fmt.Errorf("no address provided for plugin %s", pluginID)
+	}
+
 	ds.mu.Lock()
 	defer ds.mu.Unlock()
 
+	var lastErr error
+	for _, address := range addresses {
+		err := ds.registerUnsafe(ctx, pluginID, address)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if ds.logger != nil && len(addresses) > 1 {
+			ds.logger.WarnTag("discovery", "插件 %s 地址 %s 不可用，尝试下一个地址: %v", pluginID, address, err)
+		}
+	}
+	return lastErr
+}
+
+// registerUnsafe 连接单个地址并注册插件，调用方需持有锁
+func (ds *DiscoveryService) registerUnsafe(ctx context.Context, pluginID, address string) error {
 	if ds.logger != nil {
 		ds.logger.InfoTag("discovery", "注册插件",
 			"plugin_id", pluginID,
 			"address", address)
 	}
 
-	// 创建gRPC连接
-	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	// 创建gRPC连接，启用 mTLS 时校验插件证书
+	opts := append([]grpc.DialOption{certs.DialOption(pluginID)}, requestid.DialOptions()...)
+	opts = append(opts, chaos.DialOptions(pluginID)...)
+	conn, err := grpc.Dial(address, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to create connection to plugin %s: %w", pluginID, err)
 	}
@@ -76,6 +114,11 @@ func (ds *DiscoveryService) RegisterPlugin(ctx context.Context, pluginID, addres
 		return fmt.Errorf("plugin %s health check failed: %w", pluginID, err)
 	}
 
+	transport := TransportTCP
+	if ports.IsUnixAddress(address) {
+		transport = TransportUnix
+	}
+
 	// 转换能力信息
 	capabilities := make([]string, len(infoResp.Capabilities))
 	for i, cap := range infoResp.Capabilities {
@@ -91,6 +134,7 @@ func (ds *DiscoveryService) RegisterPlugin(ctx context.Context, pluginID, addres
 		Version:      infoResp.PluginInfo.Version,
 		Status:       healthResp.Status,
 		Address:      address,
+		Transport:    transport,
 		Capabilities: capabilities,
 		LastSeen:     time.Now(),
 	}
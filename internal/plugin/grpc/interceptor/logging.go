@@ -6,6 +6,7 @@ import (
 
 	"google.golang.org/grpc"
 	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/requestid"
 )
 
 // LoggingInterceptor 创建gRPC日志拦截器
@@ -74,8 +75,5 @@ func StreamLoggingInterceptor(logger *logging.Logger) grpc.StreamServerIntercept
 
 // getRequestIDFromContext 从上下文中获取请求ID
 func getRequestIDFromContext(ctx context.Context) string {
-	if requestID, ok := ctx.Value("request_id").(string); ok {
-		return requestID
-	}
-	return ""
+	return requestid.FromContext(ctx)
 }
\ No newline at end of file
@@ -3,12 +3,15 @@ package lifecycle
 import (
 	"context"
 	"fmt"
+	"os/exec"
 	"sync"
 	"time"
 
 	"xiaozhi-server-go/internal/platform/logging"
 	"xiaozhi-server-go/internal/plugin/capability"
 	"xiaozhi-server-go/internal/plugin/grpc/discovery"
+	"xiaozhi-server-go/internal/plugin/logs"
+	"xiaozhi-server-go/internal/plugin/ports"
 )
 
 // PluginStatus 插件状态
@@ -43,6 +46,8 @@ type LifecycleManager struct {
 	discovery     *discovery.DiscoveryService
 	plugins       map[string]*PluginMetadata
 	pluginPorts   map[string]int
+	portManager   *ports.PortManager
+	logs          *logs.Manager
 	mu            sync.RWMutex
 	logger        *logging.Logger
 }
@@ -58,10 +63,39 @@ func NewLifecycleManager(
 		discovery:   discovery,
 		plugins:     make(map[string]*PluginMetadata),
 		pluginPorts: getDefaultPluginPorts(),
+		logs:        logs.NewManager(logs.Options{}),
 		logger:      logger,
 	}
 }
 
+// SetLogManager 设置插件日志采集管理器
+func (lm *LifecycleManager) SetLogManager(manager *logs.Manager) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	lm.logs = manager
+}
+
+// Logs 返回插件日志采集管理器
+func (lm *LifecycleManager) Logs() *logs.Manager {
+	lm.mu.RLock()
+	defer lm.mu.RUnlock()
+	return lm.logs
+}
+
+// CaptureProcess 将插件进程的 stdout/stderr 接入日志采集，需在 cmd.Start 之前调用
+func (lm *LifecycleManager) CaptureProcess(pluginID string, cmd *exec.Cmd) {
+	manager := lm.Logs()
+	cmd.Stdout = manager.Writer(pluginID, logs.StreamStdout)
+	cmd.Stderr = manager.Writer(pluginID, logs.StreamStderr)
+}
+
+// SetPortManager 设置端口管理器，启用Unix域套接字时优先通过套接字连接插件
+func (lm *LifecycleManager) SetPortManager(portManager *ports.PortManager) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	lm.portManager = portManager
+}
+
 // getDefaultPluginPorts 获取默认插件端口分配
 func getDefaultPluginPorts() map[string]int {
 	return map[string]int{
@@ -240,10 +274,17 @@ func (lm *LifecycleManager) startPluginUnsafe(ctx context.Context, pluginID stri
 		return fmt.Errorf("no port allocated for plugin %s", pluginID)
 	}
 
-	address := fmt.Sprintf("0.0.0.0:%d", port)
+	// 已分配套接字的插件优先走Unix域套接字，由发现服务协商，失败时回退到TCP
+	addresses := make([]string, 0, 2)
+	if lm.portManager != nil {
+		if path, ok := lm.portManager.GetPluginSocket(pluginID); ok {
+			addresses = append(addresses, ports.UnixAddress(path))
+		}
+	}
+	addresses = append(addresses, fmt.Sprintf("0.0.0.0:%d", port))
 
 	// 注册到发现服务
-	if err := lm.discovery.RegisterPlugin(ctx, pluginID, address); err != nil {
+	if err := lm.discovery.RegisterPluginEndpoints(ctx, pluginID, addresses...); err != nil {
 		return fmt.Errorf("failed to register plugin %s: %w", pluginID, err)
 	}
 
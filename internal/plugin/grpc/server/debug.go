@@ -0,0 +1,143 @@
+package server
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	pluginpb "xiaozhi-server-go/gen/go/api/proto"
+)
+
+// debugEnabled 是否启用插件调试，由核心按 PluginGRPC.Debug 设置
+var debugEnabled atomic.Bool
+
+// SetDebug 设置是否启用插件调试，需在插件gRPC服务器启动前调用
+func SetDebug(enabled bool) {
+	debugEnabled.Store(enabled)
+}
+
+// DebugEnabled 是否启用插件调试
+func DebugEnabled() bool {
+	return debugEnabled.Load()
+}
+
+// callMetrics 按方法统计的gRPC调用次数和耗时
+type callMetrics struct {
+	mu      sync.Mutex
+	methods map[string]*pluginpb.MethodMetrics
+}
+
+func (m *callMetrics) record(method string, elapsed time.Duration, err error) {
+	ms := float64(elapsed.Microseconds()) / 1000
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.methods == nil {
+		m.methods = make(map[string]*pluginpb.MethodMetrics)
+	}
+	stats, ok := m.methods[method]
+	if !ok {
+		stats = &pluginpb.MethodMetrics{Method: method}
+		m.methods[method] = stats
+	}
+	stats.Calls++
+	if err != nil {
+		stats.Errors++
+	}
+	stats.TotalMs += ms
+	if ms > stats.MaxMs {
+		stats.MaxMs = ms
+	}
+}
+
+// snapshot 返回按方法名排序的统计副本
+func (m *callMetrics) snapshot() []*pluginpb.MethodMetrics {
+	m.mu.Lock()
+	out := make([]*pluginpb.MethodMetrics, 0, len(m.methods))
+	for _, stats := range m.methods {
+		out = append(out, &pluginpb.MethodMetrics{
+			Method:  stats.Method,
+			Calls:   stats.Calls,
+			Errors:  stats.Errors,
+			TotalMs: stats.TotalMs,
+			MaxMs:   stats.MaxMs,
+		})
+	}
+	m.mu.Unlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].Method < out[j].Method })
+	return out
+}
+
+// debugService 插件调试服务实现
+type debugService struct {
+	s *GRPCServer
+}
+
+// GetInfo 返回插件信息、进程信息和已注册的gRPC服务
+func (d *debugService) GetInfo(ctx context.Context, _ *pluginpb.GetDebugInfoRequest) (*pluginpb.GetDebugInfoResponse, error) {
+	resp := &pluginpb.GetDebugInfoResponse{
+		Address:       d.s.address,
+		Pid:           int32(os.Getpid()),
+		GoVersion:     runtime.Version(),
+		StartedAt:     timestamppb.New(d.s.startedAt),
+		UptimeSeconds: int64(time.Since(d.s.startedAt).Seconds()),
+	}
+	if d.s.plugin != nil {
+		if info, err := d.s.plugin.GetPluginInfo(ctx, &pluginpb.GetPluginInfoRequest{}); err == nil {
+			resp.PluginInfo = info.GetPluginInfo()
+		}
+	}
+	for name := range d.s.server.GetServiceInfo() {
+		resp.Services = append(resp.Services, name)
+	}
+	sort.Strings(resp.Services)
+	if d.s.logger != nil {
+		resp.LogLevel = d.s.logger.Level()
+	}
+	return resp, nil
+}
+
+// DumpMetrics 返回gRPC调用统计和Go运行时指标
+func (d *debugService) DumpMetrics(context.Context, *pluginpb.DumpMetricsRequest) (*pluginpb.DumpMetricsResponse, error) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	return &pluginpb.DumpMetricsResponse{
+		Methods: d.s.metrics.snapshot(),
+		Runtime: map[string]float64{
+			"goroutines":        float64(runtime.NumGoroutine()),
+			"heap_alloc_bytes":  float64(mem.HeapAlloc),
+			"heap_sys_bytes":    float64(mem.HeapSys),
+			"gc_count":          float64(mem.NumGC),
+			"gc_pause_total_ms": float64(mem.PauseTotalNs) / 1e6,
+		},
+	}, nil
+}
+
+// SetLogLevel 修改插件日志级别；插件与核心在同一进程中运行时共用日志，修改对整个进程生效
+func (d *debugService) SetLogLevel(_ context.Context, req *pluginpb.SetLogLevelRequest) (*pluginpb.SetLogLevelResponse, error) {
+	level := strings.ToLower(strings.TrimSpace(req.GetLevel()))
+	switch level {
+	case "debug", "info", "warn", "error":
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "level must be debug, info, warn or error, got %q", req.GetLevel())
+	}
+	if d.s.logger == nil {
+		return nil, status.Error(codes.FailedPrecondition, "plugin has no logger")
+	}
+	previous := d.s.logger.Level()
+	if !d.s.logger.SetLevel(level) {
+		return nil, status.Error(codes.FailedPrecondition, "plugin logger does not support changing level")
+	}
+	d.s.logger.InfoTag("gRPC", "插件日志级别已修改",
+		"address", d.s.address,
+		"previous_level", previous,
+		"level", level)
+	return &pluginpb.SetLogLevelResponse{PreviousLevel: previous, Level: level}, nil
+}
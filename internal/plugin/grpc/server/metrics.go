@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+
+	pluginpb "xiaozhi-server-go/gen/go/api/proto"
+	"xiaozhi-server-go/internal/plugin/sdk"
+)
+
+// metricsService 为插件服务提供 ScrapeMetrics，其余方法交给插件自己的实现
+type metricsService struct {
+	pluginpb.PluginServiceServer
+	s *GRPCServer
+}
+
+// ScrapeMetrics 返回插件通过 sdk.PluginMetrics 记录的指标和本服务器的gRPC调用统计
+func (m *metricsService) ScrapeMetrics(_ context.Context, req *pluginpb.ScrapeMetricsRequest) (*pluginpb.ScrapeMetricsResponse, error) {
+	resp := &pluginpb.ScrapeMetricsResponse{}
+	if req.GetPluginId() != "" {
+		for _, sample := range sdk.PluginMetrics(req.GetPluginId()).Snapshot() {
+			resp.Metrics = append(resp.Metrics, sampleToPB(sample))
+		}
+	}
+	for _, stats := range m.s.metrics.snapshot() {
+		labels := map[string]string{"method": stats.Method}
+		resp.Metrics = append(resp.Metrics,
+			&pluginpb.MetricSample{
+				Name:   "plugin_grpc_requests_total",
+				Type:   sdk.MetricCounter,
+				Help:   "gRPC requests handled by the plugin.",
+				Labels: labels,
+				Value:  float64(stats.Calls),
+			},
+			&pluginpb.MetricSample{
+				Name:   "plugin_grpc_errors_total",
+				Type:   sdk.MetricCounter,
+				Help:   "gRPC requests that returned an error.",
+				Labels: labels,
+				Value:  float64(stats.Errors),
+			},
+			&pluginpb.MetricSample{
+				Name:   "plugin_grpc_request_seconds_total",
+				Type:   sdk.MetricCounter,
+				Help:   "Total time spent handling gRPC requests.",
+				Labels: labels,
+				Value:  stats.TotalMs / 1000,
+			},
+		)
+	}
+	return resp, nil
+}
+
+func sampleToPB(sample sdk.Sample) *pluginpb.MetricSample {
+	pb := &pluginpb.MetricSample{
+		Name:   sample.Name,
+		Type:   sample.Type,
+		Help:   sample.Help,
+		Labels: sample.Labels,
+		Value:  sample.Value,
+		Count:  sample.Count,
+		Sum:    sample.Sum,
+	}
+	for i, bound := range sample.Buckets {
+		pb.Buckets = append(pb.Buckets, &pluginpb.HistogramBucket{UpperBound: bound, Count: sample.Counts[i]})
+	}
+	return pb
+}
@@ -4,21 +4,29 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"os"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/requestid"
+	"xiaozhi-server-go/internal/plugin/grpc/certs"
+	"xiaozhi-server-go/internal/plugin/ports"
 	pluginpb "xiaozhi-server-go/gen/go/api/proto"
 )
 
 // GRPCServer gRPC服务器封装
 type GRPCServer struct {
-	server   *grpc.Server
-	listener net.Listener
-	address  string
-	logger   *logging.Logger
+	server    *grpc.Server
+	listener  net.Listener
+	address   string
+	logger    *logging.Logger
+	credsErr  error // 启用 mTLS 但缺少证书时记录，启动时返回
+	plugin    pluginpb.PluginServiceServer
+	startedAt time.Time
+	metrics   callMetrics
 }
 
 // NewGRPCServer 创建新的gRPC服务器
@@ -28,33 +36,76 @@ func NewGRPCServer(address string, logger *logging.Logger) *GRPCServer {
 	}
 
 	return &GRPCServer{
-		address: address,
-		logger:  logger,
+		address:   address,
+		logger:    logger,
+		startedAt: time.Now(),
 	}
 }
 
 // RegisterPluginService 注册插件服务
 func (s *GRPCServer) RegisterPluginService(service pluginpb.PluginServiceServer) {
 	if s.server == nil {
-		s.server = grpc.NewServer(
+		// 启用 mTLS 时只接受核心签发的客户端证书
+		opts, err := certs.ServerOptions(s.address)
+		if err != nil {
+			s.credsErr = err
+		}
+		s.server = grpc.NewServer(append(opts,
+			// 核心随调用传来的关联ID放入上下文，并记入插件日志
 			grpc.ChainUnaryInterceptor(
-				// 可以在这里添加拦截器
+				requestid.UnaryServerInterceptor(false),
+				s.logUnary,
 			),
 			grpc.ChainStreamInterceptor(
-				// 可以在这里添加流式拦截器
+				requestid.StreamServerInterceptor(false),
+				s.logStream,
 			),
-		)
+		)...)
 	}
 
-	pluginpb.RegisterPluginServiceServer(s.server, service)
+	pluginpb.RegisterPluginServiceServer(s.server, &metricsService{PluginServiceServer: service, s: s})
+	s.plugin = service
+}
+
+// logUnary 记录调用耗时和关联ID
+func (s *GRPCServer) logUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	s.logCall(ctx, info.FullMethod, start, err)
+	return resp, err
+}
+
+// logStream 记录流式调用耗时和关联ID
+func (s *GRPCServer) logStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	s.logCall(ss.Context(), info.FullMethod, start, err)
+	return err
+}
+
+func (s *GRPCServer) logCall(ctx context.Context, method string, start time.Time, err error) {
+	s.metrics.record(method, time.Since(start), err)
+	if s.logger == nil {
+		return
+	}
+	args := []any{"method", method, "duration_ms", time.Since(start).Milliseconds(), "request_id", requestid.FromContext(ctx)}
+	if err != nil {
+		s.logger.WarnTag("gRPC", "调用失败", append(args, "error", err)...)
+		return
+	}
+	s.logger.DebugTag("gRPC", "调用完成", args...)
 }
 
 // Start 启动gRPC服务器
 func (s *GRPCServer) Start() error {
 	var err error
 
+	if s.credsErr != nil {
+		return fmt.Errorf("refusing to serve %s without TLS credentials: %w", s.address, s.credsErr)
+	}
+
 	// 创建监听器
-	s.listener, err = net.Listen("tcp", s.address)
+	s.listener, err = s.listen()
 	if err != nil {
 		return fmt.Errorf("failed to listen on %s: %w", s.address, err)
 	}
@@ -68,6 +119,29 @@ func (s *GRPCServer) Start() error {
 	return s.server.Serve(s.listener)
 }
 
+// listen 按地址创建监听器，unix:// 地址监听Unix域套接字，其余按TCP处理
+func (s *GRPCServer) listen() (net.Listener, error) {
+	if !ports.IsUnixAddress(s.address) {
+		return net.Listen("tcp", s.address)
+	}
+
+	path := ports.SocketPath(s.address)
+	// 删除上次运行遗留的套接字文件，否则监听会因地址已占用失败
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	// 仅允许同一用户的进程连接
+	if err := os.Chmod(path, 0o600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
+
 // Stop 停止gRPC服务器
 func (s *GRPCServer) Stop() {
 	if s.server != nil {
@@ -112,13 +186,17 @@ func (s *GRPCServer) IsRunning() bool {
 	return s.server != nil && s.listener != nil
 }
 
-// EnableReflection 启用gRPC反射（用于调试）
+// EnableReflection 启用插件调试（SetDebug）时注册gRPC反射和调试服务，便于 grpcurl 和管理界面检查插件；
+// 未启用时不做任何事。需在 RegisterPluginService 之后、Start 之前调用
 func (s *GRPCServer) EnableReflection() {
-	if s.server != nil {
-		reflection.Register(s.server)
-		if s.logger != nil {
-			s.logger.InfoTag("gRPC", "已启用gRPC反射")
-		}
+	if s.server == nil || !DebugEnabled() {
+		return
+	}
+	reflection.Register(s.server)
+	pluginpb.RegisterPluginDebugServiceServer(s.server, &debugService{s: s})
+	if s.logger != nil {
+		s.logger.InfoTag("gRPC", "已启用gRPC反射和插件调试服务",
+			"address", s.address)
 	}
 }
 
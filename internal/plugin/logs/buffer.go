@@ -0,0 +1,137 @@
+package logs
+
+import (
+	"sync"
+	"time"
+)
+
+// 输出流
+const (
+	StreamStdout = "stdout"
+	StreamStderr = "stderr"
+)
+
+// Entry 插件日志行
+type Entry struct {
+	Seq      uint64    `json:"seq"`
+	Time     time.Time `json:"time"`
+	PluginID string    `json:"plugin_id"`
+	Stream   string    `json:"stream"`
+	Level    string    `json:"level"`
+	Message  string    `json:"message"`
+}
+
+// Buffer 单个插件的环形日志缓冲区，写满后覆盖最旧的日志
+type Buffer struct {
+	mu          sync.RWMutex
+	entries     []Entry
+	start       int // 最旧日志的位置
+	size        int
+	nextSeq     uint64
+	subscribers map[chan Entry]struct{}
+	dropped     uint64 // 订阅者处理不及时而丢弃的日志数
+}
+
+// NewBuffer 创建环形缓冲区
+func NewBuffer(capacity int) *Buffer {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &Buffer{
+		entries:     make([]Entry, capacity),
+		nextSeq:     1,
+		subscribers: make(map[chan Entry]struct{}),
+	}
+}
+
+// Append 追加日志并通知订阅者，返回带序号的日志
+func (b *Buffer) Append(entry Entry) Entry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entry.Seq = b.nextSeq
+	b.nextSeq++
+
+	capacity := len(b.entries)
+	if b.size < capacity {
+		b.entries[(b.start+b.size)%capacity] = entry
+		b.size++
+	} else {
+		b.entries[b.start] = entry
+		b.start = (b.start + 1) % capacity
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- entry:
+		default:
+			b.dropped++
+		}
+	}
+	return entry
+}
+
+// Tail 返回最近的 n 行日志，按时间先后排列；n 不大于 0 时返回全部
+func (b *Buffer) Tail(n int) []Entry {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if n <= 0 || n > b.size {
+		n = b.size
+	}
+	result := make([]Entry, n)
+	capacity := len(b.entries)
+	for i := 0; i < n; i++ {
+		result[i] = b.entries[(b.start+b.size-n+i)%capacity]
+	}
+	return result
+}
+
+// Since 返回序号大于 seq 的日志，按时间先后排列；已被覆盖的日志无法取回
+func (b *Buffer) Since(seq uint64) []Entry {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	capacity := len(b.entries)
+	var result []Entry
+	for i := 0; i < b.size; i++ {
+		entry := b.entries[(b.start+i)%capacity]
+		if entry.Seq > seq {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// LastSeq 返回最新一条日志的序号，没有日志时返回 0
+func (b *Buffer) LastSeq() uint64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.nextSeq - 1
+}
+
+// Subscribe 订阅新日志，返回的 cancel 用于取消订阅；订阅者处理不及时时丢弃日志而不阻塞写入
+func (b *Buffer) Subscribe() (<-chan Entry, func()) {
+	ch := make(chan Entry, 256)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Len 当前缓存的日志行数
+func (b *Buffer) Len() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.size
+}
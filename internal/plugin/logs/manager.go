@@ -0,0 +1,251 @@
+package logs
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBufferLines = 5000
+	defaultMaxFileSize = 10 * 1024 * 1024
+	defaultMaxBackups  = 3
+	maxLineLength      = 64 * 1024 // 超长的单行日志截断，避免无换行输出占满内存
+)
+
+// Options 插件日志采集配置
+type Options struct {
+	BufferLines int    // 每个插件在内存中保留的日志行数
+	Dir         string // 日志文件目录，为空时只保留在内存中
+	MaxFileSize int64  // 单个日志文件大小上限（字节），超过后轮转
+	MaxBackups  int    // 保留的轮转文件数
+}
+
+// Manager 插件日志采集管理器
+// 为每个插件的 stdout/stderr 提供写入器，按行解析级别后写入环形缓冲区，并可同时落盘
+type Manager struct {
+	opts Options
+
+	mu      sync.Mutex
+	buffers map[string]*Buffer
+	files   map[string]*rotatingFile
+}
+
+// NewManager 创建日志采集管理器
+func NewManager(opts Options) *Manager {
+	if opts.BufferLines <= 0 {
+		opts.BufferLines = defaultBufferLines
+	}
+	if opts.MaxFileSize <= 0 {
+		opts.MaxFileSize = defaultMaxFileSize
+	}
+	if opts.MaxBackups <= 0 {
+		opts.MaxBackups = defaultMaxBackups
+	}
+	return &Manager{
+		opts:    opts,
+		buffers: make(map[string]*Buffer),
+		files:   make(map[string]*rotatingFile),
+	}
+}
+
+// Buffer 获取插件的日志缓冲区，不存在时创建
+func (m *Manager) Buffer(pluginID string) *Buffer {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.bufferLocked(pluginID)
+}
+
+func (m *Manager) bufferLocked(pluginID string) *Buffer {
+	buf, ok := m.buffers[pluginID]
+	if !ok {
+		buf = NewBuffer(m.opts.BufferLines)
+		m.buffers[pluginID] = buf
+	}
+	return buf
+}
+
+// Lookup 获取插件的日志缓冲区，插件从未输出日志时返回 false
+func (m *Manager) Lookup(pluginID string) (*Buffer, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	buf, ok := m.buffers[pluginID]
+	return buf, ok
+}
+
+// Plugins 返回已采集日志的插件ID
+func (m *Manager) Plugins() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := make([]string, 0, len(m.buffers))
+	for id := range m.buffers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Writer 返回插件指定输出流的写入器，可直接赋给 exec.Cmd 的 Stdout/Stderr
+func (m *Manager) Writer(pluginID, stream string) io.Writer {
+	m.mu.Lock()
+	buf := m.bufferLocked(pluginID)
+	file := m.fileLocked(pluginID)
+	m.mu.Unlock()
+
+	return &lineWriter{pluginID: pluginID, stream: stream, buffer: buf, file: file}
+}
+
+func (m *Manager) fileLocked(pluginID string) *rotatingFile {
+	if m.opts.Dir == "" {
+		return nil
+	}
+	file, ok := m.files[pluginID]
+	if !ok {
+		file = &rotatingFile{
+			path:       filepath.Join(m.opts.Dir, pluginID+".log"),
+			maxSize:    m.opts.MaxFileSize,
+			maxBackups: m.opts.MaxBackups,
+		}
+		m.files[pluginID] = file
+	}
+	return file
+}
+
+// Close 关闭所有日志文件
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, file := range m.files {
+		file.close()
+	}
+	return nil
+}
+
+// lineWriter 按行切分输出并写入缓冲区
+type lineWriter struct {
+	pluginID string
+	stream   string
+	buffer   *Buffer
+	file     *rotatingFile
+
+	mu      sync.Mutex
+	pending []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.pending = append(w.pending, p...)
+	for {
+		idx := bytes.IndexByte(w.pending, '\n')
+		if idx < 0 {
+			break
+		}
+		w.emit(w.pending[:idx])
+		w.pending = w.pending[idx+1:]
+	}
+	if len(w.pending) > maxLineLength {
+		w.emit(w.pending[:maxLineLength])
+		w.pending = w.pending[maxLineLength:]
+	}
+	if len(w.pending) == 0 {
+		w.pending = nil
+	}
+	return len(p), nil
+}
+
+func (w *lineWriter) emit(raw []byte) {
+	line := string(bytes.TrimRight(raw, "\r"))
+	if line == "" {
+		return
+	}
+	level, message := ParseLine(w.stream, line)
+	entry := w.buffer.Append(Entry{
+		Time:     time.Now(),
+		PluginID: w.pluginID,
+		Stream:   w.stream,
+		Level:    level,
+		Message:  message,
+	})
+	if w.file != nil {
+		w.file.write(fmt.Sprintf("%s [%s] %s %s\n", entry.Time.Format(time.RFC3339Nano), w.stream, level, line))
+	}
+}
+
+// rotatingFile 按大小轮转的日志文件：xxx.log -> xxx.log.1 -> ... -> xxx.log.N
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+func (f *rotatingFile) write(line string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return
+		}
+	}
+	if f.size+int64(len(line)) > f.maxSize {
+		f.rotate()
+		if f.file == nil {
+			return
+		}
+	}
+	n, _ := f.file.WriteString(line)
+	f.size += int64(n)
+}
+
+func (f *rotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+func (f *rotatingFile) rotate() {
+	f.file.Close()
+	f.file = nil
+
+	os.Remove(fmt.Sprintf("%s.%d", f.path, f.maxBackups))
+	for i := f.maxBackups - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", f.path, i), fmt.Sprintf("%s.%d", f.path, i+1))
+	}
+	os.Rename(f.path, f.path+".1")
+
+	if err := f.open(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open plugin log file %s: %v\n", f.path, err)
+	}
+}
+
+func (f *rotatingFile) close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file != nil {
+		f.file.Close()
+		f.file = nil
+	}
+}
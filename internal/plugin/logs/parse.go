@@ -0,0 +1,116 @@
+package logs
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// 日志级别
+const (
+	LevelDebug = "debug"
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
+
+// ParseLine 解析日志行的级别和正文
+// 支持 JSON 日志（level/msg 字段）、logfmt 风格的 level=xxx、[LEVEL] 前缀和以级别开头的行，
+// 无法识别时 stderr 视为 warn，stdout 视为 info
+func ParseLine(stream, line string) (level, message string) {
+	trimmed := strings.TrimSpace(line)
+
+	if strings.HasPrefix(trimmed, "{") {
+		var fields map[string]interface{}
+		if err := json.Unmarshal([]byte(trimmed), &fields); err == nil {
+			if lv, ok := fields["level"].(string); ok {
+				level = normalizeLevel(lv)
+			}
+			if msg, ok := fields["msg"].(string); ok {
+				message = msg
+			} else if msg, ok := fields["message"].(string); ok {
+				message = msg
+			}
+		}
+	}
+
+	if level == "" {
+		level = detectLevel(trimmed)
+	}
+	if level == "" {
+		level = LevelInfo
+		if stream == StreamStderr {
+			level = LevelWarn
+		}
+	}
+	if message == "" {
+		message = trimmed
+	}
+	return level, message
+}
+
+// detectLevel 从文本日志中识别级别
+func detectLevel(line string) string {
+	lower := strings.ToLower(line)
+	if idx := strings.Index(lower, "level="); idx >= 0 {
+		value := lower[idx+len("level="):]
+		if end := strings.IndexAny(value, " \t"); end >= 0 {
+			value = value[:end]
+		}
+		if lv := normalizeLevel(strings.Trim(value, `"`)); lv != "" {
+			return lv
+		}
+	}
+
+	// 只检查行首附近，避免正文中的单词被误判为级别
+	head := lower
+	if len(head) > 40 {
+		head = head[:40]
+	}
+	for _, candidate := range []struct {
+		tokens []string
+		level  string
+	}{
+		{[]string{"[error]", "error", "[err]", "fatal", "panic"}, LevelError},
+		{[]string{"[warn]", "[warning]", "warning", "warn"}, LevelWarn},
+		{[]string{"[info]", "info"}, LevelInfo},
+		{[]string{"[debug]", "debug", "[trace]", "trace"}, LevelDebug},
+	} {
+		for _, token := range candidate.tokens {
+			if strings.HasPrefix(head, token) || strings.Contains(head, " "+token+" ") || strings.Contains(head, " "+token+":") {
+				return candidate.level
+			}
+		}
+	}
+	return ""
+}
+
+func normalizeLevel(level string) string {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug", "trace":
+		return LevelDebug
+	case "info", "notice":
+		return LevelInfo
+	case "warn", "warning":
+		return LevelWarn
+	case "error", "err", "fatal", "panic", "critical":
+		return LevelError
+	default:
+		return ""
+	}
+}
+
+// LevelRank 级别排序值，用于按最低级别过滤
+func LevelRank(level string) int {
+	switch level {
+	case LevelDebug:
+		return 0
+	case LevelInfo:
+		return 1
+	case LevelWarn:
+		return 2
+	case LevelError:
+		return 3
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,195 @@
+// Package metrics 插件指标汇总
+// 核心定期调用各插件的 ScrapeMetrics，把插件指标加上 plugin_id 标签后合并为 Prometheus 文本格式输出
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pluginpb "xiaozhi-server-go/gen/go/api/proto"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/plugin/grpc/client"
+)
+
+const (
+	defaultInterval = 15 * time.Second
+	defaultTimeout  = 5 * time.Second
+)
+
+// Options 插件指标汇总配置
+type Options struct {
+	Interval time.Duration // 采集间隔
+	Timeout  time.Duration // 单个插件的采集超时
+}
+
+// Aggregator 插件指标汇总器
+type Aggregator struct {
+	registry *capability.Registry
+	opts     Options
+	logger   *logging.Logger
+
+	mu      sync.RWMutex
+	results map[string]*scrapeResult
+	conns   map[string]*pluginConn
+}
+
+// scrapeResult 插件最近一次采集结果
+type scrapeResult struct {
+	up       bool
+	duration time.Duration
+	samples  []*pluginpb.MetricSample // 采集失败时保留上一次的结果
+}
+
+// pluginConn 到插件的连接，插件地址变化时重新连接
+type pluginConn struct {
+	address string
+	conn    *grpc.ClientConn
+}
+
+// NewAggregator 创建插件指标汇总器
+func NewAggregator(registry *capability.Registry, opts Options, logger *logging.Logger) *Aggregator {
+	if opts.Interval <= 0 {
+		opts.Interval = defaultInterval
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Aggregator{
+		registry: registry,
+		opts:     opts,
+		logger:   logger,
+		results:  make(map[string]*scrapeResult),
+		conns:    make(map[string]*pluginConn),
+	}
+}
+
+// Run 按采集间隔定期采集，直到 ctx 取消
+func (a *Aggregator) Run(ctx context.Context) error {
+	defer a.closeConns()
+	ticker := time.NewTicker(a.opts.Interval)
+	defer ticker.Stop()
+	for {
+		a.Scrape(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Scrape 采集一次全部运行中的gRPC插件，已移除的插件不再输出
+func (a *Aggregator) Scrape(ctx context.Context) {
+	targets := make(map[string]string)
+	for pluginID, providers := range a.registry.GetAllProviders() {
+		for _, provider := range providers {
+			if grpcProvider, ok := provider.(capability.GRPCProvider); ok {
+				if address := grpcProvider.GetServiceAddress(); address != "" {
+					targets[pluginID] = address
+				}
+			}
+		}
+	}
+
+	var wg sync.WaitGroup
+	for pluginID, address := range targets {
+		wg.Add(1)
+		go func(pluginID, address string) {
+			defer wg.Done()
+			a.scrape(ctx, pluginID, address)
+		}(pluginID, address)
+	}
+	wg.Wait()
+
+	a.mu.Lock()
+	for pluginID, pc := range a.conns {
+		if _, ok := targets[pluginID]; !ok {
+			pc.conn.Close()
+			delete(a.conns, pluginID)
+			delete(a.results, pluginID)
+		}
+	}
+	a.mu.Unlock()
+}
+
+func (a *Aggregator) scrape(ctx context.Context, pluginID, address string) {
+	start := time.Now()
+	samples, err := a.fetch(ctx, pluginID, address)
+	duration := time.Since(start)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	result, ok := a.results[pluginID]
+	if !ok {
+		result = &scrapeResult{}
+		a.results[pluginID] = result
+	}
+	result.duration = duration
+	if err != nil {
+		if result.up {
+			a.logger.WarnTag("插件指标", "采集插件 %s 的指标失败: %v", pluginID, err)
+		}
+		result.up = false
+		return
+	}
+	result.up = true
+	result.samples = samples
+}
+
+func (a *Aggregator) fetch(ctx context.Context, pluginID, address string) ([]*pluginpb.MetricSample, error) {
+	conn, err := a.conn(pluginID, address)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, a.opts.Timeout)
+	defer cancel()
+	resp, err := pluginpb.NewPluginServiceClient(conn).ScrapeMetrics(ctx, &pluginpb.ScrapeMetricsRequest{PluginId: pluginID})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetMetrics(), nil
+}
+
+// conn 返回到插件的连接，首次采集或插件地址变化时建立连接
+func (a *Aggregator) conn(pluginID, address string) (*grpc.ClientConn, error) {
+	a.mu.Lock()
+	pc, ok := a.conns[pluginID]
+	if ok && pc.address == address {
+		a.mu.Unlock()
+		return pc.conn, nil
+	}
+	if ok {
+		pc.conn.Close()
+		delete(a.conns, pluginID)
+	}
+	a.mu.Unlock()
+
+	conn, err := client.Dial(pluginID, address)
+	if err != nil {
+		return nil, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if existing, ok := a.conns[pluginID]; ok && existing.address == address {
+		conn.Close()
+		return existing.conn, nil
+	}
+	a.conns[pluginID] = &pluginConn{address: address, conn: conn}
+	return conn, nil
+}
+
+func (a *Aggregator) closeConns() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for pluginID, pc := range a.conns {
+		pc.conn.Close()
+		delete(a.conns, pluginID)
+	}
+}
@@ -0,0 +1,182 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	pluginpb "xiaozhi-server-go/gen/go/api/proto"
+	"xiaozhi-server-go/internal/plugin/sdk"
+)
+
+// ContentType Prometheus 文本格式
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// family 同名指标的全部序列，来自一个或多个插件
+type family struct {
+	name   string
+	kind   string
+	help   string
+	series []series
+}
+
+type series struct {
+	pluginID string
+	sample   *pluginpb.MetricSample
+}
+
+// WritePrometheus 以 Prometheus 文本格式输出插件指标，每个序列加上 plugin_id 标签
+// 同名指标在不同插件中类型不一致时只输出第一个类型；另外输出各插件的 plugin_scrape_up 和 plugin_scrape_duration_seconds
+func (a *Aggregator) WritePrometheus(w io.Writer) error {
+	a.mu.RLock()
+	pluginIDs := make([]string, 0, len(a.results))
+	for pluginID := range a.results {
+		pluginIDs = append(pluginIDs, pluginID)
+	}
+	sort.Strings(pluginIDs)
+
+	families := make(map[string]*family)
+	up := make([]string, len(pluginIDs))
+	durations := make([]string, len(pluginIDs))
+	for i, pluginID := range pluginIDs {
+		result := a.results[pluginID]
+		labels := `{plugin_id="` + escapeLabel(pluginID) + `"}`
+		up[i] = "plugin_scrape_up" + labels + " " + boolValue(result.up)
+		durations[i] = "plugin_scrape_duration_seconds" + labels + " " + formatValue(result.duration.Seconds())
+		for _, sample := range result.samples {
+			name := sanitizeName(sample.GetName())
+			if name == "" || (sample.GetType() != sdk.MetricCounter && sample.GetType() != sdk.MetricHistogram) {
+				continue
+			}
+			f, ok := families[name]
+			if !ok {
+				f = &family{name: name, kind: sample.GetType(), help: sample.GetHelp()}
+				families[name] = f
+			}
+			if f.kind != sample.GetType() {
+				continue
+			}
+			f.series = append(f.series, series{pluginID: pluginID, sample: sample})
+		}
+	}
+	a.mu.RUnlock()
+
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	bw := bufio.NewWriter(w)
+	writeFamilyHeader(bw, "plugin_scrape_up", "gauge", "Whether the last metrics scrape of the plugin succeeded.")
+	for _, line := range up {
+		fmt.Fprintln(bw, line)
+	}
+	writeFamilyHeader(bw, "plugin_scrape_duration_seconds", "gauge", "Duration of the last metrics scrape of the plugin.")
+	for _, line := range durations {
+		fmt.Fprintln(bw, line)
+	}
+	for _, name := range names {
+		f := families[name]
+		writeFamilyHeader(bw, f.name, f.kind, f.help)
+		for _, s := range f.series {
+			writeSeries(bw, f, s)
+		}
+	}
+	return bw.Flush()
+}
+
+func writeFamilyHeader(w *bufio.Writer, name, kind, help string) {
+	if help != "" {
+		fmt.Fprintf(w, "# HELP %s %s\n", name, strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help))
+	}
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, kind)
+}
+
+func writeSeries(w *bufio.Writer, f *family, s series) {
+	labels := formatLabels(s.pluginID, s.sample.GetLabels())
+	if f.kind == sdk.MetricCounter {
+		fmt.Fprintf(w, "%s{%s} %s\n", f.name, labels, formatValue(s.sample.GetValue()))
+		return
+	}
+	for _, bucket := range s.sample.GetBuckets() {
+		fmt.Fprintf(w, "%s_bucket{%s,le=\"%s\"} %d\n", f.name, labels, formatValue(bucket.GetUpperBound()), bucket.GetCount())
+	}
+	fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", f.name, labels, s.sample.GetCount())
+	fmt.Fprintf(w, "%s_sum{%s} %s\n", f.name, labels, formatValue(s.sample.GetSum()))
+	fmt.Fprintf(w, "%s_count{%s} %d\n", f.name, labels, s.sample.GetCount())
+}
+
+// formatLabels 按标签名排序输出，plugin_id 总是第一个且不能被插件覆盖
+func formatLabels(pluginID string, labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		if clean := sanitizeLabel(name); clean != "" && clean != "plugin_id" && clean != "le" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	parts := make([]string, 0, len(names)+1)
+	parts = append(parts, `plugin_id="`+escapeLabel(pluginID)+`"`)
+	for _, name := range names {
+		parts = append(parts, sanitizeLabel(name)+`="`+escapeLabel(labels[name])+`"`)
+	}
+	return strings.Join(parts, ",")
+}
+
+// sanitizeName 把指标名中不允许的字符替换为下划线
+func sanitizeName(name string) string {
+	return sanitize(name, true)
+}
+
+func sanitizeLabel(name string) string {
+	if strings.HasPrefix(name, "__") {
+		return ""
+	}
+	return sanitize(name, false)
+}
+
+func sanitize(name string, allowColon bool) string {
+	if name == "" {
+		return ""
+	}
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_', allowColon && r == ':':
+			b.WriteRune(r)
+		case r >= '0' && r <= '9' && i > 0:
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func boolValue(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
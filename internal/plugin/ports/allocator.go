@@ -1,8 +1,10 @@
 package ports
 
 import (
+	"context"
 	"fmt"
 	"net"
+	"sort"
 	"sync"
 	"time"
 
@@ -15,10 +17,18 @@ type PortAllocator struct {
 	allocated map[int]bool              // 已分配端口
 	reserved  map[string]int            // 预留端口 plugin_id -> port
 	records   map[int]*PortAllocation   // 端口分配记录
+	history   []PortEvent                // 最近的分配历史，未配置持久化时用于查询
+	store     Store                      // 持久化存储，可为空
 	mutex     sync.RWMutex               // 读写锁
 	logger    *logging.Logger            // 日志记录器
 }
 
+const (
+	maxHistoryEvents = 500             // 内存中保留的历史事件数
+	storeTimeout     = 3 * time.Second // 单次持久化操作超时
+	probeTimeout     = 200 * time.Millisecond
+)
+
 // NewPortAllocator 创建新的端口分配器
 func NewPortAllocator(portRange PortRange, logger *logging.Logger) *PortAllocator {
 	if logger == nil {
@@ -54,7 +64,7 @@ func (pa *PortAllocator) FindAvailablePort(pluginID string) (int, error) {
 	if port, exists := pa.reserved[pluginID]; exists {
 		if pa.IsPortAvailableUnlocked(port) {
 			pa.allocated[port] = true
-			pa.updateRecord(port, pluginID, StatusAllocated)
+			pa.updateRecord(port, pluginID, StatusAllocated, "reuse reserved port")
 
 			if pa.logger != nil {
 				pa.logger.InfoTag("port_allocator", "使用预分配端口",
@@ -66,7 +76,7 @@ func (pa *PortAllocator) FindAvailablePort(pluginID string) (int, error) {
 		// 预分配端口不可用，清除记录
 		delete(pa.reserved, pluginID)
 		delete(pa.allocated, port)
-		pa.updateRecord(port, pluginID, StatusError)
+		pa.updateRecord(port, pluginID, StatusError, "reserved port is in use")
 	}
 
 	// 在指定范围内寻找可用端口
@@ -74,7 +84,7 @@ func (pa *PortAllocator) FindAvailablePort(pluginID string) (int, error) {
 		if !pa.allocated[port] && pa.IsPortAvailableUnlocked(port) {
 			pa.allocated[port] = true
 			pa.reserved[pluginID] = port
-			pa.updateRecord(port, pluginID, StatusAllocated)
+			pa.updateRecord(port, pluginID, StatusAllocated, "")
 
 			if pa.logger != nil {
 				pa.logger.InfoTag("port_allocator", "成功分配端口",
@@ -120,6 +130,12 @@ func (pa *PortAllocator) IsPortAvailableUnlocked(port int) bool {
 		return false
 	}
 	conn.Close()
+
+	// 再探测本机回环地址，避免与只监听 127.0.0.1 的残留进程冲突
+	if probe, err := net.DialTimeout("tcp", fmt.Sprintf("127.0.0.1:%d", port), probeTimeout); err == nil {
+		probe.Close()
+		return false
+	}
 	return true
 }
 
@@ -146,7 +162,7 @@ func (pa *PortAllocator) ReservePort(pluginID string, port int) error {
 	// 预留端口
 	pa.allocated[port] = true
 	pa.reserved[pluginID] = port
-	pa.updateRecord(port, pluginID, StatusReserved)
+	pa.updateRecord(port, pluginID, StatusReserved, "")
 
 	if pa.logger != nil {
 		pa.logger.InfoTag("port_allocator", "预留端口成功",
@@ -177,10 +193,13 @@ func (pa *PortAllocator) ReleasePort(port int) {
 
 	if pluginID != "" {
 		delete(pa.reserved, pluginID)
+	} else if record, ok := pa.records[port]; ok {
+		// 孤儿端口不在预留表中，从分配记录取插件ID
+		pluginID = record.PluginID
 	}
 
 	delete(pa.allocated, port)
-	pa.updateRecord(port, pluginID, StatusReleased)
+	pa.updateRecord(port, pluginID, StatusReleased, "")
 
 	if pa.logger != nil {
 		pa.logger.InfoTag("port_allocator", "释放端口",
@@ -197,7 +216,7 @@ func (pa *PortAllocator) ReleasePluginPorts(pluginID string) {
 	if port, exists := pa.reserved[pluginID]; exists {
 		delete(pa.reserved, pluginID)
 		delete(pa.allocated, port)
-		pa.updateRecord(port, pluginID, StatusReleased)
+		pa.updateRecord(port, pluginID, StatusReleased, "")
 
 		if pa.logger != nil {
 			pa.logger.InfoTag("port_allocator", "释放插件端口",
@@ -231,11 +250,19 @@ func (pa *PortAllocator) GetStats() PortStats {
 		usagePercent = float64(allocatedPorts) / float64(totalPorts) * 100
 	}
 
+	orphanedPorts := 0
+	for port := range pa.allocated {
+		if record, ok := pa.records[port]; ok && record.Status == string(StatusOrphaned) {
+			orphanedPorts++
+		}
+	}
+
 	return PortStats{
 		TotalPorts:     totalPorts,
 		AllocatedPorts: allocatedPorts,
 		AvailablePorts: availablePorts,
 		ReservedPorts:  reservedPorts,
+		OrphanedPorts:  orphanedPorts,
 		UsagePercent:   usagePercent,
 	}
 }
@@ -249,12 +276,13 @@ func (pa *PortAllocator) GetAllocations() []PortAllocation {
 	for _, record := range pa.records {
 		allocations = append(allocations, *record)
 	}
+	sort.Slice(allocations, func(i, j int) bool { return allocations[i].Port < allocations[j].Port })
 
 	return allocations
 }
 
 // updateRecord 更新端口分配记录
-func (pa *PortAllocator) updateRecord(port int, pluginID string, status PortAllocationStatus) {
+func (pa *PortAllocator) updateRecord(port int, pluginID string, status PortAllocationStatus, reason string) {
 	if _, exists := pa.records[port]; !exists {
 		pa.records[port] = &PortAllocation{
 			Port:      port,
@@ -268,6 +296,131 @@ func (pa *PortAllocator) updateRecord(port int, pluginID string, status PortAllo
 		pa.records[port].Status = string(status)
 		pa.records[port].Timestamp = time.Now()
 	}
+	pa.persist(*pa.records[port], reason)
+}
+
+// persist 记录历史事件并写入持久化存储，调用方需持有锁
+func (pa *PortAllocator) persist(record PortAllocation, reason string) {
+	event := PortEvent{
+		Port:      record.Port,
+		PluginID:  record.PluginID,
+		Status:    record.Status,
+		Reason:    reason,
+		Timestamp: record.Timestamp,
+	}
+	pa.history = append(pa.history, event)
+	if len(pa.history) > maxHistoryEvents {
+		pa.history = pa.history[len(pa.history)-maxHistoryEvents:]
+	}
+
+	if pa.store == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+	if err := pa.store.Save(ctx, record); err != nil && pa.logger != nil {
+		pa.logger.WarnTag("port_allocator", "保存端口 %d 分配状态失败: %v", record.Port, err)
+	}
+	if err := pa.store.AppendEvent(ctx, event); err != nil && pa.logger != nil {
+		pa.logger.WarnTag("port_allocator", "记录端口 %d 分配历史失败: %v", record.Port, err)
+	}
+}
+
+// SetStore 设置持久化存储，需在分配端口之前调用
+func (pa *PortAllocator) SetStore(store Store) {
+	pa.mutex.Lock()
+	defer pa.mutex.Unlock()
+	pa.store = store
+}
+
+// Restore 从持久化存储恢复上次运行遗留的分配记录
+// 上次运行的插件进程已随核心退出，端口空闲时直接回收；仍被占用时标记为孤儿端口，
+// 不再分配给其他插件，等待占用进程退出后由 ReclaimOrphans 回收
+func (pa *PortAllocator) Restore(ctx context.Context) (RestoreResult, error) {
+	pa.mutex.Lock()
+	defer pa.mutex.Unlock()
+
+	var result RestoreResult
+	if pa.store == nil {
+		return result, nil
+	}
+
+	allocations, err := pa.store.LoadActive(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	for _, allocation := range allocations {
+		port := allocation.Port
+		if pa.allocated[port] {
+			continue
+		}
+		if port >= pa.portRange.Start && port <= pa.portRange.End && !pa.IsPortAvailableUnlocked(port) {
+			pa.allocated[port] = true
+			pa.updateRecord(port, allocation.PluginID, StatusOrphaned, "port still bound after restart")
+			result.Orphaned++
+			if pa.logger != nil {
+				pa.logger.WarnTag("port_allocator", "端口 %d 仍被上次运行的插件 %s 占用，暂不分配", port, allocation.PluginID)
+			}
+			continue
+		}
+		pa.updateRecord(port, allocation.PluginID, StatusReleased, "reclaimed after restart")
+		result.Reclaimed++
+	}
+
+	return result, nil
+}
+
+// ReclaimOrphans 回收已不再被占用的孤儿端口，返回回收数量
+func (pa *PortAllocator) ReclaimOrphans() int {
+	pa.mutex.Lock()
+	defer pa.mutex.Unlock()
+
+	reclaimed := 0
+	for port, record := range pa.records {
+		if record.Status != string(StatusOrphaned) || !pa.allocated[port] {
+			continue
+		}
+		if !pa.IsPortAvailableUnlocked(port) {
+			continue
+		}
+		delete(pa.allocated, port)
+		pa.updateRecord(port, record.PluginID, StatusReleased, "orphaned port reclaimed")
+		reclaimed++
+		if pa.logger != nil {
+			pa.logger.InfoTag("port_allocator", "回收孤儿端口 %d（插件 %s）", port, record.PluginID)
+		}
+	}
+	return reclaimed
+}
+
+// GetHistory 查询端口分配历史，配置了持久化存储时从存储读取
+func (pa *PortAllocator) GetHistory(ctx context.Context, filter PortEventFilter) ([]PortEvent, error) {
+	pa.mutex.RLock()
+	store := pa.store
+	pa.mutex.RUnlock()
+
+	if filter.Limit <= 0 {
+		filter.Limit = 100
+	}
+	if store != nil {
+		return store.ListEvents(ctx, filter)
+	}
+
+	pa.mutex.RLock()
+	defer pa.mutex.RUnlock()
+	events := make([]PortEvent, 0, filter.Limit)
+	for i := len(pa.history) - 1; i >= 0 && len(events) < filter.Limit; i-- {
+		event := pa.history[i]
+		if filter.PluginID != "" && event.PluginID != filter.PluginID {
+			continue
+		}
+		if filter.Port > 0 && event.Port != filter.Port {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
 }
 
 // CleanupExpiredRecords 清理过期的记录
@@ -10,6 +10,7 @@ import (
 // PortManager 端口管理器，提供高级端口管理功能
 type PortManager struct {
 	allocator *PortAllocator
+	sockets   *socketAllocator // 启用Unix域套接字后非空
 	logger    *logging.Logger
 }
 
@@ -108,6 +109,10 @@ func (pm *PortManager) StartCleanupTask(ctx context.Context, interval time.Durat
 			}
 			return
 		case <-ticker.C:
+			pm.allocator.ReclaimOrphans()
+			if pm.sockets != nil {
+				pm.sockets.cleanupStale()
+			}
 			pm.allocator.CleanupExpiredRecords(24 * time.Hour) // 清理24小时前的记录
 		}
 	}
@@ -141,7 +146,82 @@ func (pm *PortManager) FindAvailablePort(pluginID string) (int, error) {
 
 // GetStats 获取端口统计信息
 func (pm *PortManager) GetStats() PortStats {
-	return pm.allocator.GetStats()
+	stats := pm.allocator.GetStats()
+	if pm.sockets != nil {
+		stats.UnixSockets = pm.sockets.count()
+	}
+	return stats
+}
+
+// EnableUnixSockets 启用Unix域套接字，插件gRPC服务可监听 dir 下的套接字文件而非TCP端口；
+// 启用时清理目录中上次运行遗留的套接字文件
+func (pm *PortManager) EnableUnixSockets(dir string) error {
+	sockets, err := newSocketAllocator(dir)
+	if err != nil {
+		return err
+	}
+	removed, err := sockets.cleanupStale()
+	if err != nil {
+		return err
+	}
+	if removed > 0 && pm.logger != nil {
+		pm.logger.InfoTag("port_manager", "已清理 %d 个残留的插件套接字文件", removed)
+	}
+	pm.sockets = sockets
+	return nil
+}
+
+// UnixSocketsEnabled 是否启用了Unix域套接字
+func (pm *PortManager) UnixSocketsEnabled() bool {
+	return pm.sockets != nil
+}
+
+// AllocateSocket 为插件分配套接字路径
+func (pm *PortManager) AllocateSocket(pluginID string) (string, error) {
+	if pm.sockets == nil {
+		return "", ErrSocketsDisabled
+	}
+	return pm.sockets.allocate(pluginID)
+}
+
+// ReleaseSocket 释放插件套接字并删除文件
+func (pm *PortManager) ReleaseSocket(pluginID string) {
+	if pm.sockets != nil {
+		pm.sockets.release(pluginID)
+	}
+}
+
+// GetPluginSocket 获取插件已分配的套接字路径
+func (pm *PortManager) GetPluginSocket(pluginID string) (string, bool) {
+	if pm.sockets == nil {
+		return "", false
+	}
+	return pm.sockets.lookup(pluginID)
+}
+
+// SetStore 设置端口分配持久化存储
+func (pm *PortManager) SetStore(store Store) {
+	pm.allocator.SetStore(store)
+}
+
+// Restore 恢复上次运行遗留的端口分配，回收已空闲的端口并隔离仍被占用的端口
+func (pm *PortManager) Restore(ctx context.Context) (RestoreResult, error) {
+	return pm.allocator.Restore(ctx)
+}
+
+// ReclaimOrphans 回收已空闲的孤儿端口
+func (pm *PortManager) ReclaimOrphans() int {
+	return pm.allocator.ReclaimOrphans()
+}
+
+// GetAllocations 获取端口分配记录
+func (pm *PortManager) GetAllocations() []PortAllocation {
+	return pm.allocator.GetAllocations()
+}
+
+// GetHistory 查询端口分配历史
+func (pm *PortManager) GetHistory(ctx context.Context, filter PortEventFilter) ([]PortEvent, error) {
+	return pm.allocator.GetHistory(ctx, filter)
 }
 
 // PortError 端口相关错误
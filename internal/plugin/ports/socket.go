@@ -0,0 +1,171 @@
+package ports
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// UnixScheme Unix域套接字地址前缀，与gRPC的目标地址格式一致
+const UnixScheme = "unix://"
+
+// maxSocketPathLen Unix域套接字路径长度上限（Linux sun_path 为108字节，含结尾的\0）
+const maxSocketPathLen = 107
+
+// 套接字相关错误
+var (
+	ErrSocketInUse = &PortError{
+		Code:    "SOCKET_IN_USE",
+		Message: "unix socket is in use by another process",
+	}
+	ErrSocketPathTooLong = &PortError{
+		Code:    "SOCKET_PATH_TOO_LONG",
+		Message: "unix socket path exceeds the system limit",
+	}
+	ErrSocketsDisabled = &PortError{
+		Code:    "SOCKETS_DISABLED",
+		Message: "unix socket transport is not enabled",
+	}
+)
+
+// UnixAddress 将套接字路径转换为gRPC地址
+func UnixAddress(path string) string {
+	return UnixScheme + path
+}
+
+// IsUnixAddress 判断是否为Unix域套接字地址
+func IsUnixAddress(address string) bool {
+	return strings.HasPrefix(address, UnixScheme)
+}
+
+// SocketPath 从gRPC地址中取出套接字路径
+func SocketPath(address string) string {
+	return strings.TrimPrefix(address, UnixScheme)
+}
+
+// socketAllocator Unix域套接字路径分配器
+type socketAllocator struct {
+	dir     string
+	sockets map[string]string // plugin_id -> socket path
+	mutex   sync.Mutex
+}
+
+func newSocketAllocator(dir string) (*socketAllocator, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolve socket dir %s: %w", dir, err)
+	}
+	if err := os.MkdirAll(absDir, 0o700); err != nil {
+		return nil, fmt.Errorf("create socket dir %s: %w", absDir, err)
+	}
+	return &socketAllocator{
+		dir:     absDir,
+		sockets: make(map[string]string),
+	}, nil
+}
+
+// allocate 为插件生成套接字路径，清理无人监听的残留文件
+func (sa *socketAllocator) allocate(pluginID string) (string, error) {
+	sa.mutex.Lock()
+	defer sa.mutex.Unlock()
+
+	if path, ok := sa.sockets[pluginID]; ok {
+		return path, nil
+	}
+
+	path := filepath.Join(sa.dir, sanitizeSocketName(pluginID)+".sock")
+	if len(path) > maxSocketPathLen {
+		return "", ErrSocketPathTooLong
+	}
+	if _, err := os.Stat(path); err == nil {
+		if socketAlive(path) {
+			return "", ErrSocketInUse
+		}
+		if err := os.Remove(path); err != nil {
+			return "", fmt.Errorf("remove stale socket %s: %w", path, err)
+		}
+	}
+
+	sa.sockets[pluginID] = path
+	return path, nil
+}
+
+// release 释放插件套接字并删除文件
+func (sa *socketAllocator) release(pluginID string) {
+	sa.mutex.Lock()
+	defer sa.mutex.Unlock()
+
+	path, ok := sa.sockets[pluginID]
+	if !ok {
+		return
+	}
+	delete(sa.sockets, pluginID)
+	_ = os.Remove(path)
+}
+
+// lookup 获取插件已分配的套接字路径
+func (sa *socketAllocator) lookup(pluginID string) (string, bool) {
+	sa.mutex.Lock()
+	defer sa.mutex.Unlock()
+	path, ok := sa.sockets[pluginID]
+	return path, ok
+}
+
+// count 已分配的套接字数量
+func (sa *socketAllocator) count() int {
+	sa.mutex.Lock()
+	defer sa.mutex.Unlock()
+	return len(sa.sockets)
+}
+
+// cleanupStale 删除目录中无人监听且未分配的套接字文件，返回删除数量
+func (sa *socketAllocator) cleanupStale() (int, error) {
+	sa.mutex.Lock()
+	defer sa.mutex.Unlock()
+
+	matches, err := filepath.Glob(filepath.Join(sa.dir, "*.sock"))
+	if err != nil {
+		return 0, err
+	}
+
+	assigned := make(map[string]bool, len(sa.sockets))
+	for _, path := range sa.sockets {
+		assigned[path] = true
+	}
+
+	removed := 0
+	for _, path := range matches {
+		if assigned[path] || socketAlive(path) {
+			continue
+		}
+		if err := os.Remove(path); err == nil {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// socketAlive 探测套接字是否有进程在监听
+func socketAlive(path string) bool {
+	conn, err := net.DialTimeout("unix", path, probeTimeout)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// sanitizeSocketName 将插件ID转换为安全的文件名
+func sanitizeSocketName(pluginID string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, pluginID)
+}
@@ -0,0 +1,16 @@
+package ports
+
+import "context"
+
+// Store 端口分配持久化接口
+// 分配状态按端口保存一条记录，状态变化同时追加到历史，供重启后恢复和排查端口冲突
+type Store interface {
+	// LoadActive 加载仍处于占用状态（已分配、已预留、孤儿）的端口记录
+	LoadActive(ctx context.Context) ([]PortAllocation, error)
+	// Save 保存端口的当前分配状态
+	Save(ctx context.Context, allocation PortAllocation) error
+	// AppendEvent 追加分配历史事件
+	AppendEvent(ctx context.Context, event PortEvent) error
+	// ListEvents 按时间倒序查询分配历史
+	ListEvents(ctx context.Context, filter PortEventFilter) ([]PortEvent, error)
+}
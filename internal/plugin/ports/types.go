@@ -16,7 +16,7 @@ type PortAllocation struct {
 	PluginID  string    `json:"plugin_id"`
 	Address   string    `json:"address"`
 	Timestamp time.Time `json:"timestamp"`
-	Status    string    `json:"status"` // "allocated", "released", "reserved"
+	Status    string    `json:"status"` // "allocated", "released", "reserved", "orphaned"
 }
 
 // PortAllocationStatus 端口分配状态
@@ -27,14 +27,39 @@ const (
 	StatusReleased  PortAllocationStatus = "released"
 	StatusReserved  PortAllocationStatus = "reserved"
 	StatusError     PortAllocationStatus = "error"
+	StatusOrphaned  PortAllocationStatus = "orphaned" // 重启前分配、重启后仍被占用的端口
 )
 
+// PortEvent 端口分配历史事件
+type PortEvent struct {
+	Port      int       `json:"port"`
+	PluginID  string    `json:"plugin_id"`
+	Status    string    `json:"status"`
+	Reason    string    `json:"reason,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// PortEventFilter 分配历史查询条件
+type PortEventFilter struct {
+	PluginID string
+	Port     int
+	Limit    int
+}
+
+// RestoreResult 从持久化记录恢复的结果
+type RestoreResult struct {
+	Reclaimed int `json:"reclaimed"` // 端口已空闲，回收
+	Orphaned  int `json:"orphaned"`  // 端口仍被占用，暂不分配，等待空闲后回收
+}
+
 // PortStats 端口统计信息
 type PortStats struct {
 	TotalPorts     int `json:"total_ports"`
 	AllocatedPorts int `json:"allocated_ports"`
 	AvailablePorts int `json:"available_ports"`
 	ReservedPorts  int `json:"reserved_ports"`
+	OrphanedPorts  int `json:"orphaned_ports"`
+	UnixSockets    int `json:"unix_sockets"`
 	UsagePercent   float64 `json:"usage_percent"`
 }
 
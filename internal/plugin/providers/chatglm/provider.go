@@ -7,8 +7,10 @@ import (
 
 	"github.com/sashabaranov/go-openai"
 	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/platform/httpclient"
 	"xiaozhi-server-go/internal/platform/logging"
 	"xiaozhi-server-go/internal/plugin/grpc/server"
+	"xiaozhi-server-go/internal/plugin/sdk"
 )
 
 type Provider struct {
@@ -46,13 +48,15 @@ func (p *Provider) GetCapabilities() []capability.Definition {
 					"base_url":  {Type: "string", Default: "https://open.bigmodel.cn/api/paas/v4/", Description: "API Base URL"},
 					"model":     {Type: "string", Default: "glm-4", Description: "Model Name"},
 					"max_tokens": {Type: "number", Default: 2048},
+					"network": sdk.NetworkProperty,
 				},
 				Required: []string{"api_key"},
 			},
 			InputSchema: capability.Schema{
 				Type: "object",
 				Properties: map[string]capability.Property{
-					"messages": {Type: "array"},
+					"messages":        {Type: "array"},
+					"response_format": sdk.ResponseFormatProperty,
 				},
 			},
 			OutputSchema: capability.Schema{
@@ -78,6 +82,7 @@ func (p *Provider) GetCapabilities() []capability.Definition {
 					"base_url":  {Type: "string", Default: "https://open.bigmodel.cn/api/paas/v4/", Description: "API Base URL"},
 					"model":     {Type: "string", Default: "glm-4v", Description: "Model Name"},
 					"max_tokens": {Type: "number", Default: 2048},
+					"network": sdk.NetworkProperty,
 				},
 				Required: []string{"api_key"},
 			},
@@ -123,9 +128,14 @@ func (e *ChatExecutor) ExecuteStream(ctx context.Context, config map[string]inte
 	if model == "" {
 		model = "glm-4"
 	}
+	format, err := capability.ParseResponseFormat(inputs[capability.ResponseFormatKey])
+	if err != nil {
+		return nil, sdk.InvalidArgument(capability.ResponseFormatKey, "%v", err)
+	}
 
 	clientConfig := openai.DefaultConfig(apiKey)
 	clientConfig.BaseURL = baseURL
+	clientConfig.HTTPClient = httpclient.Default().ClientFor("chatglm", config)
 	client := openai.NewClientWithConfig(clientConfig)
 
 	// Parse messages
@@ -187,6 +197,10 @@ func (e *ChatExecutor) ExecuteStream(ctx context.Context, config map[string]inte
 		Messages: messages,
 		Stream:   true,
 	}
+	// GLM only supports json_object; the schema itself is conveyed by the prompt (see capability.RunStructured)
+	if format.JSON() {
+		req.ResponseFormat = &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject}
+	}
 
 	stream, err := client.CreateChatCompletionStream(ctx, req)
 	if err != nil {
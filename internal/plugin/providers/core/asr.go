@@ -3,6 +3,8 @@ package core
 import (
 	"context"
 	"fmt"
+
+	"xiaozhi-server-go/internal/domain/objectstore"
 	providers "xiaozhi-server-go/internal/domain/providers/types"
 )
 
@@ -11,9 +13,9 @@ type ASRExecutor struct {
 }
 
 func (e *ASRExecutor) Execute(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (map[string]interface{}, error) {
-	audioData, ok := inputs["audio_data"].([]byte)
-	if !ok {
-		return nil, fmt.Errorf("invalid input: audio_data must be []byte")
+	audioData, err := audioInput(ctx, inputs)
+	if err != nil {
+		return nil, err
 	}
 
 	text, err := e.provider.Transcribe(ctx, audioData)
@@ -25,3 +27,24 @@ func (e *ASRExecutor) Execute(ctx context.Context, config map[string]interface{}
 		"text": text,
 	}, nil
 }
+
+// audioInput reads the audio either inline from audio_data or, for uploads kept in
+// the object store, by ID from audio_object so large clips never travel through JSON.
+func audioInput(ctx context.Context, inputs map[string]interface{}) ([]byte, error) {
+	if audioData, ok := inputs["audio_data"].([]byte); ok {
+		return audioData, nil
+	}
+	id, ok := inputs["audio_object"].(string)
+	if !ok || id == "" {
+		return nil, fmt.Errorf("invalid input: audio_data must be []byte or audio_object must be an object ID")
+	}
+	store := objectstore.Default()
+	if store == nil {
+		return nil, fmt.Errorf("invalid input: audio_object requires the object store to be enabled")
+	}
+	audioData, err := store.Read(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audio object %s: %w", id, err)
+	}
+	return audioData, nil
+}
@@ -30,9 +30,9 @@ func (p *CoreProvider) GetCapabilities() []capability.Definition {
 			InputSchema: capability.Schema{
 				Type: "object",
 				Properties: map[string]capability.Property{
-					"audio_data": {Type: "string", Description: "Audio data bytes"},
+					"audio_data":   {Type: "string", Description: "Audio data bytes"},
+					"audio_object": {Type: "string", Description: "Object store ID of an uploaded clip, used when audio_data is absent"},
 				},
-				Required: []string{"audio_data"},
 			},
 			OutputSchema: capability.Schema{
 				Type: "object",
@@ -67,6 +67,12 @@ func (p *CoreProvider) GetCapabilities() []capability.Definition {
 			Type: capability.TypeTTS,
 			Name: "Core TTS",
 			Description: "Standard TTS capability",
+			ConfigSchema: capability.Schema{
+				Type: "object",
+				Properties: map[string]capability.Property{
+					"inline_audio": {Type: "boolean", Description: "Return audio_data bytes even when the object store is enabled"},
+				},
+			},
 			InputSchema: capability.Schema{
 				Type: "object",
 				Properties: map[string]capability.Property{
@@ -77,8 +83,11 @@ func (p *CoreProvider) GetCapabilities() []capability.Definition {
 			OutputSchema: capability.Schema{
 				Type: "object",
 				Properties: map[string]capability.Property{
-					"audio_file": {Type: "string"},
-					"audio_data": {Type: "string"},
+					"audio_file":   {Type: "string"},
+					"audio_data":   {Type: "string", Description: "Only set when the object store is disabled or inline_audio is true"},
+					"audio_object": {Type: "string", Description: "Object store ID of the synthesized audio"},
+					"audio_url":    {Type: "string", Description: "Signed download URL of the synthesized audio"},
+					"audio_size":   {Type: "integer"},
 				},
 			},
 		},
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+
+	"xiaozhi-server-go/internal/domain/objectstore"
 	providers "xiaozhi-server-go/internal/domain/providers/types"
 )
 
@@ -28,8 +31,29 @@ func (e *TTSExecutor) Execute(ctx context.Context, config map[string]interface{}
 		return nil, fmt.Errorf("failed to read TTS output file: %w", err)
 	}
 
+	// With the object store enabled the audio is returned as an object ID plus a signed
+	// download URL instead of raw bytes, which would otherwise be base64 encoded into
+	// execution results. Set inline_audio to keep the bytes for in-process consumers.
+	store := objectstore.Default()
+	if inline, _ := config["inline_audio"].(bool); store == nil || inline {
+		return map[string]interface{}{
+			"audio_file": filePath,
+			"audio_data": audioData,
+		}, nil
+	}
+
+	obj, err := store.PutBytes(ctx, objectstore.NamespaceTTS, audioData, objectstore.PutOptions{Name: filepath.Base(filePath)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store TTS output: %w", err)
+	}
+	url, _, err := store.URL(obj, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign TTS output URL: %w", err)
+	}
 	return map[string]interface{}{
-		"audio_file": filePath,
-		"audio_data": audioData,
+		"audio_file":   filePath,
+		"audio_object": obj.ID,
+		"audio_url":    url,
+		"audio_size":   obj.Size,
 	}, nil
 }
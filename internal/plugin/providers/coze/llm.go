@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"sync"
 
 	"github.com/coze-dev/coze-go"
+
+	"xiaozhi-server-go/internal/platform/httpclient"
 )
 
 type LLMConfig struct {
@@ -18,6 +21,7 @@ type LLMConfig struct {
 	ClientID    string
 	PublicKey   string
 	PrivateKey  string
+	HTTPClient  *http.Client // nil uses the shared coze client
 }
 
 type LLMProvider struct {
@@ -40,6 +44,10 @@ func NewLLMProvider(config *LLMConfig) (*LLMProvider, error) {
 	if baseURL == "" {
 		baseURL = "https://api.coze.cn"
 	}
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = httpclient.Default().Client("coze")
+	}
 
 	var authCli coze.Auth
 	if config.ClientID != "" && config.PublicKey != "" && config.PrivateKey != "" {
@@ -48,7 +56,7 @@ func NewLLMProvider(config *LLMConfig) (*LLMProvider, error) {
 			ClientID:      config.ClientID,
 			PublicKey:     config.PublicKey,
 			PrivateKeyPEM: config.PrivateKey,
-		}, coze.WithAuthBaseURL(baseURL))
+		}, coze.WithAuthBaseURL(baseURL), coze.WithAuthHttpClient(httpClient))
 		if err != nil {
 			return nil, fmt.Errorf("Coze create JWT auth client failed: %v", err)
 		}
@@ -58,7 +66,7 @@ func NewLLMProvider(config *LLMConfig) (*LLMProvider, error) {
 		// Token Auth
 		authCli = coze.NewTokenAuth(config.AccessToken)
 	}
-	p.client = coze.NewCozeAPI(authCli, coze.WithBaseURL(baseURL))
+	p.client = coze.NewCozeAPI(authCli, coze.WithBaseURL(baseURL), coze.WithHttpClient(httpClient))
 
 	return p, nil
 }
@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/platform/httpclient"
 	"xiaozhi-server-go/internal/platform/logging"
 	"xiaozhi-server-go/internal/plugin/grpc/server"
+	"xiaozhi-server-go/internal/plugin/sdk"
 )
 
 type Provider struct {
@@ -49,6 +51,7 @@ func (p *Provider) GetCapabilities() []capability.Definition {
 					"client_id":             {Type: "string", Description: "Client ID (for JWT Auth)"},
 					"public_key":            {Type: "string", Description: "Public Key (for JWT Auth)"},
 					"private_key":           {Type: "string", Secret: true, Description: "Private Key (for JWT Auth)"},
+					"network": sdk.NetworkProperty,
 				},
 				Required: []string{"base_url", "bot_id", "user_id"},
 			},
@@ -89,9 +92,10 @@ func (e *ChatExecutor) ExecuteStream(ctx context.Context, config map[string]inte
 	userID, _ := config["user_id"].(string)
 	
 	llmConfig := &LLMConfig{
-		BaseURL: baseURL,
-		BotID:   botID,
-		UserID:  userID,
+		BaseURL:    baseURL,
+		BotID:      botID,
+		UserID:     userID,
+		HTTPClient: httpclient.Default().ClientFor("coze", config),
 	}
 
 	if pat, ok := config["personal_access_token"].(string); ok {
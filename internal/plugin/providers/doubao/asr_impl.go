@@ -92,6 +92,10 @@ type ASRProvider struct {
 	preConnMutex   sync.RWMutex    // 保护预连接状态
 	preConnCtx     context.Context // 预连接上下文
 	preConnCancel  context.CancelFunc // 预连接取消函数
+
+	// 语言提示
+	language      string     // 请求的 language 字段，为空时由模型自动识别
+	languageMutex sync.Mutex // 保护语言提示
 }
 
 // NewASRProvider 创建豆包ASR提供者实例
@@ -152,6 +156,7 @@ func NewASRProvider(config *ASRConfig, deleteFile bool, logger *logging.Logger,
 		enablePunc:    true,
 		enableITN:     true,
 		enableDDC:     false,
+		language:      "zh-CN",
 
 		// 初始化异步字段
 		initDone: make(chan struct{}),
@@ -217,18 +222,21 @@ func (p *ASRProvider) generateHeader(
 
 // constructRequest 构造请求数据
 func (p *ASRProvider) constructRequest() map[string]interface{} {
+	audio := map[string]interface{}{
+		"format": "pcm",
+		//"codec":    "opus", // 默认raw音频格式
+		"rate":    16000,
+		"bits":    16,
+		"channel": 1,
+	}
+	if language := p.requestLanguage(); language != "" {
+		audio["language"] = language
+	}
 	return map[string]interface{}{
 		"user": map[string]interface{}{
 			"uid": p.reqID,
 		},
-		"audio": map[string]interface{}{
-			"format": "pcm",
-			//"codec":    "opus", // 默认raw音频格式
-			"rate":     16000,
-			"bits":     16,
-			"channel":  1,
-			"language": "zh-CN", // Added language as per doc example
-		},
+		"audio": audio,
 		"request": map[string]interface{}{
 			"model_name":      p.modelName,
 			"end_window_size": p.endWindowSize,
@@ -241,6 +249,41 @@ func (p *ASRProvider) constructRequest() map[string]interface{} {
 	}
 }
 
+// bigmodelLanguages 语言代码到大模型识别 language 字段的映射
+var bigmodelLanguages = map[string]string{
+	"zh": "zh-CN",
+	"en": "en-US",
+	"ja": "ja-JP",
+	"ko": "ko-KR",
+	"fr": "fr-FR",
+	"de": "de-DE",
+	"es": "es-MX",
+	"ru": "ru-RU",
+}
+
+// SetUserPreferences 读取连接的语言提示：languages 为设备允许的语言，language 为最近一句的语言
+// 只允许一种语言时指定该语言；允许多种语言时不指定，由模型自动识别中英混说，避免锁定在上一句的语言
+func (p *ASRProvider) SetUserPreferences(preferences map[string]interface{}) error {
+	language := "zh-CN"
+	if languages, ok := preferences["languages"].([]string); ok {
+		language = ""
+		if len(languages) == 1 {
+			language = bigmodelLanguages[languages[0]]
+		}
+	}
+	p.languageMutex.Lock()
+	p.language = language
+	p.languageMutex.Unlock()
+	return p.BaseASR.SetUserPreferences(preferences)
+}
+
+// requestLanguage 下一次请求使用的 language 字段，未设置语言提示时为 zh-CN
+func (p *ASRProvider) requestLanguage() string {
+	p.languageMutex.Lock()
+	defer p.languageMutex.Unlock()
+	return p.language
+}
+
 // GetAudioBuffer 获取基类的audioBuffer
 func (p *ASRProvider) GetAudioBuffer() *bytes.Buffer {
 	return p.BaseASR.GetAudioBuffer()
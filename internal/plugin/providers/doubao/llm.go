@@ -10,6 +10,8 @@ import (
 	"net/http"
 
 	"github.com/sashabaranov/go-openai"
+
+	"xiaozhi-server-go/internal/platform/httpclient"
 )
 
 type LLMConfig struct {
@@ -18,6 +20,7 @@ type LLMConfig struct {
 	Model        string
 	MaxTokens    int
 	ThinkingType string
+	HTTPClient   *http.Client // nil uses the shared doubao client
 }
 
 type LLMProvider struct {
@@ -32,9 +35,13 @@ func NewLLMProvider(config *LLMConfig) *LLMProvider {
 	if config.BaseURL == "" {
 		config.BaseURL = "https://ark.cn-beijing.volces.com/api/v3"
 	}
+	client := config.HTTPClient
+	if client == nil {
+		client = httpclient.Default().Client("doubao")
+	}
 	return &LLMProvider{
 		config: config,
-		client: &http.Client{},
+		client: client,
 	}
 }
 
@@ -6,8 +6,10 @@ import (
 	"sync"
 
 	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/platform/httpclient"
 	"xiaozhi-server-go/internal/platform/logging"
 	"xiaozhi-server-go/internal/plugin/grpc/server"
+	"xiaozhi-server-go/internal/plugin/sdk"
 )
 
 type Provider struct {
@@ -45,6 +47,7 @@ func (p *Provider) GetCapabilities() []capability.Definition {
 					"base_url":  {Type: "string", Description: "API Base URL"},
 					"model":     {Type: "string", Default: "doubao-pro-4k", Description: "Model ID (endpoint ID)"},
 					"max_tokens": {Type: "number", Default: 2048},
+					"network": sdk.NetworkProperty,
 				},
 				Required: []string{"api_key", "model"},
 			},
@@ -152,10 +155,11 @@ func (e *LLMExecutor) ExecuteStream(ctx context.Context, config map[string]inter
 	}
 
 	llmConfig := &LLMConfig{
-		APIKey:    apiKey,
-		BaseURL:   baseURL,
-		Model:     model,
-		MaxTokens: maxTokens,
+		APIKey:     apiKey,
+		BaseURL:    baseURL,
+		Model:      model,
+		MaxTokens:  maxTokens,
+		HTTPClient: httpclient.Default().ClientFor("doubao", config),
 	}
 
 	provider := NewLLMProvider(llmConfig)
@@ -0,0 +1,30 @@
+package gosherpa
+
+import (
+	"fmt"
+
+	"xiaozhi-server-go/internal/domain/providers/tts"
+)
+
+func init() {
+	tts.Register("gosherpa", NewCoreTTSProvider)
+}
+
+// CoreTTSProvider adapts the go-sherpa websocket synthesizer to the core TTS provider
+// interface so it can be selected through the TTS manager, e.g. as the offline fallback.
+type CoreTTSProvider struct {
+	*tts.BaseProvider
+}
+
+func NewCoreTTSProvider(config *tts.Config, deleteFile bool) (tts.Provider, error) {
+	if config.Cluster == "" {
+		return nil, fmt.Errorf("gosherpa tts requires a cluster address")
+	}
+	return &CoreTTSProvider{BaseProvider: tts.NewBaseProvider(config, deleteFile)}, nil
+}
+
+// ToTTS synthesizes text through the go-sherpa server and returns the audio file path.
+func (p *CoreTTSProvider) ToTTS(text string) (string, error) {
+	cfg := p.Config()
+	return synthesizeSpeech(&TTSConfig{Cluster: cfg.Cluster, OutputDir: cfg.OutputDir}, text)
+}
@@ -2,14 +2,17 @@ package ollama
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/sashabaranov/go-openai"
 	pluginpb "xiaozhi-server-go/gen/go/api/proto"
 	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/platform/httpclient"
 	"xiaozhi-server-go/internal/platform/logging"
 	"xiaozhi-server-go/internal/plugin/grpc/server"
+	"xiaozhi-server-go/internal/plugin/sdk"
 )
 
 type Provider struct {
@@ -47,13 +50,15 @@ func (p *Provider) GetCapabilities() []capability.Definition {
 					"base_url":  {Type: "string", Default: "http://localhost:11434/v1", Description: "API Base URL"},
 					"model":     {Type: "string", Default: "llama3", Description: "Model Name"},
 					"api_key":   {Type: "string", Default: "ollama", Description: "API Key (ignored by Ollama but required by client)"},
+					"network": sdk.NetworkProperty,
 				},
 				Required: []string{"base_url", "model"},
 			},
 			InputSchema: capability.Schema{
 				Type: "object",
 				Properties: map[string]capability.Property{
-					"messages": {Type: "array"},
+					"messages":        {Type: "array"},
+					"response_format": sdk.ResponseFormatProperty,
 				},
 			},
 			OutputSchema: capability.Schema{
@@ -74,14 +79,16 @@ func (p *Provider) GetCapabilities() []capability.Definition {
 					"base_url":  {Type: "string", Default: "http://localhost:11434/v1", Description: "API Base URL"},
 					"model":     {Type: "string", Default: "llava", Description: "Model Name"},
 					"api_key":   {Type: "string", Default: "ollama", Description: "API Key"},
+					"network": sdk.NetworkProperty,
 				},
 				Required: []string{"base_url", "model"},
 			},
 			InputSchema: capability.Schema{
 				Type: "object",
 				Properties: map[string]capability.Property{
-					"messages": {Type: "array"},
-					"images":   {Type: "array"},
+					"messages":        {Type: "array"},
+					"images":          {Type: "array"},
+					"response_format": sdk.ResponseFormatProperty,
 				},
 			},
 			OutputSchema: capability.Schema{
@@ -118,9 +125,14 @@ func (e *ChatExecutor) ExecuteStream(ctx context.Context, config map[string]inte
 	}
 
 	isQwen3 := model != "" && strings.HasPrefix(strings.ToLower(model), "qwen3")
+	format, err := capability.ParseResponseFormat(inputs[capability.ResponseFormatKey])
+	if err != nil {
+		return nil, sdk.InvalidArgument(capability.ResponseFormatKey, "%v", err)
+	}
 
 	clientConfig := openai.DefaultConfig(apiKey)
 	clientConfig.BaseURL = baseURL
+	clientConfig.HTTPClient = httpclient.Default().ClientFor("ollama", config)
 	client := openai.NewClientWithConfig(clientConfig)
 
 	// Parse messages
@@ -175,9 +187,10 @@ func (e *ChatExecutor) ExecuteStream(ctx context.Context, config map[string]inte
 	}
 
 	req := openai.ChatCompletionRequest{
-		Model:    model,
-		Messages: messages,
-		Stream:   true,
+		Model:          model,
+		Messages:       messages,
+		Stream:         true,
+		ResponseFormat: responseFormat(format),
 	}
 
 	stream, err := client.CreateChatCompletionStream(ctx, req)
@@ -226,6 +239,27 @@ func (e *ChatExecutor) ExecuteStream(ctx context.Context, config map[string]inte
 	return outCh, nil
 }
 
+// responseFormat maps the structured output request for Ollama's OpenAI-compatible endpoint,
+// which forwards it as the native "format" parameter: json_object becomes "json" and json_schema
+// becomes the schema itself, both enforced with grammar-constrained sampling (Ollama 0.5+)
+func responseFormat(format *capability.ResponseFormat) *openai.ChatCompletionResponseFormat {
+	if !format.JSON() {
+		return nil
+	}
+	if format.Type == capability.ResponseFormatJSONObject {
+		return &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject}
+	}
+	schema, _ := json.Marshal(format.Schema)
+	return &openai.ChatCompletionResponseFormat{
+		Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
+		JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
+			Name:   format.Name,
+			Schema: json.RawMessage(schema),
+			Strict: true,
+		},
+	}
+}
+
 // addNoThinkDirective 为qwen3模型在用户最后一条消息中添加/no_think指令
 func addNoThinkDirective(messages []openai.ChatCompletionMessage) []openai.ChatCompletionMessage {
 	// 复制消息列表
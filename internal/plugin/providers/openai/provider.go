@@ -2,13 +2,16 @@ package openai
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/sashabaranov/go-openai"
 	pluginpb "xiaozhi-server-go/gen/go/api/proto"
 	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/platform/httpclient"
 	"xiaozhi-server-go/internal/platform/logging"
 	"xiaozhi-server-go/internal/plugin/grpc/server"
+	"xiaozhi-server-go/internal/plugin/sdk"
 )
 
 type Provider struct {
@@ -47,13 +50,15 @@ func (p *Provider) GetCapabilities() []capability.Definition {
 					"base_url":  {Type: "string", Description: "API Base URL (optional)"},
 					"model":     {Type: "string", Default: "gpt-3.5-turbo", Description: "Model Name"},
 					"max_tokens": {Type: "number", Default: 2048},
+					"network": sdk.NetworkProperty,
 				},
 				Required: []string{"api_key", "model"},
 			},
 			InputSchema: capability.Schema{
 				Type: "object",
 				Properties: map[string]capability.Property{
-					"messages": {Type: "array"},
+					"messages":        {Type: "array"},
+					"response_format": sdk.ResponseFormatProperty,
 				},
 			},
 			OutputSchema: capability.Schema{
@@ -75,14 +80,16 @@ func (p *Provider) GetCapabilities() []capability.Definition {
 					"base_url":   {Type: "string", Description: "API Base URL (optional)"},
 					"model":      {Type: "string", Default: "gpt-4-vision-preview", Description: "Model Name"},
 					"max_tokens": {Type: "number", Default: 2048},
+					"network": sdk.NetworkProperty,
 				},
 				Required: []string{"api_key", "model"},
 			},
 			InputSchema: capability.Schema{
 				Type: "object",
 				Properties: map[string]capability.Property{
-					"messages": {Type: "array"},
-					"images":   {Type: "array"},
+					"messages":        {Type: "array"},
+					"images":          {Type: "array"},
+					"response_format": sdk.ResponseFormatProperty,
 				},
 			},
 			OutputSchema: capability.Schema{
@@ -92,6 +99,36 @@ func (p *Provider) GetCapabilities() []capability.Definition {
 				},
 			},
 		},
+		{
+			ID:          "openai_embedding",
+			Type:        capability.TypeEmbedding,
+			Name:        "OpenAI Embedding",
+			Description: "OpenAI compatible text embedding",
+			ConfigSchema: capability.Schema{
+				Type: "object",
+				Properties: map[string]capability.Property{
+					"api_key":    {Type: "string", Secret: true, Description: "API Key"},
+					"base_url":   {Type: "string", Description: "API Base URL (optional)"},
+					"model":      {Type: "string", Default: "text-embedding-3-small", Description: "Model Name"},
+					"dimensions": {Type: "number", Description: "Output dimensions (optional, text-embedding-3 only)"},
+					"network": sdk.NetworkProperty,
+				},
+				Required: []string{"api_key"},
+			},
+			InputSchema: capability.Schema{
+				Type: "object",
+				Properties: map[string]capability.Property{
+					"texts": {Type: "array", Items: &capability.Schema{Type: "string"}},
+				},
+				Required: []string{"texts"},
+			},
+			OutputSchema: capability.Schema{
+				Type: "object",
+				Properties: map[string]capability.Property{
+					"vectors": {Type: "array", Description: "One vector per input text, in input order"},
+				},
+			},
+		},
 	}
 }
 
@@ -99,6 +136,8 @@ func (p *Provider) CreateExecutor(capabilityID string) (capability.Executor, err
 	switch capabilityID {
 	case "openai_llm", "openai_vllm":
 		return &ChatExecutor{}, nil
+	case "openai_embedding":
+		return &EmbeddingExecutor{}, nil
 	default:
 		return nil, fmt.Errorf("unknown capability: %s", capabilityID)
 	}
@@ -111,26 +150,27 @@ func (e *ChatExecutor) Execute(ctx context.Context, config map[string]interface{
 }
 
 func (e *ChatExecutor) ExecuteStream(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (<-chan map[string]interface{}, error) {
-	apiKey, _ := config["api_key"].(string)
-	baseURL, _ := config["base_url"].(string)
-	model, _ := config["model"].(string)
-	maxTokens := 2048
-	if mt, ok := config["max_tokens"].(float64); ok {
-		maxTokens = int(mt)
-	} else if mt, ok := config["max_tokens"].(int); ok {
-		maxTokens = mt
+	cfg := sdk.Args(config)
+	apiKey := cfg.String("api_key", "")
+	baseURL := cfg.String("base_url", "")
+	model := cfg.String("model", "gpt-3.5-turbo")
+	maxTokens := cfg.Int("max_tokens", 2048)
+	format, err := capability.ParseResponseFormat(inputs[capability.ResponseFormatKey])
+	if err != nil {
+		return nil, sdk.InvalidArgument(capability.ResponseFormatKey, "%v", err)
 	}
 
 	clientConfig := openai.DefaultConfig(apiKey)
 	if baseURL != "" {
 		clientConfig.BaseURL = baseURL
 	}
+	clientConfig.HTTPClient = httpclient.Default().ClientFor("openai", config)
 	client := openai.NewClientWithConfig(clientConfig)
 
 	// Parse messages
 	msgsRaw, ok := inputs["messages"].([]interface{})
 	if !ok {
-		return nil, fmt.Errorf("messages input is required")
+		return nil, sdk.MissingArgument("messages")
 	}
 
 	var messages []openai.ChatCompletionMessage
@@ -175,10 +215,11 @@ func (e *ChatExecutor) ExecuteStream(ctx context.Context, config map[string]inte
 	}
 
 	req := openai.ChatCompletionRequest{
-		Model:     model,
-		Messages:  messages,
-		Stream:    true,
-		MaxTokens: maxTokens,
+		Model:          model,
+		Messages:       messages,
+		Stream:         true,
+		MaxTokens:      maxTokens,
+		ResponseFormat: responseFormat(format),
 	}
 
 	stream, err := client.CreateChatCompletionStream(ctx, req)
@@ -217,3 +258,68 @@ func (e *ChatExecutor) ExecuteStream(ctx context.Context, config map[string]inte
 
 	return outCh, nil
 }
+
+// responseFormat maps the structured output request onto OpenAI response_format; json_schema is sent as-is
+func responseFormat(format *capability.ResponseFormat) *openai.ChatCompletionResponseFormat {
+	if !format.JSON() {
+		return nil
+	}
+	if format.Type == capability.ResponseFormatJSONObject {
+		return &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject}
+	}
+	schema, _ := json.Marshal(format.Schema)
+	return &openai.ChatCompletionResponseFormat{
+		Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
+		JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
+			Name:   format.Name,
+			Schema: json.RawMessage(schema),
+			Strict: format.Strict,
+		},
+	}
+}
+
+type EmbeddingExecutor struct{}
+
+// Execute embeds inputs["texts"]; vectors are returned as []interface{} of float64 so they survive the gRPC struct conversion
+func (e *EmbeddingExecutor) Execute(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (map[string]interface{}, error) {
+	cfg := sdk.Args(config)
+	texts, ok, err := sdk.Args(inputs).LookupStrings("texts")
+	if err != nil {
+		return nil, err
+	}
+	if !ok || len(texts) == 0 {
+		return nil, sdk.MissingArgument("texts")
+	}
+
+	clientConfig := openai.DefaultConfig(cfg.String("api_key", ""))
+	if baseURL := cfg.String("base_url", ""); baseURL != "" {
+		clientConfig.BaseURL = baseURL
+	}
+	clientConfig.HTTPClient = httpclient.Default().ClientFor("openai", config)
+	client := openai.NewClientWithConfig(clientConfig)
+
+	resp, err := client.CreateEmbeddings(ctx, openai.EmbeddingRequestStrings{
+		Input:      texts,
+		Model:      openai.EmbeddingModel(cfg.String("model", "text-embedding-3-small")),
+		Dimensions: cfg.Int("dimensions", 0),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) != len(texts) {
+		return nil, fmt.Errorf("embedding count mismatch: expected %d, got %d", len(texts), len(resp.Data))
+	}
+
+	vectors := make([]interface{}, len(texts))
+	for _, item := range resp.Data {
+		if item.Index < 0 || item.Index >= len(vectors) {
+			return nil, fmt.Errorf("embedding index out of range: %d", item.Index)
+		}
+		vector := make([]interface{}, len(item.Embedding))
+		for i, v := range item.Embedding {
+			vector[i] = float64(v)
+		}
+		vectors[item.Index] = vector
+	}
+	return map[string]interface{}{"vectors": vectors}, nil
+}
@@ -0,0 +1,232 @@
+package skills
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"xiaozhi-server-go/internal/platform/httpclient"
+	"xiaozhi-server-go/internal/plugin/sdk"
+)
+
+const caldavTimeFormat = "20060102T150405Z"
+
+// CalendarExecutor queries a CalDAV calendar collection with a calendar-query REPORT.
+// The server is asked to expand recurring events, so every returned VEVENT is a single occurrence.
+type CalendarExecutor struct {
+	pool *httpclient.Pool // clients honour the network settings in the capability config
+}
+
+type multistatus struct {
+	Responses []struct {
+		Propstats []struct {
+			CalendarData string `xml:"prop>calendar-data"`
+		} `xml:"propstat"`
+	} `xml:"response"`
+}
+
+// Event is a single calendar occurrence
+type Event struct {
+	Summary  string
+	Location string
+	Start    time.Time
+	End      time.Time
+	AllDay   bool
+}
+
+func (e *CalendarExecutor) Execute(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (map[string]interface{}, error) {
+	cfg := sdk.Args(config)
+	args := sdk.Args(inputs)
+	calendarURL, err := cfg.RequireString("url")
+	if err != nil {
+		return nil, err
+	}
+	start, err := requireTime(args, "start")
+	if err != nil {
+		return nil, err
+	}
+	end, err := requireTime(args, "end")
+	if err != nil {
+		return nil, err
+	}
+	if !end.After(start) {
+		return nil, sdk.InvalidArgument("end", "must be after start")
+	}
+
+	timeRange := fmt.Sprintf(`start="%s" end="%s"`, start.UTC().Format(caldavTimeFormat), end.UTC().Format(caldavTimeFormat))
+	query := `<?xml version="1.0" encoding="utf-8"?>
+<C:calendar-query xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">
+  <D:prop>
+    <C:calendar-data><C:expand ` + timeRange + `/></C:calendar-data>
+  </D:prop>
+  <C:filter>
+    <C:comp-filter name="VCALENDAR">
+      <C:comp-filter name="VEVENT"><C:time-range ` + timeRange + `/></C:comp-filter>
+    </C:comp-filter>
+  </C:filter>
+</C:calendar-query>`
+
+	req, err := http.NewRequestWithContext(ctx, "REPORT", calendarURL, strings.NewReader(query))
+	if err != nil {
+		return nil, sdk.InvalidArgument("url", "%v", err)
+	}
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	req.Header.Set("Depth", "1")
+	if username := cfg.String("username", ""); username != "" {
+		req.SetBasicAuth(username, cfg.String("password", ""))
+	}
+	body, err := do(e.pool.ClientFor("skills", cfg), req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ms multistatus
+	if err := xml.NewDecoder(bytes.NewReader(body)).Decode(&ms); err != nil {
+		return nil, sdk.Internal("failed to parse CalDAV response", err)
+	}
+	var events []Event
+	for _, r := range ms.Responses {
+		for _, ps := range r.Propstats {
+			if ps.CalendarData == "" {
+				continue
+			}
+			for _, ev := range ParseEvents(ps.CalendarData, time.Local) {
+				// servers that ignore <expand> return whole series; keep only occurrences in range
+				if ev.End.After(start) && ev.Start.Before(end) {
+					events = append(events, ev)
+				}
+			}
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Start.Before(events[j].Start) })
+
+	items := make([]interface{}, 0, len(events))
+	for _, ev := range events {
+		items = append(items, map[string]interface{}{
+			"summary":  ev.Summary,
+			"location": ev.Location,
+			"start":    ev.Start.Format(time.RFC3339),
+			"end":      ev.End.Format(time.RFC3339),
+			"all_day":  ev.AllDay,
+		})
+	}
+	return map[string]interface{}{"events": items}, nil
+}
+
+func requireTime(args sdk.Args, key string) (time.Time, error) {
+	raw, err := args.RequireString(key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, sdk.InvalidArgument(key, "expected RFC3339 time: %v", err)
+	}
+	return t, nil
+}
+
+// ParseEvents extracts the VEVENTs of an iCalendar document.
+// Floating times and TZIDs unknown to the system are interpreted in loc.
+func ParseEvents(ics string, loc *time.Location) []Event {
+	var events []Event
+	var cur *Event
+	for _, line := range unfold(ics) {
+		name, params, value := splitProperty(line)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			cur = &Event{}
+		case name == "END" && value == "VEVENT":
+			if cur != nil && !cur.Start.IsZero() {
+				if cur.End.IsZero() {
+					cur.End = cur.Start
+					if cur.AllDay {
+						cur.End = cur.Start.AddDate(0, 0, 1)
+					}
+				}
+				events = append(events, *cur)
+			}
+			cur = nil
+		case cur == nil:
+		case name == "SUMMARY":
+			cur.Summary = unescapeText(value)
+		case name == "LOCATION":
+			cur.Location = unescapeText(value)
+		case name == "DTSTART":
+			cur.Start, cur.AllDay = parseICSTime(value, params, loc)
+		case name == "DTEND":
+			cur.End, _ = parseICSTime(value, params, loc)
+		}
+	}
+	return events
+}
+
+// unfold joins continuation lines (RFC 5545 section 3.1)
+func unfold(ics string) []string {
+	ics = strings.ReplaceAll(ics, "\r\n", "\n")
+	var lines []string
+	for _, line := range strings.Split(ics, "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// splitProperty splits "NAME;PARAM=x:value" into its parts
+func splitProperty(line string) (string, map[string]string, string) {
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return "", nil, ""
+	}
+	head, value := line[:colon], strings.TrimSpace(line[colon+1:])
+	parts := strings.Split(head, ";")
+	params := make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		if k, v, ok := strings.Cut(p, "="); ok {
+			params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+	return strings.ToUpper(parts[0]), params, value
+}
+
+func parseICSTime(value string, params map[string]string, loc *time.Location) (time.Time, bool) {
+	if params["VALUE"] == "DATE" || len(value) == 8 {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return t, true
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(caldavTimeFormat, value)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return t.In(loc), false
+	}
+	if tzid := params["TZID"]; tzid != "" {
+		if tz, err := time.LoadLocation(tzid); err == nil {
+			t, err := time.ParseInLocation("20060102T150405", value, tz)
+			if err != nil {
+				return time.Time{}, false
+			}
+			return t.In(loc), false
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, false
+}
+
+func unescapeText(s string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
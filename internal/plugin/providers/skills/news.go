@@ -0,0 +1,172 @@
+package skills
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"html"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
+
+	"xiaozhi-server-go/internal/platform/httpclient"
+	"xiaozhi-server-go/internal/plugin/sdk"
+)
+
+const maxNewsItems = 20
+
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// NewsExecutor reads headlines from an RSS or Atom feed
+type NewsExecutor struct {
+	pool *httpclient.Pool // clients honour the network settings in the capability config
+}
+
+// feed covers RSS 2.0 (<rss><channel><item>), RSS 1.0 (<rdf:RDF><item>) and Atom (<feed><entry>) documents
+type feed struct {
+	XMLName xml.Name
+	Channel struct {
+		Title string     `xml:"title"`
+		Items []feedItem `xml:"item"`
+	} `xml:"channel"`
+	Items   []feedItem  `xml:"item"` // RSS 1.0 (RDF) keeps items outside the channel
+	Title   string      `xml:"title"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type feedItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	PubDate     string `xml:"pubDate"`
+	Date        string `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Description string `xml:"description"`
+}
+
+type atomEntry struct {
+	Title string `xml:"title"`
+	Links []struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+	} `xml:"link"`
+	Updated   string `xml:"updated"`
+	Published string `xml:"published"`
+	Summary   string `xml:"summary"`
+	Content   string `xml:"content"`
+}
+
+func (e *NewsExecutor) Execute(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (map[string]interface{}, error) {
+	args := sdk.Args(inputs)
+	feedURL, err := args.RequireString("url")
+	if err != nil {
+		return nil, err
+	}
+	count := args.Int("count", 5)
+	if count <= 0 || count > maxNewsItems {
+		count = maxNewsItems
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, sdk.InvalidArgument("url", "%v", err)
+	}
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8")
+	body, err := do(e.pool.ClientFor("skills", config), req)
+	if err != nil {
+		return nil, err
+	}
+
+	var f feed
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.CharsetReader = charset.NewReaderLabel // many Chinese feeds are GBK encoded
+	decoder.Strict = false
+	if err := decoder.Decode(&f); err != nil {
+		return nil, sdk.Internal("failed to parse feed", err)
+	}
+
+	source := f.Channel.Title
+	items := make([]interface{}, 0, count)
+	add := func(title, link, published, summary string) {
+		title = cleanText(title)
+		if title == "" || len(items) >= count {
+			return
+		}
+		items = append(items, map[string]interface{}{
+			"title":     title,
+			"link":      strings.TrimSpace(link),
+			"published": normalizeTime(published),
+			"summary":   cleanText(summary),
+		})
+	}
+	switch f.XMLName.Local {
+	case "rss", "RDF":
+		for _, it := range append(f.Channel.Items, f.Items...) {
+			published := it.PubDate
+			if published == "" {
+				published = it.Date
+			}
+			add(it.Title, it.Link, published, it.Description)
+		}
+	case "feed":
+		source = f.Title
+		for _, en := range f.Entries {
+			published := en.Published
+			if published == "" {
+				published = en.Updated
+			}
+			summary := en.Summary
+			if summary == "" {
+				summary = en.Content
+			}
+			add(en.Title, atomLink(en), published, summary)
+		}
+	default:
+		return nil, sdk.InvalidArgument("url", "not an RSS or Atom feed: <%s>", f.XMLName.Local)
+	}
+
+	return map[string]interface{}{
+		"source": cleanText(source),
+		"items":  items,
+	}, nil
+}
+
+func atomLink(en atomEntry) string {
+	for _, l := range en.Links {
+		if l.Rel == "" || l.Rel == "alternate" {
+			return l.Href
+		}
+	}
+	if len(en.Links) > 0 {
+		return en.Links[0].Href
+	}
+	return ""
+}
+
+// cleanText strips markup and entities that feeds commonly embed in titles and descriptions
+func cleanText(s string) string {
+	s = tagPattern.ReplaceAllString(s, " ")
+	s = html.UnescapeString(s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+var feedTimeLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2006-01-02 15:04:05",
+}
+
+// normalizeTime converts feed dates to RFC3339, leaving unknown formats as they are
+func normalizeTime(s string) string {
+	s = strings.TrimSpace(s)
+	for _, layout := range feedTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format(time.RFC3339)
+		}
+	}
+	return s
+}
@@ -0,0 +1,188 @@
+package skills
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"xiaozhi-server-go/internal/platform/httpclient"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/plugin/sdk"
+)
+
+// Capability IDs of the built-in assistant skills
+const (
+	WeatherCapabilityID  = "weather_openmeteo"
+	NewsCapabilityID     = "news_rss"
+	CalendarCapabilityID = "calendar_caldav"
+)
+
+// maxResponseSize bounds how much of an upstream response is read
+const maxResponseSize = 4 << 20
+
+// Provider exposes weather (Open-Meteo), news (RSS/Atom) and calendar (CalDAV) lookups
+type Provider struct {
+	logger *logging.Logger
+}
+
+func NewProvider() *Provider {
+	return NewProviderWithLogger(nil)
+}
+
+func NewProviderWithLogger(logger *logging.Logger) *Provider {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Provider{
+		logger: logger,
+	}
+}
+
+func (p *Provider) GetCapabilities() []capability.Definition {
+	return []capability.Definition{
+		{
+			ID:          WeatherCapabilityID,
+			Type:        capability.TypeTool,
+			Name:        "Open-Meteo Weather",
+			Description: "Current conditions and daily forecast from Open-Meteo, no API key required",
+			ConfigSchema: capability.Schema{
+				Type: "object",
+				Properties: map[string]capability.Property{
+					"base_url":      {Type: "string", Default: openMeteoForecastURL, Description: "Forecast API URL"},
+					"geocoding_url": {Type: "string", Default: openMeteoGeocodingURL, Description: "Geocoding API URL"},
+					"language":      {Type: "string", Default: "zh", Description: "Language used to resolve place names"},
+					"network":       sdk.NetworkProperty,
+				},
+			},
+			InputSchema: capability.Schema{
+				Type: "object",
+				Properties: map[string]capability.Property{
+					"location":  {Type: "string", Description: "Place name, used when latitude/longitude are not given"},
+					"latitude":  {Type: "number"},
+					"longitude": {Type: "number"},
+					"days":      {Type: "integer", Default: 3},
+				},
+			},
+			OutputSchema: capability.Schema{
+				Type: "object",
+				Properties: map[string]capability.Property{
+					"location": {Type: "string"},
+					"current":  {Type: "object", Description: "temperature, apparent_temperature, humidity, weather_code, wind_speed"},
+					"daily":    {Type: "array", Description: "Items with date, weather_code, temperature_max, temperature_min, precipitation_probability"},
+				},
+			},
+		},
+		{
+			ID:          NewsCapabilityID,
+			Type:        capability.TypeTool,
+			Name:        "RSS News",
+			Description: "Latest headlines from an RSS or Atom feed",
+			ConfigSchema: capability.Schema{
+				Type: "object",
+				Properties: map[string]capability.Property{
+					"network": sdk.NetworkProperty,
+				},
+			},
+			InputSchema: capability.Schema{
+				Type: "object",
+				Properties: map[string]capability.Property{
+					"url":   {Type: "string"},
+					"count": {Type: "integer", Default: 5},
+				},
+				Required: []string{"url"},
+			},
+			OutputSchema: capability.Schema{
+				Type: "object",
+				Properties: map[string]capability.Property{
+					"source": {Type: "string", Description: "Feed title"},
+					"items":  {Type: "array", Description: "Items with title, link, published, summary"},
+				},
+			},
+		},
+		{
+			ID:          CalendarCapabilityID,
+			Type:        capability.TypeTool,
+			Name:        "CalDAV Calendar",
+			Description: "Events of a CalDAV calendar within a time range, recurring events expanded",
+			ConfigSchema: capability.Schema{
+				Type: "object",
+				Properties: map[string]capability.Property{
+					"url":      {Type: "string", Description: "Calendar collection URL"},
+					"username": {Type: "string"},
+					"password": {Type: "string", Secret: true},
+					"network":  sdk.NetworkProperty,
+				},
+				Required: []string{"url"},
+			},
+			InputSchema: capability.Schema{
+				Type: "object",
+				Properties: map[string]capability.Property{
+					"start": {Type: "string", Description: "RFC3339"},
+					"end":   {Type: "string", Description: "RFC3339"},
+				},
+				Required: []string{"start", "end"},
+			},
+			OutputSchema: capability.Schema{
+				Type: "object",
+				Properties: map[string]capability.Property{
+					"events": {Type: "array", Description: "Items with summary, start, end, location, all_day"},
+				},
+			},
+		},
+	}
+}
+
+func (p *Provider) CreateExecutor(capabilityID string) (capability.Executor, error) {
+	pool := httpclient.Default()
+	switch capabilityID {
+	case WeatherCapabilityID:
+		return &WeatherExecutor{pool: pool}, nil
+	case NewsCapabilityID:
+		return &NewsExecutor{pool: pool}, nil
+	case CalendarCapabilityID:
+		return &CalendarExecutor{pool: pool}, nil
+	default:
+		return nil, fmt.Errorf("unknown capability: %s", capabilityID)
+	}
+}
+
+// do sends req and returns the body of a 2xx response
+func do(client *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, sdk.Unavailable("request failed", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, sdk.Unavailable("failed to read response", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg := strings.TrimSpace(string(body))
+		if len(msg) > 200 {
+			msg = msg[:200]
+		}
+		return nil, sdk.Unavailable(fmt.Sprintf("upstream returned %d", resp.StatusCode), fmt.Errorf("%s", msg))
+	}
+	return body, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, target string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return sdk.InvalidArgument("base_url", "%v", err)
+	}
+	body, err := do(client, req)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return sdk.Internal("failed to decode response", err)
+	}
+	return nil
+}
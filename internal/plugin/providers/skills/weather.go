@@ -0,0 +1,142 @@
+package skills
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"xiaozhi-server-go/internal/platform/httpclient"
+	"xiaozhi-server-go/internal/plugin/sdk"
+)
+
+const (
+	openMeteoForecastURL  = "https://api.open-meteo.com/v1/forecast"
+	openMeteoGeocodingURL = "https://geocoding-api.open-meteo.com/v1/search"
+	maxForecastDays       = 7
+)
+
+// WeatherExecutor looks up Open-Meteo; place names are resolved with its geocoding API
+type WeatherExecutor struct {
+	pool *httpclient.Pool // clients honour the network settings in the capability config
+}
+
+func (e *WeatherExecutor) Execute(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (map[string]interface{}, error) {
+	cfg := sdk.Args(config)
+	args := sdk.Args(inputs)
+
+	days := args.Int("days", 3)
+	if days <= 0 || days > maxForecastDays {
+		days = maxForecastDays
+	}
+
+	name := args.String("location", "")
+	lat, hasLat, err := args.LookupFloat("latitude")
+	if err != nil {
+		return nil, err
+	}
+	lon, hasLon, err := args.LookupFloat("longitude")
+	if err != nil {
+		return nil, err
+	}
+	if !hasLat || !hasLon {
+		if name == "" {
+			return nil, sdk.MissingArgument("location")
+		}
+		place, err := e.geocode(ctx, cfg, name)
+		if err != nil {
+			return nil, err
+		}
+		name, lat, lon = place.Name, place.Latitude, place.Longitude
+	}
+
+	params := url.Values{}
+	params.Set("latitude", strconv.FormatFloat(lat, 'f', 4, 64))
+	params.Set("longitude", strconv.FormatFloat(lon, 'f', 4, 64))
+	params.Set("current", "temperature_2m,apparent_temperature,relative_humidity_2m,weather_code,wind_speed_10m")
+	params.Set("daily", "weather_code,temperature_2m_max,temperature_2m_min,precipitation_probability_max")
+	params.Set("timezone", "auto")
+	params.Set("forecast_days", strconv.Itoa(days))
+
+	var resp struct {
+		Current struct {
+			Temperature         float64 `json:"temperature_2m"`
+			ApparentTemperature float64 `json:"apparent_temperature"`
+			Humidity            float64 `json:"relative_humidity_2m"`
+			WeatherCode         int     `json:"weather_code"`
+			WindSpeed           float64 `json:"wind_speed_10m"`
+		} `json:"current"`
+		Daily struct {
+			Time                     []string   `json:"time"`
+			WeatherCode              []int      `json:"weather_code"`
+			TemperatureMax           []float64  `json:"temperature_2m_max"`
+			TemperatureMin           []float64  `json:"temperature_2m_min"`
+			PrecipitationProbability []*float64 `json:"precipitation_probability_max"`
+		} `json:"daily"`
+	}
+	if err := getJSON(ctx, e.pool.ClientFor("skills", cfg), cfg.String("base_url", openMeteoForecastURL)+"?"+params.Encode(), &resp); err != nil {
+		return nil, err
+	}
+
+	d := resp.Daily
+	daily := make([]interface{}, 0, len(d.Time))
+	for i, date := range d.Time {
+		if i >= len(d.WeatherCode) || i >= len(d.TemperatureMax) || i >= len(d.TemperatureMin) {
+			break
+		}
+		day := map[string]interface{}{
+			"date":            date,
+			"weather_code":    d.WeatherCode[i],
+			"temperature_max": d.TemperatureMax[i],
+			"temperature_min": d.TemperatureMin[i],
+		}
+		// Open-Meteo returns null probabilities for models without precipitation forecasts
+		if i < len(d.PrecipitationProbability) && d.PrecipitationProbability[i] != nil {
+			day["precipitation_probability"] = *d.PrecipitationProbability[i]
+		}
+		daily = append(daily, day)
+	}
+
+	return map[string]interface{}{
+		"location":  name,
+		"latitude":  lat,
+		"longitude": lon,
+		"current": map[string]interface{}{
+			"temperature":          resp.Current.Temperature,
+			"apparent_temperature": resp.Current.ApparentTemperature,
+			"humidity":             resp.Current.Humidity,
+			"weather_code":         resp.Current.WeatherCode,
+			"wind_speed":           resp.Current.WindSpeed,
+		},
+		"daily": daily,
+	}, nil
+}
+
+type place struct {
+	Name      string
+	Latitude  float64
+	Longitude float64
+}
+
+func (e *WeatherExecutor) geocode(ctx context.Context, cfg sdk.Args, name string) (*place, error) {
+	params := url.Values{}
+	params.Set("name", name)
+	params.Set("count", "1")
+	params.Set("language", cfg.String("language", "zh"))
+	params.Set("format", "json")
+
+	var resp struct {
+		Results []struct {
+			Name      string  `json:"name"`
+			Latitude  float64 `json:"latitude"`
+			Longitude float64 `json:"longitude"`
+		} `json:"results"`
+	}
+	if err := getJSON(ctx, e.pool.ClientFor("skills", cfg), cfg.String("geocoding_url", openMeteoGeocodingURL)+"?"+params.Encode(), &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Results) == 0 {
+		return nil, sdk.InvalidArgument("location", "place %q not found", name)
+	}
+	r := resp.Results[0]
+	return &place{Name: r.Name, Latitude: r.Latitude, Longitude: r.Longitude}, nil
+}
@@ -0,0 +1,349 @@
+package smarthome
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"xiaozhi-server-go/internal/plugin/sdk"
+)
+
+const matterDefaultURL = "ws://localhost:5580/ws"
+
+// Matter cluster IDs mapped onto the uniform schema
+const (
+	clusterBasicInformation = 40
+	clusterOnOff            = 6
+	clusterLevelControl     = 8
+	clusterDoorLock         = 257
+	clusterWindowCovering   = 258
+	clusterThermostat       = 513
+	clusterFanControl       = 514
+	clusterTemperature      = 1026
+	clusterHumidity         = 1029
+)
+
+func newMatterBridge(cfg sdk.Args) *matterBridge {
+	return &matterBridge{url: cfg.String("url", matterDefaultURL)}
+}
+
+// matterBridge talks to python-matter-server over its WebSocket API; each call opens its own
+// connection since the server pushes the full node list on request
+type matterBridge struct {
+	url string
+}
+
+type matterNode struct {
+	NodeID     int64                  `json:"node_id"`
+	Available  bool                   `json:"available"`
+	Attributes map[string]interface{} `json:"attributes"` // "endpoint/cluster/attribute" -> value
+}
+
+// attr returns an attribute of an endpoint
+func (n matterNode) attr(endpoint, cluster, attribute int) (interface{}, bool) {
+	v, ok := n.Attributes[fmt.Sprintf("%d/%d/%d", endpoint, cluster, attribute)]
+	return v, ok && v != nil
+}
+
+func (n matterNode) number(endpoint, cluster, attribute int) (float64, bool) {
+	v, ok := n.attr(endpoint, cluster, attribute)
+	if !ok {
+		return 0, false
+	}
+	f, ok := v.(float64)
+	return f, ok
+}
+
+// endpoints lists the endpoints of a node with the clusters they host
+func (n matterNode) endpoints() map[int]map[int]bool {
+	out := make(map[int]map[int]bool)
+	for path := range n.Attributes {
+		parts := strings.SplitN(path, "/", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		endpoint, err1 := strconv.Atoi(parts[0])
+		cluster, err2 := strconv.Atoi(parts[1])
+		if err1 != nil || err2 != nil || endpoint == 0 {
+			continue
+		}
+		if out[endpoint] == nil {
+			out[endpoint] = make(map[int]bool)
+		}
+		out[endpoint][cluster] = true
+	}
+	return out
+}
+
+func (b *matterBridge) discover(ctx context.Context) ([]Entity, error) {
+	nodes, err := b.nodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var entities []Entity
+	for _, node := range nodes {
+		entities = append(entities, matterEntities(node)...)
+	}
+	return entities, nil
+}
+
+func (b *matterBridge) nodes(ctx context.Context) ([]matterNode, error) {
+	var nodes []matterNode
+	if err := b.command(ctx, "get_nodes", map[string]interface{}{}, &nodes); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// matterEntities maps every endpoint hosting a known primary cluster to an entity with ID
+// "<node>-<endpoint>"
+func matterEntities(node matterNode) []Entity {
+	name := ""
+	for _, attribute := range []int{5, 3} { // NodeLabel, ProductName
+		if v, ok := node.attr(0, clusterBasicInformation, attribute); ok {
+			if s, _ := v.(string); s != "" {
+				name = s
+				break
+			}
+		}
+	}
+	if name == "" {
+		name = fmt.Sprintf("Matter %d", node.NodeID)
+	}
+
+	endpoints := node.endpoints()
+	ids := make([]int, 0, len(endpoints))
+	for endpoint := range endpoints {
+		ids = append(ids, endpoint)
+	}
+	sort.Ints(ids)
+
+	var entities []Entity
+	for _, endpoint := range ids {
+		entity, ok := matterEntity(node, endpoint, endpoints[endpoint])
+		if !ok {
+			continue
+		}
+		entity.Name = name
+		entities = append(entities, entity)
+	}
+	if len(entities) > 1 {
+		for i := range entities {
+			entities[i].Name = fmt.Sprintf("%s %d", name, i+1)
+		}
+	}
+	return entities
+}
+
+func matterEntity(node matterNode, endpoint int, clusters map[int]bool) (Entity, bool) {
+	entity := Entity{
+		ID:        fmt.Sprintf("%d-%d", node.NodeID, endpoint),
+		State:     make(map[string]interface{}),
+		Available: node.Available,
+	}
+	switch {
+	case clusters[clusterDoorLock]:
+		entity.Type = TypeLock
+		entity.Features = []string{FeatureLock}
+		if v, ok := node.number(endpoint, clusterDoorLock, 0); ok {
+			entity.State["locked"] = v == 1
+		}
+	case clusters[clusterWindowCovering]:
+		entity.Type = TypeCover
+		entity.Features = []string{FeatureOpenClose, FeaturePosition}
+		// Matter counts lift in 100ths of a percent closed
+		if v, ok := node.number(endpoint, clusterWindowCovering, 14); ok {
+			entity.State["position"] = math.Round(100 - v/100)
+		}
+	case clusters[clusterThermostat]:
+		entity.Type = TypeClimate
+		entity.Features = []string{FeatureTemperature}
+		if v, ok := node.number(endpoint, clusterThermostat, 18); ok {
+			entity.State["temperature"] = v / 100
+		}
+		if v, ok := node.number(endpoint, clusterThermostat, 0); ok {
+			entity.State["current_temperature"] = v / 100
+		}
+	case clusters[clusterFanControl]:
+		entity.Type = TypeFan
+		entity.Features = []string{FeatureOnOff}
+		if v, ok := node.number(endpoint, clusterFanControl, 0); ok {
+			entity.State["on"] = v != 0
+		}
+	case clusters[clusterOnOff]:
+		entity.Type = TypeSwitch
+		entity.Features = []string{FeatureOnOff}
+		if clusters[clusterLevelControl] {
+			entity.Type = TypeLight
+			entity.Features = append(entity.Features, FeatureBrightness)
+			if v, ok := node.number(endpoint, clusterLevelControl, 0); ok {
+				entity.State["brightness"] = math.Round(v / 254 * 100)
+			}
+		}
+		if v, ok := node.attr(endpoint, clusterOnOff, 0); ok {
+			entity.State["on"] = v == true
+		}
+	case clusters[clusterTemperature] || clusters[clusterHumidity]:
+		entity.Type = TypeSensor
+		if v, ok := node.number(endpoint, clusterTemperature, 0); ok {
+			entity.State["temperature"] = v / 100
+		}
+		if v, ok := node.number(endpoint, clusterHumidity, 0); ok {
+			entity.State["humidity"] = v / 100
+		}
+	default:
+		return Entity{}, false
+	}
+	return entity, true
+}
+
+func (b *matterBridge) control(ctx context.Context, entityID, action string, value float64) (Entity, error) {
+	var nodeID int64
+	var endpoint int
+	if _, err := fmt.Sscanf(entityID, "%d-%d", &nodeID, &endpoint); err != nil {
+		return Entity{}, sdk.InvalidArgument("entity_id", "unknown entity %q", entityID)
+	}
+	nodes, err := b.nodes(ctx)
+	if err != nil {
+		return Entity{}, err
+	}
+	var entities []Entity
+	for _, node := range nodes {
+		if node.NodeID == nodeID {
+			entities = matterEntities(node)
+		}
+	}
+	entity, err := findEntity(entities, entityID)
+	if err != nil {
+		return Entity{}, err
+	}
+	if err := checkAction(entity, action); err != nil {
+		return Entity{}, err
+	}
+
+	invoke := func(cluster int, command string, payload map[string]interface{}) error {
+		args := map[string]interface{}{
+			"node_id":      nodeID,
+			"endpoint_id":  endpoint,
+			"cluster_id":   cluster,
+			"command_name": command,
+			"payload":      payload,
+		}
+		if cluster == clusterDoorLock {
+			args["timed_request_timeout_ms"] = 10000
+		}
+		return b.command(ctx, "device_command", args, nil)
+	}
+	write := func(cluster, attribute int, v interface{}) error {
+		return b.command(ctx, "write_attribute", map[string]interface{}{
+			"node_id":        nodeID,
+			"attribute_path": fmt.Sprintf("%d/%d/%d", endpoint, cluster, attribute),
+			"value":          v,
+		}, nil)
+	}
+
+	on := entity.State["on"] == true
+	switch action {
+	case ActionTurnOn, ActionTurnOff, ActionToggle:
+		target := action == ActionTurnOn || (action == ActionToggle && !on)
+		if entity.Type == TypeFan {
+			mode := 0 // Off
+			if target {
+				mode = 4 // On
+			}
+			err = write(clusterFanControl, 0, mode)
+		} else if target {
+			err = invoke(clusterOnOff, "On", map[string]interface{}{})
+		} else {
+			err = invoke(clusterOnOff, "Off", map[string]interface{}{})
+		}
+		entity.State["on"] = target
+	case ActionSetBrightness:
+		level := math.Round(clampPercent(value) / 100 * 254)
+		err = invoke(clusterLevelControl, "MoveToLevelWithOnOff", map[string]interface{}{
+			"level": level, "transitionTime": 0, "optionsMask": 0, "optionsOverride": 0,
+		})
+		entity.State["brightness"] = math.Round(clampPercent(value))
+		entity.State["on"] = value > 0
+	case ActionOpen:
+		err = invoke(clusterWindowCovering, "UpOrOpen", map[string]interface{}{})
+		entity.State["position"] = 100.0
+	case ActionClose:
+		err = invoke(clusterWindowCovering, "DownOrClose", map[string]interface{}{})
+		entity.State["position"] = 0.0
+	case ActionSetPosition:
+		err = invoke(clusterWindowCovering, "GoToLiftPercentage", map[string]interface{}{
+			"liftPercent100thsValue": math.Round((100 - clampPercent(value)) * 100),
+		})
+		entity.State["position"] = math.Round(value)
+	case ActionSetTemperature:
+		err = write(clusterThermostat, 18, math.Round(value*100))
+		entity.State["temperature"] = value
+	case ActionLock:
+		err = invoke(clusterDoorLock, "LockDoor", map[string]interface{}{})
+		entity.State["locked"] = true
+	case ActionUnlock:
+		err = invoke(clusterDoorLock, "UnlockDoor", map[string]interface{}{})
+		entity.State["locked"] = false
+	}
+	if err != nil {
+		return Entity{}, err
+	}
+	return entity, nil
+}
+
+// command sends one command and waits for the response carrying the same message ID;
+// event messages pushed by the server in between are skipped
+func (b *matterBridge) command(ctx context.Context, command string, args map[string]interface{}, out interface{}) error {
+	dialer := websocket.Dialer{HandshakeTimeout: 10 * time.Second}
+	conn, _, err := dialer.DialContext(ctx, b.url, nil)
+	if err != nil {
+		return sdk.Unavailable("failed to connect to Matter Server", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetReadDeadline(deadline)
+	} else {
+		conn.SetReadDeadline(time.Now().Add(30 * time.Second))
+	}
+
+	messageID := strconv.FormatInt(time.Now().UnixNano(), 36)
+	if err := conn.WriteJSON(map[string]interface{}{
+		"message_id": messageID,
+		"command":    command,
+		"args":       args,
+	}); err != nil {
+		return sdk.Unavailable("failed to send Matter command", err)
+	}
+
+	for {
+		var msg struct {
+			MessageID string          `json:"message_id"`
+			Result    json.RawMessage `json:"result"`
+			ErrorCode *int            `json:"error_code"`
+			Details   string          `json:"details"`
+		}
+		if err := conn.ReadJSON(&msg); err != nil {
+			return sdk.Unavailable("failed to read Matter Server response", err)
+		}
+		if msg.MessageID != messageID {
+			continue // server info and events
+		}
+		if msg.ErrorCode != nil {
+			return sdk.Unavailable(fmt.Sprintf("Matter command %s failed (%d): %s", command, *msg.ErrorCode, msg.Details), nil)
+		}
+		if out != nil && len(msg.Result) > 0 {
+			if err := json.Unmarshal(msg.Result, out); err != nil {
+				return sdk.Internal("failed to decode Matter Server response", err)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,339 @@
+package smarthome
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MQTT 3.1.1 packet types used by the client
+const (
+	mqttConnect    = 1
+	mqttConnack    = 2
+	mqttPublish    = 3
+	mqttPuback     = 4
+	mqttSubscribe  = 8
+	mqttPingreq    = 12
+	mqttDisconnect = 14
+)
+
+const mqttKeepAlive = 30 * time.Second
+
+// mqttClient is a minimal MQTT 3.1.1 client: QoS 0 publish and subscribe, QoS 1 deliveries acked,
+// keepalive pings. It is only meant for talking to a local bridge such as Zigbee2MQTT.
+type mqttClient struct {
+	conn      net.Conn
+	reader    *bufio.Reader
+	onMessage func(topic string, payload []byte)
+
+	writeMu  sync.Mutex
+	packetID uint16
+
+	done    chan struct{}
+	errOnce sync.Once
+	err     error
+}
+
+type mqttOptions struct {
+	Broker   string // tcp://host:1883, ssl://host:8883 or host:port
+	ClientID string
+	Username string
+	Password string
+}
+
+func dialMQTT(ctx context.Context, opts mqttOptions, onMessage func(topic string, payload []byte)) (*mqttClient, error) {
+	network, address, useTLS, err := parseBroker(opts.Broker)
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	var conn net.Conn
+	if useTLS {
+		host, _, _ := net.SplitHostPort(address)
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: host}}).DialContext(ctx, network, address)
+	} else {
+		conn, err = dialer.DialContext(ctx, network, address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("connect to broker %s: %w", opts.Broker, err)
+	}
+
+	c := &mqttClient{
+		conn:      conn,
+		reader:    bufio.NewReader(conn),
+		onMessage: onMessage,
+		done:      make(chan struct{}),
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	} else {
+		conn.SetDeadline(time.Now().Add(10 * time.Second))
+	}
+	if err := c.handshake(opts); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.SetDeadline(time.Time{})
+
+	go c.readLoop()
+	go c.pingLoop()
+	return c, nil
+}
+
+func parseBroker(broker string) (network, address string, useTLS bool, err error) {
+	if broker == "" {
+		broker = "tcp://localhost:1883"
+	}
+	if !strings.Contains(broker, "://") {
+		broker = "tcp://" + broker
+	}
+	u, err := url.Parse(broker)
+	if err != nil {
+		return "", "", false, fmt.Errorf("invalid broker address %q: %w", broker, err)
+	}
+	port := u.Port()
+	switch u.Scheme {
+	case "tcp", "mqtt":
+		if port == "" {
+			port = "1883"
+		}
+	case "ssl", "tls", "mqtts":
+		useTLS = true
+		if port == "" {
+			port = "8883"
+		}
+	default:
+		return "", "", false, fmt.Errorf("unsupported broker scheme %q", u.Scheme)
+	}
+	return "tcp", net.JoinHostPort(u.Hostname(), port), useTLS, nil
+}
+
+func (c *mqttClient) handshake(opts mqttOptions) error {
+	var body []byte
+	body = appendString(body, "MQTT")
+	body = append(body, 4) // protocol level 3.1.1
+	flags := byte(0x02)    // clean session
+	if opts.Username != "" {
+		flags |= 0x80
+		if opts.Password != "" {
+			flags |= 0x40
+		}
+	}
+	body = append(body, flags)
+	body = binary.BigEndian.AppendUint16(body, uint16(mqttKeepAlive/time.Second))
+	body = appendString(body, opts.ClientID)
+	if opts.Username != "" {
+		body = appendString(body, opts.Username)
+		if opts.Password != "" {
+			body = appendString(body, opts.Password)
+		}
+	}
+	if err := c.write(mqttConnect<<4, body); err != nil {
+		return err
+	}
+
+	header, payload, err := c.readPacket()
+	if err != nil {
+		return fmt.Errorf("read CONNACK: %w", err)
+	}
+	if header>>4 != mqttConnack || len(payload) < 2 {
+		return fmt.Errorf("unexpected packet %d while waiting for CONNACK", header>>4)
+	}
+	if code := payload[1]; code != 0 {
+		return fmt.Errorf("broker refused connection: %s", connackReason(code))
+	}
+	return nil
+}
+
+func connackReason(code byte) string {
+	switch code {
+	case 1:
+		return "unacceptable protocol version"
+	case 2:
+		return "client identifier rejected"
+	case 3:
+		return "server unavailable"
+	case 4:
+		return "bad user name or password"
+	case 5:
+		return "not authorized"
+	default:
+		return fmt.Sprintf("return code %d", code)
+	}
+}
+
+// Subscribe subscribes to a topic filter with QoS 0
+func (c *mqttClient) Subscribe(filter string) error {
+	body := binary.BigEndian.AppendUint16(nil, c.nextPacketID())
+	body = appendString(body, filter)
+	body = append(body, 0)
+	return c.write(mqttSubscribe<<4|0x02, body)
+}
+
+// Publish sends a QoS 0 message
+func (c *mqttClient) Publish(topic string, payload []byte) error {
+	body := appendString(nil, topic)
+	body = append(body, payload...)
+	return c.write(mqttPublish<<4, body)
+}
+
+// Done is closed when the connection is lost or closed
+func (c *mqttClient) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns why the connection ended
+func (c *mqttClient) Err() error {
+	select {
+	case <-c.done:
+		return c.err
+	default:
+		return nil
+	}
+}
+
+func (c *mqttClient) Close() error {
+	c.write(mqttDisconnect<<4, nil)
+	c.fail(errors.New("client closed"))
+	return nil
+}
+
+func (c *mqttClient) fail(err error) {
+	c.errOnce.Do(func() {
+		c.err = err
+		close(c.done)
+		c.conn.Close()
+	})
+}
+
+func (c *mqttClient) readLoop() {
+	for {
+		// The broker answers our pings, so a silent connection for 1.5 keepalives is dead
+		c.conn.SetReadDeadline(time.Now().Add(mqttKeepAlive * 3 / 2))
+		header, body, err := c.readPacket()
+		if err != nil {
+			c.fail(err)
+			return
+		}
+		if header>>4 != mqttPublish {
+			continue // SUBACK, PINGRESP
+		}
+
+		qos := (header >> 1) & 0x03
+		if len(body) < 2 {
+			continue
+		}
+		topicLen := int(binary.BigEndian.Uint16(body))
+		if len(body) < 2+topicLen {
+			continue
+		}
+		topic := string(body[2 : 2+topicLen])
+		payload := body[2+topicLen:]
+		if qos > 0 {
+			if len(payload) < 2 {
+				continue
+			}
+			id := payload[:2]
+			payload = payload[2:]
+			if qos == 1 {
+				c.write(mqttPuback<<4, append([]byte(nil), id...))
+			}
+		}
+		if c.onMessage != nil {
+			c.onMessage(topic, payload)
+		}
+	}
+}
+
+func (c *mqttClient) pingLoop() {
+	ticker := time.NewTicker(mqttKeepAlive / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			if err := c.write(mqttPingreq<<4, nil); err != nil {
+				c.fail(err)
+				return
+			}
+		}
+	}
+}
+
+func (c *mqttClient) nextPacketID() uint16 {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.packetID++
+	if c.packetID == 0 {
+		c.packetID = 1
+	}
+	return c.packetID
+}
+
+func (c *mqttClient) write(header byte, body []byte) error {
+	packet := []byte{header}
+	packet = appendRemainingLength(packet, len(body))
+	packet = append(packet, body...)
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	_, err := c.conn.Write(packet)
+	return err
+}
+
+func (c *mqttClient) readPacket() (byte, []byte, error) {
+	header, err := c.reader.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		if i == 4 {
+			return 0, nil, errors.New("malformed remaining length")
+		}
+		b, err := c.reader.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length += int(b&0x7f) * multiplier
+		if b&0x80 == 0 {
+			break
+		}
+		multiplier *= 128
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, body); err != nil {
+		return 0, nil, err
+	}
+	return header, body, nil
+}
+
+func appendRemainingLength(b []byte, n int) []byte {
+	for {
+		digit := byte(n % 128)
+		n /= 128
+		if n > 0 {
+			digit |= 0x80
+		}
+		b = append(b, digit)
+		if n == 0 {
+			return b
+		}
+	}
+}
+
+func appendString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
@@ -0,0 +1,342 @@
+package smarthome
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"xiaozhi-server-go/internal/platform/httpclient"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/plugin/sdk"
+)
+
+// Capability IDs of the built-in smart home bridges
+const (
+	Zigbee2MQTTID = "smarthome_zigbee2mqtt"
+	TuyaID        = "smarthome_tuya"
+	MatterID      = "smarthome_matter"
+)
+
+// Uniform entity types shared by all bridges
+const (
+	TypeLight   = "light"
+	TypeSwitch  = "switch"
+	TypeCover   = "cover"
+	TypeClimate = "climate"
+	TypeLock    = "lock"
+	TypeFan     = "fan"
+	TypeSensor  = "sensor"
+)
+
+// Features advertise which control actions an entity accepts
+const (
+	FeatureOnOff       = "on_off"      // turn_on, turn_off, toggle
+	FeatureBrightness  = "brightness"  // set_brightness, 0-100
+	FeatureOpenClose   = "open_close"  // open, close
+	FeaturePosition    = "position"    // set_position, 0-100 where 100 is fully open
+	FeatureTemperature = "temperature" // set_temperature, degrees Celsius
+	FeatureLock        = "lock"        // lock, unlock
+)
+
+// Control actions accepted by the "control" operation
+const (
+	ActionTurnOn         = "turn_on"
+	ActionTurnOff        = "turn_off"
+	ActionToggle         = "toggle"
+	ActionSetBrightness  = "set_brightness"
+	ActionSetPosition    = "set_position"
+	ActionSetTemperature = "set_temperature"
+	ActionOpen           = "open"
+	ActionClose          = "close"
+	ActionLock           = "lock"
+	ActionUnlock         = "unlock"
+)
+
+// actionFeatures maps each action to the feature an entity needs to accept it
+var actionFeatures = map[string]string{
+	ActionTurnOn:         FeatureOnOff,
+	ActionTurnOff:        FeatureOnOff,
+	ActionToggle:         FeatureOnOff,
+	ActionSetBrightness:  FeatureBrightness,
+	ActionSetPosition:    FeaturePosition,
+	ActionSetTemperature: FeatureTemperature,
+	ActionOpen:           FeatureOpenClose,
+	ActionClose:          FeatureOpenClose,
+	ActionLock:           FeatureLock,
+	ActionUnlock:         FeatureLock,
+}
+
+// Provider exposes Zigbee2MQTT, Tuya Cloud and Matter Server as smart home bridges
+type Provider struct {
+	logger *logging.Logger
+
+	mu     sync.Mutex
+	z2m    map[string]*z2mBridge // persistent MQTT sessions keyed by broker and base topic
+	tokens map[string]*tuyaToken // Tuya access tokens keyed by endpoint and access ID
+}
+
+func NewProvider() *Provider {
+	return NewProviderWithLogger(nil)
+}
+
+func NewProviderWithLogger(logger *logging.Logger) *Provider {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Provider{
+		logger: logger,
+		z2m:    make(map[string]*z2mBridge),
+		tokens: make(map[string]*tuyaToken),
+	}
+}
+
+func (p *Provider) GetCapabilities() []capability.Definition {
+	return []capability.Definition{
+		{
+			ID:          Zigbee2MQTTID,
+			Type:        capability.TypeSmartHome,
+			Name:        "Zigbee2MQTT",
+			Description: "Control Zigbee devices paired with a Zigbee2MQTT bridge over MQTT",
+			ConfigSchema: capability.Schema{
+				Type: "object",
+				Properties: map[string]capability.Property{
+					"broker":     {Type: "string", Default: "tcp://localhost:1883", Description: "MQTT broker address, tcp:// or ssl://"},
+					"username":   {Type: "string", Description: "MQTT username"},
+					"password":   {Type: "string", Secret: true, Description: "MQTT password"},
+					"base_topic": {Type: "string", Default: "zigbee2mqtt", Description: "Zigbee2MQTT base topic"},
+					"client_id":  {Type: "string", Description: "MQTT client ID, generated when empty"},
+				},
+			},
+			InputSchema:  inputSchema(),
+			OutputSchema: outputSchema(),
+		},
+		{
+			ID:          TuyaID,
+			Type:        capability.TypeSmartHome,
+			Name:        "Tuya Cloud",
+			Description: "Control Tuya / Smart Life devices through the Tuya Cloud OpenAPI",
+			ConfigSchema: capability.Schema{
+				Type: "object",
+				Properties: map[string]capability.Property{
+					"endpoint":      {Type: "string", Default: tuyaDefaultEndpoint, Description: "OpenAPI endpoint of the project's data center"},
+					"access_id":     {Type: "string", Description: "Cloud project access ID"},
+					"access_secret": {Type: "string", Secret: true, Description: "Cloud project access secret"},
+					"uid":           {Type: "string", Description: "UID of the linked app account whose devices are exposed"},
+					"network":       sdk.NetworkProperty,
+				},
+				Required: []string{"access_id", "access_secret", "uid"},
+			},
+			InputSchema:  inputSchema(),
+			OutputSchema: outputSchema(),
+		},
+		{
+			ID:          MatterID,
+			Type:        capability.TypeSmartHome,
+			Name:        "Matter Server",
+			Description: "Control Matter devices commissioned to a python-matter-server instance",
+			ConfigSchema: capability.Schema{
+				Type: "object",
+				Properties: map[string]capability.Property{
+					"url": {Type: "string", Default: matterDefaultURL, Description: "Matter Server WebSocket URL"},
+				},
+			},
+			InputSchema:  inputSchema(),
+			OutputSchema: outputSchema(),
+		},
+	}
+}
+
+func inputSchema() capability.Schema {
+	actions := []interface{}{
+		ActionTurnOn, ActionTurnOff, ActionToggle, ActionSetBrightness, ActionSetPosition,
+		ActionSetTemperature, ActionOpen, ActionClose, ActionLock, ActionUnlock,
+	}
+	return capability.Schema{
+		Type: "object",
+		Properties: map[string]capability.Property{
+			"operation":  {Type: "string", Default: "discover", Enum: []interface{}{"discover", "state", "control"}},
+			"entity_ids": {Type: "array", Items: &capability.Schema{Type: "string"}, Description: "Entities to read for the state operation, all when empty"},
+			"entity_id":  {Type: "string", Description: "Entity to control"},
+			"action":     {Type: "string", Enum: actions},
+			"value":      {Type: "number", Description: "Brightness or position in percent, temperature in degrees Celsius"},
+		},
+	}
+}
+
+func outputSchema() capability.Schema {
+	return capability.Schema{
+		Type: "object",
+		Properties: map[string]capability.Property{
+			"entities": {Type: "array", Description: "Entities with id, name, type, room, features, state and available"},
+			"entity":   {Type: "object", Description: "The controlled entity with its expected state"},
+		},
+	}
+}
+
+func (p *Provider) CreateExecutor(capabilityID string) (capability.Executor, error) {
+	switch capabilityID {
+	case Zigbee2MQTTID:
+		return &BridgeExecutor{open: p.zigbee2mqtt}, nil
+	case TuyaID:
+		return &BridgeExecutor{open: func(ctx context.Context, cfg sdk.Args) (bridge, error) {
+			return p.tuya(cfg, httpclient.Default().ClientFor("smarthome", cfg))
+		}}, nil
+	case MatterID:
+		return &BridgeExecutor{open: func(ctx context.Context, cfg sdk.Args) (bridge, error) {
+			return newMatterBridge(cfg), nil
+		}}, nil
+	default:
+		return nil, fmt.Errorf("unknown capability: %s", capabilityID)
+	}
+}
+
+// Entity is a bridge device mapped into the uniform schema
+type Entity struct {
+	ID        string
+	Name      string
+	Type      string
+	Room      string
+	Features  []string
+	State     map[string]interface{} // on, brightness, position, temperature, current_temperature, locked, sensor readings
+	Available bool
+}
+
+func (e Entity) toMap() map[string]interface{} {
+	features := make([]interface{}, 0, len(e.Features))
+	for _, f := range e.Features {
+		features = append(features, f)
+	}
+	state := e.State
+	if state == nil {
+		state = map[string]interface{}{}
+	}
+	return map[string]interface{}{
+		"id":        e.ID,
+		"name":      e.Name,
+		"type":      e.Type,
+		"room":      e.Room,
+		"features":  features,
+		"state":     state,
+		"available": e.Available,
+	}
+}
+
+func hasFeature(features []string, feature string) bool {
+	for _, f := range features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// bridge is implemented by each backend; state reads go through discover since every backend
+// returns device state together with the device list
+type bridge interface {
+	discover(ctx context.Context) ([]Entity, error)
+	control(ctx context.Context, entityID, action string, value float64) (Entity, error)
+}
+
+// BridgeExecutor runs the discover/state/control operations against a bridge
+type BridgeExecutor struct {
+	open func(ctx context.Context, cfg sdk.Args) (bridge, error)
+}
+
+func (e *BridgeExecutor) Execute(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (map[string]interface{}, error) {
+	args := sdk.Args(inputs)
+	b, err := e.open(ctx, sdk.Args(config))
+	if err != nil {
+		return nil, err
+	}
+
+	switch op := args.String("operation", "discover"); op {
+	case "discover", "state":
+		entities, err := b.discover(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if ids := args.Strings("entity_ids", nil); op == "state" && len(ids) > 0 {
+			entities = filterEntities(entities, ids)
+		}
+		items := make([]interface{}, 0, len(entities))
+		for _, entity := range entities {
+			items = append(items, entity.toMap())
+		}
+		return map[string]interface{}{"entities": items}, nil
+	case "control":
+		entityID, err := args.RequireString("entity_id")
+		if err != nil {
+			return nil, err
+		}
+		action, err := args.RequireString("action")
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := actionFeatures[action]; !ok {
+			return nil, sdk.InvalidArgument("action", "unsupported action %q", action)
+		}
+		switch action {
+		case ActionSetBrightness, ActionSetPosition, ActionSetTemperature:
+			if !args.Has("value") {
+				return nil, sdk.MissingArgument("value")
+			}
+		}
+		value := args.Float("value", 0)
+		if (action == ActionSetBrightness || action == ActionSetPosition) && (value < 0 || value > 100) {
+			return nil, sdk.InvalidArgument("value", "must be between 0 and 100")
+		}
+		entity, err := b.control(ctx, entityID, action, value)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"entity": entity.toMap()}, nil
+	default:
+		return nil, sdk.InvalidArgument("operation", "unsupported operation %q", op)
+	}
+}
+
+func filterEntities(entities []Entity, ids []string) []Entity {
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	out := entities[:0]
+	for _, entity := range entities {
+		if wanted[entity.ID] {
+			out = append(out, entity)
+		}
+	}
+	return out
+}
+
+// checkAction rejects actions the entity does not advertise
+func checkAction(entity Entity, action string) error {
+	if !entity.Available {
+		return sdk.Unavailable(fmt.Sprintf("entity %s is offline", entity.ID), nil)
+	}
+	if feature := actionFeatures[action]; !hasFeature(entity.Features, feature) {
+		return sdk.Unsupported("entity %s (%s) does not support %s", entity.ID, entity.Type, action)
+	}
+	return nil
+}
+
+// findEntity looks up an entity by ID among discovered entities
+func findEntity(entities []Entity, id string) (Entity, error) {
+	for _, entity := range entities {
+		if entity.ID == id {
+			return entity, nil
+		}
+	}
+	return Entity{}, sdk.InvalidArgument("entity_id", "unknown entity %q", id)
+}
+
+func clampPercent(v float64) float64 {
+	switch {
+	case v < 0:
+		return 0
+	case v > 100:
+		return 100
+	}
+	return v
+}
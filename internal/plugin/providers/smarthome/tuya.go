@@ -0,0 +1,380 @@
+package smarthome
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"xiaozhi-server-go/internal/plugin/sdk"
+)
+
+const tuyaDefaultEndpoint = "https://openapi.tuyacn.com"
+
+// maxResponseSize bounds how much of a bridge response is read
+const maxResponseSize = 4 << 20
+
+type tuyaToken struct {
+	value   string
+	expires time.Time
+}
+
+// tuya builds a Tuya Cloud bridge; access tokens are cached on the provider across executors
+func (p *Provider) tuya(cfg sdk.Args, client *http.Client) (bridge, error) {
+	b := &tuyaBridge{
+		provider: p,
+		client:   client,
+		endpoint: strings.TrimRight(cfg.String("endpoint", tuyaDefaultEndpoint), "/"),
+		accessID: cfg.String("access_id", ""),
+		secret:   cfg.String("access_secret", ""),
+		uid:      cfg.String("uid", ""),
+	}
+	switch {
+	case b.accessID == "":
+		return nil, sdk.MissingArgument("access_id")
+	case b.secret == "":
+		return nil, sdk.MissingArgument("access_secret")
+	case b.uid == "":
+		return nil, sdk.MissingArgument("uid")
+	}
+	return b, nil
+}
+
+type tuyaBridge struct {
+	provider *Provider
+	client   *http.Client
+	endpoint string
+	accessID string
+	secret   string
+	uid      string
+}
+
+type tuyaDevice struct {
+	ID       string       `json:"id"`
+	Name     string       `json:"name"`
+	Category string       `json:"category"`
+	Online   bool         `json:"online"`
+	Status   []tuyaStatus `json:"status"`
+}
+
+type tuyaStatus struct {
+	Code  string      `json:"code"`
+	Value interface{} `json:"value"`
+}
+
+// tuyaCategories maps Tuya product categories onto entity types; door locks need a
+// per-operation ticket and are not mapped
+var tuyaCategories = map[string]string{
+	"dj":    TypeLight,
+	"dd":    TypeLight,
+	"xdd":   TypeLight,
+	"fwd":   TypeLight,
+	"kg":    TypeSwitch,
+	"cz":    TypeSwitch,
+	"pc":    TypeSwitch,
+	"cl":    TypeCover,
+	"clkg":  TypeCover,
+	"wk":    TypeClimate,
+	"kt":    TypeClimate,
+	"fs":    TypeFan,
+	"wsdcg": TypeSensor,
+	"co2bj": TypeSensor,
+	"pir":   TypeSensor,
+	"mcs":   TypeSensor,
+}
+
+var (
+	tuyaSwitchCodes = []string{"switch_led", "switch", "switch_1"}
+	tuyaSensorCodes = map[string]string{
+		"va_temperature":     "temperature",
+		"va_humidity":        "humidity",
+		"temp_current":       "temperature",
+		"humidity_value":     "humidity",
+		"co2_value":          "co2",
+		"battery_percentage": "battery",
+		"pir":                "occupancy",
+		"doorcontact_state":  "contact",
+	}
+)
+
+func (b *tuyaBridge) discover(ctx context.Context) ([]Entity, error) {
+	var devices []tuyaDevice
+	if err := b.call(ctx, http.MethodGet, "/v1.0/users/"+b.uid+"/devices", nil, &devices); err != nil {
+		return nil, err
+	}
+	entities := make([]Entity, 0, len(devices))
+	for _, device := range devices {
+		if entity, ok := tuyaEntity(device); ok {
+			entities = append(entities, entity)
+		}
+	}
+	return entities, nil
+}
+
+func (b *tuyaBridge) control(ctx context.Context, entityID, action string, value float64) (Entity, error) {
+	var device tuyaDevice
+	if err := b.call(ctx, http.MethodGet, "/v1.0/devices/"+entityID, nil, &device); err != nil {
+		return Entity{}, err
+	}
+	entity, ok := tuyaEntity(device)
+	if !ok {
+		return Entity{}, sdk.Unsupported("device category %q is not supported", device.Category)
+	}
+	if err := checkAction(entity, action); err != nil {
+		return Entity{}, err
+	}
+
+	commands := tuyaCommands(device, action, value)
+	if len(commands) == 0 {
+		return Entity{}, sdk.Unsupported("entity %s does not support %s", entityID, action)
+	}
+	body := map[string]interface{}{"commands": commands}
+	if err := b.call(ctx, http.MethodPost, "/v1.0/devices/"+entityID+"/commands", body, nil); err != nil {
+		return Entity{}, err
+	}
+
+	// The cloud reports the new status asynchronously; apply the commands to the last status
+	for _, command := range commands {
+		applied := false
+		for i := range device.Status {
+			if device.Status[i].Code == command.Code {
+				device.Status[i].Value = command.Value
+				applied = true
+			}
+		}
+		if !applied {
+			device.Status = append(device.Status, command)
+		}
+	}
+	entity, _ = tuyaEntity(device)
+	return entity, nil
+}
+
+func tuyaEntity(device tuyaDevice) (Entity, bool) {
+	entityType, ok := tuyaCategories[device.Category]
+	if !ok {
+		return Entity{}, false
+	}
+	status := make(map[string]interface{}, len(device.Status))
+	for _, s := range device.Status {
+		status[s.Code] = s.Value
+	}
+
+	var features []string
+	state := make(map[string]interface{})
+	if code := tuyaSwitchCode(status); code != "" && entityType != TypeCover && entityType != TypeSensor {
+		features = append(features, FeatureOnOff)
+		state["on"] = status[code] == true
+	}
+	if code, min, max := tuyaBrightness(status); code != "" {
+		features = append(features, FeatureBrightness)
+		if v, ok := status[code].(float64); ok {
+			state["brightness"] = math.Round((v - min) / (max - min) * 100)
+		}
+	}
+	if entityType == TypeCover {
+		features = append(features, FeatureOpenClose)
+		if _, ok := status["percent_control"]; ok {
+			features = append(features, FeaturePosition)
+		}
+		if v, ok := status["percent_state"].(float64); ok {
+			state["position"] = v
+		} else if v, ok := status["percent_control"].(float64); ok {
+			state["position"] = v
+		}
+	}
+	if entityType == TypeClimate {
+		if v, ok := status["temp_set"].(float64); ok {
+			features = append(features, FeatureTemperature)
+			state["temperature"] = v
+		}
+		if v, ok := status["temp_current"].(float64); ok {
+			state["current_temperature"] = v
+		}
+	}
+	if entityType == TypeSensor {
+		for code, key := range tuyaSensorCodes {
+			v, ok := status[code]
+			if !ok {
+				continue
+			}
+			// Temperature and humidity readings are reported in tenths
+			if f, isNum := v.(float64); isNum && (code == "va_temperature" || code == "va_humidity") {
+				v = f / 10
+			}
+			state[key] = v
+		}
+	}
+
+	return Entity{
+		ID:        device.ID,
+		Name:      device.Name,
+		Type:      entityType,
+		Features:  features,
+		State:     state,
+		Available: device.Online,
+	}, true
+}
+
+func tuyaSwitchCode(status map[string]interface{}) string {
+	for _, code := range tuyaSwitchCodes {
+		if _, ok := status[code]; ok {
+			return code
+		}
+	}
+	return ""
+}
+
+// tuyaBrightness returns the brightness code and its raw range, v2 lights use 10-1000
+func tuyaBrightness(status map[string]interface{}) (string, float64, float64) {
+	if _, ok := status["bright_value_v2"]; ok {
+		return "bright_value_v2", 10, 1000
+	}
+	if _, ok := status["bright_value"]; ok {
+		return "bright_value", 25, 255
+	}
+	return "", 0, 0
+}
+
+func tuyaCommands(device tuyaDevice, action string, value float64) []tuyaStatus {
+	status := make(map[string]interface{}, len(device.Status))
+	for _, s := range device.Status {
+		status[s.Code] = s.Value
+	}
+	switchCode := tuyaSwitchCode(status)
+
+	switch action {
+	case ActionTurnOn:
+		return []tuyaStatus{{Code: switchCode, Value: true}}
+	case ActionTurnOff:
+		return []tuyaStatus{{Code: switchCode, Value: false}}
+	case ActionToggle:
+		return []tuyaStatus{{Code: switchCode, Value: status[switchCode] != true}}
+	case ActionSetBrightness:
+		if value <= 0 {
+			return []tuyaStatus{{Code: switchCode, Value: false}}
+		}
+		code, min, max := tuyaBrightness(status)
+		raw := math.Round(min + clampPercent(value)/100*(max-min))
+		return []tuyaStatus{{Code: switchCode, Value: true}, {Code: code, Value: raw}}
+	case ActionOpen:
+		return []tuyaStatus{{Code: "control", Value: "open"}}
+	case ActionClose:
+		return []tuyaStatus{{Code: "control", Value: "close"}}
+	case ActionSetPosition:
+		return []tuyaStatus{{Code: "percent_control", Value: math.Round(value)}}
+	case ActionSetTemperature:
+		return []tuyaStatus{{Code: "temp_set", Value: math.Round(value)}}
+	}
+	return nil
+}
+
+// call sends a signed OpenAPI request and decodes the result field into out
+func (b *tuyaBridge) call(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	token, err := b.token(ctx)
+	if err != nil {
+		return err
+	}
+	return b.do(ctx, method, path, token, body, out)
+}
+
+func (b *tuyaBridge) token(ctx context.Context) (string, error) {
+	key := b.endpoint + "|" + b.accessID
+	b.provider.mu.Lock()
+	cached := b.provider.tokens[key]
+	b.provider.mu.Unlock()
+	if cached != nil && time.Now().Before(cached.expires) {
+		return cached.value, nil
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpireTime  int    `json:"expire_time"`
+	}
+	if err := b.do(ctx, http.MethodGet, "/v1.0/token?grant_type=1", "", nil, &result); err != nil {
+		return "", err
+	}
+	// Refresh a minute early so in-flight requests never carry an expired token
+	expires := time.Now().Add(time.Duration(result.ExpireTime)*time.Second - time.Minute)
+	b.provider.mu.Lock()
+	b.provider.tokens[key] = &tuyaToken{value: result.AccessToken, expires: expires}
+	b.provider.mu.Unlock()
+	return result.AccessToken, nil
+}
+
+func (b *tuyaBridge) do(ctx context.Context, method, path, token string, body interface{}, out interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return sdk.Internal("failed to encode request", err)
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, b.endpoint+path, bytes.NewReader(payload))
+	if err != nil {
+		return sdk.Internal("failed to build request", err)
+	}
+	t := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	req.Header.Set("client_id", b.accessID)
+	req.Header.Set("t", t)
+	req.Header.Set("sign_method", "HMAC-SHA256")
+	req.Header.Set("sign", b.sign(method, path, token, t, payload))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("access_token", token)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return sdk.Unavailable("Tuya request failed", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return sdk.Unavailable("failed to read Tuya response", err)
+	}
+
+	var envelope struct {
+		Success bool            `json:"success"`
+		Code    int             `json:"code"`
+		Msg     string          `json:"msg"`
+		Result  json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return sdk.Unavailable(fmt.Sprintf("Tuya returned %d", resp.StatusCode), err)
+	}
+	if !envelope.Success {
+		// 1010 means the cached token was revoked or expired early
+		if envelope.Code == 1010 && token != "" {
+			b.provider.mu.Lock()
+			delete(b.provider.tokens, b.endpoint+"|"+b.accessID)
+			b.provider.mu.Unlock()
+		}
+		return sdk.Unavailable(fmt.Sprintf("Tuya error %d: %s", envelope.Code, envelope.Msg), nil)
+	}
+	if out != nil && len(envelope.Result) > 0 {
+		if err := json.Unmarshal(envelope.Result, out); err != nil {
+			return sdk.Internal("failed to decode Tuya response", err)
+		}
+	}
+	return nil
+}
+
+// sign implements the Tuya OpenAPI request signature: HMAC-SHA256 over the client ID, token,
+// timestamp and the canonical request (method, body hash, path with query)
+func (b *tuyaBridge) sign(method, path, token, t string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+	stringToSign := method + "\n" + hex.EncodeToString(bodyHash[:]) + "\n\n" + path
+	mac := hmac.New(sha256.New, []byte(b.secret))
+	mac.Write([]byte(b.accessID + token + t + stringToSign))
+	return strings.ToUpper(hex.EncodeToString(mac.Sum(nil)))
+}
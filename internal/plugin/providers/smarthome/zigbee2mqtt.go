@@ -0,0 +1,424 @@
+package smarthome
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/plugin/sdk"
+)
+
+// z2mDiscoveryWait bounds how long discover waits for the retained bridge/devices message
+const z2mDiscoveryWait = 5 * time.Second
+
+// zigbee2mqtt returns the persistent bridge for the configured broker, reconnecting when the
+// previous session was lost
+func (p *Provider) zigbee2mqtt(ctx context.Context, cfg sdk.Args) (bridge, error) {
+	broker := cfg.String("broker", "tcp://localhost:1883")
+	username := cfg.String("username", "")
+	baseTopic := strings.Trim(cfg.String("base_topic", "zigbee2mqtt"), "/")
+	key := broker + "|" + username + "|" + baseTopic
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if b, ok := p.z2m[key]; ok {
+		if b.client.Err() == nil {
+			return b, nil
+		}
+		if p.logger != nil {
+			p.logger.Warn("Zigbee2MQTT session to %s lost, reconnecting: %v", broker, b.client.Err())
+		}
+		delete(p.z2m, key)
+	}
+
+	clientID := cfg.String("client_id", "")
+	if clientID == "" {
+		clientID = fmt.Sprintf("xiaozhi-%d", time.Now().UnixNano()%1e9)
+	}
+	b := &z2mBridge{
+		baseTopic: baseTopic,
+		devices:   make(map[string]*z2mDevice),
+		states:    make(map[string]map[string]interface{}),
+		offline:   make(map[string]bool),
+		ready:     make(chan struct{}),
+	}
+	client, err := dialMQTT(ctx, mqttOptions{
+		Broker:   broker,
+		ClientID: clientID,
+		Username: username,
+		Password: cfg.String("password", ""),
+	}, b.handleMessage)
+	if err != nil {
+		return nil, sdk.Unavailable("failed to connect to MQTT broker", err)
+	}
+	b.client = client
+	if err := client.Subscribe(baseTopic + "/#"); err != nil {
+		client.Close()
+		return nil, sdk.Unavailable("failed to subscribe to Zigbee2MQTT topics", err)
+	}
+	p.z2m[key] = b
+	if p.logger != nil {
+		p.logger.Info("Connected to Zigbee2MQTT at %s (base topic %s)", broker, baseTopic)
+	}
+	return b, nil
+}
+
+// z2mBridge mirrors device list, state and availability published by Zigbee2MQTT
+type z2mBridge struct {
+	baseTopic string
+	client    *mqttClient
+
+	mu        sync.RWMutex
+	devices   map[string]*z2mDevice             // keyed by friendly name
+	states    map[string]map[string]interface{} // last published state, keyed by friendly name
+	offline   map[string]bool
+	ready     chan struct{} // closed once bridge/devices arrived
+	readyOnce sync.Once
+}
+
+// z2mDevice holds the properties of a device's exposes that map onto the uniform schema
+type z2mDevice struct {
+	ieee         string
+	friendlyName string
+	description  string
+	entityType   string
+	features     []string
+
+	stateProp     string
+	valueOn       interface{}
+	valueOff      interface{}
+	valueToggle   interface{}
+	brightnessMax float64
+	positionProp  string
+	setpointProp  string
+	localTempProp string
+	sensorProps   []string
+}
+
+func (b *z2mBridge) handleMessage(topic string, payload []byte) {
+	rest := strings.TrimPrefix(topic, b.baseTopic+"/")
+	if rest == topic {
+		return
+	}
+	switch {
+	case rest == "bridge/devices":
+		b.updateDevices(payload)
+	case strings.HasPrefix(rest, "bridge/"):
+	case strings.HasSuffix(rest, "/availability"):
+		name := strings.TrimSuffix(rest, "/availability")
+		online := strings.TrimSpace(string(payload)) == "online"
+		var legacy struct {
+			State string `json:"state"`
+		}
+		if json.Unmarshal(payload, &legacy) == nil && legacy.State != "" {
+			online = legacy.State == "online"
+		}
+		b.mu.Lock()
+		b.offline[name] = !online
+		b.mu.Unlock()
+	case strings.HasSuffix(rest, "/set"), strings.HasSuffix(rest, "/get"):
+	default:
+		var state map[string]interface{}
+		if json.Unmarshal(payload, &state) != nil {
+			return
+		}
+		b.mu.Lock()
+		merged := b.states[rest]
+		if merged == nil {
+			merged = make(map[string]interface{})
+			b.states[rest] = merged
+		}
+		for k, v := range state {
+			merged[k] = v
+		}
+		b.mu.Unlock()
+	}
+}
+
+// z2mExpose is the subset of the Zigbee2MQTT exposes schema used for mapping
+type z2mExpose struct {
+	Type        string      `json:"type"`
+	Name        string      `json:"name"`
+	Property    string      `json:"property"`
+	Access      int         `json:"access"`
+	ValueOn     interface{} `json:"value_on"`
+	ValueOff    interface{} `json:"value_off"`
+	ValueToggle interface{} `json:"value_toggle"`
+	ValueMax    float64     `json:"value_max"`
+	Features    []z2mExpose `json:"features"`
+}
+
+func (b *z2mBridge) updateDevices(payload []byte) {
+	var list []struct {
+		IEEEAddress        string `json:"ieee_address"`
+		FriendlyName       string `json:"friendly_name"`
+		Type               string `json:"type"`
+		Description        string `json:"description"`
+		InterviewCompleted bool   `json:"interview_completed"`
+		Definition         *struct {
+			Exposes []z2mExpose `json:"exposes"`
+		} `json:"definition"`
+	}
+	if err := json.Unmarshal(payload, &list); err != nil {
+		return
+	}
+
+	devices := make(map[string]*z2mDevice, len(list))
+	for _, d := range list {
+		if d.Type == "Coordinator" || d.Definition == nil || !d.InterviewCompleted {
+			continue
+		}
+		device := mapZ2MDevice(d.Definition.Exposes)
+		if device == nil {
+			continue
+		}
+		device.ieee = d.IEEEAddress
+		device.friendlyName = d.FriendlyName
+		device.description = d.Description
+		devices[d.FriendlyName] = device
+	}
+
+	b.mu.Lock()
+	b.devices = devices
+	var missing []*z2mDevice
+	for name, device := range devices {
+		if _, ok := b.states[name]; !ok && device.stateProp != "" {
+			missing = append(missing, device)
+		}
+	}
+	b.mu.Unlock()
+	b.readyOnce.Do(func() { close(b.ready) })
+
+	// Device state is only retained when configured, ask for what we have not seen yet
+	for _, device := range missing {
+		payload, _ := json.Marshal(map[string]interface{}{device.stateProp: ""})
+		b.client.Publish(b.baseTopic+"/"+device.friendlyName+"/get", payload)
+	}
+}
+
+// mapZ2MDevice picks the first controllable expose (light, switch, cover, climate, lock, fan);
+// devices that only expose readings become sensors
+func mapZ2MDevice(exposes []z2mExpose) *z2mDevice {
+	device := &z2mDevice{brightnessMax: 254}
+	for _, expose := range exposes {
+		switch expose.Type {
+		case TypeLight, TypeSwitch, TypeCover, TypeClimate, TypeLock, TypeFan:
+			if device.entityType != "" {
+				continue
+			}
+			device.entityType = expose.Type
+			for _, f := range expose.Features {
+				switch {
+				case f.Name == "state" && expose.Type == TypeCover:
+					device.stateProp = f.Property
+					device.features = append(device.features, FeatureOpenClose)
+				case f.Name == "state" && expose.Type == TypeLock:
+					device.stateProp = f.Property
+					device.valueOn, device.valueOff = f.ValueOn, f.ValueOff
+					device.features = append(device.features, FeatureLock)
+				case f.Name == "state":
+					device.stateProp = f.Property
+					device.valueOn, device.valueOff, device.valueToggle = f.ValueOn, f.ValueOff, f.ValueToggle
+					device.features = append(device.features, FeatureOnOff)
+				case f.Name == "brightness":
+					if f.ValueMax > 0 {
+						device.brightnessMax = f.ValueMax
+					}
+					device.features = append(device.features, FeatureBrightness)
+				case f.Name == "position":
+					device.positionProp = f.Property
+					device.features = append(device.features, FeaturePosition)
+				case f.Name == "occupied_heating_setpoint" || (f.Name == "current_heating_setpoint" && device.setpointProp == ""):
+					device.setpointProp = f.Property
+					if !hasFeature(device.features, FeatureTemperature) {
+						device.features = append(device.features, FeatureTemperature)
+					}
+				case f.Name == "local_temperature":
+					device.localTempProp = f.Property
+				}
+			}
+		case "numeric", "binary":
+			switch expose.Name {
+			case "temperature", "humidity", "illuminance", "illuminance_lux", "pressure", "co2", "pm25", "battery", "occupancy", "contact", "water_leak", "smoke":
+				device.sensorProps = append(device.sensorProps, expose.Property)
+			}
+		}
+	}
+	if device.entityType == "" {
+		if len(device.sensorProps) == 0 {
+			return nil
+		}
+		device.entityType = TypeSensor
+	}
+	switch {
+	case device.valueOn != nil:
+	case device.entityType == TypeLock:
+		device.valueOn, device.valueOff = "LOCK", "UNLOCK"
+	default:
+		device.valueOn, device.valueOff = "ON", "OFF"
+	}
+	if device.valueToggle == nil {
+		device.valueToggle = "TOGGLE"
+	}
+	return device
+}
+
+func (b *z2mBridge) discover(ctx context.Context) ([]Entity, error) {
+	timer := time.NewTimer(z2mDiscoveryWait)
+	defer timer.Stop()
+	select {
+	case <-b.ready:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-b.client.Done():
+		return nil, sdk.Unavailable("MQTT connection lost", b.client.Err())
+	case <-timer.C:
+		return nil, sdk.Unavailable(fmt.Sprintf("no device list on %s/bridge/devices, is Zigbee2MQTT running?", b.baseTopic), nil)
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	entities := make([]Entity, 0, len(b.devices))
+	for name, device := range b.devices {
+		entities = append(entities, device.entity(b.states[name], !b.offline[name]))
+	}
+	return entities, nil
+}
+
+func (b *z2mBridge) control(ctx context.Context, entityID, action string, value float64) (Entity, error) {
+	entities, err := b.discover(ctx)
+	if err != nil {
+		return Entity{}, err
+	}
+	entity, err := findEntity(entities, entityID)
+	if err != nil {
+		return Entity{}, err
+	}
+	if err := checkAction(entity, action); err != nil {
+		return Entity{}, err
+	}
+
+	b.mu.RLock()
+	var device *z2mDevice
+	for _, d := range b.devices {
+		if d.ieee == entityID {
+			device = d
+		}
+	}
+	b.mu.RUnlock()
+	if device == nil {
+		return Entity{}, sdk.InvalidArgument("entity_id", "unknown entity %q", entityID)
+	}
+
+	command := device.command(action, value)
+	payload, err := json.Marshal(command)
+	if err != nil {
+		return Entity{}, sdk.Internal("failed to encode command", err)
+	}
+	if err := b.client.Publish(b.baseTopic+"/"+device.friendlyName+"/set", payload); err != nil {
+		return Entity{}, sdk.Unavailable("failed to publish command", err)
+	}
+
+	// Zigbee2MQTT publishes the new state once the device reports back; apply the command
+	// optimistically so callers see the expected state right away
+	if action == ActionToggle {
+		command = map[string]interface{}{}
+	}
+	b.mu.Lock()
+	merged := b.states[device.friendlyName]
+	if merged == nil {
+		merged = make(map[string]interface{})
+		b.states[device.friendlyName] = merged
+	}
+	for k, v := range command {
+		merged[k] = v
+	}
+	entity = device.entity(merged, !b.offline[device.friendlyName])
+	b.mu.Unlock()
+	return entity, nil
+}
+
+func (d *z2mDevice) command(action string, value float64) map[string]interface{} {
+	switch action {
+	case ActionTurnOn:
+		return map[string]interface{}{d.stateProp: d.valueOn}
+	case ActionTurnOff:
+		return map[string]interface{}{d.stateProp: d.valueOff}
+	case ActionToggle:
+		return map[string]interface{}{d.stateProp: d.valueToggle}
+	case ActionSetBrightness:
+		if value <= 0 {
+			return map[string]interface{}{d.stateProp: d.valueOff}
+		}
+		return map[string]interface{}{d.stateProp: d.valueOn, "brightness": math.Round(value / 100 * d.brightnessMax)}
+	case ActionOpen:
+		return map[string]interface{}{d.stateProp: "OPEN"}
+	case ActionClose:
+		return map[string]interface{}{d.stateProp: "CLOSE"}
+	case ActionSetPosition:
+		return map[string]interface{}{d.positionProp: math.Round(value)}
+	case ActionSetTemperature:
+		return map[string]interface{}{d.setpointProp: value}
+	case ActionLock:
+		return map[string]interface{}{d.stateProp: d.valueOn}
+	case ActionUnlock:
+		return map[string]interface{}{d.stateProp: d.valueOff}
+	}
+	return nil
+}
+
+func (d *z2mDevice) entity(raw map[string]interface{}, available bool) Entity {
+	room, name := "", d.friendlyName
+	if i := strings.LastIndex(name, "/"); i > 0 {
+		room, name = name[:i], name[i+1:]
+		if j := strings.LastIndex(room, "/"); j >= 0 {
+			room = room[j+1:]
+		}
+	}
+	if d.description != "" {
+		name = d.description
+	}
+
+	state := make(map[string]interface{})
+	if v, ok := raw[d.stateProp]; ok && d.stateProp != "" {
+		switch d.entityType {
+		case TypeLock:
+			state["locked"] = fmt.Sprint(v) == fmt.Sprint(d.valueOn)
+		case TypeCover:
+			state["open"] = fmt.Sprint(v) == "OPEN"
+		default:
+			state["on"] = fmt.Sprint(v) == fmt.Sprint(d.valueOn)
+		}
+	}
+	if v, ok := raw["brightness"].(float64); ok && d.brightnessMax > 0 {
+		state["brightness"] = math.Round(v / d.brightnessMax * 100)
+	}
+	if v, ok := raw[d.positionProp].(float64); ok && d.positionProp != "" {
+		state["position"] = v
+	}
+	if v, ok := raw[d.setpointProp]; ok && d.setpointProp != "" {
+		state["temperature"] = v
+	}
+	if v, ok := raw[d.localTempProp]; ok && d.localTempProp != "" {
+		state["current_temperature"] = v
+	}
+	for _, prop := range d.sensorProps {
+		if v, ok := raw[prop]; ok {
+			state[prop] = v
+		}
+	}
+
+	return Entity{
+		ID:        d.ieee,
+		Name:      name,
+		Type:      d.entityType,
+		Room:      room,
+		Features:  d.features,
+		State:     state,
+		Available: available,
+	}
+}
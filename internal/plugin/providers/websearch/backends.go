@@ -0,0 +1,181 @@
+package websearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"xiaozhi-server-go/internal/plugin/sdk"
+)
+
+const (
+	bingEndpoint  = "https://api.bing.microsoft.com/v7.0/search"
+	braveEndpoint = "https://api.search.brave.com/res/v1/web/search"
+)
+
+// maxResponseSize bounds how much of a backend response is read
+const maxResponseSize = 4 << 20
+
+type searchBackend interface {
+	search(ctx context.Context, client *http.Client, cfg sdk.Args, query string, count int) ([]Result, error)
+}
+
+// searxng queries a self-hosted SearxNG instance through its JSON API
+type searxng struct{}
+
+func (searxng) search(ctx context.Context, client *http.Client, cfg sdk.Args, query string, count int) ([]Result, error) {
+	baseURL := strings.TrimRight(cfg.String("base_url", ""), "/")
+	if baseURL == "" {
+		return nil, sdk.MissingArgument("base_url")
+	}
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("format", "json")
+	if lang := cfg.String("language", ""); lang != "" {
+		params.Set("language", lang)
+	}
+	if cfg.Bool("safe_search", true) {
+		params.Set("safesearch", "1")
+	} else {
+		params.Set("safesearch", "0")
+	}
+
+	var resp struct {
+		Results []struct {
+			Title   string `json:"title"`
+			URL     string `json:"url"`
+			Content string `json:"content"`
+		} `json:"results"`
+	}
+	if err := getJSON(ctx, client, baseURL+"/search?"+params.Encode(), nil, &resp); err != nil {
+		return nil, err
+	}
+	results := make([]Result, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		results = append(results, Result{Title: r.Title, URL: r.URL, Snippet: r.Content})
+	}
+	return results, nil
+}
+
+// bing queries the Bing Web Search v7 API
+type bing struct{}
+
+func (bing) search(ctx context.Context, client *http.Client, cfg sdk.Args, query string, count int) ([]Result, error) {
+	apiKey := cfg.String("api_key", "")
+	if apiKey == "" {
+		return nil, sdk.MissingArgument("api_key")
+	}
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("count", strconv.Itoa(count))
+	params.Set("textFormat", "Raw")
+	if lang := cfg.String("language", ""); lang != "" {
+		params.Set("mkt", lang)
+	}
+	if cfg.Bool("safe_search", true) {
+		params.Set("safeSearch", "Moderate")
+	} else {
+		params.Set("safeSearch", "Off")
+	}
+
+	var resp struct {
+		WebPages struct {
+			Value []struct {
+				Name    string `json:"name"`
+				URL     string `json:"url"`
+				Snippet string `json:"snippet"`
+			} `json:"value"`
+		} `json:"webPages"`
+	}
+	endpoint := cfg.String("base_url", bingEndpoint)
+	header := http.Header{"Ocp-Apim-Subscription-Key": {apiKey}}
+	if err := getJSON(ctx, client, endpoint+"?"+params.Encode(), header, &resp); err != nil {
+		return nil, err
+	}
+	results := make([]Result, 0, len(resp.WebPages.Value))
+	for _, r := range resp.WebPages.Value {
+		results = append(results, Result{Title: r.Name, URL: r.URL, Snippet: r.Snippet})
+	}
+	return results, nil
+}
+
+// brave queries the Brave Search web API
+type brave struct{}
+
+func (brave) search(ctx context.Context, client *http.Client, cfg sdk.Args, query string, count int) ([]Result, error) {
+	apiKey := cfg.String("api_key", "")
+	if apiKey == "" {
+		return nil, sdk.MissingArgument("api_key")
+	}
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("count", strconv.Itoa(count))
+	if lang := cfg.String("language", ""); lang != "" {
+		// Brave takes a bare language code ("zh"), not a market ("zh-CN")
+		params.Set("search_lang", strings.ToLower(strings.SplitN(lang, "-", 2)[0]))
+	}
+	if cfg.Bool("safe_search", true) {
+		params.Set("safesearch", "moderate")
+	} else {
+		params.Set("safesearch", "off")
+	}
+
+	var resp struct {
+		Web struct {
+			Results []struct {
+				Title       string `json:"title"`
+				URL         string `json:"url"`
+				Description string `json:"description"`
+			} `json:"results"`
+		} `json:"web"`
+	}
+	endpoint := cfg.String("base_url", braveEndpoint)
+	header := http.Header{
+		"X-Subscription-Token": {apiKey},
+		"Accept":               {"application/json"},
+	}
+	if err := getJSON(ctx, client, endpoint+"?"+params.Encode(), header, &resp); err != nil {
+		return nil, err
+	}
+	results := make([]Result, 0, len(resp.Web.Results))
+	for _, r := range resp.Web.Results {
+		results = append(results, Result{Title: r.Title, URL: r.URL, Snippet: r.Description})
+	}
+	return results, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, target string, header http.Header, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return sdk.InvalidArgument("base_url", "%v", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return sdk.Unavailable("search request failed", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return sdk.Unavailable("failed to read search response", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg := strings.TrimSpace(string(body))
+		if len(msg) > 200 {
+			msg = msg[:200]
+		}
+		return sdk.Unavailable(fmt.Sprintf("search backend returned %d", resp.StatusCode), fmt.Errorf("%s", msg))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return sdk.Internal("failed to decode search response", err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package websearch
+
+import (
+	"context"
+	"fmt"
+
+	"xiaozhi-server-go/internal/platform/httpclient"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/plugin/sdk"
+)
+
+// CapabilityID is the built-in web search capability
+const CapabilityID = "web_search"
+
+// maxCount caps how many results a single call may request from a backend
+const maxCount = 20
+
+// Provider exposes web search backed by SearxNG, Bing or Brave
+type Provider struct {
+	logger *logging.Logger
+}
+
+func NewProvider() *Provider {
+	return NewProviderWithLogger(nil)
+}
+
+func NewProviderWithLogger(logger *logging.Logger) *Provider {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &Provider{
+		logger: logger,
+	}
+}
+
+func (p *Provider) GetCapabilities() []capability.Definition {
+	return []capability.Definition{
+		{
+			ID:          CapabilityID,
+			Type:        capability.TypeSearch,
+			Name:        "Web Search",
+			Description: "Web search with SearxNG, Bing or Brave Search backends",
+			ConfigSchema: capability.Schema{
+				Type: "object",
+				Properties: map[string]capability.Property{
+					"backend":     {Type: "string", Default: "searxng", Enum: []interface{}{"searxng", "bing", "brave"}, Description: "Search backend"},
+					"base_url":    {Type: "string", Description: "SearxNG instance URL, or an API endpoint override for Bing/Brave"},
+					"api_key":     {Type: "string", Secret: true, Description: "Bing or Brave API key"},
+					"language":    {Type: "string", Default: "zh-CN", Description: "Result language / market"},
+					"safe_search": {Type: "boolean", Default: true, Description: "Filter adult content"},
+					"network":     sdk.NetworkProperty,
+				},
+			},
+			InputSchema: capability.Schema{
+				Type: "object",
+				Properties: map[string]capability.Property{
+					"query": {Type: "string"},
+					"count": {Type: "integer", Default: 5},
+				},
+				Required: []string{"query"},
+			},
+			OutputSchema: capability.Schema{
+				Type: "object",
+				Properties: map[string]capability.Property{
+					"results": {Type: "array", Description: "Items with title, url and snippet"},
+				},
+			},
+		},
+	}
+}
+
+func (p *Provider) CreateExecutor(capabilityID string) (capability.Executor, error) {
+	switch capabilityID {
+	case CapabilityID:
+		return &SearchExecutor{pool: httpclient.Default()}, nil
+	default:
+		return nil, fmt.Errorf("unknown capability: %s", capabilityID)
+	}
+}
+
+// Result is a single search hit as returned by a backend
+type Result struct {
+	Title   string
+	URL     string
+	Snippet string
+}
+
+type SearchExecutor struct {
+	pool *httpclient.Pool // clients honour the network settings in the capability config
+}
+
+// Execute runs inputs["query"] against the configured backend; results are returned as []interface{}
+// of maps so they survive the gRPC struct conversion
+func (e *SearchExecutor) Execute(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (map[string]interface{}, error) {
+	cfg := sdk.Args(config)
+	args := sdk.Args(inputs)
+	query, err := args.RequireString("query")
+	if err != nil {
+		return nil, err
+	}
+	count := args.Int("count", 5)
+	if count <= 0 || count > maxCount {
+		count = maxCount
+	}
+
+	var backend searchBackend
+	switch name := cfg.String("backend", "searxng"); name {
+	case "searxng":
+		backend = searxng{}
+	case "bing":
+		backend = bing{}
+	case "brave":
+		backend = brave{}
+	default:
+		return nil, sdk.InvalidArgument("backend", "unsupported backend %q", name)
+	}
+
+	results, err := backend.search(ctx, e.pool.ClientFor("websearch", cfg), cfg, query, count)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) > count {
+		results = results[:count]
+	}
+
+	items := make([]interface{}, 0, len(results))
+	for _, r := range results {
+		items = append(items, map[string]interface{}{
+			"title":   r.Title,
+			"url":     r.URL,
+			"snippet": r.Snippet,
+		})
+	}
+	return map[string]interface{}{"results": items}, nil
+}
@@ -0,0 +1,350 @@
+package whispercpp
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"mime/multipart"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/language"
+	"xiaozhi-server-go/internal/domain/providers/asr"
+	"xiaozhi-server-go/internal/platform/httpclient"
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+const (
+	defaultAddr       = "http://127.0.0.1:8080/inference"
+	defaultSampleRate = 16000
+	defaultSilence    = 800 * time.Millisecond
+	defaultThreshold  = 0.01
+	maxUtterance      = 30 * time.Second // whisper decodes at most 30s windows
+	requestTimeout    = 30 * time.Second
+)
+
+// annotations matches non-speech markers whisper emits for noise, e.g. [BLANK_AUDIO] or (音乐)
+var annotations = regexp.MustCompile(`\[[^\]]*\]|\([^)]*\)|（[^）]*）`)
+
+// ASRProvider transcribes speech with a whisper.cpp server (examples/server).
+// Whisper is not a streaming model, so PCM is buffered locally and an utterance is
+// posted to /inference once trailing silence is detected or the client stops listening.
+type ASRProvider struct {
+	*asr.BaseProvider
+	logger     *logging.Logger
+	client     *http.Client
+	addr       string
+	language   string
+	sampleRate int
+	silence    time.Duration
+	threshold  float64
+
+	mu        sync.Mutex
+	pcm       bytes.Buffer
+	voiced    bool          // whether the buffer holds speech
+	silentFor time.Duration // trailing silence after the last voiced chunk
+
+	transcribeMu sync.Mutex // keeps results in utterance order
+
+	langMu  sync.Mutex
+	allowed []string // languages the device allows, from the connection's language hint
+	hint    string   // language of the previous utterance
+}
+
+// NewASRProvider creates a whisper.cpp provider from the ASR config map
+// (addr, language, sample_rate, silence_ms, threshold, network).
+func NewASRProvider(config *asr.Config, deleteFile bool, logger *logging.Logger) (asr.Provider, error) {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	p := &ASRProvider{
+		BaseProvider: asr.NewBaseProvider(config, deleteFile),
+		logger:       logger,
+		client:       httpclient.Default().ClientFor("whispercpp", config.Data),
+		addr:         stringValue(config.Data, "addr", defaultAddr),
+		language:     stringValue(config.Data, "language", "auto"),
+		sampleRate:   intValue(config.Data, "sample_rate", defaultSampleRate),
+		silence:      time.Duration(intValue(config.Data, "silence_ms", int(defaultSilence/time.Millisecond))) * time.Millisecond,
+		threshold:    floatValue(config.Data, "threshold", defaultThreshold),
+	}
+	p.InitAudioProcessing()
+	return p, nil
+}
+
+// AddAudio buffers 16-bit mono PCM and flushes the utterance after trailing silence.
+func (p *ASRProvider) AddAudio(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	chunk := time.Duration(len(data)/2) * time.Second / time.Duration(p.sampleRate)
+
+	p.mu.Lock()
+	speech := rms(data) >= p.threshold
+	switch {
+	case speech:
+		p.voiced = true
+		p.silentFor = 0
+		p.pcm.Write(data)
+	case p.voiced:
+		p.silentFor += chunk
+		p.pcm.Write(data)
+	default:
+		// Leading silence is dropped so whisper does not hallucinate on it.
+		p.mu.Unlock()
+		return nil
+	}
+	var utterance []byte
+	if p.silentFor >= p.silence || p.bufferedLocked() >= maxUtterance {
+		utterance = p.takeLocked()
+	}
+	p.mu.Unlock()
+
+	if utterance != nil {
+		go p.recognize(utterance)
+	}
+	return nil
+}
+
+// SendLastAudio flushes whatever has been buffered when the client stops listening.
+func (p *ASRProvider) SendLastAudio(data []byte) error {
+	p.mu.Lock()
+	if len(data) > 0 {
+		p.pcm.Write(data)
+	}
+	voiced := p.voiced
+	utterance := p.takeLocked()
+	p.mu.Unlock()
+
+	if !voiced {
+		// Manual mode waits for a final result even when nothing was said.
+		if listener := p.GetListener(); listener != nil {
+			listener.OnAsrResult("", true)
+		}
+		return nil
+	}
+	go p.recognize(utterance)
+	return nil
+}
+
+// SetUserPreferences picks up the connection's language hint: "languages" lists the
+// languages the device allows and "language" the one spoken last.
+func (p *ASRProvider) SetUserPreferences(preferences map[string]interface{}) error {
+	allowed, _ := preferences["languages"].([]string)
+	hint, _ := preferences["language"].(string)
+	p.langMu.Lock()
+	p.allowed, p.hint = allowed, hint
+	p.langMu.Unlock()
+	return p.BaseProvider.SetUserPreferences(preferences)
+}
+
+// Transcribe posts a PCM utterance to the whisper.cpp server and returns the cleaned text.
+// With language "auto" and several allowed languages whisper detects the language itself;
+// when it settles on a language the device does not allow (accented speech is often taken
+// for a neighbouring language) the utterance is decoded again with the previous language.
+func (p *ASRProvider) Transcribe(ctx context.Context, audioData []byte) (string, error) {
+	p.langMu.Lock()
+	allowed, hint := p.allowed, p.hint
+	p.langMu.Unlock()
+
+	lang := p.language
+	if lang == "auto" && len(allowed) == 1 {
+		lang = allowed[0]
+	}
+	text, err := p.transcribe(ctx, audioData, lang)
+	if err != nil || lang != "auto" || len(allowed) < 2 || hint == "" {
+		return text, err
+	}
+	if detected, _ := language.Detect(text); detected != "" && !slices.Contains(allowed, detected) {
+		p.logger.Debug("whisper.cpp detected %s outside %v, retrying with %s", detected, allowed, hint)
+		return p.transcribe(ctx, audioData, hint)
+	}
+	return text, nil
+}
+
+// transcribe runs one /inference request with the given whisper language.
+func (p *ASRProvider) transcribe(ctx context.Context, audioData []byte, lang string) (string, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	file, err := form.CreateFormFile("file", "audio.wav")
+	if err != nil {
+		return "", err
+	}
+	if err := writeWAV(file, audioData, p.sampleRate); err != nil {
+		return "", err
+	}
+	fields := map[string]string{
+		"response_format": "json",
+		"temperature":     "0.0",
+		"language":        lang,
+	}
+	for k, v := range fields {
+		if err := form.WriteField(k, v); err != nil {
+			return "", err
+		}
+	}
+	if err := form.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.addr, &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("whisper.cpp request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("whisper.cpp returned %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	var result struct {
+		Text  string `json:"text"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("decode whisper.cpp response: %w", err)
+	}
+	if result.Error != "" {
+		return "", fmt.Errorf("whisper.cpp: %s", result.Error)
+	}
+	return cleanText(result.Text), nil
+}
+
+// Reset drops buffered audio.
+func (p *ASRProvider) Reset() error {
+	p.mu.Lock()
+	p.takeLocked()
+	p.mu.Unlock()
+	return nil
+}
+
+// CloseConnection is a no-op; every utterance is a separate HTTP request.
+func (p *ASRProvider) CloseConnection() error {
+	return nil
+}
+
+// Cleanup drops buffered audio.
+func (p *ASRProvider) Cleanup() error {
+	return p.Reset()
+}
+
+func (p *ASRProvider) recognize(utterance []byte) {
+	p.transcribeMu.Lock()
+	defer p.transcribeMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	start := time.Now()
+	text, err := p.Transcribe(ctx, utterance)
+	if err != nil {
+		p.logger.ErrorTag("whispercpp", "transcription failed: %v", err)
+		p.PublishAsrError(err)
+		text = ""
+	} else {
+		p.logger.DebugTag("whispercpp", "transcribed %d bytes in %s", len(utterance), time.Since(start))
+		if text != "" {
+			p.PublishAsrResult(text, true)
+		}
+	}
+	if listener := p.GetListener(); listener != nil {
+		listener.OnAsrResult(text, true)
+	}
+}
+
+func (p *ASRProvider) bufferedLocked() time.Duration {
+	return time.Duration(p.pcm.Len()/2) * time.Second / time.Duration(p.sampleRate)
+}
+
+func (p *ASRProvider) takeLocked() []byte {
+	utterance := append([]byte(nil), p.pcm.Bytes()...)
+	p.pcm.Reset()
+	p.voiced = false
+	p.silentFor = 0
+	return utterance
+}
+
+// rms returns the normalised energy of a 16-bit little-endian PCM chunk.
+func rms(pcm []byte) float64 {
+	n := len(pcm) / 2
+	if n == 0 {
+		return 0
+	}
+	var sum float64
+	for i := 0; i < n; i++ {
+		s := float64(int16(binary.LittleEndian.Uint16(pcm[2*i:]))) / 32768
+		sum += s * s
+	}
+	return math.Sqrt(sum / float64(n))
+}
+
+// writeWAV writes a 16-bit mono WAV header followed by the PCM payload.
+func writeWAV(w io.Writer, pcm []byte, sampleRate int) error {
+	header := []interface{}{
+		[]byte("RIFF"), uint32(36 + len(pcm)), []byte("WAVE"),
+		[]byte("fmt "), uint32(16), uint16(1), uint16(1),
+		uint32(sampleRate), uint32(sampleRate * 2), uint16(2), uint16(16),
+		[]byte("data"), uint32(len(pcm)),
+	}
+	for _, v := range header {
+		if err := binary.Write(w, binary.LittleEndian, v); err != nil {
+			return err
+		}
+	}
+	_, err := w.Write(pcm)
+	return err
+}
+
+func cleanText(text string) string {
+	return strings.TrimSpace(annotations.ReplaceAllString(text, ""))
+}
+
+func stringValue(data map[string]interface{}, key, fallback string) string {
+	if v, ok := data[key].(string); ok && v != "" {
+		return v
+	}
+	return fallback
+}
+
+func intValue(data map[string]interface{}, key string, fallback int) int {
+	switch v := data[key].(type) {
+	case int:
+		if v > 0 {
+			return v
+		}
+	case int64:
+		if v > 0 {
+			return int(v)
+		}
+	case float64:
+		if v > 0 {
+			return int(v)
+		}
+	}
+	return fallback
+}
+
+func floatValue(data map[string]interface{}, key string, fallback float64) float64 {
+	switch v := data[key].(type) {
+	case float64:
+		if v > 0 {
+			return v
+		}
+	case int:
+		if v > 0 {
+			return float64(v)
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,11 @@
+// Package whispercpp provides a local ASR provider backed by a whisper.cpp server,
+// used as the speech recognizer in offline mode.
+package whispercpp
+
+import (
+	"xiaozhi-server-go/internal/domain/providers/asr"
+)
+
+func init() {
+	asr.Register("whispercpp", NewASRProvider)
+}
@@ -0,0 +1,242 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Args 能力的配置或输入参数
+// 提供带默认值的类型化读取方法，参数缺失时返回默认值，类型不匹配时返回默认值并由 Lookup 系列方法报告错误
+type Args map[string]interface{}
+
+// Has 参数是否存在且不为 nil
+func (a Args) Has(key string) bool {
+	v, ok := a[key]
+	return ok && v != nil
+}
+
+// Raw 返回原始参数值
+func (a Args) Raw(key string) (interface{}, bool) {
+	v, ok := a[key]
+	if !ok || v == nil {
+		return nil, false
+	}
+	return v, true
+}
+
+// String 读取字符串参数
+func (a Args) String(key, def string) string {
+	v, ok, err := a.LookupString(key)
+	if !ok || err != nil {
+		return def
+	}
+	return v
+}
+
+// Int 读取整数参数，兼容 JSON 解码得到的 float64 和数字字符串
+func (a Args) Int(key string, def int) int {
+	v, ok, err := a.LookupInt(key)
+	if !ok || err != nil {
+		return def
+	}
+	return v
+}
+
+// Float 读取浮点数参数
+func (a Args) Float(key string, def float64) float64 {
+	v, ok, err := a.LookupFloat(key)
+	if !ok || err != nil {
+		return def
+	}
+	return v
+}
+
+// Bool 读取布尔参数，兼容 "true"/"false" 字符串
+func (a Args) Bool(key string, def bool) bool {
+	v, ok, err := a.LookupBool(key)
+	if !ok || err != nil {
+		return def
+	}
+	return v
+}
+
+// Strings 读取字符串数组参数
+func (a Args) Strings(key string, def []string) []string {
+	v, ok, err := a.LookupStrings(key)
+	if !ok || err != nil {
+		return def
+	}
+	return v
+}
+
+// Object 读取对象参数
+func (a Args) Object(key string) Args {
+	v, ok := a[key].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return Args(v)
+}
+
+// Objects 读取对象数组参数，非对象元素会被跳过
+func (a Args) Objects(key string) []Args {
+	items, ok := a[key].([]interface{})
+	if !ok {
+		return nil
+	}
+	result := make([]Args, 0, len(items))
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			result = append(result, Args(m))
+		}
+	}
+	return result
+}
+
+// LookupString 读取字符串参数，ok 表示参数是否存在
+func (a Args) LookupString(key string) (string, bool, error) {
+	raw, ok := a.Raw(key)
+	if !ok {
+		return "", false, nil
+	}
+	s, isString := raw.(string)
+	if !isString {
+		return "", true, InvalidArgument(key, "expected string, got %s", typeName(raw))
+	}
+	return s, true, nil
+}
+
+// LookupInt 读取整数参数，带小数部分的数值视为类型错误
+func (a Args) LookupInt(key string) (int, bool, error) {
+	raw, ok := a.Raw(key)
+	if !ok {
+		return 0, false, nil
+	}
+	f, err := toFloat(raw)
+	if err != nil {
+		return 0, true, InvalidArgument(key, "expected integer, got %s", typeName(raw))
+	}
+	if f != math.Trunc(f) || f > math.MaxInt64 || f < math.MinInt64 {
+		return 0, true, InvalidArgument(key, "expected integer, got %v", raw)
+	}
+	return int(f), true, nil
+}
+
+// LookupFloat 读取浮点数参数
+func (a Args) LookupFloat(key string) (float64, bool, error) {
+	raw, ok := a.Raw(key)
+	if !ok {
+		return 0, false, nil
+	}
+	f, err := toFloat(raw)
+	if err != nil {
+		return 0, true, InvalidArgument(key, "expected number, got %s", typeName(raw))
+	}
+	return f, true, nil
+}
+
+// LookupBool 读取布尔参数
+func (a Args) LookupBool(key string) (bool, bool, error) {
+	raw, ok := a.Raw(key)
+	if !ok {
+		return false, false, nil
+	}
+	switch v := raw.(type) {
+	case bool:
+		return v, true, nil
+	case string:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, true, InvalidArgument(key, "expected boolean, got %q", v)
+		}
+		return b, true, nil
+	default:
+		return false, true, InvalidArgument(key, "expected boolean, got %s", typeName(raw))
+	}
+}
+
+// LookupStrings 读取字符串数组参数
+func (a Args) LookupStrings(key string) ([]string, bool, error) {
+	raw, ok := a.Raw(key)
+	if !ok {
+		return nil, false, nil
+	}
+	switch v := raw.(type) {
+	case []string:
+		return v, true, nil
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for i, item := range v {
+			s, isString := item.(string)
+			if !isString {
+				return nil, true, InvalidArgument(fmt.Sprintf("%s[%d]", key, i), "expected string, got %s", typeName(item))
+			}
+			result = append(result, s)
+		}
+		return result, true, nil
+	default:
+		return nil, true, InvalidArgument(key, "expected array, got %s", typeName(raw))
+	}
+}
+
+// RequireString 读取必填字符串参数，缺失或为空时返回 MissingArgument 错误
+func (a Args) RequireString(key string) (string, error) {
+	v, ok, err := a.LookupString(key)
+	if err != nil {
+		return "", err
+	}
+	if !ok || v == "" {
+		return "", MissingArgument(key)
+	}
+	return v, nil
+}
+
+// toFloat 将数值类参数统一转换为 float64
+func toFloat(raw interface{}) (float64, error) {
+	switch v := raw.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint:
+		return float64(v), nil
+	case uint32:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("not a number: %T", raw)
+	}
+}
+
+// typeName 返回参数值对应的 JSON 类型名称，用于错误信息
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64, float32, int, int32, int64, uint, uint32, uint64, json.Number:
+		return "number"
+	case []interface{}, []string:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
@@ -0,0 +1,244 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+// ValidationError 参数校验失败，包含每个出错字段的错误
+type ValidationError struct {
+	Errors []*Error `json:"errors"`
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		parts[i] = fieldErr.Error()
+	}
+	return "validation failed: " + strings.Join(parts, "; ")
+}
+
+// Fields 返回出错的字段名
+func (e *ValidationError) Fields() []string {
+	fields := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		fields[i] = fieldErr.Field
+	}
+	return fields
+}
+
+// Bind 按 schema 校验并规范化参数
+// 缺失的字段填充 schema 中的默认值，数值和布尔值按声明类型转换（如 "3" -> 3），
+// 校验失败时返回 *ValidationError，列出全部出错字段；schema 未声明的字段原样保留
+func Bind(schema capability.Schema, values map[string]interface{}) (Args, error) {
+	b := &binder{}
+	result := b.object("", schema, values)
+	if len(b.errs) > 0 {
+		return nil, &ValidationError{Errors: b.errs}
+	}
+	return Args(result), nil
+}
+
+// BindInto 按 schema 校验参数后解码到结构体，结构体字段通过 json 标签与参数名对应
+func BindInto(schema capability.Schema, values map[string]interface{}, dst interface{}) error {
+	args, err := Bind(schema, values)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return Internal("encode arguments", err)
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return &ValidationError{Errors: []*Error{
+				InvalidArgument(typeErr.Field, "cannot bind %s to %s", typeErr.Value, typeErr.Type),
+			}}
+		}
+		return Internal("decode arguments", err)
+	}
+	return nil
+}
+
+// BindInputs 按能力定义的 InputSchema 绑定请求输入
+func (r *Request) BindInputs(def capability.Definition, dst interface{}) error {
+	return BindInto(def.InputSchema, r.Inputs, dst)
+}
+
+// BindConfig 按能力定义的 ConfigSchema 绑定请求配置
+func (r *Request) BindConfig(def capability.Definition, dst interface{}) error {
+	return BindInto(def.ConfigSchema, r.Config, dst)
+}
+
+// binder 递归校验参数并收集字段错误
+type binder struct {
+	errs []*Error
+}
+
+func (b *binder) fail(err *Error) {
+	b.errs = append(b.errs, err)
+}
+
+func (b *binder) object(path string, schema capability.Schema, values map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(values)+len(schema.Properties))
+	for key, value := range values {
+		result[key] = value
+	}
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	for name := range required {
+		if _, declared := schema.Properties[name]; !declared {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		prop := schema.Properties[name]
+		field := joinPath(path, name)
+		value, present := values[name]
+		if !present || value == nil {
+			if prop.Default != nil {
+				result[name] = prop.Default
+				continue
+			}
+			if required[name] {
+				b.fail(MissingArgument(field))
+			}
+			continue
+		}
+		if converted, ok := b.value(field, prop.Type, prop.Items, prop.Enum, value); ok {
+			result[name] = converted
+		}
+	}
+	return result
+}
+
+func (b *binder) value(field, typ string, items *capability.Schema, enum []interface{}, value interface{}) (interface{}, bool) {
+	converted, err := coerce(field, typ, value)
+	if err != nil {
+		b.fail(err)
+		return nil, false
+	}
+
+	switch v := converted.(type) {
+	case []interface{}:
+		if items != nil {
+			for i, item := range v {
+				itemField := fmt.Sprintf("%s[%d]", field, i)
+				if item == nil {
+					continue
+				}
+				if m, ok := item.(map[string]interface{}); ok && len(items.Properties) > 0 {
+					v[i] = b.object(itemField, *items, m)
+					continue
+				}
+				if convertedItem, ok := b.value(itemField, items.Type, nil, nil, item); ok {
+					v[i] = convertedItem
+				}
+			}
+		}
+	case map[string]interface{}:
+		if items != nil && len(items.Properties) > 0 {
+			converted = b.object(field, *items, v)
+		}
+	}
+
+	if len(enum) > 0 && !inEnum(enum, converted) {
+		b.fail(InvalidArgument(field, "must be one of %v", enum))
+		return nil, false
+	}
+	return converted, true
+}
+
+// coerce 将参数转换为 schema 声明的类型，未声明类型时原样返回
+func coerce(field, typ string, value interface{}) (interface{}, *Error) {
+	switch typ {
+	case "", "any":
+		return value, nil
+	case "string":
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+	case "number":
+		if f, err := toFloat(value); err == nil && !math.IsNaN(f) && !math.IsInf(f, 0) {
+			return f, nil
+		}
+	case "integer":
+		if f, err := toFloat(value); err == nil {
+			if f == math.Trunc(f) && f <= math.MaxInt64 && f >= math.MinInt64 {
+				return int64(f), nil
+			}
+			return nil, InvalidArgument(field, "expected integer, got %v", value)
+		}
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if parsed, err := strconv.ParseBool(v); err == nil {
+				return parsed, nil
+			}
+		}
+	case "array":
+		switch v := value.(type) {
+		case []interface{}:
+			// 复制一份，避免修改调用方的切片
+			return append([]interface{}(nil), v...), nil
+		case []string:
+			result := make([]interface{}, len(v))
+			for i, s := range v {
+				result[i] = s
+			}
+			return result, nil
+		}
+	case "object":
+		if m, ok := value.(map[string]interface{}); ok {
+			return m, nil
+		}
+	default:
+		return value, nil
+	}
+	return nil, InvalidArgument(field, "expected %s, got %s", typ, typeName(value))
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, candidate := range enum {
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+		// 数值枚举可能以不同的数值类型声明
+		if a, err := toFloat(candidate); err == nil {
+			if b, err := toFloat(value); err == nil && a == b {
+				if _, isString := value.(string); !isString {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
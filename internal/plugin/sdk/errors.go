@@ -0,0 +1,87 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Code 插件错误码
+type Code string
+
+const (
+	CodeInvalidArgument Code = "invalid_argument" // 参数类型或取值错误
+	CodeMissingArgument Code = "missing_argument" // 缺少必填参数
+	CodeUnsupported     Code = "unsupported"      // 能力或操作不支持
+	CodeUnavailable     Code = "unavailable"      // 上游服务不可用，可重试
+	CodeInternal        Code = "internal"         // 插件内部错误
+)
+
+// Error 插件返回的结构化错误
+// Field 指向出错的参数，便于调用方定位；Cause 保留原始错误
+type Error struct {
+	Code    Code   `json:"code"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+	Cause   error  `json:"-"`
+}
+
+func (e *Error) Error() string {
+	msg := string(e.Code)
+	if e.Field != "" {
+		msg += " " + e.Field
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.Cause != nil {
+		msg += ": " + e.Cause.Error()
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// InvalidArgument 参数类型或取值错误
+func InvalidArgument(field, format string, args ...interface{}) *Error {
+	return &Error{Code: CodeInvalidArgument, Field: field, Message: fmt.Sprintf(format, args...)}
+}
+
+// MissingArgument 缺少必填参数
+func MissingArgument(field string) *Error {
+	return &Error{Code: CodeMissingArgument, Field: field, Message: "is required"}
+}
+
+// Unsupported 能力或操作不支持
+func Unsupported(format string, args ...interface{}) *Error {
+	return &Error{Code: CodeUnsupported, Message: fmt.Sprintf(format, args...)}
+}
+
+// Unavailable 上游服务不可用
+func Unavailable(message string, cause error) *Error {
+	return &Error{Code: CodeUnavailable, Message: message, Cause: cause}
+}
+
+// Internal 插件内部错误
+func Internal(message string, cause error) *Error {
+	return &Error{Code: CodeInternal, Message: message, Cause: cause}
+}
+
+// CodeOf 返回错误链中的插件错误码，非插件错误返回 CodeInternal
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+	var typed *Error
+	if errors.As(err, &typed) {
+		return typed.Code
+	}
+	return CodeInternal
+}
+
+// IsCode 判断错误链中是否包含指定错误码
+func IsCode(err error, code Code) bool {
+	var typed *Error
+	return errors.As(err, &typed) && typed.Code == code
+}
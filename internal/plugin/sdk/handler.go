@@ -0,0 +1,143 @@
+// Package sdk 插件开发工具包
+// 提供区分请求与结果的能力调用类型、带默认值的参数读取方法和结构化错误，
+// 插件通过 NewExecutor/NewStreamExecutor 将处理函数适配为 capability.Executor
+package sdk
+
+import (
+	"context"
+
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+// Request 能力调用请求
+// Config 为能力的静态配置（API Key、模型等），Inputs 为本次调用的运行时输入
+type Request struct {
+	CapabilityID string
+	Config       Args
+	Inputs       Args
+}
+
+// Response 能力调用结果
+type Response struct {
+	Outputs map[string]interface{}
+}
+
+// NewResponse 创建空的调用结果
+func NewResponse() *Response {
+	return &Response{Outputs: make(map[string]interface{})}
+}
+
+// Set 设置输出字段，返回自身便于链式调用
+func (r *Response) Set(key string, value interface{}) *Response {
+	if r.Outputs == nil {
+		r.Outputs = make(map[string]interface{})
+	}
+	r.Outputs[key] = value
+	return r
+}
+
+// Handler 处理单次能力调用
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// StreamHandler 处理流式能力调用，通过 send 逐条发送输出，返回前必须停止调用 send
+type StreamHandler func(ctx context.Context, req *Request, send func(*Response) error) error
+
+// NewExecutor 将 Handler 适配为 capability.Executor
+func NewExecutor(capabilityID string, handler Handler) capability.Executor {
+	return &handlerExecutor{capabilityID: capabilityID, handler: handler}
+}
+
+// NewBoundExecutor 按能力定义创建执行器，调用处理函数前先用 Bind 校验配置和输入，
+// 处理函数拿到的 Config/Inputs 已填充默认值并完成类型转换；stream 为 nil 时不支持流式调用
+func NewBoundExecutor(def capability.Definition, handler Handler, stream StreamHandler) capability.StreamExecutor {
+	return &streamExecutor{
+		handlerExecutor: handlerExecutor{capabilityID: def.ID, handler: handler, def: &def},
+		stream:          stream,
+	}
+}
+
+// NewStreamExecutor 将 StreamHandler 适配为 capability.StreamExecutor
+// handler 为 nil 时 Execute 返回 Unsupported 错误
+func NewStreamExecutor(capabilityID string, handler Handler, stream StreamHandler) capability.StreamExecutor {
+	return &streamExecutor{
+		handlerExecutor: handlerExecutor{capabilityID: capabilityID, handler: handler},
+		stream:          stream,
+	}
+}
+
+type handlerExecutor struct {
+	capabilityID string
+	handler      Handler
+	def          *capability.Definition // 非空时按定义校验参数
+}
+
+func (e *handlerExecutor) request(config, inputs map[string]interface{}) (*Request, error) {
+	req := &Request{CapabilityID: e.capabilityID, Config: Args(config), Inputs: Args(inputs)}
+	if e.def == nil {
+		return req, nil
+	}
+	var err error
+	if req.Config, err = Bind(e.def.ConfigSchema, config); err != nil {
+		return nil, err
+	}
+	if req.Inputs, err = Bind(e.def.InputSchema, inputs); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (e *handlerExecutor) Execute(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (map[string]interface{}, error) {
+	if e.handler == nil {
+		return nil, Unsupported("%s does not support non-streaming execution", e.capabilityID)
+	}
+	req, err := e.request(config, inputs)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := e.handler(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return map[string]interface{}{}, nil
+	}
+	return resp.Outputs, nil
+}
+
+type streamExecutor struct {
+	handlerExecutor
+	stream StreamHandler
+}
+
+func (e *streamExecutor) ExecuteStream(ctx context.Context, config map[string]interface{}, inputs map[string]interface{}) (<-chan map[string]interface{}, error) {
+	if e.stream == nil {
+		return nil, Unsupported("%s does not support streaming execution", e.capabilityID)
+	}
+
+	req, err := e.request(config, inputs)
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan map[string]interface{})
+	go func() {
+		defer close(out)
+		err := e.stream(ctx, req, func(resp *Response) error {
+			if resp == nil {
+				return nil
+			}
+			select {
+			case out <- resp.Outputs:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil && ctx.Err() == nil {
+			select {
+			case out <- map[string]interface{}{"error": err.Error(), "error_code": string(CodeOf(err))}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,154 @@
+package sdk
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 指标类型
+const (
+	MetricCounter   = "counter"
+	MetricHistogram = "histogram"
+)
+
+// DefaultBuckets 直方图默认分桶（秒），与 Prometheus 客户端的默认值一致
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+var (
+	metricsMu sync.Mutex
+	metrics   = make(map[string]*Metrics)
+)
+
+// PluginMetrics 返回插件的指标，同一插件ID返回同一实例
+// 核心通过 ScrapeMetrics 定期采集，汇总到 Prometheus 指标时加上 plugin_id 标签，插件不需要自己加
+func PluginMetrics(pluginID string) *Metrics {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+	m, ok := metrics[pluginID]
+	if !ok {
+		m = &Metrics{series: make(map[string]*Sample), types: make(map[string]string)}
+		metrics[pluginID] = m
+	}
+	return m
+}
+
+// Metrics 插件进程内的计数器和直方图
+type Metrics struct {
+	mu     sync.Mutex
+	series map[string]*Sample // key 为指标名和排序后的标签
+	types  map[string]string  // 指标名对应的类型，同名指标只能是一种类型
+	help   map[string]string
+}
+
+// Sample 指标序列的当前值
+type Sample struct {
+	Name   string
+	Type   string // counter/histogram
+	Help   string
+	Labels map[string]string
+	Value  float64 // counter 的累计值
+
+	Buckets []float64 // histogram 的分桶上界，不含 +Inf
+	Counts  []uint64  // 不大于对应上界的累计观测次数
+	Count   uint64
+	Sum     float64
+}
+
+// Describe 设置指标的说明，输出为 Prometheus 的 HELP
+func (m *Metrics) Describe(name, help string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.help == nil {
+		m.help = make(map[string]string)
+	}
+	m.help[name] = help
+}
+
+// IncrementCounter 累加计数器，delta 为负数时忽略
+func (m *Metrics) IncrementCounter(name string, labels map[string]string, delta float64) {
+	if delta < 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s := m.sample(name, MetricCounter, labels); s != nil {
+		s.Value += delta
+	}
+}
+
+// RecordHistogram 在直方图中记录一次观测，按 DefaultBuckets 分桶
+func (m *Metrics) RecordHistogram(name string, labels map[string]string, value float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.sample(name, MetricHistogram, labels)
+	if s == nil {
+		return
+	}
+	for i, bound := range s.Buckets {
+		if value <= bound {
+			s.Counts[i]++
+		}
+	}
+	s.Count++
+	s.Sum += value
+}
+
+// sample 返回指标序列，不存在时创建；名称已用于其它类型的指标时返回 nil
+func (m *Metrics) sample(name, kind string, labels map[string]string) *Sample {
+	if existing, ok := m.types[name]; ok && existing != kind {
+		return nil
+	}
+	m.types[name] = kind
+
+	key := seriesKey(name, labels)
+	s, ok := m.series[key]
+	if !ok {
+		s = &Sample{Name: name, Type: kind, Labels: make(map[string]string, len(labels))}
+		for k, v := range labels {
+			s.Labels[k] = v
+		}
+		if kind == MetricHistogram {
+			s.Buckets = DefaultBuckets
+			s.Counts = make([]uint64, len(DefaultBuckets))
+		}
+		m.series[key] = s
+	}
+	return s
+}
+
+// Snapshot 返回全部指标序列的副本，按指标名和标签排序
+func (m *Metrics) Snapshot() []Sample {
+	m.mu.Lock()
+	keys := make([]string, 0, len(m.series))
+	for key := range m.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	out := make([]Sample, len(keys))
+	for i, key := range keys {
+		s := *m.series[key]
+		s.Help = m.help[s.Name]
+		s.Counts = append([]uint64(nil), s.Counts...)
+		out[i] = s
+	}
+	m.mu.Unlock()
+	return out
+}
+
+func seriesKey(name string, labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for k := range labels {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	b.WriteString(name)
+	for _, k := range names {
+		b.WriteByte(0xff)
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(labels[k])
+	}
+	return b.String()
+}
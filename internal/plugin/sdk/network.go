@@ -0,0 +1,11 @@
+package sdk
+
+import "xiaozhi-server-go/internal/plugin/capability"
+
+// NetworkProperty 能力配置中的网络参数，使用连接池客户端（httpclient.Pool.ClientFor）的提供者在 ConfigSchema 中声明为 "network"，
+// 覆盖 HTTPClient.Providers 中该提供者的代理、端点改写和CA证书
+var NetworkProperty = capability.Property{
+	Type: "object",
+	Description: `Network overrides: {"proxy": "http(s)://, socks5:// or socks5h:// proxy URL", ` +
+		`"endpoints": {"<original host or *>": "https://regional-host[:port]"}, "ca_file": "extra CA bundle (PEM)"}`,
+}
@@ -0,0 +1,11 @@
+package sdk
+
+import "xiaozhi-server-go/internal/plugin/capability"
+
+// ResponseFormatProperty LLM 能力输入中的结构化输出要求，支持的提供者在 InputSchema 中声明为 "response_format"，
+// 格式见 capability.ParseResponseFormat；调用方使用 capability.RunStructured 校验输出并修复重试
+var ResponseFormatProperty = capability.Property{
+	Type: "object",
+	Description: `Structured output: {"type": "json_object"} or ` +
+		`{"type": "json_schema", "name": "...", "schema": {<JSON Schema>}, "strict": false}`,
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"xiaozhi-server-go/internal/domain/eventbus"
 	"xiaozhi-server-go/internal/plugin/capability"
 	"xiaozhi-server-go/internal/plugin/ports"
 	"xiaozhi-server-go/internal/platform/logging"
@@ -125,6 +126,7 @@ func (psm *PluginStatusManager) StartPluginWithConfig(pluginID string, config ma
 		plugin.Status = StatusError
 		plugin.Error = fmt.Sprintf("端口分配失败: %v", err)
 		plugin.UpdatedAt = time.Now()
+		publishPluginCrashed(plugin, plugin.Error)
 		return fmt.Errorf("failed to allocate port for plugin %s: %w", pluginID, err)
 	}
 
@@ -227,6 +229,7 @@ func (psm *PluginStatusManager) ReallocatePort(pluginID string) error {
 		plugin.Status = StatusError
 		plugin.Error = fmt.Sprintf("端口重新分配失败: %v", err)
 		plugin.UpdatedAt = time.Now()
+		publishPluginCrashed(plugin, plugin.Error)
 		return fmt.Errorf("failed to reallocate port for plugin %s: %w", pluginID, err)
 	}
 
@@ -256,6 +259,7 @@ func (psm *PluginStatusManager) UpdatePluginHealth(pluginID string, status Healt
 		return
 	}
 
+	wasUnhealthy := plugin.HealthStatus == HealthStatusUnhealthy
 	plugin.HealthStatus = status
 	plugin.LastHealthCheck = time.Now()
 	plugin.UpdatedAt = time.Now()
@@ -272,6 +276,23 @@ func (psm *PluginStatusManager) UpdatePluginHealth(pluginID string, status Healt
 			"health_status", status,
 			"details", details)
 	}
+
+	if status == HealthStatusUnhealthy && !wasUnhealthy {
+		publishPluginCrashed(plugin, "health check failed: "+details)
+	}
+}
+
+// publishPluginCrashed 发布插件异常告警
+func publishPluginCrashed(plugin *PluginStatus, reason string) {
+	eventbus.Publish(eventbus.EventAlertPluginCrashed, eventbus.AlertEventData{
+		Target:  plugin.ID,
+		Message: reason,
+		Data: map[string]interface{}{
+			"plugin_name": plugin.Name,
+			"status":      string(plugin.Status),
+		},
+		Timestamp: time.Now(),
+	})
 }
 
 // GetPluginStatus 获取插件状态
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/plugin/ports"
 )
 
 // HealthChecker 健康检查器
@@ -69,6 +70,16 @@ func (hc *HealthChecker) performHealthCheck(manager *PluginStatusManager) {
 
 // checkPluginHealth 检查单个插件健康状态
 func (hc *HealthChecker) checkPluginHealth(manager *PluginStatusManager, plugin PluginStatus) {
+	// 监听Unix域套接字的插件检查套接字是否可连接
+	if ports.IsUnixAddress(plugin.Address) {
+		if hc.checkUnixSocket(ports.SocketPath(plugin.Address)) {
+			manager.UpdatePluginHealth(plugin.ID, HealthStatusHealthy, "套接字连接正常")
+		} else {
+			manager.UpdatePluginHealth(plugin.ID, HealthStatusUnhealthy, "套接字连接失败")
+		}
+		return
+	}
+
 	// 简单的健康检查逻辑
 	// 检查端口是否可访问
 	if plugin.Port > 0 && plugin.Address != "" {
@@ -99,4 +110,14 @@ func (hc *HealthChecker) checkTCPPort(port int) bool {
 	}
 	defer conn.Close()
 	return true
-}
\ No newline at end of file
+}
+
+// checkUnixSocket 检查Unix域套接字是否可访问
+func (hc *HealthChecker) checkUnixSocket(path string) bool {
+	conn, err := net.DialTimeout("unix", path, 3*time.Second)
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+	return true
+}
@@ -0,0 +1,71 @@
+package graphqltransport
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchFunc 一次加载多个键，返回结果中缺失的键视为空值
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader 单次请求内的批量加载器
+// Load 只登记键并返回延迟求值函数；GraphQL 执行器在同一层字段全部解析后才调用这些函数，
+// 第一次调用时把已登记的键合并成一次批量查询，结果在请求内缓存
+type Loader[K comparable, V any] struct {
+	batch BatchFunc[K, V]
+
+	mu      sync.Mutex
+	pending []K
+	results map[K]*loadResult[V]
+}
+
+type loadResult[V any] struct {
+	value V
+	err   error
+}
+
+// NewLoader 创建批量加载器，每个请求使用独立的实例
+func NewLoader[K comparable, V any](batch BatchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		batch:   batch,
+		results: make(map[K]*loadResult[V]),
+	}
+}
+
+// Load 登记键，返回的函数符合 graphql-go 延迟解析的签名
+func (l *Loader[K, V]) Load(ctx context.Context, key K) func() (interface{}, error) {
+	l.mu.Lock()
+	if _, ok := l.results[key]; !ok {
+		l.results[key] = nil
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.dispatch(ctx)
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		result := l.results[key]
+		return result.value, result.err
+	}
+}
+
+// dispatch 批量加载尚未加载的键
+func (l *Loader[K, V]) dispatch(ctx context.Context) {
+	l.mu.Lock()
+	keys := l.pending
+	l.pending = nil
+	l.mu.Unlock()
+	if len(keys) == 0 {
+		return
+	}
+
+	values, err := l.batch(ctx, keys)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		l.results[key] = &loadResult[V]{value: values[key], err: err}
+	}
+}
@@ -0,0 +1,499 @@
+package graphqltransport
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"gorm.io/gorm"
+
+	"xiaozhi-server-go/internal/domain/device/aggregate"
+	"xiaozhi-server-go/internal/domain/device/repository"
+	pluginconfig "xiaozhi-server-go/internal/domain/plugin/config"
+	"xiaozhi-server-go/internal/plugin/status"
+	"xiaozhi-server-go/internal/workflow"
+)
+
+const maxListLimit = 200
+
+// Sources GraphQL 查询读取的数据来源，未设置的来源在查询时返回错误
+type Sources struct {
+	Devices  repository.DeviceRepository
+	Plugins  *status.PluginStatusManager
+	Executor workflow.WorkflowExecutor
+	DB       *gorm.DB // 读取插件配额用量
+}
+
+// usageKey 用量加载器的键，按日期分组后批量查询
+type usageKey struct {
+	day    string
+	target string
+}
+
+// loaders 单次请求的批量加载器
+type loaders struct {
+	plugins *Loader[string, *status.PluginStatus]
+	usage   *Loader[usageKey, []pluginconfig.QuotaUsage]
+}
+
+type loadersKey struct{}
+
+func newLoaders(src Sources) *loaders {
+	return &loaders{
+		plugins: NewLoader(func(ctx context.Context, ids []string) (map[string]*status.PluginStatus, error) {
+			if src.Plugins == nil {
+				return nil, fmt.Errorf("plugin status manager not initialized")
+			}
+			plugins := make(map[string]*status.PluginStatus, len(ids))
+			for _, id := range ids {
+				if plugin, err := src.Plugins.GetPluginStatus(id); err == nil {
+					plugins[id] = plugin
+				}
+			}
+			return plugins, nil
+		}),
+		usage: NewLoader(func(ctx context.Context, keys []usageKey) (map[usageKey][]pluginconfig.QuotaUsage, error) {
+			if src.DB == nil {
+				return nil, fmt.Errorf("database not initialized")
+			}
+			targetsByDay := make(map[string][]string)
+			for _, key := range keys {
+				targetsByDay[key.day] = append(targetsByDay[key.day], key.target)
+			}
+			usage := make(map[usageKey][]pluginconfig.QuotaUsage, len(keys))
+			for day, targets := range targetsByDay {
+				rows, err := pluginconfig.QueryQuotaUsage(ctx, src.DB, day, targets)
+				if err != nil {
+					return nil, err
+				}
+				for _, row := range rows {
+					key := usageKey{day: day, target: row.Target}
+					usage[key] = append(usage[key], row)
+				}
+			}
+			return usage, nil
+		}),
+	}
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	l, _ := ctx.Value(loadersKey{}).(*loaders)
+	return l
+}
+
+// newSchema 构建只读查询的 GraphQL schema
+func newSchema(src Sources) (graphql.Schema, error) {
+	quotaUsageType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "QuotaUsage",
+		Description: "插件或能力的每日配额用量",
+		Fields: graphql.Fields{
+			"scope":        &graphql.Field{Type: graphql.String},
+			"target":       &graphql.Field{Type: graphql.String},
+			"day":          &graphql.Field{Type: graphql.String},
+			"requests":     &graphql.Field{Type: graphql.Float},
+			"tokens":       &graphql.Field{Type: graphql.Float},
+			"audioSeconds": &graphql.Field{Type: graphql.Float},
+		},
+	})
+
+	capabilityType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Capability",
+		Fields: graphql.Fields{
+			"id":          &graphql.Field{Type: graphql.String},
+			"type":        &graphql.Field{Type: graphql.String},
+			"name":        &graphql.Field{Type: graphql.String},
+			"description": &graphql.Field{Type: graphql.String},
+			"enabled":     &graphql.Field{Type: graphql.Boolean},
+		},
+	})
+
+	pluginType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Plugin",
+		Fields: graphql.Fields{
+			"id":              &graphql.Field{Type: graphql.String},
+			"name":            &graphql.Field{Type: graphql.String},
+			"type":            &graphql.Field{Type: graphql.String},
+			"description":     &graphql.Field{Type: graphql.String},
+			"version":         &graphql.Field{Type: graphql.String},
+			"status":          &graphql.Field{Type: graphql.String},
+			"healthStatus":    &graphql.Field{Type: graphql.String},
+			"address":         &graphql.Field{Type: graphql.String},
+			"port":            &graphql.Field{Type: graphql.Int},
+			"error":           &graphql.Field{Type: graphql.String},
+			"lastHealthCheck": timeField(),
+			"capabilities":    &graphql.Field{Type: graphql.NewList(capabilityType)},
+			"usage": &graphql.Field{
+				Type:        graphql.NewList(quotaUsageType),
+				Description: "插件及其能力在指定日期（YYYY-MM-DD，缺省为今天）的配额用量",
+				Args: graphql.FieldConfigArgument{
+					"day": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					plugin, _ := p.Source.(*status.PluginStatus)
+					if plugin == nil {
+						return nil, nil
+					}
+					day, _ := p.Args["day"].(string)
+					if day == "" {
+						day = time.Now().Format("2006-01-02")
+					}
+					l := loadersFrom(p.Context)
+					thunks := []func() (interface{}, error){l.usage.Load(p.Context, usageKey{day: day, target: plugin.ID})}
+					for _, c := range plugin.Capabilities {
+						thunks = append(thunks, l.usage.Load(p.Context, usageKey{day: day, target: c.ID}))
+					}
+					return func() (interface{}, error) {
+						usage := make([]pluginconfig.QuotaUsage, 0)
+						for _, thunk := range thunks {
+							rows, err := thunk()
+							if err != nil {
+								return nil, err
+							}
+							usage = append(usage, rows.([]pluginconfig.QuotaUsage)...)
+						}
+						return usage, nil
+					}, nil
+				},
+			},
+		},
+	})
+
+	deviceType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Device",
+		Fields: graphql.Fields{
+			"id":             &graphql.Field{Type: graphql.Int},
+			"deviceId":       &graphql.Field{Type: graphql.String},
+			"clientId":       &graphql.Field{Type: graphql.String},
+			"name":           &graphql.Field{Type: graphql.String},
+			"version":        &graphql.Field{Type: graphql.String},
+			"boardType":      &graphql.Field{Type: graphql.String},
+			"chipModelName":  &graphql.Field{Type: graphql.String},
+			"authStatus":     &graphql.Field{Type: graphql.String},
+			"online":         &graphql.Field{Type: graphql.Boolean},
+			"ota":            &graphql.Field{Type: graphql.Boolean},
+			"lastIp":         &graphql.Field{Type: graphql.String},
+			"userId":         &graphql.Field{Type: graphql.Int},
+			"totalTokens":    &graphql.Field{Type: graphql.Float},
+			"usedTokens":     &graphql.Field{Type: graphql.Float},
+			"registerTime":   timeField(),
+			"lastActiveTime": timeField(),
+		},
+	})
+
+	workflowNodeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "WorkflowNode",
+		Fields: graphql.Fields{
+			"id":          &graphql.Field{Type: graphql.String},
+			"name":        &graphql.Field{Type: graphql.String},
+			"type":        &graphql.Field{Type: graphql.String},
+			"description": &graphql.Field{Type: graphql.String},
+			"method":      &graphql.Field{Type: graphql.String},
+			"pluginId": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(workflow.Node).Plugin, nil
+				},
+			},
+			"plugin": &graphql.Field{
+				Type:        pluginType,
+				Description: "节点关联的插件，未关联或插件未注册时为空",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					node := p.Source.(workflow.Node)
+					if node.Plugin == "" {
+						return nil, nil
+					}
+					return loadersFrom(p.Context).plugins.Load(p.Context, node.Plugin), nil
+				},
+			},
+		},
+	})
+
+	workflowType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Workflow",
+		Fields: graphql.Fields{
+			"id":          &graphql.Field{Type: graphql.String},
+			"name":        &graphql.Field{Type: graphql.String},
+			"description": &graphql.Field{Type: graphql.String},
+			"version":     &graphql.Field{Type: graphql.String},
+			"nodes":       &graphql.Field{Type: graphql.NewList(workflowNodeType)},
+			"updatedAt":   timeField(),
+		},
+	})
+
+	executionNodeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ExecutionNode",
+		Fields: graphql.Fields{
+			"nodeId":    &graphql.Field{Type: graphql.String},
+			"status":    &graphql.Field{Type: graphql.String},
+			"error":     &graphql.Field{Type: graphql.String},
+			"attempts":  &graphql.Field{Type: graphql.Int},
+			"startTime": timeField(),
+			"endTime":   timeField(),
+			"elapsedMs": &graphql.Field{
+				Type: graphql.Float,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return milliseconds(p.Source.(*workflow.NodeResult).ElapsedTime), nil
+				},
+			},
+		},
+	})
+
+	executionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Execution",
+		Fields: graphql.Fields{
+			"id":         &graphql.Field{Type: graphql.String},
+			"workflowId": &graphql.Field{Type: graphql.String},
+			"status":     &graphql.Field{Type: graphql.String},
+			"error":      &graphql.Field{Type: graphql.String},
+			"startTime":  timeField(),
+			"endTime":    timeField(),
+			"durationMs": &graphql.Field{
+				Type:        graphql.Float,
+				Description: "执行耗时，未结束时为空",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					execution := p.Source.(*workflow.Execution)
+					if execution.EndTime == nil {
+						return nil, nil
+					}
+					return milliseconds(execution.EndTime.Sub(execution.StartTime)), nil
+				},
+			},
+			"nodes": &graphql.Field{
+				Type:        graphql.NewList(executionNodeType),
+				Description: "节点执行结果，按开始时间排序",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					execution := p.Source.(*workflow.Execution)
+					nodes := make([]*workflow.NodeResult, 0, len(execution.NodeResults))
+					for _, result := range execution.NodeResults {
+						nodes = append(nodes, result)
+					}
+					sort.Slice(nodes, func(i, j int) bool { return nodes[i].StartTime.Before(nodes[j].StartTime) })
+					return nodes, nil
+				},
+			},
+		},
+	})
+
+	limitArg := func(defaultValue int) *graphql.ArgumentConfig {
+		return &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultValue, Description: fmt.Sprintf("最多返回条数，上限 %d", maxListLimit)}
+	}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"devices": &graphql.Field{
+				Type: graphql.NewList(deviceType),
+				Args: graphql.FieldConfigArgument{
+					"limit":  limitArg(50),
+					"offset": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+					"online": &graphql.ArgumentConfig{Type: graphql.Boolean},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if src.Devices == nil {
+						return nil, fmt.Errorf("device repository not initialized")
+					}
+					devices, err := src.Devices.FindAll(p.Context)
+					if err != nil {
+						return nil, err
+					}
+					if online, ok := p.Args["online"].(bool); ok {
+						filtered := make([]*aggregate.Device, 0, len(devices))
+						for _, device := range devices {
+							if device.Online == online {
+								filtered = append(filtered, device)
+							}
+						}
+						devices = filtered
+					}
+					return window(devices, intArg(p, "offset"), limitValue(p)), nil
+				},
+			},
+			"device": &graphql.Field{
+				Type: deviceType,
+				Args: graphql.FieldConfigArgument{
+					"deviceId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if src.Devices == nil {
+						return nil, fmt.Errorf("device repository not initialized")
+					}
+					return src.Devices.FindByDeviceID(p.Context, p.Args["deviceId"].(string))
+				},
+			},
+			"plugins": &graphql.Field{
+				Type: graphql.NewList(pluginType),
+				Args: graphql.FieldConfigArgument{
+					"type":         &graphql.ArgumentConfig{Type: graphql.String},
+					"status":       &graphql.ArgumentConfig{Type: graphql.String},
+					"healthStatus": &graphql.ArgumentConfig{Type: graphql.String},
+					"search":       &graphql.ArgumentConfig{Type: graphql.String},
+					"limit":        limitArg(100),
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if src.Plugins == nil {
+						return nil, fmt.Errorf("plugin status manager not initialized")
+					}
+					filter := status.PluginFilter{Page: 1, PageSize: limitValue(p)}
+					filter.Type, _ = p.Args["type"].(string)
+					filter.Search, _ = p.Args["search"].(string)
+					if v, ok := p.Args["status"].(string); ok {
+						filter.Status = status.PluginStatusType(v)
+					}
+					if v, ok := p.Args["healthStatus"].(string); ok {
+						filter.HealthStatus = status.HealthStatus(v)
+					}
+					result, err := src.Plugins.ListPlugins(filter)
+					if err != nil {
+						return nil, err
+					}
+					plugins := make([]*status.PluginStatus, len(result.Plugins))
+					for i := range result.Plugins {
+						plugins[i] = &result.Plugins[i]
+					}
+					return plugins, nil
+				},
+			},
+			"plugin": &graphql.Field{
+				Type: pluginType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).plugins.Load(p.Context, p.Args["id"].(string)), nil
+				},
+			},
+			"currentWorkflow": &graphql.Field{
+				Type: workflowType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return workflow.LoadCurrentWorkflow()
+				},
+			},
+			"executions": &graphql.Field{
+				Type:        graphql.NewList(executionType),
+				Description: "已结束并保存的执行记录，新的在前",
+				Args: graphql.FieldConfigArgument{
+					"limit":      limitArg(20),
+					"workflowId": &graphql.ArgumentConfig{Type: graphql.String},
+					"status":     &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					workflowID, _ := p.Args["workflowId"].(string)
+					state, _ := p.Args["status"].(string)
+					if workflowID == "" && state == "" {
+						return workflow.ListExecutions(limitValue(p))
+					}
+					executions, err := workflow.ListExecutions(0)
+					if err != nil {
+						return nil, err
+					}
+					filtered := make([]*workflow.Execution, 0, len(executions))
+					for _, execution := range executions {
+						if (workflowID == "" || execution.WorkflowID == workflowID) && (state == "" || string(execution.Status) == state) {
+							filtered = append(filtered, execution)
+						}
+					}
+					return window(filtered, 0, limitValue(p)), nil
+				},
+			},
+			"execution": &graphql.Field{
+				Type:        executionType,
+				Description: "执行记录，包括仍在运行的执行",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id := p.Args["id"].(string)
+					if src.Executor != nil {
+						if execution, ok := src.Executor.GetExecution(id); ok {
+							return execution, nil
+						}
+					}
+					execution, err := workflow.LoadExecution(id)
+					if err != nil {
+						return nil, nil
+					}
+					return execution, nil
+				},
+			},
+			"usage": &graphql.Field{
+				Type:        graphql.NewList(quotaUsageType),
+				Description: "指定日期（YYYY-MM-DD，缺省为今天）的全部配额用量",
+				Args: graphql.FieldConfigArgument{
+					"day": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if src.DB == nil {
+						return nil, fmt.Errorf("database not initialized")
+					}
+					day, _ := p.Args["day"].(string)
+					return pluginconfig.QueryQuotaUsage(p.Context, src.DB, day, nil)
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+// timeField 时间字段，零值返回空
+func timeField() *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.DateTime,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			value, err := graphql.DefaultResolveFn(p)
+			if err != nil {
+				return nil, err
+			}
+			switch t := value.(type) {
+			case time.Time:
+				if t.IsZero() {
+					return nil, nil
+				}
+			case *time.Time:
+				if t == nil || t.IsZero() {
+					return nil, nil
+				}
+			}
+			return value, nil
+		},
+	}
+}
+
+func intArg(p graphql.ResolveParams, name string) int {
+	value, _ := p.Args[name].(int)
+	return value
+}
+
+// limitValue 读取 limit 参数，限制在 1..maxListLimit
+func limitValue(p graphql.ResolveParams) int {
+	limit := intArg(p, "limit")
+	if limit <= 0 || limit > maxListLimit {
+		return maxListLimit
+	}
+	return limit
+}
+
+// window 按偏移和条数截取列表
+func window[T any](items []T, offset, limit int) []T {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(items) {
+		offset = len(items)
+	}
+	end := offset + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[offset:end]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
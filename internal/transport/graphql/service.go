@@ -0,0 +1,98 @@
+package graphqltransport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+
+	"xiaozhi-server-go/internal/platform/logging"
+)
+
+// Service 只读 GraphQL 查询接口
+// 控制台通过一次请求获取插件、设备、工作流、执行记录和用量的组合视图；
+// 每个请求使用独立的批量加载器，同一层级引用的插件和用量合并为一次查询
+type Service struct {
+	logger  *logging.Logger
+	schema  graphql.Schema
+	sources Sources
+}
+
+// Request GraphQL 请求体
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// NewService 创建 GraphQL 查询服务
+func NewService(logger *logging.Logger, sources Sources) (*Service, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	schema, err := newSchema(sources)
+	if err != nil {
+		return nil, fmt.Errorf("build graphql schema: %w", err)
+	}
+	return &Service{
+		logger:  logger,
+		schema:  schema,
+		sources: sources,
+	}, nil
+}
+
+// Register 注册 GraphQL 路由
+func (s *Service) Register(router *gin.RouterGroup) {
+	router.POST("/graphql", s.query) // 执行查询
+	router.GET("/graphql", s.query)  // 以 query 参数执行查询
+}
+
+// query 执行 GraphQL 查询
+// @Summary GraphQL 查询
+// @Description 只读查询设备、插件、当前工作流、执行记录和配额用量；响应为标准 GraphQL 结果 {data, errors}
+// @Tags GraphQL
+// @Accept json
+// @Produce json
+// @Param request body graphqltransport.Request true "GraphQL 请求"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /v1/graphql [post]
+func (s *Service) query(c *gin.Context) {
+	var request Request
+	if c.Request.Method == http.MethodGet {
+		request.Query = c.Query("query")
+		request.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				respondError(c, "variables 必须是 JSON 对象")
+				return
+			}
+		}
+	} else if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, "请求体格式错误: "+err.Error())
+		return
+	}
+	if request.Query == "" {
+		respondError(c, "query 不能为空")
+		return
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         s.schema,
+		RequestString:  request.Query,
+		VariableValues: request.Variables,
+		OperationName:  request.OperationName,
+		Context:        withLoaders(c.Request.Context(), newLoaders(s.sources)),
+	})
+	if result.HasErrors() {
+		s.logger.DebugTag("GraphQL", "查询返回错误: %v", result.Errors)
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// respondError 以 GraphQL 错误格式返回请求错误
+func respondError(c *gin.Context, message string) {
+	c.JSON(http.StatusBadRequest, gin.H{"errors": []gin.H{{"message": message}}})
+}
@@ -0,0 +1,525 @@
+package grpctransport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	managementv1 "xiaozhi-server-go/gen/go/api/v1"
+	"xiaozhi-server-go/internal/domain/device/aggregate"
+	"xiaozhi-server-go/internal/domain/device/repository"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/requestid"
+	pluginstatus "xiaozhi-server-go/internal/plugin/status"
+	"xiaozhi-server-go/internal/workflow"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// ManagementServer 管理面gRPC服务实现，与 /api/v1 的HTTP接口操作同一组领域对象：
+// 设备仓库、插件状态管理器以及与工作流HTTP服务共享的执行器
+type ManagementServer struct {
+	devices  repository.DeviceRepository
+	plugins  *pluginstatus.PluginStatusManager
+	executor workflow.WorkflowExecutor
+	logger   *logging.Logger
+}
+
+// NewManagementServer 创建管理面服务；plugins 为空时插件相关调用返回 Unavailable
+func NewManagementServer(
+	devices repository.DeviceRepository,
+	plugins *pluginstatus.PluginStatusManager,
+	executor workflow.WorkflowExecutor,
+	logger *logging.Logger,
+) *ManagementServer {
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+	return &ManagementServer{
+		devices:  devices,
+		plugins:  plugins,
+		executor: executor,
+		logger:   logger,
+	}
+}
+
+// ListDevices 获取设备列表
+func (s *ManagementServer) ListDevices(ctx context.Context, req *managementv1.ListDevicesRequest) (*managementv1.ListDevicesResponse, error) {
+	devices, err := s.devices.FindAll(ctx)
+	if err != nil {
+		return nil, s.internal("获取设备列表失败", err)
+	}
+
+	page, pageSize := pagination(req.GetPage(), req.GetPageSize())
+	start := (page - 1) * pageSize
+	if start > len(devices) {
+		start = len(devices)
+	}
+	end := start + pageSize
+	if end > len(devices) {
+		end = len(devices)
+	}
+
+	items := make([]*managementv1.Device, 0, end-start)
+	for _, device := range devices[start:end] {
+		items = append(items, toDevice(device))
+	}
+	return &managementv1.ListDevicesResponse{
+		Devices:  items,
+		Total:    int32(len(devices)),
+		Page:     int32(page),
+		PageSize: int32(pageSize),
+	}, nil
+}
+
+// GetDevice 获取设备详情
+func (s *ManagementServer) GetDevice(ctx context.Context, req *managementv1.GetDeviceRequest) (*managementv1.Device, error) {
+	if req.GetDeviceId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "device_id is required")
+	}
+	device, err := s.devices.FindByDeviceID(ctx, req.GetDeviceId())
+	if err != nil {
+		return nil, s.internal("获取设备失败", err)
+	}
+	if device == nil {
+		return nil, status.Errorf(codes.NotFound, "device not found: %s", req.GetDeviceId())
+	}
+	return toDevice(device), nil
+}
+
+// ListPlugins 获取插件列表
+func (s *ManagementServer) ListPlugins(ctx context.Context, req *managementv1.ListPluginsRequest) (*managementv1.ListPluginsResponse, error) {
+	if s.plugins == nil {
+		return nil, status.Error(codes.Unavailable, "plugin status manager not initialized")
+	}
+	page, pageSize := pagination(req.GetPage(), req.GetPageSize())
+	result, err := s.plugins.ListPlugins(pluginstatus.PluginFilter{
+		Type:         req.GetType(),
+		Status:       pluginstatus.PluginStatusType(req.GetStatus()),
+		HealthStatus: pluginstatus.HealthStatus(req.GetHealthStatus()),
+		Search:       req.GetSearch(),
+		Page:         page,
+		PageSize:     pageSize,
+	})
+	if err != nil {
+		return nil, s.internal("获取插件列表失败", err)
+	}
+
+	items := make([]*managementv1.Plugin, 0, len(result.Plugins))
+	for i := range result.Plugins {
+		items = append(items, toPlugin(&result.Plugins[i]))
+	}
+	return &managementv1.ListPluginsResponse{
+		Plugins:    items,
+		Total:      int32(result.Total),
+		Page:       int32(result.Page),
+		PageSize:   int32(result.PageSize),
+		TotalPages: int32(result.TotalPages),
+	}, nil
+}
+
+// GetPlugin 获取插件详情
+func (s *ManagementServer) GetPlugin(ctx context.Context, req *managementv1.GetPluginRequest) (*managementv1.Plugin, error) {
+	if s.plugins == nil {
+		return nil, status.Error(codes.Unavailable, "plugin status manager not initialized")
+	}
+	plugin, err := s.plugins.GetPluginStatus(req.GetPluginId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "plugin not found: %s", req.GetPluginId())
+	}
+	return toPlugin(plugin), nil
+}
+
+// ControlPlugin 启动、停止、重启插件或重新分配端口
+func (s *ManagementServer) ControlPlugin(ctx context.Context, req *managementv1.ControlPluginRequest) (*managementv1.ControlPluginResponse, error) {
+	if s.plugins == nil {
+		return nil, status.Error(codes.Unavailable, "plugin status manager not initialized")
+	}
+	pluginID := req.GetPluginId()
+	current, err := s.plugins.GetPluginStatus(pluginID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "plugin not found: %s", pluginID)
+	}
+	oldStatus := string(current.Status)
+	oldPort := current.Port
+
+	startTime := time.Now()
+	switch req.GetAction() {
+	case "start":
+		if req.GetConfig() != nil {
+			err = s.plugins.StartPluginWithConfig(pluginID, req.GetConfig().AsMap())
+		} else {
+			err = s.plugins.StartPlugin(pluginID)
+		}
+	case "stop":
+		err = s.plugins.StopPlugin(pluginID)
+	case "restart":
+		err = s.plugins.RestartPlugin(pluginID)
+	case "reallocate_port":
+		err = s.plugins.ReallocatePort(pluginID)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported action: %s", req.GetAction())
+	}
+	if err != nil {
+		s.logger.With("request_id", requestid.FromContext(ctx)).ErrorTag("gRPC", "插件 %s 执行 %s 失败: %v", pluginID, req.GetAction(), err)
+		return nil, status.Errorf(codes.FailedPrecondition, "plugin control failed: %v", err)
+	}
+
+	response := &managementv1.ControlPluginResponse{
+		OldStatus:   oldStatus,
+		OldPort:     int32(oldPort),
+		ProcessTime: time.Since(startTime).String(),
+	}
+	if updated, err := s.plugins.GetPluginStatus(pluginID); err == nil {
+		response.NewStatus = string(updated.Status)
+		response.NewPort = int32(updated.Port)
+		response.Plugin = toPlugin(updated)
+	}
+	return response, nil
+}
+
+// GetCurrentWorkflow 获取当前工作流
+func (s *ManagementServer) GetCurrentWorkflow(ctx context.Context, req *managementv1.GetCurrentWorkflowRequest) (*managementv1.Workflow, error) {
+	wf, err := workflow.LoadCurrentWorkflow()
+	if err != nil {
+		return nil, s.internal("加载当前工作流失败", err)
+	}
+	return s.workflowMessage(wf)
+}
+
+// SaveWorkflow 校验并保存工作流
+func (s *ManagementServer) SaveWorkflow(ctx context.Context, req *managementv1.SaveWorkflowRequest) (*managementv1.Workflow, error) {
+	if req.GetWorkflow() == nil {
+		return nil, status.Error(codes.InvalidArgument, "workflow is required")
+	}
+	wf, err := fromWorkflow(req.GetWorkflow())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid workflow: %v", err)
+	}
+	if err := workflow.ValidateNodeExpressions(wf); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := workflow.SaveWorkflow(wf); err != nil {
+		return nil, s.internal("保存工作流失败", err)
+	}
+	return s.workflowMessage(wf)
+}
+
+// ListWorkflowTemplates 获取工作流模板列表
+func (s *ManagementServer) ListWorkflowTemplates(ctx context.Context, req *managementv1.ListWorkflowTemplatesRequest) (*managementv1.ListWorkflowTemplatesResponse, error) {
+	templates := workflow.DefaultTemplateRegistry().List()
+	items := make([]*managementv1.WorkflowTemplate, 0, len(templates))
+	for _, t := range templates {
+		item, err := toWorkflowTemplate(t)
+		if err != nil {
+			return nil, s.internal("转换工作流模板失败", err)
+		}
+		items = append(items, item)
+	}
+	return &managementv1.ListWorkflowTemplatesResponse{Templates: items}, nil
+}
+
+// DeployWorkflowTemplate 实例化模板并保存为当前工作流
+func (s *ManagementServer) DeployWorkflowTemplate(ctx context.Context, req *managementv1.DeployWorkflowTemplateRequest) (*managementv1.Workflow, error) {
+	wf, err := workflow.InstantiateTemplate(req.GetTemplateId(), req.GetParameters().AsMap())
+	if err != nil {
+		if errors.Is(err, workflow.ErrTemplateNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if !req.GetDryRun() {
+		if err := workflow.SaveWorkflow(wf); err != nil {
+			return nil, s.internal("保存工作流失败", err)
+		}
+	}
+	return s.workflowMessage(wf)
+}
+
+// ExecuteWorkflow 异步执行当前工作流
+func (s *ManagementServer) ExecuteWorkflow(ctx context.Context, req *managementv1.ExecuteWorkflowRequest) (*managementv1.Execution, error) {
+	wf, err := workflow.LoadCurrentWorkflow()
+	if err != nil {
+		return nil, s.internal("加载当前工作流失败", err)
+	}
+	if wf.ID != req.GetWorkflowId() {
+		return nil, status.Errorf(codes.NotFound, "workflow not found: %s", req.GetWorkflowId())
+	}
+
+	inputs := req.GetInputs().AsMap()
+	// 执行在请求结束后继续进行，不能继承调用方的 context，只带上关联ID
+	execution, err := s.executor.Execute(requestid.WithID(context.Background(), requestid.FromContext(ctx)), wf, inputs)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return s.executionMessage(execution)
+}
+
+// GetExecution 获取执行记录，已结束且不在内存中的执行从磁盘读取
+func (s *ManagementServer) GetExecution(ctx context.Context, req *managementv1.GetExecutionRequest) (*managementv1.Execution, error) {
+	execution, ok := s.executor.GetExecution(req.GetExecutionId())
+	if !ok {
+		loaded, err := workflow.LoadExecution(req.GetExecutionId())
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "execution not found: %s", req.GetExecutionId())
+		}
+		execution = loaded
+	}
+	return s.executionMessage(execution)
+}
+
+// internal 记录内部错误并返回不含细节的 Internal 状态
+func (s *ManagementServer) internal(message string, err error) error {
+	s.logger.ErrorTag("gRPC", "%s: %v", message, err)
+	return status.Error(codes.Internal, message)
+}
+
+func (s *ManagementServer) workflowMessage(wf *workflow.Workflow) (*managementv1.Workflow, error) {
+	message, err := toWorkflow(wf)
+	if err != nil {
+		return nil, s.internal("转换工作流失败", err)
+	}
+	return message, nil
+}
+
+func (s *ManagementServer) executionMessage(execution *workflow.Execution) (*managementv1.Execution, error) {
+	message, err := toExecution(execution)
+	if err != nil {
+		return nil, s.internal("转换执行记录失败", err)
+	}
+	return message, nil
+}
+
+func pagination(page, pageSize int32) (int, int) {
+	p, size := int(page), int(pageSize)
+	if p < 1 {
+		p = 1
+	}
+	if size < 1 {
+		size = defaultPageSize
+	}
+	if size > maxPageSize {
+		size = maxPageSize
+	}
+	return p, size
+}
+
+func toDevice(device *aggregate.Device) *managementv1.Device {
+	message := &managementv1.Device{
+		Id:             int64(device.ID),
+		DeviceId:       device.DeviceID,
+		ClientId:       device.ClientID,
+		Name:           device.Name,
+		Version:        device.Version,
+		BoardType:      device.BoardType,
+		ChipModelName:  device.ChipModelName,
+		AuthStatus:     string(device.AuthStatus),
+		Online:         device.Online,
+		Ota:            device.OTA,
+		LastIp:         device.LastIP,
+		RegisterTime:   timestamp(device.RegisterTime),
+		LastActiveTime: timestamp(device.LastActiveTime),
+	}
+	if device.UserID != nil {
+		message.UserId = int64(*device.UserID)
+	}
+	return message
+}
+
+func toPlugin(plugin *pluginstatus.PluginStatus) *managementv1.Plugin {
+	capabilities := make([]*managementv1.PluginCapability, 0, len(plugin.Capabilities))
+	for _, c := range plugin.Capabilities {
+		capabilities = append(capabilities, &managementv1.PluginCapability{
+			Id:          c.ID,
+			Type:        c.Type,
+			Name:        c.Name,
+			Description: c.Description,
+			Enabled:     c.Enabled,
+		})
+	}
+	return &managementv1.Plugin{
+		Id:              plugin.ID,
+		Name:            plugin.Name,
+		Type:            plugin.Type,
+		Description:     plugin.Description,
+		Version:         plugin.Version,
+		Status:          string(plugin.Status),
+		Address:         plugin.Address,
+		Port:            int32(plugin.Port),
+		HealthStatus:    string(plugin.HealthStatus),
+		Error:           plugin.Error,
+		Capabilities:    capabilities,
+		LastHealthCheck: timestamp(plugin.LastHealthCheck),
+		UpdatedAt:       timestamp(plugin.UpdatedAt),
+	}
+}
+
+// workflowEnvelopeFields 工作流JSON中由 Workflow 消息单独承载的字段，其余字段放入 spec
+var workflowEnvelopeFields = []string{"id", "name", "description", "version", "created_at", "updated_at"}
+
+func toWorkflow(wf *workflow.Workflow) (*managementv1.Workflow, error) {
+	document, err := jsonObject(wf)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range workflowEnvelopeFields {
+		delete(document, field)
+	}
+	spec, err := structpb.NewStruct(document)
+	if err != nil {
+		return nil, err
+	}
+	return &managementv1.Workflow{
+		Id:          wf.ID,
+		Name:        wf.Name,
+		Description: wf.Description,
+		Version:     wf.Version,
+		Spec:        spec,
+		CreatedAt:   timestamp(wf.CreatedAt),
+		UpdatedAt:   timestamp(wf.UpdatedAt),
+	}, nil
+}
+
+// fromWorkflow 按HTTP接口的JSON结构解析工作流，保证两种接口保存的工作流一致
+func fromWorkflow(message *managementv1.Workflow) (*workflow.Workflow, error) {
+	document := message.GetSpec().AsMap()
+	document["id"] = message.GetId()
+	document["name"] = message.GetName()
+	document["description"] = message.GetDescription()
+	document["version"] = message.GetVersion()
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	var wf workflow.Workflow
+	if err := json.Unmarshal(data, &wf); err != nil {
+		return nil, err
+	}
+	if message.GetCreatedAt() != nil {
+		wf.CreatedAt = message.GetCreatedAt().AsTime()
+	}
+	if message.GetUpdatedAt() != nil {
+		wf.UpdatedAt = message.GetUpdatedAt().AsTime()
+	}
+	return &wf, nil
+}
+
+func toWorkflowTemplate(t *workflow.WorkflowTemplate) (*managementv1.WorkflowTemplate, error) {
+	wf, err := toWorkflow(&t.Workflow)
+	if err != nil {
+		return nil, err
+	}
+	parameters := make([]*managementv1.TemplateParameter, 0, len(t.Parameters))
+	for _, p := range t.Parameters {
+		parameter := &managementv1.TemplateParameter{
+			Name:        p.Name,
+			Label:       p.Label,
+			Type:        p.Type,
+			Description: p.Description,
+			Required:    p.Required,
+		}
+		if p.Default != nil {
+			var value interface{}
+			if err := jsonRoundTrip(p.Default, &value); err != nil {
+				return nil, err
+			}
+			if parameter.DefaultValue, err = structpb.NewValue(value); err != nil {
+				return nil, err
+			}
+		}
+		if p.Validation != nil {
+			if parameter.Validation, err = toStruct(p.Validation); err != nil {
+				return nil, err
+			}
+		}
+		parameters = append(parameters, parameter)
+	}
+	return &managementv1.WorkflowTemplate{
+		Id:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+		Category:    t.Category,
+		Tags:        t.Tags,
+		Builtin:     t.Builtin,
+		Parameters:  parameters,
+		Workflow:    wf,
+	}, nil
+}
+
+func toExecution(execution *workflow.Execution) (*managementv1.Execution, error) {
+	message := &managementv1.Execution{
+		Id:         execution.ID,
+		WorkflowId: execution.WorkflowID,
+		Status:     string(execution.Status),
+		Error:      execution.Error,
+		StartTime:  timestamp(execution.StartTime),
+	}
+	if execution.EndTime != nil {
+		message.EndTime = timestamp(*execution.EndTime)
+	}
+
+	var err error
+	if message.Inputs, err = toStruct(execution.Inputs); err != nil {
+		return nil, err
+	}
+	if message.Outputs, err = toStruct(execution.Outputs); err != nil {
+		return nil, err
+	}
+	if message.NodeResults, err = toStruct(execution.NodeResults); err != nil {
+		return nil, err
+	}
+
+	var logs []interface{}
+	if err := jsonRoundTrip(execution.Logs, &logs); err != nil {
+		return nil, err
+	}
+	if message.Logs, err = structpb.NewList(logs); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// toStruct 以JSON编码为准把任意值转换为 Struct，字段名与HTTP接口一致
+func toStruct(v interface{}) (*structpb.Struct, error) {
+	document, err := jsonObject(v)
+	if err != nil {
+		return nil, err
+	}
+	return structpb.NewStruct(document)
+}
+
+func jsonObject(v interface{}) (map[string]interface{}, error) {
+	document := make(map[string]interface{})
+	if err := jsonRoundTrip(v, &document); err != nil {
+		return nil, err
+	}
+	if document == nil {
+		document = make(map[string]interface{})
+	}
+	return document, nil
+}
+
+func jsonRoundTrip(v interface{}, out interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,134 @@
+package grpctransport
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	managementv1 "xiaozhi-server-go/gen/go/api/v1"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/requestid"
+)
+
+// Server 管理面gRPC服务器
+type Server struct {
+	address  string
+	server   *grpc.Server
+	listener net.Listener
+	logger   *logging.Logger
+}
+
+// NewServer 创建管理面gRPC服务器；所有调用都必须在元数据中携带 API Token，
+// 与HTTP接口一样支持 authortoken 或 authorization: Bearer <token>
+func NewServer(address, token string, enableReflection bool, service managementv1.ManagementServiceServer, logger *logging.Logger) (*Server, error) {
+	if address == "" {
+		return nil, fmt.Errorf("management gRPC address is required")
+	}
+	if token == "" {
+		return nil, fmt.Errorf("management gRPC requires server token to be configured")
+	}
+	if logger == nil {
+		logger = logging.DefaultLogger
+	}
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			requestid.UnaryServerInterceptor(true),
+			recoveryInterceptor(logger),
+			tokenInterceptor(token),
+		),
+	)
+	managementv1.RegisterManagementServiceServer(server, service)
+	if enableReflection {
+		reflection.Register(server)
+	}
+
+	return &Server{
+		address: address,
+		server:  server,
+		logger:  logger,
+	}, nil
+}
+
+// Start 监听地址并阻塞处理请求，Stop 后返回 nil
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.address, err)
+	}
+	s.listener = listener
+
+	s.logger.InfoTag("gRPC", "管理面gRPC服务已启动，监听地址 %s", s.address)
+	if err := s.server.Serve(listener); err != nil && err != grpc.ErrServerStopped {
+		return err
+	}
+	return nil
+}
+
+// Stop 优雅停止服务器，超时后强制关闭
+func (s *Server) Stop(timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		s.logger.InfoTag("gRPC", "管理面gRPC服务已优雅关闭")
+	case <-time.After(timeout):
+		s.logger.WarnTag("gRPC", "管理面gRPC服务关闭超时，强制停止")
+		s.server.Stop()
+	}
+}
+
+// tokenInterceptor 校验调用方携带的 API Token
+func tokenInterceptor(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !validToken(ctx, token) {
+			return nil, status.Error(codes.Unauthenticated, "invalid or missing API token")
+		}
+		return handler(ctx, req)
+	}
+}
+
+func validToken(ctx context.Context, token string) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	candidates := md.Get("authortoken")
+	for _, value := range md.Get("authorization") {
+		if v, found := strings.CutPrefix(value, "Bearer "); found {
+			candidates = append(candidates, v)
+		}
+	}
+	for _, candidate := range candidates {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// recoveryInterceptor 将处理函数中的 panic 转为 Internal 错误，避免拖垮整个进程
+func recoveryInterceptor(logger *logging.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.With("request_id", requestid.FromContext(ctx)).ErrorTag("gRPC", "管理面调用 %s 发生panic: %v", info.FullMethod, r)
+				err = status.Error(codes.Internal, "internal error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
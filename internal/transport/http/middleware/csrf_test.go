@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/platform/config"
+)
+
+func TestCSRFMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(CSRFMiddleware(config.HTTPSecurityConfig{
+		CORS: config.CORSConfig{AllowedOrigins: []string{"https://admin.example.com", "*"}},
+		CSRF: config.CSRFConfig{Enabled: true},
+	}))
+	engine.POST("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		status  int
+	}{
+		{"no origin", nil, http.StatusOK},
+		{"same origin", map[string]string{"Origin": "http://server.local"}, http.StatusOK},
+		{"trusted origin", map[string]string{"Origin": "https://admin.example.com"}, http.StatusOK},
+		{"cross site origin", map[string]string{"Origin": "https://evil.example"}, http.StatusForbidden},
+		{"null origin", map[string]string{"Origin": "null"}, http.StatusForbidden},
+		{"same site referer", map[string]string{"Referer": "http://server.local/console"}, http.StatusOK},
+		{"cross site referer", map[string]string{"Referer": "https://evil.example/form"}, http.StatusForbidden},
+		{"cross site with api token", map[string]string{"Origin": "https://evil.example", "AuthorToken": "secret"}, http.StatusOK},
+		{"same origin cookie without token", map[string]string{"Origin": "http://server.local", "Cookie": "XSRF-TOKEN=abc"}, http.StatusForbidden},
+		{"same origin cookie with token", map[string]string{"Origin": "http://server.local", "Cookie": "XSRF-TOKEN=abc", "X-XSRF-TOKEN": "abc"}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "http://server.local/", nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package middleware
 
 import (
+	"xiaozhi-server-go/internal/platform/config"
 	"xiaozhi-server-go/internal/platform/logging"
 	"net/http"
+	"net/url"
 	"runtime/debug"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -137,13 +140,52 @@ func isForbiddenError(err error) bool {
 }
 
 // CORSMiddleware CORS处理中间件
-func CORSMiddleware() gin.HandlerFunc {
+// 未配置允许的来源时允许任意来源；配置后只为列出的来源和同源请求返回CORS头部，并拒绝其它来源的预检和写请求
+func CORSMiddleware(cfg config.HTTPSecurityConfig) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(cfg.CORS.AllowedOrigins))
+	for _, origin := range cfg.CORS.AllowedOrigins {
+		allowed[strings.ToLower(strings.TrimRight(origin, "/"))] = true
+	}
+	restricted := len(allowed) > 0 && !allowed["*"]
+
+	allowHeaders := "Content-Type, Authorization, AuthorToken, X-Request-ID, device-id, client-id, " + TenantTokenHeader + ", " + TenantIDHeader
+	exposeHeaders := "X-Request-ID"
+	if cfg.CSRF.Enabled {
+		header := csrfHeaderName(cfg.CSRF)
+		allowHeaders += ", " + header
+		exposeHeaders += ", " + header
+	}
+
 	return func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
+		origin := c.GetHeader("Origin")
+		if restricted {
+			if origin != "" && !allowed[strings.ToLower(origin)] && !isSameOrigin(c.Request, origin) {
+				if c.Request.Method == http.MethodOptions || !isSafeMethod(c.Request.Method) {
+					ForbiddenError(c, "不允许的跨域来源")
+					c.Abort()
+					return
+				}
+				// 只读请求照常处理，浏览器缺少CORS头部时不会把响应交给页面
+				c.Next()
+				return
+			}
+			c.Header("Vary", "Origin")
+			if origin != "" {
+				c.Header("Access-Control-Allow-Origin", origin)
+				if cfg.CORS.AllowCredentials {
+					c.Header("Access-Control-Allow-Credentials", "true")
+				}
+			}
+		} else {
+			c.Header("Access-Control-Allow-Origin", "*")
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, PATCH, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID, device-id, client-id")
-		c.Header("Access-Control-Expose-Headers", "X-Request-ID")
-		c.Header("Access-Control-Allow-Credentials", "true")
+		c.Header("Access-Control-Allow-Headers", allowHeaders)
+		c.Header("Access-Control-Expose-Headers", exposeHeaders)
+		if cfg.CORS.MaxAge > 0 {
+			c.Header("Access-Control-Max-Age", strconv.FormatInt(int64(cfg.CORS.MaxAge.Seconds()), 10))
+		}
 
 		// 处理预检请求
 		if c.Request.Method == "OPTIONS" {
@@ -155,4 +197,13 @@ func CORSMiddleware() gin.HandlerFunc {
 	}
 }
 
+// isSameOrigin 判断 Origin 是否与请求的主机相同，前端页面由本服务提供时属于同源
+func isSameOrigin(r *http.Request, origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
+
 
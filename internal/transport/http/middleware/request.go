@@ -1,8 +1,10 @@
 package middleware
 
 import (
+	"xiaozhi-server-go/internal/platform/config"
 	"xiaozhi-server-go/internal/platform/logging"
 	"bytes"
+	"fmt"
 	"io"
 	"time"
 
@@ -97,17 +99,31 @@ func RequestSizeMiddleware(maxSize int64) gin.HandlerFunc {
 }
 
 // SecurityHeadersMiddleware 安全头部中间件
-func SecurityHeadersMiddleware() gin.HandlerFunc {
+func SecurityHeadersMiddleware(cfg config.HTTPSecurityConfig) gin.HandlerFunc {
+	frameOptions := cfg.FrameOptions
+	if frameOptions == "" {
+		frameOptions = "DENY"
+	}
+	// 默认只在生产环境（release 模式）中设置HSTS
+	var hsts string
+	if cfg.HSTS == "on" || ((cfg.HSTS == "" || cfg.HSTS == "auto") && gin.Mode() == gin.ReleaseMode) {
+		maxAge := cfg.HSTSMaxAge
+		if maxAge <= 0 {
+			maxAge = 365 * 24 * time.Hour
+		}
+		hsts = fmt.Sprintf("max-age=%d; includeSubDomains", int64(maxAge.Seconds()))
+	}
+
 	return func(c *gin.Context) {
 		// 设置安全相关的HTTP头部
 		c.Header("X-Content-Type-Options", "nosniff")
-		c.Header("X-Frame-Options", "DENY")
+		if frameOptions != "off" {
+			c.Header("X-Frame-Options", frameOptions)
+		}
 		c.Header("X-XSS-Protection", "1; mode=block")
 		c.Header("Referrer-Policy", "strict-origin-when-cross-origin")
-
-		// 在生产环境中设置HSTS
-		if gin.Mode() == gin.ReleaseMode {
-			c.Header("Strict-Transport-Security", "max-age=31536000; includeSubDomains")
+		if hsts != "" {
+			c.Header("Strict-Transport-Security", hsts)
 		}
 
 		c.Next()
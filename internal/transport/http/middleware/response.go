@@ -1,20 +1,20 @@
 package middleware
 
 import (
-	"crypto/rand"
-	"encoding/hex"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/platform/requestid"
 )
 
 // APIError 标准API错误结构
 type APIError struct {
-	Code    string      `json:"code"`
+	Code    string      `json:"code" example:"VALIDATION_FAILED"` // 机器可读的错误码，取值见 utils/error_codes.go，决定响应的HTTP状态码
 	Message string      `json:"message"`
-	Details interface{} `json:"details,omitempty"`
+	Details interface{} `json:"details,omitempty"` // 校验失败时为 []FieldError
 }
 
 // APIResponse 标准API响应结构
@@ -28,23 +28,15 @@ type APIResponse struct {
 	RequestID string      `json:"request_id,omitempty"`
 }
 
-// generateRequestID 生成唯一的请求ID
-func generateRequestID() string {
-	bytes := make([]byte, 8)
-	rand.Read(bytes)
-	return "req_" + hex.EncodeToString(bytes)
-}
-
 // ResponseMiddleware 统一响应格式中间件
 func ResponseMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// 生成请求ID并设置到响应头
-		requestID := c.GetHeader("X-Request-ID")
-		if requestID == "" {
-			requestID = generateRequestID()
-		}
-		c.Header("X-Request-ID", requestID)
+		// 沿用调用方传入的合规请求ID，否则生成新ID；写入响应头和请求上下文，
+		// 之后的插件调用和工作流执行从上下文中取得同一个ID
+		requestID := requestid.Ensure(c.GetHeader(requestid.Header))
+		c.Header(requestid.Header, requestID)
 		c.Set("request_id", requestID)
+		c.Request = c.Request.WithContext(requestid.WithID(c.Request.Context(), requestID))
 
 		// 继续处理请求
 		c.Next()
@@ -93,8 +85,12 @@ func ErrorResponse(c *gin.Context, errorCode, errorMessage string, details ...in
 	c.JSON(statusCode, response)
 }
 
-// ValidationError 返回验证错误响应
+// ValidationError 返回验证错误响应，字段校验失败时 details 为 []FieldError，其余绑定错误为错误描述
 func ValidationError(c *gin.Context, err error) {
+	if fields := FieldErrors(err); len(fields) > 0 {
+		ErrorResponse(c, "VALIDATION_FAILED", "请求参数验证失败", fields)
+		return
+	}
 	ErrorResponse(c, "VALIDATION_FAILED", "请求参数验证失败", err.Error())
 }
 
@@ -132,29 +128,97 @@ func getRequestID(c *gin.Context) string {
 	if requestID, exists := c.Get("request_id"); exists {
 		return requestID.(string)
 	}
-	return ""
+	return requestid.FromContext(c.Request.Context())
+}
+
+// errorStatusCodes 错误码对应的HTTP状态码，错误码定义见 utils/error_codes.go
+var errorStatusCodes = map[string]int{
+	// 通用
+	"VALIDATION_FAILED":       http.StatusBadRequest,
+	"INVALID_INPUT":           http.StatusBadRequest,
+	"BAD_REQUEST":             http.StatusBadRequest,
+	"UNSUPPORTED_API_VERSION": http.StatusBadRequest,
+	"UNAUTHORIZED":            http.StatusUnauthorized,
+	"AUTHENTICATION_FAILED":   http.StatusUnauthorized,
+	"FORBIDDEN":               http.StatusForbidden,
+	"AUTHORIZATION_FAILED":    http.StatusForbidden,
+	"RESOURCE_NOT_FOUND":      http.StatusNotFound,
+	"CONFLICT":                http.StatusConflict,
+	"REQUEST_TOO_LARGE":       http.StatusRequestEntityTooLarge,
+	"RATE_LIMITED":            http.StatusTooManyRequests,
+	"TIMEOUT":                 http.StatusGatewayTimeout,
+	"INTERNAL_SERVER_ERROR":   http.StatusInternalServerError,
+
+	// 认证
+	"INVALID_TOKEN":       http.StatusUnauthorized,
+	"TOKEN_EXPIRED":       http.StatusUnauthorized,
+	"INVALID_CREDENTIALS": http.StatusUnauthorized,
+	"ACCOUNT_LOCKED":      http.StatusForbidden,
+	"ACCOUNT_DISABLED":    http.StatusForbidden,
+	"USER_NOT_FOUND":      http.StatusNotFound,
+	"USER_EXISTS":         http.StatusConflict,
+	"EMAIL_EXISTS":        http.StatusConflict,
+	"INVALID_PASSWORD":    http.StatusBadRequest,
+	"PASSWORD_MISMATCH":   http.StatusBadRequest,
+
+	// 工作流
+	"WORKFLOW_NOT_FOUND":       http.StatusNotFound,
+	"EXECUTION_NOT_FOUND":      http.StatusNotFound,
+	"EXECUTION_RUNNING":        http.StatusConflict,
+	"EXECUTION_COMPLETED":      http.StatusConflict,
+	"EXECUTION_CANCELLED":      http.StatusConflict,
+	"INVALID_WORKFLOW_STATE":   http.StatusConflict,
+	"WORKFLOW_TIMEOUT":         http.StatusGatewayTimeout,
+	"EXECUTION_FAILED":         http.StatusInternalServerError,
+	"NODE_EXECUTION_FAILED":    http.StatusInternalServerError,
+	"WORKFLOW_EXECUTION_ERROR": http.StatusInternalServerError,
+
+	// 设备
+	"DEVICE_NOT_FOUND":        http.StatusNotFound,
+	"FIRMWARE_NOT_FOUND":      http.StatusNotFound,
+	"DEVICE_EXISTS":           http.StatusConflict,
+	"DEVICE_ACTIVATED":        http.StatusConflict,
+	"DEVICE_BUSY":             http.StatusConflict,
+	"DEVICE_UPDATING":         http.StatusConflict,
+	"OTA_COMPLETED":           http.StatusConflict,
+	"OTA_FAILED":              http.StatusConflict,
+	"DEVICE_OFFLINE":          http.StatusServiceUnavailable,
+	"INVALID_ACTIVATION_CODE": http.StatusBadRequest,
+	"INVALID_DEVICE_ID":       http.StatusBadRequest,
+	"FIRMWARE_CORRUPTED":      http.StatusUnprocessableEntity,
+	"ACTIVATION_FAILED":       http.StatusInternalServerError,
+	"UPDATE_FAILED":           http.StatusInternalServerError,
+
+	// 配置
+	"CONFIG_NOT_FOUND":           http.StatusNotFound,
+	"CONFIG_INVALID":             http.StatusBadRequest,
+	"SCHEMA_VALIDATION_FAILED":   http.StatusBadRequest,
+	"PROVIDER_EXISTS":            http.StatusConflict,
+	"CONFIG_UPDATE_FAILED":       http.StatusInternalServerError,
+	"DATABASE_CONNECTION_FAILED": http.StatusInternalServerError,
+	"DATABASE_QUERY_FAILED":      http.StatusInternalServerError,
+
+	// 视觉分析
+	"IMAGE_TOO_LARGE":            http.StatusRequestEntityTooLarge,
+	"INVALID_IMAGE_FORMAT":       http.StatusUnsupportedMediaType,
+	"IMAGE_UPLOAD_FAILED":        http.StatusBadRequest,
+	"ANALYSIS_TIMEOUT":           http.StatusGatewayTimeout,
+	"VISION_SERVICE_UNAVAILABLE": http.StatusServiceUnavailable,
+	"VISION_PROCESSING_FAILED":   http.StatusInternalServerError,
+
+	// 系统
+	"SYSTEM_NOT_INITIALIZED": http.StatusServiceUnavailable,
+	"SYSTEM_MAINTENANCE":     http.StatusServiceUnavailable,
+	"SERVICE_UNAVAILABLE":    http.StatusServiceUnavailable,
+	"DEPENDENCY_FAILED":      http.StatusBadGateway,
+	"QUOTA_EXCEEDED":         http.StatusTooManyRequests,
+	"INSUFFICIENT_RESOURCES": http.StatusServiceUnavailable,
 }
 
-// getStatusCodeFromErrorCode 根据错误码获取对应的HTTP状态码
+// getStatusCodeFromErrorCode 根据错误码获取对应的HTTP状态码，未登记的错误码按 500 处理
 func getStatusCodeFromErrorCode(errorCode string) int {
-	switch errorCode {
-	case "VALIDATION_FAILED":
-		return http.StatusBadRequest
-	case "UNAUTHORIZED":
-		return http.StatusUnauthorized
-	case "FORBIDDEN":
-		return http.StatusForbidden
-	case "RESOURCE_NOT_FOUND":
-		return http.StatusNotFound
-	case "CONFLICT":
-		return http.StatusConflict
-	case "UNSUPPORTED_API_VERSION":
-		return http.StatusBadRequest
-	case "WORKFLOW_NOT_FOUND", "EXECUTION_NOT_FOUND", "DEVICE_NOT_FOUND":
-		return http.StatusNotFound
-	case "WORKFLOW_EXECUTION_ERROR", "VISION_PROCESSING_FAILED":
-		return http.StatusInternalServerError
-	default:
-		return http.StatusInternalServerError
+	if status, ok := errorStatusCodes[errorCode]; ok {
+		return status
 	}
-}
\ No newline at end of file
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/tenant"
+)
+
+const (
+	// TenantTokenHeader 租户访问令牌，携带后请求只能访问该租户的数据
+	TenantTokenHeader = "X-Tenant-Token"
+	// TenantIDHeader 管理员指定要操作的租户
+	TenantIDHeader = "X-Tenant-ID"
+	// AdminTokenHeader 管理员令牌，值为 Server.Token
+	AdminTokenHeader = "AuthorToken"
+)
+
+// TenantAuthenticator 按访问令牌或ID查找租户
+type TenantAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (*tenant.Tenant, error)
+	Get(ctx context.Context, id string) (*tenant.Tenant, error)
+	// MultiTenant 是否已创建默认租户以外的租户
+	MultiTenant(ctx context.Context) bool
+}
+
+// TenantMiddleware 识别请求所属的租户并写入请求上下文
+// 携带租户令牌的请求限定在该租户内；携带管理员令牌（adminToken）的请求视为管理员，可通过 X-Tenant-ID 指定租户，否则不限定租户。
+// 两种令牌都不携带的请求只在尚未创建其他租户的单租户实例中视为管理员，创建租户后一律拒绝
+func TenantMiddleware(tenants TenantAuthenticator, adminToken string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		if token := c.GetHeader(TenantTokenHeader); token != "" {
+			t, err := tenants.Authenticate(ctx, token)
+			if err != nil {
+				if errors.Is(err, tenant.ErrDisabled) {
+					ForbiddenError(c, "租户已停用")
+				} else {
+					UnauthorizedError(c, "无效的租户令牌")
+				}
+				c.Abort()
+				return
+			}
+			c.Set("tenant_id", t.ID)
+			c.Request = c.Request.WithContext(tenant.WithID(ctx, t.ID))
+			c.Next()
+			return
+		}
+
+		if token := c.GetHeader(AdminTokenHeader); token != "" {
+			if adminToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+				UnauthorizedError(c, "无效的管理员令牌")
+				c.Abort()
+				return
+			}
+		} else if tenants.MultiTenant(ctx) {
+			UnauthorizedError(c, "缺少管理员令牌或租户令牌")
+			c.Abort()
+			return
+		}
+
+		if id := c.GetHeader(TenantIDHeader); id != "" {
+			if _, err := tenants.Get(ctx, id); err != nil {
+				NotFoundError(c, "租户")
+				c.Abort()
+				return
+			}
+			c.Set("tenant_id", id)
+			c.Request = c.Request.WithContext(tenant.WithID(ctx, id))
+		}
+		c.Next()
+	}
+}
+
+// RequireAdmin 拒绝携带租户令牌的请求，用于实例级的配置、运维和租户管理接口
+func RequireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader(TenantTokenHeader) != "" {
+			ForbiddenError(c, "租户无权访问该接口")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
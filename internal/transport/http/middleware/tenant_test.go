@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/tenant"
+)
+
+// stubTenants 固定的租户集合，令牌即租户ID加前缀
+type stubTenants struct {
+	tenants map[string]*tenant.Tenant
+}
+
+func (s *stubTenants) Authenticate(ctx context.Context, token string) (*tenant.Tenant, error) {
+	for _, t := range s.tenants {
+		if "token-"+t.ID == token {
+			if !t.Enabled {
+				return nil, tenant.ErrDisabled
+			}
+			return t, nil
+		}
+	}
+	return nil, tenant.ErrInvalidToken
+}
+
+func (s *stubTenants) Get(ctx context.Context, id string) (*tenant.Tenant, error) {
+	if t, ok := s.tenants[id]; ok {
+		return t, nil
+	}
+	return nil, tenant.ErrNotFound
+}
+
+func (s *stubTenants) MultiTenant(ctx context.Context) bool {
+	for id := range s.tenants {
+		if id != tenant.DefaultID {
+			return true
+		}
+	}
+	return false
+}
+
+func newStubTenants(ids ...string) *stubTenants {
+	s := &stubTenants{tenants: map[string]*tenant.Tenant{}}
+	for _, id := range append([]string{tenant.DefaultID}, ids...) {
+		s.tenants[id] = &tenant.Tenant{ID: id, Enabled: true}
+	}
+	return s
+}
+
+func TestTenantMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const adminToken = "secret"
+
+	tests := []struct {
+		name    string
+		tenants *stubTenants
+		headers map[string]string
+		admin   bool
+		status  int
+		tenant  string
+	}{
+		{"single tenant without token", newStubTenants(), nil, false, http.StatusOK, ""},
+		{"multi tenant without token", newStubTenants("acme"), nil, false, http.StatusUnauthorized, ""},
+		{"multi tenant selects tenant without token", newStubTenants("acme"), map[string]string{TenantIDHeader: "acme"}, false, http.StatusUnauthorized, ""},
+		{"admin token", newStubTenants("acme"), map[string]string{AdminTokenHeader: adminToken}, true, http.StatusOK, ""},
+		{"admin selects tenant", newStubTenants("acme"), map[string]string{AdminTokenHeader: adminToken, TenantIDHeader: "acme"}, true, http.StatusOK, "acme"},
+		{"admin selects unknown tenant", newStubTenants("acme"), map[string]string{AdminTokenHeader: adminToken, TenantIDHeader: "other"}, true, http.StatusNotFound, ""},
+		{"wrong admin token", newStubTenants("acme"), map[string]string{AdminTokenHeader: "guess"}, false, http.StatusUnauthorized, ""},
+		{"wrong admin token single tenant", newStubTenants(), map[string]string{AdminTokenHeader: "guess"}, false, http.StatusUnauthorized, ""},
+		{"tenant token", newStubTenants("acme"), map[string]string{TenantTokenHeader: "token-acme"}, false, http.StatusOK, "acme"},
+		{"tenant token ignores tenant id", newStubTenants("acme", "other"), map[string]string{TenantTokenHeader: "token-acme", TenantIDHeader: "other"}, false, http.StatusOK, "acme"},
+		{"invalid tenant token", newStubTenants("acme"), map[string]string{TenantTokenHeader: "token-nobody"}, false, http.StatusUnauthorized, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, adminRoute := range []bool{false, true} {
+				engine := gin.New()
+				group := engine.Group("", TenantMiddleware(tt.tenants, adminToken))
+				if adminRoute {
+					group.Use(RequireAdmin())
+				}
+				var gotTenant string
+				group.GET("/", func(c *gin.Context) {
+					gotTenant, _ = tenant.FromContext(c.Request.Context())
+					c.Status(http.StatusOK)
+				})
+
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				for key, value := range tt.headers {
+					req.Header.Set(key, value)
+				}
+				rec := httptest.NewRecorder()
+				engine.ServeHTTP(rec, req)
+
+				want := tt.status
+				// 租户令牌通过认证后访问管理员接口返回 403
+				if adminRoute && want == http.StatusOK && tt.headers[TenantTokenHeader] != "" {
+					want = http.StatusForbidden
+				}
+				if rec.Code != want {
+					t.Fatalf("admin route %v: status = %d, want %d", adminRoute, rec.Code, want)
+				}
+				if want == http.StatusOK && gotTenant != tt.tenant {
+					t.Fatalf("admin route %v: tenant = %q, want %q", adminRoute, gotTenant, tt.tenant)
+				}
+			}
+		})
+	}
+}
+
+func TestTenantMiddlewareWithoutAdminToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/", TenantMiddleware(newStubTenants("acme"), ""), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	// 未配置 Server.Token 时任何管理员令牌都无效，空令牌也不能冒充
+	for _, token := range []string{"", "anything"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(AdminTokenHeader, token)
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("token %q: status = %d, want %d", token, rec.Code, http.StatusUnauthorized)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError 字段级校验错误，作为 VALIDATION_FAILED 响应的 details 返回
+type FieldError struct {
+	Field   string `json:"field"`           // 字段的 JSON/查询参数名，嵌套字段以点号连接
+	Rule    string `json:"rule"`            // 未通过的校验规则，如 required、max、oneof
+	Param   string `json:"param,omitempty"` // 规则参数，如 max=64 中的 64
+	Message string `json:"message"`
+}
+
+func init() {
+	// 校验错误使用 JSON 字段名（其次是 form 名），与客户端提交的字段一致
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			for _, tag := range []string{"json", "form"} {
+				name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+				if name == "-" {
+					return ""
+				}
+				if name != "" {
+					return name
+				}
+			}
+			return field.Name
+		})
+	}
+}
+
+// BindJSON 绑定并校验 JSON 请求体，失败时写入 VALIDATION_FAILED 响应并返回 false
+func BindJSON(c *gin.Context, obj interface{}) bool {
+	if err := c.ShouldBindJSON(obj); err != nil {
+		ValidationError(c, err)
+		return false
+	}
+	return true
+}
+
+// BindQuery 绑定并校验查询参数，失败时写入 VALIDATION_FAILED 响应并返回 false
+func BindQuery(c *gin.Context, obj interface{}) bool {
+	if err := c.ShouldBindQuery(obj); err != nil {
+		ValidationError(c, err)
+		return false
+	}
+	return true
+}
+
+// FieldErrors 把绑定错误转换为字段级错误，不是字段校验错误（如 JSON 语法错误）时返回 nil
+func FieldErrors(err error) []FieldError {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return nil
+	}
+	fields := make([]FieldError, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		field := fe.Namespace()
+		// 去掉顶层结构体名，只保留字段路径
+		if i := strings.Index(field, "."); i >= 0 {
+			field = field[i+1:]
+		}
+		fields = append(fields, FieldError{
+			Field:   field,
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: fieldErrorMessage(field, fe),
+		})
+	}
+	return fields
+}
+
+// fieldErrorMessage 生成单个字段的错误说明
+func fieldErrorMessage(field string, fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return fmt.Sprintf("%s 为必填项", field)
+	case "min":
+		return fmt.Sprintf("%s 不能小于 %s", field, fe.Param())
+	case "max":
+		return fmt.Sprintf("%s 不能大于 %s", field, fe.Param())
+	case "len":
+		return fmt.Sprintf("%s 的长度必须为 %s", field, fe.Param())
+	case "oneof":
+		return fmt.Sprintf("%s 必须是以下值之一: %s", field, fe.Param())
+	case "email":
+		return fmt.Sprintf("%s 不是有效的邮箱地址", field)
+	case "url":
+		return fmt.Sprintf("%s 不是有效的URL", field)
+	default:
+		return fmt.Sprintf("%s 未通过 %s 校验", field, fe.Tag())
+	}
+}
@@ -15,6 +15,7 @@ import (
 
 	"xiaozhi-server-go/internal/domain/device/aggregate"
 	"xiaozhi-server-go/internal/domain/device/service"
+	"xiaozhi-server-go/internal/domain/objectstore"
 	"xiaozhi-server-go/internal/platform/config"
 	"xiaozhi-server-go/internal/platform/errors"
 
@@ -26,6 +27,7 @@ type Service struct {
 	updateURL     string
 	config        *config.Config
 	deviceService *service.DeviceService
+	objects       *objectstore.Service // 未启用对象存储时为 nil，只从 data/ota_bin 查找固件
 	logger        *logging.Logger
 }
 
@@ -56,6 +58,11 @@ func NewService(
 	return service, nil
 }
 
+// UseObjects 同时从对象存储的 ota 命名空间查找固件，固件地址为带签名的下载地址
+func (s *Service) UseObjects(objects *objectstore.Service) {
+	s.objects = objects
+}
+
 // Register 注册OTA相关的HTTP路由
 func (s *Service) Register(ctx context.Context, router *gin.RouterGroup) error {
 	// OTA 主接口（支持GET和POST）
@@ -129,7 +136,7 @@ func (s *Service) handlePostOTA(c *gin.Context) {
 	}
 
 	// 获取最新固件信息
-	firmwareInfo := s.getLatestFirmwareInfo(version)
+	firmwareInfo := s.getLatestFirmwareInfo(c.Request.Context(), version)
 
 	// 检查并更新设备信息
 	device := s.checkAndUpdateDevice(c, req, deviceID, clientIDFormatted, req.Board.Name, version)
@@ -307,12 +314,16 @@ func (s *Service) trans2OTARequestBody(raw map[string]interface{}) OTARequestBod
 	return req
 }
 
-// getLatestFirmwareInfo 获取最新固件信息
-func (s *Service) getLatestFirmwareInfo(currentVersion string) FirmwareInfo {
+// getLatestFirmwareInfo 获取最新固件信息，data/ota_bin 和对象存储中的固件按版本号一起比较
+func (s *Service) getLatestFirmwareInfo(ctx context.Context, currentVersion string) FirmwareInfo {
 	otaDir := filepath.Join(".", "data", "ota_bin")
 	_ = os.MkdirAll(otaDir, 0755)
 
 	bins, _ := filepath.Glob(filepath.Join(otaDir, "*.bin"))
+	objects := s.firmwareObjects(ctx)
+	for name := range objects {
+		bins = append(bins, name)
+	}
 	if len(bins) == 0 {
 		return FirmwareInfo{
 			Version: currentVersion,
@@ -328,12 +339,46 @@ func (s *Service) getLatestFirmwareInfo(currentVersion string) FirmwareInfo {
 	latest := filepath.Base(bins[0])
 	version := strings.TrimSuffix(latest, ".bin")
 
+	if obj, ok := objects[bins[0]]; ok {
+		url, _, err := s.objects.URL(obj, 0)
+		if err != nil {
+			s.logger.Error("签发固件 %s 的下载地址失败: %v", latest, err)
+			return FirmwareInfo{Version: currentVersion}
+		}
+		return FirmwareInfo{
+			Version: version,
+			URL:     url,
+		}
+	}
+
 	return FirmwareInfo{
 		Version: version,
 		URL:     "/ota_bin/" + latest,
 	}
 }
 
+// firmwareObjects 列出对象存储中名称以 .bin 结尾的固件，按名称索引
+func (s *Service) firmwareObjects(ctx context.Context) map[string]*objectstore.Object {
+	if s.objects == nil {
+		return nil
+	}
+	items, _, err := s.objects.List(ctx, objectstore.Filter{Namespace: objectstore.NamespaceOTA, PageSize: 1000})
+	if err != nil {
+		s.logger.Error("查询对象存储中的固件失败: %v", err)
+		return nil
+	}
+	objects := make(map[string]*objectstore.Object, len(items))
+	for _, obj := range items {
+		if strings.HasSuffix(obj.Name, ".bin") && obj.Name == filepath.Base(obj.Name) {
+			// 同名固件取最近写入的一个，列表按更新时间倒序
+			if _, exists := objects[obj.Name]; !exists {
+				objects[obj.Name] = obj
+			}
+		}
+	}
+	return objects
+}
+
 // checkAndUpdateDevice 检查并更新设备信息
 func (s *Service) checkAndUpdateDevice(
 	c *gin.Context,
@@ -1,39 +1,27 @@
 package httptransport
 
-import "github.com/gin-gonic/gin"
+import (
+	"github.com/gin-gonic/gin"
 
-// APIResponse 定义统一的接口返回结构体
-type APIResponse struct {
-	Success bool        `json:"success"`
-	Data    interface{} `json:"data"`
-	Message string      `json:"message"`
-	Code    int         `json:"code"`
-}
+	httpMiddleware "xiaozhi-server-go/internal/transport/http/middleware"
+)
+
+// APIResponse 定义统一的接口返回结构体，即 middleware.APIResponse，供 Swagger 注解引用
+// 失败时 error.code 为 utils/error_codes.go 中的错误码，HTTP状态码由错误码决定
+type APIResponse = httpMiddleware.APIResponse
+
+// APIError 统一的错误结构
+type APIError = httpMiddleware.APIError
 
 // RespondSuccess 返回成功响应
-func RespondSuccess(c *gin.Context, httpStatus int, data interface{}, message string) {
+func RespondSuccess(c *gin.Context, data interface{}, message string) {
 	if message == "" {
 		message = "ok"
 	}
-
-	resp := APIResponse{
-		Success: true,
-		Message: message,
-		Code:    httpStatus,
-		Data:    data,
-	}
-
-	c.JSON(httpStatus, resp)
+	httpMiddleware.SuccessResponse(c, data, message)
 }
 
 // RespondError 返回失败响应
-func RespondError(c *gin.Context, httpStatus int, message string, data interface{}) {
-	resp := APIResponse{
-		Success: false,
-		Message: message,
-		Code:    httpStatus,
-		Data:    data,
-	}
-
-	c.JSON(httpStatus, resp)
-}
\ No newline at end of file
+func RespondError(c *gin.Context, errorCode, message string, details ...interface{}) {
+	httpMiddleware.ErrorResponse(c, errorCode, message, details...)
+}
@@ -16,8 +16,10 @@ import (
 	httpMiddleware "xiaozhi-server-go/internal/transport/http/middleware"
 	v1 "xiaozhi-server-go/internal/transport/http/v1"
 	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/plugin/logs"
 	"xiaozhi-server-go/internal/plugin/ports"
 	"xiaozhi-server-go/internal/plugin/status"
+	"xiaozhi-server-go/internal/workflow"
 )
 
 // Options configures the HTTP router builder.
@@ -25,11 +27,16 @@ type Options struct {
 	Config         *config.Config
 	Logger         *logging.Logger
 	AuthMiddleware gin.HandlerFunc
+	// TenantMiddleware resolves the request tenant for every /api/v1 route; nil disables tenant scoping
+	TenantMiddleware gin.HandlerFunc
 	StaticRoot     string
 	Registry       *capability.Registry
 	// 新增：插件状态和端口管理器
 	PluginStatusManager *status.PluginStatusManager
 	PortManager         *ports.PortManager
+	PluginLogs          *logs.Manager
+	// WorkflowExecutor is shared with the management gRPC service; when nil the workflow service creates its own
+	WorkflowExecutor workflow.WorkflowExecutor
 	// Note: PluginAPIRegistry is deprecated in gRPC architecture
 }
 
@@ -40,6 +47,8 @@ type Router struct {
 	Secured  *gin.RouterGroup
 	V1       *gin.RouterGroup
 	V1Secure *gin.RouterGroup
+	// V1Public serves /api/v1 routes that carry their own credentials (signed URLs, pairing tokens) and skip tenant authentication
+	V1Public *gin.RouterGroup
 }
 
 // Build constructs a gin engine pre-configured with logging, recovery, CORS and observability middlewares.
@@ -65,9 +74,12 @@ func Build(opts Options) (*Router, error) {
 	engine.Use(httpMiddleware.ErrorMiddleware(logger))
 	engine.Use(httpMiddleware.ResponseMiddleware())
 	engine.Use(httpMiddleware.LoggingMiddleware(logger))
-	engine.Use(httpMiddleware.SecurityHeadersMiddleware())
+	engine.Use(httpMiddleware.SecurityHeadersMiddleware(opts.Config.HTTPSecurity))
 	engine.Use(httpMiddleware.RequestSizeMiddleware(10 << 20)) // 10MB
-	engine.Use(httpMiddleware.CORSMiddleware())
+	engine.Use(httpMiddleware.CORSMiddleware(opts.Config.HTTPSecurity))
+	if opts.Config.HTTPSecurity.CSRF.Enabled {
+		engine.Use(httpMiddleware.CSRFMiddleware(opts.Config.HTTPSecurity))
+	}
 	engine.Use(loggingMiddleware(logger)) // 保留原有的日志中间件作为备份
 	engine.Use(observabilityMiddleware())
 
@@ -79,18 +91,39 @@ func Build(opts Options) (*Router, error) {
 
 	// 创建 V1 API 路由组（移除版本中间件，因为只支持 v1）
 	v1Group := api.Group("/v1")
+	// Created before the tenant middleware is installed so it is not inherited
+	v1Public := api.Group("/v1")
+	if opts.TenantMiddleware != nil {
+		// Must be installed before any route is registered on the group
+		v1Group.Use(opts.TenantMiddleware)
+	}
+	// Workflows, approvals and plugin management act on the whole instance; tenant tokens get 403
+	v1Admin := v1Group.Group("", httpMiddleware.RequireAdmin())
 
 	// Initialize Workflow Service
 	if opts.Registry != nil {
-		workflowService := v1.NewWorkflowService(opts.Config, logger, opts.Registry)
-		workflowService.RegisterRoutes(v1Group)
+		var workflowService *v1.WorkflowService
+		if opts.WorkflowExecutor != nil {
+			workflowService = v1.NewWorkflowServiceWithExecutor(opts.Config, logger, opts.Registry, opts.WorkflowExecutor)
+		} else {
+			workflowService = v1.NewWorkflowService(opts.Config, logger, opts.Registry)
+		}
+		workflowService.SetPluginStatusManager(opts.PluginStatusManager)
+		workflowService.SetPluginLogs(opts.PluginLogs)
+		workflowService.RegisterRoutes(v1Admin)
 	}
 
+	// Initialize Approval Service
+	v1.NewApprovalService(logger).RegisterRoutes(v1Admin)
+
 	// Initialize Plugin List Controller
 	if opts.PluginStatusManager != nil {
 		logger.InfoTag("HTTP", "初始化插件列表控制器")
 		pluginListController := v1.NewPluginListController(opts.PluginStatusManager, logger)
-		pluginListController.Register(v1Group)
+		pluginListController.SetRegistry(opts.Registry)
+		pluginListController.SetPortManager(opts.PortManager)
+		pluginListController.SetLogManager(opts.PluginLogs)
+		pluginListController.Register(v1Admin)
 		logger.InfoTag("HTTP", "插件列表控制器路由注册完成")
 	} else {
 		logger.InfoTag("HTTP", "插件状态管理器未初始化，跳过插件列表控制器")
@@ -111,6 +144,9 @@ func Build(opts Options) (*Router, error) {
 		staticRoot = "./web/dist"
 	}
 
+	// CSP 只作用于前端单页应用；API 响应是 JSON，文档页面自行加载脚本
+	csp := httpMiddleware.ContentSecurityPolicy(opts.Config.HTTPSecurity)
+
 	// 为静态文件创建单独的组，避免与API冲突
 	engine.NoRoute(func(c *gin.Context) {
 		path := c.Request.URL.Path
@@ -119,6 +155,9 @@ func Build(opts Options) (*Router, error) {
 			c.Next()
 			return
 		}
+		if csp != "" {
+			c.Header("Content-Security-Policy", csp)
+		}
 
 		// 静态文件服务
 		if _, err := os.Stat(staticRoot + path); err == nil {
@@ -141,6 +180,7 @@ func Build(opts Options) (*Router, error) {
 		Secured:  nil,
 		V1:       v1Group,
 		V1Secure: v1Secure,
+		V1Public: v1Public,
 	}, nil
 }
 
@@ -0,0 +1,59 @@
+package v1
+
+import "time"
+
+// AgentCreateRequest 创建智能体请求
+type AgentCreateRequest struct {
+	Name        string   `json:"name" binding:"required"` // 小写字母开头，只含小写字母、数字、_ 和 -
+	Description string   `json:"description,omitempty"`   // 用途说明，调度者据此选择委托对象
+	Prompt      string   `json:"prompt" binding:"required"`
+	Model       string   `json:"model,omitempty"`     // LLM 配置名称，缺省为默认LLM
+	Tools       []string `json:"tools,omitempty"`     // 允许调用的 MCP 工具
+	Delegates   []string `json:"delegates,omitempty"` // 可以委托子任务的智能体名称
+}
+
+// AgentUpdateRequest 更新智能体请求，未提供的字段不修改
+type AgentUpdateRequest struct {
+	Name        *string   `json:"name,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Prompt      *string   `json:"prompt,omitempty"`
+	Model       *string   `json:"model,omitempty"`
+	Tools       *[]string `json:"tools,omitempty"`
+	Delegates   *[]string `json:"delegates,omitempty"`
+}
+
+// AgentRunRequest 运行智能体请求
+type AgentRunRequest struct {
+	Input string `json:"input" binding:"required"`
+}
+
+// AgentInfo 智能体信息
+type AgentInfo struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Prompt      string    `json:"prompt"`
+	Model       string    `json:"model"`
+	Tools       []string  `json:"tools"`
+	Delegates   []string  `json:"delegates"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// AgentStep 运行中的一次工具调用或委托
+type AgentStep struct {
+	Agent      string `json:"agent"`
+	Type       string `json:"type"` // tool 或 delegate
+	Name       string `json:"name"`
+	Arguments  string `json:"arguments"`
+	Result     string `json:"result"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// AgentRunResult 运行结果
+type AgentRunResult struct {
+	Agent string      `json:"agent"`
+	Reply string      `json:"reply"`
+	Steps []AgentStep `json:"steps"`
+}
@@ -0,0 +1,65 @@
+package v1
+
+import "time"
+
+// AnalyticsQuery 用量统计查询参数
+type AnalyticsQuery struct {
+	Period   string `form:"period"`    // day/week，默认 day
+	From     string `form:"from"`      // 起始日期 YYYY-MM-DD，按周查询时对齐到所在周的周一
+	To       string `form:"to"`        // 结束日期 YYYY-MM-DD，默认今天
+	DeviceID string `form:"device_id"` // 只返回该设备的活跃情况
+	Limit    int    `form:"limit"`     // 意图和设备列表的条数，默认 20，最多 500
+}
+
+// AnalyticsUsage 一个周期的整体用量
+type AnalyticsUsage struct {
+	Conversations   int64   `json:"conversations"`
+	Turns           int64   `json:"turns"` // 用户语句数
+	ActiveDevices   int64   `json:"active_devices"`
+	AvgLatencyMs    float64 `json:"avg_latency_ms"`     // 说话结束到首帧音频下发的平均耗时
+	AvgFirstTokenMs float64 `json:"avg_first_token_ms"` // LLM首个输出的平均耗时
+	Tokens          int64   `json:"tokens"`
+	LLMCalls        int64   `json:"llm_calls"`
+	SpeechMs        int64   `json:"speech_ms"` // 送入ASR的语音时长
+	TTSChars        int64   `json:"tts_chars"` // 合成播报的字数
+}
+
+// AnalyticsIntent 意图路由次数
+type AnalyticsIntent struct {
+	Intent  string `json:"intent"`
+	Count   int64  `json:"count"`
+	Handled int64  `json:"handled"` // 由确定性处理器完成的次数
+	Failed  int64  `json:"failed"`
+}
+
+// AnalyticsProvider 提供者的能力调用情况
+type AnalyticsProvider struct {
+	Provider      string  `json:"provider"`
+	Capability    string  `json:"capability"`
+	Calls         int64   `json:"calls"`
+	Errors        int64   `json:"errors"`
+	ErrorRate     float64 `json:"error_rate"`
+	AvgDurationMs float64 `json:"avg_duration_ms"`
+}
+
+// AnalyticsDevice 设备的活跃情况
+type AnalyticsDevice struct {
+	DeviceID      string     `json:"device_id"`
+	Conversations int64      `json:"conversations"`
+	Turns         int64      `json:"turns"`
+	Tokens        int64      `json:"tokens"`
+	SpeechMs      int64      `json:"speech_ms"`
+	TTSChars      int64      `json:"tts_chars"`
+	LastActiveAt  *time.Time `json:"last_active_at,omitempty"`
+}
+
+// AnalyticsRollup 一个周期的汇总
+type AnalyticsRollup struct {
+	Period     string              `json:"period"` // day/week
+	Start      string              `json:"start"`  // 周期第一天 YYYY-MM-DD
+	Usage      AnalyticsUsage      `json:"usage"`
+	Intents    []AnalyticsIntent   `json:"intents"`   // 按次数从多到少
+	Providers  []AnalyticsProvider `json:"providers"` // 按提供者和能力排列
+	Devices    []AnalyticsDevice   `json:"devices"`   // 按语句数从多到少
+	ComputedAt time.Time           `json:"computed_at"`
+}
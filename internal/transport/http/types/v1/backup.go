@@ -0,0 +1,42 @@
+package v1
+
+import "time"
+
+// BackupInfo 备份文件信息
+type BackupInfo struct {
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`
+	Auto      bool      `json:"auto"` // 自动备份，超出保留数量时被清理
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BackupListResponse 备份列表响应
+type BackupListResponse struct {
+	Backups  []BackupInfo `json:"backups"`
+	Dir      string       `json:"dir"`
+	Interval string       `json:"interval"` // 自动备份间隔，为空表示只手动备份
+	Keep     int          `json:"keep"`
+	Paths    []string     `json:"paths"` // 备份的文件和目录
+}
+
+// BackupPassphraseRequest 校验或恢复时使用的口令，为空时使用配置的口令
+type BackupPassphraseRequest struct {
+	Passphrase string `json:"passphrase"`
+}
+
+// BackupVerifyResponse 校验结果
+type BackupVerifyResponse struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"` // 备份创建时间
+	Driver    string    `json:"driver"`
+	Database  string    `json:"database,omitempty"` // 为空表示不含数据库快照
+	Roots     []string  `json:"roots"`
+	Files     int       `json:"files"`
+	Bytes     int64     `json:"bytes"`
+}
+
+// BackupRestoreResponse 恢复结果
+type BackupRestoreResponse struct {
+	BackupVerifyResponse
+	RestartRequired bool `json:"restart_required"` // 恢复在下次启动时生效
+}
@@ -0,0 +1,27 @@
+package v1
+
+// BudgetLimits 设备适用的Token上限，0 表示不限制
+type BudgetLimits struct {
+	Profile       string `json:"profile"` // 预算名称，没有匹配的预算时为空
+	TurnTokens    int64  `json:"turn_tokens"`
+	SessionTokens int64  `json:"session_tokens"`
+	DailyTokens   int64  `json:"daily_tokens"`
+}
+
+// DeviceTokenUsageInfo 设备某一天的Token用量
+type DeviceTokenUsageInfo struct {
+	DeviceID  string       `json:"device_id"`
+	Day       string       `json:"day"`
+	Tokens    int64        `json:"tokens"`
+	Calls     int64        `json:"calls"`               // LLM调用次数
+	Rejected  int64        `json:"rejected"`            // 因超出预算未调用LLM的次数
+	Remaining *int64       `json:"remaining,omitempty"` // 当天剩余的Token，未设置每日上限时为空
+	Enabled   bool         `json:"enabled"`             // 是否启用预算检查
+	Limits    BudgetLimits `json:"limits"`
+}
+
+// DeviceTokenUsageQuery 设备用量列表查询参数
+type DeviceTokenUsageQuery struct {
+	Day   string `form:"day"`   // 日期 YYYY-MM-DD，默认今天
+	Limit int    `form:"limit"` // 返回的设备数，默认 100，最多 500
+}
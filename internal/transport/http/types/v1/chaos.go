@@ -0,0 +1,44 @@
+package v1
+
+import "time"
+
+// ChaosRuleRequest 添加故障注入规则请求
+type ChaosRuleRequest struct {
+	Target      string  `json:"target" binding:"required,oneof=capability grpc http"` // capability 能力调用，grpc 插件 gRPC 调用，http 提供者 HTTP 请求
+	Match       string  `json:"match,omitempty"`                                      // 提供者或插件ID，支持 * 通配，为空时匹配全部
+	Method      string  `json:"method,omitempty"`                                     // 能力ID、RPC 方法名或请求主机名，支持 * 通配，为空时匹配全部
+	Action      string  `json:"action" binding:"required,oneof=delay error kill"`
+	Probability float64 `json:"probability,omitempty" binding:"min=0,max=1"` // 缺省为 1
+	DelayMs     int64   `json:"delay_ms,omitempty" binding:"min=0"`
+	StatusCode  int     `json:"status_code,omitempty"` // HTTP error 返回的状态码，缺省 503
+	Message     string  `json:"message,omitempty"`
+	MaxHits     int     `json:"max_hits,omitempty" binding:"min=0"`    // 注入次数上限，0 表示不限
+	TTLSeconds  int     `json:"ttl_seconds,omitempty" binding:"min=0"` // 有效期，缺省使用 Chaos.RuleTTL
+}
+
+// ChaosRuleInfo 故障注入规则
+type ChaosRuleInfo struct {
+	ID          string    `json:"id"`
+	Target      string    `json:"target"`
+	Match       string    `json:"match"`
+	Method      string    `json:"method"`
+	Action      string    `json:"action"`
+	Probability float64   `json:"probability"`
+	DelayMs     int64     `json:"delay_ms"`
+	StatusCode  int       `json:"status_code"`
+	Message     string    `json:"message"`
+	MaxHits     int       `json:"max_hits"`
+	Hits        int       `json:"hits"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// ChaosRuleListResponse 故障注入规则列表响应
+type ChaosRuleListResponse struct {
+	Rules []ChaosRuleInfo `json:"rules"`
+}
+
+// ChaosClearResponse 清空故障注入规则的结果
+type ChaosClearResponse struct {
+	Deleted int `json:"deleted"`
+}
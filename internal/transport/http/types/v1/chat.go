@@ -0,0 +1,24 @@
+package v1
+
+// ChatRequest 文本对话请求
+type ChatRequest struct {
+	Text      string `json:"text" binding:"required"`
+	DeviceID  string `json:"device_id,omitempty"`  // 关联的设备，在线时进入设备当前的会话
+	SessionID string `json:"session_id,omitempty"` // 继续之前的会话，为空时按设备生成或新建
+	Stream    bool   `json:"stream,omitempty"`     // 以SSE逐段推送回复
+}
+
+// ChatResponse 文本对话回复
+type ChatResponse struct {
+	SessionID string   `json:"session_id"`
+	DeviceID  string   `json:"device_id,omitempty"`
+	Round     int      `json:"round"`
+	Reply     string   `json:"reply"`
+	Segments  []string `json:"segments"`
+	Blocked   bool     `json:"blocked,omitempty"` // 输入被内容审核拦截，reply 为拦截回复
+}
+
+// ChatSegment SSE 推送的回复分段（事件名 segment）
+type ChatSegment struct {
+	Text string `json:"text"`
+}
@@ -0,0 +1,26 @@
+package v1
+
+// ProviderTestRequest 供应商配置测试请求
+type ProviderTestRequest struct {
+	CapabilityID string                 `json:"capability_id" binding:"required"`
+	Config       map[string]interface{} `json:"config,omitempty"`  // 待测试的能力配置，如 api_key、model
+	Timeout      string                 `json:"timeout,omitempty"` // 如 10s，最长 1m
+}
+
+// ProviderTestResult 供应商配置测试结果
+type ProviderTestResult struct {
+	CapabilityID string `json:"capability_id"`
+	ProviderID   string `json:"provider_id"`
+	Type         string `json:"type"`
+	Success      bool   `json:"success"`
+	Message      string `json:"message"`
+	LatencyMs    int64  `json:"latency_ms"`
+}
+
+// ProviderConnectivityRequest 提供者网络连通性测试请求
+type ProviderConnectivityRequest struct {
+	Provider string                 `json:"provider" binding:"required"` // 连接池中的提供者名称，与 HTTPClient.Providers 的键相同，如 openai
+	URL      string                 `json:"url" binding:"required"`      // 测试地址，如提供者的 base_url
+	Network  map[string]interface{} `json:"network,omitempty"`           // 与能力配置中的 network 格式相同，叠加在提供者的网络配置之上
+	Timeout  string                 `json:"timeout,omitempty"`           // 如 10s，最长 1m
+}
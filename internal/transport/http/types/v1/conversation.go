@@ -0,0 +1,157 @@
+package v1
+
+import "time"
+
+// ConversationQuery 会话查询参数
+type ConversationQuery struct {
+	Page      int    `form:"page,default=1"`
+	Limit     int    `form:"limit,default=20"`
+	DeviceID  string `form:"device_id"`
+	SessionID string `form:"session_id"`
+	Q         string `form:"q"`    // 消息内容关键字
+	From      string `form:"from"` // RFC3339
+	To        string `form:"to"`   // RFC3339
+}
+
+// ConversationInfo 会话摘要
+type ConversationInfo struct {
+	SessionID string    `json:"session_id"`
+	DeviceID  string    `json:"device_id"`
+	Entries   int64     `json:"entries"`
+	Rounds    int       `json:"rounds"`
+	StartedAt time.Time `json:"started_at"`
+	LastAt    time.Time `json:"last_at"`
+}
+
+// TranscriptEntryInfo 对话记录消息
+type TranscriptEntryInfo struct {
+	ID         string    `json:"id"`
+	Round      int       `json:"round"`
+	Role       string    `json:"role"`
+	Content    string    `json:"content"`
+	ToolName   string    `json:"tool_name,omitempty"`
+	ToolCallID string    `json:"tool_call_id,omitempty"`
+	ToolArgs   string    `json:"tool_args,omitempty"`
+	LatencyMs  int64     `json:"latency_ms"`
+	Redacted   bool      `json:"redacted"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ConversationListResponse 会话列表响应
+type ConversationListResponse struct {
+	Conversations []ConversationInfo `json:"conversations"`
+	Pagination    Pagination         `json:"pagination"`
+}
+
+// ConversationDetailResponse 会话详情响应
+type ConversationDetailResponse struct {
+	Conversation ConversationInfo      `json:"conversation"`
+	Entries      []TranscriptEntryInfo `json:"entries"`
+	Latencies    []TurnLatencyInfo     `json:"latencies"` // 各轮耗时
+}
+
+// TurnLatencyInfo 一轮对话各阶段的耗时（毫秒），本轮未经过的阶段为 0
+type TurnLatencyInfo struct {
+	Round           int       `json:"round"`
+	CaptureMs       int64     `json:"capture_ms"`         // 开始说话到说话结束
+	ASRMs           int64     `json:"asr_ms"`             // 说话结束到识别结果
+	LLMFirstTokenMs int64     `json:"llm_first_token_ms"` // 识别结果到LLM首个输出
+	LLMCompleteMs   int64     `json:"llm_complete_ms"`    // 识别结果到LLM输出结束
+	TTSFirstByteMs  int64     `json:"tts_first_byte_ms"`  // 首句送入TTS到首句音频合成完成
+	PlaybackStartMs int64     `json:"playback_start_ms"`  // 识别结果到首帧音频下发
+	TotalMs         int64     `json:"total_ms"`           // 说话结束到首帧音频下发
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// FeedbackRequest 消息反馈请求
+type FeedbackRequest struct {
+	Rating  string `json:"rating" binding:"omitempty,oneof=up down"` // up/down，可为空仅提交文字
+	Comment string `json:"comment,omitempty" binding:"max=2000"`
+}
+
+// FeedbackQuery 反馈查询参数
+type FeedbackQuery struct {
+	Page      int    `form:"page,default=1"`
+	Limit     int    `form:"limit,default=20"`
+	DeviceID  string `form:"device_id"`
+	SessionID string `form:"session_id"`
+	Rating    string `form:"rating" binding:"omitempty,oneof=up down"`
+	Source    string `form:"source" binding:"omitempty,oneof=api device voice"`
+	From      string `form:"from"` // RFC3339
+	To        string `form:"to"`   // RFC3339
+}
+
+// FeedbackInfo 反馈信息
+type FeedbackInfo struct {
+	ID        string    `json:"id"`
+	SessionID string    `json:"session_id"`
+	EntryID   string    `json:"entry_id,omitempty"`
+	DeviceID  string    `json:"device_id"`
+	Round     int       `json:"round"`
+	Rating    string    `json:"rating"`
+	Comment   string    `json:"comment,omitempty"`
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FeedbackListResponse 反馈列表响应
+type FeedbackListResponse struct {
+	Feedback   []FeedbackInfo `json:"feedback"`
+	Pagination Pagination     `json:"pagination"`
+}
+
+// FeedbackDailyInfo 按天汇总的反馈
+type FeedbackDailyInfo struct {
+	Date       string `json:"date"`
+	ThumbsUp   int64  `json:"thumbs_up"`
+	ThumbsDown int64  `json:"thumbs_down"`
+}
+
+// FeedbackStatsResponse 反馈质量统计响应
+type FeedbackStatsResponse struct {
+	From             time.Time           `json:"from"`
+	To               time.Time           `json:"to"`
+	Total            int64               `json:"total"`
+	ThumbsUp         int64               `json:"thumbs_up"`
+	ThumbsDown       int64               `json:"thumbs_down"`
+	WithComment      int64               `json:"with_comment"`
+	SatisfactionRate float64             `json:"satisfaction_rate"`
+	BySource         map[string]int64    `json:"by_source"`
+	Daily            []FeedbackDailyInfo `json:"daily"`
+}
+
+// LatencyQuery 耗时统计查询参数
+type LatencyQuery struct {
+	DeviceID string `form:"device_id"`
+	From     string `form:"from"` // RFC3339
+	To       string `form:"to"`   // RFC3339
+}
+
+// StageLatencyInfo 单个阶段的耗时分布（毫秒）
+type StageLatencyInfo struct {
+	Stage string `json:"stage"` // capture/asr/llm_first_token/llm_complete/tts_first_byte/playback_start/total
+	Count int64  `json:"count"`
+	AvgMs int64  `json:"avg_ms"`
+	P50Ms int64  `json:"p50_ms"`
+	P90Ms int64  `json:"p90_ms"`
+	P95Ms int64  `json:"p95_ms"`
+	P99Ms int64  `json:"p99_ms"`
+	MaxMs int64  `json:"max_ms"`
+}
+
+// LatencyDailyInfo 按天汇总的端到端耗时
+type LatencyDailyInfo struct {
+	Date       string `json:"date"`
+	Turns      int64  `json:"turns"`
+	P50TotalMs int64  `json:"p50_total_ms"`
+	P95TotalMs int64  `json:"p95_total_ms"`
+}
+
+// LatencyStatsResponse 对话耗时统计响应
+type LatencyStatsResponse struct {
+	From   time.Time          `json:"from"`
+	To     time.Time          `json:"to"`
+	Turns  int64              `json:"turns"`
+	Stages []StageLatencyInfo `json:"stages"`
+	Daily  []LatencyDailyInfo `json:"daily"`
+}
@@ -4,11 +4,11 @@ import "time"
 
 // DeviceRegistrationRequest 设备注册请求
 type DeviceRegistrationRequest struct {
-	DeviceID   string            `json:"device_id" binding:"required"`
-	DeviceName string            `json:"device_name" binding:"required"`
-	DeviceType string            `json:"device_type" binding:"required"`
-	Model      string            `json:"model,omitempty"`
-	Version    string            `json:"version,omitempty"`
+	DeviceID   string            `json:"device_id" binding:"required" example:"AA:BB:CC:DD:EE:FF"`
+	DeviceName string            `json:"device_name" binding:"required" example:"客厅小智"`
+	DeviceType string            `json:"device_type" binding:"required" example:"esp32"`
+	Model      string            `json:"model,omitempty" example:"esp32-s3"`
+	Version    string            `json:"version,omitempty" example:"1.6.0"`
 	Location   *DeviceLocation   `json:"location,omitempty"`
 	Metadata   map[string]interface{} `json:"metadata,omitempty"`
 }
@@ -27,6 +27,7 @@ type DeviceLocation struct {
 // DeviceInfo 设备信息
 type DeviceInfo struct {
 	ID            int64              `json:"id"`
+	TenantID      string             `json:"tenant_id"`
 	DeviceID      string             `json:"device_id"`
 	DeviceName    string             `json:"device_name"`
 	DeviceType    string             `json:"device_type"`
@@ -40,6 +41,7 @@ type DeviceInfo struct {
 	Metadata      map[string]interface{} `json:"metadata"`
 	IsActive      bool               `json:"is_active"`
 	IsActivated   bool               `json:"is_activated"`
+	Media         *MediaState        `json:"media,omitempty"` // 设备正在播放或暂停中的媒体，仅设备详情返回
 	CreatedAt     time.Time          `json:"created_at"`
 	UpdatedAt     time.Time          `json:"updated_at"`
 }
@@ -92,6 +94,7 @@ type DeviceUpdateRequest struct {
 	Configuration map[string]interface{} `json:"configuration,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
 	IsActive     *bool                 `json:"is_active,omitempty"`
+	TenantID     string                `json:"tenant_id,omitempty"` // 转移到其他租户，仅管理员可用
 }
 
 // DeviceQuery 设备查询参数
@@ -0,0 +1,60 @@
+package v1
+
+import "time"
+
+// DeviceDebugLogsQuery 读取设备日志的参数
+type DeviceDebugLogsQuery struct {
+	Lines int    `form:"lines" binding:"min=0"`                                 // 读取的行数，缺省或超过上限时使用 DeviceDebug.MaxLogLines
+	Level string `form:"level" binding:"omitempty,oneof=debug info warn error"` // 最低日志级别
+}
+
+// DeviceDebugRunRequest 执行诊断命令请求
+type DeviceDebugRunRequest struct {
+	Args map[string]interface{} `json:"args,omitempty"` // 命令参数，由固件定义
+}
+
+// DeviceDebugConfigRequest 下发临时配置请求
+type DeviceDebugConfigRequest struct {
+	Overrides  map[string]interface{} `json:"overrides" binding:"required"`          // 覆盖的配置项，由固件定义
+	TTLSeconds int                    `json:"ttl_seconds,omitempty" binding:"min=0"` // 有效期，缺省或超过上限时使用 DeviceDebug.MaxOverrideTTL
+}
+
+// DeviceDebugResult 调试操作的结果
+type DeviceDebugResult struct {
+	AuditID    string      `json:"audit_id"`
+	SessionID  string      `json:"session_id"`
+	Result     interface{} `json:"result,omitempty"` // 设备返回的结果，原样透传
+	DurationMs int64       `json:"duration_ms"`
+}
+
+// DeviceDebugAuditQuery 远程调试审计查询参数
+type DeviceDebugAuditQuery struct {
+	Page     int    `form:"page,default=1"`
+	Limit    int    `form:"limit,default=20"`
+	DeviceID string `form:"device_id"`
+	Action   string `form:"action" binding:"omitempty,oneof=logs commands run config"`
+	From     string `form:"from"` // RFC3339
+	To       string `form:"to"`   // RFC3339
+}
+
+// DeviceDebugAuditInfo 远程调试审计记录
+type DeviceDebugAuditInfo struct {
+	ID         string    `json:"id"`
+	DeviceID   string    `json:"device_id"`
+	SessionID  string    `json:"session_id"`
+	Action     string    `json:"action"` // logs/commands/run/config
+	Command    string    `json:"command,omitempty"`
+	Params     string    `json:"params"` // 发给设备的参数 JSON
+	Success    bool      `json:"success"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	RequestID  string    `json:"request_id"`
+	ClientIP   string    `json:"client_ip"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// DeviceDebugAuditListResponse 远程调试审计列表响应
+type DeviceDebugAuditListResponse struct {
+	Records    []DeviceDebugAuditInfo `json:"records"`
+	Pagination Pagination             `json:"pagination"`
+}
@@ -0,0 +1,123 @@
+package v1
+
+import "time"
+
+// EvalCase 评测用例
+type EvalCase struct {
+	ID       string `json:"id,omitempty"`
+	Input    string `json:"input,omitempty"`    // LLM 提示词或 TTS 文本
+	Audio    string `json:"audio,omitempty"`    // ASR 音频，base64 编码
+	Expected string `json:"expected,omitempty"` // 期望回答或参考文本
+}
+
+// EvalSuiteCreateRequest 创建测试集请求
+type EvalSuiteCreateRequest struct {
+	Name         string     `json:"name" binding:"required,max=255"`
+	Type         string     `json:"type" binding:"required,oneof=llm asr tts"`
+	Description  string     `json:"description,omitempty" binding:"max=1024"`
+	SystemPrompt string     `json:"system_prompt,omitempty"`
+	Cases        []EvalCase `json:"cases" binding:"required,min=1"`
+}
+
+// EvalSuiteQuery 测试集查询参数
+type EvalSuiteQuery struct {
+	Page  int `form:"page,default=1"`
+	Limit int `form:"limit,default=20"`
+}
+
+// EvalSuiteInfo 测试集信息
+type EvalSuiteInfo struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	Type         string     `json:"type"`
+	Description  string     `json:"description,omitempty"`
+	SystemPrompt string     `json:"system_prompt,omitempty"`
+	CaseCount    int        `json:"case_count"`
+	Cases        []EvalCase `json:"cases,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// EvalSuiteListResponse 测试集列表响应
+type EvalSuiteListResponse struct {
+	Suites     []EvalSuiteInfo `json:"suites"`
+	Pagination Pagination      `json:"pagination"`
+}
+
+// EvalTarget 被测能力
+type EvalTarget struct {
+	CapabilityID string                 `json:"capability_id" binding:"required"`
+	Config       map[string]interface{} `json:"config,omitempty"`
+}
+
+// EvalRunRequest 发起评测请求
+type EvalRunRequest struct {
+	SuiteID string       `json:"suite_id" binding:"required"`
+	Targets []EvalTarget `json:"targets" binding:"required,min=1,dive"`
+	Judge   *EvalTarget  `json:"judge,omitempty"` // LLM 评审，仅 llm 测试集可用
+}
+
+// EvalRunQuery 评测运行查询参数
+type EvalRunQuery struct {
+	Page    int    `form:"page,default=1"`
+	Limit   int    `form:"limit,default=20"`
+	SuiteID string `form:"suite_id"`
+}
+
+// EvalCaseResult 用例结果
+type EvalCaseResult struct {
+	CaseID     string   `json:"case_id"`
+	Output     string   `json:"output"`
+	Similarity float64  `json:"similarity,omitempty"`
+	JudgeScore *float64 `json:"judge_score,omitempty"`
+	WER        float64  `json:"wer,omitempty"`
+	LatencyMs  int64    `json:"latency_ms"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// EvalTargetReport 单个能力的评测报告
+type EvalTargetReport struct {
+	CapabilityID  string           `json:"capability_id"`
+	Total         int              `json:"total"`
+	Succeeded     int              `json:"succeeded"`
+	AvgSimilarity float64          `json:"avg_similarity,omitempty"`
+	AvgJudgeScore float64          `json:"avg_judge_score,omitempty"`
+	AvgWER        float64          `json:"avg_wer,omitempty"`
+	AvgLatencyMs  float64          `json:"avg_latency_ms"`
+	P95LatencyMs  int64            `json:"p95_latency_ms"`
+	Cases         []EvalCaseResult `json:"cases,omitempty"`
+}
+
+// EvalRunInfo 评测运行信息
+type EvalRunInfo struct {
+	ID         string             `json:"id"`
+	SuiteID    string             `json:"suite_id"`
+	SuiteType  string             `json:"suite_type"`
+	Targets    []EvalTarget       `json:"targets"`
+	Judge      *EvalTarget        `json:"judge,omitempty"`
+	Status     string             `json:"status"`
+	Error      string             `json:"error,omitempty"`
+	Reports    []EvalTargetReport `json:"reports,omitempty"`
+	CreatedAt  time.Time          `json:"created_at"`
+	FinishedAt *time.Time         `json:"finished_at,omitempty"`
+}
+
+// EvalRunListResponse 评测运行列表响应
+type EvalRunListResponse struct {
+	Runs       []EvalRunInfo `json:"runs"`
+	Pagination Pagination    `json:"pagination"`
+}
+
+// EvalCaseComparison 同一用例在各次运行上的结果
+type EvalCaseComparison struct {
+	CaseID   string                    `json:"case_id"`
+	Input    string                    `json:"input,omitempty"`
+	Expected string                    `json:"expected,omitempty"`
+	Results  map[string]EvalCaseResult `json:"results"` // key: 运行ID/能力ID
+}
+
+// EvalCompareResponse 评测对比响应
+type EvalCompareResponse struct {
+	SuiteID string               `json:"suite_id"`
+	Runs    []EvalRunInfo        `json:"runs"`
+	Cases   []EvalCaseComparison `json:"cases"`
+}
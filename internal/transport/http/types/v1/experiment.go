@@ -0,0 +1,72 @@
+package v1
+
+import "time"
+
+// ExperimentVariant 实验分组
+type ExperimentVariant struct {
+	Name           string  `json:"name" binding:"required,max=64"`
+	Weight         int     `json:"weight" binding:"min=0"`
+	PromptTemplate string  `json:"prompt_template,omitempty"`
+	PromptVersion  int     `json:"prompt_version,omitempty" binding:"min=0"`
+	LLM            string  `json:"llm,omitempty"`
+	CostPer1KToken float64 `json:"cost_per_1k_token,omitempty" binding:"min=0"`
+}
+
+// ExperimentCreateRequest 创建实验请求
+type ExperimentCreateRequest struct {
+	Name           string              `json:"name" binding:"required,max=255"`
+	Description    string              `json:"description,omitempty" binding:"max=1024"`
+	TrafficPercent int                 `json:"traffic_percent,omitempty" binding:"min=0,max=100"`
+	Cohort         []string            `json:"cohort,omitempty"`
+	Variants       []ExperimentVariant `json:"variants" binding:"required,min=2,dive"`
+}
+
+// ExperimentQuery 实验查询参数
+type ExperimentQuery struct {
+	Page   int    `form:"page,default=1"`
+	Limit  int    `form:"limit,default=20"`
+	Status string `form:"status" binding:"omitempty,oneof=draft running stopped"`
+}
+
+// ExperimentInfo 实验信息
+type ExperimentInfo struct {
+	ID             string              `json:"id"`
+	Name           string              `json:"name"`
+	Description    string              `json:"description,omitempty"`
+	Status         string              `json:"status"`
+	TrafficPercent int                 `json:"traffic_percent"`
+	Cohort         []string            `json:"cohort,omitempty"`
+	Variants       []ExperimentVariant `json:"variants"`
+	CreatedAt      time.Time           `json:"created_at"`
+	StartedAt      *time.Time          `json:"started_at,omitempty"`
+	StoppedAt      *time.Time          `json:"stopped_at,omitempty"`
+}
+
+// ExperimentListResponse 实验列表响应
+type ExperimentListResponse struct {
+	Experiments []ExperimentInfo `json:"experiments"`
+	Pagination  Pagination       `json:"pagination"`
+}
+
+// ExperimentVariantResult 实验分组结果
+type ExperimentVariantResult struct {
+	Variant          string  `json:"variant"`
+	Samples          int64   `json:"samples"`
+	AvgLatencyMs     float64 `json:"avg_latency_ms"`
+	PromptTokens     int64   `json:"prompt_tokens"`
+	CompletionTokens int64   `json:"completion_tokens"`
+	AvgTokens        float64 `json:"avg_tokens"`
+	EstimatedCost    float64 `json:"estimated_cost"`
+	InterruptionRate float64 `json:"interruption_rate"`
+	ThumbsUp         int64   `json:"thumbs_up"`
+	ThumbsDown       int64   `json:"thumbs_down"`
+	SatisfactionRate float64 `json:"satisfaction_rate"`
+}
+
+// ExperimentResultsResponse 实验结果响应
+type ExperimentResultsResponse struct {
+	ExperimentID string                    `json:"experiment_id"`
+	Name         string                    `json:"name"`
+	Status       string                    `json:"status"`
+	Variants     []ExperimentVariantResult `json:"variants"`
+}
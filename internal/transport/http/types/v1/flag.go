@@ -0,0 +1,50 @@
+package v1
+
+import "time"
+
+// FlagRule 开关规则，为一组租户或设备覆盖取值
+type FlagRule struct {
+	Name    string   `json:"name,omitempty" binding:"max=64"` // 设备分组名称
+	Tenants []string `json:"tenants,omitempty"`               // 租户ID，空表示任意租户
+	Devices []string `json:"devices,omitempty"`               // 设备ID，空表示租户下的全部设备
+	Enabled bool     `json:"enabled"`
+}
+
+// FlagSetRequest 创建或更新开关请求
+type FlagSetRequest struct {
+	Description string     `json:"description,omitempty" binding:"max=1024"`
+	Enabled     bool       `json:"enabled"`                        // 没有匹配的规则时的取值
+	Rules       []FlagRule `json:"rules,omitempty" binding:"dive"` // 按顺序匹配，第一条匹配的规则决定取值
+}
+
+// FlagInfo 开关信息
+type FlagInfo struct {
+	Key         string     `json:"key"`
+	Description string     `json:"description,omitempty"`
+	Enabled     bool       `json:"enabled"`
+	Rules       []FlagRule `json:"rules"`
+	BuiltIn     bool       `json:"built_in"`             // 内置开关，删除后恢复默认值
+	Stored      bool       `json:"stored"`               // 为 false 时是内置开关的默认值
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"` // 未修改过的内置开关为空
+}
+
+// FlagListResponse 开关列表响应
+type FlagListResponse struct {
+	Flags []FlagInfo `json:"flags"`
+}
+
+// FlagEvaluateQuery 开关取值查询参数
+type FlagEvaluateQuery struct {
+	TenantID string `form:"tenant_id"` // 缺省为默认租户
+	DeviceID string `form:"device_id"`
+}
+
+// FlagDecision 开关对租户和设备的取值
+type FlagDecision struct {
+	Key      string `json:"key"`
+	TenantID string `json:"tenant_id"`
+	DeviceID string `json:"device_id,omitempty"`
+	Enabled  bool   `json:"enabled"`
+	Rule     string `json:"rule,omitempty"` // 命中的规则名称，未命名时为 "#序号"
+	Source   string `json:"source"`         // rule / flag / default / unknown
+}
@@ -0,0 +1,50 @@
+package v1
+
+import "time"
+
+// JobQuery 后台任务查询参数
+type JobQuery struct {
+	Page   int    `form:"page,default=1"`
+	Limit  int    `form:"limit,default=20"`
+	Type   string `form:"type"`
+	Status string `form:"status" binding:"omitempty,oneof=pending running succeeded dead canceled"`
+	Key    string `form:"key"`
+}
+
+// JobInfo 后台任务
+type JobInfo struct {
+	ID          string      `json:"id"`
+	Type        string      `json:"type"`
+	Key         string      `json:"key,omitempty"`
+	Payload     interface{} `json:"payload"`
+	Status      string      `json:"status"` // pending、running、succeeded、dead（死信）或 canceled
+	Attempts    int         `json:"attempts"`
+	MaxAttempts int         `json:"max_attempts"`
+	RunAt       time.Time   `json:"run_at"` // 最早可执行时间，等待重试时为退避后的时间
+	LeaseUntil  *time.Time  `json:"lease_until,omitempty"`
+	LastError   string      `json:"last_error,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+	StartedAt   *time.Time  `json:"started_at,omitempty"`
+	FinishedAt  *time.Time  `json:"finished_at,omitempty"`
+}
+
+// JobListResponse 后台任务列表响应
+type JobListResponse struct {
+	Jobs       []JobInfo  `json:"jobs"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// JobTypeStats 单个任务类型的统计
+type JobTypeStats struct {
+	Type     string           `json:"type"`
+	Workers  int              `json:"workers"`  // 本实例的工作协程数
+	Running  int              `json:"running"`  // 本实例执行中的任务数
+	Counts   map[string]int64 `json:"counts"`   // 各状态的任务数
+	Failures int64            `json:"failures"` // 本实例启动以来失败的执行次数
+}
+
+// JobStatsResponse 后台任务统计响应
+type JobStatsResponse struct {
+	Types []JobTypeStats `json:"types"`
+}
@@ -0,0 +1,85 @@
+package v1
+
+import "time"
+
+// KnowledgeBaseInfo 知识库信息
+type KnowledgeBaseInfo struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// KnowledgeBaseCreateRequest 创建知识库请求
+type KnowledgeBaseCreateRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description,omitempty"`
+}
+
+// KnowledgeBaseUpdateRequest 更新知识库请求，省略的字段不修改
+type KnowledgeBaseUpdateRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// KnowledgeDocumentInfo 知识库文档及其导入进度
+type KnowledgeDocumentInfo struct {
+	ID          string    `json:"id"`
+	BaseID      string    `json:"knowledge_base_id"`
+	Name        string    `json:"name"`
+	SourceType  string    `json:"source_type"` // file/url
+	Source      string    `json:"source"`      // 文件名或网页地址
+	Format      string    `json:"format"`      // pdf/markdown/text/html
+	Size        int64     `json:"size"`
+	Status      string    `json:"status"`   // pending/extracting/chunking/embedding/ready/failed
+	Progress    int       `json:"progress"` // 0-100
+	Chunks      int       `json:"chunks"`
+	Embedding   string    `json:"embedding"`           // 生成向量使用的能力ID
+	ExecutionID string    `json:"execution_id"`        // 导入任务的工作流执行ID
+	ObjectID    string    `json:"object_id,omitempty"` // 上传文件在对象存储中的ID，可通过 /api/v1/objects/:id/url 获取下载地址
+	Error       string    `json:"error,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// KnowledgeURLRequest 导入网页请求
+type KnowledgeURLRequest struct {
+	URL  string `json:"url" binding:"required"`
+	Name string `json:"name,omitempty"` // 缺省使用网页地址
+}
+
+// KnowledgeSearchRequest 知识库检索请求，指定 knowledge_base_ids 或 device_id
+type KnowledgeSearchRequest struct {
+	Query            string   `json:"query" binding:"required"`
+	KnowledgeBaseIDs []string `json:"knowledge_base_ids,omitempty"`
+	DeviceID         string   `json:"device_id,omitempty"` // 检索设备所属档案绑定的知识库
+	TopK             int      `json:"top_k,omitempty"`
+}
+
+// KnowledgeHit 检索命中的分块
+type KnowledgeHit struct {
+	KnowledgeBaseID string  `json:"knowledge_base_id"`
+	DocumentID      string  `json:"document_id"`
+	DocumentName    string  `json:"document_name"`
+	ChunkIndex      int     `json:"chunk_index"`
+	Content         string  `json:"content"`
+	Score           float64 `json:"score"`
+}
+
+// KnowledgeSearchResponse 知识库检索结果
+type KnowledgeSearchResponse struct {
+	Hits    []KnowledgeHit `json:"hits"`
+	Context string         `json:"context"` // 附加给 LLM 的带出处编号的参考资料
+}
+
+// KnowledgeBindingRequest 设置档案绑定请求
+type KnowledgeBindingRequest struct {
+	KnowledgeBaseIDs []string `json:"knowledge_base_ids"` // 为空时解除绑定
+}
+
+// KnowledgeBindingInfo 档案绑定的知识库
+type KnowledgeBindingInfo struct {
+	Profile          string   `json:"profile"`
+	KnowledgeBaseIDs []string `json:"knowledge_base_ids"`
+}
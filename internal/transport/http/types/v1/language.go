@@ -0,0 +1,30 @@
+package v1
+
+import "time"
+
+// LanguageInfo 支持的语言
+type LanguageInfo struct {
+	Code  string `json:"code"`
+	Name  string `json:"name"`
+	Voice string `json:"voice,omitempty"` // 该语言的播报音色，未配置时使用设备默认音色
+}
+
+// LanguageListResponse 支持的语言列表响应
+type LanguageListResponse struct {
+	Enabled   bool           `json:"enabled"`   // 是否启用语言自动识别
+	Allowed   []string       `json:"allowed"`   // 未单独设置的设备允许的语言
+	Languages []LanguageInfo `json:"languages"` // 支持的语言
+}
+
+// DeviceLanguagesRequest 设置设备允许语言请求
+type DeviceLanguagesRequest struct {
+	Languages []string `json:"languages" binding:"required,min=1,max=8"` // 语言代码或中文叫法，第一个为主要语言
+}
+
+// DeviceLanguagesInfo 设备允许的语言
+type DeviceLanguagesInfo struct {
+	DeviceID  string     `json:"device_id"`
+	Languages []string   `json:"languages"`            // 第一个为主要语言，使用设备默认音色
+	Custom    bool       `json:"custom"`               // 为 false 时是配置的默认值
+	UpdatedAt *time.Time `json:"updated_at,omitempty"` // 使用默认值时为空
+}
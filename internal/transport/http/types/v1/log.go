@@ -0,0 +1,30 @@
+package v1
+
+import "time"
+
+// LogQuery 日志查询参数
+type LogQuery struct {
+	Page  int    `form:"page,default=1"`
+	Limit int    `form:"limit,default=50"`
+	Level string `form:"level"` // 最低级别 debug/info/warn/error，缺省不过滤
+	Tag   string `form:"tag"`   // 日志标签，如 API、引导
+	Q     string `form:"q"`     // 在日志正文和属性中搜索的文本
+	From  string `form:"from"`  // RFC3339
+	To    string `form:"to"`    // RFC3339
+}
+
+// LogEntryInfo 日志记录
+type LogEntryInfo struct {
+	Time    time.Time              `json:"time"`
+	Level   string                 `json:"level"`
+	Tag     string                 `json:"tag,omitempty"`
+	Message string                 `json:"message"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+}
+
+// LogListResponse 日志查询响应
+type LogListResponse struct {
+	Entries    []LogEntryInfo `json:"entries"`
+	Pagination Pagination     `json:"pagination"`
+	Dropped    uint64         `json:"dropped"` // 启动以来因队列已满或写入失败未进入索引的日志条数
+}
@@ -0,0 +1,39 @@
+package v1
+
+import "time"
+
+// MediaTrack 曲目或电台
+type MediaTrack struct {
+	Title  string `json:"title"`
+	Artist string `json:"artist,omitempty"`
+	Source string `json:"source"`        // library/radio/provider
+	URL    string `json:"url,omitempty"` // 电台和媒体能力返回的音频流地址，本地曲库不返回
+	Live   bool   `json:"live"`
+}
+
+// MediaSearchQuery 媒体检索参数
+type MediaSearchQuery struct {
+	Query  string `form:"q"`                                                       // 为空时随机返回曲库中的曲目
+	Source string `form:"source" binding:"omitempty,oneof=library radio provider"` // 为空时依次检索电台、曲库和媒体能力
+}
+
+// MediaState 设备的播放状态
+type MediaState struct {
+	DeviceID   string      `json:"device_id"`
+	Status     string      `json:"status"` // idle/loading/playing/paused
+	Track      *MediaTrack `json:"track,omitempty"`
+	Index      int         `json:"index"`      // 当前曲目在播放列表中的位置
+	QueueSize  int         `json:"queue_size"` // 播放列表长度
+	Volume     int         `json:"volume"`     // 0-100
+	PositionMs int64       `json:"position_ms"`
+	Error      string      `json:"error,omitempty"` // 最近一次播放失败的原因
+	UpdatedAt  *time.Time  `json:"updated_at,omitempty"`
+}
+
+// MediaControlRequest 播放控制请求
+type MediaControlRequest struct {
+	Action string `json:"action" binding:"required,oneof=play pause resume stop next volume"`
+	Query  string `json:"query,omitempty"`                                                   // action 为 play 时点播的内容，为空时随机播放
+	Source string `json:"source,omitempty" binding:"omitempty,oneof=library radio provider"` // action 为 play 时的检索来源
+	Volume *int   `json:"volume,omitempty" binding:"omitempty,min=0,max=100"`                // action 为 volume 时设置的音量
+}
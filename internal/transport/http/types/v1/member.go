@@ -0,0 +1,41 @@
+package v1
+
+import "time"
+
+// BindDeviceRequest 绑定设备请求
+type BindDeviceRequest struct {
+	DeviceID string `json:"device_id" binding:"required" example:"AA:BB:CC:DD:EE:FF"`
+	Role     string `json:"role,omitempty" binding:"omitempty,oneof=owner member" example:"owner"` // 默认 member
+}
+
+// DeviceBindingInfo 用户与设备的绑定
+type DeviceBindingInfo struct {
+	UserID    uint      `json:"user_id"`
+	DeviceID  string    `json:"device_id"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MemberProfileInfo 成员资料
+type MemberProfileInfo struct {
+	UserID          uint      `json:"user_id"`
+	Name            string    `json:"name"`
+	Voice           string    `json:"voice"`
+	Language        string    `json:"language"`
+	MemoryNamespace string    `json:"memory_namespace"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// MemberProfileUpdateRequest 修改成员资料请求，未出现的字段保持不变
+type MemberProfileUpdateRequest struct {
+	Name            *string `json:"name,omitempty" example:"小明"`
+	Voice           *string `json:"voice,omitempty" example:"zh-CN-XiaoxiaoNeural"`
+	Language        *string `json:"language,omitempty" example:"zh-CN"`
+	MemoryNamespace *string `json:"memory_namespace,omitempty"` // 置空时恢复默认的 user-<id>
+}
+
+// DeviceMemberInfo 设备上的成员
+type DeviceMemberInfo struct {
+	DeviceBindingInfo
+	Profile MemberProfileInfo `json:"profile"`
+}
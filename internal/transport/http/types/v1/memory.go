@@ -0,0 +1,54 @@
+package v1
+
+import "time"
+
+// MemoryQuery 长期记忆查询参数
+type MemoryQuery struct {
+	Page  int    `form:"page,default=1"`
+	Limit int    `form:"limit,default=20"`
+	Q     string `form:"q"` // 内容关键字
+}
+
+// MemoryFactRequest 添加或修改记忆请求
+type MemoryFactRequest struct {
+	Content string `json:"content" binding:"required"` // 最多 500 字
+}
+
+// MemoryFactInfo 一条长期记忆
+type MemoryFactInfo struct {
+	ID        string    `json:"id"`
+	Namespace string    `json:"namespace"`
+	Content   string    `json:"content"`
+	Source    string    `json:"source"`     // extracted（从对话中提取）/manual（手动添加或修改）
+	DeviceID  string    `json:"device_id"`  // 提取自该设备的对话
+	SessionID string    `json:"session_id"` // 提取自该会话
+	EntryID   string    `json:"entry_id"`   // 对应对话记录中的用户消息
+	Evidence  string    `json:"evidence"`   // 提取依据的用户原话
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// MemoryFactListResponse 长期记忆列表响应
+type MemoryFactListResponse struct {
+	Facts      []MemoryFactInfo `json:"facts"`
+	Pagination Pagination       `json:"pagination"`
+}
+
+// MemoryClearResponse 清空记忆的结果
+type MemoryClearResponse struct {
+	Deleted int64 `json:"deleted"`
+}
+
+// MemoryConsentRequest 设置记忆提取授权请求
+type MemoryConsentRequest struct {
+	Granted        *bool `json:"granted" binding:"required"`
+	DeleteExisting bool  `json:"delete_existing,omitempty"` // 撤销授权时同时删除已有记忆
+}
+
+// MemoryConsentInfo 用户记忆提取授权
+type MemoryConsentInfo struct {
+	UserID    uint      `json:"user_id"`
+	Granted   bool      `json:"granted"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Deleted   int64     `json:"deleted,omitempty"` // 本次删除的记忆条数
+}
@@ -0,0 +1,54 @@
+package v1
+
+import "time"
+
+// MonitorSnippet 会话中的一条对话片段
+type MonitorSnippet struct {
+	Role    string    `json:"role"`    // user/assistant
+	Content string    `json:"content"` // 超过 200 字的部分截断
+	At      time.Time `json:"at"`
+}
+
+// MonitorFlag 管理员对会话的旁听标记
+type MonitorFlag struct {
+	Note      string    `json:"note,omitempty"`
+	FlaggedAt time.Time `json:"flagged_at"`
+}
+
+// MonitorSession 在线设备会话的实时状态
+type MonitorSession struct {
+	SessionID   string           `json:"session_id"`
+	DeviceID    string           `json:"device_id"`
+	TenantID    string           `json:"tenant_id,omitempty"`
+	State       string           `json:"state"` // idle/listening/thinking/speaking
+	Round       int              `json:"round"`
+	LLM         string           `json:"llm,omitempty"`   // 提供者/模型
+	TTS         string           `json:"tts,omitempty"`   // TTS 配置名
+	Voice       string           `json:"voice,omitempty"` // 当前播报使用的音色
+	ConnectedAt time.Time        `json:"connected_at"`
+	Recent      []MonitorSnippet `json:"recent"` // 最近的对话片段，按时间顺序
+	Flag        *MonitorFlag     `json:"flag,omitempty"`
+}
+
+// MonitorSessionQuery 在线会话查询参数
+type MonitorSessionQuery struct {
+	TenantID string `form:"tenant_id"`
+	DeviceID string `form:"device_id"`
+	State    string `form:"state" binding:"omitempty,oneof=idle listening thinking speaking"`
+	Flagged  bool   `form:"flagged"` // 只返回已标记旁听的会话
+}
+
+// MonitorFlagRequest 标记旁听请求
+type MonitorFlagRequest struct {
+	Note string `json:"note,omitempty" binding:"max=500"` // 标记原因
+}
+
+// MonitorEvent 旁听会话的实时事件，作为SSE事件的数据，事件名即 type
+type MonitorEvent struct {
+	Type      string          `json:"type"` // state/snippet/closed
+	SessionID string          `json:"session_id"`
+	State     string          `json:"state,omitempty"` // type 为 state 时的新状态
+	Round     int             `json:"round,omitempty"`
+	Snippet   *MonitorSnippet `json:"snippet,omitempty"` // type 为 snippet 时的对话片段
+	At        time.Time       `json:"at"`
+}
@@ -0,0 +1,32 @@
+package v1
+
+import "time"
+
+// NotificationChannelCreateRequest 创建通知渠道请求
+type NotificationChannelCreateRequest struct {
+	Name    string                 `json:"name" binding:"required"`
+	Type    string                 `json:"type" binding:"required,oneof=email webhook dingtalk feishu telegram"`
+	Config  map[string]interface{} `json:"config" binding:"required"`
+	Events  []string               `json:"events,omitempty"` // plugin_crashed, provider_outage, budget_exceeded 或 *
+	Enabled *bool                  `json:"enabled,omitempty"`
+}
+
+// NotificationChannelUpdateRequest 更新通知渠道请求
+type NotificationChannelUpdateRequest struct {
+	Name    *string                `json:"name,omitempty"`
+	Config  map[string]interface{} `json:"config,omitempty"` // 敏感字段传 ****** 表示保留原值
+	Events  []string               `json:"events,omitempty"`
+	Enabled *bool                  `json:"enabled,omitempty"`
+}
+
+// NotificationChannelInfo 通知渠道信息，敏感配置已脱敏
+type NotificationChannelInfo struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	Type      string                 `json:"type"`
+	Config    map[string]interface{} `json:"config"`
+	Events    []string               `json:"events"`
+	Enabled   bool                   `json:"enabled"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
@@ -0,0 +1,43 @@
+package v1
+
+import "time"
+
+// ObjectQuery 对象列表查询参数
+type ObjectQuery struct {
+	Page      int    `form:"page,default=1"`
+	Limit     int    `form:"limit,default=20"`
+	Namespace string `form:"namespace"`
+	Name      string `form:"name"`
+}
+
+// ObjectInfo 对象元数据
+type ObjectInfo struct {
+	ID          string     `json:"id"` // 内容的 SHA-256
+	Namespace   string     `json:"namespace"`
+	Name        string     `json:"name"`
+	ContentType string     `json:"content_type"`
+	Size        int64      `json:"size"`
+	Refs        int        `json:"refs"`                 // 长期引用数
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"` // 为空表示由引用方释放
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// ObjectListResponse 对象列表响应
+type ObjectListResponse struct {
+	Objects    []ObjectInfo `json:"objects"`
+	Pagination Pagination   `json:"pagination"`
+}
+
+// ObjectURLResponse 签名下载地址
+type ObjectURLResponse struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ObjectUploadResponse 上传结果
+type ObjectUploadResponse struct {
+	Object ObjectInfo `json:"object"`
+	URL    string     `json:"url"` // 默认有效期的签名下载地址
+}
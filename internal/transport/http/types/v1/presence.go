@@ -0,0 +1,38 @@
+package v1
+
+import "time"
+
+// PresenceStatus 成员在家状态
+type PresenceStatus struct {
+	UserID    uint       `json:"user_id"`
+	Name      string     `json:"name"`
+	State     string     `json:"state"`            // home / away / unknown
+	Source    string     `json:"source,omitempty"` // 进入当前状态的来源：app / geofence / router
+	Since     *time.Time `json:"since,omitempty"`  // 进入当前状态的时间
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// PresenceOverview 全部成员的在家状态
+type PresenceOverview struct {
+	AnyoneHome bool             `json:"anyone_home"`
+	Home       []string         `json:"home"` // 在家成员的称呼
+	Members    []PresenceStatus `json:"members"`
+}
+
+// PresenceCheckInRequest 配套App签到请求，state 和位置二选一
+type PresenceCheckInRequest struct {
+	State     string   `json:"state,omitempty" binding:"omitempty,oneof=home away"`
+	Latitude  *float64 `json:"latitude,omitempty" binding:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude,omitempty" binding:"omitempty,min=-180,max=180"`
+	Accuracy  float64  `json:"accuracy,omitempty" binding:"omitempty,min=0"` // 定位精度（米）
+}
+
+// PresenceRouterReport 路由器推送的在线设备
+type PresenceRouterReport struct {
+	MACs []string `json:"macs" binding:"required"`
+}
+
+// PresenceRouterReportResponse 路由器推送结果
+type PresenceRouterReportResponse struct {
+	Matched int `json:"matched"` // 其中属于成员的设备数
+}
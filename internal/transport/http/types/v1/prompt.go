@@ -0,0 +1,73 @@
+package v1
+
+import "time"
+
+// PromptCreateRequest 创建提示词模板请求
+type PromptCreateRequest struct {
+	Name        string            `json:"name" binding:"required,max=64" example:"kids-tutor"`
+	Description string            `json:"description,omitempty" binding:"max=512"`
+	Content     string            `json:"content" binding:"required" example:"你是孩子的学习伙伴，回答要简短易懂"`
+	Variables   map[string]string `json:"variables,omitempty"`
+}
+
+// PromptUpdateRequest 更新提示词模板请求，生成新版本
+type PromptUpdateRequest struct {
+	Description *string           `json:"description,omitempty"`
+	Content     *string           `json:"content,omitempty"`
+	Variables   map[string]string `json:"variables,omitempty"`
+}
+
+// PromptRenderRequest 测试渲染请求
+// Content 非空时渲染该内容（不保存），否则渲染已保存的模板版本
+type PromptRenderRequest struct {
+	Content   string            `json:"content,omitempty"`
+	Variables map[string]string `json:"variables,omitempty"` // Content 的变量默认值
+	Version   int               `json:"version,omitempty"`
+	Vars      map[string]string `json:"vars,omitempty"` // 本次渲染传入的变量
+}
+
+// PromptRenderResponse 测试渲染响应
+type PromptRenderResponse struct {
+	Name     string `json:"name,omitempty"`
+	Version  int    `json:"version,omitempty"`
+	Rendered string `json:"rendered"`
+}
+
+// PromptQuery 提示词模板查询参数
+type PromptQuery struct {
+	Page  int    `form:"page,default=1"`
+	Limit int    `form:"limit,default=20"`
+	Name  string `form:"name"`
+}
+
+// PromptInfo 提示词模板信息
+type PromptInfo struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Version     int               `json:"version"`
+	Description string            `json:"description,omitempty"`
+	Content     string            `json:"content"`
+	Variables   map[string]string `json:"variables,omitempty"`
+	Active      bool              `json:"active"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+// PromptListResponse 提示词模板列表响应
+type PromptListResponse struct {
+	Prompts    []PromptInfo `json:"prompts"`
+	Pagination Pagination   `json:"pagination"`
+}
+
+// PromptAssignRequest 模板分配请求
+type PromptAssignRequest struct {
+	TemplateName string `json:"template_name" binding:"required"`
+	Version      int    `json:"version,omitempty" binding:"min=0"` // 0 表示跟随生效版本
+}
+
+// PromptAssignmentInfo 模板分配信息
+type PromptAssignmentInfo struct {
+	DeviceID     string    `json:"device_id"`
+	TemplateName string    `json:"template_name"`
+	Version      int       `json:"version"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
@@ -0,0 +1,45 @@
+package v1
+
+import "time"
+
+// ProviderCallQuery 提供者调用录制查询参数
+type ProviderCallQuery struct {
+	Page       int    `form:"page,default=1"`
+	Limit      int    `form:"limit,default=20"`
+	Provider   string `form:"provider"`   // 提供者ID
+	Capability string `form:"capability"` // 能力ID
+	DeviceID   string `form:"device_id"`
+	Success    string `form:"success"` // true/false，缺省不按结果过滤
+	Q          string `form:"q"`       // 在请求、响应和错误信息中搜索的文本
+	From       string `form:"from"`    // RFC3339
+	To         string `form:"to"`      // RFC3339
+}
+
+// ProviderCallSummary 提供者调用录制摘要，列表中不含请求和响应
+type ProviderCallSummary struct {
+	ID             string    `json:"id"`
+	ProviderID     string    `json:"provider_id"`
+	CapabilityID   string    `json:"capability_id"`
+	CapabilityType string    `json:"capability_type"`
+	DeviceID       string    `json:"device_id"`
+	SessionID      string    `json:"session_id"`
+	Stream         bool      `json:"stream"`
+	Chunks         int       `json:"chunks"`
+	Success        bool      `json:"success"`
+	Error          string    `json:"error"`
+	DurationMs     int64     `json:"duration_ms"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ProviderCallInfo 提供者调用录制详情
+type ProviderCallInfo struct {
+	ProviderCallSummary
+	Request  string `json:"request"`  // JSON，包含 config 和 inputs，已脱敏
+	Response string `json:"response"` // JSON，流式调用为分块数组，已脱敏
+}
+
+// ProviderCallListResponse 提供者调用录制列表响应
+type ProviderCallListResponse struct {
+	Calls      []ProviderCallSummary `json:"calls"`
+	Pagination Pagination            `json:"pagination"`
+}
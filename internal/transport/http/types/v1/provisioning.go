@@ -0,0 +1,58 @@
+package v1
+
+import "time"
+
+// PairingTokenInfo 新签发的配对令牌，令牌只在签发时返回一次
+type PairingTokenInfo struct {
+	Token     string    `json:"token"`
+	Payload   string    `json:"payload" example:"xiaozhi://pair?server=https%3A%2F%2Fexample.com&token=pair_..."` // 二维码内容
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ProvisioningClaimRequest 用配对令牌换取设备凭证的请求
+type ProvisioningClaimRequest struct {
+	Token      string `json:"token" binding:"required"`
+	DeviceID   string `json:"device_id" binding:"required" example:"AA:BB:CC:DD:EE:FF"`
+	ClientID   string `json:"client_id,omitempty"` // 为空时使用 client_<设备ID>
+	DeviceName string `json:"device_name,omitempty" example:"客厅小智"`
+	DeviceType string `json:"device_type,omitempty" example:"esp32-s3"`
+	Model      string `json:"model,omitempty"`
+	Version    string `json:"version,omitempty" example:"1.0.0"`
+}
+
+// ProvisioningClaimResponse 配对结果，设备凭证只在配对时返回一次
+// Provisioning.DeviceAuth 为 token 时返回 device_token，为 jwt 时返回 key
+type ProvisioningClaimResponse struct {
+	DeviceID    string               `json:"device_id"`
+	ClientID    string               `json:"client_id"`
+	UserID      uint                 `json:"user_id"` // 设备所有者
+	TenantID    string               `json:"tenant_id"`
+	DeviceToken string               `json:"device_token,omitempty"` // 连接 WebSocket 时以 Authorization: Bearer 携带
+	Key         *IssuedDeviceKeyInfo `json:"key,omitempty"`
+	Websocket   string               `json:"websocket"`
+}
+
+// IssuedDeviceKeyInfo 新签发的设备密钥，私钥只返回一次
+// 设备连接 WebSocket 时以 Authorization: Bearer 携带用私钥签名的 JWT，头部 kid 为 key_id，声明 sub 为设备ID并包含 iat 和 exp
+type IssuedDeviceKeyInfo struct {
+	KeyID      string `json:"key_id"`
+	Algorithm  string `json:"algorithm" example:"ES256"`
+	PrivateKey string `json:"private_key"` // PEM 编码的 P-256 私钥
+	PublicKey  string `json:"public_key"`
+}
+
+// DeviceKeyIssueRequest 签发设备密钥请求
+type DeviceKeyIssueRequest struct {
+	RevokePrevious bool `json:"revoke_previous"` // 同时吊销设备的其他密钥
+}
+
+// DeviceKeyInfo 设备密钥
+type DeviceKeyInfo struct {
+	ID         string     `json:"id"`
+	DeviceID   string     `json:"device_id"`
+	PublicKey  string     `json:"public_key"`
+	Revoked    bool       `json:"revoked"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"` // 最近一次通过握手校验的时间
+}
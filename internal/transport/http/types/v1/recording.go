@@ -0,0 +1,54 @@
+package v1
+
+import "time"
+
+// RecordingQuery 录音查询参数
+type RecordingQuery struct {
+	Page      int    `form:"page,default=1"`
+	Limit     int    `form:"limit,default=20"`
+	DeviceID  string `form:"device_id"`
+	SessionID string `form:"session_id"`
+	From      string `form:"from"` // RFC3339
+	To        string `form:"to"`   // RFC3339
+}
+
+// RecordingInfo 录音信息
+type RecordingInfo struct {
+	ID         string    `json:"id"`
+	SessionID  string    `json:"session_id"`
+	DeviceID   string    `json:"device_id"`
+	Round      int       `json:"round"`
+	EntryID    string    `json:"entry_id,omitempty"` // 对应的对话记录消息ID
+	Format     string    `json:"format"`
+	SampleRate int       `json:"sample_rate"`
+	Channels   int       `json:"channels"`
+	DurationMs int64     `json:"duration_ms"`
+	Size       int64     `json:"size"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// RecordingListResponse 录音列表响应
+type RecordingListResponse struct {
+	Recordings []RecordingInfo `json:"recordings"`
+	Pagination Pagination      `json:"pagination"`
+}
+
+// RecordingConsentRequest 设置语音归档授权请求
+type RecordingConsentRequest struct {
+	Granted        *bool `json:"granted" binding:"required"`
+	DeleteExisting bool  `json:"delete_existing,omitempty"` // 撤销授权时同时删除已归档的语音
+}
+
+// RecordingConsentInfo 设备语音归档授权
+type RecordingConsentInfo struct {
+	DeviceID  string    `json:"device_id"`
+	Granted   bool      `json:"granted"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Deleted   int64     `json:"deleted,omitempty"` // 本次删除的录音条数
+}
+
+// DeviceDataErasureResponse 删除设备用户数据的结果
+type DeviceDataErasureResponse struct {
+	DeviceID string           `json:"device_id"`
+	Deleted  map[string]int64 `json:"deleted"` // 按数据类别统计的删除条数，如 recordings、transcripts
+}
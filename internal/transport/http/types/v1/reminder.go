@@ -0,0 +1,49 @@
+package v1
+
+import "time"
+
+// ReminderCreateRequest 创建提醒请求
+type ReminderCreateRequest struct {
+	DeviceID     string     `json:"device_id" binding:"required"`
+	Kind         string     `json:"kind" binding:"omitempty,oneof=timer alarm reminder"`
+	Message      string     `json:"message,omitempty"`
+	DelaySeconds int64      `json:"delay_seconds,omitempty" binding:"omitempty,min=1"`
+	FireAt       *time.Time `json:"fire_at,omitempty"`
+	Recurrence   string     `json:"recurrence,omitempty" binding:"omitempty,oneof=daily weekdays weekly"`
+}
+
+// ReminderUpdateRequest 更新提醒请求
+type ReminderUpdateRequest struct {
+	Message    *string    `json:"message,omitempty"`
+	FireAt     *time.Time `json:"fire_at,omitempty"`
+	Recurrence *string    `json:"recurrence,omitempty"` // 传空字符串表示取消重复
+}
+
+// ReminderQuery 提醒查询参数
+type ReminderQuery struct {
+	Page     int    `form:"page,default=1"`
+	Limit    int    `form:"limit,default=20"`
+	DeviceID string `form:"device_id"`
+	Kind     string `form:"kind"`
+	Status   string `form:"status"`
+}
+
+// ReminderInfo 提醒信息
+type ReminderInfo struct {
+	ID         string     `json:"id"`
+	DeviceID   string     `json:"device_id"`
+	Kind       string     `json:"kind"`
+	Message    string     `json:"message"`
+	FireAt     time.Time  `json:"fire_at"`
+	Recurrence string     `json:"recurrence,omitempty"`
+	Status     string     `json:"status"`
+	FiredAt    *time.Time `json:"fired_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// ReminderListResponse 提醒列表响应
+type ReminderListResponse struct {
+	Reminders  []ReminderInfo `json:"reminders"`
+	Pagination Pagination     `json:"pagination"`
+}
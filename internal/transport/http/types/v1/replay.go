@@ -0,0 +1,98 @@
+package v1
+
+import "time"
+
+// ReplayOptions 回放使用的配置，未指定的项使用当前配置
+type ReplayOptions struct {
+	Model          string   `json:"model,omitempty"`      // LLM 配置名称，为空时使用当前选择的 LLM
+	ModelName      string   `json:"model_name,omitempty"` // 覆盖配置中的模型名称
+	Temperature    *float64 `json:"temperature,omitempty" binding:"omitempty,min=0,max=2"`
+	MaxTokens      int      `json:"max_tokens,omitempty" binding:"min=0"`
+	SystemPrompt   string   `json:"system_prompt,omitempty"`   // 直接指定系统提示词，优先于模板
+	PromptTemplate string   `json:"prompt_template,omitempty"` // 提示词模板名称，为空时按设备当前分配解析
+	PromptVersion  int      `json:"prompt_version,omitempty" binding:"min=0"`
+
+	OriginalCostPer1KToken float64 `json:"original_cost_per_1k_token,omitempty" binding:"min=0"` // 原对话模型的每千Token成本
+	CostPer1KToken         float64 `json:"cost_per_1k_token,omitempty" binding:"min=0"`          // 回放模型的每千Token成本
+}
+
+// ReplayStartRequest 发起回放请求
+type ReplayStartRequest struct {
+	SessionID string `json:"session_id" binding:"required"`
+	ReplayOptions
+}
+
+// ReplayQuery 回放运行查询参数
+type ReplayQuery struct {
+	Page      int    `form:"page,default=1"`
+	Limit     int    `form:"limit,default=20"`
+	SessionID string `form:"session_id"`
+}
+
+// ReplayDiffSegment 回复差异片段，op 为 equal、delete（仅原回复）或 insert（仅回放回复）
+type ReplayDiffSegment struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// ReplaySide 一轮对话在原对话或回放中的结果
+type ReplaySide struct {
+	Response        string  `json:"response"`
+	FirstTokenMs    int64   `json:"first_token_ms"`
+	CompleteMs      int64   `json:"complete_ms"`
+	Tokens          int64   `json:"tokens"`
+	TokensEstimated bool    `json:"tokens_estimated"`
+	Cost            float64 `json:"cost"`
+}
+
+// ReplayTurn 一轮对话的回放结果
+type ReplayTurn struct {
+	Round      int                 `json:"round"`
+	Input      string              `json:"input"`
+	Redacted   bool                `json:"redacted"`
+	Original   ReplaySide          `json:"original"`
+	Replay     ReplaySide          `json:"replay"`
+	Similarity float64             `json:"similarity"`
+	Diff       []ReplayDiffSegment `json:"diff"`
+	Error      string              `json:"error,omitempty"`
+}
+
+// ReplaySideSummary 原对话或回放的汇总
+type ReplaySideSummary struct {
+	AvgFirstTokenMs float64 `json:"avg_first_token_ms"`
+	AvgCompleteMs   float64 `json:"avg_complete_ms"`
+	Tokens          int64   `json:"tokens"`
+	Cost            float64 `json:"cost"`
+}
+
+// ReplaySummary 回放汇总
+type ReplaySummary struct {
+	Turns         int               `json:"turns"`
+	Succeeded     int               `json:"succeeded"`
+	Skipped       int               `json:"skipped"`
+	AvgSimilarity float64           `json:"avg_similarity"`
+	Original      ReplaySideSummary `json:"original"`
+	Replay        ReplaySideSummary `json:"replay"`
+}
+
+// ReplayRunInfo 回放运行信息
+type ReplayRunInfo struct {
+	ID           string         `json:"id"`
+	SessionID    string         `json:"session_id"`
+	DeviceID     string         `json:"device_id"`
+	Options      ReplayOptions  `json:"options"`
+	ModelName    string         `json:"model_name"`
+	SystemPrompt string         `json:"system_prompt"`
+	Status       string         `json:"status"`
+	Error        string         `json:"error,omitempty"`
+	Summary      *ReplaySummary `json:"summary,omitempty"`
+	Turns        []ReplayTurn   `json:"turns,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+	FinishedAt   *time.Time     `json:"finished_at,omitempty"`
+}
+
+// ReplayRunListResponse 回放运行列表响应
+type ReplayRunListResponse struct {
+	Runs       []ReplayRunInfo `json:"runs"`
+	Pagination Pagination      `json:"pagination"`
+}
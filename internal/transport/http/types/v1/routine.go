@@ -0,0 +1,50 @@
+package v1
+
+import "time"
+
+// RoutineAction 例程动作
+type RoutineAction struct {
+	Type  string `json:"type" binding:"required,oneof=weather news calendar say workflow"`
+	Param string `json:"param,omitempty"` // weather 为地点，news 为新闻分类，say 为播报文本，workflow 为工作流ID
+}
+
+// RoutineCreateRequest 创建例程请求，schedule 和 phrase 至少提供一个
+type RoutineCreateRequest struct {
+	DeviceID string          `json:"device_id" binding:"required"`
+	Name     string          `json:"name" binding:"required"`
+	Schedule string          `json:"schedule,omitempty"` // 五段式 cron 表达式（分 时 日 月 周），如 "0 7 * * 1-5"
+	Phrase   string          `json:"phrase,omitempty"`   // 唤醒短语
+	Actions  []RoutineAction `json:"actions" binding:"required,min=1,dive"`
+	Enabled  *bool           `json:"enabled,omitempty"` // 默认启用
+}
+
+// RoutineUpdateRequest 更新例程请求，未提供的字段不修改
+type RoutineUpdateRequest struct {
+	Name     *string         `json:"name,omitempty"`
+	Schedule *string         `json:"schedule,omitempty"` // 传空字符串表示取消定时触发
+	Phrase   *string         `json:"phrase,omitempty"`   // 传空字符串表示取消唤醒短语
+	Actions  []RoutineAction `json:"actions,omitempty" binding:"omitempty,min=1,dive"`
+	Enabled  *bool           `json:"enabled,omitempty"`
+}
+
+// RoutineInfo 例程信息
+type RoutineInfo struct {
+	ID          string          `json:"id"`
+	DeviceID    string          `json:"device_id"`
+	Name        string          `json:"name"`
+	Schedule    string          `json:"schedule,omitempty"`
+	Phrase      string          `json:"phrase,omitempty"`
+	Actions     []RoutineAction `json:"actions"`
+	Enabled     bool            `json:"enabled"`
+	Description string          `json:"description"` // 口语描述，如“每天7点整播报天气和新闻”
+	NextRunAt   *time.Time      `json:"next_run_at,omitempty"`
+	LastRunAt   *time.Time      `json:"last_run_at,omitempty"`
+	LastStatus  string          `json:"last_status,omitempty"` // ok / partial / failed / missed
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// RoutineRunResponse 立即执行例程的结果
+type RoutineRunResponse struct {
+	Reply string `json:"reply"` // 推送给设备的播报文本
+}
@@ -0,0 +1,58 @@
+package v1
+
+import "time"
+
+// ScriptCreateRequest 创建脚本请求
+type ScriptCreateRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description,omitempty"`
+	Source      string `json:"source" binding:"required"` // Lua 源码
+	Comment     string `json:"comment,omitempty"`         // 版本说明
+}
+
+// ScriptUpdateRequest 更新脚本请求，修改源码时生成新版本
+type ScriptUpdateRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Source      *string `json:"source,omitempty"`
+	Comment     string  `json:"comment,omitempty"`
+}
+
+// ScriptRollbackRequest 回滚脚本请求
+type ScriptRollbackRequest struct {
+	Version int `json:"version" binding:"required,min=1"`
+}
+
+// ScriptTestRequest 试运行脚本请求
+type ScriptTestRequest struct {
+	Version int                    `json:"version,omitempty"` // 缺省为当前版本
+	Inputs  map[string]interface{} `json:"inputs,omitempty"`
+	Timeout string                 `json:"timeout,omitempty"` // 如 5s，最长 1m
+}
+
+// ScriptInfo 脚本信息
+type ScriptInfo struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Language    string    `json:"language"`
+	Version     int       `json:"version"`
+	Source      string    `json:"source"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ScriptVersionInfo 脚本版本信息
+type ScriptVersionInfo struct {
+	ScriptID  string    `json:"script_id"`
+	Version   int       `json:"version"`
+	Source    string    `json:"source"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ScriptTestResult 试运行结果
+type ScriptTestResult struct {
+	Outputs map[string]interface{} `json:"outputs"`
+	Logs    []string               `json:"logs"`
+}
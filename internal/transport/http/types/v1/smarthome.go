@@ -0,0 +1,46 @@
+package v1
+
+import "time"
+
+// SmartHomeEntity 智能家居实体
+type SmartHomeEntity struct {
+	ID        string                 `json:"id"`     // "<桥接名称>:<设备ID>"
+	Bridge    string                 `json:"bridge"` // 桥接名称
+	Name      string                 `json:"name"`
+	Type      string                 `json:"type"` // light/switch/cover/climate/lock/fan/sensor
+	Room      string                 `json:"room,omitempty"`
+	Aliases   []string               `json:"aliases,omitempty"`
+	Features  []string               `json:"features"`        // on_off/brightness/open_close/position/temperature/lock
+	State     map[string]interface{} `json:"state,omitempty"` // on/brightness/position/temperature/current_temperature/locked 及传感器读数
+	Available bool                   `json:"available"`
+	UpdatedAt time.Time              `json:"updated_at"` // 最近一次从桥接读取状态的时间
+}
+
+// SmartHomeEntityQuery 实体列表查询参数
+type SmartHomeEntityQuery struct {
+	Room    string `form:"room"`
+	Type    string `form:"type" binding:"omitempty,oneof=light switch cover climate lock fan sensor"`
+	Refresh bool   `form:"refresh"` // 为 true 时先向所有桥接重新发现实体
+}
+
+// SmartHomeControlRequest 实体控制请求
+type SmartHomeControlRequest struct {
+	Action string   `json:"action" binding:"required,oneof=turn_on turn_off toggle set_brightness set_position set_temperature open close lock unlock"`
+	Value  *float64 `json:"value,omitempty"` // set_brightness/set_position 为 0-100 的百分比，set_temperature 为摄氏度
+}
+
+// SmartHomeAlias 租户的设备别名
+type SmartHomeAlias struct {
+	Alias      string    `json:"alias"`
+	EntityID   string    `json:"entity_id"`
+	EntityName string    `json:"entity_name,omitempty"` // 实体当前的播报名称，实体已不存在时为空
+	Source     string    `json:"source"`                // manual/learned（从语音纠正中学到）
+	Hits       int       `json:"hits"`                  // 语音指令通过该别名匹配到实体的次数
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// SmartHomeAliasRequest 设置设备别名请求
+type SmartHomeAliasRequest struct {
+	Alias    string `json:"alias" binding:"required,max=32"`
+	EntityID string `json:"entity_id" binding:"required"`
+}
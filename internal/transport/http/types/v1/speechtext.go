@@ -0,0 +1,59 @@
+package v1
+
+import "time"
+
+// SpeechTextStage 播报文本处理步骤
+type SpeechTextStage struct {
+	Type        string            `json:"type" binding:"required,oneof=markdown emoji units replace regex"`
+	Language    string            `json:"language,omitempty"`    // units 使用：zh 或 en，为空时按文本是否包含汉字判断
+	Words       map[string]string `json:"words,omitempty"`       // replace 使用：原词到读法的映射，长词优先
+	Pattern     string            `json:"pattern,omitempty"`     // regex 使用：Go 正则表达式
+	Replacement string            `json:"replacement,omitempty"` // regex 使用：替换文本，用 ${1} 引用分组
+}
+
+// SpeechTextProfileRequest 创建或覆盖方案请求
+type SpeechTextProfileRequest struct {
+	Name        string            `json:"name,omitempty"` // 创建时必填，更新时以路径为准
+	Description string            `json:"description,omitempty" binding:"max=1024"`
+	Stages      []SpeechTextStage `json:"stages" binding:"max=32,dive"` // 按顺序执行
+}
+
+// SpeechTextProfileInfo 方案信息
+type SpeechTextProfileInfo struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Stages      []SpeechTextStage `json:"stages"`
+	BuiltIn     bool              `json:"built_in"`             // 数据库中没有 default 方案时返回的内置方案
+	UpdatedAt   *time.Time        `json:"updated_at,omitempty"` // 内置方案为空
+}
+
+// SpeechTextProfileListResponse 方案列表响应
+type SpeechTextProfileListResponse struct {
+	Profiles    []SpeechTextProfileInfo    `json:"profiles"`
+	Assignments []SpeechTextAssignmentInfo `json:"assignments"`
+}
+
+// SpeechTextPreviewRequest 试运行请求
+type SpeechTextPreviewRequest struct {
+	Profile string            `json:"profile,omitempty"`               // 使用已保存的方案，与 stages 二选一
+	Stages  []SpeechTextStage `json:"stages,omitempty" binding:"dive"` // 使用未保存的步骤
+	Text    string            `json:"text" binding:"required,max=4096"`
+}
+
+// SpeechTextPreviewResponse 试运行结果
+type SpeechTextPreviewResponse struct {
+	Text   string `json:"text"`   // 处理后送入 TTS 的文本
+	Source string `json:"source"` // 原始文本
+}
+
+// SpeechTextAssignRequest 为设备分配方案请求
+type SpeechTextAssignRequest struct {
+	Profile string `json:"profile" binding:"required"`
+}
+
+// SpeechTextAssignmentInfo 设备方案分配
+type SpeechTextAssignmentInfo struct {
+	DeviceID  string     `json:"device_id"`
+	Profile   string     `json:"profile"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"` // 未分配、使用 default 方案时为空
+}
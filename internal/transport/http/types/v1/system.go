@@ -325,4 +325,72 @@ const (
 	OperationRestart     = "restart"
 	OperationRefresh     = "refresh"
 	OperationCleanup     = "cleanup"
-)
\ No newline at end of file
+)
+
+// DrainRequest 进入排空模式请求
+type DrainRequest struct {
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty"` // 等待活跃会话结束的最长秒数，0 表示使用配置值
+	Reason         string `json:"reason,omitempty"`          // 排空原因，记录到日志
+}
+
+// DrainStatus 排空进度
+type DrainStatus struct {
+	State     string         `json:"state"` // serving, draining, drained
+	Reason    string         `json:"reason,omitempty"`
+	StartedAt *time.Time     `json:"started_at,omitempty"`
+	Deadline  *time.Time     `json:"deadline,omitempty"`
+	Remaining int64          `json:"remaining_seconds"` // 距截止时间的秒数
+	Active    map[string]int `json:"active"`            // 各类型活跃工作数：sessions、workflows
+	TimedOut  bool           `json:"timed_out"`
+}
+
+// SelfTestReport 自检报告
+type SelfTestReport struct {
+	StartedAt  time.Time        `json:"started_at"`
+	DurationMs int64            `json:"duration_ms"`
+	Passed     bool             `json:"passed"` // 没有失败项
+	Results    []SelfTestResult `json:"results"`
+}
+
+// SelfTestResult 单项检查结果
+type SelfTestResult struct {
+	Step       string `json:"step"`   // 所属初始化步骤ID，如 storage:init-database
+	Name       string `json:"name"`
+	Status     string `json:"status"` // pass, warn, fail, skip
+	Detail     string `json:"detail,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// BootReport 启动耗时报告
+type BootReport struct {
+	StartedAt    time.Time        `json:"started_at"`
+	FinishedAt   *time.Time       `json:"finished_at,omitempty"` // 启动未结束时为空
+	Status       string           `json:"status"`                // running, completed, failed
+	Error        string           `json:"error,omitempty"`
+	DurationMs   int64            `json:"duration_ms"`   // 启动总耗时
+	StepTotalMs  int64            `json:"step_total_ms"` // 各步骤耗时之和，大于总耗时的部分来自并行执行
+	Steps        []BootStepTiming `json:"steps"`         // 按开始时间排序
+	CriticalPath []string         `json:"critical_path"` // 决定启动总耗时的步骤链
+}
+
+// BootStepTiming 初始化步骤的执行时间
+type BootStepTiming struct {
+	ID         string   `json:"id"`
+	Title      string   `json:"title"`
+	DependsOn  []string `json:"depends_on,omitempty"`
+	Status     string   `json:"status"` // pending, running, completed, failed, skipped
+	Error      string   `json:"error,omitempty"`
+	OffsetMs   int64    `json:"offset_ms"` // 相对启动开始的时间
+	DurationMs int64    `json:"duration_ms"`
+	TimeoutMs  int64    `json:"timeout_ms"`
+}
+
+// IntentRouteStats 单个意图的路由统计，intent 为 llm 的条目表示未命中任何意图的语句
+type IntentRouteStats struct {
+	Intent           string  `json:"intent"`
+	Matched          int64   `json:"matched"`         // 分类命中次数
+	Handled          int64   `json:"handled"`         // 由确定性处理器完成的次数
+	FallbackToLLM    int64   `json:"fallback_to_llm"` // 回落到LLM的次数
+	Errors           int64   `json:"errors"`
+	AverageLatencyMs float64 `json:"average_latency_ms"`
+}
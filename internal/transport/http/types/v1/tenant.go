@@ -0,0 +1,82 @@
+package v1
+
+import "time"
+
+// TenantQuota 租户配额，各项为 0 表示不限制
+type TenantQuota struct {
+	MaxDevices    int   `json:"max_devices" binding:"min=0" example:"100"`
+	DailyRequests int64 `json:"daily_requests" binding:"min=0" example:"10000"`
+	DailyTokens   int64 `json:"daily_tokens" binding:"min=0" example:"2000000"`
+}
+
+// TenantCreateRequest 创建租户请求
+type TenantCreateRequest struct {
+	ID    string      `json:"id" binding:"required,max=64" example:"acme"`
+	Name  string      `json:"name,omitempty" binding:"max=255" example:"Acme 公司"`
+	Quota TenantQuota `json:"quota"`
+}
+
+// TenantUpdateRequest 修改租户请求，未出现的字段保持不变
+type TenantUpdateRequest struct {
+	Name    *string      `json:"name,omitempty"`
+	Enabled *bool        `json:"enabled,omitempty"`
+	Quota   *TenantQuota `json:"quota,omitempty"`
+}
+
+// TenantInfo 租户信息
+type TenantInfo struct {
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	Enabled   bool        `json:"enabled"`
+	Quota     TenantQuota `json:"quota"`
+	HasToken  bool        `json:"has_token"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// TenantTokenResponse 签发的租户访问令牌，只返回一次
+type TenantTokenResponse struct {
+	Tenant TenantInfo `json:"tenant"`
+	Token  string     `json:"token"`
+}
+
+// TenantUsageInfo 租户某一天的用量
+type TenantUsageInfo struct {
+	TenantID string      `json:"tenant_id"`
+	Day      string      `json:"day"`
+	Requests int64       `json:"requests"`
+	Tokens   int64       `json:"tokens"`
+	Devices  int64       `json:"devices"`
+	Quota    TenantQuota `json:"quota"`
+}
+
+// TenantLLMConfig 租户的 LLM 配置
+type TenantLLMConfig struct {
+	Type        string                 `json:"type" example:"openai"`
+	ModelName   string                 `json:"model_name,omitempty" example:"gpt-4o-mini"`
+	BaseURL     string                 `json:"base_url,omitempty"`
+	APIKey      string                 `json:"api_key,omitempty"` // 读取时脱敏为 ******，保存时传 ****** 表示保留原值
+	Temperature float64                `json:"temperature,omitempty"`
+	MaxTokens   int                    `json:"max_tokens,omitempty"`
+	TopP        float64                `json:"top_p,omitempty"`
+	Extra       map[string]interface{} `json:"extra,omitempty"`
+}
+
+// TenantTTSConfig 租户的 TTS 配置
+type TenantTTSConfig struct {
+	Type    string                 `json:"type" example:"doubao"`
+	Voice   string                 `json:"voice,omitempty"`
+	Format  string                 `json:"format,omitempty" example:"mp3"`
+	AppID   string                 `json:"app_id,omitempty"`
+	Token   string                 `json:"token,omitempty"` // 读取时脱敏为 ******，保存时传 ****** 表示保留原值
+	Cluster string                 `json:"cluster,omitempty"`
+	Extra   map[string]interface{} `json:"extra,omitempty"`
+}
+
+// TenantProviders 租户的供应商配置，条目与实例配置同名时覆盖实例配置，选择为空时沿用实例的选择
+type TenantProviders struct {
+	SelectedLLM string                     `json:"selected_llm,omitempty" example:"acme-llm"`
+	SelectedTTS string                     `json:"selected_tts,omitempty"`
+	LLM         map[string]TenantLLMConfig `json:"llm"`
+	TTS         map[string]TenantTTSConfig `json:"tts"`
+}
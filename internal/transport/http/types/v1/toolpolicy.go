@@ -0,0 +1,55 @@
+package v1
+
+import "time"
+
+// ToolPolicyAuditQuery 工具调用审计查询参数
+type ToolPolicyAuditQuery struct {
+	Page     int    `form:"page,default=1"`
+	Limit    int    `form:"limit,default=20"`
+	DeviceID string `form:"device_id"`
+	Agent    string `form:"agent"`
+	Name     string `form:"name"`    // 工具或能力名称
+	Allowed  string `form:"allowed"` // true/false，缺省不按结果过滤
+	From     string `form:"from"`    // RFC3339
+	To       string `form:"to"`      // RFC3339
+}
+
+// ToolPolicyAuditInfo 工具调用审计记录
+type ToolPolicyAuditInfo struct {
+	ID        string    `json:"id"`
+	DeviceID  string    `json:"device_id"`
+	SessionID string    `json:"session_id"`
+	Agent     string    `json:"agent"`
+	Kind      string    `json:"kind"` // tool/capability
+	Name      string    `json:"name"`
+	Arguments string    `json:"arguments"` // JSON
+	Allowed   bool      `json:"allowed"`
+	Profile   string    `json:"profile"`
+	Rule      string    `json:"rule"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ToolPolicyAuditListResponse 工具调用审计列表响应
+type ToolPolicyAuditListResponse struct {
+	Records    []ToolPolicyAuditInfo `json:"records"`
+	Pagination Pagination            `json:"pagination"`
+}
+
+// ToolPolicyCheckRequest 工具调用权限试算请求
+type ToolPolicyCheckRequest struct {
+	DeviceID  string                 `json:"device_id,omitempty"`
+	SessionID string                 `json:"session_id,omitempty"`
+	Agent     string                 `json:"agent,omitempty"`
+	Kind      string                 `json:"kind,omitempty"` // tool/capability，缺省为 tool
+	Name      string                 `json:"name" binding:"required"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+}
+
+// ToolPolicyCheckResponse 工具调用权限试算结果
+type ToolPolicyCheckResponse struct {
+	Allowed bool   `json:"allowed"`
+	Profile string `json:"profile"` // 参与检查的策略，多个以逗号分隔
+	Rule    string `json:"rule"`    // 做出决定的规则，为空表示使用策略的默认动作
+	Reason  string `json:"reason"`
+}
@@ -0,0 +1,25 @@
+package v1
+
+import "time"
+
+// TranslationLanguage 翻译模式支持的语言
+type TranslationLanguage struct {
+	Code  string `json:"code"`
+	Name  string `json:"name"`
+	Voice string `json:"voice,omitempty"` // 该语言配置的TTS音色
+}
+
+// TranslationStartRequest 开启或切换翻译模式请求，语言为空时使用配置的默认语言
+type TranslationStartRequest struct {
+	Source string `json:"source,omitempty"` // 源语言代码，auto 表示自动识别
+	Target string `json:"target,omitempty"` // 目标语言代码
+}
+
+// TranslationSessionInfo 设备的翻译模式状态
+type TranslationSessionInfo struct {
+	DeviceID  string     `json:"device_id"`
+	Active    bool       `json:"active"`
+	Source    string     `json:"source,omitempty"`
+	Target    string     `json:"target,omitempty"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+}
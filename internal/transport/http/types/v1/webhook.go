@@ -0,0 +1,74 @@
+package v1
+
+import "time"
+
+// WebhookCreateRequest 创建推送端点请求
+type WebhookCreateRequest struct {
+	Name    string   `json:"name" binding:"required"`
+	URL     string   `json:"url" binding:"required"`
+	Secret  string   `json:"secret,omitempty"`                // 签名密钥，为空时自动生成
+	Events  []string `json:"events" binding:"required,min=1"` // device.offline, plugin.crashed, workflow.failed, budget.exceeded 或 *
+	Enabled *bool    `json:"enabled,omitempty"`
+}
+
+// WebhookUpdateRequest 更新推送端点请求
+type WebhookUpdateRequest struct {
+	Name    *string  `json:"name,omitempty"`
+	URL     *string  `json:"url,omitempty"`
+	Secret  *string  `json:"secret,omitempty"` // 传 ****** 表示保留原值
+	Events  []string `json:"events,omitempty"`
+	Enabled *bool    `json:"enabled,omitempty"`
+}
+
+// WebhookInfo 推送端点信息，签名密钥只在创建时返回原值
+type WebhookInfo struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret"`
+	Events    []string  `json:"events"`
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookDeliveryQuery 推送记录查询参数
+type WebhookDeliveryQuery struct {
+	Page   int    `form:"page,default=1"`
+	Limit  int    `form:"limit,default=20"`
+	Event  string `form:"event"`
+	Status string `form:"status"` // pending / succeeded / failed
+}
+
+// WebhookAttempt 一次推送尝试
+type WebhookAttempt struct {
+	At           time.Time `json:"at"`
+	StatusCode   int       `json:"status_code,omitempty"` // 未收到响应时为 0
+	DurationMs   int64     `json:"duration_ms"`
+	Error        string    `json:"error,omitempty"`
+	ResponseBody string    `json:"response_body,omitempty"` // 截取前 1KB
+}
+
+// WebhookDeliveryInfo 推送记录
+type WebhookDeliveryInfo struct {
+	ID            string           `json:"id"`
+	EndpointID    string           `json:"endpoint_id"`
+	EventID       string           `json:"event_id"` // 同一事件发往各端点及重试时相同
+	Event         string           `json:"event"`
+	Payload       string           `json:"payload"` // 请求体 JSON
+	Status        string           `json:"status"`
+	Attempts      int              `json:"attempts"`
+	NextAttemptAt *time.Time       `json:"next_attempt_at,omitempty"`
+	StatusCode    int              `json:"status_code,omitempty"`
+	Error         string           `json:"error,omitempty"`
+	History       []WebhookAttempt `json:"history"`
+	DeliveredAt   *time.Time       `json:"delivered_at,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+// WebhookDeliveryListResponse 推送记录列表响应
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDeliveryInfo `json:"deliveries"`
+	Pagination Pagination            `json:"pagination"`
+}
@@ -2,8 +2,10 @@ package utils
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"xiaozhi-server-go/internal/platform/requestid"
 	httpMiddleware "xiaozhi-server-go/internal/transport/http/middleware"
 )
 
@@ -66,7 +68,7 @@ func (r *ResponseHelper) Created(c *gin.Context, data interface{}, message strin
 		Success:   true,
 		Data:      data,
 		Message:   message,
-		Timestamp: httpMiddleware.APIResponse{}.Timestamp, // 将在中间件中设置
+		Timestamp: time.Now().Unix(),
 		Version:   "v1", // 固定为 v1 版本
 		RequestID: getRequestIDFromContext(c),
 	})
@@ -95,7 +97,7 @@ func (r *ResponseHelper) Accepted(c *gin.Context, data interface{}, message stri
 		Success:   true,
 		Data:      data,
 		Message:   message,
-		Timestamp: httpMiddleware.APIResponse{}.Timestamp,
+		Timestamp: time.Now().Unix(),
 		Version:   "v1", // 固定为 v1 版本
 		RequestID: getRequestIDFromContext(c),
 	})
@@ -113,14 +115,14 @@ func (r *ResponseHelper) MovedTemporarily(c *gin.Context, location string) {
 	c.Status(http.StatusFound)
 }
 
-// 临时响应函数，等待中间件更新后替换
+// getRequestIDFromContext 获取 ResponseMiddleware 分配的请求ID
 func getRequestIDFromContext(c *gin.Context) string {
 	if requestID, exists := c.Get("request_id"); exists {
 		if id, ok := requestID.(string); ok {
 			return id
 		}
 	}
-	return ""
+	return requestid.FromContext(c.Request.Context())
 }
 
 // 全局响应助手实例
@@ -1,12 +1,15 @@
 package utils
 
 // API错误码常量定义
+// 错误响应的 error.code 取以下值，对应的HTTP状态码见 middleware/response.go 的 errorStatusCodes
 const (
 	// 通用错误码 (1000-1999)
 	ErrorCodeValidationFailed     = "VALIDATION_FAILED"
 	ErrorCodeInvalidInput         = "INVALID_INPUT"
 	ErrorCodeAuthenticationFailed = "AUTHENTICATION_FAILED"
 	ErrorCodeAuthorizationFailed  = "AUTHORIZATION_FAILED"
+	ErrorCodeUnauthorized         = "UNAUTHORIZED"
+	ErrorCodeForbidden            = "FORBIDDEN"
 	ErrorCodeResourceNotFound     = "RESOURCE_NOT_FOUND"
 	ErrorCodeInternalServer       = "INTERNAL_SERVER_ERROR"
 	ErrorCodeBadRequest          = "BAD_REQUEST"
@@ -88,6 +91,8 @@ var ErrorMessages = map[string]string{
 	ErrorCodeInvalidInput:         "输入参数无效",
 	ErrorCodeAuthenticationFailed: "身份验证失败",
 	ErrorCodeAuthorizationFailed:  "权限不足",
+	ErrorCodeUnauthorized:         "未授权访问",
+	ErrorCodeForbidden:            "禁止访问",
 	ErrorCodeResourceNotFound:     "资源不存在",
 	ErrorCodeInternalServer:       "内部服务器错误",
 	ErrorCodeBadRequest:          "请求格式错误",
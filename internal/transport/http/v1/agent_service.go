@@ -0,0 +1,239 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/agent"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// AgentServiceV1 V1版本智能体服务
+type AgentServiceV1 struct {
+	logger  *logging.Logger
+	service *agent.Service
+}
+
+// NewAgentServiceV1 创建智能体服务V1实例
+func NewAgentServiceV1(logger *logging.Logger, service *agent.Service) (*AgentServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("agent service is required")
+	}
+	return &AgentServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册智能体API路由
+func (s *AgentServiceV1) Register(router *gin.RouterGroup) {
+	agents := router.Group("/agents")
+	{
+		agents.POST("", s.createAgent)       // 创建智能体
+		agents.GET("", s.listAgents)         // 获取智能体列表
+		agents.GET("/:id", s.getAgent)       // 获取智能体详情
+		agents.PUT("/:id", s.updateAgent)    // 更新智能体
+		agents.DELETE("/:id", s.deleteAgent) // 删除智能体
+		agents.POST("/:id/run", s.runAgent)  // 运行智能体
+	}
+}
+
+// createAgent 创建智能体
+// @Summary 创建智能体
+// @Description 创建有独立提示词、模型和工具白名单的智能体；配置 delegates 的智能体可以把子任务委托给其他智能体
+// @Tags Agents
+// @Accept json
+// @Produce json
+// @Param request body v1.AgentCreateRequest true "智能体信息"
+// @Success 201 {object} httptransport.APIResponse{data=v1.AgentInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/agents [post]
+func (s *AgentServiceV1) createAgent(c *gin.Context) {
+	var request v1.AgentCreateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	a, err := s.service.Create(c.Request.Context(), agent.CreateRequest{
+		Name:        request.Name,
+		Description: request.Description,
+		Prompt:      request.Prompt,
+		Model:       request.Model,
+		Tools:       request.Tools,
+		Delegates:   request.Delegates,
+	})
+	if err != nil {
+		s.handleError(c, err, "创建智能体失败")
+		return
+	}
+	httpUtils.Response.Created(c, toAgentInfo(a), "智能体创建成功")
+}
+
+// listAgents 获取智能体列表
+// @Summary 获取智能体列表
+// @Tags Agents
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.AgentInfo}
+// @Router /v1/agents [get]
+func (s *AgentServiceV1) listAgents(c *gin.Context) {
+	items, err := s.service.List(c.Request.Context())
+	if err != nil {
+		s.handleError(c, err, "获取智能体列表失败")
+		return
+	}
+	agents := make([]v1.AgentInfo, 0, len(items))
+	for _, item := range items {
+		agents = append(agents, toAgentInfo(item))
+	}
+	httpUtils.Response.Success(c, agents, "获取智能体列表成功")
+}
+
+// getAgent 获取智能体详情
+// @Summary 获取智能体详情
+// @Tags Agents
+// @Produce json
+// @Param id path string true "智能体ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.AgentInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/agents/{id} [get]
+func (s *AgentServiceV1) getAgent(c *gin.Context) {
+	a, err := s.service.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取智能体失败")
+		return
+	}
+	httpUtils.Response.Success(c, toAgentInfo(a), "获取智能体成功")
+}
+
+// updateAgent 更新智能体
+// @Summary 更新智能体
+// @Description 被其他智能体委托的智能体不能改名
+// @Tags Agents
+// @Accept json
+// @Produce json
+// @Param id path string true "智能体ID"
+// @Param request body v1.AgentUpdateRequest true "更新内容"
+// @Success 200 {object} httptransport.APIResponse{data=v1.AgentInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/agents/{id} [put]
+func (s *AgentServiceV1) updateAgent(c *gin.Context) {
+	var request v1.AgentUpdateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	a, err := s.service.Update(c.Request.Context(), c.Param("id"), agent.UpdateRequest{
+		Name:        request.Name,
+		Description: request.Description,
+		Prompt:      request.Prompt,
+		Model:       request.Model,
+		Tools:       request.Tools,
+		Delegates:   request.Delegates,
+	})
+	if err != nil {
+		s.handleError(c, err, "更新智能体失败")
+		return
+	}
+	httpUtils.Response.Success(c, toAgentInfo(a), "智能体更新成功")
+}
+
+// deleteAgent 删除智能体
+// @Summary 删除智能体
+// @Description 被其他智能体委托的智能体不能删除
+// @Tags Agents
+// @Produce json
+// @Param id path string true "智能体ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/agents/{id} [delete]
+func (s *AgentServiceV1) deleteAgent(c *gin.Context) {
+	if err := s.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		s.handleError(c, err, "删除智能体失败")
+		return
+	}
+	httpUtils.Response.Success(c, nil, "智能体已删除")
+}
+
+// runAgent 运行智能体
+// @Summary 运行智能体
+// @Description 以给定输入运行智能体，返回回复以及按顺序记录的工具调用和委托
+// @Tags Agents
+// @Accept json
+// @Produce json
+// @Param id path string true "智能体ID"
+// @Param request body v1.AgentRunRequest true "输入"
+// @Success 200 {object} httptransport.APIResponse{data=v1.AgentRunResult}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/agents/{id}/run [post]
+func (s *AgentServiceV1) runAgent(c *gin.Context) {
+	var request v1.AgentRunRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	a, err := s.service.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "运行智能体失败")
+		return
+	}
+	result, err := s.service.Run(c.Request.Context(), a.Name, request.Input)
+	if err != nil {
+		s.handleError(c, err, "运行智能体失败")
+		return
+	}
+
+	steps := make([]v1.AgentStep, 0, len(result.Steps))
+	for _, step := range result.Steps {
+		steps = append(steps, v1.AgentStep{
+			Agent:      step.Agent,
+			Type:       string(step.Type),
+			Name:       step.Name,
+			Arguments:  step.Arguments,
+			Result:     step.Result,
+			Error:      step.Error,
+			DurationMs: step.Duration.Milliseconds(),
+		})
+	}
+	httpUtils.Response.Success(c, v1.AgentRunResult{Agent: result.Agent, Reply: result.Reply, Steps: steps}, "智能体运行成功")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *AgentServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, agent.ErrNotFound):
+		httpUtils.Response.NotFound(c, "智能体")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toAgentInfo(a *agent.Agent) v1.AgentInfo {
+	return v1.AgentInfo{
+		ID:          a.ID,
+		Name:        a.Name,
+		Description: a.Description,
+		Prompt:      a.Prompt,
+		Model:       a.Model,
+		Tools:       a.Tools,
+		Delegates:   a.Delegates,
+		CreatedAt:   a.CreatedAt,
+		UpdatedAt:   a.UpdatedAt,
+	}
+}
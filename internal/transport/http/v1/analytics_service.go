@@ -0,0 +1,169 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/analytics"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// AnalyticsServiceV1 V1版本用量统计服务，供管理后台的仪表盘使用
+type AnalyticsServiceV1 struct {
+	logger  *logging.Logger
+	service *analytics.Service
+}
+
+// NewAnalyticsServiceV1 创建用量统计服务V1实例
+func NewAnalyticsServiceV1(logger *logging.Logger, service *analytics.Service) (*AnalyticsServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("analytics service is required")
+	}
+	return &AnalyticsServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册用量统计API路由
+func (s *AnalyticsServiceV1) Register(router *gin.RouterGroup) {
+	router.GET("/analytics/rollups", s.listRollups) // 按天或按周列出汇总
+	router.GET("/analytics/summary", s.getSummary)  // 合并查询范围内的汇总
+}
+
+// listRollups 按天或按周列出汇总
+// @Summary 按天或按周列出用量汇总
+// @Description 返回后台定期预先汇总的对话数、平均延迟、Token和语音用量、热门意图、各提供者的出错比例和设备活跃情况，按周期起始日正序；不会在请求时扫描对话记录
+// @Tags Analytics
+// @Produce json
+// @Param period query string false "汇总周期，默认 day" Enums(day,week)
+// @Param from query string false "起始日期 YYYY-MM-DD，默认往前 7 天或 8 周"
+// @Param to query string false "结束日期 YYYY-MM-DD，默认今天"
+// @Param device_id query string false "只返回该设备的活跃情况"
+// @Param limit query int false "意图和设备列表的条数，默认 20，最多 500"
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.AnalyticsRollup}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/analytics/rollups [get]
+func (s *AnalyticsServiceV1) listRollups(c *gin.Context) {
+	var query v1.AnalyticsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	items, err := s.service.Rollups(c.Request.Context(), toAnalyticsQuery(query))
+	if err != nil {
+		s.handleError(c, err, "查询用量汇总失败")
+		return
+	}
+	result := make([]v1.AnalyticsRollup, 0, len(items))
+	for _, r := range items {
+		result = append(result, toAnalyticsRollup(r))
+	}
+	httpUtils.Response.Success(c, result, "查询用量汇总成功")
+}
+
+// getSummary 合并查询范围内的汇总
+// @Summary 查询范围内的用量合计
+// @Description 把查询范围内的按天汇总合并为一个汇总，活跃设备数按设备去重
+// @Tags Analytics
+// @Produce json
+// @Param period query string false "范围的对齐方式，默认 day" Enums(day,week)
+// @Param from query string false "起始日期 YYYY-MM-DD，默认往前 7 天或 8 周"
+// @Param to query string false "结束日期 YYYY-MM-DD，默认今天"
+// @Param device_id query string false "只返回该设备的活跃情况"
+// @Param limit query int false "意图和设备列表的条数，默认 20，最多 500"
+// @Success 200 {object} httptransport.APIResponse{data=v1.AnalyticsRollup}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/analytics/summary [get]
+func (s *AnalyticsServiceV1) getSummary(c *gin.Context) {
+	var query v1.AnalyticsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	summary, err := s.service.Summary(c.Request.Context(), toAnalyticsQuery(query))
+	if err != nil {
+		s.handleError(c, err, "查询用量合计失败")
+		return
+	}
+	httpUtils.Response.Success(c, toAnalyticsRollup(summary), "查询用量合计成功")
+}
+
+func toAnalyticsQuery(query v1.AnalyticsQuery) analytics.Query {
+	return analytics.Query{
+		Period:   query.Period,
+		From:     query.From,
+		To:       query.To,
+		DeviceID: query.DeviceID,
+		Limit:    query.Limit,
+	}
+}
+
+func toAnalyticsRollup(r *analytics.Rollup) v1.AnalyticsRollup {
+	u := r.Usage
+	out := v1.AnalyticsRollup{
+		Period: r.Period,
+		Start:  r.Start,
+		Usage: v1.AnalyticsUsage{
+			Conversations:   u.Conversations,
+			Turns:           u.Turns,
+			ActiveDevices:   u.ActiveDevices,
+			AvgLatencyMs:    u.AvgLatencyMs(),
+			AvgFirstTokenMs: u.AvgFirstTokenMs(),
+			Tokens:          u.Tokens,
+			LLMCalls:        u.LLMCalls,
+			SpeechMs:        u.SpeechMs,
+			TTSChars:        u.TTSChars,
+		},
+		Intents:    make([]v1.AnalyticsIntent, 0, len(r.Intents)),
+		Providers:  make([]v1.AnalyticsProvider, 0, len(r.Providers)),
+		Devices:    make([]v1.AnalyticsDevice, 0, len(r.Devices)),
+		ComputedAt: r.ComputedAt,
+	}
+	for _, stat := range r.Intents {
+		out.Intents = append(out.Intents, v1.AnalyticsIntent{
+			Intent:  stat.Intent,
+			Count:   stat.Count,
+			Handled: stat.Handled,
+			Failed:  stat.Failed,
+		})
+	}
+	for _, stat := range r.Providers {
+		out.Providers = append(out.Providers, v1.AnalyticsProvider{
+			Provider:      stat.Provider,
+			Capability:    stat.Capability,
+			Calls:         stat.Calls,
+			Errors:        stat.Errors,
+			ErrorRate:     stat.ErrorRate(),
+			AvgDurationMs: stat.AvgDurationMs(),
+		})
+	}
+	for _, stat := range r.Devices {
+		out.Devices = append(out.Devices, v1.AnalyticsDevice{
+			DeviceID:      stat.DeviceID,
+			Conversations: stat.Conversations,
+			Turns:         stat.Turns,
+			Tokens:        stat.Tokens,
+			SpeechMs:      stat.SpeechMs,
+			TTSChars:      stat.TTSChars,
+			LastActiveAt:  stat.LastActiveAt,
+		})
+	}
+	return out
+}
+
+func (s *AnalyticsServiceV1) handleError(c *gin.Context, err error, message string) {
+	if platformerrors.IsKind(err, platformerrors.KindDomain) {
+		httpUtils.Response.BadRequest(c, err.Error())
+		return
+	}
+	s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+	httpUtils.Response.InternalError(c, message)
+}
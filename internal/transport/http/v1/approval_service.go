@@ -0,0 +1,108 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/middleware"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+	"xiaozhi-server-go/internal/workflow"
+)
+
+// ApprovalService exposes the approval tasks created by workflow approval nodes
+type ApprovalService struct {
+	logger *logging.Logger
+}
+
+func NewApprovalService(logger *logging.Logger) *ApprovalService {
+	return &ApprovalService{logger: logger}
+}
+
+func (s *ApprovalService) RegisterRoutes(router *gin.RouterGroup) {
+	group := router.Group("/approvals")
+	{
+		group.GET("", s.ListApprovals)
+		group.GET("/:id", s.GetApproval)
+		group.POST("/:id/decision", s.DecideApproval)
+	}
+}
+
+// ApprovalDecisionRequest is the body of POST /approvals/:id/decision
+type ApprovalDecisionRequest struct {
+	Approved *bool  `json:"approved" binding:"required" example:"true"`
+	Approver string `json:"approver" example:"alice"` // falls back to the X-Approver header, then the client IP
+	Comment  string `json:"comment"`
+}
+
+// ListApprovals lists approval tasks, optionally filtered by ?status=pending
+// @Summary List approval tasks
+// @Tags Approvals
+// @Produce json
+// @Param status query string false "Filter by status" Enums(pending,approved,rejected,expired,cancelled)
+// @Success 200 {object} httptransport.APIResponse{data=[]workflow.ApprovalTask}
+// @Router /v1/approvals [get]
+func (s *ApprovalService) ListApprovals(c *gin.Context) {
+	tasks := workflow.DefaultApprovalManager().List(workflow.ApprovalStatus(c.Query("status")))
+	httpUtils.Response.Success(c, tasks, "")
+}
+
+// GetApproval returns a single approval task
+// @Summary Get approval task
+// @Tags Approvals
+// @Produce json
+// @Param id path string true "Approval ID"
+// @Success 200 {object} httptransport.APIResponse{data=workflow.ApprovalTask}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/approvals/{id} [get]
+func (s *ApprovalService) GetApproval(c *gin.Context) {
+	task, ok := workflow.DefaultApprovalManager().Get(c.Param("id"))
+	if !ok {
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeResourceNotFound, "approval not found: "+c.Param("id"))
+		return
+	}
+	httpUtils.Response.Success(c, task, "")
+}
+
+// DecideApproval approves or rejects a pending task, which resumes or aborts the waiting execution
+// @Summary Decide approval task
+// @Description Approves or rejects a pending task, which resumes or aborts the waiting execution. Deciding an already decided task returns 409 with the task in error.details
+// @Tags Approvals
+// @Accept json
+// @Produce json
+// @Param id path string true "Approval ID"
+// @Param request body ApprovalDecisionRequest true "Decision"
+// @Success 200 {object} httptransport.APIResponse{data=workflow.ApprovalTask}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Failure 409 {object} httptransport.APIResponse
+// @Router /v1/approvals/{id}/decision [post]
+func (s *ApprovalService) DecideApproval(c *gin.Context) {
+	var req ApprovalDecisionRequest
+	if !middleware.BindJSON(c, &req) {
+		return
+	}
+	approver := req.Approver
+	if approver == "" {
+		approver = c.GetHeader("X-Approver")
+	}
+	if approver == "" {
+		approver = c.ClientIP()
+	}
+
+	task, err := workflow.DefaultApprovalManager().Decide(c.Param("id"), *req.Approved, approver, req.Comment)
+	switch {
+	case errors.Is(err, workflow.ErrApprovalNotFound):
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeResourceNotFound, err.Error())
+		return
+	case errors.Is(err, workflow.ErrApprovalDecided):
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeConflict, err.Error(), task)
+		return
+	case err != nil:
+		httpUtils.Response.InternalError(c, err.Error())
+		return
+	}
+
+	s.logger.InfoTag("审批", "审批任务 %s 已由 %s 处理: %s", task.ID, approver, task.Status)
+	httpUtils.Response.Success(c, task, "")
+}
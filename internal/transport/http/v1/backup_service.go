@@ -0,0 +1,281 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/backup"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// BackupServiceV1 V1版本备份服务
+type BackupServiceV1 struct {
+	logger  *logging.Logger
+	service *backup.Service
+}
+
+// NewBackupServiceV1 创建备份服务V1实例
+func NewBackupServiceV1(logger *logging.Logger, service *backup.Service) (*BackupServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("backup service is required")
+	}
+	return &BackupServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册备份API路由，仅管理员可访问
+func (s *BackupServiceV1) Register(router *gin.RouterGroup) {
+	backups := router.Group("/backups")
+	{
+		backups.GET("", s.listBackups)                  // 获取备份列表
+		backups.POST("", s.createBackup)                // 立即创建备份
+		backups.POST("/import", s.importBackup)         // 导入备份文件
+		backups.GET("/:name", s.downloadBackup)         // 下载备份文件
+		backups.POST("/:name/verify", s.verifyBackup)   // 校验备份
+		backups.POST("/:name/restore", s.restoreBackup) // 从备份恢复
+		backups.DELETE("/:name", s.deleteBackup)        // 删除备份
+	}
+}
+
+// listBackups 获取备份列表
+// @Summary 获取备份列表
+// @Description 列出备份目录中的备份文件，最新的在前，并返回自动备份设置
+// @Tags Backups
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=v1.BackupListResponse}
+// @Failure 403 {object} httptransport.APIResponse
+// @Router /v1/backups [get]
+func (s *BackupServiceV1) listBackups(c *gin.Context) {
+	archives, err := s.service.List()
+	if err != nil {
+		s.handleError(c, err, "获取备份列表失败")
+		return
+	}
+	cfg := s.service.Config()
+	response := v1.BackupListResponse{
+		Backups: make([]v1.BackupInfo, 0, len(archives)),
+		Dir:     cfg.Dir,
+		Keep:    cfg.Keep,
+		Paths:   cfg.Paths,
+	}
+	if cfg.Interval > 0 {
+		response.Interval = cfg.Interval.String()
+	}
+	for _, archive := range archives {
+		response.Backups = append(response.Backups, toBackupInfo(archive))
+	}
+	httpUtils.Response.Success(c, response, "获取备份列表成功")
+}
+
+// createBackup 立即创建备份
+// @Summary 创建备份
+// @Description 对数据库做快照，连同 Backup.Paths 中的文件和目录打包并加密保存到备份目录。
+// @Description 手动创建的备份不参与自动备份的保留数量清理；已有备份或恢复在进行时返回 409
+// @Tags Backups
+// @Produce json
+// @Success 201 {object} httptransport.APIResponse{data=v1.BackupInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 409 {object} httptransport.APIResponse
+// @Router /v1/backups [post]
+func (s *BackupServiceV1) createBackup(c *gin.Context) {
+	archive, err := s.service.Create(c.Request.Context(), false)
+	if err != nil {
+		s.handleError(c, err, "创建备份失败")
+		return
+	}
+	s.logger.InfoTag("API", "创建备份", "name", archive.Name, "size", archive.Size, "request_id", getRequestID(c))
+	httpUtils.Response.Created(c, toBackupInfo(archive), "备份已创建")
+}
+
+// importBackup 导入备份文件
+// @Summary 导入备份
+// @Description 以 multipart 的 file 字段上传其他实例或之前下载的备份文件，解密并校验全部文件摘要通过后保存到备份目录。
+// @Description passphrase 缺省使用本实例配置的口令
+// @Tags Backups
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "备份文件"
+// @Param passphrase formData string false "备份口令"
+// @Success 201 {object} httptransport.APIResponse{data=v1.BackupInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 409 {object} httptransport.APIResponse
+// @Router /v1/backups/import [post]
+func (s *BackupServiceV1) importBackup(c *gin.Context) {
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		httpUtils.Response.BadRequest(c, "缺少 file 字段")
+		return
+	}
+	defer file.Close()
+
+	archive, _, err := s.service.Import(c.Request.Context(), file, c.PostForm("passphrase"))
+	if err != nil {
+		s.handleError(c, err, "导入备份失败")
+		return
+	}
+	s.logger.InfoTag("API", "导入备份", "name", archive.Name, "size", archive.Size, "request_id", getRequestID(c))
+	httpUtils.Response.Created(c, toBackupInfo(archive), "备份已导入")
+}
+
+// downloadBackup 下载备份文件
+// @Summary 下载备份
+// @Description 下载加密的备份文件，可用 xiaozhi-server restore 在其他机器上恢复
+// @Tags Backups
+// @Produce octet-stream
+// @Param name path string true "备份文件名"
+// @Success 200 {file} file
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/backups/{name} [get]
+func (s *BackupServiceV1) downloadBackup(c *gin.Context) {
+	f, archive, err := s.service.Open(c.Param("name"))
+	if err != nil {
+		s.handleError(c, err, "读取备份失败")
+		return
+	}
+	defer f.Close()
+	c.DataFromReader(http.StatusOK, archive.Size, "application/octet-stream", f, map[string]string{
+		"Content-Disposition": fmt.Sprintf("attachment; filename=%q", archive.Name),
+	})
+}
+
+// verifyBackup 校验备份
+// @Summary 校验备份
+// @Description 解密备份并逐一核对清单中每个文件的大小和 SHA-256，不修改任何数据。口令错误或内容被篡改时返回 400
+// @Tags Backups
+// @Accept json
+// @Produce json
+// @Param name path string true "备份文件名"
+// @Param request body v1.BackupPassphraseRequest false "口令，缺省使用配置的口令"
+// @Success 200 {object} httptransport.APIResponse{data=v1.BackupVerifyResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/backups/{name}/verify [post]
+func (s *BackupServiceV1) verifyBackup(c *gin.Context) {
+	request, ok := bindPassphrase(c)
+	if !ok {
+		return
+	}
+	result, err := s.service.Verify(c.Request.Context(), c.Param("name"), request.Passphrase)
+	if err != nil {
+		s.handleError(c, err, "校验备份失败")
+		return
+	}
+	httpUtils.Response.Success(c, toBackupVerifyResponse(c.Param("name"), result), "备份校验通过")
+}
+
+// restoreBackup 从备份恢复
+// @Summary 从备份恢复
+// @Description 校验备份并解包到恢复暂存目录，服务下次启动时在打开数据库之前替换数据库和备份中的文件，
+// @Description 被替换的文件改名为 *.pre-restore-<时间> 保留。校验不通过时不会暂存任何内容
+// @Tags Backups
+// @Accept json
+// @Produce json
+// @Param name path string true "备份文件名"
+// @Param request body v1.BackupPassphraseRequest false "口令，缺省使用配置的口令"
+// @Success 202 {object} httptransport.APIResponse{data=v1.BackupRestoreResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Failure 409 {object} httptransport.APIResponse
+// @Router /v1/backups/{name}/restore [post]
+func (s *BackupServiceV1) restoreBackup(c *gin.Context) {
+	request, ok := bindPassphrase(c)
+	if !ok {
+		return
+	}
+	result, err := s.service.StageRestore(c.Request.Context(), c.Param("name"), request.Passphrase)
+	if err != nil {
+		s.handleError(c, err, "恢复备份失败")
+		return
+	}
+	s.logger.WarnTag("API", "已暂存备份恢复，重启服务后生效", "name", c.Param("name"), "request_id", getRequestID(c))
+	httpUtils.Response.Accepted(c, v1.BackupRestoreResponse{
+		BackupVerifyResponse: toBackupVerifyResponse(c.Param("name"), result),
+		RestartRequired:      true,
+	}, "备份已校验并暂存，重启服务后生效")
+}
+
+// deleteBackup 删除备份
+// @Summary 删除备份
+// @Tags Backups
+// @Produce json
+// @Param name path string true "备份文件名"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/backups/{name} [delete]
+func (s *BackupServiceV1) deleteBackup(c *gin.Context) {
+	if err := s.service.Delete(c.Param("name")); err != nil {
+		s.handleError(c, err, "删除备份失败")
+		return
+	}
+	s.logger.InfoTag("API", "删除备份", "name", c.Param("name"), "request_id", getRequestID(c))
+	httpUtils.Response.Success(c, nil, "备份已删除")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *BackupServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, backup.ErrNotFound):
+		httpUtils.Response.NotFound(c, "备份")
+	case errors.Is(err, backup.ErrBusy):
+		httpUtils.Response.Conflict(c, "已有备份或恢复在进行，请稍后再试")
+	case errors.Is(err, backup.ErrDecrypt):
+		httpUtils.Response.BadRequest(c, "口令错误或备份文件已损坏")
+	case errors.Is(err, backup.ErrIntegrity):
+		s.logger.WarnTag("API", "备份完整性校验失败", "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.BadRequest(c, "备份完整性校验失败，文件可能已被篡改或损坏")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+// bindPassphrase 解析可选的口令请求体
+func bindPassphrase(c *gin.Context) (v1.BackupPassphraseRequest, bool) {
+	var request v1.BackupPassphraseRequest
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		httpUtils.Response.ValidationError(c, err)
+		return request, false
+	}
+	return request, true
+}
+
+func toBackupInfo(archive *backup.Archive) v1.BackupInfo {
+	return v1.BackupInfo{
+		Name:      archive.Name,
+		Size:      archive.Size,
+		Auto:      archive.Auto,
+		CreatedAt: archive.CreatedAt,
+	}
+}
+
+func toBackupVerifyResponse(name string, result *backup.Result) v1.BackupVerifyResponse {
+	return v1.BackupVerifyResponse{
+		Name:      name,
+		CreatedAt: result.Manifest.CreatedAt,
+		Driver:    result.Manifest.Driver,
+		Database:  result.Manifest.Database,
+		Roots:     result.Manifest.Roots,
+		Files:     result.Files,
+		Bytes:     result.Bytes,
+	}
+}
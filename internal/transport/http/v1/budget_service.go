@@ -0,0 +1,126 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/budget"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/middleware"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// BudgetServiceV1 V1版本Token预算服务
+type BudgetServiceV1 struct {
+	logger  *logging.Logger
+	service *budget.Service
+}
+
+// NewBudgetServiceV1 创建Token预算服务V1实例
+func NewBudgetServiceV1(logger *logging.Logger, service *budget.Service) (*BudgetServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("budget service is required")
+	}
+	return &BudgetServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册Token预算API路由，设备用量列表仅管理员可用
+func (s *BudgetServiceV1) Register(router *gin.RouterGroup) {
+	router.GET("/devices/:id/usage", s.getDeviceUsage) // 查询设备的Token用量和预算
+
+	usage := router.Group("/usage", middleware.RequireAdmin())
+	{
+		usage.GET("/devices", s.listDeviceUsage) // 按Token用量列出设备
+	}
+}
+
+// getDeviceUsage 查询设备的Token用量
+// @Summary 查询设备的Token用量
+// @Description 返回设备指定日期的Token用量、LLM调用次数、因超出预算被拒绝的次数以及适用的预算
+// @Tags Budgets
+// @Produce json
+// @Param id path string true "设备ID"
+// @Param day query string false "日期 YYYY-MM-DD，默认今天"
+// @Success 200 {object} httptransport.APIResponse{data=v1.DeviceTokenUsageInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/devices/{id}/usage [get]
+func (s *BudgetServiceV1) getDeviceUsage(c *gin.Context) {
+	usage, err := s.service.Usage(c.Request.Context(), c.Param("id"), c.Query("day"))
+	if err != nil {
+		s.handleError(c, err, "查询设备用量失败")
+		return
+	}
+	httpUtils.Response.Success(c, s.toUsageInfo(usage), "查询设备用量成功")
+}
+
+// listDeviceUsage 按Token用量列出设备
+// @Summary 按Token用量列出设备
+// @Description 返回指定日期有用量的设备，按 Token 数从多到少排序
+// @Tags Budgets
+// @Produce json
+// @Param day query string false "日期 YYYY-MM-DD，默认今天"
+// @Param limit query int false "返回的设备数，默认 100，最多 500"
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.DeviceTokenUsageInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/usage/devices [get]
+func (s *BudgetServiceV1) listDeviceUsage(c *gin.Context) {
+	var query v1.DeviceTokenUsageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	items, err := s.service.ListUsage(c.Request.Context(), query.Day, query.Limit)
+	if err != nil {
+		s.handleError(c, err, "查询设备用量失败")
+		return
+	}
+	result := make([]v1.DeviceTokenUsageInfo, 0, len(items))
+	for _, usage := range items {
+		result = append(result, s.toUsageInfo(usage))
+	}
+	httpUtils.Response.Success(c, result, "查询设备用量成功")
+}
+
+func (s *BudgetServiceV1) toUsageInfo(usage *budget.Usage) v1.DeviceTokenUsageInfo {
+	limits := s.service.Limits(usage.DeviceID)
+	info := v1.DeviceTokenUsageInfo{
+		DeviceID: usage.DeviceID,
+		Day:      usage.Day,
+		Tokens:   usage.Tokens,
+		Calls:    usage.Calls,
+		Rejected: usage.Rejected,
+		Enabled:  s.service.Enabled(),
+		Limits: v1.BudgetLimits{
+			Profile:       limits.Profile,
+			TurnTokens:    limits.TurnTokens,
+			SessionTokens: limits.SessionTokens,
+			DailyTokens:   limits.DailyTokens,
+		},
+	}
+	if limits.DailyTokens > 0 {
+		remaining := limits.DailyTokens - usage.Tokens
+		if remaining < 0 {
+			remaining = 0
+		}
+		info.Remaining = &remaining
+	}
+	return info
+}
+
+func (s *BudgetServiceV1) handleError(c *gin.Context, err error, message string) {
+	if platformerrors.IsKind(err, platformerrors.KindDomain) {
+		httpUtils.Response.BadRequest(c, err.Error())
+		return
+	}
+	s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+	httpUtils.Response.InternalError(c, message)
+}
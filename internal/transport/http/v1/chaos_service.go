@@ -0,0 +1,150 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/platform/chaos"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// ChaosServiceV1 V1版本故障注入服务
+type ChaosServiceV1 struct {
+	logger   *logging.Logger
+	injector *chaos.Injector
+}
+
+// NewChaosServiceV1 创建故障注入服务V1实例
+func NewChaosServiceV1(logger *logging.Logger, injector *chaos.Injector) (*ChaosServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if injector == nil {
+		return nil, fmt.Errorf("chaos injector is required")
+	}
+	return &ChaosServiceV1{
+		logger:   logger,
+		injector: injector,
+	}, nil
+}
+
+// Register 注册故障注入API路由，仅管理员可访问
+func (s *ChaosServiceV1) Register(router *gin.RouterGroup) {
+	rules := router.Group("/debug/chaos/rules")
+	{
+		rules.GET("", s.listRules)         // 获取生效中的规则
+		rules.POST("", s.addRule)          // 添加规则
+		rules.DELETE("/:id", s.deleteRule) // 删除规则
+		rules.DELETE("", s.clearRules)     // 清空规则
+	}
+}
+
+// listRules 获取故障注入规则
+// @Summary 获取故障注入规则
+// @Description 返回仍在有效期内、未达到次数上限的规则及其已注入次数
+// @Tags Debug
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=v1.ChaosRuleListResponse}
+// @Failure 403 {object} httptransport.APIResponse
+// @Router /v1/debug/chaos/rules [get]
+func (s *ChaosServiceV1) listRules(c *gin.Context) {
+	rules := s.injector.Rules()
+	infos := make([]v1.ChaosRuleInfo, 0, len(rules))
+	for _, rule := range rules {
+		infos = append(infos, toChaosRuleInfo(rule))
+	}
+	httpUtils.Response.Success(c, v1.ChaosRuleListResponse{Rules: infos}, "获取故障注入规则成功")
+}
+
+// addRule 添加故障注入规则
+// @Summary 添加故障注入规则
+// @Description 对匹配的能力调用、插件 gRPC 调用或提供者 HTTP 请求按概率注入延迟（delay）、错误（error）或中途中断（kill）；多条规则按添加顺序取第一条命中的规则，到期或达到次数上限后自动失效
+// @Tags Debug
+// @Accept json
+// @Produce json
+// @Param request body v1.ChaosRuleRequest true "规则"
+// @Success 201 {object} httptransport.APIResponse{data=v1.ChaosRuleInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Router /v1/debug/chaos/rules [post]
+func (s *ChaosServiceV1) addRule(c *gin.Context) {
+	var request v1.ChaosRuleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	rule, err := s.injector.AddRule(chaos.Rule{
+		Target:      chaos.Target(request.Target),
+		Match:       request.Match,
+		Method:      request.Method,
+		Action:      chaos.Action(request.Action),
+		Probability: request.Probability,
+		Delay:       time.Duration(request.DelayMs) * time.Millisecond,
+		StatusCode:  request.StatusCode,
+		Message:     request.Message,
+		MaxHits:     request.MaxHits,
+	}, time.Duration(request.TTLSeconds)*time.Second)
+	if err != nil {
+		httpUtils.Response.BadRequest(c, err.Error())
+		return
+	}
+	s.logger.WarnTag("API", "添加故障注入规则", "rule_id", rule.ID, "request_id", getRequestID(c))
+	httpUtils.Response.Created(c, toChaosRuleInfo(rule), "故障注入规则已添加")
+}
+
+// deleteRule 删除故障注入规则
+// @Summary 删除故障注入规则
+// @Tags Debug
+// @Produce json
+// @Param id path string true "规则ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/debug/chaos/rules/{id} [delete]
+func (s *ChaosServiceV1) deleteRule(c *gin.Context) {
+	if err := s.injector.RemoveRule(c.Param("id")); err != nil {
+		if errors.Is(err, chaos.ErrNotFound) {
+			httpUtils.Response.NotFound(c, "故障注入规则")
+			return
+		}
+		httpUtils.Response.InternalError(c, "删除故障注入规则失败")
+		return
+	}
+	httpUtils.Response.Success(c, nil, "故障注入规则已删除")
+}
+
+// clearRules 清空故障注入规则
+// @Summary 清空故障注入规则
+// @Tags Debug
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=v1.ChaosClearResponse}
+// @Failure 403 {object} httptransport.APIResponse
+// @Router /v1/debug/chaos/rules [delete]
+func (s *ChaosServiceV1) clearRules(c *gin.Context) {
+	deleted := s.injector.Clear()
+	httpUtils.Response.Success(c, v1.ChaosClearResponse{Deleted: deleted}, "故障注入规则已清空")
+}
+
+func toChaosRuleInfo(rule chaos.Rule) v1.ChaosRuleInfo {
+	return v1.ChaosRuleInfo{
+		ID:          rule.ID,
+		Target:      string(rule.Target),
+		Match:       rule.Match,
+		Method:      rule.Method,
+		Action:      string(rule.Action),
+		Probability: rule.Probability,
+		DelayMs:     rule.Delay.Milliseconds(),
+		StatusCode:  rule.StatusCode,
+		Message:     rule.Message,
+		MaxHits:     rule.MaxHits,
+		Hits:        rule.Hits,
+		CreatedAt:   rule.CreatedAt,
+		ExpiresAt:   rule.ExpiresAt,
+	}
+}
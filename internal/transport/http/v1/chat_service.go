@@ -0,0 +1,167 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/core"
+	"xiaozhi-server-go/internal/core/transport"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// ChatServiceV1 V1版本文本对话服务
+type ChatServiceV1 struct {
+	logger   *logging.Logger
+	textChat *transport.TextChat
+}
+
+// NewChatServiceV1 创建文本对话服务V1实例
+func NewChatServiceV1(logger *logging.Logger, textChat *transport.TextChat) (*ChatServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if textChat == nil {
+		return nil, fmt.Errorf("text chat is required")
+	}
+	return &ChatServiceV1{
+		logger:   logger,
+		textChat: textChat,
+	}, nil
+}
+
+// Register 注册文本对话API路由
+func (s *ChatServiceV1) Register(router *gin.RouterGroup) {
+	router.POST("/chat", s.chat) // 文本对话
+}
+
+// chat 文本对话
+// @Summary 文本对话
+// @Description 与语音设备使用相同的提示词、对话流水线和工具进行文本对话。关联的设备在线时进入设备当前的会话，共用对话历史；
+// @Description 否则创建不连接设备的会话，返回的 session_id 用于继续对话。stream=true 或 Accept: text/event-stream 时以 SSE 推送，
+// @Description 事件 segment 为回复分段，done 为完整回复（ChatResponse），error 为错误信息
+// @Tags Chat
+// @Accept json
+// @Produce json
+// @Produce text/event-stream
+// @Param request body v1.ChatRequest true "对话内容"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ChatResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 409 {object} httptransport.APIResponse
+// @Router /v1/chat [post]
+func (s *ChatServiceV1) chat(c *gin.Context) {
+	var request v1.ChatRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	if strings.TrimSpace(request.Text) == "" {
+		httpUtils.Response.BadRequest(c, "text 不能为空")
+		return
+	}
+	chatRequest := transport.TextChatRequest{
+		DeviceID:  request.DeviceID,
+		SessionID: request.SessionID,
+		Text:      request.Text,
+	}
+
+	if request.Stream || strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+		s.stream(c, chatRequest)
+		return
+	}
+
+	result, err := s.textChat.Chat(c.Request.Context(), chatRequest, nil)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+	httpUtils.Response.Success(c, toChatResponse(result), "对话成功")
+}
+
+// stream 以SSE逐段推送回复，回调在会话协程中执行，经通道交给请求协程写出
+func (s *ChatServiceV1) stream(c *gin.Context, request transport.TextChatRequest) {
+	ctx := c.Request.Context()
+	segments := make(chan string, 16)
+	type outcome struct {
+		result *core.TextChatResult
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := s.textChat.Chat(ctx, request, func(segment string) {
+			select {
+			case segments <- segment:
+			case <-ctx.Done():
+			}
+		})
+		done <- outcome{result, err}
+	}()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case segment := <-segments:
+			c.SSEvent("segment", v1.ChatSegment{Text: segment})
+			return true
+		case out := <-done:
+			// 结果返回前产生的分段已全部进入通道，先写完再结束
+			for len(segments) > 0 {
+				c.SSEvent("segment", v1.ChatSegment{Text: <-segments})
+			}
+			if out.err != nil {
+				s.logger.ErrorTag("API", "文本对话失败", "error", out.err, "request_id", getRequestID(c))
+				c.SSEvent("error", gin.H{"message": chatErrorMessage(out.err)})
+				return false
+			}
+			c.SSEvent("done", toChatResponse(out.result))
+			return false
+		}
+	})
+}
+
+// handleError 将文本对话错误映射为API错误
+func (s *ChatServiceV1) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, transport.ErrTextSessionLimit), errors.Is(err, transport.ErrTextSessionRejected):
+		httpUtils.Response.Conflict(c, chatErrorMessage(err))
+	default:
+		s.logger.ErrorTag("API", "文本对话失败", "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, chatErrorMessage(err))
+	}
+}
+
+func chatErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, transport.ErrTextSessionLimit):
+		return "文本对话会话数已达上限，请稍后再试"
+	case errors.Is(err, transport.ErrTextSessionRejected):
+		return "无法创建对话会话"
+	default:
+		return "文本对话失败"
+	}
+}
+
+func toChatResponse(result *core.TextChatResult) v1.ChatResponse {
+	segments := result.Segments
+	if segments == nil {
+		segments = []string{}
+	}
+	return v1.ChatResponse{
+		SessionID: result.SessionID,
+		DeviceID:  result.DeviceID,
+		Round:     result.Round,
+		Reply:     result.Reply,
+		Segments:  segments,
+		Blocked:   result.Blocked,
+	}
+}
@@ -1,14 +1,26 @@
 package v1
 
-import "github.com/gin-gonic/gin"
+import (
+	"context"
 
-// getRequestID 获取请求ID
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/tenant"
+	"xiaozhi-server-go/internal/platform/requestid"
+)
+
+// getRequestID 获取 ResponseMiddleware 分配的请求ID
 func getRequestID(c *gin.Context) string {
 	if requestID, exists := c.Get("request_id"); exists {
 		if id, ok := requestID.(string); ok {
 			return id
 		}
 	}
-	// 如果上下文中没有，尝试从Header获取
-	return c.GetHeader("X-Request-ID")
+	return requestid.FromContext(c.Request.Context())
+}
+
+// detachedContext 返回不随请求结束而取消的上下文，只保留租户和请求ID，供请求结束后继续运行的任务使用
+func detachedContext(c *gin.Context) context.Context {
+	ctx := tenant.WithID(context.Background(), tenant.IDFrom(c.Request.Context()))
+	return requestid.WithID(ctx, getRequestID(c))
 }
@@ -0,0 +1,262 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/config/manifest"
+	"xiaozhi-server-go/internal/domain/config/types"
+	pluginconfig "xiaozhi-server-go/internal/domain/plugin/config"
+	"xiaozhi-server-go/internal/domain/prompt"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/httpclient"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+const (
+	defaultProviderTestTimeout = 15 * time.Second
+	maxProviderTestTimeout     = time.Minute
+)
+
+// ConfigServiceV1 V1版本配置服务，提供配置导入导出、声明式清单应用和供应商配置测试
+type ConfigServiceV1 struct {
+	logger     *logging.Logger
+	repo       types.Repository
+	registry   *capability.Registry
+	reconciler *manifest.Reconciler
+}
+
+// NewConfigServiceV1 创建配置服务V1实例，prompts 为 nil 时清单不能包含提示词和设备分组
+func NewConfigServiceV1(logger *logging.Logger, repo types.Repository, registry *capability.Registry, prompts *prompt.Service) (*ConfigServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if repo == nil {
+		return nil, fmt.Errorf("config repository is required")
+	}
+	if registry == nil {
+		return nil, fmt.Errorf("capability registry is required")
+	}
+	return &ConfigServiceV1{
+		logger:     logger,
+		repo:       repo,
+		registry:   registry,
+		reconciler: manifest.NewReconciler(repo, prompts, logger),
+	}, nil
+}
+
+// Register 注册配置API路由
+func (s *ConfigServiceV1) Register(router *gin.RouterGroup) {
+	configs := router.Group("/config")
+	{
+		configs.GET("/export", s.exportConfig)                      // 导出配置
+		configs.POST("/import", s.importConfig)                     // 导入配置
+		configs.POST("/apply", s.applyConfig)                       // 应用声明式配置清单
+		configs.POST("/providers/test", s.testProvider)             // 测试供应商配置
+		configs.POST("/providers/connectivity", s.testConnectivity) // 测试提供者网络连通性
+	}
+}
+
+// exportConfig 导出配置
+// @Summary 导出配置
+// @Description 导出数据库中保存的完整服务配置，可直接用于导入
+// @Tags Config
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse
+// @Router /v1/config/export [get]
+func (s *ConfigServiceV1) exportConfig(c *gin.Context) {
+	cfg, err := s.repo.LoadConfig()
+	if err != nil {
+		s.logger.ErrorTag("API", "导出配置失败: %v", err)
+		httpUtils.Response.InternalError(c, "导出配置失败")
+		return
+	}
+	httpUtils.Response.Success(c, cfg, "导出配置成功")
+}
+
+// importConfig 导入配置
+// @Summary 导入配置
+// @Description 将导出的配置写回数据库；请求中未出现的字段保持当前值，未知字段视为错误。多数配置在重启服务后生效
+// @Tags Config
+// @Accept json
+// @Produce json
+// @Param request body object true "导出的配置"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/config/import [post]
+func (s *ConfigServiceV1) importConfig(c *gin.Context) {
+	cfg, err := s.repo.LoadConfig()
+	if err != nil {
+		s.logger.ErrorTag("API", "加载当前配置失败: %v", err)
+		httpUtils.Response.InternalError(c, "加载当前配置失败")
+		return
+	}
+
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(cfg); err != nil {
+		httpUtils.Response.BadRequest(c, "配置格式错误: "+err.Error())
+		return
+	}
+
+	if err := s.repo.SaveConfig(cfg); err != nil {
+		s.logger.ErrorTag("API", "保存导入的配置失败: %v", err)
+		httpUtils.Response.InternalError(c, "保存配置失败")
+		return
+	}
+	s.logger.InfoTag("API", "配置已导入，request_id=%s", getRequestID(c))
+	httpUtils.Response.Success(c, nil, "配置导入成功，重启服务后生效")
+}
+
+// applyConfig 应用声明式配置清单
+// @Summary 应用配置清单
+// @Description 将供应商、提示词模板、设备分组和当前工作流调整为清单描述的状态。清单中出现的部分（包括空列表）视为该类资源的完整期望状态，多余的同类资源会被删除；未出现的部分保持不变。dry_run=true 时只返回变更计划
+// @Tags Config
+// @Accept json
+// @Produce json
+// @Param dry_run query bool false "只计算变更计划，不做修改"
+// @Param request body manifest.Manifest true "配置清单"
+// @Success 200 {object} httptransport.APIResponse{data=manifest.Plan}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 500 {object} httptransport.APIResponse
+// @Router /v1/config/apply [post]
+func (s *ConfigServiceV1) applyConfig(c *gin.Context) {
+	var m manifest.Manifest
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&m); err != nil {
+		httpUtils.Response.BadRequest(c, "清单格式错误: "+err.Error())
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(c.Query("dry_run"))
+	apply := s.reconciler.Apply
+	if dryRun {
+		apply = s.reconciler.Plan
+	}
+	plan, err := apply(c.Request.Context(), &m)
+	switch {
+	case err == nil:
+	case plan == nil && platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+		return
+	case plan == nil:
+		s.logger.ErrorTag("API", "计算配置变更失败: %v", err)
+		httpUtils.Response.InternalError(c, "计算配置变更失败")
+		return
+	default:
+		// 部分变更已执行，返回计划以便调用方了解当前状态
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeInternalServer, "应用配置清单失败: "+err.Error(), plan)
+		return
+	}
+
+	if dryRun {
+		httpUtils.Response.Success(c, plan, "已生成变更计划")
+		return
+	}
+	s.logger.InfoTag("API", "配置清单已应用，%d 项变更，request_id=%s", len(plan.Changes), getRequestID(c))
+	httpUtils.Response.Success(c, plan, "配置清单已应用")
+}
+
+// testProvider 测试供应商配置
+// @Summary 测试供应商配置
+// @Description 使用给定配置对能力执行一次最小调用（LLM 发送 ping，TTS 合成短文本，其它类型仅校验执行器可创建），测试失败时 success 为 false
+// @Tags Config
+// @Accept json
+// @Produce json
+// @Param request body v1.ProviderTestRequest true "测试参数"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ProviderTestResult}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/config/providers/test [post]
+func (s *ConfigServiceV1) testProvider(c *gin.Context) {
+	var request v1.ProviderTestRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	timeout, ok := parseTestTimeout(c, request.Timeout)
+	if !ok {
+		return
+	}
+
+	def, providerID, ok := s.registry.Lookup(request.CapabilityID)
+	if !ok {
+		httpUtils.Response.NotFound(c, "能力")
+		return
+	}
+	if request.Config == nil {
+		request.Config = make(map[string]interface{})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+	defer cancel()
+	start := time.Now()
+	err := pluginconfig.ProbeCapability(ctx, s.registry, def.ID, pluginconfig.CapabilityType(def.Type), request.Config)
+
+	result := v1.ProviderTestResult{
+		CapabilityID: def.ID,
+		ProviderID:   providerID,
+		Type:         string(def.Type),
+		Success:      err == nil,
+		Message:      "连接测试成功",
+		LatencyMs:    time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Message = err.Error()
+	}
+	httpUtils.Response.Success(c, result, "供应商配置测试完成")
+}
+
+// testConnectivity 测试提供者网络连通性
+// @Summary 测试提供者网络连通性
+// @Description 按提供者的网络配置（HTTPClient.Providers 叠加请求中的 network）向 url 发送 HEAD 请求，经过配置的代理、端点改写和CA证书，返回实际请求的地址、代理、对端地址和TLS版本；收到任意HTTP响应即为成功
+// @Tags Config
+// @Accept json
+// @Produce json
+// @Param request body v1.ProviderConnectivityRequest true "测试参数"
+// @Success 200 {object} httptransport.APIResponse{data=httpclient.CheckResult}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/config/providers/connectivity [post]
+func (s *ConfigServiceV1) testConnectivity(c *gin.Context) {
+	var request v1.ProviderConnectivityRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	timeout, ok := parseTestTimeout(c, request.Timeout)
+	if !ok {
+		return
+	}
+	opts, err := httpclient.ParseNetwork(request.Network)
+	if err != nil {
+		httpUtils.Response.BadRequest(c, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+	defer cancel()
+	result := httpclient.Default().Check(ctx, request.Provider, request.URL, opts)
+	httpUtils.Response.Success(c, result, "网络连通性测试完成")
+}
+
+// parseTestTimeout 解析测试超时，为空时使用默认值，超过上限时取上限；无效时返回 400
+func parseTestTimeout(c *gin.Context, raw string) (time.Duration, bool) {
+	if raw == "" {
+		return defaultProviderTestTimeout, true
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		httpUtils.Response.BadRequest(c, "timeout 必须是正的时长，如 10s")
+		return 0, false
+	}
+	return min(d, maxProviderTestTimeout), true
+}
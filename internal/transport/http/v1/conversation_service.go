@@ -0,0 +1,513 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/transcript"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// ConversationServiceV1 V1版本对话记录服务
+type ConversationServiceV1 struct {
+	logger  *logging.Logger
+	service *transcript.Service
+}
+
+// NewConversationServiceV1 创建对话记录服务V1实例
+func NewConversationServiceV1(logger *logging.Logger, service *transcript.Service) (*ConversationServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("transcript service is required")
+	}
+	return &ConversationServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册对话记录API路由
+func (s *ConversationServiceV1) Register(router *gin.RouterGroup) {
+	conversations := router.Group("/conversations")
+	{
+		conversations.GET("", s.listConversations)                                         // 获取会话列表
+		conversations.GET("/:session_id", s.getConversation)                               // 获取会话详情
+		conversations.GET("/:session_id/export", s.exportConversation)                     // 导出会话
+		conversations.DELETE("/:session_id", s.deleteConversation)                         // 删除会话
+		conversations.POST("/:session_id/messages/:message_id/feedback", s.submitFeedback) // 提交消息反馈
+	}
+
+	feedback := router.Group("/feedback")
+	{
+		feedback.GET("", s.listFeedback)        // 获取反馈列表
+		feedback.GET("/stats", s.feedbackStats) // 获取反馈质量统计
+	}
+
+	router.GET("/metrics/latency", s.latencyStats) // 获取各阶段耗时百分位统计
+}
+
+// listConversations 获取会话列表
+// @Summary 获取会话列表
+// @Description 按设备、会话、时间范围过滤会话，支持按消息内容搜索
+// @Tags Conversations
+// @Produce json
+// @Param device_id query string false "设备ID"
+// @Param session_id query string false "会话ID"
+// @Param q query string false "消息内容关键字"
+// @Param from query string false "开始时间 RFC3339"
+// @Param to query string false "结束时间 RFC3339"
+// @Param page query int false "页码" default(1)
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} httptransport.APIResponse{data=v1.ConversationListResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/conversations [get]
+func (s *ConversationServiceV1) listConversations(c *gin.Context) {
+	var query v1.ConversationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 20
+	}
+
+	filter := transcript.Filter{
+		DeviceID:  query.DeviceID,
+		SessionID: query.SessionID,
+		Query:     query.Q,
+		Page:      query.Page,
+		PageSize:  query.Limit,
+	}
+	var err error
+	if filter.From, err = parseQueryTime(query.From); err != nil {
+		httpUtils.Response.BadRequest(c, "from 必须是 RFC3339 时间")
+		return
+	}
+	if filter.To, err = parseQueryTime(query.To); err != nil {
+		httpUtils.Response.BadRequest(c, "to 必须是 RFC3339 时间")
+		return
+	}
+
+	items, total, err := s.service.ListConversations(c.Request.Context(), filter)
+	if err != nil {
+		s.handleError(c, err, "获取会话列表失败")
+		return
+	}
+
+	conversations := make([]v1.ConversationInfo, 0, len(items))
+	for _, item := range items {
+		conversations = append(conversations, toConversationInfo(item))
+	}
+	totalPages := (total + int64(query.Limit) - 1) / int64(query.Limit)
+	httpUtils.Response.Success(c, v1.ConversationListResponse{
+		Conversations: conversations,
+		Pagination: v1.Pagination{
+			Page:       int64(query.Page),
+			Limit:      int64(query.Limit),
+			Total:      total,
+			TotalPages: totalPages,
+			HasNext:    int64(query.Page) < totalPages,
+			HasPrev:    query.Page > 1,
+		},
+	}, "获取会话列表成功")
+}
+
+// getConversation 获取会话详情
+// @Summary 获取会话详情
+// @Description 获取会话内的全部消息，包括用户语句、助手回复、工具调用和耗时，以及各轮的分阶段耗时
+// @Tags Conversations
+// @Produce json
+// @Param session_id path string true "会话ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ConversationDetailResponse}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/conversations/{session_id} [get]
+func (s *ConversationServiceV1) getConversation(c *gin.Context) {
+	conv, entries, err := s.service.GetConversation(c.Request.Context(), c.Param("session_id"))
+	if err != nil {
+		s.handleError(c, err, "获取会话失败")
+		return
+	}
+
+	infos := make([]v1.TranscriptEntryInfo, 0, len(entries))
+	for _, e := range entries {
+		infos = append(infos, v1.TranscriptEntryInfo{
+			ID:         e.ID,
+			Round:      e.Round,
+			Role:       string(e.Role),
+			Content:    e.Content,
+			ToolName:   e.ToolName,
+			ToolCallID: e.ToolCallID,
+			ToolArgs:   e.ToolArgs,
+			LatencyMs:  e.LatencyMs,
+			Redacted:   e.Redacted,
+			CreatedAt:  e.CreatedAt,
+		})
+	}
+	latencies, err := s.service.TurnLatencies(c.Request.Context(), conv.SessionID)
+	if err != nil {
+		s.handleError(c, err, "获取会话失败")
+		return
+	}
+	latencyInfos := make([]v1.TurnLatencyInfo, 0, len(latencies))
+	for _, t := range latencies {
+		latencyInfos = append(latencyInfos, v1.TurnLatencyInfo{
+			Round:           t.Round,
+			CaptureMs:       t.CaptureMs,
+			ASRMs:           t.ASRMs,
+			LLMFirstTokenMs: t.LLMFirstTokenMs,
+			LLMCompleteMs:   t.LLMCompleteMs,
+			TTSFirstByteMs:  t.TTSFirstByteMs,
+			PlaybackStartMs: t.PlaybackStartMs,
+			TotalMs:         t.TotalMs,
+			CreatedAt:       t.CreatedAt,
+		})
+	}
+	httpUtils.Response.Success(c, v1.ConversationDetailResponse{
+		Conversation: toConversationInfo(conv),
+		Entries:      infos,
+		Latencies:    latencyInfos,
+	}, "获取会话成功")
+}
+
+// exportConversation 导出会话
+// @Summary 导出会话
+// @Description 以 JSON 或 Markdown 格式下载会话记录
+// @Tags Conversations
+// @Produce json,text/markdown
+// @Param session_id path string true "会话ID"
+// @Param format query string false "导出格式 json/markdown" default(json)
+// @Success 200 {file} file
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/conversations/{session_id}/export [get]
+func (s *ConversationServiceV1) exportConversation(c *gin.Context) {
+	sessionID := c.Param("session_id")
+	format := transcript.Format(strings.ToLower(c.DefaultQuery("format", string(transcript.FormatJSON))))
+	if format == "md" {
+		format = transcript.FormatMarkdown
+	}
+
+	data, contentType, err := s.service.Export(c.Request.Context(), sessionID, format)
+	if err != nil {
+		s.handleError(c, err, "导出会话失败")
+		return
+	}
+
+	ext := "json"
+	if format == transcript.FormatMarkdown {
+		ext = "md"
+	}
+	s.logger.InfoTag("API", "导出会话", "session_id", sessionID, "format", format, "request_id", getRequestID(c))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "conversation-"+sessionID+"."+ext))
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// deleteConversation 删除会话
+// @Summary 删除会话
+// @Tags Conversations
+// @Produce json
+// @Param session_id path string true "会话ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/conversations/{session_id} [delete]
+func (s *ConversationServiceV1) deleteConversation(c *gin.Context) {
+	if err := s.service.DeleteConversation(c.Request.Context(), c.Param("session_id")); err != nil {
+		s.handleError(c, err, "删除会话失败")
+		return
+	}
+	httpUtils.Response.Success(c, nil, "会话已删除")
+}
+
+// submitFeedback 提交消息反馈
+// @Summary 提交消息反馈
+// @Description 对助手回复点赞/点踩或提交文字反馈，反馈会计入所在A/B实验的指标
+// @Tags Conversations
+// @Accept json
+// @Produce json
+// @Param session_id path string true "会话ID"
+// @Param message_id path string true "消息ID"
+// @Param request body v1.FeedbackRequest true "反馈内容"
+// @Success 201 {object} httptransport.APIResponse{data=v1.FeedbackInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/conversations/{session_id}/messages/{message_id}/feedback [post]
+func (s *ConversationServiceV1) submitFeedback(c *gin.Context) {
+	var request v1.FeedbackRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	rating, _ := transcript.ParseRating(request.Rating)
+
+	f, err := s.service.SubmitFeedback(c.Request.Context(), transcript.FeedbackRequest{
+		SessionID: c.Param("session_id"),
+		EntryID:   c.Param("message_id"),
+		Rating:    rating,
+		Comment:   request.Comment,
+		Source:    transcript.FeedbackSourceAPI,
+	})
+	if err != nil {
+		s.handleError(c, err, "提交反馈失败")
+		return
+	}
+	httpUtils.Response.Created(c, toFeedbackInfo(f), "反馈已提交")
+}
+
+// listFeedback 获取反馈列表
+// @Summary 获取反馈列表
+// @Tags Conversations
+// @Produce json
+// @Param device_id query string false "设备ID"
+// @Param session_id query string false "会话ID"
+// @Param rating query string false "评价" Enums(up, down)
+// @Param source query string false "来源" Enums(api, device, voice)
+// @Param from query string false "开始时间 RFC3339"
+// @Param to query string false "结束时间 RFC3339"
+// @Param page query int false "页码" default(1)
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} httptransport.APIResponse{data=v1.FeedbackListResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/feedback [get]
+func (s *ConversationServiceV1) listFeedback(c *gin.Context) {
+	var query v1.FeedbackQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 20
+	}
+	filter, ok := s.feedbackFilter(c, query)
+	if !ok {
+		return
+	}
+	filter.Page = query.Page
+	filter.PageSize = query.Limit
+
+	items, total, err := s.service.ListFeedback(c.Request.Context(), filter)
+	if err != nil {
+		s.handleError(c, err, "获取反馈列表失败")
+		return
+	}
+
+	feedback := make([]v1.FeedbackInfo, 0, len(items))
+	for _, f := range items {
+		feedback = append(feedback, toFeedbackInfo(f))
+	}
+	totalPages := (total + int64(query.Limit) - 1) / int64(query.Limit)
+	httpUtils.Response.Success(c, v1.FeedbackListResponse{
+		Feedback: feedback,
+		Pagination: v1.Pagination{
+			Page:       int64(query.Page),
+			Limit:      int64(query.Limit),
+			Total:      total,
+			TotalPages: totalPages,
+			HasNext:    int64(query.Page) < totalPages,
+			HasPrev:    query.Page > 1,
+		},
+	}, "获取反馈列表成功")
+}
+
+// feedbackStats 获取反馈质量统计
+// @Summary 获取反馈质量统计
+// @Description 汇总点赞/点踩、满意度、来源分布和每日趋势，默认统计最近30天
+// @Tags Conversations
+// @Produce json
+// @Param device_id query string false "设备ID"
+// @Param source query string false "来源" Enums(api, device, voice)
+// @Param from query string false "开始时间 RFC3339"
+// @Param to query string false "结束时间 RFC3339"
+// @Success 200 {object} httptransport.APIResponse{data=v1.FeedbackStatsResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/feedback/stats [get]
+func (s *ConversationServiceV1) feedbackStats(c *gin.Context) {
+	var query v1.FeedbackQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	filter, ok := s.feedbackFilter(c, query)
+	if !ok {
+		return
+	}
+
+	stats, err := s.service.FeedbackStats(c.Request.Context(), filter)
+	if err != nil {
+		s.handleError(c, err, "获取反馈统计失败")
+		return
+	}
+
+	bySource := make(map[string]int64, len(stats.BySource))
+	for source, n := range stats.BySource {
+		bySource[string(source)] = n
+	}
+	daily := make([]v1.FeedbackDailyInfo, 0, len(stats.Daily))
+	for _, d := range stats.Daily {
+		daily = append(daily, v1.FeedbackDailyInfo{
+			Date:       d.Date,
+			ThumbsUp:   d.ThumbsUp,
+			ThumbsDown: d.ThumbsDown,
+		})
+	}
+	httpUtils.Response.Success(c, v1.FeedbackStatsResponse{
+		From:             stats.From,
+		To:               stats.To,
+		Total:            stats.Total,
+		ThumbsUp:         stats.ThumbsUp,
+		ThumbsDown:       stats.ThumbsDown,
+		WithComment:      stats.WithComment,
+		SatisfactionRate: stats.SatisfactionRate,
+		BySource:         bySource,
+		Daily:            daily,
+	}, "获取反馈统计成功")
+}
+
+// latencyStats 获取各阶段耗时百分位统计
+// @Summary 获取对话耗时统计
+// @Description 按阶段（采集、ASR、LLM首字、LLM完成、TTS首包、开始播放、端到端）汇总每轮对话耗时的平均值和 P50/P90/P95/P99，并给出每日端到端耗时趋势，默认统计最近30天
+// @Tags Conversations
+// @Produce json
+// @Param device_id query string false "设备ID"
+// @Param from query string false "开始时间 RFC3339"
+// @Param to query string false "结束时间 RFC3339"
+// @Success 200 {object} httptransport.APIResponse{data=v1.LatencyStatsResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/metrics/latency [get]
+func (s *ConversationServiceV1) latencyStats(c *gin.Context) {
+	var query v1.LatencyQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	filter := transcript.LatencyFilter{DeviceID: query.DeviceID}
+	var err error
+	if filter.From, err = parseQueryTime(query.From); err != nil {
+		httpUtils.Response.BadRequest(c, "from 必须是 RFC3339 时间")
+		return
+	}
+	if filter.To, err = parseQueryTime(query.To); err != nil {
+		httpUtils.Response.BadRequest(c, "to 必须是 RFC3339 时间")
+		return
+	}
+
+	stats, err := s.service.LatencyStats(c.Request.Context(), filter)
+	if err != nil {
+		s.handleError(c, err, "获取耗时统计失败")
+		return
+	}
+
+	stages := make([]v1.StageLatencyInfo, 0, len(stats.Stages))
+	for _, st := range stats.Stages {
+		stages = append(stages, v1.StageLatencyInfo{
+			Stage: string(st.Stage),
+			Count: st.Count,
+			AvgMs: st.AvgMs,
+			P50Ms: st.P50Ms,
+			P90Ms: st.P90Ms,
+			P95Ms: st.P95Ms,
+			P99Ms: st.P99Ms,
+			MaxMs: st.MaxMs,
+		})
+	}
+	daily := make([]v1.LatencyDailyInfo, 0, len(stats.Daily))
+	for _, d := range stats.Daily {
+		daily = append(daily, v1.LatencyDailyInfo{
+			Date:       d.Date,
+			Turns:      d.Turns,
+			P50TotalMs: d.P50TotalMs,
+			P95TotalMs: d.P95TotalMs,
+		})
+	}
+	httpUtils.Response.Success(c, v1.LatencyStatsResponse{
+		From:   stats.From,
+		To:     stats.To,
+		Turns:  stats.Turns,
+		Stages: stages,
+		Daily:  daily,
+	}, "获取耗时统计成功")
+}
+
+// feedbackFilter 将查询参数转换为反馈过滤条件，参数错误时已写入响应
+func (s *ConversationServiceV1) feedbackFilter(c *gin.Context, query v1.FeedbackQuery) (transcript.FeedbackFilter, bool) {
+	rating, _ := transcript.ParseRating(query.Rating)
+	filter := transcript.FeedbackFilter{
+		DeviceID:  query.DeviceID,
+		SessionID: query.SessionID,
+		Rating:    rating,
+		Source:    transcript.FeedbackSource(query.Source),
+	}
+	var err error
+	if filter.From, err = parseQueryTime(query.From); err != nil {
+		httpUtils.Response.BadRequest(c, "from 必须是 RFC3339 时间")
+		return filter, false
+	}
+	if filter.To, err = parseQueryTime(query.To); err != nil {
+		httpUtils.Response.BadRequest(c, "to 必须是 RFC3339 时间")
+		return filter, false
+	}
+	return filter, true
+}
+
+// handleError 将领域错误映射为API错误
+func (s *ConversationServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, transcript.ErrNotFound):
+		httpUtils.Response.NotFound(c, "会话")
+	case errors.Is(err, transcript.ErrMessageNotFound):
+		httpUtils.Response.NotFound(c, "消息")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toConversationInfo(conv *transcript.Conversation) v1.ConversationInfo {
+	return v1.ConversationInfo{
+		SessionID: conv.SessionID,
+		DeviceID:  conv.DeviceID,
+		Entries:   conv.Entries,
+		Rounds:    conv.Rounds,
+		StartedAt: conv.StartedAt,
+		LastAt:    conv.LastAt,
+	}
+}
+
+func toFeedbackInfo(f *transcript.Feedback) v1.FeedbackInfo {
+	return v1.FeedbackInfo{
+		ID:        f.ID,
+		SessionID: f.SessionID,
+		EntryID:   f.EntryID,
+		DeviceID:  f.DeviceID,
+		Round:     f.Round,
+		Rating:    f.Rating.String(),
+		Comment:   f.Comment,
+		Source:    string(f.Source),
+		CreatedAt: f.CreatedAt,
+	}
+}
+
+// parseQueryTime 解析可选的 RFC3339 查询参数
+func parseQueryTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
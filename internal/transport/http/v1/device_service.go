@@ -3,12 +3,17 @@ package v1
 import (
 	"xiaozhi-server-go/internal/platform/logging"
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"xiaozhi-server-go/internal/domain/device/aggregate"
 	"xiaozhi-server-go/internal/domain/device/repository"
+	"xiaozhi-server-go/internal/domain/eventbus"
+	"xiaozhi-server-go/internal/domain/media"
+	"xiaozhi-server-go/internal/domain/tenant"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
 	"xiaozhi-server-go/internal/platform/config"
 	"xiaozhi-server-go/internal/platform/storage"
 	"xiaozhi-server-go/internal/transport/http/types/v1"
@@ -28,10 +33,11 @@ type DeviceServiceV1 struct {
 	db                *gorm.DB
 	deviceRepo        repository.DeviceRepository
 	connManager       DeviceConnectionManager
+	tenants           *tenant.Service
 }
 
-// NewDeviceServiceV1 创建设备服务V1实例
-func NewDeviceServiceV1(config *config.Config, logger *logging.Logger, connManager DeviceConnectionManager) (*DeviceServiceV1, error) {
+// NewDeviceServiceV1 创建设备服务V1实例，tenants 为 nil 时不检查租户设备配额
+func NewDeviceServiceV1(config *config.Config, logger *logging.Logger, connManager DeviceConnectionManager, tenants *tenant.Service) (*DeviceServiceV1, error) {
 	if config == nil {
 		return nil, fmt.Errorf("config is required")
 	}
@@ -74,6 +80,7 @@ func NewDeviceServiceV1(config *config.Config, logger *logging.Logger, connManag
 		db:          db,
 		deviceRepo:  deviceRepo,
 		connManager: connManager,
+		tenants:     tenants,
 	}
 
 	logger.InfoTag("DeviceService", "设备服务初始化完成")
@@ -122,8 +129,8 @@ func (s *DeviceServiceV1) registerDevice(c *gin.Context) {
 		"request_id", getRequestID(c),
 	)
 
-	// 检查设备是否已存在
-	ctx := context.Background()
+	// 检查设备是否已存在（设备ID全局唯一，不区分租户）
+	ctx := c.Request.Context()
 	existingDevice, err := s.deviceRepo.FindByDeviceID(ctx, request.DeviceID)
 	if err != nil {
 		s.logger.ErrorTag("API", "检查设备是否存在失败", "error", err, "device_id", request.DeviceID, "request_id", getRequestID(c))
@@ -135,9 +142,19 @@ func (s *DeviceServiceV1) registerDevice(c *gin.Context) {
 		return
 	}
 
+	// 设备归属于请求所在的租户
+	tenantID := tenant.IDFrom(ctx)
+	if s.tenants != nil {
+		if err := s.tenants.CheckDeviceQuota(ctx, tenantID); err != nil {
+			s.respondTenantError(c, err)
+			return
+		}
+	}
+
 	// 创建设备聚合根
 	now := time.Now()
 	newDevice := &aggregate.Device{
+		TenantID:       tenantID,
 		DeviceID:       request.DeviceID,
 		ClientID:       fmt.Sprintf("client_%s", request.DeviceID),
 		Name:           request.DeviceName,
@@ -195,7 +212,7 @@ func (s *DeviceServiceV1) listDevices(c *gin.Context) {
 
 	// 从数据库获取设备列表
 	s.logger.InfoTag("API", "开始从数据库获取设备列表", "request_id", getRequestID(c))
-	devices, total, err := s.getDeviceListFromDB(query)
+	devices, total, err := s.getDeviceListFromDB(c.Request.Context(), query)
 	if err != nil {
 		s.logger.ErrorTag("API", "获取设备列表失败",
 			"error", err,
@@ -226,7 +243,7 @@ func (s *DeviceServiceV1) listDevices(c *gin.Context) {
 
 // getDevice 获取设备详情
 // @Summary 获取设备详情
-// @Description 根据ID获取设备的详细信息
+// @Description 根据ID获取设备的详细信息，设备正在播放或暂停中的媒体在 media 字段返回
 // @Tags Devices
 // @Produce json
 // @Param id path string true "设备ID"
@@ -246,7 +263,7 @@ func (s *DeviceServiceV1) getDevice(c *gin.Context) {
 	)
 
 	// 从数据库获取设备详情
-	device, err := s.getDeviceFromDB(deviceID)
+	device, err := s.getDeviceFromDB(c.Request.Context(), deviceID)
 	if err != nil {
 		s.logger.ErrorTag("API", "获取设备详情失败", "error", err, "device_id", deviceID, "request_id", getRequestID(c))
 		httpUtils.Response.Error(c, httpUtils.ErrorCodeInternalServer, "获取设备详情失败")
@@ -256,6 +273,13 @@ func (s *DeviceServiceV1) getDevice(c *gin.Context) {
 		httpUtils.Response.NotFound(c, "设备")
 		return
 	}
+	// 附带设备正在播放或暂停中的媒体
+	if svc := media.Default(); svc != nil {
+		if state := svc.State(deviceID); state.Active() {
+			mediaState := toMediaState(state)
+			device.Media = &mediaState
+		}
+	}
 
 	httpUtils.Response.Success(c, device, "获取设备详情成功")
 }
@@ -292,8 +316,8 @@ func (s *DeviceServiceV1) updateDevice(c *gin.Context) {
 	)
 
 	// 从数据库获取设备
-	ctx := context.Background()
-	device, err := s.deviceRepo.FindByDeviceID(ctx, deviceID)
+	ctx := c.Request.Context()
+	device, err := s.findDevice(ctx, deviceID)
 	if err != nil {
 		s.logger.ErrorTag("API", "获取设备失败", "error", err, "device_id", deviceID, "request_id", getRequestID(c))
 		httpUtils.Response.Error(c, httpUtils.ErrorCodeInternalServer, "获取设备失败")
@@ -306,6 +330,21 @@ func (s *DeviceServiceV1) updateDevice(c *gin.Context) {
 
 	// 更新字段
 	updated := false
+	if request.TenantID != "" && request.TenantID != device.TenantID {
+		// 只有管理员可以把设备转移到其他租户
+		if _, scoped := tenant.FromContext(ctx); scoped {
+			httpUtils.Response.Forbidden(c, "租户不能转移设备")
+			return
+		}
+		if s.tenants != nil {
+			if err := s.tenants.CheckDeviceQuota(ctx, request.TenantID); err != nil {
+				s.respondTenantError(c, err)
+				return
+			}
+		}
+		device.TenantID = request.TenantID
+		updated = true
+	}
 	if request.DeviceName != "" {
 		device.Name = request.DeviceName
 		updated = true
@@ -317,14 +356,6 @@ func (s *DeviceServiceV1) updateDevice(c *gin.Context) {
 			device.AuthStatus = aggregate.DeviceStatusApproved
 		} else {
 			device.AuthStatus = aggregate.DeviceStatusRejected
-			// 如果禁用设备，强制断开连接
-			if s.connManager != nil {
-				if err := s.connManager.CloseDeviceConnection(deviceID); err != nil {
-					s.logger.WarnTag("API", "断开设备连接失败: %v", err)
-				} else {
-					s.logger.InfoTag("API", "已强制断开设备连接", "device_id", deviceID)
-				}
-			}
 		}
 		updated = true
 	}
@@ -338,6 +369,9 @@ func (s *DeviceServiceV1) updateDevice(c *gin.Context) {
 			return
 		}
 	}
+	if request.IsActive != nil {
+		s.applyAuthStatus(deviceID, device.AuthStatus)
+	}
 
 	httpUtils.Response.Success(c, s.convertAggregateToAPI(device), "设备信息更新成功")
 }
@@ -364,7 +398,7 @@ func (s *DeviceServiceV1) deleteDevice(c *gin.Context) {
 	)
 
 	// 检查设备是否存在
-	device, err := s.getDeviceFromDB(deviceID)
+	device, err := s.getDeviceFromDB(c.Request.Context(), deviceID)
 	if err != nil {
 		s.logger.ErrorTag("API", "获取设备失败", "error", err, "device_id", deviceID, "request_id", getRequestID(c))
 		httpUtils.Response.Error(c, httpUtils.ErrorCodeInternalServer, "获取设备失败")
@@ -382,7 +416,7 @@ func (s *DeviceServiceV1) deleteDevice(c *gin.Context) {
 	}
 
 	// 从数据库删除设备
-	ctx := context.Background()
+	ctx := c.Request.Context()
 	if err := s.deviceRepo.Delete(ctx, deviceID); err != nil {
 		s.logger.ErrorTag("API", "删除设备失败", "error", err, "device_id", deviceID, "request_id", getRequestID(c))
 		httpUtils.Response.Error(c, httpUtils.ErrorCodeInternalServer, "删除设备失败")
@@ -397,7 +431,7 @@ func (s *DeviceServiceV1) deleteDevice(c *gin.Context) {
 
 // activateDevice 激活设备
 // @Summary 激活设备
-// @Description 激活已注册的设备
+// @Description 用 ACT_<设备ID> 形式的手动激活码激活已注册的设备，已被扫码配对（/v1/provisioning/claim）取代，可通过 Provisioning.LegacyActivationCode 关闭
 // @Tags Devices
 // @Accept json
 // @Produce json
@@ -427,8 +461,8 @@ func (s *DeviceServiceV1) activateDevice(c *gin.Context) {
 	)
 
 	// 从数据库获取设备
-	ctx := context.Background()
-	device, err := s.deviceRepo.FindByDeviceID(ctx, deviceID)
+	ctx := c.Request.Context()
+	device, err := s.findDevice(ctx, deviceID)
 	if err != nil {
 		s.logger.ErrorTag("API", "获取设备失败", "error", err, "device_id", deviceID, "request_id", getRequestID(c))
 		httpUtils.Response.Error(c, httpUtils.ErrorCodeInternalServer, "获取设备失败")
@@ -445,6 +479,12 @@ func (s *DeviceServiceV1) activateDevice(c *gin.Context) {
 		return
 	}
 
+	// 手动激活码已由扫码配对取代，关闭 Provisioning.LegacyActivationCode 后不再接受
+	if !s.config.Provisioning.LegacyActivationCode {
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeActivationFailed, "手动激活码已停用，请使用扫码配对")
+		return
+	}
+
 	// 验证激活码
 	if !s.validateActivationCode(request.ActivationCode, deviceID) {
 		httpUtils.Response.Error(c, httpUtils.ErrorCodeInvalidActivationCode, "无效的激活码")
@@ -462,6 +502,7 @@ func (s *DeviceServiceV1) activateDevice(c *gin.Context) {
 	device.AuthStatus = aggregate.DeviceStatusApproved
 	device.Online = true
 	device.LastActiveTime = time.Now()
+	s.applyAuthStatus(deviceID, device.AuthStatus)
 
 	// 生成设备令牌
 	deviceToken := fmt.Sprintf("device_token_%d", time.Now().UnixNano())
@@ -508,8 +549,8 @@ func (s *DeviceServiceV1) updateDeviceStatus(c *gin.Context) {
 	)
 
 	// 从数据库获取设备
-	ctx := context.Background()
-	device, err := s.deviceRepo.FindByDeviceID(ctx, request.DeviceID)
+	ctx := c.Request.Context()
+	device, err := s.findDevice(ctx, request.DeviceID)
 	if err != nil {
 		s.logger.ErrorTag("API", "获取设备失败", "error", err, "device_id", request.DeviceID, "request_id", getRequestID(c))
 		httpUtils.Response.Error(c, httpUtils.ErrorCodeInternalServer, "获取设备失败")
@@ -537,16 +578,9 @@ func (s *DeviceServiceV1) updateDeviceStatus(c *gin.Context) {
 	} else {
 		device.AuthStatus = aggregate.DeviceStatusRejected
 		device.Online = false
-		// 如果禁用设备，强制断开连接
-		if s.connManager != nil {
-			if err := s.connManager.CloseDeviceConnection(request.DeviceID); err != nil {
-				s.logger.WarnTag("API", "断开设备连接失败: %v", err)
-			} else {
-				s.logger.InfoTag("API", "已强制断开设备连接", "device_id", request.DeviceID)
-			}
-		}
 	}
 	device.LastActiveTime = time.Now()
+	s.applyAuthStatus(request.DeviceID, device.AuthStatus)
 
 	// 构建响应消息
 	var message string
@@ -573,6 +607,23 @@ func (s *DeviceServiceV1) updateDeviceStatus(c *gin.Context) {
 	httpUtils.Response.Success(c, response, message)
 }
 
+// applyAuthStatus 通知传输层设备认证状态已变化，使消息访问控制对已建立的连接立即生效；已拒绝的设备被强制断开连接
+func (s *DeviceServiceV1) applyAuthStatus(deviceID string, status aggregate.DeviceStatus) {
+	eventbus.Publish(eventbus.EventDeviceAuthChanged, eventbus.DeviceAuthEventData{
+		DeviceID:  deviceID,
+		Status:    string(status),
+		Timestamp: time.Now(),
+	})
+	if status != aggregate.DeviceStatusRejected || s.connManager == nil {
+		return
+	}
+	if err := s.connManager.CloseDeviceConnection(deviceID); err != nil {
+		s.logger.WarnTag("API", "断开设备连接失败: %v", err)
+	} else {
+		s.logger.InfoTag("API", "已强制断开设备连接", "device_id", deviceID)
+	}
+}
+
 
 
 
@@ -596,6 +647,7 @@ func (s *DeviceServiceV1) convertStorageToAPI(device *storage.Device) *v1.Device
 
 	deviceInfo := &v1.DeviceInfo{
 		ID:            int64(device.ID),
+		TenantID:      device.TenantID,
 		DeviceID:      device.DeviceID,
 		DeviceName:    device.Name,
 		DeviceType:    device.BoardType, // 使用BoardType作为设备类型
@@ -648,6 +700,7 @@ func (s *DeviceServiceV1) convertAggregateToAPI(device *aggregate.Device) *v1.De
 
 	deviceInfo := &v1.DeviceInfo{
 		ID:            int64(device.ID),
+		TenantID:      device.TenantID,
 		DeviceID:      device.DeviceID,
 		DeviceName:    device.Name,
 		DeviceType:    device.BoardType,
@@ -689,7 +742,7 @@ func (s *DeviceServiceV1) validateActivationCode(code, deviceID string) bool {
 // ========== 数据库查询方法 ==========
 
 // getDeviceListFromDB 从数据库获取设备列表
-func (s *DeviceServiceV1) getDeviceListFromDB(query v1.DeviceQuery) ([]v1.DeviceInfo, int64, error) {
+func (s *DeviceServiceV1) getDeviceListFromDB(ctx context.Context, query v1.DeviceQuery) ([]v1.DeviceInfo, int64, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, 0, fmt.Errorf("database connection is nil")
@@ -703,11 +756,16 @@ func (s *DeviceServiceV1) getDeviceListFromDB(query v1.DeviceQuery) ([]v1.Device
 		"limit", query.Limit)
 
 	// 构建查询
-	db := s.db.Model(&storage.Device{})
+	db := s.db.WithContext(ctx).Model(&storage.Device{})
 	if db == nil {
 		return nil, 0, fmt.Errorf("failed to create database model")
 	}
 
+	// 租户请求只能看到本租户的设备
+	if tenantID, ok := tenant.FromContext(ctx); ok {
+		db = db.Where("tenant_id = ?", tenantID)
+	}
+
 	// 添加过滤条件
 	if query.Status != "" {
 		db = db.Where("auth_status = ?", query.Status)
@@ -779,11 +837,9 @@ func (s *DeviceServiceV1) getDeviceListFromDB(query v1.DeviceQuery) ([]v1.Device
 }
 
 // getDeviceFromDB 从数据库获取单个设备
-func (s *DeviceServiceV1) getDeviceFromDB(deviceID string) (*v1.DeviceInfo, error) {
-	ctx := context.Background()
-
+func (s *DeviceServiceV1) getDeviceFromDB(ctx context.Context, deviceID string) (*v1.DeviceInfo, error) {
 	// 从领域层获取设备
-	deviceAggregate, err := s.deviceRepo.FindByDeviceID(ctx, deviceID)
+	deviceAggregate, err := s.findDevice(ctx, deviceID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find device: %w", err)
 	}
@@ -796,5 +852,27 @@ func (s *DeviceServiceV1) getDeviceFromDB(deviceID string) (*v1.DeviceInfo, erro
 	return deviceInfo, nil
 }
 
+// findDevice 查找请求可见的设备，租户请求查不到其他租户的设备
+func (s *DeviceServiceV1) findDevice(ctx context.Context, deviceID string) (*aggregate.Device, error) {
+	device, err := s.deviceRepo.FindByDeviceID(ctx, deviceID)
+	if err != nil || device == nil {
+		return device, err
+	}
+	if tenantID, ok := tenant.FromContext(ctx); ok && device.TenantID != tenantID {
+		return nil, nil
+	}
+	return device, nil
+}
 
-
+// respondTenantError 返回租户配额检查的错误
+func (s *DeviceServiceV1) respondTenantError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, tenant.ErrNotFound):
+		httpUtils.Response.NotFound(c, "租户")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.Forbidden(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", "检查租户设备配额失败", "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeInternalServer, "检查租户设备配额失败")
+	}
+}
@@ -0,0 +1,267 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/devicedebug"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// DeviceDebugServiceV1 V1版本远程设备调试服务，仅管理员可访问
+type DeviceDebugServiceV1 struct {
+	logger  *logging.Logger
+	service *devicedebug.Service
+}
+
+// NewDeviceDebugServiceV1 创建远程设备调试服务V1实例
+func NewDeviceDebugServiceV1(logger *logging.Logger, service *devicedebug.Service) (*DeviceDebugServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("device debug service is required")
+	}
+	return &DeviceDebugServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册远程设备调试API路由
+func (s *DeviceDebugServiceV1) Register(router *gin.RouterGroup) {
+	router.GET("/devices/:id/debug/logs", s.logs)                     // 读取设备日志
+	router.GET("/devices/:id/debug/commands", s.commands)             // 查询固件定义的诊断命令
+	router.POST("/devices/:id/debug/commands/:command", s.runCommand) // 执行诊断命令
+	router.PUT("/devices/:id/debug/config", s.overrideConfig)         // 下发临时配置
+
+	router.GET("/device-debug/audit", s.listAudit) // 获取远程调试审计记录
+}
+
+// logs 读取设备日志
+// @Summary 读取设备日志
+// @Description 经设备连接读取设备最近的日志；设备需在线、在 hello 中声明 debug 特性并通过 remote_debug 功能开关允许，操作记入审计日志
+// @Tags DeviceDebug
+// @Produce json
+// @Param id path string true "设备ID"
+// @Param lines query int false "读取的行数，缺省或超过上限时使用 DeviceDebug.MaxLogLines"
+// @Param level query string false "最低日志级别" Enums(debug,info,warn,error)
+// @Success 200 {object} httptransport.APIResponse{data=v1.DeviceDebugResult}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 409 {object} httptransport.APIResponse
+// @Router /v1/devices/{id}/debug/logs [get]
+func (s *DeviceDebugServiceV1) logs(c *gin.Context) {
+	var query v1.DeviceDebugLogsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	s.execute(c, devicedebug.Request{
+		Action: devicedebug.ActionLogs,
+		Lines:  query.Lines,
+		Level:  query.Level,
+	}, "读取设备日志成功")
+}
+
+// commands 查询固件定义的诊断命令
+// @Summary 查询设备诊断命令
+// @Description 返回固件定义的诊断命令及其参数说明，格式由固件决定；操作记入审计日志
+// @Tags DeviceDebug
+// @Produce json
+// @Param id path string true "设备ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.DeviceDebugResult}
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 409 {object} httptransport.APIResponse
+// @Router /v1/devices/{id}/debug/commands [get]
+func (s *DeviceDebugServiceV1) commands(c *gin.Context) {
+	s.execute(c, devicedebug.Request{Action: devicedebug.ActionCommands}, "查询诊断命令成功")
+}
+
+// runCommand 执行诊断命令
+// @Summary 执行设备诊断命令
+// @Description 在设备上执行固件定义的诊断命令并返回结果；操作记入审计日志
+// @Tags DeviceDebug
+// @Accept json
+// @Produce json
+// @Param id path string true "设备ID"
+// @Param command path string true "命令名称"
+// @Param request body v1.DeviceDebugRunRequest false "命令参数"
+// @Success 200 {object} httptransport.APIResponse{data=v1.DeviceDebugResult}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 409 {object} httptransport.APIResponse
+// @Router /v1/devices/{id}/debug/commands/{command} [post]
+func (s *DeviceDebugServiceV1) runCommand(c *gin.Context) {
+	var request v1.DeviceDebugRunRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			httpUtils.Response.ValidationError(c, err)
+			return
+		}
+	}
+	s.execute(c, devicedebug.Request{
+		Action:  devicedebug.ActionRun,
+		Command: c.Param("command"),
+		Args:    request.Args,
+	}, "诊断命令执行成功")
+}
+
+// overrideConfig 下发临时配置
+// @Summary 下发设备临时配置
+// @Description 临时覆盖设备配置，到期或设备重启后由设备恢复原配置；操作记入审计日志
+// @Tags DeviceDebug
+// @Accept json
+// @Produce json
+// @Param id path string true "设备ID"
+// @Param request body v1.DeviceDebugConfigRequest true "覆盖的配置项和有效期"
+// @Success 200 {object} httptransport.APIResponse{data=v1.DeviceDebugResult}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 409 {object} httptransport.APIResponse
+// @Router /v1/devices/{id}/debug/config [put]
+func (s *DeviceDebugServiceV1) overrideConfig(c *gin.Context) {
+	var request v1.DeviceDebugConfigRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	s.execute(c, devicedebug.Request{
+		Action:    devicedebug.ActionConfig,
+		Overrides: request.Overrides,
+		TTL:       time.Duration(request.TTLSeconds) * time.Second,
+	}, "临时配置已下发")
+}
+
+// execute 在设备上执行调试操作并返回结果
+func (s *DeviceDebugServiceV1) execute(c *gin.Context, request devicedebug.Request, message string) {
+	deviceID := c.Param("id")
+	result, err := s.service.Execute(c.Request.Context(), deviceID, request, devicedebug.Origin{
+		RequestID: getRequestID(c),
+		ClientIP:  c.ClientIP(),
+	})
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+	s.logger.InfoTag("API", "远程调试", "device_id", deviceID, "action", string(request.Action), "audit_id", result.AuditID, "request_id", getRequestID(c))
+	info := v1.DeviceDebugResult{
+		AuditID:    result.AuditID,
+		SessionID:  result.SessionID,
+		DurationMs: result.DurationMs,
+	}
+	if len(result.Data) > 0 {
+		info.Result = result.Data
+	}
+	httpUtils.Response.Success(c, info, message)
+}
+
+// listAudit 获取远程调试审计记录
+// @Summary 获取远程调试审计记录
+// @Description 按设备、操作和时间范围过滤远程调试操作，包括失败和被拒绝的操作，新记录在前
+// @Tags DeviceDebug
+// @Produce json
+// @Param device_id query string false "设备ID"
+// @Param action query string false "操作" Enums(logs,commands,run,config)
+// @Param from query string false "开始时间 RFC3339"
+// @Param to query string false "结束时间 RFC3339"
+// @Param page query int false "页码" default(1)
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} httptransport.APIResponse{data=v1.DeviceDebugAuditListResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Router /v1/device-debug/audit [get]
+func (s *DeviceDebugServiceV1) listAudit(c *gin.Context) {
+	var query v1.DeviceDebugAuditQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 20
+	}
+
+	filter := devicedebug.AuditFilter{
+		DeviceID: query.DeviceID,
+		Action:   devicedebug.Action(query.Action),
+		Page:     query.Page,
+		PageSize: query.Limit,
+	}
+	var err error
+	if filter.From, err = parseQueryTime(query.From); err != nil {
+		httpUtils.Response.BadRequest(c, "from 必须是 RFC3339 时间")
+		return
+	}
+	if filter.To, err = parseQueryTime(query.To); err != nil {
+		httpUtils.Response.BadRequest(c, "to 必须是 RFC3339 时间")
+		return
+	}
+
+	items, total, err := s.service.ListAudit(c.Request.Context(), filter)
+	if err != nil {
+		s.logger.ErrorTag("API", "获取远程调试审计记录失败", "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, "获取远程调试审计记录失败")
+		return
+	}
+
+	records := make([]v1.DeviceDebugAuditInfo, 0, len(items))
+	for _, item := range items {
+		records = append(records, v1.DeviceDebugAuditInfo{
+			ID:         item.ID,
+			DeviceID:   item.DeviceID,
+			SessionID:  item.SessionID,
+			Action:     string(item.Action),
+			Command:    item.Command,
+			Params:     item.Params,
+			Success:    item.Success,
+			Error:      item.Error,
+			DurationMs: item.DurationMs,
+			RequestID:  item.RequestID,
+			ClientIP:   item.ClientIP,
+			CreatedAt:  item.CreatedAt,
+		})
+	}
+	totalPages := (total + int64(query.Limit) - 1) / int64(query.Limit)
+	httpUtils.Response.Success(c, v1.DeviceDebugAuditListResponse{
+		Records: records,
+		Pagination: v1.Pagination{
+			Page:       int64(query.Page),
+			Limit:      int64(query.Limit),
+			Total:      total,
+			TotalPages: totalPages,
+			HasNext:    int64(query.Page) < totalPages,
+			HasPrev:    query.Page > 1,
+		},
+	}, "获取远程调试审计记录成功")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *DeviceDebugServiceV1) handleError(c *gin.Context, err error) {
+	var deviceErr *devicedebug.DeviceError
+	switch {
+	case errors.Is(err, devicedebug.ErrDeviceOffline):
+		httpUtils.Response.Conflict(c, "设备不在线")
+	case errors.Is(err, devicedebug.ErrNotPermitted):
+		httpUtils.Response.Forbidden(c, "设备未开启远程调试（remote_debug 功能开关）")
+	case errors.Is(err, devicedebug.ErrUnsupported):
+		httpUtils.Response.Conflict(c, "设备固件不支持远程调试")
+	case errors.Is(err, devicedebug.ErrTimeout):
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeTimeout, "等待设备回复超时")
+	case errors.As(err, &deviceErr):
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeExecutionFailed, "设备执行失败: "+deviceErr.Message)
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", "远程调试失败", "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, "远程调试失败")
+	}
+}
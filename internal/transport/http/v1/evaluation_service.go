@@ -0,0 +1,398 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/evaluation"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// EvaluationServiceV1 V1版本批量评测服务
+type EvaluationServiceV1 struct {
+	logger  *logging.Logger
+	service *evaluation.Service
+}
+
+// NewEvaluationServiceV1 创建评测服务V1实例
+func NewEvaluationServiceV1(logger *logging.Logger, service *evaluation.Service) (*EvaluationServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("evaluation service is required")
+	}
+	return &EvaluationServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册评测API路由
+func (s *EvaluationServiceV1) Register(router *gin.RouterGroup) {
+	evaluations := router.Group("/evaluations")
+	{
+		evaluations.POST("/suites", s.createSuite)       // 上传测试集
+		evaluations.GET("/suites", s.listSuites)         // 获取测试集列表
+		evaluations.GET("/suites/:id", s.getSuite)       // 获取测试集详情
+		evaluations.DELETE("/suites/:id", s.deleteSuite) // 删除测试集
+		evaluations.POST("/runs", s.startRun)            // 发起评测
+		evaluations.GET("/runs", s.listRuns)             // 获取评测运行列表
+		evaluations.GET("/runs/:id", s.getRun)           // 获取评测报告
+		evaluations.GET("/compare", s.compareRuns)       // 对比多次评测
+	}
+}
+
+// createSuite 上传测试集
+// @Summary 上传评测测试集
+// @Description llm 用例需要 input，可选 expected；asr 用例需要 base64 音频和参考文本；tts 用例需要 input
+// @Tags Evaluations
+// @Accept json
+// @Produce json
+// @Param request body v1.EvalSuiteCreateRequest true "测试集"
+// @Success 201 {object} httptransport.APIResponse{data=v1.EvalSuiteInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/evaluations/suites [post]
+func (s *EvaluationServiceV1) createSuite(c *gin.Context) {
+	var request v1.EvalSuiteCreateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	cases := make([]evaluation.Case, 0, len(request.Cases))
+	for _, tc := range request.Cases {
+		cases = append(cases, evaluation.Case{
+			ID:       tc.ID,
+			Input:    tc.Input,
+			Audio:    tc.Audio,
+			Expected: tc.Expected,
+		})
+	}
+	suite, err := s.service.CreateSuite(c.Request.Context(), evaluation.CreateSuiteRequest{
+		Name:         request.Name,
+		Type:         evaluation.SuiteType(request.Type),
+		Description:  request.Description,
+		SystemPrompt: request.SystemPrompt,
+		Cases:        cases,
+	})
+	if err != nil {
+		s.handleError(c, err, "创建测试集失败")
+		return
+	}
+	httpUtils.Response.Created(c, toEvalSuiteInfo(suite, false), "测试集创建成功")
+}
+
+// listSuites 获取测试集列表
+// @Summary 获取评测测试集列表
+// @Tags Evaluations
+// @Produce json
+// @Param page query int false "页码" default(1)
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} httptransport.APIResponse{data=v1.EvalSuiteListResponse}
+// @Router /v1/evaluations/suites [get]
+func (s *EvaluationServiceV1) listSuites(c *gin.Context) {
+	var query v1.EvalSuiteQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 20
+	}
+
+	items, total, err := s.service.ListSuites(c.Request.Context(), query.Page, query.Limit)
+	if err != nil {
+		s.handleError(c, err, "获取测试集列表失败")
+		return
+	}
+	suites := make([]v1.EvalSuiteInfo, 0, len(items))
+	for _, suite := range items {
+		suites = append(suites, toEvalSuiteInfo(suite, false))
+	}
+	httpUtils.Response.Success(c, v1.EvalSuiteListResponse{
+		Suites:     suites,
+		Pagination: newPagination(query.Page, query.Limit, total),
+	}, "获取测试集列表成功")
+}
+
+// getSuite 获取测试集详情
+// @Summary 获取评测测试集详情
+// @Tags Evaluations
+// @Produce json
+// @Param id path string true "测试集ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.EvalSuiteInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/evaluations/suites/{id} [get]
+func (s *EvaluationServiceV1) getSuite(c *gin.Context) {
+	suite, err := s.service.GetSuite(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取测试集失败")
+		return
+	}
+	httpUtils.Response.Success(c, toEvalSuiteInfo(suite, true), "获取测试集成功")
+}
+
+// deleteSuite 删除测试集
+// @Summary 删除评测测试集
+// @Description 已有的评测报告保留
+// @Tags Evaluations
+// @Produce json
+// @Param id path string true "测试集ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/evaluations/suites/{id} [delete]
+func (s *EvaluationServiceV1) deleteSuite(c *gin.Context) {
+	if err := s.service.DeleteSuite(c.Request.Context(), c.Param("id")); err != nil {
+		s.handleError(c, err, "删除测试集失败")
+		return
+	}
+	httpUtils.Response.Success(c, nil, "测试集已删除")
+}
+
+// startRun 发起评测
+// @Summary 发起评测
+// @Description 在后台对所选能力执行测试集，可通过运行ID查询进度和报告
+// @Tags Evaluations
+// @Accept json
+// @Produce json
+// @Param request body v1.EvalRunRequest true "评测参数"
+// @Success 202 {object} httptransport.APIResponse{data=v1.EvalRunInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/evaluations/runs [post]
+func (s *EvaluationServiceV1) startRun(c *gin.Context) {
+	var request v1.EvalRunRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	req := evaluation.StartRunRequest{SuiteID: request.SuiteID}
+	for _, t := range request.Targets {
+		req.Targets = append(req.Targets, evaluation.Target{CapabilityID: t.CapabilityID, Config: t.Config})
+	}
+	if request.Judge != nil {
+		req.Judge = &evaluation.Target{CapabilityID: request.Judge.CapabilityID, Config: request.Judge.Config}
+	}
+
+	s.logger.InfoTag("API", "发起评测", "suite_id", request.SuiteID, "targets", len(req.Targets), "request_id", getRequestID(c))
+	run, err := s.service.StartRun(c.Request.Context(), req)
+	if err != nil {
+		s.handleError(c, err, "发起评测失败")
+		return
+	}
+	httpUtils.Response.Accepted(c, toEvalRunInfo(run), "评测已开始")
+}
+
+// listRuns 获取评测运行列表
+// @Summary 获取评测运行列表
+// @Tags Evaluations
+// @Produce json
+// @Param suite_id query string false "测试集ID"
+// @Param page query int false "页码" default(1)
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} httptransport.APIResponse{data=v1.EvalRunListResponse}
+// @Router /v1/evaluations/runs [get]
+func (s *EvaluationServiceV1) listRuns(c *gin.Context) {
+	var query v1.EvalRunQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 20
+	}
+
+	items, total, err := s.service.ListRuns(c.Request.Context(), evaluation.RunFilter{
+		SuiteID:  query.SuiteID,
+		Page:     query.Page,
+		PageSize: query.Limit,
+	})
+	if err != nil {
+		s.handleError(c, err, "获取评测列表失败")
+		return
+	}
+	runs := make([]v1.EvalRunInfo, 0, len(items))
+	for _, run := range items {
+		runs = append(runs, toEvalRunInfo(run))
+	}
+	httpUtils.Response.Success(c, v1.EvalRunListResponse{
+		Runs:       runs,
+		Pagination: newPagination(query.Page, query.Limit, total),
+	}, "获取评测列表成功")
+}
+
+// getRun 获取评测报告
+// @Summary 获取评测报告
+// @Description 返回各能力的汇总指标和用例明细
+// @Tags Evaluations
+// @Produce json
+// @Param id path string true "运行ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.EvalRunInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/evaluations/runs/{id} [get]
+func (s *EvaluationServiceV1) getRun(c *gin.Context) {
+	run, err := s.service.GetRun(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取评测报告失败")
+		return
+	}
+	httpUtils.Response.Success(c, toEvalRunInfo(run), "获取评测报告成功")
+}
+
+// compareRuns 对比多次评测
+// @Summary 对比评测报告
+// @Description 对比同一测试集的 2-5 次评测，返回各自的汇总指标和逐用例结果
+// @Tags Evaluations
+// @Produce json
+// @Param run_ids query string true "逗号分隔的运行ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.EvalCompareResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/evaluations/compare [get]
+func (s *EvaluationServiceV1) compareRuns(c *gin.Context) {
+	var runIDs []string
+	for _, id := range strings.Split(c.Query("run_ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			runIDs = append(runIDs, id)
+		}
+	}
+
+	comparison, err := s.service.Compare(c.Request.Context(), runIDs)
+	if err != nil {
+		s.handleError(c, err, "对比评测失败")
+		return
+	}
+
+	resp := v1.EvalCompareResponse{
+		SuiteID: comparison.SuiteID,
+		Runs:    make([]v1.EvalRunInfo, 0, len(comparison.Runs)),
+		Cases:   make([]v1.EvalCaseComparison, 0, len(comparison.Cases)),
+	}
+	for _, run := range comparison.Runs {
+		resp.Runs = append(resp.Runs, toEvalRunInfo(run))
+	}
+	for _, cc := range comparison.Cases {
+		results := make(map[string]v1.EvalCaseResult, len(cc.Results))
+		for key, r := range cc.Results {
+			results[key] = toEvalCaseResult(r)
+		}
+		resp.Cases = append(resp.Cases, v1.EvalCaseComparison{
+			CaseID:   cc.CaseID,
+			Input:    cc.Input,
+			Expected: cc.Expected,
+			Results:  results,
+		})
+	}
+	httpUtils.Response.Success(c, resp, "对比评测成功")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *EvaluationServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, evaluation.ErrSuiteNotFound):
+		httpUtils.Response.NotFound(c, "测试集")
+	case errors.Is(err, evaluation.ErrRunNotFound):
+		httpUtils.Response.NotFound(c, "评测")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func newPagination(page, limit int, total int64) v1.Pagination {
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+	return v1.Pagination{
+		Page:       int64(page),
+		Limit:      int64(limit),
+		Total:      total,
+		TotalPages: totalPages,
+		HasNext:    int64(page) < totalPages,
+		HasPrev:    page > 1,
+	}
+}
+
+func toEvalSuiteInfo(suite *evaluation.Suite, withCases bool) v1.EvalSuiteInfo {
+	info := v1.EvalSuiteInfo{
+		ID:           suite.ID,
+		Name:         suite.Name,
+		Type:         string(suite.Type),
+		Description:  suite.Description,
+		SystemPrompt: suite.SystemPrompt,
+		CaseCount:    len(suite.Cases),
+		CreatedAt:    suite.CreatedAt,
+	}
+	if withCases {
+		for _, tc := range suite.Cases {
+			info.Cases = append(info.Cases, v1.EvalCase{
+				ID:       tc.ID,
+				Input:    tc.Input,
+				Audio:    tc.Audio,
+				Expected: tc.Expected,
+			})
+		}
+	}
+	return info
+}
+
+func toEvalRunInfo(run *evaluation.Run) v1.EvalRunInfo {
+	info := v1.EvalRunInfo{
+		ID:         run.ID,
+		SuiteID:    run.SuiteID,
+		SuiteType:  string(run.SuiteType),
+		Status:     string(run.Status),
+		Error:      run.Error,
+		CreatedAt:  run.CreatedAt,
+		FinishedAt: run.FinishedAt,
+	}
+	for _, t := range run.Targets {
+		info.Targets = append(info.Targets, v1.EvalTarget{CapabilityID: t.CapabilityID, Config: t.Config})
+	}
+	if run.Judge != nil {
+		info.Judge = &v1.EvalTarget{CapabilityID: run.Judge.CapabilityID, Config: run.Judge.Config}
+	}
+	for _, r := range run.Reports {
+		report := v1.EvalTargetReport{
+			CapabilityID:  r.CapabilityID,
+			Total:         r.Total,
+			Succeeded:     r.Succeeded,
+			AvgSimilarity: r.AvgSimilarity,
+			AvgJudgeScore: r.AvgJudgeScore,
+			AvgWER:        r.AvgWER,
+			AvgLatencyMs:  r.AvgLatencyMs,
+			P95LatencyMs:  r.P95LatencyMs,
+		}
+		for _, cr := range r.Cases {
+			report.Cases = append(report.Cases, toEvalCaseResult(cr))
+		}
+		info.Reports = append(info.Reports, report)
+	}
+	return info
+}
+
+func toEvalCaseResult(r evaluation.CaseResult) v1.EvalCaseResult {
+	return v1.EvalCaseResult{
+		CaseID:     r.CaseID,
+		Output:     r.Output,
+		Similarity: r.Similarity,
+		JudgeScore: r.JudgeScore,
+		WER:        r.WER,
+		LatencyMs:  r.LatencyMs,
+		Error:      r.Error,
+	}
+}
@@ -0,0 +1,292 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/experiment"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// ExperimentServiceV1 V1版本A/B实验服务
+type ExperimentServiceV1 struct {
+	logger  *logging.Logger
+	service *experiment.Service
+}
+
+// NewExperimentServiceV1 创建实验服务V1实例
+func NewExperimentServiceV1(logger *logging.Logger, service *experiment.Service) (*ExperimentServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("experiment service is required")
+	}
+	return &ExperimentServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册实验API路由
+func (s *ExperimentServiceV1) Register(router *gin.RouterGroup) {
+	experiments := router.Group("/experiments")
+	{
+		experiments.POST("", s.createExperiment)          // 创建实验
+		experiments.GET("", s.listExperiments)            // 获取实验列表
+		experiments.GET("/:id", s.getExperiment)          // 获取实验详情
+		experiments.DELETE("/:id", s.deleteExperiment)    // 删除实验
+		experiments.POST("/:id/start", s.startExperiment) // 开始分流
+		experiments.POST("/:id/stop", s.stopExperiment)   // 停止分流
+		experiments.GET("/:id/results", s.getResults)     // 获取实验结果
+	}
+}
+
+// createExperiment 创建实验
+// @Summary 创建A/B实验
+// @Description 创建草稿状态的实验，每个分组可覆盖提示词模板和LLM
+// @Tags Experiments
+// @Accept json
+// @Produce json
+// @Param request body v1.ExperimentCreateRequest true "实验信息"
+// @Success 201 {object} httptransport.APIResponse{data=v1.ExperimentInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/experiments [post]
+func (s *ExperimentServiceV1) createExperiment(c *gin.Context) {
+	var request v1.ExperimentCreateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	s.logger.InfoTag("API", "创建实验", "name", request.Name, "request_id", getRequestID(c))
+
+	variants := make([]experiment.Variant, 0, len(request.Variants))
+	for _, v := range request.Variants {
+		variants = append(variants, experiment.Variant{
+			Name:           v.Name,
+			Weight:         v.Weight,
+			PromptTemplate: v.PromptTemplate,
+			PromptVersion:  v.PromptVersion,
+			LLM:            v.LLM,
+			CostPer1KToken: v.CostPer1KToken,
+		})
+	}
+	e, err := s.service.Create(c.Request.Context(), experiment.CreateRequest{
+		Name:           request.Name,
+		Description:    request.Description,
+		TrafficPercent: request.TrafficPercent,
+		Cohort:         request.Cohort,
+		Variants:       variants,
+	})
+	if err != nil {
+		s.handleError(c, err, "创建实验失败")
+		return
+	}
+	httpUtils.Response.Created(c, toExperimentInfo(e), "实验创建成功")
+}
+
+// listExperiments 获取实验列表
+// @Summary 获取A/B实验列表
+// @Tags Experiments
+// @Produce json
+// @Param status query string false "状态" Enums(draft, running, stopped)
+// @Param page query int false "页码" default(1)
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} httptransport.APIResponse{data=v1.ExperimentListResponse}
+// @Router /v1/experiments [get]
+func (s *ExperimentServiceV1) listExperiments(c *gin.Context) {
+	var query v1.ExperimentQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 20
+	}
+
+	items, total, err := s.service.List(c.Request.Context(), experiment.Filter{
+		Status:   experiment.Status(query.Status),
+		Page:     query.Page,
+		PageSize: query.Limit,
+	})
+	if err != nil {
+		s.handleError(c, err, "获取实验列表失败")
+		return
+	}
+
+	experiments := make([]v1.ExperimentInfo, 0, len(items))
+	for _, e := range items {
+		experiments = append(experiments, toExperimentInfo(e))
+	}
+	totalPages := (total + int64(query.Limit) - 1) / int64(query.Limit)
+	httpUtils.Response.Success(c, v1.ExperimentListResponse{
+		Experiments: experiments,
+		Pagination: v1.Pagination{
+			Page:       int64(query.Page),
+			Limit:      int64(query.Limit),
+			Total:      total,
+			TotalPages: totalPages,
+			HasNext:    int64(query.Page) < totalPages,
+			HasPrev:    query.Page > 1,
+		},
+	}, "获取实验列表成功")
+}
+
+// getExperiment 获取实验详情
+// @Summary 获取A/B实验详情
+// @Tags Experiments
+// @Produce json
+// @Param id path string true "实验ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ExperimentInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/experiments/{id} [get]
+func (s *ExperimentServiceV1) getExperiment(c *gin.Context) {
+	e, err := s.service.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取实验失败")
+		return
+	}
+	httpUtils.Response.Success(c, toExperimentInfo(e), "获取实验成功")
+}
+
+// deleteExperiment 删除实验
+// @Summary 删除A/B实验
+// @Description 删除实验及其结果，运行中的实验需先停止
+// @Tags Experiments
+// @Produce json
+// @Param id path string true "实验ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/experiments/{id} [delete]
+func (s *ExperimentServiceV1) deleteExperiment(c *gin.Context) {
+	if err := s.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		s.handleError(c, err, "删除实验失败")
+		return
+	}
+	httpUtils.Response.Success(c, nil, "实验已删除")
+}
+
+// startExperiment 开始分流
+// @Summary 开始A/B实验
+// @Tags Experiments
+// @Produce json
+// @Param id path string true "实验ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ExperimentInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/experiments/{id}/start [post]
+func (s *ExperimentServiceV1) startExperiment(c *gin.Context) {
+	e, err := s.service.Start(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "开始实验失败")
+		return
+	}
+	httpUtils.Response.Success(c, toExperimentInfo(e), "实验已开始")
+}
+
+// stopExperiment 停止分流
+// @Summary 停止A/B实验
+// @Tags Experiments
+// @Produce json
+// @Param id path string true "实验ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ExperimentInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/experiments/{id}/stop [post]
+func (s *ExperimentServiceV1) stopExperiment(c *gin.Context) {
+	e, err := s.service.Stop(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "停止实验失败")
+		return
+	}
+	httpUtils.Response.Success(c, toExperimentInfo(e), "实验已停止")
+}
+
+// getResults 获取实验结果
+// @Summary 获取A/B实验结果
+// @Description 按分组汇总延迟、token消耗、打断率和用户反馈
+// @Tags Experiments
+// @Produce json
+// @Param id path string true "实验ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ExperimentResultsResponse}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/experiments/{id}/results [get]
+func (s *ExperimentServiceV1) getResults(c *gin.Context) {
+	results, err := s.service.Results(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取实验结果失败")
+		return
+	}
+
+	variants := make([]v1.ExperimentVariantResult, 0, len(results.Variants))
+	for _, r := range results.Variants {
+		variants = append(variants, v1.ExperimentVariantResult{
+			Variant:          r.Variant,
+			Samples:          r.Samples,
+			AvgLatencyMs:     r.AvgLatencyMs,
+			PromptTokens:     r.PromptTokens,
+			CompletionTokens: r.CompletionTokens,
+			AvgTokens:        r.AvgTokens,
+			EstimatedCost:    r.EstimatedCost,
+			InterruptionRate: r.InterruptionRate,
+			ThumbsUp:         r.ThumbsUp,
+			ThumbsDown:       r.ThumbsDown,
+			SatisfactionRate: r.SatisfactionRate,
+		})
+	}
+	httpUtils.Response.Success(c, v1.ExperimentResultsResponse{
+		ExperimentID: results.ExperimentID,
+		Name:         results.Name,
+		Status:       string(results.Status),
+		Variants:     variants,
+	}, "获取实验结果成功")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *ExperimentServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, experiment.ErrNotFound):
+		httpUtils.Response.NotFound(c, "实验")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toExperimentInfo(e *experiment.Experiment) v1.ExperimentInfo {
+	variants := make([]v1.ExperimentVariant, 0, len(e.Variants))
+	for _, v := range e.Variants {
+		variants = append(variants, v1.ExperimentVariant{
+			Name:           v.Name,
+			Weight:         v.Weight,
+			PromptTemplate: v.PromptTemplate,
+			PromptVersion:  v.PromptVersion,
+			LLM:            v.LLM,
+			CostPer1KToken: v.CostPer1KToken,
+		})
+	}
+	return v1.ExperimentInfo{
+		ID:             e.ID,
+		Name:           e.Name,
+		Description:    e.Description,
+		Status:         string(e.Status),
+		TrafficPercent: e.TrafficPercent,
+		Cohort:         e.Cohort,
+		Variants:       variants,
+		CreatedAt:      e.CreatedAt,
+		StartedAt:      e.StartedAt,
+		StoppedAt:      e.StoppedAt,
+	}
+}
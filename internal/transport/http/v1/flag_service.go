@@ -0,0 +1,215 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/flags"
+	"xiaozhi-server-go/internal/domain/tenant"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// FlagServiceV1 V1版本功能开关服务
+type FlagServiceV1 struct {
+	logger  *logging.Logger
+	service *flags.Service
+}
+
+// NewFlagServiceV1 创建功能开关服务V1实例
+func NewFlagServiceV1(logger *logging.Logger, service *flags.Service) (*FlagServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("flag service is required")
+	}
+	return &FlagServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册功能开关API路由
+func (s *FlagServiceV1) Register(router *gin.RouterGroup) {
+	flagGroup := router.Group("/flags")
+	{
+		flagGroup.GET("", s.listFlags)                  // 获取开关列表
+		flagGroup.GET("/:key", s.getFlag)               // 获取开关
+		flagGroup.PUT("/:key", s.setFlag)               // 创建或更新开关
+		flagGroup.DELETE("/:key", s.deleteFlag)         // 删除开关
+		flagGroup.GET("/:key/evaluate", s.evaluateFlag) // 查询开关对租户和设备的取值
+	}
+}
+
+// listFlags 获取开关列表
+// @Summary 获取功能开关列表
+// @Description 列出数据库中的开关和未修改过的内置开关（streaming_tts、new_pipeline、binary_protocol、offline_mode）
+// @Tags Flags
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=v1.FlagListResponse}
+// @Router /v1/flags [get]
+func (s *FlagServiceV1) listFlags(c *gin.Context) {
+	items := s.service.List()
+	infos := make([]v1.FlagInfo, 0, len(items))
+	for _, f := range items {
+		infos = append(infos, toFlagInfo(f))
+	}
+	httpUtils.Response.Success(c, v1.FlagListResponse{Flags: infos}, "获取功能开关列表成功")
+}
+
+// getFlag 获取开关
+// @Summary 获取功能开关
+// @Tags Flags
+// @Produce json
+// @Param key path string true "开关键"
+// @Success 200 {object} httptransport.APIResponse{data=v1.FlagInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/flags/{key} [get]
+func (s *FlagServiceV1) getFlag(c *gin.Context) {
+	f, err := s.service.Get(c.Param("key"))
+	if err != nil {
+		s.handleError(c, err, "获取功能开关失败")
+		return
+	}
+	httpUtils.Response.Success(c, toFlagInfo(f), "获取功能开关成功")
+}
+
+// setFlag 创建或更新开关
+// @Summary 创建或更新功能开关
+// @Description 规则按顺序匹配，第一条匹配的规则决定取值，没有匹配的规则时使用 enabled；每条规则需列出租户或设备（设备分组），两者都列出时需同时满足。修改立即在本实例生效，其它实例在 Flags.RefreshInterval 内生效
+// @Tags Flags
+// @Accept json
+// @Produce json
+// @Param key path string true "开关键"
+// @Param request body v1.FlagSetRequest true "开关设置"
+// @Success 200 {object} httptransport.APIResponse{data=v1.FlagInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/flags/{key} [put]
+func (s *FlagServiceV1) setFlag(c *gin.Context) {
+	var request v1.FlagSetRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	key := c.Param("key")
+	s.logger.InfoTag("API", "更新功能开关", "key", key, "request_id", getRequestID(c))
+
+	rules := make([]flags.Rule, 0, len(request.Rules))
+	for _, r := range request.Rules {
+		rules = append(rules, flags.Rule{
+			Name:    r.Name,
+			Tenants: r.Tenants,
+			Devices: r.Devices,
+			Enabled: r.Enabled,
+		})
+	}
+	f, err := s.service.Set(c.Request.Context(), key, flags.SetRequest{
+		Description: request.Description,
+		Enabled:     request.Enabled,
+		Rules:       rules,
+	})
+	if err != nil {
+		s.handleError(c, err, "更新功能开关失败")
+		return
+	}
+	httpUtils.Response.Success(c, toFlagInfo(f), "功能开关已更新")
+}
+
+// deleteFlag 删除开关
+// @Summary 删除功能开关
+// @Description 删除数据库中的开关；内置开关恢复默认值并返回，自定义开关删除后总是关闭
+// @Tags Flags
+// @Produce json
+// @Param key path string true "开关键"
+// @Success 200 {object} httptransport.APIResponse{data=v1.FlagInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/flags/{key} [delete]
+func (s *FlagServiceV1) deleteFlag(c *gin.Context) {
+	f, err := s.service.Delete(c.Request.Context(), c.Param("key"))
+	if err != nil {
+		s.handleError(c, err, "删除功能开关失败")
+		return
+	}
+	if f == nil {
+		httpUtils.Response.Success(c, nil, "功能开关已删除")
+		return
+	}
+	httpUtils.Response.Success(c, toFlagInfo(f), "功能开关已恢复默认值")
+}
+
+// evaluateFlag 查询开关取值
+// @Summary 查询功能开关对租户和设备的取值
+// @Description 返回取值及其来源：命中的规则、开关的取值、内置默认值，或未定义（总是关闭）
+// @Tags Flags
+// @Produce json
+// @Param key path string true "开关键"
+// @Param tenant_id query string false "租户ID，缺省为默认租户"
+// @Param device_id query string false "设备ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.FlagDecision}
+// @Router /v1/flags/{key}/evaluate [get]
+func (s *FlagServiceV1) evaluateFlag(c *gin.Context) {
+	var query v1.FlagEvaluateQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	tenantID := query.TenantID
+	if tenantID == "" {
+		tenantID = tenant.DefaultID
+	}
+
+	ctx := flags.WithDevice(tenant.WithID(c.Request.Context(), tenantID), query.DeviceID)
+	decision := s.service.Evaluate(ctx, c.Param("key"))
+	httpUtils.Response.Success(c, v1.FlagDecision{
+		Key:      decision.Key,
+		TenantID: tenantID,
+		DeviceID: query.DeviceID,
+		Enabled:  decision.Enabled,
+		Rule:     decision.Rule,
+		Source:   decision.Source,
+	}, "查询功能开关成功")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *FlagServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, flags.ErrNotFound):
+		httpUtils.Response.NotFound(c, "功能开关")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toFlagInfo(f *flags.Flag) v1.FlagInfo {
+	rules := make([]v1.FlagRule, 0, len(f.Rules))
+	for _, r := range f.Rules {
+		rules = append(rules, v1.FlagRule{
+			Name:    r.Name,
+			Tenants: r.Tenants,
+			Devices: r.Devices,
+			Enabled: r.Enabled,
+		})
+	}
+	info := v1.FlagInfo{
+		Key:         f.Key,
+		Description: f.Description,
+		Enabled:     f.Enabled,
+		Rules:       rules,
+		BuiltIn:     f.BuiltIn,
+		Stored:      f.Stored,
+	}
+	if f.Stored {
+		updatedAt := f.UpdatedAt
+		info.UpdatedAt = &updatedAt
+	}
+	return info
+}
@@ -0,0 +1,222 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/job"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// JobServiceV1 V1版本后台任务服务
+type JobServiceV1 struct {
+	logger  *logging.Logger
+	service *job.Service
+}
+
+// NewJobServiceV1 创建后台任务服务V1实例
+func NewJobServiceV1(logger *logging.Logger, service *job.Service) (*JobServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("job service is required")
+	}
+	return &JobServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册后台任务API路由，仅管理员可访问
+func (s *JobServiceV1) Register(router *gin.RouterGroup) {
+	jobs := router.Group("/jobs")
+	{
+		jobs.GET("", s.listJobs)              // 获取任务列表
+		jobs.GET("/stats", s.getStats)        // 获取各类型任务统计
+		jobs.GET("/:id", s.getJob)            // 获取任务详情
+		jobs.POST("/:id/retry", s.retryJob)   // 重新执行死信或已取消的任务
+		jobs.POST("/:id/cancel", s.cancelJob) // 取消任务
+	}
+}
+
+// listJobs 获取任务列表
+// @Summary 获取后台任务列表
+// @Description 按类型、状态和去重键查询后台任务，最新的在前；status 为 dead 时即死信队列
+// @Tags Jobs
+// @Produce json
+// @Param type query string false "任务类型，如 knowledge.ingest"
+// @Param status query string false "状态" Enums(pending, running, succeeded, dead, canceled)
+// @Param key query string false "去重键"
+// @Param page query int false "页码" default(1)
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} httptransport.APIResponse{data=v1.JobListResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Router /v1/jobs [get]
+func (s *JobServiceV1) listJobs(c *gin.Context) {
+	var query v1.JobQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 20
+	}
+
+	items, total, err := s.service.List(c.Request.Context(), job.Filter{
+		Type:     query.Type,
+		Status:   job.Status(query.Status),
+		Key:      query.Key,
+		Page:     query.Page,
+		PageSize: query.Limit,
+	})
+	if err != nil {
+		s.handleError(c, err, "获取任务列表失败")
+		return
+	}
+	jobs := make([]v1.JobInfo, 0, len(items))
+	for _, j := range items {
+		jobs = append(jobs, toJobInfo(j))
+	}
+	httpUtils.Response.Success(c, v1.JobListResponse{
+		Jobs:       jobs,
+		Pagination: newPagination(query.Page, query.Limit, total),
+	}, "获取任务列表成功")
+}
+
+// getStats 获取各类型任务统计
+// @Summary 获取后台任务统计
+// @Description 返回各任务类型在数据库中各状态的任务数，以及本实例的工作协程数、执行中任务数和失败次数
+// @Tags Jobs
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=v1.JobStatsResponse}
+// @Failure 403 {object} httptransport.APIResponse
+// @Router /v1/jobs/stats [get]
+func (s *JobServiceV1) getStats(c *gin.Context) {
+	stats, err := s.service.Stats(c.Request.Context())
+	if err != nil {
+		s.handleError(c, err, "获取任务统计失败")
+		return
+	}
+	types := make([]v1.JobTypeStats, 0, len(stats))
+	for _, t := range stats {
+		counts := make(map[string]int64, len(t.Counts))
+		for status, n := range t.Counts {
+			counts[string(status)] = n
+		}
+		types = append(types, v1.JobTypeStats{
+			Type:     t.Type,
+			Workers:  t.Workers,
+			Running:  t.Running,
+			Counts:   counts,
+			Failures: t.Failures,
+		})
+	}
+	httpUtils.Response.Success(c, v1.JobStatsResponse{Types: types}, "获取任务统计成功")
+}
+
+// getJob 获取任务详情
+// @Summary 获取后台任务详情
+// @Tags Jobs
+// @Produce json
+// @Param id path string true "任务ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.JobInfo}
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/jobs/{id} [get]
+func (s *JobServiceV1) getJob(c *gin.Context) {
+	j, err := s.service.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取任务失败")
+		return
+	}
+	httpUtils.Response.Success(c, toJobInfo(j), "获取任务成功")
+}
+
+// retryJob 重新执行任务
+// @Summary 重新执行后台任务
+// @Description 将死信或已取消的任务放回队列并清零执行次数；同一去重键已有未结束的任务时拒绝
+// @Tags Jobs
+// @Produce json
+// @Param id path string true "任务ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.JobInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/jobs/{id}/retry [post]
+func (s *JobServiceV1) retryJob(c *gin.Context) {
+	j, err := s.service.Retry(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "重新执行任务失败")
+		return
+	}
+	s.logger.InfoTag("API", "重新执行任务", "job_id", j.ID, "request_id", getRequestID(c))
+	httpUtils.Response.Success(c, toJobInfo(j), "任务已放回队列")
+}
+
+// cancelJob 取消任务
+// @Summary 取消后台任务
+// @Description 取消等待中或执行中的任务；执行中的任务在下次续约时中断
+// @Tags Jobs
+// @Produce json
+// @Param id path string true "任务ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.JobInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/jobs/{id}/cancel [post]
+func (s *JobServiceV1) cancelJob(c *gin.Context) {
+	j, err := s.service.Cancel(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "取消任务失败")
+		return
+	}
+	s.logger.InfoTag("API", "取消任务", "job_id", j.ID, "request_id", getRequestID(c))
+	httpUtils.Response.Success(c, toJobInfo(j), "任务已取消")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *JobServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, job.ErrNotFound):
+		httpUtils.Response.NotFound(c, "任务")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toJobInfo(j *job.Job) v1.JobInfo {
+	var payload interface{}
+	if len(j.Payload) > 0 {
+		_ = json.Unmarshal(j.Payload, &payload)
+	}
+	return v1.JobInfo{
+		ID:          j.ID,
+		Type:        j.Type,
+		Key:         j.Key,
+		Payload:     payload,
+		Status:      string(j.Status),
+		Attempts:    j.Attempts,
+		MaxAttempts: j.MaxAttempts,
+		RunAt:       j.RunAt,
+		LeaseUntil:  j.LeaseUntil,
+		LastError:   j.LastError,
+		CreatedAt:   j.CreatedAt,
+		UpdatedAt:   j.UpdatedAt,
+		StartedAt:   j.StartedAt,
+		FinishedAt:  j.FinishedAt,
+	}
+}
@@ -0,0 +1,466 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/knowledge"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// KnowledgeServiceV1 V1版本知识库服务
+type KnowledgeServiceV1 struct {
+	logger  *logging.Logger
+	service *knowledge.Service
+}
+
+// NewKnowledgeServiceV1 创建知识库服务V1实例
+func NewKnowledgeServiceV1(logger *logging.Logger, service *knowledge.Service) (*KnowledgeServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("knowledge service is required")
+	}
+	return &KnowledgeServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册知识库API路由，仅管理员可访问
+func (s *KnowledgeServiceV1) Register(router *gin.RouterGroup) {
+	kb := router.Group("/knowledge")
+	{
+		kb.POST("", s.createBase)                                    // 创建知识库
+		kb.GET("", s.listBases)                                      // 获取知识库列表
+		kb.POST("/search", s.search)                                 // 检索知识库
+		kb.GET("/bindings", s.listBindings)                          // 获取档案绑定
+		kb.PUT("/bindings/:profile", s.setBindings)                  // 设置档案绑定
+		kb.GET("/:id", s.getBase)                                    // 获取知识库详情
+		kb.PUT("/:id", s.updateBase)                                 // 更新知识库
+		kb.DELETE("/:id", s.deleteBase)                              // 删除知识库
+		kb.POST("/:id/documents", s.uploadDocument)                  // 上传文档
+		kb.POST("/:id/documents/url", s.addURL)                      // 导入网页
+		kb.GET("/:id/documents", s.listDocuments)                    // 获取文档列表
+		kb.GET("/:id/documents/:doc_id", s.getDocument)              // 获取文档及导入进度
+		kb.DELETE("/:id/documents/:doc_id", s.deleteDocument)        // 删除文档
+		kb.POST("/:id/documents/:doc_id/reindex", s.reindexDocument) // 重新导入文档
+	}
+}
+
+// createBase 创建知识库
+// @Summary 创建知识库
+// @Tags Knowledge
+// @Accept json
+// @Produce json
+// @Param request body v1.KnowledgeBaseCreateRequest true "知识库信息"
+// @Success 201 {object} httptransport.APIResponse{data=v1.KnowledgeBaseInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Router /v1/knowledge [post]
+func (s *KnowledgeServiceV1) createBase(c *gin.Context) {
+	var request v1.KnowledgeBaseCreateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	b, err := s.service.CreateBase(c.Request.Context(), request.Name, request.Description)
+	if err != nil {
+		s.handleError(c, err, "创建知识库失败")
+		return
+	}
+	httpUtils.Response.Created(c, toKnowledgeBaseInfo(b), "知识库创建成功")
+}
+
+// listBases 获取知识库列表
+// @Summary 获取知识库列表
+// @Tags Knowledge
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.KnowledgeBaseInfo}
+// @Failure 403 {object} httptransport.APIResponse
+// @Router /v1/knowledge [get]
+func (s *KnowledgeServiceV1) listBases(c *gin.Context) {
+	items, err := s.service.ListBases(c.Request.Context())
+	if err != nil {
+		s.handleError(c, err, "获取知识库列表失败")
+		return
+	}
+	bases := make([]v1.KnowledgeBaseInfo, 0, len(items))
+	for _, item := range items {
+		bases = append(bases, toKnowledgeBaseInfo(item))
+	}
+	httpUtils.Response.Success(c, bases, "获取知识库列表成功")
+}
+
+// getBase 获取知识库详情
+// @Summary 获取知识库详情
+// @Tags Knowledge
+// @Produce json
+// @Param id path string true "知识库ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.KnowledgeBaseInfo}
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/knowledge/{id} [get]
+func (s *KnowledgeServiceV1) getBase(c *gin.Context) {
+	b, err := s.service.GetBase(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取知识库失败")
+		return
+	}
+	httpUtils.Response.Success(c, toKnowledgeBaseInfo(b), "获取知识库成功")
+}
+
+// updateBase 更新知识库
+// @Summary 更新知识库
+// @Tags Knowledge
+// @Accept json
+// @Produce json
+// @Param id path string true "知识库ID"
+// @Param request body v1.KnowledgeBaseUpdateRequest true "更新内容"
+// @Success 200 {object} httptransport.APIResponse{data=v1.KnowledgeBaseInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/knowledge/{id} [put]
+func (s *KnowledgeServiceV1) updateBase(c *gin.Context) {
+	var request v1.KnowledgeBaseUpdateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	b, err := s.service.UpdateBase(c.Request.Context(), c.Param("id"), request.Name, request.Description)
+	if err != nil {
+		s.handleError(c, err, "更新知识库失败")
+		return
+	}
+	httpUtils.Response.Success(c, toKnowledgeBaseInfo(b), "知识库更新成功")
+}
+
+// deleteBase 删除知识库
+// @Summary 删除知识库
+// @Description 删除知识库及其全部文档和分块，进行中的导入被取消，档案绑定一并解除
+// @Tags Knowledge
+// @Produce json
+// @Param id path string true "知识库ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/knowledge/{id} [delete]
+func (s *KnowledgeServiceV1) deleteBase(c *gin.Context) {
+	if err := s.service.DeleteBase(c.Request.Context(), c.Param("id")); err != nil {
+		s.handleError(c, err, "删除知识库失败")
+		return
+	}
+	httpUtils.Response.Success(c, nil, "知识库已删除")
+}
+
+// uploadDocument 上传文档
+// @Summary 上传知识库文档
+// @Description 以 multipart 的 file 字段上传 PDF、Markdown、文本或 HTML 文件，文档在后台由工作流任务依次提取文本、分块并生成向量，
+// @Description 可通过文档详情查询导入进度
+// @Tags Knowledge
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "知识库ID"
+// @Param file formData file true "文档文件"
+// @Param name formData string false "文档名称，缺省使用文件名"
+// @Success 202 {object} httptransport.APIResponse{data=v1.KnowledgeDocumentInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/knowledge/{id}/documents [post]
+func (s *KnowledgeServiceV1) uploadDocument(c *gin.Context) {
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		httpUtils.Response.BadRequest(c, "缺少 file 字段")
+		return
+	}
+	defer file.Close()
+	limit := s.service.MaxDocumentSize()
+	if header.Size > limit {
+		httpUtils.Response.BadRequest(c, fmt.Sprintf("文件超过 %d MB", limit>>20))
+		return
+	}
+	data, err := io.ReadAll(io.LimitReader(file, limit+1))
+	if err != nil {
+		httpUtils.Response.BadRequest(c, "读取上传文件失败")
+		return
+	}
+
+	doc, err := s.service.AddFile(c.Request.Context(), c.Param("id"), header.Filename, c.PostForm("name"), header.Header.Get("Content-Type"), data)
+	if err != nil {
+		s.handleError(c, err, "上传文档失败")
+		return
+	}
+	httpUtils.Response.Accepted(c, toKnowledgeDocumentInfo(doc), "文档已提交导入")
+}
+
+// addURL 导入网页
+// @Summary 导入网页到知识库
+// @Description 网页在导入任务中下载并提取正文，按响应的内容类型识别 PDF、Markdown 或文本
+// @Tags Knowledge
+// @Accept json
+// @Produce json
+// @Param id path string true "知识库ID"
+// @Param request body v1.KnowledgeURLRequest true "网页地址"
+// @Success 202 {object} httptransport.APIResponse{data=v1.KnowledgeDocumentInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/knowledge/{id}/documents/url [post]
+func (s *KnowledgeServiceV1) addURL(c *gin.Context) {
+	var request v1.KnowledgeURLRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	doc, err := s.service.AddURL(c.Request.Context(), c.Param("id"), request.URL, request.Name)
+	if err != nil {
+		s.handleError(c, err, "导入网页失败")
+		return
+	}
+	httpUtils.Response.Accepted(c, toKnowledgeDocumentInfo(doc), "网页已提交导入")
+}
+
+// listDocuments 获取文档列表
+// @Summary 获取知识库文档列表
+// @Description 返回文档及其导入状态和进度，新上传的在前
+// @Tags Knowledge
+// @Produce json
+// @Param id path string true "知识库ID"
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.KnowledgeDocumentInfo}
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/knowledge/{id}/documents [get]
+func (s *KnowledgeServiceV1) listDocuments(c *gin.Context) {
+	items, err := s.service.ListDocuments(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取文档列表失败")
+		return
+	}
+	docs := make([]v1.KnowledgeDocumentInfo, 0, len(items))
+	for _, item := range items {
+		docs = append(docs, toKnowledgeDocumentInfo(item))
+	}
+	httpUtils.Response.Success(c, docs, "获取文档列表成功")
+}
+
+// getDocument 获取文档及导入进度
+// @Summary 获取知识库文档
+// @Tags Knowledge
+// @Produce json
+// @Param id path string true "知识库ID"
+// @Param doc_id path string true "文档ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.KnowledgeDocumentInfo}
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/knowledge/{id}/documents/{doc_id} [get]
+func (s *KnowledgeServiceV1) getDocument(c *gin.Context) {
+	doc, err := s.service.GetDocument(c.Request.Context(), c.Param("id"), c.Param("doc_id"))
+	if err != nil {
+		s.handleError(c, err, "获取文档失败")
+		return
+	}
+	httpUtils.Response.Success(c, toKnowledgeDocumentInfo(doc), "获取文档成功")
+}
+
+// deleteDocument 删除文档
+// @Summary 删除知识库文档
+// @Tags Knowledge
+// @Produce json
+// @Param id path string true "知识库ID"
+// @Param doc_id path string true "文档ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/knowledge/{id}/documents/{doc_id} [delete]
+func (s *KnowledgeServiceV1) deleteDocument(c *gin.Context) {
+	if err := s.service.DeleteDocument(c.Request.Context(), c.Param("id"), c.Param("doc_id")); err != nil {
+		s.handleError(c, err, "删除文档失败")
+		return
+	}
+	httpUtils.Response.Success(c, nil, "文档已删除")
+}
+
+// reindexDocument 重新导入文档
+// @Summary 重新导入知识库文档
+// @Description 导入失败或更换向量化能力后重新导入，网页会重新下载；导入进行中时拒绝
+// @Tags Knowledge
+// @Produce json
+// @Param id path string true "知识库ID"
+// @Param doc_id path string true "文档ID"
+// @Success 202 {object} httptransport.APIResponse{data=v1.KnowledgeDocumentInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/knowledge/{id}/documents/{doc_id}/reindex [post]
+func (s *KnowledgeServiceV1) reindexDocument(c *gin.Context) {
+	doc, err := s.service.Reingest(c.Request.Context(), c.Param("id"), c.Param("doc_id"))
+	if err != nil {
+		s.handleError(c, err, "重新导入文档失败")
+		return
+	}
+	httpUtils.Response.Accepted(c, toKnowledgeDocumentInfo(doc), "文档已提交重新导入")
+}
+
+// search 检索知识库
+// @Summary 检索知识库
+// @Description 在指定知识库或设备所属档案绑定的知识库中检索，返回命中的分块和附加给 LLM 的参考资料，用于调试检索效果
+// @Tags Knowledge
+// @Accept json
+// @Produce json
+// @Param request body v1.KnowledgeSearchRequest true "检索内容"
+// @Success 200 {object} httptransport.APIResponse{data=v1.KnowledgeSearchResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/knowledge/search [post]
+func (s *KnowledgeServiceV1) search(c *gin.Context) {
+	var request v1.KnowledgeSearchRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	ctx := c.Request.Context()
+
+	baseIDs := request.KnowledgeBaseIDs
+	if len(baseIDs) == 0 {
+		if request.DeviceID == "" {
+			httpUtils.Response.BadRequest(c, "需要指定 knowledge_base_ids 或 device_id")
+			return
+		}
+		baseIDs = s.service.BaseIDsFor(request.DeviceID)
+	}
+	for _, id := range baseIDs {
+		if _, err := s.service.GetBase(ctx, id); err != nil {
+			s.handleError(c, err, "检索知识库失败")
+			return
+		}
+	}
+
+	hits, err := s.service.Search(ctx, baseIDs, request.Query, request.TopK)
+	if err != nil {
+		s.handleError(c, err, "检索知识库失败")
+		return
+	}
+	result := v1.KnowledgeSearchResponse{Hits: make([]v1.KnowledgeHit, 0, len(hits)), Context: knowledge.FormatContext(hits)}
+	for _, hit := range hits {
+		result.Hits = append(result.Hits, v1.KnowledgeHit{
+			KnowledgeBaseID: hit.BaseID,
+			DocumentID:      hit.DocumentID,
+			DocumentName:    hit.DocumentName,
+			ChunkIndex:      hit.ChunkIndex,
+			Content:         hit.Content,
+			Score:           hit.Score,
+		})
+	}
+	httpUtils.Response.Success(c, result, "检索完成")
+}
+
+// listBindings 获取档案绑定
+// @Summary 获取知识库档案绑定
+// @Description 设备按配置的 knowledge.device_profiles 归入档案，未配置的设备使用 default 档案
+// @Tags Knowledge
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.KnowledgeBindingInfo}
+// @Failure 403 {object} httptransport.APIResponse
+// @Router /v1/knowledge/bindings [get]
+func (s *KnowledgeServiceV1) listBindings(c *gin.Context) {
+	bindings := s.service.Bindings()
+	profiles := make([]string, 0, len(bindings))
+	for profile := range bindings {
+		profiles = append(profiles, profile)
+	}
+	sort.Strings(profiles)
+
+	items := make([]v1.KnowledgeBindingInfo, 0, len(profiles))
+	for _, profile := range profiles {
+		items = append(items, v1.KnowledgeBindingInfo{Profile: profile, KnowledgeBaseIDs: bindings[profile]})
+	}
+	httpUtils.Response.Success(c, items, "获取档案绑定成功")
+}
+
+// setBindings 设置档案绑定
+// @Summary 设置知识库档案绑定
+// @Description 设置档案下设备对话时检索的知识库，立即生效；knowledge_base_ids 为空时解除绑定
+// @Tags Knowledge
+// @Accept json
+// @Produce json
+// @Param profile path string true "档案名称"
+// @Param request body v1.KnowledgeBindingRequest true "绑定的知识库"
+// @Success 200 {object} httptransport.APIResponse{data=v1.KnowledgeBindingInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/knowledge/bindings/{profile} [put]
+func (s *KnowledgeServiceV1) setBindings(c *gin.Context) {
+	var request v1.KnowledgeBindingRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	profile := c.Param("profile")
+	ids, err := s.service.SetBindings(c.Request.Context(), profile, request.KnowledgeBaseIDs)
+	if err != nil {
+		s.handleError(c, err, "设置档案绑定失败")
+		return
+	}
+	if ids == nil {
+		ids = []string{}
+	}
+	httpUtils.Response.Success(c, v1.KnowledgeBindingInfo{Profile: profile, KnowledgeBaseIDs: ids}, "档案绑定已更新")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *KnowledgeServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, knowledge.ErrBaseNotFound):
+		httpUtils.Response.NotFound(c, "知识库")
+	case errors.Is(err, knowledge.ErrDocumentNotFound):
+		httpUtils.Response.NotFound(c, "文档")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toKnowledgeBaseInfo(b *knowledge.Base) v1.KnowledgeBaseInfo {
+	return v1.KnowledgeBaseInfo{
+		ID:          b.ID,
+		Name:        b.Name,
+		Description: b.Description,
+		CreatedAt:   b.CreatedAt,
+		UpdatedAt:   b.UpdatedAt,
+	}
+}
+
+func toKnowledgeDocumentInfo(d *knowledge.Document) v1.KnowledgeDocumentInfo {
+	return v1.KnowledgeDocumentInfo{
+		ID:          d.ID,
+		BaseID:      d.BaseID,
+		Name:        d.Name,
+		SourceType:  string(d.SourceType),
+		Source:      d.Source,
+		Format:      string(d.Format),
+		Size:        d.Size,
+		Status:      string(d.Status),
+		Progress:    d.Progress,
+		Chunks:      d.Chunks,
+		Embedding:   d.Embedding,
+		ExecutionID: d.ExecutionID,
+		ObjectID:    d.ObjectID,
+		Error:       d.Error,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+	}
+}
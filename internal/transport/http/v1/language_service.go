@@ -0,0 +1,151 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/language"
+	"xiaozhi-server-go/internal/domain/translation"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// LanguageServiceV1 V1版本语言服务
+type LanguageServiceV1 struct {
+	logger  *logging.Logger
+	service *language.Service
+}
+
+// NewLanguageServiceV1 创建语言服务V1实例
+func NewLanguageServiceV1(logger *logging.Logger, service *language.Service) (*LanguageServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("language service is required")
+	}
+	return &LanguageServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册语言API路由
+func (s *LanguageServiceV1) Register(router *gin.RouterGroup) {
+	router.GET("/languages", s.listLanguages)                       // 获取支持的语言
+	router.GET("/devices/:id/languages", s.getDeviceLanguages)      // 获取设备允许的语言
+	router.PUT("/devices/:id/languages", s.setDeviceLanguages)      // 设置设备允许的语言
+	router.DELETE("/devices/:id/languages", s.resetDeviceLanguages) // 恢复默认允许语言
+}
+
+// listLanguages 获取支持的语言
+// @Summary 获取支持的语言
+// @Description 返回支持自动识别的语言、各语言的播报音色和默认允许的语言
+// @Tags Languages
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=v1.LanguageListResponse}
+// @Router /v1/languages [get]
+func (s *LanguageServiceV1) listLanguages(c *gin.Context) {
+	langs := translation.Languages()
+	infos := make([]v1.LanguageInfo, 0, len(langs))
+	for _, lang := range langs {
+		infos = append(infos, v1.LanguageInfo{
+			Code:  lang.Code,
+			Name:  lang.Name,
+			Voice: s.service.Voice(lang.Code),
+		})
+	}
+	httpUtils.Response.Success(c, v1.LanguageListResponse{
+		Enabled:   s.service.Enabled(),
+		Allowed:   s.service.DefaultAllowed(),
+		Languages: infos,
+	}, "获取语言列表成功")
+}
+
+// getDeviceLanguages 获取设备允许的语言
+// @Summary 获取设备允许的语言
+// @Description 未单独设置的设备返回配置的默认值（Language.Allowed）
+// @Tags Languages
+// @Produce json
+// @Param id path string true "设备ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.DeviceLanguagesInfo}
+// @Router /v1/devices/{id}/languages [get]
+func (s *LanguageServiceV1) getDeviceLanguages(c *gin.Context) {
+	deviceID := c.Param("id")
+	langs, custom, err := s.service.Get(c.Request.Context(), deviceID)
+	if err != nil {
+		s.handleError(c, err, "获取设备语言失败")
+		return
+	}
+	httpUtils.Response.Success(c, v1.DeviceLanguagesInfo{
+		DeviceID:  deviceID,
+		Languages: langs,
+		Custom:    custom,
+	}, "获取设备语言成功")
+}
+
+// setDeviceLanguages 设置设备允许的语言
+// @Summary 设置设备允许的语言
+// @Description 设备每句话在允许的语言中识别语言，第一个为主要语言（使用设备默认音色），其它语言使用 Language.Voices 配置的音色。设备重新连接后生效
+// @Tags Languages
+// @Accept json
+// @Produce json
+// @Param id path string true "设备ID"
+// @Param request body v1.DeviceLanguagesRequest true "允许的语言"
+// @Success 200 {object} httptransport.APIResponse{data=v1.DeviceLanguagesInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/devices/{id}/languages [put]
+func (s *LanguageServiceV1) setDeviceLanguages(c *gin.Context) {
+	var request v1.DeviceLanguagesRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	deviceID := c.Param("id")
+	s.logger.InfoTag("API", "设置设备语言", "device_id", deviceID, "request_id", getRequestID(c))
+	d, err := s.service.Set(c.Request.Context(), deviceID, request.Languages)
+	if err != nil {
+		s.handleError(c, err, "设置设备语言失败")
+		return
+	}
+	updatedAt := d.UpdatedAt
+	httpUtils.Response.Success(c, v1.DeviceLanguagesInfo{
+		DeviceID:  d.DeviceID,
+		Languages: d.Languages,
+		Custom:    true,
+		UpdatedAt: &updatedAt,
+	}, "设备语言已设置")
+}
+
+// resetDeviceLanguages 恢复默认允许语言
+// @Summary 恢复设备的默认允许语言
+// @Description 删除设备单独设置的允许语言，之后使用 Language.Allowed，设备重新连接后生效
+// @Tags Languages
+// @Produce json
+// @Param id path string true "设备ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.DeviceLanguagesInfo}
+// @Router /v1/devices/{id}/languages [delete]
+func (s *LanguageServiceV1) resetDeviceLanguages(c *gin.Context) {
+	deviceID := c.Param("id")
+	if err := s.service.Reset(c.Request.Context(), deviceID); err != nil {
+		s.handleError(c, err, "恢复设备语言失败")
+		return
+	}
+	httpUtils.Response.Success(c, v1.DeviceLanguagesInfo{
+		DeviceID:  deviceID,
+		Languages: s.service.DefaultAllowed(),
+	}, "设备语言已恢复默认")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *LanguageServiceV1) handleError(c *gin.Context, err error, message string) {
+	if platformerrors.IsKind(err, platformerrors.KindDomain) {
+		httpUtils.Response.BadRequest(c, err.Error())
+		return
+	}
+	s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+	httpUtils.Response.InternalError(c, message)
+}
@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// LogServiceV1 V1版本日志查询服务
+type LogServiceV1 struct {
+	logger *logging.Logger
+	store  logging.EntryStore
+}
+
+// NewLogServiceV1 创建日志查询服务V1实例
+func NewLogServiceV1(logger *logging.Logger, store logging.EntryStore) (*LogServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if store == nil {
+		return nil, fmt.Errorf("log store is required")
+	}
+	return &LogServiceV1{
+		logger: logger,
+		store:  store,
+	}, nil
+}
+
+// Register 注册日志查询API路由，仅管理员可访问
+func (s *LogServiceV1) Register(router *gin.RouterGroup) {
+	router.GET("/logs", s.listLogs) // 查询日志
+}
+
+// listLogs 查询日志
+// @Summary 查询服务日志
+// @Description 按最低级别、标签、时间范围和文本查询服务日志索引，新日志在前。
+// @Description 日志在后台批量写入索引，最近约一秒内的日志可能还查不到；索引保留天数见 Log.Index.RetentionDays
+// @Tags Logs
+// @Produce json
+// @Param level query string false "最低级别" Enums(debug, info, warn, error)
+// @Param tag query string false "日志标签"
+// @Param q query string false "在日志正文和属性中搜索的文本"
+// @Param from query string false "开始时间 RFC3339"
+// @Param to query string false "结束时间 RFC3339"
+// @Param page query int false "页码" default(1)
+// @Param limit query int false "每页数量" default(50)
+// @Success 200 {object} httptransport.APIResponse{data=v1.LogListResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Router /v1/logs [get]
+func (s *LogServiceV1) listLogs(c *gin.Context) {
+	var query v1.LogQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 50
+	}
+
+	filter := logging.Query{
+		MinLevel: slog.LevelDebug,
+		Tag:      query.Tag,
+		Text:     query.Q,
+		Page:     query.Page,
+		PageSize: query.Limit,
+	}
+	switch strings.ToLower(query.Level) {
+	case "":
+	case "debug", "info", "warn", "error":
+		filter.MinLevel = logging.ParseLevel(query.Level)
+	default:
+		httpUtils.Response.BadRequest(c, "level 必须是 debug、info、warn 或 error")
+		return
+	}
+	var err error
+	if filter.From, err = parseQueryTime(query.From); err != nil {
+		httpUtils.Response.BadRequest(c, "from 必须是 RFC3339 时间")
+		return
+	}
+	if filter.To, err = parseQueryTime(query.To); err != nil {
+		httpUtils.Response.BadRequest(c, "to 必须是 RFC3339 时间")
+		return
+	}
+
+	entries, total, err := s.store.QueryEntries(c.Request.Context(), filter)
+	if err != nil {
+		s.logger.ErrorTag("API", "查询日志失败", "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, "查询日志失败")
+		return
+	}
+
+	response := v1.LogListResponse{
+		Entries:    make([]v1.LogEntryInfo, 0, len(entries)),
+		Pagination: newPagination(query.Page, query.Limit, total),
+		Dropped:    s.logger.IndexDropped(),
+	}
+	for _, entry := range entries {
+		response.Entries = append(response.Entries, v1.LogEntryInfo{
+			Time:    entry.Time,
+			Level:   entry.Level,
+			Tag:     entry.Tag,
+			Message: entry.Message,
+			Attrs:   entry.Attrs,
+		})
+	}
+	httpUtils.Response.Success(c, response, "查询日志成功")
+}
@@ -0,0 +1,181 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/media"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// MediaServiceV1 V1版本媒体播放服务
+type MediaServiceV1 struct {
+	logger  *logging.Logger
+	service *media.Service
+}
+
+// NewMediaServiceV1 创建媒体播放服务V1实例
+func NewMediaServiceV1(logger *logging.Logger, service *media.Service) (*MediaServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("media service is required")
+	}
+	return &MediaServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册媒体播放API路由
+func (s *MediaServiceV1) Register(router *gin.RouterGroup) {
+	router.GET("/media/search", s.search) // 检索曲库、电台和媒体能力
+
+	router.GET("/devices/:id/media", s.getState) // 获取设备播放状态
+	router.PUT("/devices/:id/media", s.control)  // 点播或控制设备播放
+}
+
+// search 检索可播放的曲目
+// @Summary 检索曲目和电台
+// @Description 按来源检索本地曲库、配置的网络电台和媒体能力，不指定来源时依次检索
+// @Tags Media
+// @Produce json
+// @Param q query string false "检索内容，为空时随机返回曲库中的曲目"
+// @Param source query string false "来源 library/radio/provider"
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.MediaTrack}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/media/search [get]
+func (s *MediaServiceV1) search(c *gin.Context) {
+	var query v1.MediaSearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	tracks, err := s.service.Search(c.Request.Context(), query.Query, query.Source)
+	if err != nil {
+		s.handleError(c, err, "检索媒体失败")
+		return
+	}
+	result := make([]v1.MediaTrack, 0, len(tracks))
+	for _, track := range tracks {
+		result = append(result, toMediaTrack(track))
+	}
+	httpUtils.Response.Success(c, result, "检索媒体成功")
+}
+
+// getState 获取设备播放状态
+// @Summary 获取设备播放状态
+// @Tags Media
+// @Produce json
+// @Param id path string true "设备ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.MediaState}
+// @Router /v1/devices/{id}/media [get]
+func (s *MediaServiceV1) getState(c *gin.Context) {
+	httpUtils.Response.Success(c, toMediaState(s.service.State(c.Param("id"))), "获取播放状态成功")
+}
+
+// control 点播或控制设备播放
+// @Summary 点播或控制设备播放
+// @Description play 检索后在设备上播放，其余操作控制设备当前的播放；设备需在线，播放和暂停以外的操作需要设备正在播放
+// @Tags Media
+// @Accept json
+// @Produce json
+// @Param id path string true "设备ID"
+// @Param request body v1.MediaControlRequest true "播放操作"
+// @Success 200 {object} httptransport.APIResponse{data=v1.MediaState}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Failure 409 {object} httptransport.APIResponse
+// @Router /v1/devices/{id}/media [put]
+func (s *MediaServiceV1) control(c *gin.Context) {
+	var request v1.MediaControlRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	deviceID := c.Param("id")
+	var (
+		state media.State
+		err   error
+	)
+	switch request.Action {
+	case string(media.ActionPlay):
+		if _, err = s.service.Play(c.Request.Context(), deviceID, request.Query, request.Source); err == nil {
+			state = s.service.State(deviceID)
+		}
+	case "volume":
+		if request.Volume == nil {
+			httpUtils.Response.BadRequest(c, "volume 不能为空")
+			return
+		}
+		state, err = s.service.SetVolume(deviceID, *request.Volume)
+	default:
+		state, err = s.service.Control(deviceID, media.Action(request.Action))
+	}
+	if err != nil {
+		s.handleError(c, err, "控制播放失败")
+		return
+	}
+	s.logger.InfoTag("API", "控制播放", "device_id", deviceID, "action", request.Action, "request_id", getRequestID(c))
+	httpUtils.Response.Success(c, toMediaState(state), "操作成功")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *MediaServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, media.ErrNotFound):
+		httpUtils.Response.NotFound(c, "媒体")
+	case errors.Is(err, media.ErrDeviceOffline):
+		httpUtils.Response.Conflict(c, "设备不在线，无法播放")
+	case errors.Is(err, media.ErrNotPlaying):
+		httpUtils.Response.Conflict(c, "设备当前没有在播放")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toMediaTrack(track media.Track) v1.MediaTrack {
+	info := v1.MediaTrack{
+		Title:  track.Title,
+		Artist: track.Artist,
+		Source: track.Source,
+		Live:   track.Live,
+	}
+	if track.Source != media.SourceLibrary {
+		info.URL = track.URL
+	}
+	return info
+}
+
+// toMediaState 转换播放状态，设备详情中的播放状态也使用该结构
+func toMediaState(state media.State) v1.MediaState {
+	info := v1.MediaState{
+		DeviceID:   state.DeviceID,
+		Status:     string(state.Status),
+		Index:      state.Index,
+		QueueSize:  state.QueueSize,
+		Volume:     state.Volume,
+		PositionMs: state.Position.Milliseconds(),
+		Error:      state.Error,
+	}
+	if state.Track != nil {
+		track := toMediaTrack(*state.Track)
+		info.Track = &track
+	}
+	if !state.UpdatedAt.IsZero() {
+		updatedAt := state.UpdatedAt
+		info.UpdatedAt = &updatedAt
+	}
+	return info
+}
@@ -0,0 +1,254 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/member"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// MemberServiceV1 V1版本家庭成员服务
+type MemberServiceV1 struct {
+	logger  *logging.Logger
+	service *member.Service
+}
+
+// NewMemberServiceV1 创建家庭成员服务V1实例
+func NewMemberServiceV1(logger *logging.Logger, service *member.Service) (*MemberServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("member service is required")
+	}
+	return &MemberServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册家庭成员API路由
+func (s *MemberServiceV1) Register(router *gin.RouterGroup) {
+	users := router.Group("/users/:id")
+	{
+		users.GET("/devices", s.listUserDevices)            // 获取用户绑定的设备
+		users.POST("/devices", s.bindDevice)                // 绑定设备
+		users.DELETE("/devices/:device_id", s.unbindDevice) // 解除绑定
+		users.GET("/profile", s.getProfile)                 // 获取成员资料
+		users.PUT("/profile", s.updateProfile)              // 修改成员资料
+	}
+	router.GET("/devices/:id/members", s.listDeviceMembers) // 获取设备上的成员
+}
+
+// listUserDevices 获取用户绑定的设备
+// @Summary 获取用户绑定的设备
+// @Tags Members
+// @Produce json
+// @Param id path int true "用户ID"
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.DeviceBindingInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/users/{id}/devices [get]
+func (s *MemberServiceV1) listUserDevices(c *gin.Context) {
+	userID, ok := s.userID(c)
+	if !ok {
+		return
+	}
+	bindings, err := s.service.ListDevices(c.Request.Context(), userID)
+	if err != nil {
+		s.handleError(c, err, "获取用户设备失败")
+		return
+	}
+	infos := make([]v1.DeviceBindingInfo, len(bindings))
+	for i, b := range bindings {
+		infos[i] = toBindingInfo(b)
+	}
+	httpUtils.Response.Success(c, infos, "获取用户设备成功")
+}
+
+// bindDevice 绑定设备
+// @Summary 绑定设备
+// @Description 将已注册的设备绑定给用户。每台设备最多一个所有者（owner），其余为家庭成员（member）；重复绑定时更新角色
+// @Tags Members
+// @Accept json
+// @Produce json
+// @Param id path int true "用户ID"
+// @Param request body v1.BindDeviceRequest true "绑定信息"
+// @Success 201 {object} httptransport.APIResponse{data=v1.DeviceBindingInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/users/{id}/devices [post]
+func (s *MemberServiceV1) bindDevice(c *gin.Context) {
+	userID, ok := s.userID(c)
+	if !ok {
+		return
+	}
+	var request v1.BindDeviceRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	s.logger.InfoTag("API", "绑定设备", "user_id", userID, "device_id", request.DeviceID, "request_id", getRequestID(c))
+
+	binding, err := s.service.BindDevice(c.Request.Context(), userID, request.DeviceID, member.Role(request.Role))
+	if err != nil {
+		s.handleError(c, err, "绑定设备失败")
+		return
+	}
+	httpUtils.Response.Created(c, toBindingInfo(binding), "设备绑定成功")
+}
+
+// unbindDevice 解除绑定
+// @Summary 解除设备绑定
+// @Tags Members
+// @Produce json
+// @Param id path int true "用户ID"
+// @Param device_id path string true "设备ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/users/{id}/devices/{device_id} [delete]
+func (s *MemberServiceV1) unbindDevice(c *gin.Context) {
+	userID, ok := s.userID(c)
+	if !ok {
+		return
+	}
+	deviceID := c.Param("device_id")
+	if err := s.service.UnbindDevice(c.Request.Context(), userID, deviceID); err != nil {
+		s.handleError(c, err, "解除设备绑定失败")
+		return
+	}
+	httpUtils.Response.Success(c, map[string]interface{}{"user_id": userID, "device_id": deviceID}, "已解除设备绑定")
+}
+
+// getProfile 获取成员资料
+// @Summary 获取成员资料
+// @Tags Members
+// @Produce json
+// @Param id path int true "用户ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.MemberProfileInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/users/{id}/profile [get]
+func (s *MemberServiceV1) getProfile(c *gin.Context) {
+	userID, ok := s.userID(c)
+	if !ok {
+		return
+	}
+	profile, err := s.service.GetProfile(c.Request.Context(), userID)
+	if err != nil {
+		s.handleError(c, err, "获取成员资料失败")
+		return
+	}
+	httpUtils.Response.Success(c, toProfileInfo(profile), "获取成员资料成功")
+}
+
+// updateProfile 修改成员资料
+// @Summary 修改成员资料
+// @Description 称呼用于按名字识别说话人；识别到该成员后使用其偏好音色和语言回复，个人记忆按命名空间隔离
+// @Tags Members
+// @Accept json
+// @Produce json
+// @Param id path int true "用户ID"
+// @Param request body v1.MemberProfileUpdateRequest true "修改内容"
+// @Success 200 {object} httptransport.APIResponse{data=v1.MemberProfileInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/users/{id}/profile [put]
+func (s *MemberServiceV1) updateProfile(c *gin.Context) {
+	userID, ok := s.userID(c)
+	if !ok {
+		return
+	}
+	var request v1.MemberProfileUpdateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	profile, err := s.service.UpdateProfile(c.Request.Context(), userID, member.ProfileUpdate{
+		Name:            request.Name,
+		Voice:           request.Voice,
+		Language:        request.Language,
+		MemoryNamespace: request.MemoryNamespace,
+	})
+	if err != nil {
+		s.handleError(c, err, "修改成员资料失败")
+		return
+	}
+	httpUtils.Response.Success(c, toProfileInfo(profile), "成员资料修改成功")
+}
+
+// listDeviceMembers 获取设备上的成员
+// @Summary 获取设备上的成员
+// @Description 返回设备绑定的全部用户及其资料，所有者在前
+// @Tags Members
+// @Produce json
+// @Param id path string true "设备ID"
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.DeviceMemberInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/devices/{id}/members [get]
+func (s *MemberServiceV1) listDeviceMembers(c *gin.Context) {
+	members, err := s.service.Members(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取设备成员失败")
+		return
+	}
+	infos := make([]v1.DeviceMemberInfo, len(members))
+	for i, m := range members {
+		infos[i] = v1.DeviceMemberInfo{
+			DeviceBindingInfo: toBindingInfo(&m.Binding),
+			Profile:           toProfileInfo(&m.Profile),
+		}
+	}
+	httpUtils.Response.Success(c, infos, "获取设备成员成功")
+}
+
+func (s *MemberServiceV1) userID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		httpUtils.Response.BadRequest(c, "无效的用户ID")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (s *MemberServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, member.ErrUserNotFound):
+		httpUtils.Response.NotFound(c, "用户")
+	case errors.Is(err, member.ErrDeviceNotFound):
+		httpUtils.Response.NotFound(c, "设备")
+	case errors.Is(err, member.ErrNotBound):
+		httpUtils.Response.NotFound(c, "设备绑定")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toBindingInfo(b *member.Binding) v1.DeviceBindingInfo {
+	return v1.DeviceBindingInfo{
+		UserID:    b.UserID,
+		DeviceID:  b.DeviceID,
+		Role:      string(b.Role),
+		CreatedAt: b.CreatedAt,
+	}
+}
+
+func toProfileInfo(p *member.Profile) v1.MemberProfileInfo {
+	return v1.MemberProfileInfo{
+		UserID:          p.UserID,
+		Name:            p.Name,
+		Voice:           p.Voice,
+		Language:        p.Language,
+		MemoryNamespace: p.MemoryNamespace,
+		UpdatedAt:       p.UpdatedAt,
+	}
+}
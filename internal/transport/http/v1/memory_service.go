@@ -0,0 +1,311 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/member"
+	"xiaozhi-server-go/internal/domain/memory"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// MemoryServiceV1 V1版本长期记忆服务
+type MemoryServiceV1 struct {
+	logger  *logging.Logger
+	service *memory.Service
+}
+
+// NewMemoryServiceV1 创建长期记忆服务V1实例
+func NewMemoryServiceV1(logger *logging.Logger, service *memory.Service) (*MemoryServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("memory service is required")
+	}
+	return &MemoryServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册长期记忆API路由
+func (s *MemoryServiceV1) Register(router *gin.RouterGroup) {
+	users := router.Group("/users/:id")
+	{
+		users.GET("/memories", s.listFacts)              // 获取记忆列表
+		users.POST("/memories", s.addFact)               // 添加记忆
+		users.PUT("/memories/:fact_id", s.updateFact)    // 修改记忆
+		users.DELETE("/memories/:fact_id", s.deleteFact) // 删除记忆
+		users.DELETE("/memories", s.clearFacts)          // 清空记忆
+		users.GET("/memory-consent", s.getConsent)       // 获取记忆提取授权
+		users.PUT("/memory-consent", s.setConsent)       // 设置记忆提取授权
+	}
+}
+
+// listFacts 获取记忆列表
+// @Summary 获取用户的长期记忆
+// @Description 列出用户记忆命名空间中的事实及其出处（设备、会话、对话记录和原话），最近更新的在前
+// @Tags Memory
+// @Produce json
+// @Param id path int true "用户ID"
+// @Param q query string false "内容关键字"
+// @Param page query int false "页码" default(1)
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} httptransport.APIResponse{data=v1.MemoryFactListResponse}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/users/{id}/memories [get]
+func (s *MemoryServiceV1) listFacts(c *gin.Context) {
+	userID, ok := s.userID(c)
+	if !ok {
+		return
+	}
+	var query v1.MemoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 20
+	}
+
+	items, total, err := s.service.List(c.Request.Context(), userID, query.Q, query.Page, query.Limit)
+	if err != nil {
+		s.handleError(c, err, "获取记忆列表失败")
+		return
+	}
+
+	facts := make([]v1.MemoryFactInfo, 0, len(items))
+	for _, item := range items {
+		facts = append(facts, toMemoryFactInfo(item))
+	}
+	totalPages := (total + int64(query.Limit) - 1) / int64(query.Limit)
+	httpUtils.Response.Success(c, v1.MemoryFactListResponse{
+		Facts: facts,
+		Pagination: v1.Pagination{
+			Page:       int64(query.Page),
+			Limit:      int64(query.Limit),
+			Total:      total,
+			TotalPages: totalPages,
+			HasNext:    int64(query.Page) < totalPages,
+			HasPrev:    query.Page > 1,
+		},
+	}, "获取记忆列表成功")
+}
+
+// addFact 添加记忆
+// @Summary 添加长期记忆
+// @Description 手动为用户添加一条记忆，不需要记忆提取授权
+// @Tags Memory
+// @Accept json
+// @Produce json
+// @Param id path int true "用户ID"
+// @Param request body v1.MemoryFactRequest true "记忆内容"
+// @Success 201 {object} httptransport.APIResponse{data=v1.MemoryFactInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/users/{id}/memories [post]
+func (s *MemoryServiceV1) addFact(c *gin.Context) {
+	userID, ok := s.userID(c)
+	if !ok {
+		return
+	}
+	var request v1.MemoryFactRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	f, err := s.service.Add(c.Request.Context(), userID, request.Content)
+	if err != nil {
+		s.handleError(c, err, "添加记忆失败")
+		return
+	}
+	httpUtils.Response.Created(c, toMemoryFactInfo(f), "记忆已添加")
+}
+
+// updateFact 修改记忆
+// @Summary 修改长期记忆
+// @Description 修改后记忆的来源记为 manual，保留原始出处
+// @Tags Memory
+// @Accept json
+// @Produce json
+// @Param id path int true "用户ID"
+// @Param fact_id path string true "记忆ID"
+// @Param request body v1.MemoryFactRequest true "记忆内容"
+// @Success 200 {object} httptransport.APIResponse{data=v1.MemoryFactInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/users/{id}/memories/{fact_id} [put]
+func (s *MemoryServiceV1) updateFact(c *gin.Context) {
+	userID, ok := s.userID(c)
+	if !ok {
+		return
+	}
+	var request v1.MemoryFactRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	f, err := s.service.Update(c.Request.Context(), userID, c.Param("fact_id"), request.Content)
+	if err != nil {
+		s.handleError(c, err, "修改记忆失败")
+		return
+	}
+	httpUtils.Response.Success(c, toMemoryFactInfo(f), "记忆已修改")
+}
+
+// deleteFact 删除记忆
+// @Summary 删除长期记忆
+// @Tags Memory
+// @Produce json
+// @Param id path int true "用户ID"
+// @Param fact_id path string true "记忆ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/users/{id}/memories/{fact_id} [delete]
+func (s *MemoryServiceV1) deleteFact(c *gin.Context) {
+	userID, ok := s.userID(c)
+	if !ok {
+		return
+	}
+	if err := s.service.Delete(c.Request.Context(), userID, c.Param("fact_id")); err != nil {
+		s.handleError(c, err, "删除记忆失败")
+		return
+	}
+	httpUtils.Response.Success(c, nil, "记忆已删除")
+}
+
+// clearFacts 清空记忆
+// @Summary 清空长期记忆
+// @Description 删除用户记忆命名空间中的全部记忆，不影响记忆提取授权
+// @Tags Memory
+// @Produce json
+// @Param id path int true "用户ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.MemoryClearResponse}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/users/{id}/memories [delete]
+func (s *MemoryServiceV1) clearFacts(c *gin.Context) {
+	userID, ok := s.userID(c)
+	if !ok {
+		return
+	}
+	deleted, err := s.service.Clear(c.Request.Context(), userID)
+	if err != nil {
+		s.handleError(c, err, "清空记忆失败")
+		return
+	}
+	s.logger.InfoTag("API", "清空长期记忆", "user_id", userID, "deleted", deleted, "request_id", getRequestID(c))
+	httpUtils.Response.Success(c, v1.MemoryClearResponse{Deleted: deleted}, "记忆已清空")
+}
+
+// getConsent 获取记忆提取授权
+// @Summary 获取记忆提取授权
+// @Tags Memory
+// @Produce json
+// @Param id path int true "用户ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.MemoryConsentInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/users/{id}/memory-consent [get]
+func (s *MemoryServiceV1) getConsent(c *gin.Context) {
+	userID, ok := s.userID(c)
+	if !ok {
+		return
+	}
+	consent, err := s.service.GetConsent(c.Request.Context(), userID)
+	if err != nil {
+		s.handleError(c, err, "获取记忆提取授权失败")
+		return
+	}
+	httpUtils.Response.Success(c, v1.MemoryConsentInfo{
+		UserID:    consent.UserID,
+		Granted:   consent.Granted,
+		UpdatedAt: consent.UpdatedAt,
+	}, "获取记忆提取授权成功")
+}
+
+// setConsent 设置记忆提取授权
+// @Summary 设置记忆提取授权
+// @Description 授权后从该用户的对话中提取长期记忆；撤销授权时可同时删除已有记忆
+// @Tags Memory
+// @Accept json
+// @Produce json
+// @Param id path int true "用户ID"
+// @Param request body v1.MemoryConsentRequest true "授权设置"
+// @Success 200 {object} httptransport.APIResponse{data=v1.MemoryConsentInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/users/{id}/memory-consent [put]
+func (s *MemoryServiceV1) setConsent(c *gin.Context) {
+	userID, ok := s.userID(c)
+	if !ok {
+		return
+	}
+	var request v1.MemoryConsentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	consent, deleted, err := s.service.SetConsent(c.Request.Context(), userID, *request.Granted, request.DeleteExisting)
+	if err != nil {
+		s.handleError(c, err, "设置记忆提取授权失败")
+		return
+	}
+	s.logger.InfoTag("API", "设置记忆提取授权", "user_id", userID, "granted", consent.Granted, "deleted", deleted, "request_id", getRequestID(c))
+	httpUtils.Response.Success(c, v1.MemoryConsentInfo{
+		UserID:    consent.UserID,
+		Granted:   consent.Granted,
+		UpdatedAt: consent.UpdatedAt,
+		Deleted:   deleted,
+	}, "记忆提取授权已更新")
+}
+
+func (s *MemoryServiceV1) userID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		httpUtils.Response.BadRequest(c, "无效的用户ID")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// handleError 将领域错误映射为API错误
+func (s *MemoryServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, member.ErrUserNotFound):
+		httpUtils.Response.NotFound(c, "用户")
+	case errors.Is(err, memory.ErrNotFound):
+		httpUtils.Response.NotFound(c, "记忆")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toMemoryFactInfo(f *memory.Fact) v1.MemoryFactInfo {
+	return v1.MemoryFactInfo{
+		ID:        f.ID,
+		Namespace: f.Namespace,
+		Content:   f.Content,
+		Source:    string(f.Source),
+		DeviceID:  f.DeviceID,
+		SessionID: f.SessionID,
+		EntryID:   f.EntryID,
+		Evidence:  f.Evidence,
+		CreatedAt: f.CreatedAt,
+		UpdatedAt: f.UpdatedAt,
+	}
+}
@@ -0,0 +1,281 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/monitor"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// monitorHeartbeatTime 旁听事件流的心跳间隔，避免代理断开空闲连接
+const monitorHeartbeatTime = 15 * time.Second
+
+// MonitorServiceV1 V1版本在线会话监控服务，供管理后台的实时视图使用
+type MonitorServiceV1 struct {
+	logger  *logging.Logger
+	service *monitor.Service
+}
+
+// NewMonitorServiceV1 创建在线会话监控服务V1实例
+func NewMonitorServiceV1(logger *logging.Logger, service *monitor.Service) (*MonitorServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("monitor service is required")
+	}
+	return &MonitorServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册在线会话监控API路由
+func (s *MonitorServiceV1) Register(router *gin.RouterGroup) {
+	router.GET("/monitor/sessions", s.listSessions)            // 列出在线会话
+	router.GET("/monitor/sessions/:id", s.getSession)          // 获取会话状态
+	router.DELETE("/monitor/sessions/:id", s.terminate)        // 终止会话
+	router.PUT("/monitor/sessions/:id/flag", s.flag)           // 标记旁听
+	router.DELETE("/monitor/sessions/:id/flag", s.unflag)      // 取消旁听
+	router.GET("/monitor/sessions/:id/events", s.streamEvents) // 旁听会话的实时事件
+}
+
+// listSessions 列出在线会话
+// @Summary 列出在线会话
+// @Description 返回在线设备会话的对话状态、最近的对话片段和当前使用的模型与音色，最近连接的在前
+// @Tags Monitor
+// @Produce json
+// @Param tenant_id query string false "租户ID"
+// @Param device_id query string false "设备ID"
+// @Param state query string false "对话状态" Enums(idle,listening,thinking,speaking)
+// @Param flagged query bool false "只返回已标记旁听的会话"
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.MonitorSession}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/monitor/sessions [get]
+func (s *MonitorServiceV1) listSessions(c *gin.Context) {
+	var query v1.MonitorSessionQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	sessions := s.service.List(monitor.Filter{
+		TenantID: query.TenantID,
+		DeviceID: query.DeviceID,
+		State:    query.State,
+		Flagged:  query.Flagged,
+	})
+	result := make([]v1.MonitorSession, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, toMonitorSession(session))
+	}
+	httpUtils.Response.Success(c, result, "获取在线会话成功")
+}
+
+// getSession 获取会话状态
+// @Summary 获取在线会话状态
+// @Tags Monitor
+// @Produce json
+// @Param id path string true "会话ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.MonitorSession}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/monitor/sessions/{id} [get]
+func (s *MonitorServiceV1) getSession(c *gin.Context) {
+	session, err := s.service.Get(c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取会话状态失败")
+		return
+	}
+	httpUtils.Response.Success(c, toMonitorSession(session), "获取会话状态成功")
+}
+
+// terminate 终止会话
+// @Summary 终止在线会话
+// @Description 断开会话的设备连接，正在旁听的事件流随之结束
+// @Tags Monitor
+// @Produce json
+// @Param id path string true "会话ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/monitor/sessions/{id} [delete]
+func (s *MonitorServiceV1) terminate(c *gin.Context) {
+	sessionID := c.Param("id")
+	if err := s.service.Terminate(sessionID); err != nil {
+		s.handleError(c, err, "终止会话失败")
+		return
+	}
+	s.logger.InfoTag("API", "终止会话", "session_id", sessionID, "request_id", getRequestID(c))
+	httpUtils.Response.Success(c, nil, "会话已终止")
+}
+
+// flag 标记旁听
+// @Summary 标记会话旁听
+// @Description 标记后可通过事件流实时旁听会话的状态变化和对话内容；已标记时更新备注，会话断开后标记自动清除
+// @Tags Monitor
+// @Accept json
+// @Produce json
+// @Param id path string true "会话ID"
+// @Param request body v1.MonitorFlagRequest false "标记备注"
+// @Success 200 {object} httptransport.APIResponse{data=v1.MonitorSession}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/monitor/sessions/{id}/flag [put]
+func (s *MonitorServiceV1) flag(c *gin.Context) {
+	var request v1.MonitorFlagRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			httpUtils.Response.ValidationError(c, err)
+			return
+		}
+	}
+
+	sessionID := c.Param("id")
+	session, err := s.service.Flag(sessionID, request.Note)
+	if err != nil {
+		s.handleError(c, err, "标记旁听失败")
+		return
+	}
+	s.logger.InfoTag("API", "标记旁听", "session_id", sessionID, "request_id", getRequestID(c))
+	httpUtils.Response.Success(c, toMonitorSession(session), "已标记旁听")
+}
+
+// unflag 取消旁听
+// @Summary 取消会话旁听
+// @Description 取消标记后正在旁听的事件流随之结束
+// @Tags Monitor
+// @Produce json
+// @Param id path string true "会话ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.MonitorSession}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/monitor/sessions/{id}/flag [delete]
+func (s *MonitorServiceV1) unflag(c *gin.Context) {
+	sessionID := c.Param("id")
+	session, err := s.service.Unflag(sessionID)
+	if err != nil {
+		s.handleError(c, err, "取消旁听失败")
+		return
+	}
+	s.logger.InfoTag("API", "取消旁听", "session_id", sessionID, "request_id", getRequestID(c))
+	httpUtils.Response.Success(c, toMonitorSession(session), "已取消旁听")
+}
+
+// streamEvents 以SSE推送旁听会话的实时事件
+// @Summary 旁听会话
+// @Description 以SSE推送已标记旁听的会话的事件，事件名为 state（对话状态变化）、snippet（新的对话片段）和 closed（会话断开，之后事件流结束）；连接时先推送一次 state 作为当前状态
+// @Tags Monitor
+// @Produce text/event-stream
+// @Param id path string true "会话ID"
+// @Success 200 {object} v1.MonitorEvent
+// @Failure 404 {object} httptransport.APIResponse
+// @Failure 409 {object} httptransport.APIResponse
+// @Router /v1/monitor/sessions/{id}/events [get]
+func (s *MonitorServiceV1) streamEvents(c *gin.Context) {
+	sessionID := c.Param("id")
+	events, cancel, err := s.service.Subscribe(sessionID)
+	if err != nil {
+		s.handleError(c, err, "旁听会话失败")
+		return
+	}
+	defer cancel()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	if session, err := s.service.Get(sessionID); err == nil {
+		c.SSEvent(string(monitor.EventState), v1.MonitorEvent{
+			Type:      string(monitor.EventState),
+			SessionID: sessionID,
+			State:     session.State,
+			Round:     session.Round,
+			At:        time.Now(),
+		})
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(monitorHeartbeatTime)
+	defer heartbeat.Stop()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case event, open := <-events:
+			if !open {
+				return false
+			}
+			c.SSEvent(string(event.Type), toMonitorEvent(event))
+			return event.Type != monitor.EventClosed
+		case <-heartbeat.C:
+			_, _ = io.WriteString(w, ": ping\n\n")
+			return true
+		}
+	})
+}
+
+// handleError 将领域错误映射为API错误
+func (s *MonitorServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, monitor.ErrNotFound):
+		httpUtils.Response.NotFound(c, "会话")
+	case errors.Is(err, monitor.ErrNotFlagged):
+		httpUtils.Response.Conflict(c, "会话未标记旁听")
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toMonitorSnippet(snippet monitor.Snippet) v1.MonitorSnippet {
+	return v1.MonitorSnippet{
+		Role:    snippet.Role,
+		Content: snippet.Content,
+		At:      snippet.At,
+	}
+}
+
+func toMonitorSession(session monitor.Session) v1.MonitorSession {
+	info := v1.MonitorSession{
+		SessionID:   session.SessionID,
+		DeviceID:    session.DeviceID,
+		TenantID:    session.TenantID,
+		State:       session.State,
+		Round:       session.Round,
+		LLM:         session.LLM,
+		TTS:         session.TTS,
+		Voice:       session.Voice,
+		ConnectedAt: session.ConnectedAt,
+		Recent:      make([]v1.MonitorSnippet, 0, len(session.Recent)),
+	}
+	for _, snippet := range session.Recent {
+		info.Recent = append(info.Recent, toMonitorSnippet(snippet))
+	}
+	if session.Flag != nil {
+		info.Flag = &v1.MonitorFlag{
+			Note:      session.Flag.Note,
+			FlaggedAt: session.Flag.FlaggedAt,
+		}
+	}
+	return info
+}
+
+func toMonitorEvent(event monitor.Event) v1.MonitorEvent {
+	info := v1.MonitorEvent{
+		Type:      string(event.Type),
+		SessionID: event.SessionID,
+		State:     event.State,
+		Round:     event.Round,
+		At:        event.At,
+	}
+	if event.Snippet != nil {
+		snippet := toMonitorSnippet(*event.Snippet)
+		info.Snippet = &snippet
+	}
+	return info
+}
@@ -0,0 +1,213 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/notification"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// NotificationServiceV1 V1版本通知渠道服务
+type NotificationServiceV1 struct {
+	logger  *logging.Logger
+	service *notification.Service
+}
+
+// NewNotificationServiceV1 创建通知渠道服务V1实例
+func NewNotificationServiceV1(logger *logging.Logger, service *notification.Service) (*NotificationServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("notification service is required")
+	}
+	return &NotificationServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册通知渠道API路由
+func (s *NotificationServiceV1) Register(router *gin.RouterGroup) {
+	channels := router.Group("/notifications/channels")
+	{
+		channels.POST("", s.createChannel)        // 创建渠道
+		channels.GET("", s.listChannels)          // 获取渠道列表
+		channels.GET("/:id", s.getChannel)        // 获取渠道详情
+		channels.PUT("/:id", s.updateChannel)     // 更新渠道
+		channels.DELETE("/:id", s.deleteChannel)  // 删除渠道
+		channels.POST("/:id/test", s.testChannel) // 发送测试通知
+	}
+}
+
+// createChannel 创建通知渠道
+// @Summary 创建通知渠道
+// @Description 创建邮件、Webhook、钉钉、飞书或 Telegram 通知渠道，可订阅插件异常、供应商不可用和配额用尽告警
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Param request body v1.NotificationChannelCreateRequest true "渠道信息"
+// @Success 201 {object} httptransport.APIResponse{data=v1.NotificationChannelInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/notifications/channels [post]
+func (s *NotificationServiceV1) createChannel(c *gin.Context) {
+	var request v1.NotificationChannelCreateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	enabled := true
+	if request.Enabled != nil {
+		enabled = *request.Enabled
+	}
+	channel, err := s.service.Create(c.Request.Context(), notification.CreateRequest{
+		Name:    request.Name,
+		Type:    notification.ChannelType(request.Type),
+		Config:  request.Config,
+		Events:  request.Events,
+		Enabled: enabled,
+	})
+	if err != nil {
+		s.handleError(c, err, "创建通知渠道失败")
+		return
+	}
+	httpUtils.Response.Created(c, toNotificationChannelInfo(channel), "通知渠道创建成功")
+}
+
+// listChannels 获取通知渠道列表
+// @Summary 获取通知渠道列表
+// @Tags Notifications
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.NotificationChannelInfo}
+// @Router /v1/notifications/channels [get]
+func (s *NotificationServiceV1) listChannels(c *gin.Context) {
+	items, err := s.service.List(c.Request.Context())
+	if err != nil {
+		s.handleError(c, err, "获取通知渠道列表失败")
+		return
+	}
+	channels := make([]v1.NotificationChannelInfo, 0, len(items))
+	for _, item := range items {
+		channels = append(channels, toNotificationChannelInfo(item))
+	}
+	httpUtils.Response.Success(c, channels, "获取通知渠道列表成功")
+}
+
+// getChannel 获取通知渠道详情
+// @Summary 获取通知渠道详情
+// @Tags Notifications
+// @Produce json
+// @Param id path string true "渠道ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.NotificationChannelInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/notifications/channels/{id} [get]
+func (s *NotificationServiceV1) getChannel(c *gin.Context) {
+	channel, err := s.service.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取通知渠道失败")
+		return
+	}
+	httpUtils.Response.Success(c, toNotificationChannelInfo(channel), "获取通知渠道成功")
+}
+
+// updateChannel 更新通知渠道
+// @Summary 更新通知渠道
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Param id path string true "渠道ID"
+// @Param request body v1.NotificationChannelUpdateRequest true "更新内容"
+// @Success 200 {object} httptransport.APIResponse{data=v1.NotificationChannelInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/notifications/channels/{id} [put]
+func (s *NotificationServiceV1) updateChannel(c *gin.Context) {
+	var request v1.NotificationChannelUpdateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	channel, err := s.service.Update(c.Request.Context(), c.Param("id"), notification.UpdateRequest{
+		Name:    request.Name,
+		Config:  request.Config,
+		Events:  request.Events,
+		Enabled: request.Enabled,
+	})
+	if err != nil {
+		s.handleError(c, err, "更新通知渠道失败")
+		return
+	}
+	httpUtils.Response.Success(c, toNotificationChannelInfo(channel), "通知渠道更新成功")
+}
+
+// deleteChannel 删除通知渠道
+// @Summary 删除通知渠道
+// @Tags Notifications
+// @Produce json
+// @Param id path string true "渠道ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/notifications/channels/{id} [delete]
+func (s *NotificationServiceV1) deleteChannel(c *gin.Context) {
+	if err := s.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		s.handleError(c, err, "删除通知渠道失败")
+		return
+	}
+	httpUtils.Response.Success(c, nil, "通知渠道已删除")
+}
+
+// testChannel 发送测试通知
+// @Summary 发送测试通知
+// @Description 向渠道发送一条测试通知，停用的渠道也会发送
+// @Tags Notifications
+// @Produce json
+// @Param id path string true "渠道ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Failure 500 {object} httptransport.APIResponse
+// @Router /v1/notifications/channels/{id}/test [post]
+func (s *NotificationServiceV1) testChannel(c *gin.Context) {
+	if err := s.service.Test(c.Request.Context(), c.Param("id")); err != nil {
+		if platformerrors.IsKind(err, platformerrors.KindTransport) {
+			httpUtils.Response.Error(c, "NOTIFICATION_FAILED", err.Error())
+			return
+		}
+		s.handleError(c, err, "发送测试通知失败")
+		return
+	}
+	httpUtils.Response.Success(c, nil, "测试通知已发送")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *NotificationServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, notification.ErrNotFound):
+		httpUtils.Response.NotFound(c, "通知渠道")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toNotificationChannelInfo(channel *notification.Channel) v1.NotificationChannelInfo {
+	return v1.NotificationChannelInfo{
+		ID:        channel.ID,
+		Name:      channel.Name,
+		Type:      string(channel.Type),
+		Config:    channel.MaskedConfig(),
+		Events:    channel.Events,
+		Enabled:   channel.Enabled,
+		CreatedAt: channel.CreatedAt,
+		UpdatedAt: channel.UpdatedAt,
+	}
+}
@@ -0,0 +1,300 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/objectstore"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// ObjectServiceV1 V1版本对象存储服务
+type ObjectServiceV1 struct {
+	logger  *logging.Logger
+	service *objectstore.Service
+}
+
+// NewObjectServiceV1 创建对象存储服务V1实例
+func NewObjectServiceV1(logger *logging.Logger, service *objectstore.Service) (*ObjectServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("object store service is required")
+	}
+	return &ObjectServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// RegisterPublic 注册签名下载路由，凭地址中的签名访问，不需要认证
+func (s *ObjectServiceV1) RegisterPublic(router *gin.RouterGroup) {
+	router.GET("/objects/:id/content", s.download)
+}
+
+// Register 注册对象管理路由，仅管理员可访问
+func (s *ObjectServiceV1) Register(router *gin.RouterGroup) {
+	objects := router.Group("/objects")
+	{
+		objects.GET("", s.listObjects)         // 获取对象列表
+		objects.POST("", s.uploadObject)       // 上传对象
+		objects.GET("/:id", s.getObject)       // 获取对象元数据
+		objects.GET("/:id/url", s.signURL)     // 签发下载地址
+		objects.DELETE("/:id", s.deleteObject) // 删除对象
+	}
+}
+
+// download 按签名地址下载对象
+// @Summary 下载对象
+// @Description 通过 url 接口、TTS 输出或 OTA 响应中签发的地址下载对象内容，地址过期或签名不符时返回 403。
+// @Description 内容按 SHA-256 寻址，ETag 即对象ID
+// @Tags Objects
+// @Produce octet-stream
+// @Param id path string true "对象ID"
+// @Param expires query int true "过期时间（Unix 秒）"
+// @Param signature query string true "签名"
+// @Success 200 {file} file
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/objects/{id}/content [get]
+func (s *ObjectServiceV1) download(c *gin.Context) {
+	id := c.Param("id")
+	if err := s.service.Verify(id, c.Query("expires"), c.Query("signature")); err != nil {
+		httpUtils.Response.Forbidden(c, err.Error())
+		return
+	}
+
+	etag := `"` + id + `"`
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	obj, rc, err := s.service.Open(c.Request.Context(), id)
+	if err != nil {
+		s.handleError(c, err, "读取对象失败")
+		return
+	}
+	defer rc.Close()
+
+	headers := map[string]string{
+		"ETag":          etag,
+		"Cache-Control": "private, max-age=3600",
+	}
+	if obj.Name != "" {
+		headers["Content-Disposition"] = fmt.Sprintf("attachment; filename=%q", obj.Name)
+	}
+	c.DataFromReader(http.StatusOK, obj.Size, obj.ContentType, rc, headers)
+}
+
+// listObjects 获取对象列表
+// @Summary 获取对象列表
+// @Description 按命名空间（tts、asr、ota、knowledge 等）和文件名查询对象，最近写入的在前
+// @Tags Objects
+// @Produce json
+// @Param namespace query string false "命名空间"
+// @Param name query string false "文件名"
+// @Param page query int false "页码" default(1)
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} httptransport.APIResponse{data=v1.ObjectListResponse}
+// @Failure 403 {object} httptransport.APIResponse
+// @Router /v1/objects [get]
+func (s *ObjectServiceV1) listObjects(c *gin.Context) {
+	var query v1.ObjectQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 20
+	}
+
+	items, total, err := s.service.List(c.Request.Context(), objectstore.Filter{
+		Namespace: query.Namespace,
+		Name:      query.Name,
+		Page:      query.Page,
+		PageSize:  query.Limit,
+	})
+	if err != nil {
+		s.handleError(c, err, "获取对象列表失败")
+		return
+	}
+	objects := make([]v1.ObjectInfo, 0, len(items))
+	for _, obj := range items {
+		objects = append(objects, toObjectInfo(obj))
+	}
+	httpUtils.Response.Success(c, v1.ObjectListResponse{
+		Objects:    objects,
+		Pagination: newPagination(query.Page, query.Limit, total),
+	}, "获取对象列表成功")
+}
+
+// uploadObject 上传对象
+// @Summary 上传对象
+// @Description 以 multipart 的 file 字段上传文件。OTA 固件上传到 ota 命名空间并以 <版本号>.bin 命名，设备检查更新时即可获取；
+// @Description 待识别的音频上传到 asr 命名空间后，把返回的对象ID作为 core.asr 的 audio_object 输入。
+// @Description ttl_seconds 缺省时使用命名空间的默认保留时长，命名空间未配置保留时长时作为长期对象保存
+// @Tags Objects
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "文件"
+// @Param namespace formData string true "命名空间"
+// @Param name formData string false "文件名，缺省使用上传的文件名"
+// @Param ttl_seconds formData int false "保留秒数"
+// @Success 201 {object} httptransport.APIResponse{data=v1.ObjectUploadResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Router /v1/objects [post]
+func (s *ObjectServiceV1) uploadObject(c *gin.Context) {
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		httpUtils.Response.BadRequest(c, "缺少 file 字段")
+		return
+	}
+	defer file.Close()
+	if limit := s.service.MaxObjectSize(); header.Size > limit {
+		httpUtils.Response.BadRequest(c, fmt.Sprintf("文件超过 %d MB", limit>>20))
+		return
+	}
+	var ttl time.Duration
+	if raw := c.PostForm("ttl_seconds"); raw != "" {
+		seconds, err := strconv.Atoi(raw)
+		if err != nil || seconds < 0 {
+			httpUtils.Response.BadRequest(c, "ttl_seconds 必须为非负整数")
+			return
+		}
+		ttl = time.Duration(seconds) * time.Second
+	}
+	name := c.PostForm("name")
+	if name == "" {
+		name = header.Filename
+	}
+
+	obj, err := s.service.Put(c.Request.Context(), c.PostForm("namespace"), file, objectstore.PutOptions{
+		Name:        name,
+		ContentType: header.Header.Get("Content-Type"),
+		TTL:         ttl,
+	})
+	if err != nil {
+		s.handleError(c, err, "上传对象失败")
+		return
+	}
+	url, _, err := s.service.URL(obj, 0)
+	if err != nil {
+		s.handleError(c, err, "签发下载地址失败")
+		return
+	}
+	s.logger.InfoTag("API", "上传对象", "object_id", obj.ID, "namespace", obj.Namespace, "size", obj.Size, "request_id", getRequestID(c))
+	httpUtils.Response.Created(c, v1.ObjectUploadResponse{Object: toObjectInfo(obj), URL: url}, "对象已上传")
+}
+
+// getObject 获取对象元数据
+// @Summary 获取对象元数据
+// @Tags Objects
+// @Produce json
+// @Param id path string true "对象ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ObjectInfo}
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/objects/{id} [get]
+func (s *ObjectServiceV1) getObject(c *gin.Context) {
+	obj, err := s.service.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取对象失败")
+		return
+	}
+	httpUtils.Response.Success(c, toObjectInfo(obj), "获取对象成功")
+}
+
+// signURL 签发下载地址
+// @Summary 签发对象下载地址
+// @Description 使用 S3 后端时返回存储的预签名地址，否则返回本服务的签名下载地址
+// @Tags Objects
+// @Produce json
+// @Param id path string true "对象ID"
+// @Param expires_in query int false "有效秒数，缺省使用 ObjectStore.URLExpiry"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ObjectURLResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/objects/{id}/url [get]
+func (s *ObjectServiceV1) signURL(c *gin.Context) {
+	var expires time.Duration
+	if raw := c.Query("expires_in"); raw != "" {
+		seconds, err := strconv.Atoi(raw)
+		if err != nil || seconds <= 0 {
+			httpUtils.Response.BadRequest(c, "expires_in 必须为正整数")
+			return
+		}
+		expires = time.Duration(seconds) * time.Second
+	}
+	obj, err := s.service.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取对象失败")
+		return
+	}
+	url, expiresAt, err := s.service.URL(obj, expires)
+	if err != nil {
+		s.handleError(c, err, "签发下载地址失败")
+		return
+	}
+	httpUtils.Response.Success(c, v1.ObjectURLResponse{ID: obj.ID, URL: url, ExpiresAt: expiresAt}, "签发下载地址成功")
+}
+
+// deleteObject 删除对象
+// @Summary 删除对象
+// @Description 强制删除对象，不考虑引用数；仍被知识库文档引用的对象删除后，对应文档无法重新导入
+// @Tags Objects
+// @Produce json
+// @Param id path string true "对象ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/objects/{id} [delete]
+func (s *ObjectServiceV1) deleteObject(c *gin.Context) {
+	if err := s.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		s.handleError(c, err, "删除对象失败")
+		return
+	}
+	s.logger.InfoTag("API", "删除对象", "object_id", c.Param("id"), "request_id", getRequestID(c))
+	httpUtils.Response.Success(c, nil, "对象已删除")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *ObjectServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, objectstore.ErrNotFound):
+		httpUtils.Response.NotFound(c, "对象")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toObjectInfo(obj *objectstore.Object) v1.ObjectInfo {
+	return v1.ObjectInfo{
+		ID:          obj.ID,
+		Namespace:   obj.Namespace,
+		Name:        obj.Name,
+		ContentType: obj.ContentType,
+		Size:        obj.Size,
+		Refs:        obj.Refs,
+		ExpiresAt:   obj.ExpiresAt,
+		CreatedAt:   obj.CreatedAt,
+		UpdatedAt:   obj.UpdatedAt,
+	}
+}
@@ -0,0 +1,214 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+
+	pluginpb "xiaozhi-server-go/gen/go/api/proto"
+	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/plugin/grpc/client"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// pluginDebugTimeout 调用插件调试服务的超时时间
+const pluginDebugTimeout = 5 * time.Second
+
+// PluginDebugInfo 插件调试信息
+type PluginDebugInfo struct {
+	PluginID      string    `json:"plugin_id"`
+	Name          string    `json:"name"`
+	Version       string    `json:"version"`
+	Address       string    `json:"address"`
+	PID           int32     `json:"pid"`
+	GoVersion     string    `json:"go_version"`
+	StartedAt     time.Time `json:"started_at"`
+	UptimeSeconds int64     `json:"uptime_seconds"`
+	Services      []string  `json:"services"` // 已注册的gRPC服务全名
+	LogLevel      string    `json:"log_level"`
+}
+
+// PluginMethodMetrics 插件单个gRPC方法的调用统计
+type PluginMethodMetrics struct {
+	Method  string  `json:"method"`
+	Calls   int64   `json:"calls"`
+	Errors  int64   `json:"errors"`
+	TotalMs float64 `json:"total_ms"`
+	MaxMs   float64 `json:"max_ms"`
+}
+
+// PluginDebugMetrics 插件调用统计和Go运行时指标
+type PluginDebugMetrics struct {
+	PluginID string                `json:"plugin_id"`
+	Methods  []PluginMethodMetrics `json:"methods"`
+	Runtime  map[string]float64    `json:"runtime"` // goroutines、heap_alloc_bytes、heap_sys_bytes、gc_count、gc_pause_total_ms
+}
+
+// PluginLogLevelRequest 修改插件日志级别请求
+type PluginLogLevelRequest struct {
+	Level string `json:"level" binding:"required,oneof=debug info warn error"`
+}
+
+// PluginLogLevelResponse 修改插件日志级别响应
+type PluginLogLevelResponse struct {
+	PluginID      string `json:"plugin_id"`
+	PreviousLevel string `json:"previous_level"`
+	Level         string `json:"level"`
+}
+
+// GetPluginDebugInfo 获取插件调试信息
+// @Summary 获取插件调试信息
+// @Description 通过插件调试服务获取插件信息、进程信息和已注册的gRPC服务，需启用 PluginGRPC.Debug
+// @Tags plugins
+// @Param id path string true "插件ID"
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=PluginDebugInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Failure 409 {object} httptransport.APIResponse
+// @Router /v1/plugins/{id}/debug [get]
+func (c *PluginListController) GetPluginDebugInfo(ctx *gin.Context) {
+	pluginID := ctx.Param("id")
+	c.withPluginDebug(ctx, pluginID, func(rpcCtx context.Context, debug pluginpb.PluginDebugServiceClient) error {
+		resp, err := debug.GetInfo(rpcCtx, &pluginpb.GetDebugInfoRequest{})
+		if err != nil {
+			return err
+		}
+		info := PluginDebugInfo{
+			PluginID:      pluginID,
+			Name:          resp.GetPluginInfo().GetName(),
+			Version:       resp.GetPluginInfo().GetVersion(),
+			Address:       resp.GetAddress(),
+			PID:           resp.GetPid(),
+			GoVersion:     resp.GetGoVersion(),
+			StartedAt:     resp.GetStartedAt().AsTime(),
+			UptimeSeconds: resp.GetUptimeSeconds(),
+			Services:      resp.GetServices(),
+			LogLevel:      resp.GetLogLevel(),
+		}
+		httpUtils.Response.Success(ctx, info, "获取插件调试信息成功")
+		return nil
+	})
+}
+
+// GetPluginDebugMetrics 获取插件调用统计
+// @Summary 获取插件调用统计
+// @Description 通过插件调试服务获取插件各gRPC方法的调用次数、错误数和耗时，以及Go运行时指标，需启用 PluginGRPC.Debug
+// @Tags plugins
+// @Param id path string true "插件ID"
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=PluginDebugMetrics}
+// @Failure 404 {object} httptransport.APIResponse
+// @Failure 409 {object} httptransport.APIResponse
+// @Router /v1/plugins/{id}/debug/metrics [get]
+func (c *PluginListController) GetPluginDebugMetrics(ctx *gin.Context) {
+	pluginID := ctx.Param("id")
+	c.withPluginDebug(ctx, pluginID, func(rpcCtx context.Context, debug pluginpb.PluginDebugServiceClient) error {
+		resp, err := debug.DumpMetrics(rpcCtx, &pluginpb.DumpMetricsRequest{})
+		if err != nil {
+			return err
+		}
+		metrics := PluginDebugMetrics{
+			PluginID: pluginID,
+			Methods:  make([]PluginMethodMetrics, 0, len(resp.GetMethods())),
+			Runtime:  resp.GetRuntime(),
+		}
+		for _, m := range resp.GetMethods() {
+			metrics.Methods = append(metrics.Methods, PluginMethodMetrics{
+				Method:  m.GetMethod(),
+				Calls:   m.GetCalls(),
+				Errors:  m.GetErrors(),
+				TotalMs: m.GetTotalMs(),
+				MaxMs:   m.GetMaxMs(),
+			})
+		}
+		httpUtils.Response.Success(ctx, metrics, "获取插件调用统计成功")
+		return nil
+	})
+}
+
+// SetPluginLogLevel 修改插件日志级别
+// @Summary 修改插件日志级别
+// @Description 通过插件调试服务修改插件日志级别，需启用 PluginGRPC.Debug；与核心在同一进程中运行的插件共用核心日志，修改对整个进程生效
+// @Tags plugins
+// @Param id path string true "插件ID"
+// @Param body body PluginLogLevelRequest true "日志级别"
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=PluginLogLevelResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Failure 409 {object} httptransport.APIResponse
+// @Router /v1/plugins/{id}/debug/log-level [put]
+func (c *PluginListController) SetPluginLogLevel(ctx *gin.Context) {
+	pluginID := ctx.Param("id")
+	var request PluginLogLevelRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(ctx, err)
+		return
+	}
+	c.withPluginDebug(ctx, pluginID, func(rpcCtx context.Context, debug pluginpb.PluginDebugServiceClient) error {
+		resp, err := debug.SetLogLevel(rpcCtx, &pluginpb.SetLogLevelRequest{Level: request.Level})
+		if err != nil {
+			return err
+		}
+		if c.logger != nil {
+			c.logger.InfoTag("plugin_debug", "插件日志级别已修改",
+				"plugin_id", pluginID,
+				"previous_level", resp.GetPreviousLevel(),
+				"level", resp.GetLevel(),
+				"request_id", GetRequestID(ctx))
+		}
+		httpUtils.Response.Success(ctx, PluginLogLevelResponse{
+			PluginID:      pluginID,
+			PreviousLevel: resp.GetPreviousLevel(),
+			Level:         resp.GetLevel(),
+		}, "插件日志级别已修改")
+		return nil
+	})
+}
+
+// withPluginDebug 连接插件的调试服务并调用 fn，统一处理插件不存在、未启用调试和调用失败
+func (c *PluginListController) withPluginDebug(ctx *gin.Context, pluginID string, fn func(context.Context, pluginpb.PluginDebugServiceClient) error) {
+	var address string
+	if c.registry != nil {
+		if provider, ok := c.registry.GetProvider(pluginID); ok {
+			if grpcProvider, ok := provider.(capability.GRPCProvider); ok {
+				address = grpcProvider.GetServiceAddress()
+			}
+		}
+	}
+	if address == "" {
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeResourceNotFound, "插件不存在或未运行gRPC服务")
+		return
+	}
+
+	conn, err := client.Dial(pluginID, address)
+	if err != nil {
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeInternalServer, "连接插件失败: "+err.Error())
+		return
+	}
+	defer conn.Close()
+
+	rpcCtx, cancel := context.WithTimeout(ctx.Request.Context(), pluginDebugTimeout)
+	defer cancel()
+	if err := fn(rpcCtx, pluginpb.NewPluginDebugServiceClient(conn)); err != nil {
+		if c.logger != nil {
+			c.logger.WarnTag("plugin_debug", "调用插件调试服务失败",
+				"plugin_id", pluginID,
+				"error", err.Error(),
+				"request_id", GetRequestID(ctx))
+		}
+		switch grpcstatus.Code(err) {
+		case codes.Unimplemented:
+			httpUtils.Response.Error(ctx, httpUtils.ErrorCodeConflict, "插件未启用调试服务，请开启 PluginGRPC.Debug")
+		case codes.InvalidArgument:
+			httpUtils.Response.Error(ctx, httpUtils.ErrorCodeValidationFailed, grpcstatus.Convert(err).Message())
+		case codes.DeadlineExceeded:
+			httpUtils.Response.Error(ctx, httpUtils.ErrorCodeTimeout, "调用插件调试服务超时")
+		default:
+			httpUtils.Response.Error(ctx, httpUtils.ErrorCodeInternalServer, "调用插件调试服务失败: "+grpcstatus.Convert(err).Message())
+		}
+	}
+}
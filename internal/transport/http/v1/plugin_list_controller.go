@@ -1,33 +1,22 @@
 package v1
 
 import (
-	"net/http"
+	"io"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"xiaozhi-server-go/internal/platform/httpclient"
 	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/plugin/logs"
+	"xiaozhi-server-go/internal/plugin/ports"
 	"xiaozhi-server-go/internal/plugin/status"
+	"xiaozhi-server-go/internal/transport/http/middleware"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
 )
 
-// API 错误代码常量
-const (
-	ValidationFailed     = "VALIDATION_FAILED"
-	InternalServerError  = "INTERNAL_SERVER_ERROR"
-	ResourceNotFound     = "RESOURCE_NOT_FOUND"
-)
-
-// APIResponse 标准API响应结构
-type APIResponse struct {
-	Success   bool        `json:"success"`
-	Data      interface{} `json:"data,omitempty"`
-	Message   string      `json:"message,omitempty"`
-	Error     *APIError   `json:"error,omitempty"`
-	Timestamp int64       `json:"timestamp"`
-	Version   string      `json:"version"`
-	RequestID string      `json:"request_id,omitempty"`
-}
-
 // PluginListResponse 插件列表响应结构
 type PluginListResponse struct {
 	Total      int           `json:"total"`
@@ -119,28 +108,29 @@ type PortStats struct {
 	AllocatedPorts int     `json:"allocated_ports"`
 	AvailablePorts int     `json:"available_ports"`
 	ReservedPorts  int     `json:"reserved_ports"`
+	OrphanedPorts  int     `json:"orphaned_ports"`
 	UsagePercent   float64 `json:"usage_percent"`
 }
 
-// APIError API错误结构
-type APIError struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+// PortAllocationsResponse 端口分配情况响应结构
+type PortAllocationsResponse struct {
+	Stats       ports.PortStats        `json:"stats"`
+	Allocations []ports.PortAllocation `json:"allocations"`
+	History     []ports.PortEvent      `json:"history"`
 }
 
-// GetRequestID 获取请求ID
+// GetRequestID 获取请求ID，与响应中的 request_id 一致
 func GetRequestID(ctx *gin.Context) string {
-	if requestID := ctx.GetHeader("X-Request-ID"); requestID != "" {
-		return requestID
-	}
-	// 如果没有请求ID，返回空字符串，避免为每个请求生成唯一ID的开销
-	return ""
+	return getRequestID(ctx)
 }
 
 // PluginListController 插件列表API控制器
 type PluginListController struct {
 	logger         *logging.Logger
 	statusManager  *status.PluginStatusManager
+	registry       *capability.Registry
+	portManager    *ports.PortManager
+	logs           *logs.Manager
 }
 
 // NewPluginListController 创建插件列表控制器
@@ -158,6 +148,21 @@ func NewPluginListController(
 	}
 }
 
+// SetRegistry 设置能力注册表，用于查询执行队列统计
+func (c *PluginListController) SetRegistry(registry *capability.Registry) {
+	c.registry = registry
+}
+
+// SetPortManager 设置端口管理器，用于查询端口分配情况和历史
+func (c *PluginListController) SetPortManager(portManager *ports.PortManager) {
+	c.portManager = portManager
+}
+
+// SetLogManager 设置插件日志采集管理器，用于查询和跟踪插件日志
+func (c *PluginListController) SetLogManager(manager *logs.Manager) {
+	c.logs = manager
+}
+
 // Register 注册路由
 func (c *PluginListController) Register(router *gin.RouterGroup) {
 	plugins := router.Group("/plugins")
@@ -165,10 +170,16 @@ func (c *PluginListController) Register(router *gin.RouterGroup) {
 		plugins.GET("/", c.ListPlugins)
 		plugins.GET("/stats", c.GetPluginStats)
 		plugins.GET("/ports", c.GetPortStats)
+		plugins.GET("/queue", c.GetQueueStats)
+		plugins.GET("/connections", c.GetConnectionStats)
 		plugins.GET("/:id", c.GetPlugin)
-	plugins.POST("/:id/control", c.ControlPlugin)
+		plugins.GET("/:id/logs", c.GetPluginLogs)
+		plugins.POST("/:id/control", c.ControlPlugin)
 		plugins.POST("/:id/health", c.CheckPluginHealth)
 		plugins.POST("/:id/reallocate-port", c.ReallocatePort)
+		plugins.GET("/:id/debug", c.GetPluginDebugInfo)
+		plugins.GET("/:id/debug/metrics", c.GetPluginDebugMetrics)
+		plugins.PUT("/:id/debug/log-level", c.SetPluginLogLevel)
 		plugins.GET("/capabilities", c.GetCapabilities)
 		plugins.GET("/capabilities/:type", c.GetCapabilitiesByType)
 	}
@@ -187,37 +198,18 @@ func (c *PluginListController) Register(router *gin.RouterGroup) {
 // @Param sort_order query string false "排序方向" Enums(asc,desc) default(desc)
 // @Param search query string false "搜索关键词"
 // @Produce json
-// @Success 200 {object} APIResponse{data=PluginListResponse}
+// @Success 200 {object} httptransport.APIResponse{data=PluginListResponse}
 // @Router /v1/plugins [get]
 func (c *PluginListController) ListPlugins(ctx *gin.Context) {
 	// 解析查询参数
 	filter := status.DefaultPluginFilter()
-	if err := ctx.ShouldBindQuery(&filter); err != nil {
-		ctx.JSON(http.StatusBadRequest, APIResponse{
-			Success: false,
-			Error: &APIError{
-				Code:    ValidationFailed,
-				Message: "请求参数验证失败: " + err.Error(),
-			},
-			Timestamp: time.Now().Unix(),
-			Version:   "v1",
-			RequestID: GetRequestID(ctx),
-		})
+	if !middleware.BindQuery(ctx, &filter) {
 		return
 	}
 
 	// 验证筛选条件
 	if err := filter.Validate(); err != nil {
-		ctx.JSON(http.StatusBadRequest, APIResponse{
-			Success: false,
-			Error: &APIError{
-				Code:    ValidationFailed,
-				Message: "筛选条件验证失败: " + err.Error(),
-			},
-			Timestamp: time.Now().Unix(),
-			Version:   "v1",
-			RequestID: GetRequestID(ctx),
-		})
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeValidationFailed, "筛选条件验证失败: "+err.Error())
 		return
 	}
 
@@ -228,16 +220,7 @@ func (c *PluginListController) ListPlugins(ctx *gin.Context) {
 			"error", err.Error(),
 			"request_id", GetRequestID(ctx))
 
-		ctx.JSON(http.StatusInternalServerError, APIResponse{
-			Success: false,
-			Error: &APIError{
-				Code:    InternalServerError,
-				Message: "获取插件列表失败: " + err.Error(),
-			},
-			Timestamp: time.Now().Unix(),
-			Version:   "v1",
-			RequestID: GetRequestID(ctx),
-		})
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeInternalServer, "获取插件列表失败: "+err.Error())
 		return
 	}
 
@@ -254,14 +237,7 @@ func (c *PluginListController) ListPlugins(ctx *gin.Context) {
 			"request_id", GetRequestID(ctx))
 	}
 
-	ctx.JSON(http.StatusOK, APIResponse{
-		Success:   true,
-		Data:      response,
-		Message:   "获取插件列表成功",
-		Timestamp: time.Now().Unix(),
-		Version:   "v1",
-		RequestID: GetRequestID(ctx),
-	})
+	httpUtils.Response.Success(ctx, response, "获取插件列表成功")
 }
 
 // GetPluginStats 获取插件统计信息
@@ -269,7 +245,7 @@ func (c *PluginListController) ListPlugins(ctx *gin.Context) {
 // @Description 获取插件的数量、状态分布、健康状态等统计信息
 // @Tags plugins
 // @Produce json
-// @Success 200 {object} APIResponse{data=PluginStats}
+// @Success 200 {object} httptransport.APIResponse{data=PluginStats}
 // @Router /v1/plugins/stats [get]
 func (c *PluginListController) GetPluginStats(ctx *gin.Context) {
 	stats := c.statusManager.GetStats()
@@ -281,32 +257,47 @@ func (c *PluginListController) GetPluginStats(ctx *gin.Context) {
 			"request_id", GetRequestID(ctx))
 	}
 
-	ctx.JSON(http.StatusOK, APIResponse{
-		Success:   true,
-		Data:      stats,
-		Message:   "获取插件统计信息成功",
-		Timestamp: time.Now().Unix(),
-		Version:   "v1",
-		RequestID: GetRequestID(ctx),
-	})
+	httpUtils.Response.Success(ctx, stats, "获取插件统计信息成功")
 }
 
 // GetPortStats 获取端口统计信息
 // @Summary 获取端口统计信息
-// @Description 获取端口使用情况统计
+// @Description 获取端口使用情况统计、当前分配记录和分配历史（含重启后回收、孤儿端口等事件）
 // @Tags plugins
+// @Param plugin_id query string false "按插件ID筛选历史"
+// @Param port query int false "按端口筛选历史"
+// @Param limit query int false "历史条数" default(100)
 // @Produce json
-// @Success 200 {object} APIResponse{data=PortStats}
+// @Success 200 {object} httptransport.APIResponse{data=PortAllocationsResponse}
+// @Failure 404 {object} httptransport.APIResponse
 // @Router /v1/plugins/ports [get]
 func (c *PluginListController) GetPortStats(ctx *gin.Context) {
-	// 这里需要访问PortManager，需要扩展StatusManager
-	// 暂时返回模拟数据
-	stats := map[string]interface{}{
-		"total_ports":     10000,
-		"allocated_ports": 5,
-		"available_ports": 9995,
-		"reserved_ports":  0,
-		"usage_percent":   0.05,
+	if c.portManager == nil {
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeResourceNotFound, "端口管理器未初始化")
+		return
+	}
+
+	filter := ports.PortEventFilter{
+		PluginID: ctx.Query("plugin_id"),
+		Limit:    100,
+	}
+	if port, err := strconv.Atoi(ctx.Query("port")); err == nil && port > 0 {
+		filter.Port = port
+	}
+	if limit, err := strconv.Atoi(ctx.Query("limit")); err == nil && limit > 0 && limit <= 1000 {
+		filter.Limit = limit
+	}
+
+	history, err := c.portManager.GetHistory(ctx.Request.Context(), filter)
+	if err != nil {
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeInternalServer, "查询端口分配历史失败: "+err.Error())
+		return
+	}
+
+	stats := PortAllocationsResponse{
+		Stats:       c.portManager.GetStats(),
+		Allocations: c.portManager.GetAllocations(),
+		History:     history,
 	}
 
 	if c.logger != nil {
@@ -314,13 +305,146 @@ func (c *PluginListController) GetPortStats(ctx *gin.Context) {
 			"request_id", GetRequestID(ctx))
 	}
 
-	ctx.JSON(http.StatusOK, APIResponse{
-		Success:   true,
-		Data:      stats,
-		Message:   "获取端口统计信息成功",
-		Timestamp: time.Now().Unix(),
-		Version:   "v1",
-		RequestID: GetRequestID(ctx),
+	httpUtils.Response.Success(ctx, stats, "获取端口统计信息成功")
+}
+
+// GetQueueStats 获取能力执行队列统计
+// @Summary 获取能力执行队列统计
+// @Description 获取各优先级（interactive、api、batch）的排队数、放行数、挤出数、拒绝数和等待时间
+// @Tags plugins
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=capability.QueueStats}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/plugins/queue [get]
+func (c *PluginListController) GetQueueStats(ctx *gin.Context) {
+	var stats capability.QueueStats
+	ok := false
+	if c.registry != nil {
+		stats, ok = c.registry.QueueStats()
+	}
+	if !ok {
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeResourceNotFound, "能力执行队列未启用")
+		return
+	}
+
+	httpUtils.Response.Success(ctx, stats, "获取执行队列统计成功")
+}
+
+// GetConnectionStats 获取提供者HTTP连接池统计
+// @Summary 获取提供者HTTP连接池统计
+// @Description 获取各提供者的请求数、连接复用数、新建连接数、TLS握手次数和HTTP/2请求数
+// @Tags plugins
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=[]httpclient.ProviderStats}
+// @Router /v1/plugins/connections [get]
+func (c *PluginListController) GetConnectionStats(ctx *gin.Context) {
+	httpUtils.Response.Success(ctx, httpclient.Default().Stats(), "获取连接池统计成功")
+}
+
+// PluginLogsResponse 插件日志响应结构
+type PluginLogsResponse struct {
+	PluginID string       `json:"plugin_id"`
+	Total    int          `json:"total"` // 缓冲区中的日志行数
+	Entries  []logs.Entry `json:"entries"`
+}
+
+const (
+	defaultLogTail   = 500
+	logHeartbeatTime = 15 * time.Second
+)
+
+// GetPluginLogs 获取插件日志
+// @Summary 获取插件日志
+// @Description 返回插件 stdout/stderr 的最近日志；follow=true 时以 SSE 持续推送新日志（事件名 log）
+// @Tags plugins
+// @Param id path string true "插件ID"
+// @Param tail query int false "返回最近的日志行数" default(500)
+// @Param level query string false "最低日志级别" Enums(debug,info,warn,error)
+// @Param follow query bool false "是否以SSE持续推送"
+// @Produce json
+// @Produce text/event-stream
+// @Success 200 {object} httptransport.APIResponse{data=PluginLogsResponse}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/plugins/{id}/logs [get]
+func (c *PluginListController) GetPluginLogs(ctx *gin.Context) {
+	pluginID := ctx.Param("id")
+	var buffer *logs.Buffer
+	ok := false
+	if c.logs != nil {
+		buffer, ok = c.logs.Lookup(pluginID)
+	}
+	if !ok {
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeResourceNotFound, "插件没有可用的日志")
+		return
+	}
+
+	tail := defaultLogTail
+	if n, err := strconv.Atoi(ctx.Query("tail")); err == nil && n > 0 {
+		tail = n
+	}
+	minLevel := logs.LevelRank(ctx.DefaultQuery("level", logs.LevelDebug))
+	match := func(entry logs.Entry) bool {
+		return logs.LevelRank(entry.Level) >= minLevel
+	}
+
+	// 先订阅再读取历史，避免两者之间的日志丢失；按序号去重
+	var updates <-chan logs.Entry
+	follow := ctx.Query("follow") == "true"
+	if follow {
+		var cancel func()
+		updates, cancel = buffer.Subscribe()
+		defer cancel()
+	}
+
+	history := buffer.Tail(tail)
+	entries := make([]logs.Entry, 0, len(history))
+	for _, entry := range history {
+		if match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+
+	if !follow {
+		httpUtils.Response.Success(ctx, PluginLogsResponse{
+				PluginID: pluginID,
+				Total:    buffer.Len(),
+				Entries:  entries,
+			}, "获取插件日志成功")
+		return
+	}
+
+	var lastSeq uint64
+	if len(history) > 0 {
+		lastSeq = history[len(history)-1].Seq
+	}
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	for _, entry := range entries {
+		ctx.SSEvent("log", entry)
+	}
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(logHeartbeatTime)
+	defer heartbeat.Stop()
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case entry, open := <-updates:
+			if !open {
+				return false
+			}
+			if entry.Seq > lastSeq && match(entry) {
+				ctx.SSEvent("log", entry)
+			}
+			return true
+		case <-heartbeat.C:
+			_, _ = io.WriteString(w, ": ping\n\n")
+			return true
+		}
 	})
 }
 
@@ -330,22 +454,13 @@ func (c *PluginListController) GetPortStats(ctx *gin.Context) {
 // @Tags plugins
 // @Param id path string true "插件ID"
 // @Produce json
-// @Success 200 {object} APIResponse{data=PluginStatus}
-// @Failure 404 {object} APIResponse
+// @Success 200 {object} httptransport.APIResponse{data=PluginStatus}
+// @Failure 404 {object} httptransport.APIResponse
 // @Router /v1/plugins/{id} [get]
 func (c *PluginListController) GetPlugin(ctx *gin.Context) {
 	pluginID := ctx.Param("id")
 	if pluginID == "" {
-		ctx.JSON(http.StatusBadRequest, APIResponse{
-			Success: false,
-			Error: &APIError{
-				Code:    ValidationFailed,
-				Message: "插件ID不能为空",
-			},
-			Timestamp: time.Now().Unix(),
-			Version:   "v1",
-			RequestID: GetRequestID(ctx),
-		})
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeValidationFailed, "插件ID不能为空")
 		return
 	}
 
@@ -358,16 +473,7 @@ func (c *PluginListController) GetPlugin(ctx *gin.Context) {
 				"request_id", GetRequestID(ctx))
 		}
 
-		ctx.JSON(http.StatusNotFound, APIResponse{
-			Success:   false,
-			Error: &APIError{
-				Code:    ResourceNotFound,
-				Message: "插件不存在: " + err.Error(),
-			},
-			Timestamp: time.Now().Unix(),
-			Version:   "v1",
-			RequestID: GetRequestID(ctx),
-		})
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeResourceNotFound, "插件不存在: "+err.Error())
 		return
 	}
 
@@ -379,14 +485,7 @@ func (c *PluginListController) GetPlugin(ctx *gin.Context) {
 			"request_id", GetRequestID(ctx))
 	}
 
-	ctx.JSON(http.StatusOK, APIResponse{
-		Success:   true,
-		Data:      plugin,
-		Message:   "获取插件详情成功",
-		Timestamp: time.Now().Unix(),
-		Version:   "v1",
-		RequestID: GetRequestID(ctx),
-	})
+	httpUtils.Response.Success(ctx, plugin, "获取插件详情成功")
 }
 
 // ControlPlugin 控制插件
@@ -396,38 +495,19 @@ func (c *PluginListController) GetPlugin(ctx *gin.Context) {
 // @Param id path string true "插件ID"
 // @Param body body PluginControlRequest true "控制请求"
 // @Produce json
-// @Success 200 {object} APIResponse{data=PluginControlResponse}
-// @Failure 400 {object} APIResponse
-// @Failure 404 {object} APIResponse
+// @Success 200 {object} httptransport.APIResponse{data=PluginControlResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
 // @Router /v1/plugins/{id}/control [post]
 func (c *PluginListController) ControlPlugin(ctx *gin.Context) {
 	pluginID := ctx.Param("id")
 	if pluginID == "" {
-		ctx.JSON(http.StatusBadRequest, APIResponse{
-			Success: false,
-			Error: &APIError{
-				Code:    ValidationFailed,
-				Message: "插件ID不能为空",
-			},
-			Timestamp: time.Now().Unix(),
-			Version:   "v1",
-			RequestID: GetRequestID(ctx),
-		})
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeValidationFailed, "插件ID不能为空")
 		return
 	}
 
 	var req status.PluginControlRequest
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, APIResponse{
-			Success: false,
-			Error: &APIError{
-				Code:    ValidationFailed,
-				Message: "请求体格式错误: " + err.Error(),
-			},
-			Timestamp: time.Now().Unix(),
-			Version:   "v1",
-			RequestID: GetRequestID(ctx),
-		})
+	if !middleware.BindJSON(ctx, &req) {
 		return
 	}
 
@@ -440,16 +520,7 @@ func (c *PluginListController) ControlPlugin(ctx *gin.Context) {
 	}
 
 	if !validActions[req.Action] {
-		ctx.JSON(http.StatusBadRequest, APIResponse{
-			Success: false,
-			Error: &APIError{
-				Code:    ValidationFailed,
-				Message: "不支持的操作类型: " + req.Action,
-			},
-			Timestamp: time.Now().Unix(),
-			Version:   "v1",
-			RequestID: GetRequestID(ctx),
-		})
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeValidationFailed, "不支持的操作类型: "+req.Action)
 		return
 	}
 
@@ -464,16 +535,7 @@ func (c *PluginListController) ControlPlugin(ctx *gin.Context) {
 				"request_id", GetRequestID(ctx))
 		}
 
-		ctx.JSON(http.StatusNotFound, APIResponse{
-			Success:   false,
-			Error: &APIError{
-				Code:    ResourceNotFound,
-				Message: "插件不存在: " + err.Error(),
-			},
-			Timestamp: time.Now().Unix(),
-			Version:   "v1",
-			RequestID: GetRequestID(ctx),
-		})
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeResourceNotFound, "插件不存在: "+err.Error())
 		return
 	}
 
@@ -517,16 +579,7 @@ func (c *PluginListController) ControlPlugin(ctx *gin.Context) {
 				"request_id", GetRequestID(ctx))
 		}
 
-		ctx.JSON(http.StatusInternalServerError, APIResponse{
-			Success:   false,
-			Error: &APIError{
-				Code:    InternalServerError,
-				Message: "插件控制失败: " + controlErr.Error(),
-			},
-			Timestamp: time.Now().Unix(),
-			Version:   "v1",
-			RequestID: GetRequestID(ctx),
-		})
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeInternalServer, "插件控制失败: "+controlErr.Error())
 		return
 	}
 
@@ -563,14 +616,7 @@ func (c *PluginListController) ControlPlugin(ctx *gin.Context) {
 			"request_id", GetRequestID(ctx))
 	}
 
-	ctx.JSON(http.StatusOK, APIResponse{
-		Success:   true,
-		Data:      response,
-		Message:   "插件控制操作完成",
-		Timestamp: time.Now().Unix(),
-		Version:   "v1",
-		RequestID: GetRequestID(ctx),
-	})
+	httpUtils.Response.Success(ctx, response, "插件控制操作完成")
 }
 
 // CheckPluginHealth 检查插件健康状态
@@ -579,22 +625,13 @@ func (c *PluginListController) ControlPlugin(ctx *gin.Context) {
 // @Tags plugins
 // @Param id path string true "插件ID"
 // @Produce json
-// @Success 200 {object} APIResponse
-// @Failure 404 {object} APIResponse
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
 // @Router /v1/plugins/{id}/health [post]
 func (c *PluginListController) CheckPluginHealth(ctx *gin.Context) {
 	pluginID := ctx.Param("id")
 	if pluginID == "" {
-		ctx.JSON(http.StatusBadRequest, APIResponse{
-			Success: false,
-			Error: &APIError{
-				Code:    ValidationFailed,
-				Message: "插件ID不能为空",
-			},
-			Timestamp: time.Now().Unix(),
-			Version:   "v1",
-			RequestID: GetRequestID(ctx),
-		})
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeValidationFailed, "插件ID不能为空")
 		return
 	}
 
@@ -607,16 +644,7 @@ func (c *PluginListController) CheckPluginHealth(ctx *gin.Context) {
 				"request_id", GetRequestID(ctx))
 		}
 
-		ctx.JSON(http.StatusNotFound, APIResponse{
-			Success:   false,
-			Error: &APIError{
-				Code:    ResourceNotFound,
-				Message: "插件不存在: " + err.Error(),
-			},
-			Timestamp: time.Now().Unix(),
-			Version:   "v1",
-			RequestID: GetRequestID(ctx),
-		})
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeResourceNotFound, "插件不存在: "+err.Error())
 		return
 	}
 
@@ -636,18 +664,11 @@ func (c *PluginListController) CheckPluginHealth(ctx *gin.Context) {
 			"request_id", GetRequestID(ctx))
 	}
 
-	ctx.JSON(http.StatusOK, APIResponse{
-		Success:   true,
-		Data: map[string]interface{}{
+	httpUtils.Response.Success(ctx, map[string]interface{}{
 			"plugin_id":      pluginID,
 			"health_status": plugin.HealthStatus,
 			"last_check":    plugin.LastHealthCheck.Format(time.RFC3339),
-		},
-		Message:   "健康检查完成",
-		Timestamp: time.Now().Unix(),
-		Version:   "v1",
-		RequestID: GetRequestID(ctx),
-	})
+		}, "健康检查完成")
 }
 
 // ReallocatePort 重新分配插件端口
@@ -656,22 +677,13 @@ func (c *PluginListController) CheckPluginHealth(ctx *gin.Context) {
 // @Tags plugins
 // @Param id path string true "插件ID"
 // @Produce json
-// @Success 200 {object} APIResponse
-// @Failure 404 {object} APIResponse
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
 // @Router /v1/plugins/{id}/reallocate-port [post]
 func (c *PluginListController) ReallocatePort(ctx *gin.Context) {
 	pluginID := ctx.Param("id")
 	if pluginID == "" {
-		ctx.JSON(http.StatusBadRequest, APIResponse{
-			Success: false,
-			Error: &APIError{
-				Code:    ValidationFailed,
-				Message: "插件ID不能为空",
-			},
-			Timestamp: time.Now().Unix(),
-			Version:   "v1",
-			RequestID: GetRequestID(ctx),
-		})
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeValidationFailed, "插件ID不能为空")
 		return
 	}
 
@@ -684,32 +696,14 @@ func (c *PluginListController) ReallocatePort(ctx *gin.Context) {
 				"request_id", GetRequestID(ctx))
 		}
 
-		ctx.JSON(http.StatusInternalServerError, APIResponse{
-			Success: false,
-			Error: &APIError{
-				Code:    InternalServerError,
-				Message: "重新分配端口失败: " + err.Error(),
-			},
-			Timestamp: time.Now().Unix(),
-			Version:   "v1",
-			RequestID: GetRequestID(ctx),
-		})
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeInternalServer, "重新分配端口失败: "+err.Error())
 		return
 	}
 
 	// 获取更新后的插件状态
 	plugin, err := c.statusManager.GetPluginStatus(pluginID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, APIResponse{
-			Success: false,
-			Error: &APIError{
-				Code:    InternalServerError,
-				Message: "获取更新后插件状态失败: " + err.Error(),
-			},
-			Timestamp: time.Now().Unix(),
-			Version:   "v1",
-			RequestID: GetRequestID(ctx),
-		})
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeInternalServer, "获取更新后插件状态失败: "+err.Error())
 		return
 	}
 
@@ -721,18 +715,11 @@ func (c *PluginListController) ReallocatePort(ctx *gin.Context) {
 			"request_id", GetRequestID(ctx))
 	}
 
-	ctx.JSON(http.StatusOK, APIResponse{
-		Success: true,
-		Data: map[string]interface{}{
+	httpUtils.Response.Success(ctx, map[string]interface{}{
 			"plugin_id": pluginID,
 			"port":      plugin.Port,
 			"address":   plugin.Address,
-		},
-		Message: "端口重新分配成功",
-		Timestamp: time.Now().Unix(),
-		Version:   "v1",
-		RequestID: GetRequestID(ctx),
-	})
+		}, "端口重新分配成功")
 }
 
 // GetCapabilities 获取所有插件能力
@@ -740,7 +727,7 @@ func (c *PluginListController) ReallocatePort(ctx *gin.Context) {
 // @Description 获取所有插件的能力定义
 // @Tags plugins
 // @Produce json
-// @Success 200 {object} APIResponse
+// @Success 200 {object} httptransport.APIResponse
 // @Router /v1/plugins/capabilities [get]
 func (c *PluginListController) GetCapabilities(ctx *gin.Context) {
 	// 获取所有插件
@@ -752,16 +739,7 @@ func (c *PluginListController) GetCapabilities(ctx *gin.Context) {
 				"request_id", GetRequestID(ctx))
 		}
 
-		ctx.JSON(http.StatusInternalServerError, APIResponse{
-			Success:   false,
-			Error: &APIError{
-				Code:    InternalServerError,
-				Message: "获取插件列表失败: " + err.Error(),
-			},
-			Timestamp: time.Now().Unix(),
-			Version:   "v1",
-			RequestID: GetRequestID(ctx),
-		})
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeInternalServer, "获取插件列表失败: "+err.Error())
 		return
 	}
 
@@ -783,14 +761,7 @@ func (c *PluginListController) GetCapabilities(ctx *gin.Context) {
 			"request_id", GetRequestID(ctx))
 	}
 
-	ctx.JSON(http.StatusOK, APIResponse{
-		Success:   true,
-		Data:      capabilities,
-		Message:   "获取插件能力列表成功",
-		Timestamp: time.Now().Unix(),
-		Version:   "v1",
-		RequestID: GetRequestID(ctx),
-	})
+	httpUtils.Response.Success(ctx, capabilities, "获取插件能力列表成功")
 }
 
 // GetCapabilitiesByType 按类型获取插件能力
@@ -799,21 +770,12 @@ func (c *PluginListController) GetCapabilities(ctx *gin.Context) {
 // @Tags plugins
 // @Param type path string true "能力类型"
 // @Produce json
-// @Success 200 {object} APIResponse
+// @Success 200 {object} httptransport.APIResponse
 // @Router /v1/plugins/capabilities/{type} [get]
 func (c *PluginListController) GetCapabilitiesByType(ctx *gin.Context) {
 	capabilityType := ctx.Param("type")
 	if capabilityType == "" {
-		ctx.JSON(http.StatusBadRequest, APIResponse{
-			Success: false,
-			Error: &APIError{
-				Code:    ValidationFailed,
-				Message: "能力类型不能为空",
-			},
-			Timestamp: time.Now().Unix(),
-			Version:   "v1",
-		RequestID: GetRequestID(ctx),
-		})
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeValidationFailed, "能力类型不能为空")
 		return
 	}
 
@@ -827,16 +789,7 @@ func (c *PluginListController) GetCapabilitiesByType(ctx *gin.Context) {
 				"request_id", GetRequestID(ctx))
 		}
 
-		ctx.JSON(http.StatusInternalServerError, APIResponse{
-			Success:   false,
-			Error: &APIError{
-				Code:    InternalServerError,
-				Message: "获取插件列表失败: " + err.Error(),
-			},
-			Timestamp: time.Now().Unix(),
-			Version:   "v1",
-			RequestID: GetRequestID(ctx),
-		})
+		httpUtils.Response.Error(ctx, httpUtils.ErrorCodeInternalServer, "获取插件列表失败: "+err.Error())
 		return
 	}
 
@@ -861,12 +814,5 @@ func (c *PluginListController) GetCapabilitiesByType(ctx *gin.Context) {
 			"request_id", GetRequestID(ctx))
 	}
 
-	ctx.JSON(http.StatusOK, APIResponse{
-		Success:   true,
-		Data:      capabilities,
-		Message:   "获取插件能力列表成功",
-		Timestamp: time.Now().Unix(),
-		Version:   "v1",
-		RequestID: GetRequestID(ctx),
-	})
+	httpUtils.Response.Success(ctx, capabilities, "获取插件能力列表成功")
 }
\ No newline at end of file
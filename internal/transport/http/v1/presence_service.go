@@ -0,0 +1,187 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/member"
+	"xiaozhi-server-go/internal/domain/presence"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// PresenceServiceV1 V1版本在家状态服务
+type PresenceServiceV1 struct {
+	logger  *logging.Logger
+	service *presence.Service
+}
+
+// NewPresenceServiceV1 创建在家状态服务V1实例
+func NewPresenceServiceV1(logger *logging.Logger, service *presence.Service) (*PresenceServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("presence service is required")
+	}
+	return &PresenceServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册在家状态API路由
+func (s *PresenceServiceV1) Register(router *gin.RouterGroup) {
+	router.GET("/presence", s.overview)             // 全部成员的在家状态
+	router.POST("/presence/router", s.routerReport) // 路由器推送在线设备
+	users := router.Group("/users/:id")
+	{
+		users.GET("/presence", s.getStatus)        // 获取成员的在家状态
+		users.POST("/presence/checkin", s.checkIn) // 配套App签到
+	}
+}
+
+// overview 全部成员的在家状态
+// @Summary 获取在家状态
+// @Description 返回参与在家判断的全部成员及在家成员的称呼
+// @Tags Presence
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=v1.PresenceOverview}
+// @Router /v1/presence [get]
+func (s *PresenceServiceV1) overview(c *gin.Context) {
+	statuses := s.service.Statuses()
+	overview := v1.PresenceOverview{
+		Home:    []string{},
+		Members: make([]v1.PresenceStatus, 0, len(statuses)),
+	}
+	for _, status := range statuses {
+		if status.State == presence.StateHome {
+			overview.AnyoneHome = true
+			overview.Home = append(overview.Home, status.Name)
+		}
+		overview.Members = append(overview.Members, toPresenceStatus(status))
+	}
+	httpUtils.Response.Success(c, overview, "获取在家状态成功")
+}
+
+// getStatus 获取成员的在家状态
+// @Summary 获取成员的在家状态
+// @Description 没有任何签到或路由器记录时状态为 unknown
+// @Tags Presence
+// @Produce json
+// @Param id path int true "用户ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.PresenceStatus}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/users/{id}/presence [get]
+func (s *PresenceServiceV1) getStatus(c *gin.Context) {
+	userID, ok := s.userID(c)
+	if !ok {
+		return
+	}
+	status, err := s.service.Status(c.Request.Context(), userID)
+	if err != nil {
+		s.handleError(c, err, "获取在家状态失败")
+		return
+	}
+	httpUtils.Response.Success(c, toPresenceStatus(status), "获取在家状态成功")
+}
+
+// checkIn 配套App签到
+// @Summary 配套App签到
+// @Description 上报到家（state=home）或离家（state=away），或上报位置按家的地理围栏判断；位置落在围栏边缘的定位精度范围内时保持原状态。状态变化时发布 presence:arrived 或 presence:left 事件
+// @Tags Presence
+// @Accept json
+// @Produce json
+// @Param id path int true "用户ID"
+// @Param request body v1.PresenceCheckInRequest true "签到内容"
+// @Success 200 {object} httptransport.APIResponse{data=v1.PresenceStatus}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/users/{id}/presence/checkin [post]
+func (s *PresenceServiceV1) checkIn(c *gin.Context) {
+	userID, ok := s.userID(c)
+	if !ok {
+		return
+	}
+	var request v1.PresenceCheckInRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	status, err := s.service.CheckIn(c.Request.Context(), userID, presence.CheckIn{
+		State:     presence.State(request.State),
+		Latitude:  request.Latitude,
+		Longitude: request.Longitude,
+		Accuracy:  request.Accuracy,
+	})
+	if err != nil {
+		s.handleError(c, err, "签到失败")
+		return
+	}
+	httpUtils.Response.Success(c, toPresenceStatus(status), "签到成功")
+}
+
+// routerReport 路由器推送在线设备
+// @Summary 路由器推送在线设备
+// @Description 路由器上报当前在线设备的MAC地址，成员的设备上线即判定到家，连续 AwayDelay 未上报才判定离家
+// @Tags Presence
+// @Accept json
+// @Produce json
+// @Param request body v1.PresenceRouterReport true "在线设备"
+// @Success 200 {object} httptransport.APIResponse{data=v1.PresenceRouterReportResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/presence/router [post]
+func (s *PresenceServiceV1) routerReport(c *gin.Context) {
+	var request v1.PresenceRouterReport
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	matched := s.service.RouterReport(c.Request.Context(), request.MACs)
+	httpUtils.Response.Success(c, v1.PresenceRouterReportResponse{Matched: matched}, "上报成功")
+}
+
+func (s *PresenceServiceV1) userID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		httpUtils.Response.BadRequest(c, "无效的用户ID")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// handleError 将领域错误映射为API错误
+func (s *PresenceServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, member.ErrUserNotFound):
+		httpUtils.Response.NotFound(c, "用户")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toPresenceStatus(status presence.Status) v1.PresenceStatus {
+	out := v1.PresenceStatus{
+		UserID: status.UserID,
+		Name:   status.Name,
+		State:  string(status.State),
+		Source: string(status.Source),
+	}
+	if !status.Since.IsZero() {
+		since := status.Since
+		out.Since = &since
+	}
+	if !status.UpdatedAt.IsZero() {
+		updatedAt := status.UpdatedAt
+		out.UpdatedAt = &updatedAt
+	}
+	return out
+}
@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// DeviceDataEraser 可按设备删除用户数据的领域服务
+type DeviceDataEraser interface {
+	EraseDeviceData(ctx context.Context, deviceID string) (int64, error)
+}
+
+// PrivacyServiceV1 V1版本用户数据删除服务
+type PrivacyServiceV1 struct {
+	logger  *logging.Logger
+	erasers map[string]DeviceDataEraser // 数据类别 -> 删除实现，只包含已启用的服务
+}
+
+// NewPrivacyServiceV1 创建用户数据删除服务V1实例
+func NewPrivacyServiceV1(logger *logging.Logger, erasers map[string]DeviceDataEraser) (*PrivacyServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	return &PrivacyServiceV1{
+		logger:  logger,
+		erasers: erasers,
+	}, nil
+}
+
+// Register 注册用户数据删除API路由
+func (s *PrivacyServiceV1) Register(router *gin.RouterGroup) {
+	router.DELETE("/devices/:id/data", s.eraseDeviceData) // 删除设备的全部用户数据
+}
+
+// eraseDeviceData 删除设备的全部用户数据
+// @Summary 删除设备的全部用户数据
+// @Description 删除设备产生的录音、语音归档授权、对话记录和反馈，设备本身及绑定关系不受影响
+// @Tags Privacy
+// @Produce json
+// @Param id path string true "设备ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.DeviceDataErasureResponse}
+// @Failure 500 {object} httptransport.APIResponse
+// @Router /v1/devices/{id}/data [delete]
+func (s *PrivacyServiceV1) eraseDeviceData(c *gin.Context) {
+	deviceID := c.Param("id")
+	categories := make([]string, 0, len(s.erasers))
+	for category := range s.erasers {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	deleted := make(map[string]int64, len(categories))
+	for _, category := range categories {
+		n, err := s.erasers[category].EraseDeviceData(c.Request.Context(), deviceID)
+		if err != nil {
+			if platformerrors.IsKind(err, platformerrors.KindDomain) {
+				httpUtils.Response.BadRequest(c, err.Error())
+				return
+			}
+			s.logger.ErrorTag("API", "删除设备用户数据失败", "device_id", deviceID, "category", category, "error", err, "request_id", getRequestID(c))
+			httpUtils.Response.InternalError(c, "删除设备用户数据失败")
+			return
+		}
+		deleted[category] = n
+	}
+
+	s.logger.InfoTag("API", "删除设备用户数据", "device_id", deviceID, "deleted", deleted, "request_id", getRequestID(c))
+	httpUtils.Response.Success(c, v1.DeviceDataErasureResponse{
+		DeviceID: deviceID,
+		Deleted:  deleted,
+	}, "设备用户数据已删除")
+}
@@ -0,0 +1,412 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/prompt"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// PromptServiceV1 V1版本提示词模板服务
+type PromptServiceV1 struct {
+	logger  *logging.Logger
+	service *prompt.Service
+}
+
+// NewPromptServiceV1 创建提示词模板服务V1实例
+func NewPromptServiceV1(logger *logging.Logger, service *prompt.Service) (*PromptServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("prompt service is required")
+	}
+	return &PromptServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册提示词模板API路由
+func (s *PromptServiceV1) Register(router *gin.RouterGroup) {
+	prompts := router.Group("/prompts")
+	{
+		prompts.POST("", s.createPrompt)                                     // 创建模板
+		prompts.GET("", s.listPrompts)                                       // 获取模板列表
+		prompts.POST("/render", s.renderContent)                             // 测试渲染未保存的内容
+		prompts.GET("/:name", s.getPrompt)                                   // 获取模板
+		prompts.PUT("/:name", s.updatePrompt)                                // 更新模板（生成新版本）
+		prompts.DELETE("/:name", s.deletePrompt)                             // 删除模板
+		prompts.GET("/:name/versions", s.listVersions)                       // 获取版本列表
+		prompts.POST("/:name/versions/:version/activate", s.activateVersion) // 切换生效版本
+		prompts.POST("/:name/render", s.renderPrompt)                        // 测试渲染已保存的模板
+	}
+
+	assignments := router.Group("/prompt-assignments")
+	{
+		assignments.GET("", s.listAssignments)              // 获取分配列表
+		assignments.PUT("/:device_id", s.assignPrompt)      // 为设备分配模板，device_id 为 * 时设置默认
+		assignments.DELETE("/:device_id", s.unassignPrompt) // 取消分配
+	}
+}
+
+// createPrompt 创建提示词模板
+// @Summary 创建提示词模板
+// @Description 创建命名模板，支持 Go 模板语法和 Jinja 风格的 {{ name }} 变量
+// @Tags Prompts
+// @Accept json
+// @Produce json
+// @Param request body v1.PromptCreateRequest true "模板信息"
+// @Success 201 {object} httptransport.APIResponse{data=v1.PromptInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/prompts [post]
+func (s *PromptServiceV1) createPrompt(c *gin.Context) {
+	var request v1.PromptCreateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	s.logger.InfoTag("API", "创建提示词模板", "name", request.Name, "request_id", getRequestID(c))
+
+	t, err := s.service.Create(c.Request.Context(), prompt.CreateRequest{
+		Name:        request.Name,
+		Description: request.Description,
+		Content:     request.Content,
+		Variables:   request.Variables,
+	})
+	if err != nil {
+		s.handleError(c, err, "创建提示词模板失败")
+		return
+	}
+	httpUtils.Response.Created(c, toPromptInfo(t), "提示词模板创建成功")
+}
+
+// listPrompts 获取提示词模板列表
+// @Summary 获取提示词模板列表
+// @Description 返回各模板的当前生效版本
+// @Tags Prompts
+// @Produce json
+// @Param name query string false "名称关键字"
+// @Param page query int false "页码" default(1)
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} httptransport.APIResponse{data=v1.PromptListResponse}
+// @Router /v1/prompts [get]
+func (s *PromptServiceV1) listPrompts(c *gin.Context) {
+	var query v1.PromptQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 20
+	}
+
+	items, total, err := s.service.List(c.Request.Context(), prompt.Filter{
+		Name:     query.Name,
+		Page:     query.Page,
+		PageSize: query.Limit,
+	})
+	if err != nil {
+		s.handleError(c, err, "获取提示词模板列表失败")
+		return
+	}
+
+	prompts := make([]v1.PromptInfo, 0, len(items))
+	for _, item := range items {
+		prompts = append(prompts, toPromptInfo(item))
+	}
+	totalPages := (total + int64(query.Limit) - 1) / int64(query.Limit)
+	httpUtils.Response.Success(c, v1.PromptListResponse{
+		Prompts: prompts,
+		Pagination: v1.Pagination{
+			Page:       int64(query.Page),
+			Limit:      int64(query.Limit),
+			Total:      total,
+			TotalPages: totalPages,
+			HasNext:    int64(query.Page) < totalPages,
+			HasPrev:    query.Page > 1,
+		},
+	}, "获取提示词模板列表成功")
+}
+
+// getPrompt 获取提示词模板
+// @Summary 获取提示词模板
+// @Tags Prompts
+// @Produce json
+// @Param name path string true "模板名称"
+// @Param version query int false "版本号，默认为生效版本"
+// @Success 200 {object} httptransport.APIResponse{data=v1.PromptInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/prompts/{name} [get]
+func (s *PromptServiceV1) getPrompt(c *gin.Context) {
+	version, err := strconv.Atoi(c.DefaultQuery("version", "0"))
+	if err != nil || version < 0 {
+		httpUtils.Response.BadRequest(c, "version 必须是非负整数")
+		return
+	}
+	t, err := s.service.Get(c.Request.Context(), c.Param("name"), version)
+	if err != nil {
+		s.handleError(c, err, "获取提示词模板失败")
+		return
+	}
+	httpUtils.Response.Success(c, toPromptInfo(t), "获取提示词模板成功")
+}
+
+// updatePrompt 更新提示词模板
+// @Summary 更新提示词模板
+// @Description 基于当前生效版本生成新版本，并设为生效版本
+// @Tags Prompts
+// @Accept json
+// @Produce json
+// @Param name path string true "模板名称"
+// @Param request body v1.PromptUpdateRequest true "更新内容"
+// @Success 200 {object} httptransport.APIResponse{data=v1.PromptInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/prompts/{name} [put]
+func (s *PromptServiceV1) updatePrompt(c *gin.Context) {
+	var request v1.PromptUpdateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	t, err := s.service.Update(c.Request.Context(), c.Param("name"), prompt.UpdateRequest{
+		Description: request.Description,
+		Content:     request.Content,
+		Variables:   request.Variables,
+	})
+	if err != nil {
+		s.handleError(c, err, "更新提示词模板失败")
+		return
+	}
+	httpUtils.Response.Success(c, toPromptInfo(t), "提示词模板更新成功")
+}
+
+// deletePrompt 删除提示词模板
+// @Summary 删除提示词模板
+// @Description 删除模板的全部版本及设备分配
+// @Tags Prompts
+// @Produce json
+// @Param name path string true "模板名称"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/prompts/{name} [delete]
+func (s *PromptServiceV1) deletePrompt(c *gin.Context) {
+	if err := s.service.Delete(c.Request.Context(), c.Param("name")); err != nil {
+		s.handleError(c, err, "删除提示词模板失败")
+		return
+	}
+	httpUtils.Response.Success(c, nil, "提示词模板已删除")
+}
+
+// listVersions 获取提示词模板版本列表
+// @Summary 获取提示词模板版本列表
+// @Tags Prompts
+// @Produce json
+// @Param name path string true "模板名称"
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.PromptInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/prompts/{name}/versions [get]
+func (s *PromptServiceV1) listVersions(c *gin.Context) {
+	versions, err := s.service.ListVersions(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		s.handleError(c, err, "获取提示词模板版本失败")
+		return
+	}
+	infos := make([]v1.PromptInfo, 0, len(versions))
+	for _, t := range versions {
+		infos = append(infos, toPromptInfo(t))
+	}
+	httpUtils.Response.Success(c, infos, "获取提示词模板版本成功")
+}
+
+// activateVersion 切换提示词模板生效版本
+// @Summary 切换提示词模板生效版本
+// @Description 将指定版本设为生效版本，可用于回滚
+// @Tags Prompts
+// @Produce json
+// @Param name path string true "模板名称"
+// @Param version path int true "版本号"
+// @Success 200 {object} httptransport.APIResponse{data=v1.PromptInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/prompts/{name}/versions/{version}/activate [post]
+func (s *PromptServiceV1) activateVersion(c *gin.Context) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version <= 0 {
+		httpUtils.Response.BadRequest(c, "version 必须是正整数")
+		return
+	}
+	t, err := s.service.Activate(c.Request.Context(), c.Param("name"), version)
+	if err != nil {
+		s.handleError(c, err, "切换提示词模板版本失败")
+		return
+	}
+	httpUtils.Response.Success(c, toPromptInfo(t), "提示词模板版本已切换")
+}
+
+// renderPrompt 测试渲染已保存的模板
+// @Summary 测试渲染提示词模板
+// @Tags Prompts
+// @Accept json
+// @Produce json
+// @Param name path string true "模板名称"
+// @Param request body v1.PromptRenderRequest true "渲染参数"
+// @Success 200 {object} httptransport.APIResponse{data=v1.PromptRenderResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/prompts/{name}/render [post]
+func (s *PromptServiceV1) renderPrompt(c *gin.Context) {
+	var request v1.PromptRenderRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	out, t, err := s.service.RenderTemplate(c.Request.Context(), c.Param("name"), request.Version, request.Vars)
+	if err != nil {
+		s.handleError(c, err, "渲染提示词模板失败")
+		return
+	}
+	httpUtils.Response.Success(c, v1.PromptRenderResponse{
+		Name:     t.Name,
+		Version:  t.Version,
+		Rendered: out,
+	}, "渲染成功")
+}
+
+// renderContent 测试渲染未保存的模板内容
+// @Summary 预览提示词模板
+// @Description 渲染未保存的模板内容，用于编辑时预览
+// @Tags Prompts
+// @Accept json
+// @Produce json
+// @Param request body v1.PromptRenderRequest true "渲染参数"
+// @Success 200 {object} httptransport.APIResponse{data=v1.PromptRenderResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/prompts/render [post]
+func (s *PromptServiceV1) renderContent(c *gin.Context) {
+	var request v1.PromptRenderRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	if request.Content == "" {
+		httpUtils.Response.BadRequest(c, "content 不能为空")
+		return
+	}
+
+	out, err := s.service.RenderContent(request.Content, request.Variables, request.Vars)
+	if err != nil {
+		s.handleError(c, err, "渲染提示词模板失败")
+		return
+	}
+	httpUtils.Response.Success(c, v1.PromptRenderResponse{Rendered: out}, "渲染成功")
+}
+
+// listAssignments 获取模板分配列表
+// @Summary 获取提示词模板分配列表
+// @Tags Prompts
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.PromptAssignmentInfo}
+// @Router /v1/prompt-assignments [get]
+func (s *PromptServiceV1) listAssignments(c *gin.Context) {
+	items, err := s.service.ListAssignments(c.Request.Context())
+	if err != nil {
+		s.handleError(c, err, "获取模板分配失败")
+		return
+	}
+	infos := make([]v1.PromptAssignmentInfo, 0, len(items))
+	for _, a := range items {
+		infos = append(infos, toPromptAssignmentInfo(a))
+	}
+	httpUtils.Response.Success(c, infos, "获取模板分配成功")
+}
+
+// assignPrompt 为设备分配模板
+// @Summary 为设备分配提示词模板
+// @Description device_id 为 * 时设置全局默认模板
+// @Tags Prompts
+// @Accept json
+// @Produce json
+// @Param device_id path string true "设备ID"
+// @Param request body v1.PromptAssignRequest true "分配信息"
+// @Success 200 {object} httptransport.APIResponse{data=v1.PromptAssignmentInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/prompt-assignments/{device_id} [put]
+func (s *PromptServiceV1) assignPrompt(c *gin.Context) {
+	var request v1.PromptAssignRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	a, err := s.service.Assign(c.Request.Context(), c.Param("device_id"), request.TemplateName, request.Version)
+	if err != nil {
+		s.handleError(c, err, "分配提示词模板失败")
+		return
+	}
+	httpUtils.Response.Success(c, toPromptAssignmentInfo(a), "提示词模板分配成功")
+}
+
+// unassignPrompt 取消设备的模板分配
+// @Summary 取消提示词模板分配
+// @Tags Prompts
+// @Produce json
+// @Param device_id path string true "设备ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Router /v1/prompt-assignments/{device_id} [delete]
+func (s *PromptServiceV1) unassignPrompt(c *gin.Context) {
+	if err := s.service.Unassign(c.Request.Context(), c.Param("device_id")); err != nil {
+		s.handleError(c, err, "取消模板分配失败")
+		return
+	}
+	httpUtils.Response.Success(c, nil, "模板分配已取消")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *PromptServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, prompt.ErrNotFound):
+		httpUtils.Response.NotFound(c, "提示词模板")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toPromptInfo(t *prompt.Template) v1.PromptInfo {
+	return v1.PromptInfo{
+		ID:          t.ID,
+		Name:        t.Name,
+		Version:     t.Version,
+		Description: t.Description,
+		Content:     t.Content,
+		Variables:   t.Variables,
+		Active:      t.Active,
+		CreatedAt:   t.CreatedAt,
+	}
+}
+
+func toPromptAssignmentInfo(a *prompt.Assignment) v1.PromptAssignmentInfo {
+	return v1.PromptAssignmentInfo{
+		DeviceID:     a.DeviceID,
+		TemplateName: a.TemplateName,
+		Version:      a.Version,
+		UpdatedAt:    a.UpdatedAt,
+	}
+}
@@ -0,0 +1,170 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/providercall"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// ProviderCallServiceV1 V1版本提供者调用录制服务
+type ProviderCallServiceV1 struct {
+	logger  *logging.Logger
+	service *providercall.Service
+}
+
+// NewProviderCallServiceV1 创建提供者调用录制服务V1实例
+func NewProviderCallServiceV1(logger *logging.Logger, service *providercall.Service) (*ProviderCallServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("provider call service is required")
+	}
+	return &ProviderCallServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册提供者调用录制API路由，仅管理员可访问
+func (s *ProviderCallServiceV1) Register(router *gin.RouterGroup) {
+	calls := router.Group("/debug/provider-calls")
+	{
+		calls.GET("", s.listCalls)   // 搜索调用录制
+		calls.GET("/:id", s.getCall) // 获取调用的请求和响应
+	}
+}
+
+// listCalls 搜索调用录制
+// @Summary 搜索提供者调用录制
+// @Description 按提供者、能力、设备、调用结果、时间范围和文本搜索采样录制的能力调用，新记录在前；列表不含请求和响应
+// @Tags Debug
+// @Produce json
+// @Param provider query string false "提供者ID"
+// @Param capability query string false "能力ID"
+// @Param device_id query string false "设备ID"
+// @Param success query bool false "是否成功"
+// @Param q query string false "在请求、响应和错误信息中搜索的文本"
+// @Param from query string false "开始时间 RFC3339"
+// @Param to query string false "结束时间 RFC3339"
+// @Param page query int false "页码" default(1)
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} httptransport.APIResponse{data=v1.ProviderCallListResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Router /v1/debug/provider-calls [get]
+func (s *ProviderCallServiceV1) listCalls(c *gin.Context) {
+	var query v1.ProviderCallQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 20
+	}
+
+	filter := providercall.Filter{
+		ProviderID:   query.Provider,
+		CapabilityID: query.Capability,
+		DeviceID:     query.DeviceID,
+		Query:        query.Q,
+		Page:         query.Page,
+		PageSize:     query.Limit,
+	}
+	if query.Success != "" {
+		success, err := strconv.ParseBool(query.Success)
+		if err != nil {
+			httpUtils.Response.BadRequest(c, "success 必须是 true 或 false")
+			return
+		}
+		filter.Success = &success
+	}
+	var err error
+	if filter.From, err = parseQueryTime(query.From); err != nil {
+		httpUtils.Response.BadRequest(c, "from 必须是 RFC3339 时间")
+		return
+	}
+	if filter.To, err = parseQueryTime(query.To); err != nil {
+		httpUtils.Response.BadRequest(c, "to 必须是 RFC3339 时间")
+		return
+	}
+
+	items, total, err := s.service.List(c.Request.Context(), filter)
+	if err != nil {
+		s.logger.ErrorTag("API", "搜索提供者调用录制失败", "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, "搜索提供者调用录制失败")
+		return
+	}
+
+	calls := make([]v1.ProviderCallSummary, 0, len(items))
+	for _, item := range items {
+		calls = append(calls, toProviderCallSummary(item))
+	}
+	totalPages := (total + int64(query.Limit) - 1) / int64(query.Limit)
+	httpUtils.Response.Success(c, v1.ProviderCallListResponse{
+		Calls: calls,
+		Pagination: v1.Pagination{
+			Page:       int64(query.Page),
+			Limit:      int64(query.Limit),
+			Total:      total,
+			TotalPages: totalPages,
+			HasNext:    int64(query.Page) < totalPages,
+			HasPrev:    query.Page > 1,
+		},
+	}, "搜索提供者调用录制成功")
+}
+
+// getCall 获取调用的请求和响应
+// @Summary 获取提供者调用录制详情
+// @Description 返回录制的完整请求和响应，密钥类字段已替换为 ******，超出长度上限的部分已截断
+// @Tags Debug
+// @Produce json
+// @Param id path string true "录制ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ProviderCallInfo}
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/debug/provider-calls/{id} [get]
+func (s *ProviderCallServiceV1) getCall(c *gin.Context) {
+	call, err := s.service.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, providercall.ErrNotFound) {
+			httpUtils.Response.NotFound(c, "调用录制")
+			return
+		}
+		s.logger.ErrorTag("API", "获取提供者调用录制失败", "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, "获取提供者调用录制失败")
+		return
+	}
+	httpUtils.Response.Success(c, v1.ProviderCallInfo{
+		ProviderCallSummary: toProviderCallSummary(call),
+		Request:             call.Request,
+		Response:            call.Response,
+	}, "获取提供者调用录制成功")
+}
+
+func toProviderCallSummary(call *providercall.Call) v1.ProviderCallSummary {
+	return v1.ProviderCallSummary{
+		ID:             call.ID,
+		ProviderID:     call.ProviderID,
+		CapabilityID:   call.CapabilityID,
+		CapabilityType: call.CapabilityType,
+		DeviceID:       call.DeviceID,
+		SessionID:      call.SessionID,
+		Stream:         call.Stream,
+		Chunks:         call.Chunks,
+		Success:        call.Success,
+		Error:          call.Error,
+		DurationMs:     call.DurationMs,
+		CreatedAt:      call.CreatedAt,
+	}
+}
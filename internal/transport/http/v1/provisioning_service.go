@@ -0,0 +1,245 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/member"
+	"xiaozhi-server-go/internal/domain/provisioning"
+	"xiaozhi-server-go/internal/domain/tenant"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// ProvisioningServiceV1 V1版本扫码配对服务
+type ProvisioningServiceV1 struct {
+	logger  *logging.Logger
+	service *provisioning.Service
+}
+
+// NewProvisioningServiceV1 创建扫码配对服务V1实例
+func NewProvisioningServiceV1(logger *logging.Logger, service *provisioning.Service) (*ProvisioningServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("provisioning service is required")
+	}
+	return &ProvisioningServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// RegisterPublic 注册配对接口，设备和配套 App 凭配对令牌访问
+func (s *ProvisioningServiceV1) RegisterPublic(router *gin.RouterGroup) {
+	router.POST("/provisioning/claim", s.claim) // 用配对令牌换取设备凭证
+}
+
+// Register 注册签发配对令牌和管理设备密钥的路由
+func (s *ProvisioningServiceV1) Register(router *gin.RouterGroup) {
+	router.POST("/users/:id/pairing-tokens", s.issue) // 签发配对令牌
+
+	keys := router.Group("/devices/:id/keys")
+	{
+		keys.GET("", s.listKeys)             // 获取设备密钥
+		keys.POST("", s.issueKey)            // 签发或轮换设备密钥
+		keys.DELETE("/:key_id", s.revokeKey) // 吊销设备密钥
+	}
+}
+
+// issue 签发配对令牌
+// @Summary 签发配对令牌
+// @Description 为用户签发短时有效的一次性配对令牌，payload 用于生成二维码；设备或配套 App 扫码后调用 /v1/provisioning/claim 完成配对，设备以该用户为所有者注册
+// @Tags Provisioning
+// @Produce json
+// @Param id path int true "用户ID"
+// @Success 201 {object} httptransport.APIResponse{data=v1.PairingTokenInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/users/{id}/pairing-tokens [post]
+func (s *ProvisioningServiceV1) issue(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		httpUtils.Response.BadRequest(c, "无效的用户ID")
+		return
+	}
+	pairing, err := s.service.Issue(c.Request.Context(), uint(id))
+	if err != nil {
+		s.handleError(c, err, "签发配对令牌失败")
+		return
+	}
+	s.logger.InfoTag("API", "签发配对令牌", "user_id", id, "request_id", getRequestID(c))
+	httpUtils.Response.Created(c, v1.PairingTokenInfo{
+		Token:     pairing.Token,
+		Payload:   pairing.Payload,
+		ExpiresAt: pairing.ExpiresAt,
+	}, "配对令牌已签发")
+}
+
+// claim 用配对令牌换取设备凭证
+// @Summary 扫码配对设备
+// @Description 设备或配套 App 用二维码中的配对令牌换取设备凭证；设备未注册时以令牌签发者为所有者创建并激活，已注册的设备只能由原所有者重新配对。令牌只能使用一次
+// @Tags Provisioning
+// @Accept json
+// @Produce json
+// @Param request body v1.ProvisioningClaimRequest true "配对令牌和设备信息"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ProvisioningClaimResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 401 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Failure 409 {object} httptransport.APIResponse
+// @Router /v1/provisioning/claim [post]
+func (s *ProvisioningServiceV1) claim(c *gin.Context) {
+	var request v1.ProvisioningClaimRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	result, err := s.service.Claim(c.Request.Context(), provisioning.Claim{
+		Token:     request.Token,
+		DeviceID:  request.DeviceID,
+		ClientID:  request.ClientID,
+		Name:      request.DeviceName,
+		BoardType: request.DeviceType,
+		ChipModel: request.Model,
+		Version:   request.Version,
+	})
+	if err != nil {
+		s.handleError(c, err, "设备配对失败")
+		return
+	}
+	s.logger.InfoTag("API", "扫码配对设备", "device_id", result.DeviceID, "user_id", result.UserID, "request_id", getRequestID(c))
+	response := v1.ProvisioningClaimResponse{
+		DeviceID:    result.DeviceID,
+		ClientID:    result.ClientID,
+		UserID:      result.UserID,
+		TenantID:    result.TenantID,
+		DeviceToken: result.DeviceToken,
+		Websocket:   result.Websocket,
+	}
+	if result.Key != nil {
+		key := toIssuedKeyInfo(result.Key)
+		response.Key = &key
+	}
+	httpUtils.Response.Success(c, response, "设备配对成功")
+}
+
+// listKeys 获取设备密钥
+// @Summary 获取设备密钥
+// @Description 列出设备的全部密钥（只含公钥），新密钥在前；设备有未吊销的密钥时，连接 WebSocket 必须携带用其中之一签名的 JWT
+// @Tags Provisioning
+// @Produce json
+// @Param id path string true "设备ID"
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.DeviceKeyInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/devices/{id}/keys [get]
+func (s *ProvisioningServiceV1) listKeys(c *gin.Context) {
+	keys, err := s.service.ListKeys(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取设备密钥失败")
+		return
+	}
+	infos := make([]v1.DeviceKeyInfo, len(keys))
+	for i, key := range keys {
+		infos[i] = v1.DeviceKeyInfo{
+			ID:         key.ID,
+			DeviceID:   key.DeviceID,
+			PublicKey:  key.PublicKey,
+			Revoked:    key.Revoked(),
+			CreatedAt:  key.CreatedAt,
+			RevokedAt:  key.RevokedAt,
+			LastUsedAt: key.LastUsedAt,
+		}
+	}
+	httpUtils.Response.Success(c, infos, "获取设备密钥成功")
+}
+
+// issueKey 签发或轮换设备密钥
+// @Summary 签发设备密钥
+// @Description 为设备签发新的 P-256 密钥，私钥只返回一次；设备此后改用 JWT 认证，原静态令牌失效。revoke_previous 为 true 时同时吊销其他密钥，否则旧密钥在吊销前仍可使用，便于设备切换
+// @Tags Provisioning
+// @Accept json
+// @Produce json
+// @Param id path string true "设备ID"
+// @Param request body v1.DeviceKeyIssueRequest false "是否吊销旧密钥"
+// @Success 201 {object} httptransport.APIResponse{data=v1.IssuedDeviceKeyInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/devices/{id}/keys [post]
+func (s *ProvisioningServiceV1) issueKey(c *gin.Context) {
+	var request v1.DeviceKeyIssueRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			httpUtils.Response.ValidationError(c, err)
+			return
+		}
+	}
+	deviceID := c.Param("id")
+	key, err := s.service.IssueKey(c.Request.Context(), deviceID, request.RevokePrevious)
+	if err != nil {
+		s.handleError(c, err, "签发设备密钥失败")
+		return
+	}
+	s.logger.InfoTag("API", "签发设备密钥", "device_id", deviceID, "key_id", key.KeyID, "revoke_previous", request.RevokePrevious, "request_id", getRequestID(c))
+	httpUtils.Response.Created(c, toIssuedKeyInfo(key), "设备密钥已签发")
+}
+
+// revokeKey 吊销设备密钥
+// @Summary 吊销设备密钥
+// @Description 吊销后用该密钥签名的 JWT 在 WebSocket 握手时被拒绝；已建立的连接不受影响
+// @Tags Provisioning
+// @Produce json
+// @Param id path string true "设备ID"
+// @Param key_id path string true "密钥ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/devices/{id}/keys/{key_id} [delete]
+func (s *ProvisioningServiceV1) revokeKey(c *gin.Context) {
+	deviceID, keyID := c.Param("id"), c.Param("key_id")
+	if err := s.service.RevokeKey(c.Request.Context(), deviceID, keyID); err != nil {
+		s.handleError(c, err, "吊销设备密钥失败")
+		return
+	}
+	s.logger.InfoTag("API", "吊销设备密钥", "device_id", deviceID, "key_id", keyID, "request_id", getRequestID(c))
+	httpUtils.Response.Success(c, map[string]interface{}{"device_id": deviceID, "key_id": keyID}, "设备密钥已吊销")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *ProvisioningServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, provisioning.ErrUserNotFound), errors.Is(err, member.ErrUserNotFound):
+		httpUtils.Response.NotFound(c, "用户")
+	case errors.Is(err, provisioning.ErrTokenInvalid):
+		httpUtils.Response.Unauthorized(c, "配对令牌无效或已过期")
+	case errors.Is(err, provisioning.ErrDeviceClaimed):
+		httpUtils.Response.Conflict(c, "设备已归属其他用户")
+	case errors.Is(err, provisioning.ErrDeviceNotFound):
+		httpUtils.Response.NotFound(c, "设备")
+	case errors.Is(err, provisioning.ErrKeyNotFound):
+		httpUtils.Response.NotFound(c, "设备密钥")
+	case errors.Is(err, tenant.ErrNotFound):
+		httpUtils.Response.NotFound(c, "租户")
+	case errors.Is(err, tenant.ErrDisabled), errors.Is(err, tenant.ErrDeviceQuota):
+		httpUtils.Response.Forbidden(c, err.Error())
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toIssuedKeyInfo(key *provisioning.IssuedKey) v1.IssuedDeviceKeyInfo {
+	return v1.IssuedDeviceKeyInfo{
+		KeyID:      key.KeyID,
+		Algorithm:  key.Algorithm,
+		PrivateKey: key.PrivateKey,
+		PublicKey:  key.PublicKey,
+	}
+}
@@ -0,0 +1,251 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/recording"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// RecordingServiceV1 V1版本语音归档服务
+type RecordingServiceV1 struct {
+	logger  *logging.Logger
+	service *recording.Service
+}
+
+// NewRecordingServiceV1 创建语音归档服务V1实例
+func NewRecordingServiceV1(logger *logging.Logger, service *recording.Service) (*RecordingServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("recording service is required")
+	}
+	return &RecordingServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册语音归档API路由
+func (s *RecordingServiceV1) Register(router *gin.RouterGroup) {
+	recordings := router.Group("/recordings")
+	{
+		recordings.GET("", s.listRecordings)         // 获取录音列表
+		recordings.GET("/:id", s.getRecording)       // 获取录音详情
+		recordings.GET("/:id/audio", s.getAudio)     // 下载录音
+		recordings.DELETE("/:id", s.deleteRecording) // 删除录音
+	}
+
+	router.GET("/devices/:id/recording-consent", s.getConsent) // 获取设备语音归档授权
+	router.PUT("/devices/:id/recording-consent", s.setConsent) // 设置设备语音归档授权
+}
+
+// listRecordings 获取录音列表
+// @Summary 获取录音列表
+// @Description 按设备、会话、时间范围过滤归档的用户语音
+// @Tags Recordings
+// @Produce json
+// @Param device_id query string false "设备ID"
+// @Param session_id query string false "会话ID"
+// @Param from query string false "开始时间 RFC3339"
+// @Param to query string false "结束时间 RFC3339"
+// @Param page query int false "页码" default(1)
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} httptransport.APIResponse{data=v1.RecordingListResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/recordings [get]
+func (s *RecordingServiceV1) listRecordings(c *gin.Context) {
+	var query v1.RecordingQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 20
+	}
+
+	filter := recording.Filter{
+		DeviceID:  query.DeviceID,
+		SessionID: query.SessionID,
+		Page:      query.Page,
+		PageSize:  query.Limit,
+	}
+	var err error
+	if filter.From, err = parseQueryTime(query.From); err != nil {
+		httpUtils.Response.BadRequest(c, "from 必须是 RFC3339 时间")
+		return
+	}
+	if filter.To, err = parseQueryTime(query.To); err != nil {
+		httpUtils.Response.BadRequest(c, "to 必须是 RFC3339 时间")
+		return
+	}
+
+	items, total, err := s.service.List(c.Request.Context(), filter)
+	if err != nil {
+		s.handleError(c, err, "获取录音列表失败")
+		return
+	}
+
+	recordings := make([]v1.RecordingInfo, 0, len(items))
+	for _, item := range items {
+		recordings = append(recordings, toRecordingInfo(item))
+	}
+	totalPages := (total + int64(query.Limit) - 1) / int64(query.Limit)
+	httpUtils.Response.Success(c, v1.RecordingListResponse{
+		Recordings: recordings,
+		Pagination: v1.Pagination{
+			Page:       int64(query.Page),
+			Limit:      int64(query.Limit),
+			Total:      total,
+			TotalPages: totalPages,
+			HasNext:    int64(query.Page) < totalPages,
+			HasPrev:    query.Page > 1,
+		},
+	}, "获取录音列表成功")
+}
+
+// getRecording 获取录音详情
+// @Summary 获取录音详情
+// @Tags Recordings
+// @Produce json
+// @Param id path string true "录音ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.RecordingInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/recordings/{id} [get]
+func (s *RecordingServiceV1) getRecording(c *gin.Context) {
+	rec, err := s.service.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取录音失败")
+		return
+	}
+	httpUtils.Response.Success(c, toRecordingInfo(rec), "获取录音成功")
+}
+
+// getAudio 下载录音
+// @Summary 下载录音
+// @Description 返回解密后的 WAV 音频
+// @Tags Recordings
+// @Produce audio/wav
+// @Param id path string true "录音ID"
+// @Success 200 {file} file
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/recordings/{id}/audio [get]
+func (s *RecordingServiceV1) getAudio(c *gin.Context) {
+	rec, data, err := s.service.Audio(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "读取录音失败")
+		return
+	}
+	s.logger.InfoTag("API", "下载录音", "recording_id", rec.ID, "device_id", rec.DeviceID, "request_id", getRequestID(c))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "recording-"+rec.ID+"."+rec.Format))
+	c.Data(http.StatusOK, "audio/wav", data)
+}
+
+// deleteRecording 删除录音
+// @Summary 删除录音
+// @Tags Recordings
+// @Produce json
+// @Param id path string true "录音ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/recordings/{id} [delete]
+func (s *RecordingServiceV1) deleteRecording(c *gin.Context) {
+	if err := s.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		s.handleError(c, err, "删除录音失败")
+		return
+	}
+	httpUtils.Response.Success(c, nil, "录音已删除")
+}
+
+// getConsent 获取设备语音归档授权
+// @Summary 获取设备语音归档授权
+// @Description 未设置过授权的设备视为未授权，不会归档语音
+// @Tags Recordings
+// @Produce json
+// @Param id path string true "设备ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.RecordingConsentInfo}
+// @Router /v1/devices/{id}/recording-consent [get]
+func (s *RecordingServiceV1) getConsent(c *gin.Context) {
+	consent, err := s.service.GetConsent(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取语音归档授权失败")
+		return
+	}
+	httpUtils.Response.Success(c, v1.RecordingConsentInfo{
+		DeviceID:  consent.DeviceID,
+		Granted:   consent.Granted,
+		UpdatedAt: consent.UpdatedAt,
+	}, "获取语音归档授权成功")
+}
+
+// setConsent 设置设备语音归档授权
+// @Summary 设置设备语音归档授权
+// @Description 授权后该设备的用户语音会加密归档；撤销授权时可同时删除已归档的语音
+// @Tags Recordings
+// @Accept json
+// @Produce json
+// @Param id path string true "设备ID"
+// @Param request body v1.RecordingConsentRequest true "授权设置"
+// @Success 200 {object} httptransport.APIResponse{data=v1.RecordingConsentInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/devices/{id}/recording-consent [put]
+func (s *RecordingServiceV1) setConsent(c *gin.Context) {
+	var request v1.RecordingConsentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	consent, deleted, err := s.service.SetConsent(c.Request.Context(), c.Param("id"), *request.Granted, request.DeleteExisting)
+	if err != nil {
+		s.handleError(c, err, "设置语音归档授权失败")
+		return
+	}
+	s.logger.InfoTag("API", "设置语音归档授权", "device_id", consent.DeviceID, "granted", consent.Granted, "deleted", deleted, "request_id", getRequestID(c))
+	httpUtils.Response.Success(c, v1.RecordingConsentInfo{
+		DeviceID:  consent.DeviceID,
+		Granted:   consent.Granted,
+		UpdatedAt: consent.UpdatedAt,
+		Deleted:   deleted,
+	}, "语音归档授权已更新")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *RecordingServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, recording.ErrNotFound):
+		httpUtils.Response.NotFound(c, "录音")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toRecordingInfo(rec *recording.Recording) v1.RecordingInfo {
+	return v1.RecordingInfo{
+		ID:         rec.ID,
+		SessionID:  rec.SessionID,
+		DeviceID:   rec.DeviceID,
+		Round:      rec.Round,
+		EntryID:    rec.EntryID,
+		Format:     rec.Format,
+		SampleRate: rec.SampleRate,
+		Channels:   rec.Channels,
+		DurationMs: rec.DurationMs,
+		Size:       rec.Size,
+		CreatedAt:  rec.CreatedAt,
+	}
+}
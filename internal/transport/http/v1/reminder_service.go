@@ -0,0 +1,259 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/reminder"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// ReminderServiceV1 V1版本提醒服务
+type ReminderServiceV1 struct {
+	logger  *logging.Logger
+	service *reminder.Service
+}
+
+// NewReminderServiceV1 创建提醒服务V1实例
+func NewReminderServiceV1(logger *logging.Logger, service *reminder.Service) (*ReminderServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("reminder service is required")
+	}
+	return &ReminderServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册提醒API路由
+func (s *ReminderServiceV1) Register(router *gin.RouterGroup) {
+	reminders := router.Group("/reminders")
+	{
+		reminders.POST("", s.createReminder)            // 创建提醒
+		reminders.GET("", s.listReminders)              // 获取提醒列表
+		reminders.GET("/:id", s.getReminder)            // 获取提醒详情
+		reminders.PUT("/:id", s.updateReminder)         // 更新提醒
+		reminders.POST("/:id/cancel", s.cancelReminder) // 取消提醒
+		reminders.DELETE("/:id", s.deleteReminder)      // 删除提醒
+	}
+}
+
+// createReminder 创建提醒
+// @Summary 创建提醒
+// @Description 为设备创建计时器、闹钟或提醒，到期后向设备推送语音播报
+// @Tags Reminders
+// @Accept json
+// @Produce json
+// @Param request body v1.ReminderCreateRequest true "提醒信息"
+// @Success 201 {object} httptransport.APIResponse{data=v1.ReminderInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/reminders [post]
+func (s *ReminderServiceV1) createReminder(c *gin.Context) {
+	var request v1.ReminderCreateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	if request.DelaySeconds <= 0 && request.FireAt == nil {
+		httpUtils.Response.BadRequest(c, "delay_seconds 和 fire_at 必须提供其一")
+		return
+	}
+
+	req := reminder.CreateRequest{
+		DeviceID:   request.DeviceID,
+		Kind:       reminder.Kind(request.Kind),
+		Message:    request.Message,
+		Delay:      time.Duration(request.DelaySeconds) * time.Second,
+		Recurrence: reminder.Recurrence(request.Recurrence),
+	}
+	if request.FireAt != nil {
+		req.FireAt = *request.FireAt
+	}
+
+	s.logger.InfoTag("API", "创建提醒", "device_id", request.DeviceID, "kind", request.Kind, "request_id", getRequestID(c))
+
+	item, err := s.service.Create(c.Request.Context(), req)
+	if err != nil {
+		s.handleError(c, err, "创建提醒失败")
+		return
+	}
+	httpUtils.Response.Created(c, toReminderInfo(item), "提醒创建成功")
+}
+
+// listReminders 获取提醒列表
+// @Summary 获取提醒列表
+// @Description 获取提醒列表，支持按设备、类型和状态过滤
+// @Tags Reminders
+// @Produce json
+// @Param device_id query string false "设备ID"
+// @Param kind query string false "类型 timer/alarm/reminder"
+// @Param status query string false "状态 pending/fired/cancelled/missed"
+// @Param page query int false "页码" default(1)
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} httptransport.APIResponse{data=v1.ReminderListResponse}
+// @Router /v1/reminders [get]
+func (s *ReminderServiceV1) listReminders(c *gin.Context) {
+	var query v1.ReminderQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 20
+	}
+
+	items, total, err := s.service.List(c.Request.Context(), reminder.Filter{
+		DeviceID: query.DeviceID,
+		Kind:     reminder.Kind(query.Kind),
+		Status:   reminder.Status(query.Status),
+		Page:     query.Page,
+		PageSize: query.Limit,
+	})
+	if err != nil {
+		s.handleError(c, err, "获取提醒列表失败")
+		return
+	}
+
+	reminders := make([]v1.ReminderInfo, 0, len(items))
+	for _, item := range items {
+		reminders = append(reminders, toReminderInfo(item))
+	}
+	totalPages := (total + int64(query.Limit) - 1) / int64(query.Limit)
+	httpUtils.Response.Success(c, v1.ReminderListResponse{
+		Reminders: reminders,
+		Pagination: v1.Pagination{
+			Page:       int64(query.Page),
+			Limit:      int64(query.Limit),
+			Total:      total,
+			TotalPages: totalPages,
+			HasNext:    int64(query.Page) < totalPages,
+			HasPrev:    query.Page > 1,
+		},
+	}, "获取提醒列表成功")
+}
+
+// getReminder 获取提醒详情
+// @Summary 获取提醒详情
+// @Tags Reminders
+// @Produce json
+// @Param id path string true "提醒ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ReminderInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/reminders/{id} [get]
+func (s *ReminderServiceV1) getReminder(c *gin.Context) {
+	item, err := s.service.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取提醒失败")
+		return
+	}
+	httpUtils.Response.Success(c, toReminderInfo(item), "获取提醒成功")
+}
+
+// updateReminder 更新提醒
+// @Summary 更新提醒
+// @Description 修改待触发提醒的内容、触发时间或重复规则
+// @Tags Reminders
+// @Accept json
+// @Produce json
+// @Param id path string true "提醒ID"
+// @Param request body v1.ReminderUpdateRequest true "更新内容"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ReminderInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/reminders/{id} [put]
+func (s *ReminderServiceV1) updateReminder(c *gin.Context) {
+	var request v1.ReminderUpdateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	req := reminder.UpdateRequest{
+		Message: request.Message,
+		FireAt:  request.FireAt,
+	}
+	if request.Recurrence != nil {
+		recurrence := reminder.Recurrence(*request.Recurrence)
+		req.Recurrence = &recurrence
+	}
+
+	item, err := s.service.Update(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		s.handleError(c, err, "更新提醒失败")
+		return
+	}
+	httpUtils.Response.Success(c, toReminderInfo(item), "提醒更新成功")
+}
+
+// cancelReminder 取消提醒
+// @Summary 取消提醒
+// @Tags Reminders
+// @Produce json
+// @Param id path string true "提醒ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ReminderInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/reminders/{id}/cancel [post]
+func (s *ReminderServiceV1) cancelReminder(c *gin.Context) {
+	item, err := s.service.Cancel(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "取消提醒失败")
+		return
+	}
+	httpUtils.Response.Success(c, toReminderInfo(item), "提醒已取消")
+}
+
+// deleteReminder 删除提醒
+// @Summary 删除提醒
+// @Tags Reminders
+// @Produce json
+// @Param id path string true "提醒ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/reminders/{id} [delete]
+func (s *ReminderServiceV1) deleteReminder(c *gin.Context) {
+	if err := s.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		s.handleError(c, err, "删除提醒失败")
+		return
+	}
+	httpUtils.Response.Success(c, nil, "提醒已删除")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *ReminderServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, reminder.ErrNotFound):
+		httpUtils.Response.NotFound(c, "提醒")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toReminderInfo(item *reminder.Reminder) v1.ReminderInfo {
+	return v1.ReminderInfo{
+		ID:         item.ID,
+		DeviceID:   item.DeviceID,
+		Kind:       string(item.Kind),
+		Message:    item.Message,
+		FireAt:     item.FireAt,
+		Recurrence: string(item.Recurrence),
+		Status:     string(item.Status),
+		FiredAt:    item.FiredAt,
+		CreatedAt:  item.CreatedAt,
+		UpdatedAt:  item.UpdatedAt,
+	}
+}
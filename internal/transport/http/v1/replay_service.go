@@ -0,0 +1,213 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/replay"
+	"xiaozhi-server-go/internal/domain/transcript"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// ReplayServiceV1 V1版本对话回放服务
+type ReplayServiceV1 struct {
+	logger  *logging.Logger
+	service *replay.Service
+}
+
+// NewReplayServiceV1 创建对话回放服务V1实例
+func NewReplayServiceV1(logger *logging.Logger, service *replay.Service) (*ReplayServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("replay service is required")
+	}
+	return &ReplayServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册对话回放API路由
+func (s *ReplayServiceV1) Register(router *gin.RouterGroup) {
+	replays := router.Group("/replays")
+	{
+		replays.POST("", s.startReplay)  // 发起回放
+		replays.GET("", s.listReplays)   // 获取回放列表
+		replays.GET("/:id", s.getReplay) // 获取回放对比结果
+	}
+}
+
+// startReplay 发起回放
+// @Summary 回放已记录的会话
+// @Description 用当前或指定的 LLM 配置和提示词重新生成会话中每轮的回复，在后台执行；每轮的对话历史使用原回复，工具调用不重新执行。可通过回放ID查询逐轮的回复差异、耗时和成本对比
+// @Tags Replays
+// @Accept json
+// @Produce json
+// @Param request body v1.ReplayStartRequest true "回放参数"
+// @Success 202 {object} httptransport.APIResponse{data=v1.ReplayRunInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/replays [post]
+func (s *ReplayServiceV1) startReplay(c *gin.Context) {
+	var request v1.ReplayStartRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	s.logger.InfoTag("API", "发起回放", "session_id", request.SessionID, "model", request.Model, "request_id", getRequestID(c))
+	run, err := s.service.Start(c.Request.Context(), request.SessionID, toReplayOptions(request.ReplayOptions))
+	if err != nil {
+		s.handleError(c, err, "发起回放失败")
+		return
+	}
+	httpUtils.Response.Accepted(c, toReplayRunInfo(run), "回放已开始")
+}
+
+// listReplays 获取回放列表
+// @Summary 获取回放列表
+// @Description 返回回放运行及汇总，不含逐轮明细
+// @Tags Replays
+// @Produce json
+// @Param session_id query string false "会话ID"
+// @Param page query int false "页码" default(1)
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} httptransport.APIResponse{data=v1.ReplayRunListResponse}
+// @Router /v1/replays [get]
+func (s *ReplayServiceV1) listReplays(c *gin.Context) {
+	var query v1.ReplayQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 20
+	}
+
+	items, total, err := s.service.List(c.Request.Context(), replay.RunFilter{
+		SessionID: query.SessionID,
+		Page:      query.Page,
+		PageSize:  query.Limit,
+	})
+	if err != nil {
+		s.handleError(c, err, "获取回放列表失败")
+		return
+	}
+	runs := make([]v1.ReplayRunInfo, 0, len(items))
+	for _, run := range items {
+		runs = append(runs, toReplayRunInfo(run))
+	}
+	httpUtils.Response.Success(c, v1.ReplayRunListResponse{
+		Runs:       runs,
+		Pagination: newPagination(query.Page, query.Limit, total),
+	}, "获取回放列表成功")
+}
+
+// getReplay 获取回放对比结果
+// @Summary 获取回放对比结果
+// @Description 返回汇总以及每轮的原回复、回放回复、逐词差异、首字和完成耗时、Token数和成本
+// @Tags Replays
+// @Produce json
+// @Param id path string true "回放ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ReplayRunInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/replays/{id} [get]
+func (s *ReplayServiceV1) getReplay(c *gin.Context) {
+	run, err := s.service.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取回放结果失败")
+		return
+	}
+	httpUtils.Response.Success(c, toReplayRunInfo(run), "获取回放结果成功")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *ReplayServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, replay.ErrNotFound):
+		httpUtils.Response.NotFound(c, "回放")
+	case errors.Is(err, transcript.ErrNotFound):
+		httpUtils.Response.NotFound(c, "会话")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toReplayOptions(o v1.ReplayOptions) replay.Options {
+	return replay.Options{
+		Model:                  o.Model,
+		ModelName:              o.ModelName,
+		Temperature:            o.Temperature,
+		MaxTokens:              o.MaxTokens,
+		SystemPrompt:           o.SystemPrompt,
+		PromptTemplate:         o.PromptTemplate,
+		PromptVersion:          o.PromptVersion,
+		OriginalCostPer1KToken: o.OriginalCostPer1KToken,
+		CostPer1KToken:         o.CostPer1KToken,
+	}
+}
+
+func toReplayRunInfo(run *replay.Run) v1.ReplayRunInfo {
+	info := v1.ReplayRunInfo{
+		ID:        run.ID,
+		SessionID: run.SessionID,
+		DeviceID:  run.DeviceID,
+		Options: v1.ReplayOptions{
+			Model:                  run.Options.Model,
+			ModelName:              run.Options.ModelName,
+			Temperature:            run.Options.Temperature,
+			MaxTokens:              run.Options.MaxTokens,
+			SystemPrompt:           run.Options.SystemPrompt,
+			PromptTemplate:         run.Options.PromptTemplate,
+			PromptVersion:          run.Options.PromptVersion,
+			OriginalCostPer1KToken: run.Options.OriginalCostPer1KToken,
+			CostPer1KToken:         run.Options.CostPer1KToken,
+		},
+		ModelName:    run.ModelName,
+		SystemPrompt: run.SystemPrompt,
+		Status:       string(run.Status),
+		Error:        run.Error,
+		CreatedAt:    run.CreatedAt,
+		FinishedAt:   run.FinishedAt,
+	}
+	if run.Summary != nil {
+		info.Summary = &v1.ReplaySummary{
+			Turns:         run.Summary.Turns,
+			Succeeded:     run.Summary.Succeeded,
+			Skipped:       run.Summary.Skipped,
+			AvgSimilarity: run.Summary.AvgSimilarity,
+			Original:      v1.ReplaySideSummary(run.Summary.Original),
+			Replay:        v1.ReplaySideSummary(run.Summary.Replay),
+		}
+	}
+	for _, t := range run.Turns {
+		diff := make([]v1.ReplayDiffSegment, 0, len(t.Diff))
+		for _, d := range t.Diff {
+			diff = append(diff, v1.ReplayDiffSegment{Op: string(d.Op), Text: d.Text})
+		}
+		info.Turns = append(info.Turns, v1.ReplayTurn{
+			Round:      t.Round,
+			Input:      t.Input,
+			Redacted:   t.Redacted,
+			Original:   v1.ReplaySide(t.Original),
+			Replay:     v1.ReplaySide(t.Replay),
+			Similarity: t.Similarity,
+			Diff:       diff,
+			Error:      t.Error,
+		})
+	}
+	return info
+}
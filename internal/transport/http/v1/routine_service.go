@@ -0,0 +1,234 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/routine"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// RoutineServiceV1 V1版本例程服务
+type RoutineServiceV1 struct {
+	logger  *logging.Logger
+	service *routine.Service
+}
+
+// NewRoutineServiceV1 创建例程服务V1实例
+func NewRoutineServiceV1(logger *logging.Logger, service *routine.Service) (*RoutineServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("routine service is required")
+	}
+	return &RoutineServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册例程API路由
+func (s *RoutineServiceV1) Register(router *gin.RouterGroup) {
+	routines := router.Group("/routines")
+	{
+		routines.POST("", s.createRoutine)       // 创建例程
+		routines.GET("", s.listRoutines)         // 获取例程列表
+		routines.GET("/:id", s.getRoutine)       // 获取例程详情
+		routines.PUT("/:id", s.updateRoutine)    // 更新例程
+		routines.DELETE("/:id", s.deleteRoutine) // 删除例程
+		routines.POST("/:id/run", s.runRoutine)  // 立即执行例程
+	}
+}
+
+// createRoutine 创建例程
+// @Summary 创建例程
+// @Description 为设备创建例程，按 cron 计划定时触发或在设备上说出唤醒短语时触发，依次执行天气、新闻、日程、固定文本或工作流动作并合并播报
+// @Tags Routines
+// @Accept json
+// @Produce json
+// @Param request body v1.RoutineCreateRequest true "例程信息"
+// @Success 201 {object} httptransport.APIResponse{data=v1.RoutineInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 409 {object} httptransport.APIResponse
+// @Router /v1/routines [post]
+func (s *RoutineServiceV1) createRoutine(c *gin.Context) {
+	var request v1.RoutineCreateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	s.logger.InfoTag("API", "创建例程", "device_id", request.DeviceID, "name", request.Name, "request_id", getRequestID(c))
+
+	item, err := s.service.Create(c.Request.Context(), routine.CreateRequest{
+		DeviceID: request.DeviceID,
+		Name:     request.Name,
+		Schedule: request.Schedule,
+		Phrase:   request.Phrase,
+		Actions:  fromRoutineActions(request.Actions),
+		Enabled:  request.Enabled,
+	})
+	if err != nil {
+		s.handleError(c, err, "创建例程失败")
+		return
+	}
+	httpUtils.Response.Created(c, toRoutineInfo(item), "例程创建成功")
+}
+
+// listRoutines 获取例程列表
+// @Summary 获取例程列表
+// @Description 按创建时间列出例程，可按设备过滤
+// @Tags Routines
+// @Produce json
+// @Param device_id query string false "设备ID"
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.RoutineInfo}
+// @Router /v1/routines [get]
+func (s *RoutineServiceV1) listRoutines(c *gin.Context) {
+	items := s.service.List(c.Request.Context(), c.Query("device_id"))
+	out := make([]v1.RoutineInfo, 0, len(items))
+	for _, item := range items {
+		out = append(out, toRoutineInfo(item))
+	}
+	httpUtils.Response.Success(c, out, "获取例程列表成功")
+}
+
+// getRoutine 获取例程详情
+// @Summary 获取例程详情
+// @Tags Routines
+// @Produce json
+// @Param id path string true "例程ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.RoutineInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/routines/{id} [get]
+func (s *RoutineServiceV1) getRoutine(c *gin.Context) {
+	item, err := s.service.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取例程失败")
+		return
+	}
+	httpUtils.Response.Success(c, toRoutineInfo(item), "获取例程成功")
+}
+
+// updateRoutine 更新例程
+// @Summary 更新例程
+// @Description 修改例程的名称、计划、唤醒短语、动作或启用状态，修改计划后重新计算下次触发时间
+// @Tags Routines
+// @Accept json
+// @Produce json
+// @Param id path string true "例程ID"
+// @Param request body v1.RoutineUpdateRequest true "更新内容"
+// @Success 200 {object} httptransport.APIResponse{data=v1.RoutineInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Failure 409 {object} httptransport.APIResponse
+// @Router /v1/routines/{id} [put]
+func (s *RoutineServiceV1) updateRoutine(c *gin.Context) {
+	var request v1.RoutineUpdateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	item, err := s.service.Update(c.Request.Context(), c.Param("id"), routine.UpdateRequest{
+		Name:     request.Name,
+		Schedule: request.Schedule,
+		Phrase:   request.Phrase,
+		Actions:  fromRoutineActions(request.Actions),
+		Enabled:  request.Enabled,
+	})
+	if err != nil {
+		s.handleError(c, err, "更新例程失败")
+		return
+	}
+	httpUtils.Response.Success(c, toRoutineInfo(item), "例程更新成功")
+}
+
+// deleteRoutine 删除例程
+// @Summary 删除例程
+// @Tags Routines
+// @Produce json
+// @Param id path string true "例程ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/routines/{id} [delete]
+func (s *RoutineServiceV1) deleteRoutine(c *gin.Context) {
+	if err := s.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		s.handleError(c, err, "删除例程失败")
+		return
+	}
+	httpUtils.Response.Success(c, nil, "例程已删除")
+}
+
+// runRoutine 立即执行例程
+// @Summary 立即执行例程
+// @Description 立即执行例程的全部动作并推送到设备播报，设备不在线时返回 409
+// @Tags Routines
+// @Produce json
+// @Param id path string true "例程ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.RoutineRunResponse}
+// @Failure 404 {object} httptransport.APIResponse
+// @Failure 409 {object} httptransport.APIResponse
+// @Router /v1/routines/{id}/run [post]
+func (s *RoutineServiceV1) runRoutine(c *gin.Context) {
+	reply, err := s.service.RunNow(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "执行例程失败")
+		return
+	}
+	httpUtils.Response.Success(c, v1.RoutineRunResponse{Reply: reply}, "例程已执行")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *RoutineServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, routine.ErrNotFound):
+		httpUtils.Response.NotFound(c, "例程")
+	case errors.Is(err, routine.ErrPhraseTaken):
+		httpUtils.Response.Conflict(c, "唤醒短语已被该设备的其他例程使用")
+	case errors.Is(err, routine.ErrDeviceOffline):
+		httpUtils.Response.Conflict(c, "设备不在线，无法播报")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func fromRoutineActions(actions []v1.RoutineAction) []routine.Action {
+	if actions == nil {
+		return nil
+	}
+	out := make([]routine.Action, 0, len(actions))
+	for _, a := range actions {
+		out = append(out, routine.Action{Type: routine.ActionType(a.Type), Param: a.Param})
+	}
+	return out
+}
+
+func toRoutineInfo(item *routine.Routine) v1.RoutineInfo {
+	actions := make([]v1.RoutineAction, 0, len(item.Actions))
+	for _, a := range item.Actions {
+		actions = append(actions, v1.RoutineAction{Type: string(a.Type), Param: a.Param})
+	}
+	return v1.RoutineInfo{
+		ID:          item.ID,
+		DeviceID:    item.DeviceID,
+		Name:        item.Name,
+		Schedule:    item.Schedule,
+		Phrase:      item.Phrase,
+		Actions:     actions,
+		Enabled:     item.Enabled,
+		Description: item.Describe(),
+		NextRunAt:   item.NextRunAt,
+		LastRunAt:   item.LastRunAt,
+		LastStatus:  string(item.LastStatus),
+		CreatedAt:   item.CreatedAt,
+		UpdatedAt:   item.UpdatedAt,
+	}
+}
@@ -0,0 +1,316 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/script"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// ScriptServiceV1 V1版本脚本服务
+type ScriptServiceV1 struct {
+	logger  *logging.Logger
+	service *script.Service
+}
+
+// NewScriptServiceV1 创建脚本服务V1实例
+func NewScriptServiceV1(logger *logging.Logger, service *script.Service) (*ScriptServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("script service is required")
+	}
+	return &ScriptServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册脚本API路由
+func (s *ScriptServiceV1) Register(router *gin.RouterGroup) {
+	scripts := router.Group("/scripts")
+	{
+		scripts.POST("", s.createScript)                    // 创建脚本
+		scripts.GET("", s.listScripts)                      // 获取脚本列表
+		scripts.GET("/:id", s.getScript)                    // 获取脚本详情
+		scripts.PUT("/:id", s.updateScript)                 // 更新脚本
+		scripts.DELETE("/:id", s.deleteScript)              // 删除脚本
+		scripts.GET("/:id/versions", s.listVersions)        // 获取版本列表
+		scripts.GET("/:id/versions/:version", s.getVersion) // 获取指定版本
+		scripts.POST("/:id/rollback", s.rollbackScript)     // 回滚到历史版本
+		scripts.POST("/:id/test", s.testScript)             // 试运行脚本
+	}
+}
+
+// createScript 创建脚本
+// @Summary 创建脚本
+// @Description 创建供工作流脚本节点使用的 Lua 脚本，保存时检查语法
+// @Tags Scripts
+// @Accept json
+// @Produce json
+// @Param request body v1.ScriptCreateRequest true "脚本信息"
+// @Success 201 {object} httptransport.APIResponse{data=v1.ScriptInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/scripts [post]
+func (s *ScriptServiceV1) createScript(c *gin.Context) {
+	var request v1.ScriptCreateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	sc, err := s.service.Create(c.Request.Context(), script.CreateRequest{
+		Name:        request.Name,
+		Description: request.Description,
+		Source:      request.Source,
+		Comment:     request.Comment,
+	})
+	if err != nil {
+		s.handleError(c, err, "创建脚本失败")
+		return
+	}
+	httpUtils.Response.Created(c, toScriptInfo(sc), "脚本创建成功")
+}
+
+// listScripts 获取脚本列表
+// @Summary 获取脚本列表
+// @Tags Scripts
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.ScriptInfo}
+// @Router /v1/scripts [get]
+func (s *ScriptServiceV1) listScripts(c *gin.Context) {
+	items, err := s.service.List(c.Request.Context())
+	if err != nil {
+		s.handleError(c, err, "获取脚本列表失败")
+		return
+	}
+	scripts := make([]v1.ScriptInfo, 0, len(items))
+	for _, item := range items {
+		scripts = append(scripts, toScriptInfo(item))
+	}
+	httpUtils.Response.Success(c, scripts, "获取脚本列表成功")
+}
+
+// getScript 获取脚本详情
+// @Summary 获取脚本详情
+// @Tags Scripts
+// @Produce json
+// @Param id path string true "脚本ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ScriptInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/scripts/{id} [get]
+func (s *ScriptServiceV1) getScript(c *gin.Context) {
+	sc, err := s.service.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取脚本失败")
+		return
+	}
+	httpUtils.Response.Success(c, toScriptInfo(sc), "获取脚本成功")
+}
+
+// updateScript 更新脚本
+// @Summary 更新脚本
+// @Description 修改源码时生成新版本，引用当前版本的脚本节点在下次执行时使用新源码
+// @Tags Scripts
+// @Accept json
+// @Produce json
+// @Param id path string true "脚本ID"
+// @Param request body v1.ScriptUpdateRequest true "更新内容"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ScriptInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/scripts/{id} [put]
+func (s *ScriptServiceV1) updateScript(c *gin.Context) {
+	var request v1.ScriptUpdateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	sc, err := s.service.Update(c.Request.Context(), c.Param("id"), script.UpdateRequest{
+		Name:        request.Name,
+		Description: request.Description,
+		Source:      request.Source,
+		Comment:     request.Comment,
+	})
+	if err != nil {
+		s.handleError(c, err, "更新脚本失败")
+		return
+	}
+	httpUtils.Response.Success(c, toScriptInfo(sc), "脚本更新成功")
+}
+
+// deleteScript 删除脚本
+// @Summary 删除脚本
+// @Tags Scripts
+// @Produce json
+// @Param id path string true "脚本ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/scripts/{id} [delete]
+func (s *ScriptServiceV1) deleteScript(c *gin.Context) {
+	if err := s.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		s.handleError(c, err, "删除脚本失败")
+		return
+	}
+	httpUtils.Response.Success(c, nil, "脚本已删除")
+}
+
+// listVersions 获取脚本版本列表
+// @Summary 获取脚本版本列表
+// @Tags Scripts
+// @Produce json
+// @Param id path string true "脚本ID"
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.ScriptVersionInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/scripts/{id}/versions [get]
+func (s *ScriptServiceV1) listVersions(c *gin.Context) {
+	items, err := s.service.Versions(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取脚本版本列表失败")
+		return
+	}
+	versions := make([]v1.ScriptVersionInfo, 0, len(items))
+	for _, item := range items {
+		versions = append(versions, toScriptVersionInfo(item))
+	}
+	httpUtils.Response.Success(c, versions, "获取脚本版本列表成功")
+}
+
+// getVersion 获取脚本指定版本
+// @Summary 获取脚本指定版本
+// @Tags Scripts
+// @Produce json
+// @Param id path string true "脚本ID"
+// @Param version path int true "版本号"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ScriptVersionInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/scripts/{id}/versions/{version} [get]
+func (s *ScriptServiceV1) getVersion(c *gin.Context) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version <= 0 {
+		httpUtils.Response.BadRequest(c, "version 必须是正整数")
+		return
+	}
+	v, err := s.service.Version(c.Request.Context(), c.Param("id"), version)
+	if err != nil {
+		s.handleError(c, err, "获取脚本版本失败")
+		return
+	}
+	httpUtils.Response.Success(c, toScriptVersionInfo(v), "获取脚本版本成功")
+}
+
+// rollbackScript 回滚脚本
+// @Summary 回滚脚本
+// @Description 以历史版本的源码创建新版本
+// @Tags Scripts
+// @Accept json
+// @Produce json
+// @Param id path string true "脚本ID"
+// @Param request body v1.ScriptRollbackRequest true "目标版本"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ScriptInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/scripts/{id}/rollback [post]
+func (s *ScriptServiceV1) rollbackScript(c *gin.Context) {
+	var request v1.ScriptRollbackRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	sc, err := s.service.Rollback(c.Request.Context(), c.Param("id"), request.Version)
+	if err != nil {
+		s.handleError(c, err, "回滚脚本失败")
+		return
+	}
+	httpUtils.Response.Success(c, toScriptInfo(sc), "脚本已回滚")
+}
+
+// testScript 试运行脚本
+// @Summary 试运行脚本
+// @Description 以给定输入在沙箱中运行脚本，返回输出和日志；脚本错误或超时返回 400
+// @Tags Scripts
+// @Accept json
+// @Produce json
+// @Param id path string true "脚本ID"
+// @Param request body v1.ScriptTestRequest false "试运行参数"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ScriptTestResult}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/scripts/{id}/test [post]
+func (s *ScriptServiceV1) testScript(c *gin.Context) {
+	var request v1.ScriptTestRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			httpUtils.Response.ValidationError(c, err)
+			return
+		}
+	}
+	var timeout time.Duration
+	if request.Timeout != "" {
+		d, err := time.ParseDuration(request.Timeout)
+		if err != nil || d <= 0 {
+			httpUtils.Response.BadRequest(c, "timeout 必须是正的时长，如 5s")
+			return
+		}
+		timeout = d
+	}
+
+	result, err := s.service.Test(c.Request.Context(), c.Param("id"), script.TestRequest{
+		Version: request.Version,
+		Inputs:  request.Inputs,
+		Timeout: timeout,
+	})
+	if err != nil {
+		s.handleError(c, err, "试运行脚本失败")
+		return
+	}
+	httpUtils.Response.Success(c, v1.ScriptTestResult{Outputs: result.Outputs, Logs: result.Logs}, "脚本运行成功")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *ScriptServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, script.ErrNotFound):
+		httpUtils.Response.NotFound(c, "脚本")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toScriptInfo(sc *script.Script) v1.ScriptInfo {
+	return v1.ScriptInfo{
+		ID:          sc.ID,
+		Name:        sc.Name,
+		Description: sc.Description,
+		Language:    string(sc.Language),
+		Version:     sc.Version,
+		Source:      sc.Source,
+		CreatedAt:   sc.CreatedAt,
+		UpdatedAt:   sc.UpdatedAt,
+	}
+}
+
+func toScriptVersionInfo(v *script.Version) v1.ScriptVersionInfo {
+	return v1.ScriptVersionInfo{
+		ScriptID:  v.ScriptID,
+		Version:   v.Version,
+		Source:    v.Source,
+		Comment:   v.Comment,
+		CreatedAt: v.CreatedAt,
+	}
+}
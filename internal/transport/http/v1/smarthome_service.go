@@ -0,0 +1,290 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/smarthome"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// SmartHomeServiceV1 V1版本智能家居服务
+type SmartHomeServiceV1 struct {
+	logger  *logging.Logger
+	service *smarthome.Service
+}
+
+// NewSmartHomeServiceV1 创建智能家居服务V1实例
+func NewSmartHomeServiceV1(logger *logging.Logger, service *smarthome.Service) (*SmartHomeServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("smart home service is required")
+	}
+	return &SmartHomeServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册智能家居API路由
+func (s *SmartHomeServiceV1) Register(router *gin.RouterGroup) {
+	smartHome := router.Group("/smarthome")
+	{
+		smartHome.GET("/entities", s.listEntities)               // 列出实体
+		smartHome.GET("/entities/:id", s.getEntity)              // 获取实体及其当前状态
+		smartHome.POST("/entities/:id/control", s.controlEntity) // 控制实体
+		smartHome.POST("/discover", s.discover)                  // 向所有桥接重新发现实体
+		smartHome.GET("/aliases", s.listAliases)                 // 列出租户的设备别名
+		smartHome.POST("/aliases", s.saveAlias)                  // 设置设备别名
+		smartHome.DELETE("/aliases/:alias", s.deleteAlias)       // 删除设备别名
+	}
+}
+
+// listEntities 列出实体
+// @Summary 列出智能家居实体
+// @Description 返回缓存的实体及其状态，可按房间和类型过滤
+// @Tags SmartHome
+// @Produce json
+// @Param room query string false "房间"
+// @Param type query string false "实体类型 light/switch/cover/climate/lock/fan/sensor"
+// @Param refresh query bool false "先向所有桥接重新发现实体"
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.SmartHomeEntity}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/smarthome/entities [get]
+func (s *SmartHomeServiceV1) listEntities(c *gin.Context) {
+	var query v1.SmartHomeEntityQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	entities := s.service.Entities()
+	if query.Refresh {
+		var err error
+		if entities, err = s.service.Discover(c.Request.Context()); err != nil {
+			s.handleError(c, err, "发现实体失败")
+			return
+		}
+	}
+	result := make([]v1.SmartHomeEntity, 0, len(entities))
+	for _, entity := range entities {
+		if query.Room != "" && entity.Room != query.Room {
+			continue
+		}
+		if query.Type != "" && entity.Type != query.Type {
+			continue
+		}
+		result = append(result, toSmartHomeEntity(entity))
+	}
+	httpUtils.Response.Success(c, result, "获取实体列表成功")
+}
+
+// getEntity 获取实体
+// @Summary 获取智能家居实体
+// @Description 缓存的状态过期时先向桥接刷新
+// @Tags SmartHome
+// @Produce json
+// @Param id path string true "实体ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.SmartHomeEntity}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/smarthome/entities/{id} [get]
+func (s *SmartHomeServiceV1) getEntity(c *gin.Context) {
+	entity, err := s.service.Entity(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取实体失败")
+		return
+	}
+	httpUtils.Response.Success(c, toSmartHomeEntity(entity), "获取实体成功")
+}
+
+// controlEntity 控制实体
+// @Summary 控制智能家居实体
+// @Description 返回控制后预期的实体状态；窗帘的 turn_on/turn_off 按 open/close 处理
+// @Tags SmartHome
+// @Accept json
+// @Produce json
+// @Param id path string true "实体ID"
+// @Param request body v1.SmartHomeControlRequest true "控制动作"
+// @Success 200 {object} httptransport.APIResponse{data=v1.SmartHomeEntity}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Failure 409 {object} httptransport.APIResponse
+// @Router /v1/smarthome/entities/{id}/control [post]
+func (s *SmartHomeServiceV1) controlEntity(c *gin.Context) {
+	var request v1.SmartHomeControlRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	action := smarthome.Action(request.Action)
+	var value float64
+	if action.NeedsValue() {
+		if request.Value == nil {
+			httpUtils.Response.BadRequest(c, "value 不能为空")
+			return
+		}
+		value = *request.Value
+	}
+
+	id := c.Param("id")
+	entity, err := s.service.Control(c.Request.Context(), id, action, value)
+	if err != nil {
+		s.handleError(c, err, "控制实体失败")
+		return
+	}
+	s.logger.InfoTag("API", "控制智能家居实体", "entity_id", id, "action", request.Action, "request_id", getRequestID(c))
+	httpUtils.Response.Success(c, toSmartHomeEntity(entity), "操作成功")
+}
+
+// discover 重新发现实体
+// @Summary 重新发现智能家居实体
+// @Description 向所有桥接重新发现实体，单个桥接失败时保留它上次发现的实体
+// @Tags SmartHome
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.SmartHomeEntity}
+// @Failure 500 {object} httptransport.APIResponse
+// @Router /v1/smarthome/discover [post]
+func (s *SmartHomeServiceV1) discover(c *gin.Context) {
+	entities, err := s.service.Discover(c.Request.Context())
+	if err != nil {
+		s.handleError(c, err, "发现实体失败")
+		return
+	}
+	result := make([]v1.SmartHomeEntity, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, toSmartHomeEntity(entity))
+	}
+	httpUtils.Response.Success(c, result, "发现实体成功")
+}
+
+// listAliases 列出设备别名
+// @Summary 列出智能家居设备别名
+// @Description 返回当前租户的设备别名，包括通过接口设置的和从语音纠正中学到的
+// @Tags SmartHome
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.SmartHomeAlias}
+// @Failure 500 {object} httptransport.APIResponse
+// @Router /v1/smarthome/aliases [get]
+func (s *SmartHomeServiceV1) listAliases(c *gin.Context) {
+	aliases, err := s.service.Aliases(c.Request.Context())
+	if err != nil {
+		s.handleError(c, err, "获取设备别名失败")
+		return
+	}
+	names := s.entityNames()
+	result := make([]v1.SmartHomeAlias, 0, len(aliases))
+	for _, alias := range aliases {
+		result = append(result, toSmartHomeAlias(alias, names[alias.EntityID]))
+	}
+	httpUtils.Response.Success(c, result, "获取设备别名成功")
+}
+
+// saveAlias 设置设备别名
+// @Summary 设置智能家居设备别名
+// @Description 把别名指向实体，别名已存在时改为指向新实体；比较别名时忽略空白、标点和“的”
+// @Tags SmartHome
+// @Accept json
+// @Produce json
+// @Param request body v1.SmartHomeAliasRequest true "别名和实体ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.SmartHomeAlias}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/smarthome/aliases [post]
+func (s *SmartHomeServiceV1) saveAlias(c *gin.Context) {
+	var request v1.SmartHomeAliasRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	alias, err := s.service.SetAlias(c.Request.Context(), request.Alias, request.EntityID, smarthome.AliasManual)
+	if err != nil {
+		s.handleError(c, err, "设置设备别名失败")
+		return
+	}
+	s.logger.InfoTag("API", "设置智能家居设备别名", "alias", alias.Alias, "entity_id", alias.EntityID, "request_id", getRequestID(c))
+	httpUtils.Response.Success(c, toSmartHomeAlias(alias, s.entityNames()[alias.EntityID]), "设置设备别名成功")
+}
+
+// deleteAlias 删除设备别名
+// @Summary 删除智能家居设备别名
+// @Tags SmartHome
+// @Produce json
+// @Param alias path string true "别名"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/smarthome/aliases/{alias} [delete]
+func (s *SmartHomeServiceV1) deleteAlias(c *gin.Context) {
+	if err := s.service.DeleteAlias(c.Request.Context(), c.Param("alias")); err != nil {
+		s.handleError(c, err, "删除设备别名失败")
+		return
+	}
+	httpUtils.Response.Success(c, nil, "删除设备别名成功")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *SmartHomeServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, smarthome.ErrNotFound):
+		httpUtils.Response.NotFound(c, "实体")
+	case errors.Is(err, smarthome.ErrAliasNotFound):
+		httpUtils.Response.NotFound(c, "别名")
+	case errors.Is(err, smarthome.ErrUnsupported):
+		httpUtils.Response.BadRequest(c, err.Error())
+	case errors.Is(err, smarthome.ErrUnavailable):
+		httpUtils.Response.Conflict(c, "设备离线，无法控制")
+	case errors.Is(err, smarthome.ErrBridge):
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message+"：桥接不可用")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toSmartHomeEntity(entity smarthome.Entity) v1.SmartHomeEntity {
+	features := entity.Features
+	if features == nil {
+		features = []string{}
+	}
+	return v1.SmartHomeEntity{
+		ID:        entity.ID,
+		Bridge:    entity.Bridge,
+		Name:      entity.Name,
+		Type:      entity.Type,
+		Room:      entity.Room,
+		Aliases:   entity.Aliases,
+		Features:  features,
+		State:     entity.State,
+		Available: entity.Available,
+		UpdatedAt: entity.UpdatedAt,
+	}
+}
+
+// entityNames 返回实体ID到播报名称的映射
+func (s *SmartHomeServiceV1) entityNames() map[string]string {
+	names := make(map[string]string)
+	for _, entity := range s.service.Entities() {
+		names[entity.ID] = entity.DisplayName()
+	}
+	return names
+}
+
+func toSmartHomeAlias(alias *smarthome.Alias, entityName string) v1.SmartHomeAlias {
+	return v1.SmartHomeAlias{
+		Alias:      alias.Alias,
+		EntityID:   alias.EntityID,
+		EntityName: entityName,
+		Source:     alias.Source,
+		Hits:       alias.Hits,
+		UpdatedAt:  alias.UpdatedAt,
+	}
+}
@@ -0,0 +1,335 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/speechtext"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// SpeechTextServiceV1 V1版本播报文本后处理服务
+type SpeechTextServiceV1 struct {
+	logger  *logging.Logger
+	service *speechtext.Service
+}
+
+// NewSpeechTextServiceV1 创建播报文本后处理服务V1实例
+func NewSpeechTextServiceV1(logger *logging.Logger, service *speechtext.Service) (*SpeechTextServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("speech text service is required")
+	}
+	return &SpeechTextServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册播报文本后处理API路由
+func (s *SpeechTextServiceV1) Register(router *gin.RouterGroup) {
+	profileGroup := router.Group("/speech-profiles")
+	{
+		profileGroup.GET("", s.listProfiles)           // 获取方案列表和设备分配
+		profileGroup.POST("", s.createProfile)         // 创建或覆盖方案
+		profileGroup.POST("/preview", s.preview)       // 试运行
+		profileGroup.GET("/:name", s.getProfile)       // 获取方案
+		profileGroup.PUT("/:name", s.updateProfile)    // 创建或覆盖方案
+		profileGroup.DELETE("/:name", s.deleteProfile) // 删除方案
+	}
+	router.GET("/devices/:id/speech-profile", s.getAssignment) // 获取设备使用的方案
+	router.PUT("/devices/:id/speech-profile", s.assign)        // 为设备分配方案
+	router.DELETE("/devices/:id/speech-profile", s.unassign)   // 取消设备的方案分配
+}
+
+// listProfiles 获取方案列表
+// @Summary 获取播报文本后处理方案列表
+// @Description 列出全部方案和设备分配；数据库中没有 default 方案时列表包含内置方案
+// @Tags SpeechText
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=v1.SpeechTextProfileListResponse}
+// @Router /v1/speech-profiles [get]
+func (s *SpeechTextServiceV1) listProfiles(c *gin.Context) {
+	ctx := c.Request.Context()
+	profiles, err := s.service.List(ctx)
+	if err != nil {
+		s.handleError(c, err, "获取播报文本方案列表失败")
+		return
+	}
+	assignments, err := s.service.ListAssignments(ctx)
+	if err != nil {
+		s.handleError(c, err, "获取播报文本方案列表失败")
+		return
+	}
+
+	response := v1.SpeechTextProfileListResponse{
+		Profiles:    make([]v1.SpeechTextProfileInfo, 0, len(profiles)),
+		Assignments: make([]v1.SpeechTextAssignmentInfo, 0, len(assignments)),
+	}
+	for _, p := range profiles {
+		response.Profiles = append(response.Profiles, toSpeechTextProfileInfo(p))
+	}
+	for _, a := range assignments {
+		response.Assignments = append(response.Assignments, toSpeechTextAssignmentInfo(a))
+	}
+	httpUtils.Response.Success(c, response, "获取播报文本方案列表成功")
+}
+
+// getProfile 获取方案
+// @Summary 获取播报文本后处理方案
+// @Tags SpeechText
+// @Produce json
+// @Param name path string true "方案名称"
+// @Success 200 {object} httptransport.APIResponse{data=v1.SpeechTextProfileInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/speech-profiles/{name} [get]
+func (s *SpeechTextServiceV1) getProfile(c *gin.Context) {
+	p, err := s.service.Get(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		s.handleError(c, err, "获取播报文本方案失败")
+		return
+	}
+	httpUtils.Response.Success(c, toSpeechTextProfileInfo(p), "获取播报文本方案成功")
+}
+
+// createProfile 创建方案
+// @Summary 创建播报文本后处理方案
+// @Description 步骤按顺序执行：markdown 去掉 Markdown 标记，emoji 去掉表情，units 把日期、时间、范围、百分比、温度和计量单位转为读法，replace 按发音词典替换，regex 正则替换。同名方案会被覆盖，修改对之后建立的连接生效
+// @Tags SpeechText
+// @Accept json
+// @Produce json
+// @Param request body v1.SpeechTextProfileRequest true "方案"
+// @Success 200 {object} httptransport.APIResponse{data=v1.SpeechTextProfileInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/speech-profiles [post]
+func (s *SpeechTextServiceV1) createProfile(c *gin.Context) {
+	var request v1.SpeechTextProfileRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	s.saveProfile(c, request.Name, request)
+}
+
+// updateProfile 创建或覆盖方案
+// @Summary 创建或覆盖播报文本后处理方案
+// @Description 保存名为 default 的方案会替换内置方案，作为未分配方案的设备的默认方案
+// @Tags SpeechText
+// @Accept json
+// @Produce json
+// @Param name path string true "方案名称"
+// @Param request body v1.SpeechTextProfileRequest true "方案"
+// @Success 200 {object} httptransport.APIResponse{data=v1.SpeechTextProfileInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/speech-profiles/{name} [put]
+func (s *SpeechTextServiceV1) updateProfile(c *gin.Context) {
+	var request v1.SpeechTextProfileRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	s.saveProfile(c, c.Param("name"), request)
+}
+
+func (s *SpeechTextServiceV1) saveProfile(c *gin.Context, name string, request v1.SpeechTextProfileRequest) {
+	s.logger.InfoTag("API", "保存播报文本方案", "name", name, "request_id", getRequestID(c))
+	p, err := s.service.Save(c.Request.Context(), &speechtext.Profile{
+		Name:        name,
+		Description: request.Description,
+		Stages:      fromSpeechTextStages(request.Stages),
+	})
+	if err != nil {
+		s.handleError(c, err, "保存播报文本方案失败")
+		return
+	}
+	httpUtils.Response.Success(c, toSpeechTextProfileInfo(p), "播报文本方案已保存")
+}
+
+// deleteProfile 删除方案
+// @Summary 删除播报文本后处理方案
+// @Description 同时取消使用该方案的设备分配；删除 default 方案后恢复使用内置方案
+// @Tags SpeechText
+// @Produce json
+// @Param name path string true "方案名称"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/speech-profiles/{name} [delete]
+func (s *SpeechTextServiceV1) deleteProfile(c *gin.Context) {
+	name := c.Param("name")
+	s.logger.InfoTag("API", "删除播报文本方案", "name", name, "request_id", getRequestID(c))
+	if err := s.service.Delete(c.Request.Context(), name); err != nil {
+		s.handleError(c, err, "删除播报文本方案失败")
+		return
+	}
+	httpUtils.Response.Success(c, nil, "播报文本方案已删除")
+}
+
+// preview 试运行
+// @Summary 试运行播报文本后处理
+// @Description 用已保存的方案或未保存的步骤处理文本，返回送入 TTS 的文本；profile 和 stages 都为空时使用 default 方案
+// @Tags SpeechText
+// @Accept json
+// @Produce json
+// @Param request body v1.SpeechTextPreviewRequest true "试运行请求"
+// @Success 200 {object} httptransport.APIResponse{data=v1.SpeechTextPreviewResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/speech-profiles/preview [post]
+func (s *SpeechTextServiceV1) preview(c *gin.Context) {
+	var request v1.SpeechTextPreviewRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	stages := fromSpeechTextStages(request.Stages)
+	if len(request.Stages) == 0 {
+		name := request.Profile
+		if name == "" {
+			name = speechtext.DefaultProfile
+		}
+		p, err := s.service.Get(c.Request.Context(), name)
+		if err != nil {
+			s.handleError(c, err, "试运行播报文本方案失败")
+			return
+		}
+		stages = p.Stages
+	}
+	text, err := s.service.Preview(stages, request.Text)
+	if err != nil {
+		s.handleError(c, err, "试运行播报文本方案失败")
+		return
+	}
+	httpUtils.Response.Success(c, v1.SpeechTextPreviewResponse{Text: text, Source: request.Text}, "试运行成功")
+}
+
+// getAssignment 获取设备使用的方案
+// @Summary 获取设备使用的播报文本方案
+// @Description 未分配方案的设备使用 default 方案
+// @Tags SpeechText
+// @Produce json
+// @Param id path string true "设备ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.SpeechTextAssignmentInfo}
+// @Router /v1/devices/{id}/speech-profile [get]
+func (s *SpeechTextServiceV1) getAssignment(c *gin.Context) {
+	a, err := s.service.Assignment(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取设备播报文本方案失败")
+		return
+	}
+	httpUtils.Response.Success(c, toSpeechTextAssignmentInfo(a), "获取设备播报文本方案成功")
+}
+
+// assign 为设备分配方案
+// @Summary 为设备分配播报文本方案
+// @Description 设备重新连接后生效
+// @Tags SpeechText
+// @Accept json
+// @Produce json
+// @Param id path string true "设备ID"
+// @Param request body v1.SpeechTextAssignRequest true "分配请求"
+// @Success 200 {object} httptransport.APIResponse{data=v1.SpeechTextAssignmentInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/devices/{id}/speech-profile [put]
+func (s *SpeechTextServiceV1) assign(c *gin.Context) {
+	var request v1.SpeechTextAssignRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	deviceID := c.Param("id")
+	s.logger.InfoTag("API", "分配播报文本方案", "device_id", deviceID, "profile", request.Profile, "request_id", getRequestID(c))
+	a, err := s.service.Assign(c.Request.Context(), deviceID, request.Profile)
+	if err != nil {
+		s.handleError(c, err, "分配播报文本方案失败")
+		return
+	}
+	httpUtils.Response.Success(c, toSpeechTextAssignmentInfo(a), "播报文本方案已分配")
+}
+
+// unassign 取消设备的方案分配
+// @Summary 取消设备的播报文本方案分配
+// @Description 取消后设备使用 default 方案，重新连接后生效
+// @Tags SpeechText
+// @Produce json
+// @Param id path string true "设备ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Router /v1/devices/{id}/speech-profile [delete]
+func (s *SpeechTextServiceV1) unassign(c *gin.Context) {
+	if err := s.service.Unassign(c.Request.Context(), c.Param("id")); err != nil {
+		s.handleError(c, err, "取消播报文本方案分配失败")
+		return
+	}
+	httpUtils.Response.Success(c, nil, "播报文本方案分配已取消")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *SpeechTextServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, speechtext.ErrNotFound):
+		httpUtils.Response.NotFound(c, "播报文本方案")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func fromSpeechTextStages(stages []v1.SpeechTextStage) []speechtext.Stage {
+	out := make([]speechtext.Stage, 0, len(stages))
+	for _, st := range stages {
+		out = append(out, speechtext.Stage{
+			Type:        st.Type,
+			Language:    st.Language,
+			Words:       st.Words,
+			Pattern:     st.Pattern,
+			Replacement: st.Replacement,
+		})
+	}
+	return out
+}
+
+func toSpeechTextProfileInfo(p *speechtext.Profile) v1.SpeechTextProfileInfo {
+	stages := make([]v1.SpeechTextStage, 0, len(p.Stages))
+	for _, st := range p.Stages {
+		stages = append(stages, v1.SpeechTextStage{
+			Type:        st.Type,
+			Language:    st.Language,
+			Words:       st.Words,
+			Pattern:     st.Pattern,
+			Replacement: st.Replacement,
+		})
+	}
+	info := v1.SpeechTextProfileInfo{
+		Name:        p.Name,
+		Description: p.Description,
+		Stages:      stages,
+		BuiltIn:     p.BuiltIn,
+	}
+	if !p.BuiltIn {
+		updatedAt := p.UpdatedAt
+		info.UpdatedAt = &updatedAt
+	}
+	return info
+}
+
+func toSpeechTextAssignmentInfo(a *speechtext.Assignment) v1.SpeechTextAssignmentInfo {
+	info := v1.SpeechTextAssignmentInfo{
+		DeviceID: a.DeviceID,
+		Profile:  a.Profile,
+	}
+	if !a.UpdatedAt.IsZero() {
+		updatedAt := a.UpdatedAt
+		info.UpdatedAt = &updatedAt
+	}
+	return info
+}
@@ -0,0 +1,268 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/intent"
+	"xiaozhi-server-go/internal/platform/drain"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/platform/selftest"
+	"xiaozhi-server-go/internal/platform/storage"
+	pluginmetrics "xiaozhi-server-go/internal/plugin/metrics"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+	"xiaozhi-server-go/internal/workflow"
+)
+
+// SystemServiceV1 V1版本系统运维服务
+type SystemServiceV1 struct {
+	logger       *logging.Logger
+	drainer      *drain.Controller
+	drainTimeout time.Duration
+	selfTest     func(context.Context) *selftest.Report // 为 nil 时不提供自检接口
+	metrics      *pluginmetrics.Aggregator              // 为 nil 时不提供指标接口
+}
+
+// NewSystemServiceV1 创建系统运维服务V1实例，selfTest 为在运行中的服务上执行自检的函数
+func NewSystemServiceV1(logger *logging.Logger, drainer *drain.Controller, drainTimeout time.Duration, selfTest func(context.Context) *selftest.Report) (*SystemServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if drainer == nil {
+		return nil, fmt.Errorf("drain controller is required")
+	}
+	return &SystemServiceV1{
+		logger:       logger,
+		drainer:      drainer,
+		drainTimeout: drainTimeout,
+		selfTest:     selfTest,
+	}, nil
+}
+
+// SetPluginMetrics 设置插件指标汇总器，用于以 Prometheus 文本格式输出插件指标
+func (s *SystemServiceV1) SetPluginMetrics(aggregator *pluginmetrics.Aggregator) {
+	s.metrics = aggregator
+}
+
+// Register 注册系统运维API路由
+func (s *SystemServiceV1) Register(router *gin.RouterGroup) {
+	system := router.Group("/system")
+	{
+		system.POST("/drain", s.startDrain)         // 进入排空模式
+		system.GET("/drain", s.getDrainStatus)      // 获取排空进度
+		system.GET("/database", s.getDatabaseStats) // 获取数据库耗时与连接池统计
+		system.GET("/selftest", s.runSelfTest)      // 执行启动自检
+		system.GET("/boot-report", s.getBootReport) // 获取启动耗时报告
+		system.GET("/metrics", s.getMetrics)        // 获取 Prometheus 格式的插件指标
+		system.GET("/intents", s.getIntentStats)    // 获取意图路由统计
+	}
+}
+
+// startDrain 进入排空模式
+// @Summary 进入排空模式
+// @Description 停止接受新的WebSocket会话和工作流执行，等待活跃会话结束（最长至截止时间）后正常关停；重复调用返回当前进度
+// @Tags System
+// @Accept json
+// @Produce json
+// @Param request body v1.DrainRequest false "排空参数"
+// @Success 202 {object} httptransport.APIResponse{data=v1.DrainStatus}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/system/drain [post]
+func (s *SystemServiceV1) startDrain(c *gin.Context) {
+	var request v1.DrainRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			httpUtils.Response.ValidationError(c, err)
+			return
+		}
+	}
+	if request.TimeoutSeconds < 0 {
+		httpUtils.Response.BadRequest(c, "timeout_seconds 不能为负数")
+		return
+	}
+
+	timeout := s.drainTimeout
+	if request.TimeoutSeconds > 0 {
+		timeout = time.Duration(request.TimeoutSeconds) * time.Second
+	}
+	reason := request.Reason
+	if reason == "" {
+		reason = "api"
+	}
+
+	s.logger.InfoTag("API", "请求进入排空模式，原因: %s，request_id: %s", reason, getRequestID(c))
+	status := s.drainer.Start(timeout, reason)
+	httpUtils.Response.Accepted(c, toDrainStatus(status), "已进入排空模式")
+}
+
+// getDrainStatus 获取排空进度
+// @Summary 获取排空进度
+// @Tags System
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=v1.DrainStatus}
+// @Router /v1/system/drain [get]
+func (s *SystemServiceV1) getDrainStatus(c *gin.Context) {
+	httpUtils.Response.Success(c, toDrainStatus(s.drainer.Status()), "获取排空进度成功")
+}
+
+// getDatabaseStats 获取数据库耗时与连接池统计
+// @Summary 获取数据库耗时与连接池统计
+// @Description 获取各类操作（query/create/update/delete/row/raw）的次数、错误数、锁冲突次数和平均/最大耗时，以及写串行化排队和连接池状态，用于验证 WAL 与连接池调优效果
+// @Tags System
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=storage.DBStats}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/system/database [get]
+func (s *SystemServiceV1) getDatabaseStats(c *gin.Context) {
+	stats, ok := storage.Stats()
+	if !ok {
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeResourceNotFound, "数据库未初始化")
+		return
+	}
+	httpUtils.Response.Success(c, stats, "获取数据库统计成功")
+}
+
+// runSelfTest 执行启动自检
+// @Summary 执行启动自检
+// @Description 按初始化依赖图执行只读检查：数据库连接与表结构、配置与密钥、所选提供者的连通性、日志与插件目录、外部 MCP 服务命令，返回逐项结果；运行中的服务不检查端口占用。与 xiaozhi-server doctor 的检查相同
+// @Tags System
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=v1.SelfTestReport}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/system/selftest [get]
+func (s *SystemServiceV1) runSelfTest(c *gin.Context) {
+	if s.selfTest == nil {
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeResourceNotFound, "自检不可用")
+		return
+	}
+	report := s.selfTest(c.Request.Context())
+	s.logger.InfoTag("API", "执行启动自检，通过: %v，request_id: %s", report.Passed, getRequestID(c))
+	httpUtils.Response.Success(c, toSelfTestReport(report), "自检完成")
+}
+
+// getBootReport 获取启动耗时报告
+// @Summary 获取启动耗时报告
+// @Description 获取本次启动各初始化步骤的开始时间、耗时、超时设置和状态，以及决定启动总耗时的关键路径，用于排查启动缓慢；依赖关系满足的步骤并行执行，步骤累计耗时可能大于总耗时
+// @Tags System
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=v1.BootReport}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/system/boot-report [get]
+func (s *SystemServiceV1) getBootReport(c *gin.Context) {
+	record := workflow.CurrentStartupRecord()
+	if record == nil {
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeResourceNotFound, "启动记录不可用")
+		return
+	}
+	httpUtils.Response.Success(c, toBootReport(record.Report()), "获取启动耗时报告成功")
+}
+
+// getMetrics 获取插件指标
+// @Summary 获取插件指标
+// @Description 以 Prometheus 文本格式输出各插件通过 ScrapeMetrics 上报的指标，每个序列带 plugin_id 标签；另含各插件的 plugin_scrape_up 和 plugin_scrape_duration_seconds。数据为最近一次定期采集的结果，间隔见 PluginMetrics.Interval
+// @Tags System
+// @Produce plain
+// @Success 200 {string} string "Prometheus 文本格式指标"
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/system/metrics [get]
+func (s *SystemServiceV1) getMetrics(c *gin.Context) {
+	if s.metrics == nil {
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeResourceNotFound, "插件指标汇总未启用")
+		return
+	}
+	c.Header("Content-Type", pluginmetrics.ContentType)
+	c.Status(http.StatusOK)
+	if err := s.metrics.WritePrometheus(c.Writer); err != nil {
+		s.logger.WarnTag("API", "输出插件指标失败: %v", err)
+	}
+}
+
+// getIntentStats 获取意图路由统计
+// @Summary 获取意图路由统计
+// @Description 获取进程启动以来各意图的分类命中、由处理器完成、回落到LLM和处理失败的次数及平均路由耗时，所有连接累计；intent 为 llm 的条目是未命中任何意图的语句
+// @Tags System
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.IntentRouteStats}
+// @Router /v1/system/intents [get]
+func (s *SystemServiceV1) getIntentStats(c *gin.Context) {
+	stats := intent.RouteStats()
+	items := make([]v1.IntentRouteStats, len(stats))
+	for i, st := range stats {
+		items[i] = v1.IntentRouteStats{
+			Intent:           st.Intent,
+			Matched:          st.Matched,
+			Handled:          st.Handled,
+			FallbackToLLM:    st.FallbackToLLM,
+			Errors:           st.Errors,
+			AverageLatencyMs: st.AverageLatency,
+		}
+	}
+	httpUtils.Response.Success(c, items, "获取意图路由统计成功")
+}
+
+func toBootReport(report workflow.StartupReport) v1.BootReport {
+	info := v1.BootReport{
+		StartedAt:    report.StartedAt,
+		FinishedAt:   report.FinishedAt,
+		Status:       string(report.Status),
+		Error:        report.Error,
+		DurationMs:   report.Duration.Milliseconds(),
+		StepTotalMs:  report.StepTotal.Milliseconds(),
+		Steps:        make([]v1.BootStepTiming, len(report.Steps)),
+		CriticalPath: report.CriticalPath,
+	}
+	for i, step := range report.Steps {
+		info.Steps[i] = v1.BootStepTiming{
+			ID:         step.ID,
+			Title:      step.Title,
+			DependsOn:  step.DependsOn,
+			Status:     string(step.Status),
+			Error:      step.Error,
+			OffsetMs:   step.Offset.Milliseconds(),
+			DurationMs: step.Duration.Milliseconds(),
+			TimeoutMs:  step.Timeout.Milliseconds(),
+		}
+	}
+	return info
+}
+
+func toSelfTestReport(report *selftest.Report) v1.SelfTestReport {
+	info := v1.SelfTestReport{
+		StartedAt:  report.StartedAt,
+		DurationMs: report.DurationMs,
+		Passed:     report.Passed,
+		Results:    make([]v1.SelfTestResult, len(report.Results)),
+	}
+	for i, result := range report.Results {
+		info.Results[i] = v1.SelfTestResult{
+			Step:       result.Step,
+			Name:       result.Name,
+			Status:     string(result.Status),
+			Detail:     result.Detail,
+			DurationMs: result.DurationMs,
+		}
+	}
+	return info
+}
+
+func toDrainStatus(status drain.Status) v1.DrainStatus {
+	info := v1.DrainStatus{
+		State:     status.State,
+		Reason:    status.Reason,
+		StartedAt: status.StartedAt,
+		Deadline:  status.Deadline,
+		Active:    status.Active,
+		TimedOut:  status.TimedOut,
+	}
+	if status.Deadline != nil && status.State == drain.StateDraining {
+		if remaining := time.Until(*status.Deadline); remaining > 0 {
+			info.Remaining = int64(remaining.Seconds())
+		}
+	}
+	return info
+}
@@ -0,0 +1,397 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/tenant"
+	"xiaozhi-server-go/internal/platform/config"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/middleware"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// TenantServiceV1 V1版本租户服务
+type TenantServiceV1 struct {
+	config  *config.Config
+	logger  *logging.Logger
+	service *tenant.Service
+}
+
+// NewTenantServiceV1 创建租户服务V1实例
+func NewTenantServiceV1(config *config.Config, logger *logging.Logger, service *tenant.Service) (*TenantServiceV1, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("tenant service is required")
+	}
+	return &TenantServiceV1{
+		config:  config,
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册租户API路由，租户管理接口仅管理员可用
+func (s *TenantServiceV1) Register(router *gin.RouterGroup) {
+	router.GET("/tenant", s.currentTenant)             // 当前租户及今日用量
+	router.GET("/tenant/providers", s.getProviders)    // 当前租户的供应商配置
+	router.PUT("/tenant/providers", s.updateProviders) // 替换当前租户的供应商配置
+
+	tenants := router.Group("/tenants", middleware.RequireAdmin())
+	{
+		tenants.POST("", s.createTenant)            // 创建租户
+		tenants.GET("", s.listTenants)              // 获取租户列表
+		tenants.GET("/:id", s.getTenant)            // 获取租户
+		tenants.PUT("/:id", s.updateTenant)         // 修改名称、启用状态或配额
+		tenants.DELETE("/:id", s.deleteTenant)      // 删除租户
+		tenants.POST("/:id/token", s.rotateToken)   // 重新签发访问令牌
+		tenants.GET("/:id/usage", s.getTenantUsage) // 查询用量
+	}
+}
+
+// currentTenant 获取当前租户
+// @Summary 获取当前租户
+// @Description 返回请求所属租户的信息和今日用量；管理员未指定 X-Tenant-ID 时返回默认租户
+// @Tags Tenants
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=v1.TenantUsageInfo}
+// @Router /v1/tenant [get]
+func (s *TenantServiceV1) currentTenant(c *gin.Context) {
+	s.respondUsage(c, tenant.IDFrom(c.Request.Context()), "")
+}
+
+// getProviders 获取当前租户的供应商配置
+// @Summary 获取租户供应商配置
+// @Description 返回请求所属租户的 LLM 和 TTS 配置及选择，密钥脱敏为 ******；管理员未指定 X-Tenant-ID 时返回默认租户
+// @Tags Tenants
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=v1.TenantProviders}
+// @Router /v1/tenant/providers [get]
+func (s *TenantServiceV1) getProviders(c *gin.Context) {
+	t, err := s.service.Get(c.Request.Context(), tenant.IDFrom(c.Request.Context()))
+	if err != nil {
+		s.handleError(c, err, "获取租户供应商配置失败")
+		return
+	}
+	httpUtils.Response.Success(c, toTenantProviders(t.Providers.Masked()), "获取租户供应商配置成功")
+}
+
+// updateProviders 替换当前租户的供应商配置
+// @Summary 修改租户供应商配置
+// @Description 整体替换请求所属租户的 LLM 和 TTS 配置。条目与实例配置同名时覆盖实例配置，选择为空时沿用实例的选择；
+// @Description 密钥传 ****** 表示保留同名条目的原值。新连接的设备使用新配置，ASR 仍使用实例配置
+// @Tags Tenants
+// @Accept json
+// @Produce json
+// @Param request body v1.TenantProviders true "供应商配置"
+// @Success 200 {object} httptransport.APIResponse{data=v1.TenantProviders}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/tenant/providers [put]
+func (s *TenantServiceV1) updateProviders(c *gin.Context) {
+	var request v1.TenantProviders
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	id := tenant.IDFrom(ctx)
+	s.logger.InfoTag("API", "修改租户供应商配置", "tenant_id", id, "request_id", getRequestID(c))
+
+	t, err := s.service.SetProviders(ctx, id, fromTenantProviders(request), s.config)
+	if err != nil {
+		s.handleError(c, err, "修改租户供应商配置失败")
+		return
+	}
+	httpUtils.Response.Success(c, toTenantProviders(t.Providers.Masked()), "租户供应商配置修改成功")
+}
+
+// createTenant 创建租户
+// @Summary 创建租户
+// @Description 创建租户并签发访问令牌，令牌只在响应中返回一次。租户ID由小写字母、数字、下划线和连字符组成
+// @Tags Tenants
+// @Accept json
+// @Produce json
+// @Param request body v1.TenantCreateRequest true "租户信息"
+// @Success 201 {object} httptransport.APIResponse{data=v1.TenantTokenResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/tenants [post]
+func (s *TenantServiceV1) createTenant(c *gin.Context) {
+	var request v1.TenantCreateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	s.logger.InfoTag("API", "创建租户", "tenant_id", request.ID, "request_id", getRequestID(c))
+
+	t, token, err := s.service.Create(c.Request.Context(), tenant.CreateRequest{
+		ID:    request.ID,
+		Name:  request.Name,
+		Quota: fromTenantQuota(request.Quota),
+	})
+	if err != nil {
+		s.handleError(c, err, "创建租户失败")
+		return
+	}
+	httpUtils.Response.Created(c, v1.TenantTokenResponse{Tenant: toTenantInfo(t), Token: token}, "租户创建成功")
+}
+
+// listTenants 获取租户列表
+// @Summary 获取租户列表
+// @Tags Tenants
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.TenantInfo}
+// @Router /v1/tenants [get]
+func (s *TenantServiceV1) listTenants(c *gin.Context) {
+	items, err := s.service.List(c.Request.Context())
+	if err != nil {
+		s.handleError(c, err, "获取租户列表失败")
+		return
+	}
+	infos := make([]v1.TenantInfo, len(items))
+	for i, t := range items {
+		infos[i] = toTenantInfo(t)
+	}
+	httpUtils.Response.Success(c, infos, "获取租户列表成功")
+}
+
+// getTenant 获取租户
+// @Summary 获取租户
+// @Tags Tenants
+// @Produce json
+// @Param id path string true "租户ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.TenantInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/tenants/{id} [get]
+func (s *TenantServiceV1) getTenant(c *gin.Context) {
+	t, err := s.service.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取租户失败")
+		return
+	}
+	httpUtils.Response.Success(c, toTenantInfo(t), "获取租户成功")
+}
+
+// updateTenant 修改租户
+// @Summary 修改租户
+// @Description 修改名称、启用状态或配额；停用后租户令牌和设备连接都会被拒绝
+// @Tags Tenants
+// @Accept json
+// @Produce json
+// @Param id path string true "租户ID"
+// @Param request body v1.TenantUpdateRequest true "修改内容"
+// @Success 200 {object} httptransport.APIResponse{data=v1.TenantInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/tenants/{id} [put]
+func (s *TenantServiceV1) updateTenant(c *gin.Context) {
+	var request v1.TenantUpdateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	update := tenant.UpdateRequest{Name: request.Name, Enabled: request.Enabled}
+	if request.Quota != nil {
+		quota := fromTenantQuota(*request.Quota)
+		update.Quota = &quota
+	}
+	t, err := s.service.Update(c.Request.Context(), c.Param("id"), update)
+	if err != nil {
+		s.handleError(c, err, "修改租户失败")
+		return
+	}
+	httpUtils.Response.Success(c, toTenantInfo(t), "租户修改成功")
+}
+
+// deleteTenant 删除租户
+// @Summary 删除租户
+// @Description 删除租户及其提示词模板和用量记录；仍有设备的租户不能删除，默认租户不能删除
+// @Tags Tenants
+// @Produce json
+// @Param id path string true "租户ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/tenants/{id} [delete]
+func (s *TenantServiceV1) deleteTenant(c *gin.Context) {
+	id := c.Param("id")
+	if err := s.service.Delete(c.Request.Context(), id); err != nil {
+		s.handleError(c, err, "删除租户失败")
+		return
+	}
+	httpUtils.Response.Success(c, map[string]interface{}{"id": id}, "租户删除成功")
+}
+
+// rotateToken 重新签发访问令牌
+// @Summary 重新签发租户令牌
+// @Description 生成新的访问令牌，旧令牌立即失效；新令牌只在响应中返回一次
+// @Tags Tenants
+// @Produce json
+// @Param id path string true "租户ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.TenantTokenResponse}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/tenants/{id}/token [post]
+func (s *TenantServiceV1) rotateToken(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	token, err := s.service.RotateToken(ctx, id)
+	if err != nil {
+		s.handleError(c, err, "签发租户令牌失败")
+		return
+	}
+	t, err := s.service.Get(ctx, id)
+	if err != nil {
+		s.handleError(c, err, "签发租户令牌失败")
+		return
+	}
+	httpUtils.Response.Success(c, v1.TenantTokenResponse{Tenant: toTenantInfo(t), Token: token}, "租户令牌已重新签发")
+}
+
+// getTenantUsage 查询租户用量
+// @Summary 查询租户用量
+// @Description 返回指定日期的能力调用次数、Token 数以及当前设备数
+// @Tags Tenants
+// @Produce json
+// @Param id path string true "租户ID"
+// @Param day query string false "日期 YYYY-MM-DD，默认今天"
+// @Success 200 {object} httptransport.APIResponse{data=v1.TenantUsageInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/tenants/{id}/usage [get]
+func (s *TenantServiceV1) getTenantUsage(c *gin.Context) {
+	s.respondUsage(c, c.Param("id"), c.Query("day"))
+}
+
+func (s *TenantServiceV1) respondUsage(c *gin.Context, id, day string) {
+	ctx := c.Request.Context()
+	t, err := s.service.Get(ctx, id)
+	if err != nil {
+		s.handleError(c, err, "查询租户用量失败")
+		return
+	}
+	usage, err := s.service.Usage(ctx, id, day)
+	if err != nil {
+		s.handleError(c, err, "查询租户用量失败")
+		return
+	}
+	httpUtils.Response.Success(c, v1.TenantUsageInfo{
+		TenantID: usage.TenantID,
+		Day:      usage.Day,
+		Requests: usage.Requests,
+		Tokens:   usage.Tokens,
+		Devices:  usage.Devices,
+		Quota:    toTenantQuota(t.Quota),
+	}, "查询租户用量成功")
+}
+
+func (s *TenantServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, tenant.ErrNotFound):
+		httpUtils.Response.NotFound(c, "租户")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toTenantInfo(t *tenant.Tenant) v1.TenantInfo {
+	return v1.TenantInfo{
+		ID:        t.ID,
+		Name:      t.Name,
+		Enabled:   t.Enabled,
+		Quota:     toTenantQuota(t.Quota),
+		HasToken:  t.TokenHash != "",
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+func toTenantQuota(q tenant.Quota) v1.TenantQuota {
+	return v1.TenantQuota{MaxDevices: q.MaxDevices, DailyRequests: q.DailyRequests, DailyTokens: q.DailyTokens}
+}
+
+func fromTenantQuota(q v1.TenantQuota) tenant.Quota {
+	return tenant.Quota{MaxDevices: q.MaxDevices, DailyRequests: q.DailyRequests, DailyTokens: q.DailyTokens}
+}
+
+func toTenantProviders(p tenant.Providers) v1.TenantProviders {
+	result := v1.TenantProviders{
+		SelectedLLM: p.SelectedLLM,
+		SelectedTTS: p.SelectedTTS,
+		LLM:         make(map[string]v1.TenantLLMConfig, len(p.LLM)),
+		TTS:         make(map[string]v1.TenantTTSConfig, len(p.TTS)),
+	}
+	for name, c := range p.LLM {
+		result.LLM[name] = v1.TenantLLMConfig{
+			Type:        c.Type,
+			ModelName:   c.ModelName,
+			BaseURL:     c.BaseURL,
+			APIKey:      c.APIKey,
+			Temperature: c.Temperature,
+			MaxTokens:   c.MaxTokens,
+			TopP:        c.TopP,
+			Extra:       c.Extra,
+		}
+	}
+	for name, c := range p.TTS {
+		result.TTS[name] = v1.TenantTTSConfig{
+			Type:    c.Type,
+			Voice:   c.Voice,
+			Format:  c.Format,
+			AppID:   c.AppID,
+			Token:   c.Token,
+			Cluster: c.Cluster,
+			Extra:   c.Extra,
+		}
+	}
+	return result
+}
+
+func fromTenantProviders(p v1.TenantProviders) tenant.Providers {
+	result := tenant.Providers{
+		SelectedLLM: p.SelectedLLM,
+		SelectedTTS: p.SelectedTTS,
+	}
+	if len(p.LLM) > 0 {
+		result.LLM = make(map[string]config.LLMConfig, len(p.LLM))
+		for name, c := range p.LLM {
+			result.LLM[name] = config.LLMConfig{
+				Type:        c.Type,
+				ModelName:   c.ModelName,
+				BaseURL:     c.BaseURL,
+				APIKey:      c.APIKey,
+				Temperature: c.Temperature,
+				MaxTokens:   c.MaxTokens,
+				TopP:        c.TopP,
+				Extra:       c.Extra,
+			}
+		}
+	}
+	if len(p.TTS) > 0 {
+		result.TTS = make(map[string]config.TTSConfig, len(p.TTS))
+		for name, c := range p.TTS {
+			result.TTS[name] = config.TTSConfig{
+				Type:    c.Type,
+				Voice:   c.Voice,
+				Format:  c.Format,
+				AppID:   c.AppID,
+				Token:   c.Token,
+				Cluster: c.Cluster,
+				Extra:   c.Extra,
+			}
+		}
+	}
+	return result
+}
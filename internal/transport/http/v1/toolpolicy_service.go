@@ -0,0 +1,176 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/toolpolicy"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// ToolPolicyServiceV1 V1版本工具调用权限服务
+type ToolPolicyServiceV1 struct {
+	logger  *logging.Logger
+	service *toolpolicy.Service
+}
+
+// NewToolPolicyServiceV1 创建工具调用权限服务V1实例
+func NewToolPolicyServiceV1(logger *logging.Logger, service *toolpolicy.Service) (*ToolPolicyServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("tool policy service is required")
+	}
+	return &ToolPolicyServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册工具调用权限API路由，仅管理员可访问
+func (s *ToolPolicyServiceV1) Register(router *gin.RouterGroup) {
+	policy := router.Group("/tool-policy")
+	{
+		policy.GET("/audit", s.listAudit) // 获取调用审计记录
+		policy.POST("/check", s.check)    // 试算调用权限
+	}
+}
+
+// listAudit 获取调用审计记录
+// @Summary 获取工具调用审计记录
+// @Description 按设备、智能体、工具名称、检查结果和时间范围过滤设备会话和智能体发起的工具与能力调用，新记录在前
+// @Tags ToolPolicy
+// @Produce json
+// @Param device_id query string false "设备ID"
+// @Param agent query string false "智能体名称"
+// @Param name query string false "工具或能力名称"
+// @Param allowed query bool false "是否放行"
+// @Param from query string false "开始时间 RFC3339"
+// @Param to query string false "结束时间 RFC3339"
+// @Param page query int false "页码" default(1)
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} httptransport.APIResponse{data=v1.ToolPolicyAuditListResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Router /v1/tool-policy/audit [get]
+func (s *ToolPolicyServiceV1) listAudit(c *gin.Context) {
+	var query v1.ToolPolicyAuditQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 20
+	}
+
+	filter := toolpolicy.AuditFilter{
+		DeviceID: query.DeviceID,
+		Agent:    query.Agent,
+		Name:     query.Name,
+		Page:     query.Page,
+		PageSize: query.Limit,
+	}
+	if query.Allowed != "" {
+		allowed, err := strconv.ParseBool(query.Allowed)
+		if err != nil {
+			httpUtils.Response.BadRequest(c, "allowed 必须是 true 或 false")
+			return
+		}
+		filter.Allowed = &allowed
+	}
+	var err error
+	if filter.From, err = parseQueryTime(query.From); err != nil {
+		httpUtils.Response.BadRequest(c, "from 必须是 RFC3339 时间")
+		return
+	}
+	if filter.To, err = parseQueryTime(query.To); err != nil {
+		httpUtils.Response.BadRequest(c, "to 必须是 RFC3339 时间")
+		return
+	}
+
+	items, total, err := s.service.ListAudit(c.Request.Context(), filter)
+	if err != nil {
+		s.logger.ErrorTag("API", "获取工具调用审计记录失败", "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, "获取工具调用审计记录失败")
+		return
+	}
+
+	records := make([]v1.ToolPolicyAuditInfo, 0, len(items))
+	for _, item := range items {
+		records = append(records, v1.ToolPolicyAuditInfo{
+			ID:        item.ID,
+			DeviceID:  item.DeviceID,
+			SessionID: item.SessionID,
+			Agent:     item.Agent,
+			Kind:      string(item.Kind),
+			Name:      item.Name,
+			Arguments: item.Arguments,
+			Allowed:   item.Allowed,
+			Profile:   item.Profile,
+			Rule:      item.Rule,
+			Reason:    item.Reason,
+			CreatedAt: item.CreatedAt,
+		})
+	}
+	totalPages := (total + int64(query.Limit) - 1) / int64(query.Limit)
+	httpUtils.Response.Success(c, v1.ToolPolicyAuditListResponse{
+		Records: records,
+		Pagination: v1.Pagination{
+			Page:       int64(query.Page),
+			Limit:      int64(query.Limit),
+			Total:      total,
+			TotalPages: totalPages,
+			HasNext:    int64(query.Page) < totalPages,
+			HasPrev:    query.Page > 1,
+		},
+	}, "获取工具调用审计记录成功")
+}
+
+// check 试算调用权限
+// @Summary 试算工具调用权限
+// @Description 按当前策略检查设备会话或智能体能否以给定参数调用工具或能力，不执行调用也不记入审计
+// @Tags ToolPolicy
+// @Accept json
+// @Produce json
+// @Param request body v1.ToolPolicyCheckRequest true "调用方和调用内容"
+// @Success 200 {object} httptransport.APIResponse{data=v1.ToolPolicyCheckResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 403 {object} httptransport.APIResponse
+// @Router /v1/tool-policy/check [post]
+func (s *ToolPolicyServiceV1) check(c *gin.Context) {
+	var request v1.ToolPolicyCheckRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	kind := toolpolicy.Kind(request.Kind)
+	switch kind {
+	case "":
+		kind = toolpolicy.KindTool
+	case toolpolicy.KindTool, toolpolicy.KindCapability:
+	default:
+		httpUtils.Response.BadRequest(c, "kind 必须是 tool 或 capability")
+		return
+	}
+
+	d := s.service.Evaluate(toolpolicy.Subject{
+		DeviceID:  request.DeviceID,
+		SessionID: request.SessionID,
+		Agent:     request.Agent,
+	}, kind, request.Name, request.Arguments)
+	httpUtils.Response.Success(c, v1.ToolPolicyCheckResponse{
+		Allowed: d.Allowed,
+		Profile: d.Profile,
+		Rule:    d.Rule,
+		Reason:  d.Reason,
+	}, "试算完成")
+}
@@ -0,0 +1,147 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/translation"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// TranslationServiceV1 V1版本翻译模式服务
+type TranslationServiceV1 struct {
+	logger  *logging.Logger
+	service *translation.Service
+}
+
+// NewTranslationServiceV1 创建翻译模式服务V1实例
+func NewTranslationServiceV1(logger *logging.Logger, service *translation.Service) (*TranslationServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("translation service is required")
+	}
+	return &TranslationServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册翻译模式API路由
+func (s *TranslationServiceV1) Register(router *gin.RouterGroup) {
+	router.GET("/translation/languages", s.listLanguages) // 获取支持的语言
+
+	router.GET("/devices/:id/translation", s.getSession)     // 获取设备翻译模式状态
+	router.PUT("/devices/:id/translation", s.startSession)   // 开启或切换翻译模式
+	router.DELETE("/devices/:id/translation", s.stopSession) // 退出翻译模式
+}
+
+// listLanguages 获取支持的语言
+// @Summary 获取翻译模式支持的语言
+// @Description 返回可作为源语言和目标语言的语言代码，以及各语言配置的TTS音色
+// @Tags Translation
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.TranslationLanguage}
+// @Router /v1/translation/languages [get]
+func (s *TranslationServiceV1) listLanguages(c *gin.Context) {
+	languages := translation.Languages()
+	result := make([]v1.TranslationLanguage, 0, len(languages))
+	for _, lang := range languages {
+		result = append(result, v1.TranslationLanguage{
+			Code:  lang.Code,
+			Name:  lang.Name,
+			Voice: s.service.Voice(lang.Code),
+		})
+	}
+	httpUtils.Response.Success(c, result, "获取支持的语言成功")
+}
+
+// getSession 获取设备翻译模式状态
+// @Summary 获取设备翻译模式状态
+// @Tags Translation
+// @Produce json
+// @Param id path string true "设备ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.TranslationSessionInfo}
+// @Router /v1/devices/{id}/translation [get]
+func (s *TranslationServiceV1) getSession(c *gin.Context) {
+	deviceID := c.Param("id")
+	session, ok := s.service.Session(deviceID)
+	if !ok {
+		httpUtils.Response.Success(c, v1.TranslationSessionInfo{DeviceID: deviceID}, "设备未开启翻译模式")
+		return
+	}
+	httpUtils.Response.Success(c, toTranslationSessionInfo(session), "获取翻译模式状态成功")
+}
+
+// startSession 开启或切换翻译模式
+// @Summary 开启或切换设备翻译模式
+// @Description 设备需在线；之后设备的语音翻译成目标语言并用该语言的音色播报，断开连接时自动退出
+// @Tags Translation
+// @Accept json
+// @Produce json
+// @Param id path string true "设备ID"
+// @Param request body v1.TranslationStartRequest true "翻译语言"
+// @Success 200 {object} httptransport.APIResponse{data=v1.TranslationSessionInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 409 {object} httptransport.APIResponse
+// @Router /v1/devices/{id}/translation [put]
+func (s *TranslationServiceV1) startSession(c *gin.Context) {
+	var request v1.TranslationStartRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	session, err := s.service.Start(c.Param("id"), request.Source, request.Target)
+	if err != nil {
+		s.handleError(c, err, "开启翻译模式失败")
+		return
+	}
+	s.logger.InfoTag("API", "开启翻译模式", "device_id", session.DeviceID, "source", session.Source, "target", session.Target, "request_id", getRequestID(c))
+	httpUtils.Response.Success(c, toTranslationSessionInfo(session), "翻译模式已开启")
+}
+
+// stopSession 退出翻译模式
+// @Summary 退出设备翻译模式
+// @Tags Translation
+// @Produce json
+// @Param id path string true "设备ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.TranslationSessionInfo}
+// @Router /v1/devices/{id}/translation [delete]
+func (s *TranslationServiceV1) stopSession(c *gin.Context) {
+	deviceID := c.Param("id")
+	if s.service.Stop(deviceID) {
+		s.logger.InfoTag("API", "退出翻译模式", "device_id", deviceID, "request_id", getRequestID(c))
+	}
+	httpUtils.Response.Success(c, v1.TranslationSessionInfo{DeviceID: deviceID}, "翻译模式已退出")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *TranslationServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, translation.ErrDeviceOffline):
+		httpUtils.Response.Conflict(c, "设备不在线，无法开启翻译模式")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toTranslationSessionInfo(session translation.Session) v1.TranslationSessionInfo {
+	startedAt := session.StartedAt
+	return v1.TranslationSessionInfo{
+		DeviceID:  session.DeviceID,
+		Active:    true,
+		Source:    session.Source,
+		Target:    session.Target,
+		StartedAt: &startedAt,
+	}
+}
@@ -0,0 +1,342 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"xiaozhi-server-go/internal/domain/webhook"
+	platformerrors "xiaozhi-server-go/internal/platform/errors"
+	"xiaozhi-server-go/internal/platform/logging"
+	"xiaozhi-server-go/internal/transport/http/types/v1"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
+)
+
+// WebhookServiceV1 V1版本事件推送服务
+type WebhookServiceV1 struct {
+	logger  *logging.Logger
+	service *webhook.Service
+}
+
+// NewWebhookServiceV1 创建事件推送服务V1实例
+func NewWebhookServiceV1(logger *logging.Logger, service *webhook.Service) (*WebhookServiceV1, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if service == nil {
+		return nil, fmt.Errorf("webhook service is required")
+	}
+	return &WebhookServiceV1{
+		logger:  logger,
+		service: service,
+	}, nil
+}
+
+// Register 注册事件推送API路由
+func (s *WebhookServiceV1) Register(router *gin.RouterGroup) {
+	webhooks := router.Group("/webhooks")
+	{
+		webhooks.POST("", s.createWebhook)                                           // 创建端点
+		webhooks.GET("", s.listWebhooks)                                             // 获取端点列表
+		webhooks.GET("/:id", s.getWebhook)                                           // 获取端点详情
+		webhooks.PUT("/:id", s.updateWebhook)                                        // 更新端点
+		webhooks.DELETE("/:id", s.deleteWebhook)                                     // 删除端点及其推送记录
+		webhooks.POST("/:id/test", s.testWebhook)                                    // 同步推送测试事件
+		webhooks.GET("/:id/deliveries", s.listDeliveries)                            // 获取推送记录
+		webhooks.GET("/:id/deliveries/:delivery_id", s.getDelivery)                  // 获取推送记录详情
+		webhooks.POST("/:id/deliveries/:delivery_id/redeliver", s.redeliverDelivery) // 重新推送
+	}
+}
+
+// createWebhook 创建推送端点
+// @Summary 创建推送端点
+// @Description 登记接收地址并订阅设备离线、插件异常、工作流失败或配额用尽事件；推送为 POST JSON，请求头 X-Xiaozhi-Signature 为 t=<unix 秒>,v1=<hex(HMAC-SHA256(secret, "<t>.<body>"))>。未指定签名密钥时自动生成，只在本次响应中返回原值
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param request body v1.WebhookCreateRequest true "端点信息"
+// @Success 201 {object} httptransport.APIResponse{data=v1.WebhookInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/webhooks [post]
+func (s *WebhookServiceV1) createWebhook(c *gin.Context) {
+	var request v1.WebhookCreateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	enabled := true
+	if request.Enabled != nil {
+		enabled = *request.Enabled
+	}
+	endpoint, err := s.service.Create(c.Request.Context(), webhook.CreateRequest{
+		Name:    request.Name,
+		URL:     request.URL,
+		Secret:  request.Secret,
+		Events:  request.Events,
+		Enabled: enabled,
+	})
+	if err != nil {
+		s.handleError(c, err, "创建推送端点失败")
+		return
+	}
+	info := toWebhookInfo(endpoint)
+	info.Secret = endpoint.Secret
+	httpUtils.Response.Created(c, info, "推送端点创建成功")
+}
+
+// listWebhooks 获取推送端点列表
+// @Summary 获取推送端点列表
+// @Tags Webhooks
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=[]v1.WebhookInfo}
+// @Router /v1/webhooks [get]
+func (s *WebhookServiceV1) listWebhooks(c *gin.Context) {
+	items, err := s.service.List(c.Request.Context())
+	if err != nil {
+		s.handleError(c, err, "获取推送端点列表失败")
+		return
+	}
+	endpoints := make([]v1.WebhookInfo, 0, len(items))
+	for _, item := range items {
+		endpoints = append(endpoints, toWebhookInfo(item))
+	}
+	httpUtils.Response.Success(c, endpoints, "获取推送端点列表成功")
+}
+
+// getWebhook 获取推送端点详情
+// @Summary 获取推送端点详情
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "端点ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.WebhookInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/webhooks/{id} [get]
+func (s *WebhookServiceV1) getWebhook(c *gin.Context) {
+	endpoint, err := s.service.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "获取推送端点失败")
+		return
+	}
+	httpUtils.Response.Success(c, toWebhookInfo(endpoint), "获取推送端点成功")
+}
+
+// updateWebhook 更新推送端点
+// @Summary 更新推送端点
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "端点ID"
+// @Param request body v1.WebhookUpdateRequest true "更新内容"
+// @Success 200 {object} httptransport.APIResponse{data=v1.WebhookInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/webhooks/{id} [put]
+func (s *WebhookServiceV1) updateWebhook(c *gin.Context) {
+	var request v1.WebhookUpdateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+
+	endpoint, err := s.service.Update(c.Request.Context(), c.Param("id"), webhook.UpdateRequest{
+		Name:    request.Name,
+		URL:     request.URL,
+		Secret:  request.Secret,
+		Events:  request.Events,
+		Enabled: request.Enabled,
+	})
+	if err != nil {
+		s.handleError(c, err, "更新推送端点失败")
+		return
+	}
+	httpUtils.Response.Success(c, toWebhookInfo(endpoint), "推送端点更新成功")
+}
+
+// deleteWebhook 删除推送端点
+// @Summary 删除推送端点
+// @Description 删除端点及其全部推送记录，尚未推送的事件不再推送
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "端点ID"
+// @Success 200 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/webhooks/{id} [delete]
+func (s *WebhookServiceV1) deleteWebhook(c *gin.Context) {
+	if err := s.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		s.handleError(c, err, "删除推送端点失败")
+		return
+	}
+	httpUtils.Response.Success(c, nil, "推送端点已删除")
+}
+
+// testWebhook 推送测试事件
+// @Summary 推送测试事件
+// @Description 向端点同步推送一条 webhook.ping 事件并返回推送记录，不重试，停用的端点也会推送
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "端点ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.WebhookDeliveryInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/webhooks/{id}/test [post]
+func (s *WebhookServiceV1) testWebhook(c *gin.Context) {
+	delivery, err := s.service.Test(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.handleError(c, err, "推送测试事件失败")
+		return
+	}
+	httpUtils.Response.Success(c, toWebhookDeliveryInfo(delivery), "测试事件已推送")
+}
+
+// listDeliveries 获取推送记录
+// @Summary 获取端点的推送记录
+// @Description 按创建时间倒序分页返回推送记录，包含请求体、推送状态和各次尝试的响应
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "端点ID"
+// @Param page query int false "页码" default(1)
+// @Param limit query int false "每页数量" default(20)
+// @Param event query string false "事件类型"
+// @Param status query string false "推送状态" Enums(pending,succeeded,failed)
+// @Success 200 {object} httptransport.APIResponse{data=v1.WebhookDeliveryListResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/webhooks/{id}/deliveries [get]
+func (s *WebhookServiceV1) listDeliveries(c *gin.Context) {
+	var query v1.WebhookDeliveryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpUtils.Response.ValidationError(c, err)
+		return
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 20
+	}
+
+	items, total, err := s.service.ListDeliveries(c.Request.Context(), webhook.DeliveryFilter{
+		EndpointID: c.Param("id"),
+		Event:      query.Event,
+		Status:     webhook.DeliveryStatus(query.Status),
+		Page:       query.Page,
+		PageSize:   query.Limit,
+	})
+	if err != nil {
+		s.handleError(c, err, "获取推送记录失败")
+		return
+	}
+
+	deliveries := make([]v1.WebhookDeliveryInfo, 0, len(items))
+	for _, item := range items {
+		deliveries = append(deliveries, toWebhookDeliveryInfo(item))
+	}
+	totalPages := (total + int64(query.Limit) - 1) / int64(query.Limit)
+	httpUtils.Response.Success(c, v1.WebhookDeliveryListResponse{
+		Deliveries: deliveries,
+		Pagination: v1.Pagination{
+			Page:       int64(query.Page),
+			Limit:      int64(query.Limit),
+			Total:      total,
+			TotalPages: totalPages,
+			HasNext:    int64(query.Page) < totalPages,
+			HasPrev:    query.Page > 1,
+		},
+	}, "获取推送记录成功")
+}
+
+// getDelivery 获取推送记录详情
+// @Summary 获取推送记录详情
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "端点ID"
+// @Param delivery_id path string true "推送记录ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.WebhookDeliveryInfo}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/webhooks/{id}/deliveries/{delivery_id} [get]
+func (s *WebhookServiceV1) getDelivery(c *gin.Context) {
+	delivery, err := s.service.GetDelivery(c.Request.Context(), c.Param("id"), c.Param("delivery_id"))
+	if err != nil {
+		s.handleError(c, err, "获取推送记录失败")
+		return
+	}
+	httpUtils.Response.Success(c, toWebhookDeliveryInfo(delivery), "获取推送记录成功")
+}
+
+// redeliverDelivery 重新推送
+// @Summary 重新推送
+// @Description 把已成功或已失败的推送记录重新加入推送队列，请求体和事件ID不变，重试次数重新计算
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "端点ID"
+// @Param delivery_id path string true "推送记录ID"
+// @Success 200 {object} httptransport.APIResponse{data=v1.WebhookDeliveryInfo}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (s *WebhookServiceV1) redeliverDelivery(c *gin.Context) {
+	delivery, err := s.service.Redeliver(c.Request.Context(), c.Param("id"), c.Param("delivery_id"))
+	if err != nil {
+		s.handleError(c, err, "重新推送失败")
+		return
+	}
+	httpUtils.Response.Success(c, toWebhookDeliveryInfo(delivery), "已加入推送队列")
+}
+
+// handleError 将领域错误映射为API错误
+func (s *WebhookServiceV1) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, webhook.ErrNotFound):
+		httpUtils.Response.NotFound(c, "推送端点")
+	case errors.Is(err, webhook.ErrDeliveryNotFound):
+		httpUtils.Response.NotFound(c, "推送记录")
+	case platformerrors.IsKind(err, platformerrors.KindDomain):
+		httpUtils.Response.BadRequest(c, err.Error())
+	default:
+		s.logger.ErrorTag("API", message, "error", err, "request_id", getRequestID(c))
+		httpUtils.Response.InternalError(c, message)
+	}
+}
+
+func toWebhookInfo(endpoint *webhook.Endpoint) v1.WebhookInfo {
+	return v1.WebhookInfo{
+		ID:        endpoint.ID,
+		Name:      endpoint.Name,
+		URL:       endpoint.URL,
+		Secret:    endpoint.MaskedSecret(),
+		Events:    endpoint.Events,
+		Enabled:   endpoint.Enabled,
+		CreatedAt: endpoint.CreatedAt,
+		UpdatedAt: endpoint.UpdatedAt,
+	}
+}
+
+func toWebhookDeliveryInfo(d *webhook.Delivery) v1.WebhookDeliveryInfo {
+	history := make([]v1.WebhookAttempt, 0, len(d.History))
+	for _, a := range d.History {
+		history = append(history, v1.WebhookAttempt{
+			At:           a.At,
+			StatusCode:   a.StatusCode,
+			DurationMs:   a.DurationMs,
+			Error:        a.Error,
+			ResponseBody: a.ResponseBody,
+		})
+	}
+	return v1.WebhookDeliveryInfo{
+		ID:            d.ID,
+		EndpointID:    d.EndpointID,
+		EventID:       d.EventID,
+		Event:         d.Event,
+		Payload:       d.Payload,
+		Status:        string(d.Status),
+		Attempts:      d.Attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		StatusCode:    d.StatusCode,
+		Error:         d.Error,
+		History:       history,
+		DeliveredAt:   d.DeliveredAt,
+		CreatedAt:     d.CreatedAt,
+		UpdatedAt:     d.UpdatedAt,
+	}
+}
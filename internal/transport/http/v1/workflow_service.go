@@ -1,28 +1,61 @@
 package v1
 
 import (
-	"net/http"
+	"context"
+	"errors"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"xiaozhi-server-go/internal/domain/tenant"
 	"xiaozhi-server-go/internal/platform/config"
 	"xiaozhi-server-go/internal/platform/logging"
 	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/plugin/logs"
+	"xiaozhi-server-go/internal/plugin/status"
+	"xiaozhi-server-go/internal/transport/http/middleware"
+	httpUtils "xiaozhi-server-go/internal/transport/http/utils"
 	"xiaozhi-server-go/internal/workflow"
 )
 
 type WorkflowService struct {
-	config   *config.Config
-	logger   *logging.Logger
-	registry *capability.Registry
-	mu       sync.RWMutex
+	config        *config.Config
+	logger        *logging.Logger
+	registry      *capability.Registry
+	executor      workflow.WorkflowExecutor
+	statusManager *status.PluginStatusManager
+	mu            sync.RWMutex
 }
 
 func NewWorkflowService(config *config.Config, logger *logging.Logger, registry *capability.Registry) *WorkflowService {
+	dagEngine := workflow.NewDAGEngine(logger)
+	executor := workflow.NewWorkflowExecutor(config, registry, dagEngine, workflow.NewDataFlowEngine(dagEngine, logger), logger)
+	return NewWorkflowServiceWithExecutor(config, logger, registry, executor)
+}
+
+// NewWorkflowServiceWithExecutor creates the service around an existing executor so that
+// executions started through other APIs (e.g. the management gRPC service) are visible here
+func NewWorkflowServiceWithExecutor(config *config.Config, logger *logging.Logger, registry *capability.Registry, executor workflow.WorkflowExecutor) *WorkflowService {
 	return &WorkflowService{
 		config:   config,
 		logger:   logger,
 		registry: registry,
+		executor: executor,
+	}
+}
+
+// SetPluginStatusManager sets the plugin status manager used to check provider health during dry runs
+func (s *WorkflowService) SetPluginStatusManager(manager *status.PluginStatusManager) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statusManager = manager
+}
+
+// SetPluginLogs sets the plugin log manager used to attach plugin output to node logs
+func (s *WorkflowService) SetPluginLogs(manager *logs.Manager) {
+	if manager != nil {
+		s.executor.SetPluginLogs(manager)
 	}
 }
 
@@ -30,43 +63,475 @@ func (s *WorkflowService) RegisterRoutes(router *gin.RouterGroup) {
 	group := router.Group("/workflow")
 	{
 		group.GET("/capabilities", s.ListCapabilities)
+		group.POST("/capabilities/:id/execute", s.ExecuteCapability)
+		group.GET("/node-types", s.ListNodeTypes)
+		group.GET("/startup", s.GetStartupWorkflow)
+		group.GET("/scheduler", s.GetSchedulerStats)
 		group.GET("/current", s.GetCurrentWorkflow)
 		group.POST("", s.SaveWorkflow)
+		group.GET("/templates", s.ListTemplates)
+		group.GET("/templates/:id", s.GetTemplate)
+		group.POST("/templates/:id/deploy", s.DeployTemplate)
 	}
+	router.POST("/workflows/:id/execute", s.ExecuteWorkflow)
+	router.GET("/executions/:id", s.GetExecution)
+	router.GET("/executions/:id/nodes/:nodeId/logs", s.GetNodeLogs)
 }
 
 // ListCapabilities returns all available capabilities
+// @Summary List capabilities
+// @Description Lists every capability registered by builtin providers and plugins
+// @Tags Workflow
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=[]capability.Definition}
+// @Failure 503 {object} httptransport.APIResponse
+// @Router /v1/workflow/capabilities [get]
 func (s *WorkflowService) ListCapabilities(c *gin.Context) {
 	if s.registry == nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "registry not initialized"})
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeServiceUnavailable, "registry not initialized")
 		return
 	}
-	caps := s.registry.ListCapabilities()
-	c.JSON(http.StatusOK, gin.H{"data": caps})
+	httpUtils.Response.Success(c, s.registry.ListCapabilities(), "")
+}
+
+// ExecuteCapabilityRequest is the body of a one-off capability call
+type ExecuteCapabilityRequest struct {
+	Config  map[string]interface{} `json:"config"`
+	Inputs  map[string]interface{} `json:"inputs"`
+	Timeout string                 `json:"timeout,omitempty" example:"30s"` // e.g. 30s, at most 5m
+	// ResponseFormat requests JSON output from an LLM capability: {"type": "json_object"} or
+	// {"type": "json_schema", "name": "...", "schema": {...}}; invalid output is sent back to the LLM for repair
+	ResponseFormat map[string]interface{} `json:"response_format,omitempty"`
+	MaxRepairs     *int                   `json:"max_repairs,omitempty"` // repair attempts, default 2, at most 5
+}
+
+// ExecuteCapabilityResponse is the merged result of a one-off capability call
+type ExecuteCapabilityResponse struct {
+	CapabilityID string                 `json:"capability_id" example:"openai_llm"`
+	Outputs      map[string]interface{} `json:"outputs"`
+	Chunks       int                    `json:"chunks"`         // streamed chunks, 0 for non-streaming calls
+	FirstChunkMs int64                  `json:"first_chunk_ms"` // time to the first chunk
+	ElapsedMs    int64                  `json:"elapsed_ms"`
+	JSON         interface{}            `json:"json,omitempty"`     // parsed output when response_format is set
+	Attempts     int                    `json:"attempts,omitempty"` // generations including repairs when response_format is set
+	Traces       []capability.Trace     `json:"traces,omitempty"`   // provider and upstream request IDs of every call made
+}
+
+const (
+	defaultCapabilityTimeout = time.Minute
+	maxCapabilityTimeout     = 5 * time.Minute
+)
+
+// ExecuteCapability runs a single capability outside of any workflow, for interactive testing.
+// Streaming output is merged into one result; chunk count and timings are reported in milliseconds.
+// @Summary Execute a capability
+// @Description Runs one capability outside of any workflow; streaming output is merged into one result. On failure the error details carry the call traces with upstream request IDs
+// @Tags Workflow
+// @Accept json
+// @Produce json
+// @Param id path string true "Capability ID"
+// @Param request body ExecuteCapabilityRequest false "Config, inputs and timeout"
+// @Success 200 {object} httptransport.APIResponse{data=ExecuteCapabilityResponse}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Failure 502 {object} httptransport.APIResponse
+// @Router /v1/workflow/capabilities/{id}/execute [post]
+func (s *WorkflowService) ExecuteCapability(c *gin.Context) {
+	if s.registry == nil {
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeServiceUnavailable, "registry not initialized")
+		return
+	}
+	var req ExecuteCapabilityRequest
+	if c.Request.ContentLength != 0 && !middleware.BindJSON(c, &req) {
+		return
+	}
+	timeout := defaultCapabilityTimeout
+	if req.Timeout != "" {
+		d, err := time.ParseDuration(req.Timeout)
+		if err != nil || d <= 0 {
+			httpUtils.Response.Error(c, httpUtils.ErrorCodeValidationFailed, "timeout must be a positive duration such as 30s")
+			return
+		}
+		timeout = min(d, maxCapabilityTimeout)
+	}
+
+	capabilityID := c.Param("id")
+	exec, err := s.registry.GetExecutor(capabilityID)
+	if err != nil {
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeResourceNotFound, err.Error())
+		return
+	}
+
+	format, err := capability.ParseResponseFormat(req.ResponseFormat)
+	if err != nil {
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeValidationFailed, err.Error())
+		return
+	}
+	maxRepairs := 2
+	if req.MaxRepairs != nil {
+		if *req.MaxRepairs < 0 || *req.MaxRepairs > 5 {
+			httpUtils.Response.Error(c, httpUtils.ErrorCodeValidationFailed, "max_repairs must be between 0 and 5")
+			return
+		}
+		maxRepairs = *req.MaxRepairs
+	}
+
+	// Traces are collected across repair attempts and returned in error details on failure
+	traces := capability.NewTraces()
+	ctx, cancel := context.WithTimeout(capability.WithTraces(capability.WithPriority(c.Request.Context(), capability.PriorityAPI), traces), timeout)
+	defer cancel()
+	var response ExecuteCapabilityResponse
+	var result *capability.RunResult
+	if format != nil {
+		structured, err := capability.RunStructured(ctx, exec, req.Config, req.Inputs, format, maxRepairs)
+		if err != nil {
+			httpUtils.Response.Error(c, httpUtils.ErrorCodeDependencyFailed, err.Error(), traces.List())
+			return
+		}
+		result, response.JSON, response.Attempts = structured.RunResult, structured.Value, structured.Attempts
+	} else if result, err = capability.Run(ctx, exec, req.Config, req.Inputs, nil); err != nil {
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeDependencyFailed, err.Error(), traces.List())
+		return
+	}
+	response.CapabilityID = capabilityID
+	response.Outputs = result.Outputs
+	response.Chunks = result.Chunks
+	response.FirstChunkMs = result.FirstChunk.Milliseconds()
+	response.ElapsedMs = result.Elapsed.Milliseconds()
+	response.Traces = traces.List()
+	httpUtils.Response.Success(c, response, "")
+}
+
+// ListNodeTypes returns builtin node types and the custom node types registered by plugins
+// @Summary List node types
+// @Description Lists builtin workflow node types and the custom node types registered by plugins
+// @Tags Workflow
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=[]workflow.NodeTypeDefinition}
+// @Router /v1/workflow/node-types [get]
+func (s *WorkflowService) ListNodeTypes(c *gin.Context) {
+	nodes := workflow.DefaultNodeRegistry()
+	nodes.SyncCapabilities(s.registry)
+	httpUtils.Response.Success(c, nodes.List(), "")
+}
+
+// GetSchedulerStats returns how many nodes are running and queued per concurrency class and execution
+// @Summary Get scheduler stats
+// @Description Returns how many nodes are running and queued per concurrency class and execution
+// @Tags Workflow
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=workflow.SchedulerStats}
+// @Router /v1/workflow/scheduler [get]
+func (s *WorkflowService) GetSchedulerStats(c *gin.Context) {
+	httpUtils.Response.Success(c, s.executor.SchedulerStats(), "")
+}
+
+// StartupWorkflowResponse is the startup sequence rendered as a workflow and its execution
+type StartupWorkflowResponse struct {
+	Workflow  workflow.Workflow  `json:"workflow"`
+	Execution workflow.Execution `json:"execution"`
+}
+
+// GetStartupWorkflow returns the service startup steps as a workflow together with per-step status and timing
+// @Summary Get startup workflow
+// @Description Returns the service startup steps as a workflow together with per-step status and timing
+// @Tags Workflow
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=StartupWorkflowResponse}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/workflow/startup [get]
+func (s *WorkflowService) GetStartupWorkflow(c *gin.Context) {
+	record := workflow.CurrentStartupRecord()
+	if record == nil {
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeResourceNotFound, "startup record not available")
+		return
+	}
+	wf, execution := record.Snapshot()
+	httpUtils.Response.Success(c, StartupWorkflowResponse{Workflow: wf, Execution: execution}, "")
 }
 
 // GetCurrentWorkflow returns the current workflow configuration
+// @Summary Get current workflow
+// @Description Returns the workflow of the request's tenant
+// @Tags Workflow
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=workflow.Workflow}
+// @Failure 500 {object} httptransport.APIResponse
+// @Router /v1/workflow/current [get]
 func (s *WorkflowService) GetCurrentWorkflow(c *gin.Context) {
-	wf, err := workflow.LoadCurrentWorkflow()
+	wf, err := workflow.LoadTenantWorkflow(tenant.IDFrom(c.Request.Context()))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		httpUtils.Response.InternalError(c, err.Error())
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"data": wf})
+	httpUtils.Response.Success(c, wf, "")
 }
 
 // SaveWorkflow saves the workflow configuration
+// @Summary Save workflow
+// @Description Saves the workflow of the request's tenant; workflows whose node expressions do not compile are rejected
+// @Tags Workflow
+// @Accept json
+// @Produce json
+// @Param request body workflow.Workflow true "Workflow"
+// @Success 200 {object} httptransport.APIResponse{data=workflow.Workflow}
+// @Failure 400 {object} httptransport.APIResponse
+// @Router /v1/workflow [post]
 func (s *WorkflowService) SaveWorkflow(c *gin.Context) {
 	var wf workflow.Workflow
-	if err := c.ShouldBindJSON(&wf); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !middleware.BindJSON(c, &wf) {
+		return
+	}
+
+	// Reject expressions that do not compile so broken transform/http nodes never reach execution
+	if err := workflow.ValidateNodeExpressions(&wf); err != nil {
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeValidationFailed, err.Error())
 		return
 	}
 
-	if err := workflow.SaveWorkflow(&wf); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := workflow.SaveTenantWorkflow(tenant.IDFrom(c.Request.Context()), &wf); err != nil {
+		httpUtils.Response.InternalError(c, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "workflow saved", "data": wf})
+	httpUtils.Response.Success(c, wf, "workflow saved")
+}
+
+// ListTemplates returns the workflow template gallery
+// @Summary List workflow templates
+// @Tags Workflow
+// @Produce json
+// @Success 200 {object} httptransport.APIResponse{data=[]workflow.WorkflowTemplate}
+// @Router /v1/workflow/templates [get]
+func (s *WorkflowService) ListTemplates(c *gin.Context) {
+	httpUtils.Response.Success(c, workflow.DefaultTemplateRegistry().List(), "")
+}
+
+// GetTemplate returns one workflow template with its parameter declarations
+// @Summary Get workflow template
+// @Tags Workflow
+// @Produce json
+// @Param id path string true "Template ID"
+// @Success 200 {object} httptransport.APIResponse{data=workflow.WorkflowTemplate}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/workflow/templates/{id} [get]
+func (s *WorkflowService) GetTemplate(c *gin.Context) {
+	t, ok := workflow.DefaultTemplateRegistry().Get(c.Param("id"))
+	if !ok {
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeResourceNotFound, "workflow template not found: "+c.Param("id"))
+		return
+	}
+	httpUtils.Response.Success(c, t, "")
+}
+
+// DeployTemplateRequest is the body of the template deploy endpoint
+type DeployTemplateRequest struct {
+	Parameters map[string]interface{} `json:"parameters"`
+}
+
+// DeployTemplate instantiates a template with the given parameters and saves it as the current
+// workflow; with dry_run=true the instantiated workflow is returned without being saved
+// @Summary Deploy workflow template
+// @Description Instantiates a template and saves it as the current workflow; with dry_run=true the workflow is only returned
+// @Tags Workflow
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID"
+// @Param dry_run query bool false "Return the instantiated workflow without saving it"
+// @Param request body DeployTemplateRequest false "Template parameters"
+// @Success 200 {object} httptransport.APIResponse{data=workflow.Workflow}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/workflow/templates/{id}/deploy [post]
+func (s *WorkflowService) DeployTemplate(c *gin.Context) {
+	var req DeployTemplateRequest
+	if c.Request.ContentLength != 0 && !middleware.BindJSON(c, &req) {
+		return
+	}
+
+	wf, err := workflow.InstantiateTemplate(c.Param("id"), req.Parameters)
+	if err != nil {
+		if errors.Is(err, workflow.ErrTemplateNotFound) {
+			httpUtils.Response.Error(c, httpUtils.ErrorCodeResourceNotFound, err.Error())
+			return
+		}
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeValidationFailed, err.Error())
+		return
+	}
+
+	if dryRun, _ := strconv.ParseBool(c.Query("dry_run")); dryRun {
+		httpUtils.Response.Success(c, wf, "")
+		return
+	}
+	if err := workflow.SaveTenantWorkflow(tenant.IDFrom(c.Request.Context()), wf); err != nil {
+		httpUtils.Response.InternalError(c, err.Error())
+		return
+	}
+	httpUtils.Response.Success(c, wf, "workflow deployed")
+}
+
+// ExecuteWorkflowRequest is the optional body of the execute endpoint
+type ExecuteWorkflowRequest struct {
+	Inputs   map[string]interface{} `json:"inputs"`
+	Workflow *workflow.Workflow     `json:"workflow,omitempty"` // unsaved workflow to execute or validate instead of the stored one
+}
+
+// ExecuteWorkflow executes a workflow, or with dry_run=true only resolves and validates it and returns a report
+// @Summary Execute workflow
+// @Description Starts an execution of the stored (or supplied) workflow and returns it with 202; with dry_run=true returns a workflow.DryRunReport instead
+// @Tags Workflow
+// @Accept json
+// @Produce json
+// @Param id path string true "Workflow ID"
+// @Param dry_run query bool false "Only resolve and validate the workflow"
+// @Param request body ExecuteWorkflowRequest false "Inputs and optional unsaved workflow"
+// @Success 200 {object} httptransport.APIResponse{data=workflow.DryRunReport}
+// @Success 202 {object} httptransport.APIResponse{data=workflow.Execution}
+// @Failure 400 {object} httptransport.APIResponse
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/workflows/{id}/execute [post]
+func (s *WorkflowService) ExecuteWorkflow(c *gin.Context) {
+	var req ExecuteWorkflowRequest
+	if c.Request.ContentLength != 0 && !middleware.BindJSON(c, &req) {
+		return
+	}
+	if req.Inputs == nil {
+		req.Inputs = make(map[string]interface{})
+	}
+
+	wf := req.Workflow
+	if wf == nil {
+		current, err := workflow.LoadTenantWorkflow(tenant.IDFrom(c.Request.Context()))
+		if err != nil {
+			httpUtils.Response.InternalError(c, err.Error())
+			return
+		}
+		wf = current
+	}
+	if wf.ID != c.Param("id") {
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeWorkflowNotFound, "workflow not found: "+c.Param("id"))
+		return
+	}
+
+	if dryRun, _ := strconv.ParseBool(c.Query("dry_run")); dryRun {
+		s.mu.RLock()
+		opts := workflow.DryRunOptions{}
+		if s.statusManager != nil {
+			opts.Health = pluginHealthChecker{manager: s.statusManager}
+		}
+		s.mu.RUnlock()
+
+		report := s.executor.DryRun(c.Request.Context(), wf, req.Inputs, opts)
+		httpUtils.Response.Success(c, report, "")
+		return
+	}
+
+	// The execution outlives the request, so only the tenant and request ID are carried over
+	execution, err := s.executor.Execute(detachedContext(c), wf, req.Inputs)
+	if err != nil {
+		httpUtils.Response.BadRequest(c, err.Error())
+		return
+	}
+	httpUtils.Response.Accepted(c, execution, "")
+}
+
+// GetExecution returns an execution with per-node results, timings and logs.
+// Finished executions are read from disk once they are no longer in memory.
+// @Summary Get execution
+// @Description Returns an execution with per-node results, timings and logs
+// @Tags Workflow
+// @Produce json
+// @Param id path string true "Execution ID"
+// @Success 200 {object} httptransport.APIResponse{data=workflow.Execution}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/executions/{id} [get]
+func (s *WorkflowService) GetExecution(c *gin.Context) {
+	execution, ok := s.findExecution(c.Request.Context(), c.Param("id"))
+	if !ok {
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeExecutionNotFound, "execution not found: "+c.Param("id"))
+		return
+	}
+	httpUtils.Response.Success(c, execution, "")
+}
+
+// NodeLogsResponse is the status, timing and logs of one node of an execution
+type NodeLogsResponse struct {
+	NodeID   string               `json:"node_id" example:"llm_1"`
+	Status   workflow.NodeStatus  `json:"status"`
+	Attempts int                  `json:"attempts"`
+	Timing   *workflow.NodeTiming `json:"timing"`
+	Logs     []workflow.NodeLog   `json:"logs"`
+}
+
+// GetNodeLogs returns the engine and plugin logs recorded for one node of an execution
+// @Summary Get node logs
+// @Description Returns the engine and plugin logs recorded for one node of an execution
+// @Tags Workflow
+// @Produce json
+// @Param id path string true "Execution ID"
+// @Param nodeId path string true "Node ID"
+// @Success 200 {object} httptransport.APIResponse{data=NodeLogsResponse}
+// @Failure 404 {object} httptransport.APIResponse
+// @Router /v1/executions/{id}/nodes/{nodeId}/logs [get]
+func (s *WorkflowService) GetNodeLogs(c *gin.Context) {
+	execution, ok := s.findExecution(c.Request.Context(), c.Param("id"))
+	if !ok {
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeExecutionNotFound, "execution not found: "+c.Param("id"))
+		return
+	}
+	result, ok := execution.NodeResults[c.Param("nodeId")]
+	if !ok {
+		httpUtils.Response.Error(c, httpUtils.ErrorCodeResourceNotFound, "node not found in execution: "+c.Param("nodeId"))
+		return
+	}
+	nodeLogs := result.Logs
+	if nodeLogs == nil {
+		nodeLogs = []workflow.NodeLog{}
+	}
+	httpUtils.Response.Success(c, NodeLogsResponse{
+		NodeID:   result.NodeID,
+		Status:   result.Status,
+		Attempts: result.Attempts,
+		Timing:   result.Timing,
+		Logs:     nodeLogs,
+	}, "")
+}
+
+// findExecution looks up an execution visible to the request; tenants only see their own
+func (s *WorkflowService) findExecution(ctx context.Context, executionID string) (*workflow.Execution, bool) {
+	execution, ok := s.executor.GetExecution(executionID)
+	if !ok {
+		loaded, err := workflow.LoadExecution(executionID)
+		if err != nil {
+			return nil, false
+		}
+		execution = loaded
+	}
+	if id, scoped := tenant.FromContext(ctx); scoped {
+		owner := execution.TenantID
+		if owner == "" {
+			owner = tenant.DefaultID
+		}
+		if owner != id {
+			return nil, false
+		}
+	}
+	return execution, true
+}
+
+// pluginHealthChecker adapts the plugin status manager to workflow.HealthChecker
+type pluginHealthChecker struct {
+	manager *status.PluginStatusManager
+}
+
+func (h pluginHealthChecker) ProviderHealth(providerID string) (bool, string, bool) {
+	plugin, err := h.manager.GetPluginStatus(providerID)
+	if err != nil || plugin == nil || plugin.HealthStatus == status.HealthStatusUnknown || plugin.HealthStatus == "" {
+		return false, "", false
+	}
+	detail := plugin.Error
+	if detail == "" {
+		detail = string(plugin.Status)
+	}
+	return plugin.HealthStatus == status.HealthStatusHealthy, detail, true
 }
@@ -0,0 +1,83 @@
+package ws
+
+import (
+	"encoding/json"
+
+	"github.com/gorilla/websocket"
+)
+
+// Device auth statuses understood by the message ACL; they mirror aggregate.DeviceStatus.
+const (
+	AuthStatusApproved = "approved"
+	AuthStatusPending  = "pending"
+	AuthStatusRejected = "rejected"
+)
+
+// Reason codes reported to devices whose messages or connections are refused by the ACL.
+const (
+	ReasonDevicePending  = "device_pending"
+	ReasonDeviceRejected = "device_rejected"
+)
+
+// CloseDeviceRejected is the close code sent when a connected device is rejected; it mirrors HTTP 403.
+const CloseDeviceRejected = 4403
+
+// MessageACL limits the messages a device may send according to its auth status.
+// Approved devices are unrestricted, pending devices may only send the configured text
+// message types, and rejected devices or any other status may send nothing.
+type MessageACL struct {
+	pending map[string]bool
+}
+
+// NewMessageACL builds an ACL that lets pending devices send the given text message types.
+func NewMessageACL(pendingTypes []string) *MessageACL {
+	pending := make(map[string]bool, len(pendingTypes))
+	for _, t := range pendingTypes {
+		pending[t] = true
+	}
+	return &MessageACL{pending: pending}
+}
+
+// Allow reports whether a frame may reach the session handler; denied frames come with a reason code.
+func (a *MessageACL) Allow(status string, messageType int, data []byte) (bool, string) {
+	switch status {
+	case AuthStatusRejected:
+		return false, ReasonDeviceRejected
+	case AuthStatusPending:
+		// 待认证设备的音频等二进制帧一律丢弃
+		if messageType != websocket.TextMessage {
+			return false, ReasonDevicePending
+		}
+		if !a.pending[textMessageType(data)] {
+			return false, ReasonDevicePending
+		}
+		return true, ""
+	case AuthStatusApproved:
+		return true, ""
+	default:
+		return false, ReasonDeviceRejected
+	}
+}
+
+// textMessageType extracts the "type" field of a JSON text message, or "" when absent.
+func textMessageType(data []byte) string {
+	var msg struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return ""
+	}
+	return msg.Type
+}
+
+// deniedMessage is the error sent to a pending device for each refused text message.
+func deniedMessage(reason, denied string) []byte {
+	data, _ := json.Marshal(map[string]interface{}{
+		"type":    "error",
+		"code":    reason,
+		"message": "device is pending approval",
+		"action":  "wait",
+		"denied":  denied,
+	})
+	return data
+}
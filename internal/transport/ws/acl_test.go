@@ -0,0 +1,44 @@
+package ws
+
+import (
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestMessageACLAllow(t *testing.T) {
+	acl := NewMessageACL([]string{"hello", "ping", "mcp"})
+
+	tests := []struct {
+		name        string
+		status      string
+		messageType int
+		data        string
+		allowed     bool
+		reason      string
+	}{
+		{"approved text", AuthStatusApproved, websocket.TextMessage, `{"type":"listen"}`, true, ""},
+		{"approved audio", AuthStatusApproved, websocket.BinaryMessage, "\x01\x02", true, ""},
+		{"unknown status", "", websocket.TextMessage, `{"type":"hello"}`, false, ReasonDeviceRejected},
+		{"unknown status audio", "", websocket.BinaryMessage, "\x01\x02", false, ReasonDeviceRejected},
+		{"unrecognised status", "suspended", websocket.TextMessage, `{"type":"hello"}`, false, ReasonDeviceRejected},
+		{"pending hello", AuthStatusPending, websocket.TextMessage, `{"type":"hello"}`, true, ""},
+		{"pending ping", AuthStatusPending, websocket.TextMessage, `{"type":"ping"}`, true, ""},
+		{"pending mcp", AuthStatusPending, websocket.TextMessage, `{"type":"mcp","payload":{}}`, true, ""},
+		{"pending listen", AuthStatusPending, websocket.TextMessage, `{"type":"listen"}`, false, ReasonDevicePending},
+		{"pending chat", AuthStatusPending, websocket.TextMessage, `{"type":"chat","text":"hi"}`, false, ReasonDevicePending},
+		{"pending missing type", AuthStatusPending, websocket.TextMessage, `{}`, false, ReasonDevicePending},
+		{"pending invalid json", AuthStatusPending, websocket.TextMessage, `hello`, false, ReasonDevicePending},
+		{"pending audio", AuthStatusPending, websocket.BinaryMessage, "\x01\x02", false, ReasonDevicePending},
+		{"rejected hello", AuthStatusRejected, websocket.TextMessage, `{"type":"hello"}`, false, ReasonDeviceRejected},
+		{"rejected audio", AuthStatusRejected, websocket.BinaryMessage, "\x01\x02", false, ReasonDeviceRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, reason := acl.Allow(tt.status, tt.messageType, []byte(tt.data))
+			if allowed != tt.allowed || reason != tt.reason {
+				t.Fatalf("Allow(%q) = %v, %q; want %v, %q", tt.status, allowed, reason, tt.allowed, tt.reason)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package ws
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -9,8 +10,12 @@ import (
 	"github.com/gorilla/websocket"
 
 	"xiaozhi-server-go/internal/domain/mcp"
+	"xiaozhi-server-go/internal/platform/observability"
 )
 
+// closeFlushTimeout bounds how long Close waits for queued frames to be flushed.
+const closeFlushTimeout = 500 * time.Millisecond
+
 // Connection wraps a gorilla websocket connection and implements the
 // src/core.Connection interface used across the legacy stack.
 type Connection struct {
@@ -20,37 +25,177 @@ type Connection struct {
 	closed     atomic.Bool
 	lastActive atomic.Int64
 	mcpHolder  atomic.Pointer[mcp.Manager]
+
+	sendBuf      *sendBuffer
+	writeTimeout time.Duration
+	writerDone   chan struct{}
+	writeErr     atomic.Pointer[error]
+
+	acl        *MessageACL  // nil disables auth-status checks on inbound frames
+	authStatus atomic.Value // string
 }
 
-// NewConnection creates a tracked websocket connection.
+// NewConnection creates a tracked websocket connection with the default send buffer.
 func NewConnection(id string, socket *websocket.Conn) *Connection {
+	return NewConnectionWithBuffer(id, socket, DefaultSendBufferConfig())
+}
+
+// NewConnectionWithBuffer creates a tracked websocket connection whose outbound
+// frames are queued in a bounded buffer and written by a dedicated goroutine.
+func NewConnectionWithBuffer(id string, socket *websocket.Conn, cfg SendBufferConfig) *Connection {
+	buf := newSendBuffer(cfg)
 	conn := &Connection{
-		id:     id,
-		socket: socket,
+		id:           id,
+		socket:       socket,
+		sendBuf:      buf,
+		writeTimeout: buf.cfg.WriteTimeout,
+		writerDone:   make(chan struct{}),
 	}
 	conn.touch()
+	go conn.writeLoop()
 	return conn
 }
 
-// WriteMessage sends a message to the client.
+// WriteMessage queues a message for the client. Text messages apply backpressure
+// when the buffer is full; binary audio frames follow the configured drop policy.
+// A client that keeps the buffer saturated is disconnected with ErrSlowConsumer.
 func (c *Connection) WriteMessage(messageType int, data []byte) error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	return c.write(messageType, data, messageType == websocket.BinaryMessage)
+}
 
+// WriteControlFrame queues a binary frame that carries a control message, such as a
+// protobuf-framed device message. Unlike audio frames it is never dropped.
+func (c *Connection) WriteControlFrame(data []byte) error {
+	return c.write(websocket.BinaryMessage, data, false)
+}
+
+func (c *Connection) write(messageType int, data []byte, audio bool) error {
 	if c.closed.Load() {
 		return fmt.Errorf("connection %s already closed", c.id)
 	}
+	if err := c.writeErr.Load(); err != nil {
+		return *err
+	}
 
-	if err := c.socket.WriteMessage(messageType, data); err != nil {
+	err := c.sendBuf.push(messageType, data, audio)
+	switch err {
+	case nil:
+		return nil
+	case ErrSlowConsumer:
+		observability.RecordMetric(context.Background(), "websocket.send_queue.slow_consumer", 1, map[string]string{
+			"component": "transport.websocket",
+			"client_id": c.id,
+		})
+		c.fail(err)
+		return err
+	case errSendBufferClosed:
+		return fmt.Errorf("connection %s already closed", c.id)
+	default:
 		return err
 	}
+}
+
+// SendBufferStats reports the outbound queue depth and drop counters.
+func (c *Connection) SendBufferStats() SendBufferStats {
+	return c.sendBuf.snapshot()
+}
+
+// writeLoop drains the send buffer onto the socket until the buffer is closed and flushed.
+func (c *Connection) writeLoop() {
+	defer close(c.writerDone)
+
+	for {
+		frame, ok := c.sendBuf.next()
+		if !ok {
+			return
+		}
 
-	c.touch()
-	return nil
+		c.mu.Lock()
+		_ = c.socket.SetWriteDeadline(time.Now().Add(c.writeTimeout))
+		err := c.socket.WriteMessage(frame.messageType, frame.data)
+		c.mu.Unlock()
+		if err != nil {
+			c.fail(err)
+			return
+		}
+		c.touch()
+	}
+}
+
+// fail records the write error and tears down the connection without waiting for a flush.
+func (c *Connection) fail(err error) {
+	c.writeErr.CompareAndSwap(nil, &err)
+	if !c.closed.CompareAndSwap(false, true) {
+		return
+	}
+	c.sendBuf.close()
+	c.recordSendStats()
+	_ = c.socket.Close()
+}
+
+// recordSendStats emits the final queue metrics for the connection.
+func (c *Connection) recordSendStats() {
+	stats := c.sendBuf.snapshot()
+	labels := map[string]string{
+		"component": "transport.websocket",
+		"client_id": c.id,
+	}
+	observability.RecordMetric(context.Background(), "websocket.send_queue.max_depth", float64(stats.MaxDepth), labels)
+	observability.RecordMetric(context.Background(), "websocket.send_queue.dropped_audio", float64(stats.DroppedAudio+stats.Downsampled), labels)
+	observability.RecordMetric(context.Background(), "websocket.send_queue.blocked_writes", float64(stats.BlockedWrites), labels)
 }
 
 // ReadMessage receives a message from the client. Supports interruption via stopChan.
+// Frames refused by the message ACL are dropped and never returned.
 func (c *Connection) ReadMessage(stopChan <-chan struct{}) (int, []byte, error) {
+	for {
+		messageType, payload, err := c.readFrame(stopChan)
+		if err != nil || c.permit(messageType, payload) {
+			return messageType, payload, err
+		}
+	}
+}
+
+// SetMessageACL enables auth-status checks on inbound frames; it must be called before the session starts reading.
+func (c *Connection) SetMessageACL(acl *MessageACL, status string) {
+	c.acl = acl
+	c.SetAuthStatus(status)
+}
+
+// SetAuthStatus updates the device auth status used by the message ACL; it takes effect on the next frame.
+func (c *Connection) SetAuthStatus(status string) {
+	c.authStatus.Store(status)
+}
+
+// AuthStatus returns the device auth status the message ACL currently applies.
+func (c *Connection) AuthStatus() string {
+	status, _ := c.authStatus.Load().(string)
+	return status
+}
+
+// permit applies the message ACL, answering refused text messages with a reason code.
+func (c *Connection) permit(messageType int, payload []byte) bool {
+	if c.acl == nil {
+		return true
+	}
+	allowed, reason := c.acl.Allow(c.AuthStatus(), messageType, payload)
+	if allowed {
+		return true
+	}
+	if messageType == websocket.TextMessage {
+		observability.RecordMetric(context.Background(), "websocket.acl.denied", 1, map[string]string{
+			"component": "transport.websocket",
+			"client_id": c.id,
+			"reason":    reason,
+		})
+		if reason == ReasonDevicePending {
+			_ = c.WriteMessage(websocket.TextMessage, deniedMessage(reason, textMessageType(payload)))
+		}
+	}
+	return false
+}
+
+func (c *Connection) readFrame(stopChan <-chan struct{}) (int, []byte, error) {
 	type readResult struct {
 		messageType int
 		payload     []byte
@@ -77,11 +222,21 @@ func (c *Connection) ReadMessage(stopChan <-chan struct{}) (int, []byte, error)
 	}
 }
 
-// Close terminates the underlying websocket connection.
+// Close flushes queued frames for a short while and terminates the underlying websocket connection.
 func (c *Connection) Close() error {
 	if !c.closed.CompareAndSwap(false, true) {
 		return nil
 	}
+	c.sendBuf.close()
+
+	timer := time.NewTimer(closeFlushTimeout)
+	defer timer.Stop()
+	select {
+	case <-c.writerDone:
+	case <-timer.C:
+	}
+
+	c.recordSendStats()
 	return c.socket.Close()
 }
 
@@ -7,4 +7,10 @@ var (
 	ErrHandshakeTimeout = errors.New("websocket handshake timed out")
 	// ErrSessionShutdown is emitted when the server requests a session shutdown.
 	ErrSessionShutdown = errors.New("websocket session shutdown")
+	// ErrSlowConsumer indicates the client did not drain its send buffer in time and was disconnected.
+	ErrSlowConsumer = errors.New("websocket slow consumer")
+	// ErrDeviceRejected is emitted when the session of a device is closed because the device was rejected.
+	ErrDeviceRejected = errors.New("websocket device rejected")
+
+	errSendBufferClosed = errors.New("websocket send buffer closed")
 )
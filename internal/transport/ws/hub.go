@@ -3,6 +3,8 @@ package ws
 import (
 	"xiaozhi-server-go/internal/platform/logging"
 	"sync"
+
+	"github.com/gorilla/websocket"
 )
 
 // Hub tracks the active websocket sessions for a transport instance.
@@ -67,6 +69,38 @@ func (h *Hub) CloseByDeviceID(deviceID string, reason error) {
 	})
 }
 
+// ApplyAuthStatus updates the auth status enforced on the live sessions of a device.
+// Rejected devices are sent a CloseDeviceRejected frame and disconnected.
+func (h *Hub) ApplyAuthStatus(deviceID string, status string) {
+	h.sessions.Range(func(key, value any) bool {
+		session, ok := value.(*Session)
+		if !ok || session.conn == nil || session.DeviceID() != deviceID {
+			return true
+		}
+		session.conn.SetAuthStatus(status)
+		if status == AuthStatusRejected {
+			h.logger.InfoTag("Hub", "Device %s rejected, closing session %s", deviceID, session.ID())
+			closeFrame := websocket.FormatCloseMessage(CloseDeviceRejected, ReasonDeviceRejected)
+			_ = session.conn.WriteMessage(websocket.CloseMessage, closeFrame)
+			session.Close(ErrDeviceRejected)
+			h.sessions.Delete(key)
+		}
+		return true
+	})
+}
+
+// SendBufferStats reports the outbound queue stats of every active session keyed by session ID.
+func (h *Hub) SendBufferStats() map[string]SendBufferStats {
+	stats := make(map[string]SendBufferStats)
+	h.sessions.Range(func(key, value any) bool {
+		if session, ok := value.(*Session); ok && session.conn != nil {
+			stats[session.ID()] = session.conn.SendBufferStats()
+		}
+		return true
+	})
+	return stats
+}
+
 // Counts exposes the number of active websocket connections.
 func (h *Hub) Counts() (clients int, sessions int) {
 	h.sessions.Range(func(key, value any) bool {
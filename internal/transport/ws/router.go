@@ -10,7 +10,9 @@ import (
 
 	"github.com/gorilla/websocket"
 
+	"xiaozhi-server-go/internal/platform/drain"
 	"xiaozhi-server-go/internal/platform/observability"
+	"xiaozhi-server-go/internal/platform/requestid"
 )
 
 // HandlerBuilder creates a session handler for an upgraded websocket connection.
@@ -23,6 +25,10 @@ type Router struct {
 
 	upgrader         *websocket.Upgrader
 	handshakeTimeout time.Duration
+	sendBuffer       SendBufferConfig
+	authenticate     func(req *http.Request, deviceID string) error
+	acl              *MessageACL
+	authStatus       func(req *http.Request, deviceID string) string
 	builder          atomic.Value // HandlerBuilder
 }
 
@@ -30,6 +36,13 @@ type Router struct {
 type RouterOptions struct {
 	HandshakeTimeout time.Duration
 	CheckOrigin      func(r *http.Request) bool
+	SendBuffer       SendBufferConfig // per-connection outbound buffer, zero values use defaults
+	// Authenticate verifies the device before the upgrade; an error rejects the request with 401, nil skips the check
+	Authenticate func(req *http.Request, deviceID string) error
+	// MessageACL restricts inbound frames by device auth status, nil disables the checks
+	MessageACL *MessageACL
+	// AuthStatus resolves the device auth status at handshake; without it every device is treated as pending
+	AuthStatus func(req *http.Request, deviceID string) string
 }
 
 // NewRouter constructs a websocket router.
@@ -51,6 +64,10 @@ func NewRouter(hub *Hub, logger *logging.Logger, opts RouterOptions) *Router {
 		logger:           logger,
 		upgrader:         upgrader,
 		handshakeTimeout: timeout,
+		sendBuffer:       opts.SendBuffer,
+		authenticate:     opts.Authenticate,
+		acl:              opts.MessageACL,
+		authStatus:       opts.AuthStatus,
 	}
 }
 
@@ -68,7 +85,48 @@ func (r *Router) Handle(w http.ResponseWriter, req *http.Request) {
 	}
 	builder := value.(HandlerBuilder)
 
-	ctx := req.Context()
+	// 排空模式下拒绝新会话，设备会按 Retry-After 重连到其他实例
+	sessionDone, err := drain.Default().Begin(drain.KindSessions)
+	if err != nil {
+		w.Header().Set("Retry-After", "5")
+		http.Error(w, "server is draining", http.StatusServiceUnavailable)
+		return
+	}
+	registered := false
+	defer func() {
+		if !registered {
+			sessionDone()
+		}
+	}()
+
+	// 经扫码配对签发过凭证的设备必须携带凭证
+	deviceID := deviceIDFrom(req)
+	if r.authenticate != nil {
+		if err := r.authenticate(req, deviceID); err != nil {
+			if r.logger != nil {
+				r.logger.WarnTag("WebSocket", "设备 %s 认证失败: %v", deviceID, err)
+			}
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	// 已拒绝的设备在握手时以原因码拒绝，待认证设备建立连接后只能发送受限的消息
+	authStatus := AuthStatusPending
+	if r.acl != nil && r.authStatus != nil {
+		authStatus = r.authStatus(req, deviceID)
+		if authStatus == AuthStatusRejected {
+			if r.logger != nil {
+				r.logger.WarnTag("WebSocket", "设备 %s 已被拒绝，拒绝握手", deviceID)
+			}
+			http.Error(w, ReasonDeviceRejected, http.StatusForbidden)
+			return
+		}
+	}
+
+	// 每个连接一个关联ID，连接内的日志、插件调用和工作流执行都带上它
+	requestID := requestid.Ensure(req.Header.Get(requestid.Header))
+	ctx := requestid.WithID(req.Context(), requestID)
 	handshakeCtx, cancel := context.WithTimeoutCause(ctx, r.handshakeTimeout, ErrHandshakeTimeout)
 	defer cancel()
 	req = req.WithContext(handshakeCtx)
@@ -79,7 +137,7 @@ func (r *Router) Handle(w http.ResponseWriter, req *http.Request) {
 		spanEnd(spanErr)
 	}()
 
-	conn, err := r.upgrader.Upgrade(w, req, nil)
+	conn, err := r.upgrader.Upgrade(w, req, http.Header{requestid.Header: []string{requestID}})
 	if err != nil {
 		spanErr = err
 		observability.RecordMetric(
@@ -91,7 +149,7 @@ func (r *Router) Handle(w http.ResponseWriter, req *http.Request) {
 			},
 		)
 		if r.logger != nil {
-			r.logger.ErrorTag("WebSocket", "握手失败: %v", err)
+			r.logger.With("request_id", requestID).ErrorTag("WebSocket", "握手失败: %v", err)
 		}
 		return
 	}
@@ -100,10 +158,13 @@ func (r *Router) Handle(w http.ResponseWriter, req *http.Request) {
 	if r.logger != nil {
 		// 打印所有请求
 		// r.logger.InfoTag("WebSocket", "收到HTTP请求 %v", req)      
-		r.logger.InfoTag("WebSocket", "建立连接 device=%s client=%s",deviceID, clientID)
+		r.logger.With("request_id", requestID).InfoTag("WebSocket", "建立连接 device=%s client=%s",deviceID, clientID)
 	}
 
-	wsConn := NewConnection(clientID, conn)
+	wsConn := NewConnectionWithBuffer(clientID, conn, r.sendBuffer)
+	if r.acl != nil {
+		wsConn.SetMessageACL(r.acl, authStatus)
+	}
 	observability.RecordMetric(
 		spanCtx,
 		"websocket.upgrade.success",
@@ -126,7 +187,7 @@ func (r *Router) Handle(w http.ResponseWriter, req *http.Request) {
 			},
 		)
 		if r.logger != nil {
-			r.logger.ErrorTag("WebSocket", "创建连接处理器失败: %v", err)
+			r.logger.With("request_id", requestID).ErrorTag("WebSocket", "创建连接处理器失败: %v", err)
 		}
 		_ = wsConn.Close()
 		return
@@ -134,6 +195,7 @@ func (r *Router) Handle(w http.ResponseWriter, req *http.Request) {
 
 	session := NewSession(spanCtx, handler, wsConn, r.logger)
 	r.hub.Register(session)
+	registered = true
 
 	observability.RecordMetric(
 		spanCtx,
@@ -147,9 +209,10 @@ func (r *Router) Handle(w http.ResponseWriter, req *http.Request) {
 	)
 
 	go session.Run(func(runErr error) {
+		defer sessionDone()
 		r.hub.Unregister(session.ID())
 		if runErr != nil && r.logger != nil {
-			r.logger.WarnTag("WebSocket", "会话 %s 异常结束: %v", session.ID(), runErr)
+			r.logger.With("request_id", requestID).WarnTag("WebSocket", "会话 %s 异常结束: %v", session.ID(), runErr)
 		}
 		observability.RecordMetric(
 			session.Context(),
@@ -165,12 +228,9 @@ func (r *Router) Handle(w http.ResponseWriter, req *http.Request) {
 }
 
 func resolveIdentifiers(req *http.Request, conn *websocket.Conn) (string, string) {
-	deviceID := req.Header.Get("Device-Id")
+	deviceID := deviceIDFrom(req)
 	clientID := req.Header.Get("Client-Id")
 
-	if deviceID == "" {
-		deviceID = req.URL.Query().Get("device-id")
-	}
 	if clientID == "" {
 		clientID = req.URL.Query().Get("client-id")
 	}
@@ -180,5 +240,9 @@ func resolveIdentifiers(req *http.Request, conn *websocket.Conn) (string, string
 	return deviceID, clientID
 }
 
-
-
+func deviceIDFrom(req *http.Request) string {
+	if deviceID := req.Header.Get("Device-Id"); deviceID != "" {
+		return deviceID
+	}
+	return req.URL.Query().Get("device-id")
+}
@@ -0,0 +1,242 @@
+package ws
+
+import (
+	"sync"
+	"time"
+)
+
+// SendPolicy controls what happens to binary audio frames once the send buffer is saturated.
+// Text (control) messages are never dropped; they always apply backpressure to the caller.
+type SendPolicy string
+
+const (
+	// SendPolicyBlock makes audio writers wait for space like control messages.
+	SendPolicyBlock SendPolicy = "block"
+	// SendPolicyDropOldest evicts the oldest queued audio frame to make room.
+	SendPolicyDropOldest SendPolicy = "drop_oldest"
+	// SendPolicyDropNewest discards the incoming audio frame.
+	SendPolicyDropNewest SendPolicy = "drop_newest"
+	// SendPolicyDownsample drops every other audio frame once the buffer is half full,
+	// and the incoming frame once it is full.
+	SendPolicyDownsample SendPolicy = "downsample"
+)
+
+// SendBufferConfig configures the per-connection outbound buffer.
+type SendBufferConfig struct {
+	Capacity            int           // maximum number of queued frames
+	AudioPolicy         SendPolicy    // policy for binary frames when saturated
+	WriteTimeout        time.Duration // deadline for a single socket write and for blocked producers
+	SlowConsumerTimeout time.Duration // disconnect once the buffer stays saturated this long
+}
+
+// DefaultSendBufferConfig returns the buffer settings used when none are configured.
+func DefaultSendBufferConfig() SendBufferConfig {
+	return SendBufferConfig{
+		Capacity:            256,
+		AudioPolicy:         SendPolicyDropOldest,
+		WriteTimeout:        10 * time.Second,
+		SlowConsumerTimeout: 15 * time.Second,
+	}
+}
+
+func (cfg SendBufferConfig) withDefaults() SendBufferConfig {
+	def := DefaultSendBufferConfig()
+	if cfg.Capacity <= 0 {
+		cfg.Capacity = def.Capacity
+	}
+	switch cfg.AudioPolicy {
+	case SendPolicyBlock, SendPolicyDropOldest, SendPolicyDropNewest, SendPolicyDownsample:
+	default:
+		cfg.AudioPolicy = def.AudioPolicy
+	}
+	if cfg.WriteTimeout <= 0 {
+		cfg.WriteTimeout = def.WriteTimeout
+	}
+	if cfg.SlowConsumerTimeout <= 0 {
+		cfg.SlowConsumerTimeout = def.SlowConsumerTimeout
+	}
+	return cfg
+}
+
+// SendBufferStats reports queue depth and drop counters for a connection.
+type SendBufferStats struct {
+	Depth         int   `json:"depth"`
+	MaxDepth      int   `json:"max_depth"`
+	Capacity      int   `json:"capacity"`
+	Enqueued      int64 `json:"enqueued"`
+	Sent          int64 `json:"sent"`
+	DroppedAudio  int64 `json:"dropped_audio"`
+	Downsampled   int64 `json:"downsampled"`
+	BlockedWrites int64 `json:"blocked_writes"`
+}
+
+type outboundFrame struct {
+	messageType int
+	data        []byte
+	audio       bool // subject to the audio drop policy
+}
+
+// sendBuffer is a bounded FIFO drained by a single writer goroutine.
+type sendBuffer struct {
+	cfg SendBufferConfig
+
+	mu             sync.Mutex
+	frames         []outboundFrame
+	saturatedSince time.Time
+	skipNext       bool
+	closed         bool
+	stats          SendBufferStats
+
+	ready chan struct{} // signals the writer that frames are available
+	space chan struct{} // signals blocked producers that a slot was freed
+	done  chan struct{} // closed when the buffer is closed
+}
+
+func newSendBuffer(cfg SendBufferConfig) *sendBuffer {
+	cfg = cfg.withDefaults()
+	return &sendBuffer{
+		cfg:    cfg,
+		frames: make([]outboundFrame, 0, cfg.Capacity),
+		stats:  SendBufferStats{Capacity: cfg.Capacity},
+		ready:  make(chan struct{}, 1),
+		space:  make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+}
+
+// push enqueues a frame, applying the audio policy to audio frames or blocking when saturated.
+// It returns ErrSlowConsumer when the buffer has stayed saturated past the threshold.
+func (b *sendBuffer) push(messageType int, data []byte, audio bool) error {
+	var deadline time.Time
+
+	for {
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			return errSendBufferClosed
+		}
+
+		if audio && b.cfg.AudioPolicy == SendPolicyDownsample && len(b.frames) >= b.cfg.Capacity/2 {
+			b.skipNext = !b.skipNext
+			if b.skipNext {
+				b.stats.Downsampled++
+				b.mu.Unlock()
+				return nil
+			}
+		}
+
+		if len(b.frames) < b.cfg.Capacity {
+			b.enqueueLocked(messageType, data, audio)
+			b.mu.Unlock()
+			return nil
+		}
+
+		now := time.Now()
+		if b.saturatedSince.IsZero() {
+			b.saturatedSince = now
+		} else if now.Sub(b.saturatedSince) > b.cfg.SlowConsumerTimeout {
+			b.mu.Unlock()
+			return ErrSlowConsumer
+		}
+
+		if audio && b.cfg.AudioPolicy != SendPolicyBlock {
+			if b.cfg.AudioPolicy == SendPolicyDropOldest && b.evictOldestAudioLocked() {
+				b.enqueueLocked(messageType, data, audio)
+			}
+			b.stats.DroppedAudio++
+			b.mu.Unlock()
+			return nil
+		}
+
+		if deadline.IsZero() {
+			b.stats.BlockedWrites++
+			deadline = now.Add(b.cfg.WriteTimeout)
+		}
+		b.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(deadline))
+		select {
+		case <-b.space:
+			timer.Stop()
+		case <-b.done:
+			timer.Stop()
+			return errSendBufferClosed
+		case <-timer.C:
+			return ErrSlowConsumer
+		}
+	}
+}
+
+func (b *sendBuffer) enqueueLocked(messageType int, data []byte, audio bool) {
+	b.frames = append(b.frames, outboundFrame{messageType: messageType, data: data, audio: audio})
+	b.stats.Enqueued++
+	if len(b.frames) > b.stats.MaxDepth {
+		b.stats.MaxDepth = len(b.frames)
+	}
+	select {
+	case b.ready <- struct{}{}:
+	default:
+	}
+}
+
+// evictOldestAudioLocked removes the oldest queued audio frame.
+func (b *sendBuffer) evictOldestAudioLocked() bool {
+	for i, f := range b.frames {
+		if f.audio {
+			b.frames = append(b.frames[:i], b.frames[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// next blocks until a frame is available. Once closed it keeps returning queued
+// frames so the writer can flush, and reports false when the buffer is empty.
+func (b *sendBuffer) next() (outboundFrame, bool) {
+	for {
+		b.mu.Lock()
+		if len(b.frames) > 0 {
+			frame := b.frames[0]
+			b.frames[0] = outboundFrame{}
+			b.frames = b.frames[1:]
+			if len(b.frames) < b.cfg.Capacity {
+				b.saturatedSince = time.Time{}
+			}
+			b.stats.Sent++
+			b.mu.Unlock()
+			select {
+			case b.space <- struct{}{}:
+			default:
+			}
+			return frame, true
+		}
+		closed := b.closed
+		b.mu.Unlock()
+		if closed {
+			return outboundFrame{}, false
+		}
+
+		select {
+		case <-b.ready:
+		case <-b.done:
+		}
+	}
+}
+
+func (b *sendBuffer) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	close(b.done)
+}
+
+func (b *sendBuffer) snapshot() SendBufferStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	stats := b.stats
+	stats.Depth = len(b.frames)
+	return stats
+}
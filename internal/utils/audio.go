@@ -5,7 +5,6 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"strings"
 	"sync"
 
 	"github.com/hajimehoshi/go-mp3"
@@ -341,6 +340,42 @@ func ReadPCMDataFromWavFile(filePath string) ([]byte, error) {
 }
 
 func AudioToPCMData(audioFile string) ([][]byte, float64, error) {
+	pcmMonoInt16, mp3SampleRate, err := decodeMP3Mono(audioFile)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(pcmMonoInt16) == 0 {
+		return [][]byte{}, 0, nil // 返回空数据
+	}
+
+	// 目标采样率设为24kHz
+	targetSampleRate := 24000
+
+	// 重采样到目标采样率（如果需要）
+	var resampledPcmInt16 []int16
+	var finalSampleRate int
+
+	if mp3SampleRate != targetSampleRate {
+		fmt.Printf("重采样从 %dHz 到 %dHz\n", mp3SampleRate, targetSampleRate)
+		resampledPcmInt16 = resamplePCM(pcmMonoInt16, mp3SampleRate, targetSampleRate)
+		finalSampleRate = targetSampleRate
+	} else {
+		resampledPcmInt16 = pcmMonoInt16
+		finalSampleRate = mp3SampleRate
+	}
+
+	monoPcmDataBytes := int16ToPCMBytes(resampledPcmInt16)
+
+	// 音频播放时长（基于重采样后的数据）
+	duration := float64(len(resampledPcmInt16)) / float64(finalSampleRate) // 单声道PCM数据的时长 (秒)
+
+	// 函数签名要求返回 [][]byte.
+	// 将整个单声道PCM数据作为外部切片中的单个段/切片返回.
+	return [][]byte{monoPcmDataBytes}, duration, nil
+}
+
+// decodeMP3Mono 解码MP3文件为16位单声道PCM样本，返回样本和原始采样率
+func decodeMP3Mono(audioFile string) ([]int16, int, error) {
 	file, err := os.Open(audioFile)
 	if err != nil {
 		return nil, 0, fmt.Errorf("打开音频文件失败: %v", err)
@@ -352,11 +387,6 @@ func AudioToPCMData(audioFile string) ([][]byte, float64, error) {
 		return nil, 0, fmt.Errorf("创建MP3解码器失败: %v", err)
 	}
 
-	mp3SampleRate := decoder.SampleRate()
-	// fmt.Println("AudioToPCMData 原始MP3采样率:", mp3SampleRate)
-	// 目标采样率设为24kHz
-	targetSampleRate := 24000
-
 	// decoder.Length() 返回解码后的PCM数据总字节数 (16-bit little-endian stereo)
 	pcmBytes := make([]byte, decoder.Length())
 	// ReadFull确保读取所有请求的字节，否则返回错误
@@ -372,11 +402,6 @@ func AudioToPCMData(audioFile string) ([][]byte, float64, error) {
 	// numMonoSamples 是转换后得到的16位单声道样本的数量.
 	numMonoSamples := len(pcmBytes) / 4
 
-	if numMonoSamples == 0 {
-		// 处理 pcmBytes 为空或数据不足以形成一个单声道样本的情况 (即少于4字节).
-		return [][]byte{}, 0, nil // 返回空数据
-	}
-
 	pcmMonoInt16 := make([]int16, numMonoSamples)
 	for i := 0; i < numMonoSamples; i++ {
 		// 从pcmBytes中提取16位小端序的左右声道样本
@@ -389,70 +414,22 @@ func AudioToPCMData(audioFile string) ([][]byte, float64, error) {
 		// 使用int32进行中间求和以防止在除法前溢出
 		pcmMonoInt16[i] = int16((int32(leftSample) + int32(rightSample)) / 2)
 	}
+	return pcmMonoInt16, decoder.SampleRate(), nil
+}
 
-	// 重采样到目标采样率（如果需要）
-	var resampledPcmInt16 []int16
-	var finalSampleRate int
-
-	if mp3SampleRate != targetSampleRate {
-		fmt.Printf("重采样从 %dHz 到 %dHz\n", mp3SampleRate, targetSampleRate)
-		resampledPcmInt16 = resamplePCM(pcmMonoInt16, mp3SampleRate, targetSampleRate)
-		finalSampleRate = targetSampleRate
-	} else {
-		resampledPcmInt16 = pcmMonoInt16
-		finalSampleRate = mp3SampleRate
+// int16ToPCMBytes 将16位样本转换为小端序字节
+func int16ToPCMBytes(samples []int16) []byte {
+	data := make([]byte, len(samples)*2) // 每个int16样本占用2字节
+	for i, sample := range samples {
+		data[i*2] = byte(sample)        // 低字节 (LSB)
+		data[i*2+1] = byte(sample >> 8) // 高字节 (MSB)
 	}
-
-	// 将 []int16 类型的单声道PCM数据转换为 []byte (仍然是16位小端序)
-	monoPcmDataBytes := make([]byte, len(resampledPcmInt16)*2) // 每个int16样本占用2字节
-	for i, sample := range resampledPcmInt16 {
-		monoPcmDataBytes[i*2] = byte(sample)        // 低字节 (LSB)
-		monoPcmDataBytes[i*2+1] = byte(sample >> 8) // 高字节 (MSB)
-	}
-
-	// 音频播放时长（基于重采样后的数据）
-	duration := float64(len(resampledPcmInt16)) / float64(finalSampleRate) // 单声道PCM数据的时长 (秒)
-
-	// 函数签名要求返回 [][]byte.
-	// 将整个单声道PCM数据作为外部切片中的单个段/切片返回.
-	return [][]byte{monoPcmDataBytes}, duration, nil
+	return data
 }
 
-// AudioToOpusData 将音频文件转换为Opus数据块
+// AudioToOpusData 将音频文件转换为Opus数据块（24kHz单声道，60ms帧）
 func AudioToOpusData(audioFile string) ([][]byte, float64, error) {
-	var pcmData [][]byte
-	var err error
-	var duration float64
-
-	// 获取采样率 (固定使用24000Hz作为Opus编码的采样率)
-	// 如果采样率不是24000Hz，PCMSlicesToOpusData会处理重采样
-	opusSampleRate := 24000
-	channels := 1
-
-	if strings.HasSuffix(audioFile, ".mp3") {
-		// 先将MP3转为PCM
-		pcmData, duration, err = AudioToPCMData(audioFile)
-		if err != nil {
-			return nil, 0, fmt.Errorf("PCM转换失败: %v", err)
-		}
-
-		if len(pcmData) == 0 {
-			return nil, 0, fmt.Errorf("PCM转换结果为空")
-		}
-
-	} else {
-		var singlePcmData []byte
-		singlePcmData, _ = ReadPCMDataFromWavFile(audioFile)
-		pcmData = [][]byte{singlePcmData}
-	}
-
-	// 将PCM转换为Opus
-	opusData, err := PCMSlicesToOpusData(pcmData, opusSampleRate, channels, 0)
-	if err != nil {
-		return nil, 0, fmt.Errorf("PCM转Opus失败: %v", err)
-	}
-
-	return opusData, duration, nil
+	return AudioToOpusFrames(audioFile, defaultOpusEncoding)
 }
 
 // CopyAudioFile 复制音频文件
@@ -670,95 +647,35 @@ func MP3ToOpusFile(inputFile, outputFile string, bitrate int) error {
 	return SaveAudioFile(opusData, outputFile)
 }
 
-// PCMSlicesToOpusData 将PCM数据切片批量编码为Opus格式
+// PCMSlicesToOpusData 将PCM数据切片批量编码为Opus格式（60ms帧），bitrate 为 0 时自动选择码率
 func PCMSlicesToOpusData(pcmSlices [][]byte, sampleRate int, channels int, bitrate int) ([][]byte, error) {
 	if len(pcmSlices) == 0 {
 		return nil, fmt.Errorf("PCM数据切片为空")
 	}
 
-	// 检查采样率是否支持
-	supportedRates := map[int]bool{8000: true, 12000: true, 16000: true, 24000: true, 48000: true}
-	if !supportedRates[sampleRate] {
-		return nil, fmt.Errorf("采样率 %dHz 不被Opus支持，仅支持8000/12000/16000/24000/48000Hz", sampleRate)
-	}
-
-	// 创建Opus编码器
-	encoder, err := opus.CreateOpusEncoder(&opus.OpusEncoderConfig{
+	encoder, err := NewOpusEncoder(OpusEncoderConfig{
 		SampleRate:    sampleRate,
-		MaxChannels:   channels,
-		Application:   opus.AppVoIP,
-		FrameDuration: opus.Framesize60Ms, // 使用60ms帧长
+		Channels:      channels,
+		FrameDuration: 60,
+		Bitrate:       bitrate,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("创建Opus编码器失败: %v", err)
+		return nil, err
 	}
 	defer encoder.Close()
 
 	// 所有编码后的Opus数据包
 	var allOpusPackets [][]byte
-
-	// 计算每帧样本数 (60ms帧)
-	samplesPerFrame := (sampleRate * 60) / 1000 // 60ms帧
-	// 每个样本的字节数 (16位 = 2字节)
-	bytesPerSample := 2 * channels
-	// 每帧字节数
-	bytesPerFrame := samplesPerFrame * bytesPerSample
-
 	for _, pcmSlice := range pcmSlices {
-		if len(pcmSlice) == 0 {
-			continue
-		}
-
 		// 确保PCM数据长度是偶数
 		if len(pcmSlice)%2 != 0 {
 			pcmSlice = pcmSlice[:len(pcmSlice)-1] // 截断最后一个字节
-			if len(pcmSlice) == 0 {
-				continue
-			}
-		}
-
-		// 计算这个PCM片段可以分成多少帧
-		numFrames := len(pcmSlice) / bytesPerFrame
-		if len(pcmSlice)%bytesPerFrame != 0 {
-			numFrames++ // 如果有剩余数据，额外增加一帧
 		}
-
-		// 逐帧处理PCM数据
-		for frameIdx := 0; frameIdx < numFrames; frameIdx++ {
-			frameStart := frameIdx * bytesPerFrame
-			frameEnd := frameStart + bytesPerFrame
-
-			// 确保不越界
-			if frameEnd > len(pcmSlice) {
-				frameEnd = len(pcmSlice)
-			}
-
-			// 当前帧的PCM数据
-			framePcm := pcmSlice[frameStart:frameEnd]
-
-			// 如果最后一帧数据不足，需要填充静音数据到完整帧大小
-			if len(framePcm) < bytesPerFrame {
-				paddedFrame := make([]byte, bytesPerFrame)
-				copy(paddedFrame, framePcm)
-				framePcm = paddedFrame
-			}
-
-			// 分配输出缓冲区 (Opus编码后的数据通常比PCM小)
-			outBuf := make([]byte, len(framePcm))
-
-			// 编码这一帧PCM数据到Opus
-			n, err := encoder.Encode(framePcm, outBuf)
-			if err != nil {
-				continue // 跳过这一帧，继续处理下一帧
-			}
-
-			if n == 0 {
-				continue // 跳过空帧
-			}
-
-			// 将编码后的Opus数据添加到结果集
-			allOpusPackets = append(allOpusPackets, outBuf[:n])
+		packets, err := encoder.Encode(pcmSlice)
+		if err != nil {
+			return nil, err
 		}
+		allOpusPackets = append(allOpusPackets, packets...)
 	}
 
 	if len(allOpusPackets) == 0 {
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hajimehoshi/go-mp3"
+)
+
+// mp3PCMStream 边解码边输出指定采样率的16位单声道PCM，用于播放长音频和网络电台
+type mp3PCMStream struct {
+	decoder    *mp3.Decoder
+	sampleRate int
+	buf        []byte // 解码得到的立体声数据
+	out        []byte // 待读出的单声道PCM
+}
+
+// NewMP3PCMStream 将MP3数据流解码为指定采样率的16位单声道PCM流，r 不需要支持Seek
+func NewMP3PCMStream(r io.Reader, sampleRate int) (io.Reader, error) {
+	decoder, err := mp3.NewDecoder(r)
+	if err != nil {
+		return nil, fmt.Errorf("创建MP3解码器失败: %v", err)
+	}
+	// 每次解码约100ms，重采样的分段边界不会产生可闻的杂音
+	return &mp3PCMStream{
+		decoder:    decoder,
+		sampleRate: sampleRate,
+		buf:        make([]byte, decoder.SampleRate()/10*4),
+	}, nil
+}
+
+func (s *mp3PCMStream) Read(p []byte) (int, error) {
+	for len(s.out) == 0 {
+		n, err := io.ReadFull(s.decoder, s.buf)
+		if n >= 4 {
+			samples := make([]int16, n/4)
+			for i := range samples {
+				left := int16(binary.LittleEndian.Uint16(s.buf[i*4:]))
+				right := int16(binary.LittleEndian.Uint16(s.buf[i*4+2:]))
+				samples[i] = int16((int32(left) + int32(right)) / 2)
+			}
+			s.out = int16ToPCMBytes(resamplePCM(samples, s.decoder.SampleRate(), s.sampleRate))
+		}
+		if err != nil {
+			if len(s.out) > 0 {
+				break
+			}
+			if err == io.ErrUnexpectedEOF {
+				err = io.EOF
+			}
+			return 0, err
+		}
+	}
+	n := copy(p, s.out)
+	s.out = s.out[n:]
+	return n, nil
+}
+
+// OpenAudioPCMStream 打开本地音频文件并输出指定采样率的16位单声道PCM，MP3边读边解码，WAV一次性读入
+func OpenAudioPCMStream(audioFile string, sampleRate int) (io.ReadCloser, error) {
+	if !strings.HasSuffix(strings.ToLower(audioFile), ".mp3") {
+		pcmData, _, err := AudioFileToPCM(audioFile, sampleRate)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(pcmData)), nil
+	}
+
+	file, err := os.Open(audioFile)
+	if err != nil {
+		return nil, fmt.Errorf("打开音频文件失败: %v", err)
+	}
+	stream, err := NewMP3PCMStream(file, sampleRate)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{stream, file}, nil
+}
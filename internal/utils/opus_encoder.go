@@ -0,0 +1,281 @@
+package utils
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	opus "github.com/qrtc/opus-go"
+)
+
+// Opus 码率范围（bps）
+const (
+	opusMinBitrate = 6000
+	opusMaxBitrate = 510000
+)
+
+// opusFECPacketLoss 开启带内 FEC 时告知编码器的预期丢包率（%），为 0 时 FEC 不生效
+const opusFECPacketLoss = 10
+
+var (
+	opusSampleRates    = map[int]bool{8000: true, 12000: true, 16000: true, 24000: true, 48000: true}
+	opusFrameDurations = map[int]opus.FrameSizeType{
+		10: opus.Framesize10Ms, 20: opus.Framesize20Ms, 40: opus.Framesize40Ms, 60: opus.Framesize60Ms,
+		80: opus.Framesize80Ms, 100: opus.Framesize100Ms, 120: opus.Framesize120Ms,
+	}
+	defaultOpusEncoding = OpusEncoderConfig{SampleRate: 24000, Channels: 1, FrameDuration: 60}
+)
+
+// OpusEncoderConfig 编码器配置
+type OpusEncoderConfig struct {
+	SampleRate    int  // 8000/12000/16000/24000/48000
+	Channels      int  // 1 或 2
+	FrameDuration int  // 帧时长（毫秒）：10/20/40/60/80/100/120
+	Bitrate       int  // 码率（bps），0 表示自动
+	Complexity    int  // 复杂度 1-10，0 表示使用编码器默认值
+	InbandFEC     bool // 带内前向纠错
+}
+
+// ValidateOpusEncoderConfig 校验编码器配置，不合法时返回可读的错误
+func ValidateOpusEncoderConfig(config OpusEncoderConfig) error {
+	if !opusSampleRates[config.SampleRate] {
+		return fmt.Errorf("采样率 %dHz 不被Opus支持，仅支持8000/12000/16000/24000/48000Hz", config.SampleRate)
+	}
+	if config.Channels != 1 && config.Channels != 2 {
+		return fmt.Errorf("Opus仅支持1或2声道，当前为 %d", config.Channels)
+	}
+	if _, ok := opusFrameDurations[config.FrameDuration]; !ok {
+		return fmt.Errorf("帧时长 %dms 不被Opus支持，仅支持10/20/40/60/80/100/120ms", config.FrameDuration)
+	}
+	if config.Bitrate != 0 && (config.Bitrate < opusMinBitrate || config.Bitrate > opusMaxBitrate) {
+		return fmt.Errorf("码率 %dbps 超出范围 %d-%d", config.Bitrate, opusMinBitrate, opusMaxBitrate)
+	}
+	if config.Complexity < 0 || config.Complexity > 10 {
+		return fmt.Errorf("编码复杂度 %d 超出范围 0-10", config.Complexity)
+	}
+	return nil
+}
+
+// OpusEncoder 封装opus编码器，按配置的帧时长把PCM切分编码
+type OpusEncoder struct {
+	encoder    *opus.OpusEncoder
+	mu         sync.Mutex
+	config     OpusEncoderConfig
+	frameBytes int
+}
+
+// NewOpusEncoder 创建新的opus编码器
+func NewOpusEncoder(config OpusEncoderConfig) (*OpusEncoder, error) {
+	if err := ValidateOpusEncoderConfig(config); err != nil {
+		return nil, err
+	}
+
+	libConfig := &opus.OpusEncoderConfig{
+		SampleRate:      config.SampleRate,
+		MaxChannels:     config.Channels,
+		Application:     opus.AppVoIP,
+		FrameDuration:   opusFrameDurations[config.FrameDuration],
+		Bitrate:         config.Bitrate,
+		Complexity:      config.Complexity,
+		EnableInbandFEC: config.InbandFEC,
+	}
+	if config.InbandFEC {
+		libConfig.PacketLossPercent = opusFECPacketLoss
+	}
+
+	encoder, err := opus.CreateOpusEncoder(libConfig)
+	if err != nil {
+		return nil, fmt.Errorf("创建Opus编码器失败: %v", err)
+	}
+
+	return &OpusEncoder{
+		encoder:    encoder,
+		config:     config,
+		frameBytes: PCMFrameBytes(config.SampleRate, config.Channels, config.FrameDuration),
+	}, nil
+}
+
+// Encode 将16位小端序PCM编码为Opus数据包，每包一帧，最后不足一帧的部分补静音
+func (e *OpusEncoder) Encode(pcmData []byte) ([][]byte, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.encoder == nil {
+		return nil, fmt.Errorf("Opus编码器已关闭")
+	}
+
+	var packets [][]byte
+	for _, frame := range SplitPCMFrames(pcmData, e.frameBytes, true) {
+		// Opus编码后的数据不会比PCM大
+		outBuf := make([]byte, len(frame))
+		n, err := e.encoder.Encode(frame, outBuf)
+		if err != nil {
+			return nil, fmt.Errorf("Opus编码失败: %v", err)
+		}
+		if n == 0 {
+			continue // 跳过空帧
+		}
+		packets = append(packets, outBuf[:n])
+	}
+	return packets, nil
+}
+
+// Close 关闭编码器
+func (e *OpusEncoder) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.encoder != nil {
+		if err := e.encoder.Close(); err != nil {
+			return fmt.Errorf("关闭Opus编码器失败: %v", err)
+		}
+		e.encoder = nil
+	}
+	return nil
+}
+
+// PCMFrameBytes 计算一帧16位PCM的字节数
+func PCMFrameBytes(sampleRate, channels, frameDuration int) int {
+	return sampleRate * frameDuration / 1000 * channels * 2
+}
+
+// SplitPCMFrames 按帧字节数切分PCM，pad 为 true 时最后不足一帧的部分补静音
+func SplitPCMFrames(pcmData []byte, frameBytes int, pad bool) [][]byte {
+	if frameBytes <= 0 || len(pcmData) == 0 {
+		return nil
+	}
+	frames := make([][]byte, 0, (len(pcmData)+frameBytes-1)/frameBytes)
+	for start := 0; start < len(pcmData); start += frameBytes {
+		end := start + frameBytes
+		if end > len(pcmData) {
+			end = len(pcmData)
+		}
+		frame := pcmData[start:end]
+		if pad && len(frame) < frameBytes {
+			padded := make([]byte, frameBytes)
+			copy(padded, frame)
+			frame = padded
+		}
+		frames = append(frames, frame)
+	}
+	return frames
+}
+
+// AudioFileToPCM 将MP3或WAV文件解码并重采样为指定采样率的16位单声道PCM，返回PCM和时长（秒）
+func AudioFileToPCM(audioFile string, sampleRate int) ([]byte, float64, error) {
+	var samples []int16
+	var sourceRate int
+	var err error
+	if strings.HasSuffix(strings.ToLower(audioFile), ".mp3") {
+		samples, sourceRate, err = decodeMP3Mono(audioFile)
+	} else {
+		samples, sourceRate, err = readWavMono(audioFile)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(samples) == 0 {
+		return nil, 0, nil
+	}
+
+	samples = resamplePCM(samples, sourceRate, sampleRate)
+	return int16ToPCMBytes(samples), float64(len(samples)) / float64(sampleRate), nil
+}
+
+// AudioToPCMFrames 将音频文件转换为指定采样率、按帧时长切分的单声道PCM帧
+func AudioToPCMFrames(audioFile string, sampleRate, frameDuration int) ([][]byte, float64, error) {
+	pcmData, duration, err := AudioFileToPCM(audioFile, sampleRate)
+	if err != nil {
+		return nil, 0, err
+	}
+	return SplitPCMFrames(pcmData, PCMFrameBytes(sampleRate, 1, frameDuration), false), duration, nil
+}
+
+// AudioToOpusFrames 将音频文件按编码器配置转换为Opus数据包，config.Channels 为 0 时按单声道处理
+func AudioToOpusFrames(audioFile string, config OpusEncoderConfig) ([][]byte, float64, error) {
+	if config.Channels == 0 {
+		config.Channels = 1
+	}
+	if config.Channels != 1 {
+		return nil, 0, fmt.Errorf("TTS音频仅支持编码为单声道，当前为 %d 声道", config.Channels)
+	}
+
+	encoder, err := NewOpusEncoder(config)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer encoder.Close()
+
+	pcmData, duration, err := AudioFileToPCM(audioFile, config.SampleRate)
+	if err != nil {
+		return nil, 0, fmt.Errorf("PCM转换失败: %v", err)
+	}
+	if len(pcmData) == 0 {
+		return nil, 0, fmt.Errorf("PCM转换结果为空")
+	}
+
+	packets, err := encoder.Encode(pcmData)
+	if err != nil {
+		return nil, 0, fmt.Errorf("PCM转Opus失败: %v", err)
+	}
+	return packets, duration, nil
+}
+
+// readWavMono 读取16位WAV文件为单声道样本，按RIFF块解析采样率和声道数；
+// 没有RIFF头的文件按24kHz单声道裸PCM（跳过44字节）处理，与 ReadPCMDataFromWavFile 一致
+func readWavMono(filePath string) ([]int16, int, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, 0, fmt.Errorf("打开WAV文件失败: %v", err)
+	}
+
+	sampleRate, channels, pcmData := 24000, 1, data
+	if len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WAVE" {
+		pcmData = nil
+		bitsPerSample := 0
+		for pos := 12; pos+8 <= len(data); {
+			id := string(data[pos : pos+4])
+			size := int(binary.LittleEndian.Uint32(data[pos+4 : pos+8]))
+			body := data[pos+8:]
+			if size < len(body) {
+				body = body[:size]
+			}
+			switch id {
+			case "fmt ":
+				if len(body) < 16 {
+					return nil, 0, fmt.Errorf("WAV格式块不完整")
+				}
+				channels = int(binary.LittleEndian.Uint16(body[2:4]))
+				sampleRate = int(binary.LittleEndian.Uint32(body[4:8]))
+				bitsPerSample = int(binary.LittleEndian.Uint16(body[14:16]))
+			case "data":
+				pcmData = body
+			}
+			pos += 8 + size + size%2 // 块按偶数字节对齐
+		}
+		if pcmData == nil {
+			return nil, 0, fmt.Errorf("WAV文件缺少数据块")
+		}
+		if bitsPerSample != 16 {
+			return nil, 0, fmt.Errorf("仅支持16位WAV，当前为 %d 位", bitsPerSample)
+		}
+		if channels < 1 || sampleRate <= 0 {
+			return nil, 0, fmt.Errorf("WAV参数无效: %dHz %d声道", sampleRate, channels)
+		}
+	} else if len(data) >= 44 {
+		pcmData = data[44:]
+	}
+
+	frameSize := 2 * channels
+	samples := make([]int16, len(pcmData)/frameSize)
+	for i := range samples {
+		sum := 0
+		for ch := 0; ch < channels; ch++ {
+			sum += int(int16(binary.LittleEndian.Uint16(pcmData[i*frameSize+ch*2:])))
+		}
+		samples[i] = int16(sum / channels)
+	}
+	return samples, sampleRate, nil
+}
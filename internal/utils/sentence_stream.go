@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// SentenceStream 把LLM的流式输出切分为可以立即送入TTS的分段
+//
+// 首段只要遇到逗号等停顿且已有 firstMinChars 个字就输出，让合成尽早开始；之后的分段按
+// SplitAtLastPunctuation 分句。任何分段超过 maxChars 个字时在字符边界处强制切分，
+// 避免长句等待过久或在合成前被截断。两个参数 <=0 时不启用对应的规则。
+type SentenceStream struct {
+	firstMinChars int
+	maxChars      int
+	pending       string
+	segments      int
+}
+
+// NewSentenceStream 创建分段器
+func NewSentenceStream(firstMinChars, maxChars int) *SentenceStream {
+	return &SentenceStream{firstMinChars: firstMinChars, maxChars: maxChars}
+}
+
+// Write 追加一段流式输出，返回其中已经完整的分段
+func (s *SentenceStream) Write(delta string) []string {
+	s.pending += delta
+
+	var out []string
+	for {
+		segment, n := s.next()
+		if n == 0 {
+			return out
+		}
+		s.pending = s.pending[n:]
+		if segment = strings.TrimSpace(segment); segment != "" {
+			s.segments++
+			out = append(out, segment)
+		}
+	}
+}
+
+// Flush 取出尚未输出的剩余文本，输出结束时调用
+func (s *SentenceStream) Flush() string {
+	rest := strings.TrimSpace(s.pending)
+	s.pending = ""
+	if rest != "" {
+		s.segments++
+	}
+	return rest
+}
+
+// Reset 丢弃尚未输出的文本，如确认输出是工具调用时
+func (s *SentenceStream) Reset() {
+	s.pending = ""
+}
+
+// Segments 已输出的分段数
+func (s *SentenceStream) Segments() int {
+	return s.segments
+}
+
+func (s *SentenceStream) next() (string, int) {
+	text := s.pending
+	if s.segments == 0 && s.firstMinChars > 0 {
+		if n := firstBreak(text, s.firstMinChars); n > 0 {
+			return s.limit(text[:n])
+		}
+	}
+	if segment, n := SplitAtLastPunctuation(text); n > 0 {
+		return s.limit(segment)
+	}
+	if s.maxChars > 0 && utf8.RuneCountInString(text) > s.maxChars {
+		return s.limit(text)
+	}
+	return "", 0
+}
+
+// limit 超过 maxChars 个字时只取前 maxChars 个字
+func (s *SentenceStream) limit(segment string) (string, int) {
+	if s.maxChars <= 0 {
+		return segment, len(segment)
+	}
+	count := 0
+	for i := range segment {
+		if count == s.maxChars {
+			return segment[:i], i
+		}
+		count++
+	}
+	return segment, len(segment)
+}
+
+// firstBreak 查找首段的切分位置：任意句末标点，或字数达到 minChars 后的第一个停顿标点
+func firstBreak(text string, minChars int) int {
+	count := 0
+	for i, r := range text {
+		switch r {
+		case '。', '？', '！', '；', '?', '!', ';', '\n':
+			if count > 0 {
+				return i + utf8.RuneLen(r)
+			}
+		case '，', '：', '、', ',', ':':
+			if count >= minChars {
+				return i + utf8.RuneLen(r)
+			}
+		case '.':
+			// 英文句号可能是小数点，需要看到下一个字符才能判断
+			end := i + 1
+			if count >= minChars && end < len(text) && (text[end] < '0' || text[end] > '9') {
+				return end
+			}
+		}
+		count++
+	}
+	return 0
+}
@@ -87,6 +87,10 @@ func SplitAtLastPunctuation(text string) (string, int) {
 		if len(text) < cutPos {
 			cutPos = len(text) / 2
 		}
+		// 不在多字节字符中间切分
+		for cutPos > 0 && !utf8.RuneStart(text[cutPos]) {
+			cutPos--
+		}
 		return text[:cutPos], cutPos
 	}
 
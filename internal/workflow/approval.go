@@ -0,0 +1,213 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ApprovalStatus 审批状态
+type ApprovalStatus string
+
+const (
+	ApprovalPending   ApprovalStatus = "pending"   // 等待审批
+	ApprovalApproved  ApprovalStatus = "approved"  // 已批准
+	ApprovalRejected  ApprovalStatus = "rejected"  // 已拒绝
+	ApprovalExpired   ApprovalStatus = "expired"   // 超时未审批
+	ApprovalCancelled ApprovalStatus = "cancelled" // 执行取消或超时，审批作废
+)
+
+// 审批超时后的处理方式，通过节点配置 on_timeout 指定
+const (
+	ApprovalTimeoutFail    = "fail"    // 节点失败，中止执行（默认）
+	ApprovalTimeoutApprove = "approve" // 视为批准，继续执行
+	ApprovalTimeoutReject  = "reject"  // 视为拒绝，中止执行
+)
+
+// 审批错误
+var (
+	ErrApprovalNotFound = errors.New("approval not found")
+	ErrApprovalDecided  = errors.New("approval already decided")
+)
+
+// ApprovalTask 人工审批任务，由 approval 节点创建
+type ApprovalTask struct {
+	ID          string                 `json:"id"`
+	ExecutionID string                 `json:"execution_id"`
+	WorkflowID  string                 `json:"workflow_id"`
+	NodeID      string                 `json:"node_id"`
+	Title       string                 `json:"title"`
+	Description string                 `json:"description,omitempty"`
+	Data        map[string]interface{} `json:"data,omitempty"` // 节点输入，供审批人查看
+	Status      ApprovalStatus         `json:"status"`
+	OnTimeout   string                 `json:"on_timeout"`
+	CreatedAt   time.Time              `json:"created_at"`
+	ExpiresAt   *time.Time             `json:"expires_at,omitempty"`
+	DecidedAt   *time.Time             `json:"decided_at,omitempty"`
+	Approver    string                 `json:"approver,omitempty"`
+	Comment     string                 `json:"comment,omitempty"`
+
+	done chan struct{}
+}
+
+// ApprovalManager 审批任务管理器
+type ApprovalManager struct {
+	mu     sync.RWMutex
+	tasks  map[string]*ApprovalTask
+	nextID uint64
+}
+
+// NewApprovalManager 创建审批任务管理器
+func NewApprovalManager() *ApprovalManager {
+	return &ApprovalManager{tasks: make(map[string]*ApprovalTask)}
+}
+
+// Create 创建待审批任务，timeout<=0 表示不超时
+func (m *ApprovalManager) Create(task ApprovalTask, timeout time.Duration) *ApprovalTask {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextID++
+	now := time.Now()
+	created := task
+	created.ID = fmt.Sprintf("approval_%d_%d", now.UnixNano(), m.nextID)
+	created.Status = ApprovalPending
+	created.CreatedAt = now
+	if created.OnTimeout == "" {
+		created.OnTimeout = ApprovalTimeoutFail
+	}
+	if timeout > 0 {
+		expires := now.Add(timeout)
+		created.ExpiresAt = &expires
+	}
+	created.done = make(chan struct{})
+	m.tasks[created.ID] = &created
+	return &created
+}
+
+// Get 返回审批任务副本
+func (m *ApprovalManager) Get(id string) (ApprovalTask, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	task, ok := m.tasks[id]
+	if !ok {
+		return ApprovalTask{}, false
+	}
+	return *task, true
+}
+
+// List 返回审批任务，status 为空时返回全部，按创建时间倒序
+func (m *ApprovalManager) List(status ApprovalStatus) []ApprovalTask {
+	m.mu.RLock()
+	result := make([]ApprovalTask, 0, len(m.tasks))
+	for _, task := range m.tasks {
+		if status == "" || task.Status == status {
+			result = append(result, *task)
+		}
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.After(result[j].CreatedAt) })
+	return result
+}
+
+// Decide 提交审批结果，approver 记录审批人身份
+func (m *ApprovalManager) Decide(id string, approved bool, approver, comment string) (ApprovalTask, error) {
+	status := ApprovalRejected
+	if approved {
+		status = ApprovalApproved
+	}
+	return m.finish(id, status, approver, comment)
+}
+
+// PendingFor 返回执行中仍在等待审批的任务数
+func (m *ApprovalManager) PendingFor(executionID string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	n := 0
+	for _, task := range m.tasks {
+		if task.ExecutionID == executionID && task.Status == ApprovalPending {
+			n++
+		}
+	}
+	return n
+}
+
+func (m *ApprovalManager) finish(id string, status ApprovalStatus, approver, comment string) (ApprovalTask, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	task, ok := m.tasks[id]
+	if !ok {
+		return ApprovalTask{}, ErrApprovalNotFound
+	}
+	if task.Status != ApprovalPending {
+		return *task, ErrApprovalDecided
+	}
+	now := time.Now()
+	task.Status = status
+	task.DecidedAt = &now
+	task.Approver = approver
+	task.Comment = comment
+	close(task.done)
+	return *task, nil
+}
+
+// Wait 等待审批结果；超时按 OnTimeout 处理并返回处理后的任务，ctx 结束时任务作废
+func (m *ApprovalManager) Wait(ctx context.Context, id string) (ApprovalTask, error) {
+	m.mu.RLock()
+	task, ok := m.tasks[id]
+	m.mu.RUnlock()
+	if !ok {
+		return ApprovalTask{}, ErrApprovalNotFound
+	}
+
+	var expired <-chan time.Time
+	if task.ExpiresAt != nil {
+		timer := time.NewTimer(time.Until(*task.ExpiresAt))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	select {
+	case <-task.done:
+	case <-expired:
+		status := ApprovalExpired
+		if task.OnTimeout == ApprovalTimeoutApprove {
+			status = ApprovalApproved
+		} else if task.OnTimeout == ApprovalTimeoutReject {
+			status = ApprovalRejected
+		}
+		// 超时和人工审批同时发生时以先提交的为准
+		m.finish(id, status, "system:timeout", "")
+	case <-ctx.Done():
+		m.finish(id, ApprovalCancelled, "", ctx.Err().Error())
+	}
+
+	result, _ := m.Get(id)
+	return result, nil
+}
+
+var (
+	defaultApprovalManager   = NewApprovalManager()
+	defaultApprovalManagerMu sync.RWMutex
+)
+
+// SetDefaultApprovalManager 设置全局审批任务管理器
+func SetDefaultApprovalManager(m *ApprovalManager) {
+	defaultApprovalManagerMu.Lock()
+	defer defaultApprovalManagerMu.Unlock()
+	if m == nil {
+		m = NewApprovalManager()
+	}
+	defaultApprovalManager = m
+}
+
+// DefaultApprovalManager 返回全局审批任务管理器
+func DefaultApprovalManager() *ApprovalManager {
+	defaultApprovalManagerMu.RLock()
+	defer defaultApprovalManagerMu.RUnlock()
+	return defaultApprovalManager
+}
@@ -0,0 +1,347 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"xiaozhi-server-go/internal/domain/tenant"
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+// 试运行问题级别
+const (
+	SeverityError   = "error"   // 实际执行必然失败
+	SeverityWarning = "warning" // 可能失败，取决于运行时数据
+)
+
+// DryRunIssue 试运行发现的问题
+type DryRunIssue struct {
+	Severity string `json:"severity"`
+	NodeID   string `json:"node_id,omitempty"`
+	EdgeID   string `json:"edge_id,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Message  string `json:"message"`
+}
+
+// DryRunNode 单个节点的试运行结果
+type DryRunNode struct {
+	NodeID       string                 `json:"node_id"`
+	Type         NodeType               `json:"type"`
+	CapabilityID string                 `json:"capability_id,omitempty"`
+	ProviderID   string                 `json:"provider_id,omitempty"`
+	Config       map[string]interface{} `json:"config,omitempty"` // 合并全局配置和默认值后的配置，敏感字段已脱敏
+	Issues       []DryRunIssue          `json:"issues,omitempty"`
+}
+
+// DryRunReport 工作流试运行报告
+type DryRunReport struct {
+	WorkflowID string        `json:"workflow_id"`
+	Valid      bool          `json:"valid"` // 没有 error 级别的问题
+	Order      []string      `json:"order"` // 拓扑执行顺序
+	Nodes      []DryRunNode  `json:"nodes"`
+	Issues     []DryRunIssue `json:"issues"` // 全部问题，包括节点和边上的问题
+}
+
+// HealthChecker 提供者健康状态查询，known 为 false 表示没有该提供者的健康信息
+type HealthChecker interface {
+	ProviderHealth(providerID string) (healthy bool, detail string, known bool)
+}
+
+// DryRunOptions 试运行选项
+type DryRunOptions struct {
+	Health HealthChecker // 为 nil 时不检查健康状态
+}
+
+const maskedValue = "******"
+
+// DryRun 试运行工作流：按拓扑顺序解析节点配置，检查引用的能力和提供者是否存在且健康，
+// 校验边两端的输入输出是否匹配，不执行任何节点
+func (e *WorkflowExecutorImpl) DryRun(ctx context.Context, workflow *Workflow, inputs map[string]interface{}, opts DryRunOptions) *DryRunReport {
+	report := &DryRunReport{
+		WorkflowID: workflow.ID,
+		Order:      []string{},
+		Nodes:      []DryRunNode{},
+		Issues:     []DryRunIssue{},
+	}
+
+	if err := e.dagEngine.ValidateWorkflow(workflow); err != nil {
+		report.Issues = append(report.Issues, DryRunIssue{Severity: SeverityError, Message: err.Error()})
+		return report
+	}
+	order, err := e.dagEngine.TopologicalSort(workflow.Nodes, workflow.Edges)
+	if err != nil {
+		report.Issues = append(report.Issues, DryRunIssue{Severity: SeverityError, Message: err.Error()})
+		return report
+	}
+	report.Order = order
+
+	nodesByID := make(map[string]*Node, len(workflow.Nodes))
+	for i := range workflow.Nodes {
+		nodesByID[workflow.Nodes[i].ID] = &workflow.Nodes[i]
+	}
+
+	// 先解析全部节点的能力定义，边检查需要上游节点的输出
+	defs := make(map[string]*capability.Definition, len(workflow.Nodes))
+	results := make(map[string]*DryRunNode, len(workflow.Nodes))
+	for _, nodeID := range order {
+		node := nodesByID[nodeID]
+		if node == nil {
+			continue
+		}
+		result := &DryRunNode{NodeID: node.ID, Type: node.Type}
+		if def := e.dryRunResolveNode(ctx, node, result, opts); def != nil {
+			defs[node.ID] = def
+		}
+		results[node.ID] = result
+	}
+
+	for _, nodeID := range order {
+		node := nodesByID[nodeID]
+		result := results[nodeID]
+		if node == nil || result == nil {
+			continue
+		}
+		e.dryRunCheckInputs(workflow, node, nodesByID, defs, inputs, result)
+		report.Nodes = append(report.Nodes, *result)
+		report.Issues = append(report.Issues, result.Issues...)
+	}
+
+	report.Valid = true
+	for _, issue := range report.Issues {
+		if issue.Severity == SeverityError {
+			report.Valid = false
+			break
+		}
+	}
+	return report
+}
+
+// dryRunResolveNode 解析节点对应的能力、提供者和配置，返回能力定义（内置控制节点返回 nil）
+func (e *WorkflowExecutorImpl) dryRunResolveNode(ctx context.Context, node *Node, result *DryRunNode, opts DryRunOptions) *capability.Definition {
+	addIssue := func(severity, field, format string, args ...interface{}) {
+		result.Issues = append(result.Issues, DryRunIssue{
+			Severity: severity,
+			NodeID:   node.ID,
+			Field:    field,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	var capabilityID string
+	switch node.Type {
+	case NodeTypeStart, NodeTypeEnd, NodeTypeParallel, NodeTypeMerge:
+		return nil
+	case NodeTypeCondition:
+		presence, err := parsePresenceCondition(node)
+		if err != nil {
+			addIssue(SeverityError, "config.presence", "%v", err)
+		} else if presence != nil && DefaultPresenceChecker() == nil {
+			addIssue(SeverityError, "config.presence", "presence is not enabled")
+		}
+		return nil
+	case NodeTypeApproval:
+		if _, err := approvalTimeout(node.Config["timeout"]); err != nil {
+			addIssue(SeverityError, "config.timeout", "%v", err)
+		}
+		return nil
+	case NodeTypeTransform:
+		if _, err := compileTransform(node); err != nil {
+			addIssue(SeverityError, "config", "%v", err)
+		}
+		return nil
+	case NodeTypeHTTP:
+		program, err := compileHTTPNode(node)
+		if err != nil {
+			addIssue(SeverityError, "config", "%v", err)
+			return nil
+		}
+		if _, err := e.resolveSecrets(tenant.IDFrom(ctx), program.secrets); err != nil {
+			addIssue(SeverityError, "config.headers", "%v", err)
+		}
+		return nil
+	case NodeTypeTask:
+		capabilityID = node.Plugin
+		if capabilityID == "" {
+			addIssue(SeverityError, "plugin", "no plugin/capability specified")
+			return nil
+		}
+	default:
+		nodes := DefaultNodeRegistry()
+		if _, ok := nodes.Executor(node.Type); !ok {
+			nodes.SyncCapabilities(e.registry)
+		}
+		nodeDef, ok := nodes.Definition(node.Type)
+		if !ok {
+			addIssue(SeverityError, "type", "unknown node type %s", node.Type)
+			return nil
+		}
+		if nodeDef.Source != sourceCapability {
+			// 代码注册的节点没有能力定义，只能确认执行器存在
+			return nil
+		}
+		capabilityID = string(node.Type)
+	}
+
+	result.CapabilityID = capabilityID
+	if e.registry == nil {
+		addIssue(SeverityError, "plugin", "capability registry not available")
+		return nil
+	}
+	def, providerID, ok := e.registry.Lookup(capabilityID)
+	if !ok {
+		addIssue(SeverityError, "plugin", "capability %s not found", capabilityID)
+		return nil
+	}
+	result.ProviderID = providerID
+
+	if opts.Health != nil {
+		if healthy, detail, known := opts.Health.ProviderHealth(providerID); known && !healthy {
+			addIssue(SeverityError, "plugin", "provider %s is unhealthy: %s", providerID, detail)
+		}
+	}
+
+	config := make(map[string]interface{}, len(node.Config))
+	for key, value := range node.Config {
+		config[key] = value
+	}
+	if node.Type == NodeTypeTask {
+		config = e.mergeGlobalConfig(capabilityID, config)
+	}
+	for name, prop := range def.ConfigSchema.Properties {
+		if _, exists := config[name]; !exists && prop.Default != nil {
+			config[name] = prop.Default
+		}
+	}
+	for _, violation := range capability.ValidateSchema(def.ConfigSchema, withoutEmpty(config)) {
+		addIssue(SeverityError, "config."+violation.Field, "%s", violation.Message)
+	}
+
+	masked := make(map[string]interface{}, len(config))
+	for key, value := range config {
+		if def.ConfigSchema.Properties[key].Secret && value != "" && value != nil {
+			value = maskedValue
+		}
+		masked[key] = value
+	}
+	result.Config = masked
+	return &def
+}
+
+// dryRunCheckInputs 检查节点输入能否由上游输出、执行输入或默认值满足，并检查类型是否匹配
+func (e *WorkflowExecutorImpl) dryRunCheckInputs(workflow *Workflow, node *Node, nodesByID map[string]*Node, defs map[string]*capability.Definition, inputs map[string]interface{}, result *DryRunNode) {
+	declared := make(map[string]InputSchema, len(node.Inputs))
+	for _, input := range node.Inputs {
+		declared[input.Name] = input
+	}
+
+	// 执行器只传入按 node.Inputs 映射的数据，能力要求的必填输入必须在节点上声明
+	if def := defs[node.ID]; def != nil {
+		required := append([]string(nil), def.InputSchema.Required...)
+		sort.Strings(required)
+		for _, name := range required {
+			if _, ok := declared[name]; !ok {
+				result.Issues = append(result.Issues, DryRunIssue{
+					Severity: SeverityError,
+					NodeID:   node.ID,
+					Field:    "inputs." + name,
+					Message:  fmt.Sprintf("capability %s requires input %s but the node does not declare it", def.ID, name),
+				})
+			}
+		}
+	}
+
+	for _, input := range node.Inputs {
+		if _, provided := inputs[input.Name]; provided {
+			continue
+		}
+		if _, isGlobal := workflow.Config.Variables[input.Name]; isGlobal {
+			continue
+		}
+
+		found := false
+		fromStart := false
+		for _, edge := range workflow.Edges {
+			if edge.To != node.ID {
+				continue
+			}
+			upstream := nodesByID[edge.From]
+			if upstream == nil {
+				continue
+			}
+			if upstream.Type == NodeTypeStart {
+				fromStart = true
+				continue
+			}
+			outputType, ok := nodeOutputType(upstream, defs[upstream.ID], input.Name)
+			if !ok {
+				continue
+			}
+			found = true
+			if !schemaTypesCompatible(outputType, input.Type) {
+				result.Issues = append(result.Issues, DryRunIssue{
+					Severity: SeverityError,
+					NodeID:   node.ID,
+					EdgeID:   edge.ID,
+					Field:    "inputs." + input.Name,
+					Message:  fmt.Sprintf("%s outputs %s as %s but %s expects %s", upstream.ID, input.Name, outputType, node.ID, input.Type),
+				})
+			}
+		}
+
+		if found || input.Default != nil || !input.Required {
+			continue
+		}
+		severity, message := SeverityError, fmt.Sprintf("required input %s is not produced by any upstream node", input.Name)
+		if fromStart {
+			// 开始节点透传执行输入，试运行时未提供只能在运行时确认
+			severity, message = SeverityWarning, fmt.Sprintf("required input %s must be supplied in the execution inputs", input.Name)
+		}
+		result.Issues = append(result.Issues, DryRunIssue{
+			Severity: severity,
+			NodeID:   node.ID,
+			Field:    "inputs." + input.Name,
+			Message:  message,
+		})
+	}
+}
+
+// nodeOutputType 返回节点声明的输出类型，类型未知时返回空字符串
+func nodeOutputType(node *Node, def *capability.Definition, name string) (string, bool) {
+	for _, output := range node.Outputs {
+		if output.Name == name {
+			return output.Type, true
+		}
+	}
+	if def != nil {
+		if prop, ok := def.OutputSchema.Properties[name]; ok {
+			return prop.Type, true
+		}
+	}
+	return "", false
+}
+
+// schemaTypesCompatible 判断输出类型能否作为输入类型使用，任一方未声明类型时视为兼容
+func schemaTypesCompatible(output, input string) bool {
+	output, input = strings.ToLower(output), strings.ToLower(input)
+	if output == "" || input == "" || output == input || output == "any" || input == "any" {
+		return true
+	}
+	if (output == "integer" && input == "number") || (output == "number" && input == "integer") {
+		return true
+	}
+	return false
+}
+
+// withoutEmpty 去掉空字符串值，使缺失的必填配置（如未填写的 api_key）被识别为缺失
+func withoutEmpty(values map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		if s, ok := value.(string); ok && s == "" {
+			continue
+		}
+		result[key] = value
+	}
+	return result
+}
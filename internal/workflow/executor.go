@@ -2,12 +2,19 @@ package workflow
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"sync"
 	"time"
 
+	"xiaozhi-server-go/internal/domain/eventbus"
+	"xiaozhi-server-go/internal/domain/tenant"
 	"xiaozhi-server-go/internal/platform/config"
+	"xiaozhi-server-go/internal/platform/drain"
+	"xiaozhi-server-go/internal/platform/requestid"
 	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/plugin/logs"
 )
 
 // WorkflowExecutorImpl 工作流执行器实现
@@ -17,6 +24,9 @@ type WorkflowExecutorImpl struct {
 	dagEngine     DAGEngine
 	dataFlow      DataFlow
 	logger        Logger
+	pluginLogs    *logs.Manager // 插件日志来源，为nil时不采集插件输出
+	scheduler     *Scheduler
+	httpClient    *http.Client // HTTP 节点的客户端，拒绝访问内网地址
 
 	// 运行时状态
 	executions    map[string]*Execution
@@ -27,28 +37,57 @@ type WorkflowExecutorImpl struct {
 
 // NewWorkflowExecutor 创建工作流执行器
 func NewWorkflowExecutor(config *config.Config, registry *capability.Registry, dagEngine DAGEngine, dataFlow DataFlow, logger Logger) WorkflowExecutor {
+	opts := SchedulerOptions{}
+	var allowedHosts []string
+	if config != nil {
+		allowedHosts = config.Workflow.HTTPAllowedHosts
+		opts = SchedulerOptions{
+			MaxWorkers:         config.Workflow.MaxWorkers,
+			DefaultParallelism: config.Workflow.DefaultParallelism,
+			Classes:            config.Workflow.ConcurrencyClasses,
+			CapabilityClasses:  config.Workflow.CapabilityClasses,
+		}
+	}
 	return &WorkflowExecutorImpl{
 		config:        config,
 		registry:      registry,
 		dagEngine:     dagEngine,
 		dataFlow:      dataFlow,
 		logger:        logger,
+		scheduler:     NewScheduler(opts),
+		httpClient:    newHTTPTargetGuard(allowedHosts).client(),
 		executions:    make(map[string]*Execution),
 		cancelFuncs:   make(map[string]context.CancelFunc),
 	}
 }
 
+// SetPluginLogs 设置插件日志来源
+func (e *WorkflowExecutorImpl) SetPluginLogs(manager *logs.Manager) {
+	e.pluginLogs = manager
+}
+
 // Execute 执行工作流
 func (e *WorkflowExecutorImpl) Execute(ctx context.Context, workflow *Workflow, inputs map[string]interface{}) (*Execution, error) {
 	// 验证工作流
 	if err := e.dagEngine.ValidateWorkflow(workflow); err != nil {
 		return nil, fmt.Errorf("workflow validation failed: %w", err)
 	}
+	if err := ValidateNodeExpressions(workflow); err != nil {
+		return nil, fmt.Errorf("workflow validation failed: %w", err)
+	}
+
+	// 排空模式下不再接受新的执行
+	done, err := drain.Default().Begin(drain.KindWorkflows)
+	if err != nil {
+		return nil, err
+	}
 
 	// 创建执行实例
 	execution := &Execution{
 		ID:          e.generateExecutionID(),
 		WorkflowID:  workflow.ID,
+		TenantID:    tenant.IDFrom(ctx),
+		RequestID:   requestid.FromContext(ctx),
 		Status:      ExecutionStatusPending,
 		StartTime:   time.Now(),
 		Context:     make(map[string]interface{}),
@@ -70,18 +109,52 @@ func (e *WorkflowExecutorImpl) Execute(ctx context.Context, workflow *Workflow,
 	e.cancelFuncsMu.Unlock()
 
 	// 启动执行
-	go e.executeWorkflow(execCtx, workflow, execution)
+	go func() {
+		defer done()
+		e.executeWorkflow(execCtx, workflow, execution)
+	}()
 
-	e.logger.Info("Workflow execution started", "execution_id", execution.ID, "workflow_id", workflow.ID)
+	e.logger.Info("Workflow execution started", "execution_id", execution.ID, "workflow_id", workflow.ID, "request_id", execution.RequestID)
 
 	return execution, nil
 }
 
+// Run 同步执行工作流并等待结束，执行记录不登记、不落盘，供对话流水线等每轮都要执行的场景使用
+func (e *WorkflowExecutorImpl) Run(ctx context.Context, workflow *Workflow, inputs map[string]interface{}) (*Execution, error) {
+	if err := e.dagEngine.ValidateWorkflow(workflow); err != nil {
+		return nil, fmt.Errorf("workflow validation failed: %w", err)
+	}
+	if err := ValidateNodeExpressions(workflow); err != nil {
+		return nil, fmt.Errorf("workflow validation failed: %w", err)
+	}
+
+	execution := &Execution{
+		ID:          e.generateExecutionID(),
+		WorkflowID:  workflow.ID,
+		TenantID:    tenant.IDFrom(ctx),
+		RequestID:   requestid.FromContext(ctx),
+		Status:      ExecutionStatusPending,
+		StartTime:   time.Now(),
+		Context:     make(map[string]interface{}),
+		NodeResults: make(map[string]*NodeResult),
+		Inputs:      inputs,
+		Outputs:     make(map[string]interface{}),
+		Logs:        make([]ExecutionLog, 0),
+		inline:      true,
+	}
+	e.executeWorkflow(ctx, workflow, execution)
+
+	if execution.Status != ExecutionStatusCompleted {
+		return execution, fmt.Errorf("workflow execution %s: %s", execution.Status, execution.Error)
+	}
+	return execution, nil
+}
+
 // executeWorkflow 执行工作流的具体逻辑
 func (e *WorkflowExecutorImpl) executeWorkflow(ctx context.Context, workflow *Workflow, execution *Execution) {
 	defer func() {
 		if r := recover(); r != nil {
-			e.logger.Error("Workflow execution panic", "execution_id", execution.ID, "panic", r)
+			e.logger.Error("Workflow execution panic", "execution_id", execution.ID, "panic", r, "request_id", execution.RequestID)
 			e.markExecutionFailed(execution, fmt.Sprintf("Execution panic: %v", r))
 		}
 	}()
@@ -109,6 +182,10 @@ func (e *WorkflowExecutorImpl) executeWorkflow(ctx context.Context, workflow *Wo
 	for {
 		select {
 		case <-timeoutCtx.Done():
+			if execution.Status == ExecutionStatusFailed || execution.Status == ExecutionStatusCancelled {
+				// 已被取消或中止（如审批被拒绝）
+				return
+			}
 			e.markExecutionFailed(execution, "Execution timeout")
 			return
 		default:
@@ -136,52 +213,45 @@ func (e *WorkflowExecutorImpl) executeWorkflow(ctx context.Context, workflow *Wo
 	}
 }
 
-// executeNodes 执行节点
+// executeNodes 并行执行节点，调用能力的节点由调度器限制并发（见 acquireNodeSlot）
 func (e *WorkflowExecutorImpl) executeNodes(ctx context.Context, workflow *Workflow, execution *Execution, nodeIDs []string) {
-	// 限制并行执行数量
-	parallelLimit := workflow.Config.ParallelLimit
-	if parallelLimit <= 0 {
-		parallelLimit = 5 // 默认限制
-	}
-
-	if len(nodeIDs) > parallelLimit {
-		// 分批执行
-		for i := 0; i < len(nodeIDs); i += parallelLimit {
-			end := i + parallelLimit
-			if end > len(nodeIDs) {
-				end = len(nodeIDs)
-			}
-
-			batch := nodeIDs[i:end]
-			e.executeNodesBatch(ctx, workflow, execution, batch)
-		}
-	} else {
-		e.executeNodesBatch(ctx, workflow, execution, nodeIDs)
-	}
-}
-
-// executeNodesBatch 批量执行节点
-func (e *WorkflowExecutorImpl) executeNodesBatch(ctx context.Context, workflow *Workflow, execution *Execution, nodeIDs []string) {
 	var wg sync.WaitGroup
-	semaphore := make(chan struct{}, len(nodeIDs))
-
 	for _, nodeID := range nodeIDs {
 		wg.Add(1)
-		semaphore <- struct{}{} // 获取信号量
-
 		go func(id string) {
-			defer func() {
-				<-semaphore // 释放信号量
-				wg.Done()
-			}()
-
+			defer wg.Done()
 			e.executeSingleNode(ctx, workflow, execution, id)
 		}(nodeID)
 	}
-
 	wg.Wait()
 }
 
+// acquireNodeSlot 为调用能力的节点申请执行槽，控制节点不占用槽位，返回 nil 的 release
+func (e *WorkflowExecutorImpl) acquireNodeSlot(ctx context.Context, workflow *Workflow, execution *Execution, node *Node) (release func(), err error) {
+	var capabilityID string
+	switch node.Type {
+	case NodeTypeStart, NodeTypeEnd, NodeTypeCondition, NodeTypeParallel, NodeTypeMerge, NodeTypeApproval, NodeTypeTransform:
+		return nil, nil
+	case NodeTypeTask:
+		capabilityID = node.Plugin
+	default:
+		capabilityID = string(node.Type)
+	}
+
+	var capabilityType string
+	if e.registry != nil {
+		if def, _, ok := e.registry.Lookup(capabilityID); ok {
+			capabilityType = string(def.Type)
+		}
+	}
+	return e.scheduler.Acquire(ctx, execution.ID, workflow.Config.ParallelLimit, e.scheduler.ClassFor(capabilityID, capabilityType))
+}
+
+// SchedulerStats 返回节点调度器状态
+func (e *WorkflowExecutorImpl) SchedulerStats() SchedulerStats {
+	return e.scheduler.Stats()
+}
+
 // executeSingleNode 执行单个节点
 func (e *WorkflowExecutorImpl) executeSingleNode(ctx context.Context, workflow *Workflow, execution *Execution, nodeID string) {
 	// 获取节点定义
@@ -209,7 +279,20 @@ func (e *WorkflowExecutorImpl) executeSingleNode(ctx context.Context, workflow *
 		Outputs:   make(map[string]interface{}),
 	}
 
+	e.executionMu.Lock()
 	execution.NodeResults[nodeID] = result
+	e.executionMu.Unlock()
+
+	queueStart := time.Now()
+	release, err := e.acquireNodeSlot(ctx, workflow, execution, node)
+	if err != nil {
+		e.markNodeFailed(execution, nodeID, fmt.Sprintf("Waiting for execution slot: %v", err))
+		return
+	}
+	if release != nil {
+		defer release()
+		result.Timing = &NodeTiming{Queue: time.Since(queueStart)}
+	}
 
 	// 根据节点类型执行
 	switch node.Type {
@@ -225,11 +308,70 @@ func (e *WorkflowExecutorImpl) executeSingleNode(ctx context.Context, workflow *
 		e.executeParallelNode(ctx, workflow, execution, node, result)
 	case NodeTypeMerge:
 		e.executeMergeNode(ctx, workflow, execution, node, result)
+	case NodeTypeApproval:
+		e.executeApprovalNode(ctx, workflow, execution, node, result)
+	case NodeTypeTransform:
+		e.executeTransformNode(ctx, workflow, execution, node, result)
+	case NodeTypeHTTP:
+		e.executeHTTPNode(ctx, workflow, execution, node, result)
 	default:
-		e.markNodeFailed(execution, nodeID, fmt.Sprintf("Unknown node type: %s", node.Type))
+		e.executeCustomNode(ctx, workflow, execution, node, result)
 	}
 }
 
+// executeCustomNode 执行插件注册的自定义节点
+func (e *WorkflowExecutorImpl) executeCustomNode(ctx context.Context, workflow *Workflow, execution *Execution, node *Node, result *NodeResult) {
+	nodes := DefaultNodeRegistry()
+	executor, ok := nodes.Executor(node.Type)
+	if !ok {
+		// 插件可能在启动后才注册，按需从能力注册表同步一次
+		nodes.SyncCapabilities(e.registry)
+		executor, ok = nodes.Executor(node.Type)
+	}
+	if !ok {
+		e.markNodeFailed(execution, node.ID, fmt.Sprintf("Unknown node type: %s", node.Type))
+		return
+	}
+
+	if result.Timing == nil {
+		result.Timing = &NodeTiming{}
+	}
+	phaseStart := time.Now()
+	inputs, err := e.dataFlow.GetNodeInputs(execution, node, workflow)
+	result.Timing.Inputs = time.Since(phaseStart)
+	if err != nil {
+		e.markNodeFailed(execution, node.ID, fmt.Sprintf("Failed to get inputs: %v", err))
+		return
+	}
+	result.Inputs = inputs
+
+	traces := capability.NewTraces()
+	execCtx := capability.WithTraces(capability.WithPriority(ctx, capability.PriorityBatch), traces)
+	phaseStart = time.Now()
+	finishCapture := e.capturePluginLogs(string(node.Type), result)
+	outputs, err := e.executeWithRetry(ctx, execution, node, result, workflow.Config.MaxRetries, func() (map[string]interface{}, error) {
+		return executor.Execute(execCtx, node, inputs)
+	})
+	finishCapture()
+	result.Traces = traces.List()
+	result.Timing.Execution = time.Since(phaseStart)
+	if err != nil {
+		e.markNodeFailed(execution, node.ID, fmt.Sprintf("Node execution failed: %v", err))
+		return
+	}
+	result.Outputs = outputs
+
+	phaseStart = time.Now()
+	err = e.validateNodeOutputs(node, result.Outputs)
+	result.Timing.Validation = time.Since(phaseStart)
+	if err != nil {
+		e.markNodeFailed(execution, node.ID, fmt.Sprintf("Output validation failed: %v", err))
+		return
+	}
+
+	e.markNodeCompleted(execution, result)
+}
+
 // executeStartNode 执行开始节点
 func (e *WorkflowExecutorImpl) executeStartNode(ctx context.Context, workflow *Workflow, execution *Execution, node *Node, result *NodeResult) {
 	// 开始节点通常只是传递输入数据
@@ -273,8 +415,14 @@ func (e *WorkflowExecutorImpl) executeEndNode(ctx context.Context, workflow *Wor
 
 // executeTaskNode 执行任务节点
 func (e *WorkflowExecutorImpl) executeTaskNode(ctx context.Context, workflow *Workflow, execution *Execution, node *Node, result *NodeResult) {
+	if result.Timing == nil {
+		result.Timing = &NodeTiming{}
+	}
+
 	// 获取节点输入数据
+	phaseStart := time.Now()
 	inputs, err := e.dataFlow.GetNodeInputs(execution, node, workflow)
+	result.Timing.Inputs = time.Since(phaseStart)
 	if err != nil {
 		e.markNodeFailed(execution, node.ID, fmt.Sprintf("Failed to get inputs: %w", err))
 		return
@@ -306,7 +454,27 @@ func (e *WorkflowExecutorImpl) executeTaskNode(ctx context.Context, workflow *Wo
 	// 合并全局配置
 	config = e.mergeGlobalConfig(capabilityID, config)
 
-	pluginOutputs, err := executor.Execute(ctx, config, inputs)
+	// 配置 response_format 的 LLM 节点要求输出 JSON，校验失败时交给 LLM 修复
+	format, maxRepairs, config, err := structuredOutput(config)
+	if err != nil {
+		e.markNodeFailed(execution, node.ID, fmt.Sprintf("Invalid structured output config: %v", err))
+		return
+	}
+
+	// 工作流节点按批处理优先级排队，让位于设备交互；每次调用的追踪信息（含重试）失败时也保留在节点结果中
+	traces := capability.NewTraces()
+	execCtx := capability.WithTraces(capability.WithPriority(ctx, capability.PriorityBatch), traces)
+	phaseStart = time.Now()
+	finishCapture := e.capturePluginLogs(capabilityID, result)
+	pluginOutputs, err := e.executeWithRetry(ctx, execution, node, result, workflow.Config.MaxRetries, func() (map[string]interface{}, error) {
+		if format != nil {
+			return runStructured(execCtx, executor, config, inputs, format, maxRepairs)
+		}
+		return executor.Execute(execCtx, config, inputs)
+	})
+	finishCapture()
+	result.Traces = traces.List()
+	result.Timing.Execution = time.Since(phaseStart)
 	if err != nil {
 		e.markNodeFailed(execution, node.ID, fmt.Sprintf("Plugin execution failed: %w", err))
 		return
@@ -316,7 +484,10 @@ func (e *WorkflowExecutorImpl) executeTaskNode(ctx context.Context, workflow *Wo
 	result.Outputs = pluginOutputs
 
 	// 验证输出Schema
-	if err := e.validateNodeOutputs(node, result.Outputs); err != nil {
+	phaseStart = time.Now()
+	err = e.validateNodeOutputs(node, result.Outputs)
+	result.Timing.Validation = time.Since(phaseStart)
+	if err != nil {
 		e.markNodeFailed(execution, node.ID, fmt.Sprintf("Output validation failed: %w", err))
 		return
 	}
@@ -324,6 +495,41 @@ func (e *WorkflowExecutorImpl) executeTaskNode(ctx context.Context, workflow *Wo
 	e.markNodeCompleted(execution, result)
 }
 
+// executeWithRetry 按工作流配置的重试次数执行能力调用
+// 日配额超限不重试，交由上层切换提供者；并发超限按建议时间等待后重试
+func (e *WorkflowExecutorImpl) executeWithRetry(ctx context.Context, execution *Execution, node *Node, result *NodeResult, maxRetries int, call func() (map[string]interface{}, error)) (map[string]interface{}, error) {
+	for attempt := 0; ; attempt++ {
+		result.Attempts = attempt + 1
+		outputs, err := call()
+		if err == nil || attempt >= maxRetries {
+			return outputs, err
+		}
+
+		wait := time.Duration(500<<attempt) * time.Millisecond
+		if wait > 10*time.Second {
+			wait = 10 * time.Second
+		}
+		var quotaErr *capability.QuotaExceededError
+		if errors.As(err, &quotaErr) {
+			if !quotaErr.Retryable() {
+				return nil, err
+			}
+			wait = quotaErr.RetryAfter
+		}
+
+		e.addLog(execution, "warn", node.ID, fmt.Sprintf("Plugin execution failed (attempt %d/%d), retrying in %s: %v", attempt+1, maxRetries+1, wait, err))
+		waitStart := time.Now()
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		if result.Timing != nil {
+			result.Timing.RetryWait += time.Since(waitStart)
+		}
+	}
+}
+
 // executeConditionNode 执行条件节点
 func (e *WorkflowExecutorImpl) executeConditionNode(ctx context.Context, workflow *Workflow, execution *Execution, node *Node, result *NodeResult) {
 	// 获取条件输入
@@ -335,18 +541,38 @@ func (e *WorkflowExecutorImpl) executeConditionNode(ctx context.Context, workflo
 
 	result.Inputs = inputs
 
+	// 在家条件，与 condition 输入同时存在时两者都成立才为真
+	presence, err := parsePresenceCondition(node)
+	if err != nil {
+		e.markNodeFailed(execution, node.ID, err.Error())
+		return
+	}
+
 	// 简单的条件判断逻辑
 	condition, ok := inputs["condition"].(string)
-	if !ok {
+	if !ok && presence == nil {
 		e.markNodeFailed(execution, node.ID, "Condition not found or invalid")
 		return
 	}
 
 	// 评估条件
-	result.Outputs = map[string]interface{}{
-		"condition": condition,
-		"result":    e.evaluateCondition(condition, inputs),
+	outputs := map[string]interface{}{}
+	matched := true
+	if ok {
+		outputs["condition"] = condition
+		matched = e.evaluateCondition(condition, inputs)
+	}
+	if presence != nil {
+		atHome, err := presence.evaluate(ctx)
+		if err != nil {
+			e.markNodeFailed(execution, node.ID, fmt.Sprintf("Presence condition failed: %v", err))
+			return
+		}
+		outputs["presence"] = atHome
+		matched = matched && atHome
 	}
+	outputs["result"] = matched
+	result.Outputs = outputs
 
 	e.markNodeCompleted(execution, result)
 }
@@ -389,6 +615,144 @@ func (e *WorkflowExecutorImpl) executeMergeNode(ctx context.Context, workflow *W
 	e.markNodeCompleted(execution, result)
 }
 
+// executeApprovalNode 执行审批节点：创建审批任务并暂停，批准后继续，拒绝或超时后中止执行
+// 节点配置：title、description、timeout（时长字符串或秒数，默认不超时）、on_timeout（fail/approve/reject）
+func (e *WorkflowExecutorImpl) executeApprovalNode(ctx context.Context, workflow *Workflow, execution *Execution, node *Node, result *NodeResult) {
+	inputs, err := e.dataFlow.GetNodeInputs(execution, node, workflow)
+	if err != nil {
+		e.markNodeFailed(execution, node.ID, fmt.Sprintf("Failed to get inputs: %v", err))
+		return
+	}
+	result.Inputs = inputs
+
+	timeout, err := approvalTimeout(node.Config["timeout"])
+	if err != nil {
+		e.markNodeFailed(execution, node.ID, err.Error())
+		return
+	}
+	onTimeout, _ := node.Config["on_timeout"].(string)
+	switch onTimeout {
+	case "", ApprovalTimeoutFail, ApprovalTimeoutApprove, ApprovalTimeoutReject:
+	default:
+		e.markNodeFailed(execution, node.ID, fmt.Sprintf("Invalid on_timeout: %s", onTimeout))
+		return
+	}
+	title, _ := node.Config["title"].(string)
+	if title == "" {
+		title = node.Name
+	}
+	description, _ := node.Config["description"].(string)
+
+	approvals := DefaultApprovalManager()
+	task := approvals.Create(ApprovalTask{
+		ExecutionID: execution.ID,
+		WorkflowID:  workflow.ID,
+		NodeID:      node.ID,
+		Title:       title,
+		Description: description,
+		Data:        inputs,
+		OnTimeout:   onTimeout,
+	}, timeout)
+
+	e.setExecutionStatus(execution, ExecutionStatusRunning, ExecutionStatusPaused)
+	e.addLog(execution, "info", node.ID, fmt.Sprintf("Waiting for approval %s", task.ID))
+	decided, err := approvals.Wait(ctx, task.ID)
+	if approvals.PendingFor(execution.ID) == 0 {
+		e.setExecutionStatus(execution, ExecutionStatusPaused, ExecutionStatusRunning)
+	}
+	if err != nil {
+		e.markNodeFailed(execution, node.ID, fmt.Sprintf("Approval failed: %v", err))
+		return
+	}
+
+	record := map[string]interface{}{
+		"approval_id": decided.ID,
+		"approved":    decided.Status == ApprovalApproved,
+		"status":      decided.Status,
+		"approver":    decided.Approver,
+		"comment":     decided.Comment,
+		"decided_at":  decided.DecidedAt,
+	}
+	result.Outputs = record
+
+	// 审批记录同时写入执行上下文，便于按执行查询全部审批人
+	e.executionMu.Lock()
+	approvalsByNode, _ := execution.Context["approvals"].(map[string]interface{})
+	if approvalsByNode == nil {
+		approvalsByNode = make(map[string]interface{})
+		execution.Context["approvals"] = approvalsByNode
+	}
+	approvalsByNode[node.ID] = record
+	e.executionMu.Unlock()
+
+	switch decided.Status {
+	case ApprovalApproved:
+		e.addLog(execution, "info", node.ID, fmt.Sprintf("Approved by %s", decided.Approver))
+		e.markNodeCompleted(execution, result)
+	case ApprovalCancelled:
+		e.markNodeFailed(execution, node.ID, "Approval cancelled: "+decided.Comment)
+	default:
+		msg := fmt.Sprintf("Approval %s", decided.Status)
+		if decided.Status == ApprovalRejected {
+			msg = fmt.Sprintf("Approval rejected by %s", decided.Approver)
+		}
+		e.markNodeFailed(execution, node.ID, msg)
+		e.abortExecution(execution, msg)
+	}
+}
+
+// approvalTimeout 解析审批超时配置，支持时长字符串（如 "30m"）和秒数
+func approvalTimeout(value interface{}) (time.Duration, error) {
+	return configDuration(value, "approval timeout")
+}
+
+// configDuration 解析节点配置中的时长，字符串按 time.ParseDuration 解析，数字按秒计，未配置时返回 0
+func configDuration(value interface{}, what string) (time.Duration, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case string:
+		if v == "" {
+			return 0, nil
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s %q: %v", what, v, err)
+		}
+		return d, nil
+	case float64:
+		return time.Duration(v * float64(time.Second)), nil
+	case int:
+		return time.Duration(v) * time.Second, nil
+	case int64:
+		return time.Duration(v) * time.Second, nil
+	default:
+		return 0, fmt.Errorf("invalid %s type %T", what, value)
+	}
+}
+
+// setExecutionStatus 执行状态为 from 时切换为 to
+func (e *WorkflowExecutorImpl) setExecutionStatus(execution *Execution, from, to ExecutionStatus) {
+	e.executionMu.Lock()
+	defer e.executionMu.Unlock()
+	if execution.Status == from {
+		execution.Status = to
+	}
+}
+
+// abortExecution 中止执行：标记失败并取消仍在运行的节点
+func (e *WorkflowExecutorImpl) abortExecution(execution *Execution, reason string) {
+	e.markExecutionFailed(execution, reason)
+
+	e.cancelFuncsMu.Lock()
+	cancel, exists := e.cancelFuncs[execution.ID]
+	delete(e.cancelFuncs, execution.ID)
+	e.cancelFuncsMu.Unlock()
+	if exists {
+		cancel()
+	}
+}
+
 // evaluateCondition 评估条件
 func (e *WorkflowExecutorImpl) evaluateCondition(condition string, inputs map[string]interface{}) bool {
 	// 简单的条件评估实现
@@ -454,7 +818,8 @@ func (e *WorkflowExecutorImpl) markExecutionCompleted(execution *Execution) {
 	execution.EndTime = &endTime
 
 	e.addLog(execution, "info", "", "Workflow execution completed")
-	e.logger.Info("Workflow execution completed", "execution_id", execution.ID, "duration", endTime.Sub(execution.StartTime))
+	e.logger.Info("Workflow execution completed", "execution_id", execution.ID, "duration", endTime.Sub(execution.StartTime), "request_id", execution.RequestID)
+	e.persistExecution(execution)
 }
 
 // markExecutionFailed 标记执行失败
@@ -465,7 +830,32 @@ func (e *WorkflowExecutorImpl) markExecutionFailed(execution *Execution, errorMs
 	execution.EndTime = &endTime
 
 	e.addLog(execution, "error", "", errorMsg)
-	e.logger.Error("Workflow execution failed", "execution_id", execution.ID, "error", errorMsg)
+	e.logger.Error("Workflow execution failed", "execution_id", execution.ID, "error", errorMsg, "request_id", execution.RequestID)
+	e.persistExecution(execution)
+
+	if !execution.inline {
+		eventbus.Publish(eventbus.EventWorkflowFailed, eventbus.WorkflowEventData{
+			ExecutionID: execution.ID,
+			WorkflowID:  execution.WorkflowID,
+			TenantID:    execution.TenantID,
+			RequestID:   execution.RequestID,
+			Error:       errorMsg,
+			Timestamp:   endTime,
+		})
+	}
+}
+
+// persistExecution 保存结束的执行记录，供进程重启后查询
+func (e *WorkflowExecutorImpl) persistExecution(execution *Execution) {
+	if execution.inline {
+		return
+	}
+	e.executionMu.RLock()
+	err := SaveExecution(execution)
+	e.executionMu.RUnlock()
+	if err != nil {
+		e.logger.Warn("Failed to persist workflow execution", "execution_id", execution.ID, "error", err)
+	}
 }
 
 // isExecutionCompleted 检查执行是否完成
@@ -494,6 +884,7 @@ func (e *WorkflowExecutorImpl) isExecutionCompleted(workflow *Workflow, executio
 }
 
 // addLog 添加执行日志
+// 节点相关的日志同时记入节点结果
 func (e *WorkflowExecutorImpl) addLog(execution *Execution, level, nodeID, message string) {
 	log := ExecutionLog{
 		Timestamp: time.Now(),
@@ -502,7 +893,50 @@ func (e *WorkflowExecutorImpl) addLog(execution *Execution, level, nodeID, messa
 		Message:   message,
 	}
 
+	e.executionMu.Lock()
+	defer e.executionMu.Unlock()
 	execution.Logs = append(execution.Logs, log)
+	if result, ok := execution.NodeResults[nodeID]; ok && nodeID != "" {
+		result.Logs = append(result.Logs, NodeLog{
+			Timestamp: log.Timestamp,
+			Level:     level,
+			Source:    NodeLogSourceEngine,
+			Attempt:   result.Attempts,
+			Message:   message,
+		})
+	}
+}
+
+// capturePluginLogs 记录能力所属插件日志的当前位置，返回的函数将此后的插件输出追加到节点日志；
+// 同一插件被并行节点同时调用时，各节点都会记录这段时间内该插件的全部输出
+func (e *WorkflowExecutorImpl) capturePluginLogs(capabilityID string, result *NodeResult) func() {
+	if e.pluginLogs == nil || e.registry == nil {
+		return func() {}
+	}
+	_, providerID, ok := e.registry.Lookup(capabilityID)
+	if !ok {
+		return func() {}
+	}
+	buffer := e.pluginLogs.Buffer(providerID)
+	startSeq := buffer.LastSeq()
+
+	return func() {
+		entries := buffer.Since(startSeq)
+		if len(entries) == 0 {
+			return
+		}
+		e.executionMu.Lock()
+		defer e.executionMu.Unlock()
+		for _, entry := range entries {
+			result.Logs = append(result.Logs, NodeLog{
+				Timestamp: entry.Time,
+				Level:     entry.Level,
+				Source:    NodeLogSourcePlugin,
+				Stream:    entry.Stream,
+				Message:   entry.Message,
+			})
+		}
+	}
 }
 
 // generateExecutionID 生成执行ID
@@ -531,7 +965,11 @@ func (e *WorkflowExecutorImpl) Cancel(executionID string) error {
 		execution.EndTime = &endTime
 		execution.Error = "Execution cancelled by user"
 	}
+	execution := e.executions[executionID]
 	e.executionMu.Unlock()
+	if execution != nil {
+		e.persistExecution(execution)
+	}
 
 	// 清理取消函数
 	e.cancelFuncsMu.Lock()
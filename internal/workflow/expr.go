@@ -0,0 +1,1263 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// 工作流表达式语言，语法取自 CEL 的一个子集：
+//
+//	字面量  1, 2.5, "text", 'text', true, false, null, [1, 2], {"key": value, name: value}
+//	访问    a.b, a["b"], list[0], list[-1]；访问 null 或不存在的字段得到 null
+//	运算    + - * / %, == != < <= > >=, in, && || !, a ?? b, cond ? a : b
+//	函数    len(x)、upper(s)、merge(a, b) 等，见 exprFunctions
+//	宏      list.map(x, expr), list.filter(x, expr), list.exists(x, expr), list.all(x, expr)
+//
+// 表达式没有副作用，不能访问文件、网络或进程；求值受步数、字符串长度、列表长度和 context 截止时间限制
+
+const (
+	maxExprDepth     = 64      // 语法嵌套深度上限，防止递归耗尽栈
+	maxExprSteps     = 100000  // 单次求值的步数上限
+	maxExprStringLen = 1 << 20 // 求值产生的字符串长度上限（字节）
+	maxExprListLen   = 10000   // 求值产生的列表长度上限
+)
+
+// ExpressionError 表达式编译或求值错误，Pos 为出错位置（从 0 开始的字节偏移）
+type ExpressionError struct {
+	Pos     int
+	Message string
+}
+
+func (e *ExpressionError) Error() string {
+	return fmt.Sprintf("expression error at position %d: %s", e.Pos+1, e.Message)
+}
+
+func exprErrorf(pos int, format string, args ...interface{}) error {
+	return &ExpressionError{Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// Expression 编译后的表达式，可并发求值
+type Expression struct {
+	source string
+	root   exprNode
+}
+
+// CompileExpression 解析表达式并检查函数和变量；names 为可引用的变量名，为 nil 时不检查变量
+func CompileExpression(source string, names []string) (*Expression, error) {
+	tokens, err := lexExpression(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{tokens: tokens}
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, exprErrorf(t.pos, "unexpected %q", t.text)
+	}
+
+	var known map[string]bool
+	if names != nil {
+		known = make(map[string]bool, len(names))
+		for _, name := range names {
+			known[name] = true
+		}
+	}
+	if err := checkExprIdents(root, known, nil); err != nil {
+		return nil, err
+	}
+	return &Expression{source: source, root: root}, nil
+}
+
+// String 返回表达式源码
+func (x *Expression) String() string {
+	return x.source
+}
+
+// Evaluate 在给定变量下求值，结果只包含 nil、bool、float64、string、[]interface{} 和 map[string]interface{}
+func (x *Expression) Evaluate(ctx context.Context, vars map[string]interface{}) (interface{}, error) {
+	normalized := make(map[string]interface{}, len(vars))
+	for name, value := range vars {
+		normalized[name] = normalizeExprValue(value)
+	}
+	s := &evalState{ctx: ctx}
+	return s.eval(x.root, &exprEnv{vars: normalized})
+}
+
+// ---- 词法分析 ----
+
+type exprTokenKind int
+
+const (
+	tokEOF exprTokenKind = iota
+	tokLiteral
+	tokIdent
+	tokOp
+)
+
+type exprToken struct {
+	kind  exprTokenKind
+	text  string
+	value interface{}
+	pos   int
+}
+
+var exprTwoCharOps = []string{"==", "!=", "<=", ">=", "&&", "||", "??"}
+
+const exprSingleCharOps = "+-*/%<>!?:.,()[]{}"
+
+func lexExpression(src string) ([]exprToken, error) {
+	var tokens []exprToken
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case isExprDigit(c) || (c == '.' && i+1 < len(src) && isExprDigit(src[i+1])):
+			start := i
+			for i < len(src) && (isExprDigit(src[i]) || src[i] == '.') {
+				i++
+			}
+			if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+				j := i + 1
+				if j < len(src) && (src[j] == '+' || src[j] == '-') {
+					j++
+				}
+				if j < len(src) && isExprDigit(src[j]) {
+					for i = j; i < len(src) && isExprDigit(src[i]); i++ {
+					}
+				}
+			}
+			n, err := strconv.ParseFloat(src[start:i], 64)
+			if err != nil {
+				return nil, exprErrorf(start, "invalid number %q", src[start:i])
+			}
+			tokens = append(tokens, exprToken{kind: tokLiteral, text: src[start:i], value: n, pos: start})
+		case c == '"' || c == '\'':
+			start := i
+			value, end, err := lexExprString(src, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+			tokens = append(tokens, exprToken{kind: tokLiteral, text: src[start:i], value: value, pos: start})
+		case isExprIdentStart(c):
+			start := i
+			for i < len(src) && (isExprIdentStart(src[i]) || isExprDigit(src[i])) {
+				i++
+			}
+			word := src[start:i]
+			switch word {
+			case "true", "false":
+				tokens = append(tokens, exprToken{kind: tokLiteral, text: word, value: word == "true", pos: start})
+			case "null":
+				tokens = append(tokens, exprToken{kind: tokLiteral, text: word, value: nil, pos: start})
+			case "in":
+				tokens = append(tokens, exprToken{kind: tokOp, text: word, pos: start})
+			default:
+				tokens = append(tokens, exprToken{kind: tokIdent, text: word, pos: start})
+			}
+		default:
+			op := ""
+			for _, candidate := range exprTwoCharOps {
+				if strings.HasPrefix(src[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" && strings.IndexByte(exprSingleCharOps, c) >= 0 {
+				op = string(c)
+			}
+			if op == "" {
+				r, _ := utf8.DecodeRuneInString(src[i:])
+				return nil, exprErrorf(i, "unexpected character %q", r)
+			}
+			tokens = append(tokens, exprToken{kind: tokOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, exprToken{kind: tokEOF, text: "end of expression", pos: len(src)}), nil
+}
+
+// lexExprString 解析从 start 开始的引号字符串，返回值和结束位置
+func lexExprString(src string, start int) (string, int, error) {
+	quote := src[start]
+	var b strings.Builder
+	for i := start + 1; i < len(src); {
+		c := src[i]
+		switch {
+		case c == quote:
+			return b.String(), i + 1, nil
+		case c == '\\':
+			if i+1 >= len(src) {
+				return "", 0, exprErrorf(i, "unterminated string")
+			}
+			switch esc := src[i+1]; esc {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case '\\', '"', '\'':
+				b.WriteByte(esc)
+			case 'u':
+				if i+6 > len(src) {
+					return "", 0, exprErrorf(i, "invalid unicode escape")
+				}
+				code, err := strconv.ParseUint(src[i+2:i+6], 16, 32)
+				if err != nil {
+					return "", 0, exprErrorf(i, "invalid unicode escape")
+				}
+				b.WriteRune(rune(code))
+				i += 4
+			default:
+				return "", 0, exprErrorf(i, "invalid escape \\%c", esc)
+			}
+			i += 2
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return "", 0, exprErrorf(start, "unterminated string")
+}
+
+func isExprDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isExprIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// ---- 语法树 ----
+
+type exprNode interface {
+	position() int
+}
+
+type literalNode struct {
+	pos   int
+	value interface{}
+}
+
+type identNode struct {
+	pos  int
+	name string
+}
+
+type memberNode struct {
+	pos    int
+	target exprNode
+	name   string
+}
+
+type indexNode struct {
+	pos    int
+	target exprNode
+	index  exprNode
+}
+
+type unaryNode struct {
+	pos     int
+	op      string
+	operand exprNode
+}
+
+type binaryNode struct {
+	pos         int
+	op          string
+	left, right exprNode
+}
+
+type ternaryNode struct {
+	pos                   int
+	cond, then, otherwise exprNode
+}
+
+type listNode struct {
+	pos   int
+	items []exprNode
+}
+
+type mapNode struct {
+	pos    int
+	keys   []string
+	values []exprNode
+}
+
+type callNode struct {
+	pos  int
+	name string
+	args []exprNode
+}
+
+type macroNode struct {
+	pos      int
+	name     string
+	target   exprNode
+	variable string
+	body     exprNode
+}
+
+func (n *literalNode) position() int { return n.pos }
+func (n *identNode) position() int   { return n.pos }
+func (n *memberNode) position() int  { return n.pos }
+func (n *indexNode) position() int   { return n.pos }
+func (n *unaryNode) position() int   { return n.pos }
+func (n *binaryNode) position() int  { return n.pos }
+func (n *ternaryNode) position() int { return n.pos }
+func (n *listNode) position() int    { return n.pos }
+func (n *mapNode) position() int     { return n.pos }
+func (n *callNode) position() int    { return n.pos }
+func (n *macroNode) position() int   { return n.pos }
+
+// exprMacros 支持的宏，第一个参数为迭代变量
+var exprMacros = map[string]bool{"map": true, "filter": true, "exists": true, "all": true}
+
+// ---- 语法分析 ----
+
+var exprBinaryPrecedence = map[string]int{
+	"??": 1,
+	"||": 2,
+	"&&": 3,
+	"==": 4, "!=": 4, "<": 4, "<=": 4, ">": 4, ">=": 4, "in": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6, "%": 6,
+}
+
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+	depth  int
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() exprToken {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *exprParser) isOp(op string) bool {
+	t := p.peek()
+	return t.kind == tokOp && t.text == op
+}
+
+func (p *exprParser) expect(op string) error {
+	if !p.isOp(op) {
+		t := p.peek()
+		return exprErrorf(t.pos, "expected %q but found %q", op, t.text)
+	}
+	p.next()
+	return nil
+}
+
+func (p *exprParser) enter() error {
+	p.depth++
+	if p.depth > maxExprDepth {
+		return exprErrorf(p.peek().pos, "expression is nested too deeply")
+	}
+	return nil
+}
+
+func (p *exprParser) parseExpr() (exprNode, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer func() { p.depth-- }()
+
+	cond, err := p.parseBinary(1)
+	if err != nil {
+		return nil, err
+	}
+	if !p.isOp("?") {
+		return cond, nil
+	}
+	t := p.next()
+	then, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	other, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	return &ternaryNode{pos: t.pos, cond: cond, then: then, otherwise: other}, nil
+}
+
+func (p *exprParser) parseBinary(minPrec int) (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		prec, ok := exprBinaryPrecedence[t.text]
+		if t.kind != tokOp || !ok || prec < minPrec {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseBinary(prec + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{pos: t.pos, op: t.text, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if p.isOp("!") || p.isOp("-") {
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer func() { p.depth-- }()
+		t := p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{pos: t.pos, op: t.text, operand: operand}, nil
+	}
+	primary, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	return p.parsePostfix(primary)
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	t := p.next()
+	switch t.kind {
+	case tokLiteral:
+		return &literalNode{pos: t.pos, value: t.value}, nil
+	case tokIdent:
+		if !p.isOp("(") {
+			return &identNode{pos: t.pos, name: t.text}, nil
+		}
+		p.next()
+		args, err := p.parseList(")")
+		if err != nil {
+			return nil, err
+		}
+		fn, ok := exprFunctions[t.text]
+		if !ok {
+			return nil, exprErrorf(t.pos, "unknown function %s", t.text)
+		}
+		if len(args) < fn.minArgs || (fn.maxArgs >= 0 && len(args) > fn.maxArgs) {
+			return nil, exprErrorf(t.pos, "%s() takes %s, got %d", t.text, fn.arity(), len(args))
+		}
+		return &callNode{pos: t.pos, name: t.text, args: args}, nil
+	case tokOp:
+		switch t.text {
+		case "(":
+			inner, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return inner, nil
+		case "[":
+			items, err := p.parseList("]")
+			if err != nil {
+				return nil, err
+			}
+			return &listNode{pos: t.pos, items: items}, nil
+		case "{":
+			return p.parseMap(t.pos)
+		}
+	}
+	return nil, exprErrorf(t.pos, "unexpected %q", t.text)
+}
+
+// parseList 解析逗号分隔的表达式直到 closing，允许末尾逗号
+func (p *exprParser) parseList(closing string) ([]exprNode, error) {
+	items := []exprNode{}
+	for !p.isOp(closing) {
+		item, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if !p.isOp(",") {
+			break
+		}
+		p.next()
+	}
+	if err := p.expect(closing); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (p *exprParser) parseMap(pos int) (exprNode, error) {
+	node := &mapNode{pos: pos}
+	for !p.isOp("}") {
+		t := p.next()
+		var key string
+		switch {
+		case t.kind == tokIdent:
+			key = t.text
+		case t.kind == tokLiteral:
+			s, ok := t.value.(string)
+			if !ok {
+				return nil, exprErrorf(t.pos, "map key must be a string or identifier")
+			}
+			key = s
+		default:
+			return nil, exprErrorf(t.pos, "expected map key but found %q", t.text)
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		node.keys = append(node.keys, key)
+		node.values = append(node.values, value)
+		if !p.isOp(",") {
+			break
+		}
+		p.next()
+	}
+	if err := p.expect("}"); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func (p *exprParser) parsePostfix(target exprNode) (exprNode, error) {
+	for {
+		switch {
+		case p.isOp("."):
+			p.next()
+			t := p.next()
+			if t.kind != tokIdent {
+				return nil, exprErrorf(t.pos, "expected field name but found %q", t.text)
+			}
+			if !p.isOp("(") {
+				target = &memberNode{pos: t.pos, target: target, name: t.text}
+				continue
+			}
+			macro, err := p.parseMacro(t, target)
+			if err != nil {
+				return nil, err
+			}
+			target = macro
+		case p.isOp("["):
+			t := p.next()
+			index, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			target = &indexNode{pos: t.pos, target: target, index: index}
+		default:
+			return target, nil
+		}
+	}
+}
+
+func (p *exprParser) parseMacro(name exprToken, target exprNode) (exprNode, error) {
+	if !exprMacros[name.text] {
+		return nil, exprErrorf(name.pos, "unknown method %s, supported: map, filter, exists, all", name.text)
+	}
+	p.next() // (
+	variable := p.next()
+	if variable.kind != tokIdent {
+		return nil, exprErrorf(variable.pos, "%s() expects an iteration variable name first", name.text)
+	}
+	if err := p.expect(","); err != nil {
+		return nil, err
+	}
+	body, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return &macroNode{pos: name.pos, name: name.text, target: target, variable: variable.text, body: body}, nil
+}
+
+// checkExprIdents 检查变量引用，known 为 nil 时不检查；locals 为宏引入的迭代变量
+func checkExprIdents(n exprNode, known map[string]bool, locals []string) error {
+	switch n := n.(type) {
+	case *identNode:
+		if known == nil || known[n.name] {
+			return nil
+		}
+		for _, local := range locals {
+			if local == n.name {
+				return nil
+			}
+		}
+		return exprErrorf(n.pos, "unknown variable %s", n.name)
+	case *memberNode:
+		return checkExprIdents(n.target, known, locals)
+	case *indexNode:
+		if err := checkExprIdents(n.target, known, locals); err != nil {
+			return err
+		}
+		return checkExprIdents(n.index, known, locals)
+	case *unaryNode:
+		return checkExprIdents(n.operand, known, locals)
+	case *binaryNode:
+		if err := checkExprIdents(n.left, known, locals); err != nil {
+			return err
+		}
+		return checkExprIdents(n.right, known, locals)
+	case *ternaryNode:
+		for _, child := range []exprNode{n.cond, n.then, n.otherwise} {
+			if err := checkExprIdents(child, known, locals); err != nil {
+				return err
+			}
+		}
+	case *listNode:
+		for _, item := range n.items {
+			if err := checkExprIdents(item, known, locals); err != nil {
+				return err
+			}
+		}
+	case *mapNode:
+		for _, value := range n.values {
+			if err := checkExprIdents(value, known, locals); err != nil {
+				return err
+			}
+		}
+	case *callNode:
+		for _, arg := range n.args {
+			if err := checkExprIdents(arg, known, locals); err != nil {
+				return err
+			}
+		}
+	case *macroNode:
+		if err := checkExprIdents(n.target, known, locals); err != nil {
+			return err
+		}
+		return checkExprIdents(n.body, known, append(append([]string(nil), locals...), n.variable))
+	}
+	return nil
+}
+
+// FieldRefs 返回表达式中以 root.NAME 形式引用的字段名；root 以其他形式出现（如 root["x"] 或单独使用）时 ok 为 false
+func (x *Expression) FieldRefs(root string) (fields []string, ok bool) {
+	seen := make(map[string]bool)
+	ok = true
+	var walk func(n exprNode)
+	walk = func(n exprNode) {
+		if member, isMember := n.(*memberNode); isMember {
+			if ident, isIdent := member.target.(*identNode); isIdent && ident.name == root {
+				if !seen[member.name] {
+					seen[member.name] = true
+					fields = append(fields, member.name)
+				}
+				return
+			}
+		}
+		if ident, isIdent := n.(*identNode); isIdent && ident.name == root {
+			ok = false
+			return
+		}
+		for _, child := range exprChildren(n) {
+			walk(child)
+		}
+	}
+	walk(x.root)
+	return fields, ok
+}
+
+// exprChildren 返回语法树节点的直接子节点
+func exprChildren(n exprNode) []exprNode {
+	switch n := n.(type) {
+	case *memberNode:
+		return []exprNode{n.target}
+	case *indexNode:
+		return []exprNode{n.target, n.index}
+	case *unaryNode:
+		return []exprNode{n.operand}
+	case *binaryNode:
+		return []exprNode{n.left, n.right}
+	case *ternaryNode:
+		return []exprNode{n.cond, n.then, n.otherwise}
+	case *listNode:
+		return n.items
+	case *mapNode:
+		return n.values
+	case *callNode:
+		return n.args
+	case *macroNode:
+		return []exprNode{n.target, n.body}
+	}
+	return nil
+}
+
+// ---- 求值 ----
+
+// exprEnv 变量作用域，根作用域持有全部变量，宏的每次迭代增加一层
+type exprEnv struct {
+	vars   map[string]interface{}
+	name   string
+	value  interface{}
+	parent *exprEnv
+}
+
+func (env *exprEnv) lookup(name string) interface{} {
+	for e := env; e != nil; e = e.parent {
+		if e.vars == nil {
+			if e.name == name {
+				return e.value
+			}
+			continue
+		}
+		if value, ok := e.vars[name]; ok {
+			return value
+		}
+	}
+	return nil
+}
+
+type evalState struct {
+	ctx   context.Context
+	steps int
+}
+
+// charge 计入求值步数，超过上限或 context 结束时返回错误
+func (s *evalState) charge(pos, n int) error {
+	before := s.steps
+	s.steps += n
+	if s.steps > maxExprSteps {
+		return exprErrorf(pos, "evaluation exceeded %d steps", maxExprSteps)
+	}
+	if s.ctx != nil && before/256 != s.steps/256 {
+		if err := s.ctx.Err(); err != nil {
+			return exprErrorf(pos, "evaluation aborted: %v", err)
+		}
+	}
+	return nil
+}
+
+func (s *evalState) checkString(pos int, value string) (interface{}, error) {
+	if len(value) > maxExprStringLen {
+		return nil, exprErrorf(pos, "string result exceeds %d bytes", maxExprStringLen)
+	}
+	return value, nil
+}
+
+func (s *evalState) checkList(pos int, value []interface{}) (interface{}, error) {
+	if len(value) > maxExprListLen {
+		return nil, exprErrorf(pos, "list result exceeds %d items", maxExprListLen)
+	}
+	return value, nil
+}
+
+func (s *evalState) eval(n exprNode, env *exprEnv) (interface{}, error) {
+	if err := s.charge(n.position(), 1); err != nil {
+		return nil, err
+	}
+
+	switch n := n.(type) {
+	case *literalNode:
+		return n.value, nil
+	case *identNode:
+		return env.lookup(n.name), nil
+	case *memberNode:
+		target, err := s.eval(n.target, env)
+		if err != nil {
+			return nil, err
+		}
+		switch t := target.(type) {
+		case nil:
+			return nil, nil
+		case map[string]interface{}:
+			return t[n.name], nil
+		default:
+			return nil, exprErrorf(n.pos, "cannot access field %s on %s", n.name, exprTypeName(target))
+		}
+	case *indexNode:
+		target, err := s.eval(n.target, env)
+		if err != nil {
+			return nil, err
+		}
+		index, err := s.eval(n.index, env)
+		if err != nil {
+			return nil, err
+		}
+		return exprIndex(n.pos, target, index)
+	case *unaryNode:
+		operand, err := s.eval(n.operand, env)
+		if err != nil {
+			return nil, err
+		}
+		if n.op == "!" {
+			b, ok := operand.(bool)
+			if !ok {
+				return nil, exprErrorf(n.pos, "! expects bool, got %s", exprTypeName(operand))
+			}
+			return !b, nil
+		}
+		f, ok := operand.(float64)
+		if !ok {
+			return nil, exprErrorf(n.pos, "- expects number, got %s", exprTypeName(operand))
+		}
+		return -f, nil
+	case *binaryNode:
+		return s.evalBinary(n, env)
+	case *ternaryNode:
+		cond, err := s.eval(n.cond, env)
+		if err != nil {
+			return nil, err
+		}
+		b, ok := cond.(bool)
+		if !ok {
+			return nil, exprErrorf(n.pos, "condition must be bool, got %s", exprTypeName(cond))
+		}
+		if b {
+			return s.eval(n.then, env)
+		}
+		return s.eval(n.otherwise, env)
+	case *listNode:
+		items := make([]interface{}, 0, len(n.items))
+		for _, item := range n.items {
+			value, err := s.eval(item, env)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		return items, nil
+	case *mapNode:
+		result := make(map[string]interface{}, len(n.keys))
+		for i, key := range n.keys {
+			value, err := s.eval(n.values[i], env)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = value
+		}
+		return result, nil
+	case *callNode:
+		args := make([]interface{}, 0, len(n.args))
+		cost := 0
+		for _, arg := range n.args {
+			value, err := s.eval(arg, env)
+			if err != nil {
+				return nil, err
+			}
+			cost += exprCost(value)
+			args = append(args, value)
+		}
+		if err := s.charge(n.pos, cost); err != nil {
+			return nil, err
+		}
+		result, err := exprFunctions[n.name].call(args)
+		if err != nil {
+			return nil, exprErrorf(n.pos, "%s(): %v", n.name, err)
+		}
+		switch r := result.(type) {
+		case string:
+			return s.checkString(n.pos, r)
+		case []interface{}:
+			return s.checkList(n.pos, r)
+		}
+		return result, nil
+	case *macroNode:
+		return s.evalMacro(n, env)
+	}
+	return nil, exprErrorf(n.position(), "unsupported expression")
+}
+
+func (s *evalState) evalBinary(n *binaryNode, env *exprEnv) (interface{}, error) {
+	left, err := s.eval(n.left, env)
+	if err != nil {
+		return nil, err
+	}
+
+	// 逻辑运算和 ?? 短路求值
+	switch n.op {
+	case "&&", "||":
+		lb, ok := left.(bool)
+		if !ok {
+			return nil, exprErrorf(n.pos, "%s expects bool, got %s", n.op, exprTypeName(left))
+		}
+		if (n.op == "&&" && !lb) || (n.op == "||" && lb) {
+			return lb, nil
+		}
+		right, err := s.eval(n.right, env)
+		if err != nil {
+			return nil, err
+		}
+		rb, ok := right.(bool)
+		if !ok {
+			return nil, exprErrorf(n.pos, "%s expects bool, got %s", n.op, exprTypeName(right))
+		}
+		return rb, nil
+	case "??":
+		if left != nil {
+			return left, nil
+		}
+		return s.eval(n.right, env)
+	}
+
+	right, err := s.eval(n.right, env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return reflect.DeepEqual(left, right), nil
+	case "!=":
+		return !reflect.DeepEqual(left, right), nil
+	case "in":
+		if err := s.charge(n.pos, exprCost(right)); err != nil {
+			return nil, err
+		}
+		return exprContains(n.pos, right, left)
+	case "<", "<=", ">", ">=":
+		cmp, err := exprCompare(n.pos, left, right)
+		if err != nil {
+			return nil, err
+		}
+		switch n.op {
+		case "<":
+			return cmp < 0, nil
+		case "<=":
+			return cmp <= 0, nil
+		case ">":
+			return cmp > 0, nil
+		default:
+			return cmp >= 0, nil
+		}
+	case "+":
+		ls, lok := left.(string)
+		rs, rok := right.(string)
+		if lok || rok {
+			if !lok {
+				ls = exprToString(left)
+			}
+			if !rok {
+				rs = exprToString(right)
+			}
+			if len(ls)+len(rs) > maxExprStringLen {
+				return nil, exprErrorf(n.pos, "string result exceeds %d bytes", maxExprStringLen)
+			}
+			return ls + rs, nil
+		}
+		ll, lok := left.([]interface{})
+		rl, rok := right.([]interface{})
+		if lok && rok {
+			if err := s.charge(n.pos, len(ll)+len(rl)); err != nil {
+				return nil, err
+			}
+			return s.checkList(n.pos, append(append(make([]interface{}, 0, len(ll)+len(rl)), ll...), rl...))
+		}
+	}
+
+	lf, lok := left.(float64)
+	rf, rok := right.(float64)
+	if !lok || !rok {
+		return nil, exprErrorf(n.pos, "%s is not supported between %s and %s", n.op, exprTypeName(left), exprTypeName(right))
+	}
+	switch n.op {
+	case "+":
+		return lf + rf, nil
+	case "-":
+		return lf - rf, nil
+	case "*":
+		return lf * rf, nil
+	case "/":
+		if rf == 0 {
+			return nil, exprErrorf(n.pos, "division by zero")
+		}
+		return lf / rf, nil
+	case "%":
+		if rf == 0 {
+			return nil, exprErrorf(n.pos, "division by zero")
+		}
+		return math.Mod(lf, rf), nil
+	}
+	return nil, exprErrorf(n.pos, "unsupported operator %s", n.op)
+}
+
+func (s *evalState) evalMacro(n *macroNode, env *exprEnv) (interface{}, error) {
+	target, err := s.eval(n.target, env)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []interface{}
+	switch t := target.(type) {
+	case nil:
+	case []interface{}:
+		items = t
+	case map[string]interface{}:
+		// 与 CEL 一致，对象按键迭代
+		for _, key := range sortedExprKeys(t) {
+			items = append(items, key)
+		}
+	default:
+		return nil, exprErrorf(n.pos, "%s() expects a list or map, got %s", n.name, exprTypeName(target))
+	}
+
+	result := make([]interface{}, 0)
+	for _, item := range items {
+		value, err := s.eval(n.body, &exprEnv{name: n.variable, value: item, parent: env})
+		if err != nil {
+			return nil, err
+		}
+		if n.name == "map" {
+			result = append(result, value)
+			continue
+		}
+		b, ok := value.(bool)
+		if !ok {
+			return nil, exprErrorf(n.pos, "%s() predicate must return bool, got %s", n.name, exprTypeName(value))
+		}
+		switch {
+		case n.name == "filter" && b:
+			result = append(result, item)
+		case n.name == "exists" && b:
+			return true, nil
+		case n.name == "all" && !b:
+			return false, nil
+		}
+	}
+
+	switch n.name {
+	case "exists":
+		return false, nil
+	case "all":
+		return true, nil
+	}
+	return result, nil
+}
+
+func exprIndex(pos int, target, index interface{}) (interface{}, error) {
+	switch t := target.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		key, ok := index.(string)
+		if !ok {
+			return nil, exprErrorf(pos, "map index must be string, got %s", exprTypeName(index))
+		}
+		return t[key], nil
+	case []interface{}:
+		f, ok := index.(float64)
+		if !ok || f != math.Trunc(f) {
+			return nil, exprErrorf(pos, "list index must be an integer, got %s", exprTypeName(index))
+		}
+		i := int(f)
+		if i < 0 {
+			i += len(t)
+		}
+		if i < 0 || i >= len(t) {
+			return nil, nil
+		}
+		return t[i], nil
+	}
+	return nil, exprErrorf(pos, "cannot index %s", exprTypeName(target))
+}
+
+func exprContains(pos int, container, item interface{}) (interface{}, error) {
+	switch c := container.(type) {
+	case nil:
+		return false, nil
+	case []interface{}:
+		for _, v := range c {
+			if reflect.DeepEqual(v, item) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case map[string]interface{}:
+		key, ok := item.(string)
+		if !ok {
+			return false, nil
+		}
+		_, exists := c[key]
+		return exists, nil
+	case string:
+		sub, ok := item.(string)
+		if !ok {
+			return nil, exprErrorf(pos, "in on a string expects a string, got %s", exprTypeName(item))
+		}
+		return strings.Contains(c, sub), nil
+	}
+	return nil, exprErrorf(pos, "in is not supported on %s", exprTypeName(container))
+}
+
+func exprCompare(pos int, left, right interface{}) (int, error) {
+	switch l := left.(type) {
+	case float64:
+		if r, ok := right.(float64); ok {
+			switch {
+			case l < r:
+				return -1, nil
+			case l > r:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	case string:
+		if r, ok := right.(string); ok {
+			return strings.Compare(l, r), nil
+		}
+	}
+	return 0, exprErrorf(pos, "cannot compare %s with %s", exprTypeName(left), exprTypeName(right))
+}
+
+// exprCost 内置函数处理集合和长字符串的求值开销
+func exprCost(value interface{}) int {
+	switch v := value.(type) {
+	case []interface{}:
+		return len(v)
+	case map[string]interface{}:
+		return len(v)
+	case string:
+		return len(v) / 256
+	}
+	return 0
+}
+
+func exprTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "bool"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "map"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func exprToString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func sortedExprKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// normalizeExprValue 将任意 Go 值深拷贝为表达式值类型，数字统一为 float64，
+// 其他类型（结构体、time.Time 等）按 JSON 编码结果转换
+func normalizeExprValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil, bool, float64, string:
+		return v
+	case json.Number:
+		f, _ := v.Float64()
+		return f
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = normalizeExprValue(item)
+		}
+		return items
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[key] = normalizeExprValue(item)
+		}
+		return result
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.String:
+		return rv.String()
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		return normalizeExprValue(rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return []interface{}{}
+		}
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			return string(rv.Bytes())
+		}
+		items := make([]interface{}, rv.Len())
+		for i := range items {
+			items[i] = normalizeExprValue(rv.Index(i).Interface())
+		}
+		return items
+	case reflect.Map:
+		if rv.Type().Key().Kind() == reflect.String {
+			result := make(map[string]interface{}, rv.Len())
+			iter := rv.MapRange()
+			for iter.Next() {
+				result[iter.Key().String()] = normalizeExprValue(iter.Value().Interface())
+			}
+			return result
+		}
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	var result interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return string(data)
+	}
+	return result
+}
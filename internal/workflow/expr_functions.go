@@ -0,0 +1,533 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// exprFunction 表达式内置函数，maxArgs 为 -1 表示不限参数个数
+type exprFunction struct {
+	minArgs int
+	maxArgs int
+	call    func(args []interface{}) (interface{}, error)
+}
+
+func (f exprFunction) arity() string {
+	switch {
+	case f.maxArgs < 0:
+		return fmt.Sprintf("at least %d arguments", f.minArgs)
+	case f.minArgs == f.maxArgs:
+		return fmt.Sprintf("%d arguments", f.minArgs)
+	}
+	return fmt.Sprintf("%d to %d arguments", f.minArgs, f.maxArgs)
+}
+
+// exprFunctions 表达式可调用的全部函数，均为纯函数
+var exprFunctions = map[string]exprFunction{
+	// 通用
+	"len":      {1, 1, exprLen},
+	"type":     {1, 1, func(args []interface{}) (interface{}, error) { return exprTypeName(args[0]), nil }},
+	"string":   {1, 1, func(args []interface{}) (interface{}, error) { return exprToString(args[0]), nil }},
+	"number":   {1, 1, func(args []interface{}) (interface{}, error) { return exprToNumber(args[0]) }},
+	"int":      {1, 1, exprInt},
+	"bool":     {1, 1, exprBool},
+	"toJSON":   {1, 1, func(args []interface{}) (interface{}, error) { return exprToString(args[0]), nil }},
+	"fromJSON": {1, 1, exprFromJSON},
+	"default":  {2, 2, exprDefault},
+
+	// 字符串
+	"upper":      {1, 1, stringFunc(strings.ToUpper)},
+	"lower":      {1, 1, stringFunc(strings.ToLower)},
+	"trim":       {1, 1, stringFunc(strings.TrimSpace)},
+	"startsWith": {2, 2, stringPredicate(strings.HasPrefix)},
+	"endsWith":   {2, 2, stringPredicate(strings.HasSuffix)},
+	"contains":   {2, 2, exprContainsFunc},
+	"split":      {2, 2, exprSplit},
+	"join":       {1, 2, exprJoin},
+	"replace":    {3, 3, exprReplace},
+	"substr":     {2, 3, exprSubstr},
+	"urlEncode":  {1, 1, stringFunc(url.QueryEscape)},
+
+	// 数字
+	"abs":   {1, 1, numberFunc(math.Abs)},
+	"floor": {1, 1, numberFunc(math.Floor)},
+	"ceil":  {1, 1, numberFunc(math.Ceil)},
+	"round": {1, 1, numberFunc(math.Round)},
+	"min":   {1, -1, exprMinMax(-1)},
+	"max":   {1, -1, exprMinMax(1)},
+	"sum":   {1, 1, exprSum},
+
+	// 列表
+	"first":   {1, 1, exprFirst},
+	"last":    {1, 1, exprLast},
+	"flatten": {1, 1, exprFlatten},
+	"unique":  {1, 1, exprUnique},
+	"sort":    {1, 1, exprSort},
+	"reverse": {1, 1, exprReverse},
+
+	// 对象
+	"keys":   {1, 1, exprKeys},
+	"values": {1, 1, exprValues},
+	"has":    {2, 2, exprHas},
+	"merge":  {1, -1, exprMerge},
+	"pick":   {2, -1, exprPick(true)},
+	"omit":   {2, -1, exprPick(false)},
+}
+
+func exprLen(args []interface{}) (interface{}, error) {
+	switch v := args[0].(type) {
+	case nil:
+		return 0.0, nil
+	case string:
+		return float64(utf8.RuneCountInString(v)), nil
+	case []interface{}:
+		return float64(len(v)), nil
+	case map[string]interface{}:
+		return float64(len(v)), nil
+	}
+	return nil, fmt.Errorf("expects string, list or map, got %s", exprTypeName(args[0]))
+}
+
+func exprToNumber(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("cannot convert %q to number", v)
+		}
+		return f, nil
+	}
+	return 0, fmt.Errorf("cannot convert %s to number", exprTypeName(value))
+}
+
+func exprInt(args []interface{}) (interface{}, error) {
+	f, err := exprToNumber(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return math.Trunc(f), nil
+}
+
+func exprBool(args []interface{}) (interface{}, error) {
+	switch v := args[0].(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	case float64:
+		return v != 0, nil
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert %q to bool", v)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("cannot convert %s to bool", exprTypeName(args[0]))
+}
+
+func exprFromJSON(args []interface{}) (interface{}, error) {
+	s, ok := args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("expects string, got %s", exprTypeName(args[0]))
+	}
+	var result interface{}
+	if err := json.Unmarshal([]byte(s), &result); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+	return result, nil
+}
+
+// exprDefault 值为 null 或空字符串时返回默认值
+func exprDefault(args []interface{}) (interface{}, error) {
+	if args[0] == nil || args[0] == "" {
+		return args[1], nil
+	}
+	return args[0], nil
+}
+
+func stringFunc(fn func(string) string) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		s, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("expects string, got %s", exprTypeName(args[0]))
+		}
+		return fn(s), nil
+	}
+}
+
+func stringPredicate(fn func(string, string) bool) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		s, ok1 := args[0].(string)
+		sub, ok2 := args[1].(string)
+		if !ok1 || !ok2 {
+			return nil, fmt.Errorf("expects two strings, got %s and %s", exprTypeName(args[0]), exprTypeName(args[1]))
+		}
+		return fn(s, sub), nil
+	}
+}
+
+func exprContainsFunc(args []interface{}) (interface{}, error) {
+	return exprContains(0, args[0], args[1])
+}
+
+func exprSplit(args []interface{}) (interface{}, error) {
+	s, ok1 := args[0].(string)
+	sep, ok2 := args[1].(string)
+	if !ok1 || !ok2 {
+		return nil, fmt.Errorf("expects two strings, got %s and %s", exprTypeName(args[0]), exprTypeName(args[1]))
+	}
+	parts := strings.SplitN(s, sep, maxExprListLen+1)
+	items := make([]interface{}, len(parts))
+	for i, part := range parts {
+		items[i] = part
+	}
+	return items, nil
+}
+
+func exprJoin(args []interface{}) (interface{}, error) {
+	list, ok := args[0].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expects list, got %s", exprTypeName(args[0]))
+	}
+	sep := ""
+	if len(args) > 1 {
+		if sep, ok = args[1].(string); !ok {
+			return nil, fmt.Errorf("separator must be string, got %s", exprTypeName(args[1]))
+		}
+	}
+	parts := make([]string, len(list))
+	size := 0
+	for i, item := range list {
+		parts[i] = exprToString(item)
+		size += len(parts[i]) + len(sep)
+		if size > maxExprStringLen {
+			return nil, fmt.Errorf("result exceeds %d bytes", maxExprStringLen)
+		}
+	}
+	return strings.Join(parts, sep), nil
+}
+
+func exprReplace(args []interface{}) (interface{}, error) {
+	s, ok1 := args[0].(string)
+	old, ok2 := args[1].(string)
+	repl, ok3 := args[2].(string)
+	if !ok1 || !ok2 || !ok3 {
+		return nil, fmt.Errorf("expects three strings")
+	}
+	if old == "" {
+		return nil, fmt.Errorf("search string must not be empty")
+	}
+	if len(repl) > len(old) {
+		if grow := strings.Count(s, old) * (len(repl) - len(old)); len(s)+grow > maxExprStringLen {
+			return nil, fmt.Errorf("result exceeds %d bytes", maxExprStringLen)
+		}
+	}
+	return strings.ReplaceAll(s, old, repl), nil
+}
+
+// exprSubstr 按字符截取 [start, end)，end 省略时截取到末尾
+func exprSubstr(args []interface{}) (interface{}, error) {
+	s, ok := args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("expects string, got %s", exprTypeName(args[0]))
+	}
+	runes := []rune(s)
+	start, err := exprIntArg(args[1])
+	if err != nil {
+		return nil, err
+	}
+	end := len(runes)
+	if len(args) > 2 {
+		if end, err = exprIntArg(args[2]); err != nil {
+			return nil, err
+		}
+	}
+	start = clampIndex(start, len(runes))
+	end = clampIndex(end, len(runes))
+	if start >= end {
+		return "", nil
+	}
+	return string(runes[start:end]), nil
+}
+
+func exprIntArg(value interface{}) (int, error) {
+	f, ok := value.(float64)
+	if !ok || f != math.Trunc(f) {
+		return 0, fmt.Errorf("expects integer, got %s", exprTypeName(value))
+	}
+	return int(f), nil
+}
+
+// clampIndex 将索引限制在 [0, n]，负数从末尾计算
+func clampIndex(i, n int) int {
+	if i < 0 {
+		i += n
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > n {
+		return n
+	}
+	return i
+}
+
+func numberFunc(fn func(float64) float64) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		f, ok := args[0].(float64)
+		if !ok {
+			return nil, fmt.Errorf("expects number, got %s", exprTypeName(args[0]))
+		}
+		return fn(f), nil
+	}
+}
+
+// exprMinMax 参数为单个列表时取列表元素，否则取全部参数；sign 为 -1 取最小值，1 取最大值
+func exprMinMax(sign int) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		values := args
+		if list, ok := args[0].([]interface{}); ok && len(args) == 1 {
+			values = list
+		}
+		if len(values) == 0 {
+			return nil, nil
+		}
+		best := values[0]
+		for _, v := range values[1:] {
+			cmp, err := exprCompare(0, v, best)
+			if err != nil {
+				return nil, err
+			}
+			if cmp*sign > 0 {
+				best = v
+			}
+		}
+		if _, err := exprCompare(0, best, best); err != nil {
+			return nil, err
+		}
+		return best, nil
+	}
+}
+
+func exprSum(args []interface{}) (interface{}, error) {
+	list, ok := args[0].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expects list, got %s", exprTypeName(args[0]))
+	}
+	total := 0.0
+	for _, item := range list {
+		f, ok := item.(float64)
+		if !ok {
+			return nil, fmt.Errorf("list contains %s", exprTypeName(item))
+		}
+		total += f
+	}
+	return total, nil
+}
+
+func exprListArg(value interface{}) ([]interface{}, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		return v, nil
+	}
+	return nil, fmt.Errorf("expects list, got %s", exprTypeName(value))
+}
+
+func exprFirst(args []interface{}) (interface{}, error) {
+	list, err := exprListArg(args[0])
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return list[0], nil
+}
+
+func exprLast(args []interface{}) (interface{}, error) {
+	list, err := exprListArg(args[0])
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return list[len(list)-1], nil
+}
+
+// exprFlatten 展开一层嵌套列表
+func exprFlatten(args []interface{}) (interface{}, error) {
+	list, err := exprListArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	result := make([]interface{}, 0, len(list))
+	for _, item := range list {
+		if inner, ok := item.([]interface{}); ok {
+			result = append(result, inner...)
+		} else {
+			result = append(result, item)
+		}
+		if len(result) > maxExprListLen {
+			return nil, fmt.Errorf("result exceeds %d items", maxExprListLen)
+		}
+	}
+	return result, nil
+}
+
+func exprUnique(args []interface{}) (interface{}, error) {
+	list, err := exprListArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(list))
+	result := make([]interface{}, 0, len(list))
+	for _, item := range list {
+		key := exprTypeName(item) + ":" + exprToString(item)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// exprSort 对数字列表或字符串列表升序排序
+func exprSort(args []interface{}) (interface{}, error) {
+	list, err := exprListArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	result := append(make([]interface{}, 0, len(list)), list...)
+	var sortErr error
+	sort.SliceStable(result, func(i, j int) bool {
+		cmp, err := exprCompare(0, result[i], result[j])
+		if err != nil && sortErr == nil {
+			sortErr = err
+		}
+		return cmp < 0
+	})
+	if sortErr != nil {
+		return nil, fmt.Errorf("list must contain only numbers or only strings")
+	}
+	return result, nil
+}
+
+func exprReverse(args []interface{}) (interface{}, error) {
+	list, err := exprListArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	result := make([]interface{}, len(list))
+	for i, item := range list {
+		result[len(list)-1-i] = item
+	}
+	return result, nil
+}
+
+func exprMapArg(value interface{}) (map[string]interface{}, error) {
+	switch v := value.(type) {
+	case nil:
+		return map[string]interface{}{}, nil
+	case map[string]interface{}:
+		return v, nil
+	}
+	return nil, fmt.Errorf("expects map, got %s", exprTypeName(value))
+}
+
+func exprKeys(args []interface{}) (interface{}, error) {
+	m, err := exprMapArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	keys := sortedExprKeys(m)
+	result := make([]interface{}, len(keys))
+	for i, key := range keys {
+		result[i] = key
+	}
+	return result, nil
+}
+
+// exprValues 按键排序返回值
+func exprValues(args []interface{}) (interface{}, error) {
+	m, err := exprMapArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	keys := sortedExprKeys(m)
+	result := make([]interface{}, len(keys))
+	for i, key := range keys {
+		result[i] = m[key]
+	}
+	return result, nil
+}
+
+func exprHas(args []interface{}) (interface{}, error) {
+	m, err := exprMapArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	key, ok := args[1].(string)
+	if !ok {
+		return nil, fmt.Errorf("key must be string, got %s", exprTypeName(args[1]))
+	}
+	_, exists := m[key]
+	return exists, nil
+}
+
+// exprMerge 浅合并多个对象，后面的同名字段覆盖前面的，null 参数被忽略
+func exprMerge(args []interface{}) (interface{}, error) {
+	result := make(map[string]interface{})
+	for _, arg := range args {
+		m, err := exprMapArg(arg)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range m {
+			result[key] = value
+		}
+	}
+	return result, nil
+}
+
+// exprPick keep 为 true 时只保留指定字段，否则去掉指定字段；字段可以逐个传入或以列表传入
+func exprPick(keep bool) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		m, err := exprMapArg(args[0])
+		if err != nil {
+			return nil, err
+		}
+		fields := make(map[string]bool)
+		for _, arg := range args[1:] {
+			names, ok := arg.([]interface{})
+			if !ok {
+				names = []interface{}{arg}
+			}
+			for _, name := range names {
+				s, ok := name.(string)
+				if !ok {
+					return nil, fmt.Errorf("field names must be strings, got %s", exprTypeName(name))
+				}
+				fields[s] = true
+			}
+		}
+		result := make(map[string]interface{})
+		for key, value := range m {
+			if fields[key] == keep {
+				result[key] = value
+			}
+		}
+		return result, nil
+	}
+}
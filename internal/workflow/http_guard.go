@@ -0,0 +1,89 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"xiaozhi-server-go/internal/platform/httpclient"
+)
+
+// httpTargetGuard 限制 HTTP 节点可访问的地址：回环、链路本地、私有和未指定地址默认拒绝，
+// 除非主机名、IP 或所在网段在允许列表中。检查在 DNS 解析之后、建立连接之前进行，重定向同样受限
+type httpTargetGuard struct {
+	hosts map[string]bool
+	nets  []*net.IPNet
+}
+
+// newHTTPTargetGuard 解析允许列表，条目为主机名、IP 或 CIDR
+func newHTTPTargetGuard(allowed []string) *httpTargetGuard {
+	g := &httpTargetGuard{hosts: make(map[string]bool)}
+	for _, entry := range allowed {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if _, ipNet, err := net.ParseCIDR(entry); err == nil {
+			g.nets = append(g.nets, ipNet)
+			continue
+		}
+		if ip := net.ParseIP(entry); ip != nil {
+			g.nets = append(g.nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+			continue
+		}
+		g.hosts[strings.TrimSuffix(entry, ".")] = true
+	}
+	return g
+}
+
+// allowedIP 公网地址或在允许网段内的地址可以访问
+func (g *httpTargetGuard) allowedIP(ip net.IP) bool {
+	for _, ipNet := range g.nets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast())
+}
+
+// control 在连接建立前检查解析后的目标地址
+func (g *httpTargetGuard) control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !g.allowedIP(ip) {
+		return fmt.Errorf("target address %s is not allowed, add it to Workflow.HTTPAllowedHosts", host)
+	}
+	return nil
+}
+
+// client 创建 HTTP 节点使用的客户端；不走代理，否则检查的是代理地址而不是目标地址
+func (g *httpTargetGuard) client() *http.Client {
+	opts := httpclient.DefaultOptions()
+	guarded := &net.Dialer{Timeout: opts.DialTimeout, KeepAlive: 30 * time.Second, Control: g.control}
+	trusted := &net.Dialer{Timeout: opts.DialTimeout, KeepAlive: 30 * time.Second}
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy: nil,
+			DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+				if host, _, err := net.SplitHostPort(address); err == nil && g.hosts[strings.ToLower(strings.TrimSuffix(host, "."))] {
+					return trusted.DialContext(ctx, network, address)
+				}
+				return guarded.DialContext(ctx, network, address)
+			},
+			ForceAttemptHTTP2:     true,
+			MaxIdleConnsPerHost:   opts.MaxIdleConnsPerHost,
+			IdleConnTimeout:       opts.IdleConnTimeout,
+			TLSHandshakeTimeout:   opts.TLSHandshakeTimeout,
+			ResponseHeaderTimeout: opts.ResponseHeaderTimeout,
+			ExpectContinueTimeout: time.Second,
+		},
+	}
+}
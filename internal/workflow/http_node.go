@@ -0,0 +1,492 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"xiaozhi-server-go/internal/domain/tenant"
+)
+
+const (
+	httpNodeTimeout        = 30 * time.Second       // 单次请求默认超时
+	httpNodeMaxRetries     = 10                     // 重试次数上限
+	httpNodeDefaultBackoff = 500 * time.Millisecond // 首次重试前的默认等待，之后每次翻倍
+	httpNodeMaxBackoff     = 30 * time.Second       // 单次重试等待上限，包括 Retry-After
+	httpNodeMaxResponse    = 5 << 20                // 响应体读取上限
+	secretEnvPrefix        = "XIAOZHI_SECRET_"      // 未在配置中找到的密钥从该前缀的环境变量读取
+)
+
+// HTTP 节点额外的表达式变量：secret 仅可在请求头中以 secret.NAME 引用，status/headers/body 仅可在 extract 中引用
+const (
+	httpVarSecret  = "secret"
+	httpVarStatus  = "status"
+	httpVarHeaders = "headers"
+	httpVarBody    = "body"
+)
+
+// httpNodeProgram 编译后的 HTTP 节点配置
+type httpNodeProgram struct {
+	method           string
+	url              *textTemplate
+	query            map[string]*textTemplate
+	headers          map[string]*textTemplate
+	secretHeaders    map[string]bool // 引用了密钥的请求头，记录时脱敏
+	secrets          []string        // 请求头引用的密钥名
+	body             valueTemplate   // nil 表示没有请求体
+	textBody         bool            // body 配置为字符串时按文本发送
+	timeout          time.Duration
+	retries          int
+	backoff          time.Duration
+	allowErrorStatus bool
+	extract          map[string]*Expression
+}
+
+// httpResponse 单次请求的结果
+type httpResponse struct {
+	status     int
+	headers    map[string]interface{}
+	body       interface{}
+	retryAfter time.Duration
+}
+
+// compileHTTPNode 解析并编译 HTTP 节点配置，模板和表达式错误在此时报告
+func compileHTTPNode(node *Node) (*httpNodeProgram, error) {
+	names := expressionNames(node)
+	config := node.Config
+	program := &httpNodeProgram{
+		method:        http.MethodGet,
+		query:         make(map[string]*textTemplate),
+		headers:       make(map[string]*textTemplate),
+		secretHeaders: make(map[string]bool),
+		timeout:       httpNodeTimeout,
+		backoff:       httpNodeDefaultBackoff,
+		extract:       make(map[string]*Expression),
+	}
+
+	if method, _ := config["method"].(string); method != "" {
+		program.method = strings.ToUpper(method)
+	}
+	switch program.method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodHead:
+	default:
+		return nil, fmt.Errorf("unsupported method %s", program.method)
+	}
+
+	rawURL, _ := config["url"].(string)
+	if rawURL == "" {
+		return nil, fmt.Errorf("config.url is required")
+	}
+	urlTemplate, err := compileTextTemplate(rawURL, names)
+	if err != nil {
+		return nil, fmt.Errorf("config.url: %w", err)
+	}
+	program.url = urlTemplate
+
+	query, err := configStringMap(config, "query")
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range query {
+		if program.query[name], err = compileTextTemplate(value, names); err != nil {
+			return nil, fmt.Errorf("config.query.%s: %w", name, err)
+		}
+	}
+
+	headers, err := configStringMap(config, "headers")
+	if err != nil {
+		return nil, err
+	}
+	headerNames := append(append([]string(nil), names...), httpVarSecret)
+	seenSecrets := make(map[string]bool)
+	for name, value := range headers {
+		t, err := compileTextTemplate(value, headerNames)
+		if err != nil {
+			return nil, fmt.Errorf("config.headers.%s: %w", name, err)
+		}
+		refs, ok := t.fieldRefs(httpVarSecret)
+		if !ok {
+			return nil, fmt.Errorf("config.headers.%s: secrets must be referenced as secret.NAME", name)
+		}
+		for _, ref := range refs {
+			program.secretHeaders[name] = true
+			if !seenSecrets[ref] {
+				seenSecrets[ref] = true
+				program.secrets = append(program.secrets, ref)
+			}
+		}
+		program.headers[name] = t
+	}
+	sort.Strings(program.secrets)
+
+	if body, ok := config["body"]; ok && body != nil && body != "" {
+		if program.method == http.MethodGet || program.method == http.MethodHead {
+			return nil, fmt.Errorf("config.body is not allowed for %s requests", program.method)
+		}
+		if program.body, err = compileValueTemplate(body, names); err != nil {
+			return nil, fmt.Errorf("config.body: %w", err)
+		}
+		_, program.textBody = body.(string)
+	}
+
+	if timeout, err := configDuration(config["timeout"], "timeout"); err != nil {
+		return nil, err
+	} else if timeout > 0 {
+		program.timeout = timeout
+	}
+	if backoff, err := configDuration(config["retry_backoff"], "retry_backoff"); err != nil {
+		return nil, err
+	} else if backoff > 0 {
+		program.backoff = backoff
+	}
+	if retries, ok := config["retries"].(float64); ok {
+		if retries < 0 || retries > httpNodeMaxRetries || retries != float64(int(retries)) {
+			return nil, fmt.Errorf("config.retries must be an integer between 0 and %d", httpNodeMaxRetries)
+		}
+		program.retries = int(retries)
+	}
+	program.allowErrorStatus, _ = config["allow_error_status"].(bool)
+
+	extract, err := configStringMap(config, "extract")
+	if err != nil {
+		return nil, err
+	}
+	extractNames := append(append([]string(nil), names...), httpVarStatus, httpVarHeaders, httpVarBody)
+	for output, source := range extract {
+		if program.extract[output], err = CompileExpression(source, extractNames); err != nil {
+			return nil, fmt.Errorf("config.extract.%s: %w", output, err)
+		}
+	}
+	return program, nil
+}
+
+// configStringMap 读取值均为字符串的对象配置
+func configStringMap(config map[string]interface{}, key string) (map[string]string, error) {
+	raw, ok := config[key]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("config.%s must be an object", key)
+	}
+	result := make(map[string]string, len(m))
+	for name, value := range m {
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("config.%s.%s must be a string", key, name)
+		}
+		result[name] = s
+	}
+	return result, nil
+}
+
+// executeHTTPNode 执行 HTTP 节点：渲染请求、按配置重试，并从响应中提取输出
+func (e *WorkflowExecutorImpl) executeHTTPNode(ctx context.Context, workflow *Workflow, execution *Execution, node *Node, result *NodeResult) {
+	program, err := compileHTTPNode(node)
+	if err != nil {
+		e.markNodeFailed(execution, node.ID, err.Error())
+		return
+	}
+
+	if result.Timing == nil {
+		result.Timing = &NodeTiming{}
+	}
+	phaseStart := time.Now()
+	inputs, err := e.dataFlow.GetNodeInputs(execution, node, workflow)
+	result.Timing.Inputs = time.Since(phaseStart)
+	if err != nil {
+		e.markNodeFailed(execution, node.ID, fmt.Sprintf("Failed to get inputs: %v", err))
+		return
+	}
+	result.Inputs = inputs
+	vars := e.expressionVariables(workflow, execution, node, inputs)
+
+	phaseStart = time.Now()
+	outputs, err := e.runHTTPNode(ctx, execution, node, result, program, vars)
+	result.Timing.Execution = time.Since(phaseStart)
+	if err != nil {
+		e.markNodeFailed(execution, node.ID, fmt.Sprintf("HTTP request failed: %v", err))
+		return
+	}
+	result.Outputs = outputs
+
+	phaseStart = time.Now()
+	err = e.validateNodeOutputs(node, result.Outputs)
+	result.Timing.Validation = time.Since(phaseStart)
+	if err != nil {
+		e.markNodeFailed(execution, node.ID, fmt.Sprintf("Output validation failed: %v", err))
+		return
+	}
+
+	e.markNodeCompleted(execution, result)
+}
+
+func (e *WorkflowExecutorImpl) runHTTPNode(ctx context.Context, execution *Execution, node *Node, result *NodeResult, program *httpNodeProgram, vars map[string]interface{}) (map[string]interface{}, error) {
+	secrets, err := e.resolveSecrets(execution.TenantID, program.secrets)
+	if err != nil {
+		return nil, err
+	}
+	req, err := program.render(ctx, vars, secrets)
+	if err != nil {
+		return nil, err
+	}
+	if len(program.headers) > 0 {
+		e.addLog(execution, "info", node.ID, fmt.Sprintf("HTTP %s %s headers=%v", req.method, req.url, program.maskedHeaders(req.headers)))
+	} else {
+		e.addLog(execution, "info", node.ID, fmt.Sprintf("HTTP %s %s", req.method, req.url))
+	}
+
+	client := e.httpClient
+	var resp *httpResponse
+	for attempt := 0; ; attempt++ {
+		result.Attempts = attempt + 1
+		var retryable bool
+		resp, retryable, err = program.send(ctx, client, req)
+		if err == nil || !retryable || attempt >= program.retries {
+			break
+		}
+
+		wait := program.backoff << attempt
+		if resp != nil && resp.retryAfter > 0 {
+			wait = resp.retryAfter
+		}
+		if wait > httpNodeMaxBackoff || wait <= 0 {
+			wait = httpNodeMaxBackoff
+		}
+		e.addLog(execution, "warn", node.ID, fmt.Sprintf("HTTP request failed (attempt %d/%d), retrying in %s: %v", attempt+1, program.retries+1, wait, err))
+		waitStart := time.Now()
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		result.Timing.RetryWait += time.Since(waitStart)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := map[string]interface{}{
+		httpVarStatus:  resp.status,
+		httpVarHeaders: resp.headers,
+		httpVarBody:    resp.body,
+	}
+	if len(program.extract) == 0 {
+		return outputs, nil
+	}
+
+	extractVars := make(map[string]interface{}, len(vars)+3)
+	for name, value := range vars {
+		extractVars[name] = value
+	}
+	for name, value := range outputs {
+		extractVars[name] = value
+	}
+	evalCtx, cancel := context.WithTimeout(ctx, transformTimeout)
+	defer cancel()
+	names := make([]string, 0, len(program.extract))
+	for name := range program.extract {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value, err := program.extract[name].Evaluate(evalCtx, extractVars)
+		if err != nil {
+			return nil, fmt.Errorf("config.extract.%s: %w", name, err)
+		}
+		outputs[name] = value
+	}
+	return outputs, nil
+}
+
+// resolveSecrets 按名称读取密钥：先查工作流配置 Secrets，再查环境变量 XIAOZHI_SECRET_<NAME>。
+// 密钥属于整个实例，只有默认租户（管理员）的执行可以引用，其他租户的执行引用密钥时失败
+func (e *WorkflowExecutorImpl) resolveSecrets(tenantID string, names []string) (map[string]interface{}, error) {
+	if len(names) > 0 && tenantID != "" && tenantID != tenant.DefaultID {
+		return nil, fmt.Errorf("secrets are not available to executions of tenant %s", tenantID)
+	}
+	secrets := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		if e.config != nil {
+			if value, ok := e.config.Workflow.Secrets[name]; ok {
+				secrets[name] = value
+				continue
+			}
+		}
+		value, ok := os.LookupEnv(secretEnvPrefix + strings.ToUpper(name))
+		if !ok {
+			return nil, fmt.Errorf("secret %s is not configured", name)
+		}
+		secrets[name] = value
+	}
+	return secrets, nil
+}
+
+// renderedRequest 渲染后的请求，重试时复用
+type renderedRequest struct {
+	method      string
+	url         string
+	headers     http.Header
+	body        []byte
+	contentType string
+}
+
+func (p *httpNodeProgram) render(ctx context.Context, vars, secrets map[string]interface{}) (*renderedRequest, error) {
+	evalCtx, cancel := context.WithTimeout(ctx, transformTimeout)
+	defer cancel()
+
+	rawURL, err := p.url.render(evalCtx, vars)
+	if err != nil {
+		return nil, fmt.Errorf("config.url: %w", err)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid url %q: must be an absolute http(s) URL", rawURL)
+	}
+	if len(p.query) > 0 {
+		q := u.Query()
+		for name, t := range p.query {
+			value, err := t.render(evalCtx, vars)
+			if err != nil {
+				return nil, fmt.Errorf("config.query.%s: %w", name, err)
+			}
+			q.Set(name, value)
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	req := &renderedRequest{method: p.method, url: u.String(), headers: make(http.Header)}
+	headerVars := make(map[string]interface{}, len(vars)+1)
+	for name, value := range vars {
+		headerVars[name] = value
+	}
+	headerVars[httpVarSecret] = secrets
+	for name, t := range p.headers {
+		value, err := t.render(evalCtx, headerVars)
+		if err != nil {
+			return nil, fmt.Errorf("config.headers.%s: %w", name, err)
+		}
+		req.headers.Set(name, value)
+	}
+
+	if p.body != nil {
+		body, err := p.body(evalCtx, vars)
+		if err != nil {
+			return nil, fmt.Errorf("config.body: %w", err)
+		}
+		if s, ok := body.(string); ok && p.textBody {
+			req.body = []byte(s)
+			req.contentType = "text/plain; charset=utf-8"
+		} else {
+			if req.body, err = json.Marshal(body); err != nil {
+				return nil, fmt.Errorf("config.body: %w", err)
+			}
+			req.contentType = "application/json"
+		}
+		if req.headers.Get("Content-Type") == "" {
+			req.headers.Set("Content-Type", req.contentType)
+		}
+	}
+	return req, nil
+}
+
+// send 发送一次请求；返回的 retryable 表示网络错误、429 或 5xx，可以重试
+func (p *httpNodeProgram) send(ctx context.Context, client *http.Client, req *renderedRequest) (*httpResponse, bool, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	var body io.Reader
+	if req.body != nil {
+		body = bytes.NewReader(req.body)
+	}
+	httpReq, err := http.NewRequestWithContext(reqCtx, req.method, req.url, body)
+	if err != nil {
+		return nil, false, err
+	}
+	httpReq.Header = req.headers.Clone()
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		// 上层 context 结束时不再重试
+		return nil, ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, httpNodeMaxResponse+1))
+	if err != nil {
+		return nil, ctx.Err() == nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if len(data) > httpNodeMaxResponse {
+		return nil, false, fmt.Errorf("response body exceeds %d bytes", httpNodeMaxResponse)
+	}
+
+	result := &httpResponse{
+		status:  resp.StatusCode,
+		headers: make(map[string]interface{}, len(resp.Header)),
+		body:    decodeHTTPBody(resp.Header.Get("Content-Type"), data),
+	}
+	for name := range resp.Header {
+		result.headers[strings.ToLower(name)] = resp.Header.Get(name)
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		result.retryAfter = time.Duration(seconds) * time.Second
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 || p.allowErrorStatus {
+		return result, false, nil
+	}
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return result, retryable, &httpStatusError{status: resp.StatusCode, body: data}
+}
+
+// httpStatusError 非 2xx 响应
+type httpStatusError struct {
+	status int
+	body   []byte
+}
+
+func (e *httpStatusError) Error() string {
+	body := string(e.body)
+	if len(body) > 200 {
+		body = body[:200] + "..."
+	}
+	return fmt.Sprintf("unexpected status %d: %s", e.status, body)
+}
+
+// decodeHTTPBody JSON 响应解析为对象，其他响应按文本返回
+func decodeHTTPBody(contentType string, data []byte) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	trimmed := bytes.TrimSpace(data)
+	if strings.Contains(contentType, "json") || (len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[')) {
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err == nil {
+			return value
+		}
+	}
+	return string(data)
+}
+
+// maskedHeaders 返回可记录的请求头，引用了密钥的请求头脱敏
+func (p *httpNodeProgram) maskedHeaders(headers http.Header) map[string]string {
+	masked := make(map[string]string, len(headers))
+	for name := range p.headers {
+		value := headers.Get(name)
+		if p.secretHeaders[name] {
+			value = maskedValue
+		}
+		masked[name] = value
+	}
+	return masked
+}
@@ -0,0 +1,268 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+// NodeExecutor 自定义节点执行器，插件通过实现该接口扩展工作流节点类型
+type NodeExecutor interface {
+	// Execute 执行节点，inputs 为数据流汇集的节点输入，返回值作为节点输出
+	Execute(ctx context.Context, node *Node, inputs map[string]interface{}) (map[string]interface{}, error)
+}
+
+// NodeExecutorFunc 函数形式的节点执行器
+type NodeExecutorFunc func(ctx context.Context, node *Node, inputs map[string]interface{}) (map[string]interface{}, error)
+
+// Execute 实现 NodeExecutor
+func (f NodeExecutorFunc) Execute(ctx context.Context, node *Node, inputs map[string]interface{}) (map[string]interface{}, error) {
+	return f(ctx, node, inputs)
+}
+
+// NodeTypeDefinition 节点类型定义，供编辑器渲染节点面板和配置表单
+type NodeTypeDefinition struct {
+	Type         NodeType            `json:"type"`
+	Name         string              `json:"name"`
+	Description  string              `json:"description,omitempty"`
+	Builtin      bool                `json:"builtin"`
+	Source       string              `json:"source,omitempty"` // 注册来源：capability 表示从能力注册表同步
+	ConfigSchema capability.Schema   `json:"config_schema"`
+	InputSchema  capability.Schema   `json:"input_schema"`
+	OutputSchema capability.Schema   `json:"output_schema"`
+	UI           *capability.UIHints `json:"ui,omitempty"`
+}
+
+// sourceCapability 由能力注册表同步的节点来源标记
+const sourceCapability = "capability"
+
+// builtinNodeTypes 内置节点类型，由执行器直接处理，不能被覆盖
+var builtinNodeTypes = []NodeTypeDefinition{
+	{Type: NodeTypeStart, Name: "开始", Builtin: true},
+	{Type: NodeTypeEnd, Name: "结束", Builtin: true},
+	{Type: NodeTypeTask, Name: "任务", Description: "调用 plugin 字段指定的能力", Builtin: true},
+	{Type: NodeTypeCondition, Name: "条件", Builtin: true},
+	{Type: NodeTypeParallel, Name: "并行", Builtin: true},
+	{Type: NodeTypeMerge, Name: "合并", Builtin: true},
+	{Type: NodeTypeApproval, Name: "审批", Description: "暂停执行，等待人工批准后继续", Builtin: true},
+	{
+		Type:        NodeTypeTransform,
+		Name:        "转换",
+		Description: "用表达式映射、过滤、合并上游输出，可引用 inputs、nodes、vars、context 和已声明的输入",
+		Builtin:     true,
+		ConfigSchema: capability.Schema{
+			Type: "object",
+			Properties: map[string]capability.Property{
+				"expression": {Type: "string", Description: "表达式，结果为对象时作为节点输出，否则作为 result 输出"},
+				"mappings":   {Type: "object", Description: "输出名到表达式的映射，覆盖 expression 中的同名输出"},
+			},
+		},
+	},
+	{
+		Type:        NodeTypeHTTP,
+		Name:        "HTTP 请求",
+		Description: "调用外部 REST 接口，url、query、headers、body 支持 {{表达式}} 占位符",
+		Builtin:     true,
+		ConfigSchema: capability.Schema{
+			Type: "object",
+			Properties: map[string]capability.Property{
+				"method":             {Type: "string", Description: "请求方法", Enum: []interface{}{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD"}, Default: "GET"},
+				"url":                {Type: "string", Description: "请求地址，占位符原样插入，参数请使用 query 或 urlEncode()"},
+				"query":              {Type: "object", Description: "查询参数，值会被编码"},
+				"headers":            {Type: "object", Description: "请求头，可用 {{secret.NAME}} 引用密钥"},
+				"body":               {Type: "object", Description: "请求体模板，对象按 JSON 发送，字符串按文本发送"},
+				"timeout":            {Type: "string", Description: "单次请求超时，如 10s", Default: "30s"},
+				"retries":            {Type: "integer", Description: "网络错误、429 和 5xx 时的重试次数", Default: 0},
+				"retry_backoff":      {Type: "string", Description: "首次重试等待时间，之后每次翻倍", Default: "500ms"},
+				"allow_error_status": {Type: "boolean", Description: "非 2xx 响应不视为失败", Default: false},
+				"extract":            {Type: "object", Description: "输出名到表达式的映射，可引用 status、headers、body"},
+			},
+			Required: []string{"url"},
+		},
+		OutputSchema: capability.Schema{
+			Type: "object",
+			Properties: map[string]capability.Property{
+				"status":  {Type: "integer", Description: "响应状态码"},
+				"headers": {Type: "object", Description: "响应头，名称为小写"},
+				"body":    {Type: "object", Description: "响应体，JSON 响应解析为对象"},
+			},
+		},
+	},
+}
+
+// IsBuiltinNodeType 是否为内置节点类型
+func IsBuiltinNodeType(t NodeType) bool {
+	for _, def := range builtinNodeTypes {
+		if def.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+type registeredNode struct {
+	def      NodeTypeDefinition
+	executor NodeExecutor
+}
+
+// NodeRegistry 自定义节点类型注册表
+type NodeRegistry struct {
+	mu    sync.RWMutex
+	nodes map[NodeType]registeredNode
+}
+
+// NewNodeRegistry 创建节点类型注册表
+func NewNodeRegistry() *NodeRegistry {
+	return &NodeRegistry{nodes: make(map[NodeType]registeredNode)}
+}
+
+// Register 注册自定义节点类型；同名类型已存在时替换
+func (r *NodeRegistry) Register(def NodeTypeDefinition, executor NodeExecutor) error {
+	if def.Type == "" {
+		return fmt.Errorf("node type is required")
+	}
+	if IsBuiltinNodeType(def.Type) {
+		return fmt.Errorf("node type %s is builtin and cannot be overridden", def.Type)
+	}
+	if executor == nil {
+		return fmt.Errorf("node type %s has no executor", def.Type)
+	}
+	if def.Name == "" {
+		def.Name = string(def.Type)
+	}
+	def.Builtin = false
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nodes[def.Type] = registeredNode{def: def, executor: executor}
+	return nil
+}
+
+// Unregister 注销自定义节点类型
+func (r *NodeRegistry) Unregister(t NodeType) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.nodes, t)
+}
+
+// Executor 获取节点类型的执行器
+func (r *NodeRegistry) Executor(t NodeType) (NodeExecutor, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	node, ok := r.nodes[t]
+	return node.executor, ok
+}
+
+// Definition 获取节点类型定义，包括内置类型
+func (r *NodeRegistry) Definition(t NodeType) (NodeTypeDefinition, bool) {
+	for _, def := range builtinNodeTypes {
+		if def.Type == t {
+			return def, true
+		}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	node, ok := r.nodes[t]
+	return node.def, ok
+}
+
+// List 返回全部节点类型，内置类型在前，自定义类型按类型名排序
+func (r *NodeRegistry) List() []NodeTypeDefinition {
+	r.mu.RLock()
+	custom := make([]NodeTypeDefinition, 0, len(r.nodes))
+	for _, node := range r.nodes {
+		custom = append(custom, node.def)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(custom, func(i, j int) bool { return custom[i].Type < custom[j].Type })
+	return append(append([]NodeTypeDefinition(nil), builtinNodeTypes...), custom...)
+}
+
+// SyncCapabilities 将能力注册表中 TypeNode 类型的能力注册为节点类型，能力ID即节点类型；
+// 已从注册表移除的能力对应的节点类型一并注销，返回同步后的插件节点数
+func (r *NodeRegistry) SyncCapabilities(registry *capability.Registry) int {
+	if registry == nil {
+		return 0
+	}
+
+	found := make(map[NodeType]bool)
+	for _, def := range registry.ListCapabilities() {
+		if def.Type != capability.TypeNode || IsBuiltinNodeType(NodeType(def.ID)) {
+			continue
+		}
+		nodeType := NodeType(def.ID)
+		found[nodeType] = true
+
+		r.mu.RLock()
+		existing, exists := r.nodes[nodeType]
+		r.mu.RUnlock()
+		if exists && existing.def.Source != sourceCapability {
+			// 代码中直接注册的执行器优先
+			continue
+		}
+
+		r.Register(NodeTypeDefinition{
+			Type:         nodeType,
+			Name:         def.Name,
+			Description:  def.Description,
+			Source:       sourceCapability,
+			ConfigSchema: def.ConfigSchema,
+			InputSchema:  def.InputSchema,
+			OutputSchema: def.OutputSchema,
+			UI:           def.UI,
+		}, &capabilityNodeExecutor{registry: registry, capabilityID: def.ID})
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for nodeType, node := range r.nodes {
+		if node.def.Source == sourceCapability && !found[nodeType] {
+			delete(r.nodes, nodeType)
+		}
+	}
+	return len(found)
+}
+
+// capabilityNodeExecutor 通过能力注册表执行插件提供的节点，节点配置作为能力配置传入
+type capabilityNodeExecutor struct {
+	registry     *capability.Registry
+	capabilityID string
+}
+
+func (e *capabilityNodeExecutor) Execute(ctx context.Context, node *Node, inputs map[string]interface{}) (map[string]interface{}, error) {
+	executor, err := e.registry.GetExecutor(e.capabilityID)
+	if err != nil {
+		return nil, err
+	}
+	config := node.Config
+	if config == nil {
+		config = make(map[string]interface{})
+	}
+	return executor.Execute(ctx, config, inputs)
+}
+
+var (
+	defaultNodeRegistry   = NewNodeRegistry()
+	defaultNodeRegistryMu sync.RWMutex
+)
+
+// SetDefaultNodeRegistry 设置全局节点类型注册表
+func SetDefaultNodeRegistry(r *NodeRegistry) {
+	defaultNodeRegistryMu.Lock()
+	defer defaultNodeRegistryMu.Unlock()
+	if r == nil {
+		r = NewNodeRegistry()
+	}
+	defaultNodeRegistry = r
+}
+
+// DefaultNodeRegistry 返回全局节点类型注册表
+func DefaultNodeRegistry() *NodeRegistry {
+	defaultNodeRegistryMu.RLock()
+	defer defaultNodeRegistryMu.RUnlock()
+	return defaultNodeRegistry
+}
@@ -0,0 +1,75 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// PresenceChecker 在家状态来源，条件节点的 presence 条件通过它判断
+type PresenceChecker interface {
+	// EvaluatePresence subject 为 anyone、everyone、成员名称或用户ID，state 为 home 或 away
+	EvaluatePresence(ctx context.Context, subject, state string) (bool, error)
+}
+
+var (
+	defaultPresenceChecker   PresenceChecker
+	defaultPresenceCheckerMu sync.RWMutex
+)
+
+// SetDefaultPresenceChecker 设置全局在家状态来源，未启用在家状态时不设置
+func SetDefaultPresenceChecker(c PresenceChecker) {
+	defaultPresenceCheckerMu.Lock()
+	defer defaultPresenceCheckerMu.Unlock()
+	defaultPresenceChecker = c
+}
+
+// DefaultPresenceChecker 返回全局在家状态来源，未启用时返回 nil
+func DefaultPresenceChecker() PresenceChecker {
+	defaultPresenceCheckerMu.RLock()
+	defer defaultPresenceCheckerMu.RUnlock()
+	return defaultPresenceChecker
+}
+
+// presenceCondition 条件节点的 config.presence，如 {"subject": "anyone", "state": "home"}
+type presenceCondition struct {
+	subject string
+	state   string
+}
+
+// parsePresenceCondition 解析条件节点的 config.presence，未配置时返回 nil
+func parsePresenceCondition(node *Node) (*presenceCondition, error) {
+	raw, ok := node.Config["presence"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("config.presence must be an object")
+	}
+	cond := &presenceCondition{subject: "anyone", state: "home"}
+	if v, ok := m["subject"]; ok && v != nil && v != "" {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("config.presence.subject must be a string")
+		}
+		cond.subject = s
+	}
+	if v, ok := m["state"]; ok && v != nil && v != "" {
+		s, ok := v.(string)
+		if !ok || (s != "home" && s != "away") {
+			return nil, fmt.Errorf("config.presence.state must be home or away")
+		}
+		cond.state = s
+	}
+	return cond, nil
+}
+
+// evaluate 通过全局在家状态来源判断条件
+func (c *presenceCondition) evaluate(ctx context.Context) (bool, error) {
+	checker := DefaultPresenceChecker()
+	if checker == nil {
+		return false, fmt.Errorf("presence is not enabled")
+	}
+	return checker.EvaluatePresence(ctx, c.subject, c.state)
+}
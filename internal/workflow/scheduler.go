@@ -0,0 +1,246 @@
+package workflow
+
+import (
+	"context"
+	"sync"
+)
+
+// 调度默认值
+const (
+	DefaultMaxWorkers     = 16 // 全部执行共享的节点并发上限
+	DefaultParallelism    = 5  // 单个执行的节点并发上限
+	DefaultConcurrencyKey = "default"
+)
+
+// SchedulerOptions 节点调度参数
+type SchedulerOptions struct {
+	MaxWorkers         int               // 全部执行共享的节点并发上限
+	DefaultParallelism int               // 工作流未设置 parallel_limit 时单个执行的并发上限
+	Classes            map[string]int    // 并发类别 -> 并发上限，未列出的类别只受全局上限约束
+	CapabilityClasses  map[string]string // 能力ID或能力类型（llm、tts等）-> 并发类别，能力ID优先
+}
+
+// Scheduler 工作流节点调度器
+// 调用能力的节点执行前须申请执行槽：同时受全局上限、执行自身的并发上限和能力所属类别的上限约束；
+// 多个执行同时排队时轮流分配，避免节点多的执行占满全部槽位
+type Scheduler struct {
+	mu   sync.Mutex
+	opts SchedulerOptions
+
+	running      int
+	classRunning map[string]int
+	execRunning  map[string]int
+
+	queues map[string][]*slotRequest // 按执行排队，队内先进先出
+	ring   []string                  // 有排队请求的执行，轮转分配
+	next   int
+}
+
+type slotRequest struct {
+	executionID string
+	class       string
+	limit       int
+	ready       chan struct{}
+	granted     bool
+}
+
+// NewScheduler 创建节点调度器
+func NewScheduler(opts SchedulerOptions) *Scheduler {
+	if opts.MaxWorkers <= 0 {
+		opts.MaxWorkers = DefaultMaxWorkers
+	}
+	if opts.DefaultParallelism <= 0 {
+		opts.DefaultParallelism = DefaultParallelism
+	}
+	return &Scheduler{
+		opts:         opts,
+		classRunning: make(map[string]int),
+		execRunning:  make(map[string]int),
+		queues:       make(map[string][]*slotRequest),
+	}
+}
+
+// ClassFor 返回能力所属的并发类别
+func (s *Scheduler) ClassFor(capabilityID, capabilityType string) string {
+	if class, ok := s.opts.CapabilityClasses[capabilityID]; ok && class != "" {
+		return class
+	}
+	if class, ok := s.opts.CapabilityClasses[capabilityType]; ok && class != "" {
+		return class
+	}
+	return DefaultConcurrencyKey
+}
+
+// Parallelism 返回执行的并发上限，limit 为工作流配置的 parallel_limit
+func (s *Scheduler) Parallelism(limit int) int {
+	if limit <= 0 {
+		return s.opts.DefaultParallelism
+	}
+	return limit
+}
+
+// Acquire 申请执行槽，阻塞到分配成功或 ctx 结束；成功后必须调用 release
+func (s *Scheduler) Acquire(ctx context.Context, executionID string, limit int, class string) (release func(), err error) {
+	req := &slotRequest{
+		executionID: executionID,
+		class:       class,
+		limit:       s.Parallelism(limit),
+		ready:       make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	if len(s.queues[executionID]) == 0 {
+		s.ring = append(s.ring, executionID)
+	}
+	s.queues[executionID] = append(s.queues[executionID], req)
+	s.dispatchLocked()
+	s.mu.Unlock()
+
+	select {
+	case <-req.ready:
+		return s.releaseFunc(req), nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		if req.granted {
+			// 分配和取消同时发生，归还已分配的槽
+			s.releaseLocked(req)
+		} else {
+			s.removeLocked(req)
+		}
+		s.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+func (s *Scheduler) releaseFunc(req *slotRequest) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			s.releaseLocked(req)
+			s.mu.Unlock()
+		})
+	}
+}
+
+func (s *Scheduler) releaseLocked(req *slotRequest) {
+	s.running--
+	s.classRunning[req.class]--
+	if s.classRunning[req.class] <= 0 {
+		delete(s.classRunning, req.class)
+	}
+	s.execRunning[req.executionID]--
+	if s.execRunning[req.executionID] <= 0 {
+		delete(s.execRunning, req.executionID)
+	}
+	s.dispatchLocked()
+}
+
+// removeLocked 从队列中移除未分配的请求
+func (s *Scheduler) removeLocked(req *slotRequest) {
+	queue := s.queues[req.executionID]
+	for i, queued := range queue {
+		if queued == req {
+			queue = append(queue[:i], queue[i+1:]...)
+			break
+		}
+	}
+	if len(queue) > 0 {
+		s.queues[req.executionID] = queue
+		return
+	}
+	delete(s.queues, req.executionID)
+	s.dropFromRingLocked(req.executionID)
+}
+
+func (s *Scheduler) dropFromRingLocked(executionID string) {
+	for i, id := range s.ring {
+		if id != executionID {
+			continue
+		}
+		s.ring = append(s.ring[:i], s.ring[i+1:]...)
+		if i < s.next {
+			s.next--
+		}
+		if s.next >= len(s.ring) {
+			s.next = 0
+		}
+		return
+	}
+}
+
+// dispatchLocked 从上次分配位置开始轮转各执行的队列，每次给一个执行分配一个槽，
+// 直到全局槽位用尽或一整轮没有可分配的请求
+func (s *Scheduler) dispatchLocked() {
+	idle := 0
+	for s.running < s.opts.MaxWorkers && len(s.ring) > 0 && idle < len(s.ring) {
+		if s.next >= len(s.ring) {
+			s.next = 0
+		}
+		executionID := s.ring[s.next]
+		req := s.pickLocked(executionID)
+		if req == nil {
+			s.next++
+			idle++
+			continue
+		}
+
+		req.granted = true
+		s.running++
+		s.classRunning[req.class]++
+		s.execRunning[executionID]++
+		close(req.ready)
+		s.removeLocked(req)
+		if _, waiting := s.queues[executionID]; waiting {
+			s.next++
+		}
+		idle = 0
+	}
+}
+
+// pickLocked 返回执行队列中第一个可分配的请求，类别已满的请求让后面的请求先执行
+func (s *Scheduler) pickLocked(executionID string) *slotRequest {
+	queue := s.queues[executionID]
+	if len(queue) == 0 || s.execRunning[executionID] >= queue[0].limit {
+		return nil
+	}
+	for _, req := range queue {
+		if limit, ok := s.opts.Classes[req.class]; ok && limit > 0 && s.classRunning[req.class] >= limit {
+			continue
+		}
+		return req
+	}
+	return nil
+}
+
+// SchedulerStats 调度器状态
+type SchedulerStats struct {
+	MaxWorkers int            `json:"max_workers"`
+	Running    int            `json:"running"`
+	Queued     int            `json:"queued"`
+	Classes    map[string]int `json:"classes"`    // 各类别运行中的节点数
+	Executions map[string]int `json:"executions"` // 各执行运行中的节点数
+}
+
+// Stats 返回调度器当前状态
+func (s *Scheduler) Stats() SchedulerStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := SchedulerStats{
+		MaxWorkers: s.opts.MaxWorkers,
+		Running:    s.running,
+		Classes:    make(map[string]int, len(s.classRunning)),
+		Executions: make(map[string]int, len(s.execRunning)),
+	}
+	for class, n := range s.classRunning {
+		stats.Classes[class] = n
+	}
+	for id, n := range s.execRunning {
+		stats.Executions[id] = n
+	}
+	for _, queue := range s.queues {
+		stats.Queued += len(queue)
+	}
+	return stats
+}
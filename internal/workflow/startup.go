@@ -0,0 +1,419 @@
+package workflow
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// 启动工作流的起止节点ID
+const (
+	StartupWorkflowID = "startup"
+	StartupBeginNode  = "startup:begin"
+	StartupReadyNode  = "startup:ready"
+)
+
+// StartupStep 服务启动步骤
+type StartupStep struct {
+	ID        string
+	Title     string
+	DependsOn []string
+	Optional  bool // 可通过配置跳过
+}
+
+// StartupRecord 服务启动流程记录
+// 启动步骤按依赖关系转换为工作流节点，执行过程中记录每个步骤的状态和耗时，供界面展示
+type StartupRecord struct {
+	mu        sync.RWMutex
+	steps     []StartupStep
+	timeouts  map[string]time.Duration
+	workflow  *Workflow
+	execution *Execution
+}
+
+// NewStartupRecord 根据启动步骤创建启动流程记录
+func NewStartupRecord(steps []StartupStep) *StartupRecord {
+	now := time.Now()
+	wf := &Workflow{
+		ID:          StartupWorkflowID,
+		Name:        "服务启动",
+		Description: "服务启动时的初始化步骤",
+		Version:     "1.0.0",
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	depth := startupDepths(steps)
+	perDepth := make(map[int]int)
+	maxDepth := 0
+	hasDependents := make(map[string]bool)
+	for _, step := range steps {
+		for _, dep := range step.DependsOn {
+			hasDependents[dep] = true
+		}
+	}
+
+	wf.Nodes = append(wf.Nodes, Node{
+		ID:       StartupBeginNode,
+		Name:     "开始启动",
+		Type:     NodeTypeStart,
+		Position: Position{X: 0, Y: 0},
+		Status:   NodeStatusCompleted,
+	})
+	for _, step := range steps {
+		d := depth[step.ID] + 1
+		if d > maxDepth {
+			maxDepth = d
+		}
+		wf.Nodes = append(wf.Nodes, Node{
+			ID:          step.ID,
+			Name:        step.Title,
+			Type:        NodeTypeTask,
+			Description: step.Title,
+			Config: map[string]interface{}{
+				"step":     step.ID,
+				"optional": step.Optional,
+			},
+			Position: Position{X: float64(d) * 240, Y: float64(perDepth[d]) * 100},
+			Status:   NodeStatusPending,
+		})
+		perDepth[d]++
+
+		if len(step.DependsOn) == 0 {
+			wf.Edges = append(wf.Edges, startupEdge(StartupBeginNode, step.ID))
+		}
+		for _, dep := range step.DependsOn {
+			wf.Edges = append(wf.Edges, startupEdge(dep, step.ID))
+		}
+		if !hasDependents[step.ID] {
+			wf.Edges = append(wf.Edges, startupEdge(step.ID, StartupReadyNode))
+		}
+	}
+	wf.Nodes = append(wf.Nodes, Node{
+		ID:       StartupReadyNode,
+		Name:     "启动完成",
+		Type:     NodeTypeEnd,
+		Position: Position{X: float64(maxDepth+1) * 240, Y: 0},
+		Status:   NodeStatusPending,
+	})
+
+	execution := &Execution{
+		ID:          fmt.Sprintf("startup_%d", now.UnixNano()),
+		WorkflowID:  StartupWorkflowID,
+		Status:      ExecutionStatusRunning,
+		StartTime:   now,
+		Context:     make(map[string]interface{}),
+		NodeResults: make(map[string]*NodeResult),
+		Outputs:     make(map[string]interface{}),
+		Logs:        make([]ExecutionLog, 0),
+	}
+	execution.NodeResults[StartupBeginNode] = &NodeResult{
+		NodeID:    StartupBeginNode,
+		Status:    NodeStatusCompleted,
+		StartTime: now,
+		EndTime:   &now,
+	}
+
+	return &StartupRecord{
+		steps:     append([]StartupStep(nil), steps...),
+		timeouts:  make(map[string]time.Duration),
+		workflow:  wf,
+		execution: execution,
+	}
+}
+
+// startupDepths 计算每个步骤在依赖图中的层级，用于节点布局
+func startupDepths(steps []StartupStep) map[string]int {
+	deps := make(map[string][]string, len(steps))
+	for _, step := range steps {
+		deps[step.ID] = step.DependsOn
+	}
+	depth := make(map[string]int, len(steps))
+	var visit func(id string, seen map[string]bool) int
+	visit = func(id string, seen map[string]bool) int {
+		if d, ok := depth[id]; ok {
+			return d
+		}
+		if seen[id] {
+			return 0
+		}
+		seen[id] = true
+		d := 0
+		for _, dep := range deps[id] {
+			if v := visit(dep, seen) + 1; v > d {
+				d = v
+			}
+		}
+		depth[id] = d
+		return d
+	}
+	for _, step := range steps {
+		visit(step.ID, make(map[string]bool))
+	}
+	return depth
+}
+
+func startupEdge(from, to string) Edge {
+	return Edge{ID: from + "->" + to, From: from, To: to}
+}
+
+// StepStarted 记录步骤开始及其超时时间
+func (r *StartupRecord) StepStarted(stepID string, timeout time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.timeouts[stepID] = timeout
+	r.execution.NodeResults[stepID] = &NodeResult{
+		NodeID:    stepID,
+		Status:    NodeStatusRunning,
+		StartTime: time.Now(),
+	}
+	r.setNodeStatus(stepID, NodeStatusRunning, "")
+}
+
+// StepCompleted 记录步骤完成
+func (r *StartupRecord) StepCompleted(stepID string) {
+	r.finishStep(stepID, NodeStatusCompleted, "")
+}
+
+// StepFailed 记录步骤失败
+func (r *StartupRecord) StepFailed(stepID string, err error) {
+	msg := ""
+	if err != nil {
+		msg = err.Error()
+	}
+	r.finishStep(stepID, NodeStatusFailed, msg)
+}
+
+// StepSkipped 记录步骤被跳过
+func (r *StartupRecord) StepSkipped(stepID, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	r.execution.NodeResults[stepID] = &NodeResult{
+		NodeID:    stepID,
+		Status:    NodeStatusSkipped,
+		StartTime: now,
+		EndTime:   &now,
+		Error:     reason,
+	}
+	r.setNodeStatus(stepID, NodeStatusSkipped, reason)
+	r.appendLog("info", stepID, "skipped: "+reason)
+}
+
+func (r *StartupRecord) finishStep(stepID string, status NodeStatus, errMsg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	result, ok := r.execution.NodeResults[stepID]
+	if !ok {
+		result = &NodeResult{NodeID: stepID, StartTime: now}
+		r.execution.NodeResults[stepID] = result
+	}
+	result.Status = status
+	result.Error = errMsg
+	result.EndTime = &now
+	result.ElapsedTime = now.Sub(result.StartTime)
+	r.setNodeStatus(stepID, status, errMsg)
+
+	if status == NodeStatusFailed {
+		r.appendLog("error", stepID, errMsg)
+	} else {
+		r.appendLog("info", stepID, fmt.Sprintf("completed in %v", result.ElapsedTime))
+	}
+}
+
+// Finish 记录启动流程结束
+func (r *StartupRecord) Finish(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	r.execution.EndTime = &now
+	if err != nil {
+		r.execution.Status = ExecutionStatusFailed
+		r.execution.Error = err.Error()
+		return
+	}
+	r.execution.Status = ExecutionStatusCompleted
+	r.execution.NodeResults[StartupReadyNode] = &NodeResult{
+		NodeID:    StartupReadyNode,
+		Status:    NodeStatusCompleted,
+		StartTime: now,
+		EndTime:   &now,
+	}
+	r.setNodeStatus(StartupReadyNode, NodeStatusCompleted, "")
+}
+
+// Duration 返回步骤耗时，步骤未完成时 ok 为 false
+func (r *StartupRecord) Duration(stepID string) (time.Duration, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result, ok := r.execution.NodeResults[stepID]
+	if !ok || result.EndTime == nil || result.Status == NodeStatusSkipped {
+		return 0, false
+	}
+	return result.ElapsedTime, true
+}
+
+// Snapshot 返回启动工作流和执行结果的副本
+func (r *StartupRecord) Snapshot() (Workflow, Execution) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wf := *r.workflow
+	wf.Nodes = append([]Node(nil), r.workflow.Nodes...)
+	wf.Edges = append([]Edge(nil), r.workflow.Edges...)
+
+	exec := *r.execution
+	exec.NodeResults = make(map[string]*NodeResult, len(r.execution.NodeResults))
+	for id, result := range r.execution.NodeResults {
+		copied := *result
+		exec.NodeResults[id] = &copied
+	}
+	exec.Logs = append([]ExecutionLog(nil), r.execution.Logs...)
+	return wf, exec
+}
+
+// StartupStepTiming 启动步骤的执行时间
+type StartupStepTiming struct {
+	ID        string
+	Title     string
+	DependsOn []string
+	Status    NodeStatus
+	Error     string
+	Offset    time.Duration // 相对启动开始的时间，未执行时为 0
+	Duration  time.Duration
+	Timeout   time.Duration
+}
+
+// StartupReport 启动耗时报告
+type StartupReport struct {
+	StartedAt    time.Time
+	FinishedAt   *time.Time
+	Status       ExecutionStatus
+	Error        string
+	Duration     time.Duration       // 启动总耗时，未结束时为至今的耗时
+	StepTotal    time.Duration       // 各步骤耗时之和，大于总耗时的部分来自并行执行
+	Steps        []StartupStepTiming // 按开始时间排序，未执行的步骤在最后
+	CriticalPath []string            // 决定启动总耗时的步骤链：从最后结束的步骤起，逐个取最后结束的依赖
+}
+
+// Report 返回各步骤的执行时间和关键路径
+func (r *StartupRecord) Report() StartupReport {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	started := r.execution.StartTime
+	report := StartupReport{
+		StartedAt: started,
+		Status:    r.execution.Status,
+		Error:     r.execution.Error,
+		Steps:     make([]StartupStepTiming, 0, len(r.steps)),
+	}
+	if end := r.execution.EndTime; end != nil {
+		finished := *end
+		report.FinishedAt = &finished
+		report.Duration = finished.Sub(started)
+	} else {
+		report.Duration = time.Since(started)
+	}
+
+	ends := make(map[string]time.Time, len(r.steps))
+	for _, step := range r.steps {
+		timing := StartupStepTiming{
+			ID:        step.ID,
+			Title:     step.Title,
+			DependsOn: step.DependsOn,
+			Status:    NodeStatusPending,
+			Timeout:   r.timeouts[step.ID],
+		}
+		if result, ok := r.execution.NodeResults[step.ID]; ok {
+			timing.Status = result.Status
+			timing.Error = result.Error
+			if result.Status != NodeStatusSkipped {
+				timing.Offset = result.StartTime.Sub(started)
+				timing.Duration = result.ElapsedTime
+				if result.EndTime != nil {
+					ends[step.ID] = *result.EndTime
+				} else {
+					timing.Duration = time.Since(result.StartTime)
+				}
+			}
+		}
+		report.StepTotal += timing.Duration
+		report.Steps = append(report.Steps, timing)
+	}
+	sort.SliceStable(report.Steps, func(i, j int) bool {
+		a, b := report.Steps[i], report.Steps[j]
+		if (a.Status == NodeStatusPending) != (b.Status == NodeStatusPending) {
+			return b.Status == NodeStatusPending
+		}
+		return a.Offset < b.Offset
+	})
+	report.CriticalPath = r.criticalPath(ends)
+	return report
+}
+
+func (r *StartupRecord) criticalPath(ends map[string]time.Time) []string {
+	deps := make(map[string][]string, len(r.steps))
+	for _, step := range r.steps {
+		deps[step.ID] = step.DependsOn
+	}
+	latest := func(ids []string) string {
+		found := ""
+		for _, id := range ids {
+			if end, ok := ends[id]; ok && (found == "" || end.After(ends[found])) {
+				found = id
+			}
+		}
+		return found
+	}
+
+	all := make([]string, 0, len(r.steps))
+	for _, step := range r.steps {
+		all = append(all, step.ID)
+	}
+	var path []string
+	for id := latest(all); id != "" && len(path) < len(r.steps); id = latest(deps[id]) {
+		path = append([]string{id}, path...)
+	}
+	return path
+}
+
+func (r *StartupRecord) setNodeStatus(nodeID string, status NodeStatus, errMsg string) {
+	for i := range r.workflow.Nodes {
+		if r.workflow.Nodes[i].ID == nodeID {
+			r.workflow.Nodes[i].Status = status
+			r.workflow.Nodes[i].Error = errMsg
+			return
+		}
+	}
+}
+
+func (r *StartupRecord) appendLog(level, nodeID, message string) {
+	r.execution.Logs = append(r.execution.Logs, ExecutionLog{
+		Timestamp: time.Now(),
+		Level:     level,
+		NodeID:    nodeID,
+		Message:   message,
+	})
+}
+
+var (
+	startupRecord   *StartupRecord
+	startupRecordMu sync.RWMutex
+)
+
+// SetStartupRecord 设置当前进程的启动流程记录
+func SetStartupRecord(r *StartupRecord) {
+	startupRecordMu.Lock()
+	defer startupRecordMu.Unlock()
+	startupRecord = r
+}
+
+// CurrentStartupRecord 返回当前进程的启动流程记录，未记录时返回 nil
+func CurrentStartupRecord() *StartupRecord {
+	startupRecordMu.RLock()
+	defer startupRecordMu.RUnlock()
+	return startupRecord
+}
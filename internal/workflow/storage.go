@@ -4,20 +4,41 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
+
+	"xiaozhi-server-go/internal/domain/tenant"
 )
 
 var (
 	workflowFile = filepath.Join("data", "workflow.json")
+	tenantDir    = filepath.Join("data", "tenants")
 	mu           sync.RWMutex
+
+	executionDir = filepath.Join("data", "executions")
+	executionMu  sync.Mutex
 )
 
-// LoadCurrentWorkflow loads the current workflow from file or returns default
+// maxStoredExecutions limits how many finished executions are kept on disk
+const maxStoredExecutions = 200
+
+// LoadCurrentWorkflow loads the default tenant's current workflow from file or returns default
 func LoadCurrentWorkflow() (*Workflow, error) {
+	return LoadTenantWorkflow(tenant.DefaultID)
+}
+
+// SaveWorkflow saves the default tenant's current workflow to file
+func SaveWorkflow(wf *Workflow) error {
+	return SaveTenantWorkflow(tenant.DefaultID, wf)
+}
+
+// LoadTenantWorkflow loads a tenant's current workflow from file or returns default
+func LoadTenantWorkflow(tenantID string) (*Workflow, error) {
 	mu.RLock()
 	defer mu.RUnlock()
 
-	data, err := os.ReadFile(workflowFile)
+	data, err := os.ReadFile(tenantWorkflowFile(tenantID))
 	if err != nil {
 		if os.IsNotExist(err) {
 			return CreateDefaultConversationWorkflow(), nil
@@ -32,8 +53,8 @@ func LoadCurrentWorkflow() (*Workflow, error) {
 	return &wf, nil
 }
 
-// SaveWorkflow saves the workflow to file
-func SaveWorkflow(wf *Workflow) error {
+// SaveTenantWorkflow saves a tenant's current workflow to file
+func SaveTenantWorkflow(tenantID string, wf *Workflow) error {
 	mu.Lock()
 	defer mu.Unlock()
 
@@ -42,10 +63,134 @@ func SaveWorkflow(wf *Workflow) error {
 		return err
 	}
 
-	dir := filepath.Dir(workflowFile)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	path := tenantWorkflowFile(tenantID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// tenantWorkflowFile keeps the default tenant at data/workflow.json so existing
+// installs are unaffected; other tenants live under data/tenants/<id>/
+func tenantWorkflowFile(tenantID string) string {
+	if tenantID == "" || tenantID == tenant.DefaultID {
+		return workflowFile
+	}
+	return filepath.Join(tenantDir, filepath.Base(tenantID), "workflow.json")
+}
+
+// SaveExecution saves a finished execution, including node results and logs,
+// and prunes the oldest records beyond maxStoredExecutions
+func SaveExecution(execution *Execution) error {
+	data, err := json.MarshalIndent(execution, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	executionMu.Lock()
+	defer executionMu.Unlock()
+
+	if err := os.MkdirAll(executionDir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(executionPath(execution.ID), data, 0644); err != nil {
+		return err
+	}
+	return pruneExecutions()
+}
+
+// LoadExecution loads a saved execution by ID
+func LoadExecution(executionID string) (*Execution, error) {
+	executionMu.Lock()
+	defer executionMu.Unlock()
+
+	data, err := os.ReadFile(executionPath(executionID))
+	if err != nil {
+		return nil, err
+	}
+
+	var execution Execution
+	if err := json.Unmarshal(data, &execution); err != nil {
+		return nil, err
+	}
+	return &execution, nil
+}
+
+// ListExecutions loads saved executions, newest first; limit <= 0 returns all of them
+func ListExecutions(limit int) ([]*Execution, error) {
+	executionMu.Lock()
+	defer executionMu.Unlock()
+
+	files, err := storedExecutionFiles()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Execution{}, nil
+		}
+		return nil, err
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime > files[j].modTime })
+	if limit > 0 && len(files) > limit {
+		files = files[:limit]
+	}
+
+	executions := make([]*Execution, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(executionDir, file.name))
+		if err != nil {
+			continue
+		}
+		var execution Execution
+		if err := json.Unmarshal(data, &execution); err != nil {
+			continue
+		}
+		executions = append(executions, &execution)
+	}
+	return executions, nil
+}
+
+func executionPath(executionID string) string {
+	// Execution IDs come from request paths; keep them inside executionDir
+	return filepath.Join(executionDir, filepath.Base(executionID)+".json")
+}
+
+type storedFile struct {
+	name    string
+	modTime int64
+}
+
+func storedExecutionFiles() ([]storedFile, error) {
+	entries, err := os.ReadDir(executionDir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]storedFile, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, storedFile{name: entry.Name(), modTime: info.ModTime().UnixNano()})
+	}
+	return files, nil
+}
+
+func pruneExecutions() error {
+	files, err := storedExecutionFiles()
+	if err != nil {
 		return err
 	}
+	if len(files) <= maxStoredExecutions {
+		return nil
+	}
 
-	return os.WriteFile(workflowFile, data, 0644)
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime < files[j].modTime })
+	for _, file := range files[:len(files)-maxStoredExecutions] {
+		os.Remove(filepath.Join(executionDir, file.name))
+	}
+	return nil
 }
@@ -0,0 +1,61 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+
+	"xiaozhi-server-go/internal/plugin/capability"
+)
+
+// maxRepairsKey 任务节点配置中结构化输出的修复重试次数
+const maxRepairsKey = "max_repairs"
+
+// defaultMaxRepairs 未配置 max_repairs 时的修复重试次数
+const defaultMaxRepairs = 2
+
+// structuredOutput 取出任务节点配置中的结构化输出要求（response_format 和 max_repairs），
+// 返回去掉这两项的配置；未要求结构化输出时 format 为 nil，配置原样返回
+func structuredOutput(config map[string]interface{}) (*capability.ResponseFormat, int, map[string]interface{}, error) {
+	format, err := capability.ParseResponseFormat(config[capability.ResponseFormatKey])
+	if err != nil {
+		return nil, 0, config, err
+	}
+	if !format.JSON() {
+		return nil, 0, config, nil
+	}
+
+	maxRepairs := defaultMaxRepairs
+	if raw, ok := config[maxRepairsKey]; ok {
+		n, ok := raw.(float64)
+		if i, isInt := raw.(int); isInt {
+			n, ok = float64(i), true
+		}
+		if !ok || n < 0 || n > 5 || n != float64(int(n)) {
+			return nil, 0, config, fmt.Errorf("%s must be an integer between 0 and 5", maxRepairsKey)
+		}
+		maxRepairs = int(n)
+	}
+
+	stripped := make(map[string]interface{}, len(config))
+	for k, v := range config {
+		if k != capability.ResponseFormatKey && k != maxRepairsKey {
+			stripped[k] = v
+		}
+	}
+	return format, maxRepairs, stripped, nil
+}
+
+// runStructured 调用 LLM 能力并校验输出，输出在能力输出之外增加解析后的 json 和生成次数 attempts
+func runStructured(ctx context.Context, executor capability.Executor, config, inputs map[string]interface{}, format *capability.ResponseFormat, maxRepairs int) (map[string]interface{}, error) {
+	result, err := capability.RunStructured(ctx, executor, config, inputs, format, maxRepairs)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make(map[string]interface{}, len(result.Outputs)+2)
+	for k, v := range result.Outputs {
+		outputs[k] = v
+	}
+	outputs["json"] = result.Value
+	outputs["attempts"] = result.Attempts
+	return outputs, nil
+}
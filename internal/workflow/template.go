@@ -0,0 +1,146 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// textTemplate 含 {{表达式}} 占位符的文本模板
+type textTemplate struct {
+	literals []string      // len(literals) == len(exprs)+1
+	exprs    []*Expression // 占位符表达式
+}
+
+// compileTextTemplate 编译文本模板，占位符内为表达式语言
+func compileTextTemplate(text string, names []string) (*textTemplate, error) {
+	t := &textTemplate{}
+	rest := text
+	offset := 0
+	for {
+		start := strings.Index(rest, "{{")
+		if start < 0 {
+			t.literals = append(t.literals, rest)
+			return t, nil
+		}
+		end := strings.Index(rest[start+2:], "}}")
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed {{ at position %d", offset+start+1)
+		}
+		source := rest[start+2 : start+2+end]
+		expr, err := CompileExpression(strings.TrimSpace(source), names)
+		if err != nil {
+			return nil, fmt.Errorf("placeholder at position %d: %w", offset+start+1, err)
+		}
+		t.literals = append(t.literals, rest[:start])
+		t.exprs = append(t.exprs, expr)
+		consumed := start + 2 + end + 2
+		offset += consumed
+		rest = rest[consumed:]
+	}
+}
+
+// isSingle 模板是否恰好是一个占位符，此时 value 保留表达式结果的原始类型
+func (t *textTemplate) isSingle() bool {
+	return len(t.exprs) == 1 && t.literals[0] == "" && t.literals[1] == ""
+}
+
+// render 渲染为字符串，null 渲染为空字符串
+func (t *textTemplate) render(ctx context.Context, vars map[string]interface{}) (string, error) {
+	var b strings.Builder
+	for i, expr := range t.exprs {
+		b.WriteString(t.literals[i])
+		value, err := expr.Evaluate(ctx, vars)
+		if err != nil {
+			return "", err
+		}
+		if value != nil {
+			b.WriteString(exprToString(value))
+		}
+	}
+	b.WriteString(t.literals[len(t.literals)-1])
+	return b.String(), nil
+}
+
+// value 渲染模板，单个占位符的模板返回表达式结果本身
+func (t *textTemplate) value(ctx context.Context, vars map[string]interface{}) (interface{}, error) {
+	if t.isSingle() {
+		return t.exprs[0].Evaluate(ctx, vars)
+	}
+	return t.render(ctx, vars)
+}
+
+// fieldRefs 汇总模板中以 root.NAME 引用的字段名
+func (t *textTemplate) fieldRefs(root string) ([]string, bool) {
+	var fields []string
+	for _, expr := range t.exprs {
+		refs, ok := expr.FieldRefs(root)
+		if !ok {
+			return nil, false
+		}
+		fields = append(fields, refs...)
+	}
+	return fields, true
+}
+
+// valueTemplate 结构化模板：对象和数组按结构渲染，字符串叶子按文本模板渲染
+type valueTemplate func(ctx context.Context, vars map[string]interface{}) (interface{}, error)
+
+// compileValueTemplate 编译 JSON 结构的模板，如 HTTP 请求体
+func compileValueTemplate(value interface{}, names []string) (valueTemplate, error) {
+	switch v := value.(type) {
+	case string:
+		t, err := compileTextTemplate(v, names)
+		if err != nil {
+			return nil, err
+		}
+		return t.value, nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fields := make([]valueTemplate, len(keys))
+		for i, key := range keys {
+			field, err := compileValueTemplate(v[key], names)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			fields[i] = field
+		}
+		return func(ctx context.Context, vars map[string]interface{}) (interface{}, error) {
+			result := make(map[string]interface{}, len(keys))
+			for i, key := range keys {
+				value, err := fields[i](ctx, vars)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", key, err)
+				}
+				result[key] = value
+			}
+			return result, nil
+		}, nil
+	case []interface{}:
+		items := make([]valueTemplate, len(v))
+		for i, item := range v {
+			compiled, err := compileValueTemplate(item, names)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			items[i] = compiled
+		}
+		return func(ctx context.Context, vars map[string]interface{}) (interface{}, error) {
+			result := make([]interface{}, len(items))
+			for i, item := range items {
+				value, err := item(ctx, vars)
+				if err != nil {
+					return nil, fmt.Errorf("[%d]: %w", i, err)
+				}
+				result[i] = value
+			}
+			return result, nil
+		}, nil
+	}
+	return func(context.Context, map[string]interface{}) (interface{}, error) { return value, nil }, nil
+}
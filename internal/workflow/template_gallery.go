@@ -0,0 +1,262 @@
+package workflow
+
+import "time"
+
+// BuiltinTemplates 返回随服务发布的内置模板库，每次调用返回新的副本
+func BuiltinTemplates() []*WorkflowTemplate {
+	return []*WorkflowTemplate{
+		voiceAssistantTemplate(),
+		nightlyBatchTTSTemplate(),
+		deviceHealthReportTemplate(),
+	}
+}
+
+func intRule(v int) *int           { return &v }
+func floatRule(v float64) *float64 { return &v }
+
+// voiceAssistantTemplate 语音助手流水线：ASR -> 拼接提示词 -> LLM -> TTS
+func voiceAssistantTemplate() *WorkflowTemplate {
+	return &WorkflowTemplate{
+		ID:          "voice-assistant",
+		Name:        "语音助手流水线",
+		Description: "识别用户语音，结合系统提示词调用大模型，再将回复合成为语音",
+		Category:    "conversation",
+		Tags:        []string{"asr", "llm", "tts"},
+		Builtin:     true,
+		Parameters: []TemplateParameter{
+			{Name: "assistant_name", Label: "助手名称", Type: ParamTypeString, Default: "小智", Validation: &Validation{MinLength: intRule(1), MaxLength: intRule(32)}},
+			{Name: "system_prompt", Label: "系统提示词", Type: ParamTypeString, Default: "你是一个友好、简洁的语音助手，回答控制在三句话以内。", Validation: &Validation{MaxLength: intRule(4000)}},
+			{Name: "temperature", Label: "采样温度", Type: ParamTypeNumber, Default: 0.7, Validation: &Validation{Min: floatRule(0), Max: floatRule(2)}},
+			{Name: "max_tokens", Label: "最大回复长度", Type: ParamTypeInteger, Default: 512, Validation: &Validation{Min: floatRule(1), Max: floatRule(8192)}},
+			{Name: "voice", Label: "发音人", Type: ParamTypeString, Default: "zh-CN-XiaoxiaoNeural", Description: "TTS 发音人，取值取决于所用的 TTS 供应商"},
+		},
+		Workflow: Workflow{
+			ID:          "voice-assistant",
+			Name:        "${assistant_name} 语音助手",
+			Description: "ASR -> LLM -> TTS",
+			Version:     "1.0.0",
+			Config: WorkflowConfig{
+				Timeout:       60 * time.Second,
+				ParallelLimit: 1,
+				Variables: map[string]interface{}{
+					"assistant_name": "${assistant_name}",
+					"system_prompt":  "${system_prompt}",
+				},
+			},
+			Nodes: []Node{
+				{
+					ID: "start", Name: "开始", Type: NodeTypeStart,
+					Inputs:   []InputSchema{{Name: "audio_data", Type: "string", Required: true, Description: "用户语音"}},
+					Position: Position{X: 0, Y: 100},
+				},
+				{
+					ID: "asr", Name: "语音识别", Type: NodeTypeTask, Plugin: "core.asr",
+					Inputs:   []InputSchema{{Name: "audio_data", Type: "string", Required: true}},
+					Outputs:  []OutputSchema{{Name: "text", Type: "string"}},
+					Position: Position{X: 200, Y: 100},
+				},
+				{
+					ID: "prompt", Name: "拼接提示词", Type: NodeTypeTransform,
+					Config: map[string]interface{}{
+						"mappings": map[string]interface{}{
+							"prompt": "vars.system_prompt + '\\n\\n用户：' + nodes.asr.text + '\\n' + vars.assistant_name + '：'",
+						},
+					},
+					Outputs:  []OutputSchema{{Name: "prompt", Type: "string"}},
+					Position: Position{X: 400, Y: 100},
+				},
+				{
+					ID: "llm", Name: "大模型", Type: NodeTypeTask, Plugin: "core.llm",
+					Config: map[string]interface{}{
+						"temperature": "${temperature}",
+						"max_tokens":  "${max_tokens}",
+					},
+					Inputs:   []InputSchema{{Name: "prompt", Type: "string", Required: true}},
+					Outputs:  []OutputSchema{{Name: "text", Type: "string"}},
+					Position: Position{X: 600, Y: 100},
+				},
+				{
+					ID: "tts", Name: "语音合成", Type: NodeTypeTask, Plugin: "core.tts",
+					Config:   map[string]interface{}{"voice": "${voice}"},
+					Inputs:   []InputSchema{{Name: "text", Type: "string", Required: true}},
+					Outputs:  []OutputSchema{{Name: "audio_object", Type: "string"}, {Name: "audio_url", Type: "string"}},
+					Position: Position{X: 800, Y: 100},
+				},
+				{ID: "end", Name: "结束", Type: NodeTypeEnd, Position: Position{X: 1000, Y: 100}},
+			},
+			Edges: []Edge{
+				{ID: "e1", From: "start", To: "asr"},
+				{ID: "e2", From: "asr", To: "prompt"},
+				{ID: "e3", From: "prompt", To: "llm"},
+				{ID: "e4", From: "llm", To: "tts"},
+				{ID: "e5", From: "tts", To: "end"},
+			},
+		},
+	}
+}
+
+// nightlyBatchTTSTemplate 夜间批量合成：拉取待合成文本 -> 清洗合并 -> TTS
+// 模板只记录 schedule 变量，由外部调度器按计划调用执行接口
+func nightlyBatchTTSTemplate() *WorkflowTemplate {
+	return &WorkflowTemplate{
+		ID:          "nightly-batch-tts",
+		Name:        "夜间批量语音合成",
+		Description: "定时从接口拉取待合成的文本列表，清洗合并后批量合成语音",
+		Category:    "batch",
+		Tags:        []string{"tts", "http", "schedule"},
+		Builtin:     true,
+		Parameters: []TemplateParameter{
+			{Name: "source_url", Label: "文本来源接口", Type: ParamTypeString, Required: true, Description: "返回 JSON 的接口，响应体为字符串数组或包含 texts 数组的对象", Validation: &Validation{Pattern: `^https?://\S+$`}},
+			{Name: "schedule", Label: "执行计划", Type: ParamTypeString, Default: "0 2 * * *", Description: "cron 表达式，写入工作流变量 schedule 供外部调度器读取", Validation: &Validation{Pattern: `^\S+( \S+){4}$`}},
+			{Name: "voice", Label: "发音人", Type: ParamTypeString, Default: "zh-CN-XiaoxiaoNeural"},
+			{Name: "separator", Label: "段落分隔符", Type: ParamTypeString, Default: "\n"},
+			{Name: "retries", Label: "拉取重试次数", Type: ParamTypeInteger, Default: 3, Validation: &Validation{Min: floatRule(0), Max: floatRule(10)}},
+		},
+		Workflow: Workflow{
+			ID:          "nightly-batch-tts",
+			Name:        "夜间批量语音合成",
+			Description: "按计划 ${schedule} 拉取文本并合成语音",
+			Version:     "1.0.0",
+			Config: WorkflowConfig{
+				Timeout:       30 * time.Minute,
+				ParallelLimit: 1,
+				Variables: map[string]interface{}{
+					"schedule":  "${schedule}",
+					"separator": "${separator}",
+				},
+			},
+			Nodes: []Node{
+				{ID: "start", Name: "开始", Type: NodeTypeStart, Position: Position{X: 0, Y: 100}},
+				{
+					ID: "fetch", Name: "拉取文本", Type: NodeTypeHTTP,
+					Config: map[string]interface{}{
+						"method":        "GET",
+						"url":           "${source_url}",
+						"timeout":       "30s",
+						"retries":       "${retries}",
+						"retry_backoff": "2s",
+						"extract": map[string]interface{}{
+							"texts": "type(body) == 'list' ? body : (body.texts ?? [])",
+						},
+					},
+					Outputs:  []OutputSchema{{Name: "texts", Type: "array"}},
+					Position: Position{X: 200, Y: 100},
+				},
+				{
+					ID: "prepare", Name: "清洗合并", Type: NodeTypeTransform,
+					Config: map[string]interface{}{
+						"mappings": map[string]interface{}{
+							"text":  "join(nodes.fetch.texts.map(t, trim(string(t))).filter(t, t != ''), vars.separator)",
+							"count": "len(nodes.fetch.texts.filter(t, trim(string(t)) != ''))",
+						},
+					},
+					Outputs:  []OutputSchema{{Name: "text", Type: "string"}, {Name: "count", Type: "number"}},
+					Position: Position{X: 400, Y: 100},
+				},
+				{
+					ID: "tts", Name: "语音合成", Type: NodeTypeTask, Plugin: "core.tts",
+					Config:   map[string]interface{}{"voice": "${voice}"},
+					Inputs:   []InputSchema{{Name: "text", Type: "string", Required: true}},
+					Outputs:  []OutputSchema{{Name: "audio_file", Type: "string"}, {Name: "audio_object", Type: "string"}, {Name: "audio_url", Type: "string"}},
+					Position: Position{X: 600, Y: 100},
+				},
+				{ID: "end", Name: "结束", Type: NodeTypeEnd, Position: Position{X: 800, Y: 100}},
+			},
+			Edges: []Edge{
+				{ID: "e1", From: "start", To: "fetch"},
+				{ID: "e2", From: "fetch", To: "prepare"},
+				{ID: "e3", From: "prepare", To: "tts"},
+				{ID: "e4", From: "tts", To: "end"},
+			},
+		},
+	}
+}
+
+// deviceHealthReportTemplate 设备健康报告：查询设备列表 -> 统计在线情况 -> 发送通知
+func deviceHealthReportTemplate() *WorkflowTemplate {
+	return &WorkflowTemplate{
+		ID:          "device-health-report",
+		Name:        "设备健康报告",
+		Description: "查询设备列表，统计在线和离线设备，并通过通知渠道发送报告",
+		Category:    "operations",
+		Tags:        []string{"device", "notification", "schedule"},
+		Builtin:     true,
+		Parameters: []TemplateParameter{
+			{Name: "api_base_url", Label: "服务地址", Type: ParamTypeString, Default: "http://127.0.0.1:8080", Description: "本服务 HTTP API 的地址", Validation: &Validation{Pattern: `^https?://[^\s/]+(/\S*)?[^/\s]$`}},
+			{Name: "token_secret", Label: "访问令牌密钥名", Type: ParamTypeString, Default: "xiaozhi_api_token", Description: "调用设备接口的令牌所在密钥，见 Workflow.Secrets", Validation: &Validation{Pattern: `^[A-Za-z_][A-Za-z0-9_]*$`}},
+			{Name: "channels", Label: "通知渠道", Type: ParamTypeArray, Required: true, Description: "通知渠道ID列表", Validation: &Validation{MinLength: intRule(1)}},
+			{Name: "schedule", Label: "执行计划", Type: ParamTypeString, Default: "0 9 * * *", Description: "cron 表达式，写入工作流变量 schedule 供外部调度器读取", Validation: &Validation{Pattern: `^\S+( \S+){4}$`}},
+			{Name: "level", Label: "通知级别", Type: ParamTypeString, Default: "info", Validation: &Validation{Enum: []string{"info", "warning", "critical"}}},
+		},
+		Workflow: Workflow{
+			ID:          "device-health-report",
+			Name:        "设备健康报告",
+			Description: "按计划 ${schedule} 汇总设备在线情况",
+			Version:     "1.0.0",
+			Config: WorkflowConfig{
+				Timeout:       2 * time.Minute,
+				ParallelLimit: 1,
+				Variables: map[string]interface{}{
+					"schedule": "${schedule}",
+					"level":    "${level}",
+				},
+			},
+			Nodes: []Node{
+				{ID: "start", Name: "开始", Type: NodeTypeStart, Position: Position{X: 0, Y: 100}},
+				{
+					ID: "devices", Name: "查询设备", Type: NodeTypeHTTP,
+					Config: map[string]interface{}{
+						"method":  "GET",
+						"url":     "${api_base_url}/api/v1/devices",
+						"query":   map[string]interface{}{"limit": "100"},
+						"headers": map[string]interface{}{"Authorization": "Bearer {{secret.${token_secret}}}"},
+						"timeout": "15s",
+						"retries": 2,
+						"extract": map[string]interface{}{
+							"devices": "body.data.devices ?? []",
+						},
+					},
+					Outputs:  []OutputSchema{{Name: "devices", Type: "array"}},
+					Position: Position{X: 200, Y: 100},
+				},
+				{
+					ID: "report", Name: "生成报告", Type: NodeTypeTransform,
+					Config: map[string]interface{}{
+						"mappings": map[string]interface{}{
+							"total":   "len(nodes.devices.devices)",
+							"offline": "nodes.devices.devices.filter(d, d.status != 'online').map(d, (d.device_name ?? '') + ' (' + d.device_id + ')')",
+							"title":   "'设备健康报告：' + string(len(nodes.devices.devices.filter(d, d.status == 'online'))) + '/' + string(len(nodes.devices.devices)) + ' 在线'",
+							"body":    "nodes.devices.devices.exists(d, d.status != 'online') ? '离线设备：\\n' + join(nodes.devices.devices.filter(d, d.status != 'online').map(d, (d.device_name ?? '') + ' (' + d.device_id + ')'), '\\n') : '全部设备在线'",
+						},
+					},
+					Outputs: []OutputSchema{
+						{Name: "total", Type: "number"},
+						{Name: "offline", Type: "array"},
+						{Name: "title", Type: "string"},
+						{Name: "body", Type: "string"},
+					},
+					Position: Position{X: 400, Y: 100},
+				},
+				{
+					ID: "notify", Name: "发送报告", Type: "notification",
+					Config: map[string]interface{}{
+						"channels": "${channels}",
+						"level":    "${level}",
+					},
+					Inputs: []InputSchema{
+						{Name: "title", Type: "string"},
+						{Name: "body", Type: "string"},
+					},
+					Position: Position{X: 600, Y: 100},
+				},
+				{ID: "end", Name: "结束", Type: NodeTypeEnd, Position: Position{X: 800, Y: 100}},
+			},
+			Edges: []Edge{
+				{ID: "e1", From: "start", To: "devices"},
+				{ID: "e2", From: "devices", To: "report"},
+				{ID: "e3", From: "report", To: "notify"},
+				{ID: "e4", From: "notify", To: "end"},
+			},
+		},
+	}
+}
@@ -0,0 +1,191 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// transformTimeout 转换节点单次求值的时间上限
+const transformTimeout = 2 * time.Second
+
+// 转换节点和 HTTP 节点表达式可引用的变量，节点声明的输入也可直接按名称引用
+const (
+	transformVarInputs  = "inputs"  // 按 node.Inputs 解析的输入
+	transformVarNodes   = "nodes"   // 直接上游节点的输出，nodes.<节点ID>.<输出名>
+	transformVarVars    = "vars"    // 工作流全局变量
+	transformVarContext = "context" // 执行上下文
+)
+
+// transformProgram 编译后的转换节点配置
+type transformProgram struct {
+	expression *Expression            // 结果为对象时作为输出，否则作为 result 输出
+	mappings   map[string]*Expression // 输出名 -> 表达式，覆盖 expression 中的同名输出
+}
+
+// expressionNames 返回节点表达式可引用的变量名
+func expressionNames(node *Node) []string {
+	names := []string{transformVarInputs, transformVarNodes, transformVarVars, transformVarContext}
+	for _, input := range node.Inputs {
+		names = append(names, input.Name)
+	}
+	return names
+}
+
+// compileTransform 编译转换节点的 expression 和 mappings 配置
+func compileTransform(node *Node) (*transformProgram, error) {
+	names := expressionNames(node)
+	program := &transformProgram{mappings: make(map[string]*Expression)}
+	if raw, ok := node.Config["expression"]; ok && raw != nil && raw != "" {
+		source, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("config.expression must be a string")
+		}
+		expr, err := CompileExpression(source, names)
+		if err != nil {
+			return nil, fmt.Errorf("config.expression: %w", err)
+		}
+		program.expression = expr
+	}
+
+	if raw, ok := node.Config["mappings"]; ok && raw != nil {
+		mappings, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("config.mappings must be an object of output name to expression")
+		}
+		for output, value := range mappings {
+			source, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("config.mappings.%s must be a string", output)
+			}
+			expr, err := CompileExpression(source, names)
+			if err != nil {
+				return nil, fmt.Errorf("config.mappings.%s: %w", output, err)
+			}
+			program.mappings[output] = expr
+		}
+	}
+
+	if program.expression == nil && len(program.mappings) == 0 {
+		return nil, fmt.Errorf("transform node requires config.expression or config.mappings")
+	}
+	return program, nil
+}
+
+// run 求值全部表达式并组装节点输出
+func (p *transformProgram) run(ctx context.Context, vars map[string]interface{}) (map[string]interface{}, error) {
+	outputs := make(map[string]interface{})
+	if p.expression != nil {
+		value, err := p.expression.Evaluate(ctx, vars)
+		if err != nil {
+			return nil, fmt.Errorf("config.expression: %w", err)
+		}
+		if m, ok := value.(map[string]interface{}); ok {
+			outputs = m
+		} else {
+			outputs["result"] = value
+		}
+	}
+
+	names := make([]string, 0, len(p.mappings))
+	for name := range p.mappings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value, err := p.mappings[name].Evaluate(ctx, vars)
+		if err != nil {
+			return nil, fmt.Errorf("config.mappings.%s: %w", name, err)
+		}
+		outputs[name] = value
+	}
+	return outputs, nil
+}
+
+// ValidateNodeExpressions 编译工作流中转换节点和 HTTP 节点的表达式与模板，并检查条件节点的在家条件，在保存和执行工作流前调用
+func ValidateNodeExpressions(workflow *Workflow) error {
+	for i := range workflow.Nodes {
+		node := &workflow.Nodes[i]
+		var err error
+		switch node.Type {
+		case NodeTypeTransform:
+			_, err = compileTransform(node)
+		case NodeTypeHTTP:
+			_, err = compileHTTPNode(node)
+		case NodeTypeCondition:
+			_, err = parsePresenceCondition(node)
+		default:
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s node %s: %w", node.Type, node.ID, err)
+		}
+	}
+	return nil
+}
+
+// executeTransformNode 执行转换节点，在沙箱中对上游输出求值表达式，不调用任何能力
+func (e *WorkflowExecutorImpl) executeTransformNode(ctx context.Context, workflow *Workflow, execution *Execution, node *Node, result *NodeResult) {
+	program, err := compileTransform(node)
+	if err != nil {
+		e.markNodeFailed(execution, node.ID, err.Error())
+		return
+	}
+
+	result.Timing = &NodeTiming{}
+	phaseStart := time.Now()
+	inputs, err := e.dataFlow.GetNodeInputs(execution, node, workflow)
+	result.Timing.Inputs = time.Since(phaseStart)
+	if err != nil {
+		e.markNodeFailed(execution, node.ID, fmt.Sprintf("Failed to get inputs: %v", err))
+		return
+	}
+	result.Inputs = inputs
+
+	vars := e.expressionVariables(workflow, execution, node, inputs)
+	evalCtx, cancel := context.WithTimeout(ctx, transformTimeout)
+	phaseStart = time.Now()
+	outputs, err := program.run(evalCtx, vars)
+	cancel()
+	result.Timing.Execution = time.Since(phaseStart)
+	if err != nil {
+		e.markNodeFailed(execution, node.ID, fmt.Sprintf("Transform failed: %v", err))
+		return
+	}
+	result.Outputs = outputs
+
+	phaseStart = time.Now()
+	err = e.validateNodeOutputs(node, result.Outputs)
+	result.Timing.Validation = time.Since(phaseStart)
+	if err != nil {
+		e.markNodeFailed(execution, node.ID, fmt.Sprintf("Output validation failed: %v", err))
+		return
+	}
+
+	e.markNodeCompleted(execution, result)
+}
+
+// expressionVariables 汇集节点表达式变量；值在求值前深拷贝，表达式无法修改执行状态
+func (e *WorkflowExecutorImpl) expressionVariables(workflow *Workflow, execution *Execution, node *Node, inputs map[string]interface{}) map[string]interface{} {
+	vars := make(map[string]interface{}, len(inputs)+4)
+	for name, value := range inputs {
+		vars[name] = value
+	}
+
+	e.executionMu.RLock()
+	upstream := make(map[string]interface{})
+	for _, depID := range e.dagEngine.GetNodeDependencies(node.ID, workflow.Edges) {
+		if dep, ok := execution.NodeResults[depID]; ok && dep.Status == NodeStatusCompleted {
+			upstream[depID] = normalizeExprValue(dep.Outputs)
+		}
+	}
+	execContext := normalizeExprValue(execution.Context)
+	e.executionMu.RUnlock()
+
+	vars[transformVarInputs] = inputs
+	vars[transformVarNodes] = upstream
+	vars[transformVarVars] = workflow.Config.Variables
+	vars[transformVarContext] = execContext
+	return vars
+}
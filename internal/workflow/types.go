@@ -3,6 +3,9 @@ package workflow
 import (
 	"context"
 	"time"
+
+	"xiaozhi-server-go/internal/plugin/capability"
+	"xiaozhi-server-go/internal/plugin/logs"
 )
 
 // NodeType 节点类型
@@ -15,6 +18,9 @@ const (
 	NodeTypeCondition NodeType = "condition" // 条件节点
 	NodeTypeParallel  NodeType = "parallel"  // 并行节点
 	NodeTypeMerge     NodeType = "merge"     // 合并节点
+	NodeTypeApproval  NodeType = "approval"  // 人工审批节点
+	NodeTypeTransform NodeType = "transform" // 数据转换节点
+	NodeTypeHTTP      NodeType = "http"      // HTTP 请求节点
 )
 
 // NodeStatus 节点状态
@@ -112,6 +118,8 @@ type WorkflowConfig struct {
 type Execution struct {
 	ID          string                 `json:"id"`
 	WorkflowID  string                 `json:"workflow_id"`
+	TenantID    string                 `json:"tenant_id,omitempty"`  // 发起执行的租户
+	RequestID   string                 `json:"request_id,omitempty"` // 发起执行的请求关联ID
 	Status      ExecutionStatus        `json:"status"`
 	StartTime   time.Time              `json:"start_time"`
 	EndTime     *time.Time             `json:"end_time,omitempty"`
@@ -121,6 +129,8 @@ type Execution struct {
 	Outputs     map[string]interface{} `json:"outputs"`      // 输出结果
 	Error       string                 `json:"error,omitempty"` // 执行错误
 	Logs        []ExecutionLog         `json:"logs"`         // 执行日志
+
+	inline bool // 由 Run 同步执行，不持久化
 }
 
 // ExecutionStatus 执行状态
@@ -145,6 +155,35 @@ type NodeResult struct {
 	Outputs     map[string]interface{} `json:"outputs"`
 	Error       string                 `json:"error,omitempty"`
 	ElapsedTime time.Duration          `json:"elapsed_time"`
+	Attempts    int                    `json:"attempts,omitempty"` // 能力调用次数，含重试
+	Timing      *NodeTiming            `json:"timing,omitempty"`
+	Logs        []NodeLog              `json:"logs,omitempty"`   // 节点日志，包括插件输出和重试记录
+	Traces      []capability.Trace     `json:"traces,omitempty"` // 能力调用的追踪信息，含提供者返回的请求ID
+}
+
+// NodeTiming 节点耗时分解
+type NodeTiming struct {
+	Queue      time.Duration `json:"queue"`      // 等待执行槽
+	Inputs     time.Duration `json:"inputs"`     // 解析输入
+	Execution  time.Duration `json:"execution"`  // 能力调用，含重试
+	RetryWait  time.Duration `json:"retry_wait"` // 重试前的等待，包含在 Execution 中
+	Validation time.Duration `json:"validation"` // 输出校验
+}
+
+// 节点日志来源
+const (
+	NodeLogSourceEngine = "engine" // 工作流引擎
+	NodeLogSourcePlugin = "plugin" // 插件输出
+)
+
+// NodeLog 节点日志
+type NodeLog struct {
+	Timestamp time.Time `json:"timestamp"`
+	Level     string    `json:"level"`
+	Source    string    `json:"source"`           // engine 或 plugin
+	Stream    string    `json:"stream,omitempty"` // 插件输出流：stdout、stderr
+	Attempt   int       `json:"attempt,omitempty"`
+	Message   string    `json:"message"`
 }
 
 // ExecutionLog 执行日志
@@ -242,12 +281,20 @@ type PluginStats struct {
 type WorkflowExecutor interface {
 	// 执行工作流
 	Execute(ctx context.Context, workflow *Workflow, inputs map[string]interface{}) (*Execution, error)
+	// 同步执行工作流并等待结束，执行记录不登记、不持久化
+	Run(ctx context.Context, workflow *Workflow, inputs map[string]interface{}) (*Execution, error)
 	// 取消执行
 	Cancel(executionID string) error
 	// 获取执行状态
 	GetExecution(executionID string) (*Execution, bool)
 	// 获取执行日志
 	GetExecutionLogs(executionID string) ([]ExecutionLog, error)
+	// 试运行：只解析和校验，不执行任何节点
+	DryRun(ctx context.Context, workflow *Workflow, inputs map[string]interface{}, opts DryRunOptions) *DryRunReport
+	// 设置插件日志来源，节点执行期间的插件输出记入节点日志
+	SetPluginLogs(manager *logs.Manager)
+	// 获取节点调度器状态
+	SchedulerStats() SchedulerStats
 }
 
 // DAGEngine DAG引擎接口
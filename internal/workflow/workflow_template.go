@@ -0,0 +1,496 @@
+package workflow
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrTemplateNotFound 工作流模板不存在
+var ErrTemplateNotFound = stderrors.New("workflow template not found")
+
+// 模板参数类型
+const (
+	ParamTypeString  = "string"
+	ParamTypeNumber  = "number"
+	ParamTypeInteger = "integer"
+	ParamTypeBoolean = "boolean"
+	ParamTypeArray   = "array"
+	ParamTypeObject  = "object"
+)
+
+var (
+	// paramPlaceholder 模板中的参数占位符 ${name}，$${ 转义为字面量 ${
+	paramPlaceholder = regexp.MustCompile(`\$?\$\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}`)
+	paramNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// TemplateParameter 模板参数声明
+type TemplateParameter struct {
+	Name        string      `json:"name"`
+	Label       string      `json:"label,omitempty"`
+	Type        string      `json:"type"` // string, number, integer, boolean, array, object
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required"`
+	Default     interface{} `json:"default,omitempty"`
+	Validation  *Validation `json:"validation,omitempty"`
+}
+
+// WorkflowTemplate 工作流模板
+// Workflow 中节点名称、描述、插件、方法、配置、输入默认值以及全局变量里的 ${参数名} 在实例化时替换为参数值：
+// 字符串恰好是一个占位符时保留参数的原始类型，否则按文本替换
+type WorkflowTemplate struct {
+	ID          string              `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Category    string              `json:"category,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Builtin     bool                `json:"builtin"`
+	Parameters  []TemplateParameter `json:"parameters"`
+	Workflow    Workflow            `json:"workflow"`
+}
+
+// TemplateParameterError 模板参数校验错误
+type TemplateParameterError struct {
+	Parameter string
+	Message   string
+}
+
+func (e *TemplateParameterError) Error() string {
+	return fmt.Sprintf("parameter %s: %s", e.Parameter, e.Message)
+}
+
+// Validate 检查模板定义：参数声明合法、默认值通过校验、占位符只引用已声明的参数
+func (t *WorkflowTemplate) Validate() error {
+	if t.ID == "" {
+		return fmt.Errorf("template ID is required")
+	}
+	declared := make(map[string]bool, len(t.Parameters))
+	for _, param := range t.Parameters {
+		if !paramNamePattern.MatchString(param.Name) {
+			return fmt.Errorf("invalid parameter name %q", param.Name)
+		}
+		if declared[param.Name] {
+			return fmt.Errorf("duplicate parameter %s", param.Name)
+		}
+		declared[param.Name] = true
+		switch param.Type {
+		case ParamTypeString, ParamTypeNumber, ParamTypeInteger, ParamTypeBoolean, ParamTypeArray, ParamTypeObject:
+		default:
+			return fmt.Errorf("parameter %s has invalid type %q", param.Name, param.Type)
+		}
+		if param.Validation != nil && param.Validation.Pattern != "" {
+			if _, err := regexp.Compile(param.Validation.Pattern); err != nil {
+				return fmt.Errorf("parameter %s has invalid pattern: %v", param.Name, err)
+			}
+		}
+		if param.Default != nil {
+			if _, err := param.check(param.Default); err != nil {
+				return fmt.Errorf("default of %w", err)
+			}
+		}
+	}
+
+	var missing []string
+	collectPlaceholders(templateFields(&t.Workflow), func(name string) {
+		if !declared[name] {
+			missing = append(missing, name)
+		}
+	})
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("undeclared parameters referenced: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// ResolveParameters 合并默认值并校验参数，返回参数名到值的映射；未声明的参数视为错误
+func (t *WorkflowTemplate) ResolveParameters(values map[string]interface{}) (map[string]interface{}, error) {
+	declared := make(map[string]bool, len(t.Parameters))
+	resolved := make(map[string]interface{}, len(t.Parameters))
+	for _, param := range t.Parameters {
+		declared[param.Name] = true
+		value, ok := values[param.Name]
+		if !ok || value == nil {
+			if param.Default == nil {
+				if param.Required {
+					return nil, &TemplateParameterError{Parameter: param.Name, Message: "is required"}
+				}
+				resolved[param.Name] = zeroParamValue(param.Type)
+				continue
+			}
+			value = param.Default
+		}
+		checked, err := param.check(value)
+		if err != nil {
+			return nil, err
+		}
+		resolved[param.Name] = checked
+	}
+
+	for name := range values {
+		if !declared[name] {
+			return nil, &TemplateParameterError{Parameter: name, Message: "is not declared by template " + t.ID}
+		}
+	}
+	return resolved, nil
+}
+
+// Instantiate 用参数值生成工作流，模板本身不会被修改
+func (t *WorkflowTemplate) Instantiate(values map[string]interface{}) (*Workflow, error) {
+	params, err := t.ResolveParameters(values)
+	if err != nil {
+		return nil, err
+	}
+
+	// 经 JSON 深拷贝，替换不会影响模板中的 map 和切片
+	data, err := json.Marshal(&t.Workflow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy template workflow: %w", err)
+	}
+	var wf Workflow
+	if err := json.Unmarshal(data, &wf); err != nil {
+		return nil, fmt.Errorf("failed to copy template workflow: %w", err)
+	}
+
+	substituteFields(&wf, params)
+	now := time.Now()
+	wf.CreatedAt = now
+	wf.UpdatedAt = now
+	return &wf, nil
+}
+
+// check 校验参数值的类型和规则，返回规范化后的值
+func (p TemplateParameter) check(value interface{}) (interface{}, error) {
+	fail := func(format string, args ...interface{}) (interface{}, error) {
+		return nil, &TemplateParameterError{Parameter: p.Name, Message: fmt.Sprintf(format, args...)}
+	}
+	rules := p.Validation
+	if rules == nil {
+		rules = &Validation{}
+	}
+
+	switch p.Type {
+	case ParamTypeString:
+		s, ok := value.(string)
+		if !ok {
+			return fail("must be a string")
+		}
+		if rules.MinLength != nil && len([]rune(s)) < *rules.MinLength {
+			return fail("must be at least %d characters", *rules.MinLength)
+		}
+		if rules.MaxLength != nil && len([]rune(s)) > *rules.MaxLength {
+			return fail("must be at most %d characters", *rules.MaxLength)
+		}
+		if rules.Pattern != "" {
+			re, err := regexp.Compile(rules.Pattern)
+			if err != nil {
+				return fail("has invalid pattern: %v", err)
+			}
+			if !re.MatchString(s) {
+				return fail("must match %s", rules.Pattern)
+			}
+		}
+		if len(rules.Enum) > 0 {
+			for _, allowed := range rules.Enum {
+				if s == allowed {
+					return s, nil
+				}
+			}
+			return fail("must be one of %s", strings.Join(rules.Enum, ", "))
+		}
+		return s, nil
+	case ParamTypeNumber, ParamTypeInteger:
+		n, ok := toParamNumber(value)
+		if !ok {
+			return fail("must be a number")
+		}
+		if p.Type == ParamTypeInteger && n != math.Trunc(n) {
+			return fail("must be an integer")
+		}
+		if rules.Min != nil && n < *rules.Min {
+			return fail("must be >= %v", *rules.Min)
+		}
+		if rules.Max != nil && n > *rules.Max {
+			return fail("must be <= %v", *rules.Max)
+		}
+		return n, nil
+	case ParamTypeBoolean:
+		b, ok := value.(bool)
+		if !ok {
+			return fail("must be a boolean")
+		}
+		return b, nil
+	case ParamTypeArray:
+		items, ok := normalizeExprValue(value).([]interface{})
+		if !ok {
+			return fail("must be an array")
+		}
+		if rules.MinLength != nil && len(items) < *rules.MinLength {
+			return fail("must have at least %d items", *rules.MinLength)
+		}
+		if rules.MaxLength != nil && len(items) > *rules.MaxLength {
+			return fail("must have at most %d items", *rules.MaxLength)
+		}
+		return items, nil
+	case ParamTypeObject:
+		m, ok := normalizeExprValue(value).(map[string]interface{})
+		if !ok {
+			return fail("must be an object")
+		}
+		return m, nil
+	}
+	return fail("has invalid type %q", p.Type)
+}
+
+func toParamNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func zeroParamValue(paramType string) interface{} {
+	switch paramType {
+	case ParamTypeString:
+		return ""
+	case ParamTypeNumber, ParamTypeInteger:
+		return float64(0)
+	case ParamTypeBoolean:
+		return false
+	case ParamTypeArray:
+		return []interface{}{}
+	case ParamTypeObject:
+		return map[string]interface{}{}
+	}
+	return nil
+}
+
+// templateFields 返回工作流中支持参数替换的字段
+func templateFields(wf *Workflow) []interface{} {
+	fields := []interface{}{wf.Name, wf.Description, wf.Config.Variables}
+	for _, node := range wf.Nodes {
+		fields = append(fields, node.Name, node.Description, node.Plugin, node.Method, node.Config)
+		for _, input := range node.Inputs {
+			fields = append(fields, input.Default)
+		}
+	}
+	return fields
+}
+
+// collectPlaceholders 遍历值中的全部 ${name} 占位符
+func collectPlaceholders(value interface{}, visit func(name string)) {
+	switch v := value.(type) {
+	case string:
+		for _, match := range paramPlaceholder.FindAllStringSubmatch(v, -1) {
+			if !strings.HasPrefix(match[0], "$$") {
+				visit(match[1])
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			collectPlaceholders(item, visit)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			collectPlaceholders(item, visit)
+		}
+	}
+}
+
+// substituteFields 替换工作流中可参数化字段的占位符
+func substituteFields(wf *Workflow, params map[string]interface{}) {
+	wf.Name = substituteText(wf.Name, params)
+	wf.Description = substituteText(wf.Description, params)
+	if wf.Config.Variables != nil {
+		wf.Config.Variables = substituteValue(wf.Config.Variables, params).(map[string]interface{})
+	}
+	for i := range wf.Nodes {
+		node := &wf.Nodes[i]
+		node.Name = substituteText(node.Name, params)
+		node.Description = substituteText(node.Description, params)
+		node.Plugin = substituteText(node.Plugin, params)
+		node.Method = substituteText(node.Method, params)
+		if node.Config != nil {
+			node.Config = substituteValue(node.Config, params).(map[string]interface{})
+		}
+		for j := range node.Inputs {
+			if node.Inputs[j].Default != nil {
+				node.Inputs[j].Default = substituteValue(node.Inputs[j].Default, params)
+			}
+		}
+	}
+}
+
+// substituteValue 递归替换占位符，恰好为单个占位符的字符串替换为参数的原始值
+func substituteValue(value interface{}, params map[string]interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if match := paramPlaceholder.FindStringSubmatch(v); match != nil && match[0] == v && !strings.HasPrefix(v, "$$") {
+			return params[match[1]]
+		}
+		return substituteText(v, params)
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = substituteValue(item, params)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[key] = substituteValue(item, params)
+		}
+		return result
+	}
+	return value
+}
+
+// substituteText 按文本替换占位符，非字符串参数按表达式语言的字符串形式写入
+func substituteText(text string, params map[string]interface{}) string {
+	if !strings.Contains(text, "${") {
+		return text
+	}
+	return paramPlaceholder.ReplaceAllStringFunc(text, func(match string) string {
+		if strings.HasPrefix(match, "$$") {
+			return match[1:]
+		}
+		name := paramPlaceholder.FindStringSubmatch(match)[1]
+		value, ok := params[name]
+		if !ok || value == nil {
+			return ""
+		}
+		return exprToString(value)
+	})
+}
+
+// TemplateRegistry 工作流模板注册表
+type TemplateRegistry struct {
+	mu        sync.RWMutex
+	templates map[string]*WorkflowTemplate
+}
+
+// NewTemplateRegistry 创建模板注册表
+func NewTemplateRegistry() *TemplateRegistry {
+	return &TemplateRegistry{templates: make(map[string]*WorkflowTemplate)}
+}
+
+// Register 注册模板；同ID模板已存在时替换
+func (r *TemplateRegistry) Register(t *WorkflowTemplate) error {
+	if t == nil {
+		return fmt.Errorf("template is nil")
+	}
+	if err := t.Validate(); err != nil {
+		return fmt.Errorf("template %s: %w", t.ID, err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.templates[t.ID] = t
+	return nil
+}
+
+// Get 获取模板
+func (r *TemplateRegistry) Get(id string) (*WorkflowTemplate, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.templates[id]
+	return t, ok
+}
+
+// List 返回全部模板，按分类和ID排序
+func (r *TemplateRegistry) List() []*WorkflowTemplate {
+	r.mu.RLock()
+	items := make([]*WorkflowTemplate, 0, len(r.templates))
+	for _, t := range r.templates {
+		items = append(items, t)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Category != items[j].Category {
+			return items[i].Category < items[j].Category
+		}
+		return items[i].ID < items[j].ID
+	})
+	return items
+}
+
+var (
+	defaultTemplateRegistry   = newBuiltinTemplateRegistry()
+	defaultTemplateRegistryMu sync.RWMutex
+)
+
+// newBuiltinTemplateRegistry 创建包含内置模板库的注册表
+func newBuiltinTemplateRegistry() *TemplateRegistry {
+	r := NewTemplateRegistry()
+	for _, t := range BuiltinTemplates() {
+		if err := r.Register(t); err != nil {
+			logger.Error("Failed to register builtin workflow template", "template", t.ID, "error", err)
+		}
+	}
+	return r
+}
+
+// SetDefaultTemplateRegistry 设置全局模板注册表
+func SetDefaultTemplateRegistry(r *TemplateRegistry) {
+	defaultTemplateRegistryMu.Lock()
+	defer defaultTemplateRegistryMu.Unlock()
+	if r == nil {
+		r = NewTemplateRegistry()
+	}
+	defaultTemplateRegistry = r
+}
+
+// DefaultTemplateRegistry 返回全局模板注册表，默认包含内置模板库
+func DefaultTemplateRegistry() *TemplateRegistry {
+	defaultTemplateRegistryMu.RLock()
+	defer defaultTemplateRegistryMu.RUnlock()
+	return defaultTemplateRegistry
+}
+
+// InstantiateTemplate 用全局模板注册表中的模板生成工作流并校验，不保存
+func InstantiateTemplate(id string, values map[string]interface{}) (*Workflow, error) {
+	t, ok := DefaultTemplateRegistry().Get(id)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, id)
+	}
+	wf, err := t.Instantiate(values)
+	if err != nil {
+		return nil, err
+	}
+	if err := NewDAGEngine(logger).ValidateWorkflow(wf); err != nil {
+		return nil, fmt.Errorf("template %s produced an invalid workflow: %w", id, err)
+	}
+	if err := ValidateNodeExpressions(wf); err != nil {
+		return nil, fmt.Errorf("template %s produced an invalid workflow: %w", id, err)
+	}
+	return wf, nil
+}
+
+// DeployFromTemplate 用模板和参数生成工作流并保存为当前工作流
+func DeployFromTemplate(id string, values map[string]interface{}) (*Workflow, error) {
+	wf, err := InstantiateTemplate(id, values)
+	if err != nil {
+		return nil, err
+	}
+	if err := SaveWorkflow(wf); err != nil {
+		return nil, err
+	}
+	return wf, nil
+}
@@ -0,0 +1,5415 @@
+// Code generated by xiaozhi-apigen from internal/platform/docs/swagger.json. DO NOT EDIT.
+
+import axios, { type AxiosInstance } from 'axios';
+
+// 接口返回的失败响应
+export class ApiError extends Error {
+  status: number;
+  code: string;
+  details: unknown;
+
+  constructor(status: number, code: string, message: string, details?: unknown) {
+    super(message);
+    this.name = 'ApiError';
+    this.status = status;
+    this.code = code;
+    this.details = details;
+  }
+}
+
+// 统一响应结构
+interface Envelope<T> {
+  success: boolean;
+  data?: T;
+  message?: string;
+  error?: { code: string; message: string; details?: unknown };
+}
+
+/**
+ * /api/v1 客户端，方法直接返回响应中的 data，失败时抛出 ApiError
+ */
+export class ApiClient {
+  private http: AxiosInstance;
+
+  constructor(baseURL = '/api', http?: AxiosInstance) {
+    this.http = http ?? axios.create({ baseURL });
+  }
+
+  private async request<T>(
+    method: string,
+    url: string,
+    params?: object,
+    data?: unknown,
+  ): Promise<T> {
+    try {
+      const response = await this.http.request<Envelope<T>>({
+        method,
+        url,
+        params,
+        data,
+      });
+      const body = response.data;
+      if (!body.success) {
+        throw new ApiError(
+          response.status,
+          body.error?.code ?? '',
+          body.error?.message ?? body.message ?? '',
+          body.error?.details,
+        );
+      }
+      return body.data as T;
+    } catch (error) {
+      if (axios.isAxiosError(error) && error.response) {
+        const body = error.response.data as Envelope<unknown> | undefined;
+        throw new ApiError(
+          error.response.status,
+          body?.error?.code ?? '',
+          body?.error?.message ?? error.message,
+          body?.error?.details,
+        );
+      }
+      throw error;
+    }
+  }
+
+  /**
+   * 获取智能体列表
+   * GET /v1/agents
+   */
+  getAgents(): Promise<AgentInfo[]> {
+    return this.request<AgentInfo[]>('GET', '/v1/agents');
+  }
+
+  /**
+   * 创建智能体
+   * 创建有独立提示词、模型和工具白名单的智能体；配置 delegates 的智能体可以把子任务委托给其他智能体
+   * POST /v1/agents
+   */
+  postAgents(body: AgentCreateRequest): Promise<AgentInfo> {
+    return this.request<AgentInfo>('POST', '/v1/agents', undefined, body);
+  }
+
+  /**
+   * 删除智能体
+   * 被其他智能体委托的智能体不能删除
+   * DELETE /v1/agents/{id}
+   */
+  deleteAgentsById(id: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/agents/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 获取智能体详情
+   * GET /v1/agents/{id}
+   */
+  getAgentsById(id: string): Promise<AgentInfo> {
+    return this.request<AgentInfo>('GET', `/v1/agents/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 更新智能体
+   * 被其他智能体委托的智能体不能改名
+   * PUT /v1/agents/{id}
+   */
+  putAgentsById(id: string, body: AgentUpdateRequest): Promise<AgentInfo> {
+    return this.request<AgentInfo>('PUT', `/v1/agents/${encodeURIComponent(id)}`, undefined, body);
+  }
+
+  /**
+   * 运行智能体
+   * 以给定输入运行智能体，返回回复以及按顺序记录的工具调用和委托
+   * POST /v1/agents/{id}/run
+   */
+  postAgentsByIdRun(id: string, body: AgentRunRequest): Promise<AgentRunResult> {
+    return this.request<AgentRunResult>('POST', `/v1/agents/${encodeURIComponent(id)}/run`, undefined, body);
+  }
+
+  /**
+   * 按天或按周列出用量汇总
+   * 返回后台定期预先汇总的对话数、平均延迟、Token和语音用量、热门意图、各提供者的出错比例和设备活跃情况，按周期起始日正序；不会在请求时扫描对话记录
+   * GET /v1/analytics/rollups
+   */
+  getAnalyticsRollups(params?: GetAnalyticsRollupsParams): Promise<AnalyticsRollup[]> {
+    return this.request<AnalyticsRollup[]>('GET', '/v1/analytics/rollups', params);
+  }
+
+  /**
+   * 查询范围内的用量合计
+   * 把查询范围内的按天汇总合并为一个汇总，活跃设备数按设备去重
+   * GET /v1/analytics/summary
+   */
+  getAnalyticsSummary(params?: GetAnalyticsSummaryParams): Promise<AnalyticsRollup> {
+    return this.request<AnalyticsRollup>('GET', '/v1/analytics/summary', params);
+  }
+
+  /**
+   * List approval tasks
+   * GET /v1/approvals
+   */
+  getApprovals(params?: GetApprovalsParams): Promise<ApprovalTask[]> {
+    return this.request<ApprovalTask[]>('GET', '/v1/approvals', params);
+  }
+
+  /**
+   * Get approval task
+   * GET /v1/approvals/{id}
+   */
+  getApprovalsById(id: string): Promise<ApprovalTask> {
+    return this.request<ApprovalTask>('GET', `/v1/approvals/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * Decide approval task
+   * Approves or rejects a pending task, which resumes or aborts the waiting execution. Deciding an already decided task returns 409 with the task in error.details
+   * POST /v1/approvals/{id}/decision
+   */
+  postApprovalsByIdDecision(id: string, body: ApprovalDecisionRequest): Promise<ApprovalTask> {
+    return this.request<ApprovalTask>('POST', `/v1/approvals/${encodeURIComponent(id)}/decision`, undefined, body);
+  }
+
+  /**
+   * 获取备份列表
+   * 列出备份目录中的备份文件，最新的在前，并返回自动备份设置
+   * GET /v1/backups
+   */
+  getBackups(): Promise<BackupListResponse> {
+    return this.request<BackupListResponse>('GET', '/v1/backups');
+  }
+
+  /**
+   * 创建备份
+   * 对数据库做快照，连同 Backup.Paths 中的文件和目录打包并加密保存到备份目录。
+   * 手动创建的备份不参与自动备份的保留数量清理；已有备份或恢复在进行时返回 409
+   * POST /v1/backups
+   */
+  postBackups(): Promise<BackupInfo> {
+    return this.request<BackupInfo>('POST', '/v1/backups');
+  }
+
+  /**
+   * 删除备份
+   * DELETE /v1/backups/{name}
+   */
+  deleteBackupsByName(name: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/backups/${encodeURIComponent(name)}`);
+  }
+
+  /**
+   * 下载备份
+   * 下载加密的备份文件，可用 xiaozhi-server restore 在其他机器上恢复
+   * GET /v1/backups/{name}
+   */
+  getBackupsByName(name: string): Promise<unknown> {
+    return this.request<unknown>('GET', `/v1/backups/${encodeURIComponent(name)}`);
+  }
+
+  /**
+   * 从备份恢复
+   * 校验备份并解包到恢复暂存目录，服务下次启动时在打开数据库之前替换数据库和备份中的文件，
+   * 被替换的文件改名为 *.pre-restore-<时间> 保留。校验不通过时不会暂存任何内容
+   * POST /v1/backups/{name}/restore
+   */
+  postBackupsByNameRestore(name: string, body?: BackupPassphraseRequest): Promise<BackupRestoreResponse> {
+    return this.request<BackupRestoreResponse>('POST', `/v1/backups/${encodeURIComponent(name)}/restore`, undefined, body);
+  }
+
+  /**
+   * 校验备份
+   * 解密备份并逐一核对清单中每个文件的大小和 SHA-256，不修改任何数据。口令错误或内容被篡改时返回 400
+   * POST /v1/backups/{name}/verify
+   */
+  postBackupsByNameVerify(name: string, body?: BackupPassphraseRequest): Promise<BackupVerifyResponse> {
+    return this.request<BackupVerifyResponse>('POST', `/v1/backups/${encodeURIComponent(name)}/verify`, undefined, body);
+  }
+
+  /**
+   * 文本对话
+   * 与语音设备使用相同的提示词、对话流水线和工具进行文本对话。关联的设备在线时进入设备当前的会话，共用对话历史；
+   * 否则创建不连接设备的会话，返回的 session_id 用于继续对话。stream=true 或 Accept: text/event-stream 时以 SSE 推送，
+   * 事件 segment 为回复分段，done 为完整回复（ChatResponse），error 为错误信息
+   * POST /v1/chat
+   */
+  postChat(body: ChatRequest): Promise<ChatResponse> {
+    return this.request<ChatResponse>('POST', '/v1/chat', undefined, body);
+  }
+
+  /**
+   * 应用配置清单
+   * 将供应商、提示词模板、设备分组和当前工作流调整为清单描述的状态。清单中出现的部分（包括空列表）视为该类资源的完整期望状态，多余的同类资源会被删除；未出现的部分保持不变。dry_run=true 时只返回变更计划
+   * POST /v1/config/apply
+   */
+  postConfigApply(body: Manifest, params?: PostConfigApplyParams): Promise<Plan> {
+    return this.request<Plan>('POST', '/v1/config/apply', params, body);
+  }
+
+  /**
+   * 导出配置
+   * 导出数据库中保存的完整服务配置，可直接用于导入
+   * GET /v1/config/export
+   */
+  getConfigExport(): Promise<void> {
+    return this.request<void>('GET', '/v1/config/export');
+  }
+
+  /**
+   * 导入配置
+   * 将导出的配置写回数据库；请求中未出现的字段保持当前值，未知字段视为错误。多数配置在重启服务后生效
+   * POST /v1/config/import
+   */
+  postConfigImport(body: Record<string, unknown>): Promise<void> {
+    return this.request<void>('POST', '/v1/config/import', undefined, body);
+  }
+
+  /**
+   * 测试提供者网络连通性
+   * 按提供者的网络配置（HTTPClient.Providers 叠加请求中的 network）向 url 发送 HEAD 请求，经过配置的代理、端点改写和CA证书，返回实际请求的地址、代理、对端地址和TLS版本；收到任意HTTP响应即为成功
+   * POST /v1/config/providers/connectivity
+   */
+  postConfigProvidersConnectivity(body: ProviderConnectivityRequest): Promise<CheckResult> {
+    return this.request<CheckResult>('POST', '/v1/config/providers/connectivity', undefined, body);
+  }
+
+  /**
+   * 测试供应商配置
+   * 使用给定配置对能力执行一次最小调用（LLM 发送 ping，TTS 合成短文本，其它类型仅校验执行器可创建），测试失败时 success 为 false
+   * POST /v1/config/providers/test
+   */
+  postConfigProvidersTest(body: ProviderTestRequest): Promise<ProviderTestResult> {
+    return this.request<ProviderTestResult>('POST', '/v1/config/providers/test', undefined, body);
+  }
+
+  /**
+   * 获取会话列表
+   * 按设备、会话、时间范围过滤会话，支持按消息内容搜索
+   * GET /v1/conversations
+   */
+  getConversations(params?: GetConversationsParams): Promise<ConversationListResponse> {
+    return this.request<ConversationListResponse>('GET', '/v1/conversations', params);
+  }
+
+  /**
+   * 删除会话
+   * DELETE /v1/conversations/{session_id}
+   */
+  deleteConversationsBySessionId(sessionId: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/conversations/${encodeURIComponent(sessionId)}`);
+  }
+
+  /**
+   * 获取会话详情
+   * 获取会话内的全部消息，包括用户语句、助手回复、工具调用和耗时，以及各轮的分阶段耗时
+   * GET /v1/conversations/{session_id}
+   */
+  getConversationsBySessionId(sessionId: string): Promise<ConversationDetailResponse> {
+    return this.request<ConversationDetailResponse>('GET', `/v1/conversations/${encodeURIComponent(sessionId)}`);
+  }
+
+  /**
+   * 导出会话
+   * 以 JSON 或 Markdown 格式下载会话记录
+   * GET /v1/conversations/{session_id}/export
+   */
+  getConversationsBySessionIdExport(sessionId: string, params?: GetConversationsBySessionIDExportParams): Promise<unknown> {
+    return this.request<unknown>('GET', `/v1/conversations/${encodeURIComponent(sessionId)}/export`, params);
+  }
+
+  /**
+   * 提交消息反馈
+   * 对助手回复点赞/点踩或提交文字反馈，反馈会计入所在A/B实验的指标
+   * POST /v1/conversations/{session_id}/messages/{message_id}/feedback
+   */
+  postConversationsBySessionIdMessagesByMessageIdFeedback(sessionId: string, messageId: string, body: FeedbackRequest): Promise<FeedbackInfo> {
+    return this.request<FeedbackInfo>('POST', `/v1/conversations/${encodeURIComponent(sessionId)}/messages/${encodeURIComponent(messageId)}/feedback`, undefined, body);
+  }
+
+  /**
+   * 清空故障注入规则
+   * DELETE /v1/debug/chaos/rules
+   */
+  deleteDebugChaosRules(): Promise<ChaosClearResponse> {
+    return this.request<ChaosClearResponse>('DELETE', '/v1/debug/chaos/rules');
+  }
+
+  /**
+   * 获取故障注入规则
+   * 返回仍在有效期内、未达到次数上限的规则及其已注入次数
+   * GET /v1/debug/chaos/rules
+   */
+  getDebugChaosRules(): Promise<ChaosRuleListResponse> {
+    return this.request<ChaosRuleListResponse>('GET', '/v1/debug/chaos/rules');
+  }
+
+  /**
+   * 添加故障注入规则
+   * 对匹配的能力调用、插件 gRPC 调用或提供者 HTTP 请求按概率注入延迟（delay）、错误（error）或中途中断（kill）；多条规则按添加顺序取第一条命中的规则，到期或达到次数上限后自动失效
+   * POST /v1/debug/chaos/rules
+   */
+  postDebugChaosRules(body: ChaosRuleRequest): Promise<ChaosRuleInfo> {
+    return this.request<ChaosRuleInfo>('POST', '/v1/debug/chaos/rules', undefined, body);
+  }
+
+  /**
+   * 删除故障注入规则
+   * DELETE /v1/debug/chaos/rules/{id}
+   */
+  deleteDebugChaosRulesById(id: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/debug/chaos/rules/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 搜索提供者调用录制
+   * 按提供者、能力、设备、调用结果、时间范围和文本搜索采样录制的能力调用，新记录在前；列表不含请求和响应
+   * GET /v1/debug/provider-calls
+   */
+  getDebugProviderCalls(params?: GetDebugProviderCallsParams): Promise<ProviderCallListResponse> {
+    return this.request<ProviderCallListResponse>('GET', '/v1/debug/provider-calls', params);
+  }
+
+  /**
+   * 获取提供者调用录制详情
+   * 返回录制的完整请求和响应，密钥类字段已替换为 ******，超出长度上限的部分已截断
+   * GET /v1/debug/provider-calls/{id}
+   */
+  getDebugProviderCallsById(id: string): Promise<ProviderCallInfo> {
+    return this.request<ProviderCallInfo>('GET', `/v1/debug/provider-calls/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 获取远程调试审计记录
+   * 按设备、操作和时间范围过滤远程调试操作，包括失败和被拒绝的操作，新记录在前
+   * GET /v1/device-debug/audit
+   */
+  getDeviceDebugAudit(params?: GetDeviceDebugAuditParams): Promise<DeviceDebugAuditListResponse> {
+    return this.request<DeviceDebugAuditListResponse>('GET', '/v1/device-debug/audit', params);
+  }
+
+  /**
+   * 获取设备列表
+   * 获取设备列表，支持分页和过滤
+   * GET /v1/devices
+   */
+  getDevices(params?: GetDevicesParams): Promise<DeviceListResponse> {
+    return this.request<DeviceListResponse>('GET', '/v1/devices', params);
+  }
+
+  /**
+   * 设备注册
+   * 新设备注册到系统
+   * POST /v1/devices
+   */
+  postDevices(body: DeviceRegistrationRequest): Promise<DeviceInfo> {
+    return this.request<DeviceInfo>('POST', '/v1/devices', undefined, body);
+  }
+
+  /**
+   * 管理员激活/禁用设备
+   * 管理员快速激活或禁用设备，通过设备MAC地址和激活状态
+   * POST /v1/devices/status
+   */
+  postDevicesStatus(body: DeviceStatusRequest): Promise<DeviceStatusResponse> {
+    return this.request<DeviceStatusResponse>('POST', '/v1/devices/status', undefined, body);
+  }
+
+  /**
+   * 删除设备
+   * 从系统中删除指定设备
+   * DELETE /v1/devices/{id}
+   */
+  deleteDevicesById(id: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/devices/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 获取设备详情
+   * 根据ID获取设备的详细信息，设备正在播放或暂停中的媒体在 media 字段返回
+   * GET /v1/devices/{id}
+   */
+  getDevicesById(id: string): Promise<DeviceInfo> {
+    return this.request<DeviceInfo>('GET', `/v1/devices/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 更新设备信息
+   * 更新指定设备的信息
+   * PUT /v1/devices/{id}
+   */
+  putDevicesById(id: string, body: DeviceUpdateRequest): Promise<DeviceInfo> {
+    return this.request<DeviceInfo>('PUT', `/v1/devices/${encodeURIComponent(id)}`, undefined, body);
+  }
+
+  /**
+   * 激活设备
+   * 用 ACT_<设备ID> 形式的手动激活码激活已注册的设备，已被扫码配对（/v1/provisioning/claim）取代，可通过 Provisioning.LegacyActivationCode 关闭
+   * POST /v1/devices/{id}/activate
+   */
+  postDevicesByIdActivate(id: string, body: DeviceActivationRequest): Promise<DeviceActivationResponse> {
+    return this.request<DeviceActivationResponse>('POST', `/v1/devices/${encodeURIComponent(id)}/activate`, undefined, body);
+  }
+
+  /**
+   * 删除设备的全部用户数据
+   * 删除设备产生的录音、语音归档授权、对话记录和反馈，设备本身及绑定关系不受影响
+   * DELETE /v1/devices/{id}/data
+   */
+  deleteDevicesByIdData(id: string): Promise<DeviceDataErasureResponse> {
+    return this.request<DeviceDataErasureResponse>('DELETE', `/v1/devices/${encodeURIComponent(id)}/data`);
+  }
+
+  /**
+   * 查询设备诊断命令
+   * 返回固件定义的诊断命令及其参数说明，格式由固件决定；操作记入审计日志
+   * GET /v1/devices/{id}/debug/commands
+   */
+  getDevicesByIdDebugCommands(id: string): Promise<DeviceDebugResult> {
+    return this.request<DeviceDebugResult>('GET', `/v1/devices/${encodeURIComponent(id)}/debug/commands`);
+  }
+
+  /**
+   * 执行设备诊断命令
+   * 在设备上执行固件定义的诊断命令并返回结果；操作记入审计日志
+   * POST /v1/devices/{id}/debug/commands/{command}
+   */
+  postDevicesByIdDebugCommandsByCommand(id: string, command: string, body?: DeviceDebugRunRequest): Promise<DeviceDebugResult> {
+    return this.request<DeviceDebugResult>('POST', `/v1/devices/${encodeURIComponent(id)}/debug/commands/${encodeURIComponent(command)}`, undefined, body);
+  }
+
+  /**
+   * 下发设备临时配置
+   * 临时覆盖设备配置，到期或设备重启后由设备恢复原配置；操作记入审计日志
+   * PUT /v1/devices/{id}/debug/config
+   */
+  putDevicesByIdDebugConfig(id: string, body: DeviceDebugConfigRequest): Promise<DeviceDebugResult> {
+    return this.request<DeviceDebugResult>('PUT', `/v1/devices/${encodeURIComponent(id)}/debug/config`, undefined, body);
+  }
+
+  /**
+   * 读取设备日志
+   * 经设备连接读取设备最近的日志；设备需在线、在 hello 中声明 debug 特性并通过 remote_debug 功能开关允许，操作记入审计日志
+   * GET /v1/devices/{id}/debug/logs
+   */
+  getDevicesByIdDebugLogs(id: string, params?: GetDevicesByIDDebugLogsParams): Promise<DeviceDebugResult> {
+    return this.request<DeviceDebugResult>('GET', `/v1/devices/${encodeURIComponent(id)}/debug/logs`, params);
+  }
+
+  /**
+   * 获取设备密钥
+   * 列出设备的全部密钥（只含公钥），新密钥在前；设备有未吊销的密钥时，连接 WebSocket 必须携带用其中之一签名的 JWT
+   * GET /v1/devices/{id}/keys
+   */
+  getDevicesByIdKeys(id: string): Promise<DeviceKeyInfo[]> {
+    return this.request<DeviceKeyInfo[]>('GET', `/v1/devices/${encodeURIComponent(id)}/keys`);
+  }
+
+  /**
+   * 签发设备密钥
+   * 为设备签发新的 P-256 密钥，私钥只返回一次；设备此后改用 JWT 认证，原静态令牌失效。revoke_previous 为 true 时同时吊销其他密钥，否则旧密钥在吊销前仍可使用，便于设备切换
+   * POST /v1/devices/{id}/keys
+   */
+  postDevicesByIdKeys(id: string, body?: DeviceKeyIssueRequest): Promise<IssuedDeviceKeyInfo> {
+    return this.request<IssuedDeviceKeyInfo>('POST', `/v1/devices/${encodeURIComponent(id)}/keys`, undefined, body);
+  }
+
+  /**
+   * 吊销设备密钥
+   * 吊销后用该密钥签名的 JWT 在 WebSocket 握手时被拒绝；已建立的连接不受影响
+   * DELETE /v1/devices/{id}/keys/{key_id}
+   */
+  deleteDevicesByIdKeysByKeyId(id: string, keyId: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/devices/${encodeURIComponent(id)}/keys/${encodeURIComponent(keyId)}`);
+  }
+
+  /**
+   * 恢复设备的默认允许语言
+   * 删除设备单独设置的允许语言，之后使用 Language.Allowed，设备重新连接后生效
+   * DELETE /v1/devices/{id}/languages
+   */
+  deleteDevicesByIdLanguages(id: string): Promise<DeviceLanguagesInfo> {
+    return this.request<DeviceLanguagesInfo>('DELETE', `/v1/devices/${encodeURIComponent(id)}/languages`);
+  }
+
+  /**
+   * 获取设备允许的语言
+   * 未单独设置的设备返回配置的默认值（Language.Allowed）
+   * GET /v1/devices/{id}/languages
+   */
+  getDevicesByIdLanguages(id: string): Promise<DeviceLanguagesInfo> {
+    return this.request<DeviceLanguagesInfo>('GET', `/v1/devices/${encodeURIComponent(id)}/languages`);
+  }
+
+  /**
+   * 设置设备允许的语言
+   * 设备每句话在允许的语言中识别语言，第一个为主要语言（使用设备默认音色），其它语言使用 Language.Voices 配置的音色。设备重新连接后生效
+   * PUT /v1/devices/{id}/languages
+   */
+  putDevicesByIdLanguages(id: string, body: DeviceLanguagesRequest): Promise<DeviceLanguagesInfo> {
+    return this.request<DeviceLanguagesInfo>('PUT', `/v1/devices/${encodeURIComponent(id)}/languages`, undefined, body);
+  }
+
+  /**
+   * 获取设备播放状态
+   * GET /v1/devices/{id}/media
+   */
+  getDevicesByIdMedia(id: string): Promise<MediaState> {
+    return this.request<MediaState>('GET', `/v1/devices/${encodeURIComponent(id)}/media`);
+  }
+
+  /**
+   * 点播或控制设备播放
+   * play 检索后在设备上播放，其余操作控制设备当前的播放；设备需在线，播放和暂停以外的操作需要设备正在播放
+   * PUT /v1/devices/{id}/media
+   */
+  putDevicesByIdMedia(id: string, body: MediaControlRequest): Promise<MediaState> {
+    return this.request<MediaState>('PUT', `/v1/devices/${encodeURIComponent(id)}/media`, undefined, body);
+  }
+
+  /**
+   * 获取设备上的成员
+   * 返回设备绑定的全部用户及其资料，所有者在前
+   * GET /v1/devices/{id}/members
+   */
+  getDevicesByIdMembers(id: string): Promise<DeviceMemberInfo[]> {
+    return this.request<DeviceMemberInfo[]>('GET', `/v1/devices/${encodeURIComponent(id)}/members`);
+  }
+
+  /**
+   * 获取设备语音归档授权
+   * 未设置过授权的设备视为未授权，不会归档语音
+   * GET /v1/devices/{id}/recording-consent
+   */
+  getDevicesByIdRecordingConsent(id: string): Promise<RecordingConsentInfo> {
+    return this.request<RecordingConsentInfo>('GET', `/v1/devices/${encodeURIComponent(id)}/recording-consent`);
+  }
+
+  /**
+   * 设置设备语音归档授权
+   * 授权后该设备的用户语音会加密归档；撤销授权时可同时删除已归档的语音
+   * PUT /v1/devices/{id}/recording-consent
+   */
+  putDevicesByIdRecordingConsent(id: string, body: RecordingConsentRequest): Promise<RecordingConsentInfo> {
+    return this.request<RecordingConsentInfo>('PUT', `/v1/devices/${encodeURIComponent(id)}/recording-consent`, undefined, body);
+  }
+
+  /**
+   * 取消设备的播报文本方案分配
+   * 取消后设备使用 default 方案，重新连接后生效
+   * DELETE /v1/devices/{id}/speech-profile
+   */
+  deleteDevicesByIdSpeechProfile(id: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/devices/${encodeURIComponent(id)}/speech-profile`);
+  }
+
+  /**
+   * 获取设备使用的播报文本方案
+   * 未分配方案的设备使用 default 方案
+   * GET /v1/devices/{id}/speech-profile
+   */
+  getDevicesByIdSpeechProfile(id: string): Promise<SpeechTextAssignmentInfo> {
+    return this.request<SpeechTextAssignmentInfo>('GET', `/v1/devices/${encodeURIComponent(id)}/speech-profile`);
+  }
+
+  /**
+   * 为设备分配播报文本方案
+   * 设备重新连接后生效
+   * PUT /v1/devices/{id}/speech-profile
+   */
+  putDevicesByIdSpeechProfile(id: string, body: SpeechTextAssignRequest): Promise<SpeechTextAssignmentInfo> {
+    return this.request<SpeechTextAssignmentInfo>('PUT', `/v1/devices/${encodeURIComponent(id)}/speech-profile`, undefined, body);
+  }
+
+  /**
+   * 退出设备翻译模式
+   * DELETE /v1/devices/{id}/translation
+   */
+  deleteDevicesByIdTranslation(id: string): Promise<TranslationSessionInfo> {
+    return this.request<TranslationSessionInfo>('DELETE', `/v1/devices/${encodeURIComponent(id)}/translation`);
+  }
+
+  /**
+   * 获取设备翻译模式状态
+   * GET /v1/devices/{id}/translation
+   */
+  getDevicesByIdTranslation(id: string): Promise<TranslationSessionInfo> {
+    return this.request<TranslationSessionInfo>('GET', `/v1/devices/${encodeURIComponent(id)}/translation`);
+  }
+
+  /**
+   * 开启或切换设备翻译模式
+   * 设备需在线；之后设备的语音翻译成目标语言并用该语言的音色播报，断开连接时自动退出
+   * PUT /v1/devices/{id}/translation
+   */
+  putDevicesByIdTranslation(id: string, body: TranslationStartRequest): Promise<TranslationSessionInfo> {
+    return this.request<TranslationSessionInfo>('PUT', `/v1/devices/${encodeURIComponent(id)}/translation`, undefined, body);
+  }
+
+  /**
+   * 查询设备的Token用量
+   * 返回设备指定日期的Token用量、LLM调用次数、因超出预算被拒绝的次数以及适用的预算
+   * GET /v1/devices/{id}/usage
+   */
+  getDevicesByIdUsage(id: string, params?: GetDevicesByIDUsageParams): Promise<DeviceTokenUsageInfo> {
+    return this.request<DeviceTokenUsageInfo>('GET', `/v1/devices/${encodeURIComponent(id)}/usage`, params);
+  }
+
+  /**
+   * 对比评测报告
+   * 对比同一测试集的 2-5 次评测，返回各自的汇总指标和逐用例结果
+   * GET /v1/evaluations/compare
+   */
+  getEvaluationsCompare(params?: GetEvaluationsCompareParams): Promise<EvalCompareResponse> {
+    return this.request<EvalCompareResponse>('GET', '/v1/evaluations/compare', params);
+  }
+
+  /**
+   * 获取评测运行列表
+   * GET /v1/evaluations/runs
+   */
+  getEvaluationsRuns(params?: GetEvaluationsRunsParams): Promise<EvalRunListResponse> {
+    return this.request<EvalRunListResponse>('GET', '/v1/evaluations/runs', params);
+  }
+
+  /**
+   * 发起评测
+   * 在后台对所选能力执行测试集，可通过运行ID查询进度和报告
+   * POST /v1/evaluations/runs
+   */
+  postEvaluationsRuns(body: EvalRunRequest): Promise<EvalRunInfo> {
+    return this.request<EvalRunInfo>('POST', '/v1/evaluations/runs', undefined, body);
+  }
+
+  /**
+   * 获取评测报告
+   * 返回各能力的汇总指标和用例明细
+   * GET /v1/evaluations/runs/{id}
+   */
+  getEvaluationsRunsById(id: string): Promise<EvalRunInfo> {
+    return this.request<EvalRunInfo>('GET', `/v1/evaluations/runs/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 获取评测测试集列表
+   * GET /v1/evaluations/suites
+   */
+  getEvaluationsSuites(params?: GetEvaluationsSuitesParams): Promise<EvalSuiteListResponse> {
+    return this.request<EvalSuiteListResponse>('GET', '/v1/evaluations/suites', params);
+  }
+
+  /**
+   * 上传评测测试集
+   * llm 用例需要 input，可选 expected；asr 用例需要 base64 音频和参考文本；tts 用例需要 input
+   * POST /v1/evaluations/suites
+   */
+  postEvaluationsSuites(body: EvalSuiteCreateRequest): Promise<EvalSuiteInfo> {
+    return this.request<EvalSuiteInfo>('POST', '/v1/evaluations/suites', undefined, body);
+  }
+
+  /**
+   * 删除评测测试集
+   * 已有的评测报告保留
+   * DELETE /v1/evaluations/suites/{id}
+   */
+  deleteEvaluationsSuitesById(id: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/evaluations/suites/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 获取评测测试集详情
+   * GET /v1/evaluations/suites/{id}
+   */
+  getEvaluationsSuitesById(id: string): Promise<EvalSuiteInfo> {
+    return this.request<EvalSuiteInfo>('GET', `/v1/evaluations/suites/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * Get execution
+   * Returns an execution with per-node results, timings and logs
+   * GET /v1/executions/{id}
+   */
+  getExecutionsById(id: string): Promise<Execution> {
+    return this.request<Execution>('GET', `/v1/executions/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * Get node logs
+   * Returns the engine and plugin logs recorded for one node of an execution
+   * GET /v1/executions/{id}/nodes/{nodeId}/logs
+   */
+  getExecutionsByIdNodesByNodeIdLogs(id: string, nodeId: string): Promise<NodeLogsResponse> {
+    return this.request<NodeLogsResponse>('GET', `/v1/executions/${encodeURIComponent(id)}/nodes/${encodeURIComponent(nodeId)}/logs`);
+  }
+
+  /**
+   * 获取A/B实验列表
+   * GET /v1/experiments
+   */
+  getExperiments(params?: GetExperimentsParams): Promise<ExperimentListResponse> {
+    return this.request<ExperimentListResponse>('GET', '/v1/experiments', params);
+  }
+
+  /**
+   * 创建A/B实验
+   * 创建草稿状态的实验，每个分组可覆盖提示词模板和LLM
+   * POST /v1/experiments
+   */
+  postExperiments(body: ExperimentCreateRequest): Promise<ExperimentInfo> {
+    return this.request<ExperimentInfo>('POST', '/v1/experiments', undefined, body);
+  }
+
+  /**
+   * 删除A/B实验
+   * 删除实验及其结果，运行中的实验需先停止
+   * DELETE /v1/experiments/{id}
+   */
+  deleteExperimentsById(id: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/experiments/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 获取A/B实验详情
+   * GET /v1/experiments/{id}
+   */
+  getExperimentsById(id: string): Promise<ExperimentInfo> {
+    return this.request<ExperimentInfo>('GET', `/v1/experiments/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 获取A/B实验结果
+   * 按分组汇总延迟、token消耗、打断率和用户反馈
+   * GET /v1/experiments/{id}/results
+   */
+  getExperimentsByIdResults(id: string): Promise<ExperimentResultsResponse> {
+    return this.request<ExperimentResultsResponse>('GET', `/v1/experiments/${encodeURIComponent(id)}/results`);
+  }
+
+  /**
+   * 开始A/B实验
+   * POST /v1/experiments/{id}/start
+   */
+  postExperimentsByIdStart(id: string): Promise<ExperimentInfo> {
+    return this.request<ExperimentInfo>('POST', `/v1/experiments/${encodeURIComponent(id)}/start`);
+  }
+
+  /**
+   * 停止A/B实验
+   * POST /v1/experiments/{id}/stop
+   */
+  postExperimentsByIdStop(id: string): Promise<ExperimentInfo> {
+    return this.request<ExperimentInfo>('POST', `/v1/experiments/${encodeURIComponent(id)}/stop`);
+  }
+
+  /**
+   * 获取反馈列表
+   * GET /v1/feedback
+   */
+  getFeedback(params?: GetFeedbackParams): Promise<FeedbackListResponse> {
+    return this.request<FeedbackListResponse>('GET', '/v1/feedback', params);
+  }
+
+  /**
+   * 获取反馈质量统计
+   * 汇总点赞/点踩、满意度、来源分布和每日趋势，默认统计最近30天
+   * GET /v1/feedback/stats
+   */
+  getFeedbackStats(params?: GetFeedbackStatsParams): Promise<FeedbackStatsResponse> {
+    return this.request<FeedbackStatsResponse>('GET', '/v1/feedback/stats', params);
+  }
+
+  /**
+   * 获取功能开关列表
+   * 列出数据库中的开关和未修改过的内置开关（streaming_tts、new_pipeline、binary_protocol、offline_mode）
+   * GET /v1/flags
+   */
+  getFlags(): Promise<FlagListResponse> {
+    return this.request<FlagListResponse>('GET', '/v1/flags');
+  }
+
+  /**
+   * 删除功能开关
+   * 删除数据库中的开关；内置开关恢复默认值并返回，自定义开关删除后总是关闭
+   * DELETE /v1/flags/{key}
+   */
+  deleteFlagsByKey(key: string): Promise<FlagInfo> {
+    return this.request<FlagInfo>('DELETE', `/v1/flags/${encodeURIComponent(key)}`);
+  }
+
+  /**
+   * 获取功能开关
+   * GET /v1/flags/{key}
+   */
+  getFlagsByKey(key: string): Promise<FlagInfo> {
+    return this.request<FlagInfo>('GET', `/v1/flags/${encodeURIComponent(key)}`);
+  }
+
+  /**
+   * 创建或更新功能开关
+   * 规则按顺序匹配，第一条匹配的规则决定取值，没有匹配的规则时使用 enabled；每条规则需列出租户或设备（设备分组），两者都列出时需同时满足。修改立即在本实例生效，其它实例在 Flags.RefreshInterval 内生效
+   * PUT /v1/flags/{key}
+   */
+  putFlagsByKey(key: string, body: FlagSetRequest): Promise<FlagInfo> {
+    return this.request<FlagInfo>('PUT', `/v1/flags/${encodeURIComponent(key)}`, undefined, body);
+  }
+
+  /**
+   * 查询功能开关对租户和设备的取值
+   * 返回取值及其来源：命中的规则、开关的取值、内置默认值，或未定义（总是关闭）
+   * GET /v1/flags/{key}/evaluate
+   */
+  getFlagsByKeyEvaluate(key: string, params?: GetFlagsByKeyEvaluateParams): Promise<FlagDecision> {
+    return this.request<FlagDecision>('GET', `/v1/flags/${encodeURIComponent(key)}/evaluate`, params);
+  }
+
+  /**
+   * 获取后台任务列表
+   * 按类型、状态和去重键查询后台任务，最新的在前；status 为 dead 时即死信队列
+   * GET /v1/jobs
+   */
+  getJobs(params?: GetJobsParams): Promise<JobListResponse> {
+    return this.request<JobListResponse>('GET', '/v1/jobs', params);
+  }
+
+  /**
+   * 获取后台任务统计
+   * 返回各任务类型在数据库中各状态的任务数，以及本实例的工作协程数、执行中任务数和失败次数
+   * GET /v1/jobs/stats
+   */
+  getJobsStats(): Promise<JobStatsResponse> {
+    return this.request<JobStatsResponse>('GET', '/v1/jobs/stats');
+  }
+
+  /**
+   * 获取后台任务详情
+   * GET /v1/jobs/{id}
+   */
+  getJobsById(id: string): Promise<JobInfo> {
+    return this.request<JobInfo>('GET', `/v1/jobs/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 取消后台任务
+   * 取消等待中或执行中的任务；执行中的任务在下次续约时中断
+   * POST /v1/jobs/{id}/cancel
+   */
+  postJobsByIdCancel(id: string): Promise<JobInfo> {
+    return this.request<JobInfo>('POST', `/v1/jobs/${encodeURIComponent(id)}/cancel`);
+  }
+
+  /**
+   * 重新执行后台任务
+   * 将死信或已取消的任务放回队列并清零执行次数；同一去重键已有未结束的任务时拒绝
+   * POST /v1/jobs/{id}/retry
+   */
+  postJobsByIdRetry(id: string): Promise<JobInfo> {
+    return this.request<JobInfo>('POST', `/v1/jobs/${encodeURIComponent(id)}/retry`);
+  }
+
+  /**
+   * 获取知识库列表
+   * GET /v1/knowledge
+   */
+  getKnowledge(): Promise<KnowledgeBaseInfo[]> {
+    return this.request<KnowledgeBaseInfo[]>('GET', '/v1/knowledge');
+  }
+
+  /**
+   * 创建知识库
+   * POST /v1/knowledge
+   */
+  postKnowledge(body: KnowledgeBaseCreateRequest): Promise<KnowledgeBaseInfo> {
+    return this.request<KnowledgeBaseInfo>('POST', '/v1/knowledge', undefined, body);
+  }
+
+  /**
+   * 获取知识库档案绑定
+   * 设备按配置的 knowledge.device_profiles 归入档案，未配置的设备使用 default 档案
+   * GET /v1/knowledge/bindings
+   */
+  getKnowledgeBindings(): Promise<KnowledgeBindingInfo[]> {
+    return this.request<KnowledgeBindingInfo[]>('GET', '/v1/knowledge/bindings');
+  }
+
+  /**
+   * 设置知识库档案绑定
+   * 设置档案下设备对话时检索的知识库，立即生效；knowledge_base_ids 为空时解除绑定
+   * PUT /v1/knowledge/bindings/{profile}
+   */
+  putKnowledgeBindingsByProfile(profile: string, body: KnowledgeBindingRequest): Promise<KnowledgeBindingInfo> {
+    return this.request<KnowledgeBindingInfo>('PUT', `/v1/knowledge/bindings/${encodeURIComponent(profile)}`, undefined, body);
+  }
+
+  /**
+   * 检索知识库
+   * 在指定知识库或设备所属档案绑定的知识库中检索，返回命中的分块和附加给 LLM 的参考资料，用于调试检索效果
+   * POST /v1/knowledge/search
+   */
+  postKnowledgeSearch(body: KnowledgeSearchRequest): Promise<KnowledgeSearchResponse> {
+    return this.request<KnowledgeSearchResponse>('POST', '/v1/knowledge/search', undefined, body);
+  }
+
+  /**
+   * 删除知识库
+   * 删除知识库及其全部文档和分块，进行中的导入被取消，档案绑定一并解除
+   * DELETE /v1/knowledge/{id}
+   */
+  deleteKnowledgeById(id: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/knowledge/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 获取知识库详情
+   * GET /v1/knowledge/{id}
+   */
+  getKnowledgeById(id: string): Promise<KnowledgeBaseInfo> {
+    return this.request<KnowledgeBaseInfo>('GET', `/v1/knowledge/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 更新知识库
+   * PUT /v1/knowledge/{id}
+   */
+  putKnowledgeById(id: string, body: KnowledgeBaseUpdateRequest): Promise<KnowledgeBaseInfo> {
+    return this.request<KnowledgeBaseInfo>('PUT', `/v1/knowledge/${encodeURIComponent(id)}`, undefined, body);
+  }
+
+  /**
+   * 获取知识库文档列表
+   * 返回文档及其导入状态和进度，新上传的在前
+   * GET /v1/knowledge/{id}/documents
+   */
+  getKnowledgeByIdDocuments(id: string): Promise<KnowledgeDocumentInfo[]> {
+    return this.request<KnowledgeDocumentInfo[]>('GET', `/v1/knowledge/${encodeURIComponent(id)}/documents`);
+  }
+
+  /**
+   * 导入网页到知识库
+   * 网页在导入任务中下载并提取正文，按响应的内容类型识别 PDF、Markdown 或文本
+   * POST /v1/knowledge/{id}/documents/url
+   */
+  postKnowledgeByIdDocumentsUrl(id: string, body: KnowledgeURLRequest): Promise<KnowledgeDocumentInfo> {
+    return this.request<KnowledgeDocumentInfo>('POST', `/v1/knowledge/${encodeURIComponent(id)}/documents/url`, undefined, body);
+  }
+
+  /**
+   * 删除知识库文档
+   * DELETE /v1/knowledge/{id}/documents/{doc_id}
+   */
+  deleteKnowledgeByIdDocumentsByDocId(id: string, docId: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/knowledge/${encodeURIComponent(id)}/documents/${encodeURIComponent(docId)}`);
+  }
+
+  /**
+   * 获取知识库文档
+   * GET /v1/knowledge/{id}/documents/{doc_id}
+   */
+  getKnowledgeByIdDocumentsByDocId(id: string, docId: string): Promise<KnowledgeDocumentInfo> {
+    return this.request<KnowledgeDocumentInfo>('GET', `/v1/knowledge/${encodeURIComponent(id)}/documents/${encodeURIComponent(docId)}`);
+  }
+
+  /**
+   * 重新导入知识库文档
+   * 导入失败或更换向量化能力后重新导入，网页会重新下载；导入进行中时拒绝
+   * POST /v1/knowledge/{id}/documents/{doc_id}/reindex
+   */
+  postKnowledgeByIdDocumentsByDocIdReindex(id: string, docId: string): Promise<KnowledgeDocumentInfo> {
+    return this.request<KnowledgeDocumentInfo>('POST', `/v1/knowledge/${encodeURIComponent(id)}/documents/${encodeURIComponent(docId)}/reindex`);
+  }
+
+  /**
+   * 获取支持的语言
+   * 返回支持自动识别的语言、各语言的播报音色和默认允许的语言
+   * GET /v1/languages
+   */
+  getLanguages(): Promise<LanguageListResponse> {
+    return this.request<LanguageListResponse>('GET', '/v1/languages');
+  }
+
+  /**
+   * 查询服务日志
+   * 按最低级别、标签、时间范围和文本查询服务日志索引，新日志在前。
+   * 日志在后台批量写入索引，最近约一秒内的日志可能还查不到；索引保留天数见 Log.Index.RetentionDays
+   * GET /v1/logs
+   */
+  getLogs(params?: GetLogsParams): Promise<LogListResponse> {
+    return this.request<LogListResponse>('GET', '/v1/logs', params);
+  }
+
+  /**
+   * 检索曲目和电台
+   * 按来源检索本地曲库、配置的网络电台和媒体能力，不指定来源时依次检索
+   * GET /v1/media/search
+   */
+  getMediaSearch(params?: GetMediaSearchParams): Promise<MediaTrack[]> {
+    return this.request<MediaTrack[]>('GET', '/v1/media/search', params);
+  }
+
+  /**
+   * 获取对话耗时统计
+   * 按阶段（采集、ASR、LLM首字、LLM完成、TTS首包、开始播放、端到端）汇总每轮对话耗时的平均值和 P50/P90/P95/P99，并给出每日端到端耗时趋势，默认统计最近30天
+   * GET /v1/metrics/latency
+   */
+  getMetricsLatency(params?: GetMetricsLatencyParams): Promise<LatencyStatsResponse> {
+    return this.request<LatencyStatsResponse>('GET', '/v1/metrics/latency', params);
+  }
+
+  /**
+   * 列出在线会话
+   * 返回在线设备会话的对话状态、最近的对话片段和当前使用的模型与音色，最近连接的在前
+   * GET /v1/monitor/sessions
+   */
+  getMonitorSessions(params?: GetMonitorSessionsParams): Promise<MonitorSession[]> {
+    return this.request<MonitorSession[]>('GET', '/v1/monitor/sessions', params);
+  }
+
+  /**
+   * 终止在线会话
+   * 断开会话的设备连接，正在旁听的事件流随之结束
+   * DELETE /v1/monitor/sessions/{id}
+   */
+  deleteMonitorSessionsById(id: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/monitor/sessions/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 获取在线会话状态
+   * GET /v1/monitor/sessions/{id}
+   */
+  getMonitorSessionsById(id: string): Promise<MonitorSession> {
+    return this.request<MonitorSession>('GET', `/v1/monitor/sessions/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 旁听会话
+   * 以SSE推送已标记旁听的会话的事件，事件名为 state（对话状态变化）、snippet（新的对话片段）和 closed（会话断开，之后事件流结束）；连接时先推送一次 state 作为当前状态
+   * GET /v1/monitor/sessions/{id}/events
+   */
+  getMonitorSessionsByIdEvents(id: string): Promise<MonitorEvent> {
+    return this.request<MonitorEvent>('GET', `/v1/monitor/sessions/${encodeURIComponent(id)}/events`);
+  }
+
+  /**
+   * 取消会话旁听
+   * 取消标记后正在旁听的事件流随之结束
+   * DELETE /v1/monitor/sessions/{id}/flag
+   */
+  deleteMonitorSessionsByIdFlag(id: string): Promise<MonitorSession> {
+    return this.request<MonitorSession>('DELETE', `/v1/monitor/sessions/${encodeURIComponent(id)}/flag`);
+  }
+
+  /**
+   * 标记会话旁听
+   * 标记后可通过事件流实时旁听会话的状态变化和对话内容；已标记时更新备注，会话断开后标记自动清除
+   * PUT /v1/monitor/sessions/{id}/flag
+   */
+  putMonitorSessionsByIdFlag(id: string, body?: MonitorFlagRequest): Promise<MonitorSession> {
+    return this.request<MonitorSession>('PUT', `/v1/monitor/sessions/${encodeURIComponent(id)}/flag`, undefined, body);
+  }
+
+  /**
+   * 获取通知渠道列表
+   * GET /v1/notifications/channels
+   */
+  getNotificationsChannels(): Promise<NotificationChannelInfo[]> {
+    return this.request<NotificationChannelInfo[]>('GET', '/v1/notifications/channels');
+  }
+
+  /**
+   * 创建通知渠道
+   * 创建邮件、Webhook、钉钉、飞书或 Telegram 通知渠道，可订阅插件异常、供应商不可用和配额用尽告警
+   * POST /v1/notifications/channels
+   */
+  postNotificationsChannels(body: NotificationChannelCreateRequest): Promise<NotificationChannelInfo> {
+    return this.request<NotificationChannelInfo>('POST', '/v1/notifications/channels', undefined, body);
+  }
+
+  /**
+   * 删除通知渠道
+   * DELETE /v1/notifications/channels/{id}
+   */
+  deleteNotificationsChannelsById(id: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/notifications/channels/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 获取通知渠道详情
+   * GET /v1/notifications/channels/{id}
+   */
+  getNotificationsChannelsById(id: string): Promise<NotificationChannelInfo> {
+    return this.request<NotificationChannelInfo>('GET', `/v1/notifications/channels/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 更新通知渠道
+   * PUT /v1/notifications/channels/{id}
+   */
+  putNotificationsChannelsById(id: string, body: NotificationChannelUpdateRequest): Promise<NotificationChannelInfo> {
+    return this.request<NotificationChannelInfo>('PUT', `/v1/notifications/channels/${encodeURIComponent(id)}`, undefined, body);
+  }
+
+  /**
+   * 发送测试通知
+   * 向渠道发送一条测试通知，停用的渠道也会发送
+   * POST /v1/notifications/channels/{id}/test
+   */
+  postNotificationsChannelsByIdTest(id: string): Promise<void> {
+    return this.request<void>('POST', `/v1/notifications/channels/${encodeURIComponent(id)}/test`);
+  }
+
+  /**
+   * 获取对象列表
+   * 按命名空间（tts、asr、ota、knowledge 等）和文件名查询对象，最近写入的在前
+   * GET /v1/objects
+   */
+  getObjects(params?: GetObjectsParams): Promise<ObjectListResponse> {
+    return this.request<ObjectListResponse>('GET', '/v1/objects', params);
+  }
+
+  /**
+   * 删除对象
+   * 强制删除对象，不考虑引用数；仍被知识库文档引用的对象删除后，对应文档无法重新导入
+   * DELETE /v1/objects/{id}
+   */
+  deleteObjectsById(id: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/objects/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 获取对象元数据
+   * GET /v1/objects/{id}
+   */
+  getObjectsById(id: string): Promise<ObjectInfo> {
+    return this.request<ObjectInfo>('GET', `/v1/objects/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 下载对象
+   * 通过 url 接口、TTS 输出或 OTA 响应中签发的地址下载对象内容，地址过期或签名不符时返回 403。
+   * 内容按 SHA-256 寻址，ETag 即对象ID
+   * GET /v1/objects/{id}/content
+   */
+  getObjectsByIdContent(id: string, params?: GetObjectsByIDContentParams): Promise<unknown> {
+    return this.request<unknown>('GET', `/v1/objects/${encodeURIComponent(id)}/content`, params);
+  }
+
+  /**
+   * 签发对象下载地址
+   * 使用 S3 后端时返回存储的预签名地址，否则返回本服务的签名下载地址
+   * GET /v1/objects/{id}/url
+   */
+  getObjectsByIdUrl(id: string, params?: GetObjectsByIDURLParams): Promise<ObjectURLResponse> {
+    return this.request<ObjectURLResponse>('GET', `/v1/objects/${encodeURIComponent(id)}/url`, params);
+  }
+
+  /**
+   * 获取插件列表
+   * 获取所有插件的信息，支持分页、筛选和排序
+   * GET /v1/plugins
+   */
+  getPlugins(params?: GetPluginsParams): Promise<PluginListResponse> {
+    return this.request<PluginListResponse>('GET', '/v1/plugins', params);
+  }
+
+  /**
+   * 获取所有插件能力
+   * 获取所有插件的能力定义
+   * GET /v1/plugins/capabilities
+   */
+  getPluginsCapabilities(): Promise<void> {
+    return this.request<void>('GET', '/v1/plugins/capabilities');
+  }
+
+  /**
+   * 按类型获取插件能力
+   * 根据类型筛选插件能力
+   * GET /v1/plugins/capabilities/{type}
+   */
+  getPluginsCapabilitiesByType(type: string): Promise<void> {
+    return this.request<void>('GET', `/v1/plugins/capabilities/${encodeURIComponent(type)}`);
+  }
+
+  /**
+   * 获取提供者HTTP连接池统计
+   * 获取各提供者的请求数、连接复用数、新建连接数、TLS握手次数和HTTP/2请求数
+   * GET /v1/plugins/connections
+   */
+  getPluginsConnections(): Promise<ProviderStats[]> {
+    return this.request<ProviderStats[]>('GET', '/v1/plugins/connections');
+  }
+
+  /**
+   * 获取端口统计信息
+   * 获取端口使用情况统计、当前分配记录和分配历史（含重启后回收、孤儿端口等事件）
+   * GET /v1/plugins/ports
+   */
+  getPluginsPorts(params?: GetPluginsPortsParams): Promise<PortAllocationsResponse> {
+    return this.request<PortAllocationsResponse>('GET', '/v1/plugins/ports', params);
+  }
+
+  /**
+   * 获取能力执行队列统计
+   * 获取各优先级（interactive、api、batch）的排队数、放行数、挤出数、拒绝数和等待时间
+   * GET /v1/plugins/queue
+   */
+  getPluginsQueue(): Promise<QueueStats> {
+    return this.request<QueueStats>('GET', '/v1/plugins/queue');
+  }
+
+  /**
+   * 获取插件统计信息
+   * 获取插件的数量、状态分布、健康状态等统计信息
+   * GET /v1/plugins/stats
+   */
+  getPluginsStats(): Promise<PluginStats> {
+    return this.request<PluginStats>('GET', '/v1/plugins/stats');
+  }
+
+  /**
+   * 获取插件详情
+   * 根据插件ID获取详细信息
+   * GET /v1/plugins/{id}
+   */
+  getPluginsById(id: string): Promise<PluginStatus> {
+    return this.request<PluginStatus>('GET', `/v1/plugins/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 控制插件
+   * 对插件进行启动、停止、重启、重新分配端口等操作
+   * POST /v1/plugins/{id}/control
+   */
+  postPluginsByIdControl(id: string, body: PluginControlRequest): Promise<PluginControlResponse> {
+    return this.request<PluginControlResponse>('POST', `/v1/plugins/${encodeURIComponent(id)}/control`, undefined, body);
+  }
+
+  /**
+   * 获取插件调试信息
+   * 通过插件调试服务获取插件信息、进程信息和已注册的gRPC服务，需启用 PluginGRPC.Debug
+   * GET /v1/plugins/{id}/debug
+   */
+  getPluginsByIdDebug(id: string): Promise<PluginDebugInfo> {
+    return this.request<PluginDebugInfo>('GET', `/v1/plugins/${encodeURIComponent(id)}/debug`);
+  }
+
+  /**
+   * 修改插件日志级别
+   * 通过插件调试服务修改插件日志级别，需启用 PluginGRPC.Debug；与核心在同一进程中运行的插件共用核心日志，修改对整个进程生效
+   * PUT /v1/plugins/{id}/debug/log-level
+   */
+  putPluginsByIdDebugLogLevel(id: string, body: PluginLogLevelRequest): Promise<PluginLogLevelResponse> {
+    return this.request<PluginLogLevelResponse>('PUT', `/v1/plugins/${encodeURIComponent(id)}/debug/log-level`, undefined, body);
+  }
+
+  /**
+   * 获取插件调用统计
+   * 通过插件调试服务获取插件各gRPC方法的调用次数、错误数和耗时，以及Go运行时指标，需启用 PluginGRPC.Debug
+   * GET /v1/plugins/{id}/debug/metrics
+   */
+  getPluginsByIdDebugMetrics(id: string): Promise<PluginDebugMetrics> {
+    return this.request<PluginDebugMetrics>('GET', `/v1/plugins/${encodeURIComponent(id)}/debug/metrics`);
+  }
+
+  /**
+   * 检查插件健康状态
+   * 手动触发插件健康检查
+   * POST /v1/plugins/{id}/health
+   */
+  postPluginsByIdHealth(id: string): Promise<void> {
+    return this.request<void>('POST', `/v1/plugins/${encodeURIComponent(id)}/health`);
+  }
+
+  /**
+   * 获取插件日志
+   * 返回插件 stdout/stderr 的最近日志；follow=true 时以 SSE 持续推送新日志（事件名 log）
+   * GET /v1/plugins/{id}/logs
+   */
+  getPluginsByIdLogs(id: string, params?: GetPluginsByIDLogsParams): Promise<PluginLogsResponse> {
+    return this.request<PluginLogsResponse>('GET', `/v1/plugins/${encodeURIComponent(id)}/logs`, params);
+  }
+
+  /**
+   * 重新分配插件端口
+   * 为插件分配新的端口
+   * POST /v1/plugins/{id}/reallocate-port
+   */
+  postPluginsByIdReallocatePort(id: string): Promise<void> {
+    return this.request<void>('POST', `/v1/plugins/${encodeURIComponent(id)}/reallocate-port`);
+  }
+
+  /**
+   * 获取在家状态
+   * 返回参与在家判断的全部成员及在家成员的称呼
+   * GET /v1/presence
+   */
+  getPresence(): Promise<PresenceOverview> {
+    return this.request<PresenceOverview>('GET', '/v1/presence');
+  }
+
+  /**
+   * 路由器推送在线设备
+   * 路由器上报当前在线设备的MAC地址，成员的设备上线即判定到家，连续 AwayDelay 未上报才判定离家
+   * POST /v1/presence/router
+   */
+  postPresenceRouter(body: PresenceRouterReport): Promise<PresenceRouterReportResponse> {
+    return this.request<PresenceRouterReportResponse>('POST', '/v1/presence/router', undefined, body);
+  }
+
+  /**
+   * 获取提示词模板分配列表
+   * GET /v1/prompt-assignments
+   */
+  getPromptAssignments(): Promise<PromptAssignmentInfo[]> {
+    return this.request<PromptAssignmentInfo[]>('GET', '/v1/prompt-assignments');
+  }
+
+  /**
+   * 取消提示词模板分配
+   * DELETE /v1/prompt-assignments/{device_id}
+   */
+  deletePromptAssignmentsByDeviceId(deviceId: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/prompt-assignments/${encodeURIComponent(deviceId)}`);
+  }
+
+  /**
+   * 为设备分配提示词模板
+   * device_id 为 * 时设置全局默认模板
+   * PUT /v1/prompt-assignments/{device_id}
+   */
+  putPromptAssignmentsByDeviceId(deviceId: string, body: PromptAssignRequest): Promise<PromptAssignmentInfo> {
+    return this.request<PromptAssignmentInfo>('PUT', `/v1/prompt-assignments/${encodeURIComponent(deviceId)}`, undefined, body);
+  }
+
+  /**
+   * 获取提示词模板列表
+   * 返回各模板的当前生效版本
+   * GET /v1/prompts
+   */
+  getPrompts(params?: GetPromptsParams): Promise<PromptListResponse> {
+    return this.request<PromptListResponse>('GET', '/v1/prompts', params);
+  }
+
+  /**
+   * 创建提示词模板
+   * 创建命名模板，支持 Go 模板语法和 Jinja 风格的 {{ name }} 变量
+   * POST /v1/prompts
+   */
+  postPrompts(body: PromptCreateRequest): Promise<PromptInfo> {
+    return this.request<PromptInfo>('POST', '/v1/prompts', undefined, body);
+  }
+
+  /**
+   * 预览提示词模板
+   * 渲染未保存的模板内容，用于编辑时预览
+   * POST /v1/prompts/render
+   */
+  postPromptsRender(body: PromptRenderRequest): Promise<PromptRenderResponse> {
+    return this.request<PromptRenderResponse>('POST', '/v1/prompts/render', undefined, body);
+  }
+
+  /**
+   * 删除提示词模板
+   * 删除模板的全部版本及设备分配
+   * DELETE /v1/prompts/{name}
+   */
+  deletePromptsByName(name: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/prompts/${encodeURIComponent(name)}`);
+  }
+
+  /**
+   * 获取提示词模板
+   * GET /v1/prompts/{name}
+   */
+  getPromptsByName(name: string, params?: GetPromptsByNameParams): Promise<PromptInfo> {
+    return this.request<PromptInfo>('GET', `/v1/prompts/${encodeURIComponent(name)}`, params);
+  }
+
+  /**
+   * 更新提示词模板
+   * 基于当前生效版本生成新版本，并设为生效版本
+   * PUT /v1/prompts/{name}
+   */
+  putPromptsByName(name: string, body: PromptUpdateRequest): Promise<PromptInfo> {
+    return this.request<PromptInfo>('PUT', `/v1/prompts/${encodeURIComponent(name)}`, undefined, body);
+  }
+
+  /**
+   * 测试渲染提示词模板
+   * POST /v1/prompts/{name}/render
+   */
+  postPromptsByNameRender(name: string, body: PromptRenderRequest): Promise<PromptRenderResponse> {
+    return this.request<PromptRenderResponse>('POST', `/v1/prompts/${encodeURIComponent(name)}/render`, undefined, body);
+  }
+
+  /**
+   * 获取提示词模板版本列表
+   * GET /v1/prompts/{name}/versions
+   */
+  getPromptsByNameVersions(name: string): Promise<PromptInfo[]> {
+    return this.request<PromptInfo[]>('GET', `/v1/prompts/${encodeURIComponent(name)}/versions`);
+  }
+
+  /**
+   * 切换提示词模板生效版本
+   * 将指定版本设为生效版本，可用于回滚
+   * POST /v1/prompts/{name}/versions/{version}/activate
+   */
+  postPromptsByNameVersionsByVersionActivate(name: string, version: number): Promise<PromptInfo> {
+    return this.request<PromptInfo>('POST', `/v1/prompts/${encodeURIComponent(name)}/versions/${encodeURIComponent(version)}/activate`);
+  }
+
+  /**
+   * 扫码配对设备
+   * 设备或配套 App 用二维码中的配对令牌换取设备凭证；设备未注册时以令牌签发者为所有者创建并激活，已注册的设备只能由原所有者重新配对。令牌只能使用一次
+   * POST /v1/provisioning/claim
+   */
+  postProvisioningClaim(body: ProvisioningClaimRequest): Promise<ProvisioningClaimResponse> {
+    return this.request<ProvisioningClaimResponse>('POST', '/v1/provisioning/claim', undefined, body);
+  }
+
+  /**
+   * 获取录音列表
+   * 按设备、会话、时间范围过滤归档的用户语音
+   * GET /v1/recordings
+   */
+  getRecordings(params?: GetRecordingsParams): Promise<RecordingListResponse> {
+    return this.request<RecordingListResponse>('GET', '/v1/recordings', params);
+  }
+
+  /**
+   * 删除录音
+   * DELETE /v1/recordings/{id}
+   */
+  deleteRecordingsById(id: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/recordings/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 获取录音详情
+   * GET /v1/recordings/{id}
+   */
+  getRecordingsById(id: string): Promise<RecordingInfo> {
+    return this.request<RecordingInfo>('GET', `/v1/recordings/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 下载录音
+   * 返回解密后的 WAV 音频
+   * GET /v1/recordings/{id}/audio
+   */
+  getRecordingsByIdAudio(id: string): Promise<unknown> {
+    return this.request<unknown>('GET', `/v1/recordings/${encodeURIComponent(id)}/audio`);
+  }
+
+  /**
+   * 获取提醒列表
+   * 获取提醒列表，支持按设备、类型和状态过滤
+   * GET /v1/reminders
+   */
+  getReminders(params?: GetRemindersParams): Promise<ReminderListResponse> {
+    return this.request<ReminderListResponse>('GET', '/v1/reminders', params);
+  }
+
+  /**
+   * 创建提醒
+   * 为设备创建计时器、闹钟或提醒，到期后向设备推送语音播报
+   * POST /v1/reminders
+   */
+  postReminders(body: ReminderCreateRequest): Promise<ReminderInfo> {
+    return this.request<ReminderInfo>('POST', '/v1/reminders', undefined, body);
+  }
+
+  /**
+   * 删除提醒
+   * DELETE /v1/reminders/{id}
+   */
+  deleteRemindersById(id: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/reminders/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 获取提醒详情
+   * GET /v1/reminders/{id}
+   */
+  getRemindersById(id: string): Promise<ReminderInfo> {
+    return this.request<ReminderInfo>('GET', `/v1/reminders/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 更新提醒
+   * 修改待触发提醒的内容、触发时间或重复规则
+   * PUT /v1/reminders/{id}
+   */
+  putRemindersById(id: string, body: ReminderUpdateRequest): Promise<ReminderInfo> {
+    return this.request<ReminderInfo>('PUT', `/v1/reminders/${encodeURIComponent(id)}`, undefined, body);
+  }
+
+  /**
+   * 取消提醒
+   * POST /v1/reminders/{id}/cancel
+   */
+  postRemindersByIdCancel(id: string): Promise<ReminderInfo> {
+    return this.request<ReminderInfo>('POST', `/v1/reminders/${encodeURIComponent(id)}/cancel`);
+  }
+
+  /**
+   * 获取回放列表
+   * 返回回放运行及汇总，不含逐轮明细
+   * GET /v1/replays
+   */
+  getReplays(params?: GetReplaysParams): Promise<ReplayRunListResponse> {
+    return this.request<ReplayRunListResponse>('GET', '/v1/replays', params);
+  }
+
+  /**
+   * 回放已记录的会话
+   * 用当前或指定的 LLM 配置和提示词重新生成会话中每轮的回复，在后台执行；每轮的对话历史使用原回复，工具调用不重新执行。可通过回放ID查询逐轮的回复差异、耗时和成本对比
+   * POST /v1/replays
+   */
+  postReplays(body: ReplayStartRequest): Promise<ReplayRunInfo> {
+    return this.request<ReplayRunInfo>('POST', '/v1/replays', undefined, body);
+  }
+
+  /**
+   * 获取回放对比结果
+   * 返回汇总以及每轮的原回复、回放回复、逐词差异、首字和完成耗时、Token数和成本
+   * GET /v1/replays/{id}
+   */
+  getReplaysById(id: string): Promise<ReplayRunInfo> {
+    return this.request<ReplayRunInfo>('GET', `/v1/replays/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 获取例程列表
+   * 按创建时间列出例程，可按设备过滤
+   * GET /v1/routines
+   */
+  getRoutines(params?: GetRoutinesParams): Promise<RoutineInfo[]> {
+    return this.request<RoutineInfo[]>('GET', '/v1/routines', params);
+  }
+
+  /**
+   * 创建例程
+   * 为设备创建例程，按 cron 计划定时触发或在设备上说出唤醒短语时触发，依次执行天气、新闻、日程、固定文本或工作流动作并合并播报
+   * POST /v1/routines
+   */
+  postRoutines(body: RoutineCreateRequest): Promise<RoutineInfo> {
+    return this.request<RoutineInfo>('POST', '/v1/routines', undefined, body);
+  }
+
+  /**
+   * 删除例程
+   * DELETE /v1/routines/{id}
+   */
+  deleteRoutinesById(id: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/routines/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 获取例程详情
+   * GET /v1/routines/{id}
+   */
+  getRoutinesById(id: string): Promise<RoutineInfo> {
+    return this.request<RoutineInfo>('GET', `/v1/routines/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 更新例程
+   * 修改例程的名称、计划、唤醒短语、动作或启用状态，修改计划后重新计算下次触发时间
+   * PUT /v1/routines/{id}
+   */
+  putRoutinesById(id: string, body: RoutineUpdateRequest): Promise<RoutineInfo> {
+    return this.request<RoutineInfo>('PUT', `/v1/routines/${encodeURIComponent(id)}`, undefined, body);
+  }
+
+  /**
+   * 立即执行例程
+   * 立即执行例程的全部动作并推送到设备播报，设备不在线时返回 409
+   * POST /v1/routines/{id}/run
+   */
+  postRoutinesByIdRun(id: string): Promise<RoutineRunResponse> {
+    return this.request<RoutineRunResponse>('POST', `/v1/routines/${encodeURIComponent(id)}/run`);
+  }
+
+  /**
+   * 获取脚本列表
+   * GET /v1/scripts
+   */
+  getScripts(): Promise<ScriptInfo[]> {
+    return this.request<ScriptInfo[]>('GET', '/v1/scripts');
+  }
+
+  /**
+   * 创建脚本
+   * 创建供工作流脚本节点使用的 Lua 脚本，保存时检查语法
+   * POST /v1/scripts
+   */
+  postScripts(body: ScriptCreateRequest): Promise<ScriptInfo> {
+    return this.request<ScriptInfo>('POST', '/v1/scripts', undefined, body);
+  }
+
+  /**
+   * 删除脚本
+   * DELETE /v1/scripts/{id}
+   */
+  deleteScriptsById(id: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/scripts/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 获取脚本详情
+   * GET /v1/scripts/{id}
+   */
+  getScriptsById(id: string): Promise<ScriptInfo> {
+    return this.request<ScriptInfo>('GET', `/v1/scripts/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 更新脚本
+   * 修改源码时生成新版本，引用当前版本的脚本节点在下次执行时使用新源码
+   * PUT /v1/scripts/{id}
+   */
+  putScriptsById(id: string, body: ScriptUpdateRequest): Promise<ScriptInfo> {
+    return this.request<ScriptInfo>('PUT', `/v1/scripts/${encodeURIComponent(id)}`, undefined, body);
+  }
+
+  /**
+   * 回滚脚本
+   * 以历史版本的源码创建新版本
+   * POST /v1/scripts/{id}/rollback
+   */
+  postScriptsByIdRollback(id: string, body: ScriptRollbackRequest): Promise<ScriptInfo> {
+    return this.request<ScriptInfo>('POST', `/v1/scripts/${encodeURIComponent(id)}/rollback`, undefined, body);
+  }
+
+  /**
+   * 试运行脚本
+   * 以给定输入在沙箱中运行脚本，返回输出和日志；脚本错误或超时返回 400
+   * POST /v1/scripts/{id}/test
+   */
+  postScriptsByIdTest(id: string, body?: ScriptTestRequest): Promise<ScriptTestResult> {
+    return this.request<ScriptTestResult>('POST', `/v1/scripts/${encodeURIComponent(id)}/test`, undefined, body);
+  }
+
+  /**
+   * 获取脚本版本列表
+   * GET /v1/scripts/{id}/versions
+   */
+  getScriptsByIdVersions(id: string): Promise<ScriptVersionInfo[]> {
+    return this.request<ScriptVersionInfo[]>('GET', `/v1/scripts/${encodeURIComponent(id)}/versions`);
+  }
+
+  /**
+   * 获取脚本指定版本
+   * GET /v1/scripts/{id}/versions/{version}
+   */
+  getScriptsByIdVersionsByVersion(id: string, version: number): Promise<ScriptVersionInfo> {
+    return this.request<ScriptVersionInfo>('GET', `/v1/scripts/${encodeURIComponent(id)}/versions/${encodeURIComponent(version)}`);
+  }
+
+  /**
+   * 列出智能家居设备别名
+   * 返回当前租户的设备别名，包括通过接口设置的和从语音纠正中学到的
+   * GET /v1/smarthome/aliases
+   */
+  getSmarthomeAliases(): Promise<SmartHomeAlias[]> {
+    return this.request<SmartHomeAlias[]>('GET', '/v1/smarthome/aliases');
+  }
+
+  /**
+   * 设置智能家居设备别名
+   * 把别名指向实体，别名已存在时改为指向新实体；比较别名时忽略空白、标点和“的”
+   * POST /v1/smarthome/aliases
+   */
+  postSmarthomeAliases(body: SmartHomeAliasRequest): Promise<SmartHomeAlias> {
+    return this.request<SmartHomeAlias>('POST', '/v1/smarthome/aliases', undefined, body);
+  }
+
+  /**
+   * 删除智能家居设备别名
+   * DELETE /v1/smarthome/aliases/{alias}
+   */
+  deleteSmarthomeAliasesByAlias(alias: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/smarthome/aliases/${encodeURIComponent(alias)}`);
+  }
+
+  /**
+   * 重新发现智能家居实体
+   * 向所有桥接重新发现实体，单个桥接失败时保留它上次发现的实体
+   * POST /v1/smarthome/discover
+   */
+  postSmarthomeDiscover(): Promise<SmartHomeEntity[]> {
+    return this.request<SmartHomeEntity[]>('POST', '/v1/smarthome/discover');
+  }
+
+  /**
+   * 列出智能家居实体
+   * 返回缓存的实体及其状态，可按房间和类型过滤
+   * GET /v1/smarthome/entities
+   */
+  getSmarthomeEntities(params?: GetSmarthomeEntitiesParams): Promise<SmartHomeEntity[]> {
+    return this.request<SmartHomeEntity[]>('GET', '/v1/smarthome/entities', params);
+  }
+
+  /**
+   * 获取智能家居实体
+   * 缓存的状态过期时先向桥接刷新
+   * GET /v1/smarthome/entities/{id}
+   */
+  getSmarthomeEntitiesById(id: string): Promise<SmartHomeEntity> {
+    return this.request<SmartHomeEntity>('GET', `/v1/smarthome/entities/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 控制智能家居实体
+   * 返回控制后预期的实体状态；窗帘的 turn_on/turn_off 按 open/close 处理
+   * POST /v1/smarthome/entities/{id}/control
+   */
+  postSmarthomeEntitiesByIdControl(id: string, body: SmartHomeControlRequest): Promise<SmartHomeEntity> {
+    return this.request<SmartHomeEntity>('POST', `/v1/smarthome/entities/${encodeURIComponent(id)}/control`, undefined, body);
+  }
+
+  /**
+   * 获取播报文本后处理方案列表
+   * 列出全部方案和设备分配；数据库中没有 default 方案时列表包含内置方案
+   * GET /v1/speech-profiles
+   */
+  getSpeechProfiles(): Promise<SpeechTextProfileListResponse> {
+    return this.request<SpeechTextProfileListResponse>('GET', '/v1/speech-profiles');
+  }
+
+  /**
+   * 创建播报文本后处理方案
+   * 步骤按顺序执行：markdown 去掉 Markdown 标记，emoji 去掉表情，units 把日期、时间、范围、百分比、温度和计量单位转为读法，replace 按发音词典替换，regex 正则替换。同名方案会被覆盖，修改对之后建立的连接生效
+   * POST /v1/speech-profiles
+   */
+  postSpeechProfiles(body: SpeechTextProfileRequest): Promise<SpeechTextProfileInfo> {
+    return this.request<SpeechTextProfileInfo>('POST', '/v1/speech-profiles', undefined, body);
+  }
+
+  /**
+   * 试运行播报文本后处理
+   * 用已保存的方案或未保存的步骤处理文本，返回送入 TTS 的文本；profile 和 stages 都为空时使用 default 方案
+   * POST /v1/speech-profiles/preview
+   */
+  postSpeechProfilesPreview(body: SpeechTextPreviewRequest): Promise<SpeechTextPreviewResponse> {
+    return this.request<SpeechTextPreviewResponse>('POST', '/v1/speech-profiles/preview', undefined, body);
+  }
+
+  /**
+   * 删除播报文本后处理方案
+   * 同时取消使用该方案的设备分配；删除 default 方案后恢复使用内置方案
+   * DELETE /v1/speech-profiles/{name}
+   */
+  deleteSpeechProfilesByName(name: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/speech-profiles/${encodeURIComponent(name)}`);
+  }
+
+  /**
+   * 获取播报文本后处理方案
+   * GET /v1/speech-profiles/{name}
+   */
+  getSpeechProfilesByName(name: string): Promise<SpeechTextProfileInfo> {
+    return this.request<SpeechTextProfileInfo>('GET', `/v1/speech-profiles/${encodeURIComponent(name)}`);
+  }
+
+  /**
+   * 创建或覆盖播报文本后处理方案
+   * 保存名为 default 的方案会替换内置方案，作为未分配方案的设备的默认方案
+   * PUT /v1/speech-profiles/{name}
+   */
+  putSpeechProfilesByName(name: string, body: SpeechTextProfileRequest): Promise<SpeechTextProfileInfo> {
+    return this.request<SpeechTextProfileInfo>('PUT', `/v1/speech-profiles/${encodeURIComponent(name)}`, undefined, body);
+  }
+
+  /**
+   * 获取启动耗时报告
+   * 获取本次启动各初始化步骤的开始时间、耗时、超时设置和状态，以及决定启动总耗时的关键路径，用于排查启动缓慢；依赖关系满足的步骤并行执行，步骤累计耗时可能大于总耗时
+   * GET /v1/system/boot-report
+   */
+  getSystemBootReport(): Promise<BootReport> {
+    return this.request<BootReport>('GET', '/v1/system/boot-report');
+  }
+
+  /**
+   * 获取数据库耗时与连接池统计
+   * 获取各类操作（query/create/update/delete/row/raw）的次数、错误数、锁冲突次数和平均/最大耗时，以及写串行化排队和连接池状态，用于验证 WAL 与连接池调优效果
+   * GET /v1/system/database
+   */
+  getSystemDatabase(): Promise<DBStats> {
+    return this.request<DBStats>('GET', '/v1/system/database');
+  }
+
+  /**
+   * 获取排空进度
+   * GET /v1/system/drain
+   */
+  getSystemDrain(): Promise<DrainStatus> {
+    return this.request<DrainStatus>('GET', '/v1/system/drain');
+  }
+
+  /**
+   * 进入排空模式
+   * 停止接受新的WebSocket会话和工作流执行，等待活跃会话结束（最长至截止时间）后正常关停；重复调用返回当前进度
+   * POST /v1/system/drain
+   */
+  postSystemDrain(body?: DrainRequest): Promise<DrainStatus> {
+    return this.request<DrainStatus>('POST', '/v1/system/drain', undefined, body);
+  }
+
+  /**
+   * 获取意图路由统计
+   * 获取进程启动以来各意图的分类命中、由处理器完成、回落到LLM和处理失败的次数及平均路由耗时，所有连接累计；intent 为 llm 的条目是未命中任何意图的语句
+   * GET /v1/system/intents
+   */
+  getSystemIntents(): Promise<IntentRouteStats[]> {
+    return this.request<IntentRouteStats[]>('GET', '/v1/system/intents');
+  }
+
+  /**
+   * 获取插件指标
+   * 以 Prometheus 文本格式输出各插件通过 ScrapeMetrics 上报的指标，每个序列带 plugin_id 标签；另含各插件的 plugin_scrape_up 和 plugin_scrape_duration_seconds。数据为最近一次定期采集的结果，间隔见 PluginMetrics.Interval
+   * GET /v1/system/metrics
+   */
+  getSystemMetrics(): Promise<string> {
+    return this.request<string>('GET', '/v1/system/metrics');
+  }
+
+  /**
+   * 执行启动自检
+   * 按初始化依赖图执行只读检查：数据库连接与表结构、配置与密钥、所选提供者的连通性、日志与插件目录、外部 MCP 服务命令，返回逐项结果；运行中的服务不检查端口占用。与 xiaozhi-server doctor 的检查相同
+   * GET /v1/system/selftest
+   */
+  getSystemSelftest(): Promise<SelfTestReport> {
+    return this.request<SelfTestReport>('GET', '/v1/system/selftest');
+  }
+
+  /**
+   * 获取当前租户
+   * 返回请求所属租户的信息和今日用量；管理员未指定 X-Tenant-ID 时返回默认租户
+   * GET /v1/tenant
+   */
+  getTenant(): Promise<TenantUsageInfo> {
+    return this.request<TenantUsageInfo>('GET', '/v1/tenant');
+  }
+
+  /**
+   * 获取租户供应商配置
+   * 返回请求所属租户的 LLM 和 TTS 配置及选择，密钥脱敏为 ******；管理员未指定 X-Tenant-ID 时返回默认租户
+   * GET /v1/tenant/providers
+   */
+  getTenantProviders(): Promise<TenantProviders> {
+    return this.request<TenantProviders>('GET', '/v1/tenant/providers');
+  }
+
+  /**
+   * 修改租户供应商配置
+   * 整体替换请求所属租户的 LLM 和 TTS 配置。条目与实例配置同名时覆盖实例配置，选择为空时沿用实例的选择；
+   * 密钥传 ****** 表示保留同名条目的原值。新连接的设备使用新配置，ASR 仍使用实例配置
+   * PUT /v1/tenant/providers
+   */
+  putTenantProviders(body: TenantProviders): Promise<TenantProviders> {
+    return this.request<TenantProviders>('PUT', '/v1/tenant/providers', undefined, body);
+  }
+
+  /**
+   * 获取租户列表
+   * GET /v1/tenants
+   */
+  getTenants(): Promise<TenantInfo[]> {
+    return this.request<TenantInfo[]>('GET', '/v1/tenants');
+  }
+
+  /**
+   * 创建租户
+   * 创建租户并签发访问令牌，令牌只在响应中返回一次。租户ID由小写字母、数字、下划线和连字符组成
+   * POST /v1/tenants
+   */
+  postTenants(body: TenantCreateRequest): Promise<TenantTokenResponse> {
+    return this.request<TenantTokenResponse>('POST', '/v1/tenants', undefined, body);
+  }
+
+  /**
+   * 删除租户
+   * 删除租户及其提示词模板和用量记录；仍有设备的租户不能删除，默认租户不能删除
+   * DELETE /v1/tenants/{id}
+   */
+  deleteTenantsById(id: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/tenants/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 获取租户
+   * GET /v1/tenants/{id}
+   */
+  getTenantsById(id: string): Promise<TenantInfo> {
+    return this.request<TenantInfo>('GET', `/v1/tenants/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 修改租户
+   * 修改名称、启用状态或配额；停用后租户令牌和设备连接都会被拒绝
+   * PUT /v1/tenants/{id}
+   */
+  putTenantsById(id: string, body: TenantUpdateRequest): Promise<TenantInfo> {
+    return this.request<TenantInfo>('PUT', `/v1/tenants/${encodeURIComponent(id)}`, undefined, body);
+  }
+
+  /**
+   * 重新签发租户令牌
+   * 生成新的访问令牌，旧令牌立即失效；新令牌只在响应中返回一次
+   * POST /v1/tenants/{id}/token
+   */
+  postTenantsByIdToken(id: string): Promise<TenantTokenResponse> {
+    return this.request<TenantTokenResponse>('POST', `/v1/tenants/${encodeURIComponent(id)}/token`);
+  }
+
+  /**
+   * 查询租户用量
+   * 返回指定日期的能力调用次数、Token 数以及当前设备数
+   * GET /v1/tenants/{id}/usage
+   */
+  getTenantsByIdUsage(id: string, params?: GetTenantsByIDUsageParams): Promise<TenantUsageInfo> {
+    return this.request<TenantUsageInfo>('GET', `/v1/tenants/${encodeURIComponent(id)}/usage`, params);
+  }
+
+  /**
+   * 获取工具调用审计记录
+   * 按设备、智能体、工具名称、检查结果和时间范围过滤设备会话和智能体发起的工具与能力调用，新记录在前
+   * GET /v1/tool-policy/audit
+   */
+  getToolPolicyAudit(params?: GetToolPolicyAuditParams): Promise<ToolPolicyAuditListResponse> {
+    return this.request<ToolPolicyAuditListResponse>('GET', '/v1/tool-policy/audit', params);
+  }
+
+  /**
+   * 试算工具调用权限
+   * 按当前策略检查设备会话或智能体能否以给定参数调用工具或能力，不执行调用也不记入审计
+   * POST /v1/tool-policy/check
+   */
+  postToolPolicyCheck(body: ToolPolicyCheckRequest): Promise<ToolPolicyCheckResponse> {
+    return this.request<ToolPolicyCheckResponse>('POST', '/v1/tool-policy/check', undefined, body);
+  }
+
+  /**
+   * 获取翻译模式支持的语言
+   * 返回可作为源语言和目标语言的语言代码，以及各语言配置的TTS音色
+   * GET /v1/translation/languages
+   */
+  getTranslationLanguages(): Promise<TranslationLanguage[]> {
+    return this.request<TranslationLanguage[]>('GET', '/v1/translation/languages');
+  }
+
+  /**
+   * 按Token用量列出设备
+   * 返回指定日期有用量的设备，按 Token 数从多到少排序
+   * GET /v1/usage/devices
+   */
+  getUsageDevices(params?: GetUsageDevicesParams): Promise<DeviceTokenUsageInfo[]> {
+    return this.request<DeviceTokenUsageInfo[]>('GET', '/v1/usage/devices', params);
+  }
+
+  /**
+   * 获取用户绑定的设备
+   * GET /v1/users/{id}/devices
+   */
+  getUsersByIdDevices(id: number): Promise<DeviceBindingInfo[]> {
+    return this.request<DeviceBindingInfo[]>('GET', `/v1/users/${encodeURIComponent(id)}/devices`);
+  }
+
+  /**
+   * 绑定设备
+   * 将已注册的设备绑定给用户。每台设备最多一个所有者（owner），其余为家庭成员（member）；重复绑定时更新角色
+   * POST /v1/users/{id}/devices
+   */
+  postUsersByIdDevices(id: number, body: BindDeviceRequest): Promise<DeviceBindingInfo> {
+    return this.request<DeviceBindingInfo>('POST', `/v1/users/${encodeURIComponent(id)}/devices`, undefined, body);
+  }
+
+  /**
+   * 解除设备绑定
+   * DELETE /v1/users/{id}/devices/{device_id}
+   */
+  deleteUsersByIdDevicesByDeviceId(id: number, deviceId: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/users/${encodeURIComponent(id)}/devices/${encodeURIComponent(deviceId)}`);
+  }
+
+  /**
+   * 清空长期记忆
+   * 删除用户记忆命名空间中的全部记忆，不影响记忆提取授权
+   * DELETE /v1/users/{id}/memories
+   */
+  deleteUsersByIdMemories(id: number): Promise<MemoryClearResponse> {
+    return this.request<MemoryClearResponse>('DELETE', `/v1/users/${encodeURIComponent(id)}/memories`);
+  }
+
+  /**
+   * 获取用户的长期记忆
+   * 列出用户记忆命名空间中的事实及其出处（设备、会话、对话记录和原话），最近更新的在前
+   * GET /v1/users/{id}/memories
+   */
+  getUsersByIdMemories(id: number, params?: GetUsersByIDMemoriesParams): Promise<MemoryFactListResponse> {
+    return this.request<MemoryFactListResponse>('GET', `/v1/users/${encodeURIComponent(id)}/memories`, params);
+  }
+
+  /**
+   * 添加长期记忆
+   * 手动为用户添加一条记忆，不需要记忆提取授权
+   * POST /v1/users/{id}/memories
+   */
+  postUsersByIdMemories(id: number, body: MemoryFactRequest): Promise<MemoryFactInfo> {
+    return this.request<MemoryFactInfo>('POST', `/v1/users/${encodeURIComponent(id)}/memories`, undefined, body);
+  }
+
+  /**
+   * 删除长期记忆
+   * DELETE /v1/users/{id}/memories/{fact_id}
+   */
+  deleteUsersByIdMemoriesByFactId(id: number, factId: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/users/${encodeURIComponent(id)}/memories/${encodeURIComponent(factId)}`);
+  }
+
+  /**
+   * 修改长期记忆
+   * 修改后记忆的来源记为 manual，保留原始出处
+   * PUT /v1/users/{id}/memories/{fact_id}
+   */
+  putUsersByIdMemoriesByFactId(id: number, factId: string, body: MemoryFactRequest): Promise<MemoryFactInfo> {
+    return this.request<MemoryFactInfo>('PUT', `/v1/users/${encodeURIComponent(id)}/memories/${encodeURIComponent(factId)}`, undefined, body);
+  }
+
+  /**
+   * 获取记忆提取授权
+   * GET /v1/users/{id}/memory-consent
+   */
+  getUsersByIdMemoryConsent(id: number): Promise<MemoryConsentInfo> {
+    return this.request<MemoryConsentInfo>('GET', `/v1/users/${encodeURIComponent(id)}/memory-consent`);
+  }
+
+  /**
+   * 设置记忆提取授权
+   * 授权后从该用户的对话中提取长期记忆；撤销授权时可同时删除已有记忆
+   * PUT /v1/users/{id}/memory-consent
+   */
+  putUsersByIdMemoryConsent(id: number, body: MemoryConsentRequest): Promise<MemoryConsentInfo> {
+    return this.request<MemoryConsentInfo>('PUT', `/v1/users/${encodeURIComponent(id)}/memory-consent`, undefined, body);
+  }
+
+  /**
+   * 签发配对令牌
+   * 为用户签发短时有效的一次性配对令牌，payload 用于生成二维码；设备或配套 App 扫码后调用 /v1/provisioning/claim 完成配对，设备以该用户为所有者注册
+   * POST /v1/users/{id}/pairing-tokens
+   */
+  postUsersByIdPairingTokens(id: number): Promise<PairingTokenInfo> {
+    return this.request<PairingTokenInfo>('POST', `/v1/users/${encodeURIComponent(id)}/pairing-tokens`);
+  }
+
+  /**
+   * 获取成员的在家状态
+   * 没有任何签到或路由器记录时状态为 unknown
+   * GET /v1/users/{id}/presence
+   */
+  getUsersByIdPresence(id: number): Promise<PresenceStatus> {
+    return this.request<PresenceStatus>('GET', `/v1/users/${encodeURIComponent(id)}/presence`);
+  }
+
+  /**
+   * 配套App签到
+   * 上报到家（state=home）或离家（state=away），或上报位置按家的地理围栏判断；位置落在围栏边缘的定位精度范围内时保持原状态。状态变化时发布 presence:arrived 或 presence:left 事件
+   * POST /v1/users/{id}/presence/checkin
+   */
+  postUsersByIdPresenceCheckin(id: number, body: PresenceCheckInRequest): Promise<PresenceStatus> {
+    return this.request<PresenceStatus>('POST', `/v1/users/${encodeURIComponent(id)}/presence/checkin`, undefined, body);
+  }
+
+  /**
+   * 获取成员资料
+   * GET /v1/users/{id}/profile
+   */
+  getUsersByIdProfile(id: number): Promise<MemberProfileInfo> {
+    return this.request<MemberProfileInfo>('GET', `/v1/users/${encodeURIComponent(id)}/profile`);
+  }
+
+  /**
+   * 修改成员资料
+   * 称呼用于按名字识别说话人；识别到该成员后使用其偏好音色和语言回复，个人记忆按命名空间隔离
+   * PUT /v1/users/{id}/profile
+   */
+  putUsersByIdProfile(id: number, body: MemberProfileUpdateRequest): Promise<MemberProfileInfo> {
+    return this.request<MemberProfileInfo>('PUT', `/v1/users/${encodeURIComponent(id)}/profile`, undefined, body);
+  }
+
+  /**
+   * 获取推送端点列表
+   * GET /v1/webhooks
+   */
+  getWebhooks(): Promise<WebhookInfo[]> {
+    return this.request<WebhookInfo[]>('GET', '/v1/webhooks');
+  }
+
+  /**
+   * 创建推送端点
+   * 登记接收地址并订阅设备离线、插件异常、工作流失败或配额用尽事件；推送为 POST JSON，请求头 X-Xiaozhi-Signature 为 t=<unix 秒>,v1=<hex(HMAC-SHA256(secret, "<t>.<body>"))>。未指定签名密钥时自动生成，只在本次响应中返回原值
+   * POST /v1/webhooks
+   */
+  postWebhooks(body: WebhookCreateRequest): Promise<WebhookInfo> {
+    return this.request<WebhookInfo>('POST', '/v1/webhooks', undefined, body);
+  }
+
+  /**
+   * 删除推送端点
+   * 删除端点及其全部推送记录，尚未推送的事件不再推送
+   * DELETE /v1/webhooks/{id}
+   */
+  deleteWebhooksById(id: string): Promise<void> {
+    return this.request<void>('DELETE', `/v1/webhooks/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 获取推送端点详情
+   * GET /v1/webhooks/{id}
+   */
+  getWebhooksById(id: string): Promise<WebhookInfo> {
+    return this.request<WebhookInfo>('GET', `/v1/webhooks/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * 更新推送端点
+   * PUT /v1/webhooks/{id}
+   */
+  putWebhooksById(id: string, body: WebhookUpdateRequest): Promise<WebhookInfo> {
+    return this.request<WebhookInfo>('PUT', `/v1/webhooks/${encodeURIComponent(id)}`, undefined, body);
+  }
+
+  /**
+   * 获取端点的推送记录
+   * 按创建时间倒序分页返回推送记录，包含请求体、推送状态和各次尝试的响应
+   * GET /v1/webhooks/{id}/deliveries
+   */
+  getWebhooksByIdDeliveries(id: string, params?: GetWebhooksByIDDeliveriesParams): Promise<WebhookDeliveryListResponse> {
+    return this.request<WebhookDeliveryListResponse>('GET', `/v1/webhooks/${encodeURIComponent(id)}/deliveries`, params);
+  }
+
+  /**
+   * 获取推送记录详情
+   * GET /v1/webhooks/{id}/deliveries/{delivery_id}
+   */
+  getWebhooksByIdDeliveriesByDeliveryId(id: string, deliveryId: string): Promise<WebhookDeliveryInfo> {
+    return this.request<WebhookDeliveryInfo>('GET', `/v1/webhooks/${encodeURIComponent(id)}/deliveries/${encodeURIComponent(deliveryId)}`);
+  }
+
+  /**
+   * 重新推送
+   * 把已成功或已失败的推送记录重新加入推送队列，请求体和事件ID不变，重试次数重新计算
+   * POST /v1/webhooks/{id}/deliveries/{delivery_id}/redeliver
+   */
+  postWebhooksByIdDeliveriesByDeliveryIdRedeliver(id: string, deliveryId: string): Promise<WebhookDeliveryInfo> {
+    return this.request<WebhookDeliveryInfo>('POST', `/v1/webhooks/${encodeURIComponent(id)}/deliveries/${encodeURIComponent(deliveryId)}/redeliver`);
+  }
+
+  /**
+   * 推送测试事件
+   * 向端点同步推送一条 webhook.ping 事件并返回推送记录，不重试，停用的端点也会推送
+   * POST /v1/webhooks/{id}/test
+   */
+  postWebhooksByIdTest(id: string): Promise<WebhookDeliveryInfo> {
+    return this.request<WebhookDeliveryInfo>('POST', `/v1/webhooks/${encodeURIComponent(id)}/test`);
+  }
+
+  /**
+   * Save workflow
+   * Saves the workflow of the request's tenant; workflows whose node expressions do not compile are rejected
+   * POST /v1/workflow
+   */
+  postWorkflow(body: Workflow): Promise<Workflow> {
+    return this.request<Workflow>('POST', '/v1/workflow', undefined, body);
+  }
+
+  /**
+   * List capabilities
+   * Lists every capability registered by builtin providers and plugins
+   * GET /v1/workflow/capabilities
+   */
+  getWorkflowCapabilities(): Promise<Definition[]> {
+    return this.request<Definition[]>('GET', '/v1/workflow/capabilities');
+  }
+
+  /**
+   * Execute a capability
+   * Runs one capability outside of any workflow; streaming output is merged into one result. On failure the error details carry the call traces with upstream request IDs
+   * POST /v1/workflow/capabilities/{id}/execute
+   */
+  postWorkflowCapabilitiesByIdExecute(id: string, body?: ExecuteCapabilityRequest): Promise<ExecuteCapabilityResponse> {
+    return this.request<ExecuteCapabilityResponse>('POST', `/v1/workflow/capabilities/${encodeURIComponent(id)}/execute`, undefined, body);
+  }
+
+  /**
+   * Get current workflow
+   * Returns the workflow of the request's tenant
+   * GET /v1/workflow/current
+   */
+  getWorkflowCurrent(): Promise<Workflow> {
+    return this.request<Workflow>('GET', '/v1/workflow/current');
+  }
+
+  /**
+   * List node types
+   * Lists builtin workflow node types and the custom node types registered by plugins
+   * GET /v1/workflow/node-types
+   */
+  getWorkflowNodeTypes(): Promise<NodeTypeDefinition[]> {
+    return this.request<NodeTypeDefinition[]>('GET', '/v1/workflow/node-types');
+  }
+
+  /**
+   * Get scheduler stats
+   * Returns how many nodes are running and queued per concurrency class and execution
+   * GET /v1/workflow/scheduler
+   */
+  getWorkflowScheduler(): Promise<SchedulerStats> {
+    return this.request<SchedulerStats>('GET', '/v1/workflow/scheduler');
+  }
+
+  /**
+   * Get startup workflow
+   * Returns the service startup steps as a workflow together with per-step status and timing
+   * GET /v1/workflow/startup
+   */
+  getWorkflowStartup(): Promise<StartupWorkflowResponse> {
+    return this.request<StartupWorkflowResponse>('GET', '/v1/workflow/startup');
+  }
+
+  /**
+   * List workflow templates
+   * GET /v1/workflow/templates
+   */
+  getWorkflowTemplates(): Promise<WorkflowTemplate[]> {
+    return this.request<WorkflowTemplate[]>('GET', '/v1/workflow/templates');
+  }
+
+  /**
+   * Get workflow template
+   * GET /v1/workflow/templates/{id}
+   */
+  getWorkflowTemplatesById(id: string): Promise<WorkflowTemplate> {
+    return this.request<WorkflowTemplate>('GET', `/v1/workflow/templates/${encodeURIComponent(id)}`);
+  }
+
+  /**
+   * Deploy workflow template
+   * Instantiates a template and saves it as the current workflow; with dry_run=true the workflow is only returned
+   * POST /v1/workflow/templates/{id}/deploy
+   */
+  postWorkflowTemplatesByIdDeploy(id: string, params?: PostWorkflowTemplatesByIDDeployParams, body?: DeployTemplateRequest): Promise<Workflow> {
+    return this.request<Workflow>('POST', `/v1/workflow/templates/${encodeURIComponent(id)}/deploy`, params, body);
+  }
+
+  /**
+   * Execute workflow
+   * Starts an execution of the stored (or supplied) workflow and returns it with 202; with dry_run=true returns a workflow.DryRunReport instead
+   * POST /v1/workflows/{id}/execute
+   */
+  postWorkflowsByIdExecute(id: string, params?: PostWorkflowsByIDExecuteParams, body?: ExecuteWorkflowRequest): Promise<unknown> {
+    return this.request<unknown>('POST', `/v1/workflows/${encodeURIComponent(id)}/execute`, params, body);
+  }
+}
+
+export const apiClient = new ApiClient();
+
+export interface GetAnalyticsRollupsParams {
+  /** 汇总周期，默认 day */
+  period?: string;
+  /** 起始日期 YYYY-MM-DD，默认往前 7 天或 8 周 */
+  from?: string;
+  /** 结束日期 YYYY-MM-DD，默认今天 */
+  to?: string;
+  /** 只返回该设备的活跃情况 */
+  device_id?: string;
+  /** 意图和设备列表的条数，默认 20，最多 500 */
+  limit?: number;
+}
+
+export interface GetAnalyticsSummaryParams {
+  /** 范围的对齐方式，默认 day */
+  period?: string;
+  /** 起始日期 YYYY-MM-DD，默认往前 7 天或 8 周 */
+  from?: string;
+  /** 结束日期 YYYY-MM-DD，默认今天 */
+  to?: string;
+  /** 只返回该设备的活跃情况 */
+  device_id?: string;
+  /** 意图和设备列表的条数，默认 20，最多 500 */
+  limit?: number;
+}
+
+export interface GetApprovalsParams {
+  /** Filter by status */
+  status?: string;
+}
+
+export interface PostConfigApplyParams {
+  /** 只计算变更计划，不做修改 */
+  dry_run?: boolean;
+}
+
+export interface GetConversationsParams {
+  /** 设备ID */
+  device_id?: string;
+  /** 会话ID */
+  session_id?: string;
+  /** 消息内容关键字 */
+  q?: string;
+  /** 开始时间 RFC3339 */
+  from?: string;
+  /** 结束时间 RFC3339 */
+  to?: string;
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  limit?: number;
+}
+
+export interface GetConversationsBySessionIDExportParams {
+  /** 导出格式 json/markdown */
+  format?: string;
+}
+
+export interface GetDebugProviderCallsParams {
+  /** 提供者ID */
+  provider?: string;
+  /** 能力ID */
+  capability?: string;
+  /** 设备ID */
+  device_id?: string;
+  /** 是否成功 */
+  success?: boolean;
+  /** 在请求、响应和错误信息中搜索的文本 */
+  q?: string;
+  /** 开始时间 RFC3339 */
+  from?: string;
+  /** 结束时间 RFC3339 */
+  to?: string;
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  limit?: number;
+}
+
+export interface GetDeviceDebugAuditParams {
+  /** 设备ID */
+  device_id?: string;
+  /** 操作 */
+  action?: string;
+  /** 开始时间 RFC3339 */
+  from?: string;
+  /** 结束时间 RFC3339 */
+  to?: string;
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  limit?: number;
+}
+
+export interface GetDevicesParams {
+  /** 按状态过滤 */
+  status?: string;
+  /** 按设备类型过滤 */
+  device_type?: string;
+  /** 搜索关键词 */
+  search?: string;
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  limit?: number;
+  /** 排序字段 */
+  sort_by?: string;
+  /** 排序方向 */
+  sort_order?: string;
+  /** 是否返回位置信息 */
+  location?: boolean;
+}
+
+export interface GetDevicesByIDDebugLogsParams {
+  /** 读取的行数，缺省或超过上限时使用 DeviceDebug.MaxLogLines */
+  lines?: number;
+  /** 最低日志级别 */
+  level?: string;
+}
+
+export interface GetDevicesByIDUsageParams {
+  /** 日期 YYYY-MM-DD，默认今天 */
+  day?: string;
+}
+
+export interface GetEvaluationsCompareParams {
+  /** 逗号分隔的运行ID */
+  run_ids?: string;
+}
+
+export interface GetEvaluationsRunsParams {
+  /** 测试集ID */
+  suite_id?: string;
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  limit?: number;
+}
+
+export interface GetEvaluationsSuitesParams {
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  limit?: number;
+}
+
+export interface GetExperimentsParams {
+  /** 状态 */
+  status?: string;
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  limit?: number;
+}
+
+export interface GetFeedbackParams {
+  /** 设备ID */
+  device_id?: string;
+  /** 会话ID */
+  session_id?: string;
+  /** 评价 */
+  rating?: string;
+  /** 来源 */
+  source?: string;
+  /** 开始时间 RFC3339 */
+  from?: string;
+  /** 结束时间 RFC3339 */
+  to?: string;
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  limit?: number;
+}
+
+export interface GetFeedbackStatsParams {
+  /** 设备ID */
+  device_id?: string;
+  /** 来源 */
+  source?: string;
+  /** 开始时间 RFC3339 */
+  from?: string;
+  /** 结束时间 RFC3339 */
+  to?: string;
+}
+
+export interface GetFlagsByKeyEvaluateParams {
+  /** 租户ID，缺省为默认租户 */
+  tenant_id?: string;
+  /** 设备ID */
+  device_id?: string;
+}
+
+export interface GetJobsParams {
+  /** 任务类型，如 knowledge.ingest */
+  type?: string;
+  /** 状态 */
+  status?: string;
+  /** 去重键 */
+  key?: string;
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  limit?: number;
+}
+
+export interface GetLogsParams {
+  /** 最低级别 */
+  level?: string;
+  /** 日志标签 */
+  tag?: string;
+  /** 在日志正文和属性中搜索的文本 */
+  q?: string;
+  /** 开始时间 RFC3339 */
+  from?: string;
+  /** 结束时间 RFC3339 */
+  to?: string;
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  limit?: number;
+}
+
+export interface GetMediaSearchParams {
+  /** 检索内容，为空时随机返回曲库中的曲目 */
+  q?: string;
+  /** 来源 library/radio/provider */
+  source?: string;
+}
+
+export interface GetMetricsLatencyParams {
+  /** 设备ID */
+  device_id?: string;
+  /** 开始时间 RFC3339 */
+  from?: string;
+  /** 结束时间 RFC3339 */
+  to?: string;
+}
+
+export interface GetMonitorSessionsParams {
+  /** 租户ID */
+  tenant_id?: string;
+  /** 设备ID */
+  device_id?: string;
+  /** 对话状态 */
+  state?: string;
+  /** 只返回已标记旁听的会话 */
+  flagged?: boolean;
+}
+
+export interface GetObjectsParams {
+  /** 命名空间 */
+  namespace?: string;
+  /** 文件名 */
+  name?: string;
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  limit?: number;
+}
+
+export interface GetObjectsByIDContentParams {
+  /** 过期时间（Unix 秒） */
+  expires?: number;
+  /** 签名 */
+  signature?: string;
+}
+
+export interface GetObjectsByIDURLParams {
+  /** 有效秒数，缺省使用 ObjectStore.URLExpiry */
+  expires_in?: number;
+}
+
+export interface GetPluginsParams {
+  /** 插件类型 */
+  type?: string;
+  /** 插件状态 */
+  status?: string;
+  /** 健康状态 */
+  health_status?: string;
+  /** 页码 */
+  page?: number;
+  /** 每页大小 */
+  page_size?: number;
+  /** 排序字段 */
+  sort_by?: string;
+  /** 排序方向 */
+  sort_order?: string;
+  /** 搜索关键词 */
+  search?: string;
+}
+
+export interface GetPluginsPortsParams {
+  /** 按插件ID筛选历史 */
+  plugin_id?: string;
+  /** 按端口筛选历史 */
+  port?: number;
+  /** 历史条数 */
+  limit?: number;
+}
+
+export interface GetPluginsByIDLogsParams {
+  /** 返回最近的日志行数 */
+  tail?: number;
+  /** 最低日志级别 */
+  level?: string;
+  /** 是否以SSE持续推送 */
+  follow?: boolean;
+}
+
+export interface GetPromptsParams {
+  /** 名称关键字 */
+  name?: string;
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  limit?: number;
+}
+
+export interface GetPromptsByNameParams {
+  /** 版本号，默认为生效版本 */
+  version?: number;
+}
+
+export interface GetRecordingsParams {
+  /** 设备ID */
+  device_id?: string;
+  /** 会话ID */
+  session_id?: string;
+  /** 开始时间 RFC3339 */
+  from?: string;
+  /** 结束时间 RFC3339 */
+  to?: string;
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  limit?: number;
+}
+
+export interface GetRemindersParams {
+  /** 设备ID */
+  device_id?: string;
+  /** 类型 timer/alarm/reminder */
+  kind?: string;
+  /** 状态 pending/fired/cancelled/missed */
+  status?: string;
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  limit?: number;
+}
+
+export interface GetReplaysParams {
+  /** 会话ID */
+  session_id?: string;
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  limit?: number;
+}
+
+export interface GetRoutinesParams {
+  /** 设备ID */
+  device_id?: string;
+}
+
+export interface GetSmarthomeEntitiesParams {
+  /** 房间 */
+  room?: string;
+  /** 实体类型 light/switch/cover/climate/lock/fan/sensor */
+  type?: string;
+  /** 先向所有桥接重新发现实体 */
+  refresh?: boolean;
+}
+
+export interface GetTenantsByIDUsageParams {
+  /** 日期 YYYY-MM-DD，默认今天 */
+  day?: string;
+}
+
+export interface GetToolPolicyAuditParams {
+  /** 设备ID */
+  device_id?: string;
+  /** 智能体名称 */
+  agent?: string;
+  /** 工具或能力名称 */
+  name?: string;
+  /** 是否放行 */
+  allowed?: boolean;
+  /** 开始时间 RFC3339 */
+  from?: string;
+  /** 结束时间 RFC3339 */
+  to?: string;
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  limit?: number;
+}
+
+export interface GetUsageDevicesParams {
+  /** 日期 YYYY-MM-DD，默认今天 */
+  day?: string;
+  /** 返回的设备数，默认 100，最多 500 */
+  limit?: number;
+}
+
+export interface GetUsersByIDMemoriesParams {
+  /** 内容关键字 */
+  q?: string;
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  limit?: number;
+}
+
+export interface GetWebhooksByIDDeliveriesParams {
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  limit?: number;
+  /** 事件类型 */
+  event?: string;
+  /** 推送状态 */
+  status?: string;
+}
+
+export interface PostWorkflowTemplatesByIDDeployParams {
+  /** Return the instantiated workflow without saving it */
+  dry_run?: boolean;
+}
+
+export interface PostWorkflowsByIDExecuteParams {
+  /** Only resolve and validate the workflow */
+  dry_run?: boolean;
+}
+
+export type Action = 'create' | 'update' | 'delete';
+
+export interface AgentCreateRequest {
+  /** 可以委托子任务的智能体名称 */
+  delegates?: string[];
+  /** 用途说明，调度者据此选择委托对象 */
+  description?: string;
+  /** LLM 配置名称，缺省为默认LLM */
+  model?: string;
+  /** 小写字母开头，只含小写字母、数字、_ 和 - */
+  name: string;
+  prompt: string;
+  /** 允许调用的 MCP 工具 */
+  tools?: string[];
+}
+
+export interface AgentInfo {
+  created_at?: string;
+  delegates?: string[];
+  description?: string;
+  id?: string;
+  model?: string;
+  name?: string;
+  prompt?: string;
+  tools?: string[];
+  updated_at?: string;
+}
+
+export interface AgentRunRequest {
+  input: string;
+}
+
+export interface AgentRunResult {
+  agent?: string;
+  reply?: string;
+  steps?: AgentStep[];
+}
+
+export interface AgentStep {
+  agent?: string;
+  arguments?: string;
+  duration_ms?: number;
+  error?: string;
+  name?: string;
+  result?: string;
+  /** tool 或 delegate */
+  type?: string;
+}
+
+export interface AgentUpdateRequest {
+  delegates?: string[];
+  description?: string;
+  model?: string;
+  name?: string;
+  prompt?: string;
+  tools?: string[];
+}
+
+export interface AnalyticsDevice {
+  conversations?: number;
+  device_id?: string;
+  last_active_at?: string;
+  speech_ms?: number;
+  tokens?: number;
+  tts_chars?: number;
+  turns?: number;
+}
+
+export interface AnalyticsIntent {
+  count?: number;
+  failed?: number;
+  /** 由确定性处理器完成的次数 */
+  handled?: number;
+  intent?: string;
+}
+
+export interface AnalyticsProvider {
+  avg_duration_ms?: number;
+  calls?: number;
+  capability?: string;
+  error_rate?: number;
+  errors?: number;
+  provider?: string;
+}
+
+export interface AnalyticsRollup {
+  computed_at?: string;
+  /** 按语句数从多到少 */
+  devices?: AnalyticsDevice[];
+  /** 按次数从多到少 */
+  intents?: AnalyticsIntent[];
+  /** day/week */
+  period?: string;
+  /** 按提供者和能力排列 */
+  providers?: AnalyticsProvider[];
+  /** 周期第一天 YYYY-MM-DD */
+  start?: string;
+  usage?: AnalyticsUsage;
+}
+
+export interface AnalyticsUsage {
+  active_devices?: number;
+  /** LLM首个输出的平均耗时 */
+  avg_first_token_ms?: number;
+  /** 说话结束到首帧音频下发的平均耗时 */
+  avg_latency_ms?: number;
+  conversations?: number;
+  llm_calls?: number;
+  /** 送入ASR的语音时长 */
+  speech_ms?: number;
+  tokens?: number;
+  /** 合成播报的字数 */
+  tts_chars?: number;
+  /** 用户语句数 */
+  turns?: number;
+}
+
+export interface ApprovalDecisionRequest {
+  approved: boolean;
+  /** falls back to the X-Approver header, then the client IP */
+  approver?: string;
+  comment?: string;
+}
+
+export type ApprovalStatus = 'pending' | 'approved' | 'rejected' | 'expired' | 'cancelled';
+
+export interface ApprovalTask {
+  approver?: string;
+  comment?: string;
+  created_at?: string;
+  /** 节点输入，供审批人查看 */
+  data?: Record<string, unknown>;
+  decided_at?: string;
+  description?: string;
+  execution_id?: string;
+  expires_at?: string;
+  id?: string;
+  node_id?: string;
+  on_timeout?: string;
+  status?: ApprovalStatus;
+  title?: string;
+  workflow_id?: string;
+}
+
+export interface BackupInfo {
+  /** 自动备份，超出保留数量时被清理 */
+  auto?: boolean;
+  created_at?: string;
+  name?: string;
+  size?: number;
+}
+
+export interface BackupListResponse {
+  backups?: BackupInfo[];
+  dir?: string;
+  /** 自动备份间隔，为空表示只手动备份 */
+  interval?: string;
+  keep?: number;
+  /** 备份的文件和目录 */
+  paths?: string[];
+}
+
+export interface BackupPassphraseRequest {
+  passphrase?: string;
+}
+
+export interface BackupRestoreResponse {
+  bytes?: number;
+  /** 备份创建时间 */
+  created_at?: string;
+  /** 为空表示不含数据库快照 */
+  database?: string;
+  driver?: string;
+  files?: number;
+  name?: string;
+  /** 恢复在下次启动时生效 */
+  restart_required?: boolean;
+  roots?: string[];
+}
+
+export interface BackupVerifyResponse {
+  bytes?: number;
+  /** 备份创建时间 */
+  created_at?: string;
+  /** 为空表示不含数据库快照 */
+  database?: string;
+  driver?: string;
+  files?: number;
+  name?: string;
+  roots?: string[];
+}
+
+export interface BindDeviceRequest {
+  device_id: string;
+  /** 默认 member */
+  role?: string;
+}
+
+export interface BootReport {
+  /** 决定启动总耗时的步骤链 */
+  critical_path?: string[];
+  /** 启动总耗时 */
+  duration_ms?: number;
+  error?: string;
+  /** 启动未结束时为空 */
+  finished_at?: string;
+  started_at?: string;
+  /** running, completed, failed */
+  status?: string;
+  /** 各步骤耗时之和，大于总耗时的部分来自并行执行 */
+  step_total_ms?: number;
+  /** 按开始时间排序 */
+  steps?: BootStepTiming[];
+}
+
+export interface BootStepTiming {
+  depends_on?: string[];
+  duration_ms?: number;
+  error?: string;
+  id?: string;
+  /** 相对启动开始的时间 */
+  offset_ms?: number;
+  /** pending, running, completed, failed, skipped */
+  status?: string;
+  timeout_ms?: number;
+  title?: string;
+}
+
+export interface BudgetLimits {
+  daily_tokens?: number;
+  /** 预算名称，没有匹配的预算时为空 */
+  profile?: string;
+  session_tokens?: number;
+  turn_tokens?: number;
+}
+
+export interface CapabilityDef {
+  config_schema?: CapabilitySchema;
+  description?: string;
+  enabled?: boolean;
+  id?: string;
+  input_schema?: CapabilitySchema;
+  name?: string;
+  output_schema?: CapabilitySchema;
+  type?: string;
+}
+
+export interface CapabilityProperty {
+  default?: unknown;
+  description?: string;
+  secret?: boolean;
+  type?: string;
+}
+
+export interface CapabilitySchema {
+  properties?: Record<string, CapabilityProperty>;
+  required?: string[];
+  type?: string;
+}
+
+export interface Change {
+  action?: Action;
+  after?: unknown;
+  applied?: boolean;
+  before?: unknown;
+  error?: string;
+  /** 更新时值发生变化的字段 */
+  fields?: string[];
+  kind?: string;
+  name?: string;
+}
+
+export interface ChaosClearResponse {
+  deleted?: number;
+}
+
+export interface ChaosRuleInfo {
+  action?: string;
+  created_at?: string;
+  delay_ms?: number;
+  expires_at?: string;
+  hits?: number;
+  id?: string;
+  match?: string;
+  max_hits?: number;
+  message?: string;
+  method?: string;
+  probability?: number;
+  status_code?: number;
+  target?: string;
+}
+
+export interface ChaosRuleListResponse {
+  rules?: ChaosRuleInfo[];
+}
+
+export interface ChaosRuleRequest {
+  action: string;
+  delay_ms?: number;
+  /** 提供者或插件ID，支持 * 通配，为空时匹配全部 */
+  match?: string;
+  /** 注入次数上限，0 表示不限 */
+  max_hits?: number;
+  message?: string;
+  /** 能力ID、RPC 方法名或请求主机名，支持 * 通配，为空时匹配全部 */
+  method?: string;
+  /** 缺省为 1 */
+  probability?: number;
+  /** HTTP error 返回的状态码，缺省 503 */
+  status_code?: number;
+  /** capability 能力调用，grpc 插件 gRPC 调用，http 提供者 HTTP 请求 */
+  target: string;
+  /** 有效期，缺省使用 Chaos.RuleTTL */
+  ttl_seconds?: number;
+}
+
+export interface ChatRequest {
+  /** 关联的设备，在线时进入设备当前的会话 */
+  device_id?: string;
+  /** 继续之前的会话，为空时按设备生成或新建 */
+  session_id?: string;
+  /** 以SSE逐段推送回复 */
+  stream?: boolean;
+  text: string;
+}
+
+export interface ChatResponse {
+  /** 输入被内容审核拦截，reply 为拦截回复 */
+  blocked?: boolean;
+  device_id?: string;
+  reply?: string;
+  round?: number;
+  segments?: string[];
+  session_id?: string;
+}
+
+export interface CheckResult {
+  /** 按 Endpoints 改写后实际请求的地址 */
+  endpoint?: string;
+  error?: string;
+  latency_ms?: number;
+  provider?: string;
+  /** 经过的代理（隐藏密码），为空表示直连 */
+  proxy?: string;
+  /** 建立连接的对端地址，经过代理时为代理地址 */
+  remote_addr?: string;
+  status_code?: number;
+  /** 收到响应即成功，不判断状态码 */
+  success?: boolean;
+  tls_version?: string;
+  /** 测试的原始地址 */
+  url?: string;
+}
+
+export interface ClassStats {
+  admitted?: number;
+  avg_wait_ms?: number;
+  max_wait_ms?: number;
+  /** 队列已满直接拒绝 */
+  rejected?: number;
+  /** 排队中被更高优先级挤出 */
+  shed?: number;
+  /** 排队超时或调用方取消 */
+  timed_out?: number;
+  waiting?: number;
+}
+
+export interface ConversationDetailResponse {
+  conversation?: ConversationInfo;
+  entries?: TranscriptEntryInfo[];
+  /** 各轮耗时 */
+  latencies?: TurnLatencyInfo[];
+}
+
+export interface ConversationInfo {
+  device_id?: string;
+  entries?: number;
+  last_at?: string;
+  rounds?: number;
+  session_id?: string;
+  started_at?: string;
+}
+
+export interface ConversationListResponse {
+  conversations?: ConversationInfo[];
+  pagination?: Pagination;
+}
+
+export interface DBOperationStats {
+  avg_ms?: number;
+  count?: number;
+  errors?: number;
+  /** 因 database is locked / busy 失败的次数 */
+  locked?: number;
+  max_ms?: number;
+  /** query/create/update/delete/row/raw */
+  operation?: string;
+}
+
+export interface DBPoolStats {
+  idle?: number;
+  in_use?: number;
+  max_open_conns?: number;
+  open?: number;
+  /** 等待空闲连接的累计次数 */
+  wait_count?: number;
+  wait_ms?: number;
+}
+
+export interface DBStats {
+  driver?: string;
+  journal_mode?: string;
+  operations?: DBOperationStats[];
+  pool?: DBPoolStats;
+  since?: string;
+  write_queue?: DBWriteQueueStats;
+}
+
+export interface DBWriteQueueStats {
+  avg_wait_ms?: number;
+  enabled?: boolean;
+  max_wait_ms?: number;
+  /** 当前排队中的写操作 */
+  waiting?: number;
+  writes?: number;
+}
+
+export interface Definition {
+  /** Static config (API keys, model selection) */
+  config_schema?: Schema;
+  description?: string;
+  /** Unique ID, e.g., "openai_chat" */
+  id?: string;
+  /** Runtime inputs (messages, audio bytes) */
+  input_schema?: Schema;
+  /** Human readable name */
+  name?: string;
+  /** Runtime outputs (text, audio bytes) */
+  output_schema?: Schema;
+  /** llm, asr, etc. */
+  type?: Type;
+  /** 工作流编辑器的展示提示，主要用于 TypeNode */
+  ui?: UIHints;
+}
+
+export interface DeployTemplateRequest {
+  parameters?: Record<string, unknown>;
+}
+
+export interface DeviceActivationRequest {
+  activation_code: string;
+  configuration?: Record<string, unknown>;
+  device_name?: string;
+}
+
+export interface DeviceActivationResponse {
+  access_token?: string;
+  device_info?: DeviceInfo;
+  device_token?: string;
+  expires_in?: number;
+  message?: string;
+  success?: boolean;
+}
+
+export interface DeviceBindingInfo {
+  created_at?: string;
+  device_id?: string;
+  role?: string;
+  user_id?: number;
+}
+
+export interface DeviceDataErasureResponse {
+  /** 按数据类别统计的删除条数，如 recordings、transcripts */
+  deleted?: Record<string, number>;
+  device_id?: string;
+}
+
+export interface DeviceDebugAuditInfo {
+  /** logs/commands/run/config */
+  action?: string;
+  client_ip?: string;
+  command?: string;
+  created_at?: string;
+  device_id?: string;
+  duration_ms?: number;
+  error?: string;
+  id?: string;
+  /** 发给设备的参数 JSON */
+  params?: string;
+  request_id?: string;
+  session_id?: string;
+  success?: boolean;
+}
+
+export interface DeviceDebugAuditListResponse {
+  pagination?: Pagination;
+  records?: DeviceDebugAuditInfo[];
+}
+
+export interface DeviceDebugConfigRequest {
+  /** 覆盖的配置项，由固件定义 */
+  overrides: Record<string, unknown>;
+  /** 有效期，缺省或超过上限时使用 DeviceDebug.MaxOverrideTTL */
+  ttl_seconds?: number;
+}
+
+export interface DeviceDebugResult {
+  audit_id?: string;
+  duration_ms?: number;
+  /** 设备返回的结果，原样透传 */
+  result?: unknown;
+  session_id?: string;
+}
+
+export interface DeviceDebugRunRequest {
+  /** 命令参数，由固件定义 */
+  args?: Record<string, unknown>;
+}
+
+export interface DeviceGroup {
+  devices?: string[];
+  name?: string;
+  prompt?: string;
+  /** 0 表示跟随当前生效版本 */
+  prompt_version?: number;
+}
+
+export interface DeviceInfo {
+  configuration?: Record<string, unknown>;
+  created_at?: string;
+  device_id?: string;
+  device_name?: string;
+  device_type?: string;
+  firmware?: FirmwareInfo;
+  id?: number;
+  is_activated?: boolean;
+  is_active?: boolean;
+  last_seen?: string;
+  location?: DeviceLocation;
+  /** 设备正在播放或暂停中的媒体，仅设备详情返回 */
+  media?: MediaState;
+  metadata?: Record<string, unknown>;
+  model?: string;
+  /** online, offline, error, unknown */
+  status?: string;
+  tenant_id?: string;
+  updated_at?: string;
+  version?: string;
+}
+
+export interface DeviceKeyInfo {
+  created_at?: string;
+  device_id?: string;
+  id?: string;
+  /** 最近一次通过握手校验的时间 */
+  last_used_at?: string;
+  public_key?: string;
+  revoked?: boolean;
+  revoked_at?: string;
+}
+
+export interface DeviceKeyIssueRequest {
+  /** 同时吊销设备的其他密钥 */
+  revoke_previous?: boolean;
+}
+
+export interface DeviceLanguagesInfo {
+  /** 为 false 时是配置的默认值 */
+  custom?: boolean;
+  device_id?: string;
+  /** 第一个为主要语言，使用设备默认音色 */
+  languages?: string[];
+  /** 使用默认值时为空 */
+  updated_at?: string;
+}
+
+export interface DeviceLanguagesRequest {
+  /** 语言代码或中文叫法，第一个为主要语言 */
+  languages: string[];
+}
+
+export interface DeviceListResponse {
+  devices?: DeviceInfo[];
+  pagination?: Pagination;
+}
+
+export interface DeviceLocation {
+  address?: string;
+  altitude?: number;
+  city?: string;
+  country?: string;
+  latitude?: number;
+  longitude?: number;
+  province?: string;
+}
+
+export interface DeviceMemberInfo {
+  created_at?: string;
+  device_id?: string;
+  profile?: MemberProfileInfo;
+  role?: string;
+  user_id?: number;
+}
+
+export interface DeviceRegistrationRequest {
+  device_id: string;
+  device_name: string;
+  device_type: string;
+  location?: DeviceLocation;
+  metadata?: Record<string, unknown>;
+  model?: string;
+  version?: string;
+}
+
+export interface DeviceStatusRequest {
+  /** 设备MAC地址 */
+  device_id: string;
+  /** 激活状态：true激活，false禁用 */
+  is_active: boolean;
+}
+
+export interface DeviceStatusResponse {
+  device_info?: DeviceInfo;
+  message?: string;
+  success?: boolean;
+}
+
+export interface DeviceTokenUsageInfo {
+  /** LLM调用次数 */
+  calls?: number;
+  day?: string;
+  device_id?: string;
+  /** 是否启用预算检查 */
+  enabled?: boolean;
+  limits?: BudgetLimits;
+  /** 因超出预算未调用LLM的次数 */
+  rejected?: number;
+  /** 当天剩余的Token，未设置每日上限时为空 */
+  remaining?: number;
+  tokens?: number;
+}
+
+export interface DeviceUpdateRequest {
+  configuration?: Record<string, unknown>;
+  device_name?: string;
+  is_active?: boolean;
+  location?: DeviceLocation;
+  metadata?: Record<string, unknown>;
+  /** 转移到其他租户，仅管理员可用 */
+  tenant_id?: string;
+}
+
+export interface DrainRequest {
+  /** 排空原因，记录到日志 */
+  reason?: string;
+  /** 等待活跃会话结束的最长秒数，0 表示使用配置值 */
+  timeout_seconds?: number;
+}
+
+export interface DrainStatus {
+  /** 各类型活跃工作数：sessions、workflows */
+  active?: Record<string, number>;
+  deadline?: string;
+  reason?: string;
+  /** 距截止时间的秒数 */
+  remaining_seconds?: number;
+  started_at?: string;
+  /** serving, draining, drained */
+  state?: string;
+  timed_out?: boolean;
+}
+
+export interface DryRunIssue {
+  edge_id?: string;
+  field?: string;
+  message?: string;
+  node_id?: string;
+  severity?: string;
+}
+
+export interface DryRunNode {
+  capability_id?: string;
+  /** 合并全局配置和默认值后的配置，敏感字段已脱敏 */
+  config?: Record<string, unknown>;
+  issues?: DryRunIssue[];
+  node_id?: string;
+  provider_id?: string;
+  type?: NodeType;
+}
+
+export interface DryRunReport {
+  /** 全部问题，包括节点和边上的问题 */
+  issues?: DryRunIssue[];
+  nodes?: DryRunNode[];
+  /** 拓扑执行顺序 */
+  order?: string[];
+  /** 没有 error 级别的问题 */
+  valid?: boolean;
+  workflow_id?: string;
+}
+
+export type Duration = number;
+
+export interface Edge {
+  from?: string;
+  id?: string;
+  label?: string;
+  to?: string;
+  weight?: number;
+}
+
+export interface Entry {
+  level?: string;
+  message?: string;
+  plugin_id?: string;
+  seq?: number;
+  stream?: string;
+  time?: string;
+}
+
+export interface EvalCase {
+  /** ASR 音频，base64 编码 */
+  audio?: string;
+  /** 期望回答或参考文本 */
+  expected?: string;
+  id?: string;
+  /** LLM 提示词或 TTS 文本 */
+  input?: string;
+}
+
+export interface EvalCaseComparison {
+  case_id?: string;
+  expected?: string;
+  input?: string;
+  /** key: 运行ID/能力ID */
+  results?: Record<string, EvalCaseResult>;
+}
+
+export interface EvalCaseResult {
+  case_id?: string;
+  error?: string;
+  judge_score?: number;
+  latency_ms?: number;
+  output?: string;
+  similarity?: number;
+  wer?: number;
+}
+
+export interface EvalCompareResponse {
+  cases?: EvalCaseComparison[];
+  runs?: EvalRunInfo[];
+  suite_id?: string;
+}
+
+export interface EvalRunInfo {
+  created_at?: string;
+  error?: string;
+  finished_at?: string;
+  id?: string;
+  judge?: EvalTarget;
+  reports?: EvalTargetReport[];
+  status?: string;
+  suite_id?: string;
+  suite_type?: string;
+  targets?: EvalTarget[];
+}
+
+export interface EvalRunListResponse {
+  pagination?: Pagination;
+  runs?: EvalRunInfo[];
+}
+
+export interface EvalRunRequest {
+  /** LLM 评审，仅 llm 测试集可用 */
+  judge?: EvalTarget;
+  suite_id: string;
+  targets: EvalTarget[];
+}
+
+export interface EvalSuiteCreateRequest {
+  cases: EvalCase[];
+  description?: string;
+  name: string;
+  system_prompt?: string;
+  type: string;
+}
+
+export interface EvalSuiteInfo {
+  case_count?: number;
+  cases?: EvalCase[];
+  created_at?: string;
+  description?: string;
+  id?: string;
+  name?: string;
+  system_prompt?: string;
+  type?: string;
+}
+
+export interface EvalSuiteListResponse {
+  pagination?: Pagination;
+  suites?: EvalSuiteInfo[];
+}
+
+export interface EvalTarget {
+  capability_id: string;
+  config?: Record<string, unknown>;
+}
+
+export interface EvalTargetReport {
+  avg_judge_score?: number;
+  avg_latency_ms?: number;
+  avg_similarity?: number;
+  avg_wer?: number;
+  capability_id?: string;
+  cases?: EvalCaseResult[];
+  p95_latency_ms?: number;
+  succeeded?: number;
+  total?: number;
+}
+
+export interface ExecuteCapabilityRequest {
+  config?: Record<string, unknown>;
+  inputs?: Record<string, unknown>;
+  /** repair attempts, default 2, at most 5 */
+  max_repairs?: number;
+  /** ResponseFormat requests JSON output from an LLM capability: {"type": "json_object"} or {"type": "json_schema", "name": "...", "schema": {...}}; invalid output is sent back to the LLM for repair */
+  response_format?: Record<string, unknown>;
+  /** e.g. 30s, at most 5m */
+  timeout?: string;
+}
+
+export interface ExecuteCapabilityResponse {
+  /** generations including repairs when response_format is set */
+  attempts?: number;
+  capability_id?: string;
+  /** streamed chunks, 0 for non-streaming calls */
+  chunks?: number;
+  elapsed_ms?: number;
+  /** time to the first chunk */
+  first_chunk_ms?: number;
+  /** parsed output when response_format is set */
+  json?: unknown;
+  outputs?: Record<string, unknown>;
+  /** provider and upstream request IDs of every call made */
+  traces?: Trace[];
+}
+
+export interface ExecuteWorkflowRequest {
+  inputs?: Record<string, unknown>;
+  /** unsaved workflow to execute or validate instead of the stored one */
+  workflow?: Workflow;
+}
+
+export interface Execution {
+  /** 执行上下文 */
+  context?: Record<string, unknown>;
+  end_time?: string;
+  /** 执行错误 */
+  error?: string;
+  id?: string;
+  /** 输入参数 */
+  inputs?: Record<string, unknown>;
+  /** 执行日志 */
+  logs?: ExecutionLog[];
+  /** 节点执行结果 */
+  node_results?: Record<string, NodeResult>;
+  /** 输出结果 */
+  outputs?: Record<string, unknown>;
+  /** 发起执行的请求关联ID */
+  request_id?: string;
+  start_time?: string;
+  status?: ExecutionStatus;
+  /** 发起执行的租户 */
+  tenant_id?: string;
+  workflow_id?: string;
+}
+
+export interface ExecutionLog {
+  details?: Record<string, unknown>;
+  /** info, warn, error */
+  level?: string;
+  message?: string;
+  node_id?: string;
+  timestamp?: string;
+}
+
+export type ExecutionStatus = 'pending' | 'running' | 'paused' | 'completed' | 'failed' | 'cancelled';
+
+export interface ExperimentCreateRequest {
+  cohort?: string[];
+  description?: string;
+  name: string;
+  traffic_percent?: number;
+  variants: ExperimentVariant[];
+}
+
+export interface ExperimentInfo {
+  cohort?: string[];
+  created_at?: string;
+  description?: string;
+  id?: string;
+  name?: string;
+  started_at?: string;
+  status?: string;
+  stopped_at?: string;
+  traffic_percent?: number;
+  variants?: ExperimentVariant[];
+}
+
+export interface ExperimentListResponse {
+  experiments?: ExperimentInfo[];
+  pagination?: Pagination;
+}
+
+export interface ExperimentResultsResponse {
+  experiment_id?: string;
+  name?: string;
+  status?: string;
+  variants?: ExperimentVariantResult[];
+}
+
+export interface ExperimentVariant {
+  cost_per_1k_token?: number;
+  llm?: string;
+  name: string;
+  prompt_template?: string;
+  prompt_version?: number;
+  weight?: number;
+}
+
+export interface ExperimentVariantResult {
+  avg_latency_ms?: number;
+  avg_tokens?: number;
+  completion_tokens?: number;
+  estimated_cost?: number;
+  interruption_rate?: number;
+  prompt_tokens?: number;
+  samples?: number;
+  satisfaction_rate?: number;
+  thumbs_down?: number;
+  thumbs_up?: number;
+  variant?: string;
+}
+
+export interface FeedbackDailyInfo {
+  date?: string;
+  thumbs_down?: number;
+  thumbs_up?: number;
+}
+
+export interface FeedbackInfo {
+  comment?: string;
+  created_at?: string;
+  device_id?: string;
+  entry_id?: string;
+  id?: string;
+  rating?: string;
+  round?: number;
+  session_id?: string;
+  source?: string;
+}
+
+export interface FeedbackListResponse {
+  feedback?: FeedbackInfo[];
+  pagination?: Pagination;
+}
+
+export interface FeedbackRequest {
+  comment?: string;
+  /** up/down，可为空仅提交文字 */
+  rating?: string;
+}
+
+export interface FeedbackStatsResponse {
+  by_source?: Record<string, number>;
+  daily?: FeedbackDailyInfo[];
+  from?: string;
+  satisfaction_rate?: number;
+  thumbs_down?: number;
+  thumbs_up?: number;
+  to?: string;
+  total?: number;
+  with_comment?: number;
+}
+
+export interface FirmwareInfo {
+  checksum?: string;
+  description?: string;
+  download_count?: number;
+  release_date?: string;
+  size?: number;
+  url?: string;
+  version?: string;
+}
+
+export interface FlagDecision {
+  device_id?: string;
+  enabled?: boolean;
+  key?: string;
+  /** 命中的规则名称，未命名时为 "#序号" */
+  rule?: string;
+  /** rule / flag / default / unknown */
+  source?: string;
+  tenant_id?: string;
+}
+
+export interface FlagInfo {
+  /** 内置开关，删除后恢复默认值 */
+  built_in?: boolean;
+  description?: string;
+  enabled?: boolean;
+  key?: string;
+  rules?: FlagRule[];
+  /** 为 false 时是内置开关的默认值 */
+  stored?: boolean;
+  /** 未修改过的内置开关为空 */
+  updated_at?: string;
+}
+
+export interface FlagListResponse {
+  flags?: FlagInfo[];
+}
+
+export interface FlagRule {
+  /** 设备ID，空表示租户下的全部设备 */
+  devices?: string[];
+  enabled?: boolean;
+  /** 设备分组名称 */
+  name?: string;
+  /** 租户ID，空表示任意租户 */
+  tenants?: string[];
+}
+
+export interface FlagSetRequest {
+  description?: string;
+  /** 没有匹配的规则时的取值 */
+  enabled?: boolean;
+  /** 按顺序匹配，第一条匹配的规则决定取值 */
+  rules?: FlagRule[];
+}
+
+export interface InputSchema {
+  default?: unknown;
+  description?: string;
+  name?: string;
+  required?: boolean;
+  /** string, number, boolean, object, array */
+  type?: string;
+  validation?: Validation;
+}
+
+export interface IntentRouteStats {
+  average_latency_ms?: number;
+  errors?: number;
+  /** 回落到LLM的次数 */
+  fallback_to_llm?: number;
+  /** 由确定性处理器完成的次数 */
+  handled?: number;
+  intent?: string;
+  /** 分类命中次数 */
+  matched?: number;
+}
+
+export interface IssuedDeviceKeyInfo {
+  algorithm?: string;
+  key_id?: string;
+  /** PEM 编码的 P-256 私钥 */
+  private_key?: string;
+  public_key?: string;
+}
+
+export interface JobInfo {
+  attempts?: number;
+  created_at?: string;
+  finished_at?: string;
+  id?: string;
+  key?: string;
+  last_error?: string;
+  lease_until?: string;
+  max_attempts?: number;
+  payload?: unknown;
+  /** 最早可执行时间，等待重试时为退避后的时间 */
+  run_at?: string;
+  started_at?: string;
+  /** pending、running、succeeded、dead（死信）或 canceled */
+  status?: string;
+  type?: string;
+  updated_at?: string;
+}
+
+export interface JobListResponse {
+  jobs?: JobInfo[];
+  pagination?: Pagination;
+}
+
+export interface JobStatsResponse {
+  types?: JobTypeStats[];
+}
+
+export interface JobTypeStats {
+  /** 各状态的任务数 */
+  counts?: Record<string, number>;
+  /** 本实例启动以来失败的执行次数 */
+  failures?: number;
+  /** 本实例执行中的任务数 */
+  running?: number;
+  type?: string;
+  /** 本实例的工作协程数 */
+  workers?: number;
+}
+
+export interface KnowledgeBaseCreateRequest {
+  description?: string;
+  name: string;
+}
+
+export interface KnowledgeBaseInfo {
+  created_at?: string;
+  description?: string;
+  id?: string;
+  name?: string;
+  updated_at?: string;
+}
+
+export interface KnowledgeBaseUpdateRequest {
+  description?: string;
+  name?: string;
+}
+
+export interface KnowledgeBindingInfo {
+  knowledge_base_ids?: string[];
+  profile?: string;
+}
+
+export interface KnowledgeBindingRequest {
+  /** 为空时解除绑定 */
+  knowledge_base_ids?: string[];
+}
+
+export interface KnowledgeDocumentInfo {
+  chunks?: number;
+  created_at?: string;
+  /** 生成向量使用的能力ID */
+  embedding?: string;
+  error?: string;
+  /** 导入任务的工作流执行ID */
+  execution_id?: string;
+  /** pdf/markdown/text/html */
+  format?: string;
+  id?: string;
+  knowledge_base_id?: string;
+  name?: string;
+  /** 上传文件在对象存储中的ID，可通过 /api/v1/objects/:id/url 获取下载地址 */
+  object_id?: string;
+  /** 0-100 */
+  progress?: number;
+  size?: number;
+  /** 文件名或网页地址 */
+  source?: string;
+  /** file/url */
+  source_type?: string;
+  /** pending/extracting/chunking/embedding/ready/failed */
+  status?: string;
+  updated_at?: string;
+}
+
+export interface KnowledgeHit {
+  chunk_index?: number;
+  content?: string;
+  document_id?: string;
+  document_name?: string;
+  knowledge_base_id?: string;
+  score?: number;
+}
+
+export interface KnowledgeSearchRequest {
+  /** 检索设备所属档案绑定的知识库 */
+  device_id?: string;
+  knowledge_base_ids?: string[];
+  query: string;
+  top_k?: number;
+}
+
+export interface KnowledgeSearchResponse {
+  /** 附加给 LLM 的带出处编号的参考资料 */
+  context?: string;
+  hits?: KnowledgeHit[];
+}
+
+export interface KnowledgeURLRequest {
+  /** 缺省使用网页地址 */
+  name?: string;
+  url: string;
+}
+
+export interface LanguageInfo {
+  code?: string;
+  name?: string;
+  /** 该语言的播报音色，未配置时使用设备默认音色 */
+  voice?: string;
+}
+
+export interface LanguageListResponse {
+  /** 未单独设置的设备允许的语言 */
+  allowed?: string[];
+  /** 是否启用语言自动识别 */
+  enabled?: boolean;
+  /** 支持的语言 */
+  languages?: LanguageInfo[];
+}
+
+export interface LatencyDailyInfo {
+  date?: string;
+  p50_total_ms?: number;
+  p95_total_ms?: number;
+  turns?: number;
+}
+
+export interface LatencyStatsResponse {
+  daily?: LatencyDailyInfo[];
+  from?: string;
+  stages?: StageLatencyInfo[];
+  to?: string;
+  turns?: number;
+}
+
+export interface LogEntryInfo {
+  attrs?: Record<string, unknown>;
+  level?: string;
+  message?: string;
+  tag?: string;
+  time?: string;
+}
+
+export interface LogListResponse {
+  /** 启动以来因队列已满或写入失败未进入索引的日志条数 */
+  dropped?: number;
+  entries?: LogEntryInfo[];
+  pagination?: Pagination;
+}
+
+export interface Manifest {
+  /** DeviceGroups 设备分组，组内设备分配同一个提示词模板 */
+  device_groups?: DeviceGroup[];
+  /** Prompts 提示词模板，与当前生效版本比较，修改时生成新版本 */
+  prompts?: PromptSpec[];
+  /** Providers 按类型（llm/tts/asr/vllm）列出供应商配置，键为供应商ID；只对出现的类型做增删 */
+  providers?: Record<string, Record<string, number[]>>;
+  /** Workflow 当前工作流 */
+  workflow?: Workflow;
+}
+
+export interface MediaControlRequest {
+  action: string;
+  /** action 为 play 时点播的内容，为空时随机播放 */
+  query?: string;
+  /** action 为 play 时的检索来源 */
+  source?: string;
+  /** action 为 volume 时设置的音量 */
+  volume?: number;
+}
+
+export interface MediaState {
+  device_id?: string;
+  /** 最近一次播放失败的原因 */
+  error?: string;
+  /** 当前曲目在播放列表中的位置 */
+  index?: number;
+  position_ms?: number;
+  /** 播放列表长度 */
+  queue_size?: number;
+  /** idle/loading/playing/paused */
+  status?: string;
+  track?: MediaTrack;
+  updated_at?: string;
+  /** 0-100 */
+  volume?: number;
+}
+
+export interface MediaTrack {
+  artist?: string;
+  live?: boolean;
+  /** library/radio/provider */
+  source?: string;
+  title?: string;
+  /** 电台和媒体能力返回的音频流地址，本地曲库不返回 */
+  url?: string;
+}
+
+export interface MemberProfileInfo {
+  language?: string;
+  memory_namespace?: string;
+  name?: string;
+  updated_at?: string;
+  user_id?: number;
+  voice?: string;
+}
+
+export interface MemberProfileUpdateRequest {
+  language?: string;
+  /** 置空时恢复默认的 user-<id> */
+  memory_namespace?: string;
+  name?: string;
+  voice?: string;
+}
+
+export interface MemoryClearResponse {
+  deleted?: number;
+}
+
+export interface MemoryConsentInfo {
+  /** 本次删除的记忆条数 */
+  deleted?: number;
+  granted?: boolean;
+  updated_at?: string;
+  user_id?: number;
+}
+
+export interface MemoryConsentRequest {
+  /** 撤销授权时同时删除已有记忆 */
+  delete_existing?: boolean;
+  granted: boolean;
+}
+
+export interface MemoryFactInfo {
+  content?: string;
+  created_at?: string;
+  /** 提取自该设备的对话 */
+  device_id?: string;
+  /** 对应对话记录中的用户消息 */
+  entry_id?: string;
+  /** 提取依据的用户原话 */
+  evidence?: string;
+  id?: string;
+  namespace?: string;
+  /** 提取自该会话 */
+  session_id?: string;
+  /** extracted（从对话中提取）/manual（手动添加或修改） */
+  source?: string;
+  updated_at?: string;
+}
+
+export interface MemoryFactListResponse {
+  facts?: MemoryFactInfo[];
+  pagination?: Pagination;
+}
+
+export interface MemoryFactRequest {
+  /** 最多 500 字 */
+  content: string;
+}
+
+export interface MonitorEvent {
+  at?: string;
+  round?: number;
+  session_id?: string;
+  /** type 为 snippet 时的对话片段 */
+  snippet?: MonitorSnippet;
+  /** type 为 state 时的新状态 */
+  state?: string;
+  /** state/snippet/closed */
+  type?: string;
+}
+
+export interface MonitorFlag {
+  flagged_at?: string;
+  note?: string;
+}
+
+export interface MonitorFlagRequest {
+  /** 标记原因 */
+  note?: string;
+}
+
+export interface MonitorSession {
+  connected_at?: string;
+  device_id?: string;
+  flag?: MonitorFlag;
+  /** 提供者/模型 */
+  llm?: string;
+  /** 最近的对话片段，按时间顺序 */
+  recent?: MonitorSnippet[];
+  round?: number;
+  session_id?: string;
+  /** idle/listening/thinking/speaking */
+  state?: string;
+  tenant_id?: string;
+  /** TTS 配置名 */
+  tts?: string;
+  /** 当前播报使用的音色 */
+  voice?: string;
+}
+
+export interface MonitorSnippet {
+  at?: string;
+  /** 超过 200 字的部分截断 */
+  content?: string;
+  /** user/assistant */
+  role?: string;
+}
+
+export interface Node {
+  /** 节点配置 */
+  config?: Record<string, unknown>;
+  description?: string;
+  /** 错误信息 */
+  error?: string;
+  id?: string;
+  /** 输入Schema */
+  inputs?: InputSchema[];
+  /** 调用的方法 */
+  method?: string;
+  name?: string;
+  /** 输出Schema */
+  outputs?: OutputSchema[];
+  /** 关联的插件ID */
+  plugin?: string;
+  /** 画布位置 */
+  position?: Position;
+  /** 节点状态 */
+  status?: NodeStatus;
+  type?: NodeType;
+}
+
+export interface NodeLog {
+  attempt?: number;
+  level?: string;
+  message?: string;
+  /** engine 或 plugin */
+  source?: string;
+  /** 插件输出流：stdout、stderr */
+  stream?: string;
+  timestamp?: string;
+}
+
+export interface NodeLogsResponse {
+  attempts?: number;
+  logs?: NodeLog[];
+  node_id?: string;
+  status?: NodeStatus;
+  timing?: NodeTiming;
+}
+
+export interface NodeResult {
+  /** 能力调用次数，含重试 */
+  attempts?: number;
+  elapsed_time?: Duration;
+  end_time?: string;
+  error?: string;
+  inputs?: Record<string, unknown>;
+  /** 节点日志，包括插件输出和重试记录 */
+  logs?: NodeLog[];
+  node_id?: string;
+  outputs?: Record<string, unknown>;
+  start_time?: string;
+  status?: NodeStatus;
+  timing?: NodeTiming;
+  /** 能力调用的追踪信息，含提供者返回的请求ID */
+  traces?: Trace[];
+}
+
+export type NodeStatus = 'pending' | 'running' | 'completed' | 'failed' | 'skipped';
+
+export interface NodeTiming {
+  /** 能力调用，含重试 */
+  execution?: Duration;
+  /** 解析输入 */
+  inputs?: Duration;
+  /** 等待执行槽 */
+  queue?: Duration;
+  /** 重试前的等待，包含在 Execution 中 */
+  retry_wait?: Duration;
+  /** 输出校验 */
+  validation?: Duration;
+}
+
+export type NodeType = 'start' | 'end' | 'task' | 'condition' | 'parallel' | 'merge' | 'approval' | 'transform' | 'http';
+
+export interface NodeTypeDefinition {
+  builtin?: boolean;
+  config_schema?: Schema;
+  description?: string;
+  input_schema?: Schema;
+  name?: string;
+  output_schema?: Schema;
+  /** 注册来源：capability 表示从能力注册表同步 */
+  source?: string;
+  type?: NodeType;
+  ui?: UIHints;
+}
+
+export interface NotificationChannelCreateRequest {
+  config: Record<string, unknown>;
+  enabled?: boolean;
+  /** plugin_crashed, provider_outage, budget_exceeded 或 * */
+  events?: string[];
+  name: string;
+  type: string;
+}
+
+export interface NotificationChannelInfo {
+  config?: Record<string, unknown>;
+  created_at?: string;
+  enabled?: boolean;
+  events?: string[];
+  id?: string;
+  name?: string;
+  type?: string;
+  updated_at?: string;
+}
+
+export interface NotificationChannelUpdateRequest {
+  /** 敏感字段传 ****** 表示保留原值 */
+  config?: Record<string, unknown>;
+  enabled?: boolean;
+  events?: string[];
+  name?: string;
+}
+
+export interface ObjectInfo {
+  content_type?: string;
+  created_at?: string;
+  /** 为空表示由引用方释放 */
+  expires_at?: string;
+  /** 内容的 SHA-256 */
+  id?: string;
+  name?: string;
+  namespace?: string;
+  /** 长期引用数 */
+  refs?: number;
+  size?: number;
+  updated_at?: string;
+}
+
+export interface ObjectListResponse {
+  objects?: ObjectInfo[];
+  pagination?: Pagination;
+}
+
+export interface ObjectURLResponse {
+  expires_at?: string;
+  id?: string;
+  url?: string;
+}
+
+export interface OutputSchema {
+  description?: string;
+  name?: string;
+  type?: string;
+}
+
+export interface Pagination {
+  has_next?: boolean;
+  has_prev?: boolean;
+  limit?: number;
+  page?: number;
+  total?: number;
+  total_pages?: number;
+}
+
+export interface PairingTokenInfo {
+  expires_at?: string;
+  /** 二维码内容 */
+  payload?: string;
+  token?: string;
+}
+
+export interface Plan {
+  changes?: Change[];
+  dry_run?: boolean;
+  /** 已与清单一致的资源数 */
+  unchanged?: number;
+}
+
+export interface PluginControlRequest {
+  action: string;
+  config?: Record<string, unknown>;
+}
+
+export interface PluginControlResponse {
+  message?: string;
+  new_port?: number;
+  new_status?: string;
+  old_port?: number;
+  old_status?: string;
+  process_time?: string;
+  success?: boolean;
+}
+
+export interface PluginDebugInfo {
+  address?: string;
+  go_version?: string;
+  log_level?: string;
+  name?: string;
+  pid?: number;
+  plugin_id?: string;
+  /** 已注册的gRPC服务全名 */
+  services?: string[];
+  started_at?: string;
+  uptime_seconds?: number;
+  version?: string;
+}
+
+export interface PluginDebugMetrics {
+  methods?: PluginMethodMetrics[];
+  plugin_id?: string;
+  /** goroutines、heap_alloc_bytes、heap_sys_bytes、gc_count、gc_pause_total_ms */
+  runtime?: Record<string, number>;
+}
+
+export interface PluginListResponse {
+  page?: number;
+  page_size?: number;
+  plugins?: PluginStatus[];
+  total?: number;
+  total_pages?: number;
+}
+
+export interface PluginLogLevelRequest {
+  level: string;
+}
+
+export interface PluginLogLevelResponse {
+  level?: string;
+  plugin_id?: string;
+  previous_level?: string;
+}
+
+export interface PluginLogsResponse {
+  entries?: Entry[];
+  plugin_id?: string;
+  /** 缓冲区中的日志行数 */
+  total?: number;
+}
+
+export interface PluginMethodMetrics {
+  calls?: number;
+  errors?: number;
+  max_ms?: number;
+  method?: string;
+  total_ms?: number;
+}
+
+export interface PluginStats {
+  by_capability?: Record<string, number>;
+  by_type?: Record<string, number>;
+  disabled?: number;
+  enabled?: number;
+  error?: number;
+  healthy?: number;
+  last_updated?: string;
+  running?: number;
+  stopped?: number;
+  total?: number;
+  unknown?: number;
+}
+
+export interface PluginStatus {
+  address?: string;
+  capabilities?: CapabilityDef[];
+  created_at?: string;
+  description?: string;
+  health_status?: string;
+  id?: string;
+  last_health_check?: string;
+  name?: string;
+  port?: number;
+  status?: string;
+  type?: string;
+  updated_at?: string;
+  version?: string;
+}
+
+export interface PortAllocation {
+  address?: string;
+  plugin_id?: string;
+  port?: number;
+  /** "allocated", "released", "reserved", "orphaned" */
+  status?: string;
+  timestamp?: string;
+}
+
+export interface PortAllocationsResponse {
+  allocations?: PortAllocation[];
+  history?: PortEvent[];
+  stats?: PortStats;
+}
+
+export interface PortEvent {
+  plugin_id?: string;
+  port?: number;
+  reason?: string;
+  status?: string;
+  timestamp?: string;
+}
+
+export interface PortStats {
+  allocated_ports?: number;
+  available_ports?: number;
+  orphaned_ports?: number;
+  reserved_ports?: number;
+  total_ports?: number;
+  unix_sockets?: number;
+  usage_percent?: number;
+}
+
+export interface Position {
+  x?: number;
+  y?: number;
+}
+
+export interface PresenceCheckInRequest {
+  /** 定位精度（米） */
+  accuracy?: number;
+  latitude?: number;
+  longitude?: number;
+  state?: string;
+}
+
+export interface PresenceOverview {
+  anyone_home?: boolean;
+  /** 在家成员的称呼 */
+  home?: string[];
+  members?: PresenceStatus[];
+}
+
+export interface PresenceRouterReport {
+  macs: string[];
+}
+
+export interface PresenceRouterReportResponse {
+  /** 其中属于成员的设备数 */
+  matched?: number;
+}
+
+export interface PresenceStatus {
+  name?: string;
+  /** 进入当前状态的时间 */
+  since?: string;
+  /** 进入当前状态的来源：app / geofence / router */
+  source?: string;
+  /** home / away / unknown */
+  state?: string;
+  updated_at?: string;
+  user_id?: number;
+}
+
+export interface PromptAssignRequest {
+  template_name: string;
+  /** 0 表示跟随生效版本 */
+  version?: number;
+}
+
+export interface PromptAssignmentInfo {
+  device_id?: string;
+  template_name?: string;
+  updated_at?: string;
+  version?: number;
+}
+
+export interface PromptCreateRequest {
+  content: string;
+  description?: string;
+  name: string;
+  variables?: Record<string, string>;
+}
+
+export interface PromptInfo {
+  active?: boolean;
+  content?: string;
+  created_at?: string;
+  description?: string;
+  id?: string;
+  name?: string;
+  variables?: Record<string, string>;
+  version?: number;
+}
+
+export interface PromptListResponse {
+  pagination?: Pagination;
+  prompts?: PromptInfo[];
+}
+
+export interface PromptRenderRequest {
+  content?: string;
+  /** Content 的变量默认值 */
+  variables?: Record<string, string>;
+  /** 本次渲染传入的变量 */
+  vars?: Record<string, string>;
+  version?: number;
+}
+
+export interface PromptRenderResponse {
+  name?: string;
+  rendered?: string;
+  version?: number;
+}
+
+export interface PromptSpec {
+  content?: string;
+  description?: string;
+  name?: string;
+  variables?: Record<string, string>;
+}
+
+export interface PromptUpdateRequest {
+  content?: string;
+  description?: string;
+  variables?: Record<string, string>;
+}
+
+export interface Property {
+  default?: unknown;
+  description?: string;
+  enum?: unknown[];
+  /** For arrays */
+  items?: Schema;
+  /** For sensitive config like API keys */
+  secret?: boolean;
+  type?: string;
+}
+
+export interface ProviderCallInfo {
+  capability_id?: string;
+  capability_type?: string;
+  chunks?: number;
+  created_at?: string;
+  device_id?: string;
+  duration_ms?: number;
+  error?: string;
+  id?: string;
+  provider_id?: string;
+  /** JSON，包含 config 和 inputs，已脱敏 */
+  request?: string;
+  /** JSON，流式调用为分块数组，已脱敏 */
+  response?: string;
+  session_id?: string;
+  stream?: boolean;
+  success?: boolean;
+}
+
+export interface ProviderCallListResponse {
+  calls?: ProviderCallSummary[];
+  pagination?: Pagination;
+}
+
+export interface ProviderCallSummary {
+  capability_id?: string;
+  capability_type?: string;
+  chunks?: number;
+  created_at?: string;
+  device_id?: string;
+  duration_ms?: number;
+  error?: string;
+  id?: string;
+  provider_id?: string;
+  session_id?: string;
+  stream?: boolean;
+  success?: boolean;
+}
+
+export interface ProviderConnectivityRequest {
+  /** 与能力配置中的 network 格式相同，叠加在提供者的网络配置之上 */
+  network?: Record<string, unknown>;
+  /** 连接池中的提供者名称，与 HTTPClient.Providers 的键相同，如 openai */
+  provider: string;
+  /** 如 10s，最长 1m */
+  timeout?: string;
+  /** 测试地址，如提供者的 base_url */
+  url: string;
+}
+
+export interface ProviderStats {
+  avg_tls_handshake_ms?: number;
+  /** 走 HTTP/2 的请求数 */
+  http2_requests?: number;
+  /** 新建连接的请求数 */
+  new_conns?: number;
+  provider?: string;
+  requests?: number;
+  /** 复用率 0~1 */
+  reuse_rate?: number;
+  /** 复用已有连接的请求数 */
+  reused_conns?: number;
+  /** TLS 握手次数 */
+  tls_handshakes?: number;
+}
+
+export interface ProviderTestRequest {
+  capability_id: string;
+  /** 待测试的能力配置，如 api_key、model */
+  config?: Record<string, unknown>;
+  /** 如 10s，最长 1m */
+  timeout?: string;
+}
+
+export interface ProviderTestResult {
+  capability_id?: string;
+  latency_ms?: number;
+  message?: string;
+  provider_id?: string;
+  success?: boolean;
+  type?: string;
+}
+
+export interface ProvisioningClaimRequest {
+  /** 为空时使用 client_<设备ID> */
+  client_id?: string;
+  device_id: string;
+  device_name?: string;
+  device_type?: string;
+  model?: string;
+  token: string;
+  version?: string;
+}
+
+export interface ProvisioningClaimResponse {
+  client_id?: string;
+  device_id?: string;
+  /** 连接 WebSocket 时以 Authorization: Bearer 携带 */
+  device_token?: string;
+  key?: IssuedDeviceKeyInfo;
+  tenant_id?: string;
+  /** 设备所有者 */
+  user_id?: number;
+  websocket?: string;
+}
+
+export interface QueueStats {
+  active?: number;
+  classes?: Record<string, ClassStats>;
+  max_concurrent?: number;
+}
+
+export interface RecordingConsentInfo {
+  /** 本次删除的录音条数 */
+  deleted?: number;
+  device_id?: string;
+  granted?: boolean;
+  updated_at?: string;
+}
+
+export interface RecordingConsentRequest {
+  /** 撤销授权时同时删除已归档的语音 */
+  delete_existing?: boolean;
+  granted: boolean;
+}
+
+export interface RecordingInfo {
+  channels?: number;
+  created_at?: string;
+  device_id?: string;
+  duration_ms?: number;
+  /** 对应的对话记录消息ID */
+  entry_id?: string;
+  format?: string;
+  id?: string;
+  round?: number;
+  sample_rate?: number;
+  session_id?: string;
+  size?: number;
+}
+
+export interface RecordingListResponse {
+  pagination?: Pagination;
+  recordings?: RecordingInfo[];
+}
+
+export interface ReminderCreateRequest {
+  delay_seconds?: number;
+  device_id: string;
+  fire_at?: string;
+  kind?: string;
+  message?: string;
+  recurrence?: string;
+}
+
+export interface ReminderInfo {
+  created_at?: string;
+  device_id?: string;
+  fire_at?: string;
+  fired_at?: string;
+  id?: string;
+  kind?: string;
+  message?: string;
+  recurrence?: string;
+  status?: string;
+  updated_at?: string;
+}
+
+export interface ReminderListResponse {
+  pagination?: Pagination;
+  reminders?: ReminderInfo[];
+}
+
+export interface ReminderUpdateRequest {
+  fire_at?: string;
+  message?: string;
+  /** 传空字符串表示取消重复 */
+  recurrence?: string;
+}
+
+export interface ReplayDiffSegment {
+  op?: string;
+  text?: string;
+}
+
+export interface ReplayOptions {
+  /** 回放模型的每千Token成本 */
+  cost_per_1k_token?: number;
+  max_tokens?: number;
+  /** LLM 配置名称，为空时使用当前选择的 LLM */
+  model?: string;
+  /** 覆盖配置中的模型名称 */
+  model_name?: string;
+  /** 原对话模型的每千Token成本 */
+  original_cost_per_1k_token?: number;
+  /** 提示词模板名称，为空时按设备当前分配解析 */
+  prompt_template?: string;
+  prompt_version?: number;
+  /** 直接指定系统提示词，优先于模板 */
+  system_prompt?: string;
+  temperature?: number;
+}
+
+export interface ReplayRunInfo {
+  created_at?: string;
+  device_id?: string;
+  error?: string;
+  finished_at?: string;
+  id?: string;
+  model_name?: string;
+  options?: ReplayOptions;
+  session_id?: string;
+  status?: string;
+  summary?: ReplaySummary;
+  system_prompt?: string;
+  turns?: ReplayTurn[];
+}
+
+export interface ReplayRunListResponse {
+  pagination?: Pagination;
+  runs?: ReplayRunInfo[];
+}
+
+export interface ReplaySide {
+  complete_ms?: number;
+  cost?: number;
+  first_token_ms?: number;
+  response?: string;
+  tokens?: number;
+  tokens_estimated?: boolean;
+}
+
+export interface ReplaySideSummary {
+  avg_complete_ms?: number;
+  avg_first_token_ms?: number;
+  cost?: number;
+  tokens?: number;
+}
+
+export interface ReplayStartRequest {
+  /** 回放模型的每千Token成本 */
+  cost_per_1k_token?: number;
+  max_tokens?: number;
+  /** LLM 配置名称，为空时使用当前选择的 LLM */
+  model?: string;
+  /** 覆盖配置中的模型名称 */
+  model_name?: string;
+  /** 原对话模型的每千Token成本 */
+  original_cost_per_1k_token?: number;
+  /** 提示词模板名称，为空时按设备当前分配解析 */
+  prompt_template?: string;
+  prompt_version?: number;
+  session_id: string;
+  /** 直接指定系统提示词，优先于模板 */
+  system_prompt?: string;
+  temperature?: number;
+}
+
+export interface ReplaySummary {
+  avg_similarity?: number;
+  original?: ReplaySideSummary;
+  replay?: ReplaySideSummary;
+  skipped?: number;
+  succeeded?: number;
+  turns?: number;
+}
+
+export interface ReplayTurn {
+  diff?: ReplayDiffSegment[];
+  error?: string;
+  input?: string;
+  original?: ReplaySide;
+  redacted?: boolean;
+  replay?: ReplaySide;
+  round?: number;
+  similarity?: number;
+}
+
+export interface RoutineAction {
+  /** weather 为地点，news 为新闻分类，say 为播报文本，workflow 为工作流ID */
+  param?: string;
+  type: string;
+}
+
+export interface RoutineCreateRequest {
+  actions: RoutineAction[];
+  device_id: string;
+  /** 默认启用 */
+  enabled?: boolean;
+  name: string;
+  /** 唤醒短语 */
+  phrase?: string;
+  /** 五段式 cron 表达式（分 时 日 月 周），如 "0 7 * * 1-5" */
+  schedule?: string;
+}
+
+export interface RoutineInfo {
+  actions?: RoutineAction[];
+  created_at?: string;
+  /** 口语描述，如“每天7点整播报天气和新闻” */
+  description?: string;
+  device_id?: string;
+  enabled?: boolean;
+  id?: string;
+  last_run_at?: string;
+  /** ok / partial / failed / missed */
+  last_status?: string;
+  name?: string;
+  next_run_at?: string;
+  phrase?: string;
+  schedule?: string;
+  updated_at?: string;
+}
+
+export interface RoutineRunResponse {
+  /** 推送给设备的播报文本 */
+  reply?: string;
+}
+
+export interface RoutineUpdateRequest {
+  actions?: RoutineAction[];
+  enabled?: boolean;
+  name?: string;
+  /** 传空字符串表示取消唤醒短语 */
+  phrase?: string;
+  /** 传空字符串表示取消定时触发 */
+  schedule?: string;
+}
+
+export interface SchedulerStats {
+  /** 各类别运行中的节点数 */
+  classes?: Record<string, number>;
+  /** 各执行运行中的节点数 */
+  executions?: Record<string, number>;
+  max_workers?: number;
+  queued?: number;
+  running?: number;
+}
+
+export interface Schema {
+  properties?: Record<string, Property>;
+  required?: string[];
+  /** object, string, number, array, boolean */
+  type?: string;
+}
+
+export interface ScriptCreateRequest {
+  /** 版本说明 */
+  comment?: string;
+  description?: string;
+  name: string;
+  /** Lua 源码 */
+  source: string;
+}
+
+export interface ScriptInfo {
+  created_at?: string;
+  description?: string;
+  id?: string;
+  language?: string;
+  name?: string;
+  source?: string;
+  updated_at?: string;
+  version?: number;
+}
+
+export interface ScriptRollbackRequest {
+  version: number;
+}
+
+export interface ScriptTestRequest {
+  inputs?: Record<string, unknown>;
+  /** 如 5s，最长 1m */
+  timeout?: string;
+  /** 缺省为当前版本 */
+  version?: number;
+}
+
+export interface ScriptTestResult {
+  logs?: string[];
+  outputs?: Record<string, unknown>;
+}
+
+export interface ScriptUpdateRequest {
+  comment?: string;
+  description?: string;
+  name?: string;
+  source?: string;
+}
+
+export interface ScriptVersionInfo {
+  comment?: string;
+  created_at?: string;
+  script_id?: string;
+  source?: string;
+  version?: number;
+}
+
+export interface SelfTestReport {
+  duration_ms?: number;
+  /** 没有失败项 */
+  passed?: boolean;
+  results?: SelfTestResult[];
+  started_at?: string;
+}
+
+export interface SelfTestResult {
+  detail?: string;
+  duration_ms?: number;
+  name?: string;
+  /** pass, warn, fail, skip */
+  status?: string;
+  /** 所属初始化步骤ID，如 storage:init-database */
+  step?: string;
+}
+
+export interface SmartHomeAlias {
+  alias?: string;
+  entity_id?: string;
+  /** 实体当前的播报名称，实体已不存在时为空 */
+  entity_name?: string;
+  /** 语音指令通过该别名匹配到实体的次数 */
+  hits?: number;
+  /** manual/learned（从语音纠正中学到） */
+  source?: string;
+  updated_at?: string;
+}
+
+export interface SmartHomeAliasRequest {
+  alias: string;
+  entity_id: string;
+}
+
+export interface SmartHomeControlRequest {
+  action: string;
+  /** set_brightness/set_position 为 0-100 的百分比，set_temperature 为摄氏度 */
+  value?: number;
+}
+
+export interface SmartHomeEntity {
+  aliases?: string[];
+  available?: boolean;
+  /** 桥接名称 */
+  bridge?: string;
+  /** on_off/brightness/open_close/position/temperature/lock */
+  features?: string[];
+  /** "<桥接名称>:<设备ID>" */
+  id?: string;
+  name?: string;
+  room?: string;
+  /** on/brightness/position/temperature/current_temperature/locked 及传感器读数 */
+  state?: Record<string, unknown>;
+  /** light/switch/cover/climate/lock/fan/sensor */
+  type?: string;
+  /** 最近一次从桥接读取状态的时间 */
+  updated_at?: string;
+}
+
+export interface SpeechTextAssignRequest {
+  profile: string;
+}
+
+export interface SpeechTextAssignmentInfo {
+  device_id?: string;
+  profile?: string;
+  /** 未分配、使用 default 方案时为空 */
+  updated_at?: string;
+}
+
+export interface SpeechTextPreviewRequest {
+  /** 使用已保存的方案，与 stages 二选一 */
+  profile?: string;
+  /** 使用未保存的步骤 */
+  stages?: SpeechTextStage[];
+  text: string;
+}
+
+export interface SpeechTextPreviewResponse {
+  /** 原始文本 */
+  source?: string;
+  /** 处理后送入 TTS 的文本 */
+  text?: string;
+}
+
+export interface SpeechTextProfileInfo {
+  /** 数据库中没有 default 方案时返回的内置方案 */
+  built_in?: boolean;
+  description?: string;
+  name?: string;
+  stages?: SpeechTextStage[];
+  /** 内置方案为空 */
+  updated_at?: string;
+}
+
+export interface SpeechTextProfileListResponse {
+  assignments?: SpeechTextAssignmentInfo[];
+  profiles?: SpeechTextProfileInfo[];
+}
+
+export interface SpeechTextProfileRequest {
+  description?: string;
+  /** 创建时必填，更新时以路径为准 */
+  name?: string;
+  /** 按顺序执行 */
+  stages?: SpeechTextStage[];
+}
+
+export interface SpeechTextStage {
+  /** units 使用：zh 或 en，为空时按文本是否包含汉字判断 */
+  language?: string;
+  /** regex 使用：Go 正则表达式 */
+  pattern?: string;
+  /** regex 使用：替换文本，用 ${1} 引用分组 */
+  replacement?: string;
+  type: string;
+  /** replace 使用：原词到读法的映射，长词优先 */
+  words?: Record<string, string>;
+}
+
+export interface StageLatencyInfo {
+  avg_ms?: number;
+  count?: number;
+  max_ms?: number;
+  p50_ms?: number;
+  p90_ms?: number;
+  p95_ms?: number;
+  p99_ms?: number;
+  /** capture/asr/llm_first_token/llm_complete/tts_first_byte/playback_start/total */
+  stage?: string;
+}
+
+export interface StartupWorkflowResponse {
+  execution?: Execution;
+  workflow?: Workflow;
+}
+
+export interface TemplateParameter {
+  default?: unknown;
+  description?: string;
+  label?: string;
+  name?: string;
+  required?: boolean;
+  /** string, number, integer, boolean, array, object */
+  type?: string;
+  validation?: Validation;
+}
+
+export interface TenantCreateRequest {
+  id: string;
+  name?: string;
+  quota?: TenantQuota;
+}
+
+export interface TenantInfo {
+  created_at?: string;
+  enabled?: boolean;
+  has_token?: boolean;
+  id?: string;
+  name?: string;
+  quota?: TenantQuota;
+  updated_at?: string;
+}
+
+export interface TenantLLMConfig {
+  /** 读取时脱敏为 ******，保存时传 ****** 表示保留原值 */
+  api_key?: string;
+  base_url?: string;
+  extra?: Record<string, unknown>;
+  max_tokens?: number;
+  model_name?: string;
+  temperature?: number;
+  top_p?: number;
+  type?: string;
+}
+
+export interface TenantProviders {
+  llm?: Record<string, TenantLLMConfig>;
+  selected_llm?: string;
+  selected_tts?: string;
+  tts?: Record<string, TenantTTSConfig>;
+}
+
+export interface TenantQuota {
+  daily_requests?: number;
+  daily_tokens?: number;
+  max_devices?: number;
+}
+
+export interface TenantTTSConfig {
+  app_id?: string;
+  cluster?: string;
+  extra?: Record<string, unknown>;
+  format?: string;
+  /** 读取时脱敏为 ******，保存时传 ****** 表示保留原值 */
+  token?: string;
+  type?: string;
+  voice?: string;
+}
+
+export interface TenantTokenResponse {
+  tenant?: TenantInfo;
+  token?: string;
+}
+
+export interface TenantUpdateRequest {
+  enabled?: boolean;
+  name?: string;
+  quota?: TenantQuota;
+}
+
+export interface TenantUsageInfo {
+  day?: string;
+  devices?: number;
+  quota?: TenantQuota;
+  requests?: number;
+  tenant_id?: string;
+  tokens?: number;
+}
+
+export interface ToolPolicyAuditInfo {
+  agent?: string;
+  allowed?: boolean;
+  /** JSON */
+  arguments?: string;
+  created_at?: string;
+  device_id?: string;
+  id?: string;
+  /** tool/capability */
+  kind?: string;
+  name?: string;
+  profile?: string;
+  reason?: string;
+  rule?: string;
+  session_id?: string;
+}
+
+export interface ToolPolicyAuditListResponse {
+  pagination?: Pagination;
+  records?: ToolPolicyAuditInfo[];
+}
+
+export interface ToolPolicyCheckRequest {
+  agent?: string;
+  arguments?: Record<string, unknown>;
+  device_id?: string;
+  /** tool/capability，缺省为 tool */
+  kind?: string;
+  name: string;
+  session_id?: string;
+}
+
+export interface ToolPolicyCheckResponse {
+  allowed?: boolean;
+  /** 参与检查的策略，多个以逗号分隔 */
+  profile?: string;
+  reason?: string;
+  /** 做出决定的规则，为空表示使用策略的默认动作 */
+  rule?: string;
+}
+
+export interface Trace {
+  capability?: string;
+  duration_ms?: number;
+  error?: string;
+  provider?: string;
+  /** 核心的关联ID */
+  request_id?: string;
+  started_at?: string;
+  /** 提供者返回的请求ID，如 OpenAI 的 x-request-id */
+  upstream_request_ids?: string[];
+}
+
+export interface TranscriptEntryInfo {
+  content?: string;
+  created_at?: string;
+  id?: string;
+  latency_ms?: number;
+  redacted?: boolean;
+  role?: string;
+  round?: number;
+  tool_args?: string;
+  tool_call_id?: string;
+  tool_name?: string;
+}
+
+export interface TranslationLanguage {
+  code?: string;
+  name?: string;
+  /** 该语言配置的TTS音色 */
+  voice?: string;
+}
+
+export interface TranslationSessionInfo {
+  active?: boolean;
+  device_id?: string;
+  source?: string;
+  started_at?: string;
+  target?: string;
+}
+
+export interface TranslationStartRequest {
+  /** 源语言代码，auto 表示自动识别 */
+  source?: string;
+  /** 目标语言代码 */
+  target?: string;
+}
+
+export interface TurnLatencyInfo {
+  /** 说话结束到识别结果 */
+  asr_ms?: number;
+  /** 开始说话到说话结束 */
+  capture_ms?: number;
+  created_at?: string;
+  /** 识别结果到LLM输出结束 */
+  llm_complete_ms?: number;
+  /** 识别结果到LLM首个输出 */
+  llm_first_token_ms?: number;
+  /** 识别结果到首帧音频下发 */
+  playback_start_ms?: number;
+  round?: number;
+  /** 说话结束到首帧音频下发 */
+  total_ms?: number;
+  /** 首句送入TTS到首句音频合成完成 */
+  tts_first_byte_ms?: number;
+}
+
+export type Type = 'llm' | 'asr' | 'tts' | 'tool' | 'node' | 'translation' | 'embedding' | 'search' | 'media' | 'smart_home';
+
+export interface UIHints {
+  /** 节点面板中的分组 */
+  category?: string;
+  color?: string;
+  icon?: string;
+  /** 配置字段 -> 编辑控件（textarea、select、code 等） */
+  widgets?: Record<string, string>;
+}
+
+export interface Validation {
+  enum?: string[];
+  max?: number;
+  max_length?: number;
+  min?: number;
+  min_length?: number;
+  pattern?: string;
+}
+
+export interface WebhookAttempt {
+  at?: string;
+  duration_ms?: number;
+  error?: string;
+  /** 截取前 1KB */
+  response_body?: string;
+  /** 未收到响应时为 0 */
+  status_code?: number;
+}
+
+export interface WebhookCreateRequest {
+  enabled?: boolean;
+  /** device.offline, plugin.crashed, workflow.failed, budget.exceeded 或 * */
+  events: string[];
+  name: string;
+  /** 签名密钥，为空时自动生成 */
+  secret?: string;
+  url: string;
+}
+
+export interface WebhookDeliveryInfo {
+  attempts?: number;
+  created_at?: string;
+  delivered_at?: string;
+  endpoint_id?: string;
+  error?: string;
+  event?: string;
+  /** 同一事件发往各端点及重试时相同 */
+  event_id?: string;
+  history?: WebhookAttempt[];
+  id?: string;
+  next_attempt_at?: string;
+  /** 请求体 JSON */
+  payload?: string;
+  status?: string;
+  status_code?: number;
+  updated_at?: string;
+}
+
+export interface WebhookDeliveryListResponse {
+  deliveries?: WebhookDeliveryInfo[];
+  pagination?: Pagination;
+}
+
+export interface WebhookInfo {
+  created_at?: string;
+  enabled?: boolean;
+  events?: string[];
+  id?: string;
+  name?: string;
+  secret?: string;
+  updated_at?: string;
+  url?: string;
+}
+
+export interface WebhookUpdateRequest {
+  enabled?: boolean;
+  events?: string[];
+  name?: string;
+  /** 传 ****** 表示保留原值 */
+  secret?: string;
+  url?: string;
+}
+
+export interface Workflow {
+  config?: WorkflowConfig;
+  created_at?: string;
+  description?: string;
+  edges?: Edge[];
+  id?: string;
+  name?: string;
+  nodes?: Node[];
+  updated_at?: string;
+  version?: string;
+}
+
+export interface WorkflowConfig {
+  /** 启用日志 */
+  enable_log?: boolean;
+  /** 最大重试次数 */
+  max_retries?: number;
+  /** 并行执行限制 */
+  parallel_limit?: number;
+  /** 执行超时时间 */
+  timeout?: Duration;
+  /** 全局变量 */
+  variables?: Record<string, unknown>;
+}
+
+export interface WorkflowTemplate {
+  builtin?: boolean;
+  category?: string;
+  description?: string;
+  id?: string;
+  name?: string;
+  parameters?: TemplateParameter[];
+  tags?: string[];
+  workflow?: Workflow;
+}
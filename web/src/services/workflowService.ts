@@ -1,14 +1,8 @@
 import axios from 'axios';
+import { apiClient, type Definition } from './apiClient.gen';
 
-export interface Capability {
-  id: string;
-  type: string;
-  name: string;
-  description: string;
-  input_schema: any;
-  output_schema: any;
-  config_schema: any;
-}
+// 能力定义，类型由 OpenAPI 规范生成（make api-clients）
+export type Capability = Definition;
 
 export interface WorkflowNode {
   id: string;
@@ -41,8 +35,7 @@ const API_BASE_URL = '/api/v1/workflow';
 
 export const workflowService = {
   getCapabilities: async (): Promise<Capability[]> => {
-    const response = await axios.get(`${API_BASE_URL}/capabilities`);
-    return response.data.data;
+    return apiClient.getWorkflowCapabilities();
   },
 
   getCurrentWorkflow: async (): Promise<Workflow> => {